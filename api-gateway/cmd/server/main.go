@@ -3,41 +3,240 @@ package main
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"flag"
-	"log"
+	"fmt"
+	"log/slog"
 	"net/http"
 	"os"
 	"os/signal"
+	"strconv"
+	"strings"
 	"syscall"
 	"time"
 
+	"github.com/learnbot/api-gateway/internal/digest"
 	"github.com/learnbot/api-gateway/internal/handler"
+	"github.com/learnbot/api-gateway/internal/mail"
 	"github.com/learnbot/api-gateway/internal/middleware"
+	"github.com/learnbot/api-gateway/internal/suggest"
+	"github.com/learnbot/api-gateway/internal/tasks"
+	"github.com/learnbot/database/encryption"
+	"github.com/learnbot/shared/healthcheck"
+	"github.com/learnbot/shared/idempotency"
+	"github.com/learnbot/shared/logging"
+	"github.com/learnbot/shared/metrics"
+	"github.com/learnbot/shared/openapi"
+	"github.com/learnbot/shared/validation"
 )
 
 func main() {
 	addr := flag.String("addr", ":8090", "HTTP server address")
 	jwtSecret := flag.String("jwt-secret", os.Getenv("JWT_SECRET"), "JWT signing secret")
+	jobAggregatorURL := flag.String("job-aggregator-url",
+		getEnv("JOB_AGGREGATOR_URL", "http://localhost:8081"), "job aggregator base URL")
+	learningResourcesURL := flag.String("learning-resources-url",
+		getEnv("LEARNING_RESOURCES_URL", "http://localhost:8082"), "learning resources base URL")
+	resumeParserURL := flag.String("resume-parser-url",
+		getEnv("RESUME_PARSER_URL", "http://localhost:8080"), "resume parser base URL")
+	jobAggregatorTimeout := flag.Duration("job-aggregator-timeout",
+		getEnvDuration("JOB_AGGREGATOR_TIMEOUT", handler.DefaultBackendTimeout), "timeout for requests proxied to the job aggregator")
+	learningResourcesTimeout := flag.Duration("learning-resources-timeout",
+		getEnvDuration("LEARNING_RESOURCES_TIMEOUT", handler.DefaultBackendTimeout), "timeout for requests proxied to learning resources")
+	resumeParserTimeout := flag.Duration("resume-parser-timeout",
+		getEnvDuration("RESUME_PARSER_TIMEOUT", 25*time.Second), "timeout for requests proxied to the resume parser")
+	digestEnabled := flag.Bool("digest", getEnv("DIGEST_ENABLED", "true") == "true",
+		"send the weekly digest email to opted-in users on Mondays")
+	trustedProxies := flag.String("trusted-proxies", os.Getenv("TRUSTED_PROXIES"),
+		"comma-separated IPs or CIDR ranges of proxies whose X-Forwarded-For is trusted")
+	userStoreKind := flag.String("user-store", getEnv("USER_STORE", "memory"),
+		`where user accounts are kept: "memory" or "postgres"`)
+	dsn := flag.String("dsn", os.Getenv("DATABASE_URL"), "PostgreSQL connection string, for -user-store=postgres")
 	flag.Parse()
 
-	logger := log.New(os.Stdout, "[api-gateway] ", log.LstdFlags|log.Lshortfile)
+	slogger := logging.New("api-gateway", os.Stdout, logging.ConfigFromEnv())
+	logger := logging.StdLogger(slogger)
 
 	// JWT configuration.
 	jwtCfg := middleware.DefaultJWTConfig(*jwtSecret)
 
-	// Rate limiter: 10 requests/second, burst of 30.
-	rateLimiter := middleware.NewRateLimiter(10, 30)
+	// Rate limiter: per user or client IP, stricter on auth endpoints.
+	rateCfg := middleware.DefaultRateLimitConfig(jwtCfg)
+	if *trustedProxies != "" {
+		rateCfg.TrustedProxies = strings.Split(*trustedProxies, ",")
+	}
+	rateLimiter, err := middleware.NewRateLimiterWithConfig(rateCfg)
+	if err != nil {
+		logger.Fatalf("invalid rate limit config: %v", err)
+	}
+
+	// Backend services. The versioned /api/v1 route groups are proxied to
+	// them behind a per-backend circuit breaker; /health reports their status.
+	backends := []handler.Backend{
+		{Name: "job-aggregator", URL: *jobAggregatorURL, Timeout: *jobAggregatorTimeout,
+			HealthPath: "/admin/health", Breaker: breakerConfigFromEnv("JOB_AGGREGATOR")},
+		{Name: "learning-resources", URL: *learningResourcesURL, Timeout: *learningResourcesTimeout,
+			HealthPath: "/health", Breaker: breakerConfigFromEnv("LEARNING_RESOURCES")},
+		{Name: "resume-parser", URL: *resumeParserURL, Timeout: *resumeParserTimeout,
+			HealthPath: "/api/v1/health", Breaker: breakerConfigFromEnv("RESUME_PARSER")},
+	}
+	proxyHandler, err := handler.NewProxyHandler(backends, handler.DefaultProxyRoutes(), slogger)
+	if err != nil {
+		logger.Fatalf("invalid backend config: %v", err)
+	}
+	healthHandler := handler.NewHealthHandler(backends, handler.DefaultHealthTimeout)
+
+	// Unknown request fields are rejected unless
+	// VALIDATION_ALLOW_UNKNOWN_FIELDS=true.
+	handler.SetValidationConfig(validation.ConfigFromEnv())
+
+	// User accounts are kept in memory unless USER_STORE=postgres, which
+	// uses the users table of the database migrated by learning-resources.
+	users, usersDB, err := userStoreFromFlags(*userStoreKind, *dsn)
+	if err != nil {
+		logger.Fatalf("invalid user store: %v", err)
+	}
+	if usersDB != nil {
+		defer usersDB.Close()
+	}
 
 	// Create handlers.
 	authHandler := handler.NewAuthHandler(jwtCfg)
+	authHandler.SetUserStore(users)
+	authHandler.SetLoginThrottle(middleware.NewLoginThrottle(lockoutConfigFromEnv(), nil, slogger))
+	mailSender := mailSenderFromEnv(slogger)
+	authHandler.SetPasswordReset(handler.PasswordResetConfig{
+		Sender: mailSender,
+		URL:    os.Getenv("PASSWORD_RESET_URL"),
+		Logger: slogger,
+	})
+	emailVerifier := handler.NewEmailVerifier(jwtCfg, handler.EmailVerificationConfig{
+		Sender: mailSender,
+		URL:    os.Getenv("EMAIL_VERIFICATION_URL"),
+		Logger: slogger,
+	})
+	authHandler.SetEmailVerifier(emailVerifier)
 	profileHandler := handler.NewProfileHandler(jwtCfg)
+	profileHandler.SetUserStore(users)
+	profileHandler.SetEmailVerifier(emailVerifier)
+	digestHandler := handler.NewDigestHandler(jwtCfg)
+	digestHandler.SetUserStore(users)
 	resumeHandler := handler.NewResumeHandler()
 	jobsHandler := handler.NewJobsHandler()
 	analysisHandler := handler.NewAnalysisHandler()
+	readinessStore := handler.NewMemoryReadinessStore(0)
+	analysisHandler.SetReadinessStore(readinessStore)
 	resourcesHandler := handler.NewResourcesHandler()
+	rolesHandler := handler.NewRolesHandler()
+	rolesHandler.SetUserStore(users)
+	// API keys survive restarts in the users database; without one they are
+	// kept in memory.
+	var apiKeyStore middleware.APIKeyStore = middleware.NewMemoryAPIKeyStore()
+	if usersDB != nil {
+		apiKeyStore = handler.NewPostgresAPIKeyStore(usersDB)
+	}
+	apiKeysHandler := handler.NewAPIKeysHandler(apiKeyStore, slogger)
+
+	// The gateway calls the backends' admin routes with ADMIN_API_KEY.
+	adminAPIKey := os.Getenv("ADMIN_API_KEY")
+	dataQualityHandler := handler.NewDataQualityHandler([]handler.DataQualityService{
+		{Name: "job-aggregator", URL: *jobAggregatorURL + "/admin/data-quality"},
+		{Name: "learning-resources", URL: *learningResourcesURL + "/admin/data-quality"},
+		{Name: "resume-parser", URL: *resumeParserURL + "/admin/data-quality"},
+	}, adminAPIKey, handler.DefaultDataQualityTimeout)
+
+	// Retried mutating requests with an Idempotency-Key get the first
+	// response again, for IDEMPOTENCY_KEY_TTL (default 24h) per caller.
+	workerCtx, stopWorkers := context.WithCancel(context.Background())
+	defer stopWorkers()
+	idempotent := idempotency.New(idempotency.ConfigFromEnv(), nil, slogger)
+	idempotent.Start(workerCtx)
+
+	// Public resource reads are served from an in-memory response cache;
+	// admins purge it with POST /api/admin/cache/purge.
+	responseCache := middleware.NewResponseCache(responseCacheConfigFromEnv())
+	cacheHandler := handler.NewCacheHandler(responseCache, slogger)
+
+	backendClient := &http.Client{Timeout: handler.DefaultBackendTimeout}
+
+	// Skill suggestions are computed from the skills of uploaded resumes,
+	// or from the last 90 days of scraped jobs while there are fewer than
+	// 50 resumes. They are computed once at startup, then again whenever an
+	// admin calls POST /api/admin/skill-suggestions/recompute.
+	suggestions := suggest.NewEngine(handler.ResumeCorpus(),
+		suggest.NewJobCorpus(digest.NewAggregatorJobs(*jobAggregatorURL, backendClient), 0), suggest.EngineConfig{})
+	suggestions.Start(workerCtx, func(err error) {
+		if err != nil {
+			slogger.Error("skill suggestion computation failed", "error", err)
+		}
+	})
+	skillSuggestionsHandler := handler.NewSkillSuggestionsHandler(suggestions, slogger)
+
+	// POST /api/v1/me/saved-jobs/rescore stores fresh match scores on the
+	// user's saved jobs in the job aggregator, on two background workers.
+	rescoreQueue := tasks.NewQueue(tasks.DefaultConfig())
+	rescoreQueue.Start(workerCtx)
+	savedJobs := handler.NewAggregatorSavedJobs(*jobAggregatorURL, backendClient)
+	rescoreHandler := handler.NewRescoreHandler(savedJobs, rescoreQueue, slogger)
 
-	// Auth middleware factory.
-	authMiddleware := middleware.RequireAuth(jwtCfg)
+	// GET /api/v1/me/export streams the user's data as a zip, at most once
+	// an hour per user.
+	exportHandler := handler.NewExportHandler([]handler.ExportSource{
+		handler.ProfileExport(users),
+		handler.ResumeExport(),
+		handler.LearningProgressExport(*learningResourcesURL, backendClient),
+		handler.SavedJobsExport(savedJobs),
+		handler.ReadinessExport(readinessStore),
+	}, slogger)
+
+	// DELETE /api/v1/me removes the account at once, then erases the
+	// user's data in each backend with ADMIN_API_KEY, retrying failures
+	// until every backend has confirmed. Admins list the erasures still
+	// pending with GET /api/admin/account-deletions.
+	var deletionStore handler.AccountDeletionStore = handler.NewMemoryAccountDeletionStore()
+	if usersDB != nil {
+		deletionStore = handler.NewPostgresAccountDeletionStore(usersDB)
+	}
+	deleter := handler.NewAccountDeleter(deletionStore, []handler.ErasureTarget{
+		handler.BackendErasure("learning-resources", *learningResourcesURL, adminAPIKey, backendClient),
+		handler.BackendErasure("job-aggregator", *jobAggregatorURL, adminAPIKey, backendClient),
+	}, slogger)
+	deleter.Start(workerCtx)
+	deletionHandler := handler.NewAccountDeletionHandler(deleter, authHandler, slogger)
+	deletionHandler.SetUserStore(users)
+	deletionHandler.SetReadinessStore(readinessStore)
+
+	// Weekly digest: every Monday at DIGEST_HOUR (default 8) UTC, opted-in
+	// users with a verified email get the week's matching jobs and their
+	// learning progress. DIGEST_UNSUBSCRIBE_URL is the public URL of
+	// GET /api/users/digest/unsubscribe.
+	if *digestEnabled {
+		digestCfg := digest.DefaultMailerConfig()
+		digestCfg.UnsubscribeURL = os.Getenv("DIGEST_UNSUBSCRIBE_URL")
+		digestCfg.Logger = slogger
+		if n, err := strconv.Atoi(os.Getenv("DIGEST_HOUR")); err == nil && n >= 0 && n < 24 {
+			digestCfg.Hour = n
+		}
+		digest.NewMailer(
+			digest.NewGenerator(digest.NewLearningProgress(*learningResourcesURL, backendClient)),
+			digest.NewAggregatorJobs(*jobAggregatorURL, backendClient),
+			handler.DigestSubscribers{Users: users}, mailSender, jwtCfg, digestCfg,
+		).Start(workerCtx)
+	}
+
+	// Auth middleware factory. The jobs and proxied routes also accept API
+	// keys, each rate limited on its own and checked for scopes by the
+	// handlers.
+	authMiddleware := middleware.Chain(middleware.RequireAuth(jwtCfg), middleware.Idempotent(idempotent))
+	machineAuth := middleware.Chain(
+		middleware.NewAPIKeyAuth(apiKeyStore, middleware.RateLimit{}).RequireAuthOrAPIKey(jwtCfg),
+		middleware.Idempotent(idempotent),
+	)
+
+	// Route groups listed in REQUIRE_VERIFIED_EMAIL (resume, jobs, analysis,
+	// data-quality, proxy) also reject users whose email is not verified.
+	verification := middleware.ParseVerificationPolicy(os.Getenv("REQUIRE_VERIFIED_EMAIL"))
 
 	// Build mux.
 	mux := http.NewServeMux()
@@ -45,22 +244,62 @@ func main() {
 	// Register routes.
 	authHandler.RegisterRoutes(mux)
 	profileHandler.RegisterRoutes(mux, authMiddleware)
-	resumeHandler.RegisterRoutes(mux, authMiddleware)
-	jobsHandler.RegisterRoutes(mux, authMiddleware)
-	analysisHandler.RegisterRoutes(mux, authMiddleware)
-	resourcesHandler.RegisterRoutes(mux)
-
-	// Health check.
-	mux.HandleFunc("/health", func(w http.ResponseWriter, r *http.Request) {
-		w.Header().Set("Content-Type", "application/json")
-		w.WriteHeader(http.StatusOK)
-		w.Write([]byte(`{"status":"ok","service":"api-gateway","version":"1.0.0"}`))
-	})
+	digestHandler.RegisterRoutes(mux)
+	resumeHandler.RegisterRoutes(mux, verification.Wrap("resume", authMiddleware))
+	jobsHandler.RegisterRoutes(mux, verification.Wrap("jobs", machineAuth))
+	analysisHandler.RegisterRoutes(mux, verification.Wrap("analysis", authMiddleware))
+	resourcesHandler.RegisterRoutes(mux, responseCache.Middleware)
+	cacheHandler.RegisterRoutes(mux, authMiddleware)
+	dataQualityHandler.RegisterRoutes(mux, verification.Wrap("data-quality", authMiddleware))
+	rolesHandler.RegisterRoutes(mux, authMiddleware)
+	apiKeysHandler.RegisterRoutes(mux, authMiddleware)
+	skillSuggestionsHandler.RegisterRoutes(mux, middleware.OptionalAuth(jwtCfg), authMiddleware)
+	rescoreHandler.RegisterRoutes(mux, verification.Wrap("proxy", authMiddleware))
+	exportHandler.RegisterRoutes(mux, authMiddleware)
+	deletionHandler.RegisterRoutes(mux, authMiddleware)
+	proxyHandler.RegisterRoutes(mux, middleware.Chain(verification.Wrap("proxy", machineAuth), responseCache.Middleware))
+
+	// Prometheus metrics and health check.
+	mux.Handle("/metrics", metrics.Handler())
+	healthHandler.RegisterRoutes(mux)
+
+	// /livez reports the process is up; /readyz also pings the user
+	// database, if any, and reaches each backend. A backend being down only
+	// degrades readiness, as the gateway keeps serving the other routes.
+	health := healthcheck.New("api-gateway", handler.DefaultHealthTimeout)
+	if usersDB != nil {
+		health.Register(healthcheck.Check{Name: "database", Required: true, Probe: healthcheck.Ping(usersDB)})
+	}
+	probeClient := &http.Client{Transport: logging.Transport(nil)}
+	for _, b := range backends {
+		health.Register(healthcheck.Check{Name: b.Name,
+			Probe: healthcheck.HTTP(probeClient, strings.TrimSuffix(b.URL, "/")+b.HealthPath)})
+	}
+	health.RegisterRoutes(mux)
+
+	// OpenAPI document and Swagger UI. Proxied routes are documented as
+	// pass-through groups; each backend serves its own document.
+	spec := openapi.New("api-gateway", "1.0.0")
+	spec.SetDescription("Public entry point of LearnBot: authentication, profiles and the " +
+		"aggregated job, analysis and learning endpoints.")
+	spec.SetErrorBody(handler.ErrorBody)
+	for _, h := range []interface{ DescribeRoutes(*openapi.Spec) }{
+		authHandler, profileHandler, digestHandler, resumeHandler, jobsHandler, analysisHandler,
+		resourcesHandler, cacheHandler, dataQualityHandler, rolesHandler, apiKeysHandler, skillSuggestionsHandler,
+		rescoreHandler, exportHandler, deletionHandler, proxyHandler, healthHandler, health,
+	} {
+		h.DescribeRoutes(spec)
+	}
+	spec.Route("/metrics", openapi.Metrics)
+	spec.Register(mux)
 
-	// Apply global middleware chain.
+	// Apply global middleware chain. Request IDs are assigned first so that
+	// every log line and downstream call for a request carries the same ID.
 	globalChain := middleware.Chain(
-		middleware.Recovery(logger),
-		middleware.Logger(logger),
+		logging.RequestIDMiddleware,
+		metrics.Middleware(mux),
+		middleware.Recovery(slogger),
+		middleware.Logger(slogger),
 		middleware.CORS([]string{"*"}),
 		rateLimiter.Middleware,
 	)
@@ -95,3 +334,131 @@ func main() {
 	}
 	logger.Println("server stopped")
 }
+
+// getEnv returns the value of the environment variable key, or fallback if unset.
+func getEnv(key, fallback string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return fallback
+}
+
+// userStoreFromFlags returns the user store named by kind and, for the
+// postgres store, its database connection to dsn, which the caller closes.
+func userStoreFromFlags(kind, dsn string) (handler.UserStore, *sql.DB, error) {
+	switch kind {
+	case "memory":
+		return handler.NewMemoryUserStore(), nil, nil
+	case "postgres":
+		if dsn == "" {
+			return nil, nil, errors.New("DATABASE_URL environment variable or -dsn flag is required for the postgres store")
+		}
+		// Profile PII is encrypted at rest; the gateway does not start
+		// without the master keys.
+		keys, err := encryption.KeyringFromEnv()
+		if err != nil {
+			return nil, nil, fmt.Errorf("load master keys from %s: %w", encryption.EnvMasterKeys, err)
+		}
+		// Failed queries are counted in /metrics.
+		db, err := metrics.OpenDB("postgres", dsn)
+		if err != nil {
+			return nil, nil, fmt.Errorf("open database: %w", err)
+		}
+		db.SetMaxOpenConns(25)
+		db.SetMaxIdleConns(5)
+		db.SetConnMaxLifetime(5 * time.Minute)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := db.PingContext(ctx); err != nil {
+			db.Close()
+			return nil, nil, fmt.Errorf("connect to database: %w", err)
+		}
+		return handler.NewPostgresUserStore(db, keys), db, nil
+	default:
+		return nil, nil, fmt.Errorf("unknown user store %q", kind)
+	}
+}
+
+// breakerConfigFromEnv reads a backend's circuit breaker settings from
+// <prefix>_BREAKER_WINDOW, _MIN_REQUESTS, _FAILURE_RATE, _COOLDOWN and
+// _HALF_OPEN_PROBES. Unset values fall back to the defaults.
+func breakerConfigFromEnv(prefix string) middleware.CircuitBreakerConfig {
+	def := middleware.DefaultCircuitBreakerConfig()
+	cfg := middleware.CircuitBreakerConfig{
+		Window:         getEnvDuration(prefix+"_BREAKER_WINDOW", def.Window),
+		MinRequests:    def.MinRequests,
+		FailureRate:    def.FailureRate,
+		Cooldown:       getEnvDuration(prefix+"_BREAKER_COOLDOWN", def.Cooldown),
+		HalfOpenProbes: def.HalfOpenProbes,
+	}
+	if n, err := strconv.Atoi(os.Getenv(prefix + "_BREAKER_MIN_REQUESTS")); err == nil {
+		cfg.MinRequests = n
+	}
+	if f, err := strconv.ParseFloat(os.Getenv(prefix+"_BREAKER_FAILURE_RATE"), 64); err == nil {
+		cfg.FailureRate = f
+	}
+	if n, err := strconv.Atoi(os.Getenv(prefix + "_BREAKER_HALF_OPEN_PROBES")); err == nil {
+		cfg.HalfOpenProbes = n
+	}
+	return cfg
+}
+
+// responseCacheConfigFromEnv reads the response cache size from
+// RESPONSE_CACHE_MAX_ENTRIES, falling back to
+// middleware.DefaultResponseCacheConfig. RESPONSE_CACHE_ENABLED=false
+// disables the cache.
+func responseCacheConfigFromEnv() middleware.ResponseCacheConfig {
+	cfg := middleware.DefaultResponseCacheConfig()
+	if os.Getenv("RESPONSE_CACHE_ENABLED") == "false" {
+		cfg.Routes = nil
+	}
+	if n, err := strconv.Atoi(os.Getenv("RESPONSE_CACHE_MAX_ENTRIES")); err == nil && n > 0 {
+		cfg.MaxEntries = n
+	}
+	return cfg
+}
+
+// lockoutConfigFromEnv reads the login lockout settings from
+// LOGIN_MAX_FAILURES, LOGIN_FAILURE_WINDOW and LOGIN_LOCKOUT_COOLDOWN,
+// falling back to middleware.DefaultLockoutConfig.
+func lockoutConfigFromEnv() middleware.LockoutConfig {
+	def := middleware.DefaultLockoutConfig()
+	cfg := middleware.LockoutConfig{
+		MaxFailures: def.MaxFailures,
+		Window:      getEnvDuration("LOGIN_FAILURE_WINDOW", def.Window),
+		Cooldown:    getEnvDuration("LOGIN_LOCKOUT_COOLDOWN", def.Cooldown),
+	}
+	if n, err := strconv.Atoi(os.Getenv("LOGIN_MAX_FAILURES")); err == nil {
+		cfg.MaxFailures = n
+	}
+	return cfg
+}
+
+// mailSenderFromEnv returns an SMTP sender configured by SMTP_ADDR,
+// SMTP_FROM, SMTP_USERNAME and SMTP_PASSWORD, or a log-only sender if
+// SMTP_ADDR is unset.
+func mailSenderFromEnv(logger *slog.Logger) mail.Sender {
+	addr := os.Getenv("SMTP_ADDR")
+	if addr == "" {
+		logger.Warn("SMTP_ADDR not set; emails are logged instead of sent")
+		return mail.NewLogSender(logger)
+	}
+	return mail.NewSMTPSender(mail.SMTPConfig{
+		Addr:     addr,
+		From:     getEnv("SMTP_FROM", "LearnBot <no-reply@learnbot.local>"),
+		Username: os.Getenv("SMTP_USERNAME"),
+		Password: os.Getenv("SMTP_PASSWORD"),
+	})
+}
+
+// getEnvDuration parses the environment variable key as a duration, or
+// returns fallback if it is unset or invalid.
+func getEnvDuration(key string, fallback time.Duration) time.Duration {
+	if v := os.Getenv(key); v != "" {
+		if d, err := time.ParseDuration(v); err == nil {
+			return d
+		}
+	}
+	return fallback
+}
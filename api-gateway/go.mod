@@ -4,9 +4,18 @@ go 1.24.0
 
 require (
 	github.com/golang-jwt/jwt/v5 v5.2.2
+	github.com/google/uuid v1.6.0
+	github.com/learnbot/database v0.0.0
 	github.com/learnbot/resume-parser v0.0.0
+	github.com/learnbot/shared v0.0.0
+	github.com/lib/pq v1.11.2
+	golang.org/x/crypto v0.31.0
 )
 
 require github.com/dslipak/pdf v0.0.2 // indirect
 
-replace github.com/learnbot/resume-parser => ../resume-parser
+replace (
+	github.com/learnbot/database => ../database
+	github.com/learnbot/resume-parser => ../resume-parser
+	github.com/learnbot/shared => ../shared
+)
@@ -0,0 +1,262 @@
+// Package digest assembles the weekly digest email: the week's new jobs that
+// best match a user's profile skills, the learning resources they are part
+// way through and one suggested next resource from their skill gaps. A
+// Mailer sends it to every opted-in user on Monday mornings.
+package digest
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/learnbot/resume-parser/pkg/recommend"
+	"github.com/learnbot/resume-parser/pkg/scoring"
+)
+
+// List names the weekly digest in unsubscribe tokens.
+const List = "weekly_digest"
+
+const (
+	// DefaultLookback is how far back a digest looks for new jobs.
+	DefaultLookback = 7 * 24 * time.Hour
+
+	// DefaultMaxJobs is the number of jobs in a digest.
+	DefaultMaxJobs = 5
+
+	// DefaultMaxInProgress is the number of in-progress resources in a
+	// digest.
+	DefaultMaxInProgress = 3
+)
+
+// Subscriber is a user who opted in to the digest.
+type Subscriber struct {
+	UserID string
+	Email  string
+	Name   string
+
+	// Profile holds the skills that jobs are ranked against.
+	Profile scoring.CandidateProfile
+}
+
+// Job is a newly posted job.
+type Job struct {
+	ID           string
+	Title        string
+	Company      string
+	URL          string
+	LocationType string
+	PostedAt     time.Time
+
+	// Requirements are what the job is scored on.
+	Requirements scoring.JobRequirements
+}
+
+// JobMatch is a job ranked for a subscriber.
+type JobMatch struct {
+	Job
+
+	// Score is the overall match score [0, 100] from scoring.Calculate.
+	Score float64
+
+	// MatchedSkills and MissingSkills are the job's required skills the
+	// subscriber has and lacks.
+	MatchedSkills []string
+	MissingSkills []string
+}
+
+// Resource is a learning resource in a digest.
+type Resource struct {
+	ID       string
+	Title    string
+	URL      string
+	Provider string
+
+	// Progress is the completion percentage of an in-progress resource.
+	Progress int
+
+	// Skill is the skill gap a suggested resource closes.
+	Skill string
+}
+
+// Edition is the input shared by all digests of one send: the new jobs
+// posted in [Since, Until).
+type Edition struct {
+	Since time.Time
+	Until time.Time
+	Jobs  []Job
+}
+
+// Digest is the content of one subscriber's email.
+type Digest struct {
+	Subscriber Subscriber
+	Since      time.Time
+	Until      time.Time
+
+	// Jobs are the best matching new jobs, best first.
+	Jobs []JobMatch
+
+	// InProgress are the resources the subscriber is part way through,
+	// most progressed first.
+	InProgress []Resource
+
+	// Suggestion is the next resource to pick up, or nil if the jobs show
+	// no gap the catalog covers.
+	Suggestion *Resource
+
+	// UnsubscribeURL is the link that opts the subscriber out.
+	UnsubscribeURL string
+}
+
+// Empty reports whether the digest has nothing to tell, in which case it is
+// not sent.
+func (d *Digest) Empty() bool {
+	return len(d.Jobs) == 0 && len(d.InProgress) == 0 && d.Suggestion == nil
+}
+
+// JobSource lists the jobs posted since a time.
+type JobSource interface {
+	NewJobs(ctx context.Context, since time.Time) ([]Job, error)
+}
+
+// ProgressSource lists the resources a user has in progress.
+type ProgressSource interface {
+	InProgress(ctx context.Context, userID string) ([]Resource, error)
+}
+
+// Generator assembles digests.
+type Generator struct {
+	progress ProgressSource
+	catalog  []recommend.ResourceEntry
+
+	// MaxJobs and MaxInProgress cap the jobs and in-progress resources of
+	// a digest; they default to DefaultMaxJobs and DefaultMaxInProgress.
+	MaxJobs       int
+	MaxInProgress int
+}
+
+// NewGenerator creates a Generator that reads in-progress resources from
+// progress and suggests resources from the recommendation catalog.
+func NewGenerator(progress ProgressSource) *Generator {
+	return &Generator{
+		progress:      progress,
+		catalog:       recommend.GetCatalog(),
+		MaxJobs:       DefaultMaxJobs,
+		MaxInProgress: DefaultMaxInProgress,
+	}
+}
+
+// Generate assembles the digest of sub for the edition. Jobs are ranked by
+// scoring.Calculate against the subscriber's profile; only jobs matching at
+// least one of their skills are included.
+func (g *Generator) Generate(ctx context.Context, sub Subscriber, ed Edition) (*Digest, error) {
+	d := &Digest{Subscriber: sub, Since: ed.Since, Until: ed.Until}
+	d.Jobs = g.rankJobs(sub.Profile, ed)
+
+	inProgress, err := g.progress.InProgress(ctx, sub.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("in-progress resources: %w", err)
+	}
+	sort.SliceStable(inProgress, func(i, j int) bool {
+		return inProgress[i].Progress > inProgress[j].Progress
+	})
+	d.Suggestion = g.suggest(d.Jobs, inProgress)
+	if len(inProgress) > g.MaxInProgress {
+		inProgress = inProgress[:g.MaxInProgress]
+	}
+	d.InProgress = inProgress
+	return d, nil
+}
+
+// rankJobs scores the edition's jobs against profile and returns the best
+// MaxJobs that match a skill, best first. Ties go to the newer job.
+func (g *Generator) rankJobs(profile scoring.CandidateProfile, ed Edition) []JobMatch {
+	var matches []JobMatch
+	for _, job := range ed.Jobs {
+		if job.PostedAt.Before(ed.Since) || !job.PostedAt.Before(ed.Until) {
+			continue
+		}
+		b := scoring.Calculate(profile, job.Requirements)
+		if len(b.MatchedRequiredSkills)+len(b.MatchedPreferredSkills) == 0 {
+			continue
+		}
+		matches = append(matches, JobMatch{
+			Job:           job,
+			Score:         b.OverallScore,
+			MatchedSkills: b.MatchedRequiredSkills,
+			MissingSkills: b.MissingRequiredSkills,
+		})
+	}
+	sort.SliceStable(matches, func(i, j int) bool {
+		a, b := matches[i], matches[j]
+		if a.Score != b.Score {
+			return a.Score > b.Score
+		}
+		if !a.PostedAt.Equal(b.PostedAt) {
+			return a.PostedAt.After(b.PostedAt)
+		}
+		return a.ID < b.ID
+	})
+	if len(matches) > g.MaxJobs {
+		matches = matches[:g.MaxJobs]
+	}
+	return matches
+}
+
+// suggest picks a catalog resource for the skill most often missing from
+// jobs, skipping resources already in progress. Resources teaching the skill
+// as their primary skill come first, then the best rated.
+func (g *Generator) suggest(jobs []JobMatch, inProgress []Resource) *Resource {
+	counts := map[string]int{}
+	for _, job := range jobs {
+		for _, s := range job.MissingSkills {
+			counts[strings.ToLower(s)]++
+		}
+	}
+	gaps := make([]string, 0, len(counts))
+	for s := range counts {
+		gaps = append(gaps, s)
+	}
+	sort.Slice(gaps, func(i, j int) bool {
+		if counts[gaps[i]] != counts[gaps[j]] {
+			return counts[gaps[i]] > counts[gaps[j]]
+		}
+		return gaps[i] < gaps[j]
+	})
+
+	started := map[string]bool{}
+	for _, r := range inProgress {
+		started[strings.ToLower(r.Title)] = true
+		started[r.URL] = true
+	}
+	for _, gap := range gaps {
+		var best *recommend.ResourceEntry
+		bestPrimary := false
+		for i := range g.catalog {
+			e := &g.catalog[i]
+			primary := strings.EqualFold(e.PrimarySkill, gap)
+			if !primary && !containsFold(e.Skills, gap) || started[strings.ToLower(e.Title)] || started[e.URL] {
+				continue
+			}
+			if best == nil || primary && !bestPrimary ||
+				primary == bestPrimary && (e.Rating > best.Rating || e.Rating == best.Rating && e.Title < best.Title) {
+				best, bestPrimary = e, primary
+			}
+		}
+		if best != nil {
+			return &Resource{ID: best.ID, Title: best.Title, URL: best.URL, Provider: best.Provider, Skill: gap}
+		}
+	}
+	return nil
+}
+
+// containsFold reports whether list contains s, ignoring case.
+func containsFold(list []string, s string) bool {
+	for _, v := range list {
+		if strings.EqualFold(v, s) {
+			return true
+		}
+	}
+	return false
+}
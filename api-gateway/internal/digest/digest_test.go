@@ -0,0 +1,204 @@
+package digest
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/learnbot/resume-parser/pkg/scoring"
+)
+
+// fakeProgress is a ProgressSource backed by a map.
+type fakeProgress map[string][]Resource
+
+func (f fakeProgress) InProgress(_ context.Context, userID string) ([]Resource, error) {
+	if userID == "broken" {
+		return nil, errors.New("learning resources unavailable")
+	}
+	return append([]Resource(nil), f[userID]...), nil
+}
+
+var (
+	weekStart = time.Date(2025, 3, 3, 8, 0, 0, 0, time.UTC)
+	weekEnd   = weekStart.Add(DefaultLookback)
+)
+
+// goDeveloper knows Go and SQL but not Kubernetes.
+var goDeveloper = Subscriber{
+	UserID: "user-1",
+	Email:  "jane@example.com",
+	Name:   "Jane",
+	Profile: scoring.CandidateProfile{
+		Skills:            []scoring.CandidateSkill{{Name: "Go", Proficiency: "advanced"}, {Name: "SQL", Proficiency: "intermediate"}},
+		YearsOfExperience: 4,
+		RemotePreference:  "any",
+	},
+}
+
+func newJob(id, title string, posted time.Time, required ...string) Job {
+	return Job{
+		ID:           id,
+		Title:        title,
+		Company:      "Acme " + id,
+		URL:          "https://jobs.example.com/" + id,
+		LocationType: "remote",
+		PostedAt:     posted,
+		Requirements: scoring.JobRequirements{Title: title, RequiredSkills: required, LocationType: "remote", MinYearsExperience: 3},
+	}
+}
+
+func testEdition() Edition {
+	return Edition{Since: weekStart, Until: weekEnd, Jobs: []Job{
+		newJob("1", "Backend Engineer", weekStart.Add(24*time.Hour), "Go", "SQL"),
+		newJob("2", "Platform Engineer", weekStart.Add(48*time.Hour), "Go", "Kubernetes"),
+		newJob("3", "Frontend Engineer", weekStart.Add(72*time.Hour), "React", "TypeScript"),
+		newJob("4", "Go Developer", weekStart.Add(-time.Hour), "Go", "SQL"),
+		newJob("5", "Data Engineer", weekStart.Add(96*time.Hour), "SQL", "Kubernetes"),
+	}}
+}
+
+func TestGenerate(t *testing.T) {
+	g := NewGenerator(fakeProgress{"user-1": {
+		{ID: "r1", Title: "Docker Deep Dive", URL: "https://learn.example.com/docker", Progress: 30},
+		{ID: "r2", Title: "Effective SQL", URL: "https://learn.example.com/sql", Progress: 70},
+	}})
+	d, err := g.Generate(context.Background(), goDeveloper, testEdition())
+	if err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+
+	// The frontend job matches no skill and job 4 predates the week.
+	var ids []string
+	for _, j := range d.Jobs {
+		ids = append(ids, j.ID)
+	}
+	if len(ids) != 3 || ids[0] != "1" {
+		t.Errorf("jobs = %v, want the full match first and jobs 2 and 5 after it", ids)
+	}
+	for i := 1; i < len(d.Jobs); i++ {
+		if d.Jobs[i].Score > d.Jobs[i-1].Score {
+			t.Errorf("jobs not ranked by score: %v", d.Jobs)
+		}
+	}
+	if d.InProgress[0].ID != "r2" || d.InProgress[1].ID != "r1" {
+		t.Errorf("in progress = %+v, want the most progressed first", d.InProgress)
+	}
+	if d.Suggestion == nil || d.Suggestion.Skill != "kubernetes" || d.Suggestion.URL == "" {
+		t.Errorf("suggestion = %+v, want a kubernetes resource", d.Suggestion)
+	}
+}
+
+func TestGenerate_Limits(t *testing.T) {
+	var started []Resource
+	for _, e := range NewGenerator(nil).catalog {
+		if e.PrimarySkill == "kubernetes" {
+			started = append(started, Resource{Title: e.Title, URL: e.URL, Progress: 10})
+		}
+	}
+	g := NewGenerator(fakeProgress{"user-1": started})
+	g.MaxJobs, g.MaxInProgress = 1, 0
+
+	d, err := g.Generate(context.Background(), goDeveloper, testEdition())
+	if err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+	if len(d.Jobs) != 1 || len(d.InProgress) != 0 {
+		t.Errorf("got %d jobs and %d in progress, want 1 and 0", len(d.Jobs), len(d.InProgress))
+	}
+	// The best job lacks no skill, so nothing is suggested.
+	if d.Suggestion != nil {
+		t.Errorf("suggestion = %+v, want none", d.Suggestion)
+	}
+
+	g.MaxJobs = 3
+	d, _ = g.Generate(context.Background(), goDeveloper, testEdition())
+	if d.Suggestion == nil || d.Suggestion.Skill != "kubernetes" {
+		t.Fatalf("suggestion = %+v, want a kubernetes resource", d.Suggestion)
+	}
+	for _, r := range started {
+		if d.Suggestion.URL == r.URL {
+			t.Errorf("suggested %q, which is already in progress", r.Title)
+		}
+	}
+}
+
+func TestGenerate_ProgressError(t *testing.T) {
+	if _, err := NewGenerator(fakeProgress{}).Generate(context.Background(), Subscriber{UserID: "broken"}, testEdition()); err == nil {
+		t.Error("expected an error when progress cannot be read")
+	}
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Rendering
+// ─────────────────────────────────────────────────────────────────────────────
+
+// goldenDigest is the digest rendered by the golden tests.
+func goldenDigest() *Digest {
+	return &Digest{
+		Subscriber: goDeveloper,
+		Since:      weekStart,
+		Until:      weekEnd,
+		Jobs: []JobMatch{
+			{Job: newJob("1", "Backend Engineer", weekStart, "Go", "SQL"), Score: 91.4, MatchedSkills: []string{"Go", "SQL"}},
+			{Job: newJob("2", "Platform Engineer <Infra>", weekStart, "Go", "Kubernetes"), Score: 67.5,
+				MatchedSkills: []string{"Go"}, MissingSkills: []string{"Kubernetes"}},
+		},
+		InProgress:     []Resource{{ID: "r2", Title: "Effective SQL", URL: "https://learn.example.com/sql", Progress: 70}},
+		Suggestion:     &Resource{Title: "Kubernetes Basics", URL: "https://learn.example.com/k8s", Provider: "CNCF", Skill: "kubernetes"},
+		UnsubscribeURL: "https://learnbot.example.com/api/users/digest/unsubscribe?token=abc",
+	}
+}
+
+func TestRender_Golden(t *testing.T) {
+	empty := &Digest{Subscriber: Subscriber{Email: "sam@example.com"}, Since: weekStart, Until: weekEnd,
+		InProgress:     []Resource{{Title: "Go by Example", URL: "https://gobyexample.com", Progress: 5}},
+		UnsubscribeURL: "https://learnbot.example.com/api/users/digest/unsubscribe?token=def"}
+
+	for name, d := range map[string]*Digest{"full": goldenDigest(), "no_jobs": empty} {
+		t.Run(name, func(t *testing.T) {
+			msg, err := Render(d)
+			if err != nil {
+				t.Fatalf("Render: %v", err)
+			}
+			if msg.To != d.Subscriber.Email || msg.Subject != d.Subject() {
+				t.Errorf("message to %q with subject %q", msg.To, msg.Subject)
+			}
+			checkGolden(t, filepath.Join("testdata", name+".html"), msg.HTML)
+			checkGolden(t, filepath.Join("testdata", name+".txt"), msg.Body)
+		})
+	}
+}
+
+func TestDigestSubject(t *testing.T) {
+	d := goldenDigest()
+	if got := d.Subject(); got != "Your LearnBot weekly digest: 2 new jobs matching your skills" {
+		t.Errorf("Subject = %q", got)
+	}
+	d.Jobs = d.Jobs[:1]
+	if got := d.Subject(); got != "Your LearnBot weekly digest: 1 new job matching your skills" {
+		t.Errorf("Subject = %q", got)
+	}
+}
+
+// checkGolden compares got with the golden file at path, or rewrites it when
+// UPDATE_GOLDEN is set.
+func checkGolden(t *testing.T, path, got string) {
+	t.Helper()
+	if os.Getenv("UPDATE_GOLDEN") != "" {
+		if err := os.WriteFile(path, []byte(got), 0o644); err != nil {
+			t.Fatalf("write %s: %v", path, err)
+		}
+		return
+	}
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read golden %s: %v (run with UPDATE_GOLDEN=1 to create it)", path, err)
+	}
+	if got != string(want) {
+		t.Errorf("%s differs from the rendered output:\n%s\n"+
+			"If the change is intended, rerun with UPDATE_GOLDEN=1 and commit the result.", path, got)
+	}
+}
@@ -0,0 +1,211 @@
+package digest
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/learnbot/api-gateway/internal/mail"
+	"github.com/learnbot/api-gateway/internal/middleware"
+)
+
+// SubscriberSource lists the users opted in to the digest.
+type SubscriberSource interface {
+	// Subscribers returns up to limit subscribers whose user IDs sort
+	// after after, in user ID order. An empty after starts from the first.
+	Subscribers(ctx context.Context, after string, limit int) ([]Subscriber, error)
+}
+
+// MailerConfig configures a Mailer.
+type MailerConfig struct {
+	// BatchSize is the number of subscribers read at a time.
+	BatchSize int
+
+	// SendTimeout bounds generating and sending one digest.
+	SendTimeout time.Duration
+
+	// Hour is the hour (UTC) on Mondays at which Start sends the digest.
+	Hour int
+
+	// UnsubscribeURL is the public URL of GET /api/users/digest/unsubscribe.
+	// The token is added as the "token" query parameter; if URL is empty
+	// the email holds the token alone.
+	UnsubscribeURL string
+
+	// Logger receives per-user failures and run summaries.
+	Logger *slog.Logger
+}
+
+// DefaultMailerConfig returns the default Mailer configuration: batches of
+// 100, 30 seconds per digest, sent at 8am UTC.
+func DefaultMailerConfig() MailerConfig {
+	return MailerConfig{BatchSize: 100, SendTimeout: 30 * time.Second, Hour: 8}
+}
+
+// RunStats counts the outcome of one Mailer run.
+type RunStats struct {
+	// Sent is the number of digests delivered.
+	Sent int
+
+	// Skipped is the number of subscribers whose digest was empty.
+	Skipped int
+
+	// Failed is the number of subscribers whose digest could not be
+	// generated or sent.
+	Failed int
+}
+
+// Mailer sends the digest to every subscriber.
+type Mailer struct {
+	gen    *Generator
+	jobs   JobSource
+	subs   SubscriberSource
+	sender mail.Sender
+	jwtCfg middleware.JWTConfig
+	cfg    MailerConfig
+	now    func() time.Time
+}
+
+// NewMailer creates a Mailer that signs unsubscribe links with jwtCfg's
+// secret. Zero config fields fall back to DefaultMailerConfig, except Hour,
+// and a nil Logger to slog.Default.
+func NewMailer(gen *Generator, jobs JobSource, subs SubscriberSource, sender mail.Sender, jwtCfg middleware.JWTConfig, cfg MailerConfig) *Mailer {
+	def := DefaultMailerConfig()
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = def.BatchSize
+	}
+	if cfg.SendTimeout <= 0 {
+		cfg.SendTimeout = def.SendTimeout
+	}
+	if cfg.Logger == nil {
+		cfg.Logger = slog.Default()
+	}
+	return &Mailer{gen: gen, jobs: jobs, subs: subs, sender: sender, jwtCfg: jwtCfg, cfg: cfg, now: time.Now}
+}
+
+// Run sends the digest of the past DefaultLookback to every subscriber,
+// reading them in batches. A subscriber whose digest fails is logged and
+// counted, and the run moves on; only failing to list the new jobs or the
+// subscribers stops it.
+func (m *Mailer) Run(ctx context.Context) (RunStats, error) {
+	var stats RunStats
+	now := m.now()
+	ed := Edition{Since: now.Add(-DefaultLookback), Until: now}
+	jobs, err := m.jobs.NewJobs(ctx, ed.Since)
+	if err != nil {
+		return stats, fmt.Errorf("list new jobs: %w", err)
+	}
+	ed.Jobs = jobs
+
+	after := ""
+	for {
+		batch, err := m.subs.Subscribers(ctx, after, m.cfg.BatchSize)
+		if err != nil {
+			return stats, fmt.Errorf("list subscribers: %w", err)
+		}
+		for _, sub := range batch {
+			if err := ctx.Err(); err != nil {
+				return stats, err
+			}
+			sent, err := m.send(ctx, sub, ed)
+			switch {
+			case err != nil:
+				stats.Failed++
+				m.cfg.Logger.ErrorContext(ctx, "digest failed", "user_id", sub.UserID, "error", err)
+			case sent:
+				stats.Sent++
+			default:
+				stats.Skipped++
+			}
+		}
+		if len(batch) < m.cfg.BatchSize {
+			break
+		}
+		after = batch[len(batch)-1].UserID
+	}
+
+	m.cfg.Logger.InfoContext(ctx, "digest run completed",
+		"jobs", len(ed.Jobs), "sent", stats.Sent, "skipped", stats.Skipped, "failed", stats.Failed)
+	return stats, nil
+}
+
+// send generates and sends the digest of sub, reporting whether it had
+// anything to send. A panic is returned as an error so that it only fails
+// this subscriber.
+func (m *Mailer) send(ctx context.Context, sub Subscriber, ed Edition) (sent bool, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+	ctx, cancel := context.WithTimeout(ctx, m.cfg.SendTimeout)
+	defer cancel()
+
+	d, err := m.gen.Generate(ctx, sub, ed)
+	if err != nil {
+		return false, err
+	}
+	if d.Empty() {
+		return false, nil
+	}
+	if d.UnsubscribeURL, err = m.unsubscribeLink(sub.UserID); err != nil {
+		return false, err
+	}
+	msg, err := Render(d)
+	if err != nil {
+		return false, err
+	}
+	if err := m.sender.Send(ctx, msg); err != nil {
+		return false, fmt.Errorf("send digest: %w", err)
+	}
+	return true, nil
+}
+
+// unsubscribeLink returns the link that unsubscribes userID.
+func (m *Mailer) unsubscribeLink(userID string) (string, error) {
+	token, err := middleware.GenerateUnsubscribeToken(m.jwtCfg, userID, List)
+	if err != nil {
+		return "", fmt.Errorf("unsubscribe token: %w", err)
+	}
+	if m.cfg.UnsubscribeURL == "" {
+		return token, nil
+	}
+	sep := "?"
+	if strings.Contains(m.cfg.UnsubscribeURL, "?") {
+		sep = "&"
+	}
+	return m.cfg.UnsubscribeURL + sep + "token=" + url.QueryEscape(token), nil
+}
+
+// NextSend returns the first send time after t: Monday at hour UTC.
+func NextSend(t time.Time, hour int) time.Time {
+	t = t.UTC()
+	next := time.Date(t.Year(), t.Month(), t.Day(), hour, 0, 0, 0, time.UTC)
+	next = next.AddDate(0, 0, (int(time.Monday)-int(next.Weekday())+7)%7)
+	if !next.After(t) {
+		next = next.AddDate(0, 0, 7)
+	}
+	return next
+}
+
+// Start starts a background goroutine that runs the Mailer every Monday at
+// the configured hour until ctx is cancelled.
+func (m *Mailer) Start(ctx context.Context) {
+	go func() {
+		for {
+			timer := time.NewTimer(NextSend(m.now(), m.cfg.Hour).Sub(m.now()))
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return
+			case <-timer.C:
+			}
+			if _, err := m.Run(ctx); err != nil {
+				m.cfg.Logger.ErrorContext(ctx, "digest run failed", "error", err)
+			}
+		}
+	}()
+}
@@ -0,0 +1,160 @@
+package digest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/url"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/learnbot/api-gateway/internal/mail"
+	"github.com/learnbot/api-gateway/internal/middleware"
+)
+
+// fakeJobs is a JobSource returning fixed jobs.
+type fakeJobs struct {
+	jobs  []Job
+	since time.Time
+	err   error
+}
+
+func (f *fakeJobs) NewJobs(_ context.Context, since time.Time) ([]Job, error) {
+	f.since = since
+	return f.jobs, f.err
+}
+
+// fakeSubscribers is a SubscriberSource that records the batches read.
+type fakeSubscribers struct {
+	subs    []Subscriber
+	batches []string
+}
+
+func (f *fakeSubscribers) Subscribers(_ context.Context, after string, limit int) ([]Subscriber, error) {
+	f.batches = append(f.batches, after)
+	var page []Subscriber
+	for _, s := range f.subs {
+		if s.UserID > after && len(page) < limit {
+			page = append(page, s)
+		}
+	}
+	return page, nil
+}
+
+// recordingSender records messages and fails for the addresses in fail.
+type recordingSender struct {
+	sent []mail.Message
+	fail map[string]bool
+}
+
+func (s *recordingSender) Send(_ context.Context, msg mail.Message) error {
+	if s.fail[msg.To] {
+		return errors.New("mailbox unavailable")
+	}
+	s.sent = append(s.sent, msg)
+	return nil
+}
+
+// panickingProgress panics for one user.
+type panickingProgress struct{ fakeProgress }
+
+func (p panickingProgress) InProgress(ctx context.Context, userID string) ([]Resource, error) {
+	if userID == "user-3" {
+		panic("boom")
+	}
+	return p.fakeProgress.InProgress(ctx, userID)
+}
+
+func TestMailerRun(t *testing.T) {
+	var subs []Subscriber
+	for i := 1; i <= 7; i++ {
+		sub := goDeveloper
+		sub.UserID = fmt.Sprintf("user-%d", i)
+		sub.Email = fmt.Sprintf("user%d@example.com", i)
+		subs = append(subs, sub)
+	}
+	// user-4 has no skills, so no job matches and the digest is empty.
+	subs[3].Profile.Skills = nil
+	// user-5's progress cannot be read.
+	subs[4].UserID = "broken"
+	sort.Slice(subs, func(i, j int) bool { return subs[i].UserID < subs[j].UserID })
+
+	jobs := &fakeJobs{jobs: testEdition().Jobs}
+	source := &fakeSubscribers{subs: subs}
+	sender := &recordingSender{fail: map[string]bool{"user6@example.com": true}}
+	jwtCfg := middleware.DefaultJWTConfig("test-secret")
+	m := NewMailer(NewGenerator(panickingProgress{fakeProgress{}}), jobs, source, sender, jwtCfg, MailerConfig{
+		BatchSize:      3,
+		UnsubscribeURL: "https://learnbot.example.com/api/users/digest/unsubscribe",
+		Logger:         slog.New(slog.NewTextHandler(io.Discard, nil)),
+	})
+	m.now = func() time.Time { return weekEnd.Add(-time.Hour) }
+
+	stats, err := m.Run(context.Background())
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	// user-3 panics, user-5 (broken) cannot be generated and user-6 cannot
+	// be delivered; the others are unaffected.
+	if stats != (RunStats{Sent: 3, Skipped: 1, Failed: 3}) {
+		t.Errorf("stats = %+v", stats)
+	}
+	if want := []string{"", "user-2", "user-6"}; strings.Join(source.batches, ",") != strings.Join(want, ",") {
+		t.Errorf("batches after %q, want %q", source.batches, want)
+	}
+	if !jobs.since.Equal(weekEnd.Add(-time.Hour - DefaultLookback)) {
+		t.Errorf("jobs since %v", jobs.since)
+	}
+
+	var got []string
+	for _, msg := range sender.sent {
+		got = append(got, msg.To)
+		i := strings.Index(msg.Body, "?token=")
+		if i < 0 {
+			t.Fatalf("no unsubscribe link in %q", msg.Body)
+		}
+		token, _ := url.QueryUnescape(strings.Fields(msg.Body[i+len("?token="):])[0])
+		userID, list, err := middleware.ParseUnsubscribeToken(jwtCfg, token)
+		if err != nil || list != List || "user"+strings.TrimPrefix(userID, "user-")+"@example.com" != msg.To {
+			t.Errorf("unsubscribe token for %s: %q, %q, %v", msg.To, userID, list, err)
+		}
+	}
+	if strings.Join(got, ",") != "user1@example.com,user2@example.com,user7@example.com" {
+		t.Errorf("sent to %v", got)
+	}
+}
+
+func TestMailerRun_JobSourceError(t *testing.T) {
+	source := &fakeSubscribers{subs: []Subscriber{goDeveloper}}
+	sender := &recordingSender{}
+	m := NewMailer(NewGenerator(fakeProgress{}), &fakeJobs{err: errors.New("aggregator down")}, source, sender,
+		middleware.DefaultJWTConfig("test-secret"), MailerConfig{Logger: slog.New(slog.NewTextHandler(io.Discard, nil))})
+	if _, err := m.Run(context.Background()); err == nil {
+		t.Error("expected an error when the new jobs cannot be listed")
+	}
+	if len(source.batches) != 0 || len(sender.sent) != 0 {
+		t.Errorf("read %d batches and sent %d emails without jobs", len(source.batches), len(sender.sent))
+	}
+}
+
+func TestNextSend(t *testing.T) {
+	tests := []struct {
+		now  string
+		want string
+	}{
+		{"2025-03-02T23:00:00Z", "2025-03-03T08:00:00Z"}, // Sunday
+		{"2025-03-03T07:59:59Z", "2025-03-03T08:00:00Z"}, // Monday, before the hour
+		{"2025-03-03T08:00:00Z", "2025-03-10T08:00:00Z"}, // Monday, at the hour
+		{"2025-03-05T12:00:00Z", "2025-03-10T08:00:00Z"}, // Wednesday
+	}
+	for _, tt := range tests {
+		now, _ := time.Parse(time.RFC3339, tt.now)
+		if got := NextSend(now, 8).Format(time.RFC3339); got != tt.want {
+			t.Errorf("NextSend(%s) = %s, want %s", tt.now, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,71 @@
+package digest
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	htmltemplate "html/template"
+	"strings"
+	texttemplate "text/template"
+	"time"
+
+	"github.com/learnbot/api-gateway/internal/mail"
+)
+
+//go:embed templates
+var templates embed.FS
+
+// templateFuncs are shared by the HTML and text templates.
+var templateFuncs = map[string]interface{}{
+	"date":  func(t time.Time) string { return t.UTC().Format("Jan 2") },
+	"score": func(s float64) string { return fmt.Sprintf("%.0f%%", s) },
+	"join":  func(s []string) string { return strings.Join(s, ", ") },
+}
+
+var (
+	htmlTemplate = htmltemplate.Must(htmltemplate.New("digest.html").
+			Funcs(templateFuncs).ParseFS(templates, "templates/digest.html"))
+	textTemplate = texttemplate.Must(texttemplate.New("digest.txt").
+			Funcs(templateFuncs).ParseFS(templates, "templates/digest.txt"))
+)
+
+// view is the data of the templates.
+type view struct {
+	*Digest
+	Name string
+}
+
+// Subject returns the subject line of d.
+func (d *Digest) Subject() string {
+	switch n := len(d.Jobs); n {
+	case 0:
+		return "Your LearnBot weekly digest"
+	case 1:
+		return "Your LearnBot weekly digest: 1 new job matching your skills"
+	default:
+		return fmt.Sprintf("Your LearnBot weekly digest: %d new jobs matching your skills", n)
+	}
+}
+
+// Render renders d as an email to its subscriber, with an HTML body and a
+// plain-text alternative.
+func Render(d *Digest) (mail.Message, error) {
+	v := view{Digest: d, Name: d.Subscriber.Name}
+	if v.Name == "" {
+		v.Name = "there"
+	}
+
+	var html, text bytes.Buffer
+	if err := htmlTemplate.Execute(&html, v); err != nil {
+		return mail.Message{}, fmt.Errorf("render digest html: %w", err)
+	}
+	if err := textTemplate.Execute(&text, v); err != nil {
+		return mail.Message{}, fmt.Errorf("render digest text: %w", err)
+	}
+	return mail.Message{
+		To:      d.Subscriber.Email,
+		Subject: d.Subject(),
+		Body:    text.String(),
+		HTML:    html.String(),
+	}, nil
+}
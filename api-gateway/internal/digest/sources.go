@@ -0,0 +1,190 @@
+package digest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/learnbot/resume-parser/pkg/scoring"
+)
+
+// maxJobPages caps the search pages AggregatorJobs reads, at 100 jobs each.
+const maxJobPages = 10
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Job aggregator
+// ─────────────────────────────────────────────────────────────────────────────
+
+// AggregatorJobs is a JobSource that searches the job aggregator.
+type AggregatorJobs struct {
+	baseURL string
+	client  *http.Client
+}
+
+// NewAggregatorJobs creates an AggregatorJobs for the job aggregator at
+// baseURL. A nil client uses http.DefaultClient.
+func NewAggregatorJobs(baseURL string, client *http.Client) *AggregatorJobs {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return &AggregatorJobs{baseURL: strings.TrimRight(baseURL, "/"), client: client}
+}
+
+// aggregatorJob holds the fields of a job aggregator search result that a
+// digest uses. Nullable columns are encoded as sql.Null* values.
+type aggregatorJob struct {
+	ID              string   `json:"id"`
+	Title           string   `json:"title"`
+	CompanyName     string   `json:"company_name"`
+	LocationType    string   `json:"location_type"`
+	ExperienceLevel string   `json:"experience_level"`
+	RequiredSkills  []string `json:"required_skills"`
+	PreferredSkills []string `json:"preferred_skills"`
+	ApplicationURL  string   `json:"application_url"`
+	Industry        struct {
+		String string
+		Valid  bool
+	} `json:"industry"`
+	LocationCity    struct{ String string } `json:"location_city"`
+	LocationCountry struct{ String string } `json:"location_country"`
+	PostedAt        struct {
+		Time  time.Time
+		Valid bool
+	} `json:"posted_at"`
+	ScrapedAt time.Time `json:"scraped_at"`
+}
+
+// NewJobs implements JobSource. Jobs without a posting date count as posted
+// when they were first scraped.
+func (s *AggregatorJobs) NewJobs(ctx context.Context, since time.Time) ([]Job, error) {
+	q := url.Values{
+		"posted_after": {since.UTC().Format(time.RFC3339)},
+		"sort":         {"recent"},
+		"limit":        {"100"},
+	}
+	var jobs []Job
+	for page := 0; page < maxJobPages; page++ {
+		var body struct {
+			Data       []aggregatorJob `json:"data"`
+			Pagination struct {
+				NextCursor string `json:"next_cursor"`
+			} `json:"pagination"`
+		}
+		if err := getJSON(ctx, s.client, s.baseURL+"/api/v1/jobs/search?"+q.Encode(), "", &body); err != nil {
+			return nil, err
+		}
+		for _, j := range body.Data {
+			posted := j.ScrapedAt
+			if j.PostedAt.Valid {
+				posted = j.PostedAt.Time
+			}
+			jobs = append(jobs, Job{
+				ID:           j.ID,
+				Title:        j.Title,
+				Company:      j.CompanyName,
+				URL:          j.ApplicationURL,
+				LocationType: j.LocationType,
+				PostedAt:     posted,
+				Requirements: scoring.JobRequirements{
+					Title:           j.Title,
+					RequiredSkills:  j.RequiredSkills,
+					PreferredSkills: j.PreferredSkills,
+					LocationCity:    j.LocationCity.String,
+					LocationCountry: j.LocationCountry.String,
+					LocationType:    j.LocationType,
+					Industry:        j.Industry.String,
+					ExperienceLevel: j.ExperienceLevel,
+				},
+			})
+		}
+		if body.Pagination.NextCursor == "" {
+			break
+		}
+		q.Set("cursor", body.Pagination.NextCursor)
+	}
+	return jobs, nil
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Learning resources
+// ─────────────────────────────────────────────────────────────────────────────
+
+// LearningProgress is a ProgressSource that reads the user's progress from
+// the learning resources service.
+type LearningProgress struct {
+	baseURL string
+	client  *http.Client
+}
+
+// NewLearningProgress creates a LearningProgress for the learning resources
+// service at baseURL. A nil client uses http.DefaultClient.
+func NewLearningProgress(baseURL string, client *http.Client) *LearningProgress {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return &LearningProgress{baseURL: strings.TrimRight(baseURL, "/"), client: client}
+}
+
+// InProgress implements ProgressSource, looking up the title and URL of
+// each resource the user has in progress.
+func (s *LearningProgress) InProgress(ctx context.Context, userID string) ([]Resource, error) {
+	var progress struct {
+		Data []struct {
+			ResourceID         string `json:"resource_id"`
+			ProgressPercentage int    `json:"progress_percentage"`
+		} `json:"data"`
+	}
+	path := "/api/v1/users/" + url.PathEscape(userID) + "/progress?status=in_progress"
+	if err := getJSON(ctx, s.client, s.baseURL+path, userID, &progress); err != nil {
+		return nil, err
+	}
+
+	resources := make([]Resource, 0, len(progress.Data))
+	for _, p := range progress.Data {
+		var resource struct {
+			Data struct {
+				Title string `json:"title"`
+				URL   string `json:"url"`
+			} `json:"data"`
+		}
+		if err := getJSON(ctx, s.client, s.baseURL+"/api/v1/resources/"+url.PathEscape(p.ResourceID), userID, &resource); err != nil {
+			return nil, err
+		}
+		resources = append(resources, Resource{
+			ID:       p.ResourceID,
+			Title:    resource.Data.Title,
+			URL:      resource.Data.URL,
+			Progress: p.ProgressPercentage,
+		})
+	}
+	return resources, nil
+}
+
+// getJSON decodes the JSON response to a GET of rawURL into v, sending
+// userID as X-User-ID when set.
+func getJSON(ctx context.Context, client *http.Client, rawURL, userID string, v interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	if userID != "" {
+		req.Header.Set("X-User-ID", userID)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("GET %s: status %d", req.URL.Path, resp.StatusCode)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		return fmt.Errorf("GET %s: decode response: %w", req.URL.Path, err)
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+package digest
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestAggregatorJobs(t *testing.T) {
+	since := time.Date(2025, 3, 3, 8, 0, 0, 0, time.UTC)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if r.URL.Path != "/api/v1/jobs/search" || q.Get("posted_after") != "2025-03-03T08:00:00Z" || q.Get("sort") != "recent" {
+			t.Errorf("unexpected request %s", r.URL)
+		}
+		if q.Get("cursor") == "" {
+			w.Write([]byte(`{"data": [{"id": "j1", "title": "Backend Engineer", "company_name": "Acme",
+				"location_type": "remote", "required_skills": ["Go"], "application_url": "https://jobs.example.com/j1",
+				"industry": {"String": "fintech", "Valid": true},
+				"posted_at": {"Time": "2025-03-04T10:00:00Z", "Valid": true}, "scraped_at": "2025-03-05T10:00:00Z"}],
+				"pagination": {"next_cursor": "page2"}}`))
+			return
+		}
+		w.Write([]byte(`{"data": [{"id": "j2", "title": "Data Engineer", "scraped_at": "2025-03-06T10:00:00Z"}],
+			"pagination": {}}`))
+	}))
+	defer srv.Close()
+
+	jobs, err := NewAggregatorJobs(srv.URL+"/", nil).NewJobs(context.Background(), since)
+	if err != nil {
+		t.Fatalf("NewJobs: %v", err)
+	}
+	if len(jobs) != 2 {
+		t.Fatalf("got %d jobs, want 2", len(jobs))
+	}
+	j := jobs[0]
+	if j.Company != "Acme" || j.URL != "https://jobs.example.com/j1" || j.Requirements.Industry != "fintech" ||
+		len(j.Requirements.RequiredSkills) != 1 || !j.PostedAt.Equal(time.Date(2025, 3, 4, 10, 0, 0, 0, time.UTC)) {
+		t.Errorf("unexpected job %+v", j)
+	}
+	// Without a posting date the job counts as posted when scraped.
+	if !jobs[1].PostedAt.Equal(time.Date(2025, 3, 6, 10, 0, 0, 0, time.UTC)) {
+		t.Errorf("job without posted_at: PostedAt = %v", jobs[1].PostedAt)
+	}
+}
+
+func TestLearningProgress(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-User-ID") == "" {
+			t.Errorf("no X-User-ID on %s", r.URL)
+		}
+		switch r.URL.Path {
+		case "/api/v1/users/user-1/progress":
+			if r.URL.Query().Get("status") != "in_progress" {
+				t.Errorf("unexpected query %q", r.URL.RawQuery)
+			}
+			w.Write([]byte(`{"success": true, "data": [{"resource_id": "r1", "progress_percentage": 40}]}`))
+		case "/api/v1/resources/r1":
+			w.Write([]byte(`{"success": true, "data": {"id": "r1", "title": "Effective SQL", "url": "https://learn.example.com/sql"}}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	got, err := NewLearningProgress(srv.URL, nil).InProgress(context.Background(), "user-1")
+	if err != nil {
+		t.Fatalf("InProgress: %v", err)
+	}
+	if len(got) != 1 || got[0] != (Resource{ID: "r1", Title: "Effective SQL", URL: "https://learn.example.com/sql", Progress: 40}) {
+		t.Errorf("InProgress = %+v", got)
+	}
+
+	if _, err := NewLearningProgress(srv.URL, nil).InProgress(context.Background(), "user-2"); err == nil {
+		t.Error("expected an error for a failed request")
+	}
+}
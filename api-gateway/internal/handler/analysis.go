@@ -2,6 +2,7 @@
 package handler
 
 import (
+	"log/slog"
 	"net/http"
 	"strings"
 
@@ -10,31 +11,86 @@ import (
 	"github.com/learnbot/resume-parser/pkg/analysis"
 	"github.com/learnbot/resume-parser/pkg/recommend"
 	"github.com/learnbot/resume-parser/pkg/scoring"
+	"github.com/learnbot/shared/openapi"
 )
 
 // AnalysisHandler handles gap analysis and training recommendation endpoints.
 type AnalysisHandler struct {
 	gapAnalyzer *analysis.Analyzer
 	recEngine   *recommend.Engine
+	readiness   ReadinessStore
+	logger      *slog.Logger
 }
 
-// NewAnalysisHandler creates a new AnalysisHandler.
+// NewAnalysisHandler creates a new AnalysisHandler. Readiness snapshots are
+// kept in a MemoryReadinessStore until SetReadinessStore replaces it.
 func NewAnalysisHandler() *AnalysisHandler {
 	return &AnalysisHandler{
 		gapAnalyzer: analysis.New(),
 		recEngine:   recommend.New(),
+		readiness:   NewMemoryReadinessStore(0),
+		logger:      slog.Default(),
 	}
 }
 
+// SetReadinessStore replaces the store of gap analysis snapshots.
+func (h *AnalysisHandler) SetReadinessStore(store ReadinessStore) {
+	h.readiness = store
+}
+
 // RegisterRoutes registers analysis routes on the mux.
 //
 //	POST /api/analysis/gaps           – analyze skill gaps for a target job
 //	GET  /api/training/recommendations – get personalized training plan
-func (h *AnalysisHandler) RegisterRoutes(mux *http.ServeMux, authMiddleware func(http.Handler) http.Handler) {
+//	GET  /api/v1/me/readiness-history  – readiness trend against a job
+func (h *AnalysisHandler) RegisterRoutes(mux openapi.Router, authMiddleware func(http.Handler) http.Handler) {
 	mux.Handle("/api/analysis/gaps",
 		authMiddleware(http.HandlerFunc(h.GapAnalysis)))
 	mux.Handle("/api/training/recommendations",
 		authMiddleware(http.HandlerFunc(h.TrainingRecommendations)))
+	mux.Handle("/api/v1/me/readiness-history",
+		authMiddleware(http.HandlerFunc(h.ReadinessHistory)))
+}
+
+// DescribeRoutes documents the routes registered by RegisterRoutes.
+func (h *AnalysisHandler) DescribeRoutes(spec *openapi.Spec) {
+	auth := []string{openapi.BearerAuth}
+	spec.Route("/api/analysis/gaps", openapi.Operation{
+		Method:   http.MethodPost,
+		Summary:  "Analyze the current user's skill gaps for a job",
+		Security: auth,
+		Request:  types.GapAnalysisRequest{},
+		Response: success(analysis.GapAnalysisResult{}),
+		Errors:   []int{http.StatusBadRequest, http.StatusUnauthorized},
+	})
+	spec.Route("/api/v1/me/readiness-history", openapi.Operation{
+		Method:  http.MethodGet,
+		Summary: "The current user's readiness history against a job",
+		Description: "Every gap analysis of a job_id is recorded; runs with unchanged results are " +
+			"recorded once, and the latest 100 are kept.",
+		Security: auth,
+		Params:   []openapi.Param{{Name: "job_id", Description: "ID of the target job", Required: true}},
+		Response: success(types.ReadinessHistoryResponse{}),
+		Errors:   []int{http.StatusBadRequest, http.StatusUnauthorized},
+	})
+	spec.Route("/api/training/recommendations",
+		openapi.Operation{
+			Method:   http.MethodGet,
+			Summary:  "Learning plan for the current user and a sample job",
+			Security: auth,
+			Params:   []openapi.Param{openapi.Query("job_id", "ID of the target job")},
+			Response: success(recommend.LearningPlan{}),
+			Errors:   []int{http.StatusBadRequest, http.StatusUnauthorized},
+		},
+		openapi.Operation{
+			Method:   http.MethodPost,
+			Summary:  "Learning plan for the current user, a job and learning preferences",
+			Security: auth,
+			Request:  types.TrainingRecommendationRequest{},
+			Response: success(recommend.LearningPlan{}),
+			Errors:   []int{http.StatusBadRequest, http.StatusUnauthorized},
+		},
+	)
 }
 
 // GapAnalysis handles POST /api/analysis/gaps.
@@ -55,6 +111,7 @@ func (h *AnalysisHandler) RegisterRoutes(mux *http.ServeMux, authMiddleware func
 //	}
 //
 // Response includes critical gaps, important gaps, readiness score, and visual data.
+// Analyses of a job_id are recorded in the user's readiness history.
 func (h *AnalysisHandler) GapAnalysis(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
 		WriteMethodNotAllowed(w)
@@ -81,6 +138,7 @@ func (h *AnalysisHandler) GapAnalysis(w http.ResponseWriter, r *http.Request) {
 
 	// Run gap analysis.
 	result := h.gapAnalyzer.Analyze(profile, jobReqs)
+	h.recordReadiness(r.Context(), userID, req.JobID, result)
 
 	WriteSuccess(w, http.StatusOK, result)
 }
@@ -135,7 +193,9 @@ func (h *AnalysisHandler) TrainingRecommendations(w http.ResponseWriter, r *http
 	// Build preferences.
 	prefs := recommend.UserPreferences{
 		PreferFree:             req.Preferences.PreferFree,
+		MaxBudget:              req.Preferences.MaxBudget,
 		MaxBudgetUSD:           req.Preferences.MaxBudgetUSD,
+		Currency:               req.Preferences.Currency,
 		WeeklyHoursAvailable:   req.Preferences.WeeklyHoursAvailable,
 		PreferHandsOn:          req.Preferences.PreferHandsOn,
 		PreferCertificates:     req.Preferences.PreferCertificates,
@@ -167,6 +227,7 @@ func (h *AnalysisHandler) resolveJobRequirements(jobID string, inline *types.Job
 			Title:               inline.Title,
 			RequiredSkills:      inline.RequiredSkills,
 			PreferredSkills:     inline.PreferredSkills,
+			NiceToHaveSkills:    inline.NiceToHaveSkills,
 			MinYearsExperience:  inline.MinYearsExperience,
 			RequiredDegreeLevel: inline.RequiredDegreeLevel,
 			LocationType:        inline.LocationType,
@@ -201,11 +262,32 @@ func NewResourcesHandler() *ResourcesHandler {
 	}
 }
 
-// RegisterRoutes registers resource routes on the mux.
+// RegisterRoutes registers resource routes on the mux behind cache, which
+// may serve repeated searches from the gateway's response cache.
 //
 //	GET /api/resources/search – search learning resources
-func (h *ResourcesHandler) RegisterRoutes(mux *http.ServeMux) {
-	mux.HandleFunc("/api/resources/search", h.Search)
+func (h *ResourcesHandler) RegisterRoutes(mux openapi.Router, cache func(http.Handler) http.Handler) {
+	mux.Handle("/api/resources/search", cache(http.HandlerFunc(h.Search)))
+}
+
+// DescribeRoutes documents the routes registered by RegisterRoutes.
+func (h *ResourcesHandler) DescribeRoutes(spec *openapi.Spec) {
+	spec.Route("/api/resources/search", openapi.Operation{
+		Method:  http.MethodGet,
+		Summary: "Search the learning resource catalog",
+		Params: []openapi.Param{
+			openapi.Query("skill", "Filter by skill name"),
+			openapi.Query("type", "Filter by resource type"),
+			openapi.Query("difficulty", "Filter by difficulty level"),
+			openapi.Query("free", `"true" to show only free resources`),
+			openapi.Query("has_certificate", `"true" to show only resources with certificates`),
+			openapi.Query("has_hands_on", `"true" to show only resources with hands-on exercises`),
+			openapi.Query("q", "Search titles and descriptions"),
+			{Name: "limit", Description: "Page size (default 20, max 100)", Type: 0},
+			{Name: "offset", Description: "Number of results to skip", Type: 0},
+		},
+		Response: successWithMeta([]recommend.ResourceEntry{}),
+	})
 }
 
 // Search handles GET /api/resources/search.
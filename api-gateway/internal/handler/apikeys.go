@@ -0,0 +1,243 @@
+// Package handler – apikeys.go implements the admin endpoints that create,
+// list and revoke API keys for machine-to-machine clients.
+package handler
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/learnbot/api-gateway/internal/middleware"
+	"github.com/learnbot/api-gateway/internal/types"
+	"github.com/learnbot/shared/openapi"
+	"github.com/learnbot/shared/validation"
+)
+
+// APIKeysHandler lets admins manage API keys. Keys are shown once, when
+// they are created; only their hashes are stored.
+type APIKeysHandler struct {
+	store  middleware.APIKeyStore
+	logger *slog.Logger
+	now    func() time.Time
+}
+
+// NewAPIKeysHandler creates an APIKeysHandler for the keys in store. Key
+// creation and revocation are logged to logger, or slog.Default if nil.
+func NewAPIKeysHandler(store middleware.APIKeyStore, logger *slog.Logger) *APIKeysHandler {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &APIKeysHandler{store: store, logger: logger, now: time.Now}
+}
+
+// RegisterRoutes registers API key routes on the mux, restricted to admins.
+//
+//	GET    /api/admin/api-keys      – list API keys
+//	POST   /api/admin/api-keys      – create an API key
+//	DELETE /api/admin/api-keys/{id} – revoke an API key
+func (h *APIKeysHandler) RegisterRoutes(mux openapi.Router, authMiddleware func(http.Handler) http.Handler) {
+	admin := func(next http.HandlerFunc) http.Handler {
+		return authMiddleware(middleware.RequireRole(middleware.RoleAdmin)(next))
+	}
+	mux.Handle("/api/admin/api-keys", admin(h.handleAPIKeys))
+	mux.Handle("/api/admin/api-keys/", admin(h.Revoke))
+}
+
+// DescribeRoutes documents the routes registered by RegisterRoutes.
+func (h *APIKeysHandler) DescribeRoutes(spec *openapi.Spec) {
+	auth := []string{openapi.BearerAuth}
+	adminErrors := []int{http.StatusUnauthorized, http.StatusForbidden}
+	spec.Route("/api/admin/api-keys",
+		openapi.Operation{
+			Method:      http.MethodGet,
+			Summary:     "List API keys",
+			Description: "Requires the admin role. Keys themselves are never returned.",
+			Security:    auth,
+			Response:    success([]types.APIKeyInfo{}),
+			Errors:      adminErrors,
+		},
+		openapi.Operation{
+			Method:  http.MethodPost,
+			Summary: "Create an API key",
+			Description: "Requires the admin role. The key is returned in this response only; " +
+				"clients send it in the X-API-Key header.",
+			Security: auth,
+			Request:  types.APIKeyCreateRequest{},
+			Response: success(types.APIKeyCreateResponse{}),
+			Status:   http.StatusCreated,
+			Errors:   append([]int{http.StatusBadRequest}, adminErrors...),
+		},
+	)
+	spec.Route("/api/admin/api-keys/", openapi.Operation{
+		Method:      http.MethodDelete,
+		Path:        "/api/admin/api-keys/{id}",
+		Summary:     "Revoke an API key",
+		Description: "Requires the admin role. Revoked keys are rejected with 401 at once.",
+		Security:    auth,
+		Response:    success(types.APIKeyInfo{}),
+		Errors:      append([]int{http.StatusNotFound, http.StatusConflict}, adminErrors...),
+	})
+}
+
+// handleAPIKeys handles GET/POST /api/admin/api-keys.
+func (h *APIKeysHandler) handleAPIKeys(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		h.List(w, r)
+	case http.MethodPost:
+		h.Create(w, r)
+	default:
+		WriteMethodNotAllowed(w)
+	}
+}
+
+// Create handles POST /api/admin/api-keys.
+//
+// Request body:
+//
+//	{"name": "acme-sync", "scopes": ["read:jobs", "read:resources"]}
+//
+// Response (201, the only time the key is shown):
+//
+//	{"success": true, "data": {"id": "...", "key": "lbk_...", "scopes": [...], ...}}
+func (h *APIKeysHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var req types.APIKeyCreateRequest
+	if !DecodeJSON(w, r, &req) {
+		return
+	}
+
+	var v Validator
+	v.Required("name", req.Name, "name is required")
+	if len(req.Scopes) == 0 {
+		v.add("scopes", validation.CodeRequired, "at least one scope is required")
+	}
+	scopes := make([]middleware.Scope, 0, len(req.Scopes))
+	for _, name := range req.Scopes {
+		scope, err := middleware.ParseScope(name)
+		if err != nil {
+			v.add("scopes", validation.CodeOneOf, err.Error())
+			continue
+		}
+		if !hasScope(scopes, scope) {
+			scopes = append(scopes, scope)
+		}
+	}
+	if req.RequestsPerSecond < 0 || req.Burst < 0 || (req.RequestsPerSecond > 0) != (req.Burst > 0) {
+		v.add("requests_per_second", validation.CodeInvalid,
+			"requests_per_second and burst must both be positive or both be omitted")
+	}
+	if v.WriteIfInvalid(w) {
+		return
+	}
+
+	key, stored, err := middleware.NewAPIKey()
+	if err != nil {
+		WriteInternalError(w)
+		return
+	}
+	stored.Name = strings.TrimSpace(req.Name)
+	stored.Scopes = scopes
+	stored.RateLimit = middleware.RateLimit{RequestsPerSecond: req.RequestsPerSecond, Burst: req.Burst}
+	stored.CreatedBy = middleware.GetUserID(r)
+	stored.CreatedAt = h.now().UTC()
+	if err := h.store.Save(stored); err != nil {
+		WriteInternalError(w)
+		return
+	}
+
+	h.logger.InfoContext(r.Context(), "api key created",
+		"key_id", stored.ID, "name", stored.Name, "admin_id", stored.CreatedBy)
+	WriteSuccess(w, http.StatusCreated, types.APIKeyCreateResponse{
+		APIKeyInfo: apiKeyInfo(stored),
+		Key:        key,
+	})
+}
+
+// List handles GET /api/admin/api-keys.
+func (h *APIKeysHandler) List(w http.ResponseWriter, r *http.Request) {
+	keys, err := h.store.List()
+	if err != nil {
+		WriteInternalError(w)
+		return
+	}
+	infos := make([]types.APIKeyInfo, len(keys))
+	for i, k := range keys {
+		infos[i] = apiKeyInfo(k)
+	}
+	WriteSuccess(w, http.StatusOK, infos)
+}
+
+// Revoke handles DELETE /api/admin/api-keys/{id}.
+func (h *APIKeysHandler) Revoke(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodDelete {
+		WriteMethodNotAllowed(w)
+		return
+	}
+	id := strings.TrimPrefix(r.URL.Path, "/api/admin/api-keys/")
+	if id == "" || strings.Contains(id, "/") {
+		WriteError(w, http.StatusNotFound, "NOT_FOUND", "route not found")
+		return
+	}
+
+	key, err := h.store.Revoke(id, h.now().UTC())
+	switch {
+	case errors.Is(err, middleware.ErrAPIKeyNotFound):
+		WriteNotFound(w, "api key")
+		return
+	case errors.Is(err, middleware.ErrAPIKeyRevoked):
+		WriteError(w, http.StatusConflict, "API_KEY_ALREADY_REVOKED", "api key is already revoked")
+		return
+	case err != nil:
+		WriteInternalError(w)
+		return
+	}
+
+	h.logger.InfoContext(r.Context(), "api key revoked",
+		"key_id", key.ID, "name", key.Name, "admin_id", middleware.GetUserID(r))
+	WriteSuccess(w, http.StatusOK, apiKeyInfo(key))
+}
+
+// apiKeyInfo describes key for responses.
+func apiKeyInfo(key middleware.APIKey) types.APIKeyInfo {
+	info := types.APIKeyInfo{
+		ID:                key.ID,
+		Name:              key.Name,
+		Hint:              key.Hint,
+		Scopes:            make([]string, len(key.Scopes)),
+		RequestsPerSecond: key.RateLimit.RequestsPerSecond,
+		Burst:             key.RateLimit.Burst,
+		CreatedBy:         key.CreatedBy,
+		CreatedAt:         key.CreatedAt,
+	}
+	for i, s := range key.Scopes {
+		info.Scopes[i] = string(s)
+	}
+	if key.Revoked() {
+		revokedAt := key.RevokedAt
+		info.RevokedAt = &revokedAt
+	}
+	return info
+}
+
+// hasScope reports whether scopes contains scope.
+func hasScope(scopes []middleware.Scope, scope middleware.Scope) bool {
+	for _, s := range scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// requireScope reports whether the caller may act within scope. If not, it
+// writes 403 Forbidden and the handler should return. Users always pass;
+// API keys pass if they were granted scope.
+func requireScope(w http.ResponseWriter, r *http.Request, scope middleware.Scope) bool {
+	if middleware.HasScope(r, scope) {
+		return true
+	}
+	WriteError(w, http.StatusForbidden, "INSUFFICIENT_SCOPE", "API key lacks the "+string(scope)+" scope")
+	return false
+}
@@ -0,0 +1,131 @@
+// Package handler – apikeys_postgres.go keeps API keys in the api_keys
+// table of the LearnBot database.
+package handler
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+
+	"github.com/learnbot/api-gateway/internal/middleware"
+)
+
+// PostgresAPIKeyStore is a middleware.APIKeyStore over the api_keys table,
+// created by the database module's migrations.
+type PostgresAPIKeyStore struct {
+	db *sql.DB
+}
+
+// NewPostgresAPIKeyStore creates a PostgresAPIKeyStore using db.
+func NewPostgresAPIKeyStore(db *sql.DB) *PostgresAPIKeyStore {
+	return &PostgresAPIKeyStore{db: db}
+}
+
+// apiKeyColumns are the columns scanned by scanAPIKey.
+const apiKeyColumns = `id, name, key_hash, hint, scopes, rate_limit_rps, rate_limit_burst,
+	created_by, created_at, revoked_at`
+
+// Save implements middleware.APIKeyStore.
+func (s *PostgresAPIKeyStore) Save(key middleware.APIKey) error {
+	scopes := make([]string, len(key.Scopes))
+	for i, sc := range key.Scopes {
+		scopes[i] = string(sc)
+	}
+	if _, err := s.db.Exec(`
+		INSERT INTO api_keys (id, name, key_hash, hint, scopes, rate_limit_rps, rate_limit_burst, created_by, created_at, revoked_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)`,
+		key.ID, key.Name, key.Hash, key.Hint, pq.Array(scopes),
+		key.RateLimit.RequestsPerSecond, key.RateLimit.Burst,
+		key.CreatedBy, key.CreatedAt, nullTime(key.RevokedAt)); err != nil {
+		return fmt.Errorf("insert api key: %w", err)
+	}
+	return nil
+}
+
+// FindByHash implements middleware.APIKeyStore.
+func (s *PostgresAPIKeyStore) FindByHash(hash string) (middleware.APIKey, error) {
+	key, err := scanAPIKey(s.db.QueryRow(`SELECT `+apiKeyColumns+` FROM api_keys WHERE key_hash = $1`, hash))
+	if errors.Is(err, sql.ErrNoRows) {
+		return middleware.APIKey{}, middleware.ErrAPIKeyNotFound
+	}
+	if err != nil {
+		return middleware.APIKey{}, fmt.Errorf("find api key: %w", err)
+	}
+	return key, nil
+}
+
+// List implements middleware.APIKeyStore.
+func (s *PostgresAPIKeyStore) List() ([]middleware.APIKey, error) {
+	rows, err := s.db.Query(`SELECT ` + apiKeyColumns + ` FROM api_keys ORDER BY created_at DESC, id`)
+	if err != nil {
+		return nil, fmt.Errorf("list api keys: %w", err)
+	}
+	defer rows.Close()
+
+	keys := []middleware.APIKey{}
+	for rows.Next() {
+		key, err := scanAPIKey(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan api key: %w", err)
+		}
+		keys = append(keys, key)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate api keys: %w", err)
+	}
+	return keys, nil
+}
+
+// Revoke implements middleware.APIKeyStore. A key revoked concurrently is
+// reported as already revoked.
+func (s *PostgresAPIKeyStore) Revoke(id string, at time.Time) (middleware.APIKey, error) {
+	key, err := scanAPIKey(s.db.QueryRow(`
+		UPDATE api_keys SET revoked_at = $2
+		WHERE id = $1 AND revoked_at IS NULL
+		RETURNING `+apiKeyColumns, id, at))
+	if err == nil {
+		return key, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return middleware.APIKey{}, fmt.Errorf("revoke api key: %w", err)
+	}
+
+	key, err = scanAPIKey(s.db.QueryRow(`SELECT `+apiKeyColumns+` FROM api_keys WHERE id = $1`, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return middleware.APIKey{}, middleware.ErrAPIKeyNotFound
+	}
+	if err != nil {
+		return middleware.APIKey{}, fmt.Errorf("find api key: %w", err)
+	}
+	return key, middleware.ErrAPIKeyRevoked
+}
+
+// scanAPIKey scans the apiKeyColumns of a row.
+func scanAPIKey(row interface{ Scan(...interface{}) error }) (middleware.APIKey, error) {
+	var (
+		key       middleware.APIKey
+		scopes    []string
+		revokedAt sql.NullTime
+	)
+	if err := row.Scan(&key.ID, &key.Name, &key.Hash, &key.Hint, pq.Array(&scopes),
+		&key.RateLimit.RequestsPerSecond, &key.RateLimit.Burst,
+		&key.CreatedBy, &key.CreatedAt, &revokedAt); err != nil {
+		return middleware.APIKey{}, err
+	}
+	key.Scopes = make([]middleware.Scope, len(scopes))
+	for i, sc := range scopes {
+		key.Scopes[i] = middleware.Scope(sc)
+	}
+	if revokedAt.Valid {
+		key.RevokedAt = revokedAt.Time
+	}
+	return key, nil
+}
+
+// nullTime returns t as a nullable column, NULL if it is zero.
+func nullTime(t time.Time) sql.NullTime {
+	return sql.NullTime{Time: t, Valid: !t.IsZero()}
+}
@@ -0,0 +1,39 @@
+package handler_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/learnbot/api-gateway/internal/handler"
+	"github.com/learnbot/api-gateway/internal/middleware"
+)
+
+func TestPostgresAPIKeyStore(t *testing.T) {
+	testAPIKeyStore(t, handler.NewPostgresAPIKeyStore(openPostgresSchema(t)))
+}
+
+func TestPostgresAPIKeyStore_SurvivesRestart(t *testing.T) {
+	db := openPostgresSchema(t)
+	raw, key, err := middleware.NewAPIKey()
+	if err != nil {
+		t.Fatalf("NewAPIKey: %v", err)
+	}
+	key.Name, key.CreatedAt = "client", time.Now().UTC().Truncate(time.Second)
+	key.Scopes = []middleware.Scope{middleware.ScopeReadJobs}
+	if err := handler.NewPostgresAPIKeyStore(db).Save(key); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	// A new store, as after a restart, still accepts the key.
+	found, err := handler.NewPostgresAPIKeyStore(db).FindByHash(middleware.HashAPIKey(raw))
+	if err != nil || found.ID != key.ID || len(found.Scopes) != 1 || found.Scopes[0] != middleware.ScopeReadJobs {
+		t.Errorf("FindByHash after restart = %+v, %v", found, err)
+	}
+	var stored string
+	if err := db.QueryRow(`SELECT key_hash FROM api_keys WHERE id = $1`, key.ID).Scan(&stored); err != nil {
+		t.Fatalf("read key_hash: %v", err)
+	}
+	if stored != middleware.HashAPIKey(raw) || stored == raw {
+		t.Errorf("key_hash = %q, want the SHA-256 of the key", stored)
+	}
+}
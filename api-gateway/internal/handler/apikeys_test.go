@@ -0,0 +1,282 @@
+package handler_test
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/learnbot/api-gateway/internal/handler"
+	"github.com/learnbot/api-gateway/internal/middleware"
+	"github.com/learnbot/api-gateway/internal/types"
+	"github.com/learnbot/shared/logging"
+)
+
+// testAPIKeyStore checks the middleware.APIKeyStore contract on an empty
+// store.
+func testAPIKeyStore(t *testing.T, store middleware.APIKeyStore) {
+	t.Helper()
+	created := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	raw, older, err := middleware.NewAPIKey()
+	if err != nil {
+		t.Fatalf("NewAPIKey: %v", err)
+	}
+	older.Name, older.CreatedBy, older.CreatedAt = "older client", "admin-1", created
+	older.Scopes = []middleware.Scope{middleware.ScopeReadJobs, middleware.ScopeReadResources}
+	older.RateLimit = middleware.RateLimit{RequestsPerSecond: 2, Burst: 10}
+	_, newer, err := middleware.NewAPIKey()
+	if err != nil {
+		t.Fatalf("NewAPIKey: %v", err)
+	}
+	newer.Name, newer.CreatedAt, newer.Scopes = "newer client", created.Add(time.Hour), []middleware.Scope{}
+	for _, k := range []middleware.APIKey{older, newer} {
+		if err := store.Save(k); err != nil {
+			t.Fatalf("Save: %v", err)
+		}
+	}
+
+	found, err := store.FindByHash(middleware.HashAPIKey(raw))
+	if err != nil {
+		t.Fatalf("FindByHash: %v", err)
+	}
+	if found.ID != older.ID || found.Name != older.Name || found.Hash != older.Hash || found.Hint != older.Hint ||
+		found.CreatedBy != "admin-1" || !found.CreatedAt.Equal(created) || found.RateLimit != older.RateLimit ||
+		len(found.Scopes) != 2 || found.Scopes[1] != middleware.ScopeReadResources || found.Revoked() {
+		t.Errorf("found key = %+v, want %+v", found, older)
+	}
+	if _, err := store.FindByHash(middleware.HashAPIKey("lbk_unknown")); !errors.Is(err, middleware.ErrAPIKeyNotFound) {
+		t.Errorf("FindByHash of an unknown key: error = %v, want ErrAPIKeyNotFound", err)
+	}
+
+	keys, err := store.List()
+	if err != nil || len(keys) != 2 || keys[0].ID != newer.ID || keys[1].ID != older.ID {
+		t.Errorf("List = %+v, %v; want newest first", keys, err)
+	}
+
+	revokedAt := created.Add(2 * time.Hour)
+	revoked, err := store.Revoke(older.ID, revokedAt)
+	if err != nil || !revoked.RevokedAt.Equal(revokedAt) {
+		t.Errorf("Revoke = %+v, %v", revoked, err)
+	}
+	if _, err := store.Revoke(older.ID, revokedAt.Add(time.Hour)); !errors.Is(err, middleware.ErrAPIKeyRevoked) {
+		t.Errorf("second Revoke: error = %v, want ErrAPIKeyRevoked", err)
+	}
+	if _, err := store.Revoke("unknown", revokedAt); !errors.Is(err, middleware.ErrAPIKeyNotFound) {
+		t.Errorf("Revoke of an unknown ID: error = %v, want ErrAPIKeyNotFound", err)
+	}
+	// Revoked keys are still found, so that they are reported as revoked.
+	if found, err := store.FindByHash(older.Hash); err != nil || !found.RevokedAt.Equal(revokedAt) {
+		t.Errorf("FindByHash of a revoked key = %+v, %v", found, err)
+	}
+}
+
+func TestMemoryAPIKeyStore(t *testing.T) {
+	testAPIKeyStore(t, middleware.NewMemoryAPIKeyStore())
+}
+
+// apiKeyTestServer serves the API key admin routes, the jobs routes and
+// the default proxy routes, which accept API keys, behind the request
+// logger. It returns the server, an admin token, the backends and the logs.
+func apiKeyTestServer(t *testing.T) (*httptest.Server, string, *recordingBackend, *recordingBackend, *bytes.Buffer) {
+	t.Helper()
+	jwtCfg := middleware.DefaultJWTConfig("test-secret")
+	logs := &bytes.Buffer{}
+	logger := logging.New("test-gateway", logs, logging.Config{})
+
+	jobs := newRecordingBackend(t)
+	learning := newRecordingBackend(t)
+	proxy, err := handler.NewProxyHandler(testBackends(jobs.URL, learning.URL, jobs.URL), handler.DefaultProxyRoutes(), nil)
+	if err != nil {
+		t.Fatalf("NewProxyHandler: %v", err)
+	}
+	store := middleware.NewMemoryAPIKeyStore()
+	keyAuth := middleware.NewAPIKeyAuth(store, middleware.RateLimit{})
+	t.Cleanup(keyAuth.Stop)
+	machineAuth := keyAuth.RequireAuthOrAPIKey(jwtCfg)
+
+	mux := http.NewServeMux()
+	handler.NewAPIKeysHandler(store, logger).RegisterRoutes(mux, middleware.RequireAuth(jwtCfg))
+	handler.NewJobsHandler().RegisterRoutes(mux, machineAuth)
+	proxy.RegisterRoutes(mux, machineAuth)
+	srv := httptest.NewServer(middleware.Logger(logger)(mux))
+	t.Cleanup(srv.Close)
+
+	admin, _, err := middleware.GenerateToken(jwtCfg, "admin-1", "admin@example.com",
+		[]middleware.Role{middleware.RoleAdmin}, true)
+	if err != nil {
+		t.Fatalf("generate token: %v", err)
+	}
+	return srv, admin, jobs, learning, logs
+}
+
+// createAPIKey creates a key with scopes and returns the response.
+func createAPIKey(t *testing.T, srv *httptest.Server, adminToken string, scopes ...string) types.APIKeyCreateResponse {
+	t.Helper()
+	resp := doRequest(t, srv, http.MethodPost, "/api/admin/api-keys",
+		types.APIKeyCreateRequest{Name: "partner-sync", Scopes: scopes}, adminToken)
+	if resp.StatusCode != http.StatusCreated {
+		resp.Body.Close()
+		t.Fatalf("create key: expected 201, got %d", resp.StatusCode)
+	}
+	var result struct {
+		Data types.APIKeyCreateResponse `json:"data"`
+	}
+	decodeResponse(t, resp, &result)
+	return result.Data
+}
+
+// withAPIKey performs a request authenticated by key and returns the status
+// and error code.
+func withAPIKey(t *testing.T, srv *httptest.Server, method, path, key string, body string) (int, string) {
+	t.Helper()
+	req, err := http.NewRequest(method, srv.URL+path, strings.NewReader(body))
+	if err != nil {
+		t.Fatalf("new request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(middleware.APIKeyHeader, key)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("request: %v", err)
+	}
+	var result struct {
+		Error *types.APIError `json:"error"`
+	}
+	decodeResponse(t, resp, &result)
+	if result.Error != nil {
+		return resp.StatusCode, result.Error.Code
+	}
+	return resp.StatusCode, ""
+}
+
+func TestAPIKeys_ScopeEnforcement(t *testing.T) {
+	srv, admin, jobs, learning, _ := apiKeyTestServer(t)
+	jobsKey := createAPIKey(t, srv, admin, "read:jobs")
+	progressKey := createAPIKey(t, srv, admin, "read:resources", "write:progress")
+	if !strings.HasPrefix(jobsKey.Key, middleware.APIKeyPrefix) || !strings.HasPrefix(jobsKey.Key, jobsKey.Hint) {
+		t.Fatalf("key %q, hint %q", jobsKey.Key, jobsKey.Hint)
+	}
+
+	progress := "/api/v1/learning/users/7d9e4f2a-1c3b-4e5d-8f6a-0b1c2d3e4f5a/progress"
+	tests := []struct {
+		name, method, path, key string
+		want                    int
+		code                    string
+	}{
+		{"jobs key searches jobs", http.MethodPost, "/api/jobs/search", jobsKey.Key, http.StatusOK, ""},
+		{"jobs key reads proxied jobs", http.MethodGet, "/api/v1/jobs/search?q=go", jobsKey.Key, http.StatusOK, ""},
+		{"jobs key reads resources", http.MethodGet, "/api/v1/learning/resources", jobsKey.Key, http.StatusForbidden, "INSUFFICIENT_SCOPE"},
+		{"jobs key writes progress", http.MethodPost, progress, jobsKey.Key, http.StatusForbidden, "INSUFFICIENT_SCOPE"},
+		{"jobs key writes jobs", http.MethodPost, "/api/v1/jobs/saved", jobsKey.Key, http.StatusForbidden, "INSUFFICIENT_SCOPE"},
+		{"jobs key reads saved jobs", http.MethodGet, "/api/v1/me/saved-jobs", jobsKey.Key, http.StatusForbidden, "INSUFFICIENT_SCOPE"},
+		{"progress key searches jobs", http.MethodPost, "/api/jobs/search", progressKey.Key, http.StatusForbidden, "INSUFFICIENT_SCOPE"},
+		{"progress key reads resources", http.MethodGet, "/api/v1/learning/resources", progressKey.Key, http.StatusOK, ""},
+		{"progress key writes progress", http.MethodPost, progress, progressKey.Key, http.StatusForbidden, "INSUFFICIENT_SCOPE"},
+		{"progress key writes other resources", http.MethodPost, "/api/v1/learning/paths/from-gaps", progressKey.Key, http.StatusForbidden, "INSUFFICIENT_SCOPE"},
+		{"key on analysis", http.MethodPost, "/api/v1/analysis/gap-analysis", progressKey.Key, http.StatusForbidden, "INSUFFICIENT_SCOPE"},
+		{"key on admin route", http.MethodGet, "/api/v1/learning/admin/resources", progressKey.Key, http.StatusForbidden, "INSUFFICIENT_SCOPE"},
+		{"key on key admin", http.MethodGet, "/api/admin/api-keys", progressKey.Key, http.StatusUnauthorized, "UNAUTHORIZED"},
+	}
+	for _, tt := range tests {
+		body := ""
+		if tt.method == http.MethodPost {
+			body = "{}"
+		}
+		status, code := withAPIKey(t, srv, tt.method, tt.path, tt.key, body)
+		if status != tt.want || code != tt.code {
+			t.Errorf("%s: got %d %q, want %d %q", tt.name, status, code, tt.want, tt.code)
+		}
+	}
+
+	for _, b := range []*recordingBackend{jobs, learning} {
+		last := b.last.Load()
+		if last == nil {
+			t.Fatal("expected requests to be forwarded")
+		}
+		if last.Header.Get(middleware.APIKeyHeader) != "" || last.Header.Get(handler.UserIDHeader) != "" {
+			t.Errorf("forwarded %s with X-API-Key %q and X-User-ID %q", last.URL.Path,
+				last.Header.Get(middleware.APIKeyHeader), last.Header.Get(handler.UserIDHeader))
+		}
+	}
+}
+
+func TestAPIKeys_Revocation(t *testing.T) {
+	srv, admin, _, _, _ := apiKeyTestServer(t)
+	key := createAPIKey(t, srv, admin, "read:jobs")
+	if status, _ := withAPIKey(t, srv, http.MethodPost, "/api/jobs/search", key.Key, "{}"); status != http.StatusOK {
+		t.Fatalf("before revocation: expected 200, got %d", status)
+	}
+
+	if status, _ := statusAndCode(t, srv, http.MethodDelete, "/api/admin/api-keys/"+key.ID, admin); status != http.StatusOK {
+		t.Fatalf("revoke: expected 200, got %d", status)
+	}
+	if status, code := withAPIKey(t, srv, http.MethodPost, "/api/jobs/search", key.Key, "{}"); status != http.StatusUnauthorized {
+		t.Errorf("revoked key: got %d %s, want 401", status, code)
+	}
+	if status, code := statusAndCode(t, srv, http.MethodDelete, "/api/admin/api-keys/"+key.ID, admin); status != http.StatusConflict {
+		t.Errorf("second revoke: got %d %s, want 409", status, code)
+	}
+	if status, _ := statusAndCode(t, srv, http.MethodDelete, "/api/admin/api-keys/unknown", admin); status != http.StatusNotFound {
+		t.Errorf("unknown key: expected 404, got %d", status)
+	}
+
+	resp := doRequest(t, srv, http.MethodGet, "/api/admin/api-keys", nil, admin)
+	var list struct {
+		Data []types.APIKeyInfo `json:"data"`
+	}
+	decodeResponse(t, resp, &list)
+	if len(list.Data) != 1 || list.Data[0].ID != key.ID || list.Data[0].RevokedAt == nil {
+		t.Errorf("list = %+v, want the revoked key", list.Data)
+	}
+}
+
+func TestAPIKeys_AdminOnlyAndValidation(t *testing.T) {
+	srv, admin, _, _, _ := apiKeyTestServer(t)
+	jwtCfg := middleware.DefaultJWTConfig("test-secret")
+	curator, _, _ := middleware.GenerateToken(jwtCfg, "curator-1", "curator@example.com",
+		[]middleware.Role{middleware.RoleCurator}, true)
+
+	for name, token := range map[string]string{"curator": curator, "anonymous": ""} {
+		resp := doRequest(t, srv, http.MethodPost, "/api/admin/api-keys",
+			types.APIKeyCreateRequest{Name: "x", Scopes: []string{"read:jobs"}}, token)
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusForbidden && resp.StatusCode != http.StatusUnauthorized {
+			t.Errorf("%s creating a key: got %d", name, resp.StatusCode)
+		}
+	}
+
+	invalid := []types.APIKeyCreateRequest{
+		{Scopes: []string{"read:jobs"}},
+		{Name: "no scopes"},
+		{Name: "bad scope", Scopes: []string{"write:jobs"}},
+		{Name: "half limit", Scopes: []string{"read:jobs"}, RequestsPerSecond: 2},
+	}
+	for _, req := range invalid {
+		resp := doRequest(t, srv, http.MethodPost, "/api/admin/api-keys", req, admin)
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusUnprocessableEntity {
+			t.Errorf("%+v: expected 422, got %d", req, resp.StatusCode)
+		}
+	}
+}
+
+func TestAPIKeys_KeyNeverLogged(t *testing.T) {
+	srv, admin, _, _, logs := apiKeyTestServer(t)
+	key := createAPIKey(t, srv, admin, "read:jobs")
+	withAPIKey(t, srv, http.MethodPost, "/api/jobs/search", key.Key, "{}")
+	withAPIKey(t, srv, http.MethodGet, "/api/v1/jobs/"+key.Key, key.Key, "")
+	withAPIKey(t, srv, http.MethodGet, "/api/v1/jobs/search", key.Key+"x", "")
+	statusAndCode(t, srv, http.MethodDelete, "/api/admin/api-keys/"+key.ID, admin)
+
+	out := logs.String()
+	if !strings.Contains(out, "api key created") || !strings.Contains(out, key.ID) {
+		t.Fatalf("expected the key's creation to be logged by ID: %s", out)
+	}
+	if strings.Contains(out, key.Key[len(middleware.APIKeyPrefix):]) {
+		t.Errorf("API key in logs: %s", out)
+	}
+}
@@ -2,85 +2,70 @@
 package handler
 
 import (
+	"context"
 	"crypto/rand"
+	"crypto/subtle"
 	"encoding/hex"
+	"errors"
+	"log/slog"
 	"net/http"
+	"net/url"
+	"strconv"
 	"strings"
-	"sync"
 	"time"
 
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/learnbot/api-gateway/internal/mail"
 	"github.com/learnbot/api-gateway/internal/middleware"
 	"github.com/learnbot/api-gateway/internal/types"
+	"github.com/learnbot/shared/openapi"
+	"github.com/learnbot/shared/validation"
 )
 
 // ─────────────────────────────────────────────────────────────────────────────
-// In-memory user store (MVP – replace with database in production)
+// IDs and password hashes
 // ─────────────────────────────────────────────────────────────────────────────
 
-// userRecord stores a user in the in-memory store.
-type userRecord struct {
-	ID           string
-	Email        string
-	PasswordHash string // bcrypt hash (simplified: SHA-256 hex for MVP)
-	FullName     string
-	IsAdmin      bool
-	CreatedAt    time.Time
-}
-
-// userStore is a thread-safe in-memory user store.
-type userStore struct {
-	mu    sync.RWMutex
-	users map[string]*userRecord // keyed by email (lowercase)
-	byID  map[string]*userRecord // keyed by ID
-}
-
-var globalUserStore = &userStore{
-	users: make(map[string]*userRecord),
-	byID:  make(map[string]*userRecord),
+// generateID generates a random hex ID.
+func generateID() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	return hex.EncodeToString(b)
 }
 
-func (s *userStore) create(email, passwordHash, fullName string) *userRecord {
-	s.mu.Lock()
-	defer s.mu.Unlock()
-	id := generateID()
-	u := &userRecord{
-		ID:           id,
-		Email:        strings.ToLower(strings.TrimSpace(email)),
-		PasswordHash: passwordHash,
-		FullName:     fullName,
-		CreatedAt:    time.Now(),
-	}
-	s.users[u.Email] = u
-	s.byID[id] = u
-	return u
-}
+// bcryptCost is the work factor of new password hashes. Hashes of a lower
+// cost are replaced on the next successful login.
+var bcryptCost = bcrypt.DefaultCost
 
-func (s *userStore) findByEmail(email string) (*userRecord, bool) {
-	s.mu.RLock()
-	defer s.mu.RUnlock()
-	u, ok := s.users[strings.ToLower(strings.TrimSpace(email))]
-	return u, ok
-}
+// maxPasswordBytes is the longest password bcrypt can hash.
+const maxPasswordBytes = 72
 
-func (s *userStore) findByID(id string) (*userRecord, bool) {
-	s.mu.RLock()
-	defer s.mu.RUnlock()
-	u, ok := s.byID[id]
-	return u, ok
+// hashPassword hashes a password with bcrypt.
+func hashPassword(password string) (string, error) {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcryptCost)
+	return string(hash), err
 }
 
-// generateID generates a random hex ID.
-func generateID() string {
-	b := make([]byte, 16)
-	rand.Read(b)
-	return hex.EncodeToString(b)
+// checkPassword reports whether password matches hash, and whether hash
+// should be replaced by a new hashPassword: it is a legacy hash or has a
+// lower cost than bcryptCost.
+func checkPassword(password, hash string) (ok, rehash bool) {
+	if !strings.HasPrefix(hash, "$2") {
+		legacy := legacyPasswordHash(password)
+		return subtle.ConstantTimeCompare([]byte(legacy), []byte(hash)) == 1, true
+	}
+	if bcrypt.CompareHashAndPassword([]byte(hash), []byte(password)) != nil {
+		return false, false
+	}
+	cost, err := bcrypt.Cost([]byte(hash))
+	return true, err != nil || cost < bcryptCost
 }
 
-// hashPassword creates a simple hash for the MVP.
-// In production, use bcrypt.
-func hashPassword(password string) string {
-	// Simple deterministic hash for MVP testing.
-	// Production: use golang.org/x/crypto/bcrypt
+// legacyPasswordHash is the 32-bit rolling hash that accounts registered
+// before the switch to bcrypt still hold. It is only checked on login,
+// which then replaces it.
+func legacyPasswordHash(password string) string {
 	h := 0
 	for _, c := range password {
 		h = h*31 + int(c)
@@ -91,8 +76,15 @@ func hashPassword(password string) string {
 	})
 }
 
-func checkPassword(password, hash string) bool {
-	return hashPassword(password) == hash
+// validatePassword rejects new passwords that bcrypt cannot hash. The
+// length rule of the request's tag counts characters, not bytes.
+func validatePassword(w http.ResponseWriter, password string) bool {
+	if len(password) <= maxPasswordBytes {
+		return true
+	}
+	var v Validator
+	v.add("password", validation.CodeMaxLength, "must be at most 72 bytes")
+	return !v.WriteIfInvalid(w)
 }
 
 // ─────────────────────────────────────────────────────────────────────────────
@@ -101,21 +93,203 @@ func checkPassword(password, hash string) bool {
 
 // AuthHandler handles authentication endpoints.
 type AuthHandler struct {
-	jwtCfg middleware.JWTConfig
+	jwtCfg   middleware.JWTConfig
+	users    UserStore
+	refresh  middleware.RefreshTokenStore
+	throttle *middleware.LoginThrottle
+	reset    PasswordResetConfig
+	verifier *EmailVerifier
 }
 
-// NewAuthHandler creates a new AuthHandler.
+// PasswordResetConfig configures the password reset flow. Zero fields fall
+// back to an in-memory store, a log-only sender, DefaultPasswordResetDuration
+// and slog.Default.
+type PasswordResetConfig struct {
+	// Store keeps the hashes of issued reset tokens.
+	Store middleware.PasswordResetStore
+
+	// Sender delivers the reset emails.
+	Sender mail.Sender
+
+	// TokenDuration is how long a reset token is valid.
+	TokenDuration time.Duration
+
+	// URL is the page that accepts a reset token, such as
+	// "https://learnbot.example/reset-password". The token is added as the
+	// "token" query parameter; if URL is empty the email holds the token
+	// alone.
+	URL string
+
+	// Logger receives delivery failures, and failures to replace the
+	// password hashes of users logging in.
+	Logger *slog.Logger
+}
+
+// NewAuthHandler creates a new AuthHandler that keeps refresh tokens in
+// memory.
 func NewAuthHandler(jwtCfg middleware.JWTConfig) *AuthHandler {
-	return &AuthHandler{jwtCfg: jwtCfg}
+	return NewAuthHandlerWithStore(jwtCfg, middleware.NewMemoryRefreshTokenStore())
+}
+
+// NewAuthHandlerWithStore creates a new AuthHandler that keeps refresh
+// tokens in store. Accounts are kept in memory until SetUserStore replaces
+// the store, and failed logins are throttled with DefaultLockoutConfig until
+// SetLoginThrottle replaces it.
+func NewAuthHandlerWithStore(jwtCfg middleware.JWTConfig, store middleware.RefreshTokenStore) *AuthHandler {
+	h := &AuthHandler{
+		jwtCfg:   jwtCfg,
+		users:    defaultUserStore,
+		refresh:  store,
+		throttle: middleware.NewLoginThrottle(middleware.DefaultLockoutConfig(), nil, nil),
+		verifier: NewEmailVerifier(jwtCfg, EmailVerificationConfig{}),
+	}
+	h.SetPasswordReset(PasswordResetConfig{})
+	return h
+}
+
+// SetUserStore replaces the store of user accounts. Give the other handlers
+// the same store.
+func (h *AuthHandler) SetUserStore(store UserStore) {
+	h.users = store
+}
+
+// SetEmailVerifier replaces the verifier that sends and checks email
+// verification links. Share it with ProfileHandler.SetEmailVerifier so that
+// resends are throttled together with the link sent on registration.
+func (h *AuthHandler) SetEmailVerifier(v *EmailVerifier) {
+	h.verifier = v
+}
+
+// SetPasswordReset configures the password reset flow.
+func (h *AuthHandler) SetPasswordReset(cfg PasswordResetConfig) {
+	if cfg.Store == nil {
+		cfg.Store = middleware.NewMemoryPasswordResetStore()
+	}
+	if cfg.Logger == nil {
+		cfg.Logger = slog.Default()
+	}
+	if cfg.Sender == nil {
+		cfg.Sender = mail.NewLogSender(cfg.Logger)
+	}
+	if cfg.TokenDuration <= 0 {
+		cfg.TokenDuration = middleware.DefaultPasswordResetDuration
+	}
+	h.reset = cfg
+}
+
+// SetLoginThrottle sets the throttle that locks accounts after repeated
+// failed logins.
+func (h *AuthHandler) SetLoginThrottle(t *middleware.LoginThrottle) {
+	h.throttle = t
 }
 
 // RegisterRoutes registers auth routes on the mux.
 //
 //	POST /api/auth/register  – register a new user
 //	POST /api/auth/login     – login and get JWT token
-func (h *AuthHandler) RegisterRoutes(mux *http.ServeMux) {
+//	POST /api/auth/refresh   – exchange a refresh token for new tokens
+//	POST /api/auth/logout    – revoke a refresh token
+//	POST /api/auth/forgot-password – email a password reset link
+//	POST /api/auth/reset-password  – set a new password with a reset token
+//	GET  /api/auth/verify          – verify an email with a verification link
+func (h *AuthHandler) RegisterRoutes(mux openapi.Router) {
 	mux.HandleFunc("/api/auth/register", h.Register)
 	mux.HandleFunc("/api/auth/login", h.Login)
+	mux.HandleFunc("/api/auth/refresh", h.Refresh)
+	mux.HandleFunc("/api/auth/logout", h.Logout)
+	mux.HandleFunc("/api/auth/forgot-password", h.ForgotPassword)
+	mux.HandleFunc("/api/auth/reset-password", h.ResetPassword)
+	mux.HandleFunc("/api/auth/verify", h.VerifyEmail)
+}
+
+// DescribeRoutes documents the routes registered by RegisterRoutes.
+func (h *AuthHandler) DescribeRoutes(spec *openapi.Spec) {
+	spec.Route("/api/auth/register", openapi.Operation{
+		Method:  http.MethodPost,
+		Summary: "Create an account and sign in",
+		Description: "Sends an email verification link. Until the email is verified, access tokens " +
+			"carry the email_unverified claim and route groups that require verification answer 403.",
+		Request:  types.RegisterRequest{},
+		Response: success(types.AuthResponse{}),
+		Status:   http.StatusCreated,
+		Errors:   []int{http.StatusBadRequest, http.StatusConflict},
+	})
+	spec.Route("/api/auth/login", openapi.Operation{
+		Method:  http.MethodPost,
+		Summary: "Sign in with email and password",
+		Description: "After repeated failed logins the account is locked for a cooldown, during which " +
+			"logins are answered with 429 and a Retry-After header, whether or not the email exists.",
+		Request:  types.LoginRequest{},
+		Response: success(types.AuthResponse{}),
+		Errors:   []int{http.StatusBadRequest, http.StatusUnauthorized, http.StatusTooManyRequests},
+	})
+	spec.Route("/api/auth/refresh", openapi.Operation{
+		Method:      http.MethodPost,
+		Summary:     "Exchange a refresh token for new tokens",
+		Description: "Refresh tokens are single use; reusing one revokes every token issued from the same sign-in.",
+		Request:     types.RefreshRequest{},
+		Response:    success(types.AuthResponse{}),
+		Errors:      []int{http.StatusBadRequest, http.StatusUnauthorized},
+	})
+	spec.Route("/api/auth/logout", openapi.Operation{
+		Method:   http.MethodPost,
+		Summary:  "Revoke a refresh token and the tokens issued with it",
+		Request:  types.RefreshRequest{},
+		Response: success(openapi.Fields{"message": ""}),
+		Errors:   []int{http.StatusBadRequest},
+	})
+	spec.Route("/api/auth/forgot-password", openapi.Operation{
+		Method:  http.MethodPost,
+		Summary: "Email a password reset link",
+		Description: "The response is 202 whether or not an account exists for the email. " +
+			"The link's token is valid for 30 minutes and works once.",
+		Request:  types.ForgotPasswordRequest{},
+		Response: success(openapi.Fields{"message": ""}),
+		Status:   http.StatusAccepted,
+		Errors:   []int{http.StatusBadRequest},
+	})
+	spec.Route("/api/auth/reset-password", openapi.Operation{
+		Method:      http.MethodPost,
+		Summary:     "Set a new password with a reset token",
+		Description: "The new password follows the rules of registration. Using a token invalidates every other reset token of the account.",
+		Request:     types.ResetPasswordRequest{},
+		Response:    success(openapi.Fields{"message": ""}),
+		Errors:      []int{http.StatusBadRequest},
+	})
+	spec.Route("/api/auth/verify", openapi.Operation{
+		Method:      http.MethodGet,
+		Summary:     "Verify an email address",
+		Description: "Target of the link in verification emails; links are valid for 24 hours. Refresh the access token afterwards to drop the email_unverified claim.",
+		Params:      []openapi.Param{{Name: "token", Required: true, Description: "Token from the verification email"}},
+		Response:    success(openapi.Fields{"message": ""}),
+		Errors:      []int{http.StatusBadRequest},
+	})
+}
+
+// issueTokens creates an access token and a refresh token for user. The
+// refresh token joins familyID, or starts a new family if it is empty.
+func (h *AuthHandler) issueTokens(user *User, familyID string) (types.AuthResponse, error) {
+	token, expiresAt, err := middleware.GenerateToken(h.jwtCfg, user.ID, user.Email, user.Roles, user.Verified)
+	if err != nil {
+		return types.AuthResponse{}, err
+	}
+	refreshToken, refreshExpiresAt, err := middleware.IssueRefreshToken(h.jwtCfg, h.refresh, user.ID, familyID)
+	if err != nil {
+		return types.AuthResponse{}, err
+	}
+	return types.AuthResponse{
+		Token:            token,
+		ExpiresAt:        expiresAt,
+		RefreshToken:     refreshToken,
+		RefreshExpiresAt: refreshExpiresAt,
+		User: types.UserInfo{
+			ID:            user.ID,
+			Email:         user.Email,
+			FullName:      user.FullName,
+			EmailVerified: user.Verified,
+			Roles:         roleNames(user.Roles),
+		},
+	}, nil
 }
 
 // Register handles POST /api/auth/register.
@@ -133,52 +307,55 @@ func (h *AuthHandler) Register(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// DecodeJSON checks the rules in RegisterRequest's tags.
 	var req types.RegisterRequest
 	if !DecodeJSON(w, r, &req) {
 		return
 	}
 
-	// Validate input.
-	var v Validator
-	v.Required("email", req.Email, "email is required")
-	v.ValidEmail("email", req.Email)
-	v.Required("password", req.Password, "password is required")
-	v.MinLength("password", req.Password, 8, "password must be at least 8 characters")
-	v.Required("full_name", req.FullName, "full_name is required")
-	if v.WriteIfInvalid(w) {
+	if !validatePassword(w, req.Password) {
 		return
 	}
 
-	// Check if email already exists.
-	if _, exists := globalUserStore.findByEmail(req.Email); exists {
+	// Create user; the store rejects emails that are already registered.
+	hash, err := hashPassword(req.Password)
+	if err != nil {
+		WriteInternalError(w)
+		return
+	}
+	user, err := h.users.CreateUser(r.Context(), req.Email, hash, req.FullName)
+	if errors.Is(err, ErrEmailTaken) {
 		WriteError(w, http.StatusConflict, "EMAIL_TAKEN",
 			"an account with this email already exists")
 		return
 	}
+	if err != nil {
+		WriteInternalError(w)
+		return
+	}
 
-	// Create user.
-	hash := hashPassword(req.Password)
-	user := globalUserStore.create(req.Email, hash, req.FullName)
+	// The account is usable right away; its tokens carry the unverified
+	// flag until the email is verified.
+	h.verifier.send(r.Context(), user)
 
-	// Generate token.
-	token, expiresAt, err := middleware.GenerateToken(h.jwtCfg, user.ID, user.Email, user.IsAdmin)
+	// Generate tokens.
+	resp, err := h.issueTokens(user, "")
 	if err != nil {
 		WriteInternalError(w)
 		return
 	}
 
-	WriteSuccess(w, http.StatusCreated, types.AuthResponse{
-		Token:     token,
-		ExpiresAt: expiresAt,
-		User: types.UserInfo{
-			ID:       user.ID,
-			Email:    user.Email,
-			FullName: user.FullName,
-		},
-	})
+	WriteSuccess(w, http.StatusCreated, resp)
+}
+
+// VerifyEmail handles GET /api/auth/verify; see EmailVerifier.Verify.
+func (h *AuthHandler) VerifyEmail(w http.ResponseWriter, r *http.Request) {
+	h.verifier.Verify(w, r, h.users)
 }
 
-// Login handles POST /api/auth/login.
+// Login handles POST /api/auth/login. Failed logins are counted per email,
+// registered or not, and lock the email out for a cooldown once the
+// throttle's limit is reached; a successful login resets the count.
 //
 // Request body:
 //
@@ -205,28 +382,311 @@ func (h *AuthHandler) Login(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	account := strings.ToLower(strings.TrimSpace(req.Email))
+	if wait := h.throttle.Locked(r.Context(), account); wait > 0 {
+		writeLockedOut(w, wait)
+		return
+	}
+
 	// Find user.
-	user, exists := globalUserStore.findByEmail(req.Email)
-	if !exists || !checkPassword(req.Password, user.PasswordHash) {
+	user, err := h.users.GetByEmail(r.Context(), req.Email)
+	if err != nil && !errors.Is(err, ErrUserNotFound) {
+		WriteInternalError(w)
+		return
+	}
+	var ok, rehash bool
+	if err == nil {
+		ok, rehash = checkPassword(req.Password, user.PasswordHash)
+	}
+	if !ok {
+		if wait := h.throttle.Failure(r.Context(), account); wait > 0 {
+			writeLockedOut(w, wait)
+			return
+		}
 		WriteError(w, http.StatusUnauthorized, "INVALID_CREDENTIALS",
 			"invalid email or password")
 		return
 	}
+	h.throttle.Success(r.Context(), account)
+	if rehash {
+		h.rehashPassword(r.Context(), user, req.Password)
+	}
 
-	// Generate token.
-	token, expiresAt, err := middleware.GenerateToken(h.jwtCfg, user.ID, user.Email, user.IsAdmin)
+	// Generate tokens.
+	resp, err := h.issueTokens(user, "")
 	if err != nil {
 		WriteInternalError(w)
 		return
 	}
 
-	WriteSuccess(w, http.StatusOK, types.AuthResponse{
-		Token:     token,
-		ExpiresAt: expiresAt,
-		User: types.UserInfo{
-			ID:       user.ID,
-			Email:    user.Email,
-			FullName: user.FullName,
-		},
+	WriteSuccess(w, http.StatusOK, resp)
+}
+
+// rehashPassword replaces a legacy or outdated hash of the user's password
+// after a successful login. A failure is logged and leaves the old hash,
+// which still works.
+func (h *AuthHandler) rehashPassword(ctx context.Context, user *User, password string) {
+	hash, err := hashPassword(password)
+	if err == nil {
+		_, err = h.users.UpdatePassword(ctx, user.ID, hash)
+	}
+	if err != nil {
+		h.reset.Logger.ErrorContext(ctx, "password rehash failed", "user_id", user.ID, "error", err)
+	}
+}
+
+// writeLockedOut answers a login for a locked account. The response is the
+// same whether or not the account exists.
+func writeLockedOut(w http.ResponseWriter, wait time.Duration) {
+	w.Header().Set("Retry-After", strconv.Itoa(max(1, int((wait+time.Second-1)/time.Second))))
+	WriteError(w, http.StatusTooManyRequests, "TOO_MANY_LOGIN_ATTEMPTS",
+		"too many failed login attempts, please try again later")
+}
+
+// Refresh handles POST /api/auth/refresh. The access token may already have
+// expired; the refresh token alone authenticates the request. Each refresh
+// token is accepted once and replaced by the one in the response.
+//
+// Request body:
+//
+//	{"refresh_token": "..."}
+//
+// Response:
+//
+//	{"success": true, "data": {"token": "...", "expires_at": "...", "refresh_token": "...", "refresh_expires_at": "...", "user": {...}}}
+func (h *AuthHandler) Refresh(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		WriteMethodNotAllowed(w)
+		return
+	}
+
+	var req types.RefreshRequest
+	if !DecodeJSON(w, r, &req) {
+		return
+	}
+
+	var v Validator
+	v.Required("refresh_token", req.RefreshToken, "refresh_token is required")
+	if v.WriteIfInvalid(w) {
+		return
+	}
+
+	old, err := h.refresh.Consume(middleware.HashRefreshToken(req.RefreshToken), time.Now())
+	switch {
+	case errors.Is(err, middleware.ErrRefreshTokenExpired):
+		WriteError(w, http.StatusUnauthorized, "REFRESH_TOKEN_EXPIRED", "refresh token has expired")
+		return
+	case err != nil:
+		WriteError(w, http.StatusUnauthorized, "INVALID_REFRESH_TOKEN", "invalid refresh token")
+		return
+	}
+
+	user, err := h.users.GetByID(r.Context(), old.UserID)
+	if err != nil && !errors.Is(err, ErrUserNotFound) {
+		WriteInternalError(w)
+		return
+	}
+	if err != nil {
+		_ = h.refresh.RevokeFamily(old.FamilyID)
+		WriteError(w, http.StatusUnauthorized, "INVALID_REFRESH_TOKEN", "invalid refresh token")
+		return
+	}
+
+	resp, err := h.issueTokens(user, old.FamilyID)
+	if err != nil {
+		WriteInternalError(w)
+		return
+	}
+
+	WriteSuccess(w, http.StatusOK, resp)
+}
+
+// Logout handles POST /api/auth/logout. It revokes the refresh token and
+// every token rotated from the same login. Unknown tokens are ignored, so
+// logging out twice succeeds. Access tokens stay valid until they expire.
+//
+// Request body:
+//
+//	{"refresh_token": "..."}
+//
+// Response:
+//
+//	{"success": true, "data": {"message": "logged out"}}
+func (h *AuthHandler) Logout(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		WriteMethodNotAllowed(w)
+		return
+	}
+
+	var req types.RefreshRequest
+	if !DecodeJSON(w, r, &req) {
+		return
+	}
+
+	var v Validator
+	v.Required("refresh_token", req.RefreshToken, "refresh_token is required")
+	if v.WriteIfInvalid(w) {
+		return
+	}
+
+	if token, err := h.refresh.Lookup(middleware.HashRefreshToken(req.RefreshToken)); err == nil {
+		if err := h.refresh.RevokeFamily(token.FamilyID); err != nil {
+			WriteInternalError(w)
+			return
+		}
+	}
+
+	WriteSuccess(w, http.StatusOK, map[string]string{"message": "logged out"})
+}
+
+// RevokeSessions ends every session of the user: their refresh tokens are
+// revoked and the access tokens issued to them so far are rejected.
+func (h *AuthHandler) RevokeSessions(userID string) error {
+	if err := h.refresh.RevokeUser(userID); err != nil {
+		return err
+	}
+	h.jwtCfg.RevokeTokens(userID)
+	return nil
+}
+
+// ForgotPassword handles POST /api/auth/forgot-password. If an account
+// exists for the email, a single-use reset token is stored (hashed) and
+// emailed to it. The response is the same either way, so it does not tell
+// whether the account exists.
+//
+// Request body:
+//
+//	{"email": "user@example.com"}
+//
+// Response (202 Accepted):
+//
+//	{"success": true, "data": {"message": "if an account exists for this email, a password reset link has been sent"}}
+func (h *AuthHandler) ForgotPassword(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		WriteMethodNotAllowed(w)
+		return
+	}
+
+	var req types.ForgotPasswordRequest
+	if !DecodeJSON(w, r, &req) {
+		return
+	}
+
+	var v Validator
+	v.Required("email", req.Email, "email is required")
+	v.ValidEmail("email", req.Email)
+	if v.WriteIfInvalid(w) {
+		return
+	}
+
+	// A token is generated and hashed for unknown emails too, so that both
+	// paths take about as long. The email is sent after the response.
+	user, err := h.users.GetByEmail(r.Context(), req.Email)
+	if err != nil && !errors.Is(err, ErrUserNotFound) {
+		WriteInternalError(w)
+		return
+	}
+	exists := err == nil
+	token, hash, err := middleware.NewPasswordResetToken()
+	if err != nil {
+		WriteInternalError(w)
+		return
+	}
+	if exists {
+		expiresAt := time.Now().Add(h.reset.TokenDuration)
+		err := h.reset.Store.Save(middleware.PasswordResetToken{Hash: hash, UserID: user.ID, ExpiresAt: expiresAt})
+		if err != nil {
+			WriteInternalError(w)
+			return
+		}
+		go h.sendResetEmail(context.WithoutCancel(r.Context()), user.Email, token)
+	}
+
+	WriteSuccess(w, http.StatusAccepted, map[string]string{
+		"message": "if an account exists for this email, a password reset link has been sent",
 	})
 }
+
+// resetEmailTimeout bounds the delivery of one password reset email.
+const resetEmailTimeout = 30 * time.Second
+
+// sendResetEmail emails a reset token to the account's address.
+func (h *AuthHandler) sendResetEmail(ctx context.Context, to, token string) {
+	ctx, cancel := context.WithTimeout(ctx, resetEmailTimeout)
+	defer cancel()
+
+	link := token
+	if h.reset.URL != "" {
+		sep := "?"
+		if strings.Contains(h.reset.URL, "?") {
+			sep = "&"
+		}
+		link = h.reset.URL + sep + "token=" + url.QueryEscape(token)
+	}
+	msg := mail.Message{
+		To:      to,
+		Subject: "Reset your LearnBot password",
+		Body: "Someone asked to reset the password of your LearnBot account.\n\n" +
+			"Use this to choose a new password within " + h.reset.TokenDuration.String() + ":\n\n" +
+			link + "\n\n" +
+			"If it was not you, ignore this email and your password stays the same.\n",
+	}
+	if err := h.reset.Sender.Send(ctx, msg); err != nil {
+		h.reset.Logger.ErrorContext(ctx, "password reset email failed", "error", err)
+	}
+}
+
+// ResetPassword handles POST /api/auth/reset-password. The token is
+// accepted once; using it invalidates every other reset token of the
+// account.
+//
+// Request body:
+//
+//	{"token": "...", "password": "new-secret123"}
+//
+// Response:
+//
+//	{"success": true, "data": {"message": "password has been reset"}}
+func (h *AuthHandler) ResetPassword(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		WriteMethodNotAllowed(w)
+		return
+	}
+
+	var req types.ResetPasswordRequest
+	if !DecodeJSON(w, r, &req) {
+		return
+	}
+	if !validatePassword(w, req.Password) {
+		return
+	}
+
+	token, err := h.reset.Store.Consume(middleware.HashPasswordResetToken(req.Token), time.Now())
+	switch {
+	case errors.Is(err, middleware.ErrResetTokenExpired):
+		WriteError(w, http.StatusBadRequest, "RESET_TOKEN_EXPIRED", "password reset token has expired")
+		return
+	case err != nil:
+		WriteError(w, http.StatusBadRequest, "INVALID_RESET_TOKEN", "invalid password reset token")
+		return
+	}
+
+	hash, err := hashPassword(req.Password)
+	if err != nil {
+		WriteInternalError(w)
+		return
+	}
+	user, err := h.users.UpdatePassword(r.Context(), token.UserID, hash)
+	if errors.Is(err, ErrUserNotFound) {
+		WriteError(w, http.StatusBadRequest, "INVALID_RESET_TOKEN", "invalid password reset token")
+		return
+	}
+	if err != nil {
+		WriteInternalError(w)
+		return
+	}
+	// Failed logins before the reset no longer count toward a lockout.
+	h.throttle.Success(r.Context(), user.Email)
+
+	WriteSuccess(w, http.StatusOK, map[string]string{"message": "password has been reset"})
+}
@@ -0,0 +1,465 @@
+package handler_test
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/learnbot/api-gateway/internal/handler"
+	"github.com/learnbot/api-gateway/internal/mail"
+	"github.com/learnbot/api-gateway/internal/middleware"
+	"github.com/learnbot/api-gateway/internal/types"
+)
+
+// authTestServer serves the auth routes plus the authenticated profile
+// endpoint with the given JWT configuration.
+func authTestServer(t *testing.T, jwtCfg middleware.JWTConfig) *httptest.Server {
+	t.Helper()
+	mux := http.NewServeMux()
+	handler.NewAuthHandler(jwtCfg).RegisterRoutes(mux)
+	handler.NewProfileHandler(jwtCfg).RegisterRoutes(mux, middleware.RequireAuth(jwtCfg))
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+// registerForTokens registers a user and returns the auth response.
+func registerForTokens(t *testing.T, srv *httptest.Server, email string) types.AuthResponse {
+	t.Helper()
+	resp := doRequest(t, srv, http.MethodPost, "/api/auth/register", types.RegisterRequest{
+		Email:    email,
+		Password: "password123",
+		FullName: "Refresh User",
+	}, "")
+	if resp.StatusCode != http.StatusCreated {
+		t.Fatalf("register: expected 201, got %d", resp.StatusCode)
+	}
+	var result struct {
+		Data types.AuthResponse `json:"data"`
+	}
+	decodeResponse(t, resp, &result)
+	if result.Data.RefreshToken == "" {
+		t.Fatal("expected a refresh token on registration")
+	}
+	return result.Data
+}
+
+// refresh exchanges a refresh token and returns the status, error code and
+// new tokens.
+func refresh(t *testing.T, srv *httptest.Server, token string) (int, string, types.AuthResponse) {
+	t.Helper()
+	resp := doRequest(t, srv, http.MethodPost, "/api/auth/refresh", types.RefreshRequest{RefreshToken: token}, "")
+	var result struct {
+		Data  types.AuthResponse `json:"data"`
+		Error *types.APIError    `json:"error"`
+	}
+	decodeResponse(t, resp, &result)
+	code := ""
+	if result.Error != nil {
+		code = result.Error.Code
+	}
+	return resp.StatusCode, code, result.Data
+}
+
+func TestRefresh_ExpiredAccessToken(t *testing.T) {
+	cfg := middleware.DefaultJWTConfig("test-secret")
+	cfg.TokenDuration = -time.Minute
+	srv := authTestServer(t, cfg)
+
+	auth := registerForTokens(t, srv, "refresh-expired-access@example.com")
+	if resp := doRequest(t, srv, http.MethodGet, "/api/users/profile", nil, auth.Token); resp.StatusCode != http.StatusUnauthorized {
+		t.Fatalf("expected the expired access token to be rejected, got %d", resp.StatusCode)
+	}
+
+	status, _, rotated := refresh(t, srv, auth.RefreshToken)
+	if status != http.StatusOK {
+		t.Fatalf("expected 200, got %d", status)
+	}
+	if rotated.Token == "" || rotated.RefreshToken == "" || rotated.RefreshToken == auth.RefreshToken {
+		t.Errorf("expected a new access token and a rotated refresh token, got %+v", rotated)
+	}
+	if rotated.User.Email != "refresh-expired-access@example.com" {
+		t.Errorf("unexpected user: %+v", rotated.User)
+	}
+}
+
+func TestRefresh_RotatedTokenReuseRejected(t *testing.T) {
+	srv := authTestServer(t, middleware.DefaultJWTConfig("test-secret"))
+	auth := registerForTokens(t, srv, "refresh-reuse@example.com")
+
+	status, _, rotated := refresh(t, srv, auth.RefreshToken)
+	if status != http.StatusOK {
+		t.Fatalf("first refresh: expected 200, got %d", status)
+	}
+
+	if status, code, _ := refresh(t, srv, auth.RefreshToken); status != http.StatusUnauthorized || code != "INVALID_REFRESH_TOKEN" {
+		t.Errorf("reused token: expected 401 INVALID_REFRESH_TOKEN, got %d %q", status, code)
+	}
+	// Reuse revokes the whole family, including the token issued in
+	// exchange for the reused one.
+	if status, _, _ := refresh(t, srv, rotated.RefreshToken); status != http.StatusUnauthorized {
+		t.Errorf("expected the rotated token to be revoked after reuse, got %d", status)
+	}
+}
+
+func TestRefresh_ExpiredRefreshToken(t *testing.T) {
+	cfg := middleware.DefaultJWTConfig("test-secret")
+	cfg.RefreshTokenDuration = -time.Second
+	srv := authTestServer(t, cfg)
+
+	auth := registerForTokens(t, srv, "refresh-expired@example.com")
+	if status, code, _ := refresh(t, srv, auth.RefreshToken); status != http.StatusUnauthorized || code != "REFRESH_TOKEN_EXPIRED" {
+		t.Errorf("expected 401 REFRESH_TOKEN_EXPIRED, got %d %q", status, code)
+	}
+}
+
+func TestRefresh_InvalidRequests(t *testing.T) {
+	srv := authTestServer(t, middleware.DefaultJWTConfig("test-secret"))
+
+	if status, code, _ := refresh(t, srv, "not-a-token"); status != http.StatusUnauthorized || code != "INVALID_REFRESH_TOKEN" {
+		t.Errorf("unknown token: expected 401 INVALID_REFRESH_TOKEN, got %d %q", status, code)
+	}
+	if resp := doRequest(t, srv, http.MethodPost, "/api/auth/refresh", types.RefreshRequest{}, ""); resp.StatusCode != http.StatusUnprocessableEntity {
+		t.Errorf("missing token: expected 422, got %d", resp.StatusCode)
+	}
+	if resp := doRequest(t, srv, http.MethodGet, "/api/auth/refresh", nil, ""); resp.StatusCode != http.StatusMethodNotAllowed {
+		t.Errorf("GET: expected 405, got %d", resp.StatusCode)
+	}
+}
+
+func TestLogout_RevokesRefreshTokens(t *testing.T) {
+	srv := authTestServer(t, middleware.DefaultJWTConfig("test-secret"))
+	auth := registerForTokens(t, srv, "logout@example.com")
+
+	_, _, rotated := refresh(t, srv, auth.RefreshToken)
+	for i := 0; i < 2; i++ {
+		resp := doRequest(t, srv, http.MethodPost, "/api/auth/logout", types.RefreshRequest{RefreshToken: rotated.RefreshToken}, "")
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("logout %d: expected 200, got %d", i+1, resp.StatusCode)
+		}
+	}
+
+	if status, _, _ := refresh(t, srv, rotated.RefreshToken); status != http.StatusUnauthorized {
+		t.Errorf("expected the refresh token to be revoked by logout, got %d", status)
+	}
+}
+
+// login posts credentials and returns the status, error code and
+// Retry-After header of the response.
+func login(t *testing.T, srv *httptest.Server, email, password string) (int, string, string) {
+	t.Helper()
+	resp := doRequest(t, srv, http.MethodPost, "/api/auth/login", types.LoginRequest{Email: email, Password: password}, "")
+	var result struct {
+		Error *types.APIError `json:"error"`
+	}
+	decodeResponse(t, resp, &result)
+	code := ""
+	if result.Error != nil {
+		code = result.Error.Code
+	}
+	return resp.StatusCode, code, resp.Header.Get("Retry-After")
+}
+
+// lockoutTestServer serves the auth routes with a throttle that locks an
+// account for a minute after three failed logins.
+func lockoutTestServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	jwtCfg := middleware.DefaultJWTConfig("test-secret")
+	h := handler.NewAuthHandler(jwtCfg)
+	h.SetLoginThrottle(middleware.NewLoginThrottle(middleware.LockoutConfig{MaxFailures: 3, Cooldown: time.Minute},
+		nil, slog.New(slog.NewTextHandler(io.Discard, nil))))
+	mux := http.NewServeMux()
+	h.RegisterRoutes(mux)
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestLogin_LockoutAfterRepeatedFailures(t *testing.T) {
+	srv := lockoutTestServer(t)
+	registerForTokens(t, srv, "lockout@example.com")
+
+	for i := 1; i < 3; i++ {
+		if status, code, _ := login(t, srv, "lockout@example.com", "wrong-password"); status != http.StatusUnauthorized || code != "INVALID_CREDENTIALS" {
+			t.Fatalf("failure %d: expected 401 INVALID_CREDENTIALS, got %d %q", i, status, code)
+		}
+	}
+	status, code, retryAfter := login(t, srv, "LOCKOUT@example.com ", "wrong-password")
+	if status != http.StatusTooManyRequests || code != "TOO_MANY_LOGIN_ATTEMPTS" || retryAfter != "60" {
+		t.Fatalf("third failure: expected 429 with Retry-After 60, got %d %q %q", status, code, retryAfter)
+	}
+	// The right password is rejected too while the account is locked.
+	if status, _, retryAfter := login(t, srv, "lockout@example.com", "password123"); status != http.StatusTooManyRequests || retryAfter == "" {
+		t.Errorf("correct password during lockout: expected 429 with Retry-After, got %d %q", status, retryAfter)
+	}
+}
+
+func TestLogin_LockoutDoesNotRevealUnknownEmails(t *testing.T) {
+	srv := lockoutTestServer(t)
+	registerForTokens(t, srv, "lockout-known@example.com")
+
+	for _, email := range []string{"lockout-known@example.com", "lockout-unknown@example.com"} {
+		var got []string
+		for i := 0; i < 4; i++ {
+			status, code, _ := login(t, srv, email, "wrong-password")
+			got = append(got, fmt.Sprintf("%d %s", status, code))
+		}
+		want := []string{"401 INVALID_CREDENTIALS", "401 INVALID_CREDENTIALS", "429 TOO_MANY_LOGIN_ATTEMPTS", "429 TOO_MANY_LOGIN_ATTEMPTS"}
+		if strings.Join(got, ",") != strings.Join(want, ",") {
+			t.Errorf("%s: responses %v, want %v", email, got, want)
+		}
+	}
+}
+
+func TestLogin_SuccessResetsFailures(t *testing.T) {
+	srv := lockoutTestServer(t)
+	registerForTokens(t, srv, "lockout-reset@example.com")
+
+	for round := 0; round < 2; round++ {
+		for i := 0; i < 2; i++ {
+			if status, _, _ := login(t, srv, "lockout-reset@example.com", "wrong-password"); status != http.StatusUnauthorized {
+				t.Fatalf("round %d, failure %d: expected 401, got %d", round, i+1, status)
+			}
+		}
+		if status, _, _ := login(t, srv, "lockout-reset@example.com", "password123"); status != http.StatusOK {
+			t.Fatalf("round %d: expected the login to succeed, got %d", round, status)
+		}
+	}
+}
+
+func TestPasswordHashes_Bcrypt(t *testing.T) {
+	store := handler.NewMemoryUserStore()
+	srv, _ := userStoreTestServer(t, store)
+	registerForTokens(t, srv, "bcrypt@example.com")
+
+	user, err := store.GetByEmail(context.Background(), "bcrypt@example.com")
+	if err != nil {
+		t.Fatalf("GetByEmail: %v", err)
+	}
+	if !strings.HasPrefix(user.PasswordHash, "$2a$") {
+		t.Errorf("expected a bcrypt hash, got %q", user.PasswordHash)
+	}
+	if status, _, _ := login(t, srv, "bcrypt@example.com", "password123"); status != http.StatusOK {
+		t.Errorf("login: expected 200, got %d", status)
+	}
+
+	// bcrypt hashes at most 72 bytes; 40 characters of "é" are 80.
+	var result struct {
+		Error *types.APIError `json:"error"`
+	}
+	resp := doRequest(t, srv, http.MethodPost, "/api/auth/register", types.RegisterRequest{
+		Email: "bcrypt-long@example.com", Password: strings.Repeat("é", 40), FullName: "Long Password",
+	}, "")
+	decodeResponse(t, resp, &result)
+	if resp.StatusCode != http.StatusUnprocessableEntity || result.Error == nil ||
+		len(result.Error.Details) != 1 || result.Error.Details[0].Field != "password" {
+		t.Errorf("long password: expected 422 on password, got %d %+v", resp.StatusCode, result.Error)
+	}
+}
+
+func TestLogin_RehashesLegacyPassword(t *testing.T) {
+	store := handler.NewMemoryUserStore()
+	srv, _ := userStoreTestServer(t, store)
+	// The hash of "password123" stored before the switch to bcrypt.
+	const legacyHash = "53ab39b70bbc"
+	if _, err := store.CreateUser(context.Background(), "legacy@example.com", legacyHash, "Legacy User"); err != nil {
+		t.Fatalf("CreateUser: %v", err)
+	}
+
+	if status, _, _ := login(t, srv, "legacy@example.com", "wrong-password"); status != http.StatusUnauthorized {
+		t.Errorf("wrong password: expected 401, got %d", status)
+	}
+	if user, _ := store.GetByEmail(context.Background(), "legacy@example.com"); user.PasswordHash != legacyHash {
+		t.Errorf("a failed login must not replace the hash, got %q", user.PasswordHash)
+	}
+
+	if status, _, _ := login(t, srv, "legacy@example.com", "password123"); status != http.StatusOK {
+		t.Fatalf("legacy login: expected 200, got %d", status)
+	}
+	user, err := store.GetByEmail(context.Background(), "legacy@example.com")
+	if err != nil {
+		t.Fatalf("GetByEmail: %v", err)
+	}
+	if !strings.HasPrefix(user.PasswordHash, "$2a$") {
+		t.Fatalf("expected the legacy hash to be replaced by bcrypt, got %q", user.PasswordHash)
+	}
+	if status, _, _ := login(t, srv, "legacy@example.com", "password123"); status != http.StatusOK {
+		t.Errorf("login after rehash: expected 200, got %d", status)
+	}
+}
+
+// captureSender records sent emails on a channel.
+type captureSender chan mail.Message
+
+func (c captureSender) Send(_ context.Context, msg mail.Message) error {
+	c <- msg
+	return nil
+}
+
+// passwordResetTestServer returns a server whose reset emails go to the
+// returned channel.
+func passwordResetTestServer(t *testing.T, tokenDuration time.Duration) (*httptest.Server, captureSender) {
+	t.Helper()
+	sent := make(captureSender, 4)
+	h := handler.NewAuthHandler(middleware.DefaultJWTConfig("test-secret"))
+	h.SetPasswordReset(handler.PasswordResetConfig{
+		Sender:        sent,
+		TokenDuration: tokenDuration,
+		URL:           "https://learnbot.example/reset-password",
+	})
+	mux := http.NewServeMux()
+	h.RegisterRoutes(mux)
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	return srv, sent
+}
+
+// forgotPassword requests a reset link for email and returns the response.
+func forgotPassword(t *testing.T, srv *httptest.Server, email string) (int, string) {
+	t.Helper()
+	resp := doRequest(t, srv, http.MethodPost, "/api/auth/forgot-password", types.ForgotPasswordRequest{Email: email}, "")
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return resp.StatusCode, string(body)
+}
+
+// resetToken waits for a reset email and returns its token.
+func resetToken(t *testing.T, sent captureSender, to string) string {
+	t.Helper()
+	select {
+	case msg := <-sent:
+		if msg.To != to {
+			t.Fatalf("reset email sent to %q, want %q", msg.To, to)
+		}
+		_, after, ok := strings.Cut(msg.Body, "https://learnbot.example/reset-password?token=")
+		if !ok {
+			t.Fatalf("reset email has no link: %q", msg.Body)
+		}
+		token, _, _ := strings.Cut(after, "\n")
+		return token
+	case <-time.After(5 * time.Second):
+		t.Fatal("no reset email sent")
+		return ""
+	}
+}
+
+// resetPassword sets a new password with token and returns the status and
+// error code.
+func resetPassword(t *testing.T, srv *httptest.Server, token, password string) (int, string) {
+	t.Helper()
+	resp := doRequest(t, srv, http.MethodPost, "/api/auth/reset-password", types.ResetPasswordRequest{Token: token, Password: password}, "")
+	var result struct {
+		Error *types.APIError `json:"error"`
+	}
+	decodeResponse(t, resp, &result)
+	if result.Error != nil {
+		return resp.StatusCode, result.Error.Code
+	}
+	return resp.StatusCode, ""
+}
+
+func TestPasswordReset_Flow(t *testing.T) {
+	srv, sent := passwordResetTestServer(t, 0)
+	registerForTokens(t, srv, "reset-flow@example.com")
+
+	if status, _ := forgotPassword(t, srv, "reset-flow@example.com"); status != http.StatusAccepted {
+		t.Fatalf("forgot-password: expected 202, got %d", status)
+	}
+	token := resetToken(t, sent, "reset-flow@example.com")
+
+	if status, code := resetPassword(t, srv, token, "short"); status != http.StatusUnprocessableEntity || code != "VALIDATION_ERROR" {
+		t.Errorf("short password: expected 422 VALIDATION_ERROR, got %d %q", status, code)
+	}
+	if status, code := resetPassword(t, srv, token, "new-password456"); status != http.StatusOK {
+		t.Fatalf("reset-password: expected 200, got %d %q", status, code)
+	}
+	if status, _, _ := login(t, srv, "reset-flow@example.com", "password123"); status != http.StatusUnauthorized {
+		t.Errorf("old password: expected 401, got %d", status)
+	}
+	if status, _, _ := login(t, srv, "reset-flow@example.com", "new-password456"); status != http.StatusOK {
+		t.Errorf("new password: expected 200, got %d", status)
+	}
+}
+
+func TestPasswordReset_TokenReuseRejected(t *testing.T) {
+	srv, sent := passwordResetTestServer(t, 0)
+	registerForTokens(t, srv, "reset-reuse@example.com")
+
+	forgotPassword(t, srv, "reset-reuse@example.com")
+	first := resetToken(t, sent, "reset-reuse@example.com")
+	forgotPassword(t, srv, "reset-reuse@example.com")
+	second := resetToken(t, sent, "reset-reuse@example.com")
+
+	if status, code := resetPassword(t, srv, first, "new-password456"); status != http.StatusOK {
+		t.Fatalf("first use: expected 200, got %d %q", status, code)
+	}
+	if status, code := resetPassword(t, srv, first, "other-password789"); status != http.StatusBadRequest || code != "INVALID_RESET_TOKEN" {
+		t.Errorf("reuse: expected 400 INVALID_RESET_TOKEN, got %d %q", status, code)
+	}
+	// A reset also invalidates the links sent before it.
+	if status, code := resetPassword(t, srv, second, "other-password789"); status != http.StatusBadRequest || code != "INVALID_RESET_TOKEN" {
+		t.Errorf("earlier link: expected 400 INVALID_RESET_TOKEN, got %d %q", status, code)
+	}
+}
+
+func TestPasswordReset_TokenExpired(t *testing.T) {
+	srv, sent := passwordResetTestServer(t, time.Millisecond)
+	registerForTokens(t, srv, "reset-expired@example.com")
+
+	forgotPassword(t, srv, "reset-expired@example.com")
+	token := resetToken(t, sent, "reset-expired@example.com")
+	time.Sleep(5 * time.Millisecond)
+
+	if status, code := resetPassword(t, srv, token, "new-password456"); status != http.StatusBadRequest || code != "RESET_TOKEN_EXPIRED" {
+		t.Errorf("expected 400 RESET_TOKEN_EXPIRED, got %d %q", status, code)
+	}
+	if status, _, _ := login(t, srv, "reset-expired@example.com", "password123"); status != http.StatusOK {
+		t.Errorf("old password after a failed reset: expected 200, got %d", status)
+	}
+}
+
+func TestForgotPassword_UnknownEmail(t *testing.T) {
+	srv, sent := passwordResetTestServer(t, 0)
+	registerForTokens(t, srv, "reset-known@example.com")
+
+	knownStatus, knownBody := forgotPassword(t, srv, "reset-known@example.com")
+	resetToken(t, sent, "reset-known@example.com")
+	unknownStatus, unknownBody := forgotPassword(t, srv, "reset-unknown@example.com")
+
+	if knownStatus != http.StatusAccepted || unknownStatus != knownStatus || unknownBody != knownBody {
+		t.Errorf("responses differ: known %d %s, unknown %d %s", knownStatus, knownBody, unknownStatus, unknownBody)
+	}
+	select {
+	case msg := <-sent:
+		t.Errorf("email sent for an unknown account: %+v", msg)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestPasswordReset_InvalidRequests(t *testing.T) {
+	srv, _ := passwordResetTestServer(t, 0)
+
+	if status, _ := forgotPassword(t, srv, "not-an-email"); status != http.StatusUnprocessableEntity {
+		t.Errorf("invalid email: expected 422, got %d", status)
+	}
+	if status, code := resetPassword(t, srv, "", "new-password456"); status != http.StatusUnprocessableEntity || code != "VALIDATION_ERROR" {
+		t.Errorf("missing token: expected 422 VALIDATION_ERROR, got %d %q", status, code)
+	}
+	if status, code := resetPassword(t, srv, "made-up-token", "new-password456"); status != http.StatusBadRequest || code != "INVALID_RESET_TOKEN" {
+		t.Errorf("unknown token: expected 400 INVALID_RESET_TOKEN, got %d %q", status, code)
+	}
+	resp := doRequest(t, srv, http.MethodGet, "/api/auth/reset-password", nil, "")
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusMethodNotAllowed {
+		t.Errorf("GET: expected 405, got %d", resp.StatusCode)
+	}
+}
@@ -0,0 +1,63 @@
+// Package handler – cache.go implements the admin endpoint that purges the
+// gateway's response cache.
+package handler
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/learnbot/api-gateway/internal/middleware"
+	"github.com/learnbot/api-gateway/internal/types"
+	"github.com/learnbot/shared/openapi"
+)
+
+// CacheHandler lets admins purge cached responses, for instance after
+// curating the learning resources they list.
+type CacheHandler struct {
+	cache  *middleware.ResponseCache
+	logger *slog.Logger
+}
+
+// NewCacheHandler creates a CacheHandler for cache. A nil logger uses
+// slog.Default().
+func NewCacheHandler(cache *middleware.ResponseCache, logger *slog.Logger) *CacheHandler {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &CacheHandler{cache: cache, logger: logger}
+}
+
+// RegisterRoutes registers the cache routes on the mux behind
+// authMiddleware and the admin role.
+//
+//	POST /api/admin/cache/purge – purge cached responses (admin)
+func (h *CacheHandler) RegisterRoutes(mux openapi.Router, authMiddleware func(http.Handler) http.Handler) {
+	mux.Handle("/api/admin/cache/purge",
+		authMiddleware(middleware.RequireRole(middleware.RoleAdmin)(http.HandlerFunc(h.Purge))))
+}
+
+// DescribeRoutes documents the routes registered by RegisterRoutes.
+func (h *CacheHandler) DescribeRoutes(spec *openapi.Spec) {
+	spec.Route("/api/admin/cache/purge", openapi.Operation{
+		Method:  http.MethodPost,
+		Summary: "Purge cached responses",
+		Description: "Requires the admin role. Removes the cached responses of paths starting with " +
+			"prefix, or every cached response without it.",
+		Security: []string{openapi.BearerAuth},
+		Params:   []openapi.Param{openapi.Query("prefix", "Path prefix to purge, e.g. /api/v1/learning/resources")},
+		Response: success(types.CachePurgeResponse{}),
+		Errors:   []int{http.StatusUnauthorized, http.StatusForbidden},
+	})
+}
+
+// Purge handles POST /api/admin/cache/purge?prefix=/api/resources.
+func (h *CacheHandler) Purge(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		WriteMethodNotAllowed(w)
+		return
+	}
+	prefix := r.URL.Query().Get("prefix")
+	n := h.cache.Purge(prefix)
+	h.logger.Info("response cache purged", "prefix", prefix, "purged", n, "admin_id", middleware.GetUserID(r))
+	WriteSuccess(w, http.StatusOK, types.CachePurgeResponse{Prefix: prefix, Purged: n, Remaining: h.cache.Len()})
+}
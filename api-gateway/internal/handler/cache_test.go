@@ -0,0 +1,58 @@
+package handler_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/learnbot/api-gateway/internal/handler"
+	"github.com/learnbot/api-gateway/internal/middleware"
+	"github.com/learnbot/api-gateway/internal/types"
+)
+
+func TestCacheHandler_Purge(t *testing.T) {
+	jwtCfg := middleware.DefaultJWTConfig("test-secret")
+	cache := middleware.NewResponseCache(middleware.DefaultResponseCacheConfig())
+	mux := http.NewServeMux()
+	handler.NewResourcesHandler().RegisterRoutes(mux, cache.Middleware)
+	handler.NewCacheHandler(cache, nil).RegisterRoutes(mux, middleware.RequireAuth(jwtCfg))
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+
+	for _, want := range []string{"MISS", "HIT"} {
+		resp := doRequest(t, srv, http.MethodGet, "/api/resources/search?skill=go", nil, "")
+		resp.Body.Close()
+		if got := resp.Header.Get(middleware.CacheHeader); got != want {
+			t.Fatalf("X-Cache = %q, want %q", got, want)
+		}
+	}
+
+	user, _, err := middleware.GenerateToken(jwtCfg, "user-1", "user@example.com", nil, false)
+	if err != nil {
+		t.Fatalf("GenerateToken: %v", err)
+	}
+	resp := doRequest(t, srv, http.MethodPost, "/api/admin/cache/purge", nil, user)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusForbidden {
+		t.Errorf("non-admin purge: expected 403, got %d", resp.StatusCode)
+	}
+
+	admin, _, err := middleware.GenerateToken(jwtCfg, "admin-1", "admin@example.com",
+		[]middleware.Role{middleware.RoleAdmin}, true)
+	if err != nil {
+		t.Fatalf("GenerateToken: %v", err)
+	}
+	var purged struct {
+		Data types.CachePurgeResponse `json:"data"`
+	}
+	decodeResponse(t, doRequest(t, srv, http.MethodPost, "/api/admin/cache/purge?prefix=/api/resources", nil, admin), &purged)
+	if purged.Data.Purged != 1 || purged.Data.Remaining != 0 || purged.Data.Prefix != "/api/resources" {
+		t.Errorf("unexpected purge response: %+v", purged.Data)
+	}
+
+	resp = doRequest(t, srv, http.MethodGet, "/api/resources/search?skill=go", nil, "")
+	resp.Body.Close()
+	if got := resp.Header.Get(middleware.CacheHeader); got != "MISS" {
+		t.Errorf("after the purge: X-Cache = %q, want MISS", got)
+	}
+}
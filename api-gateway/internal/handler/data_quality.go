@@ -0,0 +1,215 @@
+// Package handler – data_quality.go implements the admin data quality
+// dashboard, which merges the sections reported by each backend service.
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/learnbot/api-gateway/internal/middleware"
+	"github.com/learnbot/api-gateway/internal/types"
+	"github.com/learnbot/shared/dataquality"
+	"github.com/learnbot/shared/logging"
+	"github.com/learnbot/shared/openapi"
+)
+
+const (
+	// DefaultDataQualityTimeout bounds how long the gateway waits for each
+	// service before marking its section as unknown.
+	DefaultDataQualityTimeout = 3 * time.Second
+
+	// maxDataQualityBody caps the size of a service response.
+	maxDataQualityBody = 1 << 20
+)
+
+// DataQualityService identifies a backend exposing GET /admin/data-quality.
+type DataQualityService struct {
+	// Name is the service name used when the section cannot be fetched.
+	Name string
+
+	// URL is the full URL of the service's data quality endpoint.
+	URL string
+}
+
+// DataQualityHandler fans out to backend services and merges their data
+// quality sections.
+type DataQualityHandler struct {
+	services []DataQualityService
+	adminKey string
+	client   *http.Client
+	timeout  time.Duration
+}
+
+// NewDataQualityHandler creates a new DataQualityHandler that authenticates
+// to the services' admin endpoints with adminKey. A non-positive timeout
+// uses DefaultDataQualityTimeout.
+func NewDataQualityHandler(services []DataQualityService, adminKey string, timeout time.Duration) *DataQualityHandler {
+	if timeout <= 0 {
+		timeout = DefaultDataQualityTimeout
+	}
+	return &DataQualityHandler{
+		services: services,
+		adminKey: adminKey,
+		client:   &http.Client{Transport: logging.Transport(nil)},
+		timeout:  timeout,
+	}
+}
+
+// RegisterRoutes registers data quality routes on the mux.
+//
+//	GET /api/admin/data-quality – merged data quality dashboard (admin only)
+func (h *DataQualityHandler) RegisterRoutes(mux openapi.Router, authMiddleware func(http.Handler) http.Handler) {
+	mux.Handle("/api/admin/data-quality",
+		authMiddleware(middleware.RequireAdmin(http.HandlerFunc(h.DataQuality))))
+}
+
+// DescribeRoutes documents the routes registered by RegisterRoutes.
+func (h *DataQualityHandler) DescribeRoutes(spec *openapi.Spec) {
+	spec.Route("/api/admin/data-quality", openapi.Operation{
+		Method:      http.MethodGet,
+		Summary:     "Merged data quality dashboard",
+		Description: "Requires an admin token. A service that cannot be reached is reported with status \"unknown\".",
+		Security:    []string{openapi.BearerAuth},
+		Params:      []openapi.Param{{Name: "days", Description: "Window in days, forwarded to every service", Type: 0}},
+		Response:    success(types.DataQualityReport{}),
+		Errors:      []int{http.StatusUnauthorized, http.StatusForbidden},
+	})
+}
+
+// DataQuality handles GET /api/admin/data-quality.
+//
+// Every configured service is queried concurrently. A service that times out,
+// errors, or returns an unreadable body is reported with status "unknown"
+// instead of failing the whole request. The optional ?days= parameter is
+// forwarded unchanged.
+func (h *DataQualityHandler) DataQuality(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		WriteMethodNotAllowed(w)
+		return
+	}
+
+	days := r.URL.Query().Get("days")
+
+	sections := make([]types.DataQualitySection, len(h.services))
+	var wg sync.WaitGroup
+	for i, svc := range h.services {
+		wg.Add(1)
+		go func(i int, svc DataQualityService) {
+			defer wg.Done()
+			sections[i] = h.fetchSection(r.Context(), svc, days)
+		}(i, svc)
+	}
+	wg.Wait()
+
+	WriteSuccess(w, http.StatusOK, mergeDataQualitySections(sections, time.Now().UTC()))
+}
+
+// fetchSection retrieves a single service's section, returning an "unknown"
+// section on any failure.
+func (h *DataQualityHandler) fetchSection(ctx context.Context, svc DataQualityService, days string) types.DataQualitySection {
+	ctx, cancel := context.WithTimeout(ctx, h.timeout)
+	defer cancel()
+
+	target := svc.URL
+	if days != "" {
+		u, err := url.Parse(target)
+		if err == nil {
+			q := u.Query()
+			q.Set("days", days)
+			u.RawQuery = q.Encode()
+			target = u.String()
+		}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target, nil)
+	if err != nil {
+		return unknownSection(svc.Name, err)
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("X-Admin-API-Key", h.adminKey)
+	resp, err := h.client.Do(req)
+	if err != nil {
+		return unknownSection(svc.Name, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return unknownSection(svc.Name, fmt.Errorf("unexpected status %d", resp.StatusCode))
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxDataQualityBody))
+	if err != nil {
+		return unknownSection(svc.Name, err)
+	}
+	section, err := decodeDataQualitySection(body)
+	if err != nil {
+		return unknownSection(svc.Name, err)
+	}
+	if section.Service == "" {
+		section.Service = svc.Name
+	}
+	return section
+}
+
+// decodeDataQualitySection accepts either a bare section or one wrapped in a
+// {"success": ..., "data": {...}} envelope, since services differ in style.
+func decodeDataQualitySection(body []byte) (types.DataQualitySection, error) {
+	var envelope struct {
+		Data json.RawMessage `json:"data"`
+	}
+	if err := json.Unmarshal(body, &envelope); err != nil {
+		return types.DataQualitySection{}, fmt.Errorf("decode section: %w", err)
+	}
+	if len(envelope.Data) > 0 {
+		body = envelope.Data
+	}
+
+	var section types.DataQualitySection
+	if err := json.Unmarshal(body, &section); err != nil {
+		return types.DataQualitySection{}, fmt.Errorf("decode section: %w", err)
+	}
+	if section.Status == "" {
+		return types.DataQualitySection{}, fmt.Errorf("decode section: missing status")
+	}
+	if section.Checks == nil {
+		section.Checks = []dataquality.Check{}
+	}
+	return section, nil
+}
+
+// unknownSection builds the placeholder section for an unreachable service.
+func unknownSection(name string, err error) types.DataQualitySection {
+	return types.DataQualitySection{
+		Service: name,
+		Status:  "unknown",
+		Checks:  []dataquality.Check{},
+		Error:   err.Error(),
+	}
+}
+
+// mergeDataQualitySections combines per-service sections into a report and
+// derives the overall status.
+func mergeDataQualitySections(sections []types.DataQualitySection, now time.Time) types.DataQualityReport {
+	status := "healthy"
+	for _, s := range sections {
+		switch s.Status {
+		case "degraded":
+			status = "degraded"
+		case "unknown":
+			if status == "healthy" {
+				status = "unknown"
+			}
+		}
+	}
+	return types.DataQualityReport{
+		Status:      status,
+		GeneratedAt: now,
+		Sections:    sections,
+	}
+}
@@ -0,0 +1,229 @@
+package handler_test
+
+import (
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/learnbot/api-gateway/internal/handler"
+	"github.com/learnbot/api-gateway/internal/middleware"
+	"github.com/learnbot/api-gateway/internal/types"
+	"github.com/learnbot/shared/adminauth"
+)
+
+// dataQualityServer creates a gateway exposing only the data quality route.
+func dataQualityServer(t *testing.T, services []handler.DataQualityService, timeout time.Duration) (*httptest.Server, middleware.JWTConfig) {
+	return adminKeyDataQualityServer(t, services, "", timeout)
+}
+
+// adminKeyDataQualityServer is dataQualityServer with the gateway calling
+// the services with adminKey.
+func adminKeyDataQualityServer(t *testing.T, services []handler.DataQualityService, adminKey string, timeout time.Duration) (*httptest.Server, middleware.JWTConfig) {
+	t.Helper()
+	jwtCfg := middleware.DefaultJWTConfig("test-secret")
+	mux := http.NewServeMux()
+	handler.NewDataQualityHandler(services, adminKey, timeout).
+		RegisterRoutes(mux, middleware.RequireAuth(jwtCfg))
+	return httptest.NewServer(mux), jwtCfg
+}
+
+func adminToken(t *testing.T, cfg middleware.JWTConfig, isAdmin bool) string {
+	t.Helper()
+	var roles []middleware.Role
+	if isAdmin {
+		roles = []middleware.Role{middleware.RoleAdmin}
+	}
+	token, _, err := middleware.GenerateToken(cfg, "user-1", "ops@example.com", roles, true)
+	if err != nil {
+		t.Fatalf("generate token: %v", err)
+	}
+	return token
+}
+
+// staticBackend returns a backend that always responds with status and body.
+func staticBackend(status int, body string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		w.Write([]byte(body))
+	}))
+}
+
+func TestDataQuality_PartialFailureMerge(t *testing.T) {
+	// Bare section (job-aggregator style).
+	jobs := staticBackend(http.StatusOK, `{
+		"service": "job-aggregator", "status": "degraded",
+		"checks": [{"name": "rejected_jobs", "count": 4, "previous_count": 6, "trend": "down",
+		            "drill_down": {"href": "/admin/runs", "ids": ["run-1"]}}]
+	}`)
+	defer jobs.Close()
+
+	// Enveloped section (learning-resources / resume-parser style).
+	resources := staticBackend(http.StatusOK, `{"success": true, "data": {
+		"service": "learning-resources", "status": "healthy",
+		"checks": [{"name": "broken_links", "count": 0, "previous_count": 0, "trend": "flat",
+		            "drill_down": {"ids": []}}]
+	}}`)
+	defer resources.Close()
+
+	// Slow backend exceeds the gateway timeout.
+	slow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-time.After(2 * time.Second):
+		case <-r.Context().Done():
+		}
+	}))
+	defer slow.Close()
+
+	broken := staticBackend(http.StatusInternalServerError, `{"error": "boom"}`)
+	defer broken.Close()
+
+	services := []handler.DataQualityService{
+		{Name: "job-aggregator", URL: jobs.URL + "/admin/data-quality"},
+		{Name: "learning-resources", URL: resources.URL + "/admin/data-quality"},
+		{Name: "resume-parser", URL: slow.URL + "/admin/data-quality"},
+		{Name: "scoring", URL: broken.URL + "/admin/data-quality"},
+		{Name: "offline", URL: "http://127.0.0.1:1/admin/data-quality"},
+	}
+	srv, cfg := dataQualityServer(t, services, 200*time.Millisecond)
+	defer srv.Close()
+
+	start := time.Now()
+	resp := doRequest(t, srv, http.MethodGet, "/api/admin/data-quality", nil, adminToken(t, cfg, true))
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("aggregation took %v; slow services should be cut off by the timeout", elapsed)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected 200, got %d", resp.StatusCode)
+	}
+
+	var result struct {
+		Success bool                    `json:"success"`
+		Data    types.DataQualityReport `json:"data"`
+	}
+	decodeResponse(t, resp, &result)
+
+	if result.Data.Status != "degraded" {
+		t.Errorf("overall status = %q, want degraded", result.Data.Status)
+	}
+	if len(result.Data.Sections) != len(services) {
+		t.Fatalf("expected %d sections, got %d", len(services), len(result.Data.Sections))
+	}
+
+	want := []string{"degraded", "healthy", "unknown", "unknown", "unknown"}
+	for i, s := range result.Data.Sections {
+		if s.Service != services[i].Name {
+			t.Errorf("section %d service = %q, want %q", i, s.Service, services[i].Name)
+		}
+		if s.Status != want[i] {
+			t.Errorf("%s status = %q, want %q", s.Service, s.Status, want[i])
+		}
+		if s.Status == "unknown" && s.Error == "" {
+			t.Errorf("%s: unknown section should carry an error", s.Service)
+		}
+		if s.Checks == nil {
+			t.Errorf("%s: checks should never be null", s.Service)
+		}
+	}
+
+	rejected := result.Data.Sections[0].Checks[0]
+	if rejected.Count != 4 || rejected.PreviousCount != 6 || rejected.DrillDown.IDs[0] != "run-1" {
+		t.Errorf("job-aggregator check not passed through: %+v", rejected)
+	}
+}
+
+func TestDataQuality_AuthenticatesToProtectedServices(t *testing.T) {
+	section := staticBackend(http.StatusOK, `{"service": "job-aggregator", "status": "healthy", "checks": []}`)
+	defer section.Close()
+	protected := adminauth.New(adminauth.Config{APIKey: "backend-key"}, log.New(io.Discard, "", 0)).
+		Wrap(section.Config.Handler)
+	backend := httptest.NewServer(protected)
+	defer backend.Close()
+	services := []handler.DataQualityService{{Name: "job-aggregator", URL: backend.URL + "/admin/data-quality"}}
+
+	for _, tt := range []struct {
+		adminKey, want string
+	}{
+		{"backend-key", "healthy"},
+		{"wrong-key", "unknown"},
+	} {
+		srv, cfg := adminKeyDataQualityServer(t, services, tt.adminKey, time.Second)
+		resp := doRequest(t, srv, http.MethodGet, "/api/admin/data-quality", nil, adminToken(t, cfg, true))
+		var result struct {
+			Data types.DataQualityReport `json:"data"`
+		}
+		decodeResponse(t, resp, &result)
+		srv.Close()
+		if len(result.Data.Sections) != 1 || result.Data.Sections[0].Status != tt.want {
+			t.Errorf("admin key %q: sections = %+v, want %s", tt.adminKey, result.Data.Sections, tt.want)
+		}
+	}
+}
+
+func TestDataQuality_AllUnreachableIsUnknown(t *testing.T) {
+	services := []handler.DataQualityService{
+		{Name: "job-aggregator", URL: "http://127.0.0.1:1/admin/data-quality"},
+	}
+	srv, cfg := dataQualityServer(t, services, 100*time.Millisecond)
+	defer srv.Close()
+
+	resp := doRequest(t, srv, http.MethodGet, "/api/admin/data-quality", nil, adminToken(t, cfg, true))
+	var result struct {
+		Data types.DataQualityReport `json:"data"`
+	}
+	decodeResponse(t, resp, &result)
+	if result.Data.Status != "unknown" {
+		t.Errorf("overall status = %q, want unknown", result.Data.Status)
+	}
+}
+
+func TestDataQuality_ForwardsDaysParameter(t *testing.T) {
+	var gotDays string
+	backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotDays = r.URL.Query().Get("days")
+		w.Write([]byte(`{"service":"job-aggregator","status":"healthy","checks":[]}`))
+	}))
+	defer backend.Close()
+
+	srv, cfg := dataQualityServer(t, []handler.DataQualityService{
+		{Name: "job-aggregator", URL: backend.URL + "/admin/data-quality"},
+	}, time.Second)
+	defer srv.Close()
+
+	resp := doRequest(t, srv, http.MethodGet, "/api/admin/data-quality?days=30", nil, adminToken(t, cfg, true))
+	resp.Body.Close()
+	if gotDays != "30" {
+		t.Errorf("backend received days=%q, want 30", gotDays)
+	}
+}
+
+func TestDataQuality_RequiresAdmin(t *testing.T) {
+	srv, cfg := dataQualityServer(t, nil, time.Second)
+	defer srv.Close()
+
+	resp := doRequest(t, srv, http.MethodGet, "/api/admin/data-quality", nil, "")
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusUnauthorized {
+		t.Errorf("no token: expected 401, got %d", resp.StatusCode)
+	}
+
+	resp = doRequest(t, srv, http.MethodGet, "/api/admin/data-quality", nil, adminToken(t, cfg, false))
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusForbidden {
+		t.Errorf("non-admin: expected 403, got %d", resp.StatusCode)
+	}
+}
+
+func TestDataQuality_MethodNotAllowed(t *testing.T) {
+	srv, cfg := dataQualityServer(t, nil, time.Second)
+	defer srv.Close()
+
+	resp := doRequest(t, srv, http.MethodPost, "/api/admin/data-quality", nil, adminToken(t, cfg, true))
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusMethodNotAllowed {
+		t.Errorf("expected 405, got %d", resp.StatusCode)
+	}
+}
@@ -0,0 +1,556 @@
+// Package handler – deletion.go deletes user accounts. The account and the
+// data the gateway holds are removed at once; the data held by the backend
+// services is erased by deletion tasks, retried until every service has
+// confirmed the erasure.
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/learnbot/api-gateway/internal/middleware"
+	"github.com/learnbot/api-gateway/internal/types"
+	"github.com/learnbot/shared/logging"
+	"github.com/learnbot/shared/openapi"
+)
+
+const (
+	// DefaultDeletionPollInterval is how often the AccountDeleter looks for
+	// erasures due for another attempt.
+	DefaultDeletionPollInterval = time.Minute
+
+	// deletionRetryBase is the delay after an erasure's first failed
+	// attempt. It doubles with each failed attempt, up to deletionRetryMax.
+	deletionRetryBase = 30 * time.Second
+	deletionRetryMax  = time.Hour
+
+	// deletionLease is how long other workers skip an erasure claimed for
+	// an attempt. It outlasts deletionAttemptTimeout, so that an erasure is
+	// attempted again only if the worker attempting it went away.
+	deletionLease          = 5 * time.Minute
+	deletionAttemptTimeout = DefaultBackendTimeout
+
+	// deletionBatch is the most erasures claimed at a time.
+	deletionBatch = 50
+
+	// maxDeletionList caps the deletions listed by the admin route.
+	maxDeletionList = 500
+)
+
+// errUnknownErasureTarget is recorded for a deletion target that is no
+// longer configured, leaving it pending for an admin to look into.
+var errUnknownErasureTarget = errors.New("erasure target not configured")
+
+// ErasureTarget erases a deleted user's data in one service.
+type ErasureTarget struct {
+	// Name identifies the target in account deletions.
+	Name string
+
+	// Erase erases the data of userID. It must succeed for a user with no
+	// data left, since an erasure whose success was not recorded is
+	// attempted again.
+	Erase func(ctx context.Context, userID string) error
+}
+
+// BackendErasure erases a user's data through DELETE /admin/users/{id} of
+// the service at baseURL, authenticated with the admin API key. A nil
+// client uses one with DefaultBackendTimeout.
+func BackendErasure(name, baseURL, adminKey string, client *http.Client) ErasureTarget {
+	if client == nil {
+		client = &http.Client{Transport: logging.Transport(nil), Timeout: DefaultBackendTimeout}
+	}
+	baseURL = strings.TrimRight(baseURL, "/")
+	return ErasureTarget{Name: name, Erase: func(ctx context.Context, userID string) error {
+		req, err := http.NewRequestWithContext(ctx, http.MethodDelete,
+			baseURL+"/admin/users/"+url.PathEscape(userID), nil)
+		if err != nil {
+			return err
+		}
+		req.Header.Set("Accept", "application/json")
+		req.Header.Set("X-Admin-API-Key", adminKey)
+		resp, err := client.Do(req)
+		if err != nil {
+			return err
+		}
+		resp.Body.Close()
+		if resp.StatusCode < 200 || resp.StatusCode > 299 {
+			return fmt.Errorf("DELETE %s: status %d", req.URL.Path, resp.StatusCode)
+		}
+		return nil
+	}}
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Deletion store
+// ─────────────────────────────────────────────────────────────────────────────
+
+// AccountDeletionStore keeps account deletions and the status of each of
+// their targets.
+type AccountDeletionStore interface {
+	// CreateDeletion stores a new deletion with its targets.
+	CreateDeletion(ctx context.Context, d types.AccountDeletion) error
+
+	// ClaimDueTargets returns up to limit pending targets due at now,
+	// longest due first. Each claim counts an attempt and postpones the
+	// target to now+lease, so that other workers skip it meanwhile.
+	ClaimDueTargets(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]DeletionClaim, error)
+
+	// CompleteTarget records that a target confirmed its erasure at now,
+	// and completes the deletion once every target has. Completing a
+	// completed target changes nothing.
+	CompleteTarget(ctx context.Context, deletionID, target string, now time.Time) error
+
+	// RetryTarget records why an attempt of a pending target failed and
+	// when to attempt it next. Completed targets are left as they are.
+	RetryTarget(ctx context.Context, deletionID, target, lastError string, next time.Time) error
+
+	// ListDeletions returns up to limit deletions with the status, or with
+	// any status if it is empty, oldest first.
+	ListDeletions(ctx context.Context, status types.DeletionStatus, limit int) ([]types.AccountDeletion, error)
+}
+
+// DeletionClaim is a deletion target claimed for an attempt.
+type DeletionClaim struct {
+	DeletionID string
+	UserID     string
+	Target     string
+
+	// Attempt numbers the attempt, from 1.
+	Attempt int
+}
+
+// MemoryAccountDeletionStore is a thread-safe in-memory
+// AccountDeletionStore for tests and development. Deletions are lost on
+// restart; use PostgresAccountDeletionStore to keep retrying them.
+type MemoryAccountDeletionStore struct {
+	mu        sync.Mutex
+	deletions []*types.AccountDeletion // oldest first
+}
+
+// NewMemoryAccountDeletionStore creates an empty MemoryAccountDeletionStore.
+func NewMemoryAccountDeletionStore() *MemoryAccountDeletionStore {
+	return &MemoryAccountDeletionStore{}
+}
+
+// CreateDeletion implements AccountDeletionStore.
+func (s *MemoryAccountDeletionStore) CreateDeletion(_ context.Context, d types.AccountDeletion) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	c := copyDeletion(d)
+	s.deletions = append(s.deletions, &c)
+	return nil
+}
+
+// ClaimDueTargets implements AccountDeletionStore.
+func (s *MemoryAccountDeletionStore) ClaimDueTargets(_ context.Context, now time.Time, lease time.Duration, limit int) ([]DeletionClaim, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	type due struct {
+		d *types.AccountDeletion
+		t *types.DeletionTarget
+	}
+	var dues []due
+	for _, d := range s.deletions {
+		for i := range d.Targets {
+			t := &d.Targets[i]
+			if t.Status == types.DeletionPending && t.NextAttemptAt != nil && !t.NextAttemptAt.After(now) {
+				dues = append(dues, due{d, t})
+			}
+		}
+	}
+	slices.SortStableFunc(dues, func(a, b due) int { return a.t.NextAttemptAt.Compare(*b.t.NextAttemptAt) })
+
+	claims := []DeletionClaim{}
+	for _, c := range dues[:min(limit, len(dues))] {
+		next := now.Add(lease)
+		c.t.Attempts++
+		c.t.NextAttemptAt = &next
+		claims = append(claims, DeletionClaim{DeletionID: c.d.ID, UserID: c.d.UserID, Target: c.t.Name, Attempt: c.t.Attempts})
+	}
+	return claims, nil
+}
+
+// CompleteTarget implements AccountDeletionStore.
+func (s *MemoryAccountDeletionStore) CompleteTarget(_ context.Context, deletionID, target string, now time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	d, t := s.targetLocked(deletionID, target)
+	if t == nil || t.Status != types.DeletionPending {
+		return nil
+	}
+	at := now
+	t.Status, t.CompletedAt, t.NextAttemptAt, t.LastError = types.DeletionCompleted, &at, nil, ""
+	for _, other := range d.Targets {
+		if other.Status == types.DeletionPending {
+			return nil
+		}
+	}
+	d.Status, d.CompletedAt = types.DeletionCompleted, &at
+	return nil
+}
+
+// RetryTarget implements AccountDeletionStore.
+func (s *MemoryAccountDeletionStore) RetryTarget(_ context.Context, deletionID, target, lastError string, next time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, t := s.targetLocked(deletionID, target); t != nil && t.Status == types.DeletionPending {
+		t.LastError, t.NextAttemptAt = lastError, &next
+	}
+	return nil
+}
+
+// ListDeletions implements AccountDeletionStore.
+func (s *MemoryAccountDeletionStore) ListDeletions(_ context.Context, status types.DeletionStatus, limit int) ([]types.AccountDeletion, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	out := []types.AccountDeletion{}
+	for _, d := range s.deletions {
+		if len(out) == limit {
+			break
+		}
+		if status == "" || d.Status == status {
+			out = append(out, copyDeletion(*d))
+		}
+	}
+	return out, nil
+}
+
+// targetLocked returns the deletion with the ID and its target, or nils.
+func (s *MemoryAccountDeletionStore) targetLocked(deletionID, target string) (*types.AccountDeletion, *types.DeletionTarget) {
+	for _, d := range s.deletions {
+		if d.ID != deletionID {
+			continue
+		}
+		for i := range d.Targets {
+			if d.Targets[i].Name == target {
+				return d, &d.Targets[i]
+			}
+		}
+	}
+	return nil, nil
+}
+
+// copyDeletion returns a copy of d that shares nothing with it.
+func copyDeletion(d types.AccountDeletion) types.AccountDeletion {
+	d.CompletedAt = copyTime(d.CompletedAt)
+	d.Targets = slices.Clone(d.Targets)
+	for i := range d.Targets {
+		d.Targets[i].NextAttemptAt = copyTime(d.Targets[i].NextAttemptAt)
+		d.Targets[i].CompletedAt = copyTime(d.Targets[i].CompletedAt)
+	}
+	return d
+}
+
+func copyTime(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+	c := *t
+	return &c
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// AccountDeleter
+// ─────────────────────────────────────────────────────────────────────────────
+
+// AccountDeleter erases deleted accounts' data from the erasure targets.
+// Each target of a deletion is attempted until it succeeds, with a delay
+// doubling from 30s to an hour after each failure. A target is attempted
+// again only while it has not confirmed the erasure, and erasing twice is
+// harmless, so a deletion survives both failed and repeated deliveries.
+type AccountDeleter struct {
+	store   AccountDeletionStore
+	targets []ErasureTarget
+	logger  *slog.Logger
+	wake    chan struct{}
+}
+
+// NewAccountDeleter creates an AccountDeleter recording deletions in store
+// and erasing them from targets. A nil logger uses slog.Default().
+func NewAccountDeleter(store AccountDeletionStore, targets []ErasureTarget, logger *slog.Logger) *AccountDeleter {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &AccountDeleter{store: store, targets: targets, logger: logger, wake: make(chan struct{}, 1)}
+}
+
+// Request records the erasure of userID's data from every target, due at
+// once, and wakes the worker started by Start.
+func (d *AccountDeleter) Request(ctx context.Context, userID string) (*types.AccountDeletion, error) {
+	now := time.Now().UTC()
+	deletion := types.AccountDeletion{
+		ID:          uuid.NewString(),
+		UserID:      userID,
+		Status:      types.DeletionPending,
+		RequestedAt: now,
+		Targets:     make([]types.DeletionTarget, len(d.targets)),
+	}
+	for i, t := range d.targets {
+		deletion.Targets[i] = types.DeletionTarget{Name: t.Name, Status: types.DeletionPending, NextAttemptAt: &now}
+	}
+	if len(d.targets) == 0 {
+		deletion.Status, deletion.CompletedAt = types.DeletionCompleted, &now
+	}
+	if err := d.store.CreateDeletion(ctx, deletion); err != nil {
+		return nil, fmt.Errorf("create account deletion: %w", err)
+	}
+	select {
+	case d.wake <- struct{}{}:
+	default:
+	}
+	return &deletion, nil
+}
+
+// List returns up to limit deletions with the status, or with any status
+// if it is empty, oldest first.
+func (d *AccountDeleter) List(ctx context.Context, status types.DeletionStatus, limit int) ([]types.AccountDeletion, error) {
+	return d.store.ListDeletions(ctx, status, limit)
+}
+
+// Start attempts the due erasures as soon as a deletion is requested and
+// every DefaultDeletionPollInterval, until ctx is done.
+func (d *AccountDeleter) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(DefaultDeletionPollInterval)
+		defer ticker.Stop()
+		for {
+			for d.RunDue(ctx, time.Now()) == deletionBatch && ctx.Err() == nil {
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			case <-d.wake:
+			}
+		}
+	}()
+}
+
+// RunDue attempts up to deletionBatch erasures due at now and returns how
+// many it attempted.
+func (d *AccountDeleter) RunDue(ctx context.Context, now time.Time) int {
+	claims, err := d.store.ClaimDueTargets(ctx, now, deletionLease, deletionBatch)
+	if err != nil {
+		d.logger.ErrorContext(ctx, "claim account deletion targets failed", "error", err)
+		return 0
+	}
+	for _, c := range claims {
+		d.attempt(ctx, c, now)
+	}
+	return len(claims)
+}
+
+// attempt erases the data of a claimed target and records the outcome. If
+// the outcome cannot be recorded the target is attempted again once its
+// lease expires.
+func (d *AccountDeleter) attempt(ctx context.Context, c DeletionClaim, now time.Time) {
+	err := errUnknownErasureTarget
+	if i := slices.IndexFunc(d.targets, func(t ErasureTarget) bool { return t.Name == c.Target }); i >= 0 {
+		attemptCtx, cancel := context.WithTimeout(ctx, deletionAttemptTimeout)
+		err = d.targets[i].Erase(attemptCtx, c.UserID)
+		cancel()
+	}
+	log := d.logger.With("deletion_id", c.DeletionID, "user_id", c.UserID, "target", c.Target, "attempt", c.Attempt)
+
+	if err == nil {
+		if err := d.store.CompleteTarget(ctx, c.DeletionID, c.Target, now); err != nil {
+			log.ErrorContext(ctx, "record account erasure failed", "error", err)
+			return
+		}
+		log.InfoContext(ctx, "account data erased")
+		return
+	}
+	next := now.Add(deletionRetryDelay(c.Attempt))
+	log.WarnContext(ctx, "account erasure failed", "error", err, "next_attempt_at", next)
+	if err := d.store.RetryTarget(ctx, c.DeletionID, c.Target, err.Error(), next); err != nil {
+		log.ErrorContext(ctx, "record account erasure failure failed", "error", err)
+	}
+}
+
+// deletionRetryDelay returns the delay after the failure of the given
+// attempt, from 1.
+func deletionRetryDelay(attempt int) time.Duration {
+	delay := deletionRetryBase
+	for i := 1; i < attempt && delay < deletionRetryMax; i++ {
+		delay *= 2
+	}
+	return min(delay, deletionRetryMax)
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// AccountDeletionHandler
+// ─────────────────────────────────────────────────────────────────────────────
+
+// AccountDeletionHandler serves DELETE /api/v1/me, which deletes the
+// caller's account, and the admin listing of account deletions.
+type AccountDeletionHandler struct {
+	deleter   *AccountDeleter
+	auth      *AuthHandler
+	users     UserStore
+	readiness ReadinessStore
+	logger    *slog.Logger
+}
+
+// NewAccountDeletionHandler creates an AccountDeletionHandler that erases
+// the backends' data with deleter and ends the user's sessions with auth.
+// Accounts are kept in memory and readiness history is not kept until
+// SetUserStore and SetReadinessStore are called. A nil logger uses
+// slog.Default().
+func NewAccountDeletionHandler(deleter *AccountDeleter, auth *AuthHandler, logger *slog.Logger) *AccountDeletionHandler {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &AccountDeletionHandler{
+		deleter:   deleter,
+		auth:      auth,
+		users:     defaultUserStore,
+		readiness: NewMemoryReadinessStore(0),
+		logger:    logger,
+	}
+}
+
+// SetUserStore replaces the store of user accounts; see
+// AuthHandler.SetUserStore.
+func (h *AccountDeletionHandler) SetUserStore(store UserStore) {
+	h.users = store
+}
+
+// SetReadinessStore sets the store of readiness history to delete from;
+// pass the one given to AnalysisHandler.SetReadinessStore.
+func (h *AccountDeletionHandler) SetReadinessStore(store ReadinessStore) {
+	h.readiness = store
+}
+
+// RegisterRoutes registers the account deletion routes on the mux.
+//
+//	DELETE /api/v1/me                   – delete the caller's account
+//	GET    /api/admin/account-deletions – list account deletions (admin)
+func (h *AccountDeletionHandler) RegisterRoutes(mux openapi.Router, authMiddleware func(http.Handler) http.Handler) {
+	mux.Handle("/api/v1/me", authMiddleware(http.HandlerFunc(h.DeleteAccount)))
+	mux.Handle("/api/admin/account-deletions",
+		authMiddleware(middleware.RequireRole(middleware.RoleAdmin)(http.HandlerFunc(h.ListDeletions))))
+}
+
+// DescribeRoutes documents the routes registered by RegisterRoutes.
+func (h *AccountDeletionHandler) DescribeRoutes(spec *openapi.Spec) {
+	auth := []string{openapi.BearerAuth}
+	spec.Route("/api/v1/me", openapi.Operation{
+		Method:  http.MethodDelete,
+		Summary: "Delete the caller's account",
+		Description: "Ends every session, deletes the account with its profile, resume and readiness " +
+			"history, and erases the user's data from the backend services. The erasure is retried " +
+			"until every service confirms it; the returned deletion is pending until then.",
+		Security: auth,
+		Response: success(types.AccountDeletion{}),
+		Status:   http.StatusAccepted,
+		Errors:   []int{http.StatusUnauthorized, http.StatusNotFound},
+	})
+	spec.Route("/api/admin/account-deletions", openapi.Operation{
+		Method:      http.MethodGet,
+		Summary:     "List account deletions, oldest first",
+		Description: "Requires the admin role. Shows each target's attempts, last error and next attempt.",
+		Security:    auth,
+		Params: []openapi.Param{
+			openapi.Query("status", `"pending" (default), "completed" or "all"`),
+			{Name: "limit", Description: "Maximum number of deletions (default 100, max 500)", Type: 0},
+		},
+		Response: success([]types.AccountDeletion{}),
+		Errors:   []int{http.StatusBadRequest, http.StatusUnauthorized, http.StatusForbidden},
+	})
+}
+
+// DeleteAccount handles DELETE /api/v1/me. The deletion is recorded before
+// anything is deleted, so that the backends' data is erased even if a later
+// step fails and the user has to try again.
+//
+// Response (202):
+//
+//	{"success": true, "data": {"id": "...", "status": "pending", "targets": [...]}}
+func (h *AccountDeletionHandler) DeleteAccount(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodDelete {
+		WriteMethodNotAllowed(w)
+		return
+	}
+	ctx := r.Context()
+	userID := middleware.GetUserID(r)
+	if _, err := h.users.GetByID(ctx, userID); errors.Is(err, ErrUserNotFound) {
+		WriteNotFound(w, "user")
+		return
+	} else if err != nil {
+		WriteInternalError(w)
+		return
+	}
+
+	deletion, err := h.deleter.Request(ctx, userID)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "request account deletion failed", "user_id", userID, "error", err)
+		WriteInternalError(w)
+		return
+	}
+	globalProfileStore.delete(userID)
+	globalResumeStore.delete(userID)
+	if err := h.readiness.DeleteUserHistory(ctx, userID); err != nil {
+		h.logger.ErrorContext(ctx, "delete readiness history failed", "user_id", userID, "error", err)
+		WriteInternalError(w)
+		return
+	}
+	if err := h.users.DeleteUser(ctx, userID); err != nil && !errors.Is(err, ErrUserNotFound) {
+		h.logger.ErrorContext(ctx, "delete user failed", "user_id", userID, "error", err)
+		WriteInternalError(w)
+		return
+	}
+	if err := h.auth.RevokeSessions(userID); err != nil {
+		h.logger.ErrorContext(ctx, "revoke sessions failed", "user_id", userID, "error", err)
+		WriteInternalError(w)
+		return
+	}
+	h.logger.InfoContext(ctx, "account deleted", "user_id", userID, "deletion_id", deletion.ID)
+
+	WriteSuccess(w, http.StatusAccepted, deletion)
+}
+
+// ListDeletions handles GET /api/admin/account-deletions.
+//
+// Query parameters:
+//   - status: "pending" (default), "completed" or "all"
+//   - limit: maximum number of deletions (default 100, max 500)
+func (h *AccountDeletionHandler) ListDeletions(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		WriteMethodNotAllowed(w)
+		return
+	}
+	var status types.DeletionStatus
+	switch s := r.URL.Query().Get("status"); s {
+	case "", string(types.DeletionPending):
+		status = types.DeletionPending
+	case string(types.DeletionCompleted):
+		status = types.DeletionCompleted
+	case "all":
+	default:
+		WriteError(w, http.StatusBadRequest, "VALIDATION_ERROR", `status must be "pending", "completed" or "all"`)
+		return
+	}
+	limit := queryParamInt(r, "limit", 100)
+	if limit <= 0 {
+		limit = 100
+	}
+	limit = min(limit, maxDeletionList)
+
+	deletions, err := h.deleter.List(r.Context(), status, limit)
+	if err != nil {
+		h.logger.ErrorContext(r.Context(), "list account deletions failed", "error", err)
+		WriteInternalError(w)
+		return
+	}
+	WriteSuccess(w, http.StatusOK, deletions)
+}
@@ -0,0 +1,209 @@
+// Package handler – deletion_postgres.go keeps account deletions in the
+// account_deletions tables of the LearnBot database.
+package handler
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/learnbot/api-gateway/internal/types"
+)
+
+// PostgresAccountDeletionStore is an AccountDeletionStore over the
+// account_deletions and account_deletion_targets tables, created by the
+// database module's migrations. Several gateways may share it: a claimed
+// target is skipped by the others until its lease expires.
+type PostgresAccountDeletionStore struct {
+	db *sql.DB
+}
+
+// NewPostgresAccountDeletionStore creates a PostgresAccountDeletionStore
+// using db.
+func NewPostgresAccountDeletionStore(db *sql.DB) *PostgresAccountDeletionStore {
+	return &PostgresAccountDeletionStore{db: db}
+}
+
+// CreateDeletion implements AccountDeletionStore.
+func (s *PostgresAccountDeletionStore) CreateDeletion(ctx context.Context, d types.AccountDeletion) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `
+		INSERT INTO account_deletions (id, user_id, status, requested_at, completed_at)
+		VALUES ($1, $2, $3, $4, $5)`,
+		d.ID, d.UserID, d.Status, d.RequestedAt, d.CompletedAt); err != nil {
+		return fmt.Errorf("insert account deletion: %w", err)
+	}
+	for _, t := range d.Targets {
+		if _, err := tx.ExecContext(ctx, `
+			INSERT INTO account_deletion_targets (deletion_id, target, status, next_attempt_at)
+			VALUES ($1, $2, $3, $4)`,
+			d.ID, t.Name, t.Status, t.NextAttemptAt); err != nil {
+			return fmt.Errorf("insert account deletion target: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("commit transaction: %w", err)
+	}
+	return nil
+}
+
+// ClaimDueTargets implements AccountDeletionStore. Targets locked by a
+// concurrent claim are skipped rather than waited for.
+func (s *PostgresAccountDeletionStore) ClaimDueTargets(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]DeletionClaim, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		WITH due AS (
+			SELECT deletion_id, target
+			FROM account_deletion_targets
+			WHERE status = 'pending' AND next_attempt_at <= $1
+			ORDER BY next_attempt_at
+			LIMIT $3
+			FOR UPDATE SKIP LOCKED
+		)
+		UPDATE account_deletion_targets t
+		SET attempts = t.attempts + 1, next_attempt_at = $2
+		FROM due, account_deletions d
+		WHERE t.deletion_id = due.deletion_id AND t.target = due.target AND d.id = t.deletion_id
+		RETURNING t.deletion_id, d.user_id, t.target, t.attempts`,
+		now, now.Add(lease), limit)
+	if err != nil {
+		return nil, fmt.Errorf("claim account deletion targets: %w", err)
+	}
+	defer rows.Close()
+
+	claims := []DeletionClaim{}
+	for rows.Next() {
+		var c DeletionClaim
+		if err := rows.Scan(&c.DeletionID, &c.UserID, &c.Target, &c.Attempt); err != nil {
+			return nil, fmt.Errorf("scan account deletion claim: %w", err)
+		}
+		claims = append(claims, c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate account deletion claims: %w", err)
+	}
+	return claims, nil
+}
+
+// CompleteTarget implements AccountDeletionStore. The deletion row is
+// locked first, so that targets completed concurrently see each other and
+// the last of them completes the deletion.
+func (s *PostgresAccountDeletionStore) CompleteTarget(ctx context.Context, deletionID, target string, now time.Time) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var id string
+	err = tx.QueryRowContext(ctx, `SELECT id FROM account_deletions WHERE id = $1 FOR UPDATE`, deletionID).Scan(&id)
+	if err == sql.ErrNoRows {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("lock account deletion: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx, `
+		UPDATE account_deletion_targets
+		SET status = 'completed', completed_at = $3, next_attempt_at = NULL, last_error = NULL
+		WHERE deletion_id = $1 AND target = $2 AND status = 'pending'`,
+		deletionID, target, now); err != nil {
+		return fmt.Errorf("complete account deletion target: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx, `
+		UPDATE account_deletions
+		SET status = 'completed', completed_at = $2
+		WHERE id = $1 AND status = 'pending'
+		  AND NOT EXISTS (
+			SELECT 1 FROM account_deletion_targets
+			WHERE deletion_id = $1 AND status = 'pending'
+		  )`, deletionID, now); err != nil {
+		return fmt.Errorf("complete account deletion: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("commit transaction: %w", err)
+	}
+	return nil
+}
+
+// RetryTarget implements AccountDeletionStore.
+func (s *PostgresAccountDeletionStore) RetryTarget(ctx context.Context, deletionID, target, lastError string, next time.Time) error {
+	if _, err := s.db.ExecContext(ctx, `
+		UPDATE account_deletion_targets
+		SET last_error = $3, next_attempt_at = $4
+		WHERE deletion_id = $1 AND target = $2 AND status = 'pending'`,
+		deletionID, target, lastError, next); err != nil {
+		return fmt.Errorf("retry account deletion target: %w", err)
+	}
+	return nil
+}
+
+// ListDeletions implements AccountDeletionStore.
+func (s *PostgresAccountDeletionStore) ListDeletions(ctx context.Context, status types.DeletionStatus, limit int) ([]types.AccountDeletion, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT d.id, d.user_id, d.status, d.requested_at, d.completed_at,
+		       t.target, t.status, t.attempts, COALESCE(t.last_error, ''), t.next_attempt_at, t.completed_at
+		FROM (
+			SELECT * FROM account_deletions
+			WHERE $1 = '' OR status = $1
+			ORDER BY requested_at, id
+			LIMIT $2
+		) d
+		LEFT JOIN account_deletion_targets t ON t.deletion_id = d.id
+		ORDER BY d.requested_at, d.id, t.target`, string(status), limit)
+	if err != nil {
+		return nil, fmt.Errorf("list account deletions: %w", err)
+	}
+	defer rows.Close()
+
+	out := []types.AccountDeletion{}
+	for rows.Next() {
+		var (
+			d                types.AccountDeletion
+			completedAt      sql.NullTime
+			name, tStatus    sql.NullString
+			attempts         sql.NullInt64
+			lastError        sql.NullString
+			nextAt, targetAt sql.NullTime
+		)
+		if err := rows.Scan(&d.ID, &d.UserID, &d.Status, &d.RequestedAt, &completedAt,
+			&name, &tStatus, &attempts, &lastError, &nextAt, &targetAt); err != nil {
+			return nil, fmt.Errorf("scan account deletion: %w", err)
+		}
+		if n := len(out); n == 0 || out[n-1].ID != d.ID {
+			d.CompletedAt = nullTimePtr(completedAt)
+			d.Targets = []types.DeletionTarget{}
+			out = append(out, d)
+		}
+		if name.Valid {
+			last := &out[len(out)-1]
+			last.Targets = append(last.Targets, types.DeletionTarget{
+				Name:          name.String,
+				Status:        types.DeletionStatus(tStatus.String),
+				Attempts:      int(attempts.Int64),
+				LastError:     lastError.String,
+				NextAttemptAt: nullTimePtr(nextAt),
+				CompletedAt:   nullTimePtr(targetAt),
+			})
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate account deletions: %w", err)
+	}
+	return out, nil
+}
+
+// nullTimePtr returns the time of t, or nil if it is NULL.
+func nullTimePtr(t sql.NullTime) *time.Time {
+	if !t.Valid {
+		return nil
+	}
+	return &t.Time
+}
@@ -0,0 +1,412 @@
+package handler_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/learnbot/api-gateway/internal/handler"
+	"github.com/learnbot/api-gateway/internal/middleware"
+	"github.com/learnbot/api-gateway/internal/types"
+)
+
+// testAccountDeletionStore checks the AccountDeletionStore contract on an
+// empty store.
+func testAccountDeletionStore(t *testing.T, store handler.AccountDeletionStore) {
+	t.Helper()
+	ctx := context.Background()
+	now := time.Now().UTC().Truncate(time.Second)
+	lease := 5 * time.Minute
+	newDeletion := func(id, userID string, requestedAt time.Time, targets ...string) types.AccountDeletion {
+		d := types.AccountDeletion{ID: id, UserID: userID, Status: types.DeletionPending, RequestedAt: requestedAt}
+		for _, name := range targets {
+			d.Targets = append(d.Targets, types.DeletionTarget{Name: name, Status: types.DeletionPending, NextAttemptAt: &requestedAt})
+		}
+		return d
+	}
+	first := newDeletion("6f1c1df4-6a4f-4b0e-8a43-1d7f0c4b0a01", "0b8f5c1e-2d3a-4e5f-8a9b-0c1d2e3f4a01",
+		now.Add(-time.Minute), "job-aggregator", "learning-resources")
+	second := newDeletion("6f1c1df4-6a4f-4b0e-8a43-1d7f0c4b0a02", "0b8f5c1e-2d3a-4e5f-8a9b-0c1d2e3f4a02",
+		now, "job-aggregator")
+	for _, d := range []types.AccountDeletion{first, second} {
+		if err := store.CreateDeletion(ctx, d); err != nil {
+			t.Fatalf("CreateDeletion: %v", err)
+		}
+	}
+
+	claims, err := store.ClaimDueTargets(ctx, now, lease, 10)
+	if err != nil {
+		t.Fatalf("ClaimDueTargets: %v", err)
+	}
+	if len(claims) != 3 || claims[0].DeletionID != first.ID || claims[0].UserID != first.UserID ||
+		claims[2].DeletionID != second.ID || claims[0].Attempt != 1 {
+		t.Fatalf("claims = %+v, want the 3 targets, longest due first", claims)
+	}
+	// Claimed targets are leased.
+	if claims, err := store.ClaimDueTargets(ctx, now.Add(time.Minute), lease, 10); err != nil || len(claims) != 0 {
+		t.Errorf("claims during the lease = %+v, %v; want none", claims, err)
+	}
+
+	if err := store.RetryTarget(ctx, first.ID, "job-aggregator", "status 503", now.Add(30*time.Second)); err != nil {
+		t.Fatalf("RetryTarget: %v", err)
+	}
+	claims, err = store.ClaimDueTargets(ctx, now.Add(30*time.Second), lease, 10)
+	if err != nil || len(claims) != 1 || claims[0].Target != "job-aggregator" || claims[0].Attempt != 2 {
+		t.Fatalf("claims after the retry delay = %+v, %v; want the retried target's 2nd attempt", claims, err)
+	}
+
+	done := now.Add(time.Minute)
+	for _, target := range []string{"learning-resources", "job-aggregator", "job-aggregator"} {
+		if err := store.CompleteTarget(ctx, first.ID, target, done); err != nil {
+			t.Fatalf("CompleteTarget(%s): %v", target, err)
+		}
+		done = done.Add(time.Minute)
+	}
+	// A late failure of a completed target is ignored.
+	if err := store.RetryTarget(ctx, first.ID, "job-aggregator", "timeout", now.Add(time.Hour)); err != nil {
+		t.Fatalf("RetryTarget of a completed target: %v", err)
+	}
+
+	completed, err := store.ListDeletions(ctx, types.DeletionCompleted, 10)
+	if err != nil || len(completed) != 1 {
+		t.Fatalf("completed deletions = %+v, %v; want 1", completed, err)
+	}
+	got := completed[0]
+	if got.ID != first.ID || got.CompletedAt == nil || !got.CompletedAt.Equal(now.Add(2*time.Minute)) || len(got.Targets) != 2 {
+		t.Fatalf("completed deletion = %+v", got)
+	}
+	for _, target := range got.Targets {
+		if target.Status != types.DeletionCompleted || target.NextAttemptAt != nil || target.LastError != "" || target.CompletedAt == nil {
+			t.Errorf("completed target = %+v", target)
+		}
+	}
+	// Completing it again did not move its completion time.
+	if jobs := got.Targets[0]; jobs.Name != "job-aggregator" || jobs.Attempts != 2 || !jobs.CompletedAt.Equal(now.Add(2*time.Minute)) {
+		t.Errorf("job-aggregator target = %+v, want 2 attempts completed once", jobs)
+	}
+
+	pending, err := store.ListDeletions(ctx, types.DeletionPending, 10)
+	if err != nil || len(pending) != 1 || pending[0].ID != second.ID || pending[0].Targets[0].Attempts != 1 {
+		t.Errorf("pending deletions = %+v, %v; want the second", pending, err)
+	}
+	if all, err := store.ListDeletions(ctx, "", 10); err != nil || len(all) != 2 || all[0].ID != first.ID {
+		t.Errorf("all deletions = %+v, %v; want both, oldest first", all, err)
+	}
+	if all, err := store.ListDeletions(ctx, "", 1); err != nil || len(all) != 1 {
+		t.Errorf("ListDeletions with limit 1 = %d, %v", len(all), err)
+	}
+
+	// Completed targets are never claimed again.
+	claims, err = store.ClaimDueTargets(ctx, now.Add(24*time.Hour), lease, 10)
+	if err != nil || len(claims) != 1 || claims[0].DeletionID != second.ID {
+		t.Errorf("claims a day later = %+v, %v; want only the pending target", claims, err)
+	}
+}
+
+func TestMemoryAccountDeletionStore(t *testing.T) {
+	testAccountDeletionStore(t, handler.NewMemoryAccountDeletionStore())
+}
+
+func TestPostgresAccountDeletionStore(t *testing.T) {
+	testAccountDeletionStore(t, handler.NewPostgresAccountDeletionStore(openPostgresSchema(t)))
+}
+
+// fakeErasure is an erasure target that fails while failures is positive,
+// counting its calls.
+type fakeErasure struct {
+	mu       sync.Mutex
+	failures int
+	calls    int
+	erased   map[string]int // times each user was erased
+}
+
+func (f *fakeErasure) target(name string) handler.ErasureTarget {
+	return handler.ErasureTarget{Name: name, Erase: func(_ context.Context, userID string) error {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		f.calls++
+		if f.failures > 0 {
+			f.failures--
+			return errors.New("status 503")
+		}
+		if f.erased == nil {
+			f.erased = map[string]int{}
+		}
+		f.erased[userID]++
+		return nil
+	}}
+}
+
+// onlyDeletion returns the one deletion in store.
+func onlyDeletion(t *testing.T, store handler.AccountDeletionStore) types.AccountDeletion {
+	t.Helper()
+	all, err := store.ListDeletions(context.Background(), "", 10)
+	if err != nil || len(all) != 1 {
+		t.Fatalf("deletions = %+v, %v; want 1", all, err)
+	}
+	return all[0]
+}
+
+func TestAccountDeleter_RetriesFailedTargets(t *testing.T) {
+	ctx := context.Background()
+	store := handler.NewMemoryAccountDeletionStore()
+	flaky, steady := &fakeErasure{failures: 2}, &fakeErasure{}
+	deleter := handler.NewAccountDeleter(store, []handler.ErasureTarget{
+		flaky.target("job-aggregator"), steady.target("learning-resources"),
+	}, nil)
+
+	deletion, err := deleter.Request(ctx, "user-1")
+	if err != nil {
+		t.Fatalf("Request: %v", err)
+	}
+	if deletion.Status != types.DeletionPending || len(deletion.Targets) != 2 {
+		t.Fatalf("requested deletion = %+v", deletion)
+	}
+	start := time.Now()
+
+	for _, step := range []struct {
+		at        time.Duration
+		attempted int
+		status    types.DeletionStatus
+		attempts  int
+	}{
+		// Both are attempted; the flaky target fails and waits 30s.
+		{0, 2, types.DeletionPending, 1},
+		{10 * time.Second, 0, types.DeletionPending, 1},
+		// It fails again and waits twice as long.
+		{30 * time.Second, 1, types.DeletionPending, 2},
+		{80 * time.Second, 0, types.DeletionPending, 2},
+		{90 * time.Second, 1, types.DeletionCompleted, 3},
+		// Nothing is sent again once every target has confirmed.
+		{24 * time.Hour, 0, types.DeletionCompleted, 3},
+	} {
+		if n := deleter.RunDue(ctx, start.Add(step.at)); n != step.attempted {
+			t.Errorf("at %v: attempted %d targets, want %d", step.at, n, step.attempted)
+		}
+		d := onlyDeletion(t, store)
+		if d.Status != step.status || d.Targets[0].Attempts != step.attempts {
+			t.Errorf("at %v: deletion %s with %d attempts, want %s with %d", step.at, d.Status, d.Targets[0].Attempts, step.status, step.attempts)
+		}
+		if step.status == types.DeletionPending {
+			if jobs := d.Targets[0]; jobs.LastError == "" || jobs.NextAttemptAt == nil {
+				t.Errorf("at %v: pending target = %+v, want its last error and next attempt", step.at, jobs)
+			}
+			if learning := d.Targets[1]; learning.Status != types.DeletionCompleted {
+				t.Errorf("at %v: learning-resources target is %s", step.at, learning.Status)
+			}
+		}
+	}
+	if flaky.calls != 3 || steady.calls != 1 {
+		t.Errorf("calls = %d and %d, want 3 and 1", flaky.calls, steady.calls)
+	}
+	if d := onlyDeletion(t, store); d.CompletedAt == nil || d.Targets[0].LastError != "" {
+		t.Errorf("completed deletion = %+v", d)
+	}
+}
+
+// lossyDeletionStore loses the first completion recorded, as when the
+// gateway stops between a target's erasure and its recording.
+type lossyDeletionStore struct {
+	*handler.MemoryAccountDeletionStore
+	lost atomic.Bool
+}
+
+func (s *lossyDeletionStore) CompleteTarget(ctx context.Context, deletionID, target string, now time.Time) error {
+	if s.lost.CompareAndSwap(false, true) {
+		return errors.New("connection reset")
+	}
+	return s.MemoryAccountDeletionStore.CompleteTarget(ctx, deletionID, target, now)
+}
+
+func TestAccountDeleter_RedeliveryIsIdempotent(t *testing.T) {
+	ctx := context.Background()
+	store := &lossyDeletionStore{MemoryAccountDeletionStore: handler.NewMemoryAccountDeletionStore()}
+	backend := &fakeErasure{}
+	deleter := handler.NewAccountDeleter(store, []handler.ErasureTarget{backend.target("learning-resources")}, nil)
+	if _, err := deleter.Request(ctx, "user-1"); err != nil {
+		t.Fatalf("Request: %v", err)
+	}
+	start := time.Now()
+
+	// The erasure succeeds but is not recorded, so the target stays pending
+	// and is delivered again once its lease expires.
+	deleter.RunDue(ctx, start)
+	if d := onlyDeletion(t, store); d.Status != types.DeletionPending || backend.erased["user-1"] != 1 {
+		t.Fatalf("after the lost completion: %s, erased %d times", d.Status, backend.erased["user-1"])
+	}
+	if n := deleter.RunDue(ctx, start.Add(time.Minute)); n != 0 {
+		t.Errorf("attempted %d targets during the lease, want 0", n)
+	}
+	if n := deleter.RunDue(ctx, start.Add(6*time.Minute)); n != 1 {
+		t.Fatalf("attempted %d targets after the lease, want 1", n)
+	}
+
+	// The backend had nothing left to erase and confirmed again.
+	d := onlyDeletion(t, store)
+	if d.Status != types.DeletionCompleted || d.Targets[0].Attempts != 2 || backend.erased["user-1"] != 2 {
+		t.Errorf("after redelivery: %+v, erased %d times", d, backend.erased["user-1"])
+	}
+	if n := deleter.RunDue(ctx, start.Add(24*time.Hour)); n != 0 || backend.calls != 2 {
+		t.Errorf("a completed deletion was delivered again: %d attempted, %d calls", n, backend.calls)
+	}
+}
+
+// fakeErasureBackend serves DELETE /admin/users/{id}, or 503 while down,
+// recording the erased user IDs.
+type fakeErasureBackend struct {
+	*httptest.Server
+	down   atomic.Bool
+	mu     sync.Mutex
+	erased []string
+}
+
+func newFakeErasureBackend(t *testing.T, adminKey string) *fakeErasureBackend {
+	t.Helper()
+	b := &fakeErasureBackend{}
+	b.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Admin-API-Key") != adminKey {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		id, ok := strings.CutPrefix(r.URL.Path, "/admin/users/")
+		if r.Method != http.MethodDelete || !ok {
+			http.NotFound(w, r)
+			return
+		}
+		if b.down.Load() {
+			http.Error(w, "unavailable", http.StatusServiceUnavailable)
+			return
+		}
+		b.mu.Lock()
+		b.erased = append(b.erased, id)
+		b.mu.Unlock()
+		w.Write([]byte(`{"success":true}`))
+	}))
+	t.Cleanup(b.Close)
+	return b
+}
+
+func TestDeleteAccount(t *testing.T) {
+	learning := newFakeErasureBackend(t, "test-admin-key")
+	jobs := newFakeErasureBackend(t, "test-admin-key")
+	jobs.down.Store(true)
+
+	jwtCfg := middleware.DefaultJWTConfig("test-secret")
+	authMiddleware := middleware.RequireAuth(jwtCfg)
+	users := handler.NewMemoryUserStore()
+	readiness := handler.NewMemoryReadinessStore(0)
+	authH := handler.NewAuthHandler(jwtCfg)
+	authH.SetUserStore(users)
+	profileH := handler.NewProfileHandler(jwtCfg)
+	profileH.SetUserStore(users)
+	store := handler.NewMemoryAccountDeletionStore()
+	deleter := handler.NewAccountDeleter(store, []handler.ErasureTarget{
+		handler.BackendErasure("job-aggregator", jobs.URL, "test-admin-key", nil),
+		handler.BackendErasure("learning-resources", learning.URL, "test-admin-key", nil),
+	}, nil)
+	deletionH := handler.NewAccountDeletionHandler(deleter, authH, nil)
+	deletionH.SetUserStore(users)
+	deletionH.SetReadinessStore(readiness)
+
+	mux := http.NewServeMux()
+	authH.RegisterRoutes(mux)
+	profileH.RegisterRoutes(mux, authMiddleware)
+	deletionH.RegisterRoutes(mux, authMiddleware)
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	auth := registerForTokens(t, srv, "delete-me@example.com")
+	userID := auth.User.ID
+	readiness.Append(context.Background(), userID, types.ReadinessSnapshot{JobID: "job-001", ReadinessScore: 50})
+	other := registerAndLogin(t, srv, "keep-me@example.com", "password123", "Keep Me")
+
+	resp := doRequest(t, srv, http.MethodDelete, "/api/v1/me", nil, auth.Token)
+	if resp.StatusCode != http.StatusAccepted {
+		t.Fatalf("delete account: expected 202, got %d", resp.StatusCode)
+	}
+	var result struct {
+		Data types.AccountDeletion `json:"data"`
+	}
+	decodeResponse(t, resp, &result)
+	if result.Data.UserID != userID || result.Data.Status != types.DeletionPending || len(result.Data.Targets) != 2 {
+		t.Errorf("deletion = %+v", result.Data)
+	}
+
+	// The account, its sessions and the gateway's data are gone at once.
+	if status, _ := statusAndCode(t, srv, http.MethodGet, "/api/users/profile", auth.Token); status != http.StatusUnauthorized {
+		t.Errorf("profile with the old token: expected 401, got %d", status)
+	}
+	if status, _, _ := refresh(t, srv, auth.RefreshToken); status != http.StatusUnauthorized {
+		t.Errorf("refresh: expected 401, got %d", status)
+	}
+	if _, err := users.GetByID(context.Background(), userID); !errors.Is(err, handler.ErrUserNotFound) {
+		t.Errorf("GetByID after deletion: %v", err)
+	}
+	if history, _ := readiness.UserHistory(context.Background(), userID); len(history) != 0 {
+		t.Errorf("readiness history after deletion: %d snapshots", len(history))
+	}
+	if status, _ := statusAndCode(t, srv, http.MethodGet, "/api/users/profile", other); status != http.StatusOK {
+		t.Errorf("another user's profile: expected 200, got %d", status)
+	}
+
+	// One backend is down: its erasure stays pending for admins to see.
+	deleter.RunDue(context.Background(), time.Now())
+	admin, _, _ := middleware.GenerateToken(jwtCfg, "admin-1", "admin@example.com", []middleware.Role{middleware.RoleAdmin}, true)
+	pending := listDeletions(t, srv, "", admin)
+	if len(pending) != 1 || pending[0].Targets[0].Name != "job-aggregator" || pending[0].Targets[0].LastError == "" ||
+		pending[0].Targets[1].Status != types.DeletionCompleted {
+		t.Fatalf("pending deletions = %+v", pending)
+	}
+
+	jobs.down.Store(false)
+	deleter.RunDue(context.Background(), time.Now().Add(time.Minute))
+	if pending := listDeletions(t, srv, "pending", admin); len(pending) != 0 {
+		t.Errorf("pending deletions after the backend recovered = %+v", pending)
+	}
+	if all := listDeletions(t, srv, "all", admin); len(all) != 1 || all[0].Status != types.DeletionCompleted {
+		t.Errorf("all deletions = %+v", all)
+	}
+	for _, b := range []*fakeErasureBackend{learning, jobs} {
+		if len(b.erased) != 1 || b.erased[0] != userID {
+			t.Errorf("backend erased %v, want [%s]", b.erased, userID)
+		}
+	}
+
+	// The email can be registered again.
+	registerForTokens(t, srv, "delete-me@example.com")
+
+	for _, tt := range []struct {
+		method, path, token string
+		want                int
+	}{
+		{http.MethodDelete, "/api/v1/me", "", http.StatusUnauthorized},
+		{http.MethodGet, "/api/v1/me", other, http.StatusMethodNotAllowed},
+		{http.MethodGet, "/api/admin/account-deletions", other, http.StatusForbidden},
+		{http.MethodGet, "/api/admin/account-deletions?status=failed", admin, http.StatusBadRequest},
+	} {
+		if status, _ := statusAndCode(t, srv, tt.method, tt.path, tt.token); status != tt.want {
+			t.Errorf("%s %s: expected %d, got %d", tt.method, tt.path, tt.want, status)
+		}
+	}
+}
+
+// listDeletions returns the account deletions with the status listed by
+// the admin route.
+func listDeletions(t *testing.T, srv *httptest.Server, status, token string) []types.AccountDeletion {
+	t.Helper()
+	resp := doRequest(t, srv, http.MethodGet, "/api/admin/account-deletions?status="+status, nil, token)
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("list account deletions: expected 200, got %d", resp.StatusCode)
+	}
+	var result struct {
+		Data []types.AccountDeletion `json:"data"`
+	}
+	decodeResponse(t, resp, &result)
+	return result.Data
+}
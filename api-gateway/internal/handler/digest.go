@@ -0,0 +1,145 @@
+// Package handler – digest.go lists the weekly digest's subscribers and
+// implements its unsubscribe link.
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sort"
+
+	"github.com/learnbot/api-gateway/internal/digest"
+	"github.com/learnbot/api-gateway/internal/middleware"
+	"github.com/learnbot/shared/openapi"
+)
+
+// digestSubscriberIDs returns the IDs, in order, of the users after after
+// who opted in to the weekly digest.
+func (s *profileStore) digestSubscriberIDs(after string) []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	var ids []string
+	for id, p := range s.profiles {
+		if p.WeeklyDigest && id > after {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// DigestSubscribers is a digest.SubscriberSource over the in-memory profile
+// store and a UserStore. Users receive the digest once they opt in through
+// PUT /api/users/profile and their email is verified.
+type DigestSubscribers struct {
+	// Users holds the accounts; nil means the in-memory store of handlers
+	// created without one.
+	Users UserStore
+}
+
+// Subscribers implements digest.SubscriberSource.
+func (d DigestSubscribers) Subscribers(ctx context.Context, after string, limit int) ([]digest.Subscriber, error) {
+	users := d.Users
+	if users == nil {
+		users = defaultUserStore
+	}
+	var subs []digest.Subscriber
+	for _, id := range globalProfileStore.digestSubscriberIDs(after) {
+		if len(subs) == limit {
+			break
+		}
+		user, err := users.GetByID(ctx, id)
+		if errors.Is(err, ErrUserNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if !user.Verified {
+			continue
+		}
+		subs = append(subs, digest.Subscriber{
+			UserID:  id,
+			Email:   user.Email,
+			Name:    user.FullName,
+			Profile: buildCandidateProfile(id),
+		})
+	}
+	return subs, nil
+}
+
+// DigestHandler handles the weekly digest's unsubscribe link.
+type DigestHandler struct {
+	jwtCfg middleware.JWTConfig
+	users  UserStore
+}
+
+// NewDigestHandler creates a DigestHandler that verifies unsubscribe tokens
+// with jwtCfg's secret.
+func NewDigestHandler(jwtCfg middleware.JWTConfig) *DigestHandler {
+	return &DigestHandler{jwtCfg: jwtCfg, users: defaultUserStore}
+}
+
+// SetUserStore replaces the store of user accounts; see
+// AuthHandler.SetUserStore.
+func (h *DigestHandler) SetUserStore(store UserStore) {
+	h.users = store
+}
+
+// RegisterRoutes registers the digest routes on the mux. The unsubscribe
+// link is opened from an email, so its token stands in for authentication.
+//
+//	GET /api/users/digest/unsubscribe?token=... – opt out of the weekly digest
+func (h *DigestHandler) RegisterRoutes(mux openapi.Router) {
+	mux.HandleFunc("/api/users/digest/unsubscribe", h.unsubscribe)
+}
+
+// DescribeRoutes documents the routes registered by RegisterRoutes.
+func (h *DigestHandler) DescribeRoutes(spec *openapi.Spec) {
+	spec.Route("/api/users/digest/unsubscribe", openapi.Operation{
+		Method:      http.MethodGet,
+		Summary:     "Unsubscribe from the weekly digest",
+		Description: "Opened from the link in a digest email; the signed token identifies the user.",
+		Params:      []openapi.Param{openapi.Query("token", "Unsubscribe token from the digest email")},
+		Response:    success(openapi.Fields{"message": ""}),
+		Errors:      []int{http.StatusBadRequest},
+	})
+}
+
+// unsubscribe handles GET /api/users/digest/unsubscribe?token=... It opts
+// the user out of the weekly digest; unsubscribing twice succeeds.
+//
+// Response:
+//
+//	{"success": true, "data": {"message": "unsubscribed from the weekly digest"}}
+func (h *DigestHandler) unsubscribe(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		WriteMethodNotAllowed(w)
+		return
+	}
+
+	token := r.URL.Query().Get("token")
+	if token == "" {
+		WriteError(w, http.StatusBadRequest, "VALIDATION_ERROR", "token is required")
+		return
+	}
+	userID, list, err := middleware.ParseUnsubscribeToken(h.jwtCfg, token)
+	if err != nil || list != digest.List {
+		WriteError(w, http.StatusBadRequest, "INVALID_UNSUBSCRIBE_TOKEN", "invalid unsubscribe link")
+		return
+	}
+	_, err = h.users.GetByID(r.Context(), userID)
+	if errors.Is(err, ErrUserNotFound) {
+		WriteError(w, http.StatusBadRequest, "INVALID_UNSUBSCRIBE_TOKEN", "invalid unsubscribe link")
+		return
+	}
+	if err != nil {
+		WriteInternalError(w)
+		return
+	}
+	globalProfileStore.update(userID, func(p *profileRecord) {
+		p.WeeklyDigest = false
+	})
+
+	WriteSuccess(w, http.StatusOK, map[string]string{"message": "unsubscribed from the weekly digest"})
+}
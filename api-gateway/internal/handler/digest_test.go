@@ -0,0 +1,98 @@
+package handler_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/learnbot/api-gateway/internal/digest"
+	"github.com/learnbot/api-gateway/internal/handler"
+	"github.com/learnbot/api-gateway/internal/middleware"
+)
+
+// isSubscriber returns the digest subscriber with userID, or nil if the
+// user is not subscribed.
+func isSubscriber(t *testing.T, userID string) *digest.Subscriber {
+	t.Helper()
+	subs, err := handler.DigestSubscribers{}.Subscribers(context.Background(), "", 1<<20)
+	if err != nil {
+		t.Fatalf("Subscribers: %v", err)
+	}
+	for i := range subs {
+		if subs[i].UserID == userID {
+			return &subs[i]
+		}
+	}
+	return nil
+}
+
+func TestDigest_OptInAndUnsubscribe(t *testing.T) {
+	srv, sent := verificationTestServer(t, 0)
+	mux := http.NewServeMux()
+	handler.NewDigestHandler(middleware.DefaultJWTConfig("test-secret")).RegisterRoutes(mux)
+	digestSrv := httptest.NewServer(mux)
+	t.Cleanup(digestSrv.Close)
+
+	auth := registerForTokens(t, srv, "digest-optin@example.com")
+	link := verificationToken(t, sent, "digest-optin@example.com")
+	userID := auth.User.ID
+
+	resp := doRequest(t, srv, http.MethodPut, "/api/users/profile", map[string]bool{"weekly_digest": true}, auth.Token)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("opt in: expected 200, got %d", resp.StatusCode)
+	}
+	doRequest(t, srv, http.MethodPut, "/api/profile/skills",
+		map[string]interface{}{"skills": []map[string]string{{"name": "Go", "proficiency": "advanced"}}}, auth.Token).Body.Close()
+
+	// The digest waits for a verified email.
+	if isSubscriber(t, userID) != nil {
+		t.Fatal("an unverified user is a subscriber")
+	}
+	statusAndCode(t, srv, http.MethodGet, "/api/auth/verify?token="+link, "")
+	sub := isSubscriber(t, userID)
+	if sub == nil {
+		t.Fatal("an opted-in, verified user is not a subscriber")
+	}
+	if sub.Email != "digest-optin@example.com" || len(sub.Profile.Skills) != 1 || sub.Profile.Skills[0].Name != "Go" {
+		t.Errorf("unexpected subscriber %+v", sub)
+	}
+
+	token, err := middleware.GenerateUnsubscribeToken(middleware.DefaultJWTConfig("test-secret"), userID, digest.List)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 2; i++ {
+		if status, code := statusAndCode(t, digestSrv, http.MethodGet, "/api/users/digest/unsubscribe?token="+token, ""); status != http.StatusOK {
+			t.Fatalf("unsubscribe %d: expected 200, got %d %q", i+1, status, code)
+		}
+	}
+	if isSubscriber(t, userID) != nil {
+		t.Error("still a subscriber after unsubscribing")
+	}
+}
+
+func TestDigest_UnsubscribeInvalidLinks(t *testing.T) {
+	jwtCfg := middleware.DefaultJWTConfig("test-secret")
+	mux := http.NewServeMux()
+	handler.NewDigestHandler(jwtCfg).RegisterRoutes(mux)
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+
+	otherList, _ := middleware.GenerateUnsubscribeToken(jwtCfg, "user-1", "newsletter")
+	unknownUser, _ := middleware.GenerateUnsubscribeToken(jwtCfg, "no-such-user", digest.List)
+	tests := []struct {
+		name, query, code string
+	}{
+		{"missing", "", "VALIDATION_ERROR"},
+		{"garbage", "?token=not-a-token", "INVALID_UNSUBSCRIBE_TOKEN"},
+		{"other list", "?token=" + otherList, "INVALID_UNSUBSCRIBE_TOKEN"},
+		{"unknown user", "?token=" + unknownUser, "INVALID_UNSUBSCRIBE_TOKEN"},
+	}
+	for _, tt := range tests {
+		if status, code := statusAndCode(t, srv, http.MethodGet, "/api/users/digest/unsubscribe"+tt.query, ""); status != http.StatusBadRequest || code != tt.code {
+			t.Errorf("%s: expected 400 %s, got %d %q", tt.name, tt.code, status, code)
+		}
+	}
+}
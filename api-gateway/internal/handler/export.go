@@ -0,0 +1,398 @@
+// Package handler – export.go streams a user's data as a zip archive, for
+// data portability requests.
+package handler
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/learnbot/api-gateway/internal/middleware"
+	"github.com/learnbot/api-gateway/internal/types"
+	"github.com/learnbot/resume-parser/pkg/parse"
+	"github.com/learnbot/shared/logging"
+	"github.com/learnbot/shared/openapi"
+)
+
+const (
+	// DefaultExportCooldown is how long a user waits between exports.
+	DefaultExportCooldown = time.Hour
+
+	// ExportFormatVersion is the version of the export archive layout,
+	// recorded in its manifest.
+	ExportFormatVersion = 1
+
+	// exportTimeout bounds gathering and writing one export, and extends
+	// the server's write timeout for it.
+	exportTimeout = 2 * time.Minute
+
+	// maxExportBody caps the size of a backend response read for an export.
+	maxExportBody = 16 << 20
+)
+
+// errExportUnavailable is the error recorded in errors.json for a category
+// that failed; the cause is logged rather than shown to the user.
+const errExportUnavailable = "temporarily unavailable; request a new export to try again"
+
+// ExportSource gathers one category of a user's data for an export.
+type ExportSource struct {
+	// Category names the data and its file in the archive, <Category>.json.
+	Category string
+
+	// SchemaVersion is the version of the file's format.
+	SchemaVersion int
+
+	// Fetch returns the data of userID, encoded as JSON in the file.
+	Fetch func(ctx context.Context, userID string) (any, error)
+}
+
+// ExportHandler serves GET /api/v1/me/export: a zip of one JSON file per
+// source, errors.json listing the sources that failed, and manifest.json.
+// Each user may export once per cooldown.
+type ExportHandler struct {
+	sources  []ExportSource
+	cooldown time.Duration
+	now      func() time.Time
+	logger   *slog.Logger
+
+	mu         sync.Mutex
+	lastExport map[string]time.Time // keyed by user ID
+}
+
+// NewExportHandler creates an ExportHandler that writes sources in order,
+// allowing one export per user per DefaultExportCooldown. A nil logger uses
+// slog.Default().
+func NewExportHandler(sources []ExportSource, logger *slog.Logger) *ExportHandler {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &ExportHandler{
+		sources:    sources,
+		cooldown:   DefaultExportCooldown,
+		now:        time.Now,
+		logger:     logger,
+		lastExport: make(map[string]time.Time),
+	}
+}
+
+// RegisterRoutes registers the export route on the mux. It takes
+// precedence over the /api/v1/me/ group proxied to the job aggregator.
+//
+//	GET /api/v1/me/export – download the current user's data
+func (h *ExportHandler) RegisterRoutes(mux openapi.Router, authMiddleware func(http.Handler) http.Handler) {
+	mux.Handle("/api/v1/me/export", authMiddleware(http.HandlerFunc(h.Export)))
+}
+
+// DescribeRoutes documents the routes registered by RegisterRoutes.
+func (h *ExportHandler) DescribeRoutes(spec *openapi.Spec) {
+	var categories []string
+	for _, s := range h.sources {
+		categories = append(categories, s.Category+".json")
+	}
+	spec.Route("/api/v1/me/export", openapi.Operation{
+		Method:  http.MethodGet,
+		Summary: "Download the current user's data as a zip archive",
+		Description: "The archive holds " + strings.Join(categories, ", ") + " and manifest.json, " +
+			"which records the export time and each file's schema version. A category that cannot be " +
+			"read, such as when its backend is down, is listed in errors.json instead. One export is " +
+			"allowed per hour; sooner requests answer 429 with Retry-After. An export with errors does " +
+			"not count.",
+		Security:     []string{openapi.BearerAuth},
+		Response:     openapi.File{},
+		ResponseType: "application/zip",
+		Errors:       []int{http.StatusUnauthorized, http.StatusTooManyRequests},
+	})
+}
+
+// Export handles GET /api/v1/me/export.
+//
+// The archive is written to the response as each category is read, so that
+// only one category is held in memory at a time. Once it has started, a
+// failure can no longer change the status: a failed category is recorded in
+// errors.json, and a failed write ends the response.
+func (h *ExportHandler) Export(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		WriteMethodNotAllowed(w)
+		return
+	}
+	userID := middleware.GetUserID(r)
+	if userID == "" {
+		WriteError(w, http.StatusUnauthorized, "UNAUTHORIZED", "authentication required")
+		return
+	}
+	now := h.now()
+	if wait := h.claim(userID, now); wait > 0 {
+		w.Header().Set("Retry-After", strconv.Itoa(max(1, int((wait+time.Second-1)/time.Second))))
+		WriteError(w, http.StatusTooManyRequests, "TOO_MANY_EXPORTS",
+			"an export was made recently, please try again later")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), exportTimeout)
+	defer cancel()
+	// The server's write timeout is shorter than an export can take.
+	if err := http.NewResponseController(w).SetWriteDeadline(now.Add(exportTimeout)); err != nil &&
+		!errors.Is(err, http.ErrNotSupported) {
+		h.logger.WarnContext(ctx, "extend export write deadline failed", "error", err)
+	}
+
+	w.Header().Set("Content-Type", "application/zip")
+	w.Header().Set("Content-Disposition",
+		fmt.Sprintf(`attachment; filename="learnbot-export-%s.zip"`, now.UTC().Format("2006-01-02")))
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(http.StatusOK)
+
+	manifest := types.ExportManifest{
+		FormatVersion: ExportFormatVersion,
+		UserID:        userID,
+		ExportedAt:    now.UTC(),
+		Files:         []types.ExportFile{},
+	}
+	var failures []types.ExportError
+	zw := zip.NewWriter(w)
+	for _, src := range h.sources {
+		data, err := src.Fetch(ctx, userID)
+		if err != nil {
+			h.logger.ErrorContext(ctx, "export category failed", "category", src.Category, "user_id", userID, "error", err)
+			failures = append(failures, types.ExportError{Category: src.Category, Error: errExportUnavailable})
+			manifest.Errors = append(manifest.Errors, src.Category)
+			continue
+		}
+		name := src.Category + ".json"
+		if err := writeExportFile(zw, name, now, data); err != nil {
+			h.abort(ctx, userID, now, err)
+			return
+		}
+		manifest.Files = append(manifest.Files,
+			types.ExportFile{Name: name, Category: src.Category, SchemaVersion: src.SchemaVersion})
+	}
+	if len(failures) > 0 {
+		if err := writeExportFile(zw, "errors.json", now, map[string]any{"errors": failures}); err != nil {
+			h.abort(ctx, userID, now, err)
+			return
+		}
+	}
+	if err := writeExportFile(zw, "manifest.json", now, manifest); err != nil {
+		h.abort(ctx, userID, now, err)
+		return
+	}
+	if err := zw.Close(); err != nil {
+		h.abort(ctx, userID, now, err)
+		return
+	}
+	if len(failures) > 0 {
+		h.release(userID, now)
+	}
+}
+
+// claim records an export by userID at now unless one was made within the
+// cooldown, in which case it returns the time left.
+func (h *ExportHandler) claim(userID string, now time.Time) time.Duration {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if wait := h.cooldown - now.Sub(h.lastExport[userID]); wait > 0 {
+		return wait
+	}
+	h.lastExport[userID] = now
+	for id, t := range h.lastExport {
+		if now.Sub(t) >= h.cooldown {
+			delete(h.lastExport, id)
+		}
+	}
+	return 0
+}
+
+// release forgets the export by userID claimed at, so that an incomplete
+// export can be retried at once.
+func (h *ExportHandler) release(userID string, at time.Time) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.lastExport[userID].Equal(at) {
+		delete(h.lastExport, userID)
+	}
+}
+
+// abort logs an export that could not be written, typically because the
+// client went away, and releases its claim.
+func (h *ExportHandler) abort(ctx context.Context, userID string, at time.Time, err error) {
+	h.logger.WarnContext(ctx, "write export failed", "user_id", userID, "error", err)
+	h.release(userID, at)
+}
+
+// writeExportFile adds a file holding v as indented JSON to zw.
+func writeExportFile(zw *zip.Writer, name string, modified time.Time, v any) error {
+	f, err := zw.CreateHeader(&zip.FileHeader{Name: name, Method: zip.Deflate, Modified: modified})
+	if err != nil {
+		return err
+	}
+	enc := json.NewEncoder(f)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Sources
+// ─────────────────────────────────────────────────────────────────────────────
+
+// exportProfile is the profile.json of an export.
+type exportProfile struct {
+	UserID            string        `json:"user_id"`
+	Email             string        `json:"email"`
+	FullName          string        `json:"full_name"`
+	EmailVerified     bool          `json:"email_verified"`
+	Roles             []string      `json:"roles"`
+	CreatedAt         time.Time     `json:"created_at"`
+	Headline          string        `json:"headline"`
+	Summary           string        `json:"summary"`
+	LocationCity      string        `json:"location_city"`
+	LocationCountry   string        `json:"location_country"`
+	LinkedInURL       string        `json:"linkedin_url"`
+	GitHubURL         string        `json:"github_url"`
+	WebsiteURL        string        `json:"website_url"`
+	YearsOfExperience float64       `json:"years_of_experience"`
+	IsOpenToWork      bool          `json:"is_open_to_work"`
+	WeeklyDigest      bool          `json:"weekly_digest"`
+	Skills            []exportSkill `json:"skills"`
+	UpdatedAt         time.Time     `json:"updated_at"`
+}
+
+// exportSkill is a skill in profile.json.
+type exportSkill struct {
+	Name                string  `json:"name"`
+	Proficiency         string  `json:"proficiency"`
+	ProficiencyInferred bool    `json:"proficiency_inferred"`
+	YearsOfExperience   float64 `json:"years_of_experience"`
+	IsPrimary           bool    `json:"is_primary"`
+}
+
+// exportResume is an entry of resumes.json.
+type exportResume struct {
+	ID         string              `json:"id"`
+	FileName   string              `json:"file_name"`
+	ParsedAt   time.Time           `json:"parsed_at"`
+	ParsedData *parse.ParsedResume `json:"parsed_data"`
+}
+
+// ProfileExport exports the user's account, from users, and profile. The
+// password hash is left out.
+func ProfileExport(users UserStore) ExportSource {
+	return ExportSource{Category: "profile", SchemaVersion: 1, Fetch: func(ctx context.Context, userID string) (any, error) {
+		user, err := users.GetByID(ctx, userID)
+		if err != nil {
+			return nil, err
+		}
+		p := globalProfileStore.get(userID)
+		out := exportProfile{
+			UserID:            userID,
+			Email:             user.Email,
+			FullName:          user.FullName,
+			EmailVerified:     user.Verified,
+			Roles:             []string{},
+			CreatedAt:         user.CreatedAt,
+			Headline:          p.Headline,
+			Summary:           p.Summary,
+			LocationCity:      p.LocationCity,
+			LocationCountry:   p.LocationCountry,
+			LinkedInURL:       p.LinkedInURL,
+			GitHubURL:         p.GitHubURL,
+			WebsiteURL:        p.WebsiteURL,
+			YearsOfExperience: p.YearsOfExperience,
+			IsOpenToWork:      p.IsOpenToWork,
+			WeeklyDigest:      p.WeeklyDigest,
+			Skills:            make([]exportSkill, len(p.Skills)),
+			UpdatedAt:         p.UpdatedAt,
+		}
+		for _, role := range user.Roles {
+			out.Roles = append(out.Roles, string(role))
+		}
+		for i, s := range p.Skills {
+			out.Skills[i] = exportSkill{
+				Name:                s.Name,
+				Proficiency:         s.Proficiency,
+				ProficiencyInferred: s.ProficiencyInferred,
+				YearsOfExperience:   s.YearsOfExperience,
+				IsPrimary:           s.IsPrimary,
+			}
+		}
+		return out, nil
+	}}
+}
+
+// ResumeExport exports the parse results of the user's uploaded resume.
+// Only the latest upload is kept, so the list holds at most one.
+func ResumeExport() ExportSource {
+	return ExportSource{Category: "resumes", SchemaVersion: 1, Fetch: func(_ context.Context, userID string) (any, error) {
+		resumes := []exportResume{}
+		if rec, ok := globalResumeStore.get(userID); ok {
+			resumes = append(resumes, exportResume{
+				ID:         rec.ID,
+				FileName:   rec.FileName,
+				ParsedAt:   rec.ParsedAt,
+				ParsedData: rec.ParsedData,
+			})
+		}
+		return resumes, nil
+	}}
+}
+
+// ReadinessExport exports the user's readiness history for every job.
+func ReadinessExport(store ReadinessStore) ExportSource {
+	return ExportSource{Category: "readiness_history", SchemaVersion: 1, Fetch: func(ctx context.Context, userID string) (any, error) {
+		return store.UserHistory(ctx, userID)
+	}}
+}
+
+// SavedJobsExport exports the user's saved jobs, each with its job, as the
+// job aggregator returns them.
+func SavedJobsExport(saved *AggregatorSavedJobs) ExportSource {
+	return ExportSource{Category: "saved_jobs", SchemaVersion: 1, Fetch: func(ctx context.Context, userID string) (any, error) {
+		return saved.list(ctx, userID)
+	}}
+}
+
+// LearningProgressExport exports the user's progress on every learning
+// resource, as the learning resources service at baseURL returns it. A nil
+// client uses one with DefaultBackendTimeout.
+func LearningProgressExport(baseURL string, client *http.Client) ExportSource {
+	if client == nil {
+		client = &http.Client{Transport: logging.Transport(nil), Timeout: DefaultBackendTimeout}
+	}
+	baseURL = strings.TrimRight(baseURL, "/")
+	return ExportSource{Category: "learning_progress", SchemaVersion: 1, Fetch: func(ctx context.Context, userID string) (any, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet,
+			baseURL+"/api/v1/users/"+url.PathEscape(userID)+"/progress", nil)
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Accept", "application/json")
+		req.Header.Set("X-User-ID", userID)
+		resp, err := client.Do(req)
+		if err != nil {
+			return nil, err
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("GET %s: status %d", req.URL.Path, resp.StatusCode)
+		}
+		var body struct {
+			Data []json.RawMessage `json:"data"`
+		}
+		if err := json.NewDecoder(io.LimitReader(resp.Body, maxExportBody)).Decode(&body); err != nil {
+			return nil, fmt.Errorf("GET %s: decode response: %w", req.URL.Path, err)
+		}
+		if body.Data == nil {
+			body.Data = []json.RawMessage{}
+		}
+		return body.Data, nil
+	}}
+}
@@ -0,0 +1,227 @@
+package handler_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/learnbot/api-gateway/internal/handler"
+	"github.com/learnbot/api-gateway/internal/middleware"
+	"github.com/learnbot/api-gateway/internal/types"
+)
+
+// exportTestServer serves the auth, profile, analysis and export routes,
+// exporting learning progress from learningURL and saved jobs from
+// jobsURL.
+func exportTestServer(t *testing.T, learningURL, jobsURL string) *httptest.Server {
+	t.Helper()
+	jwtCfg := middleware.DefaultJWTConfig("test-secret")
+	authMiddleware := middleware.RequireAuth(jwtCfg)
+	users := handler.NewMemoryUserStore()
+	readiness := handler.NewMemoryReadinessStore(0)
+	authH := handler.NewAuthHandler(jwtCfg)
+	authH.SetUserStore(users)
+	analysisH := handler.NewAnalysisHandler()
+	analysisH.SetReadinessStore(readiness)
+	exportH := handler.NewExportHandler([]handler.ExportSource{
+		handler.ProfileExport(users),
+		handler.ResumeExport(),
+		handler.LearningProgressExport(learningURL, nil),
+		handler.SavedJobsExport(handler.NewAggregatorSavedJobs(jobsURL, nil)),
+		handler.ReadinessExport(readiness),
+	}, nil)
+
+	mux := http.NewServeMux()
+	authH.RegisterRoutes(mux)
+	handler.NewProfileHandler(jwtCfg).RegisterRoutes(mux, authMiddleware)
+	analysisH.RegisterRoutes(mux, authMiddleware)
+	exportH.RegisterRoutes(mux, authMiddleware)
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+// newFakeProgressBackend serves a learning resources progress list, or 503
+// if down.
+func newFakeProgressBackend(t *testing.T, down bool) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if down {
+			http.Error(w, "unavailable", http.StatusServiceUnavailable)
+			return
+		}
+		if !strings.HasSuffix(r.URL.Path, "/progress") || r.Header.Get("X-User-ID") == "" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(`{"success":true,"data":[{"resource_id":"res-1","status":"completed","progress_percentage":100}]}`))
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+// exportArchive downloads an export and returns its files by name.
+func exportArchive(t *testing.T, srv *httptest.Server, token string) map[string][]byte {
+	t.Helper()
+	resp := doRequest(t, srv, http.MethodGet, "/api/v1/me/export", nil, token)
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("export: expected 200, got %d", resp.StatusCode)
+	}
+	if ct := resp.Header.Get("Content-Type"); ct != "application/zip" {
+		t.Errorf("Content-Type = %q", ct)
+	}
+	if cd := resp.Header.Get("Content-Disposition"); !strings.HasPrefix(cd, `attachment; filename="learnbot-export-`) {
+		t.Errorf("Content-Disposition = %q", cd)
+	}
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("read export: %v", err)
+	}
+	zr, err := zip.NewReader(bytes.NewReader(body), int64(len(body)))
+	if err != nil {
+		t.Fatalf("open export: %v", err)
+	}
+	files := map[string][]byte{}
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		if err != nil {
+			t.Fatalf("open %s: %v", f.Name, err)
+		}
+		files[f.Name], err = io.ReadAll(rc)
+		rc.Close()
+		if err != nil {
+			t.Fatalf("read %s: %v", f.Name, err)
+		}
+	}
+	return files
+}
+
+func TestExport(t *testing.T) {
+	jobs := newFakeSavedJobsBackend(t, `[
+		{"id": "saved-1", "notes": "apply soon", "job": {"title": "Go Developer", "required_skills": ["Go"]}},
+		{"id": "saved-2", "job": null}
+	]`)
+	srv := exportTestServer(t, newFakeProgressBackend(t, false).URL, jobs.URL)
+	token := registerAndLogin(t, srv, "export@example.com", "password123", "Export User")
+	doRequest(t, srv, http.MethodPut, "/api/profile/skills", types.SkillUpdateRequest{
+		Skills: []types.SkillInput{{Name: "Go", Proficiency: "advanced"}},
+	}, token).Body.Close()
+	doRequest(t, srv, http.MethodPost, "/api/analysis/gaps", types.GapAnalysisRequest{JobID: "job-001"}, token).Body.Close()
+
+	files := exportArchive(t, srv, token)
+	if _, ok := files["errors.json"]; ok {
+		t.Errorf("unexpected errors.json: %s", files["errors.json"])
+	}
+
+	var manifest types.ExportManifest
+	if err := json.Unmarshal(files["manifest.json"], &manifest); err != nil {
+		t.Fatalf("manifest: %v", err)
+	}
+	if manifest.FormatVersion != handler.ExportFormatVersion || manifest.UserID == "" || manifest.ExportedAt.IsZero() ||
+		len(manifest.Files) != 5 || len(manifest.Errors) != 0 {
+		t.Fatalf("unexpected manifest %+v", manifest)
+	}
+	for _, f := range manifest.Files {
+		if _, ok := files[f.Name]; !ok || f.SchemaVersion < 1 {
+			t.Errorf("manifest lists %+v, archive has %v", f, ok)
+		}
+	}
+
+	var profile map[string]any
+	if err := json.Unmarshal(files["profile.json"], &profile); err != nil {
+		t.Fatalf("profile: %v", err)
+	}
+	if profile["email"] != "export@example.com" || profile["full_name"] != "Export User" {
+		t.Errorf("unexpected profile %v", profile)
+	}
+	if bytes.Contains(files["profile.json"], []byte("password")) {
+		t.Error("profile.json holds the password hash")
+	}
+	if skills, _ := profile["skills"].([]any); len(skills) != 1 {
+		t.Errorf("skills = %v", profile["skills"])
+	}
+
+	// Saved jobs are exported as the job aggregator returns them.
+	var saved []map[string]any
+	if err := json.Unmarshal(files["saved_jobs.json"], &saved); err != nil || len(saved) != 2 || saved[0]["notes"] != "apply soon" {
+		t.Errorf("saved_jobs.json = %s (%v)", files["saved_jobs.json"], err)
+	}
+	var progress []map[string]any
+	if err := json.Unmarshal(files["learning_progress.json"], &progress); err != nil || len(progress) != 1 {
+		t.Errorf("learning_progress.json = %s (%v)", files["learning_progress.json"], err)
+	}
+	var readiness []types.ReadinessSnapshot
+	if err := json.Unmarshal(files["readiness_history.json"], &readiness); err != nil ||
+		len(readiness) != 1 || readiness[0].JobID != "job-001" {
+		t.Errorf("readiness_history.json = %s (%v)", files["readiness_history.json"], err)
+	}
+	if strings.TrimSpace(string(files["resumes.json"])) != "[]" {
+		t.Errorf("resumes.json = %s", files["resumes.json"])
+	}
+}
+
+func TestExport_PartialFailure(t *testing.T) {
+	jobs := newFakeSavedJobsBackend(t, `[]`)
+	srv := exportTestServer(t, newFakeProgressBackend(t, true).URL, jobs.URL)
+	token := registerAndLogin(t, srv, "export-partial@example.com", "password123", "Partial User")
+
+	files := exportArchive(t, srv, token)
+	if _, ok := files["learning_progress.json"]; ok {
+		t.Error("the failed category was exported")
+	}
+	if _, ok := files["profile.json"]; !ok {
+		t.Error("the other categories were not exported")
+	}
+	var errs struct {
+		Errors []types.ExportError `json:"errors"`
+	}
+	if err := json.Unmarshal(files["errors.json"], &errs); err != nil ||
+		len(errs.Errors) != 1 || errs.Errors[0].Category != "learning_progress" || errs.Errors[0].Error == "" {
+		t.Fatalf("errors.json = %s (%v)", files["errors.json"], err)
+	}
+	if bytes.Contains(files["errors.json"], []byte("503")) {
+		t.Errorf("errors.json exposes the backend error: %s", files["errors.json"])
+	}
+	var manifest types.ExportManifest
+	if err := json.Unmarshal(files["manifest.json"], &manifest); err != nil ||
+		len(manifest.Errors) != 1 || len(manifest.Files) != 4 {
+		t.Errorf("manifest.json = %s (%v)", files["manifest.json"], err)
+	}
+
+	// An incomplete export does not use up the hourly export.
+	exportArchive(t, srv, token)
+}
+
+func TestExport_RateLimit(t *testing.T) {
+	srv := exportTestServer(t, newFakeProgressBackend(t, false).URL, newFakeSavedJobsBackend(t, `[]`).URL)
+	token := registerAndLogin(t, srv, "export-limit@example.com", "password123", "Limit User")
+	other := registerAndLogin(t, srv, "export-other@example.com", "password123", "Other User")
+
+	exportArchive(t, srv, token)
+	resp := doRequest(t, srv, http.MethodGet, "/api/v1/me/export", nil, token)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusTooManyRequests || resp.Header.Get("Retry-After") == "" {
+		t.Errorf("second export: expected 429 with Retry-After, got %d %q", resp.StatusCode, resp.Header.Get("Retry-After"))
+	}
+	exportArchive(t, srv, other)
+
+	for _, tt := range []struct {
+		method, token string
+		want          int
+	}{
+		{http.MethodGet, "", http.StatusUnauthorized},
+		{http.MethodPost, other, http.StatusMethodNotAllowed},
+	} {
+		resp := doRequest(t, srv, tt.method, "/api/v1/me/export", nil, tt.token)
+		resp.Body.Close()
+		if resp.StatusCode != tt.want {
+			t.Errorf("%s %q: expected %d, got %d", tt.method, tt.token, tt.want, resp.StatusCode)
+		}
+	}
+}
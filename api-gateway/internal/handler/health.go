@@ -0,0 +1,135 @@
+// Package handler – health.go implements the aggregate health check, which
+// probes every backend the gateway proxies to.
+package handler
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/learnbot/api-gateway/internal/types"
+	"github.com/learnbot/shared/logging"
+	"github.com/learnbot/shared/openapi"
+)
+
+// DefaultHealthTimeout bounds how long /health waits for each backend before
+// reporting it as down.
+const DefaultHealthTimeout = 2 * time.Second
+
+// HealthHandler reports the gateway's status together with the health of
+// each backend.
+type HealthHandler struct {
+	backends []Backend
+	client   *http.Client
+	timeout  time.Duration
+}
+
+// NewHealthHandler creates a new HealthHandler. A non-positive timeout uses
+// DefaultHealthTimeout.
+func NewHealthHandler(backends []Backend, timeout time.Duration) *HealthHandler {
+	if timeout <= 0 {
+		timeout = DefaultHealthTimeout
+	}
+	return &HealthHandler{
+		backends: backends,
+		client:   &http.Client{Transport: logging.Transport(nil)},
+		timeout:  timeout,
+	}
+}
+
+// RegisterRoutes registers the health route on the mux.
+//
+//	GET /health – gateway and per-backend health
+func (h *HealthHandler) RegisterRoutes(mux openapi.Router) {
+	mux.HandleFunc("/health", h.Health)
+}
+
+// DescribeRoutes documents the routes registered by RegisterRoutes.
+func (h *HealthHandler) DescribeRoutes(spec *openapi.Spec) {
+	spec.Route("/health", openapi.Operation{
+		Method:   http.MethodGet,
+		Summary:  "Gateway and per-backend health",
+		Response: types.HealthResponse{},
+	})
+}
+
+// Health handles GET /health.
+//
+// Backends are probed concurrently. The overall status is "degraded" when any
+// backend is down, but the response is still 200: the gateway itself is up
+// and keeps serving the routes whose backends are healthy.
+func (h *HealthHandler) Health(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		WriteMethodNotAllowed(w)
+		return
+	}
+
+	services := make([]types.ServiceHealth, len(h.backends))
+	var wg sync.WaitGroup
+	for i, b := range h.backends {
+		wg.Add(1)
+		go func(i int, b Backend) {
+			defer wg.Done()
+			services[i] = h.probe(r.Context(), b)
+		}(i, b)
+	}
+	wg.Wait()
+
+	status := "ok"
+	for _, s := range services {
+		if s.Status != "ok" {
+			status = "degraded"
+		}
+	}
+
+	writeJSON(w, http.StatusOK, types.HealthResponse{
+		Status:   status,
+		Service:  "api-gateway",
+		Version:  "1.0.0",
+		Services: services,
+	})
+}
+
+// probe checks a single backend's health endpoint.
+func (h *HealthHandler) probe(ctx context.Context, b Backend) types.ServiceHealth {
+	start := time.Now()
+	err := h.check(ctx, b)
+	result := types.ServiceHealth{
+		Name:      b.Name,
+		Status:    "ok",
+		LatencyMS: time.Since(start).Milliseconds(),
+	}
+	if err != nil {
+		result.Status = "down"
+		result.Error = err.Error()
+	}
+	return result
+}
+
+// check requests the backend's HealthPath. Any 2xx response counts as
+// healthy.
+func (h *HealthHandler) check(ctx context.Context, b Backend) error {
+	ctx, cancel := context.WithTimeout(ctx, h.timeout)
+	defer cancel()
+
+	target := strings.TrimSuffix(b.URL, "/") + b.HealthPath
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := h.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, maxDataQualityBody))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+	return nil
+}
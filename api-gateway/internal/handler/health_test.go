@@ -0,0 +1,77 @@
+package handler_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/learnbot/api-gateway/internal/handler"
+	"github.com/learnbot/api-gateway/internal/types"
+)
+
+// healthServer creates a gateway exposing only /health.
+func healthServer(backends []handler.Backend, timeout time.Duration) *httptest.Server {
+	mux := http.NewServeMux()
+	handler.NewHealthHandler(backends, timeout).RegisterRoutes(mux)
+	return httptest.NewServer(mux)
+}
+
+func TestHealth_AllBackendsUp(t *testing.T) {
+	up := staticBackend(http.StatusOK, `{"status":"ok"}`)
+	defer up.Close()
+
+	srv := healthServer([]handler.Backend{
+		{Name: "job-aggregator", URL: up.URL, HealthPath: "/admin/health"},
+		{Name: "resume-parser", URL: up.URL + "/", HealthPath: "/api/v1/health"},
+	}, time.Second)
+	defer srv.Close()
+
+	resp := doRequest(t, srv, http.MethodGet, "/health", nil, "")
+	var health types.HealthResponse
+	decodeResponse(t, resp, &health)
+	if resp.StatusCode != http.StatusOK || health.Status != "ok" || health.Service != "api-gateway" {
+		t.Errorf("unexpected health: %d %+v", resp.StatusCode, health)
+	}
+	if len(health.Services) != 2 || health.Services[0].Status != "ok" || health.Services[1].Status != "ok" {
+		t.Errorf("expected both backends to be ok, got %+v", health.Services)
+	}
+}
+
+func TestHealth_DegradedWhenBackendDown(t *testing.T) {
+	up := staticBackend(http.StatusOK, `{"status":"ok"}`)
+	defer up.Close()
+	failing := staticBackend(http.StatusServiceUnavailable, `{"status":"starting"}`)
+	defer failing.Close()
+	slow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	}))
+	defer slow.Close()
+
+	srv := healthServer([]handler.Backend{
+		{Name: "job-aggregator", URL: up.URL, HealthPath: "/admin/health"},
+		{Name: "learning-resources", URL: failing.URL, HealthPath: "/health"},
+		{Name: "resume-parser", URL: slow.URL, HealthPath: "/api/v1/health"},
+	}, 50*time.Millisecond)
+	defer srv.Close()
+
+	resp := doRequest(t, srv, http.MethodGet, "/health", nil, "")
+	var health types.HealthResponse
+	decodeResponse(t, resp, &health)
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("expected 200 while degraded, got %d", resp.StatusCode)
+	}
+	if health.Status != "degraded" {
+		t.Errorf("expected status degraded, got %q", health.Status)
+	}
+
+	want := map[string]string{"job-aggregator": "ok", "learning-resources": "down", "resume-parser": "down"}
+	for _, s := range health.Services {
+		if s.Status != want[s.Name] {
+			t.Errorf("%s: expected %s, got %s", s.Name, want[s.Name], s.Status)
+		}
+		if s.Status == "down" && s.Error == "" {
+			t.Errorf("%s: expected an error detail", s.Name)
+		}
+	}
+}
@@ -36,7 +36,7 @@ func testServer(t *testing.T) *httptest.Server {
 	profileH.RegisterRoutes(mux, authMiddleware)
 	jobsH.RegisterRoutes(mux, authMiddleware)
 	analysisH.RegisterRoutes(mux, authMiddleware)
-	resourcesH.RegisterRoutes(mux)
+	resourcesH.RegisterRoutes(mux, passThrough)
 
 	return httptest.NewServer(mux)
 }
@@ -148,8 +148,8 @@ func TestRegister_InvalidEmail(t *testing.T) {
 		FullName: "Test User",
 	}, "")
 
-	if resp.StatusCode != http.StatusBadRequest {
-		t.Errorf("expected 400 for invalid email, got %d", resp.StatusCode)
+	if resp.StatusCode != http.StatusUnprocessableEntity {
+		t.Errorf("expected 422 for invalid email, got %d", resp.StatusCode)
 	}
 }
 
@@ -163,8 +163,8 @@ func TestRegister_ShortPassword(t *testing.T) {
 		FullName: "Test User",
 	}, "")
 
-	if resp.StatusCode != http.StatusBadRequest {
-		t.Errorf("expected 400 for short password, got %d", resp.StatusCode)
+	if resp.StatusCode != http.StatusUnprocessableEntity {
+		t.Errorf("expected 422 for short password, got %d", resp.StatusCode)
 	}
 }
 
@@ -353,8 +353,8 @@ func TestUpdateSkills_InvalidProficiency(t *testing.T) {
 		},
 	}, token)
 
-	if resp.StatusCode != http.StatusBadRequest {
-		t.Errorf("expected 400 for invalid proficiency, got %d", resp.StatusCode)
+	if resp.StatusCode != http.StatusUnprocessableEntity {
+		t.Errorf("expected 422 for invalid proficiency, got %d", resp.StatusCode)
 	}
 }
 
@@ -411,6 +411,38 @@ func TestJobSearch_WithLocationFilter(t *testing.T) {
 	}
 }
 
+func TestJobSearch_ExpiredJobs(t *testing.T) {
+	srv := testServer(t)
+	defer srv.Close()
+
+	token := registerAndLogin(t, srv, "jobsearch3@example.com", "password123", "Job Search User 3")
+
+	search := func(req types.JobSearchRequest) []interface{} {
+		resp := doRequest(t, srv, http.MethodPost, "/api/jobs/search", req, token)
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("expected 200, got %d", resp.StatusCode)
+		}
+		var result map[string]interface{}
+		decodeResponse(t, resp, &result)
+		data, _ := result["data"].([]interface{}) // null when nothing matches
+		return data
+	}
+
+	for _, item := range search(types.JobSearchRequest{Query: "reliability"}) {
+		job := item.(map[string]interface{})
+		t.Errorf("expired job %v returned without include_expired", job["id"])
+	}
+
+	data := search(types.JobSearchRequest{Query: "reliability", IncludeExpired: true})
+	if len(data) != 1 {
+		t.Fatalf("expected 1 job with include_expired, got %d", len(data))
+	}
+	job := data[0].(map[string]interface{})
+	if job["status"] != "expired" || job["expiry_reason"] != "closed_notice" {
+		t.Errorf("expected expired/closed_notice, got %v/%v", job["status"], job["expiry_reason"])
+	}
+}
+
 func TestJobDetail_ValidID(t *testing.T) {
 	srv := testServer(t)
 	defer srv.Close()
@@ -605,6 +637,65 @@ func TestTrainingRecommendations_POST(t *testing.T) {
 	}
 }
 
+func TestTrainingRecommendations_LegacyBudgetUSD(t *testing.T) {
+	srv := testServer(t)
+	defer srv.Close()
+
+	token := registerAndLogin(t, srv, "training3@example.com", "password123", "Training User 3")
+
+	// Clients of the deprecated max_budget_usd field still get a plan within
+	// their budget, with the USD costs next to the localized ones.
+	resp := doRequest(t, srv, http.MethodPost, "/api/training/recommendations",
+		json.RawMessage(`{"job_id":"job-001","preferences":{"max_budget_usd":20,"currency":"EUR"}}`), token)
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected 200, got %d", resp.StatusCode)
+	}
+
+	var result struct {
+		Data struct {
+			Phases []struct {
+				Skills []struct {
+					PrimaryResource *struct {
+						Resource struct {
+							CostType    string   `json:"cost_type"`
+							CostUSD     *float64 `json:"cost_usd"`
+							DisplayCost *struct {
+								Currency string `json:"currency"`
+							} `json:"display_cost"`
+						} `json:"resource"`
+					} `json:"primary_resource"`
+				} `json:"skills"`
+			} `json:"phases"`
+			Summary struct {
+				EstimatedTotalCostUSD *float64 `json:"estimated_total_cost_usd"`
+			} `json:"summary"`
+		} `json:"data"`
+	}
+	decodeResponse(t, resp, &result)
+	if result.Data.Summary.EstimatedTotalCostUSD == nil {
+		t.Error("expected summary.estimated_total_cost_usd in response")
+	}
+	paid := 0
+	for _, phase := range result.Data.Phases {
+		for _, skill := range phase.Skills {
+			if skill.PrimaryResource == nil || skill.PrimaryResource.Resource.CostType == "free" {
+				continue
+			}
+			res := skill.PrimaryResource.Resource
+			paid++
+			if res.CostUSD == nil || *res.CostUSD > 20 {
+				t.Errorf("expected a cost_usd within the $20 budget, got %v", res.CostUSD)
+			}
+			if res.DisplayCost == nil || res.DisplayCost.Currency != "EUR" {
+				t.Errorf("expected a display_cost in EUR, got %+v", res.DisplayCost)
+			}
+		}
+	}
+	if paid == 0 {
+		t.Error("expected a paid resource within the budget")
+	}
+}
+
 // ─────────────────────────────────────────────────────────────────────────────
 // Resource search integration tests
 // ─────────────────────────────────────────────────────────────────────────────
@@ -9,6 +9,7 @@ import (
 	"github.com/learnbot/api-gateway/internal/middleware"
 	"github.com/learnbot/api-gateway/internal/types"
 	"github.com/learnbot/resume-parser/pkg/scoring"
+	"github.com/learnbot/shared/openapi"
 )
 
 // ─────────────────────────────────────────────────────────────────────────────
@@ -112,6 +113,27 @@ var sampleJobs = []types.JobDetail{
 		SalaryCurrency:  "USD",
 		ApplyURL:        "https://dataco.example.com/jobs/005",
 	},
+	{
+		JobSummary: types.JobSummary{
+			ID:              "job-006",
+			Title:           "Site Reliability Engineer",
+			Company:         "CloudScale",
+			LocationType:    "remote",
+			ExperienceLevel: "senior",
+			RequiredSkills:  []string{"Go", "Kubernetes", "Terraform", "Prometheus"},
+			PostedAt:        "2024-11-04",
+			Status:          "expired",
+			ExpiryReason:    "closed_notice",
+		},
+		Description:     "Own reliability of a multi-region Kubernetes platform. This posting has closed and is kept for reference.",
+		PreferredSkills: []string{"AWS", "Grafana"},
+		MinExperience:   5,
+		Industry:        "cloud",
+		SalaryMin:       intPtr(140000),
+		SalaryMax:       intPtr(185000),
+		SalaryCurrency:  "USD",
+		ApplyURL:        "https://cloudscale.example.com/jobs/006",
+	},
 }
 
 func intPtr(n int) *int { return &n }
@@ -134,7 +156,7 @@ func NewJobsHandler() *JobsHandler {
 //	GET  /api/jobs/recommendations – get recommended jobs for current user
 //	GET  /api/jobs/{id}            – get job details
 //	GET  /api/jobs/{id}/match      – get acceptance likelihood for a job
-func (h *JobsHandler) RegisterRoutes(mux *http.ServeMux, authMiddleware func(http.Handler) http.Handler) {
+func (h *JobsHandler) RegisterRoutes(mux openapi.Router, authMiddleware func(http.Handler) http.Handler) {
 	mux.Handle("/api/jobs/search",
 		authMiddleware(http.HandlerFunc(h.Search)))
 	mux.Handle("/api/jobs/recommendations",
@@ -142,6 +164,45 @@ func (h *JobsHandler) RegisterRoutes(mux *http.ServeMux, authMiddleware func(htt
 	mux.HandleFunc("/api/jobs/", h.handleJobByID)
 }
 
+// DescribeRoutes documents the routes registered by RegisterRoutes.
+func (h *JobsHandler) DescribeRoutes(spec *openapi.Spec) {
+	keyAuth := []string{openapi.BearerAuth, openapi.ClientAPIKey}
+	spec.Route("/api/jobs/search", openapi.Operation{
+		Method:      http.MethodPost,
+		Summary:     "Search jobs with filters",
+		Description: "API keys need the read:jobs scope.",
+		Security:    keyAuth,
+		Request:     types.JobSearchRequest{},
+		Response:    successWithMeta([]types.JobSummary{}),
+		Errors:      []int{http.StatusBadRequest, http.StatusUnauthorized, http.StatusForbidden},
+	})
+	spec.Route("/api/jobs/recommendations", openapi.Operation{
+		Method:      http.MethodGet,
+		Summary:     "Jobs ranked by acceptance likelihood for the current user",
+		Description: "API keys need the read:jobs scope and get a ranking without a profile.",
+		Security:    keyAuth,
+		Response:    success([]types.JobSummary{}),
+		Errors:      []int{http.StatusUnauthorized, http.StatusForbidden},
+	})
+	spec.Route("/api/jobs/",
+		openapi.Operation{
+			Method:   http.MethodGet,
+			Path:     "/api/jobs/{id}",
+			Summary:  "Get job details",
+			Response: success(types.JobDetail{}),
+			Errors:   []int{http.StatusNotFound},
+		},
+		openapi.Operation{
+			Method:   http.MethodGet,
+			Path:     "/api/jobs/{id}/match",
+			Summary:  "Acceptance likelihood of a user for a job",
+			Params:   []openapi.Param{openapi.Query("user_id", "User to score (default: the signed-in user)")},
+			Response: success(types.JobMatchResponse{}),
+			Errors:   []int{http.StatusNotFound},
+		},
+	)
+}
+
 // Search handles POST /api/jobs/search.
 //
 // Request body:
@@ -159,6 +220,9 @@ func (h *JobsHandler) Search(w http.ResponseWriter, r *http.Request) {
 		WriteMethodNotAllowed(w)
 		return
 	}
+	if !requireScope(w, r, middleware.ScopeReadJobs) {
+		return
+	}
 
 	var req types.JobSearchRequest
 	if !DecodeJSON(w, r, &req) {
@@ -208,6 +272,9 @@ func (h *JobsHandler) Recommendations(w http.ResponseWriter, r *http.Request) {
 		WriteMethodNotAllowed(w)
 		return
 	}
+	if !requireScope(w, r, middleware.ScopeReadJobs) {
+		return
+	}
 
 	userID := middleware.GetUserID(r)
 	profile := buildCandidateProfile(userID)
@@ -216,6 +283,9 @@ func (h *JobsHandler) Recommendations(w http.ResponseWriter, r *http.Request) {
 	var scored []scoredJob
 
 	for _, job := range sampleJobs {
+		if !isJobOpen(job.JobSummary) {
+			continue
+		}
 		jobReqs := jobToRequirements(job)
 		breakdown := scoring.Calculate(profile, jobReqs)
 		summary := job.JobSummary
@@ -354,6 +424,11 @@ func jobToRequirements(job types.JobDetail) scoring.JobRequirements {
 
 // matchesJobFilter returns true if a job matches the search filter.
 func matchesJobFilter(job types.JobDetail, filter types.JobSearchRequest) bool {
+	// Closed postings are hidden unless explicitly requested.
+	if !filter.IncludeExpired && !isJobOpen(job.JobSummary) {
+		return false
+	}
+
 	// Location type filter.
 	if filter.LocationType != "" && !strings.EqualFold(job.LocationType, filter.LocationType) {
 		return false
@@ -400,6 +475,11 @@ func matchesJobFilter(job types.JobDetail, filter types.JobSearchRequest) bool {
 	return true
 }
 
+// isJobOpen reports whether a job is still accepting applications.
+func isJobOpen(job types.JobSummary) bool {
+	return job.Status == "" || job.Status == "active"
+}
+
 // scoredJob holds a job with its match score.
 type scoredJob struct {
 	job   types.JobSummary
@@ -0,0 +1,95 @@
+package handler_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/learnbot/api-gateway/internal/handler"
+	"github.com/learnbot/api-gateway/internal/middleware"
+	"github.com/learnbot/api-gateway/internal/tasks"
+	"github.com/learnbot/shared/openapi"
+)
+
+// describedHandler is implemented by every gateway handler.
+type describedHandler interface {
+	DescribeRoutes(*openapi.Spec)
+}
+
+// passThrough stands in for the auth middleware when only routes matter.
+func passThrough(next http.Handler) http.Handler { return next }
+
+func TestDescribeRoutes(t *testing.T) {
+	jwtCfg := middleware.DefaultJWTConfig("test-secret")
+	backends := testBackends("http://jobs.test", "http://learning.test", "http://parser.test")
+	proxy, err := handler.NewProxyHandler(backends, handler.DefaultProxyRoutes(), nil)
+	if err != nil {
+		t.Fatalf("NewProxyHandler: %v", err)
+	}
+	auth := handler.NewAuthHandler(jwtCfg)
+	profile := handler.NewProfileHandler(jwtCfg)
+	digest := handler.NewDigestHandler(jwtCfg)
+	resume := handler.NewResumeHandler()
+	jobs := handler.NewJobsHandler()
+	analysis := handler.NewAnalysisHandler()
+	resources := handler.NewResourcesHandler()
+	dataQuality := handler.NewDataQualityHandler(nil, "", time.Second)
+	cache := handler.NewCacheHandler(middleware.NewResponseCache(middleware.DefaultResponseCacheConfig()), nil)
+	health := handler.NewHealthHandler(backends, time.Second)
+	rescore := handler.NewRescoreHandler(nil, tasks.NewQueue(tasks.DefaultConfig()), nil)
+	export := handler.NewExportHandler([]handler.ExportSource{handler.ResumeExport()}, nil)
+	deletion := handler.NewAccountDeletionHandler(handler.NewAccountDeleter(handler.NewMemoryAccountDeletionStore(), nil, nil), auth, nil)
+
+	register := func(mux openapi.Router) {
+		auth.RegisterRoutes(mux)
+		profile.RegisterRoutes(mux, passThrough)
+		digest.RegisterRoutes(mux)
+		resume.RegisterRoutes(mux, passThrough)
+		jobs.RegisterRoutes(mux, passThrough)
+		analysis.RegisterRoutes(mux, passThrough)
+		resources.RegisterRoutes(mux, passThrough)
+		cache.RegisterRoutes(mux, passThrough)
+		dataQuality.RegisterRoutes(mux, passThrough)
+		rescore.RegisterRoutes(mux, passThrough)
+		export.RegisterRoutes(mux, passThrough)
+		deletion.RegisterRoutes(mux, passThrough)
+		proxy.RegisterRoutes(mux, passThrough)
+		health.RegisterRoutes(mux)
+	}
+	var spec *openapi.Spec
+	describe := func(s *openapi.Spec) {
+		spec = s
+		s.SetErrorBody(handler.ErrorBody)
+		for _, h := range []describedHandler{auth, profile, digest, resume, jobs, analysis, resources, cache, dataQuality, rescore, export, deletion, proxy, health} {
+			h.DescribeRoutes(s)
+		}
+	}
+	if err := openapi.CheckRoutes(register, describe); err != nil {
+		t.Fatal(err)
+	}
+
+	raw, err := spec.JSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var doc struct {
+		Paths      map[string]map[string]json.RawMessage `json:"paths"`
+		Components struct {
+			Schemas map[string]struct {
+				Properties map[string]json.RawMessage `json:"properties"`
+			} `json:"schemas"`
+		} `json:"components"`
+	}
+	if err := json.Unmarshal(raw, &doc); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"email", "password", "full_name"} {
+		if _, ok := doc.Components.Schemas["RegisterRequest"].Properties[name]; !ok {
+			t.Errorf("RegisterRequest schema missing %q", name)
+		}
+	}
+	if _, ok := doc.Paths["/api/v1/jobs/{path}"]["post"]; !ok {
+		t.Error("expected proxied route groups to be documented")
+	}
+}
@@ -33,7 +33,7 @@ func newTestServer() *httptest.Server {
 	profileH.RegisterRoutes(mux, authMiddleware)
 	jobsH.RegisterRoutes(mux, authMiddleware)
 	analysisH.RegisterRoutes(mux, authMiddleware)
-	resourcesH.RegisterRoutes(mux)
+	resourcesH.RegisterRoutes(mux, passThrough)
 
 	return httptest.NewServer(mux)
 }
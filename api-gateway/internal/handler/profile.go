@@ -2,12 +2,16 @@
 package handler
 
 import (
+	"errors"
 	"net/http"
+	"strconv"
 	"sync"
 	"time"
 
 	"github.com/learnbot/api-gateway/internal/middleware"
 	"github.com/learnbot/api-gateway/internal/types"
+	"github.com/learnbot/shared/openapi"
+	"github.com/learnbot/shared/validation"
 )
 
 // ─────────────────────────────────────────────────────────────────────────────
@@ -26,6 +30,7 @@ type profileRecord struct {
 	WebsiteURL        string
 	YearsOfExperience float64
 	IsOpenToWork      bool
+	WeeklyDigest      bool // opted in to the weekly digest email
 	Skills            []skillRecord
 	UpdatedAt         time.Time
 }
@@ -36,6 +41,10 @@ type skillRecord struct {
 	Proficiency       string
 	YearsOfExperience float64
 	IsPrimary         bool
+
+	// ProficiencyInferred is set when the proficiency was inferred from an
+	// uploaded resume's work history rather than entered by the user.
+	ProficiencyInferred bool
 }
 
 // profileStore is a thread-safe in-memory profile store.
@@ -71,18 +80,43 @@ func (s *profileStore) update(userID string, fn func(*profileRecord)) *profileRe
 	return p
 }
 
+func (s *profileStore) delete(userID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.profiles, userID)
+}
+
 // ─────────────────────────────────────────────────────────────────────────────
 // ProfileHandler
 // ─────────────────────────────────────────────────────────────────────────────
 
 // ProfileHandler handles user profile endpoints.
 type ProfileHandler struct {
-	jwtCfg middleware.JWTConfig
+	jwtCfg   middleware.JWTConfig
+	users    UserStore
+	verifier *EmailVerifier
 }
 
-// NewProfileHandler creates a new ProfileHandler.
+// NewProfileHandler creates a new ProfileHandler over the in-memory
+// accounts, until SetUserStore replaces the store.
 func NewProfileHandler(jwtCfg middleware.JWTConfig) *ProfileHandler {
-	return &ProfileHandler{jwtCfg: jwtCfg}
+	return &ProfileHandler{
+		jwtCfg:   jwtCfg,
+		users:    defaultUserStore,
+		verifier: NewEmailVerifier(jwtCfg, EmailVerificationConfig{}),
+	}
+}
+
+// SetUserStore replaces the store of user accounts; see
+// AuthHandler.SetUserStore.
+func (h *ProfileHandler) SetUserStore(store UserStore) {
+	h.users = store
+}
+
+// SetEmailVerifier replaces the verifier that resends verification links;
+// see AuthHandler.SetEmailVerifier.
+func (h *ProfileHandler) SetEmailVerifier(v *EmailVerifier) {
+	h.verifier = v
 }
 
 // RegisterRoutes registers profile routes on the mux.
@@ -91,11 +125,73 @@ func NewProfileHandler(jwtCfg middleware.JWTConfig) *ProfileHandler {
 //	PUT  /api/users/profile    – update current user's profile
 //	GET  /api/profile/skills   – get current user's skills
 //	PUT  /api/profile/skills   – update current user's skills
-func (h *ProfileHandler) RegisterRoutes(mux *http.ServeMux, authMiddleware func(http.Handler) http.Handler) {
+//	POST /api/users/verification/resend – resend the email verification link
+func (h *ProfileHandler) RegisterRoutes(mux openapi.Router, authMiddleware func(http.Handler) http.Handler) {
 	mux.Handle("/api/users/profile",
 		authMiddleware(http.HandlerFunc(h.handleProfile)))
 	mux.Handle("/api/profile/skills",
 		authMiddleware(http.HandlerFunc(h.handleSkills)))
+	mux.Handle("/api/users/verification/resend",
+		authMiddleware(http.HandlerFunc(h.resendVerification)))
+}
+
+// DescribeRoutes documents the routes registered by RegisterRoutes.
+func (h *ProfileHandler) DescribeRoutes(spec *openapi.Spec) {
+	auth := []string{openapi.BearerAuth}
+	profile := openapi.Fields{
+		"user_id": "", "headline": "", "summary": "", "location_city": "", "location_country": "",
+		"linkedin_url": "", "github_url": "", "website_url": "", "years_of_experience": 0.0,
+		"is_open_to_work": false, "weekly_digest": false, "updated_at": time.Time{},
+	}
+	withUser := openapi.Fields{"email": "", "full_name": "", "email_verified": false, "skills": []skillRecord{}}
+	for k, v := range profile {
+		withUser[k] = v
+	}
+	skills := success(openapi.Fields{"user_id": "", "skills": []skillRecord{}, "count": 0})
+
+	spec.Route("/api/users/profile",
+		openapi.Operation{
+			Method:   http.MethodGet,
+			Summary:  "Get the current user's profile",
+			Security: auth,
+			Response: success(withUser),
+			Errors:   []int{http.StatusUnauthorized, http.StatusNotFound},
+		},
+		openapi.Operation{
+			Method:   http.MethodPut,
+			Summary:  "Update the current user's profile",
+			Security: auth,
+			Request:  types.ProfileUpdateRequest{},
+			Response: success(profile),
+			Errors:   []int{http.StatusBadRequest, http.StatusUnauthorized},
+		},
+	)
+	spec.Route("/api/profile/skills",
+		openapi.Operation{
+			Method:   http.MethodGet,
+			Summary:  "Get the current user's skills",
+			Security: auth,
+			Response: skills,
+			Errors:   []int{http.StatusUnauthorized},
+		},
+		openapi.Operation{
+			Method:   http.MethodPut,
+			Summary:  "Replace the current user's skills",
+			Security: auth,
+			Request:  types.SkillUpdateRequest{},
+			Response: skills,
+			Errors:   []int{http.StatusBadRequest, http.StatusUnauthorized},
+		},
+	)
+	spec.Route("/api/users/verification/resend", openapi.Operation{
+		Method:      http.MethodPost,
+		Summary:     "Resend the email verification link",
+		Description: "At most one link is sent per minute; sooner requests answer 429 with Retry-After.",
+		Security:    auth,
+		Response:    success(openapi.Fields{"message": ""}),
+		Status:      http.StatusAccepted,
+		Errors:      []int{http.StatusUnauthorized, http.StatusNotFound, http.StatusConflict, http.StatusTooManyRequests},
+	})
 }
 
 // handleProfile handles GET/PUT /api/users/profile.
@@ -118,11 +214,15 @@ func (h *ProfileHandler) handleProfile(w http.ResponseWriter, r *http.Request) {
 
 // getProfile handles GET /api/users/profile.
 func (h *ProfileHandler) getProfile(w http.ResponseWriter, r *http.Request, userID string) {
-	user, exists := globalUserStore.findByID(userID)
-	if !exists {
+	user, err := h.users.GetByID(r.Context(), userID)
+	if errors.Is(err, ErrUserNotFound) {
 		WriteNotFound(w, "user")
 		return
 	}
+	if err != nil {
+		WriteInternalError(w)
+		return
+	}
 
 	profile := globalProfileStore.get(userID)
 
@@ -130,6 +230,7 @@ func (h *ProfileHandler) getProfile(w http.ResponseWriter, r *http.Request, user
 		"user_id":             userID,
 		"email":               user.Email,
 		"full_name":           user.FullName,
+		"email_verified":      user.Verified,
 		"headline":            profile.Headline,
 		"summary":             profile.Summary,
 		"location_city":       profile.LocationCity,
@@ -139,6 +240,7 @@ func (h *ProfileHandler) getProfile(w http.ResponseWriter, r *http.Request, user
 		"website_url":         profile.WebsiteURL,
 		"years_of_experience": profile.YearsOfExperience,
 		"is_open_to_work":     profile.IsOpenToWork,
+		"weekly_digest":       profile.WeeklyDigest,
 		"skills":              profile.Skills,
 		"updated_at":          profile.UpdatedAt,
 	})
@@ -179,6 +281,9 @@ func (h *ProfileHandler) updateProfile(w http.ResponseWriter, r *http.Request, u
 		if req.IsOpenToWork != nil {
 			p.IsOpenToWork = *req.IsOpenToWork
 		}
+		if req.WeeklyDigest != nil {
+			p.WeeklyDigest = *req.WeeklyDigest
+		}
 	})
 
 	WriteSuccess(w, http.StatusOK, map[string]interface{}{
@@ -192,6 +297,7 @@ func (h *ProfileHandler) updateProfile(w http.ResponseWriter, r *http.Request, u
 		"website_url":         profile.WebsiteURL,
 		"years_of_experience": profile.YearsOfExperience,
 		"is_open_to_work":     profile.IsOpenToWork,
+		"weekly_digest":       profile.WeeklyDigest,
 		"updated_at":          profile.UpdatedAt,
 	})
 }
@@ -235,19 +341,14 @@ func (h *ProfileHandler) updateSkills(w http.ResponseWriter, r *http.Request, us
 	var v Validator
 	for i, skill := range req.Skills {
 		if trimSpaceStr(skill.Name) == "" {
-			v.errors = append(v.errors, types.FieldError{
-				Field:   "skills[" + itoa(i) + "].name",
-				Message: "skill name is required",
-			})
+			v.add("skills["+itoa(i)+"].name", validation.CodeRequired, "skill name is required")
 		}
 		validProficiencies := map[string]bool{
 			"beginner": true, "intermediate": true, "advanced": true, "expert": true,
 		}
 		if skill.Proficiency != "" && !validProficiencies[skill.Proficiency] {
-			v.errors = append(v.errors, types.FieldError{
-				Field:   "skills[" + itoa(i) + "].proficiency",
-				Message: "must be one of: beginner, intermediate, advanced, expert",
-			})
+			v.add("skills["+itoa(i)+"].proficiency", validation.CodeOneOf,
+				"must be one of: beginner, intermediate, advanced, expert")
 		}
 	}
 	if v.WriteIfInvalid(w) {
@@ -289,3 +390,32 @@ func itoa(n int) string {
 	}
 	return result
 }
+
+// resendVerification handles POST /api/users/verification/resend.
+func (h *ProfileHandler) resendVerification(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		WriteMethodNotAllowed(w)
+		return
+	}
+	user, err := h.users.GetByID(r.Context(), middleware.GetUserID(r))
+	if errors.Is(err, ErrUserNotFound) {
+		WriteNotFound(w, "user")
+		return
+	}
+	if err != nil {
+		WriteInternalError(w)
+		return
+	}
+	if user.Verified {
+		WriteError(w, http.StatusConflict, "EMAIL_ALREADY_VERIFIED", "email is already verified")
+		return
+	}
+	if wait := h.verifier.send(r.Context(), user); wait > 0 {
+		w.Header().Set("Retry-After", strconv.Itoa(max(1, int((wait+time.Second-1)/time.Second))))
+		WriteError(w, http.StatusTooManyRequests, "TOO_MANY_VERIFICATION_EMAILS",
+			"a verification email was sent recently, please try again later")
+		return
+	}
+
+	WriteSuccess(w, http.StatusAccepted, map[string]string{"message": "verification email sent"})
+}
@@ -0,0 +1,344 @@
+// Package handler – proxy.go forwards the versioned /api/v1 route groups to
+// the backend services.
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/learnbot/api-gateway/internal/middleware"
+	"github.com/learnbot/shared/logging"
+	"github.com/learnbot/shared/metrics"
+	"github.com/learnbot/shared/openapi"
+)
+
+const (
+	// UserIDHeader carries the authenticated user's ID to backends. Any value
+	// sent by the client is discarded before the request is forwarded.
+	UserIDHeader = "X-User-ID"
+
+	// DefaultBackendTimeout bounds a proxied request when a Backend does not
+	// set its own Timeout.
+	DefaultBackendTimeout = 10 * time.Second
+)
+
+// backendErrors counts proxied requests that failed to reach a backend.
+var backendErrors = metrics.NewCounter("gateway_backend_errors_total",
+	"Proxied requests that failed to reach a backend, by backend and reason.",
+	"backend", "reason")
+
+// Backend is a service the gateway forwards requests to.
+type Backend struct {
+	// Name identifies the backend in routes, errors and /health.
+	Name string
+
+	// URL is the backend's base URL, e.g. "http://job-aggregator:8081".
+	URL string
+
+	// Timeout bounds each proxied request, including streaming the response
+	// body. Zero uses DefaultBackendTimeout.
+	Timeout time.Duration
+
+	// HealthPath is the path polled by /health, e.g. "/health".
+	HealthPath string
+
+	// Breaker configures the backend's circuit breaker. Zero fields use
+	// middleware.DefaultCircuitBreakerConfig.
+	Breaker middleware.CircuitBreakerConfig
+}
+
+// ProxyRoute maps a gateway path prefix onto a backend.
+type ProxyRoute struct {
+	// Prefix is the gateway path prefix, ending in a slash.
+	Prefix string
+
+	// Backend is the name of the Backend serving the route.
+	Backend string
+
+	// TargetPrefix replaces Prefix in the forwarded path.
+	TargetPrefix string
+
+	// Exclude lists sub-prefixes of Prefix that are answered with 404
+	// instead of being forwarded.
+	Exclude []string
+
+	// Roles, if set, restricts the route to users holding one of them.
+	Roles []middleware.Role
+
+	// ReadScope and WriteScope are the scopes API keys need for GET and
+	// HEAD requests and for other methods. API keys are rejected where the
+	// scope is empty.
+	ReadScope  middleware.Scope
+	WriteScope middleware.Scope
+
+	// UserScoped routes start with a user ID after Prefix, which must be
+	// the caller's own unless the caller is an admin. The backends take
+	// the user from the path, so API keys, which act for no user, are
+	// rejected.
+	UserScoped bool
+}
+
+// DefaultProxyRoutes returns the route groups served by the backends.
+//
+//	/api/v1/jobs/…           → job-aggregator     /api/v1/jobs/…
+//	/api/v1/jobs/admin/…     → job-aggregator     /admin/… (admin)
+//	/api/v1/saved-jobs/…     → job-aggregator     /api/v1/saved-jobs/…
+//	/api/v1/me/…             → job-aggregator     /api/v1/me/… (saved jobs)
+//	/api/v1/analytics/…      → job-aggregator     /api/v1/analytics/…
+//	/api/v1/analysis/…       → resume-parser      /api/v1/…
+//	/api/v1/learning/…       → learning-resources /api/v1/…
+//	/api/v1/learning/users/… → learning-resources /api/v1/users/… (own progress, or admin)
+//	/api/v1/learning/admin/… → learning-resources /api/v1/admin/… (curator or admin)
+//
+// The backends check the forwarded token's roles again. API keys may read
+// jobs and learning resources.
+func DefaultProxyRoutes() []ProxyRoute {
+	return []ProxyRoute{
+		{
+			Prefix:       "/api/v1/jobs/",
+			Backend:      "job-aggregator",
+			TargetPrefix: "/api/v1/jobs/",
+			ReadScope:    middleware.ScopeReadJobs,
+		},
+		{
+			Prefix:       "/api/v1/jobs/admin/",
+			Backend:      "job-aggregator",
+			TargetPrefix: "/admin/",
+			Roles:        []middleware.Role{middleware.RoleAdmin},
+		},
+		{Prefix: "/api/v1/saved-jobs/", Backend: "job-aggregator", TargetPrefix: "/api/v1/saved-jobs/"},
+		{Prefix: "/api/v1/me/", Backend: "job-aggregator", TargetPrefix: "/api/v1/me/"},
+		{Prefix: "/api/v1/analytics/", Backend: "job-aggregator", TargetPrefix: "/api/v1/analytics/"},
+		{Prefix: "/api/v1/analysis/", Backend: "resume-parser", TargetPrefix: "/api/v1/"},
+		{
+			Prefix:       "/api/v1/learning/",
+			Backend:      "learning-resources",
+			TargetPrefix: "/api/v1/",
+			ReadScope:    middleware.ScopeReadResources,
+		},
+		{
+			Prefix:       "/api/v1/learning/users/",
+			Backend:      "learning-resources",
+			TargetPrefix: "/api/v1/users/",
+			UserScoped:   true,
+		},
+		{
+			Prefix:       "/api/v1/learning/admin/",
+			Backend:      "learning-resources",
+			TargetPrefix: "/api/v1/admin/",
+			Roles:        []middleware.Role{middleware.RoleCurator, middleware.RoleAdmin},
+		},
+	}
+}
+
+// ProxyHandler forwards route groups to backends with one
+// httputil.ReverseProxy per route. Each backend has a circuit breaker shared
+// by its routes, so a failing backend is answered with 503 instead of tying
+// up gateway connections until it times out.
+//
+// Hop-by-hop headers (Connection, Keep-Alive, Upgrade, …) and any client
+// supplied Forwarded/X-Forwarded-* headers are stripped by the reverse proxy;
+// X-Forwarded-For/-Host/-Proto are then set from the inbound request.
+type ProxyHandler struct {
+	routes   []ProxyRoute
+	handlers map[string]http.Handler
+}
+
+// NewProxyHandler creates a ProxyHandler. It fails if a route refers to an
+// unknown backend or a backend URL is not an absolute http(s) URL. Circuit
+// breaker state changes are logged to logger.
+func NewProxyHandler(backends []Backend, routes []ProxyRoute, logger *slog.Logger) (*ProxyHandler, error) {
+	byName := make(map[string]Backend, len(backends))
+	breakers := make(map[string]*middleware.CircuitBreaker, len(backends))
+	for _, b := range backends {
+		byName[b.Name] = b
+		breakers[b.Name] = middleware.NewCircuitBreaker(b.Name, b.Breaker, logger)
+	}
+
+	h := &ProxyHandler{routes: routes, handlers: make(map[string]http.Handler, len(routes))}
+	for _, route := range routes {
+		b, ok := byName[route.Backend]
+		if !ok {
+			return nil, fmt.Errorf("route %s: unknown backend %q", route.Prefix, route.Backend)
+		}
+		target, err := parseBackendURL(b.URL)
+		if err != nil {
+			return nil, fmt.Errorf("backend %s: %w", b.Name, err)
+		}
+		h.handlers[route.Prefix] = newRouteProxy(route, b, target, breakers[b.Name])
+	}
+	return h, nil
+}
+
+// RegisterRoutes registers every route group on the mux behind
+// authMiddleware, followed by the API key scope check and a role check for
+// routes with Roles.
+func (h *ProxyHandler) RegisterRoutes(mux openapi.Router, authMiddleware func(http.Handler) http.Handler) {
+	for _, route := range h.routes {
+		next := h.handlers[route.Prefix]
+		if len(route.Roles) > 0 {
+			next = middleware.RequireRole(route.Roles...)(next)
+		}
+		mux.Handle(route.Prefix, authMiddleware(requireRouteScope(route, next)))
+	}
+}
+
+// requireRouteScope rejects API keys without the route's scope for the
+// request method.
+func requireRouteScope(route ProxyRoute, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		scope := routeScope(route, r.Method)
+		if scope == "" {
+			if p := middleware.GetPrincipal(r); p != nil && p.Kind == middleware.PrincipalAPIKey {
+				WriteError(w, http.StatusForbidden, "INSUFFICIENT_SCOPE", "route is not available to API keys")
+				return
+			}
+		} else if !requireScope(w, r, scope) {
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// routeScope returns the scope API keys need for method on route.
+func routeScope(route ProxyRoute, method string) middleware.Scope {
+	if method == http.MethodGet || method == http.MethodHead {
+		return route.ReadScope
+	}
+	return route.WriteScope
+}
+
+// DescribeRoutes documents every route group as pass-through operations.
+// Their bodies are those of the backend, which serves its own document at
+// /openapi.json.
+func (h *ProxyHandler) DescribeRoutes(spec *openapi.Spec) {
+	for _, route := range h.routes {
+		desc := fmt.Sprintf("Forwarded to %s at %s{path}.", route.Backend, route.TargetPrefix)
+		if len(route.Exclude) > 0 {
+			desc += " Paths under " + strings.Join(route.Exclude, ", ") + " are not forwarded."
+		}
+		errs := []int{http.StatusUnauthorized, http.StatusForbidden, http.StatusNotFound, http.StatusBadGateway,
+			http.StatusServiceUnavailable, http.StatusGatewayTimeout}
+		if len(route.Roles) > 0 {
+			desc += " Requires the " + strings.Join(roleNames(route.Roles), " or ") + " role."
+		}
+		if route.UserScoped {
+			desc += " The path starts with the caller's own user ID; other users' IDs need the admin role."
+		}
+		ops := make([]openapi.Operation, 0, len(proxyMethods))
+		for _, method := range proxyMethods {
+			op := openapi.Operation{
+				Method:      method,
+				Path:        route.Prefix + "{path}",
+				Summary:     "Proxy to " + route.Backend,
+				Description: desc,
+				Security:    []string{openapi.BearerAuth},
+				Errors:      errs,
+			}
+			if scope := routeScope(route, method); scope != "" {
+				op.Description += " API keys need the " + string(scope) + " scope."
+				op.Security = append(op.Security, openapi.ClientAPIKey)
+			}
+			ops = append(ops, op)
+		}
+		spec.Route(route.Prefix, ops...)
+	}
+}
+
+// proxyMethods are the methods documented for each proxied route group.
+var proxyMethods = []string{http.MethodGet, http.MethodPost, http.MethodPut, http.MethodDelete}
+
+// newRouteProxy builds the forwarder for a single route.
+func newRouteProxy(route ProxyRoute, b Backend, target *url.URL, breaker *middleware.CircuitBreaker) http.Handler {
+	timeout := b.Timeout
+	if timeout <= 0 {
+		timeout = DefaultBackendTimeout
+	}
+
+	rp := &httputil.ReverseProxy{
+		Rewrite: func(pr *httputil.ProxyRequest) {
+			pr.Out.URL.Path = route.TargetPrefix + strings.TrimPrefix(pr.In.URL.Path, route.Prefix)
+			pr.Out.URL.RawPath = ""
+			pr.SetURL(target)
+			pr.SetXForwarded()
+
+			pr.Out.Header.Del(UserIDHeader)
+			pr.Out.Header.Del(middleware.APIKeyHeader)
+			if userID := middleware.GetUserID(pr.In); userID != "" {
+				pr.Out.Header.Set(UserIDHeader, userID)
+			}
+		},
+		Transport: logging.Transport(&http.Transport{
+			Proxy:               http.ProxyFromEnvironment,
+			MaxIdleConnsPerHost: 16,
+			IdleConnTimeout:     90 * time.Second,
+		}),
+		ErrorHandler: func(w http.ResponseWriter, r *http.Request, err error) {
+			writeBackendError(w, b.Name, err)
+		},
+	}
+
+	forward := breaker.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx, cancel := context.WithTimeout(r.Context(), timeout)
+		defer cancel()
+		rp.ServeHTTP(w, r.WithContext(ctx))
+	}))
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		for _, excluded := range route.Exclude {
+			if strings.HasPrefix(r.URL.Path, excluded) {
+				WriteError(w, http.StatusNotFound, "NOT_FOUND", "route not found")
+				return
+			}
+		}
+		if route.UserScoped && !ownsUserPath(r, route.Prefix) {
+			WriteError(w, http.StatusForbidden, "FORBIDDEN", "you may only access your own data")
+			return
+		}
+		forward.ServeHTTP(w, r)
+	})
+}
+
+// ownsUserPath reports whether the caller may access the user whose ID
+// follows prefix in the request path: the user themself or an admin.
+func ownsUserPath(r *http.Request, prefix string) bool {
+	if middleware.HasRole(middleware.GetRoles(r), middleware.RoleAdmin) {
+		return true
+	}
+	userID := middleware.GetUserID(r)
+	pathID, _, _ := strings.Cut(strings.TrimPrefix(r.URL.Path, prefix), "/")
+	return userID != "" && strings.EqualFold(pathID, userID)
+}
+
+// writeBackendError answers a request the backend could not serve: 504 when
+// the backend timed out, 502 otherwise.
+func writeBackendError(w http.ResponseWriter, backend string, err error) {
+	if errors.Is(err, context.DeadlineExceeded) {
+		backendErrors.Inc(backend, "timeout")
+		WriteError(w, http.StatusGatewayTimeout, "BACKEND_TIMEOUT",
+			backend+" did not respond in time")
+		return
+	}
+	backendErrors.Inc(backend, "unavailable")
+	WriteError(w, http.StatusBadGateway, "BACKEND_UNAVAILABLE",
+		backend+" is unavailable")
+}
+
+// parseBackendURL validates a backend base URL.
+func parseBackendURL(raw string) (*url.URL, error) {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return nil, fmt.Errorf("parse url: %w", err)
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, fmt.Errorf("url %q must be an absolute http(s) URL", raw)
+	}
+	return u, nil
+}
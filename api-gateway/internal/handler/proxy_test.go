@@ -0,0 +1,371 @@
+package handler_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/learnbot/api-gateway/internal/handler"
+	"github.com/learnbot/api-gateway/internal/middleware"
+	"github.com/learnbot/api-gateway/internal/types"
+)
+
+// proxyServer creates a gateway exposing only the default proxy routes.
+func proxyServer(t *testing.T, backends []handler.Backend) (*httptest.Server, string) {
+	t.Helper()
+	jwtCfg := middleware.DefaultJWTConfig("test-secret")
+	proxy, err := handler.NewProxyHandler(backends, handler.DefaultProxyRoutes(), nil)
+	if err != nil {
+		t.Fatalf("NewProxyHandler: %v", err)
+	}
+	mux := http.NewServeMux()
+	proxy.RegisterRoutes(mux, middleware.RequireAuth(jwtCfg))
+	token, _, err := middleware.GenerateToken(jwtCfg, "user-42", "dev@example.com", nil, true)
+	if err != nil {
+		t.Fatalf("generate token: %v", err)
+	}
+	return httptest.NewServer(mux), token
+}
+
+// testBackends points every default route at the given URLs.
+func testBackends(jobs, learning, parser string) []handler.Backend {
+	return []handler.Backend{
+		{Name: "job-aggregator", URL: jobs},
+		{Name: "learning-resources", URL: learning},
+		{Name: "resume-parser", URL: parser},
+	}
+}
+
+// recordingBackend records the last request it received.
+type recordingBackend struct {
+	*httptest.Server
+	last  atomic.Pointer[http.Request]
+	calls atomic.Int32
+}
+
+func newRecordingBackend(t *testing.T) *recordingBackend {
+	t.Helper()
+	b := &recordingBackend{}
+	b.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b.calls.Add(1)
+		b.last.Store(r.Clone(r.Context()))
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"ok":true}`))
+	}))
+	t.Cleanup(b.Close)
+	return b
+}
+
+func TestProxy_RewritesPathsPerRouteGroup(t *testing.T) {
+	jobs := newRecordingBackend(t)
+	learning := newRecordingBackend(t)
+	parser := newRecordingBackend(t)
+	srv, token := proxyServer(t, testBackends(jobs.URL, learning.URL, parser.URL))
+	defer srv.Close()
+
+	tests := []struct {
+		path    string
+		backend *recordingBackend
+		want    string
+	}{
+		{"/api/v1/jobs/search?q=golang&remote=true", jobs, "/api/v1/jobs/search?q=golang&remote=true"},
+		{"/api/v1/analytics/skill-demand?role=data+engineer", jobs, "/api/v1/analytics/skill-demand?role=data+engineer"},
+		{"/api/v1/me/saved-jobs?status=applied", jobs, "/api/v1/me/saved-jobs?status=applied"},
+		{"/api/v1/saved-jobs/42", jobs, "/api/v1/saved-jobs/42"},
+		{"/api/v1/analysis/gap-analysis", parser, "/api/v1/gap-analysis"},
+		{"/api/v1/analysis/skills/normalize", parser, "/api/v1/skills/normalize"},
+		{"/api/v1/learning/resources/featured?limit=5", learning, "/api/v1/resources/featured?limit=5"},
+	}
+	for _, tt := range tests {
+		resp := doRequest(t, srv, http.MethodGet, tt.path, nil, token)
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			t.Errorf("%s: expected 200, got %d", tt.path, resp.StatusCode)
+			continue
+		}
+		if got := tt.backend.last.Load().URL.RequestURI(); got != tt.want {
+			t.Errorf("%s: backend received %s, want %s", tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestProxy_PropagatesTrustedHeaders(t *testing.T) {
+	jobs := newRecordingBackend(t)
+	srv, token := proxyServer(t, testBackends(jobs.URL, jobs.URL, jobs.URL))
+	defer srv.Close()
+
+	req, _ := http.NewRequest(http.MethodGet, srv.URL+"/api/v1/jobs/search", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("X-User-ID", "spoofed-admin")
+	req.Header.Set("Connection", "X-Internal-Hop")
+	req.Header.Set("X-Internal-Hop", "secret")
+	req.Header.Set("Keep-Alive", "timeout=5")
+	req.Header.Set("X-Custom", "kept")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	resp.Body.Close()
+
+	got := jobs.last.Load()
+	if id := got.Header.Get(handler.UserIDHeader); id != "user-42" {
+		t.Errorf("expected %s from the token, got %q", handler.UserIDHeader, id)
+	}
+	for _, h := range []string{"X-Internal-Hop", "Keep-Alive"} {
+		if v := got.Header.Get(h); v != "" {
+			t.Errorf("expected hop-by-hop header %s to be stripped, got %q", h, v)
+		}
+	}
+	if got.Header.Get("X-Custom") != "kept" {
+		t.Error("expected end-to-end headers to be forwarded")
+	}
+	if got.Header.Get("X-Forwarded-For") == "" {
+		t.Error("expected X-Forwarded-For to be set")
+	}
+	if got.Header.Get("Authorization") == "" {
+		t.Error("expected the Authorization header to be forwarded")
+	}
+}
+
+func TestProxy_RequiresAuth(t *testing.T) {
+	jobs := newRecordingBackend(t)
+	srv, _ := proxyServer(t, testBackends(jobs.URL, jobs.URL, jobs.URL))
+	defer srv.Close()
+
+	resp := doRequest(t, srv, http.MethodGet, "/api/v1/jobs/search", nil, "")
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusUnauthorized {
+		t.Errorf("expected 401, got %d", resp.StatusCode)
+	}
+	if jobs.calls.Load() != 0 {
+		t.Error("expected the backend not to be called")
+	}
+}
+
+func TestProxy_ExcludedPrefixIsNotForwarded(t *testing.T) {
+	learning := newRecordingBackend(t)
+	routes := []handler.ProxyRoute{{
+		Prefix:       "/api/v1/learning/",
+		Backend:      "learning-resources",
+		TargetPrefix: "/api/v1/",
+		Exclude:      []string{"/api/v1/learning/internal/"},
+	}}
+	proxy, err := handler.NewProxyHandler(testBackends(learning.URL, learning.URL, learning.URL), routes, nil)
+	if err != nil {
+		t.Fatalf("NewProxyHandler: %v", err)
+	}
+	jwtCfg := middleware.DefaultJWTConfig("test-secret")
+	mux := http.NewServeMux()
+	proxy.RegisterRoutes(mux, middleware.RequireAuth(jwtCfg))
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+	token, _, _ := middleware.GenerateToken(jwtCfg, "user-42", "dev@example.com", nil, true)
+
+	resp := doRequest(t, srv, http.MethodPost, "/api/v1/learning/internal/resources", nil, token)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusNotFound {
+		t.Errorf("expected 404, got %d", resp.StatusCode)
+	}
+	if learning.calls.Load() != 0 {
+		t.Error("expected the excluded prefix not to be forwarded")
+	}
+}
+
+func TestProxy_AdminRoutesRequireRoles(t *testing.T) {
+	jobs := newRecordingBackend(t)
+	learning := newRecordingBackend(t)
+	srv, _ := proxyServer(t, testBackends(jobs.URL, learning.URL, jobs.URL))
+	defer srv.Close()
+
+	jwtCfg := middleware.DefaultJWTConfig("test-secret")
+	tokens := map[middleware.Role]string{}
+	for _, role := range []middleware.Role{middleware.RoleUser, middleware.RoleCurator, middleware.RoleAdmin} {
+		tokens[role], _, _ = middleware.GenerateToken(jwtCfg, "user-"+string(role), string(role)+"@example.com", []middleware.Role{role}, true)
+	}
+
+	tests := []struct {
+		role    middleware.Role
+		path    string
+		backend *recordingBackend
+		target  string
+		want    int
+	}{
+		{middleware.RoleUser, "/api/v1/learning/admin/resources", learning, "/api/v1/admin/resources", http.StatusForbidden},
+		{middleware.RoleCurator, "/api/v1/learning/admin/resources", learning, "/api/v1/admin/resources", http.StatusOK},
+		{middleware.RoleAdmin, "/api/v1/learning/admin/resources", learning, "/api/v1/admin/resources", http.StatusOK},
+		{middleware.RoleUser, "/api/v1/jobs/admin/stats", jobs, "/admin/stats", http.StatusForbidden},
+		{middleware.RoleCurator, "/api/v1/jobs/admin/stats", jobs, "/admin/stats", http.StatusForbidden},
+		{middleware.RoleAdmin, "/api/v1/jobs/admin/stats", jobs, "/admin/stats", http.StatusOK},
+		// The public routes stay open to every role.
+		{middleware.RoleUser, "/api/v1/jobs/search", jobs, "/api/v1/jobs/search", http.StatusOK},
+		{middleware.RoleUser, "/api/v1/learning/resources", learning, "/api/v1/resources", http.StatusOK},
+	}
+	for _, tt := range tests {
+		before := tt.backend.calls.Load()
+		resp := doRequest(t, srv, http.MethodGet, tt.path, nil, tokens[tt.role])
+		resp.Body.Close()
+		if resp.StatusCode != tt.want {
+			t.Errorf("%s %s: expected %d, got %d", tt.role, tt.path, tt.want, resp.StatusCode)
+			continue
+		}
+		forwarded := tt.backend.calls.Load() > before
+		if forwarded != (tt.want == http.StatusOK) {
+			t.Errorf("%s %s: forwarded = %v", tt.role, tt.path, forwarded)
+			continue
+		}
+		if forwarded {
+			last := tt.backend.last.Load()
+			if last.URL.Path != tt.target || last.Header.Get("Authorization") == "" {
+				t.Errorf("%s %s: forwarded to %s (Authorization %q), want %s with the token",
+					tt.role, tt.path, last.URL.Path, last.Header.Get("Authorization"), tt.target)
+			}
+		}
+	}
+}
+
+func TestProxy_UserRoutesOnlyForwardTheCallersOwnID(t *testing.T) {
+	learning := newRecordingBackend(t)
+	srv, token := proxyServer(t, testBackends(learning.URL, learning.URL, learning.URL))
+	defer srv.Close()
+	admin, _, _ := middleware.GenerateToken(middleware.DefaultJWTConfig("test-secret"),
+		"admin-1", "admin@example.com", []middleware.Role{middleware.RoleAdmin}, true)
+
+	tests := []struct {
+		method, path, token string
+		want                int
+	}{
+		{http.MethodGet, "/api/v1/learning/users/user-42/progress", token, http.StatusOK},
+		{http.MethodPost, "/api/v1/learning/users/user-42/progress/res-1", token, http.StatusOK},
+		{http.MethodGet, "/api/v1/learning/users/user-7/progress", token, http.StatusForbidden},
+		{http.MethodPost, "/api/v1/learning/users/user-7/progress/res-1", token, http.StatusForbidden},
+		{http.MethodGet, "/api/v1/learning/users/", token, http.StatusForbidden},
+		{http.MethodGet, "/api/v1/learning/users/user-7/progress", admin, http.StatusOK},
+	}
+	for _, tt := range tests {
+		before := learning.calls.Load()
+		resp := doRequest(t, srv, tt.method, tt.path, nil, tt.token)
+		resp.Body.Close()
+		if resp.StatusCode != tt.want {
+			t.Errorf("%s %s: expected %d, got %d", tt.method, tt.path, tt.want, resp.StatusCode)
+		}
+		if forwarded := learning.calls.Load() > before; forwarded != (tt.want == http.StatusOK) {
+			t.Errorf("%s %s: forwarded = %v", tt.method, tt.path, forwarded)
+		}
+	}
+}
+
+func TestProxy_BackendDownReturns502(t *testing.T) {
+	down := httptest.NewServer(http.NotFoundHandler())
+	downURL := down.URL
+	down.Close()
+
+	srv, token := proxyServer(t, testBackends(downURL, downURL, downURL))
+	defer srv.Close()
+
+	resp := doRequest(t, srv, http.MethodGet, "/api/v1/jobs/search", nil, token)
+	if resp.StatusCode != http.StatusBadGateway {
+		t.Fatalf("expected 502, got %d", resp.StatusCode)
+	}
+	if ct := resp.Header.Get("Content-Type"); !strings.HasPrefix(ct, "application/json") {
+		t.Errorf("expected a JSON error, got Content-Type %q", ct)
+	}
+	var body types.APIResponse
+	decodeResponse(t, resp, &body)
+	if body.Success || body.Error == nil || body.Error.Code != "BACKEND_UNAVAILABLE" {
+		t.Errorf("unexpected error envelope: %+v", body)
+	}
+	if !strings.Contains(body.Error.Message, "job-aggregator") {
+		t.Errorf("expected the backend to be named, got %q", body.Error.Message)
+	}
+}
+
+func TestProxy_BackendTimeoutReturns504(t *testing.T) {
+	release := make(chan struct{})
+	slow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer slow.Close()
+	defer close(release)
+
+	backends := testBackends(slow.URL, slow.URL, slow.URL)
+	backends[0].Timeout = 50 * time.Millisecond
+	srv, token := proxyServer(t, backends)
+	defer srv.Close()
+
+	start := time.Now()
+	resp := doRequest(t, srv, http.MethodGet, "/api/v1/jobs/search", nil, token)
+	var body types.APIResponse
+	decodeResponse(t, resp, &body)
+	if resp.StatusCode != http.StatusGatewayTimeout || body.Error == nil || body.Error.Code != "BACKEND_TIMEOUT" {
+		t.Errorf("expected a 504 BACKEND_TIMEOUT, got %d %+v", resp.StatusCode, body.Error)
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("expected the per-backend timeout to apply, took %v", elapsed)
+	}
+}
+
+func TestNewProxyHandler_InvalidConfig(t *testing.T) {
+	if _, err := handler.NewProxyHandler(nil, handler.DefaultProxyRoutes(), nil); err == nil {
+		t.Error("expected an error for a route with an unknown backend")
+	}
+	if _, err := handler.NewProxyHandler(testBackends("localhost:8081", "http://x", "http://y"), handler.DefaultProxyRoutes(), nil); err == nil {
+		t.Error("expected an error for a backend URL without a scheme")
+	}
+}
+
+func TestProxy_CircuitBreakerFailsFastOnHangingBackend(t *testing.T) {
+	var calls atomic.Int32
+	release := make(chan struct{})
+	hanging := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer hanging.Close()
+	defer close(release)
+
+	backends := testBackends(hanging.URL, hanging.URL, hanging.URL)
+	backends[0].Timeout = 50 * time.Millisecond
+	backends[2].Timeout = 50 * time.Millisecond
+	backends[2].Breaker = middleware.CircuitBreakerConfig{MinRequests: 3, FailureRate: 1, Cooldown: time.Minute}
+	srv, token := proxyServer(t, backends)
+	defer srv.Close()
+
+	// Three timeouts trip the resume-parser breaker.
+	for i := 0; i < 3; i++ {
+		resp := doRequest(t, srv, http.MethodPost, "/api/v1/analysis/score", nil, token)
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusGatewayTimeout {
+			t.Fatalf("request %d: expected 504, got %d", i+1, resp.StatusCode)
+		}
+	}
+
+	// Further requests fail fast without reaching the backend.
+	resp := doRequest(t, srv, http.MethodPost, "/api/v1/analysis/score", nil, token)
+	var body types.APIResponse
+	decodeResponse(t, resp, &body)
+	if resp.StatusCode != http.StatusServiceUnavailable || body.Error == nil || body.Error.Code != "BACKEND_CIRCUIT_OPEN" {
+		t.Fatalf("expected a 503 BACKEND_CIRCUIT_OPEN, got %d %+v", resp.StatusCode, body.Error)
+	}
+	if resp.Header.Get("Retry-After") == "" {
+		t.Error("expected a Retry-After header")
+	}
+	if calls.Load() != 3 {
+		t.Errorf("expected 3 backend calls, got %d", calls.Load())
+	}
+
+	// Other backends have their own breakers.
+	jobsResp := doRequest(t, srv, http.MethodGet, "/api/v1/jobs/search", nil, token)
+	jobsResp.Body.Close()
+	if jobsResp.StatusCode != http.StatusGatewayTimeout {
+		t.Errorf("expected the job-aggregator request to reach its backend, got %d", jobsResp.StatusCode)
+	}
+}
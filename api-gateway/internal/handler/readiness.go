@@ -0,0 +1,282 @@
+// Package handler – readiness.go records gap analysis snapshots and serves
+// each user's readiness history per job.
+package handler
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/learnbot/api-gateway/internal/middleware"
+	"github.com/learnbot/api-gateway/internal/types"
+	"github.com/learnbot/resume-parser/pkg/analysis"
+)
+
+// DefaultReadinessHistoryLimit is the number of snapshots kept per user and
+// job.
+const DefaultReadinessHistoryLimit = 100
+
+// ReadinessStore persists gap analysis snapshots per user and job.
+type ReadinessStore interface {
+	// Append adds s to the history of userID and s.JobID, reporting whether
+	// it was added. A snapshot equal to the latest one but for its time is
+	// not. The oldest snapshots are dropped past the store's limit.
+	Append(ctx context.Context, userID string, s types.ReadinessSnapshot) (bool, error)
+
+	// History returns the snapshots of userID and jobID, oldest first.
+	History(ctx context.Context, userID, jobID string) ([]types.ReadinessSnapshot, error)
+
+	// UserHistory returns the snapshots of userID for every job, ordered
+	// by job ID and then oldest first.
+	UserHistory(ctx context.Context, userID string) ([]types.ReadinessSnapshot, error)
+
+	// DeleteUserHistory removes the snapshots of userID for every job.
+	DeleteUserHistory(ctx context.Context, userID string) error
+}
+
+// readinessKey identifies a readiness history.
+type readinessKey struct{ userID, jobID string }
+
+// MemoryReadinessStore is an in-memory ReadinessStore (MVP – replace with
+// database in production).
+type MemoryReadinessStore struct {
+	limit int
+
+	mu      sync.Mutex
+	history map[readinessKey][]types.ReadinessSnapshot
+}
+
+// NewMemoryReadinessStore creates a MemoryReadinessStore keeping up to limit
+// snapshots per user and job. A limit of zero or less uses
+// DefaultReadinessHistoryLimit.
+func NewMemoryReadinessStore(limit int) *MemoryReadinessStore {
+	if limit <= 0 {
+		limit = DefaultReadinessHistoryLimit
+	}
+	return &MemoryReadinessStore{limit: limit, history: make(map[readinessKey][]types.ReadinessSnapshot)}
+}
+
+// Append implements ReadinessStore.
+func (s *MemoryReadinessStore) Append(_ context.Context, userID string, snap types.ReadinessSnapshot) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	key := readinessKey{userID, snap.JobID}
+	h := s.history[key]
+	if n := len(h); n > 0 && sameReadiness(h[n-1], snap) {
+		return false, nil
+	}
+	snap.GapSkills = slices.Clone(snap.GapSkills)
+	h = append(h, snap)
+	if len(h) > s.limit {
+		h = slices.Clone(h[len(h)-s.limit:])
+	}
+	s.history[key] = h
+	return true, nil
+}
+
+// History implements ReadinessStore.
+func (s *MemoryReadinessStore) History(_ context.Context, userID, jobID string) ([]types.ReadinessSnapshot, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return slices.Clone(s.history[readinessKey{userID, jobID}]), nil
+}
+
+// UserHistory implements ReadinessStore.
+func (s *MemoryReadinessStore) UserHistory(_ context.Context, userID string) ([]types.ReadinessSnapshot, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var jobIDs []string
+	for key := range s.history {
+		if key.userID == userID {
+			jobIDs = append(jobIDs, key.jobID)
+		}
+	}
+	slices.Sort(jobIDs)
+	history := []types.ReadinessSnapshot{}
+	for _, jobID := range jobIDs {
+		history = append(history, s.history[readinessKey{userID, jobID}]...)
+	}
+	return history, nil
+}
+
+// DeleteUserHistory implements ReadinessStore.
+func (s *MemoryReadinessStore) DeleteUserHistory(_ context.Context, userID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for key := range s.history {
+		if key.userID == userID {
+			delete(s.history, key)
+		}
+	}
+	return nil
+}
+
+// sameReadiness reports whether two snapshots differ only in their time.
+func sameReadiness(a, b types.ReadinessSnapshot) bool {
+	return a.ReadinessScore == b.ReadinessScore &&
+		a.CriticalGaps == b.CriticalGaps &&
+		a.ImportantGaps == b.ImportantGaps &&
+		a.NiceToHaveGaps == b.NiceToHaveGaps &&
+		a.TotalLearningHours == b.TotalLearningHours &&
+		slices.Equal(a.GapSkills, b.GapSkills)
+}
+
+// readinessSnapshot summarizes a gap analysis of jobID at now.
+func readinessSnapshot(jobID string, result analysis.GapAnalysisResult, now time.Time) types.ReadinessSnapshot {
+	var skills []string
+	for _, gaps := range [][]analysis.SkillGap{result.CriticalGaps, result.ImportantGaps, result.NiceToHaveGaps} {
+		for _, g := range gaps {
+			skills = append(skills, g.SkillName)
+		}
+	}
+	return types.ReadinessSnapshot{
+		JobID:              jobID,
+		ReadinessScore:     result.ReadinessScore,
+		CriticalGaps:       result.CriticalGapCount,
+		ImportantGaps:      result.ImportantGapCount,
+		NiceToHaveGaps:     result.NiceToHaveGapCount,
+		TotalLearningHours: result.TotalEstimatedLearningHours,
+		GapSkills:          skills,
+		RecordedAt:         now,
+	}
+}
+
+// recordReadiness stores the snapshot of a gap analysis by userID. Failures
+// are logged; the analysis is answered regardless.
+func (h *AnalysisHandler) recordReadiness(ctx context.Context, userID, jobID string, result analysis.GapAnalysisResult) {
+	if userID == "" || jobID == "" {
+		return
+	}
+	if _, err := h.readiness.Append(ctx, userID, readinessSnapshot(jobID, result, time.Now())); err != nil {
+		h.logger.ErrorContext(ctx, "record readiness snapshot failed", "error", err)
+	}
+}
+
+// ReadinessHistory handles GET /api/v1/me/readiness-history?job_id=...
+//
+// Returns the current user's gap analysis snapshots against the job, oldest
+// first, each with its changes since the previous one, and the change from
+// the first to the latest.
+func (h *AnalysisHandler) ReadinessHistory(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		WriteMethodNotAllowed(w)
+		return
+	}
+	userID := middleware.GetUserID(r)
+	if userID == "" {
+		WriteError(w, http.StatusUnauthorized, "UNAUTHORIZED", "authentication required")
+		return
+	}
+	jobID := r.URL.Query().Get("job_id")
+	if jobID == "" {
+		WriteError(w, http.StatusBadRequest, "VALIDATION_ERROR", "job_id is required")
+		return
+	}
+
+	history, err := h.readiness.History(r.Context(), userID, jobID)
+	if err != nil {
+		h.logger.ErrorContext(r.Context(), "readiness history failed", "error", err)
+		WriteError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "failed to load readiness history")
+		return
+	}
+	WriteSuccess(w, http.StatusOK, readinessHistory(jobID, history))
+}
+
+// readinessHistory builds the response for the snapshots of jobID.
+func readinessHistory(jobID string, history []types.ReadinessSnapshot) types.ReadinessHistoryResponse {
+	resp := types.ReadinessHistoryResponse{JobID: jobID, Snapshots: make([]types.ReadinessPoint, len(history))}
+	for i, s := range history {
+		resp.Snapshots[i].ReadinessSnapshot = s
+		if i > 0 {
+			prev := history[i-1]
+			resp.Snapshots[i].ReadinessDelta = roundPoints(s.ReadinessScore - prev.ReadinessScore)
+			resp.Snapshots[i].ClosedGaps = missingFold(prev.GapSkills, s.GapSkills)
+			resp.Snapshots[i].NewGaps = missingFold(s.GapSkills, prev.GapSkills)
+		}
+	}
+	if len(history) < 2 {
+		return resp
+	}
+
+	first, last := history[0], history[len(history)-1]
+	c := &types.ReadinessChange{
+		Since:                   first.RecordedAt,
+		ReadinessDelta:          roundPoints(last.ReadinessScore - first.ReadinessScore),
+		CriticalGapsDelta:       last.CriticalGaps - first.CriticalGaps,
+		ImportantGapsDelta:      last.ImportantGaps - first.ImportantGaps,
+		NiceToHaveGapsDelta:     last.NiceToHaveGaps - first.NiceToHaveGaps,
+		TotalLearningHoursDelta: last.TotalLearningHours - first.TotalLearningHours,
+		ClosedGaps:              missingFold(first.GapSkills, last.GapSkills),
+		NewGaps:                 missingFold(last.GapSkills, first.GapSkills),
+	}
+	if c.ClosedGaps == nil {
+		c.ClosedGaps = []string{}
+	}
+	if c.NewGaps == nil {
+		c.NewGaps = []string{}
+	}
+	c.Summary = readinessSummary(c)
+	resp.Change = c
+	return resp
+}
+
+// readinessSummary describes c, e.g. "Readiness improved 12 points since
+// March 3; Kubernetes gap closed".
+func readinessSummary(c *types.ReadinessChange) string {
+	since := c.Since.UTC().Format("January 2")
+	points := math.Round(c.ReadinessDelta)
+	var summary string
+	switch {
+	case points > 0:
+		summary = fmt.Sprintf("Readiness improved %s since %s", pluralPoints(points), since)
+	case points < 0:
+		summary = fmt.Sprintf("Readiness dropped %s since %s", pluralPoints(-points), since)
+	default:
+		summary = "Readiness unchanged since " + since
+	}
+	if n := len(c.ClosedGaps); n > 0 {
+		noun := " gap closed"
+		if n > 1 {
+			noun = " gaps closed"
+		}
+		summary += "; " + joinAnd(c.ClosedGaps) + noun
+	}
+	return summary
+}
+
+// pluralPoints formats a whole number of points.
+func pluralPoints(p float64) string {
+	if p == 1 {
+		return "1 point"
+	}
+	return fmt.Sprintf("%.0f points", p)
+}
+
+// joinAnd joins items as "a", "a and b" or "a, b and c".
+func joinAnd(items []string) string {
+	if len(items) == 1 {
+		return items[0]
+	}
+	return strings.Join(items[:len(items)-1], ", ") + " and " + items[len(items)-1]
+}
+
+// missingFold returns the items of a that are not in b, ignoring case.
+func missingFold(a, b []string) []string {
+	var missing []string
+	for _, s := range a {
+		if !slices.ContainsFunc(b, func(t string) bool { return strings.EqualFold(s, t) }) {
+			missing = append(missing, s)
+		}
+	}
+	return missing
+}
+
+// roundPoints rounds a readiness score difference to one decimal place.
+func roundPoints(d float64) float64 {
+	return math.Round(d*10) / 10
+}
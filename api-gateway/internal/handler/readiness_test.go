@@ -0,0 +1,185 @@
+package handler_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/learnbot/api-gateway/internal/handler"
+	"github.com/learnbot/api-gateway/internal/middleware"
+	"github.com/learnbot/api-gateway/internal/types"
+)
+
+// readinessTestServer serves the profile and analysis routes, keeping
+// readiness snapshots in store.
+func readinessTestServer(t *testing.T, store handler.ReadinessStore) *httptest.Server {
+	t.Helper()
+	jwtCfg := middleware.DefaultJWTConfig("test-secret")
+	authMiddleware := middleware.RequireAuth(jwtCfg)
+	analysisH := handler.NewAnalysisHandler()
+	analysisH.SetReadinessStore(store)
+
+	mux := http.NewServeMux()
+	handler.NewAuthHandler(jwtCfg).RegisterRoutes(mux)
+	handler.NewProfileHandler(jwtCfg).RegisterRoutes(mux, authMiddleware)
+	analysisH.RegisterRoutes(mux, authMiddleware)
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+// readinessHistory fetches the readiness history of jobID.
+func readinessHistory(t *testing.T, srv *httptest.Server, token, jobID string) types.ReadinessHistoryResponse {
+	t.Helper()
+	resp := doRequest(t, srv, http.MethodGet, "/api/v1/me/readiness-history?job_id="+jobID, nil, token)
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("readiness history: expected 200, got %d", resp.StatusCode)
+	}
+	var result struct {
+		Data types.ReadinessHistoryResponse `json:"data"`
+	}
+	decodeResponse(t, resp, &result)
+	return result.Data
+}
+
+func TestReadinessHistory(t *testing.T) {
+	srv := readinessTestServer(t, handler.NewMemoryReadinessStore(0))
+	token := registerAndLogin(t, srv, "readiness@example.com", "password123", "Readiness User")
+
+	setSkills := func(names ...string) {
+		var skills []types.SkillInput
+		for _, n := range names {
+			skills = append(skills, types.SkillInput{Name: n, Proficiency: "advanced"})
+		}
+		doRequest(t, srv, http.MethodPut, "/api/profile/skills", types.SkillUpdateRequest{Skills: skills}, token).Body.Close()
+	}
+	analyze := func(req types.GapAnalysisRequest) {
+		resp := doRequest(t, srv, http.MethodPost, "/api/analysis/gaps", req, token)
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("gap analysis: expected 200, got %d", resp.StatusCode)
+		}
+	}
+
+	setSkills("Go")
+	analyze(types.GapAnalysisRequest{JobID: "job-001"})
+	if h := readinessHistory(t, srv, token, "job-001"); len(h.Snapshots) != 1 || h.Change != nil {
+		t.Fatalf("expected one snapshot and no change, got %+v", h)
+	}
+
+	// Identical back-to-back runs are recorded once; inline jobs without
+	// a job_id are not recorded.
+	setSkills("Go", "PostgreSQL", "Docker", "Kubernetes")
+	analyze(types.GapAnalysisRequest{JobID: "job-001"})
+	analyze(types.GapAnalysisRequest{JobID: "job-001"})
+	analyze(types.GapAnalysisRequest{Job: &types.JobRequirementsInput{Title: "Go Developer", RequiredSkills: []string{"Go"}}})
+
+	h := readinessHistory(t, srv, token, "job-001")
+	if len(h.Snapshots) != 2 {
+		t.Fatalf("expected 2 snapshots, got %d", len(h.Snapshots))
+	}
+	first, latest := h.Snapshots[0], h.Snapshots[1]
+	if latest.ReadinessScore <= first.ReadinessScore || latest.ReadinessDelta <= 0 {
+		t.Errorf("expected readiness to improve, got %.1f then %.1f (delta %.1f)",
+			first.ReadinessScore, latest.ReadinessScore, latest.ReadinessDelta)
+	}
+	c := h.Change
+	if c == nil || !c.Since.Equal(first.RecordedAt) || c.CriticalGapsDelta != -3 {
+		t.Fatalf("unexpected change %+v", c)
+	}
+	if strings.Join(c.ClosedGaps, ",") != strings.Join(latest.ClosedGaps, ",") || len(c.ClosedGaps) != 3 || len(c.NewGaps) != 0 {
+		t.Errorf("closed gaps %v, new gaps %v", c.ClosedGaps, c.NewGaps)
+	}
+	if !strings.HasPrefix(c.Summary, "Readiness improved ") || !strings.Contains(c.Summary, "Kubernetes") ||
+		!strings.HasSuffix(c.Summary, " gaps closed") {
+		t.Errorf("summary = %q", c.Summary)
+	}
+
+	if h := readinessHistory(t, srv, token, "job-002"); len(h.Snapshots) != 0 {
+		t.Errorf("expected no history for another job, got %d snapshots", len(h.Snapshots))
+	}
+	resp := doRequest(t, srv, http.MethodGet, "/api/v1/me/readiness-history", nil, token)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("expected 400 without job_id, got %d", resp.StatusCode)
+	}
+	resp = doRequest(t, srv, http.MethodGet, "/api/v1/me/readiness-history?job_id=job-001", nil, "")
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusUnauthorized {
+		t.Errorf("expected 401 without a token, got %d", resp.StatusCode)
+	}
+}
+
+func TestReadinessHistory_Summary(t *testing.T) {
+	store := handler.NewMemoryReadinessStore(0)
+	srv := readinessTestServer(t, store)
+	token := registerAndLogin(t, srv, "readiness-summary@example.com", "password123", "Readiness Summary User")
+	claims, err := middleware.ParseToken(middleware.DefaultJWTConfig("test-secret"), token)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	march := time.Date(2025, 3, 3, 9, 0, 0, 0, time.UTC)
+	for i, s := range []types.ReadinessSnapshot{
+		{ReadinessScore: 48.2, CriticalGaps: 2, TotalLearningHours: 90, GapSkills: []string{"Kubernetes", "Terraform"}},
+		{ReadinessScore: 55, CriticalGaps: 2, TotalLearningHours: 80, GapSkills: []string{"Kubernetes", "Terraform"}},
+		{ReadinessScore: 60.4, CriticalGaps: 1, TotalLearningHours: 40, GapSkills: []string{"terraform", "AWS"}},
+	} {
+		s.JobID, s.RecordedAt = "job-001", march.AddDate(0, i, 0)
+		store.Append(context.Background(), claims.UserID, s)
+	}
+
+	h := readinessHistory(t, srv, token, "job-001")
+	if got := h.Change.Summary; got != "Readiness improved 12 points since March 3; Kubernetes gap closed" {
+		t.Errorf("summary = %q", got)
+	}
+	if c := h.Change; c.ReadinessDelta != 12.2 || c.TotalLearningHoursDelta != -50 || strings.Join(c.NewGaps, ",") != "AWS" {
+		t.Errorf("unexpected change %+v", c)
+	}
+	if p := h.Snapshots[2]; p.ReadinessDelta != 5.4 || strings.Join(p.ClosedGaps, ",") != "Kubernetes" || strings.Join(p.NewGaps, ",") != "AWS" {
+		t.Errorf("unexpected latest point %+v", p)
+	}
+}
+
+func TestMemoryReadinessStore(t *testing.T) {
+	ctx := context.Background()
+	store := handler.NewMemoryReadinessStore(3)
+	start := time.Date(2025, 3, 3, 9, 0, 0, 0, time.UTC)
+	snap := func(score float64, day int) types.ReadinessSnapshot {
+		return types.ReadinessSnapshot{JobID: "job-001", ReadinessScore: score, GapSkills: []string{"Go"}, RecordedAt: start.AddDate(0, 0, day)}
+	}
+
+	for i, tt := range []struct {
+		score float64
+		added bool
+	}{{50, true}, {50, false}, {60, true}, {50, true}, {70, true}, {80, true}} {
+		if added, err := store.Append(ctx, "user-1", snap(tt.score, i)); err != nil || added != tt.added {
+			t.Errorf("append %d (%.0f): added = %v, %v; want %v", i, tt.score, added, err, tt.added)
+		}
+	}
+	history, _ := store.History(ctx, "user-1", "job-001")
+	var scores []float64
+	for _, s := range history {
+		scores = append(scores, s.ReadinessScore)
+	}
+	if len(scores) != 3 || scores[0] != 50 || scores[1] != 70 || scores[2] != 80 {
+		t.Errorf("scores = %v, want the latest 3: [50 70 80]", scores)
+	}
+	if other, _ := store.History(ctx, "user-2", "job-001"); len(other) != 0 {
+		t.Errorf("another user's history has %d snapshots", len(other))
+	}
+
+	store.Append(ctx, "user-2", snap(40, 0))
+	if err := store.DeleteUserHistory(ctx, "user-1"); err != nil {
+		t.Fatalf("DeleteUserHistory: %v", err)
+	}
+	if history, _ := store.UserHistory(ctx, "user-1"); len(history) != 0 {
+		t.Errorf("deleted history has %d snapshots", len(history))
+	}
+	if other, _ := store.UserHistory(ctx, "user-2"); len(other) != 1 {
+		t.Errorf("another user's history has %d snapshots after the deletion, want 1", len(other))
+	}
+}
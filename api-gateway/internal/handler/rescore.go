@@ -0,0 +1,360 @@
+// Package handler – rescore.go re-scores a user's saved jobs against their
+// current profile in the background.
+package handler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/learnbot/api-gateway/internal/middleware"
+	"github.com/learnbot/api-gateway/internal/tasks"
+	"github.com/learnbot/api-gateway/internal/types"
+	"github.com/learnbot/resume-parser/pkg/scoring"
+	"github.com/learnbot/shared/logging"
+	"github.com/learnbot/shared/openapi"
+)
+
+const (
+	// savedJobsPageSize is the page size AggregatorSavedJobs lists saved
+	// jobs with, the job aggregator's maximum.
+	savedJobsPageSize = 100
+
+	// maxSavedJobsPages caps the pages AggregatorSavedJobs reads.
+	maxSavedJobsPages = 50
+
+	// maxSavedJobsBody caps the size of a job aggregator response.
+	maxSavedJobsBody = 4 << 20
+)
+
+// SavedJobScores reads a user's saved jobs and stores their match scores.
+// It is implemented by *AggregatorSavedJobs.
+type SavedJobScores interface {
+	// SavedJobs returns every saved job of userID.
+	SavedJobs(ctx context.Context, userID string) ([]SavedJobRequirements, error)
+
+	// SetScore stores the score of a saved job, computed against the
+	// profile as it was at computedAt.
+	SetScore(ctx context.Context, userID, savedJobID string, score float64, computedAt time.Time) error
+}
+
+// SavedJobRequirements is a saved job and the requirements of its job. Err
+// is set instead of Requirements when they could not be read, such as for a
+// job the source has removed.
+type SavedJobRequirements struct {
+	ID           string
+	Requirements scoring.JobRequirements
+	Err          error
+}
+
+// RescoreHandler re-scores a user's saved jobs on a task queue, one task
+// per user at a time.
+type RescoreHandler struct {
+	store  SavedJobScores
+	queue  *tasks.Queue
+	now    func() time.Time
+	logger *slog.Logger
+}
+
+// NewRescoreHandler creates a RescoreHandler that runs its tasks on queue.
+// A nil logger uses slog.Default().
+func NewRescoreHandler(store SavedJobScores, queue *tasks.Queue, logger *slog.Logger) *RescoreHandler {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &RescoreHandler{store: store, queue: queue, now: time.Now, logger: logger}
+}
+
+// RegisterRoutes registers the re-scoring routes on the mux. They take
+// precedence over the /api/v1/me/ group proxied to the job aggregator.
+//
+//	POST /api/v1/me/saved-jobs/rescore      – re-score the saved jobs
+//	GET  /api/v1/me/saved-jobs/rescore/{id} – progress of a re-score
+func (h *RescoreHandler) RegisterRoutes(mux openapi.Router, authMiddleware func(http.Handler) http.Handler) {
+	mux.Handle("/api/v1/me/saved-jobs/rescore",
+		authMiddleware(http.HandlerFunc(h.Rescore)))
+	mux.Handle("/api/v1/me/saved-jobs/rescore/",
+		authMiddleware(http.HandlerFunc(h.RescoreStatus)))
+}
+
+// DescribeRoutes documents the routes registered by RegisterRoutes.
+func (h *RescoreHandler) DescribeRoutes(spec *openapi.Spec) {
+	auth := []string{openapi.BearerAuth}
+	spec.Route("/api/v1/me/saved-jobs/rescore", openapi.Operation{
+		Method:  http.MethodPost,
+		Summary: "Re-score the current user's saved jobs against their profile",
+		Description: "Starts a background task that stores each saved job's match score and the time the " +
+			"profile was read. Returns 202 with the task, or 200 with the task already pending or running " +
+			"for the user. A saved job that cannot be scored is counted as failed; the others are still scored.",
+		Security: auth,
+		Response: success(types.RescoreStatus{}),
+		Status:   http.StatusAccepted,
+		Errors:   []int{http.StatusUnauthorized, http.StatusServiceUnavailable},
+	})
+	spec.Route("/api/v1/me/saved-jobs/rescore/", openapi.Operation{
+		Method:      http.MethodGet,
+		Path:        "/api/v1/me/saved-jobs/rescore/{id}",
+		Summary:     "Progress of re-scoring the current user's saved jobs",
+		Description: "Finished tasks are kept for an hour.",
+		Security:    auth,
+		Response:    success(types.RescoreStatus{}),
+		Errors:      []int{http.StatusUnauthorized, http.StatusNotFound},
+	})
+}
+
+// Rescore handles POST /api/v1/me/saved-jobs/rescore.
+func (h *RescoreHandler) Rescore(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		WriteMethodNotAllowed(w)
+		return
+	}
+	userID := middleware.GetUserID(r)
+	if userID == "" {
+		WriteError(w, http.StatusUnauthorized, "UNAUTHORIZED", "authentication required")
+		return
+	}
+
+	status, created, err := h.queue.Submit(rescoreKey(userID), h.rescore(userID))
+	if errors.Is(err, tasks.ErrQueueFull) {
+		WriteError(w, http.StatusServiceUnavailable, "SERVICE_UNAVAILABLE", "too many re-scores are waiting; try again later")
+		return
+	}
+	if err != nil {
+		h.logger.ErrorContext(r.Context(), "queue saved job re-score failed", "error", err)
+		WriteInternalError(w)
+		return
+	}
+	code := http.StatusOK
+	if created {
+		code = http.StatusAccepted
+	}
+	w.Header().Set("Location", "/api/v1/me/saved-jobs/rescore/"+status.ID)
+	WriteSuccess(w, code, rescoreStatus(status))
+}
+
+// RescoreStatus handles GET /api/v1/me/saved-jobs/rescore/{id}. Another
+// user's task is not found.
+func (h *RescoreHandler) RescoreStatus(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		WriteMethodNotAllowed(w)
+		return
+	}
+	userID := middleware.GetUserID(r)
+	if userID == "" {
+		WriteError(w, http.StatusUnauthorized, "UNAUTHORIZED", "authentication required")
+		return
+	}
+	id := strings.TrimPrefix(r.URL.Path, "/api/v1/me/saved-jobs/rescore/")
+	status, ok := h.queue.Status(id)
+	if !ok || status.Key != rescoreKey(userID) {
+		WriteNotFound(w, "re-score")
+		return
+	}
+	WriteSuccess(w, http.StatusOK, rescoreStatus(status))
+}
+
+// rescore returns the task that scores each saved job of userID. The
+// profile is read when the task starts, so a re-score queued behind
+// another sees the latest profile.
+func (h *RescoreHandler) rescore(userID string) tasks.Func {
+	return func(ctx context.Context, p *tasks.Progress) error {
+		computedAt := h.now().UTC()
+		profile := buildCandidateProfile(userID)
+		saved, err := h.store.SavedJobs(ctx, userID)
+		if err != nil {
+			h.logger.ErrorContext(ctx, "list saved jobs for re-score failed", "user_id", userID, "error", err)
+			return fmt.Errorf("list saved jobs: %w", err)
+		}
+		p.SetTotal(len(saved))
+		for _, s := range saved {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			if s.Err != nil {
+				p.Failed(s.ID, s.Err)
+				continue
+			}
+			score := scoring.Calculate(profile, s.Requirements).OverallScore
+			if err := h.store.SetScore(ctx, userID, s.ID, score, computedAt); err != nil {
+				p.Failed(s.ID, err)
+				continue
+			}
+			p.Succeeded()
+		}
+		return nil
+	}
+}
+
+// rescoreKey is the task queue key of userID's re-scores.
+func rescoreKey(userID string) string {
+	return "saved-job-rescore:" + userID
+}
+
+// rescoreStatus converts a task status to its response.
+func rescoreStatus(s tasks.Status) types.RescoreStatus {
+	resp := types.RescoreStatus{
+		ID:          s.ID,
+		Status:      string(s.State),
+		Total:       s.Total,
+		Scored:      s.Succeeded,
+		Failed:      s.Failed,
+		Error:       s.Err,
+		CreatedAt:   s.CreatedAt,
+		StartedAt:   s.StartedAt,
+		CompletedAt: s.FinishedAt,
+	}
+	for _, e := range s.Errors {
+		resp.Failures = append(resp.Failures, types.RescoreFailure{SavedJobID: e.Item, Error: e.Error})
+	}
+	return resp
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Job aggregator
+// ─────────────────────────────────────────────────────────────────────────────
+
+// AggregatorSavedJobs is a SavedJobScores backed by the job aggregator's
+// saved jobs API.
+type AggregatorSavedJobs struct {
+	baseURL string
+	client  *http.Client
+}
+
+// NewAggregatorSavedJobs creates an AggregatorSavedJobs for the job
+// aggregator at baseURL. A nil client uses one with DefaultBackendTimeout.
+func NewAggregatorSavedJobs(baseURL string, client *http.Client) *AggregatorSavedJobs {
+	if client == nil {
+		client = &http.Client{Transport: logging.Transport(nil), Timeout: DefaultBackendTimeout}
+	}
+	return &AggregatorSavedJobs{baseURL: strings.TrimRight(baseURL, "/"), client: client}
+}
+
+// savedJobDetail holds the fields of a saved job's current job that it is
+// scored on. Nullable columns are encoded as sql.Null* values.
+type savedJobDetail struct {
+	Title           string                  `json:"title"`
+	LocationType    string                  `json:"location_type"`
+	ExperienceLevel string                  `json:"experience_level"`
+	RequiredSkills  []string                `json:"required_skills"`
+	PreferredSkills []string                `json:"preferred_skills"`
+	Industry        struct{ String string } `json:"industry"`
+	LocationCity    struct{ String string } `json:"location_city"`
+	LocationCountry struct{ String string } `json:"location_country"`
+}
+
+// SavedJobs implements SavedJobScores. Each saved job's requirements are
+// decoded on their own, so that one malformed job fails only its entry.
+func (s *AggregatorSavedJobs) SavedJobs(ctx context.Context, userID string) ([]SavedJobRequirements, error) {
+	items, err := s.list(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	saved := make([]SavedJobRequirements, 0, len(items))
+	for _, raw := range items {
+		var item struct {
+			ID  string          `json:"id"`
+			Job json.RawMessage `json:"job"`
+		}
+		if err := json.Unmarshal(raw, &item); err != nil {
+			return nil, fmt.Errorf("decode saved job: %w", err)
+		}
+		entry := SavedJobRequirements{ID: item.ID}
+		var job savedJobDetail
+		if len(item.Job) == 0 || string(item.Job) == "null" {
+			entry.Err = errors.New("job is no longer stored")
+		} else if err := json.Unmarshal(item.Job, &job); err != nil {
+			entry.Err = fmt.Errorf("decode job requirements: %w", err)
+		} else {
+			entry.Requirements = scoring.JobRequirements{
+				Title:           job.Title,
+				RequiredSkills:  job.RequiredSkills,
+				PreferredSkills: job.PreferredSkills,
+				LocationCity:    job.LocationCity.String,
+				LocationCountry: job.LocationCountry.String,
+				LocationType:    job.LocationType,
+				Industry:        job.Industry.String,
+				ExperienceLevel: job.ExperienceLevel,
+			}
+		}
+		saved = append(saved, entry)
+	}
+	return saved, nil
+}
+
+// list returns every saved job of userID as the job aggregator encodes it,
+// with its job.
+func (s *AggregatorSavedJobs) list(ctx context.Context, userID string) ([]json.RawMessage, error) {
+	items := []json.RawMessage{}
+	for page := 0; page < maxSavedJobsPages; page++ {
+		q := url.Values{
+			"limit":  {strconv.Itoa(savedJobsPageSize)},
+			"offset": {strconv.Itoa(page * savedJobsPageSize)},
+		}
+		var body struct {
+			Data       []json.RawMessage `json:"data"`
+			Pagination struct {
+				HasMore bool `json:"has_more"`
+			} `json:"pagination"`
+		}
+		if err := s.do(ctx, http.MethodGet, "/api/v1/me/saved-jobs?"+q.Encode(), userID, nil, &body); err != nil {
+			return nil, err
+		}
+		items = append(items, body.Data...)
+		if !body.Pagination.HasMore {
+			break
+		}
+	}
+	return items, nil
+}
+
+// SetScore implements SavedJobScores.
+func (s *AggregatorSavedJobs) SetScore(ctx context.Context, userID, savedJobID string, score float64, computedAt time.Time) error {
+	body := map[string]any{"match_score": score, "computed_at": computedAt}
+	return s.do(ctx, http.MethodPut, "/api/v1/saved-jobs/"+url.PathEscape(savedJobID)+"/score", userID, body, nil)
+}
+
+// do sends a request with a JSON body, if set, on behalf of userID, and
+// decodes a 200 response into v, if set.
+func (s *AggregatorSavedJobs) do(ctx context.Context, method, path, userID string, body, v any) error {
+	var r io.Reader
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		r = bytes.NewReader(b)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, s.baseURL+path, r)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	req.Header.Set("X-User-ID", userID)
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s %s: status %d", method, req.URL.Path, resp.StatusCode)
+	}
+	if v == nil {
+		return nil
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxSavedJobsBody)).Decode(v); err != nil {
+		return fmt.Errorf("%s %s: decode response: %w", method, req.URL.Path, err)
+	}
+	return nil
+}
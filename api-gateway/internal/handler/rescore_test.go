@@ -0,0 +1,222 @@
+package handler_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/learnbot/api-gateway/internal/handler"
+	"github.com/learnbot/api-gateway/internal/middleware"
+	"github.com/learnbot/api-gateway/internal/tasks"
+	"github.com/learnbot/api-gateway/internal/types"
+)
+
+// rescoreTestServer serves the auth, profile and re-scoring routes, with
+// saved jobs read from and scored in store.
+func rescoreTestServer(t *testing.T, store handler.SavedJobScores) *httptest.Server {
+	t.Helper()
+	jwtCfg := middleware.DefaultJWTConfig("test-secret")
+	authMiddleware := middleware.RequireAuth(jwtCfg)
+	queue := tasks.NewQueue(tasks.DefaultConfig())
+	queue.Start(t.Context())
+
+	mux := http.NewServeMux()
+	handler.NewAuthHandler(jwtCfg).RegisterRoutes(mux)
+	handler.NewProfileHandler(jwtCfg).RegisterRoutes(mux, authMiddleware)
+	handler.NewRescoreHandler(store, queue, nil).RegisterRoutes(mux, authMiddleware)
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+// startRescore starts a re-score and returns its status.
+func startRescore(t *testing.T, srv *httptest.Server, token string, wantCode int) types.RescoreStatus {
+	t.Helper()
+	resp := doRequest(t, srv, http.MethodPost, "/api/v1/me/saved-jobs/rescore", nil, token)
+	if resp.StatusCode != wantCode {
+		t.Fatalf("rescore: expected %d, got %d", wantCode, resp.StatusCode)
+	}
+	var result struct {
+		Data types.RescoreStatus `json:"data"`
+	}
+	decodeResponse(t, resp, &result)
+	if loc := resp.Header.Get("Location"); loc != "/api/v1/me/saved-jobs/rescore/"+result.Data.ID {
+		t.Errorf("Location = %q", loc)
+	}
+	return result.Data
+}
+
+// waitRescore polls the re-score with id until it is done.
+func waitRescore(t *testing.T, srv *httptest.Server, token, id string) types.RescoreStatus {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		resp := doRequest(t, srv, http.MethodGet, "/api/v1/me/saved-jobs/rescore/"+id, nil, token)
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("rescore status: expected 200, got %d", resp.StatusCode)
+		}
+		var result struct {
+			Data types.RescoreStatus `json:"data"`
+		}
+		decodeResponse(t, resp, &result)
+		if result.Data.Status == "done" {
+			return result.Data
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	t.Fatalf("re-score %s did not finish", id)
+	return types.RescoreStatus{}
+}
+
+// fakeSavedJobsBackend serves a job aggregator's saved jobs list and
+// records the scores set on them.
+type fakeSavedJobsBackend struct {
+	*httptest.Server
+	mu     sync.Mutex
+	scores map[string]float64
+}
+
+func newFakeSavedJobsBackend(t *testing.T, items string) *fakeSavedJobsBackend {
+	t.Helper()
+	b := &fakeSavedJobsBackend{scores: map[string]float64{}}
+	b.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-User-ID") == "" {
+			http.Error(w, "missing user", http.StatusUnauthorized)
+			return
+		}
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/me/saved-jobs":
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"data":` + items + `,"pagination":{"has_more":false}}`))
+		case r.Method == http.MethodPut && strings.HasSuffix(r.URL.Path, "/score"):
+			var body struct {
+				MatchScore float64   `json:"match_score"`
+				ComputedAt time.Time `json:"computed_at"`
+			}
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil || body.ComputedAt.IsZero() {
+				http.Error(w, "bad body", http.StatusBadRequest)
+				return
+			}
+			id := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/api/v1/saved-jobs/"), "/score")
+			b.mu.Lock()
+			b.scores[id] = body.MatchScore
+			b.mu.Unlock()
+			w.Write([]byte(`{}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(b.Close)
+	return b
+}
+
+func TestRescoreSavedJobs(t *testing.T) {
+	backend := newFakeSavedJobsBackend(t, `[
+		{"id": "saved-1", "job": {"title": "Go Developer", "required_skills": ["Go", "PostgreSQL"], "location_type": "remote"}},
+		{"id": "saved-2", "job": {"title": "Broken", "required_skills": "Go"}},
+		{"id": "saved-3", "job": {"title": "Frontend Developer", "required_skills": ["React"], "industry": {"String": "Media", "Valid": true}}}
+	]`)
+	srv := rescoreTestServer(t, handler.NewAggregatorSavedJobs(backend.URL, nil))
+	token := registerAndLogin(t, srv, "rescore@example.com", "password123", "Rescore User")
+	doRequest(t, srv, http.MethodPut, "/api/profile/skills", types.SkillUpdateRequest{
+		Skills: []types.SkillInput{{Name: "Go", Proficiency: "advanced"}},
+	}, token).Body.Close()
+
+	started := startRescore(t, srv, token, http.StatusAccepted)
+	status := waitRescore(t, srv, token, started.ID)
+
+	// The malformed job fails on its own; the others are still scored.
+	if status.Total != 3 || status.Scored != 2 || status.Failed != 1 || status.Error != "" {
+		t.Fatalf("unexpected status %+v", status)
+	}
+	if len(status.Failures) != 1 || status.Failures[0].SavedJobID != "saved-2" {
+		t.Errorf("Failures = %+v", status.Failures)
+	}
+	if status.StartedAt == nil || status.CompletedAt == nil {
+		t.Errorf("expected start and completion times, got %+v", status)
+	}
+	backend.mu.Lock()
+	defer backend.mu.Unlock()
+	if len(backend.scores) != 2 {
+		t.Fatalf("scores = %v, want saved-1 and saved-3", backend.scores)
+	}
+	if backend.scores["saved-1"] <= backend.scores["saved-3"] {
+		t.Errorf("expected the Go job to score higher than the React job, got %v", backend.scores)
+	}
+}
+
+// blockingSavedJobs is a SavedJobScores whose listing waits for release.
+type blockingSavedJobs struct {
+	release chan struct{}
+}
+
+func (b *blockingSavedJobs) SavedJobs(ctx context.Context, userID string) ([]handler.SavedJobRequirements, error) {
+	select {
+	case <-b.release:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	return []handler.SavedJobRequirements{{ID: "saved-1"}}, nil
+}
+
+func (b *blockingSavedJobs) SetScore(context.Context, string, string, float64, time.Time) error {
+	return nil
+}
+
+func TestRescoreSavedJobs_Idempotent(t *testing.T) {
+	store := &blockingSavedJobs{release: make(chan struct{})}
+	srv := rescoreTestServer(t, store)
+	token := registerAndLogin(t, srv, "rescore-twice@example.com", "password123", "Rescore Twice")
+	other := registerAndLogin(t, srv, "rescore-other@example.com", "password123", "Rescore Other")
+
+	first := startRescore(t, srv, token, http.StatusAccepted)
+	again := startRescore(t, srv, token, http.StatusOK)
+	if again.ID != first.ID {
+		t.Errorf("second rescore started %s, want the running %s", again.ID, first.ID)
+	}
+
+	// Another user can neither see the task nor share it.
+	resp := doRequest(t, srv, http.MethodGet, "/api/v1/me/saved-jobs/rescore/"+first.ID, nil, other)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusNotFound {
+		t.Errorf("other user's status: expected 404, got %d", resp.StatusCode)
+	}
+	if own := startRescore(t, srv, other, http.StatusAccepted); own.ID == first.ID {
+		t.Error("another user joined the task")
+	}
+
+	close(store.release)
+	if s := waitRescore(t, srv, token, first.ID); s.Scored != 1 {
+		t.Errorf("unexpected status %+v", s)
+	}
+	if next := startRescore(t, srv, token, http.StatusAccepted); next.ID == first.ID {
+		t.Error("rescore after the task finished reused it")
+	}
+}
+
+func TestRescoreSavedJobs_Errors(t *testing.T) {
+	srv := rescoreTestServer(t, &blockingSavedJobs{release: make(chan struct{})})
+	token := registerAndLogin(t, srv, "rescore-errors@example.com", "password123", "Rescore Errors")
+
+	tests := []struct {
+		method, path, token string
+		want                int
+	}{
+		{http.MethodPost, "/api/v1/me/saved-jobs/rescore", "", http.StatusUnauthorized},
+		{http.MethodGet, "/api/v1/me/saved-jobs/rescore", token, http.StatusMethodNotAllowed},
+		{http.MethodGet, "/api/v1/me/saved-jobs/rescore/unknown", token, http.StatusNotFound},
+		{http.MethodPost, "/api/v1/me/saved-jobs/rescore/unknown", token, http.StatusMethodNotAllowed},
+	}
+	for _, tt := range tests {
+		resp := doRequest(t, srv, tt.method, tt.path, nil, tt.token)
+		resp.Body.Close()
+		if resp.StatusCode != tt.want {
+			t.Errorf("%s %s: expected %d, got %d", tt.method, tt.path, tt.want, resp.StatusCode)
+		}
+	}
+}
@@ -6,6 +6,8 @@ import (
 	"net/http"
 
 	"github.com/learnbot/api-gateway/internal/types"
+	"github.com/learnbot/shared/openapi"
+	"github.com/learnbot/shared/validation"
 )
 
 // ─────────────────────────────────────────────────────────────────────────────
@@ -44,10 +46,18 @@ func WriteError(w http.ResponseWriter, status int, code, message string, details
 	})
 }
 
-// WriteValidationError writes a 400 Bad Request with field-level errors.
+// WriteValidationError writes a 422 Unprocessable Entity with field-level
+// errors, listed both in the top-level errors array and in error.details.
 func WriteValidationError(w http.ResponseWriter, details []types.FieldError) {
-	WriteError(w, http.StatusBadRequest, "VALIDATION_ERROR",
-		"request validation failed", details...)
+	writeJSON(w, http.StatusUnprocessableEntity, types.APIResponse{
+		Success: false,
+		Error: &types.APIError{
+			Code:    "VALIDATION_ERROR",
+			Message: "request validation failed",
+			Details: details,
+		},
+		Errors: details,
+	})
 }
 
 // WriteNotFound writes a 404 Not Found response.
@@ -68,6 +78,19 @@ func WriteMethodNotAllowed(w http.ResponseWriter) {
 		"HTTP method not allowed")
 }
 
+// success documents a response written by WriteSuccess with data.
+func success(data interface{}) openapi.Fields {
+	return openapi.Fields{"success": true, "data": data}
+}
+
+// successWithMeta documents a response written by WriteSuccessWithMeta.
+func successWithMeta(data interface{}) openapi.Fields {
+	return openapi.Fields{"success": true, "data": data, "meta": types.ResponseMeta{}}
+}
+
+// ErrorBody documents the body written by WriteError.
+var ErrorBody = openapi.Fields{"success": false, "error": types.APIError{}}
+
 // writeJSON serializes v as JSON and writes it to the response.
 func writeJSON(w http.ResponseWriter, status int, v interface{}) {
 	w.Header().Set("Content-Type", "application/json")
@@ -79,12 +102,25 @@ func writeJSON(w http.ResponseWriter, status int, v interface{}) {
 // Request parsing helpers
 // ─────────────────────────────────────────────────────────────────────────────
 
-// DecodeJSON decodes the request body as JSON into v.
-// Returns false and writes an error response if decoding fails.
+// decodeConfig controls DecodeJSON; see SetValidationConfig.
+var decodeConfig validation.Config
+
+// SetValidationConfig sets how DecodeJSON decodes request bodies. By
+// default unknown fields are rejected.
+func SetValidationConfig(cfg validation.Config) {
+	decodeConfig = cfg
+}
+
+// DecodeJSON decodes the request body as JSON into v and checks the rules in
+// its `validate` struct tags. Returns false and writes an error response if
+// decoding fails: 400 if the body is not JSON, 422 for unknown fields,
+// values of the wrong type and failed rules.
 func DecodeJSON(w http.ResponseWriter, r *http.Request, v interface{}) bool {
-	dec := json.NewDecoder(r.Body)
-	dec.DisallowUnknownFields()
-	if err := dec.Decode(v); err != nil {
+	if err := decodeConfig.Decode(r.Body, v); err != nil {
+		if errs, ok := validation.AsErrors(err); ok {
+			WriteValidationError(w, errs)
+			return false
+		}
 		WriteError(w, http.StatusBadRequest, "INVALID_JSON",
 			"invalid request body: "+err.Error())
 		return false
@@ -96,32 +132,35 @@ func DecodeJSON(w http.ResponseWriter, r *http.Request, v interface{}) bool {
 // Validation helpers
 // ─────────────────────────────────────────────────────────────────────────────
 
-// Validator collects field validation errors.
+// Validator collects field validation errors for checks that the
+// `validate` struct tags checked by DecodeJSON cannot express.
 type Validator struct {
 	errors []types.FieldError
 }
 
+// add records a failure of field with a validation code.
+func (v *Validator) add(field, code, message string) {
+	v.errors = append(v.errors, types.FieldError{Field: field, Code: code, Message: message})
+}
+
 // Required validates that a string field is non-empty.
 func (v *Validator) Required(field, value, message string) {
 	if len(trimSpaceStr(value)) == 0 {
-		v.errors = append(v.errors, types.FieldError{Field: field, Message: message})
+		v.add(field, validation.CodeRequired, message)
 	}
 }
 
 // MinLength validates that a string field meets a minimum length.
 func (v *Validator) MinLength(field, value string, min int, message string) {
 	if len(value) < min {
-		v.errors = append(v.errors, types.FieldError{Field: field, Message: message})
+		v.add(field, validation.CodeMinLength, message)
 	}
 }
 
 // ValidEmail validates that a string looks like an email address.
 func (v *Validator) ValidEmail(field, value string) {
 	if !isValidEmail(value) {
-		v.errors = append(v.errors, types.FieldError{
-			Field:   field,
-			Message: "must be a valid email address",
-		})
+		v.add(field, validation.CodeEmail, "must be a valid email address")
 	}
 }
 
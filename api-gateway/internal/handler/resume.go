@@ -11,6 +11,7 @@ import (
 
 	"github.com/learnbot/api-gateway/internal/middleware"
 	"github.com/learnbot/resume-parser/pkg/parse"
+	"github.com/learnbot/shared/openapi"
 )
 
 // ─────────────────────────────────────────────────────────────────────────────
@@ -49,6 +50,30 @@ func (s *resumeStore) get(userID string) (*resumeRecord, bool) {
 	return rec, ok
 }
 
+func (s *resumeStore) delete(userID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.resumes, userID)
+}
+
+// skillSets returns the skill names of each user's latest parsed resume.
+func (s *resumeStore) skillSets() [][]string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	sets := make([][]string, 0, len(s.resumes))
+	for _, rec := range s.resumes {
+		if rec.ParsedData == nil {
+			continue
+		}
+		set := make([]string, len(rec.ParsedData.Skills))
+		for i, sk := range rec.ParsedData.Skills {
+			set[i] = sk.Name
+		}
+		sets = append(sets, set)
+	}
+	return sets
+}
+
 // ─────────────────────────────────────────────────────────────────────────────
 // ResumeHandler
 // ─────────────────────────────────────────────────────────────────────────────
@@ -68,11 +93,37 @@ func NewResumeHandler() *ResumeHandler {
 // RegisterRoutes registers resume routes on the mux.
 //
 //	POST /api/resume/upload  – upload and parse a resume (PDF or DOCX)
-func (h *ResumeHandler) RegisterRoutes(mux *http.ServeMux, authMiddleware func(http.Handler) http.Handler) {
+func (h *ResumeHandler) RegisterRoutes(mux openapi.Router, authMiddleware func(http.Handler) http.Handler) {
 	mux.Handle("/api/resume/upload",
 		authMiddleware(http.HandlerFunc(h.Upload)))
 }
 
+// DescribeRoutes documents the routes registered by RegisterRoutes.
+func (h *ResumeHandler) DescribeRoutes(spec *openapi.Spec) {
+	var parsed parse.ParsedResume
+	spec.Route("/api/resume/upload", openapi.Operation{
+		Method:      http.MethodPost,
+		Summary:     "Upload and parse a PDF or DOCX resume",
+		Description: "The parsed skills replace the current user's profile skills.",
+		Security:    []string{openapi.BearerAuth},
+		Request:     openapi.Fields{"resume": openapi.File{}},
+		RequestType: "multipart/form-data",
+		Response: success(openapi.Fields{
+			"resume_id":      "",
+			"file_name":      "",
+			"parsed_at":      time.Time{},
+			"personal":       parsed.PersonalInfo,
+			"skills":         parsed.Skills,
+			"experience":     parsed.WorkExperience,
+			"education":      parsed.Education,
+			"certifications": parsed.Certifications,
+			"projects":       parsed.Projects,
+			"summary":        "",
+		}),
+		Errors: []int{http.StatusBadRequest, http.StatusUnauthorized, http.StatusUnprocessableEntity},
+	})
+}
+
 // Upload handles POST /api/resume/upload.
 //
 // Accepts multipart/form-data with a "resume" file field.
@@ -162,14 +213,25 @@ func (h *ResumeHandler) Upload(w http.ResponseWriter, r *http.Request) {
 	}
 	globalResumeStore.save(userID, rec)
 
-	// Auto-update profile skills from parsed resume.
+	// Auto-update profile skills from parsed resume. Proficiencies the user
+	// set themselves win over those inferred from the work history.
 	if len(result.Skills) > 0 {
 		globalProfileStore.update(userID, func(p *profileRecord) {
+			explicit := make(map[string]string, len(p.Skills))
+			for _, s := range p.Skills {
+				if s.Proficiency != "" && !s.ProficiencyInferred {
+					explicit[strings.ToLower(s.Name)] = s.Proficiency
+				}
+			}
 			p.Skills = make([]skillRecord, len(result.Skills))
 			for i, s := range result.Skills {
 				p.Skills[i] = skillRecord{
-					Name:        s.Name,
-					Proficiency: s.Category, // use category as proficiency proxy
+					Name:                s.Name,
+					Proficiency:         s.Proficiency,
+					ProficiencyInferred: s.ProficiencyInferred,
+				}
+				if level, ok := explicit[strings.ToLower(s.Name)]; ok {
+					p.Skills[i].Proficiency, p.Skills[i].ProficiencyInferred = level, false
 				}
 			}
 		})
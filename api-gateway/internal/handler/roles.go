@@ -0,0 +1,148 @@
+// Package handler – roles.go implements the admin endpoints that assign
+// user roles.
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/learnbot/api-gateway/internal/middleware"
+	"github.com/learnbot/api-gateway/internal/types"
+	"github.com/learnbot/shared/openapi"
+)
+
+// RolesHandler lets admins read and assign user roles. New roles reach a
+// user's access token on their next login or refresh.
+type RolesHandler struct {
+	users UserStore
+}
+
+// NewRolesHandler creates a new RolesHandler over the in-memory accounts,
+// until SetUserStore replaces the store.
+func NewRolesHandler() *RolesHandler {
+	return &RolesHandler{users: defaultUserStore}
+}
+
+// SetUserStore replaces the store of user accounts; see
+// AuthHandler.SetUserStore.
+func (h *RolesHandler) SetUserStore(store UserStore) {
+	h.users = store
+}
+
+// RegisterRoutes registers role routes on the mux, restricted to admins.
+//
+//	GET /api/admin/users/{id}/roles – get a user's roles
+//	PUT /api/admin/users/{id}/roles – replace a user's roles
+func (h *RolesHandler) RegisterRoutes(mux openapi.Router, authMiddleware func(http.Handler) http.Handler) {
+	mux.Handle("/api/admin/users/",
+		authMiddleware(middleware.RequireRole(middleware.RoleAdmin)(http.HandlerFunc(h.handleUserRoles))))
+}
+
+// DescribeRoutes documents the routes registered by RegisterRoutes.
+func (h *RolesHandler) DescribeRoutes(spec *openapi.Spec) {
+	auth := []string{openapi.BearerAuth}
+	roles := success(openapi.Fields{"user_id": "", "roles": []string{}})
+	spec.Route("/api/admin/users/",
+		openapi.Operation{
+			Method:      http.MethodGet,
+			Path:        "/api/admin/users/{id}/roles",
+			Summary:     "Get a user's roles",
+			Description: "Requires the admin role.",
+			Security:    auth,
+			Response:    roles,
+			Errors:      []int{http.StatusUnauthorized, http.StatusForbidden, http.StatusNotFound},
+		},
+		openapi.Operation{
+			Method:  http.MethodPut,
+			Path:    "/api/admin/users/{id}/roles",
+			Summary: "Replace a user's roles",
+			Description: "Requires the admin role. The user role is always kept, and admins cannot " +
+				"remove their own admin role. Tokens pick up the new roles on the user's next login or refresh.",
+			Security: auth,
+			Request:  types.RoleUpdateRequest{},
+			Response: roles,
+			Errors:   []int{http.StatusBadRequest, http.StatusUnauthorized, http.StatusForbidden, http.StatusNotFound, http.StatusConflict},
+		},
+	)
+}
+
+// handleUserRoles handles GET/PUT /api/admin/users/{id}/roles.
+func (h *RolesHandler) handleUserRoles(w http.ResponseWriter, r *http.Request) {
+	userID, rest, _ := strings.Cut(strings.TrimPrefix(r.URL.Path, "/api/admin/users/"), "/")
+	if userID == "" || rest != "roles" {
+		WriteError(w, http.StatusNotFound, "NOT_FOUND", "route not found")
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		user, err := h.users.GetByID(r.Context(), userID)
+		if errors.Is(err, ErrUserNotFound) {
+			WriteNotFound(w, "user")
+			return
+		}
+		if err != nil {
+			WriteInternalError(w)
+			return
+		}
+		writeUserRoles(w, user)
+	case http.MethodPut:
+		h.updateRoles(w, r, userID)
+	default:
+		WriteMethodNotAllowed(w)
+	}
+}
+
+// updateRoles handles PUT /api/admin/users/{id}/roles.
+func (h *RolesHandler) updateRoles(w http.ResponseWriter, r *http.Request, userID string) {
+	var req types.RoleUpdateRequest
+	if !DecodeJSON(w, r, &req) {
+		return
+	}
+
+	roles := make([]middleware.Role, 0, len(req.Roles))
+	for _, name := range req.Roles {
+		role, err := middleware.ParseRole(name)
+		if err != nil {
+			WriteError(w, http.StatusBadRequest, "VALIDATION_ERROR", err.Error())
+			return
+		}
+		roles = append(roles, role)
+	}
+	roles = middleware.NormalizeRoles(roles)
+
+	// An admin demoting themselves could leave no admin to undo it.
+	if userID == middleware.GetUserID(r) && !middleware.HasRole(roles, middleware.RoleAdmin) {
+		WriteError(w, http.StatusConflict, "CANNOT_REMOVE_OWN_ADMIN", "admins cannot remove their own admin role")
+		return
+	}
+
+	user, err := h.users.UpdateProfile(r.Context(), userID, UserUpdate{Roles: roles})
+	if errors.Is(err, ErrUserNotFound) {
+		WriteNotFound(w, "user")
+		return
+	}
+	if err != nil {
+		WriteInternalError(w)
+		return
+	}
+	writeUserRoles(w, user)
+}
+
+// writeUserRoles writes the roles of user.
+func writeUserRoles(w http.ResponseWriter, user *User) {
+	WriteSuccess(w, http.StatusOK, map[string]interface{}{
+		"user_id": user.ID,
+		"roles":   roleNames(user.Roles),
+	})
+}
+
+// roleNames converts roles to the strings used in responses.
+func roleNames(roles []middleware.Role) []string {
+	names := make([]string, len(roles))
+	for i, r := range roles {
+		names[i] = string(r)
+	}
+	return names
+}
@@ -0,0 +1,131 @@
+package handler_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/learnbot/api-gateway/internal/handler"
+	"github.com/learnbot/api-gateway/internal/middleware"
+	"github.com/learnbot/api-gateway/internal/types"
+)
+
+// rolesTestServer serves the auth routes and the admin role routes.
+func rolesTestServer(t *testing.T, jwtCfg middleware.JWTConfig) *httptest.Server {
+	t.Helper()
+	mux := http.NewServeMux()
+	handler.NewAuthHandler(jwtCfg).RegisterRoutes(mux)
+	handler.NewRolesHandler().RegisterRoutes(mux, middleware.RequireAuth(jwtCfg))
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+// putRoles replaces a user's roles and returns the status, error code and
+// resulting roles.
+func putRoles(t *testing.T, srv *httptest.Server, token, userID string, roles ...string) (int, string, []string) {
+	t.Helper()
+	resp := doRequest(t, srv, http.MethodPut, "/api/admin/users/"+userID+"/roles",
+		types.RoleUpdateRequest{Roles: roles}, token)
+	var result struct {
+		Data struct {
+			Roles []string `json:"roles"`
+		} `json:"data"`
+		Error *types.APIError `json:"error"`
+	}
+	decodeResponse(t, resp, &result)
+	code := ""
+	if result.Error != nil {
+		code = result.Error.Code
+	}
+	return resp.StatusCode, code, result.Data.Roles
+}
+
+func TestRoles_AdminAssignsRoles(t *testing.T) {
+	jwtCfg := middleware.DefaultJWTConfig("test-secret")
+	srv := rolesTestServer(t, jwtCfg)
+	adminToken, _, _ := middleware.GenerateToken(jwtCfg, "admin-1", "admin@example.com",
+		[]middleware.Role{middleware.RoleAdmin}, true)
+
+	user := registerForTokens(t, srv, "roles-assign@example.com")
+	if !reflect.DeepEqual(user.User.Roles, []string{"user"}) {
+		t.Fatalf("new user roles = %v, want [user]", user.User.Roles)
+	}
+
+	status, _, roles := putRoles(t, srv, adminToken, user.User.ID, "curator", "curator")
+	if status != http.StatusOK || !reflect.DeepEqual(roles, []string{"user", "curator"}) {
+		t.Fatalf("assign curator: status %d, roles %v", status, roles)
+	}
+	if status, _ := statusAndCode(t, srv, http.MethodGet, "/api/admin/users/"+user.User.ID+"/roles", adminToken); status != http.StatusOK {
+		t.Errorf("get roles: expected 200, got %d", status)
+	}
+
+	// The new role reaches the user's token on refresh.
+	status, _, refreshed := refresh(t, srv, user.RefreshToken)
+	if status != http.StatusOK {
+		t.Fatalf("refresh: expected 200, got %d", status)
+	}
+	claims, err := middleware.ParseToken(jwtCfg, refreshed.Token)
+	if err != nil {
+		t.Fatalf("ParseToken: %v", err)
+	}
+	if !middleware.HasRole(claims.Roles, middleware.RoleCurator) || claims.IsAdmin {
+		t.Errorf("refreshed claims roles = %v, is_admin = %v", claims.Roles, claims.IsAdmin)
+	}
+}
+
+func TestRoles_PrivilegeEscalationRejected(t *testing.T) {
+	jwtCfg := middleware.DefaultJWTConfig("test-secret")
+	srv := rolesTestServer(t, jwtCfg)
+	user := registerForTokens(t, srv, "roles-escalate@example.com")
+	curatorToken, _, _ := middleware.GenerateToken(jwtCfg, user.User.ID, user.User.Email,
+		[]middleware.Role{middleware.RoleCurator}, true)
+
+	for name, token := range map[string]string{"user": user.Token, "curator": curatorToken} {
+		status, code, _ := putRoles(t, srv, token, user.User.ID, "admin")
+		if status != http.StatusForbidden || code != "FORBIDDEN" {
+			t.Errorf("%s granting itself admin: got %d %s, want 403 FORBIDDEN", name, status, code)
+		}
+		if status, _ := statusAndCode(t, srv, http.MethodGet, "/api/admin/users/"+user.User.ID+"/roles", token); status != http.StatusForbidden {
+			t.Errorf("%s reading roles: expected 403, got %d", name, status)
+		}
+	}
+	if status, _ := statusAndCode(t, srv, http.MethodGet, "/api/admin/users/"+user.User.ID+"/roles", ""); status != http.StatusUnauthorized {
+		t.Errorf("anonymous: expected 401, got %d", status)
+	}
+
+	// The refused requests changed nothing.
+	status, _, refreshed := refresh(t, srv, user.RefreshToken)
+	if status != http.StatusOK || !reflect.DeepEqual(refreshed.User.Roles, []string{"user"}) {
+		t.Errorf("after escalation attempts: status %d, roles %v", status, refreshed.User.Roles)
+	}
+}
+
+func TestRoles_InvalidRequests(t *testing.T) {
+	jwtCfg := middleware.DefaultJWTConfig("test-secret")
+	srv := rolesTestServer(t, jwtCfg)
+	adminToken, _, _ := middleware.GenerateToken(jwtCfg, "admin-2", "admin2@example.com",
+		[]middleware.Role{middleware.RoleAdmin}, true)
+	user := registerForTokens(t, srv, "roles-invalid@example.com")
+
+	tests := []struct {
+		name, userID string
+		roles        []string
+		status       int
+		code         string
+	}{
+		{"unknown role", user.User.ID, []string{"superuser"}, http.StatusBadRequest, "VALIDATION_ERROR"},
+		{"unknown user", "no-such-user", []string{"curator"}, http.StatusNotFound, "NOT_FOUND"},
+		{"own admin removed", "admin-2", []string{"curator"}, http.StatusConflict, "CANNOT_REMOVE_OWN_ADMIN"},
+	}
+	for _, tt := range tests {
+		status, code, _ := putRoles(t, srv, adminToken, tt.userID, tt.roles...)
+		if status != tt.status || code != tt.code {
+			t.Errorf("%s: got %d %s, want %d %s", tt.name, status, code, tt.status, tt.code)
+		}
+	}
+	if status, _ := statusAndCode(t, srv, http.MethodGet, "/api/admin/users/"+user.User.ID, adminToken); status != http.StatusNotFound {
+		t.Errorf("path without /roles: expected 404, got %d", status)
+	}
+}
@@ -0,0 +1,188 @@
+// Package handler – skill_suggestions.go serves skills that usually appear
+// alongside a skill, for users adding skills to their profile by hand.
+package handler
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/learnbot/api-gateway/internal/middleware"
+	"github.com/learnbot/api-gateway/internal/suggest"
+	"github.com/learnbot/api-gateway/internal/types"
+	"github.com/learnbot/shared/openapi"
+)
+
+// DefaultSuggestionLimit is the number of suggestions returned when
+// ?limit= is absent.
+const DefaultSuggestionLimit = 10
+
+// ResumeCorpus is a suggest.Corpus of the skills of each user's latest
+// uploaded resume.
+func ResumeCorpus() suggest.Corpus {
+	return suggest.CorpusFunc(func(context.Context) ([][]string, error) {
+		return globalResumeStore.skillSets(), nil
+	})
+}
+
+// SkillSuggestionsHandler serves skill co-occurrence suggestions. The
+// suggestions are only recomputed when an admin asks for it.
+type SkillSuggestionsHandler struct {
+	engine *suggest.Engine
+	logger *slog.Logger
+}
+
+// NewSkillSuggestionsHandler creates a SkillSuggestionsHandler serving the
+// suggestions of engine. A nil logger uses slog.Default().
+func NewSkillSuggestionsHandler(engine *suggest.Engine, logger *slog.Logger) *SkillSuggestionsHandler {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &SkillSuggestionsHandler{engine: engine, logger: logger}
+}
+
+// RegisterRoutes registers the skill suggestion routes on the mux. The
+// suggestions are public; optionalAuth identifies signed-in users so that
+// their own skills are left out.
+//
+//	GET  /api/v1/skills/{skill}/suggestions    – skills often listed with a skill
+//	GET  /api/admin/skill-suggestions           – status of the suggestion model (admin)
+//	POST /api/admin/skill-suggestions/recompute – recompute the suggestions (admin)
+func (h *SkillSuggestionsHandler) RegisterRoutes(mux openapi.Router, optionalAuth, authMiddleware func(http.Handler) http.Handler) {
+	admin := func(next http.HandlerFunc) http.Handler {
+		return authMiddleware(middleware.RequireRole(middleware.RoleAdmin)(next))
+	}
+	mux.Handle("/api/v1/skills/", optionalAuth(http.HandlerFunc(h.Suggestions)))
+	mux.Handle("/api/admin/skill-suggestions", admin(h.Status))
+	mux.Handle("/api/admin/skill-suggestions/recompute", admin(h.Recompute))
+}
+
+// DescribeRoutes documents the routes registered by RegisterRoutes.
+func (h *SkillSuggestionsHandler) DescribeRoutes(spec *openapi.Spec) {
+	auth := []string{openapi.BearerAuth}
+	adminErrors := []int{http.StatusUnauthorized, http.StatusForbidden}
+	spec.Route("/api/v1/skills/", openapi.Operation{
+		Method:  http.MethodGet,
+		Path:    "/api/v1/skills/{skill}/suggestions",
+		Summary: "Skills often listed alongside a skill",
+		Description: "Ranked by how much more often than chance the skills appear together in parsed " +
+			"resumes, or in scraped jobs while there are too few resumes. Aliases such as golang count " +
+			"as their taxonomy skill. With a Bearer token, the user's profile skills are left out.",
+		Params:   []openapi.Param{{Name: "limit", Description: "Maximum number of suggestions (default 10, max 20)", Type: 0}},
+		Response: success(types.SkillSuggestionsResponse{}),
+		Errors:   []int{http.StatusUnauthorized, http.StatusNotFound, http.StatusServiceUnavailable},
+	})
+	spec.Route("/api/admin/skill-suggestions", openapi.Operation{
+		Method:      http.MethodGet,
+		Summary:     "Status of the skill suggestion model",
+		Description: "Requires the admin role.",
+		Security:    auth,
+		Response:    success(suggest.Status{}),
+		Errors:      adminErrors,
+	})
+	spec.Route("/api/admin/skill-suggestions/recompute", openapi.Operation{
+		Method:  http.MethodPost,
+		Summary: "Recompute the skill suggestions",
+		Description: "Requires the admin role. The suggestions are recomputed in the background from " +
+			"the current corpus; the response reports the running recomputation.",
+		Security: auth,
+		Response: success(suggest.Status{}),
+		Status:   http.StatusAccepted,
+		Errors:   append([]int{http.StatusConflict}, adminErrors...),
+	})
+}
+
+// Suggestions handles GET /api/v1/skills/{skill}/suggestions?limit=10.
+//
+// The skill is matched by name or alias, as are the signed-in user's
+// profile skills, which are left out of the suggestions.
+func (h *SkillSuggestionsHandler) Suggestions(w http.ResponseWriter, r *http.Request) {
+	name, ok := strings.CutSuffix(strings.TrimPrefix(r.URL.Path, "/api/v1/skills/"), "/suggestions")
+	if !ok || strings.TrimSpace(name) == "" {
+		WriteError(w, http.StatusNotFound, "NOT_FOUND", "route not found")
+		return
+	}
+	if r.Method != http.MethodGet {
+		WriteMethodNotAllowed(w)
+		return
+	}
+	limit := DefaultSuggestionLimit
+	if l := r.URL.Query().Get("limit"); l != "" {
+		n, err := strconv.Atoi(l)
+		if err != nil || n < 1 || n > suggest.DefaultTopK {
+			WriteError(w, http.StatusBadRequest, "VALIDATION_ERROR",
+				"limit must be between 1 and "+strconv.Itoa(suggest.DefaultTopK))
+			return
+		}
+		limit = n
+	}
+
+	model := h.engine.Model()
+	if model == nil {
+		WriteError(w, http.StatusServiceUnavailable, "SUGGESTIONS_UNAVAILABLE",
+			"skill suggestions have not been computed yet")
+		return
+	}
+
+	var have []string
+	if userID := middleware.GetUserID(r); userID != "" {
+		for _, s := range globalProfileStore.get(userID).Skills {
+			have = append(have, s.Name)
+		}
+	}
+	skill, neighbors := h.engine.Suggest(name, have, limit)
+	if _, known := model.Name(skill.ID); !known {
+		WriteError(w, http.StatusNotFound, "SKILL_NOT_FOUND", "no suggestions for skill: "+name)
+		return
+	}
+
+	resp := types.SkillSuggestionsResponse{
+		Skill:       skill.ID,
+		Name:        skill.Name,
+		Suggestions: make([]types.SkillSuggestion, len(neighbors)),
+		Source:      model.Source,
+		ComputedAt:  model.BuiltAt,
+	}
+	for i, n := range neighbors {
+		resp.Suggestions[i] = types.SkillSuggestion{Skill: n.ID, Name: n.Name, Score: n.Score, Count: n.Count}
+	}
+	WriteSuccess(w, http.StatusOK, resp)
+}
+
+// Status handles GET /api/admin/skill-suggestions.
+func (h *SkillSuggestionsHandler) Status(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		WriteMethodNotAllowed(w)
+		return
+	}
+	WriteSuccess(w, http.StatusOK, h.engine.Status())
+}
+
+// Recompute handles POST /api/admin/skill-suggestions/recompute.
+//
+// The recomputation outlives the request; it is answered at once with 202
+// Accepted, or with 409 Conflict while another one is running.
+func (h *SkillSuggestionsHandler) Recompute(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		WriteMethodNotAllowed(w)
+		return
+	}
+	adminID := middleware.GetUserID(r)
+	status, err := h.engine.Start(context.WithoutCancel(r.Context()), func(err error) {
+		if err != nil {
+			h.logger.Error("skill suggestion recomputation failed", "error", err, "admin_id", adminID)
+			return
+		}
+		s := h.engine.Status()
+		h.logger.Info("skill suggestions recomputed",
+			"source", s.Source, "documents", s.Documents, "skills", s.Skills, "admin_id", adminID)
+	})
+	if errors.Is(err, suggest.ErrRunning) {
+		WriteError(w, http.StatusConflict, "RECOMPUTE_RUNNING", "a recomputation is already running")
+		return
+	}
+	WriteSuccess(w, http.StatusAccepted, status)
+}
@@ -0,0 +1,142 @@
+package handler_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/learnbot/api-gateway/internal/handler"
+	"github.com/learnbot/api-gateway/internal/middleware"
+	"github.com/learnbot/api-gateway/internal/suggest"
+	"github.com/learnbot/api-gateway/internal/types"
+)
+
+// suggestionsTestServer serves the profile and skill suggestion routes,
+// with suggestions computed from sets.
+func suggestionsTestServer(t *testing.T, sets ...[]string) (*httptest.Server, middleware.JWTConfig) {
+	t.Helper()
+	corpus := suggest.CorpusFunc(func(context.Context) ([][]string, error) { return sets, nil })
+	engine := suggest.NewEngine(corpus, nil, suggest.EngineConfig{MinDocuments: 1})
+
+	jwtCfg := middleware.DefaultJWTConfig("test-secret")
+	authMiddleware := middleware.RequireAuth(jwtCfg)
+	mux := http.NewServeMux()
+	handler.NewAuthHandler(jwtCfg).RegisterRoutes(mux)
+	handler.NewProfileHandler(jwtCfg).RegisterRoutes(mux, authMiddleware)
+	handler.NewSkillSuggestionsHandler(engine, nil).RegisterRoutes(mux, middleware.OptionalAuth(jwtCfg), authMiddleware)
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	return srv, jwtCfg
+}
+
+func recomputeSuggestions(t *testing.T, srv *httptest.Server, jwtCfg middleware.JWTConfig) {
+	t.Helper()
+	admin, _, err := middleware.GenerateToken(jwtCfg, "admin-1", "admin@example.com",
+		[]middleware.Role{middleware.RoleAdmin}, true)
+	if err != nil {
+		t.Fatalf("GenerateToken: %v", err)
+	}
+	resp := doRequest(t, srv, http.MethodPost, "/api/admin/skill-suggestions/recompute", nil, admin)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusAccepted {
+		t.Fatalf("recompute: expected 202, got %d", resp.StatusCode)
+	}
+	for deadline := time.Now().Add(5 * time.Second); time.Now().Before(deadline); time.Sleep(5 * time.Millisecond) {
+		var status struct {
+			Data suggest.Status `json:"data"`
+		}
+		decodeResponse(t, doRequest(t, srv, http.MethodGet, "/api/admin/skill-suggestions", nil, admin), &status)
+		if !status.Data.Running && status.Data.BuiltAt != nil {
+			return
+		}
+	}
+	t.Fatal("recomputation did not finish")
+}
+
+func suggestionsFor(t *testing.T, srv *httptest.Server, path, token string) types.SkillSuggestionsResponse {
+	t.Helper()
+	resp := doRequest(t, srv, http.MethodGet, path, nil, token)
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		t.Fatalf("GET %s: expected 200, got %d", path, resp.StatusCode)
+	}
+	var result struct {
+		Data types.SkillSuggestionsResponse `json:"data"`
+	}
+	decodeResponse(t, resp, &result)
+	return result.Data
+}
+
+func suggestedSkills(resp types.SkillSuggestionsResponse) []string {
+	ids := make([]string, len(resp.Suggestions))
+	for i, s := range resp.Suggestions {
+		ids[i] = s.Skill
+	}
+	return ids
+}
+
+func TestSkillSuggestions(t *testing.T) {
+	srv, jwtCfg := suggestionsTestServer(t,
+		[]string{"Go", "Docker", "Kubernetes"},
+		[]string{"golang", "docker", "k8s"},
+		[]string{"Go", "Docker", "PostgreSQL"},
+		[]string{"Go", "PostgreSQL"},
+		[]string{"Python", "Django"},
+		[]string{"Python", "Django"},
+	)
+
+	resp := doRequest(t, srv, http.MethodGet, "/api/v1/skills/go/suggestions", nil, "")
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("before recomputation: expected 503, got %d", resp.StatusCode)
+	}
+	recomputeSuggestions(t, srv, jwtCfg)
+
+	// Anonymous requests get every suggestion; aliases are collapsed.
+	got := suggestionsFor(t, srv, "/api/v1/skills/golang/suggestions", "")
+	if got.Skill != "go" || got.Name != "Go" || got.Source != "primary" || got.ComputedAt.IsZero() {
+		t.Errorf("unexpected response %+v", got)
+	}
+	if ids := suggestedSkills(got); len(ids) != 3 || ids[0] != "docker" {
+		t.Errorf("suggestions = %v, want docker first of 3", ids)
+	}
+	if ids := suggestedSkills(suggestionsFor(t, srv, "/api/v1/skills/Go/suggestions?limit=1", "")); len(ids) != 1 {
+		t.Errorf("limit=1 returned %v", ids)
+	}
+
+	// A signed-in user's profile skills are left out, by alias too.
+	token := registerAndLogin(t, srv, "suggest@example.com", "password123", "Suggest User")
+	doRequest(t, srv, http.MethodPut, "/api/profile/skills", types.SkillUpdateRequest{
+		Skills: []types.SkillInput{{Name: "Go"}, {Name: "k8s"}},
+	}, token).Body.Close()
+	if ids := suggestedSkills(suggestionsFor(t, srv, "/api/v1/skills/go/suggestions", token)); len(ids) != 2 ||
+		ids[0] == "kubernetes" || ids[1] == "kubernetes" {
+		t.Errorf("signed-in suggestions = %v, want docker and postgresql", ids)
+	}
+
+	for path, want := range map[string]int{
+		"/api/v1/skills/cobol/suggestions":       http.StatusNotFound,
+		"/api/v1/skills/go":                      http.StatusNotFound,
+		"/api/v1/skills/go/suggestions?limit=50": http.StatusBadRequest,
+		"/api/admin/skill-suggestions":           http.StatusUnauthorized,
+		"/api/admin/skill-suggestions/recompute": http.StatusUnauthorized,
+	} {
+		resp := doRequest(t, srv, http.MethodGet, path, nil, "")
+		resp.Body.Close()
+		if resp.StatusCode != want {
+			t.Errorf("GET %s: expected %d, got %d", path, want, resp.StatusCode)
+		}
+	}
+	resp = doRequest(t, srv, http.MethodGet, "/api/v1/skills/go/suggestions", nil, "not-a-token")
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusUnauthorized {
+		t.Errorf("invalid token: expected 401, got %d", resp.StatusCode)
+	}
+	resp = doRequest(t, srv, http.MethodPost, "/api/admin/skill-suggestions/recompute", nil, token)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusForbidden {
+		t.Errorf("recompute as a user: expected 403, got %d", resp.StatusCode)
+	}
+}
@@ -0,0 +1,184 @@
+// Package handler – users.go defines the store of user accounts shared by
+// the auth, profile, roles and digest handlers.
+package handler
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/learnbot/api-gateway/internal/middleware"
+)
+
+var (
+	// ErrUserNotFound is returned by a UserStore for an unknown user.
+	ErrUserNotFound = errors.New("user not found")
+
+	// ErrEmailTaken is returned by UserStore.CreateUser when an account
+	// already exists for the email.
+	ErrEmailTaken = errors.New("email already registered")
+)
+
+// User is a user account.
+type User struct {
+	ID           string
+	Email        string // lowercase
+	PasswordHash string
+	FullName     string
+	Roles        []middleware.Role
+	Verified     bool // email verified
+	CreatedAt    time.Time
+}
+
+// UserUpdate holds the account fields to change on a user. Nil fields are
+// left as they are.
+type UserUpdate struct {
+	FullName *string
+	Verified *bool
+	// Roles replaces the user's roles.
+	Roles []middleware.Role
+}
+
+// UserStore keeps user accounts. Emails are compared case-insensitively and
+// the users returned are copies that callers may keep.
+type UserStore interface {
+	// CreateUser adds an account holding RoleUser. It returns ErrEmailTaken
+	// if one exists for the email.
+	CreateUser(ctx context.Context, email, passwordHash, fullName string) (*User, error)
+
+	// GetByEmail returns the user with the email, or ErrUserNotFound.
+	GetByEmail(ctx context.Context, email string) (*User, error)
+
+	// GetByID returns the user with the ID, or ErrUserNotFound.
+	GetByID(ctx context.Context, id string) (*User, error)
+
+	// UpdateProfile applies update to the user with the ID and returns the
+	// updated user, or ErrUserNotFound.
+	UpdateProfile(ctx context.Context, id string, update UserUpdate) (*User, error)
+
+	// UpdatePassword replaces the password hash of the user with the ID and
+	// returns the user, or ErrUserNotFound.
+	UpdatePassword(ctx context.Context, id, passwordHash string) (*User, error)
+
+	// DeleteUser removes the account with the ID, freeing its email, or
+	// returns ErrUserNotFound.
+	DeleteUser(ctx context.Context, id string) error
+}
+
+// defaultUserStore is the store of handlers created without one, shared so
+// that they see the same accounts.
+var defaultUserStore = NewMemoryUserStore()
+
+// normalizeEmail returns the form in which emails are stored and compared.
+func normalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+// MemoryUserStore is a thread-safe in-memory UserStore for tests and
+// development.
+type MemoryUserStore struct {
+	mu      sync.RWMutex
+	byEmail map[string]*User // keyed by normalized email
+	byID    map[string]*User
+}
+
+// NewMemoryUserStore creates an empty MemoryUserStore.
+func NewMemoryUserStore() *MemoryUserStore {
+	return &MemoryUserStore{
+		byEmail: make(map[string]*User),
+		byID:    make(map[string]*User),
+	}
+}
+
+// CreateUser implements UserStore.
+func (s *MemoryUserStore) CreateUser(_ context.Context, email, passwordHash, fullName string) (*User, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	email = normalizeEmail(email)
+	if _, ok := s.byEmail[email]; ok {
+		return nil, ErrEmailTaken
+	}
+	u := &User{
+		ID:           generateID(),
+		Email:        email,
+		PasswordHash: passwordHash,
+		FullName:     fullName,
+		Roles:        []middleware.Role{middleware.RoleUser},
+		CreatedAt:    time.Now(),
+	}
+	s.byEmail[u.Email] = u
+	s.byID[u.ID] = u
+	return copyUser(u)
+}
+
+// GetByEmail implements UserStore.
+func (s *MemoryUserStore) GetByEmail(_ context.Context, email string) (*User, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return copyUser(s.byEmail[normalizeEmail(email)])
+}
+
+// GetByID implements UserStore.
+func (s *MemoryUserStore) GetByID(_ context.Context, id string) (*User, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return copyUser(s.byID[id])
+}
+
+// UpdateProfile implements UserStore. Roles are replaced, not modified, so
+// that copies returned earlier keep theirs.
+func (s *MemoryUserStore) UpdateProfile(_ context.Context, id string, update UserUpdate) (*User, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	u, ok := s.byID[id]
+	if !ok {
+		return nil, ErrUserNotFound
+	}
+	if update.FullName != nil {
+		u.FullName = *update.FullName
+	}
+	if update.Verified != nil {
+		u.Verified = *update.Verified
+	}
+	if update.Roles != nil {
+		u.Roles = append([]middleware.Role(nil), update.Roles...)
+	}
+	return copyUser(u)
+}
+
+// UpdatePassword implements UserStore.
+func (s *MemoryUserStore) UpdatePassword(_ context.Context, id, passwordHash string) (*User, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	u, ok := s.byID[id]
+	if !ok {
+		return nil, ErrUserNotFound
+	}
+	u.PasswordHash = passwordHash
+	return copyUser(u)
+}
+
+// DeleteUser implements UserStore.
+func (s *MemoryUserStore) DeleteUser(_ context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	u, ok := s.byID[id]
+	if !ok {
+		return ErrUserNotFound
+	}
+	delete(s.byID, id)
+	delete(s.byEmail, u.Email)
+	return nil
+}
+
+// copyUser returns a copy of u, or ErrUserNotFound if it is nil. The roles
+// slice is shared; it is never modified in place.
+func copyUser(u *User) (*User, error) {
+	if u == nil {
+		return nil, ErrUserNotFound
+	}
+	c := *u
+	return &c, nil
+}
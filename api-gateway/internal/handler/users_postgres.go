@@ -0,0 +1,131 @@
+// Package handler – users_postgres.go keeps user accounts in the users
+// table of the LearnBot database.
+package handler
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/google/uuid"
+
+	"github.com/learnbot/api-gateway/internal/middleware"
+	"github.com/learnbot/database/encryption"
+	"github.com/learnbot/database/repository"
+)
+
+// PostgresUserStore is a UserStore over the users table, created by the
+// database module's migrations. Deactivated users are not found.
+type PostgresUserStore struct {
+	repo *repository.UserRepository
+}
+
+// NewPostgresUserStore creates a PostgresUserStore using db. The phone,
+// city and state of profiles are encrypted at rest with keys.
+func NewPostgresUserStore(db *sql.DB, keys *encryption.Keyring) *PostgresUserStore {
+	return &PostgresUserStore{repo: repository.NewUserRepository(db).WithEncryption(keys)}
+}
+
+// Repository returns the store's user repository, for the profile columns
+// that the store does not manage. It encrypts them like the store.
+func (s *PostgresUserStore) Repository() *repository.UserRepository {
+	return s.repo
+}
+
+// CreateUser implements UserStore. The user's profile and preferences rows
+// are created with it.
+func (s *PostgresUserStore) CreateUser(ctx context.Context, email, passwordHash, fullName string) (*User, error) {
+	row, err := s.repo.CreateUser(ctx, repository.CreateUserInput{
+		Email:        email,
+		PasswordHash: &passwordHash,
+		FullName:     fullName,
+	})
+	if errors.Is(err, repository.ErrDuplicate) {
+		return nil, ErrEmailTaken
+	}
+	return userFromRow(row, err)
+}
+
+// GetByEmail implements UserStore.
+func (s *PostgresUserStore) GetByEmail(ctx context.Context, email string) (*User, error) {
+	return userFromRow(s.repo.GetUserByEmail(ctx, email))
+}
+
+// GetByID implements UserStore. IDs that are not UUIDs are not found.
+func (s *PostgresUserStore) GetByID(ctx context.Context, id string) (*User, error) {
+	uid, err := uuid.Parse(id)
+	if err != nil {
+		return nil, ErrUserNotFound
+	}
+	return userFromRow(s.repo.GetUserByID(ctx, uid))
+}
+
+// UpdateProfile implements UserStore.
+func (s *PostgresUserStore) UpdateProfile(ctx context.Context, id string, update UserUpdate) (*User, error) {
+	uid, err := uuid.Parse(id)
+	if err != nil {
+		return nil, ErrUserNotFound
+	}
+	input := repository.UpdateUserInput{FullName: update.FullName, EmailVerified: update.Verified}
+	if update.Roles != nil {
+		input.Roles = roleNames(update.Roles)
+	}
+	return userFromRow(s.repo.UpdateUser(ctx, uid, input))
+}
+
+// UpdatePassword implements UserStore.
+func (s *PostgresUserStore) UpdatePassword(ctx context.Context, id, passwordHash string) (*User, error) {
+	uid, err := uuid.Parse(id)
+	if err != nil {
+		return nil, ErrUserNotFound
+	}
+	if err := s.repo.UpdatePassword(ctx, uid, passwordHash); err != nil {
+		return userFromRow(nil, err)
+	}
+	return userFromRow(s.repo.GetUserByID(ctx, uid))
+}
+
+// DeleteUser implements UserStore. The rows of the database that reference
+// the user are deleted with the account; in a database shared with the
+// learning resources service that includes the user's progress and
+// reviews, before the account deletion erases them through the service.
+// The ratings of the resources they rated are recomputed the next time a
+// rating of each resource changes.
+func (s *PostgresUserStore) DeleteUser(ctx context.Context, id string) error {
+	uid, err := uuid.Parse(id)
+	if err != nil {
+		return ErrUserNotFound
+	}
+	if err := s.repo.DeleteUser(ctx, uid); errors.Is(err, repository.ErrNotFound) {
+		return ErrUserNotFound
+	} else if err != nil {
+		return err
+	}
+	return nil
+}
+
+// userFromRow converts a users row read with err to a User. Unknown roles
+// are dropped.
+func userFromRow(row *repository.User, err error) (*User, error) {
+	if errors.Is(err, repository.ErrNotFound) {
+		return nil, ErrUserNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	roles := make([]middleware.Role, 0, len(row.Roles))
+	for _, name := range row.Roles {
+		if role, err := middleware.ParseRole(name); err == nil {
+			roles = append(roles, role)
+		}
+	}
+	return &User{
+		ID:           row.ID.String(),
+		Email:        row.Email,
+		PasswordHash: row.PasswordHash.String,
+		FullName:     row.FullName,
+		Roles:        middleware.NormalizeRoles(roles),
+		Verified:     row.EmailVerified,
+		CreatedAt:    row.CreatedAt,
+	}, nil
+}
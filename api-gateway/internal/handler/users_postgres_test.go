@@ -0,0 +1,130 @@
+package handler_test
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/learnbot/api-gateway/internal/handler"
+	"github.com/learnbot/database/encryption"
+	"github.com/learnbot/database/migrations"
+	"github.com/learnbot/database/repository"
+	"github.com/learnbot/shared/migrate"
+)
+
+// openPostgresUserStore returns a PostgresUserStore over a new, migrated
+// schema of the DATABASE_URL database; see openPostgresSchema.
+func openPostgresUserStore(t *testing.T) *handler.PostgresUserStore {
+	t.Helper()
+	return handler.NewPostgresUserStore(openPostgresSchema(t), testKeyring(t))
+}
+
+// testKeyring returns a keyring with a single fixed master key.
+func testKeyring(t *testing.T) *encryption.Keyring {
+	t.Helper()
+	keys, err := encryption.NewKeyring("v1", map[string][]byte{"v1": bytes.Repeat([]byte{7}, encryption.MasterKeySize)})
+	if err != nil {
+		t.Fatalf("NewKeyring: %v", err)
+	}
+	return keys
+}
+
+// openPostgresSchema returns a connection pool to a new, migrated schema of
+// the DATABASE_URL database, dropped when the test ends. The test is
+// skipped without DATABASE_URL.
+func openPostgresSchema(t *testing.T) *sql.DB {
+	t.Helper()
+	dsn := os.Getenv("DATABASE_URL")
+	if dsn == "" {
+		t.Skip("DATABASE_URL not set")
+	}
+	admin, err := sql.Open("postgres", dsn)
+	if err != nil {
+		t.Fatalf("open database: %v", err)
+	}
+	t.Cleanup(func() { admin.Close() })
+
+	schema := fmt.Sprintf("gateway_users_test_%d", time.Now().UnixNano())
+	if _, err := admin.Exec("CREATE SCHEMA " + schema); err != nil {
+		t.Fatalf("create schema: %v", err)
+	}
+	t.Cleanup(func() { admin.Exec("DROP SCHEMA " + schema + " CASCADE") }) //nolint:errcheck
+
+	sep := " "
+	if strings.Contains(dsn, "://") {
+		sep = "&"
+		if !strings.Contains(dsn, "?") {
+			sep = "?"
+		}
+	}
+	db, err := sql.Open("postgres", dsn+sep+"search_path="+schema)
+	if err != nil {
+		t.Fatalf("open schema: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	m, err := migrate.New(db, migrations.FS, migrations.Table, log.New(io.Discard, "", 0))
+	if err != nil {
+		t.Fatalf("load migrations: %v", err)
+	}
+	if _, err := m.Up(context.Background()); err != nil {
+		t.Fatalf("apply migrations: %v", err)
+	}
+	return db
+}
+
+func TestPostgresUserStore(t *testing.T) {
+	testUserStore(t, openPostgresUserStore(t))
+}
+
+func TestPostgresUserStore_Routes(t *testing.T) {
+	srv, sent := userStoreTestServer(t, openPostgresUserStore(t))
+	testUserStoreRoutes(t, srv, sent)
+}
+
+func TestPostgresUserStore_EncryptsProfilePII(t *testing.T) {
+	db := openPostgresSchema(t)
+	store := handler.NewPostgresUserStore(db, testKeyring(t))
+	ctx := context.Background()
+
+	user, err := store.CreateUser(ctx, "pii@example.com", "hash", "PII User")
+	if err != nil {
+		t.Fatalf("CreateUser: %v", err)
+	}
+	uid := uuid.MustParse(user.ID)
+	phone, city, state := "+62 812 0000 0000", "Bandung", "West Java"
+	if err := store.Repository().UpdateProfile(ctx, uid, repository.UpdateProfileInput{
+		Phone: &phone, LocationCity: &city, LocationState: &state,
+	}, nil); err != nil {
+		t.Fatalf("UpdateProfile: %v", err)
+	}
+
+	var stored [3]string
+	if err := db.QueryRowContext(ctx,
+		`SELECT phone, location_city, location_state FROM user_profiles WHERE user_id = $1`, uid).
+		Scan(&stored[0], &stored[1], &stored[2]); err != nil {
+		t.Fatalf("read profile: %v", err)
+	}
+	for i, plain := range []string{phone, city, state} {
+		if !encryption.IsSealedString(stored[i]) || strings.Contains(stored[i], plain) {
+			t.Errorf("stored %q for %q, want ciphertext", stored[i], plain)
+		}
+	}
+
+	profile, err := store.Repository().GetProfile(ctx, uid)
+	if err != nil {
+		t.Fatalf("GetProfile: %v", err)
+	}
+	if profile.Phone.String != phone || profile.LocationCity.String != city || profile.LocationState.String != state {
+		t.Errorf("profile = %+v, want the decrypted values", profile)
+	}
+}
@@ -0,0 +1,187 @@
+package handler_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/learnbot/api-gateway/internal/handler"
+	"github.com/learnbot/api-gateway/internal/middleware"
+	"github.com/learnbot/api-gateway/internal/types"
+)
+
+// testUserStore checks the UserStore contract on an empty store.
+func testUserStore(t *testing.T, store handler.UserStore) {
+	t.Helper()
+	ctx := context.Background()
+
+	created, err := store.CreateUser(ctx, " Jane@Example.com", "hash-1", "Jane Doe")
+	if err != nil {
+		t.Fatalf("CreateUser: %v", err)
+	}
+	if created.ID == "" || created.Email != "jane@example.com" || created.PasswordHash != "hash-1" ||
+		created.FullName != "Jane Doe" || created.Verified || created.CreatedAt.IsZero() {
+		t.Errorf("created user = %+v", created)
+	}
+	if len(created.Roles) != 1 || created.Roles[0] != middleware.RoleUser {
+		t.Errorf("roles of a new user = %v, want [user]", created.Roles)
+	}
+	if _, err := store.CreateUser(ctx, "JANE@example.com", "hash-2", "Jane Again"); !errors.Is(err, handler.ErrEmailTaken) {
+		t.Errorf("CreateUser with a registered email: error = %v, want ErrEmailTaken", err)
+	}
+
+	byEmail, err := store.GetByEmail(ctx, "jane@EXAMPLE.com")
+	if err != nil || byEmail.ID != created.ID {
+		t.Errorf("GetByEmail = %+v, %v; want %s", byEmail, err, created.ID)
+	}
+	byID, err := store.GetByID(ctx, created.ID)
+	if err != nil || byID.Email != created.Email {
+		t.Errorf("GetByID = %+v, %v; want %s", byID, err, created.Email)
+	}
+	if _, err := store.GetByEmail(ctx, "nobody@example.com"); !errors.Is(err, handler.ErrUserNotFound) {
+		t.Errorf("GetByEmail of an unknown email: error = %v, want ErrUserNotFound", err)
+	}
+	if _, err := store.GetByID(ctx, "unknown"); !errors.Is(err, handler.ErrUserNotFound) {
+		t.Errorf("GetByID of an unknown ID: error = %v, want ErrUserNotFound", err)
+	}
+
+	verified := true
+	roles := []middleware.Role{middleware.RoleUser, middleware.RoleCurator}
+	updated, err := store.UpdateProfile(ctx, created.ID, handler.UserUpdate{Verified: &verified, Roles: roles})
+	if err != nil {
+		t.Fatalf("UpdateProfile: %v", err)
+	}
+	if !updated.Verified || len(updated.Roles) != 2 || updated.Roles[1] != middleware.RoleCurator || updated.FullName != "Jane Doe" {
+		t.Errorf("updated user = %+v", updated)
+	}
+	name := "Jane Q. Doe"
+	if updated, err = store.UpdateProfile(ctx, created.ID, handler.UserUpdate{FullName: &name}); err != nil ||
+		updated.FullName != name || !updated.Verified || len(updated.Roles) != 2 {
+		t.Errorf("UpdateProfile of the name = %+v, %v", updated, err)
+	}
+	if _, err := store.UpdateProfile(ctx, "unknown", handler.UserUpdate{Verified: &verified}); !errors.Is(err, handler.ErrUserNotFound) {
+		t.Errorf("UpdateProfile of an unknown ID: error = %v, want ErrUserNotFound", err)
+	}
+
+	if updated, err = store.UpdatePassword(ctx, created.ID, "hash-3"); err != nil || updated.PasswordHash != "hash-3" {
+		t.Errorf("UpdatePassword = %+v, %v", updated, err)
+	}
+	if byEmail, err = store.GetByEmail(ctx, "jane@example.com"); err != nil || byEmail.PasswordHash != "hash-3" {
+		t.Errorf("password hash after UpdatePassword = %+v, %v", byEmail, err)
+	}
+	if _, err := store.UpdatePassword(ctx, "unknown", "hash-4"); !errors.Is(err, handler.ErrUserNotFound) {
+		t.Errorf("UpdatePassword of an unknown ID: error = %v, want ErrUserNotFound", err)
+	}
+
+	if err := store.DeleteUser(ctx, created.ID); err != nil {
+		t.Fatalf("DeleteUser: %v", err)
+	}
+	if _, err := store.GetByID(ctx, created.ID); !errors.Is(err, handler.ErrUserNotFound) {
+		t.Errorf("GetByID of a deleted user: error = %v, want ErrUserNotFound", err)
+	}
+	if err := store.DeleteUser(ctx, created.ID); !errors.Is(err, handler.ErrUserNotFound) {
+		t.Errorf("DeleteUser again: error = %v, want ErrUserNotFound", err)
+	}
+	// The email can be registered again.
+	if _, err := store.CreateUser(ctx, "jane@example.com", "hash-5", "Jane Doe"); err != nil {
+		t.Errorf("CreateUser with a deleted user's email: %v", err)
+	}
+}
+
+// userStoreTestServer serves the auth and profile routes over store.
+// Verification emails go to the returned channel.
+func userStoreTestServer(t *testing.T, store handler.UserStore) (*httptest.Server, captureSender) {
+	t.Helper()
+	jwtCfg := middleware.DefaultJWTConfig("test-secret")
+	sent := make(captureSender, 4)
+	verifier := handler.NewEmailVerifier(jwtCfg, handler.EmailVerificationConfig{
+		Sender: sent,
+		URL:    "https://learnbot.example/api/auth/verify",
+	})
+	authH := handler.NewAuthHandler(jwtCfg)
+	authH.SetUserStore(store)
+	authH.SetEmailVerifier(verifier)
+	profileH := handler.NewProfileHandler(jwtCfg)
+	profileH.SetUserStore(store)
+	profileH.SetEmailVerifier(verifier)
+
+	mux := http.NewServeMux()
+	authH.RegisterRoutes(mux)
+	profileH.RegisterRoutes(mux, middleware.RequireAuth(jwtCfg))
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	return srv, sent
+}
+
+// testUserStoreRoutes registers, signs in and verifies an account through
+// the routes of srv.
+func testUserStoreRoutes(t *testing.T, srv *httptest.Server, sent captureSender) {
+	t.Helper()
+	auth := registerForTokens(t, srv, "store-routes@example.com")
+	verifyToken := verificationToken(t, sent, "store-routes@example.com")
+
+	resp := doRequest(t, srv, http.MethodPost, "/api/auth/register", types.RegisterRequest{
+		Email:    "Store-Routes@example.com",
+		Password: "password123",
+		FullName: "Someone Else",
+	}, "")
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusConflict {
+		t.Errorf("register with a taken email: expected 409, got %d", resp.StatusCode)
+	}
+
+	resp = doRequest(t, srv, http.MethodPost, "/api/auth/login", types.LoginRequest{
+		Email: "store-routes@example.com", Password: "password123",
+	}, "")
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("login: expected 200, got %d", resp.StatusCode)
+	}
+
+	if status, code := statusAndCode(t, srv, http.MethodGet, "/api/auth/verify?token="+verifyToken, ""); status != http.StatusOK {
+		t.Fatalf("verify: expected 200, got %d %s", status, code)
+	}
+	if !profileVerified(t, srv, auth.Token) {
+		t.Error("expected the profile to show a verified email")
+	}
+}
+
+func TestMemoryUserStore(t *testing.T) {
+	testUserStore(t, handler.NewMemoryUserStore())
+}
+
+func TestMemoryUserStore_Routes(t *testing.T) {
+	srv, sent := userStoreTestServer(t, handler.NewMemoryUserStore())
+	testUserStoreRoutes(t, srv, sent)
+}
+
+func TestMemoryUserStore_ConcurrentCreate(t *testing.T) {
+	store := handler.NewMemoryUserStore()
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	created, taken := 0, 0
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := store.CreateUser(context.Background(), "race@example.com", "hash", "Racer")
+			mu.Lock()
+			defer mu.Unlock()
+			switch {
+			case err == nil:
+				created++
+			case errors.Is(err, handler.ErrEmailTaken):
+				taken++
+			default:
+				t.Errorf("CreateUser: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+	if created != 1 || taken != 19 {
+		t.Errorf("created %d and rejected %d accounts, want 1 and 19", created, taken)
+	}
+}
@@ -0,0 +1,127 @@
+package handler_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/learnbot/api-gateway/internal/handler"
+	"github.com/learnbot/api-gateway/internal/types"
+	"github.com/learnbot/shared/validation"
+)
+
+// validationCase is a request body and the invalid fields it should be
+// rejected for, as "field:code"; none means the body is valid.
+type validationCase struct {
+	name   string
+	body   string
+	fields []string
+}
+
+// checkValidation posts each case to path and checks the 422 errors array.
+func checkValidation(t *testing.T, srv *httptest.Server, path, token string, tests []validationCase) {
+	t.Helper()
+	for _, tt := range tests {
+		resp := doRequest(t, srv, http.MethodPost, path, json.RawMessage(tt.body), token)
+		var result types.APIResponse
+		decodeResponse(t, resp, &result)
+
+		if len(tt.fields) == 0 {
+			if resp.StatusCode == http.StatusUnprocessableEntity || resp.StatusCode == http.StatusBadRequest {
+				t.Errorf("%s %s: expected a valid request, got %d %+v", path, tt.name, resp.StatusCode, result.Error)
+			}
+			continue
+		}
+		if resp.StatusCode != http.StatusUnprocessableEntity || result.Error == nil || result.Error.Code != "VALIDATION_ERROR" {
+			t.Errorf("%s %s: expected 422 VALIDATION_ERROR, got %d %+v", path, tt.name, resp.StatusCode, result.Error)
+			continue
+		}
+		var got []string
+		for _, fe := range result.Errors {
+			got = append(got, fe.Field+":"+fe.Code)
+		}
+		if len(got) != len(tt.fields) || len(result.Error.Details) != len(got) {
+			t.Errorf("%s %s: got errors %v, want %v", path, tt.name, got, tt.fields)
+			continue
+		}
+		for i := range got {
+			if got[i] != tt.fields[i] {
+				t.Errorf("%s %s: got errors %v, want %v", path, tt.name, got, tt.fields)
+				break
+			}
+		}
+	}
+}
+
+func TestValidation_RegisterRequest(t *testing.T) {
+	srv := testServer(t)
+	defer srv.Close()
+
+	checkValidation(t, srv, "/api/auth/register", "", []validationCase{
+		{"valid", `{"email":"validation@example.com","password":"password123","full_name":"Val Idation"}`, nil},
+		{"empty", `{}`, []string{"email:required", "password:required", "full_name:required"}},
+		{"bad email", `{"email":"nope","password":"password123","full_name":"A"}`, []string{"email:email"}},
+		{"short password", `{"email":"a@example.com","password":"short","full_name":"A"}`, []string{"password:min_length"}},
+		{"wrong type", `{"email":"a@example.com","password":12345678,"full_name":"A"}`, []string{"password:type"}},
+		{"unknown field", `{"email":"a@example.com","password":"password123","full_name":"A","admin":true}`,
+			[]string{"admin:unknown_field"}},
+	})
+}
+
+func TestValidation_ResetPasswordRequest(t *testing.T) {
+	srv := testServer(t)
+	defer srv.Close()
+
+	checkValidation(t, srv, "/api/auth/reset-password", "", []validationCase{
+		{"missing token", `{"password":"new-password456"}`, []string{"token:required"}},
+		{"short password", `{"token":"t","password":"short"}`, []string{"password:min_length"}},
+	})
+}
+
+func TestValidation_GapAnalysisRequest(t *testing.T) {
+	srv := testServer(t)
+	defer srv.Close()
+	token := registerAndLogin(t, srv, "validation-gaps@example.com", "password123", "Gap Validation")
+
+	checkValidation(t, srv, "/api/analysis/gaps", token, []validationCase{
+		{"valid", `{"job":{"title":"Go Developer","required_skills":["Go"],"min_years_experience":2}}`, nil},
+		{"negative experience", `{"job":{"required_skills":["Go"],"min_years_experience":-1}}`,
+			[]string{"job.min_years_experience:min"}},
+		{"misspelled field", `{"job":{"required_skill":["Go"]}}`, []string{"required_skill:unknown_field"}},
+	})
+}
+
+func TestValidation_LearningPreferences(t *testing.T) {
+	srv := testServer(t)
+	defer srv.Close()
+	token := registerAndLogin(t, srv, "validation-prefs@example.com", "password123", "Prefs Validation")
+
+	job := `"job_id":"job-001",`
+	checkValidation(t, srv, "/api/training/recommendations", token, []validationCase{
+		{"valid", `{` + job + `"preferences":{"weekly_hours_available":10,"preferred_resource_types":["course"]}}`, nil},
+		{"too many hours", `{` + job + `"preferences":{"weekly_hours_available":200}}`,
+			[]string{"preferences.weekly_hours_available:max"}},
+		{"negative budget", `{` + job + `"preferences":{"max_budget":-5}}`,
+			[]string{"preferences.max_budget:min"}},
+		{"bad currency", `{` + job + `"preferences":{"max_budget":50,"currency":"EURO"}}`,
+			[]string{"preferences.currency:max_length"}},
+		{"legacy budget", `{` + job + `"preferences":{"max_budget_usd":50}}`, nil},
+		{"negative legacy budget", `{` + job + `"preferences":{"max_budget_usd":-5}}`,
+			[]string{"preferences.max_budget_usd:min"}},
+		{"bad date and type", `{` + job + `"preferences":{"target_date":"soon","preferred_resource_types":["course","podcast"]}}`,
+			[]string{"preferences.target_date:date", "preferences.preferred_resource_types[1]:one_of"}},
+	})
+}
+
+func TestValidation_AllowUnknownFields(t *testing.T) {
+	handler.SetValidationConfig(validation.Config{AllowUnknownFields: true})
+	defer handler.SetValidationConfig(validation.Config{})
+	srv := testServer(t)
+	defer srv.Close()
+
+	checkValidation(t, srv, "/api/auth/register", "", []validationCase{
+		{"unknown field", `{"email":"unknown-allowed@example.com","password":"password123","full_name":"A","plan":"pro"}`, nil},
+		{"rules still apply", `{"email":"nope","password":"password123","full_name":"A","plan":"pro"}`, []string{"email:email"}},
+	})
+}
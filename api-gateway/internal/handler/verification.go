@@ -0,0 +1,187 @@
+// Package handler – verification.go implements email verification: the
+// links sent on registration, GET /api/auth/verify and resending links.
+package handler
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/learnbot/api-gateway/internal/mail"
+	"github.com/learnbot/api-gateway/internal/middleware"
+)
+
+// DefaultVerificationResendCooldown is how long a user waits between
+// verification emails.
+const DefaultVerificationResendCooldown = time.Minute
+
+// verificationEmailTimeout bounds the delivery of one verification email.
+const verificationEmailTimeout = 30 * time.Second
+
+// EmailVerificationConfig configures an EmailVerifier. Zero fields fall back
+// to a log-only sender, DefaultEmailVerificationDuration,
+// DefaultVerificationResendCooldown and slog.Default.
+type EmailVerificationConfig struct {
+	// Sender delivers the verification emails.
+	Sender mail.Sender
+
+	// TokenDuration is how long a verification link is valid.
+	TokenDuration time.Duration
+
+	// URL is the endpoint that verifies a token, normally the public URL of
+	// GET /api/auth/verify. The token is added as the "token" query
+	// parameter; if URL is empty the email holds the token alone.
+	URL string
+
+	// ResendCooldown is the minimum time between two verification emails
+	// to one user.
+	ResendCooldown time.Duration
+
+	// Logger receives delivery failures.
+	Logger *slog.Logger
+}
+
+// EmailVerifier sends signed, expiring email verification links and
+// verifies them. It is shared by the auth handler, which sends a link on
+// registration, and the profile handler, which resends it.
+type EmailVerifier struct {
+	jwtCfg middleware.JWTConfig
+	cfg    EmailVerificationConfig
+	now    func() time.Time
+
+	mu       sync.Mutex
+	lastSent map[string]time.Time // keyed by user ID
+}
+
+// NewEmailVerifier creates an EmailVerifier that signs links with jwtCfg's
+// secret.
+func NewEmailVerifier(jwtCfg middleware.JWTConfig, cfg EmailVerificationConfig) *EmailVerifier {
+	if cfg.Logger == nil {
+		cfg.Logger = slog.Default()
+	}
+	if cfg.Sender == nil {
+		cfg.Sender = mail.NewLogSender(cfg.Logger)
+	}
+	if cfg.TokenDuration <= 0 {
+		cfg.TokenDuration = middleware.DefaultEmailVerificationDuration
+	}
+	if cfg.ResendCooldown <= 0 {
+		cfg.ResendCooldown = DefaultVerificationResendCooldown
+	}
+	return &EmailVerifier{
+		jwtCfg:   jwtCfg,
+		cfg:      cfg,
+		now:      time.Now,
+		lastSent: make(map[string]time.Time),
+	}
+}
+
+// send emails a verification link to user unless one was sent within the
+// resend cooldown, in which case it returns the time left. The email is sent
+// in the background and failures are logged.
+func (v *EmailVerifier) send(ctx context.Context, user *User) time.Duration {
+	now := v.now()
+	v.mu.Lock()
+	if wait := v.cfg.ResendCooldown - now.Sub(v.lastSent[user.ID]); wait > 0 {
+		v.mu.Unlock()
+		return wait
+	}
+	v.lastSent[user.ID] = now
+	v.pruneLocked(now)
+	v.mu.Unlock()
+
+	token, err := middleware.GenerateEmailVerificationToken(v.jwtCfg, user.ID, user.Email, v.cfg.TokenDuration)
+	if err != nil {
+		v.cfg.Logger.ErrorContext(ctx, "verification token failed", "error", err)
+		return 0
+	}
+	go v.deliver(context.WithoutCancel(ctx), user.Email, token)
+	return 0
+}
+
+// deliver sends the verification email with token to to.
+func (v *EmailVerifier) deliver(ctx context.Context, to, token string) {
+	ctx, cancel := context.WithTimeout(ctx, verificationEmailTimeout)
+	defer cancel()
+
+	link := token
+	if v.cfg.URL != "" {
+		sep := "?"
+		if strings.Contains(v.cfg.URL, "?") {
+			sep = "&"
+		}
+		link = v.cfg.URL + sep + "token=" + url.QueryEscape(token)
+	}
+	msg := mail.Message{
+		To:      to,
+		Subject: "Verify your LearnBot email address",
+		Body: "Welcome to LearnBot! Please confirm that this is your email address " +
+			"within " + v.cfg.TokenDuration.String() + ":\n\n" +
+			link + "\n\n" +
+			"If you did not create an account, ignore this email.\n",
+	}
+	if err := v.cfg.Sender.Send(ctx, msg); err != nil {
+		v.cfg.Logger.ErrorContext(ctx, "verification email failed", "error", err)
+	}
+}
+
+// pruneLocked drops send times older than the cooldown.
+func (v *EmailVerifier) pruneLocked(now time.Time) {
+	for id, t := range v.lastSent {
+		if now.Sub(t) >= v.cfg.ResendCooldown {
+			delete(v.lastSent, id)
+		}
+	}
+}
+
+// Verify handles GET /api/auth/verify?token=... It marks the user's email
+// as verified in users; verifying twice succeeds. Access tokens issued before keep
+// the unverified flag until they are refreshed.
+//
+// Response:
+//
+//	{"success": true, "data": {"message": "email verified"}}
+func (v *EmailVerifier) Verify(w http.ResponseWriter, r *http.Request, users UserStore) {
+	if r.Method != http.MethodGet {
+		WriteMethodNotAllowed(w)
+		return
+	}
+
+	token := r.URL.Query().Get("token")
+	if token == "" {
+		WriteError(w, http.StatusBadRequest, "VALIDATION_ERROR", "token is required")
+		return
+	}
+	userID, email, err := middleware.ParseEmailVerificationToken(v.jwtCfg, token)
+	switch {
+	case errors.Is(err, middleware.ErrVerificationTokenExpired):
+		WriteError(w, http.StatusBadRequest, "VERIFICATION_TOKEN_EXPIRED", "email verification link has expired")
+		return
+	case err != nil:
+		WriteError(w, http.StatusBadRequest, "INVALID_VERIFICATION_TOKEN", "invalid email verification link")
+		return
+	}
+	user, err := users.GetByID(r.Context(), userID)
+	if err == nil && user.Email != email {
+		err = ErrUserNotFound // the link was sent to an earlier email
+	}
+	if err == nil {
+		verified := true
+		_, err = users.UpdateProfile(r.Context(), userID, UserUpdate{Verified: &verified})
+	}
+	switch {
+	case errors.Is(err, ErrUserNotFound):
+		WriteError(w, http.StatusBadRequest, "INVALID_VERIFICATION_TOKEN", "invalid email verification link")
+		return
+	case err != nil:
+		WriteInternalError(w)
+		return
+	}
+
+	WriteSuccess(w, http.StatusOK, map[string]string{"message": "email verified"})
+}
@@ -0,0 +1,179 @@
+package handler_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/learnbot/api-gateway/internal/handler"
+	"github.com/learnbot/api-gateway/internal/middleware"
+	"github.com/learnbot/api-gateway/internal/types"
+)
+
+// verificationTestServer serves the auth and profile routes, plus the jobs
+// routes behind a policy that requires a verified email. Verification emails
+// go to the returned channel.
+func verificationTestServer(t *testing.T, cooldown time.Duration) (*httptest.Server, captureSender) {
+	t.Helper()
+	jwtCfg := middleware.DefaultJWTConfig("test-secret")
+	sent := make(captureSender, 4)
+	verifier := handler.NewEmailVerifier(jwtCfg, handler.EmailVerificationConfig{
+		Sender:         sent,
+		URL:            "https://learnbot.example/api/auth/verify",
+		ResendCooldown: cooldown,
+	})
+	authH := handler.NewAuthHandler(jwtCfg)
+	authH.SetEmailVerifier(verifier)
+	profileH := handler.NewProfileHandler(jwtCfg)
+	profileH.SetEmailVerifier(verifier)
+
+	authMiddleware := middleware.RequireAuth(jwtCfg)
+	policy := middleware.ParseVerificationPolicy("jobs")
+	mux := http.NewServeMux()
+	authH.RegisterRoutes(mux)
+	profileH.RegisterRoutes(mux, policy.Wrap("profile", authMiddleware))
+	handler.NewJobsHandler().RegisterRoutes(mux, policy.Wrap("jobs", authMiddleware))
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	return srv, sent
+}
+
+// verificationToken waits for a verification email and returns its token.
+func verificationToken(t *testing.T, sent captureSender, to string) string {
+	t.Helper()
+	select {
+	case msg := <-sent:
+		if msg.To != to {
+			t.Fatalf("verification email sent to %q, want %q", msg.To, to)
+		}
+		_, after, ok := strings.Cut(msg.Body, "https://learnbot.example/api/auth/verify?token=")
+		if !ok {
+			t.Fatalf("verification email has no link: %q", msg.Body)
+		}
+		token, _, _ := strings.Cut(after, "\n")
+		return token
+	case <-time.After(5 * time.Second):
+		t.Fatal("no verification email sent")
+		return ""
+	}
+}
+
+// statusAndCode performs a request and returns the status and error code.
+func statusAndCode(t *testing.T, srv *httptest.Server, method, path, token string) (int, string) {
+	t.Helper()
+	resp := doRequest(t, srv, method, path, nil, token)
+	var result struct {
+		Error *types.APIError `json:"error"`
+	}
+	decodeResponse(t, resp, &result)
+	if result.Error != nil {
+		return resp.StatusCode, result.Error.Code
+	}
+	return resp.StatusCode, ""
+}
+
+// profileVerified returns the email_verified field of the user's profile.
+func profileVerified(t *testing.T, srv *httptest.Server, token string) bool {
+	t.Helper()
+	resp := doRequest(t, srv, http.MethodGet, "/api/users/profile", nil, token)
+	var result struct {
+		Data struct {
+			EmailVerified bool `json:"email_verified"`
+		} `json:"data"`
+	}
+	decodeResponse(t, resp, &result)
+	return result.Data.EmailVerified
+}
+
+func TestEmailVerification_Flow(t *testing.T) {
+	srv, sent := verificationTestServer(t, 0)
+	auth := registerForTokens(t, srv, "verify-flow@example.com")
+	link := verificationToken(t, sent, "verify-flow@example.com")
+
+	if auth.User.EmailVerified || profileVerified(t, srv, auth.Token) {
+		t.Fatal("new account reported as verified")
+	}
+	claims, err := middleware.ParseToken(middleware.DefaultJWTConfig("test-secret"), auth.Token)
+	if err != nil || !claims.EmailUnverified {
+		t.Fatalf("expected the email_unverified claim, got %+v, %v", claims, err)
+	}
+	// Unverified users can still sign in, but not use enforced groups.
+	if status, _, _ := login(t, srv, "verify-flow@example.com", "password123"); status != http.StatusOK {
+		t.Errorf("login while unverified: expected 200, got %d", status)
+	}
+	if status, code := statusAndCode(t, srv, http.MethodGet, "/api/jobs/recommendations", auth.Token); status != http.StatusForbidden || code != "EMAIL_NOT_VERIFIED" {
+		t.Errorf("enforced group while unverified: expected 403 EMAIL_NOT_VERIFIED, got %d %q", status, code)
+	}
+
+	if status, code := statusAndCode(t, srv, http.MethodGet, "/api/auth/verify?token="+link, ""); status != http.StatusOK {
+		t.Fatalf("verify: expected 200, got %d %q", status, code)
+	}
+	if status, _ := statusAndCode(t, srv, http.MethodGet, "/api/auth/verify?token="+link, ""); status != http.StatusOK {
+		t.Errorf("second verify: expected 200, got %d", status)
+	}
+	if !profileVerified(t, srv, auth.Token) {
+		t.Error("profile not verified after verification")
+	}
+
+	// A refreshed access token drops the flag and passes enforcement.
+	status, _, refreshed := refresh(t, srv, auth.RefreshToken)
+	if status != http.StatusOK || !refreshed.User.EmailVerified {
+		t.Fatalf("refresh: expected 200 with a verified user, got %d %+v", status, refreshed.User)
+	}
+	if status, code := statusAndCode(t, srv, http.MethodGet, "/api/jobs/recommendations", refreshed.Token); status != http.StatusOK {
+		t.Errorf("enforced group after verification: expected 200, got %d %q", status, code)
+	}
+}
+
+func TestEmailVerification_InvalidLinks(t *testing.T) {
+	srv, sent := verificationTestServer(t, 0)
+	auth := registerForTokens(t, srv, "verify-invalid@example.com")
+	verificationToken(t, sent, "verify-invalid@example.com")
+
+	tests := []struct {
+		name, query, code string
+	}{
+		{"missing", "", "VALIDATION_ERROR"},
+		{"garbage", "?token=not-a-token", "INVALID_VERIFICATION_TOKEN"},
+		// An access token is signed with a different key.
+		{"access token", "?token=" + auth.Token, "INVALID_VERIFICATION_TOKEN"},
+	}
+	for _, tt := range tests {
+		if status, code := statusAndCode(t, srv, http.MethodGet, "/api/auth/verify"+tt.query, ""); status != http.StatusBadRequest || code != tt.code {
+			t.Errorf("%s: expected 400 %s, got %d %q", tt.name, tt.code, status, code)
+		}
+	}
+	if profileVerified(t, srv, auth.Token) {
+		t.Error("an invalid link verified the account")
+	}
+}
+
+func TestEmailVerification_ResendThrottled(t *testing.T) {
+	srv, sent := verificationTestServer(t, 50*time.Millisecond)
+	auth := registerForTokens(t, srv, "verify-resend@example.com")
+	verificationToken(t, sent, "verify-resend@example.com")
+
+	// The email sent on registration counts toward the cooldown.
+	resp := doRequest(t, srv, http.MethodPost, "/api/users/verification/resend", nil, auth.Token)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusTooManyRequests || resp.Header.Get("Retry-After") != "1" {
+		t.Fatalf("resend during cooldown: expected 429 with Retry-After 1, got %d %q", resp.StatusCode, resp.Header.Get("Retry-After"))
+	}
+
+	time.Sleep(60 * time.Millisecond)
+	if status, code := statusAndCode(t, srv, http.MethodPost, "/api/users/verification/resend", auth.Token); status != http.StatusAccepted {
+		t.Fatalf("resend after cooldown: expected 202, got %d %q", status, code)
+	}
+	link := verificationToken(t, sent, "verify-resend@example.com")
+	statusAndCode(t, srv, http.MethodGet, "/api/auth/verify?token="+link, "")
+
+	time.Sleep(60 * time.Millisecond)
+	if status, code := statusAndCode(t, srv, http.MethodPost, "/api/users/verification/resend", auth.Token); status != http.StatusConflict || code != "EMAIL_ALREADY_VERIFIED" {
+		t.Errorf("resend when verified: expected 409 EMAIL_ALREADY_VERIFIED, got %d %q", status, code)
+	}
+	if status, _ := statusAndCode(t, srv, http.MethodPost, "/api/users/verification/resend", ""); status != http.StatusUnauthorized {
+		t.Errorf("resend without a token: expected 401, got %d", status)
+	}
+}
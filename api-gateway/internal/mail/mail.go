@@ -0,0 +1,188 @@
+// Package mail sends the gateway's transactional email, such as password
+// reset links, through a pluggable Sender.
+package mail
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"log/slog"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net"
+	"net/smtp"
+	"net/textproto"
+	"strings"
+	"time"
+)
+
+// Message is an email with a plain-text body and an optional HTML
+// alternative.
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+
+	// HTML, if set, is sent alongside Body as a multipart/alternative
+	// message, for clients that render HTML.
+	HTML string
+}
+
+// Sender delivers messages.
+type Sender interface {
+	Send(ctx context.Context, msg Message) error
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// SMTP
+// ─────────────────────────────────────────────────────────────────────────────
+
+// SMTPConfig configures an SMTPSender.
+type SMTPConfig struct {
+	// Addr is the server's host:port.
+	Addr string
+
+	// From is the sender address.
+	From string
+
+	// Username and Password authenticate with PLAIN auth when Username is
+	// set. net/smtp only sends them over TLS or to localhost.
+	Username string
+	Password string
+}
+
+// SMTPSender sends messages through an SMTP server, upgrading to TLS when
+// the server supports STARTTLS.
+type SMTPSender struct {
+	cfg SMTPConfig
+}
+
+// NewSMTPSender creates an SMTPSender.
+func NewSMTPSender(cfg SMTPConfig) *SMTPSender {
+	return &SMTPSender{cfg: cfg}
+}
+
+// Send implements Sender. The context's deadline bounds the whole exchange
+// with the server.
+func (s *SMTPSender) Send(ctx context.Context, msg Message) error {
+	host, _, err := net.SplitHostPort(s.cfg.Addr)
+	if err != nil {
+		return fmt.Errorf("smtp address %q: %w", s.cfg.Addr, err)
+	}
+	var d net.Dialer
+	conn, err := d.DialContext(ctx, "tcp", s.cfg.Addr)
+	if err != nil {
+		return fmt.Errorf("dial smtp server: %w", err)
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+	c, err := smtp.NewClient(conn, host)
+	if err != nil {
+		conn.Close()
+		return fmt.Errorf("smtp handshake: %w", err)
+	}
+	defer c.Close()
+
+	if ok, _ := c.Extension("STARTTLS"); ok {
+		if err := c.StartTLS(&tls.Config{ServerName: host}); err != nil {
+			return fmt.Errorf("smtp starttls: %w", err)
+		}
+	}
+	if s.cfg.Username != "" {
+		if err := c.Auth(smtp.PlainAuth("", s.cfg.Username, s.cfg.Password, host)); err != nil {
+			return fmt.Errorf("smtp auth: %w", err)
+		}
+	}
+	if err := c.Mail(s.cfg.From); err != nil {
+		return fmt.Errorf("smtp MAIL FROM: %w", err)
+	}
+	if err := c.Rcpt(msg.To); err != nil {
+		return fmt.Errorf("smtp RCPT TO: %w", err)
+	}
+	wc, err := c.Data()
+	if err != nil {
+		return fmt.Errorf("smtp DATA: %w", err)
+	}
+	if _, err := wc.Write(msg.bytes(s.cfg.From, time.Now())); err != nil {
+		wc.Close()
+		return fmt.Errorf("write message: %w", err)
+	}
+	if err := wc.Close(); err != nil {
+		return fmt.Errorf("smtp DATA: %w", err)
+	}
+	return c.Quit()
+}
+
+// bytes formats msg as an RFC 5322 message from from.
+func (m Message) bytes(from string, now time.Time) []byte {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "From: %s\r\n", headerValue(from))
+	fmt.Fprintf(&b, "To: %s\r\n", headerValue(m.To))
+	fmt.Fprintf(&b, "Subject: %s\r\n", headerValue(m.Subject))
+	fmt.Fprintf(&b, "Date: %s\r\n", now.Format(time.RFC1123Z))
+	b.WriteString("MIME-Version: 1.0\r\n")
+	if m.HTML == "" {
+		b.WriteString("Content-Type: text/plain; charset=UTF-8\r\n\r\n")
+		writeBody(&b, m.Body)
+		return b.Bytes()
+	}
+
+	mw := multipart.NewWriter(&b)
+	fmt.Fprintf(&b, "Content-Type: multipart/alternative; boundary=%s\r\n\r\n", mw.Boundary())
+	for _, part := range []struct{ contentType, body string }{
+		{"text/plain; charset=UTF-8", m.Body},
+		{"text/html; charset=UTF-8", m.HTML},
+	} {
+		w, _ := mw.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {part.contentType},
+			"Content-Transfer-Encoding": {"quoted-printable"},
+		})
+		qp := quotedprintable.NewWriter(w)
+		qp.Write([]byte(part.body))
+		qp.Close()
+	}
+	mw.Close()
+	return b.Bytes()
+}
+
+// writeBody writes body with CRLF line endings and a final line break.
+func writeBody(b *bytes.Buffer, body string) {
+	body = strings.ReplaceAll(strings.ReplaceAll(body, "\r\n", "\n"), "\n", "\r\n")
+	b.WriteString(body)
+	if !strings.HasSuffix(body, "\r\n") {
+		b.WriteString("\r\n")
+	}
+}
+
+// headerValue strips line breaks so that a value cannot add headers.
+func headerValue(s string) string {
+	return strings.NewReplacer("\r", "", "\n", "").Replace(s)
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Log-only sender for development
+// ─────────────────────────────────────────────────────────────────────────────
+
+// LogSender logs messages instead of sending them, for local development.
+// Message bodies hold secrets such as reset links, so it must not be used
+// in production.
+type LogSender struct {
+	logger *slog.Logger
+}
+
+// NewLogSender creates a LogSender. A nil logger uses slog.Default.
+func NewLogSender(logger *slog.Logger) *LogSender {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &LogSender{logger: logger}
+}
+
+// Send implements Sender.
+func (s *LogSender) Send(ctx context.Context, msg Message) error {
+	s.logger.InfoContext(ctx, "email not sent (log-only sender)",
+		"to", msg.To, "subject", msg.Subject, "body", msg.Body)
+	return nil
+}
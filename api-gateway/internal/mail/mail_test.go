@@ -0,0 +1,91 @@
+package mail
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"log/slog"
+	"mime"
+	"mime/multipart"
+	netmail "net/mail"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMessageBytes(t *testing.T) {
+	msg := Message{
+		To:      "jane@example.com",
+		Subject: "Reset your password",
+		Body:    "Line one\nLine two",
+	}
+	now := time.Date(2025, 1, 15, 10, 0, 0, 0, time.UTC)
+	got := string(msg.bytes("LearnBot <no-reply@learnbot.local>", now))
+	want := "From: LearnBot <no-reply@learnbot.local>\r\n" +
+		"To: jane@example.com\r\n" +
+		"Subject: Reset your password\r\n" +
+		"Date: Wed, 15 Jan 2025 10:00:00 +0000\r\n" +
+		"MIME-Version: 1.0\r\n" +
+		"Content-Type: text/plain; charset=UTF-8\r\n\r\n" +
+		"Line one\r\nLine two\r\n"
+	if got != want {
+		t.Errorf("bytes =\n%q\nwant\n%q", got, want)
+	}
+}
+
+func TestMessageBytes_HTML(t *testing.T) {
+	msg := Message{
+		To:      "jane@example.com",
+		Subject: "Your weekly digest",
+		Body:    "Hello Jane\nSee you = soon",
+		HTML:    "<p>Hello Jane</p>",
+	}
+	raw := msg.bytes("no-reply@learnbot.local", time.Now())
+	parsed, err := netmail.ReadMessage(bytes.NewReader(raw))
+	if err != nil {
+		t.Fatalf("ReadMessage: %v", err)
+	}
+	mediaType, params, err := mime.ParseMediaType(parsed.Header.Get("Content-Type"))
+	if err != nil || mediaType != "multipart/alternative" {
+		t.Fatalf("Content-Type = %q, %v", parsed.Header.Get("Content-Type"), err)
+	}
+
+	mr := multipart.NewReader(parsed.Body, params["boundary"])
+	for _, want := range []struct{ contentType, body string }{
+		{"text/plain; charset=UTF-8", "Hello Jane\r\nSee you = soon"},
+		{"text/html; charset=UTF-8", "<p>Hello Jane</p>"},
+	} {
+		part, err := mr.NextPart()
+		if err != nil {
+			t.Fatalf("NextPart: %v", err)
+		}
+		body, _ := io.ReadAll(part)
+		if ct := part.Header.Get("Content-Type"); ct != want.contentType || string(body) != want.body {
+			t.Errorf("part %q = %q, want %q %q", ct, body, want.contentType, want.body)
+		}
+	}
+	if _, err := mr.NextPart(); err != io.EOF {
+		t.Errorf("expected two parts, got %v", err)
+	}
+}
+
+func TestMessageBytes_HeaderInjection(t *testing.T) {
+	msg := Message{To: "jane@example.com\r\nBcc: eve@example.com", Subject: "Hi\nBcc: eve@example.com"}
+	got := string(msg.bytes("no-reply@learnbot.local", time.Now()))
+	if strings.Contains(got, "\r\nBcc:") || strings.Contains(got, "\nBcc:") {
+		t.Errorf("a header value added a header:\n%s", got)
+	}
+}
+
+func TestLogSender(t *testing.T) {
+	var buf bytes.Buffer
+	s := NewLogSender(slog.New(slog.NewTextHandler(&buf, nil)))
+	if err := s.Send(context.Background(), Message{To: "jane@example.com", Subject: "Hello", Body: "token abc"}); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"jane@example.com", "Hello", "token abc"} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("log %q lacks %q", buf.String(), want)
+		}
+	}
+}
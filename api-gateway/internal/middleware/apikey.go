@@ -0,0 +1,354 @@
+// Package middleware – apikey.go implements API keys for machine-to-machine
+// clients: scoped, hashed at rest, rate limited per key and revocable.
+package middleware
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/learnbot/shared/redact"
+)
+
+const (
+	// APIKeyHeader carries an API key on requests.
+	APIKeyHeader = "X-API-Key"
+
+	// APIKeyPrefix starts every API key, so that leaked keys are easy to
+	// recognise and redact.
+	APIKeyPrefix = "lbk_"
+
+	// apiKeyHintLength is how much of a key is kept for display.
+	apiKeyHintLength = len(APIKeyPrefix) + 6
+)
+
+// Scope is a permission granted to an API key.
+type Scope string
+
+const (
+	// ScopeReadJobs allows searching and reading jobs.
+	ScopeReadJobs Scope = "read:jobs"
+
+	// ScopeReadResources allows reading learning resources and paths.
+	ScopeReadResources Scope = "read:resources"
+
+	// ScopeWriteProgress allows recording users' learning progress.
+	ScopeWriteProgress Scope = "write:progress"
+)
+
+// allScopes lists the known scopes.
+var allScopes = []Scope{ScopeReadJobs, ScopeReadResources, ScopeWriteProgress}
+
+// ParseScope returns the known scope named s.
+func ParseScope(s string) (Scope, error) {
+	for _, sc := range allScopes {
+		if s == string(sc) {
+			return sc, nil
+		}
+	}
+	return "", fmt.Errorf("unknown scope %q", s)
+}
+
+var (
+	// ErrAPIKeyNotFound is returned for an unknown API key.
+	ErrAPIKeyNotFound = errors.New("api key not found")
+
+	// ErrAPIKeyRevoked is returned when revoking an already revoked key.
+	ErrAPIKeyRevoked = errors.New("api key already revoked")
+)
+
+// APIKey is an API key as stored: only its hash is kept.
+type APIKey struct {
+	// ID identifies the key in the admin API.
+	ID string
+
+	// Name describes the client the key was issued to.
+	Name string
+
+	// Hash is the SHA-256 hex digest of the key.
+	Hash string
+
+	// Hint is the start of the key, enough to tell keys apart.
+	Hint string
+
+	// Scopes are the permissions granted to the key.
+	Scopes []Scope
+
+	// RateLimit overrides the default per-key limit when set.
+	RateLimit RateLimit
+
+	// CreatedBy is the ID of the admin who created the key.
+	CreatedBy string
+
+	// CreatedAt is when the key was created.
+	CreatedAt time.Time
+
+	// RevokedAt is when the key was revoked; zero while it is active.
+	RevokedAt time.Time
+}
+
+// Revoked reports whether the key has been revoked.
+func (k APIKey) Revoked() bool {
+	return !k.RevokedAt.IsZero()
+}
+
+// APIKeyStore persists API key hashes.
+type APIKeyStore interface {
+	// Save stores a newly created key.
+	Save(key APIKey) error
+
+	// FindByHash returns the key with the given hash, revoked or not.
+	FindByHash(hash string) (APIKey, error)
+
+	// List returns every key, newest first.
+	List() ([]APIKey, error)
+
+	// Revoke marks the key with the given ID as revoked at the given time
+	// and returns it.
+	Revoke(id string, at time.Time) (APIKey, error)
+}
+
+// NewAPIKey generates a random API key and returns it with the ID, hint and
+// hash it is stored under. The key itself is shown to the admin once and
+// never stored.
+func NewAPIKey() (key string, stored APIKey, err error) {
+	raw := make([]byte, 32)
+	if _, err := rand.Read(raw); err != nil {
+		return "", APIKey{}, err
+	}
+	id := make([]byte, 8)
+	if _, err := rand.Read(id); err != nil {
+		return "", APIKey{}, err
+	}
+	key = APIKeyPrefix + base64.RawURLEncoding.EncodeToString(raw)
+	return key, APIKey{
+		ID:   hex.EncodeToString(id),
+		Hash: HashAPIKey(key),
+		Hint: key[:apiKeyHintLength],
+	}, nil
+}
+
+// HashAPIKey returns the SHA-256 hex digest under which an API key is
+// stored.
+func HashAPIKey(key string) string {
+	return HashRefreshToken(key)
+}
+
+// apiKeyPattern masks API keys in log lines.
+var apiKeyPattern = redact.Pattern{
+	Name:   "api_key",
+	Regexp: regexp.MustCompile(regexp.QuoteMeta(APIKeyPrefix) + `[A-Za-z0-9_\-]+`),
+	Hint:   "_",
+}
+
+// logRedactor masks personal data and API keys in the gateway's logs.
+func logRedactor() *redact.Redactor {
+	return redact.Default().With(apiKeyPattern)
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Principals
+// ─────────────────────────────────────────────────────────────────────────────
+
+// PrincipalKind tells users from API keys.
+type PrincipalKind string
+
+const (
+	// PrincipalUser is a user authenticated by a JWT.
+	PrincipalUser PrincipalKind = "user"
+
+	// PrincipalAPIKey is a client authenticated by an API key.
+	PrincipalAPIKey PrincipalKind = "api_key"
+)
+
+// Principal is the authenticated caller of a request.
+type Principal struct {
+	// Kind is how the caller authenticated.
+	Kind PrincipalKind
+
+	// ID is the user ID or the API key ID.
+	ID string
+
+	// Scopes are the API key's scopes. Users are not limited by scopes.
+	Scopes []Scope
+}
+
+// HasScope reports whether the principal may act within scope. Users hold
+// every scope; API keys hold the scopes they were created with.
+func (p *Principal) HasScope(scope Scope) bool {
+	if p == nil {
+		return false
+	}
+	if p.Kind == PrincipalUser {
+		return true
+	}
+	for _, s := range p.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// GetPrincipal returns the authenticated caller from the request context, or
+// nil if the request is not authenticated.
+func GetPrincipal(r *http.Request) *Principal {
+	p, _ := r.Context().Value(ContextKeyPrincipal).(*Principal)
+	return p
+}
+
+// HasScope reports whether the request's caller may act within scope.
+func HasScope(r *http.Request, scope Scope) bool {
+	return GetPrincipal(r).HasScope(scope)
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Authentication
+// ─────────────────────────────────────────────────────────────────────────────
+
+// DefaultAPIKeyRateLimit is the per-key limit for keys without their own:
+// 5 requests/second with a burst of 20.
+var DefaultAPIKeyRateLimit = RateLimit{RequestsPerSecond: 5, Burst: 20}
+
+// APIKeyAuth authenticates requests by API key and rate limits each key.
+type APIKeyAuth struct {
+	store   APIKeyStore
+	limit   RateLimit
+	limiter *RateLimiter
+}
+
+// NewAPIKeyAuth creates an APIKeyAuth for the keys in store. Keys without
+// their own rate limit get limit; a zero limit uses DefaultAPIKeyRateLimit.
+func NewAPIKeyAuth(store APIKeyStore, limit RateLimit) *APIKeyAuth {
+	if limit.RequestsPerSecond <= 0 || limit.Burst <= 0 {
+		limit = DefaultAPIKeyRateLimit
+	}
+	return &APIKeyAuth{store: store, limit: limit, limiter: NewRateLimiter(limit.RequestsPerSecond, limit.Burst)}
+}
+
+// Stop ends the background eviction of idle rate limit buckets.
+func (a *APIKeyAuth) Stop() {
+	a.limiter.Stop()
+}
+
+// RequireAuthOrAPIKey returns a middleware that accepts either a Bearer JWT,
+// checked as by RequireAuth, or an API key in the X-API-Key header. Unknown
+// and revoked keys get 401 Unauthorized and keys over their rate limit 429
+// Too Many Requests. API key requests carry no user ID; handlers check their
+// scopes with HasScope.
+func (a *APIKeyAuth) RequireAuthOrAPIKey(cfg JWTConfig) func(http.Handler) http.Handler {
+	requireAuth := RequireAuth(cfg)
+	return func(next http.Handler) http.Handler {
+		jwtNext := requireAuth(next)
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			raw := strings.TrimSpace(r.Header.Get(APIKeyHeader))
+			if raw == "" {
+				jwtNext.ServeHTTP(w, r)
+				return
+			}
+
+			key, err := a.store.FindByHash(HashAPIKey(raw))
+			if err != nil {
+				writeAuthError(w, "invalid API key")
+				return
+			}
+			if key.Revoked() {
+				writeAuthError(w, "API key has been revoked")
+				return
+			}
+
+			limit := key.RateLimit
+			if limit.RequestsPerSecond <= 0 || limit.Burst <= 0 {
+				limit = a.limit
+			}
+			allowed, status := a.limiter.take("key:"+key.ID, limit)
+			if !writeRateLimitHeaders(w, limit, allowed, status) {
+				return
+			}
+
+			ctx := context.WithValue(r.Context(), ContextKeyPrincipal, &Principal{
+				Kind:   PrincipalAPIKey,
+				ID:     key.ID,
+				Scopes: key.Scopes,
+			})
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// In-memory store (without a database; handler.PostgresAPIKeyStore otherwise)
+// ─────────────────────────────────────────────────────────────────────────────
+
+// MemoryAPIKeyStore is a thread-safe in-memory APIKeyStore. Its keys are
+// lost on restart.
+type MemoryAPIKeyStore struct {
+	mu     sync.RWMutex
+	keys   map[string]APIKey // keyed by ID
+	byHash map[string]string // hash → ID
+}
+
+// NewMemoryAPIKeyStore creates an empty MemoryAPIKeyStore.
+func NewMemoryAPIKeyStore() *MemoryAPIKeyStore {
+	return &MemoryAPIKeyStore{
+		keys:   make(map[string]APIKey),
+		byHash: make(map[string]string),
+	}
+}
+
+// Save implements APIKeyStore.
+func (s *MemoryAPIKeyStore) Save(key APIKey) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.keys[key.ID] = key
+	s.byHash[key.Hash] = key.ID
+	return nil
+}
+
+// FindByHash implements APIKeyStore.
+func (s *MemoryAPIKeyStore) FindByHash(hash string) (APIKey, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	id, ok := s.byHash[hash]
+	if !ok {
+		return APIKey{}, ErrAPIKeyNotFound
+	}
+	return s.keys[id], nil
+}
+
+// List implements APIKeyStore.
+func (s *MemoryAPIKeyStore) List() ([]APIKey, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	keys := make([]APIKey, 0, len(s.keys))
+	for _, k := range s.keys {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i].CreatedAt.After(keys[j].CreatedAt) })
+	return keys, nil
+}
+
+// Revoke implements APIKeyStore.
+func (s *MemoryAPIKeyStore) Revoke(id string, at time.Time) (APIKey, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	key, ok := s.keys[id]
+	if !ok {
+		return APIKey{}, ErrAPIKeyNotFound
+	}
+	if key.Revoked() {
+		return key, ErrAPIKeyRevoked
+	}
+	key.RevokedAt = at
+	s.keys[id] = key
+	return key, nil
+}
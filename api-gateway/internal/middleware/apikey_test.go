@@ -0,0 +1,171 @@
+package middleware
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/learnbot/shared/logging"
+)
+
+// saveTestKey stores a new key with scopes and limit and returns the raw key
+// and its ID.
+func saveTestKey(t *testing.T, store APIKeyStore, limit RateLimit, scopes ...Scope) (string, string) {
+	t.Helper()
+	key, stored, err := NewAPIKey()
+	if err != nil {
+		t.Fatalf("NewAPIKey: %v", err)
+	}
+	stored.Scopes = scopes
+	stored.RateLimit = limit
+	stored.CreatedAt = time.Now()
+	if err := store.Save(stored); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	return key, stored.ID
+}
+
+func TestNewAPIKey(t *testing.T) {
+	key, stored, err := NewAPIKey()
+	if err != nil {
+		t.Fatalf("NewAPIKey: %v", err)
+	}
+	if !strings.HasPrefix(key, APIKeyPrefix) || len(key) < 40 {
+		t.Errorf("key %q lacks the prefix or is too short", key)
+	}
+	if stored.Hash != HashAPIKey(key) || strings.Contains(stored.Hash, key) {
+		t.Error("stored hash does not match the key")
+	}
+	if !strings.HasPrefix(key, stored.Hint) || len(stored.Hint) >= len(key)/2 {
+		t.Errorf("hint %q reveals too much of the key", stored.Hint)
+	}
+	other, _, _ := NewAPIKey()
+	if other == key {
+		t.Error("two keys are equal")
+	}
+}
+
+func TestRequireAuthOrAPIKey(t *testing.T) {
+	cfg := DefaultJWTConfig("test-secret")
+	store := NewMemoryAPIKeyStore()
+	auth := NewAPIKeyAuth(store, RateLimit{})
+	defer auth.Stop()
+
+	var got *Principal
+	h := auth.RequireAuthOrAPIKey(cfg)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = GetPrincipal(r)
+		w.WriteHeader(http.StatusOK)
+	}))
+	serve := func(header, value string) int {
+		got = nil
+		req := httptest.NewRequest(http.MethodGet, "/api/jobs/search", nil)
+		if header != "" {
+			req.Header.Set(header, value)
+		}
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	key, id := saveTestKey(t, store, RateLimit{}, ScopeReadJobs)
+	if status := serve(APIKeyHeader, key); status != http.StatusOK {
+		t.Fatalf("valid key: expected 200, got %d", status)
+	}
+	if got == nil || got.Kind != PrincipalAPIKey || got.ID != id {
+		t.Fatalf("principal = %+v, want API key %s", got, id)
+	}
+	if !got.HasScope(ScopeReadJobs) || got.HasScope(ScopeWriteProgress) {
+		t.Errorf("key scopes = %v, want only read:jobs", got.Scopes)
+	}
+
+	token, _, _ := GenerateToken(cfg, "user-1", "jane@example.com", nil, true)
+	if status := serve("Authorization", "Bearer "+token); status != http.StatusOK {
+		t.Fatalf("JWT: expected 200, got %d", status)
+	}
+	if got == nil || got.Kind != PrincipalUser || !got.HasScope(ScopeWriteProgress) {
+		t.Errorf("JWT principal = %+v, want a user holding every scope", got)
+	}
+
+	if status := serve(APIKeyHeader, APIKeyPrefix+"not-a-real-key"); status != http.StatusUnauthorized {
+		t.Errorf("unknown key: expected 401, got %d", status)
+	}
+	if status := serve("", ""); status != http.StatusUnauthorized {
+		t.Errorf("no credentials: expected 401, got %d", status)
+	}
+
+	if _, err := store.Revoke(id, time.Now()); err != nil {
+		t.Fatalf("Revoke: %v", err)
+	}
+	if status := serve(APIKeyHeader, key); status != http.StatusUnauthorized || got != nil {
+		t.Errorf("revoked key: expected 401, got %d", status)
+	}
+	if _, err := store.Revoke(id, time.Now()); err != ErrAPIKeyRevoked {
+		t.Errorf("second Revoke: got %v, want ErrAPIKeyRevoked", err)
+	}
+}
+
+func TestRequireAuthOrAPIKey_RateLimitsPerKey(t *testing.T) {
+	store := NewMemoryAPIKeyStore()
+	auth := NewAPIKeyAuth(store, RateLimit{RequestsPerSecond: 1, Burst: 5})
+	defer auth.Stop()
+	h := auth.RequireAuthOrAPIKey(DefaultJWTConfig("test-secret"))(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	serve := func(key string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/jobs/search", nil)
+		req.Header.Set(APIKeyHeader, key)
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		return rec
+	}
+
+	limited, _ := saveTestKey(t, store, RateLimit{RequestsPerSecond: 0.01, Burst: 2}, ScopeReadJobs)
+	other, _ := saveTestKey(t, store, RateLimit{}, ScopeReadJobs)
+	for i := 0; i < 2; i++ {
+		if rec := serve(limited); rec.Code != http.StatusOK {
+			t.Fatalf("request %d: expected 200, got %d", i+1, rec.Code)
+		}
+	}
+	rec := serve(limited)
+	if rec.Code != http.StatusTooManyRequests || rec.Header().Get("Retry-After") == "" {
+		t.Errorf("over the key's limit: got %d, Retry-After %q", rec.Code, rec.Header().Get("Retry-After"))
+	}
+	rec = serve(other)
+	if rec.Code != http.StatusOK || rec.Header().Get("RateLimit-Limit") != "5" {
+		t.Errorf("other key: got %d, RateLimit-Limit %q; want 200 under the default limit",
+			rec.Code, rec.Header().Get("RateLimit-Limit"))
+	}
+}
+
+func TestLogger_RedactsAPIKeys(t *testing.T) {
+	var logs bytes.Buffer
+	store := NewMemoryAPIKeyStore()
+	key, _ := saveTestKey(t, store, RateLimit{}, ScopeReadJobs)
+	auth := NewAPIKeyAuth(store, RateLimit{})
+	defer auth.Stop()
+
+	h := Chain(
+		Logger(logging.New("test-gateway", &logs, logging.Config{})),
+		auth.RequireAuthOrAPIKey(DefaultJWTConfig("test-secret")),
+	)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	// Clients sometimes put the key in the URL by mistake.
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/jobs/"+key, nil)
+	req.Header.Set(APIKeyHeader, key)
+	h.ServeHTTP(httptest.NewRecorder(), req)
+
+	if logs.Len() == 0 {
+		t.Fatal("no request logged")
+	}
+	if strings.Contains(logs.String(), key) || strings.Contains(logs.String(), key[len(APIKeyPrefix):]) {
+		t.Errorf("API key in logs: %s", logs.String())
+	}
+	if !strings.Contains(logs.String(), "[api_key]") {
+		t.Errorf("expected the key to be masked: %s", logs.String())
+	}
+}
@@ -23,8 +23,24 @@ const (
 
 	// ContextKeyIsAdmin is the context key for the admin flag.
 	ContextKeyIsAdmin contextKey = "is_admin"
+
+	// ContextKeyEmailVerified is the context key for the email verified flag.
+	ContextKeyEmailVerified contextKey = "email_verified"
+
+	// ContextKeyRoles is the context key for the user's roles ([]Role).
+	ContextKeyRoles contextKey = "roles"
+
+	// ContextKeyPrincipal is the context key for the authenticated caller
+	// (*Principal), a user or an API key.
+	ContextKeyPrincipal contextKey = "principal"
 )
 
+// TokenVersion is the version of the claims in tokens issued by
+// GenerateToken. Version 2 added the roles claim; tokens without a version
+// predate it and are read as RoleUser rather than rejected, whatever their
+// is_admin claim says.
+const TokenVersion = 2
+
 // JWTConfig holds JWT configuration.
 type JWTConfig struct {
 	// SecretKey is the HMAC secret used to sign tokens.
@@ -32,6 +48,14 @@ type JWTConfig struct {
 
 	// TokenDuration is how long tokens are valid.
 	TokenDuration time.Duration
+
+	// RefreshTokenDuration is how long refresh tokens are valid. Each
+	// refresh issues a new refresh token with a fresh expiry.
+	RefreshTokenDuration time.Duration
+
+	// Revocations rejects the tokens of users revoked with RevokeTokens.
+	// Nil accepts every valid token.
+	Revocations *TokenRevocations
 }
 
 // DefaultJWTConfig returns a JWTConfig with sensible defaults.
@@ -41,26 +65,47 @@ func DefaultJWTConfig(secret string) JWTConfig {
 		secret = "learnbot-dev-secret-change-in-production"
 	}
 	return JWTConfig{
-		SecretKey:     []byte(secret),
-		TokenDuration: 24 * time.Hour,
+		SecretKey:            []byte(secret),
+		TokenDuration:        24 * time.Hour,
+		RefreshTokenDuration: 30 * 24 * time.Hour,
+		Revocations:          NewTokenRevocations(),
+	}
+}
+
+// RevokeTokens rejects the tokens issued to userID so far. It does nothing
+// if cfg has no Revocations.
+func (cfg JWTConfig) RevokeTokens(userID string) {
+	if cfg.Revocations != nil {
+		cfg.Revocations.Revoke(userID, time.Now(), cfg.TokenDuration)
 	}
 }
 
 // jwtClaims represents the JWT payload.
 type jwtClaims struct {
+	Version int    `json:"ver,omitempty"`
 	UserID  string `json:"user_id"`
 	Email   string `json:"email"`
-	IsAdmin bool   `json:"is_admin"`
+	Roles   []Role `json:"roles,omitempty"`
+	// IsAdmin mirrors RoleAdmin for the services that check only is_admin.
+	IsAdmin bool `json:"is_admin"`
+	// EmailUnverified flags users who have not verified their email. It is
+	// omitted for verified users.
+	EmailUnverified bool `json:"email_unverified,omitempty"`
 	jwt.RegisteredClaims
 }
 
-// GenerateToken creates a signed JWT token for the given user.
-func GenerateToken(cfg JWTConfig, userID, email string, isAdmin bool) (string, time.Time, error) {
+// GenerateToken creates a signed JWT token for the given user. RoleUser is
+// added to roles if missing.
+func GenerateToken(cfg JWTConfig, userID, email string, roles []Role, emailVerified bool) (string, time.Time, error) {
 	expiresAt := time.Now().Add(cfg.TokenDuration)
+	roles = NormalizeRoles(roles)
 	claims := jwtClaims{
-		UserID:  userID,
-		Email:   email,
-		IsAdmin: isAdmin,
+		Version:         TokenVersion,
+		UserID:          userID,
+		Email:           email,
+		Roles:           roles,
+		IsAdmin:         HasRole(roles, RoleAdmin),
+		EmailUnverified: !emailVerified,
 		RegisteredClaims: jwt.RegisteredClaims{
 			ExpiresAt: jwt.NewNumericDate(expiresAt),
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
@@ -87,6 +132,19 @@ func ParseToken(cfg JWTConfig, tokenStr string) (*jwtClaims, error) {
 	if !ok || !token.Valid {
 		return nil, jwt.ErrTokenInvalidClaims
 	}
+	// Tokens without an issue time predate any revocation.
+	var issuedAt time.Time
+	if claims.IssuedAt != nil {
+		issuedAt = claims.IssuedAt.Time
+	}
+	if cfg.Revocations.Revoked(claims.UserID, issuedAt) {
+		return nil, jwt.ErrTokenInvalidClaims
+	}
+	if claims.Version < TokenVersion {
+		claims.Roles, claims.IsAdmin = nil, false
+	}
+	claims.Roles = NormalizeRoles(claims.Roles)
+	claims.IsAdmin = HasRole(claims.Roles, RoleAdmin)
 	return claims, nil
 }
 
@@ -108,27 +166,50 @@ func RequireAuth(cfg JWTConfig) func(http.Handler) http.Handler {
 				return
 			}
 
-			// Inject claims into context.
-			ctx := context.WithValue(r.Context(), ContextKeyUserID, claims.UserID)
-			ctx = context.WithValue(ctx, ContextKeyEmail, claims.Email)
-			ctx = context.WithValue(ctx, ContextKeyIsAdmin, claims.IsAdmin)
+			next.ServeHTTP(w, withClaims(r, claims))
+		})
+	}
+}
+
+// OptionalAuth is RequireAuth for routes that also serve anonymous
+// requests: requests without a Bearer token are passed on without user
+// context values, while invalid or expired tokens are still rejected with
+// 401 Unauthorized.
+func OptionalAuth(cfg JWTConfig) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			tokenStr := extractBearerToken(r)
+			if tokenStr == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
 
-			next.ServeHTTP(w, r.WithContext(ctx))
+			claims, err := ParseToken(cfg, tokenStr)
+			if err != nil {
+				writeAuthError(w, "invalid or expired token")
+				return
+			}
+			next.ServeHTTP(w, withClaims(r, claims))
 		})
 	}
 }
 
+// withClaims injects the claims of a validated token into the request
+// context.
+func withClaims(r *http.Request, claims *jwtClaims) *http.Request {
+	ctx := context.WithValue(r.Context(), ContextKeyUserID, claims.UserID)
+	ctx = context.WithValue(ctx, ContextKeyEmail, claims.Email)
+	ctx = context.WithValue(ctx, ContextKeyIsAdmin, claims.IsAdmin)
+	ctx = context.WithValue(ctx, ContextKeyRoles, claims.Roles)
+	ctx = context.WithValue(ctx, ContextKeyEmailVerified, !claims.EmailUnverified)
+	ctx = context.WithValue(ctx, ContextKeyPrincipal, &Principal{Kind: PrincipalUser, ID: claims.UserID})
+	return r.WithContext(ctx)
+}
+
 // RequireAdmin is a middleware that requires the user to be an admin.
 // Must be used after RequireAuth.
 func RequireAdmin(next http.Handler) http.Handler {
-	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		isAdmin, _ := r.Context().Value(ContextKeyIsAdmin).(bool)
-		if !isAdmin {
-			writeJSONError(w, http.StatusForbidden, "FORBIDDEN", "admin access required")
-			return
-		}
-		next.ServeHTTP(w, r)
-	})
+	return RequireRole(RoleAdmin)(next)
 }
 
 // GetUserID extracts the authenticated user ID from the request context.
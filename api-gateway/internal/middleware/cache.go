@@ -0,0 +1,372 @@
+// Package middleware – cache.go caches the responses of public read
+// endpoints in memory.
+package middleware
+
+import (
+	"bytes"
+	"container/list"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/learnbot/shared/metrics"
+)
+
+// CacheHeader reports whether a response came from the cache: "HIT", or
+// "MISS" when the backend served it.
+const CacheHeader = "X-Cache"
+
+var (
+	cacheRequests = metrics.NewCounter("gateway_response_cache_requests_total",
+		"GET requests to cached routes, by route prefix and result: hit, miss, collapsed (served "+
+			"by a concurrent miss for the same key) or bypass.",
+		"route", "result")
+	cacheEvictions = metrics.NewCounter("gateway_response_cache_evictions_total",
+		"Cached responses evicted to stay within the size bound, by route prefix.",
+		"route")
+)
+
+// CacheRoute caches GET requests whose path starts with PathPrefix.
+type CacheRoute struct {
+	PathPrefix string
+
+	// TTL is how long a response is served from the cache.
+	TTL time.Duration
+
+	// PerUser also caches authenticated requests, separately for each user
+	// or API key. Otherwise authenticated requests bypass the cache.
+	PerUser bool
+}
+
+// ResponseCacheConfig configures a ResponseCache.
+type ResponseCacheConfig struct {
+	// Routes are the cached route groups. The longest matching PathPrefix
+	// wins.
+	Routes []CacheRoute
+
+	// MaxEntries bounds the number of cached responses; the least recently
+	// used one is evicted first.
+	MaxEntries int
+
+	// MaxBodyBytes is the largest response body that is cached.
+	MaxBodyBytes int
+}
+
+// DefaultResponseCacheConfig returns the gateway's cached routes: resource
+// search for 5 minutes, and the proxied resource listings, featured
+// resources included, for a minute per caller. Up to 1000 responses of at
+// most 1 MiB are kept.
+func DefaultResponseCacheConfig() ResponseCacheConfig {
+	return ResponseCacheConfig{
+		Routes: []CacheRoute{
+			{PathPrefix: "/api/resources/search", TTL: 5 * time.Minute},
+			{PathPrefix: "/api/v1/learning/resources", TTL: time.Minute, PerUser: true},
+		},
+		MaxEntries:   1000,
+		MaxBodyBytes: 1 << 20,
+	}
+}
+
+// ResponseCache caches successful GET responses of configured routes,
+// keyed by path, query, Accept and Accept-Encoding, and by caller on
+// per-user routes. Concurrent misses for the same key are collapsed, so
+// the backend serves one of them and the others share its response.
+type ResponseCache struct {
+	routes     []CacheRoute
+	maxEntries int
+	maxBody    int
+	now        func() time.Time
+
+	mu sync.Mutex
+	// lru holds *cacheEntry values, most recently used first; entries
+	// indexes it by key.
+	lru     *list.List
+	entries map[string]*list.Element
+	// flights are the misses being served, by key.
+	flights map[string]*cacheFlight
+}
+
+// cachedResponse is a response as written by the handler.
+type cachedResponse struct {
+	status int
+	header http.Header
+	body   []byte
+}
+
+type cacheEntry struct {
+	key     string
+	route   string
+	path    string
+	resp    *cachedResponse
+	expires time.Time
+}
+
+// cacheFlight is a miss being served. resp is set before done is closed,
+// and is nil if the response could not be shared.
+type cacheFlight struct {
+	done chan struct{}
+	resp *cachedResponse
+}
+
+// NewResponseCache creates a ResponseCache from cfg. Routes without a TTL
+// are not cached; a zero MaxEntries or MaxBodyBytes uses the default.
+func NewResponseCache(cfg ResponseCacheConfig) *ResponseCache {
+	def := DefaultResponseCacheConfig()
+	c := &ResponseCache{
+		maxEntries: cfg.MaxEntries,
+		maxBody:    cfg.MaxBodyBytes,
+		now:        time.Now,
+		lru:        list.New(),
+		entries:    make(map[string]*list.Element),
+		flights:    make(map[string]*cacheFlight),
+	}
+	for _, route := range cfg.Routes {
+		if route.TTL > 0 {
+			c.routes = append(c.routes, route)
+		}
+	}
+	if c.maxEntries <= 0 {
+		c.maxEntries = def.MaxEntries
+	}
+	if c.maxBody <= 0 {
+		c.maxBody = def.MaxBodyBytes
+	}
+	return c
+}
+
+// Middleware serves cached responses. Use it after the authentication
+// middleware, which identifies the caller.
+func (c *ResponseCache) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		route, ok := c.route(r.URL.Path)
+		if !ok || r.Method != http.MethodGet {
+			next.ServeHTTP(w, r)
+			return
+		}
+		caller := PrincipalScope(r)
+		if caller != "" && !route.PerUser {
+			cacheRequests.Inc(route.PathPrefix, "bypass")
+			next.ServeHTTP(w, r)
+			return
+		}
+		key := cacheKey(r, caller)
+
+		c.mu.Lock()
+		if resp := c.get(key); resp != nil {
+			c.mu.Unlock()
+			cacheRequests.Inc(route.PathPrefix, "hit")
+			resp.write(w, "HIT")
+			return
+		}
+		if f, ok := c.flights[key]; ok {
+			c.mu.Unlock()
+			select {
+			case <-f.done:
+			case <-r.Context().Done():
+				return
+			}
+			if f.resp != nil {
+				cacheRequests.Inc(route.PathPrefix, "collapsed")
+				f.resp.write(w, "HIT")
+				return
+			}
+			// The response could not be shared; serve this request itself.
+			cacheRequests.Inc(route.PathPrefix, "miss")
+			w.Header().Set(CacheHeader, "MISS")
+			next.ServeHTTP(w, r)
+			return
+		}
+		f := &cacheFlight{done: make(chan struct{})}
+		c.flights[key] = f
+		c.mu.Unlock()
+
+		cacheRequests.Inc(route.PathPrefix, "miss")
+		rec := &cacheRecorder{ResponseWriter: w, status: http.StatusOK, limit: c.maxBody}
+		defer func() {
+			// A panic or a cancelled request leaves the response unknown.
+			var resp *cachedResponse
+			if rec.complete() && r.Context().Err() == nil {
+				resp = rec.response()
+			}
+			c.land(key, f, resp, route, r.URL.Path, caller != "")
+		}()
+		w.Header().Set(CacheHeader, "MISS")
+		next.ServeHTTP(rec, r)
+		rec.done = true
+	})
+}
+
+// Purge removes the cached responses of paths starting with prefix, or
+// every cached response for an empty prefix, and returns how many were
+// removed.
+func (c *ResponseCache) Purge(prefix string) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	n := 0
+	for key, el := range c.entries {
+		if strings.HasPrefix(el.Value.(*cacheEntry).path, prefix) {
+			c.lru.Remove(el)
+			delete(c.entries, key)
+			n++
+		}
+	}
+	return n
+}
+
+// Len returns the number of cached responses, expired ones included until
+// they are next looked up or evicted.
+func (c *ResponseCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.lru.Len()
+}
+
+// route returns the cached route matching path.
+func (c *ResponseCache) route(path string) (CacheRoute, bool) {
+	var best CacheRoute
+	found := false
+	for _, route := range c.routes {
+		if strings.HasPrefix(path, route.PathPrefix) && len(route.PathPrefix) > len(best.PathPrefix) {
+			best, found = route, true
+		}
+	}
+	return best, found
+}
+
+// cacheKey keys r for caller. Accept-Encoding is part of the key because
+// the backend may compress the response for clients that accept it.
+func cacheKey(r *http.Request, caller string) string {
+	return strings.Join([]string{
+		caller, r.URL.Path, r.URL.RawQuery, r.Header.Get("Accept"), r.Header.Get("Accept-Encoding"),
+	}, "\x00")
+}
+
+// get returns the unexpired response cached under key. c.mu must be held.
+func (c *ResponseCache) get(key string) *cachedResponse {
+	el, ok := c.entries[key]
+	if !ok {
+		return nil
+	}
+	e := el.Value.(*cacheEntry)
+	if !c.now().Before(e.expires) {
+		c.lru.Remove(el)
+		delete(c.entries, key)
+		return nil
+	}
+	c.lru.MoveToFront(el)
+	return e.resp
+}
+
+// land ends the flight for key, sharing resp with its waiters and caching
+// it if it may be cached.
+func (c *ResponseCache) land(key string, f *cacheFlight, resp *cachedResponse, route CacheRoute, path string, private bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.flights, key)
+	f.resp = resp
+	close(f.done)
+
+	if resp == nil || !cacheable(resp, private) {
+		return
+	}
+	e := &cacheEntry{key: key, route: route.PathPrefix, path: path, resp: resp, expires: c.now().Add(route.TTL)}
+	if el, ok := c.entries[key]; ok {
+		el.Value = e
+		c.lru.MoveToFront(el)
+		return
+	}
+	c.entries[key] = c.lru.PushFront(e)
+	for c.lru.Len() > c.maxEntries {
+		oldest := c.lru.Back()
+		evicted := oldest.Value.(*cacheEntry)
+		c.lru.Remove(oldest)
+		delete(c.entries, evicted.key)
+		cacheEvictions.Inc(evicted.route)
+	}
+}
+
+// cacheable reports whether resp may be cached: a 200 response without
+// cookies that the backend has not marked no-store, or private unless it
+// is cached per caller.
+func cacheable(resp *cachedResponse, perCaller bool) bool {
+	if resp.status != http.StatusOK || len(resp.header.Values("Set-Cookie")) > 0 {
+		return false
+	}
+	cc := strings.ToLower(strings.Join(resp.header.Values("Cache-Control"), ","))
+	if strings.Contains(cc, "no-store") || strings.Contains(cc, "no-cache") {
+		return false
+	}
+	return perCaller || !strings.Contains(cc, "private")
+}
+
+// write replays resp on w with the X-Cache header set to cache.
+func (resp *cachedResponse) write(w http.ResponseWriter, cache string) {
+	h := w.Header()
+	for k, v := range resp.header {
+		h[k] = append([]string(nil), v...)
+	}
+	h.Set(CacheHeader, cache)
+	w.WriteHeader(resp.status)
+	w.Write(resp.body)
+}
+
+// cacheRecorder passes a response through to the client while keeping a
+// copy of up to limit body bytes.
+type cacheRecorder struct {
+	http.ResponseWriter
+	status      int
+	header      http.Header
+	body        bytes.Buffer
+	limit       int
+	overflow    bool
+	wroteHeader bool
+	// done is set once the handler has returned normally.
+	done bool
+}
+
+func (rec *cacheRecorder) WriteHeader(code int) {
+	if !rec.wroteHeader {
+		rec.wroteHeader = true
+		rec.status = code
+		rec.header = rec.ResponseWriter.Header().Clone()
+		rec.header.Del(CacheHeader)
+	}
+	rec.ResponseWriter.WriteHeader(code)
+}
+
+func (rec *cacheRecorder) Write(b []byte) (int, error) {
+	if !rec.wroteHeader {
+		rec.WriteHeader(http.StatusOK)
+	}
+	if !rec.overflow {
+		if rec.body.Len()+len(b) > rec.limit {
+			rec.overflow = true
+			rec.body = bytes.Buffer{}
+		} else {
+			rec.body.Write(b)
+		}
+	}
+	return rec.ResponseWriter.Write(b)
+}
+
+// Flush lets streaming responses keep working behind the cache.
+func (rec *cacheRecorder) Flush() {
+	if f, ok := rec.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// complete reports whether the whole response was recorded.
+func (rec *cacheRecorder) complete() bool {
+	return rec.done && !rec.overflow
+}
+
+func (rec *cacheRecorder) response() *cachedResponse {
+	if !rec.wroteHeader {
+		rec.status = http.StatusOK
+		rec.header = rec.ResponseWriter.Header().Clone()
+		rec.header.Del(CacheHeader)
+	}
+	return &cachedResponse{status: rec.status, header: rec.header, body: bytes.Clone(rec.body.Bytes())}
+}
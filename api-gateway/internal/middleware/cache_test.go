@@ -0,0 +1,252 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newTestCache returns a cache of cfg with a controllable clock, in front
+// of a backend that counts its calls.
+func newTestCache(cfg ResponseCacheConfig, backend http.HandlerFunc) (http.Handler, *ResponseCache, *time.Time, *atomic.Int32) {
+	c := NewResponseCache(cfg)
+	now := time.Date(2025, 1, 15, 10, 0, 0, 0, time.UTC)
+	c.now = func() time.Time { return now }
+	var calls atomic.Int32
+	h := c.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		backend(w, r)
+	}))
+	return h, c, &now, &calls
+}
+
+func echoPath(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	fmt.Fprintf(w, `{"path":%q}`, r.URL.RequestURI())
+}
+
+// get serves a GET of target, as caller if it is set.
+func get(h http.Handler, target string, caller *Principal) *httptest.ResponseRecorder {
+	r := httptest.NewRequest(http.MethodGet, target, nil)
+	if caller != nil {
+		r = r.WithContext(context.WithValue(r.Context(), ContextKeyPrincipal, caller))
+	}
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	return w
+}
+
+func TestResponseCache_HitMissAndTTL(t *testing.T) {
+	h, _, now, calls := newTestCache(DefaultResponseCacheConfig(), echoPath)
+	hits := cacheRequests.Value("/api/resources/search", "hit")
+
+	first := get(h, "/api/resources/search?skill=go", nil)
+	if first.Header().Get(CacheHeader) != "MISS" {
+		t.Fatalf("first request: X-Cache = %q, want MISS", first.Header().Get(CacheHeader))
+	}
+	second := get(h, "/api/resources/search?skill=go", nil)
+	if second.Header().Get(CacheHeader) != "HIT" || second.Body.String() != first.Body.String() ||
+		second.Header().Get("Content-Type") != "application/json" {
+		t.Errorf("second request: %q %q %v", second.Header().Get(CacheHeader), second.Body.String(), second.Header())
+	}
+	if calls.Load() != 1 {
+		t.Errorf("backend called %d times, want 1", calls.Load())
+	}
+	if d := cacheRequests.Value("/api/resources/search", "hit") - hits; d != 1 {
+		t.Errorf("hit metric advanced by %v, want 1", d)
+	}
+
+	// Another query, or another Accept header, is another key.
+	get(h, "/api/resources/search?skill=python", nil)
+	r := httptest.NewRequest(http.MethodGet, "/api/resources/search?skill=go", nil)
+	r.Header.Set("Accept", "text/csv")
+	h.ServeHTTP(httptest.NewRecorder(), r)
+	if calls.Load() != 3 {
+		t.Errorf("backend called %d times, want 3", calls.Load())
+	}
+
+	*now = now.Add(5 * time.Minute)
+	if w := get(h, "/api/resources/search?skill=go", nil); w.Header().Get(CacheHeader) != "MISS" {
+		t.Errorf("after the TTL: X-Cache = %q, want MISS", w.Header().Get(CacheHeader))
+	}
+
+	// Uncached routes are passed through without a header.
+	if w := get(h, "/api/jobs", nil); w.Header().Get(CacheHeader) != "" {
+		t.Errorf("uncached route: X-Cache = %q", w.Header().Get(CacheHeader))
+	}
+}
+
+func TestResponseCache_Authenticated(t *testing.T) {
+	h, _, _, calls := newTestCache(DefaultResponseCacheConfig(), echoPath)
+	alice := &Principal{Kind: PrincipalUser, ID: "alice"}
+	bob := &Principal{Kind: PrincipalUser, ID: "bob"}
+
+	// Authenticated requests bypass a route that is not cached per user.
+	get(h, "/api/resources/search", alice)
+	if w := get(h, "/api/resources/search", alice); w.Header().Get(CacheHeader) != "" || calls.Load() != 2 {
+		t.Errorf("authenticated request was cached: %q, %d calls", w.Header().Get(CacheHeader), calls.Load())
+	}
+
+	// Per-user routes are cached separately for each caller.
+	calls.Store(0)
+	get(h, "/api/v1/learning/resources/featured", alice)
+	if w := get(h, "/api/v1/learning/resources/featured", alice); w.Header().Get(CacheHeader) != "HIT" {
+		t.Errorf("per-user route: X-Cache = %q, want HIT", w.Header().Get(CacheHeader))
+	}
+	if w := get(h, "/api/v1/learning/resources/featured", bob); w.Header().Get(CacheHeader) != "MISS" {
+		t.Errorf("another user: X-Cache = %q, want MISS", w.Header().Get(CacheHeader))
+	}
+	if calls.Load() != 2 {
+		t.Errorf("backend called %d times, want 2", calls.Load())
+	}
+}
+
+func TestResponseCache_Uncacheable(t *testing.T) {
+	status := http.StatusOK
+	header := ""
+	h, c, _, calls := newTestCache(DefaultResponseCacheConfig(), func(w http.ResponseWriter, r *http.Request) {
+		if header != "" {
+			w.Header().Set("Cache-Control", header)
+		}
+		w.WriteHeader(status)
+	})
+
+	for _, tt := range []struct {
+		status int
+		header string
+	}{
+		{http.StatusServiceUnavailable, ""},
+		{http.StatusOK, "no-store"},
+		{http.StatusOK, "private, max-age=60"},
+		{http.StatusNotFound, ""},
+	} {
+		status, header = tt.status, tt.header
+		calls.Store(0)
+		get(h, "/api/resources/search", nil)
+		get(h, "/api/resources/search", nil)
+		if calls.Load() != 2 {
+			t.Errorf("%d %q: backend called %d times, want 2", tt.status, tt.header, calls.Load())
+		}
+	}
+	if c.Len() != 0 {
+		t.Errorf("%d responses cached, want 0", c.Len())
+	}
+}
+
+func TestResponseCache_LRUEviction(t *testing.T) {
+	cfg := DefaultResponseCacheConfig()
+	cfg.MaxEntries = 2
+	h, c, _, calls := newTestCache(cfg, echoPath)
+
+	get(h, "/api/resources/search?q=a", nil)
+	get(h, "/api/resources/search?q=b", nil)
+	get(h, "/api/resources/search?q=a", nil) // a is now the most recently used
+	get(h, "/api/resources/search?q=c", nil) // evicts b
+
+	if c.Len() != 2 {
+		t.Errorf("%d responses cached, want 2", c.Len())
+	}
+	calls.Store(0)
+	if w := get(h, "/api/resources/search?q=a", nil); w.Header().Get(CacheHeader) != "HIT" {
+		t.Errorf("a: X-Cache = %q, want HIT", w.Header().Get(CacheHeader))
+	}
+	if w := get(h, "/api/resources/search?q=b", nil); w.Header().Get(CacheHeader) != "MISS" {
+		t.Errorf("b: X-Cache = %q, want MISS", w.Header().Get(CacheHeader))
+	}
+}
+
+func TestResponseCache_OversizedBody(t *testing.T) {
+	cfg := DefaultResponseCacheConfig()
+	cfg.MaxBodyBytes = 8
+	h, c, _, _ := newTestCache(cfg, echoPath)
+
+	w := get(h, "/api/resources/search?q=long", nil)
+	if w.Body.String() != `{"path":"/api/resources/search?q=long"}` {
+		t.Errorf("body = %q", w.Body.String())
+	}
+	if c.Len() != 0 {
+		t.Errorf("oversized response was cached")
+	}
+}
+
+func TestResponseCache_Purge(t *testing.T) {
+	h, c, _, _ := newTestCache(DefaultResponseCacheConfig(), echoPath)
+	alice := &Principal{Kind: PrincipalUser, ID: "alice"}
+	get(h, "/api/resources/search?q=a", nil)
+	get(h, "/api/v1/learning/resources", alice)
+	get(h, "/api/v1/learning/resources/featured", alice)
+
+	if n := c.Purge("/api/v1/learning/"); n != 2 || c.Len() != 1 {
+		t.Errorf("Purge removed %d, %d left; want 2 and 1", n, c.Len())
+	}
+	if w := get(h, "/api/v1/learning/resources/featured", alice); w.Header().Get(CacheHeader) != "MISS" {
+		t.Errorf("purged response served from the cache")
+	}
+	if n := c.Purge(""); n != 2 || c.Len() != 0 {
+		t.Errorf("Purge of everything removed %d, %d left", n, c.Len())
+	}
+}
+
+func TestResponseCache_CollapsesConcurrentMisses(t *testing.T) {
+	release := make(chan struct{})
+	h, _, _, calls := newTestCache(DefaultResponseCacheConfig(), func(w http.ResponseWriter, r *http.Request) {
+		<-release
+		echoPath(w, r)
+	})
+
+	const n = 10
+	var wg sync.WaitGroup
+	results := make([]*httptest.ResponseRecorder, n)
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i] = get(h, "/api/resources/search?q=go", nil)
+		}(i)
+	}
+	// Let every request reach the cache before the backend answers.
+	deadline := time.Now().Add(2 * time.Second)
+	for calls.Load() < 1 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	if calls.Load() != 1 {
+		t.Errorf("backend called %d times, want 1", calls.Load())
+	}
+	misses := 0
+	for _, w := range results {
+		if w.Body.String() != `{"path":"/api/resources/search?q=go"}` {
+			t.Errorf("body = %q", w.Body.String())
+		}
+		if w.Header().Get(CacheHeader) == "MISS" {
+			misses++
+		}
+	}
+	if misses != 1 {
+		t.Errorf("%d responses marked MISS, want 1", misses)
+	}
+}
+
+func TestResponseCache_PanicReleasesWaiters(t *testing.T) {
+	c := NewResponseCache(DefaultResponseCacheConfig())
+	h := c.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic("backend exploded")
+	}))
+	func() {
+		defer func() { recover() }()
+		get(h, "/api/resources/search", nil)
+	}()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(c.flights) != 0 {
+		t.Errorf("flight left behind after a panic")
+	}
+}
@@ -0,0 +1,288 @@
+// Package middleware – circuitbreaker.go implements a per-backend circuit
+// breaker that fails fast while a backend is unhealthy.
+package middleware
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/learnbot/shared/metrics"
+)
+
+var (
+	breakerTransitions = metrics.NewCounter("gateway_circuit_breaker_transitions_total",
+		"Circuit breaker state transitions, by backend, previous state and new state.",
+		"backend", "from", "to")
+	breakerRejections = metrics.NewCounter("gateway_circuit_breaker_rejections_total",
+		"Requests rejected without calling the backend because its circuit was open.",
+		"backend")
+)
+
+// BreakerState is the state of a CircuitBreaker.
+type BreakerState int
+
+const (
+	// BreakerClosed lets every request through and tracks failures.
+	BreakerClosed BreakerState = iota
+
+	// BreakerOpen rejects every request until the cooldown has passed.
+	BreakerOpen
+
+	// BreakerHalfOpen lets a limited number of probe requests through to
+	// decide whether to close or reopen.
+	BreakerHalfOpen
+)
+
+// String returns the state name used in logs and metrics.
+func (s BreakerState) String() string {
+	switch s {
+	case BreakerClosed:
+		return "closed"
+	case BreakerOpen:
+		return "open"
+	case BreakerHalfOpen:
+		return "half_open"
+	default:
+		return "unknown"
+	}
+}
+
+// CircuitBreakerConfig configures a CircuitBreaker. Zero fields fall back to
+// DefaultCircuitBreakerConfig.
+type CircuitBreakerConfig struct {
+	// Window is the sliding window over which the failure rate is measured.
+	Window time.Duration
+
+	// MinRequests is the number of requests the window must hold before the
+	// breaker may open, so a single early failure does not trip it.
+	MinRequests int
+
+	// FailureRate is the fraction of failed requests in the window, between
+	// 0 and 1, at which the breaker opens.
+	FailureRate float64
+
+	// Cooldown is how long the breaker stays open before probing.
+	Cooldown time.Duration
+
+	// HalfOpenProbes is the number of probe requests let through while
+	// half-open. All of them must succeed for the breaker to close.
+	HalfOpenProbes int
+}
+
+// DefaultCircuitBreakerConfig opens after at least 10 requests in 30 seconds
+// of which half failed, and probes with one request after 15 seconds.
+func DefaultCircuitBreakerConfig() CircuitBreakerConfig {
+	return CircuitBreakerConfig{
+		Window:         30 * time.Second,
+		MinRequests:    10,
+		FailureRate:    0.5,
+		Cooldown:       15 * time.Second,
+		HalfOpenProbes: 1,
+	}
+}
+
+// breakerBuckets is the number of buckets the sliding window is split into.
+const breakerBuckets = 10
+
+// breakerBucket counts outcomes for one slice of the window.
+type breakerBucket struct {
+	start     time.Time
+	successes int
+	failures  int
+}
+
+// CircuitBreaker tracks the failure rate of calls to one backend. While
+// closed, calls pass through; once the failure rate over the window reaches
+// the threshold it opens and rejects calls for the cooldown, then lets
+// probes through half-open and closes again when they succeed.
+type CircuitBreaker struct {
+	name   string
+	cfg    CircuitBreakerConfig
+	logger *slog.Logger
+	now    func() time.Time
+
+	mu         sync.Mutex
+	state      BreakerState
+	generation uint64 // incremented on every transition
+	buckets    [breakerBuckets]breakerBucket
+	openedAt   time.Time
+	probes     int // probes let through in the current half-open period
+	probeOKs   int
+}
+
+// NewCircuitBreaker creates a closed CircuitBreaker for the named backend.
+// A nil logger uses slog.Default.
+func NewCircuitBreaker(name string, cfg CircuitBreakerConfig, logger *slog.Logger) *CircuitBreaker {
+	def := DefaultCircuitBreakerConfig()
+	if cfg.Window <= 0 {
+		cfg.Window = def.Window
+	}
+	if cfg.MinRequests <= 0 {
+		cfg.MinRequests = def.MinRequests
+	}
+	if cfg.FailureRate <= 0 || cfg.FailureRate > 1 {
+		cfg.FailureRate = def.FailureRate
+	}
+	if cfg.Cooldown <= 0 {
+		cfg.Cooldown = def.Cooldown
+	}
+	if cfg.HalfOpenProbes <= 0 {
+		cfg.HalfOpenProbes = def.HalfOpenProbes
+	}
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &CircuitBreaker{name: name, cfg: cfg, logger: logger, now: time.Now}
+}
+
+// State returns the breaker's current state. An open breaker whose cooldown
+// has passed is reported as half-open.
+func (cb *CircuitBreaker) State() BreakerState {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	if cb.state == BreakerOpen && !cb.now().Before(cb.openedAt.Add(cb.cfg.Cooldown)) {
+		return BreakerHalfOpen
+	}
+	return cb.state
+}
+
+// Allow reports whether a call may proceed. When ok is true the caller must
+// report the call's outcome exactly once through done. When ok is false,
+// retryAfter is how long until the breaker will let a probe through.
+func (cb *CircuitBreaker) Allow() (done func(success bool), retryAfter time.Duration, ok bool) {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	now := cb.now()
+	switch cb.state {
+	case BreakerOpen:
+		reopen := cb.openedAt.Add(cb.cfg.Cooldown)
+		if now.Before(reopen) {
+			return nil, reopen.Sub(now), false
+		}
+		cb.transition(BreakerHalfOpen)
+		fallthrough
+	case BreakerHalfOpen:
+		if cb.probes >= cb.cfg.HalfOpenProbes {
+			// Probes are in flight; wait for their outcome.
+			return nil, time.Second, false
+		}
+		cb.probes++
+	}
+
+	generation := cb.generation
+	return func(success bool) { cb.record(generation, success) }, 0, true
+}
+
+// record applies a call's outcome. Outcomes of calls admitted before the
+// latest transition are ignored.
+func (cb *CircuitBreaker) record(generation uint64, success bool) {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	if generation != cb.generation {
+		return
+	}
+
+	switch cb.state {
+	case BreakerClosed:
+		b := cb.bucket(cb.now())
+		if success {
+			b.successes++
+			return
+		}
+		b.failures++
+		if total, failures := cb.totals(); total >= cb.cfg.MinRequests &&
+			float64(failures) >= cb.cfg.FailureRate*float64(total) {
+			cb.transition(BreakerOpen)
+		}
+	case BreakerHalfOpen:
+		if !success {
+			cb.transition(BreakerOpen)
+			return
+		}
+		cb.probeOKs++
+		if cb.probeOKs >= cb.cfg.HalfOpenProbes {
+			cb.transition(BreakerClosed)
+		}
+	}
+}
+
+// bucket returns the bucket for now, recycling it if it belongs to an
+// earlier pass over the window.
+func (cb *CircuitBreaker) bucket(now time.Time) *breakerBucket {
+	width := cb.cfg.Window / breakerBuckets
+	start := now.Truncate(width)
+	b := &cb.buckets[(start.UnixNano()/int64(width))%breakerBuckets]
+	if !b.start.Equal(start) {
+		*b = breakerBucket{start: start}
+	}
+	return b
+}
+
+// totals sums the outcomes recorded within the window.
+func (cb *CircuitBreaker) totals() (total, failures int) {
+	cutoff := cb.now().Add(-cb.cfg.Window)
+	for _, b := range cb.buckets {
+		if b.start.After(cutoff) {
+			total += b.successes + b.failures
+			failures += b.failures
+		}
+	}
+	return total, failures
+}
+
+// transition moves to state, resetting the per-state bookkeeping, and
+// emits a log line and metric.
+func (cb *CircuitBreaker) transition(state BreakerState) {
+	from := cb.state
+	cb.state = state
+	cb.generation++
+	cb.probes, cb.probeOKs = 0, 0
+	switch state {
+	case BreakerOpen:
+		cb.openedAt = cb.now()
+	case BreakerClosed:
+		cb.buckets = [breakerBuckets]breakerBucket{}
+	}
+
+	breakerTransitions.Inc(cb.name, from.String(), state.String())
+	level := slog.LevelInfo
+	if state == BreakerOpen {
+		level = slog.LevelWarn
+	}
+	cb.logger.Log(context.Background(), level, "circuit breaker state change",
+		"backend", cb.name, "from", from.String(), "to", state.String())
+}
+
+// Middleware returns an HTTP middleware that guards next with the breaker.
+// Responses with a 5xx status count as failures. While the breaker is open,
+// requests are answered with 503 Service Unavailable and a Retry-After
+// header without calling next.
+func (cb *CircuitBreaker) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		done, retryAfter, ok := cb.Allow()
+		if !ok {
+			breakerRejections.Inc(cb.name)
+			w.Header().Set("Retry-After", strconv.Itoa(max(1, ceilSeconds(retryAfter))))
+			writeJSONError(w, http.StatusServiceUnavailable, "BACKEND_CIRCUIT_OPEN",
+				cb.name+" is temporarily unavailable, please retry later")
+			return
+		}
+
+		rw := newResponseWriter(w)
+		defer func() {
+			// A panic in next, such as http.ErrAbortHandler from an
+			// interrupted proxy response, counts as a failure.
+			if rec := recover(); rec != nil {
+				done(false)
+				panic(rec)
+			}
+		}()
+		next.ServeHTTP(rw, r)
+		done(rw.statusCode < http.StatusInternalServerError)
+	})
+}
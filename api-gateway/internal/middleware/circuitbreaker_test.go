@@ -0,0 +1,222 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/learnbot/shared/logging"
+)
+
+// newTestBreaker returns a breaker with a controllable clock that logs to
+// logs.
+func newTestBreaker(cfg CircuitBreakerConfig, logs *bytes.Buffer) (*CircuitBreaker, *time.Time) {
+	cb := NewCircuitBreaker("resume-parser", cfg, logging.New("test-gateway", logs, logging.Config{}))
+	now := time.Date(2025, 1, 15, 10, 0, 0, 0, time.UTC)
+	cb.now = func() time.Time { return now }
+	return cb, &now
+}
+
+// call runs one call through the breaker and reports whether it was allowed.
+func call(cb *CircuitBreaker, success bool) bool {
+	done, _, ok := cb.Allow()
+	if ok {
+		done(success)
+	}
+	return ok
+}
+
+func TestCircuitBreaker_StateMachine(t *testing.T) {
+	var logs bytes.Buffer
+	cb, now := newTestBreaker(CircuitBreakerConfig{
+		Window:         10 * time.Second,
+		MinRequests:    4,
+		FailureRate:    0.5,
+		Cooldown:       5 * time.Second,
+		HalfOpenProbes: 2,
+	}, &logs)
+	opened := breakerTransitions.Value("resume-parser", "closed", "open")
+	closed := breakerTransitions.Value("resume-parser", "half_open", "closed")
+
+	steps := []struct {
+		name    string
+		advance time.Duration
+		success bool
+		allowed bool
+		state   BreakerState
+	}{
+		{"success", 0, true, true, BreakerClosed},
+		{"failure below min requests", time.Second, false, true, BreakerClosed},
+		{"second failure, 3 requests", time.Second, false, true, BreakerClosed},
+		{"third failure trips at 3/4", time.Second, false, true, BreakerOpen},
+		{"rejected while open", time.Second, true, false, BreakerOpen},
+		{"first probe after cooldown", 4 * time.Second, true, true, BreakerHalfOpen},
+		{"second probe closes", 0, true, true, BreakerClosed},
+		{"closed again", time.Second, true, true, BreakerClosed},
+	}
+	for _, step := range steps {
+		*now = now.Add(step.advance)
+		if got := call(cb, step.success); got != step.allowed {
+			t.Fatalf("%s: allowed = %v, want %v", step.name, got, step.allowed)
+		}
+		if got := cb.State(); got != step.state {
+			t.Fatalf("%s: state = %s, want %s", step.name, got, step.state)
+		}
+	}
+
+	if d := breakerTransitions.Value("resume-parser", "closed", "open") - opened; d != 1 {
+		t.Errorf("expected 1 closed→open transition, got %v", d)
+	}
+	if d := breakerTransitions.Value("resume-parser", "half_open", "closed") - closed; d != 1 {
+		t.Errorf("expected 1 half_open→closed transition, got %v", d)
+	}
+	for _, want := range []string{`"to":"open"`, `"to":"half_open"`, `"to":"closed"`, `"backend":"resume-parser"`} {
+		if !strings.Contains(logs.String(), want) {
+			t.Errorf("expected the transition log to contain %s, got:\n%s", want, logs.String())
+		}
+	}
+}
+
+func TestCircuitBreaker_FailedProbeReopens(t *testing.T) {
+	var logs bytes.Buffer
+	cb, now := newTestBreaker(CircuitBreakerConfig{MinRequests: 2, Cooldown: 5 * time.Second}, &logs)
+
+	call(cb, false)
+	call(cb, false)
+	if cb.State() != BreakerOpen {
+		t.Fatalf("expected open, got %s", cb.State())
+	}
+
+	*now = now.Add(5 * time.Second)
+	probe, _, ok := cb.Allow()
+	if !ok {
+		t.Fatal("expected a probe after the cooldown")
+	}
+	if _, retryAfter, ok := cb.Allow(); ok || retryAfter <= 0 {
+		t.Errorf("expected concurrent requests to wait for the probe, got ok=%v retryAfter=%v", ok, retryAfter)
+	}
+	probe(false)
+
+	if cb.State() != BreakerOpen {
+		t.Fatalf("expected a failed probe to reopen the breaker, got %s", cb.State())
+	}
+	if _, retryAfter, ok := cb.Allow(); ok || retryAfter != 5*time.Second {
+		t.Errorf("expected a fresh cooldown, got ok=%v retryAfter=%v", ok, retryAfter)
+	}
+}
+
+func TestCircuitBreaker_WindowSlides(t *testing.T) {
+	var logs bytes.Buffer
+	cb, now := newTestBreaker(CircuitBreakerConfig{Window: 10 * time.Second, MinRequests: 3}, &logs)
+
+	call(cb, false)
+	call(cb, false)
+	// The early failures age out before the third request arrives.
+	*now = now.Add(11 * time.Second)
+	call(cb, false)
+	call(cb, true)
+	if cb.State() != BreakerClosed {
+		t.Errorf("expected failures outside the window to be forgotten, got %s", cb.State())
+	}
+}
+
+func TestCircuitBreaker_IgnoresOutcomesFromEarlierState(t *testing.T) {
+	var logs bytes.Buffer
+	cb, now := newTestBreaker(CircuitBreakerConfig{MinRequests: 2, Cooldown: time.Second}, &logs)
+
+	// A slow request admitted while closed finishes after the breaker
+	// opened and must not count as a half-open probe.
+	slow, _, _ := cb.Allow()
+	call(cb, false)
+	call(cb, false)
+	*now = now.Add(time.Second)
+	probe, _, ok := cb.Allow()
+	if !ok {
+		t.Fatal("expected a probe")
+	}
+	slow(true)
+	if cb.State() != BreakerHalfOpen {
+		t.Errorf("expected the stale outcome to be ignored, got %s", cb.State())
+	}
+	probe(true)
+	if cb.State() != BreakerClosed {
+		t.Errorf("expected the probe to close the breaker, got %s", cb.State())
+	}
+}
+
+func TestCircuitBreaker_Middleware(t *testing.T) {
+	var logs bytes.Buffer
+	cb, _ := newTestBreaker(CircuitBreakerConfig{MinRequests: 2, Cooldown: 30 * time.Second}, &logs)
+	rejected := breakerRejections.Value("resume-parser")
+
+	status := http.StatusBadGateway
+	calls := 0
+	h := cb.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(status)
+	}))
+	serveOnce := func() *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/analysis/score", nil))
+		return w
+	}
+
+	// 4xx responses are the client's fault and do not count as failures.
+	status = http.StatusNotFound
+	serveOnce()
+	serveOnce()
+	if cb.State() != BreakerClosed {
+		t.Fatalf("expected 4xx not to trip the breaker, got %s", cb.State())
+	}
+
+	status = http.StatusBadGateway
+	serveOnce()
+	serveOnce()
+	w := serveOnce()
+	if w.Code != http.StatusServiceUnavailable {
+		t.Fatalf("expected 503 once open, got %d", w.Code)
+	}
+	if calls != 4 {
+		t.Errorf("expected the open breaker not to call the backend, got %d calls", calls)
+	}
+	if got := w.Header().Get("Retry-After"); got != "30" {
+		t.Errorf("expected Retry-After 30, got %q", got)
+	}
+	var body struct {
+		Success bool              `json:"success"`
+		Error   map[string]string `json:"error"`
+	}
+	if err := json.NewDecoder(w.Body).Decode(&body); err != nil {
+		t.Fatalf("decode body: %v", err)
+	}
+	if body.Success || body.Error["code"] != "BACKEND_CIRCUIT_OPEN" {
+		t.Errorf("unexpected error body: %+v", body)
+	}
+	if d := breakerRejections.Value("resume-parser") - rejected; d != 1 {
+		t.Errorf("expected 1 rejection, got %v", d)
+	}
+}
+
+func TestCircuitBreaker_PanicCountsAsFailure(t *testing.T) {
+	var logs bytes.Buffer
+	cb, _ := newTestBreaker(CircuitBreakerConfig{MinRequests: 1}, &logs)
+	h := cb.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic(http.ErrAbortHandler)
+	}))
+
+	func() {
+		defer func() {
+			if rec := recover(); rec != http.ErrAbortHandler {
+				t.Errorf("expected the panic to propagate, got %v", rec)
+			}
+		}()
+		h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+	}()
+	if cb.State() != BreakerOpen {
+		t.Errorf("expected the panic to trip the breaker, got %s", cb.State())
+	}
+}
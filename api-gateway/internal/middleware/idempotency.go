@@ -0,0 +1,29 @@
+// Package middleware – idempotency.go replays responses to retried requests
+// that carry an Idempotency-Key header.
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/learnbot/shared/idempotency"
+)
+
+// PrincipalScope names the authenticated caller of r, a user or an API key,
+// so that idempotency keys are scoped per caller. It returns "" for
+// unauthenticated requests.
+func PrincipalScope(r *http.Request) string {
+	p := GetPrincipal(r)
+	if p == nil {
+		return ""
+	}
+	return string(p.Kind) + ":" + p.ID
+}
+
+// Idempotent returns middleware that handles requests with an
+// Idempotency-Key once per key and caller. Use it after the authentication
+// middleware, which sets the caller.
+func Idempotent(m *idempotency.Middleware) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return m.Wrap(PrincipalScope, next)
+	}
+}
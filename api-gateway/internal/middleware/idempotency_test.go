@@ -0,0 +1,57 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/learnbot/shared/idempotency"
+)
+
+func TestIdempotent_ScopedPerUser(t *testing.T) {
+	cfg := DefaultJWTConfig("test-secret")
+	token := func(userID string) string {
+		s, _, err := GenerateToken(cfg, userID, userID+"@example.com", nil, true)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return s
+	}
+	calls := 0
+	created := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprintf(w, "%s:%d", GetUserID(r), calls)
+	})
+	route := Chain(RequireAuth(cfg), Idempotent(idempotency.New(idempotency.Config{}, nil, nil)))(created)
+
+	post := func(bearer string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/api/profile/skills", strings.NewReader(`{}`))
+		req.Header.Set(idempotency.Header, "retry-1")
+		if bearer != "" {
+			req.Header.Set("Authorization", "Bearer "+bearer)
+		}
+		rec := httptest.NewRecorder()
+		route.ServeHTTP(rec, req)
+		return rec
+	}
+
+	alice, bob := token("alice"), token("bob")
+	first := post(alice)
+	retry := post(alice)
+	other := post(bob)
+	if retry.Body.String() != first.Body.String() || retry.Header().Get(idempotency.ReplayedHeader) != "true" {
+		t.Errorf("retry got %q, want the replayed %q", retry.Body, first.Body)
+	}
+	if other.Code != http.StatusCreated || other.Body.String() != "bob:2" {
+		t.Errorf("another user with the same key got %d %q, want a fresh 201", other.Code, other.Body)
+	}
+	if rec := post(""); rec.Code != http.StatusUnauthorized {
+		t.Errorf("anonymous request got %d, want 401", rec.Code)
+	}
+	if calls != 2 {
+		t.Errorf("handler called %d times, want 2", calls)
+	}
+}
@@ -0,0 +1,222 @@
+// Package middleware – lockout.go implements per-account throttling of
+// failed logins.
+package middleware
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/learnbot/shared/metrics"
+)
+
+var loginLockouts = metrics.NewCounter("gateway_login_lockouts_total",
+	"Accounts locked after repeated failed logins.")
+
+// LockoutConfig configures a LoginThrottle. Zero fields fall back to
+// DefaultLockoutConfig.
+type LockoutConfig struct {
+	// MaxFailures is the number of failed logins within Window after which
+	// an account is locked.
+	MaxFailures int
+
+	// Window is how long a failed login counts toward MaxFailures,
+	// measured from the first failure.
+	Window time.Duration
+
+	// Cooldown is how long a locked account rejects logins.
+	Cooldown time.Duration
+}
+
+// DefaultLockoutConfig locks an account for 15 minutes after 5 failed logins
+// within 15 minutes.
+func DefaultLockoutConfig() LockoutConfig {
+	return LockoutConfig{
+		MaxFailures: 5,
+		Window:      15 * time.Minute,
+		Cooldown:    15 * time.Minute,
+	}
+}
+
+// LoginAttempts is the failed login state of one account.
+type LoginAttempts struct {
+	// Failures is the number of failed logins in the current window.
+	Failures int
+
+	// WindowStart is the time of the first failure in the current window.
+	WindowStart time.Time
+
+	// LockedUntil is when the lockout ends; zero if the account was never
+	// locked.
+	LockedUntil time.Time
+}
+
+// LoginAttemptStore keeps failed login attempts per account key.
+type LoginAttemptStore interface {
+	// Get returns the attempts recorded for key, or the zero value.
+	Get(key string) (LoginAttempts, error)
+
+	// RecordFailure counts a failed login for key at now and returns the
+	// updated attempts. A window older than cfg.Window is started over.
+	// When the count reaches cfg.MaxFailures the key is locked until
+	// now+cfg.Cooldown and the count starts over. Concurrent calls for one
+	// key must not lose counts.
+	RecordFailure(key string, now time.Time, cfg LockoutConfig) (LoginAttempts, error)
+
+	// Reset forgets the failed logins of key. It does not end a lockout.
+	Reset(key string) error
+}
+
+// LoginThrottle locks accounts after repeated failed logins. Keys are
+// accounts as the client names them, e.g. normalized emails, and need not
+// exist, so that a lockout does not reveal whether an account does.
+//
+// Store errors are logged and let the login through: a throttle that is
+// unavailable must not lock everyone out.
+type LoginThrottle struct {
+	cfg    LockoutConfig
+	store  LoginAttemptStore
+	logger *slog.Logger
+	now    func() time.Time
+}
+
+// NewLoginThrottle creates a LoginThrottle. A nil store keeps attempts in
+// memory; a nil logger uses slog.Default.
+func NewLoginThrottle(cfg LockoutConfig, store LoginAttemptStore, logger *slog.Logger) *LoginThrottle {
+	def := DefaultLockoutConfig()
+	if cfg.MaxFailures <= 0 {
+		cfg.MaxFailures = def.MaxFailures
+	}
+	if cfg.Window <= 0 {
+		cfg.Window = def.Window
+	}
+	if cfg.Cooldown <= 0 {
+		cfg.Cooldown = def.Cooldown
+	}
+	if store == nil {
+		store = NewMemoryLoginAttemptStore()
+	}
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &LoginThrottle{cfg: cfg, store: store, logger: logger, now: time.Now}
+}
+
+// Locked returns how long the account key remains locked, or 0 if logins
+// are allowed.
+func (t *LoginThrottle) Locked(ctx context.Context, key string) time.Duration {
+	a, err := t.store.Get(key)
+	if err != nil {
+		t.logger.ErrorContext(ctx, "login throttle lookup failed", "account", accountHash(key), "error", err)
+		return 0
+	}
+	return max(a.LockedUntil.Sub(t.now()), 0)
+}
+
+// Failure records a failed login for key. If it locks the account, Failure
+// logs the lockout and returns its duration; otherwise it returns 0.
+func (t *LoginThrottle) Failure(ctx context.Context, key string) time.Duration {
+	now := t.now()
+	a, err := t.store.RecordFailure(key, now, t.cfg)
+	if err != nil {
+		t.logger.ErrorContext(ctx, "login throttle update failed", "account", accountHash(key), "error", err)
+		return 0
+	}
+	if !a.LockedUntil.After(now) {
+		return 0
+	}
+	loginLockouts.Inc()
+	t.logger.WarnContext(ctx, "account locked after failed logins",
+		"account", accountHash(key),
+		"failures", t.cfg.MaxFailures,
+		"locked_until", a.LockedUntil.UTC().Format(time.RFC3339))
+	return a.LockedUntil.Sub(now)
+}
+
+// Success forgets the failed logins of key after a successful login.
+func (t *LoginThrottle) Success(ctx context.Context, key string) {
+	if err := t.store.Reset(key); err != nil {
+		t.logger.ErrorContext(ctx, "login throttle reset failed", "account", accountHash(key), "error", err)
+	}
+}
+
+// accountHash identifies an account in logs without logging its email.
+func accountHash(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:8])
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// In-memory store (MVP – replace with a shared store when running replicas)
+// ─────────────────────────────────────────────────────────────────────────────
+
+// MemoryLoginAttemptStore is a thread-safe in-memory LoginAttemptStore.
+type MemoryLoginAttemptStore struct {
+	mu        sync.Mutex
+	attempts  map[string]*LoginAttempts
+	lastPrune time.Time
+}
+
+// NewMemoryLoginAttemptStore creates an empty MemoryLoginAttemptStore.
+func NewMemoryLoginAttemptStore() *MemoryLoginAttemptStore {
+	return &MemoryLoginAttemptStore{attempts: make(map[string]*LoginAttempts)}
+}
+
+// Get implements LoginAttemptStore.
+func (s *MemoryLoginAttemptStore) Get(key string) (LoginAttempts, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if a, ok := s.attempts[key]; ok {
+		return *a, nil
+	}
+	return LoginAttempts{}, nil
+}
+
+// RecordFailure implements LoginAttemptStore.
+func (s *MemoryLoginAttemptStore) RecordFailure(key string, now time.Time, cfg LockoutConfig) (LoginAttempts, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.pruneLocked(now, cfg.Window)
+
+	a, ok := s.attempts[key]
+	if !ok {
+		a = &LoginAttempts{}
+		s.attempts[key] = a
+	}
+	if a.Failures == 0 || now.Sub(a.WindowStart) >= cfg.Window {
+		a.Failures, a.WindowStart = 0, now
+	}
+	a.Failures++
+	if a.Failures >= cfg.MaxFailures {
+		a.Failures = 0
+		a.LockedUntil = now.Add(cfg.Cooldown)
+	}
+	return *a, nil
+}
+
+// Reset implements LoginAttemptStore.
+func (s *MemoryLoginAttemptStore) Reset(key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if a, ok := s.attempts[key]; ok {
+		a.Failures = 0
+	}
+	return nil
+}
+
+// pruneLocked drops entries whose window and lockout have both ended, at
+// most once per window so that failed logins stay cheap.
+func (s *MemoryLoginAttemptStore) pruneLocked(now time.Time, window time.Duration) {
+	if now.Sub(s.lastPrune) < window {
+		return
+	}
+	s.lastPrune = now
+	for key, a := range s.attempts {
+		if now.Sub(a.WindowStart) >= window && !a.LockedUntil.After(now) {
+			delete(s.attempts, key)
+		}
+	}
+}
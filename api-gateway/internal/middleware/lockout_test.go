@@ -0,0 +1,117 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/learnbot/shared/logging"
+)
+
+// newTestThrottle returns a throttle with a controllable clock that logs to
+// logs.
+func newTestThrottle(cfg LockoutConfig, logs *bytes.Buffer) (*LoginThrottle, *time.Time) {
+	lt := NewLoginThrottle(cfg, nil, logging.New("test-gateway", logs, logging.Config{}))
+	now := time.Date(2025, 1, 15, 10, 0, 0, 0, time.UTC)
+	lt.now = func() time.Time { return now }
+	return lt, &now
+}
+
+func TestLoginThrottle_LocksAfterMaxFailures(t *testing.T) {
+	var logs bytes.Buffer
+	lt, now := newTestThrottle(LockoutConfig{MaxFailures: 3, Window: time.Minute, Cooldown: 5 * time.Minute}, &logs)
+	ctx := context.Background()
+	before := loginLockouts.Value()
+
+	for i := 1; i < 3; i++ {
+		if wait := lt.Failure(ctx, "jane@example.com"); wait != 0 {
+			t.Fatalf("failure %d locked the account for %v", i, wait)
+		}
+		*now = now.Add(10 * time.Second)
+	}
+	if wait := lt.Failure(ctx, "jane@example.com"); wait != 5*time.Minute {
+		t.Fatalf("third failure: lockout = %v, want 5m", wait)
+	}
+	if got := loginLockouts.Value() - before; got != 1 {
+		t.Errorf("lockout counter increased by %v, want 1", got)
+	}
+	out := logs.String()
+	if !strings.Contains(out, "account locked after failed logins") || strings.Contains(out, "jane@example.com") {
+		t.Errorf("expected a lockout log line without the email, got %q", out)
+	}
+
+	*now = now.Add(2 * time.Minute)
+	if wait := lt.Locked(ctx, "jane@example.com"); wait != 3*time.Minute {
+		t.Errorf("Locked = %v, want 3m", wait)
+	}
+	if wait := lt.Locked(ctx, "other@example.com"); wait != 0 {
+		t.Errorf("other account locked for %v", wait)
+	}
+}
+
+func TestLoginThrottle_LockoutExpires(t *testing.T) {
+	lt, now := newTestThrottle(LockoutConfig{MaxFailures: 2, Window: time.Minute, Cooldown: time.Minute}, &bytes.Buffer{})
+	ctx := context.Background()
+	lt.Failure(ctx, "jane@example.com")
+	lt.Failure(ctx, "jane@example.com")
+
+	*now = now.Add(time.Minute - time.Second)
+	if wait := lt.Locked(ctx, "jane@example.com"); wait != time.Second {
+		t.Fatalf("Locked = %v, want 1s", wait)
+	}
+	*now = now.Add(time.Second)
+	if wait := lt.Locked(ctx, "jane@example.com"); wait != 0 {
+		t.Fatalf("expected the lockout to have ended, still locked for %v", wait)
+	}
+	// The count starts over after a lockout.
+	if wait := lt.Failure(ctx, "jane@example.com"); wait != 0 {
+		t.Errorf("first failure after the lockout locked the account for %v", wait)
+	}
+}
+
+func TestLoginThrottle_WindowExpires(t *testing.T) {
+	lt, now := newTestThrottle(LockoutConfig{MaxFailures: 2, Window: time.Minute, Cooldown: time.Minute}, &bytes.Buffer{})
+	ctx := context.Background()
+	lt.Failure(ctx, "jane@example.com")
+	*now = now.Add(time.Minute)
+	if wait := lt.Failure(ctx, "jane@example.com"); wait != 0 {
+		t.Errorf("a failure outside the window locked the account for %v", wait)
+	}
+}
+
+func TestLoginThrottle_SuccessResetsCount(t *testing.T) {
+	lt, _ := newTestThrottle(LockoutConfig{MaxFailures: 3, Window: time.Minute, Cooldown: time.Minute}, &bytes.Buffer{})
+	ctx := context.Background()
+	lt.Failure(ctx, "jane@example.com")
+	lt.Failure(ctx, "jane@example.com")
+	lt.Success(ctx, "jane@example.com")
+
+	for i := 1; i < 3; i++ {
+		if wait := lt.Failure(ctx, "jane@example.com"); wait != 0 {
+			t.Fatalf("failure %d after a successful login locked the account for %v", i, wait)
+		}
+	}
+	if wait := lt.Failure(ctx, "jane@example.com"); wait == 0 {
+		t.Error("expected the third failure after the reset to lock the account")
+	}
+}
+
+func TestMemoryLoginAttemptStore_Prunes(t *testing.T) {
+	s := NewMemoryLoginAttemptStore()
+	cfg := LockoutConfig{MaxFailures: 5, Window: time.Minute, Cooldown: time.Hour}
+	now := time.Date(2025, 1, 15, 10, 0, 0, 0, time.UTC)
+	s.RecordFailure("stale@example.com", now, cfg)
+	for i := 0; i < 5; i++ {
+		s.RecordFailure("locked@example.com", now, cfg)
+	}
+
+	s.RecordFailure("new@example.com", now.Add(2*time.Minute), cfg)
+	if _, ok := s.attempts["stale@example.com"]; ok {
+		t.Error("expected the stale entry to be pruned")
+	}
+	if a, _ := s.Get("locked@example.com"); a.LockedUntil.IsZero() {
+		t.Error("expected the locked entry to be kept")
+	}
+}
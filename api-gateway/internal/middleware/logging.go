@@ -2,9 +2,12 @@
 package middleware
 
 import (
-	"log"
+	"fmt"
+	"log/slog"
 	"net/http"
 	"time"
+
+	"github.com/learnbot/shared/redact"
 )
 
 // responseWriter wraps http.ResponseWriter to capture the status code.
@@ -29,9 +32,18 @@ func (rw *responseWriter) Write(b []byte) (int, error) {
 	return n, err
 }
 
-// Logger returns a middleware that logs each request with method, path,
-// status code, duration, and response size.
-func Logger(logger *log.Logger) func(http.Handler) http.Handler {
+// Unwrap returns the underlying ResponseWriter so http.ResponseController
+// can reach optional interfaces such as http.Flusher.
+func (rw *responseWriter) Unwrap() http.ResponseWriter {
+	return rw.ResponseWriter
+}
+
+// Logger returns a middleware that logs each request in one structured
+// line with method, path, status code, duration, response size, client IP,
+// user agent and, via the request context, the request ID. Email addresses,
+// phone numbers, national ID numbers and API keys are masked in the line.
+func Logger(logger *slog.Logger) func(http.Handler) http.Handler {
+	logger = redact.Logger(logger, logRedactor())
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			start := time.Now()
@@ -39,28 +51,30 @@ func Logger(logger *log.Logger) func(http.Handler) http.Handler {
 
 			next.ServeHTTP(rw, r)
 
-			duration := time.Since(start)
-			logger.Printf(
-				"[%s] %s %s %d %dB %v %s",
-				r.Method,
-				r.URL.Path,
-				extractClientIP(r),
-				rw.statusCode,
-				rw.written,
-				duration,
-				r.UserAgent(),
+			logger.InfoContext(r.Context(), "request",
+				"method", r.Method,
+				"path", r.URL.Path,
+				"status", rw.statusCode,
+				"duration_ms", time.Since(start).Milliseconds(),
+				"bytes", rw.written,
+				"client_ip", extractClientIP(r),
+				"user_agent", r.UserAgent(),
 			)
 		})
 	}
 }
 
 // Recovery returns a middleware that recovers from panics and returns 500.
-func Recovery(logger *log.Logger) func(http.Handler) http.Handler {
+// Panic values often hold request data, so the log line is redacted as in
+// Logger.
+func Recovery(logger *slog.Logger) func(http.Handler) http.Handler {
+	logger = redact.Logger(logger, logRedactor())
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			defer func() {
 				if rec := recover(); rec != nil {
-					logger.Printf("PANIC recovered: %v [%s %s]", rec, r.Method, r.URL.Path)
+					logger.ErrorContext(r.Context(), "panic recovered",
+						"panic", fmt.Sprint(rec), "method", r.Method, "path", r.URL.Path)
 					writeJSONError(w, http.StatusInternalServerError,
 						"INTERNAL_ERROR", "an unexpected error occurred")
 				}
@@ -91,7 +105,7 @@ func CORS(allowedOrigins []string) func(http.Handler) http.Handler {
 
 			w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
 			w.Header().Set("Access-Control-Allow-Headers",
-				"Content-Type, Authorization, X-Request-ID")
+				"Content-Type, Authorization, X-API-Key, X-Request-ID, X-No-Cache, Idempotency-Key")
 			w.Header().Set("Access-Control-Max-Age", "86400")
 
 			if r.Method == http.MethodOptions {
@@ -0,0 +1,142 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/learnbot/shared/logging"
+	"github.com/learnbot/shared/metrics"
+)
+
+// newTestGateway wires mux behind the same request ID, metrics and logging
+// middleware as cmd/server.
+func newTestGateway(t *testing.T, logs *bytes.Buffer) *httptest.Server {
+	t.Helper()
+	jwtCfg := DefaultJWTConfig("test-secret")
+	mux := http.NewServeMux()
+	mux.Handle("/api/profile", RequireAuth(jwtCfg)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})))
+	mux.HandleFunc("/health", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("ok"))
+	})
+	mux.HandleFunc("/api/jobs/", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("ok"))
+	})
+	mux.Handle("/metrics", metrics.Handler())
+
+	chain := Chain(
+		logging.RequestIDMiddleware,
+		metrics.Middleware(mux),
+		Recovery(logging.New("test-gateway", logs, logging.Config{})),
+		Logger(logging.New("test-gateway", logs, logging.Config{})),
+	)
+	srv := httptest.NewServer(chain(mux))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestLogger_StructuredLineWithRequestID(t *testing.T) {
+	var logs bytes.Buffer
+	srv := newTestGateway(t, &logs)
+
+	req, _ := http.NewRequest(http.MethodGet, srv.URL+"/health", nil)
+	req.Header.Set(logging.RequestIDHeader, "trace-42")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("GET /health: %v", err)
+	}
+	resp.Body.Close()
+
+	var rec map[string]interface{}
+	if err := json.Unmarshal(bytes.TrimSpace(logs.Bytes()), &rec); err != nil {
+		t.Fatalf("expected one JSON log line, got %q", logs.String())
+	}
+	for key, want := range map[string]interface{}{
+		"msg": "request", "method": "GET", "path": "/health",
+		"status": float64(http.StatusOK), "request_id": "trace-42", "service": "test-gateway",
+	} {
+		if rec[key] != want {
+			t.Errorf("%s: expected %v, got %v", key, want, rec[key])
+		}
+	}
+	if _, ok := rec["duration_ms"]; !ok {
+		t.Error("expected a duration_ms field")
+	}
+}
+
+func TestLogger_RedactsPersonalData(t *testing.T) {
+	var logs bytes.Buffer
+	srv := newTestGateway(t, &logs)
+
+	resp, err := http.Get(srv.URL + "/users/jane.doe@example.com")
+	if err != nil {
+		t.Fatalf("GET: %v", err)
+	}
+	resp.Body.Close()
+
+	var rec map[string]interface{}
+	if err := json.Unmarshal(bytes.TrimSpace(logs.Bytes()), &rec); err != nil {
+		t.Fatalf("expected one JSON log line, got %q", logs.String())
+	}
+	if rec["path"] != "/users/[email]" {
+		t.Errorf("path = %v, want the email masked", rec["path"])
+	}
+	if rec["client_ip"] != "127.0.0.1" {
+		t.Errorf("client_ip = %v, want it unchanged", rec["client_ip"])
+	}
+}
+
+func TestRecovery_RedactsPanicValue(t *testing.T) {
+	var logs bytes.Buffer
+	h := Recovery(logging.New("test-gateway", &logs, logging.Config{}))(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic(`decode body {"email":"jane.doe@example.com","phone":"+62 812-3456-7890"}`)
+	}))
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/api/profile", nil))
+
+	if w.Code != http.StatusInternalServerError {
+		t.Errorf("expected status 500, got %d", w.Code)
+	}
+	out := logs.String()
+	if strings.Contains(out, "jane.doe@example.com") || strings.Contains(out, "3456-7890") {
+		t.Errorf("personal data survived in the log: %s", out)
+	}
+	if !strings.Contains(out, `[email]`) || !strings.Contains(out, `[phone]`) {
+		t.Errorf("expected placeholders in the log: %s", out)
+	}
+}
+
+func TestMetrics_ScrapeAfterRequests(t *testing.T) {
+	srv := newTestGateway(t, &bytes.Buffer{})
+
+	for _, path := range []string{"/api/jobs/1", "/api/jobs/2", "/api/profile"} {
+		resp, err := http.Get(srv.URL + path)
+		if err != nil {
+			t.Fatalf("GET %s: %v", path, err)
+		}
+		resp.Body.Close()
+	}
+
+	resp, err := http.Get(srv.URL + "/metrics")
+	if err != nil {
+		t.Fatalf("GET /metrics: %v", err)
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+	for _, want := range []string{
+		"# TYPE http_requests_total counter",
+		"# TYPE http_request_duration_seconds histogram",
+		`http_requests_total{method="GET",route="/api/jobs/",status="200"} 2`,
+		`http_requests_total{method="GET",route="/api/profile",status="401"} 1`,
+	} {
+		if !strings.Contains(string(body), want) {
+			t.Errorf("metrics output missing %q", want)
+		}
+	}
+}
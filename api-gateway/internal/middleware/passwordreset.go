@@ -0,0 +1,122 @@
+// Package middleware – passwordreset.go implements single-use, time-limited
+// password reset tokens.
+package middleware
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"sync"
+	"time"
+)
+
+// DefaultPasswordResetDuration is how long a password reset token is valid.
+const DefaultPasswordResetDuration = 30 * time.Minute
+
+var (
+	// ErrResetTokenInvalid is returned for an unknown or already used
+	// password reset token.
+	ErrResetTokenInvalid = errors.New("invalid password reset token")
+
+	// ErrResetTokenExpired is returned for a password reset token past its
+	// expiry.
+	ErrResetTokenExpired = errors.New("password reset token expired")
+)
+
+// PasswordResetToken is a password reset token as stored: only its hash is
+// kept.
+type PasswordResetToken struct {
+	// Hash is the SHA-256 hex digest of the token.
+	Hash string
+
+	// UserID is the user whose password the token resets.
+	UserID string
+
+	// ExpiresAt is when the token stops being accepted.
+	ExpiresAt time.Time
+}
+
+// PasswordResetStore persists password reset token hashes.
+type PasswordResetStore interface {
+	// Save stores a newly issued token.
+	Save(token PasswordResetToken) error
+
+	// Consume returns the token with the given hash and deletes it along
+	// with every other token of the same user, so that a token works once
+	// and a reset invalidates the links sent before it.
+	Consume(hash string, now time.Time) (PasswordResetToken, error)
+}
+
+// NewPasswordResetToken generates a random password reset token and returns
+// it with the hash it is stored under. It does not store the token, so that
+// callers can do the same work whether or not the account exists.
+func NewPasswordResetToken() (token, hash string, err error) {
+	raw := make([]byte, 32)
+	if _, err := rand.Read(raw); err != nil {
+		return "", "", err
+	}
+	token = base64.RawURLEncoding.EncodeToString(raw)
+	return token, HashPasswordResetToken(token), nil
+}
+
+// HashPasswordResetToken returns the SHA-256 hex digest under which a
+// password reset token is stored.
+func HashPasswordResetToken(token string) string {
+	return HashRefreshToken(token)
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// In-memory store (MVP – replace with a shared store when running replicas)
+// ─────────────────────────────────────────────────────────────────────────────
+
+// MemoryPasswordResetStore is a thread-safe in-memory PasswordResetStore.
+type MemoryPasswordResetStore struct {
+	mu     sync.Mutex
+	tokens map[string]PasswordResetToken // keyed by hash
+}
+
+// NewMemoryPasswordResetStore creates an empty MemoryPasswordResetStore.
+func NewMemoryPasswordResetStore() *MemoryPasswordResetStore {
+	return &MemoryPasswordResetStore{tokens: make(map[string]PasswordResetToken)}
+}
+
+// Save implements PasswordResetStore.
+func (s *MemoryPasswordResetStore) Save(token PasswordResetToken) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.pruneLocked(time.Now())
+	s.tokens[token.Hash] = token
+	return nil
+}
+
+// Consume implements PasswordResetStore.
+func (s *MemoryPasswordResetStore) Consume(hash string, now time.Time) (PasswordResetToken, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	token, ok := s.tokens[hash]
+	if !ok {
+		return PasswordResetToken{}, ErrResetTokenInvalid
+	}
+	delete(s.tokens, hash)
+	if !now.Before(token.ExpiresAt) {
+		return PasswordResetToken{}, ErrResetTokenExpired
+	}
+	for h, t := range s.tokens {
+		if t.UserID == token.UserID {
+			delete(s.tokens, h)
+		}
+	}
+	return token, nil
+}
+
+// pruneLocked drops tokens that expired more than a minute ago. Recently
+// expired tokens are kept so that Consume can report them as expired.
+func (s *MemoryPasswordResetStore) pruneLocked(now time.Time) {
+	cutoff := now.Add(-time.Minute)
+	for hash, t := range s.tokens {
+		if t.ExpiresAt.Before(cutoff) {
+			delete(s.tokens, hash)
+		}
+	}
+}
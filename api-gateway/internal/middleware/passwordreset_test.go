@@ -0,0 +1,71 @@
+package middleware
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestNewPasswordResetToken(t *testing.T) {
+	token, hash, err := NewPasswordResetToken()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(token) < 40 || hash != HashPasswordResetToken(token) || hash == token {
+		t.Errorf("unexpected token %q with hash %q", token, hash)
+	}
+	other, _, _ := NewPasswordResetToken()
+	if other == token {
+		t.Error("two tokens are equal")
+	}
+}
+
+func TestMemoryPasswordResetStore_Expiry(t *testing.T) {
+	s := NewMemoryPasswordResetStore()
+	now := time.Now()
+	s.Save(PasswordResetToken{Hash: "h1", UserID: "u1", ExpiresAt: now.Add(DefaultPasswordResetDuration)})
+
+	if _, err := s.Consume("h1", now.Add(DefaultPasswordResetDuration)); !errors.Is(err, ErrResetTokenExpired) {
+		t.Fatalf("Consume at expiry: err = %v, want ErrResetTokenExpired", err)
+	}
+	// An expired token is gone, not merely rejected.
+	if _, err := s.Consume("h1", now); !errors.Is(err, ErrResetTokenInvalid) {
+		t.Errorf("Consume after expiry: err = %v, want ErrResetTokenInvalid", err)
+	}
+}
+
+func TestMemoryPasswordResetStore_SingleUse(t *testing.T) {
+	s := NewMemoryPasswordResetStore()
+	now := time.Now()
+	expires := now.Add(time.Minute)
+	s.Save(PasswordResetToken{Hash: "h1", UserID: "u1", ExpiresAt: expires})
+	s.Save(PasswordResetToken{Hash: "h2", UserID: "u1", ExpiresAt: expires})
+	s.Save(PasswordResetToken{Hash: "h3", UserID: "u2", ExpiresAt: expires})
+
+	tok, err := s.Consume("h1", now)
+	if err != nil || tok.UserID != "u1" {
+		t.Fatalf("Consume = %+v, %v", tok, err)
+	}
+	if _, err := s.Consume("h1", now); !errors.Is(err, ErrResetTokenInvalid) {
+		t.Errorf("reused token: err = %v, want ErrResetTokenInvalid", err)
+	}
+	// The user's other tokens are invalidated; other users' are not.
+	if _, err := s.Consume("h2", now); !errors.Is(err, ErrResetTokenInvalid) {
+		t.Errorf("sibling token: err = %v, want ErrResetTokenInvalid", err)
+	}
+	if _, err := s.Consume("h3", now); err != nil {
+		t.Errorf("other user's token: err = %v", err)
+	}
+}
+
+func TestMemoryPasswordResetStore_Prune(t *testing.T) {
+	s := NewMemoryPasswordResetStore()
+	s.Save(PasswordResetToken{Hash: "old", UserID: "u1", ExpiresAt: time.Now().Add(-time.Hour)})
+	s.Save(PasswordResetToken{Hash: "new", UserID: "u2", ExpiresAt: time.Now().Add(time.Hour)})
+	if _, ok := s.tokens["old"]; ok {
+		t.Error("long expired token was not pruned")
+	}
+	if _, ok := s.tokens["new"]; !ok {
+		t.Error("live token was pruned")
+	}
+}
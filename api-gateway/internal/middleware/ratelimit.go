@@ -2,100 +2,328 @@
 package middleware
 
 import (
+	"fmt"
+	"math"
+	"net"
 	"net/http"
+	"strconv"
+	"strings"
 	"sync"
 	"time"
 )
 
-// RateLimiter implements a per-IP token bucket rate limiter.
+// RateLimit is a token-bucket limit.
+type RateLimit struct {
+	// RequestsPerSecond is the sustained request rate.
+	RequestsPerSecond float64
+
+	// Burst is the maximum burst size (capacity of the bucket).
+	Burst float64
+}
+
+// RouteRateLimit applies a limit to requests whose path starts with
+// PathPrefix. Each route group has its own buckets.
+type RouteRateLimit struct {
+	PathPrefix string
+	RateLimit
+}
+
+// RateLimitConfig configures a RateLimiter.
+type RateLimitConfig struct {
+	// Default applies to requests not matching any route group.
+	Default RateLimit
+
+	// Routes are stricter or looser limits per route group. The longest
+	// matching PathPrefix wins.
+	Routes []RouteRateLimit
+
+	// JWT, when set, keys requests carrying a valid Bearer token by user
+	// ID; other requests are keyed by client IP.
+	JWT *JWTConfig
+
+	// TrustedProxies lists the IPs or CIDR ranges of reverse proxies whose
+	// X-Forwarded-For and X-Real-IP headers are honoured. Forwarding
+	// headers from any other peer are ignored.
+	TrustedProxies []string
+
+	// IdleTimeout is how long an unused bucket is kept before eviction.
+	IdleTimeout time.Duration
+}
+
+// DefaultRateLimitConfig returns the gateway's limits: 10 requests/second
+// with a burst of 30 by default, 1 request/second with a burst of 5 on the
+// auth endpoints, and 20 requests/second with a burst of 60 for resource
+// reads.
+func DefaultRateLimitConfig(jwtCfg JWTConfig) RateLimitConfig {
+	return RateLimitConfig{
+		Default: RateLimit{RequestsPerSecond: 10, Burst: 30},
+		Routes: []RouteRateLimit{
+			{PathPrefix: "/api/auth/", RateLimit: RateLimit{RequestsPerSecond: 1, Burst: 5}},
+			{PathPrefix: "/api/resources", RateLimit: RateLimit{RequestsPerSecond: 20, Burst: 60}},
+		},
+		JWT:         &jwtCfg,
+		IdleTimeout: 5 * time.Minute,
+	}
+}
+
+// RateLimiter implements a token bucket rate limiter keyed per client: by
+// authenticated user when a JWT is configured, otherwise by client IP.
 type RateLimiter struct {
 	mu       sync.Mutex
 	buckets  map[string]*tokenBucket
-	rate     float64 // tokens per second
-	capacity float64 // max tokens
+	limits   RateLimit
+	routes   []RouteRateLimit
+	jwt      *JWTConfig
+	trusted  []*net.IPNet
 	cleanup  time.Duration
+	now      func() time.Time
+	stop     chan struct{}
+	stopOnce sync.Once
 }
 
-// tokenBucket holds the state for a single IP's rate limit.
+// tokenBucket holds the state for a single client's rate limit.
 type tokenBucket struct {
 	tokens   float64
 	lastSeen time.Time
 }
 
-// NewRateLimiter creates a new RateLimiter.
+// NewRateLimiter creates a new RateLimiter keyed by client IP.
 //
 // Parameters:
 //   - requestsPerSecond: the sustained request rate allowed per IP
 //   - burst: the maximum burst size (capacity of the bucket)
 func NewRateLimiter(requestsPerSecond, burst float64) *RateLimiter {
+	rl, _ := NewRateLimiterWithConfig(RateLimitConfig{
+		Default: RateLimit{RequestsPerSecond: requestsPerSecond, Burst: burst},
+	})
+	return rl
+}
+
+// NewRateLimiterWithConfig creates a RateLimiter from cfg. It returns an
+// error if a trusted proxy is not a valid IP or CIDR range.
+func NewRateLimiterWithConfig(cfg RateLimitConfig) (*RateLimiter, error) {
 	rl := &RateLimiter{
-		buckets:  make(map[string]*tokenBucket),
-		rate:     requestsPerSecond,
-		capacity: burst,
-		cleanup:  5 * time.Minute,
+		buckets: make(map[string]*tokenBucket),
+		limits:  cfg.Default,
+		routes:  append([]RouteRateLimit(nil), cfg.Routes...),
+		jwt:     cfg.JWT,
+		cleanup: cfg.IdleTimeout,
+		now:     time.Now,
+		stop:    make(chan struct{}),
+	}
+	if rl.cleanup <= 0 {
+		rl.cleanup = 5 * time.Minute
+	}
+	for _, p := range cfg.TrustedProxies {
+		p = trimSpace(p)
+		if p == "" {
+			continue
+		}
+		if !strings.Contains(p, "/") {
+			if ip := net.ParseIP(p); ip != nil && ip.To4() != nil {
+				p += "/32"
+			} else {
+				p += "/128"
+			}
+		}
+		_, ipNet, err := net.ParseCIDR(p)
+		if err != nil {
+			return nil, fmt.Errorf("invalid trusted proxy %q: %w", p, err)
+		}
+		rl.trusted = append(rl.trusted, ipNet)
 	}
 	go rl.cleanupLoop()
-	return rl
+	return rl, nil
+}
+
+// Stop ends the background eviction of idle buckets.
+func (rl *RateLimiter) Stop() {
+	rl.stopOnce.Do(func() { close(rl.stop) })
 }
 
-// Allow returns true if the request from the given IP is allowed.
-func (rl *RateLimiter) Allow(ip string) bool {
+// Allow returns true if a request from the given client key is allowed
+// under the default limit.
+func (rl *RateLimiter) Allow(key string) bool {
+	allowed, _ := rl.take(key, rl.limits)
+	return allowed
+}
+
+// rateLimitStatus describes a bucket after a request was counted.
+type rateLimitStatus struct {
+	remaining  int
+	reset      time.Duration // until the bucket is full again
+	retryAfter time.Duration // until the next request is allowed, if denied
+}
+
+// take consumes a token from key's bucket if one is available.
+func (rl *RateLimiter) take(key string, limit RateLimit) (bool, rateLimitStatus) {
 	rl.mu.Lock()
 	defer rl.mu.Unlock()
 
-	now := time.Now()
-	bucket, exists := rl.buckets[ip]
+	now := rl.now()
+	bucket, exists := rl.buckets[key]
 	if !exists {
-		rl.buckets[ip] = &tokenBucket{
-			tokens:   rl.capacity - 1, // consume one token immediately
-			lastSeen: now,
-		}
-		return true
+		bucket = &tokenBucket{tokens: limit.Burst, lastSeen: now}
+		rl.buckets[key] = bucket
 	}
 
 	// Refill tokens based on elapsed time.
 	elapsed := now.Sub(bucket.lastSeen).Seconds()
-	bucket.tokens += elapsed * rl.rate
-	if bucket.tokens > rl.capacity {
-		bucket.tokens = rl.capacity
+	bucket.tokens += elapsed * limit.RequestsPerSecond
+	if bucket.tokens > limit.Burst {
+		bucket.tokens = limit.Burst
 	}
 	bucket.lastSeen = now
 
-	if bucket.tokens < 1 {
-		return false
+	allowed := bucket.tokens >= 1
+	if allowed {
+		bucket.tokens--
 	}
-	bucket.tokens--
-	return true
+
+	status := rateLimitStatus{
+		remaining: int(math.Floor(bucket.tokens)),
+		reset:     tokensDuration(limit.Burst-bucket.tokens, limit.RequestsPerSecond),
+	}
+	if !allowed {
+		status.retryAfter = tokensDuration(1-bucket.tokens, limit.RequestsPerSecond)
+	}
+	return allowed, status
 }
 
-// Middleware returns an HTTP middleware that applies rate limiting.
-// Returns 429 Too Many Requests when the limit is exceeded.
+// tokensDuration is the time needed to refill n tokens at rate per second.
+func tokensDuration(n, rate float64) time.Duration {
+	if n <= 0 {
+		return 0
+	}
+	if rate <= 0 {
+		return time.Duration(math.MaxInt64)
+	}
+	return time.Duration(n / rate * float64(time.Second))
+}
+
+// Middleware returns an HTTP middleware that applies rate limiting and sets
+// the RateLimit-Limit, RateLimit-Remaining and RateLimit-Reset headers.
+// Returns 429 Too Many Requests with Retry-After when the limit is exceeded.
 func (rl *RateLimiter) Middleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		ip := extractClientIP(r)
-		if !rl.Allow(ip) {
-			w.Header().Set("Retry-After", "1")
-			writeJSONError(w, http.StatusTooManyRequests, "RATE_LIMIT_EXCEEDED",
-				"too many requests, please slow down")
-			return
+		group, limit := rl.routeLimit(r.URL.Path)
+		allowed, status := rl.take(group+"|"+rl.clientKey(r), limit)
+		if writeRateLimitHeaders(w, limit, allowed, status) {
+			next.ServeHTTP(w, r)
 		}
-		next.ServeHTTP(w, r)
 	})
 }
 
+// writeRateLimitHeaders sets the rate limit headers for a counted request.
+// If the request was denied it also writes the 429 response and returns
+// false.
+func writeRateLimitHeaders(w http.ResponseWriter, limit RateLimit, allowed bool, status rateLimitStatus) bool {
+	h := w.Header()
+	h.Set("RateLimit-Limit", strconv.Itoa(int(limit.Burst)))
+	h.Set("RateLimit-Remaining", strconv.Itoa(status.remaining))
+	h.Set("RateLimit-Reset", strconv.Itoa(ceilSeconds(status.reset)))
+	if !allowed {
+		h.Set("Retry-After", strconv.Itoa(max(1, ceilSeconds(status.retryAfter))))
+		writeJSONError(w, http.StatusTooManyRequests, "RATE_LIMIT_EXCEEDED",
+			"too many requests, please slow down")
+		return false
+	}
+	return true
+}
+
+func ceilSeconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
+
+// routeLimit returns the route group and limit for a request path.
+func (rl *RateLimiter) routeLimit(path string) (string, RateLimit) {
+	group, limit, best := "", rl.limits, -1
+	for _, route := range rl.routes {
+		if strings.HasPrefix(path, route.PathPrefix) && len(route.PathPrefix) > best {
+			group, limit, best = route.PathPrefix, route.RateLimit, len(route.PathPrefix)
+		}
+	}
+	return group, limit
+}
+
+// clientKey identifies the client of a request: "user:<id>" for a valid
+// JWT when one is configured, "ip:<address>" otherwise.
+func (rl *RateLimiter) clientKey(r *http.Request) string {
+	if rl.jwt != nil {
+		if tokenStr := extractBearerToken(r); tokenStr != "" {
+			if claims, err := ParseToken(*rl.jwt, tokenStr); err == nil && claims.UserID != "" {
+				return "user:" + claims.UserID
+			}
+		}
+	}
+	return "ip:" + rl.clientIP(r)
+}
+
+// clientIP returns the request's client IP. Forwarding headers are only
+// honoured when the direct peer is a trusted proxy; X-Forwarded-For is then
+// read right to left, skipping trusted proxies, so a client cannot spoof its
+// address by sending its own header.
+func (rl *RateLimiter) clientIP(r *http.Request) string {
+	peer := r.RemoteAddr
+	if host, _, err := net.SplitHostPort(peer); err == nil {
+		peer = host
+	}
+	if !rl.isTrusted(peer) {
+		return peer
+	}
+
+	if xff := r.Header.Get("X-Forwarded-For"); xff != "" {
+		hops := splitAndTrim(xff, ",")
+		for i := len(hops) - 1; i >= 0; i-- {
+			if hops[i] != "" && !rl.isTrusted(hops[i]) {
+				return hops[i]
+			}
+		}
+	}
+	if xri := trimSpace(r.Header.Get("X-Real-IP")); xri != "" {
+		return xri
+	}
+	return peer
+}
+
+func (rl *RateLimiter) isTrusted(addr string) bool {
+	ip := net.ParseIP(addr)
+	if ip == nil {
+		return false
+	}
+	for _, n := range rl.trusted {
+		if n.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
 // cleanupLoop periodically removes stale buckets to prevent memory leaks.
 func (rl *RateLimiter) cleanupLoop() {
 	ticker := time.NewTicker(rl.cleanup)
 	defer ticker.Stop()
-	for range ticker.C {
-		rl.mu.Lock()
-		cutoff := time.Now().Add(-rl.cleanup)
-		for ip, bucket := range rl.buckets {
-			if bucket.lastSeen.Before(cutoff) {
-				delete(rl.buckets, ip)
-			}
+	for {
+		select {
+		case <-rl.stop:
+			return
+		case <-ticker.C:
+			rl.evictIdle()
+		}
+	}
+}
+
+// evictIdle removes buckets unused for longer than the idle timeout. An
+// evicted client starts again with a full bucket, which is what it would
+// have refilled to anyway.
+func (rl *RateLimiter) evictIdle() {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+	cutoff := rl.now().Add(-rl.cleanup)
+	for key, bucket := range rl.buckets {
+		if bucket.lastSeen.Before(cutoff) {
+			delete(rl.buckets, key)
 		}
-		rl.mu.Unlock()
 	}
 }
 
@@ -0,0 +1,200 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newTestLimiter returns a limiter with a controllable clock.
+func newTestLimiter(t *testing.T, cfg RateLimitConfig) (*RateLimiter, *time.Time) {
+	t.Helper()
+	rl, err := NewRateLimiterWithConfig(cfg)
+	if err != nil {
+		t.Fatalf("NewRateLimiterWithConfig: %v", err)
+	}
+	t.Cleanup(rl.Stop)
+	now := time.Date(2025, 1, 15, 10, 0, 0, 0, time.UTC)
+	rl.now = func() time.Time { return now }
+	return rl, &now
+}
+
+func serve(rl *RateLimiter, r *http.Request) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	rl.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})).ServeHTTP(w, r)
+	return w
+}
+
+func TestRateLimiter_KeysByUserThenIP(t *testing.T) {
+	jwtCfg := DefaultJWTConfig("test-secret")
+	rl, _ := newTestLimiter(t, RateLimitConfig{
+		Default: RateLimit{RequestsPerSecond: 1, Burst: 2},
+		JWT:     &jwtCfg,
+	})
+
+	alice, _, _ := GenerateToken(jwtCfg, "alice", "alice@example.com", nil, true)
+	bob, _, _ := GenerateToken(jwtCfg, "bob", "bob@example.com", nil, true)
+	request := func(token string) *http.Request {
+		r := httptest.NewRequest(http.MethodGet, "/api/jobs", nil)
+		r.RemoteAddr = "203.0.113.7:51000"
+		if token != "" {
+			r.Header.Set("Authorization", "Bearer "+token)
+		}
+		return r
+	}
+
+	// Alice exhausts her bucket; Bob and anonymous callers behind the same
+	// IP are unaffected.
+	for i := 0; i < 2; i++ {
+		if w := serve(rl, request(alice)); w.Code != http.StatusOK {
+			t.Fatalf("alice request %d: expected 200, got %d", i+1, w.Code)
+		}
+	}
+	if w := serve(rl, request(alice)); w.Code != http.StatusTooManyRequests {
+		t.Errorf("expected alice to be limited, got %d", w.Code)
+	}
+	if w := serve(rl, request(bob)); w.Code != http.StatusOK {
+		t.Errorf("expected bob to be allowed, got %d", w.Code)
+	}
+	if w := serve(rl, request("")); w.Code != http.StatusOK {
+		t.Errorf("expected an anonymous request to be allowed, got %d", w.Code)
+	}
+	// An invalid token falls back to the (shared) IP bucket.
+	serve(rl, request("garbage"))
+	if w := serve(rl, request("garbage")); w.Code != http.StatusTooManyRequests {
+		t.Errorf("expected the IP bucket to be exhausted, got %d", w.Code)
+	}
+}
+
+func TestRateLimiter_RouteGroupsAndHeaders(t *testing.T) {
+	rl, now := newTestLimiter(t, RateLimitConfig{
+		Default: RateLimit{RequestsPerSecond: 10, Burst: 30},
+		Routes: []RouteRateLimit{
+			{PathPrefix: "/api/auth/", RateLimit: RateLimit{RequestsPerSecond: 0.5, Burst: 2}},
+		},
+	})
+	request := func(path string) *http.Request {
+		r := httptest.NewRequest(http.MethodPost, path, nil)
+		r.RemoteAddr = "198.51.100.1:4000"
+		return r
+	}
+
+	w := serve(rl, request("/api/auth/login"))
+	if got := w.Header().Get("RateLimit-Limit"); got != "2" {
+		t.Errorf("RateLimit-Limit = %q, want 2", got)
+	}
+	if got := w.Header().Get("RateLimit-Remaining"); got != "1" {
+		t.Errorf("RateLimit-Remaining = %q, want 1", got)
+	}
+	if got := w.Header().Get("RateLimit-Reset"); got != "2" {
+		t.Errorf("RateLimit-Reset = %q, want 2", got)
+	}
+
+	serve(rl, request("/api/auth/login"))
+	w = serve(rl, request("/api/auth/register"))
+	if w.Code != http.StatusTooManyRequests || w.Header().Get("Retry-After") != "2" || w.Header().Get("RateLimit-Remaining") != "0" {
+		t.Errorf("expected 429 with Retry-After 2, got %d %v", w.Code, w.Header())
+	}
+
+	// Other routes have their own, looser bucket.
+	if w := serve(rl, request("/api/resources")); w.Code != http.StatusOK || w.Header().Get("RateLimit-Limit") != "30" {
+		t.Errorf("expected the default limit outside the auth group, got %d %v", w.Code, w.Header())
+	}
+
+	*now = now.Add(2 * time.Second)
+	if w := serve(rl, request("/api/auth/login")); w.Code != http.StatusOK {
+		t.Errorf("expected a token after refill, got %d", w.Code)
+	}
+}
+
+func TestRateLimiter_TrustedProxies(t *testing.T) {
+	rl, _ := newTestLimiter(t, RateLimitConfig{
+		Default:        RateLimit{RequestsPerSecond: 1, Burst: 1},
+		TrustedProxies: []string{"10.0.0.0/8", "192.0.2.10"},
+	})
+
+	tests := []struct {
+		remote, xff, want string
+	}{
+		// Untrusted peers cannot spoof their address.
+		{"203.0.113.7:1234", "1.2.3.4", "203.0.113.7"},
+		// The rightmost untrusted hop is the client.
+		{"10.1.2.3:1234", "1.2.3.4, 198.51.100.9, 192.0.2.10", "198.51.100.9"},
+		{"192.0.2.10:80", "", "192.0.2.10"},
+	}
+	for _, tt := range tests {
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		r.RemoteAddr = tt.remote
+		if tt.xff != "" {
+			r.Header.Set("X-Forwarded-For", tt.xff)
+		}
+		if got := rl.clientIP(r); got != tt.want {
+			t.Errorf("clientIP(%s, %q) = %q, want %q", tt.remote, tt.xff, got, tt.want)
+		}
+	}
+
+	if _, err := NewRateLimiterWithConfig(RateLimitConfig{TrustedProxies: []string{"not-an-ip"}}); err == nil {
+		t.Error("expected an error for an invalid trusted proxy")
+	}
+}
+
+func TestRateLimiter_EvictsIdleBuckets(t *testing.T) {
+	rl, now := newTestLimiter(t, RateLimitConfig{
+		Default:     RateLimit{RequestsPerSecond: 1, Burst: 1},
+		IdleTimeout: time.Minute,
+	})
+	rl.Allow("a")
+	*now = now.Add(30 * time.Second)
+	rl.Allow("b")
+	*now = now.Add(45 * time.Second)
+
+	rl.evictIdle()
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+	if _, ok := rl.buckets["a"]; ok {
+		t.Error("expected the idle bucket to be evicted")
+	}
+	if _, ok := rl.buckets["b"]; !ok {
+		t.Error("expected the recently used bucket to be kept")
+	}
+}
+
+func TestRateLimiter_Concurrent(t *testing.T) {
+	rl, err := NewRateLimiterWithConfig(RateLimitConfig{
+		Default: RateLimit{RequestsPerSecond: 0.001, Burst: 50},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rl.Stop()
+
+	const goroutines, perGoroutine = 20, 50
+	var allowed [2]atomic.Int64
+	var wg sync.WaitGroup
+	for g := 0; g < goroutines; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			key := []string{"user:a", "user:b"}[g%2]
+			for i := 0; i < perGoroutine; i++ {
+				if rl.Allow(key) {
+					allowed[g%2].Add(1)
+				}
+			}
+		}(g)
+	}
+	wg.Wait()
+
+	// Each client gets exactly its burst: the refill rate is negligible
+	// over the test's duration.
+	for i := range allowed {
+		if n := allowed[i].Load(); n != 50 {
+			t.Errorf("client %d: %d requests allowed, want 50", i, n)
+		}
+	}
+}
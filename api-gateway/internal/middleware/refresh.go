@@ -0,0 +1,201 @@
+// Package middleware – refresh.go implements opaque, rotating refresh tokens.
+package middleware
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"sync"
+	"time"
+)
+
+var (
+	// ErrRefreshTokenInvalid is returned for an unknown or revoked refresh token.
+	ErrRefreshTokenInvalid = errors.New("invalid refresh token")
+
+	// ErrRefreshTokenExpired is returned for a refresh token past its expiry.
+	ErrRefreshTokenExpired = errors.New("refresh token expired")
+
+	// ErrRefreshTokenReused is returned when an already rotated refresh token
+	// is presented again. The whole token family is revoked, since either the
+	// client or an attacker holds a stolen token.
+	ErrRefreshTokenReused = errors.New("refresh token already used")
+)
+
+// RefreshToken is a refresh token as stored: only its hash is kept.
+type RefreshToken struct {
+	// Hash is the SHA-256 hex digest of the token.
+	Hash string
+
+	// UserID is the user the token was issued to.
+	UserID string
+
+	// FamilyID groups a login's chain of rotated tokens, so that logout
+	// or reuse detection revokes all of them.
+	FamilyID string
+
+	// ExpiresAt is when the token stops being accepted.
+	ExpiresAt time.Time
+}
+
+// RefreshTokenStore persists refresh token hashes.
+type RefreshTokenStore interface {
+	// Save stores a newly issued refresh token.
+	Save(token RefreshToken) error
+
+	// Consume marks the token with the given hash as used and returns it.
+	// Each token can be consumed once; a second attempt returns
+	// ErrRefreshTokenReused and revokes the token's family.
+	Consume(hash string, now time.Time) (RefreshToken, error)
+
+	// RevokeFamily revokes every token in a family. Unknown families are
+	// ignored.
+	RevokeFamily(familyID string) error
+
+	// RevokeUser revokes every token issued to a user, ending all of their
+	// sessions. Unknown users are ignored.
+	RevokeUser(userID string) error
+
+	// Lookup returns the token with the given hash without consuming it.
+	Lookup(hash string) (RefreshToken, error)
+}
+
+// IssueRefreshToken generates a refresh token for userID in the given family
+// (a new family if empty), saves its hash in store and returns the raw token.
+func IssueRefreshToken(cfg JWTConfig, store RefreshTokenStore, userID, familyID string) (string, time.Time, error) {
+	raw := make([]byte, 32)
+	if _, err := rand.Read(raw); err != nil {
+		return "", time.Time{}, err
+	}
+	if familyID == "" {
+		id := make([]byte, 16)
+		if _, err := rand.Read(id); err != nil {
+			return "", time.Time{}, err
+		}
+		familyID = hex.EncodeToString(id)
+	}
+
+	token := base64.RawURLEncoding.EncodeToString(raw)
+	expiresAt := time.Now().Add(cfg.RefreshTokenDuration)
+	err := store.Save(RefreshToken{
+		Hash:      HashRefreshToken(token),
+		UserID:    userID,
+		FamilyID:  familyID,
+		ExpiresAt: expiresAt,
+	})
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	return token, expiresAt, nil
+}
+
+// HashRefreshToken returns the SHA-256 hex digest under which a refresh
+// token is stored.
+func HashRefreshToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// In-memory store (MVP – replace with a shared store when running replicas)
+// ─────────────────────────────────────────────────────────────────────────────
+
+// memoryRefreshEntry is a stored token and whether it has been rotated.
+type memoryRefreshEntry struct {
+	token RefreshToken
+	used  bool
+}
+
+// MemoryRefreshTokenStore is a thread-safe in-memory RefreshTokenStore.
+// Used tokens are kept until they expire so that reuse can be detected.
+type MemoryRefreshTokenStore struct {
+	mu     sync.Mutex
+	tokens map[string]*memoryRefreshEntry // keyed by hash
+}
+
+// NewMemoryRefreshTokenStore creates an empty MemoryRefreshTokenStore.
+func NewMemoryRefreshTokenStore() *MemoryRefreshTokenStore {
+	return &MemoryRefreshTokenStore{tokens: make(map[string]*memoryRefreshEntry)}
+}
+
+// Save implements RefreshTokenStore.
+func (s *MemoryRefreshTokenStore) Save(token RefreshToken) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.tokens[token.Hash] = &memoryRefreshEntry{token: token}
+	return nil
+}
+
+// Consume implements RefreshTokenStore.
+func (s *MemoryRefreshTokenStore) Consume(hash string, now time.Time) (RefreshToken, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.pruneLocked(now)
+
+	e, ok := s.tokens[hash]
+	if !ok {
+		return RefreshToken{}, ErrRefreshTokenInvalid
+	}
+	if !now.Before(e.token.ExpiresAt) {
+		delete(s.tokens, hash)
+		return RefreshToken{}, ErrRefreshTokenExpired
+	}
+	if e.used {
+		s.revokeFamilyLocked(e.token.FamilyID)
+		return RefreshToken{}, ErrRefreshTokenReused
+	}
+	e.used = true
+	return e.token, nil
+}
+
+// RevokeFamily implements RefreshTokenStore.
+func (s *MemoryRefreshTokenStore) RevokeFamily(familyID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.revokeFamilyLocked(familyID)
+	return nil
+}
+
+// RevokeUser implements RefreshTokenStore.
+func (s *MemoryRefreshTokenStore) RevokeUser(userID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for hash, e := range s.tokens {
+		if e.token.UserID == userID {
+			delete(s.tokens, hash)
+		}
+	}
+	return nil
+}
+
+// Lookup implements RefreshTokenStore.
+func (s *MemoryRefreshTokenStore) Lookup(hash string) (RefreshToken, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	e, ok := s.tokens[hash]
+	if !ok {
+		return RefreshToken{}, ErrRefreshTokenInvalid
+	}
+	return e.token, nil
+}
+
+func (s *MemoryRefreshTokenStore) revokeFamilyLocked(familyID string) {
+	for hash, e := range s.tokens {
+		if e.token.FamilyID == familyID {
+			delete(s.tokens, hash)
+		}
+	}
+}
+
+// pruneLocked drops tokens that expired more than a minute ago. Recently
+// expired tokens are kept so that Consume can report them as expired.
+func (s *MemoryRefreshTokenStore) pruneLocked(now time.Time) {
+	cutoff := now.Add(-time.Minute)
+	for hash, e := range s.tokens {
+		if e.token.ExpiresAt.Before(cutoff) {
+			delete(s.tokens, hash)
+		}
+	}
+}
@@ -0,0 +1,59 @@
+// Package middleware – revocation.go rejects the access tokens of users
+// whose sessions were revoked before the tokens expire.
+package middleware
+
+import (
+	"sync"
+	"time"
+)
+
+// TokenRevocations records the users whose access tokens issued until a
+// time are no longer accepted. Access tokens are not stored, so revoking
+// them means remembering the user until the last token issued before the
+// revocation has expired.
+type TokenRevocations struct {
+	mu      sync.Mutex
+	revoked map[string]revocation // keyed by user ID
+}
+
+// revocation is a user's revocation: tokens issued at or before at are
+// rejected, and the entry is dropped after until.
+type revocation struct {
+	at, until time.Time
+}
+
+// NewTokenRevocations creates an empty TokenRevocations.
+func NewTokenRevocations() *TokenRevocations {
+	return &TokenRevocations{revoked: make(map[string]revocation)}
+}
+
+// Revoke rejects the tokens of userID issued at or before now. lifetime is
+// the longest a token is valid, after which the revocation is forgotten.
+func (t *TokenRevocations) Revoke(userID string, now time.Time, lifetime time.Duration) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.pruneLocked(now)
+	t.revoked[userID] = revocation{at: now, until: now.Add(lifetime)}
+}
+
+// Revoked reports whether a token of userID issued at issuedAt has been
+// revoked. Token issue times are truncated to the second, so a token
+// issued in the second of the revocation counts as revoked.
+func (t *TokenRevocations) Revoked(userID string, issuedAt time.Time) bool {
+	if t == nil {
+		return false
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	r, ok := t.revoked[userID]
+	return ok && !issuedAt.After(r.at.Truncate(time.Second))
+}
+
+// pruneLocked drops the revocations whose tokens have all expired.
+func (t *TokenRevocations) pruneLocked(now time.Time) {
+	for userID, r := range t.revoked {
+		if now.After(r.until) {
+			delete(t.revoked, userID)
+		}
+	}
+}
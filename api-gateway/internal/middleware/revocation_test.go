@@ -0,0 +1,57 @@
+package middleware
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRevokeTokens(t *testing.T) {
+	cfg := DefaultJWTConfig("test-secret")
+	before, _, _ := GenerateToken(cfg, "user-1", "jane@example.com", nil, true)
+	other, _, _ := GenerateToken(cfg, "user-2", "john@example.com", nil, true)
+
+	cfg.RevokeTokens("user-1")
+	if _, err := ParseToken(cfg, before); err == nil {
+		t.Error("a token issued before the revocation was accepted")
+	}
+	if _, err := ParseToken(cfg, other); err != nil {
+		t.Errorf("another user's token was rejected: %v", err)
+	}
+	// A config without revocations accepts the token.
+	cfg.Revocations = nil
+	if _, err := ParseToken(cfg, before); err != nil {
+		t.Errorf("without revocations: %v", err)
+	}
+}
+
+func TestTokenRevocations(t *testing.T) {
+	revs := NewTokenRevocations()
+	now := time.Date(2024, 3, 1, 12, 0, 0, 500, time.UTC)
+	revs.Revoke("user-1", now, time.Hour)
+
+	for _, tt := range []struct {
+		userID   string
+		issuedAt time.Time
+		want     bool
+	}{
+		{"user-1", now.Add(-time.Minute), true},
+		// Issue times are whole seconds.
+		{"user-1", now.Truncate(time.Second), true},
+		{"user-1", now.Add(time.Second), false},
+		{"user-2", now.Add(-time.Minute), false},
+	} {
+		if got := revs.Revoked(tt.userID, tt.issuedAt); got != tt.want {
+			t.Errorf("Revoked(%s, %v) = %v, want %v", tt.userID, tt.issuedAt, got, tt.want)
+		}
+	}
+
+	// Revocations are forgotten once the tokens they reject have expired.
+	revs.Revoke("user-2", now.Add(2*time.Hour), time.Hour)
+	if revs.Revoked("user-1", now.Add(-time.Minute)) {
+		t.Error("an expired revocation was kept")
+	}
+	var none *TokenRevocations
+	if none.Revoked("user-1", now) {
+		t.Error("a nil TokenRevocations revoked a token")
+	}
+}
@@ -0,0 +1,87 @@
+// Package middleware – roles.go implements role-based access control on top
+// of RequireAuth.
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// Role is a role carried in the roles claim of access tokens.
+type Role string
+
+const (
+	// RoleUser is held by every account.
+	RoleUser Role = "user"
+
+	// RoleCurator may manage learning resources.
+	RoleCurator Role = "curator"
+
+	// RoleAdmin may use every admin route and assign roles.
+	RoleAdmin Role = "admin"
+)
+
+// allRoles lists the known roles in the order tokens carry them.
+var allRoles = []Role{RoleUser, RoleCurator, RoleAdmin}
+
+// ParseRole returns the known role named s.
+func ParseRole(s string) (Role, error) {
+	for _, r := range allRoles {
+		if strings.EqualFold(s, string(r)) {
+			return r, nil
+		}
+	}
+	return "", fmt.Errorf("unknown role %q", s)
+}
+
+// NormalizeRoles returns roles without duplicates, in a fixed order and
+// with RoleUser, which every account holds.
+func NormalizeRoles(roles []Role) []Role {
+	out := []Role{RoleUser}
+	for _, r := range allRoles[1:] {
+		if HasRole(roles, r) {
+			out = append(out, r)
+		}
+	}
+	return out
+}
+
+// HasRole reports whether roles contains role.
+func HasRole(roles []Role, role Role) bool {
+	for _, r := range roles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
+
+// GetRoles returns the authenticated user's roles from the request context.
+func GetRoles(r *http.Request) []Role {
+	roles, _ := r.Context().Value(ContextKeyRoles).([]Role)
+	return roles
+}
+
+// RequireRole returns a middleware that requires the user to hold at least
+// one of roles; others get 403 Forbidden. Must be used after RequireAuth.
+func RequireRole(roles ...Role) func(http.Handler) http.Handler {
+	names := make([]string, len(roles))
+	for i, r := range roles {
+		names[i] = string(r)
+	}
+	message := "requires role " + strings.Join(names, " or ")
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			held := GetRoles(r)
+			for _, role := range roles {
+				if HasRole(held, role) {
+					next.ServeHTTP(w, r)
+					return
+				}
+			}
+			writeJSONError(w, http.StatusForbidden, "FORBIDDEN", message)
+		})
+	}
+}
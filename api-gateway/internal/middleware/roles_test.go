@@ -0,0 +1,101 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+func TestNormalizeRoles(t *testing.T) {
+	got := NormalizeRoles([]Role{RoleAdmin, RoleCurator, RoleAdmin})
+	if want := []Role{RoleUser, RoleCurator, RoleAdmin}; !reflect.DeepEqual(got, want) {
+		t.Errorf("NormalizeRoles = %v, want %v", got, want)
+	}
+	if got := NormalizeRoles(nil); !reflect.DeepEqual(got, []Role{RoleUser}) {
+		t.Errorf("NormalizeRoles(nil) = %v", got)
+	}
+	if r, err := ParseRole("Curator"); err != nil || r != RoleCurator {
+		t.Errorf("ParseRole(Curator) = %q, %v", r, err)
+	}
+	if _, err := ParseRole("superuser"); err == nil {
+		t.Error("ParseRole accepted an unknown role")
+	}
+}
+
+func TestRequireRole(t *testing.T) {
+	cfg := DefaultJWTConfig("test-secret")
+	token := func(roles ...Role) string {
+		s, _, err := GenerateToken(cfg, "user-1", "jane@example.com", roles, true)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return s
+	}
+	ok := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusOK) })
+	curatorRoute := Chain(RequireAuth(cfg), RequireRole(RoleCurator, RoleAdmin))(ok)
+	adminRoute := Chain(RequireAuth(cfg), RequireAdmin)(ok)
+
+	tests := []struct {
+		name  string
+		route http.Handler
+		token string
+		want  int
+	}{
+		{"curator route, user", curatorRoute, token(), http.StatusForbidden},
+		{"curator route, curator", curatorRoute, token(RoleCurator), http.StatusOK},
+		{"curator route, admin", curatorRoute, token(RoleAdmin), http.StatusOK},
+		{"admin route, user", adminRoute, token(RoleUser), http.StatusForbidden},
+		{"admin route, curator", adminRoute, token(RoleCurator), http.StatusForbidden},
+		{"admin route, admin", adminRoute, token(RoleAdmin), http.StatusOK},
+		{"admin route, anonymous", adminRoute, "", http.StatusUnauthorized},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		if tt.token != "" {
+			req.Header.Set("Authorization", "Bearer "+tt.token)
+		}
+		rec := httptest.NewRecorder()
+		tt.route.ServeHTTP(rec, req)
+		if rec.Code != tt.want {
+			t.Errorf("%s: status %d, want %d", tt.name, rec.Code, tt.want)
+		}
+	}
+}
+
+func TestParseToken_LegacyTokenIsPlainUser(t *testing.T) {
+	cfg := DefaultJWTConfig("test-secret")
+	// A token issued before roles: no version, and the old admin claim.
+	legacy, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwtClaims{
+		UserID:  "user-1",
+		Email:   "jane@example.com",
+		Roles:   []Role{RoleAdmin},
+		IsAdmin: true,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+			Issuer:    "learnbot",
+		},
+	}).SignedString(cfg.SecretKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	claims, err := ParseToken(cfg, legacy)
+	if err != nil {
+		t.Fatalf("legacy token rejected: %v", err)
+	}
+	if !reflect.DeepEqual(claims.Roles, []Role{RoleUser}) || claims.IsAdmin {
+		t.Errorf("legacy token roles = %v, is_admin = %v; want plain user", claims.Roles, claims.IsAdmin)
+	}
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("Authorization", "Bearer "+legacy)
+	Chain(RequireAuth(cfg), RequireAdmin)(http.NotFoundHandler()).ServeHTTP(rec, req)
+	if rec.Code != http.StatusForbidden {
+		t.Errorf("legacy admin token on an admin route: status %d, want 403", rec.Code)
+	}
+}
@@ -0,0 +1,62 @@
+// Package middleware – unsubscribe.go implements the signed tokens of the
+// unsubscribe links in digest emails.
+package middleware
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"errors"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// ErrUnsubscribeTokenInvalid is returned for a malformed or forged
+// unsubscribe token.
+var ErrUnsubscribeTokenInvalid = errors.New("invalid unsubscribe token")
+
+// unsubscribeClaims is the payload of an unsubscribe token.
+type unsubscribeClaims struct {
+	UserID string `json:"user_id"`
+	List   string `json:"list"`
+	jwt.RegisteredClaims
+}
+
+// unsubscribeKey derives the key that signs unsubscribe tokens from the JWT
+// secret, keeping them apart from access and verification tokens.
+func unsubscribeKey(cfg JWTConfig) []byte {
+	mac := hmac.New(sha256.New, cfg.SecretKey)
+	mac.Write([]byte("learnbot digest unsubscribe"))
+	return mac.Sum(nil)
+}
+
+// GenerateUnsubscribeToken creates a signed token that unsubscribes the
+// user userID from the mailing list list, such as "weekly_digest". The
+// token does not expire, so that the link in an old email keeps working.
+func GenerateUnsubscribeToken(cfg JWTConfig, userID, list string) (string, error) {
+	claims := unsubscribeClaims{
+		UserID: userID,
+		List:   list,
+		RegisteredClaims: jwt.RegisteredClaims{
+			IssuedAt: jwt.NewNumericDate(time.Now()),
+			Issuer:   "learnbot",
+		},
+	}
+	return jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(unsubscribeKey(cfg))
+}
+
+// ParseUnsubscribeToken validates an unsubscribe token and returns the user
+// and mailing list it unsubscribes.
+func ParseUnsubscribeToken(cfg JWTConfig, tokenStr string) (userID, list string, err error) {
+	claims := &unsubscribeClaims{}
+	_, err = jwt.ParseWithClaims(tokenStr, claims, func(t *jwt.Token) (interface{}, error) {
+		if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, jwt.ErrSignatureInvalid
+		}
+		return unsubscribeKey(cfg), nil
+	})
+	if err != nil || claims.UserID == "" || claims.List == "" {
+		return "", "", ErrUnsubscribeTokenInvalid
+	}
+	return claims.UserID, claims.List, nil
+}
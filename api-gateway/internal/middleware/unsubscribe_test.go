@@ -0,0 +1,30 @@
+package middleware
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestUnsubscribeToken(t *testing.T) {
+	cfg := DefaultJWTConfig("test-secret")
+	token, err := GenerateUnsubscribeToken(cfg, "user-1", "weekly_digest")
+	if err != nil {
+		t.Fatal(err)
+	}
+	userID, list, err := ParseUnsubscribeToken(cfg, token)
+	if err != nil || userID != "user-1" || list != "weekly_digest" {
+		t.Fatalf("Parse = %q, %q, %v", userID, list, err)
+	}
+
+	if _, _, err := ParseUnsubscribeToken(DefaultJWTConfig("other-secret"), token); !errors.Is(err, ErrUnsubscribeTokenInvalid) {
+		t.Errorf("other secret: err = %v, want ErrUnsubscribeTokenInvalid", err)
+	}
+	// Unsubscribe, verification and access tokens are not interchangeable.
+	if _, _, err := ParseEmailVerificationToken(cfg, token); err == nil {
+		t.Error("an unsubscribe token passed as a verification token")
+	}
+	verify, _ := GenerateEmailVerificationToken(cfg, "user-1", "jane@example.com", 0)
+	if _, _, err := ParseUnsubscribeToken(cfg, verify); !errors.Is(err, ErrUnsubscribeTokenInvalid) {
+		t.Errorf("verification token: err = %v, want ErrUnsubscribeTokenInvalid", err)
+	}
+}
@@ -0,0 +1,81 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestEmailVerificationToken(t *testing.T) {
+	cfg := DefaultJWTConfig("test-secret")
+	token, err := GenerateEmailVerificationToken(cfg, "user-1", "jane@example.com", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	userID, email, err := ParseEmailVerificationToken(cfg, token)
+	if err != nil || userID != "user-1" || email != "jane@example.com" {
+		t.Fatalf("Parse = %q, %q, %v", userID, email, err)
+	}
+
+	if _, _, err := ParseEmailVerificationToken(DefaultJWTConfig("other-secret"), token); !errors.Is(err, ErrVerificationTokenInvalid) {
+		t.Errorf("other secret: err = %v, want ErrVerificationTokenInvalid", err)
+	}
+	// Verification and access tokens are not interchangeable.
+	if _, err := ParseToken(cfg, token); err == nil {
+		t.Error("a verification token passed as an access token")
+	}
+	access, _, _ := GenerateToken(cfg, "user-1", "jane@example.com", nil, false)
+	if _, _, err := ParseEmailVerificationToken(cfg, access); !errors.Is(err, ErrVerificationTokenInvalid) {
+		t.Errorf("access token: err = %v, want ErrVerificationTokenInvalid", err)
+	}
+}
+
+func TestEmailVerificationToken_Expired(t *testing.T) {
+	cfg := DefaultJWTConfig("test-secret")
+	token, err := GenerateEmailVerificationToken(cfg, "user-1", "jane@example.com", time.Nanosecond)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := ParseEmailVerificationToken(cfg, token); !errors.Is(err, ErrVerificationTokenExpired) {
+		t.Errorf("err = %v, want ErrVerificationTokenExpired", err)
+	}
+}
+
+func TestVerificationPolicy(t *testing.T) {
+	cfg := DefaultJWTConfig("test-secret")
+	p := ParseVerificationPolicy(" Jobs, analysis ,,")
+	if !p.Requires("jobs") || !p.Requires("analysis") || p.Requires("resume") {
+		t.Fatalf("unexpected policy %+v", p)
+	}
+
+	ok := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusOK) })
+	enforced := p.Wrap("jobs", RequireAuth(cfg))(ok)
+	open := p.Wrap("resume", RequireAuth(cfg))(ok)
+	verified, _, _ := GenerateToken(cfg, "user-1", "a@example.com", nil, true)
+	unverified, _, _ := GenerateToken(cfg, "user-2", "b@example.com", nil, false)
+
+	tests := []struct {
+		name    string
+		handler http.Handler
+		token   string
+		want    int
+	}{
+		{"enforced, verified", enforced, verified, http.StatusOK},
+		{"enforced, unverified", enforced, unverified, http.StatusForbidden},
+		{"enforced, anonymous", enforced, "", http.StatusUnauthorized},
+		{"not enforced, unverified", open, unverified, http.StatusOK},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		if tt.token != "" {
+			req.Header.Set("Authorization", "Bearer "+tt.token)
+		}
+		rec := httptest.NewRecorder()
+		tt.handler.ServeHTTP(rec, req)
+		if rec.Code != tt.want {
+			t.Errorf("%s: status %d, want %d", tt.name, rec.Code, tt.want)
+		}
+	}
+}
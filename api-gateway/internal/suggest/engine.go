@@ -0,0 +1,274 @@
+package suggest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/learnbot/api-gateway/internal/digest"
+	"github.com/learnbot/resume-parser/pkg/skills"
+)
+
+const (
+	// DefaultMinDocuments is the number of skill sets the primary corpus
+	// needs before the fallback corpus is no longer used.
+	DefaultMinDocuments = 50
+
+	// DefaultJobLookback is how far back JobCorpus reads jobs.
+	DefaultJobLookback = 90 * 24 * time.Hour
+)
+
+// ErrRunning is returned by Recompute while another recomputation is in
+// progress.
+var ErrRunning = errors.New("suggest: recomputation already running")
+
+// Corpus lists skill sets, each the raw skill names of one resume or job.
+type Corpus interface {
+	SkillSets(ctx context.Context) ([][]string, error)
+}
+
+// CorpusFunc adapts a function to a Corpus.
+type CorpusFunc func(ctx context.Context) ([][]string, error)
+
+// SkillSets implements Corpus.
+func (f CorpusFunc) SkillSets(ctx context.Context) ([][]string, error) {
+	return f(ctx)
+}
+
+// JobCorpus is a Corpus of the required and preferred skills of the jobs
+// posted within a lookback window.
+type JobCorpus struct {
+	jobs     digest.JobSource
+	lookback time.Duration
+	now      func() time.Time
+}
+
+// NewJobCorpus creates a JobCorpus over jobs. A lookback of zero or less
+// uses DefaultJobLookback.
+func NewJobCorpus(jobs digest.JobSource, lookback time.Duration) *JobCorpus {
+	if lookback <= 0 {
+		lookback = DefaultJobLookback
+	}
+	return &JobCorpus{jobs: jobs, lookback: lookback, now: time.Now}
+}
+
+// SkillSets implements Corpus.
+func (c *JobCorpus) SkillSets(ctx context.Context) ([][]string, error) {
+	jobs, err := c.jobs.NewJobs(ctx, c.now().Add(-c.lookback))
+	if err != nil {
+		return nil, err
+	}
+	sets := make([][]string, 0, len(jobs))
+	for _, j := range jobs {
+		set := append(append([]string{}, j.Requirements.RequiredSkills...), j.Requirements.PreferredSkills...)
+		sets = append(sets, set)
+	}
+	return sets, nil
+}
+
+// EngineConfig configures an Engine.
+type EngineConfig struct {
+	Config
+
+	// MinDocuments is the number of skill sets the primary corpus needs to
+	// be used on its own; below it the fallback corpus is used instead.
+	// Zero or less uses DefaultMinDocuments.
+	MinDocuments int
+}
+
+// Status describes an Engine's current model and recomputation.
+type Status struct {
+	// Running is set while a recomputation is in progress.
+	Running bool `json:"running"`
+
+	// Source is "primary" or "fallback"; empty before the first model.
+	Source string `json:"source,omitempty"`
+
+	// Documents and Skills count the skill sets and distinct skills of the
+	// current model.
+	Documents int `json:"documents"`
+	Skills    int `json:"skills"`
+
+	// BuiltAt is when the current model was built.
+	BuiltAt *time.Time `json:"built_at,omitempty"`
+
+	// LastError is the error of the latest recomputation, if it failed.
+	LastError string `json:"last_error,omitempty"`
+}
+
+// Engine serves suggestions from the latest Model, which Recompute rebuilds
+// from its corpora. Skill names are collapsed to taxonomy skills, so that
+// "golang" and "Go" count as the same skill.
+type Engine struct {
+	primary  Corpus
+	fallback Corpus
+	cfg      EngineConfig
+	skills   *skills.Normalizer
+	now      func() time.Time
+
+	mu      sync.RWMutex
+	model   *Model
+	running bool
+	lastErr error
+}
+
+// NewEngine creates an Engine built from primary, or from fallback while
+// primary has too few skill sets. fallback may be nil. The engine has no
+// model until the first Recompute.
+func NewEngine(primary, fallback Corpus, cfg EngineConfig) *Engine {
+	if cfg.MinDocuments <= 0 {
+		cfg.MinDocuments = DefaultMinDocuments
+	}
+	return &Engine{
+		primary:  primary,
+		fallback: fallback,
+		cfg:      cfg,
+		skills:   skills.New(),
+		now:      time.Now,
+	}
+}
+
+// Canonical maps a raw skill name to its taxonomy skill by name or alias.
+// Skills the taxonomy does not know are identified by their lowercased name.
+// The zero Skill is returned for blank names.
+func (e *Engine) Canonical(raw string) Skill {
+	name := strings.TrimSpace(raw)
+	if name == "" {
+		return Skill{}
+	}
+	if r := e.skills.NormalizeExact(name); r.CanonicalID != "" {
+		return Skill{ID: r.CanonicalID, Name: r.CanonicalName}
+	}
+	return Skill{ID: strings.ToLower(strings.Join(strings.Fields(name), " ")), Name: name}
+}
+
+// Recompute rebuilds the model from the corpora, replacing the current one
+// once done. It returns ErrRunning if a recomputation is already in
+// progress; on error the current model is kept.
+func (e *Engine) Recompute(ctx context.Context) (Status, error) {
+	if !e.begin() {
+		return e.Status(), ErrRunning
+	}
+	err := e.run(ctx)
+	return e.Status(), err
+}
+
+// Start is Recompute in the background: it returns at once with the running
+// status, and calls done, if not nil, with the recomputation's error.
+func (e *Engine) Start(ctx context.Context, done func(error)) (Status, error) {
+	if !e.begin() {
+		return e.Status(), ErrRunning
+	}
+	status := e.Status()
+	go func() {
+		err := e.run(ctx)
+		if done != nil {
+			done(err)
+		}
+	}()
+	return status, nil
+}
+
+// begin marks a recomputation as running, reporting false if one already
+// is.
+func (e *Engine) begin() bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.running {
+		return false
+	}
+	e.running = true
+	return true
+}
+
+// run builds a model and ends the recomputation started by begin.
+func (e *Engine) run(ctx context.Context) error {
+	model, err := e.build(ctx)
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.running, e.lastErr = false, err
+	if err == nil {
+		e.model = model
+	}
+	return err
+}
+
+// build builds a model from the primary corpus, or from the fallback
+// corpus when the primary one is too small.
+func (e *Engine) build(ctx context.Context) (*Model, error) {
+	sets, err := e.primary.SkillSets(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("primary corpus: %w", err)
+	}
+	model := Build(e.canonicalSets(sets), e.cfg.Config)
+	model.Source = "primary"
+	if model.Documents < e.cfg.MinDocuments && e.fallback != nil {
+		sets, err := e.fallback.SkillSets(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("fallback corpus: %w", err)
+		}
+		model = Build(e.canonicalSets(sets), e.cfg.Config)
+		model.Source = "fallback"
+	}
+	model.BuiltAt = e.now().UTC()
+	return model, nil
+}
+
+func (e *Engine) canonicalSets(sets [][]string) [][]Skill {
+	out := make([][]Skill, len(sets))
+	for i, set := range sets {
+		out[i] = make([]Skill, 0, len(set))
+		for _, raw := range set {
+			if s := e.Canonical(raw); s.ID != "" {
+				out[i] = append(out[i], s)
+			}
+		}
+	}
+	return out
+}
+
+// Status reports the current model and whether a recomputation is running.
+func (e *Engine) Status() Status {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	s := Status{Running: e.running}
+	if e.lastErr != nil {
+		s.LastError = e.lastErr.Error()
+	}
+	if e.model != nil {
+		built := e.model.BuiltAt
+		s.Source, s.Documents, s.Skills, s.BuiltAt = e.model.Source, e.model.Documents, e.model.Skills(), &built
+	}
+	return s
+}
+
+// Model returns the current model, or nil before the first successful
+// Recompute.
+func (e *Engine) Model() *Model {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return e.model
+}
+
+// Suggest returns up to limit skills that co-occur with skill, best first,
+// leaving out the skills in have. All names are collapsed to taxonomy
+// skills first. It returns nil before the first successful Recompute.
+func (e *Engine) Suggest(skill string, have []string, limit int) (Skill, []Neighbor) {
+	target := e.Canonical(skill)
+	model := e.Model()
+	if model == nil {
+		return target, nil
+	}
+	if name, ok := model.Name(target.ID); ok {
+		target.Name = name
+	}
+	exclude := make(map[string]bool, len(have))
+	for _, h := range have {
+		exclude[e.Canonical(h).ID] = true
+	}
+	return target, model.Neighbors(target.ID, exclude, limit)
+}
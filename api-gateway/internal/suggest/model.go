@@ -0,0 +1,196 @@
+// Package suggest suggests skills that usually appear alongside a skill
+// ("people with Kubernetes usually also list Docker"). A Model is built
+// offline from a corpus of skill sets, such as the skills of parsed resumes,
+// by counting how often each pair of skills appears in the same set and
+// keeping each skill's top neighbors by normalized pointwise mutual
+// information (NPMI).
+package suggest
+
+import (
+	"math"
+	"sort"
+	"time"
+)
+
+const (
+	// DefaultTopK is the number of neighbors kept per skill.
+	DefaultTopK = 20
+
+	// DefaultMinCount is the number of skill sets a pair must share to be
+	// kept, so that a single resume listing two rare skills does not make
+	// them each other's best suggestion.
+	DefaultMinCount = 2
+)
+
+// Config configures how a Model is built.
+type Config struct {
+	// TopK is the number of neighbors kept per skill; zero or less uses
+	// DefaultTopK.
+	TopK int
+
+	// MinCount is the minimum number of skill sets a pair must appear in
+	// together; zero or less uses DefaultMinCount.
+	MinCount int
+}
+
+func (c Config) withDefaults() Config {
+	if c.TopK <= 0 {
+		c.TopK = DefaultTopK
+	}
+	if c.MinCount <= 0 {
+		c.MinCount = DefaultMinCount
+	}
+	return c
+}
+
+// Skill is a skill in a Model.
+type Skill struct {
+	// ID is the canonical taxonomy ID, or the lowercased name of a skill
+	// the taxonomy does not know.
+	ID string `json:"id"`
+
+	// Name is the display name.
+	Name string `json:"name"`
+}
+
+// Neighbor is a skill that co-occurs with another.
+type Neighbor struct {
+	Skill
+
+	// Score is the NPMI of the pair, in (0, 1]; 1 means the skills always
+	// appear together.
+	Score float64 `json:"score"`
+
+	// Count is the number of skill sets listing both skills.
+	Count int `json:"count"`
+}
+
+// Model holds the top co-occurring neighbors of each skill. It is
+// immutable once built.
+type Model struct {
+	// Source names the corpus the model was built from.
+	Source string
+
+	// Documents is the number of skill sets counted.
+	Documents int
+
+	// BuiltAt is when the model was built.
+	BuiltAt time.Time
+
+	names     map[string]string
+	neighbors map[string][]Neighbor
+}
+
+// Build builds a Model from skill sets of canonical skills. Duplicate skills
+// within a set count once, and sets of fewer than two skills are ignored.
+func Build(docs [][]Skill, cfg Config) *Model {
+	cfg = cfg.withDefaults()
+	m := &Model{names: make(map[string]string), neighbors: make(map[string][]Neighbor)}
+
+	single := make(map[string]int)
+	pairs := make(map[[2]string]int)
+	for _, doc := range docs {
+		ids := make([]string, 0, len(doc))
+		seen := make(map[string]bool, len(doc))
+		for _, s := range doc {
+			if s.ID == "" || seen[s.ID] {
+				continue
+			}
+			seen[s.ID] = true
+			ids = append(ids, s.ID)
+			if _, ok := m.names[s.ID]; !ok {
+				m.names[s.ID] = s.Name
+			}
+		}
+		if len(ids) < 2 {
+			continue
+		}
+		m.Documents++
+		sort.Strings(ids)
+		for i, a := range ids {
+			single[a]++
+			for _, b := range ids[i+1:] {
+				pairs[[2]string{a, b}]++
+			}
+		}
+	}
+
+	for pair, n := range pairs {
+		if n < cfg.MinCount {
+			continue
+		}
+		score := NPMI(n, single[pair[0]], single[pair[1]], m.Documents)
+		if score <= 0 {
+			continue
+		}
+		a, b := pair[0], pair[1]
+		m.neighbors[a] = append(m.neighbors[a], Neighbor{Skill{b, m.names[b]}, score, n})
+		m.neighbors[b] = append(m.neighbors[b], Neighbor{Skill{a, m.names[a]}, score, n})
+	}
+	for id, ns := range m.neighbors {
+		sort.Slice(ns, func(i, j int) bool {
+			if ns[i].Score != ns[j].Score {
+				return ns[i].Score > ns[j].Score
+			}
+			if ns[i].Count != ns[j].Count {
+				return ns[i].Count > ns[j].Count
+			}
+			return ns[i].ID < ns[j].ID
+		})
+		if len(ns) > cfg.TopK {
+			ns = ns[:cfg.TopK]
+		}
+		m.neighbors[id] = ns
+	}
+	return m
+}
+
+// NPMI is the normalized pointwise mutual information of two skills listed
+// together in pair of total skill sets, and individually in x and y:
+//
+//	PMI  = ln(P(x,y) / (P(x) P(y)))
+//	NPMI = PMI / -ln(P(x,y))
+//
+// It is 1 when the skills only ever appear together, 0 when they are
+// independent and negative when they appear together less often than by
+// chance. It is rounded to four decimal places.
+func NPMI(pair, x, y, total int) float64 {
+	if pair <= 0 || x <= 0 || y <= 0 || total <= 0 {
+		return -1
+	}
+	pxy := float64(pair) / float64(total)
+	if pxy >= 1 {
+		// Every set lists both skills.
+		return 1
+	}
+	pmi := math.Log(pxy / (float64(x) / float64(total) * float64(y) / float64(total)))
+	return math.Round(pmi/-math.Log(pxy)*10000) / 10000
+}
+
+// Name returns the display name of the skill id, and whether the model has
+// seen it.
+func (m *Model) Name(id string) (string, bool) {
+	name, ok := m.names[id]
+	return name, ok
+}
+
+// Skills is the number of distinct skills the model has seen.
+func (m *Model) Skills() int {
+	return len(m.names)
+}
+
+// Neighbors returns up to limit neighbors of the skill id, best first,
+// leaving out those in exclude. A limit of zero or less returns them all.
+func (m *Model) Neighbors(id string, exclude map[string]bool, limit int) []Neighbor {
+	out := []Neighbor{}
+	for _, n := range m.neighbors[id] {
+		if exclude[n.ID] {
+			continue
+		}
+		out = append(out, n)
+		if limit > 0 && len(out) == limit {
+			break
+		}
+	}
+	return out
+}
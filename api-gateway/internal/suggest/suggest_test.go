@@ -0,0 +1,276 @@
+package suggest
+
+import (
+	"context"
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/learnbot/api-gateway/internal/digest"
+	"github.com/learnbot/resume-parser/pkg/scoring"
+)
+
+func TestNPMI(t *testing.T) {
+	tests := []struct {
+		name              string
+		pair, x, y, total int
+		want              float64
+	}{
+		// P(x,y)=1/4, P(x)=1/4, P(y)=1/2: PMI = ln 2, NPMI = ln 2 / ln 4.
+		{"positive", 2, 2, 4, 8, 0.5},
+		// x only ever appears with y, and y only with x.
+		{"always together", 3, 3, 3, 9, 1},
+		// P(x,y) = P(x) P(y).
+		{"independent", 1, 2, 2, 4, 0},
+		// P(x,y)=1/10 < P(x) P(y)=1/4.
+		{"negative", 1, 5, 5, 10, math.Round(math.Log(0.1/0.25)/-math.Log(0.1)*10000) / 10000},
+		{"every set", 5, 5, 5, 5, 1},
+		{"no pair", 0, 5, 5, 10, -1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NPMI(tt.pair, tt.x, tt.y, tt.total); got != tt.want {
+				t.Errorf("NPMI(%d, %d, %d, %d) = %v, want %v", tt.pair, tt.x, tt.y, tt.total, got, tt.want)
+			}
+		})
+	}
+}
+
+func skillSet(ids ...string) []Skill {
+	set := make([]Skill, len(ids))
+	for i, id := range ids {
+		set[i] = Skill{ID: id, Name: id}
+	}
+	return set
+}
+
+func TestBuild(t *testing.T) {
+	docs := [][]Skill{
+		skillSet("go", "docker", "kubernetes"),
+		skillSet("go", "docker", "kubernetes"),
+		skillSet("go", "postgresql", "go"),
+		skillSet("python", "postgresql"),
+		skillSet("python", "postgresql"),
+		skillSet("python", "docker"),
+		skillSet("react", "typescript"),
+		skillSet("react"), // ignored: a single skill
+		nil,
+	}
+	m := Build(docs, Config{MinCount: 2})
+	if m.Documents != 7 {
+		t.Errorf("Documents = %d, want 7", m.Documents)
+	}
+
+	// kubernetes appears twice, both times with go and docker; go and
+	// postgresql share one set, below MinCount.
+	got := m.Neighbors("kubernetes", nil, 0)
+	if len(got) != 2 || got[0].ID != "docker" || got[1].ID != "go" {
+		t.Fatalf("kubernetes neighbors = %+v, want docker then go", got)
+	}
+	// docker: 3 of 7 sets, go: 3 of 7, together in 2.
+	if want := NPMI(2, 2, 3, 7); got[0].Score != want || got[0].Count != 2 {
+		t.Errorf("kubernetes/docker = %+v, want score %v and count 2", got[0], want)
+	}
+	for _, n := range m.Neighbors("go", nil, 0) {
+		if n.ID == "postgresql" {
+			t.Errorf("go/postgresql kept below MinCount: %+v", n)
+		}
+	}
+	if got := m.Neighbors("react", nil, 0); len(got) != 0 {
+		t.Errorf("react neighbors = %+v, want none below MinCount", got)
+	}
+	if got := m.Neighbors("unknown", nil, 0); got == nil || len(got) != 0 {
+		t.Errorf("unknown neighbors = %#v, want an empty list", got)
+	}
+}
+
+func TestBuild_TopK(t *testing.T) {
+	var docs [][]Skill
+	for i := 0; i < 3; i++ {
+		docs = append(docs, skillSet("go", "a", "b", "c", "d"), skillSet("x", "y"))
+	}
+	m := Build(docs, Config{TopK: 2})
+	if got := m.Neighbors("go", nil, 0); len(got) != 2 || got[0].ID != "a" || got[1].ID != "b" {
+		t.Errorf("go neighbors = %+v, want the top 2 by score then ID", got)
+	}
+}
+
+func TestNeighbors_Exclude(t *testing.T) {
+	docs := [][]Skill{
+		skillSet("go", "docker", "kubernetes", "grpc"),
+		skillSet("go", "docker", "kubernetes", "grpc"),
+		skillSet("go", "docker"),
+		skillSet("python", "django"),
+		skillSet("python", "django"),
+	}
+	m := Build(docs, Config{})
+	got := m.Neighbors("go", map[string]bool{"docker": true}, 1)
+	if len(got) != 1 || got[0].ID == "docker" {
+		t.Errorf("Neighbors excluding docker = %+v", got)
+	}
+	if all := m.Neighbors("go", nil, 0); len(all) != 3 {
+		t.Errorf("Neighbors(go) = %+v, want docker, grpc and kubernetes", all)
+	}
+}
+
+func staticCorpus(sets ...[]string) Corpus {
+	return CorpusFunc(func(context.Context) ([][]string, error) { return sets, nil })
+}
+
+func TestEngine_AliasesCollapse(t *testing.T) {
+	e := NewEngine(staticCorpus(
+		[]string{"golang", "Docker"},
+		[]string{"Go", "docker", "golang"},
+		[]string{"Python", "Django"},
+		[]string{"python", "django"},
+	), nil, EngineConfig{MinDocuments: 1})
+	if _, err := e.Recompute(context.Background()); err != nil {
+		t.Fatalf("Recompute: %v", err)
+	}
+
+	skill, got := e.Suggest("GoLang", nil, 10)
+	if skill.ID != "go" || skill.Name != "Go" {
+		t.Errorf("Suggest skill = %+v, want go", skill)
+	}
+	// "golang" and "Go" are one skill, listed with Docker in both sets.
+	if len(got) != 1 || got[0].ID != "docker" || got[0].Count != 2 || got[0].Score != 1 {
+		t.Fatalf("Suggest(GoLang) = %+v, want docker in 2 sets", got)
+	}
+	if _, got := e.Suggest("go", []string{"Docker"}, 10); len(got) != 0 {
+		t.Errorf("Suggest excluding Docker = %+v, want none", got)
+	}
+	if _, got := e.Suggest("cobol", nil, 10); len(got) != 0 {
+		t.Errorf("Suggest(cobol) = %+v, want none", got)
+	}
+}
+
+func TestEngine_Canonical(t *testing.T) {
+	e := NewEngine(staticCorpus(), nil, EngineConfig{})
+	if got := e.Canonical("  Internal   Tooling "); got.ID != "internal tooling" || got.Name != "Internal   Tooling" {
+		t.Errorf("Canonical(unknown) = %+v", got)
+	}
+	if got := e.Canonical(" "); got.ID != "" {
+		t.Errorf("Canonical(blank) = %+v, want zero", got)
+	}
+}
+
+type stubJobs struct {
+	jobs  []digest.Job
+	since time.Time
+}
+
+func (s *stubJobs) NewJobs(_ context.Context, since time.Time) ([]digest.Job, error) {
+	s.since = since
+	return s.jobs, nil
+}
+
+func TestEngine_Fallback(t *testing.T) {
+	job := func(required, preferred []string) digest.Job {
+		return digest.Job{Requirements: scoring.JobRequirements{RequiredSkills: required, PreferredSkills: preferred}}
+	}
+	jobs := &stubJobs{jobs: []digest.Job{
+		job([]string{"Go", "Kubernetes"}, []string{"Terraform"}),
+		job([]string{"golang", "k8s"}, nil),
+		job([]string{"React", "TypeScript"}, nil),
+	}}
+	now := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	fallback := NewJobCorpus(jobs, 0)
+	fallback.now = func() time.Time { return now }
+
+	e := NewEngine(staticCorpus([]string{"Go", "React"}), fallback, EngineConfig{MinDocuments: 2})
+	e.now = func() time.Time { return now }
+	status, err := e.Recompute(context.Background())
+	if err != nil {
+		t.Fatalf("Recompute: %v", err)
+	}
+	if status.Source != "fallback" || status.Documents != 3 || status.BuiltAt == nil || !status.BuiltAt.Equal(now) {
+		t.Errorf("status = %+v, want the fallback corpus of 3 jobs", status)
+	}
+	if want := now.Add(-DefaultJobLookback); !jobs.since.Equal(want) {
+		t.Errorf("jobs read since %v, want %v", jobs.since, want)
+	}
+	if _, got := e.Suggest("Go", nil, 0); len(got) != 1 || got[0].ID != "kubernetes" {
+		t.Errorf("Suggest(Go) = %+v, want kubernetes", got)
+	}
+
+	// With enough skill sets the primary corpus is used alone.
+	e = NewEngine(staticCorpus([]string{"Go", "React"}, []string{"Go", "React"}), fallback, EngineConfig{MinDocuments: 2})
+	if status, _ := e.Recompute(context.Background()); status.Source != "primary" || status.Documents != 2 {
+		t.Errorf("status = %+v, want the primary corpus", status)
+	}
+}
+
+func TestEngine_RecomputeError(t *testing.T) {
+	fail := false
+	corpus := CorpusFunc(func(context.Context) ([][]string, error) {
+		if fail {
+			return nil, errors.New("store unavailable")
+		}
+		return [][]string{{"Go", "Docker"}, {"Go", "Docker"}}, nil
+	})
+	e := NewEngine(corpus, nil, EngineConfig{MinDocuments: 1})
+	if _, got := e.Suggest("Go", nil, 0); got != nil {
+		t.Errorf("Suggest before Recompute = %+v, want nil", got)
+	}
+	if _, err := e.Recompute(context.Background()); err != nil {
+		t.Fatalf("Recompute: %v", err)
+	}
+
+	fail = true
+	status, err := e.Recompute(context.Background())
+	if err == nil || status.LastError == "" || status.Documents != 2 {
+		t.Errorf("failed Recompute = %+v, %v; want the error and the previous model", status, err)
+	}
+	if _, got := e.Suggest("Go", nil, 0); len(got) != 1 {
+		t.Errorf("Suggest after a failed Recompute = %+v, want the previous model", got)
+	}
+}
+
+func TestEngine_RecomputeRunning(t *testing.T) {
+	release := make(chan struct{})
+	started := make(chan struct{})
+	e := NewEngine(CorpusFunc(func(context.Context) ([][]string, error) {
+		close(started)
+		<-release
+		return nil, nil
+	}), nil, EngineConfig{})
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		e.Recompute(context.Background())
+	}()
+	<-started
+	if status, err := e.Recompute(context.Background()); !errors.Is(err, ErrRunning) || !status.Running {
+		t.Errorf("concurrent Recompute = %+v, %v; want ErrRunning", status, err)
+	}
+	close(release)
+	<-done
+	if e.Status().Running {
+		t.Error("still running after Recompute returned")
+	}
+}
+
+func TestEngine_Start(t *testing.T) {
+	release := make(chan struct{})
+	e := NewEngine(CorpusFunc(func(context.Context) ([][]string, error) {
+		<-release
+		return [][]string{{"Go", "Docker"}, {"Go", "Docker"}}, nil
+	}), nil, EngineConfig{MinDocuments: 1})
+
+	done := make(chan error, 1)
+	status, err := e.Start(context.Background(), func(err error) { done <- err })
+	if err != nil || !status.Running {
+		t.Fatalf("Start = %+v, %v; want running", status, err)
+	}
+	if _, err := e.Start(context.Background(), nil); !errors.Is(err, ErrRunning) {
+		t.Errorf("second Start: %v, want ErrRunning", err)
+	}
+	close(release)
+	if err := <-done; err != nil {
+		t.Fatalf("recomputation: %v", err)
+	}
+	if status := e.Status(); status.Running || status.Documents != 2 {
+		t.Errorf("status after Start = %+v", status)
+	}
+}
@@ -0,0 +1,256 @@
+// Package tasks runs background tasks in process on a fixed number of
+// workers, and keeps their progress so that clients can poll for it.
+//
+// Tasks live in memory only: a restart loses pending tasks and the status
+// of finished ones.
+package tasks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// State is the state of a task.
+type State string
+
+const (
+	StatePending State = "pending"
+	StateRunning State = "running"
+	StateDone    State = "done"
+)
+
+// maxItemErrors caps the item failures kept per task; later ones are only
+// counted.
+const maxItemErrors = 20
+
+// ErrQueueFull is returned by Submit when Config.MaxPending tasks are
+// already waiting for a worker.
+var ErrQueueFull = errors.New("task queue is full")
+
+// Config configures a Queue.
+type Config struct {
+	// Workers is the number of tasks that run at once.
+	Workers int
+
+	// MaxPending is the number of tasks that may wait for a worker.
+	MaxPending int
+
+	// Retention is how long the status of a finished task is kept.
+	Retention time.Duration
+}
+
+// DefaultConfig returns 2 workers, up to 100 pending tasks, and finished
+// tasks kept for an hour.
+func DefaultConfig() Config {
+	return Config{Workers: 2, MaxPending: 100, Retention: time.Hour}
+}
+
+// Status is a snapshot of a task.
+type Status struct {
+	ID  string
+	Key string
+
+	State State
+
+	// Total is the number of items the task works through, once known.
+	// Succeeded and Failed count the items done so far.
+	Total     int
+	Succeeded int
+	Failed    int
+
+	// Errors holds the first item failures.
+	Errors []ItemError
+
+	// Err is why the task stopped before working through its items.
+	Err string
+
+	CreatedAt  time.Time
+	StartedAt  *time.Time
+	FinishedAt *time.Time
+}
+
+// ItemError is the failure of one item of a task.
+type ItemError struct {
+	Item  string
+	Error string
+}
+
+// Func runs a task, reporting its progress on p. Returning an error ends
+// the task early; a failed item should be reported with p.Failed instead,
+// so that the remaining items are still worked through.
+type Func func(ctx context.Context, p *Progress) error
+
+// Progress reports the progress of a running task.
+type Progress struct {
+	q *Queue
+	t *task
+}
+
+// SetTotal sets the number of items the task works through.
+func (p *Progress) SetTotal(n int) {
+	p.q.mu.Lock()
+	defer p.q.mu.Unlock()
+	p.t.status.Total = n
+}
+
+// Succeeded counts an item as done.
+func (p *Progress) Succeeded() {
+	p.q.mu.Lock()
+	defer p.q.mu.Unlock()
+	p.t.status.Succeeded++
+}
+
+// Failed counts an item as failed with err.
+func (p *Progress) Failed(item string, err error) {
+	p.q.mu.Lock()
+	defer p.q.mu.Unlock()
+	p.t.status.Failed++
+	if len(p.t.status.Errors) < maxItemErrors {
+		p.t.status.Errors = append(p.t.status.Errors, ItemError{Item: item, Error: err.Error()})
+	}
+}
+
+type task struct {
+	status Status
+	fn     Func
+}
+
+// Queue runs submitted tasks on Config.Workers workers. At most one task
+// per key is pending or running at a time.
+type Queue struct {
+	cfg     Config
+	pending chan *task
+	now     func() time.Time
+
+	mu     sync.Mutex
+	tasks  map[string]*task // by ID
+	active map[string]*task // pending or running, by key
+}
+
+// NewQueue creates a Queue from cfg. Zero fields use the defaults. Tasks
+// wait until Start is called.
+func NewQueue(cfg Config) *Queue {
+	def := DefaultConfig()
+	if cfg.Workers <= 0 {
+		cfg.Workers = def.Workers
+	}
+	if cfg.MaxPending <= 0 {
+		cfg.MaxPending = def.MaxPending
+	}
+	if cfg.Retention <= 0 {
+		cfg.Retention = def.Retention
+	}
+	return &Queue{
+		cfg:     cfg,
+		pending: make(chan *task, cfg.MaxPending),
+		now:     time.Now,
+		tasks:   make(map[string]*task),
+		active:  make(map[string]*task),
+	}
+}
+
+// Start starts the workers. They stop when ctx is done, which also cancels
+// the running tasks.
+func (q *Queue) Start(ctx context.Context) {
+	for i := 0; i < q.cfg.Workers; i++ {
+		go func() {
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case t := <-q.pending:
+					q.run(ctx, t)
+				}
+			}
+		}()
+	}
+}
+
+// Submit queues fn under key and returns its status, reporting true. If a
+// task with the same key is still pending or running, that task's status
+// is returned instead, reporting false, and fn is dropped.
+func (q *Queue) Submit(key string, fn Func) (Status, bool, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.prune()
+	if t, ok := q.active[key]; ok {
+		return t.snapshot(), false, nil
+	}
+
+	t := &task{
+		status: Status{ID: uuid.NewString(), Key: key, State: StatePending, CreatedAt: q.now()},
+		fn:     fn,
+	}
+	select {
+	case q.pending <- t:
+	default:
+		return Status{}, false, ErrQueueFull
+	}
+	q.tasks[t.status.ID] = t
+	q.active[key] = t
+	return t.snapshot(), true, nil
+}
+
+// Status returns the status of the task with the given ID, if it is still
+// kept.
+func (q *Queue) Status(id string) (Status, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.prune()
+	t, ok := q.tasks[id]
+	if !ok {
+		return Status{}, false
+	}
+	return t.snapshot(), true
+}
+
+// run runs t, turning a panic into the task's error.
+func (q *Queue) run(ctx context.Context, t *task) {
+	q.mu.Lock()
+	started := q.now()
+	t.status.State, t.status.StartedAt = StateRunning, &started
+	q.mu.Unlock()
+
+	var err error
+	func() {
+		defer func() {
+			if r := recover(); r != nil {
+				err = fmt.Errorf("task panic: %v", r)
+			}
+		}()
+		err = t.fn(ctx, &Progress{q: q, t: t})
+	}()
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	finished := q.now()
+	t.status.State, t.status.FinishedAt = StateDone, &finished
+	if err != nil {
+		t.status.Err = err.Error()
+	}
+	t.fn = nil
+	delete(q.active, t.status.Key)
+}
+
+// prune drops the finished tasks past the retention. q.mu must be held.
+func (q *Queue) prune() {
+	cutoff := q.now().Add(-q.cfg.Retention)
+	for id, t := range q.tasks {
+		if f := t.status.FinishedAt; f != nil && f.Before(cutoff) {
+			delete(q.tasks, id)
+		}
+	}
+}
+
+// snapshot copies the status of t. q.mu must be held.
+func (t *task) snapshot() Status {
+	s := t.status
+	s.Errors = slices.Clone(s.Errors)
+	return s
+}
@@ -0,0 +1,165 @@
+package tasks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// waitDone polls q until the task with id is done.
+func waitDone(t *testing.T, q *Queue, id string) Status {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		if s, ok := q.Status(id); ok && s.State == StateDone {
+			return s
+		}
+		time.Sleep(time.Millisecond)
+	}
+	t.Fatalf("task %s did not finish", id)
+	return Status{}
+}
+
+func TestQueue_Progress(t *testing.T) {
+	q := NewQueue(DefaultConfig())
+	q.Start(t.Context())
+
+	s, created, err := q.Submit("user-1", func(ctx context.Context, p *Progress) error {
+		p.SetTotal(3)
+		p.Succeeded()
+		p.Failed("item-2", errors.New("malformed"))
+		p.Succeeded()
+		return nil
+	})
+	if err != nil || !created || s.State != StatePending || s.Key != "user-1" {
+		t.Fatalf("Submit = %+v, %v, %v", s, created, err)
+	}
+	s = waitDone(t, q, s.ID)
+	if s.Total != 3 || s.Succeeded != 2 || s.Failed != 1 || s.Err != "" || s.StartedAt == nil || s.FinishedAt == nil {
+		t.Errorf("unexpected status %+v", s)
+	}
+	if len(s.Errors) != 1 || s.Errors[0] != (ItemError{Item: "item-2", Error: "malformed"}) {
+		t.Errorf("Errors = %v", s.Errors)
+	}
+	if _, ok := q.Status("unknown"); ok {
+		t.Error("Status(unknown) reported a task")
+	}
+}
+
+func TestQueue_OneTaskPerKey(t *testing.T) {
+	q := NewQueue(DefaultConfig())
+	q.Start(t.Context())
+	release := make(chan struct{})
+	var runs atomic.Int32
+	fn := func(ctx context.Context, p *Progress) error {
+		runs.Add(1)
+		<-release
+		return nil
+	}
+
+	first, created, _ := q.Submit("user-1", fn)
+	if !created {
+		t.Fatal("first Submit did not create a task")
+	}
+	again, created, _ := q.Submit("user-1", fn)
+	if created || again.ID != first.ID {
+		t.Errorf("second Submit = %s (created %v), want the pending task %s", again.ID, created, first.ID)
+	}
+	other, created, _ := q.Submit("user-2", func(context.Context, *Progress) error { return nil })
+	if !created || other.ID == first.ID {
+		t.Errorf("another key shared the task")
+	}
+
+	close(release)
+	waitDone(t, q, first.ID)
+	next, created, _ := q.Submit("user-1", fn)
+	if !created || next.ID == first.ID {
+		t.Errorf("Submit after the task finished = %s (created %v), want a new task", next.ID, created)
+	}
+	waitDone(t, q, next.ID)
+	if runs.Load() != 2 {
+		t.Errorf("fn ran %d times, want 2", runs.Load())
+	}
+}
+
+func TestQueue_BoundedConcurrency(t *testing.T) {
+	q := NewQueue(Config{Workers: 2})
+	q.Start(t.Context())
+
+	var running, peak atomic.Int32
+	var ids []string
+	for i := 0; i < 6; i++ {
+		s, _, err := q.Submit(fmt.Sprint("user-", i), func(ctx context.Context, p *Progress) error {
+			n := running.Add(1)
+			for {
+				old := peak.Load()
+				if n <= old || peak.CompareAndSwap(old, n) {
+					break
+				}
+			}
+			time.Sleep(10 * time.Millisecond)
+			running.Add(-1)
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("Submit: %v", err)
+		}
+		ids = append(ids, s.ID)
+	}
+	for _, id := range ids {
+		waitDone(t, q, id)
+	}
+	if peak.Load() != 2 {
+		t.Errorf("%d tasks ran at once, want 2", peak.Load())
+	}
+}
+
+func TestQueue_Full(t *testing.T) {
+	q := NewQueue(Config{MaxPending: 1})
+	noop := func(context.Context, *Progress) error { return nil }
+	if _, _, err := q.Submit("a", noop); err != nil {
+		t.Fatalf("Submit: %v", err)
+	}
+	if _, _, err := q.Submit("b", noop); !errors.Is(err, ErrQueueFull) {
+		t.Errorf("Submit past MaxPending error = %v, want ErrQueueFull", err)
+	}
+}
+
+func TestQueue_FailureAndPanic(t *testing.T) {
+	q := NewQueue(DefaultConfig())
+	q.Start(t.Context())
+
+	failed, _, _ := q.Submit("a", func(context.Context, *Progress) error { return errors.New("backend down") })
+	panicked, _, _ := q.Submit("b", func(context.Context, *Progress) error { panic("boom") })
+	if s := waitDone(t, q, failed.ID); s.Err != "backend down" {
+		t.Errorf("Err = %q", s.Err)
+	}
+	if s := waitDone(t, q, panicked.ID); s.Err != "task panic: boom" {
+		t.Errorf("Err = %q", s.Err)
+	}
+	// A panicking task does not leave its key blocked.
+	if _, created, _ := q.Submit("b", func(context.Context, *Progress) error { return nil }); !created {
+		t.Error("key still active after a panic")
+	}
+}
+
+func TestQueue_Retention(t *testing.T) {
+	q := NewQueue(Config{Retention: time.Minute})
+	now := time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+	var mu sync.Mutex
+	q.now = func() time.Time { mu.Lock(); defer mu.Unlock(); return now }
+	q.Start(t.Context())
+
+	s, _, _ := q.Submit("a", func(context.Context, *Progress) error { return nil })
+	waitDone(t, q, s.ID)
+	mu.Lock()
+	now = now.Add(2 * time.Minute)
+	mu.Unlock()
+	if _, ok := q.Status(s.ID); ok {
+		t.Error("finished task kept past the retention")
+	}
+}
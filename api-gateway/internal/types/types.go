@@ -1,7 +1,12 @@
 // Package types defines shared request/response types for the API gateway.
 package types
 
-import "time"
+import (
+	"time"
+
+	"github.com/learnbot/shared/dataquality"
+	"github.com/learnbot/shared/validation"
+)
 
 // ─────────────────────────────────────────────────────────────────────────────
 // Standard API response envelope
@@ -18,6 +23,10 @@ type APIResponse struct {
 	// Error contains error details when Success is false.
 	Error *APIError `json:"error,omitempty"`
 
+	// Errors lists the invalid request fields of a 422 response; the same
+	// list is in Error.Details.
+	Errors []FieldError `json:"errors,omitempty"`
+
 	// Meta contains optional metadata (pagination, etc.).
 	Meta *ResponseMeta `json:"meta,omitempty"`
 }
@@ -34,14 +43,9 @@ type APIError struct {
 	Details []FieldError `json:"details,omitempty"`
 }
 
-// FieldError represents a validation error for a specific field.
-type FieldError struct {
-	// Field is the field name that failed validation.
-	Field string `json:"field"`
-
-	// Message describes the validation failure.
-	Message string `json:"message"`
-}
+// FieldError represents a validation error for a specific field: its name,
+// a machine-readable code such as "required" and a message.
+type FieldError = validation.FieldError
 
 // ResponseMeta contains pagination and other metadata.
 type ResponseMeta struct {
@@ -62,13 +66,13 @@ type ResponseMeta struct {
 // RegisterRequest is the input for user registration.
 type RegisterRequest struct {
 	// Email is the user's email address.
-	Email string `json:"email"`
+	Email string `json:"email" validate:"required,email"`
 
 	// Password is the user's password (min 8 characters).
-	Password string `json:"password"`
+	Password string `json:"password" validate:"required,min=8"`
 
 	// FullName is the user's display name.
-	FullName string `json:"full_name"`
+	FullName string `json:"full_name" validate:"required,max=200"`
 }
 
 // LoginRequest is the input for user login.
@@ -88,10 +92,46 @@ type AuthResponse struct {
 	// ExpiresAt is the token expiration time.
 	ExpiresAt time.Time `json:"expires_at"`
 
+	// RefreshToken is a one-time token exchanged for a new access token at
+	// POST /api/auth/refresh.
+	RefreshToken string `json:"refresh_token"`
+
+	// RefreshExpiresAt is the refresh token expiration time.
+	RefreshExpiresAt time.Time `json:"refresh_expires_at"`
+
 	// User contains basic user information.
 	User UserInfo `json:"user"`
 }
 
+// RefreshRequest is the input for token refresh and logout.
+type RefreshRequest struct {
+	// RefreshToken is the refresh token from the last auth response.
+	RefreshToken string `json:"refresh_token"`
+}
+
+// RoleUpdateRequest is the input for replacing a user's roles.
+type RoleUpdateRequest struct {
+	// Roles lists the roles to grant: "user", "curator" or "admin". The
+	// user role is always kept.
+	Roles []string `json:"roles"`
+}
+
+// ForgotPasswordRequest is the input for requesting a password reset link.
+type ForgotPasswordRequest struct {
+	// Email is the account's email address.
+	Email string `json:"email"`
+}
+
+// ResetPasswordRequest is the input for setting a new password with a reset
+// token.
+type ResetPasswordRequest struct {
+	// Token is the token from the password reset email.
+	Token string `json:"token" validate:"required"`
+
+	// Password is the new password (min 8 characters).
+	Password string `json:"password" validate:"required,min=8"`
+}
+
 // UserInfo contains basic user information included in auth responses.
 type UserInfo struct {
 	// ID is the user's unique identifier.
@@ -102,6 +142,12 @@ type UserInfo struct {
 
 	// FullName is the user's display name.
 	FullName string `json:"full_name"`
+
+	// EmailVerified indicates whether the user has verified their email.
+	EmailVerified bool `json:"email_verified"`
+
+	// Roles lists the user's roles: "user", plus "curator" or "admin".
+	Roles []string `json:"roles"`
 }
 
 // JWTClaims represents the claims stored in a JWT token.
@@ -112,8 +158,17 @@ type JWTClaims struct {
 	// Email is the authenticated user's email.
 	Email string `json:"email"`
 
+	// Version is the claims version; tokens without one carry no roles.
+	Version int `json:"ver,omitempty"`
+
+	// Roles lists the user's roles.
+	Roles []string `json:"roles,omitempty"`
+
 	// IsAdmin indicates whether the user has admin privileges.
 	IsAdmin bool `json:"is_admin"`
+
+	// EmailUnverified is set for users who have not verified their email.
+	EmailUnverified bool `json:"email_unverified,omitempty"`
 }
 
 // ─────────────────────────────────────────────────────────────────────────────
@@ -131,6 +186,9 @@ type ProfileUpdateRequest struct {
 	WebsiteURL        *string  `json:"website_url,omitempty"`
 	YearsOfExperience *float64 `json:"years_of_experience,omitempty"`
 	IsOpenToWork      *bool    `json:"is_open_to_work,omitempty"`
+
+	// WeeklyDigest opts in to or out of the weekly digest email.
+	WeeklyDigest *bool `json:"weekly_digest,omitempty"`
 }
 
 // SkillUpdateRequest is the input for updating user skills.
@@ -147,6 +205,39 @@ type SkillInput struct {
 	IsPrimary         bool     `json:"is_primary"`
 }
 
+// SkillSuggestion is a skill often listed alongside another.
+type SkillSuggestion struct {
+	// Skill is the canonical skill ID and Name its display name.
+	Skill string `json:"skill"`
+	Name  string `json:"name"`
+
+	// Score is how strongly the skills are associated, in (0, 1]; 1 means
+	// they are always listed together.
+	Score float64 `json:"score"`
+
+	// Count is the number of resumes or jobs listing both skills.
+	Count int `json:"count"`
+}
+
+// SkillSuggestionsResponse is the response of
+// GET /api/v1/skills/{skill}/suggestions.
+type SkillSuggestionsResponse struct {
+	// Skill is the canonical ID of the requested skill and Name its
+	// display name.
+	Skill string `json:"skill"`
+	Name  string `json:"name"`
+
+	// Suggestions are best first, without the signed-in user's skills.
+	Suggestions []SkillSuggestion `json:"suggestions"`
+
+	// Source is the corpus the suggestions were computed from: "primary"
+	// (parsed resumes) or "fallback" (scraped jobs).
+	Source string `json:"source"`
+
+	// ComputedAt is when the suggestions were last recomputed.
+	ComputedAt time.Time `json:"computed_at"`
+}
+
 // ─────────────────────────────────────────────────────────────────────────────
 // Job matching types
 // ─────────────────────────────────────────────────────────────────────────────
@@ -168,6 +259,9 @@ type JobSearchRequest struct {
 	// Industry filters by industry.
 	Industry string `json:"industry,omitempty"`
 
+	// IncludeExpired also returns jobs that are no longer open.
+	IncludeExpired bool `json:"include_expired,omitempty"`
+
 	// Limit is the maximum number of results (default 20).
 	Limit int `json:"limit,omitempty"`
 
@@ -185,6 +279,14 @@ type JobSummary struct {
 	RequiredSkills  []string `json:"required_skills"`
 	PostedAt        string   `json:"posted_at,omitempty"`
 	MatchScore      *float64 `json:"match_score,omitempty"`
+
+	// Status is the posting status reported by the job aggregator
+	// ("active", "expired", "filled"); empty means active.
+	Status string `json:"status,omitempty"`
+
+	// ExpiryReason explains why an expired job was closed
+	// ("stale", "not_found", "redirected", "closed_notice").
+	ExpiryReason string `json:"expiry_reason,omitempty"`
 }
 
 // JobDetail is the full job representation.
@@ -234,7 +336,8 @@ type JobRequirementsInput struct {
 	Title               string   `json:"title"`
 	RequiredSkills      []string `json:"required_skills"`
 	PreferredSkills     []string `json:"preferred_skills,omitempty"`
-	MinYearsExperience  float64  `json:"min_years_experience"`
+	NiceToHaveSkills    []string `json:"nice_to_have_skills,omitempty"`
+	MinYearsExperience  float64  `json:"min_years_experience" validate:"min=0"`
 	RequiredDegreeLevel string   `json:"required_degree_level,omitempty"`
 	LocationType        string   `json:"location_type,omitempty"`
 	Industry            string   `json:"industry,omitempty"`
@@ -256,12 +359,14 @@ type TrainingRecommendationRequest struct {
 // LearningPreferencesInput captures user learning preferences.
 type LearningPreferencesInput struct {
 	PreferFree             bool     `json:"prefer_free"`
-	MaxBudgetUSD           float64  `json:"max_budget_usd,omitempty"`
-	WeeklyHoursAvailable   float64  `json:"weekly_hours_available"`
+	MaxBudget              float64  `json:"max_budget,omitempty" validate:"min=0"`
+	MaxBudgetUSD           float64  `json:"max_budget_usd,omitempty" validate:"min=0"` // deprecated: use max_budget
+	Currency               string   `json:"currency,omitempty" validate:"omitempty,min=3,max=3"`
+	WeeklyHoursAvailable   float64  `json:"weekly_hours_available" validate:"min=0,max=168"`
 	PreferHandsOn          bool     `json:"prefer_hands_on"`
 	PreferCertificates     bool     `json:"prefer_certificates"`
-	TargetDate             string   `json:"target_date,omitempty"`
-	PreferredResourceTypes []string `json:"preferred_resource_types,omitempty"`
+	TargetDate             string   `json:"target_date,omitempty" validate:"omitempty,date"`
+	PreferredResourceTypes []string `json:"preferred_resource_types,omitempty" validate:"oneof=course certification documentation video book practice article project"`
 	ExcludedProviders      []string `json:"excluded_providers,omitempty"`
 }
 
@@ -278,3 +383,286 @@ type ResourceSearchRequest struct {
 	Limit          int    `json:"limit,omitempty"`
 	Offset         int    `json:"offset,omitempty"`
 }
+
+// ReadinessSnapshot records the outcome of one gap analysis of a user
+// against a job.
+type ReadinessSnapshot struct {
+	// JobID is the job the user was analyzed against.
+	JobID string `json:"job_id"`
+
+	// ReadinessScore is the readiness score [0, 100].
+	ReadinessScore float64 `json:"readiness_score"`
+
+	// CriticalGaps, ImportantGaps and NiceToHaveGaps count the gaps by
+	// category.
+	CriticalGaps   int `json:"critical_gaps"`
+	ImportantGaps  int `json:"important_gaps"`
+	NiceToHaveGaps int `json:"nice_to_have_gaps"`
+
+	// TotalLearningHours is the estimated time to close every gap.
+	TotalLearningHours int `json:"total_learning_hours"`
+
+	// GapSkills lists the skills of all gaps.
+	GapSkills []string `json:"gap_skills"`
+
+	// RecordedAt is when the analysis ran.
+	RecordedAt time.Time `json:"recorded_at"`
+}
+
+// ReadinessPoint is a snapshot in a readiness history, with its changes
+// since the previous snapshot.
+type ReadinessPoint struct {
+	ReadinessSnapshot
+
+	// ReadinessDelta is the change in readiness score; zero on the first
+	// snapshot.
+	ReadinessDelta float64 `json:"readiness_delta"`
+
+	// ClosedGaps and NewGaps list the gap skills that disappeared and
+	// appeared.
+	ClosedGaps []string `json:"closed_gaps,omitempty"`
+	NewGaps    []string `json:"new_gaps,omitempty"`
+}
+
+// ReadinessChange summarizes a readiness history from its first snapshot
+// to its latest.
+type ReadinessChange struct {
+	// Since is when the first snapshot was recorded.
+	Since time.Time `json:"since"`
+
+	ReadinessDelta          float64 `json:"readiness_delta"`
+	CriticalGapsDelta       int     `json:"critical_gaps_delta"`
+	ImportantGapsDelta      int     `json:"important_gaps_delta"`
+	NiceToHaveGapsDelta     int     `json:"nice_to_have_gaps_delta"`
+	TotalLearningHoursDelta int     `json:"total_learning_hours_delta"`
+
+	// ClosedGaps and NewGaps list the gap skills of the first snapshot
+	// that are gone from the latest, and the other way round.
+	ClosedGaps []string `json:"closed_gaps"`
+	NewGaps    []string `json:"new_gaps"`
+
+	// Summary describes the change in a sentence, such as "Readiness
+	// improved 12 points since March 3; Kubernetes gap closed".
+	Summary string `json:"summary"`
+}
+
+// ReadinessHistoryResponse is the response of
+// GET /api/v1/me/readiness-history.
+type ReadinessHistoryResponse struct {
+	JobID string `json:"job_id"`
+
+	// Snapshots are the recorded analyses, oldest first.
+	Snapshots []ReadinessPoint `json:"snapshots"`
+
+	// Change compares the latest snapshot with the first; it is nil with
+	// fewer than two snapshots.
+	Change *ReadinessChange `json:"change"`
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Admin data quality types
+// ─────────────────────────────────────────────────────────────────────────────
+
+// DataQualityReport is the merged pipeline data quality dashboard.
+type DataQualityReport struct {
+	// Status is "degraded" if any service reports issues, otherwise
+	// "unknown" if any service could not be reached, otherwise "healthy".
+	Status      string               `json:"status"`
+	GeneratedAt time.Time            `json:"generated_at"`
+	Sections    []DataQualitySection `json:"sections"`
+}
+
+// DataQualitySection is one service's contribution to the dashboard.
+type DataQualitySection struct {
+	Service     string              `json:"service"`
+	Status      string              `json:"status"` // "healthy", "degraded" or "unknown"
+	GeneratedAt *time.Time          `json:"generated_at,omitempty"`
+	PeriodStart *time.Time          `json:"period_start,omitempty"`
+	PeriodEnd   *time.Time          `json:"period_end,omitempty"`
+	Checks      []dataquality.Check `json:"checks"`
+	Error       string              `json:"error,omitempty"`
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Admin response cache types
+// ─────────────────────────────────────────────────────────────────────────────
+
+// CachePurgeResponse is the response of POST /api/admin/cache/purge.
+type CachePurgeResponse struct {
+	// Prefix is the path prefix purged; empty when the whole cache was.
+	Prefix string `json:"prefix"`
+	// Purged is the number of cached responses removed.
+	Purged int `json:"purged"`
+	// Remaining is the number of responses still cached.
+	Remaining int `json:"remaining"`
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Saved job re-scoring types
+// ─────────────────────────────────────────────────────────────────────────────
+
+// RescoreStatus is the progress of re-scoring a user's saved jobs, returned
+// by POST /api/v1/me/saved-jobs/rescore and
+// GET /api/v1/me/saved-jobs/rescore/{id}.
+type RescoreStatus struct {
+	ID string `json:"id"`
+	// Status is "pending", "running" or "done".
+	Status string `json:"status"`
+	// Total is the number of saved jobs, once they are listed. Scored and
+	// Failed count the saved jobs done so far.
+	Total  int `json:"total"`
+	Scored int `json:"scored"`
+	Failed int `json:"failed"`
+	// Failures holds the first saved jobs that could not be scored.
+	Failures []RescoreFailure `json:"failures,omitempty"`
+	// Error is why re-scoring stopped early, e.g. the job aggregator was
+	// unreachable.
+	Error       string     `json:"error,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+	StartedAt   *time.Time `json:"started_at,omitempty"`
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+}
+
+// RescoreFailure is a saved job that could not be re-scored.
+type RescoreFailure struct {
+	SavedJobID string `json:"saved_job_id"`
+	Error      string `json:"error"`
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Data export types
+// ─────────────────────────────────────────────────────────────────────────────
+
+// ExportManifest is the manifest.json of a GET /api/v1/me/export archive.
+type ExportManifest struct {
+	// FormatVersion is the version of the archive layout.
+	FormatVersion int       `json:"format_version"`
+	UserID        string    `json:"user_id"`
+	ExportedAt    time.Time `json:"exported_at"`
+	// Files lists the category files in the archive.
+	Files []ExportFile `json:"files"`
+	// Errors lists the categories that could not be exported, described in
+	// errors.json.
+	Errors []string `json:"errors,omitempty"`
+}
+
+// ExportFile is one category file of an export archive.
+type ExportFile struct {
+	Name     string `json:"name"`
+	Category string `json:"category"`
+	// SchemaVersion is the version of the file's format, raised when its
+	// fields change incompatibly.
+	SchemaVersion int `json:"schema_version"`
+}
+
+// ExportError is a category that could not be exported, listed in
+// errors.json.
+type ExportError struct {
+	Category string `json:"category"`
+	Error    string `json:"error"`
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Account deletion types
+// ─────────────────────────────────────────────────────────────────────────────
+
+// DeletionStatus is the status of an account deletion or of one of its
+// targets.
+type DeletionStatus string
+
+const (
+	// DeletionPending is a deletion with data left to erase, or a target
+	// that has not confirmed its erasure yet.
+	DeletionPending DeletionStatus = "pending"
+
+	// DeletionCompleted is a deletion whose every target has confirmed its
+	// erasure.
+	DeletionCompleted DeletionStatus = "completed"
+)
+
+// AccountDeletion is the erasure of a deleted account's data from the
+// backend services, retried until every one of them confirms it.
+type AccountDeletion struct {
+	ID          string           `json:"id"`
+	UserID      string           `json:"user_id"`
+	Status      DeletionStatus   `json:"status"`
+	RequestedAt time.Time        `json:"requested_at"`
+	CompletedAt *time.Time       `json:"completed_at,omitempty"`
+	Targets     []DeletionTarget `json:"targets"`
+}
+
+// DeletionTarget is the erasure of a deleted account's data from one
+// service.
+type DeletionTarget struct {
+	// Name is the service, e.g. "learning-resources".
+	Name   string         `json:"name"`
+	Status DeletionStatus `json:"status"`
+	// Attempts counts the erasure requests sent to the service.
+	Attempts int `json:"attempts"`
+	// LastError is why the last attempt failed, if it did.
+	LastError string `json:"last_error,omitempty"`
+	// NextAttemptAt is when the erasure is attempted next, while pending.
+	NextAttemptAt *time.Time `json:"next_attempt_at,omitempty"`
+	CompletedAt   *time.Time `json:"completed_at,omitempty"`
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// API key types
+// ─────────────────────────────────────────────────────────────────────────────
+
+// APIKeyCreateRequest is the input for creating an API key.
+type APIKeyCreateRequest struct {
+	// Name describes the client the key is for, e.g. "acme-nightly-sync".
+	Name string `json:"name"`
+
+	// Scopes lists the permissions to grant: "read:jobs", "read:resources"
+	// and "write:progress".
+	Scopes []string `json:"scopes"`
+
+	// RequestsPerSecond and Burst override the default per-key rate limit
+	// when both are set.
+	RequestsPerSecond float64 `json:"requests_per_second,omitempty"`
+	Burst             float64 `json:"burst,omitempty"`
+}
+
+// APIKeyInfo describes an API key without the key itself.
+type APIKeyInfo struct {
+	ID                string     `json:"id"`
+	Name              string     `json:"name"`
+	Hint              string     `json:"hint"`
+	Scopes            []string   `json:"scopes"`
+	RequestsPerSecond float64    `json:"requests_per_second"`
+	Burst             float64    `json:"burst"`
+	CreatedBy         string     `json:"created_by"`
+	CreatedAt         time.Time  `json:"created_at"`
+	RevokedAt         *time.Time `json:"revoked_at,omitempty"`
+}
+
+// APIKeyCreateResponse is returned once, when a key is created. Key cannot
+// be retrieved again.
+type APIKeyCreateResponse struct {
+	APIKeyInfo
+
+	// Key is the API key, sent by the client in the X-API-Key header.
+	Key string `json:"key"`
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Health types
+// ─────────────────────────────────────────────────────────────────────────────
+
+// HealthResponse is the body of GET /health.
+type HealthResponse struct {
+	Status   string          `json:"status"` // "ok" or "degraded"
+	Service  string          `json:"service"`
+	Version  string          `json:"version"`
+	Services []ServiceHealth `json:"services"`
+}
+
+// ServiceHealth is the result of probing one backend.
+type ServiceHealth struct {
+	Name      string `json:"name"`
+	Status    string `json:"status"` // "ok" or "down"
+	LatencyMS int64  `json:"latency_ms"`
+	Error     string `json:"error,omitempty"`
+}
@@ -0,0 +1,68 @@
+// Command rotate-keys re-wraps encrypted resume files and profile PII under
+// the active master key.
+//
+// Usage:
+//
+//	LEARNBOT_MASTER_KEYS="2024-10:<new>,2024-01:<old>" \
+//	LEARNBOT_MASTER_KEY_VERSION=2024-10 \
+//	rotate-keys -dsn "$DATABASE_URL"
+//
+// Both the new and the retired keys must be configured. Once the command
+// reports success the retired key can be removed from LEARNBOT_MASTER_KEYS.
+package main
+
+import (
+	"context"
+	"database/sql"
+	"flag"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+
+	_ "github.com/lib/pq"
+
+	"github.com/learnbot/database/encryption"
+	"github.com/learnbot/database/repository"
+)
+
+func main() {
+	dsn := flag.String("dsn", os.Getenv("DATABASE_URL"), "PostgreSQL connection string")
+	batchSize := flag.Int("batch-size", 500, "rows re-wrapped per query")
+	flag.Parse()
+
+	logger := log.New(os.Stdout, "[rotate-keys] ", log.LstdFlags)
+
+	if *dsn == "" {
+		logger.Fatal("DATABASE_URL environment variable or -dsn flag is required")
+	}
+
+	keys, err := encryption.KeyringFromEnv()
+	if err != nil {
+		logger.Fatalf("load master keys: %v", err)
+	}
+
+	db, err := sql.Open("postgres", *dsn)
+	if err != nil {
+		logger.Fatalf("failed to open database: %v", err)
+	}
+	defer db.Close()
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	if err := db.PingContext(ctx); err != nil {
+		logger.Fatalf("failed to connect to database: %v", err)
+	}
+
+	logger.Printf("re-wrapping data keys under master key %q", keys.ActiveVersion())
+	res, err := repository.NewKeyRotator(db, keys, *batchSize).Rotate(ctx)
+	if res != nil {
+		logger.Printf("rotated: resume_files=%d resume_texts=%d profile_phones=%d profile_locations=%d",
+			res.ResumeFiles, res.ResumeTexts, res.ProfilePhones, res.ProfileLocations)
+	}
+	if err != nil {
+		logger.Fatalf("rotation failed (safe to re-run): %v", err)
+	}
+	logger.Println("rotation complete")
+}
@@ -0,0 +1,301 @@
+package encryption
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Errors
+// ─────────────────────────────────────────────────────────────────────────────
+
+var (
+	// ErrCorruptCiphertext means an envelope is truncated, malformed, or failed
+	// authentication (tampering, or a mismatched associated-data context).
+	ErrCorruptCiphertext = errors.New("encryption: ciphertext is corrupt")
+
+	// ErrWrongKey means the data key could not be unwrapped with the master
+	// key of the recorded version.
+	ErrWrongKey = errors.New("encryption: master key does not match envelope")
+
+	// ErrUnknownKeyVersion means an envelope references a master key version
+	// the keyring does not hold.
+	ErrUnknownKeyVersion = errors.New("encryption: unknown master key version")
+
+	// ErrInvalidKey means master key configuration is malformed.
+	ErrInvalidKey = errors.New("encryption: invalid master key")
+
+	// ErrNoKeyring means a sealed value was read without a keyring configured.
+	ErrNoKeyring = errors.New("encryption: no master key configured")
+)
+
+// DecryptError reports a failure to open an envelope. It wraps one of the
+// sentinel errors above, so callers can use errors.Is.
+type DecryptError struct {
+	// KeyVersion is the master key version recorded in the envelope, if the
+	// header could be read.
+	KeyVersion string
+	Err        error
+}
+
+func (e *DecryptError) Error() string {
+	if e.KeyVersion != "" {
+		return fmt.Sprintf("%v (key version %q)", e.Err, e.KeyVersion)
+	}
+	return e.Err.Error()
+}
+
+func (e *DecryptError) Unwrap() error { return e.Err }
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Envelope format
+// ─────────────────────────────────────────────────────────────────────────────
+
+// Binary layout (all lengths big-endian):
+//
+//	format (1) | len(version) (1) | version | len(wrapped) (2) | wrapped |
+//	nonce (12) | ciphertext+tag
+//
+// wrapped is nonce (12) | AES-GCM(master, dataKey) with the format byte and
+// version as associated data, binding the wrapped key to its header.
+const (
+	formatV1     = 0x01
+	dataKeySize  = 32
+	nonceSize    = 12
+	gcmTagSize   = 16
+	wrappedSize  = nonceSize + dataKeySize + gcmTagSize
+	minEnvelope  = 1 + 1 + 1 + 2 + wrappedSize + nonceSize + gcmTagSize
+	sealedPrefix = "enc:"
+)
+
+// envelope is the parsed form of a sealed record.
+type envelope struct {
+	version    string
+	wrappedKey []byte
+	nonce      []byte
+	ciphertext []byte
+}
+
+// parseEnvelope splits raw into its fields without decrypting anything.
+func parseEnvelope(raw []byte) (*envelope, error) {
+	if len(raw) < minEnvelope || raw[0] != formatV1 {
+		return nil, &DecryptError{Err: ErrCorruptCiphertext}
+	}
+	vlen := int(raw[1])
+	pos := 2
+	if vlen == 0 || len(raw) < pos+vlen+2 {
+		return nil, &DecryptError{Err: ErrCorruptCiphertext}
+	}
+	env := &envelope{version: string(raw[pos : pos+vlen])}
+	pos += vlen
+
+	wlen := int(binary.BigEndian.Uint16(raw[pos:]))
+	pos += 2
+	if wlen != wrappedSize || len(raw) < pos+wlen+nonceSize+gcmTagSize {
+		return nil, &DecryptError{KeyVersion: env.version, Err: ErrCorruptCiphertext}
+	}
+	env.wrappedKey = raw[pos : pos+wlen]
+	pos += wlen
+	env.nonce = raw[pos : pos+nonceSize]
+	pos += nonceSize
+	env.ciphertext = raw[pos:]
+	return env, nil
+}
+
+// header returns the format byte and version, used as associated data when
+// wrapping the data key.
+func (e *envelope) header() []byte {
+	return append([]byte{formatV1}, e.version...)
+}
+
+// marshal serialises the envelope to its binary layout.
+func (e *envelope) marshal() []byte {
+	out := make([]byte, 0, 1+1+len(e.version)+2+len(e.wrappedKey)+len(e.nonce)+len(e.ciphertext))
+	out = append(out, formatV1, byte(len(e.version)))
+	out = append(out, e.version...)
+	out = binary.BigEndian.AppendUint16(out, uint16(len(e.wrappedKey)))
+	out = append(out, e.wrappedKey...)
+	out = append(out, e.nonce...)
+	return append(out, e.ciphertext...)
+}
+
+// KeyVersion returns the master key version recorded in a sealed envelope.
+func KeyVersion(sealed []byte) (string, error) {
+	env, err := parseEnvelope(sealed)
+	if err != nil {
+		return "", err
+	}
+	return env.version, nil
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Seal / Open / Rewrap
+// ─────────────────────────────────────────────────────────────────────────────
+
+// Seal encrypts plaintext under a fresh data key wrapped by the active
+// master key. aad is authenticated but not stored; pass a value that
+// identifies the record (e.g. "user_profiles.phone:<user_id>") so that a
+// ciphertext copied to another row fails to open.
+func (k *Keyring) Seal(plaintext, aad []byte) ([]byte, error) {
+	if k == nil {
+		return nil, ErrNoKeyring
+	}
+	dataKey := make([]byte, dataKeySize)
+	if _, err := rand.Read(dataKey); err != nil {
+		return nil, fmt.Errorf("generate data key: %w", err)
+	}
+
+	env := &envelope{version: k.active}
+	wrapped, err := k.wrap(env, dataKey)
+	if err != nil {
+		return nil, err
+	}
+	env.wrappedKey = wrapped
+
+	payload, err := newAEAD(dataKey)
+	if err != nil {
+		return nil, err
+	}
+	env.nonce = make([]byte, nonceSize)
+	if _, err := rand.Read(env.nonce); err != nil {
+		return nil, fmt.Errorf("generate nonce: %w", err)
+	}
+	env.ciphertext = payload.Seal(nil, env.nonce, plaintext, aad)
+	return env.marshal(), nil
+}
+
+// Open decrypts an envelope produced by Seal. aad must match the value
+// passed to Seal. Failures are returned as *DecryptError.
+func (k *Keyring) Open(sealed, aad []byte) ([]byte, error) {
+	env, err := parseEnvelope(sealed)
+	if err != nil {
+		return nil, err
+	}
+	if k == nil {
+		return nil, &DecryptError{KeyVersion: env.version, Err: ErrNoKeyring}
+	}
+	dataKey, err := k.unwrap(env)
+	if err != nil {
+		return nil, err
+	}
+	payload, err := newAEAD(dataKey)
+	if err != nil {
+		return nil, err
+	}
+	plaintext, err := payload.Open(nil, env.nonce, env.ciphertext, aad)
+	if err != nil {
+		return nil, &DecryptError{KeyVersion: env.version, Err: ErrCorruptCiphertext}
+	}
+	return plaintext, nil
+}
+
+// Rewrap re-encrypts the data key of sealed under the active master key,
+// leaving the payload ciphertext untouched. It reports false (and returns
+// sealed unchanged) when the envelope already uses the active version.
+func (k *Keyring) Rewrap(sealed []byte) ([]byte, bool, error) {
+	env, err := parseEnvelope(sealed)
+	if err != nil {
+		return nil, false, err
+	}
+	if k == nil {
+		return nil, false, &DecryptError{KeyVersion: env.version, Err: ErrNoKeyring}
+	}
+	if env.version == k.active {
+		return sealed, false, nil
+	}
+	dataKey, err := k.unwrap(env)
+	if err != nil {
+		return nil, false, err
+	}
+
+	env.version = k.active
+	wrapped, err := k.wrap(env, dataKey)
+	if err != nil {
+		return nil, false, err
+	}
+	env.wrappedKey = wrapped
+	return env.marshal(), true, nil
+}
+
+// wrap encrypts dataKey with the master key of env.version.
+func (k *Keyring) wrap(env *envelope, dataKey []byte) ([]byte, error) {
+	master, ok := k.aeads[env.version]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownKeyVersion, env.version)
+	}
+	nonce := make([]byte, nonceSize, wrappedSize)
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("generate nonce: %w", err)
+	}
+	return master.Seal(nonce, nonce, dataKey, env.header()), nil
+}
+
+// unwrap decrypts the data key of env with the matching master key.
+func (k *Keyring) unwrap(env *envelope) ([]byte, error) {
+	master, ok := k.aeads[env.version]
+	if !ok {
+		return nil, &DecryptError{KeyVersion: env.version, Err: ErrUnknownKeyVersion}
+	}
+	dataKey, err := master.Open(nil, env.wrappedKey[:nonceSize], env.wrappedKey[nonceSize:], env.header())
+	if err != nil {
+		return nil, &DecryptError{KeyVersion: env.version, Err: ErrWrongKey}
+	}
+	return dataKey, nil
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Text column helpers
+// ─────────────────────────────────────────────────────────────────────────────
+
+// IsSealedString reports whether s was produced by SealString.
+func IsSealedString(s string) bool {
+	return strings.HasPrefix(s, sealedPrefix)
+}
+
+// SealString seals s for storage in a TEXT column as "enc:<base64>".
+func (k *Keyring) SealString(s, aad string) (string, error) {
+	sealed, err := k.Seal([]byte(s), []byte(aad))
+	if err != nil {
+		return "", err
+	}
+	return sealedPrefix + base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// OpenString reverses SealString. Values without the "enc:" prefix are
+// returned unchanged so that rows written before encryption was enabled
+// remain readable; the method may be called on a nil Keyring for them.
+func (k *Keyring) OpenString(s, aad string) (string, error) {
+	if !IsSealedString(s) {
+		return s, nil
+	}
+	raw, err := base64.StdEncoding.DecodeString(s[len(sealedPrefix):])
+	if err != nil {
+		return "", &DecryptError{Err: ErrCorruptCiphertext}
+	}
+	plaintext, err := k.Open(raw, []byte(aad))
+	if err != nil {
+		return "", err
+	}
+	return string(plaintext), nil
+}
+
+// RewrapString applies Rewrap to a value produced by SealString. Plaintext
+// values are returned unchanged with false.
+func (k *Keyring) RewrapString(s string) (string, bool, error) {
+	if !IsSealedString(s) {
+		return s, false, nil
+	}
+	raw, err := base64.StdEncoding.DecodeString(s[len(sealedPrefix):])
+	if err != nil {
+		return "", false, &DecryptError{Err: ErrCorruptCiphertext}
+	}
+	rewrapped, changed, err := k.Rewrap(raw)
+	if err != nil || !changed {
+		return s, false, err
+	}
+	return sealedPrefix + base64.StdEncoding.EncodeToString(rewrapped), true, nil
+}
@@ -0,0 +1,283 @@
+package encryption
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"testing"
+)
+
+func randomKey(t *testing.T) []byte {
+	t.Helper()
+	key := make([]byte, MasterKeySize)
+	if _, err := rand.Read(key); err != nil {
+		t.Fatalf("rand: %v", err)
+	}
+	return key
+}
+
+func mustKeyring(t *testing.T, active string, keys map[string][]byte) *Keyring {
+	t.Helper()
+	kr, err := NewKeyring(active, keys)
+	if err != nil {
+		t.Fatalf("NewKeyring: %v", err)
+	}
+	return kr
+}
+
+func TestSealOpen_RoundTrip(t *testing.T) {
+	kr := mustKeyring(t, "v1", map[string][]byte{"v1": randomKey(t)})
+	plaintext := []byte("Jane Doe\n+1 555 0100\n42 Main St, Springfield")
+	aad := []byte("resume_blobs:123")
+
+	sealed, err := kr.Seal(plaintext, aad)
+	if err != nil {
+		t.Fatalf("Seal: %v", err)
+	}
+	if bytes.Contains(sealed, []byte("555 0100")) {
+		t.Fatal("sealed envelope contains plaintext")
+	}
+
+	got, err := kr.Open(sealed, aad)
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	if !bytes.Equal(got, plaintext) {
+		t.Errorf("round trip mismatch: got %q", got)
+	}
+
+	version, err := KeyVersion(sealed)
+	if err != nil || version != "v1" {
+		t.Errorf("KeyVersion = %q, %v; want v1", version, err)
+	}
+}
+
+func TestSeal_EmptyPlaintext(t *testing.T) {
+	kr := mustKeyring(t, "v1", map[string][]byte{"v1": randomKey(t)})
+	sealed, err := kr.Seal(nil, nil)
+	if err != nil {
+		t.Fatalf("Seal: %v", err)
+	}
+	got, err := kr.Open(sealed, nil)
+	if err != nil || len(got) != 0 {
+		t.Errorf("Open = %q, %v; want empty", got, err)
+	}
+}
+
+func TestSeal_CiphertextDiffersPerRecord(t *testing.T) {
+	kr := mustKeyring(t, "v1", map[string][]byte{"v1": randomKey(t)})
+	plaintext := []byte("+1 555 0100")
+
+	a, err := kr.Seal(plaintext, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := kr.Seal(plaintext, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Equal(a, b) {
+		t.Fatal("identical plaintext produced identical envelopes")
+	}
+
+	ea, _ := parseEnvelope(a)
+	eb, _ := parseEnvelope(b)
+	if bytes.Equal(ea.wrappedKey, eb.wrappedKey) {
+		t.Error("records share a wrapped data key")
+	}
+	if bytes.Equal(ea.ciphertext, eb.ciphertext) {
+		t.Error("records share a payload ciphertext")
+	}
+}
+
+func TestRewrap_RotatesWithoutReencryptingPayload(t *testing.T) {
+	oldKey, newKey := randomKey(t), randomKey(t)
+	before := mustKeyring(t, "v1", map[string][]byte{"v1": oldKey})
+	sealed, err := before.Seal([]byte("secret"), []byte("ctx"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	after := mustKeyring(t, "v2", map[string][]byte{"v1": oldKey, "v2": newKey})
+	rewrapped, changed, err := after.Rewrap(sealed)
+	if err != nil {
+		t.Fatalf("Rewrap: %v", err)
+	}
+	if !changed {
+		t.Fatal("Rewrap reported no change for a retired key version")
+	}
+	if v, _ := KeyVersion(rewrapped); v != "v2" {
+		t.Errorf("rewrapped version = %q, want v2", v)
+	}
+
+	orig, _ := parseEnvelope(sealed)
+	next, _ := parseEnvelope(rewrapped)
+	if !bytes.Equal(orig.ciphertext, next.ciphertext) || !bytes.Equal(orig.nonce, next.nonce) {
+		t.Error("Rewrap must not touch the payload ciphertext")
+	}
+
+	// Only the new master key is needed after rotation.
+	newOnly := mustKeyring(t, "v2", map[string][]byte{"v2": newKey})
+	got, err := newOnly.Open(rewrapped, []byte("ctx"))
+	if err != nil || string(got) != "secret" {
+		t.Errorf("Open after rotation = %q, %v", got, err)
+	}
+
+	// Rewrapping again is a no-op.
+	again, changed, err := after.Rewrap(rewrapped)
+	if err != nil || changed || !bytes.Equal(again, rewrapped) {
+		t.Errorf("second Rewrap = changed %v, err %v", changed, err)
+	}
+}
+
+func TestOpen_WrongKey(t *testing.T) {
+	sealer := mustKeyring(t, "v1", map[string][]byte{"v1": randomKey(t)})
+	sealed, err := sealer.Seal([]byte("secret"), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	impostor := mustKeyring(t, "v1", map[string][]byte{"v1": randomKey(t)})
+	_, err = impostor.Open(sealed, nil)
+	if !errors.Is(err, ErrWrongKey) {
+		t.Fatalf("expected ErrWrongKey, got %v", err)
+	}
+	var de *DecryptError
+	if !errors.As(err, &de) || de.KeyVersion != "v1" {
+		t.Errorf("expected *DecryptError with version v1, got %#v", err)
+	}
+}
+
+func TestOpen_UnknownKeyVersion(t *testing.T) {
+	sealer := mustKeyring(t, "v1", map[string][]byte{"v1": randomKey(t)})
+	sealed, _ := sealer.Seal([]byte("secret"), nil)
+
+	other := mustKeyring(t, "v2", map[string][]byte{"v2": randomKey(t)})
+	if _, err := other.Open(sealed, nil); !errors.Is(err, ErrUnknownKeyVersion) {
+		t.Errorf("expected ErrUnknownKeyVersion, got %v", err)
+	}
+}
+
+func TestOpen_CorruptCiphertext(t *testing.T) {
+	kr := mustKeyring(t, "v1", map[string][]byte{"v1": randomKey(t)})
+	sealed, err := kr.Seal([]byte("some resume text"), []byte("row-1"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	flipLast := append([]byte(nil), sealed...)
+	flipLast[len(flipLast)-1] ^= 0xFF
+
+	flipWrapped := append([]byte(nil), sealed...)
+	flipWrapped[1+1+2+2+5] ^= 0xFF // inside the wrapped key
+
+	tests := []struct {
+		name    string
+		data    []byte
+		aad     []byte
+		wantErr error
+	}{
+		{"flipped payload byte", flipLast, []byte("row-1"), ErrCorruptCiphertext},
+		{"flipped wrapped key", flipWrapped, []byte("row-1"), ErrWrongKey},
+		{"truncated", sealed[:len(sealed)-20], []byte("row-1"), ErrCorruptCiphertext},
+		{"too short", sealed[:10], []byte("row-1"), ErrCorruptCiphertext},
+		{"unknown format", append([]byte{0x7F}, sealed[1:]...), []byte("row-1"), ErrCorruptCiphertext},
+		{"wrong record context", sealed, []byte("row-2"), ErrCorruptCiphertext},
+		{"empty", nil, nil, ErrCorruptCiphertext},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := kr.Open(tt.data, tt.aad)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("expected %v, got %v", tt.wantErr, err)
+			}
+			if got != nil {
+				t.Errorf("expected no plaintext on failure, got %q", got)
+			}
+		})
+	}
+}
+
+func TestSealString_RoundTripAndLegacyPassthrough(t *testing.T) {
+	kr := mustKeyring(t, "v1", map[string][]byte{"v1": randomKey(t)})
+
+	sealed, err := kr.SealString("+1 555 0100", "user_profiles.phone:u1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !IsSealedString(sealed) {
+		t.Fatalf("sealed value %q lacks prefix", sealed)
+	}
+	got, err := kr.OpenString(sealed, "user_profiles.phone:u1")
+	if err != nil || got != "+1 555 0100" {
+		t.Errorf("OpenString = %q, %v", got, err)
+	}
+
+	// Plaintext written before encryption was enabled is returned as-is,
+	// even without a keyring.
+	var none *Keyring
+	if got, err := none.OpenString("+1 555 0199", "x"); err != nil || got != "+1 555 0199" {
+		t.Errorf("legacy OpenString = %q, %v", got, err)
+	}
+	if _, err := none.OpenString(sealed, "user_profiles.phone:u1"); !errors.Is(err, ErrNoKeyring) {
+		t.Errorf("expected ErrNoKeyring, got %v", err)
+	}
+	if _, err := kr.OpenString("enc:!!!not-base64", "x"); !errors.Is(err, ErrCorruptCiphertext) {
+		t.Errorf("expected ErrCorruptCiphertext, got %v", err)
+	}
+}
+
+func TestRewrapString(t *testing.T) {
+	oldKey := randomKey(t)
+	before := mustKeyring(t, "v1", map[string][]byte{"v1": oldKey})
+	sealed, _ := before.SealString("raw resume text", "ctx")
+
+	after := mustKeyring(t, "v2", map[string][]byte{"v1": oldKey, "v2": randomKey(t)})
+	rewrapped, changed, err := after.RewrapString(sealed)
+	if err != nil || !changed {
+		t.Fatalf("RewrapString = changed %v, err %v", changed, err)
+	}
+	if got, err := after.OpenString(rewrapped, "ctx"); err != nil || got != "raw resume text" {
+		t.Errorf("OpenString after rewrap = %q, %v", got, err)
+	}
+
+	if out, changed, err := after.RewrapString("plain"); err != nil || changed || out != "plain" {
+		t.Errorf("plaintext RewrapString = %q, %v, %v", out, changed, err)
+	}
+}
+
+func TestParseKeyring(t *testing.T) {
+	k1 := base64.StdEncoding.EncodeToString(randomKey(t))
+	k2 := base64.StdEncoding.EncodeToString(randomKey(t))
+
+	kr, err := ParseKeyring("2024-10:"+k1+", 2024-01:"+k2, "")
+	if err != nil {
+		t.Fatalf("ParseKeyring: %v", err)
+	}
+	if kr.ActiveVersion() != "2024-10" {
+		t.Errorf("ActiveVersion = %q, want first entry", kr.ActiveVersion())
+	}
+	if !kr.HasVersion("2024-01") {
+		t.Error("retired version should remain available")
+	}
+
+	kr, err = ParseKeyring("2024-10:"+k1+",2024-01:"+k2, "2024-01")
+	if err != nil || kr.ActiveVersion() != "2024-01" {
+		t.Errorf("explicit active = %v, %v", kr, err)
+	}
+
+	bad := []struct{ name, spec, active string }{
+		{"empty", "", ""},
+		{"missing separator", k1, ""},
+		{"bad base64", "v1:???", ""},
+		{"short key", "v1:" + base64.StdEncoding.EncodeToString([]byte("short")), ""},
+		{"duplicate", "v1:" + k1 + ",v1:" + k2, ""},
+		{"unknown active", "v1:" + k1, "v9"},
+	}
+	for _, tt := range bad {
+		if _, err := ParseKeyring(tt.spec, tt.active); err == nil {
+			t.Errorf("%s: expected error", tt.name)
+		}
+	}
+}
@@ -0,0 +1,138 @@
+// Package encryption implements application-layer envelope encryption for
+// sensitive columns (resume files, PII in parsed profiles).
+//
+// Every record is encrypted with its own random 256-bit data key using
+// AES-GCM. The data key is itself encrypted ("wrapped") with a master key
+// identified by a version label, and the wrapped key is stored alongside the
+// ciphertext. Rotating the master key therefore only requires re-wrapping
+// the small data keys; payloads are never re-encrypted.
+package encryption
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"encoding/base64"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// MasterKeySize is the required master key length in bytes (AES-256).
+const MasterKeySize = 32
+
+// Environment variables read by KeyringFromEnv.
+const (
+	// EnvMasterKeys lists master keys as comma-separated "version:base64key"
+	// pairs, e.g. "2024-10:q3V...,2024-01:Zk9...". Retired keys must stay in
+	// the list until a rotation has re-wrapped every record.
+	EnvMasterKeys = "LEARNBOT_MASTER_KEYS"
+
+	// EnvActiveKeyVersion selects the version used for new records. When
+	// unset, the first entry of EnvMasterKeys is active.
+	EnvActiveKeyVersion = "LEARNBOT_MASTER_KEY_VERSION"
+)
+
+// Keyring holds the master keys known to the process and which one is used
+// to wrap new data keys. A Keyring is immutable and safe for concurrent use.
+type Keyring struct {
+	active string
+	aeads  map[string]cipher.AEAD
+}
+
+// NewKeyring creates a Keyring from a set of versioned master keys. active
+// must be one of the versions in keys, and every key must be MasterKeySize
+// bytes long.
+func NewKeyring(active string, keys map[string][]byte) (*Keyring, error) {
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("%w: no master keys provided", ErrInvalidKey)
+	}
+	kr := &Keyring{active: active, aeads: make(map[string]cipher.AEAD, len(keys))}
+	for version, key := range keys {
+		if err := validateVersion(version); err != nil {
+			return nil, err
+		}
+		if len(key) != MasterKeySize {
+			return nil, fmt.Errorf("%w: key %q is %d bytes, want %d",
+				ErrInvalidKey, version, len(key), MasterKeySize)
+		}
+		aead, err := newAEAD(key)
+		if err != nil {
+			return nil, err
+		}
+		kr.aeads[version] = aead
+	}
+	if _, ok := kr.aeads[active]; !ok {
+		return nil, fmt.Errorf("%w: active version %q", ErrUnknownKeyVersion, active)
+	}
+	return kr, nil
+}
+
+// KeyringFromEnv builds a Keyring from EnvMasterKeys and EnvActiveKeyVersion.
+func KeyringFromEnv() (*Keyring, error) {
+	return ParseKeyring(os.Getenv(EnvMasterKeys), os.Getenv(EnvActiveKeyVersion))
+}
+
+// ParseKeyring builds a Keyring from the EnvMasterKeys format. An empty
+// active version selects the first listed key.
+func ParseKeyring(spec, active string) (*Keyring, error) {
+	keys := make(map[string][]byte)
+	first := ""
+	for _, entry := range strings.Split(spec, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		version, encoded, ok := strings.Cut(entry, ":")
+		if !ok {
+			return nil, fmt.Errorf("%w: entry %q is not version:base64key", ErrInvalidKey, entry)
+		}
+		key, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			return nil, fmt.Errorf("%w: key %q is not valid base64", ErrInvalidKey, version)
+		}
+		if _, dup := keys[version]; dup {
+			return nil, fmt.Errorf("%w: duplicate version %q", ErrInvalidKey, version)
+		}
+		keys[version] = key
+		if first == "" {
+			first = version
+		}
+	}
+	if active == "" {
+		active = first
+	}
+	return NewKeyring(active, keys)
+}
+
+// ActiveVersion returns the master key version used for new records.
+func (k *Keyring) ActiveVersion() string {
+	return k.active
+}
+
+// HasVersion reports whether the keyring can unwrap data keys of version.
+func (k *Keyring) HasVersion(version string) bool {
+	_, ok := k.aeads[version]
+	return ok
+}
+
+// validateVersion checks that a version label fits the envelope header and
+// the EnvMasterKeys syntax.
+func validateVersion(version string) error {
+	if version == "" || len(version) > 255 || strings.ContainsAny(version, ":,") {
+		return fmt.Errorf("%w: invalid key version %q", ErrInvalidKey, version)
+	}
+	return nil
+}
+
+// newAEAD returns an AES-GCM AEAD for key.
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidKey, err)
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidKey, err)
+	}
+	return aead, nil
+}
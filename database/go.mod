@@ -3,6 +3,10 @@ module github.com/learnbot/database
 go 1.22.0
 
 require (
+	github.com/DATA-DOG/go-sqlmock v1.5.2
 	github.com/google/uuid v1.6.0
+	github.com/learnbot/shared v0.0.0
 	github.com/lib/pq v1.11.2
 )
+
+replace github.com/learnbot/shared => ../shared
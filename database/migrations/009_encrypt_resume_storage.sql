@@ -0,0 +1,45 @@
+-- Migration 009: Encrypted at-rest storage for resume files
+-- Resume files are stored as envelope-encrypted blobs (see the encryption
+-- package). PII columns (user_profiles.phone, location_city and
+-- location_state, resume_uploads.raw_text) keep their TEXT type and hold
+-- "enc:<base64>" envelopes once encryption is enabled; older plaintext rows
+-- remain readable until rewritten. Profiles store no street address, and
+-- location_country stays plaintext, as a country alone does not identify
+-- anyone.
+
+BEGIN;
+
+-- ─────────────────────────────────────────────────────────────────────────────
+-- resume_blobs: Encrypted resume file contents
+-- ─────────────────────────────────────────────────────────────────────────────
+CREATE TABLE resume_blobs (
+    resume_upload_id UUID PRIMARY KEY REFERENCES resume_uploads(id) ON DELETE CASCADE,
+    key_version      TEXT NOT NULL,                  -- master key that wraps the data key
+    envelope         BYTEA NOT NULL,                 -- wrapped data key + AES-GCM payload
+    created_at       TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at       TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT resume_blobs_key_version_not_empty CHECK (LENGTH(key_version) > 0)
+);
+
+-- Rotation scans for blobs still wrapped by a retired master key.
+CREATE INDEX idx_resume_blobs_key_version ON resume_blobs(key_version);
+
+CREATE TRIGGER resume_blobs_updated_at
+    BEFORE UPDATE ON resume_blobs
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+COMMENT ON COLUMN user_profiles.phone IS
+    'Phone number; "enc:"-prefixed envelope when at-rest encryption is enabled';
+COMMENT ON COLUMN user_profiles.location_city IS
+    'City; "enc:"-prefixed envelope when at-rest encryption is enabled';
+COMMENT ON COLUMN user_profiles.location_state IS
+    'State or province; "enc:"-prefixed envelope when at-rest encryption is enabled';
+
+-- Sealed cities and states cannot be searched, so only the country is indexed.
+DROP INDEX IF EXISTS idx_profiles_location;
+CREATE INDEX idx_profiles_location_country ON user_profiles(location_country);
+COMMENT ON COLUMN resume_uploads.raw_text IS
+    'Extracted resume text; "enc:"-prefixed envelope when at-rest encryption is enabled';
+
+COMMIT;
@@ -0,0 +1,40 @@
+-- Migration 010: Track learning resource link checks
+--
+-- The link checker periodically requests every active resource URL.
+-- consecutive_failures counts failed checks since the last success; a 404
+-- or 410 clears is_verified, and the checker deactivates resources whose
+-- links keep failing; a later successful check reactivates them.
+
+BEGIN;
+
+-- ─────────────────────────────────────────────────────────────────────────────
+-- Columns
+-- ─────────────────────────────────────────────────────────────────────────────
+
+ALTER TABLE learning_resources
+    ADD COLUMN last_checked_at      TIMESTAMPTZ,
+    ADD COLUMN last_status_code     SMALLINT,
+    ADD COLUMN consecutive_failures INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN deactivated_by_check BOOLEAN NOT NULL DEFAULT FALSE,
+
+    ADD CONSTRAINT learning_resources_consecutive_failures_positive CHECK (consecutive_failures >= 0);
+
+COMMENT ON COLUMN learning_resources.last_checked_at IS 'When the link checker last requested the URL';
+COMMENT ON COLUMN learning_resources.last_status_code IS 'HTTP status of the last check; NULL if the request failed';
+COMMENT ON COLUMN learning_resources.consecutive_failures IS 'Failed link checks since the last successful one';
+COMMENT ON COLUMN learning_resources.deactivated_by_check IS 'TRUE when the link checker set is_active = FALSE';
+
+-- ─────────────────────────────────────────────────────────────────────────────
+-- Indexes
+-- ─────────────────────────────────────────────────────────────────────────────
+
+-- Link checker: least recently checked first, including resources it
+-- deactivated so that they can come back.
+CREATE INDEX idx_learning_resources_last_checked ON learning_resources(last_checked_at ASC NULLS FIRST)
+    WHERE is_active = TRUE OR deactivated_by_check = TRUE;
+
+-- Broken link report.
+CREATE INDEX idx_learning_resources_link_failures ON learning_resources(consecutive_failures)
+    WHERE consecutive_failures > 0;
+
+COMMIT;
@@ -0,0 +1,22 @@
+-- Migration 011: Allow learning path steps without a catalog resource
+--
+-- Paths generated from a gap analysis include a step for every skill gap.
+-- When the catalog has no resource for a skill, the step names the skill
+-- and carries notes instead, so that the gap stays visible in the path.
+
+BEGIN;
+
+-- ─────────────────────────────────────────────────────────────────────────────
+-- Columns
+-- ─────────────────────────────────────────────────────────────────────────────
+
+ALTER TABLE learning_path_resources
+    ALTER COLUMN resource_id DROP NOT NULL,
+    ADD COLUMN skill_name TEXT,
+
+    ADD CONSTRAINT learning_path_resources_resource_or_skill
+        CHECK (resource_id IS NOT NULL OR skill_name IS NOT NULL);
+
+COMMENT ON COLUMN learning_path_resources.skill_name IS 'Skill covered by the step; set for notes-only steps without a resource';
+
+COMMIT;
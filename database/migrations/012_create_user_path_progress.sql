@@ -0,0 +1,38 @@
+-- Migration 012: Track user progress through learning paths
+--
+-- A user enrolls in a path; their progress is derived from the
+-- user_resource_progress rows of the path's required resources and stored
+-- here so that it can be listed cheaply. The stored values are recomputed
+-- whenever the user's resource progress changes and when the user's paths
+-- are listed, so they follow changes to a path's resources.
+
+BEGIN;
+
+-- ─────────────────────────────────────────────────────────────────────────────
+-- user_path_progress: A user's enrollment in a learning path
+-- ─────────────────────────────────────────────────────────────────────────────
+CREATE TABLE user_path_progress (
+    id                      UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id                 UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    path_id                 UUID NOT NULL REFERENCES learning_paths(id) ON DELETE CASCADE,
+    required_count          SMALLINT NOT NULL DEFAULT 0,    -- Required resource steps
+    completed_count         SMALLINT NOT NULL DEFAULT 0,    -- Required resource steps completed
+    completion_percentage   SMALLINT NOT NULL DEFAULT 0,
+    current_step            SMALLINT,                       -- First required step not completed
+    enrolled_at             TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    started_at              TIMESTAMPTZ,                    -- First progress on a path resource
+    completed_at            TIMESTAMPTZ,                    -- All required resources completed
+    updated_at              TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT user_path_progress_unique UNIQUE (user_id, path_id),
+    CONSTRAINT user_path_progress_percentage_range CHECK (completion_percentage BETWEEN 0 AND 100)
+);
+
+-- ─────────────────────────────────────────────────────────────────────────────
+-- Indexes
+-- ─────────────────────────────────────────────────────────────────────────────
+
+CREATE INDEX idx_upp_user ON user_path_progress(user_id);
+CREATE INDEX idx_upp_path ON user_path_progress(path_id);
+
+COMMIT;
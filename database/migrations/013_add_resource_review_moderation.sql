@@ -0,0 +1,65 @@
+-- Migration 013: Combine user reviews with provider ratings, and moderate reviews
+--
+-- learning_resources.rating and rating_count were overwritten with the
+-- average of resource_reviews by a trigger, which discarded the rating
+-- seeded from the provider. The provider rating is now kept in its own
+-- columns, and the repository recomputes rating and rating_count from it
+-- together with the user ratings, in the same transaction as the change
+-- that affects them.
+--
+-- Reviews can be reported by users and hidden by admins; hidden reviews
+-- are not listed and do not count towards the rating. Helpful votes are
+-- recorded once per user.
+
+BEGIN;
+
+-- ─────────────────────────────────────────────────────────────────────────────
+-- learning_resources: provider rating
+-- ─────────────────────────────────────────────────────────────────────────────
+ALTER TABLE learning_resources
+    ADD COLUMN provider_rating       NUMERIC(3,2),              -- Rating reported by the provider
+    ADD COLUMN provider_rating_count INTEGER NOT NULL DEFAULT 0,
+    ADD CONSTRAINT learning_resources_provider_rating_range
+        CHECK (provider_rating IS NULL OR provider_rating BETWEEN 0 AND 5),
+    ADD CONSTRAINT learning_resources_provider_rating_count_positive
+        CHECK (provider_rating_count >= 0);
+
+-- Ratings of resources without reviews are still the seeded ones.
+UPDATE learning_resources lr
+SET provider_rating = lr.rating,
+    provider_rating_count = lr.rating_count
+WHERE NOT EXISTS (SELECT 1 FROM resource_reviews rr WHERE rr.resource_id = lr.id);
+
+DROP TRIGGER resource_reviews_refresh_rating ON resource_reviews;
+DROP FUNCTION refresh_resource_rating();
+
+-- ─────────────────────────────────────────────────────────────────────────────
+-- resource_reviews: moderation
+-- ─────────────────────────────────────────────────────────────────────────────
+ALTER TABLE resource_reviews
+    ADD COLUMN is_reported  BOOLEAN NOT NULL DEFAULT FALSE,     -- Reported by a user
+    ADD COLUMN reported_at  TIMESTAMPTZ,                        -- First report since last moderated
+    ADD COLUMN is_hidden    BOOLEAN NOT NULL DEFAULT FALSE,     -- Hidden by an admin
+    ADD COLUMN hidden_at    TIMESTAMPTZ;
+
+-- ─────────────────────────────────────────────────────────────────────────────
+-- review_helpful_votes: One helpful vote per user and review
+-- ─────────────────────────────────────────────────────────────────────────────
+CREATE TABLE review_helpful_votes (
+    review_id   UUID NOT NULL REFERENCES resource_reviews(id) ON DELETE CASCADE,
+    user_id     UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    PRIMARY KEY (review_id, user_id)
+);
+
+-- ─────────────────────────────────────────────────────────────────────────────
+-- Indexes
+-- ─────────────────────────────────────────────────────────────────────────────
+
+CREATE INDEX idx_resource_reviews_helpful ON resource_reviews(resource_id, helpful_count DESC, created_at DESC)
+    WHERE is_hidden = FALSE;
+CREATE INDEX idx_resource_reviews_reported ON resource_reviews(reported_at)
+    WHERE is_reported = TRUE AND is_hidden = FALSE;
+
+COMMIT;
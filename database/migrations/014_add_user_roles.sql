@@ -0,0 +1,14 @@
+-- Migration 014: Store the roles of user accounts
+--
+-- The API gateway assigns roles (user, curator, admin) to accounts and
+-- carries them in access tokens. is_admin is kept for the services that
+-- only check it, and is set together with the roles.
+
+BEGIN;
+
+ALTER TABLE users
+    ADD COLUMN roles TEXT[] NOT NULL DEFAULT '{user}';
+
+UPDATE users SET roles = '{user,admin}' WHERE is_admin;
+
+COMMIT;
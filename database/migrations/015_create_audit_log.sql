@@ -0,0 +1,30 @@
+-- Migration 015: Audit log of admin mutations
+--
+-- Every successful mutating request to the admin API is recorded with the
+-- admin caller, the entity it changed, a JSON diff of the changed fields
+-- and the request ID. The job aggregator creates the same table in its own
+-- migrations, so both use IF NOT EXISTS in case they share a database;
+-- entries are told apart by service.
+
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS audit_log (
+    id          UUID PRIMARY KEY,
+    service     TEXT NOT NULL,        -- e.g. learning-resources
+    actor       TEXT NOT NULL,        -- admin user ID, api_key or anonymous
+    actor_email TEXT NOT NULL DEFAULT '',
+    action      TEXT NOT NULL,        -- e.g. resource.update
+    entity_type TEXT NOT NULL DEFAULT '',
+    entity_id   TEXT NOT NULL DEFAULT '',
+    -- {"field": {"old": ..., "new": ...}} for the changed fields
+    changes     JSONB,
+    request_id  TEXT NOT NULL DEFAULT '',
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Serve the admin listing, newest first, filtered by actor or entity.
+CREATE INDEX IF NOT EXISTS idx_audit_log_created_at ON audit_log(created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_audit_log_actor ON audit_log(actor, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_audit_log_entity ON audit_log(entity_type, entity_id, created_at DESC);
+
+COMMIT;
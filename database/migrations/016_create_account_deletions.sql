@@ -0,0 +1,41 @@
+-- Migration 016: Account deletions
+--
+-- When a user deletes their account the API gateway removes it at once and
+-- asks each backend service holding the user's data to erase it. Every
+-- service is a target of the deletion with its own status, so a service
+-- that is down is retried with backoff until it confirms the erasure. The
+-- user ID is kept without a foreign key, as the user is already gone.
+
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS account_deletions (
+    id           UUID PRIMARY KEY,
+    user_id      UUID NOT NULL,
+    status       VARCHAR(20) NOT NULL DEFAULT 'pending',
+    requested_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    completed_at TIMESTAMPTZ,
+
+    CONSTRAINT account_deletions_status_check CHECK (status IN ('pending', 'completed'))
+);
+
+CREATE TABLE IF NOT EXISTS account_deletion_targets (
+    deletion_id     UUID NOT NULL REFERENCES account_deletions(id) ON DELETE CASCADE,
+    target          VARCHAR(100) NOT NULL,  -- service name, e.g. learning-resources
+    status          VARCHAR(20) NOT NULL DEFAULT 'pending',
+    attempts        INTEGER NOT NULL DEFAULT 0,
+    last_error      TEXT,
+    -- When the erasure is attempted next; NULL once completed.
+    next_attempt_at TIMESTAMPTZ,
+    completed_at    TIMESTAMPTZ,
+
+    PRIMARY KEY (deletion_id, target),
+    CONSTRAINT account_deletion_targets_status_check CHECK (status IN ('pending', 'completed'))
+);
+
+-- Serve the admin listing of pending deletions, oldest first.
+CREATE INDEX IF NOT EXISTS idx_account_deletions_status ON account_deletions(status, requested_at);
+-- Find the targets due for another attempt.
+CREATE INDEX IF NOT EXISTS idx_account_deletion_targets_due
+    ON account_deletion_targets(next_attempt_at) WHERE status = 'pending';
+
+COMMIT;
@@ -0,0 +1,29 @@
+-- Migration 017: API keys
+--
+-- The API gateway issues API keys to machine-to-machine clients. Only the
+-- SHA-256 digest of a key is stored, with the start of the key as a hint
+-- for admins to tell keys apart. Revoked keys are kept so that requests
+-- with them are answered "revoked" rather than "invalid". created_by is
+-- the admin's user ID, kept without a foreign key so that the key outlives
+-- the admin's account.
+
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS api_keys (
+    id               VARCHAR(32) PRIMARY KEY,
+    name             VARCHAR(255) NOT NULL,
+    key_hash         CHAR(64) NOT NULL UNIQUE,          -- SHA-256 hex digest
+    hint             VARCHAR(32) NOT NULL,
+    scopes           TEXT[] NOT NULL DEFAULT '{}',
+    -- The key's own rate limit; 0 uses the gateway's default.
+    rate_limit_rps   DOUBLE PRECISION NOT NULL DEFAULT 0,
+    rate_limit_burst DOUBLE PRECISION NOT NULL DEFAULT 0,
+    created_by       VARCHAR(64) NOT NULL DEFAULT '',
+    created_at       TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    revoked_at       TIMESTAMPTZ
+);
+
+-- Serve the admin listing, newest first.
+CREATE INDEX IF NOT EXISTS idx_api_keys_created_at ON api_keys(created_at DESC);
+
+COMMIT;
@@ -0,0 +1,85 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	_ "github.com/lib/pq"
+
+	"github.com/learnbot/shared/migrate"
+)
+
+// openTestSchema returns a connection pool whose tables are created in a
+// new, empty schema of the TEST_DATABASE_URL database, dropped when the
+// test ends.
+func openTestSchema(t *testing.T) *sql.DB {
+	t.Helper()
+	dsn := os.Getenv("TEST_DATABASE_URL")
+	if dsn == "" {
+		t.Skip("TEST_DATABASE_URL not set")
+	}
+	admin, err := sql.Open("postgres", dsn)
+	if err != nil {
+		t.Fatalf("open database: %v", err)
+	}
+	t.Cleanup(func() { admin.Close() })
+
+	schema := fmt.Sprintf("migrate_test_%d", time.Now().UnixNano())
+	if _, err := admin.Exec("CREATE SCHEMA " + schema); err != nil {
+		t.Fatalf("create schema: %v", err)
+	}
+	t.Cleanup(func() { admin.Exec("DROP SCHEMA " + schema + " CASCADE") }) //nolint:errcheck
+
+	sep := " "
+	if strings.Contains(dsn, "://") {
+		sep = "&"
+		if !strings.Contains(dsn, "?") {
+			sep = "?"
+		}
+	}
+	db, err := sql.Open("postgres", dsn+sep+"search_path="+schema)
+	if err != nil {
+		t.Fatalf("open schema: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+func TestMigrations_ApplyTwice(t *testing.T) {
+	db := openTestSchema(t)
+	ctx := context.Background()
+
+	m, err := migrate.New(db, FS, Table, log.New(io.Discard, "", 0))
+	if err != nil {
+		t.Fatalf("migrate.New: %v", err)
+	}
+
+	applied, err := m.Up(ctx)
+	if err != nil {
+		t.Fatalf("first Up: %v", err)
+	}
+	files, _ := FS.ReadDir(".")
+	if applied != len(files) {
+		t.Errorf("first Up applied %d migrations, want %d", applied, len(files))
+	}
+
+	applied, err = m.Up(ctx)
+	if err != nil {
+		t.Fatalf("second Up: %v", err)
+	}
+	if applied != 0 {
+		t.Errorf("second Up applied %d migrations, want 0", applied)
+	}
+
+	var resources int
+	if err := db.QueryRow(`SELECT COUNT(*) FROM learning_resources`).Scan(&resources); err != nil {
+		t.Errorf("learning_resources table missing after migrating: %v", err)
+	}
+}
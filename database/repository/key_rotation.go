@@ -0,0 +1,180 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"github.com/learnbot/database/encryption"
+)
+
+// defaultRotationBatchSize is the number of rows re-wrapped per query.
+const defaultRotationBatchSize = 500
+
+// RotationResult reports how many envelopes a rotation re-wrapped.
+type RotationResult struct {
+	ResumeFiles      int `json:"resume_files"`
+	ResumeTexts      int `json:"resume_texts"`
+	ProfilePhones    int `json:"profile_phones"`
+	ProfileLocations int `json:"profile_locations"` // cities and states
+}
+
+// KeyRotator re-wraps stored data keys under the keyring's active master
+// key. Payloads are not re-encrypted, so rotation cost is proportional to
+// the number of records rather than their size.
+type KeyRotator struct {
+	db        *sql.DB
+	keys      *encryption.Keyring
+	batchSize int
+}
+
+// NewKeyRotator creates a KeyRotator. keys must hold the new active master
+// key as well as every retired version still referenced by stored rows.
+func NewKeyRotator(db *sql.DB, keys *encryption.Keyring, batchSize int) *KeyRotator {
+	if batchSize <= 0 {
+		batchSize = defaultRotationBatchSize
+	}
+	return &KeyRotator{db: db, keys: keys, batchSize: batchSize}
+}
+
+// Rotate re-wraps every envelope not yet under the active key version. It is
+// safe to re-run after a failure: rows already rotated are skipped.
+func (k *KeyRotator) Rotate(ctx context.Context) (*RotationResult, error) {
+	res := &RotationResult{}
+	var err error
+
+	if res.ResumeFiles, err = k.rotateBlobs(ctx); err != nil {
+		return res, err
+	}
+	if res.ResumeTexts, err = k.rotateTextColumn(ctx, "resume_uploads", "raw_text"); err != nil {
+		return res, err
+	}
+	if res.ProfilePhones, err = k.rotateTextColumn(ctx, "user_profiles", "phone"); err != nil {
+		return res, err
+	}
+	for _, column := range []string{"location_city", "location_state"} {
+		n, err := k.rotateTextColumn(ctx, "user_profiles", column)
+		res.ProfileLocations += n
+		if err != nil {
+			return res, err
+		}
+	}
+	return res, nil
+}
+
+// rotateBlobs re-wraps resume_blobs rows whose key_version is not active.
+func (k *KeyRotator) rotateBlobs(ctx context.Context) (int, error) {
+	active := k.keys.ActiveVersion()
+	rotated := 0
+	after := uuid.Nil
+
+	for {
+		rows, err := k.db.QueryContext(ctx, `
+			SELECT resume_upload_id, envelope
+			FROM resume_blobs
+			WHERE key_version <> $1 AND resume_upload_id > $2
+			ORDER BY resume_upload_id
+			LIMIT $3`, active, after, k.batchSize,
+		)
+		if err != nil {
+			return rotated, fmt.Errorf("list resume blobs: %w", err)
+		}
+
+		type blob struct {
+			id       uuid.UUID
+			envelope []byte
+		}
+		var batch []blob
+		for rows.Next() {
+			var b blob
+			if err := rows.Scan(&b.id, &b.envelope); err != nil {
+				rows.Close()
+				return rotated, fmt.Errorf("scan resume blob: %w", err)
+			}
+			batch = append(batch, b)
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return rotated, fmt.Errorf("list resume blobs: %w", err)
+		}
+		if len(batch) == 0 {
+			return rotated, nil
+		}
+
+		for _, b := range batch {
+			rewrapped, changed, err := k.keys.Rewrap(b.envelope)
+			if err != nil {
+				return rotated, fmt.Errorf("rewrap resume blob %s: %w", b.id, err)
+			}
+			if changed {
+				if _, err := k.db.ExecContext(ctx, `
+					UPDATE resume_blobs SET envelope = $1, key_version = $2
+					WHERE resume_upload_id = $3`, rewrapped, active, b.id,
+				); err != nil {
+					return rotated, fmt.Errorf("update resume blob %s: %w", b.id, err)
+				}
+				rotated++
+			}
+			after = b.id
+		}
+	}
+}
+
+// rotateTextColumn re-wraps "enc:"-prefixed values in table.column. The key
+// version lives inside the envelope, so every sealed row is inspected and
+// only those under a retired version are written back. table and column are
+// trusted constants, never user input.
+func (k *KeyRotator) rotateTextColumn(ctx context.Context, table, column string) (int, error) {
+	selectQ := fmt.Sprintf(`
+		SELECT id, %[2]s FROM %[1]s
+		WHERE %[2]s LIKE 'enc:%%' AND id > $1
+		ORDER BY id
+		LIMIT $2`, table, column)
+	updateQ := fmt.Sprintf(`UPDATE %s SET %s = $1 WHERE id = $2`, table, column)
+
+	rotated := 0
+	after := uuid.Nil
+	for {
+		rows, err := k.db.QueryContext(ctx, selectQ, after, k.batchSize)
+		if err != nil {
+			return rotated, fmt.Errorf("list %s.%s: %w", table, column, err)
+		}
+
+		type value struct {
+			id     uuid.UUID
+			sealed string
+		}
+		var batch []value
+		for rows.Next() {
+			var v value
+			if err := rows.Scan(&v.id, &v.sealed); err != nil {
+				rows.Close()
+				return rotated, fmt.Errorf("scan %s.%s: %w", table, column, err)
+			}
+			batch = append(batch, v)
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return rotated, fmt.Errorf("list %s.%s: %w", table, column, err)
+		}
+		if len(batch) == 0 {
+			return rotated, nil
+		}
+
+		for _, v := range batch {
+			rewrapped, changed, err := k.keys.RewrapString(v.sealed)
+			if err != nil {
+				return rotated, fmt.Errorf("rewrap %s.%s %s: %w", table, column, v.id, err)
+			}
+			if changed {
+				if _, err := k.db.ExecContext(ctx, updateQ, rewrapped, v.id); err != nil {
+					return rotated, fmt.Errorf("update %s.%s %s: %w", table, column, v.id, err)
+				}
+				rotated++
+			}
+			after = v.id
+		}
+	}
+}
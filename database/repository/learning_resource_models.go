@@ -147,15 +147,17 @@ type LearningPath struct {
 	UpdatedAt      time.Time          `db:"updated_at" json:"updated_at"`
 }
 
-// LearningPathResource represents a resource within a learning path.
+// LearningPathResource represents a step of a learning path. Notes-only
+// steps have a SkillName but no ResourceID.
 type LearningPathResource struct {
 	ID         uuid.UUID `db:"id" json:"id"`
 	PathID     uuid.UUID `db:"path_id" json:"path_id"`
-	ResourceID uuid.UUID `db:"resource_id" json:"resource_id"`
-	StepOrder  int16     `db:"step_order" json:"step_order"`
-	IsRequired bool      `db:"is_required" json:"is_required"`
+	ResourceID *uuid.UUID     `db:"resource_id" json:"resource_id,omitempty"`
+	SkillName  sql.NullString `db:"skill_name" json:"skill_name,omitempty"`
+	StepOrder  int16          `db:"step_order" json:"step_order"`
+	IsRequired bool           `db:"is_required" json:"is_required"`
 	Notes      sql.NullString `db:"notes" json:"notes,omitempty"`
-	CreatedAt  time.Time `db:"created_at" json:"created_at"`
+	CreatedAt  time.Time      `db:"created_at" json:"created_at"`
 }
 
 // UserResourceProgress tracks a user's progress through a resource.
@@ -173,6 +175,25 @@ type UserResourceProgress struct {
 	UpdatedAt          time.Time          `db:"updated_at" json:"updated_at"`
 }
 
+// UserPathProgress tracks a user's progress through a learning path. Only
+// required steps with a resource count towards completion; optional and
+// notes-only steps do not.
+type UserPathProgress struct {
+	ID                   uuid.UUID     `db:"id" json:"id"`
+	UserID               uuid.UUID     `db:"user_id" json:"user_id"`
+	PathID               uuid.UUID     `db:"path_id" json:"path_id"`
+	PathSlug             string        `db:"path_slug" json:"path_slug"`
+	PathTitle            string        `db:"path_title" json:"path_title"`
+	RequiredCount        int16         `db:"required_count" json:"required_count"`
+	CompletedCount       int16         `db:"completed_count" json:"completed_count"`
+	CompletionPercentage int16         `db:"completion_percentage" json:"completion_percentage"`
+	CurrentStep          sql.NullInt16 `db:"current_step" json:"current_step,omitempty"`
+	EnrolledAt           time.Time     `db:"enrolled_at" json:"enrolled_at"`
+	StartedAt            sql.NullTime  `db:"started_at" json:"started_at,omitempty"`
+	CompletedAt          sql.NullTime  `db:"completed_at" json:"completed_at,omitempty"`
+	UpdatedAt            time.Time     `db:"updated_at" json:"updated_at"`
+}
+
 // ResourceReview represents a user review for a learning resource.
 type ResourceReview struct {
 	ID           uuid.UUID      `db:"id" json:"id"`
@@ -183,6 +204,8 @@ type ResourceReview struct {
 	Body         sql.NullString `db:"body" json:"body,omitempty"`
 	IsVerified   bool           `db:"is_verified" json:"is_verified"`
 	HelpfulCount int            `db:"helpful_count" json:"helpful_count"`
+	IsReported   bool           `db:"is_reported" json:"is_reported"`
+	IsHidden     bool           `db:"is_hidden" json:"is_hidden"`
 	CreatedAt    time.Time      `db:"created_at" json:"created_at"`
 	UpdatedAt    time.Time      `db:"updated_at" json:"updated_at"`
 }
@@ -210,11 +233,27 @@ type LearningPathWithResources struct {
 }
 
 // LearningPathStep is a resource within a learning path with ordering info.
+// A notes-only step has no Resource; it names the skill to learn instead.
 type LearningPathStep struct {
 	StepOrder  int16             `json:"step_order"`
 	IsRequired bool              `json:"is_required"`
 	Notes      string            `json:"notes,omitempty"`
-	Resource   LearningResource  `json:"resource"`
+	SkillName  string            `json:"skill_name,omitempty"`
+	Resource   *LearningResource `json:"resource,omitempty"`
+}
+
+// UserResourceHistory is a resource a user has interacted with, together with
+// the user's progress and the skills the resource covers. It is the input to
+// personalized recommendations.
+type UserResourceHistory struct {
+	ResourceID  uuid.UUID          `db:"resource_id" json:"resource_id"`
+	Title       string             `db:"title" json:"title"`
+	Difficulty  ResourceDifficulty `db:"difficulty" json:"difficulty"`
+	Status      UserResourceStatus `db:"status" json:"status"`
+	UserRating  sql.NullInt16      `db:"user_rating" json:"user_rating,omitempty"`
+	CompletedAt sql.NullTime       `db:"completed_at" json:"completed_at,omitempty"`
+	// SkillIDs holds the normalized names of the skills the resource covers.
+	SkillIDs pq.StringArray `db:"skill_ids" json:"skill_ids,omitempty"`
 }
 
 // ─────────────────────────────────────────────────────────────────────────────
@@ -248,6 +287,36 @@ type ResourceSkillInput struct {
 	CoverageLevel *ResourceDifficulty
 }
 
+// ImportResourceInput is one row of a bulk resource import. When ProviderID
+// is not set, ProviderName links the resource to the provider with that
+// name, creating the provider if needed.
+type ImportResourceInput struct {
+	CreateResourceInput
+	ProviderName string
+}
+
+// ImportOptions controls a bulk resource import.
+type ImportOptions struct {
+	// Atomic rolls back the whole import if any row fails.
+	Atomic bool
+
+	// DryRun performs every insert and then rolls back, so that constraint
+	// violations such as duplicate slugs are reported without writing.
+	DryRun bool
+}
+
+// ImportResult is the outcome of one bulk import row.
+type ImportResult struct {
+	// Resource is the inserted resource, or nil if the row failed.
+	Resource *LearningResource
+
+	// ProviderCreated reports whether the row created its provider.
+	ProviderCreated bool
+
+	// Err is the reason the row failed.
+	Err error
+}
+
 // UpdateResourceInput holds the data for updating a learning resource.
 type UpdateResourceInput struct {
 	Title          *string
@@ -296,6 +365,9 @@ type ResourceQueryFilter struct {
 	// SearchQuery is a full-text search query.
 	SearchQuery string
 
+	// IncludeInactive also returns soft-deleted resources when true.
+	IncludeInactive bool
+
 	// Limit is the maximum number of results (default 20, max 100).
 	Limit int
 
@@ -311,13 +383,35 @@ type UpsertProgressInput struct {
 	UserNotes          *string
 }
 
-// CreateReviewInput holds data for creating a resource review.
+// CreateReviewInput holds data for creating or editing a resource review.
 type CreateReviewInput struct {
 	Rating int16
 	Title  *string
 	Body   *string
 }
 
+// ReviewSort is the order in which reviews are listed.
+type ReviewSort string
+
+const (
+	// ReviewSortHelpful lists the most helpful reviews first, then the newest.
+	ReviewSortHelpful ReviewSort = "helpful"
+	// ReviewSortRecent lists the newest reviews first.
+	ReviewSortRecent ReviewSort = "recent"
+)
+
+// ReviewQueryFilter holds the parameters for listing reviews.
+type ReviewQueryFilter struct {
+	// Sort is the order of the reviews (default ReviewSortHelpful).
+	Sort ReviewSort
+
+	// Limit is the maximum number of results (default 20, max 100).
+	Limit int
+
+	// Offset is the pagination offset.
+	Offset int
+}
+
 // CreateProviderInput holds data for creating a resource provider.
 type CreateProviderInput struct {
 	Name        string
@@ -335,13 +429,175 @@ type CreateLearningPathInput struct {
 	TargetSkill    *string
 	Difficulty     ResourceDifficulty
 	EstimatedHours *float64
+	CreatedBy      *uuid.UUID
 	Resources      []LearningPathResourceInput
 }
 
-// LearningPathResourceInput holds data for adding a resource to a path.
+// UpdateLearningPathInput holds data for updating a learning path. Nil
+// fields are left unchanged.
+type UpdateLearningPathInput struct {
+	Title          *string
+	Slug           *string
+	Description    *string
+	TargetRole     *string
+	TargetSkill    *string
+	Difficulty     *ResourceDifficulty
+	EstimatedHours *float64
+	IsActive       *bool
+	IsFeatured     *bool
+}
+
+// LearningPathResourceInput holds data for adding a resource to a path. A
+// notes-only step leaves ResourceID as uuid.Nil and sets SkillName.
 type LearningPathResourceInput struct {
 	ResourceID uuid.UUID
+	SkillName  string
 	StepOrder  int16
 	IsRequired bool
 	Notes      *string
 }
+
+// ResourceQualityRow is a lightweight projection of a learning resource
+// used by data quality audits.
+type ResourceQualityRow struct {
+	ID         uuid.UUID
+	Slug       string
+	URL        string
+	SkillCount int
+	CreatedAt  time.Time
+	// IsActive is false for a resource the link checker deactivated.
+	IsActive bool
+	// ConsecutiveFailures counts failed link checks since the last success.
+	ConsecutiveFailures int
+}
+
+// ResourceSkillCoverageRow is a skill of an active learning resource, used
+// by skill coverage reports.
+type ResourceSkillCoverageRow struct {
+	ResourceID uuid.UUID
+	// Skill is the normalized skill name.
+	Skill string
+	// Free reports a free or free-to-audit resource, and Beginner one for
+	// beginners or all levels.
+	Free     bool
+	Beginner bool
+}
+
+// LinkCheckTarget is a resource whose URL is due for a link check.
+type LinkCheckTarget struct {
+	ID    uuid.UUID
+	Title string
+	URL   string
+}
+
+// LinkCheckResult is the outcome of a conclusive link check.
+type LinkCheckResult struct {
+	// StatusCode is the final HTTP status, or 0 when the request failed.
+	StatusCode int
+	// OK reports whether the URL responded with a non-error status.
+	OK bool
+	// FinalURL, when set, replaces the resource URL. It is only set for
+	// permanent redirects.
+	FinalURL string
+}
+
+// PurgeResult reports the rows removed along with a purged resource.
+type PurgeResult struct {
+	SkillsRemoved    int64 `json:"skills_removed"`
+	PathStepsRemoved int64 `json:"path_steps_removed"`
+}
+
+// UserErasure reports the rows removed along with a user's data.
+type UserErasure struct {
+	ProgressRemoved     int64 `json:"progress_removed"`
+	PathProgressRemoved int64 `json:"path_progress_removed"`
+	ReviewsRemoved      int64 `json:"reviews_removed"`
+	HelpfulVotesRemoved int64 `json:"helpful_votes_removed"`
+}
+
+// DuplicateCandidate is a lightweight projection of an active learning
+// resource compared by duplicate detection.
+type DuplicateCandidate struct {
+	ID         uuid.UUID  `json:"id"`
+	Title      string     `json:"title"`
+	Slug       string     `json:"slug"`
+	URL        string     `json:"url"`
+	ProviderID *uuid.UUID `json:"provider_id,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+// MergeResult reports the rows moved to the surviving resource of a merge.
+// Rows the survivor already had an equivalent of are removed instead.
+type MergeResult struct {
+	SurvivorID         uuid.UUID   `json:"survivor_id"`
+	MergedIDs          []uuid.UUID `json:"merged_ids"`
+	SkillsMoved        int64       `json:"skills_moved"`
+	PathStepsRepointed int64       `json:"path_steps_repointed"`
+	PathStepsRemoved   int64       `json:"path_steps_removed"`
+	ProgressRepointed  int64       `json:"progress_repointed"`
+	ProgressRemoved    int64       `json:"progress_removed"`
+}
+
+// ProviderStats aggregates the active resources of a resource provider and
+// the user progress recorded against them.
+type ProviderStats struct {
+	ProviderID    uuid.UUID `json:"provider_id"`
+	ProviderName  string    `json:"provider_name"`
+	ResourceCount int       `json:"resource_count"`
+	// ByType and ByDifficulty count the resources per resource type and
+	// difficulty; values without resources are omitted.
+	ByType       map[string]int `json:"by_type"`
+	ByDifficulty map[string]int `json:"by_difficulty"`
+	// AverageRating is the mean rating of the rated resources, or nil if
+	// none is rated.
+	AverageRating *float64 `json:"average_rating"`
+	// SkillsCovered is the number of distinct skills the resources teach.
+	SkillsCovered int `json:"skills_covered"`
+	// Enrollments counts the user progress rows of the resources, whatever
+	// their status, and Completions those that are completed.
+	Enrollments    int `json:"enrollments"`
+	Completions    int `json:"completions"`
+	UsersCompleted int `json:"users_completed"`
+	// CompletionRate is Completions divided by Enrollments, or 0 without
+	// enrollments.
+	CompletionRate float64 `json:"completion_rate"`
+}
+
+// ProviderStatsSort is a ProviderStats column providers can be sorted by.
+type ProviderStatsSort string
+
+const (
+	ProviderStatsSortName           ProviderStatsSort = "provider_name"
+	ProviderStatsSortResourceCount  ProviderStatsSort = "resource_count"
+	ProviderStatsSortAverageRating  ProviderStatsSort = "average_rating"
+	ProviderStatsSortSkillsCovered  ProviderStatsSort = "skills_covered"
+	ProviderStatsSortEnrollments    ProviderStatsSort = "enrollments"
+	ProviderStatsSortCompletions    ProviderStatsSort = "completions"
+	ProviderStatsSortUsersCompleted ProviderStatsSort = "users_completed"
+	ProviderStatsSortCompletionRate ProviderStatsSort = "completion_rate"
+)
+
+// ProviderStatsFilter holds the parameters for aggregating provider stats.
+type ProviderStatsFilter struct {
+	// Sort is the column providers are ordered by (default
+	// ProviderStatsSortResourceCount). Ties are ordered by name.
+	Sort ProviderStatsSort
+
+	// Descending orders the largest values first. Providers without an
+	// average rating are always listed last.
+	Descending bool
+}
+
+// ResourceLinkStatus is the link check state of a learning resource.
+type ResourceLinkStatus struct {
+	ID                  uuid.UUID     `db:"id" json:"id"`
+	Title               string        `db:"title" json:"title"`
+	Slug                string        `db:"slug" json:"slug"`
+	URL                 string        `db:"url" json:"url"`
+	IsActive            bool          `db:"is_active" json:"is_active"`
+	IsVerified          bool          `db:"is_verified" json:"is_verified"`
+	LastCheckedAt       sql.NullTime  `db:"last_checked_at" json:"last_checked_at,omitempty"`
+	LastStatusCode      sql.NullInt32 `db:"last_status_code" json:"last_status_code,omitempty"`
+	ConsecutiveFailures int           `db:"consecutive_failures" json:"consecutive_failures"`
+	DeactivatedByCheck  bool          `db:"deactivated_by_check" json:"deactivated_by_check"`
+}
@@ -4,13 +4,33 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
+	"sort"
 	"strings"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 )
 
+// ErrSlugTaken is returned when a resource is created with an explicit slug
+// that is already in use. Generated slugs get a numeric suffix instead.
+var ErrSlugTaken = errors.New("slug already taken")
+
+// ErrResourceInPath is returned by Purge when the resource is a step of a
+// learning path and force is not set.
+var ErrResourceInPath = errors.New("resource is part of a learning path")
+
+// ErrInvalidPathSteps is returned when the resources of a learning path
+// reference missing or inactive resources, repeat a resource, or do not
+// have step_order values 1..n.
+var ErrInvalidPathSteps = errors.New("invalid path steps")
+
+// maxSlugAttempts bounds how many suffixed slugs are tried for a generated
+// slug when concurrent inserts keep taking the chosen one.
+const maxSlugAttempts = 10
+
 // LearningResourceRepository provides CRUD operations for learning resources.
 type LearningResourceRepository struct {
 	db *sql.DB
@@ -98,7 +118,9 @@ func (r *LearningResourceRepository) List(ctx context.Context, filter ResourceQu
 	var args []interface{}
 	argIdx := 1
 
-	conditions = append(conditions, "lr.is_active = TRUE")
+	if !filter.IncludeInactive {
+		conditions = append(conditions, "lr.is_active = TRUE")
+	}
 
 	if filter.SkillName != "" {
 		conditions = append(conditions, fmt.Sprintf(
@@ -147,7 +169,10 @@ func (r *LearningResourceRepository) List(ctx context.Context, filter ResourceQu
 		argIdx++
 	}
 
-	where := "WHERE " + strings.Join(conditions, " AND ")
+	where := ""
+	if len(conditions) > 0 {
+		where = "WHERE " + strings.Join(conditions, " AND ")
+	}
 
 	// Count query.
 	countQ := fmt.Sprintf(`
@@ -275,7 +300,10 @@ func (r *LearningResourceRepository) GetFeatured(ctx context.Context, limit int)
 	return resources, rows.Err()
 }
 
-// Create inserts a new learning resource and its skills.
+// Create inserts a new learning resource and its skills. When input.Slug is
+// empty a slug is generated from the title, with a "-2", "-3", ... suffix if
+// needed; the returned resource carries the final slug. An explicit slug
+// that is already in use fails with ErrSlugTaken.
 func (r *LearningResourceRepository) Create(ctx context.Context, input CreateResourceInput) (*LearningResource, error) {
 	tx, err := r.db.BeginTx(ctx, nil)
 	if err != nil {
@@ -283,6 +311,92 @@ func (r *LearningResourceRepository) Create(ctx context.Context, input CreateRes
 	}
 	defer tx.Rollback()
 
+	res, err := insertResource(ctx, tx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("commit transaction: %w", err)
+	}
+	return res, nil
+}
+
+// insertResource inserts a resource and its skills within tx, generating a
+// slug if input has none.
+func insertResource(ctx context.Context, tx *sql.Tx, input CreateResourceInput) (*LearningResource, error) {
+	if strings.TrimSpace(input.Slug) != "" {
+		return insertResourceRow(ctx, tx, input)
+	}
+
+	base := slugify(input.Title)
+	taken, err := takenSlugs(ctx, tx, base)
+	if err != nil {
+		return nil, err
+	}
+	n := 1
+	for attempt := 0; attempt < maxSlugAttempts; attempt++ {
+		for taken[suffixedSlug(base, n)] {
+			n++
+		}
+		input.Slug = suffixedSlug(base, n)
+
+		// A concurrent insert may take the slug between the lookup and the
+		// insert, so each attempt runs under a savepoint.
+		if _, err := tx.ExecContext(ctx, "SAVEPOINT resource_slug"); err != nil {
+			return nil, fmt.Errorf("create savepoint: %w", err)
+		}
+		res, err := insertResourceRow(ctx, tx, input)
+		if errors.Is(err, ErrSlugTaken) {
+			if _, err := tx.ExecContext(ctx, "ROLLBACK TO SAVEPOINT resource_slug"); err != nil {
+				return nil, fmt.Errorf("rollback to savepoint: %w", err)
+			}
+			taken[input.Slug] = true
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if _, err := tx.ExecContext(ctx, "RELEASE SAVEPOINT resource_slug"); err != nil {
+			return nil, fmt.Errorf("release savepoint: %w", err)
+		}
+		return res, nil
+	}
+	return nil, fmt.Errorf("insert resource: no free slug for %q after %d attempts: %w", base, maxSlugAttempts, ErrSlugTaken)
+}
+
+// suffixedSlug returns base for n = 1 and "base-n" otherwise.
+func suffixedSlug(base string, n int) string {
+	if n == 1 {
+		return base
+	}
+	return fmt.Sprintf("%s-%d", base, n)
+}
+
+// takenSlugs returns the existing slugs that are base or base with a suffix.
+func takenSlugs(ctx context.Context, tx *sql.Tx, base string) (map[string]bool, error) {
+	// Generated slugs contain only [a-z0-9-], so base has no LIKE wildcards.
+	rows, err := tx.QueryContext(ctx,
+		"SELECT slug FROM learning_resources WHERE slug = $1 OR slug LIKE $1 || '-%'", base)
+	if err != nil {
+		return nil, fmt.Errorf("list taken slugs: %w", err)
+	}
+	defer rows.Close()
+
+	taken := map[string]bool{}
+	for rows.Next() {
+		var slug string
+		if err := rows.Scan(&slug); err != nil {
+			return nil, fmt.Errorf("scan taken slug: %w", err)
+		}
+		taken[slug] = true
+	}
+	return taken, rows.Err()
+}
+
+// insertResourceRow inserts a resource with the slug in input, and its
+// skills, within tx.
+func insertResourceRow(ctx context.Context, tx *sql.Tx, input CreateResourceInput) (*LearningResource, error) {
 	const q = `
 		INSERT INTO learning_resources (
 			title, slug, description, url, provider_id, resource_type,
@@ -305,7 +419,7 @@ func (r *LearningResourceRepository) Create(ctx context.Context, input CreateRes
 	}
 
 	var res LearningResource
-	err = tx.QueryRowContext(ctx, q,
+	err := tx.QueryRowContext(ctx, q,
 		input.Title, input.Slug, input.Description, input.URL, input.ProviderID,
 		string(input.ResourceType), string(input.Difficulty), string(input.CostType),
 		input.CostAmount, currency, input.DurationHours, input.DurationLabel,
@@ -318,6 +432,10 @@ func (r *LearningResourceRepository) Create(ctx context.Context, input CreateRes
 		&res.HasCertificate, &res.HasHandsOn, &res.Rating, &res.RatingCount,
 		&res.EnrollmentCount, &res.LastUpdatedDate, &res.CreatedAt, &res.UpdatedAt,
 	)
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code == "23505" && pqErr.Constraint == "learning_resources_slug_unique" {
+		return nil, fmt.Errorf("insert resource: %w: %q", ErrSlugTaken, input.Slug)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("insert resource: %w", err)
 	}
@@ -338,11 +456,103 @@ func (r *LearningResourceRepository) Create(ctx context.Context, input CreateRes
 			return nil, fmt.Errorf("insert resource skill %q: %w", skill.SkillName, err)
 		}
 	}
+	return &res, nil
+}
+
+// ImportResources inserts many resources in a single transaction. Every row
+// runs under its own savepoint, so a failing row (for example a duplicate
+// slug) is reported without aborting the rows after it. Rows naming a
+// provider by ProviderName are linked to the provider with that name, which
+// is created if it does not exist yet.
+//
+// The transaction is committed unless opts.DryRun is set, or opts.Atomic is
+// set and any row failed; committed reports which happened. results has one
+// entry per row, in order.
+func (r *LearningResourceRepository) ImportResources(ctx context.Context, rows []ImportResourceInput, opts ImportOptions) (results []ImportResult, committed bool, err error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, false, fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// providers caches provider IDs by normalized name. Providers created by
+	// a row that is rolled back are removed again.
+	providers := map[string]uuid.UUID{}
+	failed := false
+	results = make([]ImportResult, len(rows))
+	for i, row := range rows {
+		if _, err := tx.ExecContext(ctx, "SAVEPOINT import_row"); err != nil {
+			return nil, false, fmt.Errorf("create savepoint: %w", err)
+		}
+
+		input := row.CreateResourceInput
+		var createdProvider string
+		var rowErr error
+		if input.ProviderID == nil && strings.TrimSpace(row.ProviderName) != "" {
+			norm := strings.ToLower(strings.TrimSpace(row.ProviderName))
+			id, ok := providers[norm]
+			if !ok {
+				var created bool
+				id, created, rowErr = resolveProvider(ctx, tx, row.ProviderName)
+				if rowErr == nil {
+					providers[norm] = id
+					if created {
+						createdProvider = norm
+					}
+				}
+			}
+			input.ProviderID = &id
+		}
+		if rowErr == nil {
+			results[i].Resource, rowErr = insertResource(ctx, tx, input)
+		}
+
+		if rowErr != nil {
+			failed = true
+			results[i].Err = rowErr
+			if createdProvider != "" {
+				delete(providers, createdProvider)
+			}
+			if _, err := tx.ExecContext(ctx, "ROLLBACK TO SAVEPOINT import_row"); err != nil {
+				return nil, false, fmt.Errorf("rollback to savepoint: %w", err)
+			}
+			continue
+		}
+		results[i].ProviderCreated = createdProvider != ""
+		if _, err := tx.ExecContext(ctx, "RELEASE SAVEPOINT import_row"); err != nil {
+			return nil, false, fmt.Errorf("release savepoint: %w", err)
+		}
+	}
 
+	if opts.DryRun || (opts.Atomic && failed) {
+		return results, false, nil
+	}
 	if err := tx.Commit(); err != nil {
-		return nil, fmt.Errorf("commit transaction: %w", err)
+		return nil, false, fmt.Errorf("commit transaction: %w", err)
 	}
-	return &res, nil
+	return results, true, nil
+}
+
+// resolveProvider returns the ID of the provider with the given name,
+// creating it within tx if needed. created reports whether it was created.
+func resolveProvider(ctx context.Context, tx *sql.Tx, name string) (id uuid.UUID, created bool, err error) {
+	norm := strings.ToLower(strings.TrimSpace(name))
+	err = tx.QueryRowContext(ctx,
+		"SELECT id FROM resource_providers WHERE normalized_name = $1", norm).Scan(&id)
+	if err == nil {
+		return id, false, nil
+	}
+	if err != sql.ErrNoRows {
+		return uuid.Nil, false, fmt.Errorf("get provider %q: %w", name, err)
+	}
+	err = tx.QueryRowContext(ctx, `
+		INSERT INTO resource_providers (name, normalized_name)
+		VALUES ($1, $2)
+		RETURNING id`, strings.TrimSpace(name), norm).Scan(&id)
+	if err != nil {
+		return uuid.Nil, false, fmt.Errorf("create provider %q: %w", name, err)
+	}
+	return id, true, nil
 }
 
 // Update modifies an existing learning resource.
@@ -460,6 +670,104 @@ func (r *LearningResourceRepository) Delete(ctx context.Context, id uuid.UUID) e
 	return nil
 }
 
+// Restore reactivates a soft-deleted resource. It also clears the link check
+// failure count, so a resource the link checker deactivated is not
+// deactivated again by the next failed check. It returns nil if the resource
+// does not exist.
+func (r *LearningResourceRepository) Restore(ctx context.Context, id uuid.UUID) (*LearningResource, error) {
+	const q = `
+		UPDATE learning_resources
+		SET is_active = TRUE, deactivated_by_check = FALSE, consecutive_failures = 0,
+		    updated_at = NOW()
+		WHERE id = $1
+		RETURNING id, title, slug, description, url, provider_id, resource_type,
+		          difficulty, cost_type, cost_amount, cost_currency, duration_hours,
+		          duration_label, language, is_active, is_featured, is_verified,
+		          has_certificate, has_hands_on, rating, rating_count, enrollment_count,
+		          last_updated_date, created_at, updated_at`
+
+	var res LearningResource
+	err := r.db.QueryRowContext(ctx, q, id).Scan(
+		&res.ID, &res.Title, &res.Slug, &res.Description, &res.URL,
+		&res.ProviderID, &res.ResourceType, &res.Difficulty, &res.CostType,
+		&res.CostAmount, &res.CostCurrency, &res.DurationHours, &res.DurationLabel,
+		&res.Language, &res.IsActive, &res.IsFeatured, &res.IsVerified,
+		&res.HasCertificate, &res.HasHandsOn, &res.Rating, &res.RatingCount,
+		&res.EnrollmentCount, &res.LastUpdatedDate, &res.CreatedAt, &res.UpdatedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("restore resource: %w", err)
+	}
+	return &res, nil
+}
+
+// Purge permanently deletes a resource together with its skills and
+// learning path steps. A resource that is a step of a learning path is only
+// purged when force is set; otherwise Purge fails with ErrResourceInPath.
+// It returns nil if the resource does not exist.
+func (r *LearningResourceRepository) Purge(ctx context.Context, id uuid.UUID, force bool) (*PurgeResult, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// Lock the row so a concurrent path insert cannot slip in between the
+	// reference check and the delete.
+	var exists bool
+	err = tx.QueryRowContext(ctx,
+		"SELECT TRUE FROM learning_resources WHERE id = $1 FOR UPDATE", id).Scan(&exists)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("lock resource: %w", err)
+	}
+
+	var result PurgeResult
+	if !force {
+		var paths int
+		err := tx.QueryRowContext(ctx,
+			"SELECT COUNT(*) FROM learning_path_resources WHERE resource_id = $1", id).Scan(&paths)
+		if err != nil {
+			return nil, fmt.Errorf("count path references: %w", err)
+		}
+		if paths > 0 {
+			return nil, ErrResourceInPath
+		}
+	}
+
+	// The foreign keys cascade, but deleting explicitly reports what was
+	// removed.
+	deletes := []struct {
+		query string
+		count *int64
+	}{
+		{"DELETE FROM resource_skills WHERE resource_id = $1", &result.SkillsRemoved},
+		{"DELETE FROM learning_path_resources WHERE resource_id = $1", &result.PathStepsRemoved},
+	}
+	for _, d := range deletes {
+		res, err := tx.ExecContext(ctx, d.query, id)
+		if err != nil {
+			return nil, fmt.Errorf("purge resource: %w", err)
+		}
+		if *d.count, err = res.RowsAffected(); err != nil {
+			return nil, fmt.Errorf("purge resource: %w", err)
+		}
+	}
+	if _, err := tx.ExecContext(ctx, "DELETE FROM learning_resources WHERE id = $1", id); err != nil {
+		return nil, fmt.Errorf("purge resource: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("commit transaction: %w", err)
+	}
+	return &result, nil
+}
+
 // ─────────────────────────────────────────────────────────────────────────────
 // Learning path queries
 // ─────────────────────────────────────────────────────────────────────────────
@@ -556,134 +864,516 @@ func (r *LearningResourceRepository) ListPaths(ctx context.Context, targetSkill,
 	return paths, rows.Err()
 }
 
-// getPathResources returns the ordered resources for a learning path.
-func (r *LearningResourceRepository) getPathResources(ctx context.Context, pathID uuid.UUID) ([]LearningPathStep, error) {
+// GetPathByID returns a learning path by ID with its resources, including
+// inactive paths. It returns nil if the path does not exist.
+func (r *LearningResourceRepository) GetPathByID(ctx context.Context, id uuid.UUID) (*LearningPathWithResources, error) {
 	const q = `
-		SELECT lpr.step_order, lpr.is_required, lpr.notes,
-		       lr.id, lr.title, lr.slug, lr.description, lr.url, lr.provider_id,
-		       lr.resource_type, lr.difficulty, lr.cost_type, lr.cost_amount,
-		       lr.cost_currency, lr.duration_hours, lr.duration_label, lr.language,
-		       lr.is_active, lr.is_featured, lr.is_verified, lr.has_certificate,
-		       lr.has_hands_on, lr.rating, lr.rating_count, lr.enrollment_count,
-		       lr.last_updated_date, lr.created_at, lr.updated_at
-		FROM learning_path_resources lpr
-		JOIN learning_resources lr ON lpr.resource_id = lr.id
-		WHERE lpr.path_id = $1
-		ORDER BY lpr.step_order`
+		SELECT lp.id, lp.title, lp.slug, lp.description, lp.target_role,
+		       lp.target_skill, lp.difficulty, lp.estimated_hours, lp.is_active,
+		       lp.is_featured, lp.created_by, lp.created_at, lp.updated_at,
+		       COUNT(lpr.id) AS resource_count,
+		       COUNT(lpr.id) FILTER (WHERE lpr.is_required = TRUE) AS required_resource_count
+		FROM learning_paths lp
+		LEFT JOIN learning_path_resources lpr ON lp.id = lpr.path_id
+		WHERE lp.id = $1
+		GROUP BY lp.id`
 
-	rows, err := r.db.QueryContext(ctx, q, pathID)
+	var path LearningPathWithResources
+	err := r.db.QueryRowContext(ctx, q, id).Scan(
+		&path.ID, &path.Title, &path.Slug, &path.Description, &path.TargetRole,
+		&path.TargetSkill, &path.Difficulty, &path.EstimatedHours, &path.IsActive,
+		&path.IsFeatured, &path.CreatedBy, &path.CreatedAt, &path.UpdatedAt,
+		&path.ResourceCount, &path.RequiredResourceCount,
+	)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
 	if err != nil {
-		return nil, fmt.Errorf("get path resources: %w", err)
+		return nil, fmt.Errorf("get path by id: %w", err)
 	}
-	defer rows.Close()
 
-	var steps []LearningPathStep
-	for rows.Next() {
-		var step LearningPathStep
-		var notes sql.NullString
-		if err := rows.Scan(
-			&step.StepOrder, &step.IsRequired, &notes,
-			&step.Resource.ID, &step.Resource.Title, &step.Resource.Slug,
-			&step.Resource.Description, &step.Resource.URL, &step.Resource.ProviderID,
-			&step.Resource.ResourceType, &step.Resource.Difficulty, &step.Resource.CostType,
-			&step.Resource.CostAmount, &step.Resource.CostCurrency, &step.Resource.DurationHours,
-			&step.Resource.DurationLabel, &step.Resource.Language, &step.Resource.IsActive,
-			&step.Resource.IsFeatured, &step.Resource.IsVerified, &step.Resource.HasCertificate,
-			&step.Resource.HasHandsOn, &step.Resource.Rating, &step.Resource.RatingCount,
-			&step.Resource.EnrollmentCount, &step.Resource.LastUpdatedDate,
-			&step.Resource.CreatedAt, &step.Resource.UpdatedAt,
-		); err != nil {
-			return nil, fmt.Errorf("scan path step: %w", err)
-		}
-		if notes.Valid {
-			step.Notes = notes.String
-		}
-		steps = append(steps, step)
+	steps, err := r.getPathResources(ctx, path.ID)
+	if err != nil {
+		return nil, err
 	}
-	return steps, rows.Err()
-}
-
-// ─────────────────────────────────────────────────────────────────────────────
-// User progress queries
-// ─────────────────────────────────────────────────────────────────────────────
+	path.Resources = steps
 
-// GetUserProgress returns a user's progress for a specific resource.
-func (r *LearningResourceRepository) GetUserProgress(ctx context.Context, userID, resourceID uuid.UUID) (*UserResourceProgress, error) {
-	const q = `
-		SELECT id, user_id, resource_id, status, progress_percentage,
-		       started_at, completed_at, user_rating, user_notes, created_at, updated_at
-		FROM user_resource_progress
-		WHERE user_id = $1 AND resource_id = $2`
+	return &path, nil
+}
 
-	var p UserResourceProgress
-	err := r.db.QueryRowContext(ctx, q, userID, resourceID).Scan(
-		&p.ID, &p.UserID, &p.ResourceID, &p.Status, &p.ProgressPercentage,
-		&p.StartedAt, &p.CompletedAt, &p.UserRating, &p.UserNotes,
-		&p.CreatedAt, &p.UpdatedAt,
-	)
-	if err == sql.ErrNoRows {
-		return nil, nil
+// CreatePath inserts a learning path and its resources. Difficulty defaults
+// to intermediate. When input.Slug is empty a slug is generated from the
+// title with a random suffix. When input.EstimatedHours is nil it is
+// computed from the resources' duration_hours. A slug that is already in
+// use fails with ErrSlugTaken, and invalid resources with
+// ErrInvalidPathSteps.
+func (r *LearningResourceRepository) CreatePath(ctx context.Context, input CreateLearningPathInput) (*LearningPathWithResources, error) {
+	if err := validatePathSteps(input.Resources); err != nil {
+		return nil, err
 	}
+	difficulty := input.Difficulty
+	if difficulty == "" {
+		difficulty = ResourceDifficultyIntermediate
+	}
+	if strings.TrimSpace(input.Slug) == "" {
+		// Generated paths often share a title, so a random suffix avoids
+		// probing for a free numeric one.
+		input.Slug = slugify(input.Title) + "-" + uuid.NewString()[:8]
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
 	if err != nil {
-		return nil, fmt.Errorf("get user progress: %w", err)
+		return nil, fmt.Errorf("begin transaction: %w", err)
 	}
-	return &p, nil
-}
+	defer tx.Rollback()
 
-// UpsertUserProgress creates or updates a user's progress for a resource.
-func (r *LearningResourceRepository) UpsertUserProgress(ctx context.Context, userID, resourceID uuid.UUID, input UpsertProgressInput) (*UserResourceProgress, error) {
-	now := time.Now()
+	const q = `
+		INSERT INTO learning_paths (
+			title, slug, description, target_role, target_skill, difficulty,
+			estimated_hours, created_by
+		) VALUES ($1,$2,$3,$4,$5,$6,$7,$8)
+		RETURNING id`
 
-	var startedAt *time.Time
-	var completedAt *time.Time
+	var id uuid.UUID
+	err = tx.QueryRowContext(ctx, q,
+		input.Title, input.Slug, input.Description, input.TargetRole,
+		input.TargetSkill, string(difficulty), input.EstimatedHours, input.CreatedBy,
+	).Scan(&id)
+	if err := pathSlugError(err, input.Slug); err != nil {
+		return nil, fmt.Errorf("insert path: %w", err)
+	}
 
-	if input.Status == UserResourceStatusInProgress {
-		startedAt = &now
+	if err := replacePathSteps(ctx, tx, id, input.Resources); err != nil {
+		return nil, err
 	}
-	if input.Status == UserResourceStatusCompleted {
-		completedAt = &now
-		pct := int16(100)
-		input.ProgressPercentage = pct
+	if input.EstimatedHours == nil {
+		if err := recomputePathHours(ctx, tx, id); err != nil {
+			return nil, err
+		}
 	}
 
-	const q = `
-		INSERT INTO user_resource_progress (
-			user_id, resource_id, status, progress_percentage,
-			started_at, completed_at, user_rating, user_notes
-		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
-		ON CONFLICT (user_id, resource_id) DO UPDATE SET
-			status = EXCLUDED.status,
-			progress_percentage = EXCLUDED.progress_percentage,
-			started_at = COALESCE(user_resource_progress.started_at, EXCLUDED.started_at),
-			completed_at = EXCLUDED.completed_at,
-			user_rating = COALESCE(EXCLUDED.user_rating, user_resource_progress.user_rating),
-			user_notes = COALESCE(EXCLUDED.user_notes, user_resource_progress.user_notes),
-			updated_at = NOW()
-		RETURNING id, user_id, resource_id, status, progress_percentage,
-		          started_at, completed_at, user_rating, user_notes, created_at, updated_at`
-
-	var p UserResourceProgress
-	err := r.db.QueryRowContext(ctx, q,
-		userID, resourceID, string(input.Status), input.ProgressPercentage,
-		startedAt, completedAt, input.UserRating, input.UserNotes,
-	).Scan(
-		&p.ID, &p.UserID, &p.ResourceID, &p.Status, &p.ProgressPercentage,
-		&p.StartedAt, &p.CompletedAt, &p.UserRating, &p.UserNotes,
-		&p.CreatedAt, &p.UpdatedAt,
-	)
-	if err != nil {
-		return nil, fmt.Errorf("upsert user progress: %w", err)
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("commit transaction: %w", err)
 	}
-	return &p, nil
+	return r.GetPathByID(ctx, id)
 }
 
-// ListUserProgress returns all resources a user has interacted with.
-func (r *LearningResourceRepository) ListUserProgress(ctx context.Context, userID uuid.UUID, status UserResourceStatus) ([]UserResourceProgress, error) {
-	var conditions []string
+// UpdatePath modifies a learning path's fields. It returns nil if the path
+// does not exist.
+func (r *LearningResourceRepository) UpdatePath(ctx context.Context, id uuid.UUID, input UpdateLearningPathInput) (*LearningPathWithResources, error) {
+	var setClauses []string
 	var args []interface{}
 	argIdx := 1
 
-	conditions = append(conditions, fmt.Sprintf("user_id = $%d", argIdx))
+	set := func(column string, value interface{}) {
+		setClauses = append(setClauses, fmt.Sprintf("%s = $%d", column, argIdx))
+		args = append(args, value)
+		argIdx++
+	}
+	if input.Title != nil {
+		set("title", *input.Title)
+	}
+	if input.Slug != nil {
+		set("slug", *input.Slug)
+	}
+	if input.Description != nil {
+		set("description", *input.Description)
+	}
+	if input.TargetRole != nil {
+		set("target_role", *input.TargetRole)
+	}
+	if input.TargetSkill != nil {
+		set("target_skill", *input.TargetSkill)
+	}
+	if input.Difficulty != nil {
+		set("difficulty", string(*input.Difficulty))
+	}
+	if input.EstimatedHours != nil {
+		set("estimated_hours", *input.EstimatedHours)
+	}
+	if input.IsActive != nil {
+		set("is_active", *input.IsActive)
+	}
+	if input.IsFeatured != nil {
+		set("is_featured", *input.IsFeatured)
+	}
+
+	if len(setClauses) == 0 {
+		return r.GetPathByID(ctx, id)
+	}
+	setClauses = append(setClauses, "updated_at = NOW()")
+
+	args = append(args, id)
+	q := fmt.Sprintf("UPDATE learning_paths SET %s WHERE id = $%d",
+		strings.Join(setClauses, ", "), argIdx)
+
+	res, err := r.db.ExecContext(ctx, q, args...)
+	if input.Slug != nil {
+		err = pathSlugError(err, *input.Slug)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("update path: %w", err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return nil, fmt.Errorf("update path: %w", err)
+	}
+	if n == 0 {
+		return nil, nil
+	}
+	return r.GetPathByID(ctx, id)
+}
+
+// DeletePath soft-deletes a learning path by setting is_active = FALSE.
+func (r *LearningResourceRepository) DeletePath(ctx context.Context, id uuid.UUID) error {
+	_, err := r.db.ExecContext(ctx,
+		"UPDATE learning_paths SET is_active = FALSE, updated_at = NOW() WHERE id = $1", id)
+	if err != nil {
+		return fmt.Errorf("delete path: %w", err)
+	}
+	return nil
+}
+
+// SetPathResources replaces the resources of a learning path with steps and
+// recomputes its estimated hours from their duration_hours. Every resource
+// must exist and be active, and step_order values must be 1..len(steps) in
+// any order; otherwise it fails with ErrInvalidPathSteps and the path is
+// left unchanged. It returns nil if the path does not exist.
+func (r *LearningResourceRepository) SetPathResources(ctx context.Context, pathID uuid.UUID, steps []LearningPathResourceInput) (*LearningPathWithResources, error) {
+	if err := validatePathSteps(steps); err != nil {
+		return nil, err
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var exists bool
+	err = tx.QueryRowContext(ctx,
+		"SELECT TRUE FROM learning_paths WHERE id = $1 FOR UPDATE", pathID).Scan(&exists)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("lock path: %w", err)
+	}
+
+	if err := replacePathSteps(ctx, tx, pathID, steps); err != nil {
+		return nil, err
+	}
+	if err := recomputePathHours(ctx, tx, pathID); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("commit transaction: %w", err)
+	}
+	return r.GetPathByID(ctx, pathID)
+}
+
+// validatePathSteps checks that steps name each resource once, that
+// notes-only steps name a skill, and that step_order values are exactly
+// 1..len(steps).
+func validatePathSteps(steps []LearningPathResourceInput) error {
+	orders := make(map[int16]bool, len(steps))
+	resources := make(map[uuid.UUID]bool, len(steps))
+	for _, step := range steps {
+		if step.ResourceID == uuid.Nil && strings.TrimSpace(step.SkillName) == "" {
+			return fmt.Errorf("%w: step %d has neither a resource nor a skill name", ErrInvalidPathSteps, step.StepOrder)
+		}
+		if step.StepOrder < 1 || int(step.StepOrder) > len(steps) {
+			return fmt.Errorf("%w: step_order %d is outside 1..%d", ErrInvalidPathSteps, step.StepOrder, len(steps))
+		}
+		if orders[step.StepOrder] {
+			return fmt.Errorf("%w: step_order %d is used more than once", ErrInvalidPathSteps, step.StepOrder)
+		}
+		if step.ResourceID != uuid.Nil && resources[step.ResourceID] {
+			return fmt.Errorf("%w: resource %s is listed more than once", ErrInvalidPathSteps, step.ResourceID)
+		}
+		orders[step.StepOrder] = true
+		resources[step.ResourceID] = true
+	}
+	return nil
+}
+
+// replacePathSteps replaces the resources of a path within tx. steps must
+// have passed validatePathSteps.
+func replacePathSteps(ctx context.Context, tx *sql.Tx, pathID uuid.UUID, steps []LearningPathResourceInput) error {
+	var ids []string
+	for _, step := range steps {
+		if step.ResourceID != uuid.Nil {
+			ids = append(ids, step.ResourceID.String())
+		}
+	}
+	if len(ids) > 0 {
+		rows, err := tx.QueryContext(ctx,
+			"SELECT id FROM learning_resources WHERE id = ANY($1::uuid[]) AND is_active = TRUE", pq.Array(ids))
+		if err != nil {
+			return fmt.Errorf("check path resources: %w", err)
+		}
+		active := make(map[uuid.UUID]bool, len(steps))
+		for rows.Next() {
+			var id uuid.UUID
+			if err := rows.Scan(&id); err != nil {
+				rows.Close()
+				return fmt.Errorf("scan path resource: %w", err)
+			}
+			active[id] = true
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return fmt.Errorf("check path resources: %w", err)
+		}
+		for _, step := range steps {
+			if step.ResourceID != uuid.Nil && !active[step.ResourceID] {
+				return fmt.Errorf("%w: resource %s does not exist or is inactive", ErrInvalidPathSteps, step.ResourceID)
+			}
+		}
+	}
+
+	if _, err := tx.ExecContext(ctx, "DELETE FROM learning_path_resources WHERE path_id = $1", pathID); err != nil {
+		return fmt.Errorf("clear path resources: %w", err)
+	}
+	for _, step := range steps {
+		var resourceID *uuid.UUID
+		if step.ResourceID != uuid.Nil {
+			resourceID = &step.ResourceID
+		}
+		var skillName *string
+		if name := strings.TrimSpace(step.SkillName); name != "" {
+			skillName = &name
+		}
+		_, err := tx.ExecContext(ctx, `
+			INSERT INTO learning_path_resources (path_id, resource_id, skill_name, step_order, is_required, notes)
+			VALUES ($1, $2, $3, $4, $5, $6)`,
+			pathID, resourceID, skillName, step.StepOrder, step.IsRequired, step.Notes)
+		if err != nil {
+			return fmt.Errorf("insert path resource: %w", err)
+		}
+	}
+	return nil
+}
+
+// recomputePathHours sets a path's estimated hours to the sum of its
+// resources' duration_hours within tx.
+func recomputePathHours(ctx context.Context, tx *sql.Tx, pathID uuid.UUID) error {
+	const q = `
+		UPDATE learning_paths
+		SET estimated_hours = (
+		        SELECT SUM(lr.duration_hours)
+		        FROM learning_path_resources lpr
+		        JOIN learning_resources lr ON lpr.resource_id = lr.id
+		        WHERE lpr.path_id = $1),
+		    updated_at = NOW()
+		WHERE id = $1`
+	if _, err := tx.ExecContext(ctx, q, pathID); err != nil {
+		return fmt.Errorf("recompute path hours: %w", err)
+	}
+	return nil
+}
+
+// pathSlugError maps a unique violation on the path slug to ErrSlugTaken.
+func pathSlugError(err error, slug string) error {
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code == "23505" && pqErr.Constraint == "learning_paths_slug_unique" {
+		return fmt.Errorf("%w: %q", ErrSlugTaken, slug)
+	}
+	return err
+}
+
+// getPathResources returns the ordered steps of a learning path, including
+// notes-only steps.
+func (r *LearningResourceRepository) getPathResources(ctx context.Context, pathID uuid.UUID) ([]LearningPathStep, error) {
+	const q = `
+		SELECT lpr.step_order, lpr.is_required, lpr.notes, COALESCE(lpr.skill_name, ''),
+		       lr.id, lr.title, lr.slug, lr.description, lr.url, lr.provider_id,
+		       lr.resource_type, lr.difficulty, lr.cost_type, lr.cost_amount,
+		       lr.cost_currency, lr.duration_hours, lr.duration_label, lr.language,
+		       lr.is_active, lr.is_featured, lr.is_verified, lr.has_certificate,
+		       lr.has_hands_on, lr.rating, lr.rating_count, lr.enrollment_count,
+		       lr.last_updated_date, lr.created_at, lr.updated_at
+		FROM learning_path_resources lpr
+		JOIN learning_resources lr ON lpr.resource_id = lr.id
+		WHERE lpr.path_id = $1
+		ORDER BY lpr.step_order`
+
+	rows, err := r.db.QueryContext(ctx, q, pathID)
+	if err != nil {
+		return nil, fmt.Errorf("get path resources: %w", err)
+	}
+	defer rows.Close()
+
+	var steps []LearningPathStep
+	for rows.Next() {
+		var step LearningPathStep
+		var notes sql.NullString
+		res := &LearningResource{}
+		if err := rows.Scan(
+			&step.StepOrder, &step.IsRequired, &notes, &step.SkillName,
+			&res.ID, &res.Title, &res.Slug, &res.Description, &res.URL,
+			&res.ProviderID, &res.ResourceType, &res.Difficulty, &res.CostType,
+			&res.CostAmount, &res.CostCurrency, &res.DurationHours, &res.DurationLabel,
+			&res.Language, &res.IsActive, &res.IsFeatured, &res.IsVerified,
+			&res.HasCertificate, &res.HasHandsOn, &res.Rating, &res.RatingCount,
+			&res.EnrollmentCount, &res.LastUpdatedDate, &res.CreatedAt, &res.UpdatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("scan path step: %w", err)
+		}
+		if notes.Valid {
+			step.Notes = notes.String
+		}
+		step.Resource = res
+		steps = append(steps, step)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate path steps: %w", err)
+	}
+
+	noteSteps, err := r.getPathNoteSteps(ctx, pathID)
+	if err != nil {
+		return nil, err
+	}
+	if len(noteSteps) == 0 {
+		return steps, nil
+	}
+	steps = append(steps, noteSteps...)
+	sort.SliceStable(steps, func(i, j int) bool { return steps[i].StepOrder < steps[j].StepOrder })
+	return steps, nil
+}
+
+// getPathNoteSteps returns the notes-only steps of a learning path, which
+// have no resource to join.
+func (r *LearningResourceRepository) getPathNoteSteps(ctx context.Context, pathID uuid.UUID) ([]LearningPathStep, error) {
+	const q = `
+		SELECT step_order, is_required, COALESCE(notes, ''), skill_name
+		FROM learning_path_resources
+		WHERE path_id = $1 AND resource_id IS NULL
+		ORDER BY step_order`
+
+	rows, err := r.db.QueryContext(ctx, q, pathID)
+	if err != nil {
+		return nil, fmt.Errorf("get path note steps: %w", err)
+	}
+	defer rows.Close()
+
+	var steps []LearningPathStep
+	for rows.Next() {
+		var step LearningPathStep
+		if err := rows.Scan(&step.StepOrder, &step.IsRequired, &step.Notes, &step.SkillName); err != nil {
+			return nil, fmt.Errorf("scan path note step: %w", err)
+		}
+		steps = append(steps, step)
+	}
+	return steps, rows.Err()
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// User progress queries
+// ─────────────────────────────────────────────────────────────────────────────
+
+// GetUserProgress returns a user's progress for a specific resource.
+func (r *LearningResourceRepository) GetUserProgress(ctx context.Context, userID, resourceID uuid.UUID) (*UserResourceProgress, error) {
+	const q = `
+		SELECT id, user_id, resource_id, status, progress_percentage,
+		       started_at, completed_at, user_rating, user_notes, created_at, updated_at
+		FROM user_resource_progress
+		WHERE user_id = $1 AND resource_id = $2`
+
+	var p UserResourceProgress
+	err := r.db.QueryRowContext(ctx, q, userID, resourceID).Scan(
+		&p.ID, &p.UserID, &p.ResourceID, &p.Status, &p.ProgressPercentage,
+		&p.StartedAt, &p.CompletedAt, &p.UserRating, &p.UserNotes,
+		&p.CreatedAt, &p.UpdatedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get user progress: %w", err)
+	}
+	return &p, nil
+}
+
+// UpsertUserProgress creates or updates a user's progress for a resource.
+// Setting a rating recomputes the resource rating; the rating of a user
+// who reviewed the resource counts through their review instead.
+func (r *LearningResourceRepository) UpsertUserProgress(ctx context.Context, userID, resourceID uuid.UUID, input UpsertProgressInput) (*UserResourceProgress, error) {
+	now := time.Now()
+
+	var startedAt *time.Time
+	var completedAt *time.Time
+
+	if input.Status == UserResourceStatusInProgress {
+		startedAt = &now
+	}
+	if input.Status == UserResourceStatusCompleted {
+		completedAt = &now
+		pct := int16(100)
+		input.ProgressPercentage = pct
+	}
+
+	const q = `
+		INSERT INTO user_resource_progress (
+			user_id, resource_id, status, progress_percentage,
+			started_at, completed_at, user_rating, user_notes
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		ON CONFLICT (user_id, resource_id) DO UPDATE SET
+			status = EXCLUDED.status,
+			progress_percentage = EXCLUDED.progress_percentage,
+			started_at = COALESCE(user_resource_progress.started_at, EXCLUDED.started_at),
+			completed_at = EXCLUDED.completed_at,
+			user_rating = COALESCE(EXCLUDED.user_rating, user_resource_progress.user_rating),
+			user_notes = COALESCE(EXCLUDED.user_notes, user_resource_progress.user_notes),
+			updated_at = NOW()
+		RETURNING id, user_id, resource_id, status, progress_percentage,
+		          started_at, completed_at, user_rating, user_notes, created_at, updated_at`
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var p UserResourceProgress
+	err = tx.QueryRowContext(ctx, q,
+		userID, resourceID, string(input.Status), input.ProgressPercentage,
+		startedAt, completedAt, input.UserRating, input.UserNotes,
+	).Scan(
+		&p.ID, &p.UserID, &p.ResourceID, &p.Status, &p.ProgressPercentage,
+		&p.StartedAt, &p.CompletedAt, &p.UserRating, &p.UserNotes,
+		&p.CreatedAt, &p.UpdatedAt,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("upsert user progress: %w", err)
+	}
+
+	// Keep the progress of enrolled paths that include the resource current.
+	if err := recomputePathProgress(ctx, tx,
+		"upp.user_id = $1 AND upp.path_id IN (SELECT path_id FROM learning_path_resources WHERE resource_id = $2)",
+		userID, resourceID); err != nil {
+		return nil, err
+	}
+	if input.UserRating != nil {
+		if err := recomputeResourceRating(ctx, tx, resourceID); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("commit transaction: %w", err)
+	}
+	return &p, nil
+}
+
+// ListUserProgress returns all resources a user has interacted with.
+func (r *LearningResourceRepository) ListUserProgress(ctx context.Context, userID uuid.UUID, status UserResourceStatus) ([]UserResourceProgress, error) {
+	var conditions []string
+	var args []interface{}
+	argIdx := 1
+
+	conditions = append(conditions, fmt.Sprintf("user_id = $%d", argIdx))
 	args = append(args, userID)
 	argIdx++
 
@@ -721,6 +1411,240 @@ func (r *LearningResourceRepository) ListUserProgress(ctx context.Context, userI
 	return progress, rows.Err()
 }
 
+// ListUserResourceHistory returns every resource a user has progress on,
+// with the normalized names of the skills each one covers, most recently
+// updated first.
+func (r *LearningResourceRepository) ListUserResourceHistory(ctx context.Context, userID uuid.UUID) ([]UserResourceHistory, error) {
+	const q = `
+		SELECT
+			lr.id, lr.title, lr.difficulty, urp.status, urp.user_rating, urp.completed_at,
+			ARRAY_AGG(DISTINCT rs.normalized_name ORDER BY rs.normalized_name) FILTER (WHERE rs.normalized_name IS NOT NULL) AS skill_ids
+		FROM user_resource_progress urp
+		JOIN learning_resources lr ON lr.id = urp.resource_id
+		LEFT JOIN resource_skills rs ON rs.resource_id = urp.resource_id
+		WHERE urp.user_id = $1
+		GROUP BY lr.id, urp.id
+		ORDER BY urp.updated_at DESC`
+
+	rows, err := r.db.QueryContext(ctx, q, userID)
+	if err != nil {
+		return nil, fmt.Errorf("list user resource history: %w", err)
+	}
+	defer rows.Close()
+
+	var history []UserResourceHistory
+	for rows.Next() {
+		var h UserResourceHistory
+		if err := rows.Scan(
+			&h.ResourceID, &h.Title, &h.Difficulty, &h.Status, &h.UserRating,
+			&h.CompletedAt, &h.SkillIDs,
+		); err != nil {
+			return nil, fmt.Errorf("scan user resource history: %w", err)
+		}
+		history = append(history, h)
+	}
+	return history, rows.Err()
+}
+
+// ListRecommendationCandidates returns active resources the user has not
+// completed, best rated first. The candidates are ranked for the user by the
+// caller.
+func (r *LearningResourceRepository) ListRecommendationCandidates(ctx context.Context, userID uuid.UUID, limit int) ([]LearningResourceWithSkills, error) {
+	if limit <= 0 {
+		limit = 200
+	}
+	const q = `
+		SELECT
+			lr.id, lr.title, lr.slug, lr.description, lr.url, lr.provider_id,
+			lr.resource_type, lr.difficulty, lr.cost_type, lr.cost_amount,
+			lr.cost_currency, lr.duration_hours, lr.duration_label, lr.language,
+			lr.is_active, lr.is_featured, lr.is_verified, lr.has_certificate,
+			lr.has_hands_on, lr.rating, lr.rating_count, lr.enrollment_count,
+			lr.last_updated_date, lr.created_at, lr.updated_at,
+			COALESCE(rp.name, '') AS provider_name,
+			rp.website_url AS provider_url,
+			ARRAY_AGG(DISTINCT rs.skill_name ORDER BY rs.skill_name) FILTER (WHERE rs.skill_name IS NOT NULL) AS skills,
+			ARRAY_AGG(DISTINCT rs.normalized_name ORDER BY rs.normalized_name) FILTER (WHERE rs.normalized_name IS NOT NULL) AS skill_ids
+		FROM learning_resources lr
+		LEFT JOIN resource_providers rp ON lr.provider_id = rp.id
+		LEFT JOIN resource_skills rs ON lr.id = rs.resource_id
+		WHERE lr.is_active = TRUE
+		  AND NOT EXISTS (
+			SELECT 1 FROM user_resource_progress urp
+			WHERE urp.resource_id = lr.id AND urp.user_id = $1 AND urp.status = 'completed')
+		GROUP BY lr.id, rp.name, rp.website_url
+		ORDER BY lr.rating DESC NULLS LAST, lr.rating_count DESC
+		LIMIT $2`
+
+	rows, err := r.db.QueryContext(ctx, q, userID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("list recommendation candidates: %w", err)
+	}
+	defer rows.Close()
+
+	var resources []LearningResourceWithSkills
+	for rows.Next() {
+		var res LearningResourceWithSkills
+		if err := rows.Scan(
+			&res.ID, &res.Title, &res.Slug, &res.Description, &res.URL,
+			&res.ProviderID, &res.ResourceType, &res.Difficulty, &res.CostType,
+			&res.CostAmount, &res.CostCurrency, &res.DurationHours, &res.DurationLabel,
+			&res.Language, &res.IsActive, &res.IsFeatured, &res.IsVerified,
+			&res.HasCertificate, &res.HasHandsOn, &res.Rating, &res.RatingCount,
+			&res.EnrollmentCount, &res.LastUpdatedDate, &res.CreatedAt, &res.UpdatedAt,
+			&res.ProviderName, &res.ProviderURL, &res.Skills, &res.SkillIDs,
+		); err != nil {
+			return nil, fmt.Errorf("scan recommendation candidate: %w", err)
+		}
+		resources = append(resources, res)
+	}
+	return resources, rows.Err()
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Learning path progress queries
+// ─────────────────────────────────────────────────────────────────────────────
+
+// userPathProgressQuery selects a user's path progress rows. The caller
+// appends the WHERE clause.
+const userPathProgressQuery = `
+		SELECT upp.id, upp.user_id, upp.path_id, lp.slug, lp.title,
+		       upp.required_count, upp.completed_count, upp.completion_percentage,
+		       upp.current_step, upp.enrolled_at, upp.started_at, upp.completed_at,
+		       upp.updated_at
+		FROM user_path_progress upp
+		JOIN learning_paths lp ON upp.path_id = lp.id`
+
+// EnrollInPath enrolls a user in a learning path and returns their
+// progress, computed from the resources they have already completed.
+// created is false if the user was already enrolled.
+func (r *LearningResourceRepository) EnrollInPath(ctx context.Context, userID, pathID uuid.UUID) (progress *UserPathProgress, created bool, err error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, false, fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	res, err := tx.ExecContext(ctx, `
+		INSERT INTO user_path_progress (user_id, path_id)
+		VALUES ($1, $2)
+		ON CONFLICT (user_id, path_id) DO NOTHING`, userID, pathID)
+	if err != nil {
+		return nil, false, fmt.Errorf("enroll in path: %w", err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return nil, false, fmt.Errorf("enroll in path: %w", err)
+	}
+
+	if err := recomputePathProgress(ctx, tx, "upp.user_id = $1 AND upp.path_id = $2", userID, pathID); err != nil {
+		return nil, false, err
+	}
+	progress, err = scanUserPathProgress(tx.QueryRowContext(ctx,
+		userPathProgressQuery+" WHERE upp.user_id = $1 AND upp.path_id = $2", userID, pathID))
+	if err != nil {
+		return nil, false, fmt.Errorf("get path progress: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, false, fmt.Errorf("commit transaction: %w", err)
+	}
+	return progress, n > 0, nil
+}
+
+// ListUserPaths returns the learning paths a user is enrolled in, newest
+// enrollment first. Progress is recomputed first so that it reflects
+// paths whose resources changed since it was stored.
+func (r *LearningResourceRepository) ListUserPaths(ctx context.Context, userID uuid.UUID) ([]UserPathProgress, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := recomputePathProgress(ctx, tx, "upp.user_id = $1", userID); err != nil {
+		return nil, err
+	}
+
+	rows, err := tx.QueryContext(ctx,
+		userPathProgressQuery+" WHERE upp.user_id = $1 ORDER BY upp.enrolled_at DESC", userID)
+	if err != nil {
+		return nil, fmt.Errorf("list user paths: %w", err)
+	}
+	defer rows.Close()
+
+	var out []UserPathProgress
+	for rows.Next() {
+		p, err := scanUserPathProgress(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan user path: %w", err)
+		}
+		out = append(out, *p)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate user paths: %w", err)
+	}
+	rows.Close()
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("commit transaction: %w", err)
+	}
+	return out, nil
+}
+
+// recomputePathProgress recomputes the user_path_progress rows matching
+// where from the current steps of their paths. Only required steps with a
+// resource count; completion is the share of them the user completed, and
+// the current step is the first of them not completed. where may refer to
+// upp.user_id and upp.path_id and is given args.
+func recomputePathProgress(ctx context.Context, tx *sql.Tx, where string, args ...interface{}) error {
+	q := fmt.Sprintf(`
+		WITH stats AS (
+			SELECT upp.id,
+			       COUNT(lpr.id) FILTER (WHERE lpr.is_required) AS required,
+			       COUNT(lpr.id) FILTER (WHERE lpr.is_required AND urp.status = 'completed') AS completed,
+			       COUNT(urp.id) FILTER (WHERE urp.status IN ('in_progress', 'completed')) AS touched,
+			       MIN(lpr.step_order) FILTER (WHERE lpr.is_required AND urp.status IS DISTINCT FROM 'completed') AS next_step
+			FROM user_path_progress upp
+			LEFT JOIN learning_path_resources lpr
+			       ON lpr.path_id = upp.path_id AND lpr.resource_id IS NOT NULL
+			LEFT JOIN user_resource_progress urp
+			       ON urp.user_id = upp.user_id AND urp.resource_id = lpr.resource_id
+			WHERE %s
+			GROUP BY upp.id
+		)
+		UPDATE user_path_progress upp
+		SET required_count = s.required,
+		    completed_count = s.completed,
+		    completion_percentage = CASE WHEN s.required = 0 THEN 0 ELSE 100 * s.completed / s.required END,
+		    current_step = s.next_step,
+		    started_at = CASE WHEN s.touched > 0 THEN COALESCE(upp.started_at, NOW()) ELSE upp.started_at END,
+		    completed_at = CASE WHEN s.required > 0 AND s.completed = s.required
+		                        THEN COALESCE(upp.completed_at, NOW()) END,
+		    updated_at = NOW()
+		FROM stats s
+		WHERE upp.id = s.id`, where)
+
+	if _, err := tx.ExecContext(ctx, q, args...); err != nil {
+		return fmt.Errorf("recompute path progress: %w", err)
+	}
+	return nil
+}
+
+// scanUserPathProgress scans a row selected by userPathProgressQuery.
+func scanUserPathProgress(row interface{ Scan(...interface{}) error }) (*UserPathProgress, error) {
+	var p UserPathProgress
+	if err := row.Scan(
+		&p.ID, &p.UserID, &p.PathID, &p.PathSlug, &p.PathTitle,
+		&p.RequiredCount, &p.CompletedCount, &p.CompletionPercentage,
+		&p.CurrentStep, &p.EnrolledAt, &p.StartedAt, &p.CompletedAt,
+		&p.UpdatedAt,
+	); err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
 // ─────────────────────────────────────────────────────────────────────────────
 // Provider queries
 // ─────────────────────────────────────────────────────────────────────────────
@@ -775,3 +1699,206 @@ func (r *LearningResourceRepository) CreateProvider(ctx context.Context, input C
 	}
 	return &p, nil
 }
+
+// ListResourceQualityRows returns a quality audit projection of every active
+// resource and every resource the link checker deactivated, including how
+// many skills each resource is mapped to.
+func (r *LearningResourceRepository) ListResourceQualityRows(ctx context.Context) ([]ResourceQualityRow, error) {
+	const q = `
+		SELECT lr.id, lr.slug, lr.url, COUNT(rs.id), lr.created_at, lr.is_active, lr.consecutive_failures
+		FROM learning_resources lr
+		LEFT JOIN resource_skills rs ON rs.resource_id = lr.id
+		WHERE lr.is_active = TRUE OR lr.deactivated_by_check
+		GROUP BY lr.id
+		ORDER BY lr.created_at DESC`
+
+	rows, err := r.db.QueryContext(ctx, q)
+	if err != nil {
+		return nil, fmt.Errorf("list resource quality rows: %w", err)
+	}
+	defer rows.Close()
+
+	var out []ResourceQualityRow
+	for rows.Next() {
+		var row ResourceQualityRow
+		if err := rows.Scan(&row.ID, &row.Slug, &row.URL, &row.SkillCount, &row.CreatedAt,
+			&row.IsActive, &row.ConsecutiveFailures); err != nil {
+			return nil, fmt.Errorf("scan resource quality row: %w", err)
+		}
+		out = append(out, row)
+	}
+	return out, rows.Err()
+}
+
+// ListResourceSkillCoverage returns every skill of every active resource.
+func (r *LearningResourceRepository) ListResourceSkillCoverage(ctx context.Context) ([]ResourceSkillCoverageRow, error) {
+	const q = `
+		SELECT lr.id, rs.normalized_name,
+		       lr.cost_type IN ('free', 'free_audit'),
+		       lr.difficulty IN ('beginner', 'all_levels')
+		FROM resource_skills rs
+		JOIN learning_resources lr ON lr.id = rs.resource_id
+		WHERE lr.is_active = TRUE
+		ORDER BY rs.normalized_name, lr.id`
+
+	rows, err := r.db.QueryContext(ctx, q)
+	if err != nil {
+		return nil, fmt.Errorf("list resource skill coverage: %w", err)
+	}
+	defer rows.Close()
+
+	var out []ResourceSkillCoverageRow
+	for rows.Next() {
+		var row ResourceSkillCoverageRow
+		if err := rows.Scan(&row.ResourceID, &row.Skill, &row.Free, &row.Beginner); err != nil {
+			return nil, fmt.Errorf("scan resource skill coverage row: %w", err)
+		}
+		out = append(out, row)
+	}
+	return out, rows.Err()
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Link checks
+// ─────────────────────────────────────────────────────────────────────────────
+
+const linkStatusColumns = `id, title, slug, url, is_active, is_verified, last_checked_at,
+		          last_status_code, consecutive_failures, deactivated_by_check`
+
+func scanLinkStatus(row interface{ Scan(...interface{}) error }) (*ResourceLinkStatus, error) {
+	var s ResourceLinkStatus
+	err := row.Scan(&s.ID, &s.Title, &s.Slug, &s.URL, &s.IsActive, &s.IsVerified,
+		&s.LastCheckedAt, &s.LastStatusCode, &s.ConsecutiveFailures, &s.DeactivatedByCheck)
+	if err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+// ListLinkCheckTargets returns up to limit resources not checked since
+// checkedBefore, least recently checked first. Resources the link checker
+// deactivated are included so that a recovered URL reactivates them.
+func (r *LearningResourceRepository) ListLinkCheckTargets(ctx context.Context, checkedBefore time.Time, limit int) ([]LinkCheckTarget, error) {
+	const q = `
+		SELECT id, title, url
+		FROM learning_resources
+		WHERE (is_active = TRUE OR deactivated_by_check = TRUE)
+		  AND (last_checked_at IS NULL OR last_checked_at < $1)
+		ORDER BY last_checked_at ASC NULLS FIRST
+		LIMIT $2`
+
+	rows, err := r.db.QueryContext(ctx, q, checkedBefore, limit)
+	if err != nil {
+		return nil, fmt.Errorf("list link check targets: %w", err)
+	}
+	defer rows.Close()
+
+	var out []LinkCheckTarget
+	for rows.Next() {
+		var t LinkCheckTarget
+		if err := rows.Scan(&t.ID, &t.Title, &t.URL); err != nil {
+			return nil, fmt.Errorf("scan link check target: %w", err)
+		}
+		out = append(out, t)
+	}
+	return out, rows.Err()
+}
+
+// GetLinkCheckTarget returns the resource with the given ID for an on-demand
+// link check, whether or not it is active. It returns nil if none exists.
+func (r *LearningResourceRepository) GetLinkCheckTarget(ctx context.Context, id uuid.UUID) (*LinkCheckTarget, error) {
+	const q = `SELECT id, title, url FROM learning_resources WHERE id = $1`
+
+	var t LinkCheckTarget
+	err := r.db.QueryRowContext(ctx, q, id).Scan(&t.ID, &t.Title, &t.URL)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get link check target: %w", err)
+	}
+	return &t, nil
+}
+
+// RecordLinkCheck stores the result of a conclusive link check. A failure
+// increments consecutive_failures, and a 404 or 410 also clears is_verified.
+// Once deactivateAfter consecutive failures are reached (0 disables this) an
+// active resource is deactivated; a later success reactivates it. It returns
+// nil if the resource no longer exists.
+func (r *LearningResourceRepository) RecordLinkCheck(ctx context.Context, id uuid.UUID, result LinkCheckResult, deactivateAfter int) (*ResourceLinkStatus, error) {
+	// SET expressions all see the row as it was before the update.
+	const q = `
+		UPDATE learning_resources SET
+		    last_checked_at      = NOW(),
+		    last_status_code     = $2,
+		    consecutive_failures = CASE WHEN $3 THEN 0 ELSE consecutive_failures + 1 END,
+		    is_verified          = CASE WHEN $2 IN (404, 410) THEN FALSE ELSE is_verified END,
+		    is_active = CASE
+		        WHEN $3 THEN is_active OR deactivated_by_check
+		        WHEN $4 > 0 AND consecutive_failures + 1 >= $4 THEN FALSE
+		        ELSE is_active END,
+		    deactivated_by_check = CASE
+		        WHEN $3 THEN FALSE
+		        WHEN $4 > 0 AND consecutive_failures + 1 >= $4 AND is_active THEN TRUE
+		        ELSE deactivated_by_check END,
+		    url = COALESCE(NULLIF($5, ''), url)
+		WHERE id = $1
+		RETURNING ` + linkStatusColumns
+
+	status := sql.NullInt32{Int32: int32(result.StatusCode), Valid: result.StatusCode != 0}
+	s, err := scanLinkStatus(r.db.QueryRowContext(ctx, q, id, status, result.OK, deactivateAfter, result.FinalURL))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("record link check: %w", err)
+	}
+	return s, nil
+}
+
+// TouchLinkCheck records an inconclusive link check, such as a throttled
+// request, without changing the failure count. It returns nil if the
+// resource no longer exists.
+func (r *LearningResourceRepository) TouchLinkCheck(ctx context.Context, id uuid.UUID, statusCode int) (*ResourceLinkStatus, error) {
+	const q = `
+		UPDATE learning_resources
+		SET last_checked_at = NOW(), last_status_code = $2
+		WHERE id = $1
+		RETURNING ` + linkStatusColumns
+
+	status := sql.NullInt32{Int32: int32(statusCode), Valid: statusCode != 0}
+	s, err := scanLinkStatus(r.db.QueryRowContext(ctx, q, id, status))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("touch link check: %w", err)
+	}
+	return s, nil
+}
+
+// ListBrokenResources returns resources whose most recent link checks
+// failed, most failures first.
+func (r *LearningResourceRepository) ListBrokenResources(ctx context.Context) ([]ResourceLinkStatus, error) {
+	const q = `
+		SELECT ` + linkStatusColumns + `
+		FROM learning_resources
+		WHERE consecutive_failures > 0
+		ORDER BY consecutive_failures DESC, last_checked_at DESC`
+
+	rows, err := r.db.QueryContext(ctx, q)
+	if err != nil {
+		return nil, fmt.Errorf("list broken resources: %w", err)
+	}
+	defer rows.Close()
+
+	var out []ResourceLinkStatus
+	for rows.Next() {
+		s, err := scanLinkStatus(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan broken resource: %w", err)
+		}
+		out = append(out, *s)
+	}
+	return out, rows.Err()
+}
@@ -0,0 +1,475 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+func TestList_IncludeInactive(t *testing.T) {
+	for _, includeInactive := range []bool{false, true} {
+		t.Run(fmt.Sprintf("include_inactive=%v", includeInactive), func(t *testing.T) {
+			// The active filter must be present exactly when inactive
+			// resources are excluded.
+			matcher := sqlmock.QueryMatcherFunc(func(expected, actual string) error {
+				if filtered := strings.Contains(actual, "is_active = TRUE"); filtered == includeInactive {
+					return fmt.Errorf("active filter present = %v in %q", filtered, actual)
+				}
+				return sqlmock.QueryMatcherRegexp.Match(expected, actual)
+			})
+			db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(matcher))
+			if err != nil {
+				t.Fatalf("sqlmock.New: %v", err)
+			}
+			defer db.Close()
+			repo := NewLearningResourceRepository(db)
+
+			mock.ExpectQuery("SELECT COUNT").WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+			mock.ExpectQuery("SELECT").WithArgs(20, 0).WillReturnRows(sqlmock.NewRows(nil))
+
+			if _, _, err := repo.List(context.Background(), ResourceQueryFilter{IncludeInactive: includeInactive}); err != nil {
+				t.Fatalf("List: %v", err)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func TestRestore(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock.New: %v", err)
+	}
+	defer db.Close()
+	repo := NewLearningResourceRepository(db)
+
+	id := uuid.New()
+	mock.ExpectQuery(`UPDATE learning_resources SET is_active = TRUE, deactivated_by_check = FALSE, consecutive_failures = 0`).
+		WithArgs(id).
+		WillReturnRows(resourceRow("Intro to Go", "intro-to-go"))
+	res, err := repo.Restore(context.Background(), id)
+	if err != nil {
+		t.Fatalf("Restore: %v", err)
+	}
+	if res == nil || !res.IsActive {
+		t.Errorf("expected an active resource, got %+v", res)
+	}
+
+	mock.ExpectQuery("UPDATE learning_resources").WillReturnRows(sqlmock.NewRows(resourceColumns))
+	if res, err := repo.Restore(context.Background(), uuid.New()); err != nil || res != nil {
+		t.Errorf("expected nil for an unknown resource, got %+v, %v", res, err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestPurge(t *testing.T) {
+	id := uuid.New()
+	expectLock := func(mock sqlmock.Sqlmock) {
+		mock.ExpectBegin()
+		mock.ExpectQuery("SELECT TRUE FROM learning_resources WHERE id = \\$1 FOR UPDATE").
+			WithArgs(id).
+			WillReturnRows(sqlmock.NewRows([]string{"bool"}).AddRow(true))
+	}
+	expectPathCount := func(mock sqlmock.Sqlmock, n int) {
+		mock.ExpectQuery("SELECT COUNT\\(\\*\\) FROM learning_path_resources").
+			WithArgs(id).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(n))
+	}
+	expectDeletes := func(mock sqlmock.Sqlmock, skills, steps int64) {
+		mock.ExpectExec("DELETE FROM resource_skills").WithArgs(id).WillReturnResult(sqlmock.NewResult(0, skills))
+		mock.ExpectExec("DELETE FROM learning_path_resources").WithArgs(id).WillReturnResult(sqlmock.NewResult(0, steps))
+		mock.ExpectExec("DELETE FROM learning_resources").WithArgs(id).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+	}
+
+	tests := []struct {
+		name    string
+		force   bool
+		expect  func(sqlmock.Sqlmock)
+		want    *PurgeResult
+		wantErr error
+	}{
+		{
+			name: "unreferenced",
+			expect: func(mock sqlmock.Sqlmock) {
+				expectLock(mock)
+				expectPathCount(mock, 0)
+				expectDeletes(mock, 3, 0)
+			},
+			want: &PurgeResult{SkillsRemoved: 3},
+		},
+		{
+			name: "in path",
+			expect: func(mock sqlmock.Sqlmock) {
+				expectLock(mock)
+				expectPathCount(mock, 2)
+				mock.ExpectRollback()
+			},
+			wantErr: ErrResourceInPath,
+		},
+		{
+			name:  "in path with force",
+			force: true,
+			expect: func(mock sqlmock.Sqlmock) {
+				expectLock(mock)
+				expectDeletes(mock, 3, 2)
+			},
+			want: &PurgeResult{SkillsRemoved: 3, PathStepsRemoved: 2},
+		},
+		{
+			name: "not found",
+			expect: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT TRUE FROM learning_resources").WillReturnRows(sqlmock.NewRows([]string{"bool"}))
+				mock.ExpectRollback()
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("sqlmock.New: %v", err)
+			}
+			defer db.Close()
+			repo := NewLearningResourceRepository(db)
+
+			tt.expect(mock)
+			got, err := repo.Purge(context.Background(), id, tt.force)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("expected error %v, got %v", tt.wantErr, err)
+			}
+			switch {
+			case tt.want == nil && got != nil:
+				t.Errorf("expected no result, got %+v", got)
+			case tt.want != nil && (got == nil || *got != *tt.want):
+				t.Errorf("expected %+v, got %+v", tt.want, got)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func TestValidatePathSteps(t *testing.T) {
+	a, b, c := uuid.New(), uuid.New(), uuid.New()
+	tests := []struct {
+		name  string
+		steps []LearningPathResourceInput
+		ok    bool
+	}{
+		{"empty", nil, true},
+		{"in order", []LearningPathResourceInput{{ResourceID: a, StepOrder: 1}, {ResourceID: b, StepOrder: 2}}, true},
+		{"reordered", []LearningPathResourceInput{{ResourceID: c, StepOrder: 3}, {ResourceID: a, StepOrder: 1}, {ResourceID: b, StepOrder: 2}}, true},
+		{"gap", []LearningPathResourceInput{{ResourceID: a, StepOrder: 1}, {ResourceID: b, StepOrder: 3}}, false},
+		{"zero", []LearningPathResourceInput{{ResourceID: a, StepOrder: 0}}, false},
+		{"duplicate order", []LearningPathResourceInput{{ResourceID: a, StepOrder: 1}, {ResourceID: b, StepOrder: 1}}, false},
+		{"duplicate resource", []LearningPathResourceInput{{ResourceID: a, StepOrder: 1}, {ResourceID: a, StepOrder: 2}}, false},
+		{"notes-only", []LearningPathResourceInput{{SkillName: "Rust", StepOrder: 1}, {SkillName: "Zig", StepOrder: 2}}, true},
+		{"no resource or skill", []LearningPathResourceInput{{StepOrder: 1}}, false},
+	}
+	for _, tt := range tests {
+		err := validatePathSteps(tt.steps)
+		if tt.ok && err != nil {
+			t.Errorf("%s: unexpected error %v", tt.name, err)
+		}
+		if !tt.ok && !errors.Is(err, ErrInvalidPathSteps) {
+			t.Errorf("%s: expected ErrInvalidPathSteps, got %v", tt.name, err)
+		}
+	}
+}
+
+// pathColumns are the columns returned by the path queries.
+var pathColumns = []string{
+	"id", "title", "slug", "description", "target_role", "target_skill", "difficulty",
+	"estimated_hours", "is_active", "is_featured", "created_by", "created_at", "updated_at",
+	"resource_count", "required_resource_count",
+}
+
+func TestSetPathResources_Reorder(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock.New: %v", err)
+	}
+	defer db.Close()
+	repo := NewLearningResourceRepository(db)
+
+	pathID, first, second := uuid.New(), uuid.New(), uuid.New()
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT TRUE FROM learning_paths WHERE id = \\$1 FOR UPDATE").
+		WithArgs(pathID).
+		WillReturnRows(sqlmock.NewRows([]string{"bool"}).AddRow(true))
+	mock.ExpectQuery("SELECT id FROM learning_resources WHERE id = ANY").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(first).AddRow(second))
+	mock.ExpectExec("DELETE FROM learning_path_resources WHERE path_id = \\$1").
+		WithArgs(pathID).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	// The old second step becomes the first.
+	mock.ExpectExec("INSERT INTO learning_path_resources").
+		WithArgs(pathID, second, nil, int16(1), true, nil).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("INSERT INTO learning_path_resources").
+		WithArgs(pathID, first, nil, int16(2), false, nil).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("UPDATE learning_paths SET estimated_hours = \\( SELECT SUM\\(lr.duration_hours\\)").
+		WithArgs(pathID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+	now := time.Now()
+	mock.ExpectQuery("FROM learning_paths lp").
+		WithArgs(pathID).
+		WillReturnRows(sqlmock.NewRows(pathColumns).AddRow(
+			pathID, "Go Backend", "go-backend", nil, nil, "Go", "intermediate",
+			12.5, true, false, nil, now, now, 2, 1,
+		))
+	mock.ExpectQuery("FROM learning_path_resources lpr").WithArgs(pathID).WillReturnRows(sqlmock.NewRows(nil))
+	mock.ExpectQuery("resource_id IS NULL").WithArgs(pathID).WillReturnRows(sqlmock.NewRows(nil))
+
+	path, err := repo.SetPathResources(context.Background(), pathID, []LearningPathResourceInput{
+		{ResourceID: second, StepOrder: 1, IsRequired: true},
+		{ResourceID: first, StepOrder: 2},
+	})
+	if err != nil {
+		t.Fatalf("SetPathResources: %v", err)
+	}
+	if path == nil || path.EstimatedHours.Float64 != 12.5 || path.ResourceCount != 2 {
+		t.Errorf("unexpected path: %+v", path)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestSetPathResources_RejectsInactiveResource(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock.New: %v", err)
+	}
+	defer db.Close()
+	repo := NewLearningResourceRepository(db)
+
+	pathID, active, inactive := uuid.New(), uuid.New(), uuid.New()
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT TRUE FROM learning_paths").
+		WillReturnRows(sqlmock.NewRows([]string{"bool"}).AddRow(true))
+	mock.ExpectQuery("SELECT id FROM learning_resources WHERE id = ANY\\(\\$1::uuid\\[\\]\\) AND is_active = TRUE").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(active))
+	mock.ExpectRollback()
+
+	_, err = repo.SetPathResources(context.Background(), pathID, []LearningPathResourceInput{
+		{ResourceID: active, StepOrder: 1},
+		{ResourceID: inactive, StepOrder: 2},
+	})
+	if !errors.Is(err, ErrInvalidPathSteps) || !strings.Contains(err.Error(), inactive.String()) {
+		t.Errorf("expected ErrInvalidPathSteps naming %s, got %v", inactive, err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+var userPathColumns = []string{
+	"id", "user_id", "path_id", "slug", "title",
+	"required_count", "completed_count", "completion_percentage",
+	"current_step", "enrolled_at", "started_at", "completed_at", "updated_at",
+}
+
+func TestEnrollInPath(t *testing.T) {
+	for _, tt := range []struct {
+		name        string
+		inserted    int64
+		wantCreated bool
+	}{
+		{"new enrollment", 1, true},
+		{"already enrolled", 0, false},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("sqlmock.New: %v", err)
+			}
+			defer db.Close()
+			repo := NewLearningResourceRepository(db)
+
+			userID, pathID := uuid.New(), uuid.New()
+			now := time.Now()
+			mock.ExpectBegin()
+			mock.ExpectExec("INSERT INTO user_path_progress").
+				WithArgs(userID, pathID).
+				WillReturnResult(sqlmock.NewResult(0, tt.inserted))
+			mock.ExpectExec(`UPDATE user_path_progress upp`).
+				WithArgs(userID, pathID).
+				WillReturnResult(sqlmock.NewResult(0, 1))
+			mock.ExpectQuery("FROM user_path_progress upp").
+				WithArgs(userID, pathID).
+				WillReturnRows(sqlmock.NewRows(userPathColumns).AddRow(
+					uuid.New(), userID, pathID, "go-path", "Go path",
+					2, 1, 50, 2, now, now, nil, now))
+			mock.ExpectCommit()
+
+			p, created, err := repo.EnrollInPath(context.Background(), userID, pathID)
+			if err != nil {
+				t.Fatalf("EnrollInPath: %v", err)
+			}
+			if created != tt.wantCreated {
+				t.Errorf("created = %v, want %v", created, tt.wantCreated)
+			}
+			if p.CompletionPercentage != 50 || p.CurrentStep.Int16 != 2 || p.CompletedAt.Valid {
+				t.Errorf("unexpected progress %+v", p)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func TestUpsertUserProgress_RecomputesPaths(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock.New: %v", err)
+	}
+	defer db.Close()
+	repo := NewLearningResourceRepository(db)
+
+	userID, resourceID := uuid.New(), uuid.New()
+	now := time.Now()
+	mock.ExpectBegin()
+	mock.ExpectQuery("INSERT INTO user_resource_progress").
+		WillReturnRows(sqlmock.NewRows([]string{
+			"id", "user_id", "resource_id", "status", "progress_percentage",
+			"started_at", "completed_at", "user_rating", "user_notes", "created_at", "updated_at",
+		}).AddRow(uuid.New(), userID, resourceID, "completed", 100, now, now, nil, nil, now, now))
+	mock.ExpectExec(`WHERE upp.user_id = \$1 AND upp.path_id IN \(SELECT path_id FROM learning_path_resources WHERE resource_id = \$2\)`).
+		WithArgs(userID, resourceID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	_, err = repo.UpsertUserProgress(context.Background(), userID, resourceID, UpsertProgressInput{
+		Status: UserResourceStatusCompleted, ProgressPercentage: 100,
+	})
+	if err != nil {
+		t.Fatalf("UpsertUserProgress: %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+// TestPathProgressIntegration runs against a migrated PostgreSQL database
+// named by TEST_DATABASE_URL, and is skipped without one.
+func TestPathProgressIntegration(t *testing.T) {
+	dsn := os.Getenv("TEST_DATABASE_URL")
+	if dsn == "" {
+		t.Skip("TEST_DATABASE_URL not set")
+	}
+	db, err := sql.Open("postgres", dsn)
+	if err != nil {
+		t.Fatalf("open database: %v", err)
+	}
+	defer db.Close()
+	repo := NewLearningResourceRepository(db)
+	ctx := context.Background()
+
+	suffix := uuid.NewString()[:8]
+	var userID uuid.UUID
+	if err := db.QueryRowContext(ctx,
+		"INSERT INTO users (email, full_name) VALUES ($1, 'Path Progress Test') RETURNING id",
+		"path-progress-"+suffix+"@example.com").Scan(&userID); err != nil {
+		t.Fatalf("create user: %v", err)
+	}
+	t.Cleanup(func() { db.Exec("DELETE FROM users WHERE id = $1", userID) })
+
+	var ids []uuid.UUID
+	for i := 0; i < 3; i++ {
+		res, err := repo.Create(ctx, CreateResourceInput{
+			Title: fmt.Sprintf("Path Progress Test %s %d", suffix, i), URL: "https://example.com/path-progress",
+			ResourceType: ResourceTypeArticle, Difficulty: ResourceDifficultyBeginner, CostType: ResourceCostFree,
+		})
+		if err != nil {
+			t.Fatalf("Create: %v", err)
+		}
+		ids = append(ids, res.ID)
+	}
+	t.Cleanup(func() { db.Exec("DELETE FROM learning_resources WHERE id = ANY($1::uuid[])", pq.Array(ids)) })
+
+	// Two required steps and one optional step.
+	path, err := repo.CreatePath(ctx, CreateLearningPathInput{
+		Title:      "Path Progress Test " + suffix,
+		Difficulty: ResourceDifficultyBeginner,
+		Resources: []LearningPathResourceInput{
+			{ResourceID: ids[0], StepOrder: 1, IsRequired: true},
+			{ResourceID: ids[1], StepOrder: 2, IsRequired: true},
+			{ResourceID: ids[2], StepOrder: 3},
+		},
+	})
+	if err != nil {
+		t.Fatalf("CreatePath: %v", err)
+	}
+	t.Cleanup(func() { db.Exec("DELETE FROM learning_paths WHERE id = $1", path.ID) })
+
+	p, created, err := repo.EnrollInPath(ctx, userID, path.ID)
+	if err != nil || !created {
+		t.Fatalf("EnrollInPath: created=%v err=%v", created, err)
+	}
+	if p.RequiredCount != 2 || p.CompletionPercentage != 0 || p.CurrentStep.Int16 != 1 || p.StartedAt.Valid {
+		t.Errorf("unexpected progress after enrolling: %+v", p)
+	}
+
+	current := func() UserPathProgress {
+		t.Helper()
+		paths, err := repo.ListUserPaths(ctx, userID)
+		if err != nil || len(paths) != 1 {
+			t.Fatalf("ListUserPaths: %v (%d paths)", err, len(paths))
+		}
+		return paths[0]
+	}
+	complete := func(id uuid.UUID) {
+		t.Helper()
+		if _, err := repo.UpsertUserProgress(ctx, userID, id, UpsertProgressInput{
+			Status: UserResourceStatusCompleted, ProgressPercentage: 100,
+		}); err != nil {
+			t.Fatalf("UpsertUserProgress: %v", err)
+		}
+	}
+
+	// Completing the optional step starts the path but does not count.
+	complete(ids[2])
+	if got := current(); got.CompletionPercentage != 0 || !got.StartedAt.Valid {
+		t.Errorf("unexpected progress after the optional step: %+v", got)
+	}
+
+	complete(ids[0])
+	if got := current(); got.CompletionPercentage != 50 || got.CurrentStep.Int16 != 2 || got.CompletedAt.Valid {
+		t.Errorf("unexpected progress after the first step: %+v", got)
+	}
+
+	// Dropping the remaining required step completes the path.
+	if _, err := repo.SetPathResources(ctx, path.ID, []LearningPathResourceInput{
+		{ResourceID: ids[0], StepOrder: 1, IsRequired: true},
+		{ResourceID: ids[2], StepOrder: 2},
+	}); err != nil {
+		t.Fatalf("SetPathResources: %v", err)
+	}
+	if got := current(); got.CompletionPercentage != 100 || got.CurrentStep.Valid || !got.CompletedAt.Valid {
+		t.Errorf("unexpected progress after the path changed: %+v", got)
+	}
+
+	if _, created, err := repo.EnrollInPath(ctx, userID, path.ID); err != nil || created {
+		t.Errorf("re-enrolling: created=%v err=%v", created, err)
+	}
+}
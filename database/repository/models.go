@@ -116,6 +116,7 @@ type User struct {
 	Locale        string         `db:"locale" json:"locale"`
 	IsActive      bool           `db:"is_active" json:"is_active"`
 	IsAdmin       bool           `db:"is_admin" json:"is_admin"`
+	Roles         pq.StringArray `db:"roles" json:"roles"`
 	LastLoginAt   sql.NullTime   `db:"last_login_at" json:"last_login_at,omitempty"`
 	CreatedAt     time.Time      `db:"created_at" json:"created_at"`
 	UpdatedAt     time.Time      `db:"updated_at" json:"updated_at"`
@@ -332,6 +333,16 @@ type CreateUserInput struct {
 	Locale       string
 }
 
+// UpdateUserInput holds the account fields to change on a user. Nil fields
+// are left as they are.
+type UpdateUserInput struct {
+	FullName      *string
+	EmailVerified *bool
+	// Roles replaces the user's roles; is_admin follows whether it holds
+	// "admin".
+	Roles []string
+}
+
 // UpdateProfileInput holds the data for updating a user profile.
 type UpdateProfileInput struct {
 	Headline          *string
@@ -392,3 +403,14 @@ type CreateEducationInput struct {
 	Activities      []string
 	Confidence      *float64
 }
+
+// CreateResumeUploadInput holds the data for storing an uploaded resume.
+type CreateResumeUploadInput struct {
+	FileName          string
+	FileType          string // "pdf" or "docx"
+	FileContent       []byte
+	ParseStatus       string // defaults to "pending"
+	RawText           *string
+	ParserVersion     *string
+	OverallConfidence *float64
+}
@@ -0,0 +1,132 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// ErrInvalidSort is returned by ProviderStats for an unknown sort column.
+var ErrInvalidSort = errors.New("invalid sort")
+
+// providerStatsOrder maps each ProviderStatsSort to its output column.
+var providerStatsOrder = map[ProviderStatsSort]string{
+	ProviderStatsSortName:           "provider_name",
+	ProviderStatsSortResourceCount:  "resource_count",
+	ProviderStatsSortAverageRating:  "average_rating",
+	ProviderStatsSortSkillsCovered:  "skills_covered",
+	ProviderStatsSortEnrollments:    "enrollments",
+	ProviderStatsSortCompletions:    "completions",
+	ProviderStatsSortUsersCompleted: "users_completed",
+	ProviderStatsSortCompletionRate: "completion_rate",
+}
+
+// ProviderStats returns the stats of every active provider, including those
+// without active resources, in a single grouped query. Only active resources
+// and the user progress recorded against them are counted. It returns
+// ErrInvalidSort for an unknown filter.Sort.
+func (r *LearningResourceRepository) ProviderStats(ctx context.Context, filter ProviderStatsFilter) ([]ProviderStats, error) {
+	if filter.Sort == "" {
+		filter.Sort = ProviderStatsSortResourceCount
+	}
+	column, ok := providerStatsOrder[filter.Sort]
+	if !ok {
+		return nil, fmt.Errorf("%w %q", ErrInvalidSort, filter.Sort)
+	}
+	direction := "ASC"
+	if filter.Descending {
+		direction = "DESC"
+	}
+
+	// Each dimension is aggregated per provider on its own, so that joining
+	// skills and progress rows does not multiply the resource counts.
+	q := `
+		WITH active AS (
+		    SELECT id, provider_id, resource_type::text AS resource_type,
+		           difficulty::text AS difficulty, rating
+		    FROM learning_resources
+		    WHERE is_active = TRUE AND provider_id IS NOT NULL
+		),
+		resource_counts AS (
+		    SELECT provider_id, COUNT(*) AS resource_count, AVG(rating)::float8 AS average_rating
+		    FROM active
+		    GROUP BY provider_id
+		),
+		type_counts AS (
+		    SELECT provider_id, jsonb_object_agg(resource_type, n) AS by_type
+		    FROM (SELECT provider_id, resource_type, COUNT(*) AS n
+		          FROM active GROUP BY provider_id, resource_type) t
+		    GROUP BY provider_id
+		),
+		difficulty_counts AS (
+		    SELECT provider_id, jsonb_object_agg(difficulty, n) AS by_difficulty
+		    FROM (SELECT provider_id, difficulty, COUNT(*) AS n
+		          FROM active GROUP BY provider_id, difficulty) t
+		    GROUP BY provider_id
+		),
+		skill_counts AS (
+		    SELECT a.provider_id, COUNT(DISTINCT rs.normalized_name) AS skills_covered
+		    FROM active a
+		    JOIN resource_skills rs ON rs.resource_id = a.id
+		    GROUP BY a.provider_id
+		),
+		progress_counts AS (
+		    SELECT a.provider_id, COUNT(*) AS enrollments,
+		           COUNT(*) FILTER (WHERE urp.status = 'completed') AS completions,
+		           COUNT(DISTINCT urp.user_id) FILTER (WHERE urp.status = 'completed') AS users_completed
+		    FROM active a
+		    JOIN user_resource_progress urp ON urp.resource_id = a.id
+		    GROUP BY a.provider_id
+		)
+		SELECT p.id, p.name AS provider_name,
+		       COALESCE(rc.resource_count, 0) AS resource_count,
+		       COALESCE(tc.by_type, '{}'::jsonb),
+		       COALESCE(dc.by_difficulty, '{}'::jsonb),
+		       rc.average_rating,
+		       COALESCE(sc.skills_covered, 0) AS skills_covered,
+		       COALESCE(pc.enrollments, 0) AS enrollments,
+		       COALESCE(pc.completions, 0) AS completions,
+		       COALESCE(pc.users_completed, 0) AS users_completed,
+		       COALESCE(pc.completions::float8 / NULLIF(pc.enrollments, 0), 0) AS completion_rate
+		FROM resource_providers p
+		LEFT JOIN resource_counts rc ON rc.provider_id = p.id
+		LEFT JOIN type_counts tc ON tc.provider_id = p.id
+		LEFT JOIN difficulty_counts dc ON dc.provider_id = p.id
+		LEFT JOIN skill_counts sc ON sc.provider_id = p.id
+		LEFT JOIN progress_counts pc ON pc.provider_id = p.id
+		WHERE p.is_active = TRUE
+		ORDER BY ` + column + ` ` + direction + ` NULLS LAST, provider_name, p.id`
+
+	rows, err := r.db.QueryContext(ctx, q)
+	if err != nil {
+		return nil, fmt.Errorf("provider stats: %w", err)
+	}
+	defer rows.Close()
+
+	out := []ProviderStats{}
+	for rows.Next() {
+		var (
+			s                    ProviderStats
+			byType, byDifficulty []byte
+			rating               sql.NullFloat64
+		)
+		if err := rows.Scan(&s.ProviderID, &s.ProviderName, &s.ResourceCount, &byType, &byDifficulty,
+			&rating, &s.SkillsCovered, &s.Enrollments, &s.Completions, &s.UsersCompleted,
+			&s.CompletionRate); err != nil {
+			return nil, fmt.Errorf("scan provider stats: %w", err)
+		}
+		if err := json.Unmarshal(byType, &s.ByType); err != nil {
+			return nil, fmt.Errorf("decode resource counts by type: %w", err)
+		}
+		if err := json.Unmarshal(byDifficulty, &s.ByDifficulty); err != nil {
+			return nil, fmt.Errorf("decode resource counts by difficulty: %w", err)
+		}
+		if rating.Valid {
+			s.AverageRating = &rating.Float64
+		}
+		out = append(out, s)
+	}
+	return out, rows.Err()
+}
@@ -0,0 +1,219 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// providerStatsColumns are the columns returned by ProviderStats.
+var providerStatsColumns = []string{
+	"id", "provider_name", "resource_count", "by_type", "by_difficulty", "average_rating",
+	"skills_covered", "enrollments", "completions", "users_completed", "completion_rate",
+}
+
+func TestProviderStats(t *testing.T) {
+	udemy, empty := uuid.New(), uuid.New()
+	tests := []struct {
+		name      string
+		filter    ProviderStatsFilter
+		wantOrder string
+	}{
+		{"default", ProviderStatsFilter{}, `ORDER BY resource_count ASC NULLS LAST, provider_name, p\.id`},
+		{"rating descending", ProviderStatsFilter{Sort: ProviderStatsSortAverageRating, Descending: true},
+			`ORDER BY average_rating DESC NULLS LAST, provider_name, p\.id`},
+		{"completion rate", ProviderStatsFilter{Sort: ProviderStatsSortCompletionRate},
+			`ORDER BY completion_rate ASC NULLS LAST`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("sqlmock.New: %v", err)
+			}
+			defer db.Close()
+			repo := NewLearningResourceRepository(db)
+
+			// One grouped statement covers every provider.
+			mock.ExpectQuery(`WITH active AS .* GROUP BY provider_id .* ` + tt.wantOrder).
+				WillReturnRows(sqlmock.NewRows(providerStatsColumns).
+					AddRow(udemy, "Udemy", 3, []byte(`{"course": 2, "video": 1}`), []byte(`{"beginner": 3}`),
+						4.5, 3, 4, 2, 2, 0.5).
+					AddRow(empty, "Empty", 0, []byte(`{}`), []byte(`{}`), nil, 0, 0, 0, 0, 0.0))
+
+			got, err := repo.ProviderStats(context.Background(), tt.filter)
+			if err != nil {
+				t.Fatalf("ProviderStats: %v", err)
+			}
+			rating := 4.5
+			want := []ProviderStats{
+				{
+					ProviderID: udemy, ProviderName: "Udemy", ResourceCount: 3,
+					ByType: map[string]int{"course": 2, "video": 1}, ByDifficulty: map[string]int{"beginner": 3},
+					AverageRating: &rating, SkillsCovered: 3, Enrollments: 4, Completions: 2, UsersCompleted: 2,
+					CompletionRate: 0.5,
+				},
+				{ProviderID: empty, ProviderName: "Empty", ByType: map[string]int{}, ByDifficulty: map[string]int{}},
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("expected %+v, got %+v", want, got)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func TestProviderStats_InvalidSort(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock.New: %v", err)
+	}
+	defer db.Close()
+
+	_, err = NewLearningResourceRepository(db).ProviderStats(context.Background(),
+		ProviderStatsFilter{Sort: "by_type; DROP TABLE users"})
+	if !errors.Is(err, ErrInvalidSort) {
+		t.Errorf("expected ErrInvalidSort, got %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+// TestProviderStatsIntegration runs against a migrated PostgreSQL database
+// named by TEST_DATABASE_URL, and is skipped without one.
+func TestProviderStatsIntegration(t *testing.T) {
+	dsn := os.Getenv("TEST_DATABASE_URL")
+	if dsn == "" {
+		t.Skip("TEST_DATABASE_URL not set")
+	}
+	db, err := sql.Open("postgres", dsn)
+	if err != nil {
+		t.Fatalf("open database: %v", err)
+	}
+	defer db.Close()
+	repo := NewLearningResourceRepository(db)
+	ctx := context.Background()
+	suffix := uuid.NewString()[:8]
+
+	var providers []uuid.UUID
+	for _, name := range []string{"Stats Provider " + suffix, "Stats Empty " + suffix} {
+		p, err := repo.CreateProvider(ctx, CreateProviderInput{Name: name})
+		if err != nil {
+			t.Fatalf("CreateProvider: %v", err)
+		}
+		providers = append(providers, p.ID)
+	}
+	t.Cleanup(func() { db.Exec("DELETE FROM resource_providers WHERE id = ANY($1::uuid[])", pq.Array(providers)) })
+
+	var users []uuid.UUID
+	for _, name := range []string{"a", "b"} {
+		var id uuid.UUID
+		if err := db.QueryRowContext(ctx,
+			"INSERT INTO users (email, full_name) VALUES ($1, 'Provider Stats Test') RETURNING id",
+			"provider-stats-"+name+"-"+suffix+"@example.com").Scan(&id); err != nil {
+			t.Fatalf("create user: %v", err)
+		}
+		users = append(users, id)
+	}
+	t.Cleanup(func() { db.Exec("DELETE FROM users WHERE id = ANY($1::uuid[])", pq.Array(users)) })
+
+	// Three active resources covering Go, Docker and Kubernetes, and an
+	// inactive one whose skill, rating and progress are not counted.
+	fixtures := []struct {
+		resourceType ResourceType
+		difficulty   ResourceDifficulty
+		rating       float64 // 0 = unrated
+		skills       []string
+		inactive     bool
+	}{
+		{ResourceTypeCourse, ResourceDifficultyBeginner, 4.0, []string{"Go", "Docker"}, false},
+		{ResourceTypeCourse, ResourceDifficultyAdvanced, 5.0, []string{"Go", "Kubernetes"}, false},
+		{ResourceTypeVideo, ResourceDifficultyBeginner, 0, []string{"go"}, false},
+		{ResourceTypeArticle, ResourceDifficultyExpert, 1.0, []string{"Rust"}, true},
+	}
+	var ids []uuid.UUID
+	for i, f := range fixtures {
+		var skills []ResourceSkillInput
+		for _, s := range f.skills {
+			skills = append(skills, ResourceSkillInput{SkillName: s})
+		}
+		res, err := repo.Create(ctx, CreateResourceInput{
+			Title: "Provider Stats Test " + suffix + " " + string(rune('A'+i)), URL: "https://example.com/provider-stats",
+			ProviderID: &providers[0], ResourceType: f.resourceType, Difficulty: f.difficulty,
+			CostType: ResourceCostFree, Skills: skills,
+		})
+		if err != nil {
+			t.Fatalf("Create: %v", err)
+		}
+		ids = append(ids, res.ID)
+		if f.rating > 0 {
+			if _, err := db.ExecContext(ctx, "UPDATE learning_resources SET rating = $2, rating_count = 1 WHERE id = $1",
+				res.ID, f.rating); err != nil {
+				t.Fatalf("rate resource: %v", err)
+			}
+		}
+	}
+	t.Cleanup(func() { db.Exec("DELETE FROM learning_resources WHERE id = ANY($1::uuid[])", pq.Array(ids)) })
+
+	progress := []struct {
+		user, resource int
+		status         UserResourceStatus
+	}{
+		{0, 0, UserResourceStatusCompleted},
+		{0, 1, UserResourceStatusInProgress},
+		{1, 0, UserResourceStatusCompleted},
+		{1, 2, UserResourceStatusSaved},
+		{1, 3, UserResourceStatusCompleted},
+	}
+	for _, p := range progress {
+		if _, err := repo.UpsertUserProgress(ctx, users[p.user], ids[p.resource], UpsertProgressInput{Status: p.status}); err != nil {
+			t.Fatalf("UpsertUserProgress: %v", err)
+		}
+	}
+	if _, err := db.ExecContext(ctx, "UPDATE learning_resources SET is_active = FALSE WHERE id = $1", ids[3]); err != nil {
+		t.Fatalf("deactivate resource: %v", err)
+	}
+
+	stats, err := repo.ProviderStats(ctx, ProviderStatsFilter{Sort: ProviderStatsSortEnrollments, Descending: true})
+	if err != nil {
+		t.Fatalf("ProviderStats: %v", err)
+	}
+	position := map[uuid.UUID]int{}
+	byID := map[uuid.UUID]ProviderStats{}
+	for i, s := range stats {
+		position[s.ProviderID] = i
+		byID[s.ProviderID] = s
+	}
+
+	rating := 4.5
+	want := ProviderStats{
+		ProviderID: providers[0], ProviderName: "Stats Provider " + suffix, ResourceCount: 3,
+		ByType:        map[string]int{"course": 2, "video": 1},
+		ByDifficulty:  map[string]int{"beginner": 2, "advanced": 1},
+		AverageRating: &rating, SkillsCovered: 3,
+		Enrollments: 4, Completions: 2, UsersCompleted: 2, CompletionRate: 0.5,
+	}
+	if got := byID[providers[0]]; !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %+v, got %+v", want, got)
+	}
+	wantEmpty := ProviderStats{
+		ProviderID: providers[1], ProviderName: "Stats Empty " + suffix,
+		ByType: map[string]int{}, ByDifficulty: map[string]int{},
+	}
+	if got := byID[providers[1]]; !reflect.DeepEqual(got, wantEmpty) {
+		t.Errorf("expected %+v, got %+v", wantEmpty, got)
+	}
+	if position[providers[0]] > position[providers[1]] {
+		t.Error("expected the provider with enrollments before the empty one")
+	}
+}
@@ -0,0 +1,206 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// ErrInvalidMerge is returned by MergeResources when the survivor is among
+// the duplicates, no duplicates are given, or the survivor is inactive.
+var ErrInvalidMerge = errors.New("invalid merge")
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Duplicate detection queries
+// ─────────────────────────────────────────────────────────────────────────────
+
+// ListDuplicateCandidates returns every active resource, oldest first, for
+// duplicate detection.
+func (r *LearningResourceRepository) ListDuplicateCandidates(ctx context.Context) ([]DuplicateCandidate, error) {
+	const q = `
+		SELECT id, title, slug, url, provider_id, created_at
+		FROM learning_resources
+		WHERE is_active = TRUE
+		ORDER BY created_at, id`
+
+	rows, err := r.db.QueryContext(ctx, q)
+	if err != nil {
+		return nil, fmt.Errorf("list duplicate candidates: %w", err)
+	}
+	defer rows.Close()
+
+	var out []DuplicateCandidate
+	for rows.Next() {
+		var c DuplicateCandidate
+		if err := rows.Scan(&c.ID, &c.Title, &c.Slug, &c.URL, &c.ProviderID, &c.CreatedAt); err != nil {
+			return nil, fmt.Errorf("scan duplicate candidate: %w", err)
+		}
+		out = append(out, c)
+	}
+	return out, rows.Err()
+}
+
+// MergeResources merges duplicates into survivor in a single transaction.
+//
+// The duplicates' skills, learning path steps and user progress are moved to
+// the survivor, and the duplicates are soft-deleted; their reviews stay with
+// them. Where the survivor already has an equivalent row it is kept:
+//
+//   - a skill the survivor already covers is dropped;
+//   - a path step is dropped if the path already includes the survivor, or
+//     an earlier step of another duplicate;
+//   - of a user's progress rows, the most advanced one is kept: completed
+//     before in progress before saved before abandoned, then the highest
+//     percentage, then the most recently updated.
+//
+// The hours and user progress of the affected paths are recomputed. It
+// returns ErrNotFound if a resource does not exist and ErrInvalidMerge for
+// an invalid request.
+func (r *LearningResourceRepository) MergeResources(ctx context.Context, survivor uuid.UUID, duplicates []uuid.UUID) (*MergeResult, error) {
+	if len(duplicates) == 0 {
+		return nil, fmt.Errorf("%w: no duplicates given", ErrInvalidMerge)
+	}
+	seen := map[uuid.UUID]bool{survivor: true}
+	dupIDs := make([]string, 0, len(duplicates))
+	result := &MergeResult{SurvivorID: survivor}
+	for _, id := range duplicates {
+		if id == survivor {
+			return nil, fmt.Errorf("%w: resource %s cannot be merged into itself", ErrInvalidMerge, id)
+		}
+		if !seen[id] {
+			seen[id] = true
+			dupIDs = append(dupIDs, id.String())
+			result.MergedIDs = append(result.MergedIDs, id)
+		}
+	}
+
+	ids := pq.Array(dupIDs)
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// Lock every row so that no path step or progress row can be added to a
+	// duplicate while it is merged.
+	rows, err := tx.QueryContext(ctx,
+		"SELECT id, is_active FROM learning_resources WHERE id = $1 OR id = ANY($2::uuid[]) FOR UPDATE",
+		survivor, ids)
+	if err != nil {
+		return nil, fmt.Errorf("lock resources: %w", err)
+	}
+	active := make(map[uuid.UUID]bool, len(seen))
+	for rows.Next() {
+		var id uuid.UUID
+		var isActive bool
+		if err := rows.Scan(&id, &isActive); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("scan resource: %w", err)
+		}
+		active[id] = isActive
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("lock resources: %w", err)
+	}
+	for id := range seen {
+		if _, ok := active[id]; !ok {
+			return nil, fmt.Errorf("%w: resource %s", ErrNotFound, id)
+		}
+	}
+	if !active[survivor] {
+		return nil, fmt.Errorf("%w: surviving resource %s is inactive", ErrInvalidMerge, survivor)
+	}
+
+	steps := []struct {
+		name  string
+		query string
+		args  []interface{}
+		count *int64
+	}{
+		{"move skills", `
+			INSERT INTO resource_skills (resource_id, skill_name, normalized_name, is_primary, coverage_level)
+			SELECT DISTINCT ON (normalized_name) $1, skill_name, normalized_name,
+			       is_primary AND NOT EXISTS (
+			           SELECT 1 FROM resource_skills WHERE resource_id = $1 AND is_primary),
+			       coverage_level
+			FROM resource_skills
+			WHERE resource_id = ANY($2::uuid[])
+			ORDER BY normalized_name, is_primary DESC
+			ON CONFLICT (resource_id, normalized_name) DO NOTHING`,
+			[]interface{}{survivor, ids}, &result.SkillsMoved},
+		{"remove skills", "DELETE FROM resource_skills WHERE resource_id = ANY($1::uuid[])",
+			[]interface{}{ids}, nil},
+		{"remove path steps", `
+			DELETE FROM learning_path_resources lpr
+			WHERE lpr.resource_id = ANY($2::uuid[])
+			  AND EXISTS (
+			      SELECT 1 FROM learning_path_resources other
+			      WHERE other.path_id = lpr.path_id AND other.id <> lpr.id
+			        AND (other.resource_id = $1
+			             OR (other.resource_id = ANY($2::uuid[])
+			                 AND (other.step_order, other.id) < (lpr.step_order, lpr.id))))`,
+			[]interface{}{survivor, ids}, &result.PathStepsRemoved},
+		{"repoint path steps", `
+			UPDATE learning_path_resources SET resource_id = $1
+			WHERE resource_id = ANY($2::uuid[])`,
+			[]interface{}{survivor, ids}, &result.PathStepsRepointed},
+		{"remove progress", `
+			DELETE FROM user_resource_progress
+			WHERE id IN (
+			    SELECT id FROM (
+			        SELECT id, ROW_NUMBER() OVER (
+			                   PARTITION BY user_id
+			                   ORDER BY CASE status WHEN 'completed' THEN 0 WHEN 'in_progress' THEN 1
+			                                        WHEN 'saved' THEN 2 ELSE 3 END,
+			                            progress_percentage DESC, updated_at DESC) AS position
+			        FROM user_resource_progress
+			        WHERE resource_id = $1 OR resource_id = ANY($2::uuid[])
+			    ) ranked
+			    WHERE position > 1)`,
+			[]interface{}{survivor, ids}, &result.ProgressRemoved},
+		{"repoint progress", `
+			UPDATE user_resource_progress SET resource_id = $1, updated_at = NOW()
+			WHERE resource_id = ANY($2::uuid[])`,
+			[]interface{}{survivor, ids}, &result.ProgressRepointed},
+		{"recompute path hours", `
+			UPDATE learning_paths lp
+			SET estimated_hours = (
+			        SELECT SUM(lr.duration_hours)
+			        FROM learning_path_resources lpr
+			        JOIN learning_resources lr ON lpr.resource_id = lr.id
+			        WHERE lpr.path_id = lp.id),
+			    updated_at = NOW()
+			WHERE lp.id IN (SELECT path_id FROM learning_path_resources WHERE resource_id = $1)`,
+			[]interface{}{survivor}, nil},
+		{"deactivate duplicates",
+			"UPDATE learning_resources SET is_active = FALSE, updated_at = NOW() WHERE id = ANY($1::uuid[])",
+			[]interface{}{ids}, nil},
+	}
+	for _, s := range steps {
+		res, err := tx.ExecContext(ctx, s.query, s.args...)
+		if err != nil {
+			return nil, fmt.Errorf("merge resources: %s: %w", s.name, err)
+		}
+		if s.count != nil {
+			if *s.count, err = res.RowsAffected(); err != nil {
+				return nil, fmt.Errorf("merge resources: %s: %w", s.name, err)
+			}
+		}
+	}
+	// Progress through every path that now includes the survivor may have
+	// changed, whether a step or a user's progress row moved to it.
+	if err := recomputePathProgress(ctx, tx,
+		"upp.path_id IN (SELECT path_id FROM learning_path_resources WHERE resource_id = $1)", survivor); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("commit transaction: %w", err)
+	}
+	return result, nil
+}
@@ -0,0 +1,120 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+)
+
+func TestMergeResources(t *testing.T) {
+	survivor, dupA, dupB := uuid.New(), uuid.New(), uuid.New()
+	errReset := errors.New("connection reset")
+	lockRows := func(active ...bool) *sqlmock.Rows {
+		rows := sqlmock.NewRows([]string{"id", "is_active"})
+		for i, id := range []uuid.UUID{survivor, dupA, dupB}[:len(active)] {
+			rows.AddRow(id, active[i])
+		}
+		return rows
+	}
+
+	tests := []struct {
+		name       string
+		duplicates []uuid.UUID
+		expect     func(sqlmock.Sqlmock)
+		want       *MergeResult
+		wantErr    error
+	}{
+		{
+			name:       "merged",
+			duplicates: []uuid.UUID{dupA, dupB, dupA},
+			expect: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT id, is_active FROM learning_resources .* FOR UPDATE").
+					WillReturnRows(lockRows(true, true, true))
+				mock.ExpectExec("INSERT INTO resource_skills").WillReturnResult(sqlmock.NewResult(0, 2))
+				mock.ExpectExec("DELETE FROM resource_skills").WillReturnResult(sqlmock.NewResult(0, 3))
+				mock.ExpectExec("DELETE FROM learning_path_resources").WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("UPDATE learning_path_resources SET resource_id").WillReturnResult(sqlmock.NewResult(0, 2))
+				mock.ExpectExec("DELETE FROM user_resource_progress").WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("UPDATE user_resource_progress SET resource_id").WillReturnResult(sqlmock.NewResult(0, 4))
+				mock.ExpectExec("UPDATE learning_paths").WithArgs(survivor).WillReturnResult(sqlmock.NewResult(0, 2))
+				mock.ExpectExec("UPDATE learning_resources SET is_active = FALSE").WillReturnResult(sqlmock.NewResult(0, 2))
+				mock.ExpectExec("UPDATE user_path_progress").WithArgs(survivor).WillReturnResult(sqlmock.NewResult(0, 3))
+				mock.ExpectCommit()
+			},
+			want: &MergeResult{
+				SurvivorID: survivor, MergedIDs: []uuid.UUID{dupA, dupB},
+				SkillsMoved: 2, PathStepsRemoved: 1, PathStepsRepointed: 2, ProgressRemoved: 1, ProgressRepointed: 4,
+			},
+		},
+		{
+			name:       "missing duplicate",
+			duplicates: []uuid.UUID{dupA, dupB},
+			expect: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery("FOR UPDATE").WillReturnRows(lockRows(true, true))
+				mock.ExpectRollback()
+			},
+			wantErr: ErrNotFound,
+		},
+		{
+			name:       "inactive survivor",
+			duplicates: []uuid.UUID{dupA},
+			expect: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery("FOR UPDATE").WillReturnRows(lockRows(false, true))
+				mock.ExpectRollback()
+			},
+			wantErr: ErrInvalidMerge,
+		},
+		{
+			name:       "failed step rolls back",
+			duplicates: []uuid.UUID{dupA},
+			expect: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery("FOR UPDATE").WillReturnRows(lockRows(true, true))
+				mock.ExpectExec("INSERT INTO resource_skills").WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectExec("DELETE FROM resource_skills").WillReturnError(errReset)
+				mock.ExpectRollback()
+			},
+			wantErr: errReset,
+		},
+		{
+			name:       "into itself",
+			duplicates: []uuid.UUID{survivor},
+			expect:     func(sqlmock.Sqlmock) {},
+			wantErr:    ErrInvalidMerge,
+		},
+		{
+			name:    "no duplicates",
+			expect:  func(sqlmock.Sqlmock) {},
+			wantErr: ErrInvalidMerge,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("sqlmock.New: %v", err)
+			}
+			defer db.Close()
+			repo := NewLearningResourceRepository(db)
+
+			tt.expect(mock)
+			got, err := repo.MergeResources(context.Background(), survivor, tt.duplicates)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("expected error %v, got %v", tt.wantErr, err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("expected %+v, got %+v", tt.want, got)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
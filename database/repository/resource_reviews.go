@@ -0,0 +1,384 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/google/uuid"
+)
+
+// ErrOwnReview is returned by MarkReviewHelpful when a user votes for their
+// own review.
+var ErrOwnReview = errors.New("cannot vote for own review")
+
+// providerRatingWeight caps how many user ratings the provider rating of a
+// resource counts as, so that user ratings can move the combined rating of
+// resources with many provider ratings.
+const providerRatingWeight = 10
+
+// reviewColumns are the resource_reviews columns scanned by scanReview.
+const reviewColumns = `id, resource_id, user_id, rating, title, body, is_verified,
+		       helpful_count, is_reported, is_hidden, created_at, updated_at`
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Review queries
+// ─────────────────────────────────────────────────────────────────────────────
+
+// UpsertReview creates the user's review of a resource, or replaces the
+// rating, title and body of their existing one. The review is verified if
+// the user completed the resource. The resource rating is recomputed in the
+// same transaction. created is false if an existing review was edited.
+func (r *LearningResourceRepository) UpsertReview(ctx context.Context, userID, resourceID uuid.UUID, input CreateReviewInput) (review *ResourceReview, created bool, err error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, false, fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	review, created, err = scanReviewInserted(tx.QueryRowContext(ctx, `
+		INSERT INTO resource_reviews (resource_id, user_id, rating, title, body, is_verified)
+		VALUES ($1, $2, $3, $4, $5, EXISTS (
+			SELECT 1 FROM user_resource_progress
+			WHERE resource_id = $1 AND user_id = $2 AND status = 'completed'
+		))
+		ON CONFLICT (resource_id, user_id) DO UPDATE SET
+			rating = EXCLUDED.rating,
+			title = EXCLUDED.title,
+			body = EXCLUDED.body,
+			is_verified = EXCLUDED.is_verified,
+			updated_at = NOW()
+		RETURNING `+reviewColumns+`, (xmax = 0)`,
+		resourceID, userID, input.Rating, input.Title, input.Body,
+	))
+	if err != nil {
+		return nil, false, fmt.Errorf("upsert review: %w", err)
+	}
+
+	if err := recomputeResourceRating(ctx, tx, resourceID); err != nil {
+		return nil, false, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, false, fmt.Errorf("commit transaction: %w", err)
+	}
+	return review, created, nil
+}
+
+// DeleteReview deletes the user's review of a resource and recomputes the
+// resource rating. It reports whether there was a review to delete.
+func (r *LearningResourceRepository) DeleteReview(ctx context.Context, userID, resourceID uuid.UUID) (bool, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return false, fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	res, err := tx.ExecContext(ctx,
+		`DELETE FROM resource_reviews WHERE resource_id = $1 AND user_id = $2`, resourceID, userID)
+	if err != nil {
+		return false, fmt.Errorf("delete review: %w", err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("delete review: %w", err)
+	}
+	if n == 0 {
+		return false, nil
+	}
+
+	if err := recomputeResourceRating(ctx, tx, resourceID); err != nil {
+		return false, err
+	}
+	if err := tx.Commit(); err != nil {
+		return false, fmt.Errorf("commit transaction: %w", err)
+	}
+	return true, nil
+}
+
+// ListReviews returns one page of the visible reviews of a resource and the
+// total number of them.
+func (r *LearningResourceRepository) ListReviews(ctx context.Context, resourceID uuid.UUID, filter ReviewQueryFilter) ([]ResourceReview, int, error) {
+	filter.Limit = normalizeReviewLimit(filter.Limit)
+
+	order := "helpful_count DESC, created_at DESC, id"
+	if filter.Sort == ReviewSortRecent {
+		order = "created_at DESC, id"
+	}
+
+	var total int
+	if err := r.db.QueryRowContext(ctx,
+		`SELECT COUNT(*) FROM resource_reviews WHERE resource_id = $1 AND is_hidden = FALSE`,
+		resourceID).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("count reviews: %w", err)
+	}
+
+	reviews, err := r.queryReviews(ctx, `
+		SELECT `+reviewColumns+`
+		FROM resource_reviews
+		WHERE resource_id = $1 AND is_hidden = FALSE
+		ORDER BY `+order+`
+		LIMIT $2 OFFSET $3`,
+		resourceID, filter.Limit, filter.Offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("list reviews: %w", err)
+	}
+	return reviews, total, nil
+}
+
+// ListReportedReviews returns one page of the reported reviews that are not
+// hidden, oldest report first, and the total number of them.
+func (r *LearningResourceRepository) ListReportedReviews(ctx context.Context, limit, offset int) ([]ResourceReview, int, error) {
+	limit = normalizeReviewLimit(limit)
+
+	var total int
+	if err := r.db.QueryRowContext(ctx,
+		`SELECT COUNT(*) FROM resource_reviews WHERE is_reported = TRUE AND is_hidden = FALSE`,
+	).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("count reported reviews: %w", err)
+	}
+
+	reviews, err := r.queryReviews(ctx, `
+		SELECT `+reviewColumns+`
+		FROM resource_reviews
+		WHERE is_reported = TRUE AND is_hidden = FALSE
+		ORDER BY reported_at, id
+		LIMIT $1 OFFSET $2`,
+		limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("list reported reviews: %w", err)
+	}
+	return reviews, total, nil
+}
+
+// MarkReviewHelpful records the user's helpful vote for a visible review and
+// returns the review. Voting again does not count twice. It returns nil if
+// the review does not exist or is hidden, and ErrOwnReview if the user wrote
+// it.
+func (r *LearningResourceRepository) MarkReviewHelpful(ctx context.Context, userID, reviewID uuid.UUID) (*ResourceReview, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	review, err := scanReview(tx.QueryRowContext(ctx, `
+		SELECT `+reviewColumns+`
+		FROM resource_reviews
+		WHERE id = $1 AND is_hidden = FALSE
+		FOR UPDATE`, reviewID))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get review: %w", err)
+	}
+	if review.UserID == userID {
+		return nil, ErrOwnReview
+	}
+
+	res, err := tx.ExecContext(ctx, `
+		INSERT INTO review_helpful_votes (review_id, user_id)
+		VALUES ($1, $2)
+		ON CONFLICT (review_id, user_id) DO NOTHING`, reviewID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("vote for review: %w", err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return nil, fmt.Errorf("vote for review: %w", err)
+	}
+	if n > 0 {
+		if err := tx.QueryRowContext(ctx, `
+			UPDATE resource_reviews SET helpful_count = helpful_count + 1
+			WHERE id = $1
+			RETURNING helpful_count`, reviewID).Scan(&review.HelpfulCount); err != nil {
+			return nil, fmt.Errorf("count helpful vote: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("commit transaction: %w", err)
+	}
+	return review, nil
+}
+
+// ReportReview flags a visible review for moderation and returns it. It
+// returns nil if the review does not exist or is hidden.
+func (r *LearningResourceRepository) ReportReview(ctx context.Context, reviewID uuid.UUID) (*ResourceReview, error) {
+	review, err := scanReview(r.db.QueryRowContext(ctx, `
+		UPDATE resource_reviews
+		SET is_reported = TRUE, reported_at = COALESCE(reported_at, NOW())
+		WHERE id = $1 AND is_hidden = FALSE
+		RETURNING `+reviewColumns, reviewID))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("report review: %w", err)
+	}
+	return review, nil
+}
+
+// SetReviewHidden hides or shows a review and recomputes the rating of its
+// resource, since hidden reviews do not count towards it. Either way the
+// review has been moderated, so its reported flag is cleared. It returns
+// nil if the review does not exist.
+func (r *LearningResourceRepository) SetReviewHidden(ctx context.Context, reviewID uuid.UUID, hidden bool) (*ResourceReview, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	review, err := scanReview(tx.QueryRowContext(ctx, `
+		UPDATE resource_reviews
+		SET is_hidden = $2,
+		    hidden_at = CASE WHEN $2 THEN COALESCE(hidden_at, NOW()) END,
+		    is_reported = FALSE,
+		    reported_at = NULL
+		WHERE id = $1
+		RETURNING `+reviewColumns, reviewID, hidden))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("set review hidden: %w", err)
+	}
+
+	if err := recomputeResourceRating(ctx, tx, review.ResourceID); err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("commit transaction: %w", err)
+	}
+	return review, nil
+}
+
+// queryReviews runs a query selecting reviewColumns.
+func (r *LearningResourceRepository) queryReviews(ctx context.Context, q string, args ...interface{}) ([]ResourceReview, error) {
+	rows, err := r.db.QueryContext(ctx, q, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	reviews := []ResourceReview{}
+	for rows.Next() {
+		review, err := scanReview(rows)
+		if err != nil {
+			return nil, err
+		}
+		reviews = append(reviews, *review)
+	}
+	return reviews, rows.Err()
+}
+
+func normalizeReviewLimit(limit int) int {
+	if limit <= 0 {
+		return 20
+	}
+	if limit > 100 {
+		return 100
+	}
+	return limit
+}
+
+func scanReview(row interface{ Scan(...interface{}) error }) (*ResourceReview, error) {
+	var rv ResourceReview
+	if err := row.Scan(
+		&rv.ID, &rv.ResourceID, &rv.UserID, &rv.Rating, &rv.Title, &rv.Body,
+		&rv.IsVerified, &rv.HelpfulCount, &rv.IsReported, &rv.IsHidden,
+		&rv.CreatedAt, &rv.UpdatedAt,
+	); err != nil {
+		return nil, err
+	}
+	return &rv, nil
+}
+
+// scanReviewInserted scans reviewColumns followed by whether the row was
+// inserted rather than updated.
+func scanReviewInserted(row *sql.Row) (*ResourceReview, bool, error) {
+	var (
+		rv       ResourceReview
+		inserted bool
+	)
+	if err := row.Scan(
+		&rv.ID, &rv.ResourceID, &rv.UserID, &rv.Rating, &rv.Title, &rv.Body,
+		&rv.IsVerified, &rv.HelpfulCount, &rv.IsReported, &rv.IsHidden,
+		&rv.CreatedAt, &rv.UpdatedAt, &inserted,
+	); err != nil {
+		return nil, false, err
+	}
+	return &rv, inserted, nil
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Rating aggregation
+// ─────────────────────────────────────────────────────────────────────────────
+
+// recomputeResourceRating stores the rating and rating_count of a resource,
+// combined from its provider rating and the ratings of its users: their
+// visible reviews, and the progress ratings of users who did not review
+// it. The resource row is locked first so that concurrent changes are
+// counted in turn rather than overwriting each other.
+func recomputeResourceRating(ctx context.Context, tx *sql.Tx, resourceID uuid.UUID) error {
+	var (
+		providerRating sql.NullFloat64
+		providerCount  int
+	)
+	err := tx.QueryRowContext(ctx, `
+		SELECT provider_rating, provider_rating_count
+		FROM learning_resources
+		WHERE id = $1
+		FOR UPDATE`, resourceID).Scan(&providerRating, &providerCount)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("lock resource rating: %w", err)
+	}
+
+	var userSum, userCount int
+	if err := tx.QueryRowContext(ctx, `
+		SELECT COALESCE(SUM(rating), 0), COUNT(*)
+		FROM (
+			SELECT rating FROM resource_reviews
+			WHERE resource_id = $1 AND is_hidden = FALSE
+			UNION ALL
+			SELECT urp.user_rating FROM user_resource_progress urp
+			WHERE urp.resource_id = $1 AND urp.user_rating IS NOT NULL
+			  AND NOT EXISTS (
+				SELECT 1 FROM resource_reviews rr
+				WHERE rr.resource_id = urp.resource_id AND rr.user_id = urp.user_id
+			  )
+		) ratings`, resourceID).Scan(&userSum, &userCount); err != nil {
+		return fmt.Errorf("aggregate user ratings: %w", err)
+	}
+
+	rating, count := combineRatings(providerRating, providerCount, userSum, userCount)
+	if _, err := tx.ExecContext(ctx,
+		`UPDATE learning_resources SET rating = $2, rating_count = $3 WHERE id = $1`,
+		resourceID, rating, count); err != nil {
+		return fmt.Errorf("update resource rating: %w", err)
+	}
+	return nil
+}
+
+// combineRatings returns the weighted average of a provider rating and
+// userCount user ratings summing to userSum, rounded to two decimals, and
+// the number of ratings behind it. The provider rating weighs as much as
+// its own number of ratings, at least one and at most providerRatingWeight.
+// The rating is null when there are no ratings.
+func combineRatings(providerRating sql.NullFloat64, providerCount, userSum, userCount int) (sql.NullFloat64, int) {
+	weight := 0
+	if providerRating.Valid {
+		weight = min(max(providerCount, 1), providerRatingWeight)
+	}
+	if weight+userCount == 0 {
+		return sql.NullFloat64{}, providerCount
+	}
+	avg := (providerRating.Float64*float64(weight) + float64(userSum)) / float64(weight+userCount)
+	return sql.NullFloat64{Float64: math.Round(avg*100) / 100, Valid: true}, providerCount + userCount
+}
@@ -0,0 +1,213 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+)
+
+var reviewColumnNames = []string{
+	"id", "resource_id", "user_id", "rating", "title", "body", "is_verified",
+	"helpful_count", "is_reported", "is_hidden", "created_at", "updated_at",
+}
+
+func TestCombineRatings(t *testing.T) {
+	provider := sql.NullFloat64{Float64: 4.5, Valid: true}
+	tests := []struct {
+		name          string
+		provider      sql.NullFloat64
+		providerCount int
+		sum, count    int
+		wantRating    sql.NullFloat64
+		wantCount     int
+	}{
+		{"provider only", provider, 1200, 0, 0, provider, 1200},
+		{"no ratings", sql.NullFloat64{}, 0, 0, 0, sql.NullFloat64{}, 0},
+		{"users only", sql.NullFloat64{}, 0, 7, 2, sql.NullFloat64{Float64: 3.5, Valid: true}, 2},
+		// The provider rating weighs as much as ten user ratings:
+		// (4.5×10 + 1) / 11.
+		{"one review", provider, 1200, 1, 1, sql.NullFloat64{Float64: 4.18, Valid: true}, 1201},
+		// Editing the review from 1 to 5 stars: (4.5×10 + 5) / 11.
+		{"edited review", provider, 1200, 5, 1, sql.NullFloat64{Float64: 4.55, Valid: true}, 1201},
+		// Deleting it restores the provider rating.
+		{"deleted review", provider, 1200, 0, 0, provider, 1200},
+		// A provider rating with few ratings weighs less: (4.5×2 + 2) / 3.
+		{"small provider count", provider, 2, 2, 1, sql.NullFloat64{Float64: 3.67, Valid: true}, 3},
+		{"provider count unknown", provider, 0, 2, 1, sql.NullFloat64{Float64: 3.25, Valid: true}, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rating, count := combineRatings(tt.provider, tt.providerCount, tt.sum, tt.count)
+			if rating != tt.wantRating || count != tt.wantCount {
+				t.Errorf("combineRatings = %v, %d, want %v, %d", rating, count, tt.wantRating, tt.wantCount)
+			}
+		})
+	}
+}
+
+// expectRecompute expects the rating of resourceID to be recomputed from a
+// 4.5 provider rating with 1200 ratings and the given user ratings, and
+// stored as want.
+func expectRecompute(mock sqlmock.Sqlmock, resourceID uuid.UUID, sum, count int, want float64, wantCount int) {
+	mock.ExpectQuery("SELECT provider_rating, provider_rating_count").WithArgs(resourceID).
+		WillReturnRows(sqlmock.NewRows([]string{"provider_rating", "provider_rating_count"}).AddRow(4.5, 1200))
+	mock.ExpectQuery("FROM resource_reviews").WithArgs(resourceID).
+		WillReturnRows(sqlmock.NewRows([]string{"sum", "count"}).AddRow(sum, count))
+	mock.ExpectExec("UPDATE learning_resources SET rating").WithArgs(resourceID, want, wantCount).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+}
+
+func TestUpsertReview_RecomputesRating(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock.New: %v", err)
+	}
+	defer db.Close()
+	repo := NewLearningResourceRepository(db)
+
+	userID, resourceID := uuid.New(), uuid.New()
+	now := time.Now()
+	for _, tt := range []struct {
+		rating   int16
+		inserted bool
+		want     float64
+	}{
+		{1, true, 4.18},
+		{5, false, 4.55},
+	} {
+		mock.ExpectBegin()
+		mock.ExpectQuery("INSERT INTO resource_reviews").
+			WithArgs(resourceID, userID, tt.rating, nil, nil).
+			WillReturnRows(sqlmock.NewRows(append(reviewColumnNames, "inserted")).AddRow(
+				uuid.New(), resourceID, userID, tt.rating, nil, nil, false, 0, false, false, now, now, tt.inserted,
+			))
+		expectRecompute(mock, resourceID, int(tt.rating), 1, tt.want, 1201)
+		mock.ExpectCommit()
+
+		review, created, err := repo.UpsertReview(context.Background(), userID, resourceID, CreateReviewInput{Rating: tt.rating})
+		if err != nil {
+			t.Fatalf("UpsertReview: %v", err)
+		}
+		if created != tt.inserted || review.Rating != tt.rating {
+			t.Errorf("UpsertReview = %+v, created %v", review, created)
+		}
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestDeleteReview_RecomputesRating(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock.New: %v", err)
+	}
+	defer db.Close()
+	repo := NewLearningResourceRepository(db)
+
+	userID, resourceID := uuid.New(), uuid.New()
+	mock.ExpectBegin()
+	mock.ExpectExec("DELETE FROM resource_reviews").WithArgs(resourceID, userID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	expectRecompute(mock, resourceID, 0, 0, 4.5, 1200)
+	mock.ExpectCommit()
+	if deleted, err := repo.DeleteReview(context.Background(), userID, resourceID); err != nil || !deleted {
+		t.Fatalf("DeleteReview = %v, %v", deleted, err)
+	}
+
+	// Without a review nothing is recomputed.
+	mock.ExpectBegin()
+	mock.ExpectExec("DELETE FROM resource_reviews").WithArgs(resourceID, userID).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectRollback()
+	if deleted, err := repo.DeleteReview(context.Background(), userID, resourceID); err != nil || deleted {
+		t.Errorf("DeleteReview of a missing review = %v, %v", deleted, err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestSetReviewHidden_RecomputesRating(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock.New: %v", err)
+	}
+	defer db.Close()
+	repo := NewLearningResourceRepository(db)
+
+	reviewID, resourceID := uuid.New(), uuid.New()
+	now := time.Now()
+	mock.ExpectBegin()
+	mock.ExpectQuery("UPDATE resource_reviews").WithArgs(reviewID, true).
+		WillReturnRows(sqlmock.NewRows(reviewColumnNames).AddRow(
+			reviewID, resourceID, uuid.New(), 1, nil, "spam", false, 0, false, true, now, now,
+		))
+	// The hidden review no longer counts.
+	expectRecompute(mock, resourceID, 0, 0, 4.5, 1200)
+	mock.ExpectCommit()
+
+	review, err := repo.SetReviewHidden(context.Background(), reviewID, true)
+	if err != nil {
+		t.Fatalf("SetReviewHidden: %v", err)
+	}
+	if review == nil || !review.IsHidden || review.IsReported {
+		t.Errorf("unexpected review %+v", review)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestMarkReviewHelpful(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock.New: %v", err)
+	}
+	defer db.Close()
+	repo := NewLearningResourceRepository(db)
+
+	author, voter, reviewID := uuid.New(), uuid.New(), uuid.New()
+	now := time.Now()
+	reviewRow := func() *sqlmock.Rows {
+		return sqlmock.NewRows(reviewColumnNames).AddRow(
+			reviewID, uuid.New(), author, 4, nil, nil, true, 2, false, false, now, now,
+		)
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("FOR UPDATE").WithArgs(reviewID).WillReturnRows(reviewRow())
+	mock.ExpectExec("INSERT INTO review_helpful_votes").WithArgs(reviewID, voter).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery("helpful_count = helpful_count \\+ 1").WithArgs(reviewID).
+		WillReturnRows(sqlmock.NewRows([]string{"helpful_count"}).AddRow(3))
+	mock.ExpectCommit()
+	if review, err := repo.MarkReviewHelpful(context.Background(), voter, reviewID); err != nil || review.HelpfulCount != 3 {
+		t.Fatalf("MarkReviewHelpful = %+v, %v", review, err)
+	}
+
+	// A repeated vote is not counted.
+	mock.ExpectBegin()
+	mock.ExpectQuery("FOR UPDATE").WithArgs(reviewID).WillReturnRows(reviewRow())
+	mock.ExpectExec("INSERT INTO review_helpful_votes").WithArgs(reviewID, voter).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+	if review, err := repo.MarkReviewHelpful(context.Background(), voter, reviewID); err != nil || review.HelpfulCount != 2 {
+		t.Fatalf("repeated MarkReviewHelpful = %+v, %v", review, err)
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("FOR UPDATE").WithArgs(reviewID).WillReturnRows(reviewRow())
+	mock.ExpectRollback()
+	if _, err := repo.MarkReviewHelpful(context.Background(), author, reviewID); !errors.Is(err, ErrOwnReview) {
+		t.Errorf("expected ErrOwnReview, got %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,165 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/learnbot/database/encryption"
+)
+
+// ResumeRepository stores uploaded resumes. File contents are kept in
+// resume_blobs and the extracted raw text in resume_uploads.raw_text, both
+// envelope-encrypted; reads decrypt transparently.
+type ResumeRepository struct {
+	db   *sql.DB
+	keys *encryption.Keyring
+}
+
+// NewResumeRepository creates a new ResumeRepository. keys is required:
+// resume files are never stored in plaintext.
+func NewResumeRepository(db *sql.DB, keys *encryption.Keyring) *ResumeRepository {
+	return &ResumeRepository{db: db, keys: keys}
+}
+
+// blobAAD binds an encrypted resume file to its upload row.
+func blobAAD(uploadID uuid.UUID) []byte {
+	return []byte("resume_blobs:" + uploadID.String())
+}
+
+// rawTextAAD binds encrypted raw text to its upload row.
+func rawTextAAD(uploadID uuid.UUID) string {
+	return "resume_uploads.raw_text:" + uploadID.String()
+}
+
+// CreateUpload stores a new resume upload as the user's current resume.
+func (r *ResumeRepository) CreateUpload(ctx context.Context, userID uuid.UUID, input CreateResumeUploadInput) (*ResumeUpload, error) {
+	if r.keys == nil {
+		return nil, fmt.Errorf("create resume upload: %w", encryption.ErrNoKeyring)
+	}
+
+	id := uuid.New()
+	sealedFile, err := r.keys.Seal(input.FileContent, blobAAD(id))
+	if err != nil {
+		return nil, fmt.Errorf("encrypt resume file: %w", err)
+	}
+
+	var rawText *string
+	if input.RawText != nil {
+		sealed, err := r.keys.SealString(*input.RawText, rawTextAAD(id))
+		if err != nil {
+			return nil, fmt.Errorf("encrypt resume text: %w", err)
+		}
+		rawText = &sealed
+	}
+
+	status := input.ParseStatus
+	if status == "" {
+		status = "pending"
+	}
+	var parsedAt *time.Time
+	if status == "done" {
+		now := time.Now()
+		parsedAt = &now
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	u := &ResumeUpload{}
+	err = tx.QueryRowContext(ctx, `
+		INSERT INTO resume_uploads (
+			id, user_id, file_name, file_type, file_size_bytes, storage_key, version,
+			parse_status, parsed_at, raw_text, parser_version, overall_confidence
+		)
+		VALUES ($1, $2, $3, $4, $5, $6,
+		        (SELECT COALESCE(MAX(version), 0) + 1 FROM resume_uploads WHERE user_id = $2),
+		        $7, $8, $9, $10, $11)
+		RETURNING id, user_id, file_name, file_type, file_size_bytes, storage_key,
+		          version, is_current, parsed_at, parse_status, parse_error,
+		          parser_version, overall_confidence, created_at`,
+		id, userID, input.FileName, input.FileType, len(input.FileContent),
+		"db:resume_blobs/"+id.String(), status, parsedAt, rawText,
+		input.ParserVersion, input.OverallConfidence,
+	).Scan(
+		&u.ID, &u.UserID, &u.FileName, &u.FileType, &u.FileSizeBytes, &u.StorageKey,
+		&u.Version, &u.IsCurrent, &u.ParsedAt, &u.ParseStatus, &u.ParseError,
+		&u.ParserVersion, &u.OverallConfidence, &u.CreatedAt,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("create resume upload: %w", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, `
+		INSERT INTO resume_blobs (resume_upload_id, key_version, envelope)
+		VALUES ($1, $2, $3)`,
+		id, r.keys.ActiveVersion(), sealedFile,
+	); err != nil {
+		return nil, fmt.Errorf("store resume file: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("commit resume upload: %w", err)
+	}
+
+	if input.RawText != nil {
+		u.RawText = sql.NullString{String: *input.RawText, Valid: true}
+	}
+	return u, nil
+}
+
+// GetUpload retrieves a resume upload by ID with its raw text decrypted.
+func (r *ResumeRepository) GetUpload(ctx context.Context, id uuid.UUID) (*ResumeUpload, error) {
+	u := &ResumeUpload{}
+	err := r.db.QueryRowContext(ctx, `
+		SELECT id, user_id, file_name, file_type, file_size_bytes, storage_key,
+		       version, is_current, parsed_at, parse_status, parse_error,
+		       raw_text, parser_version, overall_confidence, created_at
+		FROM resume_uploads WHERE id = $1`, id,
+	).Scan(
+		&u.ID, &u.UserID, &u.FileName, &u.FileType, &u.FileSizeBytes, &u.StorageKey,
+		&u.Version, &u.IsCurrent, &u.ParsedAt, &u.ParseStatus, &u.ParseError,
+		&u.RawText, &u.ParserVersion, &u.OverallConfidence, &u.CreatedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get resume upload: %w", err)
+	}
+	if u.RawText.Valid {
+		text, err := r.keys.OpenString(u.RawText.String, rawTextAAD(id))
+		if err != nil {
+			return nil, fmt.Errorf("decrypt resume text: %w", err)
+		}
+		u.RawText.String = text
+	}
+	return u, nil
+}
+
+// GetFile returns the decrypted file contents of a resume upload.
+// Corrupt or tampered blobs yield an error wrapping
+// encryption.ErrCorruptCiphertext rather than partial data.
+func (r *ResumeRepository) GetFile(ctx context.Context, uploadID uuid.UUID) ([]byte, error) {
+	var sealed []byte
+	err := r.db.QueryRowContext(ctx, `
+		SELECT envelope FROM resume_blobs WHERE resume_upload_id = $1`, uploadID,
+	).Scan(&sealed)
+	if err == sql.ErrNoRows {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get resume file: %w", err)
+	}
+	data, err := r.keys.Open(sealed, blobAAD(uploadID))
+	if err != nil {
+		return nil, fmt.Errorf("decrypt resume file: %w", err)
+	}
+	return data, nil
+}
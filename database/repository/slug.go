@@ -0,0 +1,76 @@
+package repository
+
+import (
+	"strings"
+	"unicode"
+)
+
+const (
+	// maxSlugLength caps generated slugs, leaving room for a "-N" suffix.
+	maxSlugLength = 80
+
+	// fallbackSlug is used for titles with no letters or digits.
+	fallbackSlug = "resource"
+)
+
+// slugTransliterations maps accented and special Latin letters to ASCII.
+var slugTransliterations = map[rune]string{
+	'à': "a", 'á': "a", 'â': "a", 'ã': "a", 'ä': "a", 'å': "a", 'ā': "a", 'ă': "a", 'ą': "a",
+	'ç': "c", 'ć': "c", 'č': "c", 'ĉ': "c", 'ċ': "c",
+	'ď': "d", 'đ': "d", 'ð': "d",
+	'è': "e", 'é': "e", 'ê': "e", 'ë': "e", 'ē': "e", 'ė': "e", 'ę': "e", 'ě': "e",
+	'ğ': "g", 'ģ': "g", 'ĝ': "g",
+	'ì': "i", 'í': "i", 'î': "i", 'ï': "i", 'ī': "i", 'į': "i", 'ı': "i",
+	'ķ': "k",
+	'ĺ': "l", 'ļ': "l", 'ľ': "l", 'ł': "l",
+	'ñ': "n", 'ń': "n", 'ň': "n", 'ņ': "n",
+	'ò': "o", 'ó': "o", 'ô': "o", 'õ': "o", 'ö': "o", 'ø': "o", 'ō': "o", 'ő': "o",
+	'ŕ': "r", 'ř': "r",
+	'ś': "s", 'š': "s", 'ş': "s", 'ș': "s",
+	'ť': "t", 'ţ': "t", 'ț': "t",
+	'ù': "u", 'ú': "u", 'û': "u", 'ü': "u", 'ū': "u", 'ů': "u", 'ű': "u", 'ų': "u",
+	'ý': "y", 'ÿ': "y",
+	'ź': "z", 'ż': "z", 'ž': "z",
+	'ß': "ss", 'æ': "ae", 'œ': "oe", 'þ': "th",
+	'&': " and ",
+}
+
+// slugify derives a URL slug from a title: lowercase ASCII letters and
+// digits separated by single hyphens. Accented letters are transliterated
+// and everything else, including emoji, separates words. Long titles are cut
+// at a word boundary; titles with nothing left become "resource".
+func slugify(title string) string {
+	var b strings.Builder
+	pendingHyphen := false
+	for _, r := range strings.ToLower(title) {
+		s, ok := slugTransliterations[r]
+		if !ok {
+			s = string(r)
+		}
+		for _, c := range s {
+			if (c >= 'a' && c <= 'z') || (c >= '0' && c <= '9') {
+				if pendingHyphen && b.Len() > 0 {
+					b.WriteByte('-')
+				}
+				pendingHyphen = false
+				b.WriteRune(c)
+			} else if c != '\'' && c != '’' && !unicode.Is(unicode.Mn, c) {
+				// Apostrophes and combining marks join rather than split
+				// words: "Don't" is "dont", not "don-t".
+				pendingHyphen = true
+			}
+		}
+	}
+
+	slug := b.String()
+	if len(slug) > maxSlugLength {
+		slug = slug[:maxSlugLength]
+		if i := strings.LastIndexByte(slug, '-'); i > 0 {
+			slug = slug[:i]
+		}
+	}
+	if slug == "" {
+		return fallbackSlug
+	}
+	return slug
+}
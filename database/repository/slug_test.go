@@ -0,0 +1,201 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"os"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+func TestSlugify(t *testing.T) {
+	tests := []struct {
+		title string
+		want  string
+	}{
+		{"Introduction to Go", "introduction-to-go"},
+		{"  Go: The Complete Guide (2024 Edition)!  ", "go-the-complete-guide-2024-edition"},
+		{"Crème Brûlée Programming", "creme-brulee-programming"},
+		{"Straße & Œuvre", "strasse-and-oeuvre"},
+		{"Łódź Data Science", "lodz-data-science"},
+		{"Café Decomposed", "cafe-decomposed"},
+		{"Don't Repeat Yourself", "dont-repeat-yourself"},
+		{"🚀 Rocket Science 🚀", "rocket-science"},
+		{"Python🐍for📊Data", "python-for-data"},
+		{"!!! --- ???", fallbackSlug},
+		{"🎉🎉🎉", fallbackSlug},
+		{"", fallbackSlug},
+		{"機械学習入門", fallbackSlug},
+		{"C++ / C# / F#", "c-c-f"},
+	}
+	for _, tt := range tests {
+		if got := slugify(tt.title); got != tt.want {
+			t.Errorf("slugify(%q) = %q, want %q", tt.title, got, tt.want)
+		}
+	}
+}
+
+func TestSlugify_LongTitle(t *testing.T) {
+	title := strings.Repeat("Kubernetes Operators ", 20)
+	got := slugify(title)
+	if len(got) > maxSlugLength {
+		t.Errorf("slug is %d characters, want at most %d", len(got), maxSlugLength)
+	}
+	if strings.HasSuffix(got, "-") || !strings.HasSuffix(got, "kubernetes") && !strings.HasSuffix(got, "operators") {
+		t.Errorf("slug should be cut at a word boundary, got %q", got)
+	}
+
+	word := slugify(strings.Repeat("a", 200))
+	if len(word) != maxSlugLength {
+		t.Errorf("a single long word should be cut to %d characters, got %d", maxSlugLength, len(word))
+	}
+}
+
+func TestSlugify_IsValidSlug(t *testing.T) {
+	valid := regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+	for _, title := range []string{"A -- B", "-leading", "trailing-", "x\ty\nz", "ÀÉÎÕÜ", "100% Free!"} {
+		if got := slugify(title); !valid.MatchString(got) {
+			t.Errorf("slugify(%q) = %q is not a valid slug", title, got)
+		}
+	}
+}
+
+// resourceColumns are the columns returned by the resource insert.
+var resourceColumns = []string{
+	"id", "title", "slug", "description", "url", "provider_id", "resource_type",
+	"difficulty", "cost_type", "cost_amount", "cost_currency", "duration_hours",
+	"duration_label", "language", "is_active", "is_featured", "is_verified",
+	"has_certificate", "has_hands_on", "rating", "rating_count", "enrollment_count",
+	"last_updated_date", "created_at", "updated_at",
+}
+
+func resourceRow(title, slug string) *sqlmock.Rows {
+	now := time.Now()
+	return sqlmock.NewRows(resourceColumns).AddRow(
+		uuid.New(), title, slug, nil, "https://example.com", nil, "course",
+		"intermediate", "paid", nil, "USD", nil,
+		nil, "en", true, false, false,
+		false, false, nil, 0, nil,
+		nil, now, now,
+	)
+}
+
+func slugConflict() error {
+	return &pq.Error{Code: "23505", Constraint: "learning_resources_slug_unique"}
+}
+
+func TestCreate_GeneratedSlugRetriesOnConflict(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock.New: %v", err)
+	}
+	defer db.Close()
+	repo := NewLearningResourceRepository(db)
+
+	// "intro-to-go" and "intro-to-go-2" exist; a concurrent insert takes
+	// "intro-to-go-3" before ours, so the resource ends up as "-4".
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT slug FROM learning_resources").
+		WithArgs("intro-to-go").
+		WillReturnRows(sqlmock.NewRows([]string{"slug"}).AddRow("intro-to-go").AddRow("intro-to-go-2"))
+	mock.ExpectExec("SAVEPOINT resource_slug").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery("INSERT INTO learning_resources").
+		WithArgs("Intro to Go", "intro-to-go-3", sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(),
+			sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(),
+			sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnError(slugConflict())
+	mock.ExpectExec("ROLLBACK TO SAVEPOINT resource_slug").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("SAVEPOINT resource_slug").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery("INSERT INTO learning_resources").
+		WithArgs("Intro to Go", "intro-to-go-4", sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(),
+			sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(),
+			sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnRows(resourceRow("Intro to Go", "intro-to-go-4"))
+	mock.ExpectExec("RELEASE SAVEPOINT resource_slug").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+
+	res, err := repo.Create(context.Background(), CreateResourceInput{
+		Title: "Intro to Go", URL: "https://example.com",
+		ResourceType: ResourceTypeCourse, Difficulty: ResourceDifficultyIntermediate, CostType: ResourceCostPaid,
+	})
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	if res.Slug != "intro-to-go-4" {
+		t.Errorf("expected slug intro-to-go-4, got %q", res.Slug)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestCreate_ExplicitSlugTaken(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock.New: %v", err)
+	}
+	defer db.Close()
+	repo := NewLearningResourceRepository(db)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("INSERT INTO learning_resources").WillReturnError(slugConflict())
+	mock.ExpectRollback()
+
+	_, err = repo.Create(context.Background(), CreateResourceInput{
+		Title: "Intro to Go", Slug: "intro-to-go", URL: "https://example.com",
+	})
+	if !errors.Is(err, ErrSlugTaken) {
+		t.Errorf("expected ErrSlugTaken, got %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+// TestCreate_SlugConflictIntegration runs against a migrated PostgreSQL
+// database named by TEST_DATABASE_URL, and is skipped without one.
+func TestCreate_SlugConflictIntegration(t *testing.T) {
+	dsn := os.Getenv("TEST_DATABASE_URL")
+	if dsn == "" {
+		t.Skip("TEST_DATABASE_URL not set")
+	}
+	db, err := sql.Open("postgres", dsn)
+	if err != nil {
+		t.Fatalf("open database: %v", err)
+	}
+	defer db.Close()
+	repo := NewLearningResourceRepository(db)
+	ctx := context.Background()
+
+	title := "Slug Conflict Test " + uuid.NewString()[:8]
+	base := slugify(title)
+	t.Cleanup(func() {
+		db.Exec("DELETE FROM learning_resources WHERE slug = $1 OR slug LIKE $1 || '-%'", base)
+	})
+
+	input := CreateResourceInput{
+		Title: title, URL: "https://example.com/slug-test",
+		ResourceType: ResourceTypeArticle, Difficulty: ResourceDifficultyBeginner, CostType: ResourceCostFree,
+	}
+	for _, want := range []string{base, base + "-2", base + "-3"} {
+		res, err := repo.Create(ctx, input)
+		if err != nil {
+			t.Fatalf("Create: %v", err)
+		}
+		if res.Slug != want {
+			t.Errorf("expected slug %q, got %q", want, res.Slug)
+		}
+	}
+
+	input.Slug = base
+	if _, err := repo.Create(ctx, input); !errors.Is(err, ErrSlugTaken) {
+		t.Errorf("expected ErrSlugTaken for an explicit slug, got %v", err)
+	}
+}
@@ -0,0 +1,84 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+// EraseUser removes everything stored about a user of a deleted account:
+// their resource and path progress, their reviews and their helpful votes.
+// The helpful counts of the reviews they voted for and the ratings of the
+// resources they rated are updated to match. Erasing a user with no data
+// removes nothing, so the call can be retried until it succeeds.
+func (r *LearningResourceRepository) EraseUser(ctx context.Context, userID uuid.UUID) (*UserErasure, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// Resources whose rating counts the user, in a stable order so that
+	// concurrent recomputes lock them in the same order.
+	rows, err := tx.QueryContext(ctx, `
+		SELECT resource_id FROM user_resource_progress
+		WHERE user_id = $1 AND user_rating IS NOT NULL
+		UNION
+		SELECT resource_id FROM resource_reviews WHERE user_id = $1
+		ORDER BY resource_id`, userID)
+	if err != nil {
+		return nil, fmt.Errorf("list rated resources: %w", err)
+	}
+	var rated []uuid.UUID
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("scan rated resource: %w", err)
+		}
+		rated = append(rated, id)
+	}
+	if err := rows.Err(); err != nil {
+		rows.Close()
+		return nil, fmt.Errorf("iterate rated resources: %w", err)
+	}
+	rows.Close()
+
+	if _, err := tx.ExecContext(ctx, `
+		UPDATE resource_reviews rr SET helpful_count = GREATEST(rr.helpful_count - 1, 0)
+		FROM review_helpful_votes v
+		WHERE v.review_id = rr.id AND v.user_id = $1`, userID); err != nil {
+		return nil, fmt.Errorf("update helpful counts: %w", err)
+	}
+
+	var out UserErasure
+	for _, d := range []struct {
+		table string
+		n     *int64
+	}{
+		{"review_helpful_votes", &out.HelpfulVotesRemoved},
+		{"resource_reviews", &out.ReviewsRemoved},
+		{"user_path_progress", &out.PathProgressRemoved},
+		{"user_resource_progress", &out.ProgressRemoved},
+	} {
+		res, err := tx.ExecContext(ctx, `DELETE FROM `+d.table+` WHERE user_id = $1`, userID)
+		if err != nil {
+			return nil, fmt.Errorf("delete %s: %w", d.table, err)
+		}
+		if *d.n, err = res.RowsAffected(); err != nil {
+			return nil, fmt.Errorf("delete %s: %w", d.table, err)
+		}
+	}
+
+	for _, id := range rated {
+		if err := recomputeResourceRating(ctx, tx, id); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("commit transaction: %w", err)
+	}
+	return &out, nil
+}
@@ -0,0 +1,55 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+)
+
+func TestEraseUser(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock.New: %v", err)
+	}
+	defer db.Close()
+	repo := NewLearningResourceRepository(db)
+
+	userID, resourceID := uuid.New(), uuid.New()
+	expectErase := func(rated []uuid.UUID, removed [4]int64) {
+		rows := sqlmock.NewRows([]string{"resource_id"})
+		for _, id := range rated {
+			rows.AddRow(id)
+		}
+		mock.ExpectBegin()
+		mock.ExpectQuery("SELECT resource_id FROM user_resource_progress").WithArgs(userID).WillReturnRows(rows)
+		mock.ExpectExec("helpful_count = GREATEST").WithArgs(userID).WillReturnResult(sqlmock.NewResult(0, removed[0]))
+		for i, table := range []string{"review_helpful_votes", "resource_reviews", "user_path_progress", "user_resource_progress"} {
+			mock.ExpectExec("DELETE FROM " + table).WithArgs(userID).WillReturnResult(sqlmock.NewResult(0, removed[i]))
+		}
+		for _, id := range rated {
+			// The rating falls back to the provider rating.
+			expectRecompute(mock, id, 0, 0, 4.5, 1200)
+		}
+		mock.ExpectCommit()
+	}
+
+	expectErase([]uuid.UUID{resourceID}, [4]int64{2, 1, 1, 3})
+	got, err := repo.EraseUser(context.Background(), userID)
+	if err != nil {
+		t.Fatalf("EraseUser: %v", err)
+	}
+	if *got != (UserErasure{ProgressRemoved: 3, PathProgressRemoved: 1, ReviewsRemoved: 1, HelpfulVotesRemoved: 2}) {
+		t.Errorf("unexpected erasure %+v", *got)
+	}
+
+	// Erasing again finds nothing to remove or recompute.
+	expectErase(nil, [4]int64{})
+	if got, err := repo.EraseUser(context.Background(), userID); err != nil || *got != (UserErasure{}) {
+		t.Errorf("EraseUser again = %+v, %v, want nothing removed", got, err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
@@ -3,17 +3,21 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"strings"
 	"time"
 
 	"github.com/google/uuid"
-	_ "github.com/lib/pq"
+	"github.com/lib/pq"
+
+	"github.com/learnbot/database/encryption"
 )
 
 // UserRepository provides CRUD operations for users and profiles.
 type UserRepository struct {
-	db *sql.DB
+	db   *sql.DB
+	keys *encryption.Keyring
 }
 
 // NewUserRepository creates a new UserRepository.
@@ -21,6 +25,33 @@ func NewUserRepository(db *sql.DB) *UserRepository {
 	return &UserRepository{db: db}
 }
 
+// WithEncryption enables at-rest encryption of profile PII (phone, city
+// and state) using keys. Values are decrypted transparently on read; rows written before
+// encryption was enabled are still returned as stored.
+func (r *UserRepository) WithEncryption(keys *encryption.Keyring) *UserRepository {
+	r.keys = keys
+	return r
+}
+
+// profileAAD binds an encrypted user_profiles column to its owner's row,
+// so that a value cannot be moved to another column or profile.
+func profileAAD(column string, userID uuid.UUID) string {
+	return "user_profiles." + column + ":" + userID.String()
+}
+
+// sealProfileField encrypts value for column of userID's profile, unless
+// encryption is disabled or value is empty.
+func (r *UserRepository) sealProfileField(column, value string, userID uuid.UUID) (string, error) {
+	if r.keys == nil || value == "" {
+		return value, nil
+	}
+	sealed, err := r.keys.SealString(value, profileAAD(column, userID))
+	if err != nil {
+		return "", fmt.Errorf("encrypt profile %s: %w", column, err)
+	}
+	return sealed, nil
+}
+
 // ─────────────────────────────────────────────────────────────────────────────
 // User CRUD
 // ─────────────────────────────────────────────────────────────────────────────
@@ -53,15 +84,19 @@ func (r *UserRepository) CreateUser(ctx context.Context, input CreateUserInput)
 	err = tx.QueryRowContext(ctx, `
 		INSERT INTO users (email, full_name, password_hash, avatar_url, timezone, locale)
 		VALUES ($1, $2, $3, $4, $5, $6)
-		RETURNING id, email, email_verified, full_name, avatar_url, timezone, locale,
-		          is_active, is_admin, last_login_at, created_at, updated_at`,
+		RETURNING id, email, email_verified, password_hash, full_name, avatar_url, timezone, locale,
+		          is_active, is_admin, roles, last_login_at, created_at, updated_at`,
 		email, input.FullName, input.PasswordHash, input.AvatarURL, timezone, locale,
 	).Scan(
-		&user.ID, &user.Email, &user.EmailVerified, &user.FullName,
+		&user.ID, &user.Email, &user.EmailVerified, &user.PasswordHash, &user.FullName,
 		&user.AvatarURL, &user.Timezone, &user.Locale,
-		&user.IsActive, &user.IsAdmin, &user.LastLoginAt,
+		&user.IsActive, &user.IsAdmin, &user.Roles, &user.LastLoginAt,
 		&user.CreatedAt, &user.UpdatedAt,
 	)
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code == "23505" && pqErr.Constraint == "users_email_unique" {
+		return nil, fmt.Errorf("insert user: %w: %q", ErrDuplicate, email)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("insert user: %w", err)
 	}
@@ -99,13 +134,13 @@ func (r *UserRepository) CreateUser(ctx context.Context, input CreateUserInput)
 func (r *UserRepository) GetUserByID(ctx context.Context, id uuid.UUID) (*User, error) {
 	user := &User{}
 	err := r.db.QueryRowContext(ctx, `
-		SELECT id, email, email_verified, full_name, avatar_url, timezone, locale,
-		       is_active, is_admin, last_login_at, created_at, updated_at
+		SELECT id, email, email_verified, password_hash, full_name, avatar_url,
+		       timezone, locale, is_active, is_admin, roles, last_login_at, created_at, updated_at
 		FROM users WHERE id = $1 AND is_active = TRUE`, id,
 	).Scan(
-		&user.ID, &user.Email, &user.EmailVerified, &user.FullName,
-		&user.AvatarURL, &user.Timezone, &user.Locale,
-		&user.IsActive, &user.IsAdmin, &user.LastLoginAt,
+		&user.ID, &user.Email, &user.EmailVerified, &user.PasswordHash,
+		&user.FullName, &user.AvatarURL, &user.Timezone, &user.Locale,
+		&user.IsActive, &user.IsAdmin, &user.Roles, &user.LastLoginAt,
 		&user.CreatedAt, &user.UpdatedAt,
 	)
 	if err == sql.ErrNoRows {
@@ -122,13 +157,13 @@ func (r *UserRepository) GetUserByEmail(ctx context.Context, email string) (*Use
 	user := &User{}
 	err := r.db.QueryRowContext(ctx, `
 		SELECT id, email, email_verified, password_hash, full_name, avatar_url,
-		       timezone, locale, is_active, is_admin, last_login_at, created_at, updated_at
+		       timezone, locale, is_active, is_admin, roles, last_login_at, created_at, updated_at
 		FROM users WHERE email = $1 AND is_active = TRUE`,
 		strings.ToLower(strings.TrimSpace(email)),
 	).Scan(
 		&user.ID, &user.Email, &user.EmailVerified, &user.PasswordHash,
 		&user.FullName, &user.AvatarURL, &user.Timezone, &user.Locale,
-		&user.IsActive, &user.IsAdmin, &user.LastLoginAt,
+		&user.IsActive, &user.IsAdmin, &user.Roles, &user.LastLoginAt,
 		&user.CreatedAt, &user.UpdatedAt,
 	)
 	if err == sql.ErrNoRows {
@@ -140,6 +175,70 @@ func (r *UserRepository) GetUserByEmail(ctx context.Context, email string) (*Use
 	return user, nil
 }
 
+// UpdateUser changes the account fields set in input and returns the
+// updated user, or ErrNotFound if there is no active user with the ID.
+func (r *UserRepository) UpdateUser(ctx context.Context, userID uuid.UUID, input UpdateUserInput) (*User, error) {
+	setClauses := []string{}
+	args := []interface{}{}
+	argIdx := 1
+
+	if input.FullName != nil {
+		setClauses = append(setClauses, fmt.Sprintf("full_name = $%d", argIdx))
+		args = append(args, *input.FullName)
+		argIdx++
+	}
+	if input.EmailVerified != nil {
+		setClauses = append(setClauses, fmt.Sprintf("email_verified = $%d", argIdx))
+		args = append(args, *input.EmailVerified)
+		argIdx++
+	}
+	if input.Roles != nil {
+		setClauses = append(setClauses, fmt.Sprintf("roles = $%d, is_admin = 'admin' = ANY($%d)", argIdx, argIdx))
+		args = append(args, pq.Array(input.Roles))
+		argIdx++
+	}
+	if len(setClauses) == 0 {
+		return r.GetUserByID(ctx, userID)
+	}
+
+	args = append(args, userID)
+	user := &User{}
+	err := r.db.QueryRowContext(ctx, fmt.Sprintf(`
+		UPDATE users SET %s
+		WHERE id = $%d AND is_active = TRUE
+		RETURNING id, email, email_verified, password_hash, full_name, avatar_url,
+		          timezone, locale, is_active, is_admin, roles, last_login_at, created_at, updated_at`,
+		strings.Join(setClauses, ", "), argIdx), args...,
+	).Scan(
+		&user.ID, &user.Email, &user.EmailVerified, &user.PasswordHash,
+		&user.FullName, &user.AvatarURL, &user.Timezone, &user.Locale,
+		&user.IsActive, &user.IsAdmin, &user.Roles, &user.LastLoginAt,
+		&user.CreatedAt, &user.UpdatedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("update user: %w", err)
+	}
+	return user, nil
+}
+
+// UpdatePassword replaces the password hash of an active user.
+func (r *UserRepository) UpdatePassword(ctx context.Context, userID uuid.UUID, passwordHash string) error {
+	result, err := r.db.ExecContext(ctx,
+		`UPDATE users SET password_hash = $1 WHERE id = $2 AND is_active = TRUE`,
+		passwordHash, userID)
+	if err != nil {
+		return fmt.Errorf("update password: %w", err)
+	}
+	rows, _ := result.RowsAffected()
+	if rows == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
 // UpdateLastLogin updates the user's last login timestamp.
 func (r *UserRepository) UpdateLastLogin(ctx context.Context, userID uuid.UUID) error {
 	_, err := r.db.ExecContext(ctx,
@@ -163,6 +262,25 @@ func (r *UserRepository) DeactivateUser(ctx context.Context, userID uuid.UUID) e
 	return nil
 }
 
+// DeleteUser permanently deletes a user. The rows that reference the user,
+// such as their profile, skills and learning progress, are deleted with it
+// by the schema's ON DELETE CASCADE. It returns ErrNotFound for an unknown
+// user.
+func (r *UserRepository) DeleteUser(ctx context.Context, userID uuid.UUID) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM users WHERE id = $1`, userID)
+	if err != nil {
+		return fmt.Errorf("delete user: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("delete user: %w", err)
+	}
+	if rows == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
 // ─────────────────────────────────────────────────────────────────────────────
 // Profile CRUD
 // ─────────────────────────────────────────────────────────────────────────────
@@ -189,6 +307,21 @@ func (r *UserRepository) GetProfile(ctx context.Context, userID uuid.UUID) (*Use
 	if err != nil {
 		return nil, fmt.Errorf("get profile: %w", err)
 	}
+	for _, f := range []struct {
+		column string
+		value  *sql.NullString
+	}{
+		{"location_city", &p.LocationCity}, {"location_state", &p.LocationState}, {"phone", &p.Phone},
+	} {
+		if !f.value.Valid {
+			continue
+		}
+		plain, err := r.keys.OpenString(f.value.String, profileAAD(f.column, userID))
+		if err != nil {
+			return nil, fmt.Errorf("decrypt profile %s: %w", f.column, err)
+		}
+		f.value.String = plain
+	}
 	return p, nil
 }
 
@@ -222,13 +355,21 @@ func (r *UserRepository) UpdateProfile(ctx context.Context, userID uuid.UUID, in
 		argIdx++
 	}
 	if input.LocationCity != nil {
+		city, err := r.sealProfileField("location_city", *input.LocationCity, userID)
+		if err != nil {
+			return err
+		}
 		setClauses = append(setClauses, fmt.Sprintf("location_city = $%d", argIdx))
-		args = append(args, *input.LocationCity)
+		args = append(args, city)
 		argIdx++
 	}
 	if input.LocationState != nil {
+		state, err := r.sealProfileField("location_state", *input.LocationState, userID)
+		if err != nil {
+			return err
+		}
 		setClauses = append(setClauses, fmt.Sprintf("location_state = $%d", argIdx))
-		args = append(args, *input.LocationState)
+		args = append(args, state)
 		argIdx++
 	}
 	if input.LocationCountry != nil {
@@ -237,8 +378,12 @@ func (r *UserRepository) UpdateProfile(ctx context.Context, userID uuid.UUID, in
 		argIdx++
 	}
 	if input.Phone != nil {
+		phone, err := r.sealProfileField("phone", *input.Phone, userID)
+		if err != nil {
+			return err
+		}
 		setClauses = append(setClauses, fmt.Sprintf("phone = $%d", argIdx))
-		args = append(args, *input.Phone)
+		args = append(args, phone)
 		argIdx++
 	}
 	if input.LinkedInURL != nil {
@@ -0,0 +1,178 @@
+package repository
+
+import (
+	"bytes"
+	"context"
+	"database/sql/driver"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+
+	"github.com/learnbot/database/encryption"
+)
+
+var userColumns = []string{
+	"id", "email", "email_verified", "password_hash", "full_name", "avatar_url",
+	"timezone", "locale", "is_active", "is_admin", "roles", "last_login_at", "created_at", "updated_at",
+}
+
+func TestCreateUser_DuplicateEmail(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock.New: %v", err)
+	}
+	defer db.Close()
+	repo := NewUserRepository(db)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("INSERT INTO users").
+		WillReturnError(&pq.Error{Code: "23505", Constraint: "users_email_unique"})
+	mock.ExpectRollback()
+
+	_, err = repo.CreateUser(context.Background(), CreateUserInput{Email: " Jane@Example.com", FullName: "Jane"})
+	if !errors.Is(err, ErrDuplicate) {
+		t.Errorf("CreateUser error = %v, want ErrDuplicate", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestUpdateUser(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock.New: %v", err)
+	}
+	defer db.Close()
+	repo := NewUserRepository(db)
+
+	id := uuid.New()
+	now := time.Now()
+	verified := true
+	mock.ExpectQuery(`UPDATE users SET email_verified = \$1, roles = \$2, is_admin = 'admin' = ANY\(\$2\)\s+WHERE id = \$3`).
+		WithArgs(true, pq.Array([]string{"user", "admin"}), id).
+		WillReturnRows(sqlmock.NewRows(userColumns).AddRow(
+			id, "jane@example.com", true, "hash", "Jane", nil,
+			"UTC", "en", true, true, "{user,admin}", nil, now, now,
+		))
+	mock.ExpectQuery(`UPDATE users SET full_name = \$1\s+WHERE id = \$2`).
+		WillReturnRows(sqlmock.NewRows(userColumns))
+
+	user, err := repo.UpdateUser(context.Background(), id, UpdateUserInput{
+		EmailVerified: &verified,
+		Roles:         []string{"user", "admin"},
+	})
+	if err != nil {
+		t.Fatalf("UpdateUser: %v", err)
+	}
+	if !user.EmailVerified || !user.IsAdmin || len(user.Roles) != 2 || user.Roles[1] != "admin" {
+		t.Errorf("updated user = %+v", user)
+	}
+
+	name := "Jane Doe"
+	if _, err := repo.UpdateUser(context.Background(), id, UpdateUserInput{FullName: &name}); !errors.Is(err, ErrNotFound) {
+		t.Errorf("UpdateUser of a missing user: error = %v, want ErrNotFound", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestDeleteUser(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock.New: %v", err)
+	}
+	defer db.Close()
+	repo := NewUserRepository(db)
+
+	userID := uuid.New()
+	mock.ExpectExec("DELETE FROM users").WithArgs(userID).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("DELETE FROM users").WithArgs(userID).WillReturnResult(sqlmock.NewResult(0, 0))
+	if err := repo.DeleteUser(context.Background(), userID); err != nil {
+		t.Fatalf("DeleteUser: %v", err)
+	}
+	if err := repo.DeleteUser(context.Background(), userID); !errors.Is(err, ErrNotFound) {
+		t.Errorf("DeleteUser again error = %v, want ErrNotFound", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+// capturedArg matches any string argument and records it.
+type capturedArg struct{ value string }
+
+func (a *capturedArg) Match(v driver.Value) bool {
+	s, ok := v.(string)
+	a.value = s
+	return ok
+}
+
+func TestProfile_EncryptsLocationAndPhone(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock.New: %v", err)
+	}
+	defer db.Close()
+	keys, err := encryption.NewKeyring("v1", map[string][]byte{"v1": bytes.Repeat([]byte{7}, encryption.MasterKeySize)})
+	if err != nil {
+		t.Fatalf("NewKeyring: %v", err)
+	}
+	repo := NewUserRepository(db).WithEncryption(keys)
+	ctx := context.Background()
+	userID := uuid.New()
+
+	city, state, phone := &capturedArg{}, &capturedArg{}, &capturedArg{}
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT row_to_json").WillReturnRows(sqlmock.NewRows([]string{"row_to_json"}).AddRow([]byte("{}")))
+	mock.ExpectExec(`UPDATE user_profiles SET location_city = \$1, location_state = \$2, phone = \$3 WHERE user_id = \$4`).
+		WithArgs(city, state, phone, userID).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("calculate_profile_completeness").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery("SELECT row_to_json").WillReturnRows(sqlmock.NewRows([]string{"row_to_json"}).AddRow([]byte("{}")))
+	mock.ExpectExec("INSERT INTO profile_history").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	cityIn, stateIn, phoneIn := "Bandung", "West Java", "+62 812 0000 0000"
+	if err := repo.UpdateProfile(ctx, userID, UpdateProfileInput{
+		LocationCity: &cityIn, LocationState: &stateIn, Phone: &phoneIn,
+	}, nil); err != nil {
+		t.Fatalf("UpdateProfile: %v", err)
+	}
+	for _, arg := range []*capturedArg{city, state, phone} {
+		if !strings.HasPrefix(arg.value, "enc:") {
+			t.Errorf("stored %q, want an envelope", arg.value)
+		}
+	}
+
+	now := time.Now()
+	profileRow := func(city, state, phone string) *sqlmock.Rows {
+		return sqlmock.NewRows([]string{
+			"id", "user_id", "headline", "summary", "location_city", "location_state",
+			"location_country", "phone", "linkedin_url", "github_url", "website_url",
+			"years_of_experience", "is_open_to_work", "profile_completeness", "created_at", "updated_at",
+		}).AddRow(uuid.New(), userID, nil, nil, city, state, "ID", phone, nil, nil, nil, 3, true, 50, now, now)
+	}
+	mock.ExpectQuery("FROM user_profiles WHERE user_id").WillReturnRows(profileRow(city.value, state.value, phone.value))
+	p, err := repo.GetProfile(ctx, userID)
+	if err != nil {
+		t.Fatalf("GetProfile: %v", err)
+	}
+	if p.LocationCity.String != cityIn || p.LocationState.String != stateIn || p.Phone.String != phoneIn {
+		t.Errorf("decrypted profile = %q, %q, %q", p.LocationCity.String, p.LocationState.String, p.Phone.String)
+	}
+
+	// A city sealed for another column must not open as this one.
+	mock.ExpectQuery("FROM user_profiles WHERE user_id").WillReturnRows(profileRow(state.value, city.value, phone.value))
+	if _, err := repo.GetProfile(ctx, userID); err == nil {
+		t.Error("GetProfile with swapped city and state: expected a decryption error")
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
@@ -171,7 +171,7 @@ function LearningPageInner() {
                 <div className="flex flex-wrap gap-3 mt-2 text-sm">
                   <span>📅 {plan.timeline.total_weeks} weeks</span>
                   <span>⏱ {plan.timeline.total_hours}h total</span>
-                  <span>💰 ${plan.summary.estimated_total_cost_usd.toFixed(0)} est. cost</span>
+                  <span>💰 {plan.summary.estimated_total_cost.display} est. cost</span>
                 </div>
               </div>
               <div className="text-right"><div className="text-3xl font-bold">{Math.round(plan.readiness_score)}%</div><div className="text-primary-200 text-xs">Current readiness</div></div>
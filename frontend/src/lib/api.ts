@@ -3,7 +3,7 @@ const BASE_URL = process.env.NEXT_PUBLIC_API_URL || "http://localhost:8090";
 export interface APIResponse<T = unknown> {
   success: boolean;
   data?: T;
-  error?: { code: string; message: string; details?: Array<{ field: string; message: string }> };
+  error?: { code: string; message: string; details?: Array<{ field: string; code?: string; message: string }> };
   meta?: { total: number; limit: number; offset: number };
 }
 
@@ -78,6 +78,7 @@ export interface SkillGap {
   importance_score: number;
   estimated_learning_hours: number;
   transferability_score: number;
+  demand_score: number;
   current_level?: string;
   target_level: string;
   semantic_similarity_score: number;
@@ -98,6 +99,7 @@ export interface GapAnalysisResult {
   readiness_score: number;
   top_priority_gaps: SkillGap[];
   matched_skills: string[];
+  weak_matches: Array<{ skill_name: string; category: string; current_level: string; target_level: string; levels_below: number; readiness_deduction: number }>;
   visual_data: {
     radar_chart: { labels: string[]; candidate_scores: number[]; required_scores: number[] };
     gaps_by_category: Array<{ category: string; gap_count: number; total_learning_hours: number; average_priority: number }>;
@@ -105,9 +107,11 @@ export interface GapAnalysisResult {
   };
 }
 
+export interface Price { amount: number; currency: string; display: string; }
+
 export interface Resource {
   id: string; title: string; description: string; url: string; provider: string;
-  resource_type: string; difficulty: string; cost_type: string; cost_usd?: number;
+  resource_type: string; difficulty: string; cost_type: string; cost_amount?: number; cost_currency?: string; cost_unknown?: boolean; display_cost?: Price;
   duration_hours?: number; duration_label?: string; skills: string[]; primary_skill: string;
   rating?: number; rating_count?: number; has_certificate: boolean; has_hands_on: boolean; is_verified: boolean;
 }
@@ -139,11 +143,11 @@ export interface LearningPlan {
   phases: LearningPhase[];
   timeline: { total_weeks: number; total_hours: number; weekly_hours: number; weeks: WeeklySchedule[]; target_completion_date?: string };
   matched_skills: string[];
-  summary: { headline: string; critical_gap_count: number; important_gap_count: number; free_resource_count: number; paid_resource_count: number; estimated_total_cost_usd: number; top_skills_to_learn: string[]; quick_wins: string[] };
+  summary: { headline: string; critical_gap_count: number; important_gap_count: number; free_resource_count: number; paid_resource_count: number; estimated_total_cost: Price; top_skills_to_learn: string[]; quick_wins: string[] };
 }
 
 export class APIError extends Error {
-  constructor(public code: string, message: string, public details?: Array<{ field: string; message: string }>) {
+  constructor(public code: string, message: string, public details?: Array<{ field: string; code?: string; message: string }>) {
     super(message); this.name = "APIError";
   }
 }
@@ -200,7 +204,7 @@ export interface JobRequirementsInput { title: string; required_skills: string[]
 export const analysisAPI = {
   analyzeGaps: (token: string, jobId?: string, job?: JobRequirementsInput) =>
     request<GapAnalysisResult>("/api/analysis/gaps", { method: "POST", body: JSON.stringify({ job_id: jobId, job }) }, token),
-  getTrainingPlan: (token: string, jobId?: string, job?: JobRequirementsInput, preferences?: { prefer_free?: boolean; max_budget_usd?: number; weekly_hours_available?: number; prefer_hands_on?: boolean; prefer_certificates?: boolean; target_date?: string }) =>
+  getTrainingPlan: (token: string, jobId?: string, job?: JobRequirementsInput, preferences?: { prefer_free?: boolean; max_budget?: number; currency?: string; weekly_hours_available?: number; prefer_hands_on?: boolean; prefer_certificates?: boolean; target_date?: string }) =>
     request<LearningPlan>("/api/training/recommendations", { method: "POST", body: JSON.stringify({ job_id: jobId, job, preferences }) }, token),
 };
 
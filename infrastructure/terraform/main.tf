@@ -218,7 +218,7 @@ resource "aws_lb_target_group" "api_gateway" {
     unhealthy_threshold = 3
     timeout             = 5
     interval            = 30
-    path                = "/health"
+    path                = "/readyz"
     matcher             = "200"
   }
 
@@ -466,7 +466,7 @@ resource "aws_ecs_task_definition" "api_gateway" {
       }
 
       healthCheck = {
-        command     = ["CMD-SHELL", "wget -qO- http://localhost:8090/health || exit 1"]
+        command     = ["CMD-SHELL", "wget -qO- http://localhost:8090/livez || exit 1"]
         interval    = 30
         timeout     = 5
         retries     = 3
@@ -514,7 +514,7 @@ resource "aws_ecs_task_definition" "resume_parser" {
       }
 
       healthCheck = {
-        command     = ["CMD-SHELL", "wget -qO- http://localhost:8080/health || exit 1"]
+        command     = ["CMD-SHELL", "wget -qO- http://localhost:8080/livez || exit 1"]
         interval    = 30
         timeout     = 5
         retries     = 3
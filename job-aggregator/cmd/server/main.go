@@ -5,42 +5,153 @@ import (
 	"context"
 	"database/sql"
 	"flag"
+	"fmt"
 	"log"
 	"net/http"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
 	"time"
+	_ "time/tzdata" // per-scraper time zones on hosts without a zoneinfo database
 
 	_ "github.com/lib/pq"
 
 	"github.com/learnbot/job-aggregator/internal/admin"
+	"github.com/learnbot/job-aggregator/internal/analytics"
+	"github.com/learnbot/job-aggregator/internal/api"
+	"github.com/learnbot/job-aggregator/internal/cursor"
+	"github.com/learnbot/job-aggregator/internal/expiry"
 	"github.com/learnbot/job-aggregator/internal/scheduler"
 	"github.com/learnbot/job-aggregator/internal/scraper"
 	"github.com/learnbot/job-aggregator/internal/storage"
+	"github.com/learnbot/job-aggregator/internal/webhook"
+	"github.com/learnbot/job-aggregator/migrations"
+	"github.com/learnbot/shared/adminauth"
+	"github.com/learnbot/shared/audit"
+	"github.com/learnbot/shared/healthcheck"
+	"github.com/learnbot/shared/logging"
+	"github.com/learnbot/shared/metrics"
+	"github.com/learnbot/shared/migrate"
+	"github.com/learnbot/shared/openapi"
+	"github.com/learnbot/shared/redact"
 )
 
 func main() {
 	addr := flag.String("addr", ":8081", "HTTP server address")
-	dbURL := flag.String("db", getEnv("DATABASE_URL", "postgres://localhost/learnbot?sslmode=disable"), "PostgreSQL connection URL")
+	dbURL := flag.String("db", getEnv("DATABASE_URL", "postgres://localhost/learnbot?sslmode=disable"), "PostgreSQL connection URL, or sqlite://path for a local SQLite database")
+	migrateUp := flag.Bool("migrate", false, "Apply pending PostgreSQL schema migrations on startup")
+	migrateBaseline := flag.Int("migrate-baseline", 0, "Record migrations up to this version as applied without running them, for a schema created by hand")
 	runNow := flag.Bool("run-now", false, "Run scrapers immediately on startup")
+	expiryCheck := flag.Bool("expiry-check", true, "Periodically re-check active jobs for closed postings")
+	webhooks := flag.Bool("webhooks", true, "Deliver new jobs to webhook subscriptions")
+	skillStats := flag.Bool("skill-stats", true, "Aggregate skill demand stats from new jobs after each scrape run")
+	defaultSchedule := flag.String("default-schedule", scheduler.DefaultSchedule, "Cron expression, interval or run window for scrapers without their own schedule")
+	scraperShutdownTimeout := flag.Duration("scraper-shutdown-timeout", 30*time.Second, "How long shutdown waits for in-flight scrapers to store their current page")
+	dedupThreshold := flag.Float64("dedup-threshold", storage.DefaultFuzzyDedupThreshold, "Title similarity (0-1) at which postings from the same company are merged; 0 disables fuzzy dedup")
+	redactDescriptions := flag.Bool("redact-descriptions", false, "Mask emails, phone numbers and national ID numbers in job descriptions before storing them")
+	staleAfter := flag.Duration("stale-after", scheduler.DefaultConfig().JobStaleDuration, "How long a job may go unseen before it is expired, for scrapers without their own duration; 0 disables expiry")
+	maxScrapeAge := flag.Duration("max-scrape-age", scheduler.DefaultMaxScrapeAge, "How long ago the last successful scrape may have been before /readyz reports the jobs as stale")
+	expirySchedule := flag.String("expiry-schedule", scheduler.DefaultExpirySchedule, "Cron expression or interval of the stale job expiry pass")
+	schedules := map[string]string{}
+	flag.Func("schedule", `Per-scraper schedule as "scraper name=cron expression, interval or run window" (repeatable)`, func(v string) error {
+		name, spec, ok := strings.Cut(v, "=")
+		if !ok || strings.TrimSpace(name) == "" {
+			return fmt.Errorf("expected \"scraper name=schedule\"")
+		}
+		if _, err := scheduler.ParseSchedule(spec); err != nil {
+			return err
+		}
+		schedules[strings.TrimSpace(name)] = strings.TrimSpace(spec)
+		return nil
+	})
+	timeZones := map[string]string{}
+	flag.Func("scraper-timezone", `Per-scraper time zone as "scraper name=IANA zone", e.g. "LinkedIn Jobs=America/New_York" (repeatable)`, func(v string) error {
+		name, zone, ok := strings.Cut(v, "=")
+		if !ok || strings.TrimSpace(name) == "" {
+			return fmt.Errorf("expected \"scraper name=time zone\"")
+		}
+		if _, err := time.LoadLocation(strings.TrimSpace(zone)); err != nil {
+			return err
+		}
+		timeZones[strings.TrimSpace(name)] = strings.TrimSpace(zone)
+		return nil
+	})
+	staleDurations := map[string]time.Duration{}
+	flag.Func("scraper-stale-after", `Per-scraper stale duration as "scraper name=duration" (repeatable)`, func(v string) error {
+		name, d, ok := strings.Cut(v, "=")
+		if !ok || strings.TrimSpace(name) == "" {
+			return fmt.Errorf("expected \"scraper name=duration\"")
+		}
+		dur, err := time.ParseDuration(strings.TrimSpace(d))
+		if err != nil {
+			return err
+		}
+		staleDurations[strings.TrimSpace(name)] = dur
+		return nil
+	})
 	flag.Parse()
 
-	logger := log.New(os.Stdout, "[job-aggregator] ", log.LstdFlags|log.Lshortfile)
-
-	// Connect to database
-	db, err := sql.Open("postgres", *dbURL)
-	if err != nil {
-		logger.Fatalf("failed to open database: %v", err)
+	if _, err := scheduler.ParseSchedule(*defaultSchedule); err != nil {
+		log.Fatalf("invalid -default-schedule: %v", err)
+	}
+	if _, err := scheduler.ParseSchedule(*expirySchedule); err != nil {
+		log.Fatalf("invalid -expiry-schedule: %v", err)
 	}
-	defer db.Close()
+	if *dedupThreshold < 0 || *dedupThreshold > 1 {
+		log.Fatalf("invalid -dedup-threshold %v: must be between 0 and 1", *dedupThreshold)
+	}
+
+	slogger := logging.New("job-aggregator", os.Stdout, logging.ConfigFromEnv())
+	logger := logging.StdLogger(slogger)
+
+	// Connect to database; failed queries are counted in /metrics. The
+	// scheme of -db picks the storage backend.
+	var db *sql.DB
+	var repo storage.JobRepository
+	var pgRepo *storage.PostgresRepository
+	var auditLog audit.Store
+	if path, ok := strings.CutPrefix(*dbURL, "sqlite://"); ok {
+		var err error
+		db, err = metrics.OpenDB(storage.SQLiteDriver, storage.SQLiteDSN(path))
+		if err != nil {
+			logger.Fatalf("failed to open database: %v", err)
+		}
+		defer db.Close()
+
+		sqliteRepo := storage.NewSQLiteRepository(db)
+		if err := sqliteRepo.Migrate(context.Background(), logger); err != nil {
+			logger.Fatalf("failed to migrate SQLite database: %v", err)
+		}
+		repo = sqliteRepo
+		auditLog = audit.NewSQLStore(db)
+		if *expiryCheck || *webhooks || *skillStats {
+			logger.Println("warning: expiry checks, webhooks and skill demand stats need PostgreSQL; disabled with SQLite storage")
+			*expiryCheck, *webhooks, *skillStats = false, false, false
+		}
+	} else {
+		var err error
+		db, err = metrics.OpenDB("postgres", *dbURL)
+		if err != nil {
+			logger.Fatalf("failed to open database: %v", err)
+		}
+		defer db.Close()
 
-	db.SetMaxOpenConns(25)
-	db.SetMaxIdleConns(5)
-	db.SetConnMaxLifetime(5 * time.Minute)
+		db.SetMaxOpenConns(25)
+		db.SetMaxIdleConns(5)
+		db.SetConnMaxLifetime(5 * time.Minute)
 
-	if err := db.Ping(); err != nil {
-		logger.Printf("warning: database not available: %v (continuing without DB)", err)
+		if err := db.Ping(); err != nil {
+			if *migrateUp || *migrateBaseline > 0 {
+				logger.Fatalf("cannot migrate: database not available: %v", err)
+			}
+			logger.Printf("warning: database not available: %v (continuing without DB)", err)
+		} else if err := migrateSchema(db, *migrateUp, *migrateBaseline, logger); err != nil {
+			logger.Fatalf("failed to migrate database: %v", err)
+		}
+		pgRepo = storage.NewPostgresRepository(db)
+		repo = pgRepo
+		auditLog = audit.NewSQLStore(db)
 	}
 
 	// Initialize scrapers
@@ -57,13 +168,12 @@ func main() {
 	scrapers := []scraper.Scraper{linkedInScraper, indeedScraper}
 
 	// Load career page scrapers from database
-	repo := storage.NewJobRepository(db)
 	careerPages, err := repo.GetCareerPages(context.Background())
 	if err != nil {
 		logger.Printf("warning: failed to load career pages: %v", err)
 	}
 	for _, page := range careerPages {
-		cpScraper, err := scraper.NewCareerPageScraper(page, logger)
+		cpScraper, err := scraper.NewScraperForCareerPage(page, logger)
 		if err != nil {
 			logger.Printf("warning: failed to create career page scraper for %s: %v", page.CompanyName, err)
 			continue
@@ -75,16 +185,80 @@ func main() {
 
 	// Initialize scheduler
 	schedConfig := scheduler.DefaultConfig()
-	sched := scheduler.New(db, scrapers, schedConfig, logger)
+	schedConfig.DefaultSchedule = *defaultSchedule
+	schedConfig.Schedules = schedules
+	schedConfig.TimeZones = timeZones
+	schedConfig.JobStaleDuration = *staleAfter
+	schedConfig.StaleDurations = staleDurations
+	schedConfig.ExpirySchedule = *expirySchedule
+	schedConfig.DedupThreshold = *dedupThreshold
+	if *redactDescriptions {
+		schedConfig.Redactor = redact.Default()
+	}
+	sched := scheduler.New(repo, scrapers, schedConfig, logger)
+	// A paused schedule or disabled scraper stays so across restarts.
+	if err := sched.LoadControls(context.Background()); err != nil {
+		logger.Fatalf("failed to load scheduler controls: %v", err)
+	}
+
+	// New jobs matching a webhook subscription are queued after each run.
+	var dispatcher *webhook.Dispatcher
+	if *webhooks {
+		dispatcher = webhook.New(pgRepo, webhook.DefaultConfig(), logger)
+		sched.SetNotifier(dispatcher)
+	}
+
+	// Skill demand stats are updated with the new jobs of each run.
+	if *skillStats {
+		sched.SetAnalyzer(analytics.New(pgRepo, logger))
+	}
 
 	// Set up HTTP server
 	mux := http.NewServeMux()
 	adminHandler := admin.NewHandler(repo, sched, logger)
+	// Admin routes accept ADMIN_API_KEY or a gateway JWT with the admin claim.
+	authCfg := adminauth.ConfigFromEnv()
+	if !authCfg.Enabled() {
+		logger.Println("warning: neither ADMIN_API_KEY nor JWT_SECRET is set; admin routes will reject all requests")
+	}
+	adminHandler.SetAuth(adminauth.New(authCfg, logger))
+	// Pagination cursors are signed with CURSOR_SECRET so that they survive
+	// restarts and work across replicas.
+	cursors := cursor.NewCodec([]byte(os.Getenv("CURSOR_SECRET")))
+	if os.Getenv("CURSOR_SECRET") == "" {
+		logger.Println("warning: CURSOR_SECRET is not set; pagination cursors will not survive a restart")
+	}
+	adminHandler.SetCursorCodec(cursors)
+	// Changes made through the admin routes are kept in audit_log.
+	adminHandler.SetAuditLog(auditLog, slogger)
 	adminHandler.RegisterRoutes(mux)
+	apiHandler := api.NewHandler(repo, logger)
+	apiHandler.SetCursorCodec(cursors)
+	apiHandler.RegisterRoutes(mux)
+	mux.Handle("/metrics", metrics.Handler())
+
+	// /livez reports the process is up; /readyz also pings the database and
+	// reports the jobs as degraded once no scraper has succeeded for
+	// -max-scrape-age.
+	health := healthcheck.New("job-aggregator", healthcheck.DefaultTimeout)
+	health.Register(
+		healthcheck.Check{Name: "database", Required: true, Probe: healthcheck.Ping(db)},
+		healthcheck.Check{Name: "scrape_freshness", Probe: sched.FreshnessProbe(*maxScrapeAge)},
+	)
+	health.RegisterRoutes(mux)
+
+	spec := openapi.New("job-aggregator", "1.0.0")
+	spec.SetDescription("Aggregated job search, skill demand analytics and scraper administration APIs.")
+	spec.SetErrorBody(openapi.Fields{"error": ""})
+	adminHandler.DescribeRoutes(spec)
+	apiHandler.DescribeRoutes(spec)
+	health.DescribeRoutes(spec)
+	spec.Route("/metrics", openapi.Metrics)
+	spec.Register(mux)
 
 	srv := &http.Server{
 		Addr:         *addr,
-		Handler:      mux,
+		Handler:      logging.Handler(slogger, metrics.Instrument(mux)),
 		ReadTimeout:  30 * time.Second,
 		WriteTimeout: 30 * time.Second,
 		IdleTimeout:  60 * time.Second,
@@ -97,8 +271,20 @@ func main() {
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
 
-	// Start daily schedule
-	sched.StartDailySchedule(ctx)
+	// Start the per-scraper schedule
+	sched.StartSchedule(ctx)
+	// Expire jobs their scrapers no longer list
+	sched.StartExpiry(ctx)
+
+	// Start expiry checker
+	if *expiryCheck {
+		expiry.New(pgRepo, expiry.DefaultConfig(), logger).Start(ctx)
+	}
+
+	// Start webhook delivery
+	if dispatcher != nil {
+		dispatcher.Start(ctx)
+	}
 
 	// Optionally run immediately
 	if *runNow {
@@ -117,6 +303,14 @@ func main() {
 	logger.Println("shutting down...")
 	cancel()
 
+	// Let in-flight scrapers store the page they are on; their runs are
+	// recorded as interrupted.
+	drainCtx, drainCancel := context.WithTimeout(context.Background(), *scraperShutdownTimeout)
+	if err := sched.Shutdown(drainCtx); err != nil {
+		logger.Printf("warning: scrapers still running after %v: %v", *scraperShutdownTimeout, err)
+	}
+	drainCancel()
+
 	shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), 15*time.Second)
 	defer shutdownCancel()
 
@@ -127,6 +321,42 @@ func main() {
 	logger.Println("server stopped")
 }
 
+// migrateSchema applies the pending PostgreSQL migrations if apply is set,
+// and otherwise warns about them. A positive baseline first records the
+// migrations up to that version as applied. It fails if the database was
+// migrated by a newer release.
+func migrateSchema(db *sql.DB, apply bool, baseline int, logger *log.Logger) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	m, err := migrate.New(db, migrations.FS, migrations.Table, logger)
+	if err != nil {
+		return err
+	}
+	if baseline > 0 {
+		if err := m.Baseline(ctx, baseline); err != nil {
+			return err
+		}
+	}
+	if !apply {
+		pending, err := m.Pending(ctx)
+		if err != nil {
+			return err
+		}
+		if len(pending) > 0 {
+			logger.Printf("warning: %d schema migrations pending, up to %s; run with -migrate to apply them",
+				len(pending), pending[len(pending)-1].Name)
+		}
+		return nil
+	}
+	applied, err := m.Up(ctx)
+	if err != nil {
+		return err
+	}
+	logger.Printf("database schema at version %d (%d migrations applied)", m.Latest(), applied)
+	return nil
+}
+
 func getEnv(key, defaultVal string) string {
 	if v := os.Getenv(key); v != "" {
 		return v
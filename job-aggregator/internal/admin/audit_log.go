@@ -0,0 +1,73 @@
+package admin
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/learnbot/shared/audit"
+	"github.com/learnbot/shared/pagination"
+)
+
+// maxAuditLogPageSize caps the page_size of GET /admin/audit-log.
+const maxAuditLogPageSize = 100
+
+// ListAuditLog returns the changes made through the admin API, newest
+// first. It answers 501 Not Implemented until SetAuditLog is called.
+// GET /admin/audit-log?actor=&entity_type=scraper&entity_id=&from=2025-01-01&to=2025-01-31&page=1&page_size=20
+func (h *Handler) ListAuditLog(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		h.writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	if h.audit == nil {
+		h.writeError(w, http.StatusNotImplemented, "the audit log is not configured")
+		return
+	}
+
+	filter, page, pageSize, err := parseAuditLogFilter(r.URL.Query())
+	if err != nil {
+		h.writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	entries, total, err := h.audit.Store().ListAuditEntries(r.Context(), filter)
+	if err != nil {
+		h.logger.Printf("[admin] ListAuditLog error: %v", err)
+		h.writeError(w, http.StatusInternalServerError, "failed to get the audit log")
+		return
+	}
+
+	p := pagination.FromPage(total, page, pageSize)
+	pagination.SetPageLinks(w.Header(), r.URL, p)
+	h.writeJSON(w, http.StatusOK, pagination.Envelope{Data: entries, Pagination: p})
+}
+
+// parseAuditLogFilter reads the GET /admin/audit-log query parameters and
+// returns the filter with the page and page size it selects.
+func parseAuditLogFilter(q url.Values) (audit.Filter, int, int, error) {
+	filter, err := audit.ParseFilter(q)
+	if err != nil {
+		return filter, 0, 0, err
+	}
+	filter.Service = auditService
+
+	page, pageSize := 1, 20
+	if p := q.Get("page"); p != "" {
+		n, err := strconv.Atoi(p)
+		if err != nil || n < 1 {
+			return filter, 0, 0, fmt.Errorf("invalid page %q", p)
+		}
+		page = n
+	}
+	if ps := q.Get("page_size"); ps != "" {
+		n, err := strconv.Atoi(ps)
+		if err != nil || n < 1 {
+			return filter, 0, 0, fmt.Errorf("invalid page_size %q", ps)
+		}
+		pageSize = min(n, maxAuditLogPageSize)
+	}
+	filter.Limit, filter.Offset = pageSize, (page-1)*pageSize
+	return filter, page, pageSize, nil
+}
@@ -0,0 +1,118 @@
+package admin
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/learnbot/job-aggregator/internal/scheduler"
+	"github.com/learnbot/job-aggregator/internal/scraper"
+	"github.com/learnbot/job-aggregator/internal/storage"
+	"github.com/learnbot/shared/adminauth"
+	"github.com/learnbot/shared/audit"
+	"github.com/learnbot/shared/logging"
+	"github.com/learnbot/shared/pagination"
+)
+
+func TestAuditLog(t *testing.T) {
+	db, err := sql.Open(storage.SQLiteDriver, storage.SQLiteDSN(":memory:"))
+	if err != nil {
+		t.Fatalf("open sqlite: %v", err)
+	}
+	defer db.Close()
+	repo := storage.NewSQLiteRepository(db)
+	logger := log.New(io.Discard, "", 0)
+	if err := repo.Migrate(context.Background(), logger); err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+	store := audit.NewSQLStore(db)
+	h := NewHandler(repo, scheduler.New(repo, []scraper.Scraper{stubScraper{"LinkedIn Jobs"}}, scheduler.DefaultConfig(), logger), logger)
+	h.SetAuth(adminauth.New(adminauth.Config{APIKey: "secret"}, logger))
+	h.SetAuditLog(store, nil)
+	mux := http.NewServeMux()
+	h.RegisterRoutes(mux)
+	srv := logging.RequestIDMiddleware(mux)
+
+	do := func(method, path, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Set(adminauth.APIKeyHeader, "secret")
+		req.Header.Set("X-Request-ID", "req-42")
+		w := httptest.NewRecorder()
+		srv.ServeHTTP(w, req)
+		return w
+	}
+	listed := func(query string) ([]audit.Entry, int) {
+		t.Helper()
+		w := do(http.MethodGet, "/admin/audit-log"+query, "")
+		if w.Code != http.StatusOK {
+			t.Fatalf("GET /admin/audit-log%s: expected 200, got %d: %s", query, w.Code, w.Body.String())
+		}
+		var resp struct {
+			Data       []audit.Entry         `json:"data"`
+			Pagination pagination.Pagination `json:"pagination"`
+		}
+		if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("decode %s: %v", w.Body.String(), err)
+		}
+		return resp.Data, resp.Pagination.Total
+	}
+
+	// Read-only endpoints, and mutations that fail, record nothing.
+	for _, path := range []string{"/admin/schedule", "/admin/scheduler/status", "/admin/scrape-runs", "/admin/stats", "/admin/audit-log"} {
+		do(http.MethodGet, path, "")
+	}
+	do(http.MethodPut, "/admin/scrapers/Glassdoor/enabled", `{"enabled":false}`)
+	do(http.MethodPut, "/admin/scrapers/LinkedIn%20Jobs/enabled", `{}`)
+	if entries, _ := listed(""); len(entries) != 0 {
+		t.Fatalf("expected no audit entries, got %+v", entries)
+	}
+
+	if w := do(http.MethodPut, "/admin/scrapers/LinkedIn%20Jobs/enabled", `{"enabled":false}`); w.Code != http.StatusOK {
+		t.Fatalf("disable scraper: expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if w := do(http.MethodPut, "/admin/schedule/LinkedIn%20Jobs", `{"schedule":"@daily"}`); w.Code != http.StatusOK {
+		t.Fatalf("update schedule: expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	entries, _ := listed("?entity_type=scraper&entity_id=LinkedIn%20Jobs&actor=api_key")
+	if len(entries) != 2 {
+		t.Fatalf("expected 2 audit entries, got %+v", entries)
+	}
+	schedule, enabled := entries[0], entries[1]
+	if enabled.Action != "scraper.set_enabled" || enabled.Service != auditService || enabled.Actor != "api_key" ||
+		enabled.RequestID != "req-42" || string(enabled.Changes) != `{"enabled":{"old":true,"new":false}}` {
+		t.Errorf("unexpected enabled entry %+v (changes %s)", enabled, enabled.Changes)
+	}
+	if schedule.Action != "schedule.update" ||
+		string(schedule.Changes) != `{"is_default":{"old":true,"new":false},"schedule":{"old":"`+scheduler.DefaultSchedule+`","new":"@daily"}}` {
+		t.Errorf("unexpected schedule entry %+v (changes %s)", schedule, schedule.Changes)
+	}
+
+	if entries, total := listed("?entity_type=webhook"); len(entries) != 0 || total != 0 {
+		t.Errorf("entity filter matched %+v", entries)
+	}
+	if entries, _ := listed("?to=2000-01-01"); len(entries) != 0 {
+		t.Errorf("date filter matched %+v", entries)
+	}
+	if entries, total := listed("?page_size=1&page=2"); len(entries) != 1 || entries[0].ID != enabled.ID || total != 2 {
+		t.Errorf("second page = %d %+v, want the older of 2 entries", total, entries)
+	}
+	if w := do(http.MethodGet, "/admin/audit-log?from=yesterday", ""); w.Code != http.StatusBadRequest {
+		t.Errorf("expected 400 for an invalid date, got %d", w.Code)
+	}
+}
+
+func TestListAuditLog_NotConfigured(t *testing.T) {
+	mux := newScheduleTestMux()
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/admin/audit-log", nil))
+	if w.Code != http.StatusNotImplemented {
+		t.Errorf("expected 501 without an audit log, got %d", w.Code)
+	}
+}
@@ -0,0 +1,61 @@
+package admin
+
+import (
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/learnbot/job-aggregator/internal/scheduler"
+	"github.com/learnbot/job-aggregator/internal/scraper"
+	"github.com/learnbot/job-aggregator/internal/storage"
+	"github.com/learnbot/shared/adminauth"
+	"github.com/learnbot/shared/openapi"
+)
+
+func TestAdminRoutesRequireAPIKey(t *testing.T) {
+	logger := log.New(io.Discard, "", 0)
+	sched := scheduler.New(storage.NewPostgresRepository(nil), []scraper.Scraper{stubScraper{"LinkedIn Jobs"}}, scheduler.DefaultConfig(), logger)
+	h := NewHandler(nil, sched, logger)
+	h.SetAuth(adminauth.New(adminauth.Config{APIKey: "secret"}, logger))
+	mux := http.NewServeMux()
+	h.RegisterRoutes(mux)
+
+	tests := []struct {
+		name string
+		key  string
+		want int
+	}{
+		{"missing key", "", http.StatusUnauthorized},
+		{"wrong key", "guess", http.StatusUnauthorized},
+		{"valid key", "secret", http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPut, "/admin/schedule/LinkedIn%20Jobs", strings.NewReader(`{"schedule":"@daily"}`))
+			if tt.key != "" {
+				req.Header.Set(adminauth.APIKeyHeader, tt.key)
+			}
+			w := httptest.NewRecorder()
+			mux.ServeHTTP(w, req)
+			if w.Code != tt.want {
+				t.Errorf("expected %d, got %d: %s", tt.want, w.Code, w.Body.String())
+			}
+		})
+	}
+
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/admin/health", nil))
+	if w.Code != http.StatusOK {
+		t.Errorf("/admin/health should not require a key, got %d", w.Code)
+	}
+}
+
+func TestDescribeRoutes(t *testing.T) {
+	h := NewHandler(nil, nil, log.New(io.Discard, "", 0))
+	if err := openapi.CheckRoutes(h.RegisterRoutes, h.DescribeRoutes); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,45 @@
+package admin
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/learnbot/job-aggregator/internal/model"
+)
+
+func TestNewCareerPage(t *testing.T) {
+	page, err := newCareerPage(createCareerPageRequest{
+		CompanyName:   "  Acme ",
+		CareerPageURL: "https://jobs.lever.co/acme",
+		SourceType:    model.CareerPageLever,
+	})
+	if err != nil {
+		t.Fatalf("newCareerPage: %v", err)
+	}
+	if page.CompanyName != "Acme" || !page.IsEnabled || string(page.Selectors) != "{}" {
+		t.Errorf("unexpected page: %+v", page)
+	}
+
+	page, err = newCareerPage(createCareerPageRequest{CompanyName: "Acme", CareerPageURL: "https://acme.com/careers"})
+	if err != nil || page.SourceType != model.CareerPageHTML {
+		t.Errorf("source_type should default to html, got %q (%v)", page.SourceType, err)
+	}
+
+	tests := []struct {
+		name string
+		req  createCareerPageRequest
+		want string
+	}{
+		{"missing company", createCareerPageRequest{CareerPageURL: "https://acme.com/careers"}, "company_name"},
+		{"relative url", createCareerPageRequest{CompanyName: "Acme", CareerPageURL: "/careers"}, "career_page_url"},
+		{"ftp url", createCareerPageRequest{CompanyName: "Acme", CareerPageURL: "ftp://acme.com"}, "career_page_url"},
+		{"unknown source", createCareerPageRequest{CompanyName: "Acme", CareerPageURL: "https://acme.com", SourceType: "workday"}, "source_type"},
+		{"selectors array", createCareerPageRequest{CompanyName: "Acme", CareerPageURL: "https://acme.com", Selectors: json.RawMessage(`[]`)}, "selectors"},
+	}
+	for _, tt := range tests {
+		if _, err := newCareerPage(tt.req); err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("%s: expected a %s error, got %v", tt.name, tt.want, err)
+		}
+	}
+}
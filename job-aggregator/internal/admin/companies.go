@@ -0,0 +1,215 @@
+package admin
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"github.com/learnbot/job-aggregator/internal/model"
+	"github.com/learnbot/job-aggregator/internal/storage"
+	"github.com/learnbot/shared/audit"
+	"github.com/learnbot/shared/pagination"
+)
+
+// maxCompaniesPageSize caps the page_size of GET /admin/companies.
+const maxCompaniesPageSize = 100
+
+// ListCompanies returns companies ordered by name. needs_review=true lists
+// the companies created at ingest that no admin has reviewed yet.
+// GET /admin/companies?q=google&needs_review=true&page=1&page_size=20
+func (h *Handler) ListCompanies(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		h.writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	filter, err := parseCompanyFilter(r.URL.Query())
+	if err != nil {
+		h.writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	companies, total, err := h.repo.ListCompanies(r.Context(), filter)
+	if err != nil {
+		h.logger.Printf("[admin] ListCompanies error: %v", err)
+		h.writeError(w, http.StatusInternalServerError, "failed to list companies")
+		return
+	}
+	if companies == nil {
+		companies = []model.Company{}
+	}
+
+	page := pagination.FromPage(total, filter.Page, filter.PageSize)
+	pagination.SetPageLinks(w.Header(), r.URL, page)
+	h.writeJSON(w, http.StatusOK, pagination.Envelope{Data: companies, Pagination: page})
+}
+
+// parseCompanyFilter reads the GET /admin/companies query parameters.
+func parseCompanyFilter(q url.Values) (model.CompanyFilter, error) {
+	filter := model.CompanyFilter{Query: strings.TrimSpace(q.Get("q")), Page: 1, PageSize: 20}
+	if v := q.Get("needs_review"); v != "" {
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			return filter, fmt.Errorf("invalid needs_review %q", v)
+		}
+		filter.NeedsReview = &b
+	}
+	if p := q.Get("page"); p != "" {
+		n, err := strconv.Atoi(p)
+		if err != nil || n < 1 {
+			return filter, fmt.Errorf("invalid page %q", p)
+		}
+		filter.Page = n
+	}
+	if ps := q.Get("page_size"); ps != "" {
+		n, err := strconv.Atoi(ps)
+		if err != nil || n < 1 {
+			return filter, fmt.Errorf("invalid page_size %q", ps)
+		}
+		filter.PageSize = min(n, maxCompaniesPageSize)
+	}
+	return filter, nil
+}
+
+// Company dispatches /admin/companies/{id} by method.
+func (h *Handler) Company(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(strings.TrimPrefix(r.URL.Path, "/admin/companies/"))
+	if err != nil {
+		h.writeError(w, http.StatusBadRequest, "invalid company ID format")
+		return
+	}
+	switch r.Method {
+	case http.MethodGet:
+		h.GetCompany(w, r, id)
+	case http.MethodPatch:
+		h.UpdateCompany(w, r, id)
+	default:
+		h.writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+	}
+}
+
+// GetCompany returns a company with its aliases.
+// GET /admin/companies/{id}
+func (h *Handler) GetCompany(w http.ResponseWriter, r *http.Request, id uuid.UUID) {
+	company, err := h.repo.GetCompany(r.Context(), id)
+	if errors.Is(err, storage.ErrNotFound) {
+		h.writeError(w, http.StatusNotFound, "company not found")
+		return
+	}
+	if err != nil {
+		h.logger.Printf("[admin] GetCompany error: %v", err)
+		h.writeError(w, http.StatusInternalServerError, "failed to get company")
+		return
+	}
+	h.writeJSON(w, http.StatusOK, company)
+}
+
+// UpdateCompany changes the fields given in the body, which also marks the
+// company as reviewed unless needs_review is given.
+// PATCH /admin/companies/{id}
+func (h *Handler) UpdateCompany(w http.ResponseWriter, r *http.Request, id uuid.UUID) {
+	var update model.CompanyUpdate
+	dec := json.NewDecoder(r.Body)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&update); err != nil {
+		h.writeError(w, http.StatusBadRequest, "invalid request body: "+err.Error())
+		return
+	}
+	if update.Name != nil && strings.TrimSpace(*update.Name) == "" {
+		h.writeError(w, http.StatusBadRequest, "name must not be empty")
+		return
+	}
+	if update.NeedsReview == nil {
+		reviewed := false
+		update.NeedsReview = &reviewed
+	}
+
+	before, err := h.repo.GetCompany(r.Context(), id)
+	if err != nil {
+		h.writeCompanyError(w, "UpdateCompany", "failed to update company", err)
+		return
+	}
+	company, err := h.repo.UpdateCompany(r.Context(), id, update)
+	if errors.Is(err, storage.ErrDuplicate) {
+		h.writeError(w, http.StatusConflict, "the name or an alias belongs to another company")
+		return
+	}
+	if err != nil {
+		h.writeCompanyError(w, "UpdateCompany", "failed to update company", err)
+		return
+	}
+	h.logger.Printf("[admin] updated company %s (%s)", id, company.Name)
+	audit.Record(r.Context(), audit.Change{
+		Action: "company.update", EntityType: "company", EntityID: id.String(), Before: before, After: company,
+	})
+
+	h.writeJSON(w, http.StatusOK, company)
+}
+
+// writeCompanyError writes 404 Not Found for storage.ErrNotFound, and
+// otherwise logs err as an error of op and writes 500 with message.
+func (h *Handler) writeCompanyError(w http.ResponseWriter, op, message string, err error) {
+	if errors.Is(err, storage.ErrNotFound) {
+		h.writeError(w, http.StatusNotFound, "company not found")
+		return
+	}
+	h.logger.Printf("[admin] %s error: %v", op, err)
+	h.writeError(w, http.StatusInternalServerError, message)
+}
+
+// mergeCompaniesRequest is the body of POST /admin/companies/merge.
+type mergeCompaniesRequest struct {
+	SourceID uuid.UUID `json:"source_id"`
+	TargetID uuid.UUID `json:"target_id"`
+}
+
+// MergeCompanies merges the source company into the target: the source's
+// jobs, career pages and aliases move to the target, and the source is
+// deleted.
+// POST /admin/companies/merge
+func (h *Handler) MergeCompanies(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		h.writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	var req mergeCompaniesRequest
+	dec := json.NewDecoder(r.Body)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&req); err != nil {
+		h.writeError(w, http.StatusBadRequest, "invalid request body: "+err.Error())
+		return
+	}
+	if req.SourceID == uuid.Nil || req.TargetID == uuid.Nil {
+		h.writeError(w, http.StatusBadRequest, "source_id and target_id are required")
+		return
+	}
+	if req.SourceID == req.TargetID {
+		h.writeError(w, http.StatusBadRequest, storage.ErrSelfMerge.Error())
+		return
+	}
+
+	before, err := h.repo.GetCompany(r.Context(), req.SourceID)
+	if err != nil {
+		h.writeCompanyError(w, "MergeCompanies", "failed to merge companies", err)
+		return
+	}
+	merge, err := h.repo.MergeCompanies(r.Context(), req.SourceID, req.TargetID)
+	if err != nil {
+		h.writeCompanyError(w, "MergeCompanies", "failed to merge companies", err)
+		return
+	}
+	h.logger.Printf("[admin] merged company %s into %s: %d jobs, %d career pages",
+		req.SourceID, req.TargetID, merge.JobsMoved, merge.CareerPagesMoved)
+	audit.Record(r.Context(), audit.Change{
+		Action: "company.merge", EntityType: "company", EntityID: req.TargetID.String(), Before: before, After: merge,
+	})
+
+	h.writeJSON(w, http.StatusOK, merge)
+}
@@ -0,0 +1,195 @@
+package admin
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/learnbot/job-aggregator/internal/model"
+	"github.com/learnbot/job-aggregator/internal/scheduler"
+	"github.com/learnbot/job-aggregator/internal/storage"
+	"github.com/learnbot/shared/pagination"
+)
+
+func TestParseCompanyFilter(t *testing.T) {
+	filter, err := parseCompanyFilter(map[string][]string{
+		"q": {" goo "}, "needs_review": {"true"}, "page": {"2"}, "page_size": {"500"},
+	})
+	if err != nil {
+		t.Fatalf("parseCompanyFilter: %v", err)
+	}
+	if filter.Query != "goo" || filter.NeedsReview == nil || !*filter.NeedsReview ||
+		filter.Page != 2 || filter.PageSize != maxCompaniesPageSize {
+		t.Errorf("unexpected filter: %+v", filter)
+	}
+	for _, bad := range []string{"needs_review=maybe", "page=0", "page_size=x"} {
+		key, value, _ := strings.Cut(bad, "=")
+		if _, err := parseCompanyFilter(map[string][]string{key: {value}}); err == nil {
+			t.Errorf("expected an error for %s", bad)
+		}
+	}
+}
+
+func TestCompanies(t *testing.T) {
+	db, err := sql.Open(storage.SQLiteDriver, storage.SQLiteDSN(":memory:"))
+	if err != nil {
+		t.Fatalf("open sqlite: %v", err)
+	}
+	defer db.Close()
+	repo := storage.NewSQLiteRepository(db)
+	logger := log.New(io.Discard, "", 0)
+	ctx := context.Background()
+	if err := repo.Migrate(ctx, logger); err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+	h := NewHandler(repo, scheduler.New(repo, nil, scheduler.DefaultConfig(), logger), logger)
+	mux := http.NewServeMux()
+	h.RegisterRoutes(mux)
+
+	do := func(method, path, body string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, httptest.NewRequest(method, path, strings.NewReader(body)))
+		return w
+	}
+	upsert := func(company, externalID string) *model.Job {
+		job, _, err := repo.UpsertJob(ctx, &model.ScrapedJob{
+			Source: model.SourceLinkedIn, ExternalID: externalID, CompanyName: company, Title: "Engineer " + externalID,
+			LocationType: model.LocationRemote, EmploymentType: model.EmploymentFullTime, ExperienceLevel: model.LevelMid,
+		})
+		if err != nil {
+			t.Fatalf("UpsertJob: %v", err)
+		}
+		return job
+	}
+	target := upsert("Acme", "1")
+	source := upsert("Acme Holdings", "2")
+
+	w := do(http.MethodGet, "/admin/companies?needs_review=true&q=acme", "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("list: expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var list struct {
+		Data       []model.Company       `json:"data"`
+		Pagination pagination.Pagination `json:"pagination"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &list); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if list.Pagination.Total != 2 || len(list.Data) != 2 || list.Data[0].ID != *target.CompanyID {
+		t.Errorf("unexpected list: %s", w.Body.String())
+	}
+
+	path := "/admin/companies/" + target.CompanyID.String()
+	if w := do(http.MethodPatch, path, `{"aliases":["Acme Labs", "ACME Indonesia"]}`); w.Code != http.StatusOK {
+		t.Fatalf("update: expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	w = do(http.MethodGet, path, "")
+	var company model.Company
+	if err := json.Unmarshal(w.Body.Bytes(), &company); err != nil || w.Code != http.StatusOK {
+		t.Fatalf("get: %d %s (%v)", w.Code, w.Body.String(), err)
+	}
+	if company.NeedsReview || strings.Join(company.Aliases, ",") != "acme,acme indonesia,acme labs" {
+		t.Errorf("update should replace the aliases and mark the company reviewed: %+v", company)
+	}
+
+	tests := []struct {
+		method, path, body string
+		want               int
+	}{
+		{http.MethodGet, "/admin/companies/acme", "", http.StatusBadRequest},
+		{http.MethodGet, "/admin/companies/" + target.ID.String(), "", http.StatusNotFound},
+		{http.MethodDelete, path, "", http.StatusMethodNotAllowed},
+		{http.MethodPatch, path, `{"name":" "}`, http.StatusBadRequest},
+		{http.MethodPatch, path, `{"size":"large"}`, http.StatusBadRequest},
+		{http.MethodPatch, path, `{"name":"Acme Holdings"}`, http.StatusConflict},
+		{http.MethodPost, "/admin/companies/merge", `{"source_id":"` + path[len("/admin/companies/"):] + `"}`, http.StatusBadRequest},
+		{http.MethodPost, "/admin/companies/merge",
+			`{"source_id":"` + target.CompanyID.String() + `","target_id":"` + target.CompanyID.String() + `"}`, http.StatusBadRequest},
+		{http.MethodPost, "/admin/companies/merge",
+			`{"source_id":"` + target.ID.String() + `","target_id":"` + target.CompanyID.String() + `"}`, http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		if w := do(tt.method, tt.path, tt.body); w.Code != tt.want {
+			t.Errorf("%s %s %s: expected %d, got %d: %s", tt.method, tt.path, tt.body, tt.want, w.Code, w.Body.String())
+		}
+	}
+
+	w = do(http.MethodPost, "/admin/companies/merge",
+		`{"source_id":"`+source.CompanyID.String()+`","target_id":"`+target.CompanyID.String()+`"}`)
+	if w.Code != http.StatusOK {
+		t.Fatalf("merge: expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var merge model.CompanyMerge
+	if err := json.Unmarshal(w.Body.Bytes(), &merge); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if merge.JobsMoved != 1 || merge.MergedID != *source.CompanyID {
+		t.Errorf("unexpected merge: %s", w.Body.String())
+	}
+	w = do(http.MethodGet, "/admin/jobs?company_id="+target.CompanyID.String(), "")
+	if err := json.Unmarshal(w.Body.Bytes(), &list); err != nil || w.Code != http.StatusOK {
+		t.Fatalf("search: %d %s (%v)", w.Code, w.Body.String(), err)
+	}
+	if list.Pagination.Total != 2 {
+		t.Errorf("expected both jobs under the merged company, got %s", w.Body.String())
+	}
+	if w := do(http.MethodGet, "/admin/jobs?company_id=acme", ""); w.Code != http.StatusBadRequest {
+		t.Errorf("invalid company_id: expected 400, got %d", w.Code)
+	}
+}
+
+func TestCreateCareerPage_LinksCompany(t *testing.T) {
+	db, err := sql.Open(storage.SQLiteDriver, storage.SQLiteDSN(":memory:"))
+	if err != nil {
+		t.Fatalf("open sqlite: %v", err)
+	}
+	defer db.Close()
+	repo := storage.NewSQLiteRepository(db)
+	logger := log.New(io.Discard, "", 0)
+	ctx := context.Background()
+	if err := repo.Migrate(ctx, logger); err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+	mux := http.NewServeMux()
+	NewHandler(repo, scheduler.New(repo, nil, scheduler.DefaultConfig(), logger), logger).RegisterRoutes(mux)
+
+	create := func(body string) (*httptest.ResponseRecorder, model.CompanyCareerPage) {
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/admin/career-pages", strings.NewReader(body)))
+		var resp struct {
+			CareerPage model.CompanyCareerPage `json:"career_page"`
+		}
+		json.Unmarshal(w.Body.Bytes(), &resp) //nolint:errcheck
+		return w, resp.CareerPage
+	}
+
+	w, page := create(`{"company_name":"Acme","career_page_url":"https://jobs.lever.co/acme","source_type":"lever"}`)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("create: expected 201, got %d: %s", w.Code, w.Body.String())
+	}
+	if page.CompanyID == nil {
+		t.Fatal("career page should be linked to a resolved company")
+	}
+	acme, err := repo.ResolveCompany(ctx, "Acme Inc", "")
+	if err != nil || acme.ID != *page.CompanyID {
+		t.Errorf("Acme Inc resolved to %v (%v), want %v", acme, err, *page.CompanyID)
+	}
+
+	w, page = create(`{"company_id":"` + acme.ID.String() + `","career_page_url":"https://boards.greenhouse.io/acme","source_type":"greenhouse"}`)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("create by company_id: expected 201, got %d: %s", w.Code, w.Body.String())
+	}
+	if page.CompanyName != "Acme" || page.CompanyID == nil || *page.CompanyID != acme.ID {
+		t.Errorf("career page should take the company's name: %+v", page)
+	}
+
+	if w, _ := create(`{"company_id":"` + page.ID.String() + `","career_page_url":"https://acme.com/careers"}`); w.Code != http.StatusBadRequest {
+		t.Errorf("unknown company_id: expected 400, got %d", w.Code)
+	}
+}
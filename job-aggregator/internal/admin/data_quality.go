@@ -0,0 +1,90 @@
+package admin
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/learnbot/job-aggregator/internal/model"
+	"github.com/learnbot/shared/dataquality"
+)
+
+// defaultQualityWindow is the reporting period used by the data quality
+// endpoint when the caller does not pass ?days=.
+const defaultQualityWindow = 7 * 24 * time.Hour
+
+// GetDataQuality returns the job aggregator's data quality section.
+// GET /admin/data-quality?days=7
+//
+// The section covers the last N days and compares each check against the
+// N days before that.
+func (h *Handler) GetDataQuality(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		h.writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	window := defaultQualityWindow
+	if d := r.URL.Query().Get("days"); d != "" {
+		if n, err := strconv.Atoi(d); err == nil && n > 0 {
+			window = time.Duration(n) * 24 * time.Hour
+		}
+	}
+
+	now := time.Now().UTC()
+	runs, err := h.repo.GetScrapeRunsSince(r.Context(), now.Add(-2*window))
+	if err != nil {
+		h.logger.Printf("[admin] GetDataQuality error: %v", err)
+		h.writeError(w, http.StatusInternalServerError, "failed to get data quality")
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, buildDataQualitySection(runs, now, window))
+}
+
+// buildDataQualitySection aggregates scrape runs into data quality checks.
+// Runs created in [now-window, now] count towards the current period and runs
+// in [now-2*window, now-window) towards the previous one; anything older is
+// ignored.
+func buildDataQualitySection(runs []model.ScrapeRun, now time.Time, window time.Duration) dataquality.Section {
+	periodStart := now.Add(-window)
+	prevStart := periodStart.Add(-window)
+
+	rejected := dataquality.NewCheck("rejected_jobs",
+		"Scraped jobs that failed validation or could not be stored", "/admin/runs")
+	failedRuns := dataquality.NewCheck("failed_runs",
+		"Scrape runs that ended in an error", "/admin/runs")
+	emptyRuns := dataquality.NewCheck("empty_runs",
+		"Completed scrape runs that found no jobs (possible selector drift)", "/admin/runs")
+
+	for _, run := range runs {
+		if run.CreatedAt.Before(prevStart) || run.CreatedAt.After(now) {
+			continue
+		}
+		current := !run.CreatedAt.Before(periodStart)
+
+		if run.JobsFailed > 0 {
+			tallyCheck(&rejected, current, run.JobsFailed, run.ID.String())
+		}
+		if run.Status == model.ScrapeStatusFailed {
+			tallyCheck(&failedRuns, current, 1, run.ID.String())
+		}
+		if run.Status == model.ScrapeStatusCompleted && run.JobsFound == 0 {
+			tallyCheck(&emptyRuns, current, 1, run.ID.String())
+		}
+	}
+
+	return dataquality.NewSection("job-aggregator", now, window,
+		[]dataquality.Check{rejected, failedRuns, emptyRuns})
+}
+
+// tallyCheck adds n to the current or previous count of c. Drill-down IDs are
+// only collected for the current period.
+func tallyCheck(c *dataquality.Check, current bool, n int, id string) {
+	if !current {
+		c.PreviousCount += n
+		return
+	}
+	c.Count += n
+	c.AddID(id)
+}
@@ -0,0 +1,124 @@
+package admin
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/learnbot/job-aggregator/internal/model"
+	"github.com/learnbot/shared/dataquality"
+)
+
+func seededRun(createdAt time.Time, status model.ScrapeStatus, found, failed int) model.ScrapeRun {
+	return model.ScrapeRun{
+		ID:         uuid.New(),
+		Source:     model.SourceLinkedIn,
+		Status:     status,
+		JobsFound:  found,
+		JobsFailed: failed,
+		CreatedAt:  createdAt,
+	}
+}
+
+func findCheck(t *testing.T, s dataquality.Section, name string) dataquality.Check {
+	t.Helper()
+	for _, c := range s.Checks {
+		if c.Name == name {
+			return c
+		}
+	}
+	t.Fatalf("check %q not found in section", name)
+	return dataquality.Check{}
+}
+
+func TestBuildDataQualitySection_SeededRuns(t *testing.T) {
+	now := time.Date(2024, 6, 15, 12, 0, 0, 0, time.UTC)
+	window := 7 * 24 * time.Hour
+
+	failedCurrent := seededRun(now.Add(-24*time.Hour), model.ScrapeStatusFailed, 0, 0)
+	rejectCurrent := seededRun(now.Add(-48*time.Hour), model.ScrapeStatusCompleted, 30, 4)
+	emptyCurrent := seededRun(now.Add(-72*time.Hour), model.ScrapeStatusCompleted, 0, 0)
+	runs := []model.ScrapeRun{
+		failedCurrent,
+		rejectCurrent,
+		emptyCurrent,
+		seededRun(now.Add(-96*time.Hour), model.ScrapeStatusCompleted, 25, 0),
+		// Previous period.
+		seededRun(now.Add(-8*24*time.Hour), model.ScrapeStatusCompleted, 40, 6),
+		seededRun(now.Add(-9*24*time.Hour), model.ScrapeStatusFailed, 0, 0),
+		seededRun(now.Add(-10*24*time.Hour), model.ScrapeStatusFailed, 0, 0),
+		// Outside both periods – ignored.
+		seededRun(now.Add(-30*24*time.Hour), model.ScrapeStatusFailed, 0, 99),
+	}
+
+	s := buildDataQualitySection(runs, now, window)
+
+	if s.Service != "job-aggregator" {
+		t.Errorf("Service = %q, want job-aggregator", s.Service)
+	}
+	if s.Status != "degraded" {
+		t.Errorf("Status = %q, want degraded", s.Status)
+	}
+	if !s.PeriodStart.Equal(now.Add(-window)) || !s.PeriodEnd.Equal(now) {
+		t.Errorf("unexpected period %v – %v", s.PeriodStart, s.PeriodEnd)
+	}
+
+	rejected := findCheck(t, s, "rejected_jobs")
+	if rejected.Count != 4 || rejected.PreviousCount != 6 || rejected.Trend != "down" {
+		t.Errorf("rejected_jobs = %d/%d %s, want 4/6 down",
+			rejected.Count, rejected.PreviousCount, rejected.Trend)
+	}
+	if len(rejected.DrillDown.IDs) != 1 || rejected.DrillDown.IDs[0] != rejectCurrent.ID.String() {
+		t.Errorf("rejected_jobs drill-down = %v, want [%s]", rejected.DrillDown.IDs, rejectCurrent.ID)
+	}
+
+	failed := findCheck(t, s, "failed_runs")
+	if failed.Count != 1 || failed.PreviousCount != 2 || failed.Trend != "down" {
+		t.Errorf("failed_runs = %d/%d %s, want 1/2 down",
+			failed.Count, failed.PreviousCount, failed.Trend)
+	}
+	if len(failed.DrillDown.IDs) != 1 || failed.DrillDown.IDs[0] != failedCurrent.ID.String() {
+		t.Errorf("failed_runs drill-down = %v, want [%s]", failed.DrillDown.IDs, failedCurrent.ID)
+	}
+
+	empty := findCheck(t, s, "empty_runs")
+	if empty.Count != 1 || empty.PreviousCount != 0 || empty.Trend != "up" {
+		t.Errorf("empty_runs = %d/%d %s, want 1/0 up",
+			empty.Count, empty.PreviousCount, empty.Trend)
+	}
+	if len(empty.DrillDown.IDs) != 1 || empty.DrillDown.IDs[0] != emptyCurrent.ID.String() {
+		t.Errorf("empty_runs drill-down = %v, want [%s]", empty.DrillDown.IDs, emptyCurrent.ID)
+	}
+}
+
+func TestBuildDataQualitySection_NoRunsIsHealthy(t *testing.T) {
+	s := buildDataQualitySection(nil, time.Now().UTC(), 7*24*time.Hour)
+	if s.Status != "healthy" {
+		t.Errorf("Status = %q, want healthy", s.Status)
+	}
+	for _, c := range s.Checks {
+		if c.Count != 0 || c.Trend != "flat" {
+			t.Errorf("%s = %d %s, want 0 flat", c.Name, c.Count, c.Trend)
+		}
+		if c.DrillDown.IDs == nil {
+			t.Errorf("%s drill-down IDs should be an empty slice, not nil", c.Name)
+		}
+	}
+}
+
+func TestBuildDataQualitySection_CapsDrillDownIDs(t *testing.T) {
+	now := time.Now().UTC()
+	var runs []model.ScrapeRun
+	for i := 0; i < dataquality.MaxDrillDownIDs+5; i++ {
+		runs = append(runs, seededRun(now.Add(-time.Hour), model.ScrapeStatusFailed, 0, 1))
+	}
+
+	s := buildDataQualitySection(runs, now, 7*24*time.Hour)
+	failed := findCheck(t, s, "failed_runs")
+	if failed.Count != dataquality.MaxDrillDownIDs+5 {
+		t.Errorf("Count = %d, want %d", failed.Count, dataquality.MaxDrillDownIDs+5)
+	}
+	if len(failed.DrillDown.IDs) != dataquality.MaxDrillDownIDs {
+		t.Errorf("len(IDs) = %d, want %d", len(failed.DrillDown.IDs), dataquality.MaxDrillDownIDs)
+	}
+}
@@ -2,51 +2,448 @@
 package admin
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"log"
+	"log/slog"
 	"net/http"
+	"net/url"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/learnbot/job-aggregator/internal/cursor"
 	"github.com/learnbot/job-aggregator/internal/model"
 	"github.com/learnbot/job-aggregator/internal/scheduler"
+	"github.com/learnbot/job-aggregator/internal/scraper"
 	"github.com/learnbot/job-aggregator/internal/storage"
+	"github.com/learnbot/shared/adminauth"
+	"github.com/learnbot/shared/audit"
+	"github.com/learnbot/shared/dataquality"
+	"github.com/learnbot/shared/openapi"
+	"github.com/learnbot/shared/pagination"
 )
 
 // Handler provides HTTP endpoints for the admin dashboard.
 type Handler struct {
-	repo      *storage.JobRepository
+	repo      storage.JobRepository
+	webhooks  webhookStore
 	scheduler *scheduler.Scheduler
+	auth      *adminauth.Middleware
+	audit     *audit.Recorder
+	cursors   *cursor.Codec
 	logger    *log.Logger
 }
 
-// NewHandler creates a new admin Handler.
-func NewHandler(repo *storage.JobRepository, sched *scheduler.Scheduler, logger *log.Logger) *Handler {
+// NewHandler creates a new admin Handler. Its cursors are signed with a
+// random key until SetCursorCodec is called. The webhook routes are served
+// if repo also stores webhooks.
+func NewHandler(repo storage.JobRepository, sched *scheduler.Scheduler, logger *log.Logger) *Handler {
+	webhooks, _ := repo.(webhookStore)
 	return &Handler{
 		repo:      repo,
+		webhooks:  webhooks,
 		scheduler: sched,
+		cursors:   cursor.NewCodec(nil),
 		logger:    logger,
 	}
 }
 
+// SetCursorCodec sets the codec that signs and verifies pagination cursors.
+func (h *Handler) SetCursorCodec(c *cursor.Codec) {
+	h.cursors = c
+}
+
+// SetAuth requires admin credentials on every admin route except
+// /admin/health.
+func (h *Handler) SetAuth(auth *adminauth.Middleware) {
+	h.auth = auth
+}
+
+// auditService names the job aggregator in the audit log.
+const auditService = "job-aggregator"
+
+// SetAuditLog records the changes made through the mutating admin routes
+// in store and serves them at /admin/audit-log. Entries that cannot be
+// stored are logged to logger.
+func (h *Handler) SetAuditLog(store audit.Store, logger *slog.Logger) {
+	h.audit = audit.NewRecorder(auditService, store, logger)
+}
+
 // RegisterRoutes registers all admin routes on the given mux.
-func (h *Handler) RegisterRoutes(mux *http.ServeMux) {
+func (h *Handler) RegisterRoutes(mux openapi.Router) {
 	// Dashboard stats
-	mux.HandleFunc("/admin/stats", h.GetStats)
+	mux.HandleFunc("/admin/stats", h.protect(h.GetStats))
 	// Scrape runs
-	mux.HandleFunc("/admin/runs", h.GetRecentRuns)
+	mux.HandleFunc("/admin/runs", h.protect(h.GetRecentRuns))
+	mux.HandleFunc("/admin/scrape-runs", h.protect(h.ListScrapeRuns))
+	mux.HandleFunc("/admin/scrape-runs/", h.protect(h.GetScrapeRun))
 	// Trigger manual scrape
-	mux.HandleFunc("/admin/scrape/trigger", h.TriggerScrape)
+	mux.HandleFunc("/admin/scrape/trigger", h.protect(h.TriggerScrape))
+	// Per-scraper schedules
+	mux.HandleFunc("/admin/schedule", h.protect(h.GetSchedule))
+	mux.HandleFunc("/admin/schedule/", h.protect(h.UpdateSchedule))
+	// Pausing the schedule and disabling scrapers
+	mux.HandleFunc("/admin/scheduler/status", h.protect(h.GetSchedulerStatus))
+	mux.HandleFunc("/admin/scheduler/pause", h.protect(h.PauseScheduler))
+	mux.HandleFunc("/admin/scheduler/resume", h.protect(h.ResumeScheduler))
+	// Scraper health, dry runs and enable flags
+	mux.HandleFunc("/admin/scrapers/health", h.protect(h.GetScraperHealth))
+	mux.HandleFunc("/admin/scrapers/", h.protect(h.ScraperAction))
 	// Job management
-	mux.HandleFunc("/admin/jobs", h.SearchJobs)
-	mux.HandleFunc("/admin/jobs/", h.GetJob)
+	mux.HandleFunc("/admin/jobs", h.protect(h.SearchJobs))
+	mux.HandleFunc("/admin/jobs/", h.protect(h.GetJob))
+	mux.HandleFunc("/admin/dedup-log", h.protect(h.ListDedupLog))
+	mux.HandleFunc("/admin/expiry-runs", h.protect(h.GetExpiryRuns))
 	// Career pages
-	mux.HandleFunc("/admin/career-pages", h.GetCareerPages)
-	// Health
+	mux.HandleFunc("/admin/career-pages", h.protect(h.CareerPages))
+
+	mux.HandleFunc("/admin/companies", h.protect(h.ListCompanies))
+	mux.HandleFunc("/admin/companies/", h.protect(h.Company))
+	mux.HandleFunc("/admin/companies/merge", h.protect(h.MergeCompanies))
+	// Webhook subscriptions
+	mux.HandleFunc("/admin/webhooks", h.protect(h.Webhooks))
+	mux.HandleFunc("/admin/webhooks/", h.protect(h.DeleteWebhook))
+	mux.HandleFunc("/admin/webhook-deliveries", h.protect(h.ListWebhookDeliveries))
+	// Data quality
+	mux.HandleFunc("/admin/data-quality", h.protect(h.GetDataQuality))
+	// Erasing a deleted account's data
+	mux.HandleFunc("/admin/users/", h.protect(h.EraseUser))
+	// Audit log of admin changes
+	mux.HandleFunc("/admin/audit-log", h.protect(h.ListAuditLog))
+	// Health (unauthenticated, for probes)
 	mux.HandleFunc("/admin/health", h.Health)
 }
 
+// DescribeRoutes documents the routes registered by RegisterRoutes.
+func (h *Handler) DescribeRoutes(spec *openapi.Spec) {
+	admin := []string{openapi.AdminAPIKey, openapi.BearerAuth}
+	page := openapi.Param{Name: "page", Description: "Page number, from 1", Type: 0}
+	pageSize := openapi.Param{Name: "page_size", Description: "Page size (default 20, max 100)", Type: 0}
+	denied := []int{http.StatusUnauthorized, http.StatusForbidden, http.StatusServiceUnavailable}
+	errs := func(codes ...int) []int { return append(codes, denied...) }
+
+	spec.Route("/admin/stats", openapi.Operation{
+		Method:   http.MethodGet,
+		Summary:  "Aggregated scraping statistics",
+		Security: admin,
+		Response: openapi.Fields{"stats": model.AdminStats{}, "is_running": false, "timestamp": time.Time{}},
+		Errors:   denied,
+	})
+	spec.Route("/admin/runs", openapi.Operation{
+		Method:   http.MethodGet,
+		Summary:  "Most recent scrape runs",
+		Security: admin,
+		Params:   []openapi.Param{{Name: "limit", Description: "Maximum number of runs (default 20)", Type: 0}},
+		Response: openapi.Fields{"runs": []model.ScrapeRun{}, "count": 0},
+		Errors:   denied,
+	})
+	spec.Route("/admin/scrape-runs", openapi.Operation{
+		Method:   http.MethodGet,
+		Summary:  "Scrape run history, newest first",
+		Security: admin,
+		Params: []openapi.Param{
+			openapi.Query("scraper", "Filter by scraper name"),
+			openapi.Query("status", "Filter by run status"),
+			openapi.Query("from", "Started at or after, RFC 3339 timestamp or YYYY-MM-DD date"),
+			openapi.Query("to", "Started before, RFC 3339 timestamp or YYYY-MM-DD date (inclusive)"),
+			page, pageSize,
+		},
+		Response: openapi.Fields{"data": []model.ScrapeRun{}, "pagination": pagination.Pagination{}},
+		Errors:   errs(http.StatusBadRequest),
+	})
+	spec.Route("/admin/scrape-runs/", openapi.Operation{
+		Method:   http.MethodGet,
+		Path:     "/admin/scrape-runs/{id}",
+		Summary:  "Get a scrape run",
+		Security: admin,
+		Response: model.ScrapeRun{},
+		Errors:   errs(http.StatusBadRequest, http.StatusNotFound),
+	})
+	spec.Route("/admin/scrape/trigger", openapi.Operation{
+		Method:   http.MethodPost,
+		Summary:  "Trigger a scraping run now",
+		Security: admin,
+		Response: openapi.Fields{"message": "", "running": false},
+		Status:   http.StatusAccepted,
+		Errors:   errs(http.StatusConflict),
+	})
+	spec.Route("/admin/schedule", openapi.Operation{
+		Method:   http.MethodGet,
+		Summary:  "Each scraper's schedule and next run time, in UTC and its time zone",
+		Security: admin,
+		Response: openapi.Fields{"schedules": []scheduler.ScraperSchedule{}, "count": 0},
+		Errors:   denied,
+	})
+	spec.Route("/admin/schedule/", openapi.Operation{
+		Method:      http.MethodPut,
+		Path:        "/admin/schedule/{scraper}",
+		Summary:     "Change a scraper's schedule",
+		Description: "The scraper name is URL-escaped in the path. An empty schedule restores the default.",
+		Security:    admin,
+		Request:     updateScheduleRequest{},
+		Response:    scheduler.ScraperSchedule{},
+		Errors:      errs(http.StatusBadRequest, http.StatusNotFound),
+	})
+	spec.Route("/admin/scheduler/status", openapi.Operation{
+		Method:   http.MethodGet,
+		Summary:  "Whether the schedule is paused, and each scraper's enabled flag and next run time",
+		Security: admin,
+		Response: scheduler.SchedulerStatus{},
+		Errors:   denied,
+	})
+	spec.Route("/admin/scheduler/pause", openapi.Operation{
+		Method:      http.MethodPost,
+		Summary:     "Pause the schedule",
+		Description: "Scheduled runs are recorded as skipped until the schedule is resumed; runs in progress finish. Survives restarts.",
+		Security:    admin,
+		Response:    scheduler.SchedulerStatus{},
+		Errors:      errs(http.StatusInternalServerError),
+	})
+	spec.Route("/admin/scheduler/resume", openapi.Operation{
+		Method:   http.MethodPost,
+		Summary:  "Resume the schedule",
+		Security: admin,
+		Response: scheduler.SchedulerStatus{},
+		Errors:   errs(http.StatusInternalServerError),
+	})
+	spec.Route("/admin/scrapers/health", openapi.Operation{
+		Method:  http.MethodGet,
+		Summary: "Each scraper's run health",
+		Description: "By default a scraper is failing after 3 consecutive failed runs and degraded after fewer, or when " +
+			"its last completed run found over 70% fewer jobs than its trailing 7-day average.",
+		Security: admin,
+		Response: openapi.Fields{"scrapers": []model.ScraperHealth{}, "count": 0},
+		Errors:   denied,
+	})
+	spec.Route("/admin/scrapers/",
+		openapi.Operation{
+			Method:  http.MethodPost,
+			Path:    "/admin/scrapers/{scraper}/dry-run",
+			Summary: "Run a scraper without storing its jobs",
+			Description: "Reports whether each scraped job would be new, changed (with the changed fields), unchanged or " +
+				"a duplicate of a stored job. The scraper name is URL-escaped in the path.",
+			Security: admin,
+			Params: []openapi.Param{
+				{Name: "limit", Description: fmt.Sprintf("Maximum number of pages to fetch (default %d, max %d)", scheduler.DefaultDryRunPages, maxDryRunPages), Type: 0},
+				openapi.Query("q", "Search query, instead of the configured queries"),
+				openapi.Query("location", "Location of the search query"),
+				openapi.Query("remote", "true to search remote jobs"),
+			},
+			Response: model.DryRunReport{},
+			Errors:   errs(http.StatusBadRequest, http.StatusNotFound, http.StatusConflict),
+		},
+		openapi.Operation{
+			Method:  http.MethodPut,
+			Path:    "/admin/scrapers/{scraper}/enabled",
+			Summary: "Enable or disable a scraper",
+			Description: "A disabled scraper's runs are recorded as skipped; a run in progress finishes. Survives restarts. " +
+				"The scraper name is URL-escaped in the path.",
+			Security: admin,
+			Request:  setScraperEnabledRequest{},
+			Response: scheduler.ScraperStatus{},
+			Errors:   errs(http.StatusBadRequest, http.StatusNotFound, http.StatusInternalServerError),
+		},
+	)
+	spec.Route("/admin/jobs", openapi.Operation{
+		Method:   http.MethodGet,
+		Summary:  "Search jobs, newest first",
+		Security: admin,
+		Params: []openapi.Param{
+			openapi.Query("q", "Search job titles"),
+			openapi.Query("company", "Filter by company name"),
+			openapi.Query("company_id", "Filter by company ID"),
+			openapi.Query("location_type", "Filter by work location type"),
+			openapi.Query("experience", "Filter by experience level"),
+			openapi.Query("status", "Filter by job status (default active)"),
+			openapi.Query("posted_after", "YYYY-MM-DD date"),
+			page, pageSize,
+			openapi.Query("cursor", "next_cursor of the previous page; not combined with page"),
+		},
+		Response: openapi.Fields{"data": []model.Job{}, "pagination": pagination.Pagination{}},
+		Errors:   errs(http.StatusBadRequest),
+	})
+	spec.Route("/admin/jobs/", openapi.Operation{
+		Method:   http.MethodGet,
+		Path:     "/admin/jobs/{id}",
+		Summary:  "Get a job",
+		Security: admin,
+		Response: model.Job{},
+		Errors:   errs(http.StatusBadRequest, http.StatusNotFound),
+	})
+	spec.Route("/admin/dedup-log", openapi.Operation{
+		Method:   http.MethodGet,
+		Summary:  "Fuzzy dedup merges, newest first",
+		Security: admin,
+		Params:   []openapi.Param{openapi.Query("job_id", "Only merges into this canonical job"), page, pageSize},
+		Response: openapi.Fields{"data": []model.DedupLogEntry{}, "pagination": pagination.Pagination{}},
+		Errors:   errs(http.StatusBadRequest),
+	})
+	spec.Route("/admin/expiry-runs", openapi.Operation{
+		Method:      http.MethodGet,
+		Summary:     "Most recent stale job expiry passes",
+		Description: "Each pass lists the jobs it expired per scraper, with the stale duration and cutoff used.",
+		Security:    admin,
+		Params:      []openapi.Param{{Name: "limit", Description: "Maximum number of runs (default 20)", Type: 0}},
+		Response:    openapi.Fields{"runs": []model.ExpiryRun{}, "count": 0},
+		Errors:      denied,
+	})
+	spec.Route("/admin/career-pages",
+		openapi.Operation{
+			Method:   http.MethodGet,
+			Summary:  "List company career pages",
+			Security: admin,
+			Response: openapi.Fields{"career_pages": []model.CompanyCareerPage{}, "count": 0},
+			Errors:   denied,
+		},
+		openapi.Operation{
+			Method:   http.MethodPost,
+			Summary:  "Add a company career page and start scraping it",
+			Security: admin,
+			Request:  createCareerPageRequest{},
+			Response: openapi.Fields{"career_page": model.CompanyCareerPage{}, "scraper": ""},
+			Status:   http.StatusCreated,
+			Errors:   errs(http.StatusBadRequest, http.StatusConflict),
+		},
+	)
+	spec.Route("/admin/companies", openapi.Operation{
+		Method:   http.MethodGet,
+		Summary:  "List companies by name",
+		Security: admin,
+		Params: []openapi.Param{
+			openapi.Query("q", "Search company names and aliases"),
+			openapi.Query("needs_review", "true for the companies created at ingest and not yet reviewed"),
+			page, pageSize,
+		},
+		Response: openapi.Fields{"data": []model.Company{}, "pagination": pagination.Pagination{}},
+		Errors:   errs(http.StatusBadRequest),
+	})
+	spec.Route("/admin/companies/",
+		openapi.Operation{
+			Method:   http.MethodGet,
+			Path:     "/admin/companies/{id}",
+			Summary:  "Get a company with its aliases",
+			Security: admin,
+			Response: model.Company{},
+			Errors:   errs(http.StatusBadRequest, http.StatusNotFound),
+		},
+		openapi.Operation{
+			Method:      http.MethodPatch,
+			Path:        "/admin/companies/{id}",
+			Summary:     "Update a company",
+			Description: "Omitted fields are kept, and aliases replaces the aliases. Marks the company as reviewed unless needs_review is given.",
+			Security:    admin,
+			Request:     model.CompanyUpdate{},
+			Response:    model.Company{},
+			Errors:      errs(http.StatusBadRequest, http.StatusNotFound, http.StatusConflict),
+		},
+	)
+	spec.Route("/admin/companies/merge", openapi.Operation{
+		Method:      http.MethodPost,
+		Summary:     "Merge a company into another",
+		Description: "The source's jobs, career pages and aliases move to the target, and the source is deleted.",
+		Security:    admin,
+		Request:     mergeCompaniesRequest{},
+		Response:    model.CompanyMerge{},
+		Errors:      errs(http.StatusBadRequest, http.StatusNotFound),
+	})
+	spec.Route("/admin/webhooks",
+		openapi.Operation{
+			Method:   http.MethodGet,
+			Summary:  "List webhook subscriptions",
+			Security: admin,
+			Response: openapi.Fields{"webhooks": []model.WebhookSubscription{}, "count": 0},
+			Errors:   errs(http.StatusNotImplemented),
+		},
+		openapi.Operation{
+			Method:      http.MethodPost,
+			Summary:     "Subscribe a webhook to new matching jobs",
+			Description: "The secret signs deliveries; one is generated when omitted and returned only in this response.",
+			Security:    admin,
+			Request:     createWebhookRequest{},
+			Response:    openapi.Fields{"webhook": model.WebhookSubscription{}, "secret": ""},
+			Status:      http.StatusCreated,
+			Errors:      errs(http.StatusBadRequest, http.StatusNotImplemented),
+		},
+	)
+	spec.Route("/admin/webhooks/", openapi.Operation{
+		Method:   http.MethodDelete,
+		Path:     "/admin/webhooks/{id}",
+		Summary:  "Delete a webhook subscription and its deliveries",
+		Security: admin,
+		Status:   http.StatusNoContent,
+		Errors:   errs(http.StatusBadRequest, http.StatusNotFound, http.StatusNotImplemented),
+	})
+	spec.Route("/admin/webhook-deliveries", openapi.Operation{
+		Method:   http.MethodGet,
+		Summary:  "Recent webhook deliveries, newest first",
+		Security: admin,
+		Params: []openapi.Param{
+			openapi.Query("subscription_id", "Filter by subscription"),
+			openapi.Query("status", "Filter by delivery status"),
+			{Name: "limit", Description: "Maximum number of deliveries", Type: 0},
+		},
+		Response: openapi.Fields{"deliveries": []model.WebhookDelivery{}, "count": 0},
+		Errors:   errs(http.StatusBadRequest, http.StatusNotImplemented),
+	})
+	spec.Route("/admin/data-quality", openapi.Operation{
+		Method:   http.MethodGet,
+		Summary:  "Scraper data quality checks",
+		Security: admin,
+		Params:   []openapi.Param{{Name: "days", Description: "Window in days (default 7)", Type: 0}},
+		Response: dataquality.Section{},
+		Errors:   denied,
+	})
+	spec.Route("/admin/users/", openapi.Operation{
+		Method:      http.MethodDelete,
+		Path:        "/admin/users/{id}",
+		Summary:     "Erase a deleted user's saved jobs",
+		Description: "Idempotent: erasing a user with no saved jobs succeeds.",
+		Security:    admin,
+		Response:    openapi.Fields{"user_id": "", "saved_jobs_deleted": 0},
+		Errors:      errs(http.StatusBadRequest, http.StatusInternalServerError),
+	})
+	spec.Route("/admin/audit-log", openapi.Operation{
+		Method:   http.MethodGet,
+		Summary:  "Changes made through the admin API, newest first",
+		Security: admin,
+		Params: []openapi.Param{
+			openapi.Query("actor", `Filter by admin user ID, or "api_key"`),
+			openapi.Query("entity_type", "Filter by entity type, e.g. scraper"),
+			openapi.Query("entity_id", "Filter by entity ID"),
+			openapi.Query("from", "Made at or after, RFC 3339 timestamp or YYYY-MM-DD date"),
+			openapi.Query("to", "Made before, RFC 3339 timestamp or YYYY-MM-DD date (inclusive)"),
+			page, pageSize,
+		},
+		Response: openapi.Fields{"data": []audit.Entry{}, "pagination": pagination.Pagination{}},
+		Errors:   errs(http.StatusBadRequest, http.StatusNotImplemented),
+	})
+	spec.Route("/admin/health", openapi.Operation{
+		Method:   http.MethodGet,
+		Summary:  "Health check",
+		Response: openapi.Fields{"status": "", "version": "", "is_running": false, "time": time.Time{}},
+	})
+}
+
+// protect authenticates next once SetAuth has been called, and records its
+// changes once SetAuditLog has been called.
+func (h *Handler) protect(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		handler := next
+		if h.audit != nil {
+			handler = h.audit.WrapFunc(next)
+		}
+		if h.auth == nil {
+			handler(w, r)
+			return
+		}
+		h.auth.WrapFunc(handler)(w, r)
+	}
+}
+
 // GetStats returns aggregated scraping statistics.
 // GET /admin/stats
 func (h *Handler) GetStats(w http.ResponseWriter, r *http.Request) {
@@ -100,6 +497,172 @@ func (h *Handler) GetRecentRuns(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+// GetExpiryRuns returns the most recent stale job expiry passes, with the
+// number of jobs each expired per scraper.
+// GET /admin/expiry-runs?limit=20
+func (h *Handler) GetExpiryRuns(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		h.writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	limit := 20
+	if l := r.URL.Query().Get("limit"); l != "" {
+		if n, err := strconv.Atoi(l); err == nil && n > 0 {
+			limit = n
+		}
+	}
+
+	runs, err := h.repo.ListExpiryRuns(r.Context(), limit)
+	if err != nil {
+		h.logger.Printf("[admin] GetExpiryRuns error: %v", err)
+		h.writeError(w, http.StatusInternalServerError, "failed to get expiry runs")
+		return
+	}
+	if runs == nil {
+		runs = []model.ExpiryRun{}
+	}
+
+	h.writeJSON(w, http.StatusOK, map[string]interface{}{
+		"runs":  runs,
+		"count": len(runs),
+	})
+}
+
+// maxScrapeRunPageSize caps page_size for GET /admin/scrape-runs.
+const maxScrapeRunPageSize = 100
+
+// ListScrapeRuns returns scrape run history, newest first.
+// GET /admin/scrape-runs?scraper=LinkedIn%20Jobs&status=failed&from=2025-01-01&to=2025-01-31&page=1&page_size=20
+func (h *Handler) ListScrapeRuns(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		h.writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	filter, err := parseScrapeRunFilter(r.URL.Query())
+	if err != nil {
+		h.writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	runs, total, err := h.repo.SearchScrapeRuns(r.Context(), filter)
+	if err != nil {
+		h.logger.Printf("[admin] ListScrapeRuns error: %v", err)
+		h.writeError(w, http.StatusInternalServerError, "failed to get scrape runs")
+		return
+	}
+	if runs == nil {
+		runs = []model.ScrapeRun{}
+	}
+
+	page := pagination.FromPage(total, filter.Page, filter.PageSize)
+	pagination.SetPageLinks(w.Header(), r.URL, page)
+	h.writeJSON(w, http.StatusOK, pagination.Envelope{Data: runs, Pagination: page})
+}
+
+// parseScrapeRunFilter reads the GET /admin/scrape-runs query parameters.
+// from and to accept RFC 3339 timestamps or YYYY-MM-DD dates; a date-only
+// to includes the whole day.
+func parseScrapeRunFilter(q url.Values) (model.ScrapeRunFilter, error) {
+	filter := model.ScrapeRunFilter{
+		ScraperName: strings.TrimSpace(q.Get("scraper")),
+		Page:        1,
+		PageSize:    20,
+	}
+
+	switch status := model.ScrapeStatus(q.Get("status")); status {
+	case "":
+	case model.ScrapeStatusPending, model.ScrapeStatusRunning, model.ScrapeStatusCompleted,
+		model.ScrapeStatusFailed, model.ScrapeStatusRateLimited, model.ScrapeStatusInterrupted,
+		model.ScrapeStatusSkipped:
+		filter.Status = status
+	default:
+		return filter, fmt.Errorf("invalid status %q", status)
+	}
+
+	for _, p := range []struct {
+		name string
+		dest **time.Time
+		end  bool
+	}{
+		{"from", &filter.StartedAfter, false},
+		{"to", &filter.StartedBefore, true},
+	} {
+		v := q.Get(p.name)
+		if v == "" {
+			continue
+		}
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			if t, err = time.Parse("2006-01-02", v); err != nil {
+				return filter, fmt.Errorf("invalid %s date %q", p.name, v)
+			}
+			if p.end {
+				t = t.Add(24 * time.Hour)
+			}
+		}
+		*p.dest = &t
+	}
+	if filter.StartedAfter != nil && filter.StartedBefore != nil && !filter.StartedAfter.Before(*filter.StartedBefore) {
+		return filter, fmt.Errorf("from must be before to")
+	}
+
+	if p := q.Get("page"); p != "" {
+		n, err := strconv.Atoi(p)
+		if err != nil || n < 1 {
+			return filter, fmt.Errorf("invalid page %q", p)
+		}
+		filter.Page = n
+	}
+	if ps := q.Get("page_size"); ps != "" {
+		n, err := strconv.Atoi(ps)
+		if err != nil || n < 1 {
+			return filter, fmt.Errorf("invalid page_size %q", ps)
+		}
+		if n > maxScrapeRunPageSize {
+			n = maxScrapeRunPageSize
+		}
+		filter.PageSize = n
+	}
+
+	return filter, nil
+}
+
+// GetScrapeRun retrieves a single scrape run by ID.
+// GET /admin/scrape-runs/{id}
+func (h *Handler) GetScrapeRun(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		h.writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	idStr := strings.TrimPrefix(r.URL.Path, "/admin/scrape-runs/")
+	if idStr == "" {
+		h.writeError(w, http.StatusBadRequest, "scrape run ID is required")
+		return
+	}
+
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		h.writeError(w, http.StatusBadRequest, "invalid scrape run ID format")
+		return
+	}
+
+	run, err := h.repo.GetScrapeRunByID(r.Context(), id)
+	if err == storage.ErrNotFound {
+		h.writeError(w, http.StatusNotFound, "scrape run not found")
+		return
+	}
+	if err != nil {
+		h.logger.Printf("[admin] GetScrapeRun error: %v", err)
+		h.writeError(w, http.StatusInternalServerError, "failed to get scrape run")
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, run)
+}
+
 // TriggerScrape triggers an immediate scraping run.
 // POST /admin/scrape/trigger
 func (h *Handler) TriggerScrape(w http.ResponseWriter, r *http.Request) {
@@ -116,7 +679,9 @@ func (h *Handler) TriggerScrape(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	h.scheduler.RunNow(r.Context())
+	// The run outlives the request; it stops when the scheduler shuts down.
+	h.scheduler.RunNow(context.WithoutCancel(r.Context()))
+	audit.Record(r.Context(), audit.Change{Action: "scrape.trigger", EntityType: "scheduler"})
 
 	h.writeJSON(w, http.StatusAccepted, map[string]interface{}{
 		"message": "scraping run triggered",
@@ -124,8 +689,285 @@ func (h *Handler) TriggerScrape(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
-// SearchJobs searches for jobs with filters.
+// GetSchedule returns each scraper's schedule and next run time.
+// GET /admin/schedule
+func (h *Handler) GetSchedule(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		h.writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	schedules := h.scheduler.Schedules()
+	h.writeJSON(w, http.StatusOK, map[string]interface{}{
+		"schedules": schedules,
+		"count":     len(schedules),
+	})
+}
+
+// updateScheduleRequest is the body of PUT /admin/schedule/{scraper}.
+type updateScheduleRequest struct {
+	// Schedule is a cron expression or interval; empty restores the default.
+	Schedule string `json:"schedule"`
+}
+
+// UpdateSchedule changes a scraper's schedule without a restart. The scraper
+// name is URL-escaped in the path, e.g. /admin/schedule/LinkedIn%20Jobs.
+// PUT /admin/schedule/{scraper}
+func (h *Handler) UpdateSchedule(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPut {
+		h.writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	name := strings.TrimPrefix(r.URL.Path, "/admin/schedule/")
+	if name == "" {
+		h.writeError(w, http.StatusBadRequest, "scraper name is required")
+		return
+	}
+
+	var req updateScheduleRequest
+	dec := json.NewDecoder(r.Body)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&req); err != nil {
+		h.writeError(w, http.StatusBadRequest, "invalid request body: "+err.Error())
+		return
+	}
+
+	before, _ := h.scraperStatus(name)
+	sched, err := h.scheduler.SetSchedule(name, req.Schedule)
+	if errors.Is(err, scheduler.ErrUnknownScraper) {
+		h.writeError(w, http.StatusNotFound, err.Error())
+		return
+	}
+	if err != nil {
+		h.writeError(w, http.StatusBadRequest, "invalid schedule: "+err.Error())
+		return
+	}
+	audit.Record(r.Context(), audit.Change{
+		Action: "schedule.update", EntityType: "scraper", EntityID: name,
+		Before: scheduleFields(before.ScraperSchedule),
+		After:  scheduleFields(sched),
+	})
+
+	h.writeJSON(w, http.StatusOK, sched)
+}
+
+// GetSchedulerStatus returns whether the schedule is paused, and each
+// scraper's schedule, enabled flag and next run time.
+// GET /admin/scheduler/status
+func (h *Handler) GetSchedulerStatus(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		h.writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	h.writeJSON(w, http.StatusOK, h.scheduler.Status())
+}
+
+// PauseScheduler skips scheduled runs until the schedule is resumed. The
+// runs in progress finish.
+// POST /admin/scheduler/pause
+func (h *Handler) PauseScheduler(w http.ResponseWriter, r *http.Request) {
+	h.setPaused(w, r, true)
+}
+
+// ResumeScheduler undoes PauseScheduler.
+// POST /admin/scheduler/resume
+func (h *Handler) ResumeScheduler(w http.ResponseWriter, r *http.Request) {
+	h.setPaused(w, r, false)
+}
+
+func (h *Handler) setPaused(w http.ResponseWriter, r *http.Request, paused bool) {
+	if r.Method != http.MethodPost {
+		h.writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	set, action := h.scheduler.Resume, "resume"
+	if paused {
+		set, action = h.scheduler.Pause, "pause"
+	}
+	before := h.scheduler.Status().Paused
+	if err := set(r.Context()); err != nil {
+		h.logger.Printf("[admin] %s scheduler: %v", action, err)
+		h.writeError(w, http.StatusInternalServerError, "failed to "+action+" the scheduler")
+		return
+	}
+	audit.Record(r.Context(), audit.Change{
+		Action: "scheduler." + action, EntityType: "scheduler",
+		Before: map[string]bool{"paused": before},
+		After:  map[string]bool{"paused": paused},
+	})
+
+	h.writeJSON(w, http.StatusOK, h.scheduler.Status())
+}
+
+// ScraperAction serves the routes below /admin/scrapers/{scraper}/.
+func (h *Handler) ScraperAction(w http.ResponseWriter, r *http.Request) {
+	if strings.HasSuffix(r.URL.Path, "/enabled") {
+		h.SetScraperEnabled(w, r)
+		return
+	}
+	h.DryRunScraper(w, r)
+}
+
+// setScraperEnabledRequest is the body of PUT /admin/scrapers/{scraper}/enabled.
+type setScraperEnabledRequest struct {
+	Enabled *bool `json:"enabled"`
+}
+
+// SetScraperEnabled enables or disables a scraper without a restart. The
+// scraper name is URL-escaped in the path.
+// PUT /admin/scrapers/{scraper}/enabled
+func (h *Handler) SetScraperEnabled(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPut {
+		h.writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	name, _ := strings.CutSuffix(strings.TrimPrefix(r.URL.Path, "/admin/scrapers/"), "/enabled")
+	if name == "" || strings.Contains(name, "/") {
+		h.writeError(w, http.StatusNotFound, "not found")
+		return
+	}
+
+	var req setScraperEnabledRequest
+	dec := json.NewDecoder(r.Body)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&req); err != nil {
+		h.writeError(w, http.StatusBadRequest, "invalid request body: "+err.Error())
+		return
+	}
+	if req.Enabled == nil {
+		h.writeError(w, http.StatusBadRequest, "enabled is required")
+		return
+	}
+
+	before, _ := h.scraperStatus(name)
+	status, err := h.scheduler.SetScraperEnabled(r.Context(), name, *req.Enabled)
+	switch {
+	case errors.Is(err, scheduler.ErrUnknownScraper):
+		h.writeError(w, http.StatusNotFound, err.Error())
+		return
+	case err != nil:
+		h.logger.Printf("[admin] set %s enabled: %v", name, err)
+		h.writeError(w, http.StatusInternalServerError, "failed to update the scraper")
+		return
+	}
+	audit.Record(r.Context(), audit.Change{
+		Action: "scraper.set_enabled", EntityType: "scraper", EntityID: name,
+		Before: map[string]bool{"enabled": before.Enabled},
+		After:  map[string]bool{"enabled": status.Enabled},
+	})
+
+	h.writeJSON(w, http.StatusOK, status)
+}
+
+// scraperStatus returns the schedule and enabled flag of the named scraper.
+func (h *Handler) scraperStatus(name string) (scheduler.ScraperStatus, bool) {
+	for _, s := range h.scheduler.Status().Scrapers {
+		if s.Scraper == name {
+			return s, true
+		}
+	}
+	return scheduler.ScraperStatus{}, false
+}
+
+// scheduleFields holds the parts of a schedule an admin changes, leaving
+// out the next run time that moves by itself.
+func scheduleFields(s scheduler.ScraperSchedule) map[string]interface{} {
+	return map[string]interface{}{"schedule": s.Schedule, "is_default": s.IsDefault}
+}
+
+// GetScraperHealth returns each scraper's last success, consecutive
+// failures, trailing average of jobs found and the status derived from them.
+// GET /admin/scrapers/health
+func (h *Handler) GetScraperHealth(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		h.writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	health, err := h.scheduler.ScraperHealth(r.Context())
+	if err != nil {
+		h.logger.Printf("[admin] scraper health: %v", err)
+		h.writeError(w, http.StatusInternalServerError, "failed to get scraper health")
+		return
+	}
+	if health == nil {
+		health = []model.ScraperHealth{}
+	}
+
+	h.writeJSON(w, http.StatusOK, map[string]interface{}{
+		"scrapers": health,
+		"count":    len(health),
+	})
+}
+
+// maxDryRunPages caps the limit of a dry run.
+const maxDryRunPages = 20
+
+// DryRunScraper runs a scraper without storing anything and returns the jobs
+// it found, each marked new, changed, unchanged or duplicate. limit caps the
+// pages fetched; q, location and remote replace the configured queries.
+// POST /admin/scrapers/{scraper}/dry-run?limit=3
+func (h *Handler) DryRunScraper(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		h.writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	name, ok := strings.CutSuffix(strings.TrimPrefix(r.URL.Path, "/admin/scrapers/"), "/dry-run")
+	if !ok || name == "" || strings.Contains(name, "/") {
+		h.writeError(w, http.StatusNotFound, "not found")
+		return
+	}
+	// A dry run stores nothing, so there is nothing to audit.
+	audit.Skip(r.Context())
+
+	q := r.URL.Query()
+	pages := scheduler.DefaultDryRunPages
+	if v := q.Get("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 || n > maxDryRunPages {
+			h.writeError(w, http.StatusBadRequest, fmt.Sprintf("limit must be between 1 and %d", maxDryRunPages))
+			return
+		}
+		pages = n
+	}
+	var queries []scheduler.SearchQuery
+	if q.Has("q") || q.Has("location") || q.Has("remote") {
+		var remote bool
+		if v := q.Get("remote"); v != "" {
+			var err error
+			if remote, err = strconv.ParseBool(v); err != nil {
+				h.writeError(w, http.StatusBadRequest, "remote must be true or false")
+				return
+			}
+		}
+		queries = []scheduler.SearchQuery{{Query: q.Get("q"), Location: q.Get("location"), Remote: remote}}
+	}
+
+	report, err := h.scheduler.DryRun(r.Context(), name, queries, pages)
+	switch {
+	case errors.Is(err, scheduler.ErrUnknownScraper):
+		h.writeError(w, http.StatusNotFound, err.Error())
+		return
+	case errors.Is(err, scheduler.ErrScraperBusy):
+		h.writeError(w, http.StatusConflict, err.Error())
+		return
+	case err != nil:
+		h.logger.Printf("[admin] dry run %s: %v", name, err)
+		h.writeError(w, http.StatusInternalServerError, "dry run failed")
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, report)
+}
+
+// SearchJobs searches for jobs with filters, newest first. The next_cursor
+// of a page may be passed as cursor instead of page to fetch the next one.
 // GET /admin/jobs?q=engineer&location=remote&page=1&page_size=20
+// GET /admin/jobs?q=engineer&location=remote&page_size=20&cursor=...
 func (h *Handler) SearchJobs(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
 		h.writeError(w, http.StatusMethodNotAllowed, "method not allowed")
@@ -141,17 +983,27 @@ func (h *Handler) SearchJobs(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Parse page
+	filter.Page, filter.PageSize = 1, 20
 	if p := q.Get("page"); p != "" {
-		if n, err := strconv.Atoi(p); err == nil {
+		if n, err := strconv.Atoi(p); err == nil && n > 0 {
 			filter.Page = n
 		}
 	}
 	if ps := q.Get("page_size"); ps != "" {
-		if n, err := strconv.Atoi(ps); err == nil {
+		if n, err := strconv.Atoi(ps); err == nil && n > 0 {
 			filter.PageSize = n
 		}
 	}
 
+	if v := q.Get("company_id"); v != "" {
+		id, err := uuid.Parse(v)
+		if err != nil {
+			h.writeError(w, http.StatusBadRequest, "invalid company ID format")
+			return
+		}
+		filter.CompanyID = &id
+	}
+
 	// Parse location type
 	if lt := q.Get("location_type"); lt != "" {
 		filter.LocationTypes = []model.WorkLocationType{model.WorkLocationType(lt)}
@@ -174,19 +1026,40 @@ func (h *Handler) SearchJobs(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	jobs, total, err := h.repo.SearchJobs(r.Context(), filter)
+	if token := q.Get("cursor"); token != "" {
+		if q.Get("page") != "" {
+			h.writeError(w, http.StatusBadRequest, "cursor cannot be combined with page")
+			return
+		}
+		after, err := h.cursors.Decode(token)
+		if err != nil {
+			h.writeError(w, http.StatusBadRequest, "invalid cursor")
+			return
+		}
+		filter.After = &after
+	}
+
+	jobs, total, next, err := h.repo.SearchJobs(r.Context(), filter)
 	if err != nil {
 		h.logger.Printf("[admin] SearchJobs error: %v", err)
 		h.writeError(w, http.StatusInternalServerError, "failed to search jobs")
 		return
 	}
+	if jobs == nil {
+		jobs = []model.Job{}
+	}
 
-	h.writeJSON(w, http.StatusOK, map[string]interface{}{
-		"jobs":      jobs,
-		"total":     total,
-		"page":      filter.Page,
-		"page_size": filter.PageSize,
-	})
+	var nextCursor string
+	if next != nil {
+		nextCursor = h.cursors.Encode(*next)
+	}
+	page := pagination.FromPage(total, filter.Page, filter.PageSize)
+	if filter.After != nil {
+		page = pagination.FromCursor(total, filter.PageSize, nextCursor)
+	}
+	page.NextCursor = nextCursor
+	pagination.SetPageLinks(w.Header(), r.URL, page)
+	h.writeJSON(w, http.StatusOK, pagination.Envelope{Data: jobs, Pagination: page})
 }
 
 // GetJob retrieves a single job by ID.
@@ -225,14 +1098,78 @@ func (h *Handler) GetJob(w http.ResponseWriter, r *http.Request) {
 	h.writeJSON(w, http.StatusOK, job)
 }
 
-// GetCareerPages returns all configured company career pages.
-// GET /admin/career-pages
-func (h *Handler) GetCareerPages(w http.ResponseWriter, r *http.Request) {
+// maxDedupLogPageSize caps page_size for GET /admin/dedup-log.
+const maxDedupLogPageSize = 100
+
+// ListDedupLog returns the fuzzy dedup merges, newest first, so that false
+// positives can be audited. job_id limits the list to one canonical job.
+// GET /admin/dedup-log?job_id={id}&page=1&page_size=20
+func (h *Handler) ListDedupLog(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
 		h.writeError(w, http.StatusMethodNotAllowed, "method not allowed")
 		return
 	}
 
+	q := r.URL.Query()
+	var jobID *uuid.UUID
+	if v := q.Get("job_id"); v != "" {
+		id, err := uuid.Parse(v)
+		if err != nil {
+			h.writeError(w, http.StatusBadRequest, "invalid job ID format")
+			return
+		}
+		jobID = &id
+	}
+
+	pageNum, pageSize := 1, 20
+	if p := q.Get("page"); p != "" {
+		n, err := strconv.Atoi(p)
+		if err != nil || n < 1 {
+			h.writeError(w, http.StatusBadRequest, fmt.Sprintf("invalid page %q", p))
+			return
+		}
+		pageNum = n
+	}
+	if ps := q.Get("page_size"); ps != "" {
+		n, err := strconv.Atoi(ps)
+		if err != nil || n < 1 {
+			h.writeError(w, http.StatusBadRequest, fmt.Sprintf("invalid page_size %q", ps))
+			return
+		}
+		pageSize = min(n, maxDedupLogPageSize)
+	}
+
+	entries, total, err := h.repo.ListDedupLog(r.Context(), jobID, pageNum, pageSize)
+	if err != nil {
+		h.logger.Printf("[admin] ListDedupLog error: %v", err)
+		h.writeError(w, http.StatusInternalServerError, "failed to get dedup log")
+		return
+	}
+	if entries == nil {
+		entries = []model.DedupLogEntry{}
+	}
+
+	page := pagination.FromPage(total, pageNum, pageSize)
+	pagination.SetPageLinks(w.Header(), r.URL, page)
+	h.writeJSON(w, http.StatusOK, pagination.Envelope{Data: entries, Pagination: page})
+}
+
+// CareerPages dispatches /admin/career-pages by method.
+func (h *Handler) CareerPages(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		h.GetCareerPages(w, r)
+	case http.MethodPost:
+		h.CreateCareerPage(w, r)
+	default:
+		h.writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+	}
+}
+
+// GetCareerPages returns all configured company career pages.
+// GET /admin/career-pages
+func (h *Handler) GetCareerPages(w http.ResponseWriter, r *http.Request) {
+
 	pages, err := h.repo.GetCareerPages(r.Context())
 	if err != nil {
 		h.logger.Printf("[admin] GetCareerPages error: %v", err)
@@ -246,6 +1183,131 @@ func (h *Handler) GetCareerPages(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+// createCareerPageRequest is the body of POST /admin/career-pages.
+// CompanyID links the page to an existing company, whose name is the
+// default company_name.
+type createCareerPageRequest struct {
+	CompanyID     *uuid.UUID                 `json:"company_id,omitempty"`
+	CompanyName   string                     `json:"company_name"`
+	CareerPageURL string                     `json:"career_page_url"`
+	SourceType    model.CareerPageSourceType `json:"source_type"`
+	Selectors     json.RawMessage            `json:"selectors"`
+}
+
+// CreateCareerPage stores a new career page and registers its scraper with
+// the scheduler, so it is scraped from the next cycle without a restart.
+// Without a company_id the page's company is resolved from its name and
+// URL like a scraped company name.
+// POST /admin/career-pages
+func (h *Handler) CreateCareerPage(w http.ResponseWriter, r *http.Request) {
+	var req createCareerPageRequest
+	dec := json.NewDecoder(r.Body)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&req); err != nil {
+		h.writeError(w, http.StatusBadRequest, "invalid request body: "+err.Error())
+		return
+	}
+
+	if req.CompanyID != nil {
+		company, err := h.repo.GetCompany(r.Context(), *req.CompanyID)
+		if errors.Is(err, storage.ErrNotFound) {
+			h.writeError(w, http.StatusBadRequest, "unknown company_id")
+			return
+		}
+		if err != nil {
+			h.logger.Printf("[admin] CreateCareerPage error: %v", err)
+			h.writeError(w, http.StatusInternalServerError, "failed to create career page")
+			return
+		}
+		if strings.TrimSpace(req.CompanyName) == "" {
+			req.CompanyName = company.Name
+		}
+	}
+
+	page, err := newCareerPage(req)
+	if err != nil {
+		h.writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Build the scraper first so that an unusable page is never stored.
+	sc, err := scraper.NewScraperForCareerPage(page, h.logger)
+	if err != nil {
+		h.writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if page.CompanyID == nil {
+		company, err := h.repo.ResolveCompany(r.Context(), page.CompanyName, page.CareerPageURL)
+		if err != nil {
+			h.logger.Printf("[admin] CreateCareerPage error: %v", err)
+			h.writeError(w, http.StatusInternalServerError, "failed to create career page")
+			return
+		}
+		page.CompanyID = &company.ID
+	}
+
+	if err := h.repo.CreateCareerPage(r.Context(), &page); err != nil {
+		if errors.Is(err, storage.ErrDuplicate) {
+			h.writeError(w, http.StatusConflict, "career page URL is already configured")
+			return
+		}
+		h.logger.Printf("[admin] CreateCareerPage error: %v", err)
+		h.writeError(w, http.StatusInternalServerError, "failed to create career page")
+		return
+	}
+
+	h.scheduler.AddScraper(sc)
+	h.logger.Printf("[admin] registered %s", sc.Name())
+	audit.Record(r.Context(), audit.Change{
+		Action: "career_page.create", EntityType: "career_page", EntityID: page.ID.String(), After: page,
+	})
+
+	h.writeJSON(w, http.StatusCreated, map[string]interface{}{
+		"career_page": page,
+		"scraper":     sc.Name(),
+	})
+}
+
+// newCareerPage validates a create request and converts it to a career page.
+func newCareerPage(req createCareerPageRequest) (model.CompanyCareerPage, error) {
+	page := model.CompanyCareerPage{
+		CompanyID:     req.CompanyID,
+		CompanyName:   strings.TrimSpace(req.CompanyName),
+		CareerPageURL: strings.TrimSpace(req.CareerPageURL),
+		SourceType:    req.SourceType,
+		Selectors:     []byte(req.Selectors),
+		IsEnabled:     true,
+	}
+
+	if page.CompanyName == "" {
+		return page, fmt.Errorf("company_name is required")
+	}
+	u, err := url.Parse(page.CareerPageURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return page, fmt.Errorf("career_page_url must be an absolute http(s) URL")
+	}
+
+	switch page.SourceType {
+	case "":
+		page.SourceType = model.CareerPageHTML
+	case model.CareerPageHTML, model.CareerPageGreenhouse, model.CareerPageLever, model.CareerPageFeed:
+	default:
+		return page, fmt.Errorf("unsupported source_type %q", page.SourceType)
+	}
+
+	if len(page.Selectors) == 0 || string(page.Selectors) == "null" {
+		page.Selectors = []byte("{}")
+	} else {
+		var obj map[string]interface{}
+		if err := json.Unmarshal(page.Selectors, &obj); err != nil {
+			return page, fmt.Errorf("selectors must be a JSON object")
+		}
+	}
+
+	return page, nil
+}
+
 // Health returns the health status of the service.
 // GET /admin/health
 func (h *Handler) Health(w http.ResponseWriter, r *http.Request) {
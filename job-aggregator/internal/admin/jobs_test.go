@@ -0,0 +1,36 @@
+package admin
+
+import (
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/learnbot/job-aggregator/internal/cursor"
+	"github.com/learnbot/job-aggregator/internal/model"
+)
+
+func TestSearchJobs_RejectsInvalidCursors(t *testing.T) {
+	h := NewHandler(nil, nil, log.New(io.Discard, "", 0))
+	h.SetCursorCodec(cursor.NewCodec([]byte("secret")))
+	mux := http.NewServeMux()
+	h.RegisterRoutes(mux)
+
+	valid := cursor.NewCodec([]byte("secret")).Encode(model.JobCursor{PostedAt: time.Now(), ID: uuid.New()})
+	foreign := cursor.NewCodec([]byte("other")).Encode(model.JobCursor{PostedAt: time.Now(), ID: uuid.New()})
+	for _, query := range []string{
+		"cursor=" + foreign,
+		"cursor=" + valid[:len(valid)-1],
+		"cursor=" + valid + "&page=2",
+	} {
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/admin/jobs?"+query, nil))
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("%s: expected 400, got %d", query, w.Code)
+		}
+	}
+}
@@ -0,0 +1,247 @@
+package admin
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/learnbot/job-aggregator/internal/model"
+	"github.com/learnbot/job-aggregator/internal/scheduler"
+	"github.com/learnbot/job-aggregator/internal/scraper"
+	"github.com/learnbot/job-aggregator/internal/storage"
+)
+
+type stubScraper struct{ name string }
+
+func (s stubScraper) Source() model.JobSource { return model.SourceOther }
+func (s stubScraper) Name() string            { return s.name }
+func (s stubScraper) Scrape(context.Context, model.SearchParams, chan<- *model.ScrapedJob) error {
+	return nil
+}
+
+func newScheduleTestMux() *http.ServeMux {
+	logger := log.New(io.Discard, "", 0)
+	sched := scheduler.New(storage.NewPostgresRepository(nil), []scraper.Scraper{stubScraper{"LinkedIn Jobs"}}, scheduler.DefaultConfig(), logger)
+	mux := http.NewServeMux()
+	NewHandler(nil, sched, logger).RegisterRoutes(mux)
+	return mux
+}
+
+func TestUpdateSchedule(t *testing.T) {
+	mux := newScheduleTestMux()
+
+	put := func(path, body string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, httptest.NewRequest(http.MethodPut, path, strings.NewReader(body)))
+		return w
+	}
+
+	w := put("/admin/schedule/LinkedIn%20Jobs", `{"schedule":"0 2 * * 1"}`)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var got scheduler.ScraperSchedule
+	if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil || got.Schedule != "0 2 * * 1" || got.IsDefault {
+		t.Errorf("unexpected response %s (%v)", w.Body.String(), err)
+	}
+
+	w = put("/admin/schedule/LinkedIn%20Jobs", `{"schedule":"0 2 * *"}`)
+	if w.Code != http.StatusBadRequest || !strings.Contains(w.Body.String(), "must have 5 fields") {
+		t.Errorf("expected a 400 describing the cron error, got %d: %s", w.Code, w.Body.String())
+	}
+
+	if w := put("/admin/schedule/Glassdoor", `{"schedule":"@daily"}`); w.Code != http.StatusNotFound {
+		t.Errorf("expected 404 for an unknown scraper, got %d", w.Code)
+	}
+	if w := put("/admin/schedule/LinkedIn%20Jobs", `{"cron":"@daily"}`); w.Code != http.StatusBadRequest {
+		t.Errorf("expected 400 for an unknown field, got %d", w.Code)
+	}
+
+	// The invalid update left the previous schedule in place.
+	w = httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/admin/schedule", nil))
+	var list struct {
+		Schedules []scheduler.ScraperSchedule `json:"schedules"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &list); err != nil || len(list.Schedules) != 1 {
+		t.Fatalf("unexpected schedule list %s (%v)", w.Body.String(), err)
+	}
+	if s := list.Schedules[0]; s.Scraper != "LinkedIn Jobs" || s.Schedule != "0 2 * * 1" || s.NextRun.IsZero() {
+		t.Errorf("unexpected schedule %+v", s)
+	}
+}
+
+func TestDryRunScraper(t *testing.T) {
+	mux := newScheduleTestMux()
+
+	post := func(path string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, httptest.NewRequest(http.MethodPost, path, nil))
+		return w
+	}
+
+	w := post("/admin/scrapers/LinkedIn%20Jobs/dry-run?limit=2&q=golang")
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var report model.DryRunReport
+	if err := json.Unmarshal(w.Body.Bytes(), &report); err != nil || report.Scraper != "LinkedIn Jobs" || report.PageLimit != 2 {
+		t.Errorf("unexpected response %s (%v)", w.Body.String(), err)
+	}
+
+	if w := post("/admin/scrapers/Glassdoor/dry-run"); w.Code != http.StatusNotFound {
+		t.Errorf("expected 404 for an unknown scraper, got %d", w.Code)
+	}
+	if w := post("/admin/scrapers/LinkedIn%20Jobs"); w.Code != http.StatusNotFound {
+		t.Errorf("expected 404 without /dry-run, got %d", w.Code)
+	}
+	for _, query := range []string{"limit=0", "limit=21", "limit=all", "remote=maybe"} {
+		if w := post("/admin/scrapers/LinkedIn%20Jobs/dry-run?" + query); w.Code != http.StatusBadRequest {
+			t.Errorf("%s: expected 400, got %d", query, w.Code)
+		}
+	}
+	w = httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/admin/scrapers/LinkedIn%20Jobs/dry-run", nil))
+	if w.Code != http.StatusMethodNotAllowed {
+		t.Errorf("expected 405 for GET, got %d", w.Code)
+	}
+}
+
+func TestGetScraperHealth(t *testing.T) {
+	db, err := sql.Open(storage.SQLiteDriver, storage.SQLiteDSN(":memory:"))
+	if err != nil {
+		t.Fatalf("open sqlite: %v", err)
+	}
+	defer db.Close()
+	repo := storage.NewSQLiteRepository(db)
+	logger := log.New(io.Discard, "", 0)
+	if err := repo.Migrate(context.Background(), logger); err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+	for _, status := range []model.ScrapeStatus{model.ScrapeStatusFailed, model.ScrapeStatusFailed, model.ScrapeStatusFailed} {
+		run, err := repo.CreateScrapeRun(context.Background(), model.SourceOther, "Lever: Acme", "", "")
+		if err != nil {
+			t.Fatalf("CreateScrapeRun: %v", err)
+		}
+		if err := repo.UpdateScrapeRun(context.Background(), run.ID, status, model.ScrapeRun{}); err != nil {
+			t.Fatalf("UpdateScrapeRun: %v", err)
+		}
+	}
+	if _, err := repo.RefreshScraperHealth(context.Background(), "Lever: Acme"); err != nil {
+		t.Fatalf("RefreshScraperHealth: %v", err)
+	}
+
+	scrapers := []scraper.Scraper{stubScraper{"Lever: Acme"}, stubScraper{"LinkedIn Jobs"}}
+	mux := http.NewServeMux()
+	NewHandler(repo, scheduler.New(repo, scrapers, scheduler.DefaultConfig(), logger), logger).RegisterRoutes(mux)
+
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/admin/scrapers/health", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var got struct {
+		Scrapers []model.ScraperHealth `json:"scrapers"`
+		Count    int                   `json:"count"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil || got.Count != 2 {
+		t.Fatalf("unexpected response %s (%v)", w.Body.String(), err)
+	}
+	if h := got.Scrapers[0]; h.ScraperName != "Lever: Acme" || h.Status != model.ScraperFailing || h.ConsecutiveFailures != 3 {
+		t.Errorf("unexpected health %+v", h)
+	}
+	if h := got.Scrapers[1]; h.ScraperName != "LinkedIn Jobs" || h.Status != model.ScraperHealthy || h.LastRunAt != nil {
+		t.Errorf("unexpected health without runs %+v", h)
+	}
+
+	w = httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/admin/scrapers/health", nil))
+	if w.Code != http.StatusMethodNotAllowed {
+		t.Errorf("expected 405 for POST, got %d", w.Code)
+	}
+}
+
+func TestSchedulerControls(t *testing.T) {
+	db, err := sql.Open(storage.SQLiteDriver, storage.SQLiteDSN(":memory:"))
+	if err != nil {
+		t.Fatalf("open sqlite: %v", err)
+	}
+	defer db.Close()
+	repo := storage.NewSQLiteRepository(db)
+	logger := log.New(io.Discard, "", 0)
+	if err := repo.Migrate(context.Background(), logger); err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+	scrapers := []scraper.Scraper{stubScraper{"Indeed"}, stubScraper{"LinkedIn Jobs"}}
+	mux := http.NewServeMux()
+	NewHandler(repo, scheduler.New(repo, scrapers, scheduler.DefaultConfig(), logger), logger).RegisterRoutes(mux)
+
+	do := func(method, path, body string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, httptest.NewRequest(method, path, strings.NewReader(body)))
+		return w
+	}
+	status := func(w *httptest.ResponseRecorder) scheduler.SchedulerStatus {
+		t.Helper()
+		if w.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+		}
+		var st scheduler.SchedulerStatus
+		if err := json.Unmarshal(w.Body.Bytes(), &st); err != nil {
+			t.Fatalf("unexpected response %s (%v)", w.Body.String(), err)
+		}
+		return st
+	}
+
+	if st := status(do(http.MethodPost, "/admin/scheduler/pause", "")); !st.Paused {
+		t.Errorf("expected the schedule paused, got %+v", st)
+	}
+	w := do(http.MethodPut, "/admin/scrapers/LinkedIn%20Jobs/enabled", `{"enabled":false}`)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var sc scheduler.ScraperStatus
+	if err := json.Unmarshal(w.Body.Bytes(), &sc); err != nil || sc.Scraper != "LinkedIn Jobs" || sc.Enabled || sc.NextRun.IsZero() {
+		t.Errorf("unexpected response %s (%v)", w.Body.String(), err)
+	}
+
+	st := status(do(http.MethodGet, "/admin/scheduler/status", ""))
+	if !st.Paused || len(st.Scrapers) != 2 || st.NextRun.IsZero() {
+		t.Fatalf("unexpected status %+v", st)
+	}
+	if in, li := st.Scrapers[0], st.Scrapers[1]; !in.Enabled || li.Enabled || li.Schedule != scheduler.DefaultSchedule {
+		t.Errorf("unexpected scraper status %+v, %+v", in, li)
+	}
+
+	// The flags are stored.
+	c, err := repo.GetSchedulerControls(context.Background())
+	if err != nil || !c.Paused || c.ScraperEnabled["LinkedIn Jobs"] {
+		t.Errorf("unexpected stored controls %+v (%v)", c, err)
+	}
+
+	if st := status(do(http.MethodPost, "/admin/scheduler/resume", "")); st.Paused {
+		t.Errorf("expected the schedule resumed, got %+v", st)
+	}
+
+	for _, tt := range []struct {
+		method, path, body string
+		want               int
+	}{
+		{http.MethodPut, "/admin/scrapers/Glassdoor/enabled", `{"enabled":true}`, http.StatusNotFound},
+		{http.MethodPut, "/admin/scrapers/Indeed/enabled", `{}`, http.StatusBadRequest},
+		{http.MethodPut, "/admin/scrapers/Indeed/enabled", `{"enabled":"no"}`, http.StatusBadRequest},
+		{http.MethodPost, "/admin/scrapers/Indeed/enabled", `{"enabled":true}`, http.StatusMethodNotAllowed},
+		{http.MethodGet, "/admin/scheduler/pause", "", http.StatusMethodNotAllowed},
+		{http.MethodPost, "/admin/scheduler/status", "", http.StatusMethodNotAllowed},
+	} {
+		if w := do(tt.method, tt.path, tt.body); w.Code != tt.want {
+			t.Errorf("%s %s: expected %d, got %d: %s", tt.method, tt.path, tt.want, w.Code, w.Body.String())
+		}
+	}
+}
@@ -0,0 +1,130 @@
+package admin
+
+import (
+	"encoding/json"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/learnbot/job-aggregator/internal/model"
+	"github.com/learnbot/job-aggregator/internal/storage"
+	"github.com/learnbot/shared/pagination"
+)
+
+func TestParseScrapeRunFilter(t *testing.T) {
+	q := url.Values{
+		"scraper":   {" LinkedIn Jobs "},
+		"status":    {"failed"},
+		"from":      {"2025-01-01"},
+		"to":        {"2025-01-31"},
+		"page":      {"3"},
+		"page_size": {"500"},
+	}
+	f, err := parseScrapeRunFilter(q)
+	if err != nil {
+		t.Fatalf("parseScrapeRunFilter: %v", err)
+	}
+	if f.ScraperName != "LinkedIn Jobs" || f.Status != model.ScrapeStatusFailed {
+		t.Errorf("unexpected scraper/status: %q %q", f.ScraperName, f.Status)
+	}
+	if !f.StartedAfter.Equal(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("unexpected from: %v", f.StartedAfter)
+	}
+	// A date-only "to" includes the whole day.
+	if !f.StartedBefore.Equal(time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("unexpected to: %v", f.StartedBefore)
+	}
+	if f.Page != 3 || f.PageSize != maxScrapeRunPageSize {
+		t.Errorf("unexpected paging: page=%d size=%d", f.Page, f.PageSize)
+	}
+
+	f, err = parseScrapeRunFilter(url.Values{"to": {"2025-01-31T12:00:00Z"}})
+	if err != nil || !f.StartedBefore.Equal(time.Date(2025, 1, 31, 12, 0, 0, 0, time.UTC)) {
+		t.Errorf("RFC 3339 to should be used as-is, got %v (%v)", f.StartedBefore, err)
+	}
+
+	if f, err := parseScrapeRunFilter(url.Values{}); err != nil || f.Page != 1 || f.PageSize != 20 || f.StartedAfter != nil {
+		t.Errorf("unexpected defaults: %+v (%v)", f, err)
+	}
+
+	for _, bad := range []url.Values{
+		{"status": {"done"}},
+		{"from": {"yesterday"}},
+		{"from": {"2025-02-01"}, "to": {"2025-01-01"}},
+		{"page": {"0"}},
+		{"page_size": {"many"}},
+	} {
+		if _, err := parseScrapeRunFilter(bad); err == nil {
+			t.Errorf("expected an error for %v", bad)
+		}
+	}
+}
+
+func TestGetScrapeRun_InvalidRequests(t *testing.T) {
+	h := NewHandler(nil, nil, log.New(io.Discard, "", 0))
+	mux := http.NewServeMux()
+	h.RegisterRoutes(mux)
+
+	tests := []struct {
+		method, path string
+		want         int
+	}{
+		{http.MethodGet, "/admin/scrape-runs/not-a-uuid", http.StatusBadRequest},
+		{http.MethodGet, "/admin/scrape-runs/", http.StatusBadRequest},
+		{http.MethodDelete, "/admin/scrape-runs/6f1c2a8e-0d4b-4c1e-9a57-3b0f6f1d2c11", http.StatusMethodNotAllowed},
+		{http.MethodGet, "/admin/scrape-runs?status=done", http.StatusBadRequest},
+		{http.MethodPost, "/admin/scrape-runs", http.StatusMethodNotAllowed},
+	}
+	for _, tt := range tests {
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, httptest.NewRequest(tt.method, tt.path, nil))
+		if w.Code != tt.want {
+			t.Errorf("%s %s: expected %d, got %d", tt.method, tt.path, tt.want, w.Code)
+		}
+	}
+}
+
+func TestListScrapeRuns_Pagination(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock: %v", err)
+	}
+	defer db.Close()
+
+	h := NewHandler(storage.NewPostgresRepository(db), nil, log.New(io.Discard, "", 0))
+	mux := http.NewServeMux()
+	h.RegisterRoutes(mux)
+
+	// A page past the end is empty, and links back to the last page.
+	mock.ExpectQuery(`SELECT COUNT`).WithArgs(model.ScrapeStatusFailed).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(35))
+	mock.ExpectQuery(`FROM scrape_runs`).WithArgs(model.ScrapeStatusFailed, 10, 80).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/admin/scrape-runs?status=failed&page=9&page_size=10", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var body struct {
+		Data       []json.RawMessage     `json:"data"`
+		Pagination pagination.Pagination `json:"pagination"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if body.Data == nil || len(body.Data) != 0 || body.Pagination.Total != 35 || body.Pagination.HasMore {
+		t.Errorf("unexpected page: %s", w.Body.String())
+	}
+	if got, want := w.Header().Get("Link"), `<?page=4&page_size=10&status=failed>; rel="prev"`; got != want {
+		t.Errorf("Link = %s, want %s", got, want)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,41 @@
+package admin
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/learnbot/shared/audit"
+)
+
+// EraseUser deletes everything the job aggregator stores about a user:
+// their saved jobs. The API gateway calls it when the user deletes their
+// account, and again until it succeeds, so erasing a user with no data
+// succeeds too.
+// DELETE /admin/users/{id}
+func (h *Handler) EraseUser(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodDelete {
+		h.writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	id, err := uuid.Parse(strings.TrimPrefix(r.URL.Path, "/admin/users/"))
+	if err != nil {
+		h.writeError(w, http.StatusBadRequest, "invalid user ID format")
+		return
+	}
+
+	n, err := h.repo.DeleteUserSavedJobs(r.Context(), id)
+	if err != nil {
+		h.logger.Printf("[admin] EraseUser error: %v", err)
+		h.writeError(w, http.StatusInternalServerError, "failed to erase user data")
+		return
+	}
+	h.logger.Printf("[admin] erased user %s: %d saved jobs", id, n)
+	audit.Record(r.Context(), audit.Change{
+		Action: "user.erase", EntityType: "user", EntityID: id.String(),
+		After: map[string]int{"saved_jobs_deleted": n},
+	})
+
+	h.writeJSON(w, http.StatusOK, map[string]interface{}{"user_id": id, "saved_jobs_deleted": n})
+}
@@ -0,0 +1,288 @@
+package admin
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+
+	"github.com/learnbot/job-aggregator/internal/model"
+	"github.com/learnbot/job-aggregator/internal/storage"
+	"github.com/learnbot/shared/audit"
+)
+
+// webhookStore stores webhook subscriptions and deliveries. It is
+// implemented by *storage.PostgresRepository; with other storage backends
+// the webhook routes answer 501 Not Implemented.
+type webhookStore interface {
+	ListWebhookSubscriptions(ctx context.Context) ([]model.WebhookSubscription, error)
+	CreateWebhookSubscription(ctx context.Context, sub *model.WebhookSubscription) error
+	DeleteWebhookSubscription(ctx context.Context, id uuid.UUID) error
+	ListWebhookDeliveries(ctx context.Context, filter model.WebhookDeliveryFilter) ([]model.WebhookDelivery, error)
+}
+
+// requireWebhooks writes 501 Not Implemented and returns false if the
+// storage backend has no webhook storage.
+func (h *Handler) requireWebhooks(w http.ResponseWriter) bool {
+	if h.webhooks == nil {
+		h.writeError(w, http.StatusNotImplemented, "webhooks are not supported by this storage backend")
+		return false
+	}
+	return true
+}
+
+// Webhooks dispatches /admin/webhooks by method.
+func (h *Handler) Webhooks(w http.ResponseWriter, r *http.Request) {
+	if !h.requireWebhooks(w) {
+		return
+	}
+	switch r.Method {
+	case http.MethodGet:
+		h.ListWebhooks(w, r)
+	case http.MethodPost:
+		h.CreateWebhook(w, r)
+	default:
+		h.writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+	}
+}
+
+// ListWebhooks returns all webhook subscriptions. Secrets are not included.
+// GET /admin/webhooks
+func (h *Handler) ListWebhooks(w http.ResponseWriter, r *http.Request) {
+	subs, err := h.webhooks.ListWebhookSubscriptions(r.Context())
+	if err != nil {
+		h.logger.Printf("[admin] ListWebhooks error: %v", err)
+		h.writeError(w, http.StatusInternalServerError, "failed to list webhooks")
+		return
+	}
+	if subs == nil {
+		subs = []model.WebhookSubscription{}
+	}
+
+	h.writeJSON(w, http.StatusOK, map[string]interface{}{
+		"webhooks": subs,
+		"count":    len(subs),
+	})
+}
+
+// createWebhookRequest is the body of POST /admin/webhooks.
+type createWebhookRequest struct {
+	Name       string   `json:"name"`
+	URL        string   `json:"url"`
+	Secret     string   `json:"secret"`
+	Skills     []string `json:"skills"`
+	Keywords   []string `json:"keywords"`
+	Location   string   `json:"location"`
+	RemoteOnly bool     `json:"remote_only"`
+}
+
+// CreateWebhook stores a new subscription. When no secret is given one is
+// generated; either way the secret is only returned in this response.
+// POST /admin/webhooks
+func (h *Handler) CreateWebhook(w http.ResponseWriter, r *http.Request) {
+	var req createWebhookRequest
+	dec := json.NewDecoder(r.Body)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&req); err != nil {
+		h.writeError(w, http.StatusBadRequest, "invalid request body: "+err.Error())
+		return
+	}
+
+	sub, err := newWebhookSubscription(req)
+	if err != nil {
+		h.writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if sub.Secret == "" {
+		if sub.Secret, err = generateSecret(); err != nil {
+			h.logger.Printf("[admin] CreateWebhook error: %v", err)
+			h.writeError(w, http.StatusInternalServerError, "failed to create webhook")
+			return
+		}
+	}
+
+	if err := h.webhooks.CreateWebhookSubscription(r.Context(), &sub); err != nil {
+		h.logger.Printf("[admin] CreateWebhook error: %v", err)
+		h.writeError(w, http.StatusInternalServerError, "failed to create webhook")
+		return
+	}
+	h.logger.Printf("[admin] created webhook %s (%s)", sub.ID, sub.Name)
+	audit.Record(r.Context(), audit.Change{
+		Action: "webhook.create", EntityType: "webhook", EntityID: sub.ID.String(), After: sub,
+	})
+
+	h.writeJSON(w, http.StatusCreated, map[string]interface{}{
+		"webhook": sub,
+		"secret":  sub.Secret,
+	})
+}
+
+// newWebhookSubscription validates a create request and converts it to a
+// subscription.
+func newWebhookSubscription(req createWebhookRequest) (model.WebhookSubscription, error) {
+	sub := model.WebhookSubscription{
+		Name:       strings.TrimSpace(req.Name),
+		URL:        strings.TrimSpace(req.URL),
+		Secret:     req.Secret,
+		Skills:     cleanList(req.Skills),
+		Keywords:   cleanList(req.Keywords),
+		Location:   strings.TrimSpace(req.Location),
+		RemoteOnly: req.RemoteOnly,
+		IsActive:   true,
+	}
+
+	if sub.Name == "" {
+		return sub, fmt.Errorf("name is required")
+	}
+	u, err := url.Parse(sub.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return sub, fmt.Errorf("url must be an absolute http(s) URL")
+	}
+	if req.Secret != "" && len(req.Secret) < 16 {
+		return sub, fmt.Errorf("secret must be at least 16 characters")
+	}
+	return sub, nil
+}
+
+// cleanList trims values and drops empty ones.
+func cleanList(values []string) pq.StringArray {
+	out := pq.StringArray{}
+	for _, v := range values {
+		if v = strings.TrimSpace(v); v != "" {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+// generateSecret returns a random 256-bit signing secret.
+func generateSecret() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("generate webhook secret: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// DeleteWebhook removes a subscription together with its deliveries.
+// DELETE /admin/webhooks/{id}
+func (h *Handler) DeleteWebhook(w http.ResponseWriter, r *http.Request) {
+	if !h.requireWebhooks(w) {
+		return
+	}
+	if r.Method != http.MethodDelete {
+		h.writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	id, err := uuid.Parse(strings.TrimPrefix(r.URL.Path, "/admin/webhooks/"))
+	if err != nil {
+		h.writeError(w, http.StatusBadRequest, "invalid webhook ID format")
+		return
+	}
+
+	before, err := h.webhookByID(r.Context(), id)
+	if err != nil {
+		h.logger.Printf("[admin] DeleteWebhook error: %v", err)
+		h.writeError(w, http.StatusInternalServerError, "failed to delete webhook")
+		return
+	}
+	err = h.webhooks.DeleteWebhookSubscription(r.Context(), id)
+	if errors.Is(err, storage.ErrNotFound) {
+		h.writeError(w, http.StatusNotFound, "webhook not found")
+		return
+	}
+	if err != nil {
+		h.logger.Printf("[admin] DeleteWebhook error: %v", err)
+		h.writeError(w, http.StatusInternalServerError, "failed to delete webhook")
+		return
+	}
+	h.logger.Printf("[admin] deleted webhook %s", id)
+	audit.Record(r.Context(), audit.Change{
+		Action: "webhook.delete", EntityType: "webhook", EntityID: id.String(), Before: before,
+	})
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// webhookByID returns the subscription with the ID, or nil if there is none.
+func (h *Handler) webhookByID(ctx context.Context, id uuid.UUID) (*model.WebhookSubscription, error) {
+	subs, err := h.webhooks.ListWebhookSubscriptions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for i := range subs {
+		if subs[i].ID == id {
+			return &subs[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// ListWebhookDeliveries returns recent webhook deliveries, newest first.
+// GET /admin/webhook-deliveries?subscription_id=&status=dead_letter&limit=50
+func (h *Handler) ListWebhookDeliveries(w http.ResponseWriter, r *http.Request) {
+	if !h.requireWebhooks(w) {
+		return
+	}
+	if r.Method != http.MethodGet {
+		h.writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	filter, err := parseWebhookDeliveryFilter(r.URL.Query())
+	if err != nil {
+		h.writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	deliveries, err := h.webhooks.ListWebhookDeliveries(r.Context(), filter)
+	if err != nil {
+		h.logger.Printf("[admin] ListWebhookDeliveries error: %v", err)
+		h.writeError(w, http.StatusInternalServerError, "failed to list webhook deliveries")
+		return
+	}
+	if deliveries == nil {
+		deliveries = []model.WebhookDelivery{}
+	}
+
+	h.writeJSON(w, http.StatusOK, map[string]interface{}{
+		"deliveries": deliveries,
+		"count":      len(deliveries),
+	})
+}
+
+// parseWebhookDeliveryFilter reads the delivery list query parameters.
+func parseWebhookDeliveryFilter(q url.Values) (model.WebhookDeliveryFilter, error) {
+	var filter model.WebhookDeliveryFilter
+	if v := q.Get("subscription_id"); v != "" {
+		id, err := uuid.Parse(v)
+		if err != nil {
+			return filter, fmt.Errorf("invalid subscription_id %q", v)
+		}
+		filter.SubscriptionID = &id
+	}
+	switch status := model.WebhookDeliveryStatus(q.Get("status")); status {
+	case "", model.WebhookDeliveryPending, model.WebhookDeliveryDelivered, model.WebhookDeliveryDeadLetter:
+		filter.Status = status
+	default:
+		return filter, fmt.Errorf("invalid status %q", status)
+	}
+	if v := q.Get("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 {
+			return filter, fmt.Errorf("invalid limit %q", v)
+		}
+		filter.Limit = n
+	}
+	return filter, nil
+}
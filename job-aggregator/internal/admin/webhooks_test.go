@@ -0,0 +1,68 @@
+package admin
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/learnbot/job-aggregator/internal/model"
+)
+
+func TestNewWebhookSubscription(t *testing.T) {
+	sub, err := newWebhookSubscription(createWebhookRequest{
+		Name:     " #jobs-go ",
+		URL:      "https://hooks.slack.com/services/T000/B000/XXXX",
+		Skills:   []string{" Go ", ""},
+		Keywords: []string{"backend"},
+		Location: " Berlin ",
+	})
+	if err != nil {
+		t.Fatalf("newWebhookSubscription: %v", err)
+	}
+	if sub.Name != "#jobs-go" || sub.Location != "Berlin" || !sub.IsActive {
+		t.Errorf("unexpected subscription: %+v", sub)
+	}
+	if len(sub.Skills) != 1 || sub.Skills[0] != "Go" {
+		t.Errorf("expected skills to be trimmed, got %q", sub.Skills)
+	}
+
+	tests := []struct {
+		name string
+		req  createWebhookRequest
+		want string
+	}{
+		{"missing name", createWebhookRequest{URL: "https://example.com/hook"}, "name"},
+		{"relative url", createWebhookRequest{Name: "n", URL: "/hook"}, "url"},
+		{"ftp url", createWebhookRequest{Name: "n", URL: "ftp://example.com/hook"}, "url"},
+		{"short secret", createWebhookRequest{Name: "n", URL: "https://example.com/hook", Secret: "abc"}, "secret"},
+	}
+	for _, tt := range tests {
+		if _, err := newWebhookSubscription(tt.req); err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("%s: expected a %s error, got %v", tt.name, tt.want, err)
+		}
+	}
+}
+
+func TestParseWebhookDeliveryFilter(t *testing.T) {
+	filter, err := parseWebhookDeliveryFilter(url.Values{
+		"subscription_id": {"5b0f8f0e-7d7c-4c57-9a57-3f1f7f6f2f11"},
+		"status":          {"dead_letter"},
+		"limit":           {"10"},
+	})
+	if err != nil {
+		t.Fatalf("parseWebhookDeliveryFilter: %v", err)
+	}
+	if filter.SubscriptionID == nil || filter.Status != model.WebhookDeliveryDeadLetter || filter.Limit != 10 {
+		t.Errorf("unexpected filter: %+v", filter)
+	}
+
+	for _, q := range []url.Values{
+		{"subscription_id": {"nope"}},
+		{"status": {"failed"}},
+		{"limit": {"0"}},
+	} {
+		if _, err := parseWebhookDeliveryFilter(q); err == nil {
+			t.Errorf("expected an error for %v", q)
+		}
+	}
+}
@@ -0,0 +1,198 @@
+package analytics
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/learnbot/job-aggregator/internal/model"
+	"github.com/learnbot/job-aggregator/internal/storage"
+)
+
+// DefaultBatchSize is the number of jobs read per aggregation batch.
+const DefaultBatchSize = 500
+
+// Store is the persistence used by the Aggregator. It is implemented by
+// *storage.PostgresRepository.
+type Store interface {
+	GetSkillStatsWatermark(ctx context.Context) (model.SkillStatsWatermark, error)
+	ListJobsForSkillStats(ctx context.Context, after model.SkillStatsWatermark, limit int) ([]model.SkillStatsJob, error)
+	AddSkillStats(ctx context.Context, from, to model.SkillStatsWatermark, counts []model.SkillStatCount) error
+}
+
+// Aggregator folds newly stored jobs into the skill demand stats.
+type Aggregator struct {
+	store     Store
+	matcher   *Matcher
+	batchSize int
+	logger    *log.Logger
+	// mu serializes runs; runs of other replicas are detected through the
+	// watermark instead.
+	mu sync.Mutex
+}
+
+// New creates an Aggregator that extracts skills with the built-in skill
+// ontology.
+func New(store Store, logger *log.Logger) *Aggregator {
+	return &Aggregator{
+		store:     store,
+		matcher:   DefaultMatcher(),
+		batchSize: DefaultBatchSize,
+		logger:    logger,
+	}
+}
+
+// Run folds the jobs stored since the last run into the skill demand stats
+// and returns how many jobs it read. Each batch is added together with the
+// new watermark, so a job is never counted twice, even when a run fails
+// halfway or another replica runs at the same time.
+func (a *Aggregator) Run(ctx context.Context) (int, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	total := 0
+	for {
+		from, err := a.store.GetSkillStatsWatermark(ctx)
+		if err != nil {
+			return total, err
+		}
+		jobs, err := a.store.ListJobsForSkillStats(ctx, from, a.batchSize)
+		if err != nil {
+			return total, err
+		}
+		if len(jobs) == 0 {
+			return total, nil
+		}
+
+		last := jobs[len(jobs)-1]
+		to := model.SkillStatsWatermark{CreatedAt: last.CreatedAt, JobID: last.ID}
+		err = a.store.AddSkillStats(ctx, from, to, a.Count(jobs))
+		if errors.Is(err, storage.ErrSkillStatsConflict) {
+			// Another replica folded these jobs in; continue after them.
+			a.logger.Printf("[analytics] skill stats watermark moved, continuing after it")
+			continue
+		}
+		if err != nil {
+			return total, fmt.Errorf("aggregate skill stats: %w", err)
+		}
+		total += len(jobs)
+		if len(jobs) < a.batchSize {
+			return total, nil
+		}
+	}
+}
+
+// Count counts the jobs mentioning each skill per role keyword, location
+// and week. A job counts once per skill however often it mentions it, under
+// any spelling.
+func (a *Aggregator) Count(jobs []model.SkillStatsJob) []model.SkillStatCount {
+	type key struct {
+		skill, role, location string
+		week                  time.Time
+	}
+	counts := map[key]int{}
+	for _, j := range jobs {
+		skills := a.matcher.Extract(j.Title + "\n" + j.Description)
+		if len(skills) == 0 {
+			continue
+		}
+		role := RoleKeyword(j.Title)
+		location := LocationKey(j.LocationCity, j.LocationRaw, j.LocationType)
+		posted := j.CreatedAt
+		if j.PostedAt.Valid {
+			posted = j.PostedAt.Time
+		}
+		week := WeekStart(posted)
+		for _, s := range skills {
+			counts[key{s, role, location, week}]++
+		}
+	}
+
+	out := make([]model.SkillStatCount, 0, len(counts))
+	for k, n := range counts {
+		out = append(out, model.SkillStatCount{
+			Skill: k.skill, RoleKeyword: k.role, Location: k.location, WeekStart: k.week, Jobs: n,
+		})
+	}
+	sort.Slice(out, func(i, j int) bool {
+		a, b := out[i], out[j]
+		if !a.WeekStart.Equal(b.WeekStart) {
+			return a.WeekStart.Before(b.WeekStart)
+		}
+		if a.Skill != b.Skill {
+			return a.Skill < b.Skill
+		}
+		if a.RoleKeyword != b.RoleKeyword {
+			return a.RoleKeyword < b.RoleKeyword
+		}
+		return a.Location < b.Location
+	})
+	return out
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Keys
+// ─────────────────────────────────────────────────────────────────────────────
+
+var (
+	// titleQualifierRe matches the parts of a title that qualify the role
+	// rather than name it: parentheses, and anything after a separator, as
+	// in "Data Engineer (Remote)" or "Data Engineer - Payments".
+	titleQualifierRe = regexp.MustCompile(`\([^)]*\)|\[[^\]]*\]|\s+[-–—|/,:@]\s*.*$|,.*$`)
+
+	// titleWordRe splits a title into words.
+	titleWordRe = regexp.MustCompile(`[^\p{L}\p{N}+#.-]+`)
+
+	// seniorityWords are dropped from titles, so that "Senior Data Engineer
+	// II" and "Data Engineer" share the role keyword "data engineer".
+	seniorityWords = map[string]bool{
+		"senior": true, "sr": true, "junior": true, "jr": true, "lead": true,
+		"principal": true, "staff": true, "mid": true, "mid-level": true, "entry": true,
+		"entry-level": true, "associate": true, "intern": true, "internship": true,
+		"graduate": true, "trainee": true, "i": true, "ii": true, "iii": true, "iv": true,
+	}
+)
+
+// RoleKeyword normalizes a job title to the role it names: lowercase,
+// without qualifiers or seniority words. "Sr. Data Engineer (Jakarta)"
+// becomes "data engineer".
+func RoleKeyword(title string) string {
+	t := titleQualifierRe.ReplaceAllString(strings.ToLower(title), "")
+	var words []string
+	for _, w := range titleWordRe.Split(t, -1) {
+		w = strings.Trim(w, ".-")
+		if w != "" && !seniorityWords[w] {
+			words = append(words, w)
+		}
+	}
+	return strings.Join(words, " ")
+}
+
+// LocationKey is the location a job is counted under: its city, or its raw
+// location string if the city is unknown, lowercased. Remote jobs without
+// a location are counted under "remote".
+func LocationKey(city, raw string, locationType model.WorkLocationType) string {
+	for _, s := range []string{city, raw} {
+		if s = strings.TrimSpace(s); s != "" {
+			return strings.ToLower(s)
+		}
+	}
+	if locationType == model.LocationRemote {
+		return "remote"
+	}
+	return ""
+}
+
+// WeekStart returns the Monday, in UTC, of the week containing t.
+func WeekStart(t time.Time) time.Time {
+	t = t.UTC()
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	offset := (int(day.Weekday()) + 6) % 7
+	return day.AddDate(0, 0, -offset)
+}
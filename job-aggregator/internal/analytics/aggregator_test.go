@@ -0,0 +1,167 @@
+package analytics
+
+import (
+	"context"
+	"database/sql"
+	"io"
+	"log"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/learnbot/job-aggregator/internal/model"
+	"github.com/learnbot/job-aggregator/internal/storage"
+)
+
+func TestRoleKeyword(t *testing.T) {
+	tests := map[string]string{
+		"Senior Data Engineer":             "data engineer",
+		"Sr. Data Engineer II (Jakarta)":   "data engineer",
+		"Data Engineer - Payments":         "data engineer",
+		"Lead Backend Developer, Platform": "backend developer",
+		"Front-end Developer":              "front-end developer",
+		"Staff Software Engineer | Remote": "software engineer",
+		"  ":                               "",
+	}
+	for title, want := range tests {
+		if got := RoleKeyword(title); got != want {
+			t.Errorf("RoleKeyword(%q) = %q, want %q", title, got, want)
+		}
+	}
+}
+
+func TestWeekStart(t *testing.T) {
+	// 2025-01-08 is a Wednesday; its week starts on Monday 2025-01-06.
+	want := time.Date(2025, 1, 6, 0, 0, 0, 0, time.UTC)
+	for _, ts := range []time.Time{
+		time.Date(2025, 1, 6, 0, 0, 0, 0, time.UTC),
+		time.Date(2025, 1, 8, 15, 30, 0, 0, time.UTC),
+		time.Date(2025, 1, 12, 23, 59, 0, 0, time.UTC),
+	} {
+		if got := WeekStart(ts); !got.Equal(want) {
+			t.Errorf("WeekStart(%v) = %v, want %v", ts, got, want)
+		}
+	}
+}
+
+func TestCount_CollapsesAliases(t *testing.T) {
+	a := New(nil, log.New(io.Discard, "", 0))
+	posted := sql.NullTime{Time: time.Date(2025, 1, 8, 0, 0, 0, 0, time.UTC), Valid: true}
+	jobs := []model.SkillStatsJob{
+		{ID: uuid.New(), Title: "Senior Data Engineer", Description: "Kafka and k8s.", LocationCity: "Jakarta", PostedAt: posted},
+		{ID: uuid.New(), Title: "Data Engineer", Description: "Kubernetes, Kubernetes and more Kubernetes.", LocationCity: "jakarta", PostedAt: posted},
+		{ID: uuid.New(), Title: "Data Engineer", Description: "Kafka", LocationRaw: "Anywhere", LocationType: model.LocationRemote, PostedAt: posted},
+		{ID: uuid.New(), Title: "Office Manager", Description: "Keeps the office running.", LocationCity: "Jakarta", PostedAt: posted},
+	}
+
+	got := a.Count(jobs)
+	week := time.Date(2025, 1, 6, 0, 0, 0, 0, time.UTC)
+	want := []model.SkillStatCount{
+		{Skill: "kafka", RoleKeyword: "data engineer", Location: "anywhere", WeekStart: week, Jobs: 1},
+		{Skill: "kafka", RoleKeyword: "data engineer", Location: "jakarta", WeekStart: week, Jobs: 1},
+		{Skill: "kubernetes", RoleKeyword: "data engineer", Location: "jakarta", WeekStart: week, Jobs: 2},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("Count = %+v, want %+v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("count %d = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
+
+// fakeStore is an in-memory Store.
+type fakeStore struct {
+	jobs      []model.SkillStatsJob
+	watermark model.SkillStatsWatermark
+	counts    []model.SkillStatCount
+	// conflicts is the number of AddSkillStats calls that fail because
+	// another replica moved the watermark.
+	conflicts int
+}
+
+func (s *fakeStore) GetSkillStatsWatermark(context.Context) (model.SkillStatsWatermark, error) {
+	return s.watermark, nil
+}
+
+func (s *fakeStore) ListJobsForSkillStats(_ context.Context, after model.SkillStatsWatermark, limit int) ([]model.SkillStatsJob, error) {
+	var out []model.SkillStatsJob
+	for _, j := range s.jobs {
+		if after.CreatedAt.IsZero() || j.CreatedAt.After(after.CreatedAt) {
+			out = append(out, j)
+		}
+		if len(out) == limit {
+			break
+		}
+	}
+	return out, nil
+}
+
+func (s *fakeStore) AddSkillStats(_ context.Context, from, to model.SkillStatsWatermark, counts []model.SkillStatCount) error {
+	if s.conflicts > 0 {
+		// Another replica folded in the first job.
+		s.conflicts--
+		s.watermark = model.SkillStatsWatermark{CreatedAt: s.jobs[0].CreatedAt, JobID: s.jobs[0].ID}
+		return storage.ErrSkillStatsConflict
+	}
+	if from != s.watermark {
+		return storage.ErrSkillStatsConflict
+	}
+	s.watermark = to
+	s.counts = append(s.counts, counts...)
+	return nil
+}
+
+func TestRun_IsIncremental(t *testing.T) {
+	start := time.Date(2025, 1, 6, 9, 0, 0, 0, time.UTC)
+	store := &fakeStore{}
+	for i := 0; i < 5; i++ {
+		store.jobs = append(store.jobs, model.SkillStatsJob{
+			ID: uuid.New(), Title: "Go Developer", Description: "Golang", CreatedAt: start.Add(time.Duration(i) * time.Minute),
+		})
+	}
+	a := New(store, log.New(io.Discard, "", 0))
+	a.batchSize = 2
+
+	n, err := a.Run(context.Background())
+	if err != nil || n != 5 {
+		t.Fatalf("Run = %d, %v; want 5 jobs", n, err)
+	}
+	last := store.jobs[4]
+	if store.watermark.JobID != last.ID {
+		t.Errorf("expected the watermark at the last job, got %+v", store.watermark)
+	}
+	total := 0
+	for _, c := range store.counts {
+		total += c.Jobs
+	}
+	if total != 5 {
+		t.Errorf("expected 5 counted jobs, got %d", total)
+	}
+
+	// A second run without new jobs adds nothing.
+	if n, err := a.Run(context.Background()); err != nil || n != 0 {
+		t.Errorf("second Run = %d, %v; want 0 jobs", n, err)
+	}
+}
+
+func TestRun_SkipsJobsFoldedInByAnotherReplica(t *testing.T) {
+	start := time.Date(2025, 1, 6, 9, 0, 0, 0, time.UTC)
+	store := &fakeStore{conflicts: 1}
+	for i := 0; i < 3; i++ {
+		store.jobs = append(store.jobs, model.SkillStatsJob{
+			ID: uuid.New(), Title: "Go Developer", Description: "Golang", CreatedAt: start.Add(time.Duration(i) * time.Minute),
+		})
+	}
+	a := New(store, log.New(io.Discard, "", 0))
+
+	n, err := a.Run(context.Background())
+	if err != nil || n != 2 {
+		t.Fatalf("Run = %d, %v; want the 2 remaining jobs", n, err)
+	}
+	if len(store.counts) != 1 || store.counts[0].Jobs != 2 {
+		t.Errorf("expected one count of 2 jobs, got %+v", store.counts)
+	}
+}
@@ -0,0 +1,158 @@
+// Package analytics derives skill demand statistics from scraped jobs.
+//
+// After each scrape run the Aggregator reads the jobs stored since its last
+// run, extracts the skills their descriptions mention using the shared
+// skill ontology, and adds one count per skill, role keyword, location and
+// week to the job_skill_stats table. Alias spellings are collapsed into the
+// ontology's canonical skill, so "k8s" and "Kubernetes" count as one skill.
+package analytics
+
+import (
+	"bytes"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/learnbot/shared/ontology"
+)
+
+// ambiguousTerms are aliases that are also common words or abbreviations in
+// job descriptions ("ready to go", "rest assured"). They are only matched
+// when written exactly like the skill's canonical name, such as "Go" or
+// "REST", and ignored when they are not the canonical name.
+var ambiguousTerms = map[string]bool{
+	"go": true, "rest": true, "swift": true, "gin": true, "echo": true, "fiber": true,
+	"spring": true, "node": true, "express": true, "elastic": true, "es": true,
+	"pg": true, "tf": true, "torch": true, "np": true, "ml": true, "dl": true,
+	"rn": true, "sh": true, "shell": true, "sprint": true, "coaching": true,
+	"collaboration": true, "collaborative": true,
+}
+
+// term is one spelling of a skill.
+type term struct {
+	text string
+	id   string
+	// exact terms are matched case-sensitively.
+	exact bool
+}
+
+// Matcher finds the canonical skills mentioned in a text.
+type Matcher struct {
+	terms []term
+	names map[string]string
+}
+
+// NewMatcher creates a Matcher for the canonical names, IDs and aliases of
+// nodes. Spellings shorter than two characters, such as "C" and "R", are
+// too ambiguous to match in free text and are skipped.
+func NewMatcher(nodes []ontology.SkillNode) *Matcher {
+	m := &Matcher{names: make(map[string]string, len(nodes))}
+	// A spelling listed for two skills belongs to the later one, as in the
+	// resume parser's taxonomy.
+	terms := map[string]term{}
+	add := func(text, id string, exact bool) {
+		if utf8.RuneCountInString(text) >= 2 {
+			terms[text] = term{text: text, id: id, exact: exact}
+		}
+	}
+
+	for _, n := range nodes {
+		m.names[n.ID] = n.CanonicalName
+		for _, t := range append([]string{n.CanonicalName, n.ID}, n.Aliases...) {
+			lower := strings.ToLower(t)
+			switch {
+			case !ambiguousTerms[lower]:
+				add(lower, n.ID, false)
+			case strings.EqualFold(t, n.CanonicalName):
+				add(n.CanonicalName, n.ID, true)
+			}
+		}
+	}
+
+	for _, t := range terms {
+		m.terms = append(m.terms, t)
+	}
+	// Longer spellings match first, so that "react native" is not also
+	// counted as "react".
+	sort.Slice(m.terms, func(i, j int) bool {
+		if len(m.terms[i].text) != len(m.terms[j].text) {
+			return len(m.terms[i].text) > len(m.terms[j].text)
+		}
+		return m.terms[i].text < m.terms[j].text
+	})
+	return m
+}
+
+// DefaultMatcher returns a Matcher for the built-in skill ontology.
+func DefaultMatcher() *Matcher {
+	return NewMatcher(ontology.Skills())
+}
+
+// Name returns the canonical name of the skill with the given ID, or the
+// ID if it is unknown.
+func (m *Matcher) Name(id string) string {
+	if name, ok := m.names[id]; ok {
+		return name
+	}
+	return id
+}
+
+// Extract returns the IDs of the skills mentioned in text, sorted and
+// without duplicates. Spellings only match as whole words.
+func (m *Matcher) Extract(text string) []string {
+	if strings.TrimSpace(text) == "" {
+		return nil
+	}
+	// Exact terms are matched against original and the others against
+	// lower. Matches are blanked in both, so that a shorter spelling never
+	// matches inside a longer one; ASCII lowercasing keeps them aligned.
+	original := []byte(text)
+	lower := make([]byte, len(original))
+	for i, b := range original {
+		if 'A' <= b && b <= 'Z' {
+			b += 'a' - 'A'
+		}
+		lower[i] = b
+	}
+
+	found := map[string]bool{}
+	for _, t := range m.terms {
+		haystack := lower
+		if t.exact {
+			haystack = original
+		}
+		for from := 0; ; {
+			i := bytes.Index(haystack[from:], []byte(t.text))
+			if i < 0 {
+				break
+			}
+			start, end := from+i, from+i+len(t.text)
+			if isBoundary(haystack, start-1) && isBoundary(haystack, end) {
+				found[t.id] = true
+				for k := start; k < end; k++ {
+					lower[k], original[k] = ' ', ' '
+				}
+			}
+			from = start + 1
+		}
+	}
+
+	ids := make([]string, 0, len(found))
+	for id := range found {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// isBoundary reports whether the byte at i does not continue a word: it is
+// outside b or not a letter, digit, '+', '#' or '_' ("c++", "c#").
+func isBoundary(b []byte, i int) bool {
+	if i < 0 || i >= len(b) {
+		return true
+	}
+	c := b[i]
+	return c < utf8.RuneSelf && !unicode.IsLetter(rune(c)) && !unicode.IsDigit(rune(c)) &&
+		c != '+' && c != '#' && c != '_'
+}
@@ -0,0 +1,40 @@
+package analytics
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMatcherExtract(t *testing.T) {
+	m := DefaultMatcher()
+	tests := []struct {
+		name string
+		text string
+		want []string
+	}{
+		{"aliases collapse", "We run K8s in production. Kubernetes experience required; kube-proxy a plus.", []string{"kubernetes"}},
+		{"canonical and alias", "Golang or Go, PostgreSQL (postgres).", []string{"go", "postgresql"}},
+		{"whole words only", "Javascript is not Java, and cpp is not C.", []string{"cpp", "java", "javascript"}},
+		{"symbols", "C++ and C# on .NET", []string{"cpp", "csharp", "dotnet"}},
+		{"longer spelling wins", "Build apps with React Native.", []string{"react-native"}},
+		{"ambiguous words need canonical case", "Ready to go? Rest assured, we ship Go services behind REST APIs.", []string{"go", "rest"}},
+		{"empty", "  ", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := m.Extract(tt.text); !reflect.DeepEqual(got, tt.want) && (len(got) != 0 || len(tt.want) != 0) {
+				t.Errorf("Extract(%q) = %v, want %v", tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMatcherName(t *testing.T) {
+	m := DefaultMatcher()
+	if got := m.Name("kubernetes"); got != "Kubernetes" {
+		t.Errorf("Name(kubernetes) = %q", got)
+	}
+	if got := m.Name("unknown-skill"); got != "unknown-skill" {
+		t.Errorf("expected an unknown ID to be returned as is, got %q", got)
+	}
+}
@@ -0,0 +1,132 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/learnbot/job-aggregator/internal/analytics"
+	"github.com/learnbot/job-aggregator/internal/model"
+)
+
+const (
+	// defaultDemandWeeks is the period of GET /api/v1/analytics/skill-demand
+	// without from.
+	defaultDemandWeeks = 4
+
+	// maxDemandWeeks caps the period of GET /api/v1/analytics/skill-demand.
+	maxDemandWeeks = 104
+
+	// maxDemandLimit caps its limit parameter.
+	maxDemandLimit = 100
+)
+
+// demandStore answers skill demand queries from aggregated skill stats. It
+// is implemented by *storage.PostgresRepository; with other storage
+// backends GET /api/v1/analytics/skill-demand answers 501 Not Implemented.
+type demandStore interface {
+	SkillDemand(ctx context.Context, filter model.SkillDemandFilter) ([]model.SkillDemand, error)
+}
+
+// skillDemandResponse is the body of GET /api/v1/analytics/skill-demand.
+type skillDemandResponse struct {
+	Role     string              `json:"role,omitempty"`
+	Location string              `json:"location,omitempty"`
+	From     string              `json:"from"`
+	To       string              `json:"to"`
+	Skills   []model.SkillDemand `json:"skills"`
+}
+
+// SkillDemand returns the skills most in demand in scraped jobs.
+// GET /api/v1/analytics/skill-demand?role=data%20engineer&location=jakarta&from=2025-01-01&to=2025-01-31&limit=10
+//
+// Jobs are counted in the week they were posted; from and to select the
+// first and last week. Each skill has the number of jobs mentioning it in
+// the period, and the change from the week before the last week to the last
+// week.
+func (h *Handler) SkillDemand(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		h.writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	if h.demand == nil {
+		h.writeError(w, http.StatusNotImplemented, "skill demand analytics are not supported by this storage backend")
+		return
+	}
+
+	filter, err := parseSkillDemandFilter(r.URL.Query(), time.Now())
+	if err != nil {
+		h.writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	skills, err := h.demand.SkillDemand(r.Context(), filter)
+	if err != nil {
+		h.logger.Printf("[api] SkillDemand error: %v", err)
+		h.writeError(w, http.StatusInternalServerError, "failed to get skill demand")
+		return
+	}
+	if skills == nil {
+		skills = []model.SkillDemand{}
+	}
+	for i := range skills {
+		skills[i].Name = h.skills.Name(skills[i].Skill)
+	}
+
+	h.writeJSON(w, http.StatusOK, skillDemandResponse{
+		Role:     filter.Role,
+		Location: filter.Location,
+		From:     filter.From.Format("2006-01-02"),
+		To:       filter.To.Format("2006-01-02"),
+		Skills:   skills,
+	})
+}
+
+// parseSkillDemandFilter reads the GET /api/v1/analytics/skill-demand
+// query parameters. from and to are YYYY-MM-DD dates, rounded down to the
+// start of their week; to defaults to now and from to the
+// defaultDemandWeeks weeks ending with to.
+func parseSkillDemandFilter(q url.Values, now time.Time) (model.SkillDemandFilter, error) {
+	filter := model.SkillDemandFilter{
+		Role:     analytics.RoleKeyword(q.Get("role")),
+		Location: strings.ToLower(strings.TrimSpace(q.Get("location"))),
+		To:       analytics.WeekStart(now),
+		Limit:    10,
+	}
+
+	if v := q.Get("to"); v != "" {
+		t, err := time.Parse("2006-01-02", v)
+		if err != nil {
+			return filter, fmt.Errorf("invalid to date %q", v)
+		}
+		filter.To = analytics.WeekStart(t)
+	}
+	filter.From = filter.To.AddDate(0, 0, -7*(defaultDemandWeeks-1))
+	if v := q.Get("from"); v != "" {
+		t, err := time.Parse("2006-01-02", v)
+		if err != nil {
+			return filter, fmt.Errorf("invalid from date %q", v)
+		}
+		filter.From = analytics.WeekStart(t)
+	}
+	switch {
+	case filter.From.After(filter.To):
+		return filter, fmt.Errorf("from must not be after to")
+	case filter.To.Sub(filter.From) >= maxDemandWeeks*7*24*time.Hour:
+		return filter, fmt.Errorf("the period is limited to %d weeks", maxDemandWeeks)
+	}
+
+	if v := q.Get("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 {
+			return filter, fmt.Errorf("invalid limit %q", v)
+		}
+		filter.Limit = min(n, maxDemandLimit)
+	}
+	return filter, nil
+}
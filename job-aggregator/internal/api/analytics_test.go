@@ -0,0 +1,118 @@
+package api
+
+import (
+	"database/sql"
+	"encoding/json"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/learnbot/job-aggregator/internal/storage"
+)
+
+func TestParseSkillDemandFilter(t *testing.T) {
+	// 2025-02-05 is a Wednesday.
+	now := time.Date(2025, 2, 5, 12, 0, 0, 0, time.UTC)
+
+	f, err := parseSkillDemandFilter(url.Values{}, now)
+	if err != nil {
+		t.Fatalf("parseSkillDemandFilter: %v", err)
+	}
+	if f.To != time.Date(2025, 2, 3, 0, 0, 0, 0, time.UTC) || f.From != time.Date(2025, 1, 13, 0, 0, 0, 0, time.UTC) || f.Limit != 10 {
+		t.Errorf("unexpected defaults: %+v", f)
+	}
+
+	f, err = parseSkillDemandFilter(url.Values{
+		"role":     {"Senior Data Engineers"},
+		"location": {" Jakarta "},
+		"from":     {"2025-01-01"},
+		"to":       {"2025-01-31"},
+		"limit":    {"500"},
+	}, now)
+	if err != nil {
+		t.Fatalf("parseSkillDemandFilter: %v", err)
+	}
+	if f.Role != "data engineers" || f.Location != "jakarta" || f.Limit != maxDemandLimit {
+		t.Errorf("unexpected filter: %+v", f)
+	}
+	if f.From != time.Date(2024, 12, 30, 0, 0, 0, 0, time.UTC) || f.To != time.Date(2025, 1, 27, 0, 0, 0, 0, time.UTC) {
+		t.Errorf("expected dates rounded to week starts, got %v to %v", f.From, f.To)
+	}
+
+	for _, bad := range []url.Values{
+		{"from": {"last week"}},
+		{"to": {"2025/01/31"}},
+		{"from": {"2025-02-01"}, "to": {"2025-01-01"}},
+		{"from": {"2020-01-01"}, "to": {"2025-01-01"}},
+		{"limit": {"0"}},
+	} {
+		if _, err := parseSkillDemandFilter(bad, now); err == nil {
+			t.Errorf("expected an error for %v", bad)
+		}
+	}
+}
+
+func TestSkillDemand(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock: %v", err)
+	}
+	defer db.Close()
+
+	mux := http.NewServeMux()
+	NewHandler(storage.NewPostgresRepository(db), log.New(io.Discard, "", 0)).RegisterRoutes(mux)
+
+	mock.ExpectQuery("FROM job_skill_stats").
+		WithArgs("2025-01-06", "2025-01-27", "2025-01-20", "data engineer", "jakarta", 5).
+		WillReturnRows(sqlmock.NewRows([]string{"skill", "jobs", "this_week", "last_week"}).
+			AddRow("kubernetes", 40, 12, 8))
+
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet,
+		"/api/v1/analytics/skill-demand?role=data+engineer&location=Jakarta&from=2025-01-06&to=2025-01-27&limit=5", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	var resp skillDemandResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if resp.From != "2025-01-06" || resp.To != "2025-01-27" || len(resp.Skills) != 1 {
+		t.Fatalf("unexpected response %+v", resp)
+	}
+	if s := resp.Skills[0]; s.Name != "Kubernetes" || s.Jobs != 40 || s.WeekOverWeekChange == nil || *s.WeekOverWeekChange != 0.5 {
+		t.Errorf("unexpected skill %+v", s)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+
+	w = httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/api/v1/analytics/skill-demand", nil))
+	if w.Code != http.StatusMethodNotAllowed {
+		t.Errorf("expected 405 for POST, got %d", w.Code)
+	}
+}
+
+func TestSkillDemand_UnsupportedBackend(t *testing.T) {
+	db, err := sql.Open(storage.SQLiteDriver, storage.SQLiteDSN(":memory:"))
+	if err != nil {
+		t.Fatalf("open sqlite: %v", err)
+	}
+	defer db.Close()
+
+	mux := http.NewServeMux()
+	NewHandler(storage.NewSQLiteRepository(db), log.New(io.Discard, "", 0)).RegisterRoutes(mux)
+
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/analytics/skill-demand", nil))
+	if w.Code != http.StatusNotImplemented {
+		t.Errorf("expected 501 without skill stats storage, got %d: %s", w.Code, w.Body.String())
+	}
+}
@@ -0,0 +1,320 @@
+// Package api provides the user-facing HTTP endpoints for aggregated jobs.
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/learnbot/job-aggregator/internal/analytics"
+	"github.com/learnbot/job-aggregator/internal/cursor"
+	"github.com/learnbot/job-aggregator/internal/model"
+	"github.com/learnbot/job-aggregator/internal/storage"
+	"github.com/learnbot/shared/openapi"
+	"github.com/learnbot/shared/pagination"
+)
+
+// maxSearchLimit caps the limit parameter of GET /api/v1/jobs/search.
+const maxSearchLimit = 100
+
+// Handler provides the public job API.
+type Handler struct {
+	repo    storage.JobRepository
+	demand  demandStore
+	cursors *cursor.Codec
+	skills  *analytics.Matcher
+	logger  *log.Logger
+}
+
+// NewHandler creates a new api Handler. Its cursors are signed with a random
+// key until SetCursorCodec is called. Skill demand analytics are served if
+// repo also stores skill stats.
+func NewHandler(repo storage.JobRepository, logger *log.Logger) *Handler {
+	demand, _ := repo.(demandStore)
+	return &Handler{repo: repo, demand: demand, cursors: cursor.NewCodec(nil), skills: analytics.DefaultMatcher(), logger: logger}
+}
+
+// SetCursorCodec sets the codec that signs and verifies pagination cursors.
+func (h *Handler) SetCursorCodec(c *cursor.Codec) {
+	h.cursors = c
+}
+
+// RegisterRoutes registers the public job routes on the given mux.
+func (h *Handler) RegisterRoutes(mux openapi.Router) {
+	mux.HandleFunc("/api/v1/jobs/search", h.SearchJobs)
+	mux.HandleFunc("/api/v1/jobs/", h.SaveJob)
+	mux.HandleFunc("/api/v1/saved-jobs/", h.UpdateSavedJob)
+	mux.HandleFunc("/api/v1/me/saved-jobs", h.ListSavedJobs)
+	mux.HandleFunc("/api/v1/analytics/skill-demand", h.SkillDemand)
+}
+
+// DescribeRoutes documents the routes registered by RegisterRoutes.
+func (h *Handler) DescribeRoutes(spec *openapi.Spec) {
+	spec.Route("/api/v1/jobs/search", openapi.Operation{
+		Method:  http.MethodGet,
+		Summary: "Full-text search over active jobs",
+		Params: []openapi.Param{
+			openapi.Query("q", "Search terms"),
+			openapi.Query("location", "Filter by location"),
+			{Name: "remote", Description: "Only remote jobs", Type: false},
+			{Name: "include_expired", Description: "Also search jobs expired as no longer listed", Type: false},
+			openapi.Query("company", "Filter by company name"),
+			openapi.Query("company_id", "Filter by company ID"),
+			openapi.Query("posted_after", "RFC 3339 timestamp or YYYY-MM-DD date"),
+			openapi.Query("source", "Filter by job source"),
+			{Name: "min_salary", Description: "Minimum yearly salary in currency; excludes jobs without a salary", Type: 0},
+			openapi.Query("currency", "ISO 4217 salary currency (default USD with min_salary)"),
+			openapi.Query("sort", `"relevance" or "recent" (the default without q)`),
+			{Name: "limit", Description: "Page size (default 20, max 100)", Type: 0},
+			{Name: "offset", Description: "Number of results to skip; not combined with cursor", Type: 0},
+			openapi.Query("cursor", "next_cursor of the previous page; requires sort=recent"),
+		},
+		Response: openapi.Fields{"data": []model.JobSearchResult{}, "pagination": pagination.Pagination{}},
+		Errors:   []int{http.StatusBadRequest},
+	})
+	user := openapi.Param{Name: userIDHeader, In: "header", Description: "ID of the authenticated user", Required: true}
+	spec.Route("/api/v1/jobs/",
+		openapi.Operation{
+			Method:      http.MethodPost,
+			Path:        "/api/v1/jobs/{id}/save",
+			Summary:     "Save a job",
+			Description: "Returns 201 with the saved job, or 200 if the user already saved it.",
+			Params:      []openapi.Param{user},
+			Response:    model.SavedJob{},
+			Status:      http.StatusCreated,
+			Errors:      []int{http.StatusBadRequest, http.StatusUnauthorized, http.StatusNotFound},
+		},
+		openapi.Operation{
+			Method:  http.MethodDelete,
+			Path:    "/api/v1/jobs/{id}/save",
+			Summary: "Unsave a job",
+			Params:  []openapi.Param{user},
+			Status:  http.StatusNoContent,
+			Errors:  []int{http.StatusBadRequest, http.StatusUnauthorized, http.StatusNotFound},
+		},
+	)
+	spec.Route("/api/v1/saved-jobs/",
+		openapi.Operation{
+			Method:  http.MethodPatch,
+			Path:    "/api/v1/saved-jobs/{id}",
+			Summary: "Update the application status of a saved job",
+			Description: "Statuses only move forward through saved, applied, interviewing and offer, and any of them " +
+				"can move to rejected. The time each status is reached is recorded.",
+			Params:   []openapi.Param{user},
+			Request:  updateSavedJobRequest{},
+			Response: model.SavedJob{},
+			Errors:   []int{http.StatusBadRequest, http.StatusUnauthorized, http.StatusNotFound, http.StatusUnprocessableEntity},
+		},
+		openapi.Operation{
+			Method:  http.MethodPut,
+			Path:    "/api/v1/saved-jobs/{id}/score",
+			Summary: "Store the match score of a saved job",
+			Description: "Written by the API gateway when it re-scores the user's saved jobs. A score computed " +
+				"before the stored one is ignored.",
+			Params:   []openapi.Param{user},
+			Request:  setSavedJobScoreRequest{},
+			Response: model.SavedJob{},
+			Errors:   []int{http.StatusBadRequest, http.StatusUnauthorized, http.StatusNotFound},
+		},
+	)
+	spec.Route("/api/v1/me/saved-jobs", openapi.Operation{
+		Method:      http.MethodGet,
+		Summary:     "List the user's saved jobs",
+		Description: "Newest first, with the current job data. Jobs the source has removed since are flagged with job_removed.",
+		Params: []openapi.Param{
+			user,
+			openapi.Query("status", "Filter by application status"),
+			{Name: "limit", Description: "Page size (default 20, max 100)", Type: 0},
+			{Name: "offset", Description: "Number of results to skip", Type: 0},
+		},
+		Response: openapi.Fields{"data": []model.SavedJob{}, "pagination": pagination.Pagination{}},
+		Errors:   []int{http.StatusBadRequest, http.StatusUnauthorized},
+	})
+	spec.Route("/api/v1/analytics/skill-demand", openapi.Operation{
+		Method:  http.MethodGet,
+		Summary: "Skills most in demand in scraped jobs",
+		Params: []openapi.Param{
+			openapi.Query("role", "Filter by job title"),
+			openapi.Query("location", "Filter by location"),
+			openapi.Query("from", "First week, YYYY-MM-DD"),
+			openapi.Query("to", "Last week, YYYY-MM-DD (default: now)"),
+			{Name: "limit", Description: "Maximum number of skills (max 100)", Type: 0},
+		},
+		Response: skillDemandResponse{},
+		Errors:   []int{http.StatusBadRequest, http.StatusNotImplemented},
+	})
+}
+
+// SearchJobs runs a full-text search over active jobs, and over expired
+// jobs with include_expired=true.
+// GET /api/v1/jobs/search?q=golang&location=berlin&remote=true&include_expired=false&company=acme&posted_after=2025-01-01&source=linkedin&min_salary=100000&currency=usd&sort=recent&limit=20&cursor=...
+//
+// The response is a pagination envelope with a Link header for the next and
+// previous pages. Newest-first results (sort=recent, the default without q)
+// carry a next_cursor; passing it as cursor fetches the next page without
+// skipped or repeated jobs when new jobs arrive in between. offset is still
+// accepted but not combined with cursor.
+func (h *Handler) SearchJobs(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		h.writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	q := r.URL.Query()
+	filter, err := parseSearchFilter(q)
+	if err != nil {
+		h.writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if token := q.Get("cursor"); token != "" {
+		switch {
+		case q.Get("offset") != "":
+			h.writeError(w, http.StatusBadRequest, "cursor cannot be combined with offset")
+			return
+		case filter.Sort == model.SortRelevance:
+			h.writeError(w, http.StatusBadRequest, "cursor requires sort=recent")
+			return
+		}
+		after, err := h.cursors.Decode(token)
+		if err != nil {
+			h.writeError(w, http.StatusBadRequest, "invalid cursor")
+			return
+		}
+		filter.After = &after
+	}
+
+	jobs, total, next, err := h.repo.FullTextSearch(r.Context(), filter)
+	if err != nil {
+		h.logger.Printf("[api] SearchJobs error: %v", err)
+		h.writeError(w, http.StatusInternalServerError, "failed to search jobs")
+		return
+	}
+
+	var nextCursor string
+	if next != nil {
+		nextCursor = h.cursors.Encode(*next)
+	}
+	page := pagination.New(total, filter.Limit, filter.Offset)
+	if filter.After != nil {
+		page = pagination.FromCursor(total, filter.Limit, nextCursor)
+	}
+	page.NextCursor = nextCursor
+	pagination.SetLinks(w.Header(), r.URL, page)
+	h.writeJSON(w, http.StatusOK, pagination.Envelope{Data: jobs, Pagination: page})
+}
+
+// currencyCodePattern matches an ISO 4217 currency code.
+var currencyCodePattern = regexp.MustCompile(`^[A-Za-z]{3}$`)
+
+// parseSearchFilter reads the GET /api/v1/jobs/search query parameters.
+// posted_after accepts an RFC 3339 timestamp or a YYYY-MM-DD date, and
+// currency a three-letter ISO 4217 code in either case.
+func parseSearchFilter(q url.Values) (model.JobSearchFilter, error) {
+	filter := model.JobSearchFilter{
+		Query:       strings.TrimSpace(q.Get("q")),
+		Location:    strings.TrimSpace(q.Get("location")),
+		CompanyName: strings.TrimSpace(q.Get("company")),
+		Limit:       20,
+	}
+
+	if v := q.Get("company_id"); v != "" {
+		id, err := uuid.Parse(v)
+		if err != nil {
+			return filter, fmt.Errorf("invalid company_id %q", v)
+		}
+		filter.CompanyID = &id
+	}
+	if v := q.Get("remote"); v != "" {
+		remote, err := strconv.ParseBool(v)
+		if err != nil {
+			return filter, fmt.Errorf("invalid remote %q", v)
+		}
+		filter.RemoteOnly = remote
+	}
+	if v := q.Get("include_expired"); v != "" {
+		include, err := strconv.ParseBool(v)
+		if err != nil {
+			return filter, fmt.Errorf("invalid include_expired %q", v)
+		}
+		filter.IncludeExpired = include
+	}
+
+	switch sort := model.JobSort(q.Get("sort")); sort {
+	case "", model.SortRelevance, model.SortRecent:
+		filter.Sort = sort
+	default:
+		return filter, fmt.Errorf("invalid sort %q", sort)
+	}
+
+	switch source := model.JobSource(q.Get("source")); source {
+	case "":
+	case model.SourceLinkedIn, model.SourceIndeed, model.SourceCompanyCareerPage,
+		model.SourceGlassdoor, model.SourceOther:
+		filter.Source = source
+	default:
+		return filter, fmt.Errorf("invalid source %q", source)
+	}
+
+	if v := q.Get("posted_after"); v != "" {
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			if t, err = time.Parse("2006-01-02", v); err != nil {
+				return filter, fmt.Errorf("invalid posted_after date %q", v)
+			}
+		}
+		filter.PostedAfter = &t
+	}
+
+	if v := q.Get("min_salary"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			return filter, fmt.Errorf("invalid min_salary %q", v)
+		}
+		filter.MinSalary = &n
+	}
+	if v := q.Get("currency"); v != "" {
+		if !currencyCodePattern.MatchString(v) {
+			return filter, fmt.Errorf("invalid currency %q", v)
+		}
+		filter.Currency = strings.ToUpper(v)
+	}
+
+	if v := q.Get("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 {
+			return filter, fmt.Errorf("invalid limit %q", v)
+		}
+		filter.Limit = min(n, maxSearchLimit)
+	}
+	if v := q.Get("offset"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			return filter, fmt.Errorf("invalid offset %q", v)
+		}
+		filter.Offset = n
+	}
+
+	return filter, nil
+}
+
+// writeJSON writes a JSON response.
+func (h *Handler) writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		h.logger.Printf("[api] JSON encode error: %v", err)
+	}
+}
+
+// writeError writes a JSON error response.
+func (h *Handler) writeError(w http.ResponseWriter, status int, message string) {
+	h.writeJSON(w, status, map[string]string{"error": message})
+}
@@ -0,0 +1,332 @@
+package api
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/learnbot/job-aggregator/internal/cursor"
+	"github.com/learnbot/job-aggregator/internal/model"
+	"github.com/learnbot/job-aggregator/internal/storage"
+	"github.com/learnbot/shared/openapi"
+	"github.com/learnbot/shared/pagination"
+)
+
+func TestParseSearchFilter(t *testing.T) {
+	f, err := parseSearchFilter(url.Values{
+		"q":               {" golang "},
+		"location":        {"Berlin"},
+		"remote":          {"true"},
+		"include_expired": {"true"},
+		"company":         {"Acme"},
+		"company_id":      {"6f1c2a9e-4b7d-4e8a-9c3f-2d5e8b1a7c40"},
+		"source":          {"indeed"},
+		"posted_after":    {"2025-01-15"},
+		"min_salary":      {"90000"},
+		"currency":        {"eur"},
+		"limit":           {"500"},
+		"offset":          {"40"},
+	})
+	if err != nil {
+		t.Fatalf("parseSearchFilter: %v", err)
+	}
+	if f.Query != "golang" || f.Location != "Berlin" || !f.RemoteOnly || !f.IncludeExpired || f.CompanyName != "Acme" || f.Source != model.SourceIndeed {
+		t.Errorf("unexpected filter: %+v", f)
+	}
+	if f.CompanyID == nil || f.CompanyID.String() != "6f1c2a9e-4b7d-4e8a-9c3f-2d5e8b1a7c40" {
+		t.Errorf("unexpected company_id: %v", f.CompanyID)
+	}
+	if !f.PostedAfter.Equal(time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("unexpected posted_after: %v", f.PostedAfter)
+	}
+	if f.MinSalary == nil || *f.MinSalary != 90000 || f.Currency != "EUR" {
+		t.Errorf("unexpected salary filter: %v %q", f.MinSalary, f.Currency)
+	}
+	if f.Limit != maxSearchLimit || f.Offset != 40 {
+		t.Errorf("unexpected paging: limit=%d offset=%d", f.Limit, f.Offset)
+	}
+
+	if f, err := parseSearchFilter(url.Values{}); err != nil || f.Limit != 20 || f.Offset != 0 || f.RemoteOnly || f.IncludeExpired || f.PostedAfter != nil || f.MinSalary != nil {
+		t.Errorf("unexpected defaults: %+v (%v)", f, err)
+	}
+
+	for _, bad := range []url.Values{
+		{"company_id": {"acme"}},
+		{"remote": {"sometimes"}},
+		{"include_expired": {"maybe"}},
+		{"source": {"monster"}},
+		{"posted_after": {"last week"}},
+		{"min_salary": {"100k"}},
+		{"min_salary": {"-1"}},
+		{"currency": {"$"}},
+		{"limit": {"0"}},
+		{"offset": {"-1"}},
+	} {
+		if _, err := parseSearchFilter(bad); err == nil {
+			t.Errorf("expected an error for %v", bad)
+		}
+	}
+}
+
+func TestSearchJobs(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock: %v", err)
+	}
+	defer db.Close()
+
+	mux := http.NewServeMux()
+	NewHandler(storage.NewPostgresRepository(db), log.New(io.Discard, "", 0)).RegisterRoutes(mux)
+
+	for _, tt := range []struct {
+		method, path string
+		want         int
+	}{
+		{http.MethodPost, "/api/v1/jobs/search", http.StatusMethodNotAllowed},
+		{http.MethodGet, "/api/v1/jobs/search?source=monster", http.StatusBadRequest},
+	} {
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, httptest.NewRequest(tt.method, tt.path, nil))
+		if w.Code != tt.want {
+			t.Errorf("%s %s: expected %d, got %d", tt.method, tt.path, tt.want, w.Code)
+		}
+	}
+
+	mock.ExpectQuery(`SELECT COUNT`).WithArgs("rustacean").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+	mock.ExpectQuery(`AS relevance`).WithArgs("rustacean", 20, 0).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/jobs/search?q=rustacean", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var body struct {
+		Data       []json.RawMessage     `json:"data"`
+		Pagination pagination.Pagination `json:"pagination"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil || body.Data == nil || len(body.Data) != 0 || body.Pagination.Total != 0 {
+		t.Errorf("expected an empty job list, got %s (%v)", w.Body.String(), err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestSearchJobs_Pagination(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock: %v", err)
+	}
+	defer db.Close()
+
+	mux := http.NewServeMux()
+	NewHandler(storage.NewPostgresRepository(db), log.New(io.Discard, "", 0)).RegisterRoutes(mux)
+
+	// An offset past the end is an empty page, not an error.
+	mock.ExpectQuery(`SELECT COUNT`).WithArgs("golang").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(25))
+	mock.ExpectQuery(`AS relevance`).WithArgs("golang", 10, 40).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/jobs/search?q=golang&remote=true&limit=10&offset=40", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var body struct {
+		Data       []json.RawMessage     `json:"data"`
+		Pagination pagination.Pagination `json:"pagination"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	p := body.Pagination
+	if body.Data == nil || len(body.Data) != 0 || p.Total != 25 || p.Offset != 40 || p.HasMore || p.NextOffset != nil {
+		t.Errorf("unexpected page: %s", w.Body.String())
+	}
+	if got, want := w.Header().Get("Link"), `<?limit=10&offset=20&q=golang&remote=true>; rel="prev"`; got != want {
+		t.Errorf("Link = %s, want %s", got, want)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+// searchColumns are the columns returned by the job search query.
+var searchColumns = []string{
+	"id", "dedup_hash", "source", "external_id", "company_id", "company_name", "title",
+	"description", "location_city", "location_state", "location_country",
+	"location_raw", "location_type", "employment_type", "experience_level",
+	"required_skills", "preferred_skills",
+	"salary_min", "salary_max", "salary_currency", "salary_raw", "salary_period",
+	"application_url", "company_url", "posted_at", "expires_at",
+	"scraped_at", "last_seen_at", "status", "expiry_reason",
+	"expiry_checked_at", "is_featured", "created_at", "updated_at",
+	"relevance",
+}
+
+// fakeJob is a row of the in-memory jobs table used to answer keyset
+// queries.
+type fakeJob struct {
+	id       uuid.UUID
+	postedAt time.Time
+}
+
+// rowsAfter returns up to n jobs after the cursor in newest-first order,
+// as the database would for the keyset query. table must be sorted.
+func rowsAfter(table []fakeJob, after *model.JobCursor, n int) *sqlmock.Rows {
+	rows := sqlmock.NewRows(searchColumns)
+	for _, j := range table {
+		if after != nil && (j.postedAt.After(after.PostedAt) ||
+			j.postedAt.Equal(after.PostedAt) && bytes.Compare(j.id[:], after.ID[:]) >= 0) {
+			continue
+		}
+		if n == 0 {
+			break
+		}
+		n--
+		rows.AddRow(
+			j.id, "hash", "linkedin", nil, nil, "Acme", "Go Developer",
+			nil, nil, nil, nil,
+			nil, "remote", "full_time", "mid",
+			"{go}", "{}",
+			nil, nil, "USD", nil, "",
+			"https://example.com/jobs", nil, j.postedAt, nil,
+			j.postedAt, j.postedAt, "active", "",
+			nil, false, j.postedAt, j.postedAt,
+			0.5,
+		)
+	}
+	return rows
+}
+
+func TestSearchJobs_CursorIsStableAcrossInserts(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock: %v", err)
+	}
+	defer db.Close()
+
+	h := NewHandler(storage.NewPostgresRepository(db), log.New(io.Discard, "", 0))
+	mux := http.NewServeMux()
+	h.RegisterRoutes(mux)
+
+	base := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	var table []fakeJob
+	for i := 0; i < 5; i++ {
+		table = append(table, fakeJob{uuid.New(), base.Add(-time.Duration(i) * time.Hour)})
+	}
+
+	var seen []uuid.UUID
+	var after *model.JobCursor
+	path := "/api/v1/jobs/search?remote=true&limit=2"
+	for page := 1; ; page++ {
+		mock.ExpectQuery(`SELECT COUNT`).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(len(table)))
+		if after == nil {
+			mock.ExpectQuery(`AS relevance`).WithArgs(3, 0).WillReturnRows(rowsAfter(table, nil, 3))
+		} else {
+			mock.ExpectQuery(`AS relevance`).WithArgs(after.PostedAt, after.ID, 3, 0).
+				WillReturnRows(rowsAfter(table, after, 3))
+		}
+
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+		if w.Code != http.StatusOK {
+			t.Fatalf("page %d: expected 200, got %d: %s", page, w.Code, w.Body.String())
+		}
+		var body struct {
+			Data []struct {
+				ID uuid.UUID `json:"id"`
+			} `json:"data"`
+			Pagination pagination.Pagination `json:"pagination"`
+		}
+		if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+			t.Fatalf("page %d: decode: %v", page, err)
+		}
+		for _, j := range body.Data {
+			seen = append(seen, j.ID)
+		}
+
+		next := body.Pagination.NextCursor
+		if next == "" {
+			if body.Pagination.HasMore || w.Header().Get("Link") != "" {
+				t.Errorf("page %d: expected the last page to have no next link", page)
+			}
+			break
+		}
+		if link := w.Header().Get("Link"); !strings.Contains(link, "cursor="+next) || !strings.Contains(link, "remote=true") {
+			t.Errorf("page %d: expected a next link with the cursor and filters, got %s", page, link)
+		}
+		c, err := h.cursors.Decode(next)
+		if err != nil {
+			t.Fatalf("page %d: decode cursor: %v", page, err)
+		}
+		after = &c
+		path = "/api/v1/jobs/search?remote=true&limit=2&cursor=" + next
+
+		// A newer job arrives after the first page. With offsets, the
+		// second page would repeat the first page's last job.
+		if page == 1 {
+			table = append([]fakeJob{{uuid.New(), base.Add(time.Hour)}}, table...)
+		}
+	}
+
+	want := table[1:]
+	if len(seen) != len(want) {
+		t.Fatalf("expected %d jobs without duplicates, got %d", len(want), len(seen))
+	}
+	for i, j := range want {
+		if seen[i] != j.id {
+			t.Errorf("job %d: got %s, want %s", i, seen[i], j.id)
+		}
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestSearchJobs_RejectsInvalidCursors(t *testing.T) {
+	h := NewHandler(nil, log.New(io.Discard, "", 0))
+	mux := http.NewServeMux()
+	h.RegisterRoutes(mux)
+
+	valid := h.cursors.Encode(model.JobCursor{PostedAt: time.Now(), ID: uuid.New()})
+	raw, _ := base64.RawURLEncoding.DecodeString(valid)
+	raw[0] ^= 0x80
+	tampered := base64.RawURLEncoding.EncodeToString(raw)
+	foreign := cursor.NewCodec([]byte("other")).Encode(model.JobCursor{PostedAt: time.Now(), ID: uuid.New()})
+
+	for _, query := range []string{
+		"cursor=" + tampered,
+		"cursor=" + foreign,
+		"cursor=garbage",
+		"cursor=" + valid + "&offset=20",
+		"q=golang&sort=relevance&cursor=" + valid,
+		"sort=oldest",
+	} {
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/jobs/search?"+query, nil))
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("%s: expected 400, got %d", query, w.Code)
+		}
+	}
+}
+
+func TestDescribeRoutes(t *testing.T) {
+	h := NewHandler(nil, log.New(io.Discard, "", 0))
+	if err := openapi.CheckRoutes(h.RegisterRoutes, h.DescribeRoutes); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,248 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/learnbot/job-aggregator/internal/model"
+	"github.com/learnbot/job-aggregator/internal/storage"
+	"github.com/learnbot/shared/pagination"
+)
+
+// userIDHeader carries the authenticated user's ID. The API gateway sets it
+// and discards any value sent by the client.
+const userIDHeader = "X-User-ID"
+
+// maxSavedJobsLimit caps the limit parameter of GET /api/v1/me/saved-jobs.
+const maxSavedJobsLimit = 100
+
+// updateSavedJobRequest is the body of PATCH /api/v1/saved-jobs/{id}.
+type updateSavedJobRequest struct {
+	Status model.ApplicationStatus `json:"status"`
+}
+
+// setSavedJobScoreRequest is the body of PUT /api/v1/saved-jobs/{id}/score.
+type setSavedJobScoreRequest struct {
+	MatchScore float64   `json:"match_score"`
+	ComputedAt time.Time `json:"computed_at"`
+}
+
+// userID returns the user identified by the X-User-ID header, writing 401
+// if there is none.
+func (h *Handler) userID(w http.ResponseWriter, r *http.Request) (uuid.UUID, bool) {
+	id, err := uuid.Parse(r.Header.Get(userIDHeader))
+	if err != nil {
+		h.writeError(w, http.StatusUnauthorized, "authentication required")
+		return uuid.Nil, false
+	}
+	return id, true
+}
+
+// SaveJob saves or unsaves a job for the user identified by the X-User-ID
+// header. Saving returns the saved job, with 201 if it was newly saved and
+// 200 if it already was.
+// POST /api/v1/jobs/{id}/save
+// DELETE /api/v1/jobs/{id}/save
+func (h *Handler) SaveJob(w http.ResponseWriter, r *http.Request) {
+	idStr, ok := strings.CutSuffix(strings.TrimPrefix(r.URL.Path, "/api/v1/jobs/"), "/save")
+	if !ok || strings.Contains(idStr, "/") {
+		h.writeError(w, http.StatusNotFound, "not found")
+		return
+	}
+	if r.Method != http.MethodPost && r.Method != http.MethodDelete {
+		h.writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	userID, ok := h.userID(w, r)
+	if !ok {
+		return
+	}
+	jobID, err := uuid.Parse(idStr)
+	if err != nil {
+		h.writeError(w, http.StatusBadRequest, "invalid job ID format")
+		return
+	}
+
+	if r.Method == http.MethodDelete {
+		err := h.repo.UnsaveJob(r.Context(), userID, jobID)
+		if errors.Is(err, storage.ErrNotFound) {
+			h.writeError(w, http.StatusNotFound, "job not saved")
+			return
+		}
+		if err != nil {
+			h.logger.Printf("[api] UnsaveJob error: %v", err)
+			h.writeError(w, http.StatusInternalServerError, "failed to unsave job")
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	saved, created, err := h.repo.SaveJob(r.Context(), userID, jobID)
+	if errors.Is(err, storage.ErrNotFound) {
+		h.writeError(w, http.StatusNotFound, "job not found")
+		return
+	}
+	if err != nil {
+		h.logger.Printf("[api] SaveJob error: %v", err)
+		h.writeError(w, http.StatusInternalServerError, "failed to save job")
+		return
+	}
+	status := http.StatusOK
+	if created {
+		status = http.StatusCreated
+	}
+	h.writeJSON(w, status, saved)
+}
+
+// UpdateSavedJob moves one of the user's saved jobs to a new application
+// status. Statuses only move forward through saved, applied, interviewing
+// and offer, and any of them can move to rejected; other transitions are
+// answered with 422.
+// PATCH /api/v1/saved-jobs/{id} {"status": "applied"}
+func (h *Handler) UpdateSavedJob(w http.ResponseWriter, r *http.Request) {
+	if idStr, ok := strings.CutSuffix(strings.TrimPrefix(r.URL.Path, "/api/v1/saved-jobs/"), "/score"); ok {
+		h.setSavedJobScore(w, r, idStr)
+		return
+	}
+	if r.Method != http.MethodPatch {
+		h.writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	userID, ok := h.userID(w, r)
+	if !ok {
+		return
+	}
+	id, err := uuid.Parse(strings.TrimPrefix(r.URL.Path, "/api/v1/saved-jobs/"))
+	if err != nil {
+		h.writeError(w, http.StatusBadRequest, "invalid saved job ID format")
+		return
+	}
+
+	var req updateSavedJobRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	if !req.Status.Valid() {
+		h.writeError(w, http.StatusBadRequest, fmt.Sprintf("invalid status %q", req.Status))
+		return
+	}
+
+	saved, err := h.repo.UpdateSavedJobStatus(r.Context(), userID, id, req.Status)
+	switch {
+	case errors.Is(err, storage.ErrNotFound):
+		h.writeError(w, http.StatusNotFound, "saved job not found")
+	case errors.Is(err, storage.ErrInvalidTransition):
+		h.writeError(w, http.StatusUnprocessableEntity, err.Error())
+	case err != nil:
+		h.logger.Printf("[api] UpdateSavedJob error: %v", err)
+		h.writeError(w, http.StatusInternalServerError, "failed to update saved job")
+	default:
+		h.writeJSON(w, http.StatusOK, saved)
+	}
+}
+
+// setSavedJobScore stores the match score the gateway computed for one of
+// the user's saved jobs. A score older than the stored one is ignored; the
+// saved job is returned either way.
+// PUT /api/v1/saved-jobs/{id}/score {"match_score": 72.5, "computed_at": "…"}
+func (h *Handler) setSavedJobScore(w http.ResponseWriter, r *http.Request, idStr string) {
+	if r.Method != http.MethodPut {
+		h.writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	userID, ok := h.userID(w, r)
+	if !ok {
+		return
+	}
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		h.writeError(w, http.StatusBadRequest, "invalid saved job ID format")
+		return
+	}
+
+	var req setSavedJobScoreRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	if math.IsNaN(req.MatchScore) || req.MatchScore < 0 || req.MatchScore > 100 {
+		h.writeError(w, http.StatusBadRequest, "match_score must be between 0 and 100")
+		return
+	}
+	if req.ComputedAt.IsZero() {
+		h.writeError(w, http.StatusBadRequest, "computed_at is required")
+		return
+	}
+
+	saved, err := h.repo.SetSavedJobScore(r.Context(), userID, id, req.MatchScore, req.ComputedAt)
+	switch {
+	case errors.Is(err, storage.ErrNotFound):
+		h.writeError(w, http.StatusNotFound, "saved job not found")
+	case err != nil:
+		h.logger.Printf("[api] SetSavedJobScore error: %v", err)
+		h.writeError(w, http.StatusInternalServerError, "failed to store saved job score")
+	default:
+		h.writeJSON(w, http.StatusOK, saved)
+	}
+}
+
+// ListSavedJobs lists the saved jobs of the user, newest first, with the
+// current data of each job. Jobs the source has removed since they were
+// saved are flagged with job_removed.
+// GET /api/v1/me/saved-jobs?status=applied&limit=20&offset=0
+func (h *Handler) ListSavedJobs(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		h.writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	userID, ok := h.userID(w, r)
+	if !ok {
+		return
+	}
+
+	q := r.URL.Query()
+	filter := model.SavedJobFilter{Status: model.ApplicationStatus(q.Get("status")), Limit: 20}
+	if filter.Status != "" && !filter.Status.Valid() {
+		h.writeError(w, http.StatusBadRequest, fmt.Sprintf("invalid status %q", filter.Status))
+		return
+	}
+	if v := q.Get("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 {
+			h.writeError(w, http.StatusBadRequest, fmt.Sprintf("invalid limit %q", v))
+			return
+		}
+		filter.Limit = min(n, maxSavedJobsLimit)
+	}
+	if v := q.Get("offset"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			h.writeError(w, http.StatusBadRequest, fmt.Sprintf("invalid offset %q", v))
+			return
+		}
+		filter.Offset = n
+	}
+
+	saved, total, err := h.repo.ListSavedJobs(r.Context(), userID, filter)
+	if err != nil {
+		h.logger.Printf("[api] ListSavedJobs error: %v", err)
+		h.writeError(w, http.StatusInternalServerError, "failed to list saved jobs")
+		return
+	}
+	if saved == nil {
+		saved = []model.SavedJob{}
+	}
+
+	page := pagination.New(total, filter.Limit, filter.Offset)
+	pagination.SetLinks(w.Header(), r.URL, page)
+	h.writeJSON(w, http.StatusOK, pagination.Envelope{Data: saved, Pagination: page})
+}
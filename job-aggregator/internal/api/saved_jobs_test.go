@@ -0,0 +1,245 @@
+package api
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/learnbot/job-aggregator/internal/model"
+	"github.com/learnbot/job-aggregator/internal/storage"
+)
+
+// savedJobsServer serves the API from a migrated in-memory SQLite
+// repository holding one job per title.
+func savedJobsServer(t *testing.T, titles ...string) (*http.ServeMux, *sql.DB, []*model.Job) {
+	t.Helper()
+	db, err := sql.Open(storage.SQLiteDriver, storage.SQLiteDSN(":memory:"))
+	if err != nil {
+		t.Fatalf("open sqlite: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	repo := storage.NewSQLiteRepository(db)
+	if err := repo.Migrate(context.Background(), log.New(io.Discard, "", 0)); err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+
+	var jobs []*model.Job
+	for i, title := range titles {
+		job, _, err := repo.UpsertJob(context.Background(), &model.ScrapedJob{
+			Source:          model.SourceLinkedIn,
+			ExternalID:      uuid.NewString(),
+			CompanyName:     "Acme " + string(rune('A'+i)),
+			Title:           title,
+			LocationType:    model.LocationRemote,
+			EmploymentType:  model.EmploymentFullTime,
+			ExperienceLevel: model.LevelMid,
+			SalaryCurrency:  "USD",
+			ApplicationURL:  "https://jobs.example.com/" + uuid.NewString(),
+		})
+		if err != nil {
+			t.Fatalf("UpsertJob: %v", err)
+		}
+		jobs = append(jobs, job)
+	}
+
+	mux := http.NewServeMux()
+	NewHandler(repo, log.New(io.Discard, "", 0)).RegisterRoutes(mux)
+	return mux, db, jobs
+}
+
+func serveAs(mux *http.ServeMux, userID uuid.UUID, method, path, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	if userID != uuid.Nil {
+		req.Header.Set(userIDHeader, userID.String())
+	}
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+	return w
+}
+
+func TestSaveJob(t *testing.T) {
+	mux, _, jobs := savedJobsServer(t, "Backend Engineer")
+	userID := uuid.New()
+	path := "/api/v1/jobs/" + jobs[0].ID.String() + "/save"
+
+	if w := serveAs(mux, uuid.Nil, http.MethodPost, path, ""); w.Code != http.StatusUnauthorized {
+		t.Errorf("expected 401 without a user, got %d", w.Code)
+	}
+	if w := serveAs(mux, userID, http.MethodPost, path, ""); w.Code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d: %s", w.Code, w.Body.String())
+	}
+	if w := serveAs(mux, userID, http.MethodPost, path, ""); w.Code != http.StatusOK {
+		t.Errorf("expected 200 saving again, got %d", w.Code)
+	}
+	if w := serveAs(mux, userID, http.MethodPost, "/api/v1/jobs/"+uuid.NewString()+"/save", ""); w.Code != http.StatusNotFound {
+		t.Errorf("expected 404 for an unknown job, got %d", w.Code)
+	}
+	if w := serveAs(mux, userID, http.MethodPost, "/api/v1/jobs/"+jobs[0].ID.String(), ""); w.Code != http.StatusNotFound {
+		t.Errorf("expected 404 without /save, got %d", w.Code)
+	}
+
+	if w := serveAs(mux, userID, http.MethodDelete, path, ""); w.Code != http.StatusNoContent {
+		t.Errorf("expected 204 unsaving, got %d", w.Code)
+	}
+	if w := serveAs(mux, userID, http.MethodDelete, path, ""); w.Code != http.StatusNotFound {
+		t.Errorf("expected 404 unsaving again, got %d", w.Code)
+	}
+}
+
+func TestUpdateSavedJob_StateMachine(t *testing.T) {
+	mux, _, jobs := savedJobsServer(t, "Backend Engineer")
+	userID := uuid.New()
+	w := serveAs(mux, userID, http.MethodPost, "/api/v1/jobs/"+jobs[0].ID.String()+"/save", "")
+	var saved model.SavedJob
+	if err := json.Unmarshal(w.Body.Bytes(), &saved); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	path := "/api/v1/saved-jobs/" + saved.ID.String()
+
+	for _, step := range []struct {
+		status string
+		want   int
+	}{
+		{"applied", http.StatusOK},
+		{"interviewing", http.StatusOK},
+		{"offer", http.StatusOK},
+		{"saved", http.StatusUnprocessableEntity},
+		{"interviewing", http.StatusUnprocessableEntity},
+		{"offer", http.StatusOK},
+		{"rejected", http.StatusOK},
+		{"applied", http.StatusUnprocessableEntity},
+		{"hired", http.StatusBadRequest},
+	} {
+		w := serveAs(mux, userID, http.MethodPatch, path, `{"status": "`+step.status+`"}`)
+		if w.Code != step.want {
+			t.Errorf("%s: expected %d, got %d: %s", step.status, step.want, w.Code, w.Body.String())
+		}
+	}
+
+	w = serveAs(mux, userID, http.MethodGet, "/api/v1/me/saved-jobs", "")
+	var list struct {
+		Data []model.SavedJob `json:"data"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &list); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if len(list.Data) != 1 {
+		t.Fatalf("expected one saved job, got %s", w.Body.String())
+	}
+	got := list.Data[0]
+	if got.Status != model.ApplicationRejected || got.AppliedAt == nil || got.InterviewingAt == nil || got.OfferAt == nil || got.RejectedAt == nil {
+		t.Errorf("expected a timestamp for every transition, got %+v", got)
+	}
+
+	if w := serveAs(mux, uuid.New(), http.MethodPatch, path, `{"status": "rejected"}`); w.Code != http.StatusNotFound {
+		t.Errorf("expected 404 for another user's saved job, got %d", w.Code)
+	}
+}
+
+func TestSetSavedJobScore(t *testing.T) {
+	mux, _, jobs := savedJobsServer(t, "Backend Engineer")
+	userID := uuid.New()
+	w := serveAs(mux, userID, http.MethodPost, "/api/v1/jobs/"+jobs[0].ID.String()+"/save", "")
+	var saved model.SavedJob
+	if err := json.Unmarshal(w.Body.Bytes(), &saved); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	path := "/api/v1/saved-jobs/" + saved.ID.String() + "/score"
+
+	for _, tt := range []struct {
+		body string
+		want int
+	}{
+		{`{"match_score": 101, "computed_at": "2025-03-01T10:00:00Z"}`, http.StatusBadRequest},
+		{`{"match_score": 80}`, http.StatusBadRequest},
+		{`not json`, http.StatusBadRequest},
+		{`{"match_score": 64.5, "computed_at": "2025-03-01T10:00:00Z"}`, http.StatusOK},
+	} {
+		if w := serveAs(mux, userID, http.MethodPut, path, tt.body); w.Code != tt.want {
+			t.Errorf("%s: expected %d, got %d: %s", tt.body, tt.want, w.Code, w.Body.String())
+		}
+	}
+	if w := serveAs(mux, userID, http.MethodPatch, path, `{}`); w.Code != http.StatusMethodNotAllowed {
+		t.Errorf("expected 405 for PATCH, got %d", w.Code)
+	}
+	if w := serveAs(mux, uuid.New(), http.MethodPut, path, `{"match_score": 1, "computed_at": "2025-03-02T10:00:00Z"}`); w.Code != http.StatusNotFound {
+		t.Errorf("expected 404 for another user's saved job, got %d", w.Code)
+	}
+
+	w = serveAs(mux, userID, http.MethodGet, "/api/v1/me/saved-jobs", "")
+	var list struct {
+		Data []model.SavedJob `json:"data"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &list); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if len(list.Data) != 1 || list.Data[0].MatchScore == nil || *list.Data[0].MatchScore != 64.5 || list.Data[0].ScoreComputedAt == nil {
+		t.Errorf("expected the stored score in the list, got %s", w.Body.String())
+	}
+}
+
+func TestListSavedJobs_FlagsRemovedJobs(t *testing.T) {
+	mux, db, jobs := savedJobsServer(t, "Backend Engineer", "Data Engineer", "Frontend Engineer")
+	userID := uuid.New()
+	for _, job := range jobs {
+		if w := serveAs(mux, userID, http.MethodPost, "/api/v1/jobs/"+job.ID.String()+"/save", ""); w.Code != http.StatusCreated {
+			t.Fatalf("save %s: got %d", job.Title, w.Code)
+		}
+	}
+	// The source closed one posting, and the other is gone entirely.
+	if _, err := db.Exec(`UPDATE jobs SET status = 'filled' WHERE id = $1`, jobs[1].ID); err != nil {
+		t.Fatalf("fill job: %v", err)
+	}
+	if _, err := db.Exec(`DELETE FROM jobs WHERE id = $1`, jobs[2].ID); err != nil {
+		t.Fatalf("delete job: %v", err)
+	}
+
+	if w := serveAs(mux, uuid.Nil, http.MethodGet, "/api/v1/me/saved-jobs", ""); w.Code != http.StatusUnauthorized {
+		t.Errorf("expected 401 without a user, got %d", w.Code)
+	}
+	w := serveAs(mux, userID, http.MethodGet, "/api/v1/me/saved-jobs", "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var list struct {
+		Data []struct {
+			JobID      uuid.UUID  `json:"job_id"`
+			Title      string     `json:"title"`
+			JobRemoved bool       `json:"job_removed"`
+			Job        *model.Job `json:"job"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &list); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if len(list.Data) != 3 {
+		t.Fatalf("expected 3 saved jobs, got %s", w.Body.String())
+	}
+	for _, s := range list.Data {
+		switch s.JobID {
+		case jobs[0].ID:
+			if s.JobRemoved || s.Job == nil {
+				t.Errorf("active job: removed=%v job=%v", s.JobRemoved, s.Job)
+			}
+		case jobs[1].ID:
+			if !s.JobRemoved || s.Job == nil || s.Job.Status != model.StatusFilled {
+				t.Errorf("filled job: removed=%v job=%v", s.JobRemoved, s.Job)
+			}
+		case jobs[2].ID:
+			if !s.JobRemoved || s.Job != nil || s.Title != "Frontend Engineer" {
+				t.Errorf("deleted job: removed=%v job=%v title=%q", s.JobRemoved, s.Job, s.Title)
+			}
+		}
+	}
+
+	if w := serveAs(mux, userID, http.MethodGet, "/api/v1/me/saved-jobs?status=won", ""); w.Code != http.StatusBadRequest {
+		t.Errorf("expected 400 for an unknown status, got %d", w.Code)
+	}
+}
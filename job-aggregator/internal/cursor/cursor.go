@@ -0,0 +1,83 @@
+// Package cursor encodes job cursors as opaque, signed tokens for keyset
+// pagination. Clients receive tokens as next_cursor and send them back as
+// the cursor query parameter; the signature rejects tokens that were
+// altered or issued with a different key.
+package cursor
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/learnbot/job-aggregator/internal/model"
+)
+
+// ErrInvalid is returned by Decode for malformed or tampered tokens.
+var ErrInvalid = errors.New("invalid cursor")
+
+const (
+	// payloadSize is an 8 byte Unix time in nanoseconds and a 16 byte ID.
+	payloadSize = 8 + 16
+
+	// macSize is the length of the truncated HMAC-SHA256 signature.
+	macSize = 16
+)
+
+// Codec signs and verifies cursor tokens.
+type Codec struct {
+	key []byte
+}
+
+// NewCodec creates a Codec that signs with key. An empty key is replaced by
+// a random one, so tokens only stay valid for the life of the process and
+// are not accepted by other replicas.
+func NewCodec(key []byte) *Codec {
+	if len(key) == 0 {
+		key = make([]byte, 32)
+		if _, err := rand.Read(key); err != nil {
+			panic("cursor: generate key: " + err.Error())
+		}
+	}
+	return &Codec{key: key}
+}
+
+// Encode returns the token for cur.
+func (c *Codec) Encode(cur model.JobCursor) string {
+	buf := make([]byte, payloadSize, payloadSize+macSize)
+	binary.BigEndian.PutUint64(buf, uint64(cur.PostedAt.UnixNano()))
+	copy(buf[8:], cur.ID[:])
+	buf = append(buf, c.sign(buf)...)
+	return base64.RawURLEncoding.EncodeToString(buf)
+}
+
+// Decode parses a token created by Encode. It returns ErrInvalid if the
+// token is malformed or its signature does not match.
+func (c *Codec) Decode(token string) (model.JobCursor, error) {
+	buf, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil || len(buf) != payloadSize+macSize {
+		return model.JobCursor{}, ErrInvalid
+	}
+	payload, mac := buf[:payloadSize], buf[payloadSize:]
+	if !hmac.Equal(mac, c.sign(payload)) {
+		return model.JobCursor{}, ErrInvalid
+	}
+
+	id, _ := uuid.FromBytes(payload[8:])
+	return model.JobCursor{
+		PostedAt: time.Unix(0, int64(binary.BigEndian.Uint64(payload))).UTC(),
+		ID:       id,
+	}, nil
+}
+
+// sign returns the truncated signature of payload.
+func (c *Codec) sign(payload []byte) []byte {
+	mac := hmac.New(sha256.New, c.key)
+	mac.Write(payload)
+	return mac.Sum(nil)[:macSize]
+}
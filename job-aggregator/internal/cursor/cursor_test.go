@@ -0,0 +1,54 @@
+package cursor
+
+import (
+	"encoding/base64"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/learnbot/job-aggregator/internal/model"
+)
+
+func TestCodec_RoundTrip(t *testing.T) {
+	c := NewCodec([]byte("secret"))
+	want := model.JobCursor{PostedAt: time.Date(2025, 3, 1, 12, 30, 0, 123456000, time.UTC), ID: uuid.New()}
+
+	got, err := c.Decode(c.Encode(want))
+	if err != nil {
+		t.Fatalf("Decode: %v", err)
+	}
+	if !got.PostedAt.Equal(want.PostedAt) || got.ID != want.ID {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+
+	// A codec with the same key accepts the token, e.g. on another replica.
+	if _, err := NewCodec([]byte("secret")).Decode(c.Encode(want)); err != nil {
+		t.Errorf("expected the same key to verify the token: %v", err)
+	}
+}
+
+func TestCodec_RejectsInvalidTokens(t *testing.T) {
+	c := NewCodec([]byte("secret"))
+	token := c.Encode(model.JobCursor{PostedAt: time.Now(), ID: uuid.New()})
+
+	// Move the cursor by a nanosecond, keeping a well-formed token.
+	raw, _ := base64.RawURLEncoding.DecodeString(token)
+	raw[7] ^= 1
+	tampered := base64.RawURLEncoding.EncodeToString(raw)
+	tests := map[string]string{
+		"empty":       "",
+		"not base64":  "not a cursor!",
+		"truncated":   token[:len(token)-2],
+		"tampered":    tampered,
+		"other key":   NewCodec([]byte("other")).Encode(model.JobCursor{PostedAt: time.Now(), ID: uuid.New()}),
+		"random key":  NewCodec(nil).Encode(model.JobCursor{}),
+		"extra bytes": token + "AAAA",
+	}
+	for name, tok := range tests {
+		if _, err := c.Decode(tok); !errors.Is(err, ErrInvalid) {
+			t.Errorf("%s: expected ErrInvalid, got %v", name, err)
+		}
+	}
+}
@@ -0,0 +1,298 @@
+package expiry
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"golang.org/x/time/rate"
+
+	"github.com/learnbot/job-aggregator/internal/model"
+)
+
+// Store is the persistence used by the Checker. It is implemented by
+// *storage.PostgresRepository.
+type Store interface {
+	ListJobsForExpiryCheck(ctx context.Context, postedBefore, expiredSince time.Time, limit int) ([]model.Job, error)
+	MarkJobExpired(ctx context.Context, id uuid.UUID, reason model.ExpiryReason) error
+	ReactivateJob(ctx context.Context, id uuid.UUID) error
+	TouchExpiryCheck(ctx context.Context, id uuid.UUID) error
+}
+
+// Config holds expiry checker configuration.
+type Config struct {
+	// How often a batch of jobs is re-checked
+	Interval time.Duration
+	// Only active jobs posted longer ago than this are checked
+	MinAge time.Duration
+	// Maximum number of jobs checked per batch
+	BatchSize int
+	// Per-host request budget, so one employer's site is never hammered
+	RequestsPerHostPerMinute int
+	// How long after expiry a checker-expired job is still re-checked for reposts
+	ReactivationWindow time.Duration
+	// Number of concurrent fetches
+	Workers int
+	// HTTP settings
+	RequestTimeout time.Duration
+	UserAgent      string
+	MaxBodyBytes   int64
+}
+
+// DefaultConfig returns sensible defaults.
+func DefaultConfig() Config {
+	return Config{
+		Interval:                 time.Hour,
+		MinAge:                   14 * 24 * time.Hour, // 14 days
+		BatchSize:                200,
+		RequestsPerHostPerMinute: 6,
+		ReactivationWindow:       30 * 24 * time.Hour, // 30 days
+		Workers:                  4,
+		RequestTimeout:           15 * time.Second,
+		UserAgent:                "LearnBot-JobAggregator/1.0 (https://learnbot.io; jobs@learnbot.io)",
+		MaxBodyBytes:             512 << 10,
+	}
+}
+
+// CheckStats summarises one batch.
+type CheckStats struct {
+	Checked      int
+	Expired      int
+	Reactivated  int
+	Inconclusive int
+}
+
+// Checker periodically re-fetches application URLs and updates job status.
+type Checker struct {
+	store  Store
+	client *http.Client
+	config Config
+	logger *log.Logger
+	now    func() time.Time
+
+	mu       sync.Mutex
+	limiters map[string]*rate.Limiter
+}
+
+// New creates a new Checker. Zero config values fall back to DefaultConfig.
+func New(store Store, cfg Config, logger *log.Logger) *Checker {
+	def := DefaultConfig()
+	if cfg.Interval <= 0 {
+		cfg.Interval = def.Interval
+	}
+	if cfg.MinAge <= 0 {
+		cfg.MinAge = def.MinAge
+	}
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = def.BatchSize
+	}
+	if cfg.RequestsPerHostPerMinute <= 0 {
+		cfg.RequestsPerHostPerMinute = def.RequestsPerHostPerMinute
+	}
+	if cfg.ReactivationWindow <= 0 {
+		cfg.ReactivationWindow = def.ReactivationWindow
+	}
+	if cfg.Workers <= 0 {
+		cfg.Workers = def.Workers
+	}
+	if cfg.RequestTimeout <= 0 {
+		cfg.RequestTimeout = def.RequestTimeout
+	}
+	if cfg.UserAgent == "" {
+		cfg.UserAgent = def.UserAgent
+	}
+	if cfg.MaxBodyBytes <= 0 {
+		cfg.MaxBodyBytes = def.MaxBodyBytes
+	}
+	return &Checker{
+		store:    store,
+		client:   &http.Client{Timeout: cfg.RequestTimeout},
+		config:   cfg,
+		logger:   logger,
+		now:      time.Now,
+		limiters: make(map[string]*rate.Limiter),
+	}
+}
+
+// Start runs CheckBatch every Interval until ctx is cancelled.
+func (c *Checker) Start(ctx context.Context) {
+	go func() {
+		c.logger.Printf("[expiry] checker started (every %v)", c.config.Interval)
+		ticker := time.NewTicker(c.config.Interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				c.logger.Println("[expiry] checker stopped")
+				return
+			case <-ticker.C:
+				stats, err := c.CheckBatch(ctx)
+				if err != nil {
+					c.logger.Printf("[expiry] batch error: %v", err)
+					continue
+				}
+				c.logger.Printf("[expiry] checked %d jobs: %d expired, %d reactivated, %d inconclusive",
+					stats.Checked, stats.Expired, stats.Reactivated, stats.Inconclusive)
+			}
+		}
+	}()
+}
+
+// CheckBatch re-checks the next batch of due jobs, least recently checked
+// first.
+func (c *Checker) CheckBatch(ctx context.Context) (CheckStats, error) {
+	now := c.now()
+	jobs, err := c.store.ListJobsForExpiryCheck(ctx,
+		now.Add(-c.config.MinAge), now.Add(-c.config.ReactivationWindow), c.config.BatchSize)
+	if err != nil {
+		return CheckStats{}, fmt.Errorf("list jobs for expiry check: %w", err)
+	}
+
+	var (
+		stats CheckStats
+		mu    sync.Mutex
+		wg    sync.WaitGroup
+	)
+	work := make(chan model.Job)
+	for i := 0; i < c.config.Workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for job := range work {
+				outcome := c.checkJob(ctx, job)
+				mu.Lock()
+				stats.Checked++
+				switch outcome {
+				case outcomeExpired:
+					stats.Expired++
+				case outcomeReactivated:
+					stats.Reactivated++
+				case outcomeInconclusive:
+					stats.Inconclusive++
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+
+	for _, job := range jobs {
+		select {
+		case work <- job:
+		case <-ctx.Done():
+		}
+		if ctx.Err() != nil {
+			break
+		}
+	}
+	close(work)
+	wg.Wait()
+	return stats, ctx.Err()
+}
+
+type outcome int
+
+const (
+	outcomeUnchanged outcome = iota
+	outcomeExpired
+	outcomeReactivated
+	outcomeInconclusive
+)
+
+// checkJob fetches one job's application URL and applies the result.
+// Network errors, throttling and server errors are inconclusive: the job is
+// left as-is and only its check timestamp moves forward.
+func (c *Checker) checkJob(ctx context.Context, job model.Job) outcome {
+	page, err := c.fetch(ctx, job.ApplicationURL)
+	if err != nil || page.StatusCode == http.StatusTooManyRequests || page.StatusCode >= 500 {
+		if err != nil {
+			c.logger.Printf("[expiry] fetch %s: %v", job.ApplicationURL, err)
+		}
+		c.touch(ctx, job)
+		return outcomeInconclusive
+	}
+
+	reason, closed := DetectorFor(job.Source, job.ApplicationURL).Detect(page)
+	switch {
+	case closed && job.Status == model.StatusActive:
+		if err := c.store.MarkJobExpired(ctx, job.ID, reason); err != nil {
+			c.logger.Printf("[expiry] mark job %s expired: %v", job.ID, err)
+			return outcomeInconclusive
+		}
+		c.logger.Printf("[expiry] job %s (%s at %s) expired: %s", job.ID, job.Title, job.CompanyName, reason)
+		return outcomeExpired
+
+	case !closed && job.Status == model.StatusExpired && page.StatusCode == http.StatusOK:
+		if err := c.store.ReactivateJob(ctx, job.ID); err != nil {
+			c.logger.Printf("[expiry] reactivate job %s: %v", job.ID, err)
+			return outcomeInconclusive
+		}
+		c.logger.Printf("[expiry] job %s (%s at %s) reactivated", job.ID, job.Title, job.CompanyName)
+		return outcomeReactivated
+	}
+
+	c.touch(ctx, job)
+	return outcomeUnchanged
+}
+
+func (c *Checker) touch(ctx context.Context, job model.Job) {
+	if err := c.store.TouchExpiryCheck(ctx, job.ID); err != nil {
+		c.logger.Printf("[expiry] touch job %s: %v", job.ID, err)
+	}
+}
+
+// fetch retrieves rawURL, following redirects, after waiting for the host's
+// rate limiter.
+func (c *Checker) fetch(ctx context.Context, rawURL string) (*Page, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, fmt.Errorf("invalid application URL %q", rawURL)
+	}
+	if err := c.limiterFor(u.Host).Wait(ctx); err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("create request: %w", err)
+	}
+	req.Header.Set("User-Agent", c.config.UserAgent)
+	req.Header.Set("Accept", "text/html,application/xhtml+xml;q=0.9,*/*;q=0.8")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, c.config.MaxBodyBytes))
+	if err != nil {
+		return nil, fmt.Errorf("read body: %w", err)
+	}
+	return &Page{
+		URL:        u,
+		FinalURL:   resp.Request.URL,
+		StatusCode: resp.StatusCode,
+		Body:       string(body),
+	}, nil
+}
+
+// limiterFor returns the shared rate limiter for host.
+func (c *Checker) limiterFor(host string) *rate.Limiter {
+	host = strings.ToLower(host)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	l, ok := c.limiters[host]
+	if !ok {
+		l = rate.NewLimiter(rate.Every(time.Minute/time.Duration(c.config.RequestsPerHostPerMinute)), 1)
+		c.limiters[host] = l
+	}
+	return l
+}
@@ -0,0 +1,304 @@
+package expiry
+
+import (
+	"context"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/learnbot/job-aggregator/internal/model"
+)
+
+// fakeStore records the status changes made by the checker.
+type fakeStore struct {
+	mu          sync.Mutex
+	jobs        []model.Job
+	expired     map[uuid.UUID]model.ExpiryReason
+	reactivated map[uuid.UUID]bool
+	touched     map[uuid.UUID]bool
+}
+
+func newFakeStore(jobs ...model.Job) *fakeStore {
+	return &fakeStore{
+		jobs:        jobs,
+		expired:     make(map[uuid.UUID]model.ExpiryReason),
+		reactivated: make(map[uuid.UUID]bool),
+		touched:     make(map[uuid.UUID]bool),
+	}
+}
+
+func (s *fakeStore) ListJobsForExpiryCheck(ctx context.Context, postedBefore, expiredSince time.Time, limit int) ([]model.Job, error) {
+	if len(s.jobs) > limit {
+		return s.jobs[:limit], nil
+	}
+	return s.jobs, nil
+}
+
+func (s *fakeStore) MarkJobExpired(ctx context.Context, id uuid.UUID, reason model.ExpiryReason) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.expired[id] = reason
+	return nil
+}
+
+func (s *fakeStore) ReactivateJob(ctx context.Context, id uuid.UUID) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.reactivated[id] = true
+	return nil
+}
+
+func (s *fakeStore) TouchExpiryCheck(ctx context.Context, id uuid.UUID) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.touched[id] = true
+	return nil
+}
+
+// fixtureServer simulates a career site with one route per closure signal.
+func fixtureServer() *httptest.Server {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/careers", func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, "<h1>Join us</h1><p>See all open roles.</p>")
+	})
+	mux.HandleFunc("/careers/open", func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, "<h1>Backend Engineer</h1><button>Apply now</button>")
+	})
+	mux.HandleFunc("/careers/gone", func(w http.ResponseWriter, r *http.Request) {
+		http.NotFound(w, r)
+	})
+	mux.HandleFunc("/careers/removed", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusGone)
+	})
+	mux.HandleFunc("/careers/moved", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/careers", http.StatusFound)
+	})
+	mux.HandleFunc("/careers/closed", func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, "<h1>Backend Engineer</h1><p>This position has been filled.</p>")
+	})
+	mux.HandleFunc("/careers/flaky", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+	mux.HandleFunc("/careers/throttled", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTooManyRequests)
+	})
+
+	// LinkedIn-style pages.
+	mux.HandleFunc("/jobs/view/1", func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, `<span class="closed-job">No longer accepting applications</span>`)
+	})
+	mux.HandleFunc("/jobs/view/2", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/jobs/search?trk=expired", http.StatusFound)
+	})
+	mux.HandleFunc("/jobs/search", func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, "<h1>Search jobs</h1>")
+	})
+
+	// Greenhouse-style pages.
+	mux.HandleFunc("/acme", func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, "<h1>Current openings at Acme</h1>")
+	})
+	mux.HandleFunc("/acme/jobs/100", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/acme?error=true", http.StatusFound)
+	})
+	mux.HandleFunc("/acme/jobs/200", func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, "<p>Sorry, but the job you are looking for is no longer open.</p>")
+	})
+	return httptest.NewServer(mux)
+}
+
+func newTestChecker(store Store) *Checker {
+	cfg := DefaultConfig()
+	cfg.RequestsPerHostPerMinute = 60000
+	cfg.RequestTimeout = 2 * time.Second
+	return New(store, cfg, log.New(io.Discard, "", 0))
+}
+
+func activeJob(source model.JobSource, applicationURL string) model.Job {
+	return model.Job{ID: uuid.New(), Source: source, Status: model.StatusActive, ApplicationURL: applicationURL}
+}
+
+func TestCheckBatch_ClosureSignals(t *testing.T) {
+	srv := fixtureServer()
+	defer srv.Close()
+
+	tests := []struct {
+		name   string
+		job    model.Job
+		reason model.ExpiryReason // empty: job must stay active
+	}{
+		{"open posting", activeJob(model.SourceCompanyCareerPage, srv.URL+"/careers/open"), ""},
+		{"404", activeJob(model.SourceCompanyCareerPage, srv.URL+"/careers/gone"), model.ExpiryNotFound},
+		{"410", activeJob(model.SourceIndeed, srv.URL+"/careers/removed"), model.ExpiryNotFound},
+		{"redirect to careers page", activeJob(model.SourceCompanyCareerPage, srv.URL+"/careers/moved"), model.ExpiryRedirected},
+		{"filled notice", activeJob(model.SourceCompanyCareerPage, srv.URL+"/careers/closed"), model.ExpiryClosedNotice},
+		{"linkedin banner", activeJob(model.SourceLinkedIn, srv.URL+"/jobs/view/1"), model.ExpiryClosedNotice},
+		{"linkedin redirect to search", activeJob(model.SourceLinkedIn, srv.URL+"/jobs/view/2"), model.ExpiryRedirected},
+	}
+
+	var jobs []model.Job
+	for _, tt := range tests {
+		jobs = append(jobs, tt.job)
+	}
+	store := newFakeStore(jobs...)
+
+	stats, err := newTestChecker(store).CheckBatch(context.Background())
+	if err != nil {
+		t.Fatalf("CheckBatch: %v", err)
+	}
+	if stats.Checked != len(tests) {
+		t.Errorf("Checked = %d, want %d", stats.Checked, len(tests))
+	}
+
+	wantExpired := 0
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, expired := store.expired[tt.job.ID]
+			if tt.reason == "" {
+				if expired {
+					t.Fatalf("open job expired with reason %q", got)
+				}
+				if !store.touched[tt.job.ID] {
+					t.Error("open job should have its check time recorded")
+				}
+				return
+			}
+			if got != tt.reason {
+				t.Errorf("reason = %q, want %q", got, tt.reason)
+			}
+		})
+		if tt.reason != "" {
+			wantExpired++
+		}
+	}
+	if stats.Expired != wantExpired {
+		t.Errorf("Expired = %d, want %d", stats.Expired, wantExpired)
+	}
+}
+
+func TestCheckBatch_InconclusiveResponsesOnlyTouch(t *testing.T) {
+	srv := fixtureServer()
+	defer srv.Close()
+
+	jobs := []model.Job{
+		activeJob(model.SourceCompanyCareerPage, srv.URL+"/careers/flaky"),
+		activeJob(model.SourceCompanyCareerPage, srv.URL+"/careers/throttled"),
+		activeJob(model.SourceCompanyCareerPage, "http://127.0.0.1:1/careers/offline"),
+		activeJob(model.SourceCompanyCareerPage, "not a url"),
+	}
+	store := newFakeStore(jobs...)
+
+	stats, err := newTestChecker(store).CheckBatch(context.Background())
+	if err != nil {
+		t.Fatalf("CheckBatch: %v", err)
+	}
+	if stats.Inconclusive != len(jobs) || stats.Expired != 0 {
+		t.Errorf("stats = %+v, want all inconclusive", stats)
+	}
+	for _, j := range jobs {
+		if _, ok := store.expired[j.ID]; ok {
+			t.Errorf("%s: inconclusive response must not expire the job", j.ApplicationURL)
+		}
+		if !store.touched[j.ID] {
+			t.Errorf("%s: check time should be recorded", j.ApplicationURL)
+		}
+	}
+}
+
+func TestCheckBatch_ReactivatesReopenedJob(t *testing.T) {
+	srv := fixtureServer()
+	defer srv.Close()
+
+	reposted := model.Job{
+		ID: uuid.New(), Source: model.SourceCompanyCareerPage, Status: model.StatusExpired,
+		ExpiryReason: model.ExpiryNotFound, ApplicationURL: srv.URL + "/careers/open",
+	}
+	stillGone := model.Job{
+		ID: uuid.New(), Source: model.SourceCompanyCareerPage, Status: model.StatusExpired,
+		ExpiryReason: model.ExpiryNotFound, ApplicationURL: srv.URL + "/careers/gone",
+	}
+	store := newFakeStore(reposted, stillGone)
+
+	stats, err := newTestChecker(store).CheckBatch(context.Background())
+	if err != nil {
+		t.Fatalf("CheckBatch: %v", err)
+	}
+	if stats.Reactivated != 1 {
+		t.Errorf("Reactivated = %d, want 1", stats.Reactivated)
+	}
+	if !store.reactivated[reposted.ID] {
+		t.Error("reopened job should be reactivated")
+	}
+	if store.reactivated[stillGone.ID] {
+		t.Error("job that is still gone must not be reactivated")
+	}
+	if _, ok := store.expired[stillGone.ID]; ok {
+		t.Error("already-expired job should not be expired again")
+	}
+}
+
+func TestGreenhouseDetector(t *testing.T) {
+	srv := fixtureServer()
+	defer srv.Close()
+	c := newTestChecker(newFakeStore())
+
+	tests := []struct {
+		path   string
+		reason model.ExpiryReason
+		closed bool
+	}{
+		{"/acme/jobs/100", model.ExpiryRedirected, true},
+		{"/acme/jobs/200", model.ExpiryClosedNotice, true},
+		{"/careers/open", "", false},
+	}
+	for _, tt := range tests {
+		page, err := c.fetch(context.Background(), srv.URL+tt.path)
+		if err != nil {
+			t.Fatalf("fetch %s: %v", tt.path, err)
+		}
+		reason, closed := greenhouseDetector{}.Detect(page)
+		if closed != tt.closed || reason != tt.reason {
+			t.Errorf("%s: Detect = %q, %v; want %q, %v", tt.path, reason, closed, tt.reason, tt.closed)
+		}
+	}
+}
+
+func TestDetectorFor(t *testing.T) {
+	tests := []struct {
+		source model.JobSource
+		url    string
+		want   Detector
+	}{
+		{model.SourceLinkedIn, "https://www.linkedin.com/jobs/view/123", linkedInDetector{}},
+		{model.SourceOther, "https://www.linkedin.com/jobs/view/123", linkedInDetector{}},
+		{model.SourceCompanyCareerPage, "https://boards.greenhouse.io/acme/jobs/1", greenhouseDetector{}},
+		{model.SourceCompanyCareerPage, "https://job-boards.greenhouse.io/acme/jobs/1", greenhouseDetector{}},
+		{model.SourceIndeed, "https://www.indeed.com/viewjob?jk=abc", genericDetector{}},
+		{model.SourceCompanyCareerPage, "://bad", genericDetector{}},
+	}
+	for _, tt := range tests {
+		if got := DetectorFor(tt.source, tt.url); got != tt.want {
+			t.Errorf("DetectorFor(%s, %s) = %T, want %T", tt.source, tt.url, got, tt.want)
+		}
+	}
+}
+
+func TestGenericDetector_RedirectToParentPath(t *testing.T) {
+	original, _ := url.Parse("https://example.com/about/careers/engineering/backend-123")
+	parent, _ := url.Parse("https://example.com/about/careers/engineering")
+	sibling, _ := url.Parse("https://example.com/about/careers/engineering/backend-123-v2")
+
+	if reason, ok := (genericDetector{}).Detect(&Page{URL: original, FinalURL: parent, StatusCode: 200}); !ok || reason != model.ExpiryRedirected {
+		t.Errorf("redirect to parent listing = %q, %v; want redirected", reason, ok)
+	}
+	if _, ok := (genericDetector{}).Detect(&Page{URL: original, FinalURL: sibling, StatusCode: 200}); ok {
+		t.Error("redirect to another posting should not count as closed")
+	}
+}
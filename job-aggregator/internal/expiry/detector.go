@@ -0,0 +1,203 @@
+// Package expiry re-checks scraped job postings and expires those whose
+// application pages show they were filled or closed.
+package expiry
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/learnbot/job-aggregator/internal/model"
+)
+
+// Page is the result of fetching a job's application URL.
+type Page struct {
+	// URL is the application URL that was requested.
+	URL *url.URL
+	// FinalURL is the URL after following redirects.
+	FinalURL *url.URL
+	// StatusCode is the HTTP status of the final response.
+	StatusCode int
+	// Body is the (possibly truncated) response body.
+	Body string
+}
+
+// Redirected reports whether the request ended on a different URL.
+func (p *Page) Redirected() bool {
+	if p.URL == nil || p.FinalURL == nil {
+		return false
+	}
+	return !strings.EqualFold(p.URL.Host, p.FinalURL.Host) ||
+		strings.TrimSuffix(p.URL.Path, "/") != strings.TrimSuffix(p.FinalURL.Path, "/") ||
+		p.URL.RawQuery != p.FinalURL.RawQuery
+}
+
+// Detector decides whether a fetched page shows a closed posting.
+type Detector interface {
+	// Detect returns the closure reason and true if the page shows the job
+	// is no longer open.
+	Detect(p *Page) (model.ExpiryReason, bool)
+}
+
+// DetectorFor returns the closure detector for a job's source and
+// application URL. Hosted boards are recognised by host so that career page
+// jobs pointing at Greenhouse use the Greenhouse rules.
+func DetectorFor(source model.JobSource, applicationURL string) Detector {
+	host := ""
+	if u, err := url.Parse(applicationURL); err == nil {
+		host = strings.ToLower(u.Host)
+	}
+	switch {
+	case source == model.SourceLinkedIn || strings.HasSuffix(host, "linkedin.com"):
+		return linkedInDetector{}
+	case isGreenhouseHost(host):
+		return greenhouseDetector{}
+	default:
+		return genericDetector{}
+	}
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Generic
+// ─────────────────────────────────────────────────────────────────────────────
+
+// genericClosedPhrases are lower-case phrases that indicate a closed posting
+// on most career sites.
+var genericClosedPhrases = []string{
+	"no longer accepting applications",
+	"no longer available",
+	"no longer open",
+	"position has been filled",
+	"job has expired",
+	"this job has been closed",
+	"this position is closed",
+	"this position has been closed",
+}
+
+// genericCareersPaths are landing pages that sites redirect to when a
+// posting is removed.
+var genericCareersPaths = map[string]bool{
+	"":             true,
+	"/careers":     true,
+	"/jobs":        true,
+	"/job-search":  true,
+	"/search":      true,
+	"/en/careers":  true,
+	"/careers/all": true,
+}
+
+type genericDetector struct{}
+
+// Detect applies the status, redirect and text rules shared by most sites.
+func (genericDetector) Detect(p *Page) (model.ExpiryReason, bool) {
+	if reason, ok := detectStatus(p); ok {
+		return reason, true
+	}
+	if p.Redirected() && isGenericCareersPage(p.URL, p.FinalURL) {
+		return model.ExpiryRedirected, true
+	}
+	if containsAny(p.Body, genericClosedPhrases) {
+		return model.ExpiryClosedNotice, true
+	}
+	return "", false
+}
+
+// detectStatus maps "gone" HTTP statuses to ExpiryNotFound.
+func detectStatus(p *Page) (model.ExpiryReason, bool) {
+	if p.StatusCode == http.StatusNotFound || p.StatusCode == http.StatusGone {
+		return model.ExpiryNotFound, true
+	}
+	return "", false
+}
+
+// isGenericCareersPage reports whether final looks like a listing or landing
+// page rather than a job posting: a known careers path, or a parent of the
+// original posting path.
+func isGenericCareersPage(original, final *url.URL) bool {
+	p := strings.ToLower(strings.TrimSuffix(final.Path, "/"))
+	if genericCareersPaths[p] {
+		return true
+	}
+	if final.Query().Get("error") == "true" {
+		return true
+	}
+	orig := strings.ToLower(strings.TrimSuffix(original.Path, "/"))
+	return strings.EqualFold(original.Host, final.Host) && strings.HasPrefix(orig, p+"/")
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// LinkedIn
+// ─────────────────────────────────────────────────────────────────────────────
+
+var linkedInClosedPhrases = []string{
+	"no longer accepting applications",
+	"this job is no longer available",
+}
+
+type linkedInDetector struct{}
+
+// Detect handles LinkedIn, which keeps closed postings live with a banner and
+// sends removed ones to the job search page.
+func (linkedInDetector) Detect(p *Page) (model.ExpiryReason, bool) {
+	if reason, ok := detectStatus(p); ok {
+		return reason, true
+	}
+	if p.Redirected() {
+		finalPath := strings.TrimSuffix(p.FinalURL.Path, "/")
+		if finalPath == "" || finalPath == "/jobs" || finalPath == "/jobs/search" {
+			return model.ExpiryRedirected, true
+		}
+	}
+	if containsAny(p.Body, linkedInClosedPhrases) {
+		return model.ExpiryClosedNotice, true
+	}
+	return "", false
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Greenhouse
+// ─────────────────────────────────────────────────────────────────────────────
+
+var greenhouseClosedPhrases = []string{
+	"the job you are looking for is no longer open",
+	"no longer accepting applications",
+}
+
+type greenhouseDetector struct{}
+
+// Detect handles Greenhouse boards, which redirect removed postings to the
+// company board root, usually with ?error=true.
+func (greenhouseDetector) Detect(p *Page) (model.ExpiryReason, bool) {
+	if reason, ok := detectStatus(p); ok {
+		return reason, true
+	}
+	if p.Redirected() {
+		if p.FinalURL.Query().Get("error") == "true" {
+			return model.ExpiryRedirected, true
+		}
+		// boards.greenhouse.io/<company>/jobs/<id> → boards.greenhouse.io/<company>
+		segments := strings.Split(strings.Trim(p.FinalURL.Path, "/"), "/")
+		if len(segments) <= 1 {
+			return model.ExpiryRedirected, true
+		}
+	}
+	if containsAny(p.Body, greenhouseClosedPhrases) {
+		return model.ExpiryClosedNotice, true
+	}
+	return "", false
+}
+
+func isGreenhouseHost(host string) bool {
+	return host == "boards.greenhouse.io" || host == "job-boards.greenhouse.io"
+}
+
+// containsAny reports whether body contains any of phrases, ignoring case.
+func containsAny(body string, phrases []string) bool {
+	lower := strings.ToLower(body)
+	for _, phrase := range phrases {
+		if strings.Contains(lower, phrase) {
+			return true
+		}
+	}
+	return false
+}
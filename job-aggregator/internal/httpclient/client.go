@@ -4,13 +4,17 @@ package httpclient
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"log"
 	"math"
+	"math/rand/v2"
 	"net/http"
 	"net/url"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"golang.org/x/time/rate"
@@ -18,12 +22,15 @@ import (
 
 // Config holds configuration for the HTTP client.
 type Config struct {
-	// Rate limiting
+	// Rate limiting, applied separately to each host
 	RequestsPerMinute int
 	// Retry settings
 	MaxRetries    int
 	RetryDelay    time.Duration
 	RetryMaxDelay time.Duration
+	// RetryJitter randomizes each backoff delay by up to this fraction of
+	// it (0.2 means ±20%), so that parallel scrapers do not retry in step.
+	RetryJitter float64
 	// Timeouts
 	RequestTimeout time.Duration
 	// User agent
@@ -39,6 +46,7 @@ func DefaultConfig() Config {
 		MaxRetries:        3,
 		RetryDelay:        2 * time.Second,
 		RetryMaxDelay:     30 * time.Second,
+		RetryJitter:       0.2,
 		RequestTimeout:    30 * time.Second,
 		UserAgent:         "LearnBot-JobAggregator/1.0 (https://learnbot.io; jobs@learnbot.io)",
 	}
@@ -47,9 +55,14 @@ func DefaultConfig() Config {
 // Client is a rate-limited HTTP client with retry logic.
 type Client struct {
 	httpClient *http.Client
-	limiter    *rate.Limiter
 	config     Config
 	logger     *log.Logger
+
+	mu       sync.Mutex
+	limiters map[string]*rate.Limiter // host -> limiter
+
+	// sleep waits between attempts; tests replace it to observe delays.
+	sleep func(ctx context.Context, d time.Duration) error
 }
 
 // New creates a new Client with the given configuration.
@@ -63,6 +76,10 @@ func New(cfg Config, logger *log.Logger) (*Client, error) {
 	if cfg.RetryDelay <= 0 {
 		cfg.RetryDelay = 2 * time.Second
 	}
+	if cfg.RetryMaxDelay < cfg.RetryDelay {
+		cfg.RetryMaxDelay = cfg.RetryDelay
+	}
+	cfg.RetryJitter = math.Min(math.Max(cfg.RetryJitter, 0), 1)
 	if cfg.RequestTimeout <= 0 {
 		cfg.RequestTimeout = 30 * time.Second
 	}
@@ -87,23 +104,43 @@ func New(cfg Config, logger *log.Logger) (*Client, error) {
 		Timeout:   cfg.RequestTimeout,
 	}
 
-	// Rate limiter: tokens per second = requests per minute / 60
-	tokensPerSecond := float64(cfg.RequestsPerMinute) / 60.0
-	limiter := rate.NewLimiter(rate.Limit(tokensPerSecond), cfg.RequestsPerMinute)
-
 	if logger == nil {
 		logger = log.Default()
 	}
 
 	return &Client{
 		httpClient: httpClient,
-		limiter:    limiter,
 		config:     cfg,
 		logger:     logger,
+		limiters:   make(map[string]*rate.Limiter),
+		sleep:      sleepContext,
 	}, nil
 }
 
-// Get performs a GET request with rate limiting and retry logic.
+// limiterFor returns the rate limiter for the host of rawURL, creating it on
+// first use. Each host gets RequestsPerMinute on its own, so a slow board
+// does not hold back requests to unrelated sites.
+func (c *Client) limiterFor(rawURL string) *rate.Limiter {
+	host := rawURL
+	if u, err := url.Parse(rawURL); err == nil && u.Host != "" {
+		host = strings.ToLower(u.Host)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	l, ok := c.limiters[host]
+	if !ok {
+		// tokens per second = requests per minute / 60
+		tokensPerSecond := float64(c.config.RequestsPerMinute) / 60.0
+		l = rate.NewLimiter(rate.Limit(tokensPerSecond), c.config.RequestsPerMinute)
+		c.limiters[host] = l
+	}
+	return l
+}
+
+// Get performs a GET request with rate limiting and retry logic. Responses
+// with a status that is not retried, such as 404, are returned to the caller
+// as-is; a failed request returns an *Error.
 func (c *Client) Get(ctx context.Context, rawURL string, headers map[string]string) (*http.Response, error) {
 	return c.do(ctx, http.MethodGet, rawURL, nil, headers)
 }
@@ -114,6 +151,7 @@ func (c *Client) Post(ctx context.Context, rawURL string, body io.Reader, header
 }
 
 // GetBody performs a GET request and returns the response body as a string.
+// A non-2xx response is reported as a permanent *Error.
 func (c *Client) GetBody(ctx context.Context, rawURL string, headers map[string]string) (string, error) {
 	resp, err := c.Get(ctx, rawURL, headers)
 	if err != nil {
@@ -121,6 +159,17 @@ func (c *Client) GetBody(ctx context.Context, rawURL string, headers map[string]
 	}
 	defer resp.Body.Close()
 
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return "", &Error{
+			Method:     http.MethodGet,
+			URL:        rawURL,
+			StatusCode: resp.StatusCode,
+			Attempts:   1,
+			Permanent:  true,
+			Err:        fmt.Errorf("HTTP %s", resp.Status),
+		}
+	}
+
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
 		return "", fmt.Errorf("read response body: %w", err)
@@ -128,33 +177,46 @@ func (c *Client) GetBody(ctx context.Context, rawURL string, headers map[string]
 	return string(body), nil
 }
 
-// do executes an HTTP request with rate limiting and exponential backoff retry.
+// do executes an HTTP request with rate limiting and exponential backoff
+// retry. Retryable failures are retried up to MaxRetries times, waiting at
+// least as long as the server's Retry-After asks for.
 func (c *Client) do(ctx context.Context, method, rawURL string, body io.Reader, headers map[string]string) (*http.Response, error) {
-	var lastErr error
+	if b := fetchBudgetFrom(ctx); b != nil && !b.take() {
+		return nil, Permanent(fmt.Errorf("%s %s: %w", method, rawURL, ErrFetchLimit))
+	}
+
+	limiter := c.limiterFor(rawURL)
+	reqErr := &Error{Method: method, URL: rawURL}
 
 	for attempt := 0; attempt <= c.config.MaxRetries; attempt++ {
 		if attempt > 0 {
 			delay := c.backoffDelay(attempt)
+			if reqErr.RetryAfter > delay {
+				delay = reqErr.RetryAfter
+			}
 			c.logger.Printf("retry %d/%d for %s %s (delay: %v): %v",
-				attempt, c.config.MaxRetries, method, rawURL, delay, lastErr)
+				attempt, c.config.MaxRetries, method, rawURL, delay, reqErr.Err)
 
-			select {
-			case <-ctx.Done():
-				return nil, ctx.Err()
-			case <-time.After(delay):
+			if err := c.sleep(ctx, delay); err != nil {
+				return nil, err
 			}
 		}
 
 		// Wait for rate limiter
-		if err := c.limiter.Wait(ctx); err != nil {
+		if err := limiter.Wait(ctx); err != nil {
 			return nil, fmt.Errorf("rate limiter: %w", err)
 		}
 
+		reqErr.Attempts = attempt + 1
 		resp, err := c.executeRequest(ctx, method, rawURL, body, headers)
 		if err != nil {
-			lastErr = err
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			reqErr.StatusCode, reqErr.RetryAfter, reqErr.Err = 0, 0, err
 			if !isRetryableError(err) {
-				return nil, err
+				reqErr.Permanent = true
+				return nil, reqErr
 			}
 			continue
 		}
@@ -162,12 +224,15 @@ func (c *Client) do(ctx context.Context, method, rawURL string, body io.Reader,
 		// Check for retryable HTTP status codes
 		if isRetryableStatus(resp.StatusCode) {
 			resp.Body.Close()
-			lastErr = fmt.Errorf("HTTP %d: %s", resp.StatusCode, resp.Status)
-			if resp.StatusCode == http.StatusTooManyRequests {
-				// Respect Retry-After header if present
-				if retryAfter := resp.Header.Get("Retry-After"); retryAfter != "" {
-					c.logger.Printf("rate limited by server, Retry-After: %s", retryAfter)
-				}
+			reqErr.StatusCode = resp.StatusCode
+			reqErr.RetryAfter = parseRetryAfter(resp.Header.Get("Retry-After"), time.Now())
+			reqErr.Err = fmt.Errorf("HTTP %s", resp.Status)
+			if reqErr.RetryAfter > c.config.RetryMaxDelay {
+				// The server wants a longer pause than we are willing to
+				// wait; give up now rather than retry early.
+				c.logger.Printf("giving up on %s %s: Retry-After %v exceeds max delay %v",
+					method, rawURL, reqErr.RetryAfter, c.config.RetryMaxDelay)
+				return nil, reqErr
 			}
 			continue
 		}
@@ -175,7 +240,7 @@ func (c *Client) do(ctx context.Context, method, rawURL string, body io.Reader,
 		return resp, nil
 	}
 
-	return nil, fmt.Errorf("max retries exceeded for %s %s: %w", method, rawURL, lastErr)
+	return nil, reqErr
 }
 
 // executeRequest performs a single HTTP request.
@@ -200,9 +265,13 @@ func (c *Client) executeRequest(ctx context.Context, method, rawURL string, body
 	return c.httpClient.Do(req)
 }
 
-// backoffDelay calculates exponential backoff delay for retry attempts.
+// backoffDelay calculates exponential backoff delay for retry attempts,
+// randomized by RetryJitter and capped at RetryMaxDelay.
 func (c *Client) backoffDelay(attempt int) time.Duration {
 	delay := float64(c.config.RetryDelay) * math.Pow(2, float64(attempt-1))
+	if c.config.RetryJitter > 0 {
+		delay *= 1 + c.config.RetryJitter*(2*rand.Float64()-1)
+	}
 	maxDelay := float64(c.config.RetryMaxDelay)
 	if delay > maxDelay {
 		delay = maxDelay
@@ -210,6 +279,37 @@ func (c *Client) backoffDelay(attempt int) time.Duration {
 	return time.Duration(delay)
 }
 
+// parseRetryAfter returns the delay requested by a Retry-After header, given
+// either as seconds or as an HTTP date, or 0 if the header is absent or
+// invalid.
+func parseRetryAfter(value string, now time.Time) time.Duration {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0
+	}
+	if secs, err := strconv.Atoi(value); err == nil {
+		if secs < 0 {
+			return 0
+		}
+		return time.Duration(secs) * time.Second
+	}
+	if t, err := http.ParseTime(value); err == nil && t.After(now) {
+		return t.Sub(now)
+	}
+	return 0
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
 // isRetryableError returns true if the error warrants a retry.
 func isRetryableError(err error) bool {
 	if err == nil {
@@ -245,6 +345,137 @@ func isRetryableStatus(code int) bool {
 	return false
 }
 
+// ─────────────────────────────────────────────────────────────────────────────
+// Fetch limits
+// ─────────────────────────────────────────────────────────────────────────────
+
+// ErrFetchLimit matches requests refused because the limit set with
+// WithFetchLimit was reached. Such failures are also permanent.
+var ErrFetchLimit = errors.New("fetch limit reached")
+
+type fetchBudgetKey struct{}
+
+// fetchBudget counts the requests sent under a fetch limit.
+type fetchBudget struct {
+	mu    sync.Mutex
+	limit int
+	used  int
+}
+
+// take reserves one request, reporting false once the limit is reached.
+func (b *fetchBudget) take() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.used >= b.limit {
+		return false
+	}
+	b.used++
+	return true
+}
+
+// WithFetchLimit returns a context under which clients send at most n
+// requests, counting a retried request once and not counting robots.txt.
+// Further requests fail with ErrFetchLimit without being sent, which a
+// scraper treats like any other permanent failure, so a limited run needs
+// no support from the scrapers.
+func WithFetchLimit(ctx context.Context, n int) context.Context {
+	return context.WithValue(ctx, fetchBudgetKey{}, &fetchBudget{limit: n})
+}
+
+// FetchCount returns the number of requests sent under ctx's fetch limit,
+// or 0 if it has none.
+func FetchCount(ctx context.Context) int {
+	b := fetchBudgetFrom(ctx)
+	if b == nil {
+		return 0
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.used
+}
+
+func fetchBudgetFrom(ctx context.Context) *fetchBudget {
+	b, _ := ctx.Value(fetchBudgetKey{}).(*fetchBudget)
+	return b
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Errors
+// ─────────────────────────────────────────────────────────────────────────────
+
+var (
+	// ErrPermanent matches failures that retrying cannot fix, such as a 404
+	// or a response that does not parse. Test for it with errors.Is.
+	ErrPermanent = errors.New("permanent failure")
+	// ErrRetriesExhausted matches failures that were still transient – a
+	// 429, a 5xx or a network error – when the client gave up.
+	ErrRetriesExhausted = errors.New("retries exhausted")
+)
+
+// Error describes a request the client could not complete. It matches
+// exactly one of ErrPermanent and ErrRetriesExhausted.
+type Error struct {
+	Method string
+	URL    string
+	// StatusCode is the last HTTP status received, or 0 if the last
+	// attempt got no response.
+	StatusCode int
+	Attempts   int
+	Permanent  bool
+	// RetryAfter is the delay the server last asked for, if any.
+	RetryAfter time.Duration
+	Err        error
+}
+
+func (e *Error) Error() string {
+	kind := ErrRetriesExhausted
+	if e.Permanent {
+		kind = ErrPermanent
+	}
+	return fmt.Sprintf("%s %s: %v after %d attempt(s): %v", e.Method, e.URL, kind, e.Attempts, e.Err)
+}
+
+func (e *Error) Unwrap() error { return e.Err }
+
+// Is reports whether target is the sentinel for e's kind of failure.
+func (e *Error) Is(target error) bool {
+	switch target {
+	case ErrPermanent:
+		return e.Permanent
+	case ErrRetriesExhausted:
+		return !e.Permanent
+	}
+	return false
+}
+
+// RateLimited reports whether the server was still answering 429 when the
+// client gave up.
+func (e *Error) RateLimited() bool {
+	return !e.Permanent && e.StatusCode == http.StatusTooManyRequests
+}
+
+// Permanent marks err as a failure retrying cannot fix. Scrapers use it for
+// problems found after a response arrived, such as a body that does not
+// parse. Permanent(nil) is nil.
+func Permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &permanentError{err: err}
+}
+
+type permanentError struct {
+	err error
+}
+
+func (e *permanentError) Error() string        { return e.err.Error() }
+func (e *permanentError) Unwrap() error        { return e.err }
+func (e *permanentError) Is(target error) bool { return target == ErrPermanent }
+
+// ─────────────────────────────────────────────────────────────────────────────
+// robots.txt
+// ─────────────────────────────────────────────────────────────────────────────
+
 // RobotsChecker checks robots.txt compliance.
 type RobotsChecker struct {
 	client    *Client
@@ -274,7 +505,8 @@ func (rc *RobotsChecker) IsAllowed(ctx context.Context, rawURL string) bool {
 
 	content, ok := rc.cache[domain]
 	if !ok {
-		body, err := rc.client.GetBody(ctx, robotsURL, nil)
+		// robots.txt does not count against a fetch limit.
+		body, err := rc.client.GetBody(context.WithValue(ctx, fetchBudgetKey{}, (*fetchBudget)(nil)), robotsURL, nil)
 		if err != nil {
 			rc.cache[domain] = "" // cache empty to avoid repeated failures
 			return true           // allow if robots.txt not accessible
@@ -2,10 +2,13 @@ package httpclient
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"log"
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"sync/atomic"
 	"testing"
 	"time"
 )
@@ -219,3 +222,281 @@ Disallow: /testonly/`
 		})
 	}
 }
+
+// newTestClient returns a client whose waits between attempts are recorded
+// in *delays instead of slept.
+func newTestClient(t *testing.T, cfg Config, delays *[]time.Duration) *Client {
+	t.Helper()
+	client, err := New(cfg, log.New(os.Stderr, "", 0))
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	client.sleep = func(ctx context.Context, d time.Duration) error {
+		*delays = append(*delays, d)
+		return ctx.Err()
+	}
+	return client
+}
+
+func TestClient_429Storm_HonorsRetryAfter(t *testing.T) {
+	var attempts atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if attempts.Add(1) <= 3 {
+			w.Header().Set("Retry-After", "2")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	cfg := DefaultConfig()
+	cfg.RequestsPerMinute = 600
+	cfg.MaxRetries = 3
+	cfg.RetryDelay = 10 * time.Millisecond
+	var delays []time.Duration
+	client := newTestClient(t, cfg, &delays)
+
+	body, err := client.GetBody(context.Background(), server.URL, nil)
+	if err != nil {
+		t.Fatalf("unexpected error after 429 storm: %v", err)
+	}
+	if body != "ok" {
+		t.Errorf("expected body 'ok', got %q", body)
+	}
+	if len(delays) != 3 {
+		t.Fatalf("expected 3 waits, got %v", delays)
+	}
+	for i, d := range delays {
+		if d != 2*time.Second {
+			t.Errorf("wait %d: expected Retry-After of 2s, got %v", i, d)
+		}
+	}
+}
+
+func TestClient_429Storm_ExhaustsRetries(t *testing.T) {
+	var attempts atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts.Add(1)
+		w.Header().Set("Retry-After", "1")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	cfg := DefaultConfig()
+	cfg.RequestsPerMinute = 600
+	cfg.MaxRetries = 2
+	var delays []time.Duration
+	client := newTestClient(t, cfg, &delays)
+
+	_, err := client.Get(context.Background(), server.URL, nil)
+	if !errors.Is(err, ErrRetriesExhausted) || errors.Is(err, ErrPermanent) {
+		t.Fatalf("expected a retries-exhausted error, got %v", err)
+	}
+	var reqErr *Error
+	if !errors.As(err, &reqErr) {
+		t.Fatalf("expected *Error, got %T", err)
+	}
+	if !reqErr.RateLimited() || reqErr.StatusCode != http.StatusTooManyRequests {
+		t.Errorf("expected a rate-limited error with status 429, got %+v", reqErr)
+	}
+	if reqErr.Attempts != 3 || attempts.Load() != 3 {
+		t.Errorf("expected 3 attempts, got %d (server saw %d)", reqErr.Attempts, attempts.Load())
+	}
+}
+
+func TestClient_RetryAfterBeyondMaxDelay_GivesUp(t *testing.T) {
+	var attempts atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts.Add(1)
+		w.Header().Set("Retry-After", "3600")
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	cfg := DefaultConfig()
+	cfg.RequestsPerMinute = 600
+	var delays []time.Duration
+	client := newTestClient(t, cfg, &delays)
+
+	_, err := client.Get(context.Background(), server.URL, nil)
+	var reqErr *Error
+	if !errors.As(err, &reqErr) || !errors.Is(err, ErrRetriesExhausted) {
+		t.Fatalf("expected a retries-exhausted *Error, got %v", err)
+	}
+	if reqErr.RetryAfter != time.Hour {
+		t.Errorf("expected RetryAfter of 1h, got %v", reqErr.RetryAfter)
+	}
+	if attempts.Load() != 1 || len(delays) != 0 {
+		t.Errorf("expected to give up after one attempt, got %d attempts and waits %v", attempts.Load(), delays)
+	}
+}
+
+func TestClient_Flaky500_BacksOffWithJitter(t *testing.T) {
+	var attempts atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if attempts.Add(1) <= 4 {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	cfg := DefaultConfig()
+	cfg.RequestsPerMinute = 600
+	cfg.MaxRetries = 5
+	cfg.RetryDelay = 100 * time.Millisecond
+	cfg.RetryMaxDelay = 500 * time.Millisecond
+	cfg.RetryJitter = 0.2
+	var delays []time.Duration
+	client := newTestClient(t, cfg, &delays)
+
+	if _, err := client.GetBody(context.Background(), server.URL, nil); err != nil {
+		t.Fatalf("unexpected error after flaky 500s: %v", err)
+	}
+	if len(delays) != 4 {
+		t.Fatalf("expected 4 waits, got %v", delays)
+	}
+	for i, d := range delays {
+		base := cfg.RetryDelay << i
+		lo, hi := time.Duration(float64(base)*0.8), time.Duration(float64(base)*1.2)
+		lo, hi = min(lo, cfg.RetryMaxDelay), min(hi, cfg.RetryMaxDelay)
+		if d < lo || d > hi {
+			t.Errorf("wait %d: expected %v..%v, got %v", i, lo, hi, d)
+		}
+	}
+}
+
+func TestClient_NotFound_IsPermanent(t *testing.T) {
+	var attempts atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts.Add(1)
+		http.NotFound(w, r)
+	}))
+	defer server.Close()
+
+	cfg := DefaultConfig()
+	cfg.RequestsPerMinute = 600
+	var delays []time.Duration
+	client := newTestClient(t, cfg, &delays)
+
+	_, err := client.GetBody(context.Background(), server.URL, nil)
+	if !errors.Is(err, ErrPermanent) || errors.Is(err, ErrRetriesExhausted) {
+		t.Fatalf("expected a permanent error, got %v", err)
+	}
+	var reqErr *Error
+	if !errors.As(err, &reqErr) || reqErr.StatusCode != http.StatusNotFound {
+		t.Errorf("expected *Error with status 404, got %v", err)
+	}
+	if attempts.Load() != 1 {
+		t.Errorf("expected a 404 not to be retried, got %d attempts", attempts.Load())
+	}
+}
+
+func TestClient_RateLimitIsPerHost(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	first := httptest.NewServer(handler)
+	defer first.Close()
+	second := httptest.NewServer(handler)
+	defer second.Close()
+
+	cfg := DefaultConfig()
+	cfg.RequestsPerMinute = 1
+	var delays []time.Duration
+	client := newTestClient(t, cfg, &delays)
+
+	for _, u := range []string{first.URL, second.URL} {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		resp, err := client.Get(ctx, u, nil)
+		cancel()
+		if err != nil {
+			t.Fatalf("expected the first request to %s to pass the limiter: %v", u, err)
+		}
+		resp.Body.Close()
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if _, err := client.Get(ctx, first.URL, nil); err == nil {
+		t.Error("expected a second request to the same host to be rate limited")
+	}
+}
+
+func TestClient_FetchLimit(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/robots.txt" {
+			requests.Add(1)
+		}
+		w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	cfg := DefaultConfig()
+	cfg.RequestsPerMinute = 600
+	var delays []time.Duration
+	client := newTestClient(t, cfg, &delays)
+	robots := NewRobotsChecker(client, cfg.UserAgent)
+
+	ctx := WithFetchLimit(context.Background(), 2)
+	for i := 0; i < 2; i++ {
+		if _, err := client.GetBody(ctx, server.URL+"/page", nil); err != nil {
+			t.Fatalf("request %d: %v", i+1, err)
+		}
+	}
+	if !robots.IsAllowed(ctx, server.URL+"/page") {
+		t.Error("robots.txt should still be fetched past the limit")
+	}
+	_, err := client.GetBody(ctx, server.URL+"/page", nil)
+	if !errors.Is(err, ErrFetchLimit) || !errors.Is(err, ErrPermanent) {
+		t.Fatalf("expected a permanent ErrFetchLimit, got %v", err)
+	}
+	if requests.Load() != 2 || FetchCount(ctx) != 2 {
+		t.Errorf("expected 2 requests, got %d (counted %d)", requests.Load(), FetchCount(ctx))
+	}
+	if FetchCount(context.Background()) != 0 {
+		t.Error("expected no count without a limit")
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		value string
+		want  time.Duration
+	}{
+		{"", 0},
+		{"0", 0},
+		{"5", 5 * time.Second},
+		{" 120 ", 2 * time.Minute},
+		{"-3", 0},
+		{"soon", 0},
+		{now.Add(30 * time.Second).Format(http.TimeFormat), 30 * time.Second},
+		{now.Add(-time.Minute).Format(http.TimeFormat), 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			if got := parseRetryAfter(tt.value, now); got != tt.want {
+				t.Errorf("parseRetryAfter(%q) = %v, want %v", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPermanent(t *testing.T) {
+	if Permanent(nil) != nil {
+		t.Error("expected Permanent(nil) to be nil")
+	}
+	cause := errors.New("unexpected token")
+	err := fmt.Errorf("fetch page 2: %w", Permanent(fmt.Errorf("parse HTML: %w", cause)))
+	if !errors.Is(err, ErrPermanent) || !errors.Is(err, cause) {
+		t.Errorf("expected %v to match ErrPermanent and its cause", err)
+	}
+	if errors.Is(err, ErrRetriesExhausted) {
+		t.Error("a permanent error must not match ErrRetriesExhausted")
+	}
+}
@@ -3,6 +3,7 @@ package model
 
 import (
 	"database/sql"
+	"slices"
 	"time"
 
 	"github.com/google/uuid"
@@ -30,6 +31,35 @@ const (
 	StatusUnknown JobStatus = "unknown"
 )
 
+// ExpiryReason records why a job was marked expired.
+type ExpiryReason string
+
+const (
+	// ExpiryStale means the job stopped appearing in scrape results.
+	ExpiryStale ExpiryReason = "stale"
+	// ExpiryNotFound means the posting URL returned 404 or 410.
+	ExpiryNotFound ExpiryReason = "not_found"
+	// ExpiryRedirected means the posting redirected to a generic careers page.
+	ExpiryRedirected ExpiryReason = "redirected"
+	// ExpiryClosedNotice means the page states applications are closed.
+	ExpiryClosedNotice ExpiryReason = "closed_notice"
+)
+
+// CareerPageSourceType selects which scraper handles a company career page.
+type CareerPageSourceType string
+
+const (
+	// CareerPageHTML scrapes the page with the configured CSS selectors or
+	// generic JSON API settings.
+	CareerPageHTML CareerPageSourceType = "html"
+	// CareerPageGreenhouse reads the public Greenhouse job board API.
+	CareerPageGreenhouse CareerPageSourceType = "greenhouse"
+	// CareerPageLever reads the public Lever postings API.
+	CareerPageLever CareerPageSourceType = "lever"
+	// CareerPageFeed reads an RSS 2.0 or Atom feed at the career page URL.
+	CareerPageFeed CareerPageSourceType = "feed"
+)
+
 // ExperienceLevel represents the required experience level.
 type ExperienceLevel string
 
@@ -66,6 +96,17 @@ const (
 	LocationUnknown WorkLocationType = "unknown"
 )
 
+// SalaryPeriod is the period a salary amount is paid for.
+type SalaryPeriod string
+
+const (
+	SalaryPerHour  SalaryPeriod = "hour"
+	SalaryPerDay   SalaryPeriod = "day"
+	SalaryPerWeek  SalaryPeriod = "week"
+	SalaryPerMonth SalaryPeriod = "month"
+	SalaryPerYear  SalaryPeriod = "year"
+)
+
 // ScrapeStatus represents the status of a scraping run.
 type ScrapeStatus string
 
@@ -75,9 +116,17 @@ const (
 	ScrapeStatusCompleted   ScrapeStatus = "completed"
 	ScrapeStatusFailed      ScrapeStatus = "failed"
 	ScrapeStatusRateLimited ScrapeStatus = "rate_limited"
+	// ScrapeStatusInterrupted marks a run cancelled by shutdown after
+	// storing the pages it had scraped.
+	ScrapeStatusInterrupted ScrapeStatus = "interrupted"
+	// ScrapeStatusSkipped marks a scheduled run that did not scrape because
+	// the schedule was paused or the scraper disabled; the error message
+	// says which.
+	ScrapeStatusSkipped ScrapeStatus = "skipped"
 )
 
-// Company represents a deduplicated company record.
+// Company represents a deduplicated company record. Scraped company names
+// resolve to a company through its aliases or website domain.
 type Company struct {
 	ID             uuid.UUID      `db:"id" json:"id"`
 	Name           string         `db:"name" json:"name"`
@@ -89,8 +138,48 @@ type Company struct {
 	SizeRange      sql.NullString `db:"size_range" json:"size_range,omitempty"`
 	Headquarters   sql.NullString `db:"headquarters" json:"headquarters,omitempty"`
 	Description    sql.NullString `db:"description" json:"description,omitempty"`
-	CreatedAt      time.Time      `db:"created_at" json:"created_at"`
-	UpdatedAt      time.Time      `db:"updated_at" json:"updated_at"`
+	// WebsiteDomain is the registrable domain of the company website, e.g.
+	// "google.com".
+	WebsiteDomain sql.NullString `db:"website_domain" json:"website_domain,omitempty"`
+	// Aliases are the normalised names that resolve to the company,
+	// NormalizedName among them.
+	Aliases []string `json:"aliases"`
+	// NeedsReview is set on companies created at ingest, until an admin
+	// updates or merges them.
+	NeedsReview bool      `db:"needs_review" json:"needs_review"`
+	CreatedAt   time.Time `db:"created_at" json:"created_at"`
+	UpdatedAt   time.Time `db:"updated_at" json:"updated_at"`
+}
+
+// CompanyFilter holds filter criteria for listing companies.
+type CompanyFilter struct {
+	// Query matches any part of the name or an alias.
+	Query       string
+	NeedsReview *bool
+	Page        int
+	PageSize    int
+}
+
+// CompanyUpdate holds the company fields an admin changes; nil fields are
+// kept. An empty string clears a field.
+type CompanyUpdate struct {
+	Name *string `json:"name,omitempty"`
+	// Aliases replaces the company's aliases. Names are normalised, and
+	// the normalised company name is always kept.
+	Aliases       *[]string `json:"aliases,omitempty"`
+	WebsiteDomain *string   `json:"website_domain,omitempty"`
+	Industry      *string   `json:"industry,omitempty"`
+	SizeRange     *string   `json:"size_range,omitempty"`
+	NeedsReview   *bool     `json:"needs_review,omitempty"`
+}
+
+// CompanyMerge is the result of merging one company into another.
+type CompanyMerge struct {
+	// Company is the merged company, with the aliases of both.
+	Company          Company   `json:"company"`
+	MergedID         uuid.UUID `json:"merged_id"`
+	JobsMoved        int       `json:"jobs_moved"`
+	CareerPagesMoved int       `json:"career_pages_moved"`
 }
 
 // Job represents a single job posting.
@@ -114,17 +203,22 @@ type Job struct {
 	ExperienceLevel ExperienceLevel  `db:"experience_level" json:"experience_level"`
 	RequiredSkills  pq.StringArray   `db:"required_skills" json:"required_skills,omitempty"`
 	PreferredSkills pq.StringArray   `db:"preferred_skills" json:"preferred_skills,omitempty"`
+	NiceToHaveSkills pq.StringArray  `db:"nice_to_have_skills" json:"nice_to_have_skills,omitempty"`
 	SalaryMin       sql.NullInt32    `db:"salary_min" json:"salary_min,omitempty"`
 	SalaryMax       sql.NullInt32    `db:"salary_max" json:"salary_max,omitempty"`
 	SalaryCurrency  string           `db:"salary_currency" json:"salary_currency"`
 	SalaryRaw       sql.NullString   `db:"salary_raw" json:"salary_raw,omitempty"`
+	SalaryPeriod    SalaryPeriod     `db:"salary_period" json:"salary_period,omitempty"`
 	ApplicationURL  string           `db:"application_url" json:"application_url"`
+	SourceURLs      pq.StringArray   `db:"source_urls" json:"source_urls,omitempty"`
 	CompanyURL      sql.NullString   `db:"company_url" json:"company_url,omitempty"`
 	PostedAt        sql.NullTime     `db:"posted_at" json:"posted_at,omitempty"`
 	ExpiresAt       sql.NullTime     `db:"expires_at" json:"expires_at,omitempty"`
 	ScrapedAt       time.Time        `db:"scraped_at" json:"scraped_at"`
 	LastSeenAt      time.Time        `db:"last_seen_at" json:"last_seen_at"`
 	Status          JobStatus        `db:"status" json:"status"`
+	ExpiryReason    ExpiryReason     `db:"expiry_reason" json:"expiry_reason,omitempty"`
+	ExpiryCheckedAt sql.NullTime     `db:"expiry_checked_at" json:"expiry_checked_at,omitempty"`
 	IsFeatured      bool             `db:"is_featured" json:"is_featured"`
 	RawData         []byte           `db:"raw_data" json:"raw_data,omitempty"`
 	CreatedAt       time.Time        `db:"created_at" json:"created_at"`
@@ -135,6 +229,7 @@ type Job struct {
 type ScrapeRun struct {
 	ID             uuid.UUID    `db:"id" json:"id"`
 	Source         JobSource    `db:"source" json:"source"`
+	ScraperName    string       `db:"scraper_name" json:"scraper_name"`
 	SearchQuery    string       `db:"search_query" json:"search_query"`
 	SearchLocation string       `db:"search_location" json:"search_location"`
 	Status         ScrapeStatus `db:"status" json:"status"`
@@ -173,6 +268,7 @@ type CompanyCareerPage struct {
 	CompanyID     *uuid.UUID `db:"company_id" json:"company_id,omitempty"`
 	CompanyName   string     `db:"company_name" json:"company_name"`
 	CareerPageURL string     `db:"career_page_url" json:"career_page_url"`
+	SourceType    CareerPageSourceType `db:"source_type" json:"source_type"`
 	Selectors     []byte     `db:"selectors" json:"selectors"`
 	IsEnabled     bool       `db:"is_enabled" json:"is_enabled"`
 	LastScrapedAt sql.NullTime `db:"last_scraped_at" json:"last_scraped_at,omitempty"`
@@ -184,31 +280,83 @@ type CompanyCareerPage struct {
 // ScrapedJob is the intermediate representation returned by scrapers
 // before being stored in the database.
 type ScrapedJob struct {
-	Source          JobSource
-	ExternalID      string
-	CompanyName     string
-	Title           string
-	Description     string
-	DescriptionHTML string
-	Industry        string
-	LocationRaw     string
-	LocationCity    string
-	LocationState   string
-	LocationCountry string
-	LocationType    WorkLocationType
-	EmploymentType  EmploymentType
-	ExperienceLevel ExperienceLevel
-	RequiredSkills  []string
-	PreferredSkills []string
-	SalaryMin       *int
-	SalaryMax       *int
-	SalaryCurrency  string
-	SalaryRaw       string
-	ApplicationURL  string
-	CompanyURL      string
-	PostedAt        *time.Time
-	ExpiresAt       *time.Time
-	RawData         map[string]interface{}
+	Source           JobSource              `json:"source"`
+	ExternalID       string                 `json:"external_id,omitempty"`
+	CompanyName      string                 `json:"company_name"`
+	Title            string                 `json:"title"`
+	Description      string                 `json:"description,omitempty"`
+	DescriptionHTML  string                 `json:"description_html,omitempty"`
+	Industry         string                 `json:"industry,omitempty"`
+	LocationRaw      string                 `json:"location_raw,omitempty"`
+	LocationCity     string                 `json:"location_city,omitempty"`
+	LocationState    string                 `json:"location_state,omitempty"`
+	LocationCountry  string                 `json:"location_country,omitempty"`
+	LocationType     WorkLocationType       `json:"location_type"`
+	EmploymentType   EmploymentType         `json:"employment_type"`
+	ExperienceLevel  ExperienceLevel        `json:"experience_level"`
+	RequiredSkills   []string               `json:"required_skills,omitempty"`
+	PreferredSkills  []string               `json:"preferred_skills,omitempty"`
+	NiceToHaveSkills []string               `json:"nice_to_have_skills,omitempty"`
+	SalaryMin        *int                   `json:"salary_min,omitempty"`
+	SalaryMax        *int                   `json:"salary_max,omitempty"`
+	SalaryCurrency   string                 `json:"salary_currency,omitempty"`
+	SalaryRaw        string                 `json:"salary_raw,omitempty"`
+	SalaryPeriod     SalaryPeriod           `json:"salary_period,omitempty"`
+	ApplicationURL   string                 `json:"application_url"`
+	CompanyURL       string                 `json:"company_url,omitempty"`
+	PostedAt         *time.Time             `json:"posted_at,omitempty"`
+	ExpiresAt        *time.Time             `json:"expires_at,omitempty"`
+	RawData          map[string]interface{} `json:"raw_data,omitempty"`
+	// ScraperName is the name of the scraper that found the posting. It is
+	// set by the scheduler and stored as the scraper that last saw the job.
+	ScraperName string `json:"-"`
+}
+
+// DryRunAction is what storing a scraped job would do, as found by a dry
+// run.
+type DryRunAction string
+
+const (
+	DryRunNew       DryRunAction = "new"
+	DryRunChanged   DryRunAction = "changed"
+	DryRunUnchanged DryRunAction = "unchanged"
+	// DryRunDuplicate is a posting that fuzzy deduplication would merge
+	// into an existing job.
+	DryRunDuplicate DryRunAction = "duplicate"
+)
+
+// DryRunJob is a job parsed by a dry run and the write it would cause.
+type DryRunJob struct {
+	Action DryRunAction `json:"action"`
+	// ExistingID is the stored job that would be updated or merged into.
+	ExistingID *uuid.UUID `json:"existing_id,omitempty"`
+	// ChangedFields lists the stored columns an update would change.
+	ChangedFields []string   `json:"changed_fields,omitempty"`
+	Job           ScrapedJob `json:"job"`
+}
+
+// DryRunSummary counts the jobs of a dry run by action.
+type DryRunSummary struct {
+	New       int `json:"new"`
+	Changed   int `json:"changed"`
+	Unchanged int `json:"unchanged"`
+	Duplicate int `json:"duplicate"`
+	// Failed counts jobs that could not be compared with the stored jobs.
+	Failed int `json:"failed"`
+}
+
+// DryRunReport is the result of running a scraper without storing its
+// jobs.
+type DryRunReport struct {
+	Scraper      string `json:"scraper"`
+	PageLimit    int    `json:"page_limit"`
+	PagesFetched int    `json:"pages_fetched"`
+	// Truncated is set when the scraper stopped at the page limit.
+	Truncated bool `json:"truncated"`
+	// Error is the scrape error that ended the run early, if any.
+	Error   string        `json:"error,omitempty"`
+	Summary DryRunSummary `json:"summary"`
+	Jobs    []DryRunJob   `json:"jobs"`
 }
 
 // SearchParams holds parameters for job search queries.
@@ -237,9 +385,108 @@ type JobFilter struct {
 	SalaryMax       *int
 	PostedAfter     *time.Time
 	CompanyName     string
+	// CompanyID keeps the jobs resolved to the company.
+	CompanyID       *uuid.UUID
 	TitleSearch     string
 	Page            int
 	PageSize        int
+	// After, if set, returns the jobs after the cursor in newest-first order
+	// instead of the page given by Page.
+	After *JobCursor
+}
+
+// JobSearchFilter holds the criteria for the user-facing full-text job
+// search. Only active jobs are searched unless IncludeExpired is set.
+type JobSearchFilter struct {
+	// IncludeExpired also searches expired jobs.
+	IncludeExpired bool
+	// Query is matched against the title and description.
+	Query string
+	// Location matches any part of the job's location.
+	Location    string
+	RemoteOnly  bool
+	CompanyName string
+	// CompanyID keeps the jobs resolved to the company.
+	CompanyID   *uuid.UUID
+	Source      JobSource
+	PostedAfter *time.Time
+	// MinSalary keeps jobs whose highest salary, converted to a yearly
+	// amount, is at least MinSalary in Currency. Jobs without a salary are
+	// excluded.
+	MinSalary *int
+	// Currency keeps jobs with a salary in this ISO 4217 code. It is USD
+	// when MinSalary is set without it.
+	Currency string
+	// Sort orders the results. The default is SortRelevance with a query
+	// and SortRecent without one.
+	Sort   JobSort
+	Limit  int
+	Offset int
+	// After, if set, returns the jobs after the cursor in SortRecent order
+	// instead of skipping Offset jobs.
+	After *JobCursor
+}
+
+// JobSort is the result order of a job search.
+type JobSort string
+
+const (
+	// SortRelevance orders by text relevance combined with recency.
+	SortRelevance JobSort = "relevance"
+	// SortRecent orders newest first. It is the only order that supports
+	// cursor pagination.
+	SortRecent JobSort = "recent"
+)
+
+// JobCursor is the position of a job in newest-first order, used for
+// keyset pagination. Jobs are ordered by posting time, or by scrape time
+// for jobs without a posting date, then by ID.
+type JobCursor struct {
+	PostedAt time.Time
+	ID       uuid.UUID
+}
+
+// CursorOf returns the cursor positioned at j.
+func CursorOf(j *Job) JobCursor {
+	if j.PostedAt.Valid {
+		return JobCursor{PostedAt: j.PostedAt.Time, ID: j.ID}
+	}
+	return JobCursor{PostedAt: j.ScrapedAt, ID: j.ID}
+}
+
+// JobSearchResult is a job returned by the full-text search with its
+// relevance score.
+type JobSearchResult struct {
+	Job
+	Relevance float64 `json:"relevance"`
+}
+
+// ScrapeRunFilter holds filter criteria for querying scrape run history.
+type ScrapeRunFilter struct {
+	ScraperName string
+	Status      ScrapeStatus
+	// StartedAfter and StartedBefore bound the run's creation time.
+	StartedAfter  *time.Time
+	StartedBefore *time.Time
+	Page          int
+	PageSize      int
+}
+
+// DedupLogEntry records a scraped posting that was merged into an existing
+// job because its title and company closely matched.
+type DedupLogEntry struct {
+	ID             uuid.UUID `db:"id" json:"id"`
+	JobID          uuid.UUID `db:"job_id" json:"job_id"`
+	DedupHash      string    `db:"dedup_hash" json:"dedup_hash"`
+	Source         JobSource `db:"source" json:"source"`
+	ExternalID     string    `db:"external_id" json:"external_id,omitempty"`
+	Title          string    `db:"title" json:"title"`
+	CompanyName    string    `db:"company_name" json:"company_name"`
+	ApplicationURL string    `db:"application_url" json:"application_url"`
+	CanonicalTitle string    `db:"canonical_title" json:"canonical_title"`
+	Similarity     float64   `db:"similarity" json:"similarity"`
+	Threshold      float64   `db:"threshold" json:"threshold"`
+	CreatedAt      time.Time `db:"created_at" json:"created_at"`
 }
 
 // AdminStats holds aggregated statistics for the admin dashboard.
@@ -262,3 +509,265 @@ type SourceStat struct {
 	AvgDurationMs   float64 `json:"avg_duration_ms"`
 	LastSuccessfulRun *time.Time `json:"last_successful_run,omitempty"`
 }
+
+// ScraperHealthStatus summarizes whether a scraper still finds jobs.
+type ScraperHealthStatus string
+
+const (
+	ScraperHealthy  ScraperHealthStatus = "healthy"
+	ScraperDegraded ScraperHealthStatus = "degraded"
+	ScraperFailing  ScraperHealthStatus = "failing"
+)
+
+// ScraperHealth is the run health of one scraper, refreshed from its scrape
+// runs after each run. Only completed, failed and rate limited runs count.
+type ScraperHealth struct {
+	ScraperName   string              `json:"scraper"`
+	Status        ScraperHealthStatus `json:"status"`
+	LastRunAt     *time.Time          `json:"last_run_at,omitempty"`
+	LastSuccessAt *time.Time          `json:"last_success_at,omitempty"`
+	// Jobs found by the last completed run.
+	LastJobsFound       int `json:"last_jobs_found"`
+	ConsecutiveFailures int `json:"consecutive_failures"`
+	// Average jobs found by the completed runs in the seven days before the
+	// last completed run, and how many runs that is.
+	AvgJobsFound7d float64   `json:"avg_jobs_found_7d"`
+	Runs7d         int       `json:"runs_7d"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+// ExpiryRun is one pass of the stale job expiry, which marks jobs expired
+// once the scraper that last saw them has not seen them for its stale
+// duration.
+type ExpiryRun struct {
+	ID          uuid.UUID       `json:"id"`
+	StartedAt   time.Time       `json:"started_at"`
+	CompletedAt time.Time       `json:"completed_at"`
+	JobsExpired int             `json:"jobs_expired"`
+	Scrapers    []ScraperExpiry `json:"scrapers"`
+	// Error is the first error of the pass; the scrapers after it were
+	// still expired.
+	Error string `json:"error,omitempty"`
+}
+
+// ScraperExpiry is the part of an expiry run for the jobs last seen by one
+// scraper. An empty Scraper stands for the jobs of no registered scraper,
+// which are expired after the default stale duration.
+type ScraperExpiry struct {
+	Scraper     string    `json:"scraper"`
+	StaleAfter  string    `json:"stale_after"` // e.g. "168h0m0s"
+	Cutoff      time.Time `json:"cutoff"`
+	JobsExpired int       `json:"jobs_expired"`
+}
+
+// SchedulerControls is the runtime control state of the scheduler, set
+// through the admin API and persisted so that it survives restarts.
+type SchedulerControls struct {
+	// Paused stops scheduled runs of every scraper.
+	Paused bool
+	// ScraperEnabled holds the scrapers that have been enabled or disabled,
+	// by name. Scrapers not listed are enabled.
+	ScraperEnabled map[string]bool
+}
+
+// WebhookSubscription asks for new jobs matching its criteria to be POSTed
+// to URL. Empty criteria match every job.
+type WebhookSubscription struct {
+	ID   uuid.UUID `db:"id" json:"id"`
+	Name string    `db:"name" json:"name"`
+	URL  string    `db:"url" json:"url"`
+	// Secret signs each payload; it is only returned when the subscription
+	// is created.
+	Secret string `db:"secret" json:"-"`
+	// Skills matches jobs listing any of these skills.
+	Skills pq.StringArray `db:"skills" json:"skills"`
+	// Keywords matches jobs whose title or description contains any of
+	// these words.
+	Keywords pq.StringArray `db:"keywords" json:"keywords"`
+	// Location matches any part of the job's location.
+	Location   string    `db:"location" json:"location,omitempty"`
+	RemoteOnly bool      `db:"remote_only" json:"remote_only"`
+	IsActive   bool      `db:"is_active" json:"is_active"`
+	CreatedAt  time.Time `db:"created_at" json:"created_at"`
+	UpdatedAt  time.Time `db:"updated_at" json:"updated_at"`
+}
+
+// WebhookDeliveryStatus is the state of one webhook delivery.
+type WebhookDeliveryStatus string
+
+const (
+	// WebhookDeliveryPending is queued or waiting for a retry.
+	WebhookDeliveryPending WebhookDeliveryStatus = "pending"
+	// WebhookDeliveryDelivered was accepted with a 2xx response.
+	WebhookDeliveryDelivered WebhookDeliveryStatus = "delivered"
+	// WebhookDeliveryDeadLetter failed the maximum number of attempts.
+	WebhookDeliveryDeadLetter WebhookDeliveryStatus = "dead_letter"
+)
+
+// WebhookDelivery is one job sent, or to be sent, to one subscription.
+type WebhookDelivery struct {
+	ID             uuid.UUID             `db:"id" json:"id"`
+	SubscriptionID uuid.UUID             `db:"subscription_id" json:"subscription_id"`
+	JobID          uuid.UUID             `db:"job_id" json:"job_id"`
+	Status         WebhookDeliveryStatus `db:"status" json:"status"`
+	Payload        []byte                `db:"payload" json:"-"`
+	Attempts       int                   `db:"attempts" json:"attempts"`
+	LastStatusCode sql.NullInt32         `db:"last_status_code" json:"last_status_code,omitempty"`
+	LastError      string                `db:"last_error" json:"last_error,omitempty"`
+	NextAttemptAt  time.Time             `db:"next_attempt_at" json:"next_attempt_at"`
+	DeliveredAt    sql.NullTime          `db:"delivered_at" json:"delivered_at,omitempty"`
+	CreatedAt      time.Time             `db:"created_at" json:"created_at"`
+	UpdatedAt      time.Time             `db:"updated_at" json:"updated_at"`
+}
+
+// WebhookAttempt is the outcome of one delivery attempt.
+type WebhookAttempt struct {
+	Status WebhookDeliveryStatus
+	// StatusCode is the endpoint's HTTP status, or 0 if it did not answer.
+	StatusCode int
+	Error      string
+	// NextAttemptAt is when a pending delivery is retried.
+	NextAttemptAt time.Time
+}
+
+// WebhookDeliveryFilter holds filter criteria for listing deliveries.
+type WebhookDeliveryFilter struct {
+	SubscriptionID *uuid.UUID
+	Status         WebhookDeliveryStatus
+	Limit          int
+}
+
+// SkillStatsWatermark is the last job folded into the skill demand stats.
+// Jobs are folded in (CreatedAt, JobID) order; the zero value means no job
+// has been folded in yet.
+type SkillStatsWatermark struct {
+	CreatedAt time.Time
+	JobID     uuid.UUID
+}
+
+// SkillStatsJob is the part of a job that skill demand stats are computed
+// from.
+type SkillStatsJob struct {
+	ID           uuid.UUID
+	Title        string
+	Description  string
+	LocationCity string
+	LocationRaw  string
+	LocationType WorkLocationType
+	PostedAt     sql.NullTime
+	CreatedAt    time.Time
+}
+
+// SkillStatCount is the number of new jobs mentioning a skill for one
+// role keyword, location and week.
+type SkillStatCount struct {
+	Skill       string
+	RoleKeyword string
+	Location    string
+	WeekStart   time.Time
+	Jobs        int
+}
+
+// SkillDemandFilter holds filter criteria for skill demand stats. Role and
+// Location match any part of the stored role keyword and location.
+type SkillDemandFilter struct {
+	Role     string
+	Location string
+	// From and To are the first and last week included, as week starts.
+	From  time.Time
+	To    time.Time
+	Limit int
+}
+
+// SkillDemand is the number of jobs mentioning a skill in a period, with
+// the counts of the period's last week and the week before it.
+type SkillDemand struct {
+	Skill string `json:"skill"`
+	Name  string `json:"name"`
+	Jobs  int    `json:"jobs"`
+	// ThisWeek counts the jobs of the period's last week, and LastWeek the
+	// week before it.
+	ThisWeek int `json:"this_week"`
+	LastWeek int `json:"last_week"`
+	// WeekOverWeekChange is the relative change from LastWeek to ThisWeek
+	// (0.25 for +25%), or nil when LastWeek is 0.
+	WeekOverWeekChange *float64 `json:"week_over_week_change"`
+}
+
+// ApplicationStatus is where a user is in applying for a saved job.
+type ApplicationStatus string
+
+const (
+	ApplicationSaved        ApplicationStatus = "saved"
+	ApplicationApplied      ApplicationStatus = "applied"
+	ApplicationInterviewing ApplicationStatus = "interviewing"
+	ApplicationOffer        ApplicationStatus = "offer"
+	ApplicationRejected     ApplicationStatus = "rejected"
+)
+
+// applicationFunnel orders the statuses a saved job moves through before it
+// ends with an offer. ApplicationRejected can end it at any stage.
+var applicationFunnel = []ApplicationStatus{
+	ApplicationSaved, ApplicationApplied, ApplicationInterviewing, ApplicationOffer,
+}
+
+// Valid reports whether s is a known application status.
+func (s ApplicationStatus) Valid() bool {
+	return s == ApplicationRejected || slices.Contains(applicationFunnel, s)
+}
+
+// CanTransitionTo reports whether a saved job may move from s to next.
+// Statuses only move forward through the funnel, possibly skipping steps,
+// and any status but ApplicationRejected itself may move to
+// ApplicationRejected. Nothing leaves ApplicationRejected.
+func (s ApplicationStatus) CanTransitionTo(next ApplicationStatus) bool {
+	if s == ApplicationRejected || !s.Valid() {
+		return false
+	}
+	if next == ApplicationRejected {
+		return true
+	}
+	from, to := slices.Index(applicationFunnel, s), slices.Index(applicationFunnel, next)
+	return to > from
+}
+
+// SavedJob is a job bookmarked by a user, with the user's progress in
+// applying for it.
+type SavedJob struct {
+	ID     uuid.UUID         `json:"id"`
+	UserID uuid.UUID         `json:"user_id"`
+	JobID  uuid.UUID         `json:"job_id"`
+	Status ApplicationStatus `json:"status"`
+	// Title, CompanyName and ApplicationURL are copied from the job when
+	// it is saved, so that the entry can still be shown without it.
+	Title          string `json:"title"`
+	CompanyName    string `json:"company_name"`
+	ApplicationURL string `json:"application_url"`
+	// The time the job was saved and each later status was reached. A
+	// status that was skipped has no time.
+	SavedAt        time.Time  `json:"saved_at"`
+	AppliedAt      *time.Time `json:"applied_at,omitempty"`
+	InterviewingAt *time.Time `json:"interviewing_at,omitempty"`
+	OfferAt        *time.Time `json:"offer_at,omitempty"`
+	RejectedAt     *time.Time `json:"rejected_at,omitempty"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+	// MatchScore is the job's acceptance likelihood score against the
+	// user's profile as it was at ScoreComputedAt, set when the gateway
+	// re-scores the saved jobs. Both are nil until then.
+	MatchScore      *float64   `json:"match_score,omitempty"`
+	ScoreComputedAt *time.Time `json:"score_computed_at,omitempty"`
+	// Job is the current job data when listed, nil if the job is no
+	// longer stored.
+	Job *Job `json:"job,omitempty"`
+	// JobRemoved reports that the job has since been removed by its
+	// source: it is no longer active, or no longer stored.
+	JobRemoved bool `json:"job_removed"`
+}
+
+// SavedJobFilter holds filter criteria for listing a user's saved jobs.
+type SavedJobFilter struct {
+	// Status, if set, lists only the saved jobs with this status.
+	Status ApplicationStatus
+	Limit  int
+	Offset int
+}
@@ -0,0 +1,33 @@
+package model
+
+import "testing"
+
+func TestApplicationStatus_CanTransitionTo(t *testing.T) {
+	tests := []struct {
+		from, to ApplicationStatus
+		want     bool
+	}{
+		{ApplicationSaved, ApplicationApplied, true},
+		{ApplicationApplied, ApplicationInterviewing, true},
+		{ApplicationInterviewing, ApplicationOffer, true},
+		// Steps may be skipped.
+		{ApplicationSaved, ApplicationInterviewing, true},
+		// Any stage may end in a rejection, even after an offer.
+		{ApplicationSaved, ApplicationRejected, true},
+		{ApplicationOffer, ApplicationRejected, true},
+		// Statuses never move back.
+		{ApplicationOffer, ApplicationSaved, false},
+		{ApplicationInterviewing, ApplicationApplied, false},
+		{ApplicationRejected, ApplicationApplied, false},
+		{ApplicationRejected, ApplicationRejected, false},
+		{ApplicationApplied, ApplicationApplied, false},
+		// Unknown statuses never transition.
+		{ApplicationSaved, "hired", false},
+		{"hired", ApplicationOffer, false},
+	}
+	for _, tt := range tests {
+		if got := tt.from.CanTransitionTo(tt.to); got != tt.want {
+			t.Errorf("%s.CanTransitionTo(%s) = %v, want %v", tt.from, tt.to, got, tt.want)
+		}
+	}
+}
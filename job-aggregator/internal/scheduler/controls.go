@@ -0,0 +1,180 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/learnbot/job-aggregator/internal/model"
+	"github.com/learnbot/job-aggregator/internal/scraper"
+)
+
+// Error messages of skipped runs.
+const (
+	skipPaused   = "skipped (paused)"
+	skipDisabled = "skipped (disabled)"
+)
+
+// SchedulerStatus is the runtime control state of the schedule.
+type SchedulerStatus struct {
+	// Paused is true while scheduled runs are skipped. Next run times keep
+	// advancing, so resuming does not set off a burst of missed runs.
+	Paused bool `json:"paused"`
+	// Running is true while a full scraping cycle is in progress.
+	Running bool `json:"running"`
+	// NextRun is the earliest next run time over all scrapers.
+	NextRun  time.Time       `json:"next_run"`
+	Scrapers []ScraperStatus `json:"scrapers"`
+}
+
+// ScraperStatus is the schedule of a scraper and whether it is enabled.
+type ScraperStatus struct {
+	ScraperSchedule
+	Enabled bool `json:"enabled"`
+}
+
+// LoadControls applies the pause state and disabled scrapers stored through
+// Pause, Resume and SetScraperEnabled, so that they survive restarts. It is
+// called once before StartSchedule. Stored flags of scrapers that are no
+// longer registered are kept, and apply if the scraper is added again.
+func (s *Scheduler) LoadControls(ctx context.Context) error {
+	c, err := s.repo.GetSchedulerControls(ctx)
+	if err != nil {
+		return fmt.Errorf("load scheduler controls: %w", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.paused = c.Paused
+	for name, enabled := range c.ScraperEnabled {
+		s.disabled[name] = !enabled
+	}
+	if s.paused {
+		s.logger.Println("[scheduler] schedule is paused")
+	}
+	for name, disabled := range s.disabled {
+		if disabled {
+			s.logger.Printf("[scheduler] %s is disabled", name)
+		}
+	}
+	return nil
+}
+
+// Pause skips every scheduled run from now on until Resume is called. Runs
+// already in progress finish; manual runs are not affected. The state is
+// persisted.
+func (s *Scheduler) Pause(ctx context.Context) error {
+	return s.setPaused(ctx, true)
+}
+
+// Resume undoes Pause. Scrapers run again at their next scheduled time.
+func (s *Scheduler) Resume(ctx context.Context) error {
+	return s.setPaused(ctx, false)
+}
+
+func (s *Scheduler) setPaused(ctx context.Context, paused bool) error {
+	// The flag is stored before it takes effect, so that a failed write
+	// leaves the scheduler as it was, and controlMu keeps concurrent
+	// changes from being applied in a different order than they were
+	// stored.
+	s.controlMu.Lock()
+	defer s.controlMu.Unlock()
+	if err := s.repo.SetSchedulerPaused(ctx, paused); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.paused = paused
+	if paused {
+		s.logger.Println("[scheduler] schedule paused")
+	} else {
+		s.logger.Println("[scheduler] schedule resumed")
+	}
+	return nil
+}
+
+// SetScraperEnabled enables or disables the named scraper and returns its
+// new status. A disabled scraper is skipped by scheduled runs and scraping
+// cycles, but can still be dry run; a run already in progress finishes. The
+// state is persisted.
+func (s *Scheduler) SetScraperEnabled(ctx context.Context, name string, enabled bool) (ScraperStatus, error) {
+	s.controlMu.Lock()
+	defer s.controlMu.Unlock()
+
+	s.mu.Lock()
+	_, ok := s.schedules[name]
+	s.mu.Unlock()
+	if !ok {
+		return ScraperStatus{}, fmt.Errorf("%w %q", ErrUnknownScraper, name)
+	}
+	if err := s.repo.SetScraperEnabled(ctx, name, enabled); err != nil {
+		return ScraperStatus{}, err
+	}
+
+	s.mu.Lock()
+	s.disabled[name] = !enabled
+	s.mu.Unlock()
+	if enabled {
+		s.logger.Printf("[scheduler] %s enabled", name)
+	} else {
+		s.logger.Printf("[scheduler] %s disabled", name)
+	}
+
+	for _, st := range s.Status().Scrapers {
+		if st.Scraper == name {
+			return st, nil
+		}
+	}
+	return ScraperStatus{}, fmt.Errorf("%w %q", ErrUnknownScraper, name)
+}
+
+// Status returns whether the schedule is paused, and the schedule and
+// enabled flag of every scraper, sorted by scraper name.
+func (s *Scheduler) Status() SchedulerStatus {
+	schedules := s.Schedules()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	st := SchedulerStatus{
+		Paused:   s.paused,
+		Running:  s.running,
+		Scrapers: make([]ScraperStatus, 0, len(schedules)),
+	}
+	for _, sc := range schedules {
+		st.Scrapers = append(st.Scrapers, ScraperStatus{ScraperSchedule: sc, Enabled: !s.disabled[sc.Scraper]})
+		if !sc.NextRun.IsZero() && (st.NextRun.IsZero() || sc.NextRun.Before(st.NextRun)) {
+			st.NextRun = sc.NextRun
+		}
+	}
+	return st
+}
+
+// skipReason returns why a run of sc must be skipped, or "" if it may run.
+// Pausing only affects scheduled runs.
+func (s *Scheduler) skipReason(sc scraper.Scraper, scheduled bool) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	switch {
+	case s.disabled[sc.Name()]:
+		return skipDisabled
+	case scheduled && s.paused:
+		return skipPaused
+	}
+	return ""
+}
+
+// recordSkipped adds a skipped run of sc, with reason as its message, to the
+// run history.
+func (s *Scheduler) recordSkipped(ctx context.Context, sc scraper.Scraper, reason string) {
+	s.logger.Printf("[scheduler] %s: %s", sc.Name(), reason)
+	ctx = context.WithoutCancel(ctx)
+	run, err := s.repo.CreateScrapeRun(ctx, sc.Source(), sc.Name(), "", "")
+	if err != nil {
+		s.logger.Printf("[scheduler] failed to create scrape run: %v", err)
+		return
+	}
+	if err := s.repo.UpdateScrapeRun(ctx, run.ID, model.ScrapeStatusSkipped, model.ScrapeRun{ErrorMessage: reason}); err != nil {
+		s.logger.Printf("[scheduler] failed to update scrape run: %v", err)
+	}
+}
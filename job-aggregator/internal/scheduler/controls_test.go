@@ -0,0 +1,169 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log"
+	"slices"
+	"testing"
+
+	"github.com/learnbot/job-aggregator/internal/model"
+	"github.com/learnbot/job-aggregator/internal/scraper"
+	"github.com/learnbot/job-aggregator/internal/storage"
+)
+
+// blockingScraper scrapes nothing, signalling started and then waiting for
+// release.
+type blockingScraper struct {
+	name    string
+	started chan struct{}
+	release chan struct{}
+}
+
+func (s *blockingScraper) Source() model.JobSource { return model.SourceOther }
+func (s *blockingScraper) Name() string            { return s.name }
+func (s *blockingScraper) Scrape(context.Context, model.SearchParams, chan<- *model.ScrapedJob) error {
+	s.started <- struct{}{}
+	<-s.release
+	return nil
+}
+
+func newControlsTestScheduler(repo storage.JobRepository, scrapers ...scraper.Scraper) *Scheduler {
+	cfg := DefaultConfig()
+	cfg.DefaultQueries = []SearchQuery{{Query: "golang"}}
+	return New(repo, scrapers, cfg, log.New(io.Discard, "", 0))
+}
+
+// runStatuses returns the status and message of the scraper's runs, oldest
+// first.
+func runStatuses(t *testing.T, repo storage.JobRepository, name string) []string {
+	t.Helper()
+	runs, _, err := repo.SearchScrapeRuns(context.Background(), model.ScrapeRunFilter{ScraperName: name, Page: 1, PageSize: 100})
+	if err != nil {
+		t.Fatalf("SearchScrapeRuns: %v", err)
+	}
+	var out []string
+	for i := len(runs) - 1; i >= 0; i-- {
+		out = append(out, string(runs[i].Status)+" "+runs[i].ErrorMessage)
+	}
+	return out
+}
+
+func TestControls_SkipScheduledRuns(t *testing.T) {
+	repo := newHealthTestRepository(t)
+	linkedin, indeed := &namedScraper{"LinkedIn Jobs"}, &namedScraper{"Indeed"}
+	s := newControlsTestScheduler(repo, linkedin, indeed)
+	ctx := context.Background()
+
+	if _, err := s.SetScraperEnabled(ctx, "LinkedIn Jobs", false); err != nil {
+		t.Fatalf("SetScraperEnabled: %v", err)
+	}
+	s.runScheduled(ctx, linkedin)
+	s.runScheduled(ctx, indeed)
+
+	if err := s.Pause(ctx); err != nil {
+		t.Fatalf("Pause: %v", err)
+	}
+	s.runScheduled(ctx, indeed)
+	// A manual cycle still runs while paused, but not a disabled scraper.
+	if err := s.RunOnce(ctx); err != nil {
+		t.Fatalf("RunOnce: %v", err)
+	}
+
+	if err := s.Resume(ctx); err != nil {
+		t.Fatalf("Resume: %v", err)
+	}
+	s.runScheduled(ctx, indeed)
+
+	want := []string{"skipped skipped (disabled)", "skipped skipped (disabled)"}
+	if got := runStatuses(t, repo, "LinkedIn Jobs"); !slices.Equal(got, want) {
+		t.Errorf("LinkedIn runs = %q, want %q", got, want)
+	}
+	want = []string{"completed ", "skipped skipped (paused)", "completed ", "completed "}
+	if got := runStatuses(t, repo, "Indeed"); !slices.Equal(got, want) {
+		t.Errorf("Indeed runs = %q, want %q", got, want)
+	}
+
+	// Skipped runs are not failures.
+	health, err := s.ScraperHealth(ctx)
+	if err != nil {
+		t.Fatalf("ScraperHealth: %v", err)
+	}
+	for _, h := range health {
+		if h.ConsecutiveFailures != 0 || h.Status != model.ScraperHealthy {
+			t.Errorf("unexpected health after skipped runs: %+v", h)
+		}
+	}
+}
+
+func TestControls_SurviveRestart(t *testing.T) {
+	repo := newHealthTestRepository(t)
+	ctx := context.Background()
+	s := newControlsTestScheduler(repo, &namedScraper{"LinkedIn Jobs"}, &namedScraper{"Indeed"})
+	if err := s.Pause(ctx); err != nil {
+		t.Fatalf("Pause: %v", err)
+	}
+	if _, err := s.SetScraperEnabled(ctx, "LinkedIn Jobs", false); err != nil {
+		t.Fatalf("SetScraperEnabled: %v", err)
+	}
+
+	restarted := newControlsTestScheduler(repo, &namedScraper{"LinkedIn Jobs"}, &namedScraper{"Indeed"})
+	if st := restarted.Status(); st.Paused || !st.Scrapers[1].Enabled {
+		t.Fatalf("expected defaults before LoadControls, got %+v", st)
+	}
+	if err := restarted.LoadControls(ctx); err != nil {
+		t.Fatalf("LoadControls: %v", err)
+	}
+	st := restarted.Status()
+	if !st.Paused || st.NextRun.IsZero() {
+		t.Errorf("unexpected status %+v", st)
+	}
+	enabled := map[string]bool{}
+	for _, sc := range st.Scrapers {
+		enabled[sc.Scraper] = sc.Enabled
+		if sc.NextRun.IsZero() {
+			t.Errorf("%s has no next run: %+v", sc.Scraper, sc)
+		}
+	}
+	if enabled["LinkedIn Jobs"] || !enabled["Indeed"] {
+		t.Errorf("enabled flags = %v, want only Indeed enabled", enabled)
+	}
+}
+
+func TestControls_InFlightRunFinishes(t *testing.T) {
+	repo := newHealthTestRepository(t)
+	ctx := context.Background()
+	sc := &blockingScraper{name: "LinkedIn Jobs", started: make(chan struct{}), release: make(chan struct{})}
+	s := newControlsTestScheduler(repo, sc)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		s.runScheduled(ctx, sc)
+	}()
+	<-sc.started
+
+	// Toggled while the run is in progress: the run is not interrupted.
+	if err := s.Pause(ctx); err != nil {
+		t.Fatalf("Pause: %v", err)
+	}
+	if _, err := s.SetScraperEnabled(ctx, "LinkedIn Jobs", false); err != nil {
+		t.Fatalf("SetScraperEnabled: %v", err)
+	}
+	close(sc.release)
+	<-done
+
+	s.runScheduled(ctx, sc)
+	got := runStatuses(t, repo, "LinkedIn Jobs")
+	if !slices.Equal(got, []string{"completed ", "skipped skipped (disabled)"}) {
+		t.Errorf("runs = %q, want the in-flight run completed and the next skipped", got)
+	}
+}
+
+func TestControls_UnknownScraper(t *testing.T) {
+	s := newTestScheduler(DefaultConfig(), &namedScraper{"Indeed"})
+	if _, err := s.SetScraperEnabled(context.Background(), "Glassdoor", false); !errors.Is(err, ErrUnknownScraper) {
+		t.Errorf("expected ErrUnknownScraper, got %v", err)
+	}
+}
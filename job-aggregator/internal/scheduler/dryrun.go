@@ -0,0 +1,103 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/learnbot/job-aggregator/internal/httpclient"
+	"github.com/learnbot/job-aggregator/internal/model"
+	"github.com/learnbot/job-aggregator/internal/scraper"
+	"github.com/learnbot/job-aggregator/internal/storage"
+)
+
+// DefaultDryRunPages is the page limit of a dry run when none is given.
+const DefaultDryRunPages = 3
+
+// ErrScraperBusy is returned by DryRun for a scraper that is scraping.
+var ErrScraperBusy = errors.New("scraper is already running")
+
+// DryRun runs the named scraper without storing anything and reports what
+// a real run would store. Each job is upserted under storage.WithDryRun,
+// which compares it with the stored jobs instead of writing it, and the
+// scraper's HTTP requests are capped at pages with httpclient.WithFetchLimit,
+// so no scraper needs to know it is being dry run. Scrape runs, expiry,
+// notifications and analytics are skipped.
+//
+// queries defaults to the configured queries. A scraper stopped by the page
+// limit is reported as truncated, not as an error.
+func (s *Scheduler) DryRun(ctx context.Context, name string, queries []SearchQuery, pages int) (*model.DryRunReport, error) {
+	sc := s.scraperNamed(name)
+	if sc == nil {
+		return nil, fmt.Errorf("%w %q", ErrUnknownScraper, name)
+	}
+	if !s.acquire(sc) {
+		return nil, fmt.Errorf("%w: %s", ErrScraperBusy, name)
+	}
+	defer s.release(sc)
+
+	if pages <= 0 {
+		pages = DefaultDryRunPages
+	}
+	if len(queries) == 0 {
+		queries = s.config.DefaultQueries
+	}
+	ctx = httpclient.WithFetchLimit(ctx, pages)
+	ctx, dry := storage.WithDryRun(ctx)
+	report := &model.DryRunReport{Scraper: sc.Name(), PageLimit: pages}
+	failed := 0
+
+	s.logger.Printf("[scheduler] %s: dry run with a limit of %d pages", sc.Name(), pages)
+	for _, query := range queries {
+		params := model.SearchParams{
+			Query:    query.Query,
+			Location: query.Location,
+			Remote:   query.Remote,
+			PageSize: 25,
+		}
+
+		jobsCh := make(chan *model.ScrapedJob, s.config.JobChannelBuffer)
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			for job := range jobsCh {
+				s.prepareJob(ctx, job)
+				if _, _, err := s.repo.UpsertJob(ctx, job); err != nil {
+					s.logger.Printf("[scheduler] dry run: compare job %q at %s: %v", job.Title, job.CompanyName, err)
+					failed++
+				}
+			}
+		}()
+		err := scrape(ctx, sc, params, jobsCh)
+		close(jobsCh)
+		<-done
+
+		if errors.Is(err, httpclient.ErrFetchLimit) {
+			report.Truncated = true
+			break
+		}
+		if err != nil {
+			report.Error = err.Error()
+			break
+		}
+	}
+
+	report.PagesFetched = httpclient.FetchCount(ctx)
+	report.Jobs = dry.Jobs()
+	report.Summary = dry.Summary()
+	report.Summary.Failed = failed
+	s.logger.Printf("[scheduler] %s: dry run fetched %d pages: new=%d changed=%d unchanged=%d duplicate=%d",
+		sc.Name(), report.PagesFetched, report.Summary.New, report.Summary.Changed,
+		report.Summary.Unchanged, report.Summary.Duplicate)
+	return report, ctx.Err()
+}
+
+// scraperNamed returns the first registered scraper called name, or nil.
+func (s *Scheduler) scraperNamed(name string) scraper.Scraper {
+	for _, sc := range s.Scrapers() {
+		if sc.Name() == name {
+			return sc
+		}
+	}
+	return nil
+}
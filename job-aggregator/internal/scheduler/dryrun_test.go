@@ -0,0 +1,113 @@
+package scheduler
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/learnbot/job-aggregator/internal/httpclient"
+	"github.com/learnbot/job-aggregator/internal/model"
+	"github.com/learnbot/job-aggregator/internal/storage"
+)
+
+// pagingScraper fetches numbered pages until a fetch fails, emitting one job
+// per page, like a scraper that knows nothing of dry runs.
+type pagingScraper struct {
+	client *httpclient.Client
+	base   string
+}
+
+func (s *pagingScraper) Source() model.JobSource { return model.SourceOther }
+func (s *pagingScraper) Name() string            { return "Paging" }
+func (s *pagingScraper) Scrape(ctx context.Context, _ model.SearchParams, jobs chan<- *model.ScrapedJob) error {
+	for page := 1; ; page++ {
+		if _, err := s.client.GetBody(ctx, fmt.Sprintf("%s/jobs?page=%d", s.base, page), nil); err != nil {
+			return err
+		}
+		job := pagingJob(page)
+		job.Description = "Salary: $120,000 - $150,000 per year"
+		jobs <- job
+	}
+}
+
+func pagingJob(page int) *model.ScrapedJob {
+	return &model.ScrapedJob{
+		Source:          model.SourceOther,
+		ExternalID:      fmt.Sprintf("paging-%d", page),
+		CompanyName:     "Acme",
+		Title:           fmt.Sprintf("Engineer %d", page),
+		LocationType:    model.LocationUnknown,
+		EmploymentType:  model.EmploymentFullTime,
+		ExperienceLevel: model.LevelMid,
+	}
+}
+
+func TestDryRun(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+	cfg := httpclient.DefaultConfig()
+	cfg.RequestsPerMinute = 6000
+	client, err := httpclient.New(cfg, log.New(io.Discard, "", 0))
+	if err != nil {
+		t.Fatalf("httpclient.New: %v", err)
+	}
+
+	db, err := sql.Open(storage.SQLiteDriver, storage.SQLiteDSN(":memory:"))
+	if err != nil {
+		t.Fatalf("open sqlite: %v", err)
+	}
+	defer db.Close()
+	repo := storage.NewSQLiteRepository(db)
+	if err := repo.Migrate(context.Background(), log.New(io.Discard, "", 0)); err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+	if _, _, err := repo.UpsertJob(context.Background(), pagingJob(1)); err != nil {
+		t.Fatalf("UpsertJob: %v", err)
+	}
+
+	sc := &pagingScraper{client: client, base: server.URL}
+	s := New(repo, nil, DefaultConfig(), log.New(io.Discard, "", 0))
+	s.AddScraper(sc)
+
+	report, err := s.DryRun(context.Background(), "Paging", []SearchQuery{{Query: "engineer"}}, 2)
+	if err != nil {
+		t.Fatalf("DryRun: %v", err)
+	}
+	if !report.Truncated || report.Error != "" || report.PagesFetched != 2 || report.PageLimit != 2 {
+		t.Errorf("unexpected report %+v", report)
+	}
+	if report.Summary.New != 1 || report.Summary.Changed != 1 || len(report.Jobs) != 2 {
+		t.Fatalf("unexpected summary %+v", report.Summary)
+	}
+	if j := report.Jobs[1].Job; j.SalaryMin == nil || *j.SalaryMin != 120000 || j.SalaryPeriod != model.SalaryPerYear {
+		t.Errorf("salary not normalized: %+v", j)
+	}
+
+	var n int
+	if err := db.QueryRow(`SELECT COUNT(*) FROM jobs`).Scan(&n); err != nil || n != 1 {
+		t.Errorf("dry run stored jobs: %d (%v)", n, err)
+	}
+	var runs int
+	if err := db.QueryRow(`SELECT COUNT(*) FROM scrape_runs`).Scan(&runs); err != nil || runs != 0 {
+		t.Errorf("dry run recorded %d scrape runs (%v)", runs, err)
+	}
+
+	if _, err := s.DryRun(context.Background(), "Glassdoor", nil, 1); !errors.Is(err, ErrUnknownScraper) {
+		t.Errorf("expected ErrUnknownScraper, got %v", err)
+	}
+	if !s.acquire(sc) {
+		t.Fatal("acquire failed")
+	}
+	if _, err := s.DryRun(context.Background(), "Paging", nil, 1); !errors.Is(err, ErrScraperBusy) {
+		t.Errorf("expected ErrScraperBusy, got %v", err)
+	}
+	s.release(sc)
+}
@@ -0,0 +1,129 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/learnbot/job-aggregator/internal/model"
+	"github.com/learnbot/job-aggregator/internal/scraper"
+	"github.com/learnbot/shared/healthcheck"
+)
+
+// DefaultMaxScrapeAge is how long ago the last successful scrape may have
+// been before FreshnessProbe reports the jobs as stale.
+const DefaultMaxScrapeAge = 48 * time.Hour
+
+// HealthThresholds decide the health status of a scraper.
+type HealthThresholds struct {
+	// Consecutive failed runs after which a scraper is failing. Fewer
+	// failures make it degraded.
+	FailingAfter int
+	// Fraction by which the jobs found by the last completed run may fall
+	// short of the trailing seven-day average before the scraper is
+	// degraded.
+	MaxDrop float64
+}
+
+// DefaultHealthThresholds returns the thresholds used unless configured.
+func DefaultHealthThresholds() HealthThresholds {
+	return HealthThresholds{FailingAfter: 3, MaxDrop: 0.7}
+}
+
+// Status derives the health status of h. A scraper without history has
+// nothing to compare with and is healthy.
+func (t HealthThresholds) Status(h model.ScraperHealth) model.ScraperHealthStatus {
+	switch {
+	case h.ConsecutiveFailures >= max(t.FailingAfter, 1):
+		return model.ScraperFailing
+	case h.ConsecutiveFailures > 0:
+		return model.ScraperDegraded
+	}
+	// The drop is compared as a product rather than a ratio, so that a zero
+	// average cannot divide by zero.
+	if h.Runs7d > 0 && h.AvgJobsFound7d > 0 && h.AvgJobsFound7d-float64(h.LastJobsFound) > h.AvgJobsFound7d*t.MaxDrop {
+		return model.ScraperDegraded
+	}
+	return model.ScraperHealthy
+}
+
+// ScraperHealth returns the health of every registered scraper, in the order
+// they were added, with its status derived from the configured thresholds.
+// Scrapers that have not run yet are listed with zero values.
+func (s *Scheduler) ScraperHealth(ctx context.Context) ([]model.ScraperHealth, error) {
+	stored, err := s.repo.ListScraperHealth(ctx)
+	if err != nil {
+		return nil, err
+	}
+	byName := make(map[string]model.ScraperHealth, len(stored))
+	for _, h := range stored {
+		byName[h.ScraperName] = h
+	}
+
+	var list []model.ScraperHealth
+	seen := map[string]bool{}
+	for _, sc := range s.Scrapers() {
+		name := sc.Name()
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+		h, ok := byName[name]
+		if !ok {
+			h = model.ScraperHealth{ScraperName: name}
+		}
+		h.Status = s.config.Health.Status(h)
+		list = append(list, h)
+	}
+	return list, nil
+}
+
+// LastSuccessfulScrape returns when a scraper last completed a run, and
+// false when none has.
+func (s *Scheduler) LastSuccessfulScrape(ctx context.Context) (time.Time, bool, error) {
+	stored, err := s.repo.ListScraperHealth(ctx)
+	if err != nil {
+		return time.Time{}, false, err
+	}
+	var last time.Time
+	for _, h := range stored {
+		if h.LastSuccessAt != nil && h.LastSuccessAt.After(last) {
+			last = *h.LastSuccessAt
+		}
+	}
+	return last, !last.IsZero(), nil
+}
+
+// FreshnessProbe returns a readiness probe reporting the jobs as degraded
+// when no scraper has completed a run within maxAge, or ever. Stale jobs
+// are still served, so it never fails readiness.
+func (s *Scheduler) FreshnessProbe(maxAge time.Duration) healthcheck.Probe {
+	return func(ctx context.Context) error {
+		last, ok, err := s.LastSuccessfulScrape(ctx)
+		switch {
+		case err != nil:
+			return err
+		case !ok:
+			return healthcheck.Degraded(errors.New("no successful scrape yet"))
+		}
+		if age := s.now().Sub(last); age > maxAge {
+			return healthcheck.Degraded(fmt.Errorf("last successful scrape %v ago, at %s",
+				age.Round(time.Minute), last.UTC().Format(time.RFC3339)))
+		}
+		return nil
+	}
+}
+
+// refreshHealth recomputes the stored health of sc after a run.
+func (s *Scheduler) refreshHealth(ctx context.Context, sc scraper.Scraper) {
+	h, err := s.repo.RefreshScraperHealth(ctx, sc.Name())
+	if err != nil {
+		s.logger.Printf("[scheduler] failed to refresh health of %s: %v", sc.Name(), err)
+		return
+	}
+	if status := s.config.Health.Status(*h); status != model.ScraperHealthy {
+		s.logger.Printf("[scheduler] %s is %s: %d consecutive failures, %d jobs found vs a 7-day average of %.1f",
+			sc.Name(), status, h.ConsecutiveFailures, h.LastJobsFound, h.AvgJobsFound7d)
+	}
+}
@@ -0,0 +1,125 @@
+package scheduler
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"io"
+	"log"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/learnbot/job-aggregator/internal/model"
+	"github.com/learnbot/job-aggregator/internal/storage"
+	"github.com/learnbot/shared/healthcheck"
+)
+
+func TestHealthThresholds_Status(t *testing.T) {
+	tests := []struct {
+		name string
+		h    model.ScraperHealth
+		want model.ScraperHealthStatus
+	}{
+		{"no history", model.ScraperHealth{}, model.ScraperHealthy},
+		{"first run found nothing", model.ScraperHealth{LastJobsFound: 0, Runs7d: 0}, model.ScraperHealthy},
+		{"zero average", model.ScraperHealth{LastJobsFound: 0, Runs7d: 4, AvgJobsFound7d: 0}, model.ScraperHealthy},
+		{"steady", model.ScraperHealth{LastJobsFound: 40, Runs7d: 6, AvgJobsFound7d: 42}, model.ScraperHealthy},
+		{"drop of exactly 70%", model.ScraperHealth{LastJobsFound: 30, Runs7d: 6, AvgJobsFound7d: 100}, model.ScraperHealthy},
+		{"drop over 70%", model.ScraperHealth{LastJobsFound: 29, Runs7d: 6, AvgJobsFound7d: 100}, model.ScraperDegraded},
+		{"found nothing", model.ScraperHealth{LastJobsFound: 0, Runs7d: 6, AvgJobsFound7d: 12.5}, model.ScraperDegraded},
+		{"one failure", model.ScraperHealth{ConsecutiveFailures: 1, LastJobsFound: 40, Runs7d: 6, AvgJobsFound7d: 42}, model.ScraperDegraded},
+		{"failing", model.ScraperHealth{ConsecutiveFailures: 3, LastJobsFound: 40, Runs7d: 6, AvgJobsFound7d: 42}, model.ScraperFailing},
+		{"never succeeded", model.ScraperHealth{ConsecutiveFailures: 5}, model.ScraperFailing},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := DefaultHealthThresholds().Status(tt.h); got != tt.want {
+				t.Errorf("Status(%+v) = %s, want %s", tt.h, got, tt.want)
+			}
+		})
+	}
+
+	// Zero thresholds still treat a failure as failing.
+	if got := (HealthThresholds{}).Status(model.ScraperHealth{ConsecutiveFailures: 1}); got != model.ScraperFailing {
+		t.Errorf("zero thresholds: Status = %s, want failing", got)
+	}
+}
+
+func TestRunScraperQuery_RefreshesHealth(t *testing.T) {
+	repo := newHealthTestRepository(t)
+	s := New(repo, nil, DefaultConfig(), log.New(io.Discard, "", 0))
+	s.runScraperQuery(context.Background(), &namedScraper{name: "Lever: Acme"}, model.SearchParams{Query: "golang"})
+
+	list, err := repo.ListScraperHealth(context.Background())
+	if err != nil {
+		t.Fatalf("ListScraperHealth: %v", err)
+	}
+	if len(list) != 1 || list[0].ScraperName != "Lever: Acme" || list[0].LastSuccessAt == nil {
+		t.Errorf("health not refreshed after the run: %+v", list)
+	}
+}
+
+func newHealthTestRepository(t *testing.T) *storage.SQLiteRepository {
+	t.Helper()
+	db, err := sql.Open(storage.SQLiteDriver, storage.SQLiteDSN(":memory:"))
+	if err != nil {
+		t.Fatalf("open sqlite: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	repo := storage.NewSQLiteRepository(db)
+	if err := repo.Migrate(context.Background(), log.New(io.Discard, "", 0)); err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+	return repo
+}
+
+// A scraper without runs is listed with zero values and a status, not with
+// a NaN average that fails to encode.
+func TestScraperHealth_NoHistory(t *testing.T) {
+	repo := newHealthTestRepository(t)
+	if _, err := repo.RefreshScraperHealth(context.Background(), "Lever: Acme"); err != nil {
+		t.Fatalf("RefreshScraperHealth: %v", err)
+	}
+
+	s := New(repo, nil, DefaultConfig(), log.New(io.Discard, "", 0))
+	s.AddScraper(&namedScraper{name: "Lever: Acme"})
+	s.AddScraper(&namedScraper{name: "Greenhouse: Acme"})
+	list, err := s.ScraperHealth(context.Background())
+	if err != nil {
+		t.Fatalf("ScraperHealth: %v", err)
+	}
+	if len(list) != 2 || list[0].ScraperName != "Lever: Acme" || list[1].ScraperName != "Greenhouse: Acme" {
+		t.Fatalf("unexpected scrapers %+v", list)
+	}
+	for _, h := range list {
+		if h.Status != model.ScraperHealthy || h.AvgJobsFound7d != 0 || h.LastSuccessAt != nil {
+			t.Errorf("unexpected health without runs: %+v", h)
+		}
+	}
+	if _, err := json.Marshal(list); err != nil {
+		t.Errorf("encode health: %v", err)
+	}
+}
+
+func TestFreshnessProbe(t *testing.T) {
+	repo := newHealthTestRepository(t)
+	s := New(repo, nil, DefaultConfig(), log.New(io.Discard, "", 0))
+	probe := s.FreshnessProbe(DefaultMaxScrapeAge)
+
+	// Nothing scraped yet.
+	if err := probe(context.Background()); !healthcheck.IsDegraded(err) {
+		t.Errorf("expected degraded before any scrape, got %v", err)
+	}
+
+	s.runScraperQuery(context.Background(), &namedScraper{name: "Lever: Acme"}, model.SearchParams{Query: "golang"})
+	if err := probe(context.Background()); err != nil {
+		t.Errorf("expected fresh jobs after a scrape, got %v", err)
+	}
+
+	s.now = func() time.Time { return time.Now().Add(49 * time.Hour) }
+	err := probe(context.Background())
+	if !healthcheck.IsDegraded(err) || !strings.Contains(err.Error(), "last successful scrape") {
+		t.Errorf("expected stale jobs after 49h, got %v", err)
+	}
+}
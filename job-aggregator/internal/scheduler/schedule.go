@@ -0,0 +1,397 @@
+package scheduler
+
+import (
+	"fmt"
+	"hash/fnv"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultSchedule is the fallback schedule: daily at 2am UTC.
+const DefaultSchedule = "0 2 * * *"
+
+// minInterval is the shortest interval schedule accepted.
+const minInterval = time.Minute
+
+// Schedule computes when a scraper runs next.
+type Schedule interface {
+	// Next returns the first run time after t, or the zero time if the
+	// schedule never fires again.
+	Next(t time.Time) time.Time
+}
+
+// ParseSchedule parses a schedule specification. It accepts:
+//
+//   - a five-field cron expression, "minute hour day-of-month month
+//     day-of-week", evaluated in UTC. Fields support *, lists (1,15),
+//     ranges (1-5), steps (*/15, 0-30/10), and month and weekday names
+//     (jan, mon).
+//   - the descriptors @hourly, @daily (or @midnight), @weekly, @monthly
+//     and @yearly (or @annually).
+//   - an interval, either "@every 6h" or a bare Go duration such as "30m",
+//     of at least one minute.
+//   - a run window, "@window 02:00-05:00": once a day at a time within the
+//     window, which may span midnight. The time is jittered per day, so
+//     that scrapers sharing a window do not all run at once.
+func ParseSchedule(spec string) (Schedule, error) {
+	return ParseScheduleIn(spec, time.UTC)
+}
+
+// ParseScheduleIn parses a schedule specification as ParseSchedule does,
+// with cron expressions and run windows evaluated in loc. Across daylight
+// saving time transitions, a run at a wall time that does not exist on a
+// spring-forward night happens as many minutes after the gap as it would
+// have been into it (02:30 becomes 03:30), and a run at a wall time that
+// occurs twice on a fall-back night happens once, at its first occurrence.
+func ParseScheduleIn(spec string, loc *time.Location) (Schedule, error) {
+	spec = strings.TrimSpace(spec)
+	if spec == "" {
+		return nil, fmt.Errorf("empty schedule")
+	}
+	if loc == nil {
+		loc = time.UTC
+	}
+
+	if rest, ok := strings.CutPrefix(spec, "@every "); ok {
+		return parseInterval(strings.TrimSpace(rest))
+	}
+	if _, err := time.ParseDuration(spec); err == nil {
+		return parseInterval(spec)
+	}
+	if rest, ok := strings.CutPrefix(spec, "@window "); ok {
+		return parseWindow(strings.TrimSpace(rest), loc)
+	}
+
+	switch spec {
+	case "@yearly", "@annually":
+		spec = "0 0 1 1 *"
+	case "@monthly":
+		spec = "0 0 1 * *"
+	case "@weekly":
+		spec = "0 0 * * 0"
+	case "@daily", "@midnight":
+		spec = "0 0 * * *"
+	case "@hourly":
+		spec = "0 * * * *"
+	default:
+		if strings.HasPrefix(spec, "@") {
+			return nil, fmt.Errorf("unknown schedule descriptor %q", spec)
+		}
+	}
+	return parseCron(spec, loc)
+}
+
+// intervalSchedule runs at a fixed interval after the previous run.
+type intervalSchedule time.Duration
+
+func parseInterval(s string) (Schedule, error) {
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return nil, fmt.Errorf("invalid interval %q: %w", s, err)
+	}
+	if d < minInterval {
+		return nil, fmt.Errorf("interval %s is shorter than %s", d, minInterval)
+	}
+	return intervalSchedule(d), nil
+}
+
+func (i intervalSchedule) Next(t time.Time) time.Time {
+	return t.Add(time.Duration(i))
+}
+
+// cronSchedule is a parsed five-field cron expression. Each field is a
+// bitmask of the values it matches.
+type cronSchedule struct {
+	minute, hour, dom, month, dow uint64
+	// domStar and dowStar record an unrestricted day field. As in cron,
+	// when both day fields are restricted a day matching either one fires.
+	domStar, dowStar bool
+	// loc is the time zone the fields are matched in.
+	loc *time.Location
+}
+
+// cronField describes the allowed values of one cron field.
+type cronField struct {
+	name     string
+	min, max int
+	names    map[string]int
+}
+
+var (
+	monthNames = map[string]int{
+		"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+		"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+	}
+	dayNames = map[string]int{
+		"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6,
+	}
+
+	cronFields = [5]cronField{
+		{name: "minute", min: 0, max: 59},
+		{name: "hour", min: 0, max: 23},
+		{name: "day of month", min: 1, max: 31},
+		{name: "month", min: 1, max: 12, names: monthNames},
+		// 7 is accepted as Sunday and folded onto 0.
+		{name: "day of week", min: 0, max: 7, names: dayNames},
+	}
+)
+
+// cronHorizon bounds the search for the next matching time, so that an
+// expression such as "0 0 30 2 *" is reported as never firing.
+const cronHorizon = 5 * 366 * 24 * time.Hour
+
+func parseCron(spec string, loc *time.Location) (Schedule, error) {
+	fields := strings.Fields(spec)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("cron expression %q must have 5 fields (minute hour day-of-month month day-of-week), got %d", spec, len(fields))
+	}
+
+	var masks [5]uint64
+	for i, f := range fields {
+		m, err := parseCronField(f, cronFields[i])
+		if err != nil {
+			return nil, fmt.Errorf("cron expression %q: %w", spec, err)
+		}
+		masks[i] = m
+	}
+	if masks[4]&(1<<7) != 0 {
+		masks[4] = masks[4]&^(1<<7) | 1
+	}
+
+	c := &cronSchedule{
+		minute:  masks[0],
+		hour:    masks[1],
+		dom:     masks[2],
+		month:   masks[3],
+		dow:     masks[4],
+		domStar: fields[2] == "*" || fields[2] == "?",
+		dowStar: fields[4] == "*" || fields[4] == "?",
+		loc:     loc,
+	}
+	if c.nextWall(time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)).IsZero() {
+		return nil, fmt.Errorf("cron expression %q never matches a date", spec)
+	}
+	return c, nil
+}
+
+// parseCronField parses one comma-separated cron field into a bitmask.
+func parseCronField(field string, f cronField) (uint64, error) {
+	var mask uint64
+	for _, part := range strings.Split(field, ",") {
+		rangePart, stepPart, hasStep := strings.Cut(part, "/")
+
+		lo, hi := f.min, f.max
+		switch {
+		case rangePart == "*" || rangePart == "?":
+		case strings.Contains(rangePart, "-"):
+			a, b, _ := strings.Cut(rangePart, "-")
+			var err error
+			if lo, err = parseCronValue(a, f); err != nil {
+				return 0, err
+			}
+			if hi, err = parseCronValue(b, f); err != nil {
+				return 0, err
+			}
+			if lo > hi {
+				return 0, fmt.Errorf("%s range %q is backwards", f.name, rangePart)
+			}
+		default:
+			v, err := parseCronValue(rangePart, f)
+			if err != nil {
+				return 0, err
+			}
+			lo = v
+			// "5/10" means every 10 starting at 5; a bare "5" is just 5.
+			if !hasStep {
+				hi = v
+			}
+		}
+
+		step := 1
+		if hasStep {
+			n, err := strconv.Atoi(stepPart)
+			if err != nil || n < 1 {
+				return 0, fmt.Errorf("invalid %s step %q", f.name, stepPart)
+			}
+			step = n
+		}
+
+		for v := lo; v <= hi; v += step {
+			mask |= 1 << uint(v)
+		}
+	}
+	return mask, nil
+}
+
+func parseCronValue(s string, f cronField) (int, error) {
+	if v, ok := f.names[strings.ToLower(s)]; ok {
+		return v, nil
+	}
+	v, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s value %q", f.name, s)
+	}
+	if v < f.min || v > f.max {
+		return 0, fmt.Errorf("%s value %d out of range %d-%d", f.name, v, f.min, f.max)
+	}
+	return v, nil
+}
+
+// Next returns the first matching minute after t.
+func (c *cronSchedule) Next(t time.Time) time.Time {
+	if c.loc == time.UTC {
+		return c.nextWall(t.UTC())
+	}
+	// Wall times are matched first and then placed in the time zone. A
+	// wall time whose first occurrence is not after t, in the repeated
+	// hour of a fall-back night, has already fired.
+	wall := wallClock(t.In(c.loc))
+	for {
+		if wall = c.nextWall(wall); wall.IsZero() {
+			return time.Time{}
+		}
+		if at := resolveWall(wall, c.loc); at.After(t) {
+			return at
+		}
+	}
+}
+
+// nextWall returns the first matching minute after the wall time t, given
+// as a UTC time.
+func (c *cronSchedule) nextWall(t time.Time) time.Time {
+	t = t.Truncate(time.Minute).Add(time.Minute)
+	limit := t.Add(cronHorizon)
+
+	for t.Before(limit) {
+		if c.month&(1<<uint(t.Month())) == 0 {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, time.UTC)
+			continue
+		}
+		if !c.dayMatches(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, time.UTC)
+			continue
+		}
+		if c.hour&(1<<uint(t.Hour())) == 0 {
+			t = t.Truncate(time.Hour).Add(time.Hour)
+			continue
+		}
+		if c.minute&(1<<uint(t.Minute())) == 0 {
+			t = t.Add(time.Minute)
+			continue
+		}
+		return t
+	}
+	return time.Time{}
+}
+
+func (c *cronSchedule) dayMatches(t time.Time) bool {
+	dom := c.dom&(1<<uint(t.Day())) != 0
+	dow := c.dow&(1<<uint(t.Weekday())) != 0
+	if !c.domStar && !c.dowStar {
+		return dom || dow
+	}
+	return dom && dow
+}
+
+// windowSchedule runs once a day at a time within a window of wall-clock
+// times in its time zone.
+type windowSchedule struct {
+	// start is the window's start in minutes after midnight, and length
+	// its length in minutes; start+length may pass midnight.
+	start, length int
+	loc           *time.Location
+	// seed varies the jitter between schedules with the same window.
+	seed string
+}
+
+func parseWindow(s string, loc *time.Location) (Schedule, error) {
+	from, to, ok := strings.Cut(s, "-")
+	if !ok {
+		return nil, fmt.Errorf("run window %q must be \"HH:MM-HH:MM\"", s)
+	}
+	start, err := parseClock(strings.TrimSpace(from))
+	if err != nil {
+		return nil, fmt.Errorf("run window %q: %w", s, err)
+	}
+	end, err := parseClock(strings.TrimSpace(to))
+	if err != nil {
+		return nil, fmt.Errorf("run window %q: %w", s, err)
+	}
+	length := (end - start + 24*60) % (24 * 60)
+	if length == 0 {
+		return nil, fmt.Errorf("run window %q is empty", s)
+	}
+	return &windowSchedule{start: start, length: length, loc: loc}, nil
+}
+
+// parseClock parses "HH:MM" into minutes after midnight.
+func parseClock(s string) (int, error) {
+	t, err := time.Parse("15:04", s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid time %q, want HH:MM", s)
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+// Next returns the run time of the first window whose run is after t.
+func (w *windowSchedule) Next(t time.Time) time.Time {
+	local := t.In(w.loc)
+	// The previous day's window may span midnight into today.
+	day := time.Date(local.Year(), local.Month(), local.Day()-1, 0, 0, 0, 0, time.UTC)
+	for i := 0; ; i++ {
+		d := day.AddDate(0, 0, i)
+		wall := d.Add(time.Duration(w.start+w.jitter(d)) * time.Minute)
+		if at := resolveWall(wall, w.loc); at.After(t) {
+			return at
+		}
+	}
+}
+
+// jitter returns the minutes into the window at which the window of day
+// runs, spread evenly over the window by a hash of the seed and day.
+func (w *windowSchedule) jitter(day time.Time) int {
+	h := fnv.New32a()
+	h.Write([]byte(w.seed + "|" + day.Format(time.DateOnly)))
+	return int(h.Sum32() % uint32(w.length))
+}
+
+// withJitterSeed returns s with its run window jittered by seed, so that
+// schedules sharing a window run at different times. Other schedules are
+// returned as they are.
+func withJitterSeed(s Schedule, seed string) Schedule {
+	if w, ok := s.(*windowSchedule); ok {
+		c := *w
+		c.seed = seed
+		return &c
+	}
+	return s
+}
+
+// wallClock returns the wall-clock reading of t as a UTC time.
+func wallClock(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), time.UTC)
+}
+
+// resolveWall returns the instant at which the clocks of loc read wall,
+// given as a UTC time. A wall time that occurs twice resolves to its first
+// occurrence; one skipped by a spring-forward transition resolves to the
+// instant it would be with the offset before the transition, that is as
+// far past the gap as it was into it.
+func resolveWall(wall time.Time, loc *time.Location) time.Time {
+	// Transitions are more than a day apart, so the offsets half a day
+	// either side are the only candidates.
+	_, before := wall.Add(-12 * time.Hour).In(loc).Zone()
+	_, after := wall.Add(12 * time.Hour).In(loc).Zone()
+	var first time.Time
+	for _, offset := range []int{before, after} {
+		at := wall.Add(-time.Duration(offset) * time.Second)
+		if wallClock(at.In(loc)).Equal(wall) && (first.IsZero() || at.Before(first)) {
+			first = at
+		}
+	}
+	if first.IsZero() {
+		return wall.Add(-time.Duration(before) * time.Second)
+	}
+	return first
+}
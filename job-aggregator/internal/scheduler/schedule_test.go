@@ -0,0 +1,220 @@
+package scheduler
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseSchedule_Next(t *testing.T) {
+	// Wednesday 2025-01-15 10:30 UTC.
+	from := time.Date(2025, 1, 15, 10, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		spec string
+		want time.Time
+	}{
+		{DefaultSchedule, time.Date(2025, 1, 16, 2, 0, 0, 0, time.UTC)},
+		{"*/15 * * * *", time.Date(2025, 1, 15, 10, 45, 0, 0, time.UTC)},
+		{"0 * * * *", time.Date(2025, 1, 15, 11, 0, 0, 0, time.UTC)},
+		{"0 3 * * mon", time.Date(2025, 1, 20, 3, 0, 0, 0, time.UTC)},
+		{"0 3 * * 7", time.Date(2025, 1, 19, 3, 0, 0, 0, time.UTC)},
+		{"30 9 1,15 * *", time.Date(2025, 2, 1, 9, 30, 0, 0, time.UTC)},
+		{"0 0 1 jun *", time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)},
+		{"0 8-18/5 * * 1-5", time.Date(2025, 1, 15, 13, 0, 0, 0, time.UTC)},
+		// Both day fields restricted: either one matches (the 20th, or a Friday).
+		{"0 0 20 * fri", time.Date(2025, 1, 17, 0, 0, 0, 0, time.UTC)},
+		{"0 0 29 2 *", time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC)},
+		{"@weekly", time.Date(2025, 1, 19, 0, 0, 0, 0, time.UTC)},
+		{"@hourly", time.Date(2025, 1, 15, 11, 0, 0, 0, time.UTC)},
+		{"@every 6h", from.Add(6 * time.Hour)},
+		{"90m", from.Add(90 * time.Minute)},
+	}
+	for _, tt := range tests {
+		sched, err := ParseSchedule(tt.spec)
+		if err != nil {
+			t.Errorf("ParseSchedule(%q): %v", tt.spec, err)
+			continue
+		}
+		if got := sched.Next(from); !got.Equal(tt.want) {
+			t.Errorf("%q: Next = %v, want %v", tt.spec, got, tt.want)
+		}
+	}
+}
+
+func TestParseSchedule_Invalid(t *testing.T) {
+	tests := []struct {
+		spec string
+		want string
+	}{
+		{"", "empty"},
+		{"* * * *", "must have 5 fields"},
+		{"60 * * * *", "minute value 60 out of range"},
+		{"0 24 * * *", "hour value 24"},
+		{"0 0 0 * *", "day of month value 0"},
+		{"0 0 * foo *", `invalid month value "foo"`},
+		{"*/0 * * * *", "invalid minute step"},
+		{"0 5-2 * * *", "backwards"},
+		{"0 0 30 2 *", "never matches"},
+		{"@fortnightly", "unknown schedule descriptor"},
+		{"@every soon", "invalid interval"},
+		{"10s", "shorter than"},
+		{"@window 02:00", "HH:MM-HH:MM"},
+		{"@window 2am-5am", "invalid time"},
+		{"@window 03:00-03:00", "empty"},
+	}
+	for _, tt := range tests {
+		if _, err := ParseSchedule(tt.spec); err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("ParseSchedule(%q): expected error containing %q, got %v", tt.spec, tt.want, err)
+		}
+	}
+}
+
+func newYork(t *testing.T) *time.Location {
+	t.Helper()
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("no time zone database: %v", err)
+	}
+	return loc
+}
+
+func TestParseScheduleIn_Cron(t *testing.T) {
+	ny := newYork(t)
+	sched, err := ParseScheduleIn("0 2 * * *", ny)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 2am EST is 7am UTC.
+	from := time.Date(2025, 1, 15, 10, 30, 0, 0, time.UTC)
+	if got, want := sched.Next(from), time.Date(2025, 1, 16, 7, 0, 0, 0, time.UTC); !got.Equal(want) {
+		t.Errorf("Next = %v, want %v", got, want)
+	}
+}
+
+func TestParseScheduleIn_SpringForward(t *testing.T) {
+	ny := newYork(t)
+	sched, err := ParseScheduleIn("30 2 * * *", ny)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// On 2025-03-09 New York clocks jump from 02:00 EST to 03:00 EDT, so
+	// 02:30 does not exist that night and the run moves past the gap.
+	from := time.Date(2025, 3, 8, 12, 0, 0, 0, ny)
+	got := sched.Next(from)
+	if want := time.Date(2025, 3, 9, 7, 30, 0, 0, time.UTC); !got.Equal(want) {
+		t.Fatalf("Next = %v, want %v (03:30 EDT)", got, want)
+	}
+	// The following night 02:30 exists again, in EDT.
+	if got, want := sched.Next(got), time.Date(2025, 3, 10, 6, 30, 0, 0, time.UTC); !got.Equal(want) {
+		t.Errorf("Next after the gap = %v, want %v", got, want)
+	}
+}
+
+func TestParseScheduleIn_FallBack(t *testing.T) {
+	ny := newYork(t)
+	sched, err := ParseScheduleIn("30 1 * * *", ny)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// On 2025-11-02 New York clocks go from 02:00 EDT back to 01:00 EST,
+	// so 01:30 occurs twice; the run happens once, at 01:30 EDT.
+	from := time.Date(2025, 11, 1, 12, 0, 0, 0, ny)
+	got := sched.Next(from)
+	if want := time.Date(2025, 11, 2, 5, 30, 0, 0, time.UTC); !got.Equal(want) {
+		t.Fatalf("Next = %v, want %v (01:30 EDT)", got, want)
+	}
+	// Not again at 01:30 EST an hour later, but the next night.
+	want := time.Date(2025, 11, 3, 6, 30, 0, 0, time.UTC)
+	if got := sched.Next(got); !got.Equal(want) {
+		t.Errorf("Next after the first 01:30 = %v, want %v", got, want)
+	}
+	// Nor when asked from within the repeated hour.
+	if got := sched.Next(time.Date(2025, 11, 2, 6, 10, 0, 0, time.UTC)); !got.Equal(want) {
+		t.Errorf("Next from 01:10 EST = %v, want %v", got, want)
+	}
+}
+
+func TestWindowSchedule(t *testing.T) {
+	ny := newYork(t)
+	from := time.Date(2025, 1, 15, 12, 0, 0, 0, ny)
+
+	minutes := map[int]bool{}
+	for _, name := range []string{"LinkedIn Jobs", "Indeed Jobs", "Career Page: Acme", "Career Page: Globex", "Career Page: Initech"} {
+		sched, err := ParseScheduleIn("@window 02:00-05:00", ny)
+		if err != nil {
+			t.Fatal(err)
+		}
+		sched = withJitterSeed(sched, name)
+
+		next := from
+		for day := 16; day <= 20; day++ {
+			next = sched.Next(next)
+			local := next.In(ny)
+			if local.Day() != day || local.Hour() < 2 || local.Hour() >= 5 {
+				t.Errorf("%s: run at %v, want 02:00-05:00 on the %dth", name, local, day)
+			}
+		}
+		again := withJitterSeed(sched, name).Next(from)
+		if first := sched.Next(from); !first.Equal(again) {
+			t.Errorf("%s: jitter not deterministic: %v and %v", name, first, again)
+		}
+		minutes[sched.Next(from).In(ny).Hour()*60+sched.Next(from).In(ny).Minute()] = true
+	}
+	if len(minutes) < 2 {
+		t.Errorf("all scrapers run at the same minute: %v", minutes)
+	}
+}
+
+func TestWindowSchedule_SpansMidnight(t *testing.T) {
+	sched, err := ParseSchedule("@window 23:30-00:30")
+	if err != nil {
+		t.Fatal(err)
+	}
+	sched = withJitterSeed(sched, "LinkedIn Jobs")
+	next := time.Date(2025, 1, 15, 12, 0, 0, 0, time.UTC)
+	for i := 0; i < 10; i++ {
+		prev := next
+		next = sched.Next(next)
+		if m := next.Hour()*60 + next.Minute(); m < 23*60+30 && m >= 30 {
+			t.Errorf("run at %v, outside 23:30-00:30", next)
+		}
+		if gap := next.Sub(prev); i > 0 && (gap < 23*time.Hour || gap > 25*time.Hour) {
+			t.Errorf("runs %v apart, want about a day", gap)
+		}
+	}
+}
+
+func TestWindowSchedule_DST(t *testing.T) {
+	ny := newYork(t)
+
+	// 02:00-03:00 does not exist on the spring-forward night: the run
+	// falls in 03:00-04:00 EDT, once.
+	sched, err := ParseScheduleIn("@window 02:00-03:00", ny)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sched = withJitterSeed(sched, "LinkedIn Jobs")
+	got := sched.Next(time.Date(2025, 3, 8, 12, 0, 0, 0, ny))
+	if local := got.In(ny); local.Day() != 9 || local.Hour() != 3 {
+		t.Errorf("spring forward: run at %v, want 03:00-04:00 EDT on the 9th", local)
+	}
+	if next := sched.Next(got).In(ny); next.Day() != 10 {
+		t.Errorf("spring forward: second run at %v, want the 10th", next)
+	}
+
+	// 01:00-02:00 occurs twice on the fall-back night: the run happens
+	// once, in the EDT hour.
+	sched, err = ParseScheduleIn("@window 01:00-02:00", ny)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sched = withJitterSeed(sched, "LinkedIn Jobs")
+	got = sched.Next(time.Date(2025, 11, 1, 12, 0, 0, 0, ny))
+	if local := got.In(ny); local.Day() != 2 || local.Hour() != 1 || got.Hour() != 5 {
+		t.Errorf("fall back: run at %v (%v), want 01:00-02:00 EDT on the 2nd", local, got)
+	}
+	if next := sched.Next(got).In(ny); next.Day() != 3 {
+		t.Errorf("fall back: second run at %v, want the 3rd", next)
+	}
+}
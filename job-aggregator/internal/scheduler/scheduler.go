@@ -4,14 +4,29 @@ package scheduler
 
 import (
 	"context"
-	"database/sql"
+	"errors"
+	"fmt"
 	"log"
+	"sort"
+	"strings"
 	"sync"
 	"time"
 
+	"github.com/learnbot/job-aggregator/internal/httpclient"
 	"github.com/learnbot/job-aggregator/internal/model"
 	"github.com/learnbot/job-aggregator/internal/scraper"
 	"github.com/learnbot/job-aggregator/internal/storage"
+	"github.com/learnbot/shared/geo"
+	"github.com/learnbot/shared/metrics"
+	"github.com/learnbot/shared/redact"
+)
+
+var (
+	scrapeDuration = metrics.NewHistogram("scraper_run_duration_seconds",
+		"Duration of one scraper query run in seconds, by scraper and final status.",
+		[]float64{1, 5, 15, 30, 60, 120, 300, 600, 1800}, "scraper", "status")
+	jobsFound = metrics.NewCounter("scraper_jobs_found_total",
+		"Job postings returned by scrapers, by scraper.", "scraper")
 )
 
 // Config holds scheduler configuration.
@@ -22,8 +37,36 @@ type Config struct {
 	JobChannelBuffer int
 	// Default search queries to run
 	DefaultQueries []SearchQuery
-	// How long before a job is considered stale and marked expired
+	// How long a job may go unseen by the scraper that last saw it before
+	// the expiry pass marks it expired, for scrapers without an entry in
+	// StaleDurations.
 	JobStaleDuration time.Duration
+	// Per-scraper stale durations, keyed by scraper name (Scraper.Name()).
+	StaleDurations map[string]time.Duration
+	// Schedule of the stale job expiry pass, in the format accepted by
+	// ParseSchedule.
+	ExpirySchedule string
+	// Schedule used for scrapers without an entry in Schedules: a cron
+	// expression, interval or run window accepted by ParseSchedule.
+	DefaultSchedule string
+	// Per-scraper schedules, keyed by scraper name (Scraper.Name()).
+	Schedules map[string]string
+	// Per-scraper IANA time zones, such as "America/New_York", keyed by
+	// scraper name. A scraper's cron expressions and run windows are
+	// evaluated in its zone; scrapers without one use UTC.
+	TimeZones map[string]string
+	// Most jobs a worker stores with one UpsertJobs call; jobs a scraper
+	// has already produced are batched, so a slow scraper never waits for
+	// a batch to fill. 0 uses DefaultUpsertBatchSize.
+	UpsertBatchSize int
+	// Title similarity at which a posting is merged into an existing job
+	// from the same company; 0 disables fuzzy deduplication.
+	DedupThreshold float64
+	// Thresholds of the scraper health statuses.
+	Health HealthThresholds
+	// Redactor, if set, masks personal data such as recruiter emails and
+	// phone numbers in job descriptions before they are stored.
+	Redactor *redact.Redactor
 }
 
 // SearchQuery defines a search to run on each scrape cycle.
@@ -39,6 +82,11 @@ func DefaultConfig() Config {
 		WorkerCount:      5,
 		JobChannelBuffer: 100,
 		JobStaleDuration: 7 * 24 * time.Hour, // 7 days
+		ExpirySchedule:   DefaultExpirySchedule,
+		DefaultSchedule:  DefaultSchedule,
+		UpsertBatchSize:  DefaultUpsertBatchSize,
+		DedupThreshold:   storage.DefaultFuzzyDedupThreshold,
+		Health:           DefaultHealthThresholds(),
 		DefaultQueries: []SearchQuery{
 			{Query: "software engineer", Location: "United States", Remote: true},
 			{Query: "backend developer", Location: "United States", Remote: true},
@@ -49,28 +97,121 @@ func DefaultConfig() Config {
 	}
 }
 
+// Notifier is told about the jobs each scrape run stored for the first
+// time. It is implemented by *webhook.Dispatcher.
+type Notifier interface {
+	NotifyNewJobs(ctx context.Context, jobs []model.Job) error
+}
+
+// Analyzer folds the jobs stored by scrape runs into derived statistics.
+// It is run after each scrape run that stored new jobs, and is implemented
+// by *analytics.Aggregator.
+type Analyzer interface {
+	Run(ctx context.Context) (int, error)
+}
+
 // Scheduler orchestrates scraping runs with concurrent job processing.
 type Scheduler struct {
-	repo     *storage.JobRepository
+	repo     storage.JobRepository
 	scrapers []scraper.Scraper
 	config   Config
 	logger   *log.Logger
+	notifier Notifier
+	analyzer Analyzer
 	mu       sync.Mutex
 	running  bool
+
+	// defaultSpec is Config.DefaultSchedule, once validated.
+	defaultSpec string
+	// schedules holds the schedule and next run time of each scraper name.
+	schedules map[string]*scraperSchedule
+	// busy marks scrapers that are currently scraping, so that a scheduled
+	// run and a full cycle never run the same scraper twice at once.
+	busy map[scraper.Scraper]bool
+	// wake interrupts the schedule loop's wait after a schedule change.
+	wake chan struct{}
+	now  func() time.Time
+
+	// paused skips scheduled runs and disabled skips every run of the
+	// scraper names it holds; see controls.go. controlMu serializes their
+	// changes, which are stored before they are applied.
+	paused    bool
+	disabled  map[string]bool
+	controlMu sync.Mutex
+
+	// inflight counts scraper runs started by RunOnce, RunNow and the
+	// schedule. stopRuns cancels them once Shutdown has set draining.
+	inflight sync.WaitGroup
+	draining bool
+	stopCtx  context.Context
+	stopRuns context.CancelFunc
 }
 
-// New creates a new Scheduler.
-func New(db *sql.DB, scrapers []scraper.Scraper, cfg Config, logger *log.Logger) *Scheduler {
-	return &Scheduler{
-		repo:     storage.NewJobRepository(db),
-		scrapers: scrapers,
-		config:   cfg,
-		logger:   logger,
+// scraperSchedule is the schedule of one scraper name.
+type scraperSchedule struct {
+	spec     string
+	schedule Schedule
+	custom   bool
+	next     time.Time
+	// loc is the scraper's time zone, which spec is evaluated in.
+	loc *time.Location
+}
+
+// ScraperSchedule describes when a scraper runs next.
+type ScraperSchedule struct {
+	Scraper string `json:"scraper"`
+	// Schedule is the cron expression or interval in effect.
+	Schedule string `json:"schedule"`
+	// IsDefault is true when the scraper uses the default schedule.
+	IsDefault bool `json:"is_default"`
+	// TimeZone is the zone the schedule is evaluated in.
+	TimeZone string `json:"time_zone"`
+	// NextRun is the next run in UTC, and NextRunLocal the same instant
+	// in TimeZone.
+	NextRun      time.Time `json:"next_run"`
+	NextRunLocal time.Time `json:"next_run_local"`
+	Running      bool      `json:"running"`
+}
+
+// ErrUnknownScraper is returned by SetSchedule for a scraper name that is
+// not registered.
+var ErrUnknownScraper = errors.New("unknown scraper")
+
+// New creates a new Scheduler that stores scraped jobs in repo, with fuzzy
+// deduplication at cfg.DedupThreshold. Invalid schedules in cfg are logged
+// and replaced by the default schedule.
+func New(repo storage.JobRepository, scrapers []scraper.Scraper, cfg Config, logger *log.Logger) *Scheduler {
+	s := &Scheduler{
+		repo:      repo,
+		config:    cfg,
+		logger:    logger,
+		schedules: map[string]*scraperSchedule{},
+		busy:      map[scraper.Scraper]bool{},
+		disabled:  map[string]bool{},
+		wake:      make(chan struct{}, 1),
+		now:       time.Now,
+	}
+	s.stopCtx, s.stopRuns = context.WithCancel(context.Background())
+	s.repo.SetFuzzyDedupThreshold(cfg.DedupThreshold)
+
+	s.defaultSpec = cfg.DefaultSchedule
+	if s.defaultSpec == "" {
+		s.defaultSpec = DefaultSchedule
 	}
+	if _, err := ParseSchedule(s.defaultSpec); err != nil {
+		logger.Printf("[scheduler] invalid default schedule: %v; using %q", err, DefaultSchedule)
+		s.defaultSpec = DefaultSchedule
+	}
+
+	for _, sc := range scrapers {
+		s.AddScraper(sc)
+	}
+	return s
 }
 
-// RunOnce executes a single scraping cycle for all configured scrapers.
-// It uses a worker pool to process scraped jobs concurrently.
+// RunOnce executes a single scraping cycle for all enabled scrapers, even
+// while the schedule is paused. It uses a worker pool to process scraped
+// jobs concurrently.
 func (s *Scheduler) RunOnce(ctx context.Context) error {
 	s.mu.Lock()
 	if s.running {
@@ -87,16 +228,30 @@ func (s *Scheduler) RunOnce(ctx context.Context) error {
 		s.mu.Unlock()
 	}()
 
-	s.logger.Printf("[scheduler] starting scrape cycle with %d scrapers", len(s.scrapers))
+	scrapers := s.Scrapers()
+	s.logger.Printf("[scheduler] starting scrape cycle with %d scrapers", len(scrapers))
 	start := time.Now()
 
 	var wg sync.WaitGroup
-	for _, sc := range s.scrapers {
+	for _, sc := range scrapers {
+		if reason := s.skipReason(sc, false); reason != "" {
+			s.recordSkipped(ctx, sc, reason)
+			continue
+		}
+		if !s.acquire(sc) {
+			s.logger.Printf("[scheduler] %s is already scraping, skipping", sc.Name())
+			continue
+		}
 		wg.Add(1)
-		go func(sc scraper.Scraper) {
+		started := s.goTracked(ctx, func(ctx context.Context) {
 			defer wg.Done()
+			defer s.release(sc)
 			s.runScraper(ctx, sc)
-		}(sc)
+		})
+		if !started {
+			wg.Done()
+			s.release(sc)
+		}
 	}
 
 	wg.Wait()
@@ -127,7 +282,7 @@ func (s *Scheduler) runScraper(ctx context.Context, sc scraper.Scraper) {
 // runScraperQuery runs a single scraper for a single search query.
 func (s *Scheduler) runScraperQuery(ctx context.Context, sc scraper.Scraper, params model.SearchParams) {
 	// Create scrape run log
-	run, err := s.repo.CreateScrapeRun(ctx, sc.Source(), params.Query, params.Location)
+	run, err := s.repo.CreateScrapeRun(ctx, sc.Source(), sc.Name(), params.Query, params.Location)
 	if err != nil {
 		s.logger.Printf("[scheduler] failed to create scrape run: %v", err)
 		return
@@ -136,9 +291,12 @@ func (s *Scheduler) runScraperQuery(ctx context.Context, sc scraper.Scraper, par
 	s.logger.Printf("[scheduler] %s: scraping query=%q location=%q",
 		sc.Name(), params.Query, params.Location)
 
-	// Create jobs channel and start worker pool
+	// Create jobs channel and start worker pool. Workers store every job
+	// the scraper sends, even after ctx is cancelled: scrapers stop between
+	// pages, so finishing the queue never leaves half a page behind.
 	jobsCh := make(chan *model.ScrapedJob, s.config.JobChannelBuffer)
 	stats := &scrapeStats{}
+	storeCtx := context.WithoutCancel(ctx)
 
 	// Start worker pool
 	workerCount := s.config.WorkerCount
@@ -151,12 +309,13 @@ func (s *Scheduler) runScraperQuery(ctx context.Context, sc scraper.Scraper, par
 		workerWg.Add(1)
 		go func() {
 			defer workerWg.Done()
-			s.processJobs(ctx, jobsCh, stats)
+			s.processJobs(storeCtx, sc.Name(), jobsCh, stats)
 		}()
 	}
 
 	// Run scraper (sends to jobsCh)
-	scrapeErr := sc.Scrape(ctx, params, jobsCh)
+	start := time.Now()
+	scrapeErr := scrape(ctx, sc, params, jobsCh)
 	close(jobsCh)
 
 	// Wait for all workers to finish
@@ -165,12 +324,27 @@ func (s *Scheduler) runScraperQuery(ctx context.Context, sc scraper.Scraper, par
 	// Update scrape run with final stats
 	finalStatus := model.ScrapeStatusCompleted
 	errMsg := ""
-	if scrapeErr != nil {
+	switch {
+	case ctx.Err() != nil:
+		// The pages scraped before cancellation have been stored.
+		finalStatus = model.ScrapeStatusInterrupted
+		errMsg = "interrupted: " + ctx.Err().Error()
+		s.logger.Printf("[scheduler] %s interrupted", sc.Name())
+	case scrapeErr != nil:
+		// A source that was still throttling us when retries ran out is
+		// recorded separately from a permanent failure; the next scheduled
+		// run will simply try again.
 		finalStatus = model.ScrapeStatusFailed
+		var reqErr *httpclient.Error
+		if errors.As(scrapeErr, &reqErr) && reqErr.RateLimited() {
+			finalStatus = model.ScrapeStatusRateLimited
+		}
 		errMsg = scrapeErr.Error()
 		s.logger.Printf("[scheduler] %s scrape error: %v", sc.Name(), scrapeErr)
 	}
 
+	s.notifyNewJobs(ctx, sc, stats)
+
 	stats.mu.Lock()
 	finalRun := model.ScrapeRun{
 		JobsFound:    stats.found,
@@ -182,56 +356,164 @@ func (s *Scheduler) runScraperQuery(ctx context.Context, sc scraper.Scraper, par
 	}
 	stats.mu.Unlock()
 
-	if err := s.repo.UpdateScrapeRun(ctx, run.ID, finalStatus, finalRun); err != nil {
+	scrapeDuration.Observe(time.Since(start).Seconds(), sc.Name(), string(finalStatus))
+	jobsFound.Add(float64(finalRun.JobsFound), sc.Name())
+
+	// Record the result even if the run was cancelled, so that the row does
+	// not stay "running".
+	if err := s.repo.UpdateScrapeRun(context.WithoutCancel(ctx), run.ID, finalStatus, finalRun); err != nil {
 		s.logger.Printf("[scheduler] failed to update scrape run: %v", err)
 	}
+	s.refreshHealth(context.WithoutCancel(ctx), sc)
+	if finalRun.JobsNew > 0 {
+		s.analyze(ctx, sc)
+	}
 
-	// Mark stale jobs as expired
-	cutoff := time.Now().Add(-s.config.JobStaleDuration)
-	expired, err := s.repo.MarkExpiredJobs(ctx, sc.Source(), cutoff)
-	if err != nil {
-		s.logger.Printf("[scheduler] failed to mark expired jobs: %v", err)
-	} else if expired > 0 {
-		s.logger.Printf("[scheduler] marked %d jobs as expired for %s", expired, sc.Source())
+	if finalStatus == model.ScrapeStatusInterrupted {
+		s.logger.Printf("[scheduler] %s: found=%d new=%d updated=%d failed=%d (interrupted)",
+			sc.Name(), finalRun.JobsFound, finalRun.JobsNew, finalRun.JobsUpdated, finalRun.JobsFailed)
+		return
 	}
 
 	s.logger.Printf("[scheduler] %s: found=%d new=%d updated=%d failed=%d",
 		sc.Name(), finalRun.JobsFound, finalRun.JobsNew, finalRun.JobsUpdated, finalRun.JobsFailed)
 }
 
-// processJobs is a worker that reads from the jobs channel and stores them.
-func (s *Scheduler) processJobs(ctx context.Context, jobs <-chan *model.ScrapedJob, stats *scrapeStats) {
+// scrape runs sc.Scrape, turning a panic into an error so that the run is
+// recorded as failed instead of taking down the scheduler.
+func scrape(ctx context.Context, sc scraper.Scraper, params model.SearchParams, jobs chan<- *model.ScrapedJob) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("scraper panic: %v", r)
+		}
+	}()
+	return sc.Scrape(ctx, params, jobs)
+}
+
+// DefaultUpsertBatchSize is the default Config.UpsertBatchSize.
+const DefaultUpsertBatchSize = 100
+
+// processJobs is a worker that reads from the jobs channel and stores them,
+// as last seen by the named scraper, until the channel is closed. Jobs
+// already waiting in the channel are stored together, up to
+// Config.UpsertBatchSize at a time.
+func (s *Scheduler) processJobs(ctx context.Context, scraperName string, jobs <-chan *model.ScrapedJob, stats *scrapeStats) {
+	size := s.config.UpsertBatchSize
+	if size <= 0 {
+		size = DefaultUpsertBatchSize
+	}
+	batch := make([]*model.ScrapedJob, 0, size)
 	for job := range jobs {
-		select {
-		case <-ctx.Done():
-			return
-		default:
+		batch = append(batch[:0], job)
+	drain:
+		for len(batch) < size {
+			select {
+			case job, ok := <-jobs:
+				if !ok {
+					break drain
+				}
+				batch = append(batch, job)
+			default:
+				break drain
+			}
 		}
 
 		stats.mu.Lock()
-		stats.found++
+		stats.found += len(batch)
 		stats.mu.Unlock()
-
-		_, isNew, err := s.repo.UpsertJob(ctx, job)
-		if err != nil {
-			s.logger.Printf("[scheduler] failed to upsert job %q at %s: %v",
-				job.Title, job.CompanyName, err)
-			stats.mu.Lock()
-			stats.failed++
-			stats.mu.Unlock()
-			continue
+		for _, job := range batch {
+			job.ScraperName = scraperName
+			s.prepareJob(ctx, job)
 		}
+		s.storeJobs(ctx, batch, stats)
+	}
+}
 
+// storeJobs upserts a batch of jobs and counts them in stats. If the batch
+// fails, its jobs are stored one at a time so that one bad posting does
+// not lose the others.
+func (s *Scheduler) storeJobs(ctx context.Context, batch []*model.ScrapedJob, stats *scrapeStats) {
+	results, err := s.repo.UpsertJobs(ctx, batch)
+	if err != nil && len(batch) == 1 {
+		s.logger.Printf("[scheduler] failed to upsert job %q at %s: %v",
+			batch[0].Title, batch[0].CompanyName, err)
 		stats.mu.Lock()
-		if isNew {
+		stats.failed++
+		stats.mu.Unlock()
+		return
+	}
+	if err != nil {
+		s.logger.Printf("[scheduler] failed to upsert %d jobs, retrying one at a time: %v", len(batch), err)
+		results = make([]storage.UpsertResult, 0, len(batch))
+		for _, job := range batch {
+			stored, isNew, err := s.repo.UpsertJob(ctx, job)
+			if err != nil {
+				s.logger.Printf("[scheduler] failed to upsert job %q at %s: %v",
+					job.Title, job.CompanyName, err)
+				stats.mu.Lock()
+				stats.failed++
+				stats.mu.Unlock()
+				continue
+			}
+			results = append(results, storage.UpsertResult{Job: stored, Inserted: isNew})
+		}
+	}
+
+	for _, res := range results {
+		for field, change := range res.Changes {
+			s.logger.Printf("[scheduler] job %s %s changed from %q to %q; kept the stored value",
+				res.Job.ID, field, change.Old, change.New)
+		}
+	}
+	stats.mu.Lock()
+	defer stats.mu.Unlock()
+	for _, res := range results {
+		if res.Inserted {
 			stats.newJobs++
+			stats.created = append(stats.created, *res.Job)
 		} else {
 			stats.updated++
 		}
-		stats.mu.Unlock()
 	}
 }
 
+// prepareJob fills in the fields derived from a scraped job and redacts its
+// description before it is stored.
+func (s *Scheduler) prepareJob(ctx context.Context, job *model.ScrapedJob) {
+	s.normalizeLocation(ctx, job)
+	// Salaries are extracted first; redaction leaves amounts alone, but
+	// the raw description is the better source.
+	scraper.NormalizeSalary(job)
+	if r := s.config.Redactor; r != nil {
+		job.Description = r.String(job.Description)
+		job.DescriptionHTML = r.String(job.DescriptionHTML)
+	}
+}
+
+// normalizeLocation sets the structured location of a scraped job from
+// its location text, parsing each text once and caching the result in the
+// repository. Cache errors are logged and the text parsed anyway; a
+// location the parser does not know is stored as the scraper found it.
+func (s *Scheduler) normalizeLocation(ctx context.Context, job *model.ScrapedJob) {
+	raw := scraper.LocationText(job)
+	if raw == "" {
+		return
+	}
+	loc, ok, err := s.repo.GetCachedLocation(ctx, raw)
+	if err != nil {
+		s.logger.Printf("[scheduler] location cache lookup for %q: %v", raw, err)
+	}
+	if !ok {
+		loc = geo.Parse(raw)
+		if err == nil {
+			if err := s.repo.PutCachedLocation(ctx, raw, loc); err != nil {
+				s.logger.Printf("[scheduler] location cache store for %q: %v", raw, err)
+			}
+		}
+	}
+	scraper.ApplyLocation(job, loc)
+}
+
 // scrapeStats holds thread-safe counters for a scraping run.
 type scrapeStats struct {
 	mu      sync.Mutex
@@ -240,39 +522,106 @@ type scrapeStats struct {
 	updated int
 	failed  int
 	pages   int
+	// created holds the jobs stored for the first time, for the notifier.
+	created []model.Job
 }
 
-// StartDailySchedule starts a background goroutine that runs the scraper
-// on the configured schedule (default: daily at 2am UTC).
-func (s *Scheduler) StartDailySchedule(ctx context.Context) {
+// notifyNewJobs hands the run's new jobs to the notifier, if one is set.
+// Jobs stored before an interruption are still notified.
+func (s *Scheduler) notifyNewJobs(ctx context.Context, sc scraper.Scraper, stats *scrapeStats) {
+	s.mu.Lock()
+	n := s.notifier
+	s.mu.Unlock()
+
+	stats.mu.Lock()
+	created := stats.created
+	stats.mu.Unlock()
+	if n == nil || len(created) == 0 {
+		return
+	}
+	if err := n.NotifyNewJobs(context.WithoutCancel(ctx), created); err != nil {
+		s.logger.Printf("[scheduler] failed to notify %d new jobs from %s: %v", len(created), sc.Name(), err)
+	}
+}
+
+// analyze runs the analyzer, if one is set. Jobs stored before an
+// interruption are still analyzed.
+func (s *Scheduler) analyze(ctx context.Context, sc scraper.Scraper) {
+	s.mu.Lock()
+	a := s.analyzer
+	s.mu.Unlock()
+	if a == nil {
+		return
+	}
+	if _, err := a.Run(context.WithoutCancel(ctx)); err != nil {
+		s.logger.Printf("[scheduler] failed to analyze new jobs from %s: %v", sc.Name(), err)
+	}
+}
+
+// StartSchedule starts a background goroutine that runs each scraper on its
+// own schedule (by default daily at 2am UTC). A scraper that is still
+// running when its next run is due skips that run, as does every scraper
+// while the schedule is paused and a disabled scraper.
+func (s *Scheduler) StartSchedule(ctx context.Context) {
 	go func() {
-		s.logger.Println("[scheduler] daily schedule started")
+		s.logger.Println("[scheduler] schedule started")
 
 		for {
-			// Calculate next run time (2am UTC)
-			now := time.Now().UTC()
-			next := time.Date(now.Year(), now.Month(), now.Day(), 2, 0, 0, 0, time.UTC)
-			if next.Before(now) {
-				next = next.Add(24 * time.Hour)
+			var timer *time.Timer
+			var wait <-chan time.Time
+			if next := s.nextRun(); !next.IsZero() {
+				d := next.Sub(s.now())
+				s.logger.Printf("[scheduler] next run at %v (in %v)", next, d)
+				timer = time.NewTimer(d)
+				wait = timer.C
 			}
 
-			waitDuration := time.Until(next)
-			s.logger.Printf("[scheduler] next run at %v (in %v)", next, waitDuration)
-
+			due := false
 			select {
 			case <-ctx.Done():
-				s.logger.Println("[scheduler] daily schedule stopped")
+			case <-s.wake:
+			case <-wait:
+				due = true
+			}
+			if timer != nil {
+				timer.Stop()
+			}
+			if ctx.Err() != nil {
+				s.logger.Println("[scheduler] schedule stopped")
 				return
-			case <-time.After(waitDuration):
-				if err := s.RunOnce(ctx); err != nil {
-					s.logger.Printf("[scheduler] daily run error: %v", err)
+			}
+			if !due {
+				continue
+			}
+
+			for _, sc := range s.takeDue(s.now()) {
+				started := s.goTracked(ctx, func(ctx context.Context) {
+					defer s.release(sc)
+					s.runScheduled(ctx, sc)
+				})
+				if !started {
+					s.release(sc)
 				}
 			}
 		}
 	}()
 }
 
-// RunNow triggers an immediate scraping run (for manual/admin use).
+// runScheduled runs sc for its schedule, unless the schedule is paused or
+// sc disabled. The flags are checked as each run starts, so changing them
+// never stops a run in progress.
+func (s *Scheduler) runScheduled(ctx context.Context, sc scraper.Scraper) {
+	if reason := s.skipReason(sc, true); reason != "" {
+		s.recordSkipped(ctx, sc, reason)
+		return
+	}
+	s.logger.Printf("[scheduler] scheduled run of %s", sc.Name())
+	s.runScraper(ctx, sc)
+}
+
+// RunNow triggers an immediate scraping run (for manual/admin use). The run
+// stops when ctx is cancelled or the scheduler shuts down; callers passing a
+// request context should detach it with context.WithoutCancel.
 func (s *Scheduler) RunNow(ctx context.Context) {
 	go func() {
 		if err := s.RunOnce(ctx); err != nil {
@@ -281,7 +630,266 @@ func (s *Scheduler) RunNow(ctx context.Context) {
 	}()
 }
 
-// IsRunning returns true if a scraping cycle is currently in progress.
+// goTracked runs fn in a goroutine that Shutdown waits for. fn's context is
+// also cancelled by Shutdown. It returns false, without running fn, once
+// Shutdown has been called.
+func (s *Scheduler) goTracked(ctx context.Context, fn func(ctx context.Context)) bool {
+	s.mu.Lock()
+	if s.draining {
+		s.mu.Unlock()
+		return false
+	}
+	s.inflight.Add(1)
+	s.mu.Unlock()
+
+	ctx, cancel := context.WithCancel(ctx)
+	stop := context.AfterFunc(s.stopCtx, cancel)
+	go func() {
+		defer s.inflight.Done()
+		defer cancel()
+		defer stop()
+		fn(ctx)
+	}()
+	return true
+}
+
+// Shutdown stops new scraper runs, cancels the ones in flight and waits
+// until each has stored the pages it already scraped and recorded its run
+// as interrupted. It returns ctx's error if that takes longer than ctx
+// allows.
+func (s *Scheduler) Shutdown(ctx context.Context) error {
+	s.mu.Lock()
+	s.draining = true
+	s.mu.Unlock()
+	s.stopRuns()
+
+	done := make(chan struct{})
+	go func() {
+		s.inflight.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// SetNotifier passes the new jobs of every subsequent scrape run to n.
+func (s *Scheduler) SetNotifier(n Notifier) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.notifier = n
+}
+
+// SetAnalyzer runs a after every subsequent scrape run that stores new
+// jobs.
+func (s *Scheduler) SetAnalyzer(a Analyzer) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.analyzer = a
+}
+
+// AddScraper registers an additional scraper. It takes part from the next
+// scrape cycle onwards; a cycle already in progress is not affected.
+func (s *Scheduler) AddScraper(sc scraper.Scraper) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.scrapers = append(s.scrapers, sc)
+
+	name := sc.Name()
+	if _, ok := s.schedules[name]; ok {
+		return
+	}
+	entry := &scraperSchedule{loc: time.UTC}
+	if zone, ok := s.config.TimeZones[name]; ok {
+		if loc, err := time.LoadLocation(zone); err != nil {
+			s.logger.Printf("[scheduler] invalid time zone for %s: %v; using UTC", name, err)
+		} else {
+			entry.loc = loc
+		}
+	}
+	entry.spec, entry.schedule = s.defaultSpec, entry.parse(name, s.defaultSpec)
+	if spec, ok := s.config.Schedules[name]; ok {
+		if sched, err := entry.parseErr(name, spec); err != nil {
+			s.logger.Printf("[scheduler] invalid schedule for %s: %v; using the default", name, err)
+		} else {
+			entry.spec, entry.schedule, entry.custom = spec, sched, true
+		}
+	}
+	entry.next = entry.schedule.Next(s.now())
+	s.schedules[name] = entry
+	s.signal()
+}
+
+// Scrapers returns a snapshot of the registered scrapers.
+func (s *Scheduler) Scrapers() []scraper.Scraper {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]scraper.Scraper(nil), s.scrapers...)
+}
+
+// Schedules returns the schedule and next run time of every scraper, sorted
+// by scraper name.
+func (s *Scheduler) Schedules() []ScraperSchedule {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	running := map[string]bool{}
+	for sc := range s.busy {
+		running[sc.Name()] = true
+	}
+	out := make([]ScraperSchedule, 0, len(s.schedules))
+	for name, e := range s.schedules {
+		out = append(out, e.describe(name, running[name]))
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Scraper < out[j].Scraper })
+	return out
+}
+
+// SetSchedule changes the schedule of the named scraper at runtime and
+// returns its new schedule. An empty spec restores the default schedule.
+// If spec is invalid the error describes why and the current schedule is
+// kept. The change is not persisted across restarts.
+func (s *Scheduler) SetSchedule(name, spec string) (ScraperSchedule, error) {
+	spec = strings.TrimSpace(spec)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	e, ok := s.schedules[name]
+	if !ok {
+		// An invalid spec is still reported first.
+		if spec != "" {
+			if _, err := ParseSchedule(spec); err != nil {
+				return ScraperSchedule{}, err
+			}
+		}
+		return ScraperSchedule{}, fmt.Errorf("%w %q", ErrUnknownScraper, name)
+	}
+	if spec == "" {
+		e.spec, e.schedule, e.custom = s.defaultSpec, e.parse(name, s.defaultSpec), false
+	} else {
+		sched, err := e.parseErr(name, spec)
+		if err != nil {
+			return ScraperSchedule{}, err
+		}
+		e.spec, e.schedule, e.custom = spec, sched, true
+	}
+	e.next = e.schedule.Next(s.now())
+	s.signal()
+
+	running := false
+	for sc := range s.busy {
+		if sc.Name() == name {
+			running = true
+		}
+	}
+	s.logger.Printf("[scheduler] schedule for %s set to %q; next run at %v", name, e.spec, e.next)
+	return e.describe(name, running), nil
+}
+
+// parseErr parses spec in the scraper's time zone, with run windows
+// jittered by the scraper name.
+func (e *scraperSchedule) parseErr(name, spec string) (Schedule, error) {
+	sched, err := ParseScheduleIn(spec, e.loc)
+	if err != nil {
+		return nil, err
+	}
+	return withJitterSeed(sched, name), nil
+}
+
+// parse is parseErr for a spec already known to be valid.
+func (e *scraperSchedule) parse(name, spec string) Schedule {
+	sched, _ := e.parseErr(name, spec)
+	return sched
+}
+
+func (e *scraperSchedule) describe(name string, running bool) ScraperSchedule {
+	return ScraperSchedule{
+		Scraper:      name,
+		Schedule:     e.spec,
+		IsDefault:    !e.custom,
+		TimeZone:     e.loc.String(),
+		NextRun:      e.next.UTC(),
+		NextRunLocal: e.next.In(e.loc),
+		Running:      running,
+	}
+}
+
+// nextRun returns the earliest next run time over all scrapers, or the
+// zero time if none is scheduled.
+func (s *Scheduler) nextRun() time.Time {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var next time.Time
+	for _, e := range s.schedules {
+		if !e.next.IsZero() && (next.IsZero() || e.next.Before(next)) {
+			next = e.next
+		}
+	}
+	return next
+}
+
+// takeDue advances the schedule of every scraper due at or before now and
+// returns the due scrapers that are not already running, marked busy. The
+// caller must release each of them.
+func (s *Scheduler) takeDue(now time.Time) []scraper.Scraper {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	due := map[string]bool{}
+	for name, e := range s.schedules {
+		if !e.next.IsZero() && !e.next.After(now) {
+			due[name] = true
+			e.next = e.schedule.Next(now)
+		}
+	}
+
+	var out []scraper.Scraper
+	for _, sc := range s.scrapers {
+		if !due[sc.Name()] {
+			continue
+		}
+		if s.busy[sc] {
+			s.logger.Printf("[scheduler] %s is still running, skipping scheduled run", sc.Name())
+			continue
+		}
+		s.busy[sc] = true
+		out = append(out, sc)
+	}
+	return out
+}
+
+// acquire marks sc busy, returning false if it already is.
+func (s *Scheduler) acquire(sc scraper.Scraper) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.busy[sc] {
+		return false
+	}
+	s.busy[sc] = true
+	return true
+}
+
+func (s *Scheduler) release(sc scraper.Scraper) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.busy, sc)
+}
+
+// signal wakes the schedule loop so that it picks up a schedule change.
+func (s *Scheduler) signal() {
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
+// IsRunning returns true if a full scraping cycle (RunOnce) is in progress.
+// Scheduled runs of individual scrapers are reported by Schedules.
 func (s *Scheduler) IsRunning() bool {
 	s.mu.Lock()
 	defer s.mu.Unlock()
@@ -0,0 +1,528 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+
+	"github.com/learnbot/job-aggregator/internal/httpclient"
+	"github.com/learnbot/job-aggregator/internal/model"
+	"github.com/learnbot/job-aggregator/internal/scraper"
+	"github.com/learnbot/job-aggregator/internal/storage"
+	"github.com/learnbot/shared/geo"
+	"github.com/learnbot/shared/metrics"
+	"github.com/learnbot/shared/redact"
+)
+
+type panickingScraper struct{}
+
+func (panickingScraper) Source() model.JobSource { return model.SourceOther }
+func (panickingScraper) Name() string            { return "panicking" }
+func (panickingScraper) Scrape(context.Context, model.SearchParams, chan<- *model.ScrapedJob) error {
+	panic("selector returned nil")
+}
+
+func TestScrape_RecoversPanic(t *testing.T) {
+	jobs := make(chan *model.ScrapedJob, 1)
+	err := scrape(context.Background(), panickingScraper{}, model.SearchParams{}, jobs)
+	if err == nil || !strings.Contains(err.Error(), "selector returned nil") {
+		t.Errorf("expected the panic as an error, got %v", err)
+	}
+}
+
+type namedScraper struct{ name string }
+
+func (s *namedScraper) Source() model.JobSource { return model.SourceOther }
+func (s *namedScraper) Name() string            { return s.name }
+func (s *namedScraper) Scrape(context.Context, model.SearchParams, chan<- *model.ScrapedJob) error {
+	return nil
+}
+
+// newTestScheduler builds a scheduler with a fixed clock. It never touches
+// the database unless a scrape runs.
+func newTestScheduler(cfg Config, scrapers ...scraper.Scraper) *Scheduler {
+	s := New(storage.NewPostgresRepository(nil), nil, cfg, log.New(io.Discard, "", 0))
+	s.now = func() time.Time { return time.Date(2025, 1, 15, 10, 30, 0, 0, time.UTC) }
+	for _, sc := range scrapers {
+		s.AddScraper(sc)
+	}
+	return s
+}
+
+func TestScheduler_PerScraperSchedules(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Schedules = map[string]string{
+		"LinkedIn Jobs": "0 2 * * 1",
+		"Indeed":        "not a schedule",
+	}
+	s := newTestScheduler(cfg, &namedScraper{"LinkedIn Jobs"}, &namedScraper{"Indeed"}, &namedScraper{"Career Page: Acme"})
+
+	got := map[string]ScraperSchedule{}
+	for _, sc := range s.Schedules() {
+		got[sc.Scraper] = sc
+	}
+	if li := got["LinkedIn Jobs"]; li.IsDefault || !li.NextRun.Equal(time.Date(2025, 1, 20, 2, 0, 0, 0, time.UTC)) {
+		t.Errorf("unexpected LinkedIn schedule: %+v", li)
+	}
+	// An invalid configured schedule falls back to the default.
+	if in := got["Indeed"]; !in.IsDefault || in.Schedule != DefaultSchedule {
+		t.Errorf("expected Indeed on the default schedule, got %+v", in)
+	}
+	if cp := got["Career Page: Acme"]; !cp.NextRun.Equal(time.Date(2025, 1, 16, 2, 0, 0, 0, time.UTC)) {
+		t.Errorf("unexpected default next run: %v", cp.NextRun)
+	}
+}
+
+func TestScheduler_SetSchedule(t *testing.T) {
+	s := newTestScheduler(DefaultConfig(), &namedScraper{"Career Page: Acme"})
+
+	sched, err := s.SetSchedule("Career Page: Acme", "@every 1h")
+	if err != nil {
+		t.Fatalf("SetSchedule: %v", err)
+	}
+	if sched.IsDefault || sched.Schedule != "@every 1h" || !sched.NextRun.Equal(time.Date(2025, 1, 15, 11, 30, 0, 0, time.UTC)) {
+		t.Errorf("unexpected schedule: %+v", sched)
+	}
+
+	// An invalid expression is rejected and the previous schedule kept.
+	if _, err := s.SetSchedule("Career Page: Acme", "0 25 * * *"); err == nil || !strings.Contains(err.Error(), "hour value 25") {
+		t.Errorf("expected an hour range error, got %v", err)
+	}
+	if cur := s.Schedules()[0]; cur.Schedule != "@every 1h" {
+		t.Errorf("previous schedule not retained: %+v", cur)
+	}
+
+	if _, err := s.SetSchedule("Glassdoor", "@daily"); !errors.Is(err, ErrUnknownScraper) {
+		t.Errorf("expected ErrUnknownScraper, got %v", err)
+	}
+
+	if sched, err := s.SetSchedule("Career Page: Acme", ""); err != nil || !sched.IsDefault || sched.Schedule != DefaultSchedule {
+		t.Errorf("empty schedule should restore the default, got %+v (%v)", sched, err)
+	}
+}
+
+func TestScheduler_TimeZones(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.TimeZones = map[string]string{
+		"LinkedIn Jobs": "America/New_York",
+		"Indeed":        "Mars/Olympus_Mons",
+	}
+	s := newTestScheduler(cfg, &namedScraper{"LinkedIn Jobs"}, &namedScraper{"Indeed"})
+
+	got := map[string]ScraperSchedule{}
+	for _, sc := range s.Schedules() {
+		got[sc.Scraper] = sc
+	}
+	// The default schedule, 2am, is in the scraper's own zone.
+	li := got["LinkedIn Jobs"]
+	if li.TimeZone != "America/New_York" || !li.NextRun.Equal(time.Date(2025, 1, 16, 7, 0, 0, 0, time.UTC)) ||
+		li.NextRun.Location() != time.UTC {
+		t.Errorf("LinkedIn Jobs: %+v", li)
+	}
+	if local := li.NextRunLocal; local.Hour() != 2 || local.Location().String() != "America/New_York" {
+		t.Errorf("LinkedIn Jobs: next local run %v, want 02:00 New York time", local)
+	}
+	// An unknown zone falls back to UTC.
+	if in := got["Indeed"]; in.TimeZone != "UTC" || !in.NextRun.Equal(time.Date(2025, 1, 16, 2, 0, 0, 0, time.UTC)) {
+		t.Errorf("Indeed: %+v", in)
+	}
+
+	sched, err := s.SetSchedule("LinkedIn Jobs", "@window 03:00-04:00")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if local := sched.NextRunLocal; local.Day() != 16 || local.Hour() != 3 {
+		t.Errorf("window: next local run %v, want 03:00-04:00 on the 16th", local)
+	}
+}
+
+func TestScheduler_TakeDue(t *testing.T) {
+	hourly, daily := &namedScraper{"hourly"}, &namedScraper{"daily"}
+	s := newTestScheduler(DefaultConfig(), hourly, daily)
+	if _, err := s.SetSchedule("hourly", "0 * * * *"); err != nil {
+		t.Fatal(err)
+	}
+
+	at := time.Date(2025, 1, 15, 11, 0, 0, 0, time.UTC)
+	if next := s.nextRun(); !next.Equal(at) {
+		t.Fatalf("expected the next run at %v, got %v", at, next)
+	}
+
+	due := s.takeDue(at)
+	if len(due) != 1 || due[0] != hourly {
+		t.Fatalf("expected only the hourly scraper to be due, got %v", due)
+	}
+	if next := s.nextRun(); !next.Equal(at.Add(time.Hour)) {
+		t.Errorf("schedule not advanced: %v", next)
+	}
+
+	// Still running an hour later: the run is skipped but the schedule
+	// still advances.
+	if due := s.takeDue(at.Add(time.Hour)); len(due) != 0 {
+		t.Errorf("a busy scraper should be skipped, got %v", due)
+	}
+	if !s.Schedules()[1].Running {
+		t.Error("hourly should be reported as running")
+	}
+	s.release(hourly)
+	if due := s.takeDue(at.Add(2 * time.Hour)); len(due) != 1 {
+		t.Errorf("expected the hourly scraper after release, got %v", due)
+	}
+}
+
+type failingScraper struct{}
+
+func (failingScraper) Source() model.JobSource { return model.SourceOther }
+func (failingScraper) Name() string            { return "metrics-failing" }
+func (failingScraper) Scrape(context.Context, model.SearchParams, chan<- *model.ScrapedJob) error {
+	return errors.New("blocked by robots.txt")
+}
+
+func TestRunScraperQuery_RecordsMetrics(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock: %v", err)
+	}
+	defer db.Close()
+	now := time.Now()
+	mock.ExpectQuery("INSERT INTO scrape_runs").WillReturnRows(sqlmock.NewRows([]string{
+		"id", "source", "scraper_name", "search_query", "search_location", "status",
+		"jobs_found", "jobs_new", "jobs_updated", "jobs_failed", "pages_scraped",
+		"error_message", "started_at", "completed_at", "duration_ms", "created_at",
+	}).AddRow(uuid.New().String(), "other", "metrics-failing", "go", "", "running",
+		0, 0, 0, 0, 0, "", now, nil, nil, now))
+	mock.ExpectExec("UPDATE scrape_runs").WillReturnResult(sqlmock.NewResult(0, 1))
+
+	before := scrapeDuration.Count("metrics-failing", "failed")
+	s := New(storage.NewPostgresRepository(db), nil, DefaultConfig(), log.New(io.Discard, "", 0))
+	s.runScraperQuery(context.Background(), failingScraper{}, model.SearchParams{Query: "go"})
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unmet expectations: %v", err)
+	}
+	if got := scrapeDuration.Count("metrics-failing", "failed") - before; got != 1 {
+		t.Errorf("expected 1 failed run observation, got %d", got)
+	}
+
+	w := httptest.NewRecorder()
+	metrics.Handler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	for _, want := range []string{
+		`scraper_run_duration_seconds_count{scraper="metrics-failing",status="failed"}`,
+		`scraper_jobs_found_total{scraper="metrics-failing"} 0`,
+	} {
+		if !strings.Contains(w.Body.String(), want) {
+			t.Errorf("metrics output missing %q", want)
+		}
+	}
+}
+
+// throttledScraper fails the way LinkedIn does when it keeps answering 429.
+type throttledScraper struct{}
+
+func (throttledScraper) Source() model.JobSource { return model.SourceOther }
+func (throttledScraper) Name() string            { return "throttled" }
+func (throttledScraper) Scrape(context.Context, model.SearchParams, chan<- *model.ScrapedJob) error {
+	return fmt.Errorf("linkedin scrape failed: fetch page: %w", &httpclient.Error{
+		Method: http.MethodGet, URL: "https://example.com/jobs", StatusCode: http.StatusTooManyRequests,
+		Attempts: 4, Err: errors.New("HTTP 429 Too Many Requests"),
+	})
+}
+
+func TestRunScraperQuery_ExhaustedRetriesOn429AreRateLimited(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock: %v", err)
+	}
+	defer db.Close()
+	now := time.Now()
+	mock.ExpectQuery("INSERT INTO scrape_runs").WillReturnRows(sqlmock.NewRows([]string{
+		"id", "source", "scraper_name", "search_query", "search_location", "status",
+		"jobs_found", "jobs_new", "jobs_updated", "jobs_failed", "pages_scraped",
+		"error_message", "started_at", "completed_at", "duration_ms", "created_at",
+	}).AddRow(uuid.New().String(), "other", "throttled", "go", "", "running",
+		0, 0, 0, 0, 0, "", now, nil, nil, now))
+	mock.ExpectExec("UPDATE scrape_runs").WillReturnResult(sqlmock.NewResult(0, 1))
+
+	rateLimited := scrapeDuration.Count("throttled", "rate_limited")
+	failed := scrapeDuration.Count("throttled", "failed")
+	s := New(storage.NewPostgresRepository(db), nil, DefaultConfig(), log.New(io.Discard, "", 0))
+	s.runScraperQuery(context.Background(), throttledScraper{}, model.SearchParams{Query: "go"})
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unmet expectations: %v", err)
+	}
+	if got := scrapeDuration.Count("throttled", "rate_limited") - rateLimited; got != 1 {
+		t.Errorf("expected 1 rate_limited run, got %d", got)
+	}
+	if got := scrapeDuration.Count("throttled", "failed") - failed; got != 0 {
+		t.Errorf("expected no failed runs, got %d", got)
+	}
+}
+
+// pagedScraper sends pages of perPage jobs, checking ctx between pages as
+// real scrapers do. Halfway through the second page it signals midPage and
+// waits for cancellation before finishing the page.
+type pagedScraper struct {
+	pages, perPage int
+	midPage        chan struct{}
+	pagesStarted   atomic.Int32
+}
+
+func (s *pagedScraper) Source() model.JobSource { return model.SourceOther }
+func (s *pagedScraper) Name() string            { return "paged" }
+func (s *pagedScraper) Scrape(ctx context.Context, _ model.SearchParams, jobs chan<- *model.ScrapedJob) error {
+	for page := 0; page < s.pages; page++ {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		s.pagesStarted.Add(1)
+		for i := 0; i < s.perPage; i++ {
+			if page == 1 && i == 1 {
+				close(s.midPage)
+				<-ctx.Done()
+			}
+			jobs <- pagedJob(page, i)
+		}
+	}
+	return nil
+}
+
+// pagedJob is the i-th job on a page of pagedScraper.
+func pagedJob(page, i int) *model.ScrapedJob {
+	return &model.ScrapedJob{
+		Source:         model.SourceOther,
+		CompanyName:    "Acme",
+		Title:          fmt.Sprintf("Engineer %d-%d", page, i),
+		ApplicationURL: fmt.Sprintf("https://acme.example/jobs/%d-%d", page, i),
+	}
+}
+
+func expectUpsert(mock sqlmock.Sqlmock, now time.Time, job *model.ScrapedJob) {
+	mock.ExpectQuery("FROM companies c").WillReturnRows(sqlmock.NewRows([]string{
+		"id", "website_domain", "industry", "priority",
+	}).AddRow(uuid.New().String(), "acme.example", "Software", 0))
+	mock.ExpectQuery("INSERT INTO jobs").WillReturnRows(sqlmock.NewRows([]string{
+		"id", "dedup_hash", "source", "external_id", "company_id", "company_name", "title",
+		"description", "location_city", "location_state", "location_country",
+		"location_raw", "location_type", "employment_type", "experience_level",
+		"required_skills", "preferred_skills", "nice_to_have_skills", "salary_min", "salary_max",
+		"salary_currency", "salary_raw", "salary_period", "application_url", "company_url",
+		"posted_at", "expires_at", "scraped_at", "last_seen_at", "status",
+		"is_featured", "created_at", "updated_at", "is_new",
+	}).AddRow(uuid.New().String(), storage.ComputeDedupHash(job), "other", nil, nil, "Acme", "Engineer",
+		nil, nil, nil, nil,
+		nil, "remote", "full_time", "mid",
+		"{}", "{}", "{}", nil, nil,
+		"USD", nil, "", "https://acme.example/jobs", nil,
+		nil, nil, now, now, "active",
+		false, now, now, true))
+}
+
+func TestShutdown_StopsScraperBetweenPages(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock: %v", err)
+	}
+	defer db.Close()
+
+	const perPage = 3
+	now := time.Now()
+	runID := uuid.New()
+	mock.ExpectQuery("INSERT INTO scrape_runs").WillReturnRows(sqlmock.NewRows([]string{
+		"id", "source", "scraper_name", "search_query", "search_location", "status",
+		"jobs_found", "jobs_new", "jobs_updated", "jobs_failed", "pages_scraped",
+		"error_message", "started_at", "completed_at", "duration_ms", "created_at",
+	}).AddRow(runID.String(), "other", "paged", "go", "", "running",
+		0, 0, 0, 0, 0, "", now, nil, nil, now))
+	// Both the first page and the page in progress at cancellation are
+	// stored in full; no stale-job expiry follows an interrupted run.
+	for page := 0; page < 2; page++ {
+		for i := 0; i < perPage; i++ {
+			expectUpsert(mock, now, pagedJob(page, i))
+		}
+	}
+	mock.ExpectExec("UPDATE scrape_runs").
+		WithArgs(runID, "interrupted", 2*perPage, 2*perPage, 0, 0, 0, sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	cfg := DefaultConfig()
+	cfg.WorkerCount = 1
+	cfg.DedupThreshold = 0
+	cfg.UpsertBatchSize = 1
+	cfg.DefaultQueries = []SearchQuery{{Query: "go"}}
+	s := New(storage.NewPostgresRepository(db), nil, cfg, log.New(io.Discard, "", 0))
+	sc := &pagedScraper{pages: 5, perPage: perPage, midPage: make(chan struct{})}
+	s.AddScraper(sc)
+
+	s.RunNow(context.Background())
+	select {
+	case <-sc.midPage:
+	case <-time.After(5 * time.Second):
+		t.Fatal("scraper never reached the second page")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := s.Shutdown(ctx); err != nil {
+		t.Fatalf("Shutdown: %v", err)
+	}
+	if got := sc.pagesStarted.Load(); got != 2 {
+		t.Errorf("expected the scraper to stop after 2 pages, started %d", got)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+
+	// Runs requested after shutdown are ignored.
+	s.RunNow(context.Background())
+	if err := s.Shutdown(context.Background()); err != nil {
+		t.Errorf("second Shutdown: %v", err)
+	}
+}
+
+// recordingNotifier records the jobs passed to NotifyNewJobs.
+type recordingNotifier struct {
+	jobs []model.Job
+}
+
+func (n *recordingNotifier) NotifyNewJobs(_ context.Context, jobs []model.Job) error {
+	n.jobs = append(n.jobs, jobs...)
+	return nil
+}
+
+// countingAnalyzer counts its runs.
+type countingAnalyzer struct {
+	runs int
+}
+
+func (a *countingAnalyzer) Run(context.Context) (int, error) {
+	a.runs++
+	return 0, nil
+}
+
+func TestRunScraperQuery_NotifiesNewJobs(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock: %v", err)
+	}
+	defer db.Close()
+
+	now := time.Now()
+	mock.ExpectQuery("INSERT INTO scrape_runs").WillReturnRows(sqlmock.NewRows([]string{
+		"id", "source", "scraper_name", "search_query", "search_location", "status",
+		"jobs_found", "jobs_new", "jobs_updated", "jobs_failed", "pages_scraped",
+		"error_message", "started_at", "completed_at", "duration_ms", "created_at",
+	}).AddRow(uuid.New().String(), "other", "paged", "go", "", "running",
+		0, 0, 0, 0, 0, "", now, nil, nil, now))
+	expectUpsert(mock, now, pagedJob(0, 0))
+	expectUpsert(mock, now, pagedJob(0, 1))
+	mock.ExpectExec("UPDATE scrape_runs").WillReturnResult(sqlmock.NewResult(0, 1))
+
+	cfg := DefaultConfig()
+	cfg.WorkerCount = 1
+	cfg.DedupThreshold = 0
+	cfg.UpsertBatchSize = 1
+	s := New(storage.NewPostgresRepository(db), nil, cfg, log.New(io.Discard, "", 0))
+	notifier := &recordingNotifier{}
+	s.SetNotifier(notifier)
+	analyzer := &countingAnalyzer{}
+	s.SetAnalyzer(analyzer)
+	s.runScraperQuery(context.Background(), &pagedScraper{pages: 1, perPage: 2}, model.SearchParams{Query: "go"})
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unmet expectations: %v", err)
+	}
+	if len(notifier.jobs) != 2 {
+		t.Errorf("expected 2 new jobs to be notified, got %d", len(notifier.jobs))
+	}
+	if analyzer.runs != 1 {
+		t.Errorf("expected the analyzer to run once after the run, got %d", analyzer.runs)
+	}
+}
+
+func TestProcessJobs_RedactsDescriptions(t *testing.T) {
+	repo := newHealthTestRepository(t)
+	cfg := DefaultConfig()
+	cfg.Redactor = redact.Default()
+	s := New(repo, nil, cfg, log.New(io.Discard, "", 0))
+
+	job := pagingJob(1)
+	job.Description = "Salary: $120,000 - $150,000 per year. Questions? Email jane.doe@acme.com or call +1 (555) 123-4567."
+	job.DescriptionHTML = `<p>Email <a href="mailto:jane.doe@acme.com">jane.doe@acme.com</a></p>`
+	jobs := make(chan *model.ScrapedJob, 1)
+	jobs <- job
+	close(jobs)
+	var stats scrapeStats
+	s.processJobs(context.Background(), "Test Scraper", jobs, &stats)
+
+	if stats.newJobs != 1 {
+		t.Fatalf("expected one new job, got %d (%d failed)", stats.newJobs, stats.failed)
+	}
+	stored := stats.created[0]
+	if want := "Salary: $120,000 - $150,000 per year. Questions? Email [email] or call [phone]."; stored.Description.String != want {
+		t.Errorf("Description = %q, want %q", stored.Description.String, want)
+	}
+	if want := `<p>Email <a href="mailto:[email]">[email]</a></p>`; stored.DescriptionHTML.String != want {
+		t.Errorf("DescriptionHTML = %q, want %q", stored.DescriptionHTML.String, want)
+	}
+	// The salary is extracted from the description before it is redacted.
+	if !stored.SalaryMin.Valid || stored.SalaryMin.Int32 != 120000 {
+		t.Errorf("SalaryMin = %+v, want 120000", stored.SalaryMin)
+	}
+}
+
+func TestProcessJobs_NormalizesLocations(t *testing.T) {
+	repo := newHealthTestRepository(t)
+	s := New(repo, nil, DefaultConfig(), log.New(io.Discard, "", 0))
+	ctx := context.Background()
+
+	// A cached parse is used as stored, without parsing the text again.
+	if err := repo.PutCachedLocation(ctx, "HQ", geo.Location{City: "Bandung", Country: "ID"}); err != nil {
+		t.Fatalf("PutCachedLocation: %v", err)
+	}
+
+	locations := []string{"SF Bay Area", "Remote - EMEA", "hq", "Somewhere Unknown"}
+	jobs := make(chan *model.ScrapedJob, len(locations))
+	for i, raw := range locations {
+		job := pagingJob(i + 1)
+		job.LocationRaw, job.LocationCity = raw, raw
+		jobs <- job
+	}
+	close(jobs)
+	var stats scrapeStats
+	s.processJobs(ctx, "Test Scraper", jobs, &stats)
+	if stats.newJobs != len(locations) {
+		t.Fatalf("expected %d new jobs, got %d (%d failed)", len(locations), stats.newJobs, stats.failed)
+	}
+
+	want := map[string][4]string{
+		"SF Bay Area":       {"San Francisco", "CA", "US", string(model.LocationOnSite)},
+		"Remote - EMEA":     {"", "EMEA", "", string(model.LocationRemote)},
+		"hq":                {"Bandung", "", "ID", string(model.LocationOnSite)},
+		"Somewhere Unknown": {"Somewhere Unknown", "", "", string(model.LocationUnknown)},
+	}
+	for _, job := range stats.created {
+		got := [4]string{job.LocationCity.String, job.LocationState.String, job.LocationCountry.String, string(job.LocationType)}
+		if w := want[job.LocationRaw.String]; got != w {
+			t.Errorf("location %q stored as %v, want %v", job.LocationRaw.String, got, w)
+		}
+	}
+
+	// Parsed texts are cached, unknown ones included.
+	for _, raw := range []string{"sf bay area", "Somewhere Unknown"} {
+		if _, ok, err := repo.GetCachedLocation(ctx, raw); err != nil || !ok {
+			t.Errorf("location %q not cached: %v", raw, err)
+		}
+	}
+}
@@ -0,0 +1,131 @@
+package scheduler
+
+import (
+	"context"
+	"time"
+
+	"github.com/learnbot/job-aggregator/internal/model"
+)
+
+// DefaultExpirySchedule is the default schedule of the stale job expiry
+// pass: daily at 4am UTC, after the default scrape run.
+const DefaultExpirySchedule = "0 4 * * *"
+
+// staleDuration returns how long a job last seen by the named scraper may
+// go unseen before it is expired.
+func (s *Scheduler) staleDuration(name string) time.Duration {
+	if d, ok := s.config.StaleDurations[name]; ok {
+		return d
+	}
+	return s.config.JobStaleDuration
+}
+
+// ExpireStaleJobs expires the active jobs that the scraper which last saw
+// them has not seen for its stale duration, and records the pass. Jobs of
+// no registered scraper are expired after Config.JobStaleDuration. The
+// jobs of disabled scrapers are left alone, as they are not being scraped,
+// and so are those of scrapers with a stale duration of zero or less.
+// A job that is scraped again after it expired becomes active again.
+//
+// An error expiring one scraper's jobs does not stop the others; the first
+// is returned and recorded with the run.
+func (s *Scheduler) ExpireStaleJobs(ctx context.Context) (*model.ExpiryRun, error) {
+	now := s.now()
+	run := &model.ExpiryRun{StartedAt: now, Scrapers: []model.ScraperExpiry{}}
+	var firstErr error
+
+	expire := func(name string, staleAfter time.Duration, mark func(cutoff time.Time) (int64, error)) {
+		if staleAfter <= 0 {
+			return
+		}
+		e := model.ScraperExpiry{Scraper: name, StaleAfter: staleAfter.String(), Cutoff: now.Add(-staleAfter)}
+		n, err := mark(e.Cutoff)
+		if err != nil {
+			s.logger.Printf("[scheduler] failed to expire stale jobs of %q: %v", name, err)
+			if firstErr == nil {
+				firstErr = err
+			}
+			return
+		}
+		e.JobsExpired = int(n)
+		run.JobsExpired += e.JobsExpired
+		run.Scrapers = append(run.Scrapers, e)
+	}
+
+	var names []string
+	seen := map[string]bool{}
+	for _, sc := range s.Scrapers() {
+		name := sc.Name()
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+		names = append(names, name)
+
+		s.mu.Lock()
+		disabled := s.disabled[name]
+		s.mu.Unlock()
+		if disabled {
+			continue
+		}
+		expire(name, s.staleDuration(name), func(cutoff time.Time) (int64, error) {
+			return s.repo.MarkStaleJobs(ctx, name, cutoff)
+		})
+	}
+	expire("", s.config.JobStaleDuration, func(cutoff time.Time) (int64, error) {
+		return s.repo.MarkOtherStaleJobs(ctx, names, cutoff)
+	})
+
+	run.CompletedAt = s.now()
+	if firstErr != nil {
+		run.Error = firstErr.Error()
+	}
+	if err := s.repo.CreateExpiryRun(context.WithoutCancel(ctx), run); err != nil {
+		s.logger.Printf("[scheduler] failed to record expiry run: %v", err)
+		if firstErr == nil {
+			firstErr = err
+		}
+	}
+	s.logger.Printf("[scheduler] expired %d stale jobs", run.JobsExpired)
+	return run, firstErr
+}
+
+// StartExpiry starts a background goroutine that runs ExpireStaleJobs on
+// Config.ExpirySchedule (by default daily at 4am UTC). The pass is skipped
+// while the schedule is paused, since no jobs are being seen.
+func (s *Scheduler) StartExpiry(ctx context.Context) {
+	spec := s.config.ExpirySchedule
+	if spec == "" {
+		spec = DefaultExpirySchedule
+	}
+	schedule, err := ParseSchedule(spec)
+	if err != nil {
+		s.logger.Printf("[scheduler] invalid expiry schedule: %v; using %q", err, DefaultExpirySchedule)
+		schedule, _ = ParseSchedule(DefaultExpirySchedule)
+	}
+
+	go func() {
+		for {
+			next := schedule.Next(s.now())
+			if next.IsZero() {
+				return
+			}
+			timer := time.NewTimer(next.Sub(s.now()))
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return
+			case <-timer.C:
+			}
+
+			s.mu.Lock()
+			paused := s.paused
+			s.mu.Unlock()
+			if paused {
+				s.logger.Println("[scheduler] schedule is paused, skipping stale job expiry")
+				continue
+			}
+			s.ExpireStaleJobs(ctx) //nolint:errcheck // logged
+		}
+	}()
+}
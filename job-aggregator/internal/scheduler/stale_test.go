@@ -0,0 +1,100 @@
+package scheduler
+
+import (
+	"context"
+	"database/sql"
+	"io"
+	"log"
+	"testing"
+	"time"
+
+	"github.com/learnbot/job-aggregator/internal/model"
+	"github.com/learnbot/job-aggregator/internal/scraper"
+	"github.com/learnbot/job-aggregator/internal/storage"
+)
+
+func TestExpireStaleJobs_PerScraperDurations(t *testing.T) {
+	db, err := sql.Open(storage.SQLiteDriver, storage.SQLiteDSN(":memory:"))
+	if err != nil {
+		t.Fatalf("open sqlite: %v", err)
+	}
+	defer db.Close()
+	repo := storage.NewSQLiteRepository(db)
+	if err := repo.Migrate(context.Background(), log.New(io.Discard, "", 0)); err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+
+	cfg := DefaultConfig()
+	cfg.StaleDurations = map[string]time.Duration{"Career Page: Acme": 3 * 24 * time.Hour}
+	scrapers := []scraper.Scraper{&namedScraper{"Career Page: Acme"}, &namedScraper{"LinkedIn Jobs"}, &namedScraper{"Indeed"}}
+	s := New(repo, scrapers, cfg, log.New(io.Discard, "", 0))
+	now := time.Date(2025, 3, 10, 4, 0, 0, 0, time.UTC)
+	s.now = func() time.Time { return now }
+	s.disabled["Indeed"] = true
+
+	day := 24 * time.Hour
+	jobs := map[string]struct {
+		scraper string
+		unseen  time.Duration
+		expired bool
+	}{
+		"career page, past its 3 days":  {"Career Page: Acme", 3*day + time.Second, true},
+		"career page, exactly 3 days":   {"Career Page: Acme", 3 * day, false},
+		"linkedin, within the default":  {"LinkedIn Jobs", 4 * day, false},
+		"linkedin, past the default":    {"LinkedIn Jobs", 7*day + time.Second, true},
+		"no scraper, past the default":  {"", 8 * day, true},
+		"removed scraper, past default": {"Lever: Gone", 8 * day, true},
+		"disabled scraper, long unseen": {"Indeed", 30 * day, false},
+	}
+	ids := map[string]*model.Job{}
+	for title, j := range jobs {
+		scraped := pagingJob(len(ids))
+		scraped.CompanyName, scraped.Title, scraped.ScraperName = title, title, j.scraper
+		job, _, err := repo.UpsertJob(context.Background(), scraped)
+		if err != nil {
+			t.Fatalf("UpsertJob: %v", err)
+		}
+		if _, err := db.Exec(`UPDATE jobs SET last_seen_at = $1 WHERE id = $2`, now.Add(-j.unseen), job.ID); err != nil {
+			t.Fatalf("set last_seen_at: %v", err)
+		}
+		ids[title] = job
+	}
+
+	run, err := s.ExpireStaleJobs(context.Background())
+	if err != nil {
+		t.Fatalf("ExpireStaleJobs: %v", err)
+	}
+	for title, j := range jobs {
+		got, err := repo.GetJobByID(context.Background(), ids[title].ID)
+		if err != nil {
+			t.Fatalf("GetJobByID: %v", err)
+		}
+		if expired := got.Status == model.StatusExpired; expired != j.expired {
+			t.Errorf("%s: status %s, want expired=%v", title, got.Status, j.expired)
+		}
+	}
+
+	if run.JobsExpired != 4 || run.Error != "" {
+		t.Errorf("unexpected run %+v", run)
+	}
+	want := map[string]int{"Career Page: Acme": 1, "LinkedIn Jobs": 1, "": 2}
+	if len(run.Scrapers) != len(want) {
+		t.Fatalf("unexpected scrapers %+v", run.Scrapers)
+	}
+	for _, e := range run.Scrapers {
+		if e.JobsExpired != want[e.Scraper] {
+			t.Errorf("%q expired %d jobs, want %d", e.Scraper, e.JobsExpired, want[e.Scraper])
+		}
+	}
+	if e := run.Scrapers[0]; e.StaleAfter != "72h0m0s" || !e.Cutoff.Equal(now.Add(-3*day)) {
+		t.Errorf("unexpected career page expiry %+v", e)
+	}
+
+	runs, err := repo.ListExpiryRuns(context.Background(), 10)
+	if err != nil {
+		t.Fatalf("ListExpiryRuns: %v", err)
+	}
+	if len(runs) != 1 || runs[0].ID != run.ID || runs[0].JobsExpired != 4 || len(runs[0].Scrapers) != 3 {
+		t.Errorf("unexpected recorded runs %+v", runs)
+	}
+}
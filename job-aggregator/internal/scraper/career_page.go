@@ -32,6 +32,32 @@ type CareerPageSelectors struct {
 	// JSON API mode (if the career page uses a JSON API)
 	APIEndpoint  string `json:"api_endpoint,omitempty"`
 	APIJobsField string `json:"api_jobs_field,omitempty"`
+
+	// Fallbacks for pages that render their job list client-side, tried in
+	// this order when the selectors find no jobs.
+	//
+	// JSONLD reads schema.org JobPosting data from the page's
+	// <script type="application/ld+json"> blocks.
+	JSONLD bool `json:"json_ld,omitempty"`
+	// Sitemap scrapes the pages listed in the site's sitemap whose path
+	// matches JobPathPattern (a regular expression), one job per page.
+	Sitemap        bool   `json:"sitemap,omitempty"`
+	SitemapURL     string `json:"sitemap_url,omitempty"` // default: /sitemap.xml on the career page host
+	JobPathPattern string `json:"job_path_pattern,omitempty"`
+	MaxDetailPages int    `json:"max_detail_pages,omitempty"` // default: 50
+	// Detail holds the selectors for job detail pages found in the sitemap,
+	// used when a page has no JSON-LD JobPosting.
+	Detail CareerPageDetailSelectors `json:"detail,omitempty"`
+}
+
+// CareerPageDetailSelectors defines selectors for a single job's detail
+// page. The first match of each selector is used.
+type CareerPageDetailSelectors struct {
+	Title          string `json:"title"` // default: h1
+	Location       string `json:"location"`
+	Description    string `json:"description"`
+	EmploymentType string `json:"employment_type"`
+	PostedDate     string `json:"posted_date"`
 }
 
 // CareerPageScraper scrapes job postings from company career pages.
@@ -59,6 +85,71 @@ func NewCareerPageScraper(page model.CompanyCareerPage, logger *log.Logger) (*Ca
 	}, nil
 }
 
+// NewScraperForCareerPage builds the scraper selected by page.SourceType.
+// Greenhouse and Lever pages take their board token (the Greenhouse board or
+// Lever company slug) from selectors.board_token, or from the career page
+// URL when that is not set. Feed pages read the feed at the career page URL,
+// configured by the title_pattern, company_selector, location_selector and
+// max_age_days selectors.
+func NewScraperForCareerPage(page model.CompanyCareerPage, logger *log.Logger) (Scraper, error) {
+	var cfg struct {
+		BoardToken       string `json:"board_token"`
+		TitlePattern     string `json:"title_pattern"`
+		CompanySelector  string `json:"company_selector"`
+		LocationSelector string `json:"location_selector"`
+		MaxAgeDays       int    `json:"max_age_days"`
+	}
+	if page.SourceType != model.CareerPageHTML && len(page.Selectors) > 0 {
+		if err := json.Unmarshal(page.Selectors, &cfg); err != nil {
+			return nil, fmt.Errorf("invalid selectors config: %w", err)
+		}
+	}
+
+	switch page.SourceType {
+	case model.CareerPageGreenhouse:
+		token := cfg.BoardToken
+		if token == "" {
+			token = GreenhouseBoardToken(page.CareerPageURL)
+		}
+		if token == "" {
+			return nil, fmt.Errorf("no Greenhouse board token in %s", page.CareerPageURL)
+		}
+		gs, err := NewGreenhouseScraper(token, logger)
+		if err != nil {
+			return nil, err
+		}
+		gs.companyName = page.CompanyName
+		return gs, nil
+	case model.CareerPageLever:
+		company := cfg.BoardToken
+		if company == "" {
+			company = LeverCompany(page.CareerPageURL)
+		}
+		if company == "" {
+			return nil, fmt.Errorf("no Lever company in %s", page.CareerPageURL)
+		}
+		ls, err := NewLeverScraper(company, logger)
+		if err != nil {
+			return nil, err
+		}
+		ls.companyName = page.CompanyName
+		return ls, nil
+	case model.CareerPageFeed:
+		return NewFeedScraper(FeedConfig{
+			URL:              page.CareerPageURL,
+			CompanyName:      page.CompanyName,
+			TitlePattern:     cfg.TitlePattern,
+			CompanySelector:  cfg.CompanySelector,
+			LocationSelector: cfg.LocationSelector,
+			MaxAge:           time.Duration(cfg.MaxAgeDays) * 24 * time.Hour,
+		}, logger)
+	case model.CareerPageHTML, "":
+		return NewCareerPageScraper(page, logger)
+	default:
+		return nil, fmt.Errorf("unknown career page source type %q", page.SourceType)
+	}
+}
+
 func (s *CareerPageScraper) Source() model.JobSource { return model.SourceCompanyCareerPage }
 func (s *CareerPageScraper) Name() string {
 	return fmt.Sprintf("Career Page: %s", s.page.CompanyName)
@@ -75,7 +166,7 @@ func (s *CareerPageScraper) Scrape(ctx context.Context, params model.SearchParam
 
 	// Check robots.txt
 	if !s.Robots.IsAllowed(ctx, s.page.CareerPageURL) {
-		return fmt.Errorf("robots.txt disallows scraping %s", s.page.CareerPageURL)
+		return httpclient.Permanent(fmt.Errorf("robots.txt disallows scraping %s", s.page.CareerPageURL))
 	}
 
 	// Use JSON API if configured
@@ -84,7 +175,19 @@ func (s *CareerPageScraper) Scrape(ctx context.Context, params model.SearchParam
 	}
 
 	// Otherwise scrape HTML
-	return s.scrapeHTML(ctx, selectors, params, jobs)
+	found, err := s.scrapeHTML(ctx, selectors, params, jobs)
+	if err != nil || found > 0 || !selectors.Sitemap {
+		return err
+	}
+	s.Logger.Printf("[career_page] %s: no jobs on the career page, trying the sitemap", s.page.CompanyName)
+	_, err = s.scrapeSitemap(ctx, selectors, params, jobs)
+	return err
+}
+
+// titleMatches reports whether title contains query, ignoring case. Every
+// title matches an empty query.
+func titleMatches(title, query string) bool {
+	return query == "" || strings.Contains(strings.ToLower(title), strings.ToLower(query))
 }
 
 // scrapeAPI fetches jobs from a JSON API endpoint.
@@ -98,7 +201,7 @@ func (s *CareerPageScraper) scrapeAPI(ctx context.Context, selectors CareerPageS
 
 	var data map[string]interface{}
 	if err := json.Unmarshal([]byte(body), &data); err != nil {
-		return fmt.Errorf("parse API response: %w", err)
+		return httpclient.Permanent(fmt.Errorf("parse API response: %w", err))
 	}
 
 	// Navigate to jobs field
@@ -109,12 +212,12 @@ func (s *CareerPageScraper) scrapeAPI(ctx context.Context, selectors CareerPageS
 
 	jobsData, ok := data[jobsField]
 	if !ok {
-		return fmt.Errorf("jobs field %q not found in API response", jobsField)
+		return httpclient.Permanent(fmt.Errorf("jobs field %q not found in API response", jobsField))
 	}
 
 	jobsList, ok := jobsData.([]interface{})
 	if !ok {
-		return fmt.Errorf("jobs field is not an array")
+		return httpclient.Permanent(fmt.Errorf("jobs field is not an array"))
 	}
 
 	for _, item := range jobsList {
@@ -136,11 +239,7 @@ func (s *CareerPageScraper) scrapeAPI(ctx context.Context, selectors CareerPageS
 			}
 		}
 
-		select {
-		case jobs <- job:
-		case <-ctx.Done():
-			return ctx.Err()
-		}
+		jobs <- job
 	}
 
 	return nil
@@ -204,26 +303,29 @@ func (s *CareerPageScraper) extractAPIJob(data map[string]interface{}) *model.Sc
 	job.LocationType = ExtractLocationType(job.LocationRaw, job.Description)
 	job.EmploymentType = ExtractEmploymentType(job.Title, job.Description)
 	job.ExperienceLevel = ExtractExperienceLevel(job.Title, job.Description)
-	job.RequiredSkills, job.PreferredSkills = ExtractSkillsFromText(job.Description)
+	job.RequiredSkills, job.PreferredSkills, job.NiceToHaveSkills = ExtractSkillsFromText(job.Description)
 
 	return job
 }
 
-// scrapeHTML fetches and parses HTML from the career page.
-func (s *CareerPageScraper) scrapeHTML(ctx context.Context, selectors CareerPageSelectors, params model.SearchParams, jobs chan<- *model.ScrapedJob) error {
+// scrapeHTML fetches and parses HTML from the career page and returns the
+// number of jobs found. When the selectors find no jobs on the first page
+// and selectors.JSONLD is set, the page's JSON-LD postings are used instead.
+func (s *CareerPageScraper) scrapeHTML(ctx context.Context, selectors CareerPageSelectors, params model.SearchParams, jobs chan<- *model.ScrapedJob) (int, error) {
 	currentURL := s.page.CareerPageURL
 	maxPages := 10
+	found := 0
 
 	for page := 0; page < maxPages; page++ {
 		select {
 		case <-ctx.Done():
-			return ctx.Err()
+			return found, ctx.Err()
 		default:
 		}
 
 		body, err := s.Client.GetBody(ctx, currentURL, nil)
 		if err != nil {
-			return fmt.Errorf("fetch page %d: %w", page, err)
+			return found, fmt.Errorf("fetch page %d: %w", page, err)
 		}
 
 		pageJobs, nextURL, err := s.parseCareerPageHTML(body, selectors, currentURL)
@@ -231,18 +333,24 @@ func (s *CareerPageScraper) scrapeHTML(ctx context.Context, selectors CareerPage
 			s.Logger.Printf("[career_page] parse error on page %d: %v", page, err)
 			break
 		}
+		if page == 0 && len(pageJobs) == 0 && selectors.JSONLD {
+			// The job list is rendered client-side; structured data is
+			// often embedded for search engines all the same.
+			if pageJobs, err = parseJSONLDJobs(body, s.page.CompanyName, currentURL); err != nil {
+				s.Logger.Printf("[career_page] JSON-LD parse error: %v", err)
+			}
+			s.Logger.Printf("[career_page] %s: found %d jobs in JSON-LD data", s.page.CompanyName, len(pageJobs))
+			nextURL = ""
+		}
 
 		for _, job := range pageJobs {
-			if params.Query != "" {
-				if !strings.Contains(strings.ToLower(job.Title), strings.ToLower(params.Query)) {
-					continue
-				}
-			}
-			select {
-			case jobs <- job:
-			case <-ctx.Done():
-				return ctx.Err()
+			// Found counts every job on the page, so that the sitemap
+			// fallback does not run when only the query filtered them out.
+			found++
+			if !titleMatches(job.Title, params.Query) {
+				continue
 			}
+			jobs <- job
 		}
 
 		s.Logger.Printf("[career_page] %s page %d: found %d jobs", s.page.CompanyName, page, len(pageJobs))
@@ -255,12 +363,12 @@ func (s *CareerPageScraper) scrapeHTML(ctx context.Context, selectors CareerPage
 		// Polite delay
 		select {
 		case <-ctx.Done():
-			return ctx.Err()
+			return found, ctx.Err()
 		case <-time.After(2 * time.Second):
 		}
 	}
 
-	return nil
+	return found, nil
 }
 
 // parseCareerPageHTML parses HTML using the configured selectors.
@@ -316,7 +424,7 @@ func (s *CareerPageScraper) parseCareerPageHTML(body string, selectors CareerPag
 		job.LocationType = ExtractLocationType(job.LocationRaw, job.Description)
 		job.EmploymentType = ExtractEmploymentType(job.Title, job.Description)
 		job.ExperienceLevel = ExtractExperienceLevel(job.Title, job.Description)
-		job.RequiredSkills, job.PreferredSkills = ExtractSkillsFromText(job.Description)
+		job.RequiredSkills, job.PreferredSkills, job.NiceToHaveSkills = ExtractSkillsFromText(job.Description)
 
 		jobs = append(jobs, job)
 	}
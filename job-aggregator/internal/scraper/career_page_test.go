@@ -0,0 +1,276 @@
+package scraper
+
+import (
+	"encoding/json"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/learnbot/job-aggregator/internal/model"
+)
+
+// jsonLDShellFixture is a client-rendered career page: the job list is
+// empty, but the postings are embedded as JSON-LD.
+const jsonLDShellFixture = `<!DOCTYPE html>
+<html><head>
+<script type="application/ld+json">{"@context": "https://schema.org", "@type": "Organization", "name": "Acme"}</script>
+<script type="application/ld+json">
+{
+  "@context": "https://schema.org",
+  "@graph": [
+    {
+      "@type": "JobPosting",
+      "title": "Senior Go Engineer",
+      "description": "&lt;p&gt;Build services in Go and PostgreSQL.&lt;/p&gt;",
+      "identifier": {"@type": "PropertyValue", "name": "Acme", "value": "ENG-42"},
+      "url": "/careers/jobs/eng-42",
+      "datePosted": "2025-01-10",
+      "validThrough": "2025-03-01T00:00:00Z",
+      "employmentType": ["CONTRACTOR"],
+      "hiringOrganization": {"@type": "Organization", "name": "Acme"},
+      "jobLocation": {"@type": "Place", "address": {"@type": "PostalAddress",
+        "addressLocality": "Berlin", "addressRegion": "BE", "addressCountry": {"name": "Germany"}}},
+      "baseSalary": {"@type": "MonetaryAmount", "currency": "eur",
+        "value": {"@type": "QuantitativeValue", "minValue": 70000, "maxValue": 90000, "unitText": "YEAR"}}
+    },
+    {
+      "@type": ["JobPosting"],
+      "title": "Support Specialist",
+      "jobLocationType": "TELECOMMUTE"
+    },
+    {"@type": "JobPosting", "title": ""}
+  ]
+}
+</script>
+<script type="application/ld+json">{not json</script>
+</head>
+<body><div id="app"></div></body></html>`
+
+// newTestCareerPageScraper returns a scraper for the career page at path
+// on server, configured with selectors.
+func newTestCareerPageScraper(t *testing.T, server *httptest.Server, path string, selectors CareerPageSelectors) *CareerPageScraper {
+	t.Helper()
+	raw, err := json.Marshal(selectors)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s, err := NewCareerPageScraper(model.CompanyCareerPage{
+		CompanyName:   "Acme",
+		CareerPageURL: server.URL + path,
+		Selectors:     raw,
+	}, log.New(io.Discard, "", 0))
+	if err != nil {
+		t.Fatalf("NewCareerPageScraper: %v", err)
+	}
+	return s
+}
+
+func TestCareerPageScraper_JSONLDFallback(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/careers" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(jsonLDShellFixture))
+	}))
+	defer server.Close()
+
+	s := newTestCareerPageScraper(t, server, "/careers", CareerPageSelectors{
+		JobContainer: ".job-listing",
+		Title:        "h2",
+		JSONLD:       true,
+	})
+	jobs, err := collect(t, s, model.SearchParams{})
+	if err != nil {
+		t.Fatalf("Scrape: %v", err)
+	}
+	if len(jobs) != 2 {
+		t.Fatalf("expected 2 jobs, got %d", len(jobs))
+	}
+
+	eng := jobs[0]
+	if eng.Title != "Senior Go Engineer" || eng.CompanyName != "Acme" || eng.ExternalID != "jsonld:ENG-42" {
+		t.Errorf("unexpected identity: %q %q %q", eng.Title, eng.CompanyName, eng.ExternalID)
+	}
+	if want := server.URL + "/careers/jobs/eng-42"; eng.ApplicationURL != want {
+		t.Errorf("ApplicationURL = %q, want %q", eng.ApplicationURL, want)
+	}
+	if eng.Description != "Build services in Go and PostgreSQL." {
+		t.Errorf("Description = %q", eng.Description)
+	}
+	if eng.LocationRaw != "Berlin, BE, Germany" || eng.LocationCity != "Berlin" {
+		t.Errorf("unexpected location: %q city=%q", eng.LocationRaw, eng.LocationCity)
+	}
+	if eng.EmploymentType != model.EmploymentContract {
+		t.Errorf("EmploymentType = %q, want contract", eng.EmploymentType)
+	}
+	if eng.SalaryMin == nil || *eng.SalaryMin != 70000 || eng.SalaryMax == nil || *eng.SalaryMax != 90000 || eng.SalaryCurrency != "EUR" || eng.SalaryPeriod != model.SalaryPerYear {
+		t.Errorf("unexpected salary: %v %v %q %q", eng.SalaryMin, eng.SalaryMax, eng.SalaryCurrency, eng.SalaryPeriod)
+	}
+	if eng.PostedAt == nil || eng.PostedAt.Format("2006-01-02") != "2025-01-10" || eng.ExpiresAt == nil {
+		t.Errorf("unexpected dates: posted=%v expires=%v", eng.PostedAt, eng.ExpiresAt)
+	}
+
+	support := jobs[1]
+	if support.LocationType != model.LocationRemote {
+		t.Errorf("TELECOMMUTE should map to remote, got %q", support.LocationType)
+	}
+	if support.ApplicationURL != server.URL+"/careers" || support.ExternalID != support.ApplicationURL {
+		t.Errorf("posting without url should use the page: %q %q", support.ApplicationURL, support.ExternalID)
+	}
+}
+
+func TestCareerPageScraper_JSONLDDisabled(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(jsonLDShellFixture))
+	}))
+	defer server.Close()
+
+	s := newTestCareerPageScraper(t, server, "/careers", CareerPageSelectors{JobContainer: ".job-listing", Title: "h2"})
+	jobs, err := collect(t, s, model.SearchParams{})
+	if err != nil {
+		t.Fatalf("Scrape: %v", err)
+	}
+	if len(jobs) != 0 {
+		t.Errorf("expected no jobs without the JSON-LD fallback, got %d", len(jobs))
+	}
+}
+
+// newSitemapServer serves an empty career page, a sitemap index with one
+// child sitemap, and two job detail pages: one with JSON-LD and one
+// without.
+func newSitemapServer(t *testing.T) (*httptest.Server, *[]string) {
+	t.Helper()
+	var mu sync.Mutex
+	var requested []string
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		requested = append(requested, r.URL.Path)
+		mu.Unlock()
+
+		switch r.URL.Path {
+		case "/careers":
+			w.Write([]byte(`<html><body><div id="app"></div></body></html>`))
+		case "/sitemap.xml":
+			w.Write([]byte(`<?xml version="1.0" encoding="UTF-8"?>
+<sitemapindex xmlns="http://www.sitemaps.org/schemas/sitemap/0.9">
+  <sitemap><loc>` + server.URL + `/sitemap-pages.xml</loc></sitemap>
+</sitemapindex>`))
+		case "/sitemap-pages.xml":
+			w.Write([]byte(`<?xml version="1.0" encoding="UTF-8"?>
+<urlset xmlns="http://www.sitemaps.org/schemas/sitemap/0.9">
+  <url><loc>` + server.URL + `/about</loc></url>
+  <url><loc>` + server.URL + `/careers/jobs/101</loc></url>
+  <url><loc>` + server.URL + `/careers/jobs/102</loc></url>
+  <url><loc>` + server.URL + `/careers/jobs/102</loc></url>
+  <url><loc>` + server.URL + `/careers/jobs/103</loc></url>
+</urlset>`))
+		case "/careers/jobs/101":
+			w.Write([]byte(`<html><head><script type="application/ld+json">
+{"@type": "JobPosting", "title": "Data Engineer", "description": "Python and SQL pipelines.",
+ "jobLocation": {"address": "Jakarta, Indonesia"}}
+</script></head><body><h1>ignored</h1></body></html>`))
+		case "/careers/jobs/102":
+			w.Write([]byte(`<html><body>
+<h1 class="job-title">Backend Developer</h1>
+<span class="job-location">London, UK</span>
+<div id="job-description">Build APIs with Go and Docker.</div>
+</body></html>`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server, &requested
+}
+
+func TestCareerPageScraper_SitemapFallback(t *testing.T) {
+	server, requested := newSitemapServer(t)
+	s := newTestCareerPageScraper(t, server, "/careers", CareerPageSelectors{
+		JobContainer:   ".job-listing",
+		Title:          "h2",
+		JSONLD:         true,
+		Sitemap:        true,
+		JobPathPattern: `^/careers/jobs/\d+$`,
+		Detail: CareerPageDetailSelectors{
+			Title:       "h1.job-title",
+			Location:    ".job-location",
+			Description: "#job-description",
+		},
+	})
+
+	jobs, err := collect(t, s, model.SearchParams{})
+	if err != nil {
+		t.Fatalf("Scrape: %v", err)
+	}
+	if len(jobs) != 2 {
+		t.Fatalf("expected 2 jobs, got %d", len(jobs))
+	}
+
+	de := jobs[0]
+	if de.Title != "Data Engineer" || de.LocationRaw != "Jakarta, Indonesia" || de.ExternalID != server.URL+"/careers/jobs/101" {
+		t.Errorf("JSON-LD detail page mapped to %q %q %q", de.Title, de.LocationRaw, de.ExternalID)
+	}
+	if de.RawData["extracted_from"] != "json_ld" {
+		t.Errorf("RawData = %v", de.RawData)
+	}
+
+	be := jobs[1]
+	if be.Title != "Backend Developer" || be.LocationCity != "London" || be.Description != "Build APIs with Go and Docker." {
+		t.Errorf("selector detail page mapped to %q %q %q", be.Title, be.LocationCity, be.Description)
+	}
+	if be.ApplicationURL != server.URL+"/careers/jobs/102" || be.RawData["extracted_from"] != "sitemap" {
+		t.Errorf("unexpected URL or RawData: %q %v", be.ApplicationURL, be.RawData)
+	}
+
+	// /about does not match the pattern; 102 is listed twice.
+	var details []string
+	for _, p := range *requested {
+		if strings.HasPrefix(p, "/careers/jobs/") || p == "/about" {
+			details = append(details, p)
+		}
+	}
+	if strings.Join(details, " ") != "/careers/jobs/101 /careers/jobs/102 /careers/jobs/103" {
+		t.Errorf("unexpected detail page requests: %v", details)
+	}
+}
+
+func TestCareerPageScraper_SitemapLimitsAndQuery(t *testing.T) {
+	server, _ := newSitemapServer(t)
+	s := newTestCareerPageScraper(t, server, "/careers", CareerPageSelectors{
+		JobContainer:   ".job-listing",
+		Sitemap:        true,
+		SitemapURL:     server.URL + "/sitemap-pages.xml",
+		JobPathPattern: `^/careers/jobs/`,
+		MaxDetailPages: 1,
+	})
+
+	jobs, err := collect(t, s, model.SearchParams{Query: "data"})
+	if err != nil {
+		t.Fatalf("Scrape: %v", err)
+	}
+	if len(jobs) != 1 || jobs[0].Title != "Data Engineer" {
+		t.Fatalf("expected only the first detail page, got %v", jobs)
+	}
+
+	jobs, err = collect(t, s, model.SearchParams{Query: "backend"})
+	if err != nil {
+		t.Fatalf("Scrape: %v", err)
+	}
+	if len(jobs) != 0 {
+		t.Errorf("expected the query to filter out the only scraped page, got %d jobs", len(jobs))
+	}
+}
+
+func TestCareerPageScraper_SitemapNeedsPattern(t *testing.T) {
+	server, _ := newSitemapServer(t)
+	s := newTestCareerPageScraper(t, server, "/careers", CareerPageSelectors{JobContainer: ".job-listing", Sitemap: true})
+	if _, err := collect(t, s, model.SearchParams{}); err == nil || !strings.Contains(err.Error(), "job_path_pattern") {
+		t.Errorf("expected a job_path_pattern error, got %v", err)
+	}
+}
@@ -0,0 +1,381 @@
+package scraper
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/learnbot/job-aggregator/internal/httpclient"
+	"github.com/learnbot/job-aggregator/internal/model"
+	"golang.org/x/net/html"
+)
+
+// defaultFeedMaxAge is used when FeedConfig.MaxAge is not set.
+const defaultFeedMaxAge = 30 * 24 * time.Hour
+
+// FeedConfig configures a FeedScraper.
+type FeedConfig struct {
+	// URL is the RSS 2.0 or Atom feed URL.
+	URL string
+	// CompanyName is used for items whose company cannot be extracted.
+	CompanyName string
+	// TitlePattern is an optional regular expression applied to each item
+	// title. The named groups "company", "location" and "title" replace the
+	// corresponding fields, e.g. `^(?P<company>[^:]+):\s*(?P<title>.+)$`.
+	TitlePattern string
+	// CompanySelector and LocationSelector are optional CSS-like selectors
+	// (see findBySelector) applied to the item's HTML description. They are
+	// used for fields the title pattern did not provide.
+	CompanySelector  string
+	LocationSelector string
+	// MaxAge skips items published longer ago than this. Items without a
+	// date are kept. Defaults to 30 days.
+	MaxAge time.Duration
+}
+
+// FeedScraper reads job postings from an RSS 2.0 or Atom feed, for job
+// boards that publish a feed but have no API or stable HTML structure.
+type FeedScraper struct {
+	*BaseScraper
+	cfg          FeedConfig
+	host         string
+	titlePattern *regexp.Regexp
+	now          func() time.Time
+}
+
+// feedDocument holds the elements of both feed formats: RSS 2.0 items under
+// <rss><channel>, and Atom entries directly under <feed>.
+type feedDocument struct {
+	XMLName xml.Name
+	Channel struct {
+		Items []rssItem `xml:"item"`
+	} `xml:"channel"`
+	Entries []atomEntry `xml:"entry"`
+}
+
+type rssItem struct {
+	Title       string `xml:"title"`
+	Link        string `xml:"link"`
+	GUID        string `xml:"guid"`
+	PubDate     string `xml:"pubDate"`
+	Description string `xml:"description"`
+	// content:encoded carries the full HTML body in many feeds.
+	Content string `xml:"http://purl.org/rss/1.0/modules/content/ encoded"`
+}
+
+type atomEntry struct {
+	ID    string `xml:"id"`
+	Title string `xml:"title"`
+	Links []struct {
+		Href string `xml:"href,attr"`
+		Rel  string `xml:"rel,attr"`
+	} `xml:"link"`
+	Published string `xml:"published"`
+	Updated   string `xml:"updated"`
+	Summary   string `xml:"summary"`
+	Content   string `xml:"content"`
+}
+
+// feedItem is an RSS item or Atom entry in a common form.
+type feedItem struct {
+	GUID        string
+	Title       string
+	Link        string
+	Published   string
+	Description string
+}
+
+// NewFeedScraper creates a scraper for the feed described by cfg.
+func NewFeedScraper(cfg FeedConfig, logger *log.Logger) (*FeedScraper, error) {
+	cfg.URL = strings.TrimSpace(cfg.URL)
+	u, err := url.Parse(cfg.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, fmt.Errorf("feed URL must be an absolute http(s) URL")
+	}
+
+	var titlePattern *regexp.Regexp
+	if cfg.TitlePattern != "" {
+		titlePattern, err = regexp.Compile(cfg.TitlePattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid title pattern: %w", err)
+		}
+	}
+	if cfg.MaxAge <= 0 {
+		cfg.MaxAge = defaultFeedMaxAge
+	}
+
+	hcfg := httpclient.DefaultConfig()
+	hcfg.RequestsPerMinute = 10
+	hcfg.MaxRetries = 3
+	hcfg.RetryDelay = 5 * time.Second
+
+	base, err := NewBaseScraper(hcfg, logger)
+	if err != nil {
+		return nil, err
+	}
+
+	return &FeedScraper{
+		BaseScraper:  base,
+		cfg:          cfg,
+		host:         strings.ToLower(u.Host),
+		titlePattern: titlePattern,
+		now:          time.Now,
+	}, nil
+}
+
+func (s *FeedScraper) Source() model.JobSource { return model.SourceOther }
+func (s *FeedScraper) Name() string {
+	if s.cfg.CompanyName != "" {
+		return fmt.Sprintf("Feed: %s", s.cfg.CompanyName)
+	}
+	return fmt.Sprintf("Feed: %s", s.host)
+}
+
+// Scrape fetches the feed and sends one job per item. Items are identified
+// by GUID (the link when there is none), so repeated runs upsert the same
+// rows and duplicate items within the feed are sent once.
+func (s *FeedScraper) Scrape(ctx context.Context, params model.SearchParams, jobs chan<- *model.ScrapedJob) error {
+	s.Logger.Printf("[feed] scraping %s", s.cfg.URL)
+
+	if !s.Robots.IsAllowed(ctx, s.cfg.URL) {
+		return fmt.Errorf("robots.txt disallows scraping %s", s.cfg.URL)
+	}
+
+	items, err := s.fetchItems(ctx)
+	if err != nil {
+		return err
+	}
+
+	cutoff := s.now().Add(-s.cfg.MaxAge)
+	seen := map[string]bool{}
+	found, skipped := 0, 0
+	for _, item := range items {
+		job := s.toScrapedJob(item)
+		if job == nil || seen[job.ExternalID] {
+			continue
+		}
+		seen[job.ExternalID] = true
+
+		if job.PostedAt != nil && job.PostedAt.Before(cutoff) {
+			skipped++
+			continue
+		}
+		if params.Query != "" {
+			if !strings.Contains(strings.ToLower(job.Title), strings.ToLower(params.Query)) &&
+				!strings.Contains(strings.ToLower(job.Description), strings.ToLower(params.Query)) {
+				continue
+			}
+		}
+
+		found++
+		jobs <- job
+	}
+
+	s.Logger.Printf("[feed] %s: found %d jobs, skipped %d older than %s", s.host, found, skipped, s.cfg.MaxAge)
+	return nil
+}
+
+// fetchItems downloads and parses the feed.
+func (s *FeedScraper) fetchItems(ctx context.Context) ([]feedItem, error) {
+	// See GreenhouseScraper.fetchJobs for why compression is disabled.
+	resp, err := s.Client.Get(ctx, s.cfg.URL, map[string]string{
+		"Accept":          "application/rss+xml, application/atom+xml, application/xml;q=0.9, */*;q=0.8",
+		"Accept-Encoding": "identity",
+	})
+	if err != nil {
+		return nil, fmt.Errorf("fetch feed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, httpclient.Permanent(fmt.Errorf("fetch feed: unexpected status %d", resp.StatusCode))
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("read response body: %w", err)
+	}
+	items, err := parseFeed(body)
+	return items, httpclient.Permanent(err)
+}
+
+// parseFeed parses an RSS 2.0 or Atom document.
+func parseFeed(body []byte) ([]feedItem, error) {
+	dec := xml.NewDecoder(bytes.NewReader(body))
+	dec.Strict = false
+	dec.Entity = xml.HTMLEntity
+	// Feeds declaring a legacy single-byte encoding are almost always ASCII
+	// in practice; decode them as-is rather than rejecting the feed.
+	dec.CharsetReader = func(charset string, input io.Reader) (io.Reader, error) {
+		return input, nil
+	}
+
+	var doc feedDocument
+	if err := dec.Decode(&doc); err != nil {
+		return nil, fmt.Errorf("parse feed: %w", err)
+	}
+
+	var items []feedItem
+	switch strings.ToLower(doc.XMLName.Local) {
+	case "rss":
+		for _, it := range doc.Channel.Items {
+			desc := it.Content
+			if strings.TrimSpace(desc) == "" {
+				desc = it.Description
+			}
+			items = append(items, feedItem{
+				GUID:        strings.TrimSpace(it.GUID),
+				Title:       it.Title,
+				Link:        strings.TrimSpace(it.Link),
+				Published:   it.PubDate,
+				Description: desc,
+			})
+		}
+	case "feed":
+		for _, e := range doc.Entries {
+			item := feedItem{
+				GUID:        strings.TrimSpace(e.ID),
+				Title:       e.Title,
+				Published:   e.Published,
+				Description: e.Content,
+			}
+			if item.Published == "" {
+				item.Published = e.Updated
+			}
+			if strings.TrimSpace(item.Description) == "" {
+				item.Description = e.Summary
+			}
+			for _, l := range e.Links {
+				if l.Rel == "" || l.Rel == "alternate" {
+					item.Link = strings.TrimSpace(l.Href)
+					break
+				}
+			}
+			items = append(items, item)
+		}
+	default:
+		return nil, fmt.Errorf("parse feed: unsupported root element <%s>", doc.XMLName.Local)
+	}
+	return items, nil
+}
+
+// feedDateLayouts are the date formats seen in RSS pubDate and Atom
+// published/updated elements.
+var feedDateLayouts = []string{
+	time.RFC1123Z,
+	time.RFC1123,
+	time.RFC3339,
+	"Mon, 2 Jan 2006 15:04:05 -0700",
+	"Mon, 2 Jan 2006 15:04:05 MST",
+	"2 Jan 2006 15:04:05 -0700",
+	"2006-01-02T15:04:05",
+	"2006-01-02",
+}
+
+// parseFeedDate parses a feed timestamp, returning nil if no layout matches.
+func parseFeedDate(s string) *time.Time {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return nil
+	}
+	for _, layout := range feedDateLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			t = t.UTC()
+			return &t
+		}
+	}
+	return nil
+}
+
+// toScrapedJob maps a feed item to a ScrapedJob.
+func (s *FeedScraper) toScrapedJob(item feedItem) *model.ScrapedJob {
+	title := CleanText(html.UnescapeString(item.Title))
+	guid := item.GUID
+	if guid == "" {
+		guid = item.Link
+	}
+	if title == "" || guid == "" {
+		return nil
+	}
+
+	job := &model.ScrapedJob{
+		Source:         model.SourceOther,
+		ExternalID:     fmt.Sprintf("feed:%s:%s", s.host, guid),
+		Title:          title,
+		ApplicationURL: s.cfg.URL,
+		SalaryCurrency: "USD",
+		PostedAt:       parseFeedDate(item.Published),
+	}
+	if item.Link != "" {
+		job.ApplicationURL = resolveURL(s.cfg.URL, item.Link)
+	}
+
+	var company, location string
+	if s.titlePattern != nil {
+		if m := s.titlePattern.FindStringSubmatch(title); m != nil {
+			for i, name := range s.titlePattern.SubexpNames() {
+				v := strings.TrimSpace(m[i])
+				if v == "" {
+					continue
+				}
+				switch name {
+				case "company":
+					company = v
+				case "location":
+					location = v
+				case "title":
+					job.Title = v
+				}
+			}
+		}
+	}
+
+	if desc := strings.TrimSpace(item.Description); desc != "" {
+		job.DescriptionHTML = desc
+		job.Description = htmlToText(desc)
+
+		if (company == "" && s.cfg.CompanySelector != "") || (location == "" && s.cfg.LocationSelector != "") {
+			if doc, err := html.Parse(strings.NewReader(desc)); err == nil {
+				if nodes := findBySelector(doc, s.cfg.CompanySelector); company == "" && len(nodes) > 0 {
+					company = CleanText(extractText(nodes[0]))
+				}
+				if nodes := findBySelector(doc, s.cfg.LocationSelector); location == "" && len(nodes) > 0 {
+					location = CleanText(extractText(nodes[0]))
+				}
+			}
+		}
+	}
+
+	switch {
+	case company != "":
+		job.CompanyName = company
+	case s.cfg.CompanyName != "":
+		job.CompanyName = s.cfg.CompanyName
+	default:
+		job.CompanyName = s.host
+	}
+	if location != "" {
+		job.LocationRaw = location
+		parseLocation(location, job)
+	}
+
+	job.LocationType = ExtractLocationType(job.LocationRaw, job.Description)
+	job.EmploymentType = ExtractEmploymentType(job.Title, job.Description)
+	job.ExperienceLevel = ExtractExperienceLevel(job.Title, job.Description)
+	job.RequiredSkills, job.PreferredSkills, job.NiceToHaveSkills = ExtractSkillsFromText(job.Description)
+
+	job.RawData = map[string]interface{}{
+		"feed_url":  s.cfg.URL,
+		"feed_guid": guid,
+	}
+
+	return job
+}
@@ -0,0 +1,217 @@
+package scraper
+
+import (
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/learnbot/job-aggregator/internal/model"
+)
+
+const rssFixture = `<?xml version="1.0" encoding="UTF-8"?>
+<rss version="2.0" xmlns:content="http://purl.org/rss/1.0/modules/content/">
+  <channel>
+    <title>Remote Programming Jobs</title>
+    <item>
+      <title>Acme Corp: Senior Go Engineer</title>
+      <link>https://boards.example.com/jobs/1</link>
+      <guid>https://boards.example.com/jobs/1</guid>
+      <pubDate>Tue, 07 Jan 2025 10:00:00 +0000</pubDate>
+      <description>&lt;p&gt;&lt;strong&gt;Headquarters:&lt;/strong&gt; &lt;span class="region"&gt;Anywhere in the World&lt;/span&gt;&lt;/p&gt;&lt;p&gt;Build services in Go and PostgreSQL.&lt;/p&gt;</description>
+    </item>
+    <item>
+      <title>Acme Corp: Senior Go Engineer</title>
+      <link>https://boards.example.com/jobs/1</link>
+      <guid>https://boards.example.com/jobs/1</guid>
+      <pubDate>Tue, 07 Jan 2025 10:00:00 +0000</pubDate>
+    </item>
+    <item>
+      <title>Globex: Data Analyst</title>
+      <link>/jobs/2</link>
+      <guid isPermaLink="false">job-2</guid>
+      <pubDate>Mon, 6 Jan 2025 08:30:00 GMT</pubDate>
+      <content:encoded><![CDATA[<p>SQL and Python.</p>]]></content:encoded>
+    </item>
+    <item>
+      <title>Initech: Old Posting</title>
+      <link>https://boards.example.com/jobs/3</link>
+      <guid>https://boards.example.com/jobs/3</guid>
+      <pubDate>Fri, 01 Nov 2024 10:00:00 +0000</pubDate>
+    </item>
+  </channel>
+</rss>`
+
+const atomFixture = `<?xml version="1.0" encoding="utf-8"?>
+<feed xmlns="http://www.w3.org/2005/Atom">
+  <title>Who is hiring</title>
+  <entry>
+    <id>tag:hn.example.com,2025:item-42</id>
+    <title>Platform Engineer</title>
+    <link rel="alternate" href="https://hn.example.com/item?id=42"/>
+    <updated>2025-01-08T09:00:00Z</updated>
+    <content type="html">&lt;p class="company"&gt;Hooli&lt;/p&gt;&lt;p class="location"&gt;Berlin, Germany&lt;/p&gt;&lt;p&gt;Kubernetes and Terraform.&lt;/p&gt;</content>
+  </entry>
+  <entry>
+    <id>tag:hn.example.com,2025:item-43</id>
+    <title>Support Engineer</title>
+    <link href="https://hn.example.com/item?id=43"/>
+    <published>not a date</published>
+    <summary>Help customers.</summary>
+  </entry>
+</feed>`
+
+// newTestFeedScraper serves body as the feed and fixes the clock at
+// 2025-01-10.
+func newTestFeedScraper(t *testing.T, body string, cfg FeedConfig) *FeedScraper {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/robots.txt" {
+			w.Write([]byte("User-agent: *\nAllow: /\n"))
+			return
+		}
+		w.Header().Set("Content-Type", "application/xml")
+		w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+
+	cfg.URL = server.URL + "/feed.xml"
+	s, err := NewFeedScraper(cfg, log.New(io.Discard, "", 0))
+	if err != nil {
+		t.Fatalf("NewFeedScraper: %v", err)
+	}
+	s.now = func() time.Time { return time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC) }
+	return s
+}
+
+func TestFeedScraper_RSS(t *testing.T) {
+	s := newTestFeedScraper(t, rssFixture, FeedConfig{
+		CompanyName:      "Example Board",
+		TitlePattern:     `^(?P<company>[^:]+):\s*(?P<title>.+)$`,
+		LocationSelector: ".region",
+	})
+
+	jobs, err := collect(t, s, model.SearchParams{})
+	if err != nil {
+		t.Fatalf("Scrape: %v", err)
+	}
+	// The repeated GUID is sent once and the November posting is too old.
+	if len(jobs) != 2 {
+		t.Fatalf("expected 2 jobs, got %d", len(jobs))
+	}
+
+	goJob := jobs[0]
+	if goJob.CompanyName != "Acme Corp" || goJob.Title != "Senior Go Engineer" {
+		t.Errorf("title pattern not applied: %q / %q", goJob.CompanyName, goJob.Title)
+	}
+	if goJob.Source != model.SourceOther || !strings.HasSuffix(goJob.ExternalID, ":https://boards.example.com/jobs/1") {
+		t.Errorf("unexpected identity: %s %q", goJob.Source, goJob.ExternalID)
+	}
+	if goJob.LocationRaw != "Anywhere in the World" || goJob.LocationType != model.LocationRemote {
+		t.Errorf("location selector not applied: %q %s", goJob.LocationRaw, goJob.LocationType)
+	}
+	if goJob.PostedAt == nil || !goJob.PostedAt.Equal(time.Date(2025, 1, 7, 10, 0, 0, 0, time.UTC)) {
+		t.Errorf("unexpected PostedAt %v", goJob.PostedAt)
+	}
+	if !strings.Contains(goJob.Description, "Build services in Go and PostgreSQL.") {
+		t.Errorf("unexpected description %q", goJob.Description)
+	}
+
+	analyst := jobs[1]
+	if !strings.HasSuffix(analyst.ExternalID, ":job-2") || analyst.Description != "SQL and Python." {
+		t.Errorf("content:encoded or guid not used: %q %q", analyst.ExternalID, analyst.Description)
+	}
+	if !strings.HasSuffix(analyst.ApplicationURL, "/jobs/2") || !strings.HasPrefix(analyst.ApplicationURL, "http") {
+		t.Errorf("relative link not resolved: %q", analyst.ApplicationURL)
+	}
+}
+
+func TestFeedScraper_Atom(t *testing.T) {
+	s := newTestFeedScraper(t, atomFixture, FeedConfig{
+		CompanySelector:  ".company",
+		LocationSelector: ".location",
+	})
+
+	jobs, err := collect(t, s, model.SearchParams{})
+	if err != nil {
+		t.Fatalf("Scrape: %v", err)
+	}
+	if len(jobs) != 2 {
+		t.Fatalf("expected 2 jobs, got %d", len(jobs))
+	}
+
+	platform := jobs[0]
+	if platform.CompanyName != "Hooli" || platform.LocationCity != "Berlin" {
+		t.Errorf("selectors not applied: %q %q", platform.CompanyName, platform.LocationRaw)
+	}
+	if platform.ApplicationURL != "https://hn.example.com/item?id=42" || platform.PostedAt == nil {
+		t.Errorf("unexpected link or date: %q %v", platform.ApplicationURL, platform.PostedAt)
+	}
+
+	// No company in the description and no configured name: use the host.
+	support := jobs[1]
+	if support.CompanyName != s.host || support.Description != "Help customers." || support.PostedAt != nil {
+		t.Errorf("unexpected fallback mapping: %+v", support)
+	}
+}
+
+func TestFeedScraper_MaxAge(t *testing.T) {
+	s := newTestFeedScraper(t, rssFixture, FeedConfig{MaxAge: 2 * 24 * time.Hour})
+
+	jobs, err := collect(t, s, model.SearchParams{})
+	if err != nil {
+		t.Fatalf("Scrape: %v", err)
+	}
+	if len(jobs) != 0 {
+		t.Errorf("expected every item to be older than 2 days, got %d", len(jobs))
+	}
+}
+
+func TestFeedScraper_StableExternalIDs(t *testing.T) {
+	s := newTestFeedScraper(t, rssFixture, FeedConfig{})
+
+	first, _ := collect(t, s, model.SearchParams{})
+	second, _ := collect(t, s, model.SearchParams{})
+	if len(first) == 0 || len(first) != len(second) {
+		t.Fatalf("expected the same jobs on each run, got %d and %d", len(first), len(second))
+	}
+	for i := range first {
+		if first[i].ExternalID != second[i].ExternalID {
+			t.Errorf("external ID changed between runs: %q vs %q", first[i].ExternalID, second[i].ExternalID)
+		}
+	}
+}
+
+func TestFeedScraper_NotAFeed(t *testing.T) {
+	s := newTestFeedScraper(t, `<html><body>Jobs</body></html>`, FeedConfig{})
+
+	if _, err := collect(t, s, model.SearchParams{}); err == nil || !strings.Contains(err.Error(), "unsupported root element") {
+		t.Errorf("expected an unsupported feed error, got %v", err)
+	}
+}
+
+func TestNewFeedScraper_Validation(t *testing.T) {
+	logger := log.New(io.Discard, "", 0)
+	if _, err := NewFeedScraper(FeedConfig{URL: "/feed.xml"}, logger); err == nil {
+		t.Error("expected an error for a relative URL")
+	}
+	if _, err := NewFeedScraper(FeedConfig{URL: "https://example.com/feed", TitlePattern: "("}, logger); err == nil {
+		t.Error("expected an error for an invalid title pattern")
+	}
+
+	fs, err := NewScraperForCareerPage(model.CompanyCareerPage{
+		CompanyName:   "Example Board",
+		CareerPageURL: "https://example.com/feed.rss",
+		SourceType:    model.CareerPageFeed,
+		Selectors:     []byte(`{"title_pattern":"^(?P<company>[^:]+):","max_age_days":7}`),
+	}, logger)
+	if err != nil {
+		t.Fatalf("feed page: %v", err)
+	}
+	if f, ok := fs.(*FeedScraper); !ok || f.cfg.MaxAge != 7*24*time.Hour || f.titlePattern == nil {
+		t.Errorf("feed selectors not applied: %T", fs)
+	}
+}
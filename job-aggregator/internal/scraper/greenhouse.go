@@ -0,0 +1,351 @@
+package scraper
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/learnbot/job-aggregator/internal/httpclient"
+	"github.com/learnbot/job-aggregator/internal/model"
+	"golang.org/x/net/html"
+)
+
+// greenhouseAPIBase is the public Greenhouse job board API.
+const greenhouseAPIBase = "https://boards-api.greenhouse.io/v1/boards"
+
+// greenhouseMaxPages bounds pagination in case the API keeps returning pages.
+const greenhouseMaxPages = 20
+
+// GreenhouseScraper fetches job postings from a company's Greenhouse job
+// board through the public job board API. No robots.txt check is made since
+// the API is a documented public endpoint.
+type GreenhouseScraper struct {
+	*BaseScraper
+	boardToken  string
+	companyName string
+	apiBase     string
+}
+
+// greenhouseJobsResponse is the body of GET /v1/boards/{token}/jobs.
+type greenhouseJobsResponse struct {
+	Jobs []greenhouseJob `json:"jobs"`
+	Meta struct {
+		Total int `json:"total"`
+	} `json:"meta"`
+}
+
+type greenhouseJob struct {
+	ID             int64  `json:"id"`
+	Title          string `json:"title"`
+	CompanyName    string `json:"company_name"`
+	AbsoluteURL    string `json:"absolute_url"`
+	Content        string `json:"content"`
+	UpdatedAt      string `json:"updated_at"`
+	FirstPublished string `json:"first_published"`
+	Location       struct {
+		Name string `json:"name"`
+	} `json:"location"`
+	Departments []struct {
+		Name string `json:"name"`
+	} `json:"departments"`
+	Offices []struct {
+		Name     string `json:"name"`
+		Location string `json:"location"`
+	} `json:"offices"`
+}
+
+// NewGreenhouseScraper creates a scraper for the Greenhouse board identified
+// by boardToken (the path segment in boards.greenhouse.io/{token}).
+func NewGreenhouseScraper(boardToken string, logger *log.Logger) (*GreenhouseScraper, error) {
+	boardToken = strings.TrimSpace(boardToken)
+	if boardToken == "" {
+		return nil, fmt.Errorf("greenhouse board token is required")
+	}
+
+	cfg := httpclient.DefaultConfig()
+	cfg.RequestsPerMinute = 20
+	cfg.MaxRetries = 3
+	cfg.RetryDelay = 5 * time.Second
+
+	base, err := NewBaseScraper(cfg, logger)
+	if err != nil {
+		return nil, err
+	}
+
+	return &GreenhouseScraper{
+		BaseScraper: base,
+		boardToken:  boardToken,
+		apiBase:     greenhouseAPIBase,
+	}, nil
+}
+
+func (s *GreenhouseScraper) Source() model.JobSource { return model.SourceCompanyCareerPage }
+func (s *GreenhouseScraper) Name() string {
+	if s.companyName != "" {
+		return fmt.Sprintf("Greenhouse: %s", s.companyName)
+	}
+	return fmt.Sprintf("Greenhouse: %s", s.boardToken)
+}
+
+// Scrape fetches every job on the board. The job board API normally returns
+// the whole board in one response; further pages are requested only while
+// fewer than meta.total jobs have been seen.
+func (s *GreenhouseScraper) Scrape(ctx context.Context, params model.SearchParams, jobs chan<- *model.ScrapedJob) error {
+	s.Logger.Printf("[greenhouse] scraping board %s", s.boardToken)
+
+	seen := map[int64]bool{}
+	for page := 1; page <= greenhouseMaxPages; page++ {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		resp, err := s.fetchJobs(ctx, page)
+		if err != nil {
+			return fmt.Errorf("fetch page %d: %w", page, err)
+		}
+
+		newJobs := 0
+		for _, gj := range resp.Jobs {
+			if seen[gj.ID] {
+				continue
+			}
+			seen[gj.ID] = true
+			newJobs++
+
+			job := s.toScrapedJob(gj)
+			if job == nil {
+				continue
+			}
+			if params.Query != "" {
+				if !strings.Contains(strings.ToLower(job.Title), strings.ToLower(params.Query)) &&
+					!strings.Contains(strings.ToLower(job.Description), strings.ToLower(params.Query)) {
+					continue
+				}
+			}
+
+			jobs <- job
+		}
+
+		s.Logger.Printf("[greenhouse] %s page %d: found %d jobs", s.boardToken, page, newJobs)
+
+		// Stop once the board is exhausted or the API ignores paging and
+		// repeats the same jobs.
+		if newJobs == 0 || len(seen) >= resp.Meta.Total {
+			break
+		}
+	}
+
+	return nil
+}
+
+// fetchJobs requests one page of the board's jobs with full content.
+func (s *GreenhouseScraper) fetchJobs(ctx context.Context, page int) (*greenhouseJobsResponse, error) {
+	q := url.Values{"content": {"true"}}
+	if page > 1 {
+		q.Set("page", fmt.Sprintf("%d", page))
+	}
+	endpoint := fmt.Sprintf("%s/%s/jobs?%s", s.apiBase, url.PathEscape(s.boardToken), q.Encode())
+
+	// The client's default Accept-Encoding disables transparent gzip
+	// decoding, so ask for an uncompressed body.
+	resp, err := s.Client.Get(ctx, endpoint, map[string]string{
+		"Accept":          "application/json",
+		"Accept-Encoding": "identity",
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, httpclient.Permanent(fmt.Errorf("greenhouse board %q not found", s.boardToken))
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, httpclient.Permanent(fmt.Errorf("unexpected status %d", resp.StatusCode))
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("read response body: %w", err)
+	}
+
+	var data greenhouseJobsResponse
+	if err := json.Unmarshal(body, &data); err != nil {
+		return nil, httpclient.Permanent(fmt.Errorf("parse API response: %w", err))
+	}
+	return &data, nil
+}
+
+// toScrapedJob maps a Greenhouse job to a ScrapedJob. Departments and
+// offices are kept in RawData; the first office stands in for the location
+// when the job has none of its own.
+func (s *GreenhouseScraper) toScrapedJob(gj greenhouseJob) *model.ScrapedJob {
+	if strings.TrimSpace(gj.Title) == "" {
+		return nil
+	}
+
+	// Prefer the configured company name, then the board's own name.
+	company := s.companyName
+	if company == "" {
+		company = gj.CompanyName
+	}
+	if company == "" {
+		company = s.boardToken
+	}
+
+	job := &model.ScrapedJob{
+		Source:         model.SourceCompanyCareerPage,
+		ExternalID:     fmt.Sprintf("greenhouse:%s:%d", s.boardToken, gj.ID),
+		CompanyName:    company,
+		Title:          strings.TrimSpace(gj.Title),
+		ApplicationURL: gj.AbsoluteURL,
+		SalaryCurrency: "USD",
+	}
+
+	// content is entity-escaped HTML.
+	if gj.Content != "" {
+		job.DescriptionHTML = html.UnescapeString(gj.Content)
+		job.Description = htmlToText(job.DescriptionHTML)
+	}
+
+	location := strings.TrimSpace(gj.Location.Name)
+	var departments, offices []string
+	for _, d := range gj.Departments {
+		if d.Name != "" {
+			departments = append(departments, d.Name)
+		}
+	}
+	for _, o := range gj.Offices {
+		if o.Name != "" {
+			offices = append(offices, o.Name)
+		}
+		if location == "" {
+			location = strings.TrimSpace(o.Location)
+			if location == "" {
+				location = strings.TrimSpace(o.Name)
+			}
+		}
+	}
+	if location != "" {
+		job.LocationRaw = location
+		parseLocation(location, job)
+	}
+
+	for _, ts := range []string{gj.FirstPublished, gj.UpdatedAt} {
+		if t, err := time.Parse(time.RFC3339, ts); err == nil {
+			job.PostedAt = &t
+			break
+		}
+	}
+
+	if job.ApplicationURL == "" {
+		job.ApplicationURL = fmt.Sprintf("https://boards.greenhouse.io/%s/jobs/%d", s.boardToken, gj.ID)
+	}
+
+	job.LocationType = ExtractLocationType(job.LocationRaw, job.Description)
+	job.EmploymentType = ExtractEmploymentType(job.Title, job.Description)
+	job.ExperienceLevel = ExtractExperienceLevel(job.Title, job.Description)
+	job.RequiredSkills, job.PreferredSkills, job.NiceToHaveSkills = ExtractSkillsFromText(job.Description)
+
+	job.RawData = map[string]interface{}{
+		"greenhouse_board": s.boardToken,
+		"greenhouse_id":    gj.ID,
+	}
+	if len(departments) > 0 {
+		job.RawData["departments"] = departments
+	}
+	if len(offices) > 0 {
+		job.RawData["offices"] = offices
+	}
+
+	return job
+}
+
+// GreenhouseBoardToken extracts the board token from a Greenhouse board or
+// API URL such as https://boards.greenhouse.io/acme or
+// https://boards-api.greenhouse.io/v1/boards/acme/jobs. It returns "" for
+// other URLs.
+func GreenhouseBoardToken(rawURL string) string {
+	u, err := url.Parse(strings.TrimSpace(rawURL))
+	if err != nil {
+		return ""
+	}
+	segments := strings.FieldsFunc(u.Path, func(r rune) bool { return r == '/' })
+
+	switch strings.ToLower(u.Hostname()) {
+	case "boards.greenhouse.io", "job-boards.greenhouse.io":
+		// Embedded boards use /embed/job_board?for={token}.
+		if token := u.Query().Get("for"); token != "" {
+			return token
+		}
+		if len(segments) > 0 && segments[0] != "embed" {
+			return segments[0]
+		}
+	case "boards-api.greenhouse.io":
+		for i := 0; i+1 < len(segments); i++ {
+			if segments[i] == "boards" {
+				return segments[i+1]
+			}
+		}
+	}
+	return ""
+}
+
+// blockElements are HTML elements rendered on their own line by htmlToText.
+var blockElements = map[string]bool{
+	"p": true, "div": true, "ul": true, "ol": true, "table": true, "tr": true, "section": true,
+	"h1": true, "h2": true, "h3": true, "h4": true, "h5": true, "h6": true,
+}
+
+// htmlToText converts HTML to plain text, keeping a line break between
+// block-level elements and dropping scripts and styles.
+func htmlToText(s string) string {
+	doc, err := html.Parse(strings.NewReader(s))
+	if err != nil {
+		return CleanText(s)
+	}
+
+	var sb strings.Builder
+	var walk func(*html.Node)
+	walk = func(n *html.Node) {
+		switch n.Type {
+		case html.TextNode:
+			sb.WriteString(n.Data)
+			return
+		case html.ElementNode:
+			switch {
+			case n.Data == "script" || n.Data == "style":
+				return
+			case n.Data == "br":
+				sb.WriteString("\n")
+				return
+			case n.Data == "li":
+				sb.WriteString("\n- ")
+			case blockElements[n.Data]:
+				sb.WriteString("\n")
+			}
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			walk(c)
+		}
+		if n.Type == html.ElementNode && blockElements[n.Data] {
+			sb.WriteString("\n")
+		}
+	}
+	walk(doc)
+
+	// Collapse whitespace within lines and drop blank lines.
+	var lines []string
+	for _, line := range strings.Split(sb.String(), "\n") {
+		if line = strings.Join(strings.Fields(line), " "); line != "" && line != "-" {
+			lines = append(lines, line)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,282 @@
+package scraper
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/learnbot/job-aggregator/internal/model"
+)
+
+const greenhouseFixture = `{
+  "jobs": [
+    {
+      "id": 101,
+      "title": "Senior Backend Engineer",
+      "company_name": "Acme Corp",
+      "absolute_url": "https://boards.greenhouse.io/acme/jobs/101",
+      "updated_at": "2025-01-10T12:00:00-05:00",
+      "location": {"name": "San Francisco, CA"},
+      "departments": [{"id": 1, "name": "Engineering"}],
+      "offices": [{"id": 9, "name": "San Francisco", "location": "San Francisco, CA"}],
+      "content": "&lt;p&gt;Build APIs in &lt;strong&gt;Go&lt;/strong&gt; &amp;amp; PostgreSQL.&lt;/p&gt;&lt;ul&gt;&lt;li&gt;Docker&lt;/li&gt;&lt;li&gt;Kubernetes&lt;/li&gt;&lt;/ul&gt;"
+    },
+    {
+      "id": 102,
+      "title": "Data Analyst",
+      "absolute_url": "",
+      "updated_at": "not a date",
+      "location": {"name": ""},
+      "departments": [],
+      "offices": [{"id": 10, "name": "London", "location": ""}],
+      "content": ""
+    },
+    {
+      "id": 103,
+      "title": "Product Designer",
+      "location": {"name": ""},
+      "departments": [{"id": 2, "name": "Design"}],
+      "offices": [],
+      "content": "&lt;p&gt;Design things.&lt;/p&gt;"
+    }
+  ],
+  "meta": {"total": 3}
+}`
+
+// newTestGreenhouseScraper points a scraper at a test server.
+func newTestGreenhouseScraper(t *testing.T, handler http.HandlerFunc) *GreenhouseScraper {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	s, err := NewGreenhouseScraper("acme", log.New(io.Discard, "", 0))
+	if err != nil {
+		t.Fatalf("NewGreenhouseScraper: %v", err)
+	}
+	s.apiBase = server.URL + "/v1/boards"
+	return s
+}
+
+// collect runs Scrape and returns every job sent on the channel.
+func collect(t *testing.T, s Scraper, params model.SearchParams) ([]*model.ScrapedJob, error) {
+	t.Helper()
+	ch := make(chan *model.ScrapedJob)
+	done := make(chan []*model.ScrapedJob)
+	go func() {
+		var jobs []*model.ScrapedJob
+		for j := range ch {
+			jobs = append(jobs, j)
+		}
+		done <- jobs
+	}()
+	err := s.Scrape(context.Background(), params, ch)
+	close(ch)
+	return <-done, err
+}
+
+func TestGreenhouseScraper_MapsJobs(t *testing.T) {
+	var gotPath, gotQuery string
+	s := newTestGreenhouseScraper(t, func(w http.ResponseWriter, r *http.Request) {
+		gotPath, gotQuery = r.URL.Path, r.URL.RawQuery
+		w.Write([]byte(greenhouseFixture))
+	})
+
+	jobs, err := collect(t, s, model.SearchParams{})
+	if err != nil {
+		t.Fatalf("Scrape: %v", err)
+	}
+	if gotPath != "/v1/boards/acme/jobs" || gotQuery != "content=true" {
+		t.Errorf("unexpected request %s?%s", gotPath, gotQuery)
+	}
+	if len(jobs) != 3 {
+		t.Fatalf("expected 3 jobs, got %d", len(jobs))
+	}
+
+	be := jobs[0]
+	if be.CompanyName != "Acme Corp" || be.ExternalID != "greenhouse:acme:101" {
+		t.Errorf("unexpected identity: %q %q", be.CompanyName, be.ExternalID)
+	}
+	if be.LocationCity != "San Francisco" || be.LocationState != "CA" || be.LocationType != model.LocationOnSite {
+		t.Errorf("unexpected location: %+v", be)
+	}
+	if want := "Build APIs in Go & PostgreSQL.\n- Docker\n- Kubernetes"; be.Description != want {
+		t.Errorf("Description = %q, want %q", be.Description, want)
+	}
+	if !strings.Contains(be.DescriptionHTML, "<strong>Go</strong>") {
+		t.Errorf("DescriptionHTML should be unescaped HTML: %q", be.DescriptionHTML)
+	}
+	if be.ExperienceLevel != model.LevelSenior || be.PostedAt == nil {
+		t.Errorf("level=%v posted=%v", be.ExperienceLevel, be.PostedAt)
+	}
+	if depts, _ := be.RawData["departments"].([]string); len(depts) != 1 || depts[0] != "Engineering" {
+		t.Errorf("departments not mapped: %v", be.RawData)
+	}
+
+	// No location: fall back to the office name.
+	analyst := jobs[1]
+	if analyst.LocationRaw != "London" || analyst.LocationCity != "London" {
+		t.Errorf("expected office fallback, got %q", analyst.LocationRaw)
+	}
+	if analyst.ApplicationURL != "https://boards.greenhouse.io/acme/jobs/102" {
+		t.Errorf("unexpected fallback URL %q", analyst.ApplicationURL)
+	}
+	if analyst.PostedAt != nil {
+		t.Error("an unparseable date should leave PostedAt nil")
+	}
+
+	// No location and no offices.
+	designer := jobs[2]
+	if designer.LocationRaw != "" || designer.LocationCity != "" || designer.LocationType != model.LocationUnknown {
+		t.Errorf("expected no location, got %+v", designer)
+	}
+}
+
+func TestGreenhouseScraper_Pagination(t *testing.T) {
+	var mu sync.Mutex
+	var pages []string
+	s := newTestGreenhouseScraper(t, func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		pages = append(pages, r.URL.Query().Get("page"))
+		mu.Unlock()
+		page := r.URL.Query().Get("page")
+		if page == "" {
+			page = "1"
+		}
+		fmt.Fprintf(w, `{"jobs":[{"id":%s1,"title":"Job %s-1"},{"id":%s2,"title":"Job %s-2"}],"meta":{"total":5}}`,
+			page, page, page, page)
+	})
+
+	jobs, err := collect(t, s, model.SearchParams{})
+	if err != nil {
+		t.Fatalf("Scrape: %v", err)
+	}
+	// Pages 1 and 2 give four jobs; page 3 reaches the total of five.
+	if len(pages) != 3 || pages[0] != "" || pages[1] != "2" || pages[2] != "3" {
+		t.Errorf("unexpected pages requested: %q", pages)
+	}
+	if len(jobs) != 6 {
+		t.Errorf("expected 6 jobs, got %d", len(jobs))
+	}
+}
+
+func TestGreenhouseScraper_StopsWhenPagesRepeat(t *testing.T) {
+	requests := 0
+	s := newTestGreenhouseScraper(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Write([]byte(`{"jobs":[{"id":1,"title":"Only Job"}],"meta":{"total":10}}`))
+	})
+
+	jobs, err := collect(t, s, model.SearchParams{})
+	if err != nil {
+		t.Fatalf("Scrape: %v", err)
+	}
+	if requests != 2 || len(jobs) != 1 {
+		t.Errorf("expected 2 requests and 1 job, got %d requests and %d jobs", requests, len(jobs))
+	}
+}
+
+func TestGreenhouseScraper_QueryFilter(t *testing.T) {
+	s := newTestGreenhouseScraper(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(greenhouseFixture))
+	})
+
+	jobs, err := collect(t, s, model.SearchParams{Query: "kubernetes"})
+	if err != nil {
+		t.Fatalf("Scrape: %v", err)
+	}
+	if len(jobs) != 1 || jobs[0].Title != "Senior Backend Engineer" {
+		t.Errorf("expected only the backend job, got %d", len(jobs))
+	}
+}
+
+func TestGreenhouseScraper_UnknownBoard(t *testing.T) {
+	s := newTestGreenhouseScraper(t, func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"status":404,"error":"Job not found"}`, http.StatusNotFound)
+	})
+
+	if _, err := collect(t, s, model.SearchParams{}); err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Errorf("expected a not found error, got %v", err)
+	}
+}
+
+func TestNewGreenhouseScraper_RequiresToken(t *testing.T) {
+	if _, err := NewGreenhouseScraper("  ", log.New(io.Discard, "", 0)); err == nil {
+		t.Error("expected an error for an empty board token")
+	}
+}
+
+func TestGreenhouseBoardToken(t *testing.T) {
+	tests := []struct {
+		url  string
+		want string
+	}{
+		{"https://boards.greenhouse.io/acme", "acme"},
+		{"https://boards.greenhouse.io/acme/jobs/123", "acme"},
+		{"https://job-boards.greenhouse.io/acme", "acme"},
+		{"https://boards.greenhouse.io/embed/job_board?for=acme", "acme"},
+		{"https://boards.greenhouse.io/?for=acme", "acme"},
+		{"https://boards-api.greenhouse.io/v1/boards/acme/jobs", "acme"},
+		{"https://acme.com/careers", ""},
+	}
+	for _, tt := range tests {
+		if got := GreenhouseBoardToken(tt.url); got != tt.want {
+			t.Errorf("GreenhouseBoardToken(%q) = %q, want %q", tt.url, got, tt.want)
+		}
+	}
+}
+
+func TestNewScraperForCareerPage(t *testing.T) {
+	logger := log.New(io.Discard, "", 0)
+
+	gh, err := NewScraperForCareerPage(model.CompanyCareerPage{
+		CompanyName:   "Acme",
+		CareerPageURL: "https://boards.greenhouse.io/acme",
+		SourceType:    model.CareerPageGreenhouse,
+	}, logger)
+	if err != nil {
+		t.Fatalf("greenhouse page: %v", err)
+	}
+	if g, ok := gh.(*GreenhouseScraper); !ok || g.boardToken != "acme" || g.Name() != "Greenhouse: Acme" {
+		t.Errorf("unexpected scraper %T %s", gh, gh.Name())
+	}
+
+	gh, err = NewScraperForCareerPage(model.CompanyCareerPage{
+		CareerPageURL: "https://acme.com/careers",
+		SourceType:    model.CareerPageGreenhouse,
+		Selectors:     []byte(`{"board_token":"acme-inc"}`),
+	}, logger)
+	if err != nil || gh.(*GreenhouseScraper).boardToken != "acme-inc" {
+		t.Errorf("board_token selector not used: %v", err)
+	}
+
+	if _, err := NewScraperForCareerPage(model.CompanyCareerPage{
+		CareerPageURL: "https://acme.com/careers",
+		SourceType:    model.CareerPageGreenhouse,
+	}, logger); err == nil {
+		t.Error("expected an error without a board token")
+	}
+
+	lv, err := NewScraperForCareerPage(model.CompanyCareerPage{
+		CompanyName:   "Acme",
+		CareerPageURL: "https://jobs.lever.co/acme",
+		SourceType:    model.CareerPageLever,
+	}, logger)
+	if l, ok := lv.(*LeverScraper); err != nil || !ok || l.company != "acme" || l.Name() != "Lever: Acme" {
+		t.Errorf("lever page should use LeverScraper, got %T %v", lv, err)
+	}
+
+	cp, err := NewScraperForCareerPage(model.CompanyCareerPage{CompanyName: "Acme", SourceType: model.CareerPageHTML}, logger)
+	if _, ok := cp.(*CareerPageScraper); err != nil || !ok {
+		t.Errorf("html page should use CareerPageScraper, got %T %v", cp, err)
+	}
+
+	if _, err := NewScraperForCareerPage(model.CompanyCareerPage{SourceType: "workday"}, logger); err == nil {
+		t.Error("expected an error for an unknown source type")
+	}
+}
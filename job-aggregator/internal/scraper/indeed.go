@@ -72,11 +72,7 @@ func (s *IndeedScraper) Scrape(ctx context.Context, params model.SearchParams, j
 		}
 
 		for _, job := range pageJobs {
-			select {
-			case jobs <- job:
-			case <-ctx.Done():
-				return ctx.Err()
-			}
+			jobs <- job
 		}
 
 		s.Logger.Printf("[indeed] page %d: found %d jobs", page, len(pageJobs))
@@ -112,7 +108,7 @@ func (s *IndeedScraper) scrapePage(ctx context.Context, params model.SearchParam
 	searchURL := s.baseURL + "?" + q.Encode()
 
 	if !s.Robots.IsAllowed(ctx, searchURL) {
-		return nil, false, fmt.Errorf("robots.txt disallows scraping %s", searchURL)
+		return nil, false, httpclient.Permanent(fmt.Errorf("robots.txt disallows scraping %s", searchURL))
 	}
 
 	headers := map[string]string{
@@ -128,7 +124,7 @@ func (s *IndeedScraper) scrapePage(ctx context.Context, params model.SearchParam
 
 	jobs, err := parseIndeedHTML(body)
 	if err != nil {
-		return nil, false, fmt.Errorf("parse HTML: %w", err)
+		return nil, false, httpclient.Permanent(fmt.Errorf("parse HTML: %w", err))
 	}
 
 	hasMore := len(jobs) >= params.PageSize
@@ -0,0 +1,302 @@
+package scraper
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/learnbot/job-aggregator/internal/model"
+	"golang.org/x/net/html"
+)
+
+// jsonLDPosting is the subset of a schema.org JobPosting read by the
+// career page scraper. Most fields may be a single value or an array, or a
+// string or an object, so they are decoded lazily.
+type jsonLDPosting struct {
+	Title              string          `json:"title"`
+	Description        string          `json:"description"`
+	DatePosted         string          `json:"datePosted"`
+	ValidThrough       string          `json:"validThrough"`
+	EmploymentType     json.RawMessage `json:"employmentType"`
+	HiringOrganization json.RawMessage `json:"hiringOrganization"`
+	JobLocation        json.RawMessage `json:"jobLocation"`
+	JobLocationType    string          `json:"jobLocationType"`
+	BaseSalary         *struct {
+		Currency string `json:"currency"`
+		Value    struct {
+			Value    float64 `json:"value"`
+			MinValue float64 `json:"minValue"`
+			MaxValue float64 `json:"maxValue"`
+			UnitText string  `json:"unitText"`
+		} `json:"value"`
+	} `json:"baseSalary"`
+	Identifier json.RawMessage `json:"identifier"`
+	URL        string          `json:"url"`
+}
+
+// jsonLDEmploymentTypes maps schema.org employmentType values.
+var jsonLDEmploymentTypes = map[string]model.EmploymentType{
+	"FULL_TIME":  model.EmploymentFullTime,
+	"PART_TIME":  model.EmploymentPartTime,
+	"CONTRACTOR": model.EmploymentContract,
+	"TEMPORARY":  model.EmploymentTemporary,
+	"INTERN":     model.EmploymentInternship,
+	"VOLUNTEER":  model.EmploymentVolunteer,
+	"OTHER":      model.EmploymentOther,
+}
+
+// jsonLDSalaryPeriods maps schema.org QuantitativeValue unitText values.
+var jsonLDSalaryPeriods = map[string]model.SalaryPeriod{
+	"HOUR":  model.SalaryPerHour,
+	"DAY":   model.SalaryPerDay,
+	"WEEK":  model.SalaryPerWeek,
+	"MONTH": model.SalaryPerMonth,
+	"YEAR":  model.SalaryPerYear,
+}
+
+// extractJSONLDPostings returns the JobPosting objects in the
+// <script type="application/ld+json"> blocks of doc. Postings may be top
+// level, in an array, in an @graph, or the items of an ItemList; scripts
+// that are not valid JSON are skipped.
+func extractJSONLDPostings(doc *html.Node) []jsonLDPosting {
+	var postings []jsonLDPosting
+	var walk func(*html.Node)
+	walk = func(n *html.Node) {
+		if n.Type == html.ElementNode && n.Data == "script" &&
+			strings.EqualFold(strings.TrimSpace(getAttr(n, "type")), "application/ld+json") {
+			var v interface{}
+			if err := json.Unmarshal([]byte(extractText(n)), &v); err == nil {
+				postings = collectJSONLDPostings(v, postings)
+			}
+			return
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			walk(c)
+		}
+	}
+	walk(doc)
+	return postings
+}
+
+// collectJSONLDPostings appends the JobPostings found in the decoded JSON
+// value v to postings.
+func collectJSONLDPostings(v interface{}, postings []jsonLDPosting) []jsonLDPosting {
+	switch v := v.(type) {
+	case []interface{}:
+		for _, item := range v {
+			postings = collectJSONLDPostings(item, postings)
+		}
+	case map[string]interface{}:
+		if jsonLDIsType(v["@type"], "JobPosting") {
+			// Re-encoding is the simplest way to decode the typed subset.
+			raw, err := json.Marshal(v)
+			if err == nil {
+				var p jsonLDPosting
+				if json.Unmarshal(raw, &p) == nil {
+					postings = append(postings, p)
+				}
+			}
+			return postings
+		}
+		if graph, ok := v["@graph"]; ok {
+			postings = collectJSONLDPostings(graph, postings)
+		}
+		if items, ok := v["itemListElement"]; ok {
+			postings = collectJSONLDPostings(items, postings)
+		}
+		if item, ok := v["item"]; ok {
+			postings = collectJSONLDPostings(item, postings)
+		}
+	}
+	return postings
+}
+
+// jsonLDIsType reports whether an @type value, a string or an array of
+// strings, names typ.
+func jsonLDIsType(v interface{}, typ string) bool {
+	switch v := v.(type) {
+	case string:
+		return v == typ || v == "http://schema.org/"+typ || v == "https://schema.org/"+typ
+	case []interface{}:
+		for _, t := range v {
+			if jsonLDIsType(t, typ) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// jsonLDStrings decodes a value that is a string, an array of strings, or
+// an object or array of objects with a field named key.
+func jsonLDStrings(raw json.RawMessage, key string) []string {
+	if len(raw) == 0 {
+		return nil
+	}
+	var s string
+	if json.Unmarshal(raw, &s) == nil {
+		return []string{s}
+	}
+	var list []json.RawMessage
+	if json.Unmarshal(raw, &list) == nil {
+		var out []string
+		for _, item := range list {
+			out = append(out, jsonLDStrings(item, key)...)
+		}
+		return out
+	}
+	var obj map[string]json.RawMessage
+	if key != "" && json.Unmarshal(raw, &obj) == nil {
+		return jsonLDStrings(obj[key], "")
+	}
+	return nil
+}
+
+// jsonLDLocation formats the first jobLocation address as "City, Region,
+// Country".
+func jsonLDLocation(raw json.RawMessage) string {
+	var places []json.RawMessage
+	if json.Unmarshal(raw, &places) != nil {
+		places = []json.RawMessage{raw}
+	}
+	for _, place := range places {
+		var p struct {
+			Address json.RawMessage `json:"address"`
+		}
+		if json.Unmarshal(place, &p) != nil || len(p.Address) == 0 {
+			continue
+		}
+		// The address may be a plain string.
+		if s := jsonLDStrings(p.Address, ""); len(s) > 0 {
+			return strings.TrimSpace(s[0])
+		}
+		var addr struct {
+			Locality string          `json:"addressLocality"`
+			Region   string          `json:"addressRegion"`
+			Country  json.RawMessage `json:"addressCountry"`
+		}
+		if json.Unmarshal(p.Address, &addr) != nil {
+			continue
+		}
+		var parts []string
+		for _, part := range append([]string{addr.Locality, addr.Region}, jsonLDStrings(addr.Country, "name")...) {
+			if part = strings.TrimSpace(part); part != "" {
+				parts = append(parts, part)
+			}
+		}
+		if len(parts) > 0 {
+			return strings.Join(parts, ", ")
+		}
+	}
+	return ""
+}
+
+// parseJSONLDDate parses a schema.org Date or DateTime.
+func parseJSONLDDate(s string) *time.Time {
+	s = strings.TrimSpace(s)
+	for _, layout := range []string{time.RFC3339, "2006-01-02T15:04:05", "2006-01-02"} {
+		if t, err := time.Parse(layout, s); err == nil {
+			return &t
+		}
+	}
+	return nil
+}
+
+// toScrapedJob maps a JobPosting found on pageURL to a ScrapedJob for
+// company. It returns nil for postings without a title.
+func (p jsonLDPosting) toScrapedJob(company, pageURL string) *model.ScrapedJob {
+	title := CleanText(html.UnescapeString(p.Title))
+	if title == "" {
+		return nil
+	}
+	if company == "" {
+		if names := jsonLDStrings(p.HiringOrganization, "name"); len(names) > 0 {
+			company = strings.TrimSpace(names[0])
+		}
+	}
+
+	job := &model.ScrapedJob{
+		Source:         model.SourceCompanyCareerPage,
+		CompanyName:    company,
+		Title:          title,
+		ApplicationURL: p.URL,
+		SalaryCurrency: "USD",
+	}
+	if job.ApplicationURL == "" {
+		job.ApplicationURL = pageURL
+	}
+	job.ApplicationURL = resolveURL(pageURL, job.ApplicationURL)
+
+	// The identifier is a string or a PropertyValue; the URL stands in
+	// when the posting has none.
+	job.ExternalID = job.ApplicationURL
+	if ids := jsonLDStrings(p.Identifier, "value"); len(ids) > 0 && strings.TrimSpace(ids[0]) != "" {
+		job.ExternalID = "jsonld:" + strings.TrimSpace(ids[0])
+	}
+
+	if p.Description != "" {
+		job.DescriptionHTML = html.UnescapeString(p.Description)
+		job.Description = htmlToText(job.DescriptionHTML)
+	}
+
+	if loc := jsonLDLocation(p.JobLocation); loc != "" {
+		job.LocationRaw = loc
+		parseLocation(loc, job)
+	}
+	job.PostedAt = parseJSONLDDate(p.DatePosted)
+	job.ExpiresAt = parseJSONLDDate(p.ValidThrough)
+
+	if s := p.BaseSalary; s != nil {
+		minVal, maxVal := s.Value.MinValue, s.Value.MaxValue
+		if minVal == 0 && maxVal == 0 {
+			minVal, maxVal = s.Value.Value, s.Value.Value
+		}
+		if minVal > 0 {
+			v := int(minVal)
+			job.SalaryMin = &v
+		}
+		if maxVal > 0 {
+			v := int(maxVal)
+			job.SalaryMax = &v
+		}
+		if s.Currency != "" {
+			job.SalaryCurrency = strings.ToUpper(s.Currency)
+		}
+		job.SalaryPeriod = jsonLDSalaryPeriods[strings.ToUpper(strings.TrimSpace(s.Value.UnitText))]
+	}
+
+	if strings.EqualFold(p.JobLocationType, "TELECOMMUTE") {
+		job.LocationType = model.LocationRemote
+	} else {
+		job.LocationType = ExtractLocationType(job.LocationRaw, job.Description)
+	}
+	job.EmploymentType = ExtractEmploymentType(job.Title, job.Description)
+	for _, t := range jsonLDStrings(p.EmploymentType, "") {
+		if et, ok := jsonLDEmploymentTypes[strings.ToUpper(strings.TrimSpace(t))]; ok {
+			job.EmploymentType = et
+			break
+		}
+	}
+	job.ExperienceLevel = ExtractExperienceLevel(job.Title, job.Description)
+	job.RequiredSkills, job.PreferredSkills, job.NiceToHaveSkills = ExtractSkillsFromText(job.Description)
+
+	job.RawData = map[string]interface{}{"extracted_from": "json_ld"}
+	return job
+}
+
+// parseJSONLDJobs returns the jobs in the JSON-LD JobPosting data of the
+// page at pageURL.
+func parseJSONLDJobs(body, company, pageURL string) ([]*model.ScrapedJob, error) {
+	doc, err := html.Parse(strings.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("parse HTML: %w", err)
+	}
+	var jobs []*model.ScrapedJob
+	for _, p := range extractJSONLDPostings(doc) {
+		if job := p.toScrapedJob(company, pageURL); job != nil {
+			jobs = append(jobs, job)
+		}
+	}
+	return jobs, nil
+}
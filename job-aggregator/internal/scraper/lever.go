@@ -0,0 +1,321 @@
+package scraper
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/learnbot/job-aggregator/internal/httpclient"
+	"github.com/learnbot/job-aggregator/internal/model"
+)
+
+// leverAPIBase is the public Lever postings API.
+const leverAPIBase = "https://api.lever.co/v0/postings"
+
+// leverPageSize is the number of postings requested per page.
+const leverPageSize = 100
+
+// leverMaxPages bounds pagination for very large boards.
+const leverMaxPages = 20
+
+// LeverScraper fetches job postings from a company's Lever job board
+// (jobs.lever.co/{company}) through the public postings API.
+type LeverScraper struct {
+	*BaseScraper
+	company     string
+	companyName string
+	apiBase     string
+}
+
+type leverPosting struct {
+	ID               string `json:"id"`
+	Text             string `json:"text"`
+	HostedURL        string `json:"hostedUrl"`
+	ApplyURL         string `json:"applyUrl"`
+	CreatedAt        int64  `json:"createdAt"`
+	DescriptionPlain string `json:"descriptionPlain"`
+	AdditionalPlain  string `json:"additionalPlain"`
+	WorkplaceType    string `json:"workplaceType"`
+	Categories       struct {
+		Location     string   `json:"location"`
+		Team         string   `json:"team"`
+		Department   string   `json:"department"`
+		Commitment   string   `json:"commitment"`
+		AllLocations []string `json:"allLocations"`
+	} `json:"categories"`
+	Lists []struct {
+		Text    string `json:"text"`
+		Content string `json:"content"`
+	} `json:"lists"`
+	SalaryRange *struct {
+		Currency string `json:"currency"`
+		Min      int    `json:"min"`
+		Max      int    `json:"max"`
+		Interval string `json:"interval"`
+	} `json:"salaryRange"`
+}
+
+// leverSalaryPeriods maps salaryRange.interval values. "one-time" has no
+// period.
+var leverSalaryPeriods = map[string]model.SalaryPeriod{
+	"per-hour-wage":    model.SalaryPerHour,
+	"per-day-wage":     model.SalaryPerDay,
+	"per-week-salary":  model.SalaryPerWeek,
+	"per-month-salary": model.SalaryPerMonth,
+	"per-year-salary":  model.SalaryPerYear,
+}
+
+// NewLeverScraper creates a scraper for the Lever board of company (the path
+// segment in jobs.lever.co/{company}).
+func NewLeverScraper(company string, logger *log.Logger) (*LeverScraper, error) {
+	company = strings.TrimSpace(company)
+	if company == "" {
+		return nil, fmt.Errorf("lever company is required")
+	}
+
+	cfg := httpclient.DefaultConfig()
+	cfg.RequestsPerMinute = 20
+	cfg.MaxRetries = 3
+	cfg.RetryDelay = 5 * time.Second
+
+	base, err := NewBaseScraper(cfg, logger)
+	if err != nil {
+		return nil, err
+	}
+
+	return &LeverScraper{
+		BaseScraper: base,
+		company:     company,
+		apiBase:     leverAPIBase,
+	}, nil
+}
+
+func (s *LeverScraper) Source() model.JobSource { return model.SourceCompanyCareerPage }
+func (s *LeverScraper) Name() string {
+	if s.companyName != "" {
+		return fmt.Sprintf("Lever: %s", s.companyName)
+	}
+	return fmt.Sprintf("Lever: %s", s.company)
+}
+
+// Scrape fetches every posting on the board, paging with skip/limit until a
+// short page is returned.
+func (s *LeverScraper) Scrape(ctx context.Context, params model.SearchParams, jobs chan<- *model.ScrapedJob) error {
+	s.Logger.Printf("[lever] scraping board %s", s.company)
+
+	seen := map[string]bool{}
+	for page := 0; page < leverMaxPages; page++ {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		postings, err := s.fetchPostings(ctx, page*leverPageSize)
+		if err != nil {
+			return fmt.Errorf("fetch page %d: %w", page, err)
+		}
+
+		newJobs := 0
+		for _, p := range postings {
+			if seen[p.ID] {
+				continue
+			}
+			seen[p.ID] = true
+			newJobs++
+
+			job := s.toScrapedJob(p)
+			if job == nil {
+				continue
+			}
+			if params.Query != "" {
+				if !strings.Contains(strings.ToLower(job.Title), strings.ToLower(params.Query)) &&
+					!strings.Contains(strings.ToLower(job.Description), strings.ToLower(params.Query)) {
+					continue
+				}
+			}
+
+			jobs <- job
+		}
+
+		s.Logger.Printf("[lever] %s page %d: found %d jobs", s.company, page, newJobs)
+
+		if len(postings) < leverPageSize || newJobs == 0 {
+			break
+		}
+	}
+
+	return nil
+}
+
+// fetchPostings requests one page of postings starting at skip.
+func (s *LeverScraper) fetchPostings(ctx context.Context, skip int) ([]leverPosting, error) {
+	q := url.Values{
+		"mode":  {"json"},
+		"limit": {fmt.Sprintf("%d", leverPageSize)},
+	}
+	if skip > 0 {
+		q.Set("skip", fmt.Sprintf("%d", skip))
+	}
+	endpoint := fmt.Sprintf("%s/%s?%s", s.apiBase, url.PathEscape(s.company), q.Encode())
+
+	// See GreenhouseScraper.fetchJobs for why compression is disabled.
+	resp, err := s.Client.Get(ctx, endpoint, map[string]string{
+		"Accept":          "application/json",
+		"Accept-Encoding": "identity",
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, httpclient.Permanent(fmt.Errorf("lever company %q not found", s.company))
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, httpclient.Permanent(fmt.Errorf("unexpected status %d", resp.StatusCode))
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("read response body: %w", err)
+	}
+
+	var postings []leverPosting
+	if err := json.Unmarshal(body, &postings); err != nil {
+		return nil, httpclient.Permanent(fmt.Errorf("parse API response: %w", err))
+	}
+	return postings, nil
+}
+
+// toScrapedJob maps a Lever posting to a ScrapedJob. The team and
+// department are kept in RawData.
+func (s *LeverScraper) toScrapedJob(p leverPosting) *model.ScrapedJob {
+	if strings.TrimSpace(p.Text) == "" {
+		return nil
+	}
+
+	company := s.companyName
+	if company == "" {
+		company = s.company
+	}
+
+	job := &model.ScrapedJob{
+		Source:         model.SourceCompanyCareerPage,
+		ExternalID:     fmt.Sprintf("lever:%s:%s", s.company, p.ID),
+		CompanyName:    company,
+		Title:          strings.TrimSpace(p.Text),
+		ApplicationURL: p.HostedURL,
+		SalaryCurrency: "USD",
+	}
+	if job.ApplicationURL == "" {
+		job.ApplicationURL = p.ApplyURL
+	}
+	if job.ApplicationURL == "" {
+		job.ApplicationURL = fmt.Sprintf("https://jobs.lever.co/%s/%s", s.company, p.ID)
+	}
+
+	// The requirement lists are HTML; the rest is already plain text.
+	sections := []string{strings.TrimSpace(p.DescriptionPlain)}
+	for _, l := range p.Lists {
+		if text := htmlToText(l.Content); text != "" {
+			sections = append(sections, strings.TrimSpace(l.Text+"\n"+text))
+		}
+	}
+	sections = append(sections, strings.TrimSpace(p.AdditionalPlain))
+	var nonEmpty []string
+	for _, sec := range sections {
+		if sec != "" {
+			nonEmpty = append(nonEmpty, sec)
+		}
+	}
+	job.Description = strings.Join(nonEmpty, "\n\n")
+
+	location := strings.TrimSpace(p.Categories.Location)
+	if location == "" && len(p.Categories.AllLocations) > 0 {
+		location = strings.TrimSpace(p.Categories.AllLocations[0])
+	}
+	if location != "" {
+		job.LocationRaw = location
+		parseLocation(location, job)
+	}
+
+	if p.CreatedAt > 0 {
+		t := time.UnixMilli(p.CreatedAt).UTC()
+		job.PostedAt = &t
+	}
+
+	if sr := p.SalaryRange; sr != nil && (sr.Min > 0 || sr.Max > 0) {
+		if sr.Min > 0 {
+			v := sr.Min
+			job.SalaryMin = &v
+		}
+		if sr.Max > 0 {
+			v := sr.Max
+			job.SalaryMax = &v
+		}
+		if sr.Currency != "" {
+			job.SalaryCurrency = sr.Currency
+		}
+		job.SalaryPeriod = leverSalaryPeriods[sr.Interval]
+	}
+
+	switch p.WorkplaceType {
+	case "remote":
+		job.LocationType = model.LocationRemote
+	case "hybrid":
+		job.LocationType = model.LocationHybrid
+	case "on-site", "onsite":
+		job.LocationType = model.LocationOnSite
+	default:
+		job.LocationType = ExtractLocationType(job.LocationRaw, job.Description)
+	}
+	job.EmploymentType = ExtractEmploymentType(p.Categories.Commitment+" "+job.Title, job.Description)
+	job.ExperienceLevel = ExtractExperienceLevel(job.Title, job.Description)
+	job.RequiredSkills, job.PreferredSkills, job.NiceToHaveSkills = ExtractSkillsFromText(job.Description)
+
+	job.RawData = map[string]interface{}{
+		"lever_company": s.company,
+		"lever_id":      p.ID,
+	}
+	if p.Categories.Team != "" {
+		job.RawData["team"] = p.Categories.Team
+	}
+	if p.Categories.Department != "" {
+		job.RawData["department"] = p.Categories.Department
+	}
+	if p.Categories.Commitment != "" {
+		job.RawData["commitment"] = p.Categories.Commitment
+	}
+
+	return job
+}
+
+// LeverCompany extracts the company slug from a Lever board or API URL such
+// as https://jobs.lever.co/acme or https://api.lever.co/v0/postings/acme. It
+// returns "" for other URLs.
+func LeverCompany(rawURL string) string {
+	u, err := url.Parse(strings.TrimSpace(rawURL))
+	if err != nil {
+		return ""
+	}
+	segments := strings.FieldsFunc(u.Path, func(r rune) bool { return r == '/' })
+
+	switch strings.ToLower(u.Hostname()) {
+	case "jobs.lever.co":
+		if len(segments) > 0 {
+			return segments[0]
+		}
+	case "api.lever.co":
+		for i := 0; i+1 < len(segments); i++ {
+			if segments[i] == "postings" {
+				return segments[i+1]
+			}
+		}
+	}
+	return ""
+}
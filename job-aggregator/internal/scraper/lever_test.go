@@ -0,0 +1,171 @@
+package scraper
+
+import (
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/learnbot/job-aggregator/internal/model"
+)
+
+const leverFixture = `[
+  {
+    "id": "5ac21346-8e0c-4494-8e7a-3eb92ff77902",
+    "text": "Senior Platform Engineer",
+    "hostedUrl": "https://jobs.lever.co/acme/5ac21346-8e0c-4494-8e7a-3eb92ff77902",
+    "applyUrl": "https://jobs.lever.co/acme/5ac21346-8e0c-4494-8e7a-3eb92ff77902/apply",
+    "createdAt": 1736510400000,
+    "workplaceType": "remote",
+    "descriptionPlain": "We run Kubernetes at scale.",
+    "lists": [{"text": "Requirements", "content": "<li>Go</li><li>Terraform</li>"}],
+    "additionalPlain": "Equal opportunity employer.",
+    "categories": {"location": "Berlin, Germany", "team": "Infrastructure", "department": "Engineering", "commitment": "Full-time"},
+    "salaryRange": {"currency": "EUR", "interval": "per-year-salary", "min": 90000, "max": 120000}
+  },
+  {
+    "id": "9b1f0c7e-0000-4000-8000-000000000002",
+    "text": "Support Contractor",
+    "hostedUrl": "",
+    "applyUrl": "",
+    "descriptionPlain": "",
+    "categories": {"location": "", "team": "Support", "commitment": "Contract", "allLocations": []}
+  }
+]`
+
+func newTestLeverScraper(t *testing.T, handler http.HandlerFunc) *LeverScraper {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	s, err := NewLeverScraper("acme", log.New(io.Discard, "", 0))
+	if err != nil {
+		t.Fatalf("NewLeverScraper: %v", err)
+	}
+	s.apiBase = server.URL + "/v0/postings"
+	return s
+}
+
+func TestLeverScraper_MapsPostings(t *testing.T) {
+	var gotPath, gotMode string
+	s := newTestLeverScraper(t, func(w http.ResponseWriter, r *http.Request) {
+		gotPath, gotMode = r.URL.Path, r.URL.Query().Get("mode")
+		w.Write([]byte(leverFixture))
+	})
+
+	jobs, err := collect(t, s, model.SearchParams{})
+	if err != nil {
+		t.Fatalf("Scrape: %v", err)
+	}
+	if gotPath != "/v0/postings/acme" || gotMode != "json" {
+		t.Errorf("unexpected request %s mode=%s", gotPath, gotMode)
+	}
+	if len(jobs) != 2 {
+		t.Fatalf("expected 2 jobs, got %d", len(jobs))
+	}
+
+	pe := jobs[0]
+	if pe.ExternalID != "lever:acme:5ac21346-8e0c-4494-8e7a-3eb92ff77902" || pe.CompanyName != "acme" {
+		t.Errorf("unexpected identity: %q %q", pe.ExternalID, pe.CompanyName)
+	}
+	if pe.LocationCity != "Berlin" || pe.LocationState != "Germany" || pe.LocationType != model.LocationRemote {
+		t.Errorf("unexpected location: %q %q %v", pe.LocationCity, pe.LocationState, pe.LocationType)
+	}
+	if want := "We run Kubernetes at scale.\n\nRequirements\n- Go\n- Terraform\n\nEqual opportunity employer."; pe.Description != want {
+		t.Errorf("Description = %q, want %q", pe.Description, want)
+	}
+	if pe.RawData["team"] != "Infrastructure" || pe.RawData["department"] != "Engineering" {
+		t.Errorf("categories not mapped: %v", pe.RawData)
+	}
+	if pe.SalaryMin == nil || *pe.SalaryMin != 90000 || pe.SalaryCurrency != "EUR" || pe.SalaryPeriod != model.SalaryPerYear {
+		t.Errorf("salary not mapped: %v %s %s", pe.SalaryMin, pe.SalaryCurrency, pe.SalaryPeriod)
+	}
+	if pe.PostedAt == nil || pe.PostedAt.Format("2006-01-02") != "2025-01-10" {
+		t.Errorf("unexpected PostedAt %v", pe.PostedAt)
+	}
+	if pe.ExperienceLevel != model.LevelSenior || !containsString(pe.RequiredSkills, "terraform") {
+		t.Errorf("level=%v skills=%v", pe.ExperienceLevel, pe.RequiredSkills)
+	}
+
+	sc := jobs[1]
+	if sc.LocationRaw != "" || sc.LocationType != model.LocationUnknown {
+		t.Errorf("expected no location, got %q %v", sc.LocationRaw, sc.LocationType)
+	}
+	if sc.EmploymentType != model.EmploymentContract {
+		t.Errorf("commitment should drive employment type, got %v", sc.EmploymentType)
+	}
+	if !strings.HasPrefix(sc.ApplicationURL, "https://jobs.lever.co/acme/") {
+		t.Errorf("unexpected fallback URL %q", sc.ApplicationURL)
+	}
+}
+
+func TestLeverScraper_Pagination(t *testing.T) {
+	var skips []string
+	s := newTestLeverScraper(t, func(w http.ResponseWriter, r *http.Request) {
+		skip := r.URL.Query().Get("skip")
+		skips = append(skips, skip)
+		n := leverPageSize
+		if skip == "100" {
+			n = 3
+		}
+		var b strings.Builder
+		b.WriteString("[")
+		for i := 0; i < n; i++ {
+			if i > 0 {
+				b.WriteString(",")
+			}
+			b.WriteString(`{"id":"` + skip + "-" + strings.Repeat("x", i+1) + `","text":"Engineer"}`)
+		}
+		b.WriteString("]")
+		w.Write([]byte(b.String()))
+	})
+
+	jobs, err := collect(t, s, model.SearchParams{})
+	if err != nil {
+		t.Fatalf("Scrape: %v", err)
+	}
+	if len(skips) != 2 || skips[0] != "" || skips[1] != "100" {
+		t.Errorf("unexpected pages requested: %q", skips)
+	}
+	if len(jobs) != leverPageSize+3 {
+		t.Errorf("expected %d jobs, got %d", leverPageSize+3, len(jobs))
+	}
+}
+
+func TestLeverScraper_UnknownCompany(t *testing.T) {
+	s := newTestLeverScraper(t, func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"ok":false,"error":"Document not found"}`, http.StatusNotFound)
+	})
+
+	if _, err := collect(t, s, model.SearchParams{}); err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Errorf("expected a not found error, got %v", err)
+	}
+}
+
+func TestLeverCompany(t *testing.T) {
+	tests := []struct {
+		url  string
+		want string
+	}{
+		{"https://jobs.lever.co/acme", "acme"},
+		{"https://jobs.lever.co/acme/5ac21346", "acme"},
+		{"https://api.lever.co/v0/postings/acme?mode=json", "acme"},
+		{"https://boards.greenhouse.io/acme", ""},
+	}
+	for _, tt := range tests {
+		if got := LeverCompany(tt.url); got != tt.want {
+			t.Errorf("LeverCompany(%q) = %q, want %q", tt.url, got, tt.want)
+		}
+	}
+}
+
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
@@ -74,11 +74,7 @@ func (s *LinkedInScraper) Scrape(ctx context.Context, params model.SearchParams,
 		}
 
 		for _, job := range pageJobs {
-			select {
-			case jobs <- job:
-			case <-ctx.Done():
-				return ctx.Err()
-			}
+			jobs <- job
 		}
 
 		s.Logger.Printf("[linkedin] page %d: found %d jobs", page, len(pageJobs))
@@ -117,7 +113,7 @@ func (s *LinkedInScraper) scrapePage(ctx context.Context, params model.SearchPar
 
 	// Check robots.txt
 	if !s.Robots.IsAllowed(ctx, searchURL) {
-		return nil, false, fmt.Errorf("robots.txt disallows scraping %s", searchURL)
+		return nil, false, httpclient.Permanent(fmt.Errorf("robots.txt disallows scraping %s", searchURL))
 	}
 
 	headers := map[string]string{
@@ -133,7 +129,7 @@ func (s *LinkedInScraper) scrapePage(ctx context.Context, params model.SearchPar
 
 	jobs, err := parseLinkedInHTML(body)
 	if err != nil {
-		return nil, false, fmt.Errorf("parse HTML: %w", err)
+		return nil, false, httpclient.Permanent(fmt.Errorf("parse HTML: %w", err))
 	}
 
 	hasMore := len(jobs) >= params.PageSize
@@ -0,0 +1,54 @@
+package scraper
+
+import (
+	"strings"
+
+	"github.com/learnbot/job-aggregator/internal/model"
+	"github.com/learnbot/shared/geo"
+)
+
+// LocationText returns the text a job's location is normalised from: the
+// raw location, or the city, state and country joined when the scraper
+// found no raw location.
+func LocationText(job *model.ScrapedJob) string {
+	if raw := strings.TrimSpace(job.LocationRaw); raw != "" {
+		return raw
+	}
+	var parts []string
+	for _, p := range []string{job.LocationCity, job.LocationState, job.LocationCountry} {
+		if p = strings.TrimSpace(p); p != "" {
+			parts = append(parts, p)
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// ApplyLocation sets the city, state and country of job from loc, the
+// parse of its location text; the country becomes an ISO code. A remote or
+// hybrid arrangement in the text replaces a location type that was
+// unknown or only inferred as on-site. For the zero Location, job is left
+// as the scraper filled it in. The raw location is always kept.
+func ApplyLocation(job *model.ScrapedJob, loc geo.Location) {
+	if loc.IsZero() {
+		return
+	}
+	job.LocationCity, job.LocationState = loc.City, loc.Region
+	if loc.Country != "" {
+		job.LocationCountry = loc.Country
+	} else {
+		// "Remote" alone says nothing of the country the scraper found.
+		job.LocationCountry = geo.CountryCode(job.LocationCountry)
+	}
+
+	switch job.LocationType {
+	case "", model.LocationUnknown, model.LocationOnSite:
+		switch {
+		case loc.Hybrid:
+			job.LocationType = model.LocationHybrid
+		case loc.Remote:
+			job.LocationType = model.LocationRemote
+		case job.LocationType == "" || job.LocationType == model.LocationUnknown:
+			job.LocationType = model.LocationOnSite
+		}
+	}
+}
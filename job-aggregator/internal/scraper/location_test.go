@@ -0,0 +1,79 @@
+package scraper
+
+import (
+	"testing"
+
+	"github.com/learnbot/job-aggregator/internal/model"
+	"github.com/learnbot/shared/geo"
+)
+
+func TestLocationText(t *testing.T) {
+	if got := LocationText(&model.ScrapedJob{LocationRaw: " Jakarta, ID ", LocationCity: "x"}); got != "Jakarta, ID" {
+		t.Errorf("with a raw location: %q", got)
+	}
+	if got := LocationText(&model.ScrapedJob{LocationCity: "Austin", LocationCountry: "United States"}); got != "Austin, United States" {
+		t.Errorf("from the structured fields: %q", got)
+	}
+	if got := LocationText(&model.ScrapedJob{}); got != "" {
+		t.Errorf("without a location: %q", got)
+	}
+}
+
+func TestApplyLocation(t *testing.T) {
+	tests := []struct {
+		name    string
+		job     model.ScrapedJob
+		loc     geo.Location
+		want    [3]string // city, state, country
+		wantTyp model.WorkLocationType
+	}{
+		{
+			name:    "replaces the naive split",
+			job:     model.ScrapedJob{LocationRaw: "SF Bay Area", LocationCity: "SF Bay Area", LocationType: model.LocationOnSite},
+			loc:     geo.Parse("SF Bay Area"),
+			want:    [3]string{"San Francisco", "CA", "US"},
+			wantTyp: model.LocationOnSite,
+		},
+		{
+			name:    "remote text overrides an inferred on-site",
+			job:     model.ScrapedJob{LocationRaw: "Remote - EMEA", LocationCity: "Remote - EMEA", LocationType: model.LocationOnSite},
+			loc:     geo.Parse("Remote - EMEA"),
+			want:    [3]string{"", "EMEA", ""},
+			wantTyp: model.LocationRemote,
+		},
+		{
+			name:    "explicit hybrid is kept",
+			job:     model.ScrapedJob{LocationRaw: "Remote", LocationType: model.LocationHybrid},
+			loc:     geo.Parse("Remote"),
+			want:    [3]string{"", "", ""},
+			wantTyp: model.LocationHybrid,
+		},
+		{
+			name:    "scraper country kept as a code",
+			job:     model.ScrapedJob{LocationRaw: "Remote", LocationCountry: "United States", LocationType: model.LocationUnknown},
+			loc:     geo.Parse("Remote"),
+			want:    [3]string{"", "", "US"},
+			wantTyp: model.LocationRemote,
+		},
+		{
+			name:    "unknown location left alone",
+			job:     model.ScrapedJob{LocationRaw: "Atlantis", LocationCity: "Atlantis", LocationType: model.LocationOnSite},
+			loc:     geo.Parse("Atlantis"),
+			want:    [3]string{"Atlantis", "", ""},
+			wantTyp: model.LocationOnSite,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			job := tt.job
+			ApplyLocation(&job, tt.loc)
+			got := [3]string{job.LocationCity, job.LocationState, job.LocationCountry}
+			if got != tt.want || job.LocationType != tt.wantTyp {
+				t.Errorf("got %v %s, want %v %s", got, job.LocationType, tt.want, tt.wantTyp)
+			}
+			if job.LocationRaw != tt.job.LocationRaw {
+				t.Errorf("LocationRaw changed to %q", job.LocationRaw)
+			}
+		})
+	}
+}
@@ -0,0 +1,367 @@
+package scraper
+
+import (
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/learnbot/job-aggregator/internal/model"
+)
+
+// Salary is a salary found in free text. Amounts are in Currency per
+// Period, as stated; they are not converted to yearly amounts.
+type Salary struct {
+	// Min and Max bound the range. A single amount sets both, "up to"
+	// sets only Max and "from" or a trailing "+" only Min.
+	Min *int
+	Max *int
+	// Currency is an ISO 4217 code.
+	Currency string
+	// Period is empty when the text does not say.
+	Period model.SalaryPeriod
+	// Raw is the matched text.
+	Raw string
+}
+
+// salaryCurrencies maps currency symbols and codes, upper-cased, to ISO
+// 4217 codes. A bare "$" is taken to be US dollars.
+var salaryCurrencies = map[string]string{
+	"$": "USD", "US$": "USD", "USD": "USD",
+	"C$": "CAD", "CA$": "CAD", "CAD": "CAD",
+	"A$": "AUD", "AU$": "AUD", "AUD": "AUD",
+	"S$": "SGD", "SGD": "SGD",
+	"£": "GBP", "GBP": "GBP",
+	"€": "EUR", "EUR": "EUR",
+	"¥": "JPY", "JPY": "JPY",
+	"₹": "INR", "RS": "INR", "RS.": "INR", "INR": "INR",
+	"RP": "IDR", "RP.": "IDR", "IDR": "IDR",
+	"CHF": "CHF",
+	"RM":  "MYR", "MYR": "MYR",
+	"₱": "PHP", "PHP": "PHP",
+}
+
+const (
+	salaryCurrencyPattern = `US\$|USD|CA\$|C\$|CAD|AU\$|A\$|AUD|S\$|SGD|\$|£|GBP|€|EUR|¥|JPY|₹|INR|Rs\.?|Rp\.?|IDR|CHF|RM|MYR|₱|PHP`
+	// Thousands groups use , . or a (narrow) no-break space, or Indian
+	// lakh grouping as in 12,00,000; decimals have one or two digits.
+	salaryNumberPattern = `(\d{1,2}(?:,\d{2})+,\d{3}|\d{1,3}(?:[.,\x{a0}\x{202f}]\d{3})+(?:[.,]\d{1,2})?|\d+(?:[.,]\d{1,2})?)`
+	salarySuffixPattern = `(?:\s?(million|mn|m|k|juta|jt|ribu|rb|lakhs?|lacs?|lpa)\b)?`
+	salarySidePattern   = `(?:(` + salaryCurrencyPattern + `)\s?)?` + salaryNumberPattern + salarySuffixPattern +
+		`(?:\s?(` + salaryCurrencyPattern + `))?`
+)
+
+var (
+	salaryAmountRe = regexp.MustCompile(`(?i)` + salarySidePattern +
+		`(?:\s*(?:-|–|—|~|to|sampai|hingga|s/d)\s*` + salarySidePattern + `)?`)
+
+	// salaryPeriodAfterRe matches a period right after an amount, as in
+	// "/hr", "per annum", "a year" or "45,000pa".
+	salaryPeriodAfterRe = regexp.MustCompile(`(?i)^\s*\(?\s*(?:/|per\b|a\b|an\b|each\b)?\s*` +
+		`(?:(hours?|hrs?|hourly|jam)\b|(days?|daily|hari)\b|(weeks?|weekly|wks?|minggu)\b|` +
+		`(months?|monthly|mos?|mths?|bulan|bln)\b|(years?|yearly|yrs?|annum|annually|annual|pa|tahun|thn)\b|(p\.a\.?))`)
+	// salaryPeriodBeforeRe matches a period stated before an amount, as in
+	// "Hourly rate: $25".
+	salaryPeriodBeforeRe = regexp.MustCompile(`(?i)\b(?:(hourly)|(daily)|(weekly)|(monthly)|(annual|yearly))\b`)
+
+	// salaryContextRe marks text before an amount in a job description as
+	// being about pay, so that "$5M in funding" is not taken for a salary.
+	salaryContextRe = regexp.MustCompile(`(?i)\b(?:salary|salaries|pay|paid|compensation|wages?|rate|ote|base|range|earn|earnings|stipend|remuneration|package|gaji|upah)\b`)
+
+	// salaryTrailingDigitsRe matches a number that goes on past a match,
+	// as in "120'000", which is not understood.
+	salaryTrailingDigitsRe = regexp.MustCompile(`^['’.,]\d`)
+
+	salaryUpToRe = regexp.MustCompile(`(?i)\b(?:up\s?to|max(?:imum)?|hingga|sampai)\s*:?\s*$`)
+	salaryFromRe = regexp.MustCompile(`(?i)\b(?:from|starting\s+(?:at|from)|at\s+least|min(?:imum)?|mulai)\s*:?\s*$`)
+)
+
+// salaryPeriods are the periods of the capture groups of
+// salaryPeriodAfterRe and salaryPeriodBeforeRe, in order.
+var salaryPeriods = []model.SalaryPeriod{
+	model.SalaryPerHour, model.SalaryPerDay, model.SalaryPerWeek, model.SalaryPerMonth, model.SalaryPerYear, model.SalaryPerYear,
+}
+
+// salaryContextWindow is how far before an amount the period and pay
+// keywords are looked for.
+const salaryContextWindow = 40
+
+// ExtractSalary finds the salary in a short salary string such as
+// "$120k–$150k" or "Rp 15.000.000 - 20.000.000 / bulan". Amounts need a
+// currency symbol or code. It reports false when there is no salary, or
+// when the text states several different salaries or an inconsistent range,
+// such as one with two currencies or a minimum above the maximum.
+func ExtractSalary(text string) (Salary, bool) {
+	return extractSalary(text, false)
+}
+
+// NormalizeSalary sets the structured salary fields of job from its salary
+// text. When the scraper found no salary text and no salary, the
+// description is searched instead, where an amount only counts next to a
+// pay keyword or a period. The fields are left alone when nothing
+// unambiguous is found.
+func NormalizeSalary(job *model.ScrapedJob) {
+	var s Salary
+	var ok bool
+	switch {
+	case strings.TrimSpace(job.SalaryRaw) != "":
+		s, ok = extractSalary(job.SalaryRaw, false)
+	case job.SalaryMin == nil && job.SalaryMax == nil:
+		if s, ok = extractSalary(job.Description, true); ok {
+			job.SalaryRaw = s.Raw
+		}
+	}
+	if !ok {
+		return
+	}
+	job.SalaryMin, job.SalaryMax = s.Min, s.Max
+	job.SalaryCurrency = s.Currency
+	job.SalaryPeriod = s.Period
+}
+
+// extractSalary implements ExtractSalary. With needContext, every amount
+// must have a period or a pay keyword shortly before it.
+func extractSalary(text string, needContext bool) (Salary, bool) {
+	var found *Salary
+	for _, loc := range salaryAmountRe.FindAllStringSubmatchIndex(text, -1) {
+		start, end := loc[0], loc[1]
+		// The amount must not continue a word or a number.
+		if r, _ := utf8.DecodeLastRuneInString(text[:start]); unicode.IsLetter(r) || unicode.IsDigit(r) {
+			continue
+		}
+		if salaryTrailingDigitsRe.MatchString(text[end:]) {
+			continue
+		}
+		group := func(i int) string {
+			if loc[2*i] < 0 {
+				return ""
+			}
+			return text[loc[2*i]:loc[2*i+1]]
+		}
+
+		before := text[max(0, start-salaryContextWindow):start]
+		s, valid := salaryFromMatch(group, text[end:], before)
+		if !valid {
+			// An amount with a currency that does not make sense.
+			return Salary{}, false
+		}
+		if s == nil {
+			continue
+		}
+		if needContext && s.Period == "" && !salaryContextRe.MatchString(before) {
+			continue
+		}
+		s.Raw = strings.TrimSpace(text[start:end])
+
+		if found == nil {
+			found = s
+			continue
+		}
+		if !sameAmounts(found, s) {
+			return Salary{}, false
+		}
+		switch {
+		case found.Period == "":
+			found.Period = s.Period
+		case s.Period != "" && s.Period != found.Period:
+			return Salary{}, false
+		}
+	}
+	if found == nil {
+		return Salary{}, false
+	}
+	return *found, true
+}
+
+// salaryFromMatch builds the salary of one salaryAmountRe match, given
+// its groups and the text after and before it. It returns nil without a
+// currency, which means the numbers are not an amount, and valid false for
+// a range that contradicts itself.
+func salaryFromMatch(group func(int) string, after, before string) (s *Salary, valid bool) {
+	cur1 := salaryCurrency(group(1), group(4))
+	cur2 := salaryCurrency(group(5), group(8))
+	currency := cur1
+	switch {
+	case currency == "":
+		currency = cur2
+	case cur2 != "" && cur2 != cur1:
+		return nil, false
+	}
+	if currency == "" {
+		return nil, true
+	}
+
+	lo, ok := parseSalaryAmount(group(2))
+	if !ok {
+		return nil, false
+	}
+	loScale, hiScale := salaryScale(group(3)), salaryScale(group(7))
+	hi := lo
+	isRange := group(6) != ""
+	if isRange {
+		if hi, ok = parseSalaryAmount(group(6)); !ok {
+			return nil, false
+		}
+		// "$120-150k" and "$120k-150" share the suffix.
+		if loScale == 1 && hiScale > 1 && lo < 1000 {
+			loScale = hiScale
+		}
+		if hiScale == 1 && loScale > 1 && hi < 1000 {
+			hiScale = loScale
+		}
+	} else {
+		hiScale = loScale
+	}
+	lo, hi = lo*loScale, hi*hiScale
+	if lo <= 0 || hi > math.MaxInt32 || lo > hi {
+		return nil, false
+	}
+
+	s = &Salary{Currency: currency, Period: salaryPeriod(after, before)}
+	if s.Period == "" && (strings.EqualFold(group(3), "lpa") || strings.EqualFold(group(7), "lpa")) {
+		// Lakhs per annum.
+		s.Period = model.SalaryPerYear
+	}
+	minVal, maxVal := int(math.Round(lo)), int(math.Round(hi))
+	switch {
+	case isRange:
+		s.Min, s.Max = &minVal, &maxVal
+	case salaryUpToRe.MatchString(before):
+		s.Max = &maxVal
+	case salaryFromRe.MatchString(before) || strings.HasPrefix(after, "+"):
+		s.Min = &minVal
+	default:
+		s.Min, s.Max = &minVal, &maxVal
+	}
+	return s, true
+}
+
+// salaryCurrency returns the currency code of a prefix or suffix symbol,
+// which must agree when both are given.
+func salaryCurrency(prefix, suffix string) string {
+	p := salaryCurrencies[strings.ToUpper(prefix)]
+	q := salaryCurrencies[strings.ToUpper(suffix)]
+	if p != "" && q != "" && p != q {
+		return ""
+	}
+	if p != "" {
+		return p
+	}
+	return q
+}
+
+// salaryScale returns the multiplier of an amount suffix.
+func salaryScale(suffix string) float64 {
+	switch strings.ToLower(suffix) {
+	case "k", "rb", "ribu":
+		return 1e3
+	case "lakh", "lakhs", "lac", "lacs", "lpa":
+		return 1e5
+	case "m", "mn", "million", "juta", "jt":
+		return 1e6
+	}
+	return 1
+}
+
+// salaryPeriod returns the period stated right after the amount, or else
+// shortly before it.
+func salaryPeriod(after, before string) model.SalaryPeriod {
+	after = strings.TrimPrefix(after, "+")
+	if m := salaryPeriodAfterRe.FindStringSubmatch(after); m != nil {
+		for i, period := range salaryPeriods {
+			if m[i+1] != "" {
+				return period
+			}
+		}
+	}
+	if ms := salaryPeriodBeforeRe.FindAllStringSubmatch(before, -1); len(ms) > 0 {
+		m := ms[len(ms)-1]
+		for i, period := range salaryPeriods[:5] {
+			if m[i+1] != "" {
+				return period
+			}
+		}
+	}
+	return ""
+}
+
+// parseSalaryAmount parses a number written with thousands separators,
+// which may be commas, dots or no-break spaces, and an optional decimal
+// part of one or two digits: "120,000", "15.000.000", "1.5", "1.234,56",
+// "12,00,000".
+func parseSalaryAmount(s string) (float64, bool) {
+	s = strings.NewReplacer("\u00a0", " ", "\u202f", " ").Replace(s)
+	isSep := func(r rune) bool { return r == ',' || r == '.' || r == ' ' }
+	groups := strings.FieldsFunc(s, isSep)
+	var seps []rune
+	for _, r := range s {
+		if isSep(r) {
+			seps = append(seps, r)
+		}
+	}
+	if len(seps) == 0 {
+		v, err := strconv.ParseFloat(s, 64)
+		return v, err == nil
+	}
+	if len(groups) != len(seps)+1 {
+		return 0, false
+	}
+
+	if indianGrouping(groups, seps) {
+		v, err := strconv.ParseFloat(strings.Join(groups, ""), 64)
+		return v, err == nil
+	}
+
+	// A last group of one or two digits is the decimal part.
+	intGroups, frac := groups, ""
+	thousands := seps
+	if last := groups[len(groups)-1]; len(last) < 3 {
+		if seps[len(seps)-1] == ' ' {
+			return 0, false
+		}
+		intGroups, frac = groups[:len(groups)-1], last
+		thousands = seps[:len(seps)-1]
+	}
+	for i, g := range intGroups[1:] {
+		if len(g) != 3 || thousands[i] != thousands[0] {
+			return 0, false
+		}
+	}
+	if frac != "" && len(thousands) > 0 && thousands[0] == seps[len(seps)-1] {
+		return 0, false
+	}
+
+	v, err := strconv.ParseFloat(strings.Join(intGroups, "")+"."+frac, 64)
+	if frac == "" {
+		v, err = strconv.ParseFloat(strings.Join(intGroups, ""), 64)
+	}
+	return v, err == nil
+}
+
+// indianGrouping reports whether groups split by seps are written in lakh
+// grouping: comma-separated pairs of digits ending in three digits.
+func indianGrouping(groups []string, seps []rune) bool {
+	if len(groups) < 3 || len(groups[0]) > 2 || len(groups[len(groups)-1]) != 3 {
+		return false
+	}
+	for _, g := range groups[1 : len(groups)-1] {
+		if len(g) != 2 {
+			return false
+		}
+	}
+	for _, r := range seps {
+		if r != ',' {
+			return false
+		}
+	}
+	return true
+}
+
+// sameAmounts reports whether two salaries have the same range and
+// currency.
+func sameAmounts(a, b *Salary) bool {
+	eq := func(x, y *int) bool { return (x == nil) == (y == nil) && (x == nil || *x == *y) }
+	return a.Currency == b.Currency && eq(a.Min, b.Min) && eq(a.Max, b.Max)
+}
@@ -0,0 +1,188 @@
+package scraper
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/learnbot/job-aggregator/internal/model"
+)
+
+// formatSalary renders an extracted salary as "min-max CUR/period", with
+// "_" for a missing bound.
+func formatSalary(s Salary, ok bool) string {
+	if !ok {
+		return "none"
+	}
+	bound := func(v *int) string {
+		if v == nil {
+			return "_"
+		}
+		return fmt.Sprint(*v)
+	}
+	out := bound(s.Min) + "-" + bound(s.Max) + " " + s.Currency
+	if s.Period != "" {
+		out += "/" + string(s.Period)
+	}
+	return out
+}
+
+func TestExtractSalary(t *testing.T) {
+	tests := []struct {
+		text string
+		want string
+	}{
+		// Ranges and suffixes.
+		{"$100,000 - $150,000", "100000-150000 USD"},
+		{"$120k–$150k", "120000-150000 USD"},
+		{"$120-150k", "120000-150000 USD"},
+		{"$120K to $150K per year", "120000-150000 USD/year"},
+		{"USD 90,000 — 110,000 annually", "90000-110000 USD/year"},
+		{"US$1.2M - US$1.5M", "1200000-1500000 USD"},
+		{"$1.5m", "1500000-1500000 USD"},
+		{"$95,000", "95000-95000 USD"},
+
+		// Other currencies.
+		{"£45,000 - £55,000 per annum", "45000-55000 GBP/year"},
+		{"£45,000pa", "45000-45000 GBP/year"},
+		{"€60.000 - €80.000", "60000-80000 EUR"},
+		{"45.000 € - 55.000 €", "45000-55000 EUR"},
+		{"EUR 70k-90k p.a.", "70000-90000 EUR/year"},
+		{"C$85,000 - C$100,000", "85000-100000 CAD"},
+		{"A$130k + super", "130000-130000 AUD"},
+		{"S$6,000 - S$8,000 / month", "6000-8000 SGD/month"},
+		{"CHF 120'000", "none"},
+		{"CHF 120,000 - 140,000 per year", "120000-140000 CHF/year"},
+		{"₹12,00,000 per annum", "1200000-1200000 INR/year"},
+		{"INR 15 - 20 LPA", "1500000-2000000 INR/year"},
+		{"Rs. 50,000 per month", "50000-50000 INR/month"},
+		{"Rp 15.000.000 - Rp 20.000.000 / bulan", "15000000-20000000 IDR/month"},
+		{"IDR 8 juta - 12 juta per bulan", "8000000-12000000 IDR/month"},
+		{"Rp 500rb/hari", "500000-500000 IDR/day"},
+		{"Gaji Rp 10 jt s/d 15 jt", "10000000-15000000 IDR"},
+		{"RM 5,000 - RM 7,000 monthly", "5000-7000 MYR/month"},
+		{"¥6,000,000 - ¥8,000,000", "6000000-8000000 JPY"},
+		{"₱40,000 - ₱60,000 a month", "40000-60000 PHP/month"},
+
+		// Periods.
+		{"$25 - $35/hr", "25-35 USD/hour"},
+		{"$45.50 per hour", "46-46 USD/hour"},
+		{"Hourly rate: $60-$80", "60-80 USD/hour"},
+		{"$500 a day", "500-500 USD/day"},
+		{"$2,000/week", "2000-2000 USD/week"},
+		{"Monthly stipend of $3,000", "3000-3000 USD/month"},
+
+		// Open ranges.
+		{"Up to $150,000", "_-150000 USD"},
+		{"From £40k", "40000-_ GBP"},
+		{"$200k+ base", "200000-_ USD"},
+
+		// Nothing to extract.
+		{"", "none"},
+		{"Competitive", "none"},
+		{"120,000 - 150,000", "none"},
+		{"DOE", "none"},
+
+		// Ambiguous or conflicting.
+		{"$150k - $120k", "none"},
+		{"£50,000 - $70,000", "none"},
+		{"$100k-$120k (L4) or $140k-$160k (L5)", "none"},
+		{"$5,000,000,000", "none"},
+		{"$0 - $0", "none"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			if got := formatSalary(ExtractSalary(tt.text)); got != tt.want {
+				t.Errorf("ExtractSalary(%q) = %s, want %s", tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExtractSalary_RepeatedSalary(t *testing.T) {
+	// The same range stated twice, once with a period, is not ambiguous.
+	text := "Salary: $120,000 - $150,000. The range for this role is $120,000 - $150,000 per year."
+	if got := formatSalary(ExtractSalary(text)); got != "120000-150000 USD/year" {
+		t.Errorf("got %s", got)
+	}
+}
+
+func TestParseSalaryAmount(t *testing.T) {
+	tests := []struct {
+		in   string
+		want float64
+		ok   bool
+	}{
+		{"120000", 120000, true},
+		{"120,000", 120000, true},
+		{"15.000.000", 15000000, true},
+		{"1.234,56", 1234.56, true},
+		{"1,234.56", 1234.56, true},
+		{"1.5", 1.5, true},
+		{"45\u00a0000", 45000, true},
+		{"12,00,000", 1200000, true},
+		{"1,234,56", 0, false},
+		{"1.234,567", 0, false},
+	}
+	for _, tt := range tests {
+		got, ok := parseSalaryAmount(tt.in)
+		if ok != tt.ok || (ok && got != tt.want) {
+			t.Errorf("parseSalaryAmount(%q) = %v, %v; want %v, %v", tt.in, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestNormalizeSalary(t *testing.T) {
+	intPtr := func(v int) *int { return &v }
+
+	tests := []struct {
+		name string
+		job  model.ScrapedJob
+		want string
+	}{
+		{
+			name: "salary text",
+			job:  model.ScrapedJob{SalaryRaw: "£50,000 - £70,000", SalaryCurrency: "GBP"},
+			want: "50000-70000 GBP",
+		},
+		{
+			name: "description with a pay keyword",
+			job:  model.ScrapedJob{Description: "Great team. The base salary range is $130,000 - $160,000, plus equity."},
+			want: "130000-160000 USD",
+		},
+		{
+			name: "description with a period",
+			job:  model.ScrapedJob{Description: "Contract role paying well: €600 per day, remote."},
+			want: "600-600 EUR/day",
+		},
+		{
+			name: "description amount without context",
+			job:  model.ScrapedJob{Description: "We raised $20M from top investors last year and are hiring."},
+			want: "none",
+		},
+		{
+			name: "description with two salaries",
+			job:  model.ScrapedJob{Description: "Salary: $90k-$110k in Austin, salary: $120k-$140k in New York."},
+			want: "none",
+		},
+		{
+			name: "structured salary is kept",
+			job:  model.ScrapedJob{SalaryMin: intPtr(70000), SalaryCurrency: "EUR", Description: "Salary: $1,000,000"},
+			want: "70000-_ EUR",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			job := tt.job
+			NormalizeSalary(&job)
+			got := "none"
+			if job.SalaryMin != nil || job.SalaryMax != nil {
+				got = formatSalary(Salary{Min: job.SalaryMin, Max: job.SalaryMax, Currency: job.SalaryCurrency, Period: job.SalaryPeriod}, true)
+			}
+			if got != tt.want {
+				t.Errorf("NormalizeSalary: got %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
@@ -22,6 +22,14 @@ type Scraper interface {
 
 	// Scrape fetches job postings matching the given search parameters.
 	// It sends scraped jobs to the jobs channel and returns when done.
+	//
+	// Cancellation of ctx is checked between pages: a page that has been
+	// fetched is sent in full, and the caller keeps receiving from jobs
+	// until Scrape returns, so a shutdown never stores half a page.
+	//
+	// Errors from the HTTP client are returned wrapped, and failures found
+	// after a response arrived are marked with httpclient.Permanent, so the
+	// caller can tell permanent failures from exhausted retries.
 	Scrape(ctx context.Context, params model.SearchParams, jobs chan<- *model.ScrapedJob) error
 
 	// Name returns a human-readable name for the scraper.
@@ -215,8 +223,19 @@ func parseSalaryValue(s, context string) int {
 	return int(val)
 }
 
+// Section headers used to classify skills mentioned in a job description.
+var (
+	requiredSkillHeaders   = []string{"required", "must have", "requirements"}
+	preferredSkillHeaders  = []string{"preferred"}
+	niceToHaveSkillHeaders = []string{"nice to have", "nice-to-have", "bonus points for", "bonus", "plus"}
+)
+
 // ExtractSkillsFromText extracts skill keywords from job description text.
-func ExtractSkillsFromText(text string) (required, preferred []string) {
+// Skills under a required section are required, skills under a
+// "nice to have" or "bonus points for" section are nice-to-have, skills
+// under a preferred section are preferred, and anything else is treated
+// as required.
+func ExtractSkillsFromText(text string) (required, preferred, niceToHave []string) {
 	techSkills := []string{
 		"go", "golang", "python", "java", "javascript", "typescript", "rust",
 		"c++", "c#", "ruby", "php", "swift", "kotlin", "scala",
@@ -231,8 +250,12 @@ func ExtractSkillsFromText(text string) (required, preferred []string) {
 	lower := strings.ToLower(text)
 	seen := map[string]bool{}
 
-	requiredSection := extractSection(text, []string{"required", "must have", "requirements"})
-	preferredSection := extractSection(text, []string{"preferred", "nice to have", "bonus", "plus"})
+	requiredSection := strings.ToLower(extractSection(text, requiredSkillHeaders,
+		append(append([]string{}, preferredSkillHeaders...), niceToHaveSkillHeaders...)))
+	preferredSection := strings.ToLower(extractSection(text, preferredSkillHeaders,
+		append(append([]string{}, requiredSkillHeaders...), niceToHaveSkillHeaders...)))
+	niceToHaveSection := strings.ToLower(extractSection(text, niceToHaveSkillHeaders,
+		append(append([]string{}, requiredSkillHeaders...), preferredSkillHeaders...)))
 
 	for _, skill := range techSkills {
 		if seen[skill] {
@@ -240,21 +263,25 @@ func ExtractSkillsFromText(text string) (required, preferred []string) {
 		}
 		if strings.Contains(lower, skill) {
 			seen[skill] = true
-			if requiredSection != "" && strings.Contains(strings.ToLower(requiredSection), skill) {
+			switch {
+			case requiredSection != "" && strings.Contains(requiredSection, skill):
 				required = append(required, skill)
-			} else if preferredSection != "" && strings.Contains(strings.ToLower(preferredSection), skill) {
+			case niceToHaveSection != "" && strings.Contains(niceToHaveSection, skill):
+				niceToHave = append(niceToHave, skill)
+			case preferredSection != "" && strings.Contains(preferredSection, skill):
 				preferred = append(preferred, skill)
-			} else {
+			default:
 				required = append(required, skill)
 			}
 		}
 	}
 
-	return required, preferred
+	return required, preferred, niceToHave
 }
 
-// extractSection finds text after a section header keyword.
-func extractSection(text string, headers []string) string {
+// extractSection finds text after a section header keyword. The section
+// runs for up to 500 characters and ends early at the first stop header.
+func extractSection(text string, headers, stops []string) string {
 	lower := strings.ToLower(text)
 	for _, h := range headers {
 		idx := strings.Index(lower, h)
@@ -263,6 +290,11 @@ func extractSection(text string, headers []string) string {
 			if end > len(text) {
 				end = len(text)
 			}
+			for _, stop := range stops {
+				if i := strings.Index(lower[idx+len(h):end], stop); i >= 0 {
+					end = idx + len(h) + i
+				}
+			}
 			return text[idx:end]
 		}
 	}
@@ -1,6 +1,7 @@
 package scraper
 
 import (
+	"reflect"
 	"testing"
 	"time"
 
@@ -127,7 +128,7 @@ Preferred:
 - Redis
 - Kafka`
 
-	required, preferred := ExtractSkillsFromText(text)
+	required, preferred, _ := ExtractSkillsFromText(text)
 
 	if len(required) == 0 {
 		t.Error("expected required skills to be extracted")
@@ -148,6 +149,29 @@ Preferred:
 	_ = preferred // preferred may or may not be populated depending on text parsing
 }
 
+func TestExtractSkillsFromText_NiceToHave(t *testing.T) {
+	text := `Requirements:
+- Python and Terraform
+
+Preferred:
+- Docker
+
+Bonus points for:
+- Kafka or GraphQL experience`
+
+	required, preferred, niceToHave := ExtractSkillsFromText(text)
+
+	if !reflect.DeepEqual(required, []string{"python", "terraform"}) {
+		t.Errorf("required = %v, want [python terraform]", required)
+	}
+	if !reflect.DeepEqual(preferred, []string{"docker"}) {
+		t.Errorf("preferred = %v, want [docker]", preferred)
+	}
+	if !reflect.DeepEqual(niceToHave, []string{"graphql", "kafka"}) {
+		t.Errorf("niceToHave = %v, want [graphql kafka]", niceToHave)
+	}
+}
+
 func TestCleanText(t *testing.T) {
 	tests := []struct {
 		input string
@@ -0,0 +1,209 @@
+package scraper
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/learnbot/job-aggregator/internal/httpclient"
+	"github.com/learnbot/job-aggregator/internal/model"
+	"golang.org/x/net/html"
+)
+
+// defaultMaxDetailPages bounds the job detail pages fetched from a sitemap
+// when selectors.max_detail_pages is not set.
+const defaultMaxDetailPages = 50
+
+// maxNestedSitemaps bounds the child sitemaps read from a sitemap index.
+const maxNestedSitemaps = 10
+
+// sitemapDoc is a sitemap or a sitemap index (sitemaps.org protocol).
+type sitemapDoc struct {
+	XMLName  xml.Name
+	URLs     []sitemapLoc `xml:"url"`
+	Sitemaps []sitemapLoc `xml:"sitemap"`
+}
+
+type sitemapLoc struct {
+	Loc string `xml:"loc"`
+}
+
+// sitemapURL returns the configured sitemap URL, or /sitemap.xml on the
+// career page's host.
+func (s *CareerPageScraper) sitemapURL(selectors CareerPageSelectors) (string, error) {
+	if selectors.SitemapURL != "" {
+		return selectors.SitemapURL, nil
+	}
+	u, err := url.Parse(s.page.CareerPageURL)
+	if err != nil || u.Host == "" {
+		return "", fmt.Errorf("invalid career page URL %q", s.page.CareerPageURL)
+	}
+	return u.Scheme + "://" + u.Host + "/sitemap.xml", nil
+}
+
+// scrapeSitemap reads the site's sitemap and scrapes each URL whose path
+// matches selectors.JobPathPattern as a job detail page. It returns the
+// number of jobs sent.
+func (s *CareerPageScraper) scrapeSitemap(ctx context.Context, selectors CareerPageSelectors, params model.SearchParams, jobs chan<- *model.ScrapedJob) (int, error) {
+	if selectors.JobPathPattern == "" {
+		return 0, httpclient.Permanent(fmt.Errorf("sitemap fallback needs job_path_pattern"))
+	}
+	pattern, err := regexp.Compile(selectors.JobPathPattern)
+	if err != nil {
+		return 0, httpclient.Permanent(fmt.Errorf("invalid job_path_pattern: %w", err))
+	}
+	sitemap, err := s.sitemapURL(selectors)
+	if err != nil {
+		return 0, httpclient.Permanent(err)
+	}
+
+	urls, err := s.sitemapJobURLs(ctx, sitemap, pattern)
+	if err != nil {
+		return 0, err
+	}
+	maxPages := selectors.MaxDetailPages
+	if maxPages <= 0 {
+		maxPages = defaultMaxDetailPages
+	}
+	if len(urls) > maxPages {
+		s.Logger.Printf("[career_page] %s: sitemap lists %d job pages, scraping the first %d", s.page.CompanyName, len(urls), maxPages)
+		urls = urls[:maxPages]
+	}
+
+	found := 0
+	for _, u := range urls {
+		if err := ctx.Err(); err != nil {
+			return found, err
+		}
+		if !s.Robots.IsAllowed(ctx, u) {
+			continue
+		}
+		body, err := s.Client.GetBody(ctx, u, nil)
+		if err != nil {
+			// One missing posting should not lose the rest.
+			s.Logger.Printf("[career_page] fetch job page %s: %v", u, err)
+			continue
+		}
+		job, err := s.parseJobDetailPage(body, u, selectors.Detail)
+		if err != nil {
+			s.Logger.Printf("[career_page] parse job page %s: %v", u, err)
+			continue
+		}
+		if job == nil || !titleMatches(job.Title, params.Query) {
+			continue
+		}
+		found++
+		jobs <- job
+	}
+
+	s.Logger.Printf("[career_page] %s: found %d jobs from %d sitemap job pages", s.page.CompanyName, found, len(urls))
+	return found, nil
+}
+
+// sitemapJobURLs returns the URLs in the sitemap at sitemapURL whose path
+// matches pattern, following the child sitemaps of a sitemap index one
+// level deep.
+func (s *CareerPageScraper) sitemapJobURLs(ctx context.Context, sitemapURL string, pattern *regexp.Regexp) ([]string, error) {
+	doc, err := s.fetchSitemap(ctx, sitemapURL)
+	if err != nil {
+		return nil, err
+	}
+
+	locs := doc.URLs
+	for i, child := range doc.Sitemaps {
+		if i == maxNestedSitemaps {
+			break
+		}
+		childDoc, err := s.fetchSitemap(ctx, strings.TrimSpace(child.Loc))
+		if err != nil {
+			s.Logger.Printf("[career_page] %v", err)
+			continue
+		}
+		locs = append(locs, childDoc.URLs...)
+	}
+
+	var urls []string
+	seen := map[string]bool{}
+	for _, loc := range locs {
+		raw := strings.TrimSpace(loc.Loc)
+		u, err := url.Parse(raw)
+		if err != nil || seen[raw] || !pattern.MatchString(u.Path) {
+			continue
+		}
+		seen[raw] = true
+		urls = append(urls, raw)
+	}
+	return urls, nil
+}
+
+// fetchSitemap fetches and decodes one sitemap.
+func (s *CareerPageScraper) fetchSitemap(ctx context.Context, sitemapURL string) (*sitemapDoc, error) {
+	body, err := s.Client.GetBody(ctx, sitemapURL, map[string]string{
+		"Accept": "application/xml, text/xml",
+	})
+	if err != nil {
+		return nil, fmt.Errorf("fetch sitemap %s: %w", sitemapURL, err)
+	}
+	var doc sitemapDoc
+	if err := xml.Unmarshal([]byte(body), &doc); err != nil {
+		return nil, httpclient.Permanent(fmt.Errorf("parse sitemap %s: %w", sitemapURL, err))
+	}
+	return &doc, nil
+}
+
+// parseJobDetailPage extracts the job on a detail page. JSON-LD JobPosting
+// data is preferred; otherwise the detail selectors are used, with the
+// first <h1> as the title when no title selector is set. It returns nil if
+// the page has no title.
+func (s *CareerPageScraper) parseJobDetailPage(body, pageURL string, selectors CareerPageDetailSelectors) (*model.ScrapedJob, error) {
+	doc, err := html.Parse(strings.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("parse HTML: %w", err)
+	}
+	for _, p := range extractJSONLDPostings(doc) {
+		if job := p.toScrapedJob(s.page.CompanyName, pageURL); job != nil {
+			return job, nil
+		}
+	}
+
+	first := func(selector string) string {
+		if nodes := findBySelector(doc, selector); len(nodes) > 0 {
+			return CleanText(extractText(nodes[0]))
+		}
+		return ""
+	}
+
+	titleSelector := selectors.Title
+	if titleSelector == "" {
+		titleSelector = "h1"
+	}
+	job := &model.ScrapedJob{
+		Source:         model.SourceCompanyCareerPage,
+		ExternalID:     pageURL,
+		CompanyName:    s.page.CompanyName,
+		Title:          first(titleSelector),
+		Description:    first(selectors.Description),
+		ApplicationURL: pageURL,
+		SalaryCurrency: "USD",
+	}
+	if job.Title == "" {
+		return nil, nil
+	}
+	if loc := first(selectors.Location); loc != "" {
+		job.LocationRaw = loc
+		parseLocation(loc, job)
+	}
+	if posted := first(selectors.PostedDate); posted != "" {
+		job.PostedAt = ParseRelativeDate(posted)
+	}
+
+	job.LocationType = ExtractLocationType(job.LocationRaw, job.Description)
+	job.EmploymentType = ExtractEmploymentType(job.Title+" "+first(selectors.EmploymentType), job.Description)
+	job.ExperienceLevel = ExtractExperienceLevel(job.Title, job.Description)
+	job.RequiredSkills, job.PreferredSkills, job.NiceToHaveSkills = ExtractSkillsFromText(job.Description)
+	job.RawData = map[string]interface{}{"extracted_from": "sitemap"}
+	return job, nil
+}
@@ -0,0 +1,642 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"modernc.org/sqlite"
+	sqlite3 "modernc.org/sqlite/lib"
+
+	"github.com/learnbot/job-aggregator/internal/model"
+	"github.com/learnbot/shared/geo"
+)
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Company resolution
+// ─────────────────────────────────────────────────────────────────────────────
+
+// ErrSelfMerge is returned when a company is merged into itself.
+var ErrSelfMerge = errors.New("cannot merge a company into itself")
+
+// companyPrefixes are legal-entity words dropped from the start of company
+// names, as in "PT Gojek Indonesia".
+var companyPrefixes = map[string]bool{"pt": true, "cv": true}
+
+// companyRegions are the region qualifiers, besides country names, that
+// subsidiaries add to the parent's name, as in "Google Asia Pacific".
+var companyRegions = map[string]bool{
+	"asia": true, "asia pacific": true, "apac": true, "emea": true, "europe": true,
+	"latam": true, "americas": true, "sea": true, "global": true, "international": true,
+	"worldwide": true, "uk": true, "usa": true,
+}
+
+// companyKey is the alias a company name is stored and looked up by: the
+// name normalised as for fuzzy deduplication, without leading "PT" or "CV"
+// or a trailing "Tbk". A name without ASCII letters or digits is only
+// lowercased.
+func companyKey(name string) string {
+	tokens := strings.Fields(dedupCompanyKey(name))
+	for len(tokens) > 1 && companyPrefixes[tokens[0]] {
+		tokens = tokens[1:]
+	}
+	for len(tokens) > 1 && (companySuffixes[tokens[len(tokens)-1]] || tokens[len(tokens)-1] == "tbk") {
+		tokens = tokens[:len(tokens)-1]
+	}
+	if len(tokens) == 0 {
+		return strings.Join(strings.Fields(strings.ToLower(name)), " ")
+	}
+	return strings.Join(tokens, " ")
+}
+
+// companyBaseKey strips trailing country and region qualifiers from a
+// company key, so that "google indonesia" and "google asia pacific" both
+// give "google". Country names shorter than four letters are kept, as
+// they are more often part of the name ("Scale AI").
+func companyBaseKey(key string) string {
+	tokens := strings.Fields(key)
+	for len(tokens) > 1 {
+		stripped := false
+		for n := min(3, len(tokens)-1); n >= 1; n-- {
+			tail := strings.Join(tokens[len(tokens)-n:], " ")
+			if companyRegions[tail] || (len(tail) >= 4 && geo.CountryCode(tail) != "") {
+				tokens, stripped = tokens[:len(tokens)-n], true
+				break
+			}
+		}
+		if !stripped {
+			break
+		}
+	}
+	return strings.Join(tokens, " ")
+}
+
+// secondLevelSuffixes are the labels of two-label public suffixes such as
+// co.id and com.sg.
+var secondLevelSuffixes = map[string]bool{
+	"co": true, "com": true, "net": true, "org": true, "ac": true, "go": true, "gov": true, "or": true,
+}
+
+// jobBoardDomains host postings for many employers, so they say nothing
+// about the company.
+var jobBoardDomains = map[string]bool{
+	"linkedin.com": true, "indeed.com": true, "glassdoor.com": true, "greenhouse.io": true,
+	"lever.co": true, "myworkdayjobs.com": true, "workday.com": true, "smartrecruiters.com": true,
+	"ashbyhq.com": true, "workable.com": true, "bamboohr.com": true, "recruitee.com": true,
+	"jobstreet.com": true, "jobstreet.co.id": true, "kalibrr.com": true, "glints.com": true,
+}
+
+// websiteDomain returns the registrable domain of a URL or host name, e.g.
+// "google.com" for "https://careers.google.com/jobs", or "" if it has none
+// or is a job board.
+func websiteDomain(raw string) string {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return ""
+	}
+	if !strings.Contains(raw, "://") {
+		raw = "https://" + raw
+	}
+	u, err := url.Parse(raw)
+	if err != nil {
+		return ""
+	}
+	host := strings.TrimSuffix(strings.ToLower(u.Hostname()), ".")
+	if !strings.Contains(host, ".") || net.ParseIP(host) != nil {
+		return ""
+	}
+	labels := strings.Split(host, ".")
+	n := 2
+	if len(labels) > 2 && len(labels[len(labels)-1]) == 2 && secondLevelSuffixes[labels[len(labels)-2]] {
+		n = 3
+	}
+	domain := strings.Join(labels[max(0, len(labels)-n):], ".")
+	if jobBoardDomains[domain] {
+		return ""
+	}
+	return domain
+}
+
+// companyWebsite returns the URL of the scraped employer's website: its
+// company URL, or for a career page posting the application URL.
+func companyWebsite(scraped *model.ScrapedJob) string {
+	if scraped.CompanyURL != "" {
+		return scraped.CompanyURL
+	}
+	if scraped.Source == model.SourceCompanyCareerPage {
+		return scraped.ApplicationURL
+	}
+	return ""
+}
+
+// resolveCompany implements ResolveCompany for both backends, filling in
+// the website domain and industry of a matched company that has none. It
+// returns nil for a name without a key.
+//
+// A name resolves to the company with its key as an alias, then to the
+// company with the website's domain, then to the company with the key
+// stripped of country and region qualifiers as an alias, the oldest first.
+// A domain or qualifier match adds the key as an alias.
+func resolveCompany(ctx context.Context, db *sql.DB, name, website, industry string, now time.Time) (*uuid.UUID, error) {
+	key := companyKey(name)
+	if key == "" {
+		return nil, nil
+	}
+	domain := websiteDomain(website)
+
+	var id uuid.UUID
+	var storedDomain, storedIndustry sql.NullString
+	var priority int
+	err := db.QueryRowContext(ctx, `
+		SELECT c.id, c.website_domain, c.industry,
+		       CASE WHEN EXISTS (SELECT 1 FROM company_aliases a WHERE a.company_id = c.id AND a.alias = $1) THEN 0
+		            WHEN c.website_domain = $3 THEN 1
+		            ELSE 2 END AS priority
+		FROM companies c
+		WHERE c.id IN (SELECT company_id FROM company_aliases WHERE alias IN ($1, $2))
+		   OR c.website_domain = $3
+		ORDER BY priority, c.created_at, c.id
+		LIMIT 1`,
+		key, companyBaseKey(key), domain,
+	).Scan(&id, &storedDomain, &storedIndustry, &priority)
+	if errors.Is(err, sql.ErrNoRows) {
+		return createCompany(ctx, db, name, key, domain, industry, now)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("find company: %w", err)
+	}
+
+	if priority > 0 {
+		if err := addCompanyAlias(ctx, db, key, id, now); err != nil {
+			return nil, err
+		}
+	}
+	if (!storedDomain.Valid && domain != "") || (!storedIndustry.Valid && industry != "") {
+		if _, err := db.ExecContext(ctx, `
+			UPDATE companies SET
+				website_domain = COALESCE(website_domain, $2),
+				industry       = COALESCE(industry, $3),
+				updated_at     = $4
+			WHERE id = $1`,
+			id, nullString(domain), nullString(industry), now,
+		); err != nil {
+			return nil, fmt.Errorf("enrich company: %w", err)
+		}
+	}
+	return &id, nil
+}
+
+// createCompany stores a company flagged for review with key as its
+// normalised name and alias. If another writer created it first, that
+// company is returned.
+func createCompany(ctx context.Context, db *sql.DB, name, key, domain, industry string, now time.Time) (*uuid.UUID, error) {
+	id := uuid.New()
+	res, err := db.ExecContext(ctx, `
+		INSERT INTO companies (id, name, normalized_name, website_domain, industry, needs_review, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, TRUE, $6, $6)
+		ON CONFLICT (normalized_name) DO NOTHING`,
+		id, strings.TrimSpace(name), key, nullString(domain), nullString(industry), now,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("create company: %w", err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		if err := db.QueryRowContext(ctx, `SELECT id FROM companies WHERE normalized_name = $1`, key).Scan(&id); err != nil {
+			return nil, fmt.Errorf("create company: %w", err)
+		}
+	}
+	if err := addCompanyAlias(ctx, db, key, id, now); err != nil {
+		return nil, err
+	}
+	return &id, nil
+}
+
+// addCompanyAlias makes alias resolve to the company, unless it already
+// resolves to one.
+func addCompanyAlias(ctx context.Context, db queryer, alias string, companyID uuid.UUID, now time.Time) error {
+	if _, err := db.ExecContext(ctx, `
+		INSERT INTO company_aliases (alias, company_id, created_at) VALUES ($1, $2, $3)
+		ON CONFLICT (alias) DO NOTHING`,
+		alias, companyID, now,
+	); err != nil {
+		return fmt.Errorf("add company alias: %w", err)
+	}
+	return nil
+}
+
+// resolveJobCompanies resolves the company of jobs[i] for each i in rows,
+// once per company name and website domain, and returns the company IDs
+// by index of jobs.
+func resolveJobCompanies(ctx context.Context, db *sql.DB, jobs []*model.ScrapedJob, rows []int, now time.Time) ([]*uuid.UUID, error) {
+	ids := make([]*uuid.UUID, len(jobs))
+	resolved := map[string]*uuid.UUID{}
+	for _, i := range rows {
+		website := companyWebsite(jobs[i])
+		cacheKey := companyKey(jobs[i].CompanyName) + "\x00" + websiteDomain(website)
+		id, ok := resolved[cacheKey]
+		if !ok {
+			var err error
+			if id, err = resolveCompany(ctx, db, jobs[i].CompanyName, website, jobs[i].Industry, now); err != nil {
+				return nil, err
+			}
+			resolved[cacheKey] = id
+		}
+		ids[i] = id
+	}
+	return ids, nil
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Company management
+// ─────────────────────────────────────────────────────────────────────────────
+
+// ResolveCompany returns the company a company name resolves to, creating
+// one flagged for review when none matches. website is a URL of the
+// company's website, or "".
+func (r *PostgresRepository) ResolveCompany(ctx context.Context, name, website string) (*model.Company, error) {
+	return resolveAndGetCompany(ctx, r.db, name, website, time.Now())
+}
+
+// ListCompanies returns a page of companies ordered by name, and the total
+// count.
+func (r *PostgresRepository) ListCompanies(ctx context.Context, filter model.CompanyFilter) ([]model.Company, int, error) {
+	return listCompanies(ctx, r.db, filter)
+}
+
+// GetCompany returns the company with its aliases, or ErrNotFound.
+func (r *PostgresRepository) GetCompany(ctx context.Context, id uuid.UUID) (*model.Company, error) {
+	return getCompany(ctx, r.db, id)
+}
+
+// UpdateCompany changes the fields set in update. It returns ErrNotFound
+// for an unknown company, and ErrDuplicate if the new name or an alias
+// belongs to another company.
+func (r *PostgresRepository) UpdateCompany(ctx context.Context, id uuid.UUID, update model.CompanyUpdate) (*model.Company, error) {
+	return updateCompany(ctx, r.db, id, update, time.Now())
+}
+
+// MergeCompanies moves the jobs, career pages and aliases of the source
+// company to the target and deletes the source, in one transaction.
+func (r *PostgresRepository) MergeCompanies(ctx context.Context, sourceID, targetID uuid.UUID) (*model.CompanyMerge, error) {
+	return mergeCompanies(ctx, r.db, sourceID, targetID, time.Now())
+}
+
+// ResolveCompany returns the company a company name resolves to, creating
+// one flagged for review when none matches. website is a URL of the
+// company's website, or "".
+func (r *SQLiteRepository) ResolveCompany(ctx context.Context, name, website string) (*model.Company, error) {
+	return resolveAndGetCompany(ctx, r.db, name, website, r.now())
+}
+
+// ListCompanies returns a page of companies ordered by name, and the total
+// count.
+func (r *SQLiteRepository) ListCompanies(ctx context.Context, filter model.CompanyFilter) ([]model.Company, int, error) {
+	return listCompanies(ctx, r.db, filter)
+}
+
+// GetCompany returns the company with its aliases, or ErrNotFound.
+func (r *SQLiteRepository) GetCompany(ctx context.Context, id uuid.UUID) (*model.Company, error) {
+	return getCompany(ctx, r.db, id)
+}
+
+// UpdateCompany changes the fields set in update. It returns ErrNotFound
+// for an unknown company, and ErrDuplicate if the new name or an alias
+// belongs to another company.
+func (r *SQLiteRepository) UpdateCompany(ctx context.Context, id uuid.UUID, update model.CompanyUpdate) (*model.Company, error) {
+	return updateCompany(ctx, r.db, id, update, r.now())
+}
+
+// MergeCompanies moves the jobs, career pages and aliases of the source
+// company to the target and deletes the source, in one transaction.
+func (r *SQLiteRepository) MergeCompanies(ctx context.Context, sourceID, targetID uuid.UUID) (*model.CompanyMerge, error) {
+	return mergeCompanies(ctx, r.db, sourceID, targetID, r.now())
+}
+
+// queryer is a database or a transaction.
+type queryer interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+// companyColumns is the column list read by the company queries, in the
+// order expected by scanCompany.
+const companyColumns = `id, name, normalized_name, industry, website_url, linkedin_url,
+		       logo_url, size_range, headquarters, description, website_domain, needs_review,
+		       created_at, updated_at`
+
+// scanCompany scans a row selected with companyColumns.
+func scanCompany(row interface{ Scan(...interface{}) error }, c *model.Company) error {
+	return row.Scan(
+		&c.ID, &c.Name, &c.NormalizedName, &c.Industry, &c.WebsiteURL, &c.LinkedInURL,
+		&c.LogoURL, &c.SizeRange, &c.Headquarters, &c.Description, &c.WebsiteDomain, &c.NeedsReview,
+		&c.CreatedAt, &c.UpdatedAt,
+	)
+}
+
+// resolveAndGetCompany implements ResolveCompany for both backends.
+func resolveAndGetCompany(ctx context.Context, db *sql.DB, name, website string, now time.Time) (*model.Company, error) {
+	id, err := resolveCompany(ctx, db, name, website, "", now)
+	if err != nil {
+		return nil, err
+	}
+	if id == nil {
+		return nil, fmt.Errorf("resolve company: no company name in %q", name)
+	}
+	return getCompany(ctx, db, *id)
+}
+
+// getCompany implements GetCompany for both backends.
+func getCompany(ctx context.Context, db queryer, id uuid.UUID) (*model.Company, error) {
+	c := &model.Company{}
+	err := scanCompany(db.QueryRowContext(ctx, `SELECT `+companyColumns+` FROM companies WHERE id = $1`, id), c)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get company: %w", err)
+	}
+	companies := []model.Company{*c}
+	if err := loadCompanyAliases(ctx, db, companies); err != nil {
+		return nil, err
+	}
+	return &companies[0], nil
+}
+
+// listCompanies implements ListCompanies for both backends.
+func listCompanies(ctx context.Context, db *sql.DB, filter model.CompanyFilter) ([]model.Company, int, error) {
+	if filter.PageSize <= 0 {
+		filter.PageSize = 20
+	}
+	if filter.Page <= 0 {
+		filter.Page = 1
+	}
+
+	where := []string{"1=1"}
+	var args []interface{}
+	if q := strings.TrimSpace(filter.Query); q != "" {
+		args = append(args, "%"+escapeLike(strings.ToLower(q))+"%")
+		where = append(where, fmt.Sprintf(`(LOWER(name) LIKE $%d ESCAPE '\'
+			OR id IN (SELECT company_id FROM company_aliases WHERE alias LIKE $%d ESCAPE '\'))`, len(args), len(args)))
+	}
+	if filter.NeedsReview != nil {
+		args = append(args, *filter.NeedsReview)
+		where = append(where, fmt.Sprintf("needs_review = $%d", len(args)))
+	}
+	whereClause := strings.Join(where, " AND ")
+
+	var total int
+	if err := db.QueryRowContext(ctx, "SELECT COUNT(*) FROM companies WHERE "+whereClause, args...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("count companies: %w", err)
+	}
+
+	args = append(args, filter.PageSize, (filter.Page-1)*filter.PageSize)
+	rows, err := db.QueryContext(ctx, fmt.Sprintf(`
+		SELECT %s FROM companies
+		WHERE %s
+		ORDER BY LOWER(name), id
+		LIMIT $%d OFFSET $%d`, companyColumns, whereClause, len(args)-1, len(args)), args...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("list companies: %w", err)
+	}
+	defer rows.Close()
+
+	companies := []model.Company{}
+	for rows.Next() {
+		var c model.Company
+		if err := scanCompany(rows, &c); err != nil {
+			return nil, 0, fmt.Errorf("scan company: %w", err)
+		}
+		companies = append(companies, c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("list companies: %w", err)
+	}
+	rows.Close()
+
+	if err := loadCompanyAliases(ctx, db, companies); err != nil {
+		return nil, 0, err
+	}
+	return companies, total, nil
+}
+
+// loadCompanyAliases fills in the aliases of companies, in alphabetical
+// order.
+func loadCompanyAliases(ctx context.Context, db queryer, companies []model.Company) error {
+	if len(companies) == 0 {
+		return nil
+	}
+	byID := make(map[uuid.UUID]*model.Company, len(companies))
+	ids := make([]uuid.UUID, len(companies))
+	for i := range companies {
+		companies[i].Aliases = []string{}
+		byID[companies[i].ID] = &companies[i]
+		ids[i] = companies[i].ID
+	}
+
+	var args []interface{}
+	rows, err := db.QueryContext(ctx, `
+		SELECT company_id, alias FROM company_aliases
+		WHERE company_id IN (`+placeholders(ids, &args)+`)
+		ORDER BY alias`, args...)
+	if err != nil {
+		return fmt.Errorf("list company aliases: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var id uuid.UUID
+		var alias string
+		if err := rows.Scan(&id, &alias); err != nil {
+			return fmt.Errorf("scan company alias: %w", err)
+		}
+		if c := byID[id]; c != nil {
+			c.Aliases = append(c.Aliases, alias)
+		}
+	}
+	return rows.Err()
+}
+
+// updateCompany implements UpdateCompany for both backends.
+func updateCompany(ctx context.Context, db *sql.DB, id uuid.UUID, update model.CompanyUpdate, now time.Time) (*model.Company, error) {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("update company: %w", err)
+	}
+	defer tx.Rollback() //nolint:errcheck
+
+	c, err := getCompany(ctx, tx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if update.Name != nil {
+		c.Name = strings.TrimSpace(*update.Name)
+		c.NormalizedName = companyKey(c.Name)
+	}
+	if update.WebsiteDomain != nil {
+		c.WebsiteDomain = nullString(websiteDomain(*update.WebsiteDomain))
+	}
+	if update.Industry != nil {
+		c.Industry = nullString(strings.TrimSpace(*update.Industry))
+	}
+	if update.SizeRange != nil {
+		c.SizeRange = nullString(strings.TrimSpace(*update.SizeRange))
+	}
+	if update.NeedsReview != nil {
+		c.NeedsReview = *update.NeedsReview
+	}
+	if _, err := tx.ExecContext(ctx, `
+		UPDATE companies SET
+			name            = $2,
+			normalized_name = $3,
+			website_domain  = $4,
+			industry        = $5,
+			size_range      = $6,
+			needs_review    = $7,
+			updated_at      = $8
+		WHERE id = $1`,
+		id, c.Name, c.NormalizedName, c.WebsiteDomain, c.Industry, c.SizeRange, c.NeedsReview, now,
+	); err != nil {
+		if isUniqueViolation(err) {
+			return nil, ErrDuplicate
+		}
+		return nil, fmt.Errorf("update company: %w", err)
+	}
+
+	// The normalised name always resolves to the company.
+	aliases := []string{c.NormalizedName}
+	if update.Aliases != nil {
+		for _, name := range *update.Aliases {
+			if key := companyKey(name); key != "" {
+				aliases = append(aliases, key)
+			}
+		}
+		var args []interface{}
+		args = append(args, id)
+		if _, err := tx.ExecContext(ctx, `
+			DELETE FROM company_aliases WHERE company_id = $1 AND alias NOT IN (`+placeholders(aliases, &args)+`)`,
+			args...,
+		); err != nil {
+			return nil, fmt.Errorf("update company aliases: %w", err)
+		}
+	}
+	for _, alias := range aliases {
+		var owner uuid.UUID
+		err := tx.QueryRowContext(ctx, `SELECT company_id FROM company_aliases WHERE alias = $1`, alias).Scan(&owner)
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			if err := addCompanyAlias(ctx, tx, alias, id, now); err != nil {
+				return nil, err
+			}
+		case err != nil:
+			return nil, fmt.Errorf("update company aliases: %w", err)
+		case owner != id:
+			return nil, ErrDuplicate
+		}
+	}
+
+	c, err = getCompany(ctx, tx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("update company: %w", err)
+	}
+	return c, nil
+}
+
+// mergeCompanies implements MergeCompanies for both backends. The target
+// keeps its own fields, takes the source's where it has none, and is no
+// longer flagged for review.
+func mergeCompanies(ctx context.Context, db *sql.DB, sourceID, targetID uuid.UUID, now time.Time) (*model.CompanyMerge, error) {
+	if sourceID == targetID {
+		return nil, ErrSelfMerge
+	}
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("merge companies: %w", err)
+	}
+	defer tx.Rollback() //nolint:errcheck
+
+	for _, id := range []uuid.UUID{sourceID, targetID} {
+		var exists bool
+		if err := tx.QueryRowContext(ctx, `SELECT EXISTS (SELECT 1 FROM companies WHERE id = $1)`, id).Scan(&exists); err != nil {
+			return nil, fmt.Errorf("merge companies: %w", err)
+		}
+		if !exists {
+			return nil, ErrNotFound
+		}
+	}
+
+	merge := &model.CompanyMerge{MergedID: sourceID}
+	res, err := tx.ExecContext(ctx, `UPDATE jobs SET company_id = $2 WHERE company_id = $1`, sourceID, targetID)
+	if err != nil {
+		return nil, fmt.Errorf("move company jobs: %w", err)
+	}
+	jobs, _ := res.RowsAffected()
+	res, err = tx.ExecContext(ctx, `
+		UPDATE company_career_pages SET company_id = $2, updated_at = $3 WHERE company_id = $1`,
+		sourceID, targetID, now,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("move company career pages: %w", err)
+	}
+	pages, _ := res.RowsAffected()
+	merge.JobsMoved, merge.CareerPagesMoved = int(jobs), int(pages)
+
+	if _, err := tx.ExecContext(ctx, `UPDATE company_aliases SET company_id = $2 WHERE company_id = $1`, sourceID, targetID); err != nil {
+		return nil, fmt.Errorf("move company aliases: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx, `
+		UPDATE companies SET
+			industry       = COALESCE(industry, (SELECT industry FROM companies WHERE id = $1)),
+			website_url    = COALESCE(website_url, (SELECT website_url FROM companies WHERE id = $1)),
+			linkedin_url   = COALESCE(linkedin_url, (SELECT linkedin_url FROM companies WHERE id = $1)),
+			logo_url       = COALESCE(logo_url, (SELECT logo_url FROM companies WHERE id = $1)),
+			size_range     = COALESCE(size_range, (SELECT size_range FROM companies WHERE id = $1)),
+			headquarters   = COALESCE(headquarters, (SELECT headquarters FROM companies WHERE id = $1)),
+			description    = COALESCE(description, (SELECT description FROM companies WHERE id = $1)),
+			website_domain = COALESCE(website_domain, (SELECT website_domain FROM companies WHERE id = $1)),
+			needs_review   = FALSE,
+			updated_at     = $3
+		WHERE id = $2`,
+		sourceID, targetID, now,
+	); err != nil {
+		return nil, fmt.Errorf("merge company fields: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM companies WHERE id = $1`, sourceID); err != nil {
+		return nil, fmt.Errorf("delete merged company: %w", err)
+	}
+
+	target, err := getCompany(ctx, tx, targetID)
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("merge companies: %w", err)
+	}
+	merge.Company = *target
+	return merge, nil
+}
+
+// isUniqueViolation reports whether err is a unique constraint violation
+// on either backend.
+func isUniqueViolation(err error) bool {
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		return pqErr.Code == "23505"
+	}
+	var sqliteErr *sqlite.Error
+	if errors.As(err, &sqliteErr) {
+		code := sqliteErr.Code()
+		return code == sqlite3.SQLITE_CONSTRAINT_UNIQUE || code == sqlite3.SQLITE_CONSTRAINT_PRIMARYKEY
+	}
+	return false
+}
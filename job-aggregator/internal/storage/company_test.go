@@ -0,0 +1,76 @@
+package storage
+
+import (
+	"testing"
+
+	"github.com/learnbot/job-aggregator/internal/model"
+)
+
+func TestCompanyKey(t *testing.T) {
+	tests := map[string]string{
+		"Google":                "google",
+		"Google LLC":            "google",
+		"  Acme,  Inc. ":        "acme",
+		"PT Gojek Indonesia":    "gojek indonesia",
+		"PT Bank Rakyat Tbk":    "bank rakyat",
+		"CV":                    "cv",
+		"株式会社":                  "株式会社",
+		"Scale AI":              "scale ai",
+		"Tokopedia Group Corp.": "tokopedia group",
+	}
+	for name, want := range tests {
+		if got := companyKey(name); got != want {
+			t.Errorf("companyKey(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
+
+func TestCompanyBaseKey(t *testing.T) {
+	tests := map[string]string{
+		"google indonesia":     "google",
+		"google asia pacific":  "google",
+		"grab singapore apac":  "grab",
+		"scale ai":             "scale ai",
+		"indonesia":            "indonesia",
+		"united states steel":  "united states steel",
+		"shopee united states": "shopee",
+	}
+	for key, want := range tests {
+		if got := companyBaseKey(key); got != want {
+			t.Errorf("companyBaseKey(%q) = %q, want %q", key, got, want)
+		}
+	}
+}
+
+func TestWebsiteDomain(t *testing.T) {
+	tests := map[string]string{
+		"https://careers.google.com/jobs?q=go": "google.com",
+		"www.tokopedia.com":                    "tokopedia.com",
+		"https://careers.gojek.co.id/":         "gojek.co.id",
+		"https://boards.greenhouse.io/acme":    "",
+		"https://www.linkedin.com/company/x":   "",
+		"http://10.0.0.1:8080/":                "",
+		"localhost":                            "",
+		"":                                     "",
+	}
+	for raw, want := range tests {
+		if got := websiteDomain(raw); got != want {
+			t.Errorf("websiteDomain(%q) = %q, want %q", raw, got, want)
+		}
+	}
+}
+
+func TestCompanyWebsite(t *testing.T) {
+	page := &model.ScrapedJob{Source: model.SourceCompanyCareerPage, ApplicationURL: "https://acme.com/jobs/1"}
+	if got := companyWebsite(page); got != page.ApplicationURL {
+		t.Errorf("career page website = %q, want the application URL", got)
+	}
+	board := &model.ScrapedJob{Source: model.SourceLinkedIn, ApplicationURL: "https://acme.com/jobs/1"}
+	if got := companyWebsite(board); got != "" {
+		t.Errorf("job board website = %q, want none", got)
+	}
+	board.CompanyURL = "https://acme.com"
+	if got := companyWebsite(board); got != board.CompanyURL {
+		t.Errorf("website = %q, want the company URL", got)
+	}
+}
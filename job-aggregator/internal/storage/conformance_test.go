@@ -0,0 +1,1331 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"io"
+	"log"
+	"os"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	_ "github.com/lib/pq"
+
+	"github.com/learnbot/job-aggregator/internal/model"
+	"github.com/learnbot/shared/geo"
+)
+
+// The conformance tests check that every JobRepository backend behaves the
+// same. They run against an in-memory SQLite database, and against the
+// PostgreSQL database named by TEST_DATABASE_URL when it is set. That
+// database must be migrated and disposable: the tests scope their rows by
+// unique company, scraper and URL names and delete them afterwards, but
+// MarkOtherStaleJobs expires any job last seen before 2001.
+
+// conformanceBackend opens a repository for one conformance test, with the
+// database it is built on for cleanup.
+type conformanceBackend struct {
+	name string
+	open func(tb testing.TB) (JobRepository, *sql.DB)
+}
+
+func conformanceBackends() []conformanceBackend {
+	return []conformanceBackend{
+		{name: "sqlite", open: openSQLiteRepository},
+		{name: "postgres", open: openPostgresRepository},
+	}
+}
+
+func openSQLiteRepository(t testing.TB) (JobRepository, *sql.DB) {
+	t.Helper()
+	db, err := sql.Open(SQLiteDriver, SQLiteDSN(":memory:"))
+	if err != nil {
+		t.Fatalf("open sqlite: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	repo := NewSQLiteRepository(db)
+	if err := repo.Migrate(context.Background(), log.New(io.Discard, "", 0)); err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+	return repo, db
+}
+
+func openPostgresRepository(t testing.TB) (JobRepository, *sql.DB) {
+	t.Helper()
+	dsn := os.Getenv("TEST_DATABASE_URL")
+	if dsn == "" {
+		t.Skip("TEST_DATABASE_URL not set")
+	}
+	db, err := sql.Open("postgres", dsn)
+	if err != nil {
+		t.Fatalf("open database: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return NewPostgresRepository(db), db
+}
+
+// runConformance runs test against every backend.
+func runConformance(t *testing.T, test func(t *testing.T, repo JobRepository, db *sql.DB)) {
+	for _, b := range conformanceBackends() {
+		t.Run(b.name, func(t *testing.T) {
+			repo, db := b.open(t)
+			test(t, repo, db)
+		})
+	}
+}
+
+// testCompany returns a company name unique to this test run, and deletes
+// the jobs and companies of it and its variants when the test ends.
+func testCompany(t testing.TB, db *sql.DB) string {
+	t.Helper()
+	name := "Conformance " + uuid.NewString()[:8]
+	t.Cleanup(func() {
+		db.Exec(`DELETE FROM jobs WHERE company_name LIKE $1 || '%'`, name)                          //nolint:errcheck
+		db.Exec(`DELETE FROM companies WHERE normalized_name LIKE $1 || '%'`, strings.ToLower(name)) //nolint:errcheck
+	})
+	return name
+}
+
+// testJob returns a scraped job from company with a distinct external ID,
+// so that fuzzy deduplication never merges it with another test job.
+func testJob(company, externalID, title string) *model.ScrapedJob {
+	return &model.ScrapedJob{
+		Source:          model.SourceLinkedIn,
+		ExternalID:      externalID,
+		CompanyName:     company,
+		Title:           title,
+		Description:     "Build and run backend services.",
+		LocationCity:    "Berlin",
+		LocationCountry: "Germany",
+		LocationRaw:     "Berlin, Germany",
+		LocationType:    model.LocationOnSite,
+		EmploymentType:  model.EmploymentFullTime,
+		ExperienceLevel: model.LevelMid,
+		RequiredSkills:  []string{"go", "postgresql"},
+		PreferredSkills: []string{"kubernetes"},
+		SalaryCurrency:  "USD",
+		ApplicationURL:  "https://jobs.example.com/" + externalID,
+	}
+}
+
+func mustUpsert(t *testing.T, repo JobRepository, job *model.ScrapedJob) *model.Job {
+	t.Helper()
+	stored, _, err := repo.UpsertJob(context.Background(), job)
+	if err != nil {
+		t.Fatalf("UpsertJob(%q): %v", job.Title, err)
+	}
+	return stored
+}
+
+func TestConformance_UpsertJob(t *testing.T) {
+	runConformance(t, func(t *testing.T, repo JobRepository, db *sql.DB) {
+		ctx := context.Background()
+		company := testCompany(t, db)
+		scraped := testJob(company, "up-1", "Backend Engineer")
+		minSalary, maxSalary := 60000, 90000
+		scraped.SalaryMin, scraped.SalaryMax = &minSalary, &maxSalary
+		posted := time.Now().Add(-48 * time.Hour).Truncate(time.Second)
+		scraped.PostedAt = &posted
+
+		job, isNew, err := repo.UpsertJob(ctx, scraped)
+		if err != nil {
+			t.Fatalf("UpsertJob: %v", err)
+		}
+		if !isNew {
+			t.Error("first upsert should create a new job")
+		}
+
+		got, err := repo.GetJobByID(ctx, job.ID)
+		if err != nil {
+			t.Fatalf("GetJobByID: %v", err)
+		}
+		if got.Title != "Backend Engineer" || got.CompanyName != company || got.Status != model.StatusActive {
+			t.Errorf("unexpected job: %+v", got)
+		}
+		if len(got.RequiredSkills) != 2 || got.RequiredSkills[0] != "go" || got.RequiredSkills[1] != "postgresql" {
+			t.Errorf("RequiredSkills = %v", got.RequiredSkills)
+		}
+		if len(got.SourceURLs) != 1 || got.SourceURLs[0] != scraped.ApplicationURL {
+			t.Errorf("SourceURLs = %v, want [%s]", got.SourceURLs, scraped.ApplicationURL)
+		}
+		if !got.SalaryMin.Valid || got.SalaryMin.Int32 != 60000 || !got.SalaryMax.Valid || got.SalaryMax.Int32 != 90000 {
+			t.Errorf("salary = %v-%v", got.SalaryMin, got.SalaryMax)
+		}
+		if !got.PostedAt.Valid || !got.PostedAt.Time.Equal(posted) {
+			t.Errorf("PostedAt = %v, want %v", got.PostedAt, posted)
+		}
+
+		// The same posting again updates the job in place.
+		scraped.Description = "Updated description."
+		scraped.RequiredSkills = []string{"go"}
+		again, isNew, err := repo.UpsertJob(ctx, scraped)
+		if err != nil {
+			t.Fatalf("UpsertJob again: %v", err)
+		}
+		if isNew || again.ID != job.ID {
+			t.Errorf("second upsert: isNew=%v id=%v, want existing job %v", isNew, again.ID, job.ID)
+		}
+		got, err = repo.GetJobByID(ctx, job.ID)
+		if err != nil {
+			t.Fatalf("GetJobByID: %v", err)
+		}
+		if got.Description.String != "Updated description." || len(got.RequiredSkills) != 1 {
+			t.Errorf("job not updated: description=%q skills=%v", got.Description.String, got.RequiredSkills)
+		}
+
+		if _, err := repo.GetJobByID(ctx, uuid.New()); !errors.Is(err, ErrNotFound) {
+			t.Errorf("GetJobByID(unknown) error = %v, want ErrNotFound", err)
+		}
+	})
+}
+
+func TestConformance_UpsertJobs(t *testing.T) {
+	runConformance(t, func(t *testing.T, repo JobRepository, db *sql.DB) {
+		ctx := context.Background()
+		company := testCompany(t, db)
+		posted := time.Now().Add(-48 * time.Hour).Truncate(time.Second)
+		existing := testJob(company, "ub-1", "Backend Engineer")
+		existing.PostedAt = &posted
+		stored := mustUpsert(t, repo, existing)
+
+		// A re-scrape reporting a later posting date, a new title and
+		// description, and a new location, next to a new posting that
+		// appears twice in the batch.
+		later := posted.Add(24 * time.Hour)
+		rescraped := testJob(company, "ub-1", "Frontend Engineer")
+		rescraped.PostedAt = &later
+		rescraped.Description = "Now with React."
+		rescraped.LocationCity, rescraped.LocationRaw = "Munich", "Munich, Germany"
+		fresh := testJob(company, "ub-2", "Data Engineer")
+		freshAgain := testJob(company, "ub-2", "Data Engineer")
+		freshAgain.Description = "Seen twice."
+
+		results, err := repo.UpsertJobs(ctx, []*model.ScrapedJob{rescraped, fresh, freshAgain})
+		if err != nil {
+			t.Fatalf("UpsertJobs: %v", err)
+		}
+		if len(results) != 3 {
+			t.Fatalf("UpsertJobs returned %d results, want 3", len(results))
+		}
+		if r := results[0]; r.Inserted || r.Job.ID != stored.ID {
+			t.Errorf("re-scraped job: inserted=%v id=%v, want existing job %v", r.Inserted, r.Job.ID, stored.ID)
+		}
+		if r := results[1]; !r.Inserted || r.Job.ID != results[2].Job.ID || results[2].Inserted {
+			t.Errorf("repeated posting: inserted=%v,%v ids=%v,%v; want one new job",
+				r.Inserted, results[2].Inserted, r.Job.ID, results[2].Job.ID)
+		}
+		want := map[string]FieldChange{
+			"title":    {Old: "Backend Engineer", New: "Frontend Engineer"},
+			"location": {Old: "Berlin, Germany", New: "Munich, Germany"},
+		}
+		if got := results[0].Changes; len(got) != 2 || got["title"] != want["title"] || got["location"] != want["location"] {
+			t.Errorf("Changes = %v, want %v", got, want)
+		}
+
+		got, err := repo.GetJobByID(ctx, stored.ID)
+		if err != nil {
+			t.Fatalf("GetJobByID: %v", err)
+		}
+		if got.Title != "Backend Engineer" || got.LocationCity.String != "Berlin" {
+			t.Errorf("title and location overwritten: %q in %q", got.Title, got.LocationCity.String)
+		}
+		if got.Description.String != "Now with React." {
+			t.Errorf("description not refreshed: %q", got.Description.String)
+		}
+		if !got.PostedAt.Valid || !got.PostedAt.Time.Equal(posted) {
+			t.Errorf("PostedAt = %v, want the earlier %v", got.PostedAt, posted)
+		}
+		if !got.CreatedAt.Equal(stored.CreatedAt) {
+			t.Errorf("CreatedAt = %v, want %v", got.CreatedAt, stored.CreatedAt)
+		}
+		var note sql.NullString
+		if err := db.QueryRow(`SELECT changed_fields FROM jobs WHERE id = $1`, stored.ID).Scan(&note); err != nil {
+			t.Fatalf("read changed_fields: %v", err)
+		}
+		if !strings.Contains(note.String, `"Frontend Engineer"`) || !strings.Contains(note.String, `"Munich, Germany"`) {
+			t.Errorf("changed_fields = %q", note.String)
+		}
+		if got, err := repo.GetJobByID(ctx, results[1].Job.ID); err != nil || got.Description.String != "Seen twice." {
+			t.Errorf("repeated posting not stored from its last occurrence: %v", err)
+		}
+
+		// An earlier posting date moves posted_at back; a cosmetic title
+		// difference is not a change.
+		earlier := posted.Add(-24 * time.Hour)
+		existing.PostedAt = &earlier
+		existing.Title = "backend engineer "
+		results, err = repo.UpsertJobs(ctx, []*model.ScrapedJob{existing})
+		if err != nil {
+			t.Fatalf("UpsertJobs again: %v", err)
+		}
+		if results[0].Inserted || results[0].Changes != nil {
+			t.Errorf("second re-scrape: inserted=%v changes=%v", results[0].Inserted, results[0].Changes)
+		}
+		if got := results[0].Job; !got.PostedAt.Valid || !got.PostedAt.Time.Equal(earlier) {
+			t.Errorf("PostedAt = %v, want %v", got.PostedAt, earlier)
+		}
+	})
+}
+
+func TestConformance_FuzzyDedup(t *testing.T) {
+	runConformance(t, func(t *testing.T, repo JobRepository, db *sql.DB) {
+		ctx := context.Background()
+		company := testCompany(t, db)
+		canonical := mustUpsert(t, repo, testJob(company, "dd-1", "Senior Software Engineer"))
+
+		dup := testJob(company, "dd-2", "Sr. Software Engineer (Remote)")
+		dup.Source = model.SourceIndeed
+		merged, isNew, err := repo.UpsertJob(ctx, dup)
+		if err != nil {
+			t.Fatalf("UpsertJob duplicate: %v", err)
+		}
+		if isNew || merged.ID != canonical.ID {
+			t.Fatalf("duplicate: isNew=%v id=%v, want merge into %v", isNew, merged.ID, canonical.ID)
+		}
+		if len(merged.SourceURLs) != 2 || merged.SourceURLs[1] != dup.ApplicationURL {
+			t.Errorf("SourceURLs = %v, want both application URLs", merged.SourceURLs)
+		}
+
+		// A later sighting of the merged posting goes to the same job and
+		// is logged once.
+		if again := mustUpsert(t, repo, dup); again.ID != canonical.ID || len(again.SourceURLs) != 2 {
+			t.Errorf("repeat sighting: id=%v urls=%v", again.ID, again.SourceURLs)
+		}
+		entries, total, err := repo.ListDedupLog(ctx, &canonical.ID, 1, 20)
+		if err != nil {
+			t.Fatalf("ListDedupLog: %v", err)
+		}
+		if total != 1 || len(entries) != 1 {
+			t.Fatalf("ListDedupLog = %d entries (total %d), want 1", len(entries), total)
+		}
+		if e := entries[0]; e.CanonicalTitle != "Senior Software Engineer" || e.Source != model.SourceIndeed || e.Similarity < DefaultFuzzyDedupThreshold {
+			t.Errorf("unexpected dedup log entry: %+v", e)
+		}
+
+		// With fuzzy dedup disabled the next similar posting is a new job.
+		repo.SetFuzzyDedupThreshold(0)
+		other := testJob(company, "dd-3", "Senior Software Engineer")
+		other.Source = model.SourceGlassdoor
+		if job, isNew, err := repo.UpsertJob(ctx, other); err != nil || !isNew || job.ID == canonical.ID {
+			t.Errorf("with dedup disabled: isNew=%v err=%v", isNew, err)
+		}
+	})
+}
+
+func TestConformance_SearchJobs(t *testing.T) {
+	runConformance(t, func(t *testing.T, repo JobRepository, db *sql.DB) {
+		ctx := context.Background()
+		company := testCompany(t, db)
+		base := time.Now().Add(-time.Hour).Truncate(time.Second)
+		for i, title := range []string{"Go Backend Engineer", "Data Analyst", "Platform Engineer"} {
+			job := testJob(company, "sj-"+title, title)
+			posted := base.Add(time.Duration(i) * time.Minute)
+			job.PostedAt = &posted
+			if title == "Data Analyst" {
+				job.RequiredSkills = []string{"sql", "python"}
+				job.LocationType = model.LocationRemote
+			}
+			mustUpsert(t, repo, job)
+		}
+
+		jobs, total, next, err := repo.SearchJobs(ctx, model.JobFilter{CompanyName: company, PageSize: 2})
+		if err != nil {
+			t.Fatalf("SearchJobs: %v", err)
+		}
+		if total != 3 || len(jobs) != 2 || next == nil {
+			t.Fatalf("first page: %d jobs, total %d, next %v", len(jobs), total, next)
+		}
+		if jobs[0].Title != "Platform Engineer" || jobs[1].Title != "Data Analyst" {
+			t.Errorf("not newest first: %q, %q", jobs[0].Title, jobs[1].Title)
+		}
+		rest, _, next, err := repo.SearchJobs(ctx, model.JobFilter{CompanyName: company, PageSize: 2, After: next})
+		if err != nil {
+			t.Fatalf("SearchJobs after cursor: %v", err)
+		}
+		if len(rest) != 1 || rest[0].Title != "Go Backend Engineer" || next != nil {
+			t.Errorf("second page: %d jobs, next %v", len(rest), next)
+		}
+
+		filters := map[string]model.JobFilter{
+			"skills":        {CompanyName: company, Skills: []string{"python", "rust"}},
+			"location type": {CompanyName: company, LocationTypes: []model.WorkLocationType{model.LocationRemote}},
+			"title":         {CompanyName: company, TitleSearch: "analyst"},
+		}
+		for name, filter := range filters {
+			jobs, total, _, err := repo.SearchJobs(ctx, filter)
+			if err != nil {
+				t.Fatalf("SearchJobs(%s): %v", name, err)
+			}
+			if total != 1 || len(jobs) != 1 || jobs[0].Title != "Data Analyst" {
+				t.Errorf("SearchJobs(%s) = %d jobs (total %d), want Data Analyst", name, len(jobs), total)
+			}
+		}
+		if _, total, _, err := repo.SearchJobs(ctx, model.JobFilter{
+			CompanyName: company, Sources: []model.JobSource{model.SourceIndeed},
+		}); err != nil || total != 0 {
+			t.Errorf("SearchJobs(other source) total = %d, err = %v", total, err)
+		}
+	})
+}
+
+func TestConformance_FullTextSearch(t *testing.T) {
+	runConformance(t, func(t *testing.T, repo JobRepository, db *sql.DB) {
+		ctx := context.Background()
+		company := testCompany(t, db)
+		posted := time.Now().Add(-time.Hour).Truncate(time.Second)
+		add := func(id, title, description, city, country string, locType model.WorkLocationType) {
+			job := testJob(company, id, title)
+			job.Description = description
+			job.LocationCity, job.LocationCountry, job.LocationRaw = city, country, city
+			job.LocationType = locType
+			job.PostedAt = &posted
+			mustUpsert(t, repo, job)
+		}
+		add("ft-1", "Golang Developer", "Write services.", "Berlin", "DE", model.LocationOnSite)
+		add("ft-2", "Data Analyst", "Some golang scripting.", "Jakarta", "ID", model.LocationRemote)
+		add("ft-3", "Product Designer", "Design products.", "Berlin", "DE", model.LocationRemote)
+
+		results, total, next, err := repo.FullTextSearch(ctx, model.JobSearchFilter{Query: "golang", CompanyName: company})
+		if err != nil {
+			t.Fatalf("FullTextSearch: %v", err)
+		}
+		if total != 2 || len(results) != 2 || next != nil {
+			t.Fatalf("FullTextSearch(golang) = %d results (total %d), next %v", len(results), total, next)
+		}
+		if results[0].Title != "Golang Developer" || results[0].Relevance <= results[1].Relevance {
+			t.Errorf("title match should rank first: %q (%v), %q (%v)",
+				results[0].Title, results[0].Relevance, results[1].Title, results[1].Relevance)
+		}
+
+		filters := map[string]model.JobSearchFilter{
+			"location":     {CompanyName: company, Location: "jakarta"},
+			"country name": {CompanyName: company, Location: "Indonesia"},
+			"remote only":  {CompanyName: company, Query: "golang", RemoteOnly: true},
+		}
+		for name, filter := range filters {
+			results, total, _, err := repo.FullTextSearch(ctx, filter)
+			if err != nil {
+				t.Fatalf("FullTextSearch(%s): %v", name, err)
+			}
+			if total != 1 || len(results) != 1 || results[0].Title != "Data Analyst" {
+				t.Errorf("FullTextSearch(%s) = %d results (total %d), want Data Analyst", name, len(results), total)
+			}
+		}
+
+		// Without a query, results page by cursor.
+		seen := map[uuid.UUID]bool{}
+		filter := model.JobSearchFilter{CompanyName: company, Limit: 2}
+		for page := 0; page < 3; page++ {
+			results, total, next, err := repo.FullTextSearch(ctx, filter)
+			if err != nil {
+				t.Fatalf("FullTextSearch page %d: %v", page, err)
+			}
+			if total != 3 {
+				t.Errorf("page %d total = %d, want 3", page, total)
+			}
+			for _, r := range results {
+				if seen[r.ID] {
+					t.Errorf("job %v returned twice", r.ID)
+				}
+				seen[r.ID] = true
+			}
+			if next == nil {
+				break
+			}
+			filter.After = next
+		}
+		if len(seen) != 3 {
+			t.Errorf("cursor pagination returned %d jobs, want 3", len(seen))
+		}
+	})
+}
+
+func TestConformance_SalaryFilter(t *testing.T) {
+	runConformance(t, func(t *testing.T, repo JobRepository, db *sql.DB) {
+		ctx := context.Background()
+		company := testCompany(t, db)
+		add := func(id, title string, amount int, currency string, period model.SalaryPeriod) {
+			job := testJob(company, id, title)
+			if amount > 0 {
+				job.SalaryMin, job.SalaryMax = &amount, &amount
+			}
+			job.SalaryCurrency, job.SalaryPeriod = currency, period
+			mustUpsert(t, repo, job)
+		}
+		add("sal-1", "Yearly", 90000, "USD", model.SalaryPerYear)
+		add("sal-2", "Hourly", 60, "USD", model.SalaryPerHour)
+		add("sal-3", "Monthly", 8000, "EUR", model.SalaryPerMonth)
+		add("sal-4", "Unstated", 0, "USD", "")
+
+		intPtr := func(v int) *int { return &v }
+		tests := []struct {
+			name   string
+			filter model.JobSearchFilter
+			want   string
+		}{
+			// $60 an hour is $124,800 a year.
+			{"min_salary", model.JobSearchFilter{MinSalary: intPtr(100000)}, "Hourly"},
+			{"min_salary below both", model.JobSearchFilter{MinSalary: intPtr(50000)}, "Hourly Yearly"},
+			{"currency", model.JobSearchFilter{Currency: "eur"}, "Monthly"},
+			{"min_salary and currency", model.JobSearchFilter{MinSalary: intPtr(100000), Currency: "EUR"}, ""},
+		}
+		for _, tt := range tests {
+			tt.filter.CompanyName = company
+			tt.filter.Sort = model.SortRelevance
+			results, _, _, err := repo.FullTextSearch(ctx, tt.filter)
+			if err != nil {
+				t.Fatalf("FullTextSearch(%s): %v", tt.name, err)
+			}
+			var titles []string
+			for _, r := range results {
+				titles = append(titles, r.Title)
+			}
+			sort.Strings(titles)
+			if got := strings.Join(titles, " "); got != tt.want {
+				t.Errorf("FullTextSearch(%s) = %q, want %q", tt.name, got, tt.want)
+			}
+			for _, r := range results {
+				if r.Title == "Hourly" && r.SalaryPeriod != model.SalaryPerHour {
+					t.Errorf("SalaryPeriod = %q, want hour", r.SalaryPeriod)
+				}
+			}
+		}
+	})
+}
+
+func TestConformance_MarkStaleJobs(t *testing.T) {
+	runConformance(t, func(t *testing.T, repo JobRepository, db *sql.DB) {
+		ctx := context.Background()
+		company := testCompany(t, db)
+		scraperName := "Conformance " + uuid.NewString()[:8]
+		cutoff := time.Date(2001, 1, 8, 0, 0, 0, 0, time.UTC)
+
+		// lastSeen stores a job as seen by scraper at the given time.
+		lastSeen := func(title, scraper string, at time.Time) *model.Job {
+			t.Helper()
+			scraped := testJob(company, uuid.NewString(), title)
+			scraped.ScraperName = scraper
+			job := mustUpsert(t, repo, scraped)
+			if _, err := db.Exec(`UPDATE jobs SET last_seen_at = $1 WHERE id = $2`, at, job.ID); err != nil {
+				t.Fatalf("set last_seen_at: %v", err)
+			}
+			return job
+		}
+		before := lastSeen("Before", scraperName, cutoff.Add(-time.Second))
+		at := lastSeen("At", scraperName, cutoff)
+		after := lastSeen("After", scraperName, cutoff.Add(time.Second))
+		unattributed := lastSeen("Unattributed", "", cutoff.Add(-time.Second))
+
+		status := func(job *model.Job) model.JobStatus {
+			t.Helper()
+			got, err := repo.GetJobByID(ctx, job.ID)
+			if err != nil {
+				t.Fatalf("GetJobByID: %v", err)
+			}
+			return got.Status
+		}
+
+		n, err := repo.MarkStaleJobs(ctx, scraperName, cutoff)
+		if err != nil {
+			t.Fatalf("MarkStaleJobs: %v", err)
+		}
+		if n != 1 {
+			t.Errorf("MarkStaleJobs expired %d jobs, want 1", n)
+		}
+		if status(before) != model.StatusExpired {
+			t.Errorf("job last seen before the cutoff is %s, want expired", status(before))
+		}
+		for _, job := range []*model.Job{at, after, unattributed} {
+			if got := status(job); got != model.StatusActive {
+				t.Errorf("%s job is %s, want active", job.Title, got)
+			}
+		}
+
+		// Jobs of the named scrapers are left to MarkStaleJobs.
+		if _, err := repo.MarkOtherStaleJobs(ctx, []string{scraperName}, cutoff.Add(time.Hour)); err != nil {
+			t.Fatalf("MarkOtherStaleJobs: %v", err)
+		}
+		if status(unattributed) != model.StatusExpired || status(after) != model.StatusActive {
+			t.Errorf("after MarkOtherStaleJobs: unattributed %s, after %s", status(unattributed), status(after))
+		}
+
+		// Expired jobs leave the default search.
+		_, total, _, err := repo.FullTextSearch(ctx, model.JobSearchFilter{CompanyName: company, Limit: 10})
+		if err != nil || total != 2 {
+			t.Errorf("FullTextSearch found %d jobs (%v), want 2", total, err)
+		}
+		_, total, _, err = repo.FullTextSearch(ctx, model.JobSearchFilter{CompanyName: company, IncludeExpired: true, Limit: 10})
+		if err != nil || total != 4 {
+			t.Errorf("FullTextSearch with IncludeExpired found %d jobs (%v), want 4", total, err)
+		}
+	})
+}
+
+func TestConformance_StaleJobsReappear(t *testing.T) {
+	runConformance(t, func(t *testing.T, repo JobRepository, db *sql.DB) {
+		ctx := context.Background()
+		company := testCompany(t, db)
+		scraperName := "Conformance " + uuid.NewString()[:8]
+		scraped := testJob(company, uuid.NewString(), "Site Reliability Engineer")
+		scraped.ScraperName = scraperName
+		job := mustUpsert(t, repo, scraped)
+		cutoff := time.Date(2001, 1, 8, 0, 0, 0, 0, time.UTC)
+		if _, err := db.Exec(`UPDATE jobs SET last_seen_at = $1 WHERE id = $2`, cutoff.Add(-time.Hour), job.ID); err != nil {
+			t.Fatalf("set last_seen_at: %v", err)
+		}
+
+		if _, err := repo.MarkStaleJobs(ctx, scraperName, cutoff); err != nil {
+			t.Fatalf("MarkStaleJobs: %v", err)
+		}
+		got, err := repo.GetJobByID(ctx, job.ID)
+		if err != nil {
+			t.Fatalf("GetJobByID: %v", err)
+		}
+		if got.Status != model.StatusExpired || got.ExpiryReason != model.ExpiryStale {
+			t.Errorf("status = %s (%s), want expired (stale)", got.Status, got.ExpiryReason)
+		}
+
+		// Seeing the posting again, matched by its external ID, reactivates
+		// the same job, and it is no longer stale.
+		scraped = testJob(company, scraped.ExternalID, "Site Reliability Engineer")
+		scraped.ScraperName = scraperName
+		if again := mustUpsert(t, repo, scraped); again.ID != job.ID {
+			t.Fatalf("the reappearing posting was stored as a new job")
+		}
+		got, err = repo.GetJobByID(ctx, job.ID)
+		if err != nil {
+			t.Fatalf("GetJobByID: %v", err)
+		}
+		if got.Status != model.StatusActive || got.ExpiryReason != "" {
+			t.Errorf("status = %s (%s), want active", got.Status, got.ExpiryReason)
+		}
+		if !got.LastSeenAt.After(cutoff) {
+			t.Errorf("last_seen_at = %v, want it refreshed", got.LastSeenAt)
+		}
+		if n, err := repo.MarkStaleJobs(ctx, scraperName, cutoff); err != nil || n != 0 {
+			t.Errorf("MarkStaleJobs expired %d jobs (%v) just after they were seen, want 0", n, err)
+		}
+	})
+}
+
+func TestConformance_ExpiryRuns(t *testing.T) {
+	runConformance(t, func(t *testing.T, repo JobRepository, db *sql.DB) {
+		ctx := context.Background()
+		started := time.Now().UTC().Truncate(time.Second)
+		run := &model.ExpiryRun{
+			StartedAt:   started,
+			CompletedAt: started.Add(time.Second),
+			JobsExpired: 3,
+			Scrapers: []model.ScraperExpiry{
+				{Scraper: "LinkedIn Jobs", StaleAfter: "168h0m0s", Cutoff: started.Add(-168 * time.Hour), JobsExpired: 2},
+				{StaleAfter: "168h0m0s", Cutoff: started.Add(-168 * time.Hour), JobsExpired: 1},
+			},
+			Error: "mark stale jobs: boom",
+		}
+		if err := repo.CreateExpiryRun(ctx, run); err != nil {
+			t.Fatalf("CreateExpiryRun: %v", err)
+		}
+		t.Cleanup(func() {
+			db.Exec(`DELETE FROM expiry_runs WHERE id = $1`, run.ID) //nolint:errcheck
+		})
+
+		runs, err := repo.ListExpiryRuns(ctx, 10)
+		if err != nil {
+			t.Fatalf("ListExpiryRuns: %v", err)
+		}
+		for _, got := range runs {
+			if got.ID != run.ID {
+				continue
+			}
+			if !got.StartedAt.Equal(run.StartedAt) || got.JobsExpired != 3 || got.Error != run.Error || len(got.Scrapers) != 2 {
+				t.Errorf("unexpected run %+v", got)
+			}
+			if s := got.Scrapers[0]; s.Scraper != "LinkedIn Jobs" || s.JobsExpired != 2 || !s.Cutoff.Equal(run.Scrapers[0].Cutoff) {
+				t.Errorf("unexpected scraper expiry %+v", s)
+			}
+			return
+		}
+		t.Errorf("run %s not listed in %+v", run.ID, runs)
+	})
+}
+
+func TestConformance_DryRunUpsert(t *testing.T) {
+	runConformance(t, func(t *testing.T, repo JobRepository, db *sql.DB) {
+		company := testCompany(t, db)
+		stored := testJob(company, "dry-1", "Backend Engineer")
+		job := mustUpsert(t, repo, stored)
+		same := testJob(company, "dry-2", "Data Engineer")
+		mustUpsert(t, repo, same)
+
+		ctx, dry := WithDryRun(context.Background())
+		changed := testJob(company, "dry-1", "Backend Engineer")
+		changed.Description = "Build and run backend services in Go."
+		fresh := testJob(company, "dry-3", "Frontend Engineer")
+		for _, scraped := range []*model.ScrapedJob{changed, same, fresh, fresh} {
+			if _, _, err := repo.UpsertJob(ctx, scraped); err != nil {
+				t.Fatalf("dry run UpsertJob: %v", err)
+			}
+		}
+
+		jobs := dry.Jobs()
+		if len(jobs) != 3 {
+			t.Fatalf("recorded %d jobs, want 3: %+v", len(jobs), jobs)
+		}
+		if jobs[0].Action != model.DryRunChanged || jobs[0].ExistingID == nil || *jobs[0].ExistingID != job.ID ||
+			len(jobs[0].ChangedFields) != 1 || jobs[0].ChangedFields[0] != "description" {
+			t.Errorf("changed job recorded as %+v", jobs[0])
+		}
+		if jobs[1].Action != model.DryRunUnchanged || len(jobs[1].ChangedFields) != 0 {
+			t.Errorf("unchanged job recorded as %+v", jobs[1])
+		}
+		if jobs[2].Action != model.DryRunNew || jobs[2].ExistingID != nil {
+			t.Errorf("new job recorded as %+v", jobs[2])
+		}
+		if s := dry.Summary(); s.New != 1 || s.Changed != 1 || s.Unchanged != 1 {
+			t.Errorf("unexpected summary %+v", s)
+		}
+
+		// Nothing was written.
+		var n int
+		if err := db.QueryRow(`SELECT COUNT(*) FROM jobs WHERE company_name = $1`, company).Scan(&n); err != nil {
+			t.Fatalf("count jobs: %v", err)
+		}
+		got, err := repo.GetJobByID(context.Background(), job.ID)
+		if err != nil {
+			t.Fatalf("GetJobByID: %v", err)
+		}
+		if n != 2 || got.Description.String != stored.Description {
+			t.Errorf("dry run wrote to the database: %d jobs, description %q", n, got.Description.String)
+		}
+	})
+}
+
+func TestConformance_ScrapeRuns(t *testing.T) {
+	runConformance(t, func(t *testing.T, repo JobRepository, db *sql.DB) {
+		ctx := context.Background()
+		scraperName := "conformance-" + uuid.NewString()[:8]
+		t.Cleanup(func() {
+			db.Exec(`DELETE FROM scrape_runs WHERE scraper_name = $1`, scraperName) //nolint:errcheck
+		})
+		start := time.Now().Add(-time.Minute)
+
+		run, err := repo.CreateScrapeRun(ctx, model.SourceLinkedIn, scraperName, "golang", "Berlin")
+		if err != nil {
+			t.Fatalf("CreateScrapeRun: %v", err)
+		}
+		if run.Status != model.ScrapeStatusRunning || run.ScraperName != scraperName || !run.StartedAt.Valid {
+			t.Errorf("unexpected new run: %+v", run)
+		}
+		stats := model.ScrapeRun{JobsFound: 5, JobsNew: 3, JobsUpdated: 2, PagesScraped: 1}
+		if err := repo.UpdateScrapeRun(ctx, run.ID, model.ScrapeStatusCompleted, stats); err != nil {
+			t.Fatalf("UpdateScrapeRun: %v", err)
+		}
+
+		got, err := repo.GetScrapeRunByID(ctx, run.ID)
+		if err != nil {
+			t.Fatalf("GetScrapeRunByID: %v", err)
+		}
+		if got.Status != model.ScrapeStatusCompleted || got.JobsFound != 5 || got.JobsNew != 3 ||
+			!got.CompletedAt.Valid || !got.DurationMs.Valid || got.SearchQuery != "golang" {
+			t.Errorf("unexpected completed run: %+v", got)
+		}
+		if _, err := repo.GetScrapeRunByID(ctx, uuid.New()); !errors.Is(err, ErrNotFound) {
+			t.Errorf("GetScrapeRunByID(unknown) error = %v, want ErrNotFound", err)
+		}
+
+		runs, total, err := repo.SearchScrapeRuns(ctx, model.ScrapeRunFilter{
+			ScraperName: scraperName, Status: model.ScrapeStatusCompleted, StartedAfter: &start,
+		})
+		if err != nil {
+			t.Fatalf("SearchScrapeRuns: %v", err)
+		}
+		if total != 1 || len(runs) != 1 || runs[0].ID != run.ID {
+			t.Errorf("SearchScrapeRuns = %d runs (total %d), want the new run", len(runs), total)
+		}
+		since, err := repo.GetScrapeRunsSince(ctx, start)
+		if err != nil {
+			t.Fatalf("GetScrapeRunsSince: %v", err)
+		}
+		if !containsRun(since, run.ID) {
+			t.Error("GetScrapeRunsSince is missing the new run")
+		}
+		if _, total, _ := repo.SearchScrapeRuns(ctx, model.ScrapeRunFilter{
+			ScraperName: scraperName, StartedBefore: &start,
+		}); total != 0 {
+			t.Errorf("SearchScrapeRuns(started before) total = %d, want 0", total)
+		}
+
+		stats2, err := repo.GetAdminStats(ctx)
+		if err != nil {
+			t.Fatalf("GetAdminStats: %v", err)
+		}
+		if !containsRun(stats2.RecentRuns, run.ID) {
+			t.Error("GetAdminStats recent runs are missing the new run")
+		}
+		var linkedIn *model.SourceStat
+		for i := range stats2.SourceStats {
+			if stats2.SourceStats[i].Source == string(model.SourceLinkedIn) {
+				linkedIn = &stats2.SourceStats[i]
+			}
+		}
+		if linkedIn == nil || linkedIn.SuccessfulRuns < 1 || linkedIn.LastSuccessfulRun == nil {
+			t.Errorf("GetAdminStats source stats = %+v, want a successful linkedin run", stats2.SourceStats)
+		}
+	})
+}
+
+func containsRun(runs []model.ScrapeRun, id uuid.UUID) bool {
+	for _, r := range runs {
+		if r.ID == id {
+			return true
+		}
+	}
+	return false
+}
+
+func TestConformance_ScraperHealth(t *testing.T) {
+	runConformance(t, func(t *testing.T, repo JobRepository, db *sql.DB) {
+		ctx := context.Background()
+		scraperName := "conformance-" + uuid.NewString()[:8]
+		t.Cleanup(func() {
+			db.Exec(`DELETE FROM scrape_runs WHERE scraper_name = $1`, scraperName)    //nolint:errcheck
+			db.Exec(`DELETE FROM scraper_health WHERE scraper_name = $1`, scraperName) //nolint:errcheck
+		})
+		finish := func(status model.ScrapeStatus, found int) {
+			t.Helper()
+			run, err := repo.CreateScrapeRun(ctx, model.SourceLinkedIn, scraperName, "golang", "")
+			if err != nil {
+				t.Fatalf("CreateScrapeRun: %v", err)
+			}
+			if err := repo.UpdateScrapeRun(ctx, run.ID, status, model.ScrapeRun{JobsFound: found}); err != nil {
+				t.Fatalf("UpdateScrapeRun: %v", err)
+			}
+		}
+
+		// No history yet.
+		h, err := repo.RefreshScraperHealth(ctx, scraperName)
+		if err != nil {
+			t.Fatalf("RefreshScraperHealth: %v", err)
+		}
+		if h.LastRunAt != nil || h.LastSuccessAt != nil || h.Runs7d != 0 || h.AvgJobsFound7d != 0 {
+			t.Errorf("unexpected health without runs: %+v", h)
+		}
+
+		finish(model.ScrapeStatusCompleted, 10)
+		finish(model.ScrapeStatusCompleted, 20)
+		finish(model.ScrapeStatusFailed, 0)
+		finish(model.ScrapeStatusCompleted, 3)
+		if h, err = repo.RefreshScraperHealth(ctx, scraperName); err != nil {
+			t.Fatalf("RefreshScraperHealth: %v", err)
+		}
+		if h.LastJobsFound != 3 || h.Runs7d != 2 || h.AvgJobsFound7d != 15 || h.ConsecutiveFailures != 0 || h.LastSuccessAt == nil {
+			t.Errorf("unexpected health after a success: %+v", h)
+		}
+
+		finish(model.ScrapeStatusInterrupted, 0)
+		finish(model.ScrapeStatusFailed, 0)
+		finish(model.ScrapeStatusRateLimited, 0)
+		if _, err = repo.RefreshScraperHealth(ctx, scraperName); err != nil {
+			t.Fatalf("RefreshScraperHealth: %v", err)
+		}
+		list, err := repo.ListScraperHealth(ctx)
+		if err != nil {
+			t.Fatalf("ListScraperHealth: %v", err)
+		}
+		var got *model.ScraperHealth
+		for i := range list {
+			if list[i].ScraperName == scraperName {
+				got = &list[i]
+			}
+		}
+		if got == nil {
+			t.Fatalf("ListScraperHealth did not return %s", scraperName)
+		}
+		if got.ConsecutiveFailures != 2 || got.LastJobsFound != 3 || got.AvgJobsFound7d != 15 ||
+			got.LastRunAt == nil || got.LastSuccessAt == nil || got.LastRunAt.Before(*got.LastSuccessAt) {
+			t.Errorf("unexpected stored health after failures: %+v", got)
+		}
+	})
+}
+
+func TestConformance_SchedulerControls(t *testing.T) {
+	runConformance(t, func(t *testing.T, repo JobRepository, db *sql.DB) {
+		ctx := context.Background()
+		scraperName := "conformance-" + uuid.NewString()[:8]
+		t.Cleanup(func() {
+			db.Exec(`DELETE FROM scrape_runs WHERE scraper_name = $1`, scraperName)      //nolint:errcheck
+			db.Exec(`DELETE FROM scraper_controls WHERE scraper_name = $1`, scraperName) //nolint:errcheck
+			db.Exec(`DELETE FROM scheduler_state`)                                       //nolint:errcheck
+		})
+
+		c, err := repo.GetSchedulerControls(ctx)
+		if err != nil {
+			t.Fatalf("GetSchedulerControls: %v", err)
+		}
+		if c.Paused {
+			t.Error("expected the schedule not to be paused before it ever was")
+		}
+		if _, ok := c.ScraperEnabled[scraperName]; ok {
+			t.Errorf("unexpected control for a new scraper: %+v", c)
+		}
+
+		for _, paused := range []bool{true, false, true} {
+			if err := repo.SetSchedulerPaused(ctx, paused); err != nil {
+				t.Fatalf("SetSchedulerPaused(%v): %v", paused, err)
+			}
+		}
+		if err := repo.SetScraperEnabled(ctx, scraperName, false); err != nil {
+			t.Fatalf("SetScraperEnabled: %v", err)
+		}
+		if c, err = repo.GetSchedulerControls(ctx); err != nil {
+			t.Fatalf("GetSchedulerControls: %v", err)
+		}
+		if enabled, ok := c.ScraperEnabled[scraperName]; !c.Paused || !ok || enabled {
+			t.Errorf("unexpected controls after pausing and disabling: %+v", c)
+		}
+
+		if err := repo.SetScraperEnabled(ctx, scraperName, true); err != nil {
+			t.Fatalf("SetScraperEnabled: %v", err)
+		}
+		if c, err = repo.GetSchedulerControls(ctx); err != nil || !c.ScraperEnabled[scraperName] {
+			t.Errorf("scraper not re-enabled: %+v (%v)", c, err)
+		}
+
+		// Skipped runs are recorded in the run history.
+		run, err := repo.CreateScrapeRun(ctx, model.SourceLinkedIn, scraperName, "", "")
+		if err != nil {
+			t.Fatalf("CreateScrapeRun: %v", err)
+		}
+		if err := repo.UpdateScrapeRun(ctx, run.ID, model.ScrapeStatusSkipped, model.ScrapeRun{ErrorMessage: "disabled"}); err != nil {
+			t.Fatalf("UpdateScrapeRun: %v", err)
+		}
+		got, err := repo.GetScrapeRunByID(ctx, run.ID)
+		if err != nil || got.Status != model.ScrapeStatusSkipped || got.ErrorMessage != "disabled" {
+			t.Errorf("unexpected skipped run %+v (%v)", got, err)
+		}
+	})
+}
+
+func TestConformance_SavedJobs(t *testing.T) {
+	runConformance(t, func(t *testing.T, repo JobRepository, db *sql.DB) {
+		ctx := context.Background()
+		company := testCompany(t, db)
+		userID := uuid.New()
+		t.Cleanup(func() {
+			db.Exec(`DELETE FROM saved_jobs WHERE user_id = $1`, userID) //nolint:errcheck
+		})
+		active := mustUpsert(t, repo, testJob(company, "sv-1", "Platform Engineer"))
+		expired := mustUpsert(t, repo, testJob(company, "sv-2", "Data Engineer"))
+		deleted := mustUpsert(t, repo, testJob(company, "sv-3", "Frontend Engineer"))
+
+		saved, created, err := repo.SaveJob(ctx, userID, active.ID)
+		if err != nil {
+			t.Fatalf("SaveJob: %v", err)
+		}
+		if !created || saved.Status != model.ApplicationSaved || saved.Title != "Platform Engineer" {
+			t.Errorf("unexpected saved job %+v (created %v)", saved, created)
+		}
+		again, created, err := repo.SaveJob(ctx, userID, active.ID)
+		if err != nil || created || again.ID != saved.ID {
+			t.Errorf("saving again = %+v, %v, %v, want the existing entry", again, created, err)
+		}
+		if _, _, err := repo.SaveJob(ctx, userID, uuid.New()); !errors.Is(err, ErrNotFound) {
+			t.Errorf("SaveJob(unknown job) error = %v, want ErrNotFound", err)
+		}
+
+		// Through the funnel, skipping interviewing.
+		for _, status := range []model.ApplicationStatus{model.ApplicationApplied, model.ApplicationOffer, model.ApplicationOffer} {
+			if saved, err = repo.UpdateSavedJobStatus(ctx, userID, saved.ID, status); err != nil {
+				t.Fatalf("UpdateSavedJobStatus(%s): %v", status, err)
+			}
+		}
+		if saved.Status != model.ApplicationOffer || saved.AppliedAt == nil || saved.OfferAt == nil || saved.InterviewingAt != nil {
+			t.Errorf("unexpected saved job after applying and an offer: %+v", saved)
+		}
+		if _, err := repo.UpdateSavedJobStatus(ctx, userID, saved.ID, model.ApplicationSaved); !errors.Is(err, ErrInvalidTransition) {
+			t.Errorf("offer to saved error = %v, want ErrInvalidTransition", err)
+		}
+		if _, err := repo.UpdateSavedJobStatus(ctx, uuid.New(), saved.ID, model.ApplicationRejected); !errors.Is(err, ErrNotFound) {
+			t.Errorf("updating another user's saved job error = %v, want ErrNotFound", err)
+		}
+
+		// Saved jobs outlive the jobs the source has removed since.
+		for _, job := range []*model.Job{expired, deleted} {
+			if _, _, err := repo.SaveJob(ctx, userID, job.ID); err != nil {
+				t.Fatalf("SaveJob: %v", err)
+			}
+		}
+		if _, err := db.Exec(`UPDATE jobs SET status = 'expired' WHERE id = $1`, expired.ID); err != nil {
+			t.Fatalf("expire job: %v", err)
+		}
+		if _, err := db.Exec(`DELETE FROM jobs WHERE id = $1`, deleted.ID); err != nil {
+			t.Fatalf("delete job: %v", err)
+		}
+
+		list, total, err := repo.ListSavedJobs(ctx, userID, model.SavedJobFilter{Limit: 10})
+		if err != nil {
+			t.Fatalf("ListSavedJobs: %v", err)
+		}
+		if total != 3 || len(list) != 3 {
+			t.Fatalf("ListSavedJobs = %d of %d, want 3", len(list), total)
+		}
+		byJob := map[uuid.UUID]model.SavedJob{}
+		for _, s := range list {
+			byJob[s.JobID] = s
+		}
+		if s := byJob[active.ID]; s.JobRemoved || s.Job == nil || s.Job.ID != active.ID {
+			t.Errorf("active job: removed=%v job=%v", s.JobRemoved, s.Job)
+		}
+		if s := byJob[expired.ID]; !s.JobRemoved || s.Job == nil || s.Job.Status != model.StatusExpired {
+			t.Errorf("expired job: removed=%v job=%v", s.JobRemoved, s.Job)
+		}
+		if s := byJob[deleted.ID]; !s.JobRemoved || s.Job != nil || s.Title != "Frontend Engineer" {
+			t.Errorf("deleted job: removed=%v job=%v title=%q", s.JobRemoved, s.Job, s.Title)
+		}
+
+		offers, total, err := repo.ListSavedJobs(ctx, userID, model.SavedJobFilter{Status: model.ApplicationOffer, Limit: 10})
+		if err != nil || total != 1 || len(offers) != 1 || offers[0].ID != saved.ID {
+			t.Errorf("ListSavedJobs(offer) = %d of %d (%v), want the active job", len(offers), total, err)
+		}
+
+		if err := repo.UnsaveJob(ctx, userID, active.ID); err != nil {
+			t.Fatalf("UnsaveJob: %v", err)
+		}
+		if err := repo.UnsaveJob(ctx, userID, active.ID); !errors.Is(err, ErrNotFound) {
+			t.Errorf("UnsaveJob again error = %v, want ErrNotFound", err)
+		}
+
+		// Erasing the user removes the rest, and erasing again removes nothing.
+		other := uuid.New()
+		t.Cleanup(func() {
+			db.Exec(`DELETE FROM saved_jobs WHERE user_id = $1`, other) //nolint:errcheck
+		})
+		if _, _, err := repo.SaveJob(ctx, other, active.ID); err != nil {
+			t.Fatalf("SaveJob(other user): %v", err)
+		}
+		for _, want := range []int{2, 0} {
+			if n, err := repo.DeleteUserSavedJobs(ctx, userID); err != nil || n != want {
+				t.Errorf("DeleteUserSavedJobs = %d, %v, want %d", n, err, want)
+			}
+		}
+		if _, total, err := repo.ListSavedJobs(ctx, other, model.SavedJobFilter{Limit: 10}); err != nil || total != 1 {
+			t.Errorf("other user's saved jobs = %d (%v), want 1", total, err)
+		}
+	})
+}
+
+func TestConformance_SavedJobScore(t *testing.T) {
+	runConformance(t, func(t *testing.T, repo JobRepository, db *sql.DB) {
+		ctx := context.Background()
+		company := testCompany(t, db)
+		userID := uuid.New()
+		t.Cleanup(func() {
+			db.Exec(`DELETE FROM saved_jobs WHERE user_id = $1`, userID) //nolint:errcheck
+		})
+		job := mustUpsert(t, repo, testJob(company, "sc-1", "Platform Engineer"))
+		saved, _, err := repo.SaveJob(ctx, userID, job.ID)
+		if err != nil {
+			t.Fatalf("SaveJob: %v", err)
+		}
+		if saved.MatchScore != nil || saved.ScoreComputedAt != nil {
+			t.Errorf("new saved job is scored: %v at %v", saved.MatchScore, saved.ScoreComputedAt)
+		}
+
+		at := time.Now().Add(-time.Minute).Truncate(time.Second)
+		scored, err := repo.SetSavedJobScore(ctx, userID, saved.ID, 72.5, at)
+		if err != nil {
+			t.Fatalf("SetSavedJobScore: %v", err)
+		}
+		if scored.MatchScore == nil || *scored.MatchScore != 72.5 || scored.ScoreComputedAt == nil || !scored.ScoreComputedAt.Equal(at) {
+			t.Errorf("score = %v at %v, want 72.5 at %v", scored.MatchScore, scored.ScoreComputedAt, at)
+		}
+
+		// A score computed before the stored one does not replace it.
+		stale, err := repo.SetSavedJobScore(ctx, userID, saved.ID, 10, at.Add(-time.Hour))
+		if err != nil {
+			t.Fatalf("SetSavedJobScore(stale): %v", err)
+		}
+		if *stale.MatchScore != 72.5 {
+			t.Errorf("stale score stored: %v", *stale.MatchScore)
+		}
+		list, _, err := repo.ListSavedJobs(ctx, userID, model.SavedJobFilter{Limit: 10})
+		if err != nil || len(list) != 1 || list[0].MatchScore == nil || *list[0].MatchScore != 72.5 {
+			t.Errorf("ListSavedJobs = %+v (%v), want the score", list, err)
+		}
+
+		if _, err := repo.SetSavedJobScore(ctx, uuid.New(), saved.ID, 50, at); !errors.Is(err, ErrNotFound) {
+			t.Errorf("scoring another user's saved job error = %v, want ErrNotFound", err)
+		}
+	})
+}
+
+func TestConformance_LocationCache(t *testing.T) {
+	runConformance(t, func(t *testing.T, repo JobRepository, db *sql.DB) {
+		ctx := context.Background()
+		raw := "Conformance City " + uuid.NewString()[:8] + ", ID"
+		t.Cleanup(func() {
+			db.Exec(`DELETE FROM location_cache WHERE raw_key = $1`, locationCacheKey(raw)) //nolint:errcheck
+		})
+
+		if _, ok, err := repo.GetCachedLocation(ctx, raw); err != nil || ok {
+			t.Fatalf("GetCachedLocation before caching = %v, %v; want a miss", ok, err)
+		}
+		first := geo.Location{City: "Conformance City", Region: "ID", Country: "US"}
+		if err := repo.PutCachedLocation(ctx, raw, first); err != nil {
+			t.Fatalf("PutCachedLocation: %v", err)
+		}
+		want := geo.Location{City: "Conformance City", Country: "ID", Hybrid: true}
+		if err := repo.PutCachedLocation(ctx, raw, want); err != nil {
+			t.Fatalf("PutCachedLocation again: %v", err)
+		}
+		// Keys ignore case and spacing.
+		got, ok, err := repo.GetCachedLocation(ctx, "  "+strings.ToUpper(raw))
+		if err != nil || !ok || got != want {
+			t.Errorf("GetCachedLocation = %+v, %v, %v; want %+v", got, ok, err, want)
+		}
+
+		// Locations parsed by another parser version are misses.
+		db.Exec(`UPDATE location_cache SET parser_version = $1 WHERE raw_key = $2`, geo.Version-1, locationCacheKey(raw)) //nolint:errcheck
+		if _, ok, err := repo.GetCachedLocation(ctx, raw); err != nil || ok {
+			t.Errorf("GetCachedLocation of an old version = %v, %v; want a miss", ok, err)
+		}
+	})
+}
+
+func TestConformance_CareerPages(t *testing.T) {
+	runConformance(t, func(t *testing.T, repo JobRepository, db *sql.DB) {
+		ctx := context.Background()
+		url := "https://boards.greenhouse.io/conformance" + uuid.NewString()[:8]
+		t.Cleanup(func() {
+			db.Exec(`DELETE FROM company_career_pages WHERE career_page_url = $1`, url) //nolint:errcheck
+		})
+
+		page := &model.CompanyCareerPage{
+			CompanyName:   "Conformance Careers",
+			CareerPageURL: url,
+			SourceType:    model.CareerPageGreenhouse,
+		}
+		if err := repo.CreateCareerPage(ctx, page); err != nil {
+			t.Fatalf("CreateCareerPage: %v", err)
+		}
+		if page.ID == uuid.Nil || !page.IsEnabled || page.CreatedAt.IsZero() {
+			t.Errorf("career page not filled in: %+v", page)
+		}
+		dup := &model.CompanyCareerPage{CompanyName: "Conformance Careers", CareerPageURL: url}
+		if err := repo.CreateCareerPage(ctx, dup); !errors.Is(err, ErrDuplicate) {
+			t.Errorf("duplicate CreateCareerPage error = %v, want ErrDuplicate", err)
+		}
+
+		pages, err := repo.GetCareerPages(ctx)
+		if err != nil {
+			t.Fatalf("GetCareerPages: %v", err)
+		}
+		var found *model.CompanyCareerPage
+		for i := range pages {
+			if pages[i].CareerPageURL == url {
+				found = &pages[i]
+			}
+		}
+		if found == nil {
+			t.Fatal("GetCareerPages is missing the new page")
+		}
+		if found.ID != page.ID || found.SourceType != model.CareerPageGreenhouse || string(found.Selectors) != "{}" {
+			t.Errorf("unexpected career page: %+v", found)
+		}
+	})
+}
+
+func TestConformance_ResolveCompany(t *testing.T) {
+	runConformance(t, func(t *testing.T, repo JobRepository, db *sql.DB) {
+		ctx := context.Background()
+		name := testCompany(t, db)
+		domain := strings.ToLower(strings.ReplaceAll(name, " ", "-")) + ".com"
+
+		company, err := repo.ResolveCompany(ctx, name, "https://careers."+domain+"/jobs")
+		if err != nil {
+			t.Fatalf("ResolveCompany: %v", err)
+		}
+		if !company.NeedsReview || company.WebsiteDomain.String != domain {
+			t.Errorf("new company should need review with its domain: %+v", company)
+		}
+		for _, variant := range []string{strings.ToUpper(name), name + " LLC", "PT " + name + " Tbk", name + " Indonesia", name + " Asia Pacific"} {
+			got, err := repo.ResolveCompany(ctx, variant, "")
+			if err != nil {
+				t.Fatalf("ResolveCompany(%q): %v", variant, err)
+			}
+			if got.ID != company.ID {
+				t.Errorf("ResolveCompany(%q) = %v, want %v", variant, got.ID, company.ID)
+			}
+		}
+		byDomain, err := repo.ResolveCompany(ctx, name+" Labs", "https://www."+domain)
+		if err != nil {
+			t.Fatalf("ResolveCompany by domain: %v", err)
+		}
+		if byDomain.ID != company.ID {
+			t.Errorf("domain match resolved to %v, want %v", byDomain.ID, company.ID)
+		}
+		other, err := repo.ResolveCompany(ctx, name+" Foods", "")
+		if err != nil {
+			t.Fatalf("ResolveCompany: %v", err)
+		}
+		if other.ID == company.ID {
+			t.Error("a different name without a domain should create a company")
+		}
+
+		got, err := repo.GetCompany(ctx, company.ID)
+		if err != nil {
+			t.Fatalf("GetCompany: %v", err)
+		}
+		key := strings.ToLower(name)
+		if !containsString(got.Aliases, key) || !containsString(got.Aliases, key+" indonesia") || !containsString(got.Aliases, key+" labs") {
+			t.Errorf("aliases = %v", got.Aliases)
+		}
+
+		// Jobs are linked to their company, and can be filtered by it.
+		job := testJob(name+" Indonesia", "co-1", "Go Developer")
+		stored := mustUpsert(t, repo, job)
+		if stored.CompanyID == nil || *stored.CompanyID != company.ID {
+			t.Fatalf("job company = %v, want %v", stored.CompanyID, company.ID)
+		}
+		mustUpsert(t, repo, testJob(name+" Foods", "co-2", "Go Developer"))
+		batch := []*model.ScrapedJob{testJob(name, "co-3", "Data Engineer")}
+		if _, err := repo.UpsertJobs(ctx, batch); err != nil {
+			t.Fatalf("UpsertJobs: %v", err)
+		}
+
+		jobs, total, _, err := repo.SearchJobs(ctx, model.JobFilter{CompanyID: &company.ID})
+		if err != nil {
+			t.Fatalf("SearchJobs: %v", err)
+		}
+		if total != 2 || len(jobs) != 2 {
+			t.Errorf("SearchJobs(company ID) = %d jobs (total %d), want 2", len(jobs), total)
+		}
+		results, total, _, err := repo.FullTextSearch(ctx, model.JobSearchFilter{Query: "developer", CompanyID: &company.ID})
+		if err != nil {
+			t.Fatalf("FullTextSearch: %v", err)
+		}
+		if total != 1 || len(results) != 1 || results[0].ID != stored.ID {
+			t.Errorf("FullTextSearch(company ID) = %d results (total %d), want the Go Developer", len(results), total)
+		}
+
+		reviewed := false
+		if _, err := repo.UpdateCompany(ctx, company.ID, model.CompanyUpdate{NeedsReview: &reviewed}); err != nil {
+			t.Fatalf("UpdateCompany: %v", err)
+		}
+		flagged := true
+		listed, total, err := repo.ListCompanies(ctx, model.CompanyFilter{Query: name, NeedsReview: &flagged})
+		if err != nil {
+			t.Fatalf("ListCompanies: %v", err)
+		}
+		if total != 1 || len(listed) != 1 || listed[0].ID != other.ID {
+			t.Errorf("ListCompanies(needs review) = %+v (total %d), want only %v", listed, total, other.ID)
+		}
+		taken := []string{name + " Foods"}
+		if _, err := repo.UpdateCompany(ctx, company.ID, model.CompanyUpdate{Aliases: &taken}); !errors.Is(err, ErrDuplicate) {
+			t.Errorf("UpdateCompany with another company's alias: err = %v, want ErrDuplicate", err)
+		}
+		if _, err := repo.GetCompany(ctx, uuid.New()); !errors.Is(err, ErrNotFound) {
+			t.Errorf("GetCompany(unknown) err = %v, want ErrNotFound", err)
+		}
+	})
+}
+
+func TestConformance_MergeCompanies(t *testing.T) {
+	runConformance(t, func(t *testing.T, repo JobRepository, db *sql.DB) {
+		ctx := context.Background()
+		name := testCompany(t, db)
+		url := "https://jobs.lever.co/conformance" + uuid.NewString()[:8]
+		t.Cleanup(func() {
+			db.Exec(`DELETE FROM company_career_pages WHERE career_page_url = $1`, url) //nolint:errcheck
+		})
+
+		target := mustUpsert(t, repo, testJob(name, "merge-1", "Go Developer"))
+		source := mustUpsert(t, repo, testJob(name+" Holdings", "merge-2", "Data Analyst"))
+		mustUpsert(t, repo, testJob(name+" Holdings", "merge-3", "Designer"))
+		if target.CompanyID == nil || source.CompanyID == nil || *target.CompanyID == *source.CompanyID {
+			t.Fatalf("expected two companies, got %v and %v", target.CompanyID, source.CompanyID)
+		}
+		page := &model.CompanyCareerPage{
+			CompanyID:     source.CompanyID,
+			CompanyName:   name + " Holdings",
+			CareerPageURL: url,
+			SourceType:    model.CareerPageLever,
+		}
+		if err := repo.CreateCareerPage(ctx, page); err != nil {
+			t.Fatalf("CreateCareerPage: %v", err)
+		}
+
+		if _, err := repo.MergeCompanies(ctx, *target.CompanyID, *target.CompanyID); !errors.Is(err, ErrSelfMerge) {
+			t.Errorf("self merge err = %v, want ErrSelfMerge", err)
+		}
+		if _, err := repo.MergeCompanies(ctx, uuid.New(), *target.CompanyID); !errors.Is(err, ErrNotFound) {
+			t.Errorf("merge of unknown company err = %v, want ErrNotFound", err)
+		}
+
+		merge, err := repo.MergeCompanies(ctx, *source.CompanyID, *target.CompanyID)
+		if err != nil {
+			t.Fatalf("MergeCompanies: %v", err)
+		}
+		if merge.JobsMoved != 2 || merge.CareerPagesMoved != 1 || merge.MergedID != *source.CompanyID ||
+			merge.Company.ID != *target.CompanyID || merge.Company.NeedsReview {
+			t.Errorf("unexpected merge: %+v", merge)
+		}
+		if !containsString(merge.Company.Aliases, strings.ToLower(name)+" holdings") {
+			t.Errorf("merged aliases = %v", merge.Company.Aliases)
+		}
+		if _, err := repo.GetCompany(ctx, *source.CompanyID); !errors.Is(err, ErrNotFound) {
+			t.Errorf("GetCompany(source) err = %v, want ErrNotFound", err)
+		}
+
+		var jobsLeft, pagesLeft int
+		if err := db.QueryRowContext(ctx, `SELECT COUNT(*) FROM jobs WHERE company_id = $1`, *source.CompanyID).Scan(&jobsLeft); err != nil {
+			t.Fatalf("count jobs: %v", err)
+		}
+		if err := db.QueryRowContext(ctx, `SELECT COUNT(*) FROM company_career_pages WHERE company_id = $1`, *source.CompanyID).Scan(&pagesLeft); err != nil {
+			t.Fatalf("count career pages: %v", err)
+		}
+		if jobsLeft != 0 || pagesLeft != 0 {
+			t.Errorf("%d jobs and %d career pages still reference the merged company", jobsLeft, pagesLeft)
+		}
+		if _, total, _, err := repo.SearchJobs(ctx, model.JobFilter{CompanyID: target.CompanyID}); err != nil || total != 3 {
+			t.Errorf("SearchJobs(target) total = %d, err = %v, want 3", total, err)
+		}
+
+		// The source's name now resolves to the target.
+		again := mustUpsert(t, repo, testJob(name+" Holdings", "merge-4", "Platform Engineer"))
+		if again.CompanyID == nil || *again.CompanyID != *target.CompanyID {
+			t.Errorf("re-ingested job company = %v, want %v", again.CompanyID, *target.CompanyID)
+		}
+	})
+}
+
+// containsString reports whether values contains s.
+func containsString(values []string, s string) bool {
+	for _, v := range values {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+func TestConformance_AdminStatsCountsJobs(t *testing.T) {
+	runConformance(t, func(t *testing.T, repo JobRepository, db *sql.DB) {
+		ctx := context.Background()
+		company := testCompany(t, db)
+		mustUpsert(t, repo, testJob(company, "as-1", "Frontend Engineer"))
+
+		stats, err := repo.GetAdminStats(ctx)
+		if err != nil {
+			t.Fatalf("GetAdminStats: %v", err)
+		}
+		if stats.TotalJobs < 1 || stats.ActiveJobs < 1 || stats.JobsBySource[string(model.SourceLinkedIn)] < 1 {
+			t.Errorf("unexpected stats: total=%d active=%d by source=%v",
+				stats.TotalJobs, stats.ActiveJobs, stats.JobsBySource)
+		}
+	})
+}
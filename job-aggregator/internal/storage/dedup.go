@@ -0,0 +1,386 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/learnbot/job-aggregator/internal/model"
+)
+
+// DefaultFuzzyDedupThreshold is the title similarity at or above which a
+// newly scraped posting is merged into an existing job from the same
+// company.
+const DefaultFuzzyDedupThreshold = 0.85
+
+// fuzzyDedupWindow limits merge candidates to recently created jobs.
+const fuzzyDedupWindow = 30 * 24 * time.Hour
+
+// SetFuzzyDedupThreshold sets the title similarity, between 0 and 1, at
+// which UpsertJob merges a new posting into an existing job. A threshold of
+// 0 disables fuzzy deduplication.
+func (r *PostgresRepository) SetFuzzyDedupThreshold(threshold float64) {
+	r.dedupThreshold = threshold
+}
+
+// dedupCandidate is an existing active job a new posting may duplicate.
+type dedupCandidate struct {
+	ID           uuid.UUID
+	Source       model.JobSource
+	ExternalID   string
+	Title        string
+	CompanyName  string
+	LocationCity string
+}
+
+// dedupMatch is the canonical job a scraped posting duplicates.
+type dedupMatch struct {
+	JobID      uuid.UUID
+	Title      string
+	Similarity float64
+	// Logged is set when the posting was merged on an earlier scrape and
+	// already has a dedup_log entry.
+	Logged bool
+}
+
+// findDuplicate returns the canonical job a scraped posting with a new dedup
+// hash should be merged into, or nil if it is a new job. A posting merged
+// earlier is found through dedup_log; otherwise recent active jobs from the
+// same company are compared by title. The queries are shared by the
+// PostgreSQL and SQLite backends.
+func findDuplicate(ctx context.Context, db *sql.DB, scraped *model.ScrapedJob, hash string, threshold float64) (*dedupMatch, error) {
+	var exists bool
+	if err := db.QueryRowContext(ctx,
+		`SELECT EXISTS (SELECT 1 FROM jobs WHERE dedup_hash = $1)`, hash,
+	).Scan(&exists); err != nil {
+		return nil, fmt.Errorf("check dedup hash: %w", err)
+	}
+	if exists {
+		return nil, nil
+	}
+	return matchNewPosting(ctx, db, scraped, hash, threshold)
+}
+
+// matchNewPosting is findDuplicate for a posting whose dedup hash is known
+// not to be stored.
+func matchNewPosting(ctx context.Context, db *sql.DB, scraped *model.ScrapedJob, hash string, threshold float64) (*dedupMatch, error) {
+	m := &dedupMatch{Logged: true}
+	err := db.QueryRowContext(ctx,
+		`SELECT job_id, canonical_title, similarity FROM dedup_log WHERE dedup_hash = $1`, hash,
+	).Scan(&m.JobID, &m.Title, &m.Similarity)
+	if err == nil {
+		return m, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("get dedup log entry: %w", err)
+	}
+
+	// Company names are compared normalised in Go; the query only narrows
+	// the candidates to names that start with the same word.
+	key := dedupCompanyKey(scraped.CompanyName)
+	if key == "" {
+		return nil, nil
+	}
+	rows, err := db.QueryContext(ctx, `
+		SELECT id, source, COALESCE(external_id, ''), title, company_name,
+		       COALESCE(location_city, '')
+		FROM jobs
+		WHERE status = 'active'
+		  AND created_at >= $1
+		  AND lower(company_name) LIKE $2 ESCAPE '\'`,
+		time.Now().UTC().Add(-fuzzyDedupWindow), escapeLike(strings.Fields(key)[0])+"%",
+	)
+	if err != nil {
+		return nil, fmt.Errorf("list dedup candidates: %w", err)
+	}
+	defer rows.Close()
+
+	var candidates []dedupCandidate
+	for rows.Next() {
+		var c dedupCandidate
+		if err := rows.Scan(&c.ID, &c.Source, &c.ExternalID, &c.Title, &c.CompanyName, &c.LocationCity); err != nil {
+			return nil, fmt.Errorf("scan dedup candidate: %w", err)
+		}
+		if dedupCompanyKey(c.CompanyName) == key {
+			candidates = append(candidates, c)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("list dedup candidates: %w", err)
+	}
+
+	best, score, ok := bestDuplicate(scraped, candidates, threshold)
+	if !ok {
+		return nil, nil
+	}
+	return &dedupMatch{JobID: best.ID, Title: best.Title, Similarity: score}, nil
+}
+
+// mergeDuplicate records scraped as a duplicate of the matched job and
+// refreshes that job as if it had been seen again, adding the posting's
+// application URL to its source URLs.
+func (r *PostgresRepository) mergeDuplicate(ctx context.Context, m *dedupMatch, scraped *model.ScrapedJob, hash string) (*model.Job, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("merge duplicate job: %w", err)
+	}
+	defer tx.Rollback() //nolint:errcheck
+
+	if !m.Logged {
+		if _, err := tx.ExecContext(ctx, `
+			INSERT INTO dedup_log (
+				job_id, dedup_hash, source, external_id, title, company_name,
+				application_url, canonical_title, similarity, threshold
+			) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+			ON CONFLICT (dedup_hash) DO NOTHING`,
+			m.JobID, hash, scraped.Source, scraped.ExternalID, scraped.Title, scraped.CompanyName,
+			scraped.ApplicationURL, m.Title, m.Similarity, r.dedupThreshold,
+		); err != nil {
+			return nil, fmt.Errorf("insert dedup log entry: %w", err)
+		}
+	}
+
+	res, err := tx.ExecContext(ctx, `
+		UPDATE jobs SET
+			last_seen_at  = NOW(),
+			last_seen_by  = COALESCE($3, last_seen_by),
+			status        = 'active',
+			expiry_reason = NULL,
+			expired_at    = NULL,
+			source_urls   = CASE WHEN $2 = '' OR $2 = ANY(source_urls) THEN source_urls
+			                     ELSE array_append(source_urls, $2) END,
+			updated_at    = NOW()
+		WHERE id = $1`,
+		m.JobID, scraped.ApplicationURL, nullString(scraped.ScraperName),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("update canonical job: %w", err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return nil, ErrNotFound
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("merge duplicate job: %w", err)
+	}
+	return r.GetJobByID(ctx, m.JobID)
+}
+
+// ListDedupLog returns fuzzy dedup merges, newest first. A non-nil jobID
+// limits the entries to merges into that job.
+func (r *PostgresRepository) ListDedupLog(ctx context.Context, jobID *uuid.UUID, page, pageSize int) ([]model.DedupLogEntry, int, error) {
+	return listDedupLog(ctx, r.db, jobID, page, pageSize)
+}
+
+// listDedupLog implements ListDedupLog for both backends.
+func listDedupLog(ctx context.Context, db *sql.DB, jobID *uuid.UUID, page, pageSize int) ([]model.DedupLogEntry, int, error) {
+	where := "1=1"
+	var args []interface{}
+	if jobID != nil {
+		where = "job_id = $1"
+		args = append(args, *jobID)
+	}
+
+	var total int
+	if err := db.QueryRowContext(ctx,
+		"SELECT COUNT(*) FROM dedup_log WHERE "+where, args...,
+	).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("count dedup log: %w", err)
+	}
+
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 {
+		pageSize = 20
+	}
+	idx := len(args) + 1
+	args = append(args, pageSize, (page-1)*pageSize)
+
+	rows, err := db.QueryContext(ctx, fmt.Sprintf(`
+		SELECT id, job_id, dedup_hash, source, external_id, title, company_name,
+		       application_url, canonical_title, similarity, threshold, created_at
+		FROM dedup_log
+		WHERE %s
+		ORDER BY created_at DESC
+		LIMIT $%d OFFSET $%d`, where, idx, idx+1), args...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("list dedup log: %w", err)
+	}
+	defer rows.Close()
+
+	var entries []model.DedupLogEntry
+	for rows.Next() {
+		var e model.DedupLogEntry
+		if err := rows.Scan(
+			&e.ID, &e.JobID, &e.DedupHash, &e.Source, &e.ExternalID, &e.Title,
+			&e.CompanyName, &e.ApplicationURL, &e.CanonicalTitle,
+			&e.Similarity, &e.Threshold, &e.CreatedAt,
+		); err != nil {
+			return nil, 0, fmt.Errorf("scan dedup log entry: %w", err)
+		}
+		entries = append(entries, e)
+	}
+	return entries, total, rows.Err()
+}
+
+// bestDuplicate returns the candidate whose title is most similar to the
+// scraped posting's, if that similarity reaches threshold. Candidates from
+// the same source with a different external ID are distinct postings, as are
+// candidates in a different city.
+func bestDuplicate(scraped *model.ScrapedJob, candidates []dedupCandidate, threshold float64) (dedupCandidate, float64, bool) {
+	if threshold <= 0 {
+		return dedupCandidate{}, 0, false
+	}
+
+	title := normalizeTitle(scraped.Title)
+	city := strings.ToLower(strings.TrimSpace(scraped.LocationCity))
+
+	var best dedupCandidate
+	var bestScore float64
+	for _, c := range candidates {
+		if c.Source == scraped.Source && c.ExternalID != "" && scraped.ExternalID != "" {
+			continue
+		}
+		if cc := strings.ToLower(strings.TrimSpace(c.LocationCity)); city != "" && cc != "" && cc != city {
+			continue
+		}
+		if score := titleSimilarity(title, normalizeTitle(c.Title)); score > bestScore {
+			best, bestScore = c, score
+		}
+	}
+	if bestScore < threshold {
+		return dedupCandidate{}, bestScore, false
+	}
+	return best, bestScore, true
+}
+
+var (
+	// titleWorkplaceRe matches a workplace qualifier in a title, such as
+	// "(Remote)", "[Hybrid - NYC]" or a trailing "- Remote".
+	titleWorkplaceRe = regexp.MustCompile(`[(\[](?:remote|hybrid|on-?site)\b[^)\]]*[)\]]|\s[-–|,/]\s*(?:remote|hybrid|on-?site)\b.*$`)
+	titleTokenRe     = regexp.MustCompile(`[a-z0-9+#]+`)
+
+	// titleAbbreviations expands common abbreviations so that "Sr." and
+	// "Senior" compare equal.
+	titleAbbreviations = map[string]string{
+		"sr": "senior", "snr": "senior", "jr": "junior",
+		"eng": "engineer", "engr": "engineer", "dev": "developer",
+		"mgr": "manager", "mngr": "manager", "assoc": "associate",
+		"admin": "administrator", "ops": "operations",
+		"1": "i", "2": "ii", "3": "iii", "4": "iv",
+	}
+
+	// titleLevels are tokens that distinguish otherwise identical titles:
+	// "Software Engineer II" is not a duplicate of "Software Engineer III".
+	titleLevels = map[string]bool{
+		"intern": true, "junior": true, "senior": true, "staff": true,
+		"principal": true, "lead": true, "head": true,
+		"i": true, "ii": true, "iii": true, "iv": true, "v": true,
+	}
+)
+
+// normalizeTitle lowercases a job title, drops workplace qualifiers and
+// punctuation, and expands common abbreviations.
+func normalizeTitle(title string) string {
+	title = titleWorkplaceRe.ReplaceAllString(strings.ToLower(title), " ")
+	tokens := titleTokenRe.FindAllString(title, -1)
+	for i, t := range tokens {
+		if full, ok := titleAbbreviations[t]; ok {
+			tokens[i] = full
+		}
+	}
+	return strings.Join(tokens, " ")
+}
+
+// titleSimilarity compares two normalised titles, returning a score between
+// 0 and 1. Word order is ignored, and titles at different levels score 0.
+// The score is the Sørensen–Dice coefficient of the titles' character
+// bigrams, which tolerates small spelling differences.
+func titleSimilarity(a, b string) float64 {
+	ta, tb := strings.Fields(a), strings.Fields(b)
+	if len(ta) == 0 || len(tb) == 0 {
+		return 0
+	}
+	if !sameLevels(ta, tb) {
+		return 0
+	}
+	sort.Strings(ta)
+	sort.Strings(tb)
+	sa, sb := strings.Join(ta, " "), strings.Join(tb, " ")
+	if sa == sb {
+		return 1
+	}
+
+	ba, bb := bigrams(sa), bigrams(sb)
+	if len(ba) == 0 || len(bb) == 0 {
+		return 0
+	}
+	counts := make(map[string]int, len(ba))
+	for _, g := range ba {
+		counts[g]++
+	}
+	shared := 0
+	for _, g := range bb {
+		if counts[g] > 0 {
+			counts[g]--
+			shared++
+		}
+	}
+	return 2 * float64(shared) / float64(len(ba)+len(bb))
+}
+
+func sameLevels(a, b []string) bool {
+	levels := func(tokens []string) string {
+		var ls []string
+		for _, t := range tokens {
+			if titleLevels[t] {
+				ls = append(ls, t)
+			}
+		}
+		sort.Strings(ls)
+		return strings.Join(ls, " ")
+	}
+	return levels(a) == levels(b)
+}
+
+func bigrams(s string) []string {
+	r := []rune(s)
+	if len(r) < 2 {
+		return nil
+	}
+	grams := make([]string, 0, len(r)-1)
+	for i := 0; i < len(r)-1; i++ {
+		grams = append(grams, string(r[i:i+2]))
+	}
+	return grams
+}
+
+// companySuffixes are legal-entity words dropped when comparing company
+// names.
+var companySuffixes = map[string]bool{
+	"inc": true, "llc": true, "ltd": true, "corp": true, "corporation": true,
+	"co": true, "company": true, "gmbh": true, "plc": true, "limited": true,
+}
+
+// dedupCompanyKey normalises a company name for fuzzy deduplication: it is
+// lowercased, punctuation is dropped, and trailing legal-entity suffixes are
+// removed, so "Acme, Inc." and "ACME Inc" share a key.
+func dedupCompanyKey(name string) string {
+	tokens := titleTokenRe.FindAllString(normalizeCompanyName(name), -1)
+	for len(tokens) > 1 && companySuffixes[tokens[len(tokens)-1]] {
+		tokens = tokens[:len(tokens)-1]
+	}
+	return strings.Join(tokens, " ")
+}
+
+// escapeLike escapes the LIKE wildcards in s.
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
@@ -126,3 +126,87 @@ func TestNullString(t *testing.T) {
 		t.Errorf("NullString.String = %q, want %q", ns2.String, "hello")
 	}
 }
+
+func TestNormalizeTitle(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"Sr. Software Engineer", "senior software engineer"},
+		{"Senior Software Engineer (Remote)", "senior software engineer"},
+		{"Software Engineer - Remote, US", "software engineer"},
+		{"Jr. Data Analyst [Hybrid - NYC]", "junior data analyst"},
+		{"C++ Developer", "c++ developer"},
+		{"Software Engineer 2", "software engineer ii"},
+	}
+	for _, tt := range tests {
+		if got := normalizeTitle(tt.input); got != tt.want {
+			t.Errorf("normalizeTitle(%q) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestTitleSimilarity(t *testing.T) {
+	tests := []struct {
+		a, b string
+		dup  bool
+	}{
+		{"Sr. Software Engineer", "Senior Software Engineer", true},
+		{"Senior Backend Engineer (Remote)", "Senior Backend Engineer", true},
+		{"Software Engineer, Backend", "Backend Software Engineer", true},
+		{"Frontend Engineer", "Front-end Engineer", true},
+		{"Software Engineer II", "Software Engineer III", false},
+		{"Senior Software Engineer", "Software Engineer", false},
+		{"Software Engineer", "Data Engineer", false},
+		{"Senior Software Engineer", "Senior Software Engineer, Payments", false},
+	}
+	for _, tt := range tests {
+		score := titleSimilarity(normalizeTitle(tt.a), normalizeTitle(tt.b))
+		if dup := score >= DefaultFuzzyDedupThreshold; dup != tt.dup {
+			t.Errorf("titleSimilarity(%q, %q) = %.3f, duplicate = %v, want %v", tt.a, tt.b, score, dup, tt.dup)
+		}
+	}
+}
+
+func TestBestDuplicate(t *testing.T) {
+	scraped := &model.ScrapedJob{
+		Source:       model.SourceIndeed,
+		ExternalID:   "in-1",
+		Title:        "Sr. Software Engineer",
+		CompanyName:  "Acme, Inc.",
+		LocationCity: "Austin",
+	}
+	linkedIn := dedupCandidate{Source: model.SourceLinkedIn, ExternalID: "li-1", Title: "Senior Software Engineer", LocationCity: "Austin"}
+	elsewhere := dedupCandidate{Source: model.SourceLinkedIn, ExternalID: "li-2", Title: "Senior Software Engineer", LocationCity: "Denver"}
+	sameSource := dedupCandidate{Source: model.SourceIndeed, ExternalID: "in-2", Title: "Senior Software Engineer"}
+	frontend := dedupCandidate{Source: model.SourceLinkedIn, ExternalID: "li-3", Title: "Senior Frontend Engineer"}
+
+	best, score, ok := bestDuplicate(scraped, []dedupCandidate{frontend, elsewhere, sameSource, linkedIn}, DefaultFuzzyDedupThreshold)
+	if !ok || best.ExternalID != "li-1" || score != 1 {
+		t.Errorf("expected the LinkedIn posting in Austin, got %+v (score %.3f, ok %v)", best, score, ok)
+	}
+
+	// Only distinct postings: another city, and another ID on the same source.
+	if _, _, ok := bestDuplicate(scraped, []dedupCandidate{elsewhere, sameSource}, DefaultFuzzyDedupThreshold); ok {
+		t.Error("postings in another city or with another ID from the same source should not match")
+	}
+
+	// The threshold is configurable, and 0 disables matching.
+	if _, score, ok := bestDuplicate(scraped, []dedupCandidate{frontend}, 0.6); !ok {
+		t.Errorf("expected a match below the default threshold at 0.6, score %.3f", score)
+	}
+	if _, _, ok := bestDuplicate(scraped, []dedupCandidate{linkedIn}, 0); ok {
+		t.Error("a zero threshold should disable fuzzy dedup")
+	}
+}
+
+func TestDedupCompanyKey(t *testing.T) {
+	for _, name := range []string{"Acme, Inc.", "ACME Inc", "acme llc", " Acme Corporation "} {
+		if got := dedupCompanyKey(name); got != "acme" {
+			t.Errorf("dedupCompanyKey(%q) = %q, want %q", name, got, "acme")
+		}
+	}
+	if got := dedupCompanyKey("Acme Robotics"); got == "acme" {
+		t.Errorf("dedupCompanyKey(%q) should differ from acme", "Acme Robotics")
+	}
+}
@@ -0,0 +1,156 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+
+	"github.com/google/uuid"
+	"github.com/learnbot/job-aggregator/internal/model"
+)
+
+type dryRunKey struct{}
+
+// DryRun collects the writes UpsertJob would make. Under a context made by
+// WithDryRun, UpsertJob on either backend compares each posting with the
+// stored jobs and records what it would do instead of writing anything.
+// The other repository methods are not affected.
+type DryRun struct {
+	mu   sync.Mutex
+	jobs []model.DryRunJob
+	seen map[string]bool
+}
+
+// WithDryRun returns a context under which UpsertJob only records to the
+// returned DryRun.
+func WithDryRun(ctx context.Context) (context.Context, *DryRun) {
+	d := &DryRun{seen: map[string]bool{}}
+	return context.WithValue(ctx, dryRunKey{}, d), d
+}
+
+func dryRunFrom(ctx context.Context) *DryRun {
+	d, _ := ctx.Value(dryRunKey{}).(*DryRun)
+	return d
+}
+
+// Jobs returns the recorded jobs in the order they were upserted.
+func (d *DryRun) Jobs() []model.DryRunJob {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return append([]model.DryRunJob{}, d.jobs...)
+}
+
+// Summary counts the recorded jobs by action.
+func (d *DryRun) Summary() model.DryRunSummary {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	var s model.DryRunSummary
+	for _, j := range d.jobs {
+		switch j.Action {
+		case model.DryRunNew:
+			s.New++
+		case model.DryRunChanged:
+			s.Changed++
+		case model.DryRunUnchanged:
+			s.Unchanged++
+		case model.DryRunDuplicate:
+			s.Duplicate++
+		}
+	}
+	return s
+}
+
+// record adds a job, reporting false if a job with the same dedup hash was
+// recorded before: a real run would have updated that job instead.
+func (d *DryRun) record(hash string, job model.DryRunJob) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.seen[hash] {
+		return false
+	}
+	d.seen[hash] = true
+	d.jobs = append(d.jobs, job)
+	return true
+}
+
+// dryRunUpsert is UpsertJob under a dry run, shared by the PostgreSQL and
+// SQLite backends. It returns the stored job a real upsert would update, or
+// an unsaved job without an ID for a new one.
+func dryRunUpsert(ctx context.Context, d *DryRun, db *sql.DB, getJob func(context.Context, uuid.UUID) (*model.Job, error),
+	scraped *model.ScrapedJob, threshold float64) (*model.Job, bool, error) {
+	hash := ComputeDedupHash(scraped)
+	entry := model.DryRunJob{Action: model.DryRunNew, Job: *scraped}
+	job := &model.Job{
+		DedupHash:   hash,
+		Source:      scraped.Source,
+		CompanyName: scraped.CompanyName,
+		Title:       scraped.Title,
+		Status:      model.StatusActive,
+	}
+
+	var id uuid.UUID
+	err := db.QueryRowContext(ctx, `SELECT id FROM jobs WHERE dedup_hash = $1`, hash).Scan(&id)
+	switch {
+	case err == nil:
+		if job, err = getJob(ctx, id); err != nil {
+			return nil, false, fmt.Errorf("dry run upsert job: %w", err)
+		}
+		entry.ExistingID = &id
+		entry.ChangedFields = changedJobFields(job, scraped)
+		entry.Action = model.DryRunUnchanged
+		if len(entry.ChangedFields) > 0 {
+			entry.Action = model.DryRunChanged
+		}
+	case errors.Is(err, sql.ErrNoRows):
+		if threshold > 0 {
+			match, err := findDuplicate(ctx, db, scraped, hash, threshold)
+			if err != nil {
+				return nil, false, fmt.Errorf("dry run upsert job: %w", err)
+			}
+			if match != nil {
+				entry.Action, entry.ExistingID = model.DryRunDuplicate, &match.JobID
+			}
+		}
+	default:
+		return nil, false, fmt.Errorf("dry run upsert job: %w", err)
+	}
+
+	if !d.record(hash, entry) {
+		return job, false, nil
+	}
+	return job, entry.Action == model.DryRunNew, nil
+}
+
+// changedJobFields returns the columns of job that upserting scraped would
+// change. Only the columns UpsertJob refreshes for a known posting are
+// compared.
+func changedJobFields(job *model.Job, scraped *model.ScrapedJob) []string {
+	var fields []string
+	add := func(changed bool, name string) {
+		if changed {
+			fields = append(fields, name)
+		}
+	}
+	nullStringChanged := func(old sql.NullString, s string) bool { return old != nullString(s) }
+	intChanged := func(old sql.NullInt32, v *int) bool {
+		return old.Valid != (v != nil) || (v != nil && int(old.Int32) != *v)
+	}
+
+	add(job.Status != model.StatusActive, "status")
+	add(nullStringChanged(job.Description, scraped.Description), "description")
+	add(nullStringChanged(job.DescriptionHTML, scraped.DescriptionHTML), "description_html")
+	add(!slices.Equal([]string(job.RequiredSkills), scraped.RequiredSkills), "required_skills")
+	add(!slices.Equal([]string(job.PreferredSkills), scraped.PreferredSkills), "preferred_skills")
+	add(!slices.Equal([]string(job.NiceToHaveSkills), scraped.NiceToHaveSkills), "nice_to_have_skills")
+	add(intChanged(job.SalaryMin, scraped.SalaryMin), "salary_min")
+	add(intChanged(job.SalaryMax, scraped.SalaryMax), "salary_max")
+	add(scraped.SalaryCurrency != "" && job.SalaryCurrency != scraped.SalaryCurrency, "salary_currency")
+	add(nullStringChanged(job.SalaryRaw, scraped.SalaryRaw), "salary_raw")
+	add(job.SalaryPeriod != scraped.SalaryPeriod, "salary_period")
+	add(job.ExpiresAt.Valid != (scraped.ExpiresAt != nil) ||
+		(scraped.ExpiresAt != nil && !job.ExpiresAt.Time.Equal(*scraped.ExpiresAt)), "expires_at")
+	return fields
+}
@@ -5,7 +5,7 @@ import (
 	"context"
 	"crypto/sha256"
 	"database/sql"
-	"encoding/json"
+	"errors"
 	"fmt"
 	"strings"
 	"time"
@@ -15,120 +15,66 @@ import (
 	"github.com/lib/pq"
 )
 
-// JobRepository provides CRUD operations for jobs and related entities.
-type JobRepository struct {
-	db *sql.DB
+// PostgresRepository is the PostgreSQL JobRepository. Besides the
+// JobRepository methods it stores webhook subscriptions, skill demand stats
+// and expiry checks, which the other backends do not support.
+type PostgresRepository struct {
+	db             *sql.DB
+	dedupThreshold float64
 }
 
-// NewJobRepository creates a new JobRepository.
-func NewJobRepository(db *sql.DB) *JobRepository {
-	return &JobRepository{db: db}
+// NewPostgresRepository creates a new PostgresRepository with fuzzy
+// deduplication at DefaultFuzzyDedupThreshold. The schema is applied
+// separately from the SQL files in migrations/.
+func NewPostgresRepository(db *sql.DB) *PostgresRepository {
+	return &PostgresRepository{db: db, dedupThreshold: DefaultFuzzyDedupThreshold}
 }
 
 // ─────────────────────────────────────────────────────────────────────────────
 // Job storage
 // ─────────────────────────────────────────────────────────────────────────────
 
-// UpsertJob inserts a new job or updates it if the dedup_hash already exists.
-// Returns (job, isNew, error).
-func (r *JobRepository) UpsertJob(ctx context.Context, scraped *model.ScrapedJob) (*model.Job, bool, error) {
-	hash := ComputeDedupHash(scraped)
-
-	rawData, _ := json.Marshal(scraped.RawData)
-
-	job := &model.Job{}
-	var isNew bool
-
-	err := r.db.QueryRowContext(ctx, `
-		INSERT INTO jobs (
-			dedup_hash, source, external_id, company_name, title,
-			description, description_html, industry,
-			location_city, location_state, location_country, location_raw,
-			location_type, employment_type, experience_level,
-			required_skills, preferred_skills,
-			salary_min, salary_max, salary_currency, salary_raw,
-			application_url, company_url, posted_at, expires_at, raw_data
-		) VALUES (
-			$1, $2, $3, $4, $5,
-			$6, $7, $8,
-			$9, $10, $11, $12,
-			$13, $14, $15,
-			$16, $17,
-			$18, $19, $20, $21,
-			$22, $23, $24, $25, $26
-		)
-		ON CONFLICT (dedup_hash) DO UPDATE SET
-			last_seen_at     = NOW(),
-			status           = 'active',
-			description      = EXCLUDED.description,
-			description_html = EXCLUDED.description_html,
-			required_skills  = EXCLUDED.required_skills,
-			preferred_skills = EXCLUDED.preferred_skills,
-			salary_min       = EXCLUDED.salary_min,
-			salary_max       = EXCLUDED.salary_max,
-			salary_raw       = EXCLUDED.salary_raw,
-			expires_at       = EXCLUDED.expires_at,
-			raw_data         = EXCLUDED.raw_data,
-			updated_at       = NOW()
-		RETURNING id, dedup_hash, source, external_id, company_name, title,
-		          description, location_city, location_state, location_country,
-		          location_raw, location_type, employment_type, experience_level,
-		          required_skills, preferred_skills, salary_min, salary_max,
-		          salary_currency, salary_raw, application_url, company_url,
-		          posted_at, expires_at, scraped_at, last_seen_at, status,
-		          is_featured, created_at, updated_at,
-		          (xmax = 0) AS is_new`,
-		hash, scraped.Source, nullString(scraped.ExternalID), scraped.CompanyName, scraped.Title,
-		nullString(scraped.Description), nullString(scraped.DescriptionHTML), nullString(scraped.Industry),
-		nullString(scraped.LocationCity), nullString(scraped.LocationState),
-		nullString(scraped.LocationCountry), nullString(scraped.LocationRaw),
-		scraped.LocationType, scraped.EmploymentType, scraped.ExperienceLevel,
-		pq.Array(scraped.RequiredSkills), pq.Array(scraped.PreferredSkills),
-		scraped.SalaryMin, scraped.SalaryMax, nullString(scraped.SalaryCurrency), nullString(scraped.SalaryRaw),
-		scraped.ApplicationURL, nullString(scraped.CompanyURL),
-		scraped.PostedAt, scraped.ExpiresAt, rawData,
-	).Scan(
-		&job.ID, &job.DedupHash, &job.Source, &job.ExternalID,
-		&job.CompanyName, &job.Title, &job.Description,
-		&job.LocationCity, &job.LocationState, &job.LocationCountry,
-		&job.LocationRaw, &job.LocationType, &job.EmploymentType, &job.ExperienceLevel,
-		&job.RequiredSkills, &job.PreferredSkills,
-		&job.SalaryMin, &job.SalaryMax, &job.SalaryCurrency, &job.SalaryRaw,
-		&job.ApplicationURL, &job.CompanyURL,
-		&job.PostedAt, &job.ExpiresAt, &job.ScrapedAt, &job.LastSeenAt,
-		&job.Status, &job.IsFeatured, &job.CreatedAt, &job.UpdatedAt,
-		&isNew,
-	)
-	if err != nil {
+// UpsertJob inserts a new job or refreshes the job with the same
+// dedup_hash, the hash of its source and external ID when it has one; see
+// UpsertJobs for what a refresh updates. A posting with a new dedup_hash
+// whose title closely matches a recent job from the same company is merged
+// into that job instead (see SetFuzzyDedupThreshold). Returns (job, isNew,
+// error). Under a context from WithDryRun nothing is written.
+func (r *PostgresRepository) UpsertJob(ctx context.Context, scraped *model.ScrapedJob) (*model.Job, bool, error) {
+	if d := dryRunFrom(ctx); d != nil {
+		return dryRunUpsert(ctx, d, r.db, r.GetJobByID, scraped, r.dedupThreshold)
+	}
+	results := make([]UpsertResult, 1)
+	if err := r.upsertBatch(ctx, []*model.ScrapedJob{scraped}, results); err != nil {
 		return nil, false, fmt.Errorf("upsert job: %w", err)
 	}
-
-	return job, isNew, nil
+	return results[0].Job, results[0].Inserted, nil
 }
 
 // GetJobByID retrieves a job by its UUID.
-func (r *JobRepository) GetJobByID(ctx context.Context, id uuid.UUID) (*model.Job, error) {
+func (r *PostgresRepository) GetJobByID(ctx context.Context, id uuid.UUID) (*model.Job, error) {
 	job := &model.Job{}
 	err := r.db.QueryRowContext(ctx, `
-		SELECT id, dedup_hash, source, external_id, company_name, title,
+		SELECT id, dedup_hash, source, external_id, company_id, company_name, title,
 		       description, description_html, industry,
 		       location_city, location_state, location_country, location_raw,
 		       location_type, employment_type, experience_level,
-		       required_skills, preferred_skills,
-		       salary_min, salary_max, salary_currency, salary_raw,
-		       application_url, company_url, posted_at, expires_at,
-		       scraped_at, last_seen_at, status, is_featured, created_at, updated_at
+		       required_skills, preferred_skills, nice_to_have_skills,
+		       salary_min, salary_max, salary_currency, salary_raw, COALESCE(salary_period::text, ''),
+		       application_url, source_urls, company_url, posted_at, expires_at,
+		       scraped_at, last_seen_at, status, COALESCE(expiry_reason::text, ''),
+		       expiry_checked_at, is_featured, created_at, updated_at
 		FROM jobs WHERE id = $1`, id,
 	).Scan(
-		&job.ID, &job.DedupHash, &job.Source, &job.ExternalID,
+		&job.ID, &job.DedupHash, &job.Source, &job.ExternalID, &job.CompanyID,
 		&job.CompanyName, &job.Title, &job.Description, &job.DescriptionHTML,
 		&job.Industry, &job.LocationCity, &job.LocationState, &job.LocationCountry,
 		&job.LocationRaw, &job.LocationType, &job.EmploymentType, &job.ExperienceLevel,
-		&job.RequiredSkills, &job.PreferredSkills,
-		&job.SalaryMin, &job.SalaryMax, &job.SalaryCurrency, &job.SalaryRaw,
-		&job.ApplicationURL, &job.CompanyURL, &job.PostedAt, &job.ExpiresAt,
-		&job.ScrapedAt, &job.LastSeenAt, &job.Status, &job.IsFeatured,
-		&job.CreatedAt, &job.UpdatedAt,
+		&job.RequiredSkills, &job.PreferredSkills, &job.NiceToHaveSkills,
+		&job.SalaryMin, &job.SalaryMax, &job.SalaryCurrency, &job.SalaryRaw, &job.SalaryPeriod,
+		&job.ApplicationURL, &job.SourceURLs, &job.CompanyURL, &job.PostedAt, &job.ExpiresAt,
+		&job.ScrapedAt, &job.LastSeenAt, &job.Status, &job.ExpiryReason,
+		&job.ExpiryCheckedAt, &job.IsFeatured, &job.CreatedAt, &job.UpdatedAt,
 	)
 	if err == sql.ErrNoRows {
 		return nil, ErrNotFound
@@ -139,8 +85,10 @@ func (r *JobRepository) GetJobByID(ctx context.Context, id uuid.UUID) (*model.Jo
 	return job, nil
 }
 
-// SearchJobs queries jobs with filters and pagination.
-func (r *JobRepository) SearchJobs(ctx context.Context, filter model.JobFilter) ([]model.Job, int, error) {
+// SearchJobs queries jobs with filters and pagination, newest first. next
+// is the cursor of the last job when more follow; filter.After continues
+// from it.
+func (r *PostgresRepository) SearchJobs(ctx context.Context, filter model.JobFilter) (jobs []model.Job, total int, next *model.JobCursor, err error) {
 	if filter.PageSize <= 0 {
 		filter.PageSize = 20
 	}
@@ -218,90 +166,190 @@ func (r *JobRepository) SearchJobs(ctx context.Context, filter model.JobFilter)
 		idx++
 	}
 
+	// Company filter
+	if filter.CompanyID != nil {
+		where = append(where, fmt.Sprintf("company_id = $%d", idx))
+		args = append(args, *filter.CompanyID)
+		idx++
+	}
+
 	whereClause := strings.Join(where, " AND ")
 
 	// Count query
-	var total int
 	countQuery := fmt.Sprintf("SELECT COUNT(*) FROM jobs WHERE %s", whereClause)
 	if err := r.db.QueryRowContext(ctx, countQuery, args...).Scan(&total); err != nil {
-		return nil, 0, fmt.Errorf("count jobs: %w", err)
+		return nil, 0, nil, fmt.Errorf("count jobs: %w", err)
 	}
 
-	// Data query with pagination
+	// Data query with pagination. One extra row tells whether another page
+	// follows.
 	offset := (filter.Page - 1) * filter.PageSize
-	args = append(args, filter.PageSize, offset)
+	if filter.After != nil {
+		whereClause += " AND " + keysetCondition(idx)
+		args = append(args, filter.After.PostedAt, filter.After.ID)
+		idx += 2
+		offset = 0
+	}
+	args = append(args, filter.PageSize+1, offset)
 	dataQuery := fmt.Sprintf(`
-		SELECT id, dedup_hash, source, external_id, company_name, title,
+		SELECT id, dedup_hash, source, external_id, company_id, company_name, title,
 		       description, location_city, location_state, location_country,
 		       location_raw, location_type, employment_type, experience_level,
 		       required_skills, preferred_skills,
-		       salary_min, salary_max, salary_currency, salary_raw,
+		       salary_min, salary_max, salary_currency, salary_raw, COALESCE(salary_period::text, ''),
 		       application_url, company_url, posted_at, expires_at,
-		       scraped_at, last_seen_at, status, is_featured, created_at, updated_at
+		       scraped_at, last_seen_at, status, COALESCE(expiry_reason::text, ''),
+		       expiry_checked_at, is_featured, created_at, updated_at
 		FROM jobs
 		WHERE %s
-		ORDER BY posted_at DESC NULLS LAST, scraped_at DESC
-		LIMIT $%d OFFSET $%d`, whereClause, idx, idx+1)
+		%s
+		LIMIT $%d OFFSET $%d`, whereClause, keysetOrder, idx, idx+1)
 
 	rows, err := r.db.QueryContext(ctx, dataQuery, args...)
 	if err != nil {
-		return nil, 0, fmt.Errorf("search jobs: %w", err)
+		return nil, 0, nil, fmt.Errorf("search jobs: %w", err)
 	}
 	defer rows.Close()
 
-	var jobs []model.Job
 	for rows.Next() {
 		var j model.Job
 		if err := rows.Scan(
-			&j.ID, &j.DedupHash, &j.Source, &j.ExternalID,
+			&j.ID, &j.DedupHash, &j.Source, &j.ExternalID, &j.CompanyID,
 			&j.CompanyName, &j.Title, &j.Description,
 			&j.LocationCity, &j.LocationState, &j.LocationCountry,
 			&j.LocationRaw, &j.LocationType, &j.EmploymentType, &j.ExperienceLevel,
 			&j.RequiredSkills, &j.PreferredSkills,
-			&j.SalaryMin, &j.SalaryMax, &j.SalaryCurrency, &j.SalaryRaw,
+			&j.SalaryMin, &j.SalaryMax, &j.SalaryCurrency, &j.SalaryRaw, &j.SalaryPeriod,
 			&j.ApplicationURL, &j.CompanyURL, &j.PostedAt, &j.ExpiresAt,
-			&j.ScrapedAt, &j.LastSeenAt, &j.Status, &j.IsFeatured,
-			&j.CreatedAt, &j.UpdatedAt,
+			&j.ScrapedAt, &j.LastSeenAt, &j.Status, &j.ExpiryReason,
+			&j.ExpiryCheckedAt, &j.IsFeatured, &j.CreatedAt, &j.UpdatedAt,
 		); err != nil {
-			return nil, 0, fmt.Errorf("scan job: %w", err)
+			return nil, 0, nil, fmt.Errorf("scan job: %w", err)
 		}
 		jobs = append(jobs, j)
 	}
-	return jobs, total, rows.Err()
+	if err := rows.Err(); err != nil {
+		return nil, 0, nil, fmt.Errorf("iterate jobs: %w", err)
+	}
+
+	if len(jobs) > filter.PageSize {
+		jobs = jobs[:filter.PageSize]
+		c := model.CursorOf(&jobs[len(jobs)-1])
+		next = &c
+	}
+	return jobs, total, next, nil
 }
 
-// MarkExpiredJobs marks jobs not seen since the cutoff as expired.
-func (r *JobRepository) MarkExpiredJobs(ctx context.Context, source model.JobSource, cutoff time.Time) (int64, error) {
-	result, err := r.db.ExecContext(ctx, `
-		UPDATE jobs SET status = 'expired', updated_at = NOW()
-		WHERE source = $1 AND last_seen_at < $2 AND status = 'active'`,
-		source, cutoff,
+// ─────────────────────────────────────────────────────────────────────────────
+// Expiry checks
+// ─────────────────────────────────────────────────────────────────────────────
+
+// ListJobsForExpiryCheck returns up to limit jobs due for an expiry re-check,
+// least recently checked first. Candidates are active jobs posted (or first
+// scraped) before postedBefore, plus jobs the checker itself expired since
+// expiredSince, so that reposted jobs can be reactivated. Stale-expired jobs
+// are left to the scraper, which reactivates them on its own when seen again.
+func (r *PostgresRepository) ListJobsForExpiryCheck(ctx context.Context, postedBefore, expiredSince time.Time, limit int) ([]model.Job, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT id, source, company_name, title, application_url, status,
+		       COALESCE(expiry_reason::text, ''), expiry_checked_at, created_at
+		FROM jobs
+		WHERE (status = 'active' AND COALESCE(posted_at, created_at) < $1)
+		   OR (status = 'expired' AND expiry_reason IN ('not_found', 'redirected', 'closed_notice')
+		       AND expired_at >= $2)
+		ORDER BY expiry_checked_at ASC NULLS FIRST, created_at ASC
+		LIMIT $3`, postedBefore, expiredSince, limit,
 	)
 	if err != nil {
-		return 0, fmt.Errorf("mark expired jobs: %w", err)
+		return nil, fmt.Errorf("list jobs for expiry check: %w", err)
+	}
+	defer rows.Close()
+
+	var jobs []model.Job
+	for rows.Next() {
+		var j model.Job
+		if err := rows.Scan(
+			&j.ID, &j.Source, &j.CompanyName, &j.Title, &j.ApplicationURL, &j.Status,
+			&j.ExpiryReason, &j.ExpiryCheckedAt, &j.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("scan job: %w", err)
+		}
+		jobs = append(jobs, j)
 	}
-	return result.RowsAffected()
+	return jobs, rows.Err()
+}
+
+// MarkJobExpired marks a job as expired with the detected reason.
+func (r *PostgresRepository) MarkJobExpired(ctx context.Context, id uuid.UUID, reason model.ExpiryReason) error {
+	_, err := r.db.ExecContext(ctx, `
+		UPDATE jobs
+		SET status = 'expired', expiry_reason = $2, expired_at = NOW(),
+		    expiry_checked_at = NOW(), updated_at = NOW()
+		WHERE id = $1`, id, reason,
+	)
+	if err != nil {
+		return fmt.Errorf("mark job expired: %w", err)
+	}
+	return nil
+}
+
+// ReactivateJob returns an expired job to active after it was re-detected
+// as open.
+func (r *PostgresRepository) ReactivateJob(ctx context.Context, id uuid.UUID) error {
+	_, err := r.db.ExecContext(ctx, `
+		UPDATE jobs
+		SET status = 'active', expiry_reason = NULL, expired_at = NULL,
+		    expiry_checked_at = NOW(), last_seen_at = NOW(), updated_at = NOW()
+		WHERE id = $1`, id,
+	)
+	if err != nil {
+		return fmt.Errorf("reactivate job: %w", err)
+	}
+	return nil
+}
+
+// TouchExpiryCheck records that a job was checked without changing its status.
+func (r *PostgresRepository) TouchExpiryCheck(ctx context.Context, id uuid.UUID) error {
+	_, err := r.db.ExecContext(ctx, `
+		UPDATE jobs SET expiry_checked_at = NOW() WHERE id = $1`, id,
+	)
+	if err != nil {
+		return fmt.Errorf("touch expiry check: %w", err)
+	}
+	return nil
 }
 
 // ─────────────────────────────────────────────────────────────────────────────
 // Scrape run logging
 // ─────────────────────────────────────────────────────────────────────────────
 
-// CreateScrapeRun creates a new scrape run log entry.
-func (r *JobRepository) CreateScrapeRun(ctx context.Context, source model.JobSource, query, location string) (*model.ScrapeRun, error) {
-	run := &model.ScrapeRun{}
-	err := r.db.QueryRowContext(ctx, `
-		INSERT INTO scrape_runs (source, search_query, search_location, status, started_at)
-		VALUES ($1, $2, $3, 'running', NOW())
-		RETURNING id, source, search_query, search_location, status,
-		          jobs_found, jobs_new, jobs_updated, jobs_failed, pages_scraped,
-		          error_message, started_at, completed_at, duration_ms, created_at`,
-		source, query, location,
-	).Scan(
-		&run.ID, &run.Source, &run.SearchQuery, &run.SearchLocation, &run.Status,
+// scrapeRunColumns is the column list read by the scrape run queries, in
+// the order expected by scanScrapeRun. Nullable text columns are coalesced
+// so they scan into plain strings.
+const scrapeRunColumns = `id, source, scraper_name, COALESCE(search_query, ''),
+		       COALESCE(search_location, ''), status,
+		       jobs_found, jobs_new, jobs_updated, jobs_failed, pages_scraped,
+		       COALESCE(error_message, ''), started_at, completed_at, duration_ms, created_at`
+
+// scanScrapeRun scans a row selected with scrapeRunColumns.
+func scanScrapeRun(row interface{ Scan(...interface{}) error }, run *model.ScrapeRun) error {
+	return row.Scan(
+		&run.ID, &run.Source, &run.ScraperName, &run.SearchQuery,
+		&run.SearchLocation, &run.Status,
 		&run.JobsFound, &run.JobsNew, &run.JobsUpdated, &run.JobsFailed, &run.PagesScraped,
 		&run.ErrorMessage, &run.StartedAt, &run.CompletedAt, &run.DurationMs, &run.CreatedAt,
 	)
+}
+
+// CreateScrapeRun creates a new scrape run log entry for the named scraper.
+func (r *PostgresRepository) CreateScrapeRun(ctx context.Context, source model.JobSource, scraperName, query, location string) (*model.ScrapeRun, error) {
+	run := &model.ScrapeRun{}
+	err := scanScrapeRun(r.db.QueryRowContext(ctx, `
+		INSERT INTO scrape_runs (source, scraper_name, search_query, search_location, status, started_at)
+		VALUES ($1, $2, $3, $4, 'running', NOW())
+		RETURNING `+scrapeRunColumns,
+		source, scraperName, query, location,
+	), run)
 	if err != nil {
 		return nil, fmt.Errorf("create scrape run: %w", err)
 	}
@@ -309,7 +357,7 @@ func (r *JobRepository) CreateScrapeRun(ctx context.Context, source model.JobSou
 }
 
 // UpdateScrapeRun updates a scrape run with final statistics.
-func (r *JobRepository) UpdateScrapeRun(ctx context.Context, runID uuid.UUID, status model.ScrapeStatus, stats model.ScrapeRun) error {
+func (r *PostgresRepository) UpdateScrapeRun(ctx context.Context, runID uuid.UUID, status model.ScrapeStatus, stats model.ScrapeRun) error {
 	_, err := r.db.ExecContext(ctx, `
 		UPDATE scrape_runs SET
 			status        = $2,
@@ -329,14 +377,17 @@ func (r *JobRepository) UpdateScrapeRun(ctx context.Context, runID uuid.UUID, st
 }
 
 // GetRecentScrapeRuns returns the most recent scrape runs.
-func (r *JobRepository) GetRecentScrapeRuns(ctx context.Context, limit int) ([]model.ScrapeRun, error) {
+func (r *PostgresRepository) GetRecentScrapeRuns(ctx context.Context, limit int) ([]model.ScrapeRun, error) {
+	return recentScrapeRuns(ctx, r.db, limit)
+}
+
+// recentScrapeRuns implements GetRecentScrapeRuns for both backends.
+func recentScrapeRuns(ctx context.Context, db *sql.DB, limit int) ([]model.ScrapeRun, error) {
 	if limit <= 0 {
 		limit = 20
 	}
-	rows, err := r.db.QueryContext(ctx, `
-		SELECT id, source, search_query, search_location, status,
-		       jobs_found, jobs_new, jobs_updated, jobs_failed, pages_scraped,
-		       error_message, started_at, completed_at, duration_ms, created_at
+	rows, err := db.QueryContext(ctx, `
+		SELECT `+scrapeRunColumns+`
 		FROM scrape_runs
 		ORDER BY created_at DESC
 		LIMIT $1`, limit,
@@ -344,25 +395,135 @@ func (r *JobRepository) GetRecentScrapeRuns(ctx context.Context, limit int) ([]m
 	if err != nil {
 		return nil, fmt.Errorf("get recent scrape runs: %w", err)
 	}
+	return collectScrapeRuns(rows)
+}
+
+// GetScrapeRunsSince returns all scraping runs created at or after since,
+// newest first.
+func (r *PostgresRepository) GetScrapeRunsSince(ctx context.Context, since time.Time) ([]model.ScrapeRun, error) {
+	return scrapeRunsSince(ctx, r.db, since)
+}
+
+// scrapeRunsSince implements GetScrapeRunsSince for both backends.
+func scrapeRunsSince(ctx context.Context, db *sql.DB, since time.Time) ([]model.ScrapeRun, error) {
+	rows, err := db.QueryContext(ctx, `
+		SELECT `+scrapeRunColumns+`
+		FROM scrape_runs
+		WHERE created_at >= $1
+		ORDER BY created_at DESC`, since,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("get scrape runs since: %w", err)
+	}
+	return collectScrapeRuns(rows)
+}
+
+// SearchScrapeRuns queries scrape run history with filters and pagination,
+// newest first. It also returns the total number of matching runs.
+func (r *PostgresRepository) SearchScrapeRuns(ctx context.Context, filter model.ScrapeRunFilter) ([]model.ScrapeRun, int, error) {
+	return searchScrapeRuns(ctx, r.db, filter)
+}
+
+// searchScrapeRuns implements SearchScrapeRuns for both backends.
+func searchScrapeRuns(ctx context.Context, db *sql.DB, filter model.ScrapeRunFilter) ([]model.ScrapeRun, int, error) {
+	if filter.PageSize <= 0 {
+		filter.PageSize = 20
+	}
+	if filter.Page <= 0 {
+		filter.Page = 1
+	}
+
+	where := []string{"1=1"}
+	args := []interface{}{}
+	idx := 1
+
+	if filter.ScraperName != "" {
+		where = append(where, fmt.Sprintf("scraper_name = $%d", idx))
+		args = append(args, filter.ScraperName)
+		idx++
+	}
+	if filter.Status != "" {
+		where = append(where, fmt.Sprintf("status = $%d", idx))
+		args = append(args, filter.Status)
+		idx++
+	}
+	if filter.StartedAfter != nil {
+		where = append(where, fmt.Sprintf("created_at >= $%d", idx))
+		args = append(args, *filter.StartedAfter)
+		idx++
+	}
+	if filter.StartedBefore != nil {
+		where = append(where, fmt.Sprintf("created_at < $%d", idx))
+		args = append(args, *filter.StartedBefore)
+		idx++
+	}
+
+	whereClause := strings.Join(where, " AND ")
+
+	var total int
+	countQuery := fmt.Sprintf("SELECT COUNT(*) FROM scrape_runs WHERE %s", whereClause)
+	if err := db.QueryRowContext(ctx, countQuery, args...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("count scrape runs: %w", err)
+	}
+
+	offset := (filter.Page - 1) * filter.PageSize
+	args = append(args, filter.PageSize, offset)
+	dataQuery := fmt.Sprintf(`
+		SELECT %s
+		FROM scrape_runs
+		WHERE %s
+		ORDER BY created_at DESC
+		LIMIT $%d OFFSET $%d`, scrapeRunColumns, whereClause, idx, idx+1)
+
+	rows, err := db.QueryContext(ctx, dataQuery, args...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("search scrape runs: %w", err)
+	}
+	runs, err := collectScrapeRuns(rows)
+	if err != nil {
+		return nil, 0, err
+	}
+	return runs, total, nil
+}
+
+// GetScrapeRunByID retrieves a scrape run by its UUID.
+func (r *PostgresRepository) GetScrapeRunByID(ctx context.Context, id uuid.UUID) (*model.ScrapeRun, error) {
+	return scrapeRunByID(ctx, r.db, id)
+}
+
+// scrapeRunByID implements GetScrapeRunByID for both backends.
+func scrapeRunByID(ctx context.Context, db *sql.DB, id uuid.UUID) (*model.ScrapeRun, error) {
+	run := &model.ScrapeRun{}
+	err := scanScrapeRun(db.QueryRowContext(ctx, `
+		SELECT `+scrapeRunColumns+`
+		FROM scrape_runs WHERE id = $1`, id,
+	), run)
+	if err == sql.ErrNoRows {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get scrape run by id: %w", err)
+	}
+	return run, nil
+}
+
+// collectScrapeRuns scans and closes rows selected with scrapeRunColumns.
+func collectScrapeRuns(rows *sql.Rows) ([]model.ScrapeRun, error) {
 	defer rows.Close()
 
 	var runs []model.ScrapeRun
 	for rows.Next() {
-		var r model.ScrapeRun
-		if err := rows.Scan(
-			&r.ID, &r.Source, &r.SearchQuery, &r.SearchLocation, &r.Status,
-			&r.JobsFound, &r.JobsNew, &r.JobsUpdated, &r.JobsFailed, &r.PagesScraped,
-			&r.ErrorMessage, &r.StartedAt, &r.CompletedAt, &r.DurationMs, &r.CreatedAt,
-		); err != nil {
+		var run model.ScrapeRun
+		if err := scanScrapeRun(rows, &run); err != nil {
 			return nil, fmt.Errorf("scan scrape run: %w", err)
 		}
-		runs = append(runs, r)
+		runs = append(runs, run)
 	}
 	return runs, rows.Err()
 }
 
 // GetAdminStats returns aggregated statistics for the admin dashboard.
-func (r *JobRepository) GetAdminStats(ctx context.Context) (*model.AdminStats, error) {
+func (r *PostgresRepository) GetAdminStats(ctx context.Context) (*model.AdminStats, error) {
 	stats := &model.AdminStats{
 		JobsBySource: make(map[string]int),
 	}
@@ -408,39 +569,14 @@ func (r *JobRepository) GetAdminStats(ctx context.Context) (*model.AdminStats, e
 }
 
 // ─────────────────────────────────────────────────────────────────────────────
-// Company management
+// Career pages
 // ─────────────────────────────────────────────────────────────────────────────
 
-// UpsertCompany creates or updates a company record.
-func (r *JobRepository) UpsertCompany(ctx context.Context, name string) (*model.Company, error) {
-	normalized := normalizeCompanyName(name)
-	company := &model.Company{}
-	err := r.db.QueryRowContext(ctx, `
-		INSERT INTO companies (name, normalized_name)
-		VALUES ($1, $2)
-		ON CONFLICT (normalized_name) DO UPDATE SET
-			name = EXCLUDED.name,
-			updated_at = NOW()
-		RETURNING id, name, normalized_name, industry, website_url, linkedin_url,
-		          logo_url, size_range, headquarters, description, created_at, updated_at`,
-		name, normalized,
-	).Scan(
-		&company.ID, &company.Name, &company.NormalizedName,
-		&company.Industry, &company.WebsiteURL, &company.LinkedInURL,
-		&company.LogoURL, &company.SizeRange, &company.Headquarters,
-		&company.Description, &company.CreatedAt, &company.UpdatedAt,
-	)
-	if err != nil {
-		return nil, fmt.Errorf("upsert company: %w", err)
-	}
-	return company, nil
-}
-
 // GetCareerPages returns all enabled company career pages.
-func (r *JobRepository) GetCareerPages(ctx context.Context) ([]model.CompanyCareerPage, error) {
+func (r *PostgresRepository) GetCareerPages(ctx context.Context) ([]model.CompanyCareerPage, error) {
 	rows, err := r.db.QueryContext(ctx, `
-		SELECT id, company_id, company_name, career_page_url, selectors,
-		       is_enabled, last_scraped_at, jobs_found, created_at, updated_at
+		SELECT id, company_id, company_name, career_page_url, source_type::text,
+		       selectors, is_enabled, last_scraped_at, jobs_found, created_at, updated_at
 		FROM company_career_pages
 		WHERE is_enabled = TRUE
 		ORDER BY company_name`)
@@ -454,7 +590,7 @@ func (r *JobRepository) GetCareerPages(ctx context.Context) ([]model.CompanyCare
 		var p model.CompanyCareerPage
 		if err := rows.Scan(
 			&p.ID, &p.CompanyID, &p.CompanyName, &p.CareerPageURL,
-			&p.Selectors, &p.IsEnabled, &p.LastScrapedAt,
+			&p.SourceType, &p.Selectors, &p.IsEnabled, &p.LastScrapedAt,
 			&p.JobsFound, &p.CreatedAt, &p.UpdatedAt,
 		); err != nil {
 			return nil, fmt.Errorf("scan career page: %w", err)
@@ -464,6 +600,43 @@ func (r *JobRepository) GetCareerPages(ctx context.Context) ([]model.CompanyCare
 	return pages, rows.Err()
 }
 
+// CreateCareerPage inserts a new enabled career page and fills in its ID and
+// timestamps. It returns ErrDuplicate if the career page URL is already
+// configured. The page's company name becomes an alias of its company,
+// unless it is another company's.
+func (r *PostgresRepository) CreateCareerPage(ctx context.Context, page *model.CompanyCareerPage) error {
+	selectors := page.Selectors
+	if len(selectors) == 0 {
+		selectors = []byte("{}")
+	}
+	sourceType := page.SourceType
+	if sourceType == "" {
+		sourceType = model.CareerPageHTML
+	}
+
+	err := r.db.QueryRowContext(ctx, `
+		INSERT INTO company_career_pages (company_id, company_name, career_page_url, source_type, selectors)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id, is_enabled, created_at, updated_at`,
+		page.CompanyID, page.CompanyName, page.CareerPageURL, string(sourceType), selectors,
+	).Scan(&page.ID, &page.IsEnabled, &page.CreatedAt, &page.UpdatedAt)
+	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == "23505" {
+			return ErrDuplicate
+		}
+		return fmt.Errorf("create career page: %w", err)
+	}
+	if page.CompanyID != nil {
+		if err := addCompanyAlias(ctx, r.db, companyKey(page.CompanyName), *page.CompanyID, time.Now()); err != nil {
+			return fmt.Errorf("create career page: %w", err)
+		}
+	}
+	page.SourceType = sourceType
+	page.Selectors = selectors
+	return nil
+}
+
 // ─────────────────────────────────────────────────────────────────────────────
 // Helpers
 // ─────────────────────────────────────────────────────────────────────────────
@@ -508,3 +681,6 @@ func nullString(s string) sql.NullString {
 
 // ErrNotFound is returned when a record is not found.
 var ErrNotFound = fmt.Errorf("record not found")
+
+// ErrDuplicate is returned when a record violates a uniqueness constraint.
+var ErrDuplicate = fmt.Errorf("record already exists")
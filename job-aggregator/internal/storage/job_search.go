@@ -0,0 +1,234 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/learnbot/job-aggregator/internal/model"
+	"github.com/learnbot/shared/geo"
+)
+
+// jobSearchVector is the weighted document matched by the full-text job
+// search: title words rank above description words. It must stay in sync
+// with the idx_jobs_search_fts expression index (migration 008).
+const jobSearchVector = `(setweight(to_tsvector('english', title), 'A') || setweight(to_tsvector('english', COALESCE(description, '')), 'B'))`
+
+// jobRecency scores a job between 0 and 1 by age, halving after a week.
+const jobRecency = `(1.0 / (1.0 + EXTRACT(EPOCH FROM (NOW() - COALESCE(posted_at, scraped_at))) / 604800.0))`
+
+// Weights of text relevance and recency in the search score when a query is
+// given. Without a query, results are ordered by recency alone.
+const (
+	searchRankWeight    = 0.7
+	searchRecencyWeight = 0.3
+)
+
+// jobSortKey is the newest-first keyset order of jobs, matching
+// model.JobCursor. It is served by the idx_jobs_active_recent index
+// (migration 012).
+const jobSortKey = `COALESCE(posted_at, scraped_at)`
+
+// yearlySalary is a job's highest salary as a yearly amount, so that hourly
+// and monthly pay compare with yearly salaries. Salaries without a period
+// are taken to be yearly. The factors are floats so that the product cannot
+// overflow an INTEGER.
+const yearlySalary = `(COALESCE(salary_max, salary_min) * CASE salary_period ` +
+	`WHEN 'hour' THEN 2080.0 WHEN 'day' THEN 260.0 WHEN 'week' THEN 52.0 WHEN 'month' THEN 12.0 ELSE 1.0 END)`
+
+// salaryConditions returns the conditions of the MinSalary and Currency
+// search filters, adding their arguments to args. Placeholders are
+// numbered after the arguments already in args.
+func salaryConditions(filter model.JobSearchFilter, args *[]interface{}) []string {
+	currency := strings.ToUpper(strings.TrimSpace(filter.Currency))
+	if filter.MinSalary == nil && currency == "" {
+		return nil
+	}
+	if currency == "" {
+		currency = "USD"
+	}
+	*args = append(*args, currency)
+	conditions := []string{
+		"(salary_min IS NOT NULL OR salary_max IS NOT NULL)",
+		fmt.Sprintf("salary_currency = $%d", len(*args)),
+	}
+	if filter.MinSalary != nil {
+		*args = append(*args, *filter.MinSalary)
+		conditions = append(conditions, fmt.Sprintf("%s >= $%d", yearlySalary, len(*args)))
+	}
+	return conditions
+}
+
+// searchStatusCondition returns the condition selecting the jobs searched:
+// active jobs, and expired jobs if filter.IncludeExpired is set.
+func searchStatusCondition(filter model.JobSearchFilter) string {
+	if filter.IncludeExpired {
+		return "status IN ('active', 'expired')"
+	}
+	return "status = 'active'"
+}
+
+// keysetOrder orders jobs newest first, with the ID as a tie-breaker so that
+// every job has a unique position.
+const keysetOrder = `ORDER BY ` + jobSortKey + ` DESC, id DESC`
+
+// keysetCondition returns the condition selecting the jobs after a cursor
+// in keysetOrder, with the cursor in placeholders $argIdx and $argIdx+1.
+func keysetCondition(argIdx int) string {
+	return fmt.Sprintf("(%s, id) < ($%d, $%d)", jobSortKey, argIdx, argIdx+1)
+}
+
+// FullTextSearch returns active jobs matching the filter, and expired jobs
+// with filter.IncludeExpired, ordered by a relevance score that combines
+// ts_rank over the title and description with recency, together with the
+// total number of matches.
+//
+// With model.SortRecent, which is the default without a query, results are
+// ordered newest first and next is the cursor of the last result when more
+// follow; filter.After then continues from a cursor without the cost of an
+// OFFSET scan, and stays stable when jobs are added between pages. With
+// relevance ordering next is always nil.
+func (r *PostgresRepository) FullTextSearch(ctx context.Context, filter model.JobSearchFilter) (results []model.JobSearchResult, total int, next *model.JobCursor, err error) {
+	// Normalize limit.
+	if filter.Limit <= 0 {
+		filter.Limit = 20
+	}
+	if filter.Limit > 100 {
+		filter.Limit = 100
+	}
+	if filter.Offset < 0 || filter.After != nil {
+		filter.Offset = 0
+	}
+	query := strings.TrimSpace(filter.Query)
+	keyset := filter.After != nil || filter.Sort == model.SortRecent ||
+		(filter.Sort == "" && query == "")
+
+	// Build WHERE clauses dynamically.
+	var conditions []string
+	var args []interface{}
+	argIdx := 1
+
+	conditions = append(conditions, searchStatusCondition(filter))
+
+	relevance := jobRecency
+	if query != "" {
+		conditions = append(conditions, fmt.Sprintf(
+			"%s @@ plainto_tsquery('english', $%d)", jobSearchVector, argIdx))
+		relevance = fmt.Sprintf("(%g * ts_rank(%s, plainto_tsquery('english', $%d), 32) + %g * %s)",
+			searchRankWeight, jobSearchVector, argIdx, searchRecencyWeight, jobRecency)
+		args = append(args, query)
+		argIdx++
+	}
+	if loc := strings.TrimSpace(filter.Location); loc != "" {
+		// Countries are stored as ISO codes, so a country name also
+		// matches its code.
+		cond := fmt.Sprintf(
+			"concat_ws(' ', location_city, location_state, location_country, location_raw) ILIKE $%d", argIdx)
+		args = append(args, "%"+escapeLike(loc)+"%")
+		argIdx++
+		if code := geo.CountryCode(loc); code != "" {
+			cond = fmt.Sprintf("(%s OR location_country = $%d)", cond, argIdx)
+			args = append(args, code)
+			argIdx++
+		}
+		conditions = append(conditions, cond)
+	}
+	if filter.RemoteOnly {
+		conditions = append(conditions, fmt.Sprintf("location_type = '%s'", model.LocationRemote))
+	}
+	if company := strings.TrimSpace(filter.CompanyName); company != "" {
+		conditions = append(conditions, fmt.Sprintf("company_name ILIKE $%d", argIdx))
+		args = append(args, "%"+escapeLike(company)+"%")
+		argIdx++
+	}
+	if filter.CompanyID != nil {
+		conditions = append(conditions, fmt.Sprintf("company_id = $%d", argIdx))
+		args = append(args, *filter.CompanyID)
+		argIdx++
+	}
+	if filter.Source != "" {
+		conditions = append(conditions, fmt.Sprintf("source = $%d", argIdx))
+		args = append(args, filter.Source)
+		argIdx++
+	}
+	if filter.PostedAfter != nil {
+		// Jobs without a posting date are judged by when they were scraped.
+		conditions = append(conditions, fmt.Sprintf("COALESCE(posted_at, scraped_at) >= $%d", argIdx))
+		args = append(args, *filter.PostedAfter)
+		argIdx++
+	}
+	conditions = append(conditions, salaryConditions(filter, &args)...)
+	argIdx = len(args) + 1
+
+	where := "WHERE " + strings.Join(conditions, " AND ")
+
+	if err := r.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM jobs "+where, args...).Scan(&total); err != nil {
+		return nil, 0, nil, fmt.Errorf("count search jobs: %w", err)
+	}
+
+	// The total covers every match; only the page starts after the cursor.
+	order, limit := `ORDER BY relevance DESC, `+jobSortKey+` DESC, id`, filter.Limit
+	if keyset {
+		// One extra row tells whether another page follows.
+		order, limit = keysetOrder, filter.Limit+1
+		if filter.After != nil {
+			where += " AND " + keysetCondition(argIdx)
+			args = append(args, filter.After.PostedAt, filter.After.ID)
+			argIdx += 2
+		}
+	}
+
+	dataQ := fmt.Sprintf(`
+		SELECT id, dedup_hash, source, external_id, company_id, company_name, title,
+		       description, location_city, location_state, location_country,
+		       location_raw, location_type, employment_type, experience_level,
+		       required_skills, preferred_skills,
+		       salary_min, salary_max, salary_currency, salary_raw, COALESCE(salary_period::text, ''),
+		       application_url, company_url, posted_at, expires_at,
+		       scraped_at, last_seen_at, status, COALESCE(expiry_reason::text, ''),
+		       expiry_checked_at, is_featured, created_at, updated_at,
+		       %s AS relevance
+		FROM jobs
+		%s
+		%s
+		LIMIT $%d OFFSET $%d`, relevance, where, order, argIdx, argIdx+1)
+
+	args = append(args, limit, filter.Offset)
+
+	rows, err := r.db.QueryContext(ctx, dataQ, args...)
+	if err != nil {
+		return nil, 0, nil, fmt.Errorf("search jobs: %w", err)
+	}
+	defer rows.Close()
+
+	results = []model.JobSearchResult{}
+	for rows.Next() {
+		var res model.JobSearchResult
+		j := &res.Job
+		if err := rows.Scan(
+			&j.ID, &j.DedupHash, &j.Source, &j.ExternalID, &j.CompanyID,
+			&j.CompanyName, &j.Title, &j.Description,
+			&j.LocationCity, &j.LocationState, &j.LocationCountry,
+			&j.LocationRaw, &j.LocationType, &j.EmploymentType, &j.ExperienceLevel,
+			&j.RequiredSkills, &j.PreferredSkills,
+			&j.SalaryMin, &j.SalaryMax, &j.SalaryCurrency, &j.SalaryRaw, &j.SalaryPeriod,
+			&j.ApplicationURL, &j.CompanyURL, &j.PostedAt, &j.ExpiresAt,
+			&j.ScrapedAt, &j.LastSeenAt, &j.Status, &j.ExpiryReason,
+			&j.ExpiryCheckedAt, &j.IsFeatured, &j.CreatedAt, &j.UpdatedAt,
+			&res.Relevance,
+		); err != nil {
+			return nil, 0, nil, fmt.Errorf("scan search job: %w", err)
+		}
+		results = append(results, res)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, nil, fmt.Errorf("iterate search jobs: %w", err)
+	}
+
+	if len(results) > filter.Limit {
+		results = results[:filter.Limit]
+		c := model.CursorOf(&results[len(results)-1].Job)
+		next = &c
+	}
+	return results, total, next, nil
+}
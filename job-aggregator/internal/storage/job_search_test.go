@@ -0,0 +1,196 @@
+package storage
+
+import (
+	"context"
+	"database/sql/driver"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/learnbot/job-aggregator/internal/model"
+)
+
+var searchColumns = []string{
+	"id", "dedup_hash", "source", "external_id", "company_id", "company_name", "title",
+	"description", "location_city", "location_state", "location_country",
+	"location_raw", "location_type", "employment_type", "experience_level",
+	"required_skills", "preferred_skills",
+	"salary_min", "salary_max", "salary_currency", "salary_raw", "salary_period",
+	"application_url", "company_url", "posted_at", "expires_at",
+	"scraped_at", "last_seen_at", "status", "expiry_reason",
+	"expiry_checked_at", "is_featured", "created_at", "updated_at",
+	"relevance",
+}
+
+func newMockRepository(t *testing.T) (*PostgresRepository, sqlmock.Sqlmock) {
+	t.Helper()
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return NewPostgresRepository(db), mock
+}
+
+func TestFullTextSearch_CombinedFilters(t *testing.T) {
+	repo, mock := newMockRepository(t)
+	after := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	where := regexp.QuoteMeta(`WHERE status = 'active' AND ` + jobSearchVector + ` @@ plainto_tsquery('english', $1) AND ` +
+		`concat_ws(' ', location_city, location_state, location_country, location_raw) ILIKE $2 AND ` +
+		`location_type = 'remote' AND company_name ILIKE $3 AND source = $4 AND ` +
+		`COALESCE(posted_at, scraped_at) >= $5`)
+
+	mock.ExpectQuery(`SELECT COUNT\(\*\) FROM jobs `+where).
+		WithArgs("golang", "%berlin%", "%100\\%%", model.SourceLinkedIn, after).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(42))
+
+	now := time.Now()
+	id := uuid.New()
+	mock.ExpectQuery(`ts_rank\(.*\) AS relevance\s+FROM jobs\s+`+where+`\s+ORDER BY relevance DESC.*LIMIT \$6 OFFSET \$7`).
+		WithArgs("golang", "%berlin%", "%100\\%%", model.SourceLinkedIn, after, 10, 20).
+		WillReturnRows(sqlmock.NewRows(searchColumns).AddRow(
+			id, "hash", "linkedin", "li-1", nil, "100% Remote GmbH", "Go Developer",
+			"Build services in Go", "Berlin", nil, "Germany",
+			"Berlin, Germany", "remote", "full_time", "mid",
+			"{go,postgresql}", "{}",
+			nil, nil, "USD", nil, "",
+			"https://example.com/jobs/1", nil, now, nil,
+			now, now, "active", "",
+			nil, false, now, now,
+			0.81,
+		))
+
+	results, total, next, err := repo.FullTextSearch(context.Background(), model.JobSearchFilter{
+		Query:       " golang ",
+		Location:    "berlin",
+		RemoteOnly:  true,
+		CompanyName: "100%",
+		Source:      model.SourceLinkedIn,
+		PostedAfter: &after,
+		Limit:       10,
+		Offset:      20,
+	})
+	if err != nil {
+		t.Fatalf("FullTextSearch: %v", err)
+	}
+	if total != 42 || len(results) != 1 {
+		t.Fatalf("expected 1 of 42 results, got %d of %d", len(results), total)
+	}
+	if next != nil {
+		t.Errorf("expected no cursor for relevance ordering, got %+v", next)
+	}
+	if res := results[0]; res.ID != id || res.Title != "Go Developer" || res.Relevance != 0.81 ||
+		len(res.RequiredSkills) != 2 || res.LocationState.Valid {
+		t.Errorf("unexpected result: %+v", res)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestFullTextSearch_EmptyResults(t *testing.T) {
+	repo, mock := newMockRepository(t)
+
+	// Without a query, only active jobs are filtered and ordered newest
+	// first, fetching one extra row to detect a next page.
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT COUNT(*) FROM jobs WHERE status = 'active'`) + `$`).
+		WithArgs().
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+	mock.ExpectQuery(regexp.QuoteMeta(jobRecency+` AS relevance`)+`.*`+regexp.QuoteMeta(keysetOrder)).
+		WithArgs(101, 0).
+		WillReturnRows(sqlmock.NewRows(searchColumns))
+
+	results, total, next, err := repo.FullTextSearch(context.Background(), model.JobSearchFilter{Limit: 500, Offset: -5})
+	if err != nil {
+		t.Fatalf("FullTextSearch: %v", err)
+	}
+	if total != 0 || results == nil || len(results) != 0 || next != nil {
+		t.Errorf("expected an empty, non-nil result, got %v (total %d, next %v)", results, total, next)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+// jobRow returns a search result row for a job posted at postedAt.
+func jobRow(id uuid.UUID, postedAt time.Time) []driver.Value {
+	return []driver.Value{
+		id, "hash-" + id.String(), "linkedin", nil, nil, "Acme", "Go Developer",
+		nil, nil, nil, nil,
+		nil, "remote", "full_time", "mid",
+		"{go}", "{}",
+		nil, nil, "USD", nil, "",
+		"https://example.com/jobs/" + id.String(), nil, postedAt, nil,
+		postedAt, postedAt, "active", "",
+		nil, false, postedAt, postedAt,
+		0.5,
+	}
+}
+
+func TestFullTextSearch_SalaryFilter(t *testing.T) {
+	repo, mock := newMockRepository(t)
+
+	// A minimum salary without a currency is in US dollars.
+	where := regexp.QuoteMeta(`WHERE status = 'active' AND (salary_min IS NOT NULL OR salary_max IS NOT NULL) AND ` +
+		`salary_currency = $1 AND ` + yearlySalary + ` >= $2`)
+	mock.ExpectQuery(`SELECT COUNT\(\*\) FROM jobs `+where+`$`).
+		WithArgs("USD", 120000).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+	mock.ExpectQuery(`FROM jobs\s+`+where+`.*LIMIT \$3 OFFSET \$4`).
+		WithArgs("USD", 120000, 21, 0).
+		WillReturnRows(sqlmock.NewRows(searchColumns))
+
+	minSalary := 120000
+	if _, _, _, err := repo.FullTextSearch(context.Background(), model.JobSearchFilter{MinSalary: &minSalary}); err != nil {
+		t.Fatalf("FullTextSearch: %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestFullTextSearch_KeysetPagination(t *testing.T) {
+	repo, mock := newMockRepository(t)
+	base := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	first, second, third := uuid.New(), uuid.New(), uuid.New()
+
+	// The first page fetches limit+1 rows; the extra row only signals that
+	// another page follows.
+	mock.ExpectQuery(`SELECT COUNT`).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+	mock.ExpectQuery(regexp.QuoteMeta(keysetOrder)).WithArgs(3, 0).
+		WillReturnRows(sqlmock.NewRows(searchColumns).
+			AddRow(jobRow(first, base)...).
+			AddRow(jobRow(second, base.Add(-time.Hour))...).
+			AddRow(jobRow(third, base.Add(-2*time.Hour))...))
+
+	results, total, next, err := repo.FullTextSearch(context.Background(), model.JobSearchFilter{Limit: 2})
+	if err != nil {
+		t.Fatalf("FullTextSearch: %v", err)
+	}
+	if total != 3 || len(results) != 2 || next == nil || next.ID != second || !next.PostedAt.Equal(base.Add(-time.Hour)) {
+		t.Fatalf("unexpected first page: %d of %d, next %+v", len(results), total, next)
+	}
+
+	// The next page starts after the cursor instead of at an offset, and the
+	// total still counts every match.
+	cond := regexp.QuoteMeta(`WHERE status = 'active' AND ` + keysetCondition(1))
+	mock.ExpectQuery(`SELECT COUNT\(\*\) FROM jobs WHERE status = 'active'$`).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+	mock.ExpectQuery(cond+`\s+`+regexp.QuoteMeta(keysetOrder)+`\s+LIMIT \$3 OFFSET \$4`).
+		WithArgs(next.PostedAt, next.ID, 3, 0).
+		WillReturnRows(sqlmock.NewRows(searchColumns).AddRow(jobRow(third, base.Add(-2*time.Hour))...))
+
+	results, total, next, err = repo.FullTextSearch(context.Background(), model.JobSearchFilter{Limit: 2, Offset: 7, After: next})
+	if err != nil {
+		t.Fatalf("FullTextSearch: %v", err)
+	}
+	if total != 3 || len(results) != 1 || results[0].ID != third || next != nil {
+		t.Errorf("unexpected last page: %+v (total %d, next %v)", results, total, next)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
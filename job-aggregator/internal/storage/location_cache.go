@@ -0,0 +1,83 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/learnbot/shared/geo"
+)
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Location cache
+// ─────────────────────────────────────────────────────────────────────────────
+
+// GetCachedLocation returns the stored parse of a raw location string, and
+// false if the string has not been parsed by the current geo.Version.
+func (r *PostgresRepository) GetCachedLocation(ctx context.Context, raw string) (geo.Location, bool, error) {
+	return cachedLocation(ctx, r.db, raw)
+}
+
+// PutCachedLocation stores the parse of a raw location string.
+func (r *PostgresRepository) PutCachedLocation(ctx context.Context, raw string, loc geo.Location) error {
+	return putCachedLocation(ctx, r.db, raw, loc, time.Now())
+}
+
+// GetCachedLocation returns the stored parse of a raw location string, and
+// false if the string has not been parsed by the current geo.Version.
+func (r *SQLiteRepository) GetCachedLocation(ctx context.Context, raw string) (geo.Location, bool, error) {
+	return cachedLocation(ctx, r.db, raw)
+}
+
+// PutCachedLocation stores the parse of a raw location string.
+func (r *SQLiteRepository) PutCachedLocation(ctx context.Context, raw string, loc geo.Location) error {
+	return putCachedLocation(ctx, r.db, raw, loc, r.now().UTC())
+}
+
+// locationCacheKey is the key of a raw location string: lowercased, with
+// runs of spaces collapsed, as parsing ignores both.
+func locationCacheKey(raw string) string {
+	return strings.ToLower(strings.Join(strings.Fields(raw), " "))
+}
+
+// cachedLocation implements GetCachedLocation for both backends.
+func cachedLocation(ctx context.Context, db *sql.DB, raw string) (geo.Location, bool, error) {
+	var loc geo.Location
+	err := db.QueryRowContext(ctx, `
+		SELECT city, region, country, remote, hybrid
+		FROM location_cache
+		WHERE raw_key = $1 AND parser_version = $2`,
+		locationCacheKey(raw), geo.Version,
+	).Scan(&loc.City, &loc.Region, &loc.Country, &loc.Remote, &loc.Hybrid)
+	if errors.Is(err, sql.ErrNoRows) {
+		return geo.Location{}, false, nil
+	}
+	if err != nil {
+		return geo.Location{}, false, fmt.Errorf("get cached location: %w", err)
+	}
+	return loc, true, nil
+}
+
+// putCachedLocation implements PutCachedLocation for both backends.
+func putCachedLocation(ctx context.Context, db *sql.DB, raw string, loc geo.Location, now time.Time) error {
+	_, err := db.ExecContext(ctx, `
+		INSERT INTO location_cache (raw_key, parser_version, city, region, country, remote, hybrid, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		ON CONFLICT (raw_key) DO UPDATE SET
+			parser_version = excluded.parser_version,
+			city           = excluded.city,
+			region         = excluded.region,
+			country        = excluded.country,
+			remote         = excluded.remote,
+			hybrid         = excluded.hybrid,
+			updated_at     = excluded.updated_at`,
+		locationCacheKey(raw), geo.Version, loc.City, loc.Region, loc.Country, loc.Remote, loc.Hybrid, now,
+	)
+	if err != nil {
+		return fmt.Errorf("put cached location: %w", err)
+	}
+	return nil
+}
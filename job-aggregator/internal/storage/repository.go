@@ -0,0 +1,98 @@
+package storage
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/learnbot/job-aggregator/internal/model"
+	"github.com/learnbot/shared/geo"
+)
+
+// JobRepository stores jobs, scrape runs and career pages. It is
+// implemented by PostgresRepository, used in production, and by
+// SQLiteRepository for local development and demos.
+type JobRepository interface {
+	// UpsertJob inserts a new job or refreshes the existing job with the
+	// same dedup hash, merging fuzzy duplicates as set by
+	// SetFuzzyDedupThreshold, and links it to the company its company name
+	// resolves to. It reports whether the job is new.
+	UpsertJob(ctx context.Context, scraped *model.ScrapedJob) (*model.Job, bool, error)
+	// UpsertJobs stores a batch of scraped jobs as UpsertJob does and
+	// reports, in the order of jobs, whether each was inserted and how its
+	// title or location changed.
+	UpsertJobs(ctx context.Context, jobs []*model.ScrapedJob) ([]UpsertResult, error)
+	// GetJobByID returns ErrNotFound for an unknown ID.
+	GetJobByID(ctx context.Context, id uuid.UUID) (*model.Job, error)
+	SearchJobs(ctx context.Context, filter model.JobFilter) ([]model.Job, int, *model.JobCursor, error)
+	FullTextSearch(ctx context.Context, filter model.JobSearchFilter) ([]model.JobSearchResult, int, *model.JobCursor, error)
+	SetFuzzyDedupThreshold(threshold float64)
+	ListDedupLog(ctx context.Context, jobID *uuid.UUID, page, pageSize int) ([]model.DedupLogEntry, int, error)
+
+	// MarkStaleJobs expires the active jobs the named scraper last saw
+	// before the cutoff, and MarkOtherStaleJobs those last seen by none of
+	// the named scrapers. Both return the number of jobs expired.
+	MarkStaleJobs(ctx context.Context, scraperName string, cutoff time.Time) (int64, error)
+	MarkOtherStaleJobs(ctx context.Context, scraperNames []string, cutoff time.Time) (int64, error)
+	CreateExpiryRun(ctx context.Context, run *model.ExpiryRun) error
+	ListExpiryRuns(ctx context.Context, limit int) ([]model.ExpiryRun, error)
+
+	CreateScrapeRun(ctx context.Context, source model.JobSource, scraperName, query, location string) (*model.ScrapeRun, error)
+	UpdateScrapeRun(ctx context.Context, runID uuid.UUID, status model.ScrapeStatus, stats model.ScrapeRun) error
+	GetRecentScrapeRuns(ctx context.Context, limit int) ([]model.ScrapeRun, error)
+	GetScrapeRunsSince(ctx context.Context, since time.Time) ([]model.ScrapeRun, error)
+	SearchScrapeRuns(ctx context.Context, filter model.ScrapeRunFilter) ([]model.ScrapeRun, int, error)
+	// GetScrapeRunByID returns ErrNotFound for an unknown ID.
+	GetScrapeRunByID(ctx context.Context, id uuid.UUID) (*model.ScrapeRun, error)
+	GetAdminStats(ctx context.Context) (*model.AdminStats, error)
+	// RefreshScraperHealth recomputes and stores a scraper's health from
+	// its scrape runs; ListScraperHealth reads what was stored.
+	RefreshScraperHealth(ctx context.Context, scraperName string) (*model.ScraperHealth, error)
+	ListScraperHealth(ctx context.Context) ([]model.ScraperHealth, error)
+	// GetSchedulerControls reads the pause and per-scraper enable flags
+	// stored by SetSchedulerPaused and SetScraperEnabled.
+	GetSchedulerControls(ctx context.Context) (*model.SchedulerControls, error)
+	SetSchedulerPaused(ctx context.Context, paused bool) error
+	SetScraperEnabled(ctx context.Context, scraperName string, enabled bool) error
+
+	// SaveJob returns ErrNotFound for an unknown job, and UnsaveJob and
+	// UpdateSavedJobStatus and SetSavedJobScore for a job the user has not
+	// saved. UpdateSavedJobStatus returns ErrInvalidTransition if the saved
+	// job cannot move to the status.
+	SaveJob(ctx context.Context, userID, jobID uuid.UUID) (*model.SavedJob, bool, error)
+	UnsaveJob(ctx context.Context, userID, jobID uuid.UUID) error
+	UpdateSavedJobStatus(ctx context.Context, userID, id uuid.UUID, status model.ApplicationStatus) (*model.SavedJob, error)
+	ListSavedJobs(ctx context.Context, userID uuid.UUID, filter model.SavedJobFilter) ([]model.SavedJob, int, error)
+	SetSavedJobScore(ctx context.Context, userID, id uuid.UUID, score float64, computedAt time.Time) (*model.SavedJob, error)
+	// DeleteUserSavedJobs removes all of the user's saved jobs and returns
+	// how many there were.
+	DeleteUserSavedJobs(ctx context.Context, userID uuid.UUID) (int, error)
+
+	// GetCachedLocation and PutCachedLocation cache the parse of raw
+	// location strings, so that each is parsed once per geo.Version.
+	GetCachedLocation(ctx context.Context, raw string) (geo.Location, bool, error)
+	PutCachedLocation(ctx context.Context, raw string, loc geo.Location) error
+
+	// ResolveCompany returns the company a scraped company name resolves
+	// to, as in UpsertJob, creating one flagged for review when none
+	// matches. GetCompany and UpdateCompany return ErrNotFound for an
+	// unknown ID, and UpdateCompany ErrDuplicate if the new name or an
+	// alias belongs to another company. MergeCompanies returns ErrNotFound
+	// if either company is unknown and ErrSelfMerge if they are the same.
+	ResolveCompany(ctx context.Context, name, website string) (*model.Company, error)
+	ListCompanies(ctx context.Context, filter model.CompanyFilter) ([]model.Company, int, error)
+	GetCompany(ctx context.Context, id uuid.UUID) (*model.Company, error)
+	UpdateCompany(ctx context.Context, id uuid.UUID, update model.CompanyUpdate) (*model.Company, error)
+	MergeCompanies(ctx context.Context, sourceID, targetID uuid.UUID) (*model.CompanyMerge, error)
+
+	GetCareerPages(ctx context.Context) ([]model.CompanyCareerPage, error)
+	// CreateCareerPage returns ErrDuplicate if the career page URL is
+	// already configured.
+	CreateCareerPage(ctx context.Context, page *model.CompanyCareerPage) error
+}
+
+var (
+	_ JobRepository = (*PostgresRepository)(nil)
+	_ JobRepository = (*SQLiteRepository)(nil)
+)
@@ -0,0 +1,339 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/learnbot/job-aggregator/internal/model"
+)
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Saved jobs
+// ─────────────────────────────────────────────────────────────────────────────
+
+// ErrInvalidTransition is returned when a saved job cannot move to the
+// requested application status.
+var ErrInvalidTransition = errors.New("invalid application status transition")
+
+// applicationTimeColumns holds the column recording when each application
+// status was reached, in funnel order.
+var applicationTimeColumns = []struct {
+	status model.ApplicationStatus
+	column string
+}{
+	{model.ApplicationSaved, "saved_at"},
+	{model.ApplicationApplied, "applied_at"},
+	{model.ApplicationInterviewing, "interviewing_at"},
+	{model.ApplicationOffer, "offer_at"},
+	{model.ApplicationRejected, "rejected_at"},
+}
+
+// SaveJob saves the job for the user, or returns the existing entry if it
+// is already saved. It reports whether the job was newly saved, and returns
+// ErrNotFound for an unknown job.
+func (r *PostgresRepository) SaveJob(ctx context.Context, userID, jobID uuid.UUID) (*model.SavedJob, bool, error) {
+	job, err := r.GetJobByID(ctx, jobID)
+	if err != nil {
+		return nil, false, err
+	}
+	return saveJob(ctx, r.db, userID, job, time.Now())
+}
+
+// UnsaveJob removes the job from the user's saved jobs. It returns
+// ErrNotFound if the user has not saved it.
+func (r *PostgresRepository) UnsaveJob(ctx context.Context, userID, jobID uuid.UUID) error {
+	return unsaveJob(ctx, r.db, userID, jobID)
+}
+
+// UpdateSavedJobStatus moves the user's saved job to status and records
+// when it got there. Moving to the current status changes nothing.
+func (r *PostgresRepository) UpdateSavedJobStatus(ctx context.Context, userID, id uuid.UUID, status model.ApplicationStatus) (*model.SavedJob, error) {
+	return updateSavedJobStatus(ctx, r.db, userID, id, status, time.Now())
+}
+
+// ListSavedJobs returns a page of the user's saved jobs, newest first, with
+// the current data of each job, and the total count.
+func (r *PostgresRepository) ListSavedJobs(ctx context.Context, userID uuid.UUID, filter model.SavedJobFilter) ([]model.SavedJob, int, error) {
+	return listSavedJobs(ctx, r.db, userID, filter, r.GetJobByID)
+}
+
+// SetSavedJobScore stores the match score of the user's saved job,
+// computed against the profile as it was at computedAt. A score computed
+// before the stored one is ignored.
+func (r *PostgresRepository) SetSavedJobScore(ctx context.Context, userID, id uuid.UUID, score float64, computedAt time.Time) (*model.SavedJob, error) {
+	return setSavedJobScore(ctx, r.db, userID, id, score, computedAt, time.Now())
+}
+
+// SaveJob saves the job for the user, or returns the existing entry if it
+// is already saved. It reports whether the job was newly saved, and returns
+// ErrNotFound for an unknown job.
+func (r *SQLiteRepository) SaveJob(ctx context.Context, userID, jobID uuid.UUID) (*model.SavedJob, bool, error) {
+	job, err := r.GetJobByID(ctx, jobID)
+	if err != nil {
+		return nil, false, err
+	}
+	return saveJob(ctx, r.db, userID, job, r.now().UTC())
+}
+
+// UnsaveJob removes the job from the user's saved jobs. It returns
+// ErrNotFound if the user has not saved it.
+func (r *SQLiteRepository) UnsaveJob(ctx context.Context, userID, jobID uuid.UUID) error {
+	return unsaveJob(ctx, r.db, userID, jobID)
+}
+
+// UpdateSavedJobStatus moves the user's saved job to status and records
+// when it got there. Moving to the current status changes nothing.
+func (r *SQLiteRepository) UpdateSavedJobStatus(ctx context.Context, userID, id uuid.UUID, status model.ApplicationStatus) (*model.SavedJob, error) {
+	return updateSavedJobStatus(ctx, r.db, userID, id, status, r.now().UTC())
+}
+
+// ListSavedJobs returns a page of the user's saved jobs, newest first, with
+// the current data of each job, and the total count.
+func (r *SQLiteRepository) ListSavedJobs(ctx context.Context, userID uuid.UUID, filter model.SavedJobFilter) ([]model.SavedJob, int, error) {
+	return listSavedJobs(ctx, r.db, userID, filter, r.GetJobByID)
+}
+
+// SetSavedJobScore stores the match score of the user's saved job,
+// computed against the profile as it was at computedAt. A score computed
+// before the stored one is ignored.
+func (r *SQLiteRepository) SetSavedJobScore(ctx context.Context, userID, id uuid.UUID, score float64, computedAt time.Time) (*model.SavedJob, error) {
+	return setSavedJobScore(ctx, r.db, userID, id, score, computedAt.UTC(), r.now().UTC())
+}
+
+// DeleteUserSavedJobs removes all of the user's saved jobs and returns
+// how many there were.
+func (r *PostgresRepository) DeleteUserSavedJobs(ctx context.Context, userID uuid.UUID) (int, error) {
+	return deleteUserSavedJobs(ctx, r.db, userID)
+}
+
+// DeleteUserSavedJobs removes all of the user's saved jobs and returns
+// how many there were.
+func (r *SQLiteRepository) DeleteUserSavedJobs(ctx context.Context, userID uuid.UUID) (int, error) {
+	return deleteUserSavedJobs(ctx, r.db, userID)
+}
+
+const savedJobColumns = `id, user_id, job_id, status, title, company_name, application_url,
+	saved_at, applied_at, interviewing_at, offer_at, rejected_at, updated_at,
+	match_score, score_computed_at`
+
+func scanSavedJob(row interface{ Scan(...interface{}) error }, s *model.SavedJob) error {
+	var applied, interviewing, offer, rejected, scored sql.NullTime
+	var score sql.NullFloat64
+	err := row.Scan(
+		&s.ID, &s.UserID, &s.JobID, &s.Status, &s.Title, &s.CompanyName, &s.ApplicationURL,
+		&s.SavedAt, &applied, &interviewing, &offer, &rejected, &s.UpdatedAt,
+		&score, &scored,
+	)
+	if err != nil {
+		return err
+	}
+	s.AppliedAt, s.InterviewingAt = timePtr(applied), timePtr(interviewing)
+	s.OfferAt, s.RejectedAt = timePtr(offer), timePtr(rejected)
+	if score.Valid {
+		s.MatchScore = &score.Float64
+	}
+	s.ScoreComputedAt = timePtr(scored)
+	return nil
+}
+
+// savedJobWhere returns the saved job matching the condition, or
+// ErrNotFound.
+func savedJobWhere(ctx context.Context, db *sql.DB, cond string, args ...interface{}) (*model.SavedJob, error) {
+	s := &model.SavedJob{}
+	err := scanSavedJob(db.QueryRowContext(ctx, `SELECT `+savedJobColumns+` FROM saved_jobs WHERE `+cond, args...), s)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// saveJob implements SaveJob for both backends.
+func saveJob(ctx context.Context, db *sql.DB, userID uuid.UUID, job *model.Job, now time.Time) (*model.SavedJob, bool, error) {
+	res, err := db.ExecContext(ctx, `
+		INSERT INTO saved_jobs (id, user_id, job_id, status, title, company_name, application_url, saved_at, updated_at)
+		VALUES ($1, $2, $3, 'saved', $4, $5, $6, $7, $7)
+		ON CONFLICT (user_id, job_id) DO NOTHING`,
+		uuid.New(), userID, job.ID, job.Title, job.CompanyName, job.ApplicationURL, now,
+	)
+	if err != nil {
+		return nil, false, fmt.Errorf("save job: %w", err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return nil, false, fmt.Errorf("save job: %w", err)
+	}
+	saved, err := savedJobWhere(ctx, db, `user_id = $1 AND job_id = $2`, userID, job.ID)
+	if err != nil {
+		return nil, false, fmt.Errorf("save job: %w", err)
+	}
+	return saved, n == 1, nil
+}
+
+// unsaveJob implements UnsaveJob for both backends.
+func unsaveJob(ctx context.Context, db *sql.DB, userID, jobID uuid.UUID) error {
+	res, err := db.ExecContext(ctx, `DELETE FROM saved_jobs WHERE user_id = $1 AND job_id = $2`, userID, jobID)
+	if err != nil {
+		return fmt.Errorf("unsave job: %w", err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("unsave job: %w", err)
+	}
+	if n == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// deleteUserSavedJobs implements DeleteUserSavedJobs for both backends.
+func deleteUserSavedJobs(ctx context.Context, db *sql.DB, userID uuid.UUID) (int, error) {
+	res, err := db.ExecContext(ctx, `DELETE FROM saved_jobs WHERE user_id = $1`, userID)
+	if err != nil {
+		return 0, fmt.Errorf("delete user saved jobs: %w", err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("delete user saved jobs: %w", err)
+	}
+	return int(n), nil
+}
+
+// updateSavedJobStatus implements UpdateSavedJobStatus for both backends.
+// The update only applies from a status that may move to the new one, so
+// that concurrent updates cannot make an invalid transition between them.
+func updateSavedJobStatus(ctx context.Context, db *sql.DB, userID, id uuid.UUID, status model.ApplicationStatus, now time.Time) (*model.SavedJob, error) {
+	var column string
+	args := []interface{}{id, userID, status, now}
+	var from []string
+	for _, c := range applicationTimeColumns {
+		if c.status == status {
+			column = c.column
+		}
+		if c.status.CanTransitionTo(status) {
+			args = append(args, c.status)
+			from = append(from, fmt.Sprintf("$%d", len(args)))
+		}
+	}
+
+	if len(from) > 0 {
+		res, err := db.ExecContext(ctx, `
+			UPDATE saved_jobs SET status = $3, `+column+` = $4, updated_at = $4
+			WHERE id = $1 AND user_id = $2 AND status IN (`+strings.Join(from, ", ")+`)`,
+			args...,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("update saved job status: %w", err)
+		}
+		n, err := res.RowsAffected()
+		if err != nil {
+			return nil, fmt.Errorf("update saved job status: %w", err)
+		}
+		if n == 1 {
+			saved, err := savedJobWhere(ctx, db, `id = $1`, id)
+			if err != nil {
+				return nil, fmt.Errorf("update saved job status: %w", err)
+			}
+			return saved, nil
+		}
+	}
+
+	// Nothing was updated: the saved job is missing, already has the
+	// status, or cannot move to it.
+	current, err := savedJobWhere(ctx, db, `id = $1 AND user_id = $2`, id, userID)
+	if errors.Is(err, ErrNotFound) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("update saved job status: %w", err)
+	}
+	if current.Status == status {
+		return current, nil
+	}
+	return nil, fmt.Errorf("%w from %s to %s", ErrInvalidTransition, current.Status, status)
+}
+
+// setSavedJobScore implements SetSavedJobScore for both backends. Only a
+// score at least as recent as the stored one is written, so that a slow
+// re-score cannot overwrite the result of a later one.
+func setSavedJobScore(ctx context.Context, db *sql.DB, userID, id uuid.UUID, score float64, computedAt, now time.Time) (*model.SavedJob, error) {
+	if _, err := db.ExecContext(ctx, `
+		UPDATE saved_jobs SET match_score = $3, score_computed_at = $4, updated_at = $5
+		WHERE id = $1 AND user_id = $2 AND (score_computed_at IS NULL OR score_computed_at <= $4)`,
+		id, userID, score, computedAt, now,
+	); err != nil {
+		return nil, fmt.Errorf("set saved job score: %w", err)
+	}
+	saved, err := savedJobWhere(ctx, db, `id = $1 AND user_id = $2`, id, userID)
+	if errors.Is(err, ErrNotFound) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("set saved job score: %w", err)
+	}
+	return saved, nil
+}
+
+// listSavedJobs implements ListSavedJobs for both backends. The jobs are
+// read with getJob once the saved jobs are read, as SQLite has only one
+// connection.
+func listSavedJobs(ctx context.Context, db *sql.DB, userID uuid.UUID, filter model.SavedJobFilter, getJob func(context.Context, uuid.UUID) (*model.Job, error)) ([]model.SavedJob, int, error) {
+	where := `user_id = $1`
+	args := []interface{}{userID}
+	if filter.Status != "" {
+		where += ` AND status = $2`
+		args = append(args, filter.Status)
+	}
+
+	var total int
+	if err := db.QueryRowContext(ctx, `SELECT COUNT(*) FROM saved_jobs WHERE `+where, args...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("count saved jobs: %w", err)
+	}
+
+	n := len(args)
+	rows, err := db.QueryContext(ctx, `
+		SELECT `+savedJobColumns+` FROM saved_jobs
+		WHERE `+where+`
+		ORDER BY saved_at DESC, id
+		LIMIT $`+fmt.Sprint(n+1)+` OFFSET $`+fmt.Sprint(n+2),
+		append(args, filter.Limit, filter.Offset)...,
+	)
+	if err != nil {
+		return nil, 0, fmt.Errorf("list saved jobs: %w", err)
+	}
+	var saved []model.SavedJob
+	for rows.Next() {
+		var s model.SavedJob
+		if err := scanSavedJob(rows, &s); err != nil {
+			rows.Close()
+			return nil, 0, fmt.Errorf("scan saved job: %w", err)
+		}
+		saved = append(saved, s)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("iterate saved jobs: %w", err)
+	}
+
+	for i := range saved {
+		job, err := getJob(ctx, saved[i].JobID)
+		if errors.Is(err, ErrNotFound) {
+			saved[i].JobRemoved = true
+			continue
+		}
+		if err != nil {
+			return nil, 0, fmt.Errorf("list saved jobs: %w", err)
+		}
+		saved[i].Job = job
+		saved[i].JobRemoved = job.Status != model.StatusActive
+	}
+	return saved, total, nil
+}
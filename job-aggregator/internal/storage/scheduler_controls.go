@@ -0,0 +1,109 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/learnbot/job-aggregator/internal/model"
+)
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Scheduler controls
+// ─────────────────────────────────────────────────────────────────────────────
+
+// GetSchedulerControls returns the stored pause state of the schedule and
+// the scrapers that have been enabled or disabled.
+func (r *PostgresRepository) GetSchedulerControls(ctx context.Context) (*model.SchedulerControls, error) {
+	return schedulerControls(ctx, r.db)
+}
+
+// SetSchedulerPaused stores whether the schedule is paused.
+func (r *PostgresRepository) SetSchedulerPaused(ctx context.Context, paused bool) error {
+	return setSchedulerPaused(ctx, r.db, paused, time.Now())
+}
+
+// SetScraperEnabled stores whether the named scraper is enabled.
+func (r *PostgresRepository) SetScraperEnabled(ctx context.Context, scraperName string, enabled bool) error {
+	return setScraperEnabled(ctx, r.db, scraperName, enabled, time.Now())
+}
+
+// GetSchedulerControls returns the stored pause state of the schedule and
+// the scrapers that have been enabled or disabled.
+func (r *SQLiteRepository) GetSchedulerControls(ctx context.Context) (*model.SchedulerControls, error) {
+	return schedulerControls(ctx, r.db)
+}
+
+// SetSchedulerPaused stores whether the schedule is paused.
+func (r *SQLiteRepository) SetSchedulerPaused(ctx context.Context, paused bool) error {
+	return setSchedulerPaused(ctx, r.db, paused, r.now().UTC())
+}
+
+// SetScraperEnabled stores whether the named scraper is enabled.
+func (r *SQLiteRepository) SetScraperEnabled(ctx context.Context, scraperName string, enabled bool) error {
+	return setScraperEnabled(ctx, r.db, scraperName, enabled, r.now().UTC())
+}
+
+// schedulerControls implements GetSchedulerControls for both backends.
+func schedulerControls(ctx context.Context, db *sql.DB) (*model.SchedulerControls, error) {
+	c := &model.SchedulerControls{ScraperEnabled: map[string]bool{}}
+
+	err := db.QueryRowContext(ctx, `SELECT paused FROM scheduler_state WHERE id = 1`).Scan(&c.Paused)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("get scheduler state: %w", err)
+	}
+
+	rows, err := db.QueryContext(ctx, `SELECT scraper_name, enabled FROM scraper_controls`)
+	if err != nil {
+		return nil, fmt.Errorf("list scraper controls: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var (
+			name    string
+			enabled bool
+		)
+		if err := rows.Scan(&name, &enabled); err != nil {
+			return nil, fmt.Errorf("scan scraper control: %w", err)
+		}
+		c.ScraperEnabled[name] = enabled
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate scraper controls: %w", err)
+	}
+	return c, nil
+}
+
+// setSchedulerPaused implements SetSchedulerPaused for both backends.
+func setSchedulerPaused(ctx context.Context, db *sql.DB, paused bool, now time.Time) error {
+	_, err := db.ExecContext(ctx, `
+		INSERT INTO scheduler_state (id, paused, updated_at)
+		VALUES (1, $1, $2)
+		ON CONFLICT (id) DO UPDATE SET
+			paused     = excluded.paused,
+			updated_at = excluded.updated_at`,
+		paused, now,
+	)
+	if err != nil {
+		return fmt.Errorf("set scheduler paused: %w", err)
+	}
+	return nil
+}
+
+// setScraperEnabled implements SetScraperEnabled for both backends.
+func setScraperEnabled(ctx context.Context, db *sql.DB, name string, enabled bool, now time.Time) error {
+	_, err := db.ExecContext(ctx, `
+		INSERT INTO scraper_controls (scraper_name, enabled, updated_at)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (scraper_name) DO UPDATE SET
+			enabled    = excluded.enabled,
+			updated_at = excluded.updated_at`,
+		name, enabled, now,
+	)
+	if err != nil {
+		return fmt.Errorf("set scraper enabled: %w", err)
+	}
+	return nil
+}
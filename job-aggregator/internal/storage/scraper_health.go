@@ -0,0 +1,154 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/learnbot/job-aggregator/internal/model"
+)
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Scraper health
+// ─────────────────────────────────────────────────────────────────────────────
+
+// scraperHealthWindow is the span of the rolling average of jobs found.
+const scraperHealthWindow = 7 * 24 * time.Hour
+
+// RefreshScraperHealth recomputes the named scraper's health from its scrape
+// runs and stores it. The status is left for the caller to derive.
+func (r *PostgresRepository) RefreshScraperHealth(ctx context.Context, scraperName string) (*model.ScraperHealth, error) {
+	return refreshScraperHealth(ctx, r.db, scraperName, time.Now())
+}
+
+// ListScraperHealth returns the stored health of every scraper that has
+// run, by name.
+func (r *PostgresRepository) ListScraperHealth(ctx context.Context) ([]model.ScraperHealth, error) {
+	return listScraperHealth(ctx, r.db)
+}
+
+// RefreshScraperHealth recomputes the named scraper's health from its scrape
+// runs and stores it. The status is left for the caller to derive.
+func (r *SQLiteRepository) RefreshScraperHealth(ctx context.Context, scraperName string) (*model.ScraperHealth, error) {
+	return refreshScraperHealth(ctx, r.db, scraperName, r.now().UTC())
+}
+
+// ListScraperHealth returns the stored health of every scraper that has
+// run, by name.
+func (r *SQLiteRepository) ListScraperHealth(ctx context.Context) ([]model.ScraperHealth, error) {
+	return listScraperHealth(ctx, r.db)
+}
+
+// refreshScraperHealth implements RefreshScraperHealth for both backends.
+func refreshScraperHealth(ctx context.Context, db *sql.DB, name string, now time.Time) (*model.ScraperHealth, error) {
+	h := &model.ScraperHealth{ScraperName: name, UpdatedAt: now}
+
+	var lastRun sql.NullTime
+	err := db.QueryRowContext(ctx, `
+		SELECT created_at FROM scrape_runs
+		WHERE scraper_name = $1 AND status IN ('completed', 'failed', 'rate_limited')
+		ORDER BY created_at DESC
+		LIMIT 1`, name,
+	).Scan(&lastRun)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("refresh scraper health: last run: %w", err)
+	}
+	h.LastRunAt = timePtr(lastRun)
+
+	var lastSuccess, lastSuccessDone sql.NullTime
+	err = db.QueryRowContext(ctx, `
+		SELECT created_at, completed_at, jobs_found FROM scrape_runs
+		WHERE scraper_name = $1 AND status = 'completed'
+		ORDER BY created_at DESC
+		LIMIT 1`, name,
+	).Scan(&lastSuccess, &lastSuccessDone, &h.LastJobsFound)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("refresh scraper health: last success: %w", err)
+	}
+	h.LastSuccessAt = timePtr(lastSuccessDone)
+
+	// Failures since the last success, or ever if it never succeeded.
+	failures := `SELECT COUNT(*) FROM scrape_runs
+		WHERE scraper_name = $1 AND status IN ('failed', 'rate_limited')`
+	args := []interface{}{name}
+	if lastSuccess.Valid {
+		failures += ` AND created_at > $2`
+		args = append(args, lastSuccess.Time)
+	}
+	if err := db.QueryRowContext(ctx, failures, args...).Scan(&h.ConsecutiveFailures); err != nil {
+		return nil, fmt.Errorf("refresh scraper health: failures: %w", err)
+	}
+
+	if lastSuccess.Valid {
+		err := db.QueryRowContext(ctx, `
+			SELECT COUNT(*), COALESCE(AVG(jobs_found), 0) FROM scrape_runs
+			WHERE scraper_name = $1 AND status = 'completed'
+			  AND created_at >= $2 AND created_at < $3`,
+			name, lastSuccess.Time.Add(-scraperHealthWindow), lastSuccess.Time,
+		).Scan(&h.Runs7d, &h.AvgJobsFound7d)
+		if err != nil {
+			return nil, fmt.Errorf("refresh scraper health: average: %w", err)
+		}
+	}
+
+	_, err = db.ExecContext(ctx, `
+		INSERT INTO scraper_health (scraper_name, last_run_at, last_success_at, last_jobs_found,
+		                            consecutive_failures, avg_jobs_found_7d, runs_7d, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		ON CONFLICT (scraper_name) DO UPDATE SET
+			last_run_at          = excluded.last_run_at,
+			last_success_at      = excluded.last_success_at,
+			last_jobs_found      = excluded.last_jobs_found,
+			consecutive_failures = excluded.consecutive_failures,
+			avg_jobs_found_7d    = excluded.avg_jobs_found_7d,
+			runs_7d              = excluded.runs_7d,
+			updated_at           = excluded.updated_at`,
+		name, lastRun, lastSuccessDone, h.LastJobsFound,
+		h.ConsecutiveFailures, h.AvgJobsFound7d, h.Runs7d, now,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("refresh scraper health: %w", err)
+	}
+	return h, nil
+}
+
+// listScraperHealth implements ListScraperHealth for both backends.
+func listScraperHealth(ctx context.Context, db *sql.DB) ([]model.ScraperHealth, error) {
+	rows, err := db.QueryContext(ctx, `
+		SELECT scraper_name, last_run_at, last_success_at, last_jobs_found,
+		       consecutive_failures, avg_jobs_found_7d, runs_7d, updated_at
+		FROM scraper_health
+		ORDER BY scraper_name`)
+	if err != nil {
+		return nil, fmt.Errorf("list scraper health: %w", err)
+	}
+	defer rows.Close()
+
+	var list []model.ScraperHealth
+	for rows.Next() {
+		var (
+			h                    model.ScraperHealth
+			lastRun, lastSuccess sql.NullTime
+		)
+		if err := rows.Scan(&h.ScraperName, &lastRun, &lastSuccess, &h.LastJobsFound,
+			&h.ConsecutiveFailures, &h.AvgJobsFound7d, &h.Runs7d, &h.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("scan scraper health: %w", err)
+		}
+		h.LastRunAt, h.LastSuccessAt = timePtr(lastRun), timePtr(lastSuccess)
+		list = append(list, h)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate scraper health: %w", err)
+	}
+	return list, nil
+}
+
+// timePtr returns the time of t, or nil if it is null.
+func timePtr(t sql.NullTime) *time.Time {
+	if !t.Valid {
+		return nil
+	}
+	return &t.Time
+}
@@ -0,0 +1,194 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+
+	"github.com/learnbot/job-aggregator/internal/model"
+)
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Skill demand stats
+// ─────────────────────────────────────────────────────────────────────────────
+
+// ErrSkillStatsConflict is returned by AddSkillStats when the watermark
+// moved since the jobs were read, because another aggregation folded them in
+// first.
+var ErrSkillStatsConflict = errors.New("skill stats watermark moved")
+
+// weekDate is the layout of week_start dates.
+const weekDate = "2006-01-02"
+
+// GetSkillStatsWatermark returns the last job folded into the skill demand
+// stats.
+func (r *PostgresRepository) GetSkillStatsWatermark(ctx context.Context) (model.SkillStatsWatermark, error) {
+	var (
+		createdAt sql.NullTime
+		jobID     uuid.NullUUID
+	)
+	err := r.db.QueryRowContext(ctx,
+		`SELECT last_created_at, last_job_id FROM job_skill_stats_progress WHERE id`,
+	).Scan(&createdAt, &jobID)
+	if err != nil && err != sql.ErrNoRows {
+		return model.SkillStatsWatermark{}, fmt.Errorf("get skill stats watermark: %w", err)
+	}
+	return model.SkillStatsWatermark{CreatedAt: createdAt.Time, JobID: jobID.UUID}, nil
+}
+
+// ListJobsForSkillStats returns up to limit jobs stored after the
+// watermark, in the order they are folded in.
+func (r *PostgresRepository) ListJobsForSkillStats(ctx context.Context, after model.SkillStatsWatermark, limit int) ([]model.SkillStatsJob, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT id, title, COALESCE(description, ''), COALESCE(location_city, ''),
+		       COALESCE(location_raw, ''), location_type, posted_at, created_at
+		FROM jobs
+		WHERE $1::timestamptz IS NULL OR (created_at, id) > ($1, $2)
+		ORDER BY created_at, id
+		LIMIT $3`,
+		nullTime(after.CreatedAt), after.JobID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("list jobs for skill stats: %w", err)
+	}
+	defer rows.Close()
+
+	var jobs []model.SkillStatsJob
+	for rows.Next() {
+		var j model.SkillStatsJob
+		if err := rows.Scan(&j.ID, &j.Title, &j.Description, &j.LocationCity,
+			&j.LocationRaw, &j.LocationType, &j.PostedAt, &j.CreatedAt); err != nil {
+			return nil, fmt.Errorf("scan job for skill stats: %w", err)
+		}
+		jobs = append(jobs, j)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate jobs for skill stats: %w", err)
+	}
+	return jobs, nil
+}
+
+// AddSkillStats adds counts to the skill demand stats and moves the
+// watermark from from to to, in one transaction. It returns
+// ErrSkillStatsConflict, adding nothing, if the watermark is no longer from.
+func (r *PostgresRepository) AddSkillStats(ctx context.Context, from, to model.SkillStatsWatermark, counts []model.SkillStatCount) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	res, err := tx.ExecContext(ctx, `
+		UPDATE job_skill_stats_progress
+		SET last_created_at = $1, last_job_id = $2, updated_at = NOW()
+		WHERE id
+		  AND last_created_at IS NOT DISTINCT FROM $3
+		  AND last_job_id IS NOT DISTINCT FROM $4`,
+		to.CreatedAt, to.JobID, nullTime(from.CreatedAt), nullUUID(from.JobID))
+	if err != nil {
+		return fmt.Errorf("update skill stats watermark: %w", err)
+	}
+	if n, err := res.RowsAffected(); err != nil {
+		return fmt.Errorf("update skill stats watermark: %w", err)
+	} else if n == 0 {
+		return ErrSkillStatsConflict
+	}
+
+	if len(counts) > 0 {
+		skills := make([]string, len(counts))
+		roles := make([]string, len(counts))
+		locations := make([]string, len(counts))
+		weeks := make([]string, len(counts))
+		jobs := make([]int64, len(counts))
+		for i, c := range counts {
+			skills[i], roles[i], locations[i] = c.Skill, c.RoleKeyword, c.Location
+			weeks[i] = c.WeekStart.Format(weekDate)
+			jobs[i] = int64(c.Jobs)
+		}
+		_, err := tx.ExecContext(ctx, `
+			INSERT INTO job_skill_stats (skill, role_keyword, location, week_start, job_count)
+			SELECT * FROM unnest($1::text[], $2::text[], $3::text[], $4::date[], $5::int[])
+			ON CONFLICT (skill, role_keyword, location, week_start) DO UPDATE
+			SET job_count = job_skill_stats.job_count + EXCLUDED.job_count,
+			    updated_at = NOW()`,
+			pq.Array(skills), pq.Array(roles), pq.Array(locations), pq.Array(weeks), pq.Array(jobs))
+		if err != nil {
+			return fmt.Errorf("add skill stats: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("commit transaction: %w", err)
+	}
+	return nil
+}
+
+// SkillDemand returns the skills mentioned by the most jobs in the filter's
+// weeks, most mentioned first. Name is left empty for the caller to fill in.
+func (r *PostgresRepository) SkillDemand(ctx context.Context, filter model.SkillDemandFilter) ([]model.SkillDemand, error) {
+	// The week before From is read too, for the week-over-week change of a
+	// single-week period.
+	args := []interface{}{
+		filter.From.Format(weekDate),
+		filter.To.Format(weekDate),
+		filter.To.AddDate(0, 0, -7).Format(weekDate),
+	}
+	where := []string{"week_start BETWEEN $1::date - 7 AND $2::date"}
+	if filter.Role != "" {
+		args = append(args, strings.ToLower(filter.Role))
+		where = append(where, fmt.Sprintf("strpos(role_keyword, $%d) > 0", len(args)))
+	}
+	if filter.Location != "" {
+		args = append(args, strings.ToLower(filter.Location))
+		where = append(where, fmt.Sprintf("strpos(location, $%d) > 0", len(args)))
+	}
+	args = append(args, filter.Limit)
+
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT skill,
+		       COALESCE(SUM(job_count) FILTER (WHERE week_start >= $1::date), 0) AS jobs,
+		       COALESCE(SUM(job_count) FILTER (WHERE week_start = $2::date), 0) AS this_week,
+		       COALESCE(SUM(job_count) FILTER (WHERE week_start = $3::date), 0) AS last_week
+		FROM job_skill_stats
+		WHERE `+strings.Join(where, " AND ")+`
+		GROUP BY skill
+		HAVING COALESCE(SUM(job_count) FILTER (WHERE week_start >= $1::date), 0) > 0
+		ORDER BY jobs DESC, skill ASC
+		LIMIT $`+fmt.Sprint(len(args)), args...)
+	if err != nil {
+		return nil, fmt.Errorf("skill demand: %w", err)
+	}
+	defer rows.Close()
+
+	var out []model.SkillDemand
+	for rows.Next() {
+		var d model.SkillDemand
+		if err := rows.Scan(&d.Skill, &d.Jobs, &d.ThisWeek, &d.LastWeek); err != nil {
+			return nil, fmt.Errorf("scan skill demand: %w", err)
+		}
+		if d.LastWeek > 0 {
+			change := float64(d.ThisWeek-d.LastWeek) / float64(d.LastWeek)
+			d.WeekOverWeekChange = &change
+		}
+		out = append(out, d)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate skill demand: %w", err)
+	}
+	return out, nil
+}
+
+// nullTime returns a sql.NullTime from a time (zero = null).
+func nullTime(t time.Time) sql.NullTime {
+	return sql.NullTime{Time: t, Valid: !t.IsZero()}
+}
+
+// nullUUID returns a uuid.NullUUID from an ID (uuid.Nil = null).
+func nullUUID(id uuid.UUID) uuid.NullUUID {
+	return uuid.NullUUID{UUID: id, Valid: id != uuid.Nil}
+}
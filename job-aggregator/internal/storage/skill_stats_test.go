@@ -0,0 +1,88 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/learnbot/job-aggregator/internal/model"
+	"github.com/lib/pq"
+)
+
+func TestAddSkillStats(t *testing.T) {
+	from := model.SkillStatsWatermark{CreatedAt: time.Date(2025, 1, 6, 9, 0, 0, 0, time.UTC), JobID: uuid.New()}
+	to := model.SkillStatsWatermark{CreatedAt: from.CreatedAt.Add(time.Hour), JobID: uuid.New()}
+	week := time.Date(2025, 1, 6, 0, 0, 0, 0, time.UTC)
+	counts := []model.SkillStatCount{
+		{Skill: "kubernetes", RoleKeyword: "data engineer", Location: "jakarta", WeekStart: week, Jobs: 2},
+	}
+
+	t.Run("adds counts and moves the watermark", func(t *testing.T) {
+		repo, mock := newMockRepository(t)
+		mock.ExpectBegin()
+		mock.ExpectExec("UPDATE job_skill_stats_progress").
+			WithArgs(to.CreatedAt, to.JobID, from.CreatedAt, from.JobID).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec("INSERT INTO job_skill_stats .* ON CONFLICT").
+			WithArgs(pq.Array([]string{"kubernetes"}), pq.Array([]string{"data engineer"}),
+				pq.Array([]string{"jakarta"}), pq.Array([]string{"2025-01-06"}), pq.Array([]int64{2})).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		if err := repo.AddSkillStats(context.Background(), from, to, counts); err != nil {
+			t.Fatalf("AddSkillStats: %v", err)
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	t.Run("watermark moved", func(t *testing.T) {
+		repo, mock := newMockRepository(t)
+		mock.ExpectBegin()
+		mock.ExpectExec("UPDATE job_skill_stats_progress").WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectRollback()
+
+		err := repo.AddSkillStats(context.Background(), from, to, counts)
+		if !errors.Is(err, ErrSkillStatsConflict) {
+			t.Fatalf("expected ErrSkillStatsConflict, got %v", err)
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Error(err)
+		}
+	})
+}
+
+func TestSkillDemand(t *testing.T) {
+	repo, mock := newMockRepository(t)
+	from := time.Date(2025, 1, 6, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 1, 27, 0, 0, 0, 0, time.UTC)
+
+	mock.ExpectQuery(`strpos\(role_keyword, \$4\) > 0 AND strpos\(location, \$5\) > 0.*LIMIT \$6`).
+		WithArgs("2025-01-06", "2025-01-27", "2025-01-20", "data engineer", "jakarta", 10).
+		WillReturnRows(sqlmock.NewRows([]string{"skill", "jobs", "this_week", "last_week"}).
+			AddRow("kubernetes", 40, 12, 8).
+			AddRow("kafka", 20, 5, 0))
+
+	got, err := repo.SkillDemand(context.Background(), model.SkillDemandFilter{
+		Role: "data engineer", Location: "Jakarta", From: from, To: to, Limit: 10,
+	})
+	if err != nil {
+		t.Fatalf("SkillDemand: %v", err)
+	}
+	if len(got) != 2 || got[0].Skill != "kubernetes" || got[0].Jobs != 40 {
+		t.Fatalf("unexpected demand %+v", got)
+	}
+	if got[0].WeekOverWeekChange == nil || *got[0].WeekOverWeekChange != 0.5 {
+		t.Errorf("expected a +50%% change, got %v", got[0].WeekOverWeekChange)
+	}
+	if got[1].WeekOverWeekChange != nil {
+		t.Errorf("expected no change without jobs last week, got %v", *got[1].WeekOverWeekChange)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,605 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"modernc.org/sqlite"
+	sqlite3 "modernc.org/sqlite/lib"
+
+	"github.com/learnbot/job-aggregator/internal/model"
+	"github.com/learnbot/shared/migrate"
+)
+
+// SQLiteDriver is the database/sql driver name of the SQLite backend.
+const SQLiteDriver = "sqlite"
+
+// sqliteTimeLayout is the layout of timestamps written by the driver with
+// the _time_format=sqlite DSN option, which SQLite's date functions parse.
+const sqliteTimeLayout = "2006-01-02 15:04:05.999999999-07:00"
+
+// sqliteMigrations holds the SQLite schema. The PostgreSQL schema is in
+// migrations/ at the module root.
+//
+//go:embed sqlite_migrations/*.sql
+var sqliteMigrations embed.FS
+
+// SQLiteDSN returns the driver DSN for the SQLite database file at path, or
+// for a private in-memory database if path is ":memory:".
+func SQLiteDSN(path string) string {
+	return "file:" + path + "?_time_format=sqlite&_pragma=foreign_keys(1)&_pragma=busy_timeout(5000)"
+}
+
+// SQLiteRepository is the SQLite JobRepository, for local development and
+// demos without a PostgreSQL server. Full-text search falls back to LIKE
+// matching, and webhooks, skill demand stats and expiry checks are not
+// supported.
+type SQLiteRepository struct {
+	db             *sql.DB
+	dedupThreshold float64
+	now            func() time.Time
+}
+
+// NewSQLiteRepository creates a new SQLiteRepository on a database opened
+// with SQLiteDSN, with fuzzy deduplication at DefaultFuzzyDedupThreshold.
+// It limits db to one open connection: SQLite allows one writer at a time,
+// and every connection to ":memory:" is a separate database. Call Migrate
+// before use.
+func NewSQLiteRepository(db *sql.DB) *SQLiteRepository {
+	db.SetMaxOpenConns(1)
+	db.SetConnMaxLifetime(0)
+	return &SQLiteRepository{
+		db:             db,
+		dedupThreshold: DefaultFuzzyDedupThreshold,
+		now:            func() time.Time { return time.Now().UTC() },
+	}
+}
+
+// Migrate applies the SQLite schema migrations the database has not seen
+// yet, logging each, and records them in the schema_migrations table.
+func (r *SQLiteRepository) Migrate(ctx context.Context, logger *log.Logger) error {
+	schema, err := fs.Sub(sqliteMigrations, "sqlite_migrations")
+	if err != nil {
+		return err
+	}
+	m, err := migrate.New(r.db, schema, "schema_migrations", logger)
+	if err != nil {
+		return err
+	}
+	// One process owns the database file.
+	m.DisableAdvisoryLock()
+	_, err = m.Up(ctx)
+	return err
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Job storage
+// ─────────────────────────────────────────────────────────────────────────────
+
+// sqliteJobColumns is the column list read by the SQLite job queries, in
+// the order expected by scanSQLiteJob.
+const sqliteJobColumns = `id, dedup_hash, source, external_id, company_id, company_name, title,
+		       description, description_html, industry,
+		       location_city, location_state, location_country, location_raw,
+		       location_type, employment_type, experience_level,
+		       required_skills, preferred_skills, nice_to_have_skills,
+		       salary_min, salary_max, COALESCE(salary_currency, ''), salary_raw, COALESCE(salary_period, ''),
+		       application_url, source_urls, company_url, posted_at, expires_at,
+		       scraped_at, last_seen_at, status, COALESCE(expiry_reason, ''),
+		       expiry_checked_at, is_featured, created_at, updated_at`
+
+// scanSQLiteJob scans a row selected with sqliteJobColumns, followed by
+// extra destinations.
+func scanSQLiteJob(row interface{ Scan(...interface{}) error }, j *model.Job, extra ...interface{}) error {
+	return row.Scan(append([]interface{}{
+		&j.ID, &j.DedupHash, &j.Source, &j.ExternalID, &j.CompanyID,
+		&j.CompanyName, &j.Title, &j.Description, &j.DescriptionHTML,
+		&j.Industry, &j.LocationCity, &j.LocationState, &j.LocationCountry,
+		&j.LocationRaw, &j.LocationType, &j.EmploymentType, &j.ExperienceLevel,
+		jsonArray{&j.RequiredSkills}, jsonArray{&j.PreferredSkills}, jsonArray{&j.NiceToHaveSkills},
+		&j.SalaryMin, &j.SalaryMax, &j.SalaryCurrency, &j.SalaryRaw, &j.SalaryPeriod,
+		&j.ApplicationURL, jsonArray{&j.SourceURLs}, &j.CompanyURL, &j.PostedAt, &j.ExpiresAt,
+		&j.ScrapedAt, &j.LastSeenAt, &j.Status, &j.ExpiryReason,
+		&j.ExpiryCheckedAt, &j.IsFeatured, &j.CreatedAt, &j.UpdatedAt,
+	}, extra...)...)
+}
+
+// UpsertJob inserts a new job or updates it if the dedup_hash already exists.
+// A posting with a new dedup_hash whose title closely matches a recent job
+// from the same company is merged into that job instead (see
+// SetFuzzyDedupThreshold). Returns (job, isNew, error). Under a context
+// from WithDryRun nothing is written.
+func (r *SQLiteRepository) UpsertJob(ctx context.Context, scraped *model.ScrapedJob) (*model.Job, bool, error) {
+	if d := dryRunFrom(ctx); d != nil {
+		return dryRunUpsert(ctx, d, r.db, r.GetJobByID, scraped, r.dedupThreshold)
+	}
+	res, err := r.upsertJob(ctx, scraped)
+	if err != nil {
+		return nil, false, fmt.Errorf("upsert job: %w", err)
+	}
+	return res.Job, res.Inserted, nil
+}
+
+// upsertJob implements UpsertJob and UpsertJobs. A refresh updates the
+// same fields as on PostgreSQL; see PostgresRepository.UpsertJobs.
+func (r *SQLiteRepository) upsertJob(ctx context.Context, scraped *model.ScrapedJob) (UpsertResult, error) {
+	hash := ComputeDedupHash(scraped)
+
+	if r.dedupThreshold > 0 {
+		match, err := findDuplicate(ctx, r.db, scraped, hash, r.dedupThreshold)
+		if err != nil {
+			return UpsertResult{}, err
+		}
+		if match != nil {
+			job, err := r.mergeDuplicate(ctx, match, scraped, hash)
+			if err != nil {
+				return UpsertResult{}, err
+			}
+			return UpsertResult{Job: job}, nil
+		}
+	}
+
+	rawData, _ := json.Marshal(scraped.RawData)
+	now := r.now()
+
+	// Resolved before the transaction, which holds the only connection.
+	companyID, err := resolveCompany(ctx, r.db, scraped.CompanyName, companyWebsite(scraped), scraped.Industry, now)
+	if err != nil {
+		return UpsertResult{}, err
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return UpsertResult{}, err
+	}
+	defer tx.Rollback() //nolint:errcheck
+
+	stored := &model.Job{}
+	var changedFields sql.NullString
+	err = tx.QueryRowContext(ctx, `
+		SELECT id, title, location_city, location_state, location_country, changed_fields
+		FROM jobs WHERE dedup_hash = $1`, hash,
+	).Scan(&stored.ID, &stored.Title, &stored.LocationCity, &stored.LocationState, &stored.LocationCountry, &changedFields)
+	id := stored.ID
+	isNew := errors.Is(err, sql.ErrNoRows)
+	if err != nil && !isNew {
+		return UpsertResult{}, err
+	}
+	var changes map[string]FieldChange
+	note := changedFields
+	if !isNew {
+		if changes = jobChanges(stored, scraped); changes != nil {
+			b, _ := json.Marshal(changes)
+			note = sql.NullString{String: string(b), Valid: true}
+		}
+	}
+
+	if isNew {
+		id = uuid.New()
+		_, err = tx.ExecContext(ctx, `
+			INSERT INTO jobs (
+				id, dedup_hash, source, external_id, company_name, title,
+				description, description_html, industry,
+				location_city, location_state, location_country, location_raw,
+				location_type, employment_type, experience_level,
+				required_skills, preferred_skills, nice_to_have_skills,
+				salary_min, salary_max, salary_currency, salary_raw,
+				application_url, source_urls, company_url, posted_at, expires_at, raw_data,
+				scraped_at, last_seen_at, created_at, updated_at, salary_period, last_seen_by, company_id
+			) VALUES (
+				$1, $2, $3, $4, $5, $6,
+				$7, $8, $9,
+				$10, $11, $12, $13,
+				$14, $15, $16,
+				$17, $18, $19,
+				$20, $21, COALESCE($22, 'USD'), $23,
+				$24, $25, $26, $27, $28, $29,
+				$30, $30, $30, $30, $31, $32, $33
+			)`,
+			id, hash, scraped.Source, nullString(scraped.ExternalID), scraped.CompanyName, scraped.Title,
+			nullString(scraped.Description), nullString(scraped.DescriptionHTML), nullString(scraped.Industry),
+			nullString(scraped.LocationCity), nullString(scraped.LocationState),
+			nullString(scraped.LocationCountry), nullString(scraped.LocationRaw),
+			scraped.LocationType, scraped.EmploymentType, scraped.ExperienceLevel,
+			jsonArrayValue(scraped.RequiredSkills), jsonArrayValue(scraped.PreferredSkills),
+			jsonArrayValue(scraped.NiceToHaveSkills),
+			scraped.SalaryMin, scraped.SalaryMax, nullString(scraped.SalaryCurrency), nullString(scraped.SalaryRaw),
+			scraped.ApplicationURL, jsonArrayValue([]string{scraped.ApplicationURL}), nullString(scraped.CompanyURL),
+			utcPtr(scraped.PostedAt), utcPtr(scraped.ExpiresAt), string(rawData),
+			now, nullString(string(scraped.SalaryPeriod)), nullString(scraped.ScraperName), companyID,
+		)
+	} else {
+		_, err = tx.ExecContext(ctx, `
+			UPDATE jobs SET
+				last_seen_at     = $2,
+				last_seen_by     = COALESCE($15, last_seen_by),
+				status           = 'active',
+				expiry_reason    = NULL,
+				expired_at       = NULL,
+				description      = $3,
+				description_html = $4,
+				required_skills  = $5,
+				preferred_skills = $6,
+				nice_to_have_skills = $7,
+				salary_min       = $8,
+				salary_max       = $9,
+				salary_raw       = $10,
+				expires_at       = $11,
+				raw_data         = $12,
+				salary_currency  = COALESCE($13, 'USD'),
+				salary_period    = $14,
+				posted_at        = CASE WHEN posted_at IS NULL OR $16 < posted_at THEN $16 ELSE posted_at END,
+				changed_at       = CASE WHEN changed_fields IS NOT $17 THEN $2 ELSE changed_at END,
+				changed_fields   = $17,
+				company_id       = COALESCE($18, company_id),
+				updated_at       = $2
+			WHERE id = $1`,
+			id, now,
+			nullString(scraped.Description), nullString(scraped.DescriptionHTML),
+			jsonArrayValue(scraped.RequiredSkills), jsonArrayValue(scraped.PreferredSkills),
+			jsonArrayValue(scraped.NiceToHaveSkills),
+			scraped.SalaryMin, scraped.SalaryMax, nullString(scraped.SalaryRaw),
+			utcPtr(scraped.ExpiresAt), string(rawData),
+			nullString(scraped.SalaryCurrency), nullString(string(scraped.SalaryPeriod)),
+			nullString(scraped.ScraperName), utcPtr(scraped.PostedAt), note, companyID,
+		)
+	}
+	if err != nil {
+		return UpsertResult{}, err
+	}
+	if err := tx.Commit(); err != nil {
+		return UpsertResult{}, err
+	}
+
+	job, err := r.GetJobByID(ctx, id)
+	if err != nil {
+		return UpsertResult{}, err
+	}
+	return UpsertResult{Job: job, Inserted: isNew, Changes: changes}, nil
+}
+
+// mergeDuplicate records scraped as a duplicate of the matched job and
+// refreshes that job as if it had been seen again, adding the posting's
+// application URL to its source URLs.
+func (r *SQLiteRepository) mergeDuplicate(ctx context.Context, m *dedupMatch, scraped *model.ScrapedJob, hash string) (*model.Job, error) {
+	now := r.now()
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("merge duplicate job: %w", err)
+	}
+	defer tx.Rollback() //nolint:errcheck
+
+	if !m.Logged {
+		if _, err := tx.ExecContext(ctx, `
+			INSERT INTO dedup_log (
+				id, job_id, dedup_hash, source, external_id, title, company_name,
+				application_url, canonical_title, similarity, threshold, created_at
+			) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+			ON CONFLICT (dedup_hash) DO NOTHING`,
+			uuid.New(), m.JobID, hash, scraped.Source, scraped.ExternalID, scraped.Title, scraped.CompanyName,
+			scraped.ApplicationURL, m.Title, m.Similarity, r.dedupThreshold, now,
+		); err != nil {
+			return nil, fmt.Errorf("insert dedup log entry: %w", err)
+		}
+	}
+
+	res, err := tx.ExecContext(ctx, `
+		UPDATE jobs SET
+			last_seen_at  = $3,
+			last_seen_by  = COALESCE($4, last_seen_by),
+			status        = 'active',
+			expiry_reason = NULL,
+			expired_at    = NULL,
+			source_urls   = CASE WHEN $2 = '' OR EXISTS (SELECT 1 FROM json_each(source_urls) WHERE value = $2)
+			                     THEN source_urls
+			                     ELSE json_insert(source_urls, '$[#]', $2) END,
+			updated_at    = $3
+		WHERE id = $1`,
+		m.JobID, scraped.ApplicationURL, now, nullString(scraped.ScraperName),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("update canonical job: %w", err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return nil, ErrNotFound
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("merge duplicate job: %w", err)
+	}
+	return r.GetJobByID(ctx, m.JobID)
+}
+
+// SetFuzzyDedupThreshold sets the title similarity, between 0 and 1, at
+// which UpsertJob merges a new posting into an existing job. A threshold of
+// 0 disables fuzzy deduplication.
+func (r *SQLiteRepository) SetFuzzyDedupThreshold(threshold float64) {
+	r.dedupThreshold = threshold
+}
+
+// ListDedupLog returns fuzzy dedup merges, newest first. A non-nil jobID
+// limits the entries to merges into that job.
+func (r *SQLiteRepository) ListDedupLog(ctx context.Context, jobID *uuid.UUID, page, pageSize int) ([]model.DedupLogEntry, int, error) {
+	return listDedupLog(ctx, r.db, jobID, page, pageSize)
+}
+
+// GetJobByID retrieves a job by its UUID.
+func (r *SQLiteRepository) GetJobByID(ctx context.Context, id uuid.UUID) (*model.Job, error) {
+	job := &model.Job{}
+	err := scanSQLiteJob(r.db.QueryRowContext(ctx, `
+		SELECT `+sqliteJobColumns+`
+		FROM jobs WHERE id = $1`, id,
+	), job)
+	if err == sql.ErrNoRows {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get job by id: %w", err)
+	}
+	return job, nil
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Scrape run logging
+// ─────────────────────────────────────────────────────────────────────────────
+
+// CreateScrapeRun creates a new scrape run log entry for the named scraper.
+func (r *SQLiteRepository) CreateScrapeRun(ctx context.Context, source model.JobSource, scraperName, query, location string) (*model.ScrapeRun, error) {
+	id := uuid.New()
+	if _, err := r.db.ExecContext(ctx, `
+		INSERT INTO scrape_runs (id, source, scraper_name, search_query, search_location, status, started_at, created_at)
+		VALUES ($1, $2, $3, $4, $5, 'running', $6, $6)`,
+		id, source, scraperName, query, location, r.now(),
+	); err != nil {
+		return nil, fmt.Errorf("create scrape run: %w", err)
+	}
+	run, err := scrapeRunByID(ctx, r.db, id)
+	if err != nil {
+		return nil, fmt.Errorf("create scrape run: %w", err)
+	}
+	return run, nil
+}
+
+// UpdateScrapeRun updates a scrape run with final statistics.
+func (r *SQLiteRepository) UpdateScrapeRun(ctx context.Context, runID uuid.UUID, status model.ScrapeStatus, stats model.ScrapeRun) error {
+	_, err := r.db.ExecContext(ctx, `
+		UPDATE scrape_runs SET
+			status        = $2,
+			jobs_found    = $3,
+			jobs_new      = $4,
+			jobs_updated  = $5,
+			jobs_failed   = $6,
+			pages_scraped = $7,
+			error_message = $8,
+			completed_at  = $9
+		WHERE id = $1`,
+		runID, status,
+		stats.JobsFound, stats.JobsNew, stats.JobsUpdated,
+		stats.JobsFailed, stats.PagesScraped, stats.ErrorMessage, r.now(),
+	)
+	return err
+}
+
+// GetRecentScrapeRuns returns the most recent scrape runs.
+func (r *SQLiteRepository) GetRecentScrapeRuns(ctx context.Context, limit int) ([]model.ScrapeRun, error) {
+	return recentScrapeRuns(ctx, r.db, limit)
+}
+
+// GetScrapeRunsSince returns all scraping runs created at or after since,
+// newest first.
+func (r *SQLiteRepository) GetScrapeRunsSince(ctx context.Context, since time.Time) ([]model.ScrapeRun, error) {
+	return scrapeRunsSince(ctx, r.db, since.UTC())
+}
+
+// SearchScrapeRuns queries scrape run history with filters and pagination,
+// newest first. It also returns the total number of matching runs.
+func (r *SQLiteRepository) SearchScrapeRuns(ctx context.Context, filter model.ScrapeRunFilter) ([]model.ScrapeRun, int, error) {
+	filter.StartedAfter = utcPtr(filter.StartedAfter)
+	filter.StartedBefore = utcPtr(filter.StartedBefore)
+	return searchScrapeRuns(ctx, r.db, filter)
+}
+
+// GetScrapeRunByID retrieves a scrape run by its UUID.
+func (r *SQLiteRepository) GetScrapeRunByID(ctx context.Context, id uuid.UUID) (*model.ScrapeRun, error) {
+	return scrapeRunByID(ctx, r.db, id)
+}
+
+// GetAdminStats returns aggregated statistics for the admin dashboard.
+func (r *SQLiteRepository) GetAdminStats(ctx context.Context) (*model.AdminStats, error) {
+	stats := &model.AdminStats{
+		JobsBySource: make(map[string]int),
+	}
+
+	if err := r.db.QueryRowContext(ctx, `
+		SELECT COUNT(*), COUNT(*) FILTER (WHERE status = 'active') FROM jobs`,
+	).Scan(&stats.TotalJobs, &stats.ActiveJobs); err != nil {
+		return nil, fmt.Errorf("count jobs: %w", err)
+	}
+
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT source, COUNT(*) FROM jobs WHERE status = 'active' GROUP BY source`)
+	if err != nil {
+		return nil, fmt.Errorf("count jobs by source: %w", err)
+	}
+	for rows.Next() {
+		var src string
+		var count int
+		if err := rows.Scan(&src, &count); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("scan jobs by source: %w", err)
+		}
+		stats.JobsBySource[src] = count
+	}
+	rows.Close()
+
+	if stats.RecentRuns, err = recentScrapeRuns(ctx, r.db, 10); err != nil {
+		return nil, err
+	}
+
+	// Per-source stats, as in the v_scrape_stats view of the PostgreSQL
+	// schema.
+	srcRows, err := r.db.QueryContext(ctx, `
+		SELECT source, COUNT(*),
+		       COUNT(*) FILTER (WHERE status = 'completed'),
+		       COUNT(*) FILTER (WHERE status = 'failed'),
+		       SUM(jobs_found), SUM(jobs_new),
+		       COALESCE(AVG(duration_ms), 0), MAX(completed_at)
+		FROM scrape_runs WHERE status <> 'skipped'
+		GROUP BY source ORDER BY source`)
+	if err != nil {
+		return nil, fmt.Errorf("get source stats: %w", err)
+	}
+	defer srcRows.Close()
+	for srcRows.Next() {
+		var s model.SourceStat
+		var lastRun sql.NullString
+		if err := srcRows.Scan(
+			&s.Source, &s.TotalRuns, &s.SuccessfulRuns, &s.FailedRuns,
+			&s.TotalJobsFound, &s.TotalJobsNew, &s.AvgDurationMs, &lastRun,
+		); err != nil {
+			return nil, fmt.Errorf("scan source stats: %w", err)
+		}
+		if lastRun.Valid {
+			if t, err := time.Parse(sqliteTimeLayout, lastRun.String); err == nil {
+				s.LastSuccessfulRun = &t
+			}
+		}
+		stats.SourceStats = append(stats.SourceStats, s)
+	}
+	if err := srcRows.Err(); err != nil {
+		return nil, fmt.Errorf("get source stats: %w", err)
+	}
+
+	return stats, nil
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Career pages
+// ─────────────────────────────────────────────────────────────────────────────
+
+// GetCareerPages returns all enabled company career pages.
+func (r *SQLiteRepository) GetCareerPages(ctx context.Context) ([]model.CompanyCareerPage, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT id, company_id, company_name, career_page_url, source_type,
+		       selectors, is_enabled, last_scraped_at, jobs_found, created_at, updated_at
+		FROM company_career_pages
+		WHERE is_enabled = TRUE
+		ORDER BY company_name`)
+	if err != nil {
+		return nil, fmt.Errorf("get career pages: %w", err)
+	}
+	defer rows.Close()
+
+	var pages []model.CompanyCareerPage
+	for rows.Next() {
+		var p model.CompanyCareerPage
+		if err := rows.Scan(
+			&p.ID, &p.CompanyID, &p.CompanyName, &p.CareerPageURL,
+			&p.SourceType, &p.Selectors, &p.IsEnabled, &p.LastScrapedAt,
+			&p.JobsFound, &p.CreatedAt, &p.UpdatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("scan career page: %w", err)
+		}
+		pages = append(pages, p)
+	}
+	return pages, rows.Err()
+}
+
+// CreateCareerPage inserts a new enabled career page and fills in its ID and
+// timestamps. It returns ErrDuplicate if the career page URL is already
+// configured. The page's company name becomes an alias of its company,
+// unless it is another company's.
+func (r *SQLiteRepository) CreateCareerPage(ctx context.Context, page *model.CompanyCareerPage) error {
+	selectors := page.Selectors
+	if len(selectors) == 0 {
+		selectors = []byte("{}")
+	}
+	sourceType := page.SourceType
+	if sourceType == "" {
+		sourceType = model.CareerPageHTML
+	}
+
+	id, now := uuid.New(), r.now()
+	_, err := r.db.ExecContext(ctx, `
+		INSERT INTO company_career_pages (id, company_id, company_name, career_page_url, source_type, selectors, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $7)`,
+		id, page.CompanyID, page.CompanyName, page.CareerPageURL, string(sourceType), string(selectors), now,
+	)
+	if err != nil {
+		var sqliteErr *sqlite.Error
+		if errors.As(err, &sqliteErr) && sqliteErr.Code() == sqlite3.SQLITE_CONSTRAINT_UNIQUE {
+			return ErrDuplicate
+		}
+		return fmt.Errorf("create career page: %w", err)
+	}
+	if page.CompanyID != nil {
+		if err := addCompanyAlias(ctx, r.db, companyKey(page.CompanyName), *page.CompanyID, now); err != nil {
+			return fmt.Errorf("create career page: %w", err)
+		}
+	}
+	page.ID, page.IsEnabled, page.CreatedAt, page.UpdatedAt = id, true, now, now
+	page.SourceType = sourceType
+	page.Selectors = selectors
+	return nil
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Helpers
+// ─────────────────────────────────────────────────────────────────────────────
+
+// jsonArray scans a TEXT column holding a JSON array of strings, the SQLite
+// representation of a PostgreSQL text array.
+type jsonArray struct {
+	dst *pq.StringArray
+}
+
+// Scan implements sql.Scanner.
+func (a jsonArray) Scan(src interface{}) error {
+	var raw []byte
+	switch v := src.(type) {
+	case nil:
+		*a.dst = nil
+		return nil
+	case string:
+		raw = []byte(v)
+	case []byte:
+		raw = v
+	default:
+		return fmt.Errorf("scan json array: unsupported type %T", src)
+	}
+	var items []string
+	if err := json.Unmarshal(raw, &items); err != nil {
+		return fmt.Errorf("scan json array: %w", err)
+	}
+	*a.dst = items
+	return nil
+}
+
+// jsonArrayValue returns the JSON array stored for items.
+func jsonArrayValue(items []string) string {
+	if len(items) == 0 {
+		return "[]"
+	}
+	b, _ := json.Marshal(items)
+	return string(b)
+}
+
+// utcPtr returns *t in UTC, or nil. SQLite compares timestamps as text, so
+// every timestamp written or compared must be in the same time zone.
+func utcPtr(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+	u := t.UTC()
+	return &u
+}
@@ -0,0 +1,142 @@
+-- SQLite migration 001: Create job aggregation schema
+--
+-- The SQLite schema mirrors the PostgreSQL schema in migrations/ for the
+-- tables used by SQLiteRepository. Enums are TEXT columns with CHECK
+-- constraints, arrays are JSON arrays in TEXT columns, and timestamps are
+-- always written in UTC so that they compare as text.
+
+BEGIN;
+
+-- ─────────────────────────────────────────────────────────────────────────────
+-- jobs: Core job postings table
+-- ─────────────────────────────────────────────────────────────────────────────
+CREATE TABLE jobs (
+    id                  TEXT PRIMARY KEY,
+    dedup_hash          TEXT NOT NULL UNIQUE,
+    source              TEXT NOT NULL CHECK (source IN (
+                            'linkedin', 'indeed', 'company_career_page', 'glassdoor', 'other')),
+    external_id         TEXT,
+    company_id          TEXT,
+    company_name        TEXT NOT NULL CHECK (LENGTH(TRIM(company_name)) > 0),
+    title               TEXT NOT NULL CHECK (LENGTH(TRIM(title)) > 0),
+    description         TEXT,
+    description_html    TEXT,
+    industry            TEXT,
+    location_city       TEXT,
+    location_state      TEXT,
+    location_country    TEXT,
+    location_raw        TEXT,
+    location_type       TEXT NOT NULL DEFAULT 'unknown' CHECK (location_type IN (
+                            'on_site', 'remote', 'hybrid', 'unknown')),
+    employment_type     TEXT NOT NULL DEFAULT 'full_time' CHECK (employment_type IN (
+                            'full_time', 'part_time', 'contract', 'temporary',
+                            'internship', 'volunteer', 'other')),
+    experience_level    TEXT NOT NULL DEFAULT 'unknown' CHECK (experience_level IN (
+                            'internship', 'entry', 'mid', 'senior', 'lead', 'executive', 'unknown')),
+    -- JSON arrays of skill names
+    required_skills     TEXT NOT NULL DEFAULT '[]',
+    preferred_skills    TEXT NOT NULL DEFAULT '[]',
+    nice_to_have_skills TEXT NOT NULL DEFAULT '[]',
+    salary_min          INTEGER CHECK (salary_min IS NULL OR salary_min >= 0),
+    salary_max          INTEGER CHECK (salary_max IS NULL OR salary_max >= 0),
+    salary_currency     TEXT DEFAULT 'USD',
+    salary_raw          TEXT,
+    application_url     TEXT NOT NULL,
+    -- JSON array of every application URL merged into this job
+    source_urls         TEXT NOT NULL DEFAULT '[]',
+    company_url         TEXT,
+    posted_at           TIMESTAMP,
+    expires_at          TIMESTAMP,
+    scraped_at          TIMESTAMP NOT NULL,
+    last_seen_at        TIMESTAMP NOT NULL,
+    status              TEXT NOT NULL DEFAULT 'active' CHECK (status IN (
+                            'active', 'expired', 'filled', 'unknown')),
+    expiry_reason       TEXT CHECK (expiry_reason IN (
+                            'stale', 'not_found', 'redirected', 'closed_notice')),
+    expiry_checked_at   TIMESTAMP,
+    expired_at          TIMESTAMP,
+    is_featured         BOOLEAN NOT NULL DEFAULT FALSE,
+    raw_data            TEXT,
+    created_at          TIMESTAMP NOT NULL,
+    updated_at          TIMESTAMP NOT NULL,
+
+    CHECK (salary_min IS NULL OR salary_max IS NULL OR salary_min <= salary_max)
+);
+
+CREATE INDEX idx_jobs_source ON jobs(source);
+CREATE INDEX idx_jobs_company_name ON jobs(company_name);
+CREATE INDEX idx_jobs_last_seen ON jobs(source, last_seen_at);
+CREATE INDEX idx_jobs_active_recent ON jobs(status, COALESCE(posted_at, scraped_at) DESC, id DESC);
+
+-- ─────────────────────────────────────────────────────────────────────────────
+-- dedup_log: Postings merged into an existing job by fuzzy deduplication
+-- ─────────────────────────────────────────────────────────────────────────────
+CREATE TABLE dedup_log (
+    id               TEXT PRIMARY KEY,
+    job_id           TEXT NOT NULL REFERENCES jobs(id) ON DELETE CASCADE,
+    dedup_hash       TEXT NOT NULL UNIQUE,
+    source           TEXT NOT NULL,
+    external_id      TEXT NOT NULL DEFAULT '',
+    title            TEXT NOT NULL,
+    company_name     TEXT NOT NULL,
+    application_url  TEXT NOT NULL,
+    canonical_title  TEXT NOT NULL,
+    similarity       REAL NOT NULL,
+    threshold        REAL NOT NULL,
+    created_at       TIMESTAMP NOT NULL
+);
+
+CREATE INDEX idx_dedup_log_job_id ON dedup_log(job_id);
+CREATE INDEX idx_dedup_log_created_at ON dedup_log(created_at DESC);
+
+-- ─────────────────────────────────────────────────────────────────────────────
+-- scrape_runs: Log of each scraping run
+-- ─────────────────────────────────────────────────────────────────────────────
+CREATE TABLE scrape_runs (
+    id              TEXT PRIMARY KEY,
+    source          TEXT NOT NULL,
+    scraper_name    TEXT NOT NULL DEFAULT '',
+    search_query    TEXT,
+    search_location TEXT,
+    status          TEXT NOT NULL DEFAULT 'pending' CHECK (status IN (
+                        'pending', 'running', 'completed', 'failed', 'rate_limited', 'interrupted')),
+    jobs_found      INTEGER NOT NULL DEFAULT 0,
+    jobs_new        INTEGER NOT NULL DEFAULT 0,
+    jobs_updated    INTEGER NOT NULL DEFAULT 0,
+    jobs_failed     INTEGER NOT NULL DEFAULT 0,
+    pages_scraped   INTEGER NOT NULL DEFAULT 0,
+    error_message   TEXT,
+    started_at      TIMESTAMP,
+    completed_at    TIMESTAMP,
+    -- Whole seconds, as in PostgreSQL.
+    duration_ms     INTEGER GENERATED ALWAYS AS (
+        CASE
+            WHEN completed_at IS NOT NULL AND started_at IS NOT NULL
+            THEN CAST((julianday(completed_at) - julianday(started_at)) * 86400 AS INTEGER) * 1000
+        END
+    ) STORED,
+    created_at      TIMESTAMP NOT NULL
+);
+
+CREATE INDEX idx_scrape_runs_created_at ON scrape_runs(created_at DESC);
+CREATE INDEX idx_scrape_runs_scraper_name ON scrape_runs(scraper_name, created_at DESC);
+
+-- ─────────────────────────────────────────────────────────────────────────────
+-- company_career_pages: Configurable list of company career pages to scrape
+-- ─────────────────────────────────────────────────────────────────────────────
+CREATE TABLE company_career_pages (
+    id              TEXT PRIMARY KEY,
+    company_id      TEXT,
+    company_name    TEXT NOT NULL,
+    career_page_url TEXT NOT NULL UNIQUE,
+    source_type     TEXT NOT NULL DEFAULT 'html' CHECK (source_type IN (
+                        'html', 'greenhouse', 'lever', 'feed')),
+    selectors       TEXT NOT NULL DEFAULT '{}',
+    is_enabled      BOOLEAN NOT NULL DEFAULT TRUE,
+    last_scraped_at TIMESTAMP,
+    jobs_found      INTEGER NOT NULL DEFAULT 0,
+    created_at      TIMESTAMP NOT NULL,
+    updated_at      TIMESTAMP NOT NULL
+);
+
+COMMIT;
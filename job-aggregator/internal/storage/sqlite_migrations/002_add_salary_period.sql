@@ -0,0 +1,11 @@
+-- SQLite migration 002: Salary period
+--
+-- Mirrors migrations/014_add_salary_period.sql.
+
+BEGIN;
+
+ALTER TABLE jobs
+    ADD COLUMN salary_period TEXT CHECK (salary_period IS NULL OR salary_period IN (
+        'hour', 'day', 'week', 'month', 'year'));
+
+COMMIT;
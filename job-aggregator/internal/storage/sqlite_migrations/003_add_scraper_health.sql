@@ -0,0 +1,20 @@
+-- SQLite migration 003: Scraper health
+--
+-- Mirrors migrations/015_add_scraper_health.sql.
+
+BEGIN;
+
+CREATE TABLE scraper_health (
+    scraper_name         TEXT PRIMARY KEY,
+    last_run_at          TIMESTAMP,
+    last_success_at      TIMESTAMP,
+    last_jobs_found      INTEGER NOT NULL DEFAULT 0,
+    consecutive_failures INTEGER NOT NULL DEFAULT 0,
+    avg_jobs_found_7d    REAL NOT NULL DEFAULT 0,
+    runs_7d              INTEGER NOT NULL DEFAULT 0,
+    updated_at           TIMESTAMP NOT NULL
+);
+
+CREATE INDEX idx_scrape_runs_scraper_status ON scrape_runs(scraper_name, status, created_at DESC);
+
+COMMIT;
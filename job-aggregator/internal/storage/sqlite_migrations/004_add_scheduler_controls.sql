@@ -0,0 +1,62 @@
+-- SQLite migration 004: Skipped scrape runs and scheduler controls
+--
+-- Mirrors migrations/016_add_skipped_scrape_status.sql and
+-- migrations/017_add_scheduler_controls.sql. SQLite cannot change a CHECK
+-- constraint in place, so scrape_runs is rebuilt to accept 'skipped'.
+
+BEGIN;
+
+CREATE TABLE scrape_runs_new (
+    id              TEXT PRIMARY KEY,
+    source          TEXT NOT NULL,
+    scraper_name    TEXT NOT NULL DEFAULT '',
+    search_query    TEXT,
+    search_location TEXT,
+    status          TEXT NOT NULL DEFAULT 'pending' CHECK (status IN (
+                        'pending', 'running', 'completed', 'failed', 'rate_limited', 'interrupted', 'skipped')),
+    jobs_found      INTEGER NOT NULL DEFAULT 0,
+    jobs_new        INTEGER NOT NULL DEFAULT 0,
+    jobs_updated    INTEGER NOT NULL DEFAULT 0,
+    jobs_failed     INTEGER NOT NULL DEFAULT 0,
+    pages_scraped   INTEGER NOT NULL DEFAULT 0,
+    error_message   TEXT,
+    started_at      TIMESTAMP,
+    completed_at    TIMESTAMP,
+    -- Whole seconds, as in PostgreSQL.
+    duration_ms     INTEGER GENERATED ALWAYS AS (
+        CASE
+            WHEN completed_at IS NOT NULL AND started_at IS NOT NULL
+            THEN CAST((julianday(completed_at) - julianday(started_at)) * 86400 AS INTEGER) * 1000
+        END
+    ) STORED,
+    created_at      TIMESTAMP NOT NULL
+);
+
+INSERT INTO scrape_runs_new (id, source, scraper_name, search_query, search_location, status,
+                             jobs_found, jobs_new, jobs_updated, jobs_failed, pages_scraped,
+                             error_message, started_at, completed_at, created_at)
+SELECT id, source, scraper_name, search_query, search_location, status,
+       jobs_found, jobs_new, jobs_updated, jobs_failed, pages_scraped,
+       error_message, started_at, completed_at, created_at
+FROM scrape_runs;
+
+DROP TABLE scrape_runs;
+ALTER TABLE scrape_runs_new RENAME TO scrape_runs;
+
+CREATE INDEX idx_scrape_runs_created_at ON scrape_runs(created_at DESC);
+CREATE INDEX idx_scrape_runs_scraper_name ON scrape_runs(scraper_name, created_at DESC);
+CREATE INDEX idx_scrape_runs_scraper_status ON scrape_runs(scraper_name, status, created_at DESC);
+
+CREATE TABLE scheduler_state (
+    id         INTEGER PRIMARY KEY DEFAULT 1 CHECK (id = 1),
+    paused     BOOLEAN NOT NULL DEFAULT FALSE,
+    updated_at TIMESTAMP NOT NULL
+);
+
+CREATE TABLE scraper_controls (
+    scraper_name TEXT PRIMARY KEY,
+    enabled      BOOLEAN NOT NULL DEFAULT TRUE,
+    updated_at   TIMESTAMP NOT NULL
+);
+
+COMMIT;
@@ -0,0 +1,27 @@
+-- SQLite migration 005: Saved jobs
+--
+-- Mirrors migrations/018_add_saved_jobs.sql.
+
+BEGIN;
+
+CREATE TABLE saved_jobs (
+    id              TEXT PRIMARY KEY,
+    user_id         TEXT NOT NULL,
+    job_id          TEXT NOT NULL,
+    status          TEXT NOT NULL DEFAULT 'saved' CHECK (status IN (
+                        'saved', 'applied', 'interviewing', 'offer', 'rejected')),
+    title           TEXT NOT NULL,
+    company_name    TEXT NOT NULL,
+    application_url TEXT NOT NULL,
+    saved_at        TIMESTAMP NOT NULL,
+    applied_at      TIMESTAMP,
+    interviewing_at TIMESTAMP,
+    offer_at        TIMESTAMP,
+    rejected_at     TIMESTAMP,
+    updated_at      TIMESTAMP NOT NULL,
+    UNIQUE (user_id, job_id)
+);
+
+CREATE INDEX idx_saved_jobs_user_saved_at ON saved_jobs(user_id, saved_at DESC);
+
+COMMIT;
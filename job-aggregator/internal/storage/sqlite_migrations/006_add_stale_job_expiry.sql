@@ -0,0 +1,24 @@
+-- SQLite migration 006: Stale job expiry per scraper
+--
+-- Mirrors migrations/019_add_stale_job_expiry.sql.
+
+BEGIN;
+
+ALTER TABLE jobs ADD COLUMN last_seen_by TEXT;
+
+CREATE INDEX idx_jobs_active_last_seen_by ON jobs(last_seen_by, last_seen_at)
+    WHERE status = 'active';
+
+CREATE TABLE expiry_runs (
+    id            TEXT PRIMARY KEY,
+    started_at    TIMESTAMP NOT NULL,
+    completed_at  TIMESTAMP NOT NULL,
+    jobs_expired  INTEGER NOT NULL DEFAULT 0,
+    -- JSON array of {scraper, stale_after, cutoff, jobs_expired}
+    scrapers      TEXT NOT NULL DEFAULT '[]',
+    error_message TEXT
+);
+
+CREATE INDEX idx_expiry_runs_started_at ON expiry_runs(started_at DESC);
+
+COMMIT;
@@ -0,0 +1,25 @@
+-- SQLite migration 007: Audit log of admin mutations
+--
+-- Mirrors migrations/020_create_audit_log.sql.
+
+BEGIN;
+
+CREATE TABLE audit_log (
+    id          TEXT PRIMARY KEY,
+    service     TEXT NOT NULL,
+    actor       TEXT NOT NULL,
+    actor_email TEXT NOT NULL DEFAULT '',
+    action      TEXT NOT NULL,
+    entity_type TEXT NOT NULL DEFAULT '',
+    entity_id   TEXT NOT NULL DEFAULT '',
+    -- {"field": {"old": ..., "new": ...}} for the changed fields
+    changes     TEXT,
+    request_id  TEXT NOT NULL DEFAULT '',
+    created_at  TIMESTAMP NOT NULL
+);
+
+CREATE INDEX idx_audit_log_created_at ON audit_log(created_at DESC);
+CREATE INDEX idx_audit_log_actor ON audit_log(actor, created_at DESC);
+CREATE INDEX idx_audit_log_entity ON audit_log(entity_type, entity_id, created_at DESC);
+
+COMMIT;
@@ -0,0 +1,18 @@
+-- SQLite migration 008: Location cache
+--
+-- Mirrors migrations/021_add_location_cache.sql.
+
+BEGIN;
+
+CREATE TABLE location_cache (
+    raw_key        TEXT PRIMARY KEY,
+    parser_version INTEGER NOT NULL,
+    city           TEXT NOT NULL DEFAULT '',
+    region         TEXT NOT NULL DEFAULT '',
+    country        TEXT NOT NULL DEFAULT '',
+    remote         BOOLEAN NOT NULL DEFAULT FALSE,
+    hybrid         BOOLEAN NOT NULL DEFAULT FALSE,
+    updated_at     TIMESTAMP NOT NULL
+);
+
+COMMIT;
@@ -0,0 +1,10 @@
+-- SQLite migration 009: Changed fields of re-scraped jobs
+--
+-- Mirrors migrations/022_add_job_changed_fields.sql.
+
+BEGIN;
+
+ALTER TABLE jobs ADD COLUMN changed_fields TEXT;
+ALTER TABLE jobs ADD COLUMN changed_at     TIMESTAMP;
+
+COMMIT;
@@ -0,0 +1,10 @@
+-- SQLite migration 010: Match scores of saved jobs
+--
+-- Mirrors migrations/023_add_saved_job_scores.sql.
+
+BEGIN;
+
+ALTER TABLE saved_jobs ADD COLUMN match_score       REAL;
+ALTER TABLE saved_jobs ADD COLUMN score_computed_at TIMESTAMP;
+
+COMMIT;
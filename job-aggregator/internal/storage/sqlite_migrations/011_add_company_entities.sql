@@ -0,0 +1,39 @@
+-- SQLite migration 011: Company entities
+--
+-- Mirrors migrations/024_add_company_entities.sql, and adds the companies
+-- table of the PostgreSQL schema, which the SQLite schema left out. SQLite
+-- has no regular expressions to derive company keys with, so existing jobs
+-- and career pages are not backfilled; jobs are linked when next scraped.
+
+BEGIN;
+
+CREATE TABLE companies (
+    id              TEXT PRIMARY KEY,
+    name            TEXT NOT NULL,
+    normalized_name TEXT NOT NULL UNIQUE,
+    industry        TEXT,
+    website_url     TEXT,
+    linkedin_url    TEXT,
+    logo_url        TEXT,
+    size_range      TEXT,
+    headquarters    TEXT,
+    description     TEXT,
+    website_domain  TEXT,
+    needs_review    BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at      TIMESTAMP NOT NULL,
+    updated_at      TIMESTAMP NOT NULL
+);
+
+CREATE INDEX idx_companies_website_domain ON companies(website_domain);
+
+CREATE TABLE company_aliases (
+    alias      TEXT PRIMARY KEY,
+    company_id TEXT NOT NULL REFERENCES companies(id) ON DELETE CASCADE,
+    created_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX idx_company_aliases_company_id ON company_aliases(company_id);
+CREATE INDEX idx_jobs_company_id ON jobs(company_id);
+CREATE INDEX idx_company_career_pages_company_id ON company_career_pages(company_id);
+
+COMMIT;
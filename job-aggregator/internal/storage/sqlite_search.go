@@ -0,0 +1,266 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/learnbot/job-aggregator/internal/model"
+	"github.com/learnbot/shared/geo"
+)
+
+// sqliteRecency scores a job between 0 and 1 by age, halving after a week,
+// as jobRecency does. The current time is bound to placeholder $argIdx.
+func sqliteRecency(argIdx int) string {
+	return fmt.Sprintf("(1.0 / (1.0 + (julianday($%d) - julianday(%s)) / 7.0))", argIdx, jobSortKey)
+}
+
+// likeWords returns a condition requiring every word of query to occur in
+// one of columns, matched case-insensitively with LIKE, and appends the
+// word patterns to args. It stands in for PostgreSQL full-text matching:
+// words are not stemmed, and they match inside longer words.
+func likeWords(query string, columns []string, args *[]interface{}) string {
+	var conds []string
+	for _, word := range strings.Fields(query) {
+		*args = append(*args, "%"+escapeLike(word)+"%")
+		var alts []string
+		for _, col := range columns {
+			alts = append(alts, fmt.Sprintf(`%s LIKE $%d ESCAPE '\'`, col, len(*args)))
+		}
+		conds = append(conds, "("+strings.Join(alts, " OR ")+")")
+	}
+	return strings.Join(conds, " AND ")
+}
+
+// placeholders appends values to args and returns their placeholder list,
+// for use in an IN clause.
+func placeholders[T any](values []T, args *[]interface{}) string {
+	ph := make([]string, len(values))
+	for i, v := range values {
+		*args = append(*args, v)
+		ph[i] = fmt.Sprintf("$%d", len(*args))
+	}
+	return strings.Join(ph, ", ")
+}
+
+// SearchJobs queries jobs with filters and pagination, newest first. next
+// is the cursor of the last job when more follow; filter.After continues
+// from it. The title search matches every word with LIKE.
+func (r *SQLiteRepository) SearchJobs(ctx context.Context, filter model.JobFilter) (jobs []model.Job, total int, next *model.JobCursor, err error) {
+	if filter.PageSize <= 0 {
+		filter.PageSize = 20
+	}
+	if filter.Page <= 0 {
+		filter.Page = 1
+	}
+
+	where := []string{"1=1"}
+	args := []interface{}{}
+
+	// Status filter (default to active)
+	status := filter.Status
+	if status == "" {
+		status = model.StatusActive
+	}
+	args = append(args, status)
+	where = append(where, fmt.Sprintf("status = $%d", len(args)))
+
+	if len(filter.Sources) > 0 {
+		where = append(where, "source IN ("+placeholders(filter.Sources, &args)+")")
+	}
+	if len(filter.LocationTypes) > 0 {
+		where = append(where, "location_type IN ("+placeholders(filter.LocationTypes, &args)+")")
+	}
+	if len(filter.ExperienceLevels) > 0 {
+		where = append(where, "experience_level IN ("+placeholders(filter.ExperienceLevels, &args)+")")
+	}
+
+	// Skills filter (job must have at least one of the required skills)
+	if len(filter.Skills) > 0 {
+		where = append(where, "EXISTS (SELECT 1 FROM json_each(required_skills) WHERE value IN ("+
+			placeholders(filter.Skills, &args)+"))")
+	}
+
+	if filter.SalaryMin != nil {
+		args = append(args, *filter.SalaryMin)
+		where = append(where, fmt.Sprintf("(salary_max IS NULL OR salary_max >= $%d)", len(args)))
+	}
+	if filter.PostedAfter != nil {
+		args = append(args, filter.PostedAfter.UTC())
+		where = append(where, fmt.Sprintf("(posted_at IS NULL OR posted_at >= $%d)", len(args)))
+	}
+	if title := strings.TrimSpace(filter.TitleSearch); title != "" {
+		where = append(where, likeWords(title, []string{"title"}, &args))
+	}
+	if filter.CompanyName != "" {
+		args = append(args, "%"+escapeLike(filter.CompanyName)+"%")
+		where = append(where, fmt.Sprintf(`company_name LIKE $%d ESCAPE '\'`, len(args)))
+	}
+	if filter.CompanyID != nil {
+		args = append(args, *filter.CompanyID)
+		where = append(where, fmt.Sprintf("company_id = $%d", len(args)))
+	}
+
+	whereClause := strings.Join(where, " AND ")
+
+	countQuery := fmt.Sprintf("SELECT COUNT(*) FROM jobs WHERE %s", whereClause)
+	if err := r.db.QueryRowContext(ctx, countQuery, args...).Scan(&total); err != nil {
+		return nil, 0, nil, fmt.Errorf("count jobs: %w", err)
+	}
+
+	// One extra row tells whether another page follows.
+	offset := (filter.Page - 1) * filter.PageSize
+	if filter.After != nil {
+		whereClause += " AND " + keysetCondition(len(args)+1)
+		args = append(args, filter.After.PostedAt.UTC(), filter.After.ID)
+		offset = 0
+	}
+	args = append(args, filter.PageSize+1, offset)
+	dataQuery := fmt.Sprintf(`
+		SELECT %s
+		FROM jobs
+		WHERE %s
+		%s
+		LIMIT $%d OFFSET $%d`, sqliteJobColumns, whereClause, keysetOrder, len(args)-1, len(args))
+
+	rows, err := r.db.QueryContext(ctx, dataQuery, args...)
+	if err != nil {
+		return nil, 0, nil, fmt.Errorf("search jobs: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var j model.Job
+		if err := scanSQLiteJob(rows, &j); err != nil {
+			return nil, 0, nil, fmt.Errorf("scan job: %w", err)
+		}
+		jobs = append(jobs, j)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, nil, fmt.Errorf("iterate jobs: %w", err)
+	}
+
+	if len(jobs) > filter.PageSize {
+		jobs = jobs[:filter.PageSize]
+		c := model.CursorOf(&jobs[len(jobs)-1])
+		next = &c
+	}
+	return jobs, total, next, nil
+}
+
+// FullTextSearch returns active jobs matching the filter, with the ordering
+// and pagination of (*PostgresRepository).FullTextSearch. Every query word
+// must occur in the title or description. The relevance score ranks jobs
+// with every word in the title above jobs matched by the description, and
+// combines that with recency.
+func (r *SQLiteRepository) FullTextSearch(ctx context.Context, filter model.JobSearchFilter) (results []model.JobSearchResult, total int, next *model.JobCursor, err error) {
+	if filter.Limit <= 0 {
+		filter.Limit = 20
+	}
+	if filter.Limit > 100 {
+		filter.Limit = 100
+	}
+	if filter.Offset < 0 || filter.After != nil {
+		filter.Offset = 0
+	}
+	query := strings.TrimSpace(filter.Query)
+	keyset := filter.After != nil || filter.Sort == model.SortRecent ||
+		(filter.Sort == "" && query == "")
+
+	conditions := []string{searchStatusCondition(filter)}
+	var args []interface{}
+
+	if query != "" {
+		conditions = append(conditions, likeWords(query, []string{"title", "COALESCE(description, '')"}, &args))
+	}
+	if loc := strings.TrimSpace(filter.Location); loc != "" {
+		args = append(args, "%"+escapeLike(loc)+"%")
+		cond := fmt.Sprintf(
+			`(COALESCE(location_city, '') || ' ' || COALESCE(location_state, '') || ' ' || `+
+				`COALESCE(location_country, '') || ' ' || COALESCE(location_raw, '')) LIKE $%d ESCAPE '\'`, len(args))
+		if code := geo.CountryCode(loc); code != "" {
+			args = append(args, code)
+			cond = fmt.Sprintf("(%s OR location_country = $%d)", cond, len(args))
+		}
+		conditions = append(conditions, cond)
+	}
+	if filter.RemoteOnly {
+		conditions = append(conditions, fmt.Sprintf("location_type = '%s'", model.LocationRemote))
+	}
+	if company := strings.TrimSpace(filter.CompanyName); company != "" {
+		args = append(args, "%"+escapeLike(company)+"%")
+		conditions = append(conditions, fmt.Sprintf(`company_name LIKE $%d ESCAPE '\'`, len(args)))
+	}
+	if filter.CompanyID != nil {
+		args = append(args, *filter.CompanyID)
+		conditions = append(conditions, fmt.Sprintf("company_id = $%d", len(args)))
+	}
+	if filter.Source != "" {
+		args = append(args, filter.Source)
+		conditions = append(conditions, fmt.Sprintf("source = $%d", len(args)))
+	}
+	if filter.PostedAfter != nil {
+		// Jobs without a posting date are judged by when they were scraped.
+		args = append(args, filter.PostedAfter.UTC())
+		conditions = append(conditions, fmt.Sprintf("%s >= $%d", jobSortKey, len(args)))
+	}
+	conditions = append(conditions, salaryConditions(filter, &args)...)
+
+	where := "WHERE " + strings.Join(conditions, " AND ")
+
+	if err := r.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM jobs "+where, args...).Scan(&total); err != nil {
+		return nil, 0, nil, fmt.Errorf("count search jobs: %w", err)
+	}
+
+	args = append(args, r.now())
+	relevance := sqliteRecency(len(args))
+	if query != "" {
+		relevance = fmt.Sprintf("(%g * (CASE WHEN %s THEN 1.0 ELSE 0.5 END) + %g * %s)",
+			searchRankWeight, likeWords(query, []string{"title"}, &args), searchRecencyWeight, relevance)
+	}
+
+	// The total covers every match; only the page starts after the cursor.
+	order, limit := `ORDER BY relevance DESC, `+jobSortKey+` DESC, id`, filter.Limit
+	if keyset {
+		// One extra row tells whether another page follows.
+		order, limit = keysetOrder, filter.Limit+1
+		if filter.After != nil {
+			where += " AND " + keysetCondition(len(args)+1)
+			args = append(args, filter.After.PostedAt.UTC(), filter.After.ID)
+		}
+	}
+	args = append(args, limit, filter.Offset)
+
+	dataQ := fmt.Sprintf(`
+		SELECT %s,
+		       %s AS relevance
+		FROM jobs
+		%s
+		%s
+		LIMIT $%d OFFSET $%d`, sqliteJobColumns, relevance, where, order, len(args)-1, len(args))
+
+	rows, err := r.db.QueryContext(ctx, dataQ, args...)
+	if err != nil {
+		return nil, 0, nil, fmt.Errorf("search jobs: %w", err)
+	}
+	defer rows.Close()
+
+	results = []model.JobSearchResult{}
+	for rows.Next() {
+		var res model.JobSearchResult
+		if err := scanSQLiteJob(rows, &res.Job, &res.Relevance); err != nil {
+			return nil, 0, nil, fmt.Errorf("scan search job: %w", err)
+		}
+		results = append(results, res)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, nil, fmt.Errorf("iterate search jobs: %w", err)
+	}
+
+	if len(results) > filter.Limit {
+		results = results[:filter.Limit]
+		c := model.CursorOf(&results[len(results)-1].Job)
+		next = &c
+	}
+	return results, total, next, nil
+}
@@ -0,0 +1,55 @@
+package storage
+
+import (
+	"context"
+	"io"
+	"log"
+	"reflect"
+	"testing"
+
+	"github.com/lib/pq"
+)
+
+func TestSQLiteMigrate_Idempotent(t *testing.T) {
+	repo, db := openSQLiteRepository(t)
+	if err := repo.(*SQLiteRepository).Migrate(context.Background(), log.New(io.Discard, "", 0)); err != nil {
+		t.Fatalf("second Migrate: %v", err)
+	}
+
+	files, err := sqliteMigrations.ReadDir("sqlite_migrations")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var applied int
+	if err := db.QueryRow(`SELECT COUNT(*) FROM schema_migrations`).Scan(&applied); err != nil {
+		t.Fatalf("count migrations: %v", err)
+	}
+	if applied != len(files) {
+		t.Errorf("schema_migrations has %d rows, want %d", applied, len(files))
+	}
+}
+
+func TestJSONArray_RoundTrip(t *testing.T) {
+	tests := []struct {
+		items []string
+		want  pq.StringArray
+	}{
+		{nil, pq.StringArray{}},
+		{[]string{"Go"}, pq.StringArray{"Go"}},
+		{[]string{"C++", `say "hi"`}, pq.StringArray{"C++", `say "hi"`}},
+	}
+	for _, tt := range tests {
+		var got pq.StringArray
+		if err := (jsonArray{&got}).Scan(jsonArrayValue(tt.items)); err != nil {
+			t.Fatalf("Scan(%q): %v", tt.items, err)
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("round trip of %q = %q, want %q", tt.items, got, tt.want)
+		}
+	}
+
+	var got pq.StringArray
+	if err := (jsonArray{&got}).Scan(42); err == nil {
+		t.Error("expected error scanning an integer")
+	}
+}
@@ -0,0 +1,150 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/learnbot/job-aggregator/internal/model"
+)
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Stale job expiry
+// ─────────────────────────────────────────────────────────────────────────────
+
+// MarkStaleJobs expires the active jobs last seen by the named scraper
+// before the cutoff. UpsertJob makes them active again if they reappear.
+func (r *PostgresRepository) MarkStaleJobs(ctx context.Context, scraperName string, cutoff time.Time) (int64, error) {
+	return markStaleJobs(ctx, r.db, `last_seen_by = $3`, []interface{}{scraperName}, cutoff, time.Now())
+}
+
+// MarkOtherStaleJobs expires the active jobs last seen before the cutoff by
+// none of the named scrapers, including jobs with no recorded scraper.
+func (r *PostgresRepository) MarkOtherStaleJobs(ctx context.Context, scraperNames []string, cutoff time.Time) (int64, error) {
+	cond, args := otherScrapersCond(scraperNames)
+	return markStaleJobs(ctx, r.db, cond, args, cutoff, time.Now())
+}
+
+// CreateExpiryRun records a stale job expiry pass, setting its ID.
+func (r *PostgresRepository) CreateExpiryRun(ctx context.Context, run *model.ExpiryRun) error {
+	return createExpiryRun(ctx, r.db, run)
+}
+
+// ListExpiryRuns returns the most recent expiry passes, newest first.
+func (r *PostgresRepository) ListExpiryRuns(ctx context.Context, limit int) ([]model.ExpiryRun, error) {
+	return listExpiryRuns(ctx, r.db, limit)
+}
+
+// MarkStaleJobs expires the active jobs last seen by the named scraper
+// before the cutoff. UpsertJob makes them active again if they reappear.
+func (r *SQLiteRepository) MarkStaleJobs(ctx context.Context, scraperName string, cutoff time.Time) (int64, error) {
+	return markStaleJobs(ctx, r.db, `last_seen_by = $3`, []interface{}{scraperName}, cutoff.UTC(), r.now().UTC())
+}
+
+// MarkOtherStaleJobs expires the active jobs last seen before the cutoff by
+// none of the named scrapers, including jobs with no recorded scraper.
+func (r *SQLiteRepository) MarkOtherStaleJobs(ctx context.Context, scraperNames []string, cutoff time.Time) (int64, error) {
+	cond, args := otherScrapersCond(scraperNames)
+	return markStaleJobs(ctx, r.db, cond, args, cutoff.UTC(), r.now().UTC())
+}
+
+// CreateExpiryRun records a stale job expiry pass, setting its ID.
+func (r *SQLiteRepository) CreateExpiryRun(ctx context.Context, run *model.ExpiryRun) error {
+	return createExpiryRun(ctx, r.db, run)
+}
+
+// ListExpiryRuns returns the most recent expiry passes, newest first.
+func (r *SQLiteRepository) ListExpiryRuns(ctx context.Context, limit int) ([]model.ExpiryRun, error) {
+	return listExpiryRuns(ctx, r.db, limit)
+}
+
+// otherScrapersCond returns the condition matching jobs last seen by none
+// of the named scrapers, with its arguments numbered from $3.
+func otherScrapersCond(scraperNames []string) (string, []interface{}) {
+	if len(scraperNames) == 0 {
+		return `TRUE`, nil
+	}
+	args := make([]interface{}, len(scraperNames))
+	params := make([]string, len(scraperNames))
+	for i, name := range scraperNames {
+		args[i] = name
+		params[i] = fmt.Sprintf("$%d", i+3)
+	}
+	return `(last_seen_by IS NULL OR last_seen_by NOT IN (` + strings.Join(params, ", ") + `))`, args
+}
+
+// markStaleJobs implements MarkStaleJobs and MarkOtherStaleJobs for both
+// backends. cond selects the jobs by scraper, with arguments from $3.
+func markStaleJobs(ctx context.Context, db *sql.DB, cond string, args []interface{}, cutoff, now time.Time) (int64, error) {
+	result, err := db.ExecContext(ctx, `
+		UPDATE jobs
+		SET status = 'expired', expiry_reason = 'stale', expired_at = $2, updated_at = $2
+		WHERE status = 'active' AND last_seen_at < $1 AND `+cond,
+		append([]interface{}{cutoff, now}, args...)...,
+	)
+	if err != nil {
+		return 0, fmt.Errorf("mark stale jobs: %w", err)
+	}
+	return result.RowsAffected()
+}
+
+// createExpiryRun implements CreateExpiryRun for both backends. The
+// per-scraper counts are stored as JSON text, which PostgreSQL casts to
+// JSONB.
+func createExpiryRun(ctx context.Context, db *sql.DB, run *model.ExpiryRun) error {
+	scrapers, err := json.Marshal(run.Scrapers)
+	if err != nil {
+		return fmt.Errorf("create expiry run: %w", err)
+	}
+	if run.Scrapers == nil {
+		scrapers = []byte("[]")
+	}
+	run.ID = uuid.New()
+	_, err = db.ExecContext(ctx, `
+		INSERT INTO expiry_runs (id, started_at, completed_at, jobs_expired, scrapers, error_message)
+		VALUES ($1, $2, $3, $4, $5, $6)`,
+		run.ID, run.StartedAt.UTC(), run.CompletedAt.UTC(), run.JobsExpired, string(scrapers), nullString(run.Error),
+	)
+	if err != nil {
+		return fmt.Errorf("create expiry run: %w", err)
+	}
+	return nil
+}
+
+// listExpiryRuns implements ListExpiryRuns for both backends.
+func listExpiryRuns(ctx context.Context, db *sql.DB, limit int) ([]model.ExpiryRun, error) {
+	rows, err := db.QueryContext(ctx, `
+		SELECT id, started_at, completed_at, jobs_expired, scrapers, COALESCE(error_message, '')
+		FROM expiry_runs
+		ORDER BY started_at DESC
+		LIMIT $1`, limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("list expiry runs: %w", err)
+	}
+	defer rows.Close()
+
+	var runs []model.ExpiryRun
+	for rows.Next() {
+		var (
+			run      model.ExpiryRun
+			scrapers []byte
+		)
+		if err := rows.Scan(&run.ID, &run.StartedAt, &run.CompletedAt, &run.JobsExpired, &scrapers, &run.Error); err != nil {
+			return nil, fmt.Errorf("scan expiry run: %w", err)
+		}
+		if err := json.Unmarshal(scrapers, &run.Scrapers); err != nil {
+			return nil, fmt.Errorf("decode expiry run scrapers: %w", err)
+		}
+		runs = append(runs, run)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate expiry runs: %w", err)
+	}
+	return runs, nil
+}
@@ -0,0 +1,360 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/learnbot/job-aggregator/internal/model"
+	"github.com/lib/pq"
+)
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Batched job upserts
+// ─────────────────────────────────────────────────────────────────────────────
+
+// upsertBatchSize is the number of jobs stored by one INSERT statement,
+// which keeps the statement well below PostgreSQL's 65535 parameters.
+const upsertBatchSize = 500
+
+// UpsertResult is the outcome of storing one scraped job.
+type UpsertResult struct {
+	Job *model.Job
+	// Inserted is true for a new job, and false when an existing job was
+	// refreshed or the posting merged into one.
+	Inserted bool
+	// Changes holds the fields, "title" and "location", in which the
+	// posting differs materially from the stored job. They are recorded in
+	// the job's changed_fields but not overwritten.
+	Changes map[string]FieldChange
+}
+
+// FieldChange is a stored value and the differing value re-scraped for it.
+type FieldChange struct {
+	Old string `json:"old"`
+	New string `json:"new"`
+}
+
+// UpsertJobs stores scraped jobs as UpsertJob does, with one INSERT per
+// batch of up to 500 jobs. Postings in a batch are not fuzzy-matched
+// against each other, and a posting repeated in a batch is stored once,
+// from its last occurrence. The results are in the order of jobs. Under a
+// context from WithDryRun the jobs are recorded one at a time.
+func (r *PostgresRepository) UpsertJobs(ctx context.Context, jobs []*model.ScrapedJob) ([]UpsertResult, error) {
+	if dryRunFrom(ctx) != nil {
+		return upsertEach(ctx, r.UpsertJob, jobs)
+	}
+	results := make([]UpsertResult, len(jobs))
+	for start := 0; start < len(jobs); start += upsertBatchSize {
+		end := min(start+upsertBatchSize, len(jobs))
+		if err := r.upsertBatch(ctx, jobs[start:end], results[start:end]); err != nil {
+			return nil, fmt.Errorf("upsert jobs: %w", err)
+		}
+	}
+	return results, nil
+}
+
+// upsertBatch stores jobs into out. Postings merged into another job by
+// fuzzy deduplication are stored one at a time; the others are inserted
+// or refreshed by a single statement.
+func (r *PostgresRepository) upsertBatch(ctx context.Context, jobs []*model.ScrapedJob, out []UpsertResult) error {
+	hashes := make([]string, len(jobs))
+	for i, scraped := range jobs {
+		hashes[i] = ComputeDedupHash(scraped)
+	}
+
+	pending := make([]int, 0, len(jobs))
+	if r.dedupThreshold > 0 {
+		existing, err := storedHashes(ctx, r.db, hashes)
+		if err != nil {
+			return err
+		}
+		for i, scraped := range jobs {
+			if existing[hashes[i]] {
+				pending = append(pending, i)
+				continue
+			}
+			match, err := matchNewPosting(ctx, r.db, scraped, hashes[i], r.dedupThreshold)
+			if err != nil {
+				return err
+			}
+			if match == nil {
+				pending = append(pending, i)
+				continue
+			}
+			job, err := r.mergeDuplicate(ctx, match, scraped, hashes[i])
+			if err != nil {
+				return err
+			}
+			out[i] = UpsertResult{Job: job}
+		}
+	} else {
+		for i := range jobs {
+			pending = append(pending, i)
+		}
+	}
+	if len(pending) == 0 {
+		return nil
+	}
+
+	// ON CONFLICT cannot touch a row twice in one statement, so a repeated
+	// posting is sent once.
+	last := make(map[string]int, len(pending))
+	var rows []int
+	for _, i := range pending {
+		if _, seen := last[hashes[i]]; !seen {
+			rows = append(rows, i)
+		}
+		last[hashes[i]] = i
+	}
+	for n, i := range rows {
+		rows[n] = last[hashes[i]]
+	}
+
+	companies, err := resolveJobCompanies(ctx, r.db, jobs, rows, time.Now())
+	if err != nil {
+		return err
+	}
+	stored, err := r.insertJobs(ctx, jobs, hashes, companies, rows)
+	if err != nil {
+		return err
+	}
+
+	var ids, notes []string
+	first := make(map[string]bool, len(stored))
+	for _, i := range pending {
+		res, ok := stored[hashes[i]]
+		if !ok {
+			return fmt.Errorf("job %s was not returned", hashes[i])
+		}
+		if !res.Inserted {
+			res.Changes = jobChanges(res.Job, jobs[last[hashes[i]]])
+		}
+		if !first[hashes[i]] {
+			first[hashes[i]] = true
+			if len(res.Changes) > 0 {
+				note, _ := json.Marshal(res.Changes)
+				ids, notes = append(ids, res.Job.ID.String()), append(notes, string(note))
+			}
+		} else {
+			res.Inserted = false
+		}
+		out[i] = res
+	}
+
+	if len(ids) > 0 {
+		if _, err := r.db.ExecContext(ctx, `
+			UPDATE jobs SET
+				changed_at     = CASE WHEN jobs.changed_fields IS DISTINCT FROM c.note THEN NOW() ELSE jobs.changed_at END,
+				changed_fields = c.note
+			FROM unnest($1::uuid[], $2::jsonb[]) AS c(id, note)
+			WHERE jobs.id = c.id`,
+			pq.Array(ids), pq.Array(notes),
+		); err != nil {
+			return fmt.Errorf("record changed fields: %w", err)
+		}
+	}
+	return nil
+}
+
+// insertJobs inserts or refreshes jobs[i], of company companies[i], for
+// each i in rows, whose dedup hashes must be distinct, and returns the
+// stored jobs by dedup hash.
+//
+// A refresh updates only what legitimately changes between scrapes: the
+// description, skills, salary, expiry, company and last-seen time. The title,
+// location and created_at are kept, and posted_at only moves earlier.
+func (r *PostgresRepository) insertJobs(ctx context.Context, jobs []*model.ScrapedJob, hashes []string, companies []*uuid.UUID, rows []int) (map[string]UpsertResult, error) {
+	const perRow = 30
+	var values strings.Builder
+	args := make([]any, 0, len(rows)*perRow)
+	for n, i := range rows {
+		if n > 0 {
+			values.WriteString(",\n")
+		}
+		p := len(args)
+		values.WriteString("(")
+		for k := 1; k <= 26; k++ {
+			fmt.Fprintf(&values, "$%d, ", p+k)
+		}
+		// source_urls starts as the application URL.
+		fmt.Fprintf(&values, "ARRAY[$%d]::text[], $%d, $%d, $%d, $%d)", p+22, p+27, p+28, p+29, p+30)
+		args = append(args, upsertJobArgs(jobs[i], hashes[i], companies[i])...)
+	}
+
+	q, err := r.db.QueryContext(ctx, `
+		INSERT INTO jobs (
+			dedup_hash, source, external_id, company_name, title,
+			description, description_html, industry,
+			location_city, location_state, location_country, location_raw,
+			location_type, employment_type, experience_level,
+			required_skills, preferred_skills,
+			salary_min, salary_max, salary_currency, salary_raw,
+			application_url, company_url, posted_at, expires_at, raw_data,
+			source_urls, nice_to_have_skills, salary_period, last_seen_by, company_id
+		) VALUES `+values.String()+`
+		ON CONFLICT (dedup_hash) DO UPDATE SET
+			last_seen_at     = NOW(),
+			last_seen_by     = COALESCE(EXCLUDED.last_seen_by, jobs.last_seen_by),
+			company_id       = COALESCE(EXCLUDED.company_id, jobs.company_id),
+			status           = 'active',
+			expiry_reason    = NULL,
+			expired_at       = NULL,
+			description      = EXCLUDED.description,
+			description_html = EXCLUDED.description_html,
+			required_skills  = EXCLUDED.required_skills,
+			preferred_skills = EXCLUDED.preferred_skills,
+			nice_to_have_skills = EXCLUDED.nice_to_have_skills,
+			salary_min       = EXCLUDED.salary_min,
+			salary_max       = EXCLUDED.salary_max,
+			salary_currency  = EXCLUDED.salary_currency,
+			salary_raw       = EXCLUDED.salary_raw,
+			salary_period    = EXCLUDED.salary_period,
+			posted_at        = CASE WHEN jobs.posted_at IS NULL OR EXCLUDED.posted_at < jobs.posted_at
+			                        THEN EXCLUDED.posted_at ELSE jobs.posted_at END,
+			expires_at       = EXCLUDED.expires_at,
+			raw_data         = EXCLUDED.raw_data,
+			updated_at       = NOW()
+		RETURNING id, dedup_hash, source, external_id, company_id, company_name, title,
+		          description, location_city, location_state, location_country,
+		          location_raw, location_type, employment_type, experience_level,
+		          required_skills, preferred_skills, nice_to_have_skills, salary_min, salary_max,
+		          salary_currency, salary_raw, COALESCE(salary_period::text, ''),
+		          application_url, company_url, posted_at, expires_at, scraped_at, last_seen_at, status,
+		          is_featured, created_at, updated_at,
+		          (xmax = 0) AS is_new`,
+		args...,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer q.Close()
+
+	stored := make(map[string]UpsertResult, len(rows))
+	for q.Next() {
+		job := &model.Job{}
+		var isNew bool
+		if err := q.Scan(
+			&job.ID, &job.DedupHash, &job.Source, &job.ExternalID, &job.CompanyID,
+			&job.CompanyName, &job.Title, &job.Description,
+			&job.LocationCity, &job.LocationState, &job.LocationCountry,
+			&job.LocationRaw, &job.LocationType, &job.EmploymentType, &job.ExperienceLevel,
+			&job.RequiredSkills, &job.PreferredSkills, &job.NiceToHaveSkills,
+			&job.SalaryMin, &job.SalaryMax, &job.SalaryCurrency, &job.SalaryRaw, &job.SalaryPeriod,
+			&job.ApplicationURL, &job.CompanyURL,
+			&job.PostedAt, &job.ExpiresAt, &job.ScrapedAt, &job.LastSeenAt,
+			&job.Status, &job.IsFeatured, &job.CreatedAt, &job.UpdatedAt,
+			&isNew,
+		); err != nil {
+			return nil, err
+		}
+		stored[job.DedupHash] = UpsertResult{Job: job, Inserted: isNew}
+	}
+	return stored, q.Err()
+}
+
+// upsertJobArgs returns the values of the 30 parameters of a jobs row in
+// insertJobs.
+func upsertJobArgs(scraped *model.ScrapedJob, hash string, companyID *uuid.UUID) []any {
+	rawData, _ := json.Marshal(scraped.RawData)
+	return []any{
+		hash, scraped.Source, nullString(scraped.ExternalID), scraped.CompanyName, scraped.Title,
+		nullString(scraped.Description), nullString(scraped.DescriptionHTML), nullString(scraped.Industry),
+		nullString(scraped.LocationCity), nullString(scraped.LocationState),
+		nullString(scraped.LocationCountry), nullString(scraped.LocationRaw),
+		scraped.LocationType, scraped.EmploymentType, scraped.ExperienceLevel,
+		pq.Array(scraped.RequiredSkills), pq.Array(scraped.PreferredSkills),
+		scraped.SalaryMin, scraped.SalaryMax, nullString(scraped.SalaryCurrency), nullString(scraped.SalaryRaw),
+		scraped.ApplicationURL, nullString(scraped.CompanyURL),
+		scraped.PostedAt, scraped.ExpiresAt, rawData,
+		pq.Array(scraped.NiceToHaveSkills), nullString(string(scraped.SalaryPeriod)),
+		nullString(scraped.ScraperName), companyID,
+	}
+}
+
+// storedHashes returns which of hashes are the dedup hash of a stored job.
+func storedHashes(ctx context.Context, db *sql.DB, hashes []string) (map[string]bool, error) {
+	rows, err := db.QueryContext(ctx, `SELECT dedup_hash FROM jobs WHERE dedup_hash = ANY($1)`, pq.Array(hashes))
+	if err != nil {
+		return nil, fmt.Errorf("check dedup hashes: %w", err)
+	}
+	defer rows.Close()
+	stored := map[string]bool{}
+	for rows.Next() {
+		var h string
+		if err := rows.Scan(&h); err != nil {
+			return nil, fmt.Errorf("check dedup hashes: %w", err)
+		}
+		stored[h] = true
+	}
+	return stored, rows.Err()
+}
+
+// UpsertJobs stores scraped jobs as UpsertJob does, one at a time: SQLite
+// is an in-process database, so a batch saves no round trips. The results
+// are in the order of jobs.
+func (r *SQLiteRepository) UpsertJobs(ctx context.Context, jobs []*model.ScrapedJob) ([]UpsertResult, error) {
+	if dryRunFrom(ctx) != nil {
+		return upsertEach(ctx, r.UpsertJob, jobs)
+	}
+	results := make([]UpsertResult, len(jobs))
+	for i, scraped := range jobs {
+		res, err := r.upsertJob(ctx, scraped)
+		if err != nil {
+			return nil, fmt.Errorf("upsert jobs: %w", err)
+		}
+		results[i] = res
+	}
+	return results, nil
+}
+
+// upsertEach implements UpsertJobs with upsert, one job at a time.
+func upsertEach(ctx context.Context, upsert func(context.Context, *model.ScrapedJob) (*model.Job, bool, error), jobs []*model.ScrapedJob) ([]UpsertResult, error) {
+	results := make([]UpsertResult, len(jobs))
+	for i, scraped := range jobs {
+		job, isNew, err := upsert(ctx, scraped)
+		if err != nil {
+			return nil, fmt.Errorf("upsert jobs: %w", err)
+		}
+		results[i] = UpsertResult{Job: job, Inserted: isNew}
+	}
+	return results, nil
+}
+
+// jobChanges returns the material differences of a re-scraped posting's
+// title and location from the stored job: titles that still differ once
+// normalised as for fuzzy deduplication, and a different city, state or
+// country. A posting without a location does not change it.
+func jobChanges(stored *model.Job, scraped *model.ScrapedJob) map[string]FieldChange {
+	var changes map[string]FieldChange
+	add := func(field, before, after string) {
+		if changes == nil {
+			changes = map[string]FieldChange{}
+		}
+		changes[field] = FieldChange{Old: before, New: after}
+	}
+	if normalizeTitle(stored.Title) != normalizeTitle(scraped.Title) {
+		add("title", stored.Title, scraped.Title)
+	}
+	before := joinLocation(stored.LocationCity.String, stored.LocationState.String, stored.LocationCountry.String)
+	after := joinLocation(scraped.LocationCity, scraped.LocationState, scraped.LocationCountry)
+	if after != "" && !strings.EqualFold(before, after) {
+		add("location", before, after)
+	}
+	return changes
+}
+
+// joinLocation joins the non-empty parts of a location with commas.
+func joinLocation(parts ...string) string {
+	var kept []string
+	for _, p := range parts {
+		if p = strings.TrimSpace(p); p != "" {
+			kept = append(kept, p)
+		}
+	}
+	return strings.Join(kept, ", ")
+}
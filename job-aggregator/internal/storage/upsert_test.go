@@ -0,0 +1,90 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"testing"
+
+	"github.com/learnbot/job-aggregator/internal/model"
+)
+
+func TestJobChanges(t *testing.T) {
+	stored := &model.Job{
+		Title:           "Senior Backend Engineer",
+		LocationCity:    sql.NullString{String: "Berlin", Valid: true},
+		LocationCountry: sql.NullString{String: "DE", Valid: true},
+	}
+	tests := []struct {
+		name           string
+		title          string
+		city, country  string
+		wantTitle      bool
+		wantLocation   bool
+		wantLocationTo string
+	}{
+		{name: "unchanged", title: "Senior Backend Engineer", city: "Berlin", country: "DE"},
+		{name: "cosmetic title", title: "Sr. Backend Engineer (Remote)", city: "berlin", country: "de"},
+		{name: "new title", title: "Frontend Engineer", city: "Berlin", country: "DE", wantTitle: true},
+		{name: "new city", title: "Senior Backend Engineer", city: "Munich", country: "DE",
+			wantLocation: true, wantLocationTo: "Munich, DE"},
+		{name: "location missing", title: "Senior Backend Engineer"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			changes := jobChanges(stored, &model.ScrapedJob{
+				Title: tt.title, LocationCity: tt.city, LocationCountry: tt.country,
+			})
+			if _, ok := changes["title"]; ok != tt.wantTitle {
+				t.Errorf("title change = %v, want %v", changes["title"], tt.wantTitle)
+			}
+			loc, ok := changes["location"]
+			if ok != tt.wantLocation || (ok && (loc.Old != "Berlin, DE" || loc.New != tt.wantLocationTo)) {
+				t.Errorf("location change = %+v, want %v to %q", loc, tt.wantLocation, tt.wantLocationTo)
+			}
+		})
+	}
+}
+
+// BenchmarkUpsertJobs compares storing a scrape of 100 postings, half of
+// them already stored, one UpsertJob at a time and with one UpsertJobs.
+func BenchmarkUpsertJobs(b *testing.B) {
+	const perScrape = 100
+	ctx := context.Background()
+	for _, backend := range conformanceBackends() {
+		b.Run(backend.name, func(b *testing.B) {
+			for _, mode := range []string{"row-at-a-time", "batched"} {
+				b.Run(mode, func(b *testing.B) {
+					repo, db := backend.open(b)
+					company := testCompany(b, db)
+					scrape := func(n int) []*model.ScrapedJob {
+						jobs := make([]*model.ScrapedJob, perScrape)
+						for i := range jobs {
+							// Each scrape sees the second half of the previous one again.
+							id := fmt.Sprintf("%s-%d", mode, n*perScrape/2+i)
+							jobs[i] = testJob(company, id, "Engineer "+id)
+						}
+						return jobs
+					}
+					b.ResetTimer()
+					for n := 0; n < b.N; n++ {
+						b.StopTimer()
+						jobs := scrape(n)
+						b.StartTimer()
+						if mode == "batched" {
+							if _, err := repo.UpsertJobs(ctx, jobs); err != nil {
+								b.Fatalf("UpsertJobs: %v", err)
+							}
+							continue
+						}
+						for _, job := range jobs {
+							if _, _, err := repo.UpsertJob(ctx, job); err != nil {
+								b.Fatalf("UpsertJob: %v", err)
+							}
+						}
+					}
+				})
+			}
+		})
+	}
+}
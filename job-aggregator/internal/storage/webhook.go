@@ -0,0 +1,226 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+
+	"github.com/learnbot/job-aggregator/internal/model"
+)
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Webhook subscriptions
+// ─────────────────────────────────────────────────────────────────────────────
+
+const webhookSubscriptionColumns = `id, name, url, secret, skills, keywords,
+		       COALESCE(location, ''), remote_only, is_active, created_at, updated_at`
+
+func scanWebhookSubscription(row interface{ Scan(...interface{}) error }, s *model.WebhookSubscription) error {
+	return row.Scan(
+		&s.ID, &s.Name, &s.URL, &s.Secret, &s.Skills, &s.Keywords,
+		&s.Location, &s.RemoteOnly, &s.IsActive, &s.CreatedAt, &s.UpdatedAt,
+	)
+}
+
+// CreateWebhookSubscription stores a new, active subscription and fills in
+// its generated fields.
+func (r *PostgresRepository) CreateWebhookSubscription(ctx context.Context, sub *model.WebhookSubscription) error {
+	if sub.Skills == nil {
+		sub.Skills = pq.StringArray{}
+	}
+	if sub.Keywords == nil {
+		sub.Keywords = pq.StringArray{}
+	}
+	err := r.db.QueryRowContext(ctx, `
+		INSERT INTO webhook_subscriptions (name, url, secret, skills, keywords, location, remote_only)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING id, is_active, created_at, updated_at`,
+		sub.Name, sub.URL, sub.Secret, sub.Skills, sub.Keywords,
+		nullString(sub.Location), sub.RemoteOnly,
+	).Scan(&sub.ID, &sub.IsActive, &sub.CreatedAt, &sub.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("create webhook subscription: %w", err)
+	}
+	return nil
+}
+
+// ListWebhookSubscriptions returns all subscriptions, oldest first.
+func (r *PostgresRepository) ListWebhookSubscriptions(ctx context.Context) ([]model.WebhookSubscription, error) {
+	return r.queryWebhookSubscriptions(ctx, "TRUE")
+}
+
+// ListActiveWebhookSubscriptions returns the subscriptions new jobs are
+// matched against.
+func (r *PostgresRepository) ListActiveWebhookSubscriptions(ctx context.Context) ([]model.WebhookSubscription, error) {
+	return r.queryWebhookSubscriptions(ctx, "is_active")
+}
+
+func (r *PostgresRepository) queryWebhookSubscriptions(ctx context.Context, where string) ([]model.WebhookSubscription, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT `+webhookSubscriptionColumns+`
+		FROM webhook_subscriptions
+		WHERE `+where+`
+		ORDER BY created_at ASC`)
+	if err != nil {
+		return nil, fmt.Errorf("list webhook subscriptions: %w", err)
+	}
+	defer rows.Close()
+
+	var subs []model.WebhookSubscription
+	for rows.Next() {
+		var s model.WebhookSubscription
+		if err := scanWebhookSubscription(rows, &s); err != nil {
+			return nil, fmt.Errorf("scan webhook subscription: %w", err)
+		}
+		subs = append(subs, s)
+	}
+	return subs, rows.Err()
+}
+
+// DeleteWebhookSubscription removes a subscription and its deliveries. It
+// returns ErrNotFound if there is no such subscription.
+func (r *PostgresRepository) DeleteWebhookSubscription(ctx context.Context, id uuid.UUID) error {
+	res, err := r.db.ExecContext(ctx, `DELETE FROM webhook_subscriptions WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("delete webhook subscription: %w", err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("delete webhook subscription: %w", err)
+	}
+	if n == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Webhook deliveries
+// ─────────────────────────────────────────────────────────────────────────────
+
+const webhookDeliveryColumns = `d.id, d.subscription_id, d.job_id, d.status, d.payload, d.attempts,
+		       d.last_status_code, COALESCE(d.last_error, ''), d.next_attempt_at,
+		       d.delivered_at, d.created_at, d.updated_at`
+
+func collectWebhookDeliveries(rows *sql.Rows) ([]model.WebhookDelivery, error) {
+	defer rows.Close()
+	var deliveries []model.WebhookDelivery
+	for rows.Next() {
+		var d model.WebhookDelivery
+		if err := rows.Scan(
+			&d.ID, &d.SubscriptionID, &d.JobID, &d.Status, &d.Payload, &d.Attempts,
+			&d.LastStatusCode, &d.LastError, &d.NextAttemptAt,
+			&d.DeliveredAt, &d.CreatedAt, &d.UpdatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("scan webhook delivery: %w", err)
+		}
+		deliveries = append(deliveries, d)
+	}
+	return deliveries, rows.Err()
+}
+
+// QueueWebhookDeliveries inserts pending deliveries, skipping any job that
+// was already queued for the same subscription. It returns how many were
+// queued.
+func (r *PostgresRepository) QueueWebhookDeliveries(ctx context.Context, deliveries []model.WebhookDelivery) (int, error) {
+	if len(deliveries) == 0 {
+		return 0, nil
+	}
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("queue webhook deliveries: %w", err)
+	}
+	defer tx.Rollback()
+
+	queued := 0
+	for _, d := range deliveries {
+		res, err := tx.ExecContext(ctx, `
+			INSERT INTO webhook_deliveries (subscription_id, job_id, payload)
+			VALUES ($1, $2, $3)
+			ON CONFLICT (subscription_id, job_id) DO NOTHING`,
+			d.SubscriptionID, d.JobID, d.Payload,
+		)
+		if err != nil {
+			return 0, fmt.Errorf("queue webhook delivery: %w", err)
+		}
+		if n, err := res.RowsAffected(); err == nil {
+			queued += int(n)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("queue webhook deliveries: %w", err)
+	}
+	return queued, nil
+}
+
+// ListDueWebhookDeliveries returns up to limit pending deliveries of active
+// subscriptions whose next attempt is due at now, most overdue first.
+func (r *PostgresRepository) ListDueWebhookDeliveries(ctx context.Context, now time.Time, limit int) ([]model.WebhookDelivery, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT `+webhookDeliveryColumns+`
+		FROM webhook_deliveries d
+		JOIN webhook_subscriptions s ON s.id = d.subscription_id
+		WHERE d.status = 'pending' AND d.next_attempt_at <= $1 AND s.is_active
+		ORDER BY d.next_attempt_at ASC
+		LIMIT $2`, now, limit)
+	if err != nil {
+		return nil, fmt.Errorf("list due webhook deliveries: %w", err)
+	}
+	return collectWebhookDeliveries(rows)
+}
+
+// RecordWebhookAttempt counts one attempt of a delivery and stores its
+// outcome.
+func (r *PostgresRepository) RecordWebhookAttempt(ctx context.Context, id uuid.UUID, attempt model.WebhookAttempt) error {
+	_, err := r.db.ExecContext(ctx, `
+		UPDATE webhook_deliveries
+		SET status = $2, attempts = attempts + 1,
+		    last_status_code = NULLIF($3, 0), last_error = NULLIF($4, ''),
+		    next_attempt_at = $5,
+		    delivered_at = CASE WHEN $2 = 'delivered' THEN NOW() END
+		WHERE id = $1`,
+		id, attempt.Status, attempt.StatusCode, attempt.Error, attempt.NextAttemptAt,
+	)
+	if err != nil {
+		return fmt.Errorf("record webhook attempt: %w", err)
+	}
+	return nil
+}
+
+// ListWebhookDeliveries returns the most recent deliveries matching filter,
+// newest first.
+func (r *PostgresRepository) ListWebhookDeliveries(ctx context.Context, filter model.WebhookDeliveryFilter) ([]model.WebhookDelivery, error) {
+	if filter.Limit <= 0 {
+		filter.Limit = 50
+	}
+	if filter.Limit > 500 {
+		filter.Limit = 500
+	}
+
+	where := "TRUE"
+	var args []interface{}
+	if filter.SubscriptionID != nil {
+		args = append(args, *filter.SubscriptionID)
+		where += fmt.Sprintf(" AND d.subscription_id = $%d", len(args))
+	}
+	if filter.Status != "" {
+		args = append(args, filter.Status)
+		where += fmt.Sprintf(" AND d.status = $%d", len(args))
+	}
+	args = append(args, filter.Limit)
+
+	rows, err := r.db.QueryContext(ctx, fmt.Sprintf(`
+		SELECT %s
+		FROM webhook_deliveries d
+		WHERE %s
+		ORDER BY d.created_at DESC
+		LIMIT $%d`, webhookDeliveryColumns, where, len(args)), args...)
+	if err != nil {
+		return nil, fmt.Errorf("list webhook deliveries: %w", err)
+	}
+	return collectWebhookDeliveries(rows)
+}
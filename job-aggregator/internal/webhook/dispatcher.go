@@ -0,0 +1,408 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/learnbot/job-aggregator/internal/model"
+	"github.com/learnbot/shared/metrics"
+)
+
+var deliveryAttempts = metrics.NewCounter("webhook_delivery_attempts_total",
+	"Webhook delivery attempts, by outcome (delivered, retry or dead_letter).", "outcome")
+
+// EventJobCreated is the event sent for a newly scraped job.
+const EventJobCreated = "job.created"
+
+// Store is the persistence used by the Dispatcher. It is implemented by
+// *storage.PostgresRepository.
+type Store interface {
+	ListActiveWebhookSubscriptions(ctx context.Context) ([]model.WebhookSubscription, error)
+	QueueWebhookDeliveries(ctx context.Context, deliveries []model.WebhookDelivery) (int, error)
+	ListDueWebhookDeliveries(ctx context.Context, now time.Time, limit int) ([]model.WebhookDelivery, error)
+	RecordWebhookAttempt(ctx context.Context, id uuid.UUID, attempt model.WebhookAttempt) error
+}
+
+// Config holds webhook delivery configuration.
+type Config struct {
+	// How often due deliveries are polled for; new deliveries are also
+	// sent as soon as they are queued
+	PollInterval time.Duration
+	// Maximum number of deliveries sent per batch
+	BatchSize int
+	// Number of concurrent requests
+	Workers int
+	// Failed attempts after which a delivery is dead-lettered
+	MaxAttempts int
+	// Delay before the first retry, doubled for each further retry
+	RetryDelay    time.Duration
+	RetryMaxDelay time.Duration
+	// HTTP settings
+	RequestTimeout time.Duration
+	UserAgent      string
+}
+
+// DefaultConfig returns sensible defaults.
+func DefaultConfig() Config {
+	return Config{
+		PollInterval:   30 * time.Second,
+		BatchSize:      100,
+		Workers:        4,
+		MaxAttempts:    5,
+		RetryDelay:     time.Minute,
+		RetryMaxDelay:  time.Hour,
+		RequestTimeout: 10 * time.Second,
+		UserAgent:      "LearnBot-JobAggregator/1.0 (https://learnbot.io; jobs@learnbot.io)",
+	}
+}
+
+// DeliveryStats summarises one batch.
+type DeliveryStats struct {
+	Attempted    int
+	Delivered    int
+	Retrying     int
+	DeadLettered int
+}
+
+// Payload is the JSON body of a delivery.
+type Payload struct {
+	Event          string    `json:"event"`
+	SubscriptionID uuid.UUID `json:"subscription_id"`
+	// Text summarises the job in one line, so that Slack incoming webhooks
+	// (and Discord's Slack-compatible endpoint) can display it as-is.
+	Text      string     `json:"text"`
+	Job       JobSummary `json:"job"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// JobSummary is the part of a job included in a Payload.
+type JobSummary struct {
+	ID              uuid.UUID              `json:"id"`
+	Source          model.JobSource        `json:"source"`
+	Title           string                 `json:"title"`
+	CompanyName     string                 `json:"company_name"`
+	Location        string                 `json:"location,omitempty"`
+	LocationType    model.WorkLocationType `json:"location_type"`
+	EmploymentType  model.EmploymentType   `json:"employment_type"`
+	ExperienceLevel model.ExperienceLevel  `json:"experience_level"`
+	Skills          []string               `json:"skills,omitempty"`
+	SalaryMin       *int32                 `json:"salary_min,omitempty"`
+	SalaryMax       *int32                 `json:"salary_max,omitempty"`
+	SalaryCurrency  string                 `json:"salary_currency,omitempty"`
+	SalaryPeriod    model.SalaryPeriod     `json:"salary_period,omitempty"`
+	ApplicationURL  string                 `json:"application_url"`
+	PostedAt        *time.Time             `json:"posted_at,omitempty"`
+}
+
+// Dispatcher matches new jobs against subscriptions and delivers them.
+type Dispatcher struct {
+	store  Store
+	client *http.Client
+	config Config
+	logger *log.Logger
+	now    func() time.Time
+	// wake starts a batch as soon as NotifyNewJobs queues deliveries.
+	wake chan struct{}
+}
+
+// New creates a new Dispatcher. Zero config values fall back to
+// DefaultConfig.
+func New(store Store, cfg Config, logger *log.Logger) *Dispatcher {
+	def := DefaultConfig()
+	if cfg.PollInterval <= 0 {
+		cfg.PollInterval = def.PollInterval
+	}
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = def.BatchSize
+	}
+	if cfg.Workers <= 0 {
+		cfg.Workers = def.Workers
+	}
+	if cfg.MaxAttempts <= 0 {
+		cfg.MaxAttempts = def.MaxAttempts
+	}
+	if cfg.RetryDelay <= 0 {
+		cfg.RetryDelay = def.RetryDelay
+	}
+	if cfg.RetryMaxDelay < cfg.RetryDelay {
+		cfg.RetryMaxDelay = cfg.RetryDelay
+	}
+	if cfg.RequestTimeout <= 0 {
+		cfg.RequestTimeout = def.RequestTimeout
+	}
+	if cfg.UserAgent == "" {
+		cfg.UserAgent = def.UserAgent
+	}
+	return &Dispatcher{
+		store:  store,
+		client: &http.Client{Timeout: cfg.RequestTimeout},
+		config: cfg,
+		logger: logger,
+		now:    time.Now,
+		wake:   make(chan struct{}, 1),
+	}
+}
+
+// Start runs DeliverDue every PollInterval, and whenever deliveries are
+// queued, until ctx is cancelled.
+func (d *Dispatcher) Start(ctx context.Context) {
+	go func() {
+		d.logger.Printf("[webhook] dispatcher started (every %v)", d.config.PollInterval)
+		ticker := time.NewTicker(d.config.PollInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				d.logger.Println("[webhook] dispatcher stopped")
+				return
+			case <-ticker.C:
+			case <-d.wake:
+			}
+			stats, err := d.DeliverDue(ctx)
+			if err != nil {
+				d.logger.Printf("[webhook] batch error: %v", err)
+				continue
+			}
+			if stats.Attempted > 0 {
+				d.logger.Printf("[webhook] sent %d deliveries: %d delivered, %d retrying, %d dead-lettered",
+					stats.Attempted, stats.Delivered, stats.Retrying, stats.DeadLettered)
+			}
+		}
+	}()
+}
+
+// NotifyNewJobs queues one delivery per active subscription that each job
+// matches. It is called by the scheduler after a scrape run with the jobs
+// the run stored for the first time.
+func (d *Dispatcher) NotifyNewJobs(ctx context.Context, jobs []model.Job) error {
+	if len(jobs) == 0 {
+		return nil
+	}
+	subs, err := d.store.ListActiveWebhookSubscriptions(ctx)
+	if err != nil {
+		return fmt.Errorf("list webhook subscriptions: %w", err)
+	}
+
+	now := d.now().UTC()
+	var deliveries []model.WebhookDelivery
+	for _, sub := range subs {
+		for _, job := range jobs {
+			if !Matches(sub, job) {
+				continue
+			}
+			body, err := json.Marshal(newPayload(sub, job, now))
+			if err != nil {
+				return fmt.Errorf("encode webhook payload: %w", err)
+			}
+			deliveries = append(deliveries, model.WebhookDelivery{
+				SubscriptionID: sub.ID,
+				JobID:          job.ID,
+				Payload:        body,
+			})
+		}
+	}
+
+	queued, err := d.store.QueueWebhookDeliveries(ctx, deliveries)
+	if err != nil {
+		return fmt.Errorf("queue webhook deliveries: %w", err)
+	}
+	if queued > 0 {
+		d.logger.Printf("[webhook] queued %d deliveries for %d new jobs", queued, len(jobs))
+		select {
+		case d.wake <- struct{}{}:
+		default:
+		}
+	}
+	return nil
+}
+
+// DeliverDue sends the next batch of due deliveries.
+func (d *Dispatcher) DeliverDue(ctx context.Context) (DeliveryStats, error) {
+	due, err := d.store.ListDueWebhookDeliveries(ctx, d.now(), d.config.BatchSize)
+	if err != nil {
+		return DeliveryStats{}, fmt.Errorf("list due webhook deliveries: %w", err)
+	}
+	if len(due) == 0 {
+		return DeliveryStats{}, nil
+	}
+	subs, err := d.store.ListActiveWebhookSubscriptions(ctx)
+	if err != nil {
+		return DeliveryStats{}, fmt.Errorf("list webhook subscriptions: %w", err)
+	}
+	byID := make(map[uuid.UUID]model.WebhookSubscription, len(subs))
+	for _, sub := range subs {
+		byID[sub.ID] = sub
+	}
+
+	var (
+		stats DeliveryStats
+		mu    sync.Mutex
+		wg    sync.WaitGroup
+	)
+	work := make(chan model.WebhookDelivery)
+	for i := 0; i < d.config.Workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for delivery := range work {
+				sub, ok := byID[delivery.SubscriptionID]
+				if !ok {
+					continue // deactivated since the delivery was listed
+				}
+				status := d.deliver(ctx, sub, delivery)
+				mu.Lock()
+				stats.Attempted++
+				switch status {
+				case model.WebhookDeliveryDelivered:
+					stats.Delivered++
+				case model.WebhookDeliveryPending:
+					stats.Retrying++
+				case model.WebhookDeliveryDeadLetter:
+					stats.DeadLettered++
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+
+	for _, delivery := range due {
+		select {
+		case work <- delivery:
+		case <-ctx.Done():
+		}
+		if ctx.Err() != nil {
+			break
+		}
+	}
+	close(work)
+	wg.Wait()
+	return stats, ctx.Err()
+}
+
+// deliver makes one attempt at delivery and records the outcome, returning
+// the delivery's new status.
+func (d *Dispatcher) deliver(ctx context.Context, sub model.WebhookSubscription, delivery model.WebhookDelivery) model.WebhookDeliveryStatus {
+	code, err := d.post(ctx, sub, delivery)
+	now := d.now()
+	attempt := model.WebhookAttempt{
+		Status:        model.WebhookDeliveryDelivered,
+		StatusCode:    code,
+		NextAttemptAt: now,
+	}
+	outcome := "delivered"
+	if err != nil {
+		attempt.Error = err.Error()
+		attempts := delivery.Attempts + 1
+		if attempts >= d.config.MaxAttempts {
+			attempt.Status = model.WebhookDeliveryDeadLetter
+			outcome = "dead_letter"
+			d.logger.Printf("[webhook] delivery %s to %s dead-lettered after %d attempts: %v",
+				delivery.ID, sub.Name, attempts, err)
+		} else {
+			attempt.Status = model.WebhookDeliveryPending
+			attempt.NextAttemptAt = now.Add(d.retryDelay(attempts))
+			outcome = "retry"
+			d.logger.Printf("[webhook] delivery %s to %s failed (attempt %d/%d, next at %v): %v",
+				delivery.ID, sub.Name, attempts, d.config.MaxAttempts, attempt.NextAttemptAt, err)
+		}
+	}
+	deliveryAttempts.Inc(outcome)
+
+	// Record the attempt even if shutdown cancelled the request, so that it
+	// counts towards MaxAttempts.
+	if err := d.store.RecordWebhookAttempt(context.WithoutCancel(ctx), delivery.ID, attempt); err != nil {
+		d.logger.Printf("[webhook] record attempt for delivery %s: %v", delivery.ID, err)
+	}
+	return attempt.Status
+}
+
+// post sends the delivery's payload to the subscription URL. Any response
+// other than 2xx is an error; the returned status code is 0 when the
+// endpoint did not answer.
+func (d *Dispatcher) post(ctx context.Context, sub model.WebhookSubscription, delivery model.WebhookDelivery) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, sub.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return 0, fmt.Errorf("create request: %w", err)
+	}
+	timestamp := d.now().Unix()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", d.config.UserAgent)
+	req.Header.Set(EventHeader, EventJobCreated)
+	req.Header.Set(DeliveryHeader, delivery.ID.String())
+	req.Header.Set(TimestampHeader, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(SignatureHeader, Sign(sub.Secret, timestamp, delivery.Payload))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, fmt.Errorf("HTTP %s", resp.Status)
+	}
+	return resp.StatusCode, nil
+}
+
+// retryDelay returns the wait after the given number of failed attempts.
+func (d *Dispatcher) retryDelay(attempts int) time.Duration {
+	delay := float64(d.config.RetryDelay) * math.Pow(2, float64(attempts-1))
+	if maxDelay := float64(d.config.RetryMaxDelay); delay > maxDelay {
+		delay = maxDelay
+	}
+	return time.Duration(delay)
+}
+
+// newPayload builds the delivery body for job.
+func newPayload(sub model.WebhookSubscription, job model.Job, now time.Time) Payload {
+	summary := JobSummary{
+		ID:              job.ID,
+		Source:          job.Source,
+		Title:           job.Title,
+		CompanyName:     job.CompanyName,
+		Location:        job.LocationRaw.String,
+		LocationType:    job.LocationType,
+		EmploymentType:  job.EmploymentType,
+		ExperienceLevel: job.ExperienceLevel,
+		Skills:          job.RequiredSkills,
+		SalaryCurrency:  job.SalaryCurrency,
+		SalaryPeriod:    job.SalaryPeriod,
+		ApplicationURL:  job.ApplicationURL,
+	}
+	if job.SalaryMin.Valid {
+		summary.SalaryMin = &job.SalaryMin.Int32
+	}
+	if job.SalaryMax.Valid {
+		summary.SalaryMax = &job.SalaryMax.Int32
+	}
+	if job.PostedAt.Valid {
+		summary.PostedAt = &job.PostedAt.Time
+	}
+
+	text := fmt.Sprintf("New job: %s at %s", job.Title, job.CompanyName)
+	if summary.Location != "" {
+		text += " (" + summary.Location + ")"
+	}
+	text += " " + job.ApplicationURL
+
+	return Payload{
+		Event:          EventJobCreated,
+		SubscriptionID: sub.ID,
+		Text:           text,
+		Job:            summary,
+		CreatedAt:      now,
+	}
+}
@@ -0,0 +1,284 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+
+	"github.com/learnbot/job-aggregator/internal/model"
+)
+
+// fakeStore keeps subscriptions and deliveries in memory.
+type fakeStore struct {
+	mu         sync.Mutex
+	subs       []model.WebhookSubscription
+	deliveries map[uuid.UUID]*model.WebhookDelivery
+}
+
+func newFakeStore(subs ...model.WebhookSubscription) *fakeStore {
+	return &fakeStore{subs: subs, deliveries: make(map[uuid.UUID]*model.WebhookDelivery)}
+}
+
+func (s *fakeStore) ListActiveWebhookSubscriptions(context.Context) ([]model.WebhookSubscription, error) {
+	return s.subs, nil
+}
+
+func (s *fakeStore) QueueWebhookDeliveries(_ context.Context, deliveries []model.WebhookDelivery) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	queued := 0
+outer:
+	for _, d := range deliveries {
+		for _, existing := range s.deliveries {
+			if existing.SubscriptionID == d.SubscriptionID && existing.JobID == d.JobID {
+				continue outer
+			}
+		}
+		d.ID = uuid.New()
+		d.Status = model.WebhookDeliveryPending
+		s.deliveries[d.ID] = &d
+		queued++
+	}
+	return queued, nil
+}
+
+func (s *fakeStore) ListDueWebhookDeliveries(_ context.Context, now time.Time, limit int) ([]model.WebhookDelivery, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var due []model.WebhookDelivery
+	for _, d := range s.deliveries {
+		if d.Status == model.WebhookDeliveryPending && !d.NextAttemptAt.After(now) && len(due) < limit {
+			due = append(due, *d)
+		}
+	}
+	return due, nil
+}
+
+func (s *fakeStore) RecordWebhookAttempt(_ context.Context, id uuid.UUID, attempt model.WebhookAttempt) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	d := s.deliveries[id]
+	d.Attempts++
+	d.Status = attempt.Status
+	d.NextAttemptAt = attempt.NextAttemptAt
+	d.LastError = attempt.Error
+	return nil
+}
+
+func (s *fakeStore) only(t *testing.T) model.WebhookDelivery {
+	t.Helper()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.deliveries) != 1 {
+		t.Fatalf("expected 1 delivery, got %d", len(s.deliveries))
+	}
+	for _, d := range s.deliveries {
+		return *d
+	}
+	return model.WebhookDelivery{}
+}
+
+func newTestDispatcher(store Store, clock *time.Time) *Dispatcher {
+	cfg := DefaultConfig()
+	cfg.MaxAttempts = 3
+	cfg.RetryDelay = time.Minute
+	d := New(store, cfg, log.New(io.Discard, "", 0))
+	d.now = func() time.Time { return *clock }
+	return d
+}
+
+func testJob() model.Job {
+	return model.Job{
+		ID:             uuid.New(),
+		Title:          "Go Engineer",
+		CompanyName:    "Acme",
+		LocationType:   model.LocationRemote,
+		RequiredSkills: pq.StringArray{"Go"},
+		ApplicationURL: "https://acme.example/jobs/1",
+	}
+}
+
+func TestSign(t *testing.T) {
+	body := []byte(`{"event":"job.created"}`)
+	// Computed independently with:
+	//   printf '1700000000.{"event":"job.created"}' | openssl dgst -sha256 -hmac s3cret
+	want := "sha256=e5d6bc3d65ece57128e5ab952ba3fc12b442684e90948b6e85cc7db315f5327b"
+	got := Sign("s3cret", 1700000000, body)
+	if got != want {
+		t.Errorf("Sign() = %s, want %s", got, want)
+	}
+	if !Verify("s3cret", 1700000000, body, got) {
+		t.Error("Verify rejected a valid signature")
+	}
+	for name, ok := range map[string]bool{
+		"other secret":    Verify("other", 1700000000, body, got),
+		"other timestamp": Verify("s3cret", 1700000001, body, got),
+		"other body":      Verify("s3cret", 1700000000, []byte(`{}`), got),
+		"missing prefix":  Verify("s3cret", 1700000000, body, got[len("sha256="):]),
+	} {
+		if ok {
+			t.Errorf("%s: Verify accepted an invalid signature", name)
+		}
+	}
+}
+
+func TestDispatcher_DeliversSignedPayload(t *testing.T) {
+	const secret = "0123456789abcdef"
+	type received struct {
+		header http.Header
+		body   []byte
+	}
+	got := make(chan received, 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		got <- received{r.Header, body}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	sub := model.WebhookSubscription{ID: uuid.New(), Name: "slack", URL: srv.URL, Secret: secret, Skills: pq.StringArray{"go"}}
+	store := newFakeStore(sub)
+	clock := time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC)
+	d := newTestDispatcher(store, &clock)
+
+	other := testJob()
+	other.RequiredSkills = pq.StringArray{"Java"}
+	if err := d.NotifyNewJobs(context.Background(), []model.Job{testJob(), other}); err != nil {
+		t.Fatalf("NotifyNewJobs: %v", err)
+	}
+	delivery := store.only(t)
+
+	stats, err := d.DeliverDue(context.Background())
+	if err != nil {
+		t.Fatalf("DeliverDue: %v", err)
+	}
+	if stats.Delivered != 1 {
+		t.Errorf("expected 1 delivered, got %+v", stats)
+	}
+	req := <-got
+
+	ts, err := strconv.ParseInt(req.header.Get(TimestampHeader), 10, 64)
+	if err != nil || ts != clock.Unix() {
+		t.Errorf("unexpected %s %q", TimestampHeader, req.header.Get(TimestampHeader))
+	}
+	if !Verify(secret, ts, req.body, req.header.Get(SignatureHeader)) {
+		t.Errorf("signature %q does not verify", req.header.Get(SignatureHeader))
+	}
+	if req.header.Get(DeliveryHeader) != delivery.ID.String() || req.header.Get(EventHeader) != EventJobCreated {
+		t.Errorf("unexpected delivery headers: %v", req.header)
+	}
+
+	var payload Payload
+	if err := json.Unmarshal(req.body, &payload); err != nil {
+		t.Fatalf("decode payload: %v", err)
+	}
+	if payload.Event != EventJobCreated || payload.SubscriptionID != sub.ID || payload.Job.Title != "Go Engineer" {
+		t.Errorf("unexpected payload: %+v", payload)
+	}
+	if payload.Text != "New job: Go Engineer at Acme https://acme.example/jobs/1" {
+		t.Errorf("unexpected text %q", payload.Text)
+	}
+	if store.only(t).Status != model.WebhookDeliveryDelivered {
+		t.Errorf("expected the delivery to be marked delivered")
+	}
+
+	// Notifying the same job again does not queue a second delivery.
+	if err := d.NotifyNewJobs(context.Background(), []model.Job{{ID: delivery.JobID, RequiredSkills: pq.StringArray{"Go"}}}); err != nil {
+		t.Fatalf("NotifyNewJobs: %v", err)
+	}
+	store.only(t)
+}
+
+func TestDispatcher_RetriesThenDeadLetters(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer srv.Close()
+
+	store := newFakeStore(model.WebhookSubscription{ID: uuid.New(), Name: "failing", URL: srv.URL, Secret: "0123456789abcdef"})
+	clock := time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC)
+	d := newTestDispatcher(store, &clock)
+	if err := d.NotifyNewJobs(context.Background(), []model.Job{testJob()}); err != nil {
+		t.Fatalf("NotifyNewJobs: %v", err)
+	}
+
+	// Attempt 1 fails and is retried after RetryDelay.
+	stats, _ := d.DeliverDue(context.Background())
+	delivery := store.only(t)
+	if stats.Retrying != 1 || delivery.Status != model.WebhookDeliveryPending || delivery.Attempts != 1 {
+		t.Fatalf("after attempt 1: stats %+v, delivery %+v", stats, delivery)
+	}
+	if want := clock.Add(time.Minute); !delivery.NextAttemptAt.Equal(want) {
+		t.Errorf("expected the retry at %v, got %v", want, delivery.NextAttemptAt)
+	}
+	if delivery.LastError == "" {
+		t.Error("expected the failure to be recorded")
+	}
+
+	// Not due yet: nothing is sent.
+	if stats, _ := d.DeliverDue(context.Background()); stats.Attempted != 0 {
+		t.Errorf("expected no attempt before the retry is due, got %+v", stats)
+	}
+
+	// Attempt 2 fails; the delay doubles.
+	clock = clock.Add(time.Minute)
+	d.DeliverDue(context.Background())
+	if delivery = store.only(t); !delivery.NextAttemptAt.Equal(clock.Add(2 * time.Minute)) {
+		t.Errorf("expected the second retry after 2m, got %v", delivery.NextAttemptAt.Sub(clock))
+	}
+
+	// Attempt 3 reaches MaxAttempts and dead-letters the delivery.
+	clock = clock.Add(2 * time.Minute)
+	stats, _ = d.DeliverDue(context.Background())
+	if delivery = store.only(t); stats.DeadLettered != 1 || delivery.Status != model.WebhookDeliveryDeadLetter {
+		t.Fatalf("after attempt 3: stats %+v, delivery %+v", stats, delivery)
+	}
+
+	clock = clock.Add(time.Hour)
+	if stats, _ := d.DeliverDue(context.Background()); stats.Attempted != 0 {
+		t.Errorf("expected a dead-lettered delivery not to be retried, got %+v", stats)
+	}
+	if calls.Load() != 3 {
+		t.Errorf("expected 3 requests, got %d", calls.Load())
+	}
+}
+
+func TestDispatcher_RecoversAfterFlakyEndpoint(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	store := newFakeStore(model.WebhookSubscription{ID: uuid.New(), Name: "flaky", URL: srv.URL, Secret: "0123456789abcdef"})
+	clock := time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC)
+	d := newTestDispatcher(store, &clock)
+	if err := d.NotifyNewJobs(context.Background(), []model.Job{testJob()}); err != nil {
+		t.Fatalf("NotifyNewJobs: %v", err)
+	}
+
+	d.DeliverDue(context.Background())
+	clock = clock.Add(time.Minute)
+	stats, _ := d.DeliverDue(context.Background())
+	delivery := store.only(t)
+	if stats.Delivered != 1 || delivery.Status != model.WebhookDeliveryDelivered || delivery.Attempts != 2 {
+		t.Errorf("expected delivery on the second attempt, got stats %+v, delivery %+v", stats, delivery)
+	}
+}
@@ -0,0 +1,75 @@
+// Package webhook pushes newly scraped jobs to subscribers. After each
+// scrape run the new jobs are matched against active subscriptions and
+// queued as deliveries; the Dispatcher POSTs each one as signed JSON,
+// retrying failures with exponential backoff until they are dead-lettered.
+package webhook
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/learnbot/job-aggregator/internal/model"
+)
+
+// Matches reports whether job satisfies every criterion sub sets. Within a
+// criterion any value matches: the job must list one of the skills, contain
+// one of the keywords as whole words in its title or description, and
+// contain the location in any part of its location. RemoteOnly requires a
+// remote job. A subscription without criteria matches every job.
+func Matches(sub model.WebhookSubscription, job model.Job) bool {
+	if sub.RemoteOnly && job.LocationType != model.LocationRemote {
+		return false
+	}
+	if len(sub.Skills) > 0 && !hasAnySkill(job, sub.Skills) {
+		return false
+	}
+	if len(sub.Keywords) > 0 && !hasAnyKeyword(job, sub.Keywords) {
+		return false
+	}
+	if loc := strings.TrimSpace(sub.Location); loc != "" {
+		jobLocation := strings.Join([]string{
+			job.LocationCity.String, job.LocationState.String,
+			job.LocationCountry.String, job.LocationRaw.String,
+		}, " ")
+		if !strings.Contains(strings.ToLower(jobLocation), strings.ToLower(loc)) {
+			return false
+		}
+	}
+	return true
+}
+
+func hasAnySkill(job model.Job, skills []string) bool {
+	for _, list := range [][]string{job.RequiredSkills, job.PreferredSkills, job.NiceToHaveSkills} {
+		for _, have := range list {
+			for _, want := range skills {
+				if strings.EqualFold(strings.TrimSpace(have), strings.TrimSpace(want)) {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+func hasAnyKeyword(job model.Job, keywords []string) bool {
+	text := " " + normalizeWords(job.Title+" "+job.Description.String) + " "
+	for _, kw := range keywords {
+		if kw = normalizeWords(kw); kw != "" && strings.Contains(text, " "+kw+" ") {
+			return true
+		}
+	}
+	return false
+}
+
+// normalizeWords lowercases s and reduces it to single-space separated
+// words, keeping the symbols of names like "c++", "c#" and "node.js" but
+// not trailing punctuation.
+func normalizeWords(s string) string {
+	words := strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && !strings.ContainsRune("+#.", r)
+	})
+	for i, w := range words {
+		words[i] = strings.Trim(w, ".")
+	}
+	return strings.Join(strings.Fields(strings.Join(words, " ")), " ")
+}
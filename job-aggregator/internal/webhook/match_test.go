@@ -0,0 +1,60 @@
+package webhook
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/lib/pq"
+
+	"github.com/learnbot/job-aggregator/internal/model"
+)
+
+func TestMatches(t *testing.T) {
+	job := model.Job{
+		Title:            "Senior Go Engineer",
+		Description:      sql.NullString{String: "Build APIs in Go and C++. Node.js is a plus.", Valid: true},
+		LocationCity:     sql.NullString{String: "Berlin", Valid: true},
+		LocationRaw:      sql.NullString{String: "Berlin, Germany", Valid: true},
+		LocationType:     model.LocationHybrid,
+		RequiredSkills:   pq.StringArray{"Go", "PostgreSQL"},
+		NiceToHaveSkills: pq.StringArray{"Kubernetes"},
+	}
+
+	tests := []struct {
+		name string
+		sub  model.WebhookSubscription
+		want bool
+	}{
+		{"no criteria", model.WebhookSubscription{}, true},
+		{"skill, case-insensitive", model.WebhookSubscription{Skills: pq.StringArray{"postgresql"}}, true},
+		{"nice-to-have skill", model.WebhookSubscription{Skills: pq.StringArray{"Rust", "kubernetes"}}, true},
+		{"missing skill", model.WebhookSubscription{Skills: pq.StringArray{"Rust"}}, false},
+		{"keyword in title", model.WebhookSubscription{Keywords: pq.StringArray{"engineer"}}, true},
+		{"multi-word keyword", model.WebhookSubscription{Keywords: pq.StringArray{"senior go"}}, true},
+		{"keyword with symbols", model.WebhookSubscription{Keywords: pq.StringArray{"c++"}}, true},
+		{"keyword before a period", model.WebhookSubscription{Keywords: pq.StringArray{"node.js"}}, true},
+		{"keyword is not a substring match", model.WebhookSubscription{Keywords: pq.StringArray{"engine"}}, false},
+		{"location", model.WebhookSubscription{Location: "germany"}, true},
+		{"other location", model.WebhookSubscription{Location: "Paris"}, false},
+		{"remote only", model.WebhookSubscription{RemoteOnly: true}, false},
+		{"all criteria", model.WebhookSubscription{
+			Skills: pq.StringArray{"Go"}, Keywords: pq.StringArray{"apis"}, Location: "Berlin",
+		}, true},
+		{"one criterion fails", model.WebhookSubscription{
+			Skills: pq.StringArray{"Go"}, Keywords: pq.StringArray{"python"}, Location: "Berlin",
+		}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Matches(tt.sub, job); got != tt.want {
+				t.Errorf("Matches() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	remote := job
+	remote.LocationType = model.LocationRemote
+	if !Matches(model.WebhookSubscription{RemoteOnly: true}, remote) {
+		t.Error("expected a remote job to match a remote-only subscription")
+	}
+}
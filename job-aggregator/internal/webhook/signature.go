@@ -0,0 +1,41 @@
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"strings"
+)
+
+// Headers sent with every delivery.
+const (
+	// SignatureHeader carries "sha256=" and the hex HMAC-SHA256 of
+	// "<timestamp>.<body>" keyed with the subscription secret.
+	SignatureHeader = "X-LearnBot-Signature"
+	// TimestampHeader carries the Unix time the delivery was sent, so that
+	// receivers can reject replays.
+	TimestampHeader = "X-LearnBot-Timestamp"
+	// EventHeader names the event, always EventJobCreated for now.
+	EventHeader = "X-LearnBot-Event"
+	// DeliveryHeader carries the delivery ID, unchanged across retries.
+	DeliveryHeader = "X-LearnBot-Delivery"
+)
+
+// Sign returns the SignatureHeader value for body sent at timestamp.
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify reports whether signature is the SignatureHeader value for body
+// sent at timestamp. Receivers written in Go can use it directly.
+func Verify(secret string, timestamp int64, body []byte, signature string) bool {
+	if !strings.HasPrefix(signature, "sha256=") {
+		return false
+	}
+	return hmac.Equal([]byte(signature), []byte(Sign(secret, timestamp, body)))
+}
@@ -0,0 +1,41 @@
+-- Migration 002: Track job expiry checks
+--
+-- The expiry checker periodically re-fetches application URLs and marks
+-- postings that were filled or closed. expiry_reason records why a job was
+-- expired so that checker-expired jobs can be reactivated if they reopen.
+
+BEGIN;
+
+-- ─────────────────────────────────────────────────────────────────────────────
+-- Enum types
+-- ─────────────────────────────────────────────────────────────────────────────
+
+CREATE TYPE job_expiry_reason AS ENUM (
+    'stale',            -- not seen by the scraper within the stale window
+    'not_found',        -- application URL returned 404 / 410
+    'redirected',       -- application URL redirected to a generic careers page
+    'closed_notice'     -- page states the job is no longer accepting applications
+);
+
+-- ─────────────────────────────────────────────────────────────────────────────
+-- Columns
+-- ─────────────────────────────────────────────────────────────────────────────
+
+ALTER TABLE jobs
+    ADD COLUMN expiry_reason     job_expiry_reason,
+    ADD COLUMN expiry_checked_at TIMESTAMPTZ,
+    ADD COLUMN expired_at        TIMESTAMPTZ;
+
+COMMENT ON COLUMN jobs.expiry_reason IS 'Why the job was expired; NULL while active';
+COMMENT ON COLUMN jobs.expiry_checked_at IS 'When the expiry checker last fetched the application URL';
+COMMENT ON COLUMN jobs.expired_at IS 'When the job was last moved to expired';
+
+-- ─────────────────────────────────────────────────────────────────────────────
+-- Indexes
+-- ─────────────────────────────────────────────────────────────────────────────
+
+-- Expiry checker: least recently checked first.
+CREATE INDEX idx_jobs_expiry_checked_at ON jobs(expiry_checked_at ASC NULLS FIRST)
+    WHERE status IN ('active', 'expired');
+
+COMMIT;
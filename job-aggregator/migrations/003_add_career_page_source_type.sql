@@ -0,0 +1,28 @@
+-- Migration 003: Select the scraper for each company career page
+--
+-- Many companies host their jobs on a Greenhouse board rather than their own
+-- career page. source_type tells the service which scraper to build for a
+-- row; existing rows keep the selector-based HTML scraper.
+
+BEGIN;
+
+-- ─────────────────────────────────────────────────────────────────────────────
+-- Enum types
+-- ─────────────────────────────────────────────────────────────────────────────
+
+CREATE TYPE career_page_source_type AS ENUM (
+    'html',         -- CSS selectors or generic JSON API (selectors column)
+    'greenhouse'    -- public Greenhouse job board API
+);
+
+-- ─────────────────────────────────────────────────────────────────────────────
+-- Columns
+-- ─────────────────────────────────────────────────────────────────────────────
+
+ALTER TABLE company_career_pages
+    ADD COLUMN source_type career_page_source_type NOT NULL DEFAULT 'html';
+
+COMMENT ON COLUMN company_career_pages.source_type IS
+    'Scraper used for this page; greenhouse pages take the board token from career_page_url or selectors.board_token';
+
+COMMIT;
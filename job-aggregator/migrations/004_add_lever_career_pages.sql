@@ -0,0 +1,7 @@
+-- Migration 004: Lever job boards
+--
+-- Adds the 'lever' source type for company_career_pages rows scraped through
+-- the public Lever postings API. ALTER TYPE ... ADD VALUE cannot run inside a
+-- transaction block on PostgreSQL < 12, so this migration has no BEGIN/COMMIT.
+
+ALTER TYPE career_page_source_type ADD VALUE IF NOT EXISTS 'lever';
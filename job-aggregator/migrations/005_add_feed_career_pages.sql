@@ -0,0 +1,8 @@
+-- Migration 005: RSS/Atom job feeds
+--
+-- Adds the 'feed' source type for company_career_pages rows whose
+-- career_page_url is an RSS 2.0 or Atom feed. Like migration 004 this has no
+-- BEGIN/COMMIT, because ALTER TYPE ... ADD VALUE cannot run in a transaction
+-- block on PostgreSQL < 12.
+
+ALTER TYPE career_page_source_type ADD VALUE IF NOT EXISTS 'feed';
@@ -0,0 +1,15 @@
+-- Migration 006: Scrape run history per scraper
+--
+-- Records which scraper produced each scrape run. The source alone cannot
+-- tell career page, Greenhouse, Lever and feed scrapers apart, since they
+-- all share a source.
+
+BEGIN;
+
+ALTER TABLE scrape_runs
+    ADD COLUMN IF NOT EXISTS scraper_name TEXT NOT NULL DEFAULT '';
+
+CREATE INDEX IF NOT EXISTS idx_scrape_runs_scraper_name
+    ON scrape_runs(scraper_name, created_at DESC);
+
+COMMIT;
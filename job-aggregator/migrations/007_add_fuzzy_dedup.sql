@@ -0,0 +1,35 @@
+-- Migration 007: Cross-source fuzzy deduplication
+--
+-- The same posting is often scraped from several sources (LinkedIn, Indeed,
+-- the company's own board) under slightly different titles. Such postings
+-- are merged into one canonical job: source_urls keeps every application
+-- URL, and dedup_log records each merge so false positives can be audited.
+-- dedup_log also maps the merged posting's dedup hash to its canonical job,
+-- so later sightings go straight to the canonical row.
+
+BEGIN;
+
+ALTER TABLE jobs
+    ADD COLUMN IF NOT EXISTS source_urls TEXT[] NOT NULL DEFAULT '{}';
+
+UPDATE jobs SET source_urls = ARRAY[application_url] WHERE source_urls = '{}';
+
+CREATE TABLE IF NOT EXISTS dedup_log (
+    id               UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    job_id           UUID NOT NULL REFERENCES jobs(id) ON DELETE CASCADE,
+    dedup_hash       TEXT NOT NULL UNIQUE,
+    source           job_source NOT NULL,
+    external_id      TEXT NOT NULL DEFAULT '',
+    title            TEXT NOT NULL,
+    company_name     TEXT NOT NULL,
+    application_url  TEXT NOT NULL,
+    canonical_title  TEXT NOT NULL,
+    similarity       DOUBLE PRECISION NOT NULL,
+    threshold        DOUBLE PRECISION NOT NULL,
+    created_at       TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_dedup_log_job_id ON dedup_log(job_id);
+CREATE INDEX IF NOT EXISTS idx_dedup_log_created_at ON dedup_log(created_at DESC);
+
+COMMIT;
@@ -0,0 +1,14 @@
+-- Migration 008: Full-text job search
+--
+-- Indexes the weighted title and description document searched by
+-- GET /api/v1/jobs/search. The expression must match jobSearchVector in
+-- internal/storage/job_search.go for the index to be used.
+
+BEGIN;
+
+CREATE INDEX IF NOT EXISTS idx_jobs_search_fts ON jobs USING GIN ((
+    setweight(to_tsvector('english', title), 'A') ||
+    setweight(to_tsvector('english', COALESCE(description, '')), 'B')
+)) WHERE status = 'active';
+
+COMMIT;
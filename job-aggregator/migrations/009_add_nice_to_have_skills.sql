@@ -0,0 +1,12 @@
+-- Migration 009: Nice-to-have skills
+--
+-- Stores skills listed under "nice to have" or "bonus points for" sections
+-- of a job description separately from preferred skills, so that gap
+-- analysis can rank them below preferred skills.
+
+BEGIN;
+
+ALTER TABLE jobs
+    ADD COLUMN IF NOT EXISTS nice_to_have_skills TEXT[] NOT NULL DEFAULT '{}';
+
+COMMIT;
@@ -0,0 +1,9 @@
+-- Migration 010: Interrupted scrape runs
+--
+-- A run cancelled by shutdown stores the pages it had already scraped and is
+-- recorded as 'interrupted' rather than 'failed'.
+--
+-- ALTER TYPE ... ADD VALUE cannot be used in the same transaction that adds
+-- it on older PostgreSQL versions, so this migration runs without BEGIN/COMMIT.
+
+ALTER TYPE scrape_status ADD VALUE IF NOT EXISTS 'interrupted';
@@ -0,0 +1,81 @@
+-- Migration 011: Webhook subscriptions for newly scraped jobs
+--
+-- A subscription asks for every new job matching its filter criteria to be
+-- POSTed to a URL (e.g. a Slack or Discord incoming webhook). After each
+-- scrape run the new jobs are matched against active subscriptions and one
+-- delivery row is queued per (subscription, job); the delivery worker sends
+-- them, retrying with backoff, and moves a delivery to dead_letter once it
+-- has failed max attempts times.
+
+BEGIN;
+
+-- ─────────────────────────────────────────────────────────────────────────────
+-- Enum types
+-- ─────────────────────────────────────────────────────────────────────────────
+
+CREATE TYPE webhook_delivery_status AS ENUM (
+    'pending',      -- queued or waiting for a retry
+    'delivered',    -- the endpoint answered 2xx
+    'dead_letter'   -- gave up after the maximum number of attempts
+);
+
+-- ─────────────────────────────────────────────────────────────────────────────
+-- webhook_subscriptions
+-- ─────────────────────────────────────────────────────────────────────────────
+CREATE TABLE webhook_subscriptions (
+    id              UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    name            TEXT NOT NULL,
+    url             TEXT NOT NULL,
+    -- HMAC-SHA256 key for the X-LearnBot-Signature header
+    secret          TEXT NOT NULL,
+    -- Filter criteria; an empty list or NULL matches every job
+    skills          TEXT[] NOT NULL DEFAULT '{}',
+    keywords        TEXT[] NOT NULL DEFAULT '{}',
+    location        TEXT,
+    remote_only     BOOLEAN NOT NULL DEFAULT FALSE,
+    is_active       BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at      TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at      TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE TRIGGER webhook_subscriptions_updated_at
+    BEFORE UPDATE ON webhook_subscriptions
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+-- ─────────────────────────────────────────────────────────────────────────────
+-- webhook_deliveries
+-- ─────────────────────────────────────────────────────────────────────────────
+CREATE TABLE webhook_deliveries (
+    id               UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    subscription_id  UUID NOT NULL REFERENCES webhook_subscriptions(id) ON DELETE CASCADE,
+    job_id           UUID NOT NULL REFERENCES jobs(id) ON DELETE CASCADE,
+    status           webhook_delivery_status NOT NULL DEFAULT 'pending',
+    -- JSON body, fixed when the delivery is queued so retries are identical
+    payload          JSONB NOT NULL,
+    attempts         INTEGER NOT NULL DEFAULT 0,
+    last_status_code INTEGER,
+    last_error       TEXT,
+    next_attempt_at  TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    delivered_at     TIMESTAMPTZ,
+    created_at       TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at       TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT webhook_deliveries_subscription_job_unique UNIQUE (subscription_id, job_id)
+);
+
+CREATE TRIGGER webhook_deliveries_updated_at
+    BEFORE UPDATE ON webhook_deliveries
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+-- ─────────────────────────────────────────────────────────────────────────────
+-- Indexes
+-- ─────────────────────────────────────────────────────────────────────────────
+
+-- Delivery worker: due pending deliveries first.
+CREATE INDEX idx_webhook_deliveries_due ON webhook_deliveries(next_attempt_at)
+    WHERE status = 'pending';
+-- Admin: recent deliveries, optionally per subscription.
+CREATE INDEX idx_webhook_deliveries_created_at ON webhook_deliveries(created_at DESC);
+CREATE INDEX idx_webhook_deliveries_subscription ON webhook_deliveries(subscription_id, created_at DESC);
+
+COMMIT;
@@ -0,0 +1,14 @@
+-- Migration 012: Keyset pagination for job lists
+--
+-- Serves the newest-first order used by cursor pagination of
+-- GET /api/v1/jobs/search and GET /admin/jobs, so that the next page is an
+-- index range scan instead of an OFFSET scan. The expression must match
+-- jobSortKey in internal/storage/job_search.go for the index to be used.
+
+BEGIN;
+
+CREATE INDEX IF NOT EXISTS idx_jobs_active_recent
+    ON jobs ((COALESCE(posted_at, scraped_at)) DESC, id DESC)
+    WHERE status = 'active';
+
+COMMIT;
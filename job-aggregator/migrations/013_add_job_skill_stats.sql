@@ -0,0 +1,41 @@
+-- Migration 013: Skill demand stats
+--
+-- After each scrape run the skills mentioned in the descriptions of newly
+-- stored jobs are counted per canonical skill, role keyword, location and
+-- week. Aggregation is incremental: job_skill_stats_progress records the
+-- last job folded in, and each run only reads jobs stored after it.
+
+BEGIN;
+
+-- ─────────────────────────────────────────────────────────────────────────────
+-- job_skill_stats
+-- ─────────────────────────────────────────────────────────────────────────────
+CREATE TABLE job_skill_stats (
+    skill           TEXT NOT NULL,      -- canonical skill ID from the skill ontology
+    role_keyword    TEXT NOT NULL,      -- normalized job title, e.g. 'data engineer'
+    location        TEXT NOT NULL,      -- lowercase city, raw location, or ''
+    week_start      DATE NOT NULL,      -- Monday of the posting week (UTC)
+    job_count       INTEGER NOT NULL DEFAULT 0,
+    updated_at      TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    PRIMARY KEY (skill, role_keyword, location, week_start)
+);
+
+CREATE INDEX idx_job_skill_stats_week ON job_skill_stats(week_start);
+
+-- ─────────────────────────────────────────────────────────────────────────────
+-- job_skill_stats_progress: the last job folded into job_skill_stats
+-- ─────────────────────────────────────────────────────────────────────────────
+CREATE TABLE job_skill_stats_progress (
+    id                  BOOLEAN PRIMARY KEY DEFAULT TRUE CHECK (id),
+    last_created_at     TIMESTAMPTZ,
+    last_job_id         UUID,
+    updated_at          TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+INSERT INTO job_skill_stats_progress (id) VALUES (TRUE);
+
+-- Serves the (created_at, id) order in which new jobs are read.
+CREATE INDEX IF NOT EXISTS idx_jobs_created_id ON jobs(created_at, id);
+
+COMMIT;
@@ -0,0 +1,31 @@
+-- Migration 014: Salary period
+--
+-- Salaries extracted from job descriptions are stored as stated, so an
+-- hourly rate needs its period to be compared with a yearly salary. NULL
+-- means the posting did not say.
+
+BEGIN;
+
+-- ─────────────────────────────────────────────────────────────────────────────
+-- Enum types
+-- ─────────────────────────────────────────────────────────────────────────────
+
+CREATE TYPE salary_period AS ENUM (
+    'hour',
+    'day',
+    'week',
+    'month',
+    'year'
+);
+
+-- ─────────────────────────────────────────────────────────────────────────────
+-- Columns
+-- ─────────────────────────────────────────────────────────────────────────────
+
+ALTER TABLE jobs
+    ADD COLUMN IF NOT EXISTS salary_period salary_period;
+
+COMMENT ON COLUMN jobs.salary_period IS
+    'Period of salary_min and salary_max; NULL when not stated';
+
+COMMIT;
@@ -0,0 +1,28 @@
+-- Migration 015: Scraper health
+--
+-- After each scrape run the scheduler summarizes the scraper's run history
+-- into one row, so the health dashboard is a cheap read. The status
+-- (healthy, degraded, failing) is derived from these values when read.
+
+BEGIN;
+
+-- ─────────────────────────────────────────────────────────────────────────────
+-- scraper_health
+-- ─────────────────────────────────────────────────────────────────────────────
+CREATE TABLE scraper_health (
+    scraper_name         TEXT PRIMARY KEY,  -- Scraper.Name(), as in scrape_runs
+    last_run_at          TIMESTAMPTZ,       -- last completed, failed or rate limited run
+    last_success_at      TIMESTAMPTZ,       -- last completed run
+    last_jobs_found      INTEGER NOT NULL DEFAULT 0,
+    consecutive_failures INTEGER NOT NULL DEFAULT 0,
+    -- Completed runs in the seven days before the last completed run
+    avg_jobs_found_7d    DOUBLE PRECISION NOT NULL DEFAULT 0,
+    runs_7d              INTEGER NOT NULL DEFAULT 0,
+    updated_at           TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Serves the per-scraper status queries of the refresh.
+CREATE INDEX IF NOT EXISTS idx_scrape_runs_scraper_status
+    ON scrape_runs(scraper_name, status, created_at DESC);
+
+COMMIT;
@@ -0,0 +1,10 @@
+-- Migration 016: Skipped scrape runs
+--
+-- A scheduled run of a disabled scraper, or of any scraper while the schedule
+-- is paused, is recorded as 'skipped' so that the gap in the run history is
+-- explained.
+--
+-- ALTER TYPE ... ADD VALUE cannot be used in the same transaction that adds
+-- it on older PostgreSQL versions, so this migration runs without BEGIN/COMMIT.
+
+ALTER TYPE scrape_status ADD VALUE IF NOT EXISTS 'skipped';
@@ -0,0 +1,42 @@
+-- Migration 017: Scheduler controls
+--
+-- The schedule can be paused and individual scrapers disabled from the admin
+-- API without a redeploy. The state is stored here so that it survives
+-- restarts.
+
+BEGIN;
+
+-- ─────────────────────────────────────────────────────────────────────────────
+-- scheduler_state: Pause state of the whole schedule (a single row)
+-- ─────────────────────────────────────────────────────────────────────────────
+CREATE TABLE scheduler_state (
+    id         INTEGER PRIMARY KEY DEFAULT 1 CHECK (id = 1),
+    paused     BOOLEAN NOT NULL DEFAULT FALSE,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- ─────────────────────────────────────────────────────────────────────────────
+-- scraper_controls: Scrapers enabled or disabled at runtime
+-- ─────────────────────────────────────────────────────────────────────────────
+CREATE TABLE scraper_controls (
+    scraper_name TEXT PRIMARY KEY,  -- Scraper.Name(), as in scrape_runs
+    enabled      BOOLEAN NOT NULL DEFAULT TRUE,
+    updated_at   TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Skipped runs scraped nothing and are left out of the per-source stats.
+CREATE OR REPLACE VIEW v_scrape_stats AS
+SELECT
+    source,
+    COUNT(*) AS total_runs,
+    COUNT(*) FILTER (WHERE status = 'completed') AS successful_runs,
+    COUNT(*) FILTER (WHERE status = 'failed') AS failed_runs,
+    SUM(jobs_found) AS total_jobs_found,
+    SUM(jobs_new) AS total_jobs_new,
+    AVG(duration_ms) FILTER (WHERE duration_ms IS NOT NULL) AS avg_duration_ms,
+    MAX(completed_at) AS last_successful_run
+FROM scrape_runs
+WHERE status <> 'skipped'
+GROUP BY source;
+
+COMMIT;
@@ -0,0 +1,48 @@
+-- Migration 018: Saved jobs
+--
+-- Users bookmark jobs and track their applications through the funnel
+-- saved → applied → interviewing → offer, any stage of which can end in
+-- rejected. The time each status was reached is kept in its own column.
+--
+-- job_id has no foreign key: the title, company and application URL are
+-- copied from the job when it is saved, so that an entry outlives its job.
+
+BEGIN;
+
+-- ─────────────────────────────────────────────────────────────────────────────
+-- Enum types
+-- ─────────────────────────────────────────────────────────────────────────────
+
+CREATE TYPE application_status AS ENUM (
+    'saved',
+    'applied',
+    'interviewing',
+    'offer',
+    'rejected'
+);
+
+-- ─────────────────────────────────────────────────────────────────────────────
+-- saved_jobs
+-- ─────────────────────────────────────────────────────────────────────────────
+CREATE TABLE saved_jobs (
+    id              UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id         UUID NOT NULL,  -- the gateway's X-User-ID
+    job_id          UUID NOT NULL,
+    status          application_status NOT NULL DEFAULT 'saved',
+    title           TEXT NOT NULL,
+    company_name    TEXT NOT NULL,
+    application_url TEXT NOT NULL,
+    saved_at        TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    applied_at      TIMESTAMPTZ,
+    interviewing_at TIMESTAMPTZ,
+    offer_at        TIMESTAMPTZ,
+    rejected_at     TIMESTAMPTZ,
+    updated_at      TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT saved_jobs_user_job UNIQUE (user_id, job_id)
+);
+
+-- Serves the newest-first listing of a user's saved jobs.
+CREATE INDEX idx_saved_jobs_user_saved_at ON saved_jobs(user_id, saved_at DESC);
+
+COMMIT;
@@ -0,0 +1,33 @@
+-- Migration 019: Stale job expiry per scraper
+--
+-- Jobs not seen for a while are expired by a nightly pass instead of after
+-- every scrape run. How long a job may go unseen is configured per scraper,
+-- so each job records the scraper that last saw it. Jobs stored before this
+-- migration have no scraper and use the default.
+--
+-- Each pass is logged in expiry_runs with the jobs it expired per scraper.
+
+BEGIN;
+
+ALTER TABLE jobs ADD COLUMN last_seen_by TEXT;  -- Scraper.Name(), as in scrape_runs
+
+-- Serves the per-scraper stale job queries of the expiry pass.
+CREATE INDEX idx_jobs_active_last_seen_by ON jobs(last_seen_by, last_seen_at)
+    WHERE status = 'active';
+
+-- ─────────────────────────────────────────────────────────────────────────────
+-- expiry_runs: One row per stale job expiry pass
+-- ─────────────────────────────────────────────────────────────────────────────
+CREATE TABLE expiry_runs (
+    id            UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    started_at    TIMESTAMPTZ NOT NULL,
+    completed_at  TIMESTAMPTZ NOT NULL,
+    jobs_expired  INTEGER NOT NULL DEFAULT 0,
+    -- JSON array of {scraper, stale_after, cutoff, jobs_expired}
+    scrapers      JSONB NOT NULL DEFAULT '[]',
+    error_message TEXT
+);
+
+CREATE INDEX idx_expiry_runs_started_at ON expiry_runs(started_at DESC);
+
+COMMIT;
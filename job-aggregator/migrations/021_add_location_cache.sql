@@ -0,0 +1,27 @@
+-- Migration 021: Location cache
+--
+-- Scraped location strings are normalised into a city, region, ISO country
+-- code and work arrangement at ingest. The same few thousand strings come
+-- back on every scrape, so each is parsed once and its result stored here,
+-- keyed by the lowercased string. Rows parsed by an older parser version
+-- are parsed again and overwritten.
+
+BEGIN;
+
+-- ─────────────────────────────────────────────────────────────────────────────
+-- location_cache: Parsed location strings
+-- ─────────────────────────────────────────────────────────────────────────────
+CREATE TABLE location_cache (
+    raw_key        TEXT PRIMARY KEY,
+    parser_version INTEGER NOT NULL,
+    -- Empty when the parser did not recognise the part; a row with all
+    -- of them empty and no arrangement is an unknown location.
+    city           TEXT NOT NULL DEFAULT '',
+    region         TEXT NOT NULL DEFAULT '',
+    country        TEXT NOT NULL DEFAULT '',  -- ISO 3166-1 alpha-2
+    remote         BOOLEAN NOT NULL DEFAULT FALSE,
+    hybrid         BOOLEAN NOT NULL DEFAULT FALSE,
+    updated_at     TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+COMMIT;
@@ -0,0 +1,24 @@
+-- Migration 022: Changed fields of re-scraped jobs
+--
+-- A re-scraped posting refreshes its description, salary and skills, but
+-- never its title or location: a different title or city under the same
+-- external ID is more often a source glitch than a new role. When either
+-- differs materially from what is stored, the scraped values are recorded
+-- here for review instead.
+
+BEGIN;
+
+-- ─────────────────────────────────────────────────────────────────────────────
+-- Columns
+-- ─────────────────────────────────────────────────────────────────────────────
+
+ALTER TABLE jobs
+    ADD COLUMN IF NOT EXISTS changed_fields JSONB,
+    ADD COLUMN IF NOT EXISTS changed_at     TIMESTAMPTZ;
+
+COMMENT ON COLUMN jobs.changed_fields IS
+    'Last material title or location change seen on a re-scrape, as {"field": {"old": ..., "new": ...}}; NULL if none';
+COMMENT ON COLUMN jobs.changed_at IS
+    'When changed_fields last changed';
+
+COMMIT;
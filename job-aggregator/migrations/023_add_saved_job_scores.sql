@@ -0,0 +1,22 @@
+-- Migration 023: Match scores of saved jobs
+--
+-- The API gateway re-scores a user's saved jobs against their profile when
+-- they ask for it, and stores each score on the saved job so that the list
+-- shows it without scoring every job again.
+
+BEGIN;
+
+-- ─────────────────────────────────────────────────────────────────────────────
+-- Columns
+-- ─────────────────────────────────────────────────────────────────────────────
+
+ALTER TABLE saved_jobs
+    ADD COLUMN IF NOT EXISTS match_score       DOUBLE PRECISION,
+    ADD COLUMN IF NOT EXISTS score_computed_at TIMESTAMPTZ;
+
+COMMENT ON COLUMN saved_jobs.match_score IS
+    'Acceptance likelihood score (0-100) of the job against the user''s profile; NULL until scored';
+COMMENT ON COLUMN saved_jobs.score_computed_at IS
+    'Time the profile used for match_score was read';
+
+COMMIT;
@@ -0,0 +1,112 @@
+-- Migration 024: Company entities
+--
+-- Jobs stored the company as the string the source scraped, so "Google",
+-- "Google LLC" and "Google Indonesia" were three companies. Scraped names
+-- are now resolved at ingest to a row of companies through its aliases
+-- (normalised names) or its website domain, and jobs and career pages
+-- point to the company by ID. Companies the resolver creates are flagged
+-- for review, so that an admin can merge those that are the same employer.
+--
+-- Existing companies are aliased by their normalised name and by the key
+-- the resolver derives from their name, and existing jobs and career pages
+-- are linked to the company their name's key is an alias of, creating
+-- companies, flagged for review, for names no company has.
+
+BEGIN;
+
+-- ─────────────────────────────────────────────────────────────────────────────
+-- companies: Resolver columns
+-- ─────────────────────────────────────────────────────────────────────────────
+
+ALTER TABLE companies
+    ADD COLUMN IF NOT EXISTS website_domain TEXT,
+    ADD COLUMN IF NOT EXISTS needs_review   BOOLEAN NOT NULL DEFAULT FALSE;
+
+COMMENT ON COLUMN companies.website_domain IS
+    'Registrable domain of the company website, e.g. google.com; matches postings without a known alias';
+COMMENT ON COLUMN companies.needs_review IS
+    'Set on companies created by the ingest resolver until an admin reviews or merges them';
+
+CREATE INDEX IF NOT EXISTS idx_companies_website_domain ON companies(website_domain);
+CREATE INDEX IF NOT EXISTS idx_companies_needs_review ON companies(created_at DESC) WHERE needs_review;
+
+-- ─────────────────────────────────────────────────────────────────────────────
+-- company_aliases: Normalised names that resolve to a company
+-- ─────────────────────────────────────────────────────────────────────────────
+CREATE TABLE company_aliases (
+    -- Lowercased, without punctuation or legal-entity suffixes; every
+    -- company has its own normalized_name as an alias.
+    alias      TEXT PRIMARY KEY,
+    company_id UUID NOT NULL REFERENCES companies(id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_company_aliases_company_id ON company_aliases(company_id);
+
+-- ─────────────────────────────────────────────────────────────────────────────
+-- company_career_pages: Lookup by company
+-- ─────────────────────────────────────────────────────────────────────────────
+
+CREATE INDEX IF NOT EXISTS idx_company_career_pages_company_id ON company_career_pages(company_id);
+
+-- ─────────────────────────────────────────────────────────────────────────────
+-- Backfill: Aliases for existing companies, companies for existing jobs
+-- ─────────────────────────────────────────────────────────────────────────────
+
+-- company_key_024 mirrors companyKey in internal/storage/company.go: the
+-- name lowercased into ASCII words without legal-entity suffixes, leading
+-- "PT" or "CV" or a trailing "Tbk"; a name without such words is only
+-- lowercased. Dropped at the end of the migration.
+CREATE FUNCTION company_key_024(raw TEXT) RETURNS TEXT AS $$
+    SELECT COALESCE(
+        NULLIF(
+            regexp_replace(
+                regexp_replace(
+                    array_to_string(ARRAY(SELECT (regexp_matches(LOWER(raw), '[a-z0-9+#]+', 'g'))[1]), ' '),
+                    '^((pt|cv) )+', ''),
+                '( (inc|llc|ltd|corp|corporation|co|company|gmbh|plc|limited|tbk))+$', ''),
+            ''),
+        regexp_replace(LOWER(TRIM(raw)), '\s+', ' ', 'g'))
+$$ LANGUAGE sql IMMUTABLE;
+
+-- The oldest company keeps an alias two companies share.
+INSERT INTO company_aliases (alias, company_id, created_at)
+SELECT alias, id, NOW()
+FROM (
+    SELECT normalized_name AS alias, id, created_at, 0 AS priority FROM companies
+    UNION ALL
+    SELECT company_key_024(name), id, created_at, 1 FROM companies
+) keys
+WHERE alias <> ''
+ORDER BY priority, created_at, id
+ON CONFLICT (alias) DO NOTHING;
+
+INSERT INTO companies (name, normalized_name, needs_review)
+SELECT DISTINCT ON (key) TRIM(company_name), key, TRUE
+FROM (
+    SELECT company_name, company_key_024(company_name) AS key, created_at FROM jobs WHERE company_id IS NULL
+    UNION ALL
+    SELECT company_name, company_key_024(company_name), created_at FROM company_career_pages WHERE company_id IS NULL
+) names
+WHERE key <> '' AND NOT EXISTS (SELECT 1 FROM company_aliases a WHERE a.alias = names.key)
+ORDER BY key, created_at
+ON CONFLICT (normalized_name) DO NOTHING;
+
+INSERT INTO company_aliases (alias, company_id, created_at)
+SELECT normalized_name, id, NOW() FROM companies WHERE needs_review
+ON CONFLICT (alias) DO NOTHING;
+
+-- Linking a company does not change the posting, so updated_at is kept.
+ALTER TABLE jobs DISABLE TRIGGER jobs_updated_at;
+UPDATE jobs j SET company_id = a.company_id
+FROM company_aliases a
+WHERE j.company_id IS NULL AND a.alias = company_key_024(j.company_name);
+ALTER TABLE jobs ENABLE TRIGGER jobs_updated_at;
+
+UPDATE company_career_pages p SET company_id = a.company_id
+FROM company_aliases a
+WHERE p.company_id IS NULL AND a.alias = company_key_024(p.company_name);
+
+DROP FUNCTION company_key_024(TEXT);
+
+COMMIT;
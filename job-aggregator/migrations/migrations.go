@@ -0,0 +1,14 @@
+// Package migrations embeds the job aggregator's PostgreSQL schema
+// migrations so that the server binary can apply them on startup.
+package migrations
+
+import "embed"
+
+// Table records which of the migrations have been applied. It is separate
+// from the other services' tables because they may share one database.
+const Table = "job_aggregator_schema_migrations"
+
+// FS holds the migration files, NNN_description.sql, at its root.
+//
+//go:embed *.sql
+var FS embed.FS
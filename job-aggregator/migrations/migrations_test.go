@@ -0,0 +1,174 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"io"
+	"io/fs"
+	"log"
+	"os"
+	"strings"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	_ "github.com/lib/pq"
+
+	"github.com/learnbot/shared/migrate"
+)
+
+// openTestSchema returns a connection pool whose tables are created in a
+// new, empty schema of the TEST_DATABASE_URL database, dropped when the
+// test ends.
+func openTestSchema(t *testing.T) *sql.DB {
+	t.Helper()
+	dsn := os.Getenv("TEST_DATABASE_URL")
+	if dsn == "" {
+		t.Skip("TEST_DATABASE_URL not set")
+	}
+	admin, err := sql.Open("postgres", dsn)
+	if err != nil {
+		t.Fatalf("open database: %v", err)
+	}
+	t.Cleanup(func() { admin.Close() })
+
+	schema := fmt.Sprintf("migrate_test_%d", time.Now().UnixNano())
+	if _, err := admin.Exec("CREATE SCHEMA " + schema); err != nil {
+		t.Fatalf("create schema: %v", err)
+	}
+	t.Cleanup(func() { admin.Exec("DROP SCHEMA " + schema + " CASCADE") }) //nolint:errcheck
+
+	sep := " "
+	if strings.Contains(dsn, "://") {
+		sep = "&"
+		if !strings.Contains(dsn, "?") {
+			sep = "?"
+		}
+	}
+	db, err := sql.Open("postgres", dsn+sep+"search_path="+schema)
+	if err != nil {
+		t.Fatalf("open schema: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+func TestMigrations_ApplyTwice(t *testing.T) {
+	db := openTestSchema(t)
+	ctx := context.Background()
+
+	m, err := migrate.New(db, FS, Table, log.New(io.Discard, "", 0))
+	if err != nil {
+		t.Fatalf("migrate.New: %v", err)
+	}
+
+	applied, err := m.Up(ctx)
+	if err != nil {
+		t.Fatalf("first Up: %v", err)
+	}
+	files, _ := FS.ReadDir(".")
+	if applied != len(files) {
+		t.Errorf("first Up applied %d migrations, want %d", applied, len(files))
+	}
+
+	applied, err = m.Up(ctx)
+	if err != nil {
+		t.Fatalf("second Up: %v", err)
+	}
+	if applied != 0 {
+		t.Errorf("second Up applied %d migrations, want 0", applied)
+	}
+
+	var jobs int
+	if err := db.QueryRow(`SELECT COUNT(*) FROM jobs`).Scan(&jobs); err != nil {
+		t.Errorf("jobs table missing after migrating: %v", err)
+	}
+}
+
+func TestMigrations_BackfillCompanies(t *testing.T) {
+	db := openTestSchema(t)
+	ctx := context.Background()
+	logger := log.New(io.Discard, "", 0)
+
+	before := fstest.MapFS{}
+	files, _ := FS.ReadDir(".")
+	for _, f := range files {
+		if f.Name() >= "024" || !strings.HasSuffix(f.Name(), ".sql") {
+			continue
+		}
+		data, err := fs.ReadFile(FS, f.Name())
+		if err != nil {
+			t.Fatalf("read %s: %v", f.Name(), err)
+		}
+		before[f.Name()] = &fstest.MapFile{Data: data}
+	}
+	m, err := migrate.New(db, before, Table, logger)
+	if err != nil {
+		t.Fatalf("migrate.New: %v", err)
+	}
+	if _, err := m.Up(ctx); err != nil {
+		t.Fatalf("Up to 023: %v", err)
+	}
+
+	var googleID string
+	if err := db.QueryRow(`
+		INSERT INTO companies (name, normalized_name) VALUES ('Google LLC', 'google llc') RETURNING id`,
+	).Scan(&googleID); err != nil {
+		t.Fatalf("insert company: %v", err)
+	}
+	for i, name := range []string{"Google", "PT Gojek Indonesia", "Gojek Indonesia Tbk"} {
+		if _, err := db.Exec(`
+			INSERT INTO jobs (dedup_hash, source, company_name, title, application_url)
+			VALUES ($1, 'linkedin', $2, 'Engineer', 'https://example.com/jobs')`,
+			fmt.Sprintf("hash-%d", i), name,
+		); err != nil {
+			t.Fatalf("insert job: %v", err)
+		}
+	}
+	if _, err := db.Exec(`
+		INSERT INTO company_career_pages (company_name, career_page_url) VALUES ('Google, Inc.', 'https://careers.google.com')`,
+	); err != nil {
+		t.Fatalf("insert career page: %v", err)
+	}
+
+	if m, err = migrate.New(db, FS, Table, logger); err != nil {
+		t.Fatalf("migrate.New: %v", err)
+	}
+	if _, err := m.Up(ctx); err != nil {
+		t.Fatalf("Up: %v", err)
+	}
+
+	var aliases string
+	if err := db.QueryRow(`
+		SELECT STRING_AGG(alias, ',' ORDER BY alias) FROM company_aliases WHERE company_id = $1`, googleID,
+	).Scan(&aliases); err != nil || aliases != "google,google llc" {
+		t.Errorf("Google aliases = %q (%v), want google,google llc", aliases, err)
+	}
+	rows, err := db.Query(`
+		SELECT j.company_name, c.id, c.normalized_name, c.needs_review
+		FROM jobs j JOIN companies c ON c.id = j.company_id ORDER BY j.dedup_hash`)
+	if err != nil {
+		t.Fatalf("query jobs: %v", err)
+	}
+	defer rows.Close()
+	var linked []string
+	for rows.Next() {
+		var name, id, key string
+		var needsReview bool
+		if err := rows.Scan(&name, &id, &key, &needsReview); err != nil {
+			t.Fatalf("scan: %v", err)
+		}
+		if (id == googleID) == needsReview {
+			t.Errorf("job of %s: company %s needs_review = %v", name, key, needsReview)
+		}
+		linked = append(linked, name+"="+key)
+	}
+	if got, want := strings.Join(linked, ","), "Google=google llc,PT Gojek Indonesia=gojek indonesia,Gojek Indonesia Tbk=gojek indonesia"; got != want {
+		t.Errorf("linked jobs = %s, want %s", got, want)
+	}
+	var pageCompany sql.NullString
+	if err := db.QueryRow(`SELECT company_id FROM company_career_pages`).Scan(&pageCompany); err != nil || pageCompany.String != googleID {
+		t.Errorf("career page company = %v (%v), want %s", pageCompany, err, googleID)
+	}
+}
@@ -14,23 +14,43 @@ import (
 
 	_ "github.com/lib/pq"
 
+	"github.com/learnbot/database/migrations"
 	"github.com/learnbot/database/repository"
 	"github.com/learnbot/learning-resources/internal/admin"
 	"github.com/learnbot/learning-resources/internal/api"
+	"github.com/learnbot/learning-resources/internal/coverage"
+	"github.com/learnbot/learning-resources/internal/linkcheck"
+	"github.com/learnbot/shared/adminauth"
+	"github.com/learnbot/shared/audit"
+	"github.com/learnbot/shared/healthcheck"
+	"github.com/learnbot/shared/idempotency"
+	"github.com/learnbot/shared/logging"
+	"github.com/learnbot/shared/metrics"
+	"github.com/learnbot/shared/migrate"
+	"github.com/learnbot/shared/openapi"
+	"github.com/learnbot/shared/validation"
 )
 
 func main() {
 	addr := flag.String("addr", ":8081", "HTTP server address")
 	dsn := flag.String("dsn", os.Getenv("DATABASE_URL"), "PostgreSQL connection string")
+	migrateUp := flag.Bool("migrate", false, "apply pending schema migrations on startup")
+	migrateBaseline := flag.Int("migrate-baseline", 0, "record migrations up to this version as applied without running them, for a schema created by hand")
+	linkCheck := flag.Bool("link-check", false, "periodically check resource URLs and deactivate broken ones")
+	persistFinalURL := flag.Bool("link-check-persist-final-url", false, "replace resource URLs that redirect permanently")
+	jobAggregatorURL := flag.String("job-aggregator-url", os.Getenv("JOB_AGGREGATOR_URL"),
+		"job aggregator base URL whose skill demand feeds the skill coverage report")
 	flag.Parse()
 
-	logger := log.New(os.Stdout, "[learning-resources] ", log.LstdFlags|log.Lshortfile)
+	slogger := logging.New("learning-resources", os.Stdout, logging.ConfigFromEnv())
+	logger := logging.StdLogger(slogger)
 
 	if *dsn == "" {
 		logger.Fatal("DATABASE_URL environment variable or -dsn flag is required")
 	}
 
-	db, err := sql.Open("postgres", *dsn)
+	// Failed queries are counted in /metrics.
+	db, err := metrics.OpenDB("postgres", *dsn)
 	if err != nil {
 		logger.Fatalf("failed to open database: %v", err)
 	}
@@ -49,24 +69,85 @@ func main() {
 	}
 	logger.Println("connected to database")
 
+	if err := migrateSchema(db, *migrateUp, *migrateBaseline, logger); err != nil {
+		logger.Fatalf("failed to migrate database: %v", err)
+	}
+
 	repo := repository.NewLearningResourceRepository(db)
 	apiHandler := api.NewHandler(repo, logger)
 	adminHandler := admin.NewHandler(repo, logger)
 
+	// Admin routes accept ADMIN_API_KEY or a gateway JWT with the admin claim
+	// or the curator role.
+	authCfg := adminauth.ConfigFromEnv()
+	authCfg.Roles = []string{"curator"}
+	if !authCfg.Enabled() {
+		logger.Println("warning: neither ADMIN_API_KEY nor JWT_SECRET is set; admin routes will reject all requests")
+	}
+	adminHandler.SetAuth(adminauth.New(authCfg, logger))
+	adminHandler.SetValidationConfig(validation.ConfigFromEnv())
+
+	workerCtx, stopWorkers := context.WithCancel(context.Background())
+	defer stopWorkers()
+
+	// Retried POSTs with an Idempotency-Key get the first response again,
+	// for IDEMPOTENCY_KEY_TTL (default 24h) per user or admin caller.
+	idempotent := idempotency.New(idempotency.ConfigFromEnv(), nil, slogger)
+	idempotent.Start(workerCtx)
+	apiHandler.SetIdempotency(idempotent)
+	adminHandler.SetIdempotency(idempotent)
+	// Changes made through the admin routes are kept in audit_log.
+	adminHandler.SetAuditLog(audit.NewSQLStore(db), slogger)
+	if *linkCheck {
+		cfg := linkcheck.DefaultConfig()
+		cfg.PersistFinalURL = *persistFinalURL
+		checker := linkcheck.New(repo, cfg, logger)
+		checker.Start(workerCtx)
+		adminHandler.SetLinkChecker(checker)
+	}
+
+	// The skill coverage report checks the skill taxonomy and, with a job
+	// aggregator, the skills most mentioned in recent job postings.
+	coverageSources := []coverage.Source{coverage.DefaultTaxonomy()}
+	if *jobAggregatorURL != "" {
+		coverageSources = append(coverageSources, coverage.NewJobDemand(*jobAggregatorURL,
+			&http.Client{Transport: logging.Transport(nil), Timeout: 10 * time.Second}))
+	}
+	adminHandler.SetCoverageSources(coverageSources...)
+
 	mux := http.NewServeMux()
 	apiHandler.RegisterRoutes(mux)
 	adminHandler.RegisterRoutes(mux)
 
-	// Health check endpoint.
+	// Health check endpoints: /health for compatibility, /livez for the
+	// process and /readyz for the database too.
+	mux.Handle("/metrics", metrics.Handler())
 	mux.HandleFunc("/health", func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Content-Type", "application/json")
 		w.WriteHeader(http.StatusOK)
 		w.Write([]byte(`{"status":"ok","service":"learning-resources"}`))
 	})
+	health := healthcheck.New("learning-resources", healthcheck.DefaultTimeout)
+	health.Register(healthcheck.Check{Name: "database", Required: true, Probe: healthcheck.Ping(db)})
+	health.RegisterRoutes(mux)
+
+	spec := openapi.New("learning-resources", "1.0.0")
+	spec.SetDescription("Learning resource catalog, learning paths and user progress APIs.")
+	spec.SetErrorBody(openapi.Fields{"success": false, "error": ""})
+	apiHandler.DescribeRoutes(spec)
+	adminHandler.DescribeRoutes(spec)
+	health.DescribeRoutes(spec)
+	spec.Route("/metrics", openapi.Metrics)
+	spec.Route("/health", openapi.Operation{
+		Method:   http.MethodGet,
+		Summary:  "Health check",
+		Response: openapi.Fields{"status": "", "service": ""},
+	})
+	spec.Register(mux)
 
 	srv := &http.Server{
 		Addr:         *addr,
-		Handler:      mux,
+		Handler:      logging.Handler(slogger, metrics.Instrument(mux)),
 		ReadTimeout:  30 * time.Second,
 		WriteTimeout: 30 * time.Second,
 		IdleTimeout:  60 * time.Second,
@@ -85,6 +166,7 @@ func main() {
 
 	<-quit
 	logger.Println("shutting down server...")
+	stopWorkers()
 
 	shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer shutdownCancel()
@@ -95,3 +177,39 @@ func main() {
 
 	logger.Println("server stopped")
 }
+
+// migrateSchema applies the pending schema migrations if apply is set, and
+// otherwise warns about them. A positive baseline first records the
+// migrations up to that version as applied. It fails if the database was
+// migrated by a newer release.
+func migrateSchema(db *sql.DB, apply bool, baseline int, logger *log.Logger) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	m, err := migrate.New(db, migrations.FS, migrations.Table, logger)
+	if err != nil {
+		return err
+	}
+	if baseline > 0 {
+		if err := m.Baseline(ctx, baseline); err != nil {
+			return err
+		}
+	}
+	if !apply {
+		pending, err := m.Pending(ctx)
+		if err != nil {
+			return err
+		}
+		if len(pending) > 0 {
+			logger.Printf("warning: %d schema migrations pending, up to %s; run with -migrate to apply them",
+				len(pending), pending[len(pending)-1].Name)
+		}
+		return nil
+	}
+	applied, err := m.Up(ctx)
+	if err != nil {
+		return err
+	}
+	logger.Printf("database schema at version %d (%d migrations applied)", m.Latest(), applied)
+	return nil
+}
@@ -3,9 +3,16 @@ module github.com/learnbot/learning-resources
 go 1.24.0
 
 require (
+	github.com/DATA-DOG/go-sqlmock v1.5.2
 	github.com/google/uuid v1.6.0
 	github.com/learnbot/database v0.0.0
+	github.com/learnbot/shared v0.0.0
 	github.com/lib/pq v1.11.2
 )
 
-replace github.com/learnbot/database => ../database
+require github.com/golang-jwt/jwt/v5 v5.2.2 // indirect
+
+replace (
+	github.com/learnbot/database => ../database
+	github.com/learnbot/shared => ../shared
+)
@@ -0,0 +1,79 @@
+package admin
+
+import (
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/learnbot/shared/audit"
+	"github.com/learnbot/shared/pagination"
+)
+
+// auditService names the learning resources service in the audit log.
+const auditService = "learning-resources"
+
+// SetAuditLog records the changes made through the mutating admin routes
+// in store and serves them at /api/v1/admin/audit-log. Entries that cannot be
+// stored are logged to logger.
+func (h *Handler) SetAuditLog(store audit.Store, logger *slog.Logger) {
+	h.audit = audit.NewRecorder(auditService, store, logger)
+}
+
+// auditing reports whether changes are recorded, so that handlers only
+// read an entity before changing it when the audit log needs it.
+func (h *Handler) auditing() bool {
+	return h.audit != nil
+}
+
+// handleAuditLog handles GET /api/v1/admin/audit-log
+//
+// Query parameters:
+//   - actor: admin user ID, or "api_key"
+//   - entity_type, entity_id: e.g. resource and its ID
+//   - from, to: RFC 3339 timestamps or YYYY-MM-DD dates; a date-only to
+//     includes the whole day
+//   - limit: max results (default 20, max 100)
+//   - offset: pagination offset
+func (h *Handler) handleAuditLog(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		h.writeError(w, http.StatusMethodNotAllowed, "only GET is supported")
+		return
+	}
+	if h.audit == nil {
+		h.writeError(w, http.StatusServiceUnavailable, "audit log is not enabled")
+		return
+	}
+
+	filter, err := audit.ParseFilter(r.URL.Query())
+	if err != nil {
+		h.writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	filter.Service = auditService
+	filter.Limit, filter.Offset = 20, 0
+	if l := r.URL.Query().Get("limit"); l != "" {
+		if v, err := strconv.Atoi(l); err == nil && v > 0 {
+			filter.Limit = min(v, 100)
+		}
+	}
+	if o := r.URL.Query().Get("offset"); o != "" {
+		if v, err := strconv.Atoi(o); err == nil && v > 0 {
+			filter.Offset = v
+		}
+	}
+
+	entries, total, err := h.audit.Store().ListAuditEntries(r.Context(), filter)
+	if err != nil {
+		h.logger.Printf("list audit log error: %v", err)
+		h.writeError(w, http.StatusInternalServerError, "failed to list the audit log")
+		return
+	}
+
+	page := pagination.New(total, filter.Limit, filter.Offset)
+	pagination.SetLinks(w.Header(), r.URL, page)
+	h.writeJSON(w, http.StatusOK, map[string]interface{}{
+		"success":    true,
+		"data":       entries,
+		"pagination": page,
+	})
+}
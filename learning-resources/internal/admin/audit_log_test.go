@@ -0,0 +1,143 @@
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+
+	"github.com/learnbot/shared/adminauth"
+	"github.com/learnbot/shared/audit"
+	"github.com/learnbot/shared/logging"
+)
+
+// newAuditedMux serves the admin routes of h behind API key authentication,
+// recording changes in the returned store.
+func newAuditedMux(h *Handler) (http.Handler, *audit.MemoryStore) {
+	store := audit.NewMemoryStore()
+	h.SetAuth(adminauth.New(adminauth.Config{APIKey: "secret"}, log.New(io.Discard, "", 0)))
+	h.SetAuditLog(store, nil)
+	mux := http.NewServeMux()
+	h.RegisterRoutes(mux)
+	return logging.RequestIDMiddleware(mux), store
+}
+
+func auditRequest(srv http.Handler, method, path, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	req.Header.Set(adminauth.APIKeyHeader, "secret")
+	req.Header.Set("X-Request-ID", "req-7")
+	w := httptest.NewRecorder()
+	srv.ServeHTTP(w, req)
+	return w
+}
+
+func storedEntries(t *testing.T, store audit.Store) []audit.Entry {
+	t.Helper()
+	entries, _, err := store.ListAuditEntries(context.Background(), audit.Filter{Limit: 100})
+	if err != nil {
+		t.Fatalf("ListAuditEntries: %v", err)
+	}
+	return entries
+}
+
+func TestAuditLog_UpdateResource(t *testing.T) {
+	h, mock := newMockHandler(t)
+	srv, store := newAuditedMux(h)
+	id := uuid.New()
+	created, updated := time.Now().Add(-time.Hour), time.Now()
+	row := func(title string, updatedAt time.Time) *sqlmock.Rows {
+		return sqlmock.NewRows(resourceColumns).AddRow(
+			id, title, "intro-to-go", strings.Repeat("A long description. ", 100), "https://example.com", nil, "course",
+			"beginner", "free", nil, "USD", nil,
+			nil, "en", true, false, false,
+			false, false, nil, 0, nil,
+			nil, created, updatedAt,
+		)
+	}
+
+	mock.ExpectQuery("SELECT .+ FROM learning_resources\\s+WHERE id = \\$1 AND is_active = TRUE").
+		WithArgs(id).WillReturnRows(row("Intro to Go", created))
+	mock.ExpectQuery("UPDATE learning_resources").WillReturnRows(row("Introduction to Go", updated))
+	w := auditRequest(srv, http.MethodPut, "/api/v1/admin/resources/"+id.String(), `{"title":"Introduction to Go"}`)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+
+	w = auditRequest(srv, http.MethodGet, "/api/v1/admin/audit-log?entity_type=resource&entity_id="+id.String(), "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var resp struct {
+		Data []audit.Entry `json:"data"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil || len(resp.Data) != 1 {
+		t.Fatalf("unexpected audit log %s (%v)", w.Body.String(), err)
+	}
+	e := resp.Data[0]
+	if e.Action != "resource.update" || e.Service != auditService || e.Actor != "api_key" || e.RequestID != "req-7" {
+		t.Errorf("unexpected entry %+v", e)
+	}
+	var changes map[string]audit.FieldChange
+	if err := json.Unmarshal(e.Changes, &changes); err != nil {
+		t.Fatalf("decode changes %s: %v", e.Changes, err)
+	}
+	if c := changes["title"]; c.Old != "Intro to Go" || c.New != "Introduction to Go" {
+		t.Errorf("title change = %+v", c)
+	}
+	if _, ok := changes["updated_at"]; !ok || len(changes) != 2 {
+		t.Errorf("changes = %s, want title and updated_at only", e.Changes)
+	}
+
+	if n := len(storedEntries(t, store)); n != 1 {
+		t.Errorf("store holds %d entries, want 1", n)
+	}
+}
+
+func TestAuditLog_ReadOnlyRoutesRecordNothing(t *testing.T) {
+	h, mock := newMockHandler(t)
+	srv, store := newAuditedMux(h)
+
+	mock.ExpectQuery("FROM learning_resources\\s+WHERE consecutive_failures > 0").
+		WillReturnRows(sqlmock.NewRows(nil))
+	for _, path := range []string{"/api/v1/admin/resources/broken", "/api/v1/admin/audit-log"} {
+		if w := auditRequest(srv, http.MethodGet, path, ""); w.Code != http.StatusOK {
+			t.Errorf("GET %s: expected 200, got %d: %s", path, w.Code, w.Body.String())
+		}
+	}
+	// Rejected mutations are not recorded either.
+	auditRequest(srv, http.MethodPut, "/api/v1/admin/resources/not-a-uuid", `{}`)
+	auditRequest(srv, http.MethodPost, "/api/v1/admin/providers", `{"name":""}`)
+
+	if entries := storedEntries(t, store); len(entries) != 0 {
+		t.Errorf("expected no audit entries, got %+v", entries)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestAuditLog_InvalidFilter(t *testing.T) {
+	h, _ := newMockHandler(t)
+	srv, _ := newAuditedMux(h)
+	if w := auditRequest(srv, http.MethodGet, "/api/v1/admin/audit-log?to=soon", ""); w.Code != http.StatusBadRequest {
+		t.Errorf("expected 400 for an invalid date, got %d", w.Code)
+	}
+
+	h, _ = newMockHandler(t)
+	w := httptest.NewRecorder()
+	h.handleAuditLog(w, httptest.NewRequest(http.MethodGet, "/api/v1/admin/audit-log", nil))
+	if w.Code != http.StatusServiceUnavailable {
+		t.Errorf("expected 503 without an audit log, got %d", w.Code)
+	}
+}
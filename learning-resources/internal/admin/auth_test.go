@@ -0,0 +1,93 @@
+package admin
+
+import (
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+
+	"github.com/learnbot/shared/adminauth"
+	"github.com/learnbot/shared/idempotency"
+)
+
+func TestAdminRoutesRequireAPIKey(t *testing.T) {
+	logger := log.New(io.Discard, "", 0)
+	h := NewHandler(nil, logger)
+	h.SetAuth(adminauth.New(adminauth.Config{APIKey: "secret"}, logger))
+	mux := http.NewServeMux()
+	h.RegisterRoutes(mux)
+
+	tests := []struct {
+		name string
+		key  string
+		want int
+	}{
+		{"missing key", "", http.StatusUnauthorized},
+		{"wrong key", "guess", http.StatusUnauthorized},
+		// A valid key reaches the handler, which rejects the method.
+		{"valid key", "secret", http.StatusMethodNotAllowed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/admin/resources/import", nil)
+			if tt.key != "" {
+				req.Header.Set(adminauth.APIKeyHeader, tt.key)
+			}
+			w := httptest.NewRecorder()
+			mux.ServeHTTP(w, req)
+			if w.Code != tt.want {
+				t.Errorf("expected %d, got %d: %s", tt.want, w.Code, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestAdminRoutes_Idempotency(t *testing.T) {
+	h, mock := newMockHandler(t)
+	h.SetAuth(adminauth.New(adminauth.Config{APIKey: "secret"}, log.New(io.Discard, "", 0)))
+	h.SetIdempotency(idempotency.New(idempotency.Config{}, nil, nil))
+	mux := http.NewServeMux()
+	h.RegisterRoutes(mux)
+
+	id := uuid.New()
+	now := time.Now()
+	// Only the first request reaches the database.
+	mock.ExpectQuery("UPDATE learning_resources SET is_active = TRUE").
+		WithArgs(id).
+		WillReturnRows(sqlmock.NewRows(resourceColumns).AddRow(
+			id, "Intro to Go", "intro-to-go", nil, "https://example.com", nil, "course",
+			"beginner", "free", nil, "USD", nil,
+			nil, "en", true, false, false,
+			false, false, nil, 0, nil,
+			nil, now, now,
+		))
+
+	restore := func(key string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/admin/resources/"+id.String()+"/restore", nil)
+		req.Header.Set(adminauth.APIKeyHeader, key)
+		req.Header.Set(idempotency.Header, "restore-1")
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+		return w
+	}
+	first := restore("secret")
+	retry := restore("secret")
+	if first.Code != http.StatusOK || retry.Code != http.StatusOK || retry.Body.String() != first.Body.String() {
+		t.Fatalf("expected the retry to replay 200, got %d then %d: %s", first.Code, retry.Code, retry.Body.String())
+	}
+	if retry.Header().Get(idempotency.ReplayedHeader) != "true" {
+		t.Error("expected the retry to be marked as replayed")
+	}
+	// Unauthenticated retries are rejected before their key is looked up.
+	if w := restore("guess"); w.Code != http.StatusUnauthorized {
+		t.Errorf("expected 401, got %d", w.Code)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,100 @@
+package admin
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/learnbot/database/repository"
+	"github.com/learnbot/shared/dataquality"
+)
+
+// defaultQualityWindow is the reporting period used when ?days= is absent.
+const defaultQualityWindow = 7 * 24 * time.Hour
+
+// handleDataQuality handles GET /admin/data-quality?days=7
+func (h *Handler) handleDataQuality(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		h.writeError(w, http.StatusMethodNotAllowed, "only GET is supported")
+		return
+	}
+
+	window := defaultQualityWindow
+	if d := r.URL.Query().Get("days"); d != "" {
+		if n, err := strconv.Atoi(d); err == nil && n > 0 {
+			window = time.Duration(n) * 24 * time.Hour
+		}
+	}
+
+	rows, err := h.repo.ListResourceQualityRows(r.Context())
+	if err != nil {
+		h.logger.Printf("data quality error: %v", err)
+		h.writeError(w, http.StatusInternalServerError, "failed to compute data quality")
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, map[string]interface{}{
+		"success": true,
+		"data":    buildDataQualitySection(rows, time.Now().UTC(), window),
+	})
+}
+
+// buildDataQualitySection evaluates the quality checks over the given rows.
+// A check's PreviousCount is how many of the affected resources already
+// existed at the start of the period, so an upward trend means newly added
+// resources introduced the problem.
+func buildDataQualitySection(rows []repository.ResourceQualityRow, now time.Time, window time.Duration) dataquality.Section {
+	periodStart := now.Add(-window)
+
+	brokenLinks := dataquality.NewCheck("broken_links",
+		"Resources whose most recent link checks failed, including those deactivated for it",
+		"/api/v1/admin/resources/broken")
+	invalidURLs := newResourceCheck("invalid_urls",
+		"Active resources whose URL is not an absolute http(s) link")
+	missingSkills := newResourceCheck("missing_skills",
+		"Active resources not mapped to any skill")
+
+	for _, row := range rows {
+		existedBefore := row.CreatedAt.Before(periodStart)
+		if row.ConsecutiveFailures > 0 {
+			tallyResource(&brokenLinks, existedBefore, row.ID.String())
+		}
+		if !row.IsActive {
+			continue
+		}
+		if !isValidResourceURL(row.URL) {
+			tallyResource(&invalidURLs, existedBefore, row.ID.String())
+		}
+		if row.SkillCount == 0 {
+			tallyResource(&missingSkills, existedBefore, row.ID.String())
+		}
+	}
+
+	return dataquality.NewSection("learning-resources", now, window,
+		[]dataquality.Check{brokenLinks, invalidURLs, missingSkills})
+}
+
+func newResourceCheck(name, description string) dataquality.Check {
+	return dataquality.NewCheck(name, description, "/api/v1/admin/resources/{id}")
+}
+
+// tallyResource records an affected resource against c.
+func tallyResource(c *dataquality.Check, existedBefore bool, id string) {
+	c.Count++
+	if existedBefore {
+		c.PreviousCount++
+	}
+	c.AddID(id)
+}
+
+// isValidResourceURL reports whether raw is an absolute http or https URL
+// with a host.
+func isValidResourceURL(raw string) bool {
+	u, err := url.Parse(strings.TrimSpace(raw))
+	if err != nil {
+		return false
+	}
+	return (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
@@ -0,0 +1,130 @@
+package admin
+
+import (
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/learnbot/database/repository"
+	"github.com/learnbot/shared/dataquality"
+)
+
+func findCheck(t *testing.T, s dataquality.Section, name string) dataquality.Check {
+	t.Helper()
+	for _, c := range s.Checks {
+		if c.Name == name {
+			return c
+		}
+	}
+	t.Fatalf("check %q not found", name)
+	return dataquality.Check{}
+}
+
+func TestBuildDataQualitySection_SeededResources(t *testing.T) {
+	now := time.Date(2024, 6, 15, 12, 0, 0, 0, time.UTC)
+	old := now.Add(-30 * 24 * time.Hour)
+	recent := now.Add(-24 * time.Hour)
+
+	oldInvalid := repository.ResourceQualityRow{ID: uuid.New(), URL: "not a url", SkillCount: 2, CreatedAt: old, IsActive: true}
+	newInvalid := repository.ResourceQualityRow{ID: uuid.New(), URL: "ftp://files.example.com/x", SkillCount: 1, CreatedAt: recent, IsActive: true}
+	newUnmapped := repository.ResourceQualityRow{ID: uuid.New(), URL: "https://example.com/course", SkillCount: 0, CreatedAt: recent, IsActive: true}
+	oldFailing := repository.ResourceQualityRow{
+		ID: uuid.New(), URL: "https://example.com/gone", SkillCount: 1, CreatedAt: old, IsActive: true, ConsecutiveFailures: 1,
+	}
+	oldDeactivated := repository.ResourceQualityRow{
+		ID: uuid.New(), URL: "https://example.com/404", SkillCount: 0, CreatedAt: old, ConsecutiveFailures: 3,
+	}
+	rows := []repository.ResourceQualityRow{
+		oldInvalid,
+		newInvalid,
+		newUnmapped,
+		oldFailing,
+		oldDeactivated,
+		{ID: uuid.New(), URL: "https://example.com/ok", SkillCount: 3, CreatedAt: old, IsActive: true},
+	}
+
+	s := buildDataQualitySection(rows, now, 7*24*time.Hour)
+
+	if s.Service != "learning-resources" || s.Status != "degraded" {
+		t.Errorf("got service=%q status=%q", s.Service, s.Status)
+	}
+
+	broken := findCheck(t, s, "broken_links")
+	if broken.Count != 2 || broken.PreviousCount != 2 || broken.Trend != "flat" {
+		t.Errorf("broken_links = %d/%d %s, want 2/2 flat", broken.Count, broken.PreviousCount, broken.Trend)
+	}
+	if len(broken.DrillDown.IDs) != 2 ||
+		broken.DrillDown.IDs[0] != oldFailing.ID.String() ||
+		broken.DrillDown.IDs[1] != oldDeactivated.ID.String() {
+		t.Errorf("broken_links drill-down = %v", broken.DrillDown.IDs)
+	}
+
+	invalid := findCheck(t, s, "invalid_urls")
+	if invalid.Count != 2 || invalid.PreviousCount != 1 || invalid.Trend != "up" {
+		t.Errorf("invalid_urls = %d/%d %s, want 2/1 up", invalid.Count, invalid.PreviousCount, invalid.Trend)
+	}
+	if len(invalid.DrillDown.IDs) != 2 ||
+		invalid.DrillDown.IDs[0] != oldInvalid.ID.String() ||
+		invalid.DrillDown.IDs[1] != newInvalid.ID.String() {
+		t.Errorf("invalid_urls drill-down = %v", invalid.DrillDown.IDs)
+	}
+
+	// The deactivated resource has no skills either, but only active
+	// resources count towards the other checks.
+	missing := findCheck(t, s, "missing_skills")
+	if missing.Count != 1 || missing.PreviousCount != 0 || missing.Trend != "up" {
+		t.Errorf("missing_skills = %d/%d %s, want 1/0 up", missing.Count, missing.PreviousCount, missing.Trend)
+	}
+	if len(missing.DrillDown.IDs) != 1 || missing.DrillDown.IDs[0] != newUnmapped.ID.String() {
+		t.Errorf("missing_skills drill-down = %v", missing.DrillDown.IDs)
+	}
+}
+
+func TestBuildDataQualitySection_CleanCatalogIsHealthy(t *testing.T) {
+	rows := []repository.ResourceQualityRow{
+		{ID: uuid.New(), URL: "https://example.com/a", SkillCount: 1, CreatedAt: time.Now(), IsActive: true},
+	}
+	s := buildDataQualitySection(rows, time.Now().UTC(), 7*24*time.Hour)
+	if s.Status != "healthy" {
+		t.Errorf("Status = %q, want healthy", s.Status)
+	}
+	for _, c := range s.Checks {
+		if c.Count != 0 || c.Trend != "flat" {
+			t.Errorf("%s = %d %s, want 0 flat", c.Name, c.Count, c.Trend)
+		}
+	}
+}
+
+func TestIsValidResourceURL(t *testing.T) {
+	tests := []struct {
+		url  string
+		want bool
+	}{
+		{"https://www.coursera.org/learn/go", true},
+		{"http://example.com", true},
+		{"  https://example.com/padded  ", true},
+		{"example.com/no-scheme", false},
+		{"mailto:someone@example.com", false},
+		{"https://", false},
+		{"", false},
+	}
+	for _, tt := range tests {
+		if got := isValidResourceURL(tt.url); got != tt.want {
+			t.Errorf("isValidResourceURL(%q) = %v, want %v", tt.url, got, tt.want)
+		}
+	}
+}
+
+func TestHandleDataQuality_MethodNotAllowed(t *testing.T) {
+	h := &Handler{logger: log.New(io.Discard, "", 0)}
+	req := httptest.NewRequest(http.MethodPost, "/admin/data-quality", nil)
+	w := httptest.NewRecorder()
+	h.handleDataQuality(w, req)
+	if w.Code != http.StatusMethodNotAllowed {
+		t.Errorf("expected 405, got %d", w.Code)
+	}
+}
@@ -0,0 +1,141 @@
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/google/uuid"
+
+	"github.com/learnbot/database/repository"
+	"github.com/learnbot/learning-resources/internal/dedup"
+	"github.com/learnbot/shared/audit"
+)
+
+// mergeRequest is the body of POST /api/v1/admin/resources/merge.
+type mergeRequest struct {
+	SurvivorID   string   `json:"survivor_id"`
+	DuplicateIDs []string `json:"duplicate_ids"`
+}
+
+// handleDuplicates handles GET /api/v1/admin/resources/duplicates
+//
+// Query parameters:
+//   - threshold: title similarity from which resources of the same
+//     provider are reported (default 0.85, between 0 and 1)
+//
+// Resources with the same normalised URL are always reported.
+func (h *Handler) handleDuplicates(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		h.writeError(w, http.StatusMethodNotAllowed, "only GET is supported")
+		return
+	}
+
+	threshold := dedup.DefaultTitleThreshold
+	if t := r.URL.Query().Get("threshold"); t != "" {
+		v, err := strconv.ParseFloat(t, 64)
+		if err != nil || v <= 0 || v > 1 {
+			h.writeError(w, http.StatusBadRequest, "threshold must be a number greater than 0 and at most 1")
+			return
+		}
+		threshold = v
+	}
+
+	resources, err := h.repo.ListDuplicateCandidates(r.Context())
+	if err != nil {
+		h.logger.Printf("list duplicate resources error: %v", err)
+		h.writeError(w, http.StatusInternalServerError, "failed to list duplicate resources")
+		return
+	}
+
+	clusters := dedup.Clusters(resources, threshold)
+	h.writeJSON(w, http.StatusOK, map[string]interface{}{
+		"success": true,
+		"total":   len(clusters),
+		"data":    clusters,
+	})
+}
+
+// handleMerge handles POST /api/v1/admin/resources/merge
+//
+// Request body (JSON):
+//
+//	{
+//	  "survivor_id": "uuid",
+//	  "duplicate_ids": ["uuid", "uuid"]
+//	}
+//
+// The duplicates' skills, learning path steps and user progress move to the
+// survivor and the duplicates are soft-deleted, all in one transaction.
+func (h *Handler) handleMerge(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		h.writeError(w, http.StatusMethodNotAllowed, "only POST is supported")
+		return
+	}
+
+	var req mergeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.writeError(w, http.StatusBadRequest, "invalid request body: "+err.Error())
+		return
+	}
+	survivor, err := uuid.Parse(req.SurvivorID)
+	if err != nil {
+		h.writeError(w, http.StatusBadRequest, "invalid survivor_id")
+		return
+	}
+	if len(req.DuplicateIDs) == 0 {
+		h.writeError(w, http.StatusBadRequest, "duplicate_ids is required")
+		return
+	}
+	duplicates := make([]uuid.UUID, 0, len(req.DuplicateIDs))
+	for _, s := range req.DuplicateIDs {
+		id, err := uuid.Parse(s)
+		if err != nil {
+			h.writeError(w, http.StatusBadRequest, "invalid duplicate ID: "+s)
+			return
+		}
+		duplicates = append(duplicates, id)
+	}
+
+	result, err := h.repo.MergeResources(r.Context(), survivor, duplicates)
+	switch {
+	case errors.Is(err, repository.ErrNotFound):
+		h.writeError(w, http.StatusNotFound, err.Error())
+		return
+	case errors.Is(err, repository.ErrInvalidMerge):
+		h.writeError(w, http.StatusBadRequest, err.Error())
+		return
+	case err != nil:
+		h.logger.Printf("merge resources error: %v", err)
+		h.writeError(w, http.StatusInternalServerError, "failed to merge resources")
+		return
+	}
+
+	audit.Record(r.Context(), audit.Change{
+		Action: "resource.merge", EntityType: "resource", EntityID: survivor.String(), After: result,
+	})
+	for _, id := range result.MergedIDs {
+		audit.Record(r.Context(), audit.Change{
+			Action: "resource.delete", EntityType: "resource", EntityID: id.String(),
+			Before: activeFlag(true), After: activeFlag(false),
+		})
+	}
+
+	h.writeJSON(w, http.StatusOK, map[string]interface{}{
+		"success": true,
+		"data":    result,
+	})
+}
+
+// findDuplicates returns the active resources that a resource about to be
+// created likely duplicates.
+func (h *Handler) findDuplicates(ctx context.Context, input repository.CreateResourceInput) ([]dedup.Match, error) {
+	existing, err := h.repo.ListDuplicateCandidates(ctx)
+	if err != nil {
+		return nil, err
+	}
+	candidate := repository.DuplicateCandidate{Title: input.Title, URL: input.URL, ProviderID: input.ProviderID}
+	return dedup.Find(candidate, existing, dedup.DefaultTitleThreshold), nil
+}
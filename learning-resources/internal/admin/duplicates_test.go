@@ -0,0 +1,170 @@
+package admin
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+
+	"github.com/learnbot/database/repository"
+	"github.com/learnbot/learning-resources/internal/dedup"
+)
+
+// candidateColumns are the columns returned by ListDuplicateCandidates.
+var candidateColumns = []string{"id", "title", "slug", "url", "provider_id", "created_at"}
+
+func TestHandleDuplicates(t *testing.T) {
+	h, mock := newMockHandler(t)
+	provider := uuid.New()
+	older, newer, other := uuid.New(), uuid.New(), uuid.New()
+	now := time.Now()
+
+	mock.ExpectQuery("SELECT id, title, slug, url, provider_id, created_at FROM learning_resources").
+		WillReturnRows(sqlmock.NewRows(candidateColumns).
+			AddRow(older, "Go: The Complete Guide", "go-1", "https://www.udemy.com/course/go/", provider, now.Add(-time.Hour)).
+			AddRow(newer, "Go - The Complete Guide", "go-2", "http://udemy.com/course/go?utm_source=x", provider, now).
+			AddRow(other, "Docker Mastery", "docker", "https://www.udemy.com/course/docker/", provider, now))
+	w := httptest.NewRecorder()
+	h.handleDuplicates(w, httptest.NewRequest(http.MethodGet, "/api/v1/admin/resources/duplicates", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var resp struct {
+		Total int             `json:"total"`
+		Data  []dedup.Cluster `json:"data"`
+	}
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if resp.Total != 1 || len(resp.Data[0].Resources) != 2 || resp.Data[0].SurvivorID != older {
+		t.Errorf("unexpected clusters: %+v", resp)
+	}
+
+	for _, q := range []string{"?threshold=0", "?threshold=1.5", "?threshold=high"} {
+		w := httptest.NewRecorder()
+		h.handleDuplicates(w, httptest.NewRequest(http.MethodGet, "/api/v1/admin/resources/duplicates"+q, nil))
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("%s: expected 400, got %d", q, w.Code)
+		}
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestHandleMerge(t *testing.T) {
+	survivor, dup := uuid.New(), uuid.New()
+	body := `{"survivor_id":"` + survivor.String() + `","duplicate_ids":["` + dup.String() + `"]}`
+	expectLock := func(mock sqlmock.Sqlmock, ids ...uuid.UUID) {
+		rows := sqlmock.NewRows([]string{"id", "is_active"})
+		for _, id := range ids {
+			rows.AddRow(id, true)
+		}
+		mock.ExpectBegin()
+		mock.ExpectQuery("FOR UPDATE").WillReturnRows(rows)
+	}
+
+	tests := []struct {
+		name   string
+		method string
+		body   string
+		expect func(sqlmock.Sqlmock)
+		want   int
+	}{
+		{
+			name: "merged",
+			body: body,
+			expect: func(mock sqlmock.Sqlmock) {
+				expectLock(mock, survivor, dup)
+				for i := 0; i < 8; i++ {
+					mock.ExpectExec("").WillReturnResult(sqlmock.NewResult(0, 1))
+				}
+				mock.ExpectExec("UPDATE user_path_progress").WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectCommit()
+			},
+			want: http.StatusOK,
+		},
+		{
+			name: "unknown resource",
+			body: body,
+			expect: func(mock sqlmock.Sqlmock) {
+				expectLock(mock, survivor)
+				mock.ExpectRollback()
+			},
+			want: http.StatusNotFound,
+		},
+		{
+			name: "into itself",
+			body: `{"survivor_id":"` + survivor.String() + `","duplicate_ids":["` + survivor.String() + `"]}`,
+			want: http.StatusBadRequest,
+		},
+		{name: "no duplicates", body: `{"survivor_id":"` + survivor.String() + `"}`, want: http.StatusBadRequest},
+		{name: "bad survivor", body: `{"survivor_id":"x","duplicate_ids":["` + dup.String() + `"]}`, want: http.StatusBadRequest},
+		{name: "bad duplicate", body: `{"survivor_id":"` + survivor.String() + `","duplicate_ids":["x"]}`, want: http.StatusBadRequest},
+		{name: "wrong method", method: http.MethodGet, want: http.StatusMethodNotAllowed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h, mock := newMockHandler(t)
+			if tt.expect != nil {
+				tt.expect(mock)
+			}
+			method := tt.method
+			if method == "" {
+				method = http.MethodPost
+			}
+			w := httptest.NewRecorder()
+			h.handleMerge(w, httptest.NewRequest(method, "/api/v1/admin/resources/merge", strings.NewReader(tt.body)))
+			if w.Code != tt.want {
+				t.Fatalf("expected %d, got %d: %s", tt.want, w.Code, w.Body.String())
+			}
+			if tt.want == http.StatusOK {
+				var resp struct {
+					Data repository.MergeResult `json:"data"`
+				}
+				if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+					t.Fatalf("decode: %v", err)
+				}
+				if resp.Data.SurvivorID != survivor || len(resp.Data.MergedIDs) != 1 || resp.Data.SkillsMoved != 1 {
+					t.Errorf("unexpected result %+v", resp.Data)
+				}
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func TestCreateResource_DuplicateURL(t *testing.T) {
+	h, mock := newMockHandler(t)
+	existing := uuid.New()
+	mock.ExpectQuery("FROM learning_resources").
+		WillReturnRows(sqlmock.NewRows(candidateColumns).
+			AddRow(existing, "Go Course", "go-course", "https://www.udemy.com/course/go/", nil, time.Now()))
+
+	w := httptest.NewRecorder()
+	h.handleAdminResources(w, httptest.NewRequest(http.MethodPost, "/api/v1/admin/resources",
+		strings.NewReader(`{"title":"Go for Everyone","url":"http://udemy.com/course/go/?utm_campaign=spring"}`)))
+	if w.Code != http.StatusConflict {
+		t.Fatalf("expected 409, got %d: %s", w.Code, w.Body.String())
+	}
+	var resp struct {
+		Error      string        `json:"error"`
+		Duplicates []dedup.Match `json:"duplicates"`
+	}
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if len(resp.Duplicates) != 1 || resp.Duplicates[0].ResourceID != existing || !strings.Contains(resp.Error, existing.String()) {
+		t.Errorf("unexpected response %+v", resp)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
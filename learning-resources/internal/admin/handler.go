@@ -4,20 +4,38 @@ package admin
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
 	"net/http"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/learnbot/database/repository"
+	"github.com/learnbot/learning-resources/internal/coverage"
+	"github.com/learnbot/learning-resources/internal/dedup"
+	"github.com/learnbot/shared/adminauth"
+	"github.com/learnbot/shared/audit"
+	"github.com/learnbot/shared/dataquality"
+	"github.com/learnbot/shared/idempotency"
+	"github.com/learnbot/shared/openapi"
+	"github.com/learnbot/shared/pagination"
+	"github.com/learnbot/shared/validation"
 )
 
 // Handler holds the HTTP handler dependencies for the admin API.
 type Handler struct {
-	repo   *repository.LearningResourceRepository
-	logger *log.Logger
+	repo        *repository.LearningResourceRepository
+	linkChecker LinkRechecker
+	auth        *adminauth.Middleware
+	logger      *log.Logger
+	validation  validation.Config
+	idempotency *idempotency.Middleware
+	audit       *audit.Recorder
+
+	coverageSources []coverage.Source
 }
 
 // NewHandler creates a new admin Handler.
@@ -25,23 +43,361 @@ func NewHandler(repo *repository.LearningResourceRepository, logger *log.Logger)
 	return &Handler{repo: repo, logger: logger}
 }
 
+// SetAuth requires admin credentials on every admin route.
+func (h *Handler) SetAuth(auth *adminauth.Middleware) {
+	h.auth = auth
+}
+
+// SetValidationConfig sets how resource creation requests are decoded. By
+// default unknown fields are rejected.
+func (h *Handler) SetValidationConfig(cfg validation.Config) {
+	h.validation = cfg
+}
+
+// SetIdempotency replays the stored response to mutating requests retried
+// with the same Idempotency-Key by the same admin caller. A nil middleware
+// disables replays.
+func (h *Handler) SetIdempotency(m *idempotency.Middleware) {
+	h.idempotency = m
+}
+
 // RegisterRoutes registers all admin routes on the given mux.
 //
-// Admin endpoints (should be protected by authentication middleware):
+// Admin endpoints (authenticated once SetAuth is called):
 //
+//	GET    /api/v1/admin/resources           – list resources (?include_inactive=true)
 //	POST   /api/v1/admin/resources           – create a new resource
+//	POST   /api/v1/admin/resources/import    – bulk import resources (CSV/JSON)
+//	GET    /api/v1/admin/resources/broken    – resources whose link checks fail
+//	GET    /api/v1/admin/resources/duplicates – suspected duplicate resources
+//	POST   /api/v1/admin/resources/merge     – merge duplicates into one resource
+//	POST   /api/v1/admin/resources/{id}/recheck – check a resource link now
 //	PUT    /api/v1/admin/resources/{id}      – update a resource
 //	DELETE /api/v1/admin/resources/{id}      – soft-delete a resource
+//	POST   /api/v1/admin/resources/{id}/restore – restore a soft-deleted resource
+//	DELETE /api/v1/admin/resources/{id}/purge   – permanently delete a resource
 //	POST   /api/v1/admin/providers           – create a new provider
 //	POST   /api/v1/admin/paths               – create a new learning path
-func (h *Handler) RegisterRoutes(mux *http.ServeMux) {
+//	PUT    /api/v1/admin/paths/{id}          – update a learning path
+//	DELETE /api/v1/admin/paths/{id}          – soft-delete a learning path
+//	PUT    /api/v1/admin/paths/{id}/resources – replace a path's ordered resources
+//	GET    /api/v1/admin/reviews/reported    – reported reviews awaiting moderation
+//	POST   /api/v1/admin/reviews/{id}/hide   – hide a review
+//	POST   /api/v1/admin/reviews/{id}/unhide – show a hidden review again
+//	GET    /api/v1/admin/audit-log           – changes made through these routes
+//	GET    /admin/data-quality               – data quality dashboard section
+//	GET    /admin/providers/stats            – per-provider catalog and progress stats
+//	GET    /admin/skills/coverage            – in-demand skills with thin or no coverage
+//	DELETE /admin/users/{id}                 – erase a deleted account's data
+func (h *Handler) RegisterRoutes(mux openapi.Router) {
 	mux.HandleFunc("/api/v1/admin/resources", h.withMiddleware(h.handleAdminResources))
+	mux.HandleFunc("/api/v1/admin/resources/import", h.withMiddleware(h.handleAdminImport))
+	mux.HandleFunc("/api/v1/admin/resources/broken", h.withMiddleware(h.handleBrokenResources))
+	mux.HandleFunc("/api/v1/admin/resources/duplicates", h.withMiddleware(h.handleDuplicates))
+	mux.HandleFunc("/api/v1/admin/resources/merge", h.withMiddleware(h.handleMerge))
 	mux.HandleFunc("/api/v1/admin/resources/", h.withMiddleware(h.handleAdminResourceByID))
 	mux.HandleFunc("/api/v1/admin/providers", h.withMiddleware(h.handleAdminProviders))
 	mux.HandleFunc("/api/v1/admin/paths", h.withMiddleware(h.handleAdminPaths))
+	mux.HandleFunc("/api/v1/admin/paths/", h.withMiddleware(h.handleAdminPathByID))
+	mux.HandleFunc("/api/v1/admin/reviews/reported", h.withMiddleware(h.handleReportedReviews))
+	mux.HandleFunc("/api/v1/admin/reviews/", h.withMiddleware(h.handleAdminReviewByID))
+	mux.HandleFunc("/api/v1/admin/audit-log", h.withMiddleware(h.handleAuditLog))
+	mux.HandleFunc("/admin/data-quality", h.withMiddleware(h.handleDataQuality))
+	mux.HandleFunc("/admin/providers/stats", h.withMiddleware(h.handleProviderStats))
+	mux.HandleFunc("/admin/skills/coverage", h.withMiddleware(h.handleSkillCoverage))
+	mux.HandleFunc("/admin/users/", h.withMiddleware(h.handleEraseUser))
+}
+
+// DescribeRoutes documents the routes registered by RegisterRoutes.
+func (h *Handler) DescribeRoutes(spec *openapi.Spec) {
+	admin := []string{openapi.AdminAPIKey, openapi.BearerAuth}
+	limit := openapi.Param{Name: "limit", Description: "Maximum number of results", Type: 0}
+	offset := openapi.Param{Name: "offset", Description: "Number of results to skip", Type: 0}
+	denied := []int{http.StatusUnauthorized, http.StatusForbidden}
+	errs := func(codes ...int) []int { return append(codes, denied...) }
+
+	spec.Route("/api/v1/admin/resources",
+		openapi.Operation{
+			Method:   http.MethodGet,
+			Summary:  "List resources, including inactive ones on request",
+			Security: admin,
+			Params: []openapi.Param{
+				openapi.Query("q", "Full-text search query"),
+				openapi.Query("skill", "Filter by skill name"),
+				openapi.Query("include_inactive", `"true" to include soft-deleted resources`),
+				limit, offset,
+			},
+			Response: openapi.Fields{
+				"success":    true,
+				"data":       []repository.LearningResourceWithSkills{},
+				"pagination": pagination.Pagination{},
+			},
+			Errors: denied,
+		},
+		openapi.Operation{
+			Method:  http.MethodPost,
+			Summary: "Create a resource",
+			Description: "A resource with the same normalised URL as an active resource is rejected with 409 " +
+				"Conflict and the conflicting resources. Resources of the same provider with a similar title " +
+				"are created, and listed in duplicates.",
+			Security: admin,
+			Params:   []openapi.Param{openapi.Query("allow_duplicate", `"true" to create a resource whose URL is already in the catalog`)},
+			Request:  createResourceRequest{},
+			Response: openapi.Fields{
+				"success": true, "slug": "", "data": repository.LearningResource{}, "duplicates": []dedup.Match{},
+			},
+			Status: http.StatusCreated,
+			Errors: errs(http.StatusBadRequest, http.StatusConflict),
+		},
+	)
+	spec.Route("/api/v1/admin/resources/import", openapi.Operation{
+		Method:      http.MethodPost,
+		Summary:     "Bulk import resources",
+		Description: "The body is a CSV file with a header row, or a JSON array of resources.",
+		Security:    admin,
+		Params: []openapi.Param{
+			openapi.Query("mode", `"all_or_nothing" (default) or "best_effort"`),
+			openapi.Query("dry_run", `"true" to validate every row without writing`),
+		},
+		Request: []importResourceRequest{},
+		Response: openapi.Fields{
+			"success": true, "mode": "", "dry_run": false, "committed": false,
+			"total": 0, "imported": 0, "failed": 0, "rows": []importRowResult{},
+		},
+		Errors: errs(http.StatusBadRequest, http.StatusRequestEntityTooLarge,
+			http.StatusUnsupportedMediaType, http.StatusUnprocessableEntity),
+	})
+	spec.Route("/api/v1/admin/resources/broken", openapi.Operation{
+		Method:   http.MethodGet,
+		Summary:  "List resources whose link checks fail",
+		Security: admin,
+		Params:   []openapi.Param{limit, offset},
+		Response: openapi.Fields{
+			"success":    true,
+			"data":       []repository.ResourceLinkStatus{},
+			"pagination": pagination.Pagination{},
+		},
+		Errors: denied,
+	})
+	spec.Route("/api/v1/admin/resources/duplicates", openapi.Operation{
+		Method:  http.MethodGet,
+		Summary: "List clusters of suspected duplicate resources",
+		Description: "Active resources are clustered when their URLs are equal once tracking parameters, " +
+			"the scheme and trailing slashes are dropped, or when resources of the same provider have " +
+			"similar titles. Each cluster suggests its oldest resource as the survivor.",
+		Security: admin,
+		Params: []openapi.Param{
+			{Name: "threshold", Description: "Title similarity from which resources are reported (default 0.85)", Type: 0.0},
+		},
+		Response: openapi.Fields{"success": true, "total": 0, "data": []dedup.Cluster{}},
+		Errors:   errs(http.StatusBadRequest),
+	})
+	spec.Route("/api/v1/admin/resources/merge", openapi.Operation{
+		Method:  http.MethodPost,
+		Summary: "Merge duplicate resources into a surviving resource",
+		Description: "Moves the duplicates' skills, learning path steps and user progress to the survivor " +
+			"and soft-deletes the duplicates, in a single transaction.",
+		Security: admin,
+		Request:  mergeRequest{},
+		Response: openapi.Fields{"success": true, "data": repository.MergeResult{}},
+		Errors:   errs(http.StatusBadRequest, http.StatusNotFound),
+	})
+	spec.Route("/api/v1/admin/resources/",
+		openapi.Operation{
+			Method:   http.MethodPut,
+			Path:     "/api/v1/admin/resources/{id}",
+			Summary:  "Update a resource",
+			Security: admin,
+			Request:  updateResourceRequest{},
+			Response: openapi.Fields{"success": true, "data": repository.LearningResource{}},
+			Errors:   errs(http.StatusBadRequest, http.StatusNotFound),
+		},
+		openapi.Operation{
+			Method:   http.MethodDelete,
+			Path:     "/api/v1/admin/resources/{id}",
+			Summary:  "Soft-delete a resource",
+			Security: admin,
+			Response: openapi.Fields{"success": true, "message": ""},
+			Errors:   errs(http.StatusBadRequest, http.StatusNotFound),
+		},
+		openapi.Operation{
+			Method:   http.MethodPost,
+			Path:     "/api/v1/admin/resources/{id}/restore",
+			Summary:  "Restore a soft-deleted resource",
+			Security: admin,
+			Response: openapi.Fields{"success": true, "data": repository.LearningResource{}},
+			Errors:   errs(http.StatusBadRequest, http.StatusNotFound),
+		},
+		openapi.Operation{
+			Method:   http.MethodDelete,
+			Path:     "/api/v1/admin/resources/{id}/purge",
+			Summary:  "Permanently delete a resource",
+			Security: admin,
+			Params:   []openapi.Param{openapi.Query("force", `"true" to purge a resource that is part of a learning path`)},
+			Response: openapi.Fields{"success": true, "message": "", "data": repository.PurgeResult{}},
+			Errors:   errs(http.StatusBadRequest, http.StatusNotFound, http.StatusConflict),
+		},
+		openapi.Operation{
+			Method:   http.MethodPost,
+			Path:     "/api/v1/admin/resources/{id}/recheck",
+			Summary:  "Check a resource link now",
+			Security: admin,
+			Response: openapi.Fields{"success": true, "data": repository.ResourceLinkStatus{}},
+			Errors:   errs(http.StatusBadRequest, http.StatusNotFound, http.StatusServiceUnavailable),
+		},
+	)
+	spec.Route("/api/v1/admin/providers", openapi.Operation{
+		Method:   http.MethodPost,
+		Summary:  "Create a provider",
+		Security: admin,
+		Request:  createProviderRequest{},
+		Response: openapi.Fields{"success": true, "data": repository.ResourceProvider{}},
+		Status:   http.StatusCreated,
+		Errors:   errs(http.StatusBadRequest),
+	})
+	spec.Route("/api/v1/admin/paths", openapi.Operation{
+		Method:   http.MethodPost,
+		Summary:  "Create a learning path",
+		Security: admin,
+		Request:  createPathRequest{},
+		Response: openapi.Fields{"success": true, "data": repository.LearningPathWithResources{}},
+		Status:   http.StatusCreated,
+		Errors:   errs(http.StatusBadRequest, http.StatusConflict),
+	})
+	spec.Route("/api/v1/admin/paths/",
+		openapi.Operation{
+			Method:   http.MethodPut,
+			Path:     "/api/v1/admin/paths/{id}",
+			Summary:  "Update a learning path",
+			Security: admin,
+			Request:  updatePathRequest{},
+			Response: openapi.Fields{"success": true, "data": repository.LearningPathWithResources{}},
+			Errors:   errs(http.StatusBadRequest, http.StatusNotFound, http.StatusConflict),
+		},
+		openapi.Operation{
+			Method:   http.MethodDelete,
+			Path:     "/api/v1/admin/paths/{id}",
+			Summary:  "Soft-delete a learning path",
+			Security: admin,
+			Response: openapi.Fields{"success": true, "message": ""},
+			Errors:   errs(http.StatusBadRequest, http.StatusNotFound),
+		},
+		openapi.Operation{
+			Method:   http.MethodPut,
+			Path:     "/api/v1/admin/paths/{id}/resources",
+			Summary:  "Replace a learning path's ordered resources",
+			Security: admin,
+			Request:  setPathResourcesRequest{},
+			Response: openapi.Fields{"success": true, "data": repository.LearningPathWithResources{}},
+			Errors:   errs(http.StatusBadRequest, http.StatusNotFound),
+		},
+	)
+	spec.Route("/api/v1/admin/reviews/reported", openapi.Operation{
+		Method:   http.MethodGet,
+		Summary:  "List reported reviews awaiting moderation",
+		Security: admin,
+		Params:   []openapi.Param{limit, offset},
+		Response: openapi.Fields{
+			"success":    true,
+			"data":       []repository.ResourceReview{},
+			"pagination": pagination.Pagination{},
+		},
+		Errors: denied,
+	})
+	spec.Route("/api/v1/admin/reviews/",
+		openapi.Operation{
+			Method:      http.MethodPost,
+			Path:        "/api/v1/admin/reviews/{id}/hide",
+			Summary:     "Hide a review",
+			Description: "Hidden reviews are not listed and do not count towards the resource rating.",
+			Security:    admin,
+			Response:    openapi.Fields{"success": true, "data": repository.ResourceReview{}},
+			Errors:      errs(http.StatusBadRequest, http.StatusNotFound),
+		},
+		openapi.Operation{
+			Method:   http.MethodPost,
+			Path:     "/api/v1/admin/reviews/{id}/unhide",
+			Summary:  "Show a hidden review again",
+			Security: admin,
+			Response: openapi.Fields{"success": true, "data": repository.ResourceReview{}},
+			Errors:   errs(http.StatusBadRequest, http.StatusNotFound),
+		},
+	)
+	spec.Route("/admin/data-quality", openapi.Operation{
+		Method:   http.MethodGet,
+		Summary:  "Resource catalog data quality checks",
+		Security: admin,
+		Params:   []openapi.Param{{Name: "days", Description: "Window in days (default 7)", Type: 0}},
+		Response: openapi.Fields{"success": true, "data": dataquality.Section{}},
+		Errors:   denied,
+	})
+	spec.Route("/admin/providers/stats", openapi.Operation{
+		Method:  http.MethodGet,
+		Summary: "Per-provider resource, rating, skill and progress stats",
+		Description: "Aggregates the active resources of every active provider and the user progress " +
+			"recorded against them. Enrollments count progress rows of any status; the completion rate " +
+			"is completions divided by enrollments.",
+		Security: admin,
+		Params: []openapi.Param{
+			openapi.Query("sort", "provider_name, resource_count (default), average_rating, skills_covered, "+
+				"enrollments, completions, users_completed or completion_rate"),
+			openapi.Query("order", `"asc" or "desc" (default, except for provider_name)`),
+		},
+		Response: openapi.Fields{"success": true, "total": 0, "data": []repository.ProviderStats{}},
+		Errors:   errs(http.StatusBadRequest),
+	})
+	spec.Route("/admin/skills/coverage", openapi.Operation{
+		Method:  http.MethodGet,
+		Summary: "In-demand skills with no or thin resource coverage",
+		Description: "Cross-references the skill taxonomy and, when configured, the skills of recent job " +
+			"postings against the skills of active resources. Lists skills without resources, or with fewer " +
+			"than min_resources, none free or none for beginners, most demanded first, with the criteria " +
+			"applied. Answers text/csv instead when the Accept header prefers it.",
+		Security: admin,
+		Params: []openapi.Param{
+			{Name: "min_resources", Description: "Resources below which a skill is thin (default 3)", Type: 0},
+			openapi.Query("require_free", `"false" to not report skills only for lacking a free resource`),
+			openapi.Query("require_beginner", `"false" to not report skills only for lacking a beginner resource`),
+			openapi.Query("status", `"missing" or "thin" to list only those skills`),
+		},
+		Response: openapi.Fields{"success": true, "data": coverage.Report{}},
+		Errors:   errs(http.StatusBadRequest),
+	})
+	spec.Route("/admin/users/", openapi.Operation{
+		Method:  http.MethodDelete,
+		Path:    "/admin/users/{id}",
+		Summary: "Erase a deleted user's progress, reviews and helpful votes",
+		Description: "The ratings of the resources the user rated and the helpful counts of the reviews " +
+			"they voted for are updated. Idempotent: erasing a user with no data succeeds.",
+		Security: admin,
+		Response: openapi.Fields{"success": true, "data": repository.UserErasure{}},
+		Errors:   errs(http.StatusBadRequest, http.StatusInternalServerError),
+	})
+	spec.Route("/api/v1/admin/audit-log", openapi.Operation{
+		Method:   http.MethodGet,
+		Summary:  "List changes made through the admin API, newest first",
+		Security: admin,
+		Params: []openapi.Param{
+			openapi.Query("actor", `Filter by admin user ID, or "api_key"`),
+			openapi.Query("entity_type", "Filter by entity type, e.g. resource"),
+			openapi.Query("entity_id", "Filter by entity ID"),
+			openapi.Query("from", "Made at or after, RFC 3339 timestamp or YYYY-MM-DD date"),
+			openapi.Query("to", "Made before, RFC 3339 timestamp or YYYY-MM-DD date (inclusive)"),
+			limit, offset,
+		},
+		Response: openapi.Fields{
+			"success":    true,
+			"data":       []audit.Entry{},
+			"pagination": pagination.Pagination{},
+		},
+		Errors: errs(http.StatusBadRequest, http.StatusServiceUnavailable),
+	})
 }
 
-// withMiddleware wraps a handler with logging and panic recovery.
+// withMiddleware wraps a handler with logging, panic recovery and, once
+// SetAuth, SetIdempotency and SetAuditLog are called, admin authentication,
+// idempotency key handling and audit logging. Replayed responses are not
+// audited again.
 func (h *Handler) withMiddleware(next http.HandlerFunc) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		start := time.Now()
@@ -52,22 +408,100 @@ func (h *Handler) withMiddleware(next http.HandlerFunc) http.HandlerFunc {
 			}
 		}()
 		h.logger.Printf("[ADMIN] %s %s %s", r.Method, r.URL.Path, r.RemoteAddr)
-		next(w, r)
+		handle := next
+		if h.audit != nil {
+			handle = h.audit.WrapFunc(handle)
+		}
+		if h.idempotency != nil {
+			handle = h.idempotency.WrapFunc(principalScope, handle)
+		}
+		if h.auth != nil {
+			h.auth.WrapFunc(handle)(w, r)
+		} else {
+			handle(w, r)
+		}
 		h.logger.Printf("[ADMIN] %s %s completed in %v", r.Method, r.URL.Path, time.Since(start))
 	}
 }
 
+// principalScope scopes idempotency keys by the authenticated admin caller.
+func principalScope(r *http.Request) string {
+	p, ok := adminauth.PrincipalFrom(r.Context())
+	if !ok {
+		return ""
+	}
+	return p.Method + ":" + p.UserID
+}
+
 // ─────────────────────────────────────────────────────────────────────────────
 // Resource admin handlers
 // ─────────────────────────────────────────────────────────────────────────────
 
-// handleAdminResources handles POST /api/v1/admin/resources
+// handleAdminResources handles GET and POST /api/v1/admin/resources
+func (h *Handler) handleAdminResources(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		h.listResources(w, r)
+	case http.MethodPost:
+		h.createResource(w, r)
+	default:
+		h.writeError(w, http.StatusMethodNotAllowed, "only GET and POST are supported")
+	}
+}
+
+// listResources handles GET /api/v1/admin/resources
+//
+// Query parameters:
+//   - q: full-text search query
+//   - skill: filter by skill name
+//   - include_inactive: "true" to include soft-deleted resources
+//   - limit: max results (default 20, max 100)
+//   - offset: pagination offset
+func (h *Handler) listResources(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	filter := repository.ResourceQueryFilter{
+		SkillName:       q.Get("skill"),
+		SearchQuery:     q.Get("q"),
+		IncludeInactive: q.Get("include_inactive") == "true",
+		Limit:           20,
+	}
+	if l := q.Get("limit"); l != "" {
+		if v, err := strconv.Atoi(l); err == nil && v > 0 {
+			filter.Limit = min(v, 100)
+		}
+	}
+	if o := q.Get("offset"); o != "" {
+		if v, err := strconv.Atoi(o); err == nil && v > 0 {
+			filter.Offset = v
+		}
+	}
+
+	resources, total, err := h.repo.List(r.Context(), filter)
+	if err != nil {
+		h.logger.Printf("list resources error: %v", err)
+		h.writeError(w, http.StatusInternalServerError, "failed to list resources")
+		return
+	}
+	if resources == nil {
+		resources = []repository.LearningResourceWithSkills{}
+	}
+
+	page := pagination.New(total, filter.Limit, filter.Offset)
+	pagination.SetLinks(w.Header(), r.URL, page)
+	h.writeJSON(w, http.StatusOK, map[string]interface{}{
+		"success":    true,
+		"data":       resources,
+		"pagination": page,
+	})
+}
+
+// createResource handles POST /api/v1/admin/resources
 //
 // Request body (JSON):
 //
 //	{
 //	  "title": "...",
-//	  "slug": "...",
+//	  "slug": "...",          // optional; generated from the title if empty
 //	  "description": "...",
 //	  "url": "...",
 //	  "provider_id": "uuid",
@@ -83,40 +517,74 @@ func (h *Handler) withMiddleware(next http.HandlerFunc) http.HandlerFunc {
 //	    {"skill_name": "Python", "is_primary": true, "coverage_level": "intermediate"}
 //	  ]
 //	}
-func (h *Handler) handleAdminResources(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodPost {
-		h.writeError(w, http.StatusMethodNotAllowed, "only POST is supported")
-		return
-	}
-
+//
+// The response includes the final slug. Invalid fields, including unknown
+// enum values, return 422 with an errors array; an explicit slug that is
+// already in use returns 409 Conflict.
+//
+// A URL that normalises to the URL of an active resource returns 409
+// Conflict listing the conflicting resources in duplicates, unless
+// ?allow_duplicate=true is given. Active resources of the same provider
+// with a similar title do not block the creation; they are listed in the
+// response's duplicates as a warning.
+func (h *Handler) createResource(w http.ResponseWriter, r *http.Request) {
 	var req createResourceRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+	if err := h.validation.Decode(r.Body, &req); err != nil {
+		if errs, ok := validation.AsErrors(err); ok {
+			h.writeValidationError(w, errs)
+			return
+		}
 		h.writeError(w, http.StatusBadRequest, "invalid request body: "+err.Error())
 		return
 	}
 
-	if err := req.validate(); err != nil {
-		h.writeError(w, http.StatusBadRequest, err.Error())
+	input := req.toInput()
+	duplicates, err := h.findDuplicates(r.Context(), input)
+	if err != nil {
+		h.logger.Printf("create resource error: %v", err)
+		h.writeError(w, http.StatusInternalServerError, "failed to create resource")
 		return
 	}
+	if len(duplicates) > 0 && duplicates[0].Reason == dedup.ReasonSameURL && r.URL.Query().Get("allow_duplicate") != "true" {
+		h.writeJSON(w, http.StatusConflict, map[string]interface{}{
+			"success":    false,
+			"error":      fmt.Sprintf("a resource with this URL already exists: %s", duplicates[0].ResourceID),
+			"duplicates": duplicates,
+		})
+		return
+	}
+	if duplicates == nil {
+		duplicates = []dedup.Match{}
+	}
 
-	input := req.toInput()
 	resource, err := h.repo.Create(r.Context(), input)
+	if errors.Is(err, repository.ErrSlugTaken) {
+		h.writeError(w, http.StatusConflict, fmt.Sprintf("slug %q is already taken", input.Slug))
+		return
+	}
 	if err != nil {
 		h.logger.Printf("create resource error: %v", err)
 		h.writeError(w, http.StatusInternalServerError, "failed to create resource")
 		return
 	}
 
+	audit.Record(r.Context(), audit.Change{
+		Action: "resource.create", EntityType: "resource", EntityID: resource.ID.String(), After: resource,
+	})
 	h.writeJSON(w, http.StatusCreated, map[string]interface{}{
-		"success": true,
-		"data":    resource,
+		"success":    true,
+		"slug":       resource.Slug,
+		"data":       resource,
+		"duplicates": duplicates,
 	})
 }
 
-// handleAdminResourceByID handles PUT/DELETE /api/v1/admin/resources/{id}
+// handleAdminResourceByID handles PUT/DELETE /api/v1/admin/resources/{id},
+// POST /api/v1/admin/resources/{id}/recheck, POST .../{id}/restore and
+// DELETE .../{id}/purge
 func (h *Handler) handleAdminResourceByID(w http.ResponseWriter, r *http.Request) {
 	idStr := strings.TrimPrefix(r.URL.Path, "/api/v1/admin/resources/")
+	idStr, action, _ := strings.Cut(idStr, "/")
 	if idStr == "" {
 		h.writeError(w, http.StatusBadRequest, "resource ID is required")
 		return
@@ -128,6 +596,22 @@ func (h *Handler) handleAdminResourceByID(w http.ResponseWriter, r *http.Request
 		return
 	}
 
+	switch action {
+	case "":
+	case "recheck":
+		h.recheckResource(w, r, id)
+		return
+	case "restore":
+		h.restoreResource(w, r, id)
+		return
+	case "purge":
+		h.purgeResource(w, r, id)
+		return
+	default:
+		h.writeError(w, http.StatusNotFound, "not found")
+		return
+	}
+
 	switch r.Method {
 	case http.MethodPut:
 		h.updateResource(w, r, id)
@@ -145,6 +629,16 @@ func (h *Handler) updateResource(w http.ResponseWriter, r *http.Request, id uuid
 		return
 	}
 
+	var before *repository.LearningResource
+	if h.auditing() {
+		var err error
+		if before, err = h.repo.GetByID(r.Context(), id); err != nil {
+			h.logger.Printf("update resource error: %v", err)
+			h.writeError(w, http.StatusInternalServerError, "failed to update resource")
+			return
+		}
+	}
+
 	input := req.toInput()
 	resource, err := h.repo.Update(r.Context(), id, input)
 	if err != nil {
@@ -156,6 +650,9 @@ func (h *Handler) updateResource(w http.ResponseWriter, r *http.Request, id uuid
 		h.writeError(w, http.StatusNotFound, "resource not found")
 		return
 	}
+	audit.Record(r.Context(), audit.Change{
+		Action: "resource.update", EntityType: "resource", EntityID: id.String(), Before: before, After: resource,
+	})
 
 	h.writeJSON(w, http.StatusOK, map[string]interface{}{
 		"success": true,
@@ -169,6 +666,10 @@ func (h *Handler) deleteResource(w http.ResponseWriter, r *http.Request, id uuid
 		h.writeError(w, http.StatusInternalServerError, "failed to delete resource")
 		return
 	}
+	audit.Record(r.Context(), audit.Change{
+		Action: "resource.delete", EntityType: "resource", EntityID: id.String(),
+		Before: activeFlag(true), After: activeFlag(false),
+	})
 
 	h.writeJSON(w, http.StatusOK, map[string]interface{}{
 		"success": true,
@@ -176,6 +677,72 @@ func (h *Handler) deleteResource(w http.ResponseWriter, r *http.Request, id uuid
 	})
 }
 
+// restoreResource handles POST /api/v1/admin/resources/{id}/restore
+func (h *Handler) restoreResource(w http.ResponseWriter, r *http.Request, id uuid.UUID) {
+	if r.Method != http.MethodPost {
+		h.writeError(w, http.StatusMethodNotAllowed, "only POST is supported")
+		return
+	}
+
+	resource, err := h.repo.Restore(r.Context(), id)
+	if err != nil {
+		h.logger.Printf("restore resource error: %v", err)
+		h.writeError(w, http.StatusInternalServerError, "failed to restore resource")
+		return
+	}
+	if resource == nil {
+		h.writeError(w, http.StatusNotFound, "resource not found")
+		return
+	}
+	audit.Record(r.Context(), audit.Change{
+		Action: "resource.restore", EntityType: "resource", EntityID: id.String(),
+		Before: activeFlag(false), After: activeFlag(true),
+	})
+
+	h.writeJSON(w, http.StatusOK, map[string]interface{}{
+		"success": true,
+		"data":    resource,
+	})
+}
+
+// purgeResource handles DELETE /api/v1/admin/resources/{id}/purge
+//
+// The resource is deleted permanently with its skills. A resource that is a
+// step of a learning path returns 409 Conflict unless ?force=true is given,
+// in which case it is removed from those paths as well.
+func (h *Handler) purgeResource(w http.ResponseWriter, r *http.Request, id uuid.UUID) {
+	if r.Method != http.MethodDelete {
+		h.writeError(w, http.StatusMethodNotAllowed, "only DELETE is supported")
+		return
+	}
+
+	force := r.URL.Query().Get("force") == "true"
+	result, err := h.repo.Purge(r.Context(), id, force)
+	if errors.Is(err, repository.ErrResourceInPath) {
+		h.writeError(w, http.StatusConflict, "resource is part of a learning path; use ?force=true to purge it anyway")
+		return
+	}
+	if err != nil {
+		h.logger.Printf("purge resource error: %v", err)
+		h.writeError(w, http.StatusInternalServerError, "failed to purge resource")
+		return
+	}
+	if result == nil {
+		h.writeError(w, http.StatusNotFound, "resource not found")
+		return
+	}
+
+	h.logger.Printf("purged resource %s (%d skills, %d path steps)", id, result.SkillsRemoved, result.PathStepsRemoved)
+	audit.Record(r.Context(), audit.Change{
+		Action: "resource.purge", EntityType: "resource", EntityID: id.String(), After: result,
+	})
+	h.writeJSON(w, http.StatusOK, map[string]interface{}{
+		"success": true,
+		"message": "resource purged",
+		"data":    result,
+	})
+}
+
 // ─────────────────────────────────────────────────────────────────────────────
 // Provider admin handlers
 // ─────────────────────────────────────────────────────────────────────────────
@@ -218,6 +785,9 @@ func (h *Handler) handleAdminProviders(w http.ResponseWriter, r *http.Request) {
 		h.writeError(w, http.StatusInternalServerError, "failed to create provider")
 		return
 	}
+	audit.Record(r.Context(), audit.Change{
+		Action: "provider.create", EntityType: "provider", EntityID: provider.ID.String(), After: provider,
+	})
 
 	h.writeJSON(w, http.StatusCreated, map[string]interface{}{
 		"success": true,
@@ -240,11 +810,15 @@ func (h *Handler) handleAdminProviders(w http.ResponseWriter, r *http.Request) {
 //	  "target_role": "Backend Engineer",
 //	  "target_skill": "Go",
 //	  "difficulty": "intermediate",
-//	  "estimated_hours": 120,
+//	  "estimated_hours": 120,           // optional; summed from the resources if omitted
 //	  "resources": [
-//	    {"resource_id": "uuid", "step_order": 1, "is_required": true, "notes": "..."}
+//	    {"resource_id": "uuid", "step_order": 1, "is_required": true, "notes": "..."},
+//	    {"skill_name": "Rust", "step_order": 2, "notes": "..."}   // notes-only step
 //	  ]
 //	}
+//
+// Resources must be active and step_order values must be 1..n. A slug that
+// is already in use returns 409 Conflict.
 func (h *Handler) handleAdminPaths(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
 		h.writeError(w, http.StatusMethodNotAllowed, "only POST is supported")
@@ -265,32 +839,223 @@ func (h *Handler) handleAdminPaths(w http.ResponseWriter, r *http.Request) {
 		h.writeError(w, http.StatusBadRequest, "path slug is required")
 		return
 	}
+	steps, err := toPathSteps(req.Resources)
+	if err != nil {
+		h.writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	path, err := h.repo.CreatePath(r.Context(), repository.CreateLearningPathInput{
+		Title:          req.Title,
+		Slug:           req.Slug,
+		Description:    req.Description,
+		TargetRole:     req.TargetRole,
+		TargetSkill:    req.TargetSkill,
+		Difficulty:     repository.ResourceDifficulty(req.Difficulty),
+		EstimatedHours: req.EstimatedHours,
+		Resources:      steps,
+	})
+	if h.writePathError(w, err, "create") {
+		return
+	}
+	audit.Record(r.Context(), audit.Change{
+		Action: "path.create", EntityType: "path", EntityID: path.ID.String(), After: path,
+	})
 
-	// Note: CreateLearningPath is not yet implemented in the repository.
-	// This returns a placeholder response to demonstrate the admin interface.
 	h.writeJSON(w, http.StatusCreated, map[string]interface{}{
 		"success": true,
-		"message": "learning path creation queued",
-		"data":    req,
+		"data":    path,
+	})
+}
+
+// handleAdminPathByID handles PUT/DELETE /api/v1/admin/paths/{id} and
+// PUT /api/v1/admin/paths/{id}/resources
+func (h *Handler) handleAdminPathByID(w http.ResponseWriter, r *http.Request) {
+	idStr := strings.TrimPrefix(r.URL.Path, "/api/v1/admin/paths/")
+	idStr, action, _ := strings.Cut(idStr, "/")
+	if idStr == "" {
+		h.writeError(w, http.StatusBadRequest, "path ID is required")
+		return
+	}
+
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		h.writeError(w, http.StatusBadRequest, "invalid path ID")
+		return
+	}
+
+	switch action {
+	case "":
+	case "resources":
+		h.setPathResources(w, r, id)
+		return
+	default:
+		h.writeError(w, http.StatusNotFound, "not found")
+		return
+	}
+
+	switch r.Method {
+	case http.MethodPut:
+		h.updatePath(w, r, id)
+	case http.MethodDelete:
+		h.deletePath(w, r, id)
+	default:
+		h.writeError(w, http.StatusMethodNotAllowed, "only PUT and DELETE are supported")
+	}
+}
+
+func (h *Handler) updatePath(w http.ResponseWriter, r *http.Request, id uuid.UUID) {
+	var req updatePathRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.writeError(w, http.StatusBadRequest, "invalid request body: "+err.Error())
+		return
+	}
+
+	before, ok := h.pathBeforeChange(w, r, id)
+	if !ok {
+		return
+	}
+	path, err := h.repo.UpdatePath(r.Context(), id, req.toInput())
+	if h.writePathError(w, err, "update") {
+		return
+	}
+	if path == nil {
+		h.writeError(w, http.StatusNotFound, "learning path not found")
+		return
+	}
+	audit.Record(r.Context(), audit.Change{
+		Action: "path.update", EntityType: "path", EntityID: id.String(), Before: before, After: path,
+	})
+
+	h.writeJSON(w, http.StatusOK, map[string]interface{}{
+		"success": true,
+		"data":    path,
+	})
+}
+
+func (h *Handler) deletePath(w http.ResponseWriter, r *http.Request, id uuid.UUID) {
+	if err := h.repo.DeletePath(r.Context(), id); err != nil {
+		h.logger.Printf("delete path error: %v", err)
+		h.writeError(w, http.StatusInternalServerError, "failed to delete learning path")
+		return
+	}
+	audit.Record(r.Context(), audit.Change{
+		Action: "path.delete", EntityType: "path", EntityID: id.String(),
+		Before: activeFlag(true), After: activeFlag(false),
+	})
+
+	h.writeJSON(w, http.StatusOK, map[string]interface{}{
+		"success": true,
+		"message": "learning path deleted",
+	})
+}
+
+// setPathResources handles PUT /api/v1/admin/paths/{id}/resources
+//
+// Request body (JSON):
+//
+//	{
+//	  "resources": [
+//	    {"resource_id": "uuid", "step_order": 1, "is_required": true, "notes": "..."}
+//	  ]
+//	}
+//
+// The list replaces the path's resources and its estimated hours are
+// recomputed from them.
+func (h *Handler) setPathResources(w http.ResponseWriter, r *http.Request, id uuid.UUID) {
+	if r.Method != http.MethodPut {
+		h.writeError(w, http.StatusMethodNotAllowed, "only PUT is supported")
+		return
+	}
+
+	var req setPathResourcesRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.writeError(w, http.StatusBadRequest, "invalid request body: "+err.Error())
+		return
+	}
+	steps, err := toPathSteps(req.Resources)
+	if err != nil {
+		h.writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	before, ok := h.pathBeforeChange(w, r, id)
+	if !ok {
+		return
+	}
+	path, err := h.repo.SetPathResources(r.Context(), id, steps)
+	if h.writePathError(w, err, "update") {
+		return
+	}
+	if path == nil {
+		h.writeError(w, http.StatusNotFound, "learning path not found")
+		return
+	}
+	audit.Record(r.Context(), audit.Change{
+		Action: "path.set_resources", EntityType: "path", EntityID: id.String(), Before: before, After: path,
+	})
+
+	h.writeJSON(w, http.StatusOK, map[string]interface{}{
+		"success": true,
+		"data":    path,
 	})
 }
 
+// pathBeforeChange returns the learning path about to be changed when
+// changes are audited. On failure it writes the response and returns false.
+func (h *Handler) pathBeforeChange(w http.ResponseWriter, r *http.Request, id uuid.UUID) (*repository.LearningPathWithResources, bool) {
+	if !h.auditing() {
+		return nil, true
+	}
+	path, err := h.repo.GetPathByID(r.Context(), id)
+	if err != nil {
+		h.writePathError(w, err, "update")
+		return nil, false
+	}
+	return path, true
+}
+
+// activeFlag is the part of an entity a soft delete or restore changes.
+func activeFlag(active bool) map[string]bool {
+	return map[string]bool{"is_active": active}
+}
+
+// writePathError writes the response for a failed path write and reports
+// whether err was non-nil.
+func (h *Handler) writePathError(w http.ResponseWriter, err error, verb string) bool {
+	switch {
+	case err == nil:
+		return false
+	case errors.Is(err, repository.ErrInvalidPathSteps):
+		h.writeError(w, http.StatusBadRequest, err.Error())
+	case errors.Is(err, repository.ErrSlugTaken):
+		h.writeError(w, http.StatusConflict, "path slug is already taken")
+	default:
+		h.logger.Printf("%s path error: %v", verb, err)
+		h.writeError(w, http.StatusInternalServerError, "failed to "+verb+" learning path")
+	}
+	return true
+}
+
 // ─────────────────────────────────────────────────────────────────────────────
 // Request types
 // ─────────────────────────────────────────────────────────────────────────────
 
+// createResourceRequest is the body of POST /api/v1/admin/resources. Its
+// validate tags are checked on creation; imports check the same rules in
+// validateImport, with messages that name the import row's value.
 type createResourceRequest struct {
-	Title          string                       `json:"title"`
+	Title          string                       `json:"title" validate:"required,max=500"`
 	Slug           string                       `json:"slug"`
 	Description    *string                      `json:"description,omitempty"`
-	URL            string                       `json:"url"`
-	ProviderID     *string                      `json:"provider_id,omitempty"`
-	ResourceType   string                       `json:"resource_type"`
-	Difficulty     string                       `json:"difficulty"`
-	CostType       string                       `json:"cost_type"`
-	CostAmount     *float64                     `json:"cost_amount,omitempty"`
-	CostCurrency   string                       `json:"cost_currency,omitempty"`
-	DurationHours  *float64                     `json:"duration_hours,omitempty"`
+	URL            string                       `json:"url" validate:"required,url"`
+	ProviderID     *string                      `json:"provider_id,omitempty" validate:"omitempty,uuid"`
+	ResourceType   string                       `json:"resource_type" validate:"omitempty,oneof=course certification documentation video book practice article project other"`
+	Difficulty     string                       `json:"difficulty" validate:"omitempty,oneof=beginner intermediate advanced expert all_levels"`
+	CostType       string                       `json:"cost_type" validate:"omitempty,oneof=free freemium paid subscription free_audit employer_sponsored"`
+	CostAmount     *float64                     `json:"cost_amount,omitempty" validate:"omitempty,min=0"`
+	CostCurrency   string                       `json:"cost_currency,omitempty" validate:"omitempty,min=3,max=3"`
+	DurationHours  *float64                     `json:"duration_hours,omitempty" validate:"omitempty,min=0"`
 	DurationLabel  *string                      `json:"duration_label,omitempty"`
 	Language       string                       `json:"language,omitempty"`
 	HasCertificate bool                         `json:"has_certificate"`
@@ -299,18 +1064,15 @@ type createResourceRequest struct {
 }
 
 type createResourceSkillRequest struct {
-	SkillName     string  `json:"skill_name"`
+	SkillName     string  `json:"skill_name" validate:"required"`
 	IsPrimary     bool    `json:"is_primary"`
-	CoverageLevel *string `json:"coverage_level,omitempty"`
+	CoverageLevel *string `json:"coverage_level,omitempty" validate:"omitempty,oneof=beginner intermediate advanced expert all_levels"`
 }
 
 func (r *createResourceRequest) validate() error {
 	if strings.TrimSpace(r.Title) == "" {
 		return fmt.Errorf("title is required")
 	}
-	if strings.TrimSpace(r.Slug) == "" {
-		return fmt.Errorf("slug is required")
-	}
 	if strings.TrimSpace(r.URL) == "" {
 		return fmt.Errorf("url is required")
 	}
@@ -415,11 +1177,69 @@ type createPathRequest struct {
 
 type pathResourceRequest struct {
 	ResourceID string  `json:"resource_id"`
+	SkillName  string  `json:"skill_name,omitempty"`
 	StepOrder  int16   `json:"step_order"`
 	IsRequired bool    `json:"is_required"`
 	Notes      *string `json:"notes,omitempty"`
 }
 
+// toPathSteps converts path resources from a request body. A step without
+// a resource_id is a notes-only step for skill_name.
+func toPathSteps(reqs []pathResourceRequest) ([]repository.LearningPathResourceInput, error) {
+	steps := make([]repository.LearningPathResourceInput, 0, len(reqs))
+	for _, req := range reqs {
+		var id uuid.UUID
+		if req.ResourceID != "" || req.SkillName == "" {
+			var err error
+			if id, err = uuid.Parse(req.ResourceID); err != nil {
+				return nil, fmt.Errorf("invalid resource_id %q", req.ResourceID)
+			}
+		}
+		steps = append(steps, repository.LearningPathResourceInput{
+			ResourceID: id,
+			SkillName:  req.SkillName,
+			StepOrder:  req.StepOrder,
+			IsRequired: req.IsRequired,
+			Notes:      req.Notes,
+		})
+	}
+	return steps, nil
+}
+
+type updatePathRequest struct {
+	Title          *string  `json:"title,omitempty"`
+	Slug           *string  `json:"slug,omitempty"`
+	Description    *string  `json:"description,omitempty"`
+	TargetRole     *string  `json:"target_role,omitempty"`
+	TargetSkill    *string  `json:"target_skill,omitempty"`
+	Difficulty     *string  `json:"difficulty,omitempty"`
+	EstimatedHours *float64 `json:"estimated_hours,omitempty"`
+	IsActive       *bool    `json:"is_active,omitempty"`
+	IsFeatured     *bool    `json:"is_featured,omitempty"`
+}
+
+func (r *updatePathRequest) toInput() repository.UpdateLearningPathInput {
+	input := repository.UpdateLearningPathInput{
+		Title:          r.Title,
+		Slug:           r.Slug,
+		Description:    r.Description,
+		TargetRole:     r.TargetRole,
+		TargetSkill:    r.TargetSkill,
+		EstimatedHours: r.EstimatedHours,
+		IsActive:       r.IsActive,
+		IsFeatured:     r.IsFeatured,
+	}
+	if r.Difficulty != nil {
+		d := repository.ResourceDifficulty(*r.Difficulty)
+		input.Difficulty = &d
+	}
+	return input
+}
+
+type setPathResourcesRequest struct {
+	Resources []pathResourceRequest `json:"resources"`
+}
+
 // ─────────────────────────────────────────────────────────────────────────────
 // Response helpers
 // ─────────────────────────────────────────────────────────────────────────────
@@ -438,3 +1258,12 @@ func (h *Handler) writeError(w http.ResponseWriter, status int, message string)
 		"error":   message,
 	})
 }
+
+// writeValidationError writes a 422 response listing the invalid fields.
+func (h *Handler) writeValidationError(w http.ResponseWriter, errs validation.Errors) {
+	h.writeJSON(w, http.StatusUnprocessableEntity, map[string]interface{}{
+		"success": false,
+		"error":   "request validation failed",
+		"errors":  errs,
+	})
+}
@@ -0,0 +1,247 @@
+package admin
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+
+	"github.com/learnbot/database/repository"
+	"github.com/learnbot/shared/openapi"
+	"github.com/learnbot/shared/validation"
+)
+
+// newMockHandler returns a Handler backed by a sqlmock database.
+func newMockHandler(t *testing.T) (*Handler, sqlmock.Sqlmock) {
+	t.Helper()
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock.New: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return NewHandler(repository.NewLearningResourceRepository(db), log.New(io.Discard, "", 0)), mock
+}
+
+// resourceColumns are the columns returned by the resource update queries.
+var resourceColumns = []string{
+	"id", "title", "slug", "description", "url", "provider_id", "resource_type",
+	"difficulty", "cost_type", "cost_amount", "cost_currency", "duration_hours",
+	"duration_label", "language", "is_active", "is_featured", "is_verified",
+	"has_certificate", "has_hands_on", "rating", "rating_count", "enrollment_count",
+	"last_updated_date", "created_at", "updated_at",
+}
+
+func TestCreateResource_Validation(t *testing.T) {
+	tests := []struct {
+		name       string
+		body       string
+		allowExtra bool
+		want       []string // "field:code"
+	}{
+		{"missing title and url", `{"slug":"x"}`, false, []string{"title:required", "url:required"}},
+		{"relative url", `{"title":"Go","url":"/go"}`, false, []string{"url:url"}},
+		{"bad enums", `{"title":"Go","url":"https://go.dev","difficulty":"hard","cost_type":"cheap","resource_type":"podcast"}`,
+			false, []string{"resource_type:one_of", "difficulty:one_of", "cost_type:one_of"}},
+		{"bad provider and cost", `{"title":"Go","url":"https://go.dev","provider_id":"acme","cost_amount":-1,"cost_currency":"dollars"}`,
+			false, []string{"provider_id:uuid", "cost_amount:min", "cost_currency:max_length"}},
+		{"bad skills", `{"title":"Go","url":"https://go.dev","skills":[{"skill_name":"Go","coverage_level":"deep"},{"is_primary":true}]}`,
+			false, []string{"skills[0].coverage_level:one_of", "skills[1].skill_name:required"}},
+		{"unknown field", `{"title":"Go","url":"https://go.dev","dificulty":"beginner"}`, false, []string{"dificulty:unknown_field"}},
+		{"unknown field allowed", `{"url":"https://go.dev","dificulty":"beginner"}`, true, []string{"title:required"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h, _ := newMockHandler(t)
+			h.SetValidationConfig(validation.Config{AllowUnknownFields: tt.allowExtra})
+			w := httptest.NewRecorder()
+			h.handleAdminResources(w, httptest.NewRequest(http.MethodPost, "/api/v1/admin/resources", strings.NewReader(tt.body)))
+			if w.Code != http.StatusUnprocessableEntity {
+				t.Fatalf("expected 422, got %d: %s", w.Code, w.Body.String())
+			}
+			var resp struct {
+				Errors validation.Errors `json:"errors"`
+			}
+			if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+				t.Fatalf("decode: %v", err)
+			}
+			var got []string
+			for _, fe := range resp.Errors {
+				got = append(got, fe.Field+":"+fe.Code)
+			}
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRestoreResource(t *testing.T) {
+	h, mock := newMockHandler(t)
+	id := uuid.New()
+	now := time.Now()
+
+	mock.ExpectQuery("UPDATE learning_resources SET is_active = TRUE").
+		WithArgs(id).
+		WillReturnRows(sqlmock.NewRows(resourceColumns).AddRow(
+			id, "Intro to Go", "intro-to-go", nil, "https://example.com", nil, "course",
+			"beginner", "free", nil, "USD", nil,
+			nil, "en", true, false, false,
+			false, false, nil, 0, nil,
+			nil, now, now,
+		))
+	w := httptest.NewRecorder()
+	h.handleAdminResourceByID(w, httptest.NewRequest(http.MethodPost, "/api/v1/admin/resources/"+id.String()+"/restore", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	mock.ExpectQuery("UPDATE learning_resources").WillReturnRows(sqlmock.NewRows(resourceColumns))
+	w = httptest.NewRecorder()
+	h.handleAdminResourceByID(w, httptest.NewRequest(http.MethodPost, "/api/v1/admin/resources/"+uuid.NewString()+"/restore", nil))
+	if w.Code != http.StatusNotFound {
+		t.Errorf("expected 404 for an unknown resource, got %d", w.Code)
+	}
+
+	w = httptest.NewRecorder()
+	h.handleAdminResourceByID(w, httptest.NewRequest(http.MethodGet, "/api/v1/admin/resources/"+id.String()+"/restore", nil))
+	if w.Code != http.StatusMethodNotAllowed {
+		t.Errorf("expected 405, got %d", w.Code)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestPurgeResource(t *testing.T) {
+	id := uuid.New()
+	tests := []struct {
+		name   string
+		query  string
+		expect func(sqlmock.Sqlmock)
+		want   int
+	}{
+		{
+			name: "in path",
+			expect: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery("FOR UPDATE").WillReturnRows(sqlmock.NewRows([]string{"bool"}).AddRow(true))
+				mock.ExpectQuery("FROM learning_path_resources").WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+				mock.ExpectRollback()
+			},
+			want: http.StatusConflict,
+		},
+		{
+			name:  "force",
+			query: "?force=true",
+			expect: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery("FOR UPDATE").WillReturnRows(sqlmock.NewRows([]string{"bool"}).AddRow(true))
+				mock.ExpectExec("DELETE FROM resource_skills").WillReturnResult(sqlmock.NewResult(0, 2))
+				mock.ExpectExec("DELETE FROM learning_path_resources").WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("DELETE FROM learning_resources").WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+			want: http.StatusOK,
+		},
+		{
+			name: "not found",
+			expect: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery("FOR UPDATE").WillReturnRows(sqlmock.NewRows([]string{"bool"}))
+				mock.ExpectRollback()
+			},
+			want: http.StatusNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h, mock := newMockHandler(t)
+			tt.expect(mock)
+			w := httptest.NewRecorder()
+			h.handleAdminResourceByID(w, httptest.NewRequest(http.MethodDelete, "/api/v1/admin/resources/"+id.String()+"/purge"+tt.query, nil))
+			if w.Code != tt.want {
+				t.Fatalf("expected %d, got %d: %s", tt.want, w.Code, w.Body.String())
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func TestSetPathResources(t *testing.T) {
+	pathID, active, inactive := uuid.New(), uuid.New(), uuid.New()
+	body := func(steps ...string) string {
+		return `{"resources":[` + strings.Join(steps, ",") + `]}`
+	}
+	step := func(id uuid.UUID, order int) string {
+		return fmt.Sprintf(`{"resource_id":%q,"step_order":%d}`, id, order)
+	}
+
+	tests := []struct {
+		name   string
+		method string
+		body   string
+		expect func(sqlmock.Sqlmock)
+		want   int
+	}{
+		{name: "method", method: http.MethodPost, body: body(), want: http.StatusMethodNotAllowed},
+		{name: "invalid resource id", method: http.MethodPut, body: `{"resources":[{"resource_id":"abc","step_order":1}]}`, want: http.StatusBadRequest},
+		{name: "gap in step order", method: http.MethodPut, body: body(step(active, 1), step(inactive, 3)), want: http.StatusBadRequest},
+		{
+			name:   "inactive resource",
+			method: http.MethodPut,
+			body:   body(step(active, 1), step(inactive, 2)),
+			expect: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery("FOR UPDATE").WillReturnRows(sqlmock.NewRows([]string{"bool"}).AddRow(true))
+				mock.ExpectQuery("SELECT id FROM learning_resources").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(active))
+				mock.ExpectRollback()
+			},
+			want: http.StatusBadRequest,
+		},
+		{
+			name:   "unknown path",
+			method: http.MethodPut,
+			body:   body(step(active, 1)),
+			expect: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery("FOR UPDATE").WillReturnRows(sqlmock.NewRows([]string{"bool"}))
+				mock.ExpectRollback()
+			},
+			want: http.StatusNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h, mock := newMockHandler(t)
+			if tt.expect != nil {
+				tt.expect(mock)
+			}
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(tt.method, "/api/v1/admin/paths/"+pathID.String()+"/resources", strings.NewReader(tt.body))
+			h.handleAdminPathByID(w, req)
+			if w.Code != tt.want {
+				t.Fatalf("expected %d, got %d: %s", tt.want, w.Code, w.Body.String())
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func TestDescribeRoutes(t *testing.T) {
+	h := NewHandler(nil, log.New(io.Discard, "", 0))
+	if err := openapi.CheckRoutes(h.RegisterRoutes, h.DescribeRoutes); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,460 @@
+package admin
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/learnbot/database/repository"
+	"github.com/learnbot/shared/audit"
+)
+
+const (
+	// maxImportSize limits the size of an import request body.
+	maxImportSize = 10 << 20 // 10 MB
+
+	// maxImportRows limits the number of resources in one import.
+	maxImportRows = 5000
+)
+
+// Import modes, selected with ?mode=.
+const (
+	importModeAllOrNothing = "all_or_nothing"
+	importModeBestEffort   = "best_effort"
+)
+
+// Row statuses reported by an import.
+const (
+	importStatusCreated    = "created"     // inserted and committed
+	importStatusValid      = "valid"       // would be inserted; dry run
+	importStatusRolledBack = "rolled_back" // inserted, then undone because another row failed
+	importStatusFailed     = "failed"
+)
+
+var (
+	validResourceTypes = map[repository.ResourceType]bool{
+		repository.ResourceTypeCourse: true, repository.ResourceTypeCertification: true,
+		repository.ResourceTypeDocumentation: true, repository.ResourceTypeVideo: true,
+		repository.ResourceTypeBook: true, repository.ResourceTypePractice: true,
+		repository.ResourceTypeArticle: true, repository.ResourceTypeProject: true,
+		repository.ResourceTypeOther: true,
+	}
+	validDifficulties = map[repository.ResourceDifficulty]bool{
+		repository.ResourceDifficultyBeginner: true, repository.ResourceDifficultyIntermediate: true,
+		repository.ResourceDifficultyAdvanced: true, repository.ResourceDifficultyExpert: true,
+		repository.ResourceDifficultyAllLevels: true,
+	}
+	validCostTypes = map[repository.ResourceCostType]bool{
+		repository.ResourceCostFree: true, repository.ResourceCostFreemium: true,
+		repository.ResourceCostPaid: true, repository.ResourceCostSubscription: true,
+		repository.ResourceCostFreeAudit: true, repository.ResourceCostEmployerSponsored: true,
+	}
+)
+
+// importResourceRequest is one resource in an import. Provider names a
+// provider to link, and is created if it does not exist.
+type importResourceRequest struct {
+	createResourceRequest
+	Provider string `json:"provider,omitempty"`
+}
+
+// importRow is a parsed import row with the line it starts on.
+type importRow struct {
+	line int
+	req  importResourceRequest
+	err  error // parse or validation error
+}
+
+// importRowResult is the per-row report of an import.
+type importRowResult struct {
+	Line            int        `json:"line"`
+	Slug            string     `json:"slug,omitempty"`
+	Status          string     `json:"status"`
+	ID              *uuid.UUID `json:"id,omitempty"`
+	ProviderCreated bool       `json:"provider_created,omitempty"`
+	Error           string     `json:"error,omitempty"`
+}
+
+// handleAdminImport handles POST /api/v1/admin/resources/import
+//
+// The body is a CSV file (Content-Type: text/csv) with a header row, or a
+// JSON array of resources (Content-Type: application/json) in the format
+// accepted by POST /api/v1/admin/resources plus an optional "provider" name.
+// Rows without a slug get one generated from the title; the report lists
+// the final slug of every inserted row.
+//
+// Query parameters:
+//   - mode: "all_or_nothing" (default) writes nothing if any row fails;
+//     "best_effort" imports every valid row
+//   - dry_run: "true" validates every row, including slug uniqueness,
+//     without writing
+func (h *Handler) handleAdminImport(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		h.writeError(w, http.StatusMethodNotAllowed, "only POST is supported")
+		return
+	}
+
+	mode := r.URL.Query().Get("mode")
+	if mode == "" {
+		mode = importModeAllOrNothing
+	}
+	if mode != importModeAllOrNothing && mode != importModeBestEffort {
+		h.writeError(w, http.StatusBadRequest,
+			fmt.Sprintf("mode must be %q or %q", importModeAllOrNothing, importModeBestEffort))
+		return
+	}
+	dryRun := r.URL.Query().Get("dry_run") == "true"
+
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	var parse func([]byte) ([]importRow, error)
+	switch mediaType {
+	case "text/csv", "application/csv":
+		parse = parseCSVImport
+	case "application/json":
+		parse = parseJSONImport
+	default:
+		h.writeError(w, http.StatusUnsupportedMediaType, "Content-Type must be text/csv or application/json")
+		return
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxImportSize))
+	if err != nil {
+		h.writeError(w, http.StatusRequestEntityTooLarge, "import body is too large")
+		return
+	}
+	rows, err := parse(body)
+	if err != nil {
+		h.writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if len(rows) == 0 {
+		h.writeError(w, http.StatusBadRequest, "import contains no resources")
+		return
+	}
+	if len(rows) > maxImportRows {
+		h.writeError(w, http.StatusBadRequest,
+			fmt.Sprintf("import contains %d resources; the limit is %d", len(rows), maxImportRows))
+		return
+	}
+
+	// Rows that fail validation never reach the database. The valid rows
+	// are still inserted, which catches duplicate slugs, but an
+	// all-or-nothing import with invalid rows is always rolled back.
+	results := make([]importRowResult, len(rows))
+	var inputs []repository.ImportResourceInput
+	var inputRows []int
+	validationFailed := false
+	for i := range rows {
+		row := &rows[i]
+		results[i] = importRowResult{Line: row.line, Slug: row.req.Slug}
+		if row.err == nil {
+			row.err = row.req.validateImport()
+		}
+		if row.err != nil {
+			validationFailed = true
+			results[i].Status = importStatusFailed
+			results[i].Error = row.err.Error()
+			continue
+		}
+		inputs = append(inputs, row.req.toImportInput())
+		inputRows = append(inputRows, i)
+	}
+
+	atomic := mode == importModeAllOrNothing
+	committed := false
+	if len(inputs) > 0 {
+		opts := repository.ImportOptions{
+			Atomic: atomic,
+			DryRun: dryRun || (atomic && validationFailed),
+		}
+		var repoResults []repository.ImportResult
+		repoResults, committed, err = h.repo.ImportResources(r.Context(), inputs, opts)
+		if err != nil {
+			h.logger.Printf("import resources error: %v", err)
+			h.writeError(w, http.StatusInternalServerError, "failed to import resources")
+			return
+		}
+		for j, res := range repoResults {
+			out := &results[inputRows[j]]
+			if res.Err != nil {
+				out.Status = importStatusFailed
+				out.Error = importErrorMessage(res.Err)
+				if !errors.Is(res.Err, repository.ErrSlugTaken) {
+					h.logger.Printf("import resource %q error: %v", out.Slug, res.Err)
+				}
+				continue
+			}
+			out.Slug = res.Resource.Slug
+			out.ProviderCreated = res.ProviderCreated
+			switch {
+			case committed:
+				out.Status = importStatusCreated
+				out.ID = &res.Resource.ID
+				audit.Record(r.Context(), audit.Change{
+					Action: "resource.import", EntityType: "resource", EntityID: res.Resource.ID.String(), After: res.Resource,
+				})
+			case dryRun:
+				out.Status = importStatusValid
+			default:
+				out.Status = importStatusRolledBack
+			}
+		}
+	}
+
+	if !committed {
+		// Dry runs and rolled back imports change nothing.
+		audit.Skip(r.Context())
+	}
+
+	imported, failed := 0, 0
+	for _, res := range results {
+		switch res.Status {
+		case importStatusCreated:
+			imported++
+		case importStatusFailed:
+			failed++
+		}
+	}
+
+	status := http.StatusOK
+	if atomic && failed > 0 {
+		status = http.StatusUnprocessableEntity
+	}
+	h.writeJSON(w, status, map[string]interface{}{
+		"success":   failed == 0,
+		"mode":      mode,
+		"dry_run":   dryRun,
+		"committed": committed,
+		"total":     len(rows),
+		"imported":  imported,
+		"failed":    failed,
+		"rows":      results,
+	})
+}
+
+// importErrorMessage returns the message reported for a row that failed in
+// the database. Unexpected errors are not echoed to the client.
+func importErrorMessage(err error) string {
+	if errors.Is(err, repository.ErrSlugTaken) {
+		return "duplicate slug"
+	}
+	return "failed to insert resource"
+}
+
+// validateImport validates an import row. Unlike the single-resource create
+// endpoint it also checks enum values, so that a curator sees which line
+// is wrong; empty enums take the database defaults.
+func (r *importResourceRequest) validateImport() error {
+	if err := r.validate(); err != nil {
+		return err
+	}
+	if r.ResourceType == "" {
+		r.ResourceType = string(repository.ResourceTypeCourse)
+	}
+	if r.Difficulty == "" {
+		r.Difficulty = string(repository.ResourceDifficultyIntermediate)
+	}
+	if r.CostType == "" {
+		r.CostType = string(repository.ResourceCostPaid)
+	}
+	if !validResourceTypes[repository.ResourceType(r.ResourceType)] {
+		return fmt.Errorf("invalid resource_type %q", r.ResourceType)
+	}
+	if !validDifficulties[repository.ResourceDifficulty(r.Difficulty)] {
+		return fmt.Errorf("invalid difficulty %q", r.Difficulty)
+	}
+	if !validCostTypes[repository.ResourceCostType(r.CostType)] {
+		return fmt.Errorf("invalid cost_type %q", r.CostType)
+	}
+	if r.CostAmount != nil && *r.CostAmount < 0 {
+		return fmt.Errorf("cost_amount must not be negative")
+	}
+	if r.DurationHours != nil && *r.DurationHours <= 0 {
+		return fmt.Errorf("duration_hours must be positive")
+	}
+	if r.ProviderID != nil {
+		if _, err := uuid.Parse(*r.ProviderID); err != nil {
+			return fmt.Errorf("invalid provider_id %q", *r.ProviderID)
+		}
+	}
+	for _, s := range r.Skills {
+		if s.CoverageLevel != nil && !validDifficulties[repository.ResourceDifficulty(*s.CoverageLevel)] {
+			return fmt.Errorf("invalid coverage_level %q for skill %q", *s.CoverageLevel, s.SkillName)
+		}
+	}
+	return nil
+}
+
+func (r *importResourceRequest) toImportInput() repository.ImportResourceInput {
+	return repository.ImportResourceInput{
+		CreateResourceInput: r.toInput(),
+		ProviderName:        r.Provider,
+	}
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Import parsing
+// ─────────────────────────────────────────────────────────────────────────────
+
+// parseJSONImport parses a JSON array of resources. Each row records the line
+// its object starts on. Rows with fields of the wrong type are reported as
+// row errors; malformed JSON fails the whole import.
+func parseJSONImport(body []byte) ([]importRow, error) {
+	dec := json.NewDecoder(bytes.NewReader(body))
+	if tok, err := dec.Token(); err != nil || tok != json.Delim('[') {
+		return nil, fmt.Errorf("invalid JSON import: expected an array of resources")
+	}
+
+	var rows []importRow
+	for dec.More() {
+		var raw json.RawMessage
+		start := int(dec.InputOffset())
+		if err := dec.Decode(&raw); err != nil {
+			return nil, fmt.Errorf("invalid JSON import: %v", err)
+		}
+		// The offset is just past the previous value; skip the separator.
+		for start < len(body) && strings.IndexByte(" \t\r\n,", body[start]) >= 0 {
+			start++
+		}
+		row := importRow{line: 1 + bytes.Count(body[:start], []byte("\n"))}
+		if err := json.Unmarshal(raw, &row.req); err != nil {
+			row.err = fmt.Errorf("invalid resource: %v", err)
+		}
+		rows = append(rows, row)
+	}
+	if _, err := dec.Token(); err != nil {
+		return nil, fmt.Errorf("invalid JSON import: %v", err)
+	}
+	return rows, nil
+}
+
+// csvColumns are the columns accepted in a CSV import. Skills are separated
+// by semicolons; the first skill is the primary one.
+var csvColumns = map[string]bool{
+	"title": true, "slug": true, "description": true, "url": true,
+	"provider": true, "provider_id": true, "resource_type": true,
+	"difficulty": true, "cost_type": true, "cost_amount": true,
+	"cost_currency": true, "duration_hours": true, "duration_label": true,
+	"language": true, "has_certificate": true, "has_hands_on": true,
+	"skills": true,
+}
+
+// parseCSVImport parses a CSV import with a header row. Unknown columns fail
+// the whole import, since they usually mean a misspelled header; bad values
+// are reported per row.
+func parseCSVImport(body []byte) ([]importRow, error) {
+	cr := csv.NewReader(bytes.NewReader(body))
+	cr.TrimLeadingSpace = true
+	cr.FieldsPerRecord = -1
+
+	header, err := cr.Read()
+	if err == io.EOF {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid CSV import: %v", err)
+	}
+	columns := make([]string, len(header))
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+		if !csvColumns[name] {
+			return nil, fmt.Errorf("invalid CSV import: unknown column %q", name)
+		}
+		columns[i] = name
+	}
+
+	var rows []importRow
+	for {
+		record, err := cr.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid CSV import: %v", err)
+		}
+		line, _ := cr.FieldPos(0)
+		row := importRow{line: line}
+		if len(record) != len(columns) {
+			row.err = fmt.Errorf("expected %d columns, got %d", len(columns), len(record))
+		}
+		for i, value := range record {
+			if row.err != nil || i >= len(columns) {
+				break
+			}
+			row.err = setCSVField(&row.req, columns[i], strings.TrimSpace(value))
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+// setCSVField sets one CSV column on req.
+func setCSVField(req *importResourceRequest, column, value string) error {
+	if value == "" {
+		return nil
+	}
+	switch column {
+	case "title":
+		req.Title = value
+	case "slug":
+		req.Slug = value
+	case "description":
+		req.Description = &value
+	case "url":
+		req.URL = value
+	case "provider":
+		req.Provider = value
+	case "provider_id":
+		req.ProviderID = &value
+	case "resource_type":
+		req.ResourceType = value
+	case "difficulty":
+		req.Difficulty = value
+	case "cost_type":
+		req.CostType = value
+	case "cost_currency":
+		req.CostCurrency = value
+	case "duration_label":
+		req.DurationLabel = &value
+	case "language":
+		req.Language = value
+	case "cost_amount", "duration_hours":
+		v, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return fmt.Errorf("invalid %s %q", column, value)
+		}
+		if column == "cost_amount" {
+			req.CostAmount = &v
+		} else {
+			req.DurationHours = &v
+		}
+	case "has_certificate", "has_hands_on":
+		v, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("invalid %s %q", column, value)
+		}
+		if column == "has_certificate" {
+			req.HasCertificate = v
+		} else {
+			req.HasHandsOn = v
+		}
+	case "skills":
+		for _, name := range strings.Split(value, ";") {
+			if name = strings.TrimSpace(name); name != "" {
+				req.Skills = append(req.Skills, createResourceSkillRequest{
+					SkillName: name,
+					IsPrimary: len(req.Skills) == 0,
+				})
+			}
+		}
+	}
+	return nil
+}
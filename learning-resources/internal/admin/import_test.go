@@ -0,0 +1,221 @@
+package admin
+
+import (
+	"encoding/json"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func newImportTestHandler() *Handler {
+	return &Handler{logger: log.New(io.Discard, "", 0)}
+}
+
+func TestParseCSVImport(t *testing.T) {
+	body := "title,slug,url,provider,cost_type,cost_amount,has_certificate,skills\n" +
+		"Go Basics,go-basics,https://example.com/go,Example Academy,paid,19.99,true,Go; Testing\n" +
+		"\"Multi\nline\",multi,https://example.com/m,,free,,,\n" +
+		"Bad Price,bad-price,https://example.com/p,,paid,cheap,,\n" +
+		"Short,short\n"
+
+	rows, err := parseCSVImport([]byte(body))
+	if err != nil {
+		t.Fatalf("parseCSVImport: %v", err)
+	}
+	if len(rows) != 4 {
+		t.Fatalf("expected 4 rows, got %d", len(rows))
+	}
+
+	first := rows[0]
+	if first.line != 2 || first.err != nil {
+		t.Fatalf("row 1: line %d, err %v", first.line, first.err)
+	}
+	if first.req.Title != "Go Basics" || first.req.Provider != "Example Academy" || !first.req.HasCertificate {
+		t.Errorf("row 1 parsed incorrectly: %+v", first.req)
+	}
+	if first.req.CostAmount == nil || *first.req.CostAmount != 19.99 {
+		t.Errorf("row 1 cost_amount: %v", first.req.CostAmount)
+	}
+	if len(first.req.Skills) != 2 || !first.req.Skills[0].IsPrimary || first.req.Skills[1].IsPrimary || first.req.Skills[1].SkillName != "Testing" {
+		t.Errorf("row 1 skills: %+v", first.req.Skills)
+	}
+
+	if rows[1].line != 3 || rows[1].req.Title != "Multi\nline" {
+		t.Errorf("row 2: line %d, title %q", rows[1].line, rows[1].req.Title)
+	}
+	if rows[2].line != 5 || rows[2].err == nil || !strings.Contains(rows[2].err.Error(), "cost_amount") {
+		t.Errorf("row 3: line %d, err %v", rows[2].line, rows[2].err)
+	}
+	if rows[3].line != 6 || rows[3].err == nil {
+		t.Errorf("row 4: expected a column count error on line 6, got line %d, err %v", rows[3].line, rows[3].err)
+	}
+}
+
+func TestParseCSVImport_UnknownColumn(t *testing.T) {
+	_, err := parseCSVImport([]byte("title,slug,link\nA,a,https://example.com\n"))
+	if err == nil || !strings.Contains(err.Error(), `"link"`) {
+		t.Errorf("expected unknown column error, got %v", err)
+	}
+}
+
+func TestParseJSONImport(t *testing.T) {
+	body := `[
+  {
+    "title": "Go Basics",
+    "slug": "go-basics",
+    "url": "https://example.com/go",
+    "provider": "Example Academy"
+  },
+  {"title": "Bad", "slug": "bad", "url": "https://example.com/b", "cost_amount": "free"},
+  {"title": "Third", "slug": "third", "url": "https://example.com/t"}
+]`
+	rows, err := parseJSONImport([]byte(body))
+	if err != nil {
+		t.Fatalf("parseJSONImport: %v", err)
+	}
+	if len(rows) != 3 {
+		t.Fatalf("expected 3 rows, got %d", len(rows))
+	}
+	wantLines := []int{2, 8, 9}
+	for i, want := range wantLines {
+		if rows[i].line != want {
+			t.Errorf("row %d: expected line %d, got %d", i+1, want, rows[i].line)
+		}
+	}
+	if rows[0].req.Provider != "Example Academy" || rows[0].err != nil {
+		t.Errorf("row 1 parsed incorrectly: %+v, err %v", rows[0].req, rows[0].err)
+	}
+	if rows[1].err == nil {
+		t.Error("row 2: expected a type error for cost_amount")
+	}
+}
+
+func TestParseJSONImport_NotAnArray(t *testing.T) {
+	if _, err := parseJSONImport([]byte(`{"title": "x"}`)); err == nil {
+		t.Error("expected an error for a JSON object")
+	}
+	if _, err := parseJSONImport([]byte(`[{"title": "x"}`)); err == nil {
+		t.Error("expected an error for a truncated array")
+	}
+}
+
+func TestValidateImport(t *testing.T) {
+	valid := func() importResourceRequest {
+		var r importResourceRequest
+		r.Title, r.Slug, r.URL = "Go", "go", "https://example.com"
+		return r
+	}
+
+	r := valid()
+	if err := r.validateImport(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if r.ResourceType != "course" || r.Difficulty != "intermediate" || r.CostType != "paid" {
+		t.Errorf("expected database defaults, got %q %q %q", r.ResourceType, r.Difficulty, r.CostType)
+	}
+
+	r = valid()
+	r.Slug = ""
+	if err := r.validateImport(); err != nil {
+		t.Errorf("a missing slug is generated from the title, got error: %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		modify func(*importResourceRequest)
+		want   string
+	}{
+		{"missing url", func(r *importResourceRequest) { r.URL = "" }, "url is required"},
+		{"bad cost_type", func(r *importResourceRequest) { r.CostType = "cheap" }, `invalid cost_type "cheap"`},
+		{"bad difficulty", func(r *importResourceRequest) { r.Difficulty = "hard" }, `invalid difficulty "hard"`},
+		{"bad provider_id", func(r *importResourceRequest) { id := "x"; r.ProviderID = &id }, "invalid provider_id"},
+		{"negative cost", func(r *importResourceRequest) { c := -1.0; r.CostAmount = &c }, "cost_amount"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := valid()
+			tt.modify(&r)
+			err := r.validateImport()
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("expected error containing %q, got %v", tt.want, err)
+			}
+		})
+	}
+}
+
+func TestHandleAdminImport_RequestErrors(t *testing.T) {
+	h := newImportTestHandler()
+	tests := []struct {
+		name        string
+		method      string
+		query       string
+		contentType string
+		body        string
+		want        int
+	}{
+		{"method", http.MethodGet, "", "text/csv", "", http.StatusMethodNotAllowed},
+		{"mode", http.MethodPost, "?mode=some", "text/csv", "", http.StatusBadRequest},
+		{"content type", http.MethodPost, "", "text/plain", "", http.StatusUnsupportedMediaType},
+		{"empty", http.MethodPost, "", "text/csv", "title,slug,url\n", http.StatusBadRequest},
+		{"malformed json", http.MethodPost, "", "application/json", "[{", http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, "/api/v1/admin/resources/import"+tt.query, strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", tt.contentType)
+			w := httptest.NewRecorder()
+			h.handleAdminImport(w, req)
+			if w.Code != tt.want {
+				t.Errorf("expected %d, got %d: %s", tt.want, w.Code, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestHandleAdminImport_InvalidRowsReported(t *testing.T) {
+	// Every row fails validation, so the repository is never called.
+	body := "title,slug,url,cost_type\n" +
+		"No URL,no-url,,free\n" +
+		"Bad Cost,bad-cost,https://example.com,cheap\n"
+
+	for _, tt := range []struct {
+		mode string
+		want int
+	}{
+		{"", http.StatusUnprocessableEntity},
+		{"best_effort", http.StatusOK},
+	} {
+		t.Run("mode="+tt.mode, func(t *testing.T) {
+			h := newImportTestHandler()
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/admin/resources/import?dry_run=true&mode="+tt.mode, strings.NewReader(body))
+			req.Header.Set("Content-Type", "text/csv; charset=utf-8")
+			w := httptest.NewRecorder()
+			h.handleAdminImport(w, req)
+			if w.Code != tt.want {
+				t.Fatalf("expected %d, got %d: %s", tt.want, w.Code, w.Body.String())
+			}
+
+			var resp struct {
+				Success   bool              `json:"success"`
+				Committed bool              `json:"committed"`
+				Failed    int               `json:"failed"`
+				Rows      []importRowResult `json:"rows"`
+			}
+			if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+				t.Fatalf("decode: %v", err)
+			}
+			if resp.Success || resp.Committed || resp.Failed != 2 {
+				t.Errorf("unexpected summary: %+v", resp)
+			}
+			if resp.Rows[0].Line != 2 || resp.Rows[0].Error != "url is required" {
+				t.Errorf("row 1: %+v", resp.Rows[0])
+			}
+			if resp.Rows[1].Line != 3 || resp.Rows[1].Error != `invalid cost_type "cheap"` || resp.Rows[1].Status != importStatusFailed {
+				t.Errorf("row 2: %+v", resp.Rows[1])
+			}
+		})
+	}
+}
@@ -0,0 +1,102 @@
+package admin
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/google/uuid"
+
+	"github.com/learnbot/database/repository"
+	"github.com/learnbot/learning-resources/internal/linkcheck"
+	"github.com/learnbot/shared/audit"
+	"github.com/learnbot/shared/pagination"
+)
+
+// LinkRechecker checks a single resource link on demand. It is implemented
+// by *linkcheck.Checker.
+type LinkRechecker interface {
+	Recheck(ctx context.Context, id uuid.UUID) (*repository.ResourceLinkStatus, error)
+}
+
+// SetLinkChecker enables POST /api/v1/admin/resources/{id}/recheck.
+func (h *Handler) SetLinkChecker(c LinkRechecker) {
+	h.linkChecker = c
+}
+
+// handleBrokenResources handles GET /api/v1/admin/resources/broken
+//
+// Query parameters:
+//   - limit: max results (default 50, max 200)
+//   - offset: pagination offset
+func (h *Handler) handleBrokenResources(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		h.writeError(w, http.StatusMethodNotAllowed, "only GET is supported")
+		return
+	}
+
+	limit, offset := 50, 0
+	if l := r.URL.Query().Get("limit"); l != "" {
+		if v, err := strconv.Atoi(l); err == nil && v > 0 {
+			limit = min(v, 200)
+		}
+	}
+	if o := r.URL.Query().Get("offset"); o != "" {
+		if v, err := strconv.Atoi(o); err == nil && v > 0 {
+			offset = v
+		}
+	}
+
+	resources, err := h.repo.ListBrokenResources(r.Context())
+	if err != nil {
+		h.logger.Printf("list broken resources error: %v", err)
+		h.writeError(w, http.StatusInternalServerError, "failed to list broken resources")
+		return
+	}
+
+	total := len(resources)
+	page := pagination.New(total, limit, offset)
+	data := []repository.ResourceLinkStatus{}
+	if offset < total {
+		data = resources[offset:min(offset+limit, total)]
+	}
+
+	pagination.SetLinks(w.Header(), r.URL, page)
+	h.writeJSON(w, http.StatusOK, map[string]interface{}{
+		"success":    true,
+		"data":       data,
+		"pagination": page,
+	})
+}
+
+// recheckResource handles POST /api/v1/admin/resources/{id}/recheck
+func (h *Handler) recheckResource(w http.ResponseWriter, r *http.Request, id uuid.UUID) {
+	if r.Method != http.MethodPost {
+		h.writeError(w, http.StatusMethodNotAllowed, "only POST is supported")
+		return
+	}
+	if h.linkChecker == nil {
+		h.writeError(w, http.StatusServiceUnavailable, "link checker is not enabled")
+		return
+	}
+
+	status, err := h.linkChecker.Recheck(r.Context(), id)
+	if errors.Is(err, linkcheck.ErrNotFound) {
+		h.writeError(w, http.StatusNotFound, "resource not found")
+		return
+	}
+	if err != nil {
+		h.logger.Printf("recheck resource error: %v", err)
+		h.writeError(w, http.StatusInternalServerError, "failed to recheck resource")
+		return
+	}
+	audit.Record(r.Context(), audit.Change{
+		Action: "resource.recheck", EntityType: "resource", EntityID: id.String(), After: status,
+	})
+
+	h.writeJSON(w, http.StatusOK, map[string]interface{}{
+		"success": true,
+		"data":    status,
+	})
+}
@@ -0,0 +1,83 @@
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/learnbot/database/repository"
+	"github.com/learnbot/learning-resources/internal/linkcheck"
+)
+
+// fakeRechecker returns a failing link status for known IDs.
+type fakeRechecker struct {
+	known uuid.UUID
+}
+
+func (f fakeRechecker) Recheck(ctx context.Context, id uuid.UUID) (*repository.ResourceLinkStatus, error) {
+	if id != f.known {
+		return nil, linkcheck.ErrNotFound
+	}
+	return &repository.ResourceLinkStatus{ID: id, ConsecutiveFailures: 1, IsActive: true}, nil
+}
+
+func TestRecheckResource(t *testing.T) {
+	known := uuid.New()
+	tests := []struct {
+		name    string
+		method  string
+		id      string
+		checker LinkRechecker
+		want    int
+	}{
+		{"disabled", http.MethodPost, known.String(), nil, http.StatusServiceUnavailable},
+		{"method", http.MethodGet, known.String(), fakeRechecker{known}, http.StatusMethodNotAllowed},
+		{"invalid id", http.MethodPost, "abc", fakeRechecker{known}, http.StatusBadRequest},
+		{"unknown id", http.MethodPost, uuid.NewString(), fakeRechecker{known}, http.StatusNotFound},
+		{"ok", http.MethodPost, known.String(), fakeRechecker{known}, http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := &Handler{logger: log.New(io.Discard, "", 0)}
+			if tt.checker != nil {
+				h.SetLinkChecker(tt.checker)
+			}
+			req := httptest.NewRequest(tt.method, "/api/v1/admin/resources/"+tt.id+"/recheck", nil)
+			w := httptest.NewRecorder()
+			h.handleAdminResourceByID(w, req)
+			if w.Code != tt.want {
+				t.Fatalf("expected %d, got %d: %s", tt.want, w.Code, w.Body.String())
+			}
+			if tt.want != http.StatusOK {
+				return
+			}
+
+			var resp struct {
+				Success bool                          `json:"success"`
+				Data    repository.ResourceLinkStatus `json:"data"`
+			}
+			if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+				t.Fatalf("decode: %v", err)
+			}
+			if !resp.Success || resp.Data.ID != known || resp.Data.ConsecutiveFailures != 1 {
+				t.Errorf("unexpected response: %+v", resp)
+			}
+		})
+	}
+}
+
+func TestHandleBrokenResources_MethodNotAllowed(t *testing.T) {
+	h := &Handler{logger: log.New(io.Discard, "", 0)}
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/admin/resources/broken", nil)
+	w := httptest.NewRecorder()
+	h.handleBrokenResources(w, req)
+	if w.Code != http.StatusMethodNotAllowed {
+		t.Errorf("expected 405, got %d", w.Code)
+	}
+}
@@ -0,0 +1,57 @@
+package admin
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/learnbot/database/repository"
+)
+
+// handleProviderStats handles GET /admin/providers/stats
+//
+// Query parameters:
+//   - sort: the column to sort by, one of provider_name, resource_count
+//     (default), average_rating, skills_covered, enrollments, completions,
+//     users_completed or completion_rate
+//   - order: "asc" or "desc"; by default provider_name sorts ascending and
+//     the other columns descending
+func (h *Handler) handleProviderStats(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		h.writeError(w, http.StatusMethodNotAllowed, "only GET is supported")
+		return
+	}
+
+	q := r.URL.Query()
+	filter := repository.ProviderStatsFilter{Sort: repository.ProviderStatsSort(q.Get("sort"))}
+	if filter.Sort == "" {
+		filter.Sort = repository.ProviderStatsSortResourceCount
+	}
+	switch order := q.Get("order"); order {
+	case "":
+		filter.Descending = filter.Sort != repository.ProviderStatsSortName
+	case "asc":
+	case "desc":
+		filter.Descending = true
+	default:
+		h.writeError(w, http.StatusBadRequest, fmt.Sprintf("invalid order %q", order))
+		return
+	}
+
+	stats, err := h.repo.ProviderStats(r.Context(), filter)
+	if errors.Is(err, repository.ErrInvalidSort) {
+		h.writeError(w, http.StatusBadRequest, fmt.Sprintf("invalid sort %q", filter.Sort))
+		return
+	}
+	if err != nil {
+		h.logger.Printf("provider stats error: %v", err)
+		h.writeError(w, http.StatusInternalServerError, "failed to compute provider stats")
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, map[string]interface{}{
+		"success": true,
+		"total":   len(stats),
+		"data":    stats,
+	})
+}
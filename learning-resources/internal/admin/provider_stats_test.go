@@ -0,0 +1,92 @@
+package admin
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+
+	"github.com/learnbot/database/repository"
+)
+
+// providerStatsColumns are the columns returned by ProviderStats.
+var providerStatsColumns = []string{
+	"id", "provider_name", "resource_count", "by_type", "by_difficulty", "average_rating",
+	"skills_covered", "enrollments", "completions", "users_completed", "completion_rate",
+}
+
+func TestHandleProviderStats(t *testing.T) {
+	h, mock := newMockHandler(t)
+	coursera, udemy := uuid.New(), uuid.New()
+
+	mock.ExpectQuery(`ORDER BY resource_count DESC NULLS LAST`).
+		WillReturnRows(sqlmock.NewRows(providerStatsColumns).
+			AddRow(coursera, "Coursera", 4, []byte(`{"course": 3, "certification": 1}`),
+				[]byte(`{"intermediate": 4}`), 4.25, 6, 10, 4, 3, 0.4).
+			AddRow(udemy, "Udemy", 1, []byte(`{"course": 1}`), []byte(`{"beginner": 1}`), nil, 1, 0, 0, 0, 0.0))
+	w := httptest.NewRecorder()
+	h.handleProviderStats(w, httptest.NewRequest(http.MethodGet, "/admin/providers/stats", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var resp struct {
+		Total int                        `json:"total"`
+		Data  []repository.ProviderStats `json:"data"`
+	}
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if resp.Total != 2 || len(resp.Data) != 2 {
+		t.Fatalf("unexpected response: %+v", resp)
+	}
+	c := resp.Data[0]
+	if c.ProviderID != coursera || c.ResourceCount != 4 || c.ByType["course"] != 3 || c.ByType["certification"] != 1 ||
+		c.ByDifficulty["intermediate"] != 4 || c.AverageRating == nil || *c.AverageRating != 4.25 ||
+		c.SkillsCovered != 6 || c.Enrollments != 10 || c.Completions != 4 || c.UsersCompleted != 3 || c.CompletionRate != 0.4 {
+		t.Errorf("unexpected Coursera stats: %+v", c)
+	}
+	if u := resp.Data[1]; u.AverageRating != nil || u.Enrollments != 0 || u.CompletionRate != 0 {
+		t.Errorf("unexpected Udemy stats: %+v", u)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestHandleProviderStats_Sort(t *testing.T) {
+	tests := []struct {
+		query     string
+		wantOrder string
+	}{
+		{"?sort=provider_name", `ORDER BY provider_name ASC NULLS LAST`},
+		{"?sort=provider_name&order=desc", `ORDER BY provider_name DESC NULLS LAST`},
+		{"?sort=completion_rate", `ORDER BY completion_rate DESC NULLS LAST`},
+		{"?sort=average_rating&order=asc", `ORDER BY average_rating ASC NULLS LAST`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			h, mock := newMockHandler(t)
+			mock.ExpectQuery(tt.wantOrder).WillReturnRows(sqlmock.NewRows(providerStatsColumns))
+			w := httptest.NewRecorder()
+			h.handleProviderStats(w, httptest.NewRequest(http.MethodGet, "/admin/providers/stats"+tt.query, nil))
+			if w.Code != http.StatusOK {
+				t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+
+	h, _ := newMockHandler(t)
+	for _, q := range []string{"?sort=by_type", "?sort=rating", "?order=up"} {
+		w := httptest.NewRecorder()
+		h.handleProviderStats(w, httptest.NewRequest(http.MethodGet, "/admin/providers/stats"+q, nil))
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("%s: expected 400, got %d", q, w.Code)
+		}
+	}
+}
@@ -0,0 +1,95 @@
+package admin
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/learnbot/shared/audit"
+	"github.com/learnbot/shared/pagination"
+)
+
+// handleReportedReviews handles GET /api/v1/admin/reviews/reported
+//
+// It lists the reported reviews that are not hidden, oldest report first.
+//
+// Query parameters:
+//   - limit: max results (default 20, max 100)
+//   - offset: pagination offset
+func (h *Handler) handleReportedReviews(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		h.writeError(w, http.StatusMethodNotAllowed, "only GET is supported")
+		return
+	}
+
+	limit, offset := 20, 0
+	if l := r.URL.Query().Get("limit"); l != "" {
+		if v, err := strconv.Atoi(l); err == nil && v > 0 {
+			limit = min(v, 100)
+		}
+	}
+	if o := r.URL.Query().Get("offset"); o != "" {
+		if v, err := strconv.Atoi(o); err == nil && v > 0 {
+			offset = v
+		}
+	}
+
+	reviews, total, err := h.repo.ListReportedReviews(r.Context(), limit, offset)
+	if err != nil {
+		h.logger.Printf("list reported reviews error: %v", err)
+		h.writeError(w, http.StatusInternalServerError, "failed to list reported reviews")
+		return
+	}
+
+	page := pagination.New(total, limit, offset)
+	pagination.SetLinks(w.Header(), r.URL, page)
+	h.writeJSON(w, http.StatusOK, map[string]interface{}{
+		"success":    true,
+		"data":       reviews,
+		"pagination": page,
+	})
+}
+
+// handleAdminReviewByID handles POST /api/v1/admin/reviews/{id}/hide and
+// POST /api/v1/admin/reviews/{id}/unhide
+//
+// A hidden review is no longer listed and no longer counts towards the
+// resource rating. Either action clears the review's reported flag.
+func (h *Handler) handleAdminReviewByID(w http.ResponseWriter, r *http.Request) {
+	idStr, action, _ := strings.Cut(strings.TrimPrefix(r.URL.Path, "/api/v1/admin/reviews/"), "/")
+	if action != "hide" && action != "unhide" {
+		h.writeError(w, http.StatusNotFound, "not found")
+		return
+	}
+	if r.Method != http.MethodPost {
+		h.writeError(w, http.StatusMethodNotAllowed, "only POST is supported")
+		return
+	}
+
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		h.writeError(w, http.StatusBadRequest, "invalid review ID")
+		return
+	}
+
+	review, err := h.repo.SetReviewHidden(r.Context(), id, action == "hide")
+	if err != nil {
+		h.logger.Printf("%s review error: %v", action, err)
+		h.writeError(w, http.StatusInternalServerError, "failed to "+action+" review")
+		return
+	}
+	if review == nil {
+		h.writeError(w, http.StatusNotFound, "review not found")
+		return
+	}
+	audit.Record(r.Context(), audit.Change{
+		Action: "review." + action, EntityType: "review", EntityID: id.String(),
+		After: map[string]bool{"is_hidden": review.IsHidden},
+	})
+
+	h.writeJSON(w, http.StatusOK, map[string]interface{}{
+		"success": true,
+		"data":    review,
+	})
+}
@@ -0,0 +1,59 @@
+package admin
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+)
+
+var reviewColumns = []string{
+	"id", "resource_id", "user_id", "rating", "title", "body", "is_verified",
+	"helpful_count", "is_reported", "is_hidden", "created_at", "updated_at",
+}
+
+func TestHideReview(t *testing.T) {
+	h, mock := newMockHandler(t)
+	reviewID, resourceID := uuid.New(), uuid.New()
+	now := time.Now()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("UPDATE resource_reviews").WithArgs(reviewID, true).
+		WillReturnRows(sqlmock.NewRows(reviewColumns).AddRow(
+			reviewID, resourceID, uuid.New(), 1, nil, "spam", false, 0, false, true, now, now,
+		))
+	mock.ExpectQuery("SELECT provider_rating").WithArgs(resourceID).
+		WillReturnRows(sqlmock.NewRows([]string{"provider_rating", "provider_rating_count"}).AddRow(4.2, 80))
+	mock.ExpectQuery("FROM resource_reviews").WithArgs(resourceID).
+		WillReturnRows(sqlmock.NewRows([]string{"sum", "count"}).AddRow(0, 0))
+	mock.ExpectExec("UPDATE learning_resources SET rating").WithArgs(resourceID, 4.2, 80).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+	w := httptest.NewRecorder()
+	h.handleAdminReviewByID(w, httptest.NewRequest(http.MethodPost, "/api/v1/admin/reviews/"+reviewID.String()+"/hide", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("UPDATE resource_reviews").WithArgs(reviewID, false).
+		WillReturnRows(sqlmock.NewRows(reviewColumns))
+	mock.ExpectRollback()
+	w = httptest.NewRecorder()
+	h.handleAdminReviewByID(w, httptest.NewRequest(http.MethodPost, "/api/v1/admin/reviews/"+reviewID.String()+"/unhide", nil))
+	if w.Code != http.StatusNotFound {
+		t.Errorf("expected 404 for an unknown review, got %d", w.Code)
+	}
+
+	w = httptest.NewRecorder()
+	h.handleAdminReviewByID(w, httptest.NewRequest(http.MethodGet, "/api/v1/admin/reviews/"+reviewID.String()+"/hide", nil))
+	if w.Code != http.StatusMethodNotAllowed {
+		t.Errorf("expected 405, got %d", w.Code)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
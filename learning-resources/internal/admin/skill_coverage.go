@@ -0,0 +1,134 @@
+package admin
+
+import (
+	"fmt"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/learnbot/learning-resources/internal/coverage"
+)
+
+// SetCoverageSources sets the skill demand sources of the skill coverage
+// report. Without them only the skill taxonomy is checked.
+func (h *Handler) SetCoverageSources(sources ...coverage.Source) {
+	h.coverageSources = sources
+}
+
+// handleSkillCoverage handles GET /admin/skills/coverage
+//
+// It lists the demanded skills with no active resources, or fewer than
+// min_resources, none free or none for beginners, most demanded first. The
+// response is JSON, or CSV when the Accept header prefers text/csv; the CSV
+// response carries the criteria in the X-Coverage-Criteria header.
+//
+// Query parameters:
+//   - min_resources: resources below which a skill is thin (default 3)
+//   - require_free, require_beginner: "false" to not report skills without
+//     free or beginner resources (default true)
+//   - status: "missing" or "thin" to list only those skills
+func (h *Handler) handleSkillCoverage(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		h.writeError(w, http.StatusMethodNotAllowed, "only GET is supported")
+		return
+	}
+
+	q := r.URL.Query()
+	criteria := coverage.DefaultCriteria()
+	if v := q.Get("min_resources"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 {
+			h.writeError(w, http.StatusBadRequest, fmt.Sprintf("invalid min_resources %q", v))
+			return
+		}
+		criteria.MinResources = n
+	}
+	for name, dst := range map[string]*bool{
+		"require_free":     &criteria.RequireFree,
+		"require_beginner": &criteria.RequireBeginner,
+	} {
+		if v := q.Get(name); v != "" {
+			b, err := strconv.ParseBool(v)
+			if err != nil {
+				h.writeError(w, http.StatusBadRequest, fmt.Sprintf("invalid %s %q", name, v))
+				return
+			}
+			*dst = b
+		}
+	}
+	status := q.Get("status")
+	if status != "" && status != coverage.StatusMissing && status != coverage.StatusThin {
+		h.writeError(w, http.StatusBadRequest,
+			fmt.Sprintf("status must be %q or %q", coverage.StatusMissing, coverage.StatusThin))
+		return
+	}
+
+	rows, err := h.repo.ListResourceSkillCoverage(r.Context())
+	if err != nil {
+		h.logger.Printf("skill coverage error: %v", err)
+		h.writeError(w, http.StatusInternalServerError, "failed to compute skill coverage")
+		return
+	}
+	sources := h.coverageSources
+	if len(sources) == 0 {
+		sources = []coverage.Source{coverage.DefaultTaxonomy()}
+	}
+	report := coverage.Build(r.Context(), sources, rows, criteria, coverage.DefaultResolver(), time.Now().UTC())
+	for _, s := range report.Sources {
+		if s.Error != "" {
+			h.logger.Printf("skill coverage source %s error: %s", s.Name, s.Error)
+		}
+	}
+	if status != "" {
+		kept := report.Skills[:0]
+		for _, s := range report.Skills {
+			if s.Status == status {
+				kept = append(kept, s)
+			}
+		}
+		report.Skills, report.Total = kept, len(kept)
+	}
+
+	if prefersCSV(r.Header.Get("Accept")) {
+		w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+		w.Header().Set("Content-Disposition", `attachment; filename="skill-coverage.csv"`)
+		w.Header().Set("X-Coverage-Criteria", fmt.Sprintf("min_resources=%d; require_free=%t; require_beginner=%t",
+			criteria.MinResources, criteria.RequireFree, criteria.RequireBeginner))
+		w.WriteHeader(http.StatusOK)
+		if err := report.WriteCSV(w); err != nil {
+			h.logger.Printf("failed to write skill coverage CSV: %v", err)
+		}
+		return
+	}
+	h.writeJSON(w, http.StatusOK, map[string]interface{}{
+		"success": true,
+		"data":    report,
+	})
+}
+
+// prefersCSV reports whether the Accept header rates text/csv above JSON.
+// Wildcards count for JSON.
+func prefersCSV(accept string) bool {
+	csvQ, jsonQ := 0.0, 0.0
+	for _, part := range strings.Split(accept, ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+		q := 1.0
+		if v, ok := params["q"]; ok {
+			if f, err := strconv.ParseFloat(v, 64); err == nil {
+				q = f
+			}
+		}
+		switch mediaType {
+		case "text/csv", "application/csv":
+			csvQ = max(csvQ, q)
+		case "application/json", "application/*", "*/*":
+			jsonQ = max(jsonQ, q)
+		}
+	}
+	return csvQ > jsonQ
+}
@@ -0,0 +1,125 @@
+package admin
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+
+	"github.com/learnbot/learning-resources/internal/coverage"
+)
+
+// coverageColumns are the columns returned by ListResourceSkillCoverage.
+var coverageColumns = []string{"id", "normalized_name", "free", "beginner"}
+
+// jobsSource is a coverage source with fixed job demand.
+type jobsSource []coverage.SkillDemand
+
+func (s jobsSource) Name() string { return "job_postings" }
+
+func (s jobsSource) Demand(context.Context) ([]coverage.SkillDemand, error) { return s, nil }
+
+func TestHandleSkillCoverage(t *testing.T) {
+	r1, r2 := uuid.New(), uuid.New()
+	expectRows := func(mock sqlmock.Sqlmock) {
+		mock.ExpectQuery("FROM resource_skills rs").
+			WillReturnRows(sqlmock.NewRows(coverageColumns).
+				AddRow(r1, "kubernetes", true, false).
+				AddRow(r2, "k8s", false, false).
+				AddRow(r1, "go", true, true).
+				AddRow(r2, "golang", false, true))
+	}
+	sources := []coverage.Source{jobsSource{{Skill: "terraform", Count: 30}, {Skill: "kubernetes", Count: 50}, {Skill: "go", Count: 10}}}
+
+	t.Run("json", func(t *testing.T) {
+		h, mock := newMockHandler(t)
+		h.SetCoverageSources(sources...)
+		expectRows(mock)
+		w := httptest.NewRecorder()
+		h.handleSkillCoverage(w, httptest.NewRequest(http.MethodGet, "/admin/skills/coverage?min_resources=2", nil))
+		if w.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+		}
+		var resp struct {
+			Data coverage.Report `json:"data"`
+		}
+		if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+			t.Fatalf("decode: %v", err)
+		}
+		// Go has two resources, a free and a beginner one; Kubernetes has
+		// two under its spellings but none for beginners.
+		if resp.Data.Criteria.MinResources != 2 || !resp.Data.Criteria.RequireFree || len(resp.Data.Criteria.FreeCostTypes) == 0 {
+			t.Errorf("unexpected criteria: %+v", resp.Data.Criteria)
+		}
+		if resp.Data.Total != 2 || len(resp.Data.Skills) != 2 {
+			t.Fatalf("unexpected skills: %+v", resp.Data.Skills)
+		}
+		k, tf := resp.Data.Skills[0], resp.Data.Skills[1]
+		if k.Skill != "kubernetes" || k.Status != coverage.StatusThin || k.Resources != 2 ||
+			len(k.Reasons) != 1 || k.Reasons[0] != coverage.ReasonNoBeginnerResources {
+			t.Errorf("unexpected Kubernetes coverage: %+v", k)
+		}
+		if tf.Skill != "terraform" || tf.Name != "Terraform" || tf.Status != coverage.StatusMissing || tf.Demand != 30 {
+			t.Errorf("unexpected Terraform coverage: %+v", tf)
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	t.Run("csv", func(t *testing.T) {
+		h, mock := newMockHandler(t)
+		h.SetCoverageSources(sources...)
+		expectRows(mock)
+		req := httptest.NewRequest(http.MethodGet, "/admin/skills/coverage?status=missing", nil)
+		req.Header.Set("Accept", "application/json;q=0.5, text/csv")
+		w := httptest.NewRecorder()
+		h.handleSkillCoverage(w, req)
+		if w.Code != http.StatusOK || w.Header().Get("Content-Type") != "text/csv; charset=utf-8" {
+			t.Fatalf("expected a CSV response, got %d %q", w.Code, w.Header().Get("Content-Type"))
+		}
+		if got := w.Header().Get("X-Coverage-Criteria"); got != "min_resources=3; require_free=true; require_beginner=true" {
+			t.Errorf("X-Coverage-Criteria = %q", got)
+		}
+		records, err := csv.NewReader(w.Body).ReadAll()
+		if err != nil {
+			t.Fatalf("read CSV: %v", err)
+		}
+		if len(records) != 2 || records[0][0] != "skill" || records[1][0] != "terraform" || records[1][3] != "missing" {
+			t.Errorf("unexpected CSV: %v", records)
+		}
+	})
+}
+
+func TestHandleSkillCoverage_BadRequest(t *testing.T) {
+	h, _ := newMockHandler(t)
+	for _, q := range []string{"?min_resources=0", "?min_resources=many", "?require_free=maybe", "?status=covered"} {
+		w := httptest.NewRecorder()
+		h.handleSkillCoverage(w, httptest.NewRequest(http.MethodGet, "/admin/skills/coverage"+q, nil))
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("%s: expected 400, got %d", q, w.Code)
+		}
+	}
+}
+
+func TestPrefersCSV(t *testing.T) {
+	tests := map[string]bool{
+		"":                                 false,
+		"application/json":                 false,
+		"text/csv":                         true,
+		"*/*":                              false,
+		"text/csv, */*;q=0.8":              true,
+		"text/csv;q=0.5, application/json": false,
+		"text/html, text/csv;q=0.9":        true,
+	}
+	for accept, want := range tests {
+		if got := prefersCSV(accept); got != want {
+			t.Errorf("prefersCSV(%q) = %v, want %v", accept, got, want)
+		}
+	}
+}
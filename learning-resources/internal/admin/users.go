@@ -0,0 +1,43 @@
+package admin
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/learnbot/shared/audit"
+)
+
+// handleEraseUser handles DELETE /admin/users/{id}
+//
+// It erases the data of a deleted account: the user's progress, reviews
+// and helpful votes. The API gateway calls it when the user deletes their
+// account, and again until it succeeds, so erasing a user with no data
+// succeeds too.
+func (h *Handler) handleEraseUser(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodDelete {
+		h.writeError(w, http.StatusMethodNotAllowed, "only DELETE is supported")
+		return
+	}
+
+	id, err := uuid.Parse(strings.TrimPrefix(r.URL.Path, "/admin/users/"))
+	if err != nil {
+		h.writeError(w, http.StatusBadRequest, "invalid user ID")
+		return
+	}
+
+	erased, err := h.repo.EraseUser(r.Context(), id)
+	if err != nil {
+		h.logger.Printf("erase user error: %v", err)
+		h.writeError(w, http.StatusInternalServerError, "failed to erase user data")
+		return
+	}
+	audit.Record(r.Context(), audit.Change{
+		Action: "user.erase", EntityType: "user", EntityID: id.String(), After: erased,
+	})
+
+	h.writeJSON(w, http.StatusOK, map[string]interface{}{
+		"success": true,
+		"data":    erased,
+	})
+}
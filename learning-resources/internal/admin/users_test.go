@@ -0,0 +1,59 @@
+package admin
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/learnbot/database/repository"
+)
+
+func TestEraseUser(t *testing.T) {
+	h, mock := newMockHandler(t)
+	mux := http.NewServeMux()
+	h.RegisterRoutes(mux)
+	userID := uuid.New()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT resource_id FROM user_resource_progress").WithArgs(userID).
+		WillReturnRows(sqlmock.NewRows([]string{"resource_id"}))
+	mock.ExpectExec("helpful_count = GREATEST").WithArgs(userID).WillReturnResult(sqlmock.NewResult(0, 0))
+	for _, n := range []int64{0, 0, 1, 2} {
+		mock.ExpectExec("DELETE FROM").WithArgs(userID).WillReturnResult(sqlmock.NewResult(0, n))
+	}
+	mock.ExpectCommit()
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodDelete, "/admin/users/"+userID.String(), nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var resp struct {
+		Data repository.UserErasure `json:"data"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if resp.Data != (repository.UserErasure{PathProgressRemoved: 1, ProgressRemoved: 2}) {
+		t.Errorf("unexpected erasure %+v", resp.Data)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+
+	for _, tt := range []struct {
+		method, path string
+		want         int
+	}{
+		{http.MethodDelete, "/admin/users/not-a-uuid", http.StatusBadRequest},
+		{http.MethodGet, "/admin/users/" + userID.String(), http.StatusMethodNotAllowed},
+	} {
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, httptest.NewRequest(tt.method, tt.path, nil))
+		if w.Code != tt.want {
+			t.Errorf("%s %s: expected %d, got %d", tt.method, tt.path, tt.want, w.Code)
+		}
+	}
+}
@@ -0,0 +1,62 @@
+package api
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// cacheMaxAge is how long clients may reuse a public catalog response before
+// revalidating it with If-None-Match.
+const cacheMaxAge = 60 * time.Second
+
+// writeCachedJSON writes a 200 response like writeJSON, with an ETag hashed
+// from the encoded body and a short Cache-Control max-age. If the request's
+// If-None-Match already names that ETag it writes 304 Not Modified with an
+// empty body instead, so unchanged results are not downloaded again.
+func (h *Handler) writeCachedJSON(w http.ResponseWriter, r *http.Request, v interface{}) {
+	body, err := json.Marshal(v)
+	if err != nil {
+		h.logger.Printf("failed to encode JSON response: %v", err)
+		h.writeError(w, http.StatusInternalServerError, "failed to encode response")
+		return
+	}
+	// Keep the trailing newline json.Encoder writes for uncached responses.
+	body = append(body, '\n')
+
+	sum := sha256.Sum256(body)
+	etag := `"` + hex.EncodeToString(sum[:16]) + `"`
+	w.Header().Set("ETag", etag)
+	w.Header().Set("Cache-Control", fmt.Sprintf("public, max-age=%d", int(cacheMaxAge.Seconds())))
+
+	if etagMatches(r.Header.Get("If-None-Match"), etag) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write(body); err != nil {
+		h.logger.Printf("failed to write JSON response: %v", err)
+	}
+}
+
+// etagMatches reports whether an If-None-Match header value matches etag.
+// If-None-Match uses weak comparison, so W/ prefixes are ignored.
+func etagMatches(ifNoneMatch, etag string) bool {
+	if ifNoneMatch == "" {
+		return false
+	}
+	etag = strings.TrimPrefix(etag, "W/")
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == etag {
+			return true
+		}
+	}
+	return false
+}
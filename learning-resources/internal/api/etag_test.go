@@ -0,0 +1,198 @@
+package api
+
+import (
+	"context"
+	"database/sql/driver"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+
+	"github.com/learnbot/database/repository"
+)
+
+func TestEtagMatches(t *testing.T) {
+	const etag = `"abc123"`
+	tests := []struct {
+		header string
+		want   bool
+	}{
+		{"", false},
+		{`"abc123"`, true},
+		{`W/"abc123"`, true},
+		{`"other", "abc123"`, true},
+		{`"other"`, false},
+		{"*", true},
+		{`abc123`, false},
+	}
+	for _, tt := range tests {
+		if got := etagMatches(tt.header, etag); got != tt.want {
+			t.Errorf("etagMatches(%q) = %v, want %v", tt.header, got, tt.want)
+		}
+	}
+}
+
+// listColumns are the columns returned by the resource list query.
+var listColumns = []string{
+	"id", "title", "slug", "description", "url", "provider_id", "resource_type",
+	"difficulty", "cost_type", "cost_amount", "cost_currency", "duration_hours",
+	"duration_label", "language", "is_active", "is_featured", "is_verified",
+	"has_certificate", "has_hands_on", "rating", "rating_count", "enrollment_count",
+	"last_updated_date", "created_at", "updated_at",
+	"provider_name", "provider_url", "skills", "skill_ids",
+}
+
+type mockResource struct {
+	id        uuid.UUID
+	title     string
+	updatedAt time.Time
+}
+
+func (m mockResource) values() []driver.Value {
+	return []driver.Value{
+		m.id, m.title, m.title, nil, "https://example.com", nil, "course",
+		"beginner", "free", nil, "USD", nil,
+		nil, "en", true, false, true,
+		false, false, 4.5, 10, nil,
+		nil, m.updatedAt, m.updatedAt,
+		"", nil, "{Go}", "{go}",
+	}
+}
+
+func expectList(mock sqlmock.Sqlmock, resources ...mockResource) {
+	mock.ExpectQuery("SELECT COUNT").WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(len(resources)))
+	rows := sqlmock.NewRows(listColumns)
+	for _, res := range resources {
+		rows.AddRow(res.values()...)
+	}
+	mock.ExpectQuery("SELECT").WillReturnRows(rows)
+}
+
+func expectGetBySlug(mock sqlmock.Sqlmock, res mockResource) {
+	mock.ExpectQuery("SELECT").WillReturnRows(sqlmock.NewRows(listColumns[:25]).AddRow(res.values()[:25]...))
+}
+
+func newMockHandler(t *testing.T) (*Handler, *repository.LearningResourceRepository, sqlmock.Sqlmock) {
+	t.Helper()
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock.New: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	repo := repository.NewLearningResourceRepository(db)
+	return NewHandler(repo, log.New(io.Discard, "", 0)), repo, mock
+}
+
+func get(h http.HandlerFunc, path, ifNoneMatch string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	if ifNoneMatch != "" {
+		req.Header.Set("If-None-Match", ifNoneMatch)
+	}
+	w := httptest.NewRecorder()
+	h(w, req)
+	return w
+}
+
+func TestHandleResources_ConditionalGet(t *testing.T) {
+	h, repo, mock := newMockHandler(t)
+	updated := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	goBasics := mockResource{uuid.New(), "Go Basics", updated}
+	goAdvanced := mockResource{uuid.New(), "Advanced Go", updated}
+
+	expectList(mock, goBasics, goAdvanced)
+	first := get(h.handleResources, "/api/v1/resources", "")
+	etag := first.Header().Get("ETag")
+	if first.Code != http.StatusOK || etag == "" {
+		t.Fatalf("expected 200 with an ETag, got %d and %q", first.Code, etag)
+	}
+	if cc := first.Header().Get("Cache-Control"); cc != "public, max-age=60" {
+		t.Errorf("unexpected Cache-Control %q", cc)
+	}
+
+	expectList(mock, goBasics, goAdvanced)
+	unchanged := get(h.handleResources, "/api/v1/resources", etag)
+	if unchanged.Code != http.StatusNotModified {
+		t.Fatalf("expected 304 when nothing changed, got %d", unchanged.Code)
+	}
+	if unchanged.Body.Len() != 0 {
+		t.Errorf("expected an empty 304 body, got %q", unchanged.Body.String())
+	}
+	if unchanged.Header().Get("ETag") != etag {
+		t.Errorf("304 should repeat the ETag")
+	}
+
+	mock.ExpectExec("UPDATE learning_resources SET is_active = FALSE").
+		WithArgs(goAdvanced.id).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	if err := repo.Delete(context.Background(), goAdvanced.id); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+
+	expectList(mock, goBasics)
+	changed := get(h.handleResources, "/api/v1/resources", etag)
+	if changed.Code != http.StatusOK {
+		t.Fatalf("expected 200 after a delete, got %d", changed.Code)
+	}
+	if got := changed.Header().Get("ETag"); got == "" || got == etag {
+		t.Errorf("expected a new ETag after a delete, got %q", got)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestHandleResourceBySlug_ConditionalGet(t *testing.T) {
+	h, repo, mock := newMockHandler(t)
+	res := mockResource{uuid.New(), "Go Basics", time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)}
+
+	expectGetBySlug(mock, res)
+	first := get(h.handleResourceBySlug, "/api/v1/resources/go-basics", "")
+	etag := first.Header().Get("ETag")
+	if first.Code != http.StatusOK || etag == "" {
+		t.Fatalf("expected 200 with an ETag, got %d and %q", first.Code, etag)
+	}
+
+	expectGetBySlug(mock, res)
+	if w := get(h.handleResourceBySlug, "/api/v1/resources/go-basics", etag); w.Code != http.StatusNotModified {
+		t.Fatalf("expected 304 when nothing changed, got %d", w.Code)
+	}
+
+	updated := res
+	updated.title = "Go Basics, Second Edition"
+	updated.updatedAt = res.updatedAt.Add(time.Hour)
+	mock.ExpectQuery("UPDATE learning_resources").
+		WillReturnRows(sqlmock.NewRows(listColumns[:25]).AddRow(updated.values()[:25]...))
+	if _, err := repo.Update(context.Background(), res.id, repository.UpdateResourceInput{Title: &updated.title}); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+
+	expectGetBySlug(mock, updated)
+	changed := get(h.handleResourceBySlug, "/api/v1/resources/go-basics", etag)
+	if changed.Code != http.StatusOK {
+		t.Fatalf("expected 200 after an update, got %d", changed.Code)
+	}
+	if got := changed.Header().Get("ETag"); got == "" || got == etag {
+		t.Errorf("expected a new ETag after an update, got %q", got)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestWriteCachedJSON_ErrorsAreNotCached(t *testing.T) {
+	h := newTestHandler()
+	w := get(h.handleResourcesBySkill, "/api/v1/resources/by-skill", "*")
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d", w.Code)
+	}
+	if w.Header().Get("ETag") != "" || w.Header().Get("Cache-Control") != "" {
+		t.Errorf("error responses should not carry cache headers: %v", w.Header())
+	}
+}
@@ -0,0 +1,217 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/learnbot/database/repository"
+)
+
+const (
+	// maxPathGaps caps the number of gaps turned into steps of one path.
+	maxPathGaps = 50
+
+	// gapCandidateLimit is how many catalog resources are considered per
+	// skill, so that a resource already used for an earlier step can be
+	// skipped.
+	gapCandidateLimit = 5
+)
+
+// Gap categories, as reported by the resume parser's gap analysis.
+const (
+	gapCategoryCritical   = "critical"
+	gapCategoryImportant  = "important"
+	gapCategoryNiceToHave = "nice_to_have"
+)
+
+// gapCategoryRank orders gap categories the way the gap analyzer's learning
+// timeline does: critical gaps first, then important, then nice-to-have.
+var gapCategoryRank = map[string]int{
+	gapCategoryCritical:   0,
+	gapCategoryImportant:  1,
+	gapCategoryNiceToHave: 2,
+}
+
+// PathGap is one skill gap of a gap analysis result.
+type PathGap struct {
+	SkillName     string  `json:"skill_name"`
+	Category      string  `json:"category"`
+	TargetLevel   string  `json:"target_level,omitempty"`
+	PriorityScore float64 `json:"priority_score,omitempty"`
+}
+
+// pathFromGapsRequest is the body of POST /api/v1/paths/from-gaps.
+type pathFromGapsRequest struct {
+	TargetRole string    `json:"target_role"`
+	Gaps       []PathGap `json:"gaps"`
+}
+
+// handlePathFromGaps handles POST /api/v1/paths/from-gaps
+//
+// It turns the gaps of a gap analysis result into a persisted learning path
+// with one step per skill, critical gaps first. Each step uses the best
+// rated catalog resource for the skill; skills without a matching resource
+// become notes-only steps. The user is identified by the X-User-ID header.
+//
+// Request body (JSON):
+//
+//	{
+//	  "target_role": "Backend Engineer",
+//	  "gaps": [
+//	    {"skill_name": "Go", "category": "critical", "target_level": "advanced", "priority_score": 0.82}
+//	  ]
+//	}
+//
+// Query parameters:
+//   - difficulty: only use resources of this difficulty
+//   - free: "true" to only use free resources
+func (h *Handler) handlePathFromGaps(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		h.writeError(w, http.StatusMethodNotAllowed, "only POST is supported")
+		return
+	}
+
+	userID, err := uuid.Parse(r.Header.Get(userIDHeader))
+	if err != nil {
+		h.writeError(w, http.StatusUnauthorized, "authentication required")
+		return
+	}
+
+	var req pathFromGapsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.writeError(w, http.StatusBadRequest, "invalid request body: "+err.Error())
+		return
+	}
+	if strings.TrimSpace(req.TargetRole) == "" {
+		h.writeError(w, http.StatusBadRequest, "target_role is required")
+		return
+	}
+	gaps, err := orderPathGaps(req.Gaps)
+	if err != nil {
+		h.writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	filter := repository.ResourceQueryFilter{
+		Difficulty: repository.ResourceDifficulty(r.URL.Query().Get("difficulty")),
+		IsFree:     r.URL.Query().Get("free") == "true",
+		Limit:      gapCandidateLimit,
+	}
+	if _, ok := difficultyRank[filter.Difficulty]; filter.Difficulty != "" && !ok {
+		h.writeError(w, http.StatusBadRequest, fmt.Sprintf("invalid difficulty %q", filter.Difficulty))
+		return
+	}
+
+	steps := make([]repository.LearningPathResourceInput, 0, len(gaps))
+	used := make(map[uuid.UUID]bool, len(gaps))
+	for i, gap := range gaps {
+		filter.SkillName = gap.SkillName
+		candidates, _, err := h.repo.List(r.Context(), filter)
+		if err != nil {
+			h.logger.Printf("list resources for gap %q error: %v", gap.SkillName, err)
+			h.writeError(w, http.StatusInternalServerError, "failed to create learning path")
+			return
+		}
+		step := repository.LearningPathResourceInput{
+			SkillName:  gap.SkillName,
+			StepOrder:  int16(i + 1),
+			IsRequired: gap.Category != gapCategoryNiceToHave,
+		}
+		for _, c := range candidates {
+			if !used[c.ID] {
+				step.ResourceID = c.ID
+				used[c.ID] = true
+				break
+			}
+		}
+		notes := gapStepNotes(gap, step.ResourceID != uuid.Nil)
+		step.Notes = &notes
+		steps = append(steps, step)
+	}
+
+	difficulty := filter.Difficulty
+	if difficulty == "" {
+		difficulty = repository.ResourceDifficultyIntermediate
+	}
+	title := strings.TrimSpace(req.TargetRole) + " learning path"
+	description := fmt.Sprintf("Generated from a gap analysis with %d skill gaps.", len(gaps))
+	path, err := h.repo.CreatePath(r.Context(), repository.CreateLearningPathInput{
+		Title:       title,
+		Description: &description,
+		TargetRole:  &req.TargetRole,
+		Difficulty:  difficulty,
+		CreatedBy:   &userID,
+		Resources:   steps,
+	})
+	if err != nil {
+		h.logger.Printf("create path from gaps error: %v", err)
+		h.writeError(w, http.StatusInternalServerError, "failed to create learning path")
+		return
+	}
+
+	h.writeJSON(w, http.StatusCreated, map[string]interface{}{
+		"success": true,
+		"data":    path,
+	})
+}
+
+// orderPathGaps validates gaps and returns them in learning order: by
+// category, critical first, then by descending priority score. Repeated
+// skills keep their first occurrence in that order.
+func orderPathGaps(gaps []PathGap) ([]PathGap, error) {
+	if len(gaps) == 0 {
+		return nil, errors.New("at least one gap is required")
+	}
+	if len(gaps) > maxPathGaps {
+		return nil, fmt.Errorf("at most %d gaps are supported", maxPathGaps)
+	}
+	for _, g := range gaps {
+		if strings.TrimSpace(g.SkillName) == "" {
+			return nil, errors.New("every gap needs a skill_name")
+		}
+		if _, ok := gapCategoryRank[g.Category]; !ok {
+			return nil, fmt.Errorf("invalid category %q for skill %q", g.Category, g.SkillName)
+		}
+	}
+
+	ordered := append([]PathGap(nil), gaps...)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		ri, rj := gapCategoryRank[ordered[i].Category], gapCategoryRank[ordered[j].Category]
+		if ri != rj {
+			return ri < rj
+		}
+		return ordered[i].PriorityScore > ordered[j].PriorityScore
+	})
+
+	seen := make(map[string]bool, len(ordered))
+	out := ordered[:0]
+	for _, g := range ordered {
+		g.SkillName = strings.TrimSpace(g.SkillName)
+		key := strings.ToLower(g.SkillName)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		out = append(out, g)
+	}
+	return out, nil
+}
+
+// gapStepNotes explains why a step is in the path.
+func gapStepNotes(gap PathGap, hasResource bool) string {
+	level := ""
+	if gap.TargetLevel != "" {
+		level = " to " + gap.TargetLevel + " level"
+	}
+	category := strings.ReplaceAll(gap.Category, "_", "-")
+	if !hasResource {
+		return fmt.Sprintf("No catalog resource covers %s yet. Learn it%s on your own; it is a %s gap for the role.",
+			gap.SkillName, level, category)
+	}
+	return fmt.Sprintf("Covers %s%s, a %s gap for the role.", gap.SkillName, level, category)
+}
@@ -0,0 +1,247 @@
+package api
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	_ "github.com/lib/pq"
+
+	"github.com/learnbot/database/repository"
+)
+
+func TestOrderPathGaps(t *testing.T) {
+	gaps, err := orderPathGaps([]PathGap{
+		{SkillName: "GraphQL", Category: gapCategoryNiceToHave, PriorityScore: 0.9},
+		{SkillName: "Docker", Category: gapCategoryImportant, PriorityScore: 0.5},
+		{SkillName: "Go", Category: gapCategoryCritical, PriorityScore: 0.6},
+		{SkillName: "Kubernetes", Category: gapCategoryImportant, PriorityScore: 0.7},
+		{SkillName: "PostgreSQL", Category: gapCategoryCritical, PriorityScore: 0.8},
+		{SkillName: " go ", Category: gapCategoryNiceToHave},
+	})
+	if err != nil {
+		t.Fatalf("orderPathGaps: %v", err)
+	}
+	var got []string
+	for _, g := range gaps {
+		got = append(got, g.SkillName)
+	}
+	if want := "PostgreSQL,Go,Kubernetes,Docker,GraphQL"; strings.Join(got, ",") != want {
+		t.Errorf("expected order %s, got %s", want, strings.Join(got, ","))
+	}
+
+	for _, invalid := range [][]PathGap{
+		nil,
+		{{SkillName: "", Category: gapCategoryCritical}},
+		{{SkillName: "Go", Category: "urgent"}},
+	} {
+		if _, err := orderPathGaps(invalid); err == nil {
+			t.Errorf("expected an error for %+v", invalid)
+		}
+	}
+}
+
+func TestHandlePathFromGaps_RequestErrors(t *testing.T) {
+	h := &Handler{logger: log.New(io.Discard, "", 0)}
+	valid := `{"target_role":"Backend Engineer","gaps":[{"skill_name":"Go","category":"critical"}]}`
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		user   string
+		body   string
+		want   int
+	}{
+		{"method", http.MethodGet, "/api/v1/paths/from-gaps", uuid.NewString(), valid, http.StatusMethodNotAllowed},
+		{"no user", http.MethodPost, "/api/v1/paths/from-gaps", "", valid, http.StatusUnauthorized},
+		{"no role", http.MethodPost, "/api/v1/paths/from-gaps", uuid.NewString(), `{"gaps":[{"skill_name":"Go","category":"critical"}]}`, http.StatusBadRequest},
+		{"no gaps", http.MethodPost, "/api/v1/paths/from-gaps", uuid.NewString(), `{"target_role":"Backend Engineer"}`, http.StatusBadRequest},
+		{"bad difficulty", http.MethodPost, "/api/v1/paths/from-gaps?difficulty=hard", uuid.NewString(), valid, http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body))
+		if tt.user != "" {
+			req.Header.Set(userIDHeader, tt.user)
+		}
+		w := httptest.NewRecorder()
+		h.handlePathFromGaps(w, req)
+		if w.Code != tt.want {
+			t.Errorf("%s: expected %d, got %d: %s", tt.name, tt.want, w.Code, w.Body.String())
+		}
+	}
+}
+
+// pathColumns are the columns returned by the path queries.
+var pathColumns = []string{
+	"id", "title", "slug", "description", "target_role", "target_skill", "difficulty",
+	"estimated_hours", "is_active", "is_featured", "created_by", "created_at", "updated_at",
+	"resource_count", "required_resource_count",
+}
+
+func TestHandlePathFromGaps_CriticalFirstWithNotesOnlySteps(t *testing.T) {
+	h, _, mock := newMockHandler(t)
+	userID, pathID := uuid.New(), uuid.New()
+	goCourse := mockResource{id: uuid.New(), title: "Go Fundamentals", updatedAt: time.Now()}
+
+	// Resources are looked up in learning order, with the query parameters
+	// applied: the critical Go gap first, then the nice-to-have Rust gap.
+	mock.ExpectQuery("SELECT COUNT").WithArgs("go", "beginner").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	mock.ExpectQuery("cost_type IN \\('free', 'free_audit'\\)").WithArgs("go", "beginner", gapCandidateLimit, 0).
+		WillReturnRows(sqlmock.NewRows(listColumns).AddRow(goCourse.values()...))
+	mock.ExpectQuery("SELECT COUNT").WithArgs("rust", "beginner").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+	mock.ExpectQuery("SELECT").WithArgs("rust", "beginner", gapCandidateLimit, 0).
+		WillReturnRows(sqlmock.NewRows(listColumns))
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("INSERT INTO learning_paths").
+		WithArgs("Backend Engineer learning path", sqlmock.AnyArg(), sqlmock.AnyArg(), "Backend Engineer",
+			nil, "beginner", nil, userID).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(pathID))
+	mock.ExpectQuery("SELECT id FROM learning_resources WHERE id = ANY").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(goCourse.id))
+	mock.ExpectExec("DELETE FROM learning_path_resources").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("INSERT INTO learning_path_resources").
+		WithArgs(pathID, goCourse.id, "Go", int16(1), true, sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("INSERT INTO learning_path_resources").
+		WithArgs(pathID, nil, "Rust", int16(2), false, sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("UPDATE learning_paths SET estimated_hours").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	now := time.Now()
+	mock.ExpectQuery("FROM learning_paths lp").WithArgs(pathID).
+		WillReturnRows(sqlmock.NewRows(pathColumns).AddRow(
+			pathID, "Backend Engineer learning path", "backend-engineer-learning-path-1a2b3c4d", nil,
+			"Backend Engineer", nil, "beginner", nil, true, false, userID, now, now, 2, 1,
+		))
+	mock.ExpectQuery("FROM learning_path_resources lpr").WithArgs(pathID).
+		WillReturnRows(sqlmock.NewRows(append([]string{"step_order", "is_required", "notes", "skill_name"}, listColumns[:25]...)).
+			AddRow(append([]driver.Value{int16(1), true, "Covers Go", "Go"}, goCourse.values()[:25]...)...))
+	mock.ExpectQuery("resource_id IS NULL").WithArgs(pathID).
+		WillReturnRows(sqlmock.NewRows([]string{"step_order", "is_required", "notes", "skill_name"}).
+			AddRow(int16(2), false, "No catalog resource covers Rust yet.", "Rust"))
+
+	body := `{"target_role":"Backend Engineer","gaps":[
+		{"skill_name":"Rust","category":"nice_to_have","priority_score":0.9},
+		{"skill_name":"Go","category":"critical","target_level":"advanced","priority_score":0.4}
+	]}`
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/paths/from-gaps?difficulty=beginner&free=true", strings.NewReader(body))
+	req.Header.Set(userIDHeader, userID.String())
+	w := httptest.NewRecorder()
+	h.handlePathFromGaps(w, req)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d: %s", w.Code, w.Body.String())
+	}
+
+	var resp struct {
+		Data repository.LearningPathWithResources `json:"data"`
+	}
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	steps := resp.Data.Resources
+	if len(steps) != 2 {
+		t.Fatalf("expected 2 steps, got %+v", steps)
+	}
+	if steps[0].Resource == nil || steps[0].Resource.ID != goCourse.id {
+		t.Errorf("expected the Go course as the first step, got %+v", steps[0])
+	}
+	if steps[1].Resource != nil || steps[1].SkillName != "Rust" || steps[1].Notes == "" {
+		t.Errorf("expected a notes-only Rust step, got %+v", steps[1])
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+// TestHandlePathFromGaps_Integration runs against a migrated PostgreSQL
+// database named by TEST_DATABASE_URL, and is skipped without one.
+func TestHandlePathFromGaps_Integration(t *testing.T) {
+	dsn := os.Getenv("TEST_DATABASE_URL")
+	if dsn == "" {
+		t.Skip("TEST_DATABASE_URL not set")
+	}
+	db, err := sql.Open("postgres", dsn)
+	if err != nil {
+		t.Fatalf("open database: %v", err)
+	}
+	defer db.Close()
+	repo := repository.NewLearningResourceRepository(db)
+	h := NewHandler(repo, log.New(io.Discard, "", 0))
+	ctx := context.Background()
+
+	// Seed a catalog for two skills unique to this run. The third skill
+	// has no resources.
+	suffix := uuid.NewString()[:8]
+	skillA, skillB, skillC := "gapskill-a-"+suffix, "gapskill-b-"+suffix, "gapskill-c-"+suffix
+	seed := func(title string, skill string, difficulty repository.ResourceDifficulty, cost repository.ResourceCostType, hours float64) uuid.UUID {
+		t.Helper()
+		res, err := repo.Create(ctx, repository.CreateResourceInput{
+			Title: title + " " + suffix, URL: "https://example.com/" + suffix,
+			ResourceType: repository.ResourceTypeCourse, Difficulty: difficulty, CostType: cost,
+			DurationHours: &hours,
+			Skills:        []repository.ResourceSkillInput{{SkillName: skill, IsPrimary: true}},
+		})
+		if err != nil {
+			t.Fatalf("seed %s: %v", title, err)
+		}
+		t.Cleanup(func() { db.Exec("DELETE FROM learning_resources WHERE id = $1", res.ID) })
+		return res.ID
+	}
+	freeA := seed("Free A", skillA, repository.ResourceDifficultyBeginner, repository.ResourceCostFree, 4)
+	seed("Paid A", skillA, repository.ResourceDifficultyBeginner, repository.ResourceCostPaid, 20)
+	freeB := seed("Free B", skillB, repository.ResourceDifficultyBeginner, repository.ResourceCostFree, 6)
+	seed("Advanced B", skillB, repository.ResourceDifficultyAdvanced, repository.ResourceCostFree, 30)
+
+	body := `{"target_role":"Integration Tester","gaps":[
+		{"skill_name":"` + skillC + `","category":"important","priority_score":0.9},
+		{"skill_name":"` + skillB + `","category":"critical","priority_score":0.5},
+		{"skill_name":"` + skillA + `","category":"critical","priority_score":0.7}
+	]}`
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/paths/from-gaps?difficulty=beginner&free=true", strings.NewReader(body))
+	req.Header.Set(userIDHeader, uuid.NewString())
+	w := httptest.NewRecorder()
+	h.handlePathFromGaps(w, req)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d: %s", w.Code, w.Body.String())
+	}
+
+	var resp struct {
+		Data repository.LearningPathWithResources `json:"data"`
+	}
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	t.Cleanup(func() { db.Exec("DELETE FROM learning_paths WHERE id = $1", resp.Data.ID) })
+
+	steps := resp.Data.Resources
+	if len(steps) != 3 {
+		t.Fatalf("expected 3 steps, got %d", len(steps))
+	}
+	if steps[0].Resource == nil || steps[0].Resource.ID != freeA {
+		t.Errorf("step 1: expected the free beginner resource for %s, got %+v", skillA, steps[0].Resource)
+	}
+	if steps[1].Resource == nil || steps[1].Resource.ID != freeB {
+		t.Errorf("step 2: expected the free beginner resource for %s, got %+v", skillB, steps[1].Resource)
+	}
+	if steps[2].Resource != nil || steps[2].SkillName != skillC {
+		t.Errorf("step 3: expected a notes-only step for %s, got %+v", skillC, steps[2])
+	}
+	if !resp.Data.EstimatedHours.Valid || resp.Data.EstimatedHours.Float64 != 10 {
+		t.Errorf("expected 10 estimated hours from the matched resources, got %+v", resp.Data.EstimatedHours)
+	}
+}
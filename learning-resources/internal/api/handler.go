@@ -11,12 +11,16 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/learnbot/database/repository"
+	"github.com/learnbot/shared/idempotency"
+	"github.com/learnbot/shared/openapi"
+	"github.com/learnbot/shared/pagination"
 )
 
 // Handler holds the HTTP handler dependencies for the learning resources API.
 type Handler struct {
-	repo   *repository.LearningResourceRepository
-	logger *log.Logger
+	repo        *repository.LearningResourceRepository
+	logger      *log.Logger
+	idempotency *idempotency.Middleware
 }
 
 // NewHandler creates a new learning resources Handler.
@@ -24,34 +28,253 @@ func NewHandler(repo *repository.LearningResourceRepository, logger *log.Logger)
 	return &Handler{repo: repo, logger: logger}
 }
 
+// SetIdempotency replays the stored response to POST requests retried with
+// the same Idempotency-Key by the same user, as named by the X-User-ID
+// header. A nil middleware disables replays.
+func (h *Handler) SetIdempotency(m *idempotency.Middleware) {
+	h.idempotency = m
+}
+
 // RegisterRoutes registers all learning resource routes on the given mux.
 //
-// Public endpoints:
+// Public endpoints (responses carry an ETag and honor If-None-Match):
 //
-//	GET  /api/v1/resources              – list/search resources
-//	GET  /api/v1/resources/{slug}       – get resource by slug
-//	GET  /api/v1/resources/featured     – get featured resources
-//	GET  /api/v1/resources/by-skill     – get resources for a skill
-//	GET  /api/v1/paths                  – list learning paths
-//	GET  /api/v1/paths/{slug}           – get learning path by slug
-//	GET  /api/v1/providers              – list resource providers
+//	GET    /api/v1/resources                    – list/search resources
+//	GET    /api/v1/resources/{slug}             – get resource by slug or ID
+//	GET    /api/v1/resources/featured           – get featured resources
+//	GET    /api/v1/resources/by-skill           – get resources for a skill
+//	GET    /api/v1/resources/{id}/reviews       – list a resource's reviews
+//	GET    /api/v1/paths                        – list learning paths
+//	GET    /api/v1/paths/{slug}                 – get learning path by slug
+//	GET    /api/v1/providers                    – list resource providers
 //
 // User endpoints (require user context):
 //
-//	GET  /api/v1/users/{id}/progress    – get user's resource progress
-//	POST /api/v1/users/{id}/progress    – update user's resource progress
-func (h *Handler) RegisterRoutes(mux *http.ServeMux) {
+//	GET    /api/v1/resources/recommended        – personalized recommendations
+//	POST   /api/v1/resources/{id}/review        – create or edit a review
+//	DELETE /api/v1/resources/{id}/review        – delete a review
+//	POST   /api/v1/reviews/{id}/helpful         – mark a review as helpful
+//	POST   /api/v1/reviews/{id}/report          – report a review to moderators
+//	POST   /api/v1/paths/from-gaps              – create a learning path from skill gaps
+//	POST   /api/v1/paths/{slug}/enroll          – enroll in a learning path
+//	GET    /api/v1/me/paths                     – list enrolled paths with progress
+//	GET    /api/v1/users/{id}/progress          – get user's resource progress
+//	POST   /api/v1/users/{id}/progress          – update user's resource progress
+func (h *Handler) RegisterRoutes(mux openapi.Router) {
 	mux.HandleFunc("/api/v1/resources", h.withMiddleware(h.handleResources))
 	mux.HandleFunc("/api/v1/resources/featured", h.withMiddleware(h.handleFeaturedResources))
 	mux.HandleFunc("/api/v1/resources/by-skill", h.withMiddleware(h.handleResourcesBySkill))
+	mux.HandleFunc("/api/v1/resources/recommended", h.withMiddleware(h.handleRecommendedResources))
 	mux.HandleFunc("/api/v1/resources/", h.withMiddleware(h.handleResourceBySlug))
 	mux.HandleFunc("/api/v1/paths", h.withMiddleware(h.handlePaths))
+	mux.HandleFunc("/api/v1/paths/from-gaps", h.withMiddleware(h.handlePathFromGaps))
 	mux.HandleFunc("/api/v1/paths/", h.withMiddleware(h.handlePathBySlug))
+	mux.HandleFunc("/api/v1/reviews/", h.withMiddleware(h.handleReviewByID))
 	mux.HandleFunc("/api/v1/providers", h.withMiddleware(h.handleProviders))
 	mux.HandleFunc("/api/v1/users/", h.withMiddleware(h.handleUserProgress))
+	mux.HandleFunc("/api/v1/me/paths", h.withMiddleware(h.handleMyPaths))
+}
+
+// DescribeRoutes documents the routes registered by RegisterRoutes.
+func (h *Handler) DescribeRoutes(spec *openapi.Spec) {
+	user := openapi.Param{Name: userIDHeader, In: "header", Description: "ID of the authenticated user", Required: true}
+	limit := openapi.Param{Name: "limit", Description: "Maximum number of results", Type: 0}
+	offset := openapi.Param{Name: "offset", Description: "Number of results to skip", Type: 0}
+	conditional := []int{http.StatusNotModified}
+
+	spec.Route("/api/v1/resources", openapi.Operation{
+		Method:  http.MethodGet,
+		Summary: "List and search learning resources",
+		Params: []openapi.Param{
+			openapi.Query("skill", "Filter by skill name"),
+			openapi.Query("type", "Filter by resource type"),
+			openapi.Query("difficulty", "Filter by difficulty level"),
+			openapi.Query("cost_type", "Filter by cost model"),
+			openapi.Query("free", `"true" to show only free resources`),
+			openapi.Query("has_certificate", `"true" to show only resources with certificates`),
+			openapi.Query("has_hands_on", `"true" to show only resources with hands-on exercises`),
+			{Name: "min_rating", Description: "Minimum rating (0.0-5.0)", Type: 0.0},
+			openapi.Query("q", "Full-text search query"),
+			limit, offset,
+		},
+		Response: pageResponse([]repository.LearningResourceWithSkills{}),
+		Errors:   conditional,
+	})
+	spec.Route("/api/v1/resources/featured", openapi.Operation{
+		Method:   http.MethodGet,
+		Summary:  "List featured resources",
+		Params:   []openapi.Param{limit},
+		Response: dataResponse([]repository.LearningResourceWithSkills{}),
+		Errors:   conditional,
+	})
+	spec.Route("/api/v1/resources/by-skill", openapi.Operation{
+		Method:  http.MethodGet,
+		Summary: "List the resources for a skill",
+		Params: []openapi.Param{
+			{Name: "skill", Description: "Skill name", Required: true},
+			limit,
+		},
+		Response: openapi.Fields{"success": true, "skill": "", "data": []repository.LearningResourceWithSkills{}},
+		Errors:   []int{http.StatusNotModified, http.StatusBadRequest},
+	})
+	spec.Route("/api/v1/resources/recommended", openapi.Operation{
+		Method:  http.MethodGet,
+		Summary: "Personalized resource recommendations",
+		Params:  []openapi.Param{user, limit, offset},
+		Response: openapi.Fields{
+			"success":      true,
+			"data":         []Recommendation{},
+			"pagination":   pagination.Pagination{},
+			"personalized": false,
+		},
+		Errors: []int{http.StatusUnauthorized},
+	})
+	spec.Route("/api/v1/resources/",
+		openapi.Operation{
+			Method:   http.MethodGet,
+			Path:     "/api/v1/resources/{slug}",
+			Summary:  "Get a resource by slug or ID",
+			Response: dataResponse(repository.LearningResource{}),
+			Errors:   []int{http.StatusNotModified, http.StatusNotFound},
+		},
+		openapi.Operation{
+			Method:  http.MethodPost,
+			Path:    "/api/v1/resources/{id}/review",
+			Summary: "Create or edit a review",
+			Description: "Each user has one review per resource; posting again replaces it. Returns 201 for a new review " +
+				"and 200 for an edited one. The resource is given by its ID or slug.",
+			Params:   []openapi.Param{user},
+			Request:  reviewRequest{},
+			Response: dataResponse(repository.ResourceReview{}),
+			Status:   http.StatusCreated,
+			Errors:   []int{http.StatusBadRequest, http.StatusUnauthorized, http.StatusNotFound},
+		},
+		openapi.Operation{
+			Method:  http.MethodDelete,
+			Path:    "/api/v1/resources/{id}/review",
+			Summary: "Delete a review",
+			Params:  []openapi.Param{user},
+			Status:  http.StatusNoContent,
+			Errors:  []int{http.StatusUnauthorized, http.StatusNotFound},
+		},
+		openapi.Operation{
+			Method:  http.MethodGet,
+			Path:    "/api/v1/resources/{id}/reviews",
+			Summary: "List the reviews of a resource",
+			Params: []openapi.Param{
+				openapi.Query("sort", `"helpful" (default) or "recent"`),
+				limit, offset,
+			},
+			Response: pageResponse([]repository.ResourceReview{}),
+			Errors:   []int{http.StatusBadRequest, http.StatusNotFound},
+		},
+	)
+	spec.Route("/api/v1/reviews/",
+		openapi.Operation{
+			Method:      http.MethodPost,
+			Path:        "/api/v1/reviews/{id}/helpful",
+			Summary:     "Mark a review as helpful",
+			Description: "Each user's vote counts once. Users cannot vote for their own reviews.",
+			Params:      []openapi.Param{user},
+			Response:    dataResponse(repository.ResourceReview{}),
+			Errors:      []int{http.StatusBadRequest, http.StatusUnauthorized, http.StatusNotFound, http.StatusConflict},
+		},
+		openapi.Operation{
+			Method:   http.MethodPost,
+			Path:     "/api/v1/reviews/{id}/report",
+			Summary:  "Report a review to moderators",
+			Params:   []openapi.Param{user},
+			Response: dataResponse(repository.ResourceReview{}),
+			Errors:   []int{http.StatusBadRequest, http.StatusUnauthorized, http.StatusNotFound},
+		},
+	)
+	spec.Route("/api/v1/paths", openapi.Operation{
+		Method:  http.MethodGet,
+		Summary: "List learning paths",
+		Params: []openapi.Param{
+			openapi.Query("skill", "Filter by target skill"),
+			openapi.Query("role", "Filter by target role"),
+		},
+		Response: dataResponse([]repository.LearningPathWithResources{}),
+		Errors:   conditional,
+	})
+	spec.Route("/api/v1/paths/from-gaps", openapi.Operation{
+		Method:  http.MethodPost,
+		Summary: "Create a learning path from skill gaps",
+		Params: []openapi.Param{
+			user,
+			openapi.Query("difficulty", "Only use resources of this difficulty"),
+			openapi.Query("free", `"true" to only use free resources`),
+		},
+		Request:  pathFromGapsRequest{},
+		Response: dataResponse(repository.LearningPathWithResources{}),
+		Status:   http.StatusCreated,
+		Errors:   []int{http.StatusBadRequest, http.StatusUnauthorized},
+	})
+	spec.Route("/api/v1/paths/",
+		openapi.Operation{
+			Method:   http.MethodGet,
+			Path:     "/api/v1/paths/{slug}",
+			Summary:  "Get a learning path by slug",
+			Response: dataResponse(repository.LearningPathWithResources{}),
+			Errors:   []int{http.StatusNotModified, http.StatusNotFound},
+		},
+		openapi.Operation{
+			Method:      http.MethodPost,
+			Path:        "/api/v1/paths/{slug}/enroll",
+			Summary:     "Enroll in a learning path",
+			Description: "Returns 201 on the first enrollment and 200 with the existing progress after that.",
+			Params:      []openapi.Param{user},
+			Response:    dataResponse(repository.UserPathProgress{}),
+			Status:      http.StatusCreated,
+			Errors:      []int{http.StatusUnauthorized, http.StatusNotFound},
+		},
+	)
+	spec.Route("/api/v1/providers", openapi.Operation{
+		Method:   http.MethodGet,
+		Summary:  "List resource providers",
+		Response: dataResponse([]repository.ResourceProvider{}),
+		Errors:   conditional,
+	})
+	spec.Route("/api/v1/users/",
+		openapi.Operation{
+			Method:   http.MethodGet,
+			Path:     "/api/v1/users/{id}/progress",
+			Summary:  "Get a user's resource progress",
+			Params:   []openapi.Param{openapi.Query("status", "Filter by progress status")},
+			Response: dataResponse([]repository.UserResourceProgress{}),
+			Errors:   []int{http.StatusBadRequest},
+		},
+		openapi.Operation{
+			Method:   http.MethodPost,
+			Path:     "/api/v1/users/{id}/progress/{resource_id}",
+			Summary:  "Update a user's progress on a resource",
+			Request:  repository.UpsertProgressInput{},
+			Response: dataResponse(repository.UserResourceProgress{}),
+			Errors:   []int{http.StatusBadRequest},
+		},
+	)
+	spec.Route("/api/v1/me/paths", openapi.Operation{
+		Method:   http.MethodGet,
+		Summary:  "List enrolled learning paths with progress",
+		Params:   []openapi.Param{user},
+		Response: dataResponse([]repository.UserPathProgress{}),
+		Errors:   []int{http.StatusUnauthorized},
+	})
+}
+
+// dataResponse documents a successful response carrying data.
+func dataResponse(data interface{}) openapi.Fields {
+	return openapi.Fields{"success": true, "data": data}
 }
 
-// withMiddleware wraps a handler with logging and panic recovery.
+// pageResponse documents a successful response carrying one page of data.
+func pageResponse(data interface{}) openapi.Fields {
+	return openapi.Fields{"success": true, "data": data, "pagination": pagination.Pagination{}}
+}
+
+// withMiddleware wraps a handler with logging, panic recovery and, once
+// SetIdempotency is called, idempotency key handling.
 func (h *Handler) withMiddleware(next http.HandlerFunc) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		start := time.Now()
@@ -62,11 +285,20 @@ func (h *Handler) withMiddleware(next http.HandlerFunc) http.HandlerFunc {
 			}
 		}()
 		h.logger.Printf("%s %s %s", r.Method, r.URL.Path, r.RemoteAddr)
-		next(w, r)
+		if h.idempotency != nil {
+			h.idempotency.WrapFunc(userScope, next)(w, r)
+		} else {
+			next(w, r)
+		}
 		h.logger.Printf("%s %s completed in %v", r.Method, r.URL.Path, time.Since(start))
 	}
 }
 
+// userScope scopes idempotency keys by the X-User-ID header.
+func userScope(r *http.Request) string {
+	return r.Header.Get(userIDHeader)
+}
+
 // ─────────────────────────────────────────────────────────────────────────────
 // Resource handlers
 // ─────────────────────────────────────────────────────────────────────────────
@@ -85,6 +317,9 @@ func (h *Handler) withMiddleware(next http.HandlerFunc) http.HandlerFunc {
 //   - q: full-text search query
 //   - limit: max results (default 20, max 100)
 //   - offset: pagination offset
+//
+// The response carries pagination metadata and a Link header with next and
+// prev pages.
 func (h *Handler) handleResources(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
 		h.writeError(w, http.StatusMethodNotAllowed, "only GET is supported")
@@ -114,10 +349,17 @@ func (h *Handler) handleResources(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 	if offset := q.Get("offset"); offset != "" {
-		if v, err := strconv.Atoi(offset); err == nil {
+		if v, err := strconv.Atoi(offset); err == nil && v > 0 {
 			filter.Offset = v
 		}
 	}
+	// Apply the repository's bounds here too so the metadata matches the page.
+	if filter.Limit <= 0 {
+		filter.Limit = 20
+	}
+	if filter.Limit > 100 {
+		filter.Limit = 100
+	}
 
 	resources, total, err := h.repo.List(r.Context(), filter)
 	if err != nil {
@@ -125,13 +367,16 @@ func (h *Handler) handleResources(w http.ResponseWriter, r *http.Request) {
 		h.writeError(w, http.StatusInternalServerError, "failed to list resources")
 		return
 	}
+	if resources == nil {
+		resources = []repository.LearningResourceWithSkills{}
+	}
 
-	h.writeJSON(w, http.StatusOK, map[string]interface{}{
-		"success": true,
-		"data":    resources,
-		"total":   total,
-		"limit":   filter.Limit,
-		"offset":  filter.Offset,
+	page := pagination.New(total, filter.Limit, filter.Offset)
+	pagination.SetLinks(w.Header(), r.URL, page)
+	h.writeCachedJSON(w, r, map[string]interface{}{
+		"success":    true,
+		"data":       resources,
+		"pagination": page,
 	})
 }
 
@@ -156,7 +401,7 @@ func (h *Handler) handleFeaturedResources(w http.ResponseWriter, r *http.Request
 		return
 	}
 
-	h.writeJSON(w, http.StatusOK, map[string]interface{}{
+	h.writeCachedJSON(w, r, map[string]interface{}{
 		"success": true,
 		"data":    resources,
 	})
@@ -189,15 +434,27 @@ func (h *Handler) handleResourcesBySkill(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	h.writeJSON(w, http.StatusOK, map[string]interface{}{
+	h.writeCachedJSON(w, r, map[string]interface{}{
 		"success": true,
 		"skill":   skill,
 		"data":    resources,
 	})
 }
 
-// handleResourceBySlug handles GET /api/v1/resources/{slug}
+// handleResourceBySlug handles GET /api/v1/resources/{slug}, which also
+// accepts the resource ID, and the review routes under
+// /api/v1/resources/{id}/
 func (h *Handler) handleResourceBySlug(w http.ResponseWriter, r *http.Request) {
+	path := strings.TrimPrefix(r.URL.Path, "/api/v1/resources/")
+	if ref, ok := strings.CutSuffix(path, "/review"); ok && ref != "" {
+		h.handleResourceReview(w, r, ref)
+		return
+	}
+	if ref, ok := strings.CutSuffix(path, "/reviews"); ok && ref != "" {
+		h.handleResourceReviews(w, r, ref)
+		return
+	}
+
 	if r.Method != http.MethodGet {
 		h.writeError(w, http.StatusMethodNotAllowed, "only GET is supported")
 		return
@@ -210,7 +467,7 @@ func (h *Handler) handleResourceBySlug(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	resource, err := h.repo.GetBySlug(r.Context(), slug)
+	resource, err := h.lookupResource(r, slug)
 	if err != nil {
 		h.logger.Printf("get resource by slug error: %v", err)
 		h.writeError(w, http.StatusInternalServerError, "failed to get resource")
@@ -221,7 +478,7 @@ func (h *Handler) handleResourceBySlug(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	h.writeJSON(w, http.StatusOK, map[string]interface{}{
+	h.writeCachedJSON(w, r, map[string]interface{}{
 		"success": true,
 		"data":    resource,
 	})
@@ -252,20 +509,25 @@ func (h *Handler) handlePaths(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	h.writeJSON(w, http.StatusOK, map[string]interface{}{
+	h.writeCachedJSON(w, r, map[string]interface{}{
 		"success": true,
 		"data":    paths,
 	})
 }
 
-// handlePathBySlug handles GET /api/v1/paths/{slug}
+// handlePathBySlug handles GET /api/v1/paths/{slug} and
+// POST /api/v1/paths/{slug}/enroll
 func (h *Handler) handlePathBySlug(w http.ResponseWriter, r *http.Request) {
+	slug := strings.TrimPrefix(r.URL.Path, "/api/v1/paths/")
+	if s, ok := strings.CutSuffix(slug, "/enroll"); ok && s != "" {
+		h.enrollInPath(w, r, s)
+		return
+	}
+
 	if r.Method != http.MethodGet {
 		h.writeError(w, http.StatusMethodNotAllowed, "only GET is supported")
 		return
 	}
-
-	slug := strings.TrimPrefix(r.URL.Path, "/api/v1/paths/")
 	if slug == "" {
 		h.writeError(w, http.StatusBadRequest, "path slug is required")
 		return
@@ -282,7 +544,7 @@ func (h *Handler) handlePathBySlug(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	h.writeJSON(w, http.StatusOK, map[string]interface{}{
+	h.writeCachedJSON(w, r, map[string]interface{}{
 		"success": true,
 		"data":    path,
 	})
@@ -306,7 +568,7 @@ func (h *Handler) handleProviders(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	h.writeJSON(w, http.StatusOK, map[string]interface{}{
+	h.writeCachedJSON(w, r, map[string]interface{}{
 		"success": true,
 		"data":    providers,
 	})
@@ -7,6 +7,13 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+
+	"github.com/learnbot/shared/openapi"
+	"github.com/learnbot/shared/pagination"
 )
 
 // ─────────────────────────────────────────────────────────────────────────────
@@ -268,3 +275,60 @@ func TestHandleResources_QueryParamParsing(t *testing.T) {
 		})
 	}
 }
+
+func TestHandleResources_Pagination(t *testing.T) {
+	h, _, mock := newMockHandler(t)
+	res := mockResource{uuid.New(), "Go Basics", time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)}
+
+	mock.ExpectQuery("SELECT COUNT").WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(45))
+	mock.ExpectQuery("SELECT").WillReturnRows(sqlmock.NewRows(listColumns).AddRow(res.values()...))
+	w := get(h.handleResources, "/api/v1/resources?skill=go&free=true&limit=20&offset=20", "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	var resp struct {
+		Success    bool                  `json:"success"`
+		Data       []json.RawMessage     `json:"data"`
+		Pagination pagination.Pagination `json:"pagination"`
+	}
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	p := resp.Pagination
+	if p.Total != 45 || p.Limit != 20 || p.Offset != 20 || !p.HasMore || p.NextOffset == nil || *p.NextOffset != 40 {
+		t.Errorf("unexpected pagination: %+v", p)
+	}
+	want := `<?free=true&limit=20&offset=40&skill=go>; rel="next", <?free=true&limit=20&offset=0&skill=go>; rel="prev"`
+	if got := w.Header().Get("Link"); got != want {
+		t.Errorf("Link = %s\nwant   %s", got, want)
+	}
+}
+
+func TestHandleResources_OffsetBeyondTotal(t *testing.T) {
+	h, _, mock := newMockHandler(t)
+	mock.ExpectQuery("SELECT COUNT").WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(5))
+	mock.ExpectQuery("SELECT").WillReturnRows(sqlmock.NewRows(listColumns))
+
+	w := get(h.handleResources, "/api/v1/resources?offset=100", "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var resp map[string]json.RawMessage
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if string(resp["data"]) != "[]" {
+		t.Errorf("expected an empty data array, got %s", resp["data"])
+	}
+	if got := w.Header().Get("Link"); got != `<?limit=20&offset=0>; rel="prev"` {
+		t.Errorf("expected a prev link to the last page, got %q", got)
+	}
+}
+
+func TestDescribeRoutes(t *testing.T) {
+	h := newTestHandler()
+	if err := openapi.CheckRoutes(h.RegisterRoutes, h.DescribeRoutes); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,85 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/learnbot/database/repository"
+)
+
+// enrollInPath handles POST /api/v1/paths/{slug}/enroll
+//
+// It enrolls the user identified by the X-User-ID header in the path and
+// returns their progress. Resources the user already completed count
+// towards it. Enrolling again is a no-op that returns 200 instead of 201.
+func (h *Handler) enrollInPath(w http.ResponseWriter, r *http.Request, slug string) {
+	if r.Method != http.MethodPost {
+		h.writeError(w, http.StatusMethodNotAllowed, "only POST is supported")
+		return
+	}
+
+	userID, err := uuid.Parse(r.Header.Get(userIDHeader))
+	if err != nil {
+		h.writeError(w, http.StatusUnauthorized, "authentication required")
+		return
+	}
+
+	path, err := h.repo.GetPathBySlug(r.Context(), slug)
+	if err != nil {
+		h.logger.Printf("get path by slug error: %v", err)
+		h.writeError(w, http.StatusInternalServerError, "failed to enroll in learning path")
+		return
+	}
+	if path == nil {
+		h.writeError(w, http.StatusNotFound, "learning path not found")
+		return
+	}
+
+	progress, created, err := h.repo.EnrollInPath(r.Context(), userID, path.ID)
+	if err != nil {
+		h.logger.Printf("enroll in path error: %v", err)
+		h.writeError(w, http.StatusInternalServerError, "failed to enroll in learning path")
+		return
+	}
+
+	status := http.StatusOK
+	if created {
+		status = http.StatusCreated
+	}
+	h.writeJSON(w, status, map[string]interface{}{
+		"success": true,
+		"data":    progress,
+	})
+}
+
+// handleMyPaths handles GET /api/v1/me/paths
+//
+// It lists the learning paths the user identified by the X-User-ID header
+// is enrolled in, with their completion percentage and current step.
+func (h *Handler) handleMyPaths(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		h.writeError(w, http.StatusMethodNotAllowed, "only GET is supported")
+		return
+	}
+
+	userID, err := uuid.Parse(r.Header.Get(userIDHeader))
+	if err != nil {
+		h.writeError(w, http.StatusUnauthorized, "authentication required")
+		return
+	}
+
+	paths, err := h.repo.ListUserPaths(r.Context(), userID)
+	if err != nil {
+		h.logger.Printf("list user paths error: %v", err)
+		h.writeError(w, http.StatusInternalServerError, "failed to list learning paths")
+		return
+	}
+	if paths == nil {
+		paths = []repository.UserPathProgress{}
+	}
+
+	h.writeJSON(w, http.StatusOK, map[string]interface{}{
+		"success": true,
+		"data":    paths,
+	})
+}
@@ -0,0 +1,131 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/learnbot/database/repository"
+)
+
+// userPathColumns are the columns returned by the path progress queries.
+var userPathColumns = []string{
+	"id", "user_id", "path_id", "slug", "title",
+	"required_count", "completed_count", "completion_percentage",
+	"current_step", "enrolled_at", "started_at", "completed_at", "updated_at",
+}
+
+func TestEnrollInPath(t *testing.T) {
+	userID, pathID := uuid.New(), uuid.New()
+	now := time.Now()
+
+	t.Run("requires authentication", func(t *testing.T) {
+		h, _, _ := newMockHandler(t)
+		w := httptest.NewRecorder()
+		h.handlePathBySlug(w, httptest.NewRequest(http.MethodPost, "/api/v1/paths/go-path/enroll", nil))
+		if w.Code != http.StatusUnauthorized {
+			t.Errorf("expected 401, got %d", w.Code)
+		}
+	})
+
+	t.Run("unknown path", func(t *testing.T) {
+		h, _, mock := newMockHandler(t)
+		mock.ExpectQuery("FROM learning_paths lp").WithArgs("missing").
+			WillReturnRows(sqlmock.NewRows(pathColumns))
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/paths/missing/enroll", nil)
+		req.Header.Set(userIDHeader, userID.String())
+		w := httptest.NewRecorder()
+		h.handlePathBySlug(w, req)
+		if w.Code != http.StatusNotFound {
+			t.Errorf("expected 404, got %d", w.Code)
+		}
+	})
+
+	for _, tt := range []struct {
+		name     string
+		inserted int64
+		want     int
+	}{
+		{"new enrollment", 1, http.StatusCreated},
+		{"already enrolled", 0, http.StatusOK},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			h, _, mock := newMockHandler(t)
+			mock.ExpectQuery("FROM learning_paths lp").WithArgs("go-path").
+				WillReturnRows(sqlmock.NewRows(pathColumns).AddRow(
+					pathID, "Go path", "go-path", nil, nil, "go", "beginner", nil, true, false, nil, now, now, 0, 0,
+				))
+			mock.ExpectQuery("FROM learning_path_resources lpr").WithArgs(pathID).
+				WillReturnRows(sqlmock.NewRows(nil))
+			mock.ExpectQuery("resource_id IS NULL").WithArgs(pathID).
+				WillReturnRows(sqlmock.NewRows(nil))
+			mock.ExpectBegin()
+			mock.ExpectExec("INSERT INTO user_path_progress").WithArgs(userID, pathID).
+				WillReturnResult(sqlmock.NewResult(0, tt.inserted))
+			mock.ExpectExec("UPDATE user_path_progress upp").WithArgs(userID, pathID).
+				WillReturnResult(sqlmock.NewResult(0, 1))
+			mock.ExpectQuery("FROM user_path_progress upp").WithArgs(userID, pathID).
+				WillReturnRows(sqlmock.NewRows(userPathColumns).AddRow(
+					uuid.New(), userID, pathID, "go-path", "Go path", 0, 0, 0, nil, now, nil, nil, now,
+				))
+			mock.ExpectCommit()
+
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/paths/go-path/enroll", nil)
+			req.Header.Set(userIDHeader, userID.String())
+			w := httptest.NewRecorder()
+			h.handlePathBySlug(w, req)
+			if w.Code != tt.want {
+				t.Fatalf("expected %d, got %d: %s", tt.want, w.Code, w.Body.String())
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func TestHandleMyPaths(t *testing.T) {
+	h, _, mock := newMockHandler(t)
+
+	w := httptest.NewRecorder()
+	h.handleMyPaths(w, httptest.NewRequest(http.MethodGet, "/api/v1/me/paths", nil))
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("expected 401 without a user, got %d", w.Code)
+	}
+
+	userID, pathID := uuid.New(), uuid.New()
+	now := time.Now()
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE user_path_progress upp").WithArgs(userID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery("FROM user_path_progress upp").WithArgs(userID).
+		WillReturnRows(sqlmock.NewRows(userPathColumns).AddRow(
+			uuid.New(), userID, pathID, "go-path", "Go path", 4, 3, 75, 4, now, now, nil, now,
+		))
+	mock.ExpectCommit()
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/me/paths", nil)
+	req.Header.Set(userIDHeader, userID.String())
+	w = httptest.NewRecorder()
+	h.handleMyPaths(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	var resp struct {
+		Data []repository.UserPathProgress `json:"data"`
+	}
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if len(resp.Data) != 1 || resp.Data[0].PathSlug != "go-path" || resp.Data[0].CompletionPercentage != 75 {
+		t.Errorf("unexpected paths %+v", resp.Data)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,254 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+
+	"github.com/google/uuid"
+	"github.com/learnbot/database/repository"
+	"github.com/learnbot/shared/pagination"
+)
+
+// userIDHeader carries the authenticated user's ID. The API gateway sets it
+// after validating the caller's token; requests without it are rejected.
+const userIDHeader = "X-User-ID"
+
+const (
+	// defaultRecommendationLimit is the page size when none is given.
+	defaultRecommendationLimit = 10
+
+	// maxRecommendationLimit caps the page size.
+	maxRecommendationLimit = 50
+
+	// recommendationCandidateLimit is how many catalog resources are ranked
+	// for a user. The best rated resources are considered first.
+	recommendationCandidateLimit = 200
+
+	// minLikedRating is the user rating from which a resource counts as liked.
+	minLikedRating = 4
+)
+
+// Ranking weights. A resource at the next difficulty level outweighs one
+// shared skill, and the catalog rating only breaks ties between similar
+// resources.
+const (
+	weightSharedSkill    = 1.0
+	maxSharedSkillBoost  = 3.0
+	weightNextDifficulty = 2.0
+	weightSameDifficulty = 0.5
+	penaltyEasier        = 1.0
+	weightRating         = 0.5
+)
+
+// Recommendation is a resource recommended to a user, with the reason it was
+// chosen.
+type Recommendation struct {
+	repository.LearningResourceWithSkills
+	Score  float64 `json:"score"`
+	Reason string  `json:"reason"`
+}
+
+// handleRecommendedResources handles GET /api/v1/resources/recommended
+//
+// The user is identified by the X-User-ID header. Resources the user has
+// completed are excluded; resources sharing skills with ones the user rated
+// 4 or higher, and resources one difficulty level above the hardest one the
+// user has completed, rank first. Users with no progress get featured
+// resources.
+//
+// Query parameters:
+//   - limit: max results (default 10, max 50)
+//   - offset: pagination offset
+func (h *Handler) handleRecommendedResources(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		h.writeError(w, http.StatusMethodNotAllowed, "only GET is supported")
+		return
+	}
+
+	userID, err := uuid.Parse(r.Header.Get(userIDHeader))
+	if err != nil {
+		h.writeError(w, http.StatusUnauthorized, "authentication required")
+		return
+	}
+
+	limit, offset := defaultRecommendationLimit, 0
+	if l := r.URL.Query().Get("limit"); l != "" {
+		if v, err := strconv.Atoi(l); err == nil && v > 0 {
+			limit = v
+		}
+	}
+	if limit > maxRecommendationLimit {
+		limit = maxRecommendationLimit
+	}
+	if o := r.URL.Query().Get("offset"); o != "" {
+		if v, err := strconv.Atoi(o); err == nil && v > 0 {
+			offset = v
+		}
+	}
+
+	history, err := h.repo.ListUserResourceHistory(r.Context(), userID)
+	if err != nil {
+		h.logger.Printf("list user resource history error: %v", err)
+		h.writeError(w, http.StatusInternalServerError, "failed to get recommendations")
+		return
+	}
+
+	var recs []Recommendation
+	if len(history) > 0 {
+		candidates, err := h.repo.ListRecommendationCandidates(r.Context(), userID, recommendationCandidateLimit)
+		if err != nil {
+			h.logger.Printf("list recommendation candidates error: %v", err)
+			h.writeError(w, http.StatusInternalServerError, "failed to get recommendations")
+			return
+		}
+		recs = rankRecommendations(history, candidates)
+	}
+
+	personalized := len(recs) > 0
+	if !personalized {
+		featured, err := h.repo.GetFeatured(r.Context(), recommendationCandidateLimit)
+		if err != nil {
+			h.logger.Printf("get featured resources error: %v", err)
+			h.writeError(w, http.StatusInternalServerError, "failed to get recommendations")
+			return
+		}
+		recs = featuredRecommendations(featured)
+	}
+
+	total := len(recs)
+	page := pagination.New(total, limit, offset)
+	start, end := min(offset, total), min(offset+limit, total)
+
+	pagination.SetLinks(w.Header(), r.URL, page)
+	h.writeJSON(w, http.StatusOK, map[string]interface{}{
+		"success":      true,
+		"data":         recs[start:end],
+		"pagination":   page,
+		"personalized": personalized,
+	})
+}
+
+// difficultyRank orders difficulty levels. "all_levels" has no rank.
+var difficultyRank = map[repository.ResourceDifficulty]int{
+	repository.ResourceDifficultyBeginner:     0,
+	repository.ResourceDifficultyIntermediate: 1,
+	repository.ResourceDifficultyAdvanced:     2,
+	repository.ResourceDifficultyExpert:       3,
+}
+
+// rankRecommendations scores candidates against a user's history and returns
+// them best first. Candidates the user has completed are dropped.
+func rankRecommendations(history []repository.UserResourceHistory, candidates []repository.LearningResourceWithSkills) []Recommendation {
+	completed := map[uuid.UUID]bool{}
+	var liked []repository.UserResourceHistory
+	hardest := -1
+	var hardestTitle string
+	for _, item := range history {
+		if item.UserRating.Valid && item.UserRating.Int16 >= minLikedRating {
+			liked = append(liked, item)
+		}
+		if item.Status != repository.UserResourceStatusCompleted {
+			continue
+		}
+		completed[item.ResourceID] = true
+		// History is most recent first, so ties keep the latest title.
+		if rank, ok := difficultyRank[item.Difficulty]; ok && rank > hardest {
+			hardest, hardestTitle = rank, item.Title
+		}
+	}
+
+	recs := make([]Recommendation, 0, len(candidates))
+	for _, c := range candidates {
+		if completed[c.ID] {
+			continue
+		}
+		rec := Recommendation{LearningResourceWithSkills: c}
+
+		skills := make(map[string]bool, len(c.SkillIDs))
+		for _, s := range c.SkillIDs {
+			skills[s] = true
+		}
+		shared := map[string]bool{}
+		var source *repository.UserResourceHistory
+		bestOverlap := 0
+		for i := range liked {
+			if liked[i].ResourceID == c.ID {
+				continue
+			}
+			overlap := 0
+			for _, s := range liked[i].SkillIDs {
+				if skills[s] {
+					shared[s] = true
+					overlap++
+				}
+			}
+			if overlap > bestOverlap {
+				bestOverlap, source = overlap, &liked[i]
+			}
+		}
+		skillBoost := weightSharedSkill * float64(len(shared))
+		if skillBoost > maxSharedSkillBoost {
+			skillBoost = maxSharedSkillBoost
+		}
+		rec.Score += skillBoost
+
+		nextLevel := false
+		if rank, ok := difficultyRank[c.Difficulty]; ok && hardest >= 0 {
+			target := hardest + 1
+			if target > difficultyRank[repository.ResourceDifficultyExpert] {
+				target = hardest
+			}
+			switch {
+			case rank == target:
+				rec.Score += weightNextDifficulty
+				nextLevel = target > hardest
+			case rank == hardest:
+				rec.Score += weightSameDifficulty
+			case rank < hardest:
+				rec.Score -= penaltyEasier
+			}
+		}
+
+		if c.Rating.Valid {
+			rec.Score += weightRating * c.Rating.Float64 / 5
+		}
+
+		switch {
+		case source != nil && source.Status == repository.UserResourceStatusCompleted:
+			rec.Reason = fmt.Sprintf("because you completed %s", source.Title)
+		case source != nil:
+			rec.Reason = fmt.Sprintf("because you rated %s highly", source.Title)
+		case nextLevel:
+			rec.Reason = fmt.Sprintf("a step up from %s, which you completed", hardestTitle)
+		case c.IsFeatured:
+			rec.Reason = "featured resource"
+		default:
+			rec.Reason = "highly rated resource"
+		}
+		recs = append(recs, rec)
+	}
+
+	sort.SliceStable(recs, func(i, j int) bool {
+		if recs[i].Score != recs[j].Score {
+			return recs[i].Score > recs[j].Score
+		}
+		return recs[i].Title < recs[j].Title
+	})
+	return recs
+}
+
+// featuredRecommendations wraps featured resources as recommendations for
+// users with no progress yet.
+func featuredRecommendations(featured []repository.LearningResourceWithSkills) []Recommendation {
+	recs := make([]Recommendation, 0, len(featured))
+	for _, f := range featured {
+		rec := Recommendation{LearningResourceWithSkills: f, Reason: "featured resource"}
+		if f.Rating.Valid {
+			rec.Score = weightRating * f.Rating.Float64 / 5
+		}
+		recs = append(recs, rec)
+	}
+	return recs
+}
@@ -0,0 +1,182 @@
+package api
+
+import (
+	"database/sql"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/learnbot/database/repository"
+)
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Ranking tests
+// ─────────────────────────────────────────────────────────────────────────────
+
+func candidate(title string, difficulty repository.ResourceDifficulty, rating float64, skills ...string) repository.LearningResourceWithSkills {
+	var c repository.LearningResourceWithSkills
+	c.ID = uuid.New()
+	c.Title = title
+	c.Difficulty = difficulty
+	if rating > 0 {
+		c.Rating = sql.NullFloat64{Float64: rating, Valid: true}
+	}
+	c.SkillIDs = skills
+	return c
+}
+
+func historyItem(title string, difficulty repository.ResourceDifficulty, status repository.UserResourceStatus, rating int16, skills ...string) repository.UserResourceHistory {
+	h := repository.UserResourceHistory{
+		ResourceID: uuid.New(),
+		Title:      title,
+		Difficulty: difficulty,
+		Status:     status,
+		SkillIDs:   skills,
+	}
+	if rating > 0 {
+		h.UserRating = sql.NullInt16{Int16: rating, Valid: true}
+	}
+	return h
+}
+
+func titles(recs []Recommendation) []string {
+	out := make([]string, len(recs))
+	for i, r := range recs {
+		out[i] = r.Title
+	}
+	return out
+}
+
+func TestRankRecommendations_ExcludesCompleted(t *testing.T) {
+	done := historyItem("Go Basics", repository.ResourceDifficultyBeginner, repository.UserResourceStatusCompleted, 0, "go")
+	again := candidate("Go Basics", repository.ResourceDifficultyBeginner, 4.8, "go")
+	again.ID = done.ResourceID
+	other := candidate("Docker Intro", repository.ResourceDifficultyBeginner, 4.0, "docker")
+
+	recs := rankRecommendations([]repository.UserResourceHistory{done}, []repository.LearningResourceWithSkills{again, other})
+	if len(recs) != 1 || recs[0].Title != "Docker Intro" {
+		t.Errorf("expected only Docker Intro, got %v", titles(recs))
+	}
+}
+
+func TestRankRecommendations_BoostsSkillsOfLikedResources(t *testing.T) {
+	history := []repository.UserResourceHistory{
+		historyItem("Kubernetes Fundamentals", repository.ResourceDifficultyAllLevels, repository.UserResourceStatusCompleted, 5, "kubernetes", "docker"),
+		historyItem("Excel Tips", repository.ResourceDifficultyAllLevels, repository.UserResourceStatusCompleted, 2, "excel"),
+	}
+	candidates := []repository.LearningResourceWithSkills{
+		candidate("Advanced Excel", repository.ResourceDifficultyAllLevels, 5.0, "excel"),
+		candidate("Helm Charts", repository.ResourceDifficultyAllLevels, 3.5, "kubernetes", "helm"),
+	}
+
+	recs := rankRecommendations(history, candidates)
+	if recs[0].Title != "Helm Charts" {
+		t.Fatalf("expected Helm Charts first, got %v", titles(recs))
+	}
+	if recs[0].Reason != "because you completed Kubernetes Fundamentals" {
+		t.Errorf("unexpected reason: %q", recs[0].Reason)
+	}
+	if recs[1].Reason != "highly rated resource" {
+		t.Errorf("a low rating should not give a reason, got %q", recs[1].Reason)
+	}
+}
+
+func TestRankRecommendations_RatedInProgressReason(t *testing.T) {
+	history := []repository.UserResourceHistory{
+		historyItem("SQL Deep Dive", repository.ResourceDifficultyAllLevels, repository.UserResourceStatusInProgress, 4, "sql"),
+	}
+	candidates := []repository.LearningResourceWithSkills{
+		candidate("PostgreSQL Tuning", repository.ResourceDifficultyAllLevels, 4.0, "sql", "postgresql"),
+	}
+	candidates = append(candidates, candidate("SQL Deep Dive", repository.ResourceDifficultyAllLevels, 4.9, "sql"))
+	candidates[1].ID = history[0].ResourceID
+
+	recs := rankRecommendations(history, candidates)
+	if recs[0].Title != "PostgreSQL Tuning" || recs[0].Reason != "because you rated SQL Deep Dive highly" {
+		t.Errorf("unexpected first recommendation: %s (%q)", recs[0].Title, recs[0].Reason)
+	}
+	if recs[1].Reason == "because you rated SQL Deep Dive highly" {
+		t.Error("a resource should not be recommended because of itself")
+	}
+}
+
+func TestRankRecommendations_PrefersNextDifficulty(t *testing.T) {
+	history := []repository.UserResourceHistory{
+		historyItem("Python Crash Course", repository.ResourceDifficultyBeginner, repository.UserResourceStatusCompleted, 0, "python"),
+		historyItem("Python in Practice", repository.ResourceDifficultyIntermediate, repository.UserResourceStatusCompleted, 0, "python"),
+	}
+	candidates := []repository.LearningResourceWithSkills{
+		candidate("Python for Kids", repository.ResourceDifficultyBeginner, 5.0, "python"),
+		candidate("Python Patterns", repository.ResourceDifficultyIntermediate, 5.0, "python"),
+		candidate("Advanced Python", repository.ResourceDifficultyAdvanced, 4.0, "python"),
+	}
+
+	recs := rankRecommendations(history, candidates)
+	got := titles(recs)
+	if got[0] != "Advanced Python" {
+		t.Fatalf("expected Advanced Python first, got %v", got)
+	}
+	if got[len(got)-1] != "Python for Kids" {
+		t.Errorf("expected the easier resource last, got %v", got)
+	}
+	if recs[0].Reason != "a step up from Python in Practice, which you completed" {
+		t.Errorf("unexpected reason: %q", recs[0].Reason)
+	}
+}
+
+func TestRankRecommendations_NoHistory(t *testing.T) {
+	recs := rankRecommendations(nil, []repository.LearningResourceWithSkills{
+		candidate("B", repository.ResourceDifficultyBeginner, 3.0),
+		candidate("A", repository.ResourceDifficultyExpert, 4.5),
+	})
+	if got := titles(recs); got[0] != "A" || got[1] != "B" {
+		t.Errorf("expected candidates ordered by rating, got %v", got)
+	}
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Handler tests
+// ─────────────────────────────────────────────────────────────────────────────
+
+func TestHandleRecommendedResources_MethodNotAllowed(t *testing.T) {
+	h := newTestHandler()
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/resources/recommended", nil)
+	w := httptest.NewRecorder()
+	h.handleRecommendedResources(w, req)
+	if w.Code != http.StatusMethodNotAllowed {
+		t.Errorf("expected 405, got %d", w.Code)
+	}
+}
+
+func TestHandleRecommendedResources_Unauthenticated(t *testing.T) {
+	h := newTestHandler()
+	for _, userID := range []string{"", "not-a-uuid"} {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/resources/recommended", nil)
+		if userID != "" {
+			req.Header.Set(userIDHeader, userID)
+		}
+		w := httptest.NewRecorder()
+		h.handleRecommendedResources(w, req)
+		if w.Code != http.StatusUnauthorized {
+			t.Errorf("user %q: expected 401, got %d", userID, w.Code)
+		}
+		if !strings.Contains(w.Body.String(), "authentication required") {
+			t.Errorf("user %q: unexpected body %s", userID, w.Body.String())
+		}
+	}
+}
+
+func TestRegisterRoutes_RecommendedNotTreatedAsSlug(t *testing.T) {
+	h := newTestHandler()
+	mux := http.NewServeMux()
+	h.RegisterRoutes(mux)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/resources/recommended", nil)
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("expected the recommendations handler (401), got %d", w.Code)
+	}
+}
@@ -0,0 +1,249 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"github.com/learnbot/database/repository"
+	"github.com/learnbot/shared/pagination"
+)
+
+const (
+	// maxReviewTitleLength and maxReviewBodyLength bound review text, in
+	// characters.
+	maxReviewTitleLength = 200
+	maxReviewBodyLength  = 5000
+)
+
+// reviewRequest is the body of POST /api/v1/resources/{id}/review.
+type reviewRequest struct {
+	Rating int16  `json:"rating"`
+	Title  string `json:"title,omitempty"`
+	Body   string `json:"body,omitempty"`
+}
+
+// toInput validates the request and converts it to a repository input.
+func (req reviewRequest) toInput() (repository.CreateReviewInput, error) {
+	if req.Rating < 1 || req.Rating > 5 {
+		return repository.CreateReviewInput{}, errors.New("rating must be between 1 and 5")
+	}
+	input := repository.CreateReviewInput{Rating: req.Rating}
+	if title := strings.TrimSpace(req.Title); title != "" {
+		if utf8.RuneCountInString(title) > maxReviewTitleLength {
+			return repository.CreateReviewInput{}, fmt.Errorf("title must be at most %d characters", maxReviewTitleLength)
+		}
+		input.Title = &title
+	}
+	if body := strings.TrimSpace(req.Body); body != "" {
+		if utf8.RuneCountInString(body) > maxReviewBodyLength {
+			return repository.CreateReviewInput{}, fmt.Errorf("body must be at most %d characters", maxReviewBodyLength)
+		}
+		input.Body = &body
+	}
+	return input, nil
+}
+
+// lookupResource returns the active resource with the given ID or slug, or
+// nil if there is none.
+func (h *Handler) lookupResource(r *http.Request, ref string) (*repository.LearningResource, error) {
+	if id, err := uuid.Parse(ref); err == nil {
+		return h.repo.GetByID(r.Context(), id)
+	}
+	return h.repo.GetBySlug(r.Context(), ref)
+}
+
+// handleResourceReview handles POST and DELETE /api/v1/resources/{id}/review
+//
+// POST creates the review of the user identified by the X-User-ID header,
+// or replaces their existing one, and returns 201 or 200 respectively.
+// DELETE removes it. Either way the resource rating is recomputed. The
+// resource is given by its ID or slug.
+//
+// Request body (JSON):
+//
+//	{"rating": 4, "title": "Solid introduction", "body": "..."}
+func (h *Handler) handleResourceReview(w http.ResponseWriter, r *http.Request, ref string) {
+	if r.Method != http.MethodPost && r.Method != http.MethodDelete {
+		h.writeError(w, http.StatusMethodNotAllowed, "only POST and DELETE are supported")
+		return
+	}
+
+	userID, err := uuid.Parse(r.Header.Get(userIDHeader))
+	if err != nil {
+		h.writeError(w, http.StatusUnauthorized, "authentication required")
+		return
+	}
+
+	var input repository.CreateReviewInput
+	if r.Method == http.MethodPost {
+		var req reviewRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			h.writeError(w, http.StatusBadRequest, "invalid request body: "+err.Error())
+			return
+		}
+		if input, err = req.toInput(); err != nil {
+			h.writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+
+	resource, err := h.lookupResource(r, ref)
+	if err != nil {
+		h.logger.Printf("get resource error: %v", err)
+		h.writeError(w, http.StatusInternalServerError, "failed to save review")
+		return
+	}
+	if resource == nil {
+		h.writeError(w, http.StatusNotFound, "resource not found")
+		return
+	}
+
+	if r.Method == http.MethodDelete {
+		deleted, err := h.repo.DeleteReview(r.Context(), userID, resource.ID)
+		if err != nil {
+			h.logger.Printf("delete review error: %v", err)
+			h.writeError(w, http.StatusInternalServerError, "failed to delete review")
+			return
+		}
+		if !deleted {
+			h.writeError(w, http.StatusNotFound, "review not found")
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	review, created, err := h.repo.UpsertReview(r.Context(), userID, resource.ID, input)
+	if err != nil {
+		h.logger.Printf("upsert review error: %v", err)
+		h.writeError(w, http.StatusInternalServerError, "failed to save review")
+		return
+	}
+
+	status := http.StatusOK
+	if created {
+		status = http.StatusCreated
+	}
+	h.writeJSON(w, status, map[string]interface{}{
+		"success": true,
+		"data":    review,
+	})
+}
+
+// handleResourceReviews handles GET /api/v1/resources/{id}/reviews
+//
+// Hidden reviews are not listed. The resource is given by its ID or slug.
+//
+// Query parameters:
+//   - sort: "helpful" (default) for the most helpful first, or "recent"
+//   - limit: max results (default 20, max 100)
+//   - offset: pagination offset
+func (h *Handler) handleResourceReviews(w http.ResponseWriter, r *http.Request, ref string) {
+	if r.Method != http.MethodGet {
+		h.writeError(w, http.StatusMethodNotAllowed, "only GET is supported")
+		return
+	}
+
+	q := r.URL.Query()
+	filter := repository.ReviewQueryFilter{Sort: repository.ReviewSort(q.Get("sort")), Limit: 20}
+	switch filter.Sort {
+	case "":
+		filter.Sort = repository.ReviewSortHelpful
+	case repository.ReviewSortHelpful, repository.ReviewSortRecent:
+	default:
+		h.writeError(w, http.StatusBadRequest, fmt.Sprintf("invalid sort %q", filter.Sort))
+		return
+	}
+	if v, err := strconv.Atoi(q.Get("limit")); err == nil && v > 0 {
+		filter.Limit = min(v, 100)
+	}
+	if v, err := strconv.Atoi(q.Get("offset")); err == nil && v > 0 {
+		filter.Offset = v
+	}
+
+	resource, err := h.lookupResource(r, ref)
+	if err != nil {
+		h.logger.Printf("get resource error: %v", err)
+		h.writeError(w, http.StatusInternalServerError, "failed to list reviews")
+		return
+	}
+	if resource == nil {
+		h.writeError(w, http.StatusNotFound, "resource not found")
+		return
+	}
+
+	reviews, total, err := h.repo.ListReviews(r.Context(), resource.ID, filter)
+	if err != nil {
+		h.logger.Printf("list reviews error: %v", err)
+		h.writeError(w, http.StatusInternalServerError, "failed to list reviews")
+		return
+	}
+
+	page := pagination.New(total, filter.Limit, filter.Offset)
+	pagination.SetLinks(w.Header(), r.URL, page)
+	h.writeCachedJSON(w, r, map[string]interface{}{
+		"success":    true,
+		"data":       reviews,
+		"pagination": page,
+	})
+}
+
+// handleReviewByID handles POST /api/v1/reviews/{id}/helpful and
+// POST /api/v1/reviews/{id}/report
+//
+// A helpful vote is counted once per user, and not for the user's own
+// review. A report flags the review for moderation by an admin. Both
+// require the X-User-ID header and return the review.
+func (h *Handler) handleReviewByID(w http.ResponseWriter, r *http.Request) {
+	idStr, action, _ := strings.Cut(strings.TrimPrefix(r.URL.Path, "/api/v1/reviews/"), "/")
+	if action != "helpful" && action != "report" {
+		h.writeError(w, http.StatusNotFound, "not found")
+		return
+	}
+	if r.Method != http.MethodPost {
+		h.writeError(w, http.StatusMethodNotAllowed, "only POST is supported")
+		return
+	}
+
+	userID, err := uuid.Parse(r.Header.Get(userIDHeader))
+	if err != nil {
+		h.writeError(w, http.StatusUnauthorized, "authentication required")
+		return
+	}
+	reviewID, err := uuid.Parse(idStr)
+	if err != nil {
+		h.writeError(w, http.StatusBadRequest, "invalid review ID")
+		return
+	}
+
+	var review *repository.ResourceReview
+	if action == "helpful" {
+		review, err = h.repo.MarkReviewHelpful(r.Context(), userID, reviewID)
+	} else {
+		review, err = h.repo.ReportReview(r.Context(), reviewID)
+	}
+	if errors.Is(err, repository.ErrOwnReview) {
+		h.writeError(w, http.StatusConflict, "cannot mark your own review as helpful")
+		return
+	}
+	if err != nil {
+		h.logger.Printf("%s review error: %v", action, err)
+		h.writeError(w, http.StatusInternalServerError, "failed to update review")
+		return
+	}
+	if review == nil {
+		h.writeError(w, http.StatusNotFound, "review not found")
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, map[string]interface{}{
+		"success": true,
+		"data":    review,
+	})
+}
@@ -0,0 +1,184 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/learnbot/database/repository"
+)
+
+var reviewColumns = []string{
+	"id", "resource_id", "user_id", "rating", "title", "body", "is_verified",
+	"helpful_count", "is_reported", "is_hidden", "created_at", "updated_at",
+}
+
+func postReview(h *Handler, userID uuid.UUID, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/resources/go-basics/review", strings.NewReader(body))
+	if userID != uuid.Nil {
+		req.Header.Set(userIDHeader, userID.String())
+	}
+	w := httptest.NewRecorder()
+	h.handleResourceBySlug(w, req)
+	return w
+}
+
+func TestResourceReview_Validation(t *testing.T) {
+	h := newTestHandler()
+	if w := postReview(h, uuid.Nil, `{"rating": 4}`); w.Code != http.StatusUnauthorized {
+		t.Errorf("expected 401 without a user, got %d", w.Code)
+	}
+	for _, body := range []string{
+		`{"rating": 0}`,
+		`{"rating": 6}`,
+		`{}`,
+		`{"rating": 3, "title": "` + strings.Repeat("x", maxReviewTitleLength+1) + `"}`,
+		`{"rating": 3, "body": "` + strings.Repeat("x", maxReviewBodyLength+1) + `"}`,
+		`not json`,
+	} {
+		if w := postReview(h, uuid.New(), body); w.Code != http.StatusBadRequest {
+			t.Errorf("%.40s: expected 400, got %d", body, w.Code)
+		}
+	}
+}
+
+func TestResourceReview_CreateAndEdit(t *testing.T) {
+	userID := uuid.New()
+	res := mockResource{id: uuid.New(), title: "go-basics", updatedAt: time.Now()}
+
+	for _, tt := range []struct {
+		name     string
+		inserted bool
+		want     int
+	}{
+		{"new review", true, http.StatusCreated},
+		{"edited review", false, http.StatusOK},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			h, _, mock := newMockHandler(t)
+			now := time.Now()
+			expectGetBySlug(mock, res)
+			mock.ExpectBegin()
+			mock.ExpectQuery("INSERT INTO resource_reviews").
+				WithArgs(res.id, userID, int16(4), nil, "Clear and practical").
+				WillReturnRows(sqlmock.NewRows(append(reviewColumns, "inserted")).AddRow(
+					uuid.New(), res.id, userID, 4, nil, "Clear and practical", true, 0, false, false, now, now, tt.inserted,
+				))
+			mock.ExpectQuery("SELECT provider_rating").WithArgs(res.id).
+				WillReturnRows(sqlmock.NewRows([]string{"provider_rating", "provider_rating_count"}).AddRow(nil, 0))
+			mock.ExpectQuery("FROM resource_reviews").WithArgs(res.id).
+				WillReturnRows(sqlmock.NewRows([]string{"sum", "count"}).AddRow(4, 1))
+			mock.ExpectExec("UPDATE learning_resources SET rating").WithArgs(res.id, 4.0, 1).
+				WillReturnResult(sqlmock.NewResult(0, 1))
+			mock.ExpectCommit()
+
+			w := postReview(h, userID, `{"rating": 4, "title": "  ", "body": " Clear and practical "}`)
+			if w.Code != tt.want {
+				t.Fatalf("expected %d, got %d: %s", tt.want, w.Code, w.Body.String())
+			}
+			var resp struct {
+				Data repository.ResourceReview `json:"data"`
+			}
+			if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+				t.Fatalf("decode: %v", err)
+			}
+			if resp.Data.Rating != 4 || !resp.Data.IsVerified {
+				t.Errorf("unexpected review %+v", resp.Data)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func TestResourceReviews_List(t *testing.T) {
+	h, _, mock := newMockHandler(t)
+	res := mockResource{id: uuid.New(), title: "go-basics", updatedAt: time.Now()}
+	now := time.Now()
+
+	w := get(h.handleResourceBySlug, "/api/v1/resources/go-basics/reviews?sort=stars", "")
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected 400 for an unknown sort, got %d", w.Code)
+	}
+
+	expectGetBySlug(mock, res)
+	mock.ExpectQuery("SELECT COUNT").WithArgs(res.id).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+	mock.ExpectQuery("ORDER BY created_at DESC, id").WithArgs(res.id, 2, 0).
+		WillReturnRows(sqlmock.NewRows(reviewColumns).
+			AddRow(uuid.New(), res.id, uuid.New(), 5, nil, nil, false, 0, false, false, now, now).
+			AddRow(uuid.New(), res.id, uuid.New(), 3, nil, nil, false, 7, false, false, now, now))
+
+	w = get(h.handleResourceBySlug, "/api/v1/resources/go-basics/reviews?sort=recent&limit=2", "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var resp struct {
+		Data       []repository.ResourceReview `json:"data"`
+		Pagination struct {
+			Total int `json:"total"`
+		} `json:"pagination"`
+	}
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if len(resp.Data) != 2 || resp.Pagination.Total != 3 {
+		t.Errorf("unexpected page %+v", resp)
+	}
+	if !strings.Contains(w.Header().Get("Link"), `rel="next"`) {
+		t.Errorf("expected a next link, got %q", w.Header().Get("Link"))
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestReviewByID(t *testing.T) {
+	userID, reviewID := uuid.New(), uuid.New()
+	post := func(h *Handler, path string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, path, nil)
+		req.Header.Set(userIDHeader, userID.String())
+		w := httptest.NewRecorder()
+		h.handleReviewByID(w, req)
+		return w
+	}
+
+	t.Run("own review", func(t *testing.T) {
+		h, _, mock := newMockHandler(t)
+		now := time.Now()
+		mock.ExpectBegin()
+		mock.ExpectQuery("FOR UPDATE").WithArgs(reviewID).
+			WillReturnRows(sqlmock.NewRows(reviewColumns).AddRow(
+				reviewID, uuid.New(), userID, 5, nil, nil, false, 0, false, false, now, now,
+			))
+		mock.ExpectRollback()
+		if w := post(h, "/api/v1/reviews/"+reviewID.String()+"/helpful"); w.Code != http.StatusConflict {
+			t.Errorf("expected 409, got %d", w.Code)
+		}
+	})
+
+	t.Run("report hidden review", func(t *testing.T) {
+		h, _, mock := newMockHandler(t)
+		mock.ExpectQuery("SET is_reported = TRUE").WithArgs(reviewID).
+			WillReturnRows(sqlmock.NewRows(reviewColumns))
+		if w := post(h, "/api/v1/reviews/"+reviewID.String()+"/report"); w.Code != http.StatusNotFound {
+			t.Errorf("expected 404, got %d", w.Code)
+		}
+	})
+
+	t.Run("routing", func(t *testing.T) {
+		h := newTestHandler()
+		if w := post(h, "/api/v1/reviews/"+reviewID.String()+"/like"); w.Code != http.StatusNotFound {
+			t.Errorf("expected 404 for an unknown action, got %d", w.Code)
+		}
+		if w := post(h, "/api/v1/reviews/not-a-uuid/report"); w.Code != http.StatusBadRequest {
+			t.Errorf("expected 400 for an invalid ID, got %d", w.Code)
+		}
+	})
+}
@@ -0,0 +1,293 @@
+// Package coverage reports the in-demand skills the resource catalog covers
+// thinly or not at all, to drive curation.
+//
+// Demand comes from pluggable Sources, such as the skill ontology and the
+// job aggregator's skill demand stats. Demanded skills and resource skills
+// are both resolved to the ontology's canonical skill IDs, so that a
+// resource tagged "k8s" covers Kubernetes.
+package coverage
+
+import (
+	"context"
+	"encoding/csv"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/learnbot/database/repository"
+	"github.com/learnbot/shared/ontology"
+)
+
+// Coverage statuses.
+const (
+	StatusMissing = "missing"
+	StatusThin    = "thin"
+)
+
+// Reasons a skill is reported.
+const (
+	ReasonNoResources         = "no_resources"
+	ReasonFewResources        = "fewer_than_min_resources"
+	ReasonNoFreeResources     = "no_free_resources"
+	ReasonNoBeginnerResources = "no_beginner_resources"
+)
+
+// Criteria decides when a covered skill is thin.
+type Criteria struct {
+	// MinResources is the number of active resources below which a skill
+	// is thin.
+	MinResources int `json:"min_resources"`
+
+	// RequireFree makes a skill without a free resource thin.
+	RequireFree bool `json:"require_free"`
+
+	// RequireBeginner makes a skill without a beginner resource thin.
+	RequireBeginner bool `json:"require_beginner"`
+
+	// FreeCostTypes and BeginnerDifficulties are the resource cost types
+	// and difficulties counted as free and beginner-level.
+	FreeCostTypes        []string `json:"free_cost_types"`
+	BeginnerDifficulties []string `json:"beginner_difficulties"`
+}
+
+// DefaultCriteria returns thin as fewer than 3 resources, or none that is
+// free or for beginners.
+func DefaultCriteria() Criteria {
+	return Criteria{
+		MinResources:         3,
+		RequireFree:          true,
+		RequireBeginner:      true,
+		FreeCostTypes:        []string{string(repository.ResourceCostFree), string(repository.ResourceCostFreeAudit)},
+		BeginnerDifficulties: []string{string(repository.ResourceDifficultyBeginner), string(repository.ResourceDifficultyAllLevels)},
+	}
+}
+
+// SkillDemand is a skill a Source reports demand for.
+type SkillDemand struct {
+	// Skill is a skill ID, name or alias.
+	Skill string
+	Name  string
+	// Count is how much the skill is asked for, such as the number of
+	// jobs mentioning it; 0 if the source only lists it.
+	Count int
+}
+
+// Source lists skills in demand.
+type Source interface {
+	// Name identifies the source in reports.
+	Name() string
+	Demand(ctx context.Context) ([]SkillDemand, error)
+}
+
+// SourceStatus is what a report took from a source.
+type SourceStatus struct {
+	Name   string `json:"name"`
+	Skills int    `json:"skills"`
+	// Error is set if the source failed; the report is built without it.
+	Error string `json:"error,omitempty"`
+}
+
+// SkillCoverage is a thinly covered or missing skill.
+type SkillCoverage struct {
+	Skill string `json:"skill"`
+	Name  string `json:"name"`
+	// Demand sums the counts of the sources, and Sources names them.
+	Demand  int      `json:"demand"`
+	Sources []string `json:"sources"`
+
+	Resources         int `json:"resources"`
+	FreeResources     int `json:"free_resources"`
+	BeginnerResources int `json:"beginner_resources"`
+
+	Status  string   `json:"status"`
+	Reasons []string `json:"reasons"`
+}
+
+// Report lists the missing and thin skills, most in demand first.
+type Report struct {
+	GeneratedAt time.Time       `json:"generated_at"`
+	Criteria    Criteria        `json:"criteria"`
+	Sources     []SourceStatus  `json:"sources"`
+	Total       int             `json:"total"`
+	Skills      []SkillCoverage `json:"skills"`
+}
+
+// Resolver maps skill spellings to canonical skill IDs.
+type Resolver struct {
+	ids   map[string]string
+	names map[string]string
+}
+
+// NewResolver creates a Resolver for the IDs, canonical names and aliases
+// of nodes. A spelling listed for two skills belongs to the later one, as in
+// the resume parser's taxonomy.
+func NewResolver(nodes []ontology.SkillNode) *Resolver {
+	r := &Resolver{ids: map[string]string{}, names: make(map[string]string, len(nodes))}
+	for _, n := range nodes {
+		r.names[n.ID] = n.CanonicalName
+		for _, s := range append([]string{n.ID, n.CanonicalName}, n.Aliases...) {
+			r.ids[normalize(s)] = n.ID
+		}
+	}
+	return r
+}
+
+// DefaultResolver returns a Resolver for the built-in skill ontology.
+func DefaultResolver() *Resolver {
+	return NewResolver(ontology.Skills())
+}
+
+// Resolve returns the canonical ID of skill and its canonical name. A skill
+// outside the ontology resolves to its normalized spelling and no name.
+func (r *Resolver) Resolve(skill string) (id, name string) {
+	key := normalize(skill)
+	if id, ok := r.ids[key]; ok {
+		return id, r.names[id]
+	}
+	return key, ""
+}
+
+// normalize lowercases and trims a skill spelling, as resource skills are
+// normalized.
+func normalize(skill string) string {
+	return strings.ToLower(strings.TrimSpace(skill))
+}
+
+// Build reads the sources and reports the demanded skills that resources
+// cover thinly under criteria, or not at all. A failing source is listed
+// with its error and left out.
+func Build(ctx context.Context, sources []Source, resources []repository.ResourceSkillCoverageRow,
+	criteria Criteria, resolver *Resolver, now time.Time) Report {
+	report := Report{GeneratedAt: now, Criteria: criteria, Sources: []SourceStatus{}, Skills: []SkillCoverage{}}
+
+	demanded := map[string]*SkillCoverage{}
+	for _, src := range sources {
+		status := SourceStatus{Name: src.Name()}
+		skills, err := src.Demand(ctx)
+		if err != nil {
+			status.Error = err.Error()
+			report.Sources = append(report.Sources, status)
+			continue
+		}
+		status.Skills = len(skills)
+		report.Sources = append(report.Sources, status)
+		for _, d := range skills {
+			id, name := resolver.Resolve(d.Skill)
+			if id == "" {
+				continue
+			}
+			c, ok := demanded[id]
+			if !ok {
+				name = firstNonEmpty(name, d.Name, strings.TrimSpace(d.Skill))
+				c = &SkillCoverage{Skill: id, Name: name, Sources: []string{}}
+				demanded[id] = c
+			}
+			c.Demand += d.Count
+			if n := len(c.Sources); n == 0 || c.Sources[n-1] != src.Name() {
+				c.Sources = append(c.Sources, src.Name())
+			}
+		}
+	}
+
+	// A resource tagged with two spellings of a skill counts once.
+	type counted struct {
+		skill string
+		id    uuid.UUID
+	}
+	seen := map[counted]bool{}
+	for _, row := range resources {
+		id, _ := resolver.Resolve(row.Skill)
+		c, ok := demanded[id]
+		if !ok || seen[counted{id, row.ResourceID}] {
+			continue
+		}
+		seen[counted{id, row.ResourceID}] = true
+		c.Resources++
+		if row.Free {
+			c.FreeResources++
+		}
+		if row.Beginner {
+			c.BeginnerResources++
+		}
+	}
+
+	for _, c := range demanded {
+		c.Reasons = criteria.reasons(c)
+		switch {
+		case c.Resources == 0:
+			c.Status = StatusMissing
+		case len(c.Reasons) > 0:
+			c.Status = StatusThin
+		default:
+			continue
+		}
+		report.Skills = append(report.Skills, *c)
+	}
+	sort.Slice(report.Skills, func(i, j int) bool {
+		a, b := report.Skills[i], report.Skills[j]
+		if a.Demand != b.Demand {
+			return a.Demand > b.Demand
+		}
+		if a.Resources != b.Resources {
+			return a.Resources < b.Resources
+		}
+		return a.Skill < b.Skill
+	})
+	report.Total = len(report.Skills)
+	return report
+}
+
+// reasons returns why the coverage of c falls short of the criteria.
+func (cr Criteria) reasons(c *SkillCoverage) []string {
+	if c.Resources == 0 {
+		return []string{ReasonNoResources}
+	}
+	reasons := []string{}
+	if c.Resources < cr.MinResources {
+		reasons = append(reasons, ReasonFewResources)
+	}
+	if cr.RequireFree && c.FreeResources == 0 {
+		reasons = append(reasons, ReasonNoFreeResources)
+	}
+	if cr.RequireBeginner && c.BeginnerResources == 0 {
+		reasons = append(reasons, ReasonNoBeginnerResources)
+	}
+	return reasons
+}
+
+// csvHeader is the header row of Report.WriteCSV.
+var csvHeader = []string{
+	"skill", "name", "demand", "status", "reasons", "resources", "free_resources", "beginner_resources", "sources",
+}
+
+// WriteCSV writes the skills of r as CSV with a header row. Reasons and
+// sources are separated by semicolons.
+func (r Report) WriteCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(csvHeader); err != nil {
+		return err
+	}
+	for _, s := range r.Skills {
+		if err := cw.Write([]string{
+			s.Skill, s.Name, strconv.Itoa(s.Demand), s.Status, strings.Join(s.Reasons, ";"),
+			strconv.Itoa(s.Resources), strconv.Itoa(s.FreeResources), strconv.Itoa(s.BeginnerResources),
+			strings.Join(s.Sources, ";"),
+		}); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
@@ -0,0 +1,143 @@
+package coverage
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/learnbot/database/repository"
+	"github.com/learnbot/shared/ontology"
+)
+
+// staticSource is a Source returning fixed skills or an error.
+type staticSource struct {
+	name   string
+	skills []SkillDemand
+	err    error
+}
+
+func (s staticSource) Name() string { return s.name }
+
+func (s staticSource) Demand(context.Context) ([]SkillDemand, error) { return s.skills, s.err }
+
+var testNodes = []ontology.SkillNode{
+	{ID: "go", CanonicalName: "Go", Aliases: []string{"golang"}},
+	{ID: "kubernetes", CanonicalName: "Kubernetes", Aliases: []string{"k8s"}},
+	{ID: "terraform", CanonicalName: "Terraform", Aliases: []string{"tf"}},
+	{ID: "docker", CanonicalName: "Docker"},
+}
+
+func TestBuild(t *testing.T) {
+	now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	r1, r2, r3 := uuid.New(), uuid.New(), uuid.New()
+	resources := []repository.ResourceSkillCoverageRow{
+		// Go: three resources, one free and one for beginners.
+		{ResourceID: r1, Skill: "go", Free: true},
+		{ResourceID: r2, Skill: "golang", Beginner: true},
+		{ResourceID: r3, Skill: "go"},
+		// Kubernetes: one resource tagged with two spellings.
+		{ResourceID: r1, Skill: "kubernetes", Free: true, Beginner: true},
+		{ResourceID: r1, Skill: "k8s", Free: true, Beginner: true},
+		// Docker: well covered.
+		{ResourceID: r1, Skill: "docker", Free: true},
+		{ResourceID: r2, Skill: "docker", Beginner: true},
+		{ResourceID: r3, Skill: "docker"},
+		// Not demanded by any source.
+		{ResourceID: r3, Skill: "cobol"},
+	}
+	sources := []Source{
+		NewTaxonomy(testNodes),
+		staticSource{name: "jobs", skills: []SkillDemand{
+			{Skill: "kubernetes", Count: 40},
+			{Skill: "tf", Count: 25},
+			{Skill: "go", Count: 25},
+			{Skill: "Rust", Name: "Rust", Count: 5},
+		}},
+		staticSource{name: "broken", err: errors.New("connection refused")},
+	}
+
+	report := Build(context.Background(), sources, resources, DefaultCriteria(), NewResolver(testNodes), now)
+
+	wantSources := []SourceStatus{
+		{Name: "taxonomy", Skills: 4},
+		{Name: "jobs", Skills: 4},
+		{Name: "broken", Error: "connection refused"},
+	}
+	if !reflect.DeepEqual(report.Sources, wantSources) {
+		t.Errorf("Sources = %+v", report.Sources)
+	}
+	want := []SkillCoverage{
+		{Skill: "kubernetes", Name: "Kubernetes", Demand: 40, Sources: []string{"taxonomy", "jobs"},
+			Resources: 1, FreeResources: 1, BeginnerResources: 1,
+			Status: StatusThin, Reasons: []string{ReasonFewResources}},
+		{Skill: "terraform", Name: "Terraform", Demand: 25, Sources: []string{"taxonomy", "jobs"},
+			Status: StatusMissing, Reasons: []string{ReasonNoResources}},
+		{Skill: "rust", Name: "Rust", Demand: 5, Sources: []string{"jobs"},
+			Status: StatusMissing, Reasons: []string{ReasonNoResources}},
+	}
+	if !reflect.DeepEqual(report.Skills, want) {
+		t.Errorf("Skills = %+v\nwant %+v", report.Skills, want)
+	}
+	if report.Total != 3 || !report.GeneratedAt.Equal(now) {
+		t.Errorf("Total = %d, GeneratedAt = %v", report.Total, report.GeneratedAt)
+	}
+}
+
+func TestBuild_Criteria(t *testing.T) {
+	resources := []repository.ResourceSkillCoverageRow{
+		{ResourceID: uuid.New(), Skill: "go"},
+		{ResourceID: uuid.New(), Skill: "go"},
+	}
+	sources := []Source{staticSource{name: "jobs", skills: []SkillDemand{{Skill: "go", Count: 1}}}}
+	tests := []struct {
+		name     string
+		criteria Criteria
+		want     []string
+	}{
+		{"default", DefaultCriteria(), []string{ReasonFewResources, ReasonNoFreeResources, ReasonNoBeginnerResources}},
+		{"lenient", Criteria{MinResources: 2}, nil},
+		{"free only", Criteria{MinResources: 1, RequireFree: true}, []string{ReasonNoFreeResources}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report := Build(context.Background(), sources, resources, tt.criteria, NewResolver(testNodes), time.Now())
+			if tt.want == nil {
+				if len(report.Skills) != 0 {
+					t.Errorf("expected Go to be covered, got %+v", report.Skills)
+				}
+				return
+			}
+			if len(report.Skills) != 1 || !reflect.DeepEqual(report.Skills[0].Reasons, tt.want) {
+				t.Errorf("expected reasons %v, got %+v", tt.want, report.Skills)
+			}
+		})
+	}
+}
+
+func TestReport_WriteCSV(t *testing.T) {
+	report := Report{Skills: []SkillCoverage{
+		{Skill: "kubernetes", Name: "Kubernetes", Demand: 40, Sources: []string{"taxonomy", "jobs"},
+			Resources: 1, FreeResources: 1, Status: StatusThin,
+			Reasons: []string{ReasonFewResources, ReasonNoBeginnerResources}},
+	}}
+	var buf bytes.Buffer
+	if err := report.WriteCSV(&buf); err != nil {
+		t.Fatalf("WriteCSV: %v", err)
+	}
+	records, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("read CSV: %v", err)
+	}
+	want := [][]string{
+		csvHeader,
+		{"kubernetes", "Kubernetes", "40", "thin", "fewer_than_min_resources;no_beginner_resources", "1", "1", "0", "taxonomy;jobs"},
+	}
+	if !reflect.DeepEqual(records, want) {
+		t.Errorf("CSV = %v", records)
+	}
+}
@@ -0,0 +1,112 @@
+package coverage
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/learnbot/shared/ontology"
+)
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Skill ontology
+// ─────────────────────────────────────────────────────────────────────────────
+
+// Taxonomy is a Source listing every skill of an ontology, without counts,
+// so that each skill gap analysis can report is checked.
+type Taxonomy struct {
+	nodes []ontology.SkillNode
+}
+
+// NewTaxonomy creates a Taxonomy of nodes.
+func NewTaxonomy(nodes []ontology.SkillNode) *Taxonomy {
+	return &Taxonomy{nodes: nodes}
+}
+
+// DefaultTaxonomy returns a Taxonomy of the built-in skill ontology.
+func DefaultTaxonomy() *Taxonomy {
+	return NewTaxonomy(ontology.Skills())
+}
+
+// Name implements Source.
+func (t *Taxonomy) Name() string { return "taxonomy" }
+
+// Demand implements Source.
+func (t *Taxonomy) Demand(context.Context) ([]SkillDemand, error) {
+	out := make([]SkillDemand, len(t.nodes))
+	for i, n := range t.nodes {
+		out[i] = SkillDemand{Skill: n.ID, Name: n.CanonicalName}
+	}
+	return out, nil
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Job aggregator
+// ─────────────────────────────────────────────────────────────────────────────
+
+// jobDemandLimit is the number of skills JobDemand asks for, the job
+// aggregator's maximum.
+const jobDemandLimit = 100
+
+// maxJobDemandBody caps the size of a skill demand response.
+const maxJobDemandBody = 1 << 20
+
+// JobDemand is a Source reading the skills most mentioned in recent job
+// postings from the job aggregator's skill demand stats. Each skill counts
+// the jobs mentioning it over the aggregator's default period.
+type JobDemand struct {
+	baseURL string
+	client  *http.Client
+}
+
+// NewJobDemand creates a JobDemand for the job aggregator at baseURL. A nil
+// client uses http.DefaultClient.
+func NewJobDemand(baseURL string, client *http.Client) *JobDemand {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return &JobDemand{baseURL: strings.TrimRight(baseURL, "/"), client: client}
+}
+
+// Name implements Source.
+func (s *JobDemand) Name() string { return "job_postings" }
+
+// Demand implements Source.
+func (s *JobDemand) Demand(ctx context.Context) ([]SkillDemand, error) {
+	q := url.Values{"limit": {strconv.Itoa(jobDemandLimit)}}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet,
+		s.baseURL+"/api/v1/analytics/skill-demand?"+q.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("skill demand: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("skill demand: status %d", resp.StatusCode)
+	}
+
+	var body struct {
+		Skills []struct {
+			Skill string `json:"skill"`
+			Name  string `json:"name"`
+			Jobs  int    `json:"jobs"`
+		} `json:"skills"`
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxJobDemandBody)).Decode(&body); err != nil {
+		return nil, fmt.Errorf("skill demand: decode response: %w", err)
+	}
+	out := make([]SkillDemand, len(body.Skills))
+	for i, sk := range body.Skills {
+		out[i] = SkillDemand{Skill: sk.Skill, Name: sk.Name, Count: sk.Jobs}
+	}
+	return out, nil
+}
@@ -0,0 +1,60 @@
+package coverage
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestJobDemand(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/analytics/skill-demand" || r.URL.Query().Get("limit") != "100" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"from": "2025-02-03", "to": "2025-02-24", "skills": [
+			{"skill": "kubernetes", "name": "Kubernetes", "jobs": 40, "this_week": 12, "last_week": 9},
+			{"skill": "terraform", "name": "Terraform", "jobs": 25, "this_week": 6, "last_week": 6}
+		]}`))
+	}))
+	defer srv.Close()
+
+	got, err := NewJobDemand(srv.URL+"/", nil).Demand(context.Background())
+	if err != nil {
+		t.Fatalf("Demand: %v", err)
+	}
+	want := []SkillDemand{
+		{Skill: "kubernetes", Name: "Kubernetes", Count: 40},
+		{Skill: "terraform", Name: "Terraform", Count: 25},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Demand = %+v", got)
+	}
+}
+
+func TestJobDemand_Error(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "not implemented", http.StatusNotImplemented)
+	}))
+	defer srv.Close()
+
+	if _, err := NewJobDemand(srv.URL, nil).Demand(context.Background()); err == nil {
+		t.Error("expected an error for a 501 response")
+	}
+}
+
+func TestDefaultTaxonomy(t *testing.T) {
+	skills, err := DefaultTaxonomy().Demand(context.Background())
+	if err != nil || len(skills) == 0 {
+		t.Fatalf("Demand = %d skills, %v", len(skills), err)
+	}
+	resolver := DefaultResolver()
+	for _, s := range skills {
+		if id, name := resolver.Resolve(s.Skill); id != s.Skill || name != s.Name || s.Count != 0 {
+			t.Errorf("taxonomy skill %+v resolves to %q %q", s, id, name)
+		}
+	}
+}
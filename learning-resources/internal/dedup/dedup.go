@@ -0,0 +1,303 @@
+// Package dedup detects learning resources that are likely duplicates of
+// each other: resources whose URLs are equal once normalised, and resources
+// of the same provider whose titles are nearly the same.
+package dedup
+
+import (
+	"net/url"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/google/uuid"
+
+	"github.com/learnbot/database/repository"
+)
+
+// DefaultTitleThreshold is the title similarity from which two resources of
+// the same provider are reported as duplicates.
+const DefaultTitleThreshold = 0.85
+
+// Reasons a resource is reported as a duplicate.
+const (
+	ReasonSameURL      = "same_url"
+	ReasonSimilarTitle = "similar_title"
+)
+
+// trackingParams are query parameters that identify how a visitor reached a
+// page rather than the page itself. Parameters starting with "utm_" are
+// tracking parameters too.
+var trackingParams = map[string]bool{
+	"gclid": true, "fbclid": true, "msclkid": true, "dclid": true, "yclid": true,
+	"mc_cid": true, "mc_eid": true, "igshid": true, "_ga": true, "_gl": true,
+	"ref": true, "ref_": true, "referrer": true, "affid": true, "aff_id": true,
+	"ranmid": true, "raneaid": true, "ransiteid": true, "couponcode": true,
+}
+
+// NormalizeURL returns the form of a resource URL used to compare it with
+// others. The scheme, "www." prefix, default port, fragment, trailing
+// slashes and tracking parameters are dropped, the host is lowercased and
+// the remaining query parameters are sorted, so that
+//
+//	http://www.Udemy.com/course/go/?utm_source=x&couponCode=Y
+//	https://udemy.com/course/go
+//
+// normalise to the same "udemy.com/course/go". Strings that are not absolute
+// URLs are only trimmed and lowercased.
+func NormalizeURL(raw string) string {
+	raw = strings.TrimSpace(raw)
+	u, err := url.Parse(raw)
+	if err != nil || u.Host == "" {
+		return strings.ToLower(raw)
+	}
+
+	host := strings.ToLower(u.Hostname())
+	host = strings.TrimPrefix(host, "www.")
+	if port := u.Port(); port != "" && port != "80" && port != "443" {
+		host += ":" + port
+	}
+
+	path := strings.TrimRight(u.EscapedPath(), "/")
+
+	query := u.Query()
+	for key := range query {
+		lower := strings.ToLower(key)
+		if trackingParams[lower] || strings.HasPrefix(lower, "utm_") {
+			query.Del(key)
+		}
+	}
+	out := host + path
+	if len(query) > 0 {
+		out += "?" + query.Encode() // Encode sorts by key
+	}
+	return out
+}
+
+// NormalizeTitle lowercases a title and reduces it to its words, so that
+// punctuation and spacing do not affect comparisons. Apostrophes are
+// dropped rather than splitting words: "Developer's" becomes "developers".
+func NormalizeTitle(title string) string {
+	var b strings.Builder
+	space := true
+	for _, r := range strings.ToLower(title) {
+		switch {
+		case r == '\'' || r == '’':
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			b.WriteRune(r)
+			space = false
+		case !space:
+			b.WriteRune(' ')
+			space = true
+		}
+	}
+	return strings.TrimSpace(b.String())
+}
+
+// TitleSimilarity returns the Dice coefficient of the character bigrams of
+// the normalised titles, from 0 for no shared bigrams to 1 for titles that
+// normalise to the same string.
+func TitleSimilarity(a, b string) float64 {
+	a, b = NormalizeTitle(a), NormalizeTitle(b)
+	if a == b {
+		if a == "" {
+			return 0
+		}
+		return 1
+	}
+	ba, bb := bigrams(a), bigrams(b)
+	if len(ba) == 0 || len(bb) == 0 {
+		return 0
+	}
+	shared := 0
+	counts := make(map[string]int, len(ba))
+	for _, g := range ba {
+		counts[g]++
+	}
+	for _, g := range bb {
+		if counts[g] > 0 {
+			counts[g]--
+			shared++
+		}
+	}
+	return float64(2*shared) / float64(len(ba)+len(bb))
+}
+
+func bigrams(s string) []string {
+	runes := []rune(s)
+	if len(runes) < 2 {
+		return nil
+	}
+	out := make([]string, 0, len(runes)-1)
+	for i := 0; i+1 < len(runes); i++ {
+		out = append(out, string(runes[i:i+2]))
+	}
+	return out
+}
+
+// Match is an existing resource that a resource likely duplicates.
+type Match struct {
+	ResourceID uuid.UUID `json:"resource_id"`
+	Title      string    `json:"title"`
+	URL        string    `json:"url"`
+	Reason     string    `json:"reason"`
+	Score      float64   `json:"score"`
+}
+
+// sameProvider reports whether two resources have the same provider; two
+// resources without a provider count as the same one.
+func sameProvider(a, b *uuid.UUID) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return *a == *b
+}
+
+// compare reports why a and b are likely duplicates, if they are.
+func compare(a, b repository.DuplicateCandidate, threshold float64) (reason string, score float64, ok bool) {
+	if NormalizeURL(a.URL) == NormalizeURL(b.URL) {
+		return ReasonSameURL, 1, true
+	}
+	if sameProvider(a.ProviderID, b.ProviderID) {
+		if s := TitleSimilarity(a.Title, b.Title); s >= threshold {
+			return ReasonSimilarTitle, s, true
+		}
+	}
+	return "", 0, false
+}
+
+// Find returns the resources of existing that candidate likely duplicates,
+// URL matches first, then by descending title similarity. A threshold of
+// zero or less uses DefaultTitleThreshold.
+func Find(candidate repository.DuplicateCandidate, existing []repository.DuplicateCandidate, threshold float64) []Match {
+	if threshold <= 0 {
+		threshold = DefaultTitleThreshold
+	}
+	var matches []Match
+	for _, e := range existing {
+		if e.ID == candidate.ID {
+			continue
+		}
+		if reason, score, ok := compare(candidate, e, threshold); ok {
+			matches = append(matches, Match{ResourceID: e.ID, Title: e.Title, URL: e.URL, Reason: reason, Score: round4(score)})
+		}
+	}
+	sort.SliceStable(matches, func(i, j int) bool {
+		if (matches[i].Reason == ReasonSameURL) != (matches[j].Reason == ReasonSameURL) {
+			return matches[i].Reason == ReasonSameURL
+		}
+		return matches[i].Score > matches[j].Score
+	})
+	return matches
+}
+
+// Cluster is a group of resources that are likely duplicates of each other,
+// each linked to another by a shared URL or a similar title.
+type Cluster struct {
+	// SurvivorID is the suggested resource to merge the others into: the
+	// oldest one.
+	SurvivorID uuid.UUID                       `json:"survivor_id"`
+	Reasons    []string                        `json:"reasons"`
+	Resources  []repository.DuplicateCandidate `json:"resources"`
+}
+
+// Clusters groups resources into clusters of likely duplicates. Resources
+// without duplicates are left out. Clusters are ordered by their oldest
+// resource, and list their resources oldest first. A threshold of zero or
+// less uses DefaultTitleThreshold.
+func Clusters(resources []repository.DuplicateCandidate, threshold float64) []Cluster {
+	if threshold <= 0 {
+		threshold = DefaultTitleThreshold
+	}
+	sorted := append([]repository.DuplicateCandidate(nil), resources...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if !sorted[i].CreatedAt.Equal(sorted[j].CreatedAt) {
+			return sorted[i].CreatedAt.Before(sorted[j].CreatedAt)
+		}
+		return sorted[i].ID.String() < sorted[j].ID.String()
+	})
+
+	parent := make([]int, len(sorted))
+	for i := range parent {
+		parent[i] = i
+	}
+	var root func(int) int
+	root = func(i int) int {
+		if parent[i] != i {
+			parent[i] = root(parent[i])
+		}
+		return parent[i]
+	}
+	reasons := make(map[int]map[string]bool)
+	link := func(i, j int, reason string) {
+		ri, rj := root(i), root(j)
+		if ri > rj {
+			ri, rj = rj, ri
+		}
+		if reasons[ri] == nil {
+			reasons[ri] = make(map[string]bool)
+		}
+		reasons[ri][reason] = true
+		if ri != rj {
+			parent[rj] = ri
+			for r := range reasons[rj] {
+				reasons[ri][r] = true
+			}
+			delete(reasons, rj)
+		}
+	}
+
+	// URL matches are found by key; titles are only compared within a
+	// provider.
+	byURL := make(map[string]int)
+	byProvider := make(map[uuid.UUID][]int)
+	for i, r := range sorted {
+		key := NormalizeURL(r.URL)
+		if first, ok := byURL[key]; ok {
+			link(first, i, ReasonSameURL)
+		} else {
+			byURL[key] = i
+		}
+		var provider uuid.UUID
+		if r.ProviderID != nil {
+			provider = *r.ProviderID
+		}
+		byProvider[provider] = append(byProvider[provider], i)
+	}
+	for _, group := range byProvider {
+		for x, i := range group {
+			for _, j := range group[x+1:] {
+				if TitleSimilarity(sorted[i].Title, sorted[j].Title) >= threshold {
+					link(i, j, ReasonSimilarTitle)
+				}
+			}
+		}
+	}
+
+	members := make(map[int][]repository.DuplicateCandidate)
+	var roots []int
+	for i, r := range sorted {
+		ri := root(i)
+		if _, ok := members[ri]; !ok {
+			roots = append(roots, ri)
+		}
+		members[ri] = append(members[ri], r)
+	}
+	clusters := []Cluster{}
+	for _, ri := range roots {
+		if len(members[ri]) < 2 {
+			continue
+		}
+		c := Cluster{SurvivorID: members[ri][0].ID, Resources: members[ri], Reasons: []string{}}
+		for _, reason := range []string{ReasonSameURL, ReasonSimilarTitle} {
+			if reasons[ri][reason] {
+				c.Reasons = append(c.Reasons, reason)
+			}
+		}
+		clusters = append(clusters, c)
+	}
+	return clusters
+}
+
+func round4(v float64) float64 {
+	return float64(int(v*10000+0.5)) / 10000
+}
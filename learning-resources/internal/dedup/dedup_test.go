@@ -0,0 +1,149 @@
+package dedup
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/learnbot/database/repository"
+)
+
+func TestNormalizeURL(t *testing.T) {
+	tests := []struct {
+		name string
+		raw  string
+		want string
+	}{
+		{"plain", "https://go.dev/tour", "go.dev/tour"},
+		{"http and https", "http://go.dev/tour", "go.dev/tour"},
+		{"trailing slash", "https://go.dev/tour/", "go.dev/tour"},
+		{"several trailing slashes", "https://go.dev/tour//", "go.dev/tour"},
+		{"root", "https://go.dev/", "go.dev"},
+		{"no path", "https://go.dev", "go.dev"},
+		{"host case", "https://Go.Dev/tour", "go.dev/tour"},
+		{"path case kept", "https://go.dev/Tour", "go.dev/Tour"},
+		{"www prefix", "https://www.udemy.com/course/go/", "udemy.com/course/go"},
+		{"default https port", "https://go.dev:443/tour", "go.dev/tour"},
+		{"default http port", "http://go.dev:80/tour", "go.dev/tour"},
+		{"other port kept", "http://localhost:8080/docs", "localhost:8080/docs"},
+		{"fragment", "https://go.dev/tour#basics", "go.dev/tour"},
+		{"utm params", "https://go.dev/tour?utm_source=news&utm_medium=email&UTM_Campaign=x", "go.dev/tour"},
+		{"click ids", "https://go.dev/tour?gclid=1&fbclid=2&msclkid=3", "go.dev/tour"},
+		{"udemy tracking", "https://www.udemy.com/course/go/?couponCode=SALE&ranMID=1&ranEAID=2&ranSiteID=3", "udemy.com/course/go"},
+		{"meaningful params kept", "https://youtube.com/watch?v=abc&utm_source=x", "youtube.com/watch?v=abc"},
+		{"params sorted", "https://example.com/search?q=go&lang=en", "example.com/search?lang=en&q=go"},
+		{"trailing slash before query", "https://example.com/search/?q=go", "example.com/search?q=go"},
+		{"empty query", "https://go.dev/tour?", "go.dev/tour"},
+		{"surrounding spaces", "  https://go.dev/tour  ", "go.dev/tour"},
+		{"escaped path", "https://example.com/a%20b/", "example.com/a%20b"},
+		{"not absolute", "Go.dev/Tour", "go.dev/tour"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NormalizeURL(tt.raw); got != tt.want {
+				t.Errorf("NormalizeURL(%q) = %q, want %q", tt.raw, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNormalizeTitle(t *testing.T) {
+	tests := map[string]string{
+		"Go: The Complete Developer's Guide (Golang)": "go the complete developers guide golang",
+		"  Docker -- Zero   to Hero! ":                "docker zero to hero",
+		"C++ 20":                                      "c 20",
+		"":                                            "",
+	}
+	for title, want := range tests {
+		if got := NormalizeTitle(title); got != want {
+			t.Errorf("NormalizeTitle(%q) = %q, want %q", title, got, want)
+		}
+	}
+}
+
+func TestTitleSimilarity(t *testing.T) {
+	if got := TitleSimilarity("Go: The Complete Guide", "go - the complete guide!"); got != 1 {
+		t.Errorf("titles differing in punctuation = %v, want 1", got)
+	}
+	near := TitleSimilarity("Go: The Complete Developer's Guide (Golang)", "Go - The Complete Developers Guide")
+	if near < DefaultTitleThreshold {
+		t.Errorf("near-identical titles = %v, want at least %v", near, DefaultTitleThreshold)
+	}
+	far := TitleSimilarity("Python for Beginners", "Kubernetes for Experts")
+	if far >= DefaultTitleThreshold {
+		t.Errorf("different titles = %v, want below %v", far, DefaultTitleThreshold)
+	}
+	if got := TitleSimilarity("", ""); got != 0 {
+		t.Errorf("empty titles = %v, want 0", got)
+	}
+}
+
+func candidate(title, rawURL string, provider *uuid.UUID, age int) repository.DuplicateCandidate {
+	return repository.DuplicateCandidate{
+		ID: uuid.New(), Title: title, URL: rawURL, ProviderID: provider,
+		CreatedAt: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC).Add(time.Duration(age) * time.Hour),
+	}
+}
+
+func TestFind(t *testing.T) {
+	udemy, coursera := uuid.New(), uuid.New()
+	existing := []repository.DuplicateCandidate{
+		candidate("Go: The Complete Developer's Guide", "https://www.udemy.com/course/go-the-complete-guide/", &udemy, 0),
+		candidate("Go - The Complete Developers Guide (Golang)", "https://www.udemy.com/course/learn-go/", &udemy, 1),
+		candidate("Go: The Complete Developer's Guide", "https://coursera.org/learn/go", &coursera, 2),
+		candidate("Docker Mastery", "https://www.udemy.com/course/docker-mastery/", &udemy, 3),
+	}
+
+	got := Find(repository.DuplicateCandidate{
+		Title: "Go: The Complete Developers Guide", URL: "http://udemy.com/course/learn-go?couponCode=X", ProviderID: &udemy,
+	}, existing, 0)
+	if len(got) != 2 {
+		t.Fatalf("Find = %+v, want 2 matches", got)
+	}
+	// The URL match comes first; the other provider's course is not a match.
+	if got[0].ResourceID != existing[1].ID || got[0].Reason != ReasonSameURL || got[0].Score != 1 {
+		t.Errorf("first match = %+v, want the URL match", got[0])
+	}
+	if got[1].ResourceID != existing[0].ID || got[1].Reason != ReasonSimilarTitle {
+		t.Errorf("second match = %+v, want the similar title", got[1])
+	}
+
+	// A resource is not its own duplicate.
+	if got := Find(existing[3], existing, 0); len(got) != 0 {
+		t.Errorf("Find(self) = %+v, want none", got)
+	}
+}
+
+func TestClusters(t *testing.T) {
+	udemy := uuid.New()
+	resources := []repository.DuplicateCandidate{
+		candidate("Docker Mastery", "https://www.udemy.com/course/docker-mastery/", &udemy, 5),
+		candidate("Go: The Complete Developer's Guide", "https://www.udemy.com/course/go-guide/", &udemy, 1),
+		candidate("Go - The Complete Developers Guide", "https://www.udemy.com/course/go-guide-2/", &udemy, 2),
+		candidate("Learn Go Fast", "http://udemy.com/course/go-guide-2?utm_source=x", nil, 0),
+		candidate("Kubernetes Basics", "https://k8s.io/docs/tutorials/", nil, 3),
+	}
+
+	got := Clusters(resources, 0)
+	if len(got) != 1 {
+		t.Fatalf("Clusters = %+v, want 1 cluster", got)
+	}
+	c := got[0]
+	// Both reasons chain the three Go courses together; the oldest survives.
+	if len(c.Resources) != 3 || c.SurvivorID != resources[3].ID {
+		t.Errorf("cluster = %+v, want the three Go courses with the oldest as survivor", c)
+	}
+	if len(c.Reasons) != 2 || c.Reasons[0] != ReasonSameURL || c.Reasons[1] != ReasonSimilarTitle {
+		t.Errorf("reasons = %v, want same_url and similar_title", c.Reasons)
+	}
+	for i := 1; i < len(c.Resources); i++ {
+		if c.Resources[i].CreatedAt.Before(c.Resources[i-1].CreatedAt) {
+			t.Errorf("cluster resources not oldest first: %+v", c.Resources)
+		}
+	}
+
+	if got := Clusters(resources[:1], 0); got == nil || len(got) != 0 {
+		t.Errorf("Clusters without duplicates = %#v, want an empty list", got)
+	}
+}
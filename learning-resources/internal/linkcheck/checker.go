@@ -0,0 +1,317 @@
+// Package linkcheck periodically requests learning resource URLs and records
+// whether they still resolve, deactivating resources whose links stay broken.
+package linkcheck
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/learnbot/database/repository"
+)
+
+// ErrNotFound is returned by Recheck when the resource does not exist.
+var ErrNotFound = errors.New("resource not found")
+
+// maxRedirects matches the net/http default redirect limit.
+const maxRedirects = 10
+
+// Store is the persistence used by the Checker. It is implemented by
+// *repository.LearningResourceRepository.
+type Store interface {
+	ListLinkCheckTargets(ctx context.Context, checkedBefore time.Time, limit int) ([]repository.LinkCheckTarget, error)
+	GetLinkCheckTarget(ctx context.Context, id uuid.UUID) (*repository.LinkCheckTarget, error)
+	RecordLinkCheck(ctx context.Context, id uuid.UUID, result repository.LinkCheckResult, deactivateAfter int) (*repository.ResourceLinkStatus, error)
+	TouchLinkCheck(ctx context.Context, id uuid.UUID, statusCode int) (*repository.ResourceLinkStatus, error)
+}
+
+// Config holds link checker configuration.
+type Config struct {
+	// How often a batch of resources is checked
+	Interval time.Duration
+	// Resources checked more recently than this are skipped
+	RecheckAfter time.Duration
+	// Maximum number of resources checked per batch
+	BatchSize int
+	// Number of concurrent requests
+	Workers int
+	// Consecutive failures after which a resource is deactivated
+	DeactivateAfter int
+	// Replace a resource URL with the target of a permanent redirect
+	PersistFinalURL bool
+	// HTTP settings
+	RequestTimeout time.Duration
+	UserAgent      string
+}
+
+// DefaultConfig returns sensible defaults.
+func DefaultConfig() Config {
+	return Config{
+		Interval:        time.Hour,
+		RecheckAfter:    24 * time.Hour,
+		BatchSize:       100,
+		Workers:         4,
+		DeactivateAfter: 3,
+		RequestTimeout:  10 * time.Second,
+		UserAgent:       "LearnBot-LinkChecker/1.0 (https://learnbot.io; resources@learnbot.io)",
+	}
+}
+
+// CheckStats summarises one batch.
+type CheckStats struct {
+	Checked      int
+	Healthy      int
+	Failed       int
+	Inconclusive int
+}
+
+// Checker periodically requests resource URLs and records their health.
+type Checker struct {
+	store  Store
+	client *http.Client
+	config Config
+	logger *log.Logger
+	now    func() time.Time
+}
+
+// New creates a new Checker. Zero config values fall back to DefaultConfig.
+func New(store Store, cfg Config, logger *log.Logger) *Checker {
+	def := DefaultConfig()
+	if cfg.Interval <= 0 {
+		cfg.Interval = def.Interval
+	}
+	if cfg.RecheckAfter <= 0 {
+		cfg.RecheckAfter = def.RecheckAfter
+	}
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = def.BatchSize
+	}
+	if cfg.Workers <= 0 {
+		cfg.Workers = def.Workers
+	}
+	if cfg.DeactivateAfter <= 0 {
+		cfg.DeactivateAfter = def.DeactivateAfter
+	}
+	if cfg.RequestTimeout <= 0 {
+		cfg.RequestTimeout = def.RequestTimeout
+	}
+	if cfg.UserAgent == "" {
+		cfg.UserAgent = def.UserAgent
+	}
+	return &Checker{
+		store:  store,
+		client: &http.Client{Timeout: cfg.RequestTimeout},
+		config: cfg,
+		logger: logger,
+		now:    time.Now,
+	}
+}
+
+// Start runs CheckBatch every Interval until ctx is cancelled.
+func (c *Checker) Start(ctx context.Context) {
+	go func() {
+		c.logger.Printf("[linkcheck] checker started (every %v)", c.config.Interval)
+		ticker := time.NewTicker(c.config.Interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				c.logger.Println("[linkcheck] checker stopped")
+				return
+			case <-ticker.C:
+				stats, err := c.CheckBatch(ctx)
+				if err != nil {
+					c.logger.Printf("[linkcheck] batch error: %v", err)
+					continue
+				}
+				c.logger.Printf("[linkcheck] checked %d resources: %d healthy, %d failed, %d inconclusive",
+					stats.Checked, stats.Healthy, stats.Failed, stats.Inconclusive)
+			}
+		}
+	}()
+}
+
+// CheckBatch checks the next batch of due resources, least recently checked
+// first.
+func (c *Checker) CheckBatch(ctx context.Context) (CheckStats, error) {
+	targets, err := c.store.ListLinkCheckTargets(ctx, c.now().Add(-c.config.RecheckAfter), c.config.BatchSize)
+	if err != nil {
+		return CheckStats{}, fmt.Errorf("list link check targets: %w", err)
+	}
+
+	var (
+		stats CheckStats
+		mu    sync.Mutex
+		wg    sync.WaitGroup
+	)
+	work := make(chan repository.LinkCheckTarget)
+	for i := 0; i < c.config.Workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for target := range work {
+				outcome, _, err := c.check(ctx, target)
+				if err != nil {
+					c.logger.Printf("[linkcheck] record check for resource %s: %v", target.ID, err)
+				}
+				mu.Lock()
+				stats.Checked++
+				switch outcome {
+				case outcomeHealthy:
+					stats.Healthy++
+				case outcomeFailed:
+					stats.Failed++
+				case outcomeInconclusive:
+					stats.Inconclusive++
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+
+	for _, target := range targets {
+		select {
+		case work <- target:
+		case <-ctx.Done():
+		}
+		if ctx.Err() != nil {
+			break
+		}
+	}
+	close(work)
+	wg.Wait()
+	return stats, ctx.Err()
+}
+
+// Recheck checks one resource immediately, whether or not it is due or
+// active, and returns its updated link status.
+func (c *Checker) Recheck(ctx context.Context, id uuid.UUID) (*repository.ResourceLinkStatus, error) {
+	target, err := c.store.GetLinkCheckTarget(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if target == nil {
+		return nil, ErrNotFound
+	}
+	_, status, err := c.check(ctx, *target)
+	if err != nil {
+		return nil, err
+	}
+	if status == nil {
+		return nil, ErrNotFound
+	}
+	return status, nil
+}
+
+type outcome int
+
+const (
+	outcomeHealthy outcome = iota
+	outcomeFailed
+	outcomeInconclusive
+)
+
+// check requests one resource URL and records the result. Throttled
+// requests are inconclusive: only the check timestamp and status move
+// forward. Network errors and error statuses count as failures.
+func (c *Checker) check(ctx context.Context, target repository.LinkCheckTarget) (outcome, *repository.ResourceLinkStatus, error) {
+	res, err := c.probe(ctx, target.URL)
+	if err != nil {
+		c.logger.Printf("[linkcheck] request %s: %v", target.URL, err)
+	}
+	if res.statusCode == http.StatusTooManyRequests {
+		status, err := c.store.TouchLinkCheck(ctx, target.ID, res.statusCode)
+		return outcomeInconclusive, status, err
+	}
+
+	result := repository.LinkCheckResult{
+		StatusCode: res.statusCode,
+		OK:         err == nil && res.statusCode < http.StatusBadRequest,
+	}
+	if result.OK && c.config.PersistFinalURL && res.permanent && res.finalURL != target.URL {
+		result.FinalURL = res.finalURL
+	}
+
+	status, err := c.store.RecordLinkCheck(ctx, target.ID, result, c.config.DeactivateAfter)
+	if err != nil {
+		return outcomeInconclusive, nil, err
+	}
+	if !result.OK {
+		if status != nil && !status.IsActive && status.DeactivatedByCheck && status.ConsecutiveFailures == c.config.DeactivateAfter {
+			c.logger.Printf("[linkcheck] resource %s (%s) deactivated after %d failed checks",
+				target.ID, target.Title, status.ConsecutiveFailures)
+		}
+		return outcomeFailed, status, nil
+	}
+	if result.FinalURL != "" {
+		c.logger.Printf("[linkcheck] resource %s moved permanently to %s", target.ID, result.FinalURL)
+	}
+	return outcomeHealthy, status, nil
+}
+
+// probeResult is the final response of a link check request.
+type probeResult struct {
+	statusCode int
+	finalURL   string
+	// permanent reports whether every redirect followed was permanent.
+	permanent bool
+}
+
+// probe sends a HEAD request to rawURL, following redirects. Many servers
+// reject or mishandle HEAD, so a failed or error HEAD is retried as GET.
+func (c *Checker) probe(ctx context.Context, rawURL string) (probeResult, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return probeResult{}, fmt.Errorf("invalid resource URL %q", rawURL)
+	}
+
+	res, err := c.request(ctx, http.MethodHead, u.String())
+	if err == nil && res.statusCode < http.StatusBadRequest {
+		return res, nil
+	}
+	if ctx.Err() != nil {
+		return res, err
+	}
+	return c.request(ctx, http.MethodGet, u.String())
+}
+
+func (c *Checker) request(ctx context.Context, method, rawURL string) (probeResult, error) {
+	req, err := http.NewRequestWithContext(ctx, method, rawURL, nil)
+	if err != nil {
+		return probeResult{}, fmt.Errorf("create request: %w", err)
+	}
+	req.Header.Set("User-Agent", c.config.UserAgent)
+
+	res := probeResult{permanent: true}
+	client := *c.client
+	client.CheckRedirect = func(req *http.Request, via []*http.Request) error {
+		if len(via) >= maxRedirects {
+			return fmt.Errorf("stopped after %d redirects", maxRedirects)
+		}
+		if code := req.Response.StatusCode; code != http.StatusMovedPermanently && code != http.StatusPermanentRedirect {
+			res.permanent = false
+		}
+		return nil
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return probeResult{}, err
+	}
+	defer resp.Body.Close()
+	// Drain a little of the body so the connection can be reused.
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	res.statusCode = resp.StatusCode
+	res.finalURL = resp.Request.URL.String()
+	return res, nil
+}
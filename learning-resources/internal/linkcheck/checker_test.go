@@ -0,0 +1,248 @@
+package linkcheck
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/learnbot/database/repository"
+)
+
+// fakeStore keeps link check state in memory, applying the same failure
+// counting and deactivation rules as the repository.
+type fakeStore struct {
+	mu      sync.Mutex
+	targets []repository.LinkCheckTarget
+	status  map[uuid.UUID]*repository.ResourceLinkStatus
+	results map[uuid.UUID]repository.LinkCheckResult
+	touched map[uuid.UUID]int
+}
+
+func newFakeStore(targets ...repository.LinkCheckTarget) *fakeStore {
+	s := &fakeStore{
+		targets: targets,
+		status:  make(map[uuid.UUID]*repository.ResourceLinkStatus),
+		results: make(map[uuid.UUID]repository.LinkCheckResult),
+		touched: make(map[uuid.UUID]int),
+	}
+	for _, t := range targets {
+		s.status[t.ID] = &repository.ResourceLinkStatus{ID: t.ID, Title: t.Title, URL: t.URL, IsActive: true, IsVerified: true}
+	}
+	return s
+}
+
+func (s *fakeStore) ListLinkCheckTargets(ctx context.Context, checkedBefore time.Time, limit int) ([]repository.LinkCheckTarget, error) {
+	if len(s.targets) > limit {
+		return s.targets[:limit], nil
+	}
+	return s.targets, nil
+}
+
+func (s *fakeStore) GetLinkCheckTarget(ctx context.Context, id uuid.UUID) (*repository.LinkCheckTarget, error) {
+	for _, t := range s.targets {
+		if t.ID == id {
+			return &t, nil
+		}
+	}
+	return nil, nil
+}
+
+func (s *fakeStore) RecordLinkCheck(ctx context.Context, id uuid.UUID, result repository.LinkCheckResult, deactivateAfter int) (*repository.ResourceLinkStatus, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.results[id] = result
+	st := s.status[id]
+	if result.OK {
+		st.ConsecutiveFailures = 0
+		st.IsActive = st.IsActive || st.DeactivatedByCheck
+		st.DeactivatedByCheck = false
+	} else {
+		st.ConsecutiveFailures++
+		if st.ConsecutiveFailures >= deactivateAfter && st.IsActive {
+			st.IsActive, st.DeactivatedByCheck = false, true
+		}
+	}
+	if result.StatusCode == http.StatusNotFound || result.StatusCode == http.StatusGone {
+		st.IsVerified = false
+	}
+	if result.FinalURL != "" {
+		st.URL = result.FinalURL
+	}
+	out := *st
+	return &out, nil
+}
+
+func (s *fakeStore) TouchLinkCheck(ctx context.Context, id uuid.UUID, statusCode int) (*repository.ResourceLinkStatus, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.touched[id] = statusCode
+	out := *s.status[id]
+	return &out, nil
+}
+
+// fixtureServer serves one route per link health case.
+func fixtureServer() *httptest.Server {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/ok", func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, "<h1>Course</h1>")
+	})
+	mux.HandleFunc("/gone", func(w http.ResponseWriter, r *http.Request) {
+		http.NotFound(w, r)
+	})
+	mux.HandleFunc("/no-head", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodHead {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		io.WriteString(w, "<h1>Course</h1>")
+	})
+	mux.HandleFunc("/throttled", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTooManyRequests)
+	})
+	mux.HandleFunc("/error", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+	mux.HandleFunc("/moved", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/ok", http.StatusMovedPermanently)
+	})
+	mux.HandleFunc("/temporary", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/ok", http.StatusFound)
+	})
+	mux.HandleFunc("/moved-then-temporary", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/temporary", http.StatusPermanentRedirect)
+	})
+	return httptest.NewServer(mux)
+}
+
+func target(srv *httptest.Server, path string) repository.LinkCheckTarget {
+	return repository.LinkCheckTarget{ID: uuid.New(), Title: path, URL: srv.URL + path}
+}
+
+func newTestChecker(store Store, cfg Config) *Checker {
+	return New(store, cfg, log.New(io.Discard, "", 0))
+}
+
+func TestCheckBatch(t *testing.T) {
+	srv := fixtureServer()
+	defer srv.Close()
+
+	ok := target(srv, "/ok")
+	gone := target(srv, "/gone")
+	noHead := target(srv, "/no-head")
+	throttled := target(srv, "/throttled")
+	failing := target(srv, "/error")
+	moved := target(srv, "/moved")
+	temporary := target(srv, "/temporary")
+	mixed := target(srv, "/moved-then-temporary")
+	badURL := repository.LinkCheckTarget{ID: uuid.New(), URL: "ftp://example.com/course"}
+	store := newFakeStore(ok, gone, noHead, throttled, failing, moved, temporary, mixed, badURL)
+
+	c := newTestChecker(store, Config{PersistFinalURL: true})
+	stats, err := c.CheckBatch(context.Background())
+	if err != nil {
+		t.Fatalf("CheckBatch: %v", err)
+	}
+	want := CheckStats{Checked: 9, Healthy: 5, Failed: 3, Inconclusive: 1}
+	if stats != want {
+		t.Errorf("expected stats %+v, got %+v", want, stats)
+	}
+
+	for _, tc := range []struct {
+		target     repository.LinkCheckTarget
+		ok         bool
+		statusCode int
+		finalURL   string
+	}{
+		{ok, true, http.StatusOK, ""},
+		{gone, false, http.StatusNotFound, ""},
+		{noHead, true, http.StatusOK, ""},
+		{failing, false, http.StatusInternalServerError, ""},
+		{moved, true, http.StatusOK, srv.URL + "/ok"},
+		{temporary, true, http.StatusOK, ""},
+		{mixed, true, http.StatusOK, ""},
+		{badURL, false, 0, ""},
+	} {
+		got, recorded := store.results[tc.target.ID]
+		if !recorded {
+			t.Errorf("%s: no result recorded", tc.target.URL)
+			continue
+		}
+		if got.OK != tc.ok || got.StatusCode != tc.statusCode || got.FinalURL != tc.finalURL {
+			t.Errorf("%s: expected ok=%v status=%d final=%q, got %+v",
+				tc.target.URL, tc.ok, tc.statusCode, tc.finalURL, got)
+		}
+	}
+
+	if code, touched := store.touched[throttled.ID]; !touched || code != http.StatusTooManyRequests {
+		t.Errorf("throttled resource should only be touched, got touched=%v code=%d", touched, code)
+	}
+	if _, recorded := store.results[throttled.ID]; recorded {
+		t.Error("throttled resource should not count as a failure")
+	}
+	if store.status[gone.ID].IsVerified {
+		t.Error("a 404 should clear is_verified")
+	}
+}
+
+func TestCheckBatch_FinalURLNotPersistedByDefault(t *testing.T) {
+	srv := fixtureServer()
+	defer srv.Close()
+
+	moved := target(srv, "/moved")
+	store := newFakeStore(moved)
+	if _, err := newTestChecker(store, DefaultConfig()).CheckBatch(context.Background()); err != nil {
+		t.Fatalf("CheckBatch: %v", err)
+	}
+	if got := store.results[moved.ID]; !got.OK || got.FinalURL != "" {
+		t.Errorf("expected a healthy result without a final URL, got %+v", got)
+	}
+}
+
+func TestRecheck_DeactivatesAndReactivates(t *testing.T) {
+	healthy := true
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !healthy {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer srv.Close()
+
+	res := target(srv, "/course")
+	store := newFakeStore(res)
+	c := newTestChecker(store, Config{DeactivateAfter: 2})
+	ctx := context.Background()
+
+	healthy = false
+	st, err := c.Recheck(ctx, res.ID)
+	if err != nil {
+		t.Fatalf("Recheck: %v", err)
+	}
+	if !st.IsActive || st.ConsecutiveFailures != 1 {
+		t.Errorf("after one failure: %+v", st)
+	}
+	st, _ = c.Recheck(ctx, res.ID)
+	if st.IsActive || !st.DeactivatedByCheck || st.ConsecutiveFailures != 2 {
+		t.Errorf("expected deactivation after two failures: %+v", st)
+	}
+
+	healthy = true
+	st, _ = c.Recheck(ctx, res.ID)
+	if !st.IsActive || st.DeactivatedByCheck || st.ConsecutiveFailures != 0 {
+		t.Errorf("expected reactivation after a success: %+v", st)
+	}
+}
+
+func TestRecheck_NotFound(t *testing.T) {
+	c := newTestChecker(newFakeStore(), DefaultConfig())
+	if _, err := c.Recheck(context.Background(), uuid.New()); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+}
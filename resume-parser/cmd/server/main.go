@@ -4,44 +4,157 @@ package main
 import (
 	"context"
 	"flag"
-	"log"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
 	"time"
 
+	"google.golang.org/grpc"
+
 	"github.com/learnbot/resume-parser/internal/api"
 	"github.com/learnbot/resume-parser/internal/gapanalysis"
+	"github.com/learnbot/resume-parser/internal/grpcserver"
+	"github.com/learnbot/resume-parser/internal/jobparse"
 	"github.com/learnbot/resume-parser/internal/parser"
+	"github.com/learnbot/resume-parser/internal/quality"
 	"github.com/learnbot/resume-parser/internal/recommendation"
+	"github.com/learnbot/resume-parser/internal/resultcache"
 	"github.com/learnbot/resume-parser/internal/scorer"
 	"github.com/learnbot/resume-parser/internal/taxonomy"
+	"github.com/learnbot/shared/adminauth"
+	"github.com/learnbot/shared/healthcheck"
+	"github.com/learnbot/shared/logging"
+	"github.com/learnbot/shared/metrics"
+	"github.com/learnbot/shared/openapi"
+	"github.com/learnbot/shared/validation"
 )
 
 func main() {
 	addr := flag.String("addr", ":8080", "HTTP server address")
+	grpcAddr := flag.String("grpc-addr", ":9090",
+		"gRPC server address for the scoring and gap analysis services; empty disables it")
+	maxScoreBatch := flag.Int("max-score-batch", scorer.DefaultMaxBatchSize,
+		"maximum number of candidates per batch scoring request")
+	maxGapBatch := flag.Int("max-gap-analysis-batch", gapanalysis.DefaultMaxBatchJobs,
+		"maximum number of jobs per batch gap analysis request")
+	resourcesURL := flag.String("resources-url", os.Getenv("LEARNING_RESOURCES_URL"),
+		"learning-resources service base URL; when set, recommendations also draw on its catalog")
+	analyzeTimeout := flag.Duration("analyze-timeout", api.DefaultAnalyzeTimeout,
+		"deadline for a full analysis request (parse, score, gap analysis and learning plan)")
+	cacheSize := flag.Int("result-cache-size", resultcache.DefaultCapacity,
+		"number of score, gap analysis and learning plan results cached per endpoint; 0 disables caching")
+	cacheTTL := flag.Duration("result-cache-ttl", resultcache.DefaultTTL,
+		"how long a cached score, gap analysis or learning plan is reused")
+	maxUploadSize := flag.Int64("max-upload-size", api.DefaultMaxUploadSize,
+		"maximum size in bytes of an uploaded resume or analysis request body")
+	maxTextLength := flag.Int("max-text-length", parser.DefaultMaxTextLength,
+		"maximum number of characters extracted from a resume")
+	fuzzySkills := flag.Bool("fuzzy-skill-matching", true,
+		"match misspelled skills such as \"Kubernates\" by edit distance in skill extraction and gap analysis")
 	flag.Parse()
 
-	logger := log.New(os.Stdout, "[resume-parser] ", log.LstdFlags|log.Lshortfile)
+	slogger := logging.New("resume-parser", os.Stdout, logging.ConfigFromEnv())
+	logger := logging.StdLogger(slogger)
 
 	resumeParser := parser.NewResumeParser()
+	resumeParser.SetMaxTextLength(*maxTextLength)
 	handler := api.NewHandler(resumeParser, logger)
+	handler.SetMaxUploadSize(*maxUploadSize)
+	handler.SetAnalyzeTimeout(*analyzeTimeout)
 	scorerHandler := scorer.NewHandler(logger)
+	scorerHandler.SetMaxBatchSize(*maxScoreBatch)
 	taxonomyHandler := taxonomy.NewHandler(logger)
 	gapAnalysisHandler := gapanalysis.NewHandler(logger)
+	gapAnalysisHandler.SetMaxBatchJobs(*maxGapBatch)
+	taxonomyHandler.SetFuzzyMatching(*fuzzySkills)
+	gapAnalysisHandler.SetFuzzySkillMatching(*fuzzySkills)
+	jobParseHandler := jobparse.NewHandler(logger)
+
+	// Scoring and gap analysis accept a raw job_description in place of
+	// structured requirements.
+	scorerHandler.SetRequirementsExtractor(jobparse.ExtractRequirements)
+	gapAnalysisHandler.SetRequirementsExtractor(jobparse.ExtractRequirements)
+
+	// Resource prices are converted to the user's currency with one rate
+	// table, which admins replace through /admin/currency-rates.
+	rates := recommendation.NewStaticRates()
 	recommendationHandler := recommendation.NewHandler(logger)
+	analysisEngine := recommendation.New()
+	if *resourcesURL != "" {
+		remote := recommendation.NewHTTPCatalogSource(*resourcesURL,
+			&http.Client{Timeout: 5 * time.Second, Transport: logging.Transport(nil)})
+		recommendationHandler.SetCatalogSources(remote)
+		analysisEngine = recommendation.NewWithSources(logger,
+			recommendation.BuiltinCatalogSource(), remote)
+	}
+	recommendationHandler.SetCurrencyConverter(rates)
+	analysisEngine.SetCurrencyConverter(rates)
+	handler.SetRecommendationEngine(analysisEngine)
+
+	authCfg := adminauth.ConfigFromEnv()
+	if !authCfg.Enabled() {
+		logger.Println("warning: neither ADMIN_API_KEY nor JWT_SECRET is set; admin routes will reject all requests")
+	}
+	adminAuth := adminauth.New(authCfg, logger)
+	ratesHandler := recommendation.NewRatesHandler(rates, adminAuth, logger)
+
+	// Repeated analyses of the same profile and job, as when users navigate
+	// back and forth in the frontend, are served from memory.
+	scorerHandler.SetResultCache(resultcache.New[scorer.ScoreBreakdown]("score", *cacheSize, *cacheTTL))
+	gapAnalysisHandler.SetResultCache(resultcache.New[gapanalysis.GapAnalysisResult]("gap_analysis", *cacheSize, *cacheTTL))
+	recommendationHandler.SetResultCache(resultcache.New[recommendation.LearningPlan]("recommendations", *cacheSize, *cacheTTL))
+
+	// Unknown request fields are rejected unless
+	// VALIDATION_ALLOW_UNKNOWN_FIELDS=true.
+	validationCfg := validation.ConfigFromEnv()
+	scorerHandler.SetValidationConfig(validationCfg)
+	gapAnalysisHandler.SetValidationConfig(validationCfg)
+	recommendationHandler.SetValidationConfig(validationCfg)
+	ratesHandler.SetValidationConfig(validationCfg)
+
+	// Data quality events are shared by the parser and scorer handlers.
+	qualityTracker := quality.NewTracker(quality.DefaultRetention)
+	handler.SetQualityTracker(qualityTracker)
+	scorerHandler.SetQualityTracker(qualityTracker)
+	qualityHandler := quality.NewHandler(qualityTracker, adminAuth, logger)
+
+	// The gRPC server shares the HTTP handlers' configuration.
+	grpcServer := grpcserver.NewServer()
+	grpcServer.SetMaxBatchSize(*maxScoreBatch)
+	grpcServer.SetRequirementsExtractor(jobparse.ExtractRequirements)
+	grpcServer.SetQualityTracker(qualityTracker)
 
 	mux := http.NewServeMux()
 	handler.RegisterRoutes(mux)
 	scorerHandler.RegisterRoutes(mux)
 	taxonomyHandler.RegisterRoutes(mux)
 	gapAnalysisHandler.RegisterRoutes(mux)
+	jobParseHandler.RegisterRoutes(mux)
 	recommendationHandler.RegisterRoutes(mux)
+	ratesHandler.RegisterRoutes(mux)
+	qualityHandler.RegisterRoutes(mux)
+	mux.Handle("/metrics", metrics.Handler())
+	// The parser has no dependencies to check: /readyz is ready once the
+	// process serves.
+	health := healthcheck.New("resume-parser", healthcheck.DefaultTimeout)
+	health.RegisterRoutes(mux)
+
+	spec := openapi.New("resume-parser", "1.0.0")
+	spec.SetDescription("Resume parsing, job matching, skill gap analysis and learning plan APIs.")
+	for _, h := range []interface{ DescribeRoutes(*openapi.Spec) }{
+		handler, scorerHandler, taxonomyHandler, gapAnalysisHandler,
+		jobParseHandler, recommendationHandler, ratesHandler, qualityHandler, health,
+	} {
+		h.DescribeRoutes(spec)
+	}
+	spec.Route("/metrics", openapi.Metrics)
+	spec.Register(mux)
 
 	srv := &http.Server{
 		Addr:         *addr,
-		Handler:      mux,
+		Handler:      logging.Handler(slogger, metrics.Instrument(mux)),
 		ReadTimeout:  30 * time.Second,
 		WriteTimeout: 30 * time.Second,
 		IdleTimeout:  60 * time.Second,
@@ -58,12 +171,31 @@ func main() {
 		}
 	}()
 
+	var gs *grpc.Server
+	if *grpcAddr != "" {
+		lis, err := net.Listen("tcp", *grpcAddr)
+		if err != nil {
+			logger.Fatalf("gRPC listen error: %v", err)
+		}
+		gs = grpc.NewServer()
+		grpcServer.Register(gs)
+		go func() {
+			logger.Printf("starting gRPC server on %s", *grpcAddr)
+			if err := gs.Serve(lis); err != nil {
+				logger.Fatalf("gRPC server error: %v", err)
+			}
+		}()
+	}
+
 	<-quit
 	logger.Println("shutting down server...")
 
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
+	if gs != nil {
+		gs.GracefulStop()
+	}
 	if err := srv.Shutdown(ctx); err != nil {
 		logger.Fatalf("forced shutdown: %v", err)
 	}
@@ -2,4 +2,19 @@ module github.com/learnbot/resume-parser
 
 go 1.24.0
 
-require github.com/dslipak/pdf v0.0.2
+require (
+	github.com/dslipak/pdf v0.0.2
+	github.com/golang-jwt/jwt/v5 v5.2.2
+	github.com/learnbot/shared v0.0.0
+	google.golang.org/grpc v1.65.0
+	google.golang.org/protobuf v1.34.2
+)
+
+require (
+	golang.org/x/net v0.25.0 // indirect
+	golang.org/x/sys v0.20.0 // indirect
+	golang.org/x/text v0.15.0 // indirect
+	google.golang.org/genproto/googleapis/rpc v0.0.0-20240528184218-531527333157 // indirect
+)
+
+replace github.com/learnbot/shared => ../shared
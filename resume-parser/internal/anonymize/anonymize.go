@@ -0,0 +1,337 @@
+// Package anonymize strips personal information from parsed resumes so that
+// they can be reviewed without bias.
+//
+// The contact fields the parser identified are cleared, and the free text of
+// the resume (summary, responsibilities, project and certification text) is
+// scrubbed of the same values and of anything that looks like an email
+// address, phone number, link or street address. Graduation years are
+// bucketed into ranges. Skills, job titles, employment dates and degrees are
+// left as they are, so an anonymized resume scores the same as the original.
+package anonymize
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/learnbot/resume-parser/internal/schema"
+)
+
+// Placeholders that replace redacted values.
+const (
+	NamePlaceholder    = "[name]"
+	EmailPlaceholder   = "[email]"
+	PhonePlaceholder   = "[phone]"
+	LinkPlaceholder    = "[link]"
+	AddressPlaceholder = "[address]"
+)
+
+// DefaultYearBucket is the width in years of graduation year ranges.
+const DefaultYearBucket = 5
+
+// Options selects what is redacted. The zero value redacts nothing; see
+// DefaultOptions.
+type Options struct {
+	Name  bool `json:"name"`
+	Email bool `json:"email"`
+	Phone bool `json:"phone"`
+	// Address removes street addresses from the text, and the parts of the
+	// location that hold a house number or postal code. The city and
+	// country are kept, since location fit is scored on them; set Location
+	// to remove them too.
+	Address  bool `json:"address"`
+	Location bool `json:"location"`
+	// Links removes the LinkedIn, GitHub and website links, project URLs
+	// and any other link or profile handle in the text.
+	Links bool `json:"links"`
+	// GraduationYears replaces education dates with YearBucket-year ranges,
+	// e.g. "2015-2019".
+	GraduationYears bool `json:"graduation_years"`
+	YearBucket      int  `json:"year_bucket,omitempty"`
+}
+
+// DefaultOptions redacts everything except the city and country.
+func DefaultOptions() Options {
+	return Options{
+		Name:            true,
+		Email:           true,
+		Phone:           true,
+		Address:         true,
+		Links:           true,
+		GraduationYears: true,
+		YearBucket:      DefaultYearBucket,
+	}
+}
+
+// Validate reports options that cannot be applied.
+func (o Options) Validate() error {
+	if o.YearBucket < 0 || o.YearBucket > 50 {
+		return fmt.Errorf("year_bucket must be between 1 and 50 (0 for %d), got %d", DefaultYearBucket, o.YearBucket)
+	}
+	return nil
+}
+
+var (
+	emailRe = regexp.MustCompile(`(?i)[a-z0-9._%+\-]+@[a-z0-9.\-]+\.[a-z]{2,}`)
+	// phoneCandidateRe matches runs of digits and separators; only runs with
+	// enough digits are phone numbers, which keeps "2019 - 2021" intact.
+	phoneCandidateRe = regexp.MustCompile(`\+?\(?\d[\d\s().\-]{6,}\d`)
+	urlRe            = regexp.MustCompile(`(?i)\b(?:https?://|www\.)[^\s,;)]+`)
+	// Bare profile links and handles, such as "github.com/jdoe" or
+	// "GitHub: jdoe".
+	profileRe = regexp.MustCompile(`(?i)\b(?:(?:linkedin\.com/in|github\.com|gitlab\.com|twitter\.com|x\.com)/[a-z0-9_.\-]+|(?:linkedin|github|gitlab|twitter)\s*:\s*@?[a-z0-9_.\-]+)`)
+	// streetRe matches a house number followed by a street name, in
+	// English ("221B Baker Street") or Indonesian ("Jl. Sudirman No. 5").
+	streetRe = regexp.MustCompile(`(?i)\b(?:\d{1,5}[a-z]?\s+(?:[a-z][a-z'.\-]*\s+){1,4}(?:street|st|avenue|ave|road|rd|boulevard|blvd|lane|ln|drive|dr|way|court|ct|place|pl|square|sq)\b\.?|(?:jl|jln|jalan)\.?\s+[a-z][a-z.\s]{1,40}?\s+no\.?\s*\d+[a-z]?)`)
+	yearRe   = regexp.MustCompile(`\b(19|20)\d{2}\b`)
+)
+
+// Resume returns an anonymized copy of r and the JSON paths of the fields
+// that were redacted. r is not modified. The raw text is always dropped,
+// since it cannot be scrubbed reliably.
+func Resume(r *schema.ParsedResume, opts Options) (*schema.ParsedResume, []string) {
+	if opts.YearBucket <= 0 {
+		opts.YearBucket = DefaultYearBucket
+	}
+	a := &anonymizer{opts: opts, names: nameVariants(r.PersonalInfo.Name), handles: profileHandles(r.PersonalInfo)}
+	out := copyResume(r)
+
+	p := &out.PersonalInfo
+	a.clear(opts.Name, &p.Name, "personal_info.name", NamePlaceholder)
+	a.clear(opts.Email, &p.Email, "personal_info.email", EmailPlaceholder)
+	a.clear(opts.Phone, &p.Phone, "personal_info.phone", PhonePlaceholder)
+	a.clear(opts.Links, &p.LinkedIn, "personal_info.linkedin", "")
+	a.clear(opts.Links, &p.GitHub, "personal_info.github", "")
+	a.clear(opts.Links, &p.Website, "personal_info.website", "")
+	switch {
+	case opts.Location:
+		a.clear(true, &p.Location, "personal_info.location", "")
+	case opts.Address:
+		if loc := cityAndCountry(p.Location); loc != p.Location {
+			p.Location = loc
+			a.redacted("personal_info.location")
+		}
+	}
+	// Resume files are commonly named after the candidate.
+	a.clear(opts.Name, &out.SourceFile, "source_file", "")
+	a.clear(out.RawText != "", &out.RawText, "raw_text", "")
+
+	a.scrub(&out.Summary, "summary")
+	for i := range out.WorkExperience {
+		exp := &out.WorkExperience[i]
+		for j := range exp.Responsibilities {
+			a.scrub(&exp.Responsibilities[j], fmt.Sprintf("work_experience[%d].responsibilities[%d]", i, j))
+		}
+	}
+	for i := range out.Education {
+		edu := &out.Education[i]
+		a.scrub(&edu.Honors, fmt.Sprintf("education[%d].honors", i))
+		if opts.GraduationYears {
+			a.bucket(&edu.StartDate, fmt.Sprintf("education[%d].start_date", i))
+			a.bucket(&edu.EndDate, fmt.Sprintf("education[%d].end_date", i))
+		}
+	}
+	for i := range out.Projects {
+		proj := &out.Projects[i]
+		a.clear(opts.Links, &proj.URL, fmt.Sprintf("projects[%d].url", i), "")
+		a.scrub(&proj.Name, fmt.Sprintf("projects[%d].name", i))
+		a.scrub(&proj.Description, fmt.Sprintf("projects[%d].description", i))
+	}
+	for i := range out.Certifications {
+		a.scrub(&out.Certifications[i].ID, fmt.Sprintf("certifications[%d].id", i))
+	}
+	return out, a.fields
+}
+
+// anonymizer applies one set of options, recording what it redacted.
+type anonymizer struct {
+	opts    Options
+	names   []*regexp.Regexp
+	handles []*regexp.Regexp
+	fields  []string
+}
+
+func (a *anonymizer) redacted(field string) {
+	a.fields = append(a.fields, field)
+}
+
+// clear replaces a non-empty field with placeholder if redact is set.
+func (a *anonymizer) clear(redact bool, s *string, field, placeholder string) {
+	if redact && *s != "" {
+		*s = placeholder
+		a.redacted(field)
+	}
+}
+
+// scrub redacts personal information inside free text.
+func (a *anonymizer) scrub(s *string, field string) {
+	if *s == "" {
+		return
+	}
+	scrubbed := a.scrubText(*s)
+	if scrubbed != *s {
+		*s = scrubbed
+		a.redacted(field)
+	}
+}
+
+// scrubText redacts personal information in text. Emails and links go
+// first, since they may contain the candidate's name or digits.
+func (a *anonymizer) scrubText(text string) string {
+	if a.opts.Email {
+		text = emailRe.ReplaceAllString(text, EmailPlaceholder)
+	}
+	if a.opts.Links {
+		// A sentence ending in a link keeps its full stop.
+		text = urlRe.ReplaceAllStringFunc(text, func(m string) string {
+			return LinkPlaceholder + m[len(strings.TrimRight(m, ".:!?")):]
+		})
+		text = profileRe.ReplaceAllString(text, LinkPlaceholder)
+		for _, re := range a.handles {
+			text = replaceWords(text, re, LinkPlaceholder)
+		}
+	}
+	if a.opts.Address || a.opts.Location {
+		text = streetRe.ReplaceAllString(text, AddressPlaceholder)
+	}
+	if a.opts.Phone {
+		text = phoneCandidateRe.ReplaceAllStringFunc(text, func(m string) string {
+			if digits := countDigits(m); digits < 9 || digits > 15 {
+				return m
+			}
+			return PhonePlaceholder
+		})
+	}
+	if a.opts.Name {
+		for _, re := range a.names {
+			text = replaceWords(text, re, NamePlaceholder)
+		}
+	}
+	return text
+}
+
+// bucket replaces the year of an education date with its range.
+func (a *anonymizer) bucket(s *string, field string) {
+	m := yearRe.FindString(*s)
+	if m == "" {
+		return
+	}
+	year, _ := strconv.Atoi(m)
+	*s = YearRange(year, a.opts.YearBucket)
+	a.redacted(field)
+}
+
+// YearRange returns the width-year range containing year, aligned to
+// multiples of width, e.g. "2015-2019" for 2017 and a width of 5.
+func YearRange(year, width int) string {
+	if width <= 1 {
+		return strconv.Itoa(year)
+	}
+	start := year - year%width
+	return fmt.Sprintf("%d-%d", start, start+width-1)
+}
+
+// nameVariants returns case-insensitive patterns for the full name and for
+// its parts that are long enough to be matched on their own, full name
+// first.
+func nameVariants(name string) []*regexp.Regexp {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil
+	}
+	variants := []*regexp.Regexp{regexp.MustCompile(`(?i)` + regexp.QuoteMeta(name))}
+	for _, part := range strings.Fields(name) {
+		part = strings.Trim(part, ".,")
+		if len([]rune(part)) >= 3 && !strings.EqualFold(part, name) {
+			variants = append(variants, regexp.MustCompile(`(?i)`+regexp.QuoteMeta(part)))
+		}
+	}
+	return variants
+}
+
+// profileHandles returns patterns for the LinkedIn and GitHub handles of
+// info, which also show up in repository names such as "jdoe/queue".
+func profileHandles(info schema.PersonalInfo) []*regexp.Regexp {
+	var handles []*regexp.Regexp
+	for _, link := range []string{info.LinkedIn, info.GitHub} {
+		link = strings.TrimRight(link, "/")
+		handle := link[strings.LastIndex(link, "/")+1:]
+		if len(handle) >= 3 {
+			handles = append(handles, regexp.MustCompile(`(?i)`+regexp.QuoteMeta(handle)))
+		}
+	}
+	return handles
+}
+
+// replaceWords replaces the matches of re that are whole words. Go's \b
+// only knows ASCII letters, so word boundaries are checked here.
+func replaceWords(text string, re *regexp.Regexp, repl string) string {
+	var b strings.Builder
+	last := 0
+	for _, m := range re.FindAllStringIndex(text, -1) {
+		before, _ := utf8.DecodeLastRuneInString(text[:m[0]])
+		after, _ := utf8.DecodeRuneInString(text[m[1]:])
+		if isWordRune(before) || isWordRune(after) {
+			continue
+		}
+		b.WriteString(text[last:m[0]])
+		b.WriteString(repl)
+		last = m[1]
+	}
+	if last == 0 {
+		return text
+	}
+	b.WriteString(text[last:])
+	return b.String()
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// cityAndCountry drops the parts of a comma-separated location that hold
+// a street address or postal code.
+func cityAndCountry(location string) string {
+	var kept []string
+	for _, part := range strings.Split(location, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" || strings.IndexFunc(part, unicode.IsDigit) >= 0 {
+			continue
+		}
+		kept = append(kept, part)
+	}
+	return strings.Join(kept, ", ")
+}
+
+func countDigits(s string) int {
+	n := 0
+	for _, r := range s {
+		if r >= '0' && r <= '9' {
+			n++
+		}
+	}
+	return n
+}
+
+// copyResume returns a copy of r that shares no slices with it.
+func copyResume(r *schema.ParsedResume) *schema.ParsedResume {
+	out := *r
+	out.WorkExperience = slices.Clone(r.WorkExperience)
+	for i := range out.WorkExperience {
+		out.WorkExperience[i].Responsibilities = slices.Clone(r.WorkExperience[i].Responsibilities)
+	}
+	out.Education = slices.Clone(r.Education)
+	out.Skills = slices.Clone(r.Skills)
+	out.Certifications = slices.Clone(r.Certifications)
+	out.Projects = slices.Clone(r.Projects)
+	for i := range out.Projects {
+		out.Projects[i].Technologies = slices.Clone(r.Projects[i].Technologies)
+	}
+	out.SectionsFound = slices.Clone(r.SectionsFound)
+	out.Warnings = slices.Clone(r.Warnings)
+	return &out
+}
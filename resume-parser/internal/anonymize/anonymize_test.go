@@ -0,0 +1,194 @@
+package anonymize
+
+import (
+	"encoding/json"
+	"regexp"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/learnbot/resume-parser/internal/extractor"
+	"github.com/learnbot/resume-parser/internal/schema"
+)
+
+// The patterns the parser extracts contact information with.
+var (
+	parserEmailRe = regexp.MustCompile(`(?i)[a-z0-9._%+\-]+@[a-z0-9.\-]+\.[a-z]{2,}`)
+	parserPhoneRe = regexp.MustCompile(`(?:\+?1[\s\-.]?)?\(?\d{3}\)?[\s\-.]?\d{3}[\s\-.]?\d{4}`)
+)
+
+func testResume() *schema.ParsedResume {
+	return &schema.ParsedResume{
+		SourceFile: "Jane_Doe_Resume.pdf",
+		FileType:   "pdf",
+		PersonalInfo: schema.PersonalInfo{
+			Name:     "Jane Doe",
+			Email:    "jane.doe@example.com",
+			Phone:    "(555) 123-4567",
+			Location: "Jakarta, Indonesia",
+			LinkedIn: "https://linkedin.com/in/janedoe",
+			GitHub:   "https://github.com/janedoe",
+			Website:  "https://janedoe.dev",
+		},
+		Summary: "Jane Doe is a backend engineer. Reach me at jane.doe@example.com, " +
+			"+62 812-3456-7890 or 555.987.6543. Code at github.com/janedoe and https://janedoe.dev/blog. " +
+			"Based at Jl. Sudirman No. 5, Jakarta.",
+		WorkExperience: []schema.WorkExperience{{
+			Company:   "Acme",
+			Title:     "Senior Backend Engineer",
+			StartDate: "Jan 2019",
+			EndDate:   "Mar 2023",
+			Responsibilities: []string{
+				"Cut p99 latency by 40% in 2019 - 2021 across 12 services",
+				"On call rotation contact: 555-222-3333, GitHub: jdoe-acme",
+			},
+		}},
+		Education: []schema.Education{{
+			Institution: "Universitas Indonesia",
+			Degree:      "Bachelor of Science",
+			Field:       "Computer Science",
+			StartDate:   "2013",
+			EndDate:     "Aug 2017",
+			Honors:      "Dean's list; thesis advised by Prof. Doe",
+		}},
+		Skills: []schema.Skill{{Name: "Go", Category: "technical"}, {Name: "PostgreSQL", Category: "technical"}},
+		Projects: []schema.Project{{
+			Name:         "janedoe/queue",
+			Description:  "A job queue, see https://github.com/janedoe/queue",
+			Technologies: []string{"Go", "Redis"},
+			URL:          "https://github.com/janedoe/queue",
+		}},
+		Certifications: []schema.Certification{{Name: "AWS Solutions Architect", ID: "555 444 3333"}},
+		SectionsFound:  []string{"experience", "education", "skills"},
+		RawText:        "Jane Doe\njane.doe@example.com\n(555) 123-4567",
+	}
+}
+
+func TestResume_NoContactInfoSurvives(t *testing.T) {
+	original := testResume()
+	out, fields := Resume(original, DefaultOptions())
+
+	data, err := json.Marshal(out)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	text := string(data)
+	if m := parserEmailRe.FindString(text); m != "" {
+		t.Errorf("email %q survived: %s", m, text)
+	}
+	if m := parserPhoneRe.FindString(text); m != "" {
+		t.Errorf("phone %q survived: %s", m, text)
+	}
+	if info := extractor.ExtractPersonalInfo(text); info.Email != "" || info.Phone != "" {
+		t.Errorf("parser still finds contact info: %+v", info)
+	}
+	for _, s := range []string{"Jane", "Doe", "janedoe", "jdoe-acme", "Sudirman", "2013", "2017"} {
+		if strings.Contains(text, s) {
+			t.Errorf("%q survived: %s", s, text)
+		}
+	}
+	if len(fields) == 0 || !slices.Contains(fields, "personal_info.email") || !slices.Contains(fields, "summary") {
+		t.Errorf("unexpected redacted fields %v", fields)
+	}
+
+	// The original is untouched.
+	if original.PersonalInfo.Email != "jane.doe@example.com" || !strings.Contains(original.Summary, "Jane") ||
+		original.Projects[0].URL == "" || original.Education[0].EndDate != "Aug 2017" {
+		t.Errorf("original resume was modified: %+v", original)
+	}
+}
+
+func TestResume_PreservesScoredFields(t *testing.T) {
+	out, _ := Resume(testResume(), DefaultOptions())
+
+	exp := out.WorkExperience[0]
+	if exp.Title != "Senior Backend Engineer" || exp.Company != "Acme" || exp.StartDate != "Jan 2019" || exp.EndDate != "Mar 2023" {
+		t.Errorf("work history changed: %+v", exp)
+	}
+	if want := "Cut p99 latency by 40% in 2019 - 2021 across 12 services"; exp.Responsibilities[0] != want {
+		t.Errorf("responsibility = %q, want %q", exp.Responsibilities[0], want)
+	}
+	edu := out.Education[0]
+	if edu.Degree != "Bachelor of Science" || edu.Field != "Computer Science" || edu.StartDate != "2010-2014" || edu.EndDate != "2015-2019" {
+		t.Errorf("unexpected education %+v", edu)
+	}
+	if len(out.Skills) != 2 || out.Skills[0].Name != "Go" || out.PersonalInfo.Location != "Jakarta, Indonesia" {
+		t.Errorf("skills or location changed: %+v %q", out.Skills, out.PersonalInfo.Location)
+	}
+	if out.PersonalInfo.Name != NamePlaceholder || out.RawText != "" || out.SourceFile != "" {
+		t.Errorf("unexpected personal info %+v", out.PersonalInfo)
+	}
+}
+
+func TestResume_Options(t *testing.T) {
+	out, fields := Resume(testResume(), Options{Email: true})
+	if out.PersonalInfo.Email != EmailPlaceholder || out.PersonalInfo.Name != "Jane Doe" || out.PersonalInfo.Phone == PhonePlaceholder {
+		t.Errorf("unexpected personal info %+v", out.PersonalInfo)
+	}
+	if !strings.Contains(out.Summary, "Jane Doe") || !strings.Contains(out.Summary, "github.com/janedoe") ||
+		strings.Contains(out.Summary, "@") {
+		t.Errorf("unexpected summary %q", out.Summary)
+	}
+	if out.Education[0].EndDate != "Aug 2017" {
+		t.Errorf("graduation year bucketed without GraduationYears: %q", out.Education[0].EndDate)
+	}
+	if slices.Contains(fields, "personal_info.name") {
+		t.Errorf("name reported as redacted: %v", fields)
+	}
+
+	opts := DefaultOptions()
+	opts.Location = true
+	opts.YearBucket = 10
+	out, _ = Resume(testResume(), opts)
+	if out.PersonalInfo.Location != "" || out.Education[0].EndDate != "2010-2019" {
+		t.Errorf("location %q, end date %q", out.PersonalInfo.Location, out.Education[0].EndDate)
+	}
+
+	if err := (Options{YearBucket: -1}).Validate(); err == nil {
+		t.Error("expected a negative year bucket to be invalid")
+	}
+}
+
+func TestResume_StreetAddress(t *testing.T) {
+	r := &schema.ParsedResume{
+		PersonalInfo: schema.PersonalInfo{Location: "221B Baker Street, London, NW1 6XE, United Kingdom"},
+		Summary:      "Lives at 221B Baker Street and previously 10 Downing St. in London.",
+	}
+	out, _ := Resume(r, DefaultOptions())
+	if out.PersonalInfo.Location != "London, United Kingdom" {
+		t.Errorf("Location = %q", out.PersonalInfo.Location)
+	}
+	if want := "Lives at [address] and previously [address] in London."; out.Summary != want {
+		t.Errorf("Summary = %q, want %q", out.Summary, want)
+	}
+}
+
+func TestResume_NameWordBoundaries(t *testing.T) {
+	r := &schema.ParsedResume{
+		PersonalInfo: schema.PersonalInfo{Name: "Ann Lee"},
+		Summary:      "Ann led the planning; LEE reviewed. Annual reports by Annette.",
+	}
+	out, _ := Resume(r, DefaultOptions())
+	if want := "[name] led the planning; [name] reviewed. Annual reports by Annette."; out.Summary != want {
+		t.Errorf("Summary = %q, want %q", out.Summary, want)
+	}
+}
+
+func TestYearRange(t *testing.T) {
+	tests := []struct {
+		year, width int
+		want        string
+	}{
+		{2017, 5, "2015-2019"},
+		{2015, 5, "2015-2019"},
+		{2019, 5, "2015-2019"},
+		{2020, 10, "2020-2029"},
+		{1998, 3, "1998-2000"},
+		{2017, 1, "2017"},
+	}
+	for _, tt := range tests {
+		if got := YearRange(tt.year, tt.width); got != tt.want {
+			t.Errorf("YearRange(%d, %d) = %q, want %q", tt.year, tt.width, got, tt.want)
+		}
+	}
+}
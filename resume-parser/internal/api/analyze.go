@@ -0,0 +1,264 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/learnbot/resume-parser/internal/gapanalysis"
+	"github.com/learnbot/resume-parser/internal/quality"
+	"github.com/learnbot/resume-parser/internal/recommendation"
+	"github.com/learnbot/resume-parser/internal/schema"
+	"github.com/learnbot/resume-parser/internal/scorer"
+)
+
+// DefaultAnalyzeTimeout bounds a full analysis request. It is kept below the
+// server's write timeout so that completed stages can still be returned.
+const DefaultAnalyzeTimeout = 25 * time.Second
+
+// Stages that run after the resume is parsed, in order. They name the
+// stage in warnings.
+const (
+	StageScore        = "score"
+	StageGapAnalysis  = "gap_analysis"
+	StageLearningPlan = "learning_plan"
+)
+
+// AnalyzeRequest is the JSON body of POST /api/v1/analyze/full. Exactly one
+// of ParsedResume and Profile must be set. Multipart requests carry the same
+// fields as form values, with a "resume" file in place of either.
+type AnalyzeRequest struct {
+	// ParsedResume is a resume previously returned by POST /api/v1/parse.
+	ParsedResume *schema.ParsedResume `json:"parsed_resume,omitempty"`
+
+	// Profile is a ready-made scoring profile.
+	Profile *scorer.CandidateProfile `json:"profile,omitempty"`
+
+	// Job is the job requirements to analyze against.
+	Job scorer.JobRequirements `json:"job"`
+
+	// Preferences are the user's learning preferences for the plan.
+	Preferences recommendation.UserPreferences `json:"preferences"`
+}
+
+// AnalyzeWarning reports a stage that failed or did not run.
+type AnalyzeWarning struct {
+	Stage   string `json:"stage"`
+	Message string `json:"message"`
+}
+
+// AnalyzeResult holds the output of every completed stage. A stage that
+// failed is omitted and reported in Warnings.
+type AnalyzeResult struct {
+	ParsedResume *schema.ParsedResume           `json:"parsed_resume,omitempty"`
+	Profile      *scorer.CandidateProfile       `json:"profile,omitempty"`
+	Score        *scorer.ScoreBreakdown         `json:"score,omitempty"`
+	GapAnalysis  *gapanalysis.GapAnalysisResult `json:"gap_analysis,omitempty"`
+	LearningPlan *recommendation.LearningPlan   `json:"learning_plan,omitempty"`
+	Warnings     []AnalyzeWarning               `json:"warnings,omitempty"`
+}
+
+// AnalyzeResponse is the response of POST /api/v1/analyze/full.
+type AnalyzeResponse struct {
+	Success bool               `json:"success"`
+	Data    *AnalyzeResult     `json:"data,omitempty"`
+	Error   *schema.ParseError `json:"error,omitempty"`
+}
+
+// SetRecommendationEngine sets the engine used for the learning plan stage
+// of a full analysis, e.g. one that also draws on remote catalog sources.
+func (h *Handler) SetRecommendationEngine(e *recommendation.Engine) {
+	h.recommender = e
+}
+
+// SetAnalyzeTimeout sets the deadline for a full analysis request.
+func (h *Handler) SetAnalyzeTimeout(d time.Duration) {
+	h.analyzeTimeout = d
+}
+
+// AnalyzeFull handles POST /api/v1/analyze/full, running parse, score, gap
+// analysis and learning plan generation in one request.
+//
+// The request is either multipart/form-data with a "resume" file and "job"
+// and optional "preferences" fields holding JSON, or a JSON AnalyzeRequest
+// carrying a pre-parsed resume or profile. The enrich query parameter is
+// honoured as for /api/v1/parse.
+//
+// Invalid input and parse failures are errors, since nothing can be
+// analyzed without a profile. Once a profile exists, a stage that fails or
+// misses the request deadline is reported in data.warnings and the stages
+// that completed are still returned with 200.
+//
+// Example:
+//
+//	curl -X POST http://localhost:8080/api/v1/analyze/full \
+//	  -F "resume=@/path/to/resume.pdf" \
+//	  -F 'job={"title":"Backend Engineer","required_skills":["Go","SQL"]}' \
+//	  -F 'preferences={"weekly_hours_available":8}'
+func (h *Handler) AnalyzeFull(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		h.writeError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED",
+			"only POST is supported", "")
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, h.maxUploadSize)
+	ctx, cancel := context.WithTimeout(r.Context(), h.analyzeTimeout)
+	defer cancel()
+
+	var (
+		req    AnalyzeRequest
+		upload *schema.ParseRequest
+	)
+	if strings.HasPrefix(r.Header.Get("Content-Type"), "multipart/form-data") {
+		parseReq, perr := readUpload(r)
+		if perr != nil {
+			h.writeError(w, parseErrorToHTTPStatus(perr.Code), perr.Code, perr.Message, perr.Section)
+			return
+		}
+		upload = &parseReq
+		if err := decodeFormJSON(r, "job", &req.Job, true); err != nil {
+			h.writeError(w, http.StatusBadRequest, "INVALID_REQUEST", err.Error(), "")
+			return
+		}
+		if err := decodeFormJSON(r, "preferences", &req.Preferences, false); err != nil {
+			h.writeError(w, http.StatusBadRequest, "INVALID_REQUEST", err.Error(), "")
+			return
+		}
+	} else {
+		dec := json.NewDecoder(r.Body)
+		dec.DisallowUnknownFields()
+		if err := dec.Decode(&req); err != nil {
+			if perr := tooLargeError(err); perr != nil {
+				h.writeError(w, parseErrorToHTTPStatus(perr.Code), perr.Code, perr.Message, "")
+				return
+			}
+			h.writeError(w, http.StatusBadRequest, "INVALID_REQUEST",
+				"invalid request body: "+err.Error(), "")
+			return
+		}
+		if (req.ParsedResume == nil) == (req.Profile == nil) {
+			h.writeError(w, http.StatusBadRequest, "INVALID_REQUEST",
+				"exactly one of parsed_resume and profile is required", "")
+			return
+		}
+	}
+
+	result := &AnalyzeResult{ParsedResume: req.ParsedResume, Profile: req.Profile}
+
+	if upload != nil {
+		v, err := runStage(ctx, func() interface{} {
+			parsed, perr := h.parse(*upload)
+			if perr != nil {
+				return perr
+			}
+			return parsed
+		})
+		switch {
+		case errors.Is(err, context.DeadlineExceeded):
+			h.writeError(w, http.StatusGatewayTimeout, "TIMEOUT",
+				"resume parsing did not finish before the request deadline", "")
+			return
+		case err != nil:
+			h.writeError(w, http.StatusInternalServerError, "PARSE_ERROR", err.Error(), "")
+			return
+		}
+		if perr, ok := v.(*schema.ParseError); ok {
+			h.writeError(w, parseErrorToHTTPStatus(perr.Code), perr.Code, perr.Message, perr.Section)
+			return
+		}
+		result.ParsedResume = v.(*schema.ParsedResume)
+	}
+	if result.Profile == nil {
+		profile := candidateProfileFromResume(result.ParsedResume, time.Now())
+		result.Profile = &profile
+	}
+
+	profile, job := *result.Profile, req.Job
+	if v, ok := h.stage(ctx, result, StageScore, func() interface{} {
+		breakdown := scorer.Calculate(profile, job)
+		// The explanation is only returned by /score with verbose=true.
+		breakdown.Explanation = nil
+		return &breakdown
+	}); ok {
+		result.Score = v.(*scorer.ScoreBreakdown)
+		if len(result.Score.Warnings) > 0 {
+			h.quality.Record(quality.CheckScoringInconsistencies, job.Title)
+		}
+	}
+	if v, ok := h.stage(ctx, result, StageGapAnalysis, func() interface{} {
+		gaps := h.gaps.Analyze(profile, job)
+		return &gaps
+	}); ok {
+		result.GapAnalysis = v.(*gapanalysis.GapAnalysisResult)
+	}
+	if v, ok := h.stage(ctx, result, StageLearningPlan, func() interface{} {
+		plan := h.recommender.GenerateContext(ctx, profile, job, req.Preferences)
+		return &plan
+	}); ok {
+		result.LearningPlan = v.(*recommendation.LearningPlan)
+	}
+
+	h.writeJSON(w, http.StatusOK, AnalyzeResponse{Success: true, Data: result})
+}
+
+// stage runs one analysis stage and returns its output, or records a
+// warning on result if the stage panics or misses the deadline.
+func (h *Handler) stage(ctx context.Context, result *AnalyzeResult, name string, fn func() interface{}) (interface{}, bool) {
+	v, err := runStage(ctx, fn)
+	if err != nil {
+		h.logger.Printf("WARN: analyze stage %s failed: %v", name, err)
+		result.Warnings = append(result.Warnings, AnalyzeWarning{Stage: name, Message: err.Error()})
+		return nil, false
+	}
+	return v, true
+}
+
+// runStage runs fn in its own goroutine, converting a panic into an error.
+// If ctx is done first, runStage returns ctx's error without waiting for fn,
+// whose result is then discarded.
+func runStage(ctx context.Context, fn func() interface{}) (interface{}, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("skipped: %w", err)
+	}
+	type outcome struct {
+		v   interface{}
+		err error
+	}
+	done := make(chan outcome, 1)
+	go func() {
+		defer func() {
+			if rec := recover(); rec != nil {
+				done <- outcome{err: fmt.Errorf("panic: %v", rec)}
+			}
+		}()
+		done <- outcome{v: fn()}
+	}()
+	select {
+	case o := <-done:
+		return o.v, o.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// decodeFormJSON decodes the JSON in form field name into v.
+func decodeFormJSON(r *http.Request, name string, v interface{}, required bool) error {
+	raw := r.FormValue(name)
+	if raw == "" {
+		if required {
+			return fmt.Errorf("'%s' field is required", name)
+		}
+		return nil
+	}
+	dec := json.NewDecoder(strings.NewReader(raw))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(v); err != nil {
+		return fmt.Errorf("invalid '%s' field: %v", name, err)
+	}
+	return nil
+}
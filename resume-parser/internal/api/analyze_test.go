@@ -0,0 +1,254 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/learnbot/resume-parser/internal/schema"
+)
+
+const analyzeJob = `{"title":"Backend Engineer","required_skills":["Go","Docker"],"min_years_experience":2,"location_type":"remote"}`
+
+// createAnalyzeRequest creates a multipart full analysis request with a
+// DOCX resume and the given form fields.
+func createAnalyzeRequest(t *testing.T, resumeText string, fields map[string]string) *http.Request {
+	t.Helper()
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	part, err := writer.CreateFormFile("resume", "resume.docx")
+	if err != nil {
+		t.Fatalf("failed to create form file: %v", err)
+	}
+	io.Copy(part, bytes.NewReader(buildMinimalDOCX(resumeText)))
+	for k, v := range fields {
+		writer.WriteField(k, v)
+	}
+	writer.Close()
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/analyze/full", &body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	return req
+}
+
+func decodeAnalyzeResponse(t *testing.T, w *httptest.ResponseRecorder) AnalyzeResponse {
+	t.Helper()
+	var resp AnalyzeResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	return resp
+}
+
+func TestAnalyzeFull_Upload(t *testing.T) {
+	h := buildTestHandler()
+	req := createAnalyzeRequest(t, "Jane Doe\njane@example.com\n\nSKILLS\nGo, Python, SQL", map[string]string{
+		"job":         analyzeJob,
+		"preferences": `{"weekly_hours_available":5}`,
+	})
+	w := httptest.NewRecorder()
+
+	h.AnalyzeFull(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	resp := decodeAnalyzeResponse(t, w)
+	d := resp.Data
+	if !resp.Success || d == nil {
+		t.Fatalf("expected success, got %+v", resp)
+	}
+	if len(d.Warnings) != 0 {
+		t.Errorf("expected no warnings, got %+v", d.Warnings)
+	}
+	if d.ParsedResume == nil || d.Profile == nil || d.Score == nil || d.GapAnalysis == nil || d.LearningPlan == nil {
+		t.Fatalf("expected every stage in the result, got %+v", d)
+	}
+	if len(d.Profile.Skills) == 0 {
+		t.Error("expected the profile to carry the parsed skills")
+	}
+	if !containsString(d.GapAnalysis.MatchedSkills, "Go") {
+		t.Errorf("expected Go to be matched, got %v", d.GapAnalysis.MatchedSkills)
+	}
+	if d.LearningPlan.JobTitle != "Backend Engineer" || d.LearningPlan.Timeline.WeeklyHours != 5 {
+		t.Errorf("expected the plan to use the job and preferences, got %+v", d.LearningPlan)
+	}
+}
+
+func TestAnalyzeFull_ProfileJSON(t *testing.T) {
+	h := buildTestHandler()
+	body := `{"profile":{"skills":[{"name":"Go","proficiency":"advanced"}],"years_of_experience":3},"job":` + analyzeJob + `}`
+	w := httptest.NewRecorder()
+
+	h.AnalyzeFull(w, httptest.NewRequest(http.MethodPost, "/api/v1/analyze/full", strings.NewReader(body)))
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	d := decodeAnalyzeResponse(t, w).Data
+	if d == nil || d.ParsedResume != nil || d.Score == nil || d.LearningPlan == nil {
+		t.Fatalf("expected score and plan without a parsed resume, got %+v", d)
+	}
+	if d.LearningPlan.TotalGaps != 1 {
+		t.Errorf("expected Docker as the only gap, got %d", d.LearningPlan.TotalGaps)
+	}
+}
+
+func TestAnalyzeFull_InvalidInput(t *testing.T) {
+	h := buildTestHandler()
+	tests := []struct {
+		name string
+		req  *http.Request
+		want int
+	}{
+		{"wrong method", httptest.NewRequest(http.MethodGet, "/api/v1/analyze/full", nil), http.StatusMethodNotAllowed},
+		{"no profile", httptest.NewRequest(http.MethodPost, "/api/v1/analyze/full",
+			strings.NewReader(`{"job":`+analyzeJob+`}`)), http.StatusBadRequest},
+		{"malformed JSON", httptest.NewRequest(http.MethodPost, "/api/v1/analyze/full",
+			strings.NewReader(`{"profile":`)), http.StatusBadRequest},
+		{"missing job field", createAnalyzeRequest(t, "Jane Doe\n\nSKILLS\nGo", nil), http.StatusBadRequest},
+		{"bad preferences field", createAnalyzeRequest(t, "Jane Doe\n\nSKILLS\nGo", map[string]string{
+			"job": analyzeJob, "preferences": `{"weekly_hours_available":"lots"}`,
+		}), http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			h.AnalyzeFull(w, tt.req)
+			if w.Code != tt.want {
+				t.Errorf("expected %d, got %d: %s", tt.want, w.Code, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestAnalyzeFull_BodyTooLarge(t *testing.T) {
+	h := buildTestHandler()
+	h.SetMaxUploadSize(1 << 10)
+	body := `{"profile":{"skills":[{"name":"` + strings.Repeat("x", 4<<10) + `"}]},"job":` + analyzeJob + `}`
+	w := httptest.NewRecorder()
+
+	h.AnalyzeFull(w, httptest.NewRequest(http.MethodPost, "/api/v1/analyze/full", strings.NewReader(body)))
+
+	if w.Code != http.StatusRequestEntityTooLarge {
+		t.Fatalf("expected status 413, got %d: %s", w.Code, w.Body.String())
+	}
+	if resp := decodeAnalyzeResponse(t, w); resp.Error == nil || resp.Error.Code != "FILE_TOO_LARGE" {
+		t.Errorf("expected FILE_TOO_LARGE, got %+v", resp.Error)
+	}
+}
+
+func TestAnalyzeFull_PartialFailure(t *testing.T) {
+	h := buildTestHandler()
+	// A nil engine panics, standing in for a broken learning plan stage.
+	h.SetRecommendationEngine(nil)
+	body := `{"profile":{"skills":[{"name":"Go"}]},"job":` + analyzeJob + `}`
+	w := httptest.NewRecorder()
+
+	h.AnalyzeFull(w, httptest.NewRequest(http.MethodPost, "/api/v1/analyze/full", strings.NewReader(body)))
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	d := decodeAnalyzeResponse(t, w).Data
+	if d.Score == nil || d.GapAnalysis == nil {
+		t.Errorf("expected the completed stages to be returned, got %+v", d)
+	}
+	if d.LearningPlan != nil || len(d.Warnings) != 1 || d.Warnings[0].Stage != StageLearningPlan {
+		t.Errorf("expected a single learning_plan warning, got plan=%v warnings=%+v", d.LearningPlan, d.Warnings)
+	}
+}
+
+func TestAnalyzeFull_Deadline(t *testing.T) {
+	h := buildTestHandler()
+	h.SetAnalyzeTimeout(-time.Second)
+
+	body := `{"profile":{"skills":[{"name":"Go"}]},"job":` + analyzeJob + `}`
+	w := httptest.NewRecorder()
+	h.AnalyzeFull(w, httptest.NewRequest(http.MethodPost, "/api/v1/analyze/full", strings.NewReader(body)))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if d := decodeAnalyzeResponse(t, w).Data; len(d.Warnings) != 3 || d.Score != nil {
+		t.Errorf("expected every stage to be skipped, got %+v", d)
+	}
+
+	// Without a parsed resume there is nothing to return.
+	w = httptest.NewRecorder()
+	h.AnalyzeFull(w, createAnalyzeRequest(t, "Jane Doe\n\nSKILLS\nGo", map[string]string{"job": analyzeJob}))
+	if w.Code != http.StatusGatewayTimeout {
+		t.Errorf("expected status 504, got %d: %s", w.Code, w.Body.String())
+	}
+}
+
+func TestCandidateProfileFromResume(t *testing.T) {
+	now := time.Date(2025, 6, 15, 0, 0, 0, 0, time.UTC)
+	resume := &schema.ParsedResume{
+		PersonalInfo: schema.PersonalInfo{Location: "Jakarta, Indonesia"},
+		Skills:       []schema.Skill{{Name: "Go", Proficiency: "advanced", ProficiencyInferred: true}, {Name: "go"}, {Name: "SQL"}},
+		WorkExperience: []schema.WorkExperience{
+			{Title: "Backend Engineer", StartDate: "Jan 2023", EndDate: "Present", IsCurrent: true},
+			// Overlaps the current role by six months.
+			{Title: "Software Engineer", StartDate: "07/2020", EndDate: "July 2023"},
+			{Title: "Intern", StartDate: "Summer", EndDate: "2019"},
+		},
+		Education: []schema.Education{
+			{Degree: "B.S.", Field: "Computer Science"},
+			{Degree: "Master of Science"},
+			{Degree: "Sarjana Komputer (S.Kom)"},
+			{Degree: "Bootcamp"},
+		},
+		Certifications: []schema.Certification{
+			{Name: "Certified Kubernetes Administrator", Issuer: "CNCF", Date: "March 2024"},
+			{Name: "PMP"},
+		},
+		Projects: []schema.Project{
+			{Name: "learnbot", Description: "Career coach", Technologies: []string{"Go", "React"}},
+		},
+	}
+
+	p := candidateProfileFromResume(resume, now)
+
+	if len(p.Skills) != 2 || p.Skills[0].Proficiency != "advanced" || p.Skills[1].Proficiency != "" {
+		t.Errorf("expected duplicate skills to be dropped and proficiencies kept, got %+v", p.Skills)
+	}
+	if got := []int{p.WorkHistory[0].DurationMonths, p.WorkHistory[1].DurationMonths, p.WorkHistory[2].DurationMonths}; got[0] != 29 || got[1] != 36 || got[2] != 0 {
+		t.Errorf("DurationMonths = %v, want [29 36 0]", got)
+	}
+	// July 2020 to June 2025, counting the overlap once.
+	if p.YearsOfExperience != 4.9 {
+		t.Errorf("YearsOfExperience = %v, want 4.9", p.YearsOfExperience)
+	}
+	var levels []string
+	for _, e := range p.Education {
+		levels = append(levels, e.DegreeLevel)
+	}
+	if strings.Join(levels, ",") != "bachelor,master,bachelor,other" {
+		t.Errorf("degree levels = %v", levels)
+	}
+	if p.LocationCity != "Jakarta" || p.LocationCountry != "Indonesia" {
+		t.Errorf("location = %q, %q", p.LocationCity, p.LocationCountry)
+	}
+	if len(p.Certifications) != 2 || p.Certifications[0].Issuer != "CNCF" ||
+		p.Certifications[0].Year != 2024 || p.Certifications[1].Year != 0 {
+		t.Errorf("certifications = %+v", p.Certifications)
+	}
+	if len(p.Projects) != 1 || p.Projects[0].Title != "learnbot" || len(p.Projects[0].Technologies) != 2 {
+		t.Errorf("projects = %+v", p.Projects)
+	}
+}
+
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
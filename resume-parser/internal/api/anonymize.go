@@ -0,0 +1,113 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/learnbot/resume-parser/internal/anonymize"
+	"github.com/learnbot/resume-parser/internal/schema"
+)
+
+// AnonymizeRequest is the JSON body of POST /api/v1/resume/anonymize.
+// Multipart requests carry a "resume" file in place of ParsedResume and
+// Redact as a form value holding JSON.
+type AnonymizeRequest struct {
+	// ParsedResume is a resume previously returned by POST /api/v1/parse.
+	ParsedResume *schema.ParsedResume `json:"parsed_resume"`
+
+	// Redact selects what is redacted. Fields it leaves out keep their
+	// defaults, which redact everything except the city and country.
+	Redact *anonymize.Options `json:"redact,omitempty"`
+}
+
+// AnonymizeResult is an anonymized resume and the JSON paths of the fields
+// that were redacted in it.
+type AnonymizeResult struct {
+	ParsedResume *schema.ParsedResume `json:"parsed_resume"`
+	Redacted     []string             `json:"redacted"`
+}
+
+// AnonymizeResponse is the response of POST /api/v1/resume/anonymize.
+type AnonymizeResponse struct {
+	Success bool               `json:"success"`
+	Data    *AnonymizeResult   `json:"data,omitempty"`
+	Error   *schema.ParseError `json:"error,omitempty"`
+}
+
+// AnonymizeResume handles POST /api/v1/resume/anonymize, returning a copy
+// of a resume with its personal information redacted for bias-free review.
+//
+// The request is either a JSON AnonymizeRequest carrying a pre-parsed
+// resume, or multipart/form-data with a "resume" file, which is parsed
+// first, and an optional "redact" field holding JSON. Skills, job titles,
+// employment dates and degrees are kept, so the anonymized resume scores
+// the same as the original.
+//
+// Example:
+//
+//	curl -X POST http://localhost:8080/api/v1/resume/anonymize \
+//	  -F "resume=@/path/to/resume.pdf" \
+//	  -F 'redact={"location":true}'
+func (h *Handler) AnonymizeResume(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		h.writeError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED",
+			"only POST is supported", "")
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, h.maxUploadSize)
+
+	opts := anonymize.DefaultOptions()
+	var resume *schema.ParsedResume
+	if strings.HasPrefix(r.Header.Get("Content-Type"), "multipart/form-data") {
+		upload, perr := readUpload(r)
+		if perr != nil {
+			h.writeError(w, parseErrorToHTTPStatus(perr.Code), perr.Code, perr.Message, perr.Section)
+			return
+		}
+		if err := decodeFormJSON(r, "redact", &opts, false); err != nil {
+			h.writeError(w, http.StatusBadRequest, "INVALID_REQUEST", err.Error(), "")
+			return
+		}
+		if resume, perr = h.parse(upload); perr != nil {
+			h.writeError(w, parseErrorToHTTPStatus(perr.Code), perr.Code, perr.Message, perr.Section)
+			return
+		}
+	} else {
+		// Options absent from the body keep their defaults.
+		req := AnonymizeRequest{Redact: &opts}
+		dec := json.NewDecoder(r.Body)
+		dec.DisallowUnknownFields()
+		if err := dec.Decode(&req); err != nil {
+			if perr := tooLargeError(err); perr != nil {
+				h.writeError(w, parseErrorToHTTPStatus(perr.Code), perr.Code, perr.Message, "")
+				return
+			}
+			h.writeError(w, http.StatusBadRequest, "INVALID_REQUEST",
+				"invalid request body: "+err.Error(), "")
+			return
+		}
+		if req.ParsedResume == nil {
+			h.writeError(w, http.StatusBadRequest, "INVALID_REQUEST", "parsed_resume is required", "")
+			return
+		}
+		resume = req.ParsedResume
+		if req.Redact != nil {
+			opts = *req.Redact
+		}
+	}
+	if err := opts.Validate(); err != nil {
+		h.writeError(w, http.StatusBadRequest, "INVALID_REQUEST", err.Error(), "")
+		return
+	}
+
+	anonymized, redacted := anonymize.Resume(resume, opts)
+	if redacted == nil {
+		redacted = []string{}
+	}
+	h.writeJSON(w, http.StatusOK, AnonymizeResponse{
+		Success: true,
+		Data:    &AnonymizeResult{ParsedResume: anonymized, Redacted: redacted},
+	})
+}
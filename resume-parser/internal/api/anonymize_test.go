@@ -0,0 +1,167 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/learnbot/resume-parser/internal/schema"
+	"github.com/learnbot/resume-parser/internal/scorer"
+)
+
+// anonymizeResume is a parsed resume with contact information in both its
+// personal info and its free text.
+func anonymizeResume() *schema.ParsedResume {
+	return &schema.ParsedResume{
+		PersonalInfo: schema.PersonalInfo{
+			Name:     "Jane Doe",
+			Email:    "jane.doe@example.com",
+			Phone:    "(555) 123-4567",
+			Location: "Jakarta, Indonesia",
+			GitHub:   "https://github.com/janedoe",
+		},
+		Summary: "Jane Doe, backend engineer. Call +62 812-3456-7890 or mail jane.doe@example.com.",
+		WorkExperience: []schema.WorkExperience{
+			{Company: "Acme", Title: "Senior Backend Engineer", StartDate: "Jan 2019", EndDate: "Present", IsCurrent: true},
+			{Company: "Initech", Title: "Software Engineer", StartDate: "07/2016", EndDate: "Dec 2018"},
+		},
+		Education: []schema.Education{
+			{Institution: "Universitas Indonesia", Degree: "Bachelor of Science", Field: "Computer Science", EndDate: "2016"},
+		},
+		Skills:         []schema.Skill{{Name: "Go"}, {Name: "Docker"}, {Name: "PostgreSQL"}},
+		Certifications: []schema.Certification{{Name: "Certified Kubernetes Administrator", Issuer: "CNCF", Date: "March 2024"}},
+		Projects: []schema.Project{
+			{Name: "queue", Description: "See https://github.com/janedoe/queue", Technologies: []string{"Go", "Redis"}},
+		},
+	}
+}
+
+func postAnonymize(t *testing.T, body string) *httptest.ResponseRecorder {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/resume/anonymize", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	buildTestHandler().AnonymizeResume(w, req)
+	return w
+}
+
+func decodeAnonymizeResponse(t *testing.T, w *httptest.ResponseRecorder) AnonymizeResponse {
+	t.Helper()
+	var resp AnonymizeResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	return resp
+}
+
+func TestAnonymizeResume_ProfileJSON(t *testing.T) {
+	original := anonymizeResume()
+	body, _ := json.Marshal(AnonymizeRequest{ParsedResume: original})
+	w := postAnonymize(t, string(body))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	text := w.Body.String()
+	emailRe := regexp.MustCompile(`(?i)[a-z0-9._%+\-]+@[a-z0-9.\-]+\.[a-z]{2,}`)
+	phoneRe := regexp.MustCompile(`(?:\+?1[\s\-.]?)?\(?\d{3}\)?[\s\-.]?\d{3}[\s\-.]?\d{4}`)
+	if m := emailRe.FindString(text); m != "" {
+		t.Errorf("email %q survived: %s", m, text)
+	}
+	if m := phoneRe.FindString(text); m != "" {
+		t.Errorf("phone %q survived: %s", m, text)
+	}
+	if strings.Contains(text, "Jane") || strings.Contains(text, "janedoe") {
+		t.Errorf("name or handle survived: %s", text)
+	}
+
+	resp := decodeAnonymizeResponse(t, w)
+	if !resp.Success || resp.Data == nil || !containsString(resp.Data.Redacted, "personal_info.email") {
+		t.Fatalf("unexpected response %+v", resp)
+	}
+
+	// Scoring the anonymized resume gives the same result as the original.
+	now := time.Date(2025, 6, 15, 0, 0, 0, 0, time.UTC)
+	job := scorer.JobRequirements{
+		Title:               "Backend Engineer",
+		RequiredSkills:      []string{"Go", "Docker"},
+		PreferredSkills:     []string{"Kubernetes"},
+		MinYearsExperience:  5,
+		RequiredDegreeLevel: "bachelor",
+		PreferredFields:     []string{"Computer Science"},
+		LocationCity:        "Jakarta",
+		LocationCountry:     "Indonesia",
+		LocationType:        "onsite",
+	}
+	want := scorer.CalculateBatch([]scorer.CandidateProfile{candidateProfileFromResume(original, now)}, job)
+	got := scorer.CalculateBatch([]scorer.CandidateProfile{candidateProfileFromResume(resp.Data.ParsedResume, now)}, job)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("anonymized score = %+v, want %+v", got, want)
+	}
+}
+
+func TestAnonymizeResume_RedactOptions(t *testing.T) {
+	body, _ := json.Marshal(map[string]any{
+		"parsed_resume": anonymizeResume(),
+		"redact":        map[string]any{"name": false, "year_bucket": 10},
+	})
+	w := postAnonymize(t, string(body))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	resp := decodeAnonymizeResponse(t, w)
+	out := resp.Data.ParsedResume
+	if out.PersonalInfo.Name != "Jane Doe" {
+		t.Errorf("name redacted with name=false: %q", out.PersonalInfo.Name)
+	}
+	// Options left out of the request keep their defaults.
+	if out.PersonalInfo.Email == "jane.doe@example.com" || out.Education[0].EndDate != "2010-2019" {
+		t.Errorf("defaults not applied: %+v %q", out.PersonalInfo, out.Education[0].EndDate)
+	}
+}
+
+func TestAnonymizeResume_InvalidInput(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+	}{
+		{"missing resume", `{}`},
+		{"unknown field", `{"parsed_resume":{},"resume":{}}`},
+		{"bad year bucket", `{"parsed_resume":{},"redact":{"year_bucket":-1}}`},
+		{"malformed", `{"parsed_resume":`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := postAnonymize(t, tt.body)
+			if w.Code != http.StatusBadRequest {
+				t.Errorf("expected status 400, got %d: %s", w.Code, w.Body.String())
+			}
+		})
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/resume/anonymize", nil)
+	w := httptest.NewRecorder()
+	buildTestHandler().AnonymizeResume(w, req)
+	if w.Code != http.StatusMethodNotAllowed {
+		t.Errorf("expected status 405, got %d", w.Code)
+	}
+}
+
+func TestAnonymizeResume_Upload(t *testing.T) {
+	req := createAnalyzeRequest(t, "Jane Doe\njane@example.com\n(555) 123-4567\n\nSKILLS\nGo, Python, SQL", nil)
+	req.URL.Path = "/api/v1/resume/anonymize"
+	w := httptest.NewRecorder()
+
+	buildTestHandler().AnonymizeResume(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if text := w.Body.String(); strings.Contains(text, "jane@example.com") || strings.Contains(text, "123-4567") {
+		t.Errorf("contact info survived: %s", text)
+	}
+}
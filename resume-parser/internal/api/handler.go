@@ -3,6 +3,7 @@ package api
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"log"
@@ -10,32 +11,121 @@ import (
 	"strings"
 	"time"
 
+	"github.com/learnbot/resume-parser/internal/gapanalysis"
 	"github.com/learnbot/resume-parser/internal/parser"
+	"github.com/learnbot/resume-parser/internal/quality"
+	"github.com/learnbot/resume-parser/internal/recommendation"
 	"github.com/learnbot/resume-parser/internal/schema"
+	"github.com/learnbot/shared/openapi"
 )
 
 const (
-	maxUploadSize = 10 << 20 // 10 MB
+	// DefaultMaxUploadSize is the default limit on the size of a parse or
+	// analysis request body.
+	DefaultMaxUploadSize = 10 << 20 // 10 MB
+
+	// multipartMemory is how much of a multipart upload is held in memory;
+	// the rest of the file is buffered in a temporary file.
+	multipartMemory = 1 << 20
 )
 
 // Handler holds the HTTP handler dependencies.
 type Handler struct {
-	parser *parser.ResumeParser
-	logger *log.Logger
+	parser        *parser.ResumeParser
+	logger        *log.Logger
+	quality       *quality.Tracker
+	maxUploadSize int64
+
+	// Full analysis stages and deadline.
+	gaps           *gapanalysis.Analyzer
+	recommender    *recommendation.Engine
+	analyzeTimeout time.Duration
 }
 
 // NewHandler creates a new API Handler.
 func NewHandler(p *parser.ResumeParser, logger *log.Logger) *Handler {
 	return &Handler{
-		parser: p,
-		logger: logger,
+		parser:         p,
+		logger:         logger,
+		gaps:           gapanalysis.New(),
+		recommender:    recommendation.New(),
+		analyzeTimeout: DefaultAnalyzeTimeout,
+		maxUploadSize:  DefaultMaxUploadSize,
 	}
 }
 
+// SetMaxUploadSize sets the limit on the size of a parse or analysis
+// request body, in bytes. Larger requests are rejected with 413. Values
+// below 1 restore DefaultMaxUploadSize.
+func (h *Handler) SetMaxUploadSize(n int64) {
+	if n < 1 {
+		n = DefaultMaxUploadSize
+	}
+	h.maxUploadSize = n
+}
+
+// SetQualityTracker enables recording of parse failures and low-confidence
+// parses for the data quality dashboard. A nil tracker disables recording.
+func (h *Handler) SetQualityTracker(t *quality.Tracker) {
+	h.quality = t
+}
+
 // RegisterRoutes registers all API routes on the given mux.
-func (h *Handler) RegisterRoutes(mux *http.ServeMux) {
+func (h *Handler) RegisterRoutes(mux openapi.Router) {
 	mux.HandleFunc("/api/v1/parse", h.withMiddleware(h.ParseResume))
 	mux.HandleFunc("/api/v1/health", h.withMiddleware(h.HealthCheck))
+	mux.HandleFunc("/api/v1/analyze/full", h.withMiddleware(h.AnalyzeFull))
+	mux.HandleFunc("/api/v1/resume/anonymize", h.withMiddleware(h.AnonymizeResume))
+	mux.HandleFunc("/api/v1/profile/merge", h.withMiddleware(h.MergeProfile))
+}
+
+// DescribeRoutes documents the routes registered by RegisterRoutes.
+func (h *Handler) DescribeRoutes(spec *openapi.Spec) {
+	spec.Route("/api/v1/parse", openapi.Operation{
+		Method:      http.MethodPost,
+		Summary:     "Parse a PDF or DOCX resume",
+		Params:      []openapi.Param{openapi.Query("include_raw", "Include the extracted raw text (true/false)")},
+		Request:     openapi.Fields{"resume": openapi.File{}, "enrich": false},
+		RequestType: "multipart/form-data",
+		Response:    schema.ParseResponse{},
+		Errors: []int{http.StatusBadRequest, http.StatusRequestEntityTooLarge,
+			http.StatusUnsupportedMediaType, http.StatusUnprocessableEntity},
+	})
+	spec.Route("/api/v1/health", openapi.Operation{
+		Method:   http.MethodGet,
+		Summary:  "Health check",
+		Response: openapi.Fields{"status": "", "version": "", "time": ""},
+	})
+	spec.Route("/api/v1/analyze/full", openapi.Operation{
+		Method:  http.MethodPost,
+		Summary: "Parse, score, analyze gaps and plan learning in one request",
+		Description: "Accepts an AnalyzeRequest JSON body, or multipart/form-data with a resume file " +
+			"and job and preferences fields holding JSON.",
+		Request:  AnalyzeRequest{},
+		Response: AnalyzeResponse{},
+		Errors:   []int{http.StatusBadRequest, http.StatusRequestEntityTooLarge, http.StatusGatewayTimeout},
+	})
+	spec.Route("/api/v1/resume/anonymize", openapi.Operation{
+		Method:  http.MethodPost,
+		Summary: "Redact personal information from a resume for bias-free review",
+		Description: "Accepts an AnonymizeRequest JSON body, or multipart/form-data with a resume file and a redact " +
+			"field holding JSON. Skills, job titles, employment dates and degrees are kept.",
+		Request:  AnonymizeRequest{},
+		Response: AnonymizeResponse{},
+		Errors: []int{http.StatusBadRequest, http.StatusRequestEntityTooLarge,
+			http.StatusUnsupportedMediaType, http.StatusUnprocessableEntity},
+	})
+	spec.Route("/api/v1/profile/merge", openapi.Operation{
+		Method:  http.MethodPost,
+		Summary: "Merge a re-uploaded resume into a stored profile, keeping the user's edits",
+		Description: "Accepts a MergeProfileRequest JSON body, or multipart/form-data with a resume file and a profile " +
+			"field holding JSON. Parsed skills and roles the new resume no longer lists are flagged with " +
+			"needs_confirmation rather than removed.",
+		Request:  MergeProfileRequest{},
+		Response: MergeProfileResponse{},
+		Errors: []int{http.StatusBadRequest, http.StatusRequestEntityTooLarge,
+			http.StatusUnsupportedMediaType, http.StatusUnprocessableEntity},
+	})
 }
 
 // withMiddleware wraps a handler with logging and recovery middleware.
@@ -57,7 +147,9 @@ func (h *Handler) withMiddleware(next http.HandlerFunc) http.HandlerFunc {
 
 // ParseResume handles POST /api/v1/parse
 // Accepts multipart/form-data with a "resume" file field.
-// Optional query param: include_raw=true to include raw text in response.
+// Optional query params:
+//   - include_raw=true to include raw text in response.
+//   - enrich=true to map each skill to its taxonomy node.
 //
 // Example:
 //
@@ -71,64 +163,97 @@ func (h *Handler) ParseResume(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Limit request body size
-	r.Body = http.MaxBytesReader(w, r.Body, maxUploadSize)
+	r.Body = http.MaxBytesReader(w, r.Body, h.maxUploadSize)
+
+	req, perr := readUpload(r)
+	if perr != nil {
+		h.writeError(w, parseErrorToHTTPStatus(perr.Code), perr.Code, perr.Message, perr.Section)
+		return
+	}
+	req.IncludeRaw = strings.ToLower(r.FormValue("include_raw")) == "true"
 
-	if err := r.ParseMultipartForm(maxUploadSize); err != nil {
-		h.writeError(w, http.StatusBadRequest, "INVALID_REQUEST",
-			fmt.Sprintf("failed to parse multipart form: %v", err), "")
+	parsed, perr := h.parse(req)
+	if perr != nil {
+		h.writeError(w, parseErrorToHTTPStatus(perr.Code), perr.Code, perr.Message, perr.Section)
 		return
 	}
 
+	resp := schema.ParseResponse{
+		Success: true,
+		Data:    parsed,
+	}
+
+	h.writeJSON(w, http.StatusOK, resp)
+}
+
+// readUpload reads the "resume" file field of a multipart request into a
+// ParseRequest. The enrich form value is honoured; the caller sets any other
+// options. The request body must already be limited with
+// http.MaxBytesReader; a body over the limit is reported as FILE_TOO_LARGE.
+func readUpload(r *http.Request) (schema.ParseRequest, *schema.ParseError) {
+	if err := r.ParseMultipartForm(multipartMemory); err != nil {
+		if perr := tooLargeError(err); perr != nil {
+			return schema.ParseRequest{}, perr
+		}
+		return schema.ParseRequest{}, &schema.ParseError{Code: "INVALID_REQUEST",
+			Message: fmt.Sprintf("failed to parse multipart form: %v", err)}
+	}
+
 	file, header, err := r.FormFile("resume")
 	if err != nil {
-		h.writeError(w, http.StatusBadRequest, "MISSING_FILE",
-			"'resume' file field is required", "")
-		return
+		return schema.ParseRequest{}, &schema.ParseError{Code: "MISSING_FILE",
+			Message: "'resume' file field is required"}
 	}
 	defer file.Close()
 
-	// Read file content
-	data, err := io.ReadAll(file)
-	if err != nil {
-		h.writeError(w, http.StatusInternalServerError, "READ_ERROR",
-			"failed to read uploaded file", "")
-		return
+	// Read the file into a single buffer of its size.
+	data := make([]byte, header.Size)
+	if _, err := io.ReadFull(file, data); err != nil {
+		return schema.ParseRequest{}, &schema.ParseError{Code: "READ_ERROR",
+			Message: "failed to read uploaded file"}
 	}
 
-	// Determine file type
-	fileType := detectFileType(header.Filename, header.Header.Get("Content-Type"))
+	// Determine file type, trusting the content over the name. A MIME type
+	// naming PDF or DOCX must match the content, though.
+	fileType := parser.DetectFileType(data)
+	declared := contentTypeFileType(header.Header.Get("Content-Type"))
+	if declared != "" && fileType != declared {
+		return schema.ParseRequest{}, &schema.ParseError{Code: "CONTENT_TYPE_MISMATCH",
+			Message: fmt.Sprintf("file was uploaded as %s, but its content is not a %s document",
+				header.Header.Get("Content-Type"), strings.ToUpper(declared))}
+	}
 	if fileType == "" {
-		h.writeError(w, http.StatusBadRequest, "UNSUPPORTED_FORMAT",
-			"unsupported file type; only PDF and DOCX are supported", "")
-		return
+		fileType = detectFileType(header.Filename, header.Header.Get("Content-Type"))
 	}
-
-	includeRaw := strings.ToLower(r.FormValue("include_raw")) == "true"
-
-	req := schema.ParseRequest{
-		FileName:    header.Filename,
-		FileContent: data,
-		FileType:    fileType,
-		IncludeRaw:  includeRaw,
+	if fileType == "" {
+		return schema.ParseRequest{}, &schema.ParseError{Code: "UNSUPPORTED_FORMAT",
+			Message: "unsupported file type; only PDF and DOCX are supported"}
 	}
 
+	return schema.ParseRequest{
+		FileName:     header.Filename,
+		FileContent:  data,
+		FileType:     fileType,
+		EnrichSkills: strings.ToLower(r.FormValue("enrich")) == "true",
+	}, nil
+}
+
+// parse runs the parser and records failures and low-confidence results
+// with the quality tracker.
+func (h *Handler) parse(req schema.ParseRequest) (*schema.ParsedResume, *schema.ParseError) {
 	parsed, err := h.parser.Parse(req)
 	if err != nil {
+		h.quality.Record(quality.CheckParseFailures, req.FileName)
 		if pe, ok := err.(*schema.ParseError); ok {
-			statusCode := parseErrorToHTTPStatus(pe.Code)
-			h.writeError(w, statusCode, pe.Code, pe.Message, pe.Section)
-			return
+			return nil, pe
 		}
-		h.writeError(w, http.StatusInternalServerError, "PARSE_ERROR", err.Error(), "")
-		return
+		return nil, &schema.ParseError{Code: "PARSE_ERROR", Message: err.Error()}
 	}
 
-	resp := schema.ParseResponse{
-		Success: true,
-		Data:    parsed,
+	if parsed.OverallConfidence < schema.ConfidenceLow {
+		h.quality.Record(quality.CheckLowConfidenceParses, req.FileName)
 	}
-
-	h.writeJSON(w, http.StatusOK, resp)
+	return parsed, nil
 }
 
 // HealthCheck handles GET /api/v1/health
@@ -171,7 +296,12 @@ func detectFileType(filename, contentType string) string {
 	if strings.HasSuffix(lower, ".docx") {
 		return "docx"
 	}
+	return contentTypeFileType(contentType)
+}
 
+// contentTypeFileType returns the file type a MIME type declares, or "" if
+// it declares neither PDF nor DOCX (e.g. application/octet-stream).
+func contentTypeFileType(contentType string) string {
 	ct := strings.ToLower(contentType)
 	if strings.Contains(ct, "pdf") {
 		return "pdf"
@@ -179,17 +309,31 @@ func detectFileType(filename, contentType string) string {
 	if strings.Contains(ct, "wordprocessingml") || strings.Contains(ct, "docx") {
 		return "docx"
 	}
-
 	return ""
 }
 
+// tooLargeError returns a FILE_TOO_LARGE error if err comes from a request
+// body over its http.MaxBytesReader limit, or nil otherwise.
+func tooLargeError(err error) *schema.ParseError {
+	var tooLarge *http.MaxBytesError
+	if !errors.As(err, &tooLarge) {
+		return nil
+	}
+	return &schema.ParseError{Code: "FILE_TOO_LARGE",
+		Message: fmt.Sprintf("request body exceeds the %d byte limit", tooLarge.Limit)}
+}
+
 // parseErrorToHTTPStatus maps error codes to HTTP status codes.
 func parseErrorToHTTPStatus(code string) int {
 	switch code {
 	case "EMPTY_FILE", "INVALID_FORMAT", "UNSUPPORTED_FORMAT",
 		"MISSING_FILE", "INVALID_REQUEST":
 		return http.StatusBadRequest
-	case "NO_TEXT_CONTENT":
+	case "FILE_TOO_LARGE":
+		return http.StatusRequestEntityTooLarge
+	case "CONTENT_TYPE_MISMATCH":
+		return http.StatusUnsupportedMediaType
+	case "NO_TEXT_CONTENT", "TEXT_TOO_LONG":
 		return http.StatusUnprocessableEntity
 	default:
 		return http.StatusInternalServerError
@@ -10,11 +10,13 @@ import (
 	"mime/multipart"
 	"net/http"
 	"net/http/httptest"
+	"net/textproto"
 	"os"
 	"testing"
 
 	"github.com/learnbot/resume-parser/internal/parser"
 	"github.com/learnbot/resume-parser/internal/schema"
+	"github.com/learnbot/shared/openapi"
 )
 
 // buildTestHandler creates a Handler for testing.
@@ -175,6 +177,65 @@ func TestParseResume_UnsupportedFormat(t *testing.T) {
 	}
 }
 
+// decodeErrorCode decodes an error response and returns its error code.
+func decodeErrorCode(t *testing.T, w *httptest.ResponseRecorder) string {
+	t.Helper()
+	var resp schema.ParseResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if resp.Success || resp.Error == nil {
+		t.Fatalf("expected an error response, got %+v", resp)
+	}
+	return resp.Error.Code
+}
+
+// TestParseResume_FileTooLarge tests that a body over the upload limit
+// returns 413.
+func TestParseResume_FileTooLarge(t *testing.T) {
+	h := buildTestHandler()
+	h.SetMaxUploadSize(4 << 10)
+	req := createMultipartRequest(t, "resume.docx", bytes.Repeat([]byte("x"), 16<<10))
+	w := httptest.NewRecorder()
+
+	h.ParseResume(w, req)
+
+	if w.Code != http.StatusRequestEntityTooLarge {
+		t.Errorf("expected status 413, got %d", w.Code)
+	}
+	if code := decodeErrorCode(t, w); code != "FILE_TOO_LARGE" {
+		t.Errorf("expected FILE_TOO_LARGE, got %s", code)
+	}
+}
+
+// TestParseResume_ContentTypeMismatch tests that a file whose MIME type
+// names a format its content does not have returns 415.
+func TestParseResume_ContentTypeMismatch(t *testing.T) {
+	h := buildTestHandler()
+
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	header := make(textproto.MIMEHeader)
+	header.Set("Content-Disposition", `form-data; name="resume"; filename="resume.pdf"`)
+	header.Set("Content-Type", "application/pdf")
+	part, _ := writer.CreatePart(header)
+	part.Write(buildMinimalDOCX("Jane Doe\njane@example.com"))
+	writer.Close()
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/parse", &body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	w := httptest.NewRecorder()
+
+	h.ParseResume(w, req)
+
+	if w.Code != http.StatusUnsupportedMediaType {
+		t.Errorf("expected status 415, got %d", w.Code)
+	}
+	if code := decodeErrorCode(t, w); code != "CONTENT_TYPE_MISMATCH" {
+		t.Errorf("expected CONTENT_TYPE_MISMATCH, got %s", code)
+	}
+}
+
 // TestParseResume_ValidDOCX tests successful DOCX parsing.
 func TestParseResume_ValidDOCX(t *testing.T) {
 	h := buildTestHandler()
@@ -244,6 +305,35 @@ Go, Python`
 	}
 }
 
+// TestParseResume_Enrich tests the enrich query parameter.
+func TestParseResume_Enrich(t *testing.T) {
+	h := buildTestHandler()
+	docxData := buildMinimalDOCX("Jane Doe\njane@example.com\n\nSKILLS\nGolang, Python")
+
+	req := createMultipartRequest(t, "resume.docx", docxData)
+	req.URL.RawQuery = "enrich=true"
+	w := httptest.NewRecorder()
+
+	h.ParseResume(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var resp schema.ParseResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil || resp.Data == nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	for _, s := range resp.Data.Skills {
+		if s.Name == "Golang" {
+			if s.TaxonomyNode == nil || s.TaxonomyNode.ID != "go" {
+				t.Errorf("expected Golang to resolve to the go node, got %+v", s.TaxonomyNode)
+			}
+			return
+		}
+	}
+	t.Errorf("expected Golang to be extracted, got %+v", resp.Data.Skills)
+}
+
 // TestDetectFileType tests file type detection.
 func TestDetectFileType(t *testing.T) {
 	tests := []struct {
@@ -281,6 +371,9 @@ func TestParseErrorToHTTPStatus(t *testing.T) {
 		{"INVALID_FORMAT", http.StatusBadRequest},
 		{"UNSUPPORTED_FORMAT", http.StatusBadRequest},
 		{"NO_TEXT_CONTENT", http.StatusUnprocessableEntity},
+		{"FILE_TOO_LARGE", http.StatusRequestEntityTooLarge},
+		{"CONTENT_TYPE_MISMATCH", http.StatusUnsupportedMediaType},
+		{"TEXT_TOO_LONG", http.StatusUnprocessableEntity},
 		{"UNKNOWN_ERROR", http.StatusInternalServerError},
 	}
 
@@ -309,3 +402,10 @@ func TestRegisterRoutes(t *testing.T) {
 		t.Errorf("expected health endpoint to return 200, got %d", w.Code)
 	}
 }
+
+func TestDescribeRoutes(t *testing.T) {
+	h := buildTestHandler()
+	if err := openapi.CheckRoutes(h.RegisterRoutes, h.DescribeRoutes); err != nil {
+		t.Error(err)
+	}
+}
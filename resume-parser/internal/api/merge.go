@@ -0,0 +1,100 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/learnbot/resume-parser/internal/profilemerge"
+	"github.com/learnbot/resume-parser/internal/schema"
+	"github.com/learnbot/resume-parser/internal/scorer"
+)
+
+// MergeProfileRequest is the JSON body of POST /api/v1/profile/merge.
+// Multipart requests carry a "resume" file in place of ParsedResume and
+// Profile as a form value holding JSON.
+type MergeProfileRequest struct {
+	// Profile is the stored profile, with the provenance of its values.
+	// Omit it for a first upload.
+	Profile scorer.CandidateProfile `json:"profile"`
+
+	// ParsedResume is the new resume, as returned by POST /api/v1/parse.
+	ParsedResume *schema.ParsedResume `json:"parsed_resume"`
+}
+
+// MergeProfileResult is a merged profile and what the merge changed.
+type MergeProfileResult struct {
+	Profile scorer.CandidateProfile `json:"profile"`
+	Changes profilemerge.Changes    `json:"changes"`
+}
+
+// MergeProfileResponse is the response of POST /api/v1/profile/merge.
+type MergeProfileResponse struct {
+	Success bool                `json:"success"`
+	Data    *MergeProfileResult `json:"data,omitempty"`
+	Error   *schema.ParseError  `json:"error,omitempty"`
+}
+
+// MergeProfile handles POST /api/v1/profile/merge, merging a re-uploaded
+// resume into a stored profile without losing the user's edits (see
+// profilemerge.Merge). Parsed skills and roles the new resume no longer
+// lists come back with needs_confirmation set rather than being dropped.
+//
+// The request is either a JSON MergeProfileRequest carrying a pre-parsed
+// resume, or multipart/form-data with a "resume" file, which is parsed
+// first, and a "profile" field holding JSON.
+//
+// Example:
+//
+//	curl -X POST http://localhost:8080/api/v1/profile/merge \
+//	  -F "resume=@/path/to/resume.pdf" \
+//	  -F 'profile={"skills":[{"name":"Go","proficiency":"expert","source":"user"}]}'
+func (h *Handler) MergeProfile(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		h.writeError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED",
+			"only POST is supported", "")
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, h.maxUploadSize)
+
+	var req MergeProfileRequest
+	if strings.HasPrefix(r.Header.Get("Content-Type"), "multipart/form-data") {
+		upload, perr := readUpload(r)
+		if perr != nil {
+			h.writeError(w, parseErrorToHTTPStatus(perr.Code), perr.Code, perr.Message, perr.Section)
+			return
+		}
+		if err := decodeFormJSON(r, "profile", &req.Profile, false); err != nil {
+			h.writeError(w, http.StatusBadRequest, "INVALID_REQUEST", err.Error(), "")
+			return
+		}
+		if req.ParsedResume, perr = h.parse(upload); perr != nil {
+			h.writeError(w, parseErrorToHTTPStatus(perr.Code), perr.Code, perr.Message, perr.Section)
+			return
+		}
+	} else {
+		dec := json.NewDecoder(r.Body)
+		dec.DisallowUnknownFields()
+		if err := dec.Decode(&req); err != nil {
+			if perr := tooLargeError(err); perr != nil {
+				h.writeError(w, parseErrorToHTTPStatus(perr.Code), perr.Code, perr.Message, "")
+				return
+			}
+			h.writeError(w, http.StatusBadRequest, "INVALID_REQUEST",
+				"invalid request body: "+err.Error(), "")
+			return
+		}
+		if req.ParsedResume == nil {
+			h.writeError(w, http.StatusBadRequest, "INVALID_REQUEST", "parsed_resume is required", "")
+			return
+		}
+	}
+
+	merged, changes := profilemerge.Merge(req.Profile, candidateProfileFromResume(req.ParsedResume, time.Now()))
+	h.writeJSON(w, http.StatusOK, MergeProfileResponse{
+		Success: true,
+		Data:    &MergeProfileResult{Profile: merged, Changes: changes},
+	})
+}
@@ -0,0 +1,93 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/learnbot/resume-parser/internal/schema"
+	"github.com/learnbot/resume-parser/internal/scorer"
+)
+
+func postMerge(t *testing.T, body string) (*httptest.ResponseRecorder, MergeProfileResponse) {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/profile/merge", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	buildTestHandler().MergeProfile(w, req)
+	var resp MergeProfileResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	return w, resp
+}
+
+func TestMergeProfile(t *testing.T) {
+	body, err := json.Marshal(MergeProfileRequest{
+		Profile: scorer.CandidateProfile{
+			Skills: []scorer.CandidateSkill{
+				{Name: "Go", Proficiency: "expert", Source: scorer.ProvenanceUser},
+				{Name: "Perl", Source: scorer.ProvenanceParsed},
+			},
+			WorkHistory: []scorer.WorkHistoryEntry{
+				{Title: "Software Engineer", Company: "Initech", StartDate: "07/2016", EndDate: "Dec 2018",
+					Source: scorer.ProvenanceUser},
+			},
+			RemotePreference: "remote",
+		},
+		ParsedResume: &schema.ParsedResume{
+			Skills: []schema.Skill{{Name: "go", Proficiency: "beginner", ProficiencyInferred: true}, {Name: "Docker"}},
+			WorkExperience: []schema.WorkExperience{
+				{Company: "Acme", Title: "Senior Backend Engineer", StartDate: "Jan 2019", EndDate: "Present", IsCurrent: true},
+				{Company: "Initech Inc.", Title: "Sofware Engineer", StartDate: "Jul 2016", EndDate: "Dec 2018"},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	w, resp := postMerge(t, string(body))
+	if w.Code != http.StatusOK || !resp.Success {
+		t.Fatalf("expected 200, got %d: %+v", w.Code, resp.Error)
+	}
+
+	p, c := resp.Data.Profile, resp.Data.Changes
+	if len(p.Skills) != 3 || p.Skills[1].Name != "Go" || p.Skills[1].Proficiency != "expert" || !p.Skills[2].NeedsConfirmation {
+		t.Errorf("skills = %+v", p.Skills)
+	}
+	if len(p.WorkHistory) != 2 || p.WorkHistory[0].Company != "Acme" || p.WorkHistory[0].Source != scorer.ProvenanceParsed ||
+		p.WorkHistory[1].Title != "Software Engineer" {
+		t.Errorf("work history = %+v", p.WorkHistory)
+	}
+	if p.RemotePreference != "remote" {
+		t.Errorf("remote preference = %q, want the stored one", p.RemotePreference)
+	}
+	if strings.Join(c.AddedSkills, ",") != "Docker" || strings.Join(c.UnconfirmedSkills, ",") != "Perl" ||
+		strings.Join(c.AddedWorkHistory, ",") != "Senior Backend Engineer at Acme" {
+		t.Errorf("changes = %+v", c)
+	}
+}
+
+func TestMergeProfile_InvalidRequests(t *testing.T) {
+	tests := []struct {
+		name, body string
+	}{
+		{"missing resume", `{"profile":{"skills":[]}}`},
+		{"unknown field", `{"parsed_resume":{},"resume":{}}`},
+		{"malformed", `{`},
+	}
+	for _, tt := range tests {
+		if w, resp := postMerge(t, tt.body); w.Code != http.StatusBadRequest || resp.Error == nil || resp.Error.Code != "INVALID_REQUEST" {
+			t.Errorf("%s: expected 400 INVALID_REQUEST, got %d %+v", tt.name, w.Code, resp.Error)
+		}
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/profile/merge", nil)
+	w := httptest.NewRecorder()
+	buildTestHandler().MergeProfile(w, req)
+	if w.Code != http.StatusMethodNotAllowed {
+		t.Errorf("GET: expected 405, got %d", w.Code)
+	}
+}
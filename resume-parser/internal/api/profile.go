@@ -0,0 +1,135 @@
+package api
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/learnbot/resume-parser/internal/extractor"
+	"github.com/learnbot/resume-parser/internal/schema"
+	"github.com/learnbot/resume-parser/internal/scorer"
+)
+
+// candidateProfileFromResume builds the scoring profile for a parsed resume.
+// Durations of current roles run until now. The conversion is best effort:
+// dates and degrees it cannot interpret are left at their zero values.
+func candidateProfileFromResume(resume *schema.ParsedResume, now time.Time) scorer.CandidateProfile {
+	profile := scorer.CandidateProfile{
+		Skills:      []scorer.CandidateSkill{},
+		WorkHistory: []scorer.WorkHistoryEntry{},
+		Education:   []scorer.EducationEntry{},
+	}
+
+	seen := make(map[string]bool, len(resume.Skills))
+	for _, s := range resume.Skills {
+		key := strings.ToLower(strings.TrimSpace(s.Name))
+		if key == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		profile.Skills = append(profile.Skills, scorer.CandidateSkill{Name: s.Name, Proficiency: s.Proficiency})
+	}
+
+	var spans []extractor.MonthSpan
+	for _, exp := range resume.WorkExperience {
+		entry := scorer.WorkHistoryEntry{
+			Title:     exp.Title,
+			IsCurrent: exp.IsCurrent,
+			Company:   exp.Company,
+			StartDate: exp.StartDate,
+			EndDate:   exp.EndDate,
+		}
+		if span, ok := extractor.ExperienceSpan(exp, now); ok {
+			entry.DurationMonths = span.Months()
+			spans = append(spans, span)
+		}
+		profile.WorkHistory = append(profile.WorkHistory, entry)
+	}
+	profile.YearsOfExperience = math.Round(float64(mergedMonths(spans))/12*10) / 10
+
+	for _, edu := range resume.Education {
+		profile.Education = append(profile.Education, scorer.EducationEntry{
+			DegreeLevel:  degreeLevel(edu.Degree),
+			FieldOfStudy: edu.Field,
+		})
+	}
+
+	for _, cert := range resume.Certifications {
+		entry := scorer.CertificationEntry{Name: cert.Name, Issuer: cert.Issuer}
+		if t, ok := extractor.ParseResumeDate(cert.Date); ok {
+			entry.Year = t.Year()
+		}
+		profile.Certifications = append(profile.Certifications, entry)
+	}
+
+	for _, p := range resume.Projects {
+		profile.Projects = append(profile.Projects, scorer.ProjectEntry{
+			Title:        p.Name,
+			Description:  p.Description,
+			Technologies: p.Technologies,
+		})
+	}
+
+	if parts := strings.Split(resume.PersonalInfo.Location, ","); strings.TrimSpace(parts[0]) != "" {
+		profile.LocationCity = strings.TrimSpace(parts[0])
+		if len(parts) > 1 {
+			profile.LocationCountry = strings.TrimSpace(parts[len(parts)-1])
+		}
+	}
+	return profile
+}
+
+// mergedMonths returns the number of months covered by spans, counting
+// overlapping jobs once.
+func mergedMonths(spans []extractor.MonthSpan) int {
+	sort.Slice(spans, func(i, j int) bool { return spans[i].Start < spans[j].Start })
+	total, covered := 0, math.MinInt
+	for _, s := range spans {
+		if s.Start < covered {
+			s.Start = covered
+		}
+		if s.End > s.Start {
+			total += s.End - s.Start
+			covered = s.End
+		}
+	}
+	return total
+}
+
+// degreeKeywords maps degree text to scorer degree levels, highest level
+// first. Keywords shorter than five letters must match a whole word; longer
+// ones match a word prefix ("bachelor" matches "bachelors").
+var degreeKeywords = []struct {
+	level    string
+	keywords []string
+}{
+	{"doctorate", []string{"phd", "doctor", "doktor", "dphil"}},
+	{"master", []string{"master", "magister", "mba", "msc", "m sc", "ms", "ma", "meng", "s2"}},
+	{"bachelor", []string{"bachelor", "sarjana", "bsc", "b sc", "bs", "ba", "beng", "btech", "s1"}},
+	{"associate", []string{"associate"}},
+	{"diploma", []string{"diploma", "d3"}},
+	{"certificate", []string{"certificate"}},
+	{"high_school", []string{"high school", "secondary", "sma", "smk"}},
+}
+
+// degreeLevel classifies free-form degree text such as "B.S. Computer
+// Science" or "Sarjana Teknik", returning "other" when no level is
+// recognized.
+func degreeLevel(degree string) string {
+	// Drop periods so that "B.S." reads "bs", and pad with spaces so that
+	// keywords can be matched on word boundaries.
+	text := strings.NewReplacer(".", "", ",", " ", "(", " ", ")", " ").Replace(strings.ToLower(degree))
+	text = " " + strings.Join(strings.Fields(text), " ") + " "
+	for _, d := range degreeKeywords {
+		for _, kw := range d.keywords {
+			if len(kw) < 5 {
+				kw += " "
+			}
+			if strings.Contains(text, " "+kw) {
+				return d.level
+			}
+		}
+	}
+	return "other"
+}
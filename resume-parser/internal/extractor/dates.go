@@ -0,0 +1,66 @@
+package extractor
+
+import (
+	"strings"
+	"time"
+
+	"github.com/learnbot/resume-parser/internal/schema"
+)
+
+// resumeDateLayouts are the date formats ExtractWorkExperience produces:
+// "Jan 2020", "January 2020", "01/2020" and "2020".
+var resumeDateLayouts = []string{"Jan 2006", "January 2006", "1/2006", "01/2006", "2006"}
+
+// MonthSpan is a half-open range of months, counted from year zero.
+type MonthSpan struct {
+	Start, End int
+}
+
+// Months returns the length of the span.
+func (s MonthSpan) Months() int {
+	return s.End - s.Start
+}
+
+// ExperienceSpan returns the months covered by a job. Current jobs run until
+// now and year-only dates count from January. It reports false when a date
+// cannot be parsed or the job ends before it starts.
+func ExperienceSpan(exp schema.WorkExperience, now time.Time) (MonthSpan, bool) {
+	start, ok := ParseResumeDate(exp.StartDate)
+	if !ok {
+		return MonthSpan{}, false
+	}
+	end := now
+	if !exp.IsCurrent {
+		if end, ok = ParseResumeDate(exp.EndDate); !ok {
+			return MonthSpan{}, false
+		}
+	}
+	span := MonthSpan{Start: MonthIndex(start), End: MonthIndex(end)}
+	if span.End < span.Start {
+		return MonthSpan{}, false
+	}
+	return span, true
+}
+
+// MonthIndex returns the month of t, counted from year zero.
+func MonthIndex(t time.Time) int {
+	return t.Year()*12 + int(t.Month()) - 1
+}
+
+// ParseResumeDate parses a start or end date as written in a resume.
+func ParseResumeDate(s string) (time.Time, bool) {
+	s = strings.Join(strings.Fields(strings.ReplaceAll(s, ".", "")), " ")
+	for _, layout := range resumeDateLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, true
+		}
+	}
+	// time.Parse only accepts exact month abbreviations; try the first
+	// three letters for forms like "Sept 2021".
+	if fields := strings.Fields(s); len(fields) == 2 && len(fields[0]) > 3 {
+		if t, err := time.Parse("Jan 2006", fields[0][:3]+" "+fields[1]); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
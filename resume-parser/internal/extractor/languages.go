@@ -0,0 +1,129 @@
+package extractor
+
+import (
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Language is a set of section header keywords for one resume language.
+type Language struct {
+	// Code is the ISO 639-1 language code (e.g. "en", "id").
+	Code string
+
+	// Keywords maps lowercase header text to section types.
+	Keywords map[string]SectionType
+
+	sortOnce sync.Once
+	sorted   []string
+}
+
+// keywordsByLength returns the keywords longest first, so that partial
+// matching prefers the most specific header ("riwayat pendidikan" over
+// "pendidikan") and does not depend on map order.
+func (l *Language) keywordsByLength() []string {
+	l.sortOnce.Do(func() {
+		l.sorted = make([]string, 0, len(l.Keywords))
+		for kw := range l.Keywords {
+			l.sorted = append(l.sorted, kw)
+		}
+		sort.Slice(l.sorted, func(i, j int) bool {
+			if len(l.sorted[i]) != len(l.sorted[j]) {
+				return len(l.sorted[i]) > len(l.sorted[j])
+			}
+			return l.sorted[i] < l.sorted[j]
+		})
+	})
+	return l.sorted
+}
+
+// English is the default header language.
+var English = &Language{Code: "en", Keywords: englishSectionKeywords}
+
+// Indonesian recognizes Bahasa Indonesia section headers.
+var Indonesian = &Language{Code: "id", Keywords: map[string]SectionType{
+	"ringkasan":                 SectionSummary,
+	"ringkasan profesional":     SectionSummary,
+	"profil":                    SectionSummary,
+	"profil singkat":            SectionSummary,
+	"profil pribadi":            SectionSummary,
+	"tentang saya":              SectionSummary,
+	"tujuan karir":              SectionSummary,
+	"tujuan karier":             SectionSummary,
+	"pengalaman":                SectionExperience,
+	"pengalaman kerja":          SectionExperience,
+	"pengalaman profesional":    SectionExperience,
+	"pengalaman pekerjaan":      SectionExperience,
+	"riwayat pekerjaan":         SectionExperience,
+	"riwayat kerja":             SectionExperience,
+	"pendidikan":                SectionEducation,
+	"riwayat pendidikan":        SectionEducation,
+	"latar belakang pendidikan": SectionEducation,
+	"pendidikan formal":         SectionEducation,
+	"keahlian":                  SectionSkills,
+	"keahlian teknis":           SectionSkills,
+	"keterampilan":              SectionSkills,
+	"keterampilan teknis":       SectionSkills,
+	"kemampuan":                 SectionSkills,
+	"kompetensi":                SectionSkills,
+	"sertifikasi":               SectionCertifications,
+	"sertifikat":                SectionCertifications,
+	"lisensi":                   SectionCertifications,
+	"sertifikasi & lisensi":     SectionCertifications,
+	"pelatihan":                 SectionCertifications,
+	"pelatihan & sertifikasi":   SectionCertifications,
+	"proyek":                    SectionProjects,
+	"projek":                    SectionProjects,
+	"portofolio":                SectionProjects,
+	"prestasi":                  SectionProjects,
+	"penghargaan":               SectionProjects,
+	"publikasi":                 SectionProjects,
+}}
+
+var (
+	languagesMu sync.RWMutex
+	languages   = []*Language{English, Indonesian}
+)
+
+// RegisterLanguage adds a header language for detection, replacing any
+// registered language with the same code.
+func RegisterLanguage(l *Language) {
+	languagesMu.Lock()
+	defer languagesMu.Unlock()
+	for i, existing := range languages {
+		if existing.Code == l.Code {
+			languages[i] = l
+			return
+		}
+	}
+	languages = append(languages, l)
+}
+
+// DetectLanguage returns the language whose section headers appear most
+// often in text, counting only lines that are exactly a known header.
+// English wins ties and is returned when no headers are found.
+func DetectLanguage(text string) *Language {
+	languagesMu.RLock()
+	defer languagesMu.RUnlock()
+
+	counts := make(map[*Language]int, len(languages))
+	for _, line := range strings.Split(text, "\n") {
+		clean := strings.ToLower(strings.TrimSpace(strings.Trim(strings.TrimSpace(line), "-=_*#: \t")))
+		if clean == "" || len(clean) > 60 {
+			continue
+		}
+		for _, lang := range languages {
+			if _, ok := lang.Keywords[clean]; ok {
+				counts[lang]++
+			}
+		}
+	}
+
+	best := English
+	for _, lang := range languages {
+		if counts[lang] > counts[best] {
+			best = lang
+		}
+	}
+	return best
+}
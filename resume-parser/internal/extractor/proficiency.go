@@ -0,0 +1,144 @@
+package extractor
+
+import (
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/learnbot/resume-parser/internal/schema"
+)
+
+// Inferred proficiency thresholds, in recency-weighted months of roles whose
+// title or responsibilities mention a skill:
+//
+//	under 12 months   beginner
+//	12 to 35 months   intermediate
+//	36 to 71 months   advanced
+//	72 months or more expert
+const (
+	intermediateMonths = 12
+	advancedMonths     = 36
+	expertMonths       = 72
+)
+
+// recencyWeights weigh the months of a role by how long ago it ended: in
+// full when it is current or ended within two years, by half within five
+// years, and by staleRoleWeight before that.
+var recencyWeights = []struct {
+	maxAgeMonths int
+	weight       float64
+}{
+	{24, 1.0},
+	{60, 0.5},
+}
+
+const staleRoleWeight = 0.25
+
+// InferProficiency sets the proficiency of each skill that has none from the
+// work history. The months of every role mentioning the skill, or one of the
+// names returned by aliases, are summed with recent roles weighted higher and
+// the total is mapped to a level by the thresholds above. Inferred levels are
+// flagged with ProficiencyInferred; skills no role mentions are left
+// unchanged. aliases may be nil.
+func InferProficiency(skills []schema.Skill, work []schema.WorkExperience, aliases func(skill string) []string, now time.Time) {
+	type role struct {
+		text    string
+		span    MonthSpan
+		recency float64
+	}
+	var roles []role
+	for _, exp := range work {
+		span, ok := ExperienceSpan(exp, now)
+		if !ok || span.Months() == 0 {
+			continue
+		}
+		text := strings.ToLower(exp.Title + "\n" + strings.Join(exp.Responsibilities, "\n"))
+		roles = append(roles, role{text: text, span: span, recency: recencyWeight(MonthIndex(now) - span.End)})
+	}
+
+	for i := range skills {
+		s := &skills[i]
+		if s.Proficiency != "" {
+			continue
+		}
+		terms := []string{s.Name}
+		if aliases != nil {
+			terms = append(terms, aliases(s.Name)...)
+		}
+
+		months, weighted := 0, 0.0
+		for _, r := range roles {
+			if mentionsAny(r.text, terms) {
+				months += r.span.Months()
+				weighted += float64(r.span.Months()) * r.recency
+			}
+		}
+		if months == 0 {
+			continue
+		}
+		s.Proficiency = proficiencyLevel(weighted)
+		s.ProficiencyInferred = true
+		s.ExperienceMonths = months
+	}
+}
+
+// recencyWeight returns the weight of a role that ended ageMonths ago.
+func recencyWeight(ageMonths int) float64 {
+	for _, w := range recencyWeights {
+		if ageMonths <= w.maxAgeMonths {
+			return w.weight
+		}
+	}
+	return staleRoleWeight
+}
+
+// proficiencyLevel maps recency-weighted months of experience to a level.
+func proficiencyLevel(months float64) string {
+	switch {
+	case months >= expertMonths:
+		return "expert"
+	case months >= advancedMonths:
+		return "advanced"
+	case months >= intermediateMonths:
+		return "intermediate"
+	default:
+		return "beginner"
+	}
+}
+
+// mentionsAny reports whether the lowercase text contains one of terms as a
+// whole word, ignoring case.
+func mentionsAny(text string, terms []string) bool {
+	for _, term := range terms {
+		if containsWord(text, strings.ToLower(strings.TrimSpace(term))) {
+			return true
+		}
+	}
+	return false
+}
+
+// containsWord reports whether term occurs in text not preceded or followed
+// by a letter or digit, so that "go" matches "Go services" but not "google".
+func containsWord(text, term string) bool {
+	if term == "" {
+		return false
+	}
+	for from := 0; ; {
+		i := strings.Index(text[from:], term)
+		if i < 0 {
+			return false
+		}
+		start, end := from+i, from+i+len(term)
+		before, _ := utf8.DecodeLastRuneInString(text[:start])
+		after, _ := utf8.DecodeRuneInString(text[end:])
+		if !isWordRune(before) && !isWordRune(after) {
+			return true
+		}
+		from = start + 1
+	}
+}
+
+func isWordRune(r rune) bool {
+	return r != utf8.RuneError && (unicode.IsLetter(r) || unicode.IsDigit(r))
+}
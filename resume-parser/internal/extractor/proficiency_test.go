@@ -0,0 +1,101 @@
+package extractor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/learnbot/resume-parser/internal/schema"
+)
+
+func TestInferProficiency(t *testing.T) {
+	now := time.Date(2025, time.June, 15, 0, 0, 0, 0, time.UTC)
+	work := []schema.WorkExperience{
+		{Title: "Senior Backend Engineer", StartDate: "Jan 2023", IsCurrent: true,
+			Responsibilities: []string{"Built payment services in Go", "Ran PostgreSQL on Kubernetes"}},
+		{Title: "Backend Engineer", StartDate: "Jan 2021", EndDate: "Dec 2022",
+			Responsibilities: []string{"Ported Python jobs to Golang"}},
+		{Title: "Go Developer", StartDate: "Jan 2019", EndDate: "Dec 2020",
+			Responsibilities: []string{"Maintained Google Cloud deployments"}},
+		{Title: "Web Developer", StartDate: "Jan 2017", EndDate: "Jun 2019",
+			Responsibilities: []string{"Wrote Perl CGI scripts"}},
+		{Title: "Intern", StartDate: "2010", EndDate: "unknown",
+			Responsibilities: []string{"Rust, Perl"}},
+	}
+	skills := []schema.Skill{
+		{Name: "Go"},
+		{Name: "Perl"},
+		{Name: "Rust"},
+		{Name: "PostgreSQL", Proficiency: "expert"},
+		{Name: "Python"},
+	}
+	aliases := func(skill string) []string {
+		if skill == "Go" {
+			return []string{"golang"}
+		}
+		return nil
+	}
+	InferProficiency(skills, work, aliases, now)
+
+	tests := []struct {
+		name        string
+		proficiency string
+		inferred    bool
+		months      int
+	}{
+		// 29 current + 23 and 23 months ended 2.5 and 4.5 years ago at half
+		// weight: 52 weighted months.
+		{"Go", "advanced", true, 75},
+		// 29 months ended 6 years ago at a quarter weight: 7.25.
+		{"Perl", "beginner", true, 29},
+		// Only mentioned by a role with an unreadable end date.
+		{"Rust", "", false, 0},
+		// Stated levels are kept.
+		{"PostgreSQL", "expert", false, 0},
+		// 23 months ended 2.5 years ago at half weight: 11.5.
+		{"Python", "beginner", true, 23},
+	}
+	for i, tt := range tests {
+		s := skills[i]
+		if s.Name != tt.name || s.Proficiency != tt.proficiency || s.ProficiencyInferred != tt.inferred || s.ExperienceMonths != tt.months {
+			t.Errorf("%s: got %q inferred=%v months=%d, want %q inferred=%v months=%d",
+				tt.name, s.Proficiency, s.ProficiencyInferred, s.ExperienceMonths, tt.proficiency, tt.inferred, tt.months)
+		}
+	}
+}
+
+func TestInferProficiency_NotInWorkHistory(t *testing.T) {
+	skills := []schema.Skill{{Name: "Terraform"}}
+	work := []schema.WorkExperience{{Title: "Platform Engineer", StartDate: "2015", IsCurrent: true,
+		Responsibilities: []string{"Managed Terraformed AWS accounts"}}}
+	InferProficiency(skills, work, nil, time.Date(2025, time.June, 15, 0, 0, 0, 0, time.UTC))
+	if skills[0].Proficiency != "" || skills[0].ProficiencyInferred {
+		t.Errorf("got %q inferred=%v for a skill no role mentions as a word", skills[0].Proficiency, skills[0].ProficiencyInferred)
+	}
+}
+
+func TestProficiencyLevel(t *testing.T) {
+	tests := []struct {
+		months float64
+		want   string
+	}{
+		{0.5, "beginner"}, {11.9, "beginner"}, {12, "intermediate"}, {35.9, "intermediate"},
+		{36, "advanced"}, {71.9, "advanced"}, {72, "expert"}, {300, "expert"},
+	}
+	for _, tt := range tests {
+		if got := proficiencyLevel(tt.months); got != tt.want {
+			t.Errorf("proficiencyLevel(%v) = %q, want %q", tt.months, got, tt.want)
+		}
+	}
+}
+
+func TestRecencyWeight(t *testing.T) {
+	tests := []struct {
+		age  int
+		want float64
+	}{{0, 1}, {24, 1}, {25, 0.5}, {60, 0.5}, {61, 0.25}, {200, 0.25}}
+	for _, tt := range tests {
+		if got := recencyWeight(tt.age); got != tt.want {
+			t.Errorf("recencyWeight(%d) = %v, want %v", tt.age, got, tt.want)
+		}
+	}
+}
@@ -27,8 +27,9 @@ type Section struct {
 
 var sectionHeaderRe = regexp.MustCompile(`(?im)^[\s\-=_*#]*([A-Z][A-Za-z\s&/]+?)[\s\-=_*#:]*$`)
 
-// sectionKeywords maps normalized header keywords to section types.
-var sectionKeywords = map[string]SectionType{
+// englishSectionKeywords maps normalized English header keywords to section
+// types.
+var englishSectionKeywords = map[string]SectionType{
 	"summary":                SectionSummary,
 	"professional summary":   SectionSummary,
 	"career summary":         SectionSummary,
@@ -60,6 +61,8 @@ var sectionKeywords = map[string]SectionType{
 	"technologies":           SectionSkills,
 	"tools":                  SectionSkills,
 	"certifications":         SectionCertifications,
+	"certification":          SectionCertifications,
+	"certificates":           SectionCertifications,
 	"licenses":               SectionCertifications,
 	"certifications & licenses": SectionCertifications,
 	"professional certifications": SectionCertifications,
@@ -67,6 +70,9 @@ var sectionKeywords = map[string]SectionType{
 	"projects":               SectionProjects,
 	"personal projects":      SectionProjects,
 	"key projects":           SectionProjects,
+	"project experience":     SectionProjects,
+	"open source":            SectionProjects,
+	"open source contributions": SectionProjects,
 	"achievements":           SectionProjects,
 	"accomplishments":        SectionProjects,
 	"awards":                 SectionProjects,
@@ -74,8 +80,20 @@ var sectionKeywords = map[string]SectionType{
 	"portfolio":              SectionProjects,
 }
 
-// SplitSections splits raw resume text into labeled sections.
+// SplitSections splits raw resume text into labeled sections, using the
+// header language detected by DetectLanguage.
 func SplitSections(text string) []Section {
+	return SplitSectionsIn(text, DetectLanguage(text))
+}
+
+// SplitSectionsIn splits raw resume text into labeled sections, recognizing
+// headers in lang. English headers are recognized in every language, since
+// resumes often mix them in.
+func SplitSectionsIn(text string, lang *Language) []Section {
+	langs := []*Language{lang}
+	if lang != English {
+		langs = append(langs, English)
+	}
 	lines := strings.Split(text, "\n")
 	var sections []Section
 	var currentType SectionType = SectionUnknown
@@ -101,7 +119,7 @@ func SplitSections(text string) []Section {
 		}
 
 		// Check if this line is a section header
-		if st, title, ok := detectSectionHeader(trimmed); ok {
+		if st, title, ok := detectSectionHeaderIn(trimmed, langs...); ok {
 			flush()
 			currentType = st
 			currentTitle = title
@@ -115,8 +133,15 @@ func SplitSections(text string) []Section {
 	return sections
 }
 
-// detectSectionHeader returns the section type if the line is a header.
+// detectSectionHeader returns the section type if the line is an English
+// header.
 func detectSectionHeader(line string) (SectionType, string, bool) {
+	return detectSectionHeaderIn(line, English)
+}
+
+// detectSectionHeaderIn returns the section type if the line is a header in
+// one of langs, trying them in order.
+func detectSectionHeaderIn(line string, langs ...*Language) (SectionType, string, bool) {
 	// Must be short (section headers are rarely > 50 chars)
 	if len(line) > 60 {
 		return SectionUnknown, "", false
@@ -130,27 +155,28 @@ func detectSectionHeader(line string) (SectionType, string, bool) {
 	}
 
 	normalized := strings.ToLower(clean)
-	if st, ok := sectionKeywords[normalized]; ok {
-		return st, clean, true
+	for _, lang := range langs {
+		if st, ok := lang.Keywords[normalized]; ok {
+			return st, clean, true
+		}
 	}
 
 	// Partial match: check if any keyword is a prefix/suffix
-	for kw, st := range sectionKeywords {
-		if strings.HasPrefix(normalized, kw) || strings.HasSuffix(normalized, kw) {
-			return st, clean, true
+	for _, lang := range langs {
+		for _, kw := range lang.keywordsByLength() {
+			if strings.HasPrefix(normalized, kw) || strings.HasSuffix(normalized, kw) {
+				return lang.Keywords[kw], clean, true
+			}
 		}
 	}
 
 	// All-caps short line heuristic (e.g., "EXPERIENCE", "EDUCATION")
 	if isAllCaps(clean) && len(strings.Fields(clean)) <= 4 {
-		normalized = strings.ToLower(clean)
-		if st, ok := sectionKeywords[normalized]; ok {
-			return st, clean, true
-		}
-		// Try partial
-		for kw, st := range sectionKeywords {
-			if strings.Contains(normalized, kw) {
-				return st, clean, true
+		for _, lang := range langs {
+			for _, kw := range lang.keywordsByLength() {
+				if strings.Contains(normalized, kw) {
+					return lang.Keywords[kw], clean, true
+				}
 			}
 		}
 	}
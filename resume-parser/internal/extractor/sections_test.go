@@ -1,6 +1,7 @@
 package extractor
 
 import (
+	"strings"
 	"testing"
 )
 
@@ -101,6 +102,9 @@ func TestDetectSectionHeader(t *testing.T) {
 		{"Skills", SectionSkills, true},
 		{"Certifications", SectionCertifications, true},
 		{"Projects", SectionProjects, true},
+		{"Certificates", SectionCertifications, true},
+		{"Project Experience", SectionProjects, true},
+		{"Open Source Contributions", SectionProjects, true},
 		{"Summary", SectionSummary, true},
 		{"John Smith", SectionUnknown, false},
 		{"Software Engineer at Google", SectionUnknown, false},
@@ -118,3 +122,74 @@ func TestDetectSectionHeader(t *testing.T) {
 		})
 	}
 }
+
+func TestDetectLanguage(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want *Language
+	}{
+		{"english", "EXPERIENCE\nEngineer\n\nEDUCATION\nBS\n\nSKILLS\nGo", English},
+		{"indonesian", "PENGALAMAN KERJA\nEngineer\n\nPENDIDIKAN\nS1\n\nKEAHLIAN\nGo", Indonesian},
+		// English skill headers under an Indonesian resume.
+		{"mixed", "Pengalaman Kerja\nEngineer\n\nPendidikan\nS1\n\nSkills\nGo", Indonesian},
+		{"no headers", "Jane Doe\njane@example.com", English},
+		{"tie", "Experience\nEngineer\n\nPendidikan\nS1", English},
+	}
+	for _, tt := range tests {
+		if got := DetectLanguage(tt.text); got != tt.want {
+			t.Errorf("%s: DetectLanguage = %q, want %q", tt.name, got.Code, tt.want.Code)
+		}
+	}
+}
+
+func TestSplitSections_Indonesian(t *testing.T) {
+	text := `Budi Santoso
+
+Ringkasan
+Backend engineer.
+
+Riwayat Pekerjaan
+Engineer di PT Maju
+2020 - Sekarang
+
+Riwayat Pendidikan
+Universitas Indonesia
+
+Keahlian Teknis
+Go, Docker
+
+Certifications
+AWS Certified Developer`
+
+	sections := SplitSections(text)
+	got := ListFoundSections(sections)
+	want := []string{"summary", "experience", "education", "skills", "certifications"}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("ListFoundSections = %v, want %v", got, want)
+	}
+	if skills := GetSectionText(sections, SectionSkills); skills != "Go, Docker" {
+		t.Errorf("skills section = %q, want %q", skills, "Go, Docker")
+	}
+
+	// Indonesian headers are not recognized in English mode.
+	if _, _, ok := detectSectionHeaderIn("Riwayat Pendidikan", English); ok {
+		t.Error("expected an Indonesian header to be unknown in English")
+	}
+}
+
+func TestRegisterLanguage(t *testing.T) {
+	test := &Language{Code: "zz-test", Keywords: map[string]SectionType{
+		"zzexperience": SectionExperience,
+		"zzskills":     SectionSkills,
+	}}
+	RegisterLanguage(test)
+
+	text := "ZZEXPERIENCE\nEngineer\n\nZZSKILLS\nGo"
+	if got := DetectLanguage(text); got != test {
+		t.Fatalf("DetectLanguage = %q, want zz-test", got.Code)
+	}
+	if skills := GetSectionText(SplitSections(text), SectionSkills); skills != "Go" {
+		t.Errorf("skills section = %q, want Go", skills)
+	}
+}
@@ -0,0 +1,163 @@
+package gapanalysis
+
+import (
+	"encoding/json"
+	"sort"
+
+	"github.com/learnbot/resume-parser/internal/scorer"
+)
+
+// DefaultMinSharedJobs is the number of jobs a skill must be a gap in to be
+// listed among a multi-job analysis's shared gaps, unless the caller sets
+// another.
+const DefaultMinSharedJobs = 2
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Multi-job analysis
+// ─────────────────────────────────────────────────────────────────────────────
+
+// AnalyzeMany analyzes one candidate against several jobs. Results are
+// returned in input order; each carries its input Index and its Rank among
+// the jobs by readiness score. The summary ranks the jobs by readiness and
+// lists the skills that are gaps in at least minSharedJobs of them (values
+// below 1 mean DefaultMinSharedJobs), by how much closing them would unlock.
+//
+// Jobs with identical requirements are analyzed once: later copies get the
+// first copy's analysis and rank, point at it with DuplicateOf and are left
+// out of the summary, so that saving a job twice does not inflate its
+// skills.
+func (a *Analyzer) AnalyzeMany(profile scorer.CandidateProfile, jobs []scorer.JobRequirements, minSharedJobs int) MultiJobAnalysis {
+	if minSharedJobs < 1 {
+		minSharedJobs = DefaultMinSharedJobs
+	}
+
+	results := make([]JobGapResult, len(jobs))
+	var unique []int
+	firstByKey := make(map[string]int, len(jobs))
+	for i, job := range jobs {
+		results[i] = JobGapResult{Index: i, Title: job.Title}
+		key := jobKey(job)
+		if first, ok := firstByKey[key]; ok {
+			results[i].DuplicateOf = &first
+			results[i].Analysis = results[first].Analysis
+			continue
+		}
+		firstByKey[key] = i
+		unique = append(unique, i)
+		results[i].Analysis = a.Analyze(profile, job)
+	}
+
+	byReadiness := rankByReadiness(results, unique)
+	for i := range results {
+		if d := results[i].DuplicateOf; d != nil {
+			results[i].Rank = results[*d].Rank
+		}
+	}
+
+	return MultiJobAnalysis{
+		Jobs: results,
+		Summary: MultiJobSummary{
+			UniqueJobCount:  len(unique),
+			MinSharedJobs:   minSharedJobs,
+			JobsByReadiness: byReadiness,
+			SharedGaps:      a.sharedGaps(results, unique, minSharedJobs),
+		},
+	}
+}
+
+// jobKey identifies a job by its requirements.
+func jobKey(job scorer.JobRequirements) string {
+	b, _ := json.Marshal(job)
+	return string(b)
+}
+
+// rankByReadiness sets Rank on the unique results, highest readiness first
+// with equal scores sharing a rank, and returns their indexes in rank
+// order.
+func rankByReadiness(results []JobGapResult, unique []int) []int {
+	order := append([]int{}, unique...)
+	sort.SliceStable(order, func(x, y int) bool {
+		return results[order[x]].Analysis.ReadinessScore > results[order[y]].Analysis.ReadinessScore
+	})
+	for pos, i := range order {
+		if pos > 0 && results[i].Analysis.ReadinessScore == results[order[pos-1]].Analysis.ReadinessScore {
+			results[i].Rank = results[order[pos-1]].Rank
+			continue
+		}
+		results[i].Rank = pos + 1
+	}
+	return order
+}
+
+// sharedGaps collects the skills that are gaps in at least minJobs of the
+// unique jobs and ranks them by UnlockScore: the sum, over the jobs where
+// the skill is a critical gap, of the job's readiness as a fraction. A
+// critical gap in a job the candidate is nearly ready for is worth more
+// than one in a job that is out of reach anyway. Ties are broken by the
+// number of jobs where the skill is critical, then by the number of jobs
+// where it is any gap, then by name.
+//
+// Gaps are matched across jobs by skill name and alias, and are named as
+// the first job lists them.
+func (a *Analyzer) sharedGaps(results []JobGapResult, unique []int, minJobs int) []SharedGap {
+	var shared []SharedGap
+	var norms []string
+	find := func(norm string) int {
+		for i, n := range norms {
+			if n == norm || a.metadata.SameSkill(n, norm) {
+				return i
+			}
+		}
+		return -1
+	}
+
+	for _, idx := range unique {
+		analysis := results[idx].Analysis
+		readiness := analysis.ReadinessScore / 100
+		for _, gaps := range [][]SkillGap{analysis.CriticalGaps, analysis.ImportantGaps, analysis.NiceToHaveGaps} {
+			for _, g := range gaps {
+				norm := normalizeSkill(g.SkillName)
+				i := find(norm)
+				if i < 0 {
+					i = len(shared)
+					norms = append(norms, norm)
+					shared = append(shared, SharedGap{SkillName: g.SkillName})
+				}
+				s := &shared[i]
+				if n := len(s.JobIndexes); n > 0 && s.JobIndexes[n-1] == idx {
+					// The job lists an alias of a skill it already has as a gap.
+					continue
+				}
+				s.JobIndexes = append(s.JobIndexes, idx)
+				s.JobCount++
+				if g.Category == GapCategoryCritical {
+					s.CriticalJobCount++
+					s.UnlockScore += readiness
+				}
+				s.EstimatedLearningHours = max(s.EstimatedLearningHours, g.EstimatedLearningHours)
+			}
+		}
+	}
+
+	out := make([]SharedGap, 0, len(shared))
+	for _, s := range shared {
+		if s.JobCount >= minJobs {
+			s.UnlockScore = roundTo4(s.UnlockScore)
+			sort.Ints(s.JobIndexes)
+			out = append(out, s)
+		}
+	}
+	sort.SliceStable(out, func(i, j int) bool {
+		x, y := out[i], out[j]
+		switch {
+		case x.UnlockScore != y.UnlockScore:
+			return x.UnlockScore > y.UnlockScore
+		case x.CriticalJobCount != y.CriticalJobCount:
+			return x.CriticalJobCount > y.CriticalJobCount
+		case x.JobCount != y.JobCount:
+			return x.JobCount > y.JobCount
+		}
+		return normalizeSkill(x.SkillName) < normalizeSkill(y.SkillName)
+	})
+	return out
+}
@@ -0,0 +1,167 @@
+package gapanalysis
+
+import (
+	"encoding/json"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/learnbot/resume-parser/internal/scorer"
+)
+
+// batchResult is a unique job with the given readiness and gaps, for the
+// shared gap ranking tests.
+func batchResult(index int, readiness float64, critical, important []string) JobGapResult {
+	gaps := func(names []string, category GapCategory) []SkillGap {
+		var out []SkillGap
+		for _, n := range names {
+			out = append(out, SkillGap{SkillName: n, Category: category, EstimatedLearningHours: 10 * (index + 1)})
+		}
+		return out
+	}
+	return JobGapResult{Index: index, Analysis: GapAnalysisResult{
+		ReadinessScore: readiness,
+		CriticalGaps:   gaps(critical, GapCategoryCritical),
+		ImportantGaps:  gaps(important, GapCategoryImportant),
+	}}
+}
+
+func TestSharedGaps_UnlockRanking(t *testing.T) {
+	results := []JobGapResult{
+		batchResult(0, 80, []string{"Docker"}, []string{"Kubernetes"}),
+		batchResult(1, 50, []string{"Docker", "Kubernetes"}, nil),
+		batchResult(2, 20, []string{"Kubernetes", "Terraform"}, []string{"Docker"}),
+		batchResult(3, 60, []string{"k8s"}, []string{"Terraform", "Rust"}),
+	}
+	got := newAnalyzer().sharedGaps(results, []int{0, 1, 2, 3}, 2)
+
+	// Docker:     critical at 80% and 50%, important at 20%     → 1.3
+	// Kubernetes: critical at 50%, 20% and 60% (as k8s), important at 80% → 1.3,
+	//             but critical in one more job, so it ranks first.
+	// Terraform:  critical at 20%, important at 60%             → 0.2
+	// Rust is a gap in a single job and is left out.
+	want := []SharedGap{
+		{SkillName: "Kubernetes", JobCount: 4, CriticalJobCount: 3, UnlockScore: 1.3, EstimatedLearningHours: 40, JobIndexes: []int{0, 1, 2, 3}},
+		{SkillName: "Docker", JobCount: 3, CriticalJobCount: 2, UnlockScore: 1.3, EstimatedLearningHours: 30, JobIndexes: []int{0, 1, 2}},
+		{SkillName: "Terraform", JobCount: 2, CriticalJobCount: 1, UnlockScore: 0.2, EstimatedLearningHours: 40, JobIndexes: []int{2, 3}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("shared gaps:\n got %+v\nwant %+v", got, want)
+	}
+
+	// A skill critical only in a job the candidate is far from ready for
+	// ranks below one critical in a job they nearly qualify for.
+	got = newAnalyzer().sharedGaps([]JobGapResult{
+		batchResult(0, 90, []string{"Go"}, []string{"Java"}),
+		batchResult(1, 10, []string{"Java"}, []string{"Go"}),
+	}, []int{0, 1}, 2)
+	if len(got) != 2 || got[0].SkillName != "Go" || got[0].UnlockScore != 0.9 || got[1].UnlockScore != 0.1 {
+		t.Errorf("expected Go (0.9) before Java (0.1), got %+v", got)
+	}
+}
+
+func TestAnalyzeMany_RanksJobsByReadiness(t *testing.T) {
+	profile := scorer.CandidateProfile{Skills: []scorer.CandidateSkill{
+		{Name: "Go", Proficiency: "advanced"},
+		{Name: "SQL", Proficiency: "advanced"},
+	}}
+	jobs := []scorer.JobRequirements{
+		{Title: "Platform Engineer", RequiredSkills: []string{"Kubernetes", "Terraform", "Docker"}},
+		{Title: "Backend Engineer", RequiredSkills: []string{"Go", "SQL", "Docker"}},
+		{Title: "Go Developer", RequiredSkills: []string{"Go", "SQL"}},
+	}
+	got := newAnalyzer().AnalyzeMany(profile, jobs, 0)
+
+	if want := []int{2, 1, 0}; !reflect.DeepEqual(got.Summary.JobsByReadiness, want) {
+		t.Errorf("jobs by readiness = %v, want %v", got.Summary.JobsByReadiness, want)
+	}
+	for i, wantRank := range []int{3, 2, 1} {
+		if j := got.Jobs[i]; j.Index != i || j.Rank != wantRank || j.Title != jobs[i].Title {
+			t.Errorf("job %d: index %d rank %d title %q, want rank %d", i, j.Index, j.Rank, j.Title, wantRank)
+		}
+	}
+	if got.Summary.MinSharedJobs != DefaultMinSharedJobs || len(got.Summary.SharedGaps) != 1 {
+		t.Fatalf("expected Docker as the only shared gap, got %+v", got.Summary)
+	}
+	docker := got.Summary.SharedGaps[0]
+	want := roundTo4((got.Jobs[0].Analysis.ReadinessScore + got.Jobs[1].Analysis.ReadinessScore) / 100)
+	if docker.SkillName != "Docker" || docker.CriticalJobCount != 2 || docker.UnlockScore != want {
+		t.Errorf("Docker shared gap = %+v, want unlock score %v", docker, want)
+	}
+}
+
+func TestAnalyzeMany_DuplicateJobs(t *testing.T) {
+	profile := scorer.CandidateProfile{Skills: []scorer.CandidateSkill{{Name: "Go", Proficiency: "advanced"}}}
+	backend := scorer.JobRequirements{Title: "Backend Engineer", RequiredSkills: []string{"Go", "Docker"}}
+	frontend := scorer.JobRequirements{Title: "Frontend Engineer", RequiredSkills: []string{"React"}}
+	got := newAnalyzer().AnalyzeMany(profile, []scorer.JobRequirements{backend, frontend, backend, backend}, 2)
+
+	if got.Summary.UniqueJobCount != 2 || len(got.Summary.JobsByReadiness) != 2 {
+		t.Errorf("expected 2 unique jobs, got %d %v", got.Summary.UniqueJobCount, got.Summary.JobsByReadiness)
+	}
+	for _, i := range []int{2, 3} {
+		j := got.Jobs[i]
+		if j.DuplicateOf == nil || *j.DuplicateOf != 0 || j.Rank != got.Jobs[0].Rank ||
+			j.Analysis.ReadinessScore != got.Jobs[0].Analysis.ReadinessScore {
+			t.Errorf("job %d: expected a duplicate of job 0 with its rank and analysis, got %+v", i, j)
+		}
+	}
+	if got.Jobs[0].DuplicateOf != nil || got.Jobs[1].DuplicateOf != nil {
+		t.Error("first copies must not be marked as duplicates")
+	}
+	// Saving the backend job three times does not make Docker a shared gap.
+	if len(got.Summary.SharedGaps) != 0 {
+		t.Errorf("expected no shared gaps, got %+v", got.Summary.SharedGaps)
+	}
+}
+
+func TestBatchHandler(t *testing.T) {
+	h := NewHandler(log.New(io.Discard, "", 0))
+	h.SetMaxBatchJobs(2)
+	mux := http.NewServeMux()
+	h.RegisterRoutes(mux)
+
+	job := `{"job_id":"%s","job":{"title":"Go Developer","required_skills":["Go","Docker"]}}`
+	jobs := func(ids ...string) string {
+		var parts []string
+		for _, id := range ids {
+			parts = append(parts, strings.Replace(job, "%s", id, 1))
+		}
+		return `{"profile":{"skills":[{"name":"Go"}]},"jobs":[` + strings.Join(parts, ",") + `]}`
+	}
+	tests := []struct {
+		name string
+		body string
+		want int
+	}{
+		{"valid", jobs("j-1", "j-2"), http.StatusOK},
+		{"no jobs", jobs(), http.StatusUnprocessableEntity},
+		{"too many jobs", jobs("j-1", "j-2", "j-3"), http.StatusUnprocessableEntity},
+		{"negative threshold", `{"profile":{},"jobs":[{"job":{}}],"min_shared_jobs":-1}`, http.StatusUnprocessableEntity},
+	}
+	for _, tt := range tests {
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/api/v1/gap-analysis/batch", strings.NewReader(tt.body)))
+		if w.Code != tt.want {
+			t.Errorf("%s: expected %d, got %d: %s", tt.name, tt.want, w.Code, w.Body.String())
+			continue
+		}
+		if w.Code != http.StatusOK {
+			continue
+		}
+		var resp BatchGapAnalysisResponse
+		if err := json.NewDecoder(w.Body).Decode(&resp); err != nil || resp.Data == nil {
+			t.Fatalf("%s: decoding response: %v", tt.name, err)
+		}
+		if len(resp.Data.Jobs) != 2 || resp.Data.Jobs[0].JobID != "j-1" || resp.Data.Jobs[1].JobID != "j-2" {
+			t.Errorf("%s: expected job IDs j-1 and j-2 in order, got %+v", tt.name, resp.Data.Jobs)
+		}
+		if d := resp.Data.Jobs[1].DuplicateOf; d == nil || *d != 0 {
+			t.Errorf("%s: expected the second job to duplicate the first", tt.name)
+		}
+	}
+}
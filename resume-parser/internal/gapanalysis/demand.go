@@ -0,0 +1,106 @@
+// Package gapanalysis – demand.go defines the market demand component of
+// the priority score and the weights the score is computed with.
+package gapanalysis
+
+import "math"
+
+// DemandProvider supplies the market demand for skills, such as the share
+// of recent job postings that mention them. Skill names passed to it are
+// normalized (lowercased, trimmed).
+type DemandProvider interface {
+	// DemandScore returns the demand for a skill in [0.0, 1.0], where 1.0
+	// is the most demanded skill. Unknown skills return 0.
+	DemandScore(norm string) float64
+}
+
+// StaticDemand is a DemandProvider backed by a fixed table of demand
+// scores keyed by normalized skill name.
+type StaticDemand map[string]float64
+
+// DemandScore implements DemandProvider. Scores outside [0, 1] are clamped.
+func (d StaticDemand) DemandScore(norm string) float64 {
+	return clamp01(d[norm])
+}
+
+// PriorityWeights are the weights of the priority score components. They
+// are renormalized to sum to 1, so only their ratios matter.
+type PriorityWeights struct {
+	// Importance weighs how critical the skill is to job acceptance.
+	Importance float64
+
+	// Transferability weighs how broadly useful the skill is across roles.
+	Transferability float64
+
+	// AcquisitionEase weighs how quickly the skill can be acquired.
+	AcquisitionEase float64
+
+	// Demand weighs the skill's market demand. It only applies when the
+	// Analyzer has a DemandProvider.
+	Demand float64
+}
+
+// DefaultPriorityWeights returns the weights used without
+// WithPriorityWeights. Demand has no weight, so scores do not depend on a
+// DemandProvider unless weights are configured.
+func DefaultPriorityWeights() PriorityWeights {
+	return PriorityWeights{
+		Importance:      weightImportance,
+		Transferability: weightTransferability,
+		AcquisitionEase: weightAcquisitionEase,
+	}
+}
+
+// normalized returns the weights scaled to sum to 1. Negative weights count
+// as 0, and so does Demand without a demand provider. If no weight is left,
+// the defaults are used.
+func (w PriorityWeights) normalized(hasDemand bool) PriorityWeights {
+	w.Importance = math.Max(0, w.Importance)
+	w.Transferability = math.Max(0, w.Transferability)
+	w.AcquisitionEase = math.Max(0, w.AcquisitionEase)
+	w.Demand = math.Max(0, w.Demand)
+	if !hasDemand {
+		w.Demand = 0
+	}
+
+	sum := w.Importance + w.Transferability + w.AcquisitionEase + w.Demand
+	if sum == 0 {
+		return DefaultPriorityWeights()
+	}
+	return PriorityWeights{
+		Importance:      w.Importance / sum,
+		Transferability: w.Transferability / sum,
+		AcquisitionEase: w.AcquisitionEase / sum,
+		Demand:          w.Demand / sum,
+	}
+}
+
+// WithDemandProvider makes the Analyzer weigh market demand from p into
+// the priority score. Demand only changes scores once it has a weight; see
+// WithPriorityWeights.
+func WithDemandProvider(p DemandProvider) Option {
+	return func(a *Analyzer) {
+		a.demand = p
+	}
+}
+
+// WithPriorityWeights sets the weights of the priority score components.
+// The weights are renormalized to sum to 1.
+func WithPriorityWeights(w PriorityWeights) Option {
+	return func(a *Analyzer) {
+		a.weights = w
+	}
+}
+
+// demandFor returns the provider's demand score for a skill, or 0 without a
+// provider.
+func demandFor(p DemandProvider, norm string) float64 {
+	if p == nil {
+		return 0
+	}
+	return clamp01(p.DemandScore(norm))
+}
+
+// clamp01 clamps v to [0, 1].
+func clamp01(v float64) float64 {
+	return math.Min(1.0, math.Max(0.0, v))
+}
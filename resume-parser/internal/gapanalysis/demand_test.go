@@ -0,0 +1,95 @@
+package gapanalysis
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/learnbot/resume-parser/internal/scorer"
+)
+
+func TestPriorityWeights_Normalized(t *testing.T) {
+	tests := []struct {
+		name      string
+		weights   PriorityWeights
+		hasDemand bool
+		want      PriorityWeights
+	}{
+		{"defaults unchanged", DefaultPriorityWeights(), false, PriorityWeights{0.5, 0.3, 0.2, 0}},
+		{"scaled to sum to one", PriorityWeights{1, 1, 1, 1}, true, PriorityWeights{0.25, 0.25, 0.25, 0.25}},
+		{"demand dropped without provider", PriorityWeights{1, 1, 2, 4}, false, PriorityWeights{0.25, 0.25, 0.5, 0}},
+		{"negative weights count as zero", PriorityWeights{-1, 1, 1, 0}, true, PriorityWeights{0, 0.5, 0.5, 0}},
+		{"no weight left uses defaults", PriorityWeights{0, 0, 0, 1}, false, DefaultPriorityWeights()},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.weights.normalized(tt.hasDemand)
+			if !approxEqual(got.Importance, tt.want.Importance, 1e-9) ||
+				!approxEqual(got.Transferability, tt.want.Transferability, 1e-9) ||
+				!approxEqual(got.AcquisitionEase, tt.want.AcquisitionEase, 1e-9) ||
+				!approxEqual(got.Demand, tt.want.Demand, 1e-9) {
+				t.Errorf("normalized(%+v) = %+v, want %+v", tt.weights, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStaticDemand_Clamped(t *testing.T) {
+	d := StaticDemand{"go": 1.5, "cobol": -0.2, "python": 0.7}
+	for skill, want := range map[string]float64{"go": 1, "cobol": 0, "python": 0.7, "unknown": 0} {
+		if got := d.DemandScore(skill); got != want {
+			t.Errorf("DemandScore(%q) = %v, want %v", skill, got, want)
+		}
+	}
+}
+
+func TestAnalyze_NilDemandProviderKeepsDefaultScores(t *testing.T) {
+	job := scorer.JobRequirements{
+		RequiredSkills:  []string{"Python", "Kubernetes"},
+		PreferredSkills: []string{"Docker"},
+	}
+	want := New().Analyze(scorer.CandidateProfile{}, job)
+	// A demand weight without a provider is ignored.
+	got := New(WithPriorityWeights(PriorityWeights{0.5, 0.3, 0.2, 0.4})).Analyze(scorer.CandidateProfile{}, job)
+
+	for _, w := range want.TopPriorityGaps {
+		g, ok := findGap(got.TopPriorityGaps, w.SkillName)
+		if !ok {
+			t.Fatalf("missing gap %s", w.SkillName)
+		}
+		if g.PriorityScore != w.PriorityScore || g.DemandScore != 0 {
+			t.Errorf("%s: priority %.4f, demand %.4f; want priority %.4f, demand 0",
+				w.SkillName, g.PriorityScore, g.DemandScore, w.PriorityScore)
+		}
+	}
+	for _, e := range got.VisualData.LearningTimeline {
+		if strings.Contains(e.Rationale, "market demand") {
+			t.Errorf("%s: unexpected demand rationale %q", e.SkillName, e.Rationale)
+		}
+	}
+}
+
+func TestAnalyze_DemandBoostsPriority(t *testing.T) {
+	job := scorer.JobRequirements{RequiredSkills: []string{"Python", "Fortran"}}
+	a := New(
+		WithDemandProvider(StaticDemand{"fortran": 1.0}),
+		WithPriorityWeights(PriorityWeights{0.5, 0.3, 0.2, 0.5}),
+	)
+
+	result := a.Analyze(scorer.CandidateProfile{}, job)
+
+	// Without demand, Python is more transferable and ranks first.
+	if len(result.CriticalGaps) != 2 || result.CriticalGaps[0].SkillName != "Fortran" {
+		t.Fatalf("expected Fortran to rank first, got %+v", result.CriticalGaps)
+	}
+	if d := result.CriticalGaps[0].DemandScore; d != 1.0 {
+		t.Errorf("expected Fortran demand score 1.0, got %.4f", d)
+	}
+
+	timeline := result.VisualData.LearningTimeline
+	if !strings.Contains(timeline[0].Rationale, "high market demand") {
+		t.Errorf("expected the demand rationale for Fortran, got %q", timeline[0].Rationale)
+	}
+	if strings.Contains(timeline[1].Rationale, "market demand") {
+		t.Errorf("unexpected demand rationale for Python: %q", timeline[1].Rationale)
+	}
+}
@@ -2,13 +2,20 @@
 package gapanalysis
 
 import (
+	"fmt"
 	"math"
 	"sort"
 	"strings"
 
 	"github.com/learnbot/resume-parser/internal/scorer"
+	"github.com/learnbot/shared/metrics"
 )
 
+// analysisInvocations counts gap analyses, whether for /gap-analysis, its
+// report or a full analysis.
+var analysisInvocations = metrics.NewCounter("gap_analysis_invocations_total",
+	"Skill gap analyses computed.")
+
 // ─────────────────────────────────────────────────────────────────────────────
 // Priority scoring weights
 // ─────────────────────────────────────────────────────────────────────────────
@@ -47,105 +54,86 @@ const (
 // Skill metadata database
 // ─────────────────────────────────────────────────────────────────────────────
 
-// skillMetadata holds pre-defined metadata for common skills.
-type skillMetadata struct {
-	// baseHours is the estimated hours to reach job-ready proficiency
-	// from zero knowledge.
-	baseHours int
-
-	// transferability is how broadly useful this skill is [0.0, 1.0].
-	transferability float64
-
-	// difficulty is the inherent difficulty of the skill.
-	difficulty DifficultyLevel
-
-	// targetLevel is the typical proficiency level required by employers.
-	targetLevel string
-
-	// relatedSkills lists skill names that are semantically similar.
-	relatedSkills []string
-}
-
 // builtinSkillMetadata provides metadata for common skills.
 // Skills not in this map get default values.
-var builtinSkillMetadata = map[string]skillMetadata{
+var builtinSkillMetadata = map[string]SkillMetadata{
 	// Programming languages
-	"python":     {baseHours: 120, transferability: 0.95, difficulty: DifficultyIntermediate, targetLevel: "intermediate", relatedSkills: []string{"r", "julia", "ruby"}},
-	"go":         {baseHours: 150, transferability: 0.85, difficulty: DifficultyIntermediate, targetLevel: "intermediate", relatedSkills: []string{"rust", "c", "java"}},
-	"golang":     {baseHours: 150, transferability: 0.85, difficulty: DifficultyIntermediate, targetLevel: "intermediate", relatedSkills: []string{"rust", "c", "java"}},
-	"rust":       {baseHours: 250, transferability: 0.75, difficulty: DifficultyAdvanced, targetLevel: "intermediate", relatedSkills: []string{"c", "c++", "go"}},
-	"java":       {baseHours: 160, transferability: 0.90, difficulty: DifficultyIntermediate, targetLevel: "intermediate", relatedSkills: []string{"kotlin", "scala", "c#"}},
-	"kotlin":     {baseHours: 120, transferability: 0.80, difficulty: DifficultyIntermediate, targetLevel: "intermediate", relatedSkills: []string{"java", "scala"}},
-	"scala":      {baseHours: 200, transferability: 0.75, difficulty: DifficultyAdvanced, targetLevel: "intermediate", relatedSkills: []string{"java", "kotlin"}},
-	"javascript": {baseHours: 100, transferability: 0.95, difficulty: DifficultyBeginner, targetLevel: "intermediate", relatedSkills: []string{"typescript", "node.js"}},
-	"typescript": {baseHours: 80, transferability: 0.90, difficulty: DifficultyBeginner, targetLevel: "intermediate", relatedSkills: []string{"javascript"}},
-	"c++":        {baseHours: 300, transferability: 0.80, difficulty: DifficultyAdvanced, targetLevel: "intermediate", relatedSkills: []string{"c", "rust"}},
-	"c":          {baseHours: 200, transferability: 0.75, difficulty: DifficultyAdvanced, targetLevel: "intermediate", relatedSkills: []string{"c++", "rust"}},
-	"c#":         {baseHours: 150, transferability: 0.85, difficulty: DifficultyIntermediate, targetLevel: "intermediate", relatedSkills: []string{"java", ".net"}},
-	"ruby":       {baseHours: 100, transferability: 0.75, difficulty: DifficultyBeginner, targetLevel: "intermediate", relatedSkills: []string{"python", "rails"}},
-	"php":        {baseHours: 80, transferability: 0.70, difficulty: DifficultyBeginner, targetLevel: "intermediate", relatedSkills: []string{"laravel"}},
-	"swift":      {baseHours: 150, transferability: 0.65, difficulty: DifficultyIntermediate, targetLevel: "intermediate", relatedSkills: []string{"objective-c", "kotlin"}},
-	"r":          {baseHours: 100, transferability: 0.70, difficulty: DifficultyIntermediate, targetLevel: "intermediate", relatedSkills: []string{"python", "julia"}},
+	"python":     {BaseHours: 120, Transferability: 0.95, Difficulty: DifficultyIntermediate, TargetLevel: "intermediate", RelatedSkills: []string{"r", "julia", "ruby"}},
+	"go":         {BaseHours: 150, Transferability: 0.85, Difficulty: DifficultyIntermediate, TargetLevel: "intermediate", RelatedSkills: []string{"rust", "c", "java"}},
+	"golang":     {BaseHours: 150, Transferability: 0.85, Difficulty: DifficultyIntermediate, TargetLevel: "intermediate", RelatedSkills: []string{"rust", "c", "java"}},
+	"rust":       {BaseHours: 250, Transferability: 0.75, Difficulty: DifficultyAdvanced, TargetLevel: "intermediate", RelatedSkills: []string{"c", "c++", "go"}},
+	"java":       {BaseHours: 160, Transferability: 0.90, Difficulty: DifficultyIntermediate, TargetLevel: "intermediate", RelatedSkills: []string{"kotlin", "scala", "c#"}},
+	"kotlin":     {BaseHours: 120, Transferability: 0.80, Difficulty: DifficultyIntermediate, TargetLevel: "intermediate", RelatedSkills: []string{"java", "scala"}},
+	"scala":      {BaseHours: 200, Transferability: 0.75, Difficulty: DifficultyAdvanced, TargetLevel: "intermediate", RelatedSkills: []string{"java", "kotlin"}},
+	"javascript": {BaseHours: 100, Transferability: 0.95, Difficulty: DifficultyBeginner, TargetLevel: "intermediate", RelatedSkills: []string{"typescript", "node.js"}},
+	"typescript": {BaseHours: 80, Transferability: 0.90, Difficulty: DifficultyBeginner, TargetLevel: "intermediate", RelatedSkills: []string{"javascript"}},
+	"c++":        {BaseHours: 300, Transferability: 0.80, Difficulty: DifficultyAdvanced, TargetLevel: "intermediate", RelatedSkills: []string{"c", "rust"}},
+	"c":          {BaseHours: 200, Transferability: 0.75, Difficulty: DifficultyAdvanced, TargetLevel: "intermediate", RelatedSkills: []string{"c++", "rust"}},
+	"c#":         {BaseHours: 150, Transferability: 0.85, Difficulty: DifficultyIntermediate, TargetLevel: "intermediate", RelatedSkills: []string{"java", ".net"}},
+	"ruby":       {BaseHours: 100, Transferability: 0.75, Difficulty: DifficultyBeginner, TargetLevel: "intermediate", RelatedSkills: []string{"python", "rails"}},
+	"php":        {BaseHours: 80, Transferability: 0.70, Difficulty: DifficultyBeginner, TargetLevel: "intermediate", RelatedSkills: []string{"laravel"}},
+	"swift":      {BaseHours: 150, Transferability: 0.65, Difficulty: DifficultyIntermediate, TargetLevel: "intermediate", RelatedSkills: []string{"objective-c", "kotlin"}},
+	"r":          {BaseHours: 100, Transferability: 0.70, Difficulty: DifficultyIntermediate, TargetLevel: "intermediate", RelatedSkills: []string{"python", "julia"}},
 
 	// Frameworks & libraries
-	"react":          {baseHours: 80, transferability: 0.85, difficulty: DifficultyIntermediate, targetLevel: "intermediate", relatedSkills: []string{"vue", "angular", "javascript"}},
-	"vue":            {baseHours: 70, transferability: 0.80, difficulty: DifficultyBeginner, targetLevel: "intermediate", relatedSkills: []string{"react", "angular"}},
-	"angular":        {baseHours: 100, transferability: 0.80, difficulty: DifficultyIntermediate, targetLevel: "intermediate", relatedSkills: []string{"react", "vue"}},
-	"node.js":        {baseHours: 80, transferability: 0.85, difficulty: DifficultyIntermediate, targetLevel: "intermediate", relatedSkills: []string{"javascript", "express"}},
-	"django":         {baseHours: 80, transferability: 0.75, difficulty: DifficultyIntermediate, targetLevel: "intermediate", relatedSkills: []string{"flask", "python"}},
-	"flask":          {baseHours: 50, transferability: 0.70, difficulty: DifficultyBeginner, targetLevel: "intermediate", relatedSkills: []string{"django", "python"}},
-	"spring":         {baseHours: 120, transferability: 0.80, difficulty: DifficultyIntermediate, targetLevel: "intermediate", relatedSkills: []string{"java", "spring boot"}},
-	"spring boot":    {baseHours: 100, transferability: 0.80, difficulty: DifficultyIntermediate, targetLevel: "intermediate", relatedSkills: []string{"java", "spring"}},
-	"tensorflow":     {baseHours: 150, transferability: 0.80, difficulty: DifficultyAdvanced, targetLevel: "intermediate", relatedSkills: []string{"pytorch", "keras", "python"}},
-	"pytorch":        {baseHours: 150, transferability: 0.80, difficulty: DifficultyAdvanced, targetLevel: "intermediate", relatedSkills: []string{"tensorflow", "python"}},
-	"keras":          {baseHours: 80, transferability: 0.75, difficulty: DifficultyIntermediate, targetLevel: "intermediate", relatedSkills: []string{"tensorflow", "pytorch"}},
+	"react":          {BaseHours: 80, Transferability: 0.85, Difficulty: DifficultyIntermediate, TargetLevel: "intermediate", RelatedSkills: []string{"vue", "angular", "javascript"}},
+	"vue":            {BaseHours: 70, Transferability: 0.80, Difficulty: DifficultyBeginner, TargetLevel: "intermediate", RelatedSkills: []string{"react", "angular"}},
+	"angular":        {BaseHours: 100, Transferability: 0.80, Difficulty: DifficultyIntermediate, TargetLevel: "intermediate", RelatedSkills: []string{"react", "vue"}},
+	"node.js":        {BaseHours: 80, Transferability: 0.85, Difficulty: DifficultyIntermediate, TargetLevel: "intermediate", RelatedSkills: []string{"javascript", "express"}},
+	"django":         {BaseHours: 80, Transferability: 0.75, Difficulty: DifficultyIntermediate, TargetLevel: "intermediate", RelatedSkills: []string{"flask", "python"}},
+	"flask":          {BaseHours: 50, Transferability: 0.70, Difficulty: DifficultyBeginner, TargetLevel: "intermediate", RelatedSkills: []string{"django", "python"}},
+	"spring":         {BaseHours: 120, Transferability: 0.80, Difficulty: DifficultyIntermediate, TargetLevel: "intermediate", RelatedSkills: []string{"java", "spring boot"}},
+	"spring boot":    {BaseHours: 100, Transferability: 0.80, Difficulty: DifficultyIntermediate, TargetLevel: "intermediate", RelatedSkills: []string{"java", "spring"}},
+	"tensorflow":     {BaseHours: 150, Transferability: 0.80, Difficulty: DifficultyAdvanced, TargetLevel: "intermediate", RelatedSkills: []string{"pytorch", "keras", "python"}},
+	"pytorch":        {BaseHours: 150, Transferability: 0.80, Difficulty: DifficultyAdvanced, TargetLevel: "intermediate", RelatedSkills: []string{"tensorflow", "python"}},
+	"keras":          {BaseHours: 80, Transferability: 0.75, Difficulty: DifficultyIntermediate, TargetLevel: "intermediate", RelatedSkills: []string{"tensorflow", "pytorch"}},
 
 	// Databases
-	"postgresql": {baseHours: 80, transferability: 0.90, difficulty: DifficultyIntermediate, targetLevel: "intermediate", relatedSkills: []string{"mysql", "sqlite", "sql"}},
-	"mysql":      {baseHours: 70, transferability: 0.85, difficulty: DifficultyBeginner, targetLevel: "intermediate", relatedSkills: []string{"postgresql", "sql"}},
-	"mongodb":    {baseHours: 60, transferability: 0.80, difficulty: DifficultyBeginner, targetLevel: "intermediate", relatedSkills: []string{"redis", "cassandra"}},
-	"redis":      {baseHours: 40, transferability: 0.85, difficulty: DifficultyBeginner, targetLevel: "intermediate", relatedSkills: []string{"memcached", "mongodb"}},
-	"sql":        {baseHours: 60, transferability: 0.95, difficulty: DifficultyBeginner, targetLevel: "intermediate", relatedSkills: []string{"postgresql", "mysql"}},
-	"elasticsearch": {baseHours: 80, transferability: 0.80, difficulty: DifficultyIntermediate, targetLevel: "intermediate", relatedSkills: []string{"opensearch", "solr"}},
-	"cassandra":  {baseHours: 100, transferability: 0.70, difficulty: DifficultyAdvanced, targetLevel: "intermediate", relatedSkills: []string{"mongodb", "dynamodb"}},
+	"postgresql": {BaseHours: 80, Transferability: 0.90, Difficulty: DifficultyIntermediate, TargetLevel: "intermediate", RelatedSkills: []string{"mysql", "sqlite", "sql"}},
+	"mysql":      {BaseHours: 70, Transferability: 0.85, Difficulty: DifficultyBeginner, TargetLevel: "intermediate", RelatedSkills: []string{"postgresql", "sql"}},
+	"mongodb":    {BaseHours: 60, Transferability: 0.80, Difficulty: DifficultyBeginner, TargetLevel: "intermediate", RelatedSkills: []string{"redis", "cassandra"}},
+	"redis":      {BaseHours: 40, Transferability: 0.85, Difficulty: DifficultyBeginner, TargetLevel: "intermediate", RelatedSkills: []string{"memcached", "mongodb"}},
+	"sql":        {BaseHours: 60, Transferability: 0.95, Difficulty: DifficultyBeginner, TargetLevel: "intermediate", RelatedSkills: []string{"postgresql", "mysql"}},
+	"elasticsearch": {BaseHours: 80, Transferability: 0.80, Difficulty: DifficultyIntermediate, TargetLevel: "intermediate", RelatedSkills: []string{"opensearch", "solr"}},
+	"cassandra":  {BaseHours: 100, Transferability: 0.70, Difficulty: DifficultyAdvanced, TargetLevel: "intermediate", RelatedSkills: []string{"mongodb", "dynamodb"}},
 
 	// Cloud & DevOps
-	"docker":     {baseHours: 60, transferability: 0.95, difficulty: DifficultyIntermediate, targetLevel: "intermediate", relatedSkills: []string{"kubernetes", "podman"}},
-	"kubernetes": {baseHours: 120, transferability: 0.90, difficulty: DifficultyAdvanced, targetLevel: "intermediate", relatedSkills: []string{"docker", "helm"}},
-	"aws":        {baseHours: 150, transferability: 0.90, difficulty: DifficultyIntermediate, targetLevel: "intermediate", relatedSkills: []string{"gcp", "azure"}},
-	"gcp":        {baseHours: 150, transferability: 0.85, difficulty: DifficultyIntermediate, targetLevel: "intermediate", relatedSkills: []string{"aws", "azure"}},
-	"azure":      {baseHours: 150, transferability: 0.85, difficulty: DifficultyIntermediate, targetLevel: "intermediate", relatedSkills: []string{"aws", "gcp"}},
-	"terraform":  {baseHours: 80, transferability: 0.85, difficulty: DifficultyIntermediate, targetLevel: "intermediate", relatedSkills: []string{"ansible", "pulumi"}},
-	"ansible":    {baseHours: 60, transferability: 0.80, difficulty: DifficultyIntermediate, targetLevel: "intermediate", relatedSkills: []string{"terraform", "chef"}},
-	"jenkins":    {baseHours: 60, transferability: 0.80, difficulty: DifficultyIntermediate, targetLevel: "intermediate", relatedSkills: []string{"github actions", "gitlab ci"}},
-	"git":        {baseHours: 30, transferability: 0.99, difficulty: DifficultyBeginner, targetLevel: "intermediate", relatedSkills: []string{"github", "gitlab"}},
-	"linux":      {baseHours: 80, transferability: 0.95, difficulty: DifficultyIntermediate, targetLevel: "intermediate", relatedSkills: []string{"unix", "bash"}},
-	"bash":       {baseHours: 40, transferability: 0.90, difficulty: DifficultyBeginner, targetLevel: "intermediate", relatedSkills: []string{"shell", "linux"}},
+	"docker":     {BaseHours: 60, Transferability: 0.95, Difficulty: DifficultyIntermediate, TargetLevel: "intermediate", RelatedSkills: []string{"kubernetes", "podman"}},
+	"kubernetes": {BaseHours: 120, Transferability: 0.90, Difficulty: DifficultyAdvanced, TargetLevel: "intermediate", RelatedSkills: []string{"docker", "helm"}},
+	"aws":        {BaseHours: 150, Transferability: 0.90, Difficulty: DifficultyIntermediate, TargetLevel: "intermediate", RelatedSkills: []string{"gcp", "azure"}},
+	"gcp":        {BaseHours: 150, Transferability: 0.85, Difficulty: DifficultyIntermediate, TargetLevel: "intermediate", RelatedSkills: []string{"aws", "azure"}},
+	"azure":      {BaseHours: 150, Transferability: 0.85, Difficulty: DifficultyIntermediate, TargetLevel: "intermediate", RelatedSkills: []string{"aws", "gcp"}},
+	"terraform":  {BaseHours: 80, Transferability: 0.85, Difficulty: DifficultyIntermediate, TargetLevel: "intermediate", RelatedSkills: []string{"ansible", "pulumi"}},
+	"ansible":    {BaseHours: 60, Transferability: 0.80, Difficulty: DifficultyIntermediate, TargetLevel: "intermediate", RelatedSkills: []string{"terraform", "chef"}},
+	"jenkins":    {BaseHours: 60, Transferability: 0.80, Difficulty: DifficultyIntermediate, TargetLevel: "intermediate", RelatedSkills: []string{"github actions", "gitlab ci"}},
+	"git":        {BaseHours: 30, Transferability: 0.99, Difficulty: DifficultyBeginner, TargetLevel: "intermediate", RelatedSkills: []string{"github", "gitlab"}},
+	"linux":      {BaseHours: 80, Transferability: 0.95, Difficulty: DifficultyIntermediate, TargetLevel: "intermediate", RelatedSkills: []string{"unix", "bash"}},
+	"bash":       {BaseHours: 40, Transferability: 0.90, Difficulty: DifficultyBeginner, TargetLevel: "intermediate", RelatedSkills: []string{"shell", "linux"}},
 
 	// ML/AI
-	"machine learning":  {baseHours: 200, transferability: 0.85, difficulty: DifficultyAdvanced, targetLevel: "intermediate", relatedSkills: []string{"deep learning", "python"}},
-	"deep learning":     {baseHours: 250, transferability: 0.80, difficulty: DifficultyAdvanced, targetLevel: "intermediate", relatedSkills: []string{"machine learning", "tensorflow"}},
-	"nlp":               {baseHours: 200, transferability: 0.75, difficulty: DifficultyAdvanced, targetLevel: "intermediate", relatedSkills: []string{"machine learning", "python"}},
-	"computer vision":   {baseHours: 200, transferability: 0.75, difficulty: DifficultyAdvanced, targetLevel: "intermediate", relatedSkills: []string{"deep learning", "opencv"}},
-	"data science":      {baseHours: 150, transferability: 0.85, difficulty: DifficultyIntermediate, targetLevel: "intermediate", relatedSkills: []string{"python", "machine learning"}},
-	"data engineering":  {baseHours: 150, transferability: 0.85, difficulty: DifficultyIntermediate, targetLevel: "intermediate", relatedSkills: []string{"spark", "kafka", "python"}},
-	"spark":             {baseHours: 120, transferability: 0.80, difficulty: DifficultyAdvanced, targetLevel: "intermediate", relatedSkills: []string{"hadoop", "kafka"}},
-	"kafka":             {baseHours: 80, transferability: 0.80, difficulty: DifficultyIntermediate, targetLevel: "intermediate", relatedSkills: []string{"rabbitmq", "spark"}},
+	"machine learning":  {BaseHours: 200, Transferability: 0.85, Difficulty: DifficultyAdvanced, TargetLevel: "intermediate", RelatedSkills: []string{"deep learning", "python"}},
+	"deep learning":     {BaseHours: 250, Transferability: 0.80, Difficulty: DifficultyAdvanced, TargetLevel: "intermediate", RelatedSkills: []string{"machine learning", "tensorflow"}},
+	"nlp":               {BaseHours: 200, Transferability: 0.75, Difficulty: DifficultyAdvanced, TargetLevel: "intermediate", RelatedSkills: []string{"machine learning", "python"}},
+	"computer vision":   {BaseHours: 200, Transferability: 0.75, Difficulty: DifficultyAdvanced, TargetLevel: "intermediate", RelatedSkills: []string{"deep learning", "opencv"}},
+	"data science":      {BaseHours: 150, Transferability: 0.85, Difficulty: DifficultyIntermediate, TargetLevel: "intermediate", RelatedSkills: []string{"python", "machine learning"}},
+	"data engineering":  {BaseHours: 150, Transferability: 0.85, Difficulty: DifficultyIntermediate, TargetLevel: "intermediate", RelatedSkills: []string{"spark", "kafka", "python"}},
+	"spark":             {BaseHours: 120, Transferability: 0.80, Difficulty: DifficultyAdvanced, TargetLevel: "intermediate", RelatedSkills: []string{"hadoop", "kafka"}},
+	"kafka":             {BaseHours: 80, Transferability: 0.80, Difficulty: DifficultyIntermediate, TargetLevel: "intermediate", RelatedSkills: []string{"rabbitmq", "spark"}},
 
 	// Soft skills
-	"communication":     {baseHours: 40, transferability: 1.0, difficulty: DifficultyBeginner, targetLevel: "intermediate", relatedSkills: []string{"presentation", "writing"}},
-	"leadership":        {baseHours: 80, transferability: 1.0, difficulty: DifficultyIntermediate, targetLevel: "intermediate", relatedSkills: []string{"management", "mentoring"}},
-	"agile":             {baseHours: 30, transferability: 0.95, difficulty: DifficultyBeginner, targetLevel: "intermediate", relatedSkills: []string{"scrum", "kanban"}},
-	"scrum":             {baseHours: 20, transferability: 0.90, difficulty: DifficultyBeginner, targetLevel: "intermediate", relatedSkills: []string{"agile", "kanban"}},
-	"system design":     {baseHours: 150, transferability: 0.90, difficulty: DifficultyAdvanced, targetLevel: "intermediate", relatedSkills: []string{"architecture", "distributed systems"}},
+	"communication":     {BaseHours: 40, Transferability: 1.0, Difficulty: DifficultyBeginner, TargetLevel: "intermediate", RelatedSkills: []string{"presentation", "writing"}},
+	"leadership":        {BaseHours: 80, Transferability: 1.0, Difficulty: DifficultyIntermediate, TargetLevel: "intermediate", RelatedSkills: []string{"management", "mentoring"}},
+	"agile":             {BaseHours: 30, Transferability: 0.95, Difficulty: DifficultyBeginner, TargetLevel: "intermediate", RelatedSkills: []string{"scrum", "kanban"}},
+	"scrum":             {BaseHours: 20, Transferability: 0.90, Difficulty: DifficultyBeginner, TargetLevel: "intermediate", RelatedSkills: []string{"agile", "kanban"}},
+	"system design":     {BaseHours: 150, Transferability: 0.90, Difficulty: DifficultyAdvanced, TargetLevel: "intermediate", RelatedSkills: []string{"architecture", "distributed systems"}},
 }
 
 // defaultSkillMetadata is used for skills not in builtinSkillMetadata.
-var defaultSkillMetadata = skillMetadata{
-	baseHours:       100,
-	transferability: 0.70,
-	difficulty:      DifficultyIntermediate,
-	targetLevel:     "intermediate",
+var defaultSkillMetadata = SkillMetadata{
+	BaseHours:       100,
+	Transferability: 0.70,
+	Difficulty:      DifficultyIntermediate,
+	TargetLevel:     "intermediate",
 }
 
 // ─────────────────────────────────────────────────────────────────────────────
@@ -153,57 +141,98 @@ var defaultSkillMetadata = skillMetadata{
 // ─────────────────────────────────────────────────────────────────────────────
 
 // Analyzer performs skill gap analysis between a candidate profile and job requirements.
-type Analyzer struct{}
+type Analyzer struct {
+	// metadata supplies skill metadata and alias resolution.
+	metadata SkillMetadataProvider
+
+	// demand supplies market demand scores; nil means no demand data.
+	demand DemandProvider
+
+	// weights are the normalized priority score weights.
+	weights PriorityWeights
 
-// New creates a new gap Analyzer.
-func New() *Analyzer {
-	return &Analyzer{}
+	// scale ranks candidate proficiencies against target levels.
+	scale scorer.ProficiencyScale
+}
+
+// New creates a new gap Analyzer. Without options it uses the builtin skill
+// metadata, the default priority weights and the default proficiency scale,
+// without market demand.
+func New(opts ...Option) *Analyzer {
+	a := &Analyzer{
+		metadata: BuiltinMetadataProvider{},
+		weights:  DefaultPriorityWeights(),
+		scale:    scorer.DefaultProficiencyScale(),
+	}
+	for _, opt := range opts {
+		opt(a)
+	}
+	a.weights = a.weights.normalized(a.demand != nil)
+	return a
+}
+
+// WithProficiencyScale makes the Analyzer rank proficiencies and target
+// levels on scale, which must be valid (see scorer.ProficiencyScale.Validate).
+// A proficiency not on the scale is ranked as its default level, with a
+// warning in the result.
+func WithProficiencyScale(scale scorer.ProficiencyScale) Option {
+	return func(a *Analyzer) {
+		a.scale = scale
+	}
 }
 
 // Analyze computes the skill gap analysis for a candidate against a job.
 // It returns a GapAnalysisResult with prioritized gaps and recommendations.
 func (a *Analyzer) Analyze(profile scorer.CandidateProfile, job scorer.JobRequirements) GapAnalysisResult {
+	analysisInvocations.Inc()
+
 	// Build a normalized index of candidate skills for fast lookup.
-	candidateIndex := buildCandidateIndex(profile.Skills)
+	candidateIndex, warnings := buildCandidateIndex(a.scale, profile.Skills)
+	warnings = append(warnings, a.targetLevelWarnings(job)...)
 
 	// Identify gaps in each category.
-	criticalGaps := a.identifyGaps(job.RequiredSkills, GapCategoryCritical, candidateIndex, profile.Skills)
-	importantGaps := a.identifyGaps(job.PreferredSkills, GapCategoryImportant, candidateIndex, profile.Skills)
+	criticalGaps := a.identifyGaps(job.RequiredSkills, GapCategoryCritical, candidateIndex, profile.Skills, profile.Certifications)
+	importantGaps := a.identifyGaps(job.PreferredSkills, GapCategoryImportant, candidateIndex, profile.Skills, profile.Certifications)
+	niceToHaveSkills := excludeSkills(job.NiceToHaveSkills, job.RequiredSkills, job.PreferredSkills)
+	niceToHaveGaps := a.identifyGaps(niceToHaveSkills, GapCategoryNiceToHave, candidateIndex, profile.Skills, profile.Certifications)
 
 	// Collect matched skills (required + preferred that the candidate has).
-	matchedSkills := collectMatchedSkills(job.RequiredSkills, job.PreferredSkills, candidateIndex)
+	matchedSkills := collectMatchedSkills(a.metadata, a.scale, job.RequiredSkills, job.PreferredSkills, candidateIndex)
 
 	// Sort each category by priority score descending.
 	sortGapsByPriority(criticalGaps)
 	sortGapsByPriority(importantGaps)
+	sortGapsByPriority(niceToHaveGaps)
 
 	// Build top priority gaps across all categories.
-	allGaps := append(append([]SkillGap{}, criticalGaps...), importantGaps...)
+	allGaps := append(append(append([]SkillGap{}, criticalGaps...), importantGaps...), niceToHaveGaps...)
 	sortGapsByPriority(allGaps)
 	topPriority := topN(allGaps, maxTopPriorityGaps)
 
 	// Calculate total learning hours.
-	totalHours := sumLearningHours(criticalGaps) + sumLearningHours(importantGaps)
+	totalHours := sumLearningHours(criticalGaps) + sumLearningHours(importantGaps) + sumLearningHours(niceToHaveGaps)
 
 	// Calculate readiness score: 100 - penalty for critical gaps.
-	readinessScore := calculateReadinessScore(criticalGaps, importantGaps, job)
+	readinessScore := calculateReadinessScore(a.scale, criticalGaps, importantGaps, niceToHaveGaps, job)
 
 	// Build visual data.
-	visualData := buildVisualData(criticalGaps, importantGaps, profile, job)
+	visualData := buildVisualData(criticalGaps, importantGaps, niceToHaveGaps, profile, job, a.weights)
 
 	return GapAnalysisResult{
 		CriticalGaps:                criticalGaps,
 		ImportantGaps:               importantGaps,
-		NiceToHaveGaps:              []SkillGap{}, // populated from context in future
-		TotalGaps:                   len(criticalGaps) + len(importantGaps),
+		NiceToHaveGaps:              niceToHaveGaps,
+		TotalGaps:                   len(criticalGaps) + len(importantGaps) + len(niceToHaveGaps),
 		CriticalGapCount:            len(criticalGaps),
 		ImportantGapCount:           len(importantGaps),
-		NiceToHaveGapCount:          0,
+		NiceToHaveGapCount:          len(niceToHaveGaps),
 		TotalEstimatedLearningHours: totalHours,
 		ReadinessScore:              readinessScore,
 		TopPriorityGaps:             topPriority,
 		MatchedSkills:               matchedSkills,
+		WeakMatches:                 collectWeakMatches(a.scale, criticalGaps, importantGaps, niceToHaveGaps),
 		VisualData:                  visualData,
+		Warnings:                    warnings,
 	}
 }
 
@@ -218,6 +247,7 @@ func (a *Analyzer) identifyGaps(
 	category GapCategory,
 	candidateIndex map[string]scorer.CandidateSkill,
 	allCandidateSkills []scorer.CandidateSkill,
+	certs []scorer.CertificationEntry,
 ) []SkillGap {
 	var gaps []SkillGap
 	// Track already-processed normalized skill names to avoid duplicates.
@@ -231,23 +261,35 @@ func (a *Analyzer) identifyGaps(
 		}
 		seen[norm] = true
 
-		if _, found := lookupInIndex(norm, candidateIndex); found {
+		meta := metadataFor(a.metadata, norm)
+		existing, presence := lookupInIndex(a.metadata, a.scale, norm, meta.TargetLevel, candidateIndex)
+		if presence == skillAtTarget {
 			continue // Candidate already has this skill.
 		}
 
-		// Candidate is missing this skill – build a gap entry.
-		meta := getSkillMetadata(norm)
+		// Candidate is missing this skill, or has it below the target
+		// level – build a gap entry.
 		importanceScore := categoryImportanceScore(category)
+		currentLevel := ""
+		otherSkills := allCandidateSkills
+		if presence == skillBelowTarget {
+			currentLevel = a.scale.Resolve(existing.Proficiency).Level
+			otherSkills = withoutSkill(allCandidateSkills, existing.Name)
+		}
 
-		// Find the closest existing skill for semantic similarity.
-		closestSkill, simScore := findClosestSkill(norm, allCandidateSkills)
+		// Find the closest existing skill for semantic similarity. The skill
+		// itself is excluded, since its partial knowledge is accounted for
+		// by currentLevel.
+		closestSkill, simScore := findClosestSkill(a.metadata, norm, otherSkills)
 
 		// Adjust learning hours based on semantic similarity
-		// (if candidate has a related skill, reduce hours).
-		adjustedHours := adjustLearningHours(meta.baseHours, simScore, "")
+		// (if candidate has a related skill, reduce hours) and on the
+		// candidate's current level in the skill.
+		adjustedHours := adjustLearningHours(a.scale, meta.BaseHours, simScore, currentLevel)
 
 		// Compute priority score.
-		priorityScore := computePriorityScore(importanceScore, meta.transferability, adjustedHours)
+		demandScore := demandFor(a.demand, norm)
+		priorityScore := computePriorityScore(a.weights, importanceScore, meta.Transferability, adjustedHours, demandScore)
 
 		gap := SkillGap{
 			SkillName:               skillName,
@@ -255,12 +297,14 @@ func (a *Analyzer) identifyGaps(
 			PriorityScore:           roundTo4(priorityScore),
 			ImportanceScore:         importanceScore,
 			EstimatedLearningHours:  adjustedHours,
-			TransferabilityScore:    meta.transferability,
-			TargetLevel:             meta.targetLevel,
+			TransferabilityScore:    meta.Transferability,
+			DemandScore:             roundTo4(demandScore),
+			CurrentLevel:            currentLevel,
+			TargetLevel:             meta.TargetLevel,
 			SemanticSimilarityScore: roundTo4(simScore),
 			ClosestExistingSkill:    closestSkill,
-			Difficulty:              meta.difficulty,
-			Recommendations:         buildRecommendations(skillName, meta, simScore),
+			Difficulty:              meta.Difficulty,
+			Recommendations:         buildRecommendations(skillName, meta, simScore, heldCertification(a.metadata, norm, certs)),
 		}
 
 		gaps = append(gaps, gap)
@@ -277,15 +321,22 @@ func (a *Analyzer) identifyGaps(
 //
 // Formula:
 //
-//	priority = (importance * 0.50) + (transferability * 0.30) + (acquisition_ease * 0.20)
+//	priority = (importance * w.Importance) + (transferability * w.Transferability) +
+//	           (acquisition_ease * w.AcquisitionEase) + (demand * w.Demand)
 //
-// where acquisition_ease = 1 - (hours / maxHours), clamped to [0, 1].
-func computePriorityScore(importance, transferability float64, learningHours int) float64 {
+// where acquisition_ease = 1 - (hours / maxHours), clamped to [0, 1]. With
+// the default weights this is importance*0.50 + transferability*0.30 +
+// acquisition_ease*0.20.
+func computePriorityScore(w PriorityWeights, importance, transferability float64, learningHours int, demand float64) float64 {
+	return clamp01(basePriorityScore(w, importance, transferability, learningHours) + demand*w.Demand)
+}
+
+// basePriorityScore is the priority score without its demand component.
+func basePriorityScore(w PriorityWeights, importance, transferability float64, learningHours int) float64 {
 	acquisitionEase := 1.0 - math.Min(1.0, float64(learningHours)/maxLearningHoursForNormalization)
-	score := importance*weightImportance +
-		transferability*weightTransferability +
-		acquisitionEase*weightAcquisitionEase
-	return math.Min(1.0, math.Max(0.0, score))
+	return importance*w.Importance +
+		transferability*w.Transferability +
+		acquisitionEase*w.AcquisitionEase
 }
 
 // categoryImportanceScore returns the importance score for a gap category.
@@ -308,18 +359,18 @@ func categoryImportanceScore(category GapCategory) float64 {
 
 // findClosestSkill finds the candidate's skill most semantically similar to
 // the target skill. Returns the skill name and similarity score [0, 1].
-func findClosestSkill(targetNorm string, candidateSkills []scorer.CandidateSkill) (string, float64) {
+func findClosestSkill(p SkillMetadataProvider, targetNorm string, candidateSkills []scorer.CandidateSkill) (string, float64) {
 	if len(candidateSkills) == 0 {
 		return "", 0.0
 	}
 
-	targetMeta := getSkillMetadata(targetNorm)
+	targetMeta := metadataFor(p, targetNorm)
 	bestScore := 0.0
 	bestSkill := ""
 
 	for _, cs := range candidateSkills {
 		csNorm := normalizeSkill(cs.Name)
-		sim := computeSemanticSimilarity(targetNorm, csNorm, targetMeta)
+		sim := computeSemanticSimilarity(p, targetNorm, csNorm, targetMeta)
 		if sim > bestScore {
 			bestScore = sim
 			bestSkill = cs.Name
@@ -337,33 +388,33 @@ func findClosestSkill(targetNorm string, candidateSkills []scorer.CandidateSkill
 //  3. Check if the target skill is in the candidate's related skills list → 0.7
 //  4. Check for shared category/domain via metadata → 0.4
 //  5. Use string similarity as a fallback → scaled score
-func computeSemanticSimilarity(targetNorm, candidateNorm string, targetMeta skillMetadata) float64 {
+func computeSemanticSimilarity(p SkillMetadataProvider, targetNorm, candidateNorm string, targetMeta SkillMetadata) float64 {
 	if targetNorm == candidateNorm {
 		return 1.0
 	}
 
 	// Check alias equivalence.
-	if skillsAreAliases(targetNorm, candidateNorm) {
+	if p.SameSkill(targetNorm, candidateNorm) {
 		return 0.95
 	}
 
 	// Check if candidate skill is in target's related skills.
-	for _, rel := range targetMeta.relatedSkills {
-		if normalizeSkill(rel) == candidateNorm || skillsAreAliases(normalizeSkill(rel), candidateNorm) {
+	for _, rel := range targetMeta.RelatedSkills {
+		if normalizeSkill(rel) == candidateNorm || p.SameSkill(normalizeSkill(rel), candidateNorm) {
 			return 0.70
 		}
 	}
 
 	// Check if target is in candidate's related skills.
-	candidateMeta := getSkillMetadata(candidateNorm)
-	for _, rel := range candidateMeta.relatedSkills {
-		if normalizeSkill(rel) == targetNorm || skillsAreAliases(normalizeSkill(rel), targetNorm) {
+	candidateMeta := metadataFor(p, candidateNorm)
+	for _, rel := range candidateMeta.RelatedSkills {
+		if normalizeSkill(rel) == targetNorm || p.SameSkill(normalizeSkill(rel), targetNorm) {
 			return 0.65
 		}
 	}
 
 	// Check shared difficulty/domain as a weak signal.
-	if targetMeta.difficulty == candidateMeta.difficulty && targetMeta.transferability > 0.8 {
+	if targetMeta.Difficulty == candidateMeta.Difficulty && targetMeta.Transferability > 0.8 {
 		return 0.25
 	}
 
@@ -403,7 +454,7 @@ func tokenOverlapSimilarity(a, b string) float64 {
 // adjustLearningHours adjusts the base learning hours based on:
 //   - Semantic similarity to existing skills (higher sim → fewer hours)
 //   - Candidate's current level in the skill (if partial knowledge)
-func adjustLearningHours(baseHours int, simScore float64, currentLevel string) int {
+func adjustLearningHours(scale scorer.ProficiencyScale, baseHours int, simScore float64, currentLevel string) int {
 	hours := float64(baseHours)
 
 	// Reduce hours if candidate has a related skill.
@@ -416,24 +467,42 @@ func adjustLearningHours(baseHours int, simScore float64, currentLevel string) i
 	}
 
 	// Reduce hours if candidate has partial knowledge.
-	switch strings.ToLower(currentLevel) {
-	case "beginner":
-		hours *= 0.70
-	case "intermediate":
-		hours *= 0.40
-	case "advanced":
-		hours *= 0.15
-	}
+	hours *= partialKnowledgeFactor(scale, currentLevel)
 
 	return int(math.Max(1, math.Round(hours)))
 }
 
+// partialKnowledgeFactor returns the share of a skill's learning hours left
+// for a candidate at currentLevel. On the default scale a beginner has 70%
+// left, an intermediate 40% and an advanced candidate 15%; other scales are
+// interpolated by the level's position on the scale.
+func partialKnowledgeFactor(scale scorer.ProficiencyScale, currentLevel string) float64 {
+	rank := scale.Rank(currentLevel)
+	if rank == 0 {
+		return 1
+	}
+	pos := 0.0
+	if n := len(scale.Levels); n > 1 {
+		pos = float64(rank-1) / float64(n-1)
+	}
+	switch {
+	case pos <= 1.0/3:
+		return 0.70 - 0.90*pos
+	case pos <= 2.0/3:
+		return 0.40 - 0.75*(pos-1.0/3)
+	default:
+		return 0.15
+	}
+}
+
 // ─────────────────────────────────────────────────────────────────────────────
 // Recommendations
 // ─────────────────────────────────────────────────────────────────────────────
 
 // buildRecommendations generates actionable recommendations for a skill gap.
-func buildRecommendations(skillName string, meta skillMetadata, simScore float64) []Recommendation {
+// heldCert is the name of a certification the candidate already holds for
+// the skill, or "" if they hold none.
+func buildRecommendations(skillName string, meta SkillMetadata, simScore float64, heldCert string) []Recommendation {
 	var recs []Recommendation
 	priority := 1
 
@@ -443,20 +512,20 @@ func buildRecommendations(skillName string, meta skillMetadata, simScore float64
 			Title:          "Leverage your existing related skills",
 			Description:    "You already have related knowledge. Focus on the differences and new concepts specific to " + skillName + ".",
 			ResourceType:   "documentation",
-			EstimatedHours: int(math.Max(1, float64(meta.baseHours)*0.15)),
+			EstimatedHours: int(math.Max(1, float64(meta.BaseHours)*0.15)),
 			Priority:       priority,
 		})
 		priority++
 	}
 
 	// Primary learning resource based on difficulty.
-	switch meta.difficulty {
+	switch meta.Difficulty {
 	case DifficultyBeginner:
 		recs = append(recs, Recommendation{
 			Title:          "Complete an introductory course on " + skillName,
 			Description:    "Start with a structured beginner course to build foundational knowledge. Platforms like Coursera, Udemy, or official documentation are excellent starting points.",
 			ResourceType:   "course",
-			EstimatedHours: int(math.Max(1, float64(meta.baseHours)*0.40)),
+			EstimatedHours: int(math.Max(1, float64(meta.BaseHours)*0.40)),
 			Priority:       priority,
 		})
 	case DifficultyIntermediate:
@@ -464,7 +533,7 @@ func buildRecommendations(skillName string, meta skillMetadata, simScore float64
 			Title:          "Take an intermediate-level course on " + skillName,
 			Description:    "Enroll in a structured course covering core concepts and practical applications. Look for project-based courses that include hands-on exercises.",
 			ResourceType:   "course",
-			EstimatedHours: int(math.Max(1, float64(meta.baseHours)*0.40)),
+			EstimatedHours: int(math.Max(1, float64(meta.BaseHours)*0.40)),
 			Priority:       priority,
 		})
 	case DifficultyAdvanced, DifficultyExpert:
@@ -472,7 +541,7 @@ func buildRecommendations(skillName string, meta skillMetadata, simScore float64
 			Title:          "Study " + skillName + " through official documentation and advanced resources",
 			Description:    "This is an advanced skill. Start with official documentation, then progress to advanced courses or books. Consider mentorship from an expert.",
 			ResourceType:   "documentation",
-			EstimatedHours: int(math.Max(1, float64(meta.baseHours)*0.30)),
+			EstimatedHours: int(math.Max(1, float64(meta.BaseHours)*0.30)),
 			Priority:       priority,
 		})
 	}
@@ -483,18 +552,27 @@ func buildRecommendations(skillName string, meta skillMetadata, simScore float64
 		Title:          "Build a hands-on project using " + skillName,
 		Description:    "Apply your learning by building a real project. This solidifies understanding and creates portfolio evidence of your skills.",
 		ResourceType:   "project",
-		EstimatedHours: int(math.Max(1, float64(meta.baseHours)*0.35)),
+		EstimatedHours: int(math.Max(1, float64(meta.BaseHours)*0.35)),
 		Priority:       priority,
 	})
 	priority++
 
-	// Certification recommendation for high-transferability skills.
-	if meta.transferability >= 0.85 {
+	// Certification recommendation for high-transferability skills, unless
+	// the candidate already holds one.
+	if heldCert != "" {
+		recs = append(recs, Recommendation{
+			Title:          "Build on your " + heldCert + " certification",
+			Description:    "You already hold " + heldCert + ", which covers " + skillName + ". Highlight it on your resume and refresh the topics it covers rather than pursuing another certification.",
+			ResourceType:   "certification",
+			EstimatedHours: int(math.Max(1, float64(meta.BaseHours)*0.10)),
+			Priority:       priority,
+		})
+	} else if meta.Transferability >= 0.85 {
 		recs = append(recs, Recommendation{
 			Title:          "Obtain a recognized certification in " + skillName,
 			Description:    "A certification validates your skills to employers and demonstrates commitment. Look for industry-recognized certifications.",
 			ResourceType:   "certification",
-			EstimatedHours: int(math.Max(1, float64(meta.baseHours)*0.25)),
+			EstimatedHours: int(math.Max(1, float64(meta.BaseHours)*0.25)),
 			Priority:       priority,
 		})
 	}
@@ -502,33 +580,93 @@ func buildRecommendations(skillName string, meta skillMetadata, simScore float64
 	return recs
 }
 
+// heldCertification returns the name of the first of certs that certifies
+// the skill norm, or "" if none does.
+func heldCertification(p SkillMetadataProvider, norm string, certs []scorer.CertificationEntry) string {
+	for _, c := range certs {
+		for _, skill := range scorer.CertificationSkills(c.Name) {
+			if skill == norm || p.SameSkill(skill, norm) {
+				return c.Name
+			}
+		}
+	}
+	return ""
+}
+
 // ─────────────────────────────────────────────────────────────────────────────
 // Readiness score
 // ─────────────────────────────────────────────────────────────────────────────
 
+// maxReadinessDeduction is the most points a gap of each category deducts
+// from the readiness score.
+var maxReadinessDeduction = map[GapCategory]float64{
+	GapCategoryCritical:   20.0,
+	GapCategoryImportant:  8.0,
+	GapCategoryNiceToHave: 3.0,
+}
+
 // calculateReadinessScore computes a readiness score [0, 100] for the candidate.
 //
 // Algorithm:
 //   - Start at 100.
 //   - Deduct points for each critical gap (weighted by priority score).
-//   - Deduct smaller points for important gaps.
+//   - Deduct smaller points for important gaps, and fewer still for
+//     nice-to-have gaps.
+//   - A skill the candidate has below the target level deducts only the
+//     share of the levels it is missing (see readinessDeduction).
 //   - Clamp to [0, 100].
-func calculateReadinessScore(criticalGaps, importantGaps []SkillGap, job scorer.JobRequirements) float64 {
+func calculateReadinessScore(scale scorer.ProficiencyScale, criticalGaps, importantGaps, niceToHaveGaps []SkillGap, job scorer.JobRequirements) float64 {
 	score := 100.0
-
-	// Each critical gap deducts up to 20 points (scaled by priority).
-	for _, g := range criticalGaps {
-		deduction := g.PriorityScore * 20.0
-		score -= deduction
+	for _, gaps := range [][]SkillGap{criticalGaps, importantGaps, niceToHaveGaps} {
+		for _, g := range gaps {
+			score -= readinessDeduction(scale, g)
+		}
 	}
+	return math.Max(0, math.Min(100, roundTo2(score)))
+}
 
-	// Each important gap deducts up to 8 points.
-	for _, g := range importantGaps {
-		deduction := g.PriorityScore * 8.0
-		score -= deduction
+// readinessDeduction returns the points a gap deducts from the readiness
+// score: the category's maximum scaled by priority, and for a weak match
+// also by the share of levels missing. A beginner in a skill needed at the
+// intermediate level deducts half as much as a candidate without the skill.
+func readinessDeduction(scale scorer.ProficiencyScale, g SkillGap) float64 {
+	deduction := g.PriorityScore * maxReadinessDeduction[g.Category]
+	if g.CurrentLevel == "" {
+		return deduction
+	}
+	target := scale.Rank(g.TargetLevel)
+	if target == 0 {
+		return deduction
 	}
+	return deduction * float64(levelsBelow(scale, g)) / float64(target)
+}
 
-	return math.Max(0, math.Min(100, roundTo2(score)))
+// levelsBelow returns how many levels of scale the candidate's current
+// level in a gap is below its target level.
+func levelsBelow(scale scorer.ProficiencyScale, g SkillGap) int {
+	return max(0, scale.Rank(g.TargetLevel)-scale.Rank(g.CurrentLevel))
+}
+
+// collectWeakMatches returns the gaps for skills the candidate has below
+// the target level as weak matches.
+func collectWeakMatches(scale scorer.ProficiencyScale, gapLists ...[]SkillGap) []WeakMatch {
+	weak := []WeakMatch{}
+	for _, gaps := range gapLists {
+		for _, g := range gaps {
+			if g.CurrentLevel == "" {
+				continue
+			}
+			weak = append(weak, WeakMatch{
+				SkillName:          g.SkillName,
+				Category:           g.Category,
+				CurrentLevel:       g.CurrentLevel,
+				TargetLevel:        g.TargetLevel,
+				LevelsBelow:        levelsBelow(scale, g),
+				ReadinessDeduction: roundTo2(readinessDeduction(scale, g)),
+			})
+		}
+	}
+	return weak
 }
 
 // ─────────────────────────────────────────────────────────────────────────────
@@ -537,9 +675,10 @@ func calculateReadinessScore(criticalGaps, importantGaps []SkillGap, job scorer.
 
 // buildVisualData constructs the visual representation of the gap analysis.
 func buildVisualData(
-	criticalGaps, importantGaps []SkillGap,
+	criticalGaps, importantGaps, niceToHaveGaps []SkillGap,
 	profile scorer.CandidateProfile,
 	job scorer.JobRequirements,
+	weights PriorityWeights,
 ) GapVisualData {
 	// Build radar chart data by skill category.
 	radarData := buildRadarChart(criticalGaps, importantGaps, profile, job)
@@ -548,10 +687,11 @@ func buildVisualData(
 	categorySummary := []CategorySummary{
 		buildCategorySummary("critical", criticalGaps),
 		buildCategorySummary("important", importantGaps),
+		buildCategorySummary("nice_to_have", niceToHaveGaps),
 	}
 
 	// Build learning timeline.
-	timeline := buildLearningTimeline(criticalGaps, importantGaps)
+	timeline := buildLearningTimeline(criticalGaps, importantGaps, niceToHaveGaps, weights)
 
 	return GapVisualData{
 		RadarChart:      radarData,
@@ -649,16 +789,20 @@ func buildCategorySummary(category string, gaps []SkillGap) CategorySummary {
 }
 
 // buildLearningTimeline creates a suggested learning order.
-// Critical gaps come first (sorted by priority), then important gaps.
-func buildLearningTimeline(criticalGaps, importantGaps []SkillGap) []TimelineEntry {
+// Critical gaps come first (sorted by priority), then important gaps, then
+// nice-to-have gaps.
+func buildLearningTimeline(criticalGaps, importantGaps, niceToHaveGaps []SkillGap, weights PriorityWeights) []TimelineEntry {
 	// Combine all gaps, critical first.
-	ordered := append(append([]SkillGap{}, criticalGaps...), importantGaps...)
+	ordered := append(append(append([]SkillGap{}, criticalGaps...), importantGaps...), niceToHaveGaps...)
 
 	var timeline []TimelineEntry
 	cumulative := 0
 	for i, g := range ordered {
 		cumulative += g.EstimatedLearningHours
 		rationale := buildTimelineRationale(g, i)
+		if demandDecidesOrder(weights, g, ordered[i+1:]) {
+			rationale += " It comes earlier because of its high market demand."
+		}
 		timeline = append(timeline, TimelineEntry{
 			Order:           i + 1,
 			SkillName:       g.SkillName,
@@ -679,55 +823,139 @@ func buildTimelineRationale(gap SkillGap, position int) string {
 		}
 		return "Critical skill required for the role. Address this before applying."
 	}
+	if gap.Category == GapCategoryNiceToHave {
+		return "Bonus skill for the role. Pick this up once the required and preferred skills are covered."
+	}
 	if gap.SemanticSimilarityScore >= 0.5 {
 		return "You have related skills that will accelerate learning this preferred skill."
 	}
 	return "Preferred skill that will strengthen your application once critical gaps are addressed."
 }
 
+// demandDecidesOrder reports whether gap is ordered before one of the later
+// gaps of its category only because of market demand: that gap would
+// score higher without the demand component.
+func demandDecidesOrder(w PriorityWeights, gap SkillGap, later []SkillGap) bool {
+	if w.Demand == 0 || gap.DemandScore == 0 {
+		return false
+	}
+	base := basePriorityScore(w, gap.ImportanceScore, gap.TransferabilityScore, gap.EstimatedLearningHours)
+	for _, other := range later {
+		if other.Category != gap.Category {
+			continue
+		}
+		if basePriorityScore(w, other.ImportanceScore, other.TransferabilityScore, other.EstimatedLearningHours) > base {
+			return true
+		}
+	}
+	return false
+}
+
 // ─────────────────────────────────────────────────────────────────────────────
 // Helper functions
 // ─────────────────────────────────────────────────────────────────────────────
 
-// buildCandidateIndex creates a map from normalized skill name to CandidateSkill.
-func buildCandidateIndex(skills []scorer.CandidateSkill) map[string]scorer.CandidateSkill {
+// buildCandidateIndex creates a map from normalized skill name to
+// CandidateSkill. It also returns a warning for each proficiency scale did
+// not take as given.
+func buildCandidateIndex(scale scorer.ProficiencyScale, skills []scorer.CandidateSkill) (map[string]scorer.CandidateSkill, []string) {
 	index := make(map[string]scorer.CandidateSkill, len(skills))
+	var warnings []string
 	for _, s := range skills {
 		norm := normalizeSkill(s.Name)
 		if norm == "" {
 			continue
 		}
+		level := scale.Resolve(s.Proficiency)
+		if level.Warning != "" {
+			warnings = append(warnings, fmt.Sprintf("skill %q: %s", s.Name, level.Warning))
+		}
 		// Keep the highest proficiency if the skill appears multiple times.
-		if existing, ok := index[norm]; !ok || proficiencyRank(s.Proficiency) > proficiencyRank(existing.Proficiency) {
+		if existing, ok := index[norm]; !ok || level.Rank > scale.Rank(existing.Proficiency) {
 			index[norm] = s
 		}
 	}
-	return index
+	return index, warnings
 }
 
-// lookupInIndex checks whether a skill exists in the candidate index.
+// targetLevelWarnings returns a warning for each distinct target level of
+// the job's skills that is not on the analyzer's proficiency scale.
+func (a *Analyzer) targetLevelWarnings(job scorer.JobRequirements) []string {
+	var warnings []string
+	seen := map[string]bool{}
+	for _, skills := range [][]string{job.RequiredSkills, job.PreferredSkills, job.NiceToHaveSkills} {
+		for _, s := range skills {
+			w := a.scale.Resolve(metadataFor(a.metadata, normalizeSkill(s)).TargetLevel).Warning
+			if w != "" && !seen[w] {
+				seen[w] = true
+				warnings = append(warnings, "target level: "+w)
+			}
+		}
+	}
+	return warnings
+}
+
+// skillPresence describes how a candidate's skill compares to a target level.
+type skillPresence int
+
+const (
+	// skillMissing means the candidate does not list the skill.
+	skillMissing skillPresence = iota
+
+	// skillBelowTarget means the candidate lists the skill at a proficiency
+	// below the target level.
+	skillBelowTarget
+
+	// skillAtTarget means the candidate lists the skill at or above the
+	// target level, or without a proficiency.
+	skillAtTarget
+)
+
+// lookupInIndex checks whether a skill exists in the candidate index and
+// whether the candidate's proficiency reaches targetLevel.
 // It tries exact match first, then alias matching.
-func lookupInIndex(norm string, index map[string]scorer.CandidateSkill) (scorer.CandidateSkill, bool) {
-	if s, ok := index[norm]; ok {
-		return s, true
-	}
-	// Alias matching.
-	for candidateNorm, s := range index {
-		if skillsAreAliases(norm, candidateNorm) {
-			return s, true
+func lookupInIndex(p SkillMetadataProvider, scale scorer.ProficiencyScale, norm, targetLevel string, index map[string]scorer.CandidateSkill) (scorer.CandidateSkill, skillPresence) {
+	s, ok := index[norm]
+	if !ok {
+		// Alias matching.
+		for candidateNorm, cs := range index {
+			if p.SameSkill(norm, candidateNorm) {
+				s, ok = cs, true
+				break
+			}
 		}
 	}
-	return scorer.CandidateSkill{}, false
+	if !ok {
+		return scorer.CandidateSkill{}, skillMissing
+	}
+	// A skill listed without a proficiency is assumed to be at target.
+	rank := scale.Rank(s.Proficiency)
+	if rank > 0 && rank < scale.Rank(targetLevel) {
+		return s, skillBelowTarget
+	}
+	return s, skillAtTarget
+}
+
+// withoutSkill returns skills without the entries named name.
+func withoutSkill(skills []scorer.CandidateSkill, name string) []scorer.CandidateSkill {
+	norm := normalizeSkill(name)
+	kept := make([]scorer.CandidateSkill, 0, len(skills))
+	for _, s := range skills {
+		if normalizeSkill(s.Name) != norm {
+			kept = append(kept, s)
+		}
+	}
+	return kept
 }
 
 // collectMatchedSkills returns skills from required and preferred lists that
-// the candidate already has.
-func collectMatchedSkills(required, preferred []string, index map[string]scorer.CandidateSkill) []string {
+// the candidate already has at the target level.
+func collectMatchedSkills(p SkillMetadataProvider, scale scorer.ProficiencyScale, required, preferred []string, index map[string]scorer.CandidateSkill) []string {
 	var matched []string
 	seen := map[string]bool{}
 	for _, s := range append(required, preferred...) {
 		norm := normalizeSkill(s)
-		if _, found := lookupInIndex(norm, index); found && !seen[norm] {
+		if _, presence := lookupInIndex(p, scale, norm, metadataFor(p, norm).TargetLevel, index); presence == skillAtTarget && !seen[norm] {
 			seen[norm] = true
 			matched = append(matched, s)
 		}
@@ -735,10 +963,29 @@ func collectMatchedSkills(required, preferred []string, index map[string]scorer.
 	return matched
 }
 
-// getSkillMetadata returns metadata for a skill, falling back to defaults.
-func getSkillMetadata(norm string) skillMetadata {
+// excludeSkills returns the skills not present in any of the exclude lists,
+// so that a skill listed in several categories is only reported once.
+func excludeSkills(skills []string, exclude ...[]string) []string {
+	excluded := make(map[string]bool)
+	for _, list := range exclude {
+		for _, s := range list {
+			excluded[normalizeSkill(s)] = true
+		}
+	}
+	var kept []string
+	for _, s := range skills {
+		if !excluded[normalizeSkill(s)] {
+			kept = append(kept, s)
+		}
+	}
+	return kept
+}
+
+// lookupBuiltinMetadata returns builtin metadata for a skill, resolving
+// common aliases.
+func lookupBuiltinMetadata(norm string) (SkillMetadata, bool) {
 	if meta, ok := builtinSkillMetadata[norm]; ok {
-		return meta
+		return meta, true
 	}
 	// Try alias resolution.
 	aliases := map[string]string{
@@ -767,10 +1014,10 @@ func getSkillMetadata(norm string) skillMetadata {
 	}
 	if canonical, ok := aliases[norm]; ok {
 		if meta, ok := builtinSkillMetadata[canonical]; ok {
-			return meta
+			return meta, true
 		}
 	}
-	return defaultSkillMetadata
+	return SkillMetadata{}, false
 }
 
 // skillsAreAliases returns true if two normalized skill names are equivalent.
@@ -822,22 +1069,6 @@ func normalizeSkill(s string) string {
 	return strings.TrimSpace(strings.ToLower(s))
 }
 
-// proficiencyRank returns a numeric rank for a proficiency level.
-func proficiencyRank(p string) int {
-	switch strings.ToLower(p) {
-	case "beginner":
-		return 1
-	case "intermediate":
-		return 2
-	case "advanced":
-		return 3
-	case "expert":
-		return 4
-	default:
-		return 0
-	}
-}
-
 // sortGapsByPriority sorts gaps by PriorityScore descending.
 func sortGapsByPriority(gaps []SkillGap) {
 	sort.Slice(gaps, func(i, j int) bool {
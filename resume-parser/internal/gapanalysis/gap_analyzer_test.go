@@ -2,9 +2,13 @@ package gapanalysis
 
 import (
 	"math"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/learnbot/resume-parser/internal/scorer"
+	"github.com/learnbot/shared/metrics"
 )
 
 // ─────────────────────────────────────────────────────────────────────────────
@@ -130,6 +134,127 @@ func TestAnalyze_PartialSkillMatch(t *testing.T) {
 	}
 }
 
+func TestAnalyze_SkillBelowTargetLevel(t *testing.T) {
+	job := scorer.JobRequirements{
+		RequiredSkills: []string{"Kubernetes"},
+	}
+	beginner := scorer.CandidateProfile{
+		Skills: []scorer.CandidateSkill{{Name: "Kubernetes", Proficiency: "beginner"}},
+	}
+
+	withSkill := newAnalyzer().Analyze(beginner, job)
+	without := newAnalyzer().Analyze(scorer.CandidateProfile{}, job)
+
+	gap, ok := findGap(withSkill.CriticalGaps, "Kubernetes")
+	if !ok {
+		t.Fatal("expected a Kubernetes gap for a beginner below the target level")
+	}
+	if gap.CurrentLevel != "beginner" {
+		t.Errorf("CurrentLevel = %q, want beginner", gap.CurrentLevel)
+	}
+	if gap.ClosestExistingSkill == "Kubernetes" {
+		t.Error("the skill itself should not count as its closest existing skill")
+	}
+	if containsSkill(withSkill.MatchedSkills, "Kubernetes") {
+		t.Error("a skill below the target level should not be matched")
+	}
+
+	missing, _ := findGap(without.CriticalGaps, "Kubernetes")
+	if gap.EstimatedLearningHours >= missing.EstimatedLearningHours {
+		t.Errorf("beginner should need fewer hours than no knowledge: beginner=%d, none=%d",
+			gap.EstimatedLearningHours, missing.EstimatedLearningHours)
+	}
+}
+
+func TestAnalyze_SkillWithoutProficiencyIsMatched(t *testing.T) {
+	profile := scorer.CandidateProfile{
+		Skills: []scorer.CandidateSkill{{Name: "k8s"}},
+	}
+	job := scorer.JobRequirements{RequiredSkills: []string{"Kubernetes"}}
+
+	result := newAnalyzer().Analyze(profile, job)
+
+	if len(result.CriticalGaps) != 0 {
+		t.Errorf("expected no gaps when proficiency is unknown, got %v", result.CriticalGaps)
+	}
+}
+
+func TestAnalyze_ProficiencyScale(t *testing.T) {
+	scale := scorer.ProficiencyScale{
+		Levels: []scorer.ProficiencyLevel{
+			{Name: "novice", Weight: 0.3},
+			{Name: "beginner", Weight: 0.5},
+			{Name: "intermediate", Weight: 0.7},
+			{Name: "advanced", Weight: 0.85},
+			{Name: "expert", Weight: 1.0},
+		},
+		UnspecifiedWeight: 0.6,
+		DefaultLevel:      "novice",
+	}
+	a := New(WithProficiencyScale(scale))
+	job := scorer.JobRequirements{RequiredSkills: []string{"Kubernetes"}}
+	analyze := func(proficiency string) GapAnalysisResult {
+		return a.Analyze(scorer.CandidateProfile{
+			Skills: []scorer.CandidateSkill{{Name: "Kubernetes", Proficiency: proficiency}},
+		}, job)
+	}
+
+	// Kubernetes is needed at the intermediate level, third on the scale.
+	tests := []struct {
+		proficiency string
+		current     string
+		levelsBelow int
+	}{
+		{"novice", "novice", 2},
+		{"Guru", "novice", 2},
+		{"beginner", "beginner", 1},
+		{"3", "beginner", 1},
+		{"intermediate", "", 0},
+		{"8", "", 0},
+	}
+	prevReadiness := -1.0
+	for _, tt := range tests {
+		result := analyze(tt.proficiency)
+		if tt.current == "" {
+			if len(result.WeakMatches) != 0 || !containsSkill(result.MatchedSkills, "Kubernetes") {
+				t.Errorf("%s: expected Kubernetes to be matched, got %+v", tt.proficiency, result.WeakMatches)
+			}
+			continue
+		}
+		if len(result.WeakMatches) != 1 || result.WeakMatches[0].CurrentLevel != tt.current ||
+			result.WeakMatches[0].LevelsBelow != tt.levelsBelow {
+			t.Errorf("%s: expected a weak match at %s, %d levels below, got %+v",
+				tt.proficiency, tt.current, tt.levelsBelow, result.WeakMatches)
+		}
+		if result.ReadinessScore < prevReadiness {
+			t.Errorf("%s: readiness %.2f below that of a lower level (%.2f)", tt.proficiency, result.ReadinessScore, prevReadiness)
+		}
+		prevReadiness = result.ReadinessScore
+	}
+
+	guru := analyze("Guru")
+	if len(guru.Warnings) != 1 || !strings.Contains(guru.Warnings[0], `"Kubernetes"`) {
+		t.Errorf("expected a warning for the unknown level, got %v", guru.Warnings)
+	}
+	if beginner := analyze("beginner"); len(beginner.Warnings) != 0 {
+		t.Errorf("unexpected warnings: %v", beginner.Warnings)
+	}
+
+	// A scale without the target level ranks it as its default level.
+	coarse := New(WithProficiencyScale(scorer.ProficiencyScale{
+		Levels: []scorer.ProficiencyLevel{{Name: "low", Weight: 0.4}, {Name: "high", Weight: 1}},
+	}))
+	result := coarse.Analyze(scorer.CandidateProfile{
+		Skills: []scorer.CandidateSkill{{Name: "Kubernetes", Proficiency: "high"}},
+	}, scorer.JobRequirements{RequiredSkills: []string{"Kubernetes", "Docker"}})
+	if !containsSkill(result.MatchedSkills, "Kubernetes") {
+		t.Errorf("expected Kubernetes to be matched, got %+v", result.CriticalGaps)
+	}
+	if len(result.Warnings) != 1 || !strings.HasPrefix(result.Warnings[0], "target level: ") {
+		t.Errorf("expected one target level warning, got %v", result.Warnings)
+	}
+}
+
 func TestAnalyze_MatchedSkillsPopulated(t *testing.T) {
 	profile := scorer.CandidateProfile{
 		Skills: []scorer.CandidateSkill{
@@ -521,8 +646,8 @@ func TestAnalyze_TotalLearningHoursIsSum(t *testing.T) {
 
 func TestAdjustLearningHours_HighSimilarityReducesHours(t *testing.T) {
 	base := 100
-	hoursNoSim := adjustLearningHours(base, 0.0, "")
-	hoursHighSim := adjustLearningHours(base, 0.9, "")
+	hoursNoSim := adjustLearningHours(scorer.DefaultProficiencyScale(), base, 0.0, "")
+	hoursHighSim := adjustLearningHours(scorer.DefaultProficiencyScale(), base, 0.9, "")
 
 	if hoursHighSim >= hoursNoSim {
 		t.Errorf("high similarity should reduce hours: highSim=%d, noSim=%d",
@@ -532,10 +657,10 @@ func TestAdjustLearningHours_HighSimilarityReducesHours(t *testing.T) {
 
 func TestAdjustLearningHours_CurrentLevelReducesHours(t *testing.T) {
 	base := 100
-	hoursNone := adjustLearningHours(base, 0.0, "")
-	hoursBeginner := adjustLearningHours(base, 0.0, "beginner")
-	hoursIntermediate := adjustLearningHours(base, 0.0, "intermediate")
-	hoursAdvanced := adjustLearningHours(base, 0.0, "advanced")
+	hoursNone := adjustLearningHours(scorer.DefaultProficiencyScale(), base, 0.0, "")
+	hoursBeginner := adjustLearningHours(scorer.DefaultProficiencyScale(), base, 0.0, "beginner")
+	hoursIntermediate := adjustLearningHours(scorer.DefaultProficiencyScale(), base, 0.0, "intermediate")
+	hoursAdvanced := adjustLearningHours(scorer.DefaultProficiencyScale(), base, 0.0, "advanced")
 
 	if hoursBeginner >= hoursNone {
 		t.Errorf("beginner level should reduce hours: beginner=%d, none=%d",
@@ -562,7 +687,7 @@ func TestAdjustLearningHours_AlwaysPositive(t *testing.T) {
 		{0, 0.0, ""},
 	}
 	for _, c := range cases {
-		h := adjustLearningHours(c.base, c.sim, c.level)
+		h := adjustLearningHours(scorer.DefaultProficiencyScale(), c.base, c.sim, c.level)
 		if h <= 0 {
 			t.Errorf("adjustLearningHours(%d, %.1f, %q) = %d, want > 0",
 				c.base, c.sim, c.level, h)
@@ -656,6 +781,59 @@ func TestAnalyze_PerfectMatchReadinessScore100(t *testing.T) {
 	}
 }
 
+func TestAnalyze_BeginnerInEverySkillLowersReadiness(t *testing.T) {
+	job := scorer.JobRequirements{RequiredSkills: []string{"Go", "Python", "SQL", "Docker"}}
+	beginner := scorer.CandidateProfile{}
+	for _, s := range job.RequiredSkills {
+		beginner.Skills = append(beginner.Skills, scorer.CandidateSkill{Name: s, Proficiency: "beginner"})
+	}
+
+	weak := newAnalyzer().Analyze(beginner, job)
+	missing := newAnalyzer().Analyze(scorer.CandidateProfile{}, job)
+
+	if weak.ReadinessScore > 75 {
+		t.Errorf("expected beginner-everything readiness well below 100, got %.2f", weak.ReadinessScore)
+	}
+	if weak.ReadinessScore <= missing.ReadinessScore {
+		t.Errorf("beginner readiness %.2f should beat no skills at all %.2f", weak.ReadinessScore, missing.ReadinessScore)
+	}
+	if len(weak.WeakMatches) != len(job.RequiredSkills) {
+		t.Fatalf("expected %d weak matches, got %+v", len(job.RequiredSkills), weak.WeakMatches)
+	}
+	var deducted float64
+	for _, m := range weak.WeakMatches {
+		if m.Category != GapCategoryCritical || m.CurrentLevel != "beginner" || m.LevelsBelow != 1 {
+			t.Errorf("unexpected weak match %+v", m)
+		}
+		deducted += m.ReadinessDeduction
+	}
+	if !approxEqual(100-deducted, weak.ReadinessScore, 0.05) {
+		t.Errorf("weak match deductions %.2f do not explain readiness %.2f", deducted, weak.ReadinessScore)
+	}
+	if len(missing.WeakMatches) != 0 {
+		t.Errorf("expected no weak matches for missing skills, got %+v", missing.WeakMatches)
+	}
+}
+
+func TestReadinessDeduction_ScalesWithLevelsBelow(t *testing.T) {
+	gap := SkillGap{Category: GapCategoryCritical, PriorityScore: 0.5, TargetLevel: "expert"}
+	tests := []struct {
+		current string
+		want    float64
+	}{
+		{"", 10},
+		{"beginner", 7.5},
+		{"intermediate", 5},
+		{"advanced", 2.5},
+	}
+	for _, tt := range tests {
+		gap.CurrentLevel = tt.current
+		if got := readinessDeduction(scorer.DefaultProficiencyScale(), gap); !approxEqual(got, tt.want, 1e-9) {
+			t.Errorf("readinessDeduction(current=%q) = %.4f, want %.4f", tt.current, got, tt.want)
+		}
+	}
+}
+
 // ─────────────────────────────────────────────────────────────────────────────
 // Recommendations
 // ─────────────────────────────────────────────────────────────────────────────
@@ -734,6 +912,32 @@ func TestAnalyze_RelatedSkillAddsLeverageRecommendation(t *testing.T) {
 	}
 }
 
+func TestAnalyze_HeldCertificationReplacesCertificationRecommendation(t *testing.T) {
+	job := scorer.JobRequirements{RequiredSkills: []string{"Kubernetes"}}
+	certified := scorer.CandidateProfile{
+		Certifications: []scorer.CertificationEntry{{Name: "Certified Kubernetes Administrator (CKA)"}},
+	}
+
+	var plain, held *Recommendation
+	for _, rec := range newAnalyzer().Analyze(scorer.CandidateProfile{}, job).CriticalGaps[0].Recommendations {
+		if rec.ResourceType == "certification" {
+			plain = &rec
+		}
+	}
+	for _, rec := range newAnalyzer().Analyze(certified, job).CriticalGaps[0].Recommendations {
+		if rec.ResourceType == "certification" {
+			held = &rec
+		}
+	}
+
+	if plain == nil || !strings.HasPrefix(plain.Title, "Obtain") {
+		t.Fatalf("expected a recommendation to obtain a certification, got %+v", plain)
+	}
+	if held == nil || !strings.Contains(held.Description, "You already hold Certified Kubernetes Administrator (CKA)") {
+		t.Errorf("expected the recommendation to mention the held certification, got %+v", held)
+	}
+}
+
 // ─────────────────────────────────────────────────────────────────────────────
 // Visual data
 // ─────────────────────────────────────────────────────────────────────────────
@@ -890,7 +1094,7 @@ func TestComputePriorityScore_Formula(t *testing.T) {
 	hours := 100 // ease = 1 - 100/500 = 0.8
 
 	expected := importance*0.50 + transferability*0.30 + 0.8*0.20
-	got := computePriorityScore(importance, transferability, hours)
+	got := computePriorityScore(DefaultPriorityWeights(), importance, transferability, hours, 0)
 
 	if !approxEqual(got, expected, 0.001) {
 		t.Errorf("expected priority score %.4f, got %.4f", expected, got)
@@ -898,14 +1102,14 @@ func TestComputePriorityScore_Formula(t *testing.T) {
 }
 
 func TestComputePriorityScore_ClampedToOne(t *testing.T) {
-	score := computePriorityScore(1.0, 1.0, 0)
+	score := computePriorityScore(DefaultPriorityWeights(), 1.0, 1.0, 0, 0)
 	if score > 1.0 {
 		t.Errorf("priority score should be clamped to 1.0, got %.4f", score)
 	}
 }
 
 func TestComputePriorityScore_ClampedToZero(t *testing.T) {
-	score := computePriorityScore(0.0, 0.0, int(maxLearningHoursForNormalization)*10)
+	score := computePriorityScore(DefaultPriorityWeights(), 0.0, 0.0, int(maxLearningHoursForNormalization)*10, 0)
 	if score < 0.0 {
 		t.Errorf("priority score should be clamped to 0.0, got %.4f", score)
 	}
@@ -913,8 +1117,8 @@ func TestComputePriorityScore_ClampedToZero(t *testing.T) {
 
 func TestComputePriorityScore_HighHoursReducesPriority(t *testing.T) {
 	// Same importance and transferability, but different hours.
-	scoreEasy := computePriorityScore(0.8, 0.8, 10)
-	scoreHard := computePriorityScore(0.8, 0.8, 400)
+	scoreEasy := computePriorityScore(DefaultPriorityWeights(), 0.8, 0.8, 10, 0)
+	scoreHard := computePriorityScore(DefaultPriorityWeights(), 0.8, 0.8, 400, 0)
 
 	if scoreEasy <= scoreHard {
 		t.Errorf("easy skill (10h) should have higher priority than hard skill (400h): easy=%.4f, hard=%.4f",
@@ -927,31 +1131,31 @@ func TestComputePriorityScore_HighHoursReducesPriority(t *testing.T) {
 // ─────────────────────────────────────────────────────────────────────────────
 
 func TestGetSkillMetadata_KnownSkill(t *testing.T) {
-	meta := getSkillMetadata("python")
-	if meta.baseHours <= 0 {
+	meta := metadataFor(BuiltinMetadataProvider{}, "python")
+	if meta.BaseHours <= 0 {
 		t.Error("expected positive base hours for python")
 	}
-	if meta.transferability <= 0 {
+	if meta.Transferability <= 0 {
 		t.Error("expected positive transferability for python")
 	}
 }
 
 func TestGetSkillMetadata_AliasResolution(t *testing.T) {
 	// "golang" should resolve to "go" metadata.
-	metaGolang := getSkillMetadata("golang")
-	metaGo := getSkillMetadata("go")
+	metaGolang := metadataFor(BuiltinMetadataProvider{}, "golang")
+	metaGo := metadataFor(BuiltinMetadataProvider{}, "go")
 
-	if metaGolang.baseHours != metaGo.baseHours {
+	if metaGolang.BaseHours != metaGo.BaseHours {
 		t.Errorf("golang and go should have same metadata: golang=%d, go=%d",
-			metaGolang.baseHours, metaGo.baseHours)
+			metaGolang.BaseHours, metaGo.BaseHours)
 	}
 }
 
 func TestGetSkillMetadata_UnknownSkillUsesDefault(t *testing.T) {
-	meta := getSkillMetadata("some_very_obscure_skill_xyz_123")
-	if meta.baseHours != defaultSkillMetadata.baseHours {
+	meta := metadataFor(BuiltinMetadataProvider{}, "some_very_obscure_skill_xyz_123")
+	if meta.BaseHours != defaultSkillMetadata.BaseHours {
 		t.Errorf("unknown skill should use default hours: got %d, want %d",
-			meta.baseHours, defaultSkillMetadata.baseHours)
+			meta.BaseHours, defaultSkillMetadata.BaseHours)
 	}
 }
 
@@ -960,33 +1164,33 @@ func TestGetSkillMetadata_UnknownSkillUsesDefault(t *testing.T) {
 // ─────────────────────────────────────────────────────────────────────────────
 
 func TestComputeSemanticSimilarity_SameSkill(t *testing.T) {
-	meta := getSkillMetadata("python")
-	sim := computeSemanticSimilarity("python", "python", meta)
+	meta := metadataFor(BuiltinMetadataProvider{}, "python")
+	sim := computeSemanticSimilarity(BuiltinMetadataProvider{}, "python", "python", meta)
 	if sim != 1.0 {
 		t.Errorf("same skill should have similarity=1.0, got %.4f", sim)
 	}
 }
 
 func TestComputeSemanticSimilarity_AliasSkill(t *testing.T) {
-	meta := getSkillMetadata("go")
-	sim := computeSemanticSimilarity("go", "golang", meta)
+	meta := metadataFor(BuiltinMetadataProvider{}, "go")
+	sim := computeSemanticSimilarity(BuiltinMetadataProvider{}, "go", "golang", meta)
 	if sim < 0.9 {
 		t.Errorf("alias skills should have high similarity: got %.4f", sim)
 	}
 }
 
 func TestComputeSemanticSimilarity_RelatedSkill(t *testing.T) {
-	meta := getSkillMetadata("pytorch")
+	meta := metadataFor(BuiltinMetadataProvider{}, "pytorch")
 	// TensorFlow is in PyTorch's related skills.
-	sim := computeSemanticSimilarity("pytorch", "tensorflow", meta)
+	sim := computeSemanticSimilarity(BuiltinMetadataProvider{}, "pytorch", "tensorflow", meta)
 	if sim < 0.5 {
 		t.Errorf("related skills should have moderate similarity: got %.4f", sim)
 	}
 }
 
 func TestComputeSemanticSimilarity_UnrelatedSkill(t *testing.T) {
-	meta := getSkillMetadata("python")
-	sim := computeSemanticSimilarity("python", "excel", meta)
+	meta := metadataFor(BuiltinMetadataProvider{}, "python")
+	sim := computeSemanticSimilarity(BuiltinMetadataProvider{}, "python", "excel", meta)
 	if sim > 0.5 {
 		t.Errorf("unrelated skills should have low similarity: got %.4f", sim)
 	}
@@ -1001,8 +1205,8 @@ func TestComputeSemanticSimilarity_InRange(t *testing.T) {
 		{"python", "excel"},
 	}
 	for _, pair := range pairs {
-		meta := getSkillMetadata(pair[0])
-		sim := computeSemanticSimilarity(pair[0], pair[1], meta)
+		meta := metadataFor(BuiltinMetadataProvider{}, pair[0])
+		sim := computeSemanticSimilarity(BuiltinMetadataProvider{}, pair[0], pair[1], meta)
 		if sim < 0 || sim > 1 {
 			t.Errorf("similarity(%q, %q) = %.4f, want in [0,1]", pair[0], pair[1], sim)
 		}
@@ -1053,3 +1257,128 @@ func TestAnalyze_NoDuplicateGaps(t *testing.T) {
 		seen[g.SkillName] = true
 	}
 }
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Nice-to-have gaps
+// ─────────────────────────────────────────────────────────────────────────────
+
+// niceToHaveJob lists gaps in all three categories.
+var niceToHaveJob = scorer.JobRequirements{
+	RequiredSkills:   []string{"Python", "Go"},
+	PreferredSkills:  []string{"Docker"},
+	NiceToHaveSkills: []string{"Kafka", "GraphQL", "Docker"},
+}
+
+func TestAnalyze_NiceToHaveGapCounts(t *testing.T) {
+	result := newAnalyzer().Analyze(scorer.CandidateProfile{}, niceToHaveJob)
+
+	// Docker is already an important gap and is not repeated.
+	if result.NiceToHaveGapCount != 2 || len(result.NiceToHaveGaps) != 2 {
+		t.Fatalf("expected 2 nice-to-have gaps, got count=%d len=%d",
+			result.NiceToHaveGapCount, len(result.NiceToHaveGaps))
+	}
+	if containsGap(result.NiceToHaveGaps, "Docker") {
+		t.Error("Docker should only be reported as an important gap")
+	}
+	for _, g := range result.NiceToHaveGaps {
+		if g.Category != GapCategoryNiceToHave {
+			t.Errorf("gap %q: category = %q, want %q", g.SkillName, g.Category, GapCategoryNiceToHave)
+		}
+		if g.ImportanceScore != importanceScoreNiceToHave {
+			t.Errorf("gap %q: importance = %.2f, want %.2f", g.SkillName, g.ImportanceScore, importanceScoreNiceToHave)
+		}
+	}
+	if result.TotalGaps != 5 {
+		t.Errorf("TotalGaps = %d, want 5 (2 critical + 1 important + 2 nice-to-have)", result.TotalGaps)
+	}
+	if got := sumLearningHours(result.CriticalGaps) + sumLearningHours(result.ImportantGaps) +
+		sumLearningHours(result.NiceToHaveGaps); result.TotalEstimatedLearningHours != got {
+		t.Errorf("TotalEstimatedLearningHours = %d, want %d", result.TotalEstimatedLearningHours, got)
+	}
+}
+
+func TestAnalyze_NiceToHaveMatchedSkillNotAGap(t *testing.T) {
+	profile := scorer.CandidateProfile{Skills: []scorer.CandidateSkill{{Name: "Kafka"}}}
+	result := newAnalyzer().Analyze(profile, niceToHaveJob)
+
+	if containsGap(result.NiceToHaveGaps, "Kafka") {
+		t.Error("Kafka should not be a gap when the candidate has it")
+	}
+	if result.NiceToHaveGapCount != 1 {
+		t.Errorf("expected 1 nice-to-have gap, got %d", result.NiceToHaveGapCount)
+	}
+}
+
+func TestAnalyze_NiceToHaveTimelineAfterImportant(t *testing.T) {
+	result := newAnalyzer().Analyze(scorer.CandidateProfile{}, niceToHaveJob)
+
+	timeline := result.VisualData.LearningTimeline
+	if len(timeline) != 5 {
+		t.Fatalf("expected 5 timeline entries, got %d", len(timeline))
+	}
+	want := []GapCategory{
+		GapCategoryCritical, GapCategoryCritical, GapCategoryImportant,
+		GapCategoryNiceToHave, GapCategoryNiceToHave,
+	}
+	for i, entry := range timeline {
+		if entry.Category != string(want[i]) {
+			t.Errorf("timeline[%d] category = %q, want %q", i, entry.Category, want[i])
+		}
+	}
+}
+
+func TestAnalyze_NiceToHaveReadinessDeduction(t *testing.T) {
+	without := niceToHaveJob
+	without.NiceToHaveSkills = nil
+
+	base := newAnalyzer().Analyze(scorer.CandidateProfile{}, without)
+	result := newAnalyzer().Analyze(scorer.CandidateProfile{}, niceToHaveJob)
+
+	deduction := base.ReadinessScore - result.ReadinessScore
+	if deduction <= 0 {
+		t.Errorf("expected nice-to-have gaps to lower readiness: %.2f vs %.2f", result.ReadinessScore, base.ReadinessScore)
+	}
+	if deduction > 3.0*float64(result.NiceToHaveGapCount) {
+		t.Errorf("deduction %.2f exceeds 3 points per nice-to-have gap", deduction)
+	}
+}
+
+func TestAnalyze_NiceToHaveCategorySummary(t *testing.T) {
+	result := newAnalyzer().Analyze(scorer.CandidateProfile{}, niceToHaveJob)
+
+	for _, summary := range result.VisualData.GapsByCategory {
+		if summary.Category == "nice_to_have" {
+			if summary.GapCount != 2 {
+				t.Errorf("nice_to_have GapCount = %d, want 2", summary.GapCount)
+			}
+			if summary.TotalLearningHours != sumLearningHours(result.NiceToHaveGaps) {
+				t.Errorf("nice_to_have TotalLearningHours = %d, want %d",
+					summary.TotalLearningHours, sumLearningHours(result.NiceToHaveGaps))
+			}
+			return
+		}
+	}
+	t.Error("expected a 'nice_to_have' category in GapsByCategory")
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Metrics
+// ─────────────────────────────────────────────────────────────────────────────
+
+func TestAnalyze_CountsInvocations(t *testing.T) {
+	before := analysisInvocations.Value()
+	a := newAnalyzer()
+	job := scorer.JobRequirements{RequiredSkills: []string{"Go"}}
+	a.Analyze(scorer.CandidateProfile{}, job)
+	a.Analyze(scorer.CandidateProfile{}, job)
+
+	if got := analysisInvocations.Value() - before; got != 2 {
+		t.Errorf("expected 2 invocations, got %v", got)
+	}
+
+	w := httptest.NewRecorder()
+	metrics.Handler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	if !strings.Contains(w.Body.String(), "gap_analysis_invocations_total ") {
+		t.Errorf("metrics output missing gap_analysis_invocations_total:\n%s", w.Body.String())
+	}
+}
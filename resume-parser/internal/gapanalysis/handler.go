@@ -3,31 +3,121 @@
 package gapanalysis
 
 import (
+	"bytes"
 	"encoding/json"
+	"fmt"
 	"log"
 	"net/http"
 	"time"
+
+	"github.com/learnbot/resume-parser/internal/report"
+	"github.com/learnbot/resume-parser/internal/resultcache"
+	"github.com/learnbot/resume-parser/internal/scorer"
+	"github.com/learnbot/resume-parser/internal/taxonomy"
+	"github.com/learnbot/shared/openapi"
+	"github.com/learnbot/shared/validation"
 )
 
+// DefaultMaxBatchJobs is the default maximum number of jobs accepted by a
+// single batch gap analysis request.
+const DefaultMaxBatchJobs = 25
+
 // Handler holds the HTTP handler dependencies for the gap analysis API.
 type Handler struct {
-	analyzer *Analyzer
-	logger   *log.Logger
+	analyzer     *Analyzer
+	logger       *log.Logger
+	extract      scorer.RequirementsExtractor
+	cache        *resultcache.Cache[GapAnalysisResult]
+	validation   validation.Config
+	maxBatchJobs int
 }
 
 // NewHandler creates a new gap analysis Handler.
 func NewHandler(logger *log.Logger) *Handler {
 	return &Handler{
-		analyzer: New(),
-		logger:   logger,
+		analyzer:     New(),
+		logger:       logger,
+		maxBatchJobs: DefaultMaxBatchJobs,
+	}
+}
+
+// SetMaxBatchJobs sets the maximum number of jobs accepted by
+// BatchHandler. Values below 1 restore DefaultMaxBatchJobs.
+func (h *Handler) SetMaxBatchJobs(n int) {
+	if n < 1 {
+		n = DefaultMaxBatchJobs
+	}
+	h.maxBatchJobs = n
+}
+
+// SetFuzzySkillMatching sets whether the analyses match misspelled skills,
+// such as "Kubernates", to the skills they were meant as. Enabling it reads
+// skill metadata from the taxonomy ontology, which fuzzy matching needs.
+func (h *Handler) SetFuzzySkillMatching(enabled bool) {
+	if !enabled {
+		h.analyzer = New()
+		return
 	}
+	p := NewTaxonomyMetadataProvider(taxonomy.New())
+	p.SetFuzzyMatching(true)
+	h.analyzer = New(WithSkillMetadataProvider(p))
+}
+
+// SetRequirementsExtractor enables the job_description request field,
+// using extract to turn the description into job requirements.
+func (h *Handler) SetRequirementsExtractor(extract scorer.RequirementsExtractor) {
+	h.extract = extract
+}
+
+// SetResultCache makes the handlers reuse the analyses of recent identical
+// requests from c. A nil cache disables caching.
+func (h *Handler) SetResultCache(c *resultcache.Cache[GapAnalysisResult]) {
+	h.cache = c
+}
+
+// SetValidationConfig sets how request bodies are decoded. By default
+// unknown fields are rejected.
+func (h *Handler) SetValidationConfig(cfg validation.Config) {
+	h.validation = cfg
 }
 
 // RegisterRoutes registers the gap analysis routes on the given mux.
 //
-//	POST /api/v1/gap-analysis  – perform skill gap analysis
-func (h *Handler) RegisterRoutes(mux *http.ServeMux) {
+//	POST /api/v1/gap-analysis         – perform skill gap analysis
+//	POST /api/v1/gap-analysis/batch   – analyze one candidate against several jobs
+//	POST /api/v1/gap-analysis/report  – render the analysis as a PDF or HTML report
+func (h *Handler) RegisterRoutes(mux openapi.Router) {
 	mux.HandleFunc("/api/v1/gap-analysis", h.withMiddleware(h.GapAnalysisHandler))
+	mux.HandleFunc("/api/v1/gap-analysis/batch", h.withMiddleware(h.BatchHandler))
+	mux.HandleFunc("/api/v1/gap-analysis/report", h.withMiddleware(h.ReportHandler))
+}
+
+// DescribeRoutes documents the routes registered by RegisterRoutes.
+func (h *Handler) DescribeRoutes(spec *openapi.Spec) {
+	spec.Route("/api/v1/gap-analysis", openapi.Operation{
+		Method:   http.MethodPost,
+		Summary:  "Analyze a candidate's skill gaps for a job",
+		Params:   []openapi.Param{resultcache.NoCacheParam},
+		Request:  GapAnalysisRequest{},
+		Response: GapAnalysisResponse{},
+		Errors:   []int{http.StatusBadRequest, http.StatusUnsupportedMediaType, http.StatusUnprocessableEntity},
+	})
+	spec.Route("/api/v1/gap-analysis/batch", openapi.Operation{
+		Method:   http.MethodPost,
+		Summary:  "Compare a candidate's skill gaps across several jobs",
+		Request:  BatchGapAnalysisRequest{},
+		Response: BatchGapAnalysisResponse{},
+		Errors:   []int{http.StatusBadRequest, http.StatusUnsupportedMediaType, http.StatusUnprocessableEntity},
+	})
+	spec.Route("/api/v1/gap-analysis/report", openapi.Operation{
+		Method:       http.MethodPost,
+		Summary:      "Render a gap analysis as a printable report",
+		Params:       []openapi.Param{openapi.Query("format", `"pdf" (default) or "html"`), resultcache.NoCacheParam},
+		Request:      GapAnalysisRequest{},
+		Response:     openapi.File{},
+		ResponseType: report.FormatPDF.ContentType(),
+		Errors:       []int{http.StatusBadRequest, http.StatusUnsupportedMediaType, http.StatusUnprocessableEntity},
+	})
 }
 
 // withMiddleware wraps a handler with logging and panic recovery.
@@ -84,30 +174,197 @@ func (h *Handler) GapAnalysisHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if ct := r.Header.Get("Content-Type"); ct != "" &&
-		len(ct) >= 16 && ct[:16] != "application/json" {
-		h.writeError(w, http.StatusUnsupportedMediaType,
-			"Content-Type must be application/json")
+	req, ok := h.decodeRequest(w, r)
+	if !ok {
 		return
 	}
 
-	var req GapAnalysisRequest
-	dec := json.NewDecoder(r.Body)
-	dec.DisallowUnknownFields()
-	if err := dec.Decode(&req); err != nil {
-		h.writeError(w, http.StatusBadRequest,
-			"invalid request body: "+err.Error())
+	result := h.analyze(w, r, req)
+
+	h.writeJSON(w, http.StatusOK, GapAnalysisResponse{
+		Success: true,
+		Data:    &result,
+	})
+}
+
+// BatchHandler handles POST /api/v1/gap-analysis/batch.
+//
+// Request body (JSON):
+//
+//	{
+//	  "profile": { ... CandidateProfile ... },
+//	  "jobs": [
+//	    { "job_id": "j-1", "job": { ... JobRequirements ... } }
+//	  ],
+//	  "min_shared_jobs": 2
+//	}
+//
+// Response body (JSON), one result per job in request order:
+//
+//	{
+//	  "success": true,
+//	  "data": {
+//	    "jobs":    [ { "index": 0, "job_id": "j-1", "rank": 1, "analysis": { ... } } ],
+//	    "summary": { "jobs_by_readiness": [0], "shared_gaps": [ ... ], ... }
+//	  }
+//	}
+//
+// Requests with no jobs, or with more than the configured maximum, are
+// rejected with 422.
+func (h *Handler) BatchHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		h.writeError(w, http.StatusMethodNotAllowed,
+			"only POST is supported")
 		return
 	}
 
-	result := h.analyzer.Analyze(req.Profile, req.Job)
+	var req BatchGapAnalysisRequest
+	if !h.decodeBody(w, r, &req) {
+		return
+	}
+	switch {
+	case len(req.Jobs) == 0:
+		h.writeError(w, http.StatusUnprocessableEntity,
+			"jobs must not be empty")
+		return
+	case len(req.Jobs) > h.maxBatchJobs:
+		h.writeError(w, http.StatusUnprocessableEntity,
+			fmt.Sprintf("too many jobs: %d exceeds the maximum of %d",
+				len(req.Jobs), h.maxBatchJobs))
+		return
+	}
 
-	h.writeJSON(w, http.StatusOK, GapAnalysisResponse{
+	jobs := make([]scorer.JobRequirements, len(req.Jobs))
+	for i, j := range req.Jobs {
+		job, err := scorer.ResolveJob(j.Job, j.JobDescription, h.extract)
+		if err != nil {
+			h.writeError(w, http.StatusBadRequest,
+				fmt.Sprintf("invalid jobs[%d].job_description: %v", i, err))
+			return
+		}
+		jobs[i] = job
+	}
+
+	result := h.analyzer.AnalyzeMany(req.Profile, jobs, req.MinSharedJobs)
+	for i := range result.Jobs {
+		result.Jobs[i].JobID = req.Jobs[i].JobID
+	}
+
+	h.writeJSON(w, http.StatusOK, BatchGapAnalysisResponse{
 		Success: true,
 		Data:    &result,
 	})
 }
 
+// ReportHandler handles POST /api/v1/gap-analysis/report.
+//
+// It accepts the same request body as GapAnalysisHandler and returns the
+// analysis as a printable report. The optional ?format= query parameter
+// selects "pdf" (default, application/pdf) or "html" (text/html).
+//
+// Example curl:
+//
+//	curl -X POST "http://localhost:8080/api/v1/gap-analysis/report?format=pdf" \
+//	  -H "Content-Type: application/json" \
+//	  -d '{"profile":{...},"job":{...}}' -o gap-analysis.pdf
+func (h *Handler) ReportHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		h.writeError(w, http.StatusMethodNotAllowed,
+			"only POST is supported")
+		return
+	}
+
+	format, err := report.ParseFormat(r.URL.Query().Get("format"))
+	if err != nil {
+		h.writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	req, ok := h.decodeRequest(w, r)
+	if !ok {
+		return
+	}
+
+	result := h.analyze(w, r, req)
+	doc := BuildReport(result, req.Job, time.Now())
+
+	// Render into a buffer so that a rendering failure can still be
+	// reported as a JSON error.
+	var buf bytes.Buffer
+	if err := report.Render(&buf, format, doc); err != nil {
+		h.logger.Printf("failed to render gap analysis report: %v", err)
+		h.writeError(w, http.StatusInternalServerError,
+			"failed to render report")
+		return
+	}
+
+	w.Header().Set("Content-Type", format.ContentType())
+	if format == report.FormatPDF {
+		w.Header().Set("Content-Disposition", `attachment; filename="gap-analysis-report.pdf"`)
+	}
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write(buf.Bytes()); err != nil {
+		h.logger.Printf("failed to write report: %v", err)
+	}
+}
+
+// analyze runs the gap analysis for a request, or returns the cached
+// analysis of an identical one. The X-No-Cache request header bypasses the
+// cache.
+func (h *Handler) analyze(w http.ResponseWriter, r *http.Request, req GapAnalysisRequest) GapAnalysisResult {
+	key := resultcache.Key(scorer.CanonicalProfile(req.Profile), req.Job)
+	result, _ := resultcache.Serve(h.cache, w, r, key, func() (GapAnalysisResult, error) {
+		return h.analyzer.Analyze(req.Profile, req.Job), nil
+	})
+	return result
+}
+
+// decodeRequest validates the content type, decodes a GapAnalysisRequest
+// and resolves its job requirements, writing an error response and
+// returning false on failure.
+func (h *Handler) decodeRequest(w http.ResponseWriter, r *http.Request) (GapAnalysisRequest, bool) {
+	var req GapAnalysisRequest
+	if !h.decodeBody(w, r, &req) {
+		return req, false
+	}
+
+	job, err := scorer.ResolveJob(req.Job, req.JobDescription, h.extract)
+	if err != nil {
+		h.writeError(w, http.StatusBadRequest,
+			"invalid job_description: "+err.Error())
+		return req, false
+	}
+	req.Job = job
+	return req, true
+}
+
+// decodeBody validates the content type, decodes the JSON body into v and
+// checks its validation rules, writing an error response and returning false
+// on failure.
+func (h *Handler) decodeBody(w http.ResponseWriter, r *http.Request, v interface{}) bool {
+	if ct := r.Header.Get("Content-Type"); ct != "" &&
+		len(ct) >= 16 && ct[:16] != "application/json" {
+		h.writeError(w, http.StatusUnsupportedMediaType,
+			"Content-Type must be application/json")
+		return false
+	}
+
+	if err := h.validation.Decode(r.Body, v); err != nil {
+		if errs, ok := validation.AsErrors(err); ok {
+			h.writeJSON(w, http.StatusUnprocessableEntity, GapAnalysisResponse{
+				Success: false,
+				Error:   "request validation failed",
+				Errors:  errs,
+			})
+			return false
+		}
+		h.writeError(w, http.StatusBadRequest,
+			"invalid request body: "+err.Error())
+		return false
+	}
+	return true
+}
+
 // writeJSON serialises v as JSON and writes it to the response.
 func (h *Handler) writeJSON(w http.ResponseWriter, status int, v interface{}) {
 	w.Header().Set("Content-Type", "application/json")
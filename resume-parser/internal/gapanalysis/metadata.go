@@ -0,0 +1,72 @@
+// Package gapanalysis – metadata.go defines the skill metadata used to
+// estimate and prioritize gaps, and the providers it can be loaded from.
+package gapanalysis
+
+// SkillMetadata describes a skill for gap analysis.
+type SkillMetadata struct {
+	// BaseHours is the estimated hours to reach job-ready proficiency
+	// from zero knowledge.
+	BaseHours int
+
+	// Transferability is how broadly useful this skill is [0.0, 1.0].
+	Transferability float64
+
+	// Difficulty is the inherent difficulty of the skill.
+	Difficulty DifficultyLevel
+
+	// TargetLevel is the typical proficiency level required by employers.
+	TargetLevel string
+
+	// RelatedSkills lists normalized skill names that are semantically
+	// similar.
+	RelatedSkills []string
+
+	// Prerequisites lists normalized names of skills typically learned
+	// before this one.
+	Prerequisites []string
+}
+
+// SkillMetadataProvider supplies skill metadata and alias resolution to the
+// Analyzer. Skill names passed to it are normalized (lowercased, trimmed).
+type SkillMetadataProvider interface {
+	// Metadata returns the metadata for a skill, or false if the skill is
+	// unknown. Unknown skills are analyzed with default metadata.
+	Metadata(norm string) (SkillMetadata, bool)
+
+	// SameSkill reports whether two skill names refer to the same skill.
+	SameSkill(a, b string) bool
+}
+
+// BuiltinMetadataProvider serves the metadata and aliases built into this
+// package. It is the Analyzer's default provider.
+type BuiltinMetadataProvider struct{}
+
+// Metadata implements SkillMetadataProvider.
+func (BuiltinMetadataProvider) Metadata(norm string) (SkillMetadata, bool) {
+	return lookupBuiltinMetadata(norm)
+}
+
+// SameSkill implements SkillMetadataProvider.
+func (BuiltinMetadataProvider) SameSkill(a, b string) bool {
+	return skillsAreAliases(a, b)
+}
+
+// metadataFor returns the provider's metadata for a skill, falling back to
+// defaults.
+func metadataFor(p SkillMetadataProvider, norm string) SkillMetadata {
+	if meta, ok := p.Metadata(norm); ok {
+		return meta
+	}
+	return defaultSkillMetadata
+}
+
+// Option configures an Analyzer.
+type Option func(*Analyzer)
+
+// WithSkillMetadataProvider makes the Analyzer read skill metadata from p
+// instead of the builtin tables.
+func WithSkillMetadataProvider(p SkillMetadataProvider) Option {
+	return func(a *Analyzer) {
+		a.metadata = p
+	}
+}
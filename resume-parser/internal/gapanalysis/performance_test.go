@@ -73,11 +73,12 @@ func BenchmarkAnalyze_NoGaps(b *testing.B) {
 
 // BenchmarkComputePriorityScore benchmarks the priority score computation.
 func BenchmarkComputePriorityScore(b *testing.B) {
+	w := DefaultPriorityWeights()
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
-		computePriorityScore(1.0, 0.85, 120)
-		computePriorityScore(0.6, 0.70, 80)
-		computePriorityScore(0.3, 0.95, 30)
+		computePriorityScore(w, 1.0, 0.85, 120, 0)
+		computePriorityScore(w, 0.6, 0.70, 80, 0)
+		computePriorityScore(w, 0.3, 0.95, 30, 0)
 	}
 }
 
@@ -107,7 +108,7 @@ func BenchmarkTokenOverlapSimilarity(b *testing.B) {
 func TestComputePriorityScore_ZeroInputs(t *testing.T) {
 	// With importance=0, transferability=0, hours=maxHours (ease=0):
 	// score = 0*0.50 + 0*0.30 + 0*0.20 = 0
-	score := computePriorityScore(0.0, 0.0, int(maxLearningHoursForNormalization))
+	score := computePriorityScore(DefaultPriorityWeights(), 0.0, 0.0, int(maxLearningHoursForNormalization), 0)
 	if !approxEqual(score, 0.0, 0.001) {
 		t.Errorf("expected priority score=0.0 for zero inputs, got %.4f", score)
 	}
@@ -128,7 +129,7 @@ func TestComputePriorityScore_InRange(t *testing.T) {
 	}
 
 	for _, tc := range testCases {
-		score := computePriorityScore(tc.importance, tc.transferability, tc.hours)
+		score := computePriorityScore(DefaultPriorityWeights(), tc.importance, tc.transferability, tc.hours, 0)
 		if score < 0 || score > 1 {
 			t.Errorf("priority score out of [0,1]: importance=%.2f, transferability=%.2f, hours=%d → %.4f",
 				tc.importance, tc.transferability, tc.hours, score)
@@ -177,7 +178,7 @@ func TestTokenOverlapSimilarity_EmptyStrings(t *testing.T) {
 // TestAdjustLearningHours_NoSimilarity verifies no reduction without similarity.
 func TestAdjustLearningHours_NoSimilarity(t *testing.T) {
 	baseHours := 100
-	adjusted := adjustLearningHours(baseHours, 0.0, "")
+	adjusted := adjustLearningHours(scorer.DefaultProficiencyScale(), baseHours, 0.0, "")
 	if adjusted != baseHours {
 		t.Errorf("expected no reduction with 0 similarity: base=%d, adjusted=%d",
 			baseHours, adjusted)
@@ -187,7 +188,7 @@ func TestAdjustLearningHours_NoSimilarity(t *testing.T) {
 // TestAdjustLearningHours_HighSimilarity verifies reduction with high similarity.
 func TestAdjustLearningHours_HighSimilarity(t *testing.T) {
 	baseHours := 100
-	adjusted := adjustLearningHours(baseHours, 0.7, "")
+	adjusted := adjustLearningHours(scorer.DefaultProficiencyScale(), baseHours, 0.7, "")
 	if adjusted >= baseHours {
 		t.Errorf("expected reduction with high similarity: base=%d, adjusted=%d",
 			baseHours, adjusted)
@@ -197,7 +198,7 @@ func TestAdjustLearningHours_HighSimilarity(t *testing.T) {
 // TestAdjustLearningHours_BeginnerLevel verifies reduction for beginner level.
 func TestAdjustLearningHours_BeginnerLevel(t *testing.T) {
 	baseHours := 100
-	adjusted := adjustLearningHours(baseHours, 0.0, "beginner")
+	adjusted := adjustLearningHours(scorer.DefaultProficiencyScale(), baseHours, 0.0, "beginner")
 	if adjusted >= baseHours {
 		t.Errorf("expected reduction for beginner level: base=%d, adjusted=%d",
 			baseHours, adjusted)
@@ -207,8 +208,8 @@ func TestAdjustLearningHours_BeginnerLevel(t *testing.T) {
 // TestAdjustLearningHours_AdvancedLevel verifies larger reduction for advanced level.
 func TestAdjustLearningHours_AdvancedLevel(t *testing.T) {
 	baseHours := 100
-	adjustedBeginner := adjustLearningHours(baseHours, 0.0, "beginner")
-	adjustedAdvanced := adjustLearningHours(baseHours, 0.0, "advanced")
+	adjustedBeginner := adjustLearningHours(scorer.DefaultProficiencyScale(), baseHours, 0.0, "beginner")
+	adjustedAdvanced := adjustLearningHours(scorer.DefaultProficiencyScale(), baseHours, 0.0, "advanced")
 	if adjustedAdvanced >= adjustedBeginner {
 		t.Errorf("advanced level should have fewer hours than beginner: beginner=%d, advanced=%d",
 			adjustedBeginner, adjustedAdvanced)
@@ -218,7 +219,7 @@ func TestAdjustLearningHours_AdvancedLevel(t *testing.T) {
 // TestAdjustLearningHours_MinimumOne verifies minimum of 1 hour.
 func TestAdjustLearningHours_MinimumOne(t *testing.T) {
 	// Even with maximum reduction, should be at least 1 hour
-	adjusted := adjustLearningHours(1, 1.0, "advanced")
+	adjusted := adjustLearningHours(scorer.DefaultProficiencyScale(), 1, 1.0, "advanced")
 	if adjusted < 1 {
 		t.Errorf("expected minimum 1 hour, got %d", adjusted)
 	}
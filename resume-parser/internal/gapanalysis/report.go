@@ -0,0 +1,127 @@
+// Package gapanalysis – report.go builds the printable gap analysis report
+// rendered by the internal/report package.
+package gapanalysis
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/learnbot/resume-parser/internal/report"
+	"github.com/learnbot/resume-parser/internal/scorer"
+)
+
+// BuildReport converts a gap analysis result into a report document with
+// the readiness score, skills radar chart, top priority gaps with their
+// recommendations, the learning timeline, and matched skills.
+func BuildReport(result GapAnalysisResult, job scorer.JobRequirements, generatedAt time.Time) report.Document {
+	subtitle := "Target role: " + job.Title
+	if strings.TrimSpace(job.Title) == "" {
+		subtitle = "Target role not specified"
+	}
+
+	blocks := []report.Block{
+		report.Metrics{Items: []report.Metric{
+			{Label: "Readiness score", Value: fmt.Sprintf("%.0f%%", result.ReadinessScore)},
+			{Label: "Critical gaps", Value: fmt.Sprintf("%d", result.CriticalGapCount)},
+			{Label: "Preferred gaps", Value: fmt.Sprintf("%d", result.ImportantGapCount)},
+			{Label: "Est. learning hours", Value: fmt.Sprintf("%d", result.TotalEstimatedLearningHours)},
+		}},
+
+		report.Heading{Text: "Skills Profile"},
+		report.RadarChart{
+			Labels: result.VisualData.RadarChart.Labels,
+			Series: []report.Series{
+				{Name: "You", Values: result.VisualData.RadarChart.CandidateScores},
+				{Name: "Role requirement", Values: result.VisualData.RadarChart.RequiredScores},
+			},
+		},
+
+		report.Heading{Text: "Top Priority Gaps"},
+		topGapsTable(result.TopPriorityGaps),
+
+		report.Heading{Text: "Learning Timeline"},
+		timelineTable(result.VisualData.LearningTimeline),
+
+		report.Heading{Text: "Skills You Already Have"},
+		report.List{
+			Items: result.MatchedSkills,
+			Empty: "None of the listed skills were found on your profile yet.",
+		},
+	}
+
+	return report.Document{
+		Title:       "Skill Gap Analysis Report",
+		Subtitle:    subtitle,
+		GeneratedAt: generatedAt,
+		Blocks:      blocks,
+	}
+}
+
+// topGapsTable lists the top priority gaps with their recommendations.
+func topGapsTable(gaps []SkillGap) report.Table {
+	t := report.Table{
+		Columns: []report.Column{
+			{Title: "Skill", Width: 1.4},
+			{Title: "Category", Width: 1},
+			{Title: "Priority", Width: 0.8},
+			{Title: "Hours", Width: 0.7},
+			{Title: "Recommendations", Width: 4},
+		},
+		Empty: "No skill gaps found – your profile covers every listed requirement.",
+	}
+	for _, g := range gaps {
+		recs := make([]string, 0, len(g.Recommendations))
+		for _, r := range g.Recommendations {
+			recs = append(recs, fmt.Sprintf("%d. %s (%dh)", r.Priority, r.Title, r.EstimatedHours))
+		}
+		t.Rows = append(t.Rows, []string{
+			g.SkillName,
+			categoryLabel(string(g.Category)),
+			fmt.Sprintf("%.0f%%", g.PriorityScore*100),
+			fmt.Sprintf("%d", g.EstimatedLearningHours),
+			strings.Join(recs, "\n"),
+		})
+	}
+	return t
+}
+
+// timelineTable lists the suggested learning order.
+func timelineTable(entries []TimelineEntry) report.Table {
+	t := report.Table{
+		Columns: []report.Column{
+			{Title: "#", Width: 0.4},
+			{Title: "Skill", Width: 1.4},
+			{Title: "Category", Width: 1},
+			{Title: "Hours", Width: 0.7},
+			{Title: "Cumulative", Width: 0.9},
+			{Title: "Why", Width: 3.5},
+		},
+		Empty: "Nothing to learn for this role.",
+	}
+	for _, e := range entries {
+		t.Rows = append(t.Rows, []string{
+			fmt.Sprintf("%d", e.Order),
+			e.SkillName,
+			categoryLabel(e.Category),
+			fmt.Sprintf("%d", e.EstimatedHours),
+			fmt.Sprintf("%d", e.CumulativeHours),
+			e.Rationale,
+		})
+	}
+	return t
+}
+
+// categoryLabel returns a display label for a gap category.
+func categoryLabel(category string) string {
+	switch GapCategory(category) {
+	case GapCategoryCritical:
+		return "Critical"
+	case GapCategoryImportant:
+		return "Preferred"
+	case GapCategoryNiceToHave:
+		return "Nice to have"
+	default:
+		return category
+	}
+}
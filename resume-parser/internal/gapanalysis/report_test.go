@@ -0,0 +1,291 @@
+package gapanalysis
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/learnbot/resume-parser/internal/report"
+	"github.com/learnbot/resume-parser/internal/scorer"
+	"github.com/learnbot/shared/openapi"
+)
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Test helpers
+// ─────────────────────────────────────────────────────────────────────────────
+
+// postReport sends a report request through the registered routes.
+func postReport(t *testing.T, method, query string, body GapAnalysisRequest) *httptest.ResponseRecorder {
+	t.Helper()
+	data, err := json.Marshal(body)
+	if err != nil {
+		t.Fatalf("failed to marshal request: %v", err)
+	}
+	mux := http.NewServeMux()
+	NewHandler(log.New(io.Discard, "", 0)).RegisterRoutes(mux)
+
+	req := httptest.NewRequest(method, "/api/v1/gap-analysis/report"+query, bytes.NewReader(data))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+	return w
+}
+
+func reportRequest() GapAnalysisRequest {
+	return GapAnalysisRequest{
+		Profile: scorer.CandidateProfile{
+			Skills: []scorer.CandidateSkill{{Name: "Go", Proficiency: "advanced"}},
+		},
+		Job: scorer.JobRequirements{
+			Title:           "Platform Engineer",
+			RequiredSkills:  []string{"Go", "Kubernetes", "Terraform"},
+			PreferredSkills: []string{"Prometheus"},
+		},
+	}
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// BuildReport
+// ─────────────────────────────────────────────────────────────────────────────
+
+func TestBuildReport_Sections(t *testing.T) {
+	req := reportRequest()
+	result := newAnalyzer().Analyze(req.Profile, req.Job)
+	doc := BuildReport(result, req.Job, time.Date(2025, 1, 6, 0, 0, 0, 0, time.UTC))
+
+	if doc.Subtitle != "Target role: Platform Engineer" {
+		t.Errorf("unexpected subtitle %q", doc.Subtitle)
+	}
+
+	var headings []string
+	var gaps report.Table
+	for _, b := range doc.Blocks {
+		switch b := b.(type) {
+		case report.Heading:
+			headings = append(headings, b.Text)
+		case report.Table:
+			if gaps.Columns == nil {
+				gaps = b
+			}
+		case report.RadarChart:
+			if len(b.Series) != 2 || len(b.Labels) != len(b.Series[0].Values) {
+				t.Errorf("radar chart series do not match labels: %+v", b)
+			}
+		}
+	}
+	want := "Skills Profile,Top Priority Gaps,Learning Timeline,Skills You Already Have"
+	if got := strings.Join(headings, ","); got != want {
+		t.Errorf("headings = %q, want %q", got, want)
+	}
+	if len(gaps.Rows) != len(result.TopPriorityGaps) {
+		t.Errorf("expected %d gap rows, got %d", len(result.TopPriorityGaps), len(gaps.Rows))
+	}
+	if len(gaps.Rows) > 0 && !strings.Contains(gaps.Rows[0][4], "1. ") {
+		t.Errorf("gap row should list numbered recommendations: %q", gaps.Rows[0][4])
+	}
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// ReportHandler
+// ─────────────────────────────────────────────────────────────────────────────
+
+func TestReportHandler_PDF(t *testing.T) {
+	w := postReport(t, http.MethodPost, "", reportRequest())
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if ct := w.Header().Get("Content-Type"); ct != "application/pdf" {
+		t.Errorf("expected application/pdf, got %q", ct)
+	}
+	if !strings.Contains(w.Header().Get("Content-Disposition"), "gap-analysis-report.pdf") {
+		t.Errorf("missing attachment filename: %q", w.Header().Get("Content-Disposition"))
+	}
+	if !bytes.HasPrefix(w.Body.Bytes(), []byte("%PDF-")) {
+		t.Error("body is not a PDF document")
+	}
+}
+
+func TestReportHandler_HTML(t *testing.T) {
+	w := postReport(t, http.MethodPost, "?format=html", reportRequest())
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if ct := w.Header().Get("Content-Type"); !strings.HasPrefix(ct, "text/html") {
+		t.Errorf("expected text/html, got %q", ct)
+	}
+	body := w.Body.String()
+	for _, want := range []string{"Target role: Platform Engineer", "Kubernetes", "<svg"} {
+		if !strings.Contains(body, want) {
+			t.Errorf("HTML report missing %q", want)
+		}
+	}
+}
+
+func TestReportHandler_NoGaps(t *testing.T) {
+	req := GapAnalysisRequest{
+		Profile: scorer.CandidateProfile{
+			Skills: []scorer.CandidateSkill{{Name: "Go", Proficiency: "expert"}},
+		},
+		Job: scorer.JobRequirements{Title: "Go Developer", RequiredSkills: []string{"Go"}},
+	}
+	w := postReport(t, http.MethodPost, "?format=html", req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", w.Code)
+	}
+	if !strings.Contains(w.Body.String(), "No skill gaps found") {
+		t.Error("empty gap list should render its empty-state message")
+	}
+
+	if w := postReport(t, http.MethodPost, "?format=pdf", req); w.Code != http.StatusOK {
+		t.Fatalf("expected 200 for PDF, got %d", w.Code)
+	}
+}
+
+func TestReportHandler_UnsupportedFormat(t *testing.T) {
+	w := postReport(t, http.MethodPost, "?format=docx", reportRequest())
+
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d", w.Code)
+	}
+	var resp GapAnalysisResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if resp.Success || !strings.Contains(resp.Error, "docx") {
+		t.Errorf("unexpected error response: %+v", resp)
+	}
+}
+
+func TestReportHandler_MethodNotAllowed(t *testing.T) {
+	w := postReport(t, http.MethodGet, "", reportRequest())
+
+	if w.Code != http.StatusMethodNotAllowed {
+		t.Errorf("expected 405, got %d", w.Code)
+	}
+}
+
+func TestGapAnalysisHandler_JobDescription(t *testing.T) {
+	h := NewHandler(log.New(io.Discard, "", 0))
+	h.SetRequirementsExtractor(func(description string) (scorer.JobRequirements, error) {
+		return scorer.JobRequirements{Title: "Extracted", RequiredSkills: strings.Fields(description)}, nil
+	})
+	mux := http.NewServeMux()
+	h.RegisterRoutes(mux)
+
+	body := `{"profile":{"skills":[{"name":"Go"}]},"job":{"title":"Platform Engineer"},"job_description":"Go Kubernetes"}`
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/api/v1/gap-analysis", strings.NewReader(body)))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var resp GapAnalysisResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil || resp.Data == nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(resp.Data.CriticalGaps) != 1 || resp.Data.CriticalGaps[0].SkillName != "Kubernetes" {
+		t.Errorf("expected one Kubernetes gap from the description, got %+v", resp.Data)
+	}
+}
+
+func TestGapAnalysisHandler_Validation(t *testing.T) {
+	mux := http.NewServeMux()
+	NewHandler(log.New(io.Discard, "", 0)).RegisterRoutes(mux)
+
+	tests := []struct {
+		name   string
+		body   string
+		want   int
+		fields []string
+	}{
+		{"valid", `{"profile":{"skills":[{"name":"Go"}]},"job":{"required_skills":["Go"]}}`, http.StatusOK, nil},
+		{"unnamed skill", `{"profile":{"skills":[{"name":" "}]},"job":{}}`,
+			http.StatusUnprocessableEntity, []string{"profile.skills[0].name"}},
+		{"negative experience", `{"profile":{"work_history":[{"title":"Dev","duration_months":-3}]},"job":{"max_years_experience":-1}}`,
+			http.StatusUnprocessableEntity, []string{"profile.work_history[0].duration_months", "job.max_years_experience"}},
+		{"unknown field", `{"profile":{},"job":{"requried_skills":["Go"]}}`,
+			http.StatusUnprocessableEntity, []string{"requried_skills"}},
+		{"not json", `{"profile":`, http.StatusBadRequest, nil},
+	}
+	for _, tt := range tests {
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/api/v1/gap-analysis", strings.NewReader(tt.body)))
+		if w.Code != tt.want {
+			t.Errorf("%s: expected %d, got %d: %s", tt.name, tt.want, w.Code, w.Body.String())
+			continue
+		}
+		var resp GapAnalysisResponse
+		json.NewDecoder(w.Body).Decode(&resp)
+		var fields []string
+		for _, fe := range resp.Errors {
+			fields = append(fields, fe.Field)
+		}
+		if strings.Join(fields, ",") != strings.Join(tt.fields, ",") {
+			t.Errorf("%s: invalid fields %v, want %v", tt.name, fields, tt.fields)
+		}
+	}
+}
+
+func TestReportHandler_ManyGapsPaginate(t *testing.T) {
+	req := reportRequest()
+	req.Job.RequiredSkills = nil
+	for i := 0; i < 60; i++ {
+		req.Job.RequiredSkills = append(req.Job.RequiredSkills, fmt.Sprintf("Skill %02d", i))
+	}
+	w := postReport(t, http.MethodPost, "?format=pdf", req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", w.Code)
+	}
+	if n := bytes.Count(w.Body.Bytes(), []byte("/Type /Page ")); n < 2 {
+		t.Errorf("expected the 60-entry timeline to span several pages, got %d", n)
+	}
+}
+
+func TestDescribeRoutes(t *testing.T) {
+	h := NewHandler(log.New(io.Discard, "", 0))
+	if err := openapi.CheckRoutes(h.RegisterRoutes, h.DescribeRoutes); err != nil {
+		t.Fatal(err)
+	}
+
+	spec := openapi.New("test", "1.0.0")
+	h.DescribeRoutes(spec)
+	raw, err := spec.JSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var doc struct {
+		Components struct {
+			Schemas map[string]struct {
+				Properties map[string]json.RawMessage `json:"properties"`
+			} `json:"schemas"`
+		} `json:"components"`
+	}
+	if err := json.Unmarshal(raw, &doc); err != nil {
+		t.Fatal(err)
+	}
+	for name, props := range map[string][]string{
+		"GapAnalysisRequest": {"profile", "job", "job_description"},
+		"GapAnalysisResult":  {"critical_gaps", "readiness_score"},
+	} {
+		s, ok := doc.Components.Schemas[name]
+		if !ok {
+			t.Errorf("missing schema %s", name)
+			continue
+		}
+		for _, p := range props {
+			if _, ok := s.Properties[p]; !ok {
+				t.Errorf("schema %s: missing property %s", name, p)
+			}
+		}
+	}
+}
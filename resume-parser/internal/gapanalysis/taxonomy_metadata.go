@@ -0,0 +1,115 @@
+// Package gapanalysis – taxonomy_metadata.go adapts the skill taxonomy
+// ontology into a SkillMetadataProvider.
+package gapanalysis
+
+import (
+	"strings"
+
+	"github.com/learnbot/resume-parser/internal/taxonomy"
+)
+
+// TaxonomyMetadataProvider derives skill metadata from the taxonomy
+// ontology, so that gap analysis and skill normalization share one source
+// of aliases and skill relationships.
+//
+// Aliases, related skills and prerequisites come from the ontology. The
+// ontology has no effort estimates, so learning hours, transferability,
+// difficulty and target level come from the builtin tables when the skill
+// is known there, and from defaults otherwise.
+type TaxonomyMetadataProvider struct {
+	tax   *taxonomy.Taxonomy
+	fuzzy bool
+}
+
+// NewTaxonomyMetadataProvider creates a provider backed by t.
+func NewTaxonomyMetadataProvider(t *taxonomy.Taxonomy) *TaxonomyMetadataProvider {
+	return &TaxonomyMetadataProvider{tax: t}
+}
+
+// SetFuzzyMatching sets whether skills with no exact or alias match in the
+// ontology are fuzzy matched, so that a resume's "Kubernates" meets a job's
+// "Kubernetes". It is disabled by default.
+func (p *TaxonomyMetadataProvider) SetFuzzyMatching(enabled bool) {
+	p.fuzzy = enabled
+}
+
+// Metadata implements SkillMetadataProvider.
+func (p *TaxonomyMetadataProvider) Metadata(norm string) (SkillMetadata, bool) {
+	builtin, inBuiltin := lookupBuiltinMetadata(norm)
+	node := p.node(norm)
+	if node == nil {
+		return builtin, inBuiltin
+	}
+
+	// The ontology may know the skill under a name the builtin tables use.
+	if !inBuiltin {
+		for _, name := range append([]string{node.CanonicalName, node.ID}, node.Aliases...) {
+			if builtin, inBuiltin = lookupBuiltinMetadata(normalizeSkill(name)); inBuiltin {
+				break
+			}
+		}
+	}
+	meta := defaultSkillMetadata
+	if inBuiltin {
+		meta = builtin
+	}
+
+	// Prerequisites count as related: knowing them shortens the path to
+	// the skill.
+	prerequisites := p.names(node.Prerequisites)
+	related := append(append([]string{}, meta.RelatedSkills...), p.names(node.RelatedSkills)...)
+	meta.RelatedSkills = dedupeStrings(append(related, prerequisites...))
+	meta.Prerequisites = prerequisites
+	return meta, true
+}
+
+// SameSkill implements SkillMetadataProvider. Skills found in the ontology
+// are the same when they resolve to the same node; otherwise the builtin
+// alias rules apply.
+func (p *TaxonomyMetadataProvider) SameSkill(a, b string) bool {
+	if a == b {
+		return true
+	}
+	na, nb := p.node(a), p.node(b)
+	if na != nil && nb != nil {
+		return na.ID == nb.ID
+	}
+	return skillsAreAliases(a, b)
+}
+
+// node returns the ontology node for a skill name, or nil.
+func (p *TaxonomyMetadataProvider) node(name string) *taxonomy.SkillNode {
+	if node := p.tax.LookupName(name); node != nil || !p.fuzzy {
+		return node
+	}
+	if res := p.tax.Normalize(name); res.MatchType == "fuzzy" {
+		return p.tax.Lookup(res.CanonicalID)
+	}
+	return nil
+}
+
+// names converts taxonomy node IDs to normalized skill names.
+func (p *TaxonomyMetadataProvider) names(ids []string) []string {
+	names := make([]string, 0, len(ids))
+	for _, id := range ids {
+		if node := p.tax.Lookup(id); node != nil {
+			names = append(names, normalizeSkill(node.CanonicalName))
+		} else {
+			names = append(names, strings.ReplaceAll(id, "-", " "))
+		}
+	}
+	return names
+}
+
+// dedupeStrings removes repeated entries, keeping the first occurrence.
+func dedupeStrings(ss []string) []string {
+	seen := make(map[string]bool, len(ss))
+	out := ss[:0]
+	for _, s := range ss {
+		if !seen[s] {
+			seen[s] = true
+			out = append(out, s)
+		}
+	}
+	return out
+}
@@ -0,0 +1,121 @@
+package gapanalysis
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/learnbot/resume-parser/internal/scorer"
+	"github.com/learnbot/resume-parser/internal/taxonomy"
+)
+
+func newTaxonomyAnalyzer() *Analyzer {
+	return New(WithSkillMetadataProvider(NewTaxonomyMetadataProvider(taxonomy.New())))
+}
+
+func TestTaxonomyMetadataProvider_SharedSkillsMatchBuiltin(t *testing.T) {
+	profile := scorer.CandidateProfile{
+		Skills: []scorer.CandidateSkill{
+			{Name: "Python", Proficiency: "intermediate"},
+			{Name: "PostgreSQL", Proficiency: "advanced"},
+			{Name: "Docker", Proficiency: "beginner"},
+		},
+		YearsOfExperience: 3,
+	}
+	job := scorer.JobRequirements{
+		RequiredSkills:   []string{"Go", "Kubernetes", "Docker"},
+		PreferredSkills:  []string{"AWS", "Redis"},
+		NiceToHaveSkills: []string{"Terraform"},
+	}
+
+	builtin := newAnalyzer().Analyze(profile, job)
+	fromTaxonomy := newTaxonomyAnalyzer().Analyze(profile, job)
+
+	if !reflect.DeepEqual(builtin, fromTaxonomy) {
+		t.Errorf("expected identical results for skills known to both sources\nbuiltin:  %+v\ntaxonomy: %+v",
+			builtin.VisualData.LearningTimeline, fromTaxonomy.VisualData.LearningTimeline)
+	}
+}
+
+func TestTaxonomyMetadataProvider_OntologyOnlyRelatedSkills(t *testing.T) {
+	profile := scorer.CandidateProfile{
+		Skills: []scorer.CandidateSkill{{Name: "Deep Learning", Proficiency: "advanced"}},
+	}
+	job := scorer.JobRequirements{RequiredSkills: []string{"LLM"}}
+
+	builtinGap, ok := findGap(newAnalyzer().Analyze(profile, job).CriticalGaps, "LLM")
+	if !ok {
+		t.Fatal("expected an LLM gap with builtin metadata")
+	}
+	gap, ok := findGap(newTaxonomyAnalyzer().Analyze(profile, job).CriticalGaps, "LLM")
+	if !ok {
+		t.Fatal("expected an LLM gap with taxonomy metadata")
+	}
+
+	// The ontology lists deep learning as an LLM prerequisite; the builtin
+	// tables do not know LLMs at all.
+	if gap.ClosestExistingSkill != "Deep Learning" {
+		t.Errorf("ClosestExistingSkill = %q, want Deep Learning", gap.ClosestExistingSkill)
+	}
+	if gap.SemanticSimilarityScore <= builtinGap.SemanticSimilarityScore {
+		t.Errorf("expected higher similarity from the ontology: taxonomy=%.2f, builtin=%.2f",
+			gap.SemanticSimilarityScore, builtinGap.SemanticSimilarityScore)
+	}
+	if gap.EstimatedLearningHours >= builtinGap.EstimatedLearningHours {
+		t.Errorf("expected fewer hours with a related skill: taxonomy=%d, builtin=%d",
+			gap.EstimatedLearningHours, builtinGap.EstimatedLearningHours)
+	}
+}
+
+func TestTaxonomyMetadataProvider_Aliases(t *testing.T) {
+	p := NewTaxonomyMetadataProvider(taxonomy.New())
+
+	if !p.SameSkill("ecmascript", "javascript") {
+		t.Error("expected ontology aliases to be the same skill")
+	}
+	// The builtin substring rule would treat these as aliases.
+	if p.SameSkill("java", "javascript") {
+		t.Error("expected distinct ontology skills not to be aliases")
+	}
+
+	meta, ok := p.Metadata("rag")
+	if !ok {
+		t.Fatal("expected metadata for an ontology-only skill")
+	}
+	if !reflect.DeepEqual(meta.Prerequisites, []string{"llm"}) {
+		t.Errorf("Prerequisites = %v, want [llm]", meta.Prerequisites)
+	}
+	if meta.BaseHours != defaultSkillMetadata.BaseHours {
+		t.Errorf("BaseHours = %d, want the default %d", meta.BaseHours, defaultSkillMetadata.BaseHours)
+	}
+	if _, ok := p.Metadata("not a real skill"); ok {
+		t.Error("expected unknown skills to report no metadata")
+	}
+}
+
+func TestTaxonomyMetadataProvider_FuzzyMatching(t *testing.T) {
+	p := NewTaxonomyMetadataProvider(taxonomy.New())
+	if p.SameSkill("kubernates", "kubernetes") {
+		t.Error("expected no fuzzy matching by default")
+	}
+
+	p.SetFuzzyMatching(true)
+	if !p.SameSkill("kubernates", "kubernetes") || !p.SameSkill("postgressql", "postgres") {
+		t.Error("expected misspelled skills to match the skills they were meant as")
+	}
+	if p.SameSkill("go", "c") {
+		t.Error("expected short skills never to fuzzy match")
+	}
+	if meta, ok := p.Metadata("tensorflw"); !ok || len(meta.RelatedSkills) == 0 {
+		t.Errorf("expected TensorFlow metadata for a misspelling, got %+v, %v", meta, ok)
+	}
+
+	// A candidate who misspelled a required skill holds it.
+	a := New(WithSkillMetadataProvider(p))
+	result := a.Analyze(
+		scorer.CandidateProfile{Skills: []scorer.CandidateSkill{{Name: "Kubernates", Proficiency: "expert"}}},
+		scorer.JobRequirements{RequiredSkills: []string{"Kubernetes"}},
+	)
+	if len(result.CriticalGaps) != 0 {
+		t.Errorf("expected no critical gaps, got %+v", result.CriticalGaps)
+	}
+}
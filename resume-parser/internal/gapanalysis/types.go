@@ -14,7 +14,10 @@
 //   - Transferability to other roles
 package gapanalysis
 
-import "github.com/learnbot/resume-parser/internal/scorer"
+import (
+	"github.com/learnbot/resume-parser/internal/scorer"
+	"github.com/learnbot/shared/validation"
+)
 
 // ─────────────────────────────────────────────────────────────────────────────
 // Gap category and priority types
@@ -60,9 +63,10 @@ type SkillGap struct {
 	Category GapCategory `json:"category"`
 
 	// PriorityScore is a composite score [0.0, 1.0] ranking the gap by:
-	//   - Importance to job acceptance (weight: 0.50)
-	//   - Transferability to other roles (weight: 0.30)
-	//   - Inverse of time to acquire (weight: 0.20)
+	//   - Importance to job acceptance (default weight: 0.50)
+	//   - Transferability to other roles (default weight: 0.30)
+	//   - Inverse of time to acquire (default weight: 0.20)
+	//   - Market demand (default weight: 0, see WithPriorityWeights)
 	// Higher score = higher priority to address.
 	PriorityScore float64 `json:"priority_score"`
 
@@ -80,6 +84,10 @@ type SkillGap struct {
 	// Higher = more transferable (e.g. Python > niche framework).
 	TransferabilityScore float64 `json:"transferability_score"`
 
+	// DemandScore is the skill's market demand [0.0, 1.0] from the
+	// Analyzer's DemandProvider, or 0 without one.
+	DemandScore float64 `json:"demand_score"`
+
 	// CurrentLevel is the candidate's current proficiency in this skill,
 	// if they have partial knowledge. Empty if they have no knowledge.
 	CurrentLevel string `json:"current_level,omitempty"`
@@ -158,7 +166,8 @@ type GapAnalysisResult struct {
 	TotalEstimatedLearningHours int `json:"total_estimated_learning_hours"`
 
 	// ReadinessScore is a score [0.0, 100.0] indicating how ready the
-	// candidate is for the role. Derived from the inverse of gap severity.
+	// candidate is for the role. Derived from the inverse of gap severity;
+	// weak matches count in proportion to the levels they are below target.
 	ReadinessScore float64 `json:"readiness_score"`
 
 	// TopPriorityGaps is a ranked list of the top 5 gaps to address first,
@@ -169,9 +178,43 @@ type GapAnalysisResult struct {
 	// the job requirements.
 	MatchedSkills []string `json:"matched_skills"`
 
+	// WeakMatches lists skills the candidate has below the target level,
+	// in category order and by PriorityScore within a category. Each also
+	// appears among the gaps with its CurrentLevel set, so the gap's
+	// recommendations and learning resources cover the improvement.
+	WeakMatches []WeakMatch `json:"weak_matches"`
+
 	// VisualData provides a JSON-friendly representation for frontend
 	// visualization of the gap analysis.
 	VisualData GapVisualData `json:"visual_data"`
+
+	// Warnings lists the candidate proficiencies and target levels that
+	// are not on the proficiency scale, and what they were ranked as.
+	Warnings []string `json:"warnings,omitempty"`
+}
+
+// WeakMatch is a skill the candidate has, but below the level the job
+// needs.
+type WeakMatch struct {
+	// SkillName is the name of the skill as listed by the job.
+	SkillName string `json:"skill_name"`
+
+	// Category is the category of the skill's gap.
+	Category GapCategory `json:"category"`
+
+	// CurrentLevel is the candidate's proficiency in the skill.
+	CurrentLevel string `json:"current_level"`
+
+	// TargetLevel is the proficiency level required by the job.
+	TargetLevel string `json:"target_level"`
+
+	// LevelsBelow is how many proficiency levels CurrentLevel is below
+	// TargetLevel.
+	LevelsBelow int `json:"levels_below"`
+
+	// ReadinessDeduction is the number of points the weak match deducts
+	// from the readiness score.
+	ReadinessDeduction float64 `json:"readiness_deduction"`
 }
 
 // GapVisualData provides structured data for frontend visualization.
@@ -234,6 +277,86 @@ type TimelineEntry struct {
 	Rationale string `json:"rationale"`
 }
 
+// ─────────────────────────────────────────────────────────────────────────────
+// Multi-job analysis types
+// ─────────────────────────────────────────────────────────────────────────────
+
+// MultiJobAnalysis is the output of Analyzer.AnalyzeMany.
+type MultiJobAnalysis struct {
+	// Jobs contains one result per job, in input order.
+	Jobs []JobGapResult `json:"jobs"`
+
+	// Summary compares the jobs.
+	Summary MultiJobSummary `json:"summary"`
+}
+
+// JobGapResult is the analysis of one job in a multi-job analysis.
+type JobGapResult struct {
+	// Index is the job's position in the input.
+	Index int `json:"index"`
+
+	// JobID echoes the caller-supplied job identifier, if any.
+	JobID string `json:"job_id,omitempty"`
+
+	// Title is the job's title.
+	Title string `json:"title"`
+
+	// Rank orders the jobs by readiness score (1 = closest to ready). Jobs
+	// with equal scores share a rank.
+	Rank int `json:"rank"`
+
+	// DuplicateOf is the index of an earlier job with identical
+	// requirements, if any. Duplicates are left out of the summary.
+	DuplicateOf *int `json:"duplicate_of,omitempty"`
+
+	// Analysis is the gap analysis for the job.
+	Analysis GapAnalysisResult `json:"analysis"`
+}
+
+// MultiJobSummary is the cross-job part of a multi-job analysis.
+type MultiJobSummary struct {
+	// UniqueJobCount is the number of jobs left after removing duplicates.
+	UniqueJobCount int `json:"unique_job_count"`
+
+	// MinSharedJobs is the number of jobs a skill must be a gap in to be
+	// listed in SharedGaps.
+	MinSharedJobs int `json:"min_shared_jobs"`
+
+	// JobsByReadiness lists the indexes of the unique jobs, closest to
+	// ready first.
+	JobsByReadiness []int `json:"jobs_by_readiness"`
+
+	// SharedGaps lists the skills that are gaps in at least MinSharedJobs
+	// jobs, the most valuable to learn first.
+	SharedGaps []SharedGap `json:"shared_gaps"`
+}
+
+// SharedGap is a skill that is a gap in several jobs.
+type SharedGap struct {
+	// SkillName is the name of the skill as the first job lists it.
+	SkillName string `json:"skill_name"`
+
+	// JobCount is the number of jobs where the skill is a gap of any
+	// category.
+	JobCount int `json:"job_count"`
+
+	// CriticalJobCount is the number of jobs where the skill is a critical
+	// gap.
+	CriticalJobCount int `json:"critical_job_count"`
+
+	// UnlockScore is the sum of the readiness scores, as fractions of 100,
+	// of the jobs where the skill is a critical gap. A skill missing from
+	// two jobs at 80% and 50% readiness scores 1.3.
+	UnlockScore float64 `json:"unlock_score"`
+
+	// EstimatedLearningHours is the most hours the skill needs in any of
+	// the jobs.
+	EstimatedLearningHours int `json:"estimated_learning_hours"`
+
+	// JobIndexes lists the indexes of the jobs where the skill is a gap.
+	JobIndexes []int `json:"job_indexes"`
+}
+
 // ─────────────────────────────────────────────────────────────────────────────
 // API request/response types
 // ─────────────────────────────────────────────────────────────────────────────
@@ -245,6 +368,11 @@ type GapAnalysisRequest struct {
 
 	// Job is the job requirements to analyze gaps against.
 	Job scorer.JobRequirements `json:"job"`
+
+	// JobDescription is the raw text of the job posting. When set, the
+	// requirements are extracted from it and any fields set in Job
+	// override the extracted ones.
+	JobDescription string `json:"job_description,omitempty"`
 }
 
 // GapAnalysisResponse is the output of the gap analysis API endpoint.
@@ -257,4 +385,52 @@ type GapAnalysisResponse struct {
 
 	// Error contains an error message when Success is false.
 	Error string `json:"error,omitempty"`
+
+	// Errors lists the invalid request fields when the request failed
+	// validation (422).
+	Errors validation.Errors `json:"errors,omitempty"`
+}
+
+// BatchJob is one job in a batch gap analysis request.
+type BatchJob struct {
+	// JobID is an optional caller-supplied identifier echoed in the result.
+	JobID string `json:"job_id,omitempty"`
+
+	// Job is the job requirements to analyze gaps against.
+	Job scorer.JobRequirements `json:"job"`
+
+	// JobDescription is the raw text of the job posting, resolved as in
+	// GapAnalysisRequest.
+	JobDescription string `json:"job_description,omitempty"`
+}
+
+// BatchGapAnalysisRequest is the input to the batch gap analysis API
+// endpoint.
+type BatchGapAnalysisRequest struct {
+	// Profile is the candidate's professional profile.
+	Profile scorer.CandidateProfile `json:"profile"`
+
+	// Jobs is the list of jobs to analyze the candidate against.
+	Jobs []BatchJob `json:"jobs"`
+
+	// MinSharedJobs is the number of jobs a skill must be a gap in to be
+	// listed among the shared gaps. Zero means DefaultMinSharedJobs.
+	MinSharedJobs int `json:"min_shared_jobs,omitempty" validate:"min=0"`
+}
+
+// BatchGapAnalysisResponse is the output of the batch gap analysis API
+// endpoint.
+type BatchGapAnalysisResponse struct {
+	// Success indicates whether the analysis succeeded.
+	Success bool `json:"success"`
+
+	// Data contains the multi-job analysis when Success is true.
+	Data *MultiJobAnalysis `json:"data,omitempty"`
+
+	// Error contains an error message when Success is false.
+	Error string `json:"error,omitempty"`
+
+	// Errors lists the invalid request fields when the request failed
+	// validation (422).
+	Errors validation.Errors `json:"errors,omitempty"`
 }
@@ -0,0 +1,223 @@
+package grpcserver
+
+import (
+	"github.com/learnbot/resume-parser/internal/gapanalysis"
+	"github.com/learnbot/resume-parser/internal/scorer"
+	pb "github.com/learnbot/resume-parser/pkg/scoringpb"
+)
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Proto → engine
+// ─────────────────────────────────────────────────────────────────────────────
+
+// profileFromProto converts a candidate profile. A nil profile is empty, as
+// an omitted profile is in the HTTP API.
+func profileFromProto(p *pb.CandidateProfile) scorer.CandidateProfile {
+	if p == nil {
+		return scorer.CandidateProfile{}
+	}
+	profile := scorer.CandidateProfile{
+		YearsOfExperience: p.GetYearsOfExperience(),
+		LocationCity:      p.GetLocationCity(),
+		LocationCountry:   p.GetLocationCountry(),
+		WillingToRelocate: p.GetWillingToRelocate(),
+		RemotePreference:  p.GetRemotePreference(),
+	}
+	for _, s := range p.GetSkills() {
+		profile.Skills = append(profile.Skills, scorer.CandidateSkill{
+			Name:              s.GetName(),
+			Proficiency:       s.GetProficiency(),
+			YearsOfExperience: s.GetYearsOfExperience(),
+		})
+	}
+	for _, w := range p.GetWorkHistory() {
+		profile.WorkHistory = append(profile.WorkHistory, scorer.WorkHistoryEntry{
+			Title:          w.GetTitle(),
+			Industry:       w.GetIndustry(),
+			DurationMonths: int(w.GetDurationMonths()),
+			IsCurrent:      w.GetIsCurrent(),
+		})
+	}
+	for _, e := range p.GetEducation() {
+		profile.Education = append(profile.Education, scorer.EducationEntry{
+			DegreeLevel:  e.GetDegreeLevel(),
+			FieldOfStudy: e.GetFieldOfStudy(),
+		})
+	}
+	for _, c := range p.GetCertifications() {
+		profile.Certifications = append(profile.Certifications, scorer.CertificationEntry{
+			Name:   c.GetName(),
+			Issuer: c.GetIssuer(),
+			Year:   int(c.GetYear()),
+		})
+	}
+	for _, pr := range p.GetProjects() {
+		profile.Projects = append(profile.Projects, scorer.ProjectEntry{
+			Title:        pr.GetTitle(),
+			Description:  pr.GetDescription(),
+			Technologies: pr.GetTechnologies(),
+		})
+	}
+	return profile
+}
+
+// jobFromProto converts job requirements. A nil job has no requirements.
+func jobFromProto(j *pb.JobRequirements) scorer.JobRequirements {
+	if j == nil {
+		return scorer.JobRequirements{}
+	}
+	return scorer.JobRequirements{
+		Title:               j.GetTitle(),
+		RequiredSkills:      j.GetRequiredSkills(),
+		PreferredSkills:     j.GetPreferredSkills(),
+		NiceToHaveSkills:    j.GetNiceToHaveSkills(),
+		MinYearsExperience:  j.GetMinYearsExperience(),
+		MaxYearsExperience:  j.GetMaxYearsExperience(),
+		RequiredDegreeLevel: j.GetRequiredDegreeLevel(),
+		PreferredFields:     j.GetPreferredFields(),
+		LocationCity:        j.GetLocationCity(),
+		LocationCountry:     j.GetLocationCountry(),
+		LocationType:        j.GetLocationType(),
+		Industry:            j.GetIndustry(),
+		RelatedIndustries:   j.GetRelatedIndustries(),
+		ExperienceLevel:     j.GetExperienceLevel(),
+	}
+}
+
+// weightsFromProto returns the request's scoring weights, or the defaults
+// if it has none.
+func weightsFromProto(w *pb.ScoringWeights) scorer.ScoringWeights {
+	if w == nil {
+		return scorer.DefaultScoringWeights()
+	}
+	return scorer.ScoringWeights{
+		SkillMatch:        w.GetSkillMatch(),
+		ExperienceMatch:   w.GetExperienceMatch(),
+		EducationMatch:    w.GetEducationMatch(),
+		LocationFit:       w.GetLocationFit(),
+		IndustryRelevance: w.GetIndustryRelevance(),
+	}
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Engine → proto
+// ─────────────────────────────────────────────────────────────────────────────
+
+// breakdownToProto converts a score breakdown. The explanation is not
+// part of the proto API.
+func breakdownToProto(b scorer.ScoreBreakdown) *pb.ScoreBreakdown {
+	return &pb.ScoreBreakdown{
+		OverallScore:           b.OverallScore,
+		SkillMatchScore:        b.SkillMatchScore,
+		ExperienceMatchScore:   b.ExperienceMatchScore,
+		EducationMatchScore:    b.EducationMatchScore,
+		LocationFitScore:       b.LocationFitScore,
+		IndustryRelevanceScore: b.IndustryRelevanceScore,
+		MatchedRequiredSkills:  b.MatchedRequiredSkills,
+		MissingRequiredSkills:  b.MissingRequiredSkills,
+		MatchedPreferredSkills: b.MatchedPreferredSkills,
+		Weights: &pb.ScoringWeights{
+			SkillMatch:        b.Weights.SkillMatch,
+			ExperienceMatch:   b.Weights.ExperienceMatch,
+			EducationMatch:    b.Weights.EducationMatch,
+			LocationFit:       b.Weights.LocationFit,
+			IndustryRelevance: b.Weights.IndustryRelevance,
+		},
+		Warnings: b.Warnings,
+	}
+}
+
+// resultToProto converts a batch score result.
+func resultToProto(r scorer.ScoreResult) *pb.ScoreResult {
+	return &pb.ScoreResult{
+		Index:       int32(r.Index),
+		CandidateId: r.CandidateID,
+		Rank:        int32(r.Rank),
+		Score:       breakdownToProto(r.Score),
+	}
+}
+
+// analysisToProto converts a gap analysis result.
+func analysisToProto(r gapanalysis.GapAnalysisResult) *pb.GapAnalysisResult {
+	out := &pb.GapAnalysisResult{
+		CriticalGaps:                gapsToProto(r.CriticalGaps),
+		ImportantGaps:               gapsToProto(r.ImportantGaps),
+		NiceToHaveGaps:              gapsToProto(r.NiceToHaveGaps),
+		TotalGaps:                   int32(r.TotalGaps),
+		CriticalGapCount:            int32(r.CriticalGapCount),
+		ImportantGapCount:           int32(r.ImportantGapCount),
+		NiceToHaveGapCount:          int32(r.NiceToHaveGapCount),
+		TotalEstimatedLearningHours: int32(r.TotalEstimatedLearningHours),
+		ReadinessScore:              r.ReadinessScore,
+		TopPriorityGaps:             gapsToProto(r.TopPriorityGaps),
+		MatchedSkills:               r.MatchedSkills,
+		VisualData: &pb.GapVisualData{
+			RadarChart: &pb.RadarChartData{
+				Labels:          r.VisualData.RadarChart.Labels,
+				CandidateScores: r.VisualData.RadarChart.CandidateScores,
+				RequiredScores:  r.VisualData.RadarChart.RequiredScores,
+			},
+		},
+	}
+	for _, w := range r.WeakMatches {
+		out.WeakMatches = append(out.WeakMatches, &pb.WeakMatch{
+			SkillName:          w.SkillName,
+			Category:           string(w.Category),
+			CurrentLevel:       w.CurrentLevel,
+			TargetLevel:        w.TargetLevel,
+			LevelsBelow:        int32(w.LevelsBelow),
+			ReadinessDeduction: w.ReadinessDeduction,
+		})
+	}
+	for _, c := range r.VisualData.GapsByCategory {
+		out.VisualData.GapsByCategory = append(out.VisualData.GapsByCategory, &pb.CategorySummary{
+			Category:           c.Category,
+			GapCount:           int32(c.GapCount),
+			TotalLearningHours: int32(c.TotalLearningHours),
+			AveragePriority:    c.AveragePriority,
+		})
+	}
+	for _, t := range r.VisualData.LearningTimeline {
+		out.VisualData.LearningTimeline = append(out.VisualData.LearningTimeline, &pb.TimelineEntry{
+			Order:           int32(t.Order),
+			SkillName:       t.SkillName,
+			Category:        t.Category,
+			EstimatedHours:  int32(t.EstimatedHours),
+			CumulativeHours: int32(t.CumulativeHours),
+			Rationale:       t.Rationale,
+		})
+	}
+	return out
+}
+
+// gapsToProto converts a list of skill gaps.
+func gapsToProto(gaps []gapanalysis.SkillGap) []*pb.SkillGap {
+	out := make([]*pb.SkillGap, 0, len(gaps))
+	for _, g := range gaps {
+		gap := &pb.SkillGap{
+			SkillName:               g.SkillName,
+			Category:                string(g.Category),
+			PriorityScore:           g.PriorityScore,
+			ImportanceScore:         g.ImportanceScore,
+			EstimatedLearningHours:  int32(g.EstimatedLearningHours),
+			TransferabilityScore:    g.TransferabilityScore,
+			DemandScore:             g.DemandScore,
+			CurrentLevel:            g.CurrentLevel,
+			TargetLevel:             g.TargetLevel,
+			SemanticSimilarityScore: g.SemanticSimilarityScore,
+			ClosestExistingSkill:    g.ClosestExistingSkill,
+			Difficulty:              string(g.Difficulty),
+		}
+		for _, rec := range g.Recommendations {
+			gap.Recommendations = append(gap.Recommendations, &pb.Recommendation{
+				Title:          rec.Title,
+				Description:    rec.Description,
+				ResourceType:   rec.ResourceType,
+				EstimatedHours: int32(rec.EstimatedHours),
+				Priority:       int32(rec.Priority),
+			})
+		}
+		out = append(out, gap)
+	}
+	return out
+}
@@ -0,0 +1,213 @@
+// Package grpcserver serves the scoring and gap analysis engine over gRPC,
+// for internal callers that want to avoid the JSON/HTTP overhead of the
+// /api/v1/score and /api/v1/gap-analysis endpoints.
+//
+// Requests are validated as the HTTP handlers validate them, and invalid
+// input is rejected with codes.InvalidArgument. A call whose deadline
+// expires or that is cancelled before its result is ready returns
+// codes.DeadlineExceeded or codes.Canceled.
+package grpcserver
+
+import (
+	"context"
+	"fmt"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/learnbot/resume-parser/internal/gapanalysis"
+	"github.com/learnbot/resume-parser/internal/quality"
+	"github.com/learnbot/resume-parser/internal/scorer"
+	pb "github.com/learnbot/resume-parser/pkg/scoringpb"
+)
+
+// Server implements the ScoreService and GapService.
+type Server struct {
+	analyzer     *gapanalysis.Analyzer
+	quality      *quality.Tracker
+	maxBatchSize int
+	extract      scorer.RequirementsExtractor
+}
+
+// NewServer creates a new Server.
+func NewServer() *Server {
+	return &Server{
+		analyzer:     gapanalysis.New(),
+		maxBatchSize: scorer.DefaultMaxBatchSize,
+	}
+}
+
+// SetQualityTracker enables recording of scoring requests that produce
+// consistency warnings. A nil tracker disables recording.
+func (s *Server) SetQualityTracker(t *quality.Tracker) {
+	s.quality = t
+}
+
+// SetMaxBatchSize sets the maximum number of candidates accepted by
+// CalculateBatch. Values below 1 restore scorer.DefaultMaxBatchSize.
+func (s *Server) SetMaxBatchSize(n int) {
+	if n < 1 {
+		n = scorer.DefaultMaxBatchSize
+	}
+	s.maxBatchSize = n
+}
+
+// SetRequirementsExtractor enables the job_description request field,
+// using extract to turn the description into job requirements.
+func (s *Server) SetRequirementsExtractor(extract scorer.RequirementsExtractor) {
+	s.extract = extract
+}
+
+// Register registers the ScoreService and GapService on gs.
+func (s *Server) Register(gs grpc.ServiceRegistrar) {
+	pb.RegisterScoreServiceServer(gs, &scoreService{s: s})
+	pb.RegisterGapServiceServer(gs, &gapService{s: s})
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// ScoreService
+// ─────────────────────────────────────────────────────────────────────────────
+
+type scoreService struct {
+	pb.UnimplementedScoreServiceServer
+	s *Server
+}
+
+// Calculate scores one candidate profile against a job.
+func (svc *scoreService) Calculate(ctx context.Context, req *pb.CalculateRequest) (*pb.CalculateResponse, error) {
+	job, err := svc.s.resolveJob(req.GetJob(), req.GetJobDescription())
+	if err != nil {
+		return nil, err
+	}
+	profile := profileFromProto(req.GetProfile())
+	weights := weightsFromProto(req.GetWeights())
+
+	breakdown, err := withContext(ctx, func() (scorer.ScoreBreakdown, error) {
+		return scorer.CalculateWithWeights(profile, job, weights)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(breakdown.Warnings) > 0 {
+		svc.s.quality.Record(quality.CheckScoringInconsistencies, job.Title)
+	}
+	return &pb.CalculateResponse{Score: breakdownToProto(breakdown)}, nil
+}
+
+// CalculateBatch scores many candidate profiles against the same job.
+// Requests with no candidates, or with more than the maximum batch size,
+// are rejected.
+func (svc *scoreService) CalculateBatch(ctx context.Context, req *pb.CalculateBatchRequest) (*pb.CalculateBatchResponse, error) {
+	candidates := req.GetCandidates()
+	switch {
+	case len(candidates) == 0:
+		return nil, status.Error(codes.InvalidArgument, "candidates must not be empty")
+	case len(candidates) > svc.s.maxBatchSize:
+		return nil, status.Errorf(codes.InvalidArgument,
+			"too many candidates: %d exceeds the maximum batch size of %d",
+			len(candidates), svc.s.maxBatchSize)
+	}
+	job, err := svc.s.resolveJob(req.GetJob(), req.GetJobDescription())
+	if err != nil {
+		return nil, err
+	}
+	profiles := make([]scorer.CandidateProfile, len(candidates))
+	for i, c := range candidates {
+		profiles[i] = profileFromProto(c.GetProfile())
+	}
+	weights := weightsFromProto(req.GetWeights())
+
+	results, err := withContext(ctx, func() ([]scorer.ScoreResult, error) {
+		return scorer.CalculateBatchWithWeights(profiles, job, weights)
+	})
+	if err != nil {
+		return nil, err
+	}
+	resp := &pb.CalculateBatchResponse{Results: make([]*pb.ScoreResult, len(results))}
+	for i, r := range results {
+		r.CandidateID = candidates[i].GetCandidateId()
+		if len(r.Score.Warnings) > 0 {
+			svc.s.quality.Record(quality.CheckScoringInconsistencies, job.Title)
+		}
+		resp.Results[i] = resultToProto(r)
+	}
+	return resp, nil
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// GapService
+// ─────────────────────────────────────────────────────────────────────────────
+
+type gapService struct {
+	pb.UnimplementedGapServiceServer
+	s *Server
+}
+
+// Analyze runs the skill gap analysis for a candidate profile and a job.
+func (svc *gapService) Analyze(ctx context.Context, req *pb.AnalyzeRequest) (*pb.AnalyzeResponse, error) {
+	job, err := svc.s.resolveJob(req.GetJob(), req.GetJobDescription())
+	if err != nil {
+		return nil, err
+	}
+	profile := profileFromProto(req.GetProfile())
+
+	result, err := withContext(ctx, func() (gapanalysis.GapAnalysisResult, error) {
+		return svc.s.analyzer.Analyze(profile, job), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &pb.AnalyzeResponse{Result: analysisToProto(result)}, nil
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Helpers
+// ─────────────────────────────────────────────────────────────────────────────
+
+// resolveJob returns the job requirements of a request from its structured
+// requirements and job description.
+func (s *Server) resolveJob(job *pb.JobRequirements, description string) (scorer.JobRequirements, error) {
+	resolved, err := scorer.ResolveJob(jobFromProto(job), description, s.extract)
+	if err != nil {
+		return scorer.JobRequirements{}, status.Error(codes.InvalidArgument,
+			"invalid job_description: "+err.Error())
+	}
+	return resolved, nil
+}
+
+// withContext runs compute unless ctx is already done, and returns early
+// with the context's status if ctx is done before compute returns. The
+// engine does not take a context, so an abandoned computation finishes in
+// the background. Errors from compute are invalid input.
+func withContext[T any](ctx context.Context, compute func() (T, error)) (T, error) {
+	var zero T
+	if err := ctx.Err(); err != nil {
+		return zero, status.FromContextError(err).Err()
+	}
+
+	type result struct {
+		v   T
+		err error
+	}
+	done := make(chan result, 1)
+	go func() {
+		defer func() {
+			if p := recover(); p != nil {
+				done <- result{err: status.Error(codes.Internal, fmt.Sprint("internal error: ", p))}
+			}
+		}()
+		v, err := compute()
+		if err != nil {
+			err = status.Error(codes.InvalidArgument, err.Error())
+		}
+		done <- result{v, err}
+	}()
+
+	select {
+	case <-ctx.Done():
+		return zero, status.FromContextError(ctx.Err()).Err()
+	case r := <-done:
+		return r.v, r.err
+	}
+}
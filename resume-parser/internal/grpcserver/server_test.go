@@ -0,0 +1,306 @@
+package grpcserver
+
+import (
+	"context"
+	"net"
+	"reflect"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/learnbot/resume-parser/internal/gapanalysis"
+	"github.com/learnbot/resume-parser/internal/jobparse"
+	"github.com/learnbot/resume-parser/internal/scorer"
+	pb "github.com/learnbot/resume-parser/pkg/scoringpb"
+)
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Test helpers
+// ─────────────────────────────────────────────────────────────────────────────
+
+// dial serves srv on an in-memory listener and returns a connection to it.
+func dial(t *testing.T, srv *Server) *grpc.ClientConn {
+	t.Helper()
+	lis := bufconn.Listen(1 << 20)
+	gs := grpc.NewServer()
+	srv.Register(gs)
+	go gs.Serve(lis)
+	t.Cleanup(gs.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
+
+// testProfile returns a profile that exercises every profile field.
+func testProfile() *pb.CandidateProfile {
+	return &pb.CandidateProfile{
+		Skills: []*pb.CandidateSkill{
+			{Name: "Go", Proficiency: "expert", YearsOfExperience: 5},
+			{Name: "PostgreSQL", Proficiency: "intermediate", YearsOfExperience: 3},
+			{Name: "Docker", Proficiency: "beginner"},
+		},
+		YearsOfExperience: 6,
+		WorkHistory: []*pb.WorkHistoryEntry{
+			{Title: "Backend Engineer", Industry: "Software", DurationMonths: 48, IsCurrent: true},
+			{Title: "Junior Developer", Industry: "Finance", DurationMonths: 24},
+		},
+		Education:        []*pb.EducationEntry{{DegreeLevel: "bachelor", FieldOfStudy: "Computer Science"}},
+		Certifications:   []*pb.CertificationEntry{{Name: "Certified Kubernetes Administrator (CKA)", Issuer: "CNCF", Year: 2023}},
+		Projects:         []*pb.ProjectEntry{{Title: "Event pipeline", Technologies: []string{"Kafka"}}},
+		LocationCity:     "Berlin",
+		LocationCountry:  "Germany",
+		RemotePreference: "hybrid",
+	}
+}
+
+// testJob returns a job that exercises every requirement field.
+func testJob() *pb.JobRequirements {
+	return &pb.JobRequirements{
+		Title:               "Senior Backend Engineer",
+		RequiredSkills:      []string{"Go", "PostgreSQL", "Kubernetes", "Kafka"},
+		PreferredSkills:     []string{"Docker", "Terraform"},
+		NiceToHaveSkills:    []string{"Rust"},
+		MinYearsExperience:  5,
+		MaxYearsExperience:  10,
+		RequiredDegreeLevel: "bachelor",
+		PreferredFields:     []string{"Computer Science"},
+		LocationCity:        "Berlin",
+		LocationCountry:     "Germany",
+		LocationType:        "hybrid",
+		Industry:            "Software",
+		RelatedIndustries:   []string{"Finance"},
+		ExperienceLevel:     "senior",
+	}
+}
+
+// wantCode fails the test unless err is a status error with code.
+func wantCode(t *testing.T, err error, code codes.Code) {
+	t.Helper()
+	if got := status.Code(err); got != code {
+		t.Errorf("code = %v (%v), want %v", got, err, code)
+	}
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// ScoreService
+// ─────────────────────────────────────────────────────────────────────────────
+
+func TestCalculate_MatchesDirectCalculate(t *testing.T) {
+	client := pb.NewScoreServiceClient(dial(t, NewServer()))
+	weights := &pb.ScoringWeights{SkillMatch: 0.5, ExperienceMatch: 0.3, EducationMatch: 0, LocationFit: 0.1, IndustryRelevance: 0.1}
+
+	for name, w := range map[string]*pb.ScoringWeights{"default weights": nil, "custom weights": weights} {
+		t.Run(name, func(t *testing.T) {
+			resp, err := client.Calculate(context.Background(), &pb.CalculateRequest{
+				Profile: testProfile(), Job: testJob(), Weights: w,
+			})
+			if err != nil {
+				t.Fatalf("Calculate: %v", err)
+			}
+			want, err := scorer.CalculateWithWeights(profileFromProto(testProfile()), jobFromProto(testJob()), weightsFromProto(w))
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := resp.GetScore()
+			if got.GetOverallScore() != want.OverallScore ||
+				got.GetSkillMatchScore() != want.SkillMatchScore ||
+				got.GetExperienceMatchScore() != want.ExperienceMatchScore ||
+				got.GetEducationMatchScore() != want.EducationMatchScore ||
+				got.GetLocationFitScore() != want.LocationFitScore ||
+				got.GetIndustryRelevanceScore() != want.IndustryRelevanceScore {
+				t.Errorf("scores differ:\n got %v\nwant %+v", got, want)
+			}
+			if got.GetWeights().GetSkillMatch() != want.Weights.SkillMatch ||
+				got.GetWeights().GetEducationMatch() != want.Weights.EducationMatch {
+				t.Errorf("weights = %v, want %+v", got.GetWeights(), want.Weights)
+			}
+			if !reflect.DeepEqual(got.GetMatchedRequiredSkills(), want.MatchedRequiredSkills) ||
+				!reflect.DeepEqual(got.GetMissingRequiredSkills(), want.MissingRequiredSkills) {
+				t.Errorf("skills = %v / %v, want %v / %v", got.GetMatchedRequiredSkills(),
+					got.GetMissingRequiredSkills(), want.MatchedRequiredSkills, want.MissingRequiredSkills)
+			}
+		})
+	}
+}
+
+func TestCalculate_MapsEveryField(t *testing.T) {
+	profile := profileFromProto(testProfile())
+	if profile.Certifications[0].Year != 2023 || profile.Projects[0].Technologies[0] != "Kafka" ||
+		profile.WorkHistory[0].DurationMonths != 48 || !profile.WorkHistory[0].IsCurrent ||
+		profile.Education[0].FieldOfStudy != "Computer Science" || profile.RemotePreference != "hybrid" {
+		t.Errorf("unexpected profile %+v", profile)
+	}
+	job := jobFromProto(testJob())
+	if job.MaxYearsExperience != 10 || job.NiceToHaveSkills[0] != "Rust" ||
+		job.RelatedIndustries[0] != "Finance" || job.ExperienceLevel != "senior" {
+		t.Errorf("unexpected job %+v", job)
+	}
+}
+
+func TestCalculate_JobDescription(t *testing.T) {
+	srv := NewServer()
+	srv.SetRequirementsExtractor(jobparse.ExtractRequirements)
+	client := pb.NewScoreServiceClient(dial(t, srv))
+
+	description := "Senior Go Engineer\n\nRequirements:\n- 5+ years of experience with Go\n- Experience with PostgreSQL\n"
+	resp, err := client.Calculate(context.Background(), &pb.CalculateRequest{
+		Profile: testProfile(), JobDescription: description,
+	})
+	if err != nil {
+		t.Fatalf("Calculate: %v", err)
+	}
+	job, err := scorer.ResolveJob(scorer.JobRequirements{}, description, jobparse.ExtractRequirements)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := scorer.Calculate(profileFromProto(testProfile()), job); resp.GetScore().GetOverallScore() != want.OverallScore {
+		t.Errorf("overall score = %v, want %v", resp.GetScore().GetOverallScore(), want.OverallScore)
+	}
+}
+
+func TestCalculateBatch_MatchesCalculateBatch(t *testing.T) {
+	client := pb.NewScoreServiceClient(dial(t, NewServer()))
+	weak := &pb.CandidateProfile{Skills: []*pb.CandidateSkill{{Name: "Go"}}, YearsOfExperience: 1}
+
+	resp, err := client.CalculateBatch(context.Background(), &pb.CalculateBatchRequest{
+		Job: testJob(),
+		Candidates: []*pb.BatchCandidate{
+			{CandidateId: "weak", Profile: weak},
+			{CandidateId: "strong", Profile: testProfile()},
+		},
+	})
+	if err != nil {
+		t.Fatalf("CalculateBatch: %v", err)
+	}
+	want := scorer.CalculateBatch([]scorer.CandidateProfile{profileFromProto(weak), profileFromProto(testProfile())}, jobFromProto(testJob()))
+	if len(resp.GetResults()) != len(want) {
+		t.Fatalf("got %d results, want %d", len(resp.GetResults()), len(want))
+	}
+	for i, got := range resp.GetResults() {
+		if got.GetIndex() != int32(want[i].Index) || got.GetRank() != int32(want[i].Rank) ||
+			got.GetScore().GetOverallScore() != want[i].Score.OverallScore {
+			t.Errorf("result %d = %v, want %+v", i, got, want[i])
+		}
+	}
+	if resp.GetResults()[0].GetCandidateId() != "weak" || resp.GetResults()[1].GetRank() != 1 {
+		t.Errorf("unexpected candidate ids or ranks: %v", resp.GetResults())
+	}
+}
+
+func TestScoreService_InvalidInput(t *testing.T) {
+	srv := NewServer()
+	srv.SetMaxBatchSize(2)
+	client := pb.NewScoreServiceClient(dial(t, srv))
+	ctx := context.Background()
+	candidate := &pb.BatchCandidate{Profile: testProfile()}
+
+	_, err := client.Calculate(ctx, &pb.CalculateRequest{Job: testJob(), Weights: &pb.ScoringWeights{SkillMatch: -1, ExperienceMatch: 1}})
+	wantCode(t, err, codes.InvalidArgument)
+
+	_, err = client.Calculate(ctx, &pb.CalculateRequest{Job: testJob(), Weights: &pb.ScoringWeights{}})
+	wantCode(t, err, codes.InvalidArgument)
+
+	_, err = client.Calculate(ctx, &pb.CalculateRequest{JobDescription: "Go engineer"})
+	wantCode(t, err, codes.InvalidArgument)
+
+	_, err = client.CalculateBatch(ctx, &pb.CalculateBatchRequest{Job: testJob()})
+	wantCode(t, err, codes.InvalidArgument)
+
+	_, err = client.CalculateBatch(ctx, &pb.CalculateBatchRequest{Job: testJob(), Candidates: []*pb.BatchCandidate{candidate, candidate, candidate}})
+	wantCode(t, err, codes.InvalidArgument)
+
+	_, err = client.CalculateBatch(ctx, &pb.CalculateBatchRequest{Job: testJob(), Candidates: []*pb.BatchCandidate{candidate},
+		Weights: &pb.ScoringWeights{LocationFit: -0.5}})
+	wantCode(t, err, codes.InvalidArgument)
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// GapService
+// ─────────────────────────────────────────────────────────────────────────────
+
+func TestAnalyze_MatchesAnalyzer(t *testing.T) {
+	client := pb.NewGapServiceClient(dial(t, NewServer()))
+
+	resp, err := client.Analyze(context.Background(), &pb.AnalyzeRequest{Profile: testProfile(), Job: testJob()})
+	if err != nil {
+		t.Fatalf("Analyze: %v", err)
+	}
+	want := gapanalysis.New().Analyze(profileFromProto(testProfile()), jobFromProto(testJob()))
+	got := resp.GetResult()
+
+	if got.GetReadinessScore() != want.ReadinessScore || got.GetTotalGaps() != int32(want.TotalGaps) ||
+		got.GetCriticalGapCount() != int32(want.CriticalGapCount) ||
+		got.GetTotalEstimatedLearningHours() != int32(want.TotalEstimatedLearningHours) {
+		t.Errorf("summary differs:\n got %v\nwant %+v", got, want)
+	}
+	if len(got.GetCriticalGaps()) != len(want.CriticalGaps) {
+		t.Fatalf("got %d critical gaps, want %d", len(got.GetCriticalGaps()), len(want.CriticalGaps))
+	}
+	for i, g := range got.GetCriticalGaps() {
+		w := want.CriticalGaps[i]
+		if g.GetSkillName() != w.SkillName || g.GetPriorityScore() != w.PriorityScore ||
+			g.GetCategory() != string(w.Category) || len(g.GetRecommendations()) != len(w.Recommendations) {
+			t.Errorf("critical gap %d = %v, want %+v", i, g, w)
+		}
+	}
+	if len(got.GetWeakMatches()) != len(want.WeakMatches) ||
+		len(got.GetVisualData().GetLearningTimeline()) != len(want.VisualData.LearningTimeline) ||
+		!reflect.DeepEqual(got.GetVisualData().GetRadarChart().GetCandidateScores(), want.VisualData.RadarChart.CandidateScores) {
+		t.Errorf("weak matches or visual data differ:\n got %v\nwant %+v", got, want)
+	}
+}
+
+func TestAnalyze_InvalidJobDescription(t *testing.T) {
+	client := pb.NewGapServiceClient(dial(t, NewServer()))
+	_, err := client.Analyze(context.Background(), &pb.AnalyzeRequest{Profile: testProfile(), JobDescription: "Go engineer"})
+	wantCode(t, err, codes.InvalidArgument)
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Deadlines
+// ─────────────────────────────────────────────────────────────────────────────
+
+func TestWithContext_Deadline(t *testing.T) {
+	block := make(chan struct{})
+	defer close(block)
+	slow := func() (int, error) {
+		<-block
+		return 1, nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_, err := withContext(ctx, slow)
+	wantCode(t, err, codes.DeadlineExceeded)
+
+	ctx, cancel = context.WithCancel(context.Background())
+	cancel()
+	called := false
+	_, err = withContext(ctx, func() (int, error) { called = true; return 1, nil })
+	wantCode(t, err, codes.Canceled)
+	if called {
+		t.Error("expected a done context to skip the computation")
+	}
+}
+
+func TestCalculate_ExpiredDeadline(t *testing.T) {
+	client := pb.NewScoreServiceClient(dial(t, NewServer()))
+	ctx, cancel := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+	defer cancel()
+
+	_, err := client.Calculate(ctx, &pb.CalculateRequest{Profile: testProfile(), Job: testJob()})
+	wantCode(t, err, codes.DeadlineExceeded)
+}
@@ -0,0 +1,142 @@
+// Package jobparse – handler.go provides the HTTP API for job description
+// parsing.
+//
+// Endpoints:
+//
+//	POST /api/v1/jobs/parse  – extract JobRequirements from a job description
+package jobparse
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/learnbot/resume-parser/internal/scorer"
+	"github.com/learnbot/resume-parser/internal/taxonomy"
+	"github.com/learnbot/shared/openapi"
+)
+
+// maxDescriptionSize limits the size of a parse request body.
+const maxDescriptionSize = 1 << 20 // 1 MB
+
+// ParseRequest is the input to the job description parsing API.
+type ParseRequest struct {
+	// Description is the raw text of the job posting. Simple HTML is
+	// accepted.
+	Description string `json:"description"`
+}
+
+// ParseResponse is the output of the job description parsing API.
+type ParseResponse struct {
+	Success bool                    `json:"success"`
+	Data    *scorer.JobRequirements `json:"data,omitempty"`
+	Error   string                  `json:"error,omitempty"`
+}
+
+// Handler holds the HTTP handler dependencies for the job parsing API.
+type Handler struct {
+	parser *Parser
+	logger *log.Logger
+}
+
+// NewHandler creates a new job parsing Handler.
+func NewHandler(logger *log.Logger) *Handler {
+	return &Handler{
+		parser: New(taxonomy.New()),
+		logger: logger,
+	}
+}
+
+// RegisterRoutes registers the job parsing routes on the given mux.
+func (h *Handler) RegisterRoutes(mux openapi.Router) {
+	mux.HandleFunc("/api/v1/jobs/parse", h.withMiddleware(h.ParseHandler))
+}
+
+// DescribeRoutes documents the routes registered by RegisterRoutes.
+func (h *Handler) DescribeRoutes(spec *openapi.Spec) {
+	spec.Route("/api/v1/jobs/parse", openapi.Operation{
+		Method:   http.MethodPost,
+		Summary:  "Extract structured requirements from a job description",
+		Request:  ParseRequest{},
+		Response: ParseResponse{},
+		Errors:   []int{http.StatusBadRequest, http.StatusUnprocessableEntity},
+	})
+}
+
+// withMiddleware wraps a handler with logging and panic recovery.
+func (h *Handler) withMiddleware(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		defer func() {
+			if rec := recover(); rec != nil {
+				h.logger.Printf("PANIC in jobparse: %v", rec)
+				h.writeError(w, http.StatusInternalServerError,
+					"an unexpected error occurred")
+			}
+		}()
+		h.logger.Printf("%s %s %s", r.Method, r.URL.Path, r.RemoteAddr)
+		next(w, r)
+		h.logger.Printf("%s %s completed in %v", r.Method, r.URL.Path, time.Since(start))
+	}
+}
+
+// ParseHandler handles POST /api/v1/jobs/parse.
+//
+// Request body (JSON):
+//
+//	{
+//	  "description": "Senior Backend Engineer\n\nRequirements\n- 5+ years of Go ..."
+//	}
+//
+// Response body (JSON):
+//
+//	{
+//	  "success": true,
+//	  "data": { ... JobRequirements ... }
+//	}
+//
+// The result can be passed as "job" to the scoring, gap analysis and
+// recommendation endpoints, or edited first.
+func (h *Handler) ParseHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		h.writeError(w, http.StatusMethodNotAllowed, "only POST is supported")
+		return
+	}
+
+	var req ParseRequest
+	dec := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxDescriptionSize))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&req); err != nil {
+		h.writeError(w, http.StatusBadRequest, "invalid request body: "+err.Error())
+		return
+	}
+
+	job, err := h.parser.ExtractRequirements(req.Description)
+	if err != nil {
+		h.writeError(w, http.StatusUnprocessableEntity, err.Error())
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, ParseResponse{
+		Success: true,
+		Data:    &job,
+	})
+}
+
+// writeJSON serialises v as JSON and writes it to the response.
+func (h *Handler) writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		h.logger.Printf("failed to encode JSON response: %v", err)
+	}
+}
+
+// writeError writes a structured error response.
+func (h *Handler) writeError(w http.ResponseWriter, status int, message string) {
+	h.writeJSON(w, status, ParseResponse{
+		Success: false,
+		Error:   message,
+	})
+}
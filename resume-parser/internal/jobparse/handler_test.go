@@ -0,0 +1,60 @@
+package jobparse
+
+import (
+	"encoding/json"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/learnbot/shared/openapi"
+)
+
+func TestParseHandler(t *testing.T) {
+	mux := http.NewServeMux()
+	NewHandler(log.New(io.Discard, "", 0)).RegisterRoutes(mux)
+
+	body := `{"description":"Go Developer\nRequirements:\n- 3+ years of experience with Go and Docker\n\nRemote."}`
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/api/v1/jobs/parse", strings.NewReader(body)))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var resp ParseResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil || resp.Data == nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if resp.Data.Title != "Go Developer" || resp.Data.MinYearsExperience != 3 || resp.Data.LocationType != "remote" ||
+		len(resp.Data.RequiredSkills) != 2 {
+		t.Errorf("unexpected requirements: %+v", resp.Data)
+	}
+
+	for _, tt := range []struct {
+		name   string
+		method string
+		body   string
+		want   int
+	}{
+		{"wrong method", http.MethodGet, "", http.StatusMethodNotAllowed},
+		{"malformed", http.MethodPost, `{"description":`, http.StatusBadRequest},
+		{"unknown field", http.MethodPost, `{"text":"Go"}`, http.StatusBadRequest},
+		{"empty description", http.MethodPost, `{"description":"  "}`, http.StatusUnprocessableEntity},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			mux.ServeHTTP(w, httptest.NewRequest(tt.method, "/api/v1/jobs/parse", strings.NewReader(tt.body)))
+			if w.Code != tt.want {
+				t.Errorf("expected %d, got %d: %s", tt.want, w.Code, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestDescribeRoutes(t *testing.T) {
+	h := NewHandler(log.New(io.Discard, "", 0))
+	if err := openapi.CheckRoutes(h.RegisterRoutes, h.DescribeRoutes); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,133 @@
+// Package jobparse extracts structured job requirements from the raw text
+// of a job description.
+//
+// Extraction is rule based. The description is split into sections by
+// their headers ("Requirements", "Nice to have", ...), and skills found
+// through the skill taxonomy are classified as required, preferred or
+// nice-to-have by the section they appear in and by cues on their own line
+// ("... is a plus"). Experience, degree, work arrangement and seniority are
+// read from common phrasings such as "5+ years of experience" or
+// "Bachelor's degree in Computer Science".
+package jobparse
+
+import (
+	"errors"
+	"strings"
+	"sync"
+
+	"github.com/learnbot/resume-parser/internal/scorer"
+	"github.com/learnbot/resume-parser/internal/taxonomy"
+)
+
+// ErrEmptyDescription is returned for a description with no text.
+var ErrEmptyDescription = errors.New("jobparse: job description is empty")
+
+// Parser extracts job requirements using a skill taxonomy.
+type Parser struct {
+	extractor *taxonomy.Extractor
+}
+
+// New creates a Parser backed by the given taxonomy.
+func New(t *taxonomy.Taxonomy) *Parser {
+	return &Parser{extractor: taxonomy.NewExtractor(t)}
+}
+
+var (
+	defaultOnce   sync.Once
+	defaultParser *Parser
+)
+
+// ExtractRequirements extracts job requirements from text using the
+// built-in skill taxonomy.
+func ExtractRequirements(text string) (scorer.JobRequirements, error) {
+	defaultOnce.Do(func() { defaultParser = New(taxonomy.New()) })
+	return defaultParser.ExtractRequirements(text)
+}
+
+// ExtractRequirements extracts job requirements from text. Fields it finds
+// no evidence for are left at their zero values.
+func (p *Parser) ExtractRequirements(text string) (scorer.JobRequirements, error) {
+	text = normalizeText(text)
+	if text == "" {
+		return scorer.JobRequirements{}, ErrEmptyDescription
+	}
+
+	lines := splitLines(text)
+	job := scorer.JobRequirements{Title: detectTitle(lines)}
+	job.RequiredSkills, job.PreferredSkills, job.NiceToHaveSkills = p.classifySkills(lines)
+	job.MinYearsExperience, job.MaxYearsExperience = detectYears(lines)
+	job.RequiredDegreeLevel, job.PreferredFields = detectDegree(lines)
+	job.LocationType = detectLocationType(lines)
+	job.ExperienceLevel = detectExperienceLevel(job.Title, lines)
+	return job, nil
+}
+
+// classifySkills returns the taxonomy skills mentioned in lines, grouped by
+// how strongly the description asks for them. A skill mentioned at several
+// strengths is reported once, at the strongest.
+func (p *Parser) classifySkills(lines []line) (required, preferred, niceToHave []string) {
+	strength := map[string]importance{}
+	var order []string
+	for _, l := range lines {
+		if l.imp == ignored {
+			continue
+		}
+		for _, name := range p.skillsIn(l.text) {
+			current, seen := strength[name]
+			if !seen {
+				order = append(order, name)
+			}
+			if !seen || l.imp < current {
+				strength[name] = l.imp
+			}
+		}
+	}
+
+	for _, name := range order {
+		switch strength[name] {
+		case mustHave:
+			required = append(required, name)
+		case shouldHave:
+			preferred = append(preferred, name)
+		default:
+			niceToHave = append(niceToHave, name)
+		}
+	}
+	return required, preferred, niceToHave
+}
+
+// ambiguousSkills are skill names that are also everyday words. In running
+// text they only count when capitalized ("Go", not "go").
+var ambiguousSkills = map[string]bool{
+	"go": true, "rest": true, "swift": true, "spring": true, "express": true,
+	"less": true, "r": true, "c": true, "dart": true, "rust": true, "excel": true,
+}
+
+// skillsIn returns the canonical names of the taxonomy skills in s. Fuzzy
+// matches and soft skills are dropped: in prose the former are mostly
+// noise, and the latter are not scored as job skills.
+func (p *Parser) skillsIn(s string) []string {
+	var names []string
+	for _, sk := range p.extractor.Extract(s, false).Skills {
+		if sk.MatchType != "exact" && sk.MatchType != "alias" {
+			continue
+		}
+		if sk.Domain == taxonomy.DomainCommunication {
+			continue
+		}
+		if ambiguousSkills[strings.ToLower(sk.RawText)] && sk.RawText == strings.ToLower(sk.RawText) {
+			continue
+		}
+		names = append(names, sk.CanonicalName)
+	}
+	return names
+}
+
+// normalizeText unifies line endings and drops HTML markup that survives
+// copying a posting from a web page.
+func normalizeText(text string) string {
+	text = strings.NewReplacer("\r\n", "\n", "\r", "\n", "<br>", "\n", "<br/>", "\n", "<li>", "\n• ",
+		"</p>", "\n", "&amp;", "&", "&nbsp;", " ").Replace(text)
+	text = htmlTagRe.ReplaceAllString(text, " ")
+	return strings.TrimSpace(text)
+}
@@ -0,0 +1,281 @@
+package jobparse
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// descriptionCase is the expected extraction for one description in
+// testdata/descriptions. Skill lists are checked for inclusion, so that
+// taxonomy additions do not break the test; absent lists skills that must
+// not be extracted at any strength.
+type descriptionCase struct {
+	file       string
+	title      string
+	required   []string
+	preferred  []string
+	niceToHave []string
+	absent     []string
+	minYears   float64
+	maxYears   float64
+	degree     string
+	fields     []string
+	location   string
+	level      string
+}
+
+var descriptionCases = []descriptionCase{
+	{
+		file:       "backend_go.txt",
+		title:      "Senior Backend Engineer (Go)",
+		required:   []string{"Go", "PostgreSQL", "SQL", "Docker", "Kubernetes"},
+		niceToHave: []string{"Terraform", "gRPC"},
+		// Python only appears under Benefits.
+		absent:   []string{"Python"},
+		minYears: 5,
+		location: "hybrid",
+		level:    "senior",
+	},
+	{
+		file:       "data_analyst.txt",
+		title:      "Data Analyst",
+		required:   []string{"SQL"},
+		niceToHave: []string{"Python"},
+		minYears:   2, maxYears: 4,
+		degree:   "bachelor",
+		fields:   []string{"Statistics", "Mathematics", "Economics"},
+		location: "on_site",
+	},
+	{
+		file:       "frontend_react.txt",
+		title:      "Frontend Developer – React",
+		required:   []string{"React", "JavaScript", "HTML", "CSS", "GraphQL"},
+		preferred:  []string{"TypeScript"},
+		niceToHave: []string{"Next.js"},
+		minYears:   3,
+		location:   "remote",
+	},
+	{
+		file:      "ml_engineer.txt",
+		title:     "Machine Learning Engineer",
+		required:  []string{"Python", "PyTorch", "TensorFlow"},
+		preferred: []string{"Kubernetes", "Apache Spark"},
+		minYears:  4,
+		// "MS or PhD": the lower degree is the requirement.
+		degree:   "master",
+		fields:   []string{"Computer Science", "Machine Learning"},
+		location: "hybrid",
+	},
+	{
+		file:       "devops_sre.txt",
+		title:      "Site Reliability Engineer",
+		required:   []string{"AWS", "Bash", "Prometheus", "Grafana"},
+		niceToHave: []string{"Go", "Python", "Ansible"},
+		// "founded 10 years ago" is not an experience requirement.
+		minYears: 3,
+		location: "remote",
+	},
+	{
+		file:       "junior_python.txt",
+		title:      "Junior Python Developer",
+		required:   []string{"Python", "Django", "Git"},
+		niceToHave: []string{"Docker"},
+		degree:     "bachelor",
+		fields:     []string{"Computer Science"},
+		location:   "on_site",
+		level:      "entry",
+	},
+	{
+		file:      "inline_requirements.txt",
+		title:     "Full Stack Engineer",
+		required:  []string{"Node.js", "React", "MongoDB"},
+		preferred: []string{"AWS", "Docker"},
+		minYears:  2,
+	},
+	{
+		file:       "prose_only.txt",
+		required:   []string{"Java", "Spring Boot", "MySQL"},
+		niceToHave: []string{"Apache Kafka"},
+		minYears:   5,
+		location:   "on_site",
+	},
+	{
+		file:     "html_posting.txt",
+		title:    "Staff Data Engineer",
+		required: []string{"Scala", "Apache Spark"},
+		minYears: 8,
+		location: "remote",
+		level:    "lead",
+	},
+	{
+		file:       "mobile_ios.txt",
+		title:      "iOS Engineer",
+		niceToHave: []string{"Flutter", "Kotlin"},
+		minYears:   3, maxYears: 5,
+		degree:   "bachelor",
+		fields:   []string{"Computer Science", "Software Engineering"},
+		location: "hybrid",
+	},
+	{
+		file:     "manager.txt",
+		title:    "Engineering Manager, Platform",
+		required: []string{"AWS", "Agile"},
+		// Only a preferred master's degree, and Kubernetes only as a perk.
+		absent:   []string{"Kubernetes"},
+		minYears: 7,
+	},
+	{
+		file:      "indonesian_mixed.txt",
+		title:     "Backend Developer",
+		required:  []string{"Go", "PostgreSQL", "Redis", "RabbitMQ"},
+		preferred: []string{"REST"},
+		minYears:  2,
+		degree:    "bachelor",
+		location:  "on_site",
+	},
+	{
+		file:      "security.txt",
+		title:     "Lead Security Engineer",
+		required:  []string{"Python", "Go"},
+		preferred: []string{"Kubernetes"},
+		minYears:  6,
+		location:  "hybrid",
+		level:     "lead",
+	},
+}
+
+func TestExtractRequirements_Descriptions(t *testing.T) {
+	for _, tc := range descriptionCases {
+		t.Run(strings.TrimSuffix(tc.file, ".txt"), func(t *testing.T) {
+			data, err := os.ReadFile(filepath.Join("testdata", "descriptions", tc.file))
+			if err != nil {
+				t.Fatal(err)
+			}
+			job, err := ExtractRequirements(string(data))
+			if err != nil {
+				t.Fatalf("ExtractRequirements: %v", err)
+			}
+
+			if job.Title != tc.title {
+				t.Errorf("Title = %q, want %q", job.Title, tc.title)
+			}
+			assertContains(t, "RequiredSkills", job.RequiredSkills, tc.required)
+			assertContains(t, "PreferredSkills", job.PreferredSkills, tc.preferred)
+			assertContains(t, "NiceToHaveSkills", job.NiceToHaveSkills, tc.niceToHave)
+			all := append(append(append([]string{}, job.RequiredSkills...), job.PreferredSkills...), job.NiceToHaveSkills...)
+			for _, s := range tc.absent {
+				if indexOf(all, s) >= 0 {
+					t.Errorf("expected %q not to be extracted, got %v", s, all)
+				}
+			}
+			if job.MinYearsExperience != tc.minYears || job.MaxYearsExperience != tc.maxYears {
+				t.Errorf("years = %v-%v, want %v-%v", job.MinYearsExperience, job.MaxYearsExperience, tc.minYears, tc.maxYears)
+			}
+			if job.RequiredDegreeLevel != tc.degree {
+				t.Errorf("RequiredDegreeLevel = %q, want %q", job.RequiredDegreeLevel, tc.degree)
+			}
+			assertContains(t, "PreferredFields", job.PreferredFields, tc.fields)
+			if job.LocationType != tc.location {
+				t.Errorf("LocationType = %q, want %q", job.LocationType, tc.location)
+			}
+			if job.ExperienceLevel != tc.level {
+				t.Errorf("ExperienceLevel = %q, want %q", job.ExperienceLevel, tc.level)
+			}
+		})
+	}
+}
+
+func TestExtractRequirements_Empty(t *testing.T) {
+	for _, text := range []string{"", "  \n\t", "<p></p>"} {
+		if _, err := ExtractRequirements(text); !errors.Is(err, ErrEmptyDescription) {
+			t.Errorf("ExtractRequirements(%q) error = %v, want ErrEmptyDescription", text, err)
+		}
+	}
+}
+
+func TestExtractRequirements_SkillStrength(t *testing.T) {
+	// A skill named both as a requirement and as a bonus is required.
+	job, err := ExtractRequirements("Requirements:\n- Docker\n\nNice to have:\n- Docker and Terraform\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if indexOf(job.RequiredSkills, "Docker") < 0 || indexOf(job.NiceToHaveSkills, "Docker") >= 0 {
+		t.Errorf("expected Docker to be required only, got %+v", job)
+	}
+
+	// Lowercase everyday words are not skills.
+	job, _ = ExtractRequirements("You will hit the ground running and go the extra mile. Strong Python required.")
+	if indexOf(job.RequiredSkills, "Go") >= 0 {
+		t.Errorf("expected lowercase \"go\" to be ignored, got %v", job.RequiredSkills)
+	}
+}
+
+func TestDetectYears(t *testing.T) {
+	tests := []struct {
+		text     string
+		min, max float64
+	}{
+		{"5+ years of experience", 5, 0},
+		{"3-5 years of backend development", 3, 5},
+		{"3 to 5 yrs with Java", 3, 5},
+		{"At least two years of experience", 2, 0},
+		{"Founded 12 years ago", 0, 0},
+		{"Over the past 3 years we have grown", 0, 0},
+		{"2+ years with Go, 6+ years overall", 6, 0},
+	}
+	for _, tt := range tests {
+		min, max := detectYears(splitLines(tt.text))
+		if min != tt.min || max != tt.max {
+			t.Errorf("detectYears(%q) = %v-%v, want %v-%v", tt.text, min, max, tt.min, tt.max)
+		}
+	}
+
+	// A required figure wins over a larger preferred one.
+	min, _ := detectYears(splitLines("Requirements:\n- 2+ years of experience\n- 5+ years preferred"))
+	if min != 2 {
+		t.Errorf("expected the required figure, got %v", min)
+	}
+}
+
+func TestParseHeader(t *testing.T) {
+	tests := []struct {
+		line string
+		kind sectionKind
+		rest string
+		ok   bool
+	}{
+		{"Requirements", sectionRequired, "", true},
+		{"## Nice to have", sectionNiceToHave, "", true},
+		{"**What you’ll need:**", sectionRequired, "", true},
+		{"Nice to have (not required):", sectionNiceToHave, "", true},
+		{"Preferred: AWS, Docker.", sectionPreferred, "AWS, Docker.", true},
+		{"Experience with Kafka is a plus", sectionNone, "", false},
+	}
+	for _, tt := range tests {
+		kind, rest, ok := parseHeader(tt.line)
+		if kind != tt.kind || rest != tt.rest || ok != tt.ok {
+			t.Errorf("parseHeader(%q) = %v, %q, %v; want %v, %q, %v", tt.line, kind, rest, ok, tt.kind, tt.rest, tt.ok)
+		}
+	}
+}
+
+func assertContains(t *testing.T, field string, got, want []string) {
+	t.Helper()
+	for _, w := range want {
+		if indexOf(got, w) < 0 {
+			t.Errorf("%s = %v, missing %q", field, got, w)
+		}
+	}
+}
+
+func indexOf(list []string, s string) int {
+	for i, v := range list {
+		if v == s {
+			return i
+		}
+	}
+	return -1
+}
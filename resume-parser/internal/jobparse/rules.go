@@ -0,0 +1,252 @@
+package jobparse
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Title
+// ─────────────────────────────────────────────────────────────────────────────
+
+// titlePrefixRe matches an explicit title label.
+var titlePrefixRe = regexp.MustCompile(`(?i)^(job title|position|role|title)\s*:\s*`)
+
+// looksLikeTitle reports whether s could be a job title line rather than
+// prose.
+func looksLikeTitle(s string) bool {
+	if titlePrefixRe.MatchString(s) {
+		return true
+	}
+	if len(s) > 80 || len(strings.Fields(s)) > 10 {
+		return false
+	}
+	return !strings.ContainsAny(s[len(s)-1:], ".!?")
+}
+
+// detectTitle returns the job title: an explicitly labelled one, or else
+// the first line if it looks like a title.
+func detectTitle(lines []line) string {
+	for _, l := range lines {
+		if loc := titlePrefixRe.FindStringIndex(l.text); loc != nil {
+			return strings.TrimSpace(l.text[loc[1]:])
+		}
+	}
+	if len(lines) > 0 && lines[0].isTitle {
+		return lines[0].text
+	}
+	return ""
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Years of experience
+// ─────────────────────────────────────────────────────────────────────────────
+
+const yearNumber = `(\d{1,2}(?:\.\d)?|one|two|three|four|five|six|seven|eight|nine|ten|twelve|fifteen)`
+
+// yearsRe matches "5+ years", "3-5 years", "3 to 5 yrs" and "five years".
+// The trailing context is checked separately.
+var yearsRe = regexp.MustCompile(`(?i)` + yearNumber + `\s*(?:\+|plus)?\s*(?:(?:-|–|—|to)\s*` + yearNumber + `\s*\+?\s*)?(?:years?|yrs?)\b(?:\s*\([^)]*\))?`)
+
+// yearsNoiseRe rejects durations that are not experience requirements:
+// "founded 10 years ago", "over the past 2 years", "a 2 year contract".
+var (
+	yearsAfterNoiseRe  = regexp.MustCompile(`(?i)^[\s'’]*(ago|old|contract|warranty|vesting|cliff|in business)\b`)
+	yearsBeforeNoiseRe = regexp.MustCompile(`(?i)\b(past|last|next|first|for over|founded|within|every|after)\s*$`)
+)
+
+var numberWords = map[string]float64{
+	"one": 1, "two": 2, "three": 3, "four": 4, "five": 5, "six": 6, "seven": 7,
+	"eight": 8, "nine": 9, "ten": 10, "twelve": 12, "fifteen": 15,
+}
+
+// detectYears returns the minimum and maximum years of experience asked
+// for. When a posting gives several figures ("5+ years overall, 2+ years
+// with Kubernetes"), the largest minimum is the overall requirement.
+// Figures on required lines win over preferred ones.
+func detectYears(lines []line) (minYears, maxYears float64) {
+	best := ignored
+	for _, l := range lines {
+		if l.imp == ignored || l.imp > best {
+			continue
+		}
+		for _, m := range yearsRe.FindAllStringSubmatchIndex(l.text, -1) {
+			if yearsAfterNoiseRe.MatchString(l.text[m[1]:]) || yearsBeforeNoiseRe.MatchString(l.text[:m[0]]) {
+				continue
+			}
+			lo := parseYearNumber(l.text[m[2]:m[3]])
+			hi := 0.0
+			if m[4] >= 0 {
+				hi = parseYearNumber(l.text[m[4]:m[5]])
+			}
+			if lo <= 0 || lo > 40 {
+				continue
+			}
+			if l.imp < best {
+				best, minYears, maxYears = l.imp, 0, 0
+			}
+			if lo > minYears {
+				minYears, maxYears = lo, 0
+				if hi > lo {
+					maxYears = hi
+				}
+			}
+		}
+	}
+	return minYears, maxYears
+}
+
+func parseYearNumber(s string) float64 {
+	if v, ok := numberWords[strings.ToLower(s)]; ok {
+		return v
+	}
+	v, _ := strconv.ParseFloat(s, 64)
+	return v
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Degree
+// ─────────────────────────────────────────────────────────────────────────────
+
+// degreePatterns detect degree mentions, lowest level first.
+var degreePatterns = []struct {
+	level string
+	re    *regexp.Regexp
+}{
+	{"high_school", regexp.MustCompile(`(?i)\bhigh school\b|\bGED\b`)},
+	{"associate", regexp.MustCompile(`(?i)\bassociate'?s? degree\b|\bassociate of\b`)},
+	{"bachelor", regexp.MustCompile(`(?i)\bbachelor|\bundergraduate degree\b|\bB\.?S\.?c?\.?(?:\s+degree|\s+in\b|\s+or\b|/)|\bB\.?A\.?(?:\s+degree|\s+in\b|\s+or\b|/)|\bBSc\b|\bBEng\b|\bS1\b`)},
+	{"master", regexp.MustCompile(`(?i)\bmaster'?s\b|\bmasters? (?:degree|of)\b|\bM\.?S\.?c?\.?(?:\s+degree|\s+in\b|\s+or\b)|/M\.?S\b|\bMSc\b|\bMBA\b|\bS2\b`)},
+	{"doctorate", regexp.MustCompile(`(?i)\bph\.?\s?d\b|\bdoctorate\b|\bdoctoral\b`)},
+}
+
+// fieldsRe captures the field list of "degree in Computer Science,
+// Engineering, or a related field".
+var fieldsRe = regexp.MustCompile(`(?i)(?:degree|bachelor'?s?|master'?s?|B\.?S\.?c?|M\.?S\.?c?|B\.?A|ph\.?\s?d)\.?\s+(?:in|of)\s+([^.;:()\n]+)`)
+
+var fieldSplitRe = regexp.MustCompile(`(?i)\s*(?:,|/|\bor\b|\band\b|&)\s*`)
+
+// detectDegree returns the lowest degree level required, ignoring degrees
+// that are only preferred, and the fields of study named with any degree.
+func detectDegree(lines []line) (level string, fields []string) {
+	requiredRank := -1
+	seenField := map[string]bool{}
+	for _, l := range lines {
+		if l.imp == ignored {
+			continue
+		}
+		text := strings.ReplaceAll(l.text, "’", "'")
+		if l.imp == mustHave {
+			for rank, d := range degreePatterns {
+				if d.re.MatchString(text) {
+					if requiredRank < 0 || rank < requiredRank {
+						requiredRank = rank
+					}
+					break
+				}
+			}
+		}
+		for _, m := range fieldsRe.FindAllStringSubmatch(text, -1) {
+			for _, f := range fieldSplitRe.Split(m[1], -1) {
+				f = strings.TrimSpace(strings.TrimPrefix(strings.TrimPrefix(f, "a "), "an "))
+				if f == "" || isGenericField(f) || seenField[strings.ToLower(f)] {
+					continue
+				}
+				seenField[strings.ToLower(f)] = true
+				fields = append(fields, f)
+			}
+		}
+	}
+	if requiredRank >= 0 {
+		level = degreePatterns[requiredRank].level
+	}
+	return level, fields
+}
+
+// isGenericField reports whether a field-of-study fragment is filler such
+// as "a related field" or "equivalent experience".
+func isGenericField(f string) bool {
+	lower := strings.ToLower(f)
+	for _, w := range []string{"related", "equivalent", "similar", "field", "discipline", "experience", "relevant", "years"} {
+		if strings.Contains(lower, w) {
+			return true
+		}
+	}
+	return len(strings.Fields(lower)) > 5
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Work arrangement and seniority
+// ─────────────────────────────────────────────────────────────────────────────
+
+var (
+	hybridRe    = regexp.MustCompile(`(?i)\bhybrid\b`)
+	notRemoteRe = regexp.MustCompile(`(?i)\b(not|no|non)[ -](a )?remote\b|\bremote (work )?is not\b`)
+	remoteRe    = regexp.MustCompile(`(?i)\b(remote|work from home|wfh|fully distributed|work from anywhere)\b`)
+	onSiteRe    = regexp.MustCompile(`(?i)\b(on[- ]?site|in[- ]office|in the office|office[- ]based|work from (the )?office|wfo)\b`)
+
+	// remotePerkRe matches benefits that mention remote work without making
+	// the job remote.
+	remotePerkRe = regexp.MustCompile(`(?i)\bremote[- ](work |office )?(stipend|allowance|budget|setup)\b`)
+)
+
+// detectLocationType returns "hybrid", "remote" or "on_site", or "" when
+// the posting does not say. Every section is read, since the arrangement is
+// often stated at the very end, but perks such as a "remote work stipend"
+// do not count.
+func detectLocationType(lines []line) string {
+	var text strings.Builder
+	for _, l := range lines {
+		text.WriteString(l.text)
+		text.WriteByte('\n')
+	}
+	s := remotePerkRe.ReplaceAllString(text.String(), "")
+	switch {
+	case hybridRe.MatchString(s):
+		return "hybrid"
+	case notRemoteRe.MatchString(s):
+		return "on_site"
+	case remoteRe.MatchString(s):
+		return "remote"
+	case onSiteRe.MatchString(s):
+		return "on_site"
+	}
+	return ""
+}
+
+// seniorityPatterns map title keywords to scorer experience levels, most
+// specific first.
+var seniorityPatterns = []struct {
+	level string
+	re    *regexp.Regexp
+}{
+	{"internship", regexp.MustCompile(`(?i)\bintern(ship)?\b`)},
+	{"executive", regexp.MustCompile(`(?i)\b(head of|director|vp|vice president|chief|cto|cio)\b`)},
+	{"lead", regexp.MustCompile(`(?i)\b(lead|principal|staff)\b`)},
+	{"senior", regexp.MustCompile(`(?i)\b(senior|sr\.?)\b`)},
+	{"mid", regexp.MustCompile(`(?i)\b(mid[- ]level|mid[- ]senior|intermediate)\b`)},
+	{"entry", regexp.MustCompile(`(?i)\b(junior|jr\.?|entry[- ]level|graduate|new grad)\b`)},
+}
+
+// levelInTextRe matches seniority stated in the body of a posting.
+var levelInTextRe = regexp.MustCompile(`(?i)\b(entry|mid|senior)[- ]level\b`)
+
+// detectExperienceLevel returns the seniority named in the title, or else
+// an explicit "X-level" phrase in the body.
+func detectExperienceLevel(title string, lines []line) string {
+	for _, p := range seniorityPatterns {
+		if p.re.MatchString(title) {
+			return p.level
+		}
+	}
+	for _, l := range lines {
+		if l.imp == ignored {
+			continue
+		}
+		if m := levelInTextRe.FindStringSubmatch(l.text); m != nil {
+			return strings.ToLower(m[1])
+		}
+	}
+	return ""
+}
@@ -0,0 +1,254 @@
+package jobparse
+
+import (
+	"regexp"
+	"strings"
+)
+
+// sectionKind classifies a job description section by its header.
+type sectionKind int
+
+const (
+	sectionNone        sectionKind = iota // before the first header
+	sectionRequired                       // "Requirements", "What you'll need"
+	sectionPreferred                      // "Preferred qualifications"
+	sectionNiceToHave                     // "Nice to have", "Bonus points"
+	sectionDescriptive                    // "Responsibilities", "About the role"
+	sectionIgnored                        // "Benefits", "About us"
+)
+
+// importance is how strongly a line asks for what it mentions. Lower values
+// are stronger.
+type importance int
+
+const (
+	mustHave importance = iota
+	shouldHave
+	bonus
+	ignored
+)
+
+// sectionHeaders maps lowercase header text to the section it opens.
+var sectionHeaders = map[string]sectionKind{
+	"requirements":                  sectionRequired,
+	"job requirements":              sectionRequired,
+	"required":                      sectionRequired,
+	"required skills":               sectionRequired,
+	"required qualifications":       sectionRequired,
+	"requirements & qualifications": sectionRequired,
+	"qualifications":                sectionRequired,
+	"minimum qualifications":        sectionRequired,
+	"basic qualifications":          sectionRequired,
+	"must have":                     sectionRequired,
+	"must-have":                     sectionRequired,
+	"must haves":                    sectionRequired,
+	"what you need":                 sectionRequired,
+	"what you'll need":              sectionRequired,
+	"what you will need":            sectionRequired,
+	"what we're looking for":        sectionRequired,
+	"what we are looking for":       sectionRequired,
+	"who you are":                   sectionRequired,
+	"about you":                     sectionRequired,
+	"you have":                      sectionRequired,
+	"you should have":               sectionRequired,
+	"your profile":                  sectionRequired,
+	"skills":                        sectionRequired,
+	"skills & experience":           sectionRequired,
+	"skills and experience":         sectionRequired,
+	"experience":                    sectionRequired,
+	"kualifikasi":                   sectionRequired,
+	"persyaratan":                   sectionRequired,
+
+	"preferred":                 sectionPreferred,
+	"preferred qualifications":  sectionPreferred,
+	"preferred skills":          sectionPreferred,
+	"preferred experience":      sectionPreferred,
+	"desired":                   sectionPreferred,
+	"desired skills":            sectionPreferred,
+	"desired qualifications":    sectionPreferred,
+	"desirable":                 sectionPreferred,
+	"additional qualifications": sectionPreferred,
+	"ideally you have":          sectionPreferred,
+	"good to have":              sectionPreferred,
+
+	"nice to have":     sectionNiceToHave,
+	"nice-to-have":     sectionNiceToHave,
+	"nice to haves":    sectionNiceToHave,
+	"nice-to-haves":    sectionNiceToHave,
+	"bonus":            sectionNiceToHave,
+	"bonus points":     sectionNiceToHave,
+	"bonus points for": sectionNiceToHave,
+	"bonus points if":  sectionNiceToHave,
+	"plus":             sectionNiceToHave,
+	"pluses":           sectionNiceToHave,
+	"extra credit":     sectionNiceToHave,
+	"even better if":   sectionNiceToHave,
+	"it's a plus if":   sectionNiceToHave,
+	"it's a bonus if":  sectionNiceToHave,
+
+	"responsibilities":      sectionDescriptive,
+	"key responsibilities":  sectionDescriptive,
+	"your responsibilities": sectionDescriptive,
+	"what you'll do":        sectionDescriptive,
+	"what you will do":      sectionDescriptive,
+	"what you'll be doing":  sectionDescriptive,
+	"the role":              sectionDescriptive,
+	"about the role":        sectionDescriptive,
+	"your role":             sectionDescriptive,
+	"role overview":         sectionDescriptive,
+	"job description":       sectionDescriptive,
+	"description":           sectionDescriptive,
+	"overview":              sectionDescriptive,
+	"day to day":            sectionDescriptive,
+	"the team":              sectionDescriptive,
+	"about the team":        sectionDescriptive,
+	"tech stack":            sectionDescriptive,
+	"our stack":             sectionDescriptive,
+	"tanggung jawab":        sectionDescriptive,
+	"deskripsi pekerjaan":   sectionDescriptive,
+
+	"benefits":           sectionIgnored,
+	"perks":              sectionIgnored,
+	"perks & benefits":   sectionIgnored,
+	"benefits & perks":   sectionIgnored,
+	"perks and benefits": sectionIgnored,
+	"what we offer":      sectionIgnored,
+	"we offer":           sectionIgnored,
+	"about us":           sectionIgnored,
+	"about the company":  sectionIgnored,
+	"who we are":         sectionIgnored,
+	"why join us":        sectionIgnored,
+	"why us":             sectionIgnored,
+	"compensation":       sectionIgnored,
+	"salary":             sectionIgnored,
+	"how to apply":       sectionIgnored,
+	"equal opportunity":  sectionIgnored,
+	"our values":         sectionIgnored,
+}
+
+// Cues on a line that override its section's importance. Nice-to-have cues
+// are checked first, so "not required" is not read as "required".
+var (
+	niceToHaveCueRe = regexp.MustCompile(`(?i)\b(nice[ -]to[ -]have|(is|are|would be|as) an? (plus|bonus)|a (big |huge )?plus|bonus points|not required|not a must)\b`)
+	preferredCueRe  = regexp.MustCompile(`(?i)\b(preferred|preferably|ideally|desirable|desired|is an advantage|an advantage|would be great)\b`)
+	requiredCueRe   = regexp.MustCompile(`(?i)\b(must|required|mandatory|essential)\b`)
+
+	sentenceSplitRe = regexp.MustCompile(`[.!?;]\s+`)
+	bulletPrefixRe  = regexp.MustCompile(`^[\s•\-\*‣◦⁃∙>·]+`)
+	htmlTagRe       = regexp.MustCompile(`<[^>]+>`)
+)
+
+// line is one sentence of a description, annotated with the section it
+// belongs to.
+type line struct {
+	text    string
+	section sectionKind
+	isTitle bool
+	imp     importance
+}
+
+// splitLines splits text into sentences, one or more per non-empty line.
+// Header lines set the section of the lines below them and are not returned
+// themselves, unless the header shares a line with content ("Requirements:
+// 3+ years of Go").
+func splitLines(text string) []line {
+	var lines []line
+	section := sectionNone
+	first := true
+	for _, raw := range strings.Split(text, "\n") {
+		trimmed := strings.TrimSpace(bulletPrefixRe.ReplaceAllString(raw, ""))
+		if trimmed == "" {
+			continue
+		}
+		if kind, rest, ok := parseHeader(trimmed); ok {
+			section = kind
+			first = false
+			if rest == "" {
+				continue
+			}
+			trimmed = rest
+		}
+		if first && looksLikeTitle(trimmed) {
+			lines = append(lines, line{text: trimmed, section: section, isTitle: true})
+		} else {
+			// Cues apply per sentence: in "We use Java. Kafka is a plus."
+			// only Kafka is a bonus.
+			for _, sentence := range sentenceSplitRe.Split(trimmed, -1) {
+				if sentence = strings.TrimSpace(sentence); sentence != "" {
+					lines = append(lines, line{text: sentence, section: section})
+				}
+			}
+		}
+		first = false
+	}
+
+	hasRequirements := false
+	for _, l := range lines {
+		if l.section == sectionRequired {
+			hasRequirements = true
+			break
+		}
+	}
+	for i := range lines {
+		lines[i].imp = lines[i].importance(hasRequirements)
+	}
+	return lines
+}
+
+// parseHeader reports whether s is a section header, either on its own
+// line ("Nice to have:", "## Benefits") or followed by content after a
+// colon. It returns the section and any content.
+func parseHeader(s string) (sectionKind, string, bool) {
+	head, rest := s, ""
+	if i := strings.Index(s, ":"); i >= 0 {
+		head, rest = s[:i], strings.Trim(s[i+1:], "*_ \t")
+	}
+	clean := strings.ToLower(strings.Trim(strings.ReplaceAll(head, "’", "'"), "#*_=- \t"))
+	if kind, ok := sectionHeaders[clean]; ok {
+		return kind, rest, true
+	}
+	// Headers often carry a qualifier: "Nice to have (not required)",
+	// "Requirements for this role".
+	if rest == "" && strings.HasSuffix(strings.TrimSpace(s), ":") && len(clean) <= 60 {
+		best := ""
+		for h := range sectionHeaders {
+			if len(h) > len(best) && strings.HasPrefix(clean, h+" ") {
+				best = h
+			}
+		}
+		if best != "" {
+			return sectionHeaders[best], "", true
+		}
+	}
+	return sectionNone, "", false
+}
+
+// importance returns how strongly the line asks for the skills in it. Lines
+// in descriptive sections are required when the posting has no explicit
+// requirements section, and preferred otherwise.
+func (l line) importance(hasRequirements bool) importance {
+	switch {
+	case l.section == sectionIgnored:
+		return ignored
+	case l.isTitle:
+		return mustHave
+	case niceToHaveCueRe.MatchString(l.text):
+		return bonus
+	case preferredCueRe.MatchString(l.text):
+		return shouldHave
+	case requiredCueRe.MatchString(l.text):
+		return mustHave
+	}
+	switch l.section {
+	case sectionRequired:
+		return mustHave
+	case sectionPreferred:
+		return shouldHave
+	case sectionNiceToHave:
+		return bonus
+	}
+	if hasRequirements {
+		return shouldHave
+	}
+	return mustHave
+}
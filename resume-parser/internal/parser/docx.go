@@ -4,21 +4,29 @@ import (
 	"archive/zip"
 	"bytes"
 	"encoding/xml"
+	"errors"
 	"fmt"
 	"io"
 	"strings"
+	"unicode/utf8"
 )
 
 // DOCXParser extracts text from DOCX files.
 // DOCX files are ZIP archives containing XML documents.
-type DOCXParser struct{}
+type DOCXParser struct {
+	maxChars int
+}
 
 // NewDOCXParser creates a new DOCXParser instance.
 func NewDOCXParser() *DOCXParser {
-	return &DOCXParser{}
+	return &DOCXParser{maxChars: DefaultMaxTextLength}
 }
 
 // ExtractText extracts all text content from a DOCX byte slice.
+//
+// The document XML is decoded as it is decompressed, and extraction stops
+// with a TEXT_TOO_LONG error as soon as the text exceeds the parser's
+// character limit.
 func (d *DOCXParser) ExtractText(data []byte) (string, error) {
 	if len(data) == 0 {
 		return "", &ParseError{Code: "EMPTY_FILE", Message: "DOCX file is empty"}
@@ -34,24 +42,10 @@ func (d *DOCXParser) ExtractText(data []byte) (string, error) {
 	}
 
 	// Find word/document.xml
-	var docXML []byte
+	var docXML *zip.File
 	for _, f := range r.File {
 		if f.Name == "word/document.xml" {
-			rc, err := f.Open()
-			if err != nil {
-				return "", &ParseError{
-					Code:    "DOCX_READ_ERROR",
-					Message: fmt.Sprintf("failed to open word/document.xml: %v", err),
-				}
-			}
-			docXML, err = io.ReadAll(rc)
-			rc.Close()
-			if err != nil {
-				return "", &ParseError{
-					Code:    "DOCX_READ_ERROR",
-					Message: fmt.Sprintf("failed to read word/document.xml: %v", err),
-				}
-			}
+			docXML = f
 			break
 		}
 	}
@@ -63,7 +57,20 @@ func (d *DOCXParser) ExtractText(data []byte) (string, error) {
 		}
 	}
 
-	text, err := extractTextFromWordXML(docXML)
+	rc, err := docXML.Open()
+	if err != nil {
+		return "", &ParseError{
+			Code:    "DOCX_READ_ERROR",
+			Message: fmt.Sprintf("failed to open word/document.xml: %v", err),
+		}
+	}
+	defer rc.Close()
+
+	text, err := extractTextFromWordXML(rc, d.maxChars)
+	var perr *ParseError
+	if errors.As(err, &perr) {
+		return "", perr
+	}
 	if err != nil {
 		return "", &ParseError{
 			Code:    "DOCX_PARSE_ERROR",
@@ -81,26 +88,113 @@ func (d *DOCXParser) ExtractText(data []byte) (string, error) {
 	return cleanText(text), nil
 }
 
-// wordXMLElement represents a simplified Word XML element for text extraction.
-type wordXMLElement struct {
-	XMLName xml.Name
-	Attrs   []xml.Attr `xml:",any,attr"`
-	Content string     `xml:",chardata"`
-	Inner   []wordXMLElement `xml:",any"`
+// wordParagraph accumulates the text of a w:p element.
+type wordParagraph struct {
+	text   strings.Builder
+	bullet bool
+	level  int
+}
+
+// line returns the paragraph as a line of text, or "" if it has no text.
+func (p *wordParagraph) line() string {
+	text := strings.TrimRight(p.text.String(), " \t")
+	if strings.TrimSpace(text) == "" {
+		return ""
+	}
+	if p.bullet {
+		return strings.Repeat("  ", p.level) + "• " + strings.TrimLeft(text, " \t")
+	}
+	return text
+}
+
+// wordTable accumulates the rows of a w:tbl element.
+type wordTable struct {
+	rows []wordRow
+}
+
+// wordRow is one w:tr; each cell holds its paragraph lines.
+type wordRow struct {
+	cells [][]string
+}
+
+// lines flattens the table into text lines.
+func (t *wordTable) lines() []string {
+	var out []string
+	for _, row := range t.rows {
+		layout := false
+		var flat []string
+		for _, cell := range row.cells {
+			if len(cell) > 1 {
+				layout = true
+			}
+			if len(cell) == 1 {
+				flat = append(flat, strings.TrimSpace(cell[0]))
+			}
+		}
+		if layout {
+			for _, cell := range row.cells {
+				out = append(out, cell...)
+			}
+			continue
+		}
+		if len(flat) > 0 {
+			out = append(out, strings.Join(flat, " | "))
+		}
+	}
+	return out
 }
 
-// extractTextFromWordXML parses Word XML and extracts text content,
-// preserving paragraph structure.
-func extractTextFromWordXML(data []byte) (string, error) {
-	decoder := xml.NewDecoder(bytes.NewReader(data))
-	var sb strings.Builder
+// extractTextFromWordXML parses Word XML and extracts text content, one line
+// per paragraph in document order:
+//   - list paragraphs (w:numPr) are prefixed with "• " and indented two
+//     spaces per nesting level, so bullet structure survives into section
+//     detection;
+//   - table rows whose cells hold at most one paragraph (e.g. a skills
+//     matrix) are flattened into a single "cell | cell" line;
+//   - rows with multi-paragraph cells are layout tables (typically a
+//     two-column resume) and are emitted cell by cell, keeping each column
+//     contiguous;
+//   - text boxes are emitted where they are anchored, and the VML fallback
+//     copy of drawing content (mc:Fallback) is skipped to avoid duplicates.
+//
+// It returns a TEXT_TOO_LONG *ParseError once the text runs exceed maxChars
+// characters.
+func extractTextFromWordXML(r io.Reader, maxChars int) (string, error) {
+	decoder := xml.NewDecoder(r)
+	chars := 0
+
+	var (
+		out        []string
+		paragraphs []*wordParagraph
+		tables     []*wordTable
+		inText     bool
+		skipDepth  int // > 0 while inside mc:Fallback
+	)
 
-	type stackEntry struct {
-		name string
+	// emit appends a line to the innermost open table cell, or to the
+	// document when outside tables.
+	emit := func(lines ...string) {
+		if len(tables) == 0 {
+			out = append(out, lines...)
+			return
+		}
+		t := tables[len(tables)-1]
+		if len(t.rows) == 0 {
+			t.rows = append(t.rows, wordRow{})
+		}
+		row := &t.rows[len(t.rows)-1]
+		if len(row.cells) == 0 {
+			row.cells = append(row.cells, nil)
+		}
+		last := len(row.cells) - 1
+		row.cells[last] = append(row.cells[last], lines...)
+	}
+	current := func() *wordParagraph {
+		if len(paragraphs) == 0 {
+			return nil
+		}
+		return paragraphs[len(paragraphs)-1]
 	}
-	var stack []stackEntry
-	inParagraph := false
-	paragraphHasText := false
 
 	for {
 		token, err := decoder.Token()
@@ -113,44 +207,91 @@ func extractTextFromWordXML(data []byte) (string, error) {
 
 		switch t := token.(type) {
 		case xml.StartElement:
-			localName := t.Name.Local
-			stack = append(stack, stackEntry{name: localName})
-
-			switch localName {
+			if skipDepth > 0 {
+				skipDepth++
+				continue
+			}
+			switch t.Name.Local {
+			case "Fallback":
+				skipDepth = 1
 			case "p": // w:p - paragraph
-				inParagraph = true
-				paragraphHasText = false
-			case "br": // w:br - line break
-				sb.WriteRune('\n')
+				paragraphs = append(paragraphs, &wordParagraph{})
+			case "numPr": // w:numPr - list membership
+				if p := current(); p != nil {
+					p.bullet = true
+				}
+			case "ilvl": // w:ilvl - list nesting level
+				if p := current(); p != nil {
+					for _, a := range t.Attr {
+						if a.Name.Local == "val" {
+							fmt.Sscanf(a.Value, "%d", &p.level)
+						}
+					}
+				}
+			case "t": // w:t - text run
+				inText = true
+			case "br", "cr": // w:br, w:cr - line break
+				if p := current(); p != nil {
+					p.text.WriteRune('\n')
+				}
 			case "tab": // w:tab
-				sb.WriteRune('\t')
+				if p := current(); p != nil {
+					p.text.WriteRune('\t')
+				}
+			case "tbl":
+				tables = append(tables, &wordTable{})
+			case "tr":
+				if len(tables) > 0 {
+					tbl := tables[len(tables)-1]
+					tbl.rows = append(tbl.rows, wordRow{})
+				}
+			case "tc":
+				if len(tables) > 0 {
+					tbl := tables[len(tables)-1]
+					if len(tbl.rows) == 0 {
+						tbl.rows = append(tbl.rows, wordRow{})
+					}
+					row := &tbl.rows[len(tbl.rows)-1]
+					row.cells = append(row.cells, nil)
+				}
 			}
 
 		case xml.EndElement:
-			if len(stack) > 0 {
-				top := stack[len(stack)-1]
-				stack = stack[:len(stack)-1]
-
-				if top.name == "p" {
-					inParagraph = false
-					if paragraphHasText {
-						sb.WriteRune('\n')
+			if skipDepth > 0 {
+				skipDepth--
+				continue
+			}
+			switch t.Name.Local {
+			case "t":
+				inText = false
+			case "p":
+				if p := current(); p != nil {
+					paragraphs = paragraphs[:len(paragraphs)-1]
+					if line := p.line(); line != "" {
+						emit(line)
 					}
 				}
+			case "tbl":
+				if len(tables) > 0 {
+					tbl := tables[len(tables)-1]
+					tables = tables[:len(tables)-1]
+					emit(tbl.lines()...)
+				}
 			}
 
 		case xml.CharData:
-			if inParagraph {
-				text := string(t)
-				if strings.TrimSpace(text) != "" {
-					sb.WriteString(text)
-					paragraphHasText = true
+			if inText && skipDepth == 0 {
+				if p := current(); p != nil {
+					if chars += utf8.RuneCount(t); chars > maxChars {
+						return "", textTooLongError(maxChars, fmt.Sprintf("paragraph %d", len(out)+1))
+					}
+					p.text.Write(t)
 				}
 			}
 		}
 	}
 
-	return sb.String(), nil
+	return strings.Join(out, "\n"), nil
 }
 
 // ParseError represents a document parsing error.
@@ -4,7 +4,12 @@ import (
 	"archive/zip"
 	"bytes"
 	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+
+	"github.com/learnbot/resume-parser/internal/schema"
 )
 
 // buildDOCXWithContent creates a DOCX with specific XML content.
@@ -112,3 +117,140 @@ func TestDOCXParser_WithTabs(t *testing.T) {
 		t.Error("expected non-empty text")
 	}
 }
+
+// loadDOCXFixture packages testdata/docx/<name>.xml (a w:body fragment) as
+// a DOCX file.
+func loadDOCXFixture(t *testing.T, name string) []byte {
+	t.Helper()
+	body, err := os.ReadFile(filepath.Join("testdata", "docx", name+".xml"))
+	if err != nil {
+		t.Fatalf("read fixture: %v", err)
+	}
+	return buildDOCXWithContent(string(body))
+}
+
+// TestDOCXParser_SingleColumnFixture tests run joining and bullet structure.
+func TestDOCXParser_SingleColumnFixture(t *testing.T) {
+	text, err := NewDOCXParser().ExtractText(loadDOCXFixture(t, "single_column"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, want := range []string{
+		"jane.doe@example.com | 555-123-4567\n",
+		"Senior Software Engineer\n",
+		"• Built payment services in Go\n  • Cut p99 latency by 40%\n• Mentored four engineers\n",
+	} {
+		if !strings.Contains(text, want) {
+			t.Errorf("expected text to contain %q, got:\n%s", want, text)
+		}
+	}
+
+	parsed, err := NewResumeParser().Parse(schema.ParseRequest{FileName: "single.docx", FileContent: loadDOCXFixture(t, "single_column")})
+	if err != nil {
+		t.Fatalf("unexpected parse error: %v", err)
+	}
+	if len(parsed.WorkExperience) != 1 || len(parsed.WorkExperience[0].Responsibilities) < 2 {
+		t.Errorf("expected one job with bullet responsibilities, got %+v", parsed.WorkExperience)
+	}
+	if len(parsed.Skills) != 4 {
+		t.Errorf("expected 4 skills, got %+v", parsed.Skills)
+	}
+}
+
+// TestDOCXParser_TwoColumnFixture tests that layout table columns stay
+// contiguous and text box fallbacks are not duplicated.
+func TestDOCXParser_TwoColumnFixture(t *testing.T) {
+	text, err := NewDOCXParser().ExtractText(loadDOCXFixture(t, "two_column"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !strings.Contains(text, "SKILLS\n• Go\n• PostgreSQL\nEXPERIENCE\nBackend Engineer\n") {
+		t.Errorf("expected each column to be emitted contiguously, got:\n%s", text)
+	}
+	if n := strings.Count(text, "Open to relocation"); n != 1 {
+		t.Errorf("expected the text box once, got %d times:\n%s", n, text)
+	}
+
+	parsed, err := NewResumeParser().Parse(schema.ParseRequest{FileName: "two.docx", FileContent: loadDOCXFixture(t, "two_column")})
+	if err != nil {
+		t.Fatalf("unexpected parse error: %v", err)
+	}
+	if len(parsed.Skills) != 2 {
+		t.Errorf("expected the sidebar skills only, got %+v", parsed.Skills)
+	}
+	if len(parsed.WorkExperience) == 0 {
+		t.Error("expected work experience from the main column")
+	}
+}
+
+// TestDOCXParser_SkillsTableFixture tests that table rows are flattened.
+func TestDOCXParser_SkillsTableFixture(t *testing.T) {
+	text, err := NewDOCXParser().ExtractText(loadDOCXFixture(t, "skills_table"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !strings.Contains(text, "SKILLS\nLanguages | Go, Python\nDatabases | PostgreSQL\nCloud | AWS\nCERTIFICATIONS") {
+		t.Errorf("expected one line per table row, got:\n%s", text)
+	}
+
+	parsed, err := NewResumeParser().Parse(schema.ParseRequest{FileName: "table.docx", FileContent: loadDOCXFixture(t, "skills_table")})
+	if err != nil {
+		t.Fatalf("unexpected parse error: %v", err)
+	}
+	got := map[string]bool{}
+	for _, s := range parsed.Skills {
+		got[s.Name] = true
+	}
+	for _, want := range []string{"Go", "Python", "PostgreSQL", "AWS"} {
+		if !got[want] {
+			t.Errorf("expected skill %q from the table, got %+v", want, parsed.Skills)
+		}
+	}
+}
+
+// TestDetectFileType tests content-based file type detection.
+func TestDetectFileType(t *testing.T) {
+	tests := []struct {
+		name string
+		data []byte
+		want string
+	}{
+		{"docx", buildDOCXWithContent(`<w:p><w:r><w:t>x</w:t></w:r></w:p>`), "docx"},
+		{"pdf", []byte("%PDF-1.7\n..."), "pdf"},
+		{"plain zip", buildZIP(t, "readme.txt"), ""},
+		{"text", []byte("just some text"), ""},
+		{"empty", nil, ""},
+	}
+	for _, tt := range tests {
+		if got := DetectFileType(tt.data); got != tt.want {
+			t.Errorf("%s: DetectFileType = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+
+	// Content wins over a misleading name and type.
+	parsed, err := NewResumeParser().Parse(schema.ParseRequest{
+		FileName:    "resume.bin",
+		FileContent: loadDOCXFixture(t, "single_column"),
+		FileType:    "application/octet-stream",
+	})
+	if err != nil || parsed.FileType != "docx" {
+		t.Errorf("expected a sniffed DOCX, got %v, %v", parsed, err)
+	}
+}
+
+// buildZIP creates a ZIP archive holding empty files with the given names.
+func buildZIP(t *testing.T, names ...string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	w := zip.NewWriter(&buf)
+	for _, name := range names {
+		if _, err := w.Create(name); err != nil {
+			t.Fatal(err)
+		}
+	}
+	w.Close()
+	return buf.Bytes()
+}
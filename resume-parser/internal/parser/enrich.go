@@ -0,0 +1,36 @@
+package parser
+
+import (
+	"github.com/learnbot/resume-parser/internal/schema"
+	"github.com/learnbot/resume-parser/internal/taxonomy"
+)
+
+// enrichSkills resolves each extracted skill to its taxonomy node using the
+// taxonomy's alias lists, falling back to fuzzy matching for near-misses
+// such as "Postgres SQL". Skills with no match are flagged as unresolved.
+func enrichSkills(skills []schema.Skill, tax *taxonomy.Taxonomy) {
+	for i := range skills {
+		res := tax.Normalize(skills[i].Name)
+		skills[i].MatchType = res.MatchType
+		if res.CanonicalID == "" {
+			skills[i].Unresolved = true
+			continue
+		}
+		skills[i].TaxonomyNode = &schema.SkillNodeRef{
+			ID:            res.CanonicalID,
+			CanonicalName: res.CanonicalName,
+			Domain:        string(res.Domain),
+			Category:      string(res.Category),
+		}
+	}
+}
+
+// skillAliases returns the taxonomy's other names for skill: its canonical
+// name and aliases, or nil if the skill is not in the taxonomy.
+func (rp *ResumeParser) skillAliases(skill string) []string {
+	node := rp.taxonomy.LookupName(skill)
+	if node == nil {
+		return nil
+	}
+	return append([]string{node.CanonicalName}, node.Aliases...)
+}
@@ -0,0 +1,179 @@
+package parser
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/learnbot/resume-parser/internal/schema"
+)
+
+// buildMultiPagePDF creates a valid PDF with one page per entry of pages,
+// each showing its lines in Helvetica.
+func buildMultiPagePDF(pages [][]string) []byte {
+	n := len(pages)
+	// Objects: 1 catalog, 2 page tree, 3 font, then a page and its content
+	// stream for each page.
+	objects := make([]string, 3+2*n)
+	kids := make([]string, n)
+	for i, lines := range pages {
+		pageObj, contentObj := 4+2*i, 5+2*i
+		kids[i] = fmt.Sprintf("%d 0 R", pageObj)
+
+		var content strings.Builder
+		content.WriteString("BT /F1 10 Tf 14 TL 50 750 Td\n")
+		for _, line := range lines {
+			fmt.Fprintf(&content, "(%s) Tj T*\n", line)
+		}
+		content.WriteString("ET")
+
+		objects[pageObj-1] = fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 612 792] "+
+			"/Resources << /Font << /F1 3 0 R >> >> /Contents %d 0 R >>", contentObj)
+		objects[contentObj-1] = fmt.Sprintf("<< /Length %d >>\nstream\n%s\nendstream", content.Len(), content.String())
+	}
+	objects[0] = "<< /Type /Catalog /Pages 2 0 R >>"
+	objects[1] = fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), n)
+	objects[2] = "<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica >>"
+
+	var buf bytes.Buffer
+	buf.WriteString("%PDF-1.4\n")
+	offsets := make([]int, len(objects))
+	for i, obj := range objects {
+		offsets[i] = buf.Len()
+		fmt.Fprintf(&buf, "%d 0 obj\n%s\nendobj\n", i+1, obj)
+	}
+	xref := buf.Len()
+	fmt.Fprintf(&buf, "xref\n0 %d\n0000000000 65535 f \n", len(objects)+1)
+	for _, off := range offsets {
+		fmt.Fprintf(&buf, "%010d 00000 n \n", off)
+	}
+	fmt.Fprintf(&buf, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(objects)+1, xref)
+	return buf.Bytes()
+}
+
+// largeResumePages returns n pages of experience bullet points.
+func largeResumePages(n int) [][]string {
+	pages := make([][]string, n)
+	for i := range pages {
+		for j := 0; j < 40; j++ {
+			pages[i] = append(pages[i], fmt.Sprintf("Page %d item %d built and operated Go services on Kubernetes", i+1, j+1))
+		}
+	}
+	return pages
+}
+
+// buildLargeDOCX creates a DOCX with n paragraphs of experience text.
+func buildLargeDOCX(n int) []byte {
+	lines := make([]string, n)
+	for i := range lines {
+		lines[i] = fmt.Sprintf("Item %d built and operated Go services on Kubernetes", i+1)
+	}
+	return buildMinimalDOCX(strings.Join(lines, "\n"))
+}
+
+func TestPDFParser_MultiPageDocument(t *testing.T) {
+	data := buildMultiPagePDF(largeResumePages(50))
+
+	text, err := NewPDFParser().ExtractText(data)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, want := range []string{"Page 1 item 1", "Page 50 item 40"} {
+		if !strings.Contains(text, want) {
+			t.Errorf("expected text to contain %q", want)
+		}
+	}
+}
+
+func TestPDFParser_TextTooLong(t *testing.T) {
+	p := NewPDFParser()
+	p.maxChars = 10_000 // about four pages of largeResumePages
+
+	_, err := p.ExtractText(buildMultiPagePDF(largeResumePages(50)))
+	var perr *ParseError
+	if !errors.As(err, &perr) || perr.Code != "TEXT_TOO_LONG" {
+		t.Fatalf("expected TEXT_TOO_LONG, got %v", err)
+	}
+	if !strings.Contains(perr.Message, "page 5 of 50") {
+		t.Errorf("expected the error to name the page extraction stopped at, got %q", perr.Message)
+	}
+}
+
+func TestDOCXParser_TextTooLong(t *testing.T) {
+	p := NewDOCXParser()
+	p.maxChars = 10_000
+
+	_, err := p.ExtractText(buildLargeDOCX(5_000))
+	var perr *ParseError
+	if !errors.As(err, &perr) || perr.Code != "TEXT_TOO_LONG" {
+		t.Fatalf("expected TEXT_TOO_LONG, got %v", err)
+	}
+}
+
+func TestResumeParser_SetMaxTextLength(t *testing.T) {
+	rp := NewResumeParser()
+	rp.SetMaxTextLength(1_000)
+
+	_, err := rp.Parse(schema.ParseRequest{FileName: "large.docx", FileContent: buildLargeDOCX(500)})
+	if err == nil || !strings.Contains(err.Error(), "TEXT_TOO_LONG") {
+		t.Fatalf("expected TEXT_TOO_LONG, got %v", err)
+	}
+
+	rp.SetMaxTextLength(0)
+	if rp.docxParser.maxChars != DefaultMaxTextLength || rp.pdfParser.maxChars != DefaultMaxTextLength {
+		t.Error("expected 0 to restore DefaultMaxTextLength")
+	}
+	if _, err := rp.Parse(schema.ParseRequest{FileName: "large.docx", FileContent: buildLargeDOCX(500)}); err != nil {
+		t.Errorf("unexpected error at the default limit: %v", err)
+	}
+}
+
+// TestDOCXParser_OversizedDocumentAllocations checks that a document over
+// the text limit is rejected while it is decompressed: extraction must not
+// allocate anything near the size of the document XML.
+func TestDOCXParser_OversizedDocumentAllocations(t *testing.T) {
+	data := buildLargeDOCX(400_000) // ~25 MB of document XML
+	p := NewDOCXParser()
+	p.maxChars = 10_000
+
+	var before, after runtime.MemStats
+	runtime.GC()
+	runtime.ReadMemStats(&before)
+	_, err := p.ExtractText(data)
+	runtime.ReadMemStats(&after)
+
+	if err == nil {
+		t.Fatal("expected TEXT_TOO_LONG")
+	}
+	const budget = 2 << 20
+	if allocated := after.TotalAlloc - before.TotalAlloc; allocated > budget {
+		t.Errorf("allocated %d bytes rejecting an oversized document, budget %d", allocated, budget)
+	}
+}
+
+func BenchmarkPDFParser_LargeDocument(b *testing.B) {
+	data := buildMultiPagePDF(largeResumePages(50))
+	p := NewPDFParser()
+	b.ReportAllocs()
+	b.SetBytes(int64(len(data)))
+	for i := 0; i < b.N; i++ {
+		if _, err := p.ExtractText(data); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkDOCXParser_LargeDocument(b *testing.B) {
+	data := buildLargeDOCX(5_000)
+	p := NewDOCXParser()
+	b.ReportAllocs()
+	b.SetBytes(int64(len(data)))
+	for i := 0; i < b.N; i++ {
+		if _, err := p.ExtractText(data); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
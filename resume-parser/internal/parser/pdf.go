@@ -6,20 +6,32 @@ import (
 	"fmt"
 	"strings"
 	"unicode"
+	"unicode/utf8"
 
 	"github.com/dslipak/pdf"
 )
 
+// DefaultMaxTextLength is the default limit on the number of characters
+// extracted from a document. It is far above any real resume; a document
+// with more text is rejected rather than held in memory.
+const DefaultMaxTextLength = 500_000
+
 // PDFParser extracts text from PDF files.
-type PDFParser struct{}
+type PDFParser struct {
+	maxChars int
+}
 
 // NewPDFParser creates a new PDFParser instance.
 func NewPDFParser() *PDFParser {
-	return &PDFParser{}
+	return &PDFParser{maxChars: DefaultMaxTextLength}
 }
 
 // ExtractText extracts all text content from a PDF byte slice.
 // It returns the raw text and any error encountered.
+//
+// Pages are extracted one at a time, and extraction stops with a
+// TEXT_TOO_LONG error as soon as the text exceeds the parser's character
+// limit, so a huge document never has all its text in memory.
 func (p *PDFParser) ExtractText(data []byte) (string, error) {
 	if len(data) == 0 {
 		return "", &ParseError{Code: "EMPTY_FILE", Message: "PDF file is empty"}
@@ -48,6 +60,7 @@ func (p *PDFParser) ExtractText(data []byte) (string, error) {
 		}
 	}
 
+	chars := 0
 	for i := 1; i <= numPages; i++ {
 		page := r.Page(i)
 		if page.V.IsNull() {
@@ -59,6 +72,9 @@ func (p *PDFParser) ExtractText(data []byte) (string, error) {
 			// Skip pages that fail, continue with others
 			continue
 		}
+		if chars += utf8.RuneCountInString(text); chars > p.maxChars {
+			return "", textTooLongError(p.maxChars, fmt.Sprintf("page %d of %d", i, numPages))
+		}
 		sb.WriteString(text)
 		sb.WriteString("\n")
 	}
@@ -74,6 +90,15 @@ func (p *PDFParser) ExtractText(data []byte) (string, error) {
 	return cleanText(result), nil
 }
 
+// textTooLongError returns the TEXT_TOO_LONG error for a document whose
+// text exceeds limit characters by the given position.
+func textTooLongError(limit int, at string) *ParseError {
+	return &ParseError{
+		Code:    "TEXT_TOO_LONG",
+		Message: fmt.Sprintf("document text exceeds the %d character limit (stopped at %s)", limit, at),
+	}
+}
+
 // cleanText normalizes extracted text by removing control characters
 // and normalizing whitespace while preserving newlines.
 func cleanText(text string) string {
@@ -1,12 +1,16 @@
 package parser
 
 import (
+	"archive/zip"
+	"bytes"
 	"fmt"
+	"io"
 	"strings"
 	"time"
 
 	"github.com/learnbot/resume-parser/internal/extractor"
 	"github.com/learnbot/resume-parser/internal/schema"
+	"github.com/learnbot/resume-parser/internal/taxonomy"
 )
 
 const parserVersion = "1.0.0"
@@ -15,6 +19,7 @@ const parserVersion = "1.0.0"
 type ResumeParser struct {
 	pdfParser  *PDFParser
 	docxParser *DOCXParser
+	taxonomy   *taxonomy.Taxonomy
 }
 
 // NewResumeParser creates a new ResumeParser with all sub-parsers initialized.
@@ -22,9 +27,21 @@ func NewResumeParser() *ResumeParser {
 	return &ResumeParser{
 		pdfParser:  NewPDFParser(),
 		docxParser: NewDOCXParser(),
+		taxonomy:   taxonomy.New(),
 	}
 }
 
+// SetMaxTextLength sets the maximum number of characters extracted from a
+// document; longer documents fail with a TEXT_TOO_LONG error. n < 1 restores
+// DefaultMaxTextLength.
+func (rp *ResumeParser) SetMaxTextLength(n int) {
+	if n < 1 {
+		n = DefaultMaxTextLength
+	}
+	rp.pdfParser.maxChars = n
+	rp.docxParser.maxChars = n
+}
+
 // Parse accepts a ParseRequest and returns a structured ParsedResume.
 func (rp *ResumeParser) Parse(req schema.ParseRequest) (*schema.ParsedResume, error) {
 	if len(req.FileContent) == 0 {
@@ -34,7 +51,12 @@ func (rp *ResumeParser) Parse(req schema.ParseRequest) (*schema.ParsedResume, er
 		}
 	}
 
-	fileType := normalizeFileType(req.FileType, req.FileName)
+	// The content is authoritative when it is recognizable: uploads are
+	// often mislabeled (e.g. a .docx sent as application/octet-stream).
+	fileType := DetectFileType(req.FileContent)
+	if fileType == "" {
+		fileType = normalizeFileType(req.FileType, req.FileName)
+	}
 	if fileType == "" {
 		return nil, &schema.ParseError{
 			Code:    "UNSUPPORTED_FORMAT",
@@ -48,8 +70,9 @@ func (rp *ResumeParser) Parse(req schema.ParseRequest) (*schema.ParsedResume, er
 		return nil, err
 	}
 
-	// Step 2: Split into sections
-	sections := extractor.SplitSections(rawText)
+	// Step 2: Detect the header language and split into sections
+	lang := extractor.DetectLanguage(rawText)
+	sections := extractor.SplitSectionsIn(rawText, lang)
 
 	// Step 3: Extract fields from sections
 	result := &schema.ParsedResume{
@@ -57,6 +80,7 @@ func (rp *ResumeParser) Parse(req schema.ParseRequest) (*schema.ParsedResume, er
 		SourceFile:    req.FileName,
 		FileType:      fileType,
 		ParserVersion: parserVersion,
+		Language:      lang.Code,
 		SectionsFound: extractor.ListFoundSections(sections),
 	}
 
@@ -82,6 +106,10 @@ func (rp *ResumeParser) Parse(req schema.ParseRequest) (*schema.ParsedResume, er
 	// Skills
 	skillsText := extractor.GetSectionText(sections, extractor.SectionSkills)
 	result.Skills = extractor.ExtractSkills(skillsText)
+	if req.EnrichSkills {
+		enrichSkills(result.Skills, rp.taxonomy)
+	}
+	extractor.InferProficiency(result.Skills, result.WorkExperience, rp.skillAliases, result.ParsedAt)
 
 	// Certifications
 	certText := extractor.GetSectionText(sections, extractor.SectionCertifications)
@@ -145,6 +173,44 @@ func normalizeFileType(fileType, fileName string) string {
 	return ""
 }
 
+// DetectFileType identifies a document from its content: "pdf" for the %PDF
+// header, "docx" for a ZIP archive whose [Content_Types].xml declares a
+// WordprocessingML document. It returns "" for anything else.
+func DetectFileType(data []byte) string {
+	// PDF readers accept the header anywhere in the first 1024 bytes.
+	head := data
+	if len(head) > 1024 {
+		head = head[:1024]
+	}
+	if bytes.Contains(head, []byte("%PDF-")) {
+		return "pdf"
+	}
+
+	if !bytes.HasPrefix(data, []byte("PK\x03\x04")) {
+		return ""
+	}
+	r, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return ""
+	}
+	for _, f := range r.File {
+		if f.Name != "[Content_Types].xml" {
+			continue
+		}
+		rc, err := f.Open()
+		if err != nil {
+			return ""
+		}
+		types, err := io.ReadAll(io.LimitReader(rc, 1<<20))
+		rc.Close()
+		if err == nil && bytes.Contains(types, []byte("wordprocessingml.document.main+xml")) {
+			return "docx"
+		}
+		return ""
+	}
+	return ""
+}
+
 // convertParserError converts internal parser errors to schema.ParseError.
 func convertParserError(err error) *schema.ParseError {
 	if pe, ok := err.(*ParseError); ok {
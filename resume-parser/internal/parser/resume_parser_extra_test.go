@@ -175,6 +175,50 @@ Open-source job scheduling library`
 	}
 }
 
+// TestResumeParser_InfersProficiency tests that skill levels are inferred
+// from the roles mentioning them.
+func TestResumeParser_InfersProficiency(t *testing.T) {
+	resume := `Jane Doe
+jane.doe@email.com
+
+EXPERIENCE
+Senior Software Engineer | TechCorp Inc.
+March 2021 - Present
+• Built a distributed job scheduler on Kubernetes
+
+Software Engineer | StartupXYZ
+January 2019 - February 2021
+• Built RESTful APIs using Golang and PostgreSQL
+
+SKILLS
+Go, Kubernetes, PostgreSQL, Redis`
+
+	result, err := NewResumeParser().Parse(schema.ParseRequest{
+		FileName:    "resume.docx",
+		FileContent: buildMinimalDOCX(resume),
+		FileType:    "docx",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// "Golang" is an alias of Go; nothing mentions Redis.
+	want := map[string]int{"Go": 25, "PostgreSQL": 25, "Kubernetes": 1, "Redis": 0}
+	for _, s := range result.Skills {
+		months, ok := want[s.Name]
+		if !ok {
+			continue
+		}
+		delete(want, s.Name)
+		if s.ExperienceMonths < months || s.ProficiencyInferred != (months > 0) || (s.Proficiency != "") != (months > 0) {
+			t.Errorf("%s: got %q inferred=%v months=%d, want at least %d months", s.Name, s.Proficiency, s.ProficiencyInferred, s.ExperienceMonths, months)
+		}
+	}
+	if len(want) > 0 {
+		t.Errorf("skills not extracted: %v", want)
+	}
+}
+
 // TestConvertParserError tests error conversion.
 func TestConvertParserError(t *testing.T) {
 	pe := &ParseError{Code: "TEST", Message: "test message"}
@@ -1,6 +1,9 @@
 package parser
 
 import (
+	"os"
+	"path/filepath"
+	"reflect"
 	"strings"
 	"testing"
 
@@ -193,3 +196,125 @@ func TestSchemaParseError_Error(t *testing.T) {
 		t.Error("expected non-empty error string")
 	}
 }
+
+// TestResumeParser_EnrichSkills tests mapping extracted skills to taxonomy nodes.
+func TestResumeParser_EnrichSkills(t *testing.T) {
+	rp := NewResumeParser()
+	docx := buildMinimalDOCX("Jane Doe\njane@example.com\n\nSKILLS\nGolang, Postgres SQL, Fortranish")
+
+	plain, err := rp.Parse(schema.ParseRequest{FileName: "r.docx", FileContent: docx, FileType: "docx"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, s := range plain.Skills {
+		if s.TaxonomyNode != nil || s.MatchType != "" || s.Unresolved {
+			t.Errorf("expected no enrichment without EnrichSkills, got %+v", s)
+		}
+	}
+
+	enriched, err := rp.Parse(schema.ParseRequest{FileName: "r.docx", FileContent: docx, FileType: "docx", EnrichSkills: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	skills := make(map[string]schema.Skill)
+	for _, s := range enriched.Skills {
+		skills[s.Name] = s
+	}
+
+	tests := []struct {
+		name, wantID, wantMatch string
+	}{
+		{"Golang", "go", "alias"},
+		{"Postgres SQL", "postgresql", "fuzzy"},
+	}
+	for _, tt := range tests {
+		s, ok := skills[tt.name]
+		if !ok {
+			t.Fatalf("expected skill %q to be extracted, got %+v", tt.name, enriched.Skills)
+		}
+		if s.TaxonomyNode == nil || s.TaxonomyNode.ID != tt.wantID || s.MatchType != tt.wantMatch {
+			t.Errorf("%s: got node %+v, match %q; want %s (%s)", tt.name, s.TaxonomyNode, s.MatchType, tt.wantID, tt.wantMatch)
+		}
+		if s.TaxonomyNode != nil && (s.TaxonomyNode.Domain == "" || s.TaxonomyNode.Category == "") {
+			t.Errorf("%s: expected domain and category, got %+v", tt.name, s.TaxonomyNode)
+		}
+	}
+	if s := skills["Fortranish"]; !s.Unresolved || s.TaxonomyNode != nil || s.MatchType != "none" {
+		t.Errorf("expected Fortranish to be flagged unresolved, got %+v", s)
+	}
+}
+
+// TestResumeParser_IndonesianFixtures tests section detection for resumes
+// with Indonesian headers and English skill names.
+func TestResumeParser_IndonesianFixtures(t *testing.T) {
+	tests := []struct {
+		fixture    string
+		wantSkills []string
+		wantSecs   []string
+	}{
+		{
+			fixture:    "id_backend",
+			wantSkills: []string{"Go", "Python", "Docker", "Kubernetes", "PostgreSQL", "Redis"},
+			wantSecs:   []string{"summary", "experience", "education", "skills", "certifications"},
+		},
+		{
+			fixture:    "id_data_analyst",
+			wantSkills: []string{"SQL", "Python", "Tableau", "Microsoft Excel", "Communication", "Leadership"},
+			wantSecs:   []string{"summary", "experience", "education", "skills", "projects"},
+		},
+	}
+
+	rp := NewResumeParser()
+	for _, tt := range tests {
+		t.Run(tt.fixture, func(t *testing.T) {
+			text, err := os.ReadFile(filepath.Join("testdata", "resumes", tt.fixture+".txt"))
+			if err != nil {
+				t.Fatalf("read fixture: %v", err)
+			}
+			parsed, err := rp.Parse(schema.ParseRequest{
+				FileName:    tt.fixture + ".docx",
+				FileContent: buildMinimalDOCX(string(text)),
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if parsed.Language != "id" {
+				t.Errorf("Language = %q, want id", parsed.Language)
+			}
+			if !reflect.DeepEqual(parsed.SectionsFound, tt.wantSecs) {
+				t.Errorf("SectionsFound = %v, want %v", parsed.SectionsFound, tt.wantSecs)
+			}
+			var names []string
+			for _, s := range parsed.Skills {
+				names = append(names, s.Name)
+			}
+			if !reflect.DeepEqual(names, tt.wantSkills) {
+				t.Errorf("Skills = %v, want %v", names, tt.wantSkills)
+			}
+			if len(parsed.Education) == 0 {
+				t.Error("expected education entries")
+			}
+		})
+	}
+}
+
+// TestResumeParser_EnglishLanguage tests that English resumes are still
+// detected as English.
+func TestResumeParser_EnglishLanguage(t *testing.T) {
+	rp := NewResumeParser()
+	docs := map[string][]byte{
+		"sample":        buildMinimalDOCX(sampleResumeText),
+		"single_column": loadDOCXFixture(t, "single_column"),
+		"skills_table":  loadDOCXFixture(t, "skills_table"),
+	}
+	for name, doc := range docs {
+		parsed, err := rp.Parse(schema.ParseRequest{FileName: name + ".docx", FileContent: doc})
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", name, err)
+		}
+		if parsed.Language != "en" {
+			t.Errorf("%s: Language = %q, want en", name, parsed.Language)
+		}
+	}
+}
@@ -0,0 +1,512 @@
+// Package profilemerge merges a freshly parsed resume into a stored
+// candidate profile that the user may have edited since the last upload.
+//
+// Every value of the stored profile carries its provenance (see
+// scorer.Provenance). Values the user entered or edited are never
+// overwritten; values taken from the previous resume are refreshed from the
+// new one. A parsed skill or role the new resume no longer lists is kept and
+// flagged for the user to confirm rather than silently deleted.
+//
+// The merge is deterministic and independent of the order of the input
+// lists: skills are returned sorted by name and work history most recent
+// first.
+package profilemerge
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/learnbot/resume-parser/internal/extractor"
+	"github.com/learnbot/resume-parser/internal/scorer"
+)
+
+// DateToleranceMonths is how far apart the start dates of two roles at the
+// same company with the same title may be for them to be the same role.
+// Resumes are often edited between uploads, and "2019" parses as January.
+const DateToleranceMonths = 6
+
+// maxNameEdits is how many character edits two company names or titles may
+// differ by and still match, so that a typo fixed in the resume does not
+// duplicate the role. Names shorter than minFuzzyNameLength must be equal.
+const (
+	maxNameEdits       = 2
+	minFuzzyNameLength = 6
+)
+
+// Fields a resume fills, by their JSON names in scorer.CandidateProfile.
+const (
+	FieldYearsOfExperience = "years_of_experience"
+	FieldLocationCity      = "location_city"
+	FieldLocationCountry   = "location_country"
+)
+
+// Changes summarizes what a merge did to the stored profile. Roles are
+// labeled "Title at Company". Each list is sorted.
+type Changes struct {
+	// AddedSkills are skills the new resume lists and the profile did not.
+	AddedSkills []string `json:"added_skills"`
+
+	// UpdatedSkills are parsed skills whose proficiency or years changed.
+	UpdatedSkills []string `json:"updated_skills"`
+
+	// UnconfirmedSkills are parsed skills the new resume no longer lists.
+	// They are kept with NeedsConfirmation set.
+	UnconfirmedSkills []string `json:"unconfirmed_skills"`
+
+	// AddedWorkHistory are roles that matched no stored role.
+	AddedWorkHistory []string `json:"added_work_history"`
+
+	// UpdatedWorkHistory are parsed roles refreshed from the new resume.
+	UpdatedWorkHistory []string `json:"updated_work_history"`
+
+	// UnconfirmedWorkHistory are parsed roles the new resume no longer
+	// lists. They are kept with NeedsConfirmation set.
+	UnconfirmedWorkHistory []string `json:"unconfirmed_work_history"`
+
+	// PreservedFields are user-edited fields whose parsed value differs;
+	// the user's value is kept.
+	PreservedFields []string `json:"preserved_fields"`
+}
+
+// Merge merges parsed, the profile built from a newly parsed resume, into
+// stored and reports the changes. The values of parsed are taken as parsed
+// whatever their Source; stored values without a Source count as parsed.
+//
+//   - Skills match by case-insensitive name. User skills are kept as they
+//     are, matched parsed skills take the new values, new skills are added
+//     and unmatched parsed skills are flagged with NeedsConfirmation.
+//   - Work history entries match by company and title, allowing for
+//     maxNameEdits typos, with start dates within DateToleranceMonths. They
+//     merge like skills.
+//   - Education, certifications and projects the user entered are kept and
+//     the parsed ones are replaced by those of the new resume.
+//   - Years of experience and location are taken from the new resume unless
+//     the user edited them or the resume leaves them empty.
+//
+// Fields a resume never fills, such as the remote preference, are kept.
+// Neither argument is modified.
+func Merge(stored, parsed scorer.CandidateProfile) (scorer.CandidateProfile, Changes) {
+	var c Changes
+	merged := stored
+	merged.FieldSources = mergeFields(&merged, stored, parsed, &c)
+	merged.Skills = mergeSkills(stored.Skills, parsed.Skills, &c)
+	merged.WorkHistory = mergeWorkHistory(stored.WorkHistory, parsed.WorkHistory, &c)
+	merged.Education = mergeEntries(stored.Education, parsed.Education,
+		func(e scorer.EducationEntry) scorer.Provenance { return e.Source },
+		func(e *scorer.EducationEntry) { e.Source = scorer.ProvenanceParsed },
+		func(e scorer.EducationEntry) string {
+			return normalizeName(e.DegreeLevel) + "|" + normalizeName(e.FieldOfStudy)
+		})
+	merged.Certifications = mergeEntries(stored.Certifications, parsed.Certifications,
+		func(e scorer.CertificationEntry) scorer.Provenance { return e.Source },
+		func(e *scorer.CertificationEntry) { e.Source = scorer.ProvenanceParsed },
+		func(e scorer.CertificationEntry) string { return normalizeName(e.Name) })
+	merged.Projects = mergeEntries(stored.Projects, parsed.Projects,
+		func(e scorer.ProjectEntry) scorer.Provenance { return e.Source },
+		func(e *scorer.ProjectEntry) { e.Source = scorer.ProvenanceParsed },
+		func(e scorer.ProjectEntry) string { return normalizeName(e.Title) })
+
+	for _, list := range []*[]string{
+		&c.AddedSkills, &c.UpdatedSkills, &c.UnconfirmedSkills,
+		&c.AddedWorkHistory, &c.UpdatedWorkHistory, &c.UnconfirmedWorkHistory, &c.PreservedFields,
+	} {
+		if *list == nil {
+			*list = []string{}
+		}
+		sort.Strings(*list)
+	}
+	return merged, c
+}
+
+// isUser reports whether a value with provenance p was entered by the user.
+func isUser(p scorer.Provenance) bool {
+	return p == scorer.ProvenanceUser
+}
+
+// mergeFields merges the fields a resume fills into merged and returns
+// their provenance.
+func mergeFields(merged *scorer.CandidateProfile, stored, parsed scorer.CandidateProfile, c *Changes) map[string]scorer.Provenance {
+	var sources map[string]scorer.Provenance
+	if len(stored.FieldSources) > 0 {
+		sources = make(map[string]scorer.Provenance, len(stored.FieldSources))
+		for k, v := range stored.FieldSources {
+			sources[k] = v
+		}
+	}
+	merge := func(field string, differs, parsedEmpty bool, take func()) {
+		switch {
+		case isUser(stored.FieldSources[field]):
+			if differs && !parsedEmpty {
+				c.PreservedFields = append(c.PreservedFields, field)
+			}
+		case !parsedEmpty:
+			take()
+			if sources == nil {
+				sources = make(map[string]scorer.Provenance)
+			}
+			sources[field] = scorer.ProvenanceParsed
+		}
+	}
+	merge(FieldYearsOfExperience, parsed.YearsOfExperience != stored.YearsOfExperience, parsed.YearsOfExperience == 0,
+		func() { merged.YearsOfExperience = parsed.YearsOfExperience })
+	merge(FieldLocationCity, parsed.LocationCity != stored.LocationCity, parsed.LocationCity == "",
+		func() { merged.LocationCity = parsed.LocationCity })
+	merge(FieldLocationCountry, parsed.LocationCountry != stored.LocationCountry, parsed.LocationCountry == "",
+		func() { merged.LocationCountry = parsed.LocationCountry })
+	return sources
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Skills
+// ─────────────────────────────────────────────────────────────────────────────
+
+// mergeSkills merges parsed skills into the stored ones, sorted by name.
+func mergeSkills(stored, parsed []scorer.CandidateSkill, c *Changes) []scorer.CandidateSkill {
+	storedBy := indexSkills(stored)
+	parsedBy := indexSkills(parsed)
+
+	keys := make([]string, 0, len(storedBy)+len(parsedBy))
+	for k := range storedBy {
+		keys = append(keys, k)
+	}
+	for k := range parsedBy {
+		if _, ok := storedBy[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+
+	merged := make([]scorer.CandidateSkill, 0, len(keys))
+	for _, k := range keys {
+		s, inStored := storedBy[k]
+		p, inParsed := parsedBy[k]
+		p.Source, p.NeedsConfirmation = scorer.ProvenanceParsed, false
+		switch {
+		case inStored && isUser(s.Source):
+			s.NeedsConfirmation = false
+			merged = append(merged, s)
+		case inStored && inParsed:
+			if p.Proficiency != s.Proficiency || p.YearsOfExperience != s.YearsOfExperience {
+				c.UpdatedSkills = append(c.UpdatedSkills, p.Name)
+			}
+			merged = append(merged, p)
+		case inStored:
+			if !s.NeedsConfirmation {
+				c.UnconfirmedSkills = append(c.UnconfirmedSkills, s.Name)
+			}
+			s.Source, s.NeedsConfirmation = scorer.ProvenanceParsed, true
+			merged = append(merged, s)
+		default:
+			c.AddedSkills = append(c.AddedSkills, p.Name)
+			merged = append(merged, p)
+		}
+	}
+	return merged
+}
+
+// indexSkills indexes skills by normalized name. Of duplicates the user's
+// is kept, then the one first in skillLess order.
+func indexSkills(skills []scorer.CandidateSkill) map[string]scorer.CandidateSkill {
+	index := make(map[string]scorer.CandidateSkill, len(skills))
+	for _, s := range skills {
+		k := normalizeName(s.Name)
+		if k == "" {
+			continue
+		}
+		if prev, ok := index[k]; !ok || skillLess(s, prev) {
+			index[k] = s
+		}
+	}
+	return index
+}
+
+// skillLess orders duplicate skills by preference: user skills first, then
+// by name, proficiency and years.
+func skillLess(a, b scorer.CandidateSkill) bool {
+	if ua, ub := isUser(a.Source), isUser(b.Source); ua != ub {
+		return ua
+	}
+	if a.Name != b.Name {
+		return a.Name < b.Name
+	}
+	if a.Proficiency != b.Proficiency {
+		return a.Proficiency < b.Proficiency
+	}
+	if a.YearsOfExperience != b.YearsOfExperience {
+		return a.YearsOfExperience < b.YearsOfExperience
+	}
+	return !a.NeedsConfirmation && b.NeedsConfirmation
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Work history
+// ─────────────────────────────────────────────────────────────────────────────
+
+// role is a work history entry with its matching key and dates.
+type role struct {
+	entry          scorer.WorkHistoryEntry
+	company, title string
+	start, end     int // months from year zero; -1 when unknown
+	sortKey        string
+}
+
+func newRole(e scorer.WorkHistoryEntry) role {
+	r := role{entry: e, company: normalizeCompany(e.Company), title: normalizeName(e.Title), start: -1, end: -1}
+	if t, ok := extractor.ParseResumeDate(e.StartDate); ok {
+		r.start = extractor.MonthIndex(t)
+	}
+	if t, ok := extractor.ParseResumeDate(e.EndDate); ok && !e.IsCurrent {
+		r.end = extractor.MonthIndex(t)
+	}
+	r.sortKey = strings.Join([]string{r.company, r.title, e.Company, e.Title, e.StartDate, e.EndDate, e.Industry,
+		fmt.Sprint(e.DurationMonths, e.IsCurrent, e.NeedsConfirmation), string(e.Source)}, "\x00")
+	return r
+}
+
+// matchCost returns how far apart two roles are, and false if they are
+// different roles. Differences in names count more than in dates.
+func matchCost(a, b role) (int, bool) {
+	companyEdits, ok := nameEdits(a.company, b.company)
+	if !ok {
+		return 0, false
+	}
+	titleEdits, ok := nameEdits(a.title, b.title)
+	if !ok {
+		return 0, false
+	}
+	months := 0
+	switch {
+	case a.start >= 0 && b.start >= 0:
+		months = abs(a.start - b.start)
+	case a.end >= 0 && b.end >= 0:
+		months = abs(a.end - b.end)
+	}
+	if months > DateToleranceMonths {
+		return 0, false
+	}
+	return (companyEdits+titleEdits)*(DateToleranceMonths+1) + months, true
+}
+
+// mergeWorkHistory merges parsed roles into the stored ones. Each parsed
+// role is paired with at most one stored role, closest pairs first.
+func mergeWorkHistory(stored, parsed []scorer.WorkHistoryEntry, c *Changes) []scorer.WorkHistoryEntry {
+	storedRoles := make([]role, len(stored))
+	for i, e := range stored {
+		storedRoles[i] = newRole(e)
+	}
+	parsedRoles := make([]role, len(parsed))
+	for i, e := range parsed {
+		e.Source, e.NeedsConfirmation = scorer.ProvenanceParsed, false
+		parsedRoles[i] = newRole(e)
+	}
+
+	type pair struct{ s, p, cost int }
+	var pairs []pair
+	for i, s := range storedRoles {
+		for j, p := range parsedRoles {
+			if cost, ok := matchCost(s, p); ok {
+				pairs = append(pairs, pair{i, j, cost})
+			}
+		}
+	}
+	// Ties are broken by the entries' contents rather than their positions,
+	// so the pairing does not depend on the order of the lists.
+	sort.Slice(pairs, func(a, b int) bool {
+		x, y := pairs[a], pairs[b]
+		if x.cost != y.cost {
+			return x.cost < y.cost
+		}
+		if sx, sy := storedRoles[x.s].sortKey, storedRoles[y.s].sortKey; sx != sy {
+			return sx < sy
+		}
+		return parsedRoles[x.p].sortKey < parsedRoles[y.p].sortKey
+	})
+
+	storedMatched := make([]bool, len(storedRoles))
+	parsedMatched := make([]bool, len(parsedRoles))
+	var merged []role
+	for _, pr := range pairs {
+		if storedMatched[pr.s] || parsedMatched[pr.p] {
+			continue
+		}
+		storedMatched[pr.s], parsedMatched[pr.p] = true, true
+		s, p := storedRoles[pr.s], parsedRoles[pr.p]
+		if isUser(s.entry.Source) {
+			s.entry.NeedsConfirmation = false
+			merged = append(merged, s)
+			continue
+		}
+		if p.entry != withParsedSource(s.entry) {
+			c.UpdatedWorkHistory = append(c.UpdatedWorkHistory, roleLabel(p.entry))
+		}
+		merged = append(merged, p)
+	}
+	for i, s := range storedRoles {
+		if storedMatched[i] {
+			continue
+		}
+		if !isUser(s.entry.Source) {
+			if !s.entry.NeedsConfirmation {
+				c.UnconfirmedWorkHistory = append(c.UnconfirmedWorkHistory, roleLabel(s.entry))
+			}
+			s.entry.Source, s.entry.NeedsConfirmation = scorer.ProvenanceParsed, true
+		}
+		merged = append(merged, s)
+	}
+	for j, p := range parsedRoles {
+		if !parsedMatched[j] {
+			c.AddedWorkHistory = append(c.AddedWorkHistory, roleLabel(p.entry))
+			merged = append(merged, p)
+		}
+	}
+
+	// Most recent first: current roles, then by start date, unknown last.
+	sort.SliceStable(merged, func(i, j int) bool {
+		a, b := merged[i], merged[j]
+		if a.entry.IsCurrent != b.entry.IsCurrent {
+			return a.entry.IsCurrent
+		}
+		if a.start != b.start {
+			return a.start > b.start
+		}
+		if a.sortKey != b.sortKey {
+			return a.sortKey < b.sortKey
+		}
+		return !a.entry.NeedsConfirmation && b.entry.NeedsConfirmation
+	})
+	entries := make([]scorer.WorkHistoryEntry, len(merged))
+	for i, r := range merged {
+		entries[i] = r.entry
+	}
+	return entries
+}
+
+// withParsedSource returns e as a freshly parsed entry, for comparison.
+func withParsedSource(e scorer.WorkHistoryEntry) scorer.WorkHistoryEntry {
+	e.Source, e.NeedsConfirmation = scorer.ProvenanceParsed, false
+	return e
+}
+
+func roleLabel(e scorer.WorkHistoryEntry) string {
+	switch {
+	case e.Company == "":
+		return e.Title
+	case e.Title == "":
+		return e.Company
+	}
+	return e.Title + " at " + e.Company
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Other lists
+// ─────────────────────────────────────────────────────────────────────────────
+
+// mergeEntries keeps the user's entries and replaces the parsed ones with
+// parsed, dropping parsed entries whose key equals a user entry's. The
+// result lists user entries, then parsed ones, each sorted by key.
+func mergeEntries[T any](stored, parsed []T, source func(T) scorer.Provenance, markParsed func(*T), key func(T) string) []T {
+	var user, fresh []T
+	userKeys := make(map[string]bool)
+	for _, e := range stored {
+		if isUser(source(e)) {
+			user = append(user, e)
+			userKeys[key(e)] = true
+		}
+	}
+	seen := make(map[string]bool)
+	for _, e := range parsed {
+		k := key(e)
+		if userKeys[k] || seen[k] {
+			continue
+		}
+		seen[k] = true
+		markParsed(&e)
+		fresh = append(fresh, e)
+	}
+	byKey := func(list []T) {
+		sort.SliceStable(list, func(i, j int) bool { return key(list[i]) < key(list[j]) })
+	}
+	byKey(user)
+	byKey(fresh)
+	if merged := append(user, fresh...); len(merged) > 0 {
+		return merged
+	}
+	if stored == nil && parsed == nil {
+		return nil
+	}
+	return []T{}
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Name matching
+// ─────────────────────────────────────────────────────────────────────────────
+
+// companySuffixes are legal-form words dropped from the end of company names.
+var companySuffixes = map[string]bool{
+	"inc": true, "incorporated": true, "llc": true, "ltd": true, "limited": true,
+	"corp": true, "corporation": true, "co": true, "gmbh": true, "plc": true, "tbk": true,
+}
+
+// normalizeName lowercases s and reduces it to words of letters and digits
+// separated by single spaces.
+func normalizeName(s string) string {
+	return strings.Join(strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '+' && r != '#'
+	}), " ")
+}
+
+// normalizeCompany normalizes a company name and drops its legal form, so
+// that "Acme, Inc." matches "ACME".
+func normalizeCompany(s string) string {
+	words := strings.Fields(normalizeName(s))
+	for len(words) > 1 && companySuffixes[words[len(words)-1]] {
+		words = words[:len(words)-1]
+	}
+	if len(words) > 1 && words[0] == "pt" {
+		words = words[1:]
+	}
+	return strings.Join(words, " ")
+}
+
+// nameEdits returns the edit distance between two normalized names, and
+// false if it is too large for them to match.
+func nameEdits(a, b string) (int, bool) {
+	if a == b {
+		return 0, true
+	}
+	if len([]rune(a)) < minFuzzyNameLength || len([]rune(b)) < minFuzzyNameLength {
+		return 0, false
+	}
+	d := editDistance([]rune(a), []rune(b))
+	return d, d <= maxNameEdits
+}
+
+// editDistance returns the Levenshtein distance between a and b.
+func editDistance(a, b []rune) int {
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(b)]
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
@@ -0,0 +1,411 @@
+package profilemerge
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/learnbot/resume-parser/internal/scorer"
+)
+
+const (
+	parsed = scorer.ProvenanceParsed
+	user   = scorer.ProvenanceUser
+)
+
+func skill(name, proficiency string, source scorer.Provenance) scorer.CandidateSkill {
+	return scorer.CandidateSkill{Name: name, Proficiency: proficiency, Source: source}
+}
+
+func job(title, company, start, end string, source scorer.Provenance) scorer.WorkHistoryEntry {
+	return scorer.WorkHistoryEntry{Title: title, Company: company, StartDate: start, EndDate: end,
+		IsCurrent: end == "Present", Source: source}
+}
+
+func skillNames(skills []scorer.CandidateSkill) string {
+	var out []string
+	for _, s := range skills {
+		name := s.Name + ":" + s.Proficiency + ":" + string(s.Source)
+		if s.NeedsConfirmation {
+			name += "?"
+		}
+		out = append(out, name)
+	}
+	return strings.Join(out, " ")
+}
+
+func jobLabels(jobs []scorer.WorkHistoryEntry) string {
+	var out []string
+	for _, j := range jobs {
+		label := j.Title + "@" + j.Company + ":" + j.StartDate + ":" + string(j.Source)
+		if j.NeedsConfirmation {
+			label += "?"
+		}
+		out = append(out, label)
+	}
+	return strings.Join(out, " | ")
+}
+
+func TestMerge_Skills(t *testing.T) {
+	tests := []struct {
+		name          string
+		stored, fresh []scorer.CandidateSkill
+		want          string
+		added         []string
+		updated       []string
+		unconfirmed   []string
+	}{
+		{
+			name:  "first upload",
+			fresh: []scorer.CandidateSkill{skill("Go", "advanced", ""), skill("SQL", "", "")},
+			want:  "Go:advanced:parsed SQL::parsed",
+			added: []string{"Go", "SQL"},
+		},
+		{
+			name:    "parsed skills are refreshed",
+			stored:  []scorer.CandidateSkill{skill("Go", "beginner", parsed)},
+			fresh:   []scorer.CandidateSkill{skill("go", "advanced", "")},
+			want:    "go:advanced:parsed",
+			updated: []string{"go"},
+		},
+		{
+			name:   "user edits win",
+			stored: []scorer.CandidateSkill{skill("Golang", "expert", user), skill("Kubernetes", "", user)},
+			fresh:  []scorer.CandidateSkill{skill("golang", "beginner", "")},
+			want:   "Golang:expert:user Kubernetes::user",
+		},
+		{
+			name:        "skills gone from the resume need confirmation",
+			stored:      []scorer.CandidateSkill{skill("Perl", "intermediate", parsed), skill("Go", "", "")},
+			fresh:       []scorer.CandidateSkill{skill("Rust", "", "")},
+			want:        "Go::parsed? Perl:intermediate:parsed? Rust::parsed",
+			added:       []string{"Rust"},
+			unconfirmed: []string{"Go", "Perl"},
+		},
+		{
+			name:   "flagged skills are reported once",
+			stored: []scorer.CandidateSkill{{Name: "Perl", Source: parsed, NeedsConfirmation: true}},
+			want:   "Perl::parsed?",
+		},
+		{
+			name:   "a flagged skill back on the resume is confirmed",
+			stored: []scorer.CandidateSkill{{Name: "Perl", Source: parsed, NeedsConfirmation: true}},
+			fresh:  []scorer.CandidateSkill{skill("Perl", "", "")},
+			want:   "Perl::parsed",
+		},
+		{
+			name:   "duplicates keep the user's",
+			stored: []scorer.CandidateSkill{skill("go", "beginner", parsed), skill("Go", "expert", user)},
+			fresh:  []scorer.CandidateSkill{skill("GO", "", ""), skill("Go", "", "")},
+			want:   "Go:expert:user",
+		},
+		{
+			name:   "the resume's source is ignored",
+			stored: nil,
+			fresh:  []scorer.CandidateSkill{skill("Go", "", user)},
+			want:   "Go::parsed",
+			added:  []string{"Go"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			merged, c := Merge(scorer.CandidateProfile{Skills: tt.stored}, scorer.CandidateProfile{Skills: tt.fresh})
+			if got := skillNames(merged.Skills); got != tt.want {
+				t.Errorf("skills = %q, want %q", got, tt.want)
+			}
+			for _, l := range []struct {
+				name      string
+				got, want []string
+			}{
+				{"added", c.AddedSkills, tt.added},
+				{"updated", c.UpdatedSkills, tt.updated},
+				{"unconfirmed", c.UnconfirmedSkills, tt.unconfirmed},
+			} {
+				if strings.Join(l.got, ",") != strings.Join(l.want, ",") {
+					t.Errorf("%s = %v, want %v", l.name, l.got, l.want)
+				}
+			}
+		})
+	}
+}
+
+func TestMerge_WorkHistory(t *testing.T) {
+	tests := []struct {
+		name          string
+		stored, fresh []scorer.WorkHistoryEntry
+		want          string
+		added         []string
+		updated       []string
+		unconfirmed   []string
+	}{
+		{
+			name:    "same role with fuzzy dates",
+			stored:  []scorer.WorkHistoryEntry{job("Backend Engineer", "Acme", "2019", "Dec 2021", parsed)},
+			fresh:   []scorer.WorkHistoryEntry{job("Backend Engineer", "Acme, Inc.", "Mar 2019", "Dec 2021", "")},
+			want:    "Backend Engineer@Acme, Inc.:Mar 2019:parsed",
+			updated: []string{"Backend Engineer at Acme, Inc."},
+		},
+		{
+			name:   "a role the user fixed a typo in is kept",
+			stored: []scorer.WorkHistoryEntry{job("Software Engineer", "Initech", "Jan 2016", "Dec 2018", user)},
+			fresh:  []scorer.WorkHistoryEntry{job("Sofware Enginer", "Initech", "Jan 2016", "Dec 2018", "")},
+			want:   "Software Engineer@Initech:Jan 2016:user",
+		},
+		{
+			name:        "dates too far apart are different roles",
+			stored:      []scorer.WorkHistoryEntry{job("Engineer", "Acme", "Jan 2015", "Dec 2016", parsed)},
+			fresh:       []scorer.WorkHistoryEntry{job("Engineer", "Acme", "Jan 2019", "Present", "")},
+			want:        "Engineer@Acme:Jan 2019:parsed | Engineer@Acme:Jan 2015:parsed?",
+			added:       []string{"Engineer at Acme"},
+			unconfirmed: []string{"Engineer at Acme"},
+		},
+		{
+			name: "a boomerang pairs with the closest stint",
+			stored: []scorer.WorkHistoryEntry{
+				job("Engineer", "Acme", "Jan 2015", "Dec 2016", parsed),
+				job("Engineer", "Acme", "Jan 2019", "Present", parsed),
+			},
+			fresh: []scorer.WorkHistoryEntry{
+				job("Engineer", "Acme", "Mar 2019", "Present", ""),
+				job("Engineer", "Acme", "Feb 2015", "Dec 2016", ""),
+			},
+			want:    "Engineer@Acme:Mar 2019:parsed | Engineer@Acme:Feb 2015:parsed",
+			updated: []string{"Engineer at Acme", "Engineer at Acme"},
+		},
+		{
+			name:   "short names must match exactly",
+			stored: []scorer.WorkHistoryEntry{job("SRE", "Acme", "2020", "2021", user)},
+			fresh:  []scorer.WorkHistoryEntry{job("SWE", "Acme", "2020", "2021", "")},
+			want:   "SRE@Acme:2020:user | SWE@Acme:2020:parsed",
+			added:  []string{"SWE at Acme"},
+		},
+		{
+			name:    "undated roles match by name",
+			stored:  []scorer.WorkHistoryEntry{job("Consultant", "Freelance", "", "", parsed)},
+			fresh:   []scorer.WorkHistoryEntry{job("Consultant", "freelance", "", "", "")},
+			want:    "Consultant@freelance::parsed",
+			updated: []string{"Consultant at freelance"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			merged, c := Merge(scorer.CandidateProfile{WorkHistory: tt.stored}, scorer.CandidateProfile{WorkHistory: tt.fresh})
+			if got := jobLabels(merged.WorkHistory); got != tt.want {
+				t.Errorf("work history = %q, want %q", got, tt.want)
+			}
+			for _, l := range []struct {
+				name      string
+				got, want []string
+			}{
+				{"added", c.AddedWorkHistory, tt.added},
+				{"updated", c.UpdatedWorkHistory, tt.updated},
+				{"unconfirmed", c.UnconfirmedWorkHistory, tt.unconfirmed},
+			} {
+				if strings.Join(l.got, ",") != strings.Join(l.want, ",") {
+					t.Errorf("%s = %v, want %v", l.name, l.got, l.want)
+				}
+			}
+		})
+	}
+}
+
+func TestMerge_Fields(t *testing.T) {
+	tests := []struct {
+		name      string
+		stored    scorer.CandidateProfile
+		fresh     scorer.CandidateProfile
+		city      string
+		years     float64
+		sources   map[string]scorer.Provenance
+		preserved []string
+	}{
+		{
+			name:    "parsed values are refreshed",
+			stored:  scorer.CandidateProfile{LocationCity: "Bandung", YearsOfExperience: 3},
+			fresh:   scorer.CandidateProfile{LocationCity: "Jakarta", YearsOfExperience: 4},
+			city:    "Jakarta",
+			years:   4,
+			sources: map[string]scorer.Provenance{FieldLocationCity: parsed, FieldYearsOfExperience: parsed},
+		},
+		{
+			name: "user edits win",
+			stored: scorer.CandidateProfile{LocationCity: "Singapore", YearsOfExperience: 3,
+				FieldSources: map[string]scorer.Provenance{FieldLocationCity: user}},
+			fresh:     scorer.CandidateProfile{LocationCity: "Jakarta"},
+			city:      "Singapore",
+			years:     3,
+			sources:   map[string]scorer.Provenance{FieldLocationCity: user},
+			preserved: []string{FieldLocationCity},
+		},
+		{
+			name:   "empty parsed values keep the stored ones",
+			stored: scorer.CandidateProfile{LocationCity: "Bandung", YearsOfExperience: 3},
+			city:   "Bandung",
+			years:  3,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.stored.RemotePreference = "remote"
+			merged, c := Merge(tt.stored, tt.fresh)
+			if merged.LocationCity != tt.city || merged.YearsOfExperience != tt.years || merged.RemotePreference != "remote" {
+				t.Errorf("city %q, years %v, remote %q", merged.LocationCity, merged.YearsOfExperience, merged.RemotePreference)
+			}
+			if !reflect.DeepEqual(merged.FieldSources, tt.sources) {
+				t.Errorf("field sources = %v, want %v", merged.FieldSources, tt.sources)
+			}
+			if strings.Join(c.PreservedFields, ",") != strings.Join(tt.preserved, ",") {
+				t.Errorf("preserved = %v, want %v", c.PreservedFields, tt.preserved)
+			}
+		})
+	}
+}
+
+func TestMerge_OtherLists(t *testing.T) {
+	stored := scorer.CandidateProfile{
+		Certifications: []scorer.CertificationEntry{
+			{Name: "CKA", Year: 2024, Source: user},
+			{Name: "Old Cert", Source: parsed},
+		},
+		Projects: []scorer.ProjectEntry{{Title: "learnbot", Description: "mine", Source: user}},
+	}
+	fresh := scorer.CandidateProfile{
+		Certifications: []scorer.CertificationEntry{{Name: "cka", Year: 2023}, {Name: "AWS SAA"}},
+		Projects:       []scorer.ProjectEntry{{Title: "queue"}, {Title: "Learnbot", Description: "parsed"}},
+	}
+	merged, _ := Merge(stored, fresh)
+
+	want := []scorer.CertificationEntry{{Name: "CKA", Year: 2024, Source: user}, {Name: "AWS SAA", Source: parsed}}
+	if !reflect.DeepEqual(merged.Certifications, want) {
+		t.Errorf("certifications = %+v, want %+v", merged.Certifications, want)
+	}
+	if len(merged.Projects) != 2 || merged.Projects[0].Description != "mine" || merged.Projects[1].Title != "queue" {
+		t.Errorf("projects = %+v", merged.Projects)
+	}
+	if merged.Education != nil {
+		t.Errorf("education = %+v, want nil", merged.Education)
+	}
+}
+
+// mergeTestProfiles are a stored profile and a re-parsed resume exercising
+// every rule at once.
+func mergeTestProfiles() (stored, fresh scorer.CandidateProfile) {
+	stored = scorer.CandidateProfile{
+		Skills: []scorer.CandidateSkill{
+			skill("Go", "expert", user), skill("Perl", "beginner", parsed),
+			skill("SQL", "intermediate", parsed), skill("go", "", parsed),
+		},
+		WorkHistory: []scorer.WorkHistoryEntry{
+			job("Engineer", "Acme", "Jan 2015", "Dec 2016", parsed),
+			job("Engineer", "Acme", "Jan 2019", "Present", parsed),
+			job("Software Engineer", "Initech", "Jan 2017", "Dec 2018", user),
+			job("Intern", "Globex", "2014", "2014", parsed),
+		},
+		Education:    []scorer.EducationEntry{{DegreeLevel: "bachelor", Source: parsed}},
+		LocationCity: "Bandung",
+		FieldSources: map[string]scorer.Provenance{FieldLocationCity: user},
+	}
+	fresh = scorer.CandidateProfile{
+		Skills: []scorer.CandidateSkill{
+			skill("SQL", "advanced", ""), skill("Docker", "", ""), skill("GO", "advanced", ""),
+		},
+		WorkHistory: []scorer.WorkHistoryEntry{
+			job("Engineer", "Acme", "Feb 2019", "Present", ""),
+			job("Engineer", "ACME Corp", "Jan 2015", "Jan 2017", ""),
+			job("Sofware Engineer", "Initech", "Jan 2017", "Dec 2018", ""),
+			job("Staff Engineer", "Hooli", "2013", "2014", ""),
+		},
+		Education:         []scorer.EducationEntry{{DegreeLevel: "master"}, {DegreeLevel: "bachelor"}},
+		LocationCity:      "Jakarta",
+		YearsOfExperience: 9,
+	}
+	return stored, fresh
+}
+
+func TestMerge_OrderIndependent(t *testing.T) {
+	stored, fresh := mergeTestProfiles()
+	want, wantChanges := Merge(stored, fresh)
+
+	reversed := func(p scorer.CandidateProfile) scorer.CandidateProfile {
+		r := p
+		r.Skills, r.WorkHistory, r.Education = nil, nil, nil
+		for i := len(p.Skills) - 1; i >= 0; i-- {
+			r.Skills = append(r.Skills, p.Skills[i])
+		}
+		for i := len(p.WorkHistory) - 1; i >= 0; i-- {
+			r.WorkHistory = append(r.WorkHistory, p.WorkHistory[i])
+		}
+		for i := len(p.Education) - 1; i >= 0; i-- {
+			r.Education = append(r.Education, p.Education[i])
+		}
+		return r
+	}
+	rotated := func(p scorer.CandidateProfile) scorer.CandidateProfile {
+		r := p
+		r.Skills = append(append([]scorer.CandidateSkill(nil), p.Skills[1:]...), p.Skills[0])
+		r.WorkHistory = append(append([]scorer.WorkHistoryEntry(nil), p.WorkHistory[2:]...), p.WorkHistory[:2]...)
+		return r
+	}
+	for name, in := range map[string][2]scorer.CandidateProfile{
+		"reversed":       {reversed(stored), reversed(fresh)},
+		"rotated":        {rotated(stored), rotated(fresh)},
+		"stored reverse": {reversed(stored), fresh},
+	} {
+		got, gotChanges := Merge(in[0], in[1])
+		if !reflect.DeepEqual(got, want) || !reflect.DeepEqual(gotChanges, wantChanges) {
+			t.Errorf("%s: merge depends on input order:\n got %+v\nwant %+v", name, got, want)
+		}
+	}
+
+	if got := skillNames(want.Skills); got != "Docker::parsed Go:expert:user Perl:beginner:parsed? SQL:advanced:parsed" {
+		t.Errorf("skills = %q", got)
+	}
+	if got := jobLabels(want.WorkHistory); got != "Engineer@Acme:Feb 2019:parsed | Software Engineer@Initech:Jan 2017:user | "+
+		"Engineer@ACME Corp:Jan 2015:parsed | Intern@Globex:2014:parsed? | Staff Engineer@Hooli:2013:parsed" {
+		t.Errorf("work history = %q", got)
+	}
+	if want.LocationCity != "Bandung" || want.YearsOfExperience != 9 || len(want.Education) != 2 {
+		t.Errorf("merged profile = %+v", want)
+	}
+}
+
+func TestMerge_Idempotent(t *testing.T) {
+	stored, fresh := mergeTestProfiles()
+	once, _ := Merge(stored, fresh)
+	twice, c := Merge(once, fresh)
+	if !reflect.DeepEqual(twice, once) {
+		t.Errorf("merging the same resume again changed the profile:\n got %+v\nwant %+v", twice, once)
+	}
+	empty := Changes{
+		AddedSkills: []string{}, UpdatedSkills: []string{}, UnconfirmedSkills: []string{},
+		AddedWorkHistory: []string{}, UpdatedWorkHistory: []string{}, UnconfirmedWorkHistory: []string{},
+		PreservedFields: []string{FieldLocationCity},
+	}
+	if !reflect.DeepEqual(c, empty) {
+		t.Errorf("changes = %+v, want none but the preserved city", c)
+	}
+}
+
+func TestMerge_DoesNotModifyArguments(t *testing.T) {
+	stored, fresh := mergeTestProfiles()
+	wantStored, wantFresh := mergeTestProfiles()
+	Merge(stored, fresh)
+	if !reflect.DeepEqual(stored, wantStored) || !reflect.DeepEqual(fresh, wantFresh) {
+		t.Error("Merge modified its arguments")
+	}
+}
+
+func TestNormalizeCompany(t *testing.T) {
+	tests := []struct{ in, want string }{
+		{"Acme, Inc.", "acme"},
+		{"ACME Corp", "acme"},
+		{"PT Gojek Indonesia Tbk", "gojek indonesia"},
+		{"Co", "co"},
+		{"  Initech   LLC ", "initech"},
+	}
+	for _, tt := range tests {
+		if got := normalizeCompany(tt.in); got != tt.want {
+			t.Errorf("normalizeCompany(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,85 @@
+package quality
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/learnbot/shared/adminauth"
+	"github.com/learnbot/shared/dataquality"
+	"github.com/learnbot/shared/openapi"
+)
+
+// defaultWindow is the reporting period used when ?days= is absent.
+const defaultWindow = 7 * 24 * time.Hour
+
+// Handler serves the resume parser's data quality section.
+type Handler struct {
+	tracker *Tracker
+	auth    *adminauth.Middleware
+	logger  *log.Logger
+}
+
+// NewHandler creates a new data quality Handler backed by tracker,
+// authenticating callers with auth.
+func NewHandler(tracker *Tracker, auth *adminauth.Middleware, logger *log.Logger) *Handler {
+	return &Handler{tracker: tracker, auth: auth, logger: logger}
+}
+
+// RegisterRoutes registers the data quality routes on the given mux.
+//
+//	GET /admin/data-quality  – data quality dashboard section
+func (h *Handler) RegisterRoutes(mux openapi.Router) {
+	mux.Handle("/admin/data-quality", h.auth.WrapFunc(h.DataQualityHandler))
+}
+
+// DescribeRoutes documents the routes registered by RegisterRoutes.
+func (h *Handler) DescribeRoutes(spec *openapi.Spec) {
+	spec.Route("/admin/data-quality", openapi.Operation{
+		Method:   http.MethodGet,
+		Summary:  "Parse failure and low-confidence rates",
+		Params:   []openapi.Param{{Name: "days", Description: "Window in days (default 7)", Type: 0}},
+		Security: []string{openapi.AdminAPIKey, openapi.BearerAuth},
+		Response: openapi.Fields{"success": false, "data": dataquality.Section{}},
+	})
+}
+
+// DataQualityHandler handles GET /admin/data-quality?days=7.
+//
+// The window is capped at half the tracker's retention so that the previous
+// period is always fully covered.
+func (h *Handler) DataQualityHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		h.writeJSON(w, http.StatusMethodNotAllowed, map[string]interface{}{
+			"success": false,
+			"error":   "only GET is supported",
+		})
+		return
+	}
+
+	window := defaultWindow
+	if d := r.URL.Query().Get("days"); d != "" {
+		if n, err := strconv.Atoi(d); err == nil && n > 0 {
+			window = time.Duration(n) * 24 * time.Hour
+		}
+	}
+	if max := h.tracker.retention / 2; window > max {
+		window = max
+	}
+
+	h.writeJSON(w, http.StatusOK, map[string]interface{}{
+		"success": true,
+		"data":    h.tracker.Section(window),
+	})
+}
+
+// writeJSON serialises v as JSON and writes it to the response.
+func (h *Handler) writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		h.logger.Printf("failed to encode JSON response: %v", err)
+	}
+}
@@ -0,0 +1,136 @@
+// Package quality records data quality events produced by the resume parser
+// and scorer, and reports them as a section of the pipeline data quality
+// dashboard.
+//
+// The service is stateless, so events are kept in memory for a bounded
+// retention period; counts reset when the process restarts.
+package quality
+
+import (
+	"sync"
+	"time"
+
+	"github.com/learnbot/shared/dataquality"
+)
+
+// Check names reported by this service.
+const (
+	CheckParseFailures          = "parse_failures"
+	CheckLowConfidenceParses    = "low_confidence_parses"
+	CheckScoringInconsistencies = "scoring_inconsistencies"
+)
+
+// checkDescriptions defines the checks included in every section, in order.
+var checkDescriptions = []struct {
+	name        string
+	description string
+}{
+	{CheckParseFailures, "Resume uploads that could not be parsed"},
+	{CheckLowConfidenceParses, "Resumes parsed with low overall confidence"},
+	{CheckScoringInconsistencies, "Scoring requests that produced inconsistency warnings"},
+}
+
+const (
+	// DefaultRetention is how long events are kept in memory.
+	DefaultRetention = 60 * 24 * time.Hour
+
+	// maxEvents bounds memory use regardless of retention.
+	maxEvents = 50000
+)
+
+// event is a single recorded occurrence of a check.
+type event struct {
+	check string
+	id    string
+	at    time.Time
+}
+
+// Tracker is a concurrency-safe in-memory store of data quality events.
+type Tracker struct {
+	mu        sync.Mutex
+	events    []event
+	retention time.Duration
+	now       func() time.Time
+}
+
+// NewTracker creates a Tracker that keeps events for the given retention.
+// A non-positive retention uses DefaultRetention.
+func NewTracker(retention time.Duration) *Tracker {
+	if retention <= 0 {
+		retention = DefaultRetention
+	}
+	return &Tracker{
+		retention: retention,
+		now:       func() time.Time { return time.Now().UTC() },
+	}
+}
+
+// Record notes one occurrence of check for the record identified by id.
+// It is safe to call on a nil Tracker, which discards the event.
+func (t *Tracker) Record(check, id string) {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	now := t.now()
+	t.events = append(t.events, event{check: check, id: id, at: now})
+	t.pruneLocked(now)
+}
+
+// pruneLocked drops events older than the retention and enforces maxEvents.
+// Events are appended in time order, so the oldest are always at the front.
+func (t *Tracker) pruneLocked(now time.Time) {
+	cutoff := now.Add(-t.retention)
+	drop := 0
+	for drop < len(t.events) && t.events[drop].at.Before(cutoff) {
+		drop++
+	}
+	if over := len(t.events) - drop - maxEvents; over > 0 {
+		drop += over
+	}
+	if drop > 0 {
+		t.events = append(t.events[:0], t.events[drop:]...)
+	}
+}
+
+// Section summarises events in the last window and compares them with the
+// window before it. For parser checks the drill-down IDs are source file
+// names; for scoring checks they are job titles.
+func (t *Tracker) Section(window time.Duration) dataquality.Section {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	now := t.now()
+	periodStart := now.Add(-window)
+	prevStart := periodStart.Add(-window)
+
+	checks := make([]dataquality.Check, len(checkDescriptions))
+	index := make(map[string]int, len(checkDescriptions))
+	for i, d := range checkDescriptions {
+		checks[i] = dataquality.NewCheck(d.name, d.description, "")
+		index[d.name] = i
+	}
+
+	// Walk newest first so drill-down IDs favour the latest occurrences.
+	for i := len(t.events) - 1; i >= 0; i-- {
+		e := t.events[i]
+		if e.at.Before(prevStart) {
+			break
+		}
+		ci, ok := index[e.check]
+		if !ok {
+			continue
+		}
+		c := &checks[ci]
+		if e.at.Before(periodStart) {
+			c.PreviousCount++
+			continue
+		}
+		c.Count++
+		c.AddID(e.id)
+	}
+
+	return dataquality.NewSection("resume-parser", now, window, checks)
+}
@@ -0,0 +1,202 @@
+package quality
+
+import (
+	"encoding/json"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/learnbot/shared/adminauth"
+	"github.com/learnbot/shared/dataquality"
+	"github.com/learnbot/shared/openapi"
+)
+
+// newTestTracker returns a Tracker whose clock is controlled by *clock.
+func newTestTracker(clock *time.Time) *Tracker {
+	t := NewTracker(DefaultRetention)
+	t.now = func() time.Time { return *clock }
+	return t
+}
+
+func findCheck(t *testing.T, s dataquality.Section, name string) dataquality.Check {
+	t.Helper()
+	for _, c := range s.Checks {
+		if c.Name == name {
+			return c
+		}
+	}
+	t.Fatalf("check %q not found", name)
+	return dataquality.Check{}
+}
+
+func TestTracker_SectionWithSeededEvents(t *testing.T) {
+	start := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	clock := start
+	tr := newTestTracker(&clock)
+
+	// Previous period: two low-confidence parses, one scoring inconsistency.
+	tr.Record(CheckLowConfidenceParses, "old-a.pdf")
+	tr.Record(CheckLowConfidenceParses, "old-b.pdf")
+	tr.Record(CheckScoringInconsistencies, "Backend Engineer")
+
+	// Current period.
+	clock = start.Add(8 * 24 * time.Hour)
+	tr.Record(CheckLowConfidenceParses, "new-a.docx")
+	tr.Record(CheckParseFailures, "broken.pdf")
+	tr.Record(CheckScoringInconsistencies, "Data Engineer")
+
+	clock = start.Add(10 * 24 * time.Hour)
+	s := tr.Section(7 * 24 * time.Hour)
+
+	if s.Service != "resume-parser" || s.Status != "degraded" {
+		t.Errorf("got service=%q status=%q", s.Service, s.Status)
+	}
+	if len(s.Checks) != 3 {
+		t.Fatalf("expected 3 checks, got %d", len(s.Checks))
+	}
+
+	low := findCheck(t, s, CheckLowConfidenceParses)
+	if low.Count != 1 || low.PreviousCount != 2 || low.Trend != "down" {
+		t.Errorf("low_confidence_parses = %d/%d %s, want 1/2 down", low.Count, low.PreviousCount, low.Trend)
+	}
+	if len(low.DrillDown.IDs) != 1 || low.DrillDown.IDs[0] != "new-a.docx" {
+		t.Errorf("low_confidence_parses drill-down = %v", low.DrillDown.IDs)
+	}
+
+	failures := findCheck(t, s, CheckParseFailures)
+	if failures.Count != 1 || failures.PreviousCount != 0 || failures.Trend != "up" {
+		t.Errorf("parse_failures = %d/%d %s, want 1/0 up", failures.Count, failures.PreviousCount, failures.Trend)
+	}
+
+	scoring := findCheck(t, s, CheckScoringInconsistencies)
+	if scoring.Count != 1 || scoring.PreviousCount != 1 || scoring.Trend != "flat" {
+		t.Errorf("scoring_inconsistencies = %d/%d %s, want 1/1 flat", scoring.Count, scoring.PreviousCount, scoring.Trend)
+	}
+}
+
+func TestTracker_EmptyIsHealthy(t *testing.T) {
+	s := NewTracker(0).Section(7 * 24 * time.Hour)
+	if s.Status != "healthy" {
+		t.Errorf("Status = %q, want healthy", s.Status)
+	}
+	for _, c := range s.Checks {
+		if c.DrillDown.IDs == nil {
+			t.Errorf("%s: drill-down IDs should be empty, not nil", c.Name)
+		}
+	}
+}
+
+func TestTracker_PrunesExpiredEvents(t *testing.T) {
+	clock := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	tr := newTestTracker(&clock)
+	tr.Record(CheckParseFailures, "ancient.pdf")
+
+	clock = clock.Add(DefaultRetention + time.Hour)
+	tr.Record(CheckParseFailures, "fresh.pdf")
+
+	if len(tr.events) != 1 || tr.events[0].id != "fresh.pdf" {
+		t.Errorf("expected only the fresh event to remain, got %+v", tr.events)
+	}
+}
+
+func TestTracker_NilRecordIsNoop(t *testing.T) {
+	var tr *Tracker
+	tr.Record(CheckParseFailures, "x.pdf") // must not panic
+}
+
+func TestDataQualityHandler(t *testing.T) {
+	tr := NewTracker(0)
+	tr.Record(CheckParseFailures, "broken.pdf")
+	h := NewHandler(tr, nil, log.New(io.Discard, "", 0))
+
+	req := httptest.NewRequest(http.MethodGet, "/admin/data-quality?days=1", nil)
+	w := httptest.NewRecorder()
+	h.DataQualityHandler(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", w.Code)
+	}
+	var resp struct {
+		Success bool                `json:"success"`
+		Data    dataquality.Section `json:"data"`
+	}
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if !resp.Success || resp.Data.Status != "degraded" {
+		t.Errorf("unexpected response: %+v", resp)
+	}
+	if got := resp.Data.PeriodEnd.Sub(resp.Data.PeriodStart); got != 24*time.Hour {
+		t.Errorf("period length = %v, want 24h", got)
+	}
+}
+
+func TestDataQualityHandler_MethodNotAllowed(t *testing.T) {
+	h := NewHandler(NewTracker(0), nil, log.New(io.Discard, "", 0))
+	req := httptest.NewRequest(http.MethodPost, "/admin/data-quality", nil)
+	w := httptest.NewRecorder()
+	h.DataQualityHandler(w, req)
+	if w.Code != http.StatusMethodNotAllowed {
+		t.Errorf("expected 405, got %d", w.Code)
+	}
+}
+
+func TestDataQualityRoute_RequiresAdmin(t *testing.T) {
+	const secret = "test-jwt-secret"
+	logger := log.New(io.Discard, "", 0)
+	auth := adminauth.New(adminauth.Config{APIKey: "secret", JWTSecret: []byte(secret)}, logger)
+	mux := http.NewServeMux()
+	NewHandler(NewTracker(0), auth, logger).RegisterRoutes(mux)
+
+	token := func(isAdmin bool) string {
+		claims := jwt.MapClaims{
+			"user_id":  "user-1",
+			"is_admin": isAdmin,
+			"iss":      "learnbot",
+			"exp":      time.Now().Add(time.Hour).Unix(),
+		}
+		signed, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(secret))
+		if err != nil {
+			t.Fatalf("sign token: %v", err)
+		}
+		return "Bearer " + signed
+	}
+
+	tests := []struct {
+		name   string
+		header string
+		value  string
+		want   int
+	}{
+		{"no credentials", "", "", http.StatusUnauthorized},
+		{"wrong key", adminauth.APIKeyHeader, "guess", http.StatusUnauthorized},
+		{"non-admin token", "Authorization", token(false), http.StatusForbidden},
+		{"admin key", adminauth.APIKeyHeader, "secret", http.StatusOK},
+		{"admin token", "Authorization", token(true), http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/admin/data-quality", nil)
+			if tt.header != "" {
+				req.Header.Set(tt.header, tt.value)
+			}
+			w := httptest.NewRecorder()
+			mux.ServeHTTP(w, req)
+			if w.Code != tt.want {
+				t.Errorf("expected %d, got %d: %s", tt.want, w.Code, strings.TrimSpace(w.Body.String()))
+			}
+		})
+	}
+}
+
+func TestDescribeRoutes(t *testing.T) {
+	h := NewHandler(NewTracker(0), nil, log.New(io.Discard, "", 0))
+	if err := openapi.CheckRoutes(h.RegisterRoutes, h.DescribeRoutes); err != nil {
+		t.Error(err)
+	}
+}
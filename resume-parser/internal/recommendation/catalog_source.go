@@ -0,0 +1,241 @@
+// Package recommendation – catalog_source.go defines where the engine looks
+// for learning resources: the in-memory catalog and the learning-resources
+// service backed by the database catalog.
+package recommendation
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CatalogSource supplies learning resources that cover a skill.
+type CatalogSource interface {
+	// FindBySkill returns resources covering skill that pass the user's
+	// preference filters. The budget is applied by the engine, once prices
+	// are converted to the user's currency.
+	FindBySkill(ctx context.Context, skill string, prefs UserPreferences) ([]ResourceEntry, error)
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// In-memory catalog
+// ─────────────────────────────────────────────────────────────────────────────
+
+// MemoryCatalogSource serves resources from an in-memory slice.
+type MemoryCatalogSource struct {
+	entries []ResourceEntry
+}
+
+// NewMemoryCatalogSource creates a source over entries.
+func NewMemoryCatalogSource(entries []ResourceEntry) *MemoryCatalogSource {
+	return &MemoryCatalogSource{entries: entries}
+}
+
+// BuiltinCatalogSource returns a source over the built-in resource catalog.
+func BuiltinCatalogSource() *MemoryCatalogSource {
+	return NewMemoryCatalogSource(builtinCatalog)
+}
+
+// FindBySkill implements CatalogSource. It never fails.
+func (s *MemoryCatalogSource) FindBySkill(_ context.Context, skill string, prefs UserPreferences) ([]ResourceEntry, error) {
+	norm := normalizeSkillName(skill)
+	canonical := resolveAlias(norm)
+
+	var matches []ResourceEntry
+	for _, res := range s.entries {
+		if !resourceMatchesSkill(res, canonical, norm) {
+			continue
+		}
+		if !passesPreferenceFilter(res, prefs) {
+			continue
+		}
+		matches = append(matches, res)
+	}
+	return matches, nil
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// learning-resources service
+// ─────────────────────────────────────────────────────────────────────────────
+
+// DefaultHTTPCatalogLimit is the number of resources requested per skill
+// from the learning-resources service.
+const DefaultHTTPCatalogLimit = 10
+
+// HTTPCatalogSource fetches resources from the learning-resources service's
+// GET /api/v1/resources/by-skill endpoint.
+type HTTPCatalogSource struct {
+	baseURL string
+	client  *http.Client
+
+	// Limit is the maximum number of resources requested per skill.
+	Limit int
+}
+
+// NewHTTPCatalogSource creates a source for the learning-resources service
+// at baseURL (e.g. "http://learning-resources:8080"). A nil client gets a
+// client with a 5 second timeout.
+func NewHTTPCatalogSource(baseURL string, client *http.Client) *HTTPCatalogSource {
+	if client == nil {
+		client = &http.Client{Timeout: 5 * time.Second}
+	}
+	return &HTTPCatalogSource{
+		baseURL: strings.TrimRight(baseURL, "/"),
+		client:  client,
+		Limit:   DefaultHTTPCatalogLimit,
+	}
+}
+
+// FindBySkill implements CatalogSource.
+func (s *HTTPCatalogSource) FindBySkill(ctx context.Context, skill string, prefs UserPreferences) ([]ResourceEntry, error) {
+	q := url.Values{}
+	q.Set("skill", skill)
+	q.Set("limit", strconv.Itoa(s.Limit))
+	endpoint := s.baseURL + "/api/v1/resources/by-skill?" + q.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, fmt.Errorf("build request: %w", err)
+	}
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("fetch resources for %q: %w", skill, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("fetch resources for %q: unexpected status %d", skill, resp.StatusCode)
+	}
+
+	var body struct {
+		Success bool             `json:"success"`
+		Error   string           `json:"error"`
+		Data    []remoteResource `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return nil, fmt.Errorf("decode resources for %q: %w", skill, err)
+	}
+	if !body.Success {
+		return nil, fmt.Errorf("fetch resources for %q: %s", skill, body.Error)
+	}
+
+	var matches []ResourceEntry
+	for _, r := range body.Data {
+		res := r.toEntry()
+		if passesPreferenceFilter(res, prefs) {
+			matches = append(matches, res)
+		}
+	}
+	return matches, nil
+}
+
+// remoteResource is a resource as serialized by the learning-resources
+// service. Nullable columns are encoded as database/sql null types.
+type remoteResource struct {
+	ID             string      `json:"id"`
+	Title          string      `json:"title"`
+	Description    nullString  `json:"description"`
+	URL            string      `json:"url"`
+	ResourceType   string      `json:"resource_type"`
+	Difficulty     string      `json:"difficulty"`
+	CostType       string      `json:"cost_type"`
+	CostAmount     nullFloat64 `json:"cost_amount"`
+	CostCurrency   string      `json:"cost_currency"`
+	DurationHours  nullFloat64 `json:"duration_hours"`
+	DurationLabel  nullString  `json:"duration_label"`
+	IsVerified     bool        `json:"is_verified"`
+	HasCertificate bool        `json:"has_certificate"`
+	HasHandsOn     bool        `json:"has_hands_on"`
+	Rating         nullFloat64 `json:"rating"`
+	RatingCount    int         `json:"rating_count"`
+	ProviderName   string      `json:"provider_name"`
+	Skills         []string    `json:"skills"`
+	SkillIDs       []string    `json:"skill_ids"`
+}
+
+// nullString mirrors the JSON encoding of sql.NullString.
+type nullString struct {
+	String string
+	Valid  bool
+}
+
+// nullFloat64 mirrors the JSON encoding of sql.NullFloat64.
+type nullFloat64 struct {
+	Float64 float64
+	Valid   bool
+}
+
+// toEntry converts a remote resource to a catalog entry.
+func (r remoteResource) toEntry() ResourceEntry {
+	skills := r.SkillIDs
+	if len(skills) == 0 {
+		skills = r.Skills
+	}
+	normalized := make([]string, 0, len(skills))
+	for _, s := range skills {
+		normalized = append(normalized, normalizeSkillName(s))
+	}
+
+	return ResourceEntry{
+		ID:             r.ID,
+		Title:          r.Title,
+		Description:    r.Description.String,
+		URL:            r.URL,
+		Provider:       r.ProviderName,
+		ResourceType:   r.ResourceType,
+		Difficulty:     r.Difficulty,
+		CostType:       r.CostType,
+		CostAmount:     r.CostAmount.Float64,
+		CostUnknown:    !r.CostAmount.Valid && r.CostType != "free",
+		CostCurrency:   normalizeCurrency(r.CostCurrency),
+		DurationHours:  r.DurationHours.Float64,
+		DurationLabel:  r.DurationLabel.String,
+		Skills:         normalized,
+		Rating:         r.Rating.Float64,
+		RatingCount:    r.RatingCount,
+		HasCertificate: r.HasCertificate,
+		HasHandsOn:     r.HasHandsOn,
+		IsVerified:     r.IsVerified,
+	}
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Merging
+// ─────────────────────────────────────────────────────────────────────────────
+
+// mergeResources concatenates resources from several sources, keeping one
+// entry per URL. When two sources list the same URL the verified entry
+// wins; otherwise the earlier source wins.
+func mergeResources(lists ...[]ResourceEntry) []ResourceEntry {
+	var merged []ResourceEntry
+	index := make(map[string]int)
+	for _, list := range lists {
+		for _, res := range list {
+			key := resourceKey(res)
+			if key == "" {
+				merged = append(merged, res)
+				continue
+			}
+			if i, ok := index[key]; ok {
+				if res.IsVerified && !merged[i].IsVerified {
+					merged[i] = res
+				}
+				continue
+			}
+			index[key] = len(merged)
+			merged = append(merged, res)
+		}
+	}
+	return merged
+}
+
+// resourceKey returns the deduplication key for a resource: its URL,
+// ignoring case and a trailing slash.
+func resourceKey(res ResourceEntry) string {
+	return strings.TrimRight(strings.ToLower(strings.TrimSpace(res.URL)), "/")
+}
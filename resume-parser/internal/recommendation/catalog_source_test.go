@@ -0,0 +1,181 @@
+package recommendation
+
+import (
+	"bytes"
+	"context"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/learnbot/resume-parser/internal/gapanalysis"
+)
+
+// byskillResponse is a learning-resources response in the service's wire
+// format, with sql.Null* columns encoded as objects.
+const byskillResponse = `{
+  "success": true,
+  "skill": "python",
+  "data": [
+    {
+      "id": "0b6f0d1c-7f0e-4a43-9d43-0d5f1b4c9a11",
+      "title": "Python Beginner Course",
+      "description": {"String": "From the database catalog.", "Valid": true},
+      "url": "https://example.com/python/",
+      "resource_type": "course",
+      "difficulty": "beginner",
+      "cost_type": "free",
+      "cost_amount": {"Float64": 0, "Valid": false},
+      "duration_hours": {"Float64": 18, "Valid": true},
+      "duration_label": {"String": "", "Valid": false},
+      "is_verified": false,
+      "rating": {"Float64": 4.9, "Valid": true},
+      "rating_count": 20,
+      "provider_name": "Remote",
+      "skills": ["Python"],
+      "skill_ids": ["python"]
+    },
+    {
+      "id": "5e0a7c3e-5b52-4a7e-8d8c-3c2f3b8e2f7b",
+      "title": "Python Data Projects",
+      "description": {"String": "", "Valid": false},
+      "url": "https://example.com/python-projects",
+      "resource_type": "project",
+      "difficulty": "intermediate",
+      "cost_type": "paid",
+      "cost_amount": {"Float64": 39, "Valid": true},
+      "duration_hours": {"Float64": 12, "Valid": true},
+      "is_verified": true,
+      "has_hands_on": true,
+      "rating_count": 0,
+      "provider_name": "Remote",
+      "skills": ["Python", "pandas"],
+      "skill_ids": ["python", "pandas"]
+    }
+  ]
+}`
+
+func newByskillServer(t *testing.T, status int, body string) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/resources/by-skill" || r.URL.Query().Get("skill") == "" {
+			t.Errorf("unexpected request %s", r.URL)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		w.Write([]byte(body))
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestHTTPCatalogSource_FindBySkill(t *testing.T) {
+	srv := newByskillServer(t, http.StatusOK, byskillResponse)
+	src := NewHTTPCatalogSource(srv.URL+"/", srv.Client())
+
+	resources, err := src.FindBySkill(context.Background(), "Python", UserPreferences{})
+	if err != nil {
+		t.Fatalf("FindBySkill: %v", err)
+	}
+	if len(resources) != 2 {
+		t.Fatalf("expected 2 resources, got %d", len(resources))
+	}
+	got := resources[1]
+	if got.CostAmount != 39 || got.CostCurrency != "USD" || got.DurationHours != 12 || got.Provider != "Remote" || !got.IsVerified {
+		t.Errorf("unexpected mapping: %+v", got)
+	}
+	if len(got.Skills) != 2 || got.Skills[1] != "pandas" {
+		t.Errorf("Skills = %v, want [python pandas]", got.Skills)
+	}
+	if resources[0].Description != "From the database catalog." || resources[0].Rating != 4.9 {
+		t.Errorf("unexpected mapping: %+v", resources[0])
+	}
+
+	// Preference filters apply to remote resources too.
+	free, err := src.FindBySkill(context.Background(), "Python", UserPreferences{PreferFree: true})
+	if err != nil {
+		t.Fatalf("FindBySkill: %v", err)
+	}
+	if len(free) != 1 || free[0].CostType != "free" {
+		t.Errorf("expected only the free resource, got %+v", free)
+	}
+}
+
+func TestHTTPCatalogSource_Errors(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+		body   string
+	}{
+		{"server error", http.StatusInternalServerError, `{"success":false,"error":"database unavailable"}`},
+		{"unsuccessful", http.StatusOK, `{"success":false,"error":"boom"}`},
+		{"malformed", http.StatusOK, `{"success":`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := newByskillServer(t, tt.status, tt.body)
+			if _, err := NewHTTPCatalogSource(srv.URL, srv.Client()).FindBySkill(context.Background(), "python", UserPreferences{}); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
+
+func TestEngine_MergesCatalogSources(t *testing.T) {
+	srv := newByskillServer(t, http.StatusOK, byskillResponse)
+	engine := NewWithSources(nil,
+		NewMemoryCatalogSource(testCatalog),
+		NewHTTPCatalogSource(srv.URL, srv.Client()),
+	)
+
+	resources := engine.findMatchingResources(context.Background(), "Python", UserPreferences{})
+
+	// Two Python entries from memory, one new remote entry; the remote
+	// duplicate of https://example.com/python is dropped.
+	if len(resources) != 3 {
+		t.Fatalf("expected 3 resources, got %d: %+v", len(resources), resources)
+	}
+	seen := make(map[string]ResourceEntry)
+	for _, r := range resources {
+		seen[resourceKey(r)] = r
+	}
+	if r := seen["https://example.com/python"]; r.ID != "python-course" {
+		t.Errorf("expected the verified builtin entry to win the duplicate, got %q", r.ID)
+	}
+	if _, ok := seen["https://example.com/python-projects"]; !ok {
+		t.Error("expected the remote-only resource to be included")
+	}
+}
+
+func TestMergeResources_PrefersVerified(t *testing.T) {
+	unverified := ResourceEntry{ID: "a", URL: "https://example.com/x"}
+	verified := ResourceEntry{ID: "b", URL: "HTTPS://example.com/x/", IsVerified: true}
+	other := ResourceEntry{ID: "c", URL: "https://example.com/y", IsVerified: true}
+
+	merged := mergeResources([]ResourceEntry{unverified, other}, []ResourceEntry{verified})
+	if len(merged) != 2 || merged[0].ID != "b" || merged[1].ID != "c" {
+		t.Errorf("expected the verified duplicate in the first entry's place, got %+v", merged)
+	}
+}
+
+func TestEngine_RemoteFailureFallsBackToBuiltin(t *testing.T) {
+	srv := newByskillServer(t, http.StatusServiceUnavailable, `{"success":false,"error":"down"}`)
+	var logs bytes.Buffer
+	engine := NewWithSources(log.New(&logs, "", 0),
+		NewMemoryCatalogSource(testCatalog),
+		NewHTTPCatalogSource(srv.URL, srv.Client()),
+	)
+
+	rec := engine.buildSkillRecommendation(context.Background(), gapanalysis.SkillGap{
+		SkillName: "Python", Category: gapanalysis.GapCategoryCritical,
+		PriorityScore: 0.9, EstimatedLearningHours: 40, TargetLevel: "intermediate",
+	}, applyPreferenceDefaults(UserPreferences{}), nil)
+
+	if rec.PrimaryResource == nil || !strings.HasPrefix(rec.PrimaryResource.Resource.ID, "python-") {
+		t.Errorf("expected a builtin primary resource despite the remote failure, got %+v", rec.PrimaryResource)
+	}
+	if !strings.Contains(logs.String(), "WARN") || !strings.Contains(logs.String(), "503") {
+		t.Errorf("expected a logged warning, got %q", logs.String())
+	}
+}
@@ -0,0 +1,252 @@
+// Package recommendation – currency.go converts resource prices to the
+// user's currency, so that budgets, cost scoring and the prices shown in
+// plans all use one currency.
+package recommendation
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// BaseCurrency is the currency of catalog prices without a currency, of
+// plans for users without a preferred currency, and the reference of
+// StaticRates.
+const BaseCurrency = "USD"
+
+// CurrencyConverter converts prices between currencies.
+type CurrencyConverter interface {
+	// Convert converts amount from one currency to another, both ISO 4217
+	// codes. It reports false if the rate of either currency is unknown.
+	Convert(amount float64, from, to string) (float64, bool)
+}
+
+// DefaultCurrencyRates returns the built-in exchange rates: units of each
+// currency per US dollar.
+func DefaultCurrencyRates() map[string]float64 {
+	return map[string]float64{
+		"USD": 1,
+		"EUR": 0.92,
+		"GBP": 0.79,
+		"IDR": 16000,
+		"SGD": 1.34,
+		"MYR": 4.7,
+		"INR": 83,
+		"JPY": 150,
+		"AUD": 1.52,
+		"CAD": 1.36,
+	}
+}
+
+// StaticRates is a CurrencyConverter over a table of exchange rates
+// against BaseCurrency. The table can be replaced while in use, e.g. by
+// an admin.
+type StaticRates struct {
+	mu        sync.RWMutex
+	rates     map[string]float64
+	updatedAt time.Time
+}
+
+// NewStaticRates creates a converter over DefaultCurrencyRates.
+func NewStaticRates() *StaticRates {
+	return &StaticRates{rates: DefaultCurrencyRates(), updatedAt: time.Now().UTC()}
+}
+
+// Convert implements CurrencyConverter.
+func (s *StaticRates) Convert(amount float64, from, to string) (float64, bool) {
+	from, to = normalizeCurrency(from), normalizeCurrency(to)
+	if from == to {
+		return amount, true
+	}
+	s.mu.RLock()
+	fromRate, okFrom := s.rates[from]
+	toRate, okTo := s.rates[to]
+	s.mu.RUnlock()
+	if !okFrom || !okTo {
+		return 0, false
+	}
+	return amount / fromRate * toRate, true
+}
+
+// Rates returns a copy of the rate table and when it was last set.
+func (s *StaticRates) Rates() (map[string]float64, time.Time) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	out := make(map[string]float64, len(s.rates))
+	for code, rate := range s.rates {
+		out[code] = rate
+	}
+	return out, s.updatedAt
+}
+
+// SetRates replaces the rate table. Codes are three letters, upper-cased;
+// rates are positive and BaseCurrency, if listed, is 1. BaseCurrency is
+// always kept. The table is left unchanged if any entry is invalid.
+func (s *StaticRates) SetRates(rates map[string]float64) error {
+	table := make(map[string]float64, len(rates)+1)
+	for code, rate := range rates {
+		norm := normalizeCurrency(code)
+		if !validCurrencyCode(norm) {
+			return fmt.Errorf("invalid currency code %q", code)
+		}
+		if rate <= 0 || math.IsInf(rate, 0) || math.IsNaN(rate) {
+			return fmt.Errorf("invalid rate %v for %s", rate, norm)
+		}
+		if norm == BaseCurrency && rate != 1 {
+			return fmt.Errorf("rate of %s must be 1", BaseCurrency)
+		}
+		table[norm] = rate
+	}
+	table[BaseCurrency] = 1
+
+	s.mu.Lock()
+	s.rates = table
+	s.updatedAt = time.Now().UTC()
+	s.mu.Unlock()
+	return nil
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Prices
+// ─────────────────────────────────────────────────────────────────────────────
+
+// currencyDecimals lists the currencies whose minor unit is not a
+// hundredth, e.g. prices in rupiah or yen have no decimals.
+var currencyDecimals = map[string]int{
+	"IDR": 0,
+	"JPY": 0,
+	"KRW": 0,
+	"VND": 0,
+	"CLP": 0,
+	"BHD": 3,
+	"KWD": 3,
+	"OMR": 3,
+}
+
+// decimalsOf returns the number of decimals of prices in currency.
+func decimalsOf(currency string) int {
+	if d, ok := currencyDecimals[currency]; ok {
+		return d
+	}
+	return 2
+}
+
+// roundPrice rounds amount to the minor unit of currency, halves away from
+// zero.
+func roundPrice(amount float64, currency string) float64 {
+	scale := math.Pow10(decimalsOf(currency))
+	return math.Round(amount*scale) / scale
+}
+
+// newPrice returns amount in currency, rounded to its minor unit.
+func newPrice(amount float64, currency string) Price {
+	amount = roundPrice(amount, currency)
+	return Price{Amount: amount, Currency: currency, Display: formatPrice(amount, currency)}
+}
+
+// formatPrice renders an amount with its currency code and thousands
+// separators, e.g. "IDR 319,840" or "USD 1,299.00".
+func formatPrice(amount float64, currency string) string {
+	s := strconv.FormatFloat(math.Abs(amount), 'f', decimalsOf(currency), 64)
+	whole, frac, _ := strings.Cut(s, ".")
+	var b strings.Builder
+	for i, c := range whole {
+		if i > 0 && (len(whole)-i)%3 == 0 {
+			b.WriteByte(',')
+		}
+		b.WriteRune(c)
+	}
+	if frac != "" {
+		b.WriteString("." + frac)
+	}
+	sign := ""
+	if amount < 0 {
+		sign = "-"
+	}
+	return currency + " " + sign + b.String()
+}
+
+// normalizeCurrency trims and upper-cases a currency code; empty is
+// BaseCurrency.
+func normalizeCurrency(code string) string {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	if code == "" {
+		return BaseCurrency
+	}
+	return code
+}
+
+// validCurrencyCode reports whether code looks like an ISO 4217 code.
+func validCurrencyCode(code string) bool {
+	if len(code) != 3 {
+		return false
+	}
+	for _, c := range code {
+		if c < 'A' || c > 'Z' {
+			return false
+		}
+	}
+	return true
+}
+
+// localizeCost sets res.DisplayCost to the resource's cost in currency, and
+// the deprecated res.CostUSD to its cost in USD. A free resource needs no
+// rate; otherwise DisplayCost is nil when the price is unknown or conv knows
+// no rate for either currency, and the resource is treated as over any
+// budget.
+func localizeCost(res *ResourceEntry, conv CurrencyConverter, currency string) {
+	res.CostUSD = 0
+	if usd, ok := convertCost(*res, conv, BaseCurrency); ok {
+		res.CostUSD = roundPrice(usd, BaseCurrency)
+	}
+	amount, ok := convertCost(*res, conv, currency)
+	if !ok {
+		res.DisplayCost = nil
+		return
+	}
+	p := newPrice(amount, currency)
+	res.DisplayCost = &p
+}
+
+// convertCost returns the resource's cost in currency.
+func convertCost(res ResourceEntry, conv CurrencyConverter, currency string) (float64, bool) {
+	from := normalizeCurrency(res.CostCurrency)
+	switch {
+	case res.CostType == "free":
+		return 0, true
+	case res.CostUnknown:
+		return 0, false
+	case from == currency:
+		return res.CostAmount, true
+	case conv == nil:
+		return 0, false
+	default:
+		return conv.Convert(res.CostAmount, from, currency)
+	}
+}
+
+// legacyBudget converts the deprecated USD budget to prefs.Currency when no
+// budget is set in that currency. Without a rate there is no limit.
+func legacyBudget(prefs UserPreferences, conv CurrencyConverter) UserPreferences {
+	if prefs.MaxBudget > 0 || prefs.MaxBudgetUSD <= 0 {
+		return prefs
+	}
+	switch currency := normalizeCurrency(prefs.Currency); {
+	case currency == BaseCurrency:
+		prefs.MaxBudget = prefs.MaxBudgetUSD
+	case conv != nil:
+		if budget, ok := conv.Convert(prefs.MaxBudgetUSD, BaseCurrency, currency); ok {
+			prefs.MaxBudget = budget
+		}
+	}
+	return prefs
+}
+
+// withinBudget reports whether a localized resource costs at most budget.
+// A resource without a known price is over budget.
+func withinBudget(res ResourceEntry, budget float64) bool {
+	return res.DisplayCost != nil && res.DisplayCost.Amount <= budget
+}
@@ -0,0 +1,350 @@
+package recommendation
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/learnbot/resume-parser/internal/scorer"
+	"github.com/learnbot/shared/adminauth"
+	"github.com/learnbot/shared/openapi"
+)
+
+func TestNewPrice_Rounding(t *testing.T) {
+	tests := []struct {
+		amount   float64
+		currency string
+		want     float64
+		display  string
+	}{
+		{19.99 * 16000, "IDR", 319840, "IDR 319,840"},
+		{1234.5, "IDR", 1235, "IDR 1,235"},
+		{18.3908, "EUR", 18.39, "EUR 18.39"},
+		{0.125, "USD", 0.13, "USD 0.13"},
+		{1299, "USD", 1299, "USD 1,299.00"},
+		{2.5, "JPY", 3, "JPY 3"},
+		{1.2345, "KWD", 1.235, "KWD 1.235"},
+		{0, "USD", 0, "USD 0.00"},
+		{1234567.891, "GBP", 1234567.89, "GBP 1,234,567.89"},
+	}
+	for _, tt := range tests {
+		got := newPrice(tt.amount, tt.currency)
+		if got.Amount != tt.want || got.Display != tt.display || got.Currency != tt.currency {
+			t.Errorf("newPrice(%v, %s) = %+v, want %v %q", tt.amount, tt.currency, got, tt.want, tt.display)
+		}
+	}
+}
+
+func TestStaticRates_Convert(t *testing.T) {
+	rates := NewStaticRates()
+
+	if got, ok := rates.Convert(100, "EUR", "idr"); !ok || roundPrice(got, "IDR") != 1739130 {
+		t.Errorf("100 EUR in IDR = %v (%v), want 1739130", got, ok)
+	}
+	if got, ok := rates.Convert(42, "XYZ", "XYZ"); !ok || got != 42 {
+		t.Errorf("same currency = %v (%v), want 42 without a rate", got, ok)
+	}
+	if _, ok := rates.Convert(10, "USD", "XYZ"); ok {
+		t.Error("expected no rate for XYZ")
+	}
+	if _, ok := rates.Convert(10, "XYZ", "USD"); ok {
+		t.Error("expected no rate from XYZ")
+	}
+}
+
+func TestStaticRates_SetRates(t *testing.T) {
+	rates := NewStaticRates()
+	for _, bad := range []map[string]float64{
+		{"EURO": 0.9},
+		{"EUR": 0},
+		{"EUR": -1},
+		{"USD": 2},
+	} {
+		if err := rates.SetRates(bad); err == nil {
+			t.Errorf("SetRates(%v): expected an error", bad)
+		}
+	}
+	if table, _ := rates.Rates(); table["IDR"] != 16000 {
+		t.Errorf("invalid rates changed the table: %v", table)
+	}
+
+	if err := rates.SetRates(map[string]float64{"eur": 0.5}); err != nil {
+		t.Fatalf("SetRates: %v", err)
+	}
+	table, _ := rates.Rates()
+	if len(table) != 2 || table["EUR"] != 0.5 || table["USD"] != 1 {
+		t.Errorf("expected only EUR and USD, got %v", table)
+	}
+	if _, ok := rates.Convert(1, "USD", "IDR"); ok {
+		t.Error("IDR still has a rate after being dropped")
+	}
+}
+
+func TestLocalizeCost_MissingRate(t *testing.T) {
+	rates := NewStaticRates()
+	paid := ResourceEntry{ID: "paid", CostType: "paid", CostAmount: 10, CostCurrency: "XYZ"}
+	free := ResourceEntry{ID: "free", CostType: "free", CostCurrency: "XYZ"}
+
+	localizeCost(&paid, rates, "USD")
+	localizeCost(&free, rates, "USD")
+	if paid.DisplayCost != nil || withinBudget(paid, 1e9) {
+		t.Errorf("a price without a rate must be over any budget, got %+v", paid.DisplayCost)
+	}
+	if free.DisplayCost == nil || free.DisplayCost.Amount != 0 || !withinBudget(free, 1) {
+		t.Errorf("a free resource needs no rate, got %+v", free.DisplayCost)
+	}
+
+	localizeCost(&paid, nil, "USD")
+	if paid.DisplayCost != nil {
+		t.Errorf("expected no price without a converter, got %+v", paid.DisplayCost)
+	}
+}
+
+func TestLocalizeCost_UnknownPrice(t *testing.T) {
+	rates := NewStaticRates()
+	remote := remoteResource{ID: "paid", CostType: "paid", CostCurrency: "USD"}.toEntry()
+	if !remote.CostUnknown {
+		t.Fatal("a paid resource without a cost_amount should have an unknown price")
+	}
+	localizeCost(&remote, rates, "USD")
+	if remote.DisplayCost != nil || withinBudget(remote, 1e9) {
+		t.Errorf("an unknown price must be over any budget, got %+v", remote.DisplayCost)
+	}
+
+	free := remoteResource{ID: "free", CostType: "free"}.toEntry()
+	localizeCost(&free, rates, "IDR")
+	if free.CostUnknown || free.DisplayCost == nil || free.DisplayCost.Amount != 0 {
+		t.Errorf("a free resource without a cost_amount is free, got %+v", free.DisplayCost)
+	}
+
+	// Only free resources skip the exchange rate.
+	freemium := ResourceEntry{ID: "freemium", CostType: "freemium", CostCurrency: "XYZ"}
+	localizeCost(&freemium, rates, "USD")
+	if freemium.DisplayCost != nil {
+		t.Errorf("expected no price without a rate, got %+v", freemium.DisplayCost)
+	}
+}
+
+// currencyTestCatalog holds Python resources priced in three currencies.
+var currencyTestCatalog = []ResourceEntry{
+	{
+		ID: "py-usd", Title: "Python in Dollars", Provider: "A", ResourceType: "course",
+		Difficulty: "intermediate", CostType: "paid", CostAmount: 20, CostCurrency: "USD",
+		DurationHours: 10, Skills: []string{"python"}, PrimarySkill: "python",
+		Rating: 4.5, RatingCount: 1000, IsVerified: true,
+	},
+	{
+		ID: "py-idr", Title: "Python in Rupiah", Provider: "B", ResourceType: "course",
+		Difficulty: "intermediate", CostType: "paid", CostAmount: 800000, CostCurrency: "IDR",
+		DurationHours: 10, Skills: []string{"python"}, PrimarySkill: "python",
+		Rating: 4.5, RatingCount: 1000, IsVerified: true,
+	},
+	{
+		ID: "py-xyz", Title: "Python in Unknown Money", Provider: "C", ResourceType: "course",
+		Difficulty: "intermediate", CostType: "paid", CostAmount: 1, CostCurrency: "XYZ",
+		DurationHours: 10, Skills: []string{"python"}, PrimarySkill: "python",
+		Rating: 4.5, RatingCount: 1000, IsVerified: true,
+	},
+}
+
+func TestFindMatchingResources_BudgetInUserCurrency(t *testing.T) {
+	engine := NewWithCatalog(currencyTestCatalog)
+
+	// 500,000 rupiah covers $20 (320,000) but not 800,000 rupiah, and the
+	// resource without a rate is never within budget.
+	resources := engine.findMatchingResources(context.Background(), "Python",
+		UserPreferences{MaxBudget: 500000, Currency: "IDR"})
+	if len(resources) != 1 || resources[0].ID != "py-usd" {
+		t.Fatalf("expected only py-usd within budget, got %v", resources)
+	}
+	if p := resources[0].DisplayCost; p == nil || p.Amount != 320000 || p.Display != "IDR 320,000" {
+		t.Errorf("unexpected display cost %+v", p)
+	}
+
+	// Without a budget, the unknown price is kept but not shown.
+	resources = engine.findMatchingResources(context.Background(), "Python", UserPreferences{Currency: "IDR"})
+	if len(resources) != 3 {
+		t.Fatalf("expected all 3 resources without a budget, got %d", len(resources))
+	}
+	for _, r := range resources {
+		if r.ID == "py-xyz" && r.DisplayCost != nil {
+			t.Errorf("expected no display cost without a rate, got %+v", r.DisplayCost)
+		}
+	}
+}
+
+func TestFindMatchingResources_UnknownPriceIsOverBudget(t *testing.T) {
+	unpriced := currencyTestCatalog[0]
+	unpriced.ID, unpriced.CostAmount, unpriced.CostUnknown = "py-unpriced", 0, true
+	engine := NewWithCatalog([]ResourceEntry{unpriced, currencyTestCatalog[0]})
+
+	resources := engine.findMatchingResources(context.Background(), "Python", UserPreferences{MaxBudget: 50})
+	if len(resources) != 1 || resources[0].ID != "py-usd" {
+		t.Errorf("expected only py-usd within budget, got %v", resources)
+	}
+}
+
+func TestFindMatchingResources_MissingRateIsNotFree(t *testing.T) {
+	engine := NewWithCatalog(currencyTestCatalog)
+
+	resources := engine.findMatchingResources(context.Background(), "Python", UserPreferences{PreferFree: true})
+	if len(resources) != 0 {
+		t.Errorf("expected no free resources, got %v", resources)
+	}
+}
+
+func TestComputePreferenceAlignment_BudgetFitInUserCurrency(t *testing.T) {
+	engine := NewWithCatalog(currencyTestCatalog)
+	prefs := UserPreferences{MaxBudget: 1000000, Currency: "IDR"}
+
+	resources := engine.findMatchingResources(context.Background(), "Python", prefs)
+	scores := map[string]float64{}
+	for _, r := range resources {
+		scores[r.ID] = computePreferenceAlignment(r, prefs)
+	}
+	// The cheaper resource fits the budget better; the one without a price
+	// gets no budget fit at all.
+	if !(scores["py-usd"] > scores["py-idr"] && scores["py-idr"] > scores["py-xyz"]) {
+		t.Errorf("expected py-usd > py-idr > py-xyz, got %v", scores)
+	}
+}
+
+func TestGenerate_TotalCostInUserCurrency(t *testing.T) {
+	engine := NewWithCatalog(currencyTestCatalog)
+	job := scorer.JobRequirements{Title: "Data Engineer", RequiredSkills: []string{"Python"}}
+
+	plan := engine.Generate(scorer.CandidateProfile{}, job, UserPreferences{Currency: "eur"})
+	primary := findSkill(t, plan, "Python").PrimaryResource
+	if primary == nil || primary.Resource.DisplayCost == nil {
+		t.Fatalf("expected a priced primary resource, got %+v", primary)
+	}
+	total := plan.Summary.EstimatedTotalCost
+	if total.Currency != "EUR" || total.Amount != primary.Resource.DisplayCost.Amount {
+		t.Errorf("total %+v, want the primary's %+v", total, primary.Resource.DisplayCost)
+	}
+}
+
+func TestUserPreferences_LegacyBudgetUSD(t *testing.T) {
+	var prefs UserPreferences
+	if err := json.Unmarshal([]byte(`{"max_budget_usd":25,"currency":"IDR"}`), &prefs); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	if got := legacyBudget(applyPreferenceDefaults(prefs), NewStaticRates()); got.MaxBudget != 400000 {
+		t.Errorf("MaxBudget = %v, want $25 in rupiah (400000)", got.MaxBudget)
+	}
+	if got := legacyBudget(UserPreferences{MaxBudgetUSD: 25, Currency: "USD"}, nil); got.MaxBudget != 25 {
+		t.Errorf("MaxBudget = %v, want 25 without conversion", got.MaxBudget)
+	}
+	if got := legacyBudget(UserPreferences{MaxBudget: 100, MaxBudgetUSD: 25, Currency: "USD"}, nil); got.MaxBudget != 100 {
+		t.Errorf("MaxBudget = %v, want max_budget to win over max_budget_usd", got.MaxBudget)
+	}
+
+	// The legacy budget filters like max_budget: $25 covers py-usd but not
+	// 800,000 rupiah.
+	engine := NewWithCatalog(currencyTestCatalog[:2])
+	job := scorer.JobRequirements{Title: "Data Engineer", RequiredSkills: []string{"Python"}}
+	rec := findSkill(t, engine.Generate(scorer.CandidateProfile{}, job, prefs), "Python")
+	if rec.PrimaryResource == nil || rec.PrimaryResource.Resource.ID != "py-usd" || len(rec.AlternativeResources) != 0 {
+		t.Errorf("expected only py-usd within the legacy budget, got %+v", rec)
+	}
+}
+
+func TestGenerate_LegacyUSDCosts(t *testing.T) {
+	engine := NewWithCatalog(currencyTestCatalog[1:2])
+	job := scorer.JobRequirements{Title: "Data Engineer", RequiredSkills: []string{"Python"}}
+
+	plan := engine.Generate(scorer.CandidateProfile{}, job, UserPreferences{Currency: "IDR"})
+	primary := findSkill(t, plan, "Python").PrimaryResource
+	if primary == nil || primary.Resource.CostUSD != 50 {
+		t.Fatalf("expected 800,000 rupiah as $50, got %+v", primary)
+	}
+	if plan.Summary.EstimatedTotalCostUSD != 50 {
+		t.Errorf("EstimatedTotalCostUSD = %v, want 50", plan.Summary.EstimatedTotalCostUSD)
+	}
+	data, err := json.Marshal(plan)
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+	for _, field := range []string{`"cost_usd":50`, `"estimated_total_cost_usd":50`, `"display_cost":`} {
+		if !strings.Contains(string(data), field) {
+			t.Errorf("plan JSON lacks %s", field)
+		}
+	}
+}
+
+func TestRecommendationHandler_UsesConverter(t *testing.T) {
+	h := NewHandler(log.New(io.Discard, "", 0))
+	rates := NewStaticRates()
+	h.SetCurrencyConverter(rates)
+	if err := rates.SetRates(map[string]float64{"XYZ": 2}); err != nil {
+		t.Fatal(err)
+	}
+	mux := http.NewServeMux()
+	h.RegisterRoutes(mux)
+
+	for currency, want := range map[string]int{"XYZ": http.StatusOK, "IDR": http.StatusUnprocessableEntity} {
+		body := `{"profile":{},"job":{"required_skills":["Go"]},"preferences":{"currency":"` + currency + `"}}`
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/api/v1/recommendations", strings.NewReader(body)))
+		if w.Code != want {
+			t.Errorf("currency %s: status %d, want %d: %s", currency, w.Code, want, w.Body)
+		}
+	}
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Rates handler tests
+// ─────────────────────────────────────────────────────────────────────────────
+
+func TestRatesHandler(t *testing.T) {
+	rates := NewStaticRates()
+	logger := log.New(io.Discard, "", 0)
+	h := NewRatesHandler(rates, adminauth.New(adminauth.Config{APIKey: "secret"}, logger), logger)
+	mux := http.NewServeMux()
+	h.RegisterRoutes(mux)
+
+	tests := []struct {
+		name   string
+		method string
+		key    string
+		body   string
+		want   int
+	}{
+		{"no key", http.MethodGet, "", "", http.StatusUnauthorized},
+		{"get", http.MethodGet, "secret", "", http.StatusOK},
+		{"invalid rate", http.MethodPut, "secret", `{"rates":{"EUR":-1}}`, http.StatusUnprocessableEntity},
+		{"missing rates", http.MethodPut, "secret", `{}`, http.StatusUnprocessableEntity},
+		{"not json", http.MethodPut, "secret", `rates`, http.StatusBadRequest},
+		{"put", http.MethodPut, "secret", `{"rates":{"EUR":0.5,"IDR":15000}}`, http.StatusOK},
+		{"delete", http.MethodDelete, "secret", "", http.StatusMethodNotAllowed},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest(tt.method, "/admin/currency-rates", strings.NewReader(tt.body))
+		if tt.key != "" {
+			req.Header.Set(adminauth.APIKeyHeader, tt.key)
+		}
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+		if w.Code != tt.want {
+			t.Errorf("%s: status %d, want %d: %s", tt.name, w.Code, tt.want, w.Body)
+		}
+	}
+
+	table, _ := rates.Rates()
+	if len(table) != 3 || table["EUR"] != 0.5 || table["IDR"] != 15000 {
+		t.Errorf("unexpected rates after PUT: %v", table)
+	}
+}
+
+func TestRatesHandler_DescribeRoutes(t *testing.T) {
+	logger := log.New(io.Discard, "", 0)
+	h := NewRatesHandler(NewStaticRates(), adminauth.New(adminauth.Config{}, logger), logger)
+	if err := openapi.CheckRoutes(h.RegisterRoutes, h.DescribeRoutes); err != nil {
+		t.Error(err)
+	}
+}
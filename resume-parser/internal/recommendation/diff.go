@@ -0,0 +1,116 @@
+// Package recommendation – diff.go implements a field-level comparison of
+// learning plans, used to review how engine changes affect generated plans.
+package recommendation
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+)
+
+// DiffPlans compares two learning plans field by field and returns one line
+// per difference, e.g.
+//
+//	phases[0].skills[1].primary_resource.resource.id: "go-tour" → "go-by-example"
+//
+// Paths use the plans' JSON field names. An empty result means the plans are
+// identical.
+func DiffPlans(want, got LearningPlan) []string {
+	a, errA := toJSONValue(want)
+	b, errB := toJSONValue(got)
+	if errA != nil || errB != nil {
+		return []string{fmt.Sprintf("cannot compare plans: %v %v", errA, errB)}
+	}
+	var diffs []string
+	diffJSONValues("", a, b, &diffs)
+	return diffs
+}
+
+// toJSONValue converts v to its generic JSON representation.
+func toJSONValue(v interface{}) (interface{}, error) {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var out interface{}
+	if err := json.Unmarshal(raw, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// diffJSONValues appends the differences between a and b under path.
+func diffJSONValues(path string, a, b interface{}, diffs *[]string) {
+	switch av := a.(type) {
+	case map[string]interface{}:
+		bv, ok := b.(map[string]interface{})
+		if !ok {
+			break
+		}
+		keys := make([]string, 0, len(av)+len(bv))
+		for k := range av {
+			keys = append(keys, k)
+		}
+		for k := range bv {
+			if _, seen := av[k]; !seen {
+				keys = append(keys, k)
+			}
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			diffJSONValues(joinPath(path, k), av[k], bv[k], diffs)
+		}
+		return
+
+	case []interface{}:
+		bv, ok := b.([]interface{})
+		if !ok {
+			break
+		}
+		n := max(len(av), len(bv))
+		for i := 0; i < n; i++ {
+			p := fmt.Sprintf("%s[%d]", path, i)
+			switch {
+			case i >= len(av):
+				*diffs = append(*diffs, fmt.Sprintf("%s: added %s", p, formatJSONValue(bv[i])))
+			case i >= len(bv):
+				*diffs = append(*diffs, fmt.Sprintf("%s: removed %s", p, formatJSONValue(av[i])))
+			default:
+				diffJSONValues(p, av[i], bv[i], diffs)
+			}
+		}
+		return
+	}
+
+	if fmt.Sprint(a) != fmt.Sprint(b) || fmt.Sprintf("%T", a) != fmt.Sprintf("%T", b) {
+		*diffs = append(*diffs, fmt.Sprintf("%s: %s → %s", path, formatJSONValue(a), formatJSONValue(b)))
+	}
+}
+
+// joinPath appends a field name to a JSON path.
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+// formatJSONValue renders a value compactly for diff output. Objects are
+// summarised by their id or title where available to keep lines readable.
+func formatJSONValue(v interface{}) string {
+	if m, ok := v.(map[string]interface{}); ok {
+		for _, key := range []string{"id", "skill_name", "title", "phase_name"} {
+			if s, ok := m[key].(string); ok {
+				return fmt.Sprintf("{%s: %q}", key, s)
+			}
+		}
+	}
+	if v == nil {
+		return "null"
+	}
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(raw)
+}
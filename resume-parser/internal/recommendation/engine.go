@@ -4,10 +4,13 @@
 package recommendation
 
 import (
+	"context"
 	"fmt"
+	"log"
 	"math"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/learnbot/resume-parser/internal/gapanalysis"
 	"github.com/learnbot/resume-parser/internal/scorer"
@@ -20,43 +23,125 @@ import (
 // Engine is the training recommendation engine.
 type Engine struct {
 	gapAnalyzer *gapanalysis.Analyzer
-	catalog     []ResourceEntry
+	sources     []CatalogSource
+	questions   []QuestionEntry
+	converter   CurrencyConverter
+	logger      *log.Logger
+
+	// now is the clock used to anchor the timeline's completion date.
+	now func() time.Time
+
+	// deterministic enables total ordering of tied skills and resources.
+	deterministic bool
 }
 
 // New creates a new recommendation Engine with the built-in resource catalog.
 func New() *Engine {
-	return &Engine{
-		gapAnalyzer: gapanalysis.New(),
-		catalog:     builtinCatalog,
-	}
+	return NewWithCatalog(builtinCatalog)
 }
 
 // NewWithCatalog creates a new Engine with a custom resource catalog.
 // Useful for testing.
 func NewWithCatalog(catalog []ResourceEntry) *Engine {
+	return NewWithSources(nil, NewMemoryCatalogSource(catalog))
+}
+
+// NewWithSources creates a new Engine that draws resources from all of the
+// given sources, in order. A source that fails is skipped with a warning
+// written to logger (the standard logger if nil), so the remaining sources
+// still produce a plan.
+func NewWithSources(logger *log.Logger, sources ...CatalogSource) *Engine {
+	if logger == nil {
+		logger = log.Default()
+	}
 	return &Engine{
 		gapAnalyzer: gapanalysis.New(),
-		catalog:     catalog,
+		sources:     sources,
+		questions:   builtinQuestionBank,
+		converter:   NewStaticRates(),
+		logger:      logger,
+		now:         time.Now,
 	}
 }
 
+// SetCurrencyConverter sets the exchange rates used to convert resource
+// prices to the user's currency, by default the built-in static rates.
+func (e *Engine) SetCurrencyConverter(c CurrencyConverter) {
+	e.converter = c
+}
+
+// Deterministic returns a copy of the engine whose plans depend only on its
+// inputs: the timeline starts at the fixed time now, and skills or resources
+// with equal scores are ordered by name and ID instead of catalog position.
+// It is intended for offline evaluation and golden-file tests.
+func (e *Engine) Deterministic(now time.Time) *Engine {
+	c := *e
+	c.now = func() time.Time { return now }
+	c.deterministic = true
+	return &c
+}
+
 // Generate produces a personalized learning plan for the given profile, job,
 // and user preferences.
 func (e *Engine) Generate(
 	profile scorer.CandidateProfile,
 	job scorer.JobRequirements,
 	prefs UserPreferences,
+) LearningPlan {
+	return e.GenerateContext(context.Background(), profile, job, prefs)
+}
+
+// GenerateContext is like Generate; ctx bounds requests to remote catalog
+// sources.
+func (e *Engine) GenerateContext(
+	ctx context.Context,
+	profile scorer.CandidateProfile,
+	job scorer.JobRequirements,
+	prefs UserPreferences,
+) LearningPlan {
+	return e.generate(ctx, profile, job, prefs, nil)
+}
+
+// GenerateWithProgress is like GenerateContext for a user regenerating
+// their plan. Completed resources are not recommended again, and skills the
+// user has made progress on need fewer hours; skills and phases already
+// covered are kept, marked completed, with no hours left. The timeline
+// schedules only the remaining hours, and the summary describes the
+// progress since the last plan.
+func (e *Engine) GenerateWithProgress(
+	ctx context.Context,
+	profile scorer.CandidateProfile,
+	job scorer.JobRequirements,
+	prefs UserPreferences,
+	progress LearningProgress,
+) LearningPlan {
+	return e.generate(ctx, profile, job, prefs, newProgressIndex(progress))
+}
+
+// generate builds a learning plan, adapted to progress unless it is nil.
+func (e *Engine) generate(
+	ctx context.Context,
+	profile scorer.CandidateProfile,
+	job scorer.JobRequirements,
+	prefs UserPreferences,
+	progress *progressIndex,
 ) LearningPlan {
 	// Apply defaults to preferences.
-	prefs = applyPreferenceDefaults(prefs)
+	prefs = legacyBudget(applyPreferenceDefaults(prefs), e.converter)
 
 	// Run gap analysis.
 	gapResult := e.gapAnalyzer.Analyze(profile, job)
 
 	// Build skill recommendations for each gap category.
-	criticalRecs := e.buildSkillRecommendations(gapResult.CriticalGaps, prefs)
-	importantRecs := e.buildSkillRecommendations(gapResult.ImportantGaps, prefs)
-	niceToHaveRecs := e.buildSkillRecommendations(gapResult.NiceToHaveGaps, prefs)
+	criticalRecs := e.buildSkillRecommendations(ctx, gapResult.CriticalGaps, prefs, progress)
+	importantRecs := e.buildSkillRecommendations(ctx, gapResult.ImportantGaps, prefs, progress)
+	niceToHaveRecs := e.buildSkillRecommendations(ctx, gapResult.NiceToHaveGaps, prefs, progress)
+
+	if e.deterministic {
+		sortSkillRecommendations(criticalRecs)
+		sortSkillRecommendations(importantRecs)
+		sortSkillRecommendations(niceToHaveRecs)
+	}
 
 	// Build learning phases.
 	phases := buildPhases(criticalRecs, importantRecs, niceToHaveRecs, prefs)
@@ -65,10 +150,13 @@ func (e *Engine) Generate(
 	totalHours := sumPhaseHours(phases)
 
 	// Build timeline.
-	timeline := buildTimeline(phases, prefs, job.Title)
+	timeline := buildTimelineAt(phases, prefs, job.Title, e.now())
 
 	// Build summary.
-	summary := buildSummary(gapResult, phases, job.Title)
+	summary := buildSummary(gapResult, phases, job.Title, prefs.Currency)
+	if progress != nil {
+		summary.Progress = buildPlanProgress(progress, phases)
+	}
 
 	return LearningPlan{
 		JobTitle:            job.Title,
@@ -86,28 +174,49 @@ func (e *Engine) Generate(
 // Skill recommendation building
 // ─────────────────────────────────────────────────────────────────────────────
 
-// buildSkillRecommendations creates SkillRecommendation entries for a list of gaps.
-func (e *Engine) buildSkillRecommendations(gaps []gapanalysis.SkillGap, prefs UserPreferences) []SkillRecommendation {
+// buildSkillRecommendations creates SkillRecommendation entries for a list of
+// gaps, adapted to progress unless it is nil.
+func (e *Engine) buildSkillRecommendations(ctx context.Context, gaps []gapanalysis.SkillGap, prefs UserPreferences, progress *progressIndex) []SkillRecommendation {
 	var recs []SkillRecommendation
 	for _, gap := range gaps {
-		rec := e.buildSkillRecommendation(gap, prefs)
+		rec := e.buildSkillRecommendation(ctx, gap, prefs, progress)
+		if !rec.Completed && practiceLevel(gap.TargetLevel) {
+			rec.PracticeQuestions = e.recommendQuestions(gap.SkillName, gap.TargetLevel, gap.CurrentLevel, DefaultMaxQuestionSets)
+		}
 		recs = append(recs, rec)
 	}
 	return recs
 }
 
-// buildSkillRecommendation creates a SkillRecommendation for a single gap.
-func (e *Engine) buildSkillRecommendation(gap gapanalysis.SkillGap, prefs UserPreferences) SkillRecommendation {
-	// Find matching resources from the catalog.
-	candidates := e.findMatchingResources(gap.SkillName, prefs)
+// buildSkillRecommendation creates a SkillRecommendation for a single gap,
+// adapted to progress unless it is nil.
+func (e *Engine) buildSkillRecommendation(ctx context.Context, gap gapanalysis.SkillGap, prefs UserPreferences, progress *progressIndex) SkillRecommendation {
+	learned := progress.skillProgress(gap.SkillName)
+	if learned >= 1 {
+		return completedSkillRecommendation(gap)
+	}
+
+	// Find matching resources from the catalog sources, except those the
+	// user has completed.
+	candidates := progress.withoutCompleted(e.findMatchingResources(ctx, gap.SkillName, prefs))
 
 	// Score and rank candidates.
 	scored := e.scoreResources(candidates, gap, prefs)
 
 	// Sort by relevance score descending.
-	sort.Slice(scored, func(i, j int) bool {
-		return scored[i].RelevanceScore > scored[j].RelevanceScore
-	})
+	if e.deterministic {
+		sort.SliceStable(scored, func(i, j int) bool {
+			if scored[i].RelevanceScore != scored[j].RelevanceScore {
+				return scored[i].RelevanceScore > scored[j].RelevanceScore
+			}
+			return scored[i].Resource.ID < scored[j].Resource.ID
+		})
+	} else {
+		sort.Slice(scored, func(i, j int) bool {
+			return scored[i].RelevanceScore > scored[j].RelevanceScore
+		})
+	}
+	progress.preferInProgress(scored)
 
 	var primary *RecommendedResource
 	var alternatives []RecommendedResource
@@ -131,7 +240,7 @@ func (e *Engine) buildSkillRecommendation(gap gapanalysis.SkillGap, prefs UserPr
 		}
 	}
 
-	return SkillRecommendation{
+	rec := SkillRecommendation{
 		SkillName:                gap.SkillName,
 		GapCategory:              string(gap.Category),
 		PriorityScore:            gap.PriorityScore,
@@ -141,23 +250,46 @@ func (e *Engine) buildSkillRecommendation(gap gapanalysis.SkillGap, prefs UserPr
 		CurrentLevel:             gap.CurrentLevel,
 		TargetLevel:              gap.TargetLevel,
 	}
+	applySkillProgress(&rec, learned)
+	return rec
+}
+
+// sortSkillRecommendations orders recommendations by priority descending,
+// breaking ties by skill name.
+func sortSkillRecommendations(recs []SkillRecommendation) {
+	sort.SliceStable(recs, func(i, j int) bool {
+		if recs[i].PriorityScore != recs[j].PriorityScore {
+			return recs[i].PriorityScore > recs[j].PriorityScore
+		}
+		return normalizeSkillName(recs[i].SkillName) < normalizeSkillName(recs[j].SkillName)
+	})
 }
 
 // ─────────────────────────────────────────────────────────────────────────────
 // Resource matching and scoring
 // ─────────────────────────────────────────────────────────────────────────────
 
-// findMatchingResources returns catalog resources that cover the given skill.
-func (e *Engine) findMatchingResources(skillName string, prefs UserPreferences) []ResourceEntry {
-	norm := normalizeSkillName(skillName)
-	canonical := resolveAlias(norm)
-
-	var matches []ResourceEntry
-	for _, res := range e.catalog {
-		if !resourceMatchesSkill(res, canonical, norm) {
+// findMatchingResources returns resources from all catalog sources that
+// cover the given skill, deduplicated by URL, with their prices converted
+// to the user's currency. Resources over the user's budget, or without a
+// known price, are left out.
+func (e *Engine) findMatchingResources(ctx context.Context, skillName string, prefs UserPreferences) []ResourceEntry {
+	lists := make([][]ResourceEntry, 0, len(e.sources))
+	for _, src := range e.sources {
+		found, err := src.FindBySkill(ctx, skillName, prefs)
+		if err != nil {
+			e.logger.Printf("WARN: catalog source %T failed for %q, skipping: %v", src, skillName, err)
 			continue
 		}
-		if !passesPreferenceFilter(res, prefs) {
+		lists = append(lists, found)
+	}
+
+	currency := normalizeCurrency(prefs.Currency)
+	merged := mergeResources(lists...)
+	matches := merged[:0]
+	for _, res := range merged {
+		localizeCost(&res, e.converter, currency)
+		if prefs.MaxBudget > 0 && !withinBudget(res, prefs.MaxBudget) {
 			continue
 		}
 		matches = append(matches, res)
@@ -196,11 +328,6 @@ func passesPreferenceFilter(res ResourceEntry, prefs UserPreferences) bool {
 		return false
 	}
 
-	// Budget filter.
-	if prefs.MaxBudgetUSD > 0 && res.CostUSD > prefs.MaxBudgetUSD {
-		return false
-	}
-
 	// Excluded providers.
 	for _, excluded := range prefs.ExcludedProviders {
 		if strings.EqualFold(res.Provider, excluded) {
@@ -283,6 +410,15 @@ func computeRelevanceScore(res ResourceEntry, gap gapanalysis.SkillGap, prefs Us
 		popularityScore*0.10
 }
 
+// levelRank maps difficulty and proficiency levels to numeric ranks.
+var levelRank = map[string]int{
+	"beginner":     1,
+	"intermediate": 2,
+	"advanced":     3,
+	"expert":       4,
+	"all_levels":   2, // treat as intermediate
+}
+
 // computeDifficultyFit returns a score [0, 1] for how well the resource
 // difficulty matches the user's current level and target level.
 func computeDifficultyFit(resDifficulty, targetLevel, currentLevel string) float64 {
@@ -290,15 +426,6 @@ func computeDifficultyFit(resDifficulty, targetLevel, currentLevel string) float
 		return 0.9
 	}
 
-	// Map levels to numeric ranks.
-	levelRank := map[string]int{
-		"beginner":     1,
-		"intermediate": 2,
-		"advanced":     3,
-		"expert":       4,
-		"all_levels":   2, // treat as intermediate
-	}
-
 	targetRank := levelRank[strings.ToLower(targetLevel)]
 	if targetRank == 0 {
 		targetRank = 2 // default to intermediate
@@ -347,6 +474,12 @@ func computePreferenceAlignment(res ResourceEntry, prefs UserPreferences) float6
 		score += 0.1
 	}
 
+	// Budget fit: the less of the budget a resource takes, the better. A
+	// resource without a known price gets nothing.
+	if prefs.MaxBudget > 0 && withinBudget(res, prefs.MaxBudget) {
+		score += 0.1 * (1 - res.DisplayCost.Amount/prefs.MaxBudget)
+	}
+
 	return math.Min(1.0, score)
 }
 
@@ -414,8 +547,12 @@ func buildPhase(
 	description string,
 ) LearningPhase {
 	totalHours := 0.0
+	hoursCompleted := 0.0
+	completed := len(recs) > 0
 	for _, rec := range recs {
 		totalHours += float64(rec.EstimatedHoursToJobReady)
+		hoursCompleted += rec.HoursCompleted
+		completed = completed && rec.Completed
 	}
 
 	weeklyHours := prefs.WeeklyHoursAvailable
@@ -434,6 +571,8 @@ func buildPhase(
 		TotalHours:       roundTo2(totalHours),
 		EstimatedWeeks:   roundTo1(estimatedWeeks),
 		Milestone:        milestone,
+		HoursCompleted:   roundTo2(hoursCompleted),
+		Completed:        completed,
 	}
 }
 
@@ -465,27 +604,36 @@ func buildPhaseMilestone(phaseNum int, phaseName string, recs []SkillRecommendat
 // Summary building
 // ─────────────────────────────────────────────────────────────────────────────
 
-// buildSummary creates a high-level summary of the learning plan.
+// buildSummary creates a high-level summary of the learning plan, with
+// costs in currency.
 func buildSummary(
 	gapResult gapanalysis.GapAnalysisResult,
 	phases []LearningPhase,
 	jobTitle string,
+	currency string,
 ) LearningPlanSummary {
 	freeCount := 0
 	paidCount := 0
 	totalCost := 0.0
+	totalCostUSD := 0.0
 	topSkills := make([]string, 0, 3)
 	quickWins := make([]string, 0)
 
 	for _, phase := range phases {
 		for _, rec := range phase.Skills {
+			if rec.Completed {
+				continue
+			}
 			if rec.PrimaryResource != nil {
 				if rec.PrimaryResource.Resource.CostType == "free" ||
 					rec.PrimaryResource.Resource.CostType == "free_audit" {
 					freeCount++
 				} else {
 					paidCount++
-					totalCost += rec.PrimaryResource.Resource.CostUSD
+					if cost := rec.PrimaryResource.Resource.DisplayCost; cost != nil {
+						totalCost += cost.Amount
+					}
+					totalCostUSD += rec.PrimaryResource.Resource.CostUSD
 				}
 			}
 			if len(topSkills) < 3 {
@@ -506,7 +654,8 @@ func buildSummary(
 		ImportantGapCount:     gapResult.ImportantGapCount,
 		FreeResourceCount:     freeCount,
 		PaidResourceCount:     paidCount,
-		EstimatedTotalCostUSD: roundTo2(totalCost),
+		EstimatedTotalCost:    newPrice(totalCost, normalizeCurrency(currency)),
+		EstimatedTotalCostUSD: roundPrice(totalCostUSD, BaseCurrency),
 		TopSkillsToLearn:      topSkills,
 		QuickWins:             quickWins,
 	}
@@ -519,8 +668,14 @@ func buildHeadline(gapResult gapanalysis.GapAnalysisResult, phases []LearningPha
 	}
 
 	totalWeeks := 0.0
+	allCompleted := len(phases) > 0
 	for _, p := range phases {
 		totalWeeks += p.EstimatedWeeks
+		allCompleted = allCompleted && p.Completed
+	}
+
+	if allCompleted {
+		return fmt.Sprintf("🎉 You've covered every skill gap for %s – ready to apply!", jobTitle)
 	}
 
 	if gapResult.CriticalGapCount == 0 {
@@ -590,6 +745,7 @@ func applyPreferenceDefaults(prefs UserPreferences) UserPreferences {
 	if prefs.WeeklyHoursAvailable <= 0 {
 		prefs.WeeklyHoursAvailable = 10
 	}
+	prefs.Currency = normalizeCurrency(prefs.Currency)
 	return prefs
 }
 
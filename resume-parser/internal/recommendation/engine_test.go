@@ -1,6 +1,7 @@
 package recommendation
 
 import (
+	"context"
 	"math"
 	"testing"
 
@@ -29,7 +30,7 @@ var testCatalog = []ResourceEntry{
 		Description: "Learn Python from scratch.",
 		URL:         "https://example.com/python",
 		Provider:    "TestProvider", ResourceType: "course", Difficulty: "beginner",
-		CostType: "free", CostUSD: 0, DurationHours: 20,
+		CostType: "free", CostAmount: 0, CostCurrency: "USD", DurationHours: 20,
 		Skills: []string{"python"}, PrimarySkill: "python",
 		Rating: 4.5, RatingCount: 10000, HasCertificate: true, HasHandsOn: true, IsVerified: true,
 	},
@@ -38,7 +39,7 @@ var testCatalog = []ResourceEntry{
 		Description: "Advanced Python programming.",
 		URL:         "https://example.com/python-advanced",
 		Provider:    "OtherProvider", ResourceType: "course", Difficulty: "advanced",
-		CostType: "paid", CostUSD: 29.99, DurationHours: 15,
+		CostType: "paid", CostAmount: 29.99, CostCurrency: "USD", DurationHours: 15,
 		Skills: []string{"python", "oop"}, PrimarySkill: "python",
 		Rating: 4.7, RatingCount: 5000, HasCertificate: false, HasHandsOn: true, IsVerified: true,
 	},
@@ -47,7 +48,7 @@ var testCatalog = []ResourceEntry{
 		Description: "Learn Go programming.",
 		URL:         "https://example.com/go",
 		Provider:    "TestProvider", ResourceType: "course", Difficulty: "intermediate",
-		CostType: "paid", CostUSD: 19.99, DurationHours: 10,
+		CostType: "paid", CostAmount: 19.99, CostCurrency: "USD", DurationHours: 10,
 		Skills: []string{"go"}, PrimarySkill: "go",
 		Rating: 4.6, RatingCount: 8000, HasCertificate: true, HasHandsOn: true, IsVerified: true,
 	},
@@ -56,7 +57,7 @@ var testCatalog = []ResourceEntry{
 		Description: "Learn Docker.",
 		URL:         "https://example.com/docker",
 		Provider:    "TestProvider", ResourceType: "course", Difficulty: "intermediate",
-		CostType: "free", CostUSD: 0, DurationHours: 8,
+		CostType: "free", CostAmount: 0, CostCurrency: "USD", DurationHours: 8,
 		Skills: []string{"docker"}, PrimarySkill: "docker",
 		Rating: 4.4, RatingCount: 3000, HasCertificate: false, HasHandsOn: true, IsVerified: false,
 	},
@@ -65,7 +66,7 @@ var testCatalog = []ResourceEntry{
 		Description: "Learn SQL.",
 		URL:         "https://example.com/sql",
 		Provider:    "TestProvider", ResourceType: "course", Difficulty: "beginner",
-		CostType: "free", CostUSD: 0, DurationHours: 5,
+		CostType: "free", CostAmount: 0, CostCurrency: "USD", DurationHours: 5,
 		Skills: []string{"sql", "postgresql"}, PrimarySkill: "sql",
 		Rating: 4.3, RatingCount: 2000, HasCertificate: false, HasHandsOn: true, IsVerified: true,
 	},
@@ -242,7 +243,7 @@ func TestFindMatchingResources_ExactMatch(t *testing.T) {
 	engine := newTestEngine()
 	prefs := UserPreferences{}
 
-	resources := engine.findMatchingResources("Python", prefs)
+	resources := engine.findMatchingResources(context.Background(), "Python", prefs)
 
 	if len(resources) == 0 {
 		t.Error("expected resources for Python")
@@ -266,7 +267,7 @@ func TestFindMatchingResources_AliasMatch(t *testing.T) {
 	prefs := UserPreferences{}
 
 	// "golang" should match "go" resources.
-	resources := engine.findMatchingResources("golang", prefs)
+	resources := engine.findMatchingResources(context.Background(), "golang", prefs)
 
 	if len(resources) == 0 {
 		t.Error("expected resources for golang (alias for go)")
@@ -277,7 +278,7 @@ func TestFindMatchingResources_NoMatch(t *testing.T) {
 	engine := newTestEngine()
 	prefs := UserPreferences{}
 
-	resources := engine.findMatchingResources("some_obscure_skill_xyz", prefs)
+	resources := engine.findMatchingResources(context.Background(), "some_obscure_skill_xyz", prefs)
 
 	if len(resources) != 0 {
 		t.Errorf("expected 0 resources for unknown skill, got %d", len(resources))
@@ -288,7 +289,7 @@ func TestFindMatchingResources_FreeFilter(t *testing.T) {
 	engine := newTestEngine()
 	prefs := UserPreferences{PreferFree: true}
 
-	resources := engine.findMatchingResources("Python", prefs)
+	resources := engine.findMatchingResources(context.Background(), "Python", prefs)
 
 	for _, r := range resources {
 		if r.CostType != "free" && r.CostType != "free_audit" {
@@ -299,13 +300,13 @@ func TestFindMatchingResources_FreeFilter(t *testing.T) {
 
 func TestFindMatchingResources_BudgetFilter(t *testing.T) {
 	engine := newTestEngine()
-	prefs := UserPreferences{MaxBudgetUSD: 25.00}
+	prefs := UserPreferences{MaxBudget: 25.00}
 
-	resources := engine.findMatchingResources("Python", prefs)
+	resources := engine.findMatchingResources(context.Background(), "Python", prefs)
 
 	for _, r := range resources {
-		if r.CostUSD > 25.00 {
-			t.Errorf("resource %q exceeds budget: $%.2f > $25.00", r.Title, r.CostUSD)
+		if r.DisplayCost == nil || r.DisplayCost.Amount > 25.00 {
+			t.Errorf("resource %q exceeds budget: %+v > 25.00", r.Title, r.DisplayCost)
 		}
 	}
 }
@@ -314,7 +315,7 @@ func TestFindMatchingResources_ExcludedProvider(t *testing.T) {
 	engine := newTestEngine()
 	prefs := UserPreferences{ExcludedProviders: []string{"OtherProvider"}}
 
-	resources := engine.findMatchingResources("Python", prefs)
+	resources := engine.findMatchingResources(context.Background(), "Python", prefs)
 
 	for _, r := range resources {
 		if r.Provider == "OtherProvider" {
@@ -327,7 +328,7 @@ func TestFindMatchingResources_ResourceTypeFilter(t *testing.T) {
 	engine := newTestEngine()
 	prefs := UserPreferences{PreferredResourceTypes: []string{"course"}}
 
-	resources := engine.findMatchingResources("Python", prefs)
+	resources := engine.findMatchingResources(context.Background(), "Python", prefs)
 
 	for _, r := range resources {
 		if r.ResourceType != "course" {
@@ -390,7 +391,7 @@ func TestComputeRelevanceScore_FreePreferenceBoost(t *testing.T) {
 	paidRes := ResourceEntry{
 		ID: "paid", Title: "Paid Python",
 		Provider: "Test", ResourceType: "course", Difficulty: "intermediate",
-		CostType: "paid", CostUSD: 19.99, Skills: []string{"python"}, PrimarySkill: "python",
+		CostType: "paid", CostAmount: 19.99, CostCurrency: "USD", Skills: []string{"python"}, PrimarySkill: "python",
 		Rating: 4.5, RatingCount: 1000, IsVerified: true,
 	}
 
@@ -743,7 +744,7 @@ func TestBuildSkillRecommendation_HasPrimaryResource(t *testing.T) {
 	gap := testGap("Python", "critical", "intermediate", "")
 	prefs := UserPreferences{}
 
-	rec := engine.buildSkillRecommendation(gap, prefs)
+	rec := engine.buildSkillRecommendation(context.Background(), gap, prefs, nil)
 
 	if rec.PrimaryResource == nil {
 		t.Error("expected primary resource for Python (in test catalog)")
@@ -755,7 +756,7 @@ func TestBuildSkillRecommendation_NoPrimaryForUnknownSkill(t *testing.T) {
 	gap := testGap("some_obscure_skill_xyz", "critical", "intermediate", "")
 	prefs := UserPreferences{}
 
-	rec := engine.buildSkillRecommendation(gap, prefs)
+	rec := engine.buildSkillRecommendation(context.Background(), gap, prefs, nil)
 
 	if rec.PrimaryResource != nil {
 		t.Error("expected no primary resource for unknown skill")
@@ -767,7 +768,7 @@ func TestBuildSkillRecommendation_AlternativesAreDifferent(t *testing.T) {
 	gap := testGap("Python", "critical", "intermediate", "")
 	prefs := UserPreferences{}
 
-	rec := engine.buildSkillRecommendation(gap, prefs)
+	rec := engine.buildSkillRecommendation(context.Background(), gap, prefs, nil)
 
 	if rec.PrimaryResource == nil {
 		t.Skip("no primary resource found")
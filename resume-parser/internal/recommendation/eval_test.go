@@ -0,0 +1,260 @@
+package recommendation
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/learnbot/resume-parser/internal/scorer"
+)
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Offline evaluation harness
+//
+// Each file in testdata/eval/scenarios is a profile + job + preferences
+// fixture. The plan generated for it is compared with the committed golden
+// plan in testdata/eval/golden, and aggregate metrics across all scenarios are
+// compared with testdata/eval/golden/summary.txt.
+//
+// After an intentional engine or catalog change, regenerate with:
+//
+//	UPDATE_GOLDEN=1 go test ./internal/recommendation -run TestEvaluation
+//
+// and review the golden diffs (especially summary.txt) in the pull request.
+// ─────────────────────────────────────────────────────────────────────────────
+
+const (
+	evalScenarioDir = "testdata/eval/scenarios"
+	evalGoldenDir   = "testdata/eval/golden"
+	evalSummaryFile = "summary.txt"
+
+	// maxReportedDiffs caps the diff lines printed per scenario.
+	maxReportedDiffs = 40
+)
+
+// evalStart is the fixed plan start time for golden generation.
+var evalStart = time.Date(2025, time.January, 6, 0, 0, 0, 0, time.UTC)
+
+// evalScenario is a golden scenario fixture.
+type evalScenario struct {
+	Name        string                  `json:"-"`
+	Description string                  `json:"description"`
+	Profile     scorer.CandidateProfile `json:"profile"`
+	Job         scorer.JobRequirements  `json:"job"`
+	Preferences UserPreferences         `json:"preferences"`
+}
+
+// evalMetrics aggregates plan characteristics across scenarios.
+type evalMetrics struct {
+	Scenarios     int
+	TotalHours    float64
+	TotalWeeks    int
+	FreeResources int
+	PaidResources int
+	TotalCostUSD  float64
+	PhaseCounts   map[string]int
+}
+
+func updateGolden() bool {
+	return os.Getenv("UPDATE_GOLDEN") != ""
+}
+
+func loadEvalScenarios(t *testing.T) []evalScenario {
+	t.Helper()
+	paths, err := filepath.Glob(filepath.Join(evalScenarioDir, "*.json"))
+	if err != nil {
+		t.Fatalf("glob scenarios: %v", err)
+	}
+	if len(paths) == 0 {
+		t.Fatalf("no scenarios found in %s", evalScenarioDir)
+	}
+
+	var scenarios []evalScenario
+	for _, path := range paths {
+		raw, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatalf("read %s: %v", path, err)
+		}
+		var s evalScenario
+		if err := json.Unmarshal(raw, &s); err != nil {
+			t.Fatalf("decode %s: %v", path, err)
+		}
+		s.Name = strings.TrimSuffix(filepath.Base(path), ".json")
+		scenarios = append(scenarios, s)
+	}
+	return scenarios
+}
+
+// checkGolden compares got with the golden file at path, or rewrites it when
+// UPDATE_GOLDEN is set.
+func checkGolden(t *testing.T, path string, got []byte, diff func(want []byte) []string) {
+	t.Helper()
+	if updateGolden() {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatalf("create golden dir: %v", err)
+		}
+		if err := os.WriteFile(path, got, 0o644); err != nil {
+			t.Fatalf("write %s: %v", path, err)
+		}
+		return
+	}
+
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read golden %s: %v (run with UPDATE_GOLDEN=1 to create it)", path, err)
+	}
+	diffs := diff(want)
+	if len(diffs) == 0 {
+		return
+	}
+	shown := diffs
+	if len(shown) > maxReportedDiffs {
+		shown = shown[:maxReportedDiffs]
+	}
+	msg := strings.Join(shown, "\n  ")
+	if len(diffs) > len(shown) {
+		msg += fmt.Sprintf("\n  ... and %d more", len(diffs)-len(shown))
+	}
+	t.Errorf("%s differs from generated output (%d changes):\n  %s\n"+
+		"If the change is intended, rerun with UPDATE_GOLDEN=1 and commit the result.",
+		path, len(diffs), msg)
+}
+
+func TestEvaluation_GoldenPlans(t *testing.T) {
+	engine := New().Deterministic(evalStart)
+	metrics := evalMetrics{PhaseCounts: map[string]int{}}
+
+	for _, sc := range loadEvalScenarios(t) {
+		plan := engine.Generate(sc.Profile, sc.Job, sc.Preferences)
+		metrics.add(plan)
+
+		t.Run(sc.Name, func(t *testing.T) {
+			got, err := json.MarshalIndent(plan, "", "  ")
+			if err != nil {
+				t.Fatalf("marshal plan: %v", err)
+			}
+			checkGolden(t, filepath.Join(evalGoldenDir, sc.Name+".json"), append(got, '\n'),
+				func(raw []byte) []string {
+					var want LearningPlan
+					if err := json.Unmarshal(raw, &want); err != nil {
+						return []string{fmt.Sprintf("decode golden: %v", err)}
+					}
+					return DiffPlans(want, plan)
+				})
+		})
+	}
+
+	summary := metrics.String()
+	t.Logf("evaluation summary:\n%s", summary)
+	checkGolden(t, filepath.Join(evalGoldenDir, evalSummaryFile), []byte(summary),
+		func(want []byte) []string {
+			return diffLines(string(want), summary)
+		})
+}
+
+func TestEvaluation_DeterministicIsRepeatable(t *testing.T) {
+	engine := New().Deterministic(evalStart)
+	for _, sc := range loadEvalScenarios(t) {
+		a := engine.Generate(sc.Profile, sc.Job, sc.Preferences)
+		b := engine.Generate(sc.Profile, sc.Job, sc.Preferences)
+		if diffs := DiffPlans(a, b); len(diffs) > 0 {
+			t.Errorf("%s: deterministic engine produced different plans:\n  %s",
+				sc.Name, strings.Join(diffs, "\n  "))
+		}
+	}
+}
+
+func TestDiffPlans(t *testing.T) {
+	engine := NewWithCatalog(testCatalog).Deterministic(evalStart)
+	profile := scorer.CandidateProfile{Skills: []scorer.CandidateSkill{{Name: "Go"}}}
+	job := scorer.JobRequirements{Title: "Backend", RequiredSkills: []string{"Go", "Python", "Docker"}}
+
+	base := engine.Generate(profile, job, UserPreferences{})
+	if diffs := DiffPlans(base, base); len(diffs) != 0 {
+		t.Fatalf("identical plans should not differ, got %v", diffs)
+	}
+
+	changed := engine.Generate(profile, job, UserPreferences{WeeklyHoursAvailable: 5})
+	diffs := DiffPlans(base, changed)
+	if len(diffs) == 0 {
+		t.Fatal("expected differences for different weekly hours")
+	}
+	found := false
+	for _, d := range diffs {
+		if strings.HasPrefix(d, "timeline.weekly_hours: 10 → 5") {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("expected a timeline.weekly_hours change, got:\n%s", strings.Join(diffs, "\n"))
+	}
+
+	trimmed := base
+	trimmed.Phases = base.Phases[:len(base.Phases)-1]
+	diffs = DiffPlans(base, trimmed)
+	if len(diffs) == 0 || !strings.Contains(diffs[0], "removed {phase_name:") {
+		t.Errorf("expected removed phase to be summarised by name, got %v", diffs)
+	}
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Metrics
+// ─────────────────────────────────────────────────────────────────────────────
+
+// add accumulates a plan into the metrics.
+func (m *evalMetrics) add(plan LearningPlan) {
+	m.Scenarios++
+	m.TotalHours += plan.TotalEstimatedHours
+	m.TotalWeeks += plan.Timeline.TotalWeeks
+	m.FreeResources += plan.Summary.FreeResourceCount
+	m.PaidResources += plan.Summary.PaidResourceCount
+	m.TotalCostUSD += plan.Summary.EstimatedTotalCost.Amount
+	for _, p := range plan.Phases {
+		m.PhaseCounts[p.PhaseName]++
+	}
+}
+
+// String renders the metrics as a stable, diffable report.
+func (m evalMetrics) String() string {
+	var b strings.Builder
+	n := float64(max(1, m.Scenarios))
+	freeShare := 0.0
+	if total := m.FreeResources + m.PaidResources; total > 0 {
+		freeShare = float64(m.FreeResources) / float64(total)
+	}
+
+	fmt.Fprintf(&b, "scenarios:              %d\n", m.Scenarios)
+	fmt.Fprintf(&b, "avg plan hours:         %.1f\n", m.TotalHours/n)
+	fmt.Fprintf(&b, "avg plan weeks:         %.1f\n", float64(m.TotalWeeks)/n)
+	fmt.Fprintf(&b, "free resource share:    %.1f%%\n", freeShare*100)
+	fmt.Fprintf(&b, "avg paid cost (USD):    %.2f\n", m.TotalCostUSD/n)
+	b.WriteString("plans with phase:\n")
+	for _, name := range []string{"Critical Skills", "Preferred Skills", "Nice-to-Have Skills"} {
+		fmt.Fprintf(&b, "  %-22s%d\n", name+":", m.PhaseCounts[name])
+	}
+	return b.String()
+}
+
+// diffLines returns a line-by-line comparison of two text reports.
+func diffLines(want, got string) []string {
+	wl := strings.Split(strings.TrimRight(want, "\n"), "\n")
+	gl := strings.Split(strings.TrimRight(got, "\n"), "\n")
+	var diffs []string
+	for i := 0; i < max(len(wl), len(gl)); i++ {
+		var w, g string
+		if i < len(wl) {
+			w = wl[i]
+		}
+		if i < len(gl) {
+			g = gl[i]
+		}
+		if w != g {
+			diffs = append(diffs, fmt.Sprintf("- %s\n  + %s", w, g))
+		}
+	}
+	return diffs
+}
@@ -3,31 +3,127 @@
 package recommendation
 
 import (
+	"context"
 	"encoding/json"
+	"fmt"
 	"log"
 	"net/http"
+	"strconv"
+	"strings"
 	"time"
+
+	"github.com/learnbot/resume-parser/internal/resultcache"
+	"github.com/learnbot/resume-parser/internal/scorer"
+	"github.com/learnbot/shared/openapi"
+	"github.com/learnbot/shared/validation"
 )
 
 // Handler holds the HTTP handler dependencies for the recommendation API.
 type Handler struct {
-	engine *Engine
-	logger *log.Logger
+	engine     *Engine
+	converter  CurrencyConverter
+	plans      *planStore
+	logger     *log.Logger
+	cache      *resultcache.Cache[LearningPlan]
+	validation validation.Config
 }
 
 // NewHandler creates a new recommendation Handler.
 func NewHandler(logger *log.Logger) *Handler {
+	engine := New()
 	return &Handler{
-		engine: New(),
-		logger: logger,
+		engine:    engine,
+		converter: engine.converter,
+		plans:     newPlanStore(DefaultPlanStoreCapacity),
+		logger:    logger,
 	}
 }
 
+// SetCatalogSources makes the engine draw resources from sources in
+// addition to the built-in catalog. The built-in catalog is always queried
+// first, so recommendations survive an unavailable remote source.
+func (h *Handler) SetCatalogSources(sources ...CatalogSource) {
+	all := append([]CatalogSource{BuiltinCatalogSource()}, sources...)
+	h.engine = NewWithSources(h.logger, all...)
+	h.engine.SetCurrencyConverter(h.converter)
+}
+
+// SetCurrencyConverter sets the exchange rates used for prices and
+// budgets. Requests in a currency without a rate are rejected.
+func (h *Handler) SetCurrencyConverter(c CurrencyConverter) {
+	h.converter = c
+	h.engine.SetCurrencyConverter(c)
+}
+
+// SetResultCache makes the handlers reuse the plans generated for recent
+// identical requests from c. A nil cache disables caching.
+func (h *Handler) SetResultCache(c *resultcache.Cache[LearningPlan]) {
+	h.cache = c
+}
+
+// SetValidationConfig sets how request bodies are decoded. By default
+// unknown fields are rejected.
+func (h *Handler) SetValidationConfig(cfg validation.Config) {
+	h.validation = cfg
+}
+
 // RegisterRoutes registers the recommendation routes on the given mux.
 //
-//	POST /api/v1/recommendations  – generate a personalized learning plan
-func (h *Handler) RegisterRoutes(mux *http.ServeMux) {
+//	POST /api/v1/recommendations           – generate a personalized learning plan
+//	GET  /api/v1/recommendations/plan.ics  – export a learning plan as iCalendar
+//	GET  /api/v1/interview-prep            – interview practice questions per skill
+func (h *Handler) RegisterRoutes(mux openapi.Router) {
 	mux.HandleFunc("/api/v1/recommendations", h.withMiddleware(h.RecommendationHandler))
+	mux.HandleFunc("/api/v1/recommendations/plan.ics", h.withMiddleware(h.PlanICSHandler))
+	mux.HandleFunc("/api/v1/interview-prep", h.withMiddleware(h.InterviewPrepHandler))
+}
+
+// DescribeRoutes documents the routes registered by RegisterRoutes.
+func (h *Handler) DescribeRoutes(spec *openapi.Spec) {
+	spec.Route("/api/v1/recommendations", openapi.Operation{
+		Method:   http.MethodPost,
+		Summary:  "Generate a learning plan for a candidate and job",
+		Params:   []openapi.Param{resultcache.NoCacheParam},
+		Request:  RecommendationRequest{},
+		Response: RecommendationResponse{},
+		Errors:   []int{http.StatusBadRequest, http.StatusUnsupportedMediaType, http.StatusUnprocessableEntity},
+	})
+	icsParams := []openapi.Param{
+		openapi.Query("plan_id", "ID returned by POST /api/v1/recommendations"),
+		openapi.Query("start", "First day of the plan, YYYY-MM-DD (default: today)"),
+		{Name: "weekly_hours", Description: "Length of the weekly study block (default: the plan's)", Type: 0.0},
+		openapi.Query("tz", "IANA time zone for event times (default: UTC)"),
+	}
+	spec.Route("/api/v1/recommendations/plan.ics",
+		openapi.Operation{
+			Method:       http.MethodGet,
+			Summary:      "Export a saved learning plan as an iCalendar file",
+			Params:       icsParams,
+			Response:     "",
+			ResponseType: "text/calendar",
+			Errors:       []int{http.StatusBadRequest, http.StatusNotFound},
+		},
+		openapi.Operation{
+			Method:       http.MethodPost,
+			Summary:      "Generate a learning plan and export it as an iCalendar file",
+			Params:       append(icsParams, resultcache.NoCacheParam),
+			Request:      RecommendationRequest{},
+			Response:     "",
+			ResponseType: "text/calendar",
+			Errors:       []int{http.StatusBadRequest, http.StatusUnsupportedMediaType, http.StatusUnprocessableEntity},
+		},
+	)
+	spec.Route("/api/v1/interview-prep", openapi.Operation{
+		Method:  http.MethodGet,
+		Summary: "Interview practice question sets for skills",
+		Params: []openapi.Param{
+			{Name: "skills", Description: "Comma-separated skill names", Required: true},
+			openapi.Query("level", "Level being interviewed for: beginner, intermediate (default), advanced or expert"),
+			{Name: "limit", Description: "Maximum question sets per skill (default 3, max 10)", Type: 0},
+		},
+		Response: InterviewPrepResponse{},
+		Errors:   []int{http.StatusBadRequest},
+	})
 }
 
 // withMiddleware wraps a handler with logging and panic recovery.
@@ -56,7 +152,8 @@ func (h *Handler) withMiddleware(next http.HandlerFunc) http.HandlerFunc {
 //	  "job":     { ... JobRequirements  ... },
 //	  "preferences": {
 //	    "prefer_free": false,
-//	    "max_budget_usd": 100,
+//	    "max_budget": 1500000,
+//	    "currency": "IDR",
 //	    "weekly_hours_available": 10,
 //	    "prefer_hands_on": true,
 //	    "prefer_certificates": false,
@@ -71,6 +168,7 @@ func (h *Handler) withMiddleware(next http.HandlerFunc) http.HandlerFunc {
 //	{
 //	  "success": true,
 //	  "data": {
+//	    "plan_id": "...",
 //	    "job_title": "...",
 //	    "readiness_score": 45.0,
 //	    "total_gaps": 3,
@@ -94,27 +192,226 @@ func (h *Handler) RecommendationHandler(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
+	req, ok := h.decodeRequest(w, r)
+	if !ok {
+		return
+	}
+
+	plan := h.generate(w, r, req)
+	plan.PlanID = h.plans.Save(plan)
+
+	h.writeJSON(w, http.StatusOK, RecommendationResponse{
+		Success: true,
+		Data:    &plan,
+	})
+}
+
+// PlanICSHandler handles GET /api/v1/recommendations/plan.ics.
+//
+// The plan is either a previously generated one, selected with the plan_id
+// query parameter, or generated from a RecommendationRequest body as for
+// POST /api/v1/recommendations (POST is accepted too, for clients that
+// cannot send a GET body).
+//
+// Query parameters:
+//
+//	plan_id       – ID returned by POST /api/v1/recommendations
+//	start         – first day of the plan, YYYY-MM-DD (default: today)
+//	weekly_hours  – length of the weekly study block (default: the plan's)
+//	tz            – IANA time zone for event times (default: UTC)
+//
+// Example curl:
+//
+//	curl -o plan.ics "http://localhost:8080/api/v1/recommendations/plan.ics?plan_id=...&tz=Europe/Berlin"
+func (h *Handler) PlanICSHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodPost {
+		h.writeError(w, http.StatusMethodNotAllowed,
+			"only GET and POST are supported")
+		return
+	}
+
+	q := r.URL.Query()
+	opts := ICSOptions{Location: time.UTC}
+	if tz := q.Get("tz"); tz != "" {
+		loc, err := time.LoadLocation(tz)
+		if err != nil {
+			h.writeError(w, http.StatusBadRequest, fmt.Sprintf("unknown time zone %q", tz))
+			return
+		}
+		opts.Location = loc
+	}
+	opts.Start = time.Now().In(opts.Location)
+	if start := q.Get("start"); start != "" {
+		t, err := time.ParseInLocation("2006-01-02", start, opts.Location)
+		if err != nil {
+			h.writeError(w, http.StatusBadRequest, "start must be a YYYY-MM-DD date")
+			return
+		}
+		opts.Start = t
+	}
+	if wh := q.Get("weekly_hours"); wh != "" {
+		v, err := strconv.ParseFloat(wh, 64)
+		if err != nil || v <= 0 || v > 168 {
+			h.writeError(w, http.StatusBadRequest, "weekly_hours must be a number between 0 and 168")
+			return
+		}
+		opts.WeeklyHours = v
+	}
+
+	var plan LearningPlan
+	if id := q.Get("plan_id"); id != "" {
+		var found bool
+		if plan, found = h.plans.Get(id); !found {
+			h.writeError(w, http.StatusNotFound, "plan not found")
+			return
+		}
+	} else {
+		if r.ContentLength == 0 {
+			h.writeError(w, http.StatusBadRequest,
+				"either the plan_id parameter or a request body is required")
+			return
+		}
+		req, ok := h.decodeRequest(w, r)
+		if !ok {
+			return
+		}
+		plan = h.generate(w, r, req)
+	}
+
+	w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+	w.Header().Set("Content-Disposition", `attachment; filename="learning-plan.ics"`)
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write(PlanToICS(plan, opts)); err != nil {
+		h.logger.Printf("failed to write calendar response: %v", err)
+	}
+}
+
+// generate generates the learning plan for a request, or returns the
+// cached plan of an identical one. The X-No-Cache request header bypasses
+// the cache. Each request saves a cached plan under a new ID.
+func (h *Handler) generate(w http.ResponseWriter, r *http.Request, req RecommendationRequest) LearningPlan {
+	ctx := r.Context()
+	if h.cache != nil {
+		// A cached plan is shared with other requests, so a client that
+		// goes away must not cut its catalog lookups short.
+		ctx = context.WithoutCancel(ctx)
+	}
+	key := resultcache.Key(scorer.CanonicalProfile(req.Profile), req.Job, req.Preferences, req.Progress)
+	plan, _ := resultcache.Serve(h.cache, w, r, key, func() (LearningPlan, error) {
+		if req.Progress != nil {
+			return h.engine.GenerateWithProgress(ctx, req.Profile, req.Job, req.Preferences, *req.Progress), nil
+		}
+		return h.engine.GenerateContext(ctx, req.Profile, req.Job, req.Preferences), nil
+	})
+	return plan
+}
+
+// decodeRequest decodes a RecommendationRequest body, writing an error
+// response and returning false if it is invalid.
+func (h *Handler) decodeRequest(w http.ResponseWriter, r *http.Request) (RecommendationRequest, bool) {
+	var req RecommendationRequest
 	if ct := r.Header.Get("Content-Type"); ct != "" &&
 		len(ct) >= 16 && ct[:16] != "application/json" {
 		h.writeError(w, http.StatusUnsupportedMediaType,
 			"Content-Type must be application/json")
-		return
+		return req, false
 	}
 
-	var req RecommendationRequest
-	dec := json.NewDecoder(r.Body)
-	dec.DisallowUnknownFields()
-	if err := dec.Decode(&req); err != nil {
+	if err := h.validation.Decode(r.Body, &req); err != nil {
+		if errs, ok := validation.AsErrors(err); ok {
+			h.writeJSON(w, http.StatusUnprocessableEntity, RecommendationResponse{
+				Success: false,
+				Error:   "request validation failed",
+				Errors:  errs,
+			})
+			return req, false
+		}
 		h.writeError(w, http.StatusBadRequest,
 			"invalid request body: "+err.Error())
+		return req, false
+	}
+	if c := req.Preferences.Currency; c != "" {
+		if _, ok := h.converter.Convert(1, BaseCurrency, c); !ok {
+			h.writeJSON(w, http.StatusUnprocessableEntity, RecommendationResponse{
+				Success: false,
+				Error:   "request validation failed",
+				Errors: validation.Errors{{
+					Field: "preferences.currency", Code: validation.CodeOneOf,
+					Message: fmt.Sprintf("no exchange rate for %q", c),
+				}},
+			})
+			return req, false
+		}
+	}
+	return req, true
+}
+
+// InterviewPrepHandler handles GET /api/v1/interview-prep.
+//
+// Query parameters:
+//
+//	skills – comma-separated skill names (required, at most 20)
+//	level  – level being interviewed for (optional, default "intermediate")
+//	limit  – max question sets per skill (optional, default 3, max 10)
+//
+// Response body (JSON):
+//
+//	{
+//	  "success": true,
+//	  "data": [
+//	    {"skill_name": "go", "target_level": "intermediate", "question_sets": [...]},
+//	    {"skill_name": "kubernetes", "target_level": "intermediate", "question_sets": [...]}
+//	  ]
+//	}
+//
+// Example curl:
+//
+//	curl "http://localhost:8080/api/v1/interview-prep?skills=go,kubernetes&level=advanced"
+func (h *Handler) InterviewPrepHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		h.writeJSON(w, http.StatusMethodNotAllowed, InterviewPrepResponse{Error: "only GET is supported"})
 		return
 	}
+	q := r.URL.Query()
 
-	plan := h.engine.Generate(req.Profile, req.Job, req.Preferences)
+	var skills []string
+	for _, s := range strings.Split(q.Get("skills"), ",") {
+		if s = strings.TrimSpace(s); s != "" {
+			skills = append(skills, s)
+		}
+	}
+	if len(skills) == 0 {
+		h.writeJSON(w, http.StatusBadRequest, InterviewPrepResponse{Error: "query parameter 'skills' is required"})
+		return
+	}
+	if len(skills) > MaxInterviewPrepSkills {
+		h.writeJSON(w, http.StatusBadRequest, InterviewPrepResponse{
+			Error: fmt.Sprintf("at most %d skills are supported", MaxInterviewPrepSkills),
+		})
+		return
+	}
 
-	h.writeJSON(w, http.StatusOK, RecommendationResponse{
+	level := strings.ToLower(strings.TrimSpace(q.Get("level")))
+	if level != "" && (levelRank[level] == 0 || level == "all_levels") {
+		h.writeJSON(w, http.StatusBadRequest, InterviewPrepResponse{
+			Error: "level must be one of beginner, intermediate, advanced or expert",
+		})
+		return
+	}
+
+	limit := 3
+	if l := q.Get("limit"); l != "" {
+		n, err := strconv.Atoi(l)
+		if err != nil || n < 1 || n > 10 {
+			h.writeJSON(w, http.StatusBadRequest, InterviewPrepResponse{Error: "query parameter 'limit' must be between 1 and 10"})
+			return
+		}
+		limit = n
+	}
+
+	h.writeJSON(w, http.StatusOK, InterviewPrepResponse{
 		Success: true,
-		Data:    &plan,
+		Data:    h.engine.InterviewPrep(skills, level, limit),
 	})
 }
 
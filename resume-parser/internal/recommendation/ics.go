@@ -0,0 +1,249 @@
+// Package recommendation – ics.go exports a learning plan as an iCalendar
+// (RFC 5545) file so that users can put their study blocks on a calendar.
+package recommendation
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// icsSessionHour is the local hour at which weekly study blocks start.
+const icsSessionHour = 9
+
+// ICSOptions controls how a learning plan is laid out on the calendar.
+type ICSOptions struct {
+	// Start is the first day of the plan. Only its date is used; blocks
+	// start at 09:00 in Location.
+	Start time.Time
+
+	// WeeklyHours is the length of each weekly study block. Zero uses the
+	// plan's timeline weekly hours, or 10.
+	WeeklyHours float64
+
+	// Location is the time zone for event times. Nil means UTC.
+	Location *time.Location
+
+	// Now is the DTSTAMP written on every event. Zero means time.Now.
+	Now time.Time
+}
+
+// PlanToICS converts a learning plan into an iCalendar file.
+//
+// Each skill recommendation becomes a weekly recurring study block that
+// runs for as many whole weeks as its hours fill, followed by a single
+// shorter block for any fractional remainder. Skills are scheduled one after
+// another, each starting on a week boundary, in phase order. Phases and
+// skills with no estimated hours are left off the calendar.
+func PlanToICS(plan LearningPlan, opts ICSOptions) []byte {
+	loc := opts.Location
+	if loc == nil {
+		loc = time.UTC
+	}
+	weeklyHours := opts.WeeklyHours
+	if weeklyHours <= 0 {
+		weeklyHours = plan.Timeline.WeeklyHours
+	}
+	if weeklyHours <= 0 {
+		weeklyHours = 10
+	}
+	now := opts.Now
+	if now.IsZero() {
+		now = time.Now()
+	}
+	start := opts.Start.In(loc)
+	first := time.Date(start.Year(), start.Month(), start.Day(), icsSessionHour, 0, 0, 0, loc)
+
+	w := &icsWriter{}
+	w.line("BEGIN:VCALENDAR")
+	w.line("VERSION:2.0")
+	w.line("PRODID:-//LearnBot//Learning Plan//EN")
+	w.line("CALSCALE:GREGORIAN")
+	w.line("METHOD:PUBLISH")
+	w.line("X-WR-CALNAME:" + icsEscape(icsCalendarName(plan)))
+	w.line("X-WR-TIMEZONE:" + loc.String())
+
+	week := 0
+	for _, phase := range plan.Phases {
+		if phase.TotalHours <= 0 {
+			continue
+		}
+		for i, rec := range phase.Skills {
+			hours := skillScheduleHours(rec)
+			if hours <= 0 {
+				continue
+			}
+			fullWeeks := int(math.Floor(hours / weeklyHours))
+			remainder := hours - float64(fullWeeks)*weeklyHours
+			// Ignore floating-point dust left over from the division.
+			if remainder < 0.01 {
+				remainder = 0
+			}
+
+			uid := fmt.Sprintf("%s-p%d-s%d", first.Format("20060102"), phase.PhaseNumber, i+1)
+			summary := "Study: " + rec.SkillName
+			description := icsDescription(phase, rec, hours)
+
+			if fullWeeks > 0 {
+				w.event(icsEvent{
+					uid:         uid,
+					stamp:       now,
+					start:       first.AddDate(0, 0, 7*week),
+					hours:       weeklyHours,
+					count:       fullWeeks,
+					summary:     summary,
+					description: description,
+					url:         primaryURL(rec),
+				}, loc)
+			}
+			if remainder > 0 {
+				w.event(icsEvent{
+					uid:         uid + "-final",
+					stamp:       now,
+					start:       first.AddDate(0, 0, 7*(week+fullWeeks)),
+					hours:       remainder,
+					summary:     summary + " (final session)",
+					description: description,
+					url:         primaryURL(rec),
+				}, loc)
+			}
+
+			week += fullWeeks
+			if remainder > 0 {
+				week++
+			}
+		}
+	}
+
+	w.line("END:VCALENDAR")
+	return []byte(w.b.String())
+}
+
+// skillScheduleHours returns the study hours to schedule for a skill, using
+// the same rule as the week-by-week timeline.
+func skillScheduleHours(rec SkillRecommendation) float64 {
+	if rec.PrimaryResource != nil && rec.PrimaryResource.EstimatedCompletionHours > 0 {
+		return rec.PrimaryResource.EstimatedCompletionHours
+	}
+	return float64(rec.EstimatedHoursToJobReady)
+}
+
+// primaryURL returns the URL of the skill's primary resource, if any.
+func primaryURL(rec SkillRecommendation) string {
+	if rec.PrimaryResource == nil {
+		return ""
+	}
+	return rec.PrimaryResource.Resource.URL
+}
+
+// icsCalendarName returns the calendar's display name.
+func icsCalendarName(plan LearningPlan) string {
+	if plan.JobTitle == "" {
+		return "Learning Plan"
+	}
+	return "Learning Plan: " + plan.JobTitle
+}
+
+// icsDescription builds the event description for a skill's study blocks.
+func icsDescription(phase LearningPhase, rec SkillRecommendation, hours float64) string {
+	lines := []string{
+		fmt.Sprintf("Phase %d: %s", phase.PhaseNumber, phase.PhaseName),
+		fmt.Sprintf("Estimated hours: %.1f", hours),
+	}
+	if rec.PrimaryResource != nil {
+		res := rec.PrimaryResource.Resource
+		lines = append(lines, fmt.Sprintf("Resource: %s (%s)", res.Title, res.Provider))
+		if res.URL != "" {
+			lines = append(lines, res.URL)
+		}
+	} else {
+		lines = append(lines, "Resource: self-study")
+	}
+	return strings.Join(lines, "\n")
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// iCalendar encoding
+// ─────────────────────────────────────────────────────────────────────────────
+
+// icsEvent is a VEVENT. A count above one makes it repeat weekly.
+type icsEvent struct {
+	uid         string
+	stamp       time.Time
+	start       time.Time
+	hours       float64
+	count       int
+	summary     string
+	description string
+	url         string
+}
+
+// icsWriter accumulates content lines, folding them as RFC 5545 requires.
+type icsWriter struct {
+	b strings.Builder
+}
+
+// event writes e, with times in loc.
+func (w *icsWriter) event(e icsEvent, loc *time.Location) {
+	w.line("BEGIN:VEVENT")
+	w.line("UID:" + e.uid + "@learnbot")
+	w.line("DTSTAMP:" + e.stamp.UTC().Format("20060102T150405Z"))
+	if loc == time.UTC {
+		w.line("DTSTART:" + e.start.Format("20060102T150405Z"))
+	} else {
+		w.line("DTSTART;TZID=" + loc.String() + ":" + e.start.Format("20060102T150405"))
+	}
+	w.line("DURATION:" + icsDuration(e.hours))
+	if e.count > 1 {
+		w.line(fmt.Sprintf("RRULE:FREQ=WEEKLY;COUNT=%d", e.count))
+	}
+	w.line("SUMMARY:" + icsEscape(e.summary))
+	w.line("DESCRIPTION:" + icsEscape(e.description))
+	if e.url != "" {
+		w.line("URL:" + e.url)
+	}
+	w.line("END:VEVENT")
+}
+
+// line writes one content line, folding it at 75 octets without splitting
+// a UTF-8 sequence.
+func (w *icsWriter) line(s string) {
+	const limit = 75
+	width := 0
+	for _, r := range s {
+		n := utf8.RuneLen(r)
+		if width+n > limit {
+			w.b.WriteString("\r\n ")
+			width = 1
+		}
+		w.b.WriteRune(r)
+		width += n
+	}
+	w.b.WriteString("\r\n")
+}
+
+// icsDuration formats hours as an RFC 5545 duration, rounded to the minute.
+func icsDuration(hours float64) string {
+	minutes := int(math.Round(hours * 60))
+	if minutes < 1 {
+		minutes = 1
+	}
+	h, m := minutes/60, minutes%60
+	switch {
+	case m == 0:
+		return fmt.Sprintf("PT%dH", h)
+	case h == 0:
+		return fmt.Sprintf("PT%dM", m)
+	default:
+		return fmt.Sprintf("PT%dH%dM", h, m)
+	}
+}
+
+var icsEscaper = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`)
+
+// icsEscape escapes a TEXT property value.
+func icsEscape(s string) string {
+	return icsEscaper.Replace(s)
+}
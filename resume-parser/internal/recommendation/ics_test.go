@@ -0,0 +1,239 @@
+package recommendation
+
+import (
+	"encoding/json"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/learnbot/shared/openapi"
+)
+
+// icsTestPlan has a fractional-week skill, a whole-week skill, and an empty
+// phase.
+func icsTestPlan() LearningPlan {
+	return LearningPlan{
+		JobTitle: "Backend Engineer",
+		Phases: []LearningPhase{
+			{
+				PhaseNumber: 1, PhaseName: "Critical Skills", TotalHours: 45,
+				Skills: []SkillRecommendation{
+					{
+						SkillName: "Go", GapCategory: "critical", EstimatedHoursToJobReady: 40,
+						PrimaryResource: &RecommendedResource{
+							Resource: ResourceEntry{
+								Title: "Go, Concurrency; and More", Provider: "TestProvider",
+								URL: "https://example.com/go",
+							},
+							EstimatedCompletionHours: 25,
+						},
+					},
+					{SkillName: "Docker", GapCategory: "critical", EstimatedHoursToJobReady: 20},
+				},
+			},
+			{PhaseNumber: 2, PhaseName: "Important Skills", TotalHours: 0},
+		},
+		Timeline: LearningTimeline{WeeklyHours: 10},
+	}
+}
+
+// unfold reverses RFC 5545 line folding.
+func unfold(ics string) string {
+	return strings.ReplaceAll(ics, "\r\n ", "")
+}
+
+func TestPlanToICS_FractionalWeeks(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	ics := string(PlanToICS(icsTestPlan(), ICSOptions{
+		Start: time.Date(2025, 1, 6, 0, 0, 0, 0, time.UTC),
+		Now:   now,
+	}))
+	if !strings.HasPrefix(ics, "BEGIN:VCALENDAR\r\n") || !strings.HasSuffix(ics, "END:VCALENDAR\r\n") {
+		t.Fatalf("expected a CRLF-delimited calendar, got:\n%s", ics)
+	}
+	for _, line := range strings.Split(ics, "\r\n") {
+		if len(line) > 75 {
+			t.Errorf("line longer than 75 octets: %q", line)
+		}
+	}
+	ics = unfold(ics)
+
+	// Go: 25h at 10h/week is two full weekly blocks and a 5h final block.
+	for _, want := range []string{
+		"DTSTART:20250106T090000Z\r\nDURATION:PT10H\r\nRRULE:FREQ=WEEKLY;COUNT=2\r\nSUMMARY:Study: Go\r\n",
+		"DTSTART:20250120T090000Z\r\nDURATION:PT5H\r\nSUMMARY:Study: Go (final session)\r\n",
+		// Docker starts the week after Go's final block.
+		"DTSTART:20250127T090000Z\r\nDURATION:PT10H\r\nRRULE:FREQ=WEEKLY;COUNT=2\r\nSUMMARY:Study: Docker\r\n",
+		"URL:https://example.com/go\r\n",
+		`Resource: Go\, Concurrency\; and More (TestProvider)\nhttps://example.com/go`,
+		"DTSTAMP:20250101T120000Z\r\n",
+	} {
+		if !strings.Contains(ics, want) {
+			t.Errorf("expected calendar to contain %q, got:\n%s", want, ics)
+		}
+	}
+	if n := strings.Count(ics, "BEGIN:VEVENT"); n != 3 {
+		t.Errorf("expected 3 events (the empty phase is skipped), got %d", n)
+	}
+	if strings.Contains(ics, "Important Skills") {
+		t.Error("expected the zero-hour phase to be left off the calendar")
+	}
+}
+
+func TestPlanToICS_TimeZoneAndWeeklyHours(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skipf("time zone database unavailable: %v", err)
+	}
+	ics := unfold(string(PlanToICS(icsTestPlan(), ICSOptions{
+		Start:       time.Date(2025, 3, 24, 0, 0, 0, 0, berlin),
+		WeeklyHours: 7.5,
+		Location:    berlin,
+	})))
+
+	// 25h at 7.5h/week: three 7.5h blocks and a 2.5h final block.
+	for _, want := range []string{
+		"X-WR-TIMEZONE:Europe/Berlin\r\n",
+		"DTSTART;TZID=Europe/Berlin:20250324T090000\r\nDURATION:PT7H30M\r\nRRULE:FREQ=WEEKLY;COUNT=3\r\n",
+		"DTSTART;TZID=Europe/Berlin:20250414T090000\r\nDURATION:PT2H30M\r\n",
+	} {
+		if !strings.Contains(ics, want) {
+			t.Errorf("expected calendar to contain %q, got:\n%s", want, ics)
+		}
+	}
+}
+
+func TestPlanICSHandler(t *testing.T) {
+	h := NewHandler(log.New(io.Discard, "", 0))
+	mux := http.NewServeMux()
+	h.RegisterRoutes(mux)
+
+	body := `{"profile":{"skills":[{"name":"Python","proficiency":"advanced"}]},` +
+		`"job":{"title":"Backend Engineer","required_skills":["Go","Docker"]},` +
+		`"preferences":{"weekly_hours_available":8}}`
+
+	// Generate a plan, then export it by ID.
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/api/v1/recommendations", strings.NewReader(body)))
+	var resp RecommendationResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil || resp.Data == nil || resp.Data.PlanID == "" {
+		t.Fatalf("expected a plan with an ID, got %d %+v (%v)", w.Code, resp, err)
+	}
+
+	w = httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet,
+		"/api/v1/recommendations/plan.ics?plan_id="+resp.Data.PlanID+"&start=2025-01-06&tz=UTC", nil))
+	if w.Code != http.StatusOK || !strings.HasPrefix(w.Header().Get("Content-Type"), "text/calendar") {
+		t.Fatalf("expected a calendar, got %d %s", w.Code, w.Body.String())
+	}
+	byID := w.Body.String()
+	if !strings.Contains(byID, "SUMMARY:Study: Go") || !strings.Contains(byID, "DTSTART:20250106T090000Z") {
+		t.Errorf("unexpected calendar:\n%s", byID)
+	}
+
+	// The same request body produces the same events.
+	w = httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet,
+		"/api/v1/recommendations/plan.ics?start=2025-01-06", strings.NewReader(body)))
+	stamp := func(ics string) string {
+		var out []string
+		for _, line := range strings.Split(ics, "\r\n") {
+			if !strings.HasPrefix(line, "DTSTAMP:") {
+				out = append(out, line)
+			}
+		}
+		return strings.Join(out, "\r\n")
+	}
+	if w.Code != http.StatusOK || stamp(w.Body.String()) != stamp(byID) {
+		t.Errorf("expected the body and plan ID exports to match, got %d:\n%s", w.Code, w.Body.String())
+	}
+
+	for _, tt := range []struct {
+		name   string
+		target string
+		body   string
+		want   int
+	}{
+		{"unknown plan", "/api/v1/recommendations/plan.ics?plan_id=nope", "", http.StatusNotFound},
+		{"no plan", "/api/v1/recommendations/plan.ics", "", http.StatusBadRequest},
+		{"bad time zone", "/api/v1/recommendations/plan.ics?tz=Mars/Olympus", body, http.StatusBadRequest},
+		{"bad start", "/api/v1/recommendations/plan.ics?start=06/01/2025", body, http.StatusBadRequest},
+		{"bad weekly hours", "/api/v1/recommendations/plan.ics?weekly_hours=0", body, http.StatusBadRequest},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			var r io.Reader
+			if tt.body != "" {
+				r = strings.NewReader(tt.body)
+			}
+			w := httptest.NewRecorder()
+			mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, tt.target, r))
+			if w.Code != tt.want {
+				t.Errorf("expected %d, got %d: %s", tt.want, w.Code, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestRecommendationHandler_Validation(t *testing.T) {
+	mux := http.NewServeMux()
+	NewHandler(log.New(io.Discard, "", 0)).RegisterRoutes(mux)
+
+	tests := []struct {
+		name        string
+		preferences string
+		want        int
+		fields      []string
+	}{
+		{"valid", `{"weekly_hours_available":8,"target_date":"2025-06-01","preferred_resource_types":["course","video"]}`,
+			http.StatusOK, nil},
+		{"negative budget", `{"max_budget":-10}`, http.StatusUnprocessableEntity, []string{"preferences.max_budget"}},
+		{"unknown currency", `{"currency":"XYZ"}`, http.StatusUnprocessableEntity, []string{"preferences.currency"}},
+		{"too many hours", `{"weekly_hours_available":200}`, http.StatusUnprocessableEntity, []string{"preferences.weekly_hours_available"}},
+		{"bad target date", `{"target_date":"June 1st"}`, http.StatusUnprocessableEntity, []string{"preferences.target_date"}},
+		{"unknown resource type", `{"preferred_resource_types":["course","podcast"]}`,
+			http.StatusUnprocessableEntity, []string{"preferences.preferred_resource_types[1]"}},
+	}
+	for _, tt := range tests {
+		body := `{"profile":{},"job":{"required_skills":["Go"]},"preferences":` + tt.preferences + `}`
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/api/v1/recommendations", strings.NewReader(body)))
+		if w.Code != tt.want {
+			t.Errorf("%s: expected %d, got %d: %s", tt.name, tt.want, w.Code, w.Body.String())
+			continue
+		}
+		var resp RecommendationResponse
+		json.NewDecoder(w.Body).Decode(&resp)
+		var fields []string
+		for _, fe := range resp.Errors {
+			fields = append(fields, fe.Field)
+		}
+		if strings.Join(fields, ",") != strings.Join(tt.fields, ",") {
+			t.Errorf("%s: invalid fields %v, want %v", tt.name, fields, tt.fields)
+		}
+	}
+}
+
+func TestPlanStore_EvictsOldest(t *testing.T) {
+	s := newPlanStore(2)
+	first := s.Save(LearningPlan{JobTitle: "a"})
+	s.Save(LearningPlan{JobTitle: "b"})
+	third := s.Save(LearningPlan{JobTitle: "c"})
+
+	if _, ok := s.Get(first); ok {
+		t.Error("expected the oldest plan to be evicted")
+	}
+	if plan, ok := s.Get(third); !ok || plan.JobTitle != "c" || plan.PlanID != third {
+		t.Errorf("Get(%q) = %+v, %v", third, plan, ok)
+	}
+}
+
+func TestDescribeRoutes(t *testing.T) {
+	h := NewHandler(log.New(io.Discard, "", 0))
+	if err := openapi.CheckRoutes(h.RegisterRoutes, h.DescribeRoutes); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,61 @@
+// Package recommendation – plan_store.go keeps recently generated learning
+// plans in memory so that they can be exported by ID.
+package recommendation
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"sync"
+)
+
+// DefaultPlanStoreCapacity is the number of plans the handler remembers.
+const DefaultPlanStoreCapacity = 1000
+
+// planStore is a bounded, concurrency-safe store of generated plans. When
+// full, the oldest plan is evicted.
+type planStore struct {
+	mu       sync.Mutex
+	capacity int
+	plans    map[string]LearningPlan
+	order    []string
+}
+
+func newPlanStore(capacity int) *planStore {
+	return &planStore{
+		capacity: capacity,
+		plans:    make(map[string]LearningPlan),
+	}
+}
+
+// Save assigns the plan a new ID, stores it and returns the ID.
+func (s *planStore) Save(plan LearningPlan) string {
+	id := newPlanID()
+	plan.PlanID = id
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.order) >= s.capacity {
+		delete(s.plans, s.order[0])
+		s.order = s.order[1:]
+	}
+	s.plans[id] = plan
+	s.order = append(s.order, id)
+	return id
+}
+
+// Get returns the plan with the given ID.
+func (s *planStore) Get(id string) (LearningPlan, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	plan, ok := s.plans[id]
+	return plan, ok
+}
+
+// newPlanID returns a random 128-bit hex identifier.
+func newPlanID() string {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		panic("recommendation: crypto/rand failed: " + err.Error())
+	}
+	return hex.EncodeToString(b[:])
+}
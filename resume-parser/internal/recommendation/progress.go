@@ -0,0 +1,157 @@
+// Package recommendation – progress.go adapts a learning plan to the
+// progress the user made since their last plan.
+package recommendation
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/learnbot/resume-parser/internal/gapanalysis"
+)
+
+// progressIndex is a LearningProgress indexed for lookups while a plan is
+// built. A nil *progressIndex is a fresh plan: no resource is excluded and
+// no skill has progress.
+type progressIndex struct {
+	completed  map[string]bool
+	inProgress map[string]bool
+	skills     map[string]float64 // canonical skill name → progress [0, 1]
+}
+
+// newProgressIndex indexes p. Progress values are clamped to [0, 1]; for a
+// skill listed twice, the last value wins.
+func newProgressIndex(p LearningProgress) *progressIndex {
+	idx := &progressIndex{
+		completed:  make(map[string]bool, len(p.CompletedResourceIDs)),
+		inProgress: make(map[string]bool, len(p.InProgressResourceIDs)),
+		skills:     make(map[string]float64, len(p.Skills)),
+	}
+	for _, id := range p.CompletedResourceIDs {
+		idx.completed[id] = true
+	}
+	for _, id := range p.InProgressResourceIDs {
+		idx.inProgress[id] = true
+	}
+	for _, s := range p.Skills {
+		idx.skills[resolveAlias(normalizeSkillName(s.SkillName))] = math.Max(0, math.Min(1, s.Progress))
+	}
+	return idx
+}
+
+// withoutCompleted returns resources without the completed ones.
+func (p *progressIndex) withoutCompleted(resources []ResourceEntry) []ResourceEntry {
+	if p == nil || len(p.completed) == 0 {
+		return resources
+	}
+	out := resources[:0:0]
+	for _, res := range resources {
+		if !p.completed[res.ID] {
+			out = append(out, res)
+		}
+	}
+	return out
+}
+
+// preferInProgress moves the started resources to the front of ranked,
+// keeping the ranking otherwise, so that a started resource stays the
+// primary one.
+func (p *progressIndex) preferInProgress(ranked []RecommendedResource) {
+	if p == nil || len(p.inProgress) == 0 {
+		return
+	}
+	sort.SliceStable(ranked, func(i, j int) bool {
+		return p.inProgress[ranked[i].Resource.ID] && !p.inProgress[ranked[j].Resource.ID]
+	})
+}
+
+// skillProgress returns the user's progress on skill, 0 if unknown.
+func (p *progressIndex) skillProgress(skill string) float64 {
+	if p == nil {
+		return 0
+	}
+	return p.skills[resolveAlias(normalizeSkillName(skill))]
+}
+
+// completedSkillRecommendation is the recommendation for a gap the user has
+// closed: no resources and no hours left.
+func completedSkillRecommendation(gap gapanalysis.SkillGap) SkillRecommendation {
+	return SkillRecommendation{
+		SkillName:      gap.SkillName,
+		GapCategory:    string(gap.Category),
+		PriorityScore:  gap.PriorityScore,
+		CurrentLevel:   gap.CurrentLevel,
+		TargetLevel:    gap.TargetLevel,
+		Progress:       1,
+		HoursCompleted: float64(gap.EstimatedLearningHours),
+		Completed:      true,
+	}
+}
+
+// applySkillProgress reduces the hours of rec by the share learned, which
+// is below 1. At least an hour is left of a skill and of each resource.
+func applySkillProgress(rec *SkillRecommendation, learned float64) {
+	if learned <= 0 {
+		return
+	}
+	full := rec.EstimatedHoursToJobReady
+	left := int(math.Round(float64(full) * (1 - learned)))
+	if full > 0 && left < 1 {
+		left = 1
+	}
+	rec.EstimatedHoursToJobReady = left
+	rec.HoursCompleted = float64(full - left)
+	rec.Progress = roundTo2(learned)
+
+	reduce := func(r *RecommendedResource) {
+		r.EstimatedCompletionHours = math.Max(1.0, roundTo1(r.EstimatedCompletionHours*(1-learned)))
+	}
+	if rec.PrimaryResource != nil {
+		reduce(rec.PrimaryResource)
+	}
+	for i := range rec.AlternativeResources {
+		reduce(&rec.AlternativeResources[i])
+	}
+}
+
+// buildPlanProgress sums up the progress reflected in phases.
+func buildPlanProgress(p *progressIndex, phases []LearningPhase) *PlanProgress {
+	progress := &PlanProgress{
+		ResourcesCompleted: len(p.completed),
+		SkillsCompleted:    []string{},
+	}
+	hours := 0.0
+	for _, phase := range phases {
+		if phase.Completed {
+			progress.PhasesCompleted++
+		}
+		hours += phase.HoursCompleted
+		for _, rec := range phase.Skills {
+			if rec.Completed {
+				progress.SkillsCompleted = append(progress.SkillsCompleted, rec.SkillName)
+			}
+		}
+	}
+	progress.HoursCompleted = roundTo1(hours)
+	progress.Message = buildProgressMessage(progress)
+	return progress
+}
+
+// buildProgressMessage describes progress in one sentence.
+func buildProgressMessage(p *PlanProgress) string {
+	var parts []string
+	if p.ResourcesCompleted > 0 {
+		parts = append(parts, fmt.Sprintf("%d resource%s completed", p.ResourcesCompleted, pluralize(p.ResourcesCompleted)))
+	}
+	if len(p.SkillsCompleted) > 0 {
+		parts = append(parts, joinSkills(p.SkillsCompleted, 3)+" covered")
+	}
+	if p.HoursCompleted > 0 {
+		parts = append(parts, fmt.Sprintf("%.0f hours of learning done", p.HoursCompleted))
+	}
+	if len(parts) == 0 {
+		return "No progress recorded since your last plan."
+	}
+	return "Since your last plan: " + strings.Join(parts, ", ") + "."
+}
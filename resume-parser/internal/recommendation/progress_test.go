@@ -0,0 +1,211 @@
+package recommendation
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/learnbot/resume-parser/internal/scorer"
+)
+
+// progressTestPlans returns a fresh plan and a progress-aware plan for the
+// same profile, job and preferences: Python, Go, Docker and SQL to learn,
+// with the fresh plan's Python and Docker resources completed and Python
+// half learned, Docker and SQL fully learned.
+func progressTestPlans(t *testing.T) (fresh, regenerated LearningPlan) {
+	t.Helper()
+	engine := NewWithCatalog(testCatalog).Deterministic(time.Date(2025, 3, 3, 0, 0, 0, 0, time.UTC))
+	job := scorer.JobRequirements{
+		Title:           "Backend Engineer",
+		RequiredSkills:  []string{"Python", "Go"},
+		PreferredSkills: []string{"Docker", "SQL"},
+	}
+	prefs := UserPreferences{WeeklyHoursAvailable: 10}
+
+	fresh = engine.GenerateContext(context.Background(), scorer.CandidateProfile{}, job, prefs)
+	python := findSkill(t, fresh, "Python")
+	if python.PrimaryResource == nil {
+		t.Fatal("fresh plan has no Python resource")
+	}
+	regenerated = engine.GenerateWithProgress(context.Background(), scorer.CandidateProfile{}, job, prefs, LearningProgress{
+		CompletedResourceIDs: []string{python.PrimaryResource.Resource.ID, "docker-course"},
+		Skills: []SkillProgress{
+			{SkillName: "python", Progress: 0.5},
+			{SkillName: "Docker", Progress: 1},
+			{SkillName: "SQL", Progress: 1},
+		},
+	})
+	return fresh, regenerated
+}
+
+// findSkill returns the recommendation for skill in plan.
+func findSkill(t *testing.T, plan LearningPlan, skill string) SkillRecommendation {
+	t.Helper()
+	for _, phase := range plan.Phases {
+		for _, rec := range phase.Skills {
+			if rec.SkillName == skill {
+				return rec
+			}
+		}
+	}
+	t.Fatalf("no recommendation for %s", skill)
+	return SkillRecommendation{}
+}
+
+func TestGenerateWithProgress_ExcludesCompletedResources(t *testing.T) {
+	fresh, plan := progressTestPlans(t)
+	completed := findSkill(t, fresh, "Python").PrimaryResource.Resource.ID
+
+	python := findSkill(t, plan, "Python")
+	if python.PrimaryResource == nil || python.PrimaryResource.Resource.ID == completed {
+		t.Fatalf("expected another primary resource than the completed %s, got %+v", completed, python.PrimaryResource)
+	}
+	for _, alt := range python.AlternativeResources {
+		if alt.Resource.ID == completed {
+			t.Errorf("completed resource %s recommended as an alternative", completed)
+		}
+	}
+}
+
+func TestGenerateWithProgress_ReducesPartiallyLearnedSkills(t *testing.T) {
+	fresh, plan := progressTestPlans(t)
+
+	before, after := findSkill(t, fresh, "Python"), findSkill(t, plan, "Python")
+	if after.EstimatedHoursToJobReady != before.EstimatedHoursToJobReady/2 {
+		t.Errorf("expected half of %d hours left, got %d", before.EstimatedHoursToJobReady, after.EstimatedHoursToJobReady)
+	}
+	if after.Progress != 0.5 || after.HoursCompleted != float64(before.EstimatedHoursToJobReady-after.EstimatedHoursToJobReady) {
+		t.Errorf("unexpected progress %.2f and hours completed %.1f", after.Progress, after.HoursCompleted)
+	}
+	if after.Completed {
+		t.Error("a half-learned skill is marked completed")
+	}
+
+	// A skill without progress is planned as in the fresh plan.
+	if diffs := DiffPlans(LearningPlan{Phases: []LearningPhase{{Skills: []SkillRecommendation{findSkill(t, fresh, "Go")}}}},
+		LearningPlan{Phases: []LearningPhase{{Skills: []SkillRecommendation{findSkill(t, plan, "Go")}}}}); len(diffs) > 0 {
+		t.Errorf("Go recommendation changed: %v", diffs)
+	}
+}
+
+func TestGenerateWithProgress_KeepsCompletedPhases(t *testing.T) {
+	fresh, plan := progressTestPlans(t)
+
+	if len(plan.Phases) != len(fresh.Phases) {
+		t.Fatalf("expected the fresh plan's %d phases, got %d", len(fresh.Phases), len(plan.Phases))
+	}
+	preferred := plan.Phases[1]
+	if preferred.PhaseName != "Preferred Skills" || !preferred.Completed {
+		t.Fatalf("expected the Preferred Skills phase completed, got %s (completed=%v)", preferred.PhaseName, preferred.Completed)
+	}
+	if preferred.TotalHours != 0 || preferred.EstimatedWeeks != 0 || preferred.HoursCompleted != fresh.Phases[1].TotalHours {
+		t.Errorf("completed phase: %.1f hours and %.1f weeks left, %.1f completed", preferred.TotalHours, preferred.EstimatedWeeks, preferred.HoursCompleted)
+	}
+	for _, rec := range preferred.Skills {
+		if !rec.Completed || rec.PrimaryResource != nil || rec.EstimatedHoursToJobReady != 0 {
+			t.Errorf("completed skill %s: %+v", rec.SkillName, rec)
+		}
+	}
+	if plan.Phases[0].Completed {
+		t.Error("the Critical Skills phase is marked completed")
+	}
+}
+
+func TestGenerateWithProgress_TimelineSchedulesRemainingHours(t *testing.T) {
+	fresh, plan := progressTestPlans(t)
+
+	hoursCompleted := 0.0
+	for _, phase := range plan.Phases {
+		hoursCompleted += phase.HoursCompleted
+	}
+	if plan.TotalEstimatedHours+hoursCompleted != fresh.TotalEstimatedHours {
+		t.Errorf("%.1f hours left plus %.1f completed, want the fresh plan's %.1f",
+			plan.TotalEstimatedHours, hoursCompleted, fresh.TotalEstimatedHours)
+	}
+
+	tl := plan.Timeline
+	if tl.HoursCompleted != hoursCompleted || fresh.Timeline.HoursCompleted != 0 {
+		t.Errorf("timeline hours completed = %.1f, want %.1f (fresh: %.1f)", tl.HoursCompleted, hoursCompleted, fresh.Timeline.HoursCompleted)
+	}
+	if tl.TotalWeeks >= fresh.Timeline.TotalWeeks || tl.TotalHours >= fresh.Timeline.TotalHours {
+		t.Errorf("timeline of %d weeks and %.1f hours, want less than the fresh %d weeks and %.1f hours",
+			tl.TotalWeeks, tl.TotalHours, fresh.Timeline.TotalWeeks, fresh.Timeline.TotalHours)
+	}
+	if tl.TargetCompletionDate >= fresh.Timeline.TargetCompletionDate {
+		t.Errorf("completion date %s, want before the fresh %s", tl.TargetCompletionDate, fresh.Timeline.TargetCompletionDate)
+	}
+	for _, week := range tl.Weeks {
+		if week.PhaseNumber == 2 {
+			t.Errorf("week %d schedules the completed phase: %s", week.WeekNumber, week.SkillFocus)
+		}
+	}
+	if last := tl.Weeks[len(tl.Weeks)-1]; last.CumulativeHours != tl.TotalHours {
+		t.Errorf("last week's cumulative hours %.1f, want %.1f", last.CumulativeHours, tl.TotalHours)
+	}
+}
+
+func TestGenerateWithProgress_Summary(t *testing.T) {
+	fresh, plan := progressTestPlans(t)
+
+	if fresh.Summary.Progress != nil {
+		t.Errorf("fresh plan has progress %+v", fresh.Summary.Progress)
+	}
+	p := plan.Summary.Progress
+	if p == nil {
+		t.Fatal("expected progress in the summary")
+	}
+	if p.ResourcesCompleted != 2 || p.PhasesCompleted != 1 || strings.Join(p.SkillsCompleted, ",") != "Docker,SQL" ||
+		p.HoursCompleted != plan.Timeline.HoursCompleted {
+		t.Errorf("unexpected progress %+v", p)
+	}
+	if !strings.HasPrefix(p.Message, "Since your last plan: 2 resources completed, Docker, SQL covered") {
+		t.Errorf("unexpected message %q", p.Message)
+	}
+	for _, skill := range append(plan.Summary.TopSkillsToLearn, plan.Summary.QuickWins...) {
+		if skill == "Docker" || skill == "SQL" {
+			t.Errorf("completed skill %s still listed to learn", skill)
+		}
+	}
+}
+
+func TestGenerateWithProgress_KeepsStartedResource(t *testing.T) {
+	engine := newTestEngine()
+	job := scorer.JobRequirements{Title: "Data Engineer", RequiredSkills: []string{"Python"}}
+	prefs := UserPreferences{WeeklyHoursAvailable: 10}
+
+	fresh := engine.Generate(scorer.CandidateProfile{}, job, prefs)
+	python := findSkill(t, fresh, "Python")
+	if len(python.AlternativeResources) == 0 {
+		t.Fatal("fresh plan has no Python alternative")
+	}
+	started := python.AlternativeResources[0].Resource.ID
+
+	plan := engine.GenerateWithProgress(context.Background(), scorer.CandidateProfile{}, job, prefs,
+		LearningProgress{InProgressResourceIDs: []string{started}})
+	if got := findSkill(t, plan, "Python").PrimaryResource; got == nil || got.Resource.ID != started {
+		t.Errorf("expected the started %s as primary resource, got %+v", started, got)
+	}
+	if plan.Summary.Progress == nil || plan.Summary.Progress.Message != "No progress recorded since your last plan." {
+		t.Errorf("unexpected progress %+v", plan.Summary.Progress)
+	}
+}
+
+func TestGenerateWithProgress_AllGapsCovered(t *testing.T) {
+	engine := newTestEngine()
+	job := scorer.JobRequirements{Title: "Backend Engineer", RequiredSkills: []string{"Go", "Docker"}}
+
+	plan := engine.GenerateWithProgress(context.Background(), scorer.CandidateProfile{}, job, UserPreferences{},
+		LearningProgress{Skills: []SkillProgress{{SkillName: "Go", Progress: 1}, {SkillName: "docker", Progress: 1.5}}})
+
+	if len(plan.Phases) != 1 || !plan.Phases[0].Completed {
+		t.Fatalf("expected one completed phase, got %+v", plan.Phases)
+	}
+	if plan.TotalEstimatedHours != 0 || plan.Timeline.TotalWeeks != 0 || plan.Timeline.HoursCompleted == 0 {
+		t.Errorf("expected nothing left to schedule, got %.1f hours over %d weeks (%.1f completed)",
+			plan.TotalEstimatedHours, plan.Timeline.TotalWeeks, plan.Timeline.HoursCompleted)
+	}
+	if !strings.Contains(plan.Summary.Headline, "covered every skill gap") {
+		t.Errorf("unexpected headline %q", plan.Summary.Headline)
+	}
+}
@@ -0,0 +1,438 @@
+// Package recommendation – questions.go contains the built-in interview
+// question bank and recommends practice question sets for skill gaps, so
+// that users preparing for a specific job can rehearse its interview as well
+// as learn its skills.
+package recommendation
+
+import (
+	"sort"
+	"strings"
+)
+
+const (
+	// DefaultMaxQuestionSets is the number of question sets included per
+	// skill recommendation.
+	DefaultMaxQuestionSets = 2
+
+	// MaxInterviewPrepSkills caps the skills of one interview prep request.
+	MaxInterviewPrepSkills = 20
+)
+
+// builtinQuestionBank is the built-in interview question bank.
+// Question sets are keyed by their primary skill (normalized lowercase) and
+// difficulty.
+var builtinQuestionBank = []QuestionEntry{
+	// ── Go ──────────────────────────────────────────────────────────────────
+	{
+		ID: "go-fundamentals", Title: "Go Fundamentals", PrimarySkill: "go",
+		Skills: []string{"go"}, Difficulty: "beginner", Format: "conceptual", EstimatedMinutes: 30,
+		Questions: []string{
+			"What is the difference between an array and a slice in Go?",
+			"How do you handle errors in Go, and why does Go not use exceptions?",
+			"What does the zero value of a type mean? Give examples for structs, maps and pointers.",
+			"When would you use a pointer receiver instead of a value receiver?",
+		},
+	},
+	{
+		ID: "go-concurrency", Title: "Go Concurrency Patterns", PrimarySkill: "go",
+		Skills: []string{"go", "concurrency"}, Difficulty: "intermediate", Format: "coding", EstimatedMinutes: 60,
+		Questions: []string{
+			"Write a worker pool that processes jobs from a channel with N goroutines.",
+			"How does a buffered channel differ from an unbuffered one? When would you choose each?",
+			"Use context.Context to cancel a group of goroutines on the first error.",
+			"What is a data race, and how do you find one with the race detector?",
+			"Implement a rate limiter that allows at most N calls per second.",
+		},
+	},
+	{
+		ID: "go-internals", Title: "Go Runtime and Performance", PrimarySkill: "go",
+		Skills: []string{"go", "performance"}, Difficulty: "advanced", Format: "conceptual", EstimatedMinutes: 45,
+		Questions: []string{
+			"How does the Go scheduler map goroutines onto OS threads?",
+			"Explain escape analysis and how it affects heap allocations.",
+			"How would you profile a service with high tail latency using pprof?",
+			"What are the trade-offs of sync.Pool, and when does it hurt performance?",
+		},
+	},
+
+	// ── Python ──────────────────────────────────────────────────────────────
+	{
+		ID: "python-fundamentals", Title: "Python Fundamentals", PrimarySkill: "python",
+		Skills: []string{"python"}, Difficulty: "beginner", Format: "conceptual", EstimatedMinutes: 30,
+		Questions: []string{
+			"What is the difference between a list and a tuple?",
+			"How do default mutable arguments behave, and how do you avoid the pitfall?",
+			"Explain list comprehensions and generator expressions.",
+			"What are *args and **kwargs used for?",
+		},
+	},
+	{
+		ID: "python-intermediate", Title: "Idiomatic Python", PrimarySkill: "python",
+		Skills: []string{"python", "oop"}, Difficulty: "intermediate", Format: "coding", EstimatedMinutes: 60,
+		Questions: []string{
+			"Write a decorator that retries a function up to three times on exception.",
+			"Implement a context manager that times the block it wraps.",
+			"How does the GIL affect multi-threaded CPU-bound code, and what are the alternatives?",
+			"Explain how dataclasses differ from plain classes and named tuples.",
+		},
+	},
+	{
+		ID: "python-advanced", Title: "Python Internals", PrimarySkill: "python",
+		Skills: []string{"python"}, Difficulty: "advanced", Format: "conceptual", EstimatedMinutes: 45,
+		Questions: []string{
+			"How does Python's memory management and garbage collection work?",
+			"Explain descriptors and how properties are built on them.",
+			"When would you use asyncio over threads or processes?",
+			"What are metaclasses, and when are they the right tool?",
+		},
+	},
+
+	// ── JavaScript / TypeScript ─────────────────────────────────────────────
+	{
+		ID: "javascript-core", Title: "JavaScript Core Concepts", PrimarySkill: "javascript",
+		Skills: []string{"javascript"}, Difficulty: "intermediate", Format: "conceptual", EstimatedMinutes: 45,
+		Questions: []string{
+			"Explain closures with an example of where they are useful.",
+			"How does the event loop order microtasks and macrotasks?",
+			"What is the difference between ==, === and Object.is?",
+			"How does prototypal inheritance work?",
+			"Implement debounce and explain how it differs from throttle.",
+		},
+	},
+	{
+		ID: "typescript-types", Title: "TypeScript Type System", PrimarySkill: "typescript",
+		Skills: []string{"typescript", "javascript"}, Difficulty: "intermediate", Format: "coding", EstimatedMinutes: 45,
+		Questions: []string{
+			"What is the difference between an interface and a type alias?",
+			"Write a generic function that safely reads a nested property.",
+			"Explain union narrowing and discriminated unions.",
+			"How do mapped and conditional types work? Implement a DeepReadonly type.",
+		},
+	},
+
+	// ── React ───────────────────────────────────────────────────────────────
+	{
+		ID: "react-hooks", Title: "React Hooks and State", PrimarySkill: "react",
+		Skills: []string{"react", "javascript"}, Difficulty: "intermediate", Format: "coding", EstimatedMinutes: 60,
+		Questions: []string{
+			"When does a component re-render, and how do memo and useMemo help?",
+			"Write a custom hook that fetches data and handles loading and errors.",
+			"What causes stale closures in useEffect, and how do you avoid them?",
+			"When would you reach for context, a reducer or an external state library?",
+		},
+	},
+	{
+		ID: "react-architecture", Title: "React Application Architecture", PrimarySkill: "react",
+		Skills: []string{"react", "typescript"}, Difficulty: "advanced", Format: "system_design", EstimatedMinutes: 60,
+		Questions: []string{
+			"Design the component structure and data flow for a collaborative document editor.",
+			"How would you diagnose and fix a slow list of 10,000 items?",
+			"Compare client-side rendering, server-side rendering and streaming.",
+		},
+	},
+
+	// ── Node.js ─────────────────────────────────────────────────────────────
+	{
+		ID: "nodejs-backend", Title: "Node.js Backend Development", PrimarySkill: "node.js",
+		Skills: []string{"node.js", "javascript"}, Difficulty: "intermediate", Format: "conceptual", EstimatedMinutes: 45,
+		Questions: []string{
+			"Why is blocking the event loop a problem, and how do you detect it?",
+			"How do streams and backpressure work in Node.js?",
+			"How would you handle graceful shutdown of an HTTP server?",
+			"When would you use worker threads or the cluster module?",
+		},
+	},
+
+	// ── Java ────────────────────────────────────────────────────────────────
+	{
+		ID: "java-core", Title: "Core Java", PrimarySkill: "java",
+		Skills: []string{"java", "oop"}, Difficulty: "intermediate", Format: "conceptual", EstimatedMinutes: 45,
+		Questions: []string{
+			"Explain the contract between equals and hashCode.",
+			"How does HashMap handle collisions?",
+			"What does the volatile keyword guarantee?",
+			"Compare checked and unchecked exceptions.",
+		},
+	},
+
+	// ── SQL / databases ─────────────────────────────────────────────────────
+	{
+		ID: "sql-queries", Title: "SQL Query Practice", PrimarySkill: "sql",
+		Skills: []string{"sql", "postgresql"}, Difficulty: "intermediate", Format: "coding", EstimatedMinutes: 45,
+		Questions: []string{
+			"Write a query returning the second highest salary per department.",
+			"Explain the difference between INNER, LEFT and FULL OUTER joins.",
+			"Use a window function to compute a 7-day moving average.",
+			"How do GROUP BY and HAVING differ from WHERE?",
+		},
+	},
+	{
+		ID: "postgresql-performance", Title: "PostgreSQL Performance", PrimarySkill: "postgresql",
+		Skills: []string{"postgresql", "sql"}, Difficulty: "advanced", Format: "debugging", EstimatedMinutes: 45,
+		Questions: []string{
+			"Read an EXPLAIN ANALYZE plan and explain why a sequential scan was chosen.",
+			"When does a composite index help a query, and when does column order matter?",
+			"What are transaction isolation levels, and which anomalies does each prevent?",
+			"How does MVCC lead to table bloat, and what does VACUUM do about it?",
+		},
+	},
+	{
+		ID: "redis-caching", Title: "Redis and Caching", PrimarySkill: "redis",
+		Skills: []string{"redis", "system design"}, Difficulty: "intermediate", Format: "conceptual", EstimatedMinutes: 30,
+		Questions: []string{
+			"Compare cache-aside, write-through and write-behind caching.",
+			"How do you prevent a cache stampede when a hot key expires?",
+			"Which Redis data structures would you use for a leaderboard, and why?",
+		},
+	},
+
+	// ── Docker / Kubernetes ─────────────────────────────────────────────────
+	{
+		ID: "docker-essentials", Title: "Docker Essentials", PrimarySkill: "docker",
+		Skills: []string{"docker"}, Difficulty: "intermediate", Format: "conceptual", EstimatedMinutes: 30,
+		Questions: []string{
+			"What is the difference between an image and a container?",
+			"How do multi-stage builds reduce image size?",
+			"Why does layer order in a Dockerfile matter for build caching?",
+			"How do containers isolate processes from the host?",
+		},
+	},
+	{
+		ID: "kubernetes-operations", Title: "Kubernetes Operations", PrimarySkill: "kubernetes",
+		Skills: []string{"kubernetes", "docker"}, Difficulty: "intermediate", Format: "debugging", EstimatedMinutes: 60,
+		Questions: []string{
+			"A pod is stuck in CrashLoopBackOff. Walk through how you debug it.",
+			"What is the difference between a Deployment, a StatefulSet and a DaemonSet?",
+			"How do liveness and readiness probes differ, and what goes wrong when they are misconfigured?",
+			"How does a Service route traffic to pods?",
+		},
+	},
+	{
+		ID: "kubernetes-architecture", Title: "Kubernetes Architecture", PrimarySkill: "kubernetes",
+		Skills: []string{"kubernetes"}, Difficulty: "advanced", Format: "system_design", EstimatedMinutes: 60,
+		Questions: []string{
+			"Explain how the control plane reconciles the desired state of a Deployment.",
+			"Design a zero-downtime rollout strategy for a stateful service.",
+			"How would you set resource requests and limits, and what happens on memory pressure?",
+		},
+	},
+
+	// ── Cloud / infrastructure ──────────────────────────────────────────────
+	{
+		ID: "aws-architecture", Title: "AWS Architecture", PrimarySkill: "aws",
+		Skills: []string{"aws", "system design"}, Difficulty: "intermediate", Format: "system_design", EstimatedMinutes: 60,
+		Questions: []string{
+			"Design a highly available web application across availability zones.",
+			"When would you choose Lambda over ECS or EC2?",
+			"How do IAM roles differ from IAM users, and why prefer roles for services?",
+			"Compare SQS and SNS, and describe a fan-out pattern using both.",
+		},
+	},
+	{
+		ID: "terraform-practice", Title: "Terraform in Practice", PrimarySkill: "terraform",
+		Skills: []string{"terraform", "aws"}, Difficulty: "intermediate", Format: "conceptual", EstimatedMinutes: 30,
+		Questions: []string{
+			"What problem does remote state with locking solve?",
+			"How do you structure modules for several environments?",
+			"What happens when resources drift from the Terraform state, and how do you handle it?",
+		},
+	},
+	{
+		ID: "linux-troubleshooting", Title: "Linux Troubleshooting", PrimarySkill: "linux",
+		Skills: []string{"linux"}, Difficulty: "intermediate", Format: "debugging", EstimatedMinutes: 45,
+		Questions: []string{
+			"A server is slow. Which commands do you run first, and what do you look for?",
+			"Explain the difference between a process's virtual and resident memory.",
+			"How do you find which process is holding a port or a deleted file open?",
+		},
+	},
+
+	// ── System design / algorithms ──────────────────────────────────────────
+	{
+		ID: "system-design-fundamentals", Title: "System Design Fundamentals", PrimarySkill: "system design",
+		Skills: []string{"system design", "distributed systems"}, Difficulty: "intermediate", Format: "system_design", EstimatedMinutes: 60,
+		Questions: []string{
+			"Design a URL shortener that handles 10,000 writes per second.",
+			"Explain the CAP theorem with an example of a trade-off you would make.",
+			"How would you shard a database, and how do you choose the shard key?",
+			"Design a rate limiter for a public API.",
+		},
+	},
+	{
+		ID: "system-design-advanced", Title: "Large-Scale System Design", PrimarySkill: "system design",
+		Skills: []string{"system design", "distributed systems", "kafka"}, Difficulty: "advanced", Format: "system_design", EstimatedMinutes: 90,
+		Questions: []string{
+			"Design a news feed for 100 million users.",
+			"How would you build exactly-once processing on top of an at-least-once message queue?",
+			"Design a globally distributed key-value store and explain its consistency model.",
+		},
+	},
+	{
+		ID: "algorithms-core", Title: "Core Algorithms and Data Structures", PrimarySkill: "algorithms",
+		Skills: []string{"algorithms", "data structures"}, Difficulty: "intermediate", Format: "coding", EstimatedMinutes: 90,
+		Questions: []string{
+			"Find the longest substring without repeating characters.",
+			"Merge k sorted lists, and state the time complexity of your solution.",
+			"Detect a cycle in a directed graph.",
+			"Implement an LRU cache with O(1) get and put.",
+		},
+	},
+	{
+		ID: "kafka-streaming", Title: "Kafka and Event Streaming", PrimarySkill: "kafka",
+		Skills: []string{"kafka", "distributed systems"}, Difficulty: "advanced", Format: "conceptual", EstimatedMinutes: 45,
+		Questions: []string{
+			"How do partitions and consumer groups determine parallelism and ordering?",
+			"What delivery guarantees does Kafka offer, and how are they configured?",
+			"How do you handle poison messages in a consumer?",
+		},
+	},
+
+	// ── Machine learning ────────────────────────────────────────────────────
+	{
+		ID: "ml-fundamentals", Title: "Machine Learning Fundamentals", PrimarySkill: "machine learning",
+		Skills: []string{"machine learning", "python"}, Difficulty: "intermediate", Format: "conceptual", EstimatedMinutes: 45,
+		Questions: []string{
+			"Explain the bias-variance trade-off.",
+			"How do you detect and prevent overfitting?",
+			"When would you use precision, recall or ROC AUC to evaluate a classifier?",
+			"How do you handle a heavily imbalanced dataset?",
+		},
+	},
+
+	// ── Git ─────────────────────────────────────────────────────────────────
+	{
+		ID: "git-workflows", Title: "Git Workflows", PrimarySkill: "git",
+		Skills: []string{"git"}, Difficulty: "all_levels", Format: "conceptual", EstimatedMinutes: 20,
+		Questions: []string{
+			"What is the difference between merge and rebase, and when do you use each?",
+			"How do you recover a commit after a hard reset?",
+			"How would you find the commit that introduced a bug?",
+		},
+	},
+}
+
+// GetQuestionBank returns the built-in interview question bank.
+func GetQuestionBank() []QuestionEntry {
+	return builtinQuestionBank
+}
+
+// WithQuestionBank returns a copy of the engine that recommends practice
+// questions from bank instead of the built-in question bank.
+func (e *Engine) WithQuestionBank(bank []QuestionEntry) *Engine {
+	c := *e
+	c.questions = bank
+	return &c
+}
+
+// practiceLevel reports whether a skill required at targetLevel gets practice
+// questions: interviews probe skills required at intermediate level or above.
+func practiceLevel(targetLevel string) bool {
+	return levelRank[strings.ToLower(targetLevel)] >= levelRank["intermediate"]
+}
+
+// InterviewPrep recommends up to limit question sets for each skill, in the
+// given order, for interviews at targetLevel (intermediate if empty).
+func (e *Engine) InterviewPrep(skills []string, targetLevel string, limit int) []InterviewPrep {
+	if targetLevel == "" {
+		targetLevel = "intermediate"
+	}
+	prep := make([]InterviewPrep, 0, len(skills))
+	for _, skill := range skills {
+		sets := e.recommendQuestions(skill, targetLevel, "", limit)
+		if sets == nil {
+			sets = []RecommendedQuestionSet{}
+		}
+		prep = append(prep, InterviewPrep{SkillName: skill, TargetLevel: targetLevel, QuestionSets: sets})
+	}
+	return prep
+}
+
+// recommendQuestions returns up to limit question sets covering skillName,
+// best first. Sets easier than the user's current level are left out.
+func (e *Engine) recommendQuestions(skillName, targetLevel, currentLevel string, limit int) []RecommendedQuestionSet {
+	norm := normalizeSkillName(skillName)
+	canonical := resolveAlias(norm)
+	currentRank := levelRank[strings.ToLower(currentLevel)]
+
+	var sets []RecommendedQuestionSet
+	for _, q := range e.questions {
+		if !questionMatchesSkill(q, canonical, norm) {
+			continue
+		}
+		if q.Difficulty != "all_levels" && levelRank[strings.ToLower(q.Difficulty)] < currentRank {
+			continue
+		}
+		sets = append(sets, RecommendedQuestionSet{
+			QuestionSet:    q,
+			RelevanceScore: roundTo4(computeQuestionRelevance(q, canonical, targetLevel)),
+		})
+	}
+	sort.SliceStable(sets, func(i, j int) bool {
+		if sets[i].RelevanceScore != sets[j].RelevanceScore {
+			return sets[i].RelevanceScore > sets[j].RelevanceScore
+		}
+		return sets[i].QuestionSet.ID < sets[j].QuestionSet.ID
+	})
+	if limit > 0 && len(sets) > limit {
+		sets = sets[:limit]
+	}
+	return sets
+}
+
+// questionMatchesSkill returns true if a question set practises the given
+// skill. Unlike resources, question sets only match whole skill names, so
+// that "go" questions are not offered for "django".
+func questionMatchesSkill(q QuestionEntry, canonical, norm string) bool {
+	if p := normalizeSkillName(q.PrimarySkill); p == canonical || p == norm {
+		return true
+	}
+	for _, s := range q.Skills {
+		if sNorm := normalizeSkillName(s); sNorm == canonical || sNorm == norm {
+			return true
+		}
+	}
+	return false
+}
+
+// computeQuestionRelevance computes a composite relevance score [0, 1] for a
+// question set.
+//
+// Factors and weights:
+//   - Skill match quality (primary vs secondary): 0.60
+//   - Difficulty fit (matches the target level): 0.40
+func computeQuestionRelevance(q QuestionEntry, canonical, targetLevel string) float64 {
+	skillScore := 0.5 // secondary skill match
+	if normalizeSkillName(q.PrimarySkill) == canonical {
+		skillScore = 1.0 // primary skill match
+	}
+	return skillScore*0.60 + computeQuestionDifficultyFit(q.Difficulty, targetLevel)*0.40
+}
+
+// computeQuestionDifficultyFit returns a score [0, 1] for how well a question
+// set's difficulty matches the level being interviewed for. Unlike learning
+// resources, which may start below the target and build up, practice
+// questions fit best at the target level itself; one level below makes a
+// warm-up and one above a stretch.
+func computeQuestionDifficultyFit(difficulty, targetLevel string) float64 {
+	if difficulty == "all_levels" {
+		return 0.9
+	}
+	targetRank := levelRank[strings.ToLower(targetLevel)]
+	if targetRank == 0 {
+		targetRank = 2 // default to intermediate
+	}
+	rank := levelRank[strings.ToLower(difficulty)]
+	if rank == 0 {
+		rank = 2
+	}
+
+	switch rank - targetRank {
+	case 0:
+		return 1.0
+	case -1:
+		return 0.7
+	case 1:
+		return 0.6
+	}
+	return 0.3
+}
@@ -0,0 +1,263 @@
+package recommendation
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/learnbot/resume-parser/internal/gapanalysis"
+)
+
+// testQuestionBank is a minimal question bank for testing.
+var testQuestionBank = []QuestionEntry{
+	{
+		ID: "go-basics", Title: "Go Basics", PrimarySkill: "go", Skills: []string{"go"},
+		Difficulty: "beginner", Format: "conceptual", Questions: []string{"What is a slice?"}, EstimatedMinutes: 20,
+	},
+	{
+		ID: "go-concurrency", Title: "Go Concurrency", PrimarySkill: "go", Skills: []string{"go", "concurrency"},
+		Difficulty: "intermediate", Format: "coding", Questions: []string{"Write a worker pool."}, EstimatedMinutes: 45,
+	},
+	{
+		ID: "go-internals", Title: "Go Internals", PrimarySkill: "go", Skills: []string{"go"},
+		Difficulty: "advanced", Format: "conceptual", Questions: []string{"How does the scheduler work?"}, EstimatedMinutes: 45,
+	},
+	{
+		ID: "k8s-ops", Title: "Kubernetes Operations", PrimarySkill: "kubernetes", Skills: []string{"kubernetes", "go"},
+		Difficulty: "intermediate", Format: "debugging", Questions: []string{"Debug a CrashLoopBackOff."}, EstimatedMinutes: 60,
+	},
+	{
+		ID: "git-any", Title: "Git", PrimarySkill: "git", Skills: []string{"git"},
+		Difficulty: "all_levels", Format: "conceptual", Questions: []string{"Merge or rebase?"}, EstimatedMinutes: 15,
+	},
+}
+
+// newQuestionTestEngine creates an Engine with the test catalog and question bank.
+func newQuestionTestEngine() *Engine {
+	return newTestEngine().WithQuestionBank(testQuestionBank)
+}
+
+func questionSetIDs(sets []RecommendedQuestionSet) []string {
+	ids := make([]string, len(sets))
+	for i, s := range sets {
+		ids[i] = s.QuestionSet.ID
+	}
+	return ids
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Question filtering tests
+// ─────────────────────────────────────────────────────────────────────────────
+
+func TestRecommendQuestions_ExactMatch(t *testing.T) {
+	engine := newQuestionTestEngine()
+
+	sets := engine.recommendQuestions("Kubernetes", "intermediate", "", 0)
+
+	if len(sets) != 1 || sets[0].QuestionSet.ID != "k8s-ops" {
+		t.Errorf("expected only k8s-ops for Kubernetes, got %v", questionSetIDs(sets))
+	}
+}
+
+func TestRecommendQuestions_AliasMatch(t *testing.T) {
+	engine := newQuestionTestEngine()
+
+	// "golang" should match "go" question sets.
+	sets := engine.recommendQuestions("golang", "intermediate", "", 0)
+
+	if len(sets) != 4 {
+		t.Errorf("expected 4 question sets for golang (alias for go), got %v", questionSetIDs(sets))
+	}
+}
+
+func TestRecommendQuestions_NoSubstringMatch(t *testing.T) {
+	engine := newQuestionTestEngine()
+
+	// "django" contains "go" but must not match Go question sets.
+	sets := engine.recommendQuestions("django", "intermediate", "", 0)
+
+	if len(sets) != 0 {
+		t.Errorf("expected 0 question sets for django, got %v", questionSetIDs(sets))
+	}
+}
+
+func TestRecommendQuestions_CurrentLevelFilter(t *testing.T) {
+	engine := newQuestionTestEngine()
+
+	sets := engine.recommendQuestions("go", "advanced", "intermediate", 0)
+
+	for _, s := range sets {
+		if s.QuestionSet.Difficulty == "beginner" {
+			t.Errorf("expected no beginner sets for an intermediate user, got %s", s.QuestionSet.ID)
+		}
+	}
+	if len(sets) != 3 {
+		t.Errorf("expected 3 question sets, got %v", questionSetIDs(sets))
+	}
+}
+
+func TestRecommendQuestions_AllLevelsKept(t *testing.T) {
+	engine := newQuestionTestEngine()
+
+	sets := engine.recommendQuestions("git", "expert", "advanced", 0)
+
+	if len(sets) != 1 || sets[0].QuestionSet.ID != "git-any" {
+		t.Errorf("expected the all_levels git set, got %v", questionSetIDs(sets))
+	}
+}
+
+func TestRecommendQuestions_SortedAndLimited(t *testing.T) {
+	engine := newQuestionTestEngine()
+
+	sets := engine.recommendQuestions("go", "advanced", "", 2)
+
+	if len(sets) != 2 {
+		t.Fatalf("expected 2 question sets, got %v", questionSetIDs(sets))
+	}
+	if sets[0].QuestionSet.ID != "go-internals" {
+		t.Errorf("expected go-internals first for an advanced target, got %v", questionSetIDs(sets))
+	}
+	if sets[0].RelevanceScore < sets[1].RelevanceScore {
+		t.Errorf("expected sets sorted by relevance, got %.4f before %.4f", sets[0].RelevanceScore, sets[1].RelevanceScore)
+	}
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Question relevance tests
+// ─────────────────────────────────────────────────────────────────────────────
+
+func TestComputeQuestionRelevance_PrimarySkillHigherThanSecondary(t *testing.T) {
+	primary := computeQuestionRelevance(testQuestionBank[1], "go", "intermediate")
+	secondary := computeQuestionRelevance(testQuestionBank[3], "go", "intermediate")
+
+	if primary <= secondary {
+		t.Errorf("expected primary skill score (%.4f) > secondary (%.4f)", primary, secondary)
+	}
+	if !approxEqual(primary, 1.0, 0.001) {
+		t.Errorf("expected 1.0 for a primary match at the target level, got %.4f", primary)
+	}
+}
+
+func TestComputeQuestionDifficultyFit(t *testing.T) {
+	tests := []struct {
+		difficulty, target string
+		want               float64
+	}{
+		{"intermediate", "intermediate", 1.0},
+		{"all_levels", "advanced", 0.9},
+		{"beginner", "intermediate", 0.7},
+		{"advanced", "intermediate", 0.6},
+		{"expert", "beginner", 0.3},
+		{"beginner", "expert", 0.3},
+		{"intermediate", "", 1.0},
+	}
+	for _, tt := range tests {
+		if got := computeQuestionDifficultyFit(tt.difficulty, tt.target); got != tt.want {
+			t.Errorf("computeQuestionDifficultyFit(%q, %q) = %.2f, want %.2f", tt.difficulty, tt.target, got, tt.want)
+		}
+	}
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Practice questions in recommendations
+// ─────────────────────────────────────────────────────────────────────────────
+
+func TestBuildSkillRecommendations_PracticeQuestions(t *testing.T) {
+	engine := newQuestionTestEngine()
+	gaps := []gapanalysis.SkillGap{
+		testGap("Go", "critical", "intermediate", ""),
+		testGap("Git", "critical", "beginner", ""),
+	}
+
+	recs := engine.buildSkillRecommendations(context.Background(), gaps, UserPreferences{}, nil)
+
+	if len(recs) != 2 {
+		t.Fatalf("expected 2 recommendations, got %d", len(recs))
+	}
+	if got := questionSetIDs(recs[0].PracticeQuestions); len(got) != DefaultMaxQuestionSets || got[0] != "go-concurrency" {
+		t.Errorf("expected %d practice sets for Go led by go-concurrency, got %v", DefaultMaxQuestionSets, got)
+	}
+	if recs[1].PracticeQuestions != nil {
+		t.Errorf("expected no practice questions for a beginner target, got %v", questionSetIDs(recs[1].PracticeQuestions))
+	}
+}
+
+func TestInterviewPrep_DefaultsAndUnknownSkills(t *testing.T) {
+	engine := newQuestionTestEngine()
+
+	prep := engine.InterviewPrep([]string{"go", "cobol"}, "", 1)
+
+	if len(prep) != 2 {
+		t.Fatalf("expected an entry per skill, got %d", len(prep))
+	}
+	if prep[0].TargetLevel != "intermediate" || len(prep[0].QuestionSets) != 1 {
+		t.Errorf("unexpected go entry %+v", prep[0])
+	}
+	if prep[1].QuestionSets == nil || len(prep[1].QuestionSets) != 0 {
+		t.Errorf("expected an empty list for cobol, got %#v", prep[1].QuestionSets)
+	}
+}
+
+func TestBuiltinQuestionBank_Valid(t *testing.T) {
+	seen := make(map[string]bool)
+	for _, q := range GetQuestionBank() {
+		if seen[q.ID] {
+			t.Errorf("duplicate question set ID %q", q.ID)
+		}
+		seen[q.ID] = true
+		if _, ok := levelRank[q.Difficulty]; !ok {
+			t.Errorf("question set %q has unknown difficulty %q", q.ID, q.Difficulty)
+		}
+		if len(q.Questions) == 0 || q.PrimarySkill == "" {
+			t.Errorf("question set %q has no questions or primary skill", q.ID)
+		}
+	}
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Interview prep handler tests
+// ─────────────────────────────────────────────────────────────────────────────
+
+func TestInterviewPrepHandler(t *testing.T) {
+	h := NewHandler(log.New(io.Discard, "", 0))
+	mux := http.NewServeMux()
+	h.RegisterRoutes(mux)
+
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/interview-prep?skills=go,%20kubernetes&level=advanced&limit=2", nil))
+	var resp InterviewPrepResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil || w.Code != http.StatusOK || !resp.Success {
+		t.Fatalf("expected success, got %d %+v (%v)", w.Code, resp, err)
+	}
+	if len(resp.Data) != 2 || resp.Data[1].SkillName != "kubernetes" || resp.Data[0].TargetLevel != "advanced" {
+		t.Fatalf("unexpected data %+v", resp.Data)
+	}
+	for _, p := range resp.Data {
+		if len(p.QuestionSets) == 0 || len(p.QuestionSets) > 2 {
+			t.Errorf("expected 1-2 question sets for %s, got %d", p.SkillName, len(p.QuestionSets))
+		}
+	}
+
+	for target, want := range map[string]int{
+		"/api/v1/interview-prep":                            http.StatusBadRequest,
+		"/api/v1/interview-prep?skills=,":                   http.StatusBadRequest,
+		"/api/v1/interview-prep?skills=go&level=guru":       http.StatusBadRequest,
+		"/api/v1/interview-prep?skills=go&level=all_levels": http.StatusBadRequest,
+		"/api/v1/interview-prep?skills=go&limit=11":         http.StatusBadRequest,
+	} {
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, target, nil))
+		if w.Code != want {
+			t.Errorf("GET %s: expected %d, got %d", target, want, w.Code)
+		}
+	}
+	w = httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/api/v1/interview-prep?skills=go", nil))
+	if w.Code != http.StatusMethodNotAllowed {
+		t.Errorf("POST: expected 405, got %d", w.Code)
+	}
+}
@@ -0,0 +1,114 @@
+// Package recommendation – rates_handler.go serves the exchange rates used
+// for prices and budgets to admins.
+package recommendation
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/learnbot/shared/adminauth"
+	"github.com/learnbot/shared/openapi"
+	"github.com/learnbot/shared/validation"
+)
+
+// RatesHandler serves the rate table of a StaticRates. Every route
+// requires admin credentials.
+type RatesHandler struct {
+	rates      *StaticRates
+	auth       *adminauth.Middleware
+	logger     *log.Logger
+	validation validation.Config
+}
+
+// NewRatesHandler creates a RatesHandler over rates, authenticating callers
+// with auth.
+func NewRatesHandler(rates *StaticRates, auth *adminauth.Middleware, logger *log.Logger) *RatesHandler {
+	return &RatesHandler{rates: rates, auth: auth, logger: logger}
+}
+
+// SetValidationConfig sets how request bodies are decoded. By default
+// unknown fields are rejected.
+func (h *RatesHandler) SetValidationConfig(cfg validation.Config) {
+	h.validation = cfg
+}
+
+// RegisterRoutes registers the rates routes on the given mux.
+//
+//	GET /admin/currency-rates  – the exchange rate table
+//	PUT /admin/currency-rates  – replace the exchange rate table
+func (h *RatesHandler) RegisterRoutes(mux openapi.Router) {
+	mux.Handle("/admin/currency-rates", h.auth.WrapFunc(h.RatesHandler))
+}
+
+// DescribeRoutes documents the routes registered by RegisterRoutes.
+func (h *RatesHandler) DescribeRoutes(spec *openapi.Spec) {
+	admin := []string{openapi.AdminAPIKey, openapi.BearerAuth}
+	spec.Route("/admin/currency-rates",
+		openapi.Operation{
+			Method:   http.MethodGet,
+			Summary:  "Exchange rates used for resource prices and budgets",
+			Security: admin,
+			Response: CurrencyRatesResponse{},
+		},
+		openapi.Operation{
+			Method:  http.MethodPut,
+			Summary: "Replace the exchange rates",
+			Description: "Rates are units of each currency per US dollar. USD is always kept at 1; the " +
+				"table is left unchanged if any rate is invalid. Currencies without a rate are rejected " +
+				"in recommendation requests.",
+			Security: admin,
+			Request:  UpdateCurrencyRatesRequest{},
+			Response: CurrencyRatesResponse{},
+			Errors:   []int{http.StatusBadRequest, http.StatusUnprocessableEntity},
+		},
+	)
+}
+
+// RatesHandler handles GET and PUT /admin/currency-rates.
+func (h *RatesHandler) RatesHandler(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+	case http.MethodPut:
+		var req UpdateCurrencyRatesRequest
+		if err := h.validation.Decode(r.Body, &req); err != nil {
+			if errs, ok := validation.AsErrors(err); ok {
+				h.writeJSON(w, http.StatusUnprocessableEntity, CurrencyRatesResponse{
+					Error:  "request validation failed",
+					Errors: errs,
+				})
+				return
+			}
+			h.writeJSON(w, http.StatusBadRequest, CurrencyRatesResponse{Error: "invalid request body: " + err.Error()})
+			return
+		}
+		if err := h.rates.SetRates(req.Rates); err != nil {
+			h.writeJSON(w, http.StatusUnprocessableEntity, CurrencyRatesResponse{
+				Error:  "request validation failed",
+				Errors: validation.Errors{{Field: "rates", Code: validation.CodeOneOf, Message: err.Error()}},
+			})
+			return
+		}
+		p, _ := adminauth.PrincipalFrom(r.Context())
+		h.logger.Printf("[ADMIN] currency rates replaced by %s: %d currencies", p, len(req.Rates))
+	default:
+		h.writeJSON(w, http.StatusMethodNotAllowed, CurrencyRatesResponse{Error: "only GET and PUT are supported"})
+		return
+	}
+
+	rates, updatedAt := h.rates.Rates()
+	h.writeJSON(w, http.StatusOK, CurrencyRatesResponse{
+		Success: true,
+		Data:    &CurrencyRates{Base: BaseCurrency, Rates: rates, UpdatedAt: updatedAt.Truncate(time.Second)},
+	})
+}
+
+// writeJSON serialises v as JSON and writes it to the response.
+func (h *RatesHandler) writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		h.logger.Printf("failed to encode JSON response: %v", err)
+	}
+}
@@ -16,7 +16,15 @@ import (
 //  3. Respect the user's weekly hours available.
 //  4. Insert checkpoint weeks at phase boundaries.
 //  5. Calculate cumulative hours and target completion date.
+//
+// Completed skills are not scheduled; the hours already spent on the skills
+// are reported apart from the scheduled ones.
 func buildTimeline(phases []LearningPhase, prefs UserPreferences, jobTitle string) LearningTimeline {
+	return buildTimelineAt(phases, prefs, jobTitle, time.Now())
+}
+
+// buildTimelineAt is buildTimeline with an explicit plan start time.
+func buildTimelineAt(phases []LearningPhase, prefs UserPreferences, jobTitle string, start time.Time) LearningTimeline {
 	weeklyHours := prefs.WeeklyHoursAvailable
 	if weeklyHours <= 0 {
 		weeklyHours = 10
@@ -25,9 +33,18 @@ func buildTimeline(phases []LearningPhase, prefs UserPreferences, jobTitle strin
 	var weeks []WeeklySchedule
 	weekNum := 1
 	cumulativeHours := 0.0
+	hoursCompleted := 0.0
 
 	for _, phase := range phases {
+		hoursCompleted += phase.HoursCompleted
+		remaining := 0
 		for _, skillRec := range phase.Skills {
+			// Completed skills have nothing left to schedule.
+			if skillRec.Completed {
+				continue
+			}
+			remaining++
+
 			// Determine the resource to schedule.
 			var resourceTitle string
 			var resourceHours float64
@@ -84,8 +101,8 @@ func buildTimeline(phases []LearningPhase, prefs UserPreferences, jobTitle strin
 			}
 		}
 
-		// Add a phase review week if the phase has multiple skills.
-		if len(phase.Skills) > 1 {
+		// Add a phase review week if the phase has multiple skills left.
+		if remaining > 1 {
 			cumulativeHours += weeklyHours * 0.5 // half week for review
 			weeks = append(weeks, WeeklySchedule{
 				WeekNumber:            weekNum,
@@ -107,14 +124,15 @@ func buildTimeline(phases []LearningPhase, prefs UserPreferences, jobTitle strin
 	if prefs.TargetDate != "" {
 		targetDate = prefs.TargetDate
 	} else if len(weeks) > 0 {
-		// Estimate based on start date = today.
-		completionDate := time.Now().AddDate(0, 0, len(weeks)*7)
+		// Estimate based on the plan start date.
+		completionDate := start.AddDate(0, 0, len(weeks)*7)
 		targetDate = completionDate.Format("2006-01-02")
 	}
 
 	return LearningTimeline{
 		TotalWeeks:           len(weeks),
 		TotalHours:           roundTo1(cumulativeHours),
+		HoursCompleted:       roundTo1(hoursCompleted),
 		WeeklyHours:          weeklyHours,
 		Weeks:                weeks,
 		TargetCompletionDate: targetDate,
@@ -3,7 +3,8 @@
 // produces a personalized, structured learning plan.
 //
 // The MVP uses a rule-based approach:
-//  1. For each skill gap, query the built-in resource catalog
+//  1. For each skill gap, query the resource catalog sources (the built-in
+//     catalog and, when configured, the learning-resources service)
 //  2. Filter resources by user preferences (free/paid, time commitment)
 //  3. Rank resources by relevance, quality, and popularity
 //  4. Generate a phased learning timeline with milestones
@@ -16,7 +17,12 @@
 //   - Alternative resources for flexibility
 package recommendation
 
-import "github.com/learnbot/resume-parser/internal/scorer"
+import (
+	"time"
+
+	"github.com/learnbot/resume-parser/internal/scorer"
+	"github.com/learnbot/shared/validation"
+)
 
 // ─────────────────────────────────────────────────────────────────────────────
 // User preferences
@@ -28,12 +34,21 @@ type UserPreferences struct {
 	// PreferFree indicates the user prefers free resources over paid ones.
 	PreferFree bool `json:"prefer_free"`
 
-	// MaxBudgetUSD is the maximum budget for paid resources (0 = no limit).
-	MaxBudgetUSD float64 `json:"max_budget_usd,omitempty"`
+	// MaxBudget is the maximum price of a resource, in Currency
+	// (0 = no limit).
+	MaxBudget float64 `json:"max_budget,omitempty" validate:"min=0"`
+
+	// MaxBudgetUSD is the maximum price of a resource in USD. Deprecated:
+	// use MaxBudget and Currency; it is only read when MaxBudget is 0.
+	MaxBudgetUSD float64 `json:"max_budget_usd,omitempty" validate:"min=0"`
+
+	// Currency is the ISO 4217 code of the currency the user budgets and
+	// sees prices in (default: "USD").
+	Currency string `json:"currency,omitempty" validate:"omitempty,min=3,max=3"`
 
 	// WeeklyHoursAvailable is the number of hours per week the user can dedicate
 	// to learning (default: 10).
-	WeeklyHoursAvailable float64 `json:"weekly_hours_available"`
+	WeeklyHoursAvailable float64 `json:"weekly_hours_available" validate:"min=0,max=168"`
 
 	// PreferHandsOn indicates the user prefers hands-on/project-based learning.
 	PreferHandsOn bool `json:"prefer_hands_on"`
@@ -44,18 +59,48 @@ type UserPreferences struct {
 
 	// TargetDate is the optional target date for completing the learning plan
 	// (ISO 8601 date string, e.g. "2025-06-01"). Empty = no deadline.
-	TargetDate string `json:"target_date,omitempty"`
+	TargetDate string `json:"target_date,omitempty" validate:"omitempty,date"`
 
 	// PreferredResourceTypes lists preferred resource types in priority order.
 	// Empty = no preference (all types considered).
 	// Valid values: "course", "certification", "documentation", "video",
 	// "book", "practice", "article", "project"
-	PreferredResourceTypes []string `json:"preferred_resource_types,omitempty"`
+	PreferredResourceTypes []string `json:"preferred_resource_types,omitempty" validate:"oneof=course certification documentation video book practice article project"`
 
 	// ExcludedProviders lists provider names to exclude from recommendations.
 	ExcludedProviders []string `json:"excluded_providers,omitempty"`
 }
 
+// ─────────────────────────────────────────────────────────────────────────────
+// Learning progress
+// ─────────────────────────────────────────────────────────────────────────────
+
+// LearningProgress is what the user has learned since their last plan. A
+// plan generated with it covers only the learning that is left.
+type LearningProgress struct {
+	// CompletedResourceIDs lists resources the user has finished. They are
+	// not recommended again.
+	CompletedResourceIDs []string `json:"completed_resource_ids,omitempty"`
+
+	// InProgressResourceIDs lists resources the user has started. One that
+	// is still recommended for a skill is kept as its primary resource.
+	InProgressResourceIDs []string `json:"in_progress_resource_ids,omitempty"`
+
+	// Skills is the user's self-assessed progress on skills of the plan.
+	Skills []SkillProgress `json:"skills,omitempty"`
+}
+
+// SkillProgress is the user's self-assessed progress on one skill.
+type SkillProgress struct {
+	// SkillName is the skill, matched to the plan's skills like catalog
+	// skills (case-insensitive, with aliases).
+	SkillName string `json:"skill_name" validate:"required"`
+
+	// Progress is the share of the way to the skill's target level already
+	// covered, from 0 (not started) to 1 (job-ready).
+	Progress float64 `json:"progress" validate:"min=0,max=1"`
+}
+
 // ─────────────────────────────────────────────────────────────────────────────
 // Resource catalog types
 // ─────────────────────────────────────────────────────────────────────────────
@@ -90,7 +135,24 @@ type ResourceEntry struct {
 	// Values: "free", "freemium", "paid", "subscription", "free_audit"
 	CostType string `json:"cost_type"`
 
-	// CostUSD is the approximate cost in USD (0 for free resources).
+	// CostAmount is the approximate cost in CostCurrency (0 for free
+	// resources, and when CostUnknown is set).
+	CostAmount float64 `json:"cost_amount,omitempty"`
+
+	// CostUnknown is set when the catalog has no price for a resource
+	// that is not free. Such a resource is over any budget.
+	CostUnknown bool `json:"cost_unknown,omitempty"`
+
+	// CostCurrency is the ISO 4217 code of CostAmount's currency
+	// (empty = "USD").
+	CostCurrency string `json:"cost_currency,omitempty"`
+
+	// DisplayCost is the cost in the user's currency, set on recommended
+	// resources. It is nil when no exchange rate is known.
+	DisplayCost *Price `json:"display_cost,omitempty"`
+
+	// CostUSD is the cost in USD, set on recommended resources whose price
+	// converts to USD. Deprecated: use DisplayCost.
 	CostUSD float64 `json:"cost_usd,omitempty"`
 
 	// DurationHours is the estimated completion time in hours.
@@ -121,6 +183,49 @@ type ResourceEntry struct {
 	IsVerified bool `json:"is_verified"`
 }
 
+// Price is an amount of money as shown to the user.
+type Price struct {
+	// Amount is rounded to the currency's minor unit.
+	Amount float64 `json:"amount"`
+
+	// Currency is the ISO 4217 currency code.
+	Currency string `json:"currency"`
+
+	// Display is the formatted price, e.g. "IDR 319,840".
+	Display string `json:"display"`
+}
+
+// QuestionEntry is a set of interview practice questions on one skill in the
+// built-in question bank.
+type QuestionEntry struct {
+	// ID is a unique identifier for the question set.
+	ID string `json:"id"`
+
+	// Title is the question set title.
+	Title string `json:"title"`
+
+	// PrimarySkill is the skill the questions practise (normalized
+	// lowercase).
+	PrimarySkill string `json:"primary_skill"`
+
+	// Skills lists every skill the questions touch (normalized lowercase).
+	Skills []string `json:"skills"`
+
+	// Difficulty is the level the questions are pitched at.
+	// Values: "beginner", "intermediate", "advanced", "expert", "all_levels"
+	Difficulty string `json:"difficulty"`
+
+	// Format is the interview format the questions prepare for.
+	// Values: "conceptual", "coding", "system_design", "debugging"
+	Format string `json:"format"`
+
+	// Questions are the practice questions, easiest first.
+	Questions []string `json:"questions"`
+
+	// EstimatedMinutes is the time to work through the set.
+	EstimatedMinutes int `json:"estimated_minutes"`
+}
+
 // ─────────────────────────────────────────────────────────────────────────────
 // Recommendation output types
 // ─────────────────────────────────────────────────────────────────────────────
@@ -174,6 +279,47 @@ type SkillRecommendation struct {
 
 	// TargetLevel is the required proficiency level.
 	TargetLevel string `json:"target_level"`
+
+	// PracticeQuestions lists up to 2 interview question sets for the
+	// skill, best first. Only skills required at intermediate level or
+	// above have them.
+	PracticeQuestions []RecommendedQuestionSet `json:"practice_questions,omitempty"`
+
+	// Progress is the user's self-assessed progress on the skill [0.0, 1.0]
+	// for plans generated with progress. Resource and job-ready hours are
+	// reduced by it.
+	Progress float64 `json:"progress,omitempty"`
+
+	// HoursCompleted is the part of the skill's learning hours already
+	// covered by Progress.
+	HoursCompleted float64 `json:"hours_completed,omitempty"`
+
+	// Completed indicates the user has reached the target level; the skill
+	// has no resources and is not scheduled.
+	Completed bool `json:"completed,omitempty"`
+}
+
+// RecommendedQuestionSet is a question set recommended for a skill.
+type RecommendedQuestionSet struct {
+	// QuestionSet is the underlying question bank entry.
+	QuestionSet QuestionEntry `json:"question_set"`
+
+	// RelevanceScore is a composite score [0.0, 1.0] of how well the set
+	// matches the skill and the level being prepared for.
+	// Factors: skill match, difficulty fit.
+	RelevanceScore float64 `json:"relevance_score"`
+}
+
+// InterviewPrep lists the question sets recommended for one skill.
+type InterviewPrep struct {
+	// SkillName is the requested skill.
+	SkillName string `json:"skill_name"`
+
+	// TargetLevel is the level being prepared for.
+	TargetLevel string `json:"target_level"`
+
+	// QuestionSets are the recommended question sets, best first.
+	QuestionSets []RecommendedQuestionSet `json:"question_sets"`
 }
 
 // LearningPhase represents a phase in the learning plan.
@@ -199,6 +345,14 @@ type LearningPhase struct {
 
 	// Milestone is the achievement unlocked upon completing this phase.
 	Milestone string `json:"milestone"`
+
+	// HoursCompleted is the learning hours of the phase's skills already
+	// covered. TotalHours is what is left.
+	HoursCompleted float64 `json:"hours_completed,omitempty"`
+
+	// Completed indicates every skill of the phase is completed. The phase
+	// is kept in the plan, with no hours left, so the user still sees it.
+	Completed bool `json:"completed,omitempty"`
 }
 
 // WeeklySchedule represents a suggested weekly study schedule.
@@ -236,9 +390,13 @@ type LearningTimeline struct {
 	// TotalWeeks is the total number of weeks in the plan.
 	TotalWeeks int `json:"total_weeks"`
 
-	// TotalHours is the total estimated study hours.
+	// TotalHours is the total estimated study hours still to schedule.
 	TotalHours float64 `json:"total_hours"`
 
+	// HoursCompleted is the study hours already spent on the plan's
+	// skills, which are not scheduled again.
+	HoursCompleted float64 `json:"hours_completed,omitempty"`
+
 	// WeeklyHours is the planned weekly study hours.
 	WeeklyHours float64 `json:"weekly_hours"`
 
@@ -252,6 +410,11 @@ type LearningTimeline struct {
 
 // LearningPlan is the complete personalized learning plan output.
 type LearningPlan struct {
+	// PlanID identifies a plan generated by the recommendation API, so that
+	// it can be exported later (e.g. as a calendar). Empty for plans built
+	// directly with the Engine.
+	PlanID string `json:"plan_id,omitempty"`
+
 	// JobTitle is the target job title.
 	JobTitle string `json:"job_title"`
 
@@ -295,7 +458,13 @@ type LearningPlanSummary struct {
 	// PaidResourceCount is the number of paid resources recommended.
 	PaidResourceCount int `json:"paid_resource_count"`
 
-	// EstimatedTotalCostUSD is the estimated total cost of paid resources.
+	// EstimatedTotalCost is the estimated total cost of paid resources, in
+	// the user's currency. Resources without a known exchange rate are left
+	// out.
+	EstimatedTotalCost Price `json:"estimated_total_cost"`
+
+	// EstimatedTotalCostUSD is the same total in USD. Deprecated: use
+	// EstimatedTotalCost.
 	EstimatedTotalCostUSD float64 `json:"estimated_total_cost_usd"`
 
 	// TopSkillsToLearn lists the top 3 skills to focus on first.
@@ -303,6 +472,29 @@ type LearningPlanSummary struct {
 
 	// QuickWins lists skills that can be learned quickly (< 20 hours).
 	QuickWins []string `json:"quick_wins"`
+
+	// Progress sums up the progress since the last plan, for plans
+	// generated with progress.
+	Progress *PlanProgress `json:"progress,omitempty"`
+}
+
+// PlanProgress sums up the user's progress since their last plan.
+type PlanProgress struct {
+	// ResourcesCompleted is the number of resources finished.
+	ResourcesCompleted int `json:"resources_completed"`
+
+	// SkillsCompleted lists the plan's skills at their target level.
+	SkillsCompleted []string `json:"skills_completed"`
+
+	// PhasesCompleted is the number of phases with every skill completed.
+	PhasesCompleted int `json:"phases_completed"`
+
+	// HoursCompleted is the learning hours already covered.
+	HoursCompleted float64 `json:"hours_completed"`
+
+	// Message describes the progress, e.g. "Since your last plan: 2
+	// resources completed, Go covered, 12 hours of learning done."
+	Message string `json:"message"`
 }
 
 // ─────────────────────────────────────────────────────────────────────────────
@@ -319,6 +511,10 @@ type RecommendationRequest struct {
 
 	// Preferences are the user's learning preferences.
 	Preferences UserPreferences `json:"preferences"`
+
+	// Progress is the user's progress since their last plan, if the plan
+	// is being regenerated.
+	Progress *LearningProgress `json:"progress,omitempty"`
 }
 
 // RecommendationResponse is the output of the recommendation API endpoint.
@@ -331,4 +527,54 @@ type RecommendationResponse struct {
 
 	// Error contains an error message when Success is false.
 	Error string `json:"error,omitempty"`
+
+	// Errors lists the invalid request fields when the request failed
+	// validation (422).
+	Errors validation.Errors `json:"errors,omitempty"`
+}
+
+// InterviewPrepResponse is the output of the interview prep API endpoint.
+type InterviewPrepResponse struct {
+	// Success indicates whether the request succeeded.
+	Success bool `json:"success"`
+
+	// Data lists the question sets per requested skill, in request order.
+	Data []InterviewPrep `json:"data,omitempty"`
+
+	// Error contains an error message when Success is false.
+	Error string `json:"error,omitempty"`
+}
+
+// CurrencyRates is the exchange rate table of the admin rates endpoint.
+type CurrencyRates struct {
+	// Base is the currency every rate is relative to, always USD.
+	Base string `json:"base"`
+
+	// Rates maps ISO 4217 codes to units per unit of Base.
+	Rates map[string]float64 `json:"rates"`
+
+	// UpdatedAt is when the table was last replaced.
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// UpdateCurrencyRatesRequest is the input of PUT /admin/currency-rates. It
+// replaces the whole table.
+type UpdateCurrencyRatesRequest struct {
+	Rates map[string]float64 `json:"rates" validate:"required"`
+}
+
+// CurrencyRatesResponse is the output of the admin rates endpoint.
+type CurrencyRatesResponse struct {
+	// Success indicates whether the request succeeded.
+	Success bool `json:"success"`
+
+	// Data contains the rate table when Success is true.
+	Data *CurrencyRates `json:"data,omitempty"`
+
+	// Error contains an error message when Success is false.
+	Error string `json:"error,omitempty"`
+
+	// Errors lists the invalid request fields when the request failed
+	// validation (422).
+	Errors validation.Errors `json:"errors,omitempty"`
 }
@@ -0,0 +1,90 @@
+package report
+
+// Glyph widths of the standard Helvetica fonts in 1/1000 em for the printable
+// ASCII range (0x20–0x7E), taken from the Adobe Core 14 font metrics.
+var (
+	helveticaWidths = [95]int{
+		278, 278, 355, 556, 556, 889, 667, 191, 333, 333, 389, 584, 278, 333, 278, 278, // space … /
+		556, 556, 556, 556, 556, 556, 556, 556, 556, 556, // 0 … 9
+		278, 278, 584, 584, 584, 556, 1015, // : … @
+		667, 667, 722, 722, 667, 611, 778, 722, 278, 500, 667, 556, 833, // A … M
+		722, 778, 667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, // N … Z
+		278, 278, 278, 469, 556, 333, // [ … `
+		556, 556, 500, 556, 556, 278, 556, 556, 222, 222, 500, 222, 833, // a … m
+		556, 556, 556, 556, 333, 500, 278, 556, 500, 722, 500, 500, 500, // n … z
+		334, 260, 334, 584, // { … ~
+	}
+
+	helveticaBoldWidths = [95]int{
+		278, 333, 474, 556, 556, 889, 722, 238, 333, 333, 389, 584, 278, 333, 278, 278, // space … /
+		556, 556, 556, 556, 556, 556, 556, 556, 556, 556, // 0 … 9
+		333, 333, 584, 584, 584, 611, 975, // : … @
+		722, 722, 722, 722, 667, 611, 778, 722, 278, 556, 722, 611, 833, // A … M
+		722, 778, 667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, // N … Z
+		333, 278, 333, 584, 556, 333, // [ … `
+		556, 611, 556, 611, 556, 333, 611, 611, 278, 278, 556, 278, 889, // a … m
+		611, 611, 611, 611, 389, 556, 333, 611, 556, 778, 556, 556, 500, // n … z
+		389, 280, 389, 584, // { … ~
+	}
+)
+
+// winAnsiSpecial maps the non-Latin-1 characters of WinAnsiEncoding that
+// commonly appear in generated text.
+var winAnsiSpecial = map[rune]byte{
+	'€': 0x80, '‚': 0x82, '„': 0x84, '…': 0x85, '‘': 0x91, '’': 0x92,
+	'“': 0x93, '”': 0x94, '•': 0x95, '–': 0x96, '—': 0x97, '™': 0x99,
+}
+
+// highWidths gives widths for the WinAnsi bytes above 0x7F that differ
+// noticeably from the default.
+var highWidths = map[byte]int{
+	0x85: 1000, 0x91: 222, 0x92: 222, 0x93: 333, 0x94: 333, 0x95: 350, 0x97: 1000,
+}
+
+// encodeWinAnsi converts s to WinAnsiEncoding bytes. Latin-1 characters map
+// directly; symbols and emoji outside the encoding are dropped and other
+// unsupported characters become '?'.
+func encodeWinAnsi(s string) []byte {
+	out := make([]byte, 0, len(s))
+	for _, r := range s {
+		switch {
+		case r == '\t':
+			out = append(out, ' ')
+		case r >= 0x20 && r <= 0x7E:
+			out = append(out, byte(r))
+		case r >= 0xA0 && r <= 0xFF:
+			out = append(out, byte(r))
+		default:
+			if b, ok := winAnsiSpecial[r]; ok {
+				out = append(out, b)
+			} else if r >= 0x2000 || r < 0x20 {
+				continue
+			} else {
+				out = append(out, '?')
+			}
+		}
+	}
+	return out
+}
+
+// textWidth returns the width of WinAnsi-encoded text in points.
+func textWidth(b []byte, bold bool, size float64) float64 {
+	widths := &helveticaWidths
+	if bold {
+		widths = &helveticaBoldWidths
+	}
+	total := 0
+	for _, c := range b {
+		switch {
+		case c >= 0x20 && c <= 0x7E:
+			total += widths[c-0x20]
+		default:
+			if w, ok := highWidths[c]; ok {
+				total += w
+			} else {
+				total += 556
+			}
+		}
+	}
+	return float64(total) * size / 1000
+}
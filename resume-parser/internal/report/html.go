@@ -0,0 +1,250 @@
+package report
+
+import (
+	"fmt"
+	"html/template"
+	"io"
+	"strings"
+)
+
+// RenderHTML writes doc to w as a standalone HTML page styled for printing.
+// Charts are inline SVG, so the page has no external dependencies.
+func RenderHTML(w io.Writer, doc Document) error {
+	view := htmlDocument{
+		Title:     doc.Title,
+		Subtitle:  doc.Subtitle,
+		Generated: generatedLabel(doc.GeneratedAt),
+	}
+	for _, b := range doc.Blocks {
+		switch b := b.(type) {
+		case Heading:
+			view.Blocks = append(view.Blocks, htmlBlock{Kind: "heading", Text: b.Text})
+		case Paragraph:
+			view.Blocks = append(view.Blocks, htmlBlock{Kind: "paragraph", Text: b.Text})
+		case Metrics:
+			view.Blocks = append(view.Blocks, htmlBlock{Kind: "metrics", Metrics: b.Items})
+		case RadarChart:
+			if !b.drawable() {
+				view.Blocks = append(view.Blocks, htmlTable(b.asTable()))
+				continue
+			}
+			view.Blocks = append(view.Blocks, htmlBlock{Kind: "radar", Radar: buildHTMLRadar(b)})
+		case Table:
+			view.Blocks = append(view.Blocks, htmlTable(b))
+		case List:
+			view.Blocks = append(view.Blocks, htmlBlock{Kind: "list", Items: b.Items, Text: b.Empty})
+		default:
+			return fmt.Errorf("report: unsupported block type %T", b)
+		}
+	}
+	return htmlTemplate.Execute(w, view)
+}
+
+type htmlDocument struct {
+	Title     string
+	Subtitle  string
+	Generated string
+	Blocks    []htmlBlock
+}
+
+type htmlBlock struct {
+	Kind    string
+	Text    string
+	Metrics []Metric
+	Items   []string
+	Columns []htmlColumn
+	Rows    [][][]string // row → cell → lines
+	Radar   *htmlRadar
+}
+
+type htmlColumn struct {
+	Title   string
+	Percent string
+}
+
+type htmlRadar struct {
+	Size   int
+	Rings  []string
+	Axes   [][4]string
+	Labels []htmlRadarLabel
+	Series []htmlRadarSeries
+}
+
+type htmlRadarLabel struct {
+	X, Y   string
+	Anchor string
+	Text   string
+}
+
+type htmlRadarSeries struct {
+	Name   string
+	Color  string
+	Points string
+}
+
+func htmlTable(t Table) htmlBlock {
+	b := htmlBlock{Kind: "table", Text: t.Empty}
+	var total float64
+	for _, c := range t.Columns {
+		total += columnShare(c)
+	}
+	for _, c := range t.Columns {
+		b.Columns = append(b.Columns, htmlColumn{
+			Title:   c.Title,
+			Percent: fmt.Sprintf("%.1f%%", 100*columnShare(c)/total),
+		})
+	}
+	for _, row := range t.Rows {
+		cells := make([][]string, len(t.Columns))
+		for i := range t.Columns {
+			if i < len(row) {
+				cells[i] = strings.Split(row[i], "\n")
+			}
+		}
+		b.Rows = append(b.Rows, cells)
+	}
+	return b
+}
+
+// buildHTMLRadar precomputes SVG geometry for a radar chart.
+func buildHTMLRadar(c RadarChart) *htmlRadar {
+	const size, radius, labelGap = 360.0, 120.0, 16.0
+	cx, cy := size/2, size/2
+	n := len(c.Labels)
+	max := c.chartMax()
+
+	points := func(values func(i int) float64, r float64) string {
+		parts := make([]string, n)
+		for i := range parts {
+			x, y := radarPoint(i, n, values(i), max, cx, cy, r, false)
+			parts[i] = fmt.Sprintf("%.1f,%.1f", x, y)
+		}
+		return strings.Join(parts, " ")
+	}
+
+	r := &htmlRadar{Size: int(size)}
+	for _, ring := range []float64{0.25, 0.5, 0.75, 1} {
+		r.Rings = append(r.Rings, points(func(int) float64 { return ring * max }, radius))
+	}
+	for i, label := range c.Labels {
+		x, y := radarPoint(i, n, max, max, cx, cy, radius, false)
+		r.Axes = append(r.Axes, [4]string{fmt.Sprintf("%.1f", cx), fmt.Sprintf("%.1f", cy),
+			fmt.Sprintf("%.1f", x), fmt.Sprintf("%.1f", y)})
+
+		lx, ly := radarPoint(i, n, max, max, cx, cy, radius+labelGap, false)
+		anchor := "middle"
+		switch {
+		case lx < cx-1:
+			anchor = "end"
+		case lx > cx+1:
+			anchor = "start"
+		}
+		r.Labels = append(r.Labels, htmlRadarLabel{
+			X: fmt.Sprintf("%.1f", lx), Y: fmt.Sprintf("%.1f", ly+4), Anchor: anchor, Text: label,
+		})
+	}
+	for si, s := range c.Series {
+		col := seriesColor(si)
+		r.Series = append(r.Series, htmlRadarSeries{
+			Name:   s.Name,
+			Color:  fmt.Sprintf("rgb(%d,%d,%d)", int(col[0]*255), int(col[1]*255), int(col[2]*255)),
+			Points: points(func(i int) float64 { return valueAt(s.Values, i) }, radius),
+		})
+	}
+	return r
+}
+
+var htmlTemplate = template.Must(template.New("report").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>{{.Title}}</title>
+<style>
+  @page { size: A4; margin: 18mm 16mm; }
+  body { font-family: Helvetica, Arial, sans-serif; color: #212226; font-size: 10pt; max-width: 180mm; margin: 0 auto; }
+  h1 { font-size: 20pt; margin: 0 0 4pt; }
+  h2 { font-size: 14pt; margin: 18pt 0 8pt; page-break-after: avoid; }
+  .subtitle { font-size: 12pt; color: #6b7280; margin: 0; }
+  .generated { font-size: 9pt; color: #6b7280; margin: 2pt 0 0; }
+  header { border-bottom: 1px solid #d1d5db; padding-bottom: 10pt; margin-bottom: 12pt; }
+  .metrics { display: flex; gap: 10pt; flex-wrap: wrap; }
+  .metric { flex: 1 1 0; min-width: 30mm; background: #f0f2f5; padding: 8pt; }
+  .metric .label { font-size: 8.5pt; color: #6b7280; }
+  .metric .value { font-size: 18pt; font-weight: bold; margin-top: 4pt; }
+  .chart { text-align: center; page-break-inside: avoid; }
+  .chart svg text { font-size: 11px; fill: #6b7280; }
+  .legend span { display: inline-block; width: 9px; height: 9px; margin: 0 4px 0 12px; }
+  table { width: 100%; border-collapse: collapse; table-layout: fixed; }
+  thead { display: table-header-group; }
+  th { background: #f0f2f5; text-align: left; font-size: 9pt; padding: 4pt; }
+  td { padding: 4pt; border-bottom: 0.5pt solid #d1d5db; vertical-align: top; word-wrap: break-word; }
+  tr { page-break-inside: avoid; }
+  .muted { color: #6b7280; }
+</style>
+</head>
+<body>
+<header>
+  <h1>{{.Title}}</h1>
+  {{- if .Subtitle}}<p class="subtitle">{{.Subtitle}}</p>{{end}}
+  {{- if .Generated}}<p class="generated">{{.Generated}}</p>{{end}}
+</header>
+{{- range .Blocks}}
+{{- if eq .Kind "heading"}}
+<h2>{{.Text}}</h2>
+{{- else if eq .Kind "paragraph"}}
+<p>{{.Text}}</p>
+{{- else if eq .Kind "metrics"}}
+<div class="metrics">
+  {{- range .Metrics}}
+  <div class="metric"><div class="label">{{.Label}}</div><div class="value">{{.Value}}</div></div>
+  {{- end}}
+</div>
+{{- else if eq .Kind "radar"}}
+<div class="chart">
+  <svg xmlns="http://www.w3.org/2000/svg" width="{{.Radar.Size}}" height="{{.Radar.Size}}" viewBox="0 0 {{.Radar.Size}} {{.Radar.Size}}" role="img">
+    {{- range .Radar.Rings}}
+    <polygon points="{{.}}" fill="none" stroke="#d1d5db" stroke-width="1"/>
+    {{- end}}
+    {{- range .Radar.Axes}}
+    <line x1="{{index . 0}}" y1="{{index . 1}}" x2="{{index . 2}}" y2="{{index . 3}}" stroke="#d1d5db" stroke-width="1"/>
+    {{- end}}
+    {{- range .Radar.Series}}
+    <polygon points="{{.Points}}" fill="{{.Color}}" fill-opacity="0.15" stroke="{{.Color}}" stroke-width="2"><title>{{.Name}}</title></polygon>
+    {{- end}}
+    {{- range .Radar.Labels}}
+    <text x="{{.X}}" y="{{.Y}}" text-anchor="{{.Anchor}}">{{.Text}}</text>
+    {{- end}}
+  </svg>
+  <div class="legend">
+    {{- range .Radar.Series}}<span style="background: {{.Color}}"></span>{{.Name}}{{end}}
+  </div>
+</div>
+{{- else if eq .Kind "table"}}
+{{- if .Rows}}
+<table>
+  <colgroup>{{range .Columns}}<col style="width: {{.Percent}}">{{end}}</colgroup>
+  <thead><tr>{{range .Columns}}<th>{{.Title}}</th>{{end}}</tr></thead>
+  <tbody>
+    {{- range .Rows}}
+    <tr>{{range .}}<td>{{range $i, $line := .}}{{if $i}}<br>{{end}}{{$line}}{{end}}</td>{{end}}</tr>
+    {{- end}}
+  </tbody>
+</table>
+{{- else if .Text}}
+<p class="muted">{{.Text}}</p>
+{{- end}}
+{{- else if eq .Kind "list"}}
+{{- if .Items}}
+<ul>
+  {{- range .Items}}
+  <li>{{.}}</li>
+  {{- end}}
+</ul>
+{{- else if .Text}}
+<p class="muted">{{.Text}}</p>
+{{- end}}
+{{- end}}
+{{- end}}
+</body>
+</html>
+`))
@@ -0,0 +1,543 @@
+package report
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+)
+
+// A4 page geometry in points.
+const (
+	pageWidth    = 595.28
+	pageHeight   = 841.89
+	marginX      = 50.0
+	marginTop    = 56.0
+	marginBottom = 64.0 // leaves room for the footer
+	contentWidth = pageWidth - 2*marginX
+
+	bodySize    = 10.0
+	lineHeight  = 14.0
+	cellPadding = 4.0
+)
+
+var (
+	colorText   = [3]float64{0.13, 0.13, 0.15}
+	colorMuted  = [3]float64{0.42, 0.45, 0.50}
+	colorRule   = [3]float64{0.82, 0.84, 0.87}
+	colorHeader = [3]float64{0.94, 0.95, 0.97}
+)
+
+// RenderPDF writes doc to w as a PDF document.
+func RenderPDF(w io.Writer, doc Document) error {
+	l := &pdfLayout{}
+	l.newPage()
+	l.title(doc)
+	for _, b := range doc.Blocks {
+		switch b := b.(type) {
+		case Heading:
+			l.heading(b.Text)
+		case Paragraph:
+			l.paragraph(b.Text, colorText)
+		case Metrics:
+			l.metrics(b)
+		case RadarChart:
+			l.radar(b)
+		case Table:
+			l.table(b)
+		case List:
+			l.list(b)
+		default:
+			return fmt.Errorf("report: unsupported block type %T", b)
+		}
+	}
+	l.footers(doc.Title)
+	return writePDF(w, doc, l.pages)
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Layout
+// ─────────────────────────────────────────────────────────────────────────────
+
+// pdfLayout places blocks top to bottom, starting a new page whenever the
+// next piece does not fit. Each page is a raw content stream.
+type pdfLayout struct {
+	pages []*bytes.Buffer
+	cur   *bytes.Buffer
+	y     float64 // current top of free space
+}
+
+func (l *pdfLayout) newPage() {
+	l.cur = &bytes.Buffer{}
+	l.pages = append(l.pages, l.cur)
+	l.y = pageHeight - marginTop
+}
+
+// ensure starts a new page unless h points of vertical space remain.
+func (l *pdfLayout) ensure(h float64) {
+	if l.y-h < marginBottom {
+		l.newPage()
+	}
+}
+
+// atTop reports whether nothing has been placed on the current page yet.
+func (l *pdfLayout) atTop() bool {
+	return l.y == pageHeight-marginTop
+}
+
+func (l *pdfLayout) title(doc Document) {
+	if doc.Title != "" {
+		l.text(marginX, l.y-20, true, 20, colorText, doc.Title)
+		l.y -= 30
+	}
+	if doc.Subtitle != "" {
+		l.text(marginX, l.y-12, false, 12, colorMuted, doc.Subtitle)
+		l.y -= 18
+	}
+	if label := generatedLabel(doc.GeneratedAt); label != "" {
+		l.text(marginX, l.y-9, false, 9, colorMuted, label)
+		l.y -= 15
+	}
+	l.stroke(colorRule, 1)
+	l.line(marginX, l.y, pageWidth-marginX, l.y)
+	l.y -= 18
+}
+
+func (l *pdfLayout) heading(text string) {
+	// Keep the heading with at least a few lines of what follows.
+	l.ensure(26 + 3*lineHeight)
+	if !l.atTop() {
+		l.y -= 10
+	}
+	l.text(marginX, l.y-14, true, 14, colorText, text)
+	l.y -= 24
+}
+
+func (l *pdfLayout) paragraph(text string, color [3]float64) {
+	for _, line := range wrapText(text, false, bodySize, contentWidth) {
+		l.ensure(lineHeight)
+		l.text(marginX, l.y-bodySize, false, bodySize, color, line)
+		l.y -= lineHeight
+	}
+	l.y -= 6
+}
+
+func (l *pdfLayout) metrics(m Metrics) {
+	const boxHeight, gap, perRow = 54.0, 10.0, 4
+	for start := 0; start < len(m.Items); start += perRow {
+		row := m.Items[start:min(start+perRow, len(m.Items))]
+		l.ensure(boxHeight + 12)
+		boxWidth := (contentWidth - gap*float64(len(row)-1)) / float64(len(row))
+		for i, item := range row {
+			x := marginX + float64(i)*(boxWidth+gap)
+			l.fill(colorHeader)
+			l.rect(x, l.y-boxHeight, boxWidth, boxHeight, true)
+			l.text(x+8, l.y-16, false, 8.5, colorMuted, truncateToWidth(item.Label, false, 8.5, boxWidth-16))
+			l.text(x+8, l.y-42, true, 18, colorText, truncateToWidth(item.Value, true, 18, boxWidth-16))
+		}
+		l.y -= boxHeight + 12
+	}
+}
+
+func (l *pdfLayout) radar(c RadarChart) {
+	if !c.drawable() {
+		l.table(c.asTable())
+		return
+	}
+	const radius, labelGap, legendHeight = 95.0, 14.0, 22.0
+	height := 2*radius + 2*labelGap + 24 + legendHeight
+	l.ensure(height)
+
+	n := len(c.Labels)
+	max := c.chartMax()
+	cx, cy := pageWidth/2, l.y-labelGap-12-radius
+
+	// Grid rings and axes.
+	l.stroke(colorRule, 0.75)
+	for _, ring := range []float64{0.25, 0.5, 0.75, 1} {
+		pts := make([][2]float64, n)
+		for i := range pts {
+			pts[i][0], pts[i][1] = radarPoint(i, n, ring*max, max, cx, cy, radius, true)
+		}
+		l.polygon(pts)
+	}
+	for i := 0; i < n; i++ {
+		x, y := radarPoint(i, n, max, max, cx, cy, radius, true)
+		l.line(cx, cy, x, y)
+	}
+
+	// Axis labels.
+	for i, label := range c.Labels {
+		x, y := radarPoint(i, n, max, max, cx, cy, radius+labelGap, true)
+		enc := encodeWinAnsi(label)
+		w := textWidth(enc, false, 9)
+		switch {
+		case x < cx-1:
+			x -= w
+		case math.Abs(x-cx) <= 1:
+			x -= w / 2
+		}
+		l.textBytes(x, y-3, false, 9, colorMuted, enc)
+	}
+
+	// Series.
+	for si, s := range c.Series {
+		color := seriesColor(si)
+		l.stroke(color, 1.75)
+		pts := make([][2]float64, n)
+		for i := range pts {
+			pts[i][0], pts[i][1] = radarPoint(i, n, valueAt(s.Values, i), max, cx, cy, radius, true)
+		}
+		l.polygon(pts)
+		l.fill(color)
+		for _, p := range pts {
+			l.rect(p[0]-2, p[1]-2, 4, 4, true)
+		}
+	}
+
+	// Legend.
+	x := marginX
+	legendY := cy - radius - labelGap - 18
+	for si, s := range c.Series {
+		l.fill(seriesColor(si))
+		l.rect(x, legendY, 8, 8, true)
+		l.text(x+12, legendY, false, 9, colorText, s.Name)
+		x += 24 + textWidth(encodeWinAnsi(s.Name), false, 9)
+	}
+	l.y -= height
+}
+
+func (l *pdfLayout) table(t Table) {
+	if len(t.Rows) == 0 {
+		if t.Empty != "" {
+			l.paragraph(t.Empty, colorMuted)
+		}
+		return
+	}
+
+	widths := columnWidths(t.Columns)
+	headerLines := make([][]string, len(t.Columns))
+	headerCount := 1
+	for i, col := range t.Columns {
+		headerLines[i] = wrapText(col.Title, true, 9, widths[i]-2*cellPadding)
+		headerCount = max(headerCount, len(headerLines[i]))
+	}
+	headerHeight := float64(headerCount)*12 + 2*cellPadding
+
+	drawHeader := func() {
+		l.fill(colorHeader)
+		l.rect(marginX, l.y-headerHeight, contentWidth, headerHeight, true)
+		x := marginX
+		for i, lines := range headerLines {
+			for j, line := range lines {
+				l.text(x+cellPadding, l.y-cellPadding-9-float64(j)*12, true, 9, colorText, line)
+			}
+			x += widths[i]
+		}
+		l.y -= headerHeight
+	}
+
+	l.ensure(headerHeight + lineHeight + 2*cellPadding)
+	drawHeader()
+
+	for _, row := range t.Rows {
+		cells := make([][]string, len(t.Columns))
+		rowLines := 1
+		for i := range t.Columns {
+			cell := ""
+			if i < len(row) {
+				cell = row[i]
+			}
+			cells[i] = wrapText(cell, false, bodySize, widths[i]-2*cellPadding)
+			rowLines = max(rowLines, len(cells[i]))
+		}
+
+		// Draw the row, continuing on the next page if it does not fit.
+		for done := 0; done < rowLines; {
+			fit := int((l.y - marginBottom - 2*cellPadding) / lineHeight)
+			if fit < 1 || (fit < rowLines-done && fit < 2 && !l.atTop()) {
+				l.newPage()
+				drawHeader()
+				continue
+			}
+			chunk := min(fit, rowLines-done)
+			x := marginX
+			for i, lines := range cells {
+				for j := done; j < min(done+chunk, len(lines)); j++ {
+					l.text(x+cellPadding, l.y-cellPadding-bodySize-float64(j-done)*lineHeight,
+						false, bodySize, colorText, lines[j])
+				}
+				x += widths[i]
+			}
+			l.y -= float64(chunk)*lineHeight + 2*cellPadding
+			l.stroke(colorRule, 0.5)
+			l.line(marginX, l.y, pageWidth-marginX, l.y)
+			done += chunk
+		}
+	}
+	l.y -= 10
+}
+
+func (l *pdfLayout) list(list List) {
+	if len(list.Items) == 0 {
+		if list.Empty != "" {
+			l.paragraph(list.Empty, colorMuted)
+		}
+		return
+	}
+	const indent = 14.0
+	for _, item := range list.Items {
+		for i, line := range wrapText(item, false, bodySize, contentWidth-indent) {
+			l.ensure(lineHeight)
+			if i == 0 {
+				l.textBytes(marginX+3, l.y-bodySize, false, bodySize, colorMuted, []byte{0x95})
+			}
+			l.text(marginX+indent, l.y-bodySize, false, bodySize, colorText, line)
+			l.y -= lineHeight
+		}
+	}
+	l.y -= 6
+}
+
+// footers adds "title – Page n of N" to every page.
+func (l *pdfLayout) footers(title string) {
+	total := len(l.pages)
+	for i, page := range l.pages {
+		l.cur = page
+		l.stroke(colorRule, 0.5)
+		l.line(marginX, 44, pageWidth-marginX, 44)
+		l.text(marginX, 30, false, 8, colorMuted, truncateToWidth(title, false, 8, contentWidth-80))
+		label := encodeWinAnsi(fmt.Sprintf("Page %d of %d", i+1, total))
+		l.textBytes(pageWidth-marginX-textWidth(label, false, 8), 30, false, 8, colorMuted, label)
+	}
+}
+
+// columnWidths converts relative column widths to points.
+func columnWidths(cols []Column) []float64 {
+	total := 0.0
+	for _, c := range cols {
+		total += columnShare(c)
+	}
+	out := make([]float64, len(cols))
+	for i, c := range cols {
+		out[i] = contentWidth * columnShare(c) / total
+	}
+	return out
+}
+
+func columnShare(c Column) float64 {
+	if c.Width > 0 {
+		return c.Width
+	}
+	return 1
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Text wrapping
+// ─────────────────────────────────────────────────────────────────────────────
+
+// wrapText splits s into lines no wider than width. Newlines in s start new
+// lines, and words longer than a line are broken. The result always has at
+// least one line.
+func wrapText(s string, bold bool, size, width float64) []string {
+	var lines []string
+	for _, para := range strings.Split(s, "\n") {
+		line := ""
+		for _, word := range strings.Fields(para) {
+			candidate := word
+			if line != "" {
+				candidate = line + " " + word
+			}
+			if textWidth(encodeWinAnsi(candidate), bold, size) <= width {
+				line = candidate
+				continue
+			}
+			if line != "" {
+				lines = append(lines, line)
+			}
+			// Break words that are wider than a whole line.
+			for textWidth(encodeWinAnsi(word), bold, size) > width {
+				cut := fitPrefix(word, bold, size, width)
+				lines = append(lines, word[:cut])
+				word = word[cut:]
+			}
+			line = word
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// fitPrefix returns the byte length of the longest prefix of word (at least
+// one rune) that fits in width.
+func fitPrefix(word string, bold bool, size, width float64) int {
+	cut := 0
+	for i := range word {
+		if i > 0 && textWidth(encodeWinAnsi(word[:i]), bold, size) > width {
+			break
+		}
+		cut = i
+	}
+	if cut == 0 {
+		for i := range word {
+			if i > 0 {
+				return i
+			}
+		}
+		return len(word)
+	}
+	return cut
+}
+
+// truncateToWidth shortens s with an ellipsis so that it fits in width.
+func truncateToWidth(s string, bold bool, size, width float64) string {
+	if textWidth(encodeWinAnsi(s), bold, size) <= width {
+		return s
+	}
+	runes := []rune(s)
+	for len(runes) > 0 {
+		runes = runes[:len(runes)-1]
+		if textWidth(encodeWinAnsi(string(runes)+"…"), bold, size) <= width {
+			break
+		}
+	}
+	return string(runes) + "…"
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Drawing primitives
+// ─────────────────────────────────────────────────────────────────────────────
+
+func (l *pdfLayout) text(x, y float64, bold bool, size float64, color [3]float64, s string) {
+	l.textBytes(x, y, bold, size, color, encodeWinAnsi(s))
+}
+
+func (l *pdfLayout) textBytes(x, y float64, bold bool, size float64, color [3]float64, b []byte) {
+	font := "F1"
+	if bold {
+		font = "F2"
+	}
+	fmt.Fprintf(l.cur, "%.3f %.3f %.3f rg BT /%s %.1f Tf %.2f %.2f Td (%s) Tj ET\n",
+		color[0], color[1], color[2], font, size, x, y, escapePDFString(b))
+}
+
+func (l *pdfLayout) fill(c [3]float64) {
+	fmt.Fprintf(l.cur, "%.3f %.3f %.3f rg\n", c[0], c[1], c[2])
+}
+
+func (l *pdfLayout) stroke(c [3]float64, width float64) {
+	fmt.Fprintf(l.cur, "%.3f %.3f %.3f RG %.2f w\n", c[0], c[1], c[2], width)
+}
+
+func (l *pdfLayout) line(x1, y1, x2, y2 float64) {
+	fmt.Fprintf(l.cur, "%.2f %.2f m %.2f %.2f l S\n", x1, y1, x2, y2)
+}
+
+func (l *pdfLayout) rect(x, y, w, h float64, filled bool) {
+	op := "S"
+	if filled {
+		op = "f"
+	}
+	fmt.Fprintf(l.cur, "%.2f %.2f %.2f %.2f re %s\n", x, y, w, h, op)
+}
+
+// polygon strokes a closed path through pts.
+func (l *pdfLayout) polygon(pts [][2]float64) {
+	for i, p := range pts {
+		op := "l"
+		if i == 0 {
+			op = "m"
+		}
+		fmt.Fprintf(l.cur, "%.2f %.2f %s ", p[0], p[1], op)
+	}
+	l.cur.WriteString("s\n")
+}
+
+// escapePDFString escapes b for use in a PDF literal string.
+func escapePDFString(b []byte) string {
+	var sb strings.Builder
+	for _, c := range b {
+		switch {
+		case c == '(' || c == ')' || c == '\\':
+			sb.WriteByte('\\')
+			sb.WriteByte(c)
+		case c < 0x20 || c > 0x7E:
+			fmt.Fprintf(&sb, "\\%03o", c)
+		default:
+			sb.WriteByte(c)
+		}
+	}
+	return sb.String()
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// File structure
+// ─────────────────────────────────────────────────────────────────────────────
+
+// writePDF assembles the page content streams into a PDF file.
+//
+// Object layout: 1 catalog, 2 page tree, 3–4 fonts, 5 info, then a page and
+// a content stream object per page.
+func writePDF(w io.Writer, doc Document, pages []*bytes.Buffer) error {
+	const firstPageObj = 6
+	objCount := firstPageObj - 1 + 2*len(pages)
+
+	var out bytes.Buffer
+	offsets := make([]int, objCount+1)
+	writeObj := func(n int, body string) {
+		offsets[n] = out.Len()
+		fmt.Fprintf(&out, "%d 0 obj\n%s\nendobj\n", n, body)
+	}
+
+	out.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+
+	writeObj(1, "<< /Type /Catalog /Pages 2 0 R >>")
+
+	kids := make([]string, len(pages))
+	for i := range pages {
+		kids[i] = fmt.Sprintf("%d 0 R", firstPageObj+2*i)
+	}
+	writeObj(2, fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(pages)))
+
+	writeObj(3, "<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>")
+	writeObj(4, "<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>")
+
+	info := fmt.Sprintf("<< /Title (%s) /Producer (LearnBot)", escapePDFString(encodeWinAnsi(doc.Title)))
+	if !doc.GeneratedAt.IsZero() {
+		info += fmt.Sprintf(" /CreationDate (D:%s)", doc.GeneratedAt.UTC().Format("20060102150405Z"))
+	}
+	writeObj(5, info+" >>")
+
+	for i, page := range pages {
+		pageObj := firstPageObj + 2*i
+		writeObj(pageObj, fmt.Sprintf(
+			"<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %.2f %.2f] "+
+				"/Resources << /Font << /F1 3 0 R /F2 4 0 R >> >> /Contents %d 0 R >>",
+			pageWidth, pageHeight, pageObj+1))
+
+		var compressed bytes.Buffer
+		zw := zlib.NewWriter(&compressed)
+		if _, err := zw.Write(page.Bytes()); err != nil {
+			return fmt.Errorf("compress page %d: %w", i+1, err)
+		}
+		if err := zw.Close(); err != nil {
+			return fmt.Errorf("compress page %d: %w", i+1, err)
+		}
+		offsets[pageObj+1] = out.Len()
+		fmt.Fprintf(&out, "%d 0 obj\n<< /Length %d /Filter /FlateDecode >>\nstream\n", pageObj+1, compressed.Len())
+		out.Write(compressed.Bytes())
+		out.WriteString("\nendstream\nendobj\n")
+	}
+
+	xref := out.Len()
+	fmt.Fprintf(&out, "xref\n0 %d\n0000000000 65535 f \n", objCount+1)
+	for n := 1; n <= objCount; n++ {
+		fmt.Fprintf(&out, "%010d 00000 n \n", offsets[n])
+	}
+	fmt.Fprintf(&out, "trailer\n<< /Size %d /Root 1 0 R /Info 5 0 R >>\nstartxref\n%d\n%%%%EOF\n", objCount+1, xref)
+
+	_, err := w.Write(out.Bytes())
+	return err
+}
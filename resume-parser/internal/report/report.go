@@ -0,0 +1,244 @@
+// Package report renders printable reports (PDF or HTML) from a simple
+// document model. Callers such as the gap analysis and scorer handlers build
+// a Document out of headings, metrics, charts, tables and lists; this package
+// handles layout, pagination and output format.
+//
+// The PDF renderer is self-contained (standard Helvetica fonts, no external
+// dependencies). Long tables and lists continue onto new pages rather than
+// being truncated, and table headers are repeated on each page.
+package report
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"strings"
+	"time"
+)
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Formats
+// ─────────────────────────────────────────────────────────────────────────────
+
+// Format is a report output format.
+type Format string
+
+const (
+	// FormatPDF renders an application/pdf document.
+	FormatPDF Format = "pdf"
+
+	// FormatHTML renders a standalone, printable HTML page.
+	FormatHTML Format = "html"
+)
+
+// ParseFormat parses a format name. An empty string selects FormatPDF.
+func ParseFormat(s string) (Format, error) {
+	switch Format(strings.ToLower(strings.TrimSpace(s))) {
+	case "", FormatPDF:
+		return FormatPDF, nil
+	case FormatHTML:
+		return FormatHTML, nil
+	default:
+		return "", fmt.Errorf("unsupported report format %q: must be pdf or html", s)
+	}
+}
+
+// ContentType returns the MIME type for the format.
+func (f Format) ContentType() string {
+	if f == FormatHTML {
+		return "text/html; charset=utf-8"
+	}
+	return "application/pdf"
+}
+
+// Render writes doc to w in the given format.
+func Render(w io.Writer, f Format, doc Document) error {
+	switch f {
+	case FormatPDF:
+		return RenderPDF(w, doc)
+	case FormatHTML:
+		return RenderHTML(w, doc)
+	default:
+		return fmt.Errorf("unsupported report format %q", f)
+	}
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Document model
+// ─────────────────────────────────────────────────────────────────────────────
+
+// Document is a report to render.
+type Document struct {
+	// Title is shown at the top of the first page and in page footers.
+	Title string
+
+	// Subtitle is shown under the title (e.g. the target job title).
+	Subtitle string
+
+	// GeneratedAt is the report timestamp shown under the title.
+	GeneratedAt time.Time
+
+	// Blocks is the report body, rendered in order.
+	Blocks []Block
+}
+
+// Block is a renderable element of a Document. The concrete types are
+// Heading, Paragraph, Metrics, RadarChart, Table and List.
+type Block interface {
+	block()
+}
+
+// Heading starts a new report section.
+type Heading struct {
+	Text string
+}
+
+// Paragraph is a block of wrapped text.
+type Paragraph struct {
+	Text string
+}
+
+// Metrics is a row of headline figures (e.g. readiness score).
+type Metrics struct {
+	Items []Metric
+}
+
+// Metric is a single labelled figure.
+type Metric struct {
+	Label string
+	Value string
+}
+
+// RadarChart plots one or more series on radial axes.
+type RadarChart struct {
+	// Labels names each axis. Charts with fewer than three axes are rendered
+	// as a table, since a radar needs at least three.
+	Labels []string
+
+	// Series are the plotted value sets; each must have one value per label.
+	Series []Series
+
+	// Max is the value at the outer ring (default 1).
+	Max float64
+}
+
+// Series is one plotted value set of a RadarChart.
+type Series struct {
+	Name   string
+	Values []float64
+}
+
+// Table is a grid of text cells. Cells may contain newlines.
+type Table struct {
+	Columns []Column
+	Rows    [][]string
+
+	// Empty is shown instead of the table when Rows is empty.
+	Empty string
+}
+
+// Column describes a table column.
+type Column struct {
+	Title string
+
+	// Width is the column's share of the table width, relative to the other
+	// columns. Zero counts as 1.
+	Width float64
+}
+
+// List is a bulleted list.
+type List struct {
+	Items []string
+
+	// Empty is shown instead of the list when Items is empty.
+	Empty string
+}
+
+func (Heading) block()    {}
+func (Paragraph) block()  {}
+func (Metrics) block()    {}
+func (RadarChart) block() {}
+func (Table) block()      {}
+func (List) block()       {}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Shared helpers
+// ─────────────────────────────────────────────────────────────────────────────
+
+// seriesColors is the palette used for chart series, as RGB in [0, 1].
+var seriesColors = [][3]float64{
+	{0.231, 0.510, 0.965}, // blue
+	{0.961, 0.620, 0.043}, // amber
+	{0.063, 0.725, 0.506}, // green
+	{0.937, 0.267, 0.267}, // red
+}
+
+// seriesColor returns the palette colour for series i.
+func seriesColor(i int) [3]float64 {
+	return seriesColors[i%len(seriesColors)]
+}
+
+// chartMax returns the chart's outer ring value.
+func (c RadarChart) chartMax() float64 {
+	if c.Max > 0 {
+		return c.Max
+	}
+	return 1
+}
+
+// drawable reports whether the chart has enough axes to draw as a radar.
+func (c RadarChart) drawable() bool {
+	return len(c.Labels) >= 3
+}
+
+// asTable returns the chart's data as a table, used when it cannot be drawn.
+func (c RadarChart) asTable() Table {
+	cols := []Column{{Title: "Dimension", Width: 2}}
+	for _, s := range c.Series {
+		cols = append(cols, Column{Title: s.Name})
+	}
+	t := Table{Columns: cols, Empty: "No chart data."}
+	for i, label := range c.Labels {
+		row := []string{label}
+		for _, s := range c.Series {
+			row = append(row, formatChartValue(valueAt(s.Values, i), c.chartMax()))
+		}
+		t.Rows = append(t.Rows, row)
+	}
+	return t
+}
+
+// formatChartValue renders v as a percentage of max.
+func formatChartValue(v, max float64) string {
+	return fmt.Sprintf("%.0f%%", 100*v/max)
+}
+
+// valueAt returns values[i], or 0 when the series is short.
+func valueAt(values []float64, i int) float64 {
+	if i < len(values) {
+		return values[i]
+	}
+	return 0
+}
+
+// radarPoint returns the position of value v on axis i of n, for a chart
+// centred at (cx, cy) with radius r. Axis 0 points up; axes run clockwise.
+// yUp selects PDF coordinates (y grows upwards) instead of SVG ones.
+func radarPoint(i, n int, v, max, cx, cy, r float64, yUp bool) (float64, float64) {
+	frac := math.Max(0, math.Min(1, v/max))
+	angle := 2 * math.Pi * float64(i) / float64(n)
+	x := cx + r*frac*math.Sin(angle)
+	dy := r * frac * math.Cos(angle)
+	if yUp {
+		return x, cy + dy
+	}
+	return x, cy - dy
+}
+
+// generatedLabel formats the report timestamp.
+func generatedLabel(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return "Generated " + t.UTC().Format("2 January 2006 15:04 UTC")
+}
@@ -0,0 +1,272 @@
+package report
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dslipak/pdf"
+)
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Test helpers
+// ─────────────────────────────────────────────────────────────────────────────
+
+func sampleDocument() Document {
+	return Document{
+		Title:       "Skill Gap Analysis Report",
+		Subtitle:    "Target role: Backend Engineer",
+		GeneratedAt: time.Date(2025, 3, 1, 9, 30, 0, 0, time.UTC),
+		Blocks: []Block{
+			Metrics{Items: []Metric{{Label: "Readiness score", Value: "62%"}, {Label: "Critical gaps", Value: "2"}}},
+			Heading{Text: "Skills Profile"},
+			RadarChart{
+				Labels: []string{"Required Skills", "Preferred Skills", "Experience", "Education"},
+				Series: []Series{
+					{Name: "You", Values: []float64{0.5, 0.25, 1, 1}},
+					{Name: "Role requirement", Values: []float64{1, 1, 1, 1}},
+				},
+			},
+			Heading{Text: "Top Priority Gaps"},
+			Table{
+				Columns: []Column{{Title: "Skill"}, {Title: "Recommendations", Width: 3}},
+				Rows:    [][]string{{"Kubernetes", "1. Take a course (20h)\n2. Build a project (15h)"}},
+			},
+			Paragraph{Text: "Café – naïve “quotes” (parens) and a back\\slash 🚀"},
+		},
+	}
+}
+
+// renderPDF renders doc and opens it with an independent PDF reader.
+func renderPDF(t *testing.T, doc Document) ([]byte, *pdf.Reader) {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := RenderPDF(&buf, doc); err != nil {
+		t.Fatalf("RenderPDF: %v", err)
+	}
+	data := buf.Bytes()
+	r, err := pdf.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatalf("generated PDF cannot be opened: %v", err)
+	}
+	return data, r
+}
+
+// pdfText extracts the plain text of every page.
+func pdfText(t *testing.T, r *pdf.Reader) []string {
+	t.Helper()
+	pages := make([]string, r.NumPage())
+	for i := range pages {
+		text, err := r.Page(i + 1).GetPlainText(nil)
+		if err != nil {
+			t.Fatalf("page %d text: %v", i+1, err)
+		}
+		pages[i] = text
+	}
+	return pages
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Tests
+// ─────────────────────────────────────────────────────────────────────────────
+
+func TestParseFormat(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    Format
+		wantErr bool
+	}{
+		{"", FormatPDF, false},
+		{"pdf", FormatPDF, false},
+		{" HTML ", FormatHTML, false},
+		{"docx", "", true},
+	}
+	for _, tt := range tests {
+		got, err := ParseFormat(tt.in)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("ParseFormat(%q) = %q, %v", tt.in, got, err)
+		}
+	}
+	if FormatPDF.ContentType() != "application/pdf" || !strings.HasPrefix(FormatHTML.ContentType(), "text/html") {
+		t.Error("unexpected content types")
+	}
+}
+
+func TestRenderPDF_ValidStructure(t *testing.T) {
+	data, r := renderPDF(t, sampleDocument())
+
+	if !bytes.HasPrefix(data, []byte("%PDF-1.4")) || !bytes.HasSuffix(data, []byte("%%EOF\n")) {
+		t.Fatal("missing PDF header or trailer")
+	}
+	if r.NumPage() != 1 {
+		t.Errorf("expected 1 page, got %d", r.NumPage())
+	}
+
+	// Every xref entry must point at the start of its object.
+	xrefAt := bytes.LastIndex(data, []byte("\nxref\n")) + 1
+	entries := regexp.MustCompile(`(\d{10}) 00000 n `).FindAllSubmatch(data[xrefAt:], -1)
+	if len(entries) == 0 {
+		t.Fatal("no xref entries")
+	}
+	for i, e := range entries {
+		off, _ := strconv.Atoi(string(e[1]))
+		want := fmt.Sprintf("%d 0 obj", i+1)
+		if !bytes.HasPrefix(data[off:], []byte(want)) {
+			t.Errorf("xref entry %d points at %q, want %q", i+1, data[off:off+12], want)
+		}
+	}
+
+	text := strings.Join(pdfText(t, r), "\n")
+	for _, want := range []string{"Skill Gap Analysis Report", "Readiness score", "62%", "Kubernetes", "Build a project", "Page 1 of 1"} {
+		if !strings.Contains(text, want) {
+			t.Errorf("PDF text missing %q", want)
+		}
+	}
+}
+
+func TestRenderPDF_EmptyDocumentIsOnePage(t *testing.T) {
+	doc := Document{
+		Title: "Empty",
+		Blocks: []Block{
+			Heading{Text: "Top Priority Gaps"},
+			Table{Columns: []Column{{Title: "Skill"}}, Empty: "No skill gaps found."},
+			List{Empty: "Nothing here."},
+			RadarChart{},
+		},
+	}
+	_, r := renderPDF(t, doc)
+	if r.NumPage() != 1 {
+		t.Fatalf("expected 1 page, got %d", r.NumPage())
+	}
+	text := pdfText(t, r)[0]
+	if !strings.Contains(text, "No skill gaps found.") || !strings.Contains(text, "Nothing here.") {
+		t.Errorf("empty-state text missing: %q", text)
+	}
+}
+
+func TestRenderPDF_LongTablesPaginate(t *testing.T) {
+	const rows = 150
+	table := Table{Columns: []Column{{Title: "#"}, {Title: "Skill", Width: 3}}}
+	for i := 1; i <= rows; i++ {
+		table.Rows = append(table.Rows, []string{strconv.Itoa(i), fmt.Sprintf("Skill-%03d", i)})
+	}
+	list := List{}
+	for i := 1; i <= 120; i++ {
+		list.Items = append(list.Items, fmt.Sprintf("Matched-%03d", i))
+	}
+	doc := Document{Title: "Long", Blocks: []Block{table, list}}
+
+	_, r := renderPDF(t, doc)
+	if r.NumPage() < 3 {
+		t.Fatalf("expected the table and list to span several pages, got %d", r.NumPage())
+	}
+	pages := pdfText(t, r)
+	all := strings.Join(pages, "\n")
+	for i := 1; i <= rows; i++ {
+		if !strings.Contains(all, fmt.Sprintf("Skill-%03d", i)) {
+			t.Fatalf("row %d missing: long tables must not be truncated", i)
+		}
+	}
+	if !strings.Contains(all, "Matched-120") {
+		t.Error("last list item missing")
+	}
+	// The header is repeated on continuation pages.
+	if !strings.Contains(pages[1], "Skill") || !strings.Contains(pages[1], fmt.Sprintf("Page 2 of %d", len(pages))) {
+		t.Errorf("page 2 should repeat the table header and carry a footer: %q", pages[1][:min(200, len(pages[1]))])
+	}
+}
+
+func TestRenderPDF_TallCellSplitsAcrossPages(t *testing.T) {
+	var skills []string
+	for i := 0; i < 400; i++ {
+		skills = append(skills, fmt.Sprintf("skill%03d", i))
+	}
+	doc := Document{Blocks: []Block{Table{
+		Columns: []Column{{Title: "Skills"}},
+		Rows:    [][]string{{strings.Join(skills, "\n")}},
+	}}}
+	_, r := renderPDF(t, doc)
+	if r.NumPage() < 2 {
+		t.Fatalf("expected a single tall row to continue on a new page, got %d pages", r.NumPage())
+	}
+	if all := strings.Join(pdfText(t, r), "\n"); !strings.Contains(all, "skill399") {
+		t.Error("tall cell was truncated")
+	}
+}
+
+func TestWrapText(t *testing.T) {
+	lines := wrapText("the quick brown fox jumps over the lazy dog", false, 10, 60)
+	if len(lines) < 3 {
+		t.Errorf("expected wrapping, got %q", lines)
+	}
+	for _, line := range lines {
+		if w := textWidth(encodeWinAnsi(line), false, 10); w > 60 {
+			t.Errorf("line %q is %.1fpt wide, exceeds 60", line, w)
+		}
+	}
+
+	long := wrapText(strings.Repeat("x", 200), false, 10, 50)
+	if strings.Join(long, "") != strings.Repeat("x", 200) {
+		t.Error("long words must be broken, not truncated")
+	}
+
+	if got := wrapText("", false, 10, 50); len(got) != 1 {
+		t.Errorf("empty text should produce one line, got %q", got)
+	}
+}
+
+func TestEncodeWinAnsi(t *testing.T) {
+	got := encodeWinAnsi("Café – “ok” 🚀中")
+	want := []byte{'C', 'a', 'f', 0xE9, ' ', 0x96, ' ', 0x93, 'o', 'k', 0x94, ' '}
+	if !bytes.Equal(got, want) {
+		t.Errorf("encodeWinAnsi = %q, want %q", got, want)
+	}
+	if helveticaWidths['~'-0x20] != 584 || helveticaBoldWidths['z'-0x20] != 500 {
+		t.Error("font width tables are misaligned")
+	}
+	if escapePDFString([]byte(`a(b)\`)) != `a\(b\)\\` {
+		t.Error("PDF string escaping is wrong")
+	}
+}
+
+func TestRenderHTML(t *testing.T) {
+	doc := sampleDocument()
+	doc.Blocks = append(doc.Blocks, Paragraph{Text: "<script>alert(1)</script>"})
+
+	var buf bytes.Buffer
+	if err := RenderHTML(&buf, doc); err != nil {
+		t.Fatalf("RenderHTML: %v", err)
+	}
+	html := buf.String()
+
+	for _, want := range []string{
+		"<!DOCTYPE html>", "<h1>Skill Gap Analysis Report</h1>", "<svg", "<polygon",
+		"<th>Recommendations</th>", "1. Take a course (20h)<br>2. Build a project (15h)",
+		"rgb(58,130,246)",
+	} {
+		if !strings.Contains(html, want) {
+			t.Errorf("HTML missing %q", want)
+		}
+	}
+	if strings.Contains(html, "<script>") {
+		t.Error("user text must be escaped")
+	}
+}
+
+func TestRenderHTML_SmallRadarFallsBackToTable(t *testing.T) {
+	var buf bytes.Buffer
+	err := RenderHTML(&buf, Document{Blocks: []Block{RadarChart{
+		Labels: []string{"Skills", "Experience"},
+		Series: []Series{{Name: "You", Values: []float64{0.5, 1}}},
+	}}})
+	if err != nil {
+		t.Fatalf("RenderHTML: %v", err)
+	}
+	if strings.Contains(buf.String(), "<svg") || !strings.Contains(buf.String(), "50%") {
+		t.Errorf("two-axis chart should render as a table: %s", buf.String())
+	}
+}
@@ -0,0 +1,183 @@
+// Package resultcache keeps the results of recent scoring, gap analysis and
+// learning plan requests in memory, so that a client asking for the same
+// analysis again, such as the frontend when users navigate back and forth,
+// does not redo the computation.
+//
+// Results are keyed by a hash of the canonicalized request (see Key), kept
+// for a fixed TTL, and evicted least recently used first when the cache is
+// full. Identical requests that arrive while a result is being computed
+// wait for that result instead of computing it again.
+package resultcache
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/learnbot/shared/metrics"
+)
+
+const (
+	// DefaultCapacity is the default number of results kept per cache.
+	DefaultCapacity = 1000
+
+	// DefaultTTL is the default time a result stays cached.
+	DefaultTTL = 10 * time.Minute
+)
+
+// errAbandoned is returned to callers waiting for a computation that
+// panicked.
+var errAbandoned = errors.New("resultcache: computation of the shared result panicked")
+
+// lookups counts cache lookups by cache name and result ("hit" or "miss").
+var lookups = metrics.NewCounter("result_cache_lookups_total",
+	"Result cache lookups, by cache and result (hit or miss).", "cache", "result")
+
+// Cache is a bounded, concurrency-safe LRU cache of results with a TTL. A
+// nil *Cache caches nothing: Do always computes the result.
+//
+// Cached values are shared between callers. Callers may change fields of
+// the value they get, but must not modify the slices or maps it refers to.
+type Cache[V any] struct {
+	name     string
+	capacity int
+	ttl      time.Duration
+	now      func() time.Time
+
+	mu       sync.Mutex
+	order    *list.List // of *entry[V], most recently used first
+	entries  map[string]*list.Element
+	inflight map[string]*call[V]
+}
+
+// entry is a cached result.
+type entry[V any] struct {
+	key     string
+	value   V
+	expires time.Time
+}
+
+// call is a result being computed.
+type call[V any] struct {
+	done  chan struct{}
+	value V
+	err   error
+}
+
+// New creates a cache named name, as reported in the metrics, that keeps
+// up to capacity results for ttl each. It returns nil, a disabled cache,
+// if capacity or ttl is not positive.
+func New[V any](name string, capacity int, ttl time.Duration) *Cache[V] {
+	if capacity < 1 || ttl <= 0 {
+		return nil
+	}
+	return &Cache[V]{
+		name:     name,
+		capacity: capacity,
+		ttl:      ttl,
+		now:      time.Now,
+		order:    list.New(),
+		entries:  make(map[string]*list.Element),
+		inflight: make(map[string]*call[V]),
+	}
+}
+
+// TTL returns how long results stay cached, or 0 for a nil cache.
+func (c *Cache[V]) TTL() time.Duration {
+	if c == nil {
+		return 0
+	}
+	return c.ttl
+}
+
+// Len returns the number of cached results.
+func (c *Cache[V]) Len() int {
+	if c == nil {
+		return 0
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.order.Len()
+}
+
+// Do returns the cached result for key, or computes it with fn and caches
+// it. When the same key is already being computed, Do waits for that
+// computation and returns its result. hit reports whether the result was
+// not computed by this call. Errors are returned but not cached. An empty
+// key, or a nil cache, always computes the result.
+func (c *Cache[V]) Do(key string, fn func() (V, error)) (value V, hit bool, err error) {
+	if c == nil || key == "" {
+		value, err = fn()
+		return value, false, err
+	}
+
+	c.mu.Lock()
+	if el, ok := c.entries[key]; ok {
+		e := el.Value.(*entry[V])
+		if c.now().Before(e.expires) {
+			c.order.MoveToFront(el)
+			c.mu.Unlock()
+			lookups.Inc(c.name, "hit")
+			return e.value, true, nil
+		}
+		c.removeElement(el)
+	}
+	if cl, ok := c.inflight[key]; ok {
+		c.mu.Unlock()
+		<-cl.done
+		lookups.Inc(c.name, "hit")
+		return cl.value, true, cl.err
+	}
+	cl := &call[V]{done: make(chan struct{}), err: errAbandoned}
+	c.inflight[key] = cl
+	c.mu.Unlock()
+	lookups.Inc(c.name, "miss")
+
+	// Release waiters even if fn panics; they then get errAbandoned, as the
+	// panic propagates to this caller.
+	defer func() {
+		c.mu.Lock()
+		delete(c.inflight, key)
+		if cl.err == nil {
+			c.add(key, cl.value)
+		}
+		c.mu.Unlock()
+		close(cl.done)
+	}()
+	cl.value, cl.err = fn()
+	return cl.value, false, cl.err
+}
+
+// add stores a result, evicting the least recently used one if the cache
+// is full. c.mu must be held.
+func (c *Cache[V]) add(key string, value V) {
+	if el, ok := c.entries[key]; ok {
+		c.removeElement(el)
+	}
+	if c.order.Len() >= c.capacity {
+		c.removeElement(c.order.Back())
+	}
+	c.entries[key] = c.order.PushFront(&entry[V]{key: key, value: value, expires: c.now().Add(c.ttl)})
+}
+
+// removeElement removes a cached result. c.mu must be held.
+func (c *Cache[V]) removeElement(el *list.Element) {
+	c.order.Remove(el)
+	delete(c.entries, el.Value.(*entry[V]).key)
+}
+
+// Key returns a stable hash of parts, encoded as JSON. Callers canonicalize
+// the parts first, so that equivalent requests share a key. It returns ""
+// if a part cannot be encoded.
+func Key(parts ...interface{}) string {
+	b, err := json.Marshal(parts)
+	if err != nil {
+		return ""
+	}
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,248 @@
+package resultcache
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// counting returns a computation that returns v and counts its calls.
+func counting(calls *int32, v int) func() (int, error) {
+	return func() (int, error) {
+		atomic.AddInt32(calls, 1)
+		return v, nil
+	}
+}
+
+func TestCache_HitAndMiss(t *testing.T) {
+	c := New[int]("test_hit", 10, time.Minute)
+	var calls int32
+	hitsBefore, missesBefore := lookups.Value("test_hit", "hit"), lookups.Value("test_hit", "miss")
+
+	v, hit, err := c.Do("k", counting(&calls, 1))
+	if err != nil || v != 1 || hit {
+		t.Fatalf("first Do = %d, %v, %v; want 1, miss", v, hit, err)
+	}
+	v, hit, err = c.Do("k", counting(&calls, 2))
+	if err != nil || v != 1 || !hit {
+		t.Fatalf("second Do = %d, %v, %v; want cached 1, hit", v, hit, err)
+	}
+	if calls != 1 {
+		t.Errorf("computed %d times, want 1", calls)
+	}
+	if got := lookups.Value("test_hit", "hit") - hitsBefore; got != 1 {
+		t.Errorf("hit counter moved by %v, want 1", got)
+	}
+	if got := lookups.Value("test_hit", "miss") - missesBefore; got != 1 {
+		t.Errorf("miss counter moved by %v, want 1", got)
+	}
+}
+
+func TestCache_EvictsLeastRecentlyUsed(t *testing.T) {
+	c := New[int]("test_lru", 2, time.Minute)
+	var calls int32
+	c.Do("a", counting(&calls, 1))
+	c.Do("b", counting(&calls, 2))
+	c.Do("a", counting(&calls, 1)) // a is now the most recently used
+	c.Do("c", counting(&calls, 3)) // evicts b
+
+	if c.Len() != 2 {
+		t.Errorf("Len = %d, want 2", c.Len())
+	}
+	if _, hit, _ := c.Do("a", counting(&calls, 1)); !hit {
+		t.Error("expected a to stay cached")
+	}
+	if _, hit, _ := c.Do("b", counting(&calls, 2)); hit {
+		t.Error("expected b to be evicted")
+	}
+}
+
+func TestCache_ExpiresAfterTTL(t *testing.T) {
+	c := New[int]("test_ttl", 10, time.Minute)
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	c.now = func() time.Time { return now }
+	var calls int32
+
+	c.Do("k", counting(&calls, 1))
+	now = now.Add(59 * time.Second)
+	if _, hit, _ := c.Do("k", counting(&calls, 2)); !hit {
+		t.Error("expected a hit within the TTL")
+	}
+	now = now.Add(time.Second)
+	if v, hit, _ := c.Do("k", counting(&calls, 2)); hit || v != 2 {
+		t.Errorf("expected a recomputed 2 after the TTL, got %d (hit %v)", v, hit)
+	}
+}
+
+func TestCache_ErrorsAreNotCached(t *testing.T) {
+	c := New[int]("test_err", 10, time.Minute)
+	errFailed := errors.New("failed")
+	if _, _, err := c.Do("k", func() (int, error) { return 0, errFailed }); err != errFailed {
+		t.Fatalf("err = %v, want %v", err, errFailed)
+	}
+	var calls int32
+	if v, hit, err := c.Do("k", counting(&calls, 5)); err != nil || hit || v != 5 {
+		t.Errorf("Do after an error = %d, %v, %v; want a computed 5", v, hit, err)
+	}
+}
+
+func TestCache_ParallelIdenticalRequestsComputeOnce(t *testing.T) {
+	c := New[int]("test_parallel", 10, time.Minute)
+	var calls int32
+	release := make(chan struct{})
+	slow := func() (int, error) {
+		atomic.AddInt32(&calls, 1)
+		<-release
+		return 42, nil
+	}
+
+	const n = 50
+	var wg sync.WaitGroup
+	results := make([]int, n)
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i], _, _ = c.Do("k", slow)
+		}(i)
+	}
+	// Let the goroutines queue up behind the first computation.
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	if calls != 1 {
+		t.Errorf("computed %d times, want 1", calls)
+	}
+	for i, v := range results {
+		if v != 42 {
+			t.Fatalf("result %d = %d, want 42", i, v)
+		}
+	}
+}
+
+func TestCache_PanicReleasesWaiters(t *testing.T) {
+	c := New[int]("test_panic", 10, time.Minute)
+	started, release := make(chan struct{}), make(chan struct{})
+	go func() {
+		defer func() { recover() }()
+		c.Do("k", func() (int, error) {
+			close(started)
+			<-release
+			panic("boom")
+		})
+	}()
+	<-started
+
+	done := make(chan error)
+	go func() {
+		_, _, err := c.Do("k", func() (int, error) { return 1, nil })
+		done <- err
+	}()
+	time.Sleep(10 * time.Millisecond)
+	close(release)
+
+	if err := <-done; err != errAbandoned {
+		t.Errorf("waiter err = %v, want %v", err, errAbandoned)
+	}
+	if c.Len() != 0 {
+		t.Error("a panicked computation must not be cached")
+	}
+}
+
+func TestCache_NilAndEmptyKeyCompute(t *testing.T) {
+	var calls int32
+	var disabled *Cache[int]
+	disabled.Do("k", counting(&calls, 1))
+	disabled.Do("k", counting(&calls, 1))
+	if New[int]("test_disabled", 0, time.Minute) != nil {
+		t.Error("expected capacity 0 to disable the cache")
+	}
+
+	c := New[int]("test_empty_key", 10, time.Minute)
+	c.Do("", counting(&calls, 1))
+	c.Do("", counting(&calls, 1))
+	if calls != 4 || c.Len() != 0 {
+		t.Errorf("calls = %d, Len = %d; want 4 uncached calls", calls, c.Len())
+	}
+}
+
+func TestKey(t *testing.T) {
+	type req struct {
+		Skills []string `json:"skills"`
+	}
+	a := Key(req{[]string{"go", "sql"}}, 1)
+	if a != Key(req{[]string{"go", "sql"}}, 1) {
+		t.Error("expected equal parts to share a key")
+	}
+	if a == Key(req{[]string{"go", "sql"}}, 2) || a == Key(req{[]string{"sql", "go"}}, 1) {
+		t.Error("expected different parts to get different keys")
+	}
+	if Key(func() {}) != "" {
+		t.Error("expected an unencodable part to yield no key")
+	}
+}
+
+func TestBypass(t *testing.T) {
+	tests := []struct {
+		header, value string
+		want          bool
+	}{
+		{"", "", false},
+		{NoCacheHeader, "1", true},
+		{NoCacheHeader, "true", true},
+		{NoCacheHeader, "yes", true},
+		{NoCacheHeader, "false", false},
+		{"Cache-Control", "no-cache", true},
+		{"Cache-Control", "max-age=0, No-Cache", true},
+		{"Cache-Control", "max-age=60", false},
+	}
+	for _, tt := range tests {
+		r := httptest.NewRequest(http.MethodPost, "/", nil)
+		if tt.header != "" {
+			r.Header.Set(tt.header, tt.value)
+		}
+		if got := Bypass(r); got != tt.want {
+			t.Errorf("Bypass(%s: %q) = %v, want %v", tt.header, tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestServe_Headers(t *testing.T) {
+	c := New[int]("test_serve", 10, 5*time.Minute)
+	var calls int32
+	serve := func(bypass bool) http.Header {
+		r := httptest.NewRequest(http.MethodPost, "/", nil)
+		if bypass {
+			r.Header.Set(NoCacheHeader, "1")
+		}
+		w := httptest.NewRecorder()
+		if _, err := Serve(c, w, r, "k", counting(&calls, 1)); err != nil {
+			t.Fatal(err)
+		}
+		return w.Header()
+	}
+
+	if h := serve(false); h.Get(StatusHeader) != "MISS" || h.Get("Cache-Control") != "private, max-age=300" {
+		t.Errorf("first response headers = %v", h)
+	}
+	if h := serve(false); h.Get(StatusHeader) != "HIT" {
+		t.Errorf("second response headers = %v", h)
+	}
+	if h := serve(true); h.Get(StatusHeader) != "BYPASS" || h.Get("Cache-Control") != "no-store" {
+		t.Errorf("bypass response headers = %v", h)
+	}
+	if calls != 2 {
+		t.Errorf("computed %d times, want 2", calls)
+	}
+
+	w := httptest.NewRecorder()
+	Serve[int](nil, w, httptest.NewRequest(http.MethodPost, "/", nil), "k", counting(&calls, 1))
+	if len(w.Header()) != 0 {
+		t.Errorf("expected no headers without a cache, got %v", w.Header())
+	}
+}
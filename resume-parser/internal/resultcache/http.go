@@ -0,0 +1,72 @@
+package resultcache
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/learnbot/shared/openapi"
+)
+
+// NoCacheHeader is the request header that bypasses the cache: a request
+// with X-No-Cache set to a true value ("1", "true") is computed afresh, and
+// its result is not cached.
+const NoCacheHeader = "X-No-Cache"
+
+// NoCacheParam documents NoCacheHeader on the cached routes.
+var NoCacheParam = openapi.Param{
+	Name:        NoCacheHeader,
+	In:          "header",
+	Description: "Set to true to compute a fresh result instead of reusing a cached one",
+}
+
+// StatusHeader is the response header that reports how a result was
+// served: "HIT" from the cache, "MISS" computed and cached, or "BYPASS"
+// computed because the request asked not to use the cache.
+const StatusHeader = "X-Cache"
+
+// Bypass reports whether a request asks not to use the cache, with
+// X-No-Cache or with Cache-Control: no-cache. An X-No-Cache value that is
+// not a boolean counts as true.
+func Bypass(r *http.Request) bool {
+	if v := strings.TrimSpace(r.Header.Get(NoCacheHeader)); v != "" {
+		b, err := strconv.ParseBool(v)
+		return err != nil || b
+	}
+	for _, d := range strings.Split(r.Header.Get("Cache-Control"), ",") {
+		if strings.EqualFold(strings.TrimSpace(d), "no-cache") {
+			return true
+		}
+	}
+	return false
+}
+
+// Serve returns the result of a request from c, computing it with fn on a
+// miss, and sets the response headers that describe it: StatusHeader, and a
+// Cache-Control hint that the client may reuse a result for the cache's
+// TTL. Requests that Bypass the cache, and failed computations, are marked
+// no-store. With a nil cache, Serve only calls fn.
+func Serve[V any](c *Cache[V], w http.ResponseWriter, r *http.Request, key string, fn func() (V, error)) (V, error) {
+	if c == nil {
+		return fn()
+	}
+	if Bypass(r) {
+		w.Header().Set(StatusHeader, "BYPASS")
+		w.Header().Set("Cache-Control", "no-store")
+		return fn()
+	}
+
+	value, hit, err := c.Do(key, fn)
+	if err != nil {
+		w.Header().Set("Cache-Control", "no-store")
+		return value, err
+	}
+	status := "MISS"
+	if hit {
+		status = "HIT"
+	}
+	w.Header().Set(StatusHeader, status)
+	w.Header().Set("Cache-Control", fmt.Sprintf("private, max-age=%d", int(c.TTL().Seconds())))
+	return value, nil
+}
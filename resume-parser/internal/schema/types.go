@@ -53,6 +53,29 @@ type Skill struct {
 	Name       string          `json:"name"`
 	Category   string          `json:"category"` // "technical", "soft", "language", "tool"
 	Confidence ConfidenceScore `json:"confidence"`
+
+	// Proficiency is "beginner", "intermediate", "advanced" or "expert".
+	// When the resume states no level it is inferred from the work history
+	// and ProficiencyInferred is set; see extractor.InferProficiency.
+	Proficiency         string `json:"proficiency,omitempty"`
+	ProficiencyInferred bool   `json:"proficiency_inferred,omitempty"`
+	// ExperienceMonths is the number of months of roles mentioning the
+	// skill, counted when the proficiency is inferred.
+	ExperienceMonths int `json:"experience_months,omitempty"`
+
+	// Taxonomy enrichment, only populated when ParseRequest.EnrichSkills is
+	// set. Name keeps the text as written in the resume.
+	TaxonomyNode *SkillNodeRef `json:"taxonomy_node,omitempty"`
+	MatchType    string        `json:"match_type,omitempty"` // "exact", "alias", "fuzzy", "none"
+	Unresolved   bool          `json:"unresolved,omitempty"`
+}
+
+// SkillNodeRef identifies the taxonomy node an extracted skill resolved to.
+type SkillNodeRef struct {
+	ID            string `json:"id"`
+	CanonicalName string `json:"canonical_name"`
+	Domain        string `json:"domain"`
+	Category      string `json:"category"`
 }
 
 // Certification represents a professional certification or license.
@@ -82,6 +105,7 @@ type ParsedResume struct {
 	SourceFile    string    `json:"source_file"`
 	FileType      string    `json:"file_type"` // "pdf" or "docx"
 	ParserVersion string    `json:"parser_version"`
+	Language      string    `json:"language"` // ISO 639-1 code of the section headers, e.g. "en", "id"
 
 	// Extracted sections
 	PersonalInfo   PersonalInfo     `json:"personal_info"`
@@ -119,6 +143,9 @@ type ParseRequest struct {
 	FileContent []byte `json:"file_content"`
 	FileType    string `json:"file_type"` // "pdf" or "docx"
 	IncludeRaw  bool   `json:"include_raw,omitempty"`
+
+	// EnrichSkills maps each extracted skill to its skill taxonomy node.
+	EnrichSkills bool `json:"enrich_skills,omitempty"`
 }
 
 // ParseResponse wraps the parsed resume and any errors.
@@ -0,0 +1,84 @@
+package scorer
+
+import (
+	"runtime"
+	"sort"
+	"sync"
+)
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Batch scoring
+// ─────────────────────────────────────────────────────────────────────────────
+
+// CalculateBatch scores every profile against the same job. Results are
+// returned in input order; each carries its input Index and its Rank among
+// the batch by overall score.
+//
+// Profiles are scored concurrently by a worker pool bounded by GOMAXPROCS.
+// Each result is identical to calling Calculate on the same inputs.
+func CalculateBatch(profiles []CandidateProfile, job JobRequirements) []ScoreResult {
+	results, _ := CalculateBatchWithWeights(profiles, job, DefaultScoringWeights())
+	return results
+}
+
+// CalculateBatchWithWeights is CalculateBatch with the given component
+// weights; each result is identical to calling CalculateWithWeights. It
+// returns ErrNegativeWeight, ErrZeroWeights or ErrRelatedSkillCredit for
+// invalid weights.
+func CalculateBatchWithWeights(profiles []CandidateProfile, job JobRequirements, weights ScoringWeights) ([]ScoreResult, error) {
+	if err := weights.Validate(); err != nil {
+		return nil, err
+	}
+	results := make([]ScoreResult, len(profiles))
+	if len(profiles) == 0 {
+		return results, nil
+	}
+
+	workers := runtime.GOMAXPROCS(0)
+	if workers > len(profiles) {
+		workers = len(profiles)
+	}
+
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				// The weights are valid, so scoring cannot fail.
+				score, _ := CalculateWithWeights(profiles[i], job, weights)
+				results[i] = ScoreResult{Index: i, Score: score}
+			}
+		}()
+	}
+	for i := range profiles {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+
+	assignRanks(results)
+	return results, nil
+}
+
+// assignRanks sets Rank on every result using competition ranking: the
+// highest overall score is rank 1 and equal scores share a rank, so scores
+// of 90, 90, 80 rank 1, 1, 3.
+func assignRanks(results []ScoreResult) {
+	order := make([]int, len(results))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return results[order[a]].Score.OverallScore > results[order[b]].Score.OverallScore
+	})
+
+	for pos, i := range order {
+		if pos > 0 && results[i].Score.OverallScore == results[order[pos-1]].Score.OverallScore {
+			results[i].Rank = results[order[pos-1]].Rank
+			continue
+		}
+		results[i].Rank = pos + 1
+	}
+}
@@ -0,0 +1,223 @@
+package scorer
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Test helpers
+// ─────────────────────────────────────────────────────────────────────────────
+
+func batchTestJob() JobRequirements {
+	return JobRequirements{
+		Title:               "Backend Engineer",
+		RequiredSkills:      []string{"Go", "PostgreSQL", "Docker"},
+		PreferredSkills:     []string{"Kubernetes"},
+		MinYearsExperience:  3,
+		RequiredDegreeLevel: "bachelor",
+		LocationType:        "remote",
+		Industry:            "software",
+	}
+}
+
+// batchTestProfiles returns n profiles with varied skills and experience so
+// that scores differ, plus a few exact duplicates to exercise tied ranks.
+func batchTestProfiles(n int) []CandidateProfile {
+	skills := []string{"Go", "PostgreSQL", "Docker", "Kubernetes", "Python"}
+	profiles := make([]CandidateProfile, n)
+	for i := range profiles {
+		p := CandidateProfile{
+			YearsOfExperience: float64(i % 8),
+			Education:         []EducationEntry{{DegreeLevel: "bachelor"}},
+			RemotePreference:  "remote",
+		}
+		for j, s := range skills {
+			if (i>>j)&1 == 1 {
+				p.Skills = append(p.Skills, CandidateSkill{Name: s, Proficiency: "advanced"})
+			}
+		}
+		profiles[i] = p
+	}
+	return profiles
+}
+
+func postBatch(t *testing.T, h *Handler, req BatchScoreRequest) (*httptest.ResponseRecorder, BatchScoreResponse) {
+	t.Helper()
+	body, err := json.Marshal(req)
+	if err != nil {
+		t.Fatalf("failed to marshal request: %v", err)
+	}
+	r := httptest.NewRequest(http.MethodPost, "/api/v1/score/batch", bytes.NewReader(body))
+	r.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	h.BatchScoreHandler(w, r)
+
+	var resp BatchScoreResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	return w, resp
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// CalculateBatch
+// ─────────────────────────────────────────────────────────────────────────────
+
+func TestCalculateBatch_MatchesSingleCalls(t *testing.T) {
+	job := batchTestJob()
+	profiles := batchTestProfiles(64)
+
+	results := CalculateBatch(profiles, job)
+
+	if len(results) != len(profiles) {
+		t.Fatalf("expected %d results, got %d", len(profiles), len(results))
+	}
+	for i, p := range profiles {
+		if results[i].Index != i {
+			t.Errorf("result %d has index %d", i, results[i].Index)
+		}
+		if want := Calculate(p, job); !reflect.DeepEqual(results[i].Score, want) {
+			t.Errorf("result %d differs from Calculate:\n got %+v\nwant %+v", i, results[i].Score, want)
+		}
+	}
+}
+
+func TestCalculateBatch_OrderingIsStable(t *testing.T) {
+	job := batchTestJob()
+	profiles := batchTestProfiles(100)
+
+	first := CalculateBatch(profiles, job)
+	for run := 0; run < 10; run++ {
+		if got := CalculateBatch(profiles, job); !reflect.DeepEqual(got, first) {
+			t.Fatalf("run %d produced different results", run)
+		}
+	}
+}
+
+func TestCalculateBatch_Ranks(t *testing.T) {
+	job := batchTestJob()
+	strong := CandidateProfile{
+		Skills: []CandidateSkill{
+			{Name: "Go", Proficiency: "expert"},
+			{Name: "PostgreSQL", Proficiency: "expert"},
+			{Name: "Docker", Proficiency: "expert"},
+		},
+		YearsOfExperience: 5,
+		Education:         []EducationEntry{{DegreeLevel: "bachelor"}},
+		RemotePreference:  "remote",
+	}
+	weak := CandidateProfile{}
+
+	results := CalculateBatch([]CandidateProfile{weak, strong, strong}, job)
+
+	if results[1].Rank != 1 || results[2].Rank != 1 {
+		t.Errorf("equal top scores should share rank 1, got %d and %d", results[1].Rank, results[2].Rank)
+	}
+	if results[0].Rank != 3 {
+		t.Errorf("expected the weak candidate to rank 3, got %d", results[0].Rank)
+	}
+}
+
+func TestCalculateBatch_Empty(t *testing.T) {
+	if got := CalculateBatch(nil, batchTestJob()); len(got) != 0 {
+		t.Errorf("expected no results, got %d", len(got))
+	}
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// BatchScoreHandler
+// ─────────────────────────────────────────────────────────────────────────────
+
+func TestBatchScoreHandler_Success(t *testing.T) {
+	h := buildTestScorerHandler()
+	profiles := batchTestProfiles(20)
+	req := BatchScoreRequest{Job: batchTestJob()}
+	for i, p := range profiles {
+		req.Candidates = append(req.Candidates, BatchCandidate{CandidateID: fmt.Sprintf("cand-%02d", i), Profile: p})
+	}
+
+	w, resp := postBatch(t, h, req)
+
+	if w.Code != http.StatusOK || !resp.Success {
+		t.Fatalf("expected 200 success, got %d: %s", w.Code, resp.Error)
+	}
+	if len(resp.Data) != len(profiles) {
+		t.Fatalf("expected %d results, got %d", len(profiles), len(resp.Data))
+	}
+	for i, res := range resp.Data {
+		if res.Index != i || res.CandidateID != fmt.Sprintf("cand-%02d", i) {
+			t.Errorf("result %d is tagged index=%d id=%q", i, res.Index, res.CandidateID)
+		}
+		if res.Rank < 1 || res.Rank > len(profiles) {
+			t.Errorf("result %d has rank %d out of range", i, res.Rank)
+		}
+		if want := Calculate(profiles[i], req.Job); res.Score.OverallScore != want.OverallScore {
+			t.Errorf("result %d score %.2f, single call %.2f", i, res.Score.OverallScore, want.OverallScore)
+		}
+		if res.Score.Explanation != nil {
+			t.Errorf("result %d has an explanation without verbose=true", i)
+		}
+	}
+}
+
+func TestBatchScoreHandler_ExceedsCap(t *testing.T) {
+	h := buildTestScorerHandler()
+	h.SetMaxBatchSize(3)
+	req := BatchScoreRequest{Job: batchTestJob(), Candidates: make([]BatchCandidate, 4)}
+
+	w, resp := postBatch(t, h, req)
+
+	if w.Code != http.StatusUnprocessableEntity {
+		t.Fatalf("expected 422, got %d", w.Code)
+	}
+	if resp.Success || !strings.Contains(resp.Error, "maximum batch size of 3") {
+		t.Errorf("unexpected error response: %+v", resp)
+	}
+
+	req.Candidates = req.Candidates[:3]
+	if w, _ := postBatch(t, h, req); w.Code != http.StatusOK {
+		t.Errorf("a batch at the cap should be accepted, got %d", w.Code)
+	}
+}
+
+func TestBatchScoreHandler_EmptyCandidates(t *testing.T) {
+	w, resp := postBatch(t, buildTestScorerHandler(), BatchScoreRequest{Job: batchTestJob()})
+
+	if w.Code != http.StatusUnprocessableEntity || resp.Success {
+		t.Errorf("expected 422, got %d", w.Code)
+	}
+}
+
+func TestBatchScoreHandler_MethodNotAllowed(t *testing.T) {
+	h := buildTestScorerHandler()
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/score/batch", nil)
+	w := httptest.NewRecorder()
+
+	h.BatchScoreHandler(w, req)
+
+	if w.Code != http.StatusMethodNotAllowed {
+		t.Errorf("expected 405, got %d", w.Code)
+	}
+}
+
+func TestBatchScoreHandler_RouteRegistered(t *testing.T) {
+	mux := http.NewServeMux()
+	buildTestScorerHandler().RegisterRoutes(mux)
+
+	body := `{"job":{"title":"Go Developer","required_skills":["Go"]},"candidates":[{"profile":{"skills":[{"name":"Go"}]}}]}`
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/score/batch", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+}
@@ -0,0 +1,80 @@
+package scorer
+
+import (
+	"sort"
+	"strings"
+)
+
+// CanonicalProfile returns a copy of p with the lists whose order does not
+// affect scoring sorted: skills, certifications and projects, and each
+// project's technologies. Profiles that differ only in the order of these
+// lists have equal canonical forms, so they share result cache entries.
+// Provenance does not affect scoring either and is cleared.
+func CanonicalProfile(p CandidateProfile) CandidateProfile {
+	p = withoutProvenance(p)
+	sort.SliceStable(p.Skills, func(i, j int) bool {
+		a, b := p.Skills[i], p.Skills[j]
+		if na, nb := normalizeSkillName(a.Name), normalizeSkillName(b.Name); na != nb {
+			return na < nb
+		}
+		if a.Name != b.Name {
+			return a.Name < b.Name
+		}
+		if a.Proficiency != b.Proficiency {
+			return a.Proficiency < b.Proficiency
+		}
+		return a.YearsOfExperience < b.YearsOfExperience
+	})
+
+	sort.SliceStable(p.Certifications, func(i, j int) bool {
+		a, b := p.Certifications[i], p.Certifications[j]
+		if a.Name != b.Name {
+			return a.Name < b.Name
+		}
+		if a.Issuer != b.Issuer {
+			return a.Issuer < b.Issuer
+		}
+		return a.Year < b.Year
+	})
+
+	for i, proj := range p.Projects {
+		techs := append([]string(nil), proj.Technologies...)
+		sort.Slice(techs, func(i, j int) bool { return strings.ToLower(techs[i]) < strings.ToLower(techs[j]) })
+		p.Projects[i].Technologies = techs
+	}
+	sort.SliceStable(p.Projects, func(i, j int) bool {
+		a, b := p.Projects[i], p.Projects[j]
+		if a.Title != b.Title {
+			return a.Title < b.Title
+		}
+		return a.Description < b.Description
+	})
+	return p
+}
+
+// withoutProvenance returns a copy of p with its lists copied and their
+// provenance cleared.
+func withoutProvenance(p CandidateProfile) CandidateProfile {
+	p.FieldSources = nil
+	p.Skills = append([]CandidateSkill(nil), p.Skills...)
+	for i := range p.Skills {
+		p.Skills[i].Source, p.Skills[i].NeedsConfirmation = "", false
+	}
+	p.WorkHistory = append([]WorkHistoryEntry(nil), p.WorkHistory...)
+	for i := range p.WorkHistory {
+		p.WorkHistory[i].Source, p.WorkHistory[i].NeedsConfirmation = "", false
+	}
+	p.Education = append([]EducationEntry(nil), p.Education...)
+	for i := range p.Education {
+		p.Education[i].Source = ""
+	}
+	p.Certifications = append([]CertificationEntry(nil), p.Certifications...)
+	for i := range p.Certifications {
+		p.Certifications[i].Source = ""
+	}
+	p.Projects = append([]ProjectEntry(nil), p.Projects...)
+	for i := range p.Projects {
+		p.Projects[i].Source = ""
+	}
+	return p
+}
@@ -0,0 +1,147 @@
+package scorer
+
+import (
+	"strings"
+	"unicode"
+)
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Skill evidence from certifications and projects
+// ─────────────────────────────────────────────────────────────────────────────
+
+const (
+	// certifiedSkillWeight is the proficiency weight of a skill backed by a
+	// certification: it counts as at least "advanced".
+	certifiedSkillWeight = 0.9
+
+	// projectSkillWeight is the proficiency weight of a skill the candidate
+	// only used in a project: it counts as "beginner".
+	projectSkillWeight = 0.5
+)
+
+// certificationSkills maps phrases found in certification names to the
+// normalized skills they certify. Phrases match whole words, case
+// insensitively.
+var certificationSkills = []struct {
+	phrase string
+	skills []string
+}{
+	{"kubernetes", []string{"kubernetes"}},
+	{"cka", []string{"kubernetes"}},
+	{"ckad", []string{"kubernetes"}},
+	{"cks", []string{"kubernetes"}},
+	{"aws", []string{"aws"}},
+	{"amazon web services", []string{"aws"}},
+	{"azure", []string{"azure"}},
+	{"google cloud", []string{"gcp"}},
+	{"gcp", []string{"gcp"}},
+	{"terraform", []string{"terraform"}},
+	{"docker", []string{"docker"}},
+	{"java", []string{"java"}},
+	{"python", []string{"python"}},
+	{"tensorflow", []string{"tensorflow"}},
+	{"mongodb", []string{"mongodb"}},
+	{"postgresql", []string{"postgresql"}},
+	{"databricks", []string{"spark"}},
+	{"snowflake", []string{"snowflake"}},
+	{"salesforce", []string{"salesforce"}},
+	{"scrum master", []string{"scrum", "agile"}},
+	{"csm", []string{"scrum", "agile"}},
+	{"psm", []string{"scrum", "agile"}},
+	{"pmp", []string{"project management"}},
+	{"cissp", []string{"security"}},
+	{"security+", []string{"security"}},
+	{"ceh", []string{"security"}},
+	{"ccna", []string{"networking"}},
+	{"rhcsa", []string{"linux"}},
+	{"rhce", []string{"linux"}},
+	{"lfcs", []string{"linux"}},
+}
+
+// CertificationSkills returns the normalized skills a certification
+// certifies, judging by its name, e.g. "kubernetes" for "Certified
+// Kubernetes Administrator (CKA)". It returns nil for an unknown
+// certification.
+func CertificationSkills(name string) []string {
+	name = strings.ToLower(name)
+	var skills []string
+	seen := map[string]bool{}
+	for _, c := range certificationSkills {
+		if !containsPhrase(name, c.phrase) {
+			continue
+		}
+		for _, s := range c.skills {
+			if !seen[s] {
+				seen[s] = true
+				skills = append(skills, s)
+			}
+		}
+	}
+	return skills
+}
+
+// addCertifiedSkills raises every skill certified by the candidate's
+// certifications to certifiedSkillWeight in index, adding the skills the
+// candidate does not list.
+func addCertifiedSkills(index map[string]indexedSkill, certs []CertificationEntry) {
+	for _, c := range certs {
+		for _, skill := range CertificationSkills(c.Name) {
+			listed := false
+			for norm, s := range index {
+				if norm != skill && !skillsAreAliases(skill, norm) {
+					continue
+				}
+				listed = true
+				if s.weight < certifiedSkillWeight {
+					s.weight, s.evidence, s.source = certifiedSkillWeight, "certification", c.Name
+					index[norm] = s
+				}
+			}
+			if !listed {
+				index[skill] = indexedSkill{name: skill, weight: certifiedSkillWeight, evidence: "certification", source: c.Name}
+			}
+		}
+	}
+}
+
+// addProjectSkills adds the technologies of the candidate's projects that
+// match no skill in index, at projectSkillWeight.
+func addProjectSkills(index map[string]indexedSkill, projects []ProjectEntry) {
+	for _, p := range projects {
+		for _, tech := range p.Technologies {
+			norm := normalizeSkillName(tech)
+			if norm == "" {
+				continue
+			}
+			if _, _, found := lookupSkill(norm, index); found {
+				continue
+			}
+			index[norm] = indexedSkill{name: tech, weight: projectSkillWeight, evidence: "project", source: p.Title}
+		}
+	}
+}
+
+// containsPhrase reports whether text contains phrase as whole words: not
+// directly preceded or followed by a letter or digit.
+func containsPhrase(text, phrase string) bool {
+	for from := 0; ; {
+		i := strings.Index(text[from:], phrase)
+		if i < 0 {
+			return false
+		}
+		start, end := from+i, from+i+len(phrase)
+		if !wordCharAt(text, start-1) && !wordCharAt(text, end) {
+			return true
+		}
+		from = start + 1
+	}
+}
+
+// wordCharAt reports whether the byte at i in s is a letter or digit.
+func wordCharAt(s string, i int) bool {
+	if i < 0 || i >= len(s) {
+		return false
+	}
+	r := rune(s[i])
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
@@ -0,0 +1,97 @@
+package scorer
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCertificationSkills(t *testing.T) {
+	tests := []struct {
+		name string
+		want []string
+	}{
+		{"Certified Kubernetes Administrator (CKA)", []string{"kubernetes"}},
+		{"AWS Certified Solutions Architect – Associate", []string{"aws"}},
+		{"Certified ScrumMaster (CSM)", []string{"scrum", "agile"}},
+		{"CompTIA Security+", []string{"security"}},
+		// Phrases match whole words only.
+		{"Javascript Fundamentals", nil},
+		{"First Aid", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CertificationSkills(tt.name); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("CertificationSkills(%q) = %v, want %v", tt.name, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestScoreSkillMatch_CertificationBoostsListedSkill(t *testing.T) {
+	job := JobRequirements{RequiredSkills: []string{"Kubernetes"}}
+	listed := CandidateProfile{Skills: []CandidateSkill{{Name: "k8s", Proficiency: "beginner"}}}
+	certified := listed
+	certified.Certifications = []CertificationEntry{{Name: "Certified Kubernetes Administrator", Issuer: "CNCF", Year: 2024}}
+
+	base, _ := scoreSkillMatch(listed, job, ScoringWeights{})
+	boosted, exp := scoreSkillMatch(certified, job, ScoringWeights{})
+
+	// Required skills make up 80% of the score.
+	if !approxEqual(boosted, certifiedSkillWeight*0.80, 1e-9) || boosted <= base {
+		t.Errorf("expected certified score %.2f above %.2f, got %.4f", certifiedSkillWeight*0.80, base, boosted)
+	}
+	c := exp.RequiredSkills[0]
+	if c.Evidence != "certification" || c.Source != "Certified Kubernetes Administrator" || c.MatchedAs != "k8s" {
+		t.Errorf("unexpected contribution %+v", c)
+	}
+}
+
+func TestScoreSkillMatch_CertificationKeepsHigherProficiency(t *testing.T) {
+	profile := CandidateProfile{
+		Skills:         []CandidateSkill{{Name: "AWS", Proficiency: "expert"}},
+		Certifications: []CertificationEntry{{Name: "AWS Certified Cloud Practitioner"}},
+	}
+	score, exp := scoreSkillMatch(profile, JobRequirements{RequiredSkills: []string{"AWS"}}, ScoringWeights{})
+
+	if !approxEqual(score, 0.80, 1e-9) || exp.RequiredSkills[0].Evidence != "skill" {
+		t.Errorf("expected the expert skill to stand, got %.4f %+v", score, exp.RequiredSkills[0])
+	}
+}
+
+func TestScoreSkillMatch_CertificationOnly(t *testing.T) {
+	profile := CandidateProfile{Certifications: []CertificationEntry{{Name: "HashiCorp Certified: Terraform Associate"}}}
+	score, exp := scoreSkillMatch(profile, JobRequirements{RequiredSkills: []string{"Terraform"}}, ScoringWeights{})
+
+	if !approxEqual(score, certifiedSkillWeight*0.80, 1e-9) {
+		t.Errorf("expected score %.2f, got %.4f", certifiedSkillWeight*0.80, score)
+	}
+	if c := exp.RequiredSkills[0]; !c.Matched || c.Evidence != "certification" {
+		t.Errorf("unexpected contribution %+v", c)
+	}
+}
+
+func TestScoreSkillMatch_ProjectTechnologiesAreWeakEvidence(t *testing.T) {
+	profile := CandidateProfile{
+		Skills: []CandidateSkill{{Name: "Python", Proficiency: "intermediate"}},
+		Projects: []ProjectEntry{{
+			Title:        "Chat bot",
+			Technologies: []string{"Python", "Redis"},
+		}},
+	}
+	job := JobRequirements{RequiredSkills: []string{"Python", "Redis"}}
+
+	score, exp := scoreSkillMatch(profile, job, ScoringWeights{})
+
+	// Python keeps its listed proficiency; Redis only counts as beginner.
+	want := (DefaultProficiencyScale().Resolve("intermediate").Weight + projectSkillWeight) / 2 * 0.80
+	if !approxEqual(score, want, 1e-9) {
+		t.Errorf("expected score %.4f, got %.4f", want, score)
+	}
+	python, redis := exp.RequiredSkills[0], exp.RequiredSkills[1]
+	if python.Evidence != "skill" || python.Source != "" {
+		t.Errorf("unexpected Python contribution %+v", python)
+	}
+	if redis.Evidence != "project" || redis.Source != "Chat bot" || redis.ProficiencyMultiplier != projectSkillWeight {
+		t.Errorf("unexpected Redis contribution %+v", redis)
+	}
+}
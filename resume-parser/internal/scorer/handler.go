@@ -4,26 +4,100 @@ package scorer
 
 import (
 	"encoding/json"
+	"fmt"
 	"log"
 	"net/http"
+	"strconv"
 	"time"
+
+	"github.com/learnbot/resume-parser/internal/quality"
+	"github.com/learnbot/resume-parser/internal/resultcache"
+	"github.com/learnbot/shared/openapi"
+	"github.com/learnbot/shared/validation"
 )
 
+// DefaultMaxBatchSize is the default maximum number of candidates accepted
+// by a single batch scoring request.
+const DefaultMaxBatchSize = 500
+
 // Handler holds the HTTP handler dependencies for the scoring API.
 type Handler struct {
-	logger *log.Logger
+	logger       *log.Logger
+	quality      *quality.Tracker
+	maxBatchSize int
+	extract      RequirementsExtractor
+	cache        *resultcache.Cache[ScoreBreakdown]
+	validation   validation.Config
 }
 
 // NewHandler creates a new scoring Handler.
 func NewHandler(logger *log.Logger) *Handler {
-	return &Handler{logger: logger}
+	return &Handler{logger: logger, maxBatchSize: DefaultMaxBatchSize}
+}
+
+// SetQualityTracker enables recording of scoring requests that produce
+// consistency warnings. A nil tracker disables recording.
+func (h *Handler) SetQualityTracker(t *quality.Tracker) {
+	h.quality = t
+}
+
+// SetMaxBatchSize sets the maximum number of candidates accepted by
+// BatchScoreHandler. Values below 1 restore DefaultMaxBatchSize.
+func (h *Handler) SetMaxBatchSize(n int) {
+	if n < 1 {
+		n = DefaultMaxBatchSize
+	}
+	h.maxBatchSize = n
+}
+
+// SetRequirementsExtractor enables the job_description request field,
+// using extract to turn the description into job requirements.
+func (h *Handler) SetRequirementsExtractor(extract RequirementsExtractor) {
+	h.extract = extract
+}
+
+// SetResultCache makes ScoreHandler reuse the breakdowns of recent
+// identical requests from c. A nil cache disables caching.
+func (h *Handler) SetResultCache(c *resultcache.Cache[ScoreBreakdown]) {
+	h.cache = c
+}
+
+// SetValidationConfig sets how request bodies are decoded. By default
+// unknown fields are rejected.
+func (h *Handler) SetValidationConfig(cfg validation.Config) {
+	h.validation = cfg
 }
 
 // RegisterRoutes registers the scoring routes on the given mux.
 //
-//	POST /api/v1/score  – calculate acceptance likelihood score
-func (h *Handler) RegisterRoutes(mux *http.ServeMux) {
+//	POST /api/v1/score        – calculate acceptance likelihood score
+//	POST /api/v1/score/batch  – score many candidates against one job
+//
+// Both accept ?verbose=true to include each score's Explanation.
+func (h *Handler) RegisterRoutes(mux openapi.Router) {
 	mux.HandleFunc("/api/v1/score", h.withMiddleware(h.ScoreHandler))
+	mux.HandleFunc("/api/v1/score/batch", h.withMiddleware(h.BatchScoreHandler))
+}
+
+// DescribeRoutes documents the routes registered by RegisterRoutes.
+func (h *Handler) DescribeRoutes(spec *openapi.Spec) {
+	verbose := openapi.Query("verbose", "Explain each score component with per-skill contributions (true/false)")
+	spec.Route("/api/v1/score", openapi.Operation{
+		Method:   http.MethodPost,
+		Summary:  "Score a candidate against a job",
+		Params:   []openapi.Param{verbose, resultcache.NoCacheParam},
+		Request:  ScoreRequest{},
+		Response: ScoreResponse{},
+		Errors:   []int{http.StatusBadRequest, http.StatusUnsupportedMediaType, http.StatusUnprocessableEntity},
+	})
+	spec.Route("/api/v1/score/batch", openapi.Operation{
+		Method:   http.MethodPost,
+		Summary:  "Score and rank candidates against one job",
+		Params:   []openapi.Param{verbose},
+		Request:  BatchScoreRequest{},
+		Response: BatchScoreResponse{},
+		Errors:   []int{http.StatusBadRequest, http.StatusUnsupportedMediaType, http.StatusUnprocessableEntity},
+	})
 }
 
 // withMiddleware wraps a handler with logging and panic recovery.
@@ -49,9 +123,16 @@ func (h *Handler) withMiddleware(next http.HandlerFunc) http.HandlerFunc {
 //
 //	{
 //	  "profile": { ... CandidateProfile ... },
-//	  "job":     { ... JobRequirements  ... }
+//	  "job":     { ... JobRequirements  ... },
+//	  "weights": { "skill_match": 0.5, "education_match": 0, ... }
 //	}
 //
+// weights is optional and overrides the default component weights; weights
+// that do not sum to 1 are renormalized with a warning, and negative or
+// all-zero weights are rejected with 422. Its proficiency_scale replaces
+// the default proficiency levels; an invalid one is rejected with 422 too.
+// The breakdown echoes the weights used.
+//
 // Response body (JSON):
 //
 //	{
@@ -59,6 +140,13 @@ func (h *Handler) withMiddleware(next http.HandlerFunc) http.HandlerFunc {
 //	  "data": { ... ScoreBreakdown ... }
 //	}
 //
+// With ?verbose=true the breakdown includes its "explanation": the match,
+// proficiency multiplier and contribution of every job skill, and the
+// inputs behind the other component scores.
+//
+// With a result cache, a repeated request is answered from the cache; the
+// X-No-Cache request header bypasses it.
+//
 // Example curl:
 //
 //	curl -X POST http://localhost:8080/api/v1/score \
@@ -71,23 +159,29 @@ func (h *Handler) ScoreHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if ct := r.Header.Get("Content-Type"); ct != "" &&
-		len(ct) >= 16 && ct[:16] != "application/json" {
-		h.writeError(w, http.StatusUnsupportedMediaType,
-			"Content-Type must be application/json")
+	var req ScoreRequest
+	if !h.decodeRequest(w, r, &req) {
 		return
 	}
-
-	var req ScoreRequest
-	dec := json.NewDecoder(r.Body)
-	dec.DisallowUnknownFields()
-	if err := dec.Decode(&req); err != nil {
-		h.writeError(w, http.StatusBadRequest,
-			"invalid request body: "+err.Error())
+	if !h.resolveJob(w, &req.Job, req.JobDescription) {
 		return
 	}
 
-	breakdown := Calculate(req.Profile, req.Job)
+	weights := weightsOrDefault(req.Weights)
+	key := resultcache.Key(CanonicalProfile(req.Profile), req.Job, weights)
+	breakdown, err := resultcache.Serve(h.cache, w, r, key, func() (ScoreBreakdown, error) {
+		return CalculateWithWeights(req.Profile, req.Job, weights)
+	})
+	if err != nil {
+		h.writeError(w, http.StatusUnprocessableEntity, err.Error())
+		return
+	}
+	if len(breakdown.Warnings) > 0 {
+		h.quality.Record(quality.CheckScoringInconsistencies, req.Job.Title)
+	}
+	if !verbose(r) {
+		breakdown.Explanation = nil
+	}
 
 	h.writeJSON(w, http.StatusOK, ScoreResponse{
 		Success: true,
@@ -95,6 +189,140 @@ func (h *Handler) ScoreHandler(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+// BatchScoreHandler handles POST /api/v1/score/batch.
+//
+// Request body (JSON):
+//
+//	{
+//	  "job":        { ... JobRequirements ... },
+//	  "candidates": [
+//	    { "candidate_id": "c-1", "profile": { ... CandidateProfile ... } }
+//	  ]
+//	}
+//
+// Response body (JSON), one result per candidate in request order:
+//
+//	{
+//	  "success": true,
+//	  "data": [
+//	    { "index": 0, "candidate_id": "c-1", "rank": 1, "score": { ... ScoreBreakdown ... } }
+//	  ]
+//	}
+//
+// With ?verbose=true each score includes its explanation. Requests with no
+// candidates, or with more than the configured maximum batch size, are
+// rejected with 422.
+func (h *Handler) BatchScoreHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		h.writeError(w, http.StatusMethodNotAllowed,
+			"only POST is supported")
+		return
+	}
+
+	var req BatchScoreRequest
+	if !h.decodeRequest(w, r, &req) {
+		return
+	}
+	if !h.resolveJob(w, &req.Job, req.JobDescription) {
+		return
+	}
+
+	switch {
+	case len(req.Candidates) == 0:
+		h.writeError(w, http.StatusUnprocessableEntity,
+			"candidates must not be empty")
+		return
+	case len(req.Candidates) > h.maxBatchSize:
+		h.writeError(w, http.StatusUnprocessableEntity,
+			fmt.Sprintf("too many candidates: %d exceeds the maximum batch size of %d",
+				len(req.Candidates), h.maxBatchSize))
+		return
+	}
+
+	profiles := make([]CandidateProfile, len(req.Candidates))
+	for i, c := range req.Candidates {
+		profiles[i] = c.Profile
+	}
+
+	results, err := CalculateBatchWithWeights(profiles, req.Job, weightsOrDefault(req.Weights))
+	if err != nil {
+		h.writeError(w, http.StatusUnprocessableEntity, err.Error())
+		return
+	}
+	explain := verbose(r)
+	for i := range results {
+		results[i].CandidateID = req.Candidates[i].CandidateID
+		if len(results[i].Score.Warnings) > 0 {
+			h.quality.Record(quality.CheckScoringInconsistencies, req.Job.Title)
+		}
+		if !explain {
+			results[i].Score.Explanation = nil
+		}
+	}
+
+	h.writeJSON(w, http.StatusOK, BatchScoreResponse{
+		Success: true,
+		Data:    results,
+	})
+}
+
+// weightsOrDefault returns the request's scoring weights, or the defaults
+// if it has none.
+func weightsOrDefault(w *ScoringWeights) ScoringWeights {
+	if w == nil {
+		return DefaultScoringWeights()
+	}
+	return *w
+}
+
+// verbose reports whether the request asks for score explanations with
+// ?verbose=true.
+func verbose(r *http.Request) bool {
+	v, _ := strconv.ParseBool(r.URL.Query().Get("verbose"))
+	return v
+}
+
+// decodeRequest validates the content type, decodes the JSON body into v and
+// checks its validation rules, writing an error response and returning false
+// on failure.
+func (h *Handler) decodeRequest(w http.ResponseWriter, r *http.Request, v interface{}) bool {
+	if ct := r.Header.Get("Content-Type"); ct != "" &&
+		len(ct) >= 16 && ct[:16] != "application/json" {
+		h.writeError(w, http.StatusUnsupportedMediaType,
+			"Content-Type must be application/json")
+		return false
+	}
+
+	if err := h.validation.Decode(r.Body, v); err != nil {
+		if errs, ok := validation.AsErrors(err); ok {
+			h.writeJSON(w, http.StatusUnprocessableEntity, ScoreResponse{
+				Success: false,
+				Error:   "request validation failed",
+				Errors:  errs,
+			})
+			return false
+		}
+		h.writeError(w, http.StatusBadRequest,
+			"invalid request body: "+err.Error())
+		return false
+	}
+	return true
+}
+
+// resolveJob replaces *job with the requirements resolved from it and the
+// request's job description, writing an error response and returning false
+// on failure.
+func (h *Handler) resolveJob(w http.ResponseWriter, job *JobRequirements, description string) bool {
+	resolved, err := ResolveJob(*job, description, h.extract)
+	if err != nil {
+		h.writeError(w, http.StatusBadRequest,
+			"invalid job_description: "+err.Error())
+		return false
+	}
+	*job = resolved
+	return true
+}
+
 // writeJSON serialises v as JSON and writes it to the response.
 func (h *Handler) writeJSON(w http.ResponseWriter, status int, v interface{}) {
 	w.Header().Set("Content-Type", "application/json")
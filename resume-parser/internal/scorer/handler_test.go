@@ -3,12 +3,20 @@ package scorer
 import (
 	"bytes"
 	"encoding/json"
+	"io"
 	"log"
 	"net/http"
 	"net/http/httptest"
 	"os"
 	"strings"
+	"sync"
 	"testing"
+	"time"
+
+	"github.com/learnbot/resume-parser/internal/resultcache"
+	"github.com/learnbot/shared/metrics"
+	"github.com/learnbot/shared/openapi"
+	"github.com/learnbot/shared/validation"
 )
 
 // buildTestScorerHandler creates a Handler for testing.
@@ -71,6 +79,47 @@ func TestScoreHandler_InvalidJSON(t *testing.T) {
 	}
 }
 
+func TestScoreHandler_Validation(t *testing.T) {
+	tests := []struct {
+		name       string
+		body       string
+		allowExtra bool
+		want       int
+		fields     []string
+	}{
+		{"valid", `{"profile":{"skills":[{"name":"Go"}]},"job":{"required_skills":["Go"]}}`, false, http.StatusOK, nil},
+		{"unnamed skill", `{"profile":{"skills":[{"name":"Go"},{"proficiency":"expert"}]},"job":{}}`,
+			false, http.StatusUnprocessableEntity, []string{"profile.skills[1].name"}},
+		{"negative years", `{"profile":{"years_of_experience":-1},"job":{"min_years_experience":-2}}`,
+			false, http.StatusUnprocessableEntity, []string{"profile.years_of_experience", "job.min_years_experience"}},
+		{"wrong type", `{"profile":{"years_of_experience":"five"},"job":{}}`,
+			false, http.StatusUnprocessableEntity, []string{"profile.years_of_experience"}},
+		{"unknown field", `{"profile":{},"job":{},"jbo":{}}`, false, http.StatusUnprocessableEntity, []string{"jbo"}},
+		{"unknown field allowed", `{"profile":{},"job":{},"jbo":{}}`, true, http.StatusOK, nil},
+	}
+	for _, tt := range tests {
+		h := buildTestScorerHandler()
+		h.SetValidationConfig(validation.Config{AllowUnknownFields: tt.allowExtra})
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/score", strings.NewReader(tt.body))
+		w := httptest.NewRecorder()
+		h.ScoreHandler(w, req)
+
+		if w.Code != tt.want {
+			t.Errorf("%s: expected %d, got %d: %s", tt.name, tt.want, w.Code, w.Body.String())
+			continue
+		}
+		var resp ScoreResponse
+		json.NewDecoder(w.Body).Decode(&resp)
+		var fields []string
+		for _, fe := range resp.Errors {
+			fields = append(fields, fe.Field)
+		}
+		if strings.Join(fields, ",") != strings.Join(tt.fields, ",") {
+			t.Errorf("%s: invalid fields %v, want %v", tt.name, fields, tt.fields)
+		}
+	}
+}
+
 func TestScoreHandler_ValidRequest(t *testing.T) {
 	h := buildTestScorerHandler()
 
@@ -196,6 +245,43 @@ func TestScoreHandler_ResponseContainsBreakdown(t *testing.T) {
 	mustContain(t, resp.Data.MissingRequiredSkills, "Java")
 }
 
+func TestScoreHandler_Verbose(t *testing.T) {
+	h := buildTestScorerHandler()
+	scoreReq := ScoreRequest{
+		Profile: CandidateProfile{Skills: []CandidateSkill{{Name: "k8s", Proficiency: "advanced"}}},
+		Job:     JobRequirements{RequiredSkills: []string{"Kubernetes", "Java"}},
+	}
+
+	for _, tt := range []struct {
+		target  string
+		explain bool
+	}{
+		{"/api/v1/score", false},
+		{"/api/v1/score?verbose=false", false},
+		{"/api/v1/score?verbose=true", true},
+	} {
+		req := httptest.NewRequest(http.MethodPost, tt.target, buildScoreRequest(t, scoreReq))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		h.ScoreHandler(w, req)
+
+		var resp ScoreResponse
+		if err := json.NewDecoder(w.Body).Decode(&resp); err != nil || resp.Data == nil {
+			t.Fatalf("%s: failed to decode response: %v", tt.target, err)
+		}
+		if got := resp.Data.Explanation != nil; got != tt.explain {
+			t.Errorf("%s: explanation present = %v, want %v", tt.target, got, tt.explain)
+			continue
+		}
+		if !tt.explain {
+			continue
+		}
+		if c := resp.Data.Explanation.SkillMatch.RequiredSkills[0]; c.MatchedAs != "k8s" || c.MatchType != "alias" {
+			t.Errorf("%s: unexpected Kubernetes contribution %+v", tt.target, c)
+		}
+	}
+}
+
 func TestScoreHandler_ContentTypeJSON(t *testing.T) {
 	h := buildTestScorerHandler()
 
@@ -229,6 +315,65 @@ func TestRegisterScorerRoutes(t *testing.T) {
 	}
 }
 
+func TestScoreHandler_JobDescription(t *testing.T) {
+	h := buildTestScorerHandler()
+	body := `{"profile":{"skills":[{"name":"Go"}]},"job":{"title":"Go Developer"},"job_description":"Go, Docker"}`
+
+	// Without an extractor the field is rejected.
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/score", strings.NewReader(body))
+	w := httptest.NewRecorder()
+	h.ScoreHandler(w, req)
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected 400 without an extractor, got %d", w.Code)
+	}
+
+	var described string
+	h.SetRequirementsExtractor(func(description string) (JobRequirements, error) {
+		described = description
+		return JobRequirements{Title: "Extracted", RequiredSkills: strings.Split(description, ", ")}, nil
+	})
+	req = httptest.NewRequest(http.MethodPost, "/api/v1/score", strings.NewReader(body))
+	w = httptest.NewRecorder()
+	h.ScoreHandler(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var resp ScoreResponse
+	json.NewDecoder(w.Body).Decode(&resp)
+	if described != "Go, Docker" {
+		t.Errorf("expected the description to be extracted, got %q", described)
+	}
+	// One of the two extracted required skills matches.
+	if resp.Data == nil || len(resp.Data.MatchedRequiredSkills) != 1 || len(resp.Data.MissingRequiredSkills) != 1 {
+		t.Errorf("expected the extracted skills to be scored, got %+v", resp.Data)
+	}
+}
+
+func TestResolveJob(t *testing.T) {
+	extract := func(string) (JobRequirements, error) {
+		return JobRequirements{Title: "Extracted", RequiredSkills: []string{"Go"}, MinYearsExperience: 5, LocationType: "remote"}, nil
+	}
+
+	job, err := ResolveJob(JobRequirements{Title: "Platform Engineer", MinYearsExperience: 3}, "...", extract)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if job.Title != "Platform Engineer" || job.MinYearsExperience != 3 {
+		t.Errorf("expected structured fields to win, got %+v", job)
+	}
+	if len(job.RequiredSkills) != 1 || job.LocationType != "remote" {
+		t.Errorf("expected extracted fields to fill the gaps, got %+v", job)
+	}
+
+	structured := JobRequirements{Title: "As sent"}
+	if job, err := ResolveJob(structured, "", nil); err != nil || job.Title != "As sent" {
+		t.Errorf("expected structured requirements to pass through, got %+v, %v", job, err)
+	}
+	if _, err := ResolveJob(structured, "text", nil); err != ErrDescriptionUnsupported {
+		t.Errorf("expected ErrDescriptionUnsupported, got %v", err)
+	}
+}
+
 // ─────────────────────────────────────────────────────────────────────────────
 // Benchmarks
 // ─────────────────────────────────────────────────────────────────────────────
@@ -341,6 +486,122 @@ func BenchmarkCalculate_MinimalInput(b *testing.B) {
 	}
 }
 
+// ─────────────────────────────────────────────────────────────────────────────
+// Result cache
+// ─────────────────────────────────────────────────────────────────────────────
+
+func TestScoreHandler_ResultCache(t *testing.T) {
+	h := buildTestScorerHandler()
+	h.SetResultCache(resultcache.New[ScoreBreakdown]("score_test", 10, time.Minute))
+	post := func(body string, header ...string) *httptest.ResponseRecorder {
+		t.Helper()
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/score", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		if len(header) == 2 {
+			req.Header.Set(header[0], header[1])
+		}
+		w := httptest.NewRecorder()
+		h.ScoreHandler(w, req)
+		if w.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+		}
+		return w
+	}
+	job := `"job":{"title":"Engineer","required_skills":["Go","SQL"],"preferred_skills":["Docker"]}`
+
+	first := post(`{"profile":{"skills":[{"name":"Go"},{"name":"SQL","proficiency":"beginner"}]},` + job + `}`)
+	// The same skills in another order hit the same entry.
+	reordered := post(`{"profile":{"skills":[{"name":"SQL","proficiency":"beginner"},{"name":"Go"}]},` + job + `}`)
+	bypassed := post(`{"profile":{"skills":[{"name":"Go"},{"name":"SQL","proficiency":"beginner"}]},`+job+`}`,
+		resultcache.NoCacheHeader, "true")
+
+	for _, tt := range []struct {
+		name string
+		w    *httptest.ResponseRecorder
+		want string
+	}{{"first", first, "MISS"}, {"reordered", reordered, "HIT"}, {"bypassed", bypassed, "BYPASS"}} {
+		if got := tt.w.Header().Get(resultcache.StatusHeader); got != tt.want {
+			t.Errorf("%s request: %s = %q, want %q", tt.name, resultcache.StatusHeader, got, tt.want)
+		}
+		if tt.w.Body.String() != first.Body.String() {
+			t.Errorf("%s request: body differs from the first response", tt.name)
+		}
+	}
+	if got := first.Header().Get("Cache-Control"); got != "private, max-age=60" {
+		t.Errorf("Cache-Control = %q", got)
+	}
+}
+
+func TestScoreHandler_ParallelIdenticalRequests(t *testing.T) {
+	h := buildTestScorerHandler()
+	h.SetResultCache(resultcache.New[ScoreBreakdown]("score_parallel_test", 10, time.Minute))
+	body := `{"profile":{"skills":[{"name":"Rust"}],"years_of_experience":3},"job":{"title":"Systems Engineer","required_skills":["Rust","C++"]}}`
+
+	before := scoringInvocations.Value()
+	const n = 20
+	var wg sync.WaitGroup
+	bodies := make([]string, n)
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/score", strings.NewReader(body))
+			w := httptest.NewRecorder()
+			h.ScoreHandler(w, req)
+			if w.Code == http.StatusOK {
+				bodies[i] = w.Body.String()
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	if got := scoringInvocations.Value() - before; got != 1 {
+		t.Errorf("scored %v times, want 1", got)
+	}
+	for i, b := range bodies {
+		if b == "" || b != bodies[0] {
+			t.Fatalf("response %d differs or failed: %q", i, b)
+		}
+	}
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Metrics
+// ─────────────────────────────────────────────────────────────────────────────
+
+func TestScoreHandler_Metrics(t *testing.T) {
+	mux := http.NewServeMux()
+	buildTestScorerHandler().RegisterRoutes(mux)
+	mux.Handle("/metrics", metrics.Handler())
+	srv := httptest.NewServer(metrics.Instrument(mux))
+	defer srv.Close()
+
+	body := `{"profile":{"skills":[{"name":"Go"}]},"job":{"title":"Engineer","required_skills":["Go"]}}`
+	for i := 0; i < 3; i++ {
+		resp, err := http.Post(srv.URL+"/api/v1/score", "application/json", strings.NewReader(body))
+		if err != nil {
+			t.Fatalf("POST /api/v1/score: %v", err)
+		}
+		resp.Body.Close()
+	}
+
+	resp, err := http.Get(srv.URL + "/metrics")
+	if err != nil {
+		t.Fatalf("GET /metrics: %v", err)
+	}
+	defer resp.Body.Close()
+	scraped, _ := io.ReadAll(resp.Body)
+	for _, want := range []string{
+		"# TYPE scoring_invocations_total counter",
+		`http_requests_total{method="POST",route="/api/v1/score",status="200"} 3`,
+		"# TYPE http_request_duration_seconds histogram",
+	} {
+		if !strings.Contains(string(scraped), want) {
+			t.Errorf("metrics output missing %q", want)
+		}
+	}
+}
+
 // BenchmarkScoreHandler_HTTP benchmarks the full HTTP handler path.
 func BenchmarkScoreHandler_HTTP(b *testing.B) {
 	h := buildTestScorerHandler()
@@ -380,3 +641,44 @@ func BenchmarkScoreHandler_HTTP(b *testing.B) {
 		h.ScoreHandler(w, req)
 	}
 }
+
+func TestDescribeRoutes(t *testing.T) {
+	h := buildTestScorerHandler()
+	if err := openapi.CheckRoutes(h.RegisterRoutes, h.DescribeRoutes); err != nil {
+		t.Fatal(err)
+	}
+
+	spec := openapi.New("test", "1.0.0")
+	h.DescribeRoutes(spec)
+	raw, err := spec.JSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var doc struct {
+		Components struct {
+			Schemas map[string]struct {
+				Properties map[string]json.RawMessage `json:"properties"`
+			} `json:"schemas"`
+		} `json:"components"`
+	}
+	if err := json.Unmarshal(raw, &doc); err != nil {
+		t.Fatal(err)
+	}
+	want := map[string][]string{
+		"ScoreRequest":     {"profile", "job", "job_description", "weights"},
+		"CandidateProfile": {"skills", "years_of_experience", "work_history"},
+		"ScoreBreakdown":   {"overall_score", "skill_match_score"},
+	}
+	for name, props := range want {
+		s, ok := doc.Components.Schemas[name]
+		if !ok {
+			t.Errorf("missing schema %s", name)
+			continue
+		}
+		for _, p := range props {
+			if _, ok := s.Properties[p]; !ok {
+				t.Errorf("schema %s: missing property %s", name, p)
+			}
+		}
+	}
+}
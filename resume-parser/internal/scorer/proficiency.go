@@ -0,0 +1,200 @@
+package scorer
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// ProficiencyLevel is one level of a ProficiencyScale.
+type ProficiencyLevel struct {
+	// Name is the level's label, matched case-insensitively against
+	// candidate proficiencies.
+	Name string `json:"name"`
+
+	// Weight is the skill match multiplier [0, 1] of a skill at this level.
+	Weight float64 `json:"weight"`
+}
+
+// ProficiencyScale is the rubric proficiencies are scored on: ordered
+// levels with their weights, lowest first. A proficiency is a level name or
+// a number, which is placed on the scale between NumericMin and NumericMax;
+// a number between two levels earns a weight between theirs. A name not on
+// the scale is scored as DefaultLevel, with a warning.
+type ProficiencyScale struct {
+	// Levels are the levels from lowest to highest. Their weights must
+	// increase.
+	Levels []ProficiencyLevel `json:"levels"`
+
+	// UnspecifiedWeight is the weight of a skill listed without a
+	// proficiency.
+	UnspecifiedWeight float64 `json:"unspecified_weight"`
+
+	// DefaultLevel is the level a proficiency not on the scale is scored
+	// as; empty means the lowest level.
+	DefaultLevel string `json:"default_level,omitempty"`
+
+	// NumericMin and NumericMax are the range numeric proficiencies are
+	// given in, e.g. 1 and 10; both 0 means 1–10. NumericMin maps to the
+	// lowest level and NumericMax to the highest.
+	NumericMin float64 `json:"numeric_min,omitempty"`
+	NumericMax float64 `json:"numeric_max,omitempty"`
+}
+
+// ErrInvalidProficiencyScale is returned for a proficiency scale that
+// cannot be used; the error names the problem.
+var ErrInvalidProficiencyScale = errors.New("invalid proficiency scale")
+
+// DefaultProficiencyScale returns the beginner–expert scale used by
+// Calculate and gap analysis.
+func DefaultProficiencyScale() ProficiencyScale {
+	return ProficiencyScale{
+		Levels: []ProficiencyLevel{
+			{Name: "beginner", Weight: 0.5},
+			{Name: "intermediate", Weight: 0.75},
+			{Name: "advanced", Weight: 0.9},
+			{Name: "expert", Weight: 1.0},
+		},
+		UnspecifiedWeight: 0.7, // assume intermediate-ish
+		DefaultLevel:      "beginner",
+		NumericMin:        1,
+		NumericMax:        10,
+	}
+}
+
+// Validate reports whether the scale can be used: it needs at least one
+// level, level names must be unique and not numbers, weights must be in
+// [0, 1] and increase with the level, DefaultLevel must be a level and
+// NumericMax must exceed NumericMin.
+func (s ProficiencyScale) Validate() error {
+	if len(s.Levels) == 0 {
+		return fmt.Errorf("%w: no levels", ErrInvalidProficiencyScale)
+	}
+	seen := make(map[string]bool, len(s.Levels))
+	for i, l := range s.Levels {
+		name := normalizeLevel(l.Name)
+		switch {
+		case name == "":
+			return fmt.Errorf("%w: level %d has no name", ErrInvalidProficiencyScale, i+1)
+		case seen[name]:
+			return fmt.Errorf("%w: duplicate level %q", ErrInvalidProficiencyScale, l.Name)
+		case isNumeric(name):
+			return fmt.Errorf("%w: level name %q is a number", ErrInvalidProficiencyScale, l.Name)
+		case l.Weight < 0 || l.Weight > 1:
+			return fmt.Errorf("%w: weight of %q must be between 0 and 1", ErrInvalidProficiencyScale, l.Name)
+		case i > 0 && l.Weight <= s.Levels[i-1].Weight:
+			return fmt.Errorf("%w: weight of %q must be above that of %q", ErrInvalidProficiencyScale, l.Name, s.Levels[i-1].Name)
+		}
+		seen[name] = true
+	}
+	if s.UnspecifiedWeight < 0 || s.UnspecifiedWeight > 1 {
+		return fmt.Errorf("%w: unspecified_weight must be between 0 and 1", ErrInvalidProficiencyScale)
+	}
+	if s.DefaultLevel != "" && s.levelIndex(s.DefaultLevel) < 0 {
+		return fmt.Errorf("%w: default level %q is not a level", ErrInvalidProficiencyScale, s.DefaultLevel)
+	}
+	if low, high := s.numericRange(); high <= low {
+		return fmt.Errorf("%w: numeric_max must be above numeric_min", ErrInvalidProficiencyScale)
+	}
+	return nil
+}
+
+// ResolvedProficiency is a proficiency placed on a ProficiencyScale.
+type ResolvedProficiency struct {
+	// Level is the name of the level the proficiency is at, the nearest
+	// one for a number; empty for an unspecified proficiency.
+	Level string
+
+	// Rank is the 1-based position of Level on the scale; 0 for an
+	// unspecified proficiency.
+	Rank int
+
+	// Weight is the proficiency's skill match multiplier.
+	Weight float64
+
+	// Warning explains a proficiency that was not taken as given: a name
+	// not on the scale or a number out of range.
+	Warning string
+}
+
+// Resolve places the proficiency p on the scale. The scale must be valid.
+func (s ProficiencyScale) Resolve(p string) ResolvedProficiency {
+	norm := normalizeLevel(p)
+	if norm == "" {
+		return ResolvedProficiency{Weight: s.UnspecifiedWeight}
+	}
+	if i := s.levelIndex(norm); i >= 0 {
+		return s.at(i)
+	}
+	if v, err := strconv.ParseFloat(norm, 64); err == nil && !math.IsNaN(v) && !math.IsInf(v, 0) {
+		return s.resolveNumeric(v)
+	}
+	i := 0
+	if s.DefaultLevel != "" {
+		i = s.levelIndex(s.DefaultLevel)
+	}
+	r := s.at(i)
+	r.Warning = fmt.Sprintf("proficiency %q is not on the proficiency scale; it was scored as %q", p, r.Level)
+	return r
+}
+
+// Rank returns the 1-based position of proficiency p on the scale, or 0 for
+// an unspecified proficiency.
+func (s ProficiencyScale) Rank(p string) int {
+	return s.Resolve(p).Rank
+}
+
+// resolveNumeric places the number v on the scale, interpolating the
+// weight between the levels around it.
+func (s ProficiencyScale) resolveNumeric(v float64) ResolvedProficiency {
+	low, high := s.numericRange()
+	var warning string
+	if v < low || v > high {
+		warning = fmt.Sprintf("proficiency %g is outside %g–%g; it was scored as %g",
+			v, low, high, math.Max(low, math.Min(high, v)))
+	}
+	pos := math.Max(0, math.Min(1, (v-low)/(high-low))) * float64(len(s.Levels)-1)
+	below, above := int(math.Floor(pos)), int(math.Ceil(pos))
+	r := s.at(int(math.Round(pos)))
+	r.Weight = s.Levels[below].Weight + (s.Levels[above].Weight-s.Levels[below].Weight)*(pos-float64(below))
+	r.Warning = warning
+	return r
+}
+
+// at returns the level at index i as a ResolvedProficiency.
+func (s ProficiencyScale) at(i int) ResolvedProficiency {
+	return ResolvedProficiency{Level: s.Levels[i].Name, Rank: i + 1, Weight: s.Levels[i].Weight}
+}
+
+// levelIndex returns the index of the level named name, or -1.
+func (s ProficiencyScale) levelIndex(name string) int {
+	name = normalizeLevel(name)
+	for i, l := range s.Levels {
+		if normalizeLevel(l.Name) == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// numericRange returns NumericMin and NumericMax, or 1 and 10 when both are
+// unset.
+func (s ProficiencyScale) numericRange() (float64, float64) {
+	if s.NumericMin == 0 && s.NumericMax == 0 {
+		return 1, 10
+	}
+	return s.NumericMin, s.NumericMax
+}
+
+// normalizeLevel lowercases and trims a proficiency.
+func normalizeLevel(p string) string {
+	return strings.ToLower(strings.TrimSpace(p))
+}
+
+// isNumeric reports whether s parses as a number.
+func isNumeric(s string) bool {
+	_, err := strconv.ParseFloat(s, 64)
+	return err == nil
+}
@@ -0,0 +1,155 @@
+package scorer
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+// fiveLevelScale is a custom rubric with a level below beginner.
+func fiveLevelScale() *ProficiencyScale {
+	return &ProficiencyScale{
+		Levels: []ProficiencyLevel{
+			{Name: "novice", Weight: 0.3},
+			{Name: "beginner", Weight: 0.5},
+			{Name: "intermediate", Weight: 0.7},
+			{Name: "advanced", Weight: 0.85},
+			{Name: "expert", Weight: 1.0},
+		},
+		UnspecifiedWeight: 0.6,
+		DefaultLevel:      "novice",
+	}
+}
+
+// skillMatchFor returns the skill match score of a candidate with Go at
+// proficiency p for a job requiring Go.
+func skillMatchFor(t *testing.T, p string, weights ScoringWeights) ScoreBreakdown {
+	t.Helper()
+	profile := CandidateProfile{Skills: []CandidateSkill{{Name: "Go", Proficiency: p}}}
+	b, err := CalculateWithWeights(profile, JobRequirements{RequiredSkills: []string{"Go"}}, weights)
+	if err != nil {
+		t.Fatalf("CalculateWithWeights(%q): %v", p, err)
+	}
+	return b
+}
+
+func TestDefaultProficiencyScale(t *testing.T) {
+	scale := DefaultProficiencyScale()
+	if err := scale.Validate(); err != nil {
+		t.Fatalf("Validate: %v", err)
+	}
+	tests := []struct {
+		proficiency string
+		rank        int
+		weight      float64
+	}{
+		{"beginner", 1, 0.5},
+		{"Intermediate", 2, 0.75},
+		{" advanced ", 3, 0.9},
+		{"expert", 4, 1.0},
+		{"", 0, 0.7},
+	}
+	for _, tt := range tests {
+		r := scale.Resolve(tt.proficiency)
+		if r.Rank != tt.rank || r.Weight != tt.weight || r.Warning != "" {
+			t.Errorf("Resolve(%q) = %+v, want rank %d weight %v", tt.proficiency, r, tt.rank, tt.weight)
+		}
+	}
+}
+
+func TestProficiencyScale_Numeric(t *testing.T) {
+	scale := *fiveLevelScale()
+	tests := []struct {
+		proficiency string
+		level       string
+		weight      float64
+	}{
+		{"1", "novice", 0.3},
+		{"10", "expert", 1.0},
+		{"5.5", "intermediate", 0.7},
+		// Between beginner (3.25) and intermediate (5.5).
+		{"4", "beginner", 0.5 + 0.2*(0.75/2.25)},
+	}
+	for _, tt := range tests {
+		r := scale.Resolve(tt.proficiency)
+		if r.Level != tt.level || !approxEqual(r.Weight, tt.weight, 1e-9) || r.Warning != "" {
+			t.Errorf("Resolve(%q) = %+v, want %s at %.4f", tt.proficiency, r, tt.level, tt.weight)
+		}
+	}
+
+	r := scale.Resolve("12")
+	if r.Level != "expert" || r.Weight != 1.0 || !strings.Contains(r.Warning, "outside 1–10") {
+		t.Errorf("Resolve(12) = %+v, want expert clamped with a warning", r)
+	}
+}
+
+func TestCalculateWithWeights_ProficiencyScaleMonotonic(t *testing.T) {
+	weights := DefaultScoringWeights()
+	weights.ProficiencyScale = fiveLevelScale()
+
+	prev := -1.0
+	for _, level := range []string{"novice", "beginner", "intermediate", "advanced", "expert"} {
+		b := skillMatchFor(t, level, weights)
+		if b.SkillMatchScore <= prev {
+			t.Errorf("%s: skill match %.4f does not exceed the level below (%.4f)", level, b.SkillMatchScore, prev)
+		}
+		prev = b.SkillMatchScore
+	}
+
+	prev = -1.0
+	for _, n := range []string{"1", "2", "3", "4", "5", "6", "7", "8", "9", "10"} {
+		b := skillMatchFor(t, n, weights)
+		if b.SkillMatchScore <= prev {
+			t.Errorf("%s/10: skill match %.4f does not exceed %.4f", n, b.SkillMatchScore, prev)
+		}
+		if len(b.Warnings) != 0 {
+			t.Errorf("%s/10: unexpected warnings %v", n, b.Warnings)
+		}
+		prev = b.SkillMatchScore
+	}
+	if top := skillMatchFor(t, "expert", weights); top.SkillMatchScore != prev {
+		t.Errorf("10/10 scored %.4f, expert %.4f", prev, top.SkillMatchScore)
+	}
+}
+
+func TestCalculateWithWeights_UnknownProficiency(t *testing.T) {
+	weights := DefaultScoringWeights()
+	weights.ProficiencyScale = fiveLevelScale()
+
+	unknown := skillMatchFor(t, "Guru", weights)
+	novice := skillMatchFor(t, "novice", weights)
+	if unknown.SkillMatchScore == 0 || unknown.SkillMatchScore != novice.SkillMatchScore {
+		t.Errorf("unknown level scored %.4f, want the default level's %.4f", unknown.SkillMatchScore, novice.SkillMatchScore)
+	}
+	if len(unknown.Warnings) != 1 || !strings.Contains(unknown.Warnings[0], `"Go"`) || !strings.Contains(unknown.Warnings[0], "novice") {
+		t.Errorf("expected a warning naming the skill and default level, got %v", unknown.Warnings)
+	}
+
+	// The default scale assumes beginner.
+	if b := skillMatchFor(t, "fluent", DefaultScoringWeights()); b.SkillMatchScore != 0.4 || len(b.Warnings) != 1 {
+		t.Errorf("fluent on the default scale: %.4f, %v", b.SkillMatchScore, b.Warnings)
+	}
+}
+
+func TestProficiencyScale_Validate(t *testing.T) {
+	tests := map[string]func(s *ProficiencyScale){
+		"no levels":          func(s *ProficiencyScale) { s.Levels = nil },
+		"unnamed level":      func(s *ProficiencyScale) { s.Levels[2].Name = " " },
+		"duplicate level":    func(s *ProficiencyScale) { s.Levels[1].Name = "Novice" },
+		"numeric level":      func(s *ProficiencyScale) { s.Levels[0].Name = "0" },
+		"weight above 1":     func(s *ProficiencyScale) { s.Levels[4].Weight = 1.2 },
+		"decreasing weights": func(s *ProficiencyScale) { s.Levels[3].Weight = 0.6 },
+		"unspecified weight": func(s *ProficiencyScale) { s.UnspecifiedWeight = -0.1 },
+		"unknown default":    func(s *ProficiencyScale) { s.DefaultLevel = "master" },
+		"empty range":        func(s *ProficiencyScale) { s.NumericMin, s.NumericMax = 5, 5 },
+	}
+	for name, mutate := range tests {
+		scale := fiveLevelScale()
+		mutate(scale)
+		weights := DefaultScoringWeights()
+		weights.ProficiencyScale = scale
+		if _, err := CalculateWithWeights(CandidateProfile{}, JobRequirements{}, weights); !errors.Is(err, ErrInvalidProficiencyScale) {
+			t.Errorf("%s: expected ErrInvalidProficiencyScale, got %v", name, err)
+		}
+	}
+}
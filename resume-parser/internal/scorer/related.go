@@ -0,0 +1,87 @@
+package scorer
+
+import (
+	"sync"
+
+	"github.com/learnbot/resume-parser/internal/taxonomy"
+)
+
+// maxPartialMultiplier caps the proficiency multiplier of a partial match
+// below that of the weakest real match (0.5, a beginner or project skill),
+// so that a related skill never earns more credit than the skill itself.
+const maxPartialMultiplier = 0.4
+
+// Similarities of related skills, on the scale gap analysis uses.
+const (
+	// similarityRelated is for a skill the ontology lists as related to
+	// the other, in either direction.
+	similarityRelated = 0.7
+
+	// similaritySibling is for skills in the same category that build on
+	// a common skill, such as PyTorch and TensorFlow.
+	similaritySibling = 0.6
+)
+
+// relatedTaxonomy is the ontology partial credit reads skill relationships
+// from, built on first use.
+var relatedTaxonomy = sync.OnceValue(taxonomy.New)
+
+// relatedSkillSimilarity returns how similar the candidate skill is to the
+// required one, or 0 if the ontology does not relate them.
+func relatedSkillSimilarity(required, candidate string) float64 {
+	tax := relatedTaxonomy()
+	r, c := tax.LookupName(required), tax.LookupName(candidate)
+	if r == nil || c == nil || r.ID == c.ID {
+		return 0
+	}
+	if containsID(r.RelatedSkills, c.ID) || containsID(c.RelatedSkills, r.ID) {
+		return similarityRelated
+	}
+	if r.Category == c.Category && buildOnCommonSkill(r, c) {
+		return similaritySibling
+	}
+	return 0
+}
+
+// bestRelatedSkill returns the candidate skill that earns the most partial
+// credit toward the required skill, with its similarity and multiplier.
+func bestRelatedSkill(required string, index map[string]indexedSkill, credit float64) (indexedSkill, float64, float64, bool) {
+	var best indexedSkill
+	var bestSim, bestMult float64
+	for _, s := range index {
+		sim := relatedSkillSimilarity(required, s.name)
+		if sim == 0 {
+			continue
+		}
+		mult := sim * credit * s.weight
+		if mult > bestMult || (mult == bestMult && s.name < best.name) {
+			best, bestSim, bestMult = s, sim, mult
+		}
+	}
+	if bestMult == 0 {
+		return indexedSkill{}, 0, 0, false
+	}
+	return best, bestSim, min(bestMult, maxPartialMultiplier), true
+}
+
+func containsID(ids []string, id string) bool {
+	for _, v := range ids {
+		if v == id {
+			return true
+		}
+	}
+	return false
+}
+
+// buildOnCommonSkill reports whether a and b have a prerequisite or related
+// skill in common.
+func buildOnCommonSkill(a, b *taxonomy.SkillNode) bool {
+	for _, as := range [][]string{a.Prerequisites, a.RelatedSkills} {
+		for _, id := range as {
+			if containsID(b.Prerequisites, id) || containsID(b.RelatedSkills, id) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,89 @@
+package scorer
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func withRelatedSkillCredit(credit float64) ScoringWeights {
+	w := DefaultScoringWeights()
+	w.RelatedSkillCredit = credit
+	return w
+}
+
+func TestRelatedSkillCredit_PartialMatch(t *testing.T) {
+	profile := CandidateProfile{Skills: []CandidateSkill{
+		{Name: "PyTorch", Proficiency: "expert"},
+		{Name: "Go", Proficiency: "expert"},
+	}}
+	job := JobRequirements{RequiredSkills: []string{"TensorFlow", "Go"}}
+
+	off, _ := CalculateWithWeights(profile, job, DefaultScoringWeights())
+	on, err := CalculateWithWeights(profile, job, withRelatedSkillCredit(0.5))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if off.PartialMatches != nil {
+		t.Errorf("expected no partial matches by default, got %+v", off.PartialMatches)
+	}
+	if on.SkillMatchScore <= off.SkillMatchScore || on.OverallScore <= off.OverallScore {
+		t.Errorf("expected partial credit to raise the score: off %.2f/%.2f, on %.2f/%.2f",
+			off.SkillMatchScore, off.OverallScore, on.SkillMatchScore, on.OverallScore)
+	}
+
+	// PyTorch and TensorFlow are sibling ML frameworks: 0.6 × 0.5 × 1.0.
+	want := []PartialMatch{{Skill: "TensorFlow", RelatedSkill: "PyTorch", Similarity: 0.6, ProficiencyMultiplier: 0.3, Contribution: 0.12}}
+	if !reflect.DeepEqual(on.PartialMatches, want) {
+		t.Errorf("partial matches = %+v, want %+v", on.PartialMatches, want)
+	}
+	// A partial match is still a missing skill.
+	if !reflect.DeepEqual(on.MissingRequiredSkills, []string{"TensorFlow"}) {
+		t.Errorf("missing = %v, want [TensorFlow]", on.MissingRequiredSkills)
+	}
+	if c := on.Explanation.SkillMatch.RequiredSkills[0]; c.Matched || c.RelatedSkill != "PyTorch" || c.Contribution != 0.12 {
+		t.Errorf("explanation = %+v", c)
+	}
+}
+
+func TestRelatedSkillCredit_NeverOutscoresRealMatch(t *testing.T) {
+	job := JobRequirements{RequiredSkills: []string{"TypeScript"}}
+	related := CandidateProfile{Skills: []CandidateSkill{{Name: "JavaScript", Proficiency: "expert"}}}
+	beginner := CandidateProfile{Skills: []CandidateSkill{{Name: "TypeScript", Proficiency: "beginner"}}}
+
+	partial, _ := CalculateWithWeights(related, job, withRelatedSkillCredit(1))
+	exact, _ := CalculateWithWeights(beginner, job, withRelatedSkillCredit(1))
+	if len(partial.PartialMatches) != 1 || partial.PartialMatches[0].ProficiencyMultiplier != maxPartialMultiplier {
+		t.Fatalf("expected a capped partial match, got %+v", partial.PartialMatches)
+	}
+	if partial.SkillMatchScore >= exact.SkillMatchScore {
+		t.Errorf("an expert related skill (%.2f) outscored a beginner real match (%.2f)",
+			partial.SkillMatchScore, exact.SkillMatchScore)
+	}
+}
+
+func TestRelatedSkillCredit_UnrelatedSkills(t *testing.T) {
+	profile := CandidateProfile{Skills: []CandidateSkill{{Name: "Figma", Proficiency: "expert"}}}
+	job := JobRequirements{RequiredSkills: []string{"Kubernetes", "Some In-House Tool"}}
+
+	off, _ := CalculateWithWeights(profile, job, DefaultScoringWeights())
+	on, _ := CalculateWithWeights(profile, job, withRelatedSkillCredit(1))
+	if on.SkillMatchScore != off.SkillMatchScore || len(on.PartialMatches) != 0 {
+		t.Errorf("expected no credit for unrelated skills: off %.2f, on %.2f %+v",
+			off.SkillMatchScore, on.SkillMatchScore, on.PartialMatches)
+	}
+}
+
+func TestRelatedSkillCredit_Validation(t *testing.T) {
+	for _, credit := range []float64{-0.1, 1.5} {
+		if _, err := CalculateWithWeights(CandidateProfile{}, JobRequirements{}, withRelatedSkillCredit(credit)); !errors.Is(err, ErrRelatedSkillCredit) {
+			t.Errorf("credit %v: expected ErrRelatedSkillCredit, got %v", credit, err)
+		}
+	}
+	// The credit is not a component weight and survives renormalization.
+	w, _, err := ScoringWeights{SkillMatch: 2, RelatedSkillCredit: 0.5}.normalize()
+	if err != nil || w.SkillMatch != 1 || w.RelatedSkillCredit != 0.5 {
+		t.Errorf("normalize = %+v, %v", w, err)
+	}
+}
@@ -0,0 +1,73 @@
+package scorer
+
+import "errors"
+
+// RequirementsExtractor extracts job requirements from the raw text of a
+// job description. jobparse.ExtractRequirements is the standard
+// implementation; it lives outside this package, which it depends on.
+type RequirementsExtractor func(description string) (JobRequirements, error)
+
+// ErrDescriptionUnsupported is returned by ResolveJob when a description is
+// given but no extractor is configured.
+var ErrDescriptionUnsupported = errors.New("job_description is not supported by this server")
+
+// ResolveJob returns the job requirements for a request that carries
+// structured requirements, a raw job description, or both. Requirements
+// are extracted from a non-empty description, then every field set in job
+// overrides the extracted value, so callers can correct the extraction.
+func ResolveJob(job JobRequirements, description string, extract RequirementsExtractor) (JobRequirements, error) {
+	if description == "" {
+		return job, nil
+	}
+	if extract == nil {
+		return JobRequirements{}, ErrDescriptionUnsupported
+	}
+	base, err := extract(description)
+	if err != nil {
+		return JobRequirements{}, err
+	}
+
+	if job.Title != "" {
+		base.Title = job.Title
+	}
+	if len(job.RequiredSkills) > 0 {
+		base.RequiredSkills = job.RequiredSkills
+	}
+	if len(job.PreferredSkills) > 0 {
+		base.PreferredSkills = job.PreferredSkills
+	}
+	if len(job.NiceToHaveSkills) > 0 {
+		base.NiceToHaveSkills = job.NiceToHaveSkills
+	}
+	if job.MinYearsExperience > 0 {
+		base.MinYearsExperience = job.MinYearsExperience
+	}
+	if job.MaxYearsExperience > 0 {
+		base.MaxYearsExperience = job.MaxYearsExperience
+	}
+	if job.RequiredDegreeLevel != "" {
+		base.RequiredDegreeLevel = job.RequiredDegreeLevel
+	}
+	if len(job.PreferredFields) > 0 {
+		base.PreferredFields = job.PreferredFields
+	}
+	if job.LocationCity != "" {
+		base.LocationCity = job.LocationCity
+	}
+	if job.LocationCountry != "" {
+		base.LocationCountry = job.LocationCountry
+	}
+	if job.LocationType != "" {
+		base.LocationType = job.LocationType
+	}
+	if job.Industry != "" {
+		base.Industry = job.Industry
+	}
+	if len(job.RelatedIndustries) > 0 {
+		base.RelatedIndustries = job.RelatedIndustries
+	}
+	if job.ExperienceLevel != "" {
+		base.ExperienceLevel = job.ExperienceLevel
+	}
+	return base, nil
+}
@@ -2,10 +2,19 @@
 package scorer
 
 import (
+	"fmt"
 	"math"
 	"strings"
+
+	"github.com/learnbot/shared/geo"
+	"github.com/learnbot/shared/metrics"
 )
 
+// scoringInvocations counts profiles scored, whether through /score,
+// /score/batch or a full analysis.
+var scoringInvocations = metrics.NewCounter("scoring_invocations_total",
+	"Candidate profiles scored against a job.")
+
 // degreeLevelRank maps degree level strings to a numeric rank for comparison.
 // Higher rank = higher degree.
 var degreeLevelRank = map[string]int{
@@ -20,16 +29,6 @@ var degreeLevelRank = map[string]int{
 	"other":        0,
 }
 
-// proficiencyWeight maps proficiency levels to a weight multiplier used when
-// computing skill match scores.
-var proficiencyWeight = map[string]float64{
-	"beginner":     0.5,
-	"intermediate": 0.75,
-	"advanced":     0.9,
-	"expert":       1.0,
-	"":             0.7, // unknown proficiency – assume intermediate-ish
-}
-
 // experienceLevelYears maps experience level labels to approximate midpoint
 // years of experience, used when the job specifies a level but no explicit
 // year range.
@@ -47,8 +46,9 @@ var experienceLevelYears = map[string]float64{
 // ─────────────────────────────────────────────────────────────────────────────
 
 // Calculate computes the acceptance likelihood score for a candidate against
-// a job posting. It returns a ScoreBreakdown with the overall score (0–100)
-// and the individual component scores (0–1).
+// a job posting. It returns a ScoreBreakdown with the overall score (0–100),
+// the individual component scores (0–1) and an Explanation of how each
+// component score was reached.
 //
 // The weighted formula is:
 //
@@ -56,21 +56,50 @@ var experienceLevelYears = map[string]float64{
 //	           education_match * 0.15 + location_fit * 0.10 +
 //	           industry_relevance * 0.15) * 100
 func Calculate(profile CandidateProfile, job JobRequirements) ScoreBreakdown {
-	skillScore, matched, missing, matchedPref := scoreSkillMatch(profile, job)
-	expScore := scoreExperienceMatch(profile, job)
-	eduScore := scoreEducationMatch(profile, job)
-	locScore := scoreLocationFit(profile, job)
-	indScore := scoreIndustryRelevance(profile, job)
-
-	overall := (skillScore*WeightSkillMatch +
-		expScore*WeightExperienceMatch +
-		eduScore*WeightEducationMatch +
-		locScore*WeightLocationFit +
-		indScore*WeightIndustryRelevance) * 100.0
+	breakdown, _ := CalculateWithWeights(profile, job, DefaultScoringWeights())
+	return breakdown
+}
+
+// CalculateWithWeights is Calculate with the given component weights in
+// place of the defaults. Weights that do not sum to 1.0 are renormalized,
+// with a warning in the breakdown; the breakdown reports the weights used.
+// Proficiencies are scored on the weights' ProficiencyScale, with a warning
+// for each one not on it. It returns ErrNegativeWeight, ErrZeroWeights,
+// ErrRelatedSkillCredit or ErrInvalidProficiencyScale for invalid weights.
+func CalculateWithWeights(profile CandidateProfile, job JobRequirements, weights ScoringWeights) (ScoreBreakdown, error) {
+	weights, weightsWarning, err := weights.normalize()
+	if err != nil {
+		return ScoreBreakdown{}, err
+	}
+
+	scoringInvocations.Inc()
+	skillScore, skillExp := scoreSkillMatch(profile, job, weights)
+	expScore, expExp := scoreExperienceMatch(profile, job)
+	eduScore, eduExp := scoreEducationMatch(profile, job)
+	locScore, locExp := scoreLocationFit(profile, job)
+	indScore, indExp := scoreIndustryRelevance(profile, job)
+
+	overall := (skillScore*weights.SkillMatch +
+		expScore*weights.ExperienceMatch +
+		eduScore*weights.EducationMatch +
+		locScore*weights.LocationFit +
+		indScore*weights.IndustryRelevance) * 100.0
 
 	// Clamp to [0, 100]
 	overall = math.Max(0, math.Min(100, overall))
 
+	skillExp.ComponentExplanation.weigh(skillScore, weights.SkillMatch)
+	expExp.ComponentExplanation.weigh(expScore, weights.ExperienceMatch)
+	eduExp.ComponentExplanation.weigh(eduScore, weights.EducationMatch)
+	locExp.ComponentExplanation.weigh(locScore, weights.LocationFit)
+	indExp.ComponentExplanation.weigh(indScore, weights.IndustryRelevance)
+
+	warnings := append(consistencyWarnings(profile, job), skillExp.warnings...)
+	if weightsWarning != "" {
+		warnings = append(warnings, weightsWarning)
+	}
+
+	matched, missing, matchedPref := skillExp.matchedSkills()
 	return ScoreBreakdown{
 		OverallScore:           roundTo2(overall),
 		SkillMatchScore:        roundTo2(skillScore),
@@ -81,67 +110,95 @@ func Calculate(profile CandidateProfile, job JobRequirements) ScoreBreakdown {
 		MatchedRequiredSkills:  matched,
 		MissingRequiredSkills:  missing,
 		MatchedPreferredSkills: matchedPref,
-	}
+		PartialMatches:         skillExp.partialMatches(),
+		Weights:                weights,
+		Warnings:               warnings,
+		Explanation: &Explanation{
+			SkillMatch:        skillExp,
+			ExperienceMatch:   expExp,
+			EducationMatch:    eduExp,
+			LocationFit:       locExp,
+			IndustryRelevance: indExp,
+		},
+	}, nil
+}
+
+// weigh records a component's score and its weighted points.
+func (c *ComponentExplanation) weigh(score, weight float64) {
+	c.Score = roundTo4(score)
+	c.Weight = weight
+	c.Points = roundTo2(score * weight * 100)
 }
 
 // ─────────────────────────────────────────────────────────────────────────────
 // Component scorers
 // ─────────────────────────────────────────────────────────────────────────────
 
-// scoreSkillMatch computes the skill match component score [0, 1].
+// scoreSkillMatch computes the skill match component score [0, 1] and
+// explains it.
 //
 // Algorithm:
-//  1. Build a normalised lookup of candidate skills → proficiency weight.
+//  1. Build a normalised lookup of candidate skills → proficiency weight
+//     on the weights' proficiency scale, including skills evidenced by
+//     certifications and projects.
 //  2. For each required skill, check for an exact or fuzzy match.
 //     - Matched required skills contribute to a weighted numerator.
-//     - Missing required skills are tracked separately.
+//     - Missing required skills are tracked separately. With a positive
+//       RelatedSkillCredit, one the candidate has a related skill for adds
+//       partial credit (see bestRelatedSkill).
 //  3. Preferred skills add a bonus (up to 20% of the required score).
 //  4. Final score = required_score * 0.80 + preferred_bonus * 0.20
 //     (capped at 1.0).
-func scoreSkillMatch(profile CandidateProfile, job JobRequirements) (
-	score float64,
-	matchedRequired []string,
-	missingRequired []string,
-	matchedPreferred []string,
-) {
+func scoreSkillMatch(profile CandidateProfile, job JobRequirements, weights ScoringWeights) (float64, SkillMatchExplanation) {
+	var e SkillMatchExplanation
 	if len(job.RequiredSkills) == 0 {
 		// No required skills specified – full score by default.
-		return 1.0, nil, nil, nil
+		e.Note = "the job lists no required skills"
+		return 1.0, e
 	}
 
 	// Build candidate skill index: normalised name → proficiency weight.
-	candidateIndex := buildSkillIndex(profile.Skills)
+	candidateIndex, warnings := buildSkillIndex(profile, weights.proficiencyScale())
+	e.warnings = warnings
+	relatedCredit := weights.RelatedSkillCredit
 
-	// Score required skills.
+	// Score required skills. Each is worth an equal share of 80% of the
+	// score, scaled by the candidate's proficiency.
+	requiredShare := 0.80 / float64(len(job.RequiredSkills))
 	var requiredWeightedSum float64
-	var requiredMaxSum float64
-
 	for _, req := range job.RequiredSkills {
-		reqNorm := normalizeSkillName(req)
-		weight, found := lookupSkill(reqNorm, candidateIndex)
-		requiredMaxSum += 1.0
-		if found {
-			requiredWeightedSum += weight
-			matchedRequired = append(matchedRequired, req)
-		} else {
-			missingRequired = append(missingRequired, req)
+		c := SkillContribution{Skill: req}
+		if s, matchType, found := lookupSkill(normalizeSkillName(req), candidateIndex); found {
+			requiredWeightedSum += s.weight
+			c.Matched, c.MatchedAs, c.MatchType, c.Proficiency = true, s.name, matchType, s.proficiency
+			c.Evidence, c.Source = s.evidence, s.source
+			c.ProficiencyMultiplier = s.weight
+			c.Contribution = roundTo4(s.weight * requiredShare)
+		} else if relatedCredit > 0 {
+			if s, sim, mult, ok := bestRelatedSkill(req, candidateIndex, relatedCredit); ok {
+				requiredWeightedSum += mult
+				c.RelatedSkill, c.RelatedSkillSimilarity, c.Proficiency = s.name, sim, s.proficiency
+				c.ProficiencyMultiplier = roundTo4(mult)
+				c.Contribution = roundTo4(mult * requiredShare)
+			}
 		}
+		e.RequiredSkills = append(e.RequiredSkills, c)
 	}
+	requiredScore := requiredWeightedSum / float64(len(job.RequiredSkills))
 
-	requiredScore := 0.0
-	if requiredMaxSum > 0 {
-		requiredScore = requiredWeightedSum / requiredMaxSum
-	}
-
-	// Score preferred skills (bonus).
+	// Score preferred skills (bonus). Each matched one is worth an equal
+	// share of 20% of the score.
 	var preferredMatched int
 	for _, pref := range job.PreferredSkills {
-		prefNorm := normalizeSkillName(pref)
-		_, found := lookupSkill(prefNorm, candidateIndex)
-		if found {
+		c := SkillContribution{Skill: pref}
+		if s, matchType, found := lookupSkill(normalizeSkillName(pref), candidateIndex); found {
 			preferredMatched++
-			matchedPreferred = append(matchedPreferred, pref)
+			c.Matched, c.MatchedAs, c.MatchType, c.Proficiency = true, s.name, matchType, s.proficiency
+			c.Evidence, c.Source = s.evidence, s.source
+			c.ProficiencyMultiplier = 1.0
+			c.Contribution = roundTo4(0.20 / float64(len(job.PreferredSkills)))
 		}
+		e.PreferredSkills = append(e.PreferredSkills, c)
 	}
 
 	preferredBonus := 0.0
@@ -150,24 +207,66 @@ func scoreSkillMatch(profile CandidateProfile, job JobRequirements) (
 	}
 
 	// Combine: required skills are 80% of the score, preferred are 20%.
-	score = requiredScore*0.80 + preferredBonus*0.20
-	score = math.Min(1.0, score)
-	return score, matchedRequired, missingRequired, matchedPreferred
+	score := requiredScore*0.80 + preferredBonus*0.20
+	return math.Min(1.0, score), e
 }
 
-// scoreExperienceMatch computes the experience match component score [0, 1].
+// matchedSkills returns the matched and missing required skills and the
+// matched preferred skills, in job order.
+func (e SkillMatchExplanation) matchedSkills() (matchedRequired, missingRequired, matchedPreferred []string) {
+	for _, c := range e.RequiredSkills {
+		if c.Matched {
+			matchedRequired = append(matchedRequired, c.Skill)
+		} else {
+			missingRequired = append(missingRequired, c.Skill)
+		}
+	}
+	for _, c := range e.PreferredSkills {
+		if c.Matched {
+			matchedPreferred = append(matchedPreferred, c.Skill)
+		}
+	}
+	return matchedRequired, missingRequired, matchedPreferred
+}
+
+// partialMatches returns the required skills credited through a related
+// skill, in job order.
+func (e SkillMatchExplanation) partialMatches() []PartialMatch {
+	var out []PartialMatch
+	for _, c := range e.RequiredSkills {
+		if c.RelatedSkill == "" {
+			continue
+		}
+		out = append(out, PartialMatch{
+			Skill:                 c.Skill,
+			RelatedSkill:          c.RelatedSkill,
+			Similarity:            c.RelatedSkillSimilarity,
+			ProficiencyMultiplier: c.ProficiencyMultiplier,
+			Contribution:          c.Contribution,
+		})
+	}
+	return out
+}
+
+// scoreExperienceMatch computes the experience match component score
+// [0, 1] and explains it.
 //
 // Algorithm:
 //  1. Determine the target years from MinYearsExperience / ExperienceLevel.
 //  2. Compute a ratio of candidate years to target years.
 //  3. Apply a soft penalty for over-qualification (> 2× target).
 //  4. Also consider title/role similarity from work history.
-func scoreExperienceMatch(profile CandidateProfile, job JobRequirements) float64 {
+func scoreExperienceMatch(profile CandidateProfile, job JobRequirements) (float64, ExperienceMatchExplanation) {
+	e := ExperienceMatchExplanation{CandidateYearsSource: "profile", MaxYears: job.MaxYearsExperience}
 	targetMin := job.MinYearsExperience
+	if targetMin > 0 {
+		e.RequiredYearsSource = "job"
+	}
 
 	// If no explicit minimum, infer from experience level.
 	if targetMin == 0 && job.ExperienceLevel != "" {
 		targetMin = experienceLevelYears[strings.ToLower(job.ExperienceLevel)]
+		e.RequiredYearsSource = "experience_level"
 	}
 
 	candidateYears := profile.YearsOfExperience
@@ -179,33 +278,46 @@ func scoreExperienceMatch(profile CandidateProfile, job JobRequirements) float64
 			totalMonths += w.DurationMonths
 		}
 		candidateYears = float64(totalMonths) / 12.0
+		e.CandidateYearsSource = "work_history"
 	}
 
 	yearsScore := computeYearsScore(candidateYears, targetMin, job.MaxYearsExperience)
 
 	// Title similarity bonus: check if any past title matches the job title.
-	titleBonus := computeTitleSimilarity(profile.WorkHistory, job.Title)
+	title, titleBonus := closestTitle(profile.WorkHistory, job.Title)
+
+	e.CandidateYears = roundTo2(candidateYears)
+	e.RequiredYears = targetMin
+	e.YearsScore = roundTo4(yearsScore)
+	e.YearsContribution = roundTo4(yearsScore * 0.70)
+	e.TitleSimilarity = roundTo4(titleBonus)
+	e.ClosestTitle = title
+	e.TitleContribution = roundTo4(titleBonus * 0.30)
 
 	// Combine: years are 70% of experience score, title similarity 30%.
 	score := yearsScore*0.70 + titleBonus*0.30
-	return math.Min(1.0, score)
+	return math.Min(1.0, score), e
 }
 
-// scoreEducationMatch computes the education match component score [0, 1].
+// scoreEducationMatch computes the education match component score
+// [0, 1] and explains it.
 //
 // Algorithm:
 //  1. If no degree is required, return 1.0.
 //  2. Find the candidate's highest degree level.
 //  3. Score based on whether the candidate meets or exceeds the requirement.
 //  4. Apply a field-of-study bonus if the field matches preferred fields.
-func scoreEducationMatch(profile CandidateProfile, job JobRequirements) float64 {
+func scoreEducationMatch(profile CandidateProfile, job JobRequirements) (float64, EducationMatchExplanation) {
+	e := EducationMatchExplanation{RequiredDegreeLevel: job.RequiredDegreeLevel, DegreeScore: 1.0}
 	if job.RequiredDegreeLevel == "" {
-		return 1.0 // No education requirement.
+		e.Note = "the job requires no degree"
+		return 1.0, e // No education requirement.
 	}
 
 	requiredRank := degreeLevelRank[strings.ToLower(job.RequiredDegreeLevel)]
 	if requiredRank == 0 {
-		return 1.0 // Unknown requirement – don't penalise.
+		e.Note = "the required degree level is not ranked"
+		return 1.0, e // Unknown requirement – don't penalise.
 	}
 
 	// Find candidate's highest degree rank.
@@ -216,6 +328,7 @@ func scoreEducationMatch(profile CandidateProfile, job JobRequirements) float64
 		if rank > highestRank {
 			highestRank = rank
 			highestFieldOfStudy = edu.FieldOfStudy
+			e.CandidateDegreeLevel = edu.DegreeLevel
 		}
 	}
 
@@ -224,64 +337,84 @@ func scoreEducationMatch(profile CandidateProfile, job JobRequirements) float64
 	case highestRank == 0:
 		// No education info – partial credit.
 		degreeScore = 0.3
+		e.LevelsBelow = requiredRank
 	case highestRank >= requiredRank:
 		degreeScore = 1.0
 	case highestRank == requiredRank-1:
 		// One level below – partial credit.
 		degreeScore = 0.6
+		e.LevelsBelow = 1
 	default:
 		// Two or more levels below.
 		degreeScore = 0.2
+		e.LevelsBelow = requiredRank - highestRank
 	}
 
 	// Field-of-study bonus (up to 0.2 added to degree score, capped at 1.0).
 	fieldBonus := computeFieldBonus(highestFieldOfStudy, job.PreferredFields)
-	return math.Min(1.0, degreeScore+fieldBonus*0.2)
+	score := math.Min(1.0, degreeScore+fieldBonus*0.2)
+
+	e.DegreeScore = degreeScore
+	e.FieldOfStudy = highestFieldOfStudy
+	e.FieldMatched = fieldBonus > 0
+	e.FieldContribution = roundTo4(score - degreeScore)
+	return score, e
 }
 
-// scoreLocationFit computes the location fit component score [0, 1].
+// scoreLocationFit computes the location fit component score [0, 1] and
+// explains it.
 //
 // Algorithm:
 //  1. If the job is fully remote, check candidate's remote preference.
 //  2. If the job is on-site or hybrid, check city/country match or relocation.
-func scoreLocationFit(profile CandidateProfile, job JobRequirements) float64 {
+func scoreLocationFit(profile CandidateProfile, job JobRequirements) (float64, LocationFitExplanation) {
 	jobLocType := strings.ToLower(job.LocationType)
 	candidatePref := strings.ToLower(profile.RemotePreference)
+	e := LocationFitExplanation{JobLocationType: jobLocType, CandidatePreference: candidatePref}
 
 	switch jobLocType {
 	case "remote":
 		// Remote job: candidate who prefers remote or "any" is a perfect fit.
 		if candidatePref == "remote" || candidatePref == "any" || candidatePref == "" {
-			return 1.0
+			e.Match = "preference_matches"
+			return 1.0, e
 		}
 		// Candidate prefers on-site but job is remote – partial fit.
-		return 0.7
+		e.Match = "different_preference"
+		return 0.7, e
 
 	case "hybrid":
 		if candidatePref == "hybrid" || candidatePref == "any" || candidatePref == "" {
-			return 1.0
+			e.Match = "preference_matches"
+			return 1.0, e
 		}
+		e.Match = "different_preference"
 		if candidatePref == "remote" {
-			return 0.6
+			return 0.6, e
 		}
 		// on_site preference for hybrid role – reasonable fit.
-		return 0.8
+		return 0.8, e
 
 	default: // "on_site" or unknown
 		// Check geographic match.
-		return computeGeoScore(profile, job)
+		score, match := computeGeoScore(profile, job)
+		e.Match = match
+		return score, e
 	}
 }
 
-// scoreIndustryRelevance computes the industry relevance component score [0, 1].
+// scoreIndustryRelevance computes the industry relevance component score
+// [0, 1] and explains it.
 //
 // Algorithm:
 //  1. If no industry is specified in the job, return 1.0.
 //  2. Check if any of the candidate's past industries match the target.
 //  3. Check related industries for partial credit.
-func scoreIndustryRelevance(profile CandidateProfile, job JobRequirements) float64 {
+func scoreIndustryRelevance(profile CandidateProfile, job JobRequirements) (float64, IndustryRelevanceExplanation) {
+	e := IndustryRelevanceExplanation{JobIndustry: job.Industry}
 	if job.Industry == "" {
-		return 1.0 // No industry requirement.
+		e.Match = "no_requirement"
+		return 1.0, e // No industry requirement.
 	}
 
 	targetIndustry := normalizeIndustry(job.Industry)
@@ -293,8 +426,8 @@ func scoreIndustryRelevance(profile CandidateProfile, job JobRequirements) float
 	}
 
 	// Check candidate's work history industries.
-	var directMatch bool
-	var relatedMatch bool
+	var directMatch string
+	var relatedMatch string
 
 	for _, w := range profile.WorkHistory {
 		if w.Industry == "" {
@@ -302,65 +435,149 @@ func scoreIndustryRelevance(profile CandidateProfile, job JobRequirements) float
 		}
 		norm := normalizeIndustry(w.Industry)
 		if norm == targetIndustry {
-			directMatch = true
+			directMatch = w.Industry
 			break
 		}
-		if relatedSet[norm] {
-			relatedMatch = true
+		if relatedSet[norm] && relatedMatch == "" {
+			relatedMatch = w.Industry
 		}
 	}
 
 	switch {
-	case directMatch:
-		return 1.0
-	case relatedMatch:
-		return 0.7
+	case directMatch != "":
+		e.Match, e.MatchedIndustry = "direct", directMatch
+		return 1.0, e
+	case relatedMatch != "":
+		e.Match, e.MatchedIndustry = "related", relatedMatch
+		return 0.7, e
 	case len(profile.WorkHistory) == 0:
 		// No work history – neutral score.
-		return 0.5
+		e.Match = "no_work_history"
+		return 0.5, e
 	default:
 		// No industry match at all.
-		return 0.2
+		e.Match = "none"
+		return 0.2, e
 	}
 }
 
+// ─────────────────────────────────────────────────────────────────────────────
+// Input consistency checks
+// ─────────────────────────────────────────────────────────────────────────────
+
+// historyToleranceYears is how far the declared years of experience may drift
+// from the work history total before it is reported as inconsistent.
+const historyToleranceYears = 2.0
+
+// consistencyWarnings returns human-readable warnings for contradictory
+// inputs. It never affects the score; it exists so that callers (and the
+// data quality dashboard) can spot bad upstream data.
+func consistencyWarnings(profile CandidateProfile, job JobRequirements) []string {
+	var warnings []string
+
+	if job.MaxYearsExperience > 0 && job.MaxYearsExperience < job.MinYearsExperience {
+		warnings = append(warnings, fmt.Sprintf(
+			"job max_years_experience (%.1f) is below min_years_experience (%.1f)",
+			job.MaxYearsExperience, job.MinYearsExperience))
+	}
+
+	required := make(map[string]bool, len(job.RequiredSkills))
+	for _, s := range job.RequiredSkills {
+		required[normalizeSkillName(s)] = true
+	}
+	for _, s := range job.PreferredSkills {
+		if required[normalizeSkillName(s)] {
+			warnings = append(warnings, fmt.Sprintf(
+				"skill %q is listed as both required and preferred", s))
+		}
+	}
+
+	if profile.YearsOfExperience > 0 && len(profile.WorkHistory) > 0 {
+		var totalMonths int
+		for _, w := range profile.WorkHistory {
+			totalMonths += w.DurationMonths
+		}
+		historyYears := float64(totalMonths) / 12.0
+		if math.Abs(profile.YearsOfExperience-historyYears) > historyToleranceYears {
+			warnings = append(warnings, fmt.Sprintf(
+				"profile years_of_experience (%.1f) disagrees with work history total (%.1f)",
+				profile.YearsOfExperience, historyYears))
+		}
+	}
+
+	if profile.YearsOfExperience > 0 {
+		for _, s := range profile.Skills {
+			if s.YearsOfExperience > profile.YearsOfExperience {
+				warnings = append(warnings, fmt.Sprintf(
+					"skill %q claims %.1f years, more than total experience (%.1f)",
+					s.Name, s.YearsOfExperience, profile.YearsOfExperience))
+			}
+		}
+	}
+
+	return warnings
+}
+
 // ─────────────────────────────────────────────────────────────────────────────
 // Helper functions
 // ─────────────────────────────────────────────────────────────────────────────
 
-// buildSkillIndex creates a map from normalised skill name to proficiency weight.
-func buildSkillIndex(skills []CandidateSkill) map[string]float64 {
-	index := make(map[string]float64, len(skills))
-	for _, s := range skills {
+// indexedSkill is a candidate skill in a skill index.
+type indexedSkill struct {
+	name        string
+	proficiency string
+	weight      float64
+	// evidence and source are the SkillContribution fields of the same
+	// names.
+	evidence string
+	source   string
+}
+
+// buildSkillIndex creates a map from normalised skill name to the
+// candidate's skill and its proficiency weight on scale. Certifications
+// raise the weight of the skills they certify, and project technologies add
+// the skills the candidate does not list. It also returns a warning for
+// each proficiency the scale did not take as given.
+func buildSkillIndex(profile CandidateProfile, scale ProficiencyScale) (map[string]indexedSkill, []string) {
+	index := make(map[string]indexedSkill, len(profile.Skills))
+	var warnings []string
+	for _, s := range profile.Skills {
 		norm := normalizeSkillName(s.Name)
 		if norm == "" {
 			continue
 		}
-		w := proficiencyWeight[strings.ToLower(s.Proficiency)]
+		level := scale.Resolve(s.Proficiency)
+		if level.Warning != "" {
+			warnings = append(warnings, fmt.Sprintf("skill %q: %s", s.Name, level.Warning))
+		}
+		w := level.Weight
 		// Keep the highest weight if the skill appears multiple times.
-		if existing, ok := index[norm]; !ok || w > existing {
-			index[norm] = w
+		if existing, ok := index[norm]; !ok || w > existing.weight {
+			index[norm] = indexedSkill{name: s.Name, proficiency: s.Proficiency, weight: w, evidence: "skill"}
 		}
 	}
-	return index
+	addCertifiedSkills(index, profile.Certifications)
+	addProjectSkills(index, profile.Projects)
+	return index, warnings
 }
 
 // lookupSkill checks whether a required skill exists in the candidate index.
-// It first tries an exact normalised match, then a substring/alias match.
-// Returns the proficiency weight and whether a match was found.
-func lookupSkill(reqNorm string, index map[string]float64) (float64, bool) {
+// It first tries an exact normalised match ("exact"), then a substring/alias
+// match ("alias"). Returns the matched skill, the match type and whether a
+// match was found.
+func lookupSkill(reqNorm string, index map[string]indexedSkill) (indexedSkill, string, bool) {
 	// Exact match.
-	if w, ok := index[reqNorm]; ok {
-		return w, true
+	if s, ok := index[reqNorm]; ok {
+		return s, "exact", true
 	}
 
 	// Alias / substring match: e.g. "golang" matches "go", "node" matches "node.js".
-	for candidateNorm, w := range index {
+	for candidateNorm, s := range index {
 		if skillsAreAliases(reqNorm, candidateNorm) {
-			return w, true
+			return s, "alias", true
 		}
 	}
-	return 0, false
+	return indexedSkill{}, "", false
 }
 
 // skillsAreAliases returns true if two normalised skill names are considered
@@ -441,26 +658,29 @@ func computeYearsScore(candidateYears, targetMin, targetMax float64) float64 {
 	return math.Max(0.1, ratio)
 }
 
-// computeTitleSimilarity returns a bonus [0, 1] based on how closely the
-// candidate's past job titles match the target job title.
-func computeTitleSimilarity(history []WorkHistoryEntry, targetTitle string) float64 {
+// closestTitle returns the candidate's past job title most similar to the
+// target job title, and its similarity [0, 1]. The similarity is a neutral
+// 0.5, without a title, when either side is unknown.
+func closestTitle(history []WorkHistoryEntry, targetTitle string) (string, float64) {
 	if targetTitle == "" || len(history) == 0 {
-		return 0.5 // Neutral when no data.
+		return "", 0.5 // Neutral when no data.
 	}
 
 	targetNorm := normalizeJobTitle(targetTitle)
 	targetWords := strings.Fields(targetNorm)
 
 	bestScore := 0.0
+	bestTitle := ""
 	for _, w := range history {
 		candidateNorm := normalizeJobTitle(w.Title)
 		candidateWords := strings.Fields(candidateNorm)
 		sim := wordOverlapScore(targetWords, candidateWords)
 		if sim > bestScore {
 			bestScore = sim
+			bestTitle = w.Title
 		}
 	}
-	return bestScore
+	return bestTitle, bestScore
 }
 
 // normalizeJobTitle lowercases and removes common filler words from a title.
@@ -530,29 +750,41 @@ func computeFieldBonus(candidateField string, preferredFields []string) float64
 	return 0
 }
 
-// computeGeoScore returns a location score for on-site / hybrid jobs.
-func computeGeoScore(profile CandidateProfile, job JobRequirements) float64 {
+// computeGeoScore returns a location score for on-site / hybrid jobs, and
+// the LocationFitExplanation match it is based on.
+func computeGeoScore(profile CandidateProfile, job JobRequirements) (float64, string) {
 	// Same city → perfect match.
 	if profile.LocationCity != "" && job.LocationCity != "" {
-		if strings.EqualFold(profile.LocationCity, job.LocationCity) {
-			return 1.0
+		if samePlace(profile.LocationCity, job.LocationCity, geo.CityName) {
+			return 1.0, "same_city"
 		}
 	}
 
 	// Same country → good match (commutable or willing to relocate within country).
 	if profile.LocationCountry != "" && job.LocationCountry != "" {
-		if strings.EqualFold(profile.LocationCountry, job.LocationCountry) {
-			return 0.8
+		if samePlace(profile.LocationCountry, job.LocationCountry, geo.CountryCode) {
+			return 0.8, "same_country"
 		}
 	}
 
 	// Willing to relocate internationally → partial credit.
 	if profile.WillingToRelocate {
-		return 0.6
+		return 0.6, "willing_to_relocate"
 	}
 
 	// Different country, not willing to relocate.
-	return 0.2
+	return 0.2, "different_location"
+}
+
+// samePlace reports whether two city or country names name the same
+// place. Names the gazetteer knows are compared by their canonical form, so
+// that "US" matches "United States" and "NYC" matches "New York"; other
+// names are compared case-insensitively.
+func samePlace(a, b string, canonical func(string) string) bool {
+	if ca, cb := canonical(a), canonical(b); ca != "" && cb != "" {
+		return ca == cb
+	}
+	return strings.EqualFold(strings.TrimSpace(a), strings.TrimSpace(b))
 }
 
 // normalizeIndustry lowercases and trims an industry string.
@@ -564,3 +796,8 @@ func normalizeIndustry(s string) string {
 func roundTo2(v float64) float64 {
 	return math.Round(v*100) / 100
 }
+
+// roundTo4 rounds a float64 to 4 decimal places.
+func roundTo4(v float64) float64 {
+	return math.Round(v*10000) / 10000
+}
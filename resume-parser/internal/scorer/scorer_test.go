@@ -626,6 +626,30 @@ func TestScoreLocationFit_SameCountryDifferentCity(t *testing.T) {
 	}
 }
 
+func TestScoreLocationFit_NormalizedNames(t *testing.T) {
+	tests := []struct {
+		name      string
+		profile   CandidateProfile
+		job       JobRequirements
+		wantMatch string
+	}{
+		{"country code and name", CandidateProfile{LocationCountry: "United States"}, JobRequirements{LocationCountry: "US"}, "same_country"},
+		{"alpha-3 code", CandidateProfile{LocationCountry: "IDN"}, JobRequirements{LocationCountry: "Indonesia"}, "same_country"},
+		{"city alias", CandidateProfile{LocationCity: "NYC"}, JobRequirements{LocationCity: "New York"}, "same_city"},
+		{"unknown names as written", CandidateProfile{LocationCountry: "Wakanda"}, JobRequirements{LocationCountry: "wakanda"}, "same_country"},
+		{"different countries", CandidateProfile{LocationCountry: "UK"}, JobRequirements{LocationCountry: "US"}, "different_location"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.job.LocationType = "on_site"
+			_, e := scoreLocationFit(tt.profile, tt.job)
+			if e.Match != tt.wantMatch {
+				t.Errorf("match = %q, want %q", e.Match, tt.wantMatch)
+			}
+		})
+	}
+}
+
 func TestScoreLocationFit_DifferentCountryWillingToRelocate(t *testing.T) {
 	profile := CandidateProfile{
 		LocationCountry:   "Brazil",
@@ -958,3 +982,181 @@ func TestRoundTo2(t *testing.T) {
 		}
 	}
 }
+
+func TestConsistencyWarnings(t *testing.T) {
+	tests := []struct {
+		name    string
+		profile CandidateProfile
+		job     JobRequirements
+		want    int
+	}{
+		{
+			name: "consistent inputs",
+			profile: CandidateProfile{
+				YearsOfExperience: 5,
+				WorkHistory:       []WorkHistoryEntry{{DurationMonths: 60}},
+				Skills:            []CandidateSkill{{Name: "Go", YearsOfExperience: 4}},
+			},
+			job:  JobRequirements{RequiredSkills: []string{"Go"}, MinYearsExperience: 3, MaxYearsExperience: 8},
+			want: 0,
+		},
+		{
+			name: "max below min",
+			job:  JobRequirements{MinYearsExperience: 5, MaxYearsExperience: 2},
+			want: 1,
+		},
+		{
+			name: "skill both required and preferred",
+			job:  JobRequirements{RequiredSkills: []string{"Go"}, PreferredSkills: []string{"go", "Docker"}},
+			want: 1,
+		},
+		{
+			name: "declared years disagree with history",
+			profile: CandidateProfile{
+				YearsOfExperience: 10,
+				WorkHistory:       []WorkHistoryEntry{{DurationMonths: 24}},
+			},
+			want: 1,
+		},
+		{
+			name: "skill years exceed total",
+			profile: CandidateProfile{
+				YearsOfExperience: 3,
+				Skills:            []CandidateSkill{{Name: "Java", YearsOfExperience: 7}},
+			},
+			want: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := consistencyWarnings(tt.profile, tt.job)
+			if len(got) != tt.want {
+				t.Errorf("got %d warnings %v, want %d", len(got), got, tt.want)
+			}
+		})
+	}
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Explanation
+// ─────────────────────────────────────────────────────────────────────────────
+
+func explainedCandidate() (CandidateProfile, JobRequirements) {
+	profile := CandidateProfile{
+		Skills: []CandidateSkill{
+			{Name: "Golang", Proficiency: "expert"},
+			{Name: "Docker", Proficiency: "intermediate"},
+			{Name: "k8s", Proficiency: "beginner"},
+			{Name: "Terraform"},
+		},
+		WorkHistory: []WorkHistoryEntry{
+			{Title: "Backend Developer", Industry: "Fintech", DurationMonths: 30},
+			{Title: "Junior Software Engineer", Industry: "Retail", DurationMonths: 12},
+		},
+		Education:       []EducationEntry{{DegreeLevel: "associate", FieldOfStudy: "Computer Science"}},
+		LocationCity:    "Bandung",
+		LocationCountry: "Indonesia",
+	}
+	job := JobRequirements{
+		Title:               "Senior Software Engineer",
+		RequiredSkills:      []string{"Go", "Docker", "Kubernetes", "Rust"},
+		PreferredSkills:     []string{"Terraform", "AWS", "GraphQL"},
+		MinYearsExperience:  5,
+		RequiredDegreeLevel: "bachelor",
+		PreferredFields:     []string{"Computer Science"},
+		LocationCity:        "Jakarta",
+		LocationCountry:     "Indonesia",
+		LocationType:        "on_site",
+		Industry:            "Banking",
+		RelatedIndustries:   []string{"Fintech"},
+	}
+	return profile, job
+}
+
+func TestCalculate_ExplanationContributionsReproduceScores(t *testing.T) {
+	profile, job := explainedCandidate()
+	result := Calculate(profile, job)
+	e := result.Explanation
+	if e == nil {
+		t.Fatal("expected an explanation")
+	}
+
+	skills := 0.0
+	for _, c := range append(append([]SkillContribution{}, e.SkillMatch.RequiredSkills...), e.SkillMatch.PreferredSkills...) {
+		skills += c.Contribution
+	}
+	if !approxEqual(skills, result.SkillMatchScore, 0.01) {
+		t.Errorf("skill contributions sum to %.4f, skill match score is %.2f", skills, result.SkillMatchScore)
+	}
+	exp := e.ExperienceMatch.YearsContribution + e.ExperienceMatch.TitleContribution
+	if !approxEqual(exp, result.ExperienceMatchScore, 0.01) {
+		t.Errorf("experience contributions sum to %.4f, experience match score is %.2f", exp, result.ExperienceMatchScore)
+	}
+	edu := e.EducationMatch.DegreeScore + e.EducationMatch.FieldContribution
+	if !approxEqual(edu, result.EducationMatchScore, 0.01) {
+		t.Errorf("education contributions sum to %.4f, education match score is %.2f", edu, result.EducationMatchScore)
+	}
+	if !approxEqual(e.LocationFit.Score, result.LocationFitScore, 0.01) ||
+		!approxEqual(e.IndustryRelevance.Score, result.IndustryRelevanceScore, 0.01) {
+		t.Errorf("location or industry explanation disagrees with the breakdown: %+v, %+v", e.LocationFit, e.IndustryRelevance)
+	}
+
+	points := e.SkillMatch.Points + e.ExperienceMatch.Points + e.EducationMatch.Points +
+		e.LocationFit.Points + e.IndustryRelevance.Points
+	if !approxEqual(points, result.OverallScore, 0.05) {
+		t.Errorf("component points sum to %.2f, overall score is %.2f", points, result.OverallScore)
+	}
+}
+
+func TestCalculate_ExplanationDetails(t *testing.T) {
+	profile, job := explainedCandidate()
+	e := Calculate(profile, job).Explanation
+
+	req := e.SkillMatch.RequiredSkills
+	if len(req) != 4 {
+		t.Fatalf("expected 4 required skill contributions, got %+v", req)
+	}
+	if c := req[0]; !c.Matched || c.MatchedAs != "Golang" || c.MatchType != "alias" || c.ProficiencyMultiplier != 1.0 || c.Contribution != 0.2 {
+		t.Errorf("unexpected Go contribution %+v", c)
+	}
+	if c := req[1]; c.MatchType != "exact" || c.ProficiencyMultiplier != 0.75 || c.Contribution != 0.15 {
+		t.Errorf("unexpected Docker contribution %+v", c)
+	}
+	if c := req[2]; c.MatchedAs != "k8s" || c.MatchType != "alias" || c.Proficiency != "beginner" || c.ProficiencyMultiplier != 0.5 {
+		t.Errorf("unexpected Kubernetes contribution %+v", c)
+	}
+	if c := req[3]; c.Matched || c.Contribution != 0 {
+		t.Errorf("expected Rust to be unmatched, got %+v", c)
+	}
+	if c := e.SkillMatch.PreferredSkills[0]; !c.Matched || c.ProficiencyMultiplier != 1.0 {
+		t.Errorf("expected Terraform to count fully as a preferred skill, got %+v", c)
+	}
+
+	if x := e.ExperienceMatch; x.CandidateYears != 3.5 || x.CandidateYearsSource != "work_history" ||
+		x.RequiredYears != 5 || x.RequiredYearsSource != "job" || x.ClosestTitle != "Junior Software Engineer" {
+		t.Errorf("unexpected experience explanation %+v", x)
+	}
+	if x := e.EducationMatch; x.CandidateDegreeLevel != "associate" || x.LevelsBelow != 1 ||
+		x.DegreeScore != 0.6 || !x.FieldMatched || x.FieldContribution != 0.2 {
+		t.Errorf("unexpected education explanation %+v", x)
+	}
+	if e.LocationFit.Match != "same_country" {
+		t.Errorf("expected a same_country location match, got %+v", e.LocationFit)
+	}
+	if x := e.IndustryRelevance; x.Match != "related" || x.MatchedIndustry != "Fintech" {
+		t.Errorf("unexpected industry explanation %+v", x)
+	}
+}
+
+func TestCalculate_ExplanationNotes(t *testing.T) {
+	e := Calculate(CandidateProfile{}, JobRequirements{}).Explanation
+	if e.SkillMatch.Note == "" || e.SkillMatch.Score != 1.0 {
+		t.Errorf("expected a note for a job without required skills, got %+v", e.SkillMatch)
+	}
+	if e.EducationMatch.Note == "" || e.EducationMatch.DegreeScore != 1.0 {
+		t.Errorf("expected a note for a job without a degree requirement, got %+v", e.EducationMatch)
+	}
+	if e.IndustryRelevance.Match != "no_requirement" {
+		t.Errorf("expected no industry requirement, got %+v", e.IndustryRelevance)
+	}
+}
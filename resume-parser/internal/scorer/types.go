@@ -1,7 +1,8 @@
 // Package scorer implements the acceptance likelihood scoring algorithm
 // for matching user profiles against job postings.
 //
-// The algorithm uses a weighted formula from the LearnBot specification:
+// The algorithm uses a weighted formula from the LearnBot specification,
+// whose weights callers can override (see ScoringWeights):
 //
 //	Score = (skill_match * 0.35) + (experience_match * 0.25) +
 //	        (education_match * 0.15) + (location_fit * 0.10) +
@@ -11,7 +12,9 @@
 // is expressed as a percentage in [0.0, 100.0].
 package scorer
 
-// Weights defines the contribution of each scoring component.
+import "github.com/learnbot/shared/validation"
+
+// Weights defines the default contribution of each scoring component.
 // They must sum to 1.0.
 const (
 	WeightSkillMatch        = 0.35
@@ -29,14 +32,19 @@ type JobRequirements struct {
 	// RequiredSkills is the list of must-have skills.
 	RequiredSkills []string `json:"required_skills"`
 
-	// PreferredSkills is the list of nice-to-have skills.
+	// PreferredSkills is the list of preferred skills.
 	PreferredSkills []string `json:"preferred_skills,omitempty"`
 
+	// NiceToHaveSkills lists skills the posting mentions as a bonus
+	// ("nice to have", "bonus points for"). They do not affect the match
+	// score; gap analysis reports them as nice-to-have gaps.
+	NiceToHaveSkills []string `json:"nice_to_have_skills,omitempty"`
+
 	// MinYearsExperience is the minimum years of experience required.
-	MinYearsExperience float64 `json:"min_years_experience"`
+	MinYearsExperience float64 `json:"min_years_experience" validate:"min=0"`
 
 	// MaxYearsExperience is the maximum years of experience (0 = no upper limit).
-	MaxYearsExperience float64 `json:"max_years_experience,omitempty"`
+	MaxYearsExperience float64 `json:"max_years_experience,omitempty" validate:"min=0"`
 
 	// RequiredDegreeLevel is the minimum degree level required.
 	// Accepted values: "high_school", "associate", "bachelor", "master",
@@ -73,7 +81,7 @@ type CandidateProfile struct {
 	Skills []CandidateSkill `json:"skills"`
 
 	// YearsOfExperience is the total years of professional experience.
-	YearsOfExperience float64 `json:"years_of_experience"`
+	YearsOfExperience float64 `json:"years_of_experience" validate:"min=0"`
 
 	// WorkHistory is the list of past and current positions.
 	WorkHistory []WorkHistoryEntry `json:"work_history"`
@@ -81,6 +89,15 @@ type CandidateProfile struct {
 	// Education is the list of educational qualifications.
 	Education []EducationEntry `json:"education"`
 
+	// Certifications is the list of professional certifications. A
+	// certification for a skill (see CertificationSkills) counts as
+	// evidence of that skill at an advanced level.
+	Certifications []CertificationEntry `json:"certifications,omitempty"`
+
+	// Projects is the list of personal or professional projects. Their
+	// technologies count as weak evidence of skills not listed in Skills.
+	Projects []ProjectEntry `json:"projects,omitempty"`
+
 	// LocationCity is the candidate's current city.
 	LocationCity string `json:"location_city,omitempty"`
 
@@ -93,19 +110,47 @@ type CandidateProfile struct {
 	// RemotePreference indicates the candidate's preferred work arrangement.
 	// Accepted values: "remote", "hybrid", "on_site", "any".
 	RemotePreference string `json:"remote_preference,omitempty"`
+
+	// FieldSources records the provenance of the fields a resume can
+	// fill, keyed by JSON name: "years_of_experience", "location_city" and
+	// "location_country". Fields without an entry count as parsed. The
+	// list entries carry their own Source. Provenance does not affect
+	// scoring; it lets a re-uploaded resume be merged into the profile
+	// without losing the user's edits.
+	FieldSources map[string]Provenance `json:"field_sources,omitempty"`
 }
 
+// Provenance records where a profile value came from.
+type Provenance string
+
+const (
+	// ProvenanceParsed marks a value taken from a parsed resume. An empty
+	// Provenance means the same.
+	ProvenanceParsed Provenance = "parsed"
+
+	// ProvenanceUser marks a value the user entered or edited.
+	ProvenanceUser Provenance = "user"
+)
+
 // CandidateSkill represents a single skill with optional proficiency metadata.
 type CandidateSkill struct {
 	// Name is the skill name (e.g. "Go", "Python").
-	Name string `json:"name"`
+	Name string `json:"name" validate:"required"`
 
 	// Proficiency is the self-assessed level: "beginner", "intermediate",
-	// "advanced", "expert".
+	// "advanced", "expert", another level of the proficiency scale, or a
+	// number on it such as "7" (see ProficiencyScale).
 	Proficiency string `json:"proficiency,omitempty"`
 
 	// YearsOfExperience is the number of years using this skill.
-	YearsOfExperience float64 `json:"years_of_experience,omitempty"`
+	YearsOfExperience float64 `json:"years_of_experience,omitempty" validate:"min=0"`
+
+	// Source is where the skill came from.
+	Source Provenance `json:"source,omitempty"`
+
+	// NeedsConfirmation is set on a parsed skill that a later resume no
+	// longer lists, for the user to keep or remove.
+	NeedsConfirmation bool `json:"needs_confirmation,omitempty"`
 }
 
 // WorkHistoryEntry represents a single job in the candidate's work history.
@@ -117,10 +162,56 @@ type WorkHistoryEntry struct {
 	Industry string `json:"industry,omitempty"`
 
 	// DurationMonths is the length of the role in months.
-	DurationMonths int `json:"duration_months"`
+	DurationMonths int `json:"duration_months" validate:"min=0"`
 
 	// IsCurrent indicates whether this is the candidate's current role.
 	IsCurrent bool `json:"is_current"`
+
+	// Company is the employer.
+	Company string `json:"company,omitempty"`
+
+	// StartDate and EndDate are the dates of the role as written in the
+	// resume (e.g. "Jan 2020").
+	StartDate string `json:"start_date,omitempty"`
+	EndDate   string `json:"end_date,omitempty"`
+
+	// Source is where the entry came from.
+	Source Provenance `json:"source,omitempty"`
+
+	// NeedsConfirmation is set on a parsed entry that a later resume no
+	// longer lists, for the user to keep or remove.
+	NeedsConfirmation bool `json:"needs_confirmation,omitempty"`
+}
+
+// CertificationEntry represents a single professional certification.
+type CertificationEntry struct {
+	// Name is the certification name (e.g. "Certified Kubernetes
+	// Administrator (CKA)").
+	Name string `json:"name"`
+
+	// Issuer is the issuing organization (e.g. "CNCF").
+	Issuer string `json:"issuer,omitempty"`
+
+	// Year is the year the certification was obtained (0 = unknown).
+	Year int `json:"year,omitempty"`
+
+	// Source is where the entry came from.
+	Source Provenance `json:"source,omitempty"`
+}
+
+// ProjectEntry represents a single project.
+type ProjectEntry struct {
+	// Title is the project name.
+	Title string `json:"title"`
+
+	// Description describes the project.
+	Description string `json:"description,omitempty"`
+
+	// Technologies lists the technologies the project used.
+	Technologies []string `json:"technologies,omitempty"`
+
+	// Source is where the entry came from.
+	Source Provenance `json:"source,omitempty"`
 }
 
 // EducationEntry represents a single educational qualification.
@@ -130,6 +221,9 @@ type EducationEntry struct {
 
 	// FieldOfStudy is the field or major (e.g. "Computer Science").
 	FieldOfStudy string `json:"field_of_study,omitempty"`
+
+	// Source is where the entry came from.
+	Source Provenance `json:"source,omitempty"`
 }
 
 // ScoreBreakdown holds the individual component scores and the final result.
@@ -160,6 +254,236 @@ type ScoreBreakdown struct {
 
 	// MatchedPreferredSkills lists preferred skills the candidate has.
 	MatchedPreferredSkills []string `json:"matched_preferred_skills,omitempty"`
+
+	// PartialMatches lists the missing required skills that earned partial
+	// credit through a related skill. It is only set when the weights'
+	// RelatedSkillCredit is positive.
+	PartialMatches []PartialMatch `json:"partial_matches,omitempty"`
+
+	// Weights are the component weights the overall score was computed
+	// with, after renormalization.
+	Weights ScoringWeights `json:"weights"`
+
+	// Warnings lists inconsistencies detected in the inputs (e.g. a job whose
+	// maximum experience is below its minimum, or weights that had to be
+	// renormalized). The score is still computed.
+	Warnings []string `json:"warnings,omitempty"`
+
+	// Explanation details how each component score was reached. The
+	// scoring endpoints only return it with verbose=true.
+	Explanation *Explanation `json:"explanation,omitempty"`
+}
+
+// Explanation breaks a ScoreBreakdown down into the inputs and
+// contributions behind each component score.
+type Explanation struct {
+	SkillMatch        SkillMatchExplanation        `json:"skill_match"`
+	ExperienceMatch   ExperienceMatchExplanation   `json:"experience_match"`
+	EducationMatch    EducationMatchExplanation    `json:"education_match"`
+	LocationFit       LocationFitExplanation       `json:"location_fit"`
+	IndustryRelevance IndustryRelevanceExplanation `json:"industry_relevance"`
+}
+
+// ComponentExplanation is the part of a component's explanation shared by
+// all components.
+type ComponentExplanation struct {
+	// Score is the component score [0, 1].
+	Score float64 `json:"score"`
+
+	// Weight is the component's weight in the overall score.
+	Weight float64 `json:"weight"`
+
+	// Points is the component's contribution to the overall score
+	// (Score * Weight * 100). The points of all components add up to the
+	// overall score.
+	Points float64 `json:"points"`
+
+	// Note explains a score that has no contributions, such as a job
+	// without required skills.
+	Note string `json:"note,omitempty"`
+}
+
+// SkillMatchExplanation explains the skill match score. Without a Note, the
+// contributions of the required and preferred skills add up to Score.
+type SkillMatchExplanation struct {
+	ComponentExplanation
+
+	// RequiredSkills explains each required skill; together they make up
+	// 80% of the score.
+	RequiredSkills []SkillContribution `json:"required_skills,omitempty"`
+
+	// PreferredSkills explains each preferred skill; together they make up
+	// 20% of the score.
+	PreferredSkills []SkillContribution `json:"preferred_skills,omitempty"`
+
+	// warnings are the breakdown warnings about candidate proficiencies.
+	warnings []string
+}
+
+// SkillContribution explains how one job skill contributes to the skill
+// match score.
+type SkillContribution struct {
+	// Skill is the skill as listed in the job.
+	Skill string `json:"skill"`
+
+	// Matched reports whether the candidate has the skill.
+	Matched bool `json:"matched"`
+
+	// MatchedAs is the candidate skill that matched it.
+	MatchedAs string `json:"matched_as,omitempty"`
+
+	// MatchType is "exact" when MatchedAs is the same skill name, or
+	// "alias" when it is an alias or a variant (e.g. "k8s" for
+	// "Kubernetes").
+	MatchType string `json:"match_type,omitempty"`
+
+	// Proficiency is the candidate's proficiency in MatchedAs.
+	Proficiency string `json:"proficiency,omitempty"`
+
+	// Evidence is what the match is based on: "skill" for a listed skill,
+	// "certification" for a skill backed by a certification, or "project"
+	// for a skill only used in a project.
+	Evidence string `json:"evidence,omitempty"`
+
+	// Source is the certification or project behind Evidence.
+	Source string `json:"source,omitempty"`
+
+	// ProficiencyMultiplier is the weight the proficiency applies to a
+	// matched required skill: 0.9 at least for a certified skill, and 0.5
+	// for a project skill. Preferred skills count fully at any
+	// proficiency.
+	ProficiencyMultiplier float64 `json:"proficiency_multiplier"`
+
+	// RelatedSkill is the candidate skill that earned partial credit for
+	// an unmatched required skill, when related skill credit is enabled.
+	RelatedSkill string `json:"related_skill,omitempty"`
+
+	// RelatedSkillSimilarity is how similar the ontology considers
+	// RelatedSkill to Skill [0, 1].
+	RelatedSkillSimilarity float64 `json:"related_skill_similarity,omitempty"`
+
+	// Contribution is the skill's contribution to the skill match score.
+	Contribution float64 `json:"contribution"`
+}
+
+// PartialMatch is a missing required skill credited through a related
+// skill the candidate has.
+type PartialMatch struct {
+	// Skill is the required skill as listed in the job.
+	Skill string `json:"skill"`
+
+	// RelatedSkill is the candidate skill that earned the credit.
+	RelatedSkill string `json:"related_skill"`
+
+	// Similarity is how similar the ontology considers the two skills
+	// [0, 1].
+	Similarity float64 `json:"similarity"`
+
+	// ProficiencyMultiplier is the fraction of a full match the related
+	// skill earned: similarity × related_skill_credit × its proficiency
+	// weight, capped at 0.4 so that it stays below any real match.
+	ProficiencyMultiplier float64 `json:"proficiency_multiplier"`
+
+	// Contribution is the credit's contribution to the skill match score.
+	Contribution float64 `json:"contribution"`
+}
+
+// ExperienceMatchExplanation explains the experience match score: 70% years
+// of experience and 30% job title similarity.
+type ExperienceMatchExplanation struct {
+	ComponentExplanation
+
+	// CandidateYears is the candidate's years of experience.
+	CandidateYears float64 `json:"candidate_years"`
+
+	// CandidateYearsSource is "profile", or "work_history" when the years
+	// were summed from the work history.
+	CandidateYearsSource string `json:"candidate_years_source"`
+
+	// RequiredYears is the job's minimum years of experience (0 = none).
+	RequiredYears float64 `json:"required_years"`
+
+	// RequiredYearsSource is "job", or "experience_level" when the minimum
+	// was inferred from the job's experience level.
+	RequiredYearsSource string `json:"required_years_source,omitempty"`
+
+	// MaxYears is the job's maximum years of experience (0 = no limit).
+	MaxYears float64 `json:"max_years,omitempty"`
+
+	// YearsScore is the score [0, 1] of CandidateYears against the range.
+	YearsScore float64 `json:"years_score"`
+
+	// YearsContribution is YearsScore's contribution to Score.
+	YearsContribution float64 `json:"years_contribution"`
+
+	// TitleSimilarity is the similarity [0, 1] of the closest past title to
+	// the job title; 0.5 when either is unknown.
+	TitleSimilarity float64 `json:"title_similarity"`
+
+	// ClosestTitle is the past title most similar to the job title.
+	ClosestTitle string `json:"closest_title,omitempty"`
+
+	// TitleContribution is TitleSimilarity's contribution to Score.
+	TitleContribution float64 `json:"title_contribution"`
+}
+
+// EducationMatchExplanation explains the education match score. Score is
+// DegreeScore plus FieldContribution.
+type EducationMatchExplanation struct {
+	ComponentExplanation
+
+	// RequiredDegreeLevel is the job's minimum degree level.
+	RequiredDegreeLevel string `json:"required_degree_level,omitempty"`
+
+	// CandidateDegreeLevel is the candidate's highest degree level.
+	CandidateDegreeLevel string `json:"candidate_degree_level,omitempty"`
+
+	// LevelsBelow is how many degree levels the candidate's highest degree
+	// is below the required one (0 when it meets it).
+	LevelsBelow int `json:"levels_below"`
+
+	// DegreeScore is the score [0, 1] of the degree level comparison.
+	DegreeScore float64 `json:"degree_score"`
+
+	// FieldOfStudy is the field of the candidate's highest degree.
+	FieldOfStudy string `json:"field_of_study,omitempty"`
+
+	// FieldMatched reports whether FieldOfStudy is a preferred field.
+	FieldMatched bool `json:"field_matched"`
+
+	// FieldContribution is the preferred field bonus added to Score.
+	FieldContribution float64 `json:"field_contribution"`
+}
+
+// LocationFitExplanation explains the location fit score.
+type LocationFitExplanation struct {
+	ComponentExplanation
+
+	// JobLocationType is the job's work arrangement.
+	JobLocationType string `json:"job_location_type,omitempty"`
+
+	// CandidatePreference is the candidate's preferred work arrangement.
+	CandidatePreference string `json:"candidate_preference,omitempty"`
+
+	// Match is how the score was reached: "preference_matches",
+	// "different_preference", "same_city", "same_country",
+	// "willing_to_relocate" or "different_location".
+	Match string `json:"match"`
+}
+
+// IndustryRelevanceExplanation explains the industry relevance score.
+type IndustryRelevanceExplanation struct {
+	ComponentExplanation
+
+	// JobIndustry is the job's industry.
+	JobIndustry string `json:"job_industry,omitempty"`
+
+	// MatchedIndustry is the work history industry that matched.
+	MatchedIndustry string `json:"matched_industry,omitempty"`
+
+	// Match is how the score was reached: "no_requirement", "direct",
+	// "related", "no_work_history" or "none".
+	Match string `json:"match"`
 }
 
 // ScoreRequest is the input to the scoring API endpoint.
@@ -169,6 +493,14 @@ type ScoreRequest struct {
 
 	// Job is the job requirements to score against.
 	Job JobRequirements `json:"job"`
+
+	// JobDescription is the raw text of the job posting. When set, the
+	// requirements are extracted from it and any fields set in Job
+	// override the extracted ones.
+	JobDescription string `json:"job_description,omitempty"`
+
+	// Weights overrides the default component weights.
+	Weights *ScoringWeights `json:"weights,omitempty"`
 }
 
 // ScoreResponse is the output of the scoring API endpoint.
@@ -181,4 +513,64 @@ type ScoreResponse struct {
 
 	// Error contains an error message when Success is false.
 	Error string `json:"error,omitempty"`
+
+	// Errors lists the invalid request fields when the request failed
+	// validation (422).
+	Errors validation.Errors `json:"errors,omitempty"`
+}
+
+// ScoreResult is the score of one candidate within a batch.
+type ScoreResult struct {
+	// Index is the candidate's position in the request.
+	Index int `json:"index"`
+
+	// CandidateID echoes the caller-supplied candidate identifier, if any.
+	CandidateID string `json:"candidate_id,omitempty"`
+
+	// Rank is the candidate's position when the batch is ordered by overall
+	// score (1 = best). Candidates with equal scores share a rank.
+	Rank int `json:"rank"`
+
+	// Score is the candidate's score breakdown.
+	Score ScoreBreakdown `json:"score"`
+}
+
+// BatchCandidate is one candidate in a batch scoring request.
+type BatchCandidate struct {
+	// CandidateID is an optional caller-supplied identifier echoed in the
+	// result.
+	CandidateID string `json:"candidate_id,omitempty"`
+
+	// Profile is the candidate's professional profile.
+	Profile CandidateProfile `json:"profile"`
+}
+
+// BatchScoreRequest is the input to the batch scoring API endpoint.
+type BatchScoreRequest struct {
+	// Job is the job requirements every candidate is scored against.
+	Job JobRequirements `json:"job"`
+
+	// JobDescription is the raw text of the job posting. When set, the
+	// requirements are extracted from it and any fields set in Job
+	// override the extracted ones.
+	JobDescription string `json:"job_description,omitempty"`
+
+	// Candidates is the list of candidates to score.
+	Candidates []BatchCandidate `json:"candidates"`
+
+	// Weights overrides the default component weights for every candidate.
+	Weights *ScoringWeights `json:"weights,omitempty"`
+}
+
+// BatchScoreResponse is the output of the batch scoring API endpoint.
+type BatchScoreResponse struct {
+	// Success indicates whether the scoring succeeded.
+	Success bool `json:"success"`
+
+	// Data contains one result per candidate, in request order, when
+	// Success is true.
+	Data []ScoreResult `json:"data,omitempty"`
+
+	// Error contains an error message when Success is false.
+	Error string `json:"error,omitempty"`
 }
@@ -0,0 +1,115 @@
+package scorer
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// ScoringWeights are the weights of the score components. Callers can
+// override the defaults per request, e.g. to ignore education for a role
+// that does not need a degree. Weights that do not sum to 1.0 are
+// renormalized.
+type ScoringWeights struct {
+	SkillMatch        float64 `json:"skill_match"`
+	ExperienceMatch   float64 `json:"experience_match"`
+	EducationMatch    float64 `json:"education_match"`
+	LocationFit       float64 `json:"location_fit"`
+	IndustryRelevance float64 `json:"industry_relevance"`
+
+	// RelatedSkillCredit enables partial credit for required skills the
+	// candidate lacks but has a related skill for: the related skill earns
+	// this fraction [0, 1] of its similarity-scaled proficiency credit. It
+	// is not a component weight and is never renormalized; 0, the default,
+	// disables partial credit.
+	RelatedSkillCredit float64 `json:"related_skill_credit,omitempty"`
+
+	// ProficiencyScale is the rubric candidate proficiencies are scored
+	// on; nil means DefaultProficiencyScale. Like RelatedSkillCredit it is
+	// not renormalized.
+	ProficiencyScale *ProficiencyScale `json:"proficiency_scale,omitempty"`
+}
+
+// ErrNegativeWeight is returned for scoring weights with a negative
+// component.
+var ErrNegativeWeight = errors.New("scoring weights must not be negative")
+
+// ErrZeroWeights is returned for scoring weights that are all zero.
+var ErrZeroWeights = errors.New("scoring weights must not all be zero")
+
+// ErrRelatedSkillCredit is returned for a RelatedSkillCredit outside [0, 1].
+var ErrRelatedSkillCredit = errors.New("related_skill_credit must be between 0 and 1")
+
+// weightSumTolerance is how far the weights may sum from 1.0 before they
+// are renormalized.
+const weightSumTolerance = 1e-6
+
+// DefaultScoringWeights returns the weights of the LearnBot specification
+// formula, used by Calculate.
+func DefaultScoringWeights() ScoringWeights {
+	return ScoringWeights{
+		SkillMatch:        WeightSkillMatch,
+		ExperienceMatch:   WeightExperienceMatch,
+		EducationMatch:    WeightEducationMatch,
+		LocationFit:       WeightLocationFit,
+		IndustryRelevance: WeightIndustryRelevance,
+	}
+}
+
+// Validate reports whether the weights can be used: none may be negative,
+// at least one must be positive, RelatedSkillCredit must not exceed 1 and a
+// ProficiencyScale must be valid.
+func (w ScoringWeights) Validate() error {
+	if w.ProficiencyScale != nil {
+		if err := w.ProficiencyScale.Validate(); err != nil {
+			return err
+		}
+	}
+	if w.RelatedSkillCredit < 0 || w.RelatedSkillCredit > 1 {
+		return ErrRelatedSkillCredit
+	}
+	if w.SkillMatch < 0 || w.ExperienceMatch < 0 || w.EducationMatch < 0 ||
+		w.LocationFit < 0 || w.IndustryRelevance < 0 {
+		return ErrNegativeWeight
+	}
+	if w.sum() == 0 {
+		return ErrZeroWeights
+	}
+	return nil
+}
+
+// normalize validates the weights and scales them to sum to 1.0. When they
+// had to be scaled it also returns a warning for the score breakdown.
+func (w ScoringWeights) normalize() (ScoringWeights, string, error) {
+	if err := w.Validate(); err != nil {
+		return ScoringWeights{}, "", err
+	}
+	sum := w.sum()
+	if math.Abs(sum-1.0) <= weightSumTolerance {
+		return w, "", nil
+	}
+	normalized := ScoringWeights{
+		SkillMatch:        w.SkillMatch / sum,
+		ExperienceMatch:   w.ExperienceMatch / sum,
+		EducationMatch:    w.EducationMatch / sum,
+		LocationFit:       w.LocationFit / sum,
+		IndustryRelevance: w.IndustryRelevance / sum,
+
+		RelatedSkillCredit: w.RelatedSkillCredit,
+		ProficiencyScale:   w.ProficiencyScale,
+	}
+	return normalized, fmt.Sprintf("scoring weights sum to %.4g, not 1; they were renormalized", sum), nil
+}
+
+// proficiencyScale returns the scale proficiencies are scored on.
+func (w ScoringWeights) proficiencyScale() ProficiencyScale {
+	if w.ProficiencyScale == nil {
+		return DefaultProficiencyScale()
+	}
+	return *w.ProficiencyScale
+}
+
+// sum returns the total of the weights.
+func (w ScoringWeights) sum() float64 {
+	return w.SkillMatch + w.ExperienceMatch + w.EducationMatch + w.LocationFit + w.IndustryRelevance
+}
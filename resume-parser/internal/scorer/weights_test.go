@@ -0,0 +1,137 @@
+package scorer
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestScoringWeights_Normalize(t *testing.T) {
+	w, warning, err := DefaultScoringWeights().normalize()
+	if err != nil || warning != "" || w != DefaultScoringWeights() {
+		t.Errorf("defaults: got %+v, %q, %v", w, warning, err)
+	}
+
+	w, warning, err = ScoringWeights{SkillMatch: 2, ExperienceMatch: 1, EducationMatch: 0, LocationFit: 1, IndustryRelevance: 0}.normalize()
+	if err != nil {
+		t.Fatalf("normalize: %v", err)
+	}
+	if w.SkillMatch != 0.5 || w.ExperienceMatch != 0.25 || w.LocationFit != 0.25 || w.EducationMatch != 0 {
+		t.Errorf("expected weights scaled to sum to 1, got %+v", w)
+	}
+	if !strings.Contains(warning, "renormalized") {
+		t.Errorf("expected a renormalization warning, got %q", warning)
+	}
+
+	if _, _, err := (ScoringWeights{}).normalize(); !errors.Is(err, ErrZeroWeights) {
+		t.Errorf("expected ErrZeroWeights, got %v", err)
+	}
+	if _, _, err := (ScoringWeights{SkillMatch: 1, EducationMatch: -0.1}).normalize(); !errors.Is(err, ErrNegativeWeight) {
+		t.Errorf("expected ErrNegativeWeight, got %v", err)
+	}
+}
+
+func TestCalculateWithWeights_DefaultVsSkewed(t *testing.T) {
+	// A strong engineer without a degree, applying to a job that asks for
+	// one.
+	profile := CandidateProfile{
+		Skills:            []CandidateSkill{{Name: "Go", Proficiency: "expert"}, {Name: "Docker", Proficiency: "advanced"}},
+		YearsOfExperience: 6,
+		WorkHistory:       []WorkHistoryEntry{{Title: "Backend Engineer", Industry: "Software", DurationMonths: 72}},
+		RemotePreference:  "remote",
+	}
+	job := JobRequirements{
+		Title:               "Backend Engineer",
+		RequiredSkills:      []string{"Go", "Docker"},
+		MinYearsExperience:  5,
+		RequiredDegreeLevel: "bachelor",
+		LocationType:        "remote",
+		Industry:            "Software",
+	}
+
+	def := Calculate(profile, job)
+	if def.Weights != DefaultScoringWeights() {
+		t.Errorf("expected the default weights to be echoed, got %+v", def.Weights)
+	}
+
+	// A startup that does not care about degrees.
+	startup := ScoringWeights{SkillMatch: 0.5, ExperienceMatch: 0.3, EducationMatch: 0, LocationFit: 0.1, IndustryRelevance: 0.1}
+	skewed, err := CalculateWithWeights(profile, job, startup)
+	if err != nil {
+		t.Fatalf("CalculateWithWeights: %v", err)
+	}
+	if skewed.OverallScore <= def.OverallScore {
+		t.Errorf("expected ignoring education to raise the score: default %.2f, skewed %.2f", def.OverallScore, skewed.OverallScore)
+	}
+	if skewed.Weights != startup || len(skewed.Warnings) != 0 {
+		t.Errorf("expected the weights echoed without warnings, got %+v, %v", skewed.Weights, skewed.Warnings)
+	}
+	// Component scores do not depend on the weights.
+	if skewed.SkillMatchScore != def.SkillMatchScore || skewed.EducationMatchScore != def.EducationMatchScore {
+		t.Errorf("component scores changed with the weights: %+v vs %+v", skewed, def)
+	}
+	if e := skewed.Explanation.EducationMatch; e.Weight != 0 || e.Points != 0 {
+		t.Errorf("expected education to carry no points, got %+v", e.ComponentExplanation)
+	}
+
+	// The same ratios at another scale give the same score, with a warning.
+	scaled, err := CalculateWithWeights(profile, job, ScoringWeights{SkillMatch: 5, ExperienceMatch: 3, LocationFit: 1, IndustryRelevance: 1})
+	if err != nil {
+		t.Fatalf("CalculateWithWeights: %v", err)
+	}
+	if scaled.OverallScore != skewed.OverallScore || len(scaled.Warnings) != 1 {
+		t.Errorf("expected the renormalized score %.2f with a warning, got %.2f, %v", skewed.OverallScore, scaled.OverallScore, scaled.Warnings)
+	}
+}
+
+func TestScoreHandler_Weights(t *testing.T) {
+	h := buildTestScorerHandler()
+	profile := CandidateProfile{Skills: []CandidateSkill{{Name: "Go", Proficiency: "expert"}}}
+	job := JobRequirements{RequiredSkills: []string{"Go"}, RequiredDegreeLevel: "master"}
+
+	post := func(weights *ScoringWeights) (int, ScoreResponse) {
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/score",
+			buildScoreRequest(t, ScoreRequest{Profile: profile, Job: job, Weights: weights}))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		h.ScoreHandler(w, req)
+		var resp ScoreResponse
+		if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		return w.Code, resp
+	}
+
+	code, resp := post(&ScoringWeights{SkillMatch: 1, ExperienceMatch: 1})
+	if code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", code, resp.Error)
+	}
+	if got := resp.Data.Weights; got.SkillMatch != 0.5 || got.ExperienceMatch != 0.5 || got.EducationMatch != 0 {
+		t.Errorf("expected the effective weights echoed, got %+v", got)
+	}
+	if len(resp.Data.Warnings) != 1 {
+		t.Errorf("expected a renormalization warning, got %v", resp.Data.Warnings)
+	}
+
+	if code, resp := post(&ScoringWeights{}); code != http.StatusUnprocessableEntity || resp.Success {
+		t.Errorf("expected 422 for all-zero weights, got %d", code)
+	}
+	if code, _ := post(nil); code != http.StatusOK {
+		t.Errorf("expected 200 without weights, got %d", code)
+	}
+}
+
+func TestBatchScoreHandler_ZeroWeights(t *testing.T) {
+	h := buildTestScorerHandler()
+	req := BatchScoreRequest{
+		Job:        batchTestJob(),
+		Candidates: []BatchCandidate{{Profile: batchTestProfiles(1)[0]}},
+		Weights:    &ScoringWeights{},
+	}
+	if w, _ := postBatch(t, h, req); w.Code != http.StatusUnprocessableEntity {
+		t.Errorf("expected 422 for all-zero weights, got %d", w.Code)
+	}
+}
@@ -0,0 +1,114 @@
+// Package taxonomy – fuzzy.go implements the typo-tolerant fallback used
+// when a skill string has no exact or alias match.
+package taxonomy
+
+// MinFuzzyConfidence is the lowest similarity a fuzzy match is accepted at.
+// Similarity is 1 - edits/length of the longer string, so one typo is
+// accepted in a five-letter skill and two in a ten-letter one.
+const MinFuzzyConfidence = 0.8
+
+// fuzzyTerm is a canonical name, ID, or alias fuzzy matching compares
+// against.
+type fuzzyTerm struct {
+	runes []rune
+	text  string
+	node  *SkillNode
+}
+
+// indexFuzzyTerm adds s as a fuzzy matching term for node.
+func (t *Taxonomy) indexFuzzyTerm(s string, node *SkillNode) {
+	norm := normalise(s)
+	if t.fuzzySeen[norm] {
+		return
+	}
+	t.fuzzySeen[norm] = true
+	t.fuzzyTerms = append(t.fuzzyTerms, fuzzyTerm{runes: []rune(norm), text: norm, node: node})
+}
+
+// maxFuzzyEdits is the most edits a fuzzy match of strings whose shorter
+// one has n runes may need. Short names get none: "Go" and "C" are one
+// edit apart, as are "Java" and "Lava", so skills of four runes or fewer
+// only ever match exactly.
+func maxFuzzyEdits(n int) int {
+	switch {
+	case n <= 4:
+		return 0
+	case n <= 7:
+		return 1
+	case n <= 11:
+		return 2
+	default:
+		return 3
+	}
+}
+
+// fuzzyMatch returns the skill whose canonical name, ID, or alias is the
+// fewest edits from norm, with its similarity, or nil if none is within the
+// length-aware edit bound and MinFuzzyConfidence. Equally close terms are
+// ranked by Jaro-Winkler similarity, which favours a shared prefix.
+func (t *Taxonomy) fuzzyMatch(norm string) (*SkillNode, float64) {
+	input := []rune(norm)
+	var best *SkillNode
+	bestEdits, bestJW, bestScore := 0, 0.0, 0.0
+	for _, term := range t.fuzzyTerms {
+		bound := maxFuzzyEdits(min(len(input), len(term.runes)))
+		if bound == 0 {
+			continue
+		}
+		if best != nil && bound > bestEdits {
+			// Nothing farther than the best match so far can win.
+			bound = bestEdits
+		}
+		d := editDistance(input, term.runes, bound)
+		if d > bound {
+			continue
+		}
+		score := 1 - float64(d)/float64(max(len(input), len(term.runes)))
+		if score < MinFuzzyConfidence {
+			continue
+		}
+		jw := jaroWinkler(norm, term.text)
+		if best == nil || d < bestEdits || (d == bestEdits && jw > bestJW) {
+			best, bestEdits, bestJW, bestScore = term.node, d, jw, score
+		}
+	}
+	return best, bestScore
+}
+
+// editDistance returns the optimal string alignment distance between a and
+// b: the number of insertions, deletions, substitutions, and transpositions
+// of adjacent runes that turn one into the other. It stops early and
+// returns bound+1 once the distance is known to exceed bound.
+func editDistance(a, b []rune, bound int) int {
+	if d := len(a) - len(b); d > bound || -d > bound {
+		return bound + 1
+	}
+	// prev2, prev, and cur are the rows for a[:i-2], a[:i-1], and a[:i].
+	prev2 := make([]int, len(b)+1)
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		rowMin := cur[0]
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			d := min(min(prev[j]+1, cur[j-1]+1), prev[j-1]+cost)
+			if i > 1 && j > 1 && a[i-1] == b[j-2] && a[i-2] == b[j-1] {
+				d = min(d, prev2[j-2]+1)
+			}
+			cur[j] = d
+			rowMin = min(rowMin, d)
+		}
+		if rowMin > bound {
+			return bound + 1
+		}
+		prev2, prev, cur = prev, cur, prev2
+	}
+	return prev[len(b)]
+}
@@ -0,0 +1,193 @@
+// Package taxonomy – graph.go implements queries over the prerequisite and
+// related-skill links of the ontology.
+//
+// The ontology is maintained by hand, so the queries never trust it to be a
+// DAG: links that would close a cycle, or that name a skill missing from the
+// taxonomy, are skipped and reported instead of followed.
+package taxonomy
+
+import (
+	"errors"
+	"fmt"
+)
+
+// MaxRelatedDepth is the greatest depth GetRelated follows links to.
+const MaxRelatedDepth = 3
+
+// ErrUnknownSkill is returned by the graph queries for a skill ID that is not
+// in the taxonomy.
+var ErrUnknownSkill = errors.New("taxonomy: unknown skill")
+
+// Reasons reported in SkippedEdge.
+const (
+	skipCycle        = "cycle"
+	skipUnknownSkill = "unknown_skill"
+)
+
+// GetPrerequisiteChain returns every skill that must be learned before id,
+// directly or transitively, in topological order.
+func (t *Taxonomy) GetPrerequisiteChain(id string) (PrerequisiteChain, error) {
+	node := t.byID[id]
+	if node == nil {
+		return PrerequisiteChain{}, fmt.Errorf("%w: %s", ErrUnknownSkill, id)
+	}
+	w := t.walkPrerequisites(id, nil)
+	return PrerequisiteChain{
+		Skill:         w.graphSkill(id),
+		Prerequisites: w.graphSkills(w.order[:len(w.order)-1]),
+		SkippedEdges:  w.skipped,
+	}, nil
+}
+
+// GetRelated returns the skills reachable from id through related-skill
+// links, at most depth links away, nearest first. A depth below 1 means 1 and
+// one above MaxRelatedDepth means MaxRelatedDepth. Each skill is listed once,
+// at its shortest distance; id itself is never listed.
+func (t *Taxonomy) GetRelated(id string, depth int) ([]RelatedSkill, error) {
+	if t.byID[id] == nil {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownSkill, id)
+	}
+	depth = min(max(depth, 1), MaxRelatedDepth)
+
+	seen := map[string]bool{id: true}
+	frontier := []string{id}
+	var out []RelatedSkill
+	for d := 1; d <= depth && len(frontier) > 0; d++ {
+		var next []string
+		for _, from := range frontier {
+			for _, rel := range t.byID[from].RelatedSkills {
+				node := t.byID[rel]
+				if node == nil || seen[rel] {
+					continue
+				}
+				seen[rel] = true
+				next = append(next, rel)
+				out = append(out, RelatedSkill{Skill: graphSkill(node), Distance: d, Via: from})
+			}
+		}
+		frontier = next
+	}
+	return out, nil
+}
+
+// ShortestLearningPath returns the skills a candidate who has fromSkills
+// still needs to learn to reach toSkill, in an order they can be learned in.
+//
+// Every prerequisite is required, so the shortest path is the target plus
+// its transitive prerequisites the candidate does not have. A skill the
+// candidate has is assumed to include its own prerequisites: someone who
+// knows TypeScript is not sent back to learn JavaScript. fromSkills are
+// matched by canonical ID, name, or alias; toSkill is a canonical ID.
+func (t *Taxonomy) ShortestLearningPath(fromSkills []string, toSkill string) (LearningPath, error) {
+	if t.byID[toSkill] == nil {
+		return LearningPath{}, fmt.Errorf("%w: %s", ErrUnknownSkill, toSkill)
+	}
+	known := make(map[string]bool, len(fromSkills))
+	var unrecognized []string
+	for _, s := range fromSkills {
+		if node := t.LookupName(s); node != nil {
+			known[node.ID] = true
+		} else {
+			unrecognized = append(unrecognized, s)
+		}
+	}
+
+	path := LearningPath{
+		Target:             graphSkill(t.byID[toSkill]),
+		Steps:              []GraphSkill{},
+		UnrecognizedSkills: unrecognized,
+	}
+	if known[toSkill] {
+		path.AlreadyKnown = true
+		return path, nil
+	}
+	w := t.walkPrerequisites(toSkill, known)
+	path.Target = w.graphSkill(toSkill)
+	path.Steps = w.graphSkills(w.order)
+	path.SkippedEdges = w.skipped
+	return path, nil
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Prerequisite walk
+// ─────────────────────────────────────────────────────────────────────────────
+
+// prerequisiteWalk is a depth-first walk of the prerequisite links.
+type prerequisiteWalk struct {
+	t     *Taxonomy
+	known map[string]bool
+
+	// state is 1 while a skill's prerequisites are being walked and 2 once
+	// it has been added to order.
+	state map[string]int
+
+	// order lists the walked skills in post-order, i.e. topologically, with
+	// the starting skill last.
+	order   []string
+	skipped []SkippedEdge
+}
+
+// walkPrerequisites walks the prerequisites of id, not descending into the
+// skills in known. id must be in the taxonomy.
+func (t *Taxonomy) walkPrerequisites(id string, known map[string]bool) *prerequisiteWalk {
+	w := &prerequisiteWalk{t: t, known: known, state: make(map[string]int)}
+	w.visit(id)
+	return w
+}
+
+func (w *prerequisiteWalk) visit(id string) {
+	w.state[id] = 1
+	for _, p := range w.t.byID[id].Prerequisites {
+		switch {
+		case w.t.byID[p] == nil:
+			w.skipped = append(w.skipped, SkippedEdge{SkillID: id, PrerequisiteID: p, Reason: skipUnknownSkill})
+		case w.known[p]:
+			// The candidate has p, and with it p's own prerequisites.
+		case w.state[p] == 1:
+			w.skipped = append(w.skipped, SkippedEdge{SkillID: id, PrerequisiteID: p, Reason: skipCycle})
+		case w.state[p] == 0:
+			w.visit(p)
+		}
+	}
+	w.state[id] = 2
+	w.order = append(w.order, id)
+}
+
+// graphSkill returns id with the prerequisite links the walk kept.
+func (w *prerequisiteWalk) graphSkill(id string) GraphSkill {
+	node := w.t.byID[id]
+	s := graphSkill(node)
+	for _, p := range node.Prerequisites {
+		if w.state[p] == 2 && !w.isSkipped(id, p) {
+			s.Prerequisites = append(s.Prerequisites, p)
+		}
+	}
+	return s
+}
+
+func (w *prerequisiteWalk) graphSkills(ids []string) []GraphSkill {
+	out := make([]GraphSkill, 0, len(ids))
+	for _, id := range ids {
+		out = append(out, w.graphSkill(id))
+	}
+	return out
+}
+
+func (w *prerequisiteWalk) isSkipped(id, p string) bool {
+	for _, e := range w.skipped {
+		if e.SkillID == id && e.PrerequisiteID == p {
+			return true
+		}
+	}
+	return false
+}
+
+// graphSkill returns node without its prerequisite links.
+func graphSkill(node *SkillNode) GraphSkill {
+	return GraphSkill{
+		ID:            node.ID,
+		CanonicalName: node.CanonicalName,
+		Domain:        node.Domain,
+		Category:      node.Category,
+	}
+}
@@ -0,0 +1,200 @@
+package taxonomy
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// cyclicTaxonomy is a hand-maintained-looking fixture with mistakes in it:
+//
+//	c → b → a → c   (a prerequisite cycle)
+//	d → d           (a skill listed as its own prerequisite)
+//	e → missing     (a prerequisite that is not in the taxonomy)
+//	x ↔ y ↔ z       (related-skill links in both directions)
+func cyclicTaxonomy() *Taxonomy {
+	return newTaxonomy([]SkillNode{
+		{ID: "a", CanonicalName: "A", Category: CategoryLanguage, Prerequisites: []string{"c"}},
+		{ID: "b", CanonicalName: "B", Category: CategoryBackend, Prerequisites: []string{"a"}},
+		{ID: "c", CanonicalName: "C", Category: CategoryBackend, Prerequisites: []string{"b"}},
+		{ID: "d", CanonicalName: "D", Prerequisites: []string{"d"}},
+		{ID: "e", CanonicalName: "E", Prerequisites: []string{"missing", "d"}},
+		{ID: "x", CanonicalName: "X", RelatedSkills: []string{"y", "x", "missing"}},
+		{ID: "y", CanonicalName: "Y", RelatedSkills: []string{"x", "z"}},
+		{ID: "z", CanonicalName: "Z", RelatedSkills: []string{"y", "x"}},
+	})
+}
+
+func ids(skills []GraphSkill) []string {
+	out := []string{}
+	for _, s := range skills {
+		out = append(out, s.ID)
+	}
+	return out
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// GetPrerequisiteChain
+// ─────────────────────────────────────────────────────────────────────────────
+
+func TestGetPrerequisiteChain_TopologicalOrder(t *testing.T) {
+	chain, err := New().GetPrerequisiteChain("nestjs")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// NestJS needs Node.js and TypeScript, both of which need JavaScript.
+	if got, want := ids(chain.Prerequisites), []string{"javascript", "nodejs", "typescript"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("prerequisites = %v, want %v", got, want)
+	}
+	if chain.Skill.CanonicalName != "NestJS" || chain.Skill.Category != CategoryBackend {
+		t.Errorf("skill = %+v, want NestJS (backend)", chain.Skill)
+	}
+	if !reflect.DeepEqual(chain.Skill.Prerequisites, []string{"nodejs", "typescript"}) {
+		t.Errorf("skill prerequisites = %v", chain.Skill.Prerequisites)
+	}
+	if len(chain.SkippedEdges) != 0 {
+		t.Errorf("expected no skipped edges in the built-in ontology, got %+v", chain.SkippedEdges)
+	}
+}
+
+func TestGetPrerequisiteChain_NoPrerequisites(t *testing.T) {
+	chain, err := New().GetPrerequisiteChain("go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if chain.Prerequisites == nil || len(chain.Prerequisites) != 0 {
+		t.Errorf("expected an empty prerequisite list, got %v", chain.Prerequisites)
+	}
+}
+
+func TestGetPrerequisiteChain_Cycles(t *testing.T) {
+	tax := cyclicTaxonomy()
+
+	chain, err := tax.GetPrerequisiteChain("c")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := ids(chain.Prerequisites), []string{"a", "b"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("prerequisites = %v, want %v", got, want)
+	}
+	want := []SkippedEdge{{SkillID: "a", PrerequisiteID: "c", Reason: "cycle"}}
+	if !reflect.DeepEqual(chain.SkippedEdges, want) {
+		t.Errorf("skipped edges = %+v, want %+v", chain.SkippedEdges, want)
+	}
+	// The edge closing the cycle is left out of the returned DAG.
+	if p := chain.Prerequisites[0].Prerequisites; len(p) != 0 {
+		t.Errorf("a's prerequisites = %v, want none", p)
+	}
+
+	chain, err = tax.GetPrerequisiteChain("e")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := ids(chain.Prerequisites); !reflect.DeepEqual(got, []string{"d"}) {
+		t.Errorf("prerequisites = %v, want [d]", got)
+	}
+	want = []SkippedEdge{
+		{SkillID: "e", PrerequisiteID: "missing", Reason: "unknown_skill"},
+		{SkillID: "d", PrerequisiteID: "d", Reason: "cycle"},
+	}
+	if !reflect.DeepEqual(chain.SkippedEdges, want) {
+		t.Errorf("skipped edges = %+v, want %+v", chain.SkippedEdges, want)
+	}
+}
+
+func TestGetPrerequisiteChain_UnknownSkill(t *testing.T) {
+	if _, err := New().GetPrerequisiteChain("nonexistent-skill-xyz"); !errors.Is(err, ErrUnknownSkill) {
+		t.Errorf("expected ErrUnknownSkill, got %v", err)
+	}
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// GetRelated
+// ─────────────────────────────────────────────────────────────────────────────
+
+func TestGetRelated_Depth(t *testing.T) {
+	tax := cyclicTaxonomy()
+
+	got, err := tax.GetRelated("x", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []RelatedSkill{{Skill: GraphSkill{ID: "y", CanonicalName: "Y"}, Distance: 1, Via: "x"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("depth 1 = %+v, want %+v", got, want)
+	}
+
+	// The links loop back to x; each skill is still listed once, and x
+	// never.
+	got, _ = tax.GetRelated("x", 3)
+	want = append(want, RelatedSkill{Skill: GraphSkill{ID: "z", CanonicalName: "Z"}, Distance: 2, Via: "y"})
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("depth 3 = %+v, want %+v", got, want)
+	}
+
+	if got, _ := tax.GetRelated("x", 0); len(got) != 1 {
+		t.Errorf("depth 0 should mean 1, got %+v", got)
+	}
+}
+
+func TestGetRelated_UnknownSkill(t *testing.T) {
+	if _, err := cyclicTaxonomy().GetRelated("missing", 1); !errors.Is(err, ErrUnknownSkill) {
+		t.Errorf("expected ErrUnknownSkill, got %v", err)
+	}
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// ShortestLearningPath
+// ─────────────────────────────────────────────────────────────────────────────
+
+func TestShortestLearningPath(t *testing.T) {
+	tax := New()
+	tests := []struct {
+		name string
+		from []string
+		want []string
+	}{
+		{"from scratch", nil, []string{"javascript", "nodejs", "typescript", "nestjs"}},
+		{"knows JavaScript", []string{"JS"}, []string{"nodejs", "typescript", "nestjs"}},
+		// TypeScript implies JavaScript, so only Node.js still needs it.
+		{"knows TypeScript", []string{"typescript"}, []string{"javascript", "nodejs", "nestjs"}},
+		{"knows both", []string{"Node.js", "TypeScript"}, []string{"nestjs"}},
+		{"knows the target", []string{"NestJS"}, []string{}},
+	}
+	for _, tt := range tests {
+		path, err := tax.ShortestLearningPath(tt.from, "nestjs")
+		if err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		if got := ids(path.Steps); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: steps = %v, want %v", tt.name, got, tt.want)
+		}
+		if path.AlreadyKnown != (len(tt.want) == 0) {
+			t.Errorf("%s: already_known = %v", tt.name, path.AlreadyKnown)
+		}
+	}
+}
+
+func TestShortestLearningPath_Cycles(t *testing.T) {
+	path, err := cyclicTaxonomy().ShortestLearningPath([]string{"B", "cobol"}, "c")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := ids(path.Steps); !reflect.DeepEqual(got, []string{"c"}) {
+		t.Errorf("steps = %v, want [c]", got)
+	}
+	if !reflect.DeepEqual(path.UnrecognizedSkills, []string{"cobol"}) {
+		t.Errorf("unrecognized = %v, want [cobol]", path.UnrecognizedSkills)
+	}
+
+	path, _ = cyclicTaxonomy().ShortestLearningPath(nil, "c")
+	if got := ids(path.Steps); !reflect.DeepEqual(got, []string{"a", "b", "c"}) {
+		t.Errorf("steps = %v, want [a b c]", got)
+	}
+}
+
+func TestShortestLearningPath_UnknownTarget(t *testing.T) {
+	if _, err := New().ShortestLearningPath([]string{"go"}, "nonexistent-skill-xyz"); !errors.Is(err, ErrUnknownSkill) {
+		t.Errorf("expected ErrUnknownSkill, got %v", err)
+	}
+}
@@ -3,10 +3,13 @@
 //
 // Endpoints:
 //
-//	POST /api/v1/skills/extract    – extract skills from free-form text
-//	POST /api/v1/skills/normalize  – normalize raw skill strings to taxonomy
-//	GET  /api/v1/skills/lookup     – look up a skill by canonical ID
-//	GET  /api/v1/skills/search     – search the taxonomy
+//	POST /api/v1/skills/extract       – extract skills from free-form text
+//	POST /api/v1/skills/normalize     – normalize raw skill strings to taxonomy
+//	GET  /api/v1/skills/lookup        – look up a skill by canonical ID
+//	GET  /api/v1/skills/search        – search the taxonomy
+//	GET  /api/v1/skills/prerequisites – transitive prerequisites of a skill
+//	GET  /api/v1/skills/related       – skills related to a skill, to a depth
+//	POST /api/v1/skills/learning-path – skills still needed to reach a skill
 package taxonomy
 
 import (
@@ -16,6 +19,8 @@ import (
 	"strconv"
 	"strings"
 	"time"
+
+	"github.com/learnbot/shared/openapi"
 )
 
 // Handler holds the HTTP handler dependencies for the taxonomy API.
@@ -35,12 +40,81 @@ func NewHandler(logger *log.Logger) *Handler {
 	}
 }
 
+// SetFuzzyMatching sets whether skill extraction fuzzy matches misspelled
+// skills. It is enabled by default; normalization always fuzzy matches.
+func (h *Handler) SetFuzzyMatching(enabled bool) {
+	h.extractor.SetFuzzyMatching(enabled)
+}
+
 // RegisterRoutes registers the taxonomy routes on the given mux.
-func (h *Handler) RegisterRoutes(mux *http.ServeMux) {
+func (h *Handler) RegisterRoutes(mux openapi.Router) {
 	mux.HandleFunc("/api/v1/skills/extract", h.withMiddleware(h.ExtractHandler))
 	mux.HandleFunc("/api/v1/skills/normalize", h.withMiddleware(h.NormalizeHandler))
 	mux.HandleFunc("/api/v1/skills/lookup", h.withMiddleware(h.LookupHandler))
 	mux.HandleFunc("/api/v1/skills/search", h.withMiddleware(h.SearchHandler))
+	mux.HandleFunc("/api/v1/skills/prerequisites", h.withMiddleware(h.PrerequisitesHandler))
+	mux.HandleFunc("/api/v1/skills/related", h.withMiddleware(h.RelatedHandler))
+	mux.HandleFunc("/api/v1/skills/learning-path", h.withMiddleware(h.LearningPathHandler))
+}
+
+// DescribeRoutes documents the routes registered by RegisterRoutes.
+func (h *Handler) DescribeRoutes(spec *openapi.Spec) {
+	spec.Route("/api/v1/skills/extract", openapi.Operation{
+		Method:   http.MethodPost,
+		Summary:  "Extract taxonomy skills from free text",
+		Request:  ExtractRequest{},
+		Response: ExtractResponse{},
+		Errors:   []int{http.StatusBadRequest},
+	})
+	spec.Route("/api/v1/skills/normalize", openapi.Operation{
+		Method:   http.MethodPost,
+		Summary:  "Normalize raw skill names to taxonomy skills",
+		Request:  NormalizeRequest{},
+		Response: NormalizeResponse{},
+		Errors:   []int{http.StatusBadRequest},
+	})
+	spec.Route("/api/v1/skills/lookup", openapi.Operation{
+		Method:   http.MethodGet,
+		Summary:  "Look up a taxonomy skill by ID",
+		Params:   []openapi.Param{{Name: "id", Description: "Canonical skill ID", Required: true}},
+		Response: LookupResponse{},
+		Errors:   []int{http.StatusBadRequest, http.StatusNotFound},
+	})
+	spec.Route("/api/v1/skills/search", openapi.Operation{
+		Method:  http.MethodGet,
+		Summary: "Search the skill taxonomy",
+		Params: []openapi.Param{
+			openapi.Query("q", "Search term"),
+			openapi.Query("domain", "Restrict results to a domain"),
+			openapi.Query("category", "Restrict results to a category"),
+			{Name: "limit", Description: "Maximum number of results", Type: 0},
+		},
+		Response: SearchResponse{},
+	})
+	spec.Route("/api/v1/skills/prerequisites", openapi.Operation{
+		Method:   http.MethodGet,
+		Summary:  "List a skill's transitive prerequisites in learning order",
+		Params:   []openapi.Param{{Name: "id", Description: "Canonical skill ID", Required: true}},
+		Response: PrerequisitesResponse{},
+		Errors:   []int{http.StatusBadRequest, http.StatusNotFound},
+	})
+	spec.Route("/api/v1/skills/related", openapi.Operation{
+		Method:  http.MethodGet,
+		Summary: "List the skills related to a skill",
+		Params: []openapi.Param{
+			{Name: "id", Description: "Canonical skill ID", Required: true},
+			{Name: "depth", Description: "How many related-skill links to follow (1-3, default 1)", Type: 0},
+		},
+		Response: RelatedResponse{},
+		Errors:   []int{http.StatusBadRequest, http.StatusNotFound},
+	})
+	spec.Route("/api/v1/skills/learning-path", openapi.Operation{
+		Method:   http.MethodPost,
+		Summary:  "List the skills a candidate still needs to learn a skill",
+		Request:  LearningPathRequest{},
+		Response: LearningPathResponse{},
+		Errors:   []int{http.StatusBadRequest, http.StatusNotFound},
+	})
 }
 
 // withMiddleware wraps a handler with logging and panic recovery.
@@ -221,6 +295,139 @@ func (h *Handler) SearchHandler(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+// PrerequisitesHandler handles GET /api/v1/skills/prerequisites?id=<canonical-id>
+//
+// Response body (JSON):
+//
+//	{
+//	  "success": true,
+//	  "data": {
+//	    "skill": {"id": "nestjs", "canonical_name": "NestJS", "prerequisites": ["nodejs", "typescript"], ...},
+//	    "prerequisites": [
+//	      {"id": "javascript", "canonical_name": "JavaScript", ...},
+//	      {"id": "nodejs", "canonical_name": "Node.js", "prerequisites": ["javascript"], ...},
+//	      ...
+//	    ]
+//	  }
+//	}
+func (h *Handler) PrerequisitesHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		h.writeError(w, http.StatusMethodNotAllowed, "only GET is supported")
+		return
+	}
+
+	id := strings.TrimSpace(r.URL.Query().Get("id"))
+	if id == "" {
+		h.writeError(w, http.StatusBadRequest, "query parameter 'id' is required")
+		return
+	}
+
+	chain, err := h.taxonomy.GetPrerequisiteChain(id)
+	if err != nil {
+		h.writeError(w, http.StatusNotFound, "skill not found: "+id)
+		return
+	}
+	h.writeJSON(w, http.StatusOK, PrerequisitesResponse{
+		Success: true,
+		Data:    &chain,
+	})
+}
+
+// RelatedHandler handles GET /api/v1/skills/related?id=<canonical-id>[&depth=...]
+//
+// Query parameters:
+//
+//	id    – the canonical skill ID (required)
+//	depth – how many related-skill links to follow (optional, 1-3, default 1)
+//
+// Response body (JSON):
+//
+//	{
+//	  "success": true,
+//	  "data": [{"skill": {"id": "grpc", ...}, "distance": 1, "via": "go"}, ...],
+//	  "total": 5
+//	}
+func (h *Handler) RelatedHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		h.writeError(w, http.StatusMethodNotAllowed, "only GET is supported")
+		return
+	}
+
+	id := strings.TrimSpace(r.URL.Query().Get("id"))
+	if id == "" {
+		h.writeError(w, http.StatusBadRequest, "query parameter 'id' is required")
+		return
+	}
+	depth := 1
+	if d := r.URL.Query().Get("depth"); d != "" {
+		n, err := strconv.Atoi(d)
+		if err != nil || n < 1 || n > MaxRelatedDepth {
+			h.writeError(w, http.StatusBadRequest,
+				"query parameter 'depth' must be between 1 and "+strconv.Itoa(MaxRelatedDepth))
+			return
+		}
+		depth = n
+	}
+
+	related, err := h.taxonomy.GetRelated(id, depth)
+	if err != nil {
+		h.writeError(w, http.StatusNotFound, "skill not found: "+id)
+		return
+	}
+	h.writeJSON(w, http.StatusOK, RelatedResponse{
+		Success: true,
+		Data:    related,
+		Total:   len(related),
+	})
+}
+
+// LearningPathHandler handles POST /api/v1/skills/learning-path
+//
+// Request body (JSON):
+//
+//	{
+//	  "from_skills": ["JavaScript", "Node.js"],
+//	  "to_skill": "nestjs"
+//	}
+//
+// Response body (JSON):
+//
+//	{
+//	  "success": true,
+//	  "data": {
+//	    "target": {"id": "nestjs", ...},
+//	    "already_known": false,
+//	    "steps": [{"id": "typescript", ...}, {"id": "nestjs", ...}]
+//	  }
+//	}
+func (h *Handler) LearningPathHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		h.writeError(w, http.StatusMethodNotAllowed, "only POST is supported")
+		return
+	}
+
+	var req LearningPathRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.writeError(w, http.StatusBadRequest, "invalid request body: "+err.Error())
+		return
+	}
+	to := strings.TrimSpace(req.ToSkill)
+	if to == "" {
+		h.writeError(w, http.StatusBadRequest, "to_skill is required")
+		return
+	}
+
+	path, err := h.taxonomy.ShortestLearningPath(req.FromSkills, to)
+	if err != nil {
+		h.writeError(w, http.StatusNotFound, "skill not found: "+to)
+		return
+	}
+	h.writeJSON(w, http.StatusOK, LearningPathResponse{
+		Success: true,
+		Data:    &path,
+	})
+}
+
 // writeJSON serialises v as JSON and writes it to the response.
 func (h *Handler) writeJSON(w http.ResponseWriter, status int, v interface{}) {
 	w.Header().Set("Content-Type", "application/json")
@@ -9,6 +9,8 @@ import (
 	"os"
 	"strings"
 	"testing"
+
+	"github.com/learnbot/shared/openapi"
 )
 
 // buildTestHandler creates a Handler for testing.
@@ -312,6 +314,66 @@ func TestSearchHandler_WithLimit(t *testing.T) {
 	}
 }
 
+// ─────────────────────────────────────────────────────────────────────────────
+// Graph handlers
+// ─────────────────────────────────────────────────────────────────────────────
+
+func TestGraphHandlers_Errors(t *testing.T) {
+	h := buildTestHandler()
+	mux := http.NewServeMux()
+	h.RegisterRoutes(mux)
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		body   string
+		want   int
+	}{
+		{"prerequisites without id", http.MethodGet, "/api/v1/skills/prerequisites", "", http.StatusBadRequest},
+		{"prerequisites of unknown skill", http.MethodGet, "/api/v1/skills/prerequisites?id=nonexistent", "", http.StatusNotFound},
+		{"related of unknown skill", http.MethodGet, "/api/v1/skills/related?id=nonexistent", "", http.StatusNotFound},
+		{"related too deep", http.MethodGet, "/api/v1/skills/related?id=go&depth=4", "", http.StatusBadRequest},
+		{"related bad depth", http.MethodGet, "/api/v1/skills/related?id=go&depth=x", "", http.StatusBadRequest},
+		{"path without target", http.MethodPost, "/api/v1/skills/learning-path", `{"from_skills":["go"]}`, http.StatusBadRequest},
+		{"path to unknown skill", http.MethodPost, "/api/v1/skills/learning-path", `{"to_skill":"nonexistent"}`, http.StatusNotFound},
+		{"path with GET", http.MethodGet, "/api/v1/skills/learning-path", "", http.StatusMethodNotAllowed},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body))
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+		if w.Code != tt.want {
+			t.Errorf("%s: expected %d, got %d: %s", tt.name, tt.want, w.Code, w.Body.String())
+		}
+	}
+}
+
+func TestLearningPathHandler_ValidRequest(t *testing.T) {
+	h := buildTestHandler()
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/skills/learning-path",
+		strings.NewReader(`{"from_skills":["JavaScript","Node.js","Cobol"],"to_skill":"nestjs"}`))
+	w := httptest.NewRecorder()
+	h.LearningPathHandler(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	var resp LearningPathResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if resp.Data == nil || len(resp.Data.Steps) != 2 {
+		t.Fatalf("expected TypeScript then NestJS, got %+v", resp.Data)
+	}
+	if s := resp.Data.Steps[0]; s.ID != "typescript" || s.CanonicalName != "TypeScript" || s.Category != CategoryLanguage {
+		t.Errorf("first step = %+v, want TypeScript (language)", s)
+	}
+	if len(resp.Data.UnrecognizedSkills) != 1 {
+		t.Errorf("expected Cobol to be unrecognized, got %v", resp.Data.UnrecognizedSkills)
+	}
+}
+
 // ─────────────────────────────────────────────────────────────────────────────
 // RegisterRoutes
 // ─────────────────────────────────────────────────────────────────────────────
@@ -331,6 +393,9 @@ func TestRegisterTaxonomyRoutes(t *testing.T) {
 		{http.MethodPost, "/api/v1/skills/normalize", `{"skills":["golang"]}`, http.StatusOK},
 		{http.MethodGet, "/api/v1/skills/lookup?id=go", "", http.StatusOK},
 		{http.MethodGet, "/api/v1/skills/search?q=python", "", http.StatusOK},
+		{http.MethodGet, "/api/v1/skills/prerequisites?id=nestjs", "", http.StatusOK},
+		{http.MethodGet, "/api/v1/skills/related?id=go&depth=2", "", http.StatusOK},
+		{http.MethodPost, "/api/v1/skills/learning-path", `{"from_skills":["js"],"to_skill":"nestjs"}`, http.StatusOK},
 	}
 
 	for _, tt := range routes {
@@ -353,3 +418,10 @@ func TestRegisterTaxonomyRoutes(t *testing.T) {
 		})
 	}
 }
+
+func TestDescribeRoutes(t *testing.T) {
+	h := buildTestHandler()
+	if err := openapi.CheckRoutes(h.RegisterRoutes, h.DescribeRoutes); err != nil {
+		t.Error(err)
+	}
+}
@@ -26,16 +26,27 @@ type Taxonomy struct {
 
 	// all is the ordered list of all skill nodes (for iteration).
 	all []*SkillNode
+
+	// fuzzyTerms lists the distinct normalised canonical names, IDs, and
+	// aliases, for fuzzy matching.
+	fuzzyTerms []fuzzyTerm
+	fuzzySeen  map[string]bool
 }
 
 // New creates a Taxonomy populated with the built-in skill ontology.
 func New() *Taxonomy {
+	return newTaxonomy(builtinSkills)
+}
+
+// newTaxonomy creates a Taxonomy populated with nodes.
+func newTaxonomy(nodes []SkillNode) *Taxonomy {
 	t := &Taxonomy{
-		byID:    make(map[string]*SkillNode, len(builtinSkills)),
-		byAlias: make(map[string]string),
+		byID:      make(map[string]*SkillNode, len(nodes)),
+		byAlias:   make(map[string]string),
+		fuzzySeen: make(map[string]bool),
 	}
-	for i := range builtinSkills {
-		node := &builtinSkills[i]
+	for i := range nodes {
+		node := &nodes[i]
 		t.byID[node.ID] = node
 		t.all = append(t.all, node)
 
@@ -47,6 +58,12 @@ func New() *Taxonomy {
 		for _, alias := range node.Aliases {
 			t.byAlias[normalise(alias)] = node.ID
 		}
+
+		t.indexFuzzyTerm(node.CanonicalName, node)
+		t.indexFuzzyTerm(node.ID, node)
+		for _, alias := range node.Aliases {
+			t.indexFuzzyTerm(alias, node)
+		}
 	}
 	return t
 }
@@ -56,6 +73,15 @@ func (t *Taxonomy) Lookup(id string) *SkillNode {
 	return t.byID[id]
 }
 
+// LookupName returns the SkillNode whose canonical name, ID, or alias equals
+// name (case-insensitive), or nil. Unlike Normalize it never fuzzy matches.
+func (t *Taxonomy) LookupName(name string) *SkillNode {
+	if id, ok := t.byAlias[normalise(name)]; ok {
+		return t.byID[id]
+	}
+	return nil
+}
+
 // All returns all skill nodes in the taxonomy.
 func (t *Taxonomy) All() []*SkillNode {
 	return t.all
@@ -104,10 +130,21 @@ func aliasContains(aliases []string, q string) bool {
 // Normalize maps a raw skill string to its canonical taxonomy entry.
 // It tries, in order:
 //  1. Exact match on normalised canonical name / ID / alias.
-//  2. Fuzzy match using Jaro-Winkler similarity (threshold 0.85).
+//  2. Fuzzy match by edit distance, for typos such as "Kubernates". Names
+//     of four characters or fewer are never fuzzy matched, and longer ones
+//     must reach MinFuzzyConfidence.
 //
 // Returns a NormalizeResult with MatchType "exact", "alias", "fuzzy", or "none".
 func (t *Taxonomy) Normalize(raw string) NormalizeResult {
+	return t.normalize(raw, true)
+}
+
+// NormalizeExact is Normalize without the fuzzy fallback.
+func (t *Taxonomy) NormalizeExact(raw string) NormalizeResult {
+	return t.normalize(raw, false)
+}
+
+func (t *Taxonomy) normalize(raw string, fuzzy bool) NormalizeResult {
 	result := NormalizeResult{Input: raw}
 	if strings.TrimSpace(raw) == "" {
 		result.MatchType = "none"
@@ -134,28 +171,11 @@ func (t *Taxonomy) Normalize(raw string) NormalizeResult {
 	}
 
 	// 2. Fuzzy match.
-	bestScore := 0.0
-	var bestNode *SkillNode
-
-	for _, node := range t.all {
-		// Compare against canonical name.
-		score := jaroWinkler(norm, normalise(node.CanonicalName))
-		if score > bestScore {
-			bestScore = score
-			bestNode = node
-		}
-		// Compare against each alias.
-		for _, alias := range node.Aliases {
-			score = jaroWinkler(norm, normalise(alias))
-			if score > bestScore {
-				bestScore = score
-				bestNode = node
-			}
-		}
+	if !fuzzy {
+		result.MatchType = "none"
+		return result
 	}
-
-	const fuzzyThreshold = 0.85
-	if bestScore >= fuzzyThreshold && bestNode != nil {
+	if bestNode, bestScore := t.fuzzyMatch(norm); bestNode != nil {
 		result.CanonicalID = bestNode.ID
 		result.CanonicalName = bestNode.CanonicalName
 		result.Domain = bestNode.Domain
@@ -198,6 +218,9 @@ type Extractor struct {
 
 	// tokenSplitter splits a sentence into tokens.
 	tokenSplitter *regexp.Regexp
+
+	// fuzzy enables the fuzzy fallback for tokens with no exact match.
+	fuzzy bool
 }
 
 // NewExtractor creates an Extractor backed by the given taxonomy.
@@ -206,11 +229,19 @@ func NewExtractor(t *Taxonomy) *Extractor {
 		taxonomy:         t,
 		sentenceSplitter: regexp.MustCompile(`[.!?\n;]+`),
 		tokenSplitter:    regexp.MustCompile(`[\s,/|•\t]+`),
+		fuzzy:            true,
 	}
 	e.buildMultiWordPatterns()
 	return e
 }
 
+// SetFuzzyMatching sets whether tokens with no exact or alias match are
+// fuzzy matched, so that typos like "Reactt" are still extracted. It is
+// enabled by default.
+func (e *Extractor) SetFuzzyMatching(enabled bool) {
+	e.fuzzy = enabled
+}
+
 // buildMultiWordPatterns compiles regex patterns for multi-word skills that
 // appear frequently in job descriptions.
 func (e *Extractor) buildMultiWordPatterns() {
@@ -261,7 +292,7 @@ func (e *Extractor) Extract(text string, includeUnknown bool) ExtractionResult {
 	for _, re := range e.multiWordPatterns {
 		matches := re.FindAllString(remaining, -1)
 		for _, match := range matches {
-			norm := e.taxonomy.Normalize(match)
+			norm := e.taxonomy.normalize(match, e.fuzzy)
 			if norm.MatchType != "none" && !seenIDs[norm.CanonicalID] {
 				seenIDs[norm.CanonicalID] = true
 				allSkills = append(allSkills, ExtractedSkill{
@@ -288,7 +319,7 @@ func (e *Extractor) Extract(text string, includeUnknown bool) ExtractionResult {
 		if len(token) < 2 {
 			continue
 		}
-		norm := e.taxonomy.Normalize(token)
+		norm := e.taxonomy.normalize(token, e.fuzzy)
 		if norm.MatchType != "none" && !seenIDs[norm.CanonicalID] {
 			seenIDs[norm.CanonicalID] = true
 			allSkills = append(allSkills, ExtractedSkill{
@@ -58,6 +58,19 @@ func TestLookup_NonExistentID(t *testing.T) {
 	}
 }
 
+func TestLookupName(t *testing.T) {
+	tax := New()
+	for _, name := range []string{"Kubernetes", "k8s", "  KUBE "} {
+		if node := tax.LookupName(name); node == nil || node.ID != "kubernetes" {
+			t.Errorf("LookupName(%q) = %v, want kubernetes", name, node)
+		}
+	}
+	// Near misses are not fuzzy matched.
+	if node := tax.LookupName("kubernets"); node != nil {
+		t.Errorf("LookupName(kubernets) = %q, want nil", node.ID)
+	}
+}
+
 func TestAll_ReturnsAllNodes(t *testing.T) {
 	tax := New()
 	all := tax.All()
@@ -194,6 +207,70 @@ func TestNormalize_FuzzyMatch(t *testing.T) {
 	}
 }
 
+func TestNormalize_FuzzyTypos(t *testing.T) {
+	tax := New()
+	tests := []struct {
+		input  string
+		wantID string
+	}{
+		{"Kubernates", "kubernetes"},
+		{"PostgressSQL", "postgresql"},
+		{"Reactt", "react"},
+		{"Pyhton", "python"},
+		{"tensorflw", "tensorflow"},
+	}
+	for _, tt := range tests {
+		result := tax.Normalize(tt.input)
+		if result.CanonicalID != tt.wantID || result.MatchType != "fuzzy" {
+			t.Errorf("Normalize(%q) = %q (%s), want fuzzy %q", tt.input, result.CanonicalID, result.MatchType, tt.wantID)
+		}
+		if result.Input != tt.input || result.FuzzyScore < MinFuzzyConfidence || result.FuzzyScore >= 1 {
+			t.Errorf("Normalize(%q): input %q, score %.4f", tt.input, result.Input, result.FuzzyScore)
+		}
+	}
+}
+
+func TestNormalize_FuzzyIsLengthAware(t *testing.T) {
+	tax := New()
+	// Each is one edit from a skill, but too short for that to mean a typo.
+	for _, input := range []string{"Gp", "Cc", "Lava", "Rusy", "vuex"} {
+		if result := tax.Normalize(input); result.MatchType == "fuzzy" {
+			t.Errorf("Normalize(%q) fuzzy matched %q", input, result.CanonicalID)
+		}
+	}
+}
+
+func TestNormalizeExact(t *testing.T) {
+	tax := New()
+	if result := tax.NormalizeExact("Kubernates"); result.MatchType != "none" || result.CanonicalID != "" {
+		t.Errorf("NormalizeExact(Kubernates) = %+v, want no match", result)
+	}
+	if result := tax.NormalizeExact("k8s"); result.CanonicalID != "kubernetes" {
+		t.Errorf("NormalizeExact(k8s) = %q, want kubernetes", result.CanonicalID)
+	}
+}
+
+func TestEditDistance(t *testing.T) {
+	tests := []struct {
+		a, b  string
+		bound int
+		want  int
+	}{
+		{"kubernetes", "kubernetes", 2, 0},
+		{"kubernates", "kubernetes", 2, 1},
+		{"pyhton", "python", 2, 1}, // a transposition is one edit
+		{"postgressql", "postgresql", 2, 1},
+		{"react", "reactt", 2, 1},
+		{"python", "kubernetes", 2, 3}, // over the bound
+		{"go", "kubernetes", 3, 4},     // lengths alone exceed the bound
+	}
+	for _, tt := range tests {
+		if got := editDistance([]rune(tt.a), []rune(tt.b), tt.bound); got != tt.want {
+			t.Errorf("editDistance(%q, %q, %d) = %d, want %d", tt.a, tt.b, tt.bound, got, tt.want)
+		}
+	}
+}
+
 func TestNormalize_NoMatch(t *testing.T) {
 	tax := New()
 	result := tax.Normalize("xyzzy-nonexistent-skill-12345")
@@ -358,6 +435,23 @@ func TestExtract_Deduplication(t *testing.T) {
 	}
 }
 
+func TestExtract_FuzzyMatching(t *testing.T) {
+	ext := NewExtractor(New())
+	text := "Experience with Kubernates and Reactt."
+	if findExtracted(ext.Extract(text, false).Skills, "kubernetes") == nil {
+		t.Error("expected a misspelled skill to be extracted by default")
+	}
+
+	ext.SetFuzzyMatching(false)
+	result := ext.Extract(text, true)
+	if s := findExtracted(result.Skills, "kubernetes"); s != nil {
+		t.Errorf("expected no fuzzy matches when disabled, got %+v", s)
+	}
+	if len(result.UnknownSkills) == 0 {
+		t.Error("expected the misspelled skills to be reported as unknown")
+	}
+}
+
 func TestExtract_EmptyText(t *testing.T) {
 	tax := New()
 	ext := NewExtractor(tax)
@@ -677,6 +771,25 @@ func BenchmarkNormalize_FuzzyMatch(b *testing.B) {
 	}
 }
 
+// BenchmarkNormalize_FuzzyMiss measures the worst case, a skill-like token
+// that matches nothing and is compared against every term.
+func BenchmarkNormalize_FuzzyMiss(b *testing.B) {
+	tax := New()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		tax.Normalize("xyzzy-framework")
+	}
+}
+
+func BenchmarkNormalize_FuzzyTypos(b *testing.B) {
+	tax := New()
+	typos := []string{"Kubernates", "PostgressSQL", "Reactt", "Pyhton", "tensorflw"}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		tax.Normalize(typos[i%len(typos)])
+	}
+}
+
 func BenchmarkExtract_ShortText(b *testing.B) {
 	tax := New()
 	ext := NewExtractor(tax)
@@ -688,9 +801,23 @@ func BenchmarkExtract_ShortText(b *testing.B) {
 }
 
 func BenchmarkExtract_LongJobDescription(b *testing.B) {
-	tax := New()
-	ext := NewExtractor(tax)
-	text := `We are looking for a Senior Software Engineer with 5+ years of experience.
+	benchmarkExtract(b, longJobDescription, true)
+}
+
+func BenchmarkExtract_LongJobDescriptionExact(b *testing.B) {
+	benchmarkExtract(b, longJobDescription, false)
+}
+
+func benchmarkExtract(b *testing.B, text string, fuzzy bool) {
+	ext := NewExtractor(New())
+	ext.SetFuzzyMatching(fuzzy)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		ext.Extract(text, false)
+	}
+}
+
+const longJobDescription = `We are looking for a Senior Software Engineer with 5+ years of experience.
 
 Required Skills:
 - Go or Python (3+ years)
@@ -713,9 +840,3 @@ Soft Skills:
 - Excellent problem solving abilities
 - Team player with collaboration mindset
 - Agile/Scrum methodology experience`
-
-	b.ResetTimer()
-	for i := 0; i < b.N; i++ {
-		ext.Extract(text, false)
-	}
-}
@@ -9,86 +9,64 @@
 //   - Mapping of raw user skill strings to canonical taxonomy entries
 package taxonomy
 
+import "github.com/learnbot/shared/ontology"
+
 // ─────────────────────────────────────────────────────────────────────────────
 // Taxonomy node types
 // ─────────────────────────────────────────────────────────────────────────────
 
 // Domain is the top-level grouping (e.g. "Engineering", "Data Science").
-type Domain string
+type Domain = ontology.Domain
 
 const (
-	DomainEngineering    Domain = "engineering"
-	DomainDataScience    Domain = "data_science"
-	DomainDevOps         Domain = "devops"
-	DomainDesign         Domain = "design"
-	DomainManagement     Domain = "management"
-	DomainCommunication  Domain = "communication"
-	DomainDomain         Domain = "domain_knowledge"
+	DomainEngineering   = ontology.DomainEngineering
+	DomainDataScience   = ontology.DomainDataScience
+	DomainDevOps        = ontology.DomainDevOps
+	DomainDesign        = ontology.DomainDesign
+	DomainManagement    = ontology.DomainManagement
+	DomainCommunication = ontology.DomainCommunication
+	DomainDomain        = ontology.DomainDomain
 )
 
 // Category is the second-level grouping within a domain
 // (e.g. "Frontend", "Backend", "Database").
-type Category string
+type Category = ontology.Category
 
 const (
 	// Engineering categories
-	CategoryLanguage    Category = "language"
-	CategoryFrontend    Category = "frontend"
-	CategoryBackend     Category = "backend"
-	CategoryMobile      Category = "mobile"
-	CategoryDatabase    Category = "database"
-	CategoryCloud       Category = "cloud"
-	CategoryDevOps      Category = "devops"
-	CategorySecurity    Category = "security"
-	CategoryTesting     Category = "testing"
-	CategoryAPI         Category = "api"
-	CategoryMessaging   Category = "messaging"
+	CategoryLanguage  = ontology.CategoryLanguage
+	CategoryFrontend  = ontology.CategoryFrontend
+	CategoryBackend   = ontology.CategoryBackend
+	CategoryMobile    = ontology.CategoryMobile
+	CategoryDatabase  = ontology.CategoryDatabase
+	CategoryCloud     = ontology.CategoryCloud
+	CategoryDevOps    = ontology.CategoryDevOps
+	CategorySecurity  = ontology.CategorySecurity
+	CategoryTesting   = ontology.CategoryTesting
+	CategoryAPI       = ontology.CategoryAPI
+	CategoryMessaging = ontology.CategoryMessaging
 
 	// Data Science categories
-	CategoryMLFramework Category = "ml_framework"
-	CategoryDataTools   Category = "data_tools"
-	CategoryMLConcept   Category = "ml_concept"
+	CategoryMLFramework = ontology.CategoryMLFramework
+	CategoryDataTools   = ontology.CategoryDataTools
+	CategoryMLConcept   = ontology.CategoryMLConcept
 
 	// Soft skill categories
-	CategoryLeadership  Category = "leadership"
-	CategoryCollaboration Category = "collaboration"
-	CategoryCommunication Category = "communication_skill"
-	CategoryProblemSolving Category = "problem_solving"
-	CategoryProjectMgmt Category = "project_management"
+	CategoryLeadership     = ontology.CategoryLeadership
+	CategoryCollaboration  = ontology.CategoryCollaboration
+	CategoryCommunication  = ontology.CategoryCommunication
+	CategoryProblemSolving = ontology.CategoryProblemSolving
+	CategoryProjectMgmt    = ontology.CategoryProjectMgmt
 
 	// Domain knowledge categories
-	CategoryFinance     Category = "finance"
-	CategoryHealthcare  Category = "healthcare"
-	CategoryEcommerce   Category = "ecommerce"
-	CategoryLegal       Category = "legal"
+	CategoryFinance    = ontology.CategoryFinance
+	CategoryHealthcare = ontology.CategoryHealthcare
+	CategoryEcommerce  = ontology.CategoryEcommerce
+	CategoryLegal      = ontology.CategoryLegal
 )
 
 // SkillNode represents a single skill entry in the taxonomy.
-type SkillNode struct {
-	// ID is the canonical identifier (lowercase, hyphenated).
-	ID string `json:"id"`
-
-	// CanonicalName is the preferred display name.
-	CanonicalName string `json:"canonical_name"`
-
-	// Domain is the top-level grouping.
-	Domain Domain `json:"domain"`
-
-	// Category is the second-level grouping.
-	Category Category `json:"category"`
-
-	// Aliases lists all known synonyms and alternate spellings.
-	Aliases []string `json:"aliases,omitempty"`
-
-	// Prerequisites lists IDs of skills that are typically learned before this one.
-	Prerequisites []string `json:"prerequisites,omitempty"`
-
-	// RelatedSkills lists IDs of skills that are commonly used alongside this one.
-	RelatedSkills []string `json:"related_skills,omitempty"`
-
-	// Description is a short human-readable description.
-	Description string `json:"description,omitempty"`
-}
+type SkillNode = ontology.SkillNode
 
 // ─────────────────────────────────────────────────────────────────────────────
 // Extraction types
@@ -165,6 +143,89 @@ type NormalizeResult struct {
 	FuzzyScore float64 `json:"fuzzy_score,omitempty"`
 }
 
+// ─────────────────────────────────────────────────────────────────────────────
+// Skill graph types
+// ─────────────────────────────────────────────────────────────────────────────
+
+// GraphSkill is a skill returned by a graph query.
+type GraphSkill struct {
+	// ID is the canonical skill ID.
+	ID string `json:"id"`
+
+	// CanonicalName is the preferred display name.
+	CanonicalName string `json:"canonical_name"`
+
+	// Domain is the top-level grouping.
+	Domain Domain `json:"domain"`
+
+	// Category is the second-level grouping.
+	Category Category `json:"category"`
+
+	// Prerequisites lists the IDs of this skill's prerequisites that are part
+	// of the same result, so that the result's dependency DAG can be rebuilt.
+	Prerequisites []string `json:"prerequisites,omitempty"`
+}
+
+// SkippedEdge is a prerequisite link a graph query did not follow.
+type SkippedEdge struct {
+	// SkillID is the skill that declares the prerequisite.
+	SkillID string `json:"skill_id"`
+
+	// PrerequisiteID is the declared prerequisite.
+	PrerequisiteID string `json:"prerequisite_id"`
+
+	// Reason is "cycle" when following the link would loop back to a skill
+	// that depends on SkillID, or "unknown_skill" when PrerequisiteID is not
+	// in the taxonomy.
+	Reason string `json:"reason"`
+}
+
+// PrerequisiteChain is the transitive prerequisite DAG of a skill.
+type PrerequisiteChain struct {
+	// Skill is the queried skill.
+	Skill GraphSkill `json:"skill"`
+
+	// Prerequisites lists every transitive prerequisite in topological
+	// order: each skill comes after all of its own prerequisites.
+	Prerequisites []GraphSkill `json:"prerequisites"`
+
+	// SkippedEdges lists the links left out to keep the result acyclic.
+	SkippedEdges []SkippedEdge `json:"skipped_edges,omitempty"`
+}
+
+// RelatedSkill is a skill reached from another through related-skill links.
+type RelatedSkill struct {
+	// Skill is the related skill.
+	Skill GraphSkill `json:"skill"`
+
+	// Distance is the number of links from the queried skill (1 = directly
+	// related).
+	Distance int `json:"distance"`
+
+	// Via is the ID of the skill this one was reached from.
+	Via string `json:"via"`
+}
+
+// LearningPath is what a candidate still has to learn to reach a skill.
+type LearningPath struct {
+	// Target is the skill to learn.
+	Target GraphSkill `json:"target"`
+
+	// AlreadyKnown reports whether the candidate already has the target.
+	AlreadyKnown bool `json:"already_known"`
+
+	// Steps lists the skills to learn in order, ending with the target.
+	// Each skill comes after its prerequisites. Empty when AlreadyKnown.
+	Steps []GraphSkill `json:"steps"`
+
+	// UnrecognizedSkills lists the candidate skills not found in the
+	// taxonomy; they cannot shorten the path.
+	UnrecognizedSkills []string `json:"unrecognized_skills,omitempty"`
+
+	// SkippedEdges lists the links left out to keep the path acyclic.
+	SkippedEdges []SkippedEdge `json:"skipped_edges,omitempty"`
+}
+
 // ─────────────────────────────────────────────────────────────────────────────
 // API request/response types
 // ─────────────────────────────────────────────────────────────────────────────
@@ -233,3 +294,35 @@ type SearchResponse struct {
 	Total   int         `json:"total"`
 	Error   string      `json:"error,omitempty"`
 }
+
+// PrerequisitesResponse is the output of the prerequisite chain API.
+type PrerequisitesResponse struct {
+	Success bool               `json:"success"`
+	Data    *PrerequisiteChain `json:"data,omitempty"`
+	Error   string             `json:"error,omitempty"`
+}
+
+// RelatedResponse is the output of the related skills API.
+type RelatedResponse struct {
+	Success bool           `json:"success"`
+	Data    []RelatedSkill `json:"data,omitempty"`
+	Total   int            `json:"total"`
+	Error   string         `json:"error,omitempty"`
+}
+
+// LearningPathRequest is the input to the learning path API.
+type LearningPathRequest struct {
+	// FromSkills lists the skills the candidate already has, by canonical
+	// ID, name, or alias.
+	FromSkills []string `json:"from_skills"`
+
+	// ToSkill is the canonical ID of the skill to learn.
+	ToSkill string `json:"to_skill"`
+}
+
+// LearningPathResponse is the output of the learning path API.
+type LearningPathResponse struct {
+	Success bool          `json:"success"`
+	Data    *LearningPath `json:"data,omitempty"`
+	Error   string        `json:"error,omitempty"`
+}
@@ -3,6 +3,8 @@
 package recommend
 
 import (
+	"time"
+
 	"github.com/learnbot/resume-parser/internal/recommendation"
 	"github.com/learnbot/resume-parser/internal/scorer"
 )
@@ -28,6 +30,12 @@ func New() *Engine {
 	return &Engine{inner: recommendation.New()}
 }
 
+// Deterministic returns a copy of the engine that produces reproducible
+// plans anchored to the fixed start time now.
+func (e *Engine) Deterministic(now time.Time) *Engine {
+	return &Engine{inner: e.inner.Deterministic(now)}
+}
+
 // Generate produces a personalized learning plan.
 func (e *Engine) Generate(
 	profile scorer.CandidateProfile,
@@ -20,13 +20,63 @@ type WorkHistoryEntry = scorer.WorkHistoryEntry
 // EducationEntry represents a single educational qualification.
 type EducationEntry = scorer.EducationEntry
 
+// CertificationEntry represents a certification the candidate holds.
+type CertificationEntry = scorer.CertificationEntry
+
+// ProjectEntry represents a project the candidate worked on.
+type ProjectEntry = scorer.ProjectEntry
+
+// Provenance records where a profile value came from.
+type Provenance = scorer.Provenance
+
+const (
+	ProvenanceParsed = scorer.ProvenanceParsed
+	ProvenanceUser   = scorer.ProvenanceUser
+)
+
 // JobRequirements describes the requirements extracted from a job posting.
 type JobRequirements = scorer.JobRequirements
 
 // ScoreBreakdown holds the individual component scores and the final result.
 type ScoreBreakdown = scorer.ScoreBreakdown
 
+// ScoreResult is the score of one candidate within a batch.
+type ScoreResult = scorer.ScoreResult
+
+// ScoringWeights are the weights of the score components.
+type ScoringWeights = scorer.ScoringWeights
+
+// DefaultScoringWeights returns the weights used by Calculate.
+func DefaultScoringWeights() ScoringWeights {
+	return scorer.DefaultScoringWeights()
+}
+
+// ProficiencyScale is the rubric candidate proficiencies are scored on.
+type ProficiencyScale = scorer.ProficiencyScale
+
+// ProficiencyLevel is one level of a ProficiencyScale.
+type ProficiencyLevel = scorer.ProficiencyLevel
+
+// DefaultProficiencyScale returns the beginner–expert scale used by
+// Calculate.
+func DefaultProficiencyScale() ProficiencyScale {
+	return scorer.DefaultProficiencyScale()
+}
+
 // Calculate computes the acceptance likelihood score for a candidate against a job.
 func Calculate(profile CandidateProfile, job JobRequirements) ScoreBreakdown {
 	return scorer.Calculate(profile, job)
 }
+
+// CalculateWithWeights computes the score with the given component weights.
+// Weights that do not sum to 1.0 are renormalized; negative or all-zero
+// weights return an error.
+func CalculateWithWeights(profile CandidateProfile, job JobRequirements, weights ScoringWeights) (ScoreBreakdown, error) {
+	return scorer.CalculateWithWeights(profile, job, weights)
+}
+
+// CalculateBatch scores many candidates against one job concurrently and
+// returns the results in input order, each with its rank in the batch.
+func CalculateBatch(profiles []CandidateProfile, job JobRequirements) []ScoreResult {
+	return scorer.CalculateBatch(profiles, job)
+}
@@ -62,3 +62,20 @@ func TestCalculate_ScoreInRange(t *testing.T) {
 		t.Errorf("experience match score out of [0,1]: %.2f", result.ExperienceMatchScore)
 	}
 }
+
+func TestCalculateBatch_PreservesOrder(t *testing.T) {
+	job := scoring.JobRequirements{RequiredSkills: []string{"Go"}}
+	profiles := []scoring.CandidateProfile{
+		{},
+		{Skills: []scoring.CandidateSkill{{Name: "Go", Proficiency: "expert"}}},
+	}
+
+	results := scoring.CalculateBatch(profiles, job)
+
+	if len(results) != 2 || results[0].Index != 0 || results[1].Index != 1 {
+		t.Fatalf("results not in input order: %+v", results)
+	}
+	if results[1].Rank != 1 || results[0].Rank != 2 {
+		t.Errorf("expected ranks [2 1], got [%d %d]", results[0].Rank, results[1].Rank)
+	}
+}
@@ -0,0 +1,9 @@
+// Package scoringpb holds the protocol buffer messages and gRPC stubs of
+// the ScoreService and GapService, generated from
+// proto/learnbot/scoring/v1/scoring.proto.
+//
+// Internal services use NewScoreServiceClient and NewGapServiceClient to
+// call the resume parser's gRPC server (see the -grpc-addr flag).
+package scoringpb
+
+//go:generate protoc --proto_path=../../proto --go_out=../.. --go_opt=module=github.com/learnbot/resume-parser --go-grpc_out=../.. --go-grpc_opt=module=github.com/learnbot/resume-parser learnbot/scoring/v1/scoring.proto
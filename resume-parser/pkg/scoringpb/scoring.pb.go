@@ -0,0 +1,2885 @@
+// Scoring and gap analysis engine for internal callers.
+//
+// The messages mirror the JSON bodies of POST /api/v1/score,
+// /api/v1/score/batch and /api/v1/gap-analysis; field names and semantics
+// are those of the HTTP API. Score explanations (?verbose=true) are only
+// available over HTTP.
+//
+// Regenerate pkg/scoringpb after editing: go generate ./pkg/scoringpb
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.34.2
+// 	protoc        (unknown)
+// source: learnbot/scoring/v1/scoring.proto
+
+package scoringpb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type CandidateProfile struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Skills            []*CandidateSkill     `protobuf:"bytes,1,rep,name=skills,proto3" json:"skills,omitempty"`
+	YearsOfExperience float64               `protobuf:"fixed64,2,opt,name=years_of_experience,json=yearsOfExperience,proto3" json:"years_of_experience,omitempty"`
+	WorkHistory       []*WorkHistoryEntry   `protobuf:"bytes,3,rep,name=work_history,json=workHistory,proto3" json:"work_history,omitempty"`
+	Education         []*EducationEntry     `protobuf:"bytes,4,rep,name=education,proto3" json:"education,omitempty"`
+	Certifications    []*CertificationEntry `protobuf:"bytes,5,rep,name=certifications,proto3" json:"certifications,omitempty"`
+	Projects          []*ProjectEntry       `protobuf:"bytes,6,rep,name=projects,proto3" json:"projects,omitempty"`
+	LocationCity      string                `protobuf:"bytes,7,opt,name=location_city,json=locationCity,proto3" json:"location_city,omitempty"`
+	LocationCountry   string                `protobuf:"bytes,8,opt,name=location_country,json=locationCountry,proto3" json:"location_country,omitempty"`
+	WillingToRelocate bool                  `protobuf:"varint,9,opt,name=willing_to_relocate,json=willingToRelocate,proto3" json:"willing_to_relocate,omitempty"`
+	// "remote", "hybrid", "on_site" or "any".
+	RemotePreference string `protobuf:"bytes,10,opt,name=remote_preference,json=remotePreference,proto3" json:"remote_preference,omitempty"`
+}
+
+func (x *CandidateProfile) Reset() {
+	*x = CandidateProfile{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_learnbot_scoring_v1_scoring_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CandidateProfile) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CandidateProfile) ProtoMessage() {}
+
+func (x *CandidateProfile) ProtoReflect() protoreflect.Message {
+	mi := &file_learnbot_scoring_v1_scoring_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CandidateProfile.ProtoReflect.Descriptor instead.
+func (*CandidateProfile) Descriptor() ([]byte, []int) {
+	return file_learnbot_scoring_v1_scoring_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *CandidateProfile) GetSkills() []*CandidateSkill {
+	if x != nil {
+		return x.Skills
+	}
+	return nil
+}
+
+func (x *CandidateProfile) GetYearsOfExperience() float64 {
+	if x != nil {
+		return x.YearsOfExperience
+	}
+	return 0
+}
+
+func (x *CandidateProfile) GetWorkHistory() []*WorkHistoryEntry {
+	if x != nil {
+		return x.WorkHistory
+	}
+	return nil
+}
+
+func (x *CandidateProfile) GetEducation() []*EducationEntry {
+	if x != nil {
+		return x.Education
+	}
+	return nil
+}
+
+func (x *CandidateProfile) GetCertifications() []*CertificationEntry {
+	if x != nil {
+		return x.Certifications
+	}
+	return nil
+}
+
+func (x *CandidateProfile) GetProjects() []*ProjectEntry {
+	if x != nil {
+		return x.Projects
+	}
+	return nil
+}
+
+func (x *CandidateProfile) GetLocationCity() string {
+	if x != nil {
+		return x.LocationCity
+	}
+	return ""
+}
+
+func (x *CandidateProfile) GetLocationCountry() string {
+	if x != nil {
+		return x.LocationCountry
+	}
+	return ""
+}
+
+func (x *CandidateProfile) GetWillingToRelocate() bool {
+	if x != nil {
+		return x.WillingToRelocate
+	}
+	return false
+}
+
+func (x *CandidateProfile) GetRemotePreference() string {
+	if x != nil {
+		return x.RemotePreference
+	}
+	return ""
+}
+
+type CandidateSkill struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// "beginner", "intermediate", "advanced" or "expert".
+	Proficiency       string  `protobuf:"bytes,2,opt,name=proficiency,proto3" json:"proficiency,omitempty"`
+	YearsOfExperience float64 `protobuf:"fixed64,3,opt,name=years_of_experience,json=yearsOfExperience,proto3" json:"years_of_experience,omitempty"`
+}
+
+func (x *CandidateSkill) Reset() {
+	*x = CandidateSkill{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_learnbot_scoring_v1_scoring_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CandidateSkill) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CandidateSkill) ProtoMessage() {}
+
+func (x *CandidateSkill) ProtoReflect() protoreflect.Message {
+	mi := &file_learnbot_scoring_v1_scoring_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CandidateSkill.ProtoReflect.Descriptor instead.
+func (*CandidateSkill) Descriptor() ([]byte, []int) {
+	return file_learnbot_scoring_v1_scoring_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *CandidateSkill) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CandidateSkill) GetProficiency() string {
+	if x != nil {
+		return x.Proficiency
+	}
+	return ""
+}
+
+func (x *CandidateSkill) GetYearsOfExperience() float64 {
+	if x != nil {
+		return x.YearsOfExperience
+	}
+	return 0
+}
+
+type WorkHistoryEntry struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Title          string `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty"`
+	Industry       string `protobuf:"bytes,2,opt,name=industry,proto3" json:"industry,omitempty"`
+	DurationMonths int32  `protobuf:"varint,3,opt,name=duration_months,json=durationMonths,proto3" json:"duration_months,omitempty"`
+	IsCurrent      bool   `protobuf:"varint,4,opt,name=is_current,json=isCurrent,proto3" json:"is_current,omitempty"`
+}
+
+func (x *WorkHistoryEntry) Reset() {
+	*x = WorkHistoryEntry{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_learnbot_scoring_v1_scoring_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WorkHistoryEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WorkHistoryEntry) ProtoMessage() {}
+
+func (x *WorkHistoryEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_learnbot_scoring_v1_scoring_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WorkHistoryEntry.ProtoReflect.Descriptor instead.
+func (*WorkHistoryEntry) Descriptor() ([]byte, []int) {
+	return file_learnbot_scoring_v1_scoring_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *WorkHistoryEntry) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *WorkHistoryEntry) GetIndustry() string {
+	if x != nil {
+		return x.Industry
+	}
+	return ""
+}
+
+func (x *WorkHistoryEntry) GetDurationMonths() int32 {
+	if x != nil {
+		return x.DurationMonths
+	}
+	return 0
+}
+
+func (x *WorkHistoryEntry) GetIsCurrent() bool {
+	if x != nil {
+		return x.IsCurrent
+	}
+	return false
+}
+
+type EducationEntry struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	DegreeLevel  string `protobuf:"bytes,1,opt,name=degree_level,json=degreeLevel,proto3" json:"degree_level,omitempty"`
+	FieldOfStudy string `protobuf:"bytes,2,opt,name=field_of_study,json=fieldOfStudy,proto3" json:"field_of_study,omitempty"`
+}
+
+func (x *EducationEntry) Reset() {
+	*x = EducationEntry{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_learnbot_scoring_v1_scoring_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EducationEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EducationEntry) ProtoMessage() {}
+
+func (x *EducationEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_learnbot_scoring_v1_scoring_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EducationEntry.ProtoReflect.Descriptor instead.
+func (*EducationEntry) Descriptor() ([]byte, []int) {
+	return file_learnbot_scoring_v1_scoring_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *EducationEntry) GetDegreeLevel() string {
+	if x != nil {
+		return x.DegreeLevel
+	}
+	return ""
+}
+
+func (x *EducationEntry) GetFieldOfStudy() string {
+	if x != nil {
+		return x.FieldOfStudy
+	}
+	return ""
+}
+
+type CertificationEntry struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name   string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Issuer string `protobuf:"bytes,2,opt,name=issuer,proto3" json:"issuer,omitempty"`
+	// 0 when unknown.
+	Year int32 `protobuf:"varint,3,opt,name=year,proto3" json:"year,omitempty"`
+}
+
+func (x *CertificationEntry) Reset() {
+	*x = CertificationEntry{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_learnbot_scoring_v1_scoring_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CertificationEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CertificationEntry) ProtoMessage() {}
+
+func (x *CertificationEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_learnbot_scoring_v1_scoring_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CertificationEntry.ProtoReflect.Descriptor instead.
+func (*CertificationEntry) Descriptor() ([]byte, []int) {
+	return file_learnbot_scoring_v1_scoring_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *CertificationEntry) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CertificationEntry) GetIssuer() string {
+	if x != nil {
+		return x.Issuer
+	}
+	return ""
+}
+
+func (x *CertificationEntry) GetYear() int32 {
+	if x != nil {
+		return x.Year
+	}
+	return 0
+}
+
+type ProjectEntry struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Title        string   `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty"`
+	Description  string   `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	Technologies []string `protobuf:"bytes,3,rep,name=technologies,proto3" json:"technologies,omitempty"`
+}
+
+func (x *ProjectEntry) Reset() {
+	*x = ProjectEntry{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_learnbot_scoring_v1_scoring_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ProjectEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProjectEntry) ProtoMessage() {}
+
+func (x *ProjectEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_learnbot_scoring_v1_scoring_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProjectEntry.ProtoReflect.Descriptor instead.
+func (*ProjectEntry) Descriptor() ([]byte, []int) {
+	return file_learnbot_scoring_v1_scoring_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ProjectEntry) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *ProjectEntry) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *ProjectEntry) GetTechnologies() []string {
+	if x != nil {
+		return x.Technologies
+	}
+	return nil
+}
+
+type JobRequirements struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Title              string   `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty"`
+	RequiredSkills     []string `protobuf:"bytes,2,rep,name=required_skills,json=requiredSkills,proto3" json:"required_skills,omitempty"`
+	PreferredSkills    []string `protobuf:"bytes,3,rep,name=preferred_skills,json=preferredSkills,proto3" json:"preferred_skills,omitempty"`
+	NiceToHaveSkills   []string `protobuf:"bytes,4,rep,name=nice_to_have_skills,json=niceToHaveSkills,proto3" json:"nice_to_have_skills,omitempty"`
+	MinYearsExperience float64  `protobuf:"fixed64,5,opt,name=min_years_experience,json=minYearsExperience,proto3" json:"min_years_experience,omitempty"`
+	// 0 means no upper limit.
+	MaxYearsExperience float64 `protobuf:"fixed64,6,opt,name=max_years_experience,json=maxYearsExperience,proto3" json:"max_years_experience,omitempty"`
+	// Empty means no degree requirement.
+	RequiredDegreeLevel string   `protobuf:"bytes,7,opt,name=required_degree_level,json=requiredDegreeLevel,proto3" json:"required_degree_level,omitempty"`
+	PreferredFields     []string `protobuf:"bytes,8,rep,name=preferred_fields,json=preferredFields,proto3" json:"preferred_fields,omitempty"`
+	LocationCity        string   `protobuf:"bytes,9,opt,name=location_city,json=locationCity,proto3" json:"location_city,omitempty"`
+	LocationCountry     string   `protobuf:"bytes,10,opt,name=location_country,json=locationCountry,proto3" json:"location_country,omitempty"`
+	// "remote", "hybrid" or "on_site".
+	LocationType      string   `protobuf:"bytes,11,opt,name=location_type,json=locationType,proto3" json:"location_type,omitempty"`
+	Industry          string   `protobuf:"bytes,12,opt,name=industry,proto3" json:"industry,omitempty"`
+	RelatedIndustries []string `protobuf:"bytes,13,rep,name=related_industries,json=relatedIndustries,proto3" json:"related_industries,omitempty"`
+	ExperienceLevel   string   `protobuf:"bytes,14,opt,name=experience_level,json=experienceLevel,proto3" json:"experience_level,omitempty"`
+}
+
+func (x *JobRequirements) Reset() {
+	*x = JobRequirements{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_learnbot_scoring_v1_scoring_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *JobRequirements) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JobRequirements) ProtoMessage() {}
+
+func (x *JobRequirements) ProtoReflect() protoreflect.Message {
+	mi := &file_learnbot_scoring_v1_scoring_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JobRequirements.ProtoReflect.Descriptor instead.
+func (*JobRequirements) Descriptor() ([]byte, []int) {
+	return file_learnbot_scoring_v1_scoring_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *JobRequirements) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *JobRequirements) GetRequiredSkills() []string {
+	if x != nil {
+		return x.RequiredSkills
+	}
+	return nil
+}
+
+func (x *JobRequirements) GetPreferredSkills() []string {
+	if x != nil {
+		return x.PreferredSkills
+	}
+	return nil
+}
+
+func (x *JobRequirements) GetNiceToHaveSkills() []string {
+	if x != nil {
+		return x.NiceToHaveSkills
+	}
+	return nil
+}
+
+func (x *JobRequirements) GetMinYearsExperience() float64 {
+	if x != nil {
+		return x.MinYearsExperience
+	}
+	return 0
+}
+
+func (x *JobRequirements) GetMaxYearsExperience() float64 {
+	if x != nil {
+		return x.MaxYearsExperience
+	}
+	return 0
+}
+
+func (x *JobRequirements) GetRequiredDegreeLevel() string {
+	if x != nil {
+		return x.RequiredDegreeLevel
+	}
+	return ""
+}
+
+func (x *JobRequirements) GetPreferredFields() []string {
+	if x != nil {
+		return x.PreferredFields
+	}
+	return nil
+}
+
+func (x *JobRequirements) GetLocationCity() string {
+	if x != nil {
+		return x.LocationCity
+	}
+	return ""
+}
+
+func (x *JobRequirements) GetLocationCountry() string {
+	if x != nil {
+		return x.LocationCountry
+	}
+	return ""
+}
+
+func (x *JobRequirements) GetLocationType() string {
+	if x != nil {
+		return x.LocationType
+	}
+	return ""
+}
+
+func (x *JobRequirements) GetIndustry() string {
+	if x != nil {
+		return x.Industry
+	}
+	return ""
+}
+
+func (x *JobRequirements) GetRelatedIndustries() []string {
+	if x != nil {
+		return x.RelatedIndustries
+	}
+	return nil
+}
+
+func (x *JobRequirements) GetExperienceLevel() string {
+	if x != nil {
+		return x.ExperienceLevel
+	}
+	return ""
+}
+
+// ScoringWeights are the weights of the score components. Weights that do
+// not sum to 1.0 are renormalized; none may be negative, and at least one
+// must be positive.
+type ScoringWeights struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SkillMatch        float64 `protobuf:"fixed64,1,opt,name=skill_match,json=skillMatch,proto3" json:"skill_match,omitempty"`
+	ExperienceMatch   float64 `protobuf:"fixed64,2,opt,name=experience_match,json=experienceMatch,proto3" json:"experience_match,omitempty"`
+	EducationMatch    float64 `protobuf:"fixed64,3,opt,name=education_match,json=educationMatch,proto3" json:"education_match,omitempty"`
+	LocationFit       float64 `protobuf:"fixed64,4,opt,name=location_fit,json=locationFit,proto3" json:"location_fit,omitempty"`
+	IndustryRelevance float64 `protobuf:"fixed64,5,opt,name=industry_relevance,json=industryRelevance,proto3" json:"industry_relevance,omitempty"`
+}
+
+func (x *ScoringWeights) Reset() {
+	*x = ScoringWeights{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_learnbot_scoring_v1_scoring_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ScoringWeights) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScoringWeights) ProtoMessage() {}
+
+func (x *ScoringWeights) ProtoReflect() protoreflect.Message {
+	mi := &file_learnbot_scoring_v1_scoring_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScoringWeights.ProtoReflect.Descriptor instead.
+func (*ScoringWeights) Descriptor() ([]byte, []int) {
+	return file_learnbot_scoring_v1_scoring_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ScoringWeights) GetSkillMatch() float64 {
+	if x != nil {
+		return x.SkillMatch
+	}
+	return 0
+}
+
+func (x *ScoringWeights) GetExperienceMatch() float64 {
+	if x != nil {
+		return x.ExperienceMatch
+	}
+	return 0
+}
+
+func (x *ScoringWeights) GetEducationMatch() float64 {
+	if x != nil {
+		return x.EducationMatch
+	}
+	return 0
+}
+
+func (x *ScoringWeights) GetLocationFit() float64 {
+	if x != nil {
+		return x.LocationFit
+	}
+	return 0
+}
+
+func (x *ScoringWeights) GetIndustryRelevance() float64 {
+	if x != nil {
+		return x.IndustryRelevance
+	}
+	return 0
+}
+
+type ScoreBreakdown struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Percentage [0, 100].
+	OverallScore float64 `protobuf:"fixed64,1,opt,name=overall_score,json=overallScore,proto3" json:"overall_score,omitempty"`
+	// Component scores [0, 1].
+	SkillMatchScore        float64  `protobuf:"fixed64,2,opt,name=skill_match_score,json=skillMatchScore,proto3" json:"skill_match_score,omitempty"`
+	ExperienceMatchScore   float64  `protobuf:"fixed64,3,opt,name=experience_match_score,json=experienceMatchScore,proto3" json:"experience_match_score,omitempty"`
+	EducationMatchScore    float64  `protobuf:"fixed64,4,opt,name=education_match_score,json=educationMatchScore,proto3" json:"education_match_score,omitempty"`
+	LocationFitScore       float64  `protobuf:"fixed64,5,opt,name=location_fit_score,json=locationFitScore,proto3" json:"location_fit_score,omitempty"`
+	IndustryRelevanceScore float64  `protobuf:"fixed64,6,opt,name=industry_relevance_score,json=industryRelevanceScore,proto3" json:"industry_relevance_score,omitempty"`
+	MatchedRequiredSkills  []string `protobuf:"bytes,7,rep,name=matched_required_skills,json=matchedRequiredSkills,proto3" json:"matched_required_skills,omitempty"`
+	MissingRequiredSkills  []string `protobuf:"bytes,8,rep,name=missing_required_skills,json=missingRequiredSkills,proto3" json:"missing_required_skills,omitempty"`
+	MatchedPreferredSkills []string `protobuf:"bytes,9,rep,name=matched_preferred_skills,json=matchedPreferredSkills,proto3" json:"matched_preferred_skills,omitempty"`
+	// The weights the overall score was computed with, after renormalization.
+	Weights  *ScoringWeights `protobuf:"bytes,10,opt,name=weights,proto3" json:"weights,omitempty"`
+	Warnings []string        `protobuf:"bytes,11,rep,name=warnings,proto3" json:"warnings,omitempty"`
+}
+
+func (x *ScoreBreakdown) Reset() {
+	*x = ScoreBreakdown{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_learnbot_scoring_v1_scoring_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ScoreBreakdown) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScoreBreakdown) ProtoMessage() {}
+
+func (x *ScoreBreakdown) ProtoReflect() protoreflect.Message {
+	mi := &file_learnbot_scoring_v1_scoring_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScoreBreakdown.ProtoReflect.Descriptor instead.
+func (*ScoreBreakdown) Descriptor() ([]byte, []int) {
+	return file_learnbot_scoring_v1_scoring_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ScoreBreakdown) GetOverallScore() float64 {
+	if x != nil {
+		return x.OverallScore
+	}
+	return 0
+}
+
+func (x *ScoreBreakdown) GetSkillMatchScore() float64 {
+	if x != nil {
+		return x.SkillMatchScore
+	}
+	return 0
+}
+
+func (x *ScoreBreakdown) GetExperienceMatchScore() float64 {
+	if x != nil {
+		return x.ExperienceMatchScore
+	}
+	return 0
+}
+
+func (x *ScoreBreakdown) GetEducationMatchScore() float64 {
+	if x != nil {
+		return x.EducationMatchScore
+	}
+	return 0
+}
+
+func (x *ScoreBreakdown) GetLocationFitScore() float64 {
+	if x != nil {
+		return x.LocationFitScore
+	}
+	return 0
+}
+
+func (x *ScoreBreakdown) GetIndustryRelevanceScore() float64 {
+	if x != nil {
+		return x.IndustryRelevanceScore
+	}
+	return 0
+}
+
+func (x *ScoreBreakdown) GetMatchedRequiredSkills() []string {
+	if x != nil {
+		return x.MatchedRequiredSkills
+	}
+	return nil
+}
+
+func (x *ScoreBreakdown) GetMissingRequiredSkills() []string {
+	if x != nil {
+		return x.MissingRequiredSkills
+	}
+	return nil
+}
+
+func (x *ScoreBreakdown) GetMatchedPreferredSkills() []string {
+	if x != nil {
+		return x.MatchedPreferredSkills
+	}
+	return nil
+}
+
+func (x *ScoreBreakdown) GetWeights() *ScoringWeights {
+	if x != nil {
+		return x.Weights
+	}
+	return nil
+}
+
+func (x *ScoreBreakdown) GetWarnings() []string {
+	if x != nil {
+		return x.Warnings
+	}
+	return nil
+}
+
+type CalculateRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Profile *CandidateProfile `protobuf:"bytes,1,opt,name=profile,proto3" json:"profile,omitempty"`
+	Job     *JobRequirements  `protobuf:"bytes,2,opt,name=job,proto3" json:"job,omitempty"`
+	// Raw job posting text. When set, the requirements are extracted from it
+	// and any fields set in job override the extracted ones.
+	JobDescription string `protobuf:"bytes,3,opt,name=job_description,json=jobDescription,proto3" json:"job_description,omitempty"`
+	// Overrides the default weights when set.
+	Weights *ScoringWeights `protobuf:"bytes,4,opt,name=weights,proto3" json:"weights,omitempty"`
+}
+
+func (x *CalculateRequest) Reset() {
+	*x = CalculateRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_learnbot_scoring_v1_scoring_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CalculateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CalculateRequest) ProtoMessage() {}
+
+func (x *CalculateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_learnbot_scoring_v1_scoring_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CalculateRequest.ProtoReflect.Descriptor instead.
+func (*CalculateRequest) Descriptor() ([]byte, []int) {
+	return file_learnbot_scoring_v1_scoring_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *CalculateRequest) GetProfile() *CandidateProfile {
+	if x != nil {
+		return x.Profile
+	}
+	return nil
+}
+
+func (x *CalculateRequest) GetJob() *JobRequirements {
+	if x != nil {
+		return x.Job
+	}
+	return nil
+}
+
+func (x *CalculateRequest) GetJobDescription() string {
+	if x != nil {
+		return x.JobDescription
+	}
+	return ""
+}
+
+func (x *CalculateRequest) GetWeights() *ScoringWeights {
+	if x != nil {
+		return x.Weights
+	}
+	return nil
+}
+
+type CalculateResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Score *ScoreBreakdown `protobuf:"bytes,1,opt,name=score,proto3" json:"score,omitempty"`
+}
+
+func (x *CalculateResponse) Reset() {
+	*x = CalculateResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_learnbot_scoring_v1_scoring_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CalculateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CalculateResponse) ProtoMessage() {}
+
+func (x *CalculateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_learnbot_scoring_v1_scoring_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CalculateResponse.ProtoReflect.Descriptor instead.
+func (*CalculateResponse) Descriptor() ([]byte, []int) {
+	return file_learnbot_scoring_v1_scoring_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *CalculateResponse) GetScore() *ScoreBreakdown {
+	if x != nil {
+		return x.Score
+	}
+	return nil
+}
+
+type BatchCandidate struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Optional caller-supplied identifier, echoed in the result.
+	CandidateId string            `protobuf:"bytes,1,opt,name=candidate_id,json=candidateId,proto3" json:"candidate_id,omitempty"`
+	Profile     *CandidateProfile `protobuf:"bytes,2,opt,name=profile,proto3" json:"profile,omitempty"`
+}
+
+func (x *BatchCandidate) Reset() {
+	*x = BatchCandidate{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_learnbot_scoring_v1_scoring_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BatchCandidate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchCandidate) ProtoMessage() {}
+
+func (x *BatchCandidate) ProtoReflect() protoreflect.Message {
+	mi := &file_learnbot_scoring_v1_scoring_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchCandidate.ProtoReflect.Descriptor instead.
+func (*BatchCandidate) Descriptor() ([]byte, []int) {
+	return file_learnbot_scoring_v1_scoring_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *BatchCandidate) GetCandidateId() string {
+	if x != nil {
+		return x.CandidateId
+	}
+	return ""
+}
+
+func (x *BatchCandidate) GetProfile() *CandidateProfile {
+	if x != nil {
+		return x.Profile
+	}
+	return nil
+}
+
+type CalculateBatchRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Job            *JobRequirements  `protobuf:"bytes,1,opt,name=job,proto3" json:"job,omitempty"`
+	JobDescription string            `protobuf:"bytes,2,opt,name=job_description,json=jobDescription,proto3" json:"job_description,omitempty"`
+	Candidates     []*BatchCandidate `protobuf:"bytes,3,rep,name=candidates,proto3" json:"candidates,omitempty"`
+	Weights        *ScoringWeights   `protobuf:"bytes,4,opt,name=weights,proto3" json:"weights,omitempty"`
+}
+
+func (x *CalculateBatchRequest) Reset() {
+	*x = CalculateBatchRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_learnbot_scoring_v1_scoring_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CalculateBatchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CalculateBatchRequest) ProtoMessage() {}
+
+func (x *CalculateBatchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_learnbot_scoring_v1_scoring_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CalculateBatchRequest.ProtoReflect.Descriptor instead.
+func (*CalculateBatchRequest) Descriptor() ([]byte, []int) {
+	return file_learnbot_scoring_v1_scoring_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *CalculateBatchRequest) GetJob() *JobRequirements {
+	if x != nil {
+		return x.Job
+	}
+	return nil
+}
+
+func (x *CalculateBatchRequest) GetJobDescription() string {
+	if x != nil {
+		return x.JobDescription
+	}
+	return ""
+}
+
+func (x *CalculateBatchRequest) GetCandidates() []*BatchCandidate {
+	if x != nil {
+		return x.Candidates
+	}
+	return nil
+}
+
+func (x *CalculateBatchRequest) GetWeights() *ScoringWeights {
+	if x != nil {
+		return x.Weights
+	}
+	return nil
+}
+
+type ScoreResult struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The candidate's position in the request.
+	Index       int32  `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`
+	CandidateId string `protobuf:"bytes,2,opt,name=candidate_id,json=candidateId,proto3" json:"candidate_id,omitempty"`
+	// Position by overall score (1 = best); equal scores share a rank.
+	Rank  int32           `protobuf:"varint,3,opt,name=rank,proto3" json:"rank,omitempty"`
+	Score *ScoreBreakdown `protobuf:"bytes,4,opt,name=score,proto3" json:"score,omitempty"`
+}
+
+func (x *ScoreResult) Reset() {
+	*x = ScoreResult{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_learnbot_scoring_v1_scoring_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ScoreResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScoreResult) ProtoMessage() {}
+
+func (x *ScoreResult) ProtoReflect() protoreflect.Message {
+	mi := &file_learnbot_scoring_v1_scoring_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScoreResult.ProtoReflect.Descriptor instead.
+func (*ScoreResult) Descriptor() ([]byte, []int) {
+	return file_learnbot_scoring_v1_scoring_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ScoreResult) GetIndex() int32 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *ScoreResult) GetCandidateId() string {
+	if x != nil {
+		return x.CandidateId
+	}
+	return ""
+}
+
+func (x *ScoreResult) GetRank() int32 {
+	if x != nil {
+		return x.Rank
+	}
+	return 0
+}
+
+func (x *ScoreResult) GetScore() *ScoreBreakdown {
+	if x != nil {
+		return x.Score
+	}
+	return nil
+}
+
+type CalculateBatchResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// One result per candidate, in request order.
+	Results []*ScoreResult `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+}
+
+func (x *CalculateBatchResponse) Reset() {
+	*x = CalculateBatchResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_learnbot_scoring_v1_scoring_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CalculateBatchResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CalculateBatchResponse) ProtoMessage() {}
+
+func (x *CalculateBatchResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_learnbot_scoring_v1_scoring_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CalculateBatchResponse.ProtoReflect.Descriptor instead.
+func (*CalculateBatchResponse) Descriptor() ([]byte, []int) {
+	return file_learnbot_scoring_v1_scoring_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *CalculateBatchResponse) GetResults() []*ScoreResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+type AnalyzeRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Profile        *CandidateProfile `protobuf:"bytes,1,opt,name=profile,proto3" json:"profile,omitempty"`
+	Job            *JobRequirements  `protobuf:"bytes,2,opt,name=job,proto3" json:"job,omitempty"`
+	JobDescription string            `protobuf:"bytes,3,opt,name=job_description,json=jobDescription,proto3" json:"job_description,omitempty"`
+}
+
+func (x *AnalyzeRequest) Reset() {
+	*x = AnalyzeRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_learnbot_scoring_v1_scoring_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AnalyzeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AnalyzeRequest) ProtoMessage() {}
+
+func (x *AnalyzeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_learnbot_scoring_v1_scoring_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AnalyzeRequest.ProtoReflect.Descriptor instead.
+func (*AnalyzeRequest) Descriptor() ([]byte, []int) {
+	return file_learnbot_scoring_v1_scoring_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *AnalyzeRequest) GetProfile() *CandidateProfile {
+	if x != nil {
+		return x.Profile
+	}
+	return nil
+}
+
+func (x *AnalyzeRequest) GetJob() *JobRequirements {
+	if x != nil {
+		return x.Job
+	}
+	return nil
+}
+
+func (x *AnalyzeRequest) GetJobDescription() string {
+	if x != nil {
+		return x.JobDescription
+	}
+	return ""
+}
+
+type AnalyzeResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Result *GapAnalysisResult `protobuf:"bytes,1,opt,name=result,proto3" json:"result,omitempty"`
+}
+
+func (x *AnalyzeResponse) Reset() {
+	*x = AnalyzeResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_learnbot_scoring_v1_scoring_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AnalyzeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AnalyzeResponse) ProtoMessage() {}
+
+func (x *AnalyzeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_learnbot_scoring_v1_scoring_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AnalyzeResponse.ProtoReflect.Descriptor instead.
+func (*AnalyzeResponse) Descriptor() ([]byte, []int) {
+	return file_learnbot_scoring_v1_scoring_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *AnalyzeResponse) GetResult() *GapAnalysisResult {
+	if x != nil {
+		return x.Result
+	}
+	return nil
+}
+
+type GapAnalysisResult struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	CriticalGaps                []*SkillGap `protobuf:"bytes,1,rep,name=critical_gaps,json=criticalGaps,proto3" json:"critical_gaps,omitempty"`
+	ImportantGaps               []*SkillGap `protobuf:"bytes,2,rep,name=important_gaps,json=importantGaps,proto3" json:"important_gaps,omitempty"`
+	NiceToHaveGaps              []*SkillGap `protobuf:"bytes,3,rep,name=nice_to_have_gaps,json=niceToHaveGaps,proto3" json:"nice_to_have_gaps,omitempty"`
+	TotalGaps                   int32       `protobuf:"varint,4,opt,name=total_gaps,json=totalGaps,proto3" json:"total_gaps,omitempty"`
+	CriticalGapCount            int32       `protobuf:"varint,5,opt,name=critical_gap_count,json=criticalGapCount,proto3" json:"critical_gap_count,omitempty"`
+	ImportantGapCount           int32       `protobuf:"varint,6,opt,name=important_gap_count,json=importantGapCount,proto3" json:"important_gap_count,omitempty"`
+	NiceToHaveGapCount          int32       `protobuf:"varint,7,opt,name=nice_to_have_gap_count,json=niceToHaveGapCount,proto3" json:"nice_to_have_gap_count,omitempty"`
+	TotalEstimatedLearningHours int32       `protobuf:"varint,8,opt,name=total_estimated_learning_hours,json=totalEstimatedLearningHours,proto3" json:"total_estimated_learning_hours,omitempty"`
+	// [0, 100].
+	ReadinessScore  float64        `protobuf:"fixed64,9,opt,name=readiness_score,json=readinessScore,proto3" json:"readiness_score,omitempty"`
+	TopPriorityGaps []*SkillGap    `protobuf:"bytes,10,rep,name=top_priority_gaps,json=topPriorityGaps,proto3" json:"top_priority_gaps,omitempty"`
+	MatchedSkills   []string       `protobuf:"bytes,11,rep,name=matched_skills,json=matchedSkills,proto3" json:"matched_skills,omitempty"`
+	WeakMatches     []*WeakMatch   `protobuf:"bytes,12,rep,name=weak_matches,json=weakMatches,proto3" json:"weak_matches,omitempty"`
+	VisualData      *GapVisualData `protobuf:"bytes,13,opt,name=visual_data,json=visualData,proto3" json:"visual_data,omitempty"`
+}
+
+func (x *GapAnalysisResult) Reset() {
+	*x = GapAnalysisResult{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_learnbot_scoring_v1_scoring_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GapAnalysisResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GapAnalysisResult) ProtoMessage() {}
+
+func (x *GapAnalysisResult) ProtoReflect() protoreflect.Message {
+	mi := &file_learnbot_scoring_v1_scoring_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GapAnalysisResult.ProtoReflect.Descriptor instead.
+func (*GapAnalysisResult) Descriptor() ([]byte, []int) {
+	return file_learnbot_scoring_v1_scoring_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *GapAnalysisResult) GetCriticalGaps() []*SkillGap {
+	if x != nil {
+		return x.CriticalGaps
+	}
+	return nil
+}
+
+func (x *GapAnalysisResult) GetImportantGaps() []*SkillGap {
+	if x != nil {
+		return x.ImportantGaps
+	}
+	return nil
+}
+
+func (x *GapAnalysisResult) GetNiceToHaveGaps() []*SkillGap {
+	if x != nil {
+		return x.NiceToHaveGaps
+	}
+	return nil
+}
+
+func (x *GapAnalysisResult) GetTotalGaps() int32 {
+	if x != nil {
+		return x.TotalGaps
+	}
+	return 0
+}
+
+func (x *GapAnalysisResult) GetCriticalGapCount() int32 {
+	if x != nil {
+		return x.CriticalGapCount
+	}
+	return 0
+}
+
+func (x *GapAnalysisResult) GetImportantGapCount() int32 {
+	if x != nil {
+		return x.ImportantGapCount
+	}
+	return 0
+}
+
+func (x *GapAnalysisResult) GetNiceToHaveGapCount() int32 {
+	if x != nil {
+		return x.NiceToHaveGapCount
+	}
+	return 0
+}
+
+func (x *GapAnalysisResult) GetTotalEstimatedLearningHours() int32 {
+	if x != nil {
+		return x.TotalEstimatedLearningHours
+	}
+	return 0
+}
+
+func (x *GapAnalysisResult) GetReadinessScore() float64 {
+	if x != nil {
+		return x.ReadinessScore
+	}
+	return 0
+}
+
+func (x *GapAnalysisResult) GetTopPriorityGaps() []*SkillGap {
+	if x != nil {
+		return x.TopPriorityGaps
+	}
+	return nil
+}
+
+func (x *GapAnalysisResult) GetMatchedSkills() []string {
+	if x != nil {
+		return x.MatchedSkills
+	}
+	return nil
+}
+
+func (x *GapAnalysisResult) GetWeakMatches() []*WeakMatch {
+	if x != nil {
+		return x.WeakMatches
+	}
+	return nil
+}
+
+func (x *GapAnalysisResult) GetVisualData() *GapVisualData {
+	if x != nil {
+		return x.VisualData
+	}
+	return nil
+}
+
+type SkillGap struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SkillName string `protobuf:"bytes,1,opt,name=skill_name,json=skillName,proto3" json:"skill_name,omitempty"`
+	// "critical", "important" or "nice_to_have".
+	Category                string            `protobuf:"bytes,2,opt,name=category,proto3" json:"category,omitempty"`
+	PriorityScore           float64           `protobuf:"fixed64,3,opt,name=priority_score,json=priorityScore,proto3" json:"priority_score,omitempty"`
+	ImportanceScore         float64           `protobuf:"fixed64,4,opt,name=importance_score,json=importanceScore,proto3" json:"importance_score,omitempty"`
+	EstimatedLearningHours  int32             `protobuf:"varint,5,opt,name=estimated_learning_hours,json=estimatedLearningHours,proto3" json:"estimated_learning_hours,omitempty"`
+	TransferabilityScore    float64           `protobuf:"fixed64,6,opt,name=transferability_score,json=transferabilityScore,proto3" json:"transferability_score,omitempty"`
+	DemandScore             float64           `protobuf:"fixed64,7,opt,name=demand_score,json=demandScore,proto3" json:"demand_score,omitempty"`
+	CurrentLevel            string            `protobuf:"bytes,8,opt,name=current_level,json=currentLevel,proto3" json:"current_level,omitempty"`
+	TargetLevel             string            `protobuf:"bytes,9,opt,name=target_level,json=targetLevel,proto3" json:"target_level,omitempty"`
+	SemanticSimilarityScore float64           `protobuf:"fixed64,10,opt,name=semantic_similarity_score,json=semanticSimilarityScore,proto3" json:"semantic_similarity_score,omitempty"`
+	ClosestExistingSkill    string            `protobuf:"bytes,11,opt,name=closest_existing_skill,json=closestExistingSkill,proto3" json:"closest_existing_skill,omitempty"`
+	Recommendations         []*Recommendation `protobuf:"bytes,12,rep,name=recommendations,proto3" json:"recommendations,omitempty"`
+	Difficulty              string            `protobuf:"bytes,13,opt,name=difficulty,proto3" json:"difficulty,omitempty"`
+}
+
+func (x *SkillGap) Reset() {
+	*x = SkillGap{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_learnbot_scoring_v1_scoring_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SkillGap) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SkillGap) ProtoMessage() {}
+
+func (x *SkillGap) ProtoReflect() protoreflect.Message {
+	mi := &file_learnbot_scoring_v1_scoring_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SkillGap.ProtoReflect.Descriptor instead.
+func (*SkillGap) Descriptor() ([]byte, []int) {
+	return file_learnbot_scoring_v1_scoring_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *SkillGap) GetSkillName() string {
+	if x != nil {
+		return x.SkillName
+	}
+	return ""
+}
+
+func (x *SkillGap) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+func (x *SkillGap) GetPriorityScore() float64 {
+	if x != nil {
+		return x.PriorityScore
+	}
+	return 0
+}
+
+func (x *SkillGap) GetImportanceScore() float64 {
+	if x != nil {
+		return x.ImportanceScore
+	}
+	return 0
+}
+
+func (x *SkillGap) GetEstimatedLearningHours() int32 {
+	if x != nil {
+		return x.EstimatedLearningHours
+	}
+	return 0
+}
+
+func (x *SkillGap) GetTransferabilityScore() float64 {
+	if x != nil {
+		return x.TransferabilityScore
+	}
+	return 0
+}
+
+func (x *SkillGap) GetDemandScore() float64 {
+	if x != nil {
+		return x.DemandScore
+	}
+	return 0
+}
+
+func (x *SkillGap) GetCurrentLevel() string {
+	if x != nil {
+		return x.CurrentLevel
+	}
+	return ""
+}
+
+func (x *SkillGap) GetTargetLevel() string {
+	if x != nil {
+		return x.TargetLevel
+	}
+	return ""
+}
+
+func (x *SkillGap) GetSemanticSimilarityScore() float64 {
+	if x != nil {
+		return x.SemanticSimilarityScore
+	}
+	return 0
+}
+
+func (x *SkillGap) GetClosestExistingSkill() string {
+	if x != nil {
+		return x.ClosestExistingSkill
+	}
+	return ""
+}
+
+func (x *SkillGap) GetRecommendations() []*Recommendation {
+	if x != nil {
+		return x.Recommendations
+	}
+	return nil
+}
+
+func (x *SkillGap) GetDifficulty() string {
+	if x != nil {
+		return x.Difficulty
+	}
+	return ""
+}
+
+type Recommendation struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Title          string `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty"`
+	Description    string `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	ResourceType   string `protobuf:"bytes,3,opt,name=resource_type,json=resourceType,proto3" json:"resource_type,omitempty"`
+	EstimatedHours int32  `protobuf:"varint,4,opt,name=estimated_hours,json=estimatedHours,proto3" json:"estimated_hours,omitempty"`
+	Priority       int32  `protobuf:"varint,5,opt,name=priority,proto3" json:"priority,omitempty"`
+}
+
+func (x *Recommendation) Reset() {
+	*x = Recommendation{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_learnbot_scoring_v1_scoring_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Recommendation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Recommendation) ProtoMessage() {}
+
+func (x *Recommendation) ProtoReflect() protoreflect.Message {
+	mi := &file_learnbot_scoring_v1_scoring_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Recommendation.ProtoReflect.Descriptor instead.
+func (*Recommendation) Descriptor() ([]byte, []int) {
+	return file_learnbot_scoring_v1_scoring_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *Recommendation) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *Recommendation) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Recommendation) GetResourceType() string {
+	if x != nil {
+		return x.ResourceType
+	}
+	return ""
+}
+
+func (x *Recommendation) GetEstimatedHours() int32 {
+	if x != nil {
+		return x.EstimatedHours
+	}
+	return 0
+}
+
+func (x *Recommendation) GetPriority() int32 {
+	if x != nil {
+		return x.Priority
+	}
+	return 0
+}
+
+type WeakMatch struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SkillName          string  `protobuf:"bytes,1,opt,name=skill_name,json=skillName,proto3" json:"skill_name,omitempty"`
+	Category           string  `protobuf:"bytes,2,opt,name=category,proto3" json:"category,omitempty"`
+	CurrentLevel       string  `protobuf:"bytes,3,opt,name=current_level,json=currentLevel,proto3" json:"current_level,omitempty"`
+	TargetLevel        string  `protobuf:"bytes,4,opt,name=target_level,json=targetLevel,proto3" json:"target_level,omitempty"`
+	LevelsBelow        int32   `protobuf:"varint,5,opt,name=levels_below,json=levelsBelow,proto3" json:"levels_below,omitempty"`
+	ReadinessDeduction float64 `protobuf:"fixed64,6,opt,name=readiness_deduction,json=readinessDeduction,proto3" json:"readiness_deduction,omitempty"`
+}
+
+func (x *WeakMatch) Reset() {
+	*x = WeakMatch{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_learnbot_scoring_v1_scoring_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WeakMatch) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WeakMatch) ProtoMessage() {}
+
+func (x *WeakMatch) ProtoReflect() protoreflect.Message {
+	mi := &file_learnbot_scoring_v1_scoring_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WeakMatch.ProtoReflect.Descriptor instead.
+func (*WeakMatch) Descriptor() ([]byte, []int) {
+	return file_learnbot_scoring_v1_scoring_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *WeakMatch) GetSkillName() string {
+	if x != nil {
+		return x.SkillName
+	}
+	return ""
+}
+
+func (x *WeakMatch) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+func (x *WeakMatch) GetCurrentLevel() string {
+	if x != nil {
+		return x.CurrentLevel
+	}
+	return ""
+}
+
+func (x *WeakMatch) GetTargetLevel() string {
+	if x != nil {
+		return x.TargetLevel
+	}
+	return ""
+}
+
+func (x *WeakMatch) GetLevelsBelow() int32 {
+	if x != nil {
+		return x.LevelsBelow
+	}
+	return 0
+}
+
+func (x *WeakMatch) GetReadinessDeduction() float64 {
+	if x != nil {
+		return x.ReadinessDeduction
+	}
+	return 0
+}
+
+type GapVisualData struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	RadarChart       *RadarChartData    `protobuf:"bytes,1,opt,name=radar_chart,json=radarChart,proto3" json:"radar_chart,omitempty"`
+	GapsByCategory   []*CategorySummary `protobuf:"bytes,2,rep,name=gaps_by_category,json=gapsByCategory,proto3" json:"gaps_by_category,omitempty"`
+	LearningTimeline []*TimelineEntry   `protobuf:"bytes,3,rep,name=learning_timeline,json=learningTimeline,proto3" json:"learning_timeline,omitempty"`
+}
+
+func (x *GapVisualData) Reset() {
+	*x = GapVisualData{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_learnbot_scoring_v1_scoring_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GapVisualData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GapVisualData) ProtoMessage() {}
+
+func (x *GapVisualData) ProtoReflect() protoreflect.Message {
+	mi := &file_learnbot_scoring_v1_scoring_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GapVisualData.ProtoReflect.Descriptor instead.
+func (*GapVisualData) Descriptor() ([]byte, []int) {
+	return file_learnbot_scoring_v1_scoring_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *GapVisualData) GetRadarChart() *RadarChartData {
+	if x != nil {
+		return x.RadarChart
+	}
+	return nil
+}
+
+func (x *GapVisualData) GetGapsByCategory() []*CategorySummary {
+	if x != nil {
+		return x.GapsByCategory
+	}
+	return nil
+}
+
+func (x *GapVisualData) GetLearningTimeline() []*TimelineEntry {
+	if x != nil {
+		return x.LearningTimeline
+	}
+	return nil
+}
+
+type RadarChartData struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Labels          []string  `protobuf:"bytes,1,rep,name=labels,proto3" json:"labels,omitempty"`
+	CandidateScores []float64 `protobuf:"fixed64,2,rep,packed,name=candidate_scores,json=candidateScores,proto3" json:"candidate_scores,omitempty"`
+	RequiredScores  []float64 `protobuf:"fixed64,3,rep,packed,name=required_scores,json=requiredScores,proto3" json:"required_scores,omitempty"`
+}
+
+func (x *RadarChartData) Reset() {
+	*x = RadarChartData{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_learnbot_scoring_v1_scoring_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RadarChartData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RadarChartData) ProtoMessage() {}
+
+func (x *RadarChartData) ProtoReflect() protoreflect.Message {
+	mi := &file_learnbot_scoring_v1_scoring_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RadarChartData.ProtoReflect.Descriptor instead.
+func (*RadarChartData) Descriptor() ([]byte, []int) {
+	return file_learnbot_scoring_v1_scoring_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *RadarChartData) GetLabels() []string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+func (x *RadarChartData) GetCandidateScores() []float64 {
+	if x != nil {
+		return x.CandidateScores
+	}
+	return nil
+}
+
+func (x *RadarChartData) GetRequiredScores() []float64 {
+	if x != nil {
+		return x.RequiredScores
+	}
+	return nil
+}
+
+type CategorySummary struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Category           string  `protobuf:"bytes,1,opt,name=category,proto3" json:"category,omitempty"`
+	GapCount           int32   `protobuf:"varint,2,opt,name=gap_count,json=gapCount,proto3" json:"gap_count,omitempty"`
+	TotalLearningHours int32   `protobuf:"varint,3,opt,name=total_learning_hours,json=totalLearningHours,proto3" json:"total_learning_hours,omitempty"`
+	AveragePriority    float64 `protobuf:"fixed64,4,opt,name=average_priority,json=averagePriority,proto3" json:"average_priority,omitempty"`
+}
+
+func (x *CategorySummary) Reset() {
+	*x = CategorySummary{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_learnbot_scoring_v1_scoring_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CategorySummary) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CategorySummary) ProtoMessage() {}
+
+func (x *CategorySummary) ProtoReflect() protoreflect.Message {
+	mi := &file_learnbot_scoring_v1_scoring_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CategorySummary.ProtoReflect.Descriptor instead.
+func (*CategorySummary) Descriptor() ([]byte, []int) {
+	return file_learnbot_scoring_v1_scoring_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *CategorySummary) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+func (x *CategorySummary) GetGapCount() int32 {
+	if x != nil {
+		return x.GapCount
+	}
+	return 0
+}
+
+func (x *CategorySummary) GetTotalLearningHours() int32 {
+	if x != nil {
+		return x.TotalLearningHours
+	}
+	return 0
+}
+
+func (x *CategorySummary) GetAveragePriority() float64 {
+	if x != nil {
+		return x.AveragePriority
+	}
+	return 0
+}
+
+type TimelineEntry struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Order           int32  `protobuf:"varint,1,opt,name=order,proto3" json:"order,omitempty"`
+	SkillName       string `protobuf:"bytes,2,opt,name=skill_name,json=skillName,proto3" json:"skill_name,omitempty"`
+	Category        string `protobuf:"bytes,3,opt,name=category,proto3" json:"category,omitempty"`
+	EstimatedHours  int32  `protobuf:"varint,4,opt,name=estimated_hours,json=estimatedHours,proto3" json:"estimated_hours,omitempty"`
+	CumulativeHours int32  `protobuf:"varint,5,opt,name=cumulative_hours,json=cumulativeHours,proto3" json:"cumulative_hours,omitempty"`
+	Rationale       string `protobuf:"bytes,6,opt,name=rationale,proto3" json:"rationale,omitempty"`
+}
+
+func (x *TimelineEntry) Reset() {
+	*x = TimelineEntry{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_learnbot_scoring_v1_scoring_proto_msgTypes[24]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TimelineEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TimelineEntry) ProtoMessage() {}
+
+func (x *TimelineEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_learnbot_scoring_v1_scoring_proto_msgTypes[24]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TimelineEntry.ProtoReflect.Descriptor instead.
+func (*TimelineEntry) Descriptor() ([]byte, []int) {
+	return file_learnbot_scoring_v1_scoring_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *TimelineEntry) GetOrder() int32 {
+	if x != nil {
+		return x.Order
+	}
+	return 0
+}
+
+func (x *TimelineEntry) GetSkillName() string {
+	if x != nil {
+		return x.SkillName
+	}
+	return ""
+}
+
+func (x *TimelineEntry) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+func (x *TimelineEntry) GetEstimatedHours() int32 {
+	if x != nil {
+		return x.EstimatedHours
+	}
+	return 0
+}
+
+func (x *TimelineEntry) GetCumulativeHours() int32 {
+	if x != nil {
+		return x.CumulativeHours
+	}
+	return 0
+}
+
+func (x *TimelineEntry) GetRationale() string {
+	if x != nil {
+		return x.Rationale
+	}
+	return ""
+}
+
+var File_learnbot_scoring_v1_scoring_proto protoreflect.FileDescriptor
+
+var file_learnbot_scoring_v1_scoring_proto_rawDesc = []byte{
+	0x0a, 0x21, 0x6c, 0x65, 0x61, 0x72, 0x6e, 0x62, 0x6f, 0x74, 0x2f, 0x73, 0x63, 0x6f, 0x72, 0x69,
+	0x6e, 0x67, 0x2f, 0x76, 0x31, 0x2f, 0x73, 0x63, 0x6f, 0x72, 0x69, 0x6e, 0x67, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x12, 0x13, 0x6c, 0x65, 0x61, 0x72, 0x6e, 0x62, 0x6f, 0x74, 0x2e, 0x73, 0x63,
+	0x6f, 0x72, 0x69, 0x6e, 0x67, 0x2e, 0x76, 0x31, 0x22, 0xc9, 0x04, 0x0a, 0x10, 0x43, 0x61, 0x6e,
+	0x64, 0x69, 0x64, 0x61, 0x74, 0x65, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x12, 0x3b, 0x0a,
+	0x06, 0x73, 0x6b, 0x69, 0x6c, 0x6c, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x23, 0x2e,
+	0x6c, 0x65, 0x61, 0x72, 0x6e, 0x62, 0x6f, 0x74, 0x2e, 0x73, 0x63, 0x6f, 0x72, 0x69, 0x6e, 0x67,
+	0x2e, 0x76, 0x31, 0x2e, 0x43, 0x61, 0x6e, 0x64, 0x69, 0x64, 0x61, 0x74, 0x65, 0x53, 0x6b, 0x69,
+	0x6c, 0x6c, 0x52, 0x06, 0x73, 0x6b, 0x69, 0x6c, 0x6c, 0x73, 0x12, 0x2e, 0x0a, 0x13, 0x79, 0x65,
+	0x61, 0x72, 0x73, 0x5f, 0x6f, 0x66, 0x5f, 0x65, 0x78, 0x70, 0x65, 0x72, 0x69, 0x65, 0x6e, 0x63,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x11, 0x79, 0x65, 0x61, 0x72, 0x73, 0x4f, 0x66,
+	0x45, 0x78, 0x70, 0x65, 0x72, 0x69, 0x65, 0x6e, 0x63, 0x65, 0x12, 0x48, 0x0a, 0x0c, 0x77, 0x6f,
+	0x72, 0x6b, 0x5f, 0x68, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x25, 0x2e, 0x6c, 0x65, 0x61, 0x72, 0x6e, 0x62, 0x6f, 0x74, 0x2e, 0x73, 0x63, 0x6f, 0x72,
+	0x69, 0x6e, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x57, 0x6f, 0x72, 0x6b, 0x48, 0x69, 0x73, 0x74, 0x6f,
+	0x72, 0x79, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0b, 0x77, 0x6f, 0x72, 0x6b, 0x48, 0x69, 0x73,
+	0x74, 0x6f, 0x72, 0x79, 0x12, 0x41, 0x0a, 0x09, 0x65, 0x64, 0x75, 0x63, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x6c, 0x65, 0x61, 0x72, 0x6e, 0x62,
+	0x6f, 0x74, 0x2e, 0x73, 0x63, 0x6f, 0x72, 0x69, 0x6e, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x64,
+	0x75, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x09, 0x65, 0x64,
+	0x75, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x4f, 0x0a, 0x0e, 0x63, 0x65, 0x72, 0x74, 0x69,
+	0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x27, 0x2e, 0x6c, 0x65, 0x61, 0x72, 0x6e, 0x62, 0x6f, 0x74, 0x2e, 0x73, 0x63, 0x6f, 0x72, 0x69,
+	0x6e, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x65, 0x72, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0e, 0x63, 0x65, 0x72, 0x74, 0x69, 0x66,
+	0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x3d, 0x0a, 0x08, 0x70, 0x72, 0x6f, 0x6a,
+	0x65, 0x63, 0x74, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x6c, 0x65, 0x61,
+	0x72, 0x6e, 0x62, 0x6f, 0x74, 0x2e, 0x73, 0x63, 0x6f, 0x72, 0x69, 0x6e, 0x67, 0x2e, 0x76, 0x31,
+	0x2e, 0x50, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x08, 0x70,
+	0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x73, 0x12, 0x23, 0x0a, 0x0d, 0x6c, 0x6f, 0x63, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x5f, 0x63, 0x69, 0x74, 0x79, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c,
+	0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x43, 0x69, 0x74, 0x79, 0x12, 0x29, 0x0a, 0x10,
+	0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x72, 0x79,
+	0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x43, 0x6f, 0x75, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x2e, 0x0a, 0x13, 0x77, 0x69, 0x6c, 0x6c, 0x69,
+	0x6e, 0x67, 0x5f, 0x74, 0x6f, 0x5f, 0x72, 0x65, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x65, 0x18, 0x09,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x11, 0x77, 0x69, 0x6c, 0x6c, 0x69, 0x6e, 0x67, 0x54, 0x6f, 0x52,
+	0x65, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x65, 0x12, 0x2b, 0x0a, 0x11, 0x72, 0x65, 0x6d, 0x6f, 0x74,
+	0x65, 0x5f, 0x70, 0x72, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x18, 0x0a, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x10, 0x72, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x50, 0x72, 0x65, 0x66, 0x65, 0x72,
+	0x65, 0x6e, 0x63, 0x65, 0x22, 0x76, 0x0a, 0x0e, 0x43, 0x61, 0x6e, 0x64, 0x69, 0x64, 0x61, 0x74,
+	0x65, 0x53, 0x6b, 0x69, 0x6c, 0x6c, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x70, 0x72,
+	0x6f, 0x66, 0x69, 0x63, 0x69, 0x65, 0x6e, 0x63, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0b, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x63, 0x69, 0x65, 0x6e, 0x63, 0x79, 0x12, 0x2e, 0x0a, 0x13,
+	0x79, 0x65, 0x61, 0x72, 0x73, 0x5f, 0x6f, 0x66, 0x5f, 0x65, 0x78, 0x70, 0x65, 0x72, 0x69, 0x65,
+	0x6e, 0x63, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x11, 0x79, 0x65, 0x61, 0x72, 0x73,
+	0x4f, 0x66, 0x45, 0x78, 0x70, 0x65, 0x72, 0x69, 0x65, 0x6e, 0x63, 0x65, 0x22, 0x8c, 0x01, 0x0a,
+	0x10, 0x57, 0x6f, 0x72, 0x6b, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x45, 0x6e, 0x74, 0x72,
+	0x79, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x69, 0x6e, 0x64, 0x75, 0x73,
+	0x74, 0x72, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x69, 0x6e, 0x64, 0x75, 0x73,
+	0x74, 0x72, 0x79, 0x12, 0x27, 0x0a, 0x0f, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f,
+	0x6d, 0x6f, 0x6e, 0x74, 0x68, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0e, 0x64, 0x75,
+	0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4d, 0x6f, 0x6e, 0x74, 0x68, 0x73, 0x12, 0x1d, 0x0a, 0x0a,
+	0x69, 0x73, 0x5f, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x09, 0x69, 0x73, 0x43, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x22, 0x59, 0x0a, 0x0e, 0x45,
+	0x64, 0x75, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x21, 0x0a,
+	0x0c, 0x64, 0x65, 0x67, 0x72, 0x65, 0x65, 0x5f, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x67, 0x72, 0x65, 0x65, 0x4c, 0x65, 0x76, 0x65, 0x6c,
+	0x12, 0x24, 0x0a, 0x0e, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x5f, 0x6f, 0x66, 0x5f, 0x73, 0x74, 0x75,
+	0x64, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x4f,
+	0x66, 0x53, 0x74, 0x75, 0x64, 0x79, 0x22, 0x54, 0x0a, 0x12, 0x43, 0x65, 0x72, 0x74, 0x69, 0x66,
+	0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x12, 0x0a, 0x04,
+	0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65,
+	0x12, 0x16, 0x0a, 0x06, 0x69, 0x73, 0x73, 0x75, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x06, 0x69, 0x73, 0x73, 0x75, 0x65, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x79, 0x65, 0x61, 0x72,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x79, 0x65, 0x61, 0x72, 0x22, 0x6a, 0x0a, 0x0c,
+	0x50, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x14, 0x0a, 0x05,
+	0x74, 0x69, 0x74, 0x6c, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x69, 0x74,
+	0x6c, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f,
+	0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70,
+	0x74, 0x69, 0x6f, 0x6e, 0x12, 0x22, 0x0a, 0x0c, 0x74, 0x65, 0x63, 0x68, 0x6e, 0x6f, 0x6c, 0x6f,
+	0x67, 0x69, 0x65, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0c, 0x74, 0x65, 0x63, 0x68,
+	0x6e, 0x6f, 0x6c, 0x6f, 0x67, 0x69, 0x65, 0x73, 0x22, 0xd8, 0x04, 0x0a, 0x0f, 0x4a, 0x6f, 0x62,
+	0x52, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x14, 0x0a, 0x05,
+	0x74, 0x69, 0x74, 0x6c, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x69, 0x74,
+	0x6c, 0x65, 0x12, 0x27, 0x0a, 0x0f, 0x72, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x64, 0x5f, 0x73,
+	0x6b, 0x69, 0x6c, 0x6c, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0e, 0x72, 0x65, 0x71,
+	0x75, 0x69, 0x72, 0x65, 0x64, 0x53, 0x6b, 0x69, 0x6c, 0x6c, 0x73, 0x12, 0x29, 0x0a, 0x10, 0x70,
+	0x72, 0x65, 0x66, 0x65, 0x72, 0x72, 0x65, 0x64, 0x5f, 0x73, 0x6b, 0x69, 0x6c, 0x6c, 0x73, 0x18,
+	0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0f, 0x70, 0x72, 0x65, 0x66, 0x65, 0x72, 0x72, 0x65, 0x64,
+	0x53, 0x6b, 0x69, 0x6c, 0x6c, 0x73, 0x12, 0x2d, 0x0a, 0x13, 0x6e, 0x69, 0x63, 0x65, 0x5f, 0x74,
+	0x6f, 0x5f, 0x68, 0x61, 0x76, 0x65, 0x5f, 0x73, 0x6b, 0x69, 0x6c, 0x6c, 0x73, 0x18, 0x04, 0x20,
+	0x03, 0x28, 0x09, 0x52, 0x10, 0x6e, 0x69, 0x63, 0x65, 0x54, 0x6f, 0x48, 0x61, 0x76, 0x65, 0x53,
+	0x6b, 0x69, 0x6c, 0x6c, 0x73, 0x12, 0x30, 0x0a, 0x14, 0x6d, 0x69, 0x6e, 0x5f, 0x79, 0x65, 0x61,
+	0x72, 0x73, 0x5f, 0x65, 0x78, 0x70, 0x65, 0x72, 0x69, 0x65, 0x6e, 0x63, 0x65, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x01, 0x52, 0x12, 0x6d, 0x69, 0x6e, 0x59, 0x65, 0x61, 0x72, 0x73, 0x45, 0x78, 0x70,
+	0x65, 0x72, 0x69, 0x65, 0x6e, 0x63, 0x65, 0x12, 0x30, 0x0a, 0x14, 0x6d, 0x61, 0x78, 0x5f, 0x79,
+	0x65, 0x61, 0x72, 0x73, 0x5f, 0x65, 0x78, 0x70, 0x65, 0x72, 0x69, 0x65, 0x6e, 0x63, 0x65, 0x18,
+	0x06, 0x20, 0x01, 0x28, 0x01, 0x52, 0x12, 0x6d, 0x61, 0x78, 0x59, 0x65, 0x61, 0x72, 0x73, 0x45,
+	0x78, 0x70, 0x65, 0x72, 0x69, 0x65, 0x6e, 0x63, 0x65, 0x12, 0x32, 0x0a, 0x15, 0x72, 0x65, 0x71,
+	0x75, 0x69, 0x72, 0x65, 0x64, 0x5f, 0x64, 0x65, 0x67, 0x72, 0x65, 0x65, 0x5f, 0x6c, 0x65, 0x76,
+	0x65, 0x6c, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x13, 0x72, 0x65, 0x71, 0x75, 0x69, 0x72,
+	0x65, 0x64, 0x44, 0x65, 0x67, 0x72, 0x65, 0x65, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x12, 0x29, 0x0a,
+	0x10, 0x70, 0x72, 0x65, 0x66, 0x65, 0x72, 0x72, 0x65, 0x64, 0x5f, 0x66, 0x69, 0x65, 0x6c, 0x64,
+	0x73, 0x18, 0x08, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0f, 0x70, 0x72, 0x65, 0x66, 0x65, 0x72, 0x72,
+	0x65, 0x64, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x12, 0x23, 0x0a, 0x0d, 0x6c, 0x6f, 0x63, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x63, 0x69, 0x74, 0x79, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0c, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x43, 0x69, 0x74, 0x79, 0x12, 0x29, 0x0a,
+	0x10, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x72,
+	0x79, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x23, 0x0a, 0x0d, 0x6c, 0x6f, 0x63, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0c, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x79, 0x70, 0x65, 0x12, 0x1a, 0x0a,
+	0x08, 0x69, 0x6e, 0x64, 0x75, 0x73, 0x74, 0x72, 0x79, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x08, 0x69, 0x6e, 0x64, 0x75, 0x73, 0x74, 0x72, 0x79, 0x12, 0x2d, 0x0a, 0x12, 0x72, 0x65, 0x6c,
+	0x61, 0x74, 0x65, 0x64, 0x5f, 0x69, 0x6e, 0x64, 0x75, 0x73, 0x74, 0x72, 0x69, 0x65, 0x73, 0x18,
+	0x0d, 0x20, 0x03, 0x28, 0x09, 0x52, 0x11, 0x72, 0x65, 0x6c, 0x61, 0x74, 0x65, 0x64, 0x49, 0x6e,
+	0x64, 0x75, 0x73, 0x74, 0x72, 0x69, 0x65, 0x73, 0x12, 0x29, 0x0a, 0x10, 0x65, 0x78, 0x70, 0x65,
+	0x72, 0x69, 0x65, 0x6e, 0x63, 0x65, 0x5f, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x18, 0x0e, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0f, 0x65, 0x78, 0x70, 0x65, 0x72, 0x69, 0x65, 0x6e, 0x63, 0x65, 0x4c, 0x65,
+	0x76, 0x65, 0x6c, 0x22, 0xd7, 0x01, 0x0a, 0x0e, 0x53, 0x63, 0x6f, 0x72, 0x69, 0x6e, 0x67, 0x57,
+	0x65, 0x69, 0x67, 0x68, 0x74, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x73, 0x6b, 0x69, 0x6c, 0x6c, 0x5f,
+	0x6d, 0x61, 0x74, 0x63, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0a, 0x73, 0x6b, 0x69,
+	0x6c, 0x6c, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x12, 0x29, 0x0a, 0x10, 0x65, 0x78, 0x70, 0x65, 0x72,
+	0x69, 0x65, 0x6e, 0x63, 0x65, 0x5f, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x01, 0x52, 0x0f, 0x65, 0x78, 0x70, 0x65, 0x72, 0x69, 0x65, 0x6e, 0x63, 0x65, 0x4d, 0x61, 0x74,
+	0x63, 0x68, 0x12, 0x27, 0x0a, 0x0f, 0x65, 0x64, 0x75, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f,
+	0x6d, 0x61, 0x74, 0x63, 0x68, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0e, 0x65, 0x64, 0x75,
+	0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x12, 0x21, 0x0a, 0x0c, 0x6c,
+	0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x66, 0x69, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x01, 0x52, 0x0b, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x46, 0x69, 0x74, 0x12, 0x2d,
+	0x0a, 0x12, 0x69, 0x6e, 0x64, 0x75, 0x73, 0x74, 0x72, 0x79, 0x5f, 0x72, 0x65, 0x6c, 0x65, 0x76,
+	0x61, 0x6e, 0x63, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x01, 0x52, 0x11, 0x69, 0x6e, 0x64, 0x75,
+	0x73, 0x74, 0x72, 0x79, 0x52, 0x65, 0x6c, 0x65, 0x76, 0x61, 0x6e, 0x63, 0x65, 0x22, 0xb8, 0x04,
+	0x0a, 0x0e, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x42, 0x72, 0x65, 0x61, 0x6b, 0x64, 0x6f, 0x77, 0x6e,
+	0x12, 0x23, 0x0a, 0x0d, 0x6f, 0x76, 0x65, 0x72, 0x61, 0x6c, 0x6c, 0x5f, 0x73, 0x63, 0x6f, 0x72,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0c, 0x6f, 0x76, 0x65, 0x72, 0x61, 0x6c, 0x6c,
+	0x53, 0x63, 0x6f, 0x72, 0x65, 0x12, 0x2a, 0x0a, 0x11, 0x73, 0x6b, 0x69, 0x6c, 0x6c, 0x5f, 0x6d,
+	0x61, 0x74, 0x63, 0x68, 0x5f, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01,
+	0x52, 0x0f, 0x73, 0x6b, 0x69, 0x6c, 0x6c, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x53, 0x63, 0x6f, 0x72,
+	0x65, 0x12, 0x34, 0x0a, 0x16, 0x65, 0x78, 0x70, 0x65, 0x72, 0x69, 0x65, 0x6e, 0x63, 0x65, 0x5f,
+	0x6d, 0x61, 0x74, 0x63, 0x68, 0x5f, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x01, 0x52, 0x14, 0x65, 0x78, 0x70, 0x65, 0x72, 0x69, 0x65, 0x6e, 0x63, 0x65, 0x4d, 0x61, 0x74,
+	0x63, 0x68, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x12, 0x32, 0x0a, 0x15, 0x65, 0x64, 0x75, 0x63, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x5f, 0x73, 0x63, 0x6f, 0x72, 0x65,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x01, 0x52, 0x13, 0x65, 0x64, 0x75, 0x63, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x12, 0x2c, 0x0a, 0x12, 0x6c,
+	0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x66, 0x69, 0x74, 0x5f, 0x73, 0x63, 0x6f, 0x72,
+	0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x01, 0x52, 0x10, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x46, 0x69, 0x74, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x12, 0x38, 0x0a, 0x18, 0x69, 0x6e, 0x64,
+	0x75, 0x73, 0x74, 0x72, 0x79, 0x5f, 0x72, 0x65, 0x6c, 0x65, 0x76, 0x61, 0x6e, 0x63, 0x65, 0x5f,
+	0x73, 0x63, 0x6f, 0x72, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x01, 0x52, 0x16, 0x69, 0x6e, 0x64,
+	0x75, 0x73, 0x74, 0x72, 0x79, 0x52, 0x65, 0x6c, 0x65, 0x76, 0x61, 0x6e, 0x63, 0x65, 0x53, 0x63,
+	0x6f, 0x72, 0x65, 0x12, 0x36, 0x0a, 0x17, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x65, 0x64, 0x5f, 0x72,
+	0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x64, 0x5f, 0x73, 0x6b, 0x69, 0x6c, 0x6c, 0x73, 0x18, 0x07,
+	0x20, 0x03, 0x28, 0x09, 0x52, 0x15, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x65, 0x64, 0x52, 0x65, 0x71,
+	0x75, 0x69, 0x72, 0x65, 0x64, 0x53, 0x6b, 0x69, 0x6c, 0x6c, 0x73, 0x12, 0x36, 0x0a, 0x17, 0x6d,
+	0x69, 0x73, 0x73, 0x69, 0x6e, 0x67, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x64, 0x5f,
+	0x73, 0x6b, 0x69, 0x6c, 0x6c, 0x73, 0x18, 0x08, 0x20, 0x03, 0x28, 0x09, 0x52, 0x15, 0x6d, 0x69,
+	0x73, 0x73, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x64, 0x53, 0x6b, 0x69,
+	0x6c, 0x6c, 0x73, 0x12, 0x38, 0x0a, 0x18, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x65, 0x64, 0x5f, 0x70,
+	0x72, 0x65, 0x66, 0x65, 0x72, 0x72, 0x65, 0x64, 0x5f, 0x73, 0x6b, 0x69, 0x6c, 0x6c, 0x73, 0x18,
+	0x09, 0x20, 0x03, 0x28, 0x09, 0x52, 0x16, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x65, 0x64, 0x50, 0x72,
+	0x65, 0x66, 0x65, 0x72, 0x72, 0x65, 0x64, 0x53, 0x6b, 0x69, 0x6c, 0x6c, 0x73, 0x12, 0x3d, 0x0a,
+	0x07, 0x77, 0x65, 0x69, 0x67, 0x68, 0x74, 0x73, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x23,
+	0x2e, 0x6c, 0x65, 0x61, 0x72, 0x6e, 0x62, 0x6f, 0x74, 0x2e, 0x73, 0x63, 0x6f, 0x72, 0x69, 0x6e,
+	0x67, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x63, 0x6f, 0x72, 0x69, 0x6e, 0x67, 0x57, 0x65, 0x69, 0x67,
+	0x68, 0x74, 0x73, 0x52, 0x07, 0x77, 0x65, 0x69, 0x67, 0x68, 0x74, 0x73, 0x12, 0x1a, 0x0a, 0x08,
+	0x77, 0x61, 0x72, 0x6e, 0x69, 0x6e, 0x67, 0x73, 0x18, 0x0b, 0x20, 0x03, 0x28, 0x09, 0x52, 0x08,
+	0x77, 0x61, 0x72, 0x6e, 0x69, 0x6e, 0x67, 0x73, 0x22, 0xf3, 0x01, 0x0a, 0x10, 0x43, 0x61, 0x6c,
+	0x63, 0x75, 0x6c, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x3f, 0x0a,
+	0x07, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x25,
+	0x2e, 0x6c, 0x65, 0x61, 0x72, 0x6e, 0x62, 0x6f, 0x74, 0x2e, 0x73, 0x63, 0x6f, 0x72, 0x69, 0x6e,
+	0x67, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x61, 0x6e, 0x64, 0x69, 0x64, 0x61, 0x74, 0x65, 0x50, 0x72,
+	0x6f, 0x66, 0x69, 0x6c, 0x65, 0x52, 0x07, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x12, 0x36,
+	0x0a, 0x03, 0x6a, 0x6f, 0x62, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x6c, 0x65,
+	0x61, 0x72, 0x6e, 0x62, 0x6f, 0x74, 0x2e, 0x73, 0x63, 0x6f, 0x72, 0x69, 0x6e, 0x67, 0x2e, 0x76,
+	0x31, 0x2e, 0x4a, 0x6f, 0x62, 0x52, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x6d, 0x65, 0x6e, 0x74,
+	0x73, 0x52, 0x03, 0x6a, 0x6f, 0x62, 0x12, 0x27, 0x0a, 0x0f, 0x6a, 0x6f, 0x62, 0x5f, 0x64, 0x65,
+	0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0e, 0x6a, 0x6f, 0x62, 0x44, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12,
+	0x3d, 0x0a, 0x07, 0x77, 0x65, 0x69, 0x67, 0x68, 0x74, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x23, 0x2e, 0x6c, 0x65, 0x61, 0x72, 0x6e, 0x62, 0x6f, 0x74, 0x2e, 0x73, 0x63, 0x6f, 0x72,
+	0x69, 0x6e, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x63, 0x6f, 0x72, 0x69, 0x6e, 0x67, 0x57, 0x65,
+	0x69, 0x67, 0x68, 0x74, 0x73, 0x52, 0x07, 0x77, 0x65, 0x69, 0x67, 0x68, 0x74, 0x73, 0x22, 0x4e,
+	0x0a, 0x11, 0x43, 0x61, 0x6c, 0x63, 0x75, 0x6c, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x39, 0x0a, 0x05, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x23, 0x2e, 0x6c, 0x65, 0x61, 0x72, 0x6e, 0x62, 0x6f, 0x74, 0x2e, 0x73, 0x63,
+	0x6f, 0x72, 0x69, 0x6e, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x42, 0x72,
+	0x65, 0x61, 0x6b, 0x64, 0x6f, 0x77, 0x6e, 0x52, 0x05, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x22, 0x74,
+	0x0a, 0x0e, 0x42, 0x61, 0x74, 0x63, 0x68, 0x43, 0x61, 0x6e, 0x64, 0x69, 0x64, 0x61, 0x74, 0x65,
+	0x12, 0x21, 0x0a, 0x0c, 0x63, 0x61, 0x6e, 0x64, 0x69, 0x64, 0x61, 0x74, 0x65, 0x5f, 0x69, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x63, 0x61, 0x6e, 0x64, 0x69, 0x64, 0x61, 0x74,
+	0x65, 0x49, 0x64, 0x12, 0x3f, 0x0a, 0x07, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x25, 0x2e, 0x6c, 0x65, 0x61, 0x72, 0x6e, 0x62, 0x6f, 0x74, 0x2e,
+	0x73, 0x63, 0x6f, 0x72, 0x69, 0x6e, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x61, 0x6e, 0x64, 0x69,
+	0x64, 0x61, 0x74, 0x65, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x52, 0x07, 0x70, 0x72, 0x6f,
+	0x66, 0x69, 0x6c, 0x65, 0x22, 0xfc, 0x01, 0x0a, 0x15, 0x43, 0x61, 0x6c, 0x63, 0x75, 0x6c, 0x61,
+	0x74, 0x65, 0x42, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x36,
+	0x0a, 0x03, 0x6a, 0x6f, 0x62, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x6c, 0x65,
+	0x61, 0x72, 0x6e, 0x62, 0x6f, 0x74, 0x2e, 0x73, 0x63, 0x6f, 0x72, 0x69, 0x6e, 0x67, 0x2e, 0x76,
+	0x31, 0x2e, 0x4a, 0x6f, 0x62, 0x52, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x6d, 0x65, 0x6e, 0x74,
+	0x73, 0x52, 0x03, 0x6a, 0x6f, 0x62, 0x12, 0x27, 0x0a, 0x0f, 0x6a, 0x6f, 0x62, 0x5f, 0x64, 0x65,
+	0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0e, 0x6a, 0x6f, 0x62, 0x44, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12,
+	0x43, 0x0a, 0x0a, 0x63, 0x61, 0x6e, 0x64, 0x69, 0x64, 0x61, 0x74, 0x65, 0x73, 0x18, 0x03, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x6c, 0x65, 0x61, 0x72, 0x6e, 0x62, 0x6f, 0x74, 0x2e, 0x73,
+	0x63, 0x6f, 0x72, 0x69, 0x6e, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x61, 0x74, 0x63, 0x68, 0x43,
+	0x61, 0x6e, 0x64, 0x69, 0x64, 0x61, 0x74, 0x65, 0x52, 0x0a, 0x63, 0x61, 0x6e, 0x64, 0x69, 0x64,
+	0x61, 0x74, 0x65, 0x73, 0x12, 0x3d, 0x0a, 0x07, 0x77, 0x65, 0x69, 0x67, 0x68, 0x74, 0x73, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x6c, 0x65, 0x61, 0x72, 0x6e, 0x62, 0x6f, 0x74,
+	0x2e, 0x73, 0x63, 0x6f, 0x72, 0x69, 0x6e, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x63, 0x6f, 0x72,
+	0x69, 0x6e, 0x67, 0x57, 0x65, 0x69, 0x67, 0x68, 0x74, 0x73, 0x52, 0x07, 0x77, 0x65, 0x69, 0x67,
+	0x68, 0x74, 0x73, 0x22, 0x95, 0x01, 0x0a, 0x0b, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x52, 0x65, 0x73,
+	0x75, 0x6c, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x21, 0x0a, 0x0c, 0x63, 0x61, 0x6e,
+	0x64, 0x69, 0x64, 0x61, 0x74, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0b, 0x63, 0x61, 0x6e, 0x64, 0x69, 0x64, 0x61, 0x74, 0x65, 0x49, 0x64, 0x12, 0x12, 0x0a, 0x04,
+	0x72, 0x61, 0x6e, 0x6b, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x72, 0x61, 0x6e, 0x6b,
+	0x12, 0x39, 0x0a, 0x05, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x23, 0x2e, 0x6c, 0x65, 0x61, 0x72, 0x6e, 0x62, 0x6f, 0x74, 0x2e, 0x73, 0x63, 0x6f, 0x72, 0x69,
+	0x6e, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x42, 0x72, 0x65, 0x61, 0x6b,
+	0x64, 0x6f, 0x77, 0x6e, 0x52, 0x05, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x22, 0x54, 0x0a, 0x16, 0x43,
+	0x61, 0x6c, 0x63, 0x75, 0x6c, 0x61, 0x74, 0x65, 0x42, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3a, 0x0a, 0x07, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73,
+	0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x6c, 0x65, 0x61, 0x72, 0x6e, 0x62, 0x6f,
+	0x74, 0x2e, 0x73, 0x63, 0x6f, 0x72, 0x69, 0x6e, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x63, 0x6f,
+	0x72, 0x65, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x52, 0x07, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74,
+	0x73, 0x22, 0xb2, 0x01, 0x0a, 0x0e, 0x41, 0x6e, 0x61, 0x6c, 0x79, 0x7a, 0x65, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x3f, 0x0a, 0x07, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x25, 0x2e, 0x6c, 0x65, 0x61, 0x72, 0x6e, 0x62, 0x6f, 0x74,
+	0x2e, 0x73, 0x63, 0x6f, 0x72, 0x69, 0x6e, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x61, 0x6e, 0x64,
+	0x69, 0x64, 0x61, 0x74, 0x65, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x52, 0x07, 0x70, 0x72,
+	0x6f, 0x66, 0x69, 0x6c, 0x65, 0x12, 0x36, 0x0a, 0x03, 0x6a, 0x6f, 0x62, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x24, 0x2e, 0x6c, 0x65, 0x61, 0x72, 0x6e, 0x62, 0x6f, 0x74, 0x2e, 0x73, 0x63,
+	0x6f, 0x72, 0x69, 0x6e, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x4a, 0x6f, 0x62, 0x52, 0x65, 0x71, 0x75,
+	0x69, 0x72, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x03, 0x6a, 0x6f, 0x62, 0x12, 0x27, 0x0a,
+	0x0f, 0x6a, 0x6f, 0x62, 0x5f, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x6a, 0x6f, 0x62, 0x44, 0x65, 0x73, 0x63, 0x72,
+	0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x51, 0x0a, 0x0f, 0x41, 0x6e, 0x61, 0x6c, 0x79, 0x7a,
+	0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3e, 0x0a, 0x06, 0x72, 0x65, 0x73,
+	0x75, 0x6c, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x26, 0x2e, 0x6c, 0x65, 0x61, 0x72,
+	0x6e, 0x62, 0x6f, 0x74, 0x2e, 0x73, 0x63, 0x6f, 0x72, 0x69, 0x6e, 0x67, 0x2e, 0x76, 0x31, 0x2e,
+	0x47, 0x61, 0x70, 0x41, 0x6e, 0x61, 0x6c, 0x79, 0x73, 0x69, 0x73, 0x52, 0x65, 0x73, 0x75, 0x6c,
+	0x74, 0x52, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x22, 0x80, 0x06, 0x0a, 0x11, 0x47, 0x61,
+	0x70, 0x41, 0x6e, 0x61, 0x6c, 0x79, 0x73, 0x69, 0x73, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12,
+	0x42, 0x0a, 0x0d, 0x63, 0x72, 0x69, 0x74, 0x69, 0x63, 0x61, 0x6c, 0x5f, 0x67, 0x61, 0x70, 0x73,
+	0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x6c, 0x65, 0x61, 0x72, 0x6e, 0x62, 0x6f,
+	0x74, 0x2e, 0x73, 0x63, 0x6f, 0x72, 0x69, 0x6e, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x6b, 0x69,
+	0x6c, 0x6c, 0x47, 0x61, 0x70, 0x52, 0x0c, 0x63, 0x72, 0x69, 0x74, 0x69, 0x63, 0x61, 0x6c, 0x47,
+	0x61, 0x70, 0x73, 0x12, 0x44, 0x0a, 0x0e, 0x69, 0x6d, 0x70, 0x6f, 0x72, 0x74, 0x61, 0x6e, 0x74,
+	0x5f, 0x67, 0x61, 0x70, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x6c, 0x65,
+	0x61, 0x72, 0x6e, 0x62, 0x6f, 0x74, 0x2e, 0x73, 0x63, 0x6f, 0x72, 0x69, 0x6e, 0x67, 0x2e, 0x76,
+	0x31, 0x2e, 0x53, 0x6b, 0x69, 0x6c, 0x6c, 0x47, 0x61, 0x70, 0x52, 0x0d, 0x69, 0x6d, 0x70, 0x6f,
+	0x72, 0x74, 0x61, 0x6e, 0x74, 0x47, 0x61, 0x70, 0x73, 0x12, 0x48, 0x0a, 0x11, 0x6e, 0x69, 0x63,
+	0x65, 0x5f, 0x74, 0x6f, 0x5f, 0x68, 0x61, 0x76, 0x65, 0x5f, 0x67, 0x61, 0x70, 0x73, 0x18, 0x03,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x6c, 0x65, 0x61, 0x72, 0x6e, 0x62, 0x6f, 0x74, 0x2e,
+	0x73, 0x63, 0x6f, 0x72, 0x69, 0x6e, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x6b, 0x69, 0x6c, 0x6c,
+	0x47, 0x61, 0x70, 0x52, 0x0e, 0x6e, 0x69, 0x63, 0x65, 0x54, 0x6f, 0x48, 0x61, 0x76, 0x65, 0x47,
+	0x61, 0x70, 0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x5f, 0x67, 0x61, 0x70,
+	0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x47, 0x61,
+	0x70, 0x73, 0x12, 0x2c, 0x0a, 0x12, 0x63, 0x72, 0x69, 0x74, 0x69, 0x63, 0x61, 0x6c, 0x5f, 0x67,
+	0x61, 0x70, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x10,
+	0x63, 0x72, 0x69, 0x74, 0x69, 0x63, 0x61, 0x6c, 0x47, 0x61, 0x70, 0x43, 0x6f, 0x75, 0x6e, 0x74,
+	0x12, 0x2e, 0x0a, 0x13, 0x69, 0x6d, 0x70, 0x6f, 0x72, 0x74, 0x61, 0x6e, 0x74, 0x5f, 0x67, 0x61,
+	0x70, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x05, 0x52, 0x11, 0x69,
+	0x6d, 0x70, 0x6f, 0x72, 0x74, 0x61, 0x6e, 0x74, 0x47, 0x61, 0x70, 0x43, 0x6f, 0x75, 0x6e, 0x74,
+	0x12, 0x32, 0x0a, 0x16, 0x6e, 0x69, 0x63, 0x65, 0x5f, 0x74, 0x6f, 0x5f, 0x68, 0x61, 0x76, 0x65,
+	0x5f, 0x67, 0x61, 0x70, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x12, 0x6e, 0x69, 0x63, 0x65, 0x54, 0x6f, 0x48, 0x61, 0x76, 0x65, 0x47, 0x61, 0x70, 0x43,
+	0x6f, 0x75, 0x6e, 0x74, 0x12, 0x43, 0x0a, 0x1e, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x5f, 0x65, 0x73,
+	0x74, 0x69, 0x6d, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x6c, 0x65, 0x61, 0x72, 0x6e, 0x69, 0x6e, 0x67,
+	0x5f, 0x68, 0x6f, 0x75, 0x72, 0x73, 0x18, 0x08, 0x20, 0x01, 0x28, 0x05, 0x52, 0x1b, 0x74, 0x6f,
+	0x74, 0x61, 0x6c, 0x45, 0x73, 0x74, 0x69, 0x6d, 0x61, 0x74, 0x65, 0x64, 0x4c, 0x65, 0x61, 0x72,
+	0x6e, 0x69, 0x6e, 0x67, 0x48, 0x6f, 0x75, 0x72, 0x73, 0x12, 0x27, 0x0a, 0x0f, 0x72, 0x65, 0x61,
+	0x64, 0x69, 0x6e, 0x65, 0x73, 0x73, 0x5f, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x18, 0x09, 0x20, 0x01,
+	0x28, 0x01, 0x52, 0x0e, 0x72, 0x65, 0x61, 0x64, 0x69, 0x6e, 0x65, 0x73, 0x73, 0x53, 0x63, 0x6f,
+	0x72, 0x65, 0x12, 0x49, 0x0a, 0x11, 0x74, 0x6f, 0x70, 0x5f, 0x70, 0x72, 0x69, 0x6f, 0x72, 0x69,
+	0x74, 0x79, 0x5f, 0x67, 0x61, 0x70, 0x73, 0x18, 0x0a, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1d, 0x2e,
+	0x6c, 0x65, 0x61, 0x72, 0x6e, 0x62, 0x6f, 0x74, 0x2e, 0x73, 0x63, 0x6f, 0x72, 0x69, 0x6e, 0x67,
+	0x2e, 0x76, 0x31, 0x2e, 0x53, 0x6b, 0x69, 0x6c, 0x6c, 0x47, 0x61, 0x70, 0x52, 0x0f, 0x74, 0x6f,
+	0x70, 0x50, 0x72, 0x69, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x47, 0x61, 0x70, 0x73, 0x12, 0x25, 0x0a,
+	0x0e, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x65, 0x64, 0x5f, 0x73, 0x6b, 0x69, 0x6c, 0x6c, 0x73, 0x18,
+	0x0b, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0d, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x65, 0x64, 0x53, 0x6b,
+	0x69, 0x6c, 0x6c, 0x73, 0x12, 0x41, 0x0a, 0x0c, 0x77, 0x65, 0x61, 0x6b, 0x5f, 0x6d, 0x61, 0x74,
+	0x63, 0x68, 0x65, 0x73, 0x18, 0x0c, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x6c, 0x65, 0x61,
+	0x72, 0x6e, 0x62, 0x6f, 0x74, 0x2e, 0x73, 0x63, 0x6f, 0x72, 0x69, 0x6e, 0x67, 0x2e, 0x76, 0x31,
+	0x2e, 0x57, 0x65, 0x61, 0x6b, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x52, 0x0b, 0x77, 0x65, 0x61, 0x6b,
+	0x4d, 0x61, 0x74, 0x63, 0x68, 0x65, 0x73, 0x12, 0x43, 0x0a, 0x0b, 0x76, 0x69, 0x73, 0x75, 0x61,
+	0x6c, 0x5f, 0x64, 0x61, 0x74, 0x61, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x6c,
+	0x65, 0x61, 0x72, 0x6e, 0x62, 0x6f, 0x74, 0x2e, 0x73, 0x63, 0x6f, 0x72, 0x69, 0x6e, 0x67, 0x2e,
+	0x76, 0x31, 0x2e, 0x47, 0x61, 0x70, 0x56, 0x69, 0x73, 0x75, 0x61, 0x6c, 0x44, 0x61, 0x74, 0x61,
+	0x52, 0x0a, 0x76, 0x69, 0x73, 0x75, 0x61, 0x6c, 0x44, 0x61, 0x74, 0x61, 0x22, 0xd2, 0x04, 0x0a,
+	0x08, 0x53, 0x6b, 0x69, 0x6c, 0x6c, 0x47, 0x61, 0x70, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x6b, 0x69,
+	0x6c, 0x6c, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73,
+	0x6b, 0x69, 0x6c, 0x6c, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x63, 0x61, 0x74, 0x65,
+	0x67, 0x6f, 0x72, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x63, 0x61, 0x74, 0x65,
+	0x67, 0x6f, 0x72, 0x79, 0x12, 0x25, 0x0a, 0x0e, 0x70, 0x72, 0x69, 0x6f, 0x72, 0x69, 0x74, 0x79,
+	0x5f, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0d, 0x70, 0x72,
+	0x69, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x12, 0x29, 0x0a, 0x10, 0x69,
+	0x6d, 0x70, 0x6f, 0x72, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x5f, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0f, 0x69, 0x6d, 0x70, 0x6f, 0x72, 0x74, 0x61, 0x6e, 0x63,
+	0x65, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x12, 0x38, 0x0a, 0x18, 0x65, 0x73, 0x74, 0x69, 0x6d, 0x61,
+	0x74, 0x65, 0x64, 0x5f, 0x6c, 0x65, 0x61, 0x72, 0x6e, 0x69, 0x6e, 0x67, 0x5f, 0x68, 0x6f, 0x75,
+	0x72, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x16, 0x65, 0x73, 0x74, 0x69, 0x6d, 0x61,
+	0x74, 0x65, 0x64, 0x4c, 0x65, 0x61, 0x72, 0x6e, 0x69, 0x6e, 0x67, 0x48, 0x6f, 0x75, 0x72, 0x73,
+	0x12, 0x33, 0x0a, 0x15, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x61, 0x62, 0x69, 0x6c,
+	0x69, 0x74, 0x79, 0x5f, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x01, 0x52,
+	0x14, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79,
+	0x53, 0x63, 0x6f, 0x72, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x64, 0x65, 0x6d, 0x61, 0x6e, 0x64, 0x5f,
+	0x73, 0x63, 0x6f, 0x72, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0b, 0x64, 0x65, 0x6d,
+	0x61, 0x6e, 0x64, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x12, 0x23, 0x0a, 0x0d, 0x63, 0x75, 0x72, 0x72,
+	0x65, 0x6e, 0x74, 0x5f, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0c, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x12, 0x21, 0x0a,
+	0x0c, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x5f, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x18, 0x09, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0b, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x4c, 0x65, 0x76, 0x65, 0x6c,
+	0x12, 0x3a, 0x0a, 0x19, 0x73, 0x65, 0x6d, 0x61, 0x6e, 0x74, 0x69, 0x63, 0x5f, 0x73, 0x69, 0x6d,
+	0x69, 0x6c, 0x61, 0x72, 0x69, 0x74, 0x79, 0x5f, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x18, 0x0a, 0x20,
+	0x01, 0x28, 0x01, 0x52, 0x17, 0x73, 0x65, 0x6d, 0x61, 0x6e, 0x74, 0x69, 0x63, 0x53, 0x69, 0x6d,
+	0x69, 0x6c, 0x61, 0x72, 0x69, 0x74, 0x79, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x12, 0x34, 0x0a, 0x16,
+	0x63, 0x6c, 0x6f, 0x73, 0x65, 0x73, 0x74, 0x5f, 0x65, 0x78, 0x69, 0x73, 0x74, 0x69, 0x6e, 0x67,
+	0x5f, 0x73, 0x6b, 0x69, 0x6c, 0x6c, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x09, 0x52, 0x14, 0x63, 0x6c,
+	0x6f, 0x73, 0x65, 0x73, 0x74, 0x45, 0x78, 0x69, 0x73, 0x74, 0x69, 0x6e, 0x67, 0x53, 0x6b, 0x69,
+	0x6c, 0x6c, 0x12, 0x4d, 0x0a, 0x0f, 0x72, 0x65, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x64, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x0c, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x6c, 0x65,
+	0x61, 0x72, 0x6e, 0x62, 0x6f, 0x74, 0x2e, 0x73, 0x63, 0x6f, 0x72, 0x69, 0x6e, 0x67, 0x2e, 0x76,
+	0x31, 0x2e, 0x52, 0x65, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x64, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x52, 0x0f, 0x72, 0x65, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x64, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x73, 0x12, 0x1e, 0x0a, 0x0a, 0x64, 0x69, 0x66, 0x66, 0x69, 0x63, 0x75, 0x6c, 0x74, 0x79, 0x18,
+	0x0d, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x64, 0x69, 0x66, 0x66, 0x69, 0x63, 0x75, 0x6c, 0x74,
+	0x79, 0x22, 0xb2, 0x01, 0x0a, 0x0e, 0x52, 0x65, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x64, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65,
+	0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x23, 0x0a, 0x0d,
+	0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0c, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x54, 0x79, 0x70,
+	0x65, 0x12, 0x27, 0x0a, 0x0f, 0x65, 0x73, 0x74, 0x69, 0x6d, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x68,
+	0x6f, 0x75, 0x72, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0e, 0x65, 0x73, 0x74, 0x69,
+	0x6d, 0x61, 0x74, 0x65, 0x64, 0x48, 0x6f, 0x75, 0x72, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x72,
+	0x69, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x70, 0x72,
+	0x69, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x22, 0xe2, 0x01, 0x0a, 0x09, 0x57, 0x65, 0x61, 0x6b, 0x4d,
+	0x61, 0x74, 0x63, 0x68, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x6b, 0x69, 0x6c, 0x6c, 0x5f, 0x6e, 0x61,
+	0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x6b, 0x69, 0x6c, 0x6c, 0x4e,
+	0x61, 0x6d, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x63, 0x61, 0x74, 0x65, 0x67, 0x6f, 0x72, 0x79, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x63, 0x61, 0x74, 0x65, 0x67, 0x6f, 0x72, 0x79, 0x12,
+	0x23, 0x0a, 0x0d, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x5f, 0x6c, 0x65, 0x76, 0x65, 0x6c,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x4c,
+	0x65, 0x76, 0x65, 0x6c, 0x12, 0x21, 0x0a, 0x0c, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x5f, 0x6c,
+	0x65, 0x76, 0x65, 0x6c, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x74, 0x61, 0x72, 0x67,
+	0x65, 0x74, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x12, 0x21, 0x0a, 0x0c, 0x6c, 0x65, 0x76, 0x65, 0x6c,
+	0x73, 0x5f, 0x62, 0x65, 0x6c, 0x6f, 0x77, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0b, 0x6c,
+	0x65, 0x76, 0x65, 0x6c, 0x73, 0x42, 0x65, 0x6c, 0x6f, 0x77, 0x12, 0x2f, 0x0a, 0x13, 0x72, 0x65,
+	0x61, 0x64, 0x69, 0x6e, 0x65, 0x73, 0x73, 0x5f, 0x64, 0x65, 0x64, 0x75, 0x63, 0x74, 0x69, 0x6f,
+	0x6e, 0x18, 0x06, 0x20, 0x01, 0x28, 0x01, 0x52, 0x12, 0x72, 0x65, 0x61, 0x64, 0x69, 0x6e, 0x65,
+	0x73, 0x73, 0x44, 0x65, 0x64, 0x75, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0xf6, 0x01, 0x0a, 0x0d,
+	0x47, 0x61, 0x70, 0x56, 0x69, 0x73, 0x75, 0x61, 0x6c, 0x44, 0x61, 0x74, 0x61, 0x12, 0x44, 0x0a,
+	0x0b, 0x72, 0x61, 0x64, 0x61, 0x72, 0x5f, 0x63, 0x68, 0x61, 0x72, 0x74, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x23, 0x2e, 0x6c, 0x65, 0x61, 0x72, 0x6e, 0x62, 0x6f, 0x74, 0x2e, 0x73, 0x63,
+	0x6f, 0x72, 0x69, 0x6e, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x61, 0x64, 0x61, 0x72, 0x43, 0x68,
+	0x61, 0x72, 0x74, 0x44, 0x61, 0x74, 0x61, 0x52, 0x0a, 0x72, 0x61, 0x64, 0x61, 0x72, 0x43, 0x68,
+	0x61, 0x72, 0x74, 0x12, 0x4e, 0x0a, 0x10, 0x67, 0x61, 0x70, 0x73, 0x5f, 0x62, 0x79, 0x5f, 0x63,
+	0x61, 0x74, 0x65, 0x67, 0x6f, 0x72, 0x79, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x24, 0x2e,
+	0x6c, 0x65, 0x61, 0x72, 0x6e, 0x62, 0x6f, 0x74, 0x2e, 0x73, 0x63, 0x6f, 0x72, 0x69, 0x6e, 0x67,
+	0x2e, 0x76, 0x31, 0x2e, 0x43, 0x61, 0x74, 0x65, 0x67, 0x6f, 0x72, 0x79, 0x53, 0x75, 0x6d, 0x6d,
+	0x61, 0x72, 0x79, 0x52, 0x0e, 0x67, 0x61, 0x70, 0x73, 0x42, 0x79, 0x43, 0x61, 0x74, 0x65, 0x67,
+	0x6f, 0x72, 0x79, 0x12, 0x4f, 0x0a, 0x11, 0x6c, 0x65, 0x61, 0x72, 0x6e, 0x69, 0x6e, 0x67, 0x5f,
+	0x74, 0x69, 0x6d, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x22,
+	0x2e, 0x6c, 0x65, 0x61, 0x72, 0x6e, 0x62, 0x6f, 0x74, 0x2e, 0x73, 0x63, 0x6f, 0x72, 0x69, 0x6e,
+	0x67, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x45, 0x6e, 0x74,
+	0x72, 0x79, 0x52, 0x10, 0x6c, 0x65, 0x61, 0x72, 0x6e, 0x69, 0x6e, 0x67, 0x54, 0x69, 0x6d, 0x65,
+	0x6c, 0x69, 0x6e, 0x65, 0x22, 0x7c, 0x0a, 0x0e, 0x52, 0x61, 0x64, 0x61, 0x72, 0x43, 0x68, 0x61,
+	0x72, 0x74, 0x44, 0x61, 0x74, 0x61, 0x12, 0x16, 0x0a, 0x06, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x73,
+	0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x06, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x12, 0x29,
+	0x0a, 0x10, 0x63, 0x61, 0x6e, 0x64, 0x69, 0x64, 0x61, 0x74, 0x65, 0x5f, 0x73, 0x63, 0x6f, 0x72,
+	0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x01, 0x52, 0x0f, 0x63, 0x61, 0x6e, 0x64, 0x69, 0x64,
+	0x61, 0x74, 0x65, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x73, 0x12, 0x27, 0x0a, 0x0f, 0x72, 0x65, 0x71,
+	0x75, 0x69, 0x72, 0x65, 0x64, 0x5f, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x73, 0x18, 0x03, 0x20, 0x03,
+	0x28, 0x01, 0x52, 0x0e, 0x72, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x64, 0x53, 0x63, 0x6f, 0x72,
+	0x65, 0x73, 0x22, 0xa7, 0x01, 0x0a, 0x0f, 0x43, 0x61, 0x74, 0x65, 0x67, 0x6f, 0x72, 0x79, 0x53,
+	0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x12, 0x1a, 0x0a, 0x08, 0x63, 0x61, 0x74, 0x65, 0x67, 0x6f,
+	0x72, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x63, 0x61, 0x74, 0x65, 0x67, 0x6f,
+	0x72, 0x79, 0x12, 0x1b, 0x0a, 0x09, 0x67, 0x61, 0x70, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x67, 0x61, 0x70, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12,
+	0x30, 0x0a, 0x14, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x5f, 0x6c, 0x65, 0x61, 0x72, 0x6e, 0x69, 0x6e,
+	0x67, 0x5f, 0x68, 0x6f, 0x75, 0x72, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x12, 0x74,
+	0x6f, 0x74, 0x61, 0x6c, 0x4c, 0x65, 0x61, 0x72, 0x6e, 0x69, 0x6e, 0x67, 0x48, 0x6f, 0x75, 0x72,
+	0x73, 0x12, 0x29, 0x0a, 0x10, 0x61, 0x76, 0x65, 0x72, 0x61, 0x67, 0x65, 0x5f, 0x70, 0x72, 0x69,
+	0x6f, 0x72, 0x69, 0x74, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0f, 0x61, 0x76, 0x65,
+	0x72, 0x61, 0x67, 0x65, 0x50, 0x72, 0x69, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x22, 0xd2, 0x01, 0x0a,
+	0x0d, 0x54, 0x69, 0x6d, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x14,
+	0x0a, 0x05, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x6f,
+	0x72, 0x64, 0x65, 0x72, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x6b, 0x69, 0x6c, 0x6c, 0x5f, 0x6e, 0x61,
+	0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x6b, 0x69, 0x6c, 0x6c, 0x4e,
+	0x61, 0x6d, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x63, 0x61, 0x74, 0x65, 0x67, 0x6f, 0x72, 0x79, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x63, 0x61, 0x74, 0x65, 0x67, 0x6f, 0x72, 0x79, 0x12,
+	0x27, 0x0a, 0x0f, 0x65, 0x73, 0x74, 0x69, 0x6d, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x68, 0x6f, 0x75,
+	0x72, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0e, 0x65, 0x73, 0x74, 0x69, 0x6d, 0x61,
+	0x74, 0x65, 0x64, 0x48, 0x6f, 0x75, 0x72, 0x73, 0x12, 0x29, 0x0a, 0x10, 0x63, 0x75, 0x6d, 0x75,
+	0x6c, 0x61, 0x74, 0x69, 0x76, 0x65, 0x5f, 0x68, 0x6f, 0x75, 0x72, 0x73, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x0f, 0x63, 0x75, 0x6d, 0x75, 0x6c, 0x61, 0x74, 0x69, 0x76, 0x65, 0x48, 0x6f,
+	0x75, 0x72, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x65,
+	0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c,
+	0x65, 0x32, 0xd5, 0x01, 0x0a, 0x0c, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x53, 0x65, 0x72, 0x76, 0x69,
+	0x63, 0x65, 0x12, 0x5a, 0x0a, 0x09, 0x43, 0x61, 0x6c, 0x63, 0x75, 0x6c, 0x61, 0x74, 0x65, 0x12,
+	0x25, 0x2e, 0x6c, 0x65, 0x61, 0x72, 0x6e, 0x62, 0x6f, 0x74, 0x2e, 0x73, 0x63, 0x6f, 0x72, 0x69,
+	0x6e, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x61, 0x6c, 0x63, 0x75, 0x6c, 0x61, 0x74, 0x65, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x26, 0x2e, 0x6c, 0x65, 0x61, 0x72, 0x6e, 0x62, 0x6f,
+	0x74, 0x2e, 0x73, 0x63, 0x6f, 0x72, 0x69, 0x6e, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x61, 0x6c,
+	0x63, 0x75, 0x6c, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x69,
+	0x0a, 0x0e, 0x43, 0x61, 0x6c, 0x63, 0x75, 0x6c, 0x61, 0x74, 0x65, 0x42, 0x61, 0x74, 0x63, 0x68,
+	0x12, 0x2a, 0x2e, 0x6c, 0x65, 0x61, 0x72, 0x6e, 0x62, 0x6f, 0x74, 0x2e, 0x73, 0x63, 0x6f, 0x72,
+	0x69, 0x6e, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x61, 0x6c, 0x63, 0x75, 0x6c, 0x61, 0x74, 0x65,
+	0x42, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2b, 0x2e, 0x6c,
+	0x65, 0x61, 0x72, 0x6e, 0x62, 0x6f, 0x74, 0x2e, 0x73, 0x63, 0x6f, 0x72, 0x69, 0x6e, 0x67, 0x2e,
+	0x76, 0x31, 0x2e, 0x43, 0x61, 0x6c, 0x63, 0x75, 0x6c, 0x61, 0x74, 0x65, 0x42, 0x61, 0x74, 0x63,
+	0x68, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x32, 0x62, 0x0a, 0x0a, 0x47, 0x61, 0x70,
+	0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x54, 0x0a, 0x07, 0x41, 0x6e, 0x61, 0x6c, 0x79,
+	0x7a, 0x65, 0x12, 0x23, 0x2e, 0x6c, 0x65, 0x61, 0x72, 0x6e, 0x62, 0x6f, 0x74, 0x2e, 0x73, 0x63,
+	0x6f, 0x72, 0x69, 0x6e, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x6e, 0x61, 0x6c, 0x79, 0x7a, 0x65,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x24, 0x2e, 0x6c, 0x65, 0x61, 0x72, 0x6e, 0x62,
+	0x6f, 0x74, 0x2e, 0x73, 0x63, 0x6f, 0x72, 0x69, 0x6e, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x6e,
+	0x61, 0x6c, 0x79, 0x7a, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x3b, 0x5a,
+	0x39, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6c, 0x65, 0x61, 0x72,
+	0x6e, 0x62, 0x6f, 0x74, 0x2f, 0x72, 0x65, 0x73, 0x75, 0x6d, 0x65, 0x2d, 0x70, 0x61, 0x72, 0x73,
+	0x65, 0x72, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x73, 0x63, 0x6f, 0x72, 0x69, 0x6e, 0x67, 0x70, 0x62,
+	0x3b, 0x73, 0x63, 0x6f, 0x72, 0x69, 0x6e, 0x67, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x33,
+}
+
+var (
+	file_learnbot_scoring_v1_scoring_proto_rawDescOnce sync.Once
+	file_learnbot_scoring_v1_scoring_proto_rawDescData = file_learnbot_scoring_v1_scoring_proto_rawDesc
+)
+
+func file_learnbot_scoring_v1_scoring_proto_rawDescGZIP() []byte {
+	file_learnbot_scoring_v1_scoring_proto_rawDescOnce.Do(func() {
+		file_learnbot_scoring_v1_scoring_proto_rawDescData = protoimpl.X.CompressGZIP(file_learnbot_scoring_v1_scoring_proto_rawDescData)
+	})
+	return file_learnbot_scoring_v1_scoring_proto_rawDescData
+}
+
+var file_learnbot_scoring_v1_scoring_proto_msgTypes = make([]protoimpl.MessageInfo, 25)
+var file_learnbot_scoring_v1_scoring_proto_goTypes = []any{
+	(*CandidateProfile)(nil),       // 0: learnbot.scoring.v1.CandidateProfile
+	(*CandidateSkill)(nil),         // 1: learnbot.scoring.v1.CandidateSkill
+	(*WorkHistoryEntry)(nil),       // 2: learnbot.scoring.v1.WorkHistoryEntry
+	(*EducationEntry)(nil),         // 3: learnbot.scoring.v1.EducationEntry
+	(*CertificationEntry)(nil),     // 4: learnbot.scoring.v1.CertificationEntry
+	(*ProjectEntry)(nil),           // 5: learnbot.scoring.v1.ProjectEntry
+	(*JobRequirements)(nil),        // 6: learnbot.scoring.v1.JobRequirements
+	(*ScoringWeights)(nil),         // 7: learnbot.scoring.v1.ScoringWeights
+	(*ScoreBreakdown)(nil),         // 8: learnbot.scoring.v1.ScoreBreakdown
+	(*CalculateRequest)(nil),       // 9: learnbot.scoring.v1.CalculateRequest
+	(*CalculateResponse)(nil),      // 10: learnbot.scoring.v1.CalculateResponse
+	(*BatchCandidate)(nil),         // 11: learnbot.scoring.v1.BatchCandidate
+	(*CalculateBatchRequest)(nil),  // 12: learnbot.scoring.v1.CalculateBatchRequest
+	(*ScoreResult)(nil),            // 13: learnbot.scoring.v1.ScoreResult
+	(*CalculateBatchResponse)(nil), // 14: learnbot.scoring.v1.CalculateBatchResponse
+	(*AnalyzeRequest)(nil),         // 15: learnbot.scoring.v1.AnalyzeRequest
+	(*AnalyzeResponse)(nil),        // 16: learnbot.scoring.v1.AnalyzeResponse
+	(*GapAnalysisResult)(nil),      // 17: learnbot.scoring.v1.GapAnalysisResult
+	(*SkillGap)(nil),               // 18: learnbot.scoring.v1.SkillGap
+	(*Recommendation)(nil),         // 19: learnbot.scoring.v1.Recommendation
+	(*WeakMatch)(nil),              // 20: learnbot.scoring.v1.WeakMatch
+	(*GapVisualData)(nil),          // 21: learnbot.scoring.v1.GapVisualData
+	(*RadarChartData)(nil),         // 22: learnbot.scoring.v1.RadarChartData
+	(*CategorySummary)(nil),        // 23: learnbot.scoring.v1.CategorySummary
+	(*TimelineEntry)(nil),          // 24: learnbot.scoring.v1.TimelineEntry
+}
+var file_learnbot_scoring_v1_scoring_proto_depIdxs = []int32{
+	1,  // 0: learnbot.scoring.v1.CandidateProfile.skills:type_name -> learnbot.scoring.v1.CandidateSkill
+	2,  // 1: learnbot.scoring.v1.CandidateProfile.work_history:type_name -> learnbot.scoring.v1.WorkHistoryEntry
+	3,  // 2: learnbot.scoring.v1.CandidateProfile.education:type_name -> learnbot.scoring.v1.EducationEntry
+	4,  // 3: learnbot.scoring.v1.CandidateProfile.certifications:type_name -> learnbot.scoring.v1.CertificationEntry
+	5,  // 4: learnbot.scoring.v1.CandidateProfile.projects:type_name -> learnbot.scoring.v1.ProjectEntry
+	7,  // 5: learnbot.scoring.v1.ScoreBreakdown.weights:type_name -> learnbot.scoring.v1.ScoringWeights
+	0,  // 6: learnbot.scoring.v1.CalculateRequest.profile:type_name -> learnbot.scoring.v1.CandidateProfile
+	6,  // 7: learnbot.scoring.v1.CalculateRequest.job:type_name -> learnbot.scoring.v1.JobRequirements
+	7,  // 8: learnbot.scoring.v1.CalculateRequest.weights:type_name -> learnbot.scoring.v1.ScoringWeights
+	8,  // 9: learnbot.scoring.v1.CalculateResponse.score:type_name -> learnbot.scoring.v1.ScoreBreakdown
+	0,  // 10: learnbot.scoring.v1.BatchCandidate.profile:type_name -> learnbot.scoring.v1.CandidateProfile
+	6,  // 11: learnbot.scoring.v1.CalculateBatchRequest.job:type_name -> learnbot.scoring.v1.JobRequirements
+	11, // 12: learnbot.scoring.v1.CalculateBatchRequest.candidates:type_name -> learnbot.scoring.v1.BatchCandidate
+	7,  // 13: learnbot.scoring.v1.CalculateBatchRequest.weights:type_name -> learnbot.scoring.v1.ScoringWeights
+	8,  // 14: learnbot.scoring.v1.ScoreResult.score:type_name -> learnbot.scoring.v1.ScoreBreakdown
+	13, // 15: learnbot.scoring.v1.CalculateBatchResponse.results:type_name -> learnbot.scoring.v1.ScoreResult
+	0,  // 16: learnbot.scoring.v1.AnalyzeRequest.profile:type_name -> learnbot.scoring.v1.CandidateProfile
+	6,  // 17: learnbot.scoring.v1.AnalyzeRequest.job:type_name -> learnbot.scoring.v1.JobRequirements
+	17, // 18: learnbot.scoring.v1.AnalyzeResponse.result:type_name -> learnbot.scoring.v1.GapAnalysisResult
+	18, // 19: learnbot.scoring.v1.GapAnalysisResult.critical_gaps:type_name -> learnbot.scoring.v1.SkillGap
+	18, // 20: learnbot.scoring.v1.GapAnalysisResult.important_gaps:type_name -> learnbot.scoring.v1.SkillGap
+	18, // 21: learnbot.scoring.v1.GapAnalysisResult.nice_to_have_gaps:type_name -> learnbot.scoring.v1.SkillGap
+	18, // 22: learnbot.scoring.v1.GapAnalysisResult.top_priority_gaps:type_name -> learnbot.scoring.v1.SkillGap
+	20, // 23: learnbot.scoring.v1.GapAnalysisResult.weak_matches:type_name -> learnbot.scoring.v1.WeakMatch
+	21, // 24: learnbot.scoring.v1.GapAnalysisResult.visual_data:type_name -> learnbot.scoring.v1.GapVisualData
+	19, // 25: learnbot.scoring.v1.SkillGap.recommendations:type_name -> learnbot.scoring.v1.Recommendation
+	22, // 26: learnbot.scoring.v1.GapVisualData.radar_chart:type_name -> learnbot.scoring.v1.RadarChartData
+	23, // 27: learnbot.scoring.v1.GapVisualData.gaps_by_category:type_name -> learnbot.scoring.v1.CategorySummary
+	24, // 28: learnbot.scoring.v1.GapVisualData.learning_timeline:type_name -> learnbot.scoring.v1.TimelineEntry
+	9,  // 29: learnbot.scoring.v1.ScoreService.Calculate:input_type -> learnbot.scoring.v1.CalculateRequest
+	12, // 30: learnbot.scoring.v1.ScoreService.CalculateBatch:input_type -> learnbot.scoring.v1.CalculateBatchRequest
+	15, // 31: learnbot.scoring.v1.GapService.Analyze:input_type -> learnbot.scoring.v1.AnalyzeRequest
+	10, // 32: learnbot.scoring.v1.ScoreService.Calculate:output_type -> learnbot.scoring.v1.CalculateResponse
+	14, // 33: learnbot.scoring.v1.ScoreService.CalculateBatch:output_type -> learnbot.scoring.v1.CalculateBatchResponse
+	16, // 34: learnbot.scoring.v1.GapService.Analyze:output_type -> learnbot.scoring.v1.AnalyzeResponse
+	32, // [32:35] is the sub-list for method output_type
+	29, // [29:32] is the sub-list for method input_type
+	29, // [29:29] is the sub-list for extension type_name
+	29, // [29:29] is the sub-list for extension extendee
+	0,  // [0:29] is the sub-list for field type_name
+}
+
+func init() { file_learnbot_scoring_v1_scoring_proto_init() }
+func file_learnbot_scoring_v1_scoring_proto_init() {
+	if File_learnbot_scoring_v1_scoring_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_learnbot_scoring_v1_scoring_proto_msgTypes[0].Exporter = func(v any, i int) any {
+			switch v := v.(*CandidateProfile); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_learnbot_scoring_v1_scoring_proto_msgTypes[1].Exporter = func(v any, i int) any {
+			switch v := v.(*CandidateSkill); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_learnbot_scoring_v1_scoring_proto_msgTypes[2].Exporter = func(v any, i int) any {
+			switch v := v.(*WorkHistoryEntry); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_learnbot_scoring_v1_scoring_proto_msgTypes[3].Exporter = func(v any, i int) any {
+			switch v := v.(*EducationEntry); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_learnbot_scoring_v1_scoring_proto_msgTypes[4].Exporter = func(v any, i int) any {
+			switch v := v.(*CertificationEntry); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_learnbot_scoring_v1_scoring_proto_msgTypes[5].Exporter = func(v any, i int) any {
+			switch v := v.(*ProjectEntry); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_learnbot_scoring_v1_scoring_proto_msgTypes[6].Exporter = func(v any, i int) any {
+			switch v := v.(*JobRequirements); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_learnbot_scoring_v1_scoring_proto_msgTypes[7].Exporter = func(v any, i int) any {
+			switch v := v.(*ScoringWeights); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_learnbot_scoring_v1_scoring_proto_msgTypes[8].Exporter = func(v any, i int) any {
+			switch v := v.(*ScoreBreakdown); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_learnbot_scoring_v1_scoring_proto_msgTypes[9].Exporter = func(v any, i int) any {
+			switch v := v.(*CalculateRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_learnbot_scoring_v1_scoring_proto_msgTypes[10].Exporter = func(v any, i int) any {
+			switch v := v.(*CalculateResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_learnbot_scoring_v1_scoring_proto_msgTypes[11].Exporter = func(v any, i int) any {
+			switch v := v.(*BatchCandidate); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_learnbot_scoring_v1_scoring_proto_msgTypes[12].Exporter = func(v any, i int) any {
+			switch v := v.(*CalculateBatchRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_learnbot_scoring_v1_scoring_proto_msgTypes[13].Exporter = func(v any, i int) any {
+			switch v := v.(*ScoreResult); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_learnbot_scoring_v1_scoring_proto_msgTypes[14].Exporter = func(v any, i int) any {
+			switch v := v.(*CalculateBatchResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_learnbot_scoring_v1_scoring_proto_msgTypes[15].Exporter = func(v any, i int) any {
+			switch v := v.(*AnalyzeRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_learnbot_scoring_v1_scoring_proto_msgTypes[16].Exporter = func(v any, i int) any {
+			switch v := v.(*AnalyzeResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_learnbot_scoring_v1_scoring_proto_msgTypes[17].Exporter = func(v any, i int) any {
+			switch v := v.(*GapAnalysisResult); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_learnbot_scoring_v1_scoring_proto_msgTypes[18].Exporter = func(v any, i int) any {
+			switch v := v.(*SkillGap); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_learnbot_scoring_v1_scoring_proto_msgTypes[19].Exporter = func(v any, i int) any {
+			switch v := v.(*Recommendation); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_learnbot_scoring_v1_scoring_proto_msgTypes[20].Exporter = func(v any, i int) any {
+			switch v := v.(*WeakMatch); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_learnbot_scoring_v1_scoring_proto_msgTypes[21].Exporter = func(v any, i int) any {
+			switch v := v.(*GapVisualData); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_learnbot_scoring_v1_scoring_proto_msgTypes[22].Exporter = func(v any, i int) any {
+			switch v := v.(*RadarChartData); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_learnbot_scoring_v1_scoring_proto_msgTypes[23].Exporter = func(v any, i int) any {
+			switch v := v.(*CategorySummary); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_learnbot_scoring_v1_scoring_proto_msgTypes[24].Exporter = func(v any, i int) any {
+			switch v := v.(*TimelineEntry); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_learnbot_scoring_v1_scoring_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   25,
+			NumExtensions: 0,
+			NumServices:   2,
+		},
+		GoTypes:           file_learnbot_scoring_v1_scoring_proto_goTypes,
+		DependencyIndexes: file_learnbot_scoring_v1_scoring_proto_depIdxs,
+		MessageInfos:      file_learnbot_scoring_v1_scoring_proto_msgTypes,
+	}.Build()
+	File_learnbot_scoring_v1_scoring_proto = out.File
+	file_learnbot_scoring_v1_scoring_proto_rawDesc = nil
+	file_learnbot_scoring_v1_scoring_proto_goTypes = nil
+	file_learnbot_scoring_v1_scoring_proto_depIdxs = nil
+}
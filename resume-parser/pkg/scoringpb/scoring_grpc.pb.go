@@ -0,0 +1,286 @@
+// Scoring and gap analysis engine for internal callers.
+//
+// The messages mirror the JSON bodies of POST /api/v1/score,
+// /api/v1/score/batch and /api/v1/gap-analysis; field names and semantics
+// are those of the HTTP API. Score explanations (?verbose=true) are only
+// available over HTTP.
+//
+// Regenerate pkg/scoringpb after editing: go generate ./pkg/scoringpb
+
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: learnbot/scoring/v1/scoring.proto
+
+package scoringpb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	ScoreService_Calculate_FullMethodName      = "/learnbot.scoring.v1.ScoreService/Calculate"
+	ScoreService_CalculateBatch_FullMethodName = "/learnbot.scoring.v1.ScoreService/CalculateBatch"
+)
+
+// ScoreServiceClient is the client API for ScoreService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// ScoreService computes acceptance likelihood scores.
+type ScoreServiceClient interface {
+	// Calculate scores one candidate profile against a job.
+	Calculate(ctx context.Context, in *CalculateRequest, opts ...grpc.CallOption) (*CalculateResponse, error)
+	// CalculateBatch scores many candidate profiles against the same job.
+	CalculateBatch(ctx context.Context, in *CalculateBatchRequest, opts ...grpc.CallOption) (*CalculateBatchResponse, error)
+}
+
+type scoreServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewScoreServiceClient(cc grpc.ClientConnInterface) ScoreServiceClient {
+	return &scoreServiceClient{cc}
+}
+
+func (c *scoreServiceClient) Calculate(ctx context.Context, in *CalculateRequest, opts ...grpc.CallOption) (*CalculateResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CalculateResponse)
+	err := c.cc.Invoke(ctx, ScoreService_Calculate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scoreServiceClient) CalculateBatch(ctx context.Context, in *CalculateBatchRequest, opts ...grpc.CallOption) (*CalculateBatchResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CalculateBatchResponse)
+	err := c.cc.Invoke(ctx, ScoreService_CalculateBatch_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ScoreServiceServer is the server API for ScoreService service.
+// All implementations must embed UnimplementedScoreServiceServer
+// for forward compatibility.
+//
+// ScoreService computes acceptance likelihood scores.
+type ScoreServiceServer interface {
+	// Calculate scores one candidate profile against a job.
+	Calculate(context.Context, *CalculateRequest) (*CalculateResponse, error)
+	// CalculateBatch scores many candidate profiles against the same job.
+	CalculateBatch(context.Context, *CalculateBatchRequest) (*CalculateBatchResponse, error)
+	mustEmbedUnimplementedScoreServiceServer()
+}
+
+// UnimplementedScoreServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedScoreServiceServer struct{}
+
+func (UnimplementedScoreServiceServer) Calculate(context.Context, *CalculateRequest) (*CalculateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Calculate not implemented")
+}
+func (UnimplementedScoreServiceServer) CalculateBatch(context.Context, *CalculateBatchRequest) (*CalculateBatchResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CalculateBatch not implemented")
+}
+func (UnimplementedScoreServiceServer) mustEmbedUnimplementedScoreServiceServer() {}
+func (UnimplementedScoreServiceServer) testEmbeddedByValue()                      {}
+
+// UnsafeScoreServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ScoreServiceServer will
+// result in compilation errors.
+type UnsafeScoreServiceServer interface {
+	mustEmbedUnimplementedScoreServiceServer()
+}
+
+func RegisterScoreServiceServer(s grpc.ServiceRegistrar, srv ScoreServiceServer) {
+	// If the following call pancis, it indicates UnimplementedScoreServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&ScoreService_ServiceDesc, srv)
+}
+
+func _ScoreService_Calculate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CalculateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScoreServiceServer).Calculate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ScoreService_Calculate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScoreServiceServer).Calculate(ctx, req.(*CalculateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ScoreService_CalculateBatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CalculateBatchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScoreServiceServer).CalculateBatch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ScoreService_CalculateBatch_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScoreServiceServer).CalculateBatch(ctx, req.(*CalculateBatchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// ScoreService_ServiceDesc is the grpc.ServiceDesc for ScoreService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ScoreService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "learnbot.scoring.v1.ScoreService",
+	HandlerType: (*ScoreServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Calculate",
+			Handler:    _ScoreService_Calculate_Handler,
+		},
+		{
+			MethodName: "CalculateBatch",
+			Handler:    _ScoreService_CalculateBatch_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "learnbot/scoring/v1/scoring.proto",
+}
+
+const (
+	GapService_Analyze_FullMethodName = "/learnbot.scoring.v1.GapService/Analyze"
+)
+
+// GapServiceClient is the client API for GapService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// GapService runs the skill gap analysis engine.
+type GapServiceClient interface {
+	// Analyze compares a candidate profile against a job and returns the
+	// prioritized skill gaps.
+	Analyze(ctx context.Context, in *AnalyzeRequest, opts ...grpc.CallOption) (*AnalyzeResponse, error)
+}
+
+type gapServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewGapServiceClient(cc grpc.ClientConnInterface) GapServiceClient {
+	return &gapServiceClient{cc}
+}
+
+func (c *gapServiceClient) Analyze(ctx context.Context, in *AnalyzeRequest, opts ...grpc.CallOption) (*AnalyzeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AnalyzeResponse)
+	err := c.cc.Invoke(ctx, GapService_Analyze_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GapServiceServer is the server API for GapService service.
+// All implementations must embed UnimplementedGapServiceServer
+// for forward compatibility.
+//
+// GapService runs the skill gap analysis engine.
+type GapServiceServer interface {
+	// Analyze compares a candidate profile against a job and returns the
+	// prioritized skill gaps.
+	Analyze(context.Context, *AnalyzeRequest) (*AnalyzeResponse, error)
+	mustEmbedUnimplementedGapServiceServer()
+}
+
+// UnimplementedGapServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedGapServiceServer struct{}
+
+func (UnimplementedGapServiceServer) Analyze(context.Context, *AnalyzeRequest) (*AnalyzeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Analyze not implemented")
+}
+func (UnimplementedGapServiceServer) mustEmbedUnimplementedGapServiceServer() {}
+func (UnimplementedGapServiceServer) testEmbeddedByValue()                    {}
+
+// UnsafeGapServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to GapServiceServer will
+// result in compilation errors.
+type UnsafeGapServiceServer interface {
+	mustEmbedUnimplementedGapServiceServer()
+}
+
+func RegisterGapServiceServer(s grpc.ServiceRegistrar, srv GapServiceServer) {
+	// If the following call pancis, it indicates UnimplementedGapServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&GapService_ServiceDesc, srv)
+}
+
+func _GapService_Analyze_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AnalyzeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GapServiceServer).Analyze(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GapService_Analyze_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GapServiceServer).Analyze(ctx, req.(*AnalyzeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// GapService_ServiceDesc is the grpc.ServiceDesc for GapService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var GapService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "learnbot.scoring.v1.GapService",
+	HandlerType: (*GapServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Analyze",
+			Handler:    _GapService_Analyze_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "learnbot/scoring/v1/scoring.proto",
+}
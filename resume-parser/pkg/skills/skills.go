@@ -0,0 +1,32 @@
+// Package skills provides a public API for mapping skill names to the skill
+// taxonomy. This package wraps the internal taxonomy package for use by
+// external modules.
+package skills
+
+import "github.com/learnbot/resume-parser/internal/taxonomy"
+
+// NormalizeResult is the output of normalizing a raw skill string.
+type NormalizeResult = taxonomy.NormalizeResult
+
+// Normalizer maps raw skill names, including aliases such as "golang", to
+// canonical taxonomy skills.
+type Normalizer struct {
+	inner *taxonomy.Taxonomy
+}
+
+// New creates a Normalizer over the built-in taxonomy.
+func New() *Normalizer {
+	return &Normalizer{inner: taxonomy.New()}
+}
+
+// Normalize maps raw to its taxonomy skill by name or alias, falling back
+// to fuzzy matching for misspellings.
+func (n *Normalizer) Normalize(raw string) NormalizeResult {
+	return n.inner.Normalize(raw)
+}
+
+// NormalizeExact is Normalize without the fuzzy fallback, for names that
+// should only match the taxonomy by name or alias.
+func (n *Normalizer) NormalizeExact(raw string) NormalizeResult {
+	return n.inner.NormalizeExact(raw)
+}
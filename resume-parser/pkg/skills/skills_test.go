@@ -0,0 +1,26 @@
+package skills_test
+
+import (
+	"testing"
+
+	"github.com/learnbot/resume-parser/pkg/skills"
+)
+
+func TestNormalizeExact_Alias(t *testing.T) {
+	n := skills.New()
+	for _, raw := range []string{"Go", "golang", "GoLang"} {
+		if got := n.NormalizeExact(raw); got.CanonicalID != "go" || got.CanonicalName != "Go" {
+			t.Errorf("NormalizeExact(%q) = %+v, want go", raw, got)
+		}
+	}
+}
+
+func TestNormalizeExact_NoFuzzyMatch(t *testing.T) {
+	n := skills.New()
+	if got := n.NormalizeExact("Kubernates"); got.CanonicalID != "" || got.MatchType != "none" {
+		t.Errorf("NormalizeExact(Kubernates) = %+v, want no match", got)
+	}
+	if got := n.Normalize("Kubernates"); got.CanonicalID != "kubernetes" {
+		t.Errorf("Normalize(Kubernates) = %+v, want kubernetes", got)
+	}
+}
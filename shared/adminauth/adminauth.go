@@ -0,0 +1,203 @@
+// Package adminauth authenticates requests to the admin routes of the
+// internal services. Callers present either the static admin API key or a
+// JWT issued by the API gateway whose is_admin claim is set or whose roles
+// claim holds one of the configured roles.
+package adminauth
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// APIKeyHeader is the request header that carries the static admin API key.
+const APIKeyHeader = "X-Admin-API-Key"
+
+// tokenIssuer is the issuer of API gateway tokens.
+const tokenIssuer = "learnbot"
+
+// Config holds admin authentication settings. At least one of APIKey and
+// JWTSecret must be set, otherwise every admin request is rejected.
+type Config struct {
+	// APIKey is the static key accepted in the X-Admin-API-Key header.
+	APIKey string
+	// JWTSecret is the API gateway's HMAC signing secret. When set, Bearer
+	// tokens carrying the admin claim are accepted as well.
+	JWTSecret []byte
+	// Roles lists the gateway roles, besides admin, whose tokens are
+	// accepted, e.g. "curator".
+	Roles []string
+}
+
+// ConfigFromEnv reads ADMIN_API_KEY and JWT_SECRET.
+func ConfigFromEnv() Config {
+	cfg := Config{APIKey: os.Getenv("ADMIN_API_KEY")}
+	if secret := os.Getenv("JWT_SECRET"); secret != "" {
+		cfg.JWTSecret = []byte(secret)
+	}
+	return cfg
+}
+
+// Enabled reports whether any credential can be accepted.
+func (c Config) Enabled() bool {
+	return c.APIKey != "" || len(c.JWTSecret) > 0
+}
+
+// Principal identifies an authenticated admin caller.
+type Principal struct {
+	// Method is "api_key" or "jwt".
+	Method string
+	// UserID and Email are set for JWT callers.
+	UserID string
+	Email  string
+}
+
+func (p Principal) String() string {
+	if p.Method == "jwt" {
+		return fmt.Sprintf("user %s (%s)", p.UserID, p.Email)
+	}
+	return "api key"
+}
+
+type contextKey struct{}
+
+// PrincipalFrom returns the admin caller stored in ctx by the middleware.
+func PrincipalFrom(ctx context.Context) (Principal, bool) {
+	p, ok := ctx.Value(contextKey{}).(Principal)
+	return p, ok
+}
+
+// gatewayClaims mirrors the claims in tokens issued by the API gateway.
+type gatewayClaims struct {
+	UserID  string   `json:"user_id"`
+	Email   string   `json:"email"`
+	Roles   []string `json:"roles"`
+	IsAdmin bool     `json:"is_admin"`
+	jwt.RegisteredClaims
+}
+
+// Middleware rejects requests without valid admin credentials.
+type Middleware struct {
+	config Config
+	logger *log.Logger
+}
+
+// New creates admin authentication middleware.
+func New(cfg Config, logger *log.Logger) *Middleware {
+	return &Middleware{config: cfg, logger: logger}
+}
+
+// Wrap authenticates each request before calling next. Missing or invalid
+// credentials get 401, a valid token without the admin claim gets 403, and
+// every authenticated request is logged with its principal.
+func (m *Middleware) Wrap(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !m.config.Enabled() {
+			writeError(w, http.StatusServiceUnavailable, "ADMIN_AUTH_DISABLED", "admin authentication is not configured")
+			return
+		}
+
+		p, status, message := m.authenticate(r)
+		if status != http.StatusOK {
+			code := "UNAUTHORIZED"
+			if status == http.StatusForbidden {
+				code = "FORBIDDEN"
+			}
+			m.logger.Printf("[admin-auth] rejected %s %s from %s: %s", r.Method, r.URL.Path, r.RemoteAddr, message)
+			writeError(w, status, code, message)
+			return
+		}
+
+		m.logger.Printf("[admin-auth] %s %s by %s", r.Method, r.URL.Path, p)
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), contextKey{}, p)))
+	})
+}
+
+// WrapFunc is Wrap for handler functions.
+func (m *Middleware) WrapFunc(next http.HandlerFunc) http.HandlerFunc {
+	return m.Wrap(next).ServeHTTP
+}
+
+// authenticate checks the API key header first, then a Bearer token.
+func (m *Middleware) authenticate(r *http.Request) (Principal, int, string) {
+	if key := r.Header.Get(APIKeyHeader); key != "" {
+		if m.config.APIKey == "" || subtle.ConstantTimeCompare([]byte(key), []byte(m.config.APIKey)) != 1 {
+			return Principal{}, http.StatusUnauthorized, "invalid admin API key"
+		}
+		return Principal{Method: "api_key"}, http.StatusOK, ""
+	}
+
+	token := bearerToken(r)
+	if token == "" || len(m.config.JWTSecret) == 0 {
+		return Principal{}, http.StatusUnauthorized, "missing admin credentials"
+	}
+	claims, err := m.parseToken(token)
+	if err != nil {
+		return Principal{}, http.StatusUnauthorized, "invalid or expired token"
+	}
+	if !claims.IsAdmin && !m.hasRole(claims.Roles) {
+		return Principal{}, http.StatusForbidden, "admin access required"
+	}
+	return Principal{Method: "jwt", UserID: claims.UserID, Email: claims.Email}, http.StatusOK, ""
+}
+
+// hasRole reports whether roles holds one of the configured roles.
+func (m *Middleware) hasRole(roles []string) bool {
+	for _, r := range roles {
+		for _, accepted := range m.config.Roles {
+			if r == accepted {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (m *Middleware) parseToken(tokenStr string) (*gatewayClaims, error) {
+	token, err := jwt.ParseWithClaims(tokenStr, &gatewayClaims{}, func(t *jwt.Token) (interface{}, error) {
+		if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, jwt.ErrSignatureInvalid
+		}
+		return m.config.JWTSecret, nil
+	}, jwt.WithIssuer(tokenIssuer), jwt.WithExpirationRequired())
+	if err != nil {
+		return nil, err
+	}
+	claims, ok := token.Claims.(*gatewayClaims)
+	if !ok || !token.Valid {
+		return nil, jwt.ErrTokenInvalidClaims
+	}
+	return claims, nil
+}
+
+// bearerToken extracts the token from an "Authorization: Bearer" header.
+func bearerToken(r *http.Request) string {
+	scheme, token, ok := strings.Cut(r.Header.Get("Authorization"), " ")
+	if !ok || !strings.EqualFold(scheme, "bearer") {
+		return ""
+	}
+	return strings.TrimSpace(token)
+}
+
+// writeError writes the standard JSON error envelope.
+func writeError(w http.ResponseWriter, status int, code, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	if status == http.StatusUnauthorized {
+		w.Header().Set("WWW-Authenticate", `Bearer realm="learnbot-admin"`)
+	}
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": false,
+		"error": map[string]string{
+			"code":    code,
+			"message": message,
+		},
+	})
+}
@@ -0,0 +1,108 @@
+package adminauth
+
+import (
+	"encoding/json"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const testSecret = "test-jwt-secret"
+
+func signToken(t *testing.T, secret string, claims gatewayClaims) string {
+	t.Helper()
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(secret))
+	if err != nil {
+		t.Fatalf("sign token: %v", err)
+	}
+	return token
+}
+
+func claimsFor(isAdmin bool) gatewayClaims {
+	return gatewayClaims{
+		UserID:  "user-1",
+		Email:   "admin@learnbot.io",
+		IsAdmin: isAdmin,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    tokenIssuer,
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+		},
+	}
+}
+
+func TestWrap(t *testing.T) {
+	expired := claimsFor(true)
+	expired.ExpiresAt = jwt.NewNumericDate(time.Now().Add(-time.Minute))
+	curator := claimsFor(false)
+	curator.Roles = []string{"user", "curator"}
+	curatorCfg := Config{JWTSecret: []byte(testSecret), Roles: []string{"curator"}}
+
+	tests := []struct {
+		name     string
+		cfg      Config
+		apiKey   string
+		bearer   string
+		want     int
+		wantCode string
+		wantWho  string
+	}{
+		{"not configured", Config{}, "secret", "", http.StatusServiceUnavailable, "ADMIN_AUTH_DISABLED", ""},
+		{"missing key", Config{APIKey: "secret"}, "", "", http.StatusUnauthorized, "UNAUTHORIZED", ""},
+		{"wrong key", Config{APIKey: "secret"}, "guess", "", http.StatusUnauthorized, "UNAUTHORIZED", ""},
+		{"valid key", Config{APIKey: "secret"}, "secret", "", http.StatusOK, "", "api key"},
+		{"jwt not enabled", Config{APIKey: "secret"}, "", signToken(t, testSecret, claimsFor(true)), http.StatusUnauthorized, "UNAUTHORIZED", ""},
+		{"admin jwt", Config{JWTSecret: []byte(testSecret)}, "", signToken(t, testSecret, claimsFor(true)), http.StatusOK, "", "user user-1 (admin@learnbot.io)"},
+		{"non-admin jwt", Config{JWTSecret: []byte(testSecret)}, "", signToken(t, testSecret, claimsFor(false)), http.StatusForbidden, "FORBIDDEN", ""},
+		{"wrong signature", Config{JWTSecret: []byte(testSecret)}, "", signToken(t, "other", claimsFor(true)), http.StatusUnauthorized, "UNAUTHORIZED", ""},
+		{"expired jwt", Config{JWTSecret: []byte(testSecret)}, "", signToken(t, testSecret, expired), http.StatusUnauthorized, "UNAUTHORIZED", ""},
+		{"curator jwt, role accepted", curatorCfg, "", signToken(t, testSecret, curator), http.StatusOK, "", "user user-1 (admin@learnbot.io)"},
+		{"curator jwt, role not accepted", Config{JWTSecret: []byte(testSecret)}, "", signToken(t, testSecret, curator), http.StatusForbidden, "FORBIDDEN", ""},
+		{"user jwt, curator accepted", curatorCfg, "", signToken(t, testSecret, claimsFor(false)), http.StatusForbidden, "FORBIDDEN", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var who string
+			next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				p, _ := PrincipalFrom(r.Context())
+				who = p.String()
+			})
+			h := New(tt.cfg, log.New(io.Discard, "", 0)).Wrap(next)
+
+			req := httptest.NewRequest(http.MethodDelete, "/admin/thing", nil)
+			if tt.apiKey != "" {
+				req.Header.Set(APIKeyHeader, tt.apiKey)
+			}
+			if tt.bearer != "" {
+				req.Header.Set("Authorization", "Bearer "+tt.bearer)
+			}
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, req)
+
+			if w.Code != tt.want {
+				t.Fatalf("expected %d, got %d: %s", tt.want, w.Code, w.Body.String())
+			}
+			if tt.want == http.StatusOK {
+				if who != tt.wantWho {
+					t.Errorf("expected principal %q, got %q", tt.wantWho, who)
+				}
+				return
+			}
+
+			var resp struct {
+				Success bool              `json:"success"`
+				Error   map[string]string `json:"error"`
+			}
+			if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+				t.Fatalf("decode: %v", err)
+			}
+			if resp.Success || resp.Error["code"] != tt.wantCode || resp.Error["message"] == "" {
+				t.Errorf("unexpected error envelope: %+v", resp)
+			}
+		})
+	}
+}
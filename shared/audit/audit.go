@@ -0,0 +1,256 @@
+// Package audit records who changed what through the admin APIs of the
+// internal services.
+//
+// Recorder wraps the admin routes. For each successful POST, PUT, PATCH or
+// DELETE it stores an Entry per change the handler reported with Record,
+// naming the admin caller authenticated by adminauth and the request ID set
+// by logging. A mutating request that reports nothing is still stored,
+// with its method and path as the action, so an unannotated endpoint is
+// never silently missing from the log. Read-only requests store nothing.
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/learnbot/shared/adminauth"
+	"github.com/learnbot/shared/logging"
+)
+
+// Entry is one audited change.
+type Entry struct {
+	ID      string `json:"id"`
+	Service string `json:"service"`
+	// Actor is the admin user ID, "api_key" for the static admin key, or
+	// "anonymous" when admin authentication is disabled.
+	Actor      string `json:"actor"`
+	ActorEmail string `json:"actor_email,omitempty"`
+	// Action names the change, e.g. "resource.update".
+	Action     string `json:"action"`
+	EntityType string `json:"entity_type,omitempty"`
+	EntityID   string `json:"entity_id,omitempty"`
+	// Changes is the Diff of the entity, or null if nothing was compared.
+	Changes   json.RawMessage `json:"changes,omitempty"`
+	RequestID string          `json:"request_id,omitempty"`
+	CreatedAt time.Time       `json:"created_at"`
+}
+
+// Change describes a mutation made by an admin handler.
+type Change struct {
+	Action     string
+	EntityType string
+	EntityID   string
+	// Before and After are the entity before and after the change; nil on
+	// creation and deletion respectively. They are compared with Diff.
+	Before, After any
+}
+
+// pending collects the changes reported while handling one request.
+type pending struct {
+	mu      sync.Mutex
+	entries []Entry
+	errs    []error
+	skip    bool
+}
+
+type pendingKey struct{}
+
+// Record reports a change made while handling the request of ctx. The diff
+// is taken immediately, so the values may be modified afterwards. Outside a
+// Recorder it does nothing.
+func Record(ctx context.Context, c Change) {
+	p, ok := ctx.Value(pendingKey{}).(*pending)
+	if !ok {
+		return
+	}
+	changes, err := Diff(c.Before, c.After)
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if err != nil {
+		p.errs = append(p.errs, fmt.Errorf("diff %s: %w", c.Action, err))
+	}
+	p.entries = append(p.entries, Entry{
+		Action:     c.Action,
+		EntityType: c.EntityType,
+		EntityID:   c.EntityID,
+		Changes:    changes,
+	})
+}
+
+// Skip marks the request of ctx as changing nothing, e.g. a dry run, so
+// that no entry is stored for it. Outside a Recorder it does nothing.
+func Skip(ctx context.Context) {
+	if p, ok := ctx.Value(pendingKey{}).(*pending); ok {
+		p.mu.Lock()
+		p.skip = true
+		p.mu.Unlock()
+	}
+}
+
+// Recorder stores the changes made by admin requests.
+//
+// Store errors are logged: the response has been sent by then, and an
+// unavailable store must not take the admin endpoints down with it.
+type Recorder struct {
+	service string
+	store   Store
+	logger  *slog.Logger
+	now     func() time.Time
+}
+
+// NewRecorder creates a Recorder storing the entries of service in store. A
+// nil logger uses slog.Default.
+func NewRecorder(service string, store Store, logger *slog.Logger) *Recorder {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &Recorder{service: service, store: store, logger: logger, now: time.Now}
+}
+
+// Store returns the store the Recorder writes to.
+func (rec *Recorder) Store() Store {
+	return rec.store
+}
+
+// Wrap records the changes of each mutating request handled by next that
+// gets a response below 400. It must run inside the admin authentication,
+// which names the actor, and inside idempotency replays, which change
+// nothing.
+func (rec *Recorder) Wrap(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !mutating(r.Method) {
+			next.ServeHTTP(w, r)
+			return
+		}
+		p := &pending{}
+		sw := &statusWriter{ResponseWriter: w}
+		next.ServeHTTP(sw, r.WithContext(context.WithValue(r.Context(), pendingKey{}, p)))
+		if sw.status == 0 {
+			sw.status = http.StatusOK
+		}
+		if sw.status >= 400 {
+			return
+		}
+		rec.save(r, p)
+	})
+}
+
+// WrapFunc is Wrap for handler functions.
+func (rec *Recorder) WrapFunc(next http.HandlerFunc) http.HandlerFunc {
+	return rec.Wrap(next).ServeHTTP
+}
+
+// save stores the changes of the request r.
+func (rec *Recorder) save(r *http.Request, p *pending) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	ctx := r.Context()
+	for _, err := range p.errs {
+		rec.logger.ErrorContext(ctx, "audit diff failed", "error", err)
+	}
+	if p.skip {
+		return
+	}
+	entries := p.entries
+	if len(entries) == 0 {
+		entries = []Entry{{Action: r.Method + " " + r.URL.Path}}
+	}
+
+	actor, email := "anonymous", ""
+	if principal, ok := adminauth.PrincipalFrom(ctx); ok {
+		actor = principal.Method
+		if principal.Method == "jwt" {
+			actor, email = principal.UserID, principal.Email
+		}
+	}
+	now := rec.now().UTC()
+	for _, e := range entries {
+		e.Service = rec.service
+		e.Actor, e.ActorEmail = actor, email
+		e.RequestID = logging.RequestID(ctx)
+		e.CreatedAt = now
+		if err := rec.store.AppendAuditEntry(ctx, e); err != nil {
+			rec.logger.ErrorContext(ctx, "audit entry not stored", "action", e.Action, "error", err)
+		}
+	}
+}
+
+// mutating reports whether requests with method change state.
+func mutating(method string) bool {
+	switch method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// statusWriter remembers the status of the response it passes through.
+type statusWriter struct {
+	http.ResponseWriter
+	status int
+}
+
+func (w *statusWriter) WriteHeader(status int) {
+	if w.status == 0 {
+		w.status = status
+	}
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *statusWriter) Write(p []byte) (int, error) {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	return w.ResponseWriter.Write(p)
+}
+
+// Unwrap lets http.ResponseController reach the underlying writer.
+func (w *statusWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// ParseFilter reads the actor, entity_type, entity_id, from and to query
+// parameters of an audit log listing. from and to accept RFC 3339
+// timestamps or YYYY-MM-DD dates; a date-only to includes the whole day.
+// Paging is left to the caller.
+func ParseFilter(q url.Values) (Filter, error) {
+	filter := Filter{
+		Actor:      strings.TrimSpace(q.Get("actor")),
+		EntityType: strings.TrimSpace(q.Get("entity_type")),
+		EntityID:   strings.TrimSpace(q.Get("entity_id")),
+	}
+	for _, p := range []struct {
+		name string
+		dest **time.Time
+		end  bool
+	}{
+		{"from", &filter.From, false},
+		{"to", &filter.To, true},
+	} {
+		v := q.Get(p.name)
+		if v == "" {
+			continue
+		}
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			if t, err = time.Parse("2006-01-02", v); err != nil {
+				return filter, fmt.Errorf("invalid %s date %q", p.name, v)
+			}
+			if p.end {
+				t = t.Add(24 * time.Hour)
+			}
+		}
+		*p.dest = &t
+	}
+	if filter.From != nil && filter.To != nil && !filter.From.Before(*filter.To) {
+		return filter, fmt.Errorf("from must be before to")
+	}
+	return filter, nil
+}
@@ -0,0 +1,170 @@
+package audit
+
+import (
+	"context"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/learnbot/shared/adminauth"
+	"github.com/learnbot/shared/logging"
+)
+
+// auditedServer serves handler behind admin API key authentication and a
+// Recorder writing to the returned store.
+func auditedServer(handler http.HandlerFunc) (http.Handler, *MemoryStore) {
+	store := NewMemoryStore()
+	rec := NewRecorder("test-service", store, nil)
+	auth := adminauth.New(adminauth.Config{APIKey: "key"}, log.New(io.Discard, "", 0))
+	return logging.RequestIDMiddleware(auth.WrapFunc(rec.WrapFunc(handler))), store
+}
+
+func serve(h http.Handler, method, path string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, nil)
+	req.Header.Set(adminauth.APIKeyHeader, "key")
+	req.Header.Set("X-Request-ID", "req-1")
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	return rec
+}
+
+func entries(t *testing.T, store Store) []Entry {
+	t.Helper()
+	list, _, err := store.ListAuditEntries(context.Background(), Filter{Limit: 100})
+	if err != nil {
+		t.Fatalf("ListAuditEntries: %v", err)
+	}
+	return list
+}
+
+func TestRecorder_RecordsMutations(t *testing.T) {
+	h, store := auditedServer(func(w http.ResponseWriter, r *http.Request) {
+		Record(r.Context(), Change{
+			Action: "resource.update", EntityType: "resource", EntityID: "r1",
+			Before: map[string]any{"title": "Go"}, After: map[string]any{"title": "Go 101"},
+		})
+	})
+	if rec := serve(h, http.MethodPut, "/admin/resources/r1"); rec.Code != http.StatusOK {
+		t.Fatalf("status = %d", rec.Code)
+	}
+
+	list := entries(t, store)
+	if len(list) != 1 {
+		t.Fatalf("got %d entries, want 1", len(list))
+	}
+	e := list[0]
+	if e.ID == "" || e.Service != "test-service" || e.Actor != "api_key" || e.RequestID != "req-1" ||
+		e.Action != "resource.update" || e.EntityType != "resource" || e.EntityID != "r1" || e.CreatedAt.IsZero() {
+		t.Errorf("entry = %+v", e)
+	}
+	if string(e.Changes) != `{"title":{"old":"Go","new":"Go 101"}}` {
+		t.Errorf("changes = %s", e.Changes)
+	}
+}
+
+func TestRecorder_ReadOnlyRequestsRecordNothing(t *testing.T) {
+	h, store := auditedServer(func(w http.ResponseWriter, r *http.Request) {
+		Record(r.Context(), Change{Action: "ignored"})
+		w.Write([]byte("{}"))
+	})
+	for _, method := range []string{http.MethodGet, http.MethodHead, http.MethodOptions} {
+		serve(h, method, "/admin/resources")
+	}
+	if list := entries(t, store); len(list) != 0 {
+		t.Errorf("read-only requests stored %+v", list)
+	}
+}
+
+func TestRecorder_FailedRequestsRecordNothing(t *testing.T) {
+	h, store := auditedServer(func(w http.ResponseWriter, r *http.Request) {
+		Record(r.Context(), Change{Action: "resource.create"})
+		http.Error(w, "invalid", http.StatusBadRequest)
+	})
+	serve(h, http.MethodPost, "/admin/resources")
+	if list := entries(t, store); len(list) != 0 {
+		t.Errorf("a rejected request stored %+v", list)
+	}
+}
+
+func TestRecorder_FallbackAndSkip(t *testing.T) {
+	h, store := auditedServer(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("dry_run") == "true" {
+			Skip(r.Context())
+		}
+		w.WriteHeader(http.StatusAccepted)
+	})
+	serve(h, http.MethodPost, "/admin/scrape/trigger?dry_run=true")
+	serve(h, http.MethodPost, "/admin/scrape/trigger")
+
+	list := entries(t, store)
+	if len(list) != 1 || list[0].Action != "POST /admin/scrape/trigger" || list[0].Changes != nil {
+		t.Errorf("entries = %+v, want one for the unannotated request", list)
+	}
+}
+
+func TestRecord_OutsideRecorder(t *testing.T) {
+	// Does not panic.
+	Record(context.Background(), Change{Action: "noop"})
+	Skip(context.Background())
+}
+
+func TestMemoryStore_FilterAndPaging(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryStore()
+	base := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	for i, e := range []Entry{
+		{Actor: "u1", EntityType: "resource", EntityID: "r1"},
+		{Actor: "u2", EntityType: "resource", EntityID: "r2"},
+		{Actor: "u1", EntityType: "path", EntityID: "p1"},
+		{Actor: "u1", EntityType: "resource", EntityID: "r1"},
+	} {
+		e.Service = "svc"
+		e.CreatedAt = base.Add(time.Duration(i) * 24 * time.Hour)
+		store.AppendAuditEntry(ctx, e)
+	}
+
+	list, total, _ := store.ListAuditEntries(ctx, Filter{Actor: "u1"})
+	if total != 3 || len(list) != 3 || !list[0].CreatedAt.After(list[1].CreatedAt) {
+		t.Errorf("actor filter = %d %+v, want 3 newest first", total, list)
+	}
+	list, total, _ = store.ListAuditEntries(ctx, Filter{EntityType: "resource", EntityID: "r1", Limit: 1, Offset: 1})
+	if total != 2 || len(list) != 1 || !list[0].CreatedAt.Equal(base) {
+		t.Errorf("entity filter page 2 = %d %+v", total, list)
+	}
+	from, to := base.Add(24*time.Hour), base.Add(3*24*time.Hour)
+	if _, total, _ = store.ListAuditEntries(ctx, Filter{From: &from, To: &to}); total != 2 {
+		t.Errorf("date filter matched %d entries, want 2", total)
+	}
+	if _, total, _ = store.ListAuditEntries(ctx, Filter{Service: "other"}); total != 0 {
+		t.Errorf("service filter matched %d entries, want 0", total)
+	}
+}
+
+func TestParseFilter(t *testing.T) {
+	f, err := ParseFilter(url.Values{
+		"actor": {" u1 "}, "entity_type": {"resource"}, "entity_id": {"r1"},
+		"from": {"2025-03-01"}, "to": {"2025-03-02"},
+	})
+	if err != nil {
+		t.Fatalf("ParseFilter: %v", err)
+	}
+	if f.Actor != "u1" || f.EntityType != "resource" || f.EntityID != "r1" {
+		t.Errorf("filter = %+v", f)
+	}
+	if want := time.Date(2025, 3, 3, 0, 0, 0, 0, time.UTC); f.To == nil || !f.To.Equal(want) {
+		t.Errorf("to = %v, want the end of the day %v", f.To, want)
+	}
+
+	for _, q := range []url.Values{
+		{"from": {"yesterday"}},
+		{"from": {"2025-03-02"}, "to": {"2025-03-01"}},
+	} {
+		if _, err := ParseFilter(q); err == nil {
+			t.Errorf("ParseFilter(%v): expected an error", q)
+		}
+	}
+}
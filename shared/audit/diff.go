@@ -0,0 +1,142 @@
+package audit
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+const (
+	// MaxValueBytes is the largest JSON value kept in a diff. A larger
+	// changed value is replaced by a note of its size.
+	MaxValueBytes = 1024
+
+	// Redacted replaces the values of sensitive fields.
+	Redacted = "[redacted]"
+)
+
+// sensitiveFields are redacted wherever they appear in a diff, along with
+// fields ending in "_" and one of them, e.g. "webhook_secret".
+var sensitiveFields = []string{"password", "password_hash", "secret", "token", "api_key"}
+
+// FieldChange is a changed field in a diff. Old is null for an added field
+// and New for a removed one.
+type FieldChange struct {
+	Old any `json:"old"`
+	New any `json:"new"`
+}
+
+// Diff compares the JSON encodings of before and after and returns the
+// changed top-level fields as an object of FieldChange, e.g.
+//
+//	{"title":{"old":"Go","new":"Go 101"}}
+//
+// Unchanged fields are omitted, sensitive fields are redacted at any depth
+// and changed values longer than MaxValueBytes are summarized. Values that
+// do not encode to objects are compared as a field named "value". Diff
+// returns nil if nothing changed.
+func Diff(before, after any) (json.RawMessage, error) {
+	old, err := fields(before)
+	if err != nil {
+		return nil, err
+	}
+	cur, err := fields(after)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := make(map[string]FieldChange)
+	for name, o := range old {
+		n, ok := cur[name]
+		if ok && reflect.DeepEqual(o, n) {
+			continue
+		}
+		changes[name] = FieldChange{Old: value(name, o), New: value(name, n)}
+	}
+	for name, n := range cur {
+		if _, ok := old[name]; !ok {
+			changes[name] = FieldChange{New: value(name, n)}
+		}
+	}
+	if len(changes) == 0 {
+		return nil, nil
+	}
+	return json.Marshal(changes)
+}
+
+// fields decodes the JSON encoding of v into its top-level fields. nil has
+// none.
+func fields(v any) (map[string]any, error) {
+	if v == nil {
+		return nil, nil
+	}
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var decoded any
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.UseNumber()
+	if err := dec.Decode(&decoded); err != nil {
+		return nil, err
+	}
+	switch d := decoded.(type) {
+	case nil:
+		return nil, nil
+	case map[string]any:
+		return d, nil
+	default:
+		return map[string]any{"value": d}, nil
+	}
+}
+
+// value returns the value of the named field as kept in a diff.
+func value(name string, v any) any {
+	if v == nil {
+		return nil
+	}
+	if sensitive(name) {
+		return Redacted
+	}
+	v = redact(v)
+	if raw, err := json.Marshal(v); err == nil && len(raw) > MaxValueBytes {
+		return fmt.Sprintf("[%d bytes]", len(raw))
+	}
+	return v
+}
+
+// redact replaces the sensitive fields of objects nested in v.
+func redact(v any) any {
+	switch d := v.(type) {
+	case map[string]any:
+		out := make(map[string]any, len(d))
+		for name, item := range d {
+			if sensitive(name) && item != nil {
+				out[name] = Redacted
+			} else {
+				out[name] = redact(item)
+			}
+		}
+		return out
+	case []any:
+		out := make([]any, len(d))
+		for i, item := range d {
+			out[i] = redact(item)
+		}
+		return out
+	}
+	return v
+}
+
+// sensitive reports whether the field name holds a credential.
+func sensitive(name string) bool {
+	name = strings.ToLower(name)
+	for _, s := range sensitiveFields {
+		if name == s || strings.HasSuffix(name, "_"+s) {
+			return true
+		}
+	}
+	return false
+}
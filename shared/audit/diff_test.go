@@ -0,0 +1,130 @@
+package audit
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+type resource struct {
+	Title        string   `json:"title"`
+	Description  string   `json:"description"`
+	Skills       []string `json:"skills"`
+	PasswordHash string   `json:"password_hash,omitempty"`
+	Rating       float64  `json:"rating"`
+}
+
+func decodeDiff(t *testing.T, raw json.RawMessage) map[string]FieldChange {
+	t.Helper()
+	var changes map[string]FieldChange
+	if err := json.Unmarshal(raw, &changes); err != nil {
+		t.Fatalf("decode diff %s: %v", raw, err)
+	}
+	return changes
+}
+
+func TestDiff_ChangedFieldsOnly(t *testing.T) {
+	long := strings.Repeat("x", 4*MaxValueBytes)
+	before := resource{Title: "Go", Description: long, Skills: []string{"go"}, Rating: 4.5}
+	after := before
+	after.Title = "Go 101"
+	after.Skills = []string{"go", "testing"}
+
+	raw, err := Diff(before, &after)
+	if err != nil {
+		t.Fatalf("Diff: %v", err)
+	}
+	changes := decodeDiff(t, raw)
+	if len(changes) != 2 {
+		t.Fatalf("diff = %s, want title and skills only", raw)
+	}
+	if c := changes["title"]; c.Old != "Go" || c.New != "Go 101" {
+		t.Errorf("title change = %+v", c)
+	}
+	if c := changes["skills"]; len(c.Old.([]any)) != 1 || len(c.New.([]any)) != 2 {
+		t.Errorf("skills change = %+v", c)
+	}
+	if strings.Contains(string(raw), long) {
+		t.Error("diff holds the unchanged description")
+	}
+}
+
+func TestDiff_NothingChanged(t *testing.T) {
+	r := resource{Title: "Go", Rating: 4.5}
+	raw, err := Diff(r, r)
+	if err != nil || raw != nil {
+		t.Errorf("Diff of equal values = %s, %v; want nil", raw, err)
+	}
+	if raw, err := Diff(nil, nil); err != nil || raw != nil {
+		t.Errorf("Diff(nil, nil) = %s, %v; want nil", raw, err)
+	}
+}
+
+func TestDiff_CreationAndDeletion(t *testing.T) {
+	r := resource{Title: "Go"}
+	changes := decodeDiff(t, must(Diff(nil, r)))
+	if c := changes["title"]; c.Old != nil || c.New != "Go" {
+		t.Errorf("created title = %+v", c)
+	}
+	changes = decodeDiff(t, must(Diff(r, nil)))
+	if c := changes["title"]; c.Old != "Go" || c.New != nil {
+		t.Errorf("deleted title = %+v", c)
+	}
+}
+
+func TestDiff_RedactsSensitiveFields(t *testing.T) {
+	before := map[string]any{
+		"password_hash": "$2a$10$old",
+		"config":        map[string]any{"webhook_secret": "s1", "url": "https://a.example"},
+	}
+	after := map[string]any{
+		"password_hash": "$2a$10$new",
+		"config":        map[string]any{"webhook_secret": "s2", "url": "https://b.example"},
+		"api_key":       "k",
+	}
+	raw := must(Diff(before, after))
+	for _, secret := range []string{"$2a$10$old", "$2a$10$new", "s1", "s2", `"k"`} {
+		if strings.Contains(string(raw), secret) {
+			t.Errorf("diff %s holds %s", raw, secret)
+		}
+	}
+	changes := decodeDiff(t, raw)
+	if c := changes["password_hash"]; c.Old != Redacted || c.New != Redacted {
+		t.Errorf("password_hash change = %+v, want both redacted", c)
+	}
+	if c := changes["api_key"]; c.Old != nil || c.New != Redacted {
+		t.Errorf("api_key change = %+v", c)
+	}
+	if cfg, _ := changes["config"].New.(map[string]any); cfg["url"] != "https://b.example" || cfg["webhook_secret"] != Redacted {
+		t.Errorf("nested change = %+v", changes["config"])
+	}
+}
+
+func TestDiff_SummarizesLargeValues(t *testing.T) {
+	before := resource{Description: "short"}
+	after := resource{Description: strings.Repeat("y", 2*MaxValueBytes)}
+	c := decodeDiff(t, must(Diff(before, after)))["description"]
+	if c.Old != "short" || c.New != "[2050 bytes]" {
+		t.Errorf("description change = %+v", c)
+	}
+}
+
+func TestDiff_ScalarValues(t *testing.T) {
+	c := decodeDiff(t, must(Diff(true, false)))["value"]
+	if c.Old != true || c.New != false {
+		t.Errorf("scalar change = %+v", c)
+	}
+}
+
+func TestDiff_Unencodable(t *testing.T) {
+	if _, err := Diff(nil, map[string]any{"f": func() {}}); err == nil {
+		t.Error("expected an error for a value JSON cannot encode")
+	}
+}
+
+func must(raw json.RawMessage, err error) json.RawMessage {
+	if err != nil {
+		panic(err)
+	}
+	return raw
+}
@@ -0,0 +1,222 @@
+package audit
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Filter selects audit entries. Zero fields match every entry.
+type Filter struct {
+	Service    string
+	Actor      string
+	EntityType string
+	EntityID   string
+	// From is inclusive and To exclusive.
+	From, To *time.Time
+	// Limit defaults to 20; Offset skips the newest entries.
+	Limit, Offset int
+}
+
+// Store keeps audit entries.
+type Store interface {
+	// AppendAuditEntry stores e, assigning its ID if it has none.
+	AppendAuditEntry(ctx context.Context, e Entry) error
+
+	// ListAuditEntries returns a page of the entries matching f, newest
+	// first, and how many match in total.
+	ListAuditEntries(ctx context.Context, f Filter) ([]Entry, int, error)
+}
+
+// newID returns a random version 4 UUID.
+func newID() string {
+	var b [16]byte
+	rand.Read(b[:])
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// In-memory store (tests and development)
+// ─────────────────────────────────────────────────────────────────────────────
+
+// MemoryStore is a thread-safe in-memory Store.
+type MemoryStore struct {
+	mu      sync.Mutex
+	entries []Entry
+}
+
+// NewMemoryStore creates an empty MemoryStore.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{}
+}
+
+// AppendAuditEntry implements Store.
+func (s *MemoryStore) AppendAuditEntry(_ context.Context, e Entry) error {
+	if e.ID == "" {
+		e.ID = newID()
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.entries = append(s.entries, e)
+	return nil
+}
+
+// ListAuditEntries implements Store.
+func (s *MemoryStore) ListAuditEntries(_ context.Context, f Filter) ([]Entry, int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var matched []Entry
+	for i := len(s.entries) - 1; i >= 0; i-- {
+		if e := s.entries[i]; f.matches(e) {
+			matched = append(matched, e)
+		}
+	}
+	sort.SliceStable(matched, func(i, j int) bool { return matched[i].CreatedAt.After(matched[j].CreatedAt) })
+	total := len(matched)
+	limit, offset := f.page()
+	if offset > total {
+		offset = total
+	}
+	end := offset + limit
+	if end > total {
+		end = total
+	}
+	return append([]Entry(nil), matched[offset:end]...), total, nil
+}
+
+// matches reports whether e is selected by f.
+func (f Filter) matches(e Entry) bool {
+	switch {
+	case f.Service != "" && e.Service != f.Service,
+		f.Actor != "" && e.Actor != f.Actor,
+		f.EntityType != "" && e.EntityType != f.EntityType,
+		f.EntityID != "" && e.EntityID != f.EntityID,
+		f.From != nil && e.CreatedAt.Before(*f.From),
+		f.To != nil && !e.CreatedAt.Before(*f.To):
+		return false
+	}
+	return true
+}
+
+// page returns the limit and offset of f with defaults applied.
+func (f Filter) page() (int, int) {
+	limit, offset := f.Limit, f.Offset
+	if limit <= 0 {
+		limit = 20
+	}
+	if offset < 0 {
+		offset = 0
+	}
+	return limit, offset
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// SQL store
+// ─────────────────────────────────────────────────────────────────────────────
+
+// SQLStore is a Store over the audit_log table. Its queries run on
+// PostgreSQL and SQLite; the table is created by each service's migrations.
+type SQLStore struct {
+	db *sql.DB
+}
+
+// NewSQLStore creates an SQLStore using db.
+func NewSQLStore(db *sql.DB) *SQLStore {
+	return &SQLStore{db: db}
+}
+
+const entryColumns = `id, service, actor, actor_email, action, entity_type, entity_id, changes, request_id, created_at`
+
+// AppendAuditEntry implements Store. Times are stored in UTC, since SQLite
+// compares them as text.
+func (s *SQLStore) AppendAuditEntry(ctx context.Context, e Entry) error {
+	if e.ID == "" {
+		e.ID = newID()
+	}
+	var changes interface{}
+	if len(e.Changes) > 0 {
+		changes = string(e.Changes)
+	}
+	_, err := s.db.ExecContext(ctx, `
+		INSERT INTO audit_log (`+entryColumns+`)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)`,
+		e.ID, e.Service, e.Actor, e.ActorEmail, e.Action, e.EntityType, e.EntityID,
+		changes, e.RequestID, e.CreatedAt.UTC(),
+	)
+	if err != nil {
+		return fmt.Errorf("append audit entry: %w", err)
+	}
+	return nil
+}
+
+// ListAuditEntries implements Store.
+func (s *SQLStore) ListAuditEntries(ctx context.Context, f Filter) ([]Entry, int, error) {
+	where := []string{"1=1"}
+	args := []interface{}{}
+	add := func(cond string, arg interface{}) {
+		args = append(args, arg)
+		where = append(where, fmt.Sprintf(cond, len(args)))
+	}
+	if f.Service != "" {
+		add("service = $%d", f.Service)
+	}
+	if f.Actor != "" {
+		add("actor = $%d", f.Actor)
+	}
+	if f.EntityType != "" {
+		add("entity_type = $%d", f.EntityType)
+	}
+	if f.EntityID != "" {
+		add("entity_id = $%d", f.EntityID)
+	}
+	if f.From != nil {
+		add("created_at >= $%d", f.From.UTC())
+	}
+	if f.To != nil {
+		add("created_at < $%d", f.To.UTC())
+	}
+	whereClause := strings.Join(where, " AND ")
+
+	var total int
+	if err := s.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM audit_log WHERE `+whereClause, args...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("count audit entries: %w", err)
+	}
+
+	limit, offset := f.page()
+	args = append(args, limit, offset)
+	rows, err := s.db.QueryContext(ctx, fmt.Sprintf(`
+		SELECT %s
+		FROM audit_log
+		WHERE %s
+		ORDER BY created_at DESC, id
+		LIMIT $%d OFFSET $%d`, entryColumns, whereClause, len(args)-1, len(args)), args...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("list audit entries: %w", err)
+	}
+	defer rows.Close()
+
+	entries := []Entry{}
+	for rows.Next() {
+		var e Entry
+		var changes sql.NullString
+		if err := rows.Scan(&e.ID, &e.Service, &e.Actor, &e.ActorEmail, &e.Action,
+			&e.EntityType, &e.EntityID, &changes, &e.RequestID, &e.CreatedAt); err != nil {
+			return nil, 0, fmt.Errorf("scan audit entry: %w", err)
+		}
+		if changes.Valid {
+			e.Changes = []byte(changes.String)
+		}
+		entries = append(entries, e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("list audit entries: %w", err)
+	}
+	return entries, total, nil
+}
@@ -0,0 +1,73 @@
+package audit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+var entryColumnNames = []string{
+	"id", "service", "actor", "actor_email", "action", "entity_type", "entity_id", "changes", "request_id", "created_at",
+}
+
+func TestSQLStore(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock.New: %v", err)
+	}
+	defer db.Close()
+	store := NewSQLStore(db)
+	ctx := context.Background()
+	at := time.Date(2025, 3, 1, 13, 0, 0, 0, time.FixedZone("CET", 3600))
+
+	mock.ExpectExec("INSERT INTO audit_log").
+		WithArgs(sqlmock.AnyArg(), "svc", "u1", "a@example.com", "resource.update", "resource", "r1",
+			`{"title":{"old":"a","new":"b"}}`, "req-1", at.UTC()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("INSERT INTO audit_log").
+		WithArgs("fixed-id", "svc", "api_key", "", "POST /x", "", "", nil, "", at.UTC()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err = store.AppendAuditEntry(ctx, Entry{
+		Service: "svc", Actor: "u1", ActorEmail: "a@example.com", Action: "resource.update",
+		EntityType: "resource", EntityID: "r1", Changes: []byte(`{"title":{"old":"a","new":"b"}}`),
+		RequestID: "req-1", CreatedAt: at,
+	})
+	if err != nil {
+		t.Fatalf("AppendAuditEntry: %v", err)
+	}
+	if err := store.AppendAuditEntry(ctx, Entry{ID: "fixed-id", Service: "svc", Actor: "api_key", Action: "POST /x", CreatedAt: at}); err != nil {
+		t.Fatalf("AppendAuditEntry without changes: %v", err)
+	}
+
+	from := at.Add(-time.Hour)
+	mock.ExpectQuery(`SELECT COUNT\(\*\) FROM audit_log WHERE 1=1 AND service = \$1 AND actor = \$2 AND created_at >= \$3`).
+		WithArgs("svc", "u1", from.UTC()).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	mock.ExpectQuery(`ORDER BY created_at DESC, id\s+LIMIT \$4 OFFSET \$5`).
+		WithArgs("svc", "u1", from.UTC(), 20, 0).
+		WillReturnRows(sqlmock.NewRows(entryColumnNames).AddRow(
+			"id-1", "svc", "u1", "a@example.com", "resource.update", "resource", "r1",
+			`{"title":{"old":"a","new":"b"}}`, "req-1", at.UTC(),
+		))
+
+	list, total, err := store.ListAuditEntries(ctx, Filter{Service: "svc", Actor: "u1", From: &from})
+	if err != nil {
+		t.Fatalf("ListAuditEntries: %v", err)
+	}
+	if total != 1 || len(list) != 1 || list[0].ID != "id-1" || string(list[0].Changes) != `{"title":{"old":"a","new":"b"}}` {
+		t.Errorf("entries = %d %+v", total, list)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestNewID(t *testing.T) {
+	id := newID()
+	if len(id) != 36 || id[14] != '4' || id == newID() {
+		t.Errorf("newID() = %q, want a random version 4 UUID", id)
+	}
+}
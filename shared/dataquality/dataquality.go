@@ -0,0 +1,104 @@
+// Package dataquality defines the section each service contributes to the
+// pipeline data quality dashboard (GET /admin/data-quality), which the API
+// gateway merges into a single report.
+//
+// A section covers a reporting period and compares every check's count
+// with a previous count, so that the dashboard shows whether a problem is
+// getting worse:
+//
+//	{"service": "job-aggregator", "status": "degraded", "checks": [
+//	    {"name": "failed_runs", "count": 3, "previous_count": 1, "trend": "up", ...}]}
+package dataquality
+
+import "time"
+
+// Section statuses.
+const (
+	StatusHealthy  = "healthy"
+	StatusDegraded = "degraded"
+)
+
+// Check trends.
+const (
+	TrendUp   = "up"
+	TrendDown = "down"
+	TrendFlat = "flat"
+)
+
+// MaxDrillDownIDs caps the number of record IDs listed per check.
+const MaxDrillDownIDs = 20
+
+// Section is one service's section of the data quality dashboard.
+type Section struct {
+	Service     string    `json:"service"`
+	Status      string    `json:"status"` // "healthy" or "degraded"
+	GeneratedAt time.Time `json:"generated_at"`
+	PeriodStart time.Time `json:"period_start"`
+	PeriodEnd   time.Time `json:"period_end"`
+	Checks      []Check   `json:"checks"`
+}
+
+// Check is a single data quality indicator. What PreviousCount counts is up
+// to the service: the same check over the preceding period of equal length,
+// or the affected records that already existed when the period started.
+type Check struct {
+	Name          string    `json:"name"`
+	Description   string    `json:"description"`
+	Count         int       `json:"count"`
+	PreviousCount int       `json:"previous_count"`
+	Trend         string    `json:"trend"` // "up", "down" or "flat"
+	DrillDown     DrillDown `json:"drill_down"`
+}
+
+// DrillDown identifies the records behind a check. Href, if set, is the
+// path of the endpoint that returns a record, with an {id} placeholder or
+// as a list to look the IDs up in.
+type DrillDown struct {
+	Href string   `json:"href,omitempty"`
+	IDs  []string `json:"ids"`
+}
+
+// NewCheck returns a check with no records, whose drill-down links to href.
+func NewCheck(name, description, href string) Check {
+	return Check{Name: name, Description: description, DrillDown: DrillDown{Href: href, IDs: []string{}}}
+}
+
+// AddID lists id in the drill-down unless it already holds MaxDrillDownIDs.
+func (c *Check) AddID(id string) {
+	if len(c.DrillDown.IDs) < MaxDrillDownIDs {
+		c.DrillDown.IDs = append(c.DrillDown.IDs, id)
+	}
+}
+
+// NewSection returns service's section for the window ending at now. It
+// sets the trend of each check and reports the section as degraded if any
+// check has a count.
+func NewSection(service string, now time.Time, window time.Duration, checks []Check) Section {
+	status := StatusHealthy
+	for i := range checks {
+		checks[i].Trend = Trend(checks[i].Count, checks[i].PreviousCount)
+		if checks[i].Count > 0 {
+			status = StatusDegraded
+		}
+	}
+	return Section{
+		Service:     service,
+		Status:      status,
+		GeneratedAt: now,
+		PeriodStart: now.Add(-window),
+		PeriodEnd:   now,
+		Checks:      checks,
+	}
+}
+
+// Trend describes how a count moved relative to the previous count.
+func Trend(current, previous int) string {
+	switch {
+	case current > previous:
+		return TrendUp
+	case current < previous:
+		return TrendDown
+	default:
+		return TrendFlat
+	}
+}
@@ -0,0 +1,61 @@
+package dataquality
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestTrend(t *testing.T) {
+	tests := []struct {
+		current, previous int
+		want              string
+	}{
+		{3, 1, TrendUp},
+		{1, 3, TrendDown},
+		{2, 2, TrendFlat},
+		{0, 0, TrendFlat},
+	}
+	for _, tt := range tests {
+		if got := Trend(tt.current, tt.previous); got != tt.want {
+			t.Errorf("Trend(%d, %d) = %q, want %q", tt.current, tt.previous, got, tt.want)
+		}
+	}
+}
+
+func TestNewSection(t *testing.T) {
+	now := time.Date(2024, 6, 15, 12, 0, 0, 0, time.UTC)
+	failing := NewCheck("failing", "Failing records", "/records/{id}")
+	failing.Count, failing.PreviousCount = 2, 5
+	s := NewSection("svc", now, 7*24*time.Hour, []Check{NewCheck("clean", "Clean records", ""), failing})
+
+	if s.Service != "svc" || s.Status != StatusDegraded || !s.PeriodEnd.Equal(now) || !s.PeriodStart.Equal(now.Add(-7*24*time.Hour)) {
+		t.Errorf("unexpected section: %+v", s)
+	}
+	if s.Checks[0].Trend != TrendFlat || s.Checks[1].Trend != TrendDown {
+		t.Errorf("trends = %q, %q, want flat, down", s.Checks[0].Trend, s.Checks[1].Trend)
+	}
+	if s := NewSection("svc", now, time.Hour, []Check{NewCheck("clean", "Clean records", "")}); s.Status != StatusHealthy {
+		t.Errorf("Status = %q, want healthy", s.Status)
+	}
+}
+
+func TestCheck_AddIDAndJSON(t *testing.T) {
+	c := NewCheck("failing", "Failing records", "")
+	for i := 0; i < MaxDrillDownIDs+5; i++ {
+		c.AddID(strconv.Itoa(i))
+	}
+	if len(c.DrillDown.IDs) != MaxDrillDownIDs || c.DrillDown.IDs[0] != "0" {
+		t.Errorf("drill-down IDs = %v, want the first %d", c.DrillDown.IDs, MaxDrillDownIDs)
+	}
+
+	data, err := json.Marshal(NewCheck("clean", "Clean records", ""))
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+	if !strings.Contains(string(data), `"drill_down":{"ids":[]}`) {
+		t.Errorf("a check without records should list no IDs and no href: %s", data)
+	}
+}
@@ -0,0 +1,256 @@
+package geo
+
+// country is a country of the gazetteer. Aliases are lookup keys (see key)
+// besides the code and name.
+type country struct {
+	code    string // ISO 3166-1 alpha-2
+	name    string
+	aliases []string
+}
+
+// region is a state or province. Codes are the postal abbreviations job
+// boards write after a city, as in "Austin, TX".
+type region struct {
+	country string
+	code    string
+	name    string
+	aliases []string
+}
+
+// city is a city of the gazetteer. Region is a region code for countries
+// whose regions are listed in builtinRegions, and empty otherwise.
+type city struct {
+	name    string
+	region  string
+	country string
+	aliases []string
+}
+
+// builtinCountries lists the countries job postings commonly name, with the
+// alpha-3 code and common spellings as aliases.
+var builtinCountries = []country{
+	{"US", "United States", []string{"usa", "us of a", "united states of america", "america", "u s"}},
+	{"CA", "Canada", []string{"can"}},
+	{"MX", "Mexico", []string{"mex", "méxico"}},
+	{"BR", "Brazil", []string{"bra", "brasil"}},
+	{"AR", "Argentina", []string{"arg"}},
+	{"CL", "Chile", []string{"chl"}},
+	{"CO", "Colombia", []string{"col"}},
+	{"PE", "Peru", []string{"per"}},
+	{"GB", "United Kingdom", []string{"uk", "gbr", "great britain", "britain", "england", "scotland", "wales", "northern ireland"}},
+	{"IE", "Ireland", []string{"irl", "republic of ireland"}},
+	{"DE", "Germany", []string{"deu", "deutschland"}},
+	{"FR", "France", []string{"fra"}},
+	{"NL", "Netherlands", []string{"nld", "the netherlands", "holland"}},
+	{"BE", "Belgium", []string{"bel"}},
+	{"LU", "Luxembourg", []string{"lux"}},
+	{"CH", "Switzerland", []string{"che", "schweiz", "suisse"}},
+	{"AT", "Austria", []string{"aut", "österreich"}},
+	{"ES", "Spain", []string{"esp", "españa"}},
+	{"PT", "Portugal", []string{"prt"}},
+	{"IT", "Italy", []string{"ita", "italia"}},
+	{"SE", "Sweden", []string{"swe", "sverige"}},
+	{"NO", "Norway", []string{"nor", "norge"}},
+	{"DK", "Denmark", []string{"dnk", "danmark"}},
+	{"FI", "Finland", []string{"fin", "suomi"}},
+	{"PL", "Poland", []string{"pol", "polska"}},
+	{"CZ", "Czechia", []string{"cze", "czech republic"}},
+	{"RO", "Romania", []string{"rou"}},
+	{"UA", "Ukraine", []string{"ukr"}},
+	{"GR", "Greece", []string{"grc"}},
+	{"TR", "Turkey", []string{"tur", "türkiye", "turkiye"}},
+	{"IL", "Israel", []string{"isr"}},
+	{"AE", "United Arab Emirates", []string{"are", "uae"}},
+	{"SA", "Saudi Arabia", []string{"sau", "ksa"}},
+	{"EG", "Egypt", []string{"egy"}},
+	{"NG", "Nigeria", []string{"nga"}},
+	{"KE", "Kenya", []string{"ken"}},
+	{"ZA", "South Africa", []string{"zaf", "rsa"}},
+	{"IN", "India", []string{"ind", "bharat"}},
+	{"PK", "Pakistan", []string{"pak"}},
+	{"BD", "Bangladesh", []string{"bgd"}},
+	{"LK", "Sri Lanka", []string{"lka"}},
+	{"CN", "China", []string{"chn", "prc", "people's republic of china", "mainland china"}},
+	{"HK", "Hong Kong", []string{"hkg", "hong kong sar", "hong kong sar china"}},
+	{"TW", "Taiwan", []string{"twn"}},
+	{"JP", "Japan", []string{"jpn"}},
+	{"KR", "South Korea", []string{"kor", "korea", "republic of korea"}},
+	{"SG", "Singapore", []string{"sgp"}},
+	{"MY", "Malaysia", []string{"mys"}},
+	{"ID", "Indonesia", []string{"idn", "republik indonesia"}},
+	{"TH", "Thailand", []string{"tha"}},
+	{"VN", "Vietnam", []string{"vnm", "viet nam"}},
+	{"PH", "Philippines", []string{"phl", "the philippines"}},
+	{"AU", "Australia", []string{"aus"}},
+	{"NZ", "New Zealand", []string{"nzl", "aotearoa"}},
+}
+
+// builtinRegions lists the states and provinces of the countries whose job
+// boards write "City, ST".
+var builtinRegions = []region{
+	{"US", "AL", "Alabama", nil}, {"US", "AK", "Alaska", nil}, {"US", "AZ", "Arizona", nil},
+	{"US", "AR", "Arkansas", nil}, {"US", "CA", "California", nil}, {"US", "CO", "Colorado", nil},
+	{"US", "CT", "Connecticut", nil}, {"US", "DE", "Delaware", nil}, {"US", "DC", "District of Columbia", nil},
+	{"US", "FL", "Florida", nil}, {"US", "GA", "Georgia", nil}, {"US", "HI", "Hawaii", nil},
+	{"US", "ID", "Idaho", nil}, {"US", "IL", "Illinois", nil}, {"US", "IN", "Indiana", nil},
+	{"US", "IA", "Iowa", nil}, {"US", "KS", "Kansas", nil}, {"US", "KY", "Kentucky", nil},
+	{"US", "LA", "Louisiana", nil}, {"US", "ME", "Maine", nil}, {"US", "MD", "Maryland", nil},
+	{"US", "MA", "Massachusetts", nil}, {"US", "MI", "Michigan", nil}, {"US", "MN", "Minnesota", nil},
+	{"US", "MS", "Mississippi", nil}, {"US", "MO", "Missouri", nil}, {"US", "MT", "Montana", nil},
+	{"US", "NE", "Nebraska", nil}, {"US", "NV", "Nevada", nil}, {"US", "NH", "New Hampshire", nil},
+	{"US", "NJ", "New Jersey", nil}, {"US", "NM", "New Mexico", nil}, {"US", "NY", "New York", []string{"new york state"}},
+	{"US", "NC", "North Carolina", nil}, {"US", "ND", "North Dakota", nil}, {"US", "OH", "Ohio", nil},
+	{"US", "OK", "Oklahoma", nil}, {"US", "OR", "Oregon", nil}, {"US", "PA", "Pennsylvania", nil},
+	{"US", "RI", "Rhode Island", nil}, {"US", "SC", "South Carolina", nil}, {"US", "SD", "South Dakota", nil},
+	{"US", "TN", "Tennessee", nil}, {"US", "TX", "Texas", nil}, {"US", "UT", "Utah", nil},
+	{"US", "VT", "Vermont", nil}, {"US", "VA", "Virginia", nil}, {"US", "WA", "Washington", []string{"washington state"}},
+	{"US", "WV", "West Virginia", nil}, {"US", "WI", "Wisconsin", nil}, {"US", "WY", "Wyoming", nil},
+	{"US", "PR", "Puerto Rico", nil},
+
+	{"CA", "AB", "Alberta", nil}, {"CA", "BC", "British Columbia", nil}, {"CA", "MB", "Manitoba", nil},
+	{"CA", "NB", "New Brunswick", nil}, {"CA", "NL", "Newfoundland and Labrador", nil}, {"CA", "NS", "Nova Scotia", nil},
+	{"CA", "ON", "Ontario", nil}, {"CA", "PE", "Prince Edward Island", nil}, {"CA", "QC", "Quebec", []string{"québec"}},
+	{"CA", "SK", "Saskatchewan", nil},
+
+	{"AU", "NSW", "New South Wales", nil}, {"AU", "VIC", "Victoria", nil}, {"AU", "QLD", "Queensland", nil},
+	{"AU", "ACT", "Australian Capital Territory", nil},
+}
+
+// builtinCities lists the cities job postings most often name, with the
+// metro-area names and local spellings job boards use as aliases. The
+// names of cities that are also countries or states ("Singapore", "New
+// York") are read as the city.
+var builtinCities = []city{
+	// United States
+	{"San Francisco", "CA", "US", []string{"sf", "san fran", "sf bay area", "bay area", "san francisco bay area", "sfba"}},
+	{"San Jose", "CA", "US", []string{"silicon valley"}},
+	{"Palo Alto", "CA", "US", nil},
+	{"Mountain View", "CA", "US", nil},
+	{"Oakland", "CA", "US", nil},
+	{"Los Angeles", "CA", "US", []string{"los angeles metropolitan"}},
+	{"San Diego", "CA", "US", nil},
+	{"Seattle", "WA", "US", []string{"seattle tacoma"}},
+	{"Portland", "OR", "US", nil},
+	{"New York", "NY", "US", []string{"nyc", "new york city", "manhattan", "brooklyn", "new york city metropolitan"}},
+	{"Boston", "MA", "US", nil},
+	{"Cambridge", "MA", "US", nil},
+	{"Washington, D.C.", "DC", "US", []string{"dc metro"}},
+	{"Chicago", "IL", "US", nil},
+	{"Austin", "TX", "US", nil},
+	{"Dallas", "TX", "US", []string{"dallas fort worth", "dfw"}},
+	{"Houston", "TX", "US", nil},
+	{"Denver", "CO", "US", nil},
+	{"Atlanta", "GA", "US", nil},
+	{"Miami", "FL", "US", nil},
+	{"Philadelphia", "PA", "US", []string{"philly"}},
+	{"Pittsburgh", "PA", "US", nil},
+	{"Minneapolis", "MN", "US", []string{"minneapolis st paul", "twin cities"}},
+	{"Detroit", "MI", "US", nil},
+	{"Phoenix", "AZ", "US", nil},
+	{"Salt Lake City", "UT", "US", []string{"slc"}},
+	{"Raleigh", "NC", "US", []string{"raleigh durham", "research triangle"}},
+	{"Nashville", "TN", "US", nil},
+	{"St. Louis", "MO", "US", []string{"saint louis"}},
+	// Canada
+	{"Toronto", "ON", "CA", []string{"gta"}},
+	{"Vancouver", "BC", "CA", nil},
+	{"Montreal", "QC", "CA", []string{"montréal"}},
+	{"Ottawa", "ON", "CA", nil},
+	{"Calgary", "AB", "CA", nil},
+	// Latin America
+	{"Mexico City", "", "MX", []string{"ciudad de mexico", "ciudad de méxico", "cdmx"}},
+	{"São Paulo", "", "BR", []string{"sao paulo"}},
+	{"Buenos Aires", "", "AR", nil},
+	{"Bogotá", "", "CO", []string{"bogota"}},
+	{"Santiago", "", "CL", nil},
+	// Europe
+	{"London", "", "GB", []string{"city of london"}},
+	{"Manchester", "", "GB", nil},
+	{"Edinburgh", "", "GB", nil},
+	{"Dublin", "", "IE", nil},
+	{"Berlin", "", "DE", nil},
+	{"Munich", "", "DE", []string{"münchen", "munchen"}},
+	{"Hamburg", "", "DE", nil},
+	{"Frankfurt", "", "DE", []string{"frankfurt am main"}},
+	{"Paris", "", "FR", []string{"ile de france", "île de france"}},
+	{"Amsterdam", "", "NL", nil},
+	{"Rotterdam", "", "NL", nil},
+	{"Brussels", "", "BE", []string{"bruxelles", "brussel"}},
+	{"Zurich", "", "CH", []string{"zürich"}},
+	{"Geneva", "", "CH", []string{"genève", "geneve"}},
+	{"Vienna", "", "AT", []string{"wien"}},
+	{"Madrid", "", "ES", nil},
+	{"Barcelona", "", "ES", nil},
+	{"Lisbon", "", "PT", []string{"lisboa"}},
+	{"Milan", "", "IT", []string{"milano"}},
+	{"Rome", "", "IT", []string{"roma"}},
+	{"Stockholm", "", "SE", nil},
+	{"Oslo", "", "NO", nil},
+	{"Copenhagen", "", "DK", []string{"københavn", "kobenhavn"}},
+	{"Helsinki", "", "FI", nil},
+	{"Warsaw", "", "PL", []string{"warszawa"}},
+	{"Krakow", "", "PL", []string{"kraków"}},
+	{"Prague", "", "CZ", []string{"praha"}},
+	{"Bucharest", "", "RO", nil},
+	{"Kyiv", "", "UA", []string{"kiev"}},
+	{"Athens", "", "GR", nil},
+	{"Istanbul", "", "TR", nil},
+	// Middle East and Africa
+	{"Tel Aviv", "", "IL", []string{"tel aviv yafo"}},
+	{"Dubai", "", "AE", nil},
+	{"Abu Dhabi", "", "AE", nil},
+	{"Riyadh", "", "SA", nil},
+	{"Cairo", "", "EG", nil},
+	{"Lagos", "", "NG", nil},
+	{"Nairobi", "", "KE", nil},
+	{"Cape Town", "", "ZA", nil},
+	{"Johannesburg", "", "ZA", []string{"joburg"}},
+	// Asia Pacific
+	{"Bengaluru", "", "IN", []string{"bangalore", "bengaluru urban"}},
+	{"Mumbai", "", "IN", []string{"bombay"}},
+	{"New Delhi", "", "IN", []string{"delhi", "delhi ncr", "ncr"}},
+	{"Gurugram", "", "IN", []string{"gurgaon"}},
+	{"Hyderabad", "", "IN", nil},
+	{"Pune", "", "IN", nil},
+	{"Chennai", "", "IN", []string{"madras"}},
+	{"Karachi", "", "PK", nil},
+	{"Lahore", "", "PK", nil},
+	{"Dhaka", "", "BD", nil},
+	{"Beijing", "", "CN", nil},
+	{"Shanghai", "", "CN", nil},
+	{"Shenzhen", "", "CN", nil},
+	{"Hong Kong", "", "HK", nil},
+	{"Taipei", "", "TW", nil},
+	{"Tokyo", "", "JP", nil},
+	{"Seoul", "", "KR", nil},
+	{"Singapore", "", "SG", nil},
+	{"Kuala Lumpur", "", "MY", []string{"kl"}},
+	{"Jakarta", "", "ID", []string{"dki jakarta", "jakarta raya", "jakarta selatan", "south jakarta", "jakarta pusat", "central jakarta", "jakarta barat", "west jakarta", "jakarta utara", "jakarta timur", "jabodetabek"}},
+	{"Bandung", "", "ID", nil},
+	{"Surabaya", "", "ID", nil},
+	{"Yogyakarta", "", "ID", []string{"jogja", "jogjakarta", "di yogyakarta"}},
+	{"Denpasar", "", "ID", []string{"bali"}},
+	{"Tangerang", "", "ID", []string{"tangerang selatan", "south tangerang"}},
+	{"Bangkok", "", "TH", nil},
+	{"Ho Chi Minh City", "", "VN", []string{"ho chi minh", "saigon", "hcmc"}},
+	{"Hanoi", "", "VN", []string{"ha noi"}},
+	{"Manila", "", "PH", []string{"metro manila"}},
+	{"Makati", "", "PH", nil},
+	{"Sydney", "NSW", "AU", nil},
+	{"Melbourne", "VIC", "AU", nil},
+	{"Brisbane", "QLD", "AU", nil},
+	{"Auckland", "", "NZ", nil},
+}
+
+// builtinAreas are the multi-country areas remote postings are open to,
+// keyed by lookup key. Their values are stored as the region.
+var builtinAreas = map[string]string{
+	"emea": "EMEA", "europe middle east and africa": "EMEA",
+	"apac": "APAC", "asia pacific": "APAC", "asia": "APAC",
+	"latam": "LATAM", "latin america": "LATAM", "south america": "LATAM",
+	"americas": "Americas", "north america": "North America", "amer": "Americas",
+	"europe": "Europe", "eu": "Europe", "european union": "Europe", "cet": "Europe",
+	"uk and ireland": "Europe", "dach": "DACH", "nordics": "Nordics", "benelux": "Benelux",
+	"middle east": "Middle East", "mena": "MENA", "africa": "Africa", "sea": "Southeast Asia",
+	"southeast asia": "Southeast Asia", "anz": "ANZ",
+}
@@ -0,0 +1,262 @@
+// Package geo normalises free-text job and candidate locations. Job boards
+// write the same place many ways ("SF Bay Area", "San Francisco, CA",
+// "Remote - US", "London/Hybrid"); Parse reads them into a city, region,
+// ISO country code and work arrangement using rules and a small builtin
+// gazetteer of common cities, regions and countries, so that locations can
+// be compared and filtered on.
+//
+//	geo.Parse("Jakarta, ID")     // {City: "Jakarta", Country: "ID"}
+//	geo.Parse("Remote - EMEA")   // {Region: "EMEA", Remote: true}
+//	geo.CountryCode("United States") == geo.CountryCode("US") // "US"
+//
+// Parsing is rule-based and needs no network access. Places missing from
+// the gazetteer parse to what the rules can tell, or to the zero Location.
+package geo
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// Version identifies the parsing rules and gazetteer. It changes whenever
+// they change, so that locations parsed and stored by an older version can
+// be told apart and parsed again.
+const Version = 1
+
+// Location is a parsed location. The zero Location means nothing in the
+// text was recognised.
+type Location struct {
+	// City is the canonical city name, e.g. "San Francisco".
+	City string `json:"city,omitempty"`
+	// Region is a state or province code for the United States, Canada
+	// and Australia ("CA", "ON", "NSW"), or the multi-country area a
+	// remote role is open to ("EMEA", "APAC").
+	Region string `json:"region,omitempty"`
+	// Country is an ISO 3166-1 alpha-2 code, e.g. "US".
+	Country string `json:"country,omitempty"`
+	// Remote and Hybrid report the work arrangement the text names.
+	Remote bool `json:"remote,omitempty"`
+	Hybrid bool `json:"hybrid,omitempty"`
+}
+
+// IsZero reports whether nothing in the text was recognised.
+func (l Location) IsZero() bool {
+	return l == Location{}
+}
+
+var (
+	// arrangementRe matches the words that name a work arrangement
+	// rather than a place.
+	arrangementRe = regexp.MustCompile(`(?i)\b(?:fully remote|remote[- ]first|remote|work from home|wfh|anywhere|worldwide|global|hybrid|on-?site|in[- ]office)\b`)
+	// separatorRe splits a location into its parts. Hyphens only
+	// separate with spaces around them, so that "Winston-Salem" stays
+	// whole.
+	separatorRe = regexp.MustCompile(`\s+[-–—]\s+|[,/|;()\[\]·•]|\s[-–—]|[-–—]\s|^[-–—]|[-–—]$`)
+	// postcodeRe matches a trailing postal code, as in "CA 94105".
+	postcodeRe = regexp.MustCompile(`\s+\d{4,6}(?:-\d{4})?$`)
+)
+
+// Parse reads a location string into a Location. Parts the gazetteer does
+// not know are kept as the city when the rest of the location places it,
+// as in "Springfield, IL"; a location of which nothing is recognised
+// parses to the zero Location.
+//
+// A two-letter code that is both a US state and a country code ("CA",
+// "IN", "ID") is read as the country when the city is in that country
+// ("Jakarta, ID") and as the state otherwise ("Boise, ID").
+func Parse(raw string) Location {
+	var loc Location
+	for _, m := range arrangementRe.FindAllString(raw, -1) {
+		switch w := strings.ToLower(m); {
+		case w == "hybrid":
+			loc.Hybrid = true
+		case strings.HasPrefix(w, "on") || strings.HasPrefix(w, "in"):
+		default:
+			loc.Remote = true
+		}
+	}
+	if loc.Hybrid {
+		loc.Remote = false
+	}
+	rest := arrangementRe.ReplaceAllString(raw, " ")
+
+	var parts []string
+	for _, part := range separatorRe.Split(rest, -1) {
+		part = postcodeRe.ReplaceAllString(strings.TrimSpace(part), "")
+		if key(part) != "" {
+			parts = append(parts, part)
+		}
+	}
+
+	var unknown string
+	for i := 0; i < len(parts); i++ {
+		k := partKey(parts[i])
+		if loc.City == "" && unknown == "" {
+			// A city name may itself contain a separator, as in
+			// "Washington, D.C.".
+			c, ok := city{}, false
+			if i+1 < len(parts) {
+				if c, ok = cityByKey[k+" "+partKey(parts[i+1])]; ok {
+					i++
+				}
+			}
+			if !ok {
+				c, ok = lookupCity(k)
+			}
+			if ok {
+				loc.City = c.name
+				if loc.Country == "" || loc.Country == c.country {
+					loc.Country = c.country
+					if c.region != "" {
+						loc.Region = c.region
+					}
+				}
+				continue
+			}
+		}
+		if placePart(&loc, k) {
+			continue
+		}
+		if unknown == "" && loc.City == "" && loc.Region == "" && loc.Country == "" {
+			unknown = strings.Join(strings.Fields(parts[i]), " ")
+		}
+	}
+	if unknown != "" && loc.City == "" && (loc.Region != "" || loc.Country != "") {
+		loc.City = unknown
+	}
+	return loc
+}
+
+// placePart fills in the region or country of loc from one part of a
+// location, and reports whether the part was recognised.
+func placePart(loc *Location, k string) bool {
+	if area, ok := builtinAreas[k]; ok {
+		if loc.Region == "" {
+			loc.Region = area
+		}
+		return true
+	}
+	if loc.Country != "" {
+		if r, ok := regionByKey[loc.Country+":"+k]; ok {
+			if loc.Region == "" {
+				loc.Region = r.code
+			}
+			return true
+		}
+	}
+	c, isCountry := countryByKey[k]
+	r, isState := regionByKey["US:"+k]
+	if isState && isCountry && len(k) == 2 && loc.Country != c.code {
+		isCountry = false
+	}
+	switch {
+	case isCountry:
+		if loc.Country == "" {
+			loc.Country = c.code
+		}
+		return true
+	case isState && (loc.Country == "" || loc.Country == "US"):
+		if loc.Region == "" {
+			loc.Region = r.code
+		}
+		loc.Country = "US"
+		return true
+	}
+	if r, ok := regionByName[k]; ok && (loc.Country == "" || loc.Country == r.country) {
+		if loc.Region == "" {
+			loc.Region = r.code
+		}
+		loc.Country = r.country
+		return true
+	}
+	return false
+}
+
+// partKey is the key of one part of a location, without the words around
+// a place in phrases such as "based in Berlin" and "US only".
+func partKey(part string) string {
+	k := key(part)
+	for _, prefix := range []string{"based in ", "in "} {
+		k = strings.TrimPrefix(k, prefix)
+	}
+	return strings.TrimSuffix(k, " only")
+}
+
+// lookupCity finds a city by its key, or by the key without qualifiers
+// such as "Greater" and "Metropolitan Area".
+func lookupCity(k string) (city, bool) {
+	if c, ok := cityByKey[k]; ok {
+		return c, true
+	}
+	stripped := strings.TrimPrefix(k, "greater ")
+	for _, suffix := range []string{" metropolitan area", " metro area", " area", " metropolitan", " metro", " region"} {
+		stripped = strings.TrimSuffix(stripped, suffix)
+	}
+	c, ok := cityByKey[stripped]
+	return c, ok
+}
+
+// CountryCode returns the ISO 3166-1 alpha-2 code of a country given by
+// name, alpha-2 or alpha-3 code, or common spelling, or "" if the
+// gazetteer does not know it. "United States", "USA" and "US" all return
+// "US".
+func CountryCode(s string) string {
+	return countryByKey[key(s)].code
+}
+
+// CityName returns the canonical name of a city given by name or alias,
+// or "" if the gazetteer does not know it. "NYC" and "New York City" both
+// return "New York".
+func CityName(s string) string {
+	c, _ := lookupCity(key(s))
+	return c.name
+}
+
+// key is the form of a name used for lookups: lowercased, without dots and
+// apostrophes, with other punctuation as spaces.
+func key(s string) string {
+	var b strings.Builder
+	space := true
+	for _, r := range strings.ToLower(s) {
+		switch {
+		case r == '.' || r == '\'' || r == '’':
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			b.WriteRune(r)
+			space = false
+		case !space:
+			b.WriteRune(' ')
+			space = true
+		}
+	}
+	return strings.TrimSpace(b.String())
+}
+
+var (
+	countryByKey = map[string]country{}
+	cityByKey    = map[string]city{}
+	// regionByKey holds regions by country and code or name, as in
+	// "US:ca" and "US:california"; regionByName by name alone.
+	regionByKey  = map[string]region{}
+	regionByName = map[string]region{}
+)
+
+func init() {
+	for _, c := range builtinCountries {
+		for _, k := range append([]string{c.code, c.name}, c.aliases...) {
+			countryByKey[key(k)] = c
+		}
+	}
+	for _, r := range builtinRegions {
+		regionByKey[r.country+":"+key(r.code)] = r
+		for _, k := range append([]string{r.name}, r.aliases...) {
+			regionByKey[r.country+":"+key(k)] = r
+			regionByName[key(k)] = r
+		}
+	}
+	for _, c := range builtinCities {
+		for _, k := range append([]string{c.name}, c.aliases...) {
+			cityByKey[key(k)] = c
+		}
+	}
+}
@@ -0,0 +1,137 @@
+package geo
+
+import "testing"
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		raw  string
+		want Location
+	}{
+		// Cities with their metro-area names and local spellings.
+		{"SF Bay Area", Location{City: "San Francisco", Region: "CA", Country: "US"}},
+		{"San Francisco, CA", Location{City: "San Francisco", Region: "CA", Country: "US"}},
+		{"San Francisco, CA 94105", Location{City: "San Francisco", Region: "CA", Country: "US"}},
+		{"San Francisco Bay Area", Location{City: "San Francisco", Region: "CA", Country: "US"}},
+		{"New York, NY", Location{City: "New York", Region: "NY", Country: "US"}},
+		{"NYC", Location{City: "New York", Region: "NY", Country: "US"}},
+		{"New York City Metropolitan Area", Location{City: "New York", Region: "NY", Country: "US"}},
+		{"Washington, D.C.", Location{City: "Washington, D.C.", Region: "DC", Country: "US"}},
+		{"Washington, DC, United States", Location{City: "Washington, D.C.", Region: "DC", Country: "US"}},
+		{"Seattle, Washington, United States", Location{City: "Seattle", Region: "WA", Country: "US"}},
+		{"Austin, Texas", Location{City: "Austin", Region: "TX", Country: "US"}},
+		{"St. Louis, MO", Location{City: "St. Louis", Region: "MO", Country: "US"}},
+		{"Greater London", Location{City: "London", Country: "GB"}},
+		{"London, England, United Kingdom", Location{City: "London", Country: "GB"}},
+		{"Munich, Germany", Location{City: "Munich", Country: "DE"}},
+		{"München", Location{City: "Munich", Country: "DE"}},
+		{"Zürich, Switzerland", Location{City: "Zurich", Country: "CH"}},
+		{"Sao Paulo, Brazil", Location{City: "São Paulo", Country: "BR"}},
+		{"Bengaluru, Karnataka, India", Location{City: "Bengaluru", Country: "IN"}},
+		{"Bangalore", Location{City: "Bengaluru", Country: "IN"}},
+		{"Toronto, ON", Location{City: "Toronto", Region: "ON", Country: "CA"}},
+		{"Vancouver, British Columbia, Canada", Location{City: "Vancouver", Region: "BC", Country: "CA"}},
+		{"Sydney, NSW, Australia", Location{City: "Sydney", Region: "NSW", Country: "AU"}},
+		{"Singapore", Location{City: "Singapore", Country: "SG"}},
+		{"Hong Kong SAR", Location{Country: "HK"}},
+
+		// Two-letter codes that are both a country and a US state.
+		{"Jakarta, ID", Location{City: "Jakarta", Country: "ID"}},
+		{"Boise, ID", Location{City: "Boise", Region: "ID", Country: "US"}},
+		{"Bengaluru, IN", Location{City: "Bengaluru", Country: "IN"}},
+		{"Indianapolis, IN", Location{City: "Indianapolis", Region: "IN", Country: "US"}},
+		{"Jakarta Selatan, DKI Jakarta, Indonesia", Location{City: "Jakarta", Country: "ID"}},
+		{"Greater Jakarta Area", Location{City: "Jakarta", Country: "ID"}},
+		{"Bandung, Jawa Barat", Location{City: "Bandung", Country: "ID"}},
+
+		// Work arrangements.
+		{"Remote", Location{Remote: true}},
+		{"Remote - EMEA", Location{Region: "EMEA", Remote: true}},
+		{"Remote (US only)", Location{Country: "US", Remote: true}},
+		{"Remote-US", Location{Country: "US", Remote: true}},
+		{"Remote in Germany", Location{Country: "DE", Remote: true}},
+		{"Fully Remote, APAC", Location{Region: "APAC", Remote: true}},
+		{"Anywhere", Location{Remote: true}},
+		{"Worldwide", Location{Remote: true}},
+		{"Work from home - Jakarta", Location{City: "Jakarta", Country: "ID", Remote: true}},
+		{"London/Hybrid", Location{City: "London", Country: "GB", Hybrid: true}},
+		{"Hybrid - Berlin, Germany", Location{City: "Berlin", Country: "DE", Hybrid: true}},
+		{"Hybrid remote, Toronto", Location{City: "Toronto", Region: "ON", Country: "CA", Hybrid: true}},
+		{"On-site | Austin, TX", Location{City: "Austin", Region: "TX", Country: "US"}},
+		{"Amsterdam (On-site)", Location{City: "Amsterdam", Country: "NL"}},
+		{"Remote · LATAM", Location{Region: "LATAM", Remote: true}},
+
+		// Countries and regions alone.
+		{"United States", Location{Country: "US"}},
+		{"USA", Location{Country: "US"}},
+		{"U.S.", Location{Country: "US"}},
+		{"UK", Location{Country: "GB"}},
+		{"California, United States", Location{Region: "CA", Country: "US"}},
+		{"Ontario, Canada", Location{Region: "ON", Country: "CA"}},
+		{"Indonesia", Location{Country: "ID"}},
+
+		// Places the gazetteer does not know.
+		{"Springfield, IL", Location{City: "Springfield", Region: "IL", Country: "US"}},
+		{"Winston-Salem, NC", Location{City: "Winston-Salem", Region: "NC", Country: "US"}},
+		{"Semarang, Indonesia", Location{City: "Semarang", Country: "ID"}},
+		{"Multiple Locations", Location{}},
+		{"Atlantis", Location{}},
+		{"", Location{}},
+		{"  ,  / ", Location{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.raw, func(t *testing.T) {
+			if got := Parse(tt.raw); got != tt.want {
+				t.Errorf("Parse(%q) = %+v, want %+v", tt.raw, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCountryCode(t *testing.T) {
+	tests := map[string]string{
+		"United States": "US", "US": "US", "usa": "US", "U.S.A.": "US",
+		"United Kingdom": "GB", "England": "GB", "GBR": "GB",
+		"Indonesia": "ID", "IDN": "ID", "Deutschland": "DE",
+		"Atlantis": "", "": "",
+	}
+	for in, want := range tests {
+		if got := CountryCode(in); got != want {
+			t.Errorf("CountryCode(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestCityName(t *testing.T) {
+	tests := map[string]string{
+		"NYC": "New York", "new york city": "New York", "SF": "San Francisco",
+		"Greater Jakarta": "Jakarta", "Bombay": "Mumbai", "Springfield": "",
+	}
+	for in, want := range tests {
+		if got := CityName(in); got != want {
+			t.Errorf("CityName(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestGazetteerKeysUnique(t *testing.T) {
+	// An alias shadowing another entry's name would make that entry
+	// unreachable.
+	seen := map[string]string{}
+	for _, c := range builtinCities {
+		for _, k := range append([]string{c.name}, c.aliases...) {
+			if other, ok := seen[key(k)]; ok {
+				t.Errorf("city key %q of %s is also a key of %s", key(k), c.name, other)
+			}
+			seen[key(k)] = c.name
+		}
+	}
+	seen = map[string]string{}
+	for _, c := range builtinCountries {
+		for _, k := range append([]string{c.code, c.name}, c.aliases...) {
+			if other, ok := seen[key(k)]; ok {
+				t.Errorf("country key %q of %s is also a key of %s", key(k), c.name, other)
+			}
+			seen[key(k)] = c.name
+		}
+	}
+}
@@ -0,0 +1,8 @@
+module github.com/learnbot/shared
+
+go 1.22.0
+
+require (
+	github.com/DATA-DOG/go-sqlmock v1.5.2
+	github.com/golang-jwt/jwt/v5 v5.2.2
+)
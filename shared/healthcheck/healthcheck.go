@@ -0,0 +1,269 @@
+// Package healthcheck serves a service's liveness and readiness probes.
+//
+// GET /livez only reports that the process is up and serving, so that an
+// orchestrator restarts a process that is not. GET /readyz runs the
+// registered dependency checks – a database ping, a backend request – and
+// returns 503 when a required one fails, so that the orchestrator stops
+// routing traffic to the instance until it recovers. A failing optional
+// check, or one reporting Degraded, only marks the instance degraded.
+//
+// Checks run concurrently, each bounded by the checker's timeout. The
+// ?exclude= parameter of /readyz skips the named checks, for debugging an
+// instance held out of rotation by one of them.
+package healthcheck
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/learnbot/shared/openapi"
+)
+
+// DefaultTimeout bounds how long a check may take before it is reported as
+// down.
+const DefaultTimeout = 2 * time.Second
+
+// Check and report statuses.
+const (
+	StatusOK       = "ok"
+	StatusDegraded = "degraded"
+	StatusDown     = "down"
+)
+
+// Probe checks one dependency. It returns nil when the dependency is
+// healthy, an error wrapped with Degraded when it works but should be
+// looked at, and any other error when it is down. It must return once ctx
+// is done.
+type Probe func(ctx context.Context) error
+
+// Check is a registered dependency check.
+type Check struct {
+	// Name identifies the check in the report and in ?exclude=.
+	Name string
+
+	// Required checks fail readiness when they are down; optional ones
+	// only degrade it.
+	Required bool
+
+	// Probe runs the check.
+	Probe Probe
+}
+
+// Result is the outcome of one check.
+type Result struct {
+	Name      string `json:"name"`
+	Status    string `json:"status"`
+	Required  bool   `json:"required"`
+	LatencyMS int64  `json:"latency_ms"`
+	Error     string `json:"error,omitempty"`
+}
+
+// Report is the readiness of a service: StatusDown when a required check is
+// down, StatusDegraded when any other check is not ok, and StatusOK
+// otherwise.
+type Report struct {
+	Status   string   `json:"status"`
+	Service  string   `json:"service"`
+	Checks   []Result `json:"checks"`
+	Excluded []string `json:"excluded,omitempty"`
+}
+
+// Checker runs a service's registered checks.
+type Checker struct {
+	service string
+	timeout time.Duration
+
+	mu     sync.RWMutex
+	checks []Check
+}
+
+// New creates a Checker for service with no checks. A non-positive timeout
+// uses DefaultTimeout.
+func New(service string, timeout time.Duration) *Checker {
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+	return &Checker{service: service, timeout: timeout}
+}
+
+// Register adds checks. Each needs a unique name.
+func (c *Checker) Register(checks ...Check) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.checks = append(c.checks, checks...)
+}
+
+// Run runs every registered check not in exclude, concurrently, and
+// reports their results in registration order.
+func (c *Checker) Run(ctx context.Context, exclude map[string]bool) Report {
+	c.mu.RLock()
+	checks := append([]Check(nil), c.checks...)
+	c.mu.RUnlock()
+
+	report := Report{Status: StatusOK, Service: c.service, Checks: []Result{}}
+	var run []Check
+	for _, check := range checks {
+		if exclude[check.Name] {
+			report.Excluded = append(report.Excluded, check.Name)
+			continue
+		}
+		run = append(run, check)
+	}
+
+	results := make([]Result, len(run))
+	var wg sync.WaitGroup
+	for i, check := range run {
+		wg.Add(1)
+		go func(i int, check Check) {
+			defer wg.Done()
+			results[i] = c.run(ctx, check)
+		}(i, check)
+	}
+	wg.Wait()
+
+	for _, r := range results {
+		switch {
+		case r.Status == StatusDown && r.Required:
+			report.Status = StatusDown
+		case r.Status != StatusOK && report.Status == StatusOK:
+			report.Status = StatusDegraded
+		}
+	}
+	report.Checks = append(report.Checks, results...)
+	return report
+}
+
+// run runs one check within the checker's timeout. A probe that overruns
+// it is reported as down without waiting for it to return.
+func (c *Checker) run(ctx context.Context, check Check) Result {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	start := time.Now()
+	done := make(chan error, 1)
+	go func() { done <- check.Probe(ctx) }()
+	var err error
+	select {
+	case err = <-done:
+	case <-ctx.Done():
+		err = fmt.Errorf("timed out after %v", c.timeout)
+	}
+
+	result := Result{
+		Name:      check.Name,
+		Status:    StatusOK,
+		Required:  check.Required,
+		LatencyMS: time.Since(start).Milliseconds(),
+	}
+	switch {
+	case IsDegraded(err):
+		result.Status, result.Error = StatusDegraded, err.Error()
+	case err != nil:
+		result.Status, result.Error = StatusDown, err.Error()
+	}
+	return result
+}
+
+// names returns the names of the registered checks.
+func (c *Checker) names() map[string]bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	names := make(map[string]bool, len(c.checks))
+	for _, check := range c.checks {
+		names[check.Name] = true
+	}
+	return names
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// HTTP
+// ─────────────────────────────────────────────────────────────────────────────
+
+// RegisterRoutes registers the probe routes on the mux.
+//
+//	GET /livez  – the process is up
+//	GET /readyz – the dependencies are healthy
+func (c *Checker) RegisterRoutes(mux openapi.Router) {
+	mux.HandleFunc("/livez", c.Live)
+	mux.HandleFunc("/readyz", c.Ready)
+}
+
+// DescribeRoutes documents the routes registered by RegisterRoutes.
+func (c *Checker) DescribeRoutes(spec *openapi.Spec) {
+	spec.Route("/livez", openapi.Operation{
+		Method:   http.MethodGet,
+		Summary:  "Liveness probe",
+		Tags:     []string{"health"},
+		Response: openapi.Fields{"status": "", "service": ""},
+	})
+	spec.Route("/readyz", openapi.Operation{
+		Method:      http.MethodGet,
+		Summary:     "Readiness probe",
+		Description: "Runs the dependency checks. Returns 503 when a required check is down.",
+		Tags:        []string{"health"},
+		Params:      []openapi.Param{openapi.Query("exclude", "Comma-separated names of checks to skip")},
+		Response:    Report{},
+		Errors:      []int{http.StatusBadRequest, http.StatusServiceUnavailable},
+	})
+}
+
+// Live handles GET /livez. It always reports ok: answering at all is the
+// signal.
+func (c *Checker) Live(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		writeJSON(w, http.StatusMethodNotAllowed, map[string]string{"error": "only GET is supported"})
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]string{"status": StatusOK, "service": c.service})
+}
+
+// Ready handles GET /readyz.
+//
+// Query parameters:
+//   - exclude: comma-separated names of checks to skip; an unknown name is
+//     rejected with 400
+//
+// The response is the Report, with status 503 when a required check is
+// down and 200 otherwise, degraded or not.
+func (c *Checker) Ready(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		writeJSON(w, http.StatusMethodNotAllowed, map[string]string{"error": "only GET is supported"})
+		return
+	}
+
+	exclude := map[string]bool{}
+	if v := r.URL.Query().Get("exclude"); v != "" {
+		known := c.names()
+		for _, name := range strings.Split(v, ",") {
+			name = strings.TrimSpace(name)
+			if name == "" {
+				continue
+			}
+			if !known[name] {
+				writeJSON(w, http.StatusBadRequest, map[string]string{"error": fmt.Sprintf("unknown check %q", name)})
+				return
+			}
+			exclude[name] = true
+		}
+	}
+
+	report := c.Run(r.Context(), exclude)
+	status := http.StatusOK
+	if report.Status == StatusDown {
+		status = http.StatusServiceUnavailable
+	}
+	w.Header().Set("Cache-Control", "no-store")
+	writeJSON(w, status, report)
+}
+
+// writeJSON writes v as a JSON response.
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
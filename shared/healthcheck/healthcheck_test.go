@@ -0,0 +1,166 @@
+package healthcheck
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+// ready requests /readyz with query and decodes the report.
+func ready(t *testing.T, c *Checker, query string) (int, Report) {
+	t.Helper()
+	w := httptest.NewRecorder()
+	c.Ready(w, httptest.NewRequest(http.MethodGet, "/readyz"+query, nil))
+	var report Report
+	if w.Code != http.StatusBadRequest {
+		if err := json.NewDecoder(w.Body).Decode(&report); err != nil {
+			t.Fatalf("decode: %v", err)
+		}
+	}
+	return w.Code, report
+}
+
+// closedDB returns a database connection that has been closed.
+func closedDB(t *testing.T) Pinger {
+	t.Helper()
+	db, _, err := sqlmock.New(sqlmock.MonitorPingsOption(true))
+	if err != nil {
+		t.Fatalf("sqlmock: %v", err)
+	}
+	db.Close()
+	return db
+}
+
+// deadBackend returns the URL of a server that is no longer listening.
+func deadBackend() string {
+	srv := httptest.NewServer(http.NotFoundHandler())
+	srv.Close()
+	return srv.URL + "/health"
+}
+
+func TestReady_ClosedDatabase(t *testing.T) {
+	c := New("learning-resources", time.Second)
+	c.Register(Check{Name: "database", Required: true, Probe: Ping(closedDB(t))})
+
+	code, report := ready(t, c, "")
+	if code != http.StatusServiceUnavailable || report.Status != StatusDown {
+		t.Fatalf("expected 503 down, got %d %s", code, report.Status)
+	}
+	if len(report.Checks) != 1 || report.Checks[0].Status != StatusDown || report.Checks[0].Error == "" ||
+		!report.Checks[0].Required || report.Service != "learning-resources" {
+		t.Errorf("unexpected report: %+v", report)
+	}
+}
+
+func TestReady_DeadBackend(t *testing.T) {
+	healthy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"status":"ok"}`))
+	}))
+	defer healthy.Close()
+
+	c := New("api-gateway", time.Second)
+	c.Register(
+		Check{Name: "resume-parser", Probe: HTTP(nil, healthy.URL)},
+		Check{Name: "job-aggregator", Probe: HTTP(nil, deadBackend())},
+	)
+
+	// An optional backend being down degrades readiness but does not fail
+	// it.
+	code, report := ready(t, c, "")
+	if code != http.StatusOK || report.Status != StatusDegraded {
+		t.Fatalf("expected 200 degraded, got %d %s", code, report.Status)
+	}
+	if report.Checks[0].Name != "resume-parser" || report.Checks[0].Status != StatusOK ||
+		report.Checks[1].Name != "job-aggregator" || report.Checks[1].Status != StatusDown {
+		t.Errorf("unexpected checks: %+v", report.Checks)
+	}
+
+	c.Register(Check{Name: "learning-resources", Required: true, Probe: HTTP(nil, deadBackend())})
+	if code, report := ready(t, c, ""); code != http.StatusServiceUnavailable || report.Status != StatusDown {
+		t.Errorf("expected 503 with a required backend down, got %d %s", code, report.Status)
+	}
+}
+
+func TestReady_Degraded(t *testing.T) {
+	c := New("job-aggregator", time.Second)
+	c.Register(
+		Check{Name: "database", Required: true, Probe: func(context.Context) error { return nil }},
+		Check{Name: "scrape_freshness", Required: true, Probe: func(context.Context) error {
+			return Degraded(errors.New("last successful scrape 3d ago"))
+		}},
+	)
+	code, report := ready(t, c, "")
+	if code != http.StatusOK || report.Status != StatusDegraded {
+		t.Fatalf("expected 200 degraded, got %d %s", code, report.Status)
+	}
+	if r := report.Checks[1]; r.Status != StatusDegraded || r.Error != "last successful scrape 3d ago" {
+		t.Errorf("unexpected freshness result: %+v", r)
+	}
+}
+
+func TestReady_Timeout(t *testing.T) {
+	c := New("learning-resources", 20*time.Millisecond)
+	block := make(chan struct{})
+	defer close(block)
+	c.Register(Check{Name: "database", Required: true, Probe: func(context.Context) error {
+		<-block // ignores its context
+		return nil
+	}})
+
+	start := time.Now()
+	code, report := ready(t, c, "")
+	if code != http.StatusServiceUnavailable || !strings.Contains(report.Checks[0].Error, "timed out") {
+		t.Errorf("expected a timed out check, got %d %+v", code, report.Checks)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Ready took %v despite the timeout", elapsed)
+	}
+}
+
+func TestReady_Exclude(t *testing.T) {
+	c := New("learning-resources", time.Second)
+	c.Register(
+		Check{Name: "database", Required: true, Probe: Ping(closedDB(t))},
+		Check{Name: "cache", Probe: func(context.Context) error { return nil }},
+	)
+
+	code, report := ready(t, c, "?exclude=database")
+	if code != http.StatusOK || report.Status != StatusOK {
+		t.Fatalf("expected 200 ok without the database check, got %d %s", code, report.Status)
+	}
+	if len(report.Checks) != 1 || report.Checks[0].Name != "cache" ||
+		len(report.Excluded) != 1 || report.Excluded[0] != "database" {
+		t.Errorf("unexpected report: %+v", report)
+	}
+
+	if code, _ := ready(t, c, "?exclude=database,redis"); code != http.StatusBadRequest {
+		t.Errorf("expected 400 for an unknown check, got %d", code)
+	}
+}
+
+func TestLive(t *testing.T) {
+	// Liveness ignores the dependencies.
+	c := New("learning-resources", time.Second)
+	c.Register(Check{Name: "database", Required: true, Probe: Ping(closedDB(t))})
+
+	mux := http.NewServeMux()
+	c.RegisterRoutes(mux)
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/livez", nil))
+	if w.Code != http.StatusOK || !strings.Contains(w.Body.String(), `"status":"ok"`) {
+		t.Errorf("expected 200 ok, got %d %s", w.Code, w.Body.String())
+	}
+
+	w = httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/livez", nil))
+	if w.Code != http.StatusMethodNotAllowed {
+		t.Errorf("expected 405 for POST, got %d", w.Code)
+	}
+}
@@ -0,0 +1,69 @@
+package healthcheck
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// maxProbeBody caps how much of a backend's health response is read.
+const maxProbeBody = 1 << 16
+
+// degradedError marks a probe error as degraded rather than down.
+type degradedError struct {
+	err error
+}
+
+func (e degradedError) Error() string { return e.err.Error() }
+
+func (e degradedError) Unwrap() error { return e.err }
+
+// Degraded wraps err so that the check reporting it is degraded rather
+// than down: it never fails readiness.
+func Degraded(err error) error {
+	return degradedError{err: err}
+}
+
+// IsDegraded reports whether err was wrapped with Degraded.
+func IsDegraded(err error) bool {
+	var d degradedError
+	return errors.As(err, &d)
+}
+
+// Pinger is a database connection; *sql.DB implements it.
+type Pinger interface {
+	PingContext(ctx context.Context) error
+}
+
+// Ping returns a Probe pinging db.
+func Ping(db Pinger) Probe {
+	return func(ctx context.Context) error {
+		return db.PingContext(ctx)
+	}
+}
+
+// HTTP returns a Probe requesting url; any 2xx response is healthy. A nil
+// client uses http.DefaultClient.
+func HTTP(client *http.Client, url string) Probe {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return func(ctx context.Context) error {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		if err != nil {
+			return err
+		}
+		resp, err := client.Do(req)
+		if err != nil {
+			return err
+		}
+		defer resp.Body.Close()
+		io.Copy(io.Discard, io.LimitReader(resp.Body, maxProbeBody))
+		if resp.StatusCode < 200 || resp.StatusCode > 299 {
+			return fmt.Errorf("unexpected status %d", resp.StatusCode)
+		}
+		return nil
+	}
+}
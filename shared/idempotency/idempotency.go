@@ -0,0 +1,354 @@
+// Package idempotency makes retried POST, PUT, PATCH and DELETE requests
+// safe. A client that sends an Idempotency-Key header gets the stored
+// response of the first request with that key instead of the request being
+// handled twice.
+//
+// Keys are scoped per caller, so two users may pick the same key. A key
+// reused with a different method, path or body is rejected with 409, as is
+// a retry that arrives while the first request is still being handled.
+// Requests without a key, or from callers the scope function does not
+// recognize, are handled as usual.
+package idempotency
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/learnbot/shared/metrics"
+)
+
+const (
+	// Header is the request header that carries the idempotency key.
+	Header = "Idempotency-Key"
+
+	// ReplayedHeader is set to "true" on replayed responses.
+	ReplayedHeader = "Idempotent-Replayed"
+
+	// MaxKeyLength is the longest accepted idempotency key.
+	MaxKeyLength = 255
+
+	// TTLEnv and MaxRequestBytesEnv are the environment variables read by
+	// ConfigFromEnv.
+	TTLEnv             = "IDEMPOTENCY_KEY_TTL"
+	MaxRequestBytesEnv = "IDEMPOTENCY_MAX_REQUEST_BYTES"
+)
+
+var requests = metrics.NewCounter("idempotency_requests_total",
+	"Requests carrying an Idempotency-Key, by outcome.", "outcome")
+
+// Config configures a Middleware. Zero fields fall back to DefaultConfig.
+type Config struct {
+	// TTL is how long a response is replayed for its key.
+	TTL time.Duration
+
+	// LockTimeout is how long a key stays reserved for a request that never
+	// completes, e.g. because its replica crashed.
+	LockTimeout time.Duration
+
+	// CleanupInterval is how often Start removes expired entries.
+	CleanupInterval time.Duration
+
+	// MaxResponseBytes is the largest response body that is stored. Larger
+	// responses are sent but not stored, so a retry is handled again.
+	MaxResponseBytes int
+
+	// MaxRequestBytes is the largest request body read to fingerprint a
+	// request with a key. Larger requests are rejected with 413.
+	MaxRequestBytes int64
+}
+
+// DefaultConfig replays responses for 24 hours, stores bodies up to 1 MiB
+// and reads request bodies up to 16 MiB, enough for a resume upload.
+func DefaultConfig() Config {
+	return Config{
+		TTL:              24 * time.Hour,
+		LockTimeout:      time.Minute,
+		CleanupInterval:  10 * time.Minute,
+		MaxResponseBytes: 1 << 20,
+		MaxRequestBytes:  16 << 20,
+	}
+}
+
+// ConfigFromEnv returns DefaultConfig with the TTL read from
+// IDEMPOTENCY_KEY_TTL, e.g. "12h", and the request body limit from
+// IDEMPOTENCY_MAX_REQUEST_BYTES.
+func ConfigFromEnv() Config {
+	cfg := DefaultConfig()
+	if d, err := time.ParseDuration(os.Getenv(TTLEnv)); err == nil && d > 0 {
+		cfg.TTL = d
+	}
+	if n, err := strconv.ParseInt(os.Getenv(MaxRequestBytesEnv), 10, 64); err == nil && n > 0 {
+		cfg.MaxRequestBytes = n
+	}
+	return cfg
+}
+
+// ScopeFunc returns the caller that owns a request's idempotency keys, e.g.
+// the authenticated user ID, or "" if the request has no known caller.
+type ScopeFunc func(r *http.Request) string
+
+// Middleware replays stored responses to retried requests.
+//
+// Store errors are logged and let the request through: an unavailable
+// store must not take the mutating endpoints down with it.
+type Middleware struct {
+	cfg    Config
+	store  Store
+	logger *slog.Logger
+	now    func() time.Time
+}
+
+// New creates a Middleware. A nil store keeps entries in memory; a nil
+// logger uses slog.Default.
+func New(cfg Config, store Store, logger *slog.Logger) *Middleware {
+	def := DefaultConfig()
+	if cfg.TTL <= 0 {
+		cfg.TTL = def.TTL
+	}
+	if cfg.LockTimeout <= 0 {
+		cfg.LockTimeout = def.LockTimeout
+	}
+	if cfg.CleanupInterval <= 0 {
+		cfg.CleanupInterval = def.CleanupInterval
+	}
+	if cfg.MaxResponseBytes <= 0 {
+		cfg.MaxResponseBytes = def.MaxResponseBytes
+	}
+	if cfg.MaxRequestBytes <= 0 {
+		cfg.MaxRequestBytes = def.MaxRequestBytes
+	}
+	if store == nil {
+		store = NewMemoryStore()
+	}
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &Middleware{cfg: cfg, store: store, logger: logger, now: time.Now}
+}
+
+// Start removes expired entries every CleanupInterval until ctx is done.
+func (m *Middleware) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(m.cfg.CleanupInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if _, err := m.store.DeleteExpired(m.now()); err != nil {
+					m.logger.Error("idempotency cleanup failed", "error", err)
+				}
+			}
+		}
+	}()
+}
+
+// Wrap handles each mutating request with an Idempotency-Key at most once
+// per key and caller, as named by scope. Server errors, 401, 403 and 429
+// responses are not stored, so the client can retry them.
+func (m *Middleware) Wrap(scope ScopeFunc, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key := r.Header.Get(Header)
+		if key == "" || !mutating(r.Method) {
+			next.ServeHTTP(w, r)
+			return
+		}
+		if len(key) > MaxKeyLength {
+			writeError(w, http.StatusBadRequest, "INVALID_IDEMPOTENCY_KEY",
+				"Idempotency-Key must be at most 255 characters")
+			return
+		}
+		owner := scope(r)
+		if owner == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, m.cfg.MaxRequestBytes))
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			writeError(w, http.StatusRequestEntityTooLarge, "REQUEST_TOO_LARGE",
+				fmt.Sprintf("request body must be at most %d bytes", tooLarge.Limit))
+			return
+		}
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "INVALID_BODY", "failed to read request body")
+			return
+		}
+		r.Body = io.NopCloser(bytes.NewReader(body))
+
+		storeKey := owner + "\x00" + key
+		fp := fingerprint(r, body)
+		now := m.now()
+		existing, reserved, err := m.store.Reserve(storeKey,
+			Entry{Fingerprint: fp, ExpiresAt: now.Add(m.cfg.LockTimeout)}, now)
+		if err != nil {
+			m.logger.ErrorContext(r.Context(), "idempotency reserve failed", "error", err)
+			next.ServeHTTP(w, r)
+			return
+		}
+		if !reserved {
+			m.answerRetry(w, existing, fp)
+			return
+		}
+
+		saved := false
+		defer func() {
+			// Release the key if the response was not stored, including
+			// when next panics.
+			if saved {
+				return
+			}
+			if err := m.store.Delete(storeKey); err != nil {
+				m.logger.ErrorContext(r.Context(), "idempotency release failed", "error", err)
+			}
+		}()
+
+		rec := &recorder{ResponseWriter: w, limit: m.cfg.MaxResponseBytes}
+		next.ServeHTTP(rec, r)
+		if !rec.storable() {
+			return
+		}
+		err = m.store.Save(storeKey, Entry{
+			Fingerprint: fp,
+			Response:    &Response{Status: rec.status, Header: rec.header, Body: rec.body.Bytes()},
+			ExpiresAt:   m.now().Add(m.cfg.TTL),
+		})
+		if err != nil {
+			m.logger.ErrorContext(r.Context(), "idempotency save failed", "error", err)
+			return
+		}
+		saved = true
+		requests.Inc("stored")
+	})
+}
+
+// WrapFunc is Wrap for handler functions.
+func (m *Middleware) WrapFunc(scope ScopeFunc, next http.HandlerFunc) http.HandlerFunc {
+	return m.Wrap(scope, next).ServeHTTP
+}
+
+// answerRetry answers a request whose key is already reserved.
+func (m *Middleware) answerRetry(w http.ResponseWriter, e Entry, fp string) {
+	switch {
+	case e.Fingerprint != fp:
+		requests.Inc("conflict")
+		writeError(w, http.StatusConflict, "IDEMPOTENCY_KEY_REUSED",
+			"Idempotency-Key was already used for a different request")
+	case e.Response == nil:
+		requests.Inc("in_progress")
+		w.Header().Set("Retry-After", "1")
+		writeError(w, http.StatusConflict, "IDEMPOTENCY_KEY_IN_USE",
+			"a request with this Idempotency-Key is still being processed")
+	default:
+		requests.Inc("replayed")
+		h := w.Header()
+		for name, values := range e.Response.Header {
+			// Headers set by outer middleware, such as the request ID,
+			// describe this request rather than the first one.
+			if _, ok := h[name]; !ok {
+				h[name] = values
+			}
+		}
+		h.Set(ReplayedHeader, "true")
+		w.WriteHeader(e.Response.Status)
+		w.Write(e.Response.Body)
+	}
+}
+
+// mutating reports whether requests with method change state.
+func mutating(method string) bool {
+	switch method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// fingerprint hashes the parts of a request that must match for a retry.
+func fingerprint(r *http.Request, body []byte) string {
+	h := sha256.New()
+	io.WriteString(h, r.Method+" "+r.URL.RequestURI()+"\n")
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// recorder passes a response through to the client and keeps a copy.
+type recorder struct {
+	http.ResponseWriter
+	limit    int
+	status   int
+	header   http.Header
+	body     bytes.Buffer
+	overflow bool
+}
+
+func (r *recorder) WriteHeader(status int) {
+	if r.status != 0 {
+		return
+	}
+	r.status = status
+	r.header = r.ResponseWriter.Header().Clone()
+	r.ResponseWriter.WriteHeader(status)
+}
+
+func (r *recorder) Write(p []byte) (int, error) {
+	if r.status == 0 {
+		r.WriteHeader(http.StatusOK)
+	}
+	if !r.overflow {
+		if r.body.Len()+len(p) > r.limit {
+			r.overflow = true
+			r.body.Reset()
+		} else {
+			r.body.Write(p)
+		}
+	}
+	return r.ResponseWriter.Write(p)
+}
+
+// Unwrap lets http.ResponseController reach the underlying writer.
+func (r *recorder) Unwrap() http.ResponseWriter {
+	return r.ResponseWriter
+}
+
+// storable reports whether the response may be replayed to retries.
+func (r *recorder) storable() bool {
+	if r.status == 0 {
+		r.status = http.StatusOK
+		r.header = r.ResponseWriter.Header().Clone()
+	}
+	switch {
+	case r.overflow, r.status >= 500:
+		return false
+	case r.status == http.StatusUnauthorized, r.status == http.StatusForbidden,
+		r.status == http.StatusTooManyRequests:
+		return false
+	}
+	return true
+}
+
+// writeError writes the standard JSON error envelope.
+func writeError(w http.ResponseWriter, status int, code, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": false,
+		"error": map[string]string{
+			"code":    code,
+			"message": message,
+		},
+	})
+}
@@ -0,0 +1,243 @@
+package idempotency
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// userScope scopes keys by the X-User-ID header.
+func userScope(r *http.Request) string { return r.Header.Get("X-User-ID") }
+
+// counting returns a handler that creates a numbered resource per call.
+func counting(calls *int64) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt64(calls, 1)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprintf(w, `{"id":%d}`, n)
+	})
+}
+
+func send(h http.Handler, method, path, user, key, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	if user != "" {
+		req.Header.Set("X-User-ID", user)
+	}
+	if key != "" {
+		req.Header.Set(Header, key)
+	}
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestWrap_ReplaysResponse(t *testing.T) {
+	var calls int64
+	h := New(Config{}, nil, nil).Wrap(userScope, counting(&calls))
+
+	first := send(h, http.MethodPost, "/progress", "u1", "k1", `{"status":"completed"}`)
+	retry := send(h, http.MethodPost, "/progress", "u1", "k1", `{"status":"completed"}`)
+
+	if calls != 1 {
+		t.Fatalf("handler called %d times, want 1", calls)
+	}
+	if retry.Code != http.StatusCreated || retry.Body.String() != first.Body.String() {
+		t.Errorf("retry got %d %q, want %d %q", retry.Code, retry.Body, first.Code, first.Body)
+	}
+	if retry.Header().Get(ReplayedHeader) != "true" || first.Header().Get(ReplayedHeader) != "" {
+		t.Errorf("replayed headers: first %q, retry %q", first.Header().Get(ReplayedHeader), retry.Header().Get(ReplayedHeader))
+	}
+	if ct := retry.Header().Get("Content-Type"); ct != "application/json" {
+		t.Errorf("retry Content-Type = %q", ct)
+	}
+}
+
+func TestWrap_RejectsDifferentRequest(t *testing.T) {
+	var calls int64
+	h := New(Config{}, nil, nil).Wrap(userScope, counting(&calls))
+
+	send(h, http.MethodPost, "/progress", "u1", "k1", `{"status":"completed"}`)
+	for _, tt := range []struct{ name, path, body string }{
+		{"different body", "/progress", `{"status":"in_progress"}`},
+		{"different path", "/resources", `{"status":"completed"}`},
+	} {
+		rec := send(h, http.MethodPost, tt.path, "u1", "k1", tt.body)
+		if rec.Code != http.StatusConflict || !strings.Contains(rec.Body.String(), "IDEMPOTENCY_KEY_REUSED") {
+			t.Errorf("%s: got %d %s, want 409 IDEMPOTENCY_KEY_REUSED", tt.name, rec.Code, rec.Body)
+		}
+	}
+	if calls != 1 {
+		t.Errorf("handler called %d times, want 1", calls)
+	}
+}
+
+func TestWrap_ConcurrentFirstRequests(t *testing.T) {
+	var calls int64
+	release := make(chan struct{})
+	slow := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+		counting(&calls).ServeHTTP(w, r)
+	})
+	h := New(Config{}, nil, nil).Wrap(userScope, slow)
+
+	const n = 10
+	codes := make(chan int, n)
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			codes <- send(h, http.MethodPost, "/resources", "u1", "k1", `{}`).Code
+		}()
+	}
+	// Every request but the one holding the key is rejected without
+	// waiting for it.
+	for i := 0; i < n-1; i++ {
+		if code := <-codes; code != http.StatusConflict {
+			t.Errorf("concurrent request got %d, want 409", code)
+		}
+	}
+	close(release)
+	wg.Wait()
+	if code := <-codes; code != http.StatusCreated {
+		t.Errorf("first request got %d, want 201", code)
+	}
+	if calls != 1 {
+		t.Errorf("handler called %d times, want 1", calls)
+	}
+	if retry := send(h, http.MethodPost, "/resources", "u1", "k1", `{}`); retry.Code != http.StatusCreated {
+		t.Errorf("retry after completion got %d, want the stored 201", retry.Code)
+	}
+}
+
+func TestWrap_ScopedPerCaller(t *testing.T) {
+	var calls int64
+	h := New(Config{}, nil, nil).Wrap(userScope, counting(&calls))
+
+	a := send(h, http.MethodPost, "/resources", "u1", "k1", `{}`)
+	b := send(h, http.MethodPost, "/resources", "u2", "k1", `{}`)
+	if calls != 2 || a.Body.String() == b.Body.String() {
+		t.Errorf("one user's key replayed another's response: %s, %s", a.Body, b.Body)
+	}
+
+	// Without a caller, or a key, or on reads, every request is handled.
+	send(h, http.MethodPost, "/resources", "", "k1", `{}`)
+	send(h, http.MethodPost, "/resources", "", "k1", `{}`)
+	send(h, http.MethodPost, "/resources", "u1", "", `{}`)
+	send(h, http.MethodGet, "/resources", "u1", "k2", "")
+	send(h, http.MethodGet, "/resources", "u1", "k2", "")
+	if calls != 7 {
+		t.Errorf("handler called %d times, want 7", calls)
+	}
+}
+
+func TestWrap_RetriesFailures(t *testing.T) {
+	for _, status := range []int{http.StatusInternalServerError, http.StatusUnauthorized, http.StatusTooManyRequests} {
+		var calls int64
+		h := New(Config{}, nil, nil).Wrap(userScope, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt64(&calls, 1)
+			w.WriteHeader(status)
+		}))
+		send(h, http.MethodPost, "/resources", "u1", "k1", `{}`)
+		send(h, http.MethodPost, "/resources", "u1", "k1", `{}`)
+		if calls != 2 {
+			t.Errorf("status %d: handler called %d times, want 2", status, calls)
+		}
+	}
+
+	// A panic releases the key too.
+	store := NewMemoryStore()
+	h := New(Config{}, store, nil).Wrap(userScope, http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+		panic("boom")
+	}))
+	func() {
+		defer func() { recover() }()
+		send(h, http.MethodPost, "/resources", "u1", "k1", `{}`)
+	}()
+	if store.Len() != 0 {
+		t.Errorf("store holds %d entries after a panic, want 0", store.Len())
+	}
+}
+
+func TestWrap_LargeResponsesNotStored(t *testing.T) {
+	var calls int64
+	h := New(Config{MaxResponseBytes: 4}, nil, nil).Wrap(userScope, counting(&calls))
+	send(h, http.MethodPost, "/resources", "u1", "k1", `{}`)
+	send(h, http.MethodPost, "/resources", "u1", "k1", `{}`)
+	if calls != 2 {
+		t.Errorf("handler called %d times, want 2", calls)
+	}
+}
+
+func TestWrap_RejectsLongKey(t *testing.T) {
+	var calls int64
+	h := New(Config{}, nil, nil).Wrap(userScope, counting(&calls))
+	rec := send(h, http.MethodPost, "/resources", "u1", strings.Repeat("k", MaxKeyLength+1), `{}`)
+	if rec.Code != http.StatusBadRequest || calls != 0 {
+		t.Errorf("got %d with %d calls, want 400 without calling the handler", rec.Code, calls)
+	}
+}
+
+func TestWrap_RejectsLargeRequest(t *testing.T) {
+	var calls int64
+	h := New(Config{MaxRequestBytes: 8}, nil, nil).Wrap(userScope, counting(&calls))
+
+	rec := send(h, http.MethodPost, "/resources", "u1", "k1", `{"title":"too long"}`)
+	if rec.Code != http.StatusRequestEntityTooLarge || calls != 0 || !strings.Contains(rec.Body.String(), "REQUEST_TOO_LARGE") {
+		t.Errorf("got %d %s with %d calls, want 413 without calling the handler", rec.Code, rec.Body, calls)
+	}
+	if rec := send(h, http.MethodPost, "/resources", "u1", "k1", `{}`); rec.Code != http.StatusCreated {
+		t.Errorf("small request: got %d, want 201", rec.Code)
+	}
+	// Requests without a key are not read by the middleware.
+	if rec := send(h, http.MethodPost, "/resources", "u1", "", `{"title":"too long"}`); rec.Code != http.StatusCreated {
+		t.Errorf("request without a key: got %d, want 201", rec.Code)
+	}
+}
+
+func TestWrap_Expiry(t *testing.T) {
+	var calls int64
+	store := NewMemoryStore()
+	m := New(Config{TTL: time.Hour}, store, nil)
+	now := time.Now()
+	m.now = func() time.Time { return now }
+	h := m.Wrap(userScope, counting(&calls))
+
+	send(h, http.MethodPost, "/resources", "u1", "k1", `{}`)
+	now = now.Add(2 * time.Hour)
+	send(h, http.MethodPost, "/resources", "u1", "k1", `{}`)
+	if calls != 2 {
+		t.Errorf("handler called %d times after the key expired, want 2", calls)
+	}
+
+	now = now.Add(2 * time.Hour)
+	if n, _ := store.DeleteExpired(now); n != 1 || store.Len() != 0 {
+		t.Errorf("DeleteExpired removed %d, %d left; want 1 removed, none left", n, store.Len())
+	}
+}
+
+func TestConfigFromEnv(t *testing.T) {
+	t.Setenv(TTLEnv, "2h")
+	if got := ConfigFromEnv().TTL; got != 2*time.Hour {
+		t.Errorf("TTL = %v, want 2h", got)
+	}
+	t.Setenv(TTLEnv, "")
+	if got := ConfigFromEnv().TTL; got != DefaultConfig().TTL {
+		t.Errorf("TTL = %v, want the default", got)
+	}
+
+	t.Setenv(MaxRequestBytesEnv, "1024")
+	if got := ConfigFromEnv().MaxRequestBytes; got != 1024 {
+		t.Errorf("MaxRequestBytes = %d, want 1024", got)
+	}
+	t.Setenv(MaxRequestBytesEnv, "lots")
+	if got := ConfigFromEnv().MaxRequestBytes; got != DefaultConfig().MaxRequestBytes {
+		t.Errorf("MaxRequestBytes = %d, want the default", got)
+	}
+}
@@ -0,0 +1,111 @@
+package idempotency
+
+import (
+	"net/http"
+	"sync"
+	"time"
+)
+
+// Response is a stored response, replayed to retries.
+type Response struct {
+	Status int
+	Header http.Header
+	Body   []byte
+}
+
+// Entry is the state of one idempotency key.
+type Entry struct {
+	// Fingerprint identifies the request that first used the key: a hash
+	// of its method, path and body.
+	Fingerprint string
+
+	// Response is the first request's response, or nil while that request
+	// is still being handled.
+	Response *Response
+
+	// ExpiresAt is when the key may be used for a new request.
+	ExpiresAt time.Time
+}
+
+// Store keeps idempotency entries by key. Keys already include the caller's
+// scope.
+type Store interface {
+	// Reserve saves e under key unless key holds an entry that has not
+	// expired at now, and reports whether it did. Otherwise it returns the
+	// existing entry. Concurrent calls for one key must reserve it at most
+	// once.
+	Reserve(key string, e Entry, now time.Time) (Entry, bool, error)
+
+	// Save replaces the entry under key.
+	Save(key string, e Entry) error
+
+	// Delete removes key, so that the next request with it is handled.
+	Delete(key string) error
+
+	// DeleteExpired removes the entries that have expired at now and
+	// returns how many it removed.
+	DeleteExpired(now time.Time) (int, error)
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// In-memory store (MVP – replace with a shared store when running replicas)
+// ─────────────────────────────────────────────────────────────────────────────
+
+// MemoryStore is a thread-safe in-memory Store.
+type MemoryStore struct {
+	mu      sync.Mutex
+	entries map[string]Entry
+}
+
+// NewMemoryStore creates an empty MemoryStore.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{entries: make(map[string]Entry)}
+}
+
+// Reserve implements Store.
+func (s *MemoryStore) Reserve(key string, e Entry, now time.Time) (Entry, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if existing, ok := s.entries[key]; ok && existing.ExpiresAt.After(now) {
+		return existing, false, nil
+	}
+	s.entries[key] = e
+	return e, true, nil
+}
+
+// Save implements Store.
+func (s *MemoryStore) Save(key string, e Entry) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.entries[key] = e
+	return nil
+}
+
+// Delete implements Store.
+func (s *MemoryStore) Delete(key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.entries, key)
+	return nil
+}
+
+// DeleteExpired implements Store.
+func (s *MemoryStore) DeleteExpired(now time.Time) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	n := 0
+	for key, e := range s.entries {
+		if !e.ExpiresAt.After(now) {
+			delete(s.entries, key)
+			n++
+		}
+	}
+	return n, nil
+}
+
+// Len returns the number of stored entries, expired or not.
+func (s *MemoryStore) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.entries)
+}
@@ -0,0 +1,72 @@
+// Package logging provides the structured JSON logger shared by the
+// services, and the request ID plumbing that lets one request be followed
+// across them.
+//
+// Every record carries time, level, msg and service; records logged with a
+// context that holds a request ID also carry request_id.
+package logging
+
+import (
+	"context"
+	"io"
+	"log"
+	"log/slog"
+	"os"
+	"strings"
+)
+
+// Config holds logger settings.
+type Config struct {
+	// Level is the minimum level logged.
+	Level slog.Level
+	// Format is "json" (the default) or "text" for local development.
+	Format string
+}
+
+// ConfigFromEnv reads LOG_LEVEL (debug, info, warn or error) and LOG_FORMAT.
+func ConfigFromEnv() Config {
+	var cfg Config
+	if err := cfg.Level.UnmarshalText([]byte(os.Getenv("LOG_LEVEL"))); err != nil {
+		cfg.Level = slog.LevelInfo
+	}
+	cfg.Format = strings.ToLower(os.Getenv("LOG_FORMAT"))
+	return cfg
+}
+
+// New returns a logger for service that writes one record per line to w.
+func New(service string, w io.Writer, cfg Config) *slog.Logger {
+	opts := &slog.HandlerOptions{Level: cfg.Level}
+	var h slog.Handler
+	if cfg.Format == "text" {
+		h = slog.NewTextHandler(w, opts)
+	} else {
+		h = slog.NewJSONHandler(w, opts)
+	}
+	return slog.New(contextHandler{h}).With("service", service)
+}
+
+// StdLogger adapts l for code that takes a *log.Logger. Each line is logged
+// at info level as the record's message.
+func StdLogger(l *slog.Logger) *log.Logger {
+	return slog.NewLogLogger(l.Handler(), slog.LevelInfo)
+}
+
+// contextHandler adds the request ID from the record's context.
+type contextHandler struct {
+	slog.Handler
+}
+
+func (h contextHandler) Handle(ctx context.Context, r slog.Record) error {
+	if id := RequestID(ctx); id != "" {
+		r.AddAttrs(slog.String("request_id", id))
+	}
+	return h.Handler.Handle(ctx, r)
+}
+
+func (h contextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return contextHandler{h.Handler.WithAttrs(attrs)}
+}
+
+func (h contextHandler) WithGroup(name string) slog.Handler {
+	return contextHandler{h.Handler.WithGroup(name)}
+}
@@ -0,0 +1,135 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func decodeLines(t *testing.T, buf *bytes.Buffer) []map[string]interface{} {
+	t.Helper()
+	var out []map[string]interface{}
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		if line == "" {
+			continue
+		}
+		var rec map[string]interface{}
+		if err := json.Unmarshal([]byte(line), &rec); err != nil {
+			t.Fatalf("log line is not JSON: %q", line)
+		}
+		out = append(out, rec)
+	}
+	return out
+}
+
+func TestNew_JSONRecord(t *testing.T) {
+	var buf bytes.Buffer
+	logger := New("test-service", &buf, Config{})
+	ctx := WithRequestID(context.Background(), "req-1")
+	logger.InfoContext(ctx, "hello", "n", 1)
+	StdLogger(logger).Printf("legacy %s", "line")
+
+	recs := decodeLines(t, &buf)
+	if len(recs) != 2 {
+		t.Fatalf("expected 2 records, got %d", len(recs))
+	}
+	first := recs[0]
+	for key, want := range map[string]interface{}{"msg": "hello", "level": "INFO", "service": "test-service", "request_id": "req-1"} {
+		if first[key] != want {
+			t.Errorf("%s: expected %v, got %v", key, want, first[key])
+		}
+	}
+	if _, ok := first["time"]; !ok {
+		t.Error("expected a time field")
+	}
+	if recs[1]["msg"] != "legacy line" || recs[1]["service"] != "test-service" {
+		t.Errorf("unexpected legacy record: %v", recs[1])
+	}
+}
+
+func TestRequestIDMiddleware(t *testing.T) {
+	var seen string
+	h := RequestIDMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		seen = RequestID(r.Context())
+	}))
+
+	for _, tt := range []struct {
+		name     string
+		incoming string
+		keep     bool
+	}{
+		{"absent", "", false},
+		{"valid", "abc-123_x.y", true},
+		{"injection", "abc\" level=ERROR", false},
+		{"too long", strings.Repeat("a", maxRequestIDLength+1), false},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tt.incoming != "" {
+				req.Header.Set(RequestIDHeader, tt.incoming)
+			}
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, req)
+
+			if tt.keep && seen != tt.incoming {
+				t.Errorf("expected incoming ID to be kept, got %q", seen)
+			}
+			if !tt.keep && (seen == tt.incoming || len(seen) != 32) {
+				t.Errorf("expected a generated ID, got %q", seen)
+			}
+			if got := w.Header().Get(RequestIDHeader); got != seen {
+				t.Errorf("response header %q does not match context ID %q", got, seen)
+			}
+		})
+	}
+}
+
+// TestRequestIDAcrossServices follows one request from an edge service
+// through a downstream call and checks both log the same ID.
+func TestRequestIDAcrossServices(t *testing.T) {
+	var downstreamLog, edgeLog bytes.Buffer
+
+	downstream := httptest.NewServer(Handler(New("downstream", &downstreamLog, Config{}),
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusAccepted)
+		})))
+	defer downstream.Close()
+
+	client := &http.Client{Transport: Transport(nil)}
+	edge := httptest.NewServer(Handler(New("edge", &edgeLog, Config{}),
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			req, _ := http.NewRequestWithContext(r.Context(), http.MethodGet, downstream.URL+"/work", nil)
+			resp, err := client.Do(req)
+			if err != nil {
+				t.Errorf("downstream call: %v", err)
+				return
+			}
+			resp.Body.Close()
+		})))
+	defer edge.Close()
+
+	resp, err := http.Get(edge.URL + "/start")
+	if err != nil {
+		t.Fatalf("GET: %v", err)
+	}
+	resp.Body.Close()
+	id := resp.Header.Get(RequestIDHeader)
+	if id == "" {
+		t.Fatal("expected a generated request ID in the response")
+	}
+
+	edgeRecs, downRecs := decodeLines(t, &edgeLog), decodeLines(t, &downstreamLog)
+	if len(edgeRecs) != 1 || len(downRecs) != 1 {
+		t.Fatalf("expected one access log line per service, got %d and %d", len(edgeRecs), len(downRecs))
+	}
+	if edgeRecs[0]["request_id"] != id || downRecs[0]["request_id"] != id {
+		t.Errorf("request ID %q not logged by both services: %v / %v", id, edgeRecs[0], downRecs[0])
+	}
+	if downRecs[0]["path"] != "/work" || downRecs[0]["status"] != float64(http.StatusAccepted) {
+		t.Errorf("unexpected downstream access log: %v", downRecs[0])
+	}
+}
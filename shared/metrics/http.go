@@ -0,0 +1,80 @@
+package metrics
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+)
+
+var (
+	httpRequests = NewCounter("http_requests_total",
+		"HTTP requests handled, by method, route pattern and status code.",
+		"method", "route", "status")
+	httpDuration = NewHistogram("http_request_duration_seconds",
+		"HTTP request latency in seconds, by method, route pattern and status code.",
+		nil, "method", "route", "status")
+)
+
+// unmatchedRoute labels requests that no registered pattern matches.
+const unmatchedRoute = "unmatched"
+
+// Middleware records http_requests_total and http_request_duration_seconds
+// for every request. Requests are labeled with the pattern routes would
+// dispatch them to, so path parameters do not create new series.
+func Middleware(routes *http.ServeMux) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+			rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+			next.ServeHTTP(rec, r)
+
+			route := unmatchedRoute
+			if _, pattern := routes.Handler(r); pattern != "" {
+				route = pattern
+			}
+			labels := []string{methodLabel(r.Method), route, strconv.Itoa(rec.status)}
+			httpRequests.Inc(labels...)
+			httpDuration.Observe(time.Since(start).Seconds(), labels...)
+		})
+	}
+}
+
+// Instrument wraps mux with Middleware.
+func Instrument(mux *http.ServeMux) http.Handler {
+	return Middleware(mux)(mux)
+}
+
+// methodLabel folds non-standard methods together so clients cannot create
+// arbitrary series.
+func methodLabel(method string) string {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodPost, http.MethodPut,
+		http.MethodPatch, http.MethodDelete, http.MethodOptions:
+		return method
+	}
+	return "OTHER"
+}
+
+// statusRecorder captures the status code written by a handler.
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (r *statusRecorder) WriteHeader(code int) {
+	r.status = code
+	r.ResponseWriter.WriteHeader(code)
+}
+
+// Flush lets streaming handlers keep working behind Middleware.
+func (r *statusRecorder) Flush() {
+	if f, ok := r.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// Unwrap returns the underlying ResponseWriter so http.ResponseController
+// can reach it, e.g. to extend the write deadline of a long download.
+func (r *statusRecorder) Unwrap() http.ResponseWriter {
+	return r.ResponseWriter
+}
@@ -0,0 +1,182 @@
+package metrics
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestRegistry_TextFormat(t *testing.T) {
+	reg := NewRegistry()
+	runs := reg.NewCounter("runs_total", "Runs.\nSecond line.", "scraper")
+	latency := reg.NewHistogram("latency_seconds", "Latency.", []float64{1, 0.1}, "scraper")
+
+	runs.Inc(`a"b`)
+	runs.Add(2, `a"b`)
+	latency.Observe(0.05, "x")
+	latency.Observe(0.5, "x")
+	latency.Observe(3, "x")
+
+	var b strings.Builder
+	reg.WriteText(&b)
+	want := `# HELP latency_seconds Latency.
+# TYPE latency_seconds histogram
+latency_seconds_bucket{scraper="x",le="0.1"} 1
+latency_seconds_bucket{scraper="x",le="1"} 2
+latency_seconds_bucket{scraper="x",le="+Inf"} 3
+latency_seconds_sum{scraper="x"} 3.55
+latency_seconds_count{scraper="x"} 3
+# HELP runs_total Runs.\nSecond line.
+# TYPE runs_total counter
+runs_total{scraper="a\"b"} 3
+`
+	if got := b.String(); got != want {
+		t.Errorf("unexpected exposition:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestRegistry_Panics(t *testing.T) {
+	reg := NewRegistry()
+	c := reg.NewCounter("dup_total", "Dup.", "a")
+
+	for name, fn := range map[string]func(){
+		"duplicate name":  func() { reg.NewCounter("dup_total", "Dup.") },
+		"label mismatch":  func() { c.Inc() },
+		"negative amount": func() { c.Add(-1, "x") },
+	} {
+		t.Run(name, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Error("expected a panic")
+				}
+			}()
+			fn()
+		})
+	}
+}
+
+func TestMiddleware_Scrape(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v1/items/", func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/missing") {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte("ok"))
+	})
+	mux.Handle("/metrics", Handler())
+	srv := httptest.NewServer(Instrument(mux))
+	defer srv.Close()
+
+	for _, path := range []string{"/api/v1/items/1", "/api/v1/items/2", "/api/v1/items/missing", "/nope"} {
+		resp, err := http.Get(srv.URL + path)
+		if err != nil {
+			t.Fatalf("GET %s: %v", path, err)
+		}
+		resp.Body.Close()
+	}
+
+	resp, err := http.Get(srv.URL + "/metrics")
+	if err != nil {
+		t.Fatalf("GET /metrics: %v", err)
+	}
+	defer resp.Body.Close()
+	if ct := resp.Header.Get("Content-Type"); !strings.HasPrefix(ct, "text/plain; version=0.0.4") {
+		t.Errorf("unexpected content type %q", ct)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	for _, want := range []string{
+		"# TYPE http_requests_total counter",
+		"# TYPE http_request_duration_seconds histogram",
+		`http_requests_total{method="GET",route="/api/v1/items/",status="200"} 2`,
+		`http_requests_total{method="GET",route="/api/v1/items/",status="404"} 1`,
+		`http_requests_total{method="GET",route="unmatched",status="404"} 1`,
+		`http_request_duration_seconds_count{method="GET",route="/api/v1/items/",status="200"} 2`,
+		"# TYPE db_query_errors_total counter",
+	} {
+		if !strings.Contains(string(body), want) {
+			t.Errorf("metrics output missing %q", want)
+		}
+	}
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// OpenDB
+// ─────────────────────────────────────────────────────────────────────────────
+
+var errQuery = errors.New("relation does not exist")
+
+// failingDriver is a minimal driver whose queries fail unless they are
+// "SELECT 1".
+type failingDriver struct{}
+
+func (failingDriver) Open(string) (driver.Conn, error) { return failingConn{}, nil }
+
+type failingConn struct{}
+
+func (failingConn) Prepare(string) (driver.Stmt, error) { return nil, errQuery }
+func (failingConn) Close() error                        { return nil }
+func (failingConn) Begin() (driver.Tx, error)           { return nil, errQuery }
+
+func (failingConn) QueryContext(_ context.Context, query string, _ []driver.NamedValue) (driver.Rows, error) {
+	if query == "SELECT 1" {
+		return &oneRow{}, nil
+	}
+	return nil, errQuery
+}
+
+type oneRow struct{ done bool }
+
+func (*oneRow) Columns() []string { return []string{"n"} }
+func (*oneRow) Close() error      { return nil }
+func (r *oneRow) Next(dest []driver.Value) error {
+	if r.done {
+		return io.EOF
+	}
+	r.done = true
+	dest[0] = int64(1)
+	return nil
+}
+
+func init() {
+	sql.Register("metrics-failing", failingDriver{})
+}
+
+func TestOpenDB_CountsErrors(t *testing.T) {
+	db, err := OpenDB("metrics-failing", "")
+	if err != nil {
+		t.Fatalf("OpenDB: %v", err)
+	}
+	defer db.Close()
+	ctx := context.Background()
+	queries, begins := dbErrors.Value("query"), dbErrors.Value("begin")
+
+	var n int
+	if err := db.QueryRowContext(ctx, "SELECT 1").Scan(&n); err != nil || n != 1 {
+		t.Fatalf("SELECT 1: n=%d err=%v", n, err)
+	}
+	if err := db.QueryRowContext(ctx, "SELECT * FROM missing").Scan(&n); !errors.Is(err, errQuery) {
+		t.Fatalf("expected the driver error, got %v", err)
+	}
+	if _, err := db.BeginTx(ctx, nil); !errors.Is(err, errQuery) {
+		t.Fatalf("expected the driver error from BeginTx, got %v", err)
+	}
+	if got := dbErrors.Value("query") - queries; got != 1 {
+		t.Errorf("expected 1 query error, got %v", got)
+	}
+	if got := dbErrors.Value("begin") - begins; got != 1 {
+		t.Errorf("expected 1 begin error, got %v", got)
+	}
+}
+
+func TestOpenDB_UnknownDriver(t *testing.T) {
+	if _, err := OpenDB("no-such-driver", ""); err == nil {
+		t.Error("expected an error for an unregistered driver")
+	}
+}
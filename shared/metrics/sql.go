@@ -0,0 +1,213 @@
+package metrics
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+)
+
+var dbErrors = NewCounter("db_query_errors_total",
+	"Database operations that returned an error, by operation.",
+	"operation")
+
+// OpenDB is a drop-in replacement for sql.Open that counts failed queries,
+// statements and transactions in db_query_errors_total. Every repository
+// built on the returned pool is covered without changes to its methods.
+func OpenDB(driverName, dsn string) (*sql.DB, error) {
+	// sql.Open only looks up the driver; it does not connect.
+	probe, err := sql.Open(driverName, dsn)
+	if err != nil {
+		return nil, err
+	}
+	drv := probe.Driver()
+	probe.Close()
+
+	var base driver.Connector
+	if dc, ok := drv.(driver.DriverContext); ok {
+		if base, err = dc.OpenConnector(dsn); err != nil {
+			return nil, fmt.Errorf("open connector: %w", err)
+		}
+	} else {
+		base = dsnConnector{dsn: dsn, drv: drv}
+	}
+	return sql.OpenDB(connector{base}), nil
+}
+
+// countError records err under operation unless it is nil or one of the
+// sentinels database/sql uses for control flow.
+func countError(operation string, err error) error {
+	if err != nil && !errors.Is(err, driver.ErrSkip) && !errors.Is(err, driver.ErrRemoveArgument) {
+		dbErrors.Inc(operation)
+	}
+	return err
+}
+
+// dsnConnector adapts drivers that do not implement driver.DriverContext.
+type dsnConnector struct {
+	dsn string
+	drv driver.Driver
+}
+
+func (c dsnConnector) Connect(context.Context) (driver.Conn, error) { return c.drv.Open(c.dsn) }
+func (c dsnConnector) Driver() driver.Driver                        { return c.drv }
+
+type connector struct {
+	driver.Connector
+}
+
+func (c connector) Connect(ctx context.Context) (driver.Conn, error) {
+	cn, err := c.Connector.Connect(ctx)
+	if err != nil {
+		return nil, countError("connect", err)
+	}
+	return &conn{cn}, nil
+}
+
+// conn forwards every optional driver interface to the wrapped connection,
+// falling back to what database/sql would do when it is missing.
+type conn struct {
+	driver.Conn
+}
+
+func (c *conn) Prepare(query string) (driver.Stmt, error) {
+	return c.PrepareContext(context.Background(), query)
+}
+
+func (c *conn) PrepareContext(ctx context.Context, query string) (driver.Stmt, error) {
+	var (
+		st  driver.Stmt
+		err error
+	)
+	if p, ok := c.Conn.(driver.ConnPrepareContext); ok {
+		st, err = p.PrepareContext(ctx, query)
+	} else {
+		st, err = c.Conn.Prepare(query)
+	}
+	if err != nil {
+		return nil, countError("prepare", err)
+	}
+	return &stmt{st}, nil
+}
+
+func (c *conn) Begin() (driver.Tx, error) {
+	return c.BeginTx(context.Background(), driver.TxOptions{})
+}
+
+func (c *conn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	var (
+		t   driver.Tx
+		err error
+	)
+	if b, ok := c.Conn.(driver.ConnBeginTx); ok {
+		t, err = b.BeginTx(ctx, opts)
+	} else {
+		t, err = c.Conn.Begin()
+	}
+	if err != nil {
+		return nil, countError("begin", err)
+	}
+	return tx{t}, nil
+}
+
+func (c *conn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	q, ok := c.Conn.(driver.QueryerContext)
+	if !ok {
+		return nil, driver.ErrSkip
+	}
+	rows, err := q.QueryContext(ctx, query, args)
+	return rows, countError("query", err)
+}
+
+func (c *conn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	e, ok := c.Conn.(driver.ExecerContext)
+	if !ok {
+		return nil, driver.ErrSkip
+	}
+	res, err := e.ExecContext(ctx, query, args)
+	return res, countError("exec", err)
+}
+
+func (c *conn) Ping(ctx context.Context) error {
+	if p, ok := c.Conn.(driver.Pinger); ok {
+		return countError("ping", p.Ping(ctx))
+	}
+	return nil
+}
+
+func (c *conn) CheckNamedValue(nv *driver.NamedValue) error {
+	if ch, ok := c.Conn.(driver.NamedValueChecker); ok {
+		return ch.CheckNamedValue(nv)
+	}
+	return driver.ErrSkip
+}
+
+func (c *conn) ResetSession(ctx context.Context) error {
+	if r, ok := c.Conn.(driver.SessionResetter); ok {
+		return r.ResetSession(ctx)
+	}
+	return nil
+}
+
+func (c *conn) IsValid() bool {
+	if v, ok := c.Conn.(driver.Validator); ok {
+		return v.IsValid()
+	}
+	return true
+}
+
+type tx struct {
+	driver.Tx
+}
+
+func (t tx) Commit() error   { return countError("commit", t.Tx.Commit()) }
+func (t tx) Rollback() error { return countError("rollback", t.Tx.Rollback()) }
+
+type stmt struct {
+	driver.Stmt
+}
+
+func (s *stmt) ExecContext(ctx context.Context, args []driver.NamedValue) (driver.Result, error) {
+	if e, ok := s.Stmt.(driver.StmtExecContext); ok {
+		res, err := e.ExecContext(ctx, args)
+		return res, countError("exec", err)
+	}
+	values, err := namedToValues(args)
+	if err != nil {
+		return nil, err
+	}
+	res, err := s.Stmt.Exec(values)
+	return res, countError("exec", err)
+}
+
+func (s *stmt) QueryContext(ctx context.Context, args []driver.NamedValue) (driver.Rows, error) {
+	if q, ok := s.Stmt.(driver.StmtQueryContext); ok {
+		rows, err := q.QueryContext(ctx, args)
+		return rows, countError("query", err)
+	}
+	values, err := namedToValues(args)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := s.Stmt.Query(values)
+	return rows, countError("query", err)
+}
+
+func (s *stmt) CheckNamedValue(nv *driver.NamedValue) error {
+	if ch, ok := s.Stmt.(driver.NamedValueChecker); ok {
+		return ch.CheckNamedValue(nv)
+	}
+	return driver.ErrSkip
+}
+
+func namedToValues(args []driver.NamedValue) ([]driver.Value, error) {
+	values := make([]driver.Value, len(args))
+	for i, a := range args {
+		if a.Name != "" {
+			return nil, errors.New("metrics: driver does not support named parameters")
+		}
+		values[i] = a.Value
+	}
+	return values, nil
+}
@@ -0,0 +1,268 @@
+// Package migrate applies the SQL schema migrations embedded in a service
+// binary. Migrations are files named NNN_description.sql, applied in
+// version order and recorded in a per-service table, so that services
+// sharing one database keep separate histories.
+//
+// Each file runs as a single multi-statement script and manages its own
+// transaction with BEGIN/COMMIT; this lets migrations such as ALTER TYPE
+// ... ADD VALUE run outside a transaction block where PostgreSQL requires
+// it. Only up migrations are supported.
+package migrate
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"io/fs"
+	"log"
+	"regexp"
+	"sort"
+	"strconv"
+)
+
+// ErrDatabaseAhead is returned when the database has migrations applied
+// that the binary does not know, which means it was migrated by a newer
+// release. Running an older binary against it is refused.
+var ErrDatabaseAhead = errors.New("database schema is newer than this binary")
+
+// Migration is one schema migration file.
+type Migration struct {
+	// Version is the number the file name starts with.
+	Version int
+	// Name is the file name.
+	Name string
+	// SQL is the file content.
+	SQL string
+}
+
+var (
+	fileNameRE  = regexp.MustCompile(`^(\d+)_[A-Za-z0-9_]+\.sql$`)
+	tableNameRE = regexp.MustCompile(`^[a-z_][a-z0-9_]*$`)
+)
+
+// Load reads the migrations at the root of fsys, ordered by version. Files
+// that do not end in .sql are ignored; a .sql file whose name does not
+// start with a version, or two files with the same version, are errors.
+func Load(fsys fs.FS) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, fmt.Errorf("read migrations: %w", err)
+	}
+
+	var migrations []Migration
+	seen := make(map[int]string)
+	for _, e := range entries {
+		name := e.Name()
+		if e.IsDir() || len(name) < 4 || name[len(name)-4:] != ".sql" {
+			continue
+		}
+		m := fileNameRE.FindStringSubmatch(name)
+		if m == nil {
+			return nil, fmt.Errorf("migration %s: file name must look like 001_description.sql", name)
+		}
+		version, err := strconv.Atoi(m[1])
+		if err != nil || version <= 0 {
+			return nil, fmt.Errorf("migration %s: invalid version %q", name, m[1])
+		}
+		if other, ok := seen[version]; ok {
+			return nil, fmt.Errorf("migrations %s and %s have the same version %d", other, name, version)
+		}
+		seen[version] = name
+
+		content, err := fs.ReadFile(fsys, name)
+		if err != nil {
+			return nil, fmt.Errorf("read migration %s: %w", name, err)
+		}
+		migrations = append(migrations, Migration{Version: version, Name: name, SQL: string(content)})
+	}
+
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+	return migrations, nil
+}
+
+// Migrator applies migrations to one database.
+type Migrator struct {
+	db         *sql.DB
+	migrations []Migration
+	table      string
+	lockKey    int64
+	logger     *log.Logger
+}
+
+// New creates a Migrator for the migrations in fsys (see Load), recording
+// applied versions in table. On PostgreSQL, Up holds an advisory lock
+// derived from table so that replicas starting together apply each
+// migration once; call DisableAdvisoryLock for other databases.
+func New(db *sql.DB, fsys fs.FS, table string, logger *log.Logger) (*Migrator, error) {
+	if !tableNameRE.MatchString(table) {
+		return nil, fmt.Errorf("invalid migrations table name %q", table)
+	}
+	migrations, err := Load(fsys)
+	if err != nil {
+		return nil, err
+	}
+	h := fnv.New64a()
+	h.Write([]byte(table))
+	return &Migrator{
+		db:         db,
+		migrations: migrations,
+		table:      table,
+		lockKey:    int64(h.Sum64()),
+		logger:     logger,
+	}, nil
+}
+
+// DisableAdvisoryLock stops Up from taking the PostgreSQL advisory lock,
+// for databases such as SQLite that have none.
+func (m *Migrator) DisableAdvisoryLock() {
+	m.lockKey = 0
+}
+
+// Latest returns the highest version known to the binary, or 0 if there
+// are no migrations.
+func (m *Migrator) Latest() int {
+	if len(m.migrations) == 0 {
+		return 0
+	}
+	return m.migrations[len(m.migrations)-1].Version
+}
+
+// Pending returns the migrations not yet applied to the database, or
+// ErrDatabaseAhead if the database has a version the binary does not know.
+// It creates the migrations table if it is missing.
+func (m *Migrator) Pending(ctx context.Context) ([]Migration, error) {
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get connection: %w", err)
+	}
+	defer conn.Close()
+	return m.pending(ctx, conn)
+}
+
+// Up applies the pending migrations in version order and returns how many
+// it applied. It stops at the first failing migration, leaving the later
+// ones pending. Up returns ErrDatabaseAhead without applying anything if
+// the database is newer than the binary.
+func (m *Migrator) Up(ctx context.Context) (int, error) {
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("get connection: %w", err)
+	}
+	defer conn.Close()
+
+	// The lock is held by the session, so every statement below runs on
+	// the same connection.
+	if m.lockKey != 0 {
+		if _, err := conn.ExecContext(ctx, `SELECT pg_advisory_lock($1)`, m.lockKey); err != nil {
+			return 0, fmt.Errorf("acquire migration lock: %w", err)
+		}
+		defer conn.ExecContext(context.Background(), `SELECT pg_advisory_unlock($1)`, m.lockKey) //nolint:errcheck
+	}
+
+	pending, err := m.pending(ctx, conn)
+	if err != nil {
+		return 0, err
+	}
+	for i, mig := range pending {
+		if err := m.apply(ctx, conn, mig); err != nil {
+			return i, err
+		}
+		m.logger.Printf("applied migration %s", mig.Name)
+	}
+	return len(pending), nil
+}
+
+// Baseline records every migration up to and including version as applied
+// without running it, for databases whose schema was created by hand
+// before the service applied its own migrations.
+func (m *Migrator) Baseline(ctx context.Context, version int) error {
+	if version > m.Latest() {
+		return fmt.Errorf("baseline version %d is newer than the latest migration %d", version, m.Latest())
+	}
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return fmt.Errorf("get connection: %w", err)
+	}
+	defer conn.Close()
+
+	pending, err := m.pending(ctx, conn)
+	if err != nil {
+		return err
+	}
+	for _, mig := range pending {
+		if mig.Version > version {
+			break
+		}
+		if err := m.record(ctx, conn, mig); err != nil {
+			return err
+		}
+		m.logger.Printf("recorded migration %s as applied", mig.Name)
+	}
+	return nil
+}
+
+// pending implements Pending on conn.
+func (m *Migrator) pending(ctx context.Context, conn *sql.Conn) ([]Migration, error) {
+	if _, err := conn.ExecContext(ctx, fmt.Sprintf(`
+		CREATE TABLE IF NOT EXISTS %s (
+			version    INTEGER PRIMARY KEY,
+			name       TEXT NOT NULL,
+			applied_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+		)`, m.table)); err != nil {
+		return nil, fmt.Errorf("create %s: %w", m.table, err)
+	}
+
+	rows, err := conn.QueryContext(ctx, fmt.Sprintf(`SELECT version FROM %s`, m.table))
+	if err != nil {
+		return nil, fmt.Errorf("list applied migrations: %w", err)
+	}
+	defer rows.Close()
+
+	applied := make(map[int]bool)
+	newest := 0
+	for rows.Next() {
+		var v int
+		if err := rows.Scan(&v); err != nil {
+			return nil, fmt.Errorf("scan applied migration: %w", err)
+		}
+		applied[v] = true
+		newest = max(newest, v)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("list applied migrations: %w", err)
+	}
+	if newest > m.Latest() {
+		return nil, fmt.Errorf("%w: database is at version %d, binary knows up to %d", ErrDatabaseAhead, newest, m.Latest())
+	}
+
+	var pending []Migration
+	for _, mig := range m.migrations {
+		if !applied[mig.Version] {
+			pending = append(pending, mig)
+		}
+	}
+	return pending, nil
+}
+
+// apply runs one migration script and records it.
+func (m *Migrator) apply(ctx context.Context, conn *sql.Conn, mig Migration) error {
+	if _, err := conn.ExecContext(ctx, mig.SQL); err != nil {
+		// A failed statement inside the script's BEGIN leaves the session
+		// in an aborted transaction.
+		conn.ExecContext(context.Background(), `ROLLBACK`) //nolint:errcheck
+		return fmt.Errorf("apply migration %s: %w", mig.Name, err)
+	}
+	return m.record(ctx, conn, mig)
+}
+
+// record adds mig to the migrations table.
+func (m *Migrator) record(ctx context.Context, conn *sql.Conn, mig Migration) error {
+	if _, err := conn.ExecContext(ctx,
+		fmt.Sprintf(`INSERT INTO %s (version, name) VALUES ($1, $2)`, m.table), mig.Version, mig.Name,
+	); err != nil {
+		return fmt.Errorf("record migration %s: %w", mig.Name, err)
+	}
+	return nil
+}
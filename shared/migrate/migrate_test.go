@@ -0,0 +1,171 @@
+package migrate
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+var testFS = fstest.MapFS{
+	"002_add_index.sql":  {Data: []byte("CREATE INDEX idx ON t(id);")},
+	"001_create_t.sql":   {Data: []byte("BEGIN;\nCREATE TABLE t (id INT);\nCOMMIT;\n")},
+	"README.md":          {Data: []byte("not a migration")},
+	"fixtures/003_x.sql": {Data: []byte("ignored: not at the root")},
+}
+
+func newMockMigrator(t *testing.T) (*Migrator, sqlmock.Sqlmock) {
+	t.Helper()
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock.New: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	m, err := New(db, testFS, "test_migrations", log.New(io.Discard, "", 0))
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	return m, mock
+}
+
+func TestLoad_OrdersByVersion(t *testing.T) {
+	migrations, err := Load(testFS)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if len(migrations) != 2 {
+		t.Fatalf("expected 2 migrations, got %d", len(migrations))
+	}
+	if migrations[0].Version != 1 || migrations[0].Name != "001_create_t.sql" {
+		t.Errorf("first migration = %d %s", migrations[0].Version, migrations[0].Name)
+	}
+	if migrations[1].Version != 2 || !strings.HasPrefix(migrations[1].SQL, "CREATE INDEX") {
+		t.Errorf("second migration = %d %q", migrations[1].Version, migrations[1].SQL)
+	}
+}
+
+func TestLoad_RejectsBadFiles(t *testing.T) {
+	tests := map[string]fstest.MapFS{
+		"no version": {"create_t.sql": {}},
+		"zero":       {"000_init.sql": {}},
+		"duplicate":  {"001_a.sql": {}, "01_b.sql": {}},
+	}
+	for name, fsys := range tests {
+		if _, err := Load(fsys); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
+
+func TestNew_RejectsBadTableName(t *testing.T) {
+	if _, err := New(nil, testFS, "migrations; DROP TABLE t", log.New(io.Discard, "", 0)); err == nil {
+		t.Error("expected error for an invalid table name")
+	}
+}
+
+func TestUp_AppliesPendingMigrations(t *testing.T) {
+	m, mock := newMockMigrator(t)
+
+	mock.ExpectExec(`SELECT pg_advisory_lock`).WithArgs(m.lockKey).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(`CREATE TABLE IF NOT EXISTS test_migrations`).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(`SELECT version FROM test_migrations`).
+		WillReturnRows(sqlmock.NewRows([]string{"version"}).AddRow(1))
+	mock.ExpectExec(`CREATE INDEX idx`).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(`INSERT INTO test_migrations`).WithArgs(2, "002_add_index.sql").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`SELECT pg_advisory_unlock`).WithArgs(m.lockKey).WillReturnResult(sqlmock.NewResult(0, 0))
+
+	applied, err := m.Up(context.Background())
+	if err != nil {
+		t.Fatalf("Up: %v", err)
+	}
+	if applied != 1 {
+		t.Errorf("expected 1 applied migration, got %d", applied)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestUp_UpToDate(t *testing.T) {
+	m, mock := newMockMigrator(t)
+	m.DisableAdvisoryLock()
+
+	mock.ExpectExec(`CREATE TABLE IF NOT EXISTS test_migrations`).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(`SELECT version FROM test_migrations`).
+		WillReturnRows(sqlmock.NewRows([]string{"version"}).AddRow(1).AddRow(2))
+
+	applied, err := m.Up(context.Background())
+	if err != nil || applied != 0 {
+		t.Fatalf("Up = %d, %v; want 0, nil", applied, err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestUp_RefusesNewerDatabase(t *testing.T) {
+	m, mock := newMockMigrator(t)
+	m.DisableAdvisoryLock()
+
+	mock.ExpectExec(`CREATE TABLE IF NOT EXISTS test_migrations`).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(`SELECT version FROM test_migrations`).
+		WillReturnRows(sqlmock.NewRows([]string{"version"}).AddRow(1).AddRow(2).AddRow(3))
+
+	applied, err := m.Up(context.Background())
+	if !errors.Is(err, ErrDatabaseAhead) {
+		t.Fatalf("expected ErrDatabaseAhead, got %v", err)
+	}
+	if applied != 0 {
+		t.Errorf("expected nothing applied, got %d", applied)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestUp_StopsAtFailedMigration(t *testing.T) {
+	m, mock := newMockMigrator(t)
+	m.DisableAdvisoryLock()
+
+	mock.ExpectExec(`CREATE TABLE IF NOT EXISTS test_migrations`).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(`SELECT version FROM test_migrations`).WillReturnRows(sqlmock.NewRows([]string{"version"}))
+	mock.ExpectExec(`CREATE TABLE t`).WillReturnError(errors.New("syntax error"))
+	mock.ExpectExec(`ROLLBACK`).WillReturnResult(sqlmock.NewResult(0, 0))
+
+	applied, err := m.Up(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "001_create_t.sql") {
+		t.Fatalf("expected error naming the migration, got %v", err)
+	}
+	if applied != 0 {
+		t.Errorf("expected nothing applied, got %d", applied)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestBaseline_RecordsWithoutRunning(t *testing.T) {
+	m, mock := newMockMigrator(t)
+
+	mock.ExpectExec(`CREATE TABLE IF NOT EXISTS test_migrations`).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(`SELECT version FROM test_migrations`).WillReturnRows(sqlmock.NewRows([]string{"version"}))
+	mock.ExpectExec(`INSERT INTO test_migrations`).WithArgs(1, "001_create_t.sql").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	if err := m.Baseline(context.Background(), 1); err != nil {
+		t.Fatalf("Baseline: %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+
+	if err := m.Baseline(context.Background(), 3); err == nil {
+		t.Error("expected error for a baseline beyond the latest migration")
+	}
+}
@@ -0,0 +1,87 @@
+// Package ontology holds LearnBot's canonical skill ontology: the skills
+// the platform knows, grouped by domain and category, with their aliases,
+// prerequisites, and related skills. The resume parser's taxonomy indexes
+// it for normalization and extraction; other services use it to collapse
+// alias spellings such as "k8s" and "Kubernetes" into one skill.
+package ontology
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Taxonomy node types
+// ─────────────────────────────────────────────────────────────────────────────
+
+// Domain is the top-level grouping (e.g. "Engineering", "Data Science").
+type Domain string
+
+const (
+	DomainEngineering   Domain = "engineering"
+	DomainDataScience   Domain = "data_science"
+	DomainDevOps        Domain = "devops"
+	DomainDesign        Domain = "design"
+	DomainManagement    Domain = "management"
+	DomainCommunication Domain = "communication"
+	DomainDomain        Domain = "domain_knowledge"
+)
+
+// Category is the second-level grouping within a domain
+// (e.g. "Frontend", "Backend", "Database").
+type Category string
+
+const (
+	// Engineering categories
+	CategoryLanguage  Category = "language"
+	CategoryFrontend  Category = "frontend"
+	CategoryBackend   Category = "backend"
+	CategoryMobile    Category = "mobile"
+	CategoryDatabase  Category = "database"
+	CategoryCloud     Category = "cloud"
+	CategoryDevOps    Category = "devops"
+	CategorySecurity  Category = "security"
+	CategoryTesting   Category = "testing"
+	CategoryAPI       Category = "api"
+	CategoryMessaging Category = "messaging"
+
+	// Data Science categories
+	CategoryMLFramework Category = "ml_framework"
+	CategoryDataTools   Category = "data_tools"
+	CategoryMLConcept   Category = "ml_concept"
+
+	// Soft skill categories
+	CategoryLeadership     Category = "leadership"
+	CategoryCollaboration  Category = "collaboration"
+	CategoryCommunication  Category = "communication_skill"
+	CategoryProblemSolving Category = "problem_solving"
+	CategoryProjectMgmt    Category = "project_management"
+
+	// Domain knowledge categories
+	CategoryFinance    Category = "finance"
+	CategoryHealthcare Category = "healthcare"
+	CategoryEcommerce  Category = "ecommerce"
+	CategoryLegal      Category = "legal"
+)
+
+// SkillNode represents a single skill entry in the taxonomy.
+type SkillNode struct {
+	// ID is the canonical identifier (lowercase, hyphenated).
+	ID string `json:"id"`
+
+	// CanonicalName is the preferred display name.
+	CanonicalName string `json:"canonical_name"`
+
+	// Domain is the top-level grouping.
+	Domain Domain `json:"domain"`
+
+	// Category is the second-level grouping.
+	Category Category `json:"category"`
+
+	// Aliases lists all known synonyms and alternate spellings.
+	Aliases []string `json:"aliases,omitempty"`
+
+	// Prerequisites lists IDs of skills that are typically learned before this one.
+	Prerequisites []string `json:"prerequisites,omitempty"`
+
+	// RelatedSkills lists IDs of skills that are commonly used alongside this one.
+	RelatedSkills []string `json:"related_skills,omitempty"`
+
+	// Description is a short human-readable description.
+	Description string `json:"description,omitempty"`
+}
@@ -0,0 +1,43 @@
+package openapi
+
+import (
+	"fmt"
+	"html"
+	"net/http"
+)
+
+// swaggerUIVersion is the major version of swagger-ui-dist loaded by the
+// docs page.
+const swaggerUIVersion = "5"
+
+// docsPage is the Swagger UI page. The document URL is relative, so that
+// the page keeps working behind a proxy that serves the service under a
+// path prefix.
+const docsPage = `<!DOCTYPE html>
+<html lang="en">
+<head>
+  <meta charset="utf-8">
+  <title>%[1]s API</title>
+  <link rel="stylesheet" href="https://unpkg.com/swagger-ui-dist@%[2]s/swagger-ui.css">
+</head>
+<body>
+  <div id="swagger-ui"></div>
+  <script src="https://unpkg.com/swagger-ui-dist@%[2]s/swagger-ui-bundle.js"></script>
+  <script>SwaggerUIBundle({url: "openapi.json", dom_id: "#swagger-ui"});</script>
+</body>
+</html>
+`
+
+// DocsHandler serves a Swagger UI page for the document at ./openapi.json.
+func DocsHandler(title string) http.Handler {
+	page := []byte(fmt.Sprintf(docsPage, html.EscapeString(title), swaggerUIVersion))
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			w.Header().Set("Allow", "GET, HEAD")
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Write(page)
+	})
+}
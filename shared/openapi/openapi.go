@@ -0,0 +1,475 @@
+// Package openapi builds the OpenAPI 3 document of a service from the Go
+// types its handlers read and write, and serves it at /openapi.json with a
+// Swagger UI at /docs.
+//
+// Handlers document their routes next to the patterns they register, in a
+// DescribeRoutes method that mirrors RegisterRoutes:
+//
+//	func (h *Handler) DescribeRoutes(spec *openapi.Spec) {
+//		spec.Route("/api/v1/score", openapi.Operation{
+//			Method:   http.MethodPost,
+//			Summary:  "Score a candidate against a job",
+//			Request:  ScoreRequest{},
+//			Response: ScoreResponse{},
+//		})
+//	}
+//
+// Schemas are generated by reflection from the json tags of the request and
+// response types, so the document changes with them. CheckRoutes compares
+// the two methods, so that a test fails when a route is registered without
+// being documented.
+package openapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Security scheme names accepted in Operation.Security.
+const (
+	// BearerAuth is a JWT issued by the API gateway, sent as
+	// "Authorization: Bearer <token>".
+	BearerAuth = "bearerAuth"
+
+	// AdminAPIKey is the static admin API key, sent in the X-Admin-API-Key
+	// header.
+	AdminAPIKey = "adminApiKey"
+
+	// ClientAPIKey is an API key issued by the API gateway to a
+	// machine-to-machine client, sent in the X-API-Key header.
+	ClientAPIKey = "clientApiKey"
+)
+
+// securitySchemes defines the schemes named by the constants above.
+var securitySchemes = map[string]interface{}{
+	BearerAuth:   map[string]interface{}{"type": "http", "scheme": "bearer", "bearerFormat": "JWT"},
+	AdminAPIKey:  map[string]interface{}{"type": "apiKey", "in": "header", "name": "X-Admin-API-Key"},
+	ClientAPIKey: map[string]interface{}{"type": "apiKey", "in": "header", "name": "X-API-Key"},
+}
+
+// Spec is the OpenAPI document of one service. Routes are added with Route
+// before the document is first served.
+type Spec struct {
+	title       string
+	version     string
+	description string
+
+	routes      []Route
+	envelope    interface{}
+	envelopeKey string
+	errorBody   interface{}
+
+	once sync.Once
+	doc  []byte
+	err  error
+}
+
+// Route documents the operations served by one mux pattern.
+type Route struct {
+	// Pattern is the pattern the handler is registered under, e.g.
+	// "/api/v1/resources/" for a subtree.
+	Pattern string
+
+	// Operations are the methods and paths answered under Pattern.
+	Operations []Operation
+}
+
+// Operation is one method on one path.
+type Operation struct {
+	// Method is the HTTP method, e.g. http.MethodPost.
+	Method string
+
+	// Path is the OpenAPI path, with "{name}" path parameters. It defaults
+	// to the route pattern.
+	Path string
+
+	// Summary and Description describe the operation.
+	Summary     string
+	Description string
+
+	// Tags group operations in the UI. They default to the spec's tag for
+	// the path, its first segment after any /api/vN prefix.
+	Tags []string
+
+	// Security names the schemes that authorize the operation; any one of
+	// them is enough. Empty means the operation is public.
+	Security []string
+
+	// Params are the query and header parameters. Path parameters are added
+	// from Path unless listed here.
+	Params []Param
+
+	// Request is a value of the request body type, or nil for none.
+	// RequestType is its media type, by default "application/json".
+	Request     interface{}
+	RequestType string
+
+	// Response is a value of the success response body type, or nil for a
+	// response without a body. ResponseType is its media type, by default
+	// "application/json". Status is the success status, by default 200.
+	Response     interface{}
+	ResponseType string
+	Status       int
+
+	// Errors lists the error statuses the operation returns, documented
+	// with the spec's error body (see SetErrorBody).
+	Errors []int
+}
+
+// Param is a query, header or path parameter.
+type Param struct {
+	// Name is the parameter name.
+	Name string
+
+	// In is "query" (the default), "header" or "path".
+	In string
+
+	// Description describes the parameter.
+	Description string
+
+	// Required reports whether the parameter must be sent. Path
+	// parameters are always required.
+	Required bool
+
+	// Type is a value of the parameter's type, or nil for a string.
+	Type interface{}
+}
+
+// Metrics documents the Prometheus /metrics route served by every service.
+var Metrics = Operation{
+	Method:       http.MethodGet,
+	Summary:      "Prometheus metrics",
+	Tags:         []string{"metrics"},
+	Response:     "",
+	ResponseType: "text/plain",
+}
+
+// Query returns an optional string query parameter.
+func Query(name, description string) Param {
+	return Param{Name: name, In: "query", Description: description}
+}
+
+// Fields documents a JSON object by example, for handlers that write ad hoc
+// maps: each key is a property whose schema is that of its value, e.g.
+// Fields{"resources": []Resource{}, "total": 0}.
+type Fields map[string]interface{}
+
+// File documents a file upload in a multipart/form-data request, or a
+// binary response body.
+type File struct{}
+
+// New creates the spec of a service.
+func New(title, version string) *Spec {
+	return &Spec{title: title, version: version}
+}
+
+// SetDescription sets the description shown at the top of the document.
+func (s *Spec) SetDescription(d string) {
+	s.description = d
+}
+
+// SetEnvelope declares that every JSON response is wrapped in the envelope
+// type of v, with the operation's Response in its key field, as the API
+// gateway wraps responses in {"success":…,"data":…}.
+func (s *Spec) SetEnvelope(v interface{}, key string) {
+	s.envelope, s.envelopeKey = v, key
+}
+
+// SetErrorBody sets the body type of error responses. Without one, errors
+// are documented with the envelope, or else with the operation's Response,
+// whose types carry an error field in most services.
+func (s *Spec) SetErrorBody(v interface{}) {
+	s.errorBody = v
+}
+
+// Route documents the operations registered under a mux pattern.
+func (s *Spec) Route(pattern string, ops ...Operation) {
+	s.routes = append(s.routes, Route{Pattern: pattern, Operations: ops})
+}
+
+// Patterns returns the documented mux patterns, sorted.
+func (s *Spec) Patterns() []string {
+	patterns := make([]string, 0, len(s.routes))
+	for _, r := range s.routes {
+		patterns = append(patterns, r.Pattern)
+	}
+	sort.Strings(patterns)
+	return patterns
+}
+
+// Check reports the differences between the documented routes and the
+// patterns a service registers, such as those recorded by a Recorder.
+func (s *Spec) Check(registered []string) error {
+	documented := make(map[string]bool, len(s.routes))
+	for _, r := range s.routes {
+		documented[r.Pattern] = true
+	}
+	seen := make(map[string]bool, len(registered))
+	var undocumented, unregistered []string
+	for _, p := range registered {
+		seen[p] = true
+		if !documented[p] {
+			undocumented = append(undocumented, p)
+		}
+	}
+	for _, p := range s.Patterns() {
+		if !seen[p] {
+			unregistered = append(unregistered, p)
+		}
+	}
+	if len(undocumented) == 0 && len(unregistered) == 0 {
+		return nil
+	}
+	var problems []string
+	if len(undocumented) > 0 {
+		sort.Strings(undocumented)
+		problems = append(problems, "routes missing from the spec: "+strings.Join(undocumented, ", "))
+	}
+	if len(unregistered) > 0 {
+		problems = append(problems, "documented routes not registered: "+strings.Join(unregistered, ", "))
+	}
+	return fmt.Errorf("openapi: %s", strings.Join(problems, "; "))
+}
+
+// CheckRoutes reports the differences between the routes register
+// registers and the routes describe documents.
+func CheckRoutes(register func(Router), describe func(*Spec)) error {
+	rec := &Recorder{}
+	register(rec)
+	spec := New("", "")
+	describe(spec)
+	return spec.Check(rec.Patterns)
+}
+
+// JSON returns the OpenAPI document. It is built on the first call; routes
+// added later are not included.
+func (s *Spec) JSON() ([]byte, error) {
+	s.once.Do(func() {
+		s.doc, s.err = json.MarshalIndent(s.document(), "", "  ")
+	})
+	return s.doc, s.err
+}
+
+// Register documents and registers the document at /openapi.json and the
+// Swagger UI at /docs.
+func (s *Spec) Register(mux Router) {
+	s.Route("/openapi.json", Operation{
+		Method:   http.MethodGet,
+		Summary:  "OpenAPI document of this service",
+		Tags:     []string{"docs"},
+		Response: Fields{},
+	})
+	s.Route("/docs", Operation{
+		Method:       http.MethodGet,
+		Summary:      "Swagger UI for the OpenAPI document",
+		Tags:         []string{"docs"},
+		Response:     "",
+		ResponseType: "text/html",
+	})
+	mux.Handle("/openapi.json", s.Handler())
+	mux.Handle("/docs", DocsHandler(s.title))
+}
+
+// Handler serves the OpenAPI document.
+func (s *Spec) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			w.Header().Set("Allow", "GET, HEAD")
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		doc, err := s.JSON()
+		if err != nil {
+			http.Error(w, "failed to build the OpenAPI document", http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(doc)
+	})
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Document
+// ─────────────────────────────────────────────────────────────────────────────
+
+// object is a JSON object in the document.
+type object = map[string]interface{}
+
+// pathParam matches a "{name}" path parameter.
+var pathParam = regexp.MustCompile(`\{([^}/]+)\}`)
+
+// versionPrefix matches the /api/vN prefix skipped by default tags.
+var versionPrefix = regexp.MustCompile(`^/api(/v\d+)?/`)
+
+// document builds the OpenAPI document.
+func (s *Spec) document() object {
+	g := newGenerator()
+	paths := object{}
+	used := map[string]bool{}
+
+	for _, route := range s.routes {
+		for _, op := range route.Operations {
+			path := op.Path
+			if path == "" {
+				path = route.Pattern
+			}
+			item, _ := paths[path].(object)
+			if item == nil {
+				item = object{}
+				paths[path] = item
+			}
+			item[strings.ToLower(op.Method)] = s.operation(g, path, op)
+			for _, name := range op.Security {
+				used[name] = true
+			}
+		}
+	}
+
+	info := object{"title": s.title, "version": s.version}
+	if s.description != "" {
+		info["description"] = s.description
+	}
+	components := object{"schemas": g.schemas}
+	if len(used) > 0 {
+		schemes := object{}
+		for name := range used {
+			schemes[name] = securitySchemes[name]
+		}
+		components["securitySchemes"] = schemes
+	}
+	return object{
+		"openapi":    "3.0.3",
+		"info":       info,
+		"paths":      paths,
+		"components": components,
+	}
+}
+
+// operation builds the document of one operation.
+func (s *Spec) operation(g *generator, path string, op Operation) object {
+	out := object{}
+	if op.Summary != "" {
+		out["summary"] = op.Summary
+	}
+	if op.Description != "" {
+		out["description"] = op.Description
+	}
+	tags := op.Tags
+	if len(tags) == 0 {
+		tags = []string{defaultTag(path)}
+	}
+	out["tags"] = tags
+
+	if params := s.parameters(g, path, op.Params); len(params) > 0 {
+		out["parameters"] = params
+	}
+	if op.Request != nil {
+		ct := op.RequestType
+		if ct == "" {
+			ct = "application/json"
+		}
+		out["requestBody"] = object{
+			"required": true,
+			"content":  object{ct: object{"schema": g.schemaOf(op.Request)}},
+		}
+	}
+
+	status := op.Status
+	if status == 0 {
+		status = http.StatusOK
+	}
+	responses := object{fmt.Sprint(status): s.response(g, status, op.Response, op.ResponseType)}
+	for _, code := range op.Errors {
+		body := s.errorBody
+		if body == nil && s.envelope == nil {
+			body = op.Response
+		}
+		responses[fmt.Sprint(code)] = s.response(g, code, body, "")
+	}
+	out["responses"] = responses
+
+	if len(op.Security) > 0 {
+		reqs := make([]object, len(op.Security))
+		for i, name := range op.Security {
+			reqs[i] = object{name: []string{}}
+		}
+		out["security"] = reqs
+	}
+	return out
+}
+
+// parameters documents the path parameters of path and params.
+func (s *Spec) parameters(g *generator, path string, params []Param) []object {
+	var out []object
+	listed := map[string]bool{}
+	for _, p := range params {
+		listed[p.Name] = true
+	}
+	for _, m := range pathParam.FindAllStringSubmatch(path, -1) {
+		if !listed[m[1]] {
+			out = append(out, object{"name": m[1], "in": "path", "required": true, "schema": object{"type": "string"}})
+		}
+	}
+	for _, p := range params {
+		in := p.In
+		if in == "" {
+			in = "query"
+		}
+		var schema object = object{"type": "string"}
+		if p.Type != nil {
+			schema = g.schemaOf(p.Type)
+		}
+		param := object{"name": p.Name, "in": in, "schema": schema}
+		if p.Description != "" {
+			param["description"] = p.Description
+		}
+		if p.Required || in == "path" {
+			param["required"] = true
+		}
+		out = append(out, param)
+	}
+	return out
+}
+
+// response documents a response with body v of media type ct. JSON bodies
+// are wrapped in the spec's envelope.
+func (s *Spec) response(g *generator, status int, v interface{}, ct string) object {
+	out := object{"description": http.StatusText(status)}
+	if ct == "" {
+		ct = "application/json"
+	}
+	var schema object
+	switch {
+	case ct == "application/json" && s.envelope != nil:
+		schema = g.schemaOf(s.envelope)
+		if v != nil && status < 300 {
+			schema = object{"allOf": []object{schema, {
+				"type":       "object",
+				"properties": object{s.envelopeKey: g.schemaOf(v)},
+			}}}
+		}
+	case v != nil:
+		schema = g.schemaOf(v)
+	default:
+		return out
+	}
+	out["content"] = object{ct: object{"schema": schema}}
+	return out
+}
+
+// defaultTag returns the first path segment after any /api/vN prefix.
+func defaultTag(path string) string {
+	rest := strings.TrimPrefix(versionPrefix.ReplaceAllString(path, "/"), "/")
+	if i := strings.IndexByte(rest, '/'); i >= 0 {
+		rest = rest[:i]
+	}
+	if rest == "" {
+		return "default"
+	}
+	return rest
+}
@@ -0,0 +1,267 @@
+package openapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+type testAddress struct {
+	City string `json:"city"`
+}
+
+type testBase struct {
+	ID string `json:"id"`
+}
+
+type testUser struct {
+	testBase
+	Email     string          `json:"email"`
+	Age       int             `json:"age,omitempty"`
+	Score     float64         `json:"score"`
+	Admin     bool            `json:"is_admin"`
+	Count     int64           `json:"count,string"`
+	CreatedAt time.Time       `json:"created_at"`
+	Address   *testAddress    `json:"address,omitempty"`
+	Tags      []string        `json:"tags"`
+	Labels    map[string]int  `json:"labels"`
+	Extra     interface{}     `json:"extra"`
+	Raw       json.RawMessage `json:"raw"`
+	Parent    *testUser       `json:"parent,omitempty"`
+	Secret    string          `json:"-"`
+	hidden    string
+	Untagged  string
+	Nested    struct{ N int }   `json:"nested"`
+	Meta      map[string]string `json:"meta,omitempty"`
+}
+
+type testEnvelope struct {
+	Success bool        `json:"success"`
+	Data    interface{} `json:"data,omitempty"`
+	Error   string      `json:"error,omitempty"`
+}
+
+// decode builds the document of spec and decodes it back.
+func decode(t *testing.T, spec *Spec) map[string]interface{} {
+	t.Helper()
+	raw, err := spec.JSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var doc map[string]interface{}
+	if err := json.Unmarshal(raw, &doc); err != nil {
+		t.Fatal(err)
+	}
+	return doc
+}
+
+// at returns the value at a path of object keys in doc.
+func at(t *testing.T, doc interface{}, keys ...string) interface{} {
+	t.Helper()
+	v := doc
+	for _, k := range keys {
+		m, ok := v.(map[string]interface{})
+		if !ok {
+			t.Fatalf("%s: not an object at %q", strings.Join(keys, "."), k)
+		}
+		if v, ok = m[k]; !ok {
+			t.Fatalf("%s: missing %q", strings.Join(keys, "."), k)
+		}
+	}
+	return v
+}
+
+func TestSchema_StructFields(t *testing.T) {
+	spec := New("test", "1.0.0")
+	spec.Route("/users", Operation{Method: http.MethodPost, Request: testUser{}, Response: testUser{}})
+	doc := decode(t, spec)
+
+	props := at(t, doc, "components", "schemas", "testUser", "properties").(map[string]interface{})
+	want := map[string]string{
+		"id": "string", "email": "string", "age": "integer", "score": "number",
+		"is_admin": "boolean", "count": "string", "created_at": "string",
+		"tags": "array", "labels": "object", "Untagged": "string", "nested": "object",
+	}
+	for name, typ := range want {
+		got, _ := at(t, props, name, "type").(string)
+		if got != typ {
+			t.Errorf("property %s: type %q, want %q", name, got, typ)
+		}
+	}
+	for _, name := range []string{"Secret", "hidden", "testBase"} {
+		if _, ok := props[name]; ok {
+			t.Errorf("unexpected property %s", name)
+		}
+	}
+	if ref := at(t, props, "address", "$ref"); ref != "#/components/schemas/testAddress" {
+		t.Errorf("address $ref = %v", ref)
+	}
+	if ref := at(t, props, "parent", "$ref"); ref != "#/components/schemas/testUser" {
+		t.Errorf("recursive parent $ref = %v", ref)
+	}
+	if at(t, props, "created_at", "format") != "date-time" {
+		t.Error("expected created_at to be a date-time")
+	}
+	if len(at(t, props, "extra").(map[string]interface{})) != 0 || len(at(t, props, "raw").(map[string]interface{})) != 0 {
+		t.Error("expected interface and raw JSON fields to accept any value")
+	}
+	if at(t, props, "labels", "additionalProperties", "type") != "integer" {
+		t.Error("expected map values to be documented")
+	}
+
+	body := at(t, doc, "paths", "/users", "post", "requestBody", "content", "application/json", "schema", "$ref")
+	if body != "#/components/schemas/testUser" {
+		t.Errorf("request body $ref = %v", body)
+	}
+}
+
+func TestSchema_Fields(t *testing.T) {
+	spec := New("test", "1.0.0")
+	spec.Route("/upload", Operation{
+		Method:      http.MethodPost,
+		Request:     Fields{"file": File{}, "address": testAddress{}},
+		RequestType: "multipart/form-data",
+		Response:    Fields{"users": []testUser{}, "total": 0},
+	})
+	doc := decode(t, spec)
+	op := at(t, doc, "paths", "/upload", "post")
+
+	req := at(t, op, "requestBody", "content", "multipart/form-data", "schema", "properties")
+	if at(t, req, "file", "format") != "binary" {
+		t.Error("expected file to be binary")
+	}
+	resp := at(t, op, "responses", "200", "content", "application/json", "schema", "properties")
+	if at(t, resp, "users", "items", "$ref") != "#/components/schemas/testUser" || at(t, resp, "total", "type") != "integer" {
+		t.Errorf("unexpected response properties %v", resp)
+	}
+}
+
+func TestSpec_EnvelopeAndErrors(t *testing.T) {
+	spec := New("test", "1.0.0")
+	spec.SetEnvelope(testEnvelope{}, "data")
+	spec.Route("/users/", Operation{
+		Method:   http.MethodGet,
+		Path:     "/users/{id}",
+		Security: []string{BearerAuth},
+		Params:   []Param{Query("fields", "Fields to include")},
+		Response: testUser{},
+		Errors:   []int{http.StatusNotFound},
+	})
+	doc := decode(t, spec)
+	op := at(t, doc, "paths", "/users/{id}", "get")
+
+	ok := at(t, op, "responses", "200", "content", "application/json", "schema", "allOf").([]interface{})
+	if at(t, ok[0], "$ref") != "#/components/schemas/testEnvelope" ||
+		at(t, ok[1], "properties", "data", "$ref") != "#/components/schemas/testUser" {
+		t.Errorf("unexpected enveloped response %v", ok)
+	}
+	if at(t, op, "responses", "404", "content", "application/json", "schema", "$ref") != "#/components/schemas/testEnvelope" {
+		t.Error("expected the error to be documented with the envelope")
+	}
+
+	params := at(t, op, "parameters").([]interface{})
+	if len(params) != 2 || at(t, params[0], "in") != "path" || at(t, params[0], "name") != "id" ||
+		at(t, params[1], "in") != "query" || at(t, params[1], "name") != "fields" {
+		t.Errorf("unexpected parameters %v", params)
+	}
+	if !reflect.DeepEqual(at(t, op, "tags"), []interface{}{"users"}) {
+		t.Errorf("tags = %v", at(t, op, "tags"))
+	}
+	if at(t, doc, "components", "securitySchemes", BearerAuth, "scheme") != "bearer" {
+		t.Error("expected the bearer scheme to be defined")
+	}
+}
+
+func TestSpec_ErrorsDefaultToResponse(t *testing.T) {
+	spec := New("test", "1.0.0")
+	spec.Route("/score", Operation{Method: http.MethodPost, Response: testEnvelope{}, Errors: []int{http.StatusBadRequest}})
+	doc := decode(t, spec)
+
+	if at(t, doc, "paths", "/score", "post", "responses", "400", "content", "application/json", "schema", "$ref") != "#/components/schemas/testEnvelope" {
+		t.Error("expected the error to be documented with the response type")
+	}
+}
+
+func TestDefaultTag(t *testing.T) {
+	tests := map[string]string{
+		"/api/v1/score":       "score",
+		"/api/v1/resources/x": "resources",
+		"/api/auth/login":     "auth",
+		"/admin/stats":        "admin",
+		"/health":             "health",
+		"/":                   "default",
+	}
+	for path, want := range tests {
+		if got := defaultTag(path); got != want {
+			t.Errorf("defaultTag(%q) = %q, want %q", path, got, want)
+		}
+	}
+}
+
+func TestSpec_Check(t *testing.T) {
+	spec := New("test", "1.0.0")
+	spec.Route("/a", Operation{Method: http.MethodGet})
+	spec.Route("/b", Operation{Method: http.MethodGet})
+
+	rec := &Recorder{}
+	rec.HandleFunc("/a", func(http.ResponseWriter, *http.Request) {})
+	rec.Handle("/b", http.NotFoundHandler())
+	if err := spec.Check(rec.Patterns); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	err := spec.Check([]string{"/a", "/c"})
+	if err == nil || !strings.Contains(err.Error(), "missing from the spec: /c") || !strings.Contains(err.Error(), "not registered: /b") {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestCheckRoutes(t *testing.T) {
+	register := func(mux Router) {
+		mux.HandleFunc("/a", func(http.ResponseWriter, *http.Request) {})
+		mux.HandleFunc("/b", func(http.ResponseWriter, *http.Request) {})
+	}
+	err := CheckRoutes(register, func(spec *Spec) {
+		spec.Route("/a", Operation{Method: http.MethodGet})
+	})
+	if err == nil || !strings.Contains(err.Error(), "missing from the spec: /b") {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestSpec_Register(t *testing.T) {
+	spec := New("test <svc>", "1.0.0")
+	mux := http.NewServeMux()
+	spec.Register(mux)
+
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/openapi.json", nil))
+	if w.Code != http.StatusOK || w.Header().Get("Content-Type") != "application/json" {
+		t.Fatalf("GET /openapi.json: %d %s", w.Code, w.Header().Get("Content-Type"))
+	}
+	var doc map[string]interface{}
+	if err := json.Unmarshal(w.Body.Bytes(), &doc); err != nil {
+		t.Fatal(err)
+	}
+	if doc["openapi"] != "3.0.3" || at(t, doc, "info", "title") != "test <svc>" {
+		t.Errorf("unexpected document header %v %v", doc["openapi"], doc["info"])
+	}
+	at(t, doc, "paths", "/docs", "get")
+
+	w = httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/docs", nil))
+	if w.Code != http.StatusOK || !strings.Contains(w.Body.String(), `url: "openapi.json"`) ||
+		!strings.Contains(w.Body.String(), "test &lt;svc&gt; API") {
+		t.Errorf("GET /docs: %d %s", w.Code, w.Body.String())
+	}
+
+	w = httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/openapi.json", nil))
+	if w.Code != http.StatusMethodNotAllowed {
+		t.Errorf("POST /openapi.json: %d", w.Code)
+	}
+}
@@ -0,0 +1,27 @@
+package openapi
+
+import "net/http"
+
+// Router is the part of *http.ServeMux that handlers register their routes
+// on. Handlers accept a Router rather than a *http.ServeMux so that tests
+// can record the routes with a Recorder.
+type Router interface {
+	Handle(pattern string, handler http.Handler)
+	HandleFunc(pattern string, handler func(http.ResponseWriter, *http.Request))
+}
+
+// Recorder is a Router that records the registered patterns, to compare
+// them to a Spec with Spec.Check.
+type Recorder struct {
+	Patterns []string
+}
+
+// Handle records pattern.
+func (r *Recorder) Handle(pattern string, _ http.Handler) {
+	r.Patterns = append(r.Patterns, pattern)
+}
+
+// HandleFunc records pattern.
+func (r *Recorder) HandleFunc(pattern string, _ func(http.ResponseWriter, *http.Request)) {
+	r.Patterns = append(r.Patterns, pattern)
+}
@@ -0,0 +1,181 @@
+package openapi
+
+import (
+	"encoding"
+	"encoding/json"
+	"reflect"
+	"regexp"
+	"strings"
+	"time"
+)
+
+var (
+	timeType          = reflect.TypeOf(time.Time{})
+	durationType      = reflect.TypeOf(time.Duration(0))
+	fileType          = reflect.TypeOf(File{})
+	fieldsType        = reflect.TypeOf(Fields{})
+	jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+)
+
+// nonIdentifier matches the characters of a Go type name, such as the
+// brackets of an instantiated generic type, that are not allowed in a
+// component name.
+var nonIdentifier = regexp.MustCompile(`[^A-Za-z0-9_.]+`)
+
+// generator builds schemas, collecting named struct types as components.
+type generator struct {
+	schemas object
+	names   map[reflect.Type]string
+	taken   map[string]reflect.Type
+}
+
+func newGenerator() *generator {
+	return &generator{
+		schemas: object{},
+		names:   map[reflect.Type]string{},
+		taken:   map[string]reflect.Type{},
+	}
+}
+
+// schemaOf returns the schema of v's type. A Fields value documents the
+// object it describes.
+func (g *generator) schemaOf(v interface{}) object {
+	if f, ok := v.(Fields); ok {
+		return g.fieldsSchema(f)
+	}
+	return g.schema(reflect.TypeOf(v))
+}
+
+// schema returns the schema of t, as encoding/json encodes it. Named
+// structs are referenced by component name.
+func (g *generator) schema(t reflect.Type) object {
+	if t == nil {
+		return object{}
+	}
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	switch t {
+	case timeType:
+		return object{"type": "string", "format": "date-time"}
+	case durationType:
+		return object{"type": "integer", "format": "int64", "description": "nanoseconds"}
+	case fileType:
+		return object{"type": "string", "format": "binary"}
+	case fieldsType:
+		return object{"type": "object"}
+	}
+	ptr := reflect.PointerTo(t)
+	if t.Implements(jsonMarshalerType) || ptr.Implements(jsonMarshalerType) {
+		if t.Implements(textMarshalerType) || ptr.Implements(textMarshalerType) {
+			return object{"type": "string"}
+		}
+		return object{}
+	}
+	if t.Implements(textMarshalerType) || ptr.Implements(textMarshalerType) {
+		return object{"type": "string"}
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return object{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return object{"type": "integer"}
+	case reflect.Int64, reflect.Uint64:
+		return object{"type": "integer", "format": "int64"}
+	case reflect.Float32:
+		return object{"type": "number", "format": "float"}
+	case reflect.Float64:
+		return object{"type": "number", "format": "double"}
+	case reflect.String:
+		return object{"type": "string"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 && t.Kind() == reflect.Slice {
+			return object{"type": "string", "format": "byte"}
+		}
+		return object{"type": "array", "items": g.schema(t.Elem())}
+	case reflect.Map:
+		return object{"type": "object", "additionalProperties": g.schema(t.Elem())}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return g.structSchema(t)
+		}
+		return object{"$ref": "#/components/schemas/" + g.component(t)}
+	default:
+		// Interfaces hold any value.
+		return object{}
+	}
+}
+
+// component returns the component name of a named struct type, adding its
+// schema on first use. Types that share a name across packages are told
+// apart by their package name.
+func (g *generator) component(t reflect.Type) string {
+	if name, ok := g.names[t]; ok {
+		return name
+	}
+	name := nonIdentifier.ReplaceAllString(t.Name(), "_")
+	if other, ok := g.taken[name]; ok && other != t {
+		pkg := t.PkgPath()
+		name = pkg[strings.LastIndexByte(pkg, '/')+1:] + "." + name
+	}
+	g.names[t], g.taken[name] = name, t
+	// Register the name before building the schema so that recursive types
+	// refer to themselves.
+	g.schemas[name] = object{}
+	g.schemas[name] = g.structSchema(t)
+	return name
+}
+
+// structSchema returns the object schema of a struct's JSON fields.
+func (g *generator) structSchema(t reflect.Type) object {
+	props := object{}
+	g.addFields(props, t)
+	return object{"type": "object", "properties": props}
+}
+
+// addFields adds the JSON fields of struct t to props, flattening embedded
+// structs as encoding/json does.
+func (g *generator) addFields(props object, t reflect.Type) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+		if f.Anonymous && name == "" {
+			ft := f.Type
+			if ft.Kind() == reflect.Pointer {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				g.addFields(props, ft)
+				continue
+			}
+		}
+		if !f.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		if strings.Contains(","+opts+",", ",string,") {
+			props[name] = object{"type": "string"}
+			continue
+		}
+		props[name] = g.schema(f.Type)
+	}
+}
+
+// fieldsSchema returns the object schema described by f.
+func (g *generator) fieldsSchema(f Fields) object {
+	props := object{}
+	for k, v := range f {
+		props[k] = g.schemaOf(v)
+	}
+	return object{"type": "object", "properties": props}
+}
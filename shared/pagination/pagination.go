@@ -0,0 +1,132 @@
+// Package pagination provides the list envelope shared by the services'
+// list endpoints, together with RFC 8288 (formerly RFC 5988) Link headers
+// so that clients can follow next/prev links instead of doing paging math.
+//
+// A list response looks like:
+//
+//	{"data": [...], "pagination": {"total": 42, "limit": 20, "offset": 20, "next_offset": 40, "has_more": true}}
+//
+// Link targets are query-only references ("?limit=20&offset=40&skill=go")
+// that resolve against the request path. They stay correct when a service
+// is reached through the API gateway under a different path prefix.
+package pagination
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// Pagination describes one page of a list.
+type Pagination struct {
+	// Total is the number of items across all pages.
+	Total int `json:"total"`
+
+	// Limit is the maximum number of items in the page.
+	Limit int `json:"limit"`
+
+	// Offset is the index of the page's first item.
+	Offset int `json:"offset"`
+
+	// NextOffset is the offset of the next page, or nil on the last page.
+	NextOffset *int `json:"next_offset"`
+
+	// HasMore reports whether items follow this page.
+	HasMore bool `json:"has_more"`
+
+	// NextCursor is an opaque token for the next page on lists that support
+	// cursor pagination, or empty on the last page.
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+// New describes the page of limit items starting at offset in a list of
+// total items. An offset beyond total is a valid, empty last page.
+func New(total, limit, offset int) Pagination {
+	p := Pagination{Total: total, Limit: limit, Offset: offset}
+	if limit > 0 && offset+limit < total {
+		next := offset + limit
+		p.NextOffset = &next
+		p.HasMore = true
+	}
+	return p
+}
+
+// FromPage describes page (1-based) of pageSize items.
+func FromPage(total, page, pageSize int) Pagination {
+	return New(total, pageSize, (max(page, 1)-1)*pageSize)
+}
+
+// FromCursor describes a page of limit items fetched with a cursor, whose
+// offset is unknown. next is the cursor of the following page, or empty on
+// the last page.
+func FromCursor(total, limit int, next string) Pagination {
+	return Pagination{Total: total, Limit: limit, HasMore: next != "", NextCursor: next}
+}
+
+// Envelope is the body of a list response.
+type Envelope struct {
+	Data       interface{} `json:"data"`
+	Pagination Pagination  `json:"pagination"`
+}
+
+// prevOffset returns the offset of the previous page. Past the end of the
+// list, the previous page is the last one that holds items.
+func (p Pagination) prevOffset() (int, bool) {
+	if p.Offset <= 0 || p.Limit <= 0 {
+		return 0, false
+	}
+	if p.Offset >= p.Total {
+		return max(0, (p.Total-1)/p.Limit*p.Limit), true
+	}
+	return max(0, p.Offset-p.Limit), true
+}
+
+// SetLinks sets a Link header with next and prev relations that page
+// through u with limit and offset query parameters. Other query parameters,
+// such as filters, are preserved. When p has a NextCursor, the next link
+// carries it in the cursor parameter instead of an offset.
+func SetLinks(h http.Header, u *url.URL, p Pagination) {
+	setLinks(h, u, p, func(q url.Values, offset int) {
+		q.Set("limit", strconv.Itoa(p.Limit))
+		q.Set("offset", strconv.Itoa(offset))
+	})
+}
+
+// SetPageLinks is SetLinks for endpoints paged with page and page_size
+// query parameters. p must come from FromPage.
+func SetPageLinks(h http.Header, u *url.URL, p Pagination) {
+	setLinks(h, u, p, func(q url.Values, offset int) {
+		q.Set("page", strconv.Itoa(offset/p.Limit+1))
+		q.Set("page_size", strconv.Itoa(p.Limit))
+	})
+}
+
+// setLinks builds the Link header, using set to write a page's offset into
+// the query.
+func setLinks(h http.Header, u *url.URL, p Pagination, set func(q url.Values, offset int)) {
+	link := func(rel string, offset int, cursor string) string {
+		q := u.Query()
+		set(q, offset)
+		if cursor != "" {
+			q.Del("offset")
+			q.Del("page")
+			q.Set("cursor", cursor)
+		}
+		return "<?" + q.Encode() + `>; rel="` + rel + `"`
+	}
+
+	var links []string
+	switch {
+	case p.NextCursor != "":
+		links = append(links, link("next", 0, p.NextCursor))
+	case p.NextOffset != nil:
+		links = append(links, link("next", *p.NextOffset, ""))
+	}
+	if prev, ok := p.prevOffset(); ok {
+		links = append(links, link("prev", prev, ""))
+	}
+	if len(links) > 0 {
+		h.Set("Link", strings.Join(links, ", "))
+	}
+}
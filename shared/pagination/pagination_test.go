@@ -0,0 +1,117 @@
+package pagination
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+)
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name                 string
+		total, limit, offset int
+		next                 int // -1 for none
+		hasMore              bool
+	}{
+		{"first page", 45, 20, 0, 20, true},
+		{"middle page", 45, 20, 20, 40, true},
+		{"last page", 45, 20, 40, -1, false},
+		{"exact fit", 40, 20, 20, -1, false},
+		{"beyond the end", 45, 20, 100, -1, false},
+		{"empty list", 0, 20, 0, -1, false},
+	}
+	for _, tt := range tests {
+		p := New(tt.total, tt.limit, tt.offset)
+		if p.HasMore != tt.hasMore {
+			t.Errorf("%s: has_more = %v, want %v", tt.name, p.HasMore, tt.hasMore)
+		}
+		switch {
+		case tt.next < 0 && p.NextOffset != nil:
+			t.Errorf("%s: expected no next offset, got %d", tt.name, *p.NextOffset)
+		case tt.next >= 0 && (p.NextOffset == nil || *p.NextOffset != tt.next):
+			t.Errorf("%s: expected next offset %d, got %v", tt.name, tt.next, p.NextOffset)
+		}
+	}
+
+	if p := FromPage(45, 2, 20); p.Offset != 20 || p.Limit != 20 || *p.NextOffset != 40 {
+		t.Errorf("unexpected FromPage result: %+v", p)
+	}
+}
+
+func TestSetLinks_PreservesFilters(t *testing.T) {
+	u, _ := url.Parse("/api/v1/resources?skill=go&free=true&limit=20&offset=20")
+	h := http.Header{}
+	SetLinks(h, u, New(45, 20, 20))
+
+	want := `<?free=true&limit=20&offset=40&skill=go>; rel="next", <?free=true&limit=20&offset=0&skill=go>; rel="prev"`
+	if got := h.Get("Link"); got != want {
+		t.Errorf("Link = %s\nwant   %s", got, want)
+	}
+}
+
+func TestSetLinks_Edges(t *testing.T) {
+	u, _ := url.Parse("/api/v1/jobs/search?q=golang")
+	tests := []struct {
+		name string
+		p    Pagination
+		want string
+	}{
+		{"single page", New(5, 20, 0), ""},
+		{"first page", New(45, 20, 0), `<?limit=20&offset=20&q=golang>; rel="next"`},
+		{"beyond the end links back to the last page", New(45, 20, 100), `<?limit=20&offset=40&q=golang>; rel="prev"`},
+		{"beyond an empty list", New(0, 20, 40), `<?limit=20&offset=0&q=golang>; rel="prev"`},
+	}
+	for _, tt := range tests {
+		h := http.Header{}
+		SetLinks(h, u, tt.p)
+		if got := h.Get("Link"); got != tt.want {
+			t.Errorf("%s: Link = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestSetPageLinks(t *testing.T) {
+	u, _ := url.Parse("/admin/scrape-runs?status=failed&page=2&page_size=10")
+	h := http.Header{}
+	SetPageLinks(h, u, FromPage(35, 2, 10))
+
+	want := `<?page=3&page_size=10&status=failed>; rel="next", <?page=1&page_size=10&status=failed>; rel="prev"`
+	if got := h.Get("Link"); got != want {
+		t.Errorf("Link = %s\nwant   %s", got, want)
+	}
+
+	h = http.Header{}
+	SetPageLinks(h, u, FromPage(35, 9, 10))
+	if got, want := h.Get("Link"), `<?page=4&page_size=10&status=failed>; rel="prev"`; got != want {
+		t.Errorf("beyond the end: Link = %s, want %s", got, want)
+	}
+}
+
+func TestSetLinks_Cursor(t *testing.T) {
+	u, _ := url.Parse("/api/v1/jobs/search?remote=true&limit=10&cursor=abc")
+	h := http.Header{}
+	p := FromCursor(45, 10, "def")
+	SetLinks(h, u, p)
+	if got, want := h.Get("Link"), `<?cursor=def&limit=10&remote=true>; rel="next"`; got != want {
+		t.Errorf("Link = %s, want %s", got, want)
+	}
+	if !p.HasMore || p.NextOffset != nil {
+		t.Errorf("unexpected cursor page: %+v", p)
+	}
+
+	// The first page of a cursor list also links to its second page by cursor.
+	u, _ = url.Parse("/admin/jobs?page=1&page_size=10&q=go")
+	h = http.Header{}
+	first := FromPage(45, 1, 10)
+	first.NextCursor = "def"
+	SetPageLinks(h, u, first)
+	if got, want := h.Get("Link"), `<?cursor=def&page_size=10&q=go>; rel="next"`; got != want {
+		t.Errorf("Link = %s, want %s", got, want)
+	}
+
+	h = http.Header{}
+	SetLinks(h, u, FromCursor(45, 10, ""))
+	if got := h.Get("Link"); got != "" {
+		t.Errorf("expected no links on the last cursor page, got %s", got)
+	}
+}
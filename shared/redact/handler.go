@@ -0,0 +1,90 @@
+package redact
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+)
+
+// NewHandler returns a slog.Handler that redacts the message and attribute
+// values of each record with r before passing it to h. String values are
+// redacted, as are errors, fmt.Stringers, byte slices and other values that
+// format with personal data in them, such as a logged request body.
+func NewHandler(h slog.Handler, r *Redactor) slog.Handler {
+	return &handler{next: h, r: r}
+}
+
+// Logger returns l with its records redacted by r.
+func Logger(l *slog.Logger, r *Redactor) *slog.Logger {
+	return slog.New(NewHandler(l.Handler(), r))
+}
+
+type handler struct {
+	next slog.Handler
+	r    *Redactor
+}
+
+func (h *handler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.next.Enabled(ctx, level)
+}
+
+func (h *handler) Handle(ctx context.Context, rec slog.Record) error {
+	out := slog.NewRecord(rec.Time, rec.Level, h.r.String(rec.Message), rec.PC)
+	rec.Attrs(func(a slog.Attr) bool {
+		out.AddAttrs(h.attr(a))
+		return true
+	})
+	return h.next.Handle(ctx, out)
+}
+
+func (h *handler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	redacted := make([]slog.Attr, len(attrs))
+	for i, a := range attrs {
+		redacted[i] = h.attr(a)
+	}
+	return &handler{next: h.next.WithAttrs(redacted), r: h.r}
+}
+
+func (h *handler) WithGroup(name string) slog.Handler {
+	return &handler{next: h.next.WithGroup(name), r: h.r}
+}
+
+// attr redacts the value of a, recursing into groups.
+func (h *handler) attr(a slog.Attr) slog.Attr {
+	v := a.Value.Resolve()
+	switch v.Kind() {
+	case slog.KindString:
+		a.Value = slog.StringValue(h.r.String(v.String()))
+	case slog.KindGroup:
+		group := v.Group()
+		redacted := make([]slog.Attr, len(group))
+		for i, ga := range group {
+			redacted[i] = h.attr(ga)
+		}
+		a.Value = slog.GroupValue(redacted...)
+	case slog.KindAny:
+		var s string
+		switch x := v.Any().(type) {
+		case error:
+			s = x.Error()
+		case fmt.Stringer:
+			s = x.String()
+		case []byte:
+			s = string(x)
+		default:
+			s = fmt.Sprint(x)
+		}
+		// Values without personal data keep their type, so that e.g. a
+		// map is still encoded as a JSON object.
+		if redacted := h.r.String(s); redacted != s {
+			a.Value = slog.StringValue(redacted)
+		} else if _, ok := v.Any().([]byte); ok {
+			a.Value = slog.StringValue(s)
+		} else {
+			a.Value = v
+		}
+	default:
+		a.Value = v
+	}
+	return a
+}
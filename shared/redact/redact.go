@@ -0,0 +1,257 @@
+// Package redact masks personal data in free text: email addresses, phone
+// numbers and national ID numbers. The services use it on scraped job
+// descriptions before storing them and on log records before writing them.
+//
+// A Redactor applies a list of patterns in order, replacing each match with
+// a placeholder such as "[email]". DefaultPatterns covers the common cases;
+// custom patterns can be added with Redactor.With.
+//
+//	r := redact.Default().With(redact.Pattern{
+//		Name:   "employee_id",
+//		Regexp: regexp.MustCompile(`\bEMP-\d{6}\b`),
+//	})
+//	r.String("Ask jane@example.com about EMP-123456")
+//	// "Ask [email] about [employee_id]"
+package redact
+
+import (
+	"net"
+	"regexp"
+	"strings"
+)
+
+// Pattern is one kind of personal data.
+type Pattern struct {
+	// Name identifies the pattern, e.g. "email".
+	Name string
+
+	// Regexp matches the data.
+	Regexp *regexp.Regexp
+
+	// Replacement replaces each match. Empty means "[Name]".
+	Replacement string
+
+	// Hint lists characters of which every match contains at least one.
+	// Text with none of them is skipped without running Regexp, which
+	// keeps text without personal data cheap. Empty means always run.
+	Hint string
+
+	// Valid, if set, reports whether a match is to be redacted.
+	Valid func(match string) bool
+
+	// windowed runs Regexp only on the text around hint characters, and
+	// only where a window holds at least minHints of them; see windows.
+	windowed bool
+	minHints int
+}
+
+const digits = "0123456789"
+
+var (
+	emailRe = regexp.MustCompile(`(?i)[a-z0-9._%+\-]+@[a-z0-9.\-]+\.[a-z]{2,}`)
+	// nationalIDRe matches US social security numbers, 16-digit numbers
+	// such as Indonesian NIKs (and card numbers), and UK national insurance
+	// numbers.
+	nationalIDRe = regexp.MustCompile(`\b(?:\d{3}-\d{2}-\d{4}|\d{16}|[A-CEGHJ-PR-TW-Z]{2} ?\d{2} ?\d{2} ?\d{2} ?[A-D])\b`)
+	// phoneRe matches groups of digits joined by single separators, with an
+	// optional country code and area code in parentheses; validPhone then
+	// checks the digit count.
+	phoneRe = regexp.MustCompile(`(?:\+\d{1,3}[\s.\-]?)?(?:\(\d{1,4}\)[\s.\-]?)?\b\d{2,5}(?:[\s.\-]\d{2,5}){1,4}\b|\+\d{9,15}\b`)
+	// Strings phoneRe matches that are not phone numbers.
+	dateRe      = regexp.MustCompile(`^(?:19|20)\d{2}[\-./]\d{1,2}[\-./]\d{1,2}\b`)
+	thousandsRe = regexp.MustCompile(`^\d{1,3}(?:[.,]\d{3})+$`)
+)
+
+// DefaultPatterns returns the built-in patterns: email addresses, national
+// ID numbers and phone numbers, in that order. The slice is a new copy.
+func DefaultPatterns() []Pattern {
+	return []Pattern{
+		{Name: "email", Regexp: emailRe, Hint: "@", windowed: true, minHints: 1},
+		// Before phones, which would claim ID numbers with separators.
+		{Name: "national_id", Regexp: nationalIDRe, Hint: digits, windowed: true, minHints: 6},
+		{Name: "phone", Regexp: phoneRe, Hint: digits, Valid: validPhone, windowed: true, minHints: 9},
+	}
+}
+
+// validPhone rejects matches with too few or too many digits for a phone
+// number, and IP addresses, dates and amounts such as "10.000.000 - 15.000.000"
+// that have the same shape.
+func validPhone(match string) bool {
+	if n := countAny(match, digits); n < 9 || n > 15 {
+		return false
+	}
+	if net.ParseIP(match) != nil || dateRe.MatchString(match) {
+		return false
+	}
+	amounts := true
+	for _, part := range strings.Split(match, "-") {
+		if !thousandsRe.MatchString(strings.TrimSpace(part)) {
+			amounts = false
+			break
+		}
+	}
+	return !amounts
+}
+
+// Redactor replaces personal data in text. A nil *Redactor leaves text
+// unchanged, so it can stand for redaction being disabled. It is safe for
+// concurrent use.
+type Redactor struct {
+	patterns []Pattern
+}
+
+// New returns a Redactor for patterns, applied in order. It panics if a
+// pattern has no Name or Regexp.
+func New(patterns ...Pattern) *Redactor {
+	r := &Redactor{patterns: make([]Pattern, 0, len(patterns))}
+	for _, p := range patterns {
+		if p.Name == "" || p.Regexp == nil {
+			panic("redact: pattern needs a Name and a Regexp")
+		}
+		if p.Replacement == "" {
+			p.Replacement = "[" + p.Name + "]"
+		}
+		r.patterns = append(r.patterns, p)
+	}
+	return r
+}
+
+// Default returns a Redactor for DefaultPatterns.
+func Default() *Redactor {
+	return New(DefaultPatterns()...)
+}
+
+var std = Default()
+
+// String redacts s with the default patterns.
+func String(s string) string {
+	return std.String(s)
+}
+
+// With returns a Redactor that applies r's patterns followed by patterns.
+// r is not modified.
+func (r *Redactor) With(patterns ...Pattern) *Redactor {
+	var base []Pattern
+	if r != nil {
+		base = r.patterns
+	}
+	return New(append(append([]Pattern(nil), base...), patterns...)...)
+}
+
+// String returns s with every match of r's patterns replaced. It returns s
+// itself when nothing matches.
+func (r *Redactor) String(s string) string {
+	if r == nil || s == "" {
+		return s
+	}
+	for i := range r.patterns {
+		p := &r.patterns[i]
+		if p.Hint != "" && !strings.ContainsAny(s, p.Hint) {
+			continue
+		}
+		if p.windowed {
+			s = p.replaceWindows(s)
+			continue
+		}
+		if p.Valid == nil {
+			s = p.Regexp.ReplaceAllLiteralString(s, p.Replacement)
+			continue
+		}
+		s = p.Regexp.ReplaceAllStringFunc(s, func(m string) string {
+			if p.Valid(m) {
+				return p.Replacement
+			}
+			return m
+		})
+	}
+	return s
+}
+
+// replaceWindows is ReplaceAllStringFunc restricted to the windows of s,
+// which is much faster on long text where hint characters are sparse.
+func (p *Pattern) replaceWindows(s string) string {
+	var b strings.Builder
+	last := 0
+	for _, w := range windows(s, p.Hint) {
+		if countAny(s[w[0]:w[1]], p.Hint) < p.minHints {
+			continue
+		}
+		for _, m := range p.Regexp.FindAllStringIndex(s[w[0]:w[1]], -1) {
+			start, end := w[0]+m[0], w[0]+m[1]
+			if p.Valid != nil && !p.Valid(s[start:end]) {
+				continue
+			}
+			b.WriteString(s[last:start])
+			b.WriteString(p.Replacement)
+			last = end
+		}
+	}
+	if last == 0 {
+		return s
+	}
+	b.WriteString(s[last:])
+	return b.String()
+}
+
+// Window bounds: hint characters at most windowGap bytes apart form one
+// cluster, which is widened by windowMargin bytes (for prefixes such as "+"
+// or "AB " and suffixes) and then to the surrounding whitespace.
+const (
+	windowGap    = 3
+	windowMargin = 4
+)
+
+// windows returns the spans of s that can hold a match of a pattern whose
+// matches contain no whitespace outside clusters of hint characters. Spans
+// start and end at whitespace or at the ends of s, so anchors such as \b
+// behave as they do on the whole text.
+func windows(s, hint string) [][2]int {
+	var out [][2]int
+	for i := 0; i < len(s); {
+		j := strings.IndexAny(s[i:], hint)
+		if j < 0 {
+			break
+		}
+		start := i + j
+		end := start + 1
+		for end < len(s) {
+			k := strings.IndexAny(s[end:min(end+windowGap+1, len(s))], hint)
+			if k < 0 {
+				break
+			}
+			end += k + 1
+		}
+		i = end
+
+		start = max(start-windowMargin, 0)
+		for start > 0 && !isSpace(s[start-1]) {
+			start--
+		}
+		end = min(end+windowMargin, len(s))
+		for end < len(s) && !isSpace(s[end]) {
+			end++
+		}
+		if n := len(out); n > 0 && start <= out[n-1][1] {
+			out[n-1][1] = max(out[n-1][1], end)
+		} else {
+			out = append(out, [2]int{start, end})
+		}
+		i = max(i, end)
+	}
+	return out
+}
+
+// countAny returns the number of bytes of s that are in chars.
+func countAny(s, chars string) int {
+	n := 0
+	for i := 0; i < len(s); i++ {
+		if strings.IndexByte(chars, s[i]) >= 0 {
+			n++
+		}
+	}
+	return n
+}
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == '\f' || c == '\v'
+}
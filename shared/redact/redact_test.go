@@ -0,0 +1,166 @@
+package redact
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestString(t *testing.T) {
+	tests := []struct {
+		name, in, want string
+	}{
+		{"email", "Send your CV to jane.doe+jobs@example.co.id today", "Send your CV to [email] today"},
+		{"us phone", "Call (555) 123-4567 or 555.987.6543", "Call [phone] or [phone]"},
+		{"international phone", "WhatsApp +62 812-3456-7890, +6281234567890", "WhatsApp [phone], [phone]"},
+		{"ssn", "SSN 123-45-6789 on file", "SSN [national_id] on file"},
+		{"nik", "NIK 3174012345678901", "NIK [national_id]"},
+		{"nino", "NI number AB 12 34 56 C", "NI number [national_id]"},
+		{"year range", "Experience from 2019 - 2021 and 2019-2021", "Experience from 2019 - 2021 and 2019-2021"},
+		{"date and time", "Posted 2024-01-15 10:30:00", "Posted 2024-01-15 10:30:00"},
+		{"salary", "Salary Rp 10.000.000-15.000.000 per month, $120,000 - $150,000", "Salary Rp 10.000.000-15.000.000 per month, $120,000 - $150,000"},
+		{"ip address", "client 192.168.100.200", "client 192.168.100.200"},
+		{"version", "Go 1.22.0, Kubernetes 1.29", "Go 1.22.0, Kubernetes 1.29"},
+		{"short number", "Team of 12, 5+ years, ext 1234", "Team of 12, 5+ years, ext 1234"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := String(tt.in); got != tt.want {
+				t.Errorf("String(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRedactor_CustomPatterns(t *testing.T) {
+	r := Default().With(
+		Pattern{Name: "employee_id", Regexp: regexp.MustCompile(`\bEMP-\d{6}\b`)},
+		Pattern{Name: "token", Regexp: regexp.MustCompile(`tok_[a-z0-9]+`), Replacement: "***", Hint: "_"},
+	)
+	got := r.String("jane@example.com owns EMP-123456, key tok_abc123")
+	if want := "[email] owns [employee_id], key ***"; got != want {
+		t.Errorf("String = %q, want %q", got, want)
+	}
+
+	// The base redactor is unchanged.
+	if got := Default().String("EMP-123456"); got != "EMP-123456" {
+		t.Errorf("Default redacted a custom pattern: %q", got)
+	}
+
+	// Custom patterns alone.
+	only := New(Pattern{Name: "employee_id", Regexp: regexp.MustCompile(`\bEMP-\d{6}\b`)})
+	if got := only.String("EMP-123456 jane@example.com"); got != "[employee_id] jane@example.com" {
+		t.Errorf("String = %q", got)
+	}
+}
+
+func TestRedactor_Nil(t *testing.T) {
+	var r *Redactor
+	if got := r.String("jane@example.com"); got != "jane@example.com" {
+		t.Errorf("nil Redactor changed the text: %q", got)
+	}
+	if got := r.With(DefaultPatterns()...).String("jane@example.com"); got != "[email]" {
+		t.Errorf("With on nil = %q", got)
+	}
+}
+
+func TestNew_InvalidPattern(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("expected a panic for a pattern without a Regexp")
+		}
+	}()
+	New(Pattern{Name: "broken"})
+}
+
+type contact struct{ Email string }
+
+type stringer string
+
+func (s stringer) String() string { return string(s) }
+
+func TestHandler(t *testing.T) {
+	var buf bytes.Buffer
+	logger := Logger(slog.New(slog.NewJSONHandler(&buf, nil)), Default())
+
+	logger.With("user", "jane@example.com").WithGroup("req").Error("failed for jane@example.com",
+		"body", []byte(`{"phone":"(555) 123-4567"}`),
+		"err", errors.New("lookup 123-45-6789 failed"),
+		"who", stringer("call +62 812-3456-7890"),
+		"contact", contact{Email: "jane@example.com"},
+		"status", 500,
+		"headers", map[string]string{"accept": "application/json"},
+		slog.Group("form", "email", "jane@example.com"),
+	)
+
+	out := buf.String()
+	for _, s := range []string{"jane@example.com", "123-4567", "123-45-6789", "3456-7890"} {
+		if strings.Contains(out, s) {
+			t.Errorf("%q survived: %s", s, out)
+		}
+	}
+	var rec map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &rec); err != nil {
+		t.Fatalf("log line is not JSON: %q", out)
+	}
+	if rec["msg"] != "failed for [email]" || rec["user"] != "[email]" {
+		t.Errorf("unexpected record %v", rec)
+	}
+	req, _ := rec["req"].(map[string]any)
+	if req["status"] != float64(500) || req["body"] != `{"phone":"[phone]"}` {
+		t.Errorf("unexpected group %v", req)
+	}
+	// Values without personal data keep their encoding.
+	if _, ok := req["headers"].(map[string]any); !ok {
+		t.Errorf("headers = %#v, want a JSON object", req["headers"])
+	}
+}
+
+// description is a typical scraped job description with a recruiter's
+// contact details at the end.
+var description = strings.Repeat("We are looking for a Senior Backend Engineer with 5+ years of experience "+
+	"in Go, PostgreSQL and Kubernetes to join our platform team of 12 engineers. "+
+	"You will design APIs serving 10,000 requests per second, mentor engineers "+
+	"and own services end to end. Salary: Rp 25.000.000 - 35.000.000 per month. ", 8) +
+	"Questions? Contact recruiter Jane at jane.doe@example.com or +62 812-3456-7890."
+
+// BenchmarkString benchmarks redacting a job description with contact
+// details, as done for every scraped job.
+func BenchmarkString(b *testing.B) {
+	r := Default()
+	b.SetBytes(int64(len(description)))
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		r.String(description)
+	}
+}
+
+// BenchmarkString_Clean benchmarks a description without personal data.
+func BenchmarkString_Clean(b *testing.B) {
+	r := Default()
+	clean := strings.TrimSuffix(description, "Questions? Contact recruiter Jane at jane.doe@example.com or +62 812-3456-7890.")
+	b.SetBytes(int64(len(clean)))
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		r.String(clean)
+	}
+}
+
+// BenchmarkHandler benchmarks logging a request line through the handler.
+func BenchmarkHandler(b *testing.B) {
+	var buf bytes.Buffer
+	logger := Logger(slog.New(slog.NewJSONHandler(&buf, nil)), Default())
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		buf.Reset()
+		logger.Info("request", "method", "GET", "path", "/api/jobs/search", "status", 200,
+			"duration_ms", 12, "client_ip", "10.0.0.1", "user_agent", "Mozilla/5.0 (X11; Linux x86_64)")
+	}
+}
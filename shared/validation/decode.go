@@ -0,0 +1,84 @@
+package validation
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// AllowUnknownFieldsEnv is the environment variable read by ConfigFromEnv.
+const AllowUnknownFieldsEnv = "VALIDATION_ALLOW_UNKNOWN_FIELDS"
+
+// Config controls how request bodies are decoded.
+type Config struct {
+	// AllowUnknownFields accepts JSON fields the request type does not
+	// declare. By default they are rejected, so that misspelled fields do
+	// not go unnoticed; allow them while clients roll out a new field.
+	AllowUnknownFields bool
+}
+
+// ConfigFromEnv reads the Config from VALIDATION_ALLOW_UNKNOWN_FIELDS
+// ("true" allows unknown fields).
+func ConfigFromEnv() Config {
+	allow, _ := strconv.ParseBool(os.Getenv(AllowUnknownFieldsEnv))
+	return Config{AllowUnknownFields: allow}
+}
+
+// Decode decodes the JSON body r into v and validates v's struct tags.
+//
+// Unknown fields, values of the wrong type and failed rules are returned
+// as Errors, answered with 422. Any other error means the body is not
+// JSON at all, answered with 400.
+func (c Config) Decode(r io.Reader, v interface{}) error {
+	dec := json.NewDecoder(r)
+	if !c.AllowUnknownFields {
+		dec.DisallowUnknownFields()
+	}
+	if err := dec.Decode(v); err != nil {
+		var typeErr *json.UnmarshalTypeError
+		switch {
+		case errors.As(err, &typeErr):
+			name := typeErr.Field
+			if name == "" {
+				name = "body"
+			}
+			return Errors{{Field: name, Code: CodeType, Message: "must be " + article(typeErr.Type.Kind().String())}}
+		case strings.HasPrefix(err.Error(), "json: unknown field "):
+			name, _ := strconv.Unquote(strings.TrimPrefix(err.Error(), "json: unknown field "))
+			return Errors{{Field: name, Code: CodeUnknownField, Message: "unknown field"}}
+		}
+		return err
+	}
+	if errs := Struct(v); errs != nil {
+		return errs
+	}
+	return nil
+}
+
+// AsErrors returns the field errors in err, if it holds any.
+func AsErrors(err error) (Errors, bool) {
+	var errs Errors
+	if errors.As(err, &errs) {
+		return errs, true
+	}
+	return nil, false
+}
+
+// article names a JSON value of Go kind for messages.
+func article(kind string) string {
+	switch kind {
+	case "string":
+		return "a string"
+	case "bool":
+		return "a boolean"
+	case "slice", "array":
+		return "an array"
+	case "struct", "map":
+		return "an object"
+	default:
+		return "a number"
+	}
+}
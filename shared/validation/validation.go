@@ -0,0 +1,469 @@
+// Package validation checks decoded request bodies and reports every failing
+// field at once, so that clients can show the error next to the field. The
+// services answer failed validation with 422 Unprocessable Entity and an
+// errors array:
+//
+//	{"errors": [{"field": "email", "code": "email", "message": "must be a valid email address"}]}
+//
+// Rules are declared in `validate` struct tags or added programmatically
+// with a Validator:
+//
+//	type RegisterRequest struct {
+//		Email    string `json:"email" validate:"required,email"`
+//		Password string `json:"password" validate:"required,min=8,max=72"`
+//		Level    string `json:"level,omitempty" validate:"omitempty,oneof=beginner advanced"`
+//	}
+//
+// The tag rules are:
+//
+//	required      non-empty string (ignoring spaces), non-nil pointer, non-empty slice or map, non-zero value
+//	omitempty     skip the remaining rules when the value is empty
+//	email         a plausible email address
+//	url           an absolute http(s) URL
+//	uuid          a UUID such as 7d9e4f2a-1c3b-4e5d-8f6a-0b1c2d3e4f5a
+//	date          a YYYY-MM-DD date
+//	min=N, max=N  length of strings (in characters) and slices, value of numbers
+//	oneof=a b c   one of the listed values; on a []string, every element
+//
+// Fields are named by their JSON names. Nested structs and slices of structs
+// are validated too, with names such as "job.title" and "skills[2].name".
+package validation
+
+import (
+	"fmt"
+	"net/url"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+)
+
+// Error codes reported in FieldError.Code.
+const (
+	CodeRequired     = "required"
+	CodeEmail        = "email"
+	CodeURL          = "url"
+	CodeUUID         = "uuid"
+	CodeDate         = "date"
+	CodeMinLength    = "min_length"
+	CodeMaxLength    = "max_length"
+	CodeMin          = "min"
+	CodeMax          = "max"
+	CodeMinItems     = "min_items"
+	CodeMaxItems     = "max_items"
+	CodeOneOf        = "one_of"
+	CodeType         = "type"
+	CodeUnknownField = "unknown_field"
+	CodeInvalid      = "invalid"
+)
+
+// FieldError is a validation failure of one field.
+type FieldError struct {
+	// Field is the JSON path of the field, e.g. "skills[0].name".
+	Field string `json:"field"`
+
+	// Code identifies the failed rule, e.g. "required" or "one_of".
+	Code string `json:"code"`
+
+	// Message describes the failure for humans.
+	Message string `json:"message"`
+}
+
+// Errors lists the failures of a request. It is returned as an error by
+// Decode and Validator.Err.
+type Errors []FieldError
+
+// Error implements error.
+func (e Errors) Error() string {
+	parts := make([]string, len(e))
+	for i, fe := range e {
+		parts[i] = fe.Field + ": " + fe.Message
+	}
+	return strings.Join(parts, "; ")
+}
+
+// Struct validates v, a struct or pointer to one, against its `validate`
+// tags. It returns nil if every rule holds. It panics on a malformed tag.
+func Struct(v interface{}) Errors {
+	var errs Errors
+	validateValue(reflect.ValueOf(v), "", &errs)
+	return errs
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Programmatic rules
+// ─────────────────────────────────────────────────────────────────────────────
+
+// Validator collects field errors from programmatic rules, for checks that
+// struct tags cannot express. The zero value is ready to use.
+type Validator struct {
+	errs Errors
+}
+
+// Add records a failure of field.
+func (v *Validator) Add(field, code, message string) {
+	v.errs = append(v.errs, FieldError{Field: field, Code: code, Message: message})
+}
+
+// Struct adds the failures of the struct tag rules of s.
+func (v *Validator) Struct(s interface{}) {
+	v.errs = append(v.errs, Struct(s)...)
+}
+
+// Required checks that value is not empty or only spaces.
+func (v *Validator) Required(field, value string) {
+	if strings.TrimSpace(value) == "" {
+		v.Add(field, CodeRequired, field+" is required")
+	}
+}
+
+// Email checks that value is an email address.
+func (v *Validator) Email(field, value string) {
+	if !IsEmail(value) {
+		v.Add(field, CodeEmail, "must be a valid email address")
+	}
+}
+
+// MinLength checks that value has at least n characters.
+func (v *Validator) MinLength(field, value string, n int) {
+	if utf8.RuneCountInString(value) < n {
+		v.Add(field, CodeMinLength, fmt.Sprintf("must be at least %d characters", n))
+	}
+}
+
+// MaxLength checks that value has at most n characters.
+func (v *Validator) MaxLength(field, value string, n int) {
+	if utf8.RuneCountInString(value) > n {
+		v.Add(field, CodeMaxLength, fmt.Sprintf("must be at most %d characters", n))
+	}
+}
+
+// OneOf checks that value is one of allowed. An empty value passes; combine
+// with Required for mandatory fields.
+func (v *Validator) OneOf(field, value string, allowed ...string) {
+	if value == "" {
+		return
+	}
+	for _, a := range allowed {
+		if value == a {
+			return
+		}
+	}
+	v.Add(field, CodeOneOf, "must be one of: "+strings.Join(allowed, ", "))
+}
+
+// Range checks that min <= value <= max.
+func (v *Validator) Range(field string, value, min, max float64) {
+	switch {
+	case value < min:
+		v.Add(field, CodeMin, "must be at least "+formatNumber(min))
+	case value > max:
+		v.Add(field, CodeMax, "must be at most "+formatNumber(max))
+	}
+}
+
+// Errors returns the collected failures.
+func (v *Validator) Errors() Errors {
+	return v.errs
+}
+
+// Err returns the collected failures as an error, or nil if there are none.
+func (v *Validator) Err() error {
+	if len(v.errs) == 0 {
+		return nil
+	}
+	return v.errs
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Formats
+// ─────────────────────────────────────────────────────────────────────────────
+
+var (
+	emailRe = regexp.MustCompile(`^[^@\s]+@[^@\s]+\.[^@\s]+$`)
+	uuidRe  = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+)
+
+// IsEmail reports whether s looks like an email address.
+func IsEmail(s string) bool {
+	return len(s) <= 254 && emailRe.MatchString(s)
+}
+
+// IsURL reports whether s is an absolute http(s) URL.
+func IsURL(s string) bool {
+	u, err := url.Parse(s)
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
+
+// IsDate reports whether s is a YYYY-MM-DD date.
+func IsDate(s string) bool {
+	_, err := time.Parse("2006-01-02", s)
+	return err == nil
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Struct tags
+// ─────────────────────────────────────────────────────────────────────────────
+
+// rule is one parsed rule of a `validate` tag.
+type rule struct {
+	name  string
+	arg   float64
+	words []string // for oneof
+}
+
+// field is a struct field with its JSON name and rules.
+type field struct {
+	index int
+	name  string
+	rules []rule
+}
+
+// fieldCache holds the parsed fields of each struct type.
+var fieldCache sync.Map // reflect.Type → []field
+
+// fieldsOf returns the exported fields of struct type t.
+func fieldsOf(t reflect.Type) []field {
+	if cached, ok := fieldCache.Load(t); ok {
+		return cached.([]field)
+	}
+	var fields []field
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+		name, _, _ := strings.Cut(sf.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if name == "" && !sf.Anonymous {
+			name = sf.Name
+		}
+		fields = append(fields, field{index: i, name: name, rules: parseRules(t, sf)})
+	}
+	fieldCache.Store(t, fields)
+	return fields
+}
+
+// parseRules parses the `validate` tag of sf.
+func parseRules(t reflect.Type, sf reflect.StructField) []rule {
+	tag := sf.Tag.Get("validate")
+	if tag == "" {
+		return nil
+	}
+	var rules []rule
+	for _, part := range strings.Split(tag, ",") {
+		name, arg, hasArg := strings.Cut(strings.TrimSpace(part), "=")
+		r := rule{name: name}
+		switch name {
+		case "required", "omitempty", "email", "url", "uuid", "date":
+		case "min", "max":
+			n, err := strconv.ParseFloat(arg, 64)
+			if !hasArg || err != nil {
+				panic(fmt.Sprintf("validation: %s.%s: invalid %s rule %q", t, sf.Name, name, part))
+			}
+			r.arg = n
+		case "oneof":
+			r.words = strings.Fields(arg)
+			if len(r.words) == 0 {
+				panic(fmt.Sprintf("validation: %s.%s: empty oneof rule", t, sf.Name))
+			}
+		default:
+			panic(fmt.Sprintf("validation: %s.%s: unknown rule %q", t, sf.Name, name))
+		}
+		rules = append(rules, r)
+	}
+	return rules
+}
+
+// validateValue validates v, named path, and the values nested in it.
+func validateValue(v reflect.Value, path string, errs *Errors) {
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return
+		}
+		v = v.Elem()
+	}
+	switch v.Kind() {
+	case reflect.Struct:
+		for _, f := range fieldsOf(v.Type()) {
+			fv := v.Field(f.index)
+			name := joinPath(path, f.name)
+			if !applyRules(fv, name, f.rules, errs) {
+				continue
+			}
+			validateValue(fv, name, errs)
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			validateValue(v.Index(i), fmt.Sprintf("%s[%d]", path, i), errs)
+		}
+	}
+}
+
+// joinPath appends a field name to a path.
+func joinPath(path, name string) string {
+	switch {
+	case name == "":
+		return path // embedded struct without a JSON name
+	case path == "":
+		return name
+	default:
+		return path + "." + name
+	}
+}
+
+// applyRules checks v against rules and reports whether nested values are
+// to be validated, which they are not for missing values.
+func applyRules(v reflect.Value, name string, rules []rule, errs *Errors) bool {
+	empty := isEmpty(v)
+	for _, r := range rules {
+		switch r.name {
+		case "required":
+			if empty {
+				*errs = append(*errs, FieldError{Field: name, Code: CodeRequired, Message: name + " is required"})
+				return false
+			}
+		case "omitempty":
+			if empty {
+				return false
+			}
+		default:
+			if fe, ok := check(indirect(v), name, r); !ok {
+				*errs = append(*errs, fe...)
+			}
+		}
+	}
+	return !empty
+}
+
+// indirect dereferences non-nil pointers.
+func indirect(v reflect.Value) reflect.Value {
+	for v.Kind() == reflect.Pointer && !v.IsNil() {
+		v = v.Elem()
+	}
+	return v
+}
+
+// isEmpty reports whether v is missing for the required rule.
+func isEmpty(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.String:
+		return strings.TrimSpace(v.String()) == ""
+	case reflect.Pointer, reflect.Interface:
+		return v.IsNil()
+	case reflect.Slice, reflect.Map:
+		return v.Len() == 0
+	default:
+		return v.IsZero()
+	}
+}
+
+// check applies a format, bound or oneof rule to v.
+func check(v reflect.Value, name string, r rule) ([]FieldError, bool) {
+	fail := func(code, msg string) ([]FieldError, bool) {
+		return []FieldError{{Field: name, Code: code, Message: msg}}, false
+	}
+	switch v.Kind() {
+	case reflect.String:
+		s := v.String()
+		switch r.name {
+		case "email":
+			if !IsEmail(s) {
+				return fail(CodeEmail, "must be a valid email address")
+			}
+		case "url":
+			if !IsURL(s) {
+				return fail(CodeURL, "must be an absolute http(s) URL")
+			}
+		case "uuid":
+			if !uuidRe.MatchString(s) {
+				return fail(CodeUUID, "must be a valid UUID")
+			}
+		case "date":
+			if !IsDate(s) {
+				return fail(CodeDate, "must be a YYYY-MM-DD date")
+			}
+		case "min":
+			if float64(utf8.RuneCountInString(s)) < r.arg {
+				return fail(CodeMinLength, "must be at least "+formatNumber(r.arg)+" characters")
+			}
+		case "max":
+			if float64(utf8.RuneCountInString(s)) > r.arg {
+				return fail(CodeMaxLength, "must be at most "+formatNumber(r.arg)+" characters")
+			}
+		case "oneof":
+			if !contains(r.words, s) {
+				return fail(CodeOneOf, "must be one of: "+strings.Join(r.words, ", "))
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		switch r.name {
+		case "min":
+			if float64(v.Len()) < r.arg {
+				return fail(CodeMinItems, "must have at least "+formatNumber(r.arg)+" items")
+			}
+		case "max":
+			if float64(v.Len()) > r.arg {
+				return fail(CodeMaxItems, "must have at most "+formatNumber(r.arg)+" items")
+			}
+		case "oneof":
+			var errs []FieldError
+			for i := 0; i < v.Len(); i++ {
+				if e := indirect(v.Index(i)); e.Kind() == reflect.String && !contains(r.words, e.String()) {
+					errs = append(errs, FieldError{
+						Field:   fmt.Sprintf("%s[%d]", name, i),
+						Code:    CodeOneOf,
+						Message: "must be one of: " + strings.Join(r.words, ", "),
+					})
+				}
+			}
+			return errs, len(errs) == 0
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		n := toFloat(v)
+		switch r.name {
+		case "min":
+			if n < r.arg {
+				return fail(CodeMin, "must be at least "+formatNumber(r.arg))
+			}
+		case "max":
+			if n > r.arg {
+				return fail(CodeMax, "must be at most "+formatNumber(r.arg))
+			}
+		}
+	}
+	return nil, true
+}
+
+// toFloat returns the numeric value of v.
+func toFloat(v reflect.Value) float64 {
+	switch {
+	case v.CanInt():
+		return float64(v.Int())
+	case v.CanUint():
+		return float64(v.Uint())
+	default:
+		return v.Float()
+	}
+}
+
+// formatNumber formats n without trailing zeros.
+func formatNumber(n float64) string {
+	return strconv.FormatFloat(n, 'f', -1, 64)
+}
+
+func contains(words []string, s string) bool {
+	for _, w := range words {
+		if w == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,149 @@
+package validation
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type testSkill struct {
+	Name  string `json:"name" validate:"required,max=20"`
+	Level string `json:"level,omitempty" validate:"omitempty,oneof=beginner advanced"`
+}
+
+type testRequest struct {
+	Email    string      `json:"email" validate:"required,email"`
+	Password string      `json:"password" validate:"required,min=8"`
+	Website  string      `json:"website,omitempty" validate:"omitempty,url"`
+	Budget   *float64    `json:"budget,omitempty" validate:"omitempty,min=0"`
+	Hours    int         `json:"hours" validate:"max=80"`
+	Types    []string    `json:"types,omitempty" validate:"oneof=course video"`
+	Skills   []testSkill `json:"skills" validate:"max=2"`
+	Lead     *testSkill  `json:"lead,omitempty"`
+	Ignored  string      `json:"-" validate:"required"`
+}
+
+// fields returns "field:code" for each error.
+func fields(errs Errors) []string {
+	var out []string
+	for _, e := range errs {
+		out = append(out, e.Field+":"+e.Code)
+	}
+	return out
+}
+
+func TestStruct(t *testing.T) {
+	valid := func() testRequest {
+		return testRequest{Email: "jane@example.com", Password: "correct-horse", Hours: 10}
+	}
+	negative := -5.0
+	tests := []struct {
+		name   string
+		modify func(*testRequest)
+		want   []string
+	}{
+		{"valid", func(r *testRequest) {}, nil},
+		{"missing fields", func(r *testRequest) { r.Email, r.Password = "", "  " },
+			[]string{"email:required", "password:required"}},
+		{"bad email", func(r *testRequest) { r.Email = "jane" }, []string{"email:email"}},
+		{"short password", func(r *testRequest) { r.Password = "short" }, []string{"password:min_length"}},
+		{"relative url", func(r *testRequest) { r.Website = "/about" }, []string{"website:url"}},
+		{"negative budget", func(r *testRequest) { r.Budget = &negative }, []string{"budget:min"}},
+		{"too many hours", func(r *testRequest) { r.Hours = 81 }, []string{"hours:max"}},
+		{"bad enum element", func(r *testRequest) { r.Types = []string{"course", "podcast"} }, []string{"types[1]:one_of"}},
+		{"nested skill", func(r *testRequest) {
+			r.Skills = []testSkill{{Name: "Go"}, {Name: "", Level: "expert"}}
+		}, []string{"skills[1].name:required", "skills[1].level:one_of"}},
+		{"too many skills", func(r *testRequest) {
+			r.Skills = []testSkill{{Name: "a"}, {Name: "b"}, {Name: "c"}}
+		}, []string{"skills:max_items"}},
+		{"nested pointer", func(r *testRequest) { r.Lead = &testSkill{Name: strings.Repeat("x", 21)} },
+			[]string{"lead.name:max_length"}},
+	}
+	for _, tt := range tests {
+		req := valid()
+		tt.modify(&req)
+		if got := fields(Struct(&req)); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: got %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestStruct_PanicsOnBadTag(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("expected a panic for an unknown rule")
+		}
+	}()
+	Struct(struct {
+		Name string `json:"name" validate:"requird"`
+	}{})
+}
+
+func TestValidator(t *testing.T) {
+	var v Validator
+	v.Required("name", "")
+	v.Email("email", "not-an-email")
+	v.MinLength("password", "abc", 8)
+	v.MaxLength("bio", "abcdef", 5)
+	v.OneOf("difficulty", "expert", "beginner", "advanced")
+	v.OneOf("cost_type", "", "free", "paid")
+	v.Range("hours", 200, 0, 168)
+	v.Struct(testRequest{Email: "jane@example.com", Password: "correct-horse", Hours: 100})
+
+	want := []string{"name:required", "email:email", "password:min_length", "bio:max_length",
+		"difficulty:one_of", "hours:max", "hours:max"}
+	if got := fields(v.Errors()); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+	if v.Err() == nil || !strings.Contains(v.Err().Error(), "difficulty: must be one of: beginner, advanced") {
+		t.Errorf("Err() = %v", v.Err())
+	}
+	if (&Validator{}).Err() != nil {
+		t.Error("empty validator reported an error")
+	}
+}
+
+func TestDecode(t *testing.T) {
+	tests := []struct {
+		name   string
+		cfg    Config
+		body   string
+		want   []string
+		syntax bool
+	}{
+		{"valid", Config{}, `{"email":"jane@example.com","password":"correct-horse"}`, nil, false},
+		{"unknown field", Config{}, `{"email":"jane@example.com","password":"correct-horse","pasword":"x"}`,
+			[]string{"pasword:unknown_field"}, false},
+		{"unknown field allowed", Config{AllowUnknownFields: true},
+			`{"email":"jane@example.com","password":"correct-horse","pasword":"x"}`, nil, false},
+		{"wrong type", Config{}, `{"email":"jane@example.com","password":"correct-horse","hours":"ten"}`,
+			[]string{"hours:type"}, false},
+		{"failed rules", Config{}, `{"email":"jane"}`, []string{"email:email", "password:required"}, false},
+		{"not json", Config{}, `{"email":`, nil, true},
+	}
+	for _, tt := range tests {
+		var req testRequest
+		err := tt.cfg.Decode(strings.NewReader(tt.body), &req)
+		errs, ok := AsErrors(err)
+		switch {
+		case tt.syntax && (err == nil || ok):
+			t.Errorf("%s: got %v, want a syntax error", tt.name, err)
+		case !tt.syntax && err != nil && !ok:
+			t.Errorf("%s: unexpected error %v", tt.name, err)
+		case !tt.syntax && !reflect.DeepEqual(fields(errs), tt.want):
+			t.Errorf("%s: got %v, want %v", tt.name, fields(errs), tt.want)
+		}
+	}
+}
+
+func TestConfigFromEnv(t *testing.T) {
+	t.Setenv(AllowUnknownFieldsEnv, "true")
+	if !ConfigFromEnv().AllowUnknownFields {
+		t.Error("expected unknown fields to be allowed")
+	}
+	t.Setenv(AllowUnknownFieldsEnv, "")
+	if ConfigFromEnv().AllowUnknownFields {
+		t.Error("expected unknown fields to be rejected by default")
+	}
+}